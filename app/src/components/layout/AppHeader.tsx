@@ -5,6 +5,10 @@ import { ThemeToggle } from '@/components/common/ThemeToggle'
 import { LanguageSelector } from '@/components/common/LanguageSelector'
 import { ContextBreadcrumb } from '@/components/common/ContextBreadcrumb'
 import { Button } from '@/components/ui/button'
+import { runtimeConfig } from '@/lib/runtime-config'
+
+const branding = runtimeConfig?.branding
+
 interface AppHeaderProps {
   variant?: 'minimal' | 'full'
   className?: string
@@ -48,20 +52,29 @@ export function AppHeader({
           layoutId="app-logo"
           className="flex items-center gap-3"
         >
-          <motion.div
-            layoutId="app-logo-icon"
-            className="flex h-8 w-8 items-center justify-center border-2 border-foreground"
-          >
-            <FileText
-              size={16}
-              className="text-foreground"
+          {branding?.logoUrl ? (
+            <motion.img
+              layoutId="app-logo-icon"
+              src={branding.logoUrl}
+              alt=""
+              className="h-8 w-8 object-contain"
             />
-          </motion.div>
+          ) : (
+            <motion.div
+              layoutId="app-logo-icon"
+              className="flex h-8 w-8 items-center justify-center border-2 border-foreground"
+            >
+              <FileText
+                size={16}
+                className="text-foreground"
+              />
+            </motion.div>
+          )}
           <motion.span
             layoutId="app-logo-text"
             className="font-display text-lg font-bold uppercase tracking-tight text-foreground"
           >
-            PDF Forge
+            {branding?.title || 'PDF Forge'}
           </motion.span>
         </motion.div>
 
@@ -1,10 +1,28 @@
 import apiClient from '@/lib/api-client'
 import type { RoleEntry } from '@/stores/auth-store'
+import type { TenantWithRole } from '@/features/tenants/types'
+import type { WorkspaceWithRole } from '@/features/workspaces/types'
 
 interface MyRolesResponse {
   roles: RoleEntry[]
 }
 
+/**
+ * Current user profile from GET /me
+ */
+export interface MeResponse {
+  user: {
+    id: string
+    email: string
+    fullName: string
+    status: string
+  }
+  systemRole: string | null
+  tenants: TenantWithRole[]
+  workspaces: WorkspaceWithRole[]
+  features: Record<string, boolean>
+}
+
 /**
  * Get current user's roles
  */
@@ -13,6 +31,31 @@ export async function fetchMyRoles(): Promise<RoleEntry[]> {
   return response.data.roles
 }
 
+/**
+ * Get current user's profile: system role, memberships and feature flags in one call
+ */
+export async function fetchMe(): Promise<MeResponse> {
+  const response = await apiClient.get<MeResponse>('/me')
+  return response.data
+}
+
+/**
+ * Flatten the memberships of /me into role entries, one per tenant and workspace
+ */
+export function rolesFromMe(me: MeResponse): RoleEntry[] {
+  const roles: RoleEntry[] = []
+  if (me.systemRole) {
+    roles.push({ type: 'SYSTEM', role: me.systemRole, resourceId: null })
+  }
+  for (const tenant of me.tenants) {
+    roles.push({ type: 'TENANT', role: tenant.role, resourceId: tenant.id })
+  }
+  for (const workspace of me.workspaces) {
+    roles.push({ type: 'WORKSPACE', role: workspace.role, resourceId: workspace.id })
+  }
+  return roles
+}
+
 /**
  * Record resource access (for analytics/audit)
  */
@@ -2,7 +2,7 @@ import { useEffect, useRef, type ReactNode } from 'react'
 import { useAuthStore } from '@/stores/auth-store'
 import { refreshAccessToken, getUserInfo, setupTokenRefresh, initOIDCConfig } from '@/lib/oidc'
 import { getAuthConfig, getOIDCConfig } from '@/lib/auth-config'
-import { fetchMyRoles } from '@/features/auth/api/auth-api'
+import { fetchMe, rolesFromMe } from '@/features/auth/api/auth-api'
 import { initializeTheme } from '@/stores/theme-store'
 import { LoadingOverlay } from '@/components/common/LoadingSpinner'
 
@@ -28,7 +28,7 @@ export function AuthProvider({ children }: AuthProviderProps) {
     const cleanupTheme = initializeTheme()
 
     // Use getState() for all actions — stable references, no subscription
-    const { setAuthLoading, setTokens, setUserProfile, setAllRoles, clearAuth } = useAuthStore.getState()
+    const { setAuthLoading, setTokens, setUserProfile, setAllRoles, setFeatures, clearAuth } = useAuthStore.getState()
 
     // Skip if already started (prevents StrictMode double-call)
     if (initRef.current.started) {
@@ -68,8 +68,9 @@ export function AuthProvider({ children }: AuthProviderProps) {
             lastName: 'Admin',
             username: 'admin',
           })
-          const roles = await fetchMyRoles()
-          setAllRoles(roles)
+          const me = await fetchMe()
+          setAllRoles(rolesFromMe(me))
+          setFeatures(me.features)
           // No token refresh needed in dummyAuth mode
           console.log('[Auth] Init complete: dummyAuth ok')
           return
@@ -103,8 +104,9 @@ export function AuthProvider({ children }: AuthProviderProps) {
               username: userInfo.preferred_username,
             })
 
-            const roles = await fetchMyRoles()
-            setAllRoles(roles)
+            const me = await fetchMe()
+            setAllRoles(rolesFromMe(me))
+            setFeatures(me.features)
 
             console.log('[Auth] User info and roles loaded')
           } catch (error) {
@@ -6,7 +6,8 @@ export { PermissionGuard } from './components/PermissionGuard'
 export { usePermission, useCanAccessAdmin } from './hooks/usePermission'
 
 // API
-export { fetchMyRoles, recordAccess } from './api/auth-api'
+export { fetchMe, fetchMyRoles, recordAccess, rolesFromMe } from './api/auth-api'
+export type { MeResponse } from './api/auth-api'
 
 // Types and Rules
 export * from './types'
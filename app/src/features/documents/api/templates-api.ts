@@ -25,12 +25,13 @@ export async function fetchTemplatesByFolder(
 
 export interface MoveTemplateRequest {
   folderId: string | null
+  revision: number
 }
 
 /**
  * Move template to a different folder
  * @param templateId - Template ID to move
- * @param data - Contains target folderId (null for root)
+ * @param data - Contains target folderId (null for root) and the revision the move is based on
  */
 export async function moveTemplate(
   templateId: string,
@@ -38,7 +39,7 @@ export async function moveTemplate(
 ): Promise<TemplateListItem> {
   const response = await apiClient.put<TemplateListItem>(
     `/content/templates/${templateId}`,
-    { folderId: data.folderId ?? 'root' }
+    { folderId: data.folderId ?? 'root', revision: data.revision }
   )
   return response.data
 }
@@ -330,6 +330,7 @@ function DocumentsPageContent() {
       await moveTemplate.mutateAsync({
         templateId: templateToMove.id,
         folderId: targetFolder.id,
+        revision: templateToMove.revision,
       })
       setMoveTemplateDialogOpen(false)
       setTemplateToMove(null)
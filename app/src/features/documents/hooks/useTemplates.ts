@@ -31,10 +31,12 @@ export function useMoveTemplate() {
     mutationFn: ({
       templateId,
       folderId,
+      revision,
     }: {
       templateId: string
       folderId: string | null
-    }) => moveTemplate(templateId, { folderId }),
+      revision: number
+    }) => moveTemplate(templateId, { folderId, revision }),
     onSuccess: () => {
       queryClient.invalidateQueries({ queryKey: templateKeys.all })
     },
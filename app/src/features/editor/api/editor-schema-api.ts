@@ -0,0 +1,12 @@
+import { apiClient } from '@/lib/api-client'
+import type { EditorSchema } from '../types/editor-schema'
+
+export const editorSchemaApi = {
+  /**
+   * Fetch the nodes, marks and attrs the server converter renders
+   */
+  get: async (): Promise<EditorSchema> => {
+    const response = await apiClient.get<EditorSchema>('/editor/schema')
+    return response.data
+  },
+}
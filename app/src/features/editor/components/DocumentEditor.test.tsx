@@ -36,6 +36,10 @@ vi.mock('react-i18next', () => ({
   useTranslation: () => ({ t: (key: string) => key }),
 }))
 
+vi.mock('../api/editor-schema-api', () => ({
+  editorSchemaApi: { get: vi.fn(() => new Promise(() => {})) },
+}))
+
 vi.mock('@tiptap/react', () => ({
   useEditor: () => bodyEditor,
   EditorContent: () => <div data-testid="body-editor-content" />,
@@ -160,6 +164,26 @@ vi.mock('../extensions/PageBreak', () => ({
   PageBreakHR: {},
 }))
 
+vi.mock('../extensions/SecurityPattern', () => ({
+  SecurityPatternExtension: {},
+}))
+
+vi.mock('../extensions/PageNumber', () => ({
+  PageNumberExtension: {},
+}))
+
+vi.mock('../extensions/ExternalPdf', () => ({
+  ExternalPdfExtension: {},
+}))
+
+vi.mock('../extensions/References', () => ({
+  ReferencesExtension: {},
+}))
+
+vi.mock('../extensions/Repeater', () => ({
+  RepeaterExtension: {},
+}))
+
 vi.mock('../extensions/SlashCommands', () => ({
   SlashCommandsExtension: { configure: () => ({}) },
   slashCommandsSuggestion: {},
@@ -188,6 +212,14 @@ vi.mock('../extensions/LineSpacing', () => ({
   LineSpacingExtension: {},
 }))
 
+vi.mock('../extensions/PaginationHints', () => ({
+  PaginationHintsExtension: {},
+}))
+
+vi.mock('../extensions/NodeVisibility', () => ({
+  NodeVisibilityExtension: {},
+}))
+
 vi.mock('./DocumentPageHeader', async () => {
   const React = await import('react')
 
@@ -28,6 +28,11 @@ import { ConditionalExtension } from '../extensions/Conditional'
 import { MentionExtension } from '../extensions/Mentions'
 import { ImageExtension, type ImageShape } from '../extensions/Image'
 import { PageBreakHR } from '../extensions/PageBreak'
+import { SecurityPatternExtension } from '../extensions/SecurityPattern'
+import { PageNumberExtension } from '../extensions/PageNumber'
+import { ExternalPdfExtension } from '../extensions/ExternalPdf'
+import { ReferencesExtension } from '../extensions/References'
+import { RepeaterExtension } from '../extensions/Repeater'
 import { SlashCommandsExtension, slashCommandsSuggestion } from '../extensions/SlashCommands'
 import {
   TableExtension,
@@ -39,6 +44,8 @@ import { TableInjectorExtension } from '../extensions/TableInjector'
 import { ListInjectorExtension } from '../extensions/ListInjector'
 import { StoredMarksPersistenceExtension } from '../extensions/StoredMarksPersistence'
 import { LineSpacingExtension } from '../extensions/LineSpacing'
+import { PaginationHintsExtension } from '../extensions/PaginationHints'
+import { NodeVisibilityExtension } from '../extensions/NodeVisibility'
 import { ImageInsertModal, type ImageInsertResult } from './ImageInsertModal'
 import { VariableFormatDialog } from './VariableFormatDialog'
 import { VariablesPanel } from './VariablesPanel'
@@ -52,6 +59,7 @@ import { cn } from '@/lib/utils'
 import { type Variable } from '../types'
 import type { SurfaceKind } from '../types/document-surface'
 import { useDocumentHeaderStore, useDocumentFooterStore, usePaginationStore } from '../stores'
+import { useEditorSchemaStore } from '../stores/editor-schema-store'
 import type { Editor } from '@tiptap/core'
 import { useVariableInsertion } from '../hooks/useVariableInsertion'
 import { resolveActiveSurface, type ActiveSurface } from '../services/variable-insertion'
@@ -185,12 +193,19 @@ export function DocumentEditor({
       FontSize.configure({ types: ['textStyle'] }),
       StoredMarksPersistenceExtension,
       LineSpacingExtension,
+      PaginationHintsExtension,
+      NodeVisibilityExtension,
       TextAlign.configure({ types: ['heading', 'paragraph', 'tableCell', 'tableHeader'] }),
       InjectorExtension,
       MentionExtension,
       ConditionalExtension,
       ImageExtension,
       PageBreakHR,
+      SecurityPatternExtension,
+      PageNumberExtension,
+      ExternalPdfExtension,
+      ReferencesExtension,
+      RepeaterExtension,
       SlashCommandsExtension.configure({
         suggestion: slashCommandsSuggestion,
       }),
@@ -221,6 +236,11 @@ export function DocumentEditor({
     },
   }, [editorKey]) // Recreate editor when editorKey changes
 
+  // Load the features the server renders, so unsupported nodes are hidden from the slash menu
+  useEffect(() => {
+    void useEditorSchemaStore.getState().loadSchema()
+  }, [])
+
   // Store editor reference for export/import
   useEffect(() => {
     if (editor && editorRef) {
@@ -6,6 +6,11 @@ export type NodeContextType =
   | 'injector'
   | 'conditional'
   | 'pageBreak'
+  | 'securityPattern'
+  | 'pageNumber'
+  | 'externalPdf'
+  | 'references'
+  | 'repeater'
 
 interface EditorNodeContextMenuProps {
   x: number
@@ -39,6 +44,11 @@ export const EditorNodeContextMenu = ({
     injector: t('editor.context_menu.node_types.variable'),
     conditional: t('editor.context_menu.node_types.conditional'),
     pageBreak: t('editor.context_menu.node_types.page_break'),
+    securityPattern: t('editor.context_menu.node_types.security_pattern'),
+    pageNumber: t('editor.context_menu.node_types.page_number'),
+    externalPdf: t('editor.context_menu.node_types.external_pdf'),
+    references: t('editor.context_menu.node_types.references'),
+    repeater: t('editor.context_menu.node_types.repeater'),
   }[nodeType]
 
   useEffect(() => {
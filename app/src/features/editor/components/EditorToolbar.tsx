@@ -49,6 +49,8 @@ import { TextColorPicker } from './TextColorPicker'
 import { FontFamilyPicker } from './FontFamilyPicker'
 import { FontSizePicker } from './FontSizePicker'
 import { LineSpacingPicker } from './LineSpacingPicker'
+import { PaginationHintsPicker } from './PaginationHintsPicker'
+import { VisibilityPicker } from './VisibilityPicker'
 import { useOverflowScroll } from '@/hooks/use-overflow-scroll'
 import { getEffectiveMarkAttrs } from '../utils/mark-attributes'
 import { cn } from '@/lib/utils'
@@ -196,6 +198,12 @@ export function EditorToolbar({
           {/* Line Spacing */}
           <LineSpacingPicker editor={editor} />
 
+          {/* Pagination hints (body only; header and footer do not paginate) */}
+          {!isConstrainedSurface && <PaginationHintsPicker editor={editor} />}
+
+          {/* Output format visibility */}
+          <VisibilityPicker editor={editor} />
+
           <Separator orientation="vertical" className="h-6 mx-1" />
 
           {/* Headings */}
@@ -25,19 +25,23 @@ interface InjectorConfigDialogProps {
     showLabelIfEmpty?: boolean
     defaultValue?: string | null
     format?: string | null
+    timeZone?: string | null
+    dateOffset?: string | null
   }
   onApply: (config: {
     prefix?: string | null
     suffix?: string | null
     showLabelIfEmpty?: boolean
     defaultValue?: string | null
+    timeZone?: string | null
+    dateOffset?: string | null
   }) => void
 }
 
 export function InjectorConfigDialog({
   open,
   onOpenChange,
-  injectorType: _injectorType,
+  injectorType,
   variableId,
   variableLabel,
   currentConfig,
@@ -54,6 +58,10 @@ export function InjectorConfigDialog({
   const [defaultValue, setDefaultValue] = useState(
     currentConfig.defaultValue || ''
   )
+  const [timeZone, setTimeZone] = useState(currentConfig.timeZone || '')
+  const [dateOffset, setDateOffset] = useState(currentConfig.dateOffset || '')
+  const isDate = injectorType === 'DATE'
+  const dateOffsetValid = !dateOffset || /^[+-]?\d+(d|wd|w|m|y)$/i.test(dateOffset.trim())
 
   // Reset state when dialog opens
   useEffect(() => {
@@ -62,13 +70,15 @@ export function InjectorConfigDialog({
       setSuffix(currentConfig.suffix || '')
       setShowLabelIfEmpty(currentConfig.showLabelIfEmpty || false)
       setDefaultValue(currentConfig.defaultValue || '')
+      setTimeZone(currentConfig.timeZone || '')
+      setDateOffset(currentConfig.dateOffset || '')
       setIsSubmitting(false)
     }
   }, [open, currentConfig])
 
   const handleSubmit = (e: React.FormEvent) => {
     e.preventDefault()
-    if (isSubmitting) return
+    if (isSubmitting || !dateOffsetValid) return
 
     setIsSubmitting(true)
 
@@ -78,6 +88,10 @@ export function InjectorConfigDialog({
         suffix: suffix || null,
         showLabelIfEmpty,
         defaultValue: defaultValue || null,
+        ...(isDate && {
+          timeZone: timeZone.trim() || null,
+          dateOffset: dateOffset.trim() || null,
+        }),
       })
     } finally {
       setIsSubmitting(false)
@@ -165,6 +179,47 @@ export function InjectorConfigDialog({
               </p>
             </div>
 
+            {/* Date: time zone and offset */}
+            {isDate && (
+              <div className="space-y-4 border border-border p-4">
+                <Label className="text-xs font-medium uppercase tracking-wider">
+                  {t('editor.injector_config.date_section')}
+                </Label>
+
+                <div className="space-y-2">
+                  <Label htmlFor="time-zone" className="text-xs font-medium">
+                    {t('editor.injector_config.time_zone')}
+                  </Label>
+                  <Input
+                    id="time-zone"
+                    value={timeZone}
+                    onChange={(e) => setTimeZone(e.target.value)}
+                    placeholder={t('editor.injector_config.time_zone_placeholder')}
+                    className="border-border font-mono text-xs"
+                  />
+                  <p className="text-xs text-muted-foreground">
+                    {t('editor.injector_config.time_zone_desc')}
+                  </p>
+                </div>
+
+                <div className="space-y-2">
+                  <Label htmlFor="date-offset" className="text-xs font-medium">
+                    {t('editor.injector_config.date_offset')}
+                  </Label>
+                  <Input
+                    id="date-offset"
+                    value={dateOffset}
+                    onChange={(e) => setDateOffset(e.target.value)}
+                    placeholder={t('editor.injector_config.date_offset_placeholder')}
+                    className="border-border font-mono text-xs"
+                  />
+                  <p className={dateOffsetValid ? 'text-xs text-muted-foreground' : 'text-xs text-destructive'}>
+                    {t(dateOffsetValid ? 'editor.injector_config.date_offset_desc' : 'editor.injector_config.date_offset_invalid')}
+                  </p>
+                </div>
+              </div>
+            )}
+
             {/* Empty Value Behavior */}
             <div className="space-y-4 border border-border p-4">
               <Label className="text-xs font-medium uppercase tracking-wider">
@@ -222,7 +277,7 @@ export function InjectorConfigDialog({
             </button>
             <button
               type="submit"
-              disabled={isSubmitting}
+              disabled={isSubmitting || !dateOffsetValid}
               className="rounded-none bg-foreground px-6 py-2.5 font-mono text-xs uppercase tracking-wider text-background transition-colors hover:bg-foreground/90 disabled:opacity-50"
             >
               {isSubmitting ? t('common.saving') : t('common.apply')}
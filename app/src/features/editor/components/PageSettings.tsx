@@ -10,9 +10,45 @@ import {
   SelectTrigger,
   SelectValue,
 } from '@/components/ui/select'
-import { PAGE_SIZES, DEFAULT_MARGINS, MARGIN_LIMITS, type PageMargins } from '../types'
+import {
+  PAGE_SIZES,
+  DEFAULT_MARGINS,
+  MARGIN_LIMITS,
+  type LayoutVariants,
+  type MarginPreset,
+  type Overlays,
+  type PageMargins,
+  type PageStampPosition,
+} from '../types'
 import { usePaginationStore } from '../stores'
 
+const PAGE_STAMP_POSITIONS: PageStampPosition[] = [
+  'top-left',
+  'top-right',
+  'bottom-left',
+  'bottom-center',
+  'bottom-right',
+]
+
+const DEFAULT_PAGE_STAMP_FORMAT = '{{page}} / {{total}}'
+
+const VARIANT_PAPER_SIZES = ['A4', 'LETTER', 'LEGAL'] as const
+const MARGIN_PRESETS: MarginPreset[] = ['narrow', 'normal', 'wide']
+
+/** Parses a comma-separated list of font scales, keeping values between 0.5 and 2 */
+function parseFontScales(value: string): number[] {
+  return value
+    .split(',')
+    .map((part) => parseFloat(part.trim()))
+    .filter((scale) => !Number.isNaN(scale) && scale >= 0.5 && scale <= 2)
+}
+
+/** Toggles a value in a list, returning undefined instead of an empty list */
+function toggleValue<T>(list: T[] | undefined, value: T): T[] | undefined {
+  const next = list?.includes(value) ? list.filter((item) => item !== value) : [...(list ?? []), value]
+  return next.length > 0 ? next : undefined
+}
+
 interface PageSettingsProps {
   /** Whether the settings are disabled (read-only mode) */
   disabled?: boolean
@@ -20,7 +56,18 @@ interface PageSettingsProps {
 
 export function PageSettings({ disabled = false }: PageSettingsProps) {
   const { t } = useTranslation()
-  const { pageSize, margins, setPageSize, setMargins } = usePaginationStore()
+  const {
+    pageSize,
+    margins,
+    pageStamp,
+    layoutVariants,
+    overlays,
+    setPageSize,
+    setMargins,
+    setPageStamp,
+    setLayoutVariants,
+    setOverlays,
+  } = usePaginationStore()
 
   const [open, setOpen] = useState(false)
   const [customMargins, setCustomMargins] = useState(margins)
@@ -100,6 +147,49 @@ export function PageSettings({ disabled = false }: PageSettingsProps) {
     })
   }
 
+  const handlePageStampPositionChange = (value: string) => {
+    if (value === 'none') {
+      setPageStamp(null)
+      return
+    }
+    setPageStamp({
+      position: value as PageStampPosition,
+      format: pageStamp?.format || DEFAULT_PAGE_STAMP_FORMAT,
+    })
+  }
+
+  const handlePageStampFormatBlur = (value: string) => {
+    if (!pageStamp) return
+    setPageStamp({ ...pageStamp, format: value.trim() || DEFAULT_PAGE_STAMP_FORMAT })
+  }
+
+  const updateLayoutVariants = (update: Partial<LayoutVariants>) => {
+    const next = { ...layoutVariants, ...update }
+    const isEmpty = !next.paperSizes && !next.marginPresets && !next.fontScales
+    setLayoutVariants(isEmpty ? null : next)
+  }
+
+  const updateOverlays = (update: Partial<Overlays>) => {
+    const next = { ...overlays, ...update }
+    const isEmpty = !next.watermark && !next.stamp && !next.bates
+    setOverlays(isEmpty ? null : next)
+  }
+
+  const handleStampBlur = (value: string) => {
+    const text = value.trim()
+    updateOverlays({ stamp: text ? { ...overlays?.stamp, text } : undefined })
+  }
+
+  const handleBatesPrefixBlur = (value: string) => {
+    if (!overlays?.bates) return
+    updateOverlays({ bates: { ...overlays.bates, prefix: value.trim() || undefined } })
+  }
+
+  const handleFontScalesBlur = (value: string) => {
+    const fontScales = parseFontScales(value)
+    updateLayoutVariants({ fontScales: fontScales.length > 0 ? fontScales : undefined })
+  }
+
   const getCurrentSizeKey = () => {
     return Object.entries(PAGE_SIZES).find(
       ([_, size]) => size.width === pageSize.width && size.height === pageSize.height
@@ -174,6 +264,154 @@ export function PageSettings({ disabled = false }: PageSettingsProps) {
               </Select>
             </div>
 
+            {/* Page Stamp */}
+            <div>
+              <label
+                htmlFor="page-stamp"
+                className="mb-2 block font-mono text-[10px] font-medium uppercase tracking-widest text-muted-foreground"
+              >
+                {t('editor.pageSettings.pageStamp')}
+              </label>
+              <Select
+                value={pageStamp?.position ?? 'none'}
+                onValueChange={handlePageStampPositionChange}
+              >
+                <SelectTrigger id="page-stamp" className="border-border">
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  <SelectItem value="none">{t('editor.pageSettings.pageStampNone')}</SelectItem>
+                  {PAGE_STAMP_POSITIONS.map((position) => (
+                    <SelectItem key={position} value={position}>
+                      {t(`editor.pageSettings.pageStampPositions.${position}`)}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+              {pageStamp && (
+                <input
+                  key={pageStamp.format}
+                  aria-label={t('editor.pageSettings.pageStampFormat')}
+                  defaultValue={pageStamp.format}
+                  onBlur={(e) => handlePageStampFormatBlur(e.target.value)}
+                  className="mt-2 w-full rounded-none border-0 border-b border-border bg-transparent py-2 text-base font-light text-foreground outline-none transition-all placeholder:text-muted-foreground/50 focus-visible:border-foreground focus-visible:ring-0"
+                />
+              )}
+              <p className="mt-1 text-xs text-muted-foreground">
+                {t('editor.pageSettings.pageStampHint', { page: '{{page}}', total: '{{total}}' })}
+              </p>
+            </div>
+
+            {/* Layout Variants */}
+            <div>
+              <label className="mb-2 block font-mono text-[10px] font-medium uppercase tracking-widest text-muted-foreground">
+                {t('editor.pageSettings.layoutVariants')}
+              </label>
+              <div className="flex flex-wrap gap-2">
+                {VARIANT_PAPER_SIZES.filter((size) => size !== getCurrentSizeKey()).map((size) => (
+                  <button
+                    key={size}
+                    type="button"
+                    aria-pressed={layoutVariants?.paperSizes?.includes(size) ?? false}
+                    onClick={() =>
+                      updateLayoutVariants({ paperSizes: toggleValue(layoutVariants?.paperSizes, size) })
+                    }
+                    className={cn(
+                      'border px-2 py-1 text-xs transition-colors',
+                      layoutVariants?.paperSizes?.includes(size)
+                        ? 'border-foreground bg-foreground text-background'
+                        : 'border-border text-muted-foreground hover:border-foreground',
+                    )}
+                  >
+                    {PAGE_SIZES[size].label}
+                  </button>
+                ))}
+                {MARGIN_PRESETS.map((preset) => (
+                  <button
+                    key={preset}
+                    type="button"
+                    aria-pressed={layoutVariants?.marginPresets?.includes(preset) ?? false}
+                    onClick={() =>
+                      updateLayoutVariants({ marginPresets: toggleValue(layoutVariants?.marginPresets, preset) })
+                    }
+                    className={cn(
+                      'border px-2 py-1 text-xs transition-colors',
+                      layoutVariants?.marginPresets?.includes(preset)
+                        ? 'border-foreground bg-foreground text-background'
+                        : 'border-border text-muted-foreground hover:border-foreground',
+                    )}
+                  >
+                    {t(`editor.pageSettings.marginPresets.${preset}`)}
+                  </button>
+                ))}
+              </div>
+              <input
+                key={layoutVariants?.fontScales?.join(', ') ?? ''}
+                aria-label={t('editor.pageSettings.fontScales')}
+                placeholder={t('editor.pageSettings.fontScales')}
+                defaultValue={layoutVariants?.fontScales?.join(', ') ?? ''}
+                onBlur={(e) => handleFontScalesBlur(e.target.value)}
+                className="mt-2 w-full rounded-none border-0 border-b border-border bg-transparent py-2 text-base font-light text-foreground outline-none transition-all placeholder:text-muted-foreground/50 focus-visible:border-foreground focus-visible:ring-0"
+              />
+              <p className="mt-1 text-xs text-muted-foreground">
+                {t('editor.pageSettings.layoutVariantsHint')}
+              </p>
+            </div>
+
+            {/* Overlays */}
+            <div>
+              <label className="mb-2 block font-mono text-[10px] font-medium uppercase tracking-widest text-muted-foreground">
+                {t('editor.pageSettings.overlays')}
+              </label>
+              <input
+                key={`watermark-${overlays?.watermark ?? ''}`}
+                aria-label={t('editor.pageSettings.overlayWatermark')}
+                placeholder={t('editor.pageSettings.overlayWatermark')}
+                maxLength={100}
+                defaultValue={overlays?.watermark ?? ''}
+                onBlur={(e) => updateOverlays({ watermark: e.target.value.trim() || undefined })}
+                className="mt-2 w-full rounded-none border-0 border-b border-border bg-transparent py-2 text-base font-light text-foreground outline-none transition-all placeholder:text-muted-foreground/50 focus-visible:border-foreground focus-visible:ring-0"
+              />
+              <input
+                key={`stamp-${overlays?.stamp?.text ?? ''}`}
+                aria-label={t('editor.pageSettings.overlayStamp')}
+                placeholder={t('editor.pageSettings.overlayStamp')}
+                maxLength={40}
+                defaultValue={overlays?.stamp?.text ?? ''}
+                onBlur={(e) => handleStampBlur(e.target.value)}
+                className="mt-2 w-full rounded-none border-0 border-b border-border bg-transparent py-2 text-base font-light text-foreground outline-none transition-all placeholder:text-muted-foreground/50 focus-visible:border-foreground focus-visible:ring-0"
+              />
+              <div className="mt-2 flex items-center gap-2">
+                <button
+                  type="button"
+                  aria-pressed={!!overlays?.bates}
+                  onClick={() => updateOverlays({ bates: overlays?.bates ? undefined : {} })}
+                  className={cn(
+                    'shrink-0 border px-2 py-1 text-xs transition-colors',
+                    overlays?.bates
+                      ? 'border-foreground bg-foreground text-background'
+                      : 'border-border text-muted-foreground hover:border-foreground',
+                  )}
+                >
+                  {t('editor.pageSettings.overlayBates')}
+                </button>
+                {overlays?.bates && (
+                  <input
+                    key={`bates-${overlays.bates.prefix ?? ''}`}
+                    aria-label={t('editor.pageSettings.overlayBatesPrefix')}
+                    placeholder={t('editor.pageSettings.overlayBatesPrefix')}
+                    maxLength={20}
+                    defaultValue={overlays.bates.prefix ?? ''}
+                    onBlur={(e) => handleBatesPrefixBlur(e.target.value)}
+                    className="w-full rounded-none border-0 border-b border-border bg-transparent py-1 text-sm font-light text-foreground outline-none transition-all placeholder:text-muted-foreground/50 focus-visible:border-foreground focus-visible:ring-0"
+                  />
+                )}
+              </div>
+              <p className="mt-1 text-xs text-muted-foreground">
+                {t('editor.pageSettings.overlaysHint')}
+              </p>
+            </div>
+
             {/* Margins */}
             <div>
               <label className="mb-2 block font-mono text-[10px] font-medium uppercase tracking-widest text-muted-foreground">
@@ -0,0 +1,99 @@
+import { useState } from 'react'
+import type { Editor } from '@tiptap/react'
+import { Check, ChevronDown, Rows3 } from 'lucide-react'
+import { Button } from '@/components/ui/button'
+import {
+  Popover,
+  PopoverContent,
+  PopoverTrigger,
+} from '@/components/ui/popover'
+import { cn } from '@/lib/utils'
+import { useTranslation } from 'react-i18next'
+import type { PaginationHints } from '../extensions/PaginationHints'
+
+interface PaginationHintsPickerProps {
+  editor: Editor
+}
+
+type HintOption = {
+  key: 'keepTogether' | 'breakBefore' | 'breakAfter' | 'avoidOrphans'
+  labelKey: string
+}
+
+const HINT_OPTIONS: HintOption[] = [
+  { key: 'keepTogether', labelKey: 'editor.toolbar.keepTogether' },
+  { key: 'breakBefore', labelKey: 'editor.toolbar.breakBefore' },
+  { key: 'breakAfter', labelKey: 'editor.toolbar.breakAfter' },
+  { key: 'avoidOrphans', labelKey: 'editor.toolbar.avoidOrphans' },
+]
+
+function getTopLevelHints(editor: Editor): Partial<PaginationHints> | null {
+  const { $from } = editor.state.selection
+  if ($from.depth < 1) return null
+  return $from.node(1).attrs as Partial<PaginationHints>
+}
+
+export function PaginationHintsPicker({ editor }: PaginationHintsPickerProps) {
+  const { t } = useTranslation()
+  const [open, setOpen] = useState(false)
+  const hints = getTopLevelHints(editor)
+
+  const isActive = (key: HintOption['key']) =>
+    key === 'avoidOrphans' ? (hints?.minOrphanLines ?? 0) >= 2 : !!hints?.[key]
+
+  const toggle = (key: HintOption['key']) => {
+    const next = !isActive(key)
+    const update: Partial<PaginationHints> =
+      key === 'avoidOrphans' ? { minOrphanLines: next ? 2 : null } : { [key]: next }
+    editor.chain().focus().setPaginationHints(update).run()
+  }
+
+  return (
+    <Popover open={open} onOpenChange={setOpen}>
+      <PopoverTrigger asChild>
+        <Button
+          type="button"
+          variant="ghost"
+          size="sm"
+          className="h-8 shrink-0 gap-1 px-2 text-xs font-normal"
+          onMouseDown={(e) => e.preventDefault()}
+          disabled={!editor.can().setPaginationHints({})}
+          title={t('editor.toolbar.pagination')}
+        >
+          <Rows3 className="h-3.5 w-3.5" />
+          <ChevronDown className="h-3.5 w-3.5 shrink-0 opacity-50" />
+        </Button>
+      </PopoverTrigger>
+
+      <PopoverContent
+        className="w-[200px] p-1"
+        align="start"
+        sideOffset={4}
+        onOpenAutoFocus={(e) => e.preventDefault()}
+        onCloseAutoFocus={(e) => e.preventDefault()}
+      >
+        {HINT_OPTIONS.map((option) => (
+          <button
+            key={option.key}
+            type="button"
+            onMouseDown={(e) => e.preventDefault()}
+            onClick={() => toggle(option.key)}
+            className={cn(
+              'flex w-full cursor-pointer items-center gap-2 rounded-sm px-2 py-1.5 text-sm',
+              'hover:bg-accent hover:text-accent-foreground',
+              'outline-none',
+            )}
+          >
+            <Check
+              className={cn(
+                'h-3.5 w-3.5 shrink-0',
+                isActive(option.key) ? 'opacity-100' : 'opacity-0',
+              )}
+            />
+            {t(option.labelKey)}
+          </button>
+        ))}
+      </PopoverContent>
+    </Popover>
+  )
+}
@@ -11,7 +11,7 @@ import { useTranslation } from 'react-i18next'
 import { AnimatePresence, motion } from 'framer-motion'
 import { cn } from '@/lib/utils'
 import { Button } from '@/components/ui/button'
-import type { AutoSaveStatus } from '../hooks/useAutoSave'
+import { SaveConflictError, type AutoSaveStatus } from '../hooks/useAutoSave'
 
 // =============================================================================
 // Types
@@ -152,8 +152,7 @@ function StatusIcon({ status }: StatusIconProps) {
 export function SaveStatusIndicator({
   status,
   lastSavedAt,
-   
-  error: _error,
+  error,
   onRetry,
   className,
 }: SaveStatusIndicatorProps) {
@@ -173,7 +172,9 @@ export function SaveStatusIndicator({
       case 'saved':
         return t('editor.autoSave.saved') || 'Guardado'
       case 'error':
-        return t('editor.autoSave.error') || 'Error al guardar'
+        return error instanceof SaveConflictError
+          ? t('editor.autoSave.conflict') || 'Modificado en otra sesión, recarga'
+          : t('editor.autoSave.error') || 'Error al guardar'
       default:
         return null
     }
@@ -227,7 +228,7 @@ export function SaveStatusIndicator({
 
       {/* Retry button for error state */}
       <AnimatePresence>
-        {status === 'error' && onRetry && (
+        {status === 'error' && onRetry && !(error instanceof SaveConflictError) && (
           <motion.div
             initial={{ opacity: 0, scale: 0.8, x: 20 }}
             animate={{ opacity: 1, scale: 1, x: 0 }}
@@ -0,0 +1,90 @@
+import { useState } from 'react'
+import type { Editor } from '@tiptap/react'
+import { Check, ChevronDown, Eye, EyeOff } from 'lucide-react'
+import { Button } from '@/components/ui/button'
+import {
+  Popover,
+  PopoverContent,
+  PopoverTrigger,
+} from '@/components/ui/popover'
+import { cn } from '@/lib/utils'
+import { useTranslation } from 'react-i18next'
+import {
+  OUTPUT_VISIBILITIES,
+  normalizeOutputVisibility,
+  type OutputVisibility,
+} from '../extensions/NodeVisibility'
+
+interface VisibilityPickerProps {
+  editor: Editor
+}
+
+function getCurrentVisibility(editor: Editor): OutputVisibility | null {
+  const { $from } = editor.state.selection
+  if ($from.depth < 1) return null
+  return normalizeOutputVisibility($from.node(1).attrs.visibility)
+}
+
+export function VisibilityPicker({ editor }: VisibilityPickerProps) {
+  const { t } = useTranslation()
+  const [open, setOpen] = useState(false)
+  const current = getCurrentVisibility(editor)
+  const options: (OutputVisibility | null)[] = [null, ...OUTPUT_VISIBILITIES]
+
+  const applyVisibility = (value: OutputVisibility | null) => {
+    editor.chain().focus().setNodeVisibility(value).run()
+    setOpen(false)
+  }
+
+  return (
+    <Popover open={open} onOpenChange={setOpen}>
+      <PopoverTrigger asChild>
+        <Button
+          type="button"
+          variant="ghost"
+          size="sm"
+          className={cn(
+            'h-8 shrink-0 gap-1 px-2 text-xs font-normal',
+            current && 'text-primary',
+          )}
+          onMouseDown={(e) => e.preventDefault()}
+          disabled={!editor.can().setNodeVisibility(null)}
+          title={t('editor.toolbar.visibility')}
+        >
+          {current ? <EyeOff className="h-3.5 w-3.5" /> : <Eye className="h-3.5 w-3.5" />}
+          <ChevronDown className="h-3.5 w-3.5 shrink-0 opacity-50" />
+        </Button>
+      </PopoverTrigger>
+
+      <PopoverContent
+        className="w-[200px] p-1"
+        align="start"
+        sideOffset={4}
+        onOpenAutoFocus={(e) => e.preventDefault()}
+        onCloseAutoFocus={(e) => e.preventDefault()}
+      >
+        {options.map((value) => (
+          <button
+            key={value ?? 'all'}
+            type="button"
+            onMouseDown={(e) => e.preventDefault()}
+            onClick={() => applyVisibility(value)}
+            className={cn(
+              'flex w-full cursor-pointer items-center gap-2 rounded-sm px-2 py-1.5 text-sm',
+              'hover:bg-accent hover:text-accent-foreground',
+              'outline-none',
+            )}
+          >
+            <Check
+              className={cn(
+                'h-3.5 w-3.5 shrink-0',
+                current === value ? 'opacity-100' : 'opacity-0',
+              )}
+            />
+            {t(`editor.toolbar.visibilityOptions.${value ?? 'all'}`)}
+          </button>
+        ))}
+      </PopoverContent>
+    </Popover>
+  )
+}
@@ -0,0 +1,63 @@
+import { Node, mergeAttributes } from '@tiptap/core'
+import { ReactNodeViewRenderer } from '@tiptap/react'
+import { ExternalPdfComponent } from './ExternalPdfComponent'
+
+export type ExternalPdfMode = 'append' | 'embed'
+
+export interface ExternalPdfAttrs {
+  /** Asset URL (http(s) or storage://). */
+  src: string | null
+  /** Injectable holding the PDF URL; takes precedence over src when it has a value. */
+  injectableId: string | null
+  mode: ExternalPdfMode
+  /** Page ranges such as "1-3,5". Empty includes every page. */
+  pages: string | null
+  label: string | null
+}
+
+declare module '@tiptap/core' {
+  interface Commands<ReturnType> {
+    externalPdf: {
+      setExternalPdf: (attrs?: Partial<ExternalPdfAttrs>) => ReturnType
+    }
+  }
+}
+
+export const ExternalPdfExtension = Node.create({
+  name: 'externalPdf',
+  group: 'block',
+  atom: true,
+  draggable: true,
+
+  addAttributes() {
+    return {
+      src: { default: null },
+      injectableId: { default: null },
+      mode: { default: 'append' },
+      pages: { default: null },
+      label: { default: null },
+    }
+  },
+
+  addCommands() {
+    return {
+      setExternalPdf:
+        (attrs) =>
+        ({ commands }) => {
+          return commands.insertContent({ type: this.name, attrs })
+        },
+    }
+  },
+
+  addNodeView() {
+    return ReactNodeViewRenderer(ExternalPdfComponent)
+  },
+
+  parseHTML() {
+    return [{ tag: 'div[data-type="external-pdf"]' }]
+  },
+
+  renderHTML({ HTMLAttributes }) {
+    return ['div', mergeAttributes(HTMLAttributes, { 'data-type': 'external-pdf' })]
+  },
+})
@@ -0,0 +1,77 @@
+import { useState } from 'react'
+import { useTranslation } from 'react-i18next'
+import { NodeViewWrapper, type NodeViewProps } from '@tiptap/react'
+import { FileStack } from 'lucide-react'
+import { cn } from '@/lib/utils'
+import { EditorNodeContextMenu } from '../../components/EditorNodeContextMenu'
+import type { ExternalPdfAttrs } from './ExternalPdf'
+
+// Editor placeholder; the PDF is downloaded and its pages placed at render time.
+export const ExternalPdfComponent = (props: NodeViewProps) => {
+  const { node, selected, deleteNode, updateAttributes } = props
+  const attrs = node.attrs as ExternalPdfAttrs
+  const { t } = useTranslation()
+  const [contextMenu, setContextMenu] = useState<{ x: number; y: number } | null>(null)
+
+  const handleContextMenu = (e: React.MouseEvent) => {
+    e.preventDefault()
+    e.stopPropagation()
+    setContextMenu({ x: e.clientX, y: e.clientY })
+  }
+
+  return (
+    <NodeViewWrapper>
+      <div
+        data-drag-handle
+        contentEditable={false}
+        onContextMenu={handleContextMenu}
+        className={cn(
+          'external-pdf-node cursor-grab select-none my-2 rounded border border-dashed px-3 py-2',
+          selected ? 'border-muted-foreground' : 'border-border'
+        )}
+        style={{ WebkitUserSelect: 'none', userSelect: 'none' }}
+      >
+        <div className="flex items-center gap-2 text-xs text-muted-foreground">
+          <FileStack className="w-4 h-4" />
+          <select
+            value={attrs.mode}
+            onChange={(e) => updateAttributes({ mode: e.target.value })}
+            className="bg-transparent text-xs outline-none"
+          >
+            <option value="append">{t('editor.external_pdf.append')}</option>
+            <option value="embed">{t('editor.external_pdf.embed')}</option>
+          </select>
+          {attrs.injectableId ? (
+            <span className="flex-1 truncate font-mono">{attrs.label || attrs.injectableId}</span>
+          ) : (
+            <input
+              value={attrs.src ?? ''}
+              onChange={(e) => updateAttributes({ src: e.target.value || null })}
+              placeholder="https://…/annex.pdf"
+              className="flex-1 bg-transparent font-mono text-xs outline-none"
+              aria-label={t('editor.external_pdf.src')}
+            />
+          )}
+          <input
+            value={attrs.pages ?? ''}
+            onChange={(e) => updateAttributes({ pages: e.target.value || null })}
+            placeholder={t('editor.external_pdf.allPages')}
+            className="w-20 bg-transparent font-mono text-xs outline-none"
+            aria-label={t('editor.external_pdf.pages')}
+          />
+        </div>
+        <p className="mt-1 text-[10px] text-muted-foreground">{t('editor.external_pdf.hint')}</p>
+      </div>
+
+      {contextMenu && (
+        <EditorNodeContextMenu
+          x={contextMenu.x}
+          y={contextMenu.y}
+          nodeType="externalPdf"
+          onDelete={deleteNode}
+          onClose={() => setContextMenu(null)}
+        />
+      )}
+    </NodeViewWrapper>
+  )
+}
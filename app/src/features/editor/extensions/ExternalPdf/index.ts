@@ -0,0 +1,3 @@
+export { ExternalPdfExtension } from './ExternalPdf'
+export type { ExternalPdfAttrs, ExternalPdfMode } from './ExternalPdf'
+export { ExternalPdfComponent } from './ExternalPdfComponent'
@@ -43,7 +43,7 @@ const MIN_WIDTH = 40
 export const InjectorComponent = (props: NodeViewProps) => {
   const { node, selected, deleteNode, updateAttributes, editor, getPos } = props
   const { t } = useTranslation()
-  const { label, type, format, variableId, prefix, suffix, showLabelIfEmpty, defaultValue, width } = node.attrs
+  const { label, type, format, variableId, prefix, suffix, showLabelIfEmpty, defaultValue, width, timeZone, dateOffset } = node.attrs
 
   const chipRef = useRef<HTMLSpanElement>(null)
   const [isResizing, setIsResizing] = useState(false)
@@ -114,6 +114,8 @@ export const InjectorComponent = (props: NodeViewProps) => {
     suffix?: string | null
     showLabelIfEmpty?: boolean
     defaultValue?: string | null
+    timeZone?: string | null
+    dateOffset?: string | null
   }) => {
     updateAttributes(config)
   }
@@ -273,6 +275,8 @@ export const InjectorComponent = (props: NodeViewProps) => {
             showLabelIfEmpty,
             defaultValue,
             format,
+            timeZone,
+            dateOffset,
           }}
           onApply={handleApplyConfig}
         />
@@ -19,6 +19,10 @@ export interface InjectorOptions {
   defaultValue?: string | null
   /** Ancho fijo en píxeles (null = auto) */
   width?: number | null
+  /** Zona horaria IANA de la fecha (null = la del documento) */
+  timeZone?: string | null
+  /** Desplazamiento de la fecha (ej: "+30d", "+10wd") */
+  dateOffset?: string | null
 }
 
 declare module '@tiptap/core' {
@@ -71,6 +75,12 @@ export const InjectorExtension = Node.create({
       defaultValue: {
         default: null,
       },
+      timeZone: {
+        default: null,
+      },
+      dateOffset: {
+        default: null,
+      },
       width: {
         default: null,
         parseHTML: (element: HTMLElement) => {
@@ -18,7 +18,7 @@ import {
 import { Input } from '@/components/ui/input'
 import { TableStylesPanel } from '../Table/TableStylesPanel'
 import type { ListInjectorAttrs } from './types'
-import { LIST_SYMBOL_OPTIONS } from '../../types/list-input'
+import { LIST_SYMBOL_OPTIONS, NUMBERED_LIST_SYMBOLS, type ListSymbolType } from '../../types/list-input'
 import {
   useInjectablesStore,
   selectVariableByVariableId,
@@ -147,6 +147,36 @@ export function ListInjectorComponent({ node, editor, selected, deleteNode, upda
           </Select>
         </div>
 
+        {/* Numbering: start number and continuation */}
+        {NUMBERED_LIST_SYMBOLS.includes((attrs.symbol || 'bullet') as ListSymbolType) && (
+          <div className="flex flex-shrink-0 items-center gap-1.5">
+            <Input
+              type="number"
+              min={1}
+              value={attrs.start ?? ''}
+              placeholder={attrs.continueNumbering ? '…' : '1'}
+              disabled={attrs.continueNumbering}
+              onChange={(e) => {
+                const value = parseInt(e.target.value, 10)
+                updateAttributes({ start: Number.isNaN(value) || value < 1 ? null : value })
+              }}
+              className="h-8 w-14 text-xs font-mono"
+              aria-label={t('editor.listInjector.start')}
+              title={t('editor.listInjector.start')}
+            />
+            <label className="flex items-center gap-1 text-xs text-muted-foreground whitespace-nowrap">
+              <input
+                type="checkbox"
+                checked={!!attrs.continueNumbering}
+                onChange={(e) =>
+                  updateAttributes({ continueNumbering: e.target.checked, start: e.target.checked ? null : attrs.start })
+                }
+              />
+              {t('editor.listInjector.continueNumbering')}
+            </label>
+          </div>
+        )}
+
         <div className="flex items-center gap-1">
           <Tooltip>
             <TooltipTrigger asChild>
@@ -41,6 +41,12 @@ export const ListInjectorExtension = Node.create({
       symbol: {
         default: 'bullet',
       },
+      start: {
+        default: null,
+      },
+      continueNumbering: {
+        default: false,
+      },
       // Header style overrides
       headerFontFamily: { default: null },
       headerFontSize: { default: null },
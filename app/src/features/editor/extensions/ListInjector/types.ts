@@ -16,6 +16,10 @@ export interface ListInjectorAttrs {
   label: string
   lang?: string
   symbol?: string
+  /** First item number for numbered symbols (null = injector value or 1) */
+  start?: number | null
+  /** Continue numbering from the previous numbered list injector */
+  continueNumbering?: boolean
   headerStyles?: Partial<ListStylesAttrs>
   itemStyles?: Partial<ListStylesAttrs>
 }
@@ -1,7 +1,7 @@
 import Mention from '@tiptap/extension-mention'
 import type { Editor } from '@tiptap/core'
 import { PluginKey } from '@tiptap/pm/state'
-import { filterVariables, type MentionVariable } from './variables'
+import { filterVariables, repeaterItemVariables, type MentionVariable } from './variables'
 import { variableSuggestion } from './suggestion'
 import {
   hasConfigurableOptions,
@@ -17,7 +17,10 @@ export const MentionExtension = Mention.configure({
     pluginKey: MentionPluginKey,
     allowSpaces: true,
     ...variableSuggestion,
-    items: ({ query }: { query: string }) => filterVariables(query),
+    items: ({ query, editor }: { query: string; editor: Editor }) => [
+      ...repeaterItemVariables(editor, query),
+      ...filterVariables(query),
+    ],
     command: ({
       editor,
       range,
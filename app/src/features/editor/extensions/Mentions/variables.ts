@@ -11,6 +11,7 @@ import {
   Type,
 } from 'lucide-react'
 import type { LucideIcon } from 'lucide-react'
+import type { Editor } from '@tiptap/core'
 import type { InjectorType, Variable } from '../../types/variables'
 import type { FormatConfig } from '../../types/injectable'
 import {
@@ -76,3 +77,26 @@ export function getMentionVariables(): MentionVariable[] {
 export function filterVariables(query: string): MentionVariable[] {
   return storeFilterVariables(query).map(mapToMentionVariable)
 }
+
+/**
+ * Item variables of the repeaters around the cursor: "<alias>" (a list item), "<alias>.index"
+ * (its position) and, once the query names one, "<alias>.<column key>" of a table row.
+ */
+export function repeaterItemVariables(editor: Editor, query: string): MentionVariable[] {
+  const { $from } = editor.state.selection
+  const needle = query.toLowerCase()
+  const items: MentionVariable[] = []
+  for (let depth = $from.depth; depth > 0; depth--) {
+    const node = $from.node(depth)
+    if (node.type.name !== 'repeater') continue
+    const alias = (node.attrs.alias as string | null) || 'item'
+    const index = `${alias}.index`
+    const ids = [alias, index]
+    if (query.startsWith(`${alias}.`) && !ids.includes(query)) ids.push(query)
+    for (const id of ids) {
+      if (!id.toLowerCase().includes(needle)) continue
+      items.push({ id, label: id, type: id === index ? 'NUMBER' : 'TEXT', group: 'variable' })
+    }
+  }
+  return items
+}
@@ -0,0 +1,81 @@
+import { Extension } from '@tiptap/core'
+
+/** Output formats a block can be restricted to; null renders it everywhere. */
+export const OUTPUT_VISIBILITIES = ['pdf', 'html', 'print'] as const
+export type OutputVisibility = (typeof OUTPUT_VISIBILITIES)[number]
+
+declare module '@tiptap/core' {
+  interface Commands<ReturnType> {
+    nodeVisibility: {
+      setNodeVisibility: (visibility: OutputVisibility | null) => ReturnType
+    }
+  }
+}
+
+export interface NodeVisibilityOptions {
+  types: string[]
+}
+
+export function normalizeOutputVisibility(value: unknown): OutputVisibility | null {
+  return OUTPUT_VISIBILITIES.includes(value as OutputVisibility)
+    ? (value as OutputVisibility)
+    : null
+}
+
+export const NodeVisibilityExtension = Extension.create<NodeVisibilityOptions>({
+  name: 'nodeVisibility',
+
+  addOptions() {
+    return {
+      types: [
+        'paragraph',
+        'heading',
+        'blockquote',
+        'bulletList',
+        'orderedList',
+        'listInjector',
+        'table',
+        'tableInjector',
+        'customImage',
+        'conditional',
+      ],
+    }
+  },
+
+  addGlobalAttributes() {
+    return [
+      {
+        types: this.options.types,
+        attributes: {
+          visibility: {
+            default: null,
+            parseHTML: (element) =>
+              normalizeOutputVisibility(element.getAttribute('data-visibility')),
+            renderHTML: (attributes) =>
+              attributes.visibility ? { 'data-visibility': attributes.visibility } : {},
+          },
+        },
+      },
+    ]
+  },
+
+  addCommands() {
+    return {
+      // Restricts the top-level block that holds the selection
+      setNodeVisibility:
+        (visibility) =>
+        ({ state, tr, dispatch }) => {
+          const { $from } = state.selection
+          if ($from.depth < 1) return false
+
+          const node = $from.node(1)
+          if (!this.options.types.includes(node.type.name)) return false
+
+          if (dispatch) {
+            tr.setNodeMarkup($from.before(1), undefined, { ...node.attrs, visibility })
+          }
+          return true
+        },
+    }
+  },
+})
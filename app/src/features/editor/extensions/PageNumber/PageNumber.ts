@@ -0,0 +1,55 @@
+import { Node, mergeAttributes } from '@tiptap/core'
+import { ReactNodeViewRenderer } from '@tiptap/react'
+import { PageNumberComponent } from './PageNumberComponent'
+
+/** current: page the node lands on; total: page count of the rendered document */
+export type PageNumberDisplay = 'current' | 'total'
+
+declare module '@tiptap/core' {
+  interface Commands<ReturnType> {
+    pageNumber: {
+      setPageNumber: (display: PageNumberDisplay) => ReturnType
+    }
+  }
+}
+
+export const PageNumberExtension = Node.create({
+  name: 'pageNumber',
+  group: 'inline',
+  inline: true,
+  atom: true,
+  marks: '_',
+  allowGapCursor: false,
+
+  addAttributes() {
+    return {
+      display: {
+        default: 'total',
+        parseHTML: (element: HTMLElement) => element.getAttribute('data-display') || 'total',
+        renderHTML: (attributes: Record<string, unknown>) => ({ 'data-display': attributes.display }),
+      },
+    }
+  },
+
+  addCommands() {
+    return {
+      setPageNumber:
+        (display) =>
+        ({ commands }) => {
+          return commands.insertContent({ type: this.name, attrs: { display } })
+        },
+    }
+  },
+
+  addNodeView() {
+    return ReactNodeViewRenderer(PageNumberComponent)
+  },
+
+  parseHTML() {
+    return [{ tag: 'span[data-type="page-number"]' }]
+  },
+
+  renderHTML({ HTMLAttributes }) {
+    return ['span', mergeAttributes(HTMLAttributes, { 'data-type': 'page-number' })]
+  },
+})
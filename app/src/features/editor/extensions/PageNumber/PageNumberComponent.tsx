@@ -0,0 +1,50 @@
+import { useState } from 'react'
+import { useTranslation } from 'react-i18next'
+import { NodeViewWrapper, type NodeViewProps } from '@tiptap/react'
+import { Hash } from 'lucide-react'
+import { cn } from '@/lib/utils'
+import { EditorNodeContextMenu } from '../../components/EditorNodeContextMenu'
+import type { PageNumberDisplay } from './PageNumber'
+
+// Editor chip; the number is resolved when the document is rendered.
+export const PageNumberComponent = (props: NodeViewProps) => {
+  const { node, selected, deleteNode } = props
+  const display = node.attrs.display as PageNumberDisplay
+  const { t } = useTranslation()
+  const [contextMenu, setContextMenu] = useState<{ x: number; y: number } | null>(null)
+
+  const handleContextMenu = (e: React.MouseEvent) => {
+    e.preventDefault()
+    e.stopPropagation()
+    setContextMenu({ x: e.clientX, y: e.clientY })
+  }
+
+  return (
+    <NodeViewWrapper as="span" className="mx-1" style={{ display: 'inline' }}>
+      <span
+        contentEditable={false}
+        onContextMenu={handleContextMenu}
+        className={cn(
+          'inline-flex items-center gap-1 rounded-md border border-dashed border-gray-300 bg-gray-50 px-2 py-0.5 text-sm font-medium text-gray-600 select-none',
+          'dark:border-info-border dark:bg-info-muted dark:text-info-foreground',
+          selected && 'ring-2 ring-ring'
+        )}
+      >
+        <Hash className="h-3 w-3" />
+        {display === 'current'
+          ? t('editor.slashCommands.pageNumber')
+          : t('editor.slashCommands.totalPages')}
+      </span>
+
+      {contextMenu && (
+        <EditorNodeContextMenu
+          x={contextMenu.x}
+          y={contextMenu.y}
+          nodeType="pageNumber"
+          onDelete={deleteNode}
+          onClose={() => setContextMenu(null)}
+        />
+      )}
+    </NodeViewWrapper>
+  )
+}
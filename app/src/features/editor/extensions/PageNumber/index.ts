@@ -0,0 +1,3 @@
+export { PageNumberExtension } from './PageNumber'
+export type { PageNumberDisplay } from './PageNumber'
+export { PageNumberComponent } from './PageNumberComponent'
@@ -0,0 +1,95 @@
+import { Extension } from '@tiptap/core'
+
+/** Pagination hints stored as node attrs and applied by the PDF renderer. */
+export interface PaginationHints {
+  keepTogether: boolean
+  breakBefore: boolean
+  breakAfter: boolean
+  /** Lines a paragraph must keep at a page edge; 2 or more avoids orphans and widows */
+  minOrphanLines: number | null
+}
+
+declare module '@tiptap/core' {
+  interface Commands<ReturnType> {
+    paginationHints: {
+      setPaginationHints: (hints: Partial<PaginationHints>) => ReturnType
+    }
+  }
+}
+
+export interface PaginationHintsOptions {
+  types: string[]
+}
+
+function booleanAttribute(name: string) {
+  return {
+    default: false,
+    parseHTML: (element: HTMLElement) => element.getAttribute(`data-${name}`) === 'true',
+    renderHTML: (attributes: Record<string, unknown>) => {
+      const key = name.replace(/-([a-z])/g, (_, c: string) => c.toUpperCase())
+      return attributes[key] ? { [`data-${name}`]: 'true' } : {}
+    },
+  }
+}
+
+export const PaginationHintsExtension = Extension.create<PaginationHintsOptions>({
+  name: 'paginationHints',
+
+  addOptions() {
+    return {
+      types: [
+        'paragraph',
+        'heading',
+        'blockquote',
+        'bulletList',
+        'orderedList',
+        'listInjector',
+        'table',
+        'tableInjector',
+      ],
+    }
+  },
+
+  addGlobalAttributes() {
+    return [
+      {
+        types: this.options.types,
+        attributes: {
+          keepTogether: booleanAttribute('keep-together'),
+          breakBefore: booleanAttribute('break-before'),
+          breakAfter: booleanAttribute('break-after'),
+          minOrphanLines: {
+            default: null,
+            parseHTML: (element) => {
+              const value = parseInt(element.getAttribute('data-min-orphan-lines') ?? '', 10)
+              return Number.isNaN(value) ? null : value
+            },
+            renderHTML: (attributes) =>
+              attributes.minOrphanLines ? { 'data-min-orphan-lines': attributes.minOrphanLines } : {},
+          },
+        },
+      },
+    ]
+  },
+
+  addCommands() {
+    return {
+      // Page breaks only apply between top-level blocks, so hints target the
+      // top-level block that holds the selection.
+      setPaginationHints:
+        (hints) =>
+        ({ state, tr, dispatch }) => {
+          const { $from } = state.selection
+          if ($from.depth < 1) return false
+
+          const node = $from.node(1)
+          if (!this.options.types.includes(node.type.name)) return false
+
+          if (dispatch) {
+            tr.setNodeMarkup($from.before(1), undefined, { ...node.attrs, ...hints })
+          }
+          return true
+        },
+    }
+  },
+})
@@ -0,0 +1,54 @@
+import { Node, mergeAttributes } from '@tiptap/core'
+import { ReactNodeViewRenderer } from '@tiptap/react'
+import { ReferencesComponent } from './ReferencesComponent'
+
+export interface ReferencesAttrs {
+  /** Shown above the table; empty shows none. */
+  title: string | null
+  /** Follow each link in the text with its reference number. */
+  markers: boolean
+}
+
+declare module '@tiptap/core' {
+  interface Commands<ReturnType> {
+    references: {
+      setReferences: (attrs?: Partial<ReferencesAttrs>) => ReturnType
+    }
+  }
+}
+
+export const ReferencesExtension = Node.create({
+  name: 'references',
+  group: 'block',
+  atom: true,
+  draggable: true,
+
+  addAttributes() {
+    return {
+      title: { default: null },
+      markers: { default: false },
+    }
+  },
+
+  addCommands() {
+    return {
+      setReferences:
+        (attrs) =>
+        ({ commands }) => {
+          return commands.insertContent({ type: this.name, attrs })
+        },
+    }
+  },
+
+  addNodeView() {
+    return ReactNodeViewRenderer(ReferencesComponent)
+  },
+
+  parseHTML() {
+    return [{ tag: 'div[data-type="references"]' }]
+  },
+
+  renderHTML({ HTMLAttributes }) {
+    return ['div', mergeAttributes(HTMLAttributes, { 'data-type': 'references' })]
+  },
+})
@@ -0,0 +1,66 @@
+import { useState } from 'react'
+import { useTranslation } from 'react-i18next'
+import { NodeViewWrapper, type NodeViewProps } from '@tiptap/react'
+import { Link2 } from 'lucide-react'
+import { cn } from '@/lib/utils'
+import { EditorNodeContextMenu } from '../../components/EditorNodeContextMenu'
+import type { ReferencesAttrs } from './References'
+
+// Editor placeholder; the table lists the links of the compiled document at render time.
+export const ReferencesComponent = (props: NodeViewProps) => {
+  const { node, selected, deleteNode, updateAttributes } = props
+  const attrs = node.attrs as ReferencesAttrs
+  const { t } = useTranslation()
+  const [contextMenu, setContextMenu] = useState<{ x: number; y: number } | null>(null)
+
+  const handleContextMenu = (e: React.MouseEvent) => {
+    e.preventDefault()
+    e.stopPropagation()
+    setContextMenu({ x: e.clientX, y: e.clientY })
+  }
+
+  return (
+    <NodeViewWrapper>
+      <div
+        data-drag-handle
+        contentEditable={false}
+        onContextMenu={handleContextMenu}
+        className={cn(
+          'references-node cursor-grab select-none my-2 rounded border border-dashed px-3 py-2',
+          selected ? 'border-muted-foreground' : 'border-border'
+        )}
+        style={{ WebkitUserSelect: 'none', userSelect: 'none' }}
+      >
+        <div className="flex items-center gap-2 text-xs text-muted-foreground">
+          <Link2 className="w-4 h-4" />
+          <input
+            value={attrs.title ?? ''}
+            onChange={(e) => updateAttributes({ title: e.target.value || null })}
+            placeholder={t('editor.references.title')}
+            className="flex-1 bg-transparent text-xs outline-none"
+            aria-label={t('editor.references.title')}
+          />
+          <label className="flex items-center gap-1">
+            <input
+              type="checkbox"
+              checked={attrs.markers}
+              onChange={(e) => updateAttributes({ markers: e.target.checked })}
+            />
+            {t('editor.references.markers')}
+          </label>
+        </div>
+        <p className="mt-1 text-[10px] text-muted-foreground">{t('editor.references.hint')}</p>
+      </div>
+
+      {contextMenu && (
+        <EditorNodeContextMenu
+          x={contextMenu.x}
+          y={contextMenu.y}
+          nodeType="references"
+          onDelete={deleteNode}
+          onClose={() => setContextMenu(null)}
+        />
+      )}
+    </NodeViewWrapper>
+  )
+}
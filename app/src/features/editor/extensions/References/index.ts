@@ -0,0 +1,3 @@
+export { ReferencesExtension } from './References'
+export type { ReferencesAttrs } from './References'
+export { ReferencesComponent } from './ReferencesComponent'
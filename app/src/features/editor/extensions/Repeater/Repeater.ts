@@ -0,0 +1,62 @@
+import { Node, mergeAttributes } from '@tiptap/core'
+import { ReactNodeViewRenderer } from '@tiptap/react'
+import { RepeaterComponent } from './RepeaterComponent'
+
+export const DEFAULT_REPEATER_ALIAS = 'item'
+
+export interface RepeaterAttrs {
+  /** Table or list variable repeated over. */
+  variableId: string | null
+  /** Name of the current item inside the block; empty uses DEFAULT_REPEATER_ALIAS. */
+  alias: string | null
+}
+
+declare module '@tiptap/core' {
+  interface Commands<ReturnType> {
+    repeater: {
+      setRepeater: (attrs?: Partial<RepeaterAttrs>) => ReturnType
+    }
+  }
+}
+
+export const RepeaterExtension = Node.create({
+  name: 'repeater',
+  group: 'block',
+  content: 'block+',
+  draggable: true,
+  allowGapCursor: false,
+
+  addAttributes() {
+    return {
+      variableId: { default: null },
+      alias: { default: null },
+    }
+  },
+
+  addCommands() {
+    return {
+      setRepeater:
+        (attrs) =>
+        ({ commands }) => {
+          return commands.wrapIn(this.name, attrs)
+        },
+    }
+  },
+
+  addNodeView() {
+    return ReactNodeViewRenderer(RepeaterComponent, {
+      stopEvent: (event) => {
+        const target = event.event.target as HTMLElement
+        return !!target.closest('[data-toolbar]')
+      },
+    })
+  },
+
+  parseHTML() {
+    return [{ tag: 'div[data-type="repeater"]' }]
+  },
+
+  renderHTML({ HTMLAttributes }) {
+    return ['div', mergeAttributes(HTMLAttributes, { 'data-type': 'repeater' }), 0]
+  },
+})
@@ -0,0 +1,74 @@
+import { useState } from 'react'
+import { useTranslation } from 'react-i18next'
+import { NodeViewContent, NodeViewWrapper, type NodeViewProps } from '@tiptap/react'
+import { Repeat } from 'lucide-react'
+import { cn } from '@/lib/utils'
+import { EditorNodeContextMenu } from '../../components/EditorNodeContextMenu'
+import { DEFAULT_REPEATER_ALIAS, type RepeaterAttrs } from './Repeater'
+
+// The block is rendered once per row or item of the variable; inside it, @ offers the item fields.
+export const RepeaterComponent = (props: NodeViewProps) => {
+  const { node, selected, deleteNode, updateAttributes, editor } = props
+  const attrs = node.attrs as RepeaterAttrs
+  const { t } = useTranslation()
+  const [contextMenu, setContextMenu] = useState<{ x: number; y: number } | null>(null)
+
+  const handleContextMenu = (e: React.MouseEvent) => {
+    e.preventDefault()
+    e.stopPropagation()
+    setContextMenu({ x: e.clientX, y: e.clientY })
+  }
+
+  return (
+    <NodeViewWrapper className="my-6 relative">
+      <div
+        className={cn(
+          'repeater-node relative rounded-lg border-2 border-dashed p-4 pt-6',
+          selected ? 'border-muted-foreground' : 'border-border'
+        )}
+      >
+        <div
+          data-drag-handle
+          data-toolbar
+          contentEditable={false}
+          onContextMenu={handleContextMenu}
+          className="absolute -top-3 left-4 z-10 flex h-6 cursor-grab items-center gap-1.5 rounded border bg-card px-2 text-xs text-muted-foreground shadow-sm"
+        >
+          <Repeat className="h-3.5 w-3.5" />
+          <span>{t('editor.repeater.title')}</span>
+          <input
+            value={attrs.variableId ?? ''}
+            onChange={(e) => updateAttributes({ variableId: e.target.value || null })}
+            placeholder={t('editor.repeater.variable')}
+            disabled={!editor.isEditable}
+            className="w-32 bg-transparent font-mono text-xs outline-none"
+            aria-label={t('editor.repeater.variable')}
+          />
+          <span>{t('editor.repeater.as')}</span>
+          <input
+            value={attrs.alias ?? ''}
+            onChange={(e) => updateAttributes({ alias: e.target.value.replace(/\./g, '') || null })}
+            placeholder={DEFAULT_REPEATER_ALIAS}
+            disabled={!editor.isEditable}
+            className="w-16 bg-transparent font-mono text-xs outline-none"
+            aria-label={t('editor.repeater.alias')}
+          />
+        </div>
+        <NodeViewContent className="repeater-content" />
+        <p contentEditable={false} className="mt-1 text-[10px] text-muted-foreground">
+          {t('editor.repeater.hint', { alias: attrs.alias || DEFAULT_REPEATER_ALIAS })}
+        </p>
+      </div>
+
+      {contextMenu && (
+        <EditorNodeContextMenu
+          x={contextMenu.x}
+          y={contextMenu.y}
+          nodeType="repeater"
+          onDelete={deleteNode}
+          onClose={() => setContextMenu(null)}
+        />
+      )}
+    </NodeViewWrapper>
+  )
+}
@@ -0,0 +1,3 @@
+export { RepeaterExtension, DEFAULT_REPEATER_ALIAS } from './Repeater'
+export type { RepeaterAttrs } from './Repeater'
+export { RepeaterComponent } from './RepeaterComponent'
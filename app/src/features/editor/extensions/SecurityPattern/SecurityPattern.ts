@@ -0,0 +1,60 @@
+import { Node, mergeAttributes } from '@tiptap/core'
+import { ReactNodeViewRenderer } from '@tiptap/react'
+import { SecurityPatternComponent } from './SecurityPatternComponent'
+
+export type SecurityPatternVariant = 'guilloche' | 'microtext'
+
+export interface SecurityPatternAttrs {
+  variant: SecurityPatternVariant
+  /** Band height in px. 0 draws the guilloche as the page background. */
+  height: number
+  color: string
+  /** Microtext repeated with the document ID. */
+  text: string
+}
+
+declare module '@tiptap/core' {
+  interface Commands<ReturnType> {
+    securityPattern: {
+      setSecurityPattern: (attrs?: Partial<SecurityPatternAttrs>) => ReturnType
+    }
+  }
+}
+
+export const SecurityPatternExtension = Node.create({
+  name: 'securityPattern',
+  group: 'block',
+  atom: true,
+  draggable: true,
+
+  addAttributes() {
+    return {
+      variant: { default: 'guilloche' },
+      height: { default: 80 },
+      color: { default: '#3a6ea5' },
+      text: { default: 'ORIGINAL' },
+    }
+  },
+
+  addCommands() {
+    return {
+      setSecurityPattern:
+        (attrs) =>
+        ({ commands }) => {
+          return commands.insertContent({ type: this.name, attrs })
+        },
+    }
+  },
+
+  addNodeView() {
+    return ReactNodeViewRenderer(SecurityPatternComponent)
+  },
+
+  parseHTML() {
+    return [{ tag: 'div[data-type="security-pattern"]' }]
+  },
+
+  renderHTML({ HTMLAttributes }) {
+    return ['div', mergeAttributes(HTMLAttributes, { 'data-type': 'security-pattern' })]
+  },
+})
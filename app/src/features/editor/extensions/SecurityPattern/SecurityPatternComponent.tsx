@@ -0,0 +1,92 @@
+import { useState } from 'react'
+import { useTranslation } from 'react-i18next'
+import { NodeViewWrapper, type NodeViewProps } from '@tiptap/react'
+import { ShieldCheck } from 'lucide-react'
+import { cn } from '@/lib/utils'
+import { EditorNodeContextMenu } from '../../components/EditorNodeContextMenu'
+import type { SecurityPatternAttrs } from './SecurityPattern'
+
+// Editor placeholder; the real pattern is generated per document at render time.
+export const SecurityPatternComponent = (props: NodeViewProps) => {
+  const { node, selected, deleteNode, updateAttributes } = props
+  const attrs = node.attrs as SecurityPatternAttrs
+  const { t } = useTranslation()
+  const [contextMenu, setContextMenu] = useState<{ x: number; y: number } | null>(null)
+
+  const isMicrotext = attrs.variant === 'microtext'
+  const isBackground = !isMicrotext && !attrs.height
+
+  const handleContextMenu = (e: React.MouseEvent) => {
+    e.preventDefault()
+    e.stopPropagation()
+    setContextMenu({ x: e.clientX, y: e.clientY })
+  }
+
+  return (
+    <NodeViewWrapper>
+      <div
+        data-drag-handle
+        contentEditable={false}
+        onContextMenu={handleContextMenu}
+        className={cn(
+          'security-pattern-node cursor-grab select-none my-2 rounded border border-dashed px-3 py-2',
+          selected ? 'border-muted-foreground' : 'border-border'
+        )}
+        style={{
+          WebkitUserSelect: 'none',
+          userSelect: 'none',
+          minHeight: isMicrotext || isBackground ? undefined : attrs.height,
+          backgroundImage: isMicrotext
+            ? undefined
+            : `repeating-linear-gradient(135deg, ${attrs.color}22 0 1px, transparent 1px 6px)`,
+        }}
+      >
+        <div className="flex items-center gap-2 text-xs text-muted-foreground">
+          <ShieldCheck className="w-4 h-4" style={{ color: attrs.color }} />
+          <select
+            value={isBackground ? 'background' : attrs.variant}
+            onChange={(e) => {
+              const value = e.target.value
+              if (value === 'background') {
+                updateAttributes({ variant: 'guilloche', height: 0 })
+              } else {
+                updateAttributes({ variant: value, height: attrs.height || 80 })
+              }
+            }}
+            className="bg-transparent text-xs outline-none"
+          >
+            <option value="guilloche">{t('editor.security_pattern.guilloche')}</option>
+            <option value="background">{t('editor.security_pattern.background')}</option>
+            <option value="microtext">{t('editor.security_pattern.microtext')}</option>
+          </select>
+          {isMicrotext && (
+            <input
+              value={attrs.text}
+              onChange={(e) => updateAttributes({ text: e.target.value })}
+              placeholder="ORIGINAL"
+              className="flex-1 bg-transparent text-xs outline-none"
+            />
+          )}
+          <input
+            type="color"
+            value={attrs.color}
+            onChange={(e) => updateAttributes({ color: e.target.value })}
+            className="h-4 w-6 cursor-pointer bg-transparent"
+            aria-label={t('editor.security_pattern.color')}
+          />
+        </div>
+        <p className="mt-1 text-[10px] text-muted-foreground">{t('editor.security_pattern.hint')}</p>
+      </div>
+
+      {contextMenu && (
+        <EditorNodeContextMenu
+          x={contextMenu.x}
+          y={contextMenu.y}
+          nodeType="securityPattern"
+          onDelete={deleteNode}
+          onClose={() => setContextMenu(null)}
+        />
+      )}
+    </NodeViewWrapper>
+  )
+}
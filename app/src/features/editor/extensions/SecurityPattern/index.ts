@@ -0,0 +1,3 @@
+export { SecurityPatternExtension } from './SecurityPattern'
+export type { SecurityPatternAttrs, SecurityPatternVariant } from './SecurityPattern'
+export { SecurityPatternComponent } from './SecurityPatternComponent'
@@ -11,11 +11,19 @@ import {
   SplitSquareVertical,
   Image,
   GitBranch,
+  Repeat,
   Variable,
   Table2,
+  ShieldCheck,
+  Fingerprint,
+  FileStack,
+  Link2,
+  Hash,
+  BookOpen,
 } from 'lucide-react'
 import type { LucideIcon } from 'lucide-react'
 import type { Editor } from '@tiptap/core'
+import { useEditorSchemaStore } from '../../stores/editor-schema-store'
 
 export interface SlashCommand {
   id: string
@@ -24,6 +32,8 @@ export interface SlashCommand {
   icon: LucideIcon
   groupKey: string
   aliases?: string[]
+  /** Document node the command inserts, hidden when the server cannot render it */
+  node: string
   action: (editor: Editor) => void
 }
 
@@ -31,6 +41,7 @@ export const SLASH_COMMANDS: SlashCommand[] = [
   // Basic
   {
     id: 'text',
+    node: 'paragraph',
     titleKey: 'editor.slashCommands.text',
     descriptionKey: 'editor.slashCommands.textDesc',
     icon: Type,
@@ -40,6 +51,7 @@ export const SLASH_COMMANDS: SlashCommand[] = [
   },
   {
     id: 'heading1',
+    node: 'heading',
     titleKey: 'editor.slashCommands.heading1',
     descriptionKey: 'editor.slashCommands.heading1Desc',
     icon: Heading1,
@@ -49,6 +61,7 @@ export const SLASH_COMMANDS: SlashCommand[] = [
   },
   {
     id: 'heading2',
+    node: 'heading',
     titleKey: 'editor.slashCommands.heading2',
     descriptionKey: 'editor.slashCommands.heading2Desc',
     icon: Heading2,
@@ -58,6 +71,7 @@ export const SLASH_COMMANDS: SlashCommand[] = [
   },
   {
     id: 'heading3',
+    node: 'heading',
     titleKey: 'editor.slashCommands.heading3',
     descriptionKey: 'editor.slashCommands.heading3Desc',
     icon: Heading3,
@@ -69,6 +83,7 @@ export const SLASH_COMMANDS: SlashCommand[] = [
   // Lists
   {
     id: 'bulletList',
+    node: 'bulletList',
     titleKey: 'editor.slashCommands.bulletList',
     descriptionKey: 'editor.slashCommands.bulletListDesc',
     icon: List,
@@ -78,6 +93,7 @@ export const SLASH_COMMANDS: SlashCommand[] = [
   },
   {
     id: 'orderedList',
+    node: 'orderedList',
     titleKey: 'editor.slashCommands.orderedList',
     descriptionKey: 'editor.slashCommands.orderedListDesc',
     icon: ListOrdered,
@@ -89,6 +105,7 @@ export const SLASH_COMMANDS: SlashCommand[] = [
   // Blocks
   {
     id: 'blockquote',
+    node: 'blockquote',
     titleKey: 'editor.slashCommands.blockquote',
     descriptionKey: 'editor.slashCommands.blockquoteDesc',
     icon: Quote,
@@ -98,6 +115,7 @@ export const SLASH_COMMANDS: SlashCommand[] = [
   },
   {
     id: 'codeBlock',
+    node: 'codeBlock',
     titleKey: 'editor.slashCommands.codeBlock',
     descriptionKey: 'editor.slashCommands.codeBlockDesc',
     icon: Code,
@@ -107,6 +125,7 @@ export const SLASH_COMMANDS: SlashCommand[] = [
   },
   {
     id: 'divider',
+    node: 'horizontalRule',
     titleKey: 'editor.slashCommands.divider',
     descriptionKey: 'editor.slashCommands.dividerDesc',
     icon: Minus,
@@ -116,6 +135,7 @@ export const SLASH_COMMANDS: SlashCommand[] = [
   },
   {
     id: 'pageBreak',
+    node: 'pageBreak',
     titleKey: 'editor.slashCommands.pageBreak',
     descriptionKey: 'editor.slashCommands.pageBreakDesc',
     icon: SplitSquareVertical,
@@ -123,8 +143,69 @@ export const SLASH_COMMANDS: SlashCommand[] = [
     aliases: ['page', 'break', 'salto', 'pagina'],
     action: (editor) => editor.chain().focus().setPageBreak().run(),
   },
+  {
+    id: 'pageNumber',
+    node: 'pageNumber',
+    titleKey: 'editor.slashCommands.pageNumber',
+    descriptionKey: 'editor.slashCommands.pageNumberDesc',
+    icon: Hash,
+    groupKey: 'editor.slashCommands.groups.documents',
+    aliases: ['page', 'pagina', 'página', 'number', 'numero'],
+    action: (editor) => editor.chain().focus().setPageNumber('current').run(),
+  },
+  {
+    id: 'totalPages',
+    node: 'pageNumber',
+    titleKey: 'editor.slashCommands.totalPages',
+    descriptionKey: 'editor.slashCommands.totalPagesDesc',
+    icon: BookOpen,
+    groupKey: 'editor.slashCommands.groups.documents',
+    aliases: ['pages', 'paginas', 'páginas', 'total', 'count'],
+    action: (editor) => editor.chain().focus().setPageNumber('total').run(),
+  },
+  {
+    id: 'guilloche',
+    node: 'securityPattern',
+    titleKey: 'editor.slashCommands.guilloche',
+    descriptionKey: 'editor.slashCommands.guillocheDesc',
+    icon: ShieldCheck,
+    groupKey: 'editor.slashCommands.groups.documents',
+    aliases: ['security', 'seguridad', 'guilloche', 'certificate', 'certificado'],
+    action: (editor) => editor.chain().focus().setSecurityPattern({ variant: 'guilloche' }).run(),
+  },
+  {
+    id: 'microtext',
+    node: 'securityPattern',
+    titleKey: 'editor.slashCommands.microtext',
+    descriptionKey: 'editor.slashCommands.microtextDesc',
+    icon: Fingerprint,
+    groupKey: 'editor.slashCommands.groups.documents',
+    aliases: ['security', 'seguridad', 'microtexto', 'microprint'],
+    action: (editor) => editor.chain().focus().setSecurityPattern({ variant: 'microtext' }).run(),
+  },
+  {
+    id: 'externalPdf',
+    node: 'externalPdf',
+    titleKey: 'editor.slashCommands.externalPdf',
+    descriptionKey: 'editor.slashCommands.externalPdfDesc',
+    icon: FileStack,
+    groupKey: 'editor.slashCommands.groups.documents',
+    aliases: ['pdf', 'annex', 'anexo', 'attachment', 'adjunto'],
+    action: (editor) => editor.chain().focus().setExternalPdf().run(),
+  },
+  {
+    id: 'references',
+    node: 'references',
+    titleKey: 'editor.slashCommands.references',
+    descriptionKey: 'editor.slashCommands.referencesDesc',
+    icon: Link2,
+    groupKey: 'editor.slashCommands.groups.documents',
+    aliases: ['links', 'enlaces', 'referencias', 'bibliography', 'bibliografia'],
+    action: (editor) => editor.chain().focus().setReferences().run(),
+  },
   {
     id: 'table',
+    node: 'table',
     titleKey: 'editor.slashCommands.table',
     descriptionKey: 'editor.slashCommands.tableDesc',
     icon: Table2,
@@ -137,6 +218,7 @@ export const SLASH_COMMANDS: SlashCommand[] = [
   // Media
   {
     id: 'image',
+    node: 'customImage',
     titleKey: 'editor.slashCommands.image',
     descriptionKey: 'editor.slashCommands.imageDesc',
     icon: Image,
@@ -153,6 +235,7 @@ export const SLASH_COMMANDS: SlashCommand[] = [
   // Documents
   {
     id: 'conditional',
+    node: 'conditional',
     titleKey: 'editor.slashCommands.conditional',
     descriptionKey: 'editor.slashCommands.conditionalDesc',
     icon: GitBranch,
@@ -162,8 +245,21 @@ export const SLASH_COMMANDS: SlashCommand[] = [
       editor.chain().focus().setConditional({}).run()
     },
   },
+  {
+    id: 'repeater',
+    node: 'repeater',
+    titleKey: 'editor.slashCommands.repeater',
+    descriptionKey: 'editor.slashCommands.repeaterDesc',
+    icon: Repeat,
+    groupKey: 'editor.slashCommands.groups.documents',
+    aliases: ['loop', 'repeat', 'each', 'repetir', 'bucle'],
+    action: (editor) => {
+      editor.chain().focus().setRepeater({}).run()
+    },
+  },
   {
     id: 'variable',
+    node: 'injector',
     titleKey: 'editor.slashCommands.variable',
     descriptionKey: 'editor.slashCommands.variableDesc',
     icon: Variable,
@@ -178,9 +274,11 @@ export const SLASH_COMMANDS: SlashCommand[] = [
 
 export const filterCommands = (query: string, t: (key: string) => string, editor?: Editor): SlashCommand[] => {
   const isInTable = editor?.isActive('table')
+  const { isNodeSupported } = useEditorSchemaStore.getState()
+  const supportedCommands = SLASH_COMMANDS.filter(cmd => isNodeSupported(cmd.node))
   const baseCommands = isInTable
-    ? SLASH_COMMANDS.filter(cmd => !['heading1', 'heading2', 'heading3'].includes(cmd.id))
-    : SLASH_COMMANDS
+    ? supportedCommands.filter(cmd => !['heading1', 'heading2', 'heading3'].includes(cmd.id))
+    : supportedCommands
 
   if (!query) return baseCommands
 
@@ -0,0 +1,244 @@
+import { useCallback, useState } from 'react'
+import { useTranslation } from 'react-i18next'
+import * as DialogPrimitive from '@radix-ui/react-dialog'
+import { Calculator, Plus, Trash2, X } from 'lucide-react'
+import { cn } from '@/lib/utils'
+import { Button } from '@/components/ui/button'
+import { Label } from '@/components/ui/label'
+import { Input } from '@/components/ui/input'
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from '@/components/ui/select'
+import {
+  AGGREGATE_FUNCTIONS,
+  type AggregateFunction,
+  type CalculatedColumn,
+  type TableAggregate,
+  type TableInjectorAttrs,
+} from './types'
+
+interface TableCalculationsPanelProps {
+  open: boolean
+  onOpenChange: (open: boolean) => void
+  attrs: TableInjectorAttrs
+  /** Keys of the columns declared by the injectable metadata */
+  columnKeys: string[]
+  onApply: (attrs: Record<string, unknown>) => void
+}
+
+export function TableCalculationsPanel({ open, onOpenChange, attrs, columnKeys, onApply }: TableCalculationsPanelProps) {
+  const { t } = useTranslation()
+  const [columns, setColumns] = useState<CalculatedColumn[]>([])
+  const [aggregates, setAggregates] = useState<TableAggregate[]>([])
+  const [footerLabel, setFooterLabel] = useState('')
+
+  // Load current calculations when dialog opens (store previous props pattern)
+  const [prevOpen, setPrevOpen] = useState(open)
+  if (open !== prevOpen) {
+    setPrevOpen(open)
+    if (open) {
+      setColumns(attrs.calculatedColumns ?? [])
+      setAggregates(attrs.aggregates ?? [])
+      setFooterLabel(attrs.footerLabel ?? '')
+    }
+  }
+
+  const aggregateColumns = [...columnKeys, ...columns.map((col) => col.key).filter(Boolean)]
+
+  const updateColumn = useCallback((index: number, patch: Partial<CalculatedColumn>) => {
+    setColumns((prev) => prev.map((col, i) => (i === index ? { ...col, ...patch } : col)))
+  }, [])
+
+  const updateAggregate = useCallback((index: number, patch: Partial<TableAggregate>) => {
+    setAggregates((prev) => prev.map((agg, i) => (i === index ? { ...agg, ...patch } : agg)))
+  }, [])
+
+  const handleApply = useCallback(() => {
+    const cleanColumns = columns
+      .map((col) => ({ ...col, key: col.key.trim(), expression: col.expression.trim() }))
+      .filter((col) => col.key && col.expression)
+    const cleanAggregates = aggregates.filter((agg) => agg.column)
+    onApply({
+      calculatedColumns: cleanColumns.length > 0 ? cleanColumns : null,
+      aggregates: cleanAggregates.length > 0 ? cleanAggregates : null,
+      footerLabel: footerLabel.trim() || null,
+    })
+    onOpenChange(false)
+  }, [columns, aggregates, footerLabel, onApply, onOpenChange])
+
+  return (
+    <DialogPrimitive.Root open={open} onOpenChange={onOpenChange}>
+      <DialogPrimitive.Portal>
+        <DialogPrimitive.Overlay className="fixed inset-0 z-50 bg-black/80 data-[state=open]:animate-in data-[state=closed]:animate-out data-[state=closed]:fade-out-0 data-[state=open]:fade-in-0" />
+        <DialogPrimitive.Content
+          aria-describedby={undefined}
+          className={cn(
+            'fixed left-[50%] top-[50%] z-50 w-full max-w-2xl translate-x-[-50%] translate-y-[-50%] border border-border bg-background p-0 shadow-lg duration-200',
+            'data-[state=open]:animate-in data-[state=closed]:animate-out data-[state=closed]:fade-out-0 data-[state=open]:fade-in-0 data-[state=closed]:zoom-out-95 data-[state=open]:zoom-in-95'
+          )}
+        >
+          {/* Header */}
+          <div className="flex items-start justify-between border-b border-border p-6">
+            <div className="flex items-center gap-2">
+              <Calculator className="h-5 w-5 text-muted-foreground" />
+              <DialogPrimitive.Title className="font-mono text-sm font-medium uppercase tracking-widest text-foreground">
+                {t('editor.tableInjector.calculationsTitle', 'Calculations')}
+              </DialogPrimitive.Title>
+            </div>
+            <DialogPrimitive.Close className="text-muted-foreground transition-colors hover:text-foreground">
+              <X className="h-5 w-5" />
+              <span className="sr-only">Close</span>
+            </DialogPrimitive.Close>
+          </div>
+
+          {/* Content */}
+          <div className="max-h-[60vh] space-y-6 overflow-y-auto p-6">
+            {/* Calculated columns */}
+            <div className="grid gap-2">
+              <Label>{t('editor.tableInjector.calculatedColumns', 'Calculated columns')}</Label>
+              <p className="text-xs text-muted-foreground">
+                {t('editor.tableInjector.expressionHint', 'Use column keys in expressions, e.g. qty * unitPrice')}
+                {columnKeys.length > 0 && `: ${columnKeys.join(', ')}`}
+              </p>
+              {columns.map((col, index) => (
+                <div key={index} className="flex items-center gap-2">
+                  <Input
+                    value={col.label}
+                    onChange={(e) => updateColumn(index, { label: e.target.value })}
+                    placeholder={t('editor.tableInjector.columnLabel', 'Label')}
+                    className="h-8 w-28 text-xs"
+                  />
+                  <Input
+                    value={col.key}
+                    onChange={(e) => updateColumn(index, { key: e.target.value })}
+                    placeholder={t('editor.tableInjector.columnKey', 'Key')}
+                    className="h-8 w-24 font-mono text-xs"
+                  />
+                  <Input
+                    value={col.expression}
+                    onChange={(e) => updateColumn(index, { expression: e.target.value })}
+                    placeholder="qty * unitPrice"
+                    className="h-8 flex-1 font-mono text-xs"
+                    aria-label={t('editor.tableInjector.expression', 'Expression')}
+                  />
+                  <Input
+                    value={col.format ?? ''}
+                    onChange={(e) => updateColumn(index, { format: e.target.value || undefined })}
+                    placeholder="%.2f"
+                    className="h-8 w-16 font-mono text-xs"
+                    aria-label={t('editor.tableInjector.format', 'Format')}
+                  />
+                  <Button
+                    variant="ghost"
+                    size="icon"
+                    className="h-8 w-8 text-destructive hover:text-destructive"
+                    onClick={() => setColumns((prev) => prev.filter((_, i) => i !== index))}
+                  >
+                    <Trash2 className="h-4 w-4" />
+                  </Button>
+                </div>
+              ))}
+              <Button
+                variant="outline"
+                size="sm"
+                className="w-fit"
+                onClick={() => setColumns((prev) => [...prev, { key: '', label: '', expression: '' }])}
+              >
+                <Plus className="h-3 w-3" />
+                {t('editor.tableInjector.addColumn', 'Add column')}
+              </Button>
+            </div>
+
+            {/* Aggregates */}
+            <div className="grid gap-2">
+              <Label>{t('editor.tableInjector.aggregates', 'Footer totals')}</Label>
+              {aggregates.map((agg, index) => (
+                <div key={index} className="flex items-center gap-2">
+                  <Select value={agg.column} onValueChange={(v) => updateAggregate(index, { column: v })}>
+                    <SelectTrigger className="h-8 flex-1 font-mono text-xs">
+                      <SelectValue placeholder={t('editor.tableInjector.selectColumn', 'Select column')} />
+                    </SelectTrigger>
+                    <SelectContent>
+                      {aggregateColumns.map((key) => (
+                        <SelectItem key={key} value={key}>
+                          {key}
+                        </SelectItem>
+                      ))}
+                    </SelectContent>
+                  </Select>
+                  <Select
+                    value={agg.function}
+                    onValueChange={(v) => updateAggregate(index, { function: v as AggregateFunction })}
+                  >
+                    <SelectTrigger className="h-8 w-32 text-xs">
+                      <SelectValue />
+                    </SelectTrigger>
+                    <SelectContent>
+                      {AGGREGATE_FUNCTIONS.map((fn) => (
+                        <SelectItem key={fn} value={fn}>
+                          {t(`editor.tableInjector.functions.${fn}`, fn)}
+                        </SelectItem>
+                      ))}
+                    </SelectContent>
+                  </Select>
+                  <Button
+                    variant="ghost"
+                    size="icon"
+                    className="h-8 w-8 text-destructive hover:text-destructive"
+                    onClick={() => setAggregates((prev) => prev.filter((_, i) => i !== index))}
+                  >
+                    <Trash2 className="h-4 w-4" />
+                  </Button>
+                </div>
+              ))}
+              <Button
+                variant="outline"
+                size="sm"
+                className="w-fit"
+                onClick={() => setAggregates((prev) => [...prev, { column: '', function: 'sum' }])}
+              >
+                <Plus className="h-3 w-3" />
+                {t('editor.tableInjector.addAggregate', 'Add total')}
+              </Button>
+            </div>
+
+            {/* Footer label */}
+            {aggregates.length > 0 && (
+              <div className="grid gap-2">
+                <Label>{t('editor.tableInjector.footerLabel', 'Footer label')}</Label>
+                <Input
+                  value={footerLabel}
+                  onChange={(e) => setFooterLabel(e.target.value)}
+                  placeholder={t('editor.tableInjector.footerLabelPlaceholder', 'Total')}
+                  className="h-9"
+                />
+              </div>
+            )}
+          </div>
+
+          {/* Footer */}
+          <div className="flex justify-end gap-3 border-t border-border p-6">
+            <button
+              type="button"
+              onClick={() => onOpenChange(false)}
+              className="rounded-none border border-border bg-background px-6 py-2.5 font-mono text-xs uppercase tracking-wider text-muted-foreground transition-colors hover:border-foreground hover:text-foreground"
+            >
+              {t('common.cancel', 'Cancel')}
+            </button>
+            <button
+              type="button"
+              onClick={handleApply}
+              className="rounded-none bg-foreground px-6 py-2.5 font-mono text-xs uppercase tracking-wider text-background transition-colors hover:bg-foreground/90"
+            >
+              {t('common.apply', 'Apply')}
+            </button>
+          </div>
+        </DialogPrimitive.Content>
+      </DialogPrimitive.Portal>
+    </DialogPrimitive.Root>
+  )
+}
@@ -1,7 +1,7 @@
 import { useCallback, useMemo, useState } from 'react'
 import { NodeViewWrapper, type NodeViewProps } from '@tiptap/react'
 import { useTranslation } from 'react-i18next'
-import { Table2, Settings, Trash2, AlertTriangle } from 'lucide-react'
+import { Table2, Settings, Trash2, AlertTriangle, Calculator } from 'lucide-react'
 import { Button } from '@/components/ui/button'
 import {
   Tooltip,
@@ -9,6 +9,7 @@ import {
   TooltipTrigger,
 } from '@/components/ui/tooltip'
 import { TableStylesPanel } from '../Table/TableStylesPanel'
+import { TableCalculationsPanel } from './TableCalculationsPanel'
 import type { TableInjectorAttrs } from './types'
 import type { TableColumnMeta } from '../../types/variables'
 import {
@@ -19,6 +20,7 @@ import {
 export function TableInjectorComponent({ node, editor, selected, deleteNode, updateAttributes }: NodeViewProps) {
   const { t, i18n } = useTranslation()
   const [stylesOpen, setStylesOpen] = useState(false)
+  const [calculationsOpen, setCalculationsOpen] = useState(false)
   const attrs = node.attrs as TableInjectorAttrs
 
   // Get the variable from store to access metadata
@@ -34,6 +36,8 @@ export function TableInjectorComponent({ node, editor, selected, deleteNode, upd
     return variable.metadata.columns as TableColumnMeta[]
   }, [variable?.metadata?.columns])
 
+  const calculatedColumns = attrs.calculatedColumns ?? []
+
   // Get column label for current language
   const getColumnLabel = useCallback(
     (col: TableColumnMeta) => {
@@ -82,6 +86,22 @@ export function TableInjectorComponent({ node, editor, selected, deleteNode, upd
 
         {/* Actions */}
         <div className="flex items-center gap-1">
+          <Tooltip>
+            <TooltipTrigger asChild>
+              <Button
+                variant="ghost"
+                size="icon"
+                className="h-8 w-8"
+                onClick={() => setCalculationsOpen(true)}
+              >
+                <Calculator className="h-4 w-4" />
+              </Button>
+            </TooltipTrigger>
+            <TooltipContent>
+              {t('editor.tableInjector.editCalculations', 'Calculations')}
+            </TooltipContent>
+          </Tooltip>
+
           <Tooltip>
             <TooltipTrigger asChild>
               <Button
@@ -117,7 +137,7 @@ export function TableInjectorComponent({ node, editor, selected, deleteNode, upd
       </div>
 
       {/* Column headers preview */}
-      {columns.length > 0 && (
+      {(columns.length > 0 || calculatedColumns.length > 0) && (
         <div className="mt-3 pt-3 border-t border-dashed border-muted-foreground/20">
           <div className="flex gap-1 overflow-x-auto pb-2">
             {columns.map((col) => (
@@ -128,12 +148,22 @@ export function TableInjectorComponent({ node, editor, selected, deleteNode, upd
                 {getColumnLabel(col)}
               </div>
             ))}
+            {calculatedColumns.map((col) => (
+              <div
+                key={`calc-${col.key}`}
+                className="flex-shrink-0 flex items-center gap-1 px-3 py-1.5 bg-primary/10 rounded text-xs font-medium text-primary border border-primary/20"
+                title={col.expression}
+              >
+                <Calculator className="h-3 w-3" />
+                {col.label || col.key}
+              </div>
+            ))}
           </div>
         </div>
       )}
 
       {/* Preview hint */}
-      <div className={`${columns.length > 0 ? 'mt-2' : 'mt-3 pt-3 border-t border-dashed border-muted-foreground/20'}`}>
+      <div className={`${columns.length > 0 || calculatedColumns.length > 0 ? 'mt-2' : 'mt-3 pt-3 border-t border-dashed border-muted-foreground/20'}`}>
         <div className="text-xs text-muted-foreground text-center">
           {t(
             'editor.tableInjector.previewHint',
@@ -142,6 +172,15 @@ export function TableInjectorComponent({ node, editor, selected, deleteNode, upd
         </div>
       </div>
 
+      {/* Calculations Panel */}
+      <TableCalculationsPanel
+        open={calculationsOpen}
+        onOpenChange={setCalculationsOpen}
+        attrs={attrs}
+        columnKeys={columns.map((col) => col.key)}
+        onApply={updateAttributes}
+      />
+
       {/* Styles Panel */}
       <TableStylesPanel
         editor={editor}
@@ -52,6 +52,10 @@ export const TableInjectorExtension = Node.create({
       bodyFontWeight: { default: null },
       bodyTextColor: { default: null },
       bodyTextAlign: { default: null },
+      // Calculated columns and footer aggregates
+      calculatedColumns: { default: null },
+      aggregates: { default: null },
+      footerLabel: { default: null },
     }
   },
 
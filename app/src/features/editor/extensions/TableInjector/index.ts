@@ -1,4 +1,10 @@
 export { TableInjectorExtension } from './TableInjectorExtension'
 export { TableInjectorComponent } from './TableInjectorComponent'
 
-export type { TableInjectorAttrs, TableInjectorOptions } from './types'
+export type {
+  TableInjectorAttrs,
+  TableInjectorOptions,
+  CalculatedColumn,
+  TableAggregate,
+  AggregateFunction,
+} from './types'
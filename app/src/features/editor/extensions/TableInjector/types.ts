@@ -10,6 +10,28 @@ export interface TableInjectorAttrs {
   // Style overrides (user can customize even though content is dynamic)
   headerStyles?: Partial<TableStylesAttrs>
   bodyStyles?: Partial<TableStylesAttrs>
+  // Derived columns and footer totals evaluated by the renderer
+  calculatedColumns?: CalculatedColumn[] | null
+  aggregates?: TableAggregate[] | null
+  footerLabel?: string | null
+}
+
+// Column appended to the injected table, computed per row from an expr-lang expression
+export interface CalculatedColumn {
+  key: string
+  label: string
+  expression: string // e.g. "qty * unitPrice"
+  format?: string
+}
+
+export type AggregateFunction = 'sum' | 'avg' | 'min' | 'max' | 'count'
+
+export const AGGREGATE_FUNCTIONS: AggregateFunction[] = ['sum', 'avg', 'min', 'max', 'count']
+
+// Footer cell summarizing a column
+export interface TableAggregate {
+  column: string
+  function: AggregateFunction
 }
 
 // Options for the TableInjector extension
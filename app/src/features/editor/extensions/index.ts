@@ -4,6 +4,11 @@ export { MentionExtension, MentionList } from './Mentions'
 export { ConditionalExtension, ConditionalComponent } from './Conditional'
 export { ImageExtension, ImageComponent, ImageAlignSelector } from './Image'
 export { PageBreakHR, PageBreakHRComponent } from './PageBreak'
+export { SecurityPatternExtension, SecurityPatternComponent } from './SecurityPattern'
+export { PageNumberExtension, PageNumberComponent } from './PageNumber'
+export { ExternalPdfExtension, ExternalPdfComponent } from './ExternalPdf'
+export { ReferencesExtension, ReferencesComponent } from './References'
+export { RepeaterExtension, RepeaterComponent } from './Repeater'
 export {
   SlashCommandsExtension,
   slashCommandsSuggestion,
@@ -37,6 +42,11 @@ export type {
   ImageAttributes,
   ImageAlignOption,
 } from './Image'
+export type { SecurityPatternAttrs, SecurityPatternVariant } from './SecurityPattern'
+export type { PageNumberDisplay } from './PageNumber'
+export type { ExternalPdfAttrs, ExternalPdfMode } from './ExternalPdf'
+export type { ReferencesAttrs } from './References'
+export type { RepeaterAttrs } from './Repeater'
 export type { SlashCommand, SlashCommandsOptions } from './SlashCommands'
 export type { TableStylesAttrs, TableAttrs, TableCellAttrs } from './Table'
 export type { TableInjectorAttrs, TableInjectorOptions } from './TableInjector'
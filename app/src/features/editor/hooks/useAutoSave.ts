@@ -7,6 +7,7 @@
 
 import { useCallback, useEffect, useMemo, useRef, useState } from 'react'
 import type { Editor } from '@tiptap/core'
+import axios from 'axios'
 import { versionsApi } from '@/features/templates/api/templates-api'
 import { exportDocument } from '../services/document-export'
 import { useDocumentHeaderStore } from '../stores/document-header-store'
@@ -23,10 +24,23 @@ export interface AutoSaveState {
   isDirty: boolean
 }
 
+/**
+ * Raised when the version was saved elsewhere since it was loaded.
+ * Retrying cannot help: the editor has to reload the latest content.
+ */
+export class SaveConflictError extends Error {
+  constructor() {
+    super('The version was changed elsewhere since it was loaded')
+    this.name = 'SaveConflictError'
+  }
+}
+
 export interface UseAutoSaveOptions {
   editor: Editor | null
   templateId: string
   versionId: string
+  /** Revision of the loaded version; each save sends it and advances it */
+  revision?: number
   enabled: boolean
   debounceMs?: number
   meta?: Partial<DocumentMeta>
@@ -46,6 +60,7 @@ export function useAutoSave({
   editor,
   templateId,
   versionId,
+  revision,
   enabled,
   debounceMs = DEFAULT_DEBOUNCE_MS,
   meta,
@@ -60,17 +75,29 @@ export function useAutoSave({
   const retryCountRef = useRef(0)
   const isInitializedRef = useRef(false)
   const savePromiseRef = useRef<Promise<void> | null>(null)
+  const revisionRef = useRef(revision)
+
+  // Adopt the revision of a freshly loaded version; saves advance it from their responses.
+  useEffect(() => {
+    revisionRef.current = revision
+  }, [revision, versionId])
 
   const pageSize = usePaginationStore((s) => s.pageSize)
   const margins = usePaginationStore((s) => s.margins)
-  const pagination = useMemo(() => ({ pageSize, margins }), [pageSize, margins])
+  const pageStamp = usePaginationStore((s) => s.pageStamp)
+  const layoutVariants = usePaginationStore((s) => s.layoutVariants)
+  const overlays = usePaginationStore((s) => s.overlays)
+  const pagination = useMemo(
+    () => ({ pageSize, margins, pageStamp, layoutVariants, overlays }),
+    [pageSize, margins, pageStamp, layoutVariants, overlays]
+  )
 
   const scheduleSaveRef = useRef<(() => void) | null>(null)
 
-  // Subscribe to surface store changes outside React's selector system.
+  // Subscribe to surface and page store changes outside React's selector system.
   // These stores don't drive rendering — they only trigger auto-save.
   useEffect(() => {
-    const unsubs = [useDocumentHeaderStore, useDocumentFooterStore].map((store) =>
+    const unsubs = [useDocumentHeaderStore, useDocumentFooterStore, usePaginationStore].map((store) =>
       store.subscribe(() => {
         scheduleSaveRef.current?.()
       })
@@ -96,7 +123,8 @@ export function useAutoSave({
   }, [enabled])
 
   const performSave = useCallback(() => {
-    if (!editor || !enabled) {
+    const baseRevision = revisionRef.current
+    if (!editor || !enabled || baseRevision === undefined) {
       return Promise.resolve()
     }
 
@@ -116,6 +144,11 @@ export function useAutoSave({
               description: meta?.description,
               language: meta?.language || 'es',
               customFields: meta?.customFields,
+              renderer: meta?.renderer,
+              allowDegradedRender: meta?.allowDegradedRender,
+              unitSystem: meta?.unitSystem,
+              timeZone: meta?.timeZone,
+              holidays: meta?.holidays,
             }
 
             const portableDoc = exportDocument(
@@ -125,7 +158,11 @@ export function useAutoSave({
               { includeChecksum: true }
             )
 
-            await versionsApi.update(templateId, versionId, { contentStructure: portableDoc })
+            const response = await versionsApi.update(templateId, versionId, {
+              contentStructure: portableDoc,
+              revision: baseRevision,
+            })
+            revisionRef.current = response.revision
 
             setStatus('saved')
             setLastSavedAt(new Date())
@@ -139,9 +176,12 @@ export function useAutoSave({
 
             return
           } catch (err) {
-            const saveError = err instanceof Error ? err : new Error('Save failed')
+            const conflict = axios.isAxiosError(err) && err.response?.status === 409
+            const saveError = conflict
+              ? new SaveConflictError()
+              : err instanceof Error ? err : new Error('Save failed')
 
-            if (attempt < MAX_RETRIES) {
+            if (!conflict && attempt < MAX_RETRIES) {
               retryCountRef.current = attempt + 1
               await new Promise((resolve) => setTimeout(resolve, 1000))
               continue
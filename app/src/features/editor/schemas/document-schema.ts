@@ -40,6 +40,11 @@ export const DocumentMetaSchema = z.object({
   description: z.string().optional(),
   language: LanguageSchema,
   customFields: z.record(z.string(), z.string()).optional(),
+  renderer: z.string().optional(),
+  allowDegradedRender: z.boolean().optional(),
+  unitSystem: z.enum(['metric', 'imperial']).optional(),
+  timeZone: z.string().optional(),
+  holidays: z.array(z.string().regex(/^\d{4}-\d{2}-\d{2}$/)).optional(),
 })
 
 // =============================================================================
@@ -53,11 +58,46 @@ export const PageMarginsSchema = z.object({
   right: z.number().min(0),
 })
 
+export const PageStampSchema = z.object({
+  position: z.enum(['top-left', 'top-right', 'bottom-left', 'bottom-center', 'bottom-right']),
+  format: z.string(),
+})
+
+export const LayoutVariantsSchema = z.object({
+  paperSizes: z.array(z.enum(['A4', 'LETTER', 'LEGAL'])).optional(),
+  marginPresets: z.array(z.enum(['narrow', 'normal', 'wide'])).optional(),
+  fontScales: z.array(z.number().min(0.5).max(2)).optional(),
+})
+
+const PageStampPositionSchema = z.enum(['top-left', 'top-right', 'bottom-left', 'bottom-center', 'bottom-right'])
+
+export const OverlaysSchema = z.object({
+  watermark: z.string().max(100).optional(),
+  stamp: z
+    .object({
+      text: z.string().min(1).max(40),
+      position: PageStampPositionSchema.optional(),
+      color: z.string().regex(/^#[0-9A-Fa-f]{6}$/).optional(),
+    })
+    .optional(),
+  bates: z
+    .object({
+      prefix: z.string().max(20).optional(),
+      start: z.number().int().min(0).optional(),
+      digits: z.number().int().min(0).max(12).optional(),
+      position: PageStampPositionSchema.optional(),
+    })
+    .optional(),
+})
+
 export const PageConfigSchema = z.object({
   formatId: PageFormatIdSchema,
   width: z.number().positive('El ancho debe ser positivo'),
   height: z.number().positive('La altura debe ser positiva'),
   margins: PageMarginsSchema,
+  pageStamp: PageStampSchema.optional(),
+  layoutVariants: LayoutVariantsSchema.optional(),
+  overlays: OverlaysSchema.optional(),
 })
 
 // =============================================================================
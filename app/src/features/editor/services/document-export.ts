@@ -31,7 +31,7 @@ import { deriveSurfaceEnabled } from '../utils/document-surface'
 // =============================================================================
 
 interface EditorStoreData {
-  pagination: Pick<PaginationStore, 'pageSize' | 'margins'>
+  pagination: Pick<PaginationStore, 'pageSize' | 'margins'> & Partial<Pick<PaginationStore, 'pageStamp' | 'layoutVariants' | 'overlays'>>
 }
 
 // =============================================================================
@@ -179,13 +179,16 @@ function getPageFormatId(pageSize: { width: number; height: number }): PageConfi
  * Converts pagination store config to PageConfig format
  */
 function extractPageConfig(pagination: EditorStoreData['pagination']): PageConfig {
-  const { pageSize, margins } = pagination
+  const { pageSize, margins, pageStamp, layoutVariants, overlays } = pagination
 
   return {
     formatId: getPageFormatId(pageSize),
     width: pageSize.width,
     height: pageSize.height,
     margins: { ...margins },
+    ...(pageStamp ? { pageStamp: { ...pageStamp } } : {}),
+    ...(layoutVariants ? { layoutVariants: { ...layoutVariants } } : {}),
+    ...(overlays ? { overlays: { ...overlays } } : {}),
   }
 }
 
@@ -18,7 +18,7 @@ import type {
   BackendVariable,
   VariableResolutionResult,
 } from '../types/document-format'
-import type { PageSize } from '../types'
+import type { LayoutVariants, Overlays, PageSize, PageStamp } from '../types'
 import { DOCUMENT_FORMAT_VERSION } from '../types/document-format'
 import { validateDocument, isVersionCompatible, compareVersions } from '../schemas/document-schema'
 import { validateDocumentSemantics } from './document-validator'
@@ -35,6 +35,9 @@ interface ImportStoreActions {
   setPaginationConfig: (config: Partial<{
     pageSize: PageSize
     margins: PageConfig['margins']
+    pageStamp: PageStamp | null
+    layoutVariants: LayoutVariants | null
+    overlays: Overlays | null
   }>) => void
 }
 
@@ -198,6 +201,9 @@ function restorePageConfig(
   actions.setPaginationConfig({
     pageSize,
     margins: { ...pageConfig.margins },
+    pageStamp: pageConfig.pageStamp ? { ...pageConfig.pageStamp } : null,
+    layoutVariants: pageConfig.layoutVariants ? { ...pageConfig.layoutVariants } : null,
+    overlays: pageConfig.overlays ? { ...pageConfig.overlays } : null,
   })
 }
 
@@ -0,0 +1,45 @@
+import { create } from 'zustand'
+import { editorSchemaApi } from '../api/editor-schema-api'
+import type { EditorSchema } from '../types/editor-schema'
+
+interface EditorSchemaState {
+  schema: EditorSchema | null
+  supportedNodes: Set<string> | null
+  loadSchema: () => Promise<void>
+  /** True until the schema loads, so servers without the endpoint keep every feature */
+  isNodeSupported: (type: string) => boolean
+  isMarkSupported: (type: string) => boolean
+}
+
+let pending: Promise<void> | null = null
+
+export const useEditorSchemaStore = create<EditorSchemaState>((set, get) => ({
+  schema: null,
+  supportedNodes: null,
+
+  loadSchema: () => {
+    if (get().schema) return Promise.resolve()
+    pending ??= editorSchemaApi
+      .get()
+      .then((schema) => {
+        set({ schema, supportedNodes: new Set(schema.nodes.map((n) => n.type)) })
+      })
+      .catch((error) => {
+        console.warn('[EditorSchema] Failed to load editor schema, all features enabled:', error)
+      })
+      .finally(() => {
+        pending = null
+      })
+    return pending
+  },
+
+  isNodeSupported: (type) => {
+    const { supportedNodes } = get()
+    return supportedNodes === null || supportedNodes.has(type)
+  },
+
+  isMarkSupported: (type) => {
+    const { schema } = get()
+    return schema === null || schema.marks.some((m) => m.type === type)
+  },
+}))
@@ -1,5 +1,5 @@
 import { create } from 'zustand'
-import type { PageMargins, PageSize } from '../types'
+import type { LayoutVariants, Overlays, PageMargins, PageSize, PageStamp } from '../types'
 import { PAGE_SIZES, DEFAULT_MARGINS } from '../types'
 
 // =============================================================================
@@ -9,11 +9,17 @@ import { PAGE_SIZES, DEFAULT_MARGINS } from '../types'
 export interface PaginationState {
   pageSize: PageSize
   margins: PageMargins
+  pageStamp: PageStamp | null
+  layoutVariants: LayoutVariants | null
+  overlays: Overlays | null
 }
 
 export interface PaginationActions {
   setPageSize: (size: PageSize) => void
   setMargins: (margins: PageMargins) => void
+  setPageStamp: (pageStamp: PageStamp | null) => void
+  setLayoutVariants: (layoutVariants: LayoutVariants | null) => void
+  setOverlays: (overlays: Overlays | null) => void
   reset: () => void
 }
 
@@ -26,6 +32,9 @@ export type PaginationStore = PaginationState & PaginationActions
 const initialState: PaginationState = {
   pageSize: PAGE_SIZES.A4,
   margins: DEFAULT_MARGINS,
+  pageStamp: null,
+  layoutVariants: null,
+  overlays: null,
 }
 
 // =============================================================================
@@ -39,6 +48,12 @@ export const usePaginationStore = create<PaginationStore>()((set) => ({
 
   setMargins: (margins) => set({ margins }),
 
+  setPageStamp: (pageStamp) => set({ pageStamp }),
+
+  setLayoutVariants: (layoutVariants) => set({ layoutVariants }),
+
+  setOverlays: (overlays) => set({ overlays }),
+
   reset: () => set(initialState),
 }))
 
@@ -52,6 +67,9 @@ export const usePaginationStore = create<PaginationStore>()((set) => ({
 export const selectPageConfig = (state: PaginationStore) => ({
   pageSize: state.pageSize,
   margins: state.margins,
+  pageStamp: state.pageStamp,
+  layoutVariants: state.layoutVariants,
+  overlays: state.overlays,
 })
 
 /**
@@ -84,6 +84,21 @@ export interface DocumentMeta {
 
   /** Custom metadata key-value pairs */
   customFields?: Record<string, string>
+
+  /** Rendering backend registered through the SDK; omitted renders with Typst */
+  renderer?: string
+
+  /** Lets render requests ask for a degraded render that reports failed injectors, images and external PDFs */
+  allowDegradedRender?: boolean
+
+  /** Converts measurements to metric or imperial units; render requests may override it */
+  unitSystem?: 'metric' | 'imperial'
+
+  /** IANA time zone dates are shown in, such as America/Santiago; omitted keeps each value's own zone */
+  timeZone?: string
+
+  /** Holidays (YYYY-MM-DD) skipped when counting working days */
+  holidays?: string[]
 }
 
 // =============================================================================
@@ -104,6 +119,77 @@ export interface PageConfig {
 
   /** Page margins in pixels */
   margins: PageMargins
+
+  /** Page counter printed on every page, outside the header and footer */
+  pageStamp?: PageStamp
+
+  /** Layout values a render request may switch to (paper size, margins, font scale) */
+  layoutVariants?: LayoutVariants
+
+  /** Watermark, stamp and Bates numbering printed over every page; render requests may replace them */
+  overlays?: Overlays
+}
+
+export type MarginPreset = 'narrow' | 'normal' | 'wide'
+
+export interface LayoutVariants {
+  /** Paper sizes besides formatId */
+  paperSizes?: Exclude<PageFormatId, 'CUSTOM'>[]
+
+  /** Margin presets: narrow (64px), normal (96px), wide (144px) */
+  marginPresets?: MarginPreset[]
+
+  /** Font size multipliers (0.5-2) */
+  fontScales?: number[]
+}
+
+export type PageStampPosition =
+  | 'top-left'
+  | 'top-right'
+  | 'bottom-left'
+  | 'bottom-center'
+  | 'bottom-right'
+
+export interface PageStamp {
+  /** Corner or edge where the counter is printed */
+  position: PageStampPosition
+
+  /** Text with {{page}} and {{total}} placeholders (backend default: "{{page}} / {{total}}") */
+  format: string
+}
+
+export interface Overlays {
+  /** Text stamped diagonally across every page, e.g. "DRAFT" */
+  watermark?: string
+
+  /** Framed label, e.g. "COPY", printed at a corner of every page */
+  stamp?: OverlayStamp
+
+  /** Sequential page identifier, e.g. ACME000001 */
+  bates?: BatesNumbering
+}
+
+export interface OverlayStamp {
+  text: string
+
+  /** Backend default: top-right */
+  position?: PageStampPosition
+
+  /** #RRGGBB (backend default: #C62828) */
+  color?: string
+}
+
+export interface BatesNumbering {
+  prefix?: string
+
+  /** Number of the first page (backend default: 1) */
+  start?: number
+
+  /** Width the number is zero-padded to (backend default: 6) */
+  digits?: number
+
+  /** Backend default: bottom-right */
+  position?: PageStampPosition
 }
 
 
@@ -0,0 +1,25 @@
+/**
+ * Document features the server build renders (GET /editor/schema)
+ */
+export interface EditorNodeSchema {
+  type: string
+  attrs: string[]
+  /** Outputs that render the node; the others omit it */
+  outputs: string[]
+}
+
+export interface EditorMarkSchema {
+  type: string
+  attrs: string[]
+}
+
+export interface EditorSchema {
+  formatVersion: string
+  /** Changes whenever the supported nodes, marks or attrs change */
+  converterVersion: string
+  commonAttrs: string[]
+  nodes: EditorNodeSchema[]
+  marks: EditorMarkSchema[]
+  injectableTypes: string[]
+  outputs: string[]
+}
@@ -1,4 +1,4 @@
-export type ListSymbolType = 'bullet' | 'number' | 'dash' | 'roman' | 'letter'
+export type ListSymbolType = 'bullet' | 'number' | 'dash' | 'roman' | 'letter' | 'multilevel'
 
 export const LIST_SYMBOL_OPTIONS: { value: ListSymbolType; i18nKey: string; marker: string }[] = [
   { value: 'bullet', i18nKey: 'editor.listInjector.symbols.bullet', marker: '•' },
@@ -6,8 +6,12 @@ export const LIST_SYMBOL_OPTIONS: { value: ListSymbolType; i18nKey: string; mark
   { value: 'dash', i18nKey: 'editor.listInjector.symbols.dash', marker: '–' },
   { value: 'roman', i18nKey: 'editor.listInjector.symbols.roman', marker: 'i.' },
   { value: 'letter', i18nKey: 'editor.listInjector.symbols.letter', marker: 'a)' },
+  { value: 'multilevel', i18nKey: 'editor.listInjector.symbols.multilevel', marker: '1.1' },
 ]
 
+/** Symbols that number their items; these support a start number and continued numbering. */
+export const NUMBERED_LIST_SYMBOLS: ListSymbolType[] = ['number', 'roman', 'letter', 'multilevel']
+
 export interface ListInputItem {
   id: string
   value: string
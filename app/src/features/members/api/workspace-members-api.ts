@@ -13,11 +13,12 @@ export async function listWorkspaceMembers(): Promise<WorkspaceMember[]> {
   return response.data.data
 }
 
+// Adds a registered user. An unknown email is sent an invitation instead (202) and no member is returned.
 export async function inviteWorkspaceMember(
   data: InviteWorkspaceMemberRequest
-): Promise<WorkspaceMember> {
+): Promise<WorkspaceMember | null> {
   const response = await apiClient.post<WorkspaceMember>(BASE_PATH, data)
-  return response.data
+  return response.status === 202 ? null : response.data
 }
 
 export async function updateWorkspaceMemberRole(
@@ -250,6 +250,7 @@ export const versionsApi = {
   /**
    * Programa la publicación de una versión.
    * POST /api/v1/content/templates/{templateId}/versions/{versionId}/schedule-publish
+   * publishAt sin offset (YYYY-MM-DDTHH:MM) se interpreta en la zona horaria del workspace.
    */
   schedulePublish: async (
     templateId: string,
@@ -54,7 +54,7 @@ export function EditTemplateDialog({
       if (title.trim() !== template.title) {
         await updateTemplate.mutateAsync({
           templateId: template.id,
-          data: { title: title.trim() },
+          data: { title: title.trim(), revision: template.revision },
         })
       }
 
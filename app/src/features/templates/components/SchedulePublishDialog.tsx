@@ -54,7 +54,8 @@ export function SchedulePublishDialog({
   const handleSubmit = () => {
     if (!date || !time) return
 
-    const publishAt = new Date(`${date}T${time}`).toISOString()
+    // Sent without offset: the server reads it in the workspace time zone
+    const publishAt = `${date}T${time}`
     onConfirm(publishAt)
     // Reset form after successful submission
     setDate('')
@@ -130,7 +131,7 @@ export function SchedulePublishDialog({
             <p className="text-sm text-info-foreground">
               {t(
                 'templates.scheduleDialog.info',
-                'The version will be published automatically at the scheduled time. You can cancel anytime before then.'
+                'The version will be published automatically at the scheduled time, in the workspace time zone. You can cancel anytime before then.'
               )}
             </p>
           </div>
@@ -110,9 +110,10 @@ export function TemplateDetailPage() {
   const updateVersion = useUpdateVersion(templateId)
 
   const handleTitleSave = async (newTitle: string) => {
+    if (!template) return
     await updateTemplate.mutateAsync({
       templateId,
-      data: { title: newTitle },
+      data: { title: newTitle, revision: template.revision },
     })
   }
 
@@ -449,7 +450,7 @@ export function TemplateDetailPage() {
     try {
       await updateVersion.mutateAsync({
         versionId: version.id,
-        data: { name: newName },
+        data: { name: newName, revision: version.revision },
       })
       toast({
         title: t('templates.versions.renamed', 'Version renamed'),
@@ -69,6 +69,7 @@ export interface TemplateVersionDetail {
   scheduledArchiveAt?: string
   archivedAt?: string
   archivedBy?: string
+  revision: number
   createdAt: string
   updatedAt: string
   createdBy?: string
@@ -101,4 +102,5 @@ export interface UpdateVersionRequest {
   name?: string
   description?: string
   contentStructure?: PortableDocument
+  revision: number // Revision the edit is based on; 409 if it changed since
 }
@@ -10,6 +10,7 @@ import {
 } from '@/components/ui/dialog'
 import { useCreateWorkspaceInjectable } from '../hooks/useWorkspaceInjectables'
 import { InjectableForm } from './InjectableForm'
+import type { InjectableDataType } from '../types'
 
 interface CreateInjectableDialogProps {
   open: boolean
@@ -33,6 +34,8 @@ export function CreateInjectableDialog({
   const [label, setLabel] = useState('')
   const [defaultValue, setDefaultValue] = useState('')
   const [description, setDescription] = useState('')
+  const [formula, setFormula] = useState('')
+  const [dataType, setDataType] = useState<InjectableDataType>('TEXT')
   const createInjectable = useCreateWorkspaceInjectable()
 
   const handleOpenChange = useCallback(
@@ -42,6 +45,8 @@ export function CreateInjectableDialog({
         setLabel('')
         setDefaultValue('')
         setDescription('')
+        setFormula('')
+        setDataType('TEXT')
       }
       onOpenChange(isOpen)
     },
@@ -65,6 +70,8 @@ export function CreateInjectableDialog({
         label: label.trim(),
         defaultValue: defaultValue.trim(),
         description: description.trim() || undefined,
+        formula: formula.trim() || undefined,
+        dataType: formula.trim() ? dataType : 'TEXT',
       })
       handleOpenChange(false)
     } catch {
@@ -108,6 +115,10 @@ export function CreateInjectableDialog({
             onDefaultValueChange={setDefaultValue}
             description={description}
             onDescriptionChange={setDescription}
+            formula={formula}
+            onFormulaChange={setFormula}
+            dataType={dataType}
+            onDataTypeChange={setDataType}
             idPrefix="injectable"
           />
 
@@ -10,7 +10,7 @@ import {
 } from '@/components/ui/dialog'
 import { useUpdateWorkspaceInjectable } from '../hooks/useWorkspaceInjectables'
 import { InjectableForm } from './InjectableForm'
-import type { WorkspaceInjectable } from '../types'
+import type { InjectableDataType, WorkspaceInjectable } from '../types'
 
 interface EditInjectableDialogProps {
   open: boolean
@@ -29,6 +29,10 @@ export function EditInjectableDialog({
   const [label, setLabel] = useState(injectable?.label ?? '')
   const [defaultValue, setDefaultValue] = useState(injectable?.defaultValue ?? '')
   const [description, setDescription] = useState(injectable?.description ?? '')
+  const [formula, setFormula] = useState(injectable?.formula ?? '')
+  const [dataType, setDataType] = useState<InjectableDataType>(
+    injectable?.dataType ?? 'TEXT'
+  )
   const updateInjectable = useUpdateWorkspaceInjectable()
 
   async function handleSubmit(e: React.FormEvent): Promise<void> {
@@ -44,6 +48,9 @@ export function EditInjectableDialog({
           label: label.trim(),
           defaultValue: defaultValue.trim(),
           description: description.trim() || undefined,
+          formula: formula.trim(),
+          dataType: formula.trim() ? dataType : 'TEXT',
+          revision: injectable.revision,
         },
       })
       onOpenChange(false)
@@ -88,6 +95,10 @@ export function EditInjectableDialog({
             onDefaultValueChange={setDefaultValue}
             description={description}
             onDescriptionChange={setDescription}
+            formula={formula}
+            onFormulaChange={setFormula}
+            dataType={dataType}
+            onDataTypeChange={setDataType}
             idPrefix="edit-injectable"
           />
 
@@ -1,4 +1,7 @@
 import { useTranslation } from 'react-i18next'
+import type { InjectableDataType } from '../types'
+
+const DATA_TYPES: InjectableDataType[] = ['TEXT', 'NUMBER', 'DATE', 'BOOLEAN']
 
 interface InjectableFormProps {
   keyValue: string
@@ -9,6 +12,10 @@ interface InjectableFormProps {
   onDefaultValueChange: (value: string) => void
   description: string
   onDescriptionChange: (value: string) => void
+  formula: string
+  onFormulaChange: (value: string) => void
+  dataType: InjectableDataType
+  onDataTypeChange: (value: InjectableDataType) => void
   showKeyHint?: boolean
   idPrefix: string
 }
@@ -22,6 +29,10 @@ export function InjectableForm({
   onDefaultValueChange,
   description,
   onDescriptionChange,
+  formula,
+  onFormulaChange,
+  dataType,
+  onDataTypeChange,
   showKeyHint = true,
   idPrefix,
 }: InjectableFormProps): React.ReactElement {
@@ -102,6 +113,52 @@ export function InjectableForm({
         />
       </div>
 
+      <div>
+        <label htmlFor={`${idPrefix}-formula`} className={labelClassName}>
+          {t('variables.formula', 'Formula')}
+          <span className="ml-1 normal-case text-muted-foreground/50">
+            ({t('common.optional', 'optional')})
+          </span>
+        </label>
+        <textarea
+          id={`${idPrefix}-formula`}
+          value={formula}
+          onChange={(e) => onFormulaChange(e.target.value)}
+          placeholder={t(
+            'variables.formulaPlaceholder',
+            'e.g., sum(subtotal, shipping) * (1 + tax_rate)'
+          )}
+          rows={2}
+          className={`${inputClassName} resize-none font-mono text-sm`}
+        />
+        <p className="mt-1 text-xs text-muted-foreground/70">
+          {t(
+            'variables.formulaHint',
+            'Computes the value from other variables. Use sum, concat, dateDiff and conditionals; the default value applies when it fails.'
+          )}
+        </p>
+      </div>
+
+      {formula.trim() && (
+        <div>
+          <label htmlFor={`${idPrefix}-dataType`} className={labelClassName}>
+            {t('variables.dataType', 'Data Type')}
+          </label>
+          <select
+            id={`${idPrefix}-dataType`}
+            value={dataType}
+            onChange={(e) => onDataTypeChange(e.target.value as InjectableDataType)}
+            className={inputClassName}
+          >
+            {DATA_TYPES.map((type) => (
+              <option key={type} value={type}>
+                {t(`variables.dataTypes.${type}`, type)}
+              </option>
+            ))}
+          </select>
+        </div>
+      )}
+
       <div>
         <label htmlFor={`${idPrefix}-description`} className={labelClassName}>
           {t('variables.description', 'Description')}
@@ -1,16 +1,20 @@
 // Workspace Injectable types based on Swagger API specification
 
+export type InjectableDataType = 'TEXT' | 'NUMBER' | 'DATE' | 'BOOLEAN'
+
 export interface WorkspaceInjectable {
   id: string
   workspaceId: string
   key: string
   label: string
   defaultValue: string
-  dataType: 'TEXT' // Currently only TEXT is supported
+  dataType: InjectableDataType // Only TEXT unless the injectable has a formula
+  formula?: string
   description?: string
   sourceType: 'INTERNAL' | 'EXTERNAL'
   isActive: boolean
   metadata?: Record<string, unknown>
+  revision: number
   createdAt: string
   updatedAt: string
 }
@@ -24,6 +28,8 @@ export interface CreateWorkspaceInjectableRequest {
   label: string
   defaultValue: string
   description?: string
+  formula?: string
+  dataType?: InjectableDataType
   metadata?: Record<string, unknown>
 }
 
@@ -32,5 +38,8 @@ export interface UpdateWorkspaceInjectableRequest {
   label?: string
   defaultValue?: string
   description?: string
+  formula?: string // empty removes the formula
+  dataType?: InjectableDataType
   metadata?: Record<string, unknown>
+  revision: number // Revision the edit is based on; 409 if it changed since
 }
@@ -4,10 +4,7 @@ import { useAppContextStore } from '@/stores/app-context-store'
 import { useEditorEnvironmentStore } from '@/features/editor/stores'
 
 import { refreshAccessToken } from '@/lib/oidc'
-
-// API Base URL derived from base path
-const BASE_PATH = (import.meta.env.VITE_BASE_PATH || '').replace(/\/$/, '')
-const API_BASE_URL = `${BASE_PATH}/api/v1`
+import { API_BASE_URL } from '@/lib/runtime-config'
 
 // Flag to prevent multiple simultaneous refresh attempts
 let isRefreshing = false
@@ -1,10 +1,7 @@
 /**
- * Runtime auth configuration fetched from backend.
+ * Runtime auth configuration injected into index.html or fetched from backend.
  */
-
-// API Base URL derived from base path
-const BASE_PATH = (import.meta.env.VITE_BASE_PATH || '').replace(/\/$/, '')
-const API_BASE_URL = `${BASE_PATH}/api/v1`
+import { API_BASE_URL, runtimeConfig } from '@/lib/runtime-config'
 
 export interface PanelProvider {
   name: string
@@ -19,13 +16,23 @@ export interface Features {
   gallery?: boolean
 }
 
+export interface Branding {
+  title?: string
+  logoUrl?: string
+  faviconUrl?: string
+  primaryColor?: string
+}
+
 export interface AuthConfig {
   dummyAuth: boolean
+  basePath?: string
+  apiBaseUrl?: string
   panelProvider?: PanelProvider
   features?: Features
+  branding?: Branding
 }
 
-let cachedConfig: AuthConfig | null = null
+let cachedConfig: AuthConfig | null = runtimeConfig ?? null
 let fetchPromise: Promise<AuthConfig> | null = null
 
 /**
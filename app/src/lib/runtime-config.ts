@@ -0,0 +1,53 @@
+/**
+ * Client configuration injected by the backend into index.html.
+ * Absent in the Vite dev server, where it is fetched from /api/v1/config instead.
+ */
+import type { AuthConfig, Branding } from '@/lib/auth-config'
+
+declare global {
+  interface Window {
+    __PDF_FORGE_CONFIG__?: AuthConfig
+  }
+}
+
+export const runtimeConfig: AuthConfig | undefined = window.__PDF_FORGE_CONFIG__
+
+// API Base URL: injected by the backend, or derived from the build-time base path
+const BASE_PATH = (import.meta.env.VITE_BASE_PATH || '').replace(/\/$/, '')
+export const API_BASE_URL = runtimeConfig?.apiBaseUrl || `${BASE_PATH}/api/v1`
+
+/**
+ * Convert a #RRGGBB color to the "H S% L%" triplet used by the theme CSS variables.
+ */
+function hexToHslTriplet(hex: string): string | null {
+  const match = /^#([0-9a-f]{2})([0-9a-f]{2})([0-9a-f]{2})$/i.exec(hex)
+  if (!match) return null
+
+  const [r, g, b] = match.slice(1).map((c) => parseInt(c, 16) / 255)
+  const max = Math.max(r, g, b)
+  const min = Math.min(r, g, b)
+  const l = (max + min) / 2
+  let h = 0
+  let s = 0
+  if (max !== min) {
+    const d = max - min
+    s = l > 0.5 ? d / (2 - max - min) : d / (max + min)
+    if (max === r) h = (g - b) / d + (g < b ? 6 : 0)
+    else if (max === g) h = (b - r) / d + 2
+    else h = (r - g) / d + 4
+    h *= 60
+  }
+  return `${Math.round(h)} ${Math.round(s * 100)}% ${Math.round(l * 100)}%`
+}
+
+/**
+ * Apply the install branding that index.html cannot carry: the primary theme color.
+ * Title and favicon are replaced by the backend before the page loads.
+ */
+export function applyBranding(branding: Branding | undefined): void {
+  if (!branding?.primaryColor) return
+  const hsl = hexToHslTriplet(branding.primaryColor)
+  if (hsl) {
+    document.documentElement.style.setProperty('--primary', hsl)
+  }
+}
@@ -7,11 +7,14 @@ import { routeTree } from './routeTree.gen'
 import { AuthProvider } from '@/features/auth/components/AuthProvider'
 import { Toaster } from '@/components/ui/toaster'
 import { TooltipProvider } from '@/components/ui/tooltip'
+import { applyBranding, runtimeConfig } from '@/lib/runtime-config'
 import './index.css'
 
 declare const __BUILD_TIMESTAMP__: string
 console.log(`[PDF-Forge] Build: ${__BUILD_TIMESTAMP__}`)
 
+applyBranding(runtimeConfig?.branding)
+
 // Create a new router instance
 // @ts-expect-error - TanStack Router requires strictNullChecks but we have it disabled
 const router = createRouter({
@@ -4,7 +4,7 @@ import { ArrowRight, FileText, Loader2, AlertCircle } from 'lucide-react'
 import { useTranslation } from 'react-i18next'
 import { useAuthStore } from '@/stores/auth-store'
 import { loginWithCredentials, getUserInfo } from '@/lib/oidc'
-import { fetchMyRoles } from '@/features/auth/api/auth-api'
+import { fetchMe, rolesFromMe } from '@/features/auth/api/auth-api'
 import { LanguageSelector } from '@/components/common/LanguageSelector'
 import { ThemeToggle } from '@/components/common/ThemeToggle'
 
@@ -45,7 +45,7 @@ function LoginPage() {
       const tokens = await loginWithCredentials(username, password)
 
       // Store tokens
-      const { setTokens, setUserProfile, setAllRoles } = useAuthStore.getState()
+      const { setTokens, setUserProfile, setAllRoles, setFeatures } = useAuthStore.getState()
       setTokens(tokens.access_token, tokens.refresh_token, tokens.expires_in)
 
       // Get user info from OIDC provider
@@ -58,10 +58,11 @@ function LoginPage() {
         username: userInfo.preferred_username,
       })
 
-      // Fetch roles from backend API
+      // Fetch roles and feature flags from backend API
       try {
-        const roles = await fetchMyRoles()
-        setAllRoles(roles)
+        const me = await fetchMe()
+        setAllRoles(rolesFromMe(me))
+        setFeatures(me.features)
       } catch (rolesError) {
         console.warn('[Auth] Failed to fetch roles:', rolesError)
         // Continue without roles - user can still access basic features
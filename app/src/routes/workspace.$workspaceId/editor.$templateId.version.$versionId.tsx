@@ -119,9 +119,12 @@ function EditorPage() {
   const createStoreActions = useCallback(() => ({
     // eslint-disable-next-line @typescript-eslint/no-explicit-any -- Generic config type
     setPaginationConfig: (config: any) => {
-      const { pageSize, margins } = config
+      const { pageSize, margins, pageStamp, layoutVariants, overlays } = config
       if (pageSize) usePaginationStore.getState().setPageSize(pageSize)
       if (margins) usePaginationStore.getState().setMargins(margins)
+      if (pageStamp !== undefined) usePaginationStore.getState().setPageStamp(pageStamp)
+      if (layoutVariants !== undefined) usePaginationStore.getState().setLayoutVariants(layoutVariants)
+      if (overlays !== undefined) usePaginationStore.getState().setOverlays(overlays)
     },
   }), [])
 
@@ -153,6 +156,9 @@ function EditorPage() {
       pagination: {
         pageSize: usePaginationStore.getState().pageSize,
         margins: usePaginationStore.getState().margins,
+        pageStamp: usePaginationStore.getState().pageStamp,
+        layoutVariants: usePaginationStore.getState().layoutVariants,
+        overlays: usePaginationStore.getState().overlays,
       },
     }
 
@@ -233,15 +239,22 @@ function EditorPage() {
   }, [version, editorRef.current, getBackendVariables, createStoreActions])
 
   // Auto-save hook
+  const contentMeta = (version?.contentStructure as unknown as PortableDocument | undefined)?.meta
   const autoSave = useAutoSave({
     editor: editorInstance,
     templateId,
     versionId,
+    revision: version?.revision,
     enabled: isEditable && contentLoadedRef.current,
     debounceMs: 2000,
     meta: {
       title: version?.name || t('editor.document'),
       language: 'es',
+      renderer: contentMeta?.renderer,
+      allowDegradedRender: contentMeta?.allowDegradedRender,
+      unitSystem: contentMeta?.unitSystem,
+      timeZone: contentMeta?.timeZone,
+      holidays: contentMeta?.holidays,
     },
   })
 
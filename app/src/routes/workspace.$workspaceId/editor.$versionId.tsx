@@ -6,7 +6,7 @@ import { usePaginationStore } from '@/features/editor/stores'
 import { PAGE_SIZES, DEFAULT_MARGINS } from '@/features/editor'
 import { exportAndDownload, importFromFile, type ImportResult } from '@/features/editor/services'
 import { ImportValidationDialog } from '@/features/editor/components/ImportValidationDialog'
-import type { DocumentMeta, PageMargins, PageSize } from '@/features/editor/types'
+import type { DocumentMeta, LayoutVariants, Overlays, PageMargins, PageSize, PageStamp } from '@/features/editor/types'
 import { useState, useCallback, useRef, useEffect } from 'react'
 import { useTranslation } from 'react-i18next'
 
@@ -83,13 +83,28 @@ function EditorPage() {
 
     const editor = editorRef.current
     const stores = {
-      setPaginationConfig: (config: { pageSize?: PageSize; margins?: PageMargins }) => {
+      setPaginationConfig: (config: {
+        pageSize?: PageSize
+        margins?: PageMargins
+        pageStamp?: PageStamp | null
+        layoutVariants?: LayoutVariants | null
+        overlays?: Overlays | null
+      }) => {
         if (config.pageSize) {
           usePaginationStore.getState().setPageSize(config.pageSize)
         }
         if (config.margins) {
           usePaginationStore.getState().setMargins(config.margins)
         }
+        if (config.pageStamp !== undefined) {
+          usePaginationStore.getState().setPageStamp(config.pageStamp)
+        }
+        if (config.layoutVariants !== undefined) {
+          usePaginationStore.getState().setLayoutVariants(config.layoutVariants)
+        }
+        if (config.overlays !== undefined) {
+          usePaginationStore.getState().setOverlays(config.overlays)
+        }
       },
     }
 
@@ -55,6 +55,8 @@ interface AuthState {
   systemRoles: SystemRole[]
   userProfile: UserProfile | null
   allRoles: RoleEntry[]
+  // Effective feature flags of the user, from GET /me
+  features: Record<string, boolean>
   // Deduplication flags - prevent redundant API calls
   userInfoLoaded: boolean
   rolesLoaded: boolean
@@ -68,6 +70,7 @@ interface AuthState {
   setSystemRoles: (roles: SystemRole[]) => void
   setUserProfile: (profile: UserProfile | null) => void
   setAllRoles: (roles: RoleEntry[]) => void
+  setFeatures: (features: Record<string, boolean>) => void
   clearAuth: (showExpiredToast?: boolean) => void
 
   // Computed
@@ -94,6 +97,7 @@ export const useAuthStore = create<AuthState>()(
       systemRoles: [],
       userProfile: null,
       allRoles: [],
+      features: {},
       userInfoLoaded: false,
       rolesLoaded: false,
 
@@ -128,6 +132,8 @@ export const useAuthStore = create<AuthState>()(
         set({ allRoles: roles, systemRoles, rolesLoaded: true })
       },
 
+      setFeatures: (features) => set({ features }),
+
       clearAuth: (showExpiredToast = true) => {
         // Reset interceptor state to prevent stale refresh locks
         resetInterceptorState()
@@ -142,6 +148,7 @@ export const useAuthStore = create<AuthState>()(
           systemRoles: [],
           userProfile: null,
           allRoles: [],
+          features: {},
           userInfoLoaded: false,
           rolesLoaded: false,
         })
@@ -120,6 +120,7 @@ export interface TemplateVersionDetail {
   archivedBy?: string;
   scheduledPublishAt?: string;
   scheduledArchiveAt?: string;
+  revision: number;
   createdAt: string;
   createdBy?: string;
   updatedAt?: string;
@@ -147,6 +148,7 @@ export interface Template {
   isPublicLibrary: boolean;
   documentTypeId?: string | null;
   documentTypeName?: Record<string, string> | null;
+  revision: number;
   createdAt: string;
   updatedAt?: string;
 }
@@ -177,6 +179,7 @@ export interface TemplateWithAllVersionsResponse {
   versions: TemplateVersionSummaryResponse[];
   documentTypeId?: string | null;
   documentTypeName?: Record<string, string> | null;
+  revision: number;
   createdAt: string;
   updatedAt?: string;
 }
@@ -198,6 +201,7 @@ export interface TemplateVersionSummaryResponse {
   archivedBy?: string;
   scheduledPublishAt?: string;
   scheduledArchiveAt?: string;
+  revision: number;
   updatedAt?: string;
 }
 
@@ -223,6 +227,7 @@ export interface TemplateVersionResponse {
   archivedBy?: string;
   scheduledPublishAt?: string;
   scheduledArchiveAt?: string;
+  revision: number;
   updatedAt?: string;
 }
 
@@ -279,6 +284,7 @@ export interface UpdateTemplateRequest {
   folderId?: string;
   isPublicLibrary?: boolean;
   documentTypeId?: string | null;
+  revision: number; // Revision the edit is based on; 409 if it changed since
 }
 
 export interface CreateVersionRequest {
@@ -296,6 +302,7 @@ export interface UpdateVersionRequest {
   name?: string;
   description?: string;
   contentStructure?: Record<string, unknown>;
+  revision: number; // Revision the edit is based on; 409 if it changed since
 }
 
 export interface CreateFolderRequest {
@@ -5,17 +5,26 @@ import (
 	"fmt"
 	"io/fs"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/middleware"
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres"
+	"github.com/rendis/pdf-forge/core/internal/backup"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	"github.com/rendis/pdf-forge/core/internal/core/port"
+	injectablesvc "github.com/rendis/pdf-forge/core/internal/core/service/injectable"
 	"github.com/rendis/pdf-forge/core/internal/core/service/rendering/pdfrenderer"
 	"github.com/rendis/pdf-forge/core/internal/frontend"
 	"github.com/rendis/pdf-forge/core/internal/infra/config"
 	"github.com/rendis/pdf-forge/core/internal/infra/logging"
+	"github.com/rendis/pdf-forge/core/internal/infra/server"
 	"github.com/rendis/pdf-forge/core/internal/migrations"
 )
 
@@ -26,36 +35,64 @@ type Engine struct {
 	config         *config.Config
 	i18nFilePath   string
 
-	injectors           []port.Injector
-	mapper              port.RequestMapper
-	templateResolver    port.TemplateResolver
-	initFunc            port.InitFunc
-	workspaceProvider   port.WorkspaceInjectableProvider
-	renderAuthenticator port.RenderAuthenticator
-	storageProvider     port.StorageProvider
-	designTokens        *pdfrenderer.TypstDesignTokens
-	frontendFS          fs.FS // Embedded SPA filesystem; nil = no frontend served
-	frontendOverridden  bool  // True if SetFrontendFS was called (even with nil)
+	injectors            []port.Injector
+	packs                []port.InjectorPack
+	mapper               port.RequestMapper
+	templateResolver     port.TemplateResolver
+	initFunc             port.InitFunc
+	workspaceProvider    port.WorkspaceInjectableProvider
+	renderAuthenticator  port.RenderAuthenticator
+	storageProvider      port.StorageProvider
+	objectStorage        port.ObjectStorage
+	currencyRateProvider port.CurrencyRateProvider
+	notificationChannels []port.NotificationChannel
+	eventPublishers      []port.EventPublisher
+	subscriptions        map[entity.DomainEventType][]port.EventHandler
+	invitationMailer     port.InvitationMailer
+	designTokens         *pdfrenderer.TypstDesignTokens
+	rendererBackends     []port.RendererBackend
+	imageEncoders        []port.ImageEncoder
+	preRenderHooks       []port.PreRenderHook
+	postRenderHooks      []port.PostRenderHook
+	chaos                *injectablesvc.ChaosOptions
+	frontendFS           fs.FS // Embedded SPA filesystem; nil = no frontend served
+	frontendOverridden   bool  // True if SetFrontendFS was called (even with nil)
 
 	// Middleware
 	globalMiddleware []gin.HandlerFunc // Applied to all routes (after CORS, before auth)
 	apiMiddleware    []gin.HandlerFunc // Applied to /api/v1/* routes (after auth)
+	customRoutes     []server.CustomRoute
 
 	// Lifecycle hooks
 	onStartHooks    []func(ctx context.Context) error // Run after config/preflight, before HTTP server
 	onShutdownHooks []func(ctx context.Context) error // Run after HTTP server stops, before exit
 }
 
+// Option configures an Engine at creation, e.g. New(WithFrontendFS(dist)).
+type Option func(*Engine)
+
+// WithFrontendFS serves a custom frontend build instead of the embedded one. See SetFrontendFS.
+func WithFrontendFS(fsys fs.FS) Option {
+	return func(e *Engine) {
+		e.SetFrontendFS(fsys)
+	}
+}
+
 // New creates a new Engine with default configuration.
-func New() *Engine {
-	return &Engine{}
+func New(opts ...Option) *Engine {
+	return newEngine(&Engine{}, opts)
 }
 
 // NewWithConfig creates a new Engine that loads config from the given file path.
-func NewWithConfig(configPath string) *Engine {
-	return &Engine{
-		configFilePath: configPath,
+func NewWithConfig(configPath string, opts ...Option) *Engine {
+	return newEngine(&Engine{configFilePath: configPath}, opts)
+}
+
+func newEngine(e *Engine, opts []Option) *Engine {
+	for _, opt := range opts {
+		opt(e)
 	}
+	return e
 }
 
 // SetI18nFilePath sets the path to user-provided i18n translations file.
@@ -71,6 +108,14 @@ func (e *Engine) RegisterInjector(inj port.Injector) *Engine {
 	return e
 }
 
+// RegisterPack adds an injector pack: its injectors and translations, namespaced by its manifest.
+// The engine fails to start when two packs share a namespace, a code lacks the namespace prefix or
+// a version constraint of the manifest is not met. The user i18n file overrides pack translations.
+func (e *Engine) RegisterPack(pack port.InjectorPack) *Engine {
+	e.packs = append(e.packs, pack)
+	return e
+}
+
 // SetMapper sets the request mapper for render requests.
 // Only ONE mapper is supported.
 func (e *Engine) SetMapper(m port.RequestMapper) *Engine {
@@ -128,9 +173,73 @@ func (e *Engine) GetStorageProvider() port.StorageProvider {
 	return e.storageProvider
 }
 
+// SetObjectStorage sets the object storage that render endpoints persist PDFs to when a request
+// asks for it, and that holds asset content with object_storage.assets. It takes precedence over
+// the object_storage configuration.
+func (e *Engine) SetObjectStorage(os port.ObjectStorage) *Engine {
+	e.objectStorage = os
+	return e
+}
+
+// GetObjectStorage returns the registered object storage, or nil if not set.
+// Storage built from the object_storage configuration is not returned.
+func (e *Engine) GetObjectStorage() port.ObjectStorage {
+	return e.objectStorage
+}
+
+// SetCurrencyRateProvider sets the exchange rates that injectors (injCtx.ConvertCurrency) and
+// table formulas (convert) convert amounts with. It takes precedence over the currency configuration.
+func (e *Engine) SetCurrencyRateProvider(p port.CurrencyRateProvider) *Engine {
+	e.currencyRateProvider = p
+	return e
+}
+
+// GetCurrencyRateProvider returns the registered currency rate provider, or nil if not set.
+// Providers built from the currency configuration are not returned.
+func (e *Engine) GetCurrencyRateProvider() port.CurrencyRateProvider {
+	return e.currencyRateProvider
+}
+
+// RegisterNotificationChannel adds a channel that mirrors in-product notifications
+// (e.g., email, Slack). Multiple channels can be registered; delivery goes through
+// the outbox and is retried on error.
+func (e *Engine) RegisterNotificationChannel(ch port.NotificationChannel) *Engine {
+	e.notificationChannels = append(e.notificationChannels, ch)
+	return e
+}
+
+// RegisterEventPublisher adds a publisher that receives every outbox event
+// (e.g., forwarding version.published to a message broker). Delivery is at-least-once.
+func (e *Engine) RegisterEventPublisher(p port.EventPublisher) *Engine {
+	e.eventPublishers = append(e.eventPublishers, p)
+	return e
+}
+
+// Subscribe registers an in-process handler for a domain event type
+// (entity.EventVersionPublished, EventRenderCompleted, EventRenderFailed, EventRenderJobAbandoned,
+// EventInjectableDeactivated, EventMemberInvited).
+// Handlers of committed changes and of render outcomes are delivered through the outbox and retried
+// when they return an error; RenderJobAbandoned handlers run once on the instance that ran the job.
+func (e *Engine) Subscribe(eventType entity.DomainEventType, handler port.EventHandler) *Engine {
+	if e.subscriptions == nil {
+		e.subscriptions = make(map[entity.DomainEventType][]port.EventHandler)
+	}
+	e.subscriptions[eventType] = append(e.subscriptions[eventType], handler)
+	return e
+}
+
+// SetInvitationMailer sets the mailer that emails workspace invitation links.
+// Without it, invitations are still created and the token is returned to the inviting admin.
+func (e *Engine) SetInvitationMailer(m port.InvitationMailer) *Engine {
+	e.invitationMailer = m
+	return e
+}
+
 // SetFrontendFS overrides the embedded frontend filesystem.
 // By default, the engine loads the embedded SPA from internal/frontend/dist.
 // Pass a custom fs.FS to serve a different frontend, or nil to disable frontend serving.
+// A custom build must have index.html at its root (use fs.Sub on a dist directory); the client
+// config and the frontend.branding settings are injected into it at startup.
 func (e *Engine) SetFrontendFS(fsys fs.FS) *Engine {
 	e.frontendFS = fsys
 	e.frontendOverridden = true
@@ -145,6 +254,46 @@ func (e *Engine) SetDesignTokens(tokens pdfrenderer.TypstDesignTokens) *Engine {
 	return e
 }
 
+// RegisterRendererBackend adds an alternative PDF rendering backend (e.g. LaTeX or headless Chromium).
+// Templates select it by setting meta.renderer to its Name(); all others render with Typst.
+// Multiple backends can be registered; names must be unique.
+func (e *Engine) RegisterRendererBackend(b port.RendererBackend) *Engine {
+	e.rendererBackends = append(e.rendererBackends, b)
+	return e
+}
+
+// RegisterImageEncoder adds an output format (e.g. WebP or AVIF) for resized asset images.
+// Clients that list its content type in their Accept header receive it; PNG and JPEG are built in.
+// Encoders registered first are preferred.
+func (e *Engine) RegisterImageEncoder(enc port.ImageEncoder) *Engine {
+	e.imageEncoders = append(e.imageEncoders, enc)
+	return e
+}
+
+// RegisterPreRenderHook adds a hook that runs before the injectables of each render of the render API
+// are resolved. Hooks may change the payload, caller injectables, headers and watermark of the render.
+// Hooks run in registration order; an error fails the render.
+func (e *Engine) RegisterPreRenderHook(hook port.PreRenderHook) *Engine {
+	e.preRenderHooks = append(e.preRenderHooks, hook)
+	return e
+}
+
+// RegisterPostRenderHook adds a hook that runs after each PDF of the render API is rendered, before it
+// is returned. Hooks may change the result (e.g. stamp the PDF) or push metrics; port.RenderContextFrom
+// describes the render. Hooks run in registration order; an error fails the render.
+func (e *Engine) RegisterPostRenderHook(hook port.PostRenderHook) *Engine {
+	e.postRenderHooks = append(e.postRenderHooks, hook)
+	return e
+}
+
+// EnableChaos makes injectors and the workspace provider randomly fail or slow down, to check
+// that IsCritical flags, timeouts and default values behave as intended before a real outage does.
+// For test environments only: the engine refuses to start with it when environment is "production".
+func (e *Engine) EnableChaos(opts injectablesvc.ChaosOptions) *Engine {
+	e.chaos = &opts
+	return e
+}
+
 // UseMiddleware adds middleware to be applied globally to all routes.
 // Execution order: Recovery -> Logger -> CORS -> [User Global Middleware] -> Routes
 // Use for logging, tracing, custom headers, etc.
@@ -161,6 +310,23 @@ func (e *Engine) UseAPIMiddleware(mw gin.HandlerFunc) *Engine {
 	return e
 }
 
+// Use adds a standard net/http middleware to be applied globally to all routes, like UseMiddleware.
+// The request it passes to next, with any context values it added, reaches the handlers; not
+// calling next stops the request. Middleware run in registration order with those of UseMiddleware.
+func (e *Engine) Use(mw middleware.HTTPMiddleware) *Engine {
+	e.globalMiddleware = append(e.globalMiddleware, middleware.FromHTTP(mw))
+	return e
+}
+
+// RegisterRoute adds a custom endpoint, e.g. a health integration or an internal trigger.
+// The path is a Gin pattern under the base path (e.g. "/internal/jobs/:id"); handlers read its
+// parameters with r.PathValue. Custom routes get the global middleware only, so handlers must
+// authenticate their callers. The engine fails to start when a route clashes with another one.
+func (e *Engine) RegisterRoute(method, path string, handler http.Handler) *Engine {
+	e.customRoutes = append(e.customRoutes, server.CustomRoute{Method: method, Path: path, Handler: handler})
+	return e
+}
+
 // OnStart registers a hook that runs AFTER config/preflight, BEFORE HTTP server starts.
 // Hooks run synchronously in registration order.
 // For background processes (schedulers, workers), spawn a goroutine inside the hook.
@@ -183,7 +349,7 @@ func (e *Engine) OnShutdown(fn func(ctx context.Context) error) *Engine {
 func (e *Engine) Run() error {
 	ctx := context.Background()
 
-	// Setup structured logging
+	// Setup structured logging; replaced by the configured handler once config is loaded
 	handler := logging.NewContextHandler(
 		slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 			Level: slog.LevelInfo,
@@ -198,6 +364,13 @@ func (e *Engine) Run() error {
 		return fmt.Errorf("config: %w", err)
 	}
 
+	// Apply the configured levels, format and sampling
+	configured, err := logging.NewHandler(os.Stdout, e.config.Logging)
+	if err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	slog.SetDefault(slog.New(configured))
+
 	// Load embedded frontend (unless overridden by SetFrontendFS)
 	if !e.frontendOverridden {
 		fsys, err := frontend.Assets()
@@ -227,7 +400,126 @@ func (e *Engine) RunMigrations() error {
 	if err := e.loadConfig(); err != nil {
 		return fmt.Errorf("config: %w", err)
 	}
-	return migrations.Run(&e.config.Database)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	return migrations.Run(ctx, &e.config.Database)
+}
+
+// RunBackup loads config and writes an archive of the pdf-forge schemas and the
+// storage assets referenced by templates to path.
+// Register the storage provider before calling it to include assets.
+func (e *Engine) RunBackup(path string) error {
+	if path == "" {
+		return fmt.Errorf("usage: backup <archive.tar.gz>")
+	}
+	if err := e.loadConfig(); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	pool, err := postgres.NewPool(ctx, &e.config.Database)
+	if err != nil {
+		return fmt.Errorf("database: %w", err)
+	}
+	defer pool.Close()
+
+	// Write next to the destination and rename, so an interrupted backup never leaves a truncated archive
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".pdf-forge-backup-*")
+	if err != nil {
+		return fmt.Errorf("creating archive: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	manifest, err := backup.New(pool, e.storageProvider).Create(ctx, tmp)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("backup: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("saving archive: %w", err)
+	}
+
+	fmt.Printf("Backup written to %s (schema version: %d, tables: %d, assets: %d)\n",
+		path, manifest.SchemaVersion, len(manifest.Tables), len(manifest.Assets))
+	return nil
+}
+
+// RunRestore loads config and replaces the pdf-forge schemas with the archive at path.
+// The database must be migrated to the archive's schema version first.
+func (e *Engine) RunRestore(path string) error {
+	if path == "" {
+		return fmt.Errorf("usage: restore <archive.tar.gz>")
+	}
+	if err := e.loadConfig(); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening archive: %w", err)
+	}
+	defer f.Close()
+
+	pool, err := postgres.NewPool(ctx, &e.config.Database)
+	if err != nil {
+		return fmt.Errorf("database: %w", err)
+	}
+	defer pool.Close()
+
+	manifest, err := backup.New(pool, e.storageProvider).Restore(ctx, f)
+	if err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+
+	fmt.Printf("Backup from %s restored (schema version: %d, tables: %d, assets: %d)\n",
+		manifest.CreatedAt.Format(time.RFC3339), manifest.SchemaVersion, len(manifest.Tables), len(manifest.Assets))
+	return nil
+}
+
+// CheckContract runs the mapper on a sample request body and then every registered injector
+// against the mapped payload, reporting the injectors that cannot resolve from that payload shape.
+// It needs no config or database, so it can run from a Go test in CI.
+func (e *Engine) CheckContract(ctx context.Context, body []byte, headers map[string]string) (*injectablesvc.ContractReport, error) {
+	injReg, err := e.newInjectorRegistry()
+	if err != nil {
+		return nil, err
+	}
+	return injectablesvc.NewInjectableResolverService(injReg, nil).CheckContract(ctx, e.mapper, body, headers)
+}
+
+// RunContractCheck runs CheckContract with the sample body at path and prints the report.
+// Returns an error when any check fails, so CI jobs fail on incompatible mapper or injector changes.
+func (e *Engine) RunContractCheck(path string) error {
+	if path == "" {
+		return fmt.Errorf("usage: contract <payload.json>")
+	}
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading sample payload: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	report, err := e.CheckContract(ctx, body, nil)
+	if err != nil {
+		return fmt.Errorf("contract: %w", err)
+	}
+
+	fmt.Print(report)
+	if !report.OK() {
+		return fmt.Errorf("contract check failed for %s", path)
+	}
+	return nil
 }
 
 // loadConfig loads configuration from file or uses the provided config.
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
+	"os"
+	"slices"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -11,48 +14,147 @@ import (
 	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/controller"
 	httpmapper "github.com/rendis/pdf-forge/core/internal/adapters/primary/http/mapper"
 	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/middleware"
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/assetdownload"
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/chatwebhook"
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/currencyrate"
 	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres"
+	assetrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/asset_repo"
+	authsessionrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/auth_session_repo"
+	cleanuprepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/cleanup_repo"
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	documenttypecontractrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/document_type_contract_repo"
 	documenttyperepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/document_type_repo"
+	eventwebhookrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/event_webhook_repo"
 	folderrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/folder_repo"
+	hosteddocumentrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/hosted_document_repo"
+	injectablecoveragerepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/injectable_coverage_repo"
 	injectablerepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/injectable_repo"
+	maintenancerepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/maintenance_repo"
+	notificationrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/notification_repo"
+	notificationwebhookrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/notification_webhook_repo"
+	numberingsequencerepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/numbering_sequence_repo"
+	outboxrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/outbox_repo"
+	previewtokenrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/preview_token_repo"
+	rendercurrencyraterepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/render_currency_rate_repo"
+	renderfailurerepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/render_failure_repo"
+	renderjobrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/render_job_repo"
+	renderquotarepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/render_quota_repo"
+	scheduledrunrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/scheduled_run_repo"
+	scriptedinjectorrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/scripted_injector_repo"
 	systeminjectablerepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/system_injectable_repo"
 	systemrolerepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/system_role_repo"
+	systemstatsrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/system_stats_repo"
 	tagrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/tag_repo"
+	templatelibraryrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/template_library_repo"
 	templaterepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/template_repo"
+	templateslarepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/template_sla_repo"
 	templatetagrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/template_tag_repo"
 	templateversioninjectablerepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/template_version_injectable_repo"
 	templateversionrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/template_version_repo"
 	tenantmemberrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/tenant_member_repo"
 	tenantrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/tenant_repo"
 	useraccesshistoryrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/user_access_history_repo"
+	userpreferencesrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/user_preferences_repo"
 	userrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/user_repo"
+	versionreleasenotesrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/version_release_notes_repo"
+	webhookdeliveryrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/webhook_delivery_repo"
+	workspacefontrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/workspace_font_repo"
 	workspaceinjectablerepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/workspace_injectable_repo"
+	workspaceinvitationrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/workspace_invitation_repo"
 	workspacememberrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/workspace_member_repo"
 	workspacerepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/workspace_repo"
+	workspacesandboxrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/workspace_sandbox_repo"
+	workspacesettingsrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/workspace_settings_repo"
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/eventwebhook"
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/linkchecker"
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/objectstorage"
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/remoteinjector"
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/starlarkscript"
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/wasmplugin"
 	accesssvc "github.com/rendis/pdf-forge/core/internal/core/service/access"
 	catalogsvc "github.com/rendis/pdf-forge/core/internal/core/service/catalog"
+	eventsvc "github.com/rendis/pdf-forge/core/internal/core/service/events"
 	gallerysvc "github.com/rendis/pdf-forge/core/internal/core/service/gallery"
 	injectablesvc "github.com/rendis/pdf-forge/core/internal/core/service/injectable"
+	notificationsvc "github.com/rendis/pdf-forge/core/internal/core/service/notification"
 	organizationsvc "github.com/rendis/pdf-forge/core/internal/core/service/organization"
+	outboxsvc "github.com/rendis/pdf-forge/core/internal/core/service/outbox"
+	platformsvc "github.com/rendis/pdf-forge/core/internal/core/service/platform"
+	"github.com/rendis/pdf-forge/core/internal/core/service/rendering"
 	"github.com/rendis/pdf-forge/core/internal/core/service/rendering/pdfrenderer"
 	templatesvc "github.com/rendis/pdf-forge/core/internal/core/service/template"
 	"github.com/rendis/pdf-forge/core/internal/core/service/template/contentvalidator"
 	"github.com/rendis/pdf-forge/core/internal/extensions/injectors/datetime"
 	"github.com/rendis/pdf-forge/core/internal/infra/config"
+	"github.com/rendis/pdf-forge/core/internal/infra/metrics"
 	"github.com/rendis/pdf-forge/core/internal/infra/registry"
 	"github.com/rendis/pdf-forge/core/internal/infra/server"
 
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	"github.com/rendis/pdf-forge/core/internal/core/port"
 )
 
 // appComponents holds all initialized components.
 type appComponents struct {
-	httpServer *server.HTTPServer
-	dbPool     *pgxpool.Pool
+	httpServer    *server.HTTPServer
+	dbPool        *pgxpool.Pool
+	outboxRelay   *outboxsvc.Relay
+	renderCounter *platformsvc.RenderCounter
+	quotas        *platformsvc.RenderQuotaService         // nil when render_quotas.enabled is false
+	cleanupJob    *platformsvc.CleanupJob                 // nil when cleanup.enabled is false
+	scheduler     *templatesvc.Scheduler                  // nil when scheduler.enabled is false
+	reaper        *organizationsvc.SandboxReaper          // nil when scheduler.enabled is false
+	docIndexer    *templatesvc.HostedDocumentIndexer      // nil when document_index.enabled is false
+	renderJobs    *templatesvc.RenderJobRunner            // nil when render_jobs.enabled is false
+	slaMonitor    *templatesvc.TemplateSLAMonitor         // nil when template_slas.enabled is false
+	coverage      *templatesvc.InjectableCoverageRecorder // nil when injectable_coverage.enabled is false
+	scripts       *injectablesvc.ScriptedInjectorService  // nil when scripted_injectors.enabled is false
+	plugins       *injectablesvc.Plugins                  // nil when wasm_plugins.enabled is false
+	remotes       *injectablesvc.RemoteInjectorService    // nil when remote_injectors.enabled is false
+	webhooks      *notificationsvc.EventWebhookDispatcher // nil when event_webhooks.enabled is false
 }
 
 func (a *appComponents) cleanup() {
 	slog.Info("cleaning up resources")
+	if a.scheduler != nil {
+		a.scheduler.Stop()
+	}
+	if a.reaper != nil {
+		a.reaper.Stop()
+	}
+	if a.docIndexer != nil {
+		a.docIndexer.Stop()
+	}
+	if a.renderJobs != nil {
+		a.renderJobs.Stop()
+	}
+	if a.slaMonitor != nil {
+		a.slaMonitor.Stop()
+	}
+	if a.coverage != nil {
+		a.coverage.Stop()
+	}
+	if a.scripts != nil {
+		a.scripts.Stop()
+	}
+	if a.cleanupJob != nil {
+		a.cleanupJob.Stop()
+	}
+	if a.plugins != nil {
+		a.plugins.Close(context.Background())
+	}
+	if a.remotes != nil {
+		a.remotes.Stop()
+	}
+	if a.webhooks != nil {
+		a.webhooks.Stop()
+	}
+	// Stop the relay before the pool so its batch in flight can record outcomes
+	a.outboxRelay.Stop()
+	a.renderCounter.Stop()
+	if a.quotas != nil {
+		a.quotas.Stop()
+	}
 	postgres.Close(a.dbPool)
 	slog.Info("cleanup complete")
 }
@@ -67,6 +169,10 @@ func (e *Engine) initialize(ctx context.Context) (*appComponents, error) {
 		return nil, err
 	}
 
+	// --- Metrics ---
+	appMetrics := metrics.New()
+	appMetrics.RegisterPool(pool)
+
 	// --- Repositories ---
 	userRepo := userrepo.New(pool)
 	systemRoleRepo := systemrolerepo.New(pool)
@@ -84,7 +190,37 @@ func (e *Engine) initialize(ctx context.Context) (*appComponents, error) {
 	templateVersionRepo := templateversionrepo.New(pool)
 	templateTagRepo := templatetagrepo.New(pool)
 	templateVersionInjectableRepo := templateversioninjectablerepo.New(pool)
+	versionReleaseNotesRepo := versionreleasenotesrepo.New(pool)
 	documentTypeRepo := documenttyperepo.New(pool)
+	documentTypeContractRepo := documenttypecontractrepo.New(pool)
+	notificationRepo := notificationrepo.New(pool)
+	notificationWebhookRepo := notificationwebhookrepo.New(pool)
+	workspaceInvitationRepo := workspaceinvitationrepo.New(pool)
+	userPreferencesRepo := userpreferencesrepo.New(pool)
+	authSessionRepo := authsessionrepo.New(pool)
+	maintenanceRepo := maintenancerepo.New(pool)
+	outboxRepo := outboxrepo.New(pool)
+	systemStatsRepo := systemstatsrepo.New(pool)
+	scheduledRunRepo := scheduledrunrepo.New(pool)
+	previewTokenRepo := previewtokenrepo.New(pool)
+	hostedDocumentRepo := hosteddocumentrepo.New(pool)
+	renderJobRepo := renderjobrepo.New(pool)
+	renderFailureRepo := renderfailurerepo.New(pool)
+	renderCurrencyRateRepo := rendercurrencyraterepo.New(pool)
+	renderQuotaRepo := renderquotarepo.New(pool)
+	eventWebhookRepo := eventwebhookrepo.New(pool)
+	webhookDeliveryRepo := webhookdeliveryrepo.New(pool)
+	workspaceSandboxRepo := workspacesandboxrepo.New(pool)
+	assetRepo := assetrepo.New(pool)
+	workspaceFontRepo := workspacefontrepo.New(pool)
+	cleanupRepo := cleanuprepo.New(pool)
+	templateSLARepo := templateslarepo.New(pool)
+	injectableCoverageRepo := injectablecoveragerepo.New(pool)
+	numberingSequenceRepo := numberingsequencerepo.New(pool)
+	scriptedInjectorRepo := scriptedinjectorrepo.New(pool)
+	templateLibraryRepo := templatelibraryrepo.New(pool)
+	workspaceSettingsRepo := workspacesettingsrepo.New(pool)
+	txManager := common.NewTxManager(pool)
 
 	// --- Dummy Auth: seed default user + sample data ---
 	if cfg.DummyAuth {
@@ -105,82 +241,214 @@ func (e *Engine) initialize(ctx context.Context) (*appComponents, error) {
 		userRepo, systemRoleRepo, workspaceRepo, workspaceMemberRepo, tenantMemberRepo,
 	)
 
-	// --- i18n ---
-	// Always load embedded built-in i18n first (datetime injectors, etc.)
-	i18nCfg, err := config.LoadBuiltinInjectorI18n()
+	// --- Extensibility: Registries ---
+	injReg, err := e.newInjectorRegistry()
 	if err != nil {
 		return nil, err
 	}
-	// Merge user-provided i18n file (overrides built-in entries)
-	if e.i18nFilePath != "" {
-		userI18n, err := config.LoadInjectorI18nFromFile(e.i18nFilePath)
-		if err != nil {
+	postRenderHooks := e.postRenderHooks
+	var plugins *injectablesvc.Plugins
+	if cfg.WASMPlugins.Enabled {
+		if plugins, err = loadWASMPlugins(ctx, cfg.WASMPlugins); err != nil {
 			return nil, err
 		}
-		i18nCfg.Merge(userI18n)
-	}
-
-	// --- Extensibility: Registries ---
-	mapReg := registry.NewMapperRegistry()
-	injReg := registry.NewInjectorRegistry(i18nCfg)
-
-	// Register built-in datetime injectors (useful out of the box)
-	builtinInjectors := []port.Injector{
-		&datetime.DateNowInjector{},
-		&datetime.DateTimeNowInjector{},
-		&datetime.DayNowInjector{},
-		&datetime.MonthNowInjector{},
-		&datetime.TimeNowInjector{},
-		&datetime.YearNowInjector{},
-	}
-	for _, inj := range builtinInjectors {
-		_ = injReg.Register(inj)
+		for _, inj := range plugins.Injectors() {
+			if err := injReg.Register(inj); err != nil {
+				return nil, err
+			}
+		}
+		// Plugin post-processors run first, so hooks that sign the PDF see its final content
+		postRenderHooks = append(slices.Clone(plugins.PostRenderHooks()), e.postRenderHooks...)
 	}
-
-	// Register user-provided extensions
-	for _, inj := range e.injectors {
-		if err := injReg.Register(inj); err != nil {
+	var remotes *injectablesvc.RemoteInjectorService
+	if cfg.RemoteInjectors.Enabled {
+		if remotes, err = newRemoteInjectors(cfg.RemoteInjectors, injReg); err != nil {
 			return nil, err
 		}
+		// Register the injectors of the reachable remotes before serving; the others are retried
+		remotes.RunOnce(ctx)
 	}
+	mapReg := registry.NewMapperRegistry()
 	if e.mapper != nil {
 		if err := mapReg.Set(e.mapper); err != nil {
 			return nil, err
 		}
 	}
-	if e.initFunc != nil {
-		injReg.SetInitFunc(e.initFunc)
+
+	// Created before the bus: the template SLA monitor and the render failure notifier subscribe to
+	// render events and notify through it
+	notificationSvc := notificationsvc.NewNotificationService(notificationRepo, userRepo, userPreferencesRepo, outboxRepo, txManager)
+
+	// --- Domain Events ---
+	// Render and version events are recorded in the outbox; the relay delivers them to the bus
+	// subscribers and queues them for event webhooks
+	eventWebhookPublisher := notificationsvc.NewEventWebhookPublisher(eventWebhookRepo, webhookDeliveryRepo)
+	var slaMonitor *templatesvc.TemplateSLAMonitor
+	if cfg.TemplateSLAs.Enabled {
+		slaMonitor = templatesvc.NewTemplateSLAMonitor(templateSLARepo, outboxRepo, txManager, notificationSvc, cfg.TemplateSLAs.Interval())
+	}
+	var coverageRecorder *templatesvc.InjectableCoverageRecorder
+	if cfg.InjectableCoverage.Enabled {
+		coverageRecorder = templatesvc.NewInjectableCoverageRecorder(
+			injectableCoverageRepo, cfg.InjectableCoverage.Interval(), cfg.InjectableCoverage.RetentionDays,
+		)
+	}
+	subscriptions := maps.Clone(e.subscriptions)
+	if subscriptions == nil {
+		subscriptions = make(map[entity.DomainEventType][]port.EventHandler)
+	}
+	if slaMonitor != nil {
+		for _, t := range []entity.DomainEventType{entity.EventRenderCompleted, entity.EventRenderFailed} {
+			subscriptions[t] = append(slices.Clone(subscriptions[t]), slaMonitor.HandleRenderEvent)
+		}
 	}
+	if coverageRecorder != nil {
+		subscriptions[entity.EventRenderCompleted] = append(slices.Clone(subscriptions[entity.EventRenderCompleted]), coverageRecorder.HandleRenderEvent)
+	}
+	renderFailureNotifier := templatesvc.NewRenderFailureNotifier(templateVersionRepo, templateRepo, workspaceMemberRepo, notificationSvc, 0)
+	for _, t := range []entity.DomainEventType{entity.EventRenderFailed, entity.EventRenderJobAbandoned} {
+		subscriptions[t] = append(slices.Clone(subscriptions[t]), renderFailureNotifier.HandleRenderEvent)
+	}
+	eventBus := eventsvc.NewBus(subscriptions)
+
+	// --- Services: Notification ---
+	chatSenders := map[entity.WebhookProvider]port.ChatWebhookSender{
+		entity.WebhookProviderSlack: chatwebhook.NewSlackSender(),
+		entity.WebhookProviderTeams: chatwebhook.NewTeamsSender(),
+	}
+	notificationChannels := append(
+		[]port.NotificationChannel{notificationsvc.NewWorkspaceWebhookChannel(notificationWebhookRepo, chatSenders)},
+		e.notificationChannels...,
+	)
+	notificationWebhookSvc := notificationsvc.NewNotificationWebhookService(notificationWebhookRepo, chatSenders)
+	eventWebhookSender := eventwebhook.New(cfg.EventWebhooks.Timeout(), cfg.EventWebhooks.AllowPrivateNetworks)
+	eventWebhookSvc := notificationsvc.NewEventWebhookService(eventWebhookRepo, webhookDeliveryRepo, eventWebhookSender)
 
 	// --- Services: Organization ---
-	workspaceSvc := organizationsvc.NewWorkspaceService(workspaceRepo, tenantRepo, workspaceMemberRepo, userAccessHistoryRepo)
-	tenantSvc := organizationsvc.NewTenantService(tenantRepo, workspaceRepo, tenantMemberRepo, systemRoleRepo, userAccessHistoryRepo)
-	workspaceMemberSvc := organizationsvc.NewWorkspaceMemberService(workspaceMemberRepo, userRepo)
-	tenantMemberSvc := organizationsvc.NewTenantMemberService(tenantMemberRepo, userRepo)
+	workspaceSvc := organizationsvc.NewWorkspaceService(
+		workspaceRepo, tenantRepo, workspaceMemberRepo, userAccessHistoryRepo, workspaceSettingsRepo,
+	)
+	workspaceSettingsSvc := organizationsvc.NewWorkspaceSettingsService(workspaceSettingsRepo, workspaceRepo)
+	tenantSvc := organizationsvc.NewTenantService(
+		tenantRepo, workspaceRepo, tenantMemberRepo, systemRoleRepo, userAccessHistoryRepo, workspaceSettingsSvc, eventWebhookSvc,
+	)
+	workspaceMemberSvc := organizationsvc.NewWorkspaceMemberService(workspaceMemberRepo, userRepo, workspaceRepo, notificationSvc, txManager)
+	workspaceInvitationSvc := organizationsvc.NewWorkspaceInvitationService(
+		workspaceInvitationRepo, workspaceMemberRepo, userRepo, workspaceRepo, e.invitationMailer, notificationSvc, outboxRepo, txManager,
+	)
+	tenantMemberSvc := organizationsvc.NewTenantMemberService(tenantMemberRepo, userRepo, txManager)
+	workspaceSandboxSvc := organizationsvc.NewWorkspaceSandboxService(workspaceRepo, workspaceMemberRepo, workspaceSandboxRepo, txManager)
 
 	// --- Services: Catalog ---
 	folderSvc := catalogsvc.NewFolderService(folderRepo)
 	tagSvc := catalogsvc.NewTagService(tagRepo)
 	documentTypeSvc := catalogsvc.NewDocumentTypeService(documentTypeRepo, templateRepo)
+	documentTypeContractSvc := catalogsvc.NewDocumentTypeContractService(documentTypeContractRepo, documentTypeRepo)
+	objectStorage := e.objectStorage
+	if objectStorage == nil {
+		if objectStorage, err = newObjectStorage(cfg.ObjectStorage, cfg.Server.PublicBaseURL()); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.ObjectStorage.Assets && objectStorage == nil {
+		return nil, fmt.Errorf("object_storage.assets requires an object storage provider")
+	}
+	assetSvc := catalogsvc.NewAssetService(assetRepo, txManager, e.imageEncoders,
+		assetdownload.New(cfg.Assets.ImportTimeout(), cfg.Assets.ImportAllowPrivateNetworks),
+		catalogsvc.AssetSigningOptions{
+			Key:           cfg.Assets.SigningKey,
+			PublicBaseURL: cfg.Server.PublicBaseURL(),
+			TTL:           cfg.Assets.SignedURLTTL(),
+		},
+		catalogsvc.AssetStorageOptions{
+			Objects:   objectStorage,
+			KeyPrefix: cfg.ObjectStorage.KeyPrefix,
+			Store:     cfg.ObjectStorage.Assets,
+		},
+	)
 
 	// --- Services: Access ---
 	systemRoleSvc := accesssvc.NewSystemRoleService(systemRoleRepo, userRepo)
 	userAccessHistorySvc := accesssvc.NewUserAccessHistoryService(userAccessHistoryRepo)
+	userPreferencesSvc := accesssvc.NewUserPreferencesService(userPreferencesRepo)
+	authSessionSvc := accesssvc.NewAuthSessionService(authSessionRepo)
+	userProfileSvc := accesssvc.NewUserProfileService(
+		userRepo, systemRoleRepo, tenantMemberRepo, workspaceRepo,
+		map[string]bool{"gallery": e.storageProvider != nil},
+	)
+
+	// --- Services: Platform ---
+	maintenanceSvc := platformsvc.NewMaintenanceService(maintenanceRepo)
 
 	// --- Services: Injectable ---
 	injectableSvc := injectablesvc.NewInjectableService(
 		injectableRepo, systemInjectableRepo, injReg,
 		workspaceRepo, tenantRepo, e.workspaceProvider,
 	)
-	workspaceInjectableSvc := injectablesvc.NewWorkspaceInjectableService(workspaceInjectableRepo)
+	workspaceInjectableSvc := injectablesvc.NewWorkspaceInjectableService(workspaceInjectableRepo, outboxRepo, txManager)
 	systemInjectableSvc := injectablesvc.NewSystemInjectableService(systemInjectableRepo, injReg)
+	scriptRuntime := starlarkscript.New(starlarkscript.Options{
+		MaxSteps:       cfg.ScriptedInjectors.MaxSteps,
+		MaxSourceBytes: cfg.ScriptedInjectors.MaxSourceKB << 10,
+		MaxResultBytes: cfg.ScriptedInjectors.MaxResultKB << 10,
+		MaxAllocBytes:  cfg.ScriptedInjectors.MaxAllocMB << 20,
+	})
+	scriptedInjectorSvc := injectablesvc.NewScriptedInjectorService(
+		scriptedInjectorRepo, injReg, scriptRuntime, e.workspaceProvider,
+		cfg.ScriptedInjectors.Enabled, cfg.ScriptedInjectors.RefreshInterval(), cfg.ScriptedInjectors.MaxConcurrent,
+	)
+	// Register the stored scripts before serving, so the first renders can use them
+	scriptedInjectorSvc.RunOnce(ctx)
 
 	// --- Services: Template ---
-	templateSvc := templatesvc.NewTemplateService(templateRepo, templateVersionRepo, templateTagRepo)
-	contentValidator := contentvalidator.New(injectableSvc)
+	templateSvc := templatesvc.NewTemplateService(templateRepo, templateVersionRepo, templateTagRepo, txManager)
+	templateSLASvc := templatesvc.NewTemplateSLAService(templateSLARepo, templateRepo)
+	numberingSequenceSvc := templatesvc.NewNumberingSequenceService(
+		numberingSequenceRepo, workspaceRepo, documentTypeRepo, txManager, workspaceSettingsSvc,
+	)
+	injectableCoverageSvc := templatesvc.NewInjectableCoverageService(
+		templateRepo, injectableCoverageRepo, cfg.InjectableCoverage.RetentionDays,
+	)
+	validatorOpts := []contentvalidator.Option{contentvalidator.WithWorkspaceSettings(workspaceSettingsSvc)}
+	if cfg.LinkCheck.Enabled {
+		validatorOpts = append(validatorOpts, contentvalidator.WithLinkChecker(
+			linkchecker.New(cfg.LinkCheck.AllowedHosts, cfg.LinkCheck.Timeout()),
+		))
+	}
+	contentValidator := contentvalidator.New(injectableSvc, validatorOpts...)
 	templateVersionSvc := templatesvc.NewTemplateVersionService(
-		templateVersionRepo, templateVersionInjectableRepo, templateRepo, contentValidator,
+		templateVersionRepo, templateVersionInjectableRepo, templateRepo, contentValidator, notificationSvc, txManager, outboxRepo,
+		scheduledRunRepo, versionReleaseNotesRepo, workspaceSettingsSvc, cfg.Scheduler.MaxAttempts,
+	)
+	templateConversionSvc := templatesvc.NewTemplateConversionService(templateVersionRepo, templateRepo, injectableSvc, templateVersionSvc)
+	templateBundleSvc := templatesvc.NewTemplateBundleService(
+		templateRepo, templateVersionRepo, injectableSvc, workspaceInjectableSvc, assetSvc, templateVersionSvc, txManager,
+	)
+	templateLibrarySvc := templatesvc.NewTemplateLibraryService(
+		templateRepo, templateVersionRepo, workspaceRepo, templateLibraryRepo, assetSvc, txManager,
 	)
+	previewTokenSvc := templatesvc.NewPreviewTokenService(previewTokenRepo, templateVersionRepo, templateRepo, workspaceRepo)
+	hostedDocumentSvc := templatesvc.NewHostedDocumentService(
+		hostedDocumentRepo, tenantRepo, workspaceRepo, cfg.Server.PublicBaseURL(),
+	)
+	renderJobSvc := templatesvc.NewRenderJobService(renderJobRepo, tenantRepo, workspaceRepo, cfg.Server.PublicBaseURL())
+	persistedRenderSvc := templatesvc.NewPersistedRenderService(objectStorage, cfg.ObjectStorage.KeyPrefix, cfg.ObjectStorage.SignedURLTTL())
+
+	// --- Outbox Relay ---
+	eventPublishers := append(
+		[]port.EventPublisher{
+			notificationsvc.NewChannelPublisher(userRepo, notificationChannels),
+			eventsvc.NewOutboxPublisher(eventBus),
+			eventWebhookPublisher,
+		},
+		e.eventPublishers...,
+	)
+	outboxRelay := outboxsvc.NewRelay(outboxRepo, eventPublishers, outboxsvc.RelayOptions{
+		PollInterval: cfg.Outbox.PollInterval(),
+		BatchSize:    cfg.Outbox.BatchSize,
+		MaxAttempts:  cfg.Outbox.MaxAttempts,
+		Retention:    cfg.Outbox.Retention(),
+	})
 
 	// --- PDF Renderer ---
 	imageCache, err := pdfrenderer.NewImageCache(pdfrenderer.ImageCacheOptions{
@@ -192,19 +460,54 @@ func (e *Engine) initialize(ctx context.Context) (*appComponents, error) {
 		return nil, err
 	}
 
-	pdfRenderer, err := pdfrenderer.NewService(pdfrenderer.TypstOptions{
+	imageFetcher := pdfrenderer.NewImageFetcher(pdfrenderer.ImageFetcherOptions{
+		Cache:       imageCache,
+		MaxBytes:    cfg.Typst.ImageMaxBytes(),
+		Concurrency: cfg.Typst.ImageFetchConcurrency,
+	})
+
+	typstOpts := pdfrenderer.TypstOptions{
 		BinPath:        cfg.Typst.BinPath,
 		Timeout:        cfg.Typst.TimeoutDuration(),
 		FontDirs:       cfg.Typst.FontDirs,
 		MaxConcurrent:  cfg.Typst.MaxConcurrent,
 		AcquireTimeout: cfg.Typst.AcquireTimeoutDuration(),
-	}, imageCache, e.designTokens)
+		Metrics:        appMetrics,
+	}
+	for _, f := range cfg.Typst.FontFallbacks {
+		typstOpts.FontFallbacks = append(typstOpts.FontFallbacks, pdfrenderer.FontFallback{
+			Script:   f.Script,
+			Language: f.Language,
+			Fonts:    f.Fonts,
+		})
+	}
+	typstRenderer, err := pdfrenderer.NewService(typstOpts, imageFetcher, e.designTokens)
+	if err != nil {
+		return nil, err
+	}
+	appMetrics.RegisterRenderCapacity(typstRenderer)
+	pdfRenderer, err := rendering.NewRouter(typstRenderer, e.rendererBackends)
 	if err != nil {
 		return nil, err
 	}
+	workspaceFontSvc := catalogsvc.NewWorkspaceFontService(workspaceFontRepo, cfg.Typst.WorkspaceFontDir, typstRenderer)
 
 	// --- Injectable Resolver ---
 	injectableResolver := injectablesvc.NewInjectableResolverService(injReg, e.workspaceProvider)
+	injectableResolver.SetMetrics(appMetrics)
+	injectableResolver.SetWorkspaceInjectables(workspaceInjectableRepo)
+	if e.chaos != nil {
+		if cfg.Environment == "production" {
+			return nil, fmt.Errorf("chaos mode cannot be enabled in production")
+		}
+		if err := injectableResolver.EnableChaos(*e.chaos); err != nil {
+			return nil, err
+		}
+		slog.WarnContext(ctx, "chaos mode enabled: injectors and providers fail and slow down on purpose",
+			slog.Float64("failure_rate", e.chaos.FailureRate),
+			slog.Float64("delay_rate", e.chaos.DelayRate),
+		)
+	}
 
 	// --- Template Cache ---
 	ttl := time.Duration(cfg.Typst.TemplateCacheTTL) * time.Second
@@ -213,9 +516,70 @@ func (e *Engine) initialize(ctx context.Context) (*appComponents, error) {
 		return nil, err
 	}
 
+	// --- Platform Stats ---
+	renderCounter := platformsvc.NewRenderCounter(systemStatsRepo, 0)
+	systemStatsSvc := platformsvc.NewSystemStatsService(systemStatsRepo, typstRenderer)
+
+	// --- Orphaned Data Cleanup ---
+	cleanupSvc := platformsvc.NewCleanupService(cleanupRepo, workspaceSandboxRepo, txManager, platformsvc.CleanupOptions{
+		MaxPerCategory:        cfg.Cleanup.MaxPerCategory,
+		PreviewTokenRetention: cfg.Cleanup.PreviewTokenRetention(),
+		UnusedImageRetention:  cfg.Cleanup.UnusedImageRetention(),
+	})
+
+	// --- Render Failure Capture ---
+	renderFailureSvc := platformsvc.NewRenderFailureService(renderFailureRepo, platformsvc.RenderFailureOptions{
+		Retention:      cfg.RenderFailures.Retention(),
+		MaxKept:        cfg.RenderFailures.MaxKept,
+		MaxSourceBytes: cfg.RenderFailures.MaxSourceKB << 10,
+	})
+	var renderFailures port.RenderFailureCapturer
+	if cfg.RenderFailures.Enabled {
+		renderFailures = renderFailureSvc
+	}
+
+	// --- Render Quotas ---
+	renderQuotaSvc := platformsvc.NewRenderQuotaService(renderQuotaRepo, tenantRepo, workspaceRepo,
+		cfg.RenderQuotas.Enabled, platformsvc.RenderQuotaDefaults{
+			Tenant: entity.RenderQuotaLimits{
+				RendersPerMinute: cfg.RenderQuotas.Tenant.RendersPerMinute,
+				PagesPerMonth:    cfg.RenderQuotas.Tenant.PagesPerMonth,
+			},
+			Workspace: entity.RenderQuotaLimits{
+				RendersPerMinute: cfg.RenderQuotas.Workspace.RendersPerMinute,
+				PagesPerMonth:    cfg.RenderQuotas.Workspace.PagesPerMonth,
+			},
+		}, cfg.RenderQuotas.Replicas, cfg.RenderQuotas.RefreshInterval())
+	var renderQuotas port.RenderQuotaEnforcer
+	if cfg.RenderQuotas.Enabled {
+		// Load the quotas and the usage of the month before serving
+		renderQuotaSvc.RunOnce(ctx)
+		renderQuotas = renderQuotaSvc
+	}
+
+	// --- Render Estimation ---
+	estimation := templatesvc.RenderEstimationOptions{
+		Stats: templatesvc.NewRenderStatsCache(),
+		Cost: entity.RenderCostModel{
+			PerRender: cfg.RenderCost.PerRender,
+			PerPage:   cfg.RenderCost.PerPage,
+			PerSecond: cfg.RenderCost.PerSecond,
+		},
+		CompileTimeout: cfg.Typst.TimeoutDuration(),
+	}
+
+	// --- Currency Conversion ---
+	currencyRates, err := newCurrencyRateService(cfg.Currency, e.currencyRateProvider)
+	if err != nil {
+		return nil, err
+	}
+
 	internalRenderSvc := templatesvc.NewInternalRenderService(
-		tenantRepo, workspaceRepo, documentTypeRepo, templateRepo, templateVersionRepo,
-		pdfRenderer, injectableResolver, templateCache, e.templateResolver, e.storageProvider,
+		tenantRepo, workspaceRepo, documentTypeRepo, documentTypeContractRepo, templateRepo, templateVersionRepo,
+		pdfRenderer, injectableResolver, templateCache, e.templateResolver, e.storageProvider, assetSvc, outboxRepo,
+		renderCounter, renderFailures, estimation,
+		templatesvc.RenderHooks{PreRender: e.preRenderHooks, PostRender: postRenderHooks}, workspaceSettingsSvc,
+		renderQuotas, workspaceFontSvc, currencyRates, renderCurrencyRateRepo, numberingSequenceSvc,
 	)
 
 	// --- HTTP Mappers ---
@@ -227,18 +591,36 @@ func (e *Engine) initialize(ctx context.Context) (*appComponents, error) {
 
 	// --- Controllers ---
 	workspaceCtrl := controller.NewWorkspaceController(
-		workspaceSvc, folderSvc, tagSvc, workspaceMemberSvc, workspaceInjectableSvc, injectableMapper,
+		workspaceSvc, folderSvc, tagSvc, workspaceMemberSvc, workspaceInjectableSvc, workspaceInvitationSvc, notificationWebhookSvc,
+		templateVersionSvc, workspaceSandboxSvc, workspaceSettingsSvc, injectableMapper,
 	)
 	injectableCtrl := controller.NewContentInjectableController(injectableSvc, injectableMapper)
-	renderCtrl := controller.NewRenderController(templateVersionSvc, internalRenderSvc, pdfRenderer, e.storageProvider)
+	renderCtrl := controller.NewRenderController(
+		templateVersionSvc, internalRenderSvc, pdfRenderer, e.storageProvider, assetSvc, userPreferencesSvc, previewTokenSvc,
+		hostedDocumentSvc, renderJobSvc, persistedRenderSvc, workspaceSettingsSvc, workspaceFontSvc, e.mapper,
+	)
 	templateVersionCtrl := controller.NewTemplateVersionController(
-		templateVersionSvc, templateVersionMapper, templateMapper, renderCtrl,
+		templateVersionSvc, templateConversionSvc, templateVersionMapper, templateMapper, renderCtrl,
+	)
+	templateCtrl := controller.NewContentTemplateController(
+		templateSvc, templateSLASvc, templateBundleSvc, templateLibrarySvc, injectableCoverageSvc, templateMapper,
+		templateVersionCtrl,
 	)
-	templateCtrl := controller.NewContentTemplateController(templateSvc, templateMapper, templateVersionCtrl)
-	adminCtrl := controller.NewAdminController(tenantSvc, systemRoleSvc, systemInjectableSvc)
-	meCtrl := controller.NewMeController(tenantSvc, tenantMemberRepo, workspaceMemberRepo, userAccessHistorySvc)
-	tenantCtrl := controller.NewTenantController(tenantSvc, workspaceSvc, tenantMemberSvc)
-	documentTypeCtrl := controller.NewDocumentTypeController(documentTypeSvc, templateSvc, templateMapper)
+	adminCtrl := controller.NewAdminController(
+		tenantSvc, systemRoleSvc, systemInjectableSvc, scriptedInjectorSvc, authSessionSvc, maintenanceSvc, systemStatsSvc,
+		cleanupSvc, renderFailureSvc, renderQuotaSvc,
+	)
+	meCtrl := controller.NewMeController(
+		tenantSvc, tenantMemberRepo, workspaceMemberRepo, userAccessHistorySvc, notificationSvc, userProfileSvc, workspaceInvitationSvc,
+		userPreferencesSvc,
+	)
+	tenantCtrl := controller.NewTenantController(tenantSvc, workspaceSvc, tenantMemberSvc, workspaceSettingsSvc)
+	documentTypeCtrl := controller.NewDocumentTypeController(documentTypeSvc, documentTypeContractSvc, templateSvc, templateMapper)
+	hostedDocumentCtrl := controller.NewHostedDocumentController(hostedDocumentSvc)
+	assetCtrl := controller.NewAssetController(assetSvc)
+	fontCtrl := controller.NewFontController(workspaceFontSvc)
+	eventWebhookCtrl := controller.NewEventWebhookController(eventWebhookSvc)
+	numberingSequenceCtrl := controller.NewNumberingSequenceController(numberingSequenceSvc)
 
 	// --- Gallery Controller (optional) ---
 	var galleryCtrl *controller.GalleryController
@@ -248,7 +630,7 @@ func (e *Engine) initialize(ctx context.Context) (*appComponents, error) {
 	}
 
 	// --- HTTP Server ---
-	httpServer := server.NewHTTPServer(
+	httpServer, err := server.NewHTTPServer(
 		cfg,
 		middlewareProvider,
 		workspaceCtrl,
@@ -260,18 +642,241 @@ func (e *Engine) initialize(ctx context.Context) (*appComponents, error) {
 		documentTypeCtrl,
 		renderCtrl,
 		galleryCtrl,
+		hostedDocumentCtrl,
+		assetCtrl,
+		fontCtrl,
+		eventWebhookCtrl,
+		numberingSequenceCtrl,
 		e.globalMiddleware,
 		e.apiMiddleware,
+		e.customRoutes,
 		e.renderAuthenticator,
+		authSessionSvc,
+		maintenanceSvc,
+		typstRenderer,
+		appMetrics,
+		typstRenderer,
 		e.frontendFS,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	outboxRelay.Start()
+	renderCounter.Start()
+	var quotas *platformsvc.RenderQuotaService
+	if cfg.RenderQuotas.Enabled {
+		quotas = renderQuotaSvc
+		quotas.Start()
+	}
+
+	// Expired sandboxes are deleted by the same single instance that runs scheduled publications
+	var scheduler *templatesvc.Scheduler
+	var reaper *organizationsvc.SandboxReaper
+	if cfg.Scheduler.Enabled {
+		scheduler = templatesvc.NewScheduler(templateVersionSvc, cfg.Scheduler.PollInterval())
+		scheduler.Start()
+		reaper = organizationsvc.NewSandboxReaper(workspaceSandboxSvc, 0)
+		reaper.Start()
+	}
+
+	var cleanupJob *platformsvc.CleanupJob
+	if cfg.Cleanup.Enabled {
+		cleanupJob = platformsvc.NewCleanupJob(cleanupSvc, maintenanceSvc, cfg.Cleanup.Interval(), cfg.Cleanup.DryRun)
+		cleanupJob.Start()
+	}
+
+	var docIndexer *templatesvc.HostedDocumentIndexer
+	if cfg.DocumentIndex.Enabled {
+		indexer, err := pdfrenderer.NewDocumentIndexer(typstOpts, pdfrenderer.DocumentIndexOptions{
+			ThumbnailWidth: cfg.DocumentIndex.ThumbnailWidth,
+			PdftotextPath:  cfg.DocumentIndex.PdftotextPath,
+		})
+		if err != nil {
+			return nil, err
+		}
+		docIndexer = templatesvc.NewHostedDocumentIndexer(hostedDocumentRepo, indexer, templatesvc.HostedDocumentIndexerOptions{
+			PollInterval: cfg.DocumentIndex.PollInterval(),
+			BatchSize:    cfg.DocumentIndex.BatchSize,
+			MaxAttempts:  cfg.DocumentIndex.MaxAttempts,
+		})
+		docIndexer.Start()
+	}
+
+	var renderJobs *templatesvc.RenderJobRunner
+	if cfg.RenderJobs.Enabled {
+		renderJobs = templatesvc.NewRenderJobRunner(renderJobRepo, internalRenderSvc, maintenanceSvc, eventBus, templatesvc.RenderJobRunnerOptions{
+			PollInterval: cfg.RenderJobs.PollInterval(),
+			Lease:        cfg.RenderJobs.Lease(),
+			Concurrency:  cfg.RenderJobs.Concurrency,
+			MaxAttempts:  cfg.RenderJobs.MaxAttempts,
+		})
+		renderJobs.Start()
+	}
+
+	if slaMonitor != nil {
+		slaMonitor.Start()
+	}
+
+	if coverageRecorder != nil {
+		coverageRecorder.Start()
+	}
+
+	var scripts *injectablesvc.ScriptedInjectorService
+	if cfg.ScriptedInjectors.Enabled {
+		scripts = scriptedInjectorSvc
+		scripts.Start()
+	}
+	if remotes != nil {
+		remotes.Start()
+	}
+
+	var webhooks *notificationsvc.EventWebhookDispatcher
+	if cfg.EventWebhooks.Enabled {
+		webhooks = notificationsvc.NewEventWebhookDispatcher(eventWebhookRepo, webhookDeliveryRepo, eventWebhookSender,
+			notificationsvc.EventWebhookDispatcherOptions{
+				PollInterval: cfg.EventWebhooks.PollInterval(),
+				Lease:        max(time.Minute, 2*cfg.EventWebhooks.Timeout()),
+				BatchSize:    cfg.EventWebhooks.BatchSize,
+				MaxAttempts:  cfg.EventWebhooks.MaxAttempts,
+				Retention:    cfg.EventWebhooks.Retention(),
+			})
+		webhooks.Start()
+	}
 
 	return &appComponents{
-		httpServer: httpServer,
-		dbPool:     pool,
+		httpServer:    httpServer,
+		dbPool:        pool,
+		outboxRelay:   outboxRelay,
+		renderCounter: renderCounter,
+		quotas:        quotas,
+		cleanupJob:    cleanupJob,
+		scheduler:     scheduler,
+		reaper:        reaper,
+		docIndexer:    docIndexer,
+		renderJobs:    renderJobs,
+		slaMonitor:    slaMonitor,
+		coverage:      coverageRecorder,
+		scripts:       scripts,
+		plugins:       plugins,
+		remotes:       remotes,
+		webhooks:      webhooks,
 	}, nil
 }
 
+// loadWASMPlugins reads the configured plugin modules and loads them in a wazero sandbox.
+func loadWASMPlugins(ctx context.Context, cfg config.WASMPluginsConfig) (*injectablesvc.Plugins, error) {
+	sources := make([]injectablesvc.PluginSource, 0, len(cfg.Plugins))
+	for _, p := range cfg.Plugins {
+		module, err := os.ReadFile(p.Path)
+		if err != nil {
+			return nil, fmt.Errorf("reading WASM plugin %q: %w", p.Name, err)
+		}
+		grant := entity.PluginGrant{AllowedHosts: p.AllowedHosts}
+		for _, capability := range p.Capabilities {
+			grant.Capabilities = append(grant.Capabilities, entity.PluginCapability(capability))
+		}
+		sources = append(sources, injectablesvc.PluginSource{Name: p.Name, Module: module, Grant: grant})
+	}
+
+	runtime := wasmplugin.New(wasmplugin.Options{
+		MaxMemoryMB:          cfg.MaxMemoryMB,
+		MaxHTTPResponseBytes: cfg.MaxHTTPResponseKB << 10,
+	})
+	return injectablesvc.LoadPlugins(ctx, runtime, sources, injectablesvc.PluginOptions{
+		MaxConcurrent:      cfg.MaxConcurrent,
+		PostProcessTimeout: cfg.PostProcessTimeout(),
+	})
+}
+
+// newRemoteInjectors creates the clients of the configured remote injector services. Tokens are
+// read from the environment.
+func newRemoteInjectors(cfg config.RemoteInjectorsConfig, injReg port.InjectorRegistry) (*injectablesvc.RemoteInjectorService, error) {
+	sources := make([]injectablesvc.RemoteSource, 0, len(cfg.Remotes))
+	for _, r := range cfg.Remotes {
+		endpoint := entity.RemoteEndpoint{
+			Name:       r.Name,
+			Protocol:   entity.RemoteProtocol(r.Protocol),
+			URL:        r.URL,
+			AuthHeader: r.AuthHeader,
+			Insecure:   r.Insecure,
+		}
+		if r.AuthTokenEnv != "" {
+			if endpoint.AuthToken = os.Getenv(r.AuthTokenEnv); endpoint.AuthToken == "" {
+				return nil, fmt.Errorf("remote injector %q: %s is not set", r.Name, r.AuthTokenEnv)
+			}
+		}
+		client, err := remoteinjector.New(endpoint, remoteinjector.Options{MaxResponseBytes: cfg.MaxResponseKB << 10})
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, injectablesvc.RemoteSource{
+			Name:           r.Name,
+			Client:         client,
+			Timeout:        time.Duration(r.TimeoutMs) * time.Millisecond,
+			ForwardRequest: r.ForwardRequest,
+		})
+	}
+	return injectablesvc.NewRemoteInjectorService(injReg, sources, injectablesvc.RemoteOptions{
+		MaxConcurrent:  cfg.MaxConcurrent,
+		HealthInterval: cfg.HealthInterval(),
+	})
+}
+
+// newObjectStorage builds the object storage selected by the object_storage configuration,
+// or returns nil when no provider is set.
+func newObjectStorage(cfg config.ObjectStorageConfig, publicBaseURL string) (port.ObjectStorage, error) {
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case "local":
+		return objectstorage.NewLocal(cfg.Local.Dir, publicBaseURL, cfg.Local.SigningKey)
+	case "s3":
+		return objectstorage.NewS3(objectstorage.S3Config{
+			Bucket:          cfg.S3.Bucket,
+			Region:          cfg.S3.Region,
+			Endpoint:        cfg.S3.Endpoint,
+			AccessKeyID:     cfg.S3.AccessKeyID,
+			SecretAccessKey: cfg.S3.SecretAccessKey,
+			UsePathStyle:    cfg.S3.UsePathStyle,
+		})
+	case "gcs":
+		return objectstorage.NewGCS(objectstorage.GCSConfig{
+			Bucket:       cfg.GCS.Bucket,
+			HMACAccessID: cfg.GCS.HMACAccessID,
+			HMACSecret:   cfg.GCS.HMACSecret,
+		})
+	default:
+		return nil, fmt.Errorf("unknown object_storage.provider %q (valid: local, s3, gcs)", cfg.Provider)
+	}
+}
+
+// newCurrencyRateService builds the currency conversion of renders over provider or, when nil,
+// over the provider selected by the currency configuration. It returns nil when neither is set.
+func newCurrencyRateService(cfg config.CurrencyConfig, provider port.CurrencyRateProvider) (*injectablesvc.CurrencyRateService, error) {
+	rounding := entity.CurrencyRoundingMode(cfg.Rounding)
+	if rounding != "" && !rounding.IsValid() {
+		return nil, fmt.Errorf("unknown currency.rounding %q (valid: HALF_UP, HALF_EVEN, DOWN, UP)", cfg.Rounding)
+	}
+	if provider == nil {
+		switch cfg.Provider {
+		case "":
+			return nil, nil
+		case "ecb":
+			provider = currencyrate.NewECB(cfg.Timeout())
+		case "openexchangerates":
+			oxr, err := currencyrate.NewOpenExchangeRates(cfg.OpenExchangeRatesAppID, cfg.Timeout())
+			if err != nil {
+				return nil, err
+			}
+			provider = oxr
+		default:
+			return nil, fmt.Errorf("unknown currency.provider %q (valid: ecb, openexchangerates)", cfg.Provider)
+		}
+	}
+	return injectablesvc.NewCurrencyRateService(provider, rounding, cfg.CacheTTL()), nil
+}
+
 // seedDummyUser ensures a default admin user exists in the DB for dummy auth mode.
 // Returns the internal user ID.
 func seedDummyUser(ctx context.Context, pool *pgxpool.Pool) (string, error) {
@@ -319,3 +924,52 @@ func seedDummyInjectables(ctx context.Context, pool *pgxpool.Pool) {
 		slog.WarnContext(ctx, "failed to seed dummy injectables", slog.String("error", err.Error()))
 	}
 }
+
+// newInjectorRegistry builds the injector registry: built-in, pack and registered injectors,
+// their i18n (built-in, then packs, merged with the user file) and the global init function.
+func (e *Engine) newInjectorRegistry() (port.InjectorRegistry, error) {
+	// Always load embedded built-in i18n first (datetime injectors, etc.)
+	i18nCfg, err := config.LoadBuiltinInjectorI18n()
+	if err != nil {
+		return nil, err
+	}
+	packInjectors, packI18n, err := registry.ResolvePacks(e.packs, registry.EngineVersion())
+	if err != nil {
+		return nil, err
+	}
+	i18nCfg.Merge(packI18n)
+	// Merge user-provided i18n file (overrides built-in entries)
+	if e.i18nFilePath != "" {
+		userI18n, err := config.LoadInjectorI18nFromFile(e.i18nFilePath)
+		if err != nil {
+			return nil, err
+		}
+		i18nCfg.Merge(userI18n)
+	}
+
+	injReg := registry.NewInjectorRegistry(i18nCfg)
+
+	// Register built-in datetime injectors (useful out of the box)
+	builtinInjectors := []port.Injector{
+		&datetime.DateNowInjector{},
+		&datetime.DateTimeNowInjector{},
+		&datetime.DayNowInjector{},
+		&datetime.MonthNowInjector{},
+		&datetime.TimeNowInjector{},
+		&datetime.YearNowInjector{},
+	}
+	for _, inj := range builtinInjectors {
+		_ = injReg.Register(inj)
+	}
+
+	// Register pack and user-provided extensions
+	for _, inj := range append(packInjectors, e.injectors...) {
+		if err := injReg.Register(inj); err != nil {
+			return nil, err
+		}
+	}
+	if e.initFunc != nil {
+		injReg.SetInitFunc(e.initFunc)
+	}
+	return injReg, nil
+}
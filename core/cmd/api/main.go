@@ -52,8 +52,41 @@ func main() {
 		))
 	extensions.Register(engine)
 
+	// backup/restore run after extensions are registered so the storage provider is available
+	if len(os.Args) > 1 && (os.Args[1] == "backup" || os.Args[1] == "restore") {
+		if err := runBackupCommand(engine, os.Args[1:]); err != nil {
+			slog.Error(os.Args[1]+" failed", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "contract" {
+		path := ""
+		if len(os.Args) > 2 {
+			path = os.Args[2]
+		}
+		if err := engine.RunContractCheck(path); err != nil {
+			slog.Error("contract check failed", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err := engine.Run(); err != nil {
 		slog.Error("failed to run engine", slog.String("error", err.Error()))
 		os.Exit(1)
 	}
 }
+
+// runBackupCommand runs "backup <archive>" or "restore <archive>".
+func runBackupCommand(engine *bootstrap.Engine, args []string) error {
+	path := ""
+	if len(args) > 1 {
+		path = args[1]
+	}
+	if args[0] == "backup" {
+		return engine.RunBackup(path)
+	}
+	return engine.RunRestore(path)
+}
@@ -1560,6 +1560,40 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the resolved user, system role, tenant memberships, workspace memberships with roles\nand effective feature flags in a single call, intended for application startup.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Get my profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MeResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/me/access": {
             "post": {
                 "security": [
@@ -1614,14 +1648,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/me/roles": {
+        "/api/v1/me/invitations": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns the current user's roles. Always includes system role if assigned.\nOptionally includes tenant role if X-Tenant-ID header is provided.\nOptionally includes workspace role if X-Workspace-ID header is provided.",
+                "description": "Lists unexpired pending invitations sent to the current user's email.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1631,26 +1665,12 @@ const docTemplate = `{
                 "tags": [
                     "Me"
                 ],
-                "summary": "Get my roles",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Tenant ID to check role for",
-                        "name": "X-Tenant-ID",
-                        "in": "header"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Workspace ID to check role for",
-                        "name": "X-Workspace-ID",
-                        "in": "header"
-                    }
-                ],
+                "summary": "List my invitations",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MyRolesResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListResponse-github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto_MyInvitationResponse"
                         }
                     },
                     "401": {
@@ -1662,14 +1682,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/me/tenants": {
-            "get": {
+        "/api/v1/me/invitations/accept": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Lists tenants where the user is an active member. Supports pagination and optional search by name/code.",
+                "description": "Accepts the invitation from the email link. The invitation must be addressed to the current user's email.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1679,68 +1699,30 @@ const docTemplate = `{
                 "tags": [
                     "Me"
                 ],
-                "summary": "List my tenants with pagination and optional search",
+                "summary": "Accept invitation by token",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "perPage",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search query for tenant name or code",
-                        "name": "q",
-                        "in": "query"
+                        "description": "Token from the invitation email",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.InvitationTokenRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.PaginatedTenantsWithRoleResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MemberResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/system/injectables": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "System - Injectables"
-                ],
-                "summary": "List system injectables",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListSystemInjectablesResponse"
-                        }
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -1753,12 +1735,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/system/injectables/bulk/activate": {
-            "patch": {
+        "/api/v1/me/invitations/decline": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -1771,26 +1765,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "System - Injectables"
+                    "Me"
                 ],
-                "summary": "Bulk activate system injectables",
+                "summary": "Decline invitation by token",
                 "parameters": [
                     {
-                        "description": "Keys to activate",
+                        "description": "Token from the invitation email",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.BulkKeysRequest"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.InvitationTokenRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.BulkOperationResponse"
-                        }
+                    "204": {
+                        "description": "Invitation declined"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1809,11 +1800,23 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/system/injectables/bulk/assignments": {
+        "/api/v1/me/invitations/{invitationId}/accept": {
             "post": {
                 "security": [
                     {
@@ -1827,25 +1830,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "System - Injectables"
+                    "Me"
                 ],
-                "summary": "Bulk create scoped assignments",
+                "summary": "Accept invitation",
                 "parameters": [
                     {
-                        "description": "Keys and scope data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.BulkScopedAssignmentsRequest"
-                        }
+                        "type": "string",
+                        "description": "Invitation ID",
+                        "name": "invitationId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.BulkOperationResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MemberResponse"
                         }
                     },
                     "400": {
@@ -1860,15 +1861,23 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/me/invitations/{invitationId}/decline": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -1881,26 +1890,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "System - Injectables"
+                    "Me"
                 ],
-                "summary": "Bulk delete scoped assignments",
+                "summary": "Decline invitation",
                 "parameters": [
                     {
-                        "description": "Keys and scope data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.BulkScopedAssignmentsRequest"
-                        }
+                        "type": "string",
+                        "description": "Invitation ID",
+                        "name": "invitationId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.BulkOperationResponse"
-                        }
+                    "204": {
+                        "description": "Invitation declined"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1914,8 +1918,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
@@ -1923,13 +1933,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/system/injectables/bulk/deactivate": {
-            "patch": {
+        "/api/v1/me/notifications": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Lists the current user's in-product notifications, newest first. Includes the unread counter.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1937,25 +1948,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "System - Injectables"
+                    "Me"
                 ],
-                "summary": "Bulk deactivate system injectables",
+                "summary": "List my notifications",
                 "parameters": [
                     {
-                        "description": "Keys to deactivate",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.BulkKeysRequest"
-                        }
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "perPage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only return unread notifications",
+                        "name": "unreadOnly",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.BulkOperationResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.PaginatedNotificationsResponse"
                         }
                     },
                     "400": {
@@ -1969,18 +1991,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/api/v1/system/injectables/{key}/activate": {
-            "patch": {
+        "/api/v1/me/notifications/read-all": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -1993,36 +2009,49 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "System - Injectables"
-                ],
-                "summary": "Activate system injectable",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Injectable key",
-                        "name": "key",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Me"
                 ],
+                "summary": "Mark all notifications as read",
                 "responses": {
                     "204": {
-                        "description": "No Content"
+                        "description": "Notifications marked as read"
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
-                    },
-                    "403": {
-                        "description": "Forbidden",
+                    }
+                }
+            }
+        },
+        "/api/v1/me/notifications/unread-count": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the unread notification counter, intended for lightweight polling.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Count my unread notifications",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UnreadNotificationsCountResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
@@ -2030,8 +2059,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/system/injectables/{key}/assignments": {
-            "get": {
+        "/api/v1/me/notifications/{notificationId}/read": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -2044,24 +2073,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "System - Injectables"
+                    "Me"
                 ],
-                "summary": "List injectable assignments",
+                "summary": "Mark notification as read",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Injectable key",
-                        "name": "key",
+                        "description": "Notification ID",
+                        "name": "notificationId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListAssignmentsResponse"
-                        }
+                    "204": {
+                        "description": "Notification marked as read"
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -2069,26 +2095,55 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns locale, timezone and default workspace. Defaults (en, UTC) are returned until the user saves their own.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Get my preferences",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UserPreferencesResponse"
+                        }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "The locale sets the default render language of previews and how times are formatted in notifications;\nthe timezone is used to display schedules. The default workspace must be accessible to the user.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2096,32 +2151,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "System - Injectables"
+                    "Me"
                 ],
-                "summary": "Create injectable assignment",
+                "summary": "Update my preferences",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Injectable key",
-                        "name": "key",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Assignment data",
+                        "description": "Preferences",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.CreateAssignmentRequest"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateUserPreferencesRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.SystemInjectableAssignmentResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UserPreferencesResponse"
                         }
                     },
                     "400": {
@@ -2141,23 +2189,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/api/v1/system/injectables/{key}/assignments/{assignmentId}": {
-            "delete": {
+        "/api/v1/me/roles": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Returns the current user's roles. Always includes system role if assigned.\nOptionally includes tenant role if X-Tenant-ID header is provided.\nOptionally includes workspace role if X-Workspace-ID header is provided.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2165,43 +2208,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "System - Injectables"
+                    "Me"
                 ],
-                "summary": "Delete injectable assignment",
+                "summary": "Get my roles",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Injectable key",
-                        "name": "key",
-                        "in": "path",
-                        "required": true
+                        "description": "Tenant ID to check role for",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
                     },
                     {
                         "type": "string",
-                        "description": "Assignment ID",
-                        "name": "assignmentId",
-                        "in": "path",
-                        "required": true
+                        "description": "Workspace ID to check role for",
+                        "name": "X-Workspace-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MyRolesResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
@@ -2209,13 +2241,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/system/injectables/{key}/assignments/{assignmentId}/exclude": {
-            "patch": {
+        "/api/v1/me/tenants": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Lists tenants where the user is an active member. Supports pagination and optional search by name/code.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2223,43 +2256,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "System - Injectables"
+                    "Me"
                 ],
-                "summary": "Exclude injectable assignment",
+                "summary": "List my tenants with pagination and optional search",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Injectable key",
-                        "name": "key",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "perPage",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Assignment ID",
-                        "name": "assignmentId",
-                        "in": "path",
-                        "required": true
+                        "description": "Search query for tenant name or code",
+                        "name": "q",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.PaginatedTenantsWithRoleResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
@@ -2267,8 +2297,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/system/injectables/{key}/assignments/{assignmentId}/include": {
-            "patch": {
+        "/api/v1/system/injectables": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -2283,26 +2313,13 @@ const docTemplate = `{
                 "tags": [
                     "System - Injectables"
                 ],
-                "summary": "Include injectable assignment",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Injectable key",
-                        "name": "key",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Assignment ID",
-                        "name": "assignmentId",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                "summary": "List system injectables",
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListSystemInjectablesResponse"
+                        }
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -2315,17 +2332,11 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/api/v1/system/injectables/{key}/deactivate": {
+        "/api/v1/system/injectables/bulk/activate": {
             "patch": {
                 "security": [
                     {
@@ -2341,34 +2352,39 @@ const docTemplate = `{
                 "tags": [
                     "System - Injectables"
                 ],
-                "summary": "Deactivate system injectable",
+                "summary": "Bulk activate system injectables",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Injectable key",
-                        "name": "key",
-                        "in": "path",
-                        "required": true
+                        "description": "Keys to activate",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.BulkKeysRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.BulkOperationResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
@@ -2376,8 +2392,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/system/tenants": {
-            "get": {
+        "/api/v1/system/injectables/bulk/assignments": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -2390,36 +2406,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "System - Tenants"
+                    "System - Injectables"
                 ],
-                "summary": "List tenants with pagination",
+                "summary": "Bulk create scoped assignments",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "perPage",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search query (name or code)",
-                        "name": "q",
-                        "in": "query"
+                        "description": "Keys and scope data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.BulkScopedAssignmentsRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.PaginatedTenantsResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.BulkOperationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
                     "401": {
@@ -2436,7 +2447,7 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -2449,25 +2460,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "System - Tenants"
+                    "System - Injectables"
                 ],
-                "summary": "Create tenant",
+                "summary": "Bulk delete scoped assignments",
                 "parameters": [
                     {
-                        "description": "Tenant data",
+                        "description": "Keys and scope data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.CreateTenantRequest"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.BulkScopedAssignmentsRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.TenantResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.BulkOperationResponse"
                         }
                     },
                     "400": {
@@ -2487,18 +2498,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
-                    },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/api/v1/system/tenants/{tenantId}": {
-            "get": {
+        "/api/v1/system/injectables/bulk/deactivate": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -2511,46 +2516,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "System - Tenants"
+                    "System - Injectables"
                 ],
-                "summary": "Get tenant",
+                "summary": "Bulk deactivate system injectables",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Tenant ID",
-                        "name": "tenantId",
-                        "in": "path",
-                        "required": true
+                        "description": "Keys to deactivate",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.BulkKeysRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.TenantResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.BulkOperationResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/v1/system/injectables/{key}/activate": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -2563,39 +2572,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "System - Tenants"
+                    "System - Injectables"
                 ],
-                "summary": "Update tenant",
+                "summary": "Activate system injectable",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tenant ID",
-                        "name": "tenantId",
+                        "description": "Injectable key",
+                        "name": "key",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Tenant data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateTenantRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.TenantResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -2616,8 +2607,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/system/injectables/{key}/assignments": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -2630,21 +2623,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "System - Tenants"
+                    "System - Injectables"
                 ],
-                "summary": "Delete tenant",
+                "summary": "List injectable assignments",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tenant ID",
-                        "name": "tenantId",
+                        "description": "Injectable key",
+                        "name": "key",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListAssignmentsResponse"
+                        }
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -2665,10 +2661,8 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/system/tenants/{tenantId}/status": {
-            "patch": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -2681,32 +2675,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "System - Tenants"
+                    "System - Injectables"
                 ],
-                "summary": "Update tenant status",
+                "summary": "Create injectable assignment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tenant ID",
-                        "name": "tenantId",
+                        "description": "Injectable key",
+                        "name": "key",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Status data",
+                        "description": "Assignment data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateTenantStatusRequest"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.CreateAssignmentRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.TenantResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.SystemInjectableAssignmentResponse"
                         }
                     },
                     "400": {
@@ -2736,8 +2730,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/system/tenants/{tenantId}/workspaces": {
-            "get": {
+        "/api/v1/system/injectables/{key}/assignments/{assignmentId}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -2750,44 +2744,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "System - Tenants"
+                    "System - Injectables"
                 ],
-                "summary": "List tenant workspaces",
+                "summary": "Delete injectable assignment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tenant ID",
-                        "name": "tenantId",
+                        "description": "Injectable key",
+                        "name": "key",
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "perPage",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Search query (name)",
-                        "name": "q",
-                        "in": "query"
+                        "description": "Assignment ID",
+                        "name": "assignmentId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.PaginatedWorkspacesResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -2810,8 +2788,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/system/users": {
-            "get": {
+        "/api/v1/system/injectables/{key}/assignments/{assignmentId}/exclude": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -2824,69 +2802,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "System - Users"
+                    "System - Injectables"
                 ],
-                "summary": "List users with system roles",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListResponse-github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto_SystemRoleWithUserResponse"
-                        }
+                "summary": "Exclude injectable assignment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Injectable key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "System - Users"
-                ],
-                "summary": "Assign system role by email",
-                "parameters": [
                     {
-                        "description": "Email and role data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.AssignSystemRoleByEmailRequest"
-                        }
+                        "type": "string",
+                        "description": "Assignment ID",
+                        "name": "assignmentId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.SystemRoleWithUserResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -2899,12 +2836,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/system/users/{userId}/role": {
-            "post": {
+        "/api/v1/system/injectables/{key}/assignments/{assignmentId}/include": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -2917,39 +2860,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "System - Users"
+                    "System - Injectables"
                 ],
-                "summary": "Assign system role",
+                "summary": "Include injectable assignment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "userId",
+                        "description": "Injectable key",
+                        "name": "key",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Role data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.AssignSystemRoleRequest"
-                        }
+                        "type": "string",
+                        "description": "Assignment ID",
+                        "name": "assignmentId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.SystemRoleResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -2970,8 +2902,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/system/injectables/{key}/deactivate": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -2984,14 +2918,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "System - Users"
+                    "System - Injectables"
                 ],
-                "summary": "Revoke system role",
+                "summary": "Deactivate system injectable",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "userId",
+                        "description": "Injectable key",
+                        "name": "key",
                         "in": "path",
                         "required": true
                     }
@@ -3021,7 +2955,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/tenant": {
+        "/api/v1/system/maintenance": {
             "get": {
                 "security": [
                     {
@@ -3035,23 +2969,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenant"
-                ],
-                "summary": "Get current tenant",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Tenant ID",
-                        "name": "X-Tenant-ID",
-                        "in": "header",
-                        "required": true
-                    }
+                    "System - Maintenance"
                 ],
+                "summary": "Get maintenance state",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.TenantResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MaintenanceStateResponse"
                         }
                     },
                     "401": {
@@ -3065,12 +2990,6 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
-                        }
                     }
                 }
             },
@@ -3087,24 +3006,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenant"
+                    "System - Maintenance"
                 ],
-                "summary": "Update current tenant",
+                "summary": "Set maintenance mode",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Tenant ID",
-                        "name": "X-Tenant-ID",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "Tenant data",
+                        "description": "Maintenance mode",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateTenantRequest"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.SetMaintenanceRequest"
                         }
                     }
                 ],
@@ -3112,7 +3024,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.TenantResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MaintenanceStateResponse"
                         }
                     },
                     "400": {
@@ -3132,17 +3044,11 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/api/v1/tenant/document-types": {
+        "/api/v1/system/sessions": {
             "get": {
                 "security": [
                     {
@@ -3156,17 +3062,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenant - Document Types"
+                    "System - Sessions"
                 ],
-                "summary": "List document types",
+                "summary": "List sessions",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Tenant ID",
-                        "name": "X-Tenant-ID",
-                        "in": "header",
-                        "required": true
-                    },
                     {
                         "type": "integer",
                         "default": 1,
@@ -3176,14 +3075,20 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "default": 10,
+                        "default": 20,
                         "description": "Items per page",
                         "name": "perPage",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Search query for document type name or code",
+                        "description": "Principal type (USER, SERVICE_ACCOUNT, API_KEY)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search query (subject, email or name)",
                         "name": "q",
                         "in": "query"
                     }
@@ -3192,7 +3097,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.PaginatedDocumentTypesResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.PaginatedAuthSessionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
                     "401": {
@@ -3208,7 +3119,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/system/sessions/{sessionId}/force-reauth": {
             "post": {
                 "security": [
                     {
@@ -3222,32 +3135,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenant - Document Types"
+                    "System - Sessions"
                 ],
-                "summary": "Create document type",
+                "summary": "Force re-authentication",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tenant ID",
-                        "name": "X-Tenant-ID",
-                        "in": "header",
+                        "description": "Session ID",
+                        "name": "sessionId",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Document type data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.CreateDocumentTypeRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.DocumentTypeResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.AuthSessionResponse"
                         }
                     },
                     "400": {
@@ -3268,6 +3172,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
                     "409": {
                         "description": "Conflict",
                         "schema": {
@@ -3277,8 +3187,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/tenant/document-types/code/{code}": {
-            "get": {
+        "/api/v1/system/sessions/{sessionId}/revoke": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -3291,21 +3201,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenant - Document Types"
+                    "System - Sessions"
                 ],
-                "summary": "Get document type by code",
+                "summary": "Revoke session",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tenant ID",
-                        "name": "X-Tenant-ID",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Document Type Code",
-                        "name": "code",
+                        "description": "Session ID",
+                        "name": "sessionId",
                         "in": "path",
                         "required": true
                     }
@@ -3314,7 +3217,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.DocumentTypeResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.AuthSessionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
                     "401": {
@@ -3334,12 +3243,16 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/api/v1/tenant/document-types/code/{code}/templates": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -3352,21 +3265,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenant - Document Types"
+                    "System - Sessions"
                 ],
-                "summary": "List templates by document type code",
+                "summary": "Restore revoked session",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tenant ID",
-                        "name": "X-Tenant-ID",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Document Type Code",
-                        "name": "code",
+                        "description": "Session ID",
+                        "name": "sessionId",
                         "in": "path",
                         "required": true
                     }
@@ -3375,7 +3281,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListTemplatesResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.AuthSessionResponse"
                         }
                     },
                     "401": {
@@ -3395,11 +3301,17 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/tenant/document-types/{id}": {
+        "/api/v1/system/tenants": {
             "get": {
                 "security": [
                     {
@@ -3413,30 +3325,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenant - Document Types"
+                    "System - Tenants"
                 ],
-                "summary": "Get document type by ID",
+                "summary": "List tenants with pagination",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Tenant ID",
-                        "name": "X-Tenant-ID",
-                        "in": "header",
-                        "required": true
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "perPage",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Document Type ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Search query (name or code)",
+                        "name": "q",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.DocumentTypeResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.PaginatedTenantsResponse"
                         }
                     },
                     "401": {
@@ -3450,16 +3368,10 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
-                        }
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -3472,39 +3384,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenant - Document Types"
+                    "System - Tenants"
                 ],
-                "summary": "Update document type",
+                "summary": "Create tenant",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Tenant ID",
-                        "name": "X-Tenant-ID",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Document Type ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Document type data",
+                        "description": "Tenant data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateDocumentTypeRequest"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.CreateTenantRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.DocumentTypeResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.TenantResponse"
                         }
                     },
                     "400": {
@@ -3525,15 +3423,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/system/tenants/{tenantId}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -3546,44 +3446,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenant - Document Types"
+                    "System - Tenants"
                 ],
-                "summary": "Delete document type",
+                "summary": "Get tenant",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Tenant ID",
-                        "name": "X-Tenant-ID",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Document Type ID",
-                        "name": "id",
+                        "name": "tenantId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Delete options",
-                        "name": "request",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.DeleteDocumentTypeRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.DeleteDocumentTypeResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.TenantResponse"
                         }
                     },
                     "401": {
@@ -3605,56 +3484,8 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/tenant/members": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Tenant - Members"
-                ],
-                "summary": "List tenant members",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Tenant ID",
-                        "name": "X-Tenant-ID",
-                        "in": "header",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListResponse-github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto_TenantMemberResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
-                        }
-                    }
-                }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -3667,32 +3498,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenant - Members"
+                    "System - Tenants"
                 ],
-                "summary": "Add tenant member",
+                "summary": "Update tenant",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Tenant ID",
-                        "name": "X-Tenant-ID",
-                        "in": "header",
+                        "name": "tenantId",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Member data",
+                        "description": "Tenant data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.AddTenantMemberRequest"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateTenantRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.TenantMemberResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.TenantResponse"
                         }
                     },
                     "400": {
@@ -3713,17 +3544,15 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/tenant/members/{memberId}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -3736,31 +3565,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenant - Members"
+                    "System - Tenants"
                 ],
-                "summary": "Get tenant member",
+                "summary": "Delete tenant",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Tenant ID",
-                        "name": "X-Tenant-ID",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Member ID",
-                        "name": "memberId",
+                        "name": "tenantId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.TenantMemberResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -3781,8 +3600,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/v1/system/tenants/{tenantId}/status": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -3795,31 +3616,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenant - Members"
+                    "System - Tenants"
                 ],
-                "summary": "Update tenant member role",
+                "summary": "Update tenant status",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Tenant ID",
-                        "name": "X-Tenant-ID",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Member ID",
-                        "name": "memberId",
+                        "name": "tenantId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Role data",
+                        "description": "Status data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateTenantMemberRoleRequest"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateTenantStatusRequest"
                         }
                     }
                 ],
@@ -3827,7 +3641,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.TenantMemberResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.TenantResponse"
                         }
                     },
                     "400": {
@@ -3855,8 +3669,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/system/tenants/{tenantId}/workspaces": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -3869,33 +3685,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenant - Members"
+                    "System - Tenants"
                 ],
-                "summary": "Remove tenant member",
+                "summary": "List tenant workspaces",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Tenant ID",
-                        "name": "X-Tenant-ID",
-                        "in": "header",
+                        "name": "tenantId",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Member ID",
-                        "name": "memberId",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "perPage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search query (name)",
+                        "name": "q",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.PaginatedWorkspacesResponse"
                         }
                     },
                     "401": {
@@ -3919,7 +3745,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/tenant/workspaces": {
+        "/api/v1/system/users": {
             "get": {
                 "security": [
                     {
@@ -3933,49 +3759,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenant - Workspaces"
-                ],
-                "summary": "List workspaces with pagination and optional search",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Tenant ID",
-                        "name": "X-Tenant-ID",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "perPage",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search query for workspace name",
-                        "name": "q",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by status (ACTIVE, SUSPENDED, ARCHIVED)",
-                        "name": "status",
-                        "in": "query"
-                    }
+                    "System - Users"
                 ],
+                "summary": "List users with system roles",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.PaginatedWorkspacesResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListResponse-github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto_SystemRoleWithUserResponse"
                         }
                     },
                     "401": {
@@ -4005,24 +3796,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenant - Workspaces"
+                    "System - Users"
                 ],
-                "summary": "Create workspace in tenant",
+                "summary": "Assign system role by email",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Tenant ID",
-                        "name": "X-Tenant-ID",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "Workspace data",
+                        "description": "Email and role data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.CreateWorkspaceRequest"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.AssignSystemRoleByEmailRequest"
                         }
                     }
                 ],
@@ -4030,7 +3814,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.WorkspaceResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.SystemRoleWithUserResponse"
                         }
                     },
                     "400": {
@@ -4054,8 +3838,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/tenant/workspaces/{workspaceId}": {
-            "put": {
+        "/api/v1/system/users/{userId}/role": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -4068,31 +3852,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenant - Workspaces"
+                    "System - Users"
                 ],
-                "summary": "Update workspace in tenant",
+                "summary": "Assign system role",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tenant ID",
-                        "name": "X-Tenant-ID",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Workspace ID",
-                        "name": "workspaceId",
+                        "description": "User ID",
+                        "name": "userId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Workspace data",
+                        "description": "Role data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateWorkspaceRequest"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.AssignSystemRoleRequest"
                         }
                     }
                 ],
@@ -4100,7 +3877,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.WorkspaceResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.SystemRoleResponse"
                         }
                     },
                     "400": {
@@ -4126,12 +3903,6 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
-                    },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
-                        }
                     }
                 }
             },
@@ -4148,21 +3919,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenant - Workspaces"
+                    "System - Users"
                 ],
-                "summary": "Delete workspace from tenant",
+                "summary": "Revoke system role",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tenant ID",
-                        "name": "X-Tenant-ID",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Workspace ID",
-                        "name": "workspaceId",
+                        "description": "User ID",
+                        "name": "userId",
                         "in": "path",
                         "required": true
                     }
@@ -4192,8 +3956,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/tenant/workspaces/{workspaceId}/status": {
-            "patch": {
+        "/api/v1/tenant": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -4206,9 +3970,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenant - Workspaces"
+                    "Tenant"
                 ],
-                "summary": "Update workspace status",
+                "summary": "Get current tenant",
                 "parameters": [
                     {
                         "type": "string",
@@ -4216,21 +3980,66 @@ const docTemplate = `{
                         "name": "X-Tenant-ID",
                         "in": "header",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.TenantResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant"
+                ],
+                "summary": "Update current tenant",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Workspace ID",
-                        "name": "workspaceId",
-                        "in": "path",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
+                        "in": "header",
                         "required": true
                     },
                     {
-                        "description": "Status data",
+                        "description": "Tenant data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateWorkspaceStatusRequest"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateTenantRequest"
                         }
                     }
                 ],
@@ -4238,7 +4047,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.WorkspaceResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.TenantResponse"
                         }
                     },
                     "400": {
@@ -4268,8 +4077,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/workspace": {
+        "/api/v1/tenant/document-types": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -4277,40 +4091,65 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Workspaces"
+                    "Tenant - Document Types"
                 ],
-                "summary": "Get current workspace",
+                "summary": "List document types",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Workspace ID",
-                        "name": "X-Workspace-ID",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "perPage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search query for document type name or code",
+                        "name": "q",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.WorkspaceResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.PaginatedDocumentTypesResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     }
                 }
             },
-            "put": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -4318,32 +4157,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Workspaces"
+                    "Tenant - Document Types"
                 ],
-                "summary": "Update current workspace",
+                "summary": "Create document type",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Workspace ID",
-                        "name": "X-Workspace-ID",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "description": "Workspace data",
+                        "description": "Document type data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateWorkspaceRequest"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.CreateDocumentTypeRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.WorkspaceResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.DocumentTypeResponse"
                         }
                     },
                     "400": {
@@ -4352,15 +4191,34 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/tenant/document-types/code/{code}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -4368,24 +4226,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Workspaces"
+                    "Tenant - Document Types"
                 ],
-                "summary": "Archive current workspace",
+                "summary": "Get document type by code",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Workspace ID",
-                        "name": "X-Workspace-ID",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Document Type Code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.DocumentTypeResponse"
+                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
@@ -4399,8 +4273,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/workspace/document-types/{code}/render": {
-            "post": {
+        "/api/v1/tenant/document-types/code/{code}/templates": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -4410,67 +4284,94 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "produces": [
-                    "application/pdf"
+                    "application/json"
                 ],
                 "tags": [
-                    "Workspace - Render"
+                    "Tenant - Document Types"
                 ],
-                "summary": "Render PDF by document type",
+                "summary": "List templates by document type code",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tenant code",
-                        "name": "X-Tenant-Code",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
                         "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Workspace code",
-                        "name": "X-Workspace-Code",
-                        "in": "header",
+                        "description": "Document Type Code",
+                        "name": "code",
+                        "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListTemplatesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenant/document-types/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant - Document Types"
+                ],
+                "summary": "Get document type by ID",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Render environment: dev or prod",
-                        "name": "X-Environment",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
                         "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Document type code",
-                        "name": "code",
+                        "description": "Document Type ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Content disposition: inline (default) or attachment",
-                        "name": "disposition",
-                        "in": "query"
-                    },
-                    {
-                        "description": "Injectable values",
-                        "name": "request",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.RenderRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "file"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.DocumentTypeResponse"
                         }
                     },
                     "401": {
@@ -4490,18 +4391,15 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
-                        }
                     }
                 }
-            }
-        },
-        "/api/v1/workspace/folders": {
-            "get": {
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -4509,23 +4407,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Folders"
+                    "Tenant - Document Types"
                 ],
-                "summary": "List folders",
+                "summary": "Update document type",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Workspace ID",
-                        "name": "X-Workspace-ID",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Document Type ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Document type data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateDocumentTypeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListResponse-github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto_FolderResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.DocumentTypeResponse"
                         }
                     },
                     "400": {
@@ -4534,6 +4448,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -4542,7 +4468,12 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -4550,32 +4481,1634 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Folders"
+                    "Tenant - Document Types"
                 ],
-                "summary": "Create folder",
+                "summary": "Delete document type",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Workspace ID",
-                        "name": "X-Workspace-ID",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "description": "Folder data",
+                        "type": "string",
+                        "description": "Document Type ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Delete options",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.CreateFolderRequest"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.DeleteDocumentTypeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.DeleteDocumentTypeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenant/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant - Members"
+                ],
+                "summary": "List tenant members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListResponse-github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto_TenantMemberResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant - Members"
+                ],
+                "summary": "Add tenant member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Member data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.AddTenantMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.TenantMemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenant/members/{memberId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant - Members"
+                ],
+                "summary": "Get tenant member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member ID",
+                        "name": "memberId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.TenantMemberResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant - Members"
+                ],
+                "summary": "Update tenant member role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member ID",
+                        "name": "memberId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateTenantMemberRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.TenantMemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant - Members"
+                ],
+                "summary": "Remove tenant member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member ID",
+                        "name": "memberId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenant/workspaces": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant - Workspaces"
+                ],
+                "summary": "List workspaces with pagination and optional search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "perPage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search query for workspace name",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (ACTIVE, SUSPENDED, ARCHIVED)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.PaginatedWorkspacesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant - Workspaces"
+                ],
+                "summary": "Create workspace in tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Workspace data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.CreateWorkspaceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.WorkspaceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenant/workspaces/{workspaceId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant - Workspaces"
+                ],
+                "summary": "Update workspace in tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Workspace data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateWorkspaceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.WorkspaceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant - Workspaces"
+                ],
+                "summary": "Delete workspace from tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenant/workspaces/{workspaceId}/status": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant - Workspaces"
+                ],
+                "summary": "Update workspace status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "workspaceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateWorkspaceStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.WorkspaceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/workspace": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Get current workspace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.WorkspaceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Update current workspace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Workspace data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateWorkspaceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.WorkspaceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Archive current workspace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/workspace/document-types/{code}/render": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Workspace - Render"
+                ],
+                "summary": "Render PDF by document type",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant code",
+                        "name": "X-Tenant-Code",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Workspace code",
+                        "name": "X-Workspace-Code",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Render environment: dev or prod",
+                        "name": "X-Environment",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Document type code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Content disposition: inline (default) or attachment",
+                        "name": "disposition",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Injectable values",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.RenderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/workspace/folders": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Folders"
+                ],
+                "summary": "List folders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListResponse-github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto_FolderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Folders"
+                ],
+                "summary": "Create folder",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Folder data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.CreateFolderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.FolderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/workspace/folders/tree": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Folders"
+                ],
+                "summary": "Get folder tree",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.FolderTreeResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/workspace/folders/{folderId}": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Folders"
+                ],
+                "summary": "Get folder",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Folder ID",
+                        "name": "folderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.FolderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Folders"
+                ],
+                "summary": "Update folder",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Folder ID",
+                        "name": "folderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Folder data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateFolderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.FolderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Folders"
+                ],
+                "summary": "Delete folder",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Folder ID",
+                        "name": "folderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/workspace/folders/{folderId}/move": {
+            "patch": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Folders"
+                ],
+                "summary": "Move folder",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Folder ID",
+                        "name": "folderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Move data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MoveFolderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.FolderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/workspace/gallery": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gallery"
+                ],
+                "summary": "List gallery assets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 20)",
+                        "name": "perPage",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.GalleryListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gallery"
+                ],
+                "summary": "Delete gallery asset",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Asset key",
+                        "name": "key",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/workspace/gallery/search": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gallery"
+                ],
+                "summary": "Search gallery assets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 20)",
+                        "name": "perPage",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.GalleryListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/workspace/gallery/upload/complete": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gallery"
+                ],
+                "summary": "Complete gallery upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Upload completion",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.GalleryCompleteUploadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.GalleryCompleteUploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/workspace/gallery/upload/init": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gallery"
+                ],
+                "summary": "Initiate gallery upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Upload metadata",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.GalleryInitUploadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.GalleryInitUploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/workspace/gallery/url": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gallery"
+                ],
+                "summary": "Get gallery asset URL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Asset key",
+                        "name": "key",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.GalleryURLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/workspace/injectables": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Injectables"
+                ],
+                "summary": "List workspace injectables",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.FolderResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListWorkspaceInjectablesResponse"
                         }
                     },
                     "400": {
@@ -4583,12 +6116,16 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/api/v1/workspace/folders/tree": {
-            "get": {
+            },
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -4596,9 +6133,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Folders"
+                    "Injectables"
                 ],
-                "summary": "Get folder tree",
+                "summary": "Create workspace injectable",
                 "parameters": [
                     {
                         "type": "string",
@@ -4606,16 +6143,22 @@ const docTemplate = `{
                         "name": "X-Workspace-ID",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Injectable data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.CreateWorkspaceInjectableRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.FolderTreeResponse"
-                            }
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.WorkspaceInjectableResponse"
                         }
                     },
                     "400": {
@@ -4623,17 +6166,11 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/api/v1/workspace/folders/{folderId}": {
+        "/api/v1/workspace/injectables/{injectableId}": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -4642,9 +6179,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Folders"
+                    "Injectables"
                 ],
-                "summary": "Get folder",
+                "summary": "Get workspace injectable",
                 "parameters": [
                     {
                         "type": "string",
@@ -4655,8 +6192,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Folder ID",
-                        "name": "folderId",
+                        "description": "Injectable ID",
+                        "name": "injectableId",
                         "in": "path",
                         "required": true
                     }
@@ -4665,7 +6202,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.FolderResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.WorkspaceInjectableResponse"
                         }
                     },
                     "400": {
@@ -4690,9 +6227,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Folders"
+                    "Injectables"
                 ],
-                "summary": "Update folder",
+                "summary": "Update workspace injectable",
                 "parameters": [
                     {
                         "type": "string",
@@ -4703,18 +6240,18 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Folder ID",
-                        "name": "folderId",
+                        "description": "Injectable ID",
+                        "name": "injectableId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Folder data",
+                        "description": "Injectable data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateFolderRequest"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateWorkspaceInjectableRequest"
                         }
                     }
                 ],
@@ -4722,7 +6259,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.FolderResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.WorkspaceInjectableResponse"
                         }
                     },
                     "400": {
@@ -4747,9 +6284,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Folders"
+                    "Injectables"
                 ],
-                "summary": "Delete folder",
+                "summary": "Delete workspace injectable",
                 "parameters": [
                     {
                         "type": "string",
@@ -4760,8 +6297,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Folder ID",
-                        "name": "folderId",
+                        "description": "Injectable ID",
+                        "name": "injectableId",
                         "in": "path",
                         "required": true
                     }
@@ -4785,8 +6322,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/workspace/folders/{folderId}/move": {
-            "patch": {
+        "/api/v1/workspace/injectables/{injectableId}/activate": {
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -4794,9 +6331,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Folders"
+                    "Injectables"
                 ],
-                "summary": "Move folder",
+                "summary": "Activate injectable",
                 "parameters": [
                     {
                         "type": "string",
@@ -4807,26 +6344,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Folder ID",
-                        "name": "folderId",
+                        "description": "Injectable ID",
+                        "name": "injectableId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Move data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MoveFolderRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.FolderResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.WorkspaceInjectableResponse"
                         }
                     },
                     "400": {
@@ -4844,65 +6372,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/workspace/gallery": {
-            "get": {
-                "produces": [
+        "/api/v1/workspace/injectables/{injectableId}/deactivate": {
+            "post": {
+                "consumes": [
                     "application/json"
                 ],
-                "tags": [
-                    "Gallery"
-                ],
-                "summary": "List gallery assets",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Workspace ID",
-                        "name": "X-Workspace-ID",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number (default 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Items per page (default 20)",
-                        "name": "perPage",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.GalleryListResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
-                        }
-                    }
-                }
-            },
-            "delete": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Gallery"
+                    "Injectables"
                 ],
-                "summary": "Delete gallery asset",
+                "summary": "Deactivate injectable",
                 "parameters": [
                     {
                         "type": "string",
@@ -4913,15 +6394,18 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Asset key",
-                        "name": "key",
-                        "in": "query",
+                        "description": "Injectable ID",
+                        "name": "injectableId",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.WorkspaceInjectableResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -4929,8 +6413,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
@@ -4938,15 +6422,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/workspace/gallery/search": {
+        "/api/v1/workspace/invitations": {
             "get": {
+                "description": "Lists invitations still waiting for an answer. Expired ones are flagged with expired=true and can be resent.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Gallery"
+                    "Invitations"
                 ],
-                "summary": "Search gallery assets",
+                "summary": "List pending invitations",
                 "parameters": [
                     {
                         "type": "string",
@@ -4954,32 +6442,13 @@ const docTemplate = `{
                         "name": "X-Workspace-ID",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search query",
-                        "name": "q",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number (default 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Items per page (default 20)",
-                        "name": "perPage",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.GalleryListResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListResponse-github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto_InvitationResponse"
                         }
                     },
                     "400": {
@@ -4988,17 +6457,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/workspace/gallery/upload/complete": {
+            },
             "post": {
+                "description": "Creates a pending invitation and emails a link with an expiring token.\nNo user or membership is created until the invitee accepts.\nThe token is returned once so the link can also be shared manually.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5006,9 +6474,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Gallery"
+                    "Invitations"
                 ],
-                "summary": "Complete gallery upload",
+                "summary": "Create invitation",
                 "parameters": [
                     {
                         "type": "string",
@@ -5018,12 +6486,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Upload completion",
-                        "name": "body",
+                        "description": "Invitation data",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.GalleryCompleteUploadRequest"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.CreateInvitationRequest"
                         }
                     }
                 ],
@@ -5031,7 +6499,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.GalleryCompleteUploadResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.InvitationWithTokenResponse"
                         }
                     },
                     "400": {
@@ -5040,8 +6508,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
@@ -5049,8 +6523,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/workspace/gallery/upload/init": {
-            "post": {
+        "/api/v1/workspace/invitations/{invitationId}": {
+            "delete": {
                 "consumes": [
                     "application/json"
                 ],
@@ -5058,9 +6532,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Gallery"
+                    "Invitations"
                 ],
-                "summary": "Initiate gallery upload",
+                "summary": "Revoke invitation",
                 "parameters": [
                     {
                         "type": "string",
@@ -5070,30 +6544,37 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Upload metadata",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.GalleryInitUploadRequest"
-                        }
+                        "type": "string",
+                        "description": "Invitation ID",
+                        "name": "invitationId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "Invitation revoked"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.GalleryInitUploadResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
@@ -5101,15 +6582,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/workspace/gallery/url": {
-            "get": {
+        "/api/v1/workspace/invitations/{invitationId}/resend": {
+            "post": {
+                "description": "Replaces the token (the previous link stops working) and restarts the expiration window.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Gallery"
+                    "Invitations"
                 ],
-                "summary": "Get gallery asset URL",
+                "summary": "Resend invitation",
                 "parameters": [
                     {
                         "type": "string",
@@ -5120,9 +6605,9 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Asset key",
-                        "name": "key",
-                        "in": "query",
+                        "description": "Invitation ID",
+                        "name": "invitationId",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -5130,7 +6615,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.GalleryURLResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.InvitationWithTokenResponse"
                         }
                     },
                     "400": {
@@ -5139,8 +6624,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
@@ -5148,7 +6645,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/workspace/injectables": {
+        "/api/v1/workspace/members": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -5157,9 +6654,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Injectables"
+                    "Members"
                 ],
-                "summary": "List workspace injectables",
+                "summary": "List workspace members",
                 "parameters": [
                     {
                         "type": "string",
@@ -5173,7 +6670,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListWorkspaceInjectablesResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListResponse-github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto_MemberResponse"
                         }
                     },
                     "400": {
@@ -5191,6 +6688,7 @@ const docTemplate = `{
                 }
             },
             "post": {
+                "description": "Adds the user directly, creating a shadow user if the email is unknown.\nPrefer POST /api/v1/workspace/invitations, which waits for the invitee to accept.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5198,9 +6696,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Injectables"
+                    "Members"
                 ],
-                "summary": "Create workspace injectable",
+                "summary": "Invite member",
                 "parameters": [
                     {
                         "type": "string",
@@ -5210,12 +6708,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Injectable data",
+                        "description": "Member invitation data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.CreateWorkspaceInjectableRequest"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.InviteMemberRequest"
                         }
                     }
                 ],
@@ -5223,7 +6721,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.WorkspaceInjectableResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MemberResponse"
                         }
                     },
                     "400": {
@@ -5231,11 +6729,17 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/workspace/injectables/{injectableId}": {
+        "/api/v1/workspace/members/{memberId}": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -5244,9 +6748,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Injectables"
+                    "Members"
                 ],
-                "summary": "Get workspace injectable",
+                "summary": "Get member",
                 "parameters": [
                     {
                         "type": "string",
@@ -5257,8 +6761,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Injectable ID",
-                        "name": "injectableId",
+                        "description": "Member ID",
+                        "name": "memberId",
                         "in": "path",
                         "required": true
                     }
@@ -5267,7 +6771,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.WorkspaceInjectableResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MemberResponse"
                         }
                     },
                     "400": {
@@ -5292,9 +6796,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Injectables"
+                    "Members"
                 ],
-                "summary": "Update workspace injectable",
+                "summary": "Update member role",
                 "parameters": [
                     {
                         "type": "string",
@@ -5305,18 +6809,18 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Injectable ID",
-                        "name": "injectableId",
+                        "description": "Member ID",
+                        "name": "memberId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Injectable data",
+                        "description": "Role update data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateWorkspaceInjectableRequest"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateMemberRoleRequest"
                         }
                     }
                 ],
@@ -5324,7 +6828,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.WorkspaceInjectableResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MemberResponse"
                         }
                     },
                     "400": {
@@ -5349,9 +6853,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Injectables"
+                    "Members"
                 ],
-                "summary": "Delete workspace injectable",
+                "summary": "Remove member",
                 "parameters": [
                     {
                         "type": "string",
@@ -5362,8 +6866,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Injectable ID",
-                        "name": "injectableId",
+                        "description": "Member ID",
+                        "name": "memberId",
                         "in": "path",
                         "required": true
                     }
@@ -5387,8 +6891,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/workspace/injectables/{injectableId}/activate": {
+        "/api/v1/workspace/members/{memberId}/deactivate": {
             "post": {
+                "description": "Deactivated members keep their history but can no longer access the workspace. Owners must transfer ownership first.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5396,9 +6901,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Injectables"
+                    "Members"
                 ],
-                "summary": "Activate injectable",
+                "summary": "Deactivate member",
                 "parameters": [
                     {
                         "type": "string",
@@ -5409,8 +6914,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Injectable ID",
-                        "name": "injectableId",
+                        "description": "Member ID",
+                        "name": "memberId",
                         "in": "path",
                         "required": true
                     }
@@ -5419,7 +6924,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.WorkspaceInjectableResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MemberResponse"
                         }
                     },
                     "400": {
@@ -5433,11 +6938,17 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/workspace/injectables/{injectableId}/deactivate": {
+        "/api/v1/workspace/members/{memberId}/reactivate": {
             "post": {
                 "consumes": [
                     "application/json"
@@ -5446,9 +6957,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Injectables"
+                    "Members"
                 ],
-                "summary": "Deactivate injectable",
+                "summary": "Reactivate member",
                 "parameters": [
                     {
                         "type": "string",
@@ -5459,8 +6970,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Injectable ID",
-                        "name": "injectableId",
+                        "description": "Member ID",
+                        "name": "memberId",
                         "in": "path",
                         "required": true
                     }
@@ -5469,17 +6980,17 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.WorkspaceInjectableResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MemberResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
@@ -5487,7 +6998,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/workspace/members": {
+        "/api/v1/workspace/notification-webhooks": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -5496,9 +7007,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Members"
+                    "Notification Webhooks"
                 ],
-                "summary": "List workspace members",
+                "summary": "List notification webhooks",
                 "parameters": [
                     {
                         "type": "string",
@@ -5512,7 +7023,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListResponse-github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto_MemberResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListResponse-github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto_NotificationWebhookResponse"
                         }
                     },
                     "400": {
@@ -5521,8 +7032,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
@@ -5530,6 +7041,7 @@ const docTemplate = `{
                 }
             },
             "post": {
+                "description": "Webhook URLs must use the provider's official host (hooks.slack.com for Slack,\n*.webhook.office.com or *.logic.azure.com for Teams). Empty eventTypes subscribes to all events.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5537,9 +7049,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Members"
+                    "Notification Webhooks"
                 ],
-                "summary": "Invite member",
+                "summary": "Create notification webhook",
                 "parameters": [
                     {
                         "type": "string",
@@ -5549,12 +7061,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Member invitation data",
+                        "description": "Webhook data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.InviteMemberRequest"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.CreateNotificationWebhookRequest"
                         }
                     }
                 ],
@@ -5562,7 +7074,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MemberResponse"
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.NotificationWebhookResponse"
                         }
                     },
                     "400": {
@@ -5571,8 +7083,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
@@ -5580,7 +7092,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/workspace/members/{memberId}": {
+        "/api/v1/workspace/notification-webhooks/{webhookId}": {
             "get": {
                 "consumes": [
                     "application/json"
@@ -5589,9 +7101,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Members"
+                    "Notification Webhooks"
                 ],
-                "summary": "Get member",
+                "summary": "Get notification webhook",
                 "parameters": [
                     {
                         "type": "string",
@@ -5602,21 +7114,130 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Member ID",
-                        "name": "memberId",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.NotificationWebhookResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Omit url to keep the current webhook URL.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification Webhooks"
+                ],
+                "summary": "Update notification webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateNotificationWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.NotificationWebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification Webhooks"
+                ],
+                "summary": "Delete notification webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "X-Workspace-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MemberResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
@@ -5628,8 +7249,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/v1/workspace/notification-webhooks/{webhookId}/test": {
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -5637,9 +7260,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Members"
+                    "Notification Webhooks"
                 ],
-                "summary": "Update member role",
+                "summary": "Test notification webhook",
                 "parameters": [
                     {
                         "type": "string",
@@ -5650,27 +7273,15 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Member ID",
-                        "name": "memberId",
+                        "description": "Webhook ID",
+                        "name": "webhookId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Role update data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateMemberRoleRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MemberResponse"
-                        }
+                    "204": {
+                        "description": "Test message delivered"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -5678,6 +7289,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -5685,8 +7302,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/workspace/ownership-transfer": {
+            "post": {
+                "description": "Promotes the given member to OWNER and demotes the current owner to ADMIN in a single atomic update.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5696,7 +7316,7 @@ const docTemplate = `{
                 "tags": [
                     "Members"
                 ],
-                "summary": "Remove member",
+                "summary": "Transfer workspace ownership",
                 "parameters": [
                     {
                         "type": "string",
@@ -5706,11 +7326,13 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Member ID",
-                        "name": "memberId",
-                        "in": "path",
-                        "required": true
+                        "description": "New owner",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.TransferOwnershipRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -5723,6 +7345,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -6183,6 +7811,59 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.AuthSessionResponse": {
+            "type": "object",
+            "properties": {
+                "displayName": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "firstSeenAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ipAddress": {
+                    "type": "string"
+                },
+                "lastSeenAt": {
+                    "type": "string"
+                },
+                "principalType": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "reauthRequiredAt": {
+                    "type": "string"
+                },
+                "requestCount": {
+                    "type": "integer"
+                },
+                "revoked": {
+                    "type": "boolean"
+                },
+                "revokedAt": {
+                    "type": "string"
+                },
+                "revokedBy": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "userAgent": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
         "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.BulkKeysRequest": {
             "type": "object",
             "required": [
@@ -6340,6 +8021,53 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.CreateInvitationRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.CreateNotificationWebhookRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "provider",
+                "url"
+            ],
+            "properties": {
+                "eventTypes": {
+                    "description": "Empty = all events",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "provider": {
+                    "type": "string",
+                    "enum": [
+                        "SLACK",
+                        "TEAMS"
+                    ]
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
         "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.CreateTagRequest": {
             "type": "object",
             "required": [
@@ -6905,6 +8633,96 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.InvitationResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "expired": {
+                    "type": "boolean"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "invitedBy": {
+                    "type": "string"
+                },
+                "respondedAt": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "workspaceId": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.InvitationTokenRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.InvitationWithTokenResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "expired": {
+                    "type": "boolean"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "invitedBy": {
+                    "type": "string"
+                },
+                "respondedAt": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "workspaceId": {
+                    "type": "string"
+                }
+            }
+        },
         "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.InviteMemberRequest": {
             "type": "object",
             "required": [
@@ -6945,18 +8763,60 @@ const docTemplate = `{
                         "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.GroupResponse"
                     }
                 },
-                "items": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.InjectableResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListResponse-github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto_FolderResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.FolderResponse"
+                    }
+                }
+            }
+        },
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListResponse-github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto_InvitationResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.InvitationResponse"
+                    }
+                }
+            }
+        },
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListResponse-github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto_MemberResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.InjectableResponse"
+                        "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MemberResponse"
                     }
-                },
-                "total": {
-                    "type": "integer"
                 }
             }
         },
-        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListResponse-github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto_FolderResponse": {
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListResponse-github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto_MyInvitationResponse": {
             "type": "object",
             "properties": {
                 "count": {
@@ -6965,12 +8825,12 @@ const docTemplate = `{
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.FolderResponse"
+                        "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MyInvitationResponse"
                     }
                 }
             }
         },
-        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListResponse-github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto_MemberResponse": {
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.ListResponse-github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto_NotificationWebhookResponse": {
             "type": "object",
             "properties": {
                 "count": {
@@ -6979,7 +8839,7 @@ const docTemplate = `{
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MemberResponse"
+                        "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.NotificationWebhookResponse"
                     }
                 }
             }
@@ -7085,6 +8945,66 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MaintenanceStateResponse": {
+            "type": "object",
+            "properties": {
+                "expectedEndAt": {
+                    "type": "string"
+                },
+                "inFlightRenders": {
+                    "description": "Renders running on the instance that served the request",
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "readOnly": {
+                    "type": "boolean"
+                },
+                "rendersBlocked": {
+                    "type": "boolean"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MeResponse": {
+            "type": "object",
+            "properties": {
+                "features": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                },
+                "systemRole": {
+                    "description": "null when the user has no system role",
+                    "type": "string"
+                },
+                "tenants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.TenantWithRoleResponse"
+                    }
+                },
+                "user": {
+                    "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UserBriefResponse"
+                },
+                "workspaces": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.WorkspaceResponse"
+                    }
+                }
+            }
+        },
         "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MemberResponse": {
             "type": "object",
             "properties": {
@@ -7136,6 +9056,50 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MyInvitationResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "expired": {
+                    "type": "boolean"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "invitedBy": {
+                    "type": "string"
+                },
+                "respondedAt": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tenantId": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "workspaceId": {
+                    "type": "string"
+                },
+                "workspaceName": {
+                    "type": "string"
+                }
+            }
+        },
         "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.MyRolesResponse": {
             "type": "object",
             "properties": {
@@ -7147,6 +9111,87 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.NotificationResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "read": {
+                    "type": "boolean"
+                },
+                "readAt": {
+                    "type": "string"
+                },
+                "resourceId": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "workspaceId": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.NotificationWebhookResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "maskedUrl": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "workspaceId": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.PaginatedAuthSessionsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.AuthSessionResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.PaginationMeta"
+                }
+            }
+        },
         "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.PaginatedDocumentTypesResponse": {
             "type": "object",
             "properties": {
@@ -7161,6 +9206,23 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.PaginatedNotificationsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.NotificationResponse"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.PaginationMeta"
+                },
+                "unreadCount": {
+                    "type": "integer"
+                }
+            }
+        },
         "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.PaginatedTenantsResponse": {
             "type": "object",
             "properties": {
@@ -7292,6 +9354,26 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.SetMaintenanceRequest": {
+            "type": "object",
+            "required": [
+                "mode"
+            ],
+            "properties": {
+                "expectedEndAt": {
+                    "description": "Used for the Retry-After header",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Shown to users while active",
+                    "type": "string"
+                },
+                "mode": {
+                    "description": "OFF, READ_ONLY or DRAIN",
+                    "type": "string"
+                }
+            }
+        },
         "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.SystemInjectableAssignmentResponse": {
             "type": "object",
             "properties": {
@@ -7969,6 +10051,25 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.TransferOwnershipRequest": {
+            "type": "object",
+            "required": [
+                "memberId"
+            ],
+            "properties": {
+                "memberId": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UnreadNotificationsCountResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
         "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateDocumentTypeRequest": {
             "type": "object",
             "required": [
@@ -8013,6 +10114,30 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateNotificationWebhookRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
         "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateTagRequest": {
             "type": "object",
             "required": [
@@ -8095,6 +10220,26 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateUserPreferencesRequest": {
+            "type": "object",
+            "required": [
+                "locale",
+                "timezone"
+            ],
+            "properties": {
+                "defaultWorkspaceId": {
+                    "type": "string"
+                },
+                "locale": {
+                    "description": "BCP 47 tag, e.g. \"es-CL\"",
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "IANA name, e.g. \"America/Santiago\"",
+                    "type": "string"
+                }
+            }
+        },
         "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UpdateVersionRequest": {
             "type": "object",
             "properties": {
@@ -8191,6 +10336,24 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.UserPreferencesResponse": {
+            "type": "object",
+            "properties": {
+                "defaultWorkspaceId": {
+                    "description": "null when not set",
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
         "github_com_rendis_pdf-forge_core_internal_adapters_primary_http_dto.WorkspaceInjectableResponse": {
             "type": "object",
             "properties": {
@@ -2,20 +2,24 @@ package extensions
 
 import (
 	"context"
-	"encoding/json"
 
+	"github.com/rendis/pdf-forge/core/internal/core/payload"
 	"github.com/rendis/pdf-forge/core/internal/core/port"
 )
 
+// maxPayloadBytes caps the render payload the mapper accepts when it is not streamed.
+const maxPayloadBytes = 50 << 20
+
 // ExampleMapper implements port.RequestMapper.
-// Parses the raw render request body as a JSON object.
+// Parses the render payload (the injectables of the request) as a JSON object.
 // Replace this with your own parsing logic if your payload has a different structure.
+// For very large payloads, walk the decoder field by field with Fields.
 type ExampleMapper struct{}
 
 func (m *ExampleMapper) Map(_ context.Context, mapCtx *port.MapperContext) (any, error) {
-	var payload map[string]any
-	if err := json.Unmarshal(mapCtx.RawBody, &payload); err != nil {
+	var body map[string]any
+	if err := mapCtx.Decoder(payload.Options{MaxBytes: maxPayloadBytes}).Decode(&body); err != nil {
 		return nil, err
 	}
-	return payload, nil
+	return body, nil
 }
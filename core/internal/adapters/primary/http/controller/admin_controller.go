@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -12,6 +14,7 @@ import (
 	accessuc "github.com/rendis/pdf-forge/core/internal/core/usecase/access"
 	injectableuc "github.com/rendis/pdf-forge/core/internal/core/usecase/injectable"
 	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
+	platformuc "github.com/rendis/pdf-forge/core/internal/core/usecase/platform"
 )
 
 // NewAdminController creates a new admin controller.
@@ -19,11 +22,25 @@ func NewAdminController(
 	tenantUC organizationuc.TenantUseCase,
 	systemRoleUC accessuc.SystemRoleUseCase,
 	systemInjectableUC injectableuc.SystemInjectableUseCase,
+	scriptedInjectorUC injectableuc.ScriptedInjectorUseCase,
+	sessionUC accessuc.AuthSessionUseCase,
+	maintenanceUC platformuc.MaintenanceUseCase,
+	systemStatsUC platformuc.SystemStatsUseCase,
+	cleanupUC platformuc.CleanupUseCase,
+	renderFailureUC platformuc.RenderFailureUseCase,
+	renderQuotaUC platformuc.RenderQuotaUseCase,
 ) *AdminController {
 	return &AdminController{
 		tenantUC:           tenantUC,
 		systemRoleUC:       systemRoleUC,
 		systemInjectableUC: systemInjectableUC,
+		scriptedInjectorUC: scriptedInjectorUC,
+		sessionUC:          sessionUC,
+		maintenanceUC:      maintenanceUC,
+		systemStatsUC:      systemStatsUC,
+		cleanupUC:          cleanupUC,
+		renderFailureUC:    renderFailureUC,
+		renderQuotaUC:      renderQuotaUC,
 	}
 }
 
@@ -33,6 +50,13 @@ type AdminController struct {
 	tenantUC           organizationuc.TenantUseCase
 	systemRoleUC       accessuc.SystemRoleUseCase
 	systemInjectableUC injectableuc.SystemInjectableUseCase
+	scriptedInjectorUC injectableuc.ScriptedInjectorUseCase
+	sessionUC          accessuc.AuthSessionUseCase
+	maintenanceUC      platformuc.MaintenanceUseCase
+	systemStatsUC      platformuc.SystemStatsUseCase
+	cleanupUC          platformuc.CleanupUseCase
+	renderFailureUC    platformuc.RenderFailureUseCase
+	renderQuotaUC      platformuc.RenderQuotaUseCase
 }
 
 // RegisterRoutes registers all admin routes.
@@ -52,12 +76,53 @@ func (c *AdminController) RegisterRoutes(rg *gin.RouterGroup) {
 		system.DELETE("/tenants/:tenantId", middleware.RequireSuperAdmin(), c.DeleteTenant)
 		system.GET("/tenants/:tenantId/workspaces", c.ListTenantWorkspaces)
 
+		// Bulk workspace operations: SUPERADMIN only
+		system.PATCH("/tenants/:tenantId/workspaces/bulk/suspend", middleware.RequireSuperAdmin(), c.BulkSuspendWorkspaces)
+		system.PATCH("/tenants/:tenantId/workspaces/bulk/reactivate", middleware.RequireSuperAdmin(), c.BulkReactivateWorkspaces)
+		system.PATCH("/tenants/:tenantId/workspaces/bulk/settings", middleware.RequireSuperAdmin(), c.BulkUpdateWorkspaceSettings)
+		system.PATCH("/tenants/:tenantId/workspaces/bulk/webhook-secrets", middleware.RequireSuperAdmin(), c.BulkRotateWebhookSecrets)
+
 		// System roles management (SUPERADMIN only)
 		system.GET("/users", middleware.RequireSuperAdmin(), c.ListSystemUsers)
 		system.POST("/users", middleware.RequireSuperAdmin(), c.AssignSystemRoleByEmail)
 		system.POST("/users/:userId/role", middleware.RequireSuperAdmin(), c.AssignSystemRole)
 		system.DELETE("/users/:userId/role", middleware.RequireSuperAdmin(), c.RevokeSystemRole)
 
+		// Session introspection for incident response (SUPERADMIN only)
+		sessions := system.Group("/sessions", middleware.RequireSuperAdmin())
+		{
+			sessions.GET("", c.ListSessions)
+			sessions.POST("/:sessionId/revoke", c.RevokeSession)
+			sessions.DELETE("/:sessionId/revoke", c.RestoreSession)
+			sessions.POST("/:sessionId/force-reauth", c.ForceReauth)
+		}
+
+		// Maintenance mode
+		// Get: PLATFORM_ADMIN+
+		// Switch: SUPERADMIN only (stays reachable while maintenance is active)
+		system.GET("/maintenance", c.GetMaintenance)
+		system.PUT("/maintenance", middleware.RequireSuperAdmin(), c.SetMaintenance)
+
+		// Platform overview (PLATFORM_ADMIN+)
+		system.GET("/stats", c.GetSystemStats)
+
+		// Orphaned data cleanup (runs: PLATFORM_ADMIN+, run now: SUPERADMIN)
+		system.GET("/cleanup/runs", c.ListCleanupRuns)
+		system.POST("/cleanup/runs", middleware.RequireSuperAdmin(), c.RunCleanup)
+
+		// Captured failed compiles (list: PLATFORM_ADMIN+, download with the source: SUPERADMIN)
+		system.GET("/render-failures", c.ListRenderFailures)
+		system.GET("/render-failures/:failureId/download", middleware.RequireSuperAdmin(), c.DownloadRenderFailure)
+
+		// Render quotas (view: PLATFORM_ADMIN+, set and remove: SUPERADMIN)
+		system.GET("/quotas", c.ListRenderQuotas)
+		system.GET("/tenants/:tenantId/quota", c.GetRenderQuota)
+		system.PUT("/tenants/:tenantId/quota", middleware.RequireSuperAdmin(), c.SetRenderQuota)
+		system.DELETE("/tenants/:tenantId/quota", middleware.RequireSuperAdmin(), c.DeleteRenderQuota)
+		system.GET("/tenants/:tenantId/workspaces/:workspaceId/quota", c.GetRenderQuota)
+		system.PUT("/tenants/:tenantId/workspaces/:workspaceId/quota", middleware.RequireSuperAdmin(), c.SetRenderQuota)
+		system.DELETE("/tenants/:tenantId/workspaces/:workspaceId/quota", middleware.RequireSuperAdmin(), c.DeleteRenderQuota)
+
 		// System injectables management
 		// List: PLATFORM_ADMIN+
 		// Activate/Deactivate and assignments: SUPERADMIN only
@@ -77,6 +142,16 @@ func (c *AdminController) RegisterRoutes(rg *gin.RouterGroup) {
 			injectables.PATCH("/bulk/deactivate", middleware.RequireSuperAdmin(), c.BulkDeactivate)
 			injectables.POST("/bulk/assignments", middleware.RequireSuperAdmin(), c.BulkCreateAssignments)
 			injectables.DELETE("/bulk/assignments", middleware.RequireSuperAdmin(), c.BulkDeleteAssignments)
+
+			// Scripted injectors
+			// List/Get: PLATFORM_ADMIN+
+			// Create/Update/Delete/Test: SUPERADMIN only
+			injectables.GET("/scripts", c.ListScriptedInjectors)
+			injectables.GET("/scripts/:code", c.GetScriptedInjector)
+			injectables.POST("/scripts", middleware.RequireSuperAdmin(), c.CreateScriptedInjector)
+			injectables.PUT("/scripts/:code", middleware.RequireSuperAdmin(), c.UpdateScriptedInjector)
+			injectables.DELETE("/scripts/:code", middleware.RequireSuperAdmin(), c.DeleteScriptedInjector)
+			injectables.POST("/scripts/test", middleware.RequireSuperAdmin(), c.TestScript)
 		}
 	}
 }
@@ -310,6 +385,151 @@ func (c *AdminController) ListTenantWorkspaces(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, mapper.WorkspacesToPaginatedResponse(workspaces, total, req.Page, req.PerPage))
 }
 
+// BulkSuspendWorkspaces suspends workspaces of a tenant.
+// Requires SUPERADMIN role.
+// @Summary Bulk suspend tenant workspaces
+// @Description Suspends the listed workspaces, or every client workspace of the tenant that is not archived when the list is empty. Each workspace succeeds or fails on its own; workspaces already suspended succeed.
+// @Tags System - Tenants
+// @Accept json
+// @Produce json
+// @Param tenantId path string true "Tenant ID"
+// @Param request body dto.BulkWorkspacesRequest true "Workspaces to suspend"
+// @Success 200 {object} dto.BulkWorkspacesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/system/tenants/{tenantId}/workspaces/bulk/suspend [patch]
+// @Security BearerAuth
+func (c *AdminController) BulkSuspendWorkspaces(ctx *gin.Context) {
+	c.bulkUpdateWorkspaceStatus(ctx, entity.WorkspaceStatusSuspended)
+}
+
+// BulkReactivateWorkspaces reactivates suspended workspaces of a tenant.
+// Requires SUPERADMIN role.
+// @Summary Bulk reactivate tenant workspaces
+// @Description Reactivates the listed workspaces, or every client workspace of the tenant that is not archived when the list is empty. Each workspace succeeds or fails on its own; active workspaces succeed. Archived workspaces fail.
+// @Tags System - Tenants
+// @Accept json
+// @Produce json
+// @Param tenantId path string true "Tenant ID"
+// @Param request body dto.BulkWorkspacesRequest true "Workspaces to reactivate"
+// @Success 200 {object} dto.BulkWorkspacesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/system/tenants/{tenantId}/workspaces/bulk/reactivate [patch]
+// @Security BearerAuth
+func (c *AdminController) BulkReactivateWorkspaces(ctx *gin.Context) {
+	c.bulkUpdateWorkspaceStatus(ctx, entity.WorkspaceStatusActive)
+}
+
+// BulkUpdateWorkspaceSettings sets settings sections on workspaces of a tenant.
+// Requires SUPERADMIN role.
+// @Summary Bulk update tenant workspace settings
+// @Description Sets the settings sections in the request, such as the renderOptions flags, on the listed workspaces, or on every client workspace of the tenant that is not archived when the list is empty. Sections not in the request keep their value. Each workspace succeeds or fails on its own; system and archived workspaces fail, and so do workspaces whose tenant locked a section with another value.
+// @Tags System - Tenants
+// @Accept json
+// @Produce json
+// @Param tenantId path string true "Tenant ID"
+// @Param request body dto.BulkWorkspaceSettingsRequest true "Workspaces and settings sections"
+// @Success 200 {object} dto.BulkWorkspacesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/system/tenants/{tenantId}/workspaces/bulk/settings [patch]
+// @Security BearerAuth
+func (c *AdminController) BulkUpdateWorkspaceSettings(ctx *gin.Context) {
+	updatedBy, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, entity.ErrUnauthorized)
+		return
+	}
+
+	var req dto.BulkWorkspaceSettingsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := c.tenantUC.BulkUpdateWorkspaceSettings(ctx.Request.Context(), organizationuc.BulkWorkspaceSettingsCommand{
+		TenantID:     ctx.Param("tenantId"),
+		WorkspaceIDs: req.WorkspaceIDs,
+		Settings:     mapper.WorkspaceSettingsFromDTO(req.Settings),
+		UpdatedBy:    updatedBy,
+	})
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.BulkWorkspaceResultToResponse(result))
+}
+
+// BulkRotateWebhookSecrets rotates the event webhook signing secrets of workspaces of a tenant.
+// Requires SUPERADMIN role.
+// @Summary Bulk rotate tenant event webhook secrets
+// @Description Replaces the signing secret of every event webhook of the listed workspaces, or of every client workspace of the tenant that is not archived when the list is empty. The response carries the new secrets, which are not returned again, including those of webhooks rotated before their workspace failed. Each workspace succeeds or fails on its own; workspaces without webhooks succeed, system and archived workspaces fail.
+// @Tags System - Tenants
+// @Accept json
+// @Produce json
+// @Param tenantId path string true "Tenant ID"
+// @Param request body dto.BulkWorkspacesRequest true "Workspaces whose webhook secrets to rotate"
+// @Success 200 {object} dto.BulkWebhookSecretsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/system/tenants/{tenantId}/workspaces/bulk/webhook-secrets [patch]
+// @Security BearerAuth
+func (c *AdminController) BulkRotateWebhookSecrets(ctx *gin.Context) {
+	rotatedBy, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, entity.ErrUnauthorized)
+		return
+	}
+
+	var req dto.BulkWorkspacesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := c.tenantUC.BulkRotateWebhookSecrets(ctx.Request.Context(), organizationuc.BulkRotateWebhookSecretsCommand{
+		TenantID:     ctx.Param("tenantId"),
+		WorkspaceIDs: req.WorkspaceIDs,
+		RotatedBy:    rotatedBy,
+	})
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.BulkWebhookSecretsResultToResponse(result))
+}
+
+func (c *AdminController) bulkUpdateWorkspaceStatus(ctx *gin.Context, status entity.WorkspaceStatus) {
+	var req dto.BulkWorkspacesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := c.tenantUC.BulkUpdateWorkspaceStatus(ctx.Request.Context(), organizationuc.BulkWorkspaceStatusCommand{
+		TenantID:     ctx.Param("tenantId"),
+		WorkspaceIDs: req.WorkspaceIDs,
+		Status:       status,
+	})
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.BulkWorkspaceResultToResponse(result))
+}
+
 // --- System Role Handlers ---
 
 // ListSystemUsers lists all users with system roles.
@@ -452,6 +672,462 @@ func (c *AdminController) RevokeSystemRole(ctx *gin.Context) {
 	ctx.Status(http.StatusNoContent)
 }
 
+// --- Session Handlers ---
+
+// ListSessions lists authenticated principals, most recently active first.
+// Requires SUPERADMIN role.
+// @Summary List sessions
+// @Tags System - Sessions
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param perPage query int false "Items per page" default(20)
+// @Param type query string false "Principal type (USER, SERVICE_ACCOUNT, API_KEY)"
+// @Param q query string false "Search query (subject, email or name)"
+// @Success 200 {object} dto.PaginatedAuthSessionsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/system/sessions [get]
+// @Security BearerAuth
+func (c *AdminController) ListSessions(ctx *gin.Context) {
+	var req dto.AuthSessionListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	filters := mapper.AuthSessionListRequestToFilters(req)
+	sessions, total, err := c.sessionUC.ListSessions(ctx.Request.Context(), filters)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.AuthSessionsToPaginatedResponse(sessions, total, req.Page, req.PerPage))
+}
+
+// RevokeSession revokes an API key or service account session.
+// Requires SUPERADMIN role.
+// @Summary Revoke session
+// @Tags System - Sessions
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} dto.AuthSessionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/system/sessions/{sessionId}/revoke [post]
+// @Security BearerAuth
+func (c *AdminController) RevokeSession(ctx *gin.Context) {
+	revokedBy, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, entity.ErrUnauthorized)
+		return
+	}
+
+	cmd := mapper.RevokeSessionToCommand(ctx.Param("sessionId"), revokedBy)
+	session, err := c.sessionUC.RevokeSession(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.AuthSessionToResponse(session))
+}
+
+// RestoreSession lifts the revocation of a session.
+// Requires SUPERADMIN role.
+// @Summary Restore revoked session
+// @Tags System - Sessions
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} dto.AuthSessionResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/system/sessions/{sessionId}/revoke [delete]
+// @Security BearerAuth
+func (c *AdminController) RestoreSession(ctx *gin.Context) {
+	session, err := c.sessionUC.RestoreSession(ctx.Request.Context(), ctx.Param("sessionId"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.AuthSessionToResponse(session))
+}
+
+// ForceReauth rejects tokens of a user or service account issued before now.
+// Requires SUPERADMIN role.
+// @Summary Force re-authentication
+// @Tags System - Sessions
+// @Accept json
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} dto.AuthSessionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/system/sessions/{sessionId}/force-reauth [post]
+// @Security BearerAuth
+func (c *AdminController) ForceReauth(ctx *gin.Context) {
+	session, err := c.sessionUC.ForceReauth(ctx.Request.Context(), ctx.Param("sessionId"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.AuthSessionToResponse(session))
+}
+
+// --- Maintenance Handlers ---
+
+// GetMaintenance returns the maintenance state and the renders in flight on this instance.
+// @Summary Get maintenance state
+// @Tags System - Maintenance
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.MaintenanceStateResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/system/maintenance [get]
+// @Security BearerAuth
+func (c *AdminController) GetMaintenance(ctx *gin.Context) {
+	state, err := c.maintenanceUC.GetState(ctx.Request.Context())
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.MaintenanceToStateResponse(state, c.maintenanceUC.InFlightRenders()))
+}
+
+// SetMaintenance switches the maintenance mode for every instance.
+// Requires SUPERADMIN role.
+// @Summary Set maintenance mode
+// @Tags System - Maintenance
+// @Accept json
+// @Produce json
+// @Param request body dto.SetMaintenanceRequest true "Maintenance mode"
+// @Success 200 {object} dto.MaintenanceStateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/system/maintenance [put]
+// @Security BearerAuth
+func (c *AdminController) SetMaintenance(ctx *gin.Context) {
+	updatedBy, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, entity.ErrUnauthorized)
+		return
+	}
+
+	var req dto.SetMaintenanceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	cmd := mapper.SetMaintenanceRequestToCommand(req, updatedBy)
+	state, err := c.maintenanceUC.SetState(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.MaintenanceToStateResponse(state, c.maintenanceUC.InFlightRenders()))
+}
+
+// --- Stats Handlers ---
+
+// GetSystemStats returns the platform overview: entity counts, renders and error rates per day,
+// queue health and storage usage over time.
+// @Summary Get platform stats
+// @Description Renders are counted by every instance and flushed every minute. Storage covers asset library
+// @Description files and hosted PDFs still stored. The render queue is the one of the instance that served
+// @Description the request; everything else is platform-wide and cached for a minute.
+// @Tags System - Stats
+// @Accept json
+// @Produce json
+// @Param days query int false "Days of renders and storage to return, today included (1-365)" default(30)
+// @Success 200 {object} dto.SystemStatsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/system/stats [get]
+// @Security BearerAuth
+func (c *AdminController) GetSystemStats(ctx *gin.Context) {
+	days, ok := positiveQueryInt(ctx, "days")
+	if !ok {
+		return
+	}
+	if days == 0 {
+		days = 30
+	}
+
+	stats, err := c.systemStatsUC.GetSystemStats(ctx.Request.Context(), days)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.SystemStatsToResponse(stats))
+}
+
+// --- Cleanup Handlers ---
+
+// ListCleanupRuns lists the latest runs of the orphaned data cleanup, scheduled or manual.
+// @Summary List cleanup runs
+// @Tags System - Cleanup
+// @Accept json
+// @Produce json
+// @Param limit query int false "Runs to return (max 100)" default(20)
+// @Success 200 {object} dto.ListCleanupRunsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/system/cleanup/runs [get]
+// @Security BearerAuth
+func (c *AdminController) ListCleanupRuns(ctx *gin.Context) {
+	limit, ok := positiveQueryInt(ctx, "limit")
+	if !ok {
+		return
+	}
+
+	runs, err := c.cleanupUC.ListCleanupRuns(ctx.Request.Context(), limit)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.CleanupRunsToResponse(runs))
+}
+
+// RunCleanup runs the orphaned data cleanup now and returns its report.
+// Requires SUPERADMIN role.
+// @Summary Run cleanup
+// @Description Finds version injectables of deleted versions, unused image assets, expired preview tokens
+// @Description and stale sandboxes, and deletes them unless dryRun is true. Each category handles at most
+// @Description cleanup.max_per_category rows per run. Unused images are only deleted when
+// @Description cleanup.unused_image_retention_days is set.
+// @Tags System - Cleanup
+// @Accept json
+// @Produce json
+// @Param request body dto.RunCleanupRequest true "Cleanup run"
+// @Success 200 {object} dto.CleanupRunResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/system/cleanup/runs [post]
+// @Security BearerAuth
+func (c *AdminController) RunCleanup(ctx *gin.Context) {
+	triggeredBy, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, entity.ErrUnauthorized)
+		return
+	}
+
+	var req dto.RunCleanupRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	run, err := c.cleanupUC.RunCleanup(ctx.Request.Context(), platformuc.RunCleanupCommand{
+		DryRun:      *req.DryRun,
+		TriggeredBy: triggeredBy,
+	})
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.CleanupRunToResponse(run))
+}
+
+// --- Render Failure Handlers ---
+
+// ListRenderFailures lists the captured Typst input of failed compiles, newest first.
+// @Summary List render failures
+// @Description Renders whose Typst compile failed keep their generated source, asset manifest and compiler
+// @Description output for render_failures.retention_hours. The failed render answers with the capture ID in
+// @Description X-Render-Failure-ID and RenderFailed events carry it as failureId.
+// @Tags System - Render Failures
+// @Accept json
+// @Produce json
+// @Param limit query int false "Failures to return (max 100)" default(20)
+// @Success 200 {object} dto.ListRenderFailuresResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/system/render-failures [get]
+// @Security BearerAuth
+func (c *AdminController) ListRenderFailures(ctx *gin.Context) {
+	limit, ok := positiveQueryInt(ctx, "limit")
+	if !ok {
+		return
+	}
+
+	failures, err := c.renderFailureUC.ListRenderFailures(ctx.Request.Context(), limit)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.RenderFailuresToResponse(failures))
+}
+
+// DownloadRenderFailure downloads a captured failed compile as a zip.
+// Requires SUPERADMIN role: the source holds the rendered customer data.
+// @Summary Download render failure
+// @Description Zip with main.typ (the generated source), stderr.txt (the compiler output), assets.json
+// @Description (the images and PDFs the source referenced, without their content) and failure.json.
+// @Tags System - Render Failures
+// @Produce application/zip
+// @Param failureId path string true "Render failure ID"
+// @Success 200 {file} application/zip
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/system/render-failures/{failureId}/download [get]
+// @Security BearerAuth
+func (c *AdminController) DownloadRenderFailure(ctx *gin.Context) {
+	archive, err := c.renderFailureUC.DownloadRenderFailure(ctx.Request.Context(), ctx.Param("failureId"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", archive.Filename))
+	ctx.Data(http.StatusOK, "application/zip", archive.Archive)
+}
+
+// --- Render Quota Handlers ---
+
+// ListRenderQuotas lists the render quotas of tenants and workspaces with the defaults they override.
+// @Summary List render quotas
+// @Tags System - Render Quotas
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.RenderQuotaListResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/system/quotas [get]
+// @Security BearerAuth
+func (c *AdminController) ListRenderQuotas(ctx *gin.Context) {
+	quotas, err := c.renderQuotaUC.ListQuotas(ctx.Request.Context())
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	tenant, workspace := c.renderQuotaUC.Defaults()
+	ctx.JSON(http.StatusOK, mapper.RenderQuotasToListResponse(quotas, tenant, workspace))
+}
+
+// GetRenderQuota returns the render limits in force for a tenant, or one of its workspaces, and
+// its usage this month.
+// @Summary Get render quota
+// @Description Served at /system/tenants/{tenantId}/quota for a tenant and at
+// @Description /system/tenants/{tenantId}/workspaces/{workspaceId}/quota for a workspace.
+// @Tags System - Render Quotas
+// @Accept json
+// @Produce json
+// @Param tenantId path string true "Tenant ID"
+// @Success 200 {object} dto.RenderQuotaStatusResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/system/tenants/{tenantId}/quota [get]
+// @Security BearerAuth
+func (c *AdminController) GetRenderQuota(ctx *gin.Context) {
+	status, err := c.renderQuotaUC.GetQuota(ctx.Request.Context(), ctx.Param("tenantId"), workspaceIDParam(ctx))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.RenderQuotaStatusToResponse(status))
+}
+
+// SetRenderQuota overrides the render limits of a tenant or workspace.
+// Requires SUPERADMIN role.
+// @Summary Set render quota
+// @Description A null limit takes the default and zero is unlimited. Other instances apply it on their
+// @Description next refresh. Also served at /system/tenants/{tenantId}/workspaces/{workspaceId}/quota.
+// @Tags System - Render Quotas
+// @Accept json
+// @Produce json
+// @Param tenantId path string true "Tenant ID"
+// @Param request body dto.SetRenderQuotaRequest true "Render limits"
+// @Success 200 {object} dto.RenderQuotaStatusResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/system/tenants/{tenantId}/quota [put]
+// @Security BearerAuth
+func (c *AdminController) SetRenderQuota(ctx *gin.Context) {
+	updatedBy, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, entity.ErrUnauthorized)
+		return
+	}
+
+	var req dto.SetRenderQuotaRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cmd := mapper.SetRenderQuotaRequestToCommand(req, ctx.Param("tenantId"), workspaceIDParam(ctx), updatedBy)
+	status, err := c.renderQuotaUC.SetQuota(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.RenderQuotaStatusToResponse(status))
+}
+
+// DeleteRenderQuota removes the render quota of a tenant or workspace, so the defaults apply again.
+// Requires SUPERADMIN role.
+// @Summary Delete render quota
+// @Description Also served at /system/tenants/{tenantId}/workspaces/{workspaceId}/quota.
+// @Tags System - Render Quotas
+// @Param tenantId path string true "Tenant ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/system/tenants/{tenantId}/quota [delete]
+// @Security BearerAuth
+func (c *AdminController) DeleteRenderQuota(ctx *gin.Context) {
+	if err := c.renderQuotaUC.DeleteQuota(ctx.Request.Context(), ctx.Param("tenantId"), workspaceIDParam(ctx)); err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// workspaceIDParam returns the workspaceId path parameter, or nil on tenant routes.
+func workspaceIDParam(ctx *gin.Context) *string {
+	if id := ctx.Param("workspaceId"); id != "" {
+		return &id
+	}
+	return nil
+}
+
 // --- System Injectable Handlers ---
 
 // ListSystemInjectables lists all system injectables with their active state.
@@ -799,4 +1475,185 @@ func toBulkResponse(result *injectableuc.BulkAssignmentResult) dto.BulkOperation
 	}
 }
 
+// --- Scripted Injector Handlers ---
+
+// ListScriptedInjectors lists all scripted injectors.
+// @Summary List scripted injectors
+// @Tags System - Injectables
+// @Produce json
+// @Success 200 {object} dto.ListScriptedInjectorsResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/system/injectables/scripts [get]
+// @Security BearerAuth
+func (c *AdminController) ListScriptedInjectors(ctx *gin.Context) {
+	scripts, err := c.scriptedInjectorUC.List(ctx.Request.Context())
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToListScriptedInjectorsResponse(scripts))
+}
+
+// GetScriptedInjector gets a scripted injector with its source.
+// @Summary Get scripted injector
+// @Tags System - Injectables
+// @Produce json
+// @Param code path string true "Injector code"
+// @Success 200 {object} dto.ScriptedInjectorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/system/injectables/scripts/{code} [get]
+// @Security BearerAuth
+func (c *AdminController) GetScriptedInjector(ctx *gin.Context) {
+	script, err := c.scriptedInjectorUC.Get(ctx.Request.Context(), ctx.Param("code"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToScriptedInjectorResponse(script))
+}
+
+// CreateScriptedInjector creates a scripted injector and registers it.
+// Requires SUPERADMIN role.
+// @Summary Create scripted injector
+// @Tags System - Injectables
+// @Accept json
+// @Produce json
+// @Param request body dto.ScriptedInjectorRequest true "Scripted injector"
+// @Success 201 {object} dto.ScriptedInjectorResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/system/injectables/scripts [post]
+// @Security BearerAuth
+func (c *AdminController) CreateScriptedInjector(ctx *gin.Context) {
+	c.saveScriptedInjector(ctx, "", c.scriptedInjectorUC.Create, http.StatusCreated)
+}
+
+// UpdateScriptedInjector replaces a scripted injector.
+// Requires SUPERADMIN role.
+// @Summary Update scripted injector
+// @Tags System - Injectables
+// @Accept json
+// @Produce json
+// @Param code path string true "Injector code"
+// @Param request body dto.ScriptedInjectorRequest true "Scripted injector"
+// @Success 200 {object} dto.ScriptedInjectorResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/system/injectables/scripts/{code} [put]
+// @Security BearerAuth
+func (c *AdminController) UpdateScriptedInjector(ctx *gin.Context) {
+	c.saveScriptedInjector(ctx, ctx.Param("code"), c.scriptedInjectorUC.Update, http.StatusOK)
+}
+
+// saveScriptedInjector binds a scripted injector and saves it with save. A non-empty code
+// overrides the one in the body.
+func (c *AdminController) saveScriptedInjector(
+	ctx *gin.Context,
+	code string,
+	save func(context.Context, injectableuc.SaveScriptedInjectorCommand) (*entity.ScriptedInjector, error),
+	status int,
+) {
+	userID, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, entity.ErrUnauthorized)
+		return
+	}
+
+	var req dto.ScriptedInjectorRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if code != "" {
+		req.Code = code
+	}
+
+	script, err := save(ctx.Request.Context(), injectableuc.SaveScriptedInjectorCommand{
+		Code:         req.Code,
+		Label:        req.Label,
+		Description:  req.Description,
+		DataType:     entity.InjectableDataType(req.DataType),
+		Source:       req.Source,
+		Dependencies: req.Dependencies,
+		IsCritical:   req.IsCritical,
+		TimeoutMs:    req.TimeoutMs,
+		UserID:       userID,
+	})
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(status, dto.ToScriptedInjectorResponse(script))
+}
+
+// DeleteScriptedInjector deletes a scripted injector and unregisters it.
+// Requires SUPERADMIN role.
+// @Summary Delete scripted injector
+// @Tags System - Injectables
+// @Param code path string true "Injector code"
+// @Success 204 "No Content"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/system/injectables/scripts/{code} [delete]
+// @Security BearerAuth
+func (c *AdminController) DeleteScriptedInjector(ctx *gin.Context) {
+	if err := c.scriptedInjectorUC.Delete(ctx.Request.Context(), ctx.Param("code")); err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// TestScript runs a script against a sample input without saving it.
+// Requires SUPERADMIN role.
+// @Summary Test a script
+// @Tags System - Injectables
+// @Accept json
+// @Produce json
+// @Param request body dto.TestScriptRequest true "Script and sample input"
+// @Success 200 {object} dto.TestScriptResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/system/injectables/scripts/test [post]
+// @Security BearerAuth
+func (c *AdminController) TestScript(ctx *gin.Context) {
+	var req dto.TestScriptRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	run, err := c.scriptedInjectorUC.Test(ctx.Request.Context(), injectableuc.TestScriptCommand{
+		Source:         req.Source,
+		DataType:       entity.InjectableDataType(req.DataType),
+		TimeoutMs:      req.TimeoutMs,
+		Payload:        req.Payload,
+		Resolved:       req.Resolved,
+		TenantCode:     req.TenantCode,
+		WorkspaceCode:  req.WorkspaceCode,
+		Environment:    req.Environment,
+		SelectedFormat: req.SelectedFormat,
+		Headers:        req.Headers,
+	})
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.ToTestScriptResponse(run))
+}
+
 // --- Helper Functions ---
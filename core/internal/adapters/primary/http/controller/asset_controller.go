@@ -0,0 +1,493 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/mapper"
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/middleware"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	cataloguc "github.com/rendis/pdf-forge/core/internal/core/usecase/catalog"
+)
+
+// AssetController handles the asset library of a workspace: images, PDFs and fonts that
+// templates reference through asset:// URLs instead of remote URLs or inline data.
+type AssetController struct {
+	assetUC cataloguc.AssetUseCase
+}
+
+// NewAssetController creates a new asset controller.
+func NewAssetController(assetUC cataloguc.AssetUseCase) *AssetController {
+	return &AssetController{assetUC: assetUC}
+}
+
+// RegisterRoutes registers all /workspace/assets routes.
+func (c *AssetController) RegisterRoutes(rg *gin.RouterGroup, middlewareProvider *middleware.Provider) {
+	assets := rg.Group("/workspace/assets")
+	assets.Use(middlewareProvider.WorkspaceContext())
+	{
+		assets.GET("", c.ListAssets)                                                        // VIEWER+
+		assets.POST("", middleware.RequireEditor(), c.UploadAsset)                          // EDITOR+
+		assets.POST("/import", middleware.RequireEditor(), c.ImportAsset)                   // EDITOR+
+		assets.GET("/:assetId", c.GetAsset)                                                 // VIEWER+
+		assets.PUT("/:assetId", middleware.RequireEditor(), c.UpdateAsset)                  // EDITOR+
+		assets.DELETE("/:assetId", middleware.RequireEditor(), c.DeleteAsset)               // EDITOR+
+		assets.GET("/:assetId/content", c.GetAssetContent)                                  // VIEWER+
+		assets.GET("/:assetId/image", c.GetAssetImage)                                      // VIEWER+
+		assets.GET("/:assetId/versions", c.ListAssetVersions)                               // VIEWER+
+		assets.POST("/:assetId/versions", middleware.RequireEditor(), c.UploadAssetVersion) // EDITOR+
+		assets.GET("/:assetId/usages", c.ListAssetUsages)                                   // VIEWER+
+		assets.GET("/:assetId/signed-url", c.GetAssetSignedURL)                             // VIEWER+
+	}
+}
+
+// RegisterPublicRoutes registers routes reachable without an account.
+// The signature in the URL is the only credential.
+func (c *AssetController) RegisterPublicRoutes(public *gin.RouterGroup) {
+	public.GET("/assets/:workspaceId/:assetId", c.GetSignedAsset)
+}
+
+// ListAssets lists the assets of the current workspace, newest first.
+// @Summary List assets
+// @Tags Assets
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param kind query string false "Asset kind" Enums(IMAGE, PDF, FONT)
+// @Param tag query string false "Only assets with this tag"
+// @Param q query string false "Search by name"
+// @Success 200 {object} dto.ListResponse[dto.AssetResponse]
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/assets [get]
+// @Security BearerAuth
+func (c *AssetController) ListAssets(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	assets, err := c.assetUC.ListAssets(ctx.Request.Context(), workspaceID, port.AssetFilters{
+		Kind:   ctx.Query("kind"),
+		Tag:    ctx.Query("tag"),
+		Search: ctx.Query("q"),
+	})
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewListResponse(mapper.AssetsToResponses(assets)))
+}
+
+// UploadAsset adds a file to the asset library. The type is detected from the content.
+// A file whose content is already in the workspace returns the existing asset with duplicate set.
+// @Summary Upload asset
+// @Tags Assets
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param request body dto.UploadAssetRequest true "Asset file"
+// @Success 201 {object} dto.UploadAssetResponse
+// @Success 200 {object} dto.UploadAssetResponse "Existing asset with the same content"
+// @Failure 400 {object} dto.ErrorResponse "Unsupported type or file too large"
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/assets [post]
+// @Security BearerAuth
+func (c *AssetController) UploadAsset(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	userID, _ := middleware.GetInternalUserID(ctx)
+
+	var req dto.UploadAssetRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := c.assetUC.UploadAsset(ctx.Request.Context(), cataloguc.UploadAssetCommand{
+		WorkspaceID: workspaceID,
+		Name:        req.Name,
+		Tags:        req.Tags,
+		Data:        req.File,
+		CreatedBy:   userID,
+	})
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	status := http.StatusCreated
+	if result.Duplicate {
+		status = http.StatusOK
+	}
+	ctx.JSON(status, mapper.UploadAssetResultToResponse(result))
+}
+
+// ImportAsset downloads a remote image into the asset library and records its URL, so renders of
+// templates that reference the URL use the stored copy instead of downloading it. A URL already
+// imported, or an image already in the workspace, returns the existing asset with duplicate set.
+// @Summary Import asset from URL
+// @Tags Assets
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param request body dto.ImportAssetRequest true "Remote image"
+// @Success 201 {object} dto.UploadAssetResponse
+// @Success 200 {object} dto.UploadAssetResponse "Existing asset with the same URL or content"
+// @Failure 400 {object} dto.ErrorResponse "Invalid URL, download failed or not an image"
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/assets/import [post]
+// @Security BearerAuth
+func (c *AssetController) ImportAsset(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	userID, _ := middleware.GetInternalUserID(ctx)
+
+	var req dto.ImportAssetRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := c.assetUC.ImportAsset(ctx.Request.Context(), cataloguc.ImportAssetCommand{
+		WorkspaceID: workspaceID,
+		URL:         req.URL,
+		Name:        req.Name,
+		Tags:        req.Tags,
+		CreatedBy:   userID,
+	})
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	status := http.StatusCreated
+	if result.Duplicate {
+		status = http.StatusOK
+	}
+	ctx.JSON(status, mapper.UploadAssetResultToResponse(result))
+}
+
+// GetAsset returns an asset of the current workspace.
+// @Summary Get asset
+// @Tags Assets
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param assetId path string true "Asset ID"
+// @Success 200 {object} dto.AssetResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/assets/{assetId} [get]
+// @Security BearerAuth
+func (c *AssetController) GetAsset(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	asset, err := c.assetUC.GetAsset(ctx.Request.Context(), workspaceID, ctx.Param("assetId"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.AssetToResponse(asset))
+}
+
+// UpdateAsset renames or retags an asset. The tags replace the current ones.
+// @Summary Update asset
+// @Tags Assets
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param assetId path string true "Asset ID"
+// @Param request body dto.UpdateAssetRequest true "Asset name and tags"
+// @Success 200 {object} dto.AssetResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/assets/{assetId} [put]
+// @Security BearerAuth
+func (c *AssetController) UpdateAsset(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	var req dto.UpdateAssetRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	asset, err := c.assetUC.UpdateAsset(ctx.Request.Context(), cataloguc.UpdateAssetCommand{
+		WorkspaceID: workspaceID,
+		ID:          ctx.Param("assetId"),
+		Name:        req.Name,
+		Tags:        req.Tags,
+	})
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.AssetToResponse(asset))
+}
+
+// DeleteAsset deletes an asset with all its versions.
+// @Summary Delete asset
+// @Tags Assets
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param assetId path string true "Asset ID"
+// @Success 204 "Asset deleted"
+// @Failure 400 {object} dto.ErrorResponse "Asset is referenced by templates"
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/assets/{assetId} [delete]
+// @Security BearerAuth
+func (c *AssetController) DeleteAsset(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	if err := c.assetUC.DeleteAsset(ctx.Request.Context(), workspaceID, ctx.Param("assetId")); err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetAssetContent returns the file of an asset, by default its current version.
+// @Summary Get asset content
+// @Tags Assets
+// @Produce octet-stream
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param assetId path string true "Asset ID"
+// @Param version query int false "Version number; defaults to the current version"
+// @Success 200 {file} file
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/assets/{assetId}/content [get]
+// @Security BearerAuth
+func (c *AssetController) GetAssetContent(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	version, ok := positiveQueryInt(ctx, "version")
+	if !ok {
+		return
+	}
+
+	content, err := c.assetUC.GetAssetContent(ctx.Request.Context(), workspaceID, ctx.Param("assetId"), version)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, no-cache")
+	ctx.Data(http.StatusOK, content.ContentType, content.Data)
+}
+
+// GetAssetImage returns an image asset resized for display. Without format, the response is WebP
+// or AVIF when an encoder for it is registered and listed in Accept, else the format of the source.
+// SVG and WebP sources are returned as stored.
+// @Summary Get resized asset image
+// @Tags Assets
+// @Produce png,jpeg
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param assetId path string true "Asset ID"
+// @Param w query int false "Width in pixels (1-4096); images are never enlarged"
+// @Param format query string false "Output format: png, jpeg or a registered one such as webp"
+// @Param q query int false "Quality of lossy formats (1-100, default 80)"
+// @Param version query int false "Version number; defaults to the current version"
+// @Success 200 {file} file
+// @Success 304 "Not modified"
+// @Failure 400 {object} dto.ErrorResponse "Not an image, invalid options or unsupported format"
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/assets/{assetId}/image [get]
+// @Security BearerAuth
+func (c *AssetController) GetAssetImage(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	width, ok := positiveQueryInt(ctx, "w")
+	if !ok {
+		return
+	}
+	quality, ok := positiveQueryInt(ctx, "q")
+	if !ok {
+		return
+	}
+	version, ok := positiveQueryInt(ctx, "version")
+	if !ok {
+		return
+	}
+
+	img, err := c.assetUC.GetAssetImage(ctx.Request.Context(), cataloguc.GetAssetImageCommand{
+		WorkspaceID: workspaceID,
+		ID:          ctx.Param("assetId"),
+		Version:     version,
+		Width:       width,
+		Format:      ctx.Query("format"),
+		Quality:     quality,
+		Accept:      ctx.GetHeader("Accept"),
+	})
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	// A pinned version never changes; the current one is revalidated with the ETag.
+	if version > 0 {
+		ctx.Header("Cache-Control", "private, max-age=31536000, immutable")
+	} else {
+		ctx.Header("Cache-Control", "private, no-cache")
+	}
+	etag := `"` + img.Key + `"`
+	ctx.Header("ETag", etag)
+	ctx.Header("Vary", "Accept")
+	if ctx.GetHeader("If-None-Match") == etag {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+	ctx.Data(http.StatusOK, img.ContentType, img.Data)
+}
+
+// ListAssetVersions lists the versions of an asset, newest first.
+// @Summary List asset versions
+// @Tags Assets
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param assetId path string true "Asset ID"
+// @Success 200 {object} dto.ListResponse[dto.AssetVersionResponse]
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/assets/{assetId}/versions [get]
+// @Security BearerAuth
+func (c *AssetController) ListAssetVersions(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	versions, err := c.assetUC.ListAssetVersions(ctx.Request.Context(), workspaceID, ctx.Param("assetId"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewListResponse(mapper.AssetVersionsToResponses(versions)))
+}
+
+// UploadAssetVersion replaces the content of an asset with a new version. Every template that
+// references the asset renders the new version from then on.
+// @Summary Upload asset version
+// @Tags Assets
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param assetId path string true "Asset ID"
+// @Param request body dto.UploadAssetVersionRequest true "New file"
+// @Success 200 {object} dto.AssetResponse
+// @Failure 400 {object} dto.ErrorResponse "Unsupported type, different kind or file too large"
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/assets/{assetId}/versions [post]
+// @Security BearerAuth
+func (c *AssetController) UploadAssetVersion(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	userID, _ := middleware.GetInternalUserID(ctx)
+
+	var req dto.UploadAssetVersionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	asset, err := c.assetUC.UploadAssetVersion(ctx.Request.Context(), cataloguc.UploadAssetVersionCommand{
+		WorkspaceID: workspaceID,
+		ID:          ctx.Param("assetId"),
+		Data:        req.File,
+		CreatedBy:   userID,
+	})
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.AssetToResponse(asset))
+}
+
+// ListAssetUsages lists the template versions whose content references an asset.
+// @Summary List asset usages
+// @Tags Assets
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param assetId path string true "Asset ID"
+// @Success 200 {object} dto.ListResponse[dto.AssetUsageResponse]
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/assets/{assetId}/usages [get]
+// @Security BearerAuth
+func (c *AssetController) ListAssetUsages(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	usages, err := c.assetUC.ListAssetUsages(ctx.Request.Context(), workspaceID, ctx.Param("assetId"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewListResponse(mapper.AssetUsagesToResponses(usages)))
+}
+
+// positiveQueryInt parses an optional positive integer query parameter, 0 when absent.
+// It responds with 400 and returns false when the value is invalid.
+func positiveQueryInt(ctx *gin.Context, name string) (int, bool) {
+	v := ctx.Query(name)
+	if v == "" {
+		return 0, true
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		respondError(ctx, http.StatusBadRequest, fmt.Errorf("invalid %s %q", name, v))
+		return 0, false
+	}
+	return n, true
+}
+
+// GetAssetSignedURL returns a URL that serves the current version of an asset without an account,
+// e.g. to embed it in an email, until it expires.
+// @Summary Get signed asset URL
+// @Tags Assets
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param assetId path string true "Asset ID"
+// @Success 200 {object} dto.AssetSignedURLResponse
+// @Failure 400 {object} dto.ErrorResponse "Signed URLs are disabled"
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/assets/{assetId}/signed-url [get]
+// @Security BearerAuth
+func (c *AssetController) GetAssetSignedURL(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	signed, err := c.assetUC.SignAssetURL(ctx.Request.Context(), workspaceID, ctx.Param("assetId"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.AssetSignedURLResponse{URL: signed.URL, ExpiresAt: signed.ExpiresAt})
+}
+
+// GetSignedAsset serves the current version of an asset through a URL returned by
+// GetAssetSignedURL. The signature is the only credential.
+// @Summary Get asset by signed URL
+// @Tags Public
+// @Produce octet-stream
+// @Param workspaceId path string true "Workspace ID"
+// @Param assetId path string true "Asset ID"
+// @Param expires query string true "Expiry, in Unix seconds"
+// @Param signature query string true "URL signature"
+// @Success 200 {file} file
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/public/assets/{workspaceId}/{assetId} [get]
+func (c *AssetController) GetSignedAsset(ctx *gin.Context) {
+	content, err := c.assetUC.OpenSignedAsset(ctx.Request.Context(),
+		ctx.Param("workspaceId"), ctx.Param("assetId"), ctx.Query("expires"), ctx.Query("signature"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, max-age=300")
+	ctx.Data(http.StatusOK, content.ContentType, content.Data)
+}
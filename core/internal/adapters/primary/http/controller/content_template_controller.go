@@ -1,6 +1,9 @@
 package controller
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 
@@ -15,6 +18,10 @@ import (
 // ContentTemplateController handles template-related HTTP requests.
 type ContentTemplateController struct {
 	templateUC        templateuc.TemplateUseCase
+	slaUC             templateuc.TemplateSLAUseCase
+	bundleUC          templateuc.TemplateBundleUseCase
+	libraryUC         templateuc.TemplateLibraryUseCase
+	coverageUC        templateuc.InjectableCoverageUseCase
 	templateMapper    *mapper.TemplateMapper
 	versionController *TemplateVersionController
 }
@@ -22,11 +29,19 @@ type ContentTemplateController struct {
 // NewContentTemplateController creates a new template controller.
 func NewContentTemplateController(
 	templateUC templateuc.TemplateUseCase,
+	slaUC templateuc.TemplateSLAUseCase,
+	bundleUC templateuc.TemplateBundleUseCase,
+	libraryUC templateuc.TemplateLibraryUseCase,
+	coverageUC templateuc.InjectableCoverageUseCase,
 	templateMapper *mapper.TemplateMapper,
 	versionController *TemplateVersionController,
 ) *ContentTemplateController {
 	return &ContentTemplateController{
 		templateUC:        templateUC,
+		slaUC:             slaUC,
+		bundleUC:          bundleUC,
+		libraryUC:         libraryUC,
+		coverageUC:        coverageUC,
 		templateMapper:    templateMapper,
 		versionController: versionController,
 	}
@@ -50,6 +65,16 @@ func (c *ContentTemplateController) RegisterRoutes(rg *gin.RouterGroup, middlewa
 			templates.DELETE("/:templateId", middleware.RequireAdmin(), c.DeleteTemplate)     // ADMIN+
 			templates.POST("/:templateId/clone", middleware.RequireEditor(), c.CloneTemplate) // EDITOR+
 
+			// Portable bundles for moving templates between workspaces and environments
+			templates.GET("/:templateId/export", c.ExportTemplateBundle)                 // VIEWER+
+			templates.POST("/import", middleware.RequireAdmin(), c.ImportTemplateBundle) // ADMIN+ (publishes and archives versions)
+
+			// Tenant library: install linked copies and pull their upstream updates
+			templates.GET("/library", c.ListLibraryTemplates)                                                    // VIEWER+
+			templates.POST("/library/:templateId/install", middleware.RequireEditor(), c.InstallLibraryTemplate) // EDITOR+
+			templates.GET("/:templateId/library-update", c.PreviewLibraryUpdate)                                 // VIEWER+
+			templates.POST("/:templateId/library-update", middleware.RequireEditor(), c.PullLibraryUpdate)       // EDITOR+
+
 			// Template tag routes (tags belong to templates, not versions)
 			templates.POST("/:templateId/tags", middleware.RequireEditor(), c.AddTemplateTags)            // EDITOR+
 			templates.DELETE("/:templateId/tags/:tagId", middleware.RequireEditor(), c.RemoveTemplateTag) // EDITOR+
@@ -57,6 +82,14 @@ func (c *ContentTemplateController) RegisterRoutes(rg *gin.RouterGroup, middlewa
 			// Document type assignment
 			templates.PUT("/:templateId/document-type", middleware.RequireEditor(), c.AssignDocumentType) // EDITOR+
 
+			// Render expectations
+			templates.GET("/:templateId/sla", c.GetTemplateSLA)                                   // VIEWER+
+			templates.PUT("/:templateId/sla", middleware.RequireEditor(), c.SetTemplateSLA)       // EDITOR+
+			templates.DELETE("/:templateId/sla", middleware.RequireEditor(), c.DeleteTemplateSLA) // EDITOR+
+
+			// Where injectable values come from in renders
+			templates.GET("/:templateId/injectable-coverage", c.GetInjectableCoverage) // VIEWER+
+
 			// Version routes (nested under templates)
 			c.versionController.RegisterRoutes(templates)
 		}
@@ -184,10 +217,13 @@ func (c *ContentTemplateController) GetTemplateWithAllVersions(ctx *gin.Context)
 // @Produce json
 // @Param X-Workspace-ID header string true "Workspace ID"
 // @Param templateId path string true "Template ID"
+// @Param If-Match header string false "Revision the edit is based on, when the body has none"
 // @Param request body dto.UpdateTemplateRequest true "Template data (folderId can be a folder UUID or 'root' to move to root)"
 // @Success 200 {object} dto.TemplateResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.RevisionConflictResponse
+// @Failure 428 {object} dto.ErrorResponse "Neither revision nor If-Match given"
 // @Router /api/v1/content/templates/{templateId} [put]
 func (c *ContentTemplateController) UpdateTemplate(ctx *gin.Context) {
 	templateID := ctx.Param("templateId")
@@ -197,10 +233,16 @@ func (c *ContentTemplateController) UpdateTemplate(ctx *gin.Context) {
 		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
+	if !requireRevision(ctx, &req.Revision) {
+		return
+	}
 
 	cmd := c.templateMapper.ToUpdateCommand(templateID, &req)
 	template, err := c.templateUC.UpdateTemplate(ctx.Request.Context(), cmd)
 	if err != nil {
+		if respondRevisionConflict(ctx, err, c.templateMapper.ToResponse) {
+			return
+		}
 		HandleError(ctx, err)
 		return
 	}
@@ -263,6 +305,175 @@ func (c *ContentTemplateController) CloneTemplate(ctx *gin.Context) {
 	ctx.JSON(http.StatusCreated, c.templateMapper.ToCreateResponse(template, version))
 }
 
+// ExportTemplateBundle downloads a template as a portable bundle.
+// @Summary Export template bundle
+// @Description Zip archive with the template, all its versions, the definitions and i18n labels of the injectables they reference and the library assets they use. Import it with POST /content/templates/import.
+// @Tags Templates
+// @Produce application/zip
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param templateId path string true "Template ID"
+// @Success 200 {file} binary "Template bundle"
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/content/templates/{templateId}/export [get]
+func (c *ContentTemplateController) ExportTemplateBundle(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	templateID := ctx.Param("templateId")
+
+	bundle, err := c.bundleUC.ExportBundle(ctx.Request.Context(), workspaceID, templateID)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", bundle.Filename))
+	ctx.Data(http.StatusOK, "application/zip", bundle.Data)
+}
+
+// ImportTemplateBundle creates a template from a portable bundle.
+// @Summary Import template bundle
+// @Description Creates a new template with the bundled versions. Missing workspace injectables are created, bundled assets are added to the asset library and the version published in the source is published again. Nothing is saved if any step fails.
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param request body dto.ImportTemplateBundleRequest true "Bundle and optional title"
+// @Success 201 {object} dto.ImportTemplateBundleResponse
+// @Failure 400 {object} dto.ErrorResponse "Malformed bundle, or the published version fails publish validation"
+// @Failure 403 {object} dto.ErrorResponse "Requires ADMIN: the import publishes and archives versions"
+// @Failure 409 {object} dto.ErrorResponse "Template title already exists"
+// @Router /api/v1/content/templates/import [post]
+func (c *ContentTemplateController) ImportTemplateBundle(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	userID, _ := middleware.GetInternalUserID(ctx)
+
+	var req dto.ImportTemplateBundleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := c.bundleUC.ImportBundle(ctx.Request.Context(), c.templateMapper.ToImportBundleCommand(&req, workspaceID, userID))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, c.templateMapper.ToImportBundleResponse(result))
+}
+
+// ListLibraryTemplates lists the library of the workspace's tenant.
+// @Summary List library templates
+// @Description Public library templates with a published version of the other workspaces of the tenant. Install one with POST /content/templates/library/{templateId}/install.
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Success 200 {object} dto.ListTemplatesResponse
+// @Router /api/v1/content/templates/library [get]
+func (c *ContentTemplateController) ListLibraryTemplates(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	templates, err := c.templateUC.ListPublicLibrary(ctx.Request.Context(), workspaceID)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.templateMapper.ToListResponse(templates, 0, 0))
+}
+
+// InstallLibraryTemplate installs a library template into the workspace.
+// @Summary Install library template
+// @Description Copies the published version of a library template into the workspace as a new template with a draft version, linked to the library template so it can pull later versions. Assets it uses are copied to the workspace; workspace injectables are not, and publish validation reports the ones the workspace lacks.
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param templateId path string true "Library template ID"
+// @Param request body dto.InstallLibraryTemplateRequest false "Title and folder of the copy"
+// @Success 201 {object} dto.InstallLibraryTemplateResponse
+// @Failure 404 {object} dto.ErrorResponse "Template not in the library of the tenant"
+// @Failure 409 {object} dto.ErrorResponse "Template title already exists"
+// @Router /api/v1/content/templates/library/{templateId}/install [post]
+func (c *ContentTemplateController) InstallLibraryTemplate(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	userID, _ := middleware.GetInternalUserID(ctx)
+
+	// The body is optional
+	var req dto.InstallLibraryTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	cmd := c.templateMapper.ToInstallLibraryCommand(ctx.Param("templateId"), &req, workspaceID, userID)
+	result, err := c.libraryUC.InstallTemplate(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, c.templateMapper.ToInstallLibraryResponse(result))
+}
+
+// PreviewLibraryUpdate previews pulling the latest library version into an installed template.
+// @Summary Preview library update
+// @Description Three-way comparison of the latest non-archived version of the template and the published library version against the library version it was installed or last pulled from: the changes of each side, the conflicting changes and the merged content a pull would create.
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param templateId path string true "Template ID"
+// @Success 200 {object} dto.LibraryUpdatePreviewResponse
+// @Failure 404 {object} dto.ErrorResponse "Template not installed from the library, or no longer in the library"
+// @Router /api/v1/content/templates/{templateId}/library-update [get]
+func (c *ContentTemplateController) PreviewLibraryUpdate(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	preview, err := c.libraryUC.PreviewUpdate(ctx.Request.Context(), workspaceID, ctx.Param("templateId"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.templateMapper.ToLibraryUpdatePreviewResponse(preview))
+}
+
+// PullLibraryUpdate merges the latest library version into an installed template.
+// @Summary Pull library update
+// @Description Creates a new draft version with the merged content of the preview and moves the base of the template to the pulled library version. Conflicting changes fail the pull unless a strategy resolves them.
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param templateId path string true "Template ID"
+// @Param request body dto.PullLibraryUpdateRequest false "Conflict strategy"
+// @Success 201 {object} dto.TemplateVersionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse "Template not installed from the library, or no longer in the library"
+// @Failure 409 {object} dto.ErrorResponse "Already up to date, or conflicting changes without a strategy"
+// @Router /api/v1/content/templates/{templateId}/library-update [post]
+func (c *ContentTemplateController) PullLibraryUpdate(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	userID, _ := middleware.GetInternalUserID(ctx)
+
+	// The body is optional
+	var req dto.PullLibraryUpdateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	cmd := c.templateMapper.ToPullLibraryUpdateCommand(ctx.Param("templateId"), &req, workspaceID, userID)
+	version, err := c.libraryUC.PullUpdate(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, c.versionController.versionMapper.ToResponse(version))
+}
+
 // AddTemplateTags adds tags to a template.
 // @Summary Add tags to template
 // @Tags Templates
@@ -350,3 +561,112 @@ func (c *ContentTemplateController) AssignDocumentType(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, mapper.AssignResultToResponse(result, c.templateMapper))
 }
+
+// GetTemplateSLA retrieves the SLA of a template with the renders of its current window.
+// @Summary Get template SLA
+// @Description Returns the render expectations of the template, the renders of the current window and the expectations it does not meet. Renders of the last check interval may not be counted yet.
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param templateId path string true "Template ID"
+// @Success 200 {object} dto.TemplateSLAResponse
+// @Failure 404 {object} dto.ErrorResponse "Template not found or it has no SLA"
+// @Router /api/v1/content/templates/{templateId}/sla [get]
+func (c *ContentTemplateController) GetTemplateSLA(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	status, err := c.slaUC.GetSLA(ctx.Request.Context(), workspaceID, ctx.Param("templateId"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.TemplateSLAStatusToResponse(status))
+}
+
+// SetTemplateSLA creates or replaces the SLA of a template.
+// @Summary Set template SLA
+// @Description Sets a max average render time, a max failure rate or both, checked over a rolling window. When the window breaches them, a template.sla_breached event is sent to event webhooks and the caller is notified; template.sla_recovered follows once they are met again. Setting the SLA clears any breach.
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param templateId path string true "Template ID"
+// @Param request body dto.TemplateSLARequest true "Render expectations"
+// @Success 200 {object} dto.TemplateSLAResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/content/templates/{templateId}/sla [put]
+func (c *ContentTemplateController) SetTemplateSLA(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	userID, _ := middleware.GetInternalUserID(ctx)
+
+	var req dto.TemplateSLARequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	cmd := mapper.TemplateSLARequestToCommand(ctx.Param("templateId"), workspaceID, userID, req)
+	status, err := c.slaUC.SetSLA(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.TemplateSLAStatusToResponse(status))
+}
+
+// DeleteTemplateSLA deletes the SLA of a template.
+// @Summary Delete template SLA
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param templateId path string true "Template ID"
+// @Success 204 "No Content"
+// @Failure 404 {object} dto.ErrorResponse "Template not found or it has no SLA"
+// @Router /api/v1/content/templates/{templateId}/sla [delete]
+func (c *ContentTemplateController) DeleteTemplateSLA(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	if err := c.slaUC.DeleteSLA(ctx.Request.Context(), workspaceID, ctx.Param("templateId")); err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetInjectableCoverage reports where the values of the injectables of a template came from in its renders.
+// @Summary Get template injectable coverage
+// @Description Counts, per injectable, the renders through the render API over the last days whose value came from the payload (request or injector), the default or nowhere, and lists the injectables that always took their default or always rendered empty. Renders of the last flush interval may not be counted yet.
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param templateId path string true "Template ID"
+// @Param days query int false "Days back, up to injectable_coverage.retention_days (default 7)"
+// @Param minRenders query int false "Renders an injectable needs before it is flagged (default 10)"
+// @Success 200 {object} dto.TemplateInjectableCoverageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/content/templates/{templateId}/injectable-coverage [get]
+func (c *ContentTemplateController) GetInjectableCoverage(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	var req dto.InjectableCoverageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	coverage, err := c.coverageUC.GetCoverage(ctx.Request.Context(), workspaceID, ctx.Param("templateId"), req.Days, req.MinRenders)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.TemplateInjectableCoverageToResponse(coverage))
+}
@@ -1,7 +1,9 @@
 package controller
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
@@ -17,6 +19,7 @@ import (
 // All routes require X-Tenant-ID header and appropriate tenant role.
 type DocumentTypeController struct {
 	docTypeUC      cataloguc.DocumentTypeUseCase
+	contractUC     cataloguc.DocumentTypeContractUseCase
 	templateUC     templateuc.TemplateUseCase
 	docTypeMapper  *mapper.DocumentTypeMapper
 	templateMapper *mapper.TemplateMapper
@@ -25,11 +28,13 @@ type DocumentTypeController struct {
 // NewDocumentTypeController creates a new document type controller.
 func NewDocumentTypeController(
 	docTypeUC cataloguc.DocumentTypeUseCase,
+	contractUC cataloguc.DocumentTypeContractUseCase,
 	templateUC templateuc.TemplateUseCase,
 	templateMapper *mapper.TemplateMapper,
 ) *DocumentTypeController {
 	return &DocumentTypeController{
 		docTypeUC:      docTypeUC,
+		contractUC:     contractUC,
 		templateUC:     templateUC,
 		docTypeMapper:  mapper.NewDocumentTypeMapper(),
 		templateMapper: templateMapper,
@@ -49,9 +54,18 @@ func (c *DocumentTypeController) RegisterRoutes(rg *gin.RouterGroup, middlewareP
 		docTypes.POST("", middleware.AuthorizeTenantRole(entity.TenantRoleOwner), c.CreateDocumentType)
 		docTypes.PUT("/:id", middleware.AuthorizeTenantRole(entity.TenantRoleOwner), c.UpdateDocumentType)
 		docTypes.DELETE("/:id", middleware.AuthorizeTenantRole(entity.TenantRoleOwner), c.DeleteDocumentType)
+		docTypes.GET("/:id/contract", middleware.AuthorizeTenantRole(entity.TenantRoleAdmin), c.GetContract)
+		docTypes.PUT("/:id/contract", middleware.AuthorizeTenantRole(entity.TenantRoleOwner), c.SetContract)
+		docTypes.DELETE("/:id/contract", middleware.AuthorizeTenantRole(entity.TenantRoleOwner), c.DeleteContract)
 	}
 }
 
+// RegisterWorkspaceRoutes registers the document type routes of integrating systems, on the
+// render group: /workspace/document-types/:code/contract.
+func (c *DocumentTypeController) RegisterWorkspaceRoutes(workspaceGroup *gin.RouterGroup) {
+	workspaceGroup.GET("/document-types/:code/contract", c.GetContractByCode)
+}
+
 // ListDocumentTypes lists all document types for the current tenant with pagination.
 // @Summary List document types
 // @Tags Tenant - Document Types
@@ -305,3 +319,132 @@ func (c *DocumentTypeController) ListTemplatesByTypeCode(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, c.templateMapper.ToListResponse(templates, len(templates), 0))
 }
+
+// GetContract gets the payload contract of a document type of the tenant or a global one.
+// @Summary Get document type payload contract
+// @Tags Tenant - Document Types
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Document Type ID"
+// @Success 200 {object} dto.DocumentTypeContractResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/tenant/document-types/{id}/contract [get]
+// @Security BearerAuth
+func (c *DocumentTypeController) GetContract(ctx *gin.Context) {
+	tenantID, ok := middleware.GetTenantID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(entity.ErrMissingTenantID))
+		return
+	}
+
+	contract, err := c.contractUC.GetContract(ctx.Request.Context(), tenantID, ctx.Param("id"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.docTypeMapper.ToContractResponse(contract))
+}
+
+// SetContract creates or replaces the payload contract of a document type. Render-by-type
+// requests for the type are validated against its schema; the example must satisfy it.
+// Global types (from SYS tenant) cannot be modified.
+// @Summary Set document type payload contract
+// @Tags Tenant - Document Types
+// @Accept json
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Document Type ID"
+// @Param request body dto.SetDocumentTypeContractRequest true "JSON Schema and example payload"
+// @Success 200 {object} dto.DocumentTypeContractResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/tenant/document-types/{id}/contract [put]
+// @Security BearerAuth
+func (c *DocumentTypeController) SetContract(ctx *gin.Context) {
+	tenantID, ok := middleware.GetTenantID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(entity.ErrMissingTenantID))
+		return
+	}
+	userID, _ := middleware.GetInternalUserID(ctx)
+
+	var req dto.SetDocumentTypeContractRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(err))
+		return
+	}
+
+	contract, err := c.contractUC.SetContract(ctx.Request.Context(), cataloguc.SetDocumentTypeContractCommand{
+		DocumentTypeID: ctx.Param("id"),
+		TenantID:       tenantID,
+		Schema:         req.Schema,
+		Example:        req.Example,
+		UpdatedBy:      userID,
+	})
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.docTypeMapper.ToContractResponse(contract))
+}
+
+// DeleteContract removes the payload contract of a document type, so its renders are no longer validated.
+// @Summary Delete document type payload contract
+// @Tags Tenant - Document Types
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param id path string true "Document Type ID"
+// @Success 204
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/tenant/document-types/{id}/contract [delete]
+// @Security BearerAuth
+func (c *DocumentTypeController) DeleteContract(ctx *gin.Context) {
+	tenantID, ok := middleware.GetTenantID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(entity.ErrMissingTenantID))
+		return
+	}
+
+	if err := c.contractUC.DeleteContract(ctx.Request.Context(), tenantID, ctx.Param("id")); err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetContractByCode gets the payload contract render requests for a document type must satisfy,
+// for the teams integrating with the render API.
+// @Summary Get the payload contract of a document type
+// @Tags Workspace - Render
+// @Produce json
+// @Param X-Tenant-Code header string true "Tenant code"
+// @Param code path string true "Document type code"
+// @Success 200 {object} dto.DocumentTypeContractResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/document-types/{code}/contract [get]
+// @Security BearerAuth
+func (c *DocumentTypeController) GetContractByCode(ctx *gin.Context) {
+	tenantCode := strings.TrimSpace(ctx.GetHeader("X-Tenant-Code"))
+	if tenantCode == "" {
+		respondError(ctx, http.StatusBadRequest, fmt.Errorf("X-Tenant-Code header is required"))
+		return
+	}
+
+	contract, err := c.contractUC.GetContractByCode(ctx.Request.Context(), tenantCode, ctx.Param("code"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.docTypeMapper.ToContractResponse(contract))
+}
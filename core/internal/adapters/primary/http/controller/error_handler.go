@@ -3,18 +3,83 @@ package controller
 import (
 	"errors"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/mapper"
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/middleware"
 	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	galleryuc "github.com/rendis/pdf-forge/core/internal/core/usecase/gallery"
 )
 
-// respondError sends an error response.
+// respondError sends an error response. Render failures also carry their code, whether
+// retrying may help and the causes that can be located in the document.
 func respondError(ctx *gin.Context, statusCode int, err error) {
-	ctx.JSON(statusCode, dto.NewErrorResponse(err))
+	resp := dto.NewErrorResponse(err)
+	resp.Code, resp.Retryable = renderErrorCode(err)
+	resp.Details = mapper.RenderErrorDetailsToResponse(entity.RenderErrorDetailsOf(err))
+	ctx.JSON(statusCode, resp)
+}
+
+// renderErrorCode returns the render error code of err and whether it is retryable, or "" and
+// nil when err is not a render failure.
+func renderErrorCode(err error) (string, *bool) {
+	code, ok := entity.RenderErrorCodeOf(err)
+	if !ok {
+		return "", nil
+	}
+	retryable := code.Retryable()
+	return string(code), &retryable
+}
+
+// respondBindError responds to a request body binding failure: 413 when the body
+// exceeded the configured size limit, 400 otherwise.
+func respondBindError(ctx *gin.Context, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		middleware.AbortBodyTooLarge(ctx, maxBytesErr.Limit)
+		return
+	}
+	respondError(ctx, http.StatusBadRequest, err)
+}
+
+// respondRevisionConflict answers a stale update with 409 and the current state of the
+// resource, mapped with toResponse. It reports whether err was such a conflict.
+func respondRevisionConflict[T, R any](ctx *gin.Context, err error, toResponse func(T) R) bool {
+	var conflict *entity.RevisionConflictError
+	if !errors.As(err, &conflict) {
+		return false
+	}
+	current, ok := conflict.Current.(T)
+	if !ok {
+		return false
+	}
+	ctx.JSON(http.StatusConflict, dto.RevisionConflictResponse{
+		Error:   conflict.Error(),
+		Code:    "REVISION_CONFLICT",
+		Current: toResponse(current),
+	})
+	return true
+}
+
+// requireRevision takes the revision an update is based on from the If-Match header when the
+// body has none, and answers 428 when neither names it. It reports whether the update can go on.
+func requireRevision(ctx *gin.Context, revision **int) bool {
+	if *revision != nil {
+		return true
+	}
+	tag := strings.Trim(strings.TrimPrefix(ctx.GetHeader("If-Match"), "W/"), `"`)
+	if n, err := strconv.Atoi(tag); err == nil {
+		*revision = &n
+		return true
+	}
+	respondError(ctx, http.StatusPreconditionRequired, entity.ErrRevisionRequired)
+	return false
 }
 
 // HandleError maps domain errors to HTTP status codes.
@@ -34,6 +99,37 @@ func HandleError(ctx *gin.Context, err error) {
 		ctx.JSON(http.StatusBadRequest, gin.H{
 			"error":        missingInjectablesErr.Error(),
 			"missingCodes": missingInjectablesErr.MissingCodes,
+			"code":         entity.RenderErrorInvalidPayload,
+			"retryable":    false,
+			"details":      mapper.RenderErrorDetailsToResponse(entity.RenderErrorDetailsOf(missingInjectablesErr)),
+		})
+		return
+	}
+
+	// Check for PayloadContractError (special handling)
+	var contractErr *entity.PayloadContractError
+	if errors.As(err, &contractErr) {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":      contractErr.Error(),
+			"violations": contractErr.Violations,
+			"code":       entity.RenderErrorInvalidPayload,
+			"retryable":  false,
+		})
+		return
+	}
+
+	// Check for QuotaExceededError (special handling)
+	var quotaErr *entity.QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		retryAfter := max(int(math.Ceil(quotaErr.RetryAfter.Seconds())), 1)
+		ctx.Header("Retry-After", strconv.Itoa(retryAfter))
+		ctx.JSON(http.StatusTooManyRequests, gin.H{
+			"error":             quotaErr.Error(),
+			"code":              "QUOTA_EXCEEDED",
+			"scope":             quotaErr.Scope,
+			"limit":             quotaErr.Limit,
+			"max":               quotaErr.Max,
+			"retryAfterSeconds": retryAfter,
 		})
 		return
 	}
@@ -48,6 +144,10 @@ func HandleError(ctx *gin.Context, err error) {
 // mapErrorToStatusCode determines the appropriate HTTP status code for an error.
 func mapErrorToStatusCode(err error) int {
 	switch {
+	case is504Error(err):
+		return http.StatusGatewayTimeout
+	case is502Error(err):
+		return http.StatusBadGateway
 	case is404Error(err):
 		return http.StatusNotFound
 	case is409Error(err):
@@ -68,19 +168,46 @@ func mapErrorToStatusCode(err error) int {
 // is404Error returns true if the error should result in a 404 Not Found response.
 func is404Error(err error) bool {
 	return errors.Is(err, entity.ErrInjectableNotFound) ||
+		errors.Is(err, entity.ErrRenderQuotaNotFound) ||
 		errors.Is(err, entity.ErrTemplateNotFound) ||
 		errors.Is(err, entity.ErrTagNotFound) ||
 		errors.Is(err, entity.ErrVersionNotFound) ||
+		errors.Is(err, entity.ErrChangelogNotFound) ||
+		errors.Is(err, entity.ErrReleaseNotesNotFound) ||
+		errors.Is(err, entity.ErrObjectNotFound) ||
+		errors.Is(err, entity.ErrInvalidSignedURL) ||
 		errors.Is(err, entity.ErrVersionInjectableNotFound) ||
 		errors.Is(err, entity.ErrWorkspaceNotFound) ||
+		errors.Is(err, entity.ErrSandboxNotFound) ||
 		errors.Is(err, entity.ErrFolderNotFound) ||
 		errors.Is(err, entity.ErrUserNotFound) ||
 		errors.Is(err, entity.ErrMemberNotFound) ||
+		errors.Is(err, entity.ErrInviteeNotRegistered) ||
 		errors.Is(err, entity.ErrTenantNotFound) ||
 		errors.Is(err, entity.ErrTenantMemberNotFound) ||
 		errors.Is(err, entity.ErrSystemRoleNotFound) ||
 		errors.Is(err, entity.ErrDocumentTypeNotFound) ||
-		errors.Is(err, entity.ErrTemplateNotResolved)
+		errors.Is(err, entity.ErrTemplateNotResolved) ||
+		errors.Is(err, entity.ErrNotificationNotFound) ||
+		errors.Is(err, entity.ErrNotificationWebhookNotFound) ||
+		errors.Is(err, entity.ErrEventWebhookNotFound) ||
+		errors.Is(err, entity.ErrWebhookDeliveryNotFound) ||
+		errors.Is(err, entity.ErrInvitationNotFound) ||
+		errors.Is(err, entity.ErrPreviewTokenNotFound) ||
+		errors.Is(err, entity.ErrPreviewTokenExpired) ||
+		errors.Is(err, entity.ErrHostedDocumentNotFound) ||
+		errors.Is(err, entity.ErrRenderJobNotFound) ||
+		errors.Is(err, entity.ErrRenderFailureNotFound) ||
+		errors.Is(err, entity.ErrTemplateSLANotFound) ||
+		errors.Is(err, entity.ErrLibraryTemplateNotFound) ||
+		errors.Is(err, entity.ErrTemplateNotLinked) ||
+		errors.Is(err, entity.ErrPayloadContractNotFound) ||
+		errors.Is(err, entity.ErrThumbnailNotReady) ||
+		errors.Is(err, entity.ErrAssetNotFound) ||
+		errors.Is(err, entity.ErrWorkspaceFontNotFound) ||
+		errors.Is(err, entity.ErrNumberingSequenceNotFound) ||
+		errors.Is(err, entity.ErrScriptedInjectorNotFound) ||
+		errors.Is(err, entity.ErrSessionNotFound)
 }
 
 // is409Error returns true if the error should result in a 409 Conflict response.
@@ -88,6 +215,8 @@ func is404Error(err error) bool {
 //nolint:gocyclo // Simple list of error checks that grows with features
 func is409Error(err error) bool {
 	return errors.Is(err, entity.ErrInjectableAlreadyExists) ||
+		errors.Is(err, entity.ErrRevisionConflict) ||
+		errors.Is(err, entity.ErrRenderJobNotReady) ||
 		errors.Is(err, entity.ErrTemplateAlreadyExists) ||
 		errors.Is(err, entity.ErrVersionAlreadyExists) ||
 		errors.Is(err, entity.ErrVersionNameExists) ||
@@ -100,9 +229,22 @@ func is409Error(err error) bool {
 		errors.Is(err, entity.ErrGlobalWorkspaceExists) ||
 		errors.Is(err, entity.ErrTenantMemberExists) ||
 		errors.Is(err, entity.ErrScheduledTimeConflict) ||
+		errors.Is(err, entity.ErrScheduledOperationNotDue) ||
 		errors.Is(err, entity.ErrDocumentTypeCodeExists) ||
 		errors.Is(err, entity.ErrDocumentTypeAlreadyAssigned) ||
-		errors.Is(err, entity.ErrSystemRoleExists)
+		errors.Is(err, entity.ErrSystemRoleExists) ||
+		errors.Is(err, entity.ErrInvitationAlreadyPending) ||
+		errors.Is(err, entity.ErrInvitationNotPending) ||
+		errors.Is(err, entity.ErrMemberDeactivated) ||
+		errors.Is(err, entity.ErrMemberNotDeactivated) ||
+		errors.Is(err, entity.ErrLibraryTemplateUpToDate) ||
+		errors.Is(err, entity.ErrLibraryMergeConflict) ||
+		errors.Is(err, entity.ErrScriptedInjectorExists) ||
+		errors.Is(err, entity.ErrFontFaceExists) ||
+		errors.Is(err, entity.ErrNumberingSequenceExists) ||
+		errors.Is(err, entity.ErrSequenceNumberReassigned) ||
+		errors.Is(err, entity.ErrSessionAlreadyRevoked) ||
+		errors.Is(err, entity.ErrSessionNotRevoked)
 }
 
 // is400Error returns true if the error should result in a 400 Bad Request response.
@@ -115,12 +257,17 @@ func is400Error(err error) bool {
 		errors.Is(err, entity.ErrInvalidDataType) ||
 		errors.Is(err, entity.ErrCannotEditPublished) ||
 		errors.Is(err, entity.ErrCannotEditArchived) ||
+		errors.Is(err, entity.ErrReleaseNotesFrozen) ||
+		errors.Is(err, entity.ErrInvalidReleaseNotes) ||
+		errors.Is(err, entity.ErrObjectStorageNotConfigured) ||
+		errors.Is(err, entity.ErrPersistAndHost) ||
 		errors.Is(err, entity.ErrVersionNotPublished) ||
 		errors.Is(err, entity.ErrVersionAlreadyPublished) ||
 		errors.Is(err, entity.ErrCannotArchiveWithoutReplacement) ||
 		errors.Is(err, entity.ErrInvalidVersionStatus) ||
 		errors.Is(err, entity.ErrInvalidVersionNumber) ||
 		errors.Is(err, entity.ErrScheduledTimeInPast) ||
+		errors.Is(err, entity.ErrInvalidScheduledOperation) ||
 		errors.Is(err, entity.ErrFolderHasChildren) ||
 		errors.Is(err, entity.ErrFolderHasTemplates) ||
 		errors.Is(err, entity.ErrTagInUse) ||
@@ -128,6 +275,16 @@ func is400Error(err error) bool {
 		errors.Is(err, entity.ErrCannotArchiveSystem) ||
 		errors.Is(err, entity.ErrInvalidParentFolder) ||
 		errors.Is(err, entity.ErrCannotRemoveOwner) ||
+		errors.Is(err, entity.ErrCannotDeactivateOwner) ||
+		errors.Is(err, entity.ErrInvalidLocale) ||
+		errors.Is(err, entity.ErrInvalidTimezone) ||
+		errors.Is(err, entity.ErrLastWorkspaceOwner) ||
+		errors.Is(err, entity.ErrInvalidOwnershipTarget) ||
+		errors.Is(err, entity.ErrCannotRevokeUserSession) ||
+		errors.Is(err, entity.ErrCannotForceReauth) ||
+		errors.Is(err, entity.ErrInvalidPrincipalType) ||
+		errors.Is(err, entity.ErrInvalidMaintenanceMode) ||
+		errors.Is(err, entity.ErrInvalidStatsWindow) ||
 		errors.Is(err, entity.ErrInvalidRole) ||
 		errors.Is(err, entity.ErrInvalidTenantCode) ||
 		errors.Is(err, entity.ErrInvalidWorkspaceType) ||
@@ -137,6 +294,7 @@ func is400Error(err error) bool {
 		errors.Is(err, entity.ErrInvalidTenantRole) ||
 		errors.Is(err, entity.ErrVersionDoesNotBelongToTemplate) ||
 		errors.Is(err, entity.ErrOnlyTextTypeAllowed) ||
+		errors.Is(err, entity.ErrInvalidFormula) ||
 		errors.Is(err, entity.ErrWorkspaceIDRequired) ||
 		errors.Is(err, entity.ErrCannotModifyGlobal) ||
 		errors.Is(err, galleryuc.ErrQueryRequired) ||
@@ -145,7 +303,68 @@ func is400Error(err error) bool {
 		errors.Is(err, galleryuc.ErrUploadSizeInvalid) ||
 		errors.Is(err, galleryuc.ErrUploadSizeTooLarge) ||
 		errors.Is(err, entity.ErrDocumentTypeCodeImmutable) ||
-		errors.Is(err, entity.ErrDocumentTypeHasTemplates)
+		errors.Is(err, entity.ErrDocumentTypeHasTemplates) ||
+		errors.Is(err, entity.ErrInvalidNotificationType) ||
+		errors.Is(err, entity.ErrInvalidWebhookProvider) ||
+		errors.Is(err, entity.ErrInvalidWebhookURL) ||
+		errors.Is(err, entity.ErrWebhookDeliveryFailed) ||
+		errors.Is(err, entity.ErrInvalidWebhookEventType) ||
+		errors.Is(err, entity.ErrInvalidEventWebhookURL) ||
+		errors.Is(err, entity.ErrInvitationExpired) ||
+		errors.Is(err, entity.ErrInvalidPreviewTTL) ||
+		errors.Is(err, entity.ErrInvalidHostedAccess) ||
+		errors.Is(err, entity.ErrInvalidHostedTTL) ||
+		errors.Is(err, entity.ErrInvalidSandboxTTL) ||
+		errors.Is(err, entity.ErrInvalidWorkspaceSettings) ||
+		errors.Is(err, entity.ErrCannotSandboxWorkspace) ||
+		errors.Is(err, entity.ErrAssetTooLarge) ||
+		errors.Is(err, entity.ErrUnsupportedAssetType) ||
+		errors.Is(err, entity.ErrAssetKindMismatch) ||
+		errors.Is(err, entity.ErrFontTooLarge) ||
+		errors.Is(err, entity.ErrUnsupportedFontFile) ||
+		errors.Is(err, entity.ErrTooManyWorkspaceFonts) ||
+		errors.Is(err, entity.ErrInvalidNumberingSequence) ||
+		errors.Is(err, entity.ErrSequenceResetLocked) ||
+		errors.Is(err, entity.ErrAssetInUse) ||
+		errors.Is(err, entity.ErrAssetNotImage) ||
+		errors.Is(err, entity.ErrInvalidAssetSourceURL) ||
+		errors.Is(err, entity.ErrAssetImportFailed) ||
+		errors.Is(err, entity.ErrAssetSigningDisabled) ||
+		errors.Is(err, entity.ErrInvalidImageOptions) ||
+		errors.Is(err, entity.ErrUnsupportedImageFormat) ||
+		errors.Is(err, entity.ErrInvalidImposition) ||
+		errors.Is(err, entity.ErrInvalidTemplateSLA) ||
+		errors.Is(err, entity.ErrInvalidInjectableCoverage) ||
+		errors.Is(err, entity.ErrInvalidPayloadContract) ||
+		errors.Is(err, entity.ErrLayoutNotAllowed) ||
+		errors.Is(err, entity.ErrDegradedRenderNotAllowed) ||
+		errors.Is(err, entity.ErrUnknownRenderer) ||
+		errors.Is(err, entity.ErrUnknownNodes) ||
+		errors.Is(err, entity.ErrTypstExportUnavailable) ||
+		errors.Is(err, entity.ErrExternalPDFUnavailable) ||
+		errors.Is(err, entity.ErrImageUnavailable) ||
+		errors.Is(err, entity.ErrHTMLRenderUnavailable) ||
+		errors.Is(err, entity.ErrDocxRenderUnavailable) ||
+		errors.Is(err, entity.ErrUnsupportedRenderFormat) ||
+		errors.Is(err, entity.ErrHTMLRenderOption) ||
+		errors.Is(err, entity.ErrDocxRenderOption) ||
+		errors.Is(err, entity.ErrInvalidCurrencyRateDate) ||
+		errors.Is(err, entity.ErrDocxPreviewLink) ||
+		errors.Is(err, entity.ErrEmptyTemplateImport) ||
+		errors.Is(err, entity.ErrTemplateImportTooLarge) ||
+		errors.Is(err, entity.ErrInvalidTemplateImport) ||
+		errors.Is(err, entity.ErrDocxImportTooLarge) ||
+		errors.Is(err, entity.ErrInvalidImportMapping) ||
+		errors.Is(err, entity.ErrInvalidTemplateBundle) ||
+		errors.Is(err, entity.ErrUnsupportedTemplateBundle) ||
+		errors.Is(err, entity.ErrTemplateBundleTooLarge) ||
+		errors.Is(err, entity.ErrInvalidLibraryMergeStrategy) ||
+		errors.Is(err, entity.ErrInvalidScriptedInjector) ||
+		errors.Is(err, entity.ErrScriptFailed) ||
+		errors.Is(err, entity.ErrScriptedInjectorsDisabled) ||
+		errors.Is(err, entity.ErrInvalidRenderQuota) ||
+		errors.Is(err, entity.ErrRenderQuotasDisabled) ||
+		errors.Is(err, entity.ErrInvalidEmail)
 }
 
 // is403Error returns true if the error should result in a 403 Forbidden response.
@@ -153,16 +372,37 @@ func is403Error(err error) bool {
 	return errors.Is(err, entity.ErrWorkspaceAccessDenied) ||
 		errors.Is(err, entity.ErrForbidden) ||
 		errors.Is(err, entity.ErrInsufficientRole) ||
-		errors.Is(err, entity.ErrTenantAccessDenied)
+		errors.Is(err, entity.ErrTenantAccessDenied) ||
+		errors.Is(err, entity.ErrInvitationEmailMismatch) ||
+		errors.Is(err, entity.ErrDownloadNotAllowed) ||
+		errors.Is(err, entity.ErrWorkspaceSettingLocked) ||
+		errors.Is(err, entity.ErrCannotModifyGlobalType)
 }
 
 // is401Error returns true if the error should result in a 401 Unauthorized response.
 func is401Error(err error) bool {
-	return errors.Is(err, entity.ErrUnauthorized)
+	return errors.Is(err, entity.ErrUnauthorized) ||
+		errors.Is(err, entity.ErrSessionRevoked) ||
+		errors.Is(err, entity.ErrReauthRequired)
 }
 
 // is503Error returns true if the error should result in a 503 Service Unavailable response.
 func is503Error(err error) bool {
 	return errors.Is(err, entity.ErrLLMServiceUnavailable) ||
-		errors.Is(err, entity.ErrRendererBusy)
+		errors.Is(err, entity.ErrRendererBusy) ||
+		errors.Is(err, entity.ErrMaintenanceMode)
+}
+
+// is502Error returns true if the error should result in a 502 Bad Gateway response: a critical
+// injector or the injectable provider failed.
+func is502Error(err error) bool {
+	code, _ := entity.RenderErrorCodeOf(err)
+	return code == entity.RenderErrorInjectorFailed
+}
+
+// is504Error returns true if the error should result in a 504 Gateway Timeout response: a
+// critical injector did not answer in time.
+func is504Error(err error) bool {
+	code, _ := entity.RenderErrorCodeOf(err)
+	return code == entity.RenderErrorInjectorTimeout
 }
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireRevision(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fromBody := 4
+
+	tests := []struct {
+		name     string
+		body     *int
+		ifMatch  string
+		wantOK   bool
+		wantRev  int
+		wantCode int
+	}{
+		{name: "body revision wins", body: &fromBody, ifMatch: `"9"`, wantOK: true, wantRev: 4},
+		{name: "strong etag", ifMatch: `"7"`, wantOK: true, wantRev: 7},
+		{name: "weak etag", ifMatch: `W/"7"`, wantOK: true, wantRev: 7},
+		{name: "bare number", ifMatch: "7", wantOK: true, wantRev: 7},
+		{name: "missing", wantCode: http.StatusPreconditionRequired},
+		{name: "not a revision", ifMatch: `"abc"`, wantCode: http.StatusPreconditionRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodPut, "/", nil)
+			if tt.ifMatch != "" {
+				ctx.Request.Header.Set("If-Match", tt.ifMatch)
+			}
+			revision := tt.body
+
+			ok := requireRevision(ctx, &revision)
+
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				if assert.NotNil(t, revision) {
+					assert.Equal(t, tt.wantRev, *revision)
+				}
+				return
+			}
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/mapper"
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/middleware"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	notificationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/notification"
+)
+
+// EventWebhookController handles the event webhooks of a workspace: signed JSON posts of render
+// and publish events to integrator endpoints, and the delivery log used to debug missed calls.
+type EventWebhookController struct {
+	eventWebhookUC notificationuc.EventWebhookUseCase
+}
+
+// NewEventWebhookController creates a new event webhook controller.
+func NewEventWebhookController(eventWebhookUC notificationuc.EventWebhookUseCase) *EventWebhookController {
+	return &EventWebhookController{eventWebhookUC: eventWebhookUC}
+}
+
+// RegisterRoutes registers all /workspace/event-webhooks routes.
+func (c *EventWebhookController) RegisterRoutes(rg *gin.RouterGroup, middlewareProvider *middleware.Provider) {
+	webhooks := rg.Group("/workspace/event-webhooks")
+	webhooks.Use(middlewareProvider.WorkspaceContext(), middleware.RequireAdmin())
+	{
+		webhooks.GET("", c.ListEventWebhooks)                                                     // ADMIN+
+		webhooks.POST("", c.CreateEventWebhook)                                                   // ADMIN+
+		webhooks.GET("/:webhookId", c.GetEventWebhook)                                            // ADMIN+
+		webhooks.PUT("/:webhookId", c.UpdateEventWebhook)                                         // ADMIN+
+		webhooks.DELETE("/:webhookId", c.DeleteEventWebhook)                                      // ADMIN+
+		webhooks.POST("/:webhookId/test", c.TestEventWebhook)                                     // ADMIN+
+		webhooks.GET("/:webhookId/deliveries", c.ListWebhookDeliveries)                           // ADMIN+
+		webhooks.GET("/:webhookId/deliveries/:deliveryId", c.GetWebhookDelivery)                  // ADMIN+
+		webhooks.POST("/:webhookId/deliveries/:deliveryId/redeliver", c.RedeliverWebhookDelivery) // ADMIN+
+	}
+}
+
+// ListEventWebhooks lists the event webhooks of the current workspace.
+// @Summary List event webhooks
+// @Tags Event Webhooks
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Success 200 {object} dto.ListResponse[dto.EventWebhookResponse]
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/event-webhooks [get]
+// @Security BearerAuth
+func (c *EventWebhookController) ListEventWebhooks(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	webhooks, err := c.eventWebhookUC.ListWebhooks(ctx.Request.Context(), workspaceID)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewListResponse(mapper.EventWebhooksToResponses(webhooks)))
+}
+
+// CreateEventWebhook creates an event webhook for the current workspace.
+// @Summary Create event webhook
+// @Description The URL must be https. Empty eventTypes subscribes to every webhook event
+// @Description (render.completed, render.failed, version.published, version.archived).
+// @Description The response carries the signing secret; it is not returned again.
+// @Tags Event Webhooks
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param request body dto.CreateEventWebhookRequest true "Webhook data"
+// @Success 201 {object} dto.EventWebhookResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/event-webhooks [post]
+// @Security BearerAuth
+func (c *EventWebhookController) CreateEventWebhook(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	userID, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, entity.ErrUnauthorized)
+		return
+	}
+
+	var req dto.CreateEventWebhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	cmd := mapper.CreateEventWebhookRequestToCommand(workspaceID, req, userID)
+	webhook, err := c.eventWebhookUC.CreateWebhook(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, mapper.EventWebhookToResponseWithSecret(webhook))
+}
+
+// GetEventWebhook retrieves an event webhook by ID.
+// @Summary Get event webhook
+// @Tags Event Webhooks
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param webhookId path string true "Webhook ID"
+// @Success 200 {object} dto.EventWebhookResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/event-webhooks/{webhookId} [get]
+// @Security BearerAuth
+func (c *EventWebhookController) GetEventWebhook(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	webhook, err := c.eventWebhookUC.GetWebhook(ctx.Request.Context(), workspaceID, ctx.Param("webhookId"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.EventWebhookToResponse(webhook))
+}
+
+// UpdateEventWebhook updates an event webhook.
+// @Summary Update event webhook
+// @Description Set rotateSecret to replace the signing secret; the response then carries the new one.
+// @Tags Event Webhooks
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param webhookId path string true "Webhook ID"
+// @Param request body dto.UpdateEventWebhookRequest true "Webhook data"
+// @Success 200 {object} dto.EventWebhookResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/event-webhooks/{webhookId} [put]
+// @Security BearerAuth
+func (c *EventWebhookController) UpdateEventWebhook(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	var req dto.UpdateEventWebhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	cmd := mapper.UpdateEventWebhookRequestToCommand(ctx.Param("webhookId"), workspaceID, req)
+	webhook, err := c.eventWebhookUC.UpdateWebhook(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	if req.RotateSecret {
+		ctx.JSON(http.StatusOK, mapper.EventWebhookToResponseWithSecret(webhook))
+		return
+	}
+	ctx.JSON(http.StatusOK, mapper.EventWebhookToResponse(webhook))
+}
+
+// DeleteEventWebhook deletes an event webhook and its delivery log.
+// @Summary Delete event webhook
+// @Tags Event Webhooks
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param webhookId path string true "Webhook ID"
+// @Success 204 "No Content"
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/event-webhooks/{webhookId} [delete]
+// @Security BearerAuth
+func (c *EventWebhookController) DeleteEventWebhook(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	if err := c.eventWebhookUC.DeleteWebhook(ctx.Request.Context(), workspaceID, ctx.Param("webhookId")); err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// TestEventWebhook posts a signed webhook.ping payload to an event webhook.
+// @Summary Test event webhook
+// @Description Posts synchronously and reports the endpoint response. The ping is not added to the delivery log.
+// @Tags Event Webhooks
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param webhookId path string true "Webhook ID"
+// @Success 200 {object} dto.EventWebhookTestResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/event-webhooks/{webhookId}/test [post]
+// @Security BearerAuth
+func (c *EventWebhookController) TestEventWebhook(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	result, err := c.eventWebhookUC.TestWebhook(ctx.Request.Context(), workspaceID, ctx.Param("webhookId"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.EventWebhookTestResultToResponse(result))
+}
+
+// ListWebhookDeliveries lists the delivery log of an event webhook, newest first.
+// @Summary List webhook deliveries
+// @Tags Event Webhooks
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param webhookId path string true "Webhook ID"
+// @Param status query string false "Delivery status" Enums(PENDING, DELIVERED, FAILED)
+// @Param limit query int false "Maximum deliveries returned (default and max 200)"
+// @Success 200 {object} dto.ListResponse[dto.WebhookDeliveryResponse]
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/event-webhooks/{webhookId}/deliveries [get]
+// @Security BearerAuth
+func (c *EventWebhookController) ListWebhookDeliveries(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	status := ctx.Query("status")
+	if err := dto.ValidateWebhookDeliveryStatus(status); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	limit, ok := positiveQueryInt(ctx, "limit")
+	if !ok {
+		return
+	}
+
+	deliveries, err := c.eventWebhookUC.ListDeliveries(ctx.Request.Context(), workspaceID, ctx.Param("webhookId"), entity.WebhookDeliveryFilter{
+		Status: entity.WebhookDeliveryStatus(status),
+		Limit:  limit,
+	})
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewListResponse(mapper.WebhookDeliveriesToResponses(deliveries)))
+}
+
+// GetWebhookDelivery retrieves a delivery of an event webhook with the payload it posts.
+// @Summary Get webhook delivery
+// @Tags Event Webhooks
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param webhookId path string true "Webhook ID"
+// @Param deliveryId path string true "Delivery ID"
+// @Success 200 {object} dto.WebhookDeliveryResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/event-webhooks/{webhookId}/deliveries/{deliveryId} [get]
+// @Security BearerAuth
+func (c *EventWebhookController) GetWebhookDelivery(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	delivery, err := c.eventWebhookUC.GetDelivery(ctx.Request.Context(), workspaceID, ctx.Param("webhookId"), ctx.Param("deliveryId"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.WebhookDeliveryToResponse(delivery, true))
+}
+
+// RedeliverWebhookDelivery queues a delivery again with its attempts reset.
+// @Summary Redeliver webhook delivery
+// @Description The same payload and delivery ID are posted again, so receivers can deduplicate.
+// @Tags Event Webhooks
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param webhookId path string true "Webhook ID"
+// @Param deliveryId path string true "Delivery ID"
+// @Success 202 "Delivery queued"
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/event-webhooks/{webhookId}/deliveries/{deliveryId}/redeliver [post]
+// @Security BearerAuth
+func (c *EventWebhookController) RedeliverWebhookDelivery(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	err := c.eventWebhookUC.RedeliverDelivery(ctx.Request.Context(), workspaceID, ctx.Param("webhookId"), ctx.Param("deliveryId"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusAccepted)
+}
@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/mapper"
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/middleware"
+	cataloguc "github.com/rendis/pdf-forge/core/internal/core/usecase/catalog"
+)
+
+// FontController handles the fonts uploaded to a workspace, which renders of its templates find
+// by family name like the fonts installed on the server.
+type FontController struct {
+	fontUC cataloguc.WorkspaceFontUseCase
+}
+
+// NewFontController creates a new font controller.
+func NewFontController(fontUC cataloguc.WorkspaceFontUseCase) *FontController {
+	return &FontController{fontUC: fontUC}
+}
+
+// RegisterRoutes registers all /workspace/fonts routes.
+func (c *FontController) RegisterRoutes(rg *gin.RouterGroup, middlewareProvider *middleware.Provider) {
+	fonts := rg.Group("/workspace/fonts")
+	fonts.Use(middlewareProvider.WorkspaceContext())
+	{
+		fonts.GET("", c.ListFonts)                                         // VIEWER+
+		fonts.POST("", middleware.RequireEditor(), c.UploadFont)           // EDITOR+
+		fonts.GET("/families", c.ListFontFamilies)                         // VIEWER+
+		fonts.GET("/:fontId/file", c.GetFontFile)                          // VIEWER+
+		fonts.DELETE("/:fontId", middleware.RequireEditor(), c.DeleteFont) // EDITOR+
+	}
+}
+
+// ListFonts lists the fonts uploaded to the current workspace, by family, style and weight.
+// @Summary List workspace fonts
+// @Tags Fonts
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Success 200 {object} dto.ListResponse[dto.WorkspaceFontResponse]
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/fonts [get]
+// @Security BearerAuth
+func (c *FontController) ListFonts(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	fonts, err := c.fontUC.ListFonts(ctx.Request.Context(), workspaceID)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewListResponse(mapper.WorkspaceFontsToResponses(fonts)))
+}
+
+// UploadFont uploads a TrueType or OpenType font to the current workspace. The family, weight and
+// style are read from the file. A file the workspace already has returns the existing font with
+// duplicate set.
+// @Summary Upload workspace font
+// @Tags Fonts
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param request body dto.UploadFontRequest true "Font file"
+// @Success 201 {object} dto.UploadFontResponse
+// @Success 200 {object} dto.UploadFontResponse "Existing font with the same file"
+// @Failure 400 {object} dto.ErrorResponse "Not a TTF or OTF font, file too large or too many fonts"
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "The workspace already has a font with this family, weight and style"
+// @Router /api/v1/workspace/fonts [post]
+// @Security BearerAuth
+func (c *FontController) UploadFont(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	userID, _ := middleware.GetInternalUserID(ctx)
+
+	var req dto.UploadFontRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := c.fontUC.UploadFont(ctx.Request.Context(), cataloguc.UploadFontCommand{
+		WorkspaceID: workspaceID,
+		Data:        req.File,
+		CreatedBy:   userID,
+	})
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	status := http.StatusCreated
+	if result.Duplicate {
+		status = http.StatusOK
+	}
+	ctx.JSON(status, mapper.UploadFontResultToResponse(result))
+}
+
+// ListFontFamilies lists the font families templates of the current workspace can use, for the
+// editor font picker: those installed on the server and those uploaded to the workspace.
+// @Summary List available font families
+// @Tags Fonts
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Success 200 {object} dto.ListResponse[dto.FontFamilyResponse]
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/fonts/families [get]
+// @Security BearerAuth
+func (c *FontController) ListFontFamilies(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	families, err := c.fontUC.ListFontFamilies(ctx.Request.Context(), workspaceID)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewListResponse(mapper.FontFamiliesToResponses(families)))
+}
+
+// GetFontFile returns the file of a workspace font, for the editor to load it with the FontFace API.
+// The file of a font never changes, so it may be cached.
+// @Summary Get workspace font file
+// @Tags Fonts
+// @Produce octet-stream
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param fontId path string true "Font ID"
+// @Success 200 {file} file
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/fonts/{fontId}/file [get]
+// @Security BearerAuth
+func (c *FontController) GetFontFile(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	font, err := c.fontUC.GetFontContent(ctx.Request.Context(), workspaceID, ctx.Param("fontId"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, max-age=31536000, immutable")
+	ctx.Data(http.StatusOK, font.Format.ContentType(), font.Data)
+}
+
+// DeleteFont deletes a workspace font. Templates using its family render with the fallback fonts.
+// @Summary Delete workspace font
+// @Tags Fonts
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param fontId path string true "Font ID"
+// @Success 204 "Font deleted"
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/fonts/{fontId} [delete]
+// @Security BearerAuth
+func (c *FontController) DeleteFont(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	if err := c.fontUC.DeleteFont(ctx.Request.Context(), workspaceID, ctx.Param("fontId")); err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
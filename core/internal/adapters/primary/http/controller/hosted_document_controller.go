@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/mapper"
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/middleware"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// HostedDocumentController handles rendered PDFs kept by the server and shared through viewer links.
+// Documents are created by the render endpoints when the request asks for hosting.
+type HostedDocumentController struct {
+	hostedDocumentUC templateuc.HostedDocumentUseCase
+}
+
+// NewHostedDocumentController creates a new hosted document controller.
+func NewHostedDocumentController(hostedDocumentUC templateuc.HostedDocumentUseCase) *HostedDocumentController {
+	return &HostedDocumentController{hostedDocumentUC: hostedDocumentUC}
+}
+
+// RegisterRoutes registers all /workspace/hosted-documents routes.
+func (c *HostedDocumentController) RegisterRoutes(rg *gin.RouterGroup, middlewareProvider *middleware.Provider) {
+	docs := rg.Group("/workspace/hosted-documents")
+	docs.Use(middlewareProvider.WorkspaceContext())
+	{
+		docs.GET("", c.ListHostedDocuments)                                             // VIEWER+
+		docs.GET("/:documentId", c.ViewHostedDocument)                                  // VIEWER+
+		docs.GET("/:documentId/thumbnail", c.GetHostedDocumentThumbnail)                // VIEWER+
+		docs.DELETE("/:documentId", middleware.RequireEditor(), c.DeleteHostedDocument) // EDITOR+
+	}
+}
+
+// RegisterPublicRoutes registers the viewer route for PUBLIC and TOKEN documents.
+func (c *HostedDocumentController) RegisterPublicRoutes(public *gin.RouterGroup) {
+	public.GET("/documents/:documentId", c.ViewPublicDocument)
+}
+
+// ListHostedDocuments lists the hosted documents of the current workspace.
+// With q, only documents whose filename or extracted text match are returned, by relevance.
+// @Summary List or search hosted documents
+// @Tags Hosted Documents
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param q query string false "Full-text search (web search syntax: quoted phrases, OR, -word)"
+// @Success 200 {object} dto.ListResponse[dto.HostedDocumentResponse]
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/hosted-documents [get]
+// @Security BearerAuth
+func (c *HostedDocumentController) ListHostedDocuments(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	docs, err := c.hostedDocumentUC.ListHostedDocuments(ctx.Request.Context(), workspaceID, ctx.Query("q"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewListResponse(mapper.HostedDocumentsToResponses(docs)))
+}
+
+// ViewHostedDocument returns a hosted document of the current workspace, whatever its access mode.
+// @Summary View hosted document as a member
+// @Tags Hosted Documents
+// @Produce application/pdf
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param documentId path string true "Hosted document ID"
+// @Param download query bool false "Return as attachment; rejected when downloads are disabled"
+// @Success 200 {file} application/pdf
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/hosted-documents/{documentId} [get]
+// @Security BearerAuth
+func (c *HostedDocumentController) ViewHostedDocument(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	c.view(ctx, templateuc.OpenHostedDocumentCommand{
+		DocumentID:  ctx.Param("documentId"),
+		WorkspaceID: workspaceID,
+	})
+}
+
+// GetHostedDocumentThumbnail returns the first-page thumbnail of a hosted document of the current workspace.
+// Thumbnails are generated in the background shortly after the document is hosted.
+// @Summary Get hosted document thumbnail
+// @Tags Hosted Documents
+// @Produce image/png
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param documentId path string true "Hosted document ID"
+// @Success 200 {file} image/png
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse "Document not found or thumbnail not generated yet"
+// @Router /api/v1/workspace/hosted-documents/{documentId}/thumbnail [get]
+// @Security BearerAuth
+func (c *HostedDocumentController) GetHostedDocumentThumbnail(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	thumbnail, err := c.hostedDocumentUC.GetHostedDocumentThumbnail(ctx.Request.Context(), workspaceID, ctx.Param("documentId"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, max-age=3600")
+	ctx.Data(http.StatusOK, "image/png", thumbnail)
+}
+
+// DeleteHostedDocument deletes a hosted document; its link stops working.
+// @Summary Delete hosted document
+// @Tags Hosted Documents
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param documentId path string true "Hosted document ID"
+// @Success 204 "Hosted document deleted"
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/hosted-documents/{documentId} [delete]
+// @Security BearerAuth
+func (c *HostedDocumentController) DeleteHostedDocument(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	if err := c.hostedDocumentUC.DeleteHostedDocument(ctx.Request.Context(), workspaceID, ctx.Param("documentId")); err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ViewPublicDocument returns a PUBLIC document, or a TOKEN document when the token matches.
+// Expired, MEMBERS and unknown documents, and wrong tokens, all return 404.
+// @Summary View hosted document
+// @Tags Public
+// @Produce application/pdf
+// @Param documentId path string true "Hosted document ID"
+// @Param token query string false "Access token, for TOKEN documents"
+// @Param download query bool false "Return as attachment; rejected when downloads are disabled"
+// @Success 200 {file} application/pdf
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/public/documents/{documentId} [get]
+func (c *HostedDocumentController) ViewPublicDocument(ctx *gin.Context) {
+	c.view(ctx, templateuc.OpenHostedDocumentCommand{
+		DocumentID: ctx.Param("documentId"),
+		Token:      ctx.Query("token"),
+	})
+}
+
+func (c *HostedDocumentController) view(ctx *gin.Context, cmd templateuc.OpenHostedDocumentCommand) {
+	cmd.Download, _ = strconv.ParseBool(ctx.Query("download"))
+
+	doc, err := c.hostedDocumentUC.OpenHostedDocument(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	sendHostedDocument(ctx, doc, cmd.Download)
+}
+
+func sendHostedDocument(ctx *gin.Context, doc *entity.HostedDocument, download bool) {
+	disposition := "inline"
+	if download {
+		disposition = "attachment"
+	}
+
+	ctx.Header("Content-Type", "application/pdf")
+	// The filename is chosen by whoever hosted the document; FormatMediaType quotes or
+	// RFC 2231-encodes it so it cannot break the header.
+	ctx.Header("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": doc.Filename}))
+	ctx.Header("Content-Length", fmt.Sprintf("%d", len(doc.PDF)))
+	ctx.Header("Cache-Control", "private, no-store")
+	ctx.Data(http.StatusOK, "application/pdf", doc.PDF)
+}
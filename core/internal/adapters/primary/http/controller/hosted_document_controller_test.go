@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"mime"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+func TestSendHostedDocument_EscapesFilename(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		filename    string
+		download    bool
+		disposition string
+	}{
+		{name: "plain", filename: "offer.pdf", disposition: "inline"},
+		{name: "download", filename: "offer.pdf", download: true, disposition: "attachment"},
+		{name: "quotes and semicolons", filename: `a"b; evil=1.pdf`, disposition: "inline"},
+		{name: "non-ASCII", filename: "contrato-año.pdf", disposition: "inline"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+
+			sendHostedDocument(ctx, &entity.HostedDocument{Filename: tt.filename, PDF: []byte("%PDF-1.7")}, tt.download)
+
+			disposition, params, err := mime.ParseMediaType(rec.Header().Get("Content-Disposition"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.disposition, disposition)
+			assert.Equal(t, map[string]string{"filename": tt.filename}, params)
+		})
+	}
+}
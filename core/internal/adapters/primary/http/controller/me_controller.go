@@ -11,6 +11,7 @@ import (
 	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	"github.com/rendis/pdf-forge/core/internal/core/port"
 	accessuc "github.com/rendis/pdf-forge/core/internal/core/usecase/access"
+	notificationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/notification"
 	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
 )
 
@@ -21,6 +22,10 @@ type MeController struct {
 	tenantMemberRepo    port.TenantMemberRepository
 	workspaceMemberRepo port.WorkspaceMemberRepository
 	accessHistoryUC     accessuc.UserAccessHistoryUseCase
+	notificationUC      notificationuc.NotificationUseCase
+	profileUC           accessuc.UserProfileUseCase
+	invitationUC        organizationuc.WorkspaceInvitationUseCase
+	preferencesUC       accessuc.UserPreferencesUseCase
 }
 
 // NewMeController creates a new me controller.
@@ -29,12 +34,20 @@ func NewMeController(
 	tenantMemberRepo port.TenantMemberRepository,
 	workspaceMemberRepo port.WorkspaceMemberRepository,
 	accessHistoryUC accessuc.UserAccessHistoryUseCase,
+	notificationUC notificationuc.NotificationUseCase,
+	profileUC accessuc.UserProfileUseCase,
+	invitationUC organizationuc.WorkspaceInvitationUseCase,
+	preferencesUC accessuc.UserPreferencesUseCase,
 ) *MeController {
 	return &MeController{
 		tenantUC:            tenantUC,
 		tenantMemberRepo:    tenantMemberRepo,
 		workspaceMemberRepo: workspaceMemberRepo,
 		accessHistoryUC:     accessHistoryUC,
+		notificationUC:      notificationUC,
+		profileUC:           profileUC,
+		invitationUC:        invitationUC,
+		preferencesUC:       preferencesUC,
 	}
 }
 
@@ -43,12 +56,114 @@ func NewMeController(
 func (c *MeController) RegisterRoutes(rg *gin.RouterGroup) {
 	me := rg.Group("/me")
 	{
+		me.GET("", c.GetMe)
 		me.GET("/tenants", c.ListMyTenants)
 		me.GET("/roles", c.GetMyRoles)
+		me.GET("/preferences", c.GetMyPreferences)
+		me.PUT("/preferences", c.UpdateMyPreferences)
 		me.POST("/access", c.RecordAccess)
+		me.GET("/notifications", c.ListMyNotifications)
+		me.GET("/notifications/unread-count", c.CountMyUnreadNotifications)
+		me.POST("/notifications/read-all", c.MarkAllMyNotificationsRead)
+		me.POST("/notifications/:notificationId/read", c.MarkMyNotificationRead)
+		me.GET("/invitations", c.ListMyInvitations)
+		me.POST("/invitations/accept", c.AcceptInvitationByToken)
+		me.POST("/invitations/decline", c.DeclineInvitationByToken)
+		me.POST("/invitations/:invitationId/accept", c.AcceptMyInvitation)
+		me.POST("/invitations/:invitationId/decline", c.DeclineMyInvitation)
 	}
 }
 
+// GetMe returns the current user's profile with all memberships and feature flags.
+// @Summary Get my profile
+// @Description Returns the resolved user, system role, tenant memberships, workspace memberships with roles
+// @Description and effective feature flags in a single call, intended for application startup.
+// @Tags Me
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.MeResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/me [get]
+// @Security BearerAuth
+func (c *MeController) GetMe(ctx *gin.Context) {
+	userID, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		HandleError(ctx, entity.ErrUnauthorized)
+		return
+	}
+
+	profile, err := c.profileUC.GetProfile(ctx.Request.Context(), userID)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.UserProfileToMeResponse(profile))
+}
+
+// GetMyPreferences returns the current user's preferences.
+// @Summary Get my preferences
+// @Description Returns locale, timezone and default workspace. Defaults (en, UTC) are returned until the user saves their own.
+// @Tags Me
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.UserPreferencesResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/me/preferences [get]
+// @Security BearerAuth
+func (c *MeController) GetMyPreferences(ctx *gin.Context) {
+	userID, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		HandleError(ctx, entity.ErrUnauthorized)
+		return
+	}
+
+	prefs, err := c.preferencesUC.GetPreferences(ctx.Request.Context(), userID)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.UserPreferencesToResponse(prefs))
+}
+
+// UpdateMyPreferences replaces the current user's preferences.
+// @Summary Update my preferences
+// @Description The locale sets the default render language of previews and how times are formatted in notifications;
+// @Description the timezone is used to display schedules. The default workspace must be accessible to the user.
+// @Tags Me
+// @Accept json
+// @Produce json
+// @Param request body dto.UpdateUserPreferencesRequest true "Preferences"
+// @Success 200 {object} dto.UserPreferencesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/me/preferences [put]
+// @Security BearerAuth
+func (c *MeController) UpdateMyPreferences(ctx *gin.Context) {
+	userID, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		HandleError(ctx, entity.ErrUnauthorized)
+		return
+	}
+
+	var req dto.UpdateUserPreferencesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(err))
+		return
+	}
+
+	cmd := mapper.UpdateUserPreferencesRequestToCommand(userID, req)
+	prefs, err := c.preferencesUC.UpdatePreferences(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.UserPreferencesToResponse(prefs))
+}
+
 // ListMyTenants lists tenants the current user is a member of with pagination and optional search.
 // @Summary List my tenants with pagination and optional search
 // @Description Lists tenants where the user is an active member. Supports pagination and optional search by name/code.
@@ -192,3 +307,262 @@ func (c *MeController) RecordAccess(ctx *gin.Context) {
 
 	ctx.Status(http.StatusNoContent)
 }
+
+// ListMyNotifications lists the current user's notifications.
+// @Summary List my notifications
+// @Description Lists the current user's in-product notifications, newest first. Includes the unread counter.
+// @Tags Me
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param perPage query int false "Items per page" default(20)
+// @Param unreadOnly query bool false "Only return unread notifications"
+// @Success 200 {object} dto.PaginatedNotificationsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/me/notifications [get]
+// @Security BearerAuth
+func (c *MeController) ListMyNotifications(ctx *gin.Context) {
+	userID, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		HandleError(ctx, entity.ErrUnauthorized)
+		return
+	}
+
+	var req dto.NotificationListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(err))
+		return
+	}
+
+	filters := mapper.NotificationListRequestToFilters(req)
+	notifications, total, err := c.notificationUC.ListNotifications(ctx.Request.Context(), userID, filters)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	unread, err := c.notificationUC.CountUnread(ctx.Request.Context(), userID)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.NotificationsToPaginatedResponse(notifications, total, unread, req.Page, req.PerPage))
+}
+
+// CountMyUnreadNotifications returns the number of unread notifications.
+// @Summary Count my unread notifications
+// @Description Returns the unread notification counter, intended for lightweight polling.
+// @Tags Me
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.UnreadNotificationsCountResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/me/notifications/unread-count [get]
+// @Security BearerAuth
+func (c *MeController) CountMyUnreadNotifications(ctx *gin.Context) {
+	userID, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		HandleError(ctx, entity.ErrUnauthorized)
+		return
+	}
+
+	count, err := c.notificationUC.CountUnread(ctx.Request.Context(), userID)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.UnreadNotificationsCountResponse{Count: count})
+}
+
+// MarkMyNotificationRead marks a single notification as read.
+// @Summary Mark notification as read
+// @Tags Me
+// @Accept json
+// @Produce json
+// @Param notificationId path string true "Notification ID"
+// @Success 204 "Notification marked as read"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/me/notifications/{notificationId}/read [post]
+// @Security BearerAuth
+func (c *MeController) MarkMyNotificationRead(ctx *gin.Context) {
+	userID, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		HandleError(ctx, entity.ErrUnauthorized)
+		return
+	}
+
+	if err := c.notificationUC.MarkRead(ctx.Request.Context(), ctx.Param("notificationId"), userID); err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// MarkAllMyNotificationsRead marks all of the current user's notifications as read.
+// @Summary Mark all notifications as read
+// @Tags Me
+// @Accept json
+// @Produce json
+// @Success 204 "Notifications marked as read"
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/me/notifications/read-all [post]
+// @Security BearerAuth
+func (c *MeController) MarkAllMyNotificationsRead(ctx *gin.Context) {
+	userID, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		HandleError(ctx, entity.ErrUnauthorized)
+		return
+	}
+
+	if err := c.notificationUC.MarkAllRead(ctx.Request.Context(), userID); err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListMyInvitations lists pending workspace invitations addressed to the current user.
+// @Summary List my invitations
+// @Description Lists unexpired pending invitations sent to the current user's email.
+// @Tags Me
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.ListResponse[dto.MyInvitationResponse]
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/me/invitations [get]
+// @Security BearerAuth
+func (c *MeController) ListMyInvitations(ctx *gin.Context) {
+	userID, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		HandleError(ctx, entity.ErrUnauthorized)
+		return
+	}
+
+	invitations, err := c.invitationUC.ListMyInvitations(ctx.Request.Context(), userID)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewListResponse(mapper.MyInvitationsToResponses(invitations)))
+}
+
+// AcceptInvitationByToken accepts the invitation identified by an email link token.
+// @Summary Accept invitation by token
+// @Description Accepts the invitation from the email link. The invitation must be addressed to the current user's email.
+// @Tags Me
+// @Accept json
+// @Produce json
+// @Param request body dto.InvitationTokenRequest true "Token from the invitation email"
+// @Success 200 {object} dto.MemberResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/me/invitations/accept [post]
+// @Security BearerAuth
+func (c *MeController) AcceptInvitationByToken(ctx *gin.Context) {
+	var req dto.InvitationTokenRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(err))
+		return
+	}
+	c.acceptInvitation(ctx, organizationuc.RespondInvitationCommand{Token: req.Token})
+}
+
+// DeclineInvitationByToken declines the invitation identified by an email link token.
+// @Summary Decline invitation by token
+// @Tags Me
+// @Accept json
+// @Produce json
+// @Param request body dto.InvitationTokenRequest true "Token from the invitation email"
+// @Success 204 "Invitation declined"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/me/invitations/decline [post]
+// @Security BearerAuth
+func (c *MeController) DeclineInvitationByToken(ctx *gin.Context) {
+	var req dto.InvitationTokenRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(err))
+		return
+	}
+	c.declineInvitation(ctx, organizationuc.RespondInvitationCommand{Token: req.Token})
+}
+
+// AcceptMyInvitation accepts one of the current user's pending invitations.
+// @Summary Accept invitation
+// @Tags Me
+// @Accept json
+// @Produce json
+// @Param invitationId path string true "Invitation ID"
+// @Success 200 {object} dto.MemberResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/me/invitations/{invitationId}/accept [post]
+// @Security BearerAuth
+func (c *MeController) AcceptMyInvitation(ctx *gin.Context) {
+	c.acceptInvitation(ctx, organizationuc.RespondInvitationCommand{InvitationID: ctx.Param("invitationId")})
+}
+
+// DeclineMyInvitation declines one of the current user's pending invitations.
+// @Summary Decline invitation
+// @Tags Me
+// @Accept json
+// @Produce json
+// @Param invitationId path string true "Invitation ID"
+// @Success 204 "Invitation declined"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/me/invitations/{invitationId}/decline [post]
+// @Security BearerAuth
+func (c *MeController) DeclineMyInvitation(ctx *gin.Context) {
+	c.declineInvitation(ctx, organizationuc.RespondInvitationCommand{InvitationID: ctx.Param("invitationId")})
+}
+
+func (c *MeController) acceptInvitation(ctx *gin.Context, cmd organizationuc.RespondInvitationCommand) {
+	userID, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		HandleError(ctx, entity.ErrUnauthorized)
+		return
+	}
+	cmd.UserID = userID
+
+	member, err := c.invitationUC.AcceptInvitation(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.MemberToResponse(&entity.MemberWithUser{WorkspaceMember: *member}))
+}
+
+func (c *MeController) declineInvitation(ctx *gin.Context, cmd organizationuc.RespondInvitationCommand) {
+	userID, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		HandleError(ctx, entity.ErrUnauthorized)
+		return
+	}
+	cmd.UserID = userID
+
+	if err := c.invitationUC.DeclineInvitation(ctx.Request.Context(), cmd); err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
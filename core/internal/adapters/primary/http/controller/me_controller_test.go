@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/middleware"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	accessuc "github.com/rendis/pdf-forge/core/internal/core/usecase/access"
+	notificationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/notification"
+)
+
+func TestMeController_GetMe(t *testing.T) {
+	systemRole := entity.SystemRolePlatformAdmin
+	tenantID := "tenant-1"
+	profiles := &fakeMeProfiles{profile: &accessuc.UserProfile{
+		User:       &entity.User{ID: "user-1", Email: "ada@example.com", FullName: "Ada", Status: entity.UserStatusActive},
+		SystemRole: &systemRole,
+		Tenants: []*entity.TenantWithRole{
+			{Tenant: &entity.Tenant{ID: tenantID, Name: "Acme", Code: "ACME"}, Role: entity.TenantRoleOwner},
+			{Role: entity.TenantRoleAdmin}, // tenant no longer resolvable
+		},
+		Workspaces: []*entity.WorkspaceWithRole{{
+			Workspace: entity.Workspace{ID: "ws-1", TenantID: &tenantID, Name: "Sales", Type: entity.WorkspaceTypeClient, Status: entity.WorkspaceStatusActive},
+			Role:      entity.WorkspaceRoleEditor,
+		}},
+		Features: map[string]bool{"gallery": true, "hostedDocuments": false},
+	}}
+	router := newMeTestRouter(&MeController{profileUC: profiles}, "user-1")
+
+	rec := serveMe(router, http.MethodGet, "/api/v1/me")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "user-1", profiles.userID)
+	var resp dto.MeResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "ada@example.com", resp.User.Email)
+	require.NotNil(t, resp.SystemRole)
+	assert.Equal(t, "PLATFORM_ADMIN", *resp.SystemRole)
+	require.Len(t, resp.Tenants, 1, "tenants that cannot be resolved are dropped")
+	assert.Equal(t, "TENANT_OWNER", resp.Tenants[0].Role)
+	require.Len(t, resp.Workspaces, 1)
+	assert.Equal(t, "ws-1", resp.Workspaces[0].ID)
+	assert.Equal(t, "EDITOR", resp.Workspaces[0].Role)
+	assert.Equal(t, map[string]bool{"gallery": true, "hostedDocuments": false}, resp.Features)
+}
+
+func TestMeController_GetMeWithoutMemberships(t *testing.T) {
+	profiles := &fakeMeProfiles{profile: &accessuc.UserProfile{
+		User:     &entity.User{ID: "user-1", Email: "ada@example.com"},
+		Features: map[string]bool{},
+	}}
+	router := newMeTestRouter(&MeController{profileUC: profiles}, "user-1")
+
+	rec := serveMe(router, http.MethodGet, "/api/v1/me")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `null`, mustField(t, rec.Body.Bytes(), "systemRole"))
+	assert.JSONEq(t, `[]`, mustField(t, rec.Body.Bytes(), "tenants"), "empty lists, not null, so the UI can iterate")
+	assert.JSONEq(t, `[]`, mustField(t, rec.Body.Bytes(), "workspaces"))
+}
+
+func TestMeController_GetMeErrors(t *testing.T) {
+	router := newMeTestRouter(&MeController{profileUC: &fakeMeProfiles{}}, "")
+	assert.Equal(t, http.StatusUnauthorized, serveMe(router, http.MethodGet, "/api/v1/me").Code)
+
+	router = newMeTestRouter(&MeController{profileUC: &fakeMeProfiles{err: entity.ErrUserNotFound}}, "user-1")
+	assert.Equal(t, http.StatusNotFound, serveMe(router, http.MethodGet, "/api/v1/me").Code)
+}
+
+func TestMeController_ListMyNotifications(t *testing.T) {
+	versionID := "v-1"
+	notifications := &fakeMeNotifications{
+		list: []*entity.Notification{{
+			ID: "n-1", UserID: "user-1", Type: entity.NotificationTypeRenderFailed,
+			Title: `Rendering "Invoice" failed in prod`, Message: "typst compile failed", ResourceID: &versionID,
+		}},
+		unread: 1,
+	}
+	router := newMeTestRouter(&MeController{notificationUC: notifications}, "user-1")
+
+	rec := serveMe(router, http.MethodGet, "/api/v1/me/notifications?page=2&perPage=10&unreadOnly=true")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "user-1", notifications.userID)
+	assert.Equal(t, port.NotificationFilters{Limit: 10, Offset: 10, UnreadOnly: true}, notifications.filters)
+	var resp dto.PaginatedNotificationsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, string(entity.NotificationTypeRenderFailed), resp.Data[0].Type)
+	assert.Equal(t, &versionID, resp.Data[0].ResourceID)
+	assert.Equal(t, int64(1), resp.UnreadCount)
+}
+
+func TestMeController_ListMyNotificationsRejectsBadPage(t *testing.T) {
+	router := newMeTestRouter(&MeController{notificationUC: &fakeMeNotifications{}}, "user-1")
+
+	rec := serveMe(router, http.MethodGet, "/api/v1/me/notifications?perPage=1000")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMeController_MarkMyNotificationRead(t *testing.T) {
+	notifications := &fakeMeNotifications{}
+	router := newMeTestRouter(&MeController{notificationUC: notifications}, "user-1")
+
+	rec := serveMe(router, http.MethodPost, "/api/v1/me/notifications/n-1/read")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "n-1", notifications.readID)
+	assert.Equal(t, "user-1", notifications.userID)
+
+	notifications.err = entity.ErrNotificationNotFound
+	rec = serveMe(router, http.MethodPost, "/api/v1/me/notifications/n-2/read")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestMeController_NotificationsRequireUser(t *testing.T) {
+	router := newMeTestRouter(&MeController{notificationUC: &fakeMeNotifications{}}, "")
+
+	rec := serveMe(router, http.MethodGet, "/api/v1/me/notifications/unread-count")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func newMeTestRouter(c *MeController, userID string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	v1 := router.Group("/api/v1")
+	if userID != "" {
+		v1.Use(middleware.DummyIdentityAndRoles(userID))
+	}
+	c.RegisterRoutes(v1)
+	return router
+}
+
+func serveMe(router *gin.Engine, method, target string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+	return rec
+}
+
+func mustField(t *testing.T, body []byte, field string) string {
+	t.Helper()
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(body, &fields))
+	raw, ok := fields[field]
+	require.True(t, ok, "response has no %q", field)
+	return string(raw)
+}
+
+type fakeMeProfiles struct {
+	accessuc.UserProfileUseCase
+	profile *accessuc.UserProfile
+	err     error
+	userID  string
+}
+
+func (f *fakeMeProfiles) GetProfile(_ context.Context, userID string) (*accessuc.UserProfile, error) {
+	f.userID = userID
+	return f.profile, f.err
+}
+
+type fakeMeNotifications struct {
+	notificationuc.NotificationUseCase
+	list    []*entity.Notification
+	unread  int64
+	err     error
+	userID  string
+	readID  string
+	filters port.NotificationFilters
+}
+
+func (f *fakeMeNotifications) ListNotifications(_ context.Context, userID string, filters port.NotificationFilters) ([]*entity.Notification, int64, error) {
+	f.userID, f.filters = userID, filters
+	return f.list, int64(len(f.list)), f.err
+}
+
+func (f *fakeMeNotifications) CountUnread(_ context.Context, userID string) (int64, error) {
+	f.userID = userID
+	return f.unread, f.err
+}
+
+func (f *fakeMeNotifications) MarkRead(_ context.Context, id, userID string) error {
+	f.readID, f.userID = id, userID
+	return f.err
+}
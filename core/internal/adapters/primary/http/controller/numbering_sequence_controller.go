@@ -0,0 +1,189 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/mapper"
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/middleware"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// NumberingSequenceController handles the numbering sequences of a workspace: the fiscal or legal
+// numbers, such as invoice numbers, renders of a document type draw and render, and the registry
+// of the numbers drawn.
+type NumberingSequenceController struct {
+	sequenceUC templateuc.NumberingSequenceUseCase
+}
+
+// NewNumberingSequenceController creates a new numbering sequence controller.
+func NewNumberingSequenceController(sequenceUC templateuc.NumberingSequenceUseCase) *NumberingSequenceController {
+	return &NumberingSequenceController{sequenceUC: sequenceUC}
+}
+
+// RegisterRoutes registers all /workspace/numbering-sequences routes.
+func (c *NumberingSequenceController) RegisterRoutes(rg *gin.RouterGroup, middlewareProvider *middleware.Provider) {
+	sequences := rg.Group("/workspace/numbering-sequences")
+	sequences.Use(middlewareProvider.WorkspaceContext())
+	{
+		sequences.GET("", c.ListNumberingSequences)                                         // VIEWER+
+		sequences.POST("", middleware.RequireAdmin(), c.CreateNumberingSequence)            // ADMIN+
+		sequences.GET("/:sequenceId", c.GetNumberingSequence)                               // VIEWER+
+		sequences.PUT("/:sequenceId", middleware.RequireAdmin(), c.UpdateNumberingSequence) // ADMIN+
+		sequences.GET("/:sequenceId/numbers", c.ListSequenceNumbers)                        // VIEWER+
+	}
+}
+
+// ListNumberingSequences lists the numbering sequences of the current workspace.
+// @Summary List numbering sequences
+// @Tags Numbering Sequences
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Success 200 {object} dto.ListResponse[dto.NumberingSequenceResponse]
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/numbering-sequences [get]
+// @Security BearerAuth
+func (c *NumberingSequenceController) ListNumberingSequences(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	sequences, err := c.sequenceUC.ListSequences(ctx.Request.Context(), workspaceID)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewListResponse(mapper.NumberingSequencesToResponses(sequences)))
+}
+
+// CreateNumberingSequence creates the numbering sequence of a document type in the current workspace.
+// @Summary Create numbering sequence
+// @Description Renders of the document type outside the dev environment draw the next number and
+// @Description render it in the injectable of injectableKey. A workspace has one sequence per document type.
+// @Description The prefix may contain {YYYY} and {YY}, replaced by the year the number is drawn in.
+// @Tags Numbering Sequences
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param request body dto.CreateNumberingSequenceRequest true "Sequence data"
+// @Success 201 {object} dto.NumberingSequenceResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/numbering-sequences [post]
+// @Security BearerAuth
+func (c *NumberingSequenceController) CreateNumberingSequence(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	userID, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, entity.ErrUnauthorized)
+		return
+	}
+
+	var req dto.CreateNumberingSequenceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	cmd := mapper.CreateNumberingSequenceRequestToCommand(workspaceID, req, userID)
+	sequence, err := c.sequenceUC.CreateSequence(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, mapper.NumberingSequenceToResponse(sequence))
+}
+
+// GetNumberingSequence retrieves a numbering sequence by ID.
+// @Summary Get numbering sequence
+// @Tags Numbering Sequences
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param sequenceId path string true "Sequence ID"
+// @Success 200 {object} dto.NumberingSequenceResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/numbering-sequences/{sequenceId} [get]
+// @Security BearerAuth
+func (c *NumberingSequenceController) GetNumberingSequence(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	sequence, err := c.sequenceUC.GetSequence(ctx.Request.Context(), workspaceID, ctx.Param("sequenceId"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.NumberingSequenceToResponse(sequence))
+}
+
+// UpdateNumberingSequence updates a numbering sequence.
+// @Summary Update numbering sequence
+// @Description The document type and the counter cannot change, nor yearlyReset once the sequence
+// @Description has drawn numbers. Deactivate a sequence to stop renders from drawing from it.
+// @Tags Numbering Sequences
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param sequenceId path string true "Sequence ID"
+// @Param request body dto.UpdateNumberingSequenceRequest true "Sequence data"
+// @Success 200 {object} dto.NumberingSequenceResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/numbering-sequences/{sequenceId} [put]
+// @Security BearerAuth
+func (c *NumberingSequenceController) UpdateNumberingSequence(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	var req dto.UpdateNumberingSequenceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	cmd := mapper.UpdateNumberingSequenceRequestToCommand(ctx.Param("sequenceId"), workspaceID, req)
+	sequence, err := c.sequenceUC.UpdateSequence(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.NumberingSequenceToResponse(sequence))
+}
+
+// ListSequenceNumbers lists the numbers drawn from a numbering sequence, newest first.
+// @Summary List sequence numbers
+// @Description Numbers drawn by renders that failed are VOIDED and never drawn again. In gapless sequences they are RELEASED and go to the next render.
+// @Tags Numbering Sequences
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param sequenceId path string true "Sequence ID"
+// @Param limit query int false "Maximum numbers returned (default and max 200)"
+// @Success 200 {object} dto.ListResponse[dto.SequenceNumberResponse]
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/numbering-sequences/{sequenceId}/numbers [get]
+// @Security BearerAuth
+func (c *NumberingSequenceController) ListSequenceNumbers(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	limit, ok := positiveQueryInt(ctx, "limit")
+	if !ok {
+		return
+	}
+
+	numbers, err := c.sequenceUC.ListNumbers(ctx.Request.Context(), workspaceID, ctx.Param("sequenceId"), limit)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewListResponse(mapper.SequenceNumbersToResponses(numbers)))
+}
@@ -1,19 +1,39 @@
 package controller
 
 import (
+	"archive/zip"
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"mime"
 	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 
 	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/mapper"
 	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/middleware"
 	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/payload"
 	"github.com/rendis/pdf-forge/core/internal/core/port"
 	templatesvc "github.com/rendis/pdf-forge/core/internal/core/service/template"
+	accessuc "github.com/rendis/pdf-forge/core/internal/core/usecase/access"
+	cataloguc "github.com/rendis/pdf-forge/core/internal/core/usecase/catalog"
+	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
 	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
 )
 
@@ -25,6 +45,15 @@ type RenderController struct {
 	documentTypeRenderUC templateuc.InternalRenderUseCase
 	pdfRenderer          port.PDFRenderer
 	storageProvider      port.StorageProvider
+	assetUC              cataloguc.AssetUseCase
+	preferencesUC        accessuc.UserPreferencesUseCase
+	previewTokenUC       templateuc.PreviewTokenUseCase
+	hostedDocumentUC     templateuc.HostedDocumentUseCase
+	renderJobUC          templateuc.RenderJobUseCase
+	persistedRenderUC    templateuc.PersistedRenderUseCase
+	settingsUC           organizationuc.WorkspaceSettingsUseCase
+	fontUC               cataloguc.WorkspaceFontUseCase
+	requestMapper        port.RequestMapper // can be nil
 }
 
 // NewRenderController creates a new render controller.
@@ -33,12 +62,30 @@ func NewRenderController(
 	documentTypeRenderUC templateuc.InternalRenderUseCase,
 	pdfRenderer port.PDFRenderer,
 	storageProvider port.StorageProvider,
+	assetUC cataloguc.AssetUseCase,
+	preferencesUC accessuc.UserPreferencesUseCase,
+	previewTokenUC templateuc.PreviewTokenUseCase,
+	hostedDocumentUC templateuc.HostedDocumentUseCase,
+	renderJobUC templateuc.RenderJobUseCase,
+	persistedRenderUC templateuc.PersistedRenderUseCase,
+	settingsUC organizationuc.WorkspaceSettingsUseCase,
+	fontUC cataloguc.WorkspaceFontUseCase,
+	requestMapper port.RequestMapper,
 ) *RenderController {
 	return &RenderController{
 		versionUC:            versionUC,
 		documentTypeRenderUC: documentTypeRenderUC,
 		pdfRenderer:          pdfRenderer,
 		storageProvider:      storageProvider,
+		assetUC:              assetUC,
+		preferencesUC:        preferencesUC,
+		previewTokenUC:       previewTokenUC,
+		hostedDocumentUC:     hostedDocumentUC,
+		renderJobUC:          renderJobUC,
+		persistedRenderUC:    persistedRenderUC,
+		settingsUC:           settingsUC,
+		fontUC:               fontUC,
+		requestMapper:        requestMapper,
 	}
 }
 
@@ -47,6 +94,19 @@ func NewRenderController(
 func (c *RenderController) RegisterRoutes(versions *gin.RouterGroup) {
 	// Preview route requires EDITOR+ role
 	versions.POST("/:versionId/preview", middleware.RequireEditor(), c.PreviewVersion)
+	versions.POST("/:versionId/export/typst", middleware.RequireEditor(), c.ExportTypstProject)
+
+	// Preview links for external reviewers require EDITOR+ role
+	versions.GET("/:versionId/preview-tokens", middleware.RequireEditor(), c.ListPreviewTokens)
+	versions.POST("/:versionId/preview-tokens", middleware.RequireEditor(), c.CreatePreviewToken)
+	versions.DELETE("/:versionId/preview-tokens/:tokenId", middleware.RequireEditor(), c.RevokePreviewToken)
+}
+
+// RegisterPublicRoutes registers routes reachable without an account.
+// The token or signature in the URL is the only credential.
+func (c *RenderController) RegisterPublicRoutes(public *gin.RouterGroup) {
+	public.GET("/previews/:token", c.PreviewByToken)
+	public.GET("/objects/*key", c.GetSignedObject)
 }
 
 // RegisterWorkspaceRoutes registers document type render routes under workspace.
@@ -54,6 +114,18 @@ func (c *RenderController) RegisterRoutes(versions *gin.RouterGroup) {
 func (c *RenderController) RegisterWorkspaceRoutes(workspaceGroup *gin.RouterGroup) {
 	workspaceGroup.POST("/document-types/:code/render", c.RenderByDocumentType)
 	workspaceGroup.POST("/templates/versions/:versionId/render", c.RenderByVersionID)
+	workspaceGroup.POST("/document-types/:code/estimate", c.EstimateByDocumentType)
+	workspaceGroup.POST("/templates/versions/:versionId/estimate", c.EstimateByVersionID)
+	workspaceGroup.POST("/document-types/:code/render/jobs", c.SubmitRenderJobByDocumentType)
+	workspaceGroup.POST("/templates/versions/:versionId/render/jobs", c.SubmitRenderJobByVersionID)
+	workspaceGroup.GET("/render/jobs/:jobId", c.GetRenderJob)
+	workspaceGroup.GET("/render/jobs/:jobId/result", c.GetRenderJobResult)
+}
+
+// RegisterBatchRoutes registers the batch render routes under workspace. Their group allows a longer
+// request timeout than single renders. No RBAC is enforced, like RegisterWorkspaceRoutes.
+func (c *RenderController) RegisterBatchRoutes(workspaceGroup *gin.RouterGroup) {
+	workspaceGroup.POST("/templates/versions/:versionId/render/batch", c.RenderBatchByVersionID)
 }
 
 // PreviewVersion generates a preview PDF for a template version.
@@ -61,53 +133,58 @@ func (c *RenderController) RegisterWorkspaceRoutes(workspaceGroup *gin.RouterGro
 // @Tags Template Versions
 // @Accept json
 // @Produce application/pdf
+// @Produce text/html
+// @Produce application/vnd.openxmlformats-officedocument.wordprocessingml.document
 // @Param X-Workspace-ID header string true "Workspace ID"
 // @Param templateId path string true "Template ID"
 // @Param versionId path string true "Version ID"
+// @Param format query string false "Output format: pdf (default), html or docx"
 // @Param request body dto.RenderPreviewRequest true "Injectable values"
 // @Success 200 {file} application/pdf
+// @Header 200 {string} X-Render-Warnings "JSON array of dto.RenderWarningResponse, when there are any"
+// @Header 200 {integer} X-Render-Warning-Count "Number of render warnings, when there are any"
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /api/v1/content/templates/{templateId}/versions/{versionId}/preview [post]
 func (c *RenderController) PreviewVersion(ctx *gin.Context) {
 	versionID := ctx.Param("versionId")
-
-	// Get version with details
-	details, err := c.versionUC.GetVersionWithDetails(ctx.Request.Context(), versionID)
+	format, err := parseRenderFormat(ctx)
 	if err != nil {
 		HandleError(ctx, err)
 		return
 	}
 
-	// Parse content structure into portable document
-	doc, err := portabledoc.Parse(details.ContentStructure)
+	// Get version with details
+	details, err := c.versionUC.GetVersionWithDetails(ctx.Request.Context(), versionID)
 	if err != nil {
-		slog.ErrorContext(ctx.Request.Context(), "failed to parse content structure",
-			slog.String("version_id", versionID),
-			slog.Any("error", err),
-		)
-		respondError(ctx, http.StatusInternalServerError, fmt.Errorf("invalid content structure"))
+		HandleError(ctx, err)
 		return
 	}
 
-	if doc == nil {
-		respondError(ctx, http.StatusBadRequest, fmt.Errorf("version has no content"))
+	doc, ok := parseVersionDocument(ctx, details)
+	if !ok {
 		return
 	}
 
+	c.applyPreferredLanguage(ctx, doc)
+
 	renderReq, ok := c.buildPreviewRenderRequest(ctx, details, doc)
 	if !ok {
 		return
 	}
 
-	result, err := c.pdfRenderer.RenderPreview(ctx.Request.Context(), renderReq)
-	if err != nil {
-		slog.ErrorContext(ctx.Request.Context(), "failed to render PDF",
-			slog.String("version_id", versionID),
-			slog.Any("error", err),
-		)
-		respondError(ctx, http.StatusInternalServerError, fmt.Errorf("failed to generate PDF"))
+	switch format {
+	case portabledoc.OutputHTML:
+		c.sendHTMLPreview(ctx, versionID, renderReq)
+		return
+	case renderFormatDocx:
+		c.sendDocxPreview(ctx, versionID, renderReq)
+		return
+	}
+
+	result, ok := c.renderPreview(ctx, versionID, renderReq)
+	if !ok {
 		return
 	}
 
@@ -115,238 +192,1563 @@ func (c *RenderController) PreviewVersion(ctx *gin.Context) {
 	ctx.Header("Content-Type", "application/pdf")
 	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", result.Filename))
 	ctx.Header("Content-Length", fmt.Sprintf("%d", len(result.PDF)))
+	setRenderWarningHeaders(ctx, result.Warnings)
 
 	// Write PDF bytes
 	ctx.Data(http.StatusOK, "application/pdf", result.PDF)
 }
 
-func (c *RenderController) buildPreviewRenderRequest(
-	ctx *gin.Context,
-	details *entity.TemplateVersionWithDetails,
-	doc *portabledoc.Document,
-) (*port.RenderPreviewRequest, bool) {
-	req, ok := parsePreviewRequest(ctx)
-	if !ok {
-		return nil, false
+// ExportTypstProject returns the Typst project a preview of the version would compile.
+// @Summary Export Typst project
+// @Description Generates the Typst source for the version and payload without compiling it and returns
+// @Description a zip with main.typ, the images it references under assets/, a fonts.json manifest of the
+// @Description font families it uses and a README with the compile command. Use it to reproduce or tweak
+// @Description a render offline and to attach a minimal repro to rendering bug reports.
+// @Tags Template Versions
+// @Accept json
+// @Produce application/zip
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param templateId path string true "Template ID"
+// @Param versionId path string true "Version ID"
+// @Param request body dto.RenderPreviewRequest true "Injectable values"
+// @Success 200 {file} application/zip
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/content/templates/{templateId}/versions/{versionId}/export/typst [post]
+func (c *RenderController) ExportTypstProject(ctx *gin.Context) {
+	versionID := ctx.Param("versionId")
+
+	details, err := c.versionUC.GetVersionWithDetails(ctx.Request.Context(), versionID)
+	if err != nil {
+		HandleError(ctx, err)
+		return
 	}
 
-	renderReq := &port.RenderPreviewRequest{
-		Document:           doc,
-		Injectables:        req.Injectables,
-		InjectableDefaults: templatesvc.BuildVersionInjectableDefaults(details.Injectables),
+	doc, ok := parseVersionDocument(ctx, details)
+	if !ok {
+		return
 	}
 
-	if c.storageProvider == nil {
-		return renderReq, true
+	c.applyPreferredLanguage(ctx, doc)
+
+	renderReq, ok := c.buildPreviewRenderRequest(ctx, details, doc)
+	if !ok {
+		return
 	}
 
-	wsID, _ := middleware.GetWorkspaceID(ctx)
-	tenantID, _ := middleware.GetTenantIDFromHeader(ctx)
-	renderReq.ImageURLResolver = port.NewImageURLResolver(
-		c.storageProvider,
-		port.NewPreviewStorageContext(tenantID, wsID),
-	)
+	result, err := c.pdfRenderer.ExportTypstProject(ctx.Request.Context(), renderReq)
+	if errors.Is(err, entity.ErrLayoutNotAllowed) ||
+		errors.Is(err, entity.ErrUnknownRenderer) ||
+		errors.Is(err, entity.ErrTypstExportUnavailable) {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to export typst project",
+			slog.String("version_id", versionID),
+			slog.Any("error", err),
+		)
+		respondError(ctx, http.StatusInternalServerError, fmt.Errorf("failed to export Typst project"))
+		return
+	}
 
-	return renderReq, true
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", result.Filename))
+	ctx.Data(http.StatusOK, "application/zip", result.Archive)
 }
 
-func parsePreviewRequest(ctx *gin.Context) (*dto.RenderPreviewRequest, bool) {
-	var req dto.RenderPreviewRequest
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		if err.Error() != "EOF" {
-			respondError(ctx, http.StatusBadRequest, err)
-			return nil, false
-		}
-		req.Injectables = make(map[string]any)
+// ListPreviewTokens lists the preview links of a version.
+// @Summary List preview links
+// @Tags Template Versions
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param templateId path string true "Template ID"
+// @Param versionId path string true "Version ID"
+// @Success 200 {object} dto.ListResponse[dto.PreviewTokenResponse]
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/content/templates/{templateId}/versions/{versionId}/preview-tokens [get]
+func (c *RenderController) ListPreviewTokens(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	tokens, err := c.previewTokenUC.ListPreviewTokens(ctx.Request.Context(), workspaceID, ctx.Param("versionId"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
 	}
 
-	return &req, true
+	ctx.JSON(http.StatusOK, dto.NewListResponse(mapper.PreviewTokensToResponses(tokens)))
 }
 
-// RenderByDocumentType resolves a template by document type code and renders a PDF.
-// Uses the fallback chain: workspace → tenant system workspace → global system.
-// @Summary Render PDF by document type
-// @Tags Workspace - Render
+// CreatePreviewToken creates a share link to the rendered preview of a version.
+// @Summary Create preview link
+// @Description Creates an expiring link that lets anyone holding it view the version preview
+// @Description without a workspace account. Previews render with injectable defaults and,
+// @Description when set, the watermark text across every page.
+// @Description The token is returned once; only its hash is stored.
+// @Tags Template Versions
 // @Accept json
-// @Produce application/pdf
-// @Param X-Tenant-Code header string true "Tenant code"
-// @Param X-Workspace-Code header string true "Workspace code"
-// @Param X-Environment header string true "Render environment: dev or prod"
-// @Param code path string true "Document type code"
-// @Param disposition query string false "Content disposition: inline (default) or attachment"
-// @Param request body dto.RenderRequest false "Injectable values"
-// @Success 200 {file} application/pdf
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param templateId path string true "Template ID"
+// @Param versionId path string true "Version ID"
+// @Param request body dto.CreatePreviewTokenRequest false "Preview link options"
+// @Success 201 {object} dto.PreviewTokenWithTokenResponse
 // @Failure 400 {object} dto.ErrorResponse
-// @Failure 401 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
-// @Failure 500 {object} dto.ErrorResponse
-// @Router /api/v1/workspace/document-types/{code}/render [post]
-// @Security BearerAuth
-func (c *RenderController) RenderByDocumentType(ctx *gin.Context) {
-	tenantCode := strings.ToUpper(strings.TrimSpace(ctx.GetHeader("X-Tenant-Code")))
-	if tenantCode == "" {
-		respondError(ctx, http.StatusBadRequest, fmt.Errorf("X-Tenant-Code header is required"))
+// @Router /api/v1/content/templates/{templateId}/versions/{versionId}/preview-tokens [post]
+func (c *RenderController) CreatePreviewToken(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	userID, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, entity.ErrUnauthorized)
 		return
 	}
 
-	workspaceCode := strings.ToUpper(strings.TrimSpace(ctx.GetHeader("X-Workspace-Code")))
-	if workspaceCode == "" {
-		respondError(ctx, http.StatusBadRequest, fmt.Errorf("X-Workspace-Code header is required"))
+	var req dto.CreatePreviewTokenRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && err.Error() != "EOF" {
+		respondBindError(ctx, err)
 		return
 	}
 
-	documentTypeCode := strings.ToUpper(strings.TrimSpace(ctx.Param("code")))
-
-	// Parse optional request body
-	var req dto.RenderRequest
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		if err.Error() != "EOF" {
-			respondError(ctx, http.StatusBadRequest, err)
-			return
-		}
-		req.Injectables = make(map[string]any)
-	}
-
-	env, err := parseRenderEnvironment(ctx.GetHeader("X-Environment"))
+	cmd := mapper.CreatePreviewTokenRequestToCommand(workspaceID, ctx.Param("versionId"), userID, req)
+	token, rawToken, err := c.previewTokenUC.CreatePreviewToken(ctx.Request.Context(), cmd)
 	if err != nil {
-		respondError(ctx, http.StatusBadRequest, err)
+		HandleError(ctx, err)
 		return
 	}
 
-	result, err := c.documentTypeRenderUC.RenderByDocumentType(ctx.Request.Context(), templateuc.InternalRenderCommand{
-		TenantCode:       tenantCode,
-		WorkspaceCode:    workspaceCode,
-		TemplateTypeCode: documentTypeCode,
-		Injectables:      req.Injectables,
-		Headers:          extractHeaders(ctx),
-		Payload:          req.Injectables,
-		Environment:      env,
-	})
+	ctx.JSON(http.StatusCreated, mapper.PreviewTokenWithTokenToResponse(token, rawToken))
+}
+
+// RevokePreviewToken disables a preview link before it expires.
+// @Summary Revoke preview link
+// @Tags Template Versions
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param templateId path string true "Template ID"
+// @Param versionId path string true "Version ID"
+// @Param tokenId path string true "Preview link ID"
+// @Success 204 "Preview link revoked"
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/content/templates/{templateId}/versions/{versionId}/preview-tokens/{tokenId} [delete]
+func (c *RenderController) RevokePreviewToken(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	err := c.previewTokenUC.RevokePreviewToken(ctx.Request.Context(), workspaceID, ctx.Param("versionId"), ctx.Param("tokenId"))
 	if err != nil {
 		HandleError(ctx, err)
 		return
 	}
 
-	slog.InfoContext(ctx.Request.Context(), "document type render completed",
-		slog.String("tenant_code", tenantCode),
-		slog.String("workspace_code", workspaceCode),
-		slog.String("document_type_code", documentTypeCode),
-		slog.Int("page_count", result.PageCount),
-		slog.String("environment", string(env)),
-	)
-
-	sendPDFResponse(ctx, result)
+	ctx.Status(http.StatusNoContent)
 }
 
-// RenderByVersionID renders a PDF for a specific template version by ID.
-// Bypasses document type resolution; uses the full injectable pipeline.
-// @Summary Render PDF by version ID
-// @Tags Workspace - Render
-// @Accept json
+// PreviewByToken renders the version a preview link grants access to.
+// Injectables take their defaults; expired, revoked and unknown links all return 404.
+// @Summary View shared preview
+// @Tags Public
 // @Produce application/pdf
-// @Param X-Tenant-Code header string true "Tenant code"
-// @Param X-Workspace-Code header string true "Workspace code"
-// @Param X-Environment header string true "Render environment: dev or prod"
-// @Param versionId path string true "Template version ID"
+// @Param token path string true "Preview link token"
 // @Param disposition query string false "Content disposition: inline (default) or attachment"
-// @Param request body dto.RenderRequest false "Injectable values"
+// @Param format query string false "Output format: pdf (default) or html"
 // @Success 200 {file} application/pdf
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 401 {object} dto.ErrorResponse
+// @Header 200 {string} X-Render-Warnings "JSON array of dto.RenderWarningResponse, when there are any"
+// @Header 200 {integer} X-Render-Warning-Count "Number of render warnings, when there are any"
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
-// @Router /api/v1/workspace/templates/versions/{versionId}/render [post]
-// @Security BearerAuth
-func (c *RenderController) RenderByVersionID(ctx *gin.Context) {
-	tenantCode := strings.ToUpper(strings.TrimSpace(ctx.GetHeader("X-Tenant-Code")))
-	if tenantCode == "" {
-		respondError(ctx, http.StatusBadRequest, fmt.Errorf("X-Tenant-Code header is required"))
+// @Router /api/v1/public/previews/{token} [get]
+func (c *RenderController) PreviewByToken(ctx *gin.Context) {
+	format, err := parseRenderFormat(ctx)
+	if err != nil {
+		HandleError(ctx, err)
 		return
 	}
-
-	workspaceCode := strings.ToUpper(strings.TrimSpace(ctx.GetHeader("X-Workspace-Code")))
-	if workspaceCode == "" {
-		respondError(ctx, http.StatusBadRequest, fmt.Errorf("X-Workspace-Code header is required"))
+	if format == renderFormatDocx {
+		// An editable copy would drop the link's watermark
+		HandleError(ctx, entity.ErrDocxPreviewLink)
 		return
 	}
 
-	env, err := parseRenderEnvironment(ctx.GetHeader("X-Environment"))
+	access, err := c.previewTokenUC.ResolvePreviewToken(ctx.Request.Context(), ctx.Param("token"))
 	if err != nil {
-		respondError(ctx, http.StatusBadRequest, err)
+		HandleError(ctx, err)
 		return
 	}
 
-	versionID := ctx.Param("versionId")
+	doc, ok := parseVersionDocument(ctx, access.Version)
+	if !ok {
+		return
+	}
 
-	// Parse optional request body
-	var req dto.RenderRequest
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		if err.Error() != "EOF" {
-			respondError(ctx, http.StatusBadRequest, err)
-			return
-		}
-		req.Injectables = make(map[string]any)
+	renderReq := &port.RenderPreviewRequest{
+		Document:           doc,
+		Injectables:        make(map[string]any),
+		InjectableDefaults: templatesvc.BuildVersionInjectableDefaults(access.Version.Injectables),
+	}
+	if access.Token.Watermark != nil {
+		renderReq.Watermark = *access.Token.Watermark
 	}
+	renderReq.UnknownNodes = c.unknownNodeMode(ctx, access.Token.WorkspaceID, doc)
+	renderReq.FontDirs = c.workspaceFontDirs(ctx, access.Token.WorkspaceID)
+	if c.storageProvider != nil {
+		renderReq.ImageURLResolver = port.NewImageURLResolver(
+			c.storageProvider,
+			port.NewPreviewStorageContext(access.TenantID, access.Token.WorkspaceID),
+		)
+	}
+	renderReq.ImageURLResolver = c.assetUC.URLResolver(access.Token.WorkspaceID, renderReq.ImageURLResolver)
 
-	result, err := c.documentTypeRenderUC.RenderByVersionID(ctx.Request.Context(), templateuc.RenderByVersionIDCommand{
-		VersionID:     versionID,
-		TenantCode:    tenantCode,
-		WorkspaceCode: workspaceCode,
-		Injectables:   req.Injectables,
-		Headers:       extractHeaders(ctx),
-		Payload:       req.Injectables,
-		Environment:   env,
-	})
-	if err != nil {
-		HandleError(ctx, err)
+	if format == portabledoc.OutputHTML {
+		c.sendHTMLPreview(ctx, access.Version.ID, renderReq)
 		return
 	}
 
-	slog.InfoContext(ctx.Request.Context(), "version render completed",
-		slog.String("version_id", versionID),
-		slog.String("tenant_code", tenantCode),
-		slog.String("workspace_code", workspaceCode),
-		slog.Int("page_count", result.PageCount),
-	)
+	result, ok := c.renderPreview(ctx, access.Version.ID, renderReq)
+	if !ok {
+		return
+	}
 
 	sendPDFResponse(ctx, result)
 }
 
-func extractHeaders(ctx *gin.Context) map[string]string {
-	headers := make(map[string]string, len(ctx.Request.Header))
-	for k, v := range ctx.Request.Header {
-		if len(v) > 0 {
-			headers[k] = v[0]
-		}
+// GetSignedObject serves an object of the local object storage through a signed URL.
+// Wrong, expired and unknown URLs all return 404.
+// @Summary Download persisted object
+// @Tags Public
+// @Produce application/pdf
+// @Param key path string true "Object key"
+// @Param expires query string true "Expiry, in Unix seconds"
+// @Param signature query string true "URL signature"
+// @Success 200 {file} application/pdf
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/public/objects/{key} [get]
+func (c *RenderController) GetSignedObject(ctx *gin.Context) {
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+	body, err := c.persistedRenderUC.OpenSignedObject(ctx.Request.Context(), key, ctx.Query("expires"), ctx.Query("signature"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
 	}
-	return headers
-}
+	defer body.Close() //nolint:errcheck // read-only
 
-// parseRenderEnvironment validates the X-Environment header and returns the Environment enum.
-func parseRenderEnvironment(header string) (entity.Environment, error) {
-	v := strings.TrimSpace(header)
-	if v == "" {
-		return "", fmt.Errorf("X-Environment header is required. Valid values: dev, prod")
-	}
-	switch strings.ToLower(v) {
-	case "dev":
-		return entity.EnvironmentDev, nil
-	case "prod":
-		return entity.EnvironmentProd, nil
-	default:
-		return "", fmt.Errorf("invalid X-Environment value %q. Valid values: dev, prod", v)
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
 	}
+	ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"%s\"", path.Base(key)))
+	ctx.Header("Cache-Control", "private, max-age=300")
+	ctx.DataFromReader(http.StatusOK, -1, contentType, body, nil)
 }
 
-func sendPDFResponse(ctx *gin.Context, result *port.RenderPreviewResult) {
-	disposition := ctx.DefaultQuery("disposition", "inline")
-	if disposition != "attachment" {
-		disposition = "inline"
+// parseVersionDocument parses the version content into a portable document.
+// It writes the error response and returns false when there is nothing to render.
+func parseVersionDocument(ctx *gin.Context, details *entity.TemplateVersionWithDetails) (*portabledoc.Document, bool) {
+	doc, err := portabledoc.Parse(details.ContentStructure)
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to parse content structure",
+			slog.String("version_id", details.ID),
+			slog.Any("error", err),
+		)
+		respondError(ctx, http.StatusInternalServerError, fmt.Errorf("invalid content structure"))
+		return nil, false
+	}
+
+	if doc == nil {
+		respondError(ctx, http.StatusBadRequest, fmt.Errorf("version has no content"))
+		return nil, false
+	}
+	return doc, true
+}
+
+// renderPreview renders a preview PDF, writing the error response and returning false on failure.
+func (c *RenderController) renderPreview(ctx *gin.Context, versionID string, req *port.RenderPreviewRequest) (*port.RenderPreviewResult, bool) {
+	result, err := c.pdfRenderer.RenderPreview(ctx.Request.Context(), req)
+	if errors.Is(err, entity.ErrInvalidImposition) ||
+		errors.Is(err, entity.ErrLayoutNotAllowed) ||
+		errors.Is(err, entity.ErrUnknownRenderer) {
+		respondError(ctx, http.StatusBadRequest, err)
+		return nil, false
+	}
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to render PDF",
+			slog.String("version_id", versionID),
+			slog.Any("error", err),
+		)
+		respondError(ctx, http.StatusInternalServerError, fmt.Errorf("failed to generate PDF"))
+		return nil, false
+	}
+	return result, true
+}
+
+// sendHTMLPreview converts a preview to HTML and writes it, or the error response.
+func (c *RenderController) sendHTMLPreview(ctx *gin.Context, versionID string, req *port.RenderPreviewRequest) {
+	result, err := c.pdfRenderer.RenderHTML(ctx.Request.Context(), req)
+	if errors.Is(err, entity.ErrLayoutNotAllowed) ||
+		errors.Is(err, entity.ErrUnknownRenderer) ||
+		errors.Is(err, entity.ErrHTMLRenderUnavailable) ||
+		errors.Is(err, entity.ErrHTMLRenderOption) {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to render HTML",
+			slog.String("version_id", versionID),
+			slog.Any("error", err),
+		)
+		respondError(ctx, http.StatusInternalServerError, fmt.Errorf("failed to generate HTML"))
+		return
+	}
+	sendHTMLResponse(ctx, result)
+}
+
+// sendDocxPreview converts a preview to DOCX and writes it, or the error response.
+func (c *RenderController) sendDocxPreview(ctx *gin.Context, versionID string, req *port.RenderPreviewRequest) {
+	result, err := c.pdfRenderer.RenderDocx(ctx.Request.Context(), req)
+	if errors.Is(err, entity.ErrLayoutNotAllowed) ||
+		errors.Is(err, entity.ErrUnknownRenderer) ||
+		errors.Is(err, entity.ErrDocxRenderUnavailable) ||
+		errors.Is(err, entity.ErrDocxRenderOption) {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "failed to render DOCX",
+			slog.String("version_id", versionID),
+			slog.Any("error", err),
+		)
+		respondError(ctx, http.StatusInternalServerError, fmt.Errorf("failed to generate DOCX"))
+		return
+	}
+	sendDocxResponse(ctx, result)
+}
+
+// applyPreferredLanguage sets the document language from the user's locale when the
+// template does not define one, so previews use the editor's language by default.
+func (c *RenderController) applyPreferredLanguage(ctx *gin.Context, doc *portabledoc.Document) {
+	if doc.Meta.Language != "" || c.preferencesUC == nil {
+		return
+	}
+	userID, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		return
+	}
+
+	prefs, err := c.preferencesUC.GetPreferences(ctx.Request.Context(), userID)
+	if err != nil {
+		slog.WarnContext(ctx.Request.Context(), "failed to load user preferences for preview",
+			slog.String("user_id", userID),
+			slog.Any("error", err),
+		)
+		return
+	}
+	if lang := prefs.Language(); portabledoc.ValidLanguages.Contains(lang) {
+		doc.Meta.Language = lang
+	}
+}
+
+func (c *RenderController) buildPreviewRenderRequest(
+	ctx *gin.Context,
+	details *entity.TemplateVersionWithDetails,
+	doc *portabledoc.Document,
+) (*port.RenderPreviewRequest, bool) {
+	req, ok := parsePreviewRequest(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	renderReq := &port.RenderPreviewRequest{
+		Document:           doc,
+		Injectables:        req.Injectables,
+		InjectableDefaults: templatesvc.BuildVersionInjectableDefaults(details.Injectables),
+		Imposition:         mapper.ImpositionRequestToOptions(req.Imposition),
+		Layout:             mapper.LayoutRequestToParams(req.Layout),
+		Overlays:           mapper.OverlaysRequestToOverlays(req.Overlays),
+		DocumentID:         req.DocumentID,
+	}
+	if req.UnitSystem != "" {
+		doc.Meta.UnitSystem = req.UnitSystem
+	}
+
+	wsID, _ := middleware.GetWorkspaceID(ctx)
+	renderReq.UnknownNodes = c.unknownNodeMode(ctx, wsID, doc)
+	renderReq.FontDirs = c.workspaceFontDirs(ctx, wsID)
+	if c.storageProvider != nil {
+		tenantID, _ := middleware.GetTenantIDFromHeader(ctx)
+		renderReq.ImageURLResolver = port.NewImageURLResolver(
+			c.storageProvider,
+			port.NewPreviewStorageContext(tenantID, wsID),
+		)
+	}
+	renderReq.ImageURLResolver = c.assetUC.URLResolver(wsID, renderReq.ImageURLResolver)
+
+	return renderReq, true
+}
+
+// workspaceFontDirs returns the directory of the fonts uploaded to the workspace of a preview, or
+// nil when it has none. When the fonts cannot be loaded the preview uses the installed fonts.
+func (c *RenderController) workspaceFontDirs(ctx *gin.Context, workspaceID string) []string {
+	if c.fontUC == nil {
+		return nil
+	}
+	dir, err := c.fontUC.FontDir(ctx.Request.Context(), workspaceID)
+	if err != nil {
+		slog.WarnContext(ctx.Request.Context(), "failed to load workspace fonts for preview",
+			slog.String("workspace_id", workspaceID),
+			slog.Any("error", err),
+		)
+		return nil
+	}
+	if dir == "" {
+		return nil
+	}
+	return []string{dir}
+}
+
+// unknownNodeMode returns how the workspace renders the unknown nodes of a previewed document.
+// Nothing is looked up for documents without unknown nodes.
+func (c *RenderController) unknownNodeMode(ctx *gin.Context, workspaceID string, doc *portabledoc.Document) entity.UnknownNodeMode {
+	if len(doc.UnknownNodes()) == 0 {
+		return entity.UnknownNodesFlatten
+	}
+	return templatesvc.WorkspaceUnknownNodeMode(ctx.Request.Context(), c.settingsUC, workspaceID)
+}
+
+func parsePreviewRequest(ctx *gin.Context) (*dto.RenderPreviewRequest, bool) {
+	var req dto.RenderPreviewRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		if err.Error() != "EOF" {
+			respondBindError(ctx, err)
+			return nil, false
+		}
+		req.Injectables = make(map[string]any)
+	}
+
+	return &req, true
+}
+
+// renderPayloadField is the field of a render request holding the payload a request mapper reads.
+const renderPayloadField = "injectables"
+
+// bindRenderRequest reads the optional body of a render and returns it with the payload injectors
+// receive. Without a request mapper the payload is the injectables. With one the body is streamed:
+// see streamRenderRequest. It writes the error response and returns false on failure.
+func (c *RenderController) bindRenderRequest(ctx *gin.Context, env entity.Environment) (*dto.RenderRequest, any, bool) {
+	var req dto.RenderRequest
+	var payload any
+	if c.requestMapper == nil {
+		if err := ctx.ShouldBindJSON(&req); err != nil && err.Error() != "EOF" {
+			respondBindError(ctx, err)
+			return nil, nil, false
+		}
+		payload = req.Injectables
+	} else {
+		var err error
+		if payload, err = c.streamRenderRequest(ctx, env, &req); err != nil {
+			respondBindError(ctx, err)
+			return nil, nil, false
+		}
+	}
+	if req.Injectables == nil {
+		req.Injectables = make(map[string]any)
+	}
+	if req.Persist && req.Host != nil {
+		HandleError(ctx, entity.ErrPersistAndHost)
+		return nil, nil, false
+	}
+	return &req, payload, true
+}
+
+// streamRenderRequest hands the injectables of the body to the request mapper straight from the
+// request body, bounded only by the render body limit, so a large payload is never held whole in
+// memory. The other fields bind to req; a mapped object also supplies the injectable values.
+// A body without injectables is mapped as an empty object.
+func (c *RenderController) streamRenderRequest(ctx *gin.Context, env entity.Environment, req *dto.RenderRequest) (any, error) {
+	mapCtx := &port.MapperContext{
+		Operation:   renderOperation(ctx),
+		Environment: env,
+		Headers:     extractHeaders(ctx),
+	}
+	body := bufio.NewReader(ctx.Request.Body)
+	if _, err := body.Peek(1); errors.Is(err, io.EOF) {
+		mapCtx.RawBody = []byte("{}")
+		return c.mapRenderPayload(ctx, mapCtx)
+	}
+
+	var mapped any
+	fields := make(map[string]json.RawMessage)
+	err := payload.Stream(body, payload.Options{}, func(field string, d *payload.Decoder) error {
+		if field != renderPayloadField {
+			var raw json.RawMessage
+			if err := d.Decode(&raw); err != nil {
+				return err
+			}
+			fields[field] = raw
+			return nil
+		}
+		mapCtx.Payload = d
+		var err error
+		mapped, err = c.mapRenderPayload(ctx, mapCtx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if mapCtx.Payload == nil {
+		mapCtx.RawBody = []byte("{}")
+		if mapped, err = c.mapRenderPayload(ctx, mapCtx); err != nil {
+			return nil, err
+		}
+	}
+
+	rest, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(rest, req); err != nil {
+		return nil, err
+	}
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if values, ok := mapped.(map[string]any); ok {
+		req.Injectables = values
+	}
+	return mapped, nil
+}
+
+func (c *RenderController) mapRenderPayload(ctx *gin.Context, mapCtx *port.MapperContext) (any, error) {
+	mapped, err := c.requestMapper.Map(ctx.Request.Context(), mapCtx)
+	if err != nil {
+		return nil, fmt.Errorf("mapping render payload: %w", err)
+	}
+	return mapped, nil
+}
+
+// renderOperation returns the InjectorContext operation of a render in the requested format.
+// An unsupported format is reported once the body is read.
+func renderOperation(ctx *gin.Context) string {
+	format, _ := parseRenderFormat(ctx)
+	switch format {
+	case portabledoc.OutputHTML:
+		return templatesvc.HTMLRenderOperation
+	case renderFormatDocx:
+		return templatesvc.DocxRenderOperation
+	}
+	return templatesvc.RenderOperation
+}
+
+// RenderByDocumentType resolves a template by document type code and renders a PDF.
+// Uses the fallback chain: workspace → tenant system workspace → global system.
+// @Summary Render PDF by document type
+// @Tags Workspace - Render
+// @Accept json
+// @Produce application/pdf
+// @Produce text/html
+// @Produce application/vnd.openxmlformats-officedocument.wordprocessingml.document
+// @Param X-Tenant-Code header string true "Tenant code"
+// @Param X-Workspace-Code header string true "Workspace code"
+// @Param X-Environment header string true "Render environment: dev or prod"
+// @Param X-Currency-Rate-Date header string false "Date of the exchange rates amounts are converted with, YYYY-MM-DD; latest when missing"
+// @Param code path string true "Document type code"
+// @Param disposition query string false "Content disposition: inline (default) or attachment; docx is always an attachment"
+// @Param format query string false "Output format: pdf (default), html or docx. html and docx cannot be hosted, persisted or imposed"
+// @Param request body dto.RenderRequest false "Injectable values and optional hosting or persistence"
+// @Success 200 {file} application/pdf
+// @Header 200 {string} X-Render-Warnings "JSON array of dto.RenderWarningResponse, when there are any"
+// @Header 200 {integer} X-Render-Warning-Count "Number of render warnings, when there are any"
+// @Header 200 {integer} X-Render-Degradation-Count "Number of DEGRADED_* warnings of a degraded render, when there are any"
+// @Header 200 {string} X-Render-Injector-Timings "JSON array of dto.InjectorTimingResponse, when registry injectors ran"
+// @Header 200 {string} X-Render-Currency-Rates "JSON array of dto.CurrencyRateResponse, when amounts were converted"
+// @Header 200 {string} X-Render-Currency-Record-ID "ID of the stored record of the exchange rates, when amounts were converted"
+// @Header 200 {string} X-Render-Document-Number "Number drawn from the numbering sequence of the document type, percent-encoded when not ASCII"
+// @Success 201 {object} dto.HostedDocumentLinkResponse "When host is set"
+// @Success 201 {object} dto.PersistedRenderResponse "When persist is set"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse "A render quota of the tenant or workspace was reached (QUOTA_EXCEEDED)"
+// @Header 429 {integer} Retry-After "Seconds until the quota allows the render"
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 502 {object} dto.ErrorResponse "A critical injector failed (RENDER_INJECTOR_FAILED)"
+// @Failure 504 {object} dto.ErrorResponse "A critical injector timed out (RENDER_INJECTOR_TIMEOUT)"
+// @Header 500 {string} X-Render-Failure-ID "ID of the captured Typst input, when the compile failed"
+// @Router /api/v1/workspace/document-types/{code}/render [post]
+// @Security BearerAuth
+func (c *RenderController) RenderByDocumentType(ctx *gin.Context) {
+	tenantCode := strings.ToUpper(strings.TrimSpace(ctx.GetHeader("X-Tenant-Code")))
+	if tenantCode == "" {
+		respondError(ctx, http.StatusBadRequest, fmt.Errorf("X-Tenant-Code header is required"))
+		return
+	}
+
+	workspaceCode := strings.ToUpper(strings.TrimSpace(ctx.GetHeader("X-Workspace-Code")))
+	if workspaceCode == "" {
+		respondError(ctx, http.StatusBadRequest, fmt.Errorf("X-Workspace-Code header is required"))
+		return
+	}
+
+	documentTypeCode := strings.ToUpper(strings.TrimSpace(ctx.Param("code")))
+
+	env, err := parseRenderEnvironment(ctx.GetHeader("X-Environment"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	req, payload, ok := c.bindRenderRequest(ctx, env)
+	if !ok {
+		return
+	}
+	format, ok := parseRenderRequestFormat(ctx, req)
+	if !ok {
+		return
+	}
+
+	cmd := templateuc.InternalRenderCommand{
+		TenantCode:       tenantCode,
+		WorkspaceCode:    workspaceCode,
+		TemplateTypeCode: documentTypeCode,
+		Injectables:      req.Injectables,
+		Headers:          extractHeaders(ctx),
+		Payload:          payload,
+		Environment:      env,
+		Imposition:       mapper.ImpositionRequestToOptions(req.Imposition),
+		Layout:           mapper.LayoutRequestToParams(req.Layout),
+		Overlays:         mapper.OverlaysRequestToOverlays(req.Overlays),
+		DocumentID:       req.DocumentID,
+		Degraded:         req.Degraded,
+		UnitSystem:       req.UnitSystem,
+	}
+	switch format {
+	case portabledoc.OutputHTML:
+		page, err := c.documentTypeRenderUC.RenderHTMLByDocumentType(ctx.Request.Context(), cmd)
+		if err != nil {
+			HandleError(ctx, err)
+			return
+		}
+		sendHTMLResponse(ctx, page)
+		return
+	case renderFormatDocx:
+		docx, err := c.documentTypeRenderUC.RenderDocxByDocumentType(ctx.Request.Context(), cmd)
+		if err != nil {
+			HandleError(ctx, err)
+			return
+		}
+		sendDocxResponse(ctx, docx)
+		return
+	}
+
+	result, err := c.documentTypeRenderUC.RenderByDocumentType(ctx.Request.Context(), cmd)
+	if err != nil {
+		handleRenderError(ctx, err)
+		return
+	}
+
+	slog.InfoContext(ctx.Request.Context(), "document type render completed",
+		slog.String("tenant_code", tenantCode),
+		slog.String("workspace_code", workspaceCode),
+		slog.String("document_type_code", documentTypeCode),
+		slog.Int("page_count", result.PageCount),
+		slog.String("environment", string(env)),
+	)
+
+	if req.Host != nil {
+		c.sendHostedLink(ctx, tenantCode, workspaceCode, req.Host, result)
+		return
+	}
+	if req.Persist {
+		c.sendPersistedRender(ctx, tenantCode, workspaceCode, result)
+		return
+	}
+	sendPDFResponse(ctx, result)
+}
+
+// RenderByVersionID renders a PDF for a specific template version by ID.
+// Bypasses document type resolution; uses the full injectable pipeline.
+// @Summary Render PDF by version ID
+// @Tags Workspace - Render
+// @Accept json
+// @Produce application/pdf
+// @Produce text/html
+// @Produce application/vnd.openxmlformats-officedocument.wordprocessingml.document
+// @Param X-Tenant-Code header string true "Tenant code"
+// @Param X-Workspace-Code header string true "Workspace code"
+// @Param X-Environment header string true "Render environment: dev or prod"
+// @Param X-Currency-Rate-Date header string false "Date of the exchange rates amounts are converted with, YYYY-MM-DD; latest when missing"
+// @Param versionId path string true "Template version ID"
+// @Param disposition query string false "Content disposition: inline (default) or attachment; docx is always an attachment"
+// @Param format query string false "Output format: pdf (default), html or docx. html and docx cannot be hosted, persisted or imposed"
+// @Param request body dto.RenderRequest false "Injectable values and optional hosting or persistence"
+// @Success 200 {file} application/pdf
+// @Header 200 {string} X-Render-Warnings "JSON array of dto.RenderWarningResponse, when there are any"
+// @Header 200 {integer} X-Render-Warning-Count "Number of render warnings, when there are any"
+// @Header 200 {integer} X-Render-Degradation-Count "Number of DEGRADED_* warnings of a degraded render, when there are any"
+// @Header 200 {string} X-Render-Injector-Timings "JSON array of dto.InjectorTimingResponse, when registry injectors ran"
+// @Header 200 {string} X-Render-Currency-Rates "JSON array of dto.CurrencyRateResponse, when amounts were converted"
+// @Header 200 {string} X-Render-Currency-Record-ID "ID of the stored record of the exchange rates, when amounts were converted"
+// @Header 200 {string} X-Render-Document-Number "Number drawn from the numbering sequence of the document type, percent-encoded when not ASCII"
+// @Success 201 {object} dto.HostedDocumentLinkResponse "When host is set"
+// @Success 201 {object} dto.PersistedRenderResponse "When persist is set"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse "A render quota of the tenant or workspace was reached (QUOTA_EXCEEDED)"
+// @Header 429 {integer} Retry-After "Seconds until the quota allows the render"
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 502 {object} dto.ErrorResponse "A critical injector failed (RENDER_INJECTOR_FAILED)"
+// @Failure 504 {object} dto.ErrorResponse "A critical injector timed out (RENDER_INJECTOR_TIMEOUT)"
+// @Header 500 {string} X-Render-Failure-ID "ID of the captured Typst input, when the compile failed"
+// @Router /api/v1/workspace/templates/versions/{versionId}/render [post]
+// @Security BearerAuth
+func (c *RenderController) RenderByVersionID(ctx *gin.Context) {
+	tenantCode := strings.ToUpper(strings.TrimSpace(ctx.GetHeader("X-Tenant-Code")))
+	if tenantCode == "" {
+		respondError(ctx, http.StatusBadRequest, fmt.Errorf("X-Tenant-Code header is required"))
+		return
+	}
+
+	workspaceCode := strings.ToUpper(strings.TrimSpace(ctx.GetHeader("X-Workspace-Code")))
+	if workspaceCode == "" {
+		respondError(ctx, http.StatusBadRequest, fmt.Errorf("X-Workspace-Code header is required"))
+		return
+	}
+
+	env, err := parseRenderEnvironment(ctx.GetHeader("X-Environment"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	versionID := ctx.Param("versionId")
+
+	req, payload, ok := c.bindRenderRequest(ctx, env)
+	if !ok {
+		return
+	}
+	format, ok := parseRenderRequestFormat(ctx, req)
+	if !ok {
+		return
+	}
+
+	cmd := templateuc.RenderByVersionIDCommand{
+		VersionID:     versionID,
+		TenantCode:    tenantCode,
+		WorkspaceCode: workspaceCode,
+		Injectables:   req.Injectables,
+		Headers:       extractHeaders(ctx),
+		Payload:       payload,
+		Environment:   env,
+		Imposition:    mapper.ImpositionRequestToOptions(req.Imposition),
+		Layout:        mapper.LayoutRequestToParams(req.Layout),
+		Overlays:      mapper.OverlaysRequestToOverlays(req.Overlays),
+		DocumentID:    req.DocumentID,
+		Degraded:      req.Degraded,
+		UnitSystem:    req.UnitSystem,
+	}
+	switch format {
+	case portabledoc.OutputHTML:
+		page, err := c.documentTypeRenderUC.RenderHTMLByVersionID(ctx.Request.Context(), cmd)
+		if err != nil {
+			HandleError(ctx, err)
+			return
+		}
+		sendHTMLResponse(ctx, page)
+		return
+	case renderFormatDocx:
+		docx, err := c.documentTypeRenderUC.RenderDocxByVersionID(ctx.Request.Context(), cmd)
+		if err != nil {
+			HandleError(ctx, err)
+			return
+		}
+		sendDocxResponse(ctx, docx)
+		return
+	}
+
+	result, err := c.documentTypeRenderUC.RenderByVersionID(ctx.Request.Context(), cmd)
+	if err != nil {
+		handleRenderError(ctx, err)
+		return
+	}
+
+	slog.InfoContext(ctx.Request.Context(), "version render completed",
+		slog.String("version_id", versionID),
+		slog.String("tenant_code", tenantCode),
+		slog.String("workspace_code", workspaceCode),
+		slog.Int("page_count", result.PageCount),
+	)
+
+	if req.Host != nil {
+		c.sendHostedLink(ctx, tenantCode, workspaceCode, req.Host, result)
+		return
+	}
+	if req.Persist {
+		c.sendPersistedRender(ctx, tenantCode, workspaceCode, result)
+		return
+	}
+	sendPDFResponse(ctx, result)
+}
+
+// RenderBatchByVersionID renders a template version once per item and streams the PDFs back in a zip.
+// @Summary Batch render PDFs by version ID
+// @Description Renders the version once per item, several items at a time, and streams a zip with one PDF
+// @Description per rendered item, named with the item position and its filename, followed by manifest.json
+// @Description listing the outcome of every item. A failed item does not stop the batch; its error is in
+// @Description the manifest. Items take the injectable pipeline of a single render and each counts as one.
+// @Tags Workspace - Render
+// @Accept json
+// @Produce application/zip
+// @Param X-Tenant-Code header string true "Tenant code"
+// @Param X-Workspace-Code header string true "Workspace code"
+// @Param X-Environment header string true "Render environment: dev or prod"
+// @Param X-Currency-Rate-Date header string false "Date of the exchange rates amounts are converted with, YYYY-MM-DD; latest when missing"
+// @Param versionId path string true "Template version ID"
+// @Param request body dto.BatchRenderRequest true "Injectable values per document"
+// @Success 200 {file} application/zip
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/templates/versions/{versionId}/render/batch [post]
+func (c *RenderController) RenderBatchByVersionID(ctx *gin.Context) {
+	target, ok := parseWorkspaceRenderHeaders(ctx)
+	if !ok {
+		return
+	}
+	versionID := ctx.Param("versionId")
+
+	var req dto.BatchRenderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondBindError(ctx, err)
+		return
+	}
+
+	cmd := templateuc.BatchRenderCommand{
+		VersionID:     versionID,
+		TenantCode:    target.tenantCode,
+		WorkspaceCode: target.workspaceCode,
+		Headers:       extractHeaders(ctx),
+		Environment:   target.env,
+		Imposition:    mapper.ImpositionRequestToOptions(req.Imposition),
+		Layout:        mapper.LayoutRequestToParams(req.Layout),
+		Overlays:      mapper.OverlaysRequestToOverlays(req.Overlays),
+		Degraded:      req.Degraded,
+		UnitSystem:    req.UnitSystem,
+		Items:         make([]templateuc.BatchRenderItem, len(req.Items)),
+	}
+	for i, item := range req.Items {
+		cmd.Items[i] = templateuc.BatchRenderItem{
+			Injectables: item.Injectables,
+			DocumentID:  item.DocumentID,
+			Overlays:    mapper.OverlaysRequestToOverlays(item.Overlays),
+		}
+	}
+
+	manifest := dto.BatchRenderManifest{VersionID: versionID, Items: make([]dto.BatchRenderManifestItem, len(req.Items))}
+	for i := range manifest.Items {
+		manifest.Items[i] = dto.BatchRenderManifestItem{Index: i, Error: "not rendered"}
+	}
+
+	// The batch is bounded by its route's request timeout instead of the server write timeout.
+	_ = http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Time{})
+
+	// The zip starts with the first finished item, so errors finding the version still get a JSON response.
+	var zw *zip.Writer
+	err := c.documentTypeRenderUC.RenderBatch(ctx.Request.Context(), cmd, func(item templateuc.BatchRenderItemResult) error {
+		if zw == nil {
+			ctx.Header("Content-Type", "application/zip")
+			ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"batch-%s.zip\"", versionID))
+			ctx.Status(http.StatusOK)
+			zw = zip.NewWriter(ctx.Writer)
+		}
+
+		entry := &manifest.Items[item.Index]
+		if item.Err != nil {
+			entry.Status = mapErrorToStatusCode(item.Err)
+			entry.Error = item.Err.Error()
+			entry.Code, entry.Retryable = renderErrorCode(item.Err)
+			entry.Details = mapper.RenderErrorDetailsToResponse(entity.RenderErrorDetailsOf(item.Err))
+			if entry.Status == http.StatusInternalServerError {
+				slog.ErrorContext(ctx.Request.Context(), "batch render item failed",
+					slog.String("version_id", versionID),
+					slog.Int("index", item.Index),
+					slog.Any("error", item.Err),
+				)
+			}
+			return nil
+		}
+
+		name := batchEntryName(item.Index, req.Items[item.Index].Filename, item.Result.Filename)
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store, Modified: time.Now()})
+		if err != nil {
+			return fmt.Errorf("adding %s to batch zip: %w", name, err)
+		}
+		if _, err := w.Write(item.Result.PDF); err != nil {
+			return fmt.Errorf("writing %s to batch zip: %w", name, err)
+		}
+		*entry = dto.BatchRenderManifestItem{
+			Index:     item.Index,
+			File:      name,
+			PageCount: item.Result.PageCount,
+			Warnings:  mapper.RenderWarningsToResponse(item.Result.Warnings),
+			Degraded:  entity.CountDegradations(item.Result.Warnings) > 0,
+
+			InjectorTimings:  mapper.InjectorTimingsToResponse(item.Result.InjectorTimings),
+			CurrencyRates:    mapper.CurrencyRatesToResponse(item.Result.CurrencyRates),
+			CurrencyRecordID: item.Result.CurrencyRecordID,
+			DocumentNumber:   item.Result.DocumentNumber,
+		}
+		manifest.Rendered++
+		return zw.Flush()
+	})
+	if zw == nil {
+		HandleError(ctx, err)
+		return
+	}
+	if err != nil {
+		slog.WarnContext(ctx.Request.Context(), "batch render stopped",
+			slog.String("version_id", versionID),
+			slog.Int("rendered", manifest.Rendered),
+			slog.Any("error", err),
+		)
+	}
+	manifest.Failed = len(manifest.Items) - manifest.Rendered
+
+	if err := writeBatchManifest(zw, &manifest); err != nil {
+		slog.WarnContext(ctx.Request.Context(), "failed to finish batch zip", slog.Any("error", err))
+		return
+	}
+
+	slog.InfoContext(ctx.Request.Context(), "batch render completed",
+		slog.String("version_id", versionID),
+		slog.String("tenant_code", target.tenantCode),
+		slog.String("workspace_code", target.workspaceCode),
+		slog.Int("rendered", manifest.Rendered),
+		slog.Int("failed", manifest.Failed),
+	)
+}
+
+// batchEntryName returns the zip entry of a batch item: its 1-based position, so entries are unique
+// and sort in request order, followed by the requested filename or the rendered one.
+func batchEntryName(index int, requested, rendered string) string {
+	name := path.Base(strings.ReplaceAll(strings.TrimSpace(requested), "\\", "/"))
+	if name == "." || name == "/" || name == ".." {
+		name = rendered
+	}
+	if !strings.HasSuffix(strings.ToLower(name), ".pdf") {
+		name += ".pdf"
+	}
+	return fmt.Sprintf("%04d-%s", index+1, name)
+}
+
+// writeBatchManifest adds manifest.json and closes the zip.
+func writeBatchManifest(zw *zip.Writer, manifest *dto.BatchRenderManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding batch manifest: %w", err)
+	}
+	w, err := zw.Create("manifest.json")
+	if err != nil {
+		return fmt.Errorf("adding batch manifest: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("writing batch manifest: %w", err)
+	}
+	return zw.Close()
+}
+
+// EstimateByDocumentType estimates the render of the template a document type resolves to.
+// @Summary Estimate render by document type
+// @Description Resolves the template like the render endpoint and returns the expected page count, compile
+// @Description time class and metered cost of rendering it with the payload. The estimate comes from a dry
+// @Description compile that produces no document, emits no events and is not counted in statistics; with
+// @Description statisticsOnly it comes from the recent renders of the version on the serving instance
+// @Description instead, falling back to a dry compile when there are none. Warnings flag templates whose
+// @Description compile time approaches the render timeout or whose payload renders more pages than usual.
+// @Tags Workspace - Render
+// @Accept json
+// @Produce json
+// @Param X-Tenant-Code header string true "Tenant code"
+// @Param X-Workspace-Code header string true "Workspace code"
+// @Param X-Environment header string true "Render environment: dev or prod"
+// @Param X-Currency-Rate-Date header string false "Date of the exchange rates amounts are converted with, YYYY-MM-DD; latest when missing"
+// @Param code path string true "Document type code"
+// @Param statisticsOnly query bool false "Estimate from render statistics without compiling"
+// @Param request body dto.RenderRequest false "Sample injectable values; host is ignored"
+// @Success 200 {object} dto.RenderEstimateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/document-types/{code}/estimate [post]
+// @Security BearerAuth
+func (c *RenderController) EstimateByDocumentType(ctx *gin.Context) {
+	target, ok := parseWorkspaceRenderTarget(ctx)
+	if !ok {
+		return
+	}
+
+	estimate, err := c.documentTypeRenderUC.EstimateByDocumentType(ctx.Request.Context(), templateuc.InternalRenderCommand{
+		TenantCode:       target.tenantCode,
+		WorkspaceCode:    target.workspaceCode,
+		TemplateTypeCode: strings.ToUpper(strings.TrimSpace(ctx.Param("code"))),
+		Injectables:      target.req.Injectables,
+		Headers:          extractHeaders(ctx),
+		Payload:          target.req.Injectables,
+		Environment:      target.env,
+		Imposition:       mapper.ImpositionRequestToOptions(target.req.Imposition),
+		Layout:           mapper.LayoutRequestToParams(target.req.Layout),
+		Overlays:         mapper.OverlaysRequestToOverlays(target.req.Overlays),
+		DocumentID:       target.req.DocumentID,
+		Degraded:         target.req.Degraded,
+		UnitSystem:       target.req.UnitSystem,
+	}, ctx.Query("statisticsOnly") == "true")
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.RenderEstimateToResponse(estimate))
+}
+
+// EstimateByVersionID estimates the render of a specific template version.
+// @Summary Estimate render by version ID
+// @Description Returns the expected page count, compile time class and metered cost of rendering the version
+// @Description with the payload. See the document type estimate for how estimates are computed.
+// @Tags Workspace - Render
+// @Accept json
+// @Produce json
+// @Param X-Tenant-Code header string true "Tenant code"
+// @Param X-Workspace-Code header string true "Workspace code"
+// @Param X-Environment header string true "Render environment: dev or prod"
+// @Param X-Currency-Rate-Date header string false "Date of the exchange rates amounts are converted with, YYYY-MM-DD; latest when missing"
+// @Param versionId path string true "Template version ID"
+// @Param statisticsOnly query bool false "Estimate from render statistics without compiling"
+// @Param request body dto.RenderRequest false "Sample injectable values; host is ignored"
+// @Success 200 {object} dto.RenderEstimateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/templates/versions/{versionId}/estimate [post]
+// @Security BearerAuth
+func (c *RenderController) EstimateByVersionID(ctx *gin.Context) {
+	target, ok := parseWorkspaceRenderTarget(ctx)
+	if !ok {
+		return
+	}
+
+	estimate, err := c.documentTypeRenderUC.EstimateByVersionID(ctx.Request.Context(), templateuc.RenderByVersionIDCommand{
+		VersionID:     ctx.Param("versionId"),
+		TenantCode:    target.tenantCode,
+		WorkspaceCode: target.workspaceCode,
+		Injectables:   target.req.Injectables,
+		Headers:       extractHeaders(ctx),
+		Payload:       target.req.Injectables,
+		Environment:   target.env,
+		Imposition:    mapper.ImpositionRequestToOptions(target.req.Imposition),
+		Layout:        mapper.LayoutRequestToParams(target.req.Layout),
+		Overlays:      mapper.OverlaysRequestToOverlays(target.req.Overlays),
+		DocumentID:    target.req.DocumentID,
+		Degraded:      target.req.Degraded,
+		UnitSystem:    target.req.UnitSystem,
+	}, ctx.Query("statisticsOnly") == "true")
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.RenderEstimateToResponse(estimate))
+}
+
+// SubmitRenderJobByDocumentType queues a document type render to run in the background.
+// @Summary Submit render job by document type
+// @Description Queues the render and responds at once with the job to poll. Use it for documents that
+// @Description take longer to render than a request may last. The job takes the body of a single render,
+// @Description except host, and keeps its PDF for 24 hours after submission.
+// @Tags Workspace - Render
+// @Accept json
+// @Produce json
+// @Param X-Tenant-Code header string true "Tenant code"
+// @Param X-Workspace-Code header string true "Workspace code"
+// @Param X-Environment header string true "Render environment: dev or prod"
+// @Param X-Currency-Rate-Date header string false "Date of the exchange rates amounts are converted with, YYYY-MM-DD; latest when missing"
+// @Param code path string true "Document type code"
+// @Param request body dto.RenderRequest false "Injectable values"
+// @Success 202 {object} dto.RenderJobResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/document-types/{code}/render/jobs [post]
+// @Security BearerAuth
+func (c *RenderController) SubmitRenderJobByDocumentType(ctx *gin.Context) {
+	target, ok := parseWorkspaceRenderTarget(ctx)
+	if !ok {
+		return
+	}
+	cmd := renderJobCommand(ctx, target)
+	cmd.DocumentTypeCode = strings.ToUpper(strings.TrimSpace(ctx.Param("code")))
+	c.submitRenderJob(ctx, target, cmd)
+}
+
+// SubmitRenderJobByVersionID queues a template version render to run in the background.
+// @Summary Submit render job by version ID
+// @Description Queues the render and responds at once with the job to poll. The job takes the body of a
+// @Description single render, except host, and keeps its PDF for 24 hours after submission.
+// @Tags Workspace - Render
+// @Accept json
+// @Produce json
+// @Param X-Tenant-Code header string true "Tenant code"
+// @Param X-Workspace-Code header string true "Workspace code"
+// @Param X-Environment header string true "Render environment: dev or prod"
+// @Param X-Currency-Rate-Date header string false "Date of the exchange rates amounts are converted with, YYYY-MM-DD; latest when missing"
+// @Param versionId path string true "Template version ID"
+// @Param request body dto.RenderRequest false "Injectable values"
+// @Success 202 {object} dto.RenderJobResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/templates/versions/{versionId}/render/jobs [post]
+// @Security BearerAuth
+func (c *RenderController) SubmitRenderJobByVersionID(ctx *gin.Context) {
+	target, ok := parseWorkspaceRenderTarget(ctx)
+	if !ok {
+		return
+	}
+	cmd := renderJobCommand(ctx, target)
+	cmd.VersionID = ctx.Param("versionId")
+	c.submitRenderJob(ctx, target, cmd)
+}
+
+// renderJobCommand builds the job command shared by both submit routes.
+func renderJobCommand(ctx *gin.Context, target *workspaceRenderTarget) templateuc.SubmitRenderJobCommand {
+	return templateuc.SubmitRenderJobCommand{
+		TenantCode:    target.tenantCode,
+		WorkspaceCode: target.workspaceCode,
+		Injectables:   target.req.Injectables,
+		Headers:       extractHeaders(ctx),
+		Environment:   target.env,
+		Imposition:    mapper.ImpositionRequestToOptions(target.req.Imposition),
+		Layout:        mapper.LayoutRequestToParams(target.req.Layout),
+		Overlays:      mapper.OverlaysRequestToOverlays(target.req.Overlays),
+		DocumentID:    target.req.DocumentID,
+		Degraded:      target.req.Degraded,
+		UnitSystem:    target.req.UnitSystem,
+	}
+}
+
+func (c *RenderController) submitRenderJob(ctx *gin.Context, target *workspaceRenderTarget, cmd templateuc.SubmitRenderJobCommand) {
+	if target.req.Host != nil {
+		respondError(ctx, http.StatusBadRequest, fmt.Errorf("host is not supported by render jobs"))
+		return
+	}
+
+	state, err := c.renderJobUC.SubmitRenderJob(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusAccepted, mapper.RenderJobToResponse(state))
+}
+
+// GetRenderJob returns the status of a render job of the workspace.
+// @Summary Get render job
+// @Description Poll until status is SUCCEEDED, then download the PDF from resultUrl, or FAILED.
+// @Tags Workspace - Render
+// @Produce json
+// @Param X-Tenant-Code header string true "Tenant code"
+// @Param X-Workspace-Code header string true "Workspace code"
+// @Param jobId path string true "Render job ID"
+// @Success 200 {object} dto.RenderJobResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/render/jobs/{jobId} [get]
+// @Security BearerAuth
+func (c *RenderController) GetRenderJob(ctx *gin.Context) {
+	tenantCode, workspaceCode, ok := parseRenderJobOwner(ctx)
+	if !ok {
+		return
+	}
+
+	state, err := c.renderJobUC.GetRenderJob(ctx.Request.Context(), tenantCode, workspaceCode, ctx.Param("jobId"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, mapper.RenderJobToResponse(state))
+}
+
+// GetRenderJobResult downloads the PDF of a succeeded render job.
+// @Summary Download render job result
+// @Tags Workspace - Render
+// @Produce application/pdf
+// @Param X-Tenant-Code header string true "Tenant code"
+// @Param X-Workspace-Code header string true "Workspace code"
+// @Param jobId path string true "Render job ID"
+// @Param disposition query string false "Content disposition: inline (default) or attachment"
+// @Success 200 {file} application/pdf
+// @Header 200 {string} X-Render-Warnings "JSON array of dto.RenderWarningResponse, when there are any"
+// @Header 200 {integer} X-Render-Warning-Count "Number of render warnings, when there are any"
+// @Header 200 {integer} X-Render-Degradation-Count "Number of DEGRADED_* warnings of a degraded render, when there are any"
+// @Header 200 {string} X-Render-Injector-Timings "JSON array of dto.InjectorTimingResponse, when registry injectors ran"
+// @Header 200 {string} X-Render-Currency-Rates "JSON array of dto.CurrencyRateResponse, when amounts were converted"
+// @Header 200 {string} X-Render-Document-Number "Number drawn from the numbering sequence of the document type, percent-encoded when not ASCII"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "The job has not succeeded"
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/render/jobs/{jobId}/result [get]
+// @Security BearerAuth
+func (c *RenderController) GetRenderJobResult(ctx *gin.Context) {
+	tenantCode, workspaceCode, ok := parseRenderJobOwner(ctx)
+	if !ok {
+		return
+	}
+
+	job, err := c.renderJobUC.GetRenderJobResult(ctx.Request.Context(), tenantCode, workspaceCode, ctx.Param("jobId"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	result := &port.RenderPreviewResult{PDF: job.PDF, Warnings: job.Warnings}
+	if job.Filename != nil {
+		result.Filename = *job.Filename
+	}
+	if job.PageCount != nil {
+		result.PageCount = *job.PageCount
+	}
+	sendPDFResponse(ctx, result)
+}
+
+// parseRenderJobOwner reads the tenant and workspace headers a render job is checked against.
+func parseRenderJobOwner(ctx *gin.Context) (tenantCode, workspaceCode string, ok bool) {
+	tenantCode = strings.ToUpper(strings.TrimSpace(ctx.GetHeader("X-Tenant-Code")))
+	if tenantCode == "" {
+		respondError(ctx, http.StatusBadRequest, fmt.Errorf("X-Tenant-Code header is required"))
+		return "", "", false
+	}
+	workspaceCode = strings.ToUpper(strings.TrimSpace(ctx.GetHeader("X-Workspace-Code")))
+	if workspaceCode == "" {
+		respondError(ctx, http.StatusBadRequest, fmt.Errorf("X-Workspace-Code header is required"))
+		return "", "", false
+	}
+	return tenantCode, workspaceCode, true
+}
+
+// workspaceRenderTarget holds the headers and body shared by the workspace render routes.
+type workspaceRenderTarget struct {
+	tenantCode    string
+	workspaceCode string
+	env           entity.Environment
+	req           dto.RenderRequest
+}
+
+// parseWorkspaceRenderTarget reads the tenant, workspace and environment headers and the optional
+// render body. It writes the error response and returns false when they are invalid.
+func parseWorkspaceRenderTarget(ctx *gin.Context) (*workspaceRenderTarget, bool) {
+	target, ok := parseWorkspaceRenderHeaders(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	if err := ctx.ShouldBindJSON(&target.req); err != nil {
+		if err.Error() != "EOF" {
+			respondBindError(ctx, err)
+			return nil, false
+		}
+		target.req.Injectables = make(map[string]any)
+	}
+	return target, true
+}
+
+// parseWorkspaceRenderHeaders reads the tenant, workspace and environment headers. It writes the
+// error response and returns false when they are invalid.
+func parseWorkspaceRenderHeaders(ctx *gin.Context) (*workspaceRenderTarget, bool) {
+	target := &workspaceRenderTarget{
+		tenantCode:    strings.ToUpper(strings.TrimSpace(ctx.GetHeader("X-Tenant-Code"))),
+		workspaceCode: strings.ToUpper(strings.TrimSpace(ctx.GetHeader("X-Workspace-Code"))),
+	}
+	if target.tenantCode == "" {
+		respondError(ctx, http.StatusBadRequest, fmt.Errorf("X-Tenant-Code header is required"))
+		return nil, false
+	}
+	if target.workspaceCode == "" {
+		respondError(ctx, http.StatusBadRequest, fmt.Errorf("X-Workspace-Code header is required"))
+		return nil, false
+	}
+
+	env, err := parseRenderEnvironment(ctx.GetHeader("X-Environment"))
+	if err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return nil, false
+	}
+	target.env = env
+	return target, true
+}
+
+// sendHostedLink keeps the rendered PDF and responds with its viewer link instead of the bytes.
+func (c *RenderController) sendHostedLink(
+	ctx *gin.Context,
+	tenantCode, workspaceCode string,
+	opts *dto.HostRenderOptions,
+	result *port.RenderPreviewResult,
+) {
+	cmd := mapper.HostRenderOptionsToCommand(tenantCode, workspaceCode, opts, result)
+	link, err := c.hostedDocumentUC.HostRender(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	resp := mapper.HostedDocumentLinkToResponse(link)
+	resp.Warnings = mapper.RenderWarningsToResponse(result.Warnings)
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+// sendPersistedRender writes the rendered PDF to object storage and responds with a signed URL instead of the bytes.
+func (c *RenderController) sendPersistedRender(ctx *gin.Context, tenantCode, workspaceCode string, result *port.RenderPreviewResult) {
+	persisted, err := c.persistedRenderUC.PersistRender(ctx.Request.Context(), templateuc.PersistRenderCommand{
+		TenantCode:    tenantCode,
+		WorkspaceCode: workspaceCode,
+		Result:        result,
+	})
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, mapper.PersistedRenderToResponse(persisted, result.Warnings))
+}
+
+func extractHeaders(ctx *gin.Context) map[string]string {
+	headers := make(map[string]string, len(ctx.Request.Header))
+	for k, v := range ctx.Request.Header {
+		if len(v) > 0 {
+			headers[k] = v[0]
+		}
+	}
+	return headers
+}
+
+// parseRenderEnvironment validates the X-Environment header and returns the Environment enum.
+func parseRenderEnvironment(header string) (entity.Environment, error) {
+	v := strings.TrimSpace(header)
+	if v == "" {
+		return "", fmt.Errorf("X-Environment header is required. Valid values: dev, prod")
+	}
+	switch strings.ToLower(v) {
+	case "dev":
+		return entity.EnvironmentDev, nil
+	case "prod":
+		return entity.EnvironmentProd, nil
+	default:
+		return "", fmt.Errorf("invalid X-Environment value %q. Valid values: dev, prod", v)
+	}
+}
+
+func sendPDFResponse(ctx *gin.Context, result *port.RenderPreviewResult) {
+	disposition := ctx.DefaultQuery("disposition", "inline")
+	if disposition != "attachment" {
+		disposition = "inline"
 	}
 
 	ctx.Header("Content-Type", "application/pdf")
 	ctx.Header("Content-Disposition", fmt.Sprintf("%s; filename=\"%s\"", disposition, result.Filename))
 	ctx.Header("Content-Length", fmt.Sprintf("%d", len(result.PDF)))
+	setRenderWarningHeaders(ctx, result.Warnings)
+	setInjectorTimingsHeader(ctx, result.InjectorTimings)
+	setCurrencyRatesHeader(ctx, result)
+	setDocumentNumberHeader(ctx, result.DocumentNumber)
 	ctx.Data(http.StatusOK, "application/pdf", result.PDF)
 }
+
+// renderFormatDocx is the format query value of Word output. It is not a node visibility: DOCX
+// output includes the nodes restricted to pdf.
+const renderFormatDocx = "docx"
+
+// parseRenderFormat returns the output format of the format query parameter: pdf (the default),
+// html or docx.
+func parseRenderFormat(ctx *gin.Context) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(ctx.Query("format"))) {
+	case "", portabledoc.OutputPDF:
+		return portabledoc.OutputPDF, nil
+	case portabledoc.OutputHTML:
+		return portabledoc.OutputHTML, nil
+	case renderFormatDocx:
+		return renderFormatDocx, nil
+	default:
+		return "", entity.ErrUnsupportedRenderFormat
+	}
+}
+
+// parseRenderRequestFormat returns the output format of a workspace render, rejecting the options
+// HTML and DOCX output do not support. It writes the error response and returns false on failure.
+func parseRenderRequestFormat(ctx *gin.Context, req *dto.RenderRequest) (string, bool) {
+	format, err := parseRenderFormat(ctx)
+	if err != nil {
+		HandleError(ctx, err)
+		return "", false
+	}
+	if req.Host == nil && !req.Persist && req.Imposition == nil {
+		return format, true
+	}
+	switch format {
+	case portabledoc.OutputHTML:
+		HandleError(ctx, entity.ErrHTMLRenderOption)
+		return "", false
+	case renderFormatDocx:
+		HandleError(ctx, entity.ErrDocxRenderOption)
+		return "", false
+	}
+	return format, true
+}
+
+// htmlContentSecurityPolicy lets an HTML render load its styles and images and nothing else, so no
+// script runs in the page even if one reached it through a document value.
+const htmlContentSecurityPolicy = "default-src 'none'; img-src https: http: data:; style-src 'unsafe-inline'; font-src https: data:; base-uri 'none'; form-action 'none'"
+
+// sendHTMLResponse writes an HTML render, inline unless disposition=attachment is requested.
+func sendHTMLResponse(ctx *gin.Context, result *port.HTMLRenderResult) {
+	disposition := ctx.DefaultQuery("disposition", "inline")
+	if disposition != "attachment" {
+		disposition = "inline"
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("%s; filename=\"%s\"", disposition, result.Filename))
+	ctx.Header("Content-Security-Policy", htmlContentSecurityPolicy)
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	setRenderWarningHeaders(ctx, result.Warnings)
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", result.HTML)
+}
+
+// sendDocxResponse writes a rendered Word document. It is always an attachment: browsers cannot
+// display DOCX inline.
+func sendDocxResponse(ctx *gin.Context, result *port.DocxRenderResult) {
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", result.Filename))
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	setRenderWarningHeaders(ctx, result.Warnings)
+	ctx.Data(http.StatusOK, docxContentType, result.Docx)
+}
+
+// docxContentType is the media type of Word documents.
+const docxContentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+
+// handleRenderError writes the error response of a failed render. Compiles whose input was
+// captured for support also get its ID in X-Render-Failure-ID.
+func handleRenderError(ctx *gin.Context, err error) {
+	var compileErr *entity.CompileError
+	if errors.As(err, &compileErr) && compileErr.FailureID != "" {
+		ctx.Header("X-Render-Failure-ID", compileErr.FailureID)
+	}
+	HandleError(ctx, err)
+}
+
+// maxRenderWarningsHeader bounds the X-Render-Warnings header, well under the header limits of
+// common proxies.
+const maxRenderWarningsHeader = 4096
+
+// setRenderWarningHeaders sets X-Render-Warning-Count and X-Render-Warnings, a JSON array of the
+// warnings of a render. Warnings that do not fit the header size are left out of the array but
+// still counted.
+func setRenderWarningHeaders(ctx *gin.Context, warnings []entity.RenderWarning) {
+	if len(warnings) == 0 {
+		return
+	}
+	ctx.Header("X-Render-Warning-Count", strconv.Itoa(len(warnings)))
+	if n := entity.CountDegradations(warnings); n > 0 {
+		ctx.Header("X-Render-Degradation-Count", strconv.Itoa(n))
+	}
+
+	fitting := mapper.RenderWarningsToResponse(warnings)
+	for ; len(fitting) > 0; fitting = fitting[:len(fitting)-1] {
+		value := asciiJSON(fitting)
+		if len(value) <= maxRenderWarningsHeader {
+			ctx.Header("X-Render-Warnings", value)
+			return
+		}
+	}
+}
+
+// setInjectorTimingsHeader sets X-Render-Injector-Timings, a JSON array of the injector timings of
+// a render. Timings that do not fit the header size are left out, slowest kept first.
+func setInjectorTimingsHeader(ctx *gin.Context, timings []entity.InjectorTiming) {
+	fitting := mapper.InjectorTimingsToResponse(timings)
+	if len(fitting) == 0 {
+		return
+	}
+	value := asciiJSON(fitting)
+	if len(value) <= maxRenderWarningsHeader {
+		ctx.Header("X-Render-Injector-Timings", value)
+		return
+	}
+
+	slices.SortStableFunc(fitting, func(a, b dto.InjectorTimingResponse) int {
+		return cmp.Compare(b.DurationMs, a.DurationMs)
+	})
+	for fitting = fitting[:len(fitting)-1]; len(fitting) > 0; fitting = fitting[:len(fitting)-1] {
+		value := asciiJSON(fitting)
+		if len(value) <= maxRenderWarningsHeader {
+			ctx.Header("X-Render-Injector-Timings", value)
+			return
+		}
+	}
+}
+
+// setCurrencyRatesHeader sets X-Render-Currency-Rates, a JSON array of the exchange rates a render
+// converted amounts with, and X-Render-Currency-Record-ID, the ID of their stored record. The rates
+// are left out when they do not fit the header size.
+func setCurrencyRatesHeader(ctx *gin.Context, result *port.RenderPreviewResult) {
+	if result.CurrencyRecordID != "" {
+		ctx.Header("X-Render-Currency-Record-ID", result.CurrencyRecordID)
+	}
+	if len(result.CurrencyRates) == 0 {
+		return
+	}
+	if value := asciiJSON(mapper.CurrencyRatesToResponse(result.CurrencyRates)); len(value) <= maxRenderWarningsHeader {
+		ctx.Header("X-Render-Currency-Rates", value)
+	}
+}
+
+// setDocumentNumberHeader sets X-Render-Document-Number, the number a render drew from the
+// numbering sequence of its document type. A number with non-ASCII characters, e.g. in its
+// prefix, is percent-encoded.
+func setDocumentNumberHeader(ctx *gin.Context, number string) {
+	if number == "" {
+		return
+	}
+	for _, r := range number {
+		if r < 0x20 || r > 0x7e {
+			number = url.PathEscape(number)
+			break
+		}
+	}
+	ctx.Header("X-Render-Document-Number", number)
+}
+
+// asciiJSON encodes v as JSON with non-ASCII characters escaped, so it is a valid header value.
+func asciiJSON(v any) string {
+	data, _ := json.Marshal(v)
+	var b strings.Builder
+	for _, r := range string(data) {
+		if r < utf8.RuneSelf {
+			b.WriteRune(r)
+			continue
+		}
+		for _, unit := range utf16.Encode([]rune{r}) {
+			fmt.Fprintf(&b, "\\u%04x", unit)
+		}
+	}
+	return b.String()
+}
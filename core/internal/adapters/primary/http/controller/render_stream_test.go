@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/payload"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	templatesvc "github.com/rendis/pdf-forge/core/internal/core/service/template"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+func TestRenderController_RenderByDocumentTypeStreamsPayloadToMapper(t *testing.T) {
+	renders := &fakeInternalRender{}
+	requestMapper := &fakeRequestMapper{}
+	router := newRenderTestRouter(&RenderController{documentTypeRenderUC: renders, requestMapper: requestMapper})
+
+	body := `{"documentId":"INV-1","injectables":{"customerName":"Acme","lines":[{"qty":2}]},"unitSystem":"metric"}`
+	rec := serveRender(router, strings.NewReader(body))
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.NotNil(t, requestMapper.mapCtx)
+	assert.Nil(t, requestMapper.mapCtx.RawBody, "the payload is streamed, not buffered")
+	assert.Equal(t, templatesvc.RenderOperation, requestMapper.mapCtx.Operation)
+	assert.Equal(t, entity.EnvironmentProd, requestMapper.mapCtx.Environment)
+
+	cmd := renders.cmd
+	assert.Equal(t, "INVOICE", cmd.TemplateTypeCode)
+	assert.Equal(t, "INV-1", cmd.DocumentID)
+	assert.Equal(t, "metric", cmd.UnitSystem)
+	assert.Equal(t, map[string]any{"customerName": "Acme", "lines": []any{map[string]any{"qty": json.Number("2")}}}, cmd.Payload)
+	assert.Equal(t, cmd.Payload, cmd.Injectables, "a mapped object supplies the injectable values")
+}
+
+func TestRenderController_RenderByDocumentTypeStopsOnMapperError(t *testing.T) {
+	renders := &fakeInternalRender{}
+	router := newRenderTestRouter(&RenderController{documentTypeRenderUC: renders, requestMapper: &fakeRequestMapper{}})
+
+	tail := &countingReader{r: strings.NewReader(`,"notes":"` + strings.Repeat("x", 1<<20) + `"}`)}
+	body := io.MultiReader(strings.NewReader(`{"injectables":{"lines":[]}`), tail)
+	rec := serveRender(router, body)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "customerName")
+	assert.Nil(t, renders.cmd.Payload)
+	assert.Less(t, tail.n, 1<<20, "the rest of the body is not read once the mapper fails")
+}
+
+func TestRenderController_RenderByDocumentTypeMapsEmptyBody(t *testing.T) {
+	renders := &fakeInternalRender{}
+	requestMapper := &fakeRequestMapper{optional: true}
+	router := newRenderTestRouter(&RenderController{documentTypeRenderUC: renders, requestMapper: requestMapper})
+
+	rec := serveRender(router, http.NoBody)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, []byte("{}"), requestMapper.mapCtx.RawBody)
+	assert.Equal(t, map[string]any{}, renders.cmd.Payload)
+}
+
+func TestRenderController_RenderByDocumentTypeWithoutMapper(t *testing.T) {
+	renders := &fakeInternalRender{}
+	router := newRenderTestRouter(&RenderController{documentTypeRenderUC: renders})
+
+	rec := serveRender(router, strings.NewReader(`{"injectables":{"customerName":"Acme"}}`))
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, map[string]any{"customerName": "Acme"}, renders.cmd.Payload)
+}
+
+func newRenderTestRouter(c *RenderController) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/workspace/document-types/:code/render", c.RenderByDocumentType)
+	return router
+}
+
+func serveRender(router *gin.Engine, body io.Reader) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/workspace/document-types/invoice/render", body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Tenant-Code", "acme")
+	req.Header.Set("X-Workspace-Code", "billing")
+	req.Header.Set("X-Environment", "prod")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+type fakeInternalRender struct {
+	templateuc.InternalRenderUseCase
+	cmd templateuc.InternalRenderCommand
+}
+
+func (f *fakeInternalRender) RenderByDocumentType(_ context.Context, cmd templateuc.InternalRenderCommand) (*port.RenderPreviewResult, error) {
+	f.cmd = cmd
+	return &port.RenderPreviewResult{PDF: []byte("%PDF-1.7"), Filename: "invoice.pdf", PageCount: 1}, nil
+}
+
+// fakeRequestMapper reads the payload like a business mapper: field by field, requiring the customer.
+type fakeRequestMapper struct {
+	optional bool
+	mapCtx   *port.MapperContext
+}
+
+func (f *fakeRequestMapper) Map(_ context.Context, mapCtx *port.MapperContext) (any, error) {
+	f.mapCtx = mapCtx
+	opts := payload.Options{Required: []string{"customerName"}}
+	if f.optional {
+		opts.Required = nil
+	}
+	d := mapCtx.Decoder(opts)
+	values := make(map[string]any)
+	err := d.Fields(opts.Required, func(field string) error {
+		var v any
+		if err := d.Decode(&v); err != nil {
+			return err
+		}
+		values[field] = v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -14,6 +15,7 @@ import (
 // TemplateVersionController handles template version HTTP requests.
 type TemplateVersionController struct {
 	versionUC        templateuc.TemplateVersionUseCase
+	conversionUC     templateuc.TemplateConversionUseCase
 	versionMapper    *mapper.TemplateVersionMapper
 	templateMapper   *mapper.TemplateMapper
 	renderController *RenderController
@@ -22,12 +24,14 @@ type TemplateVersionController struct {
 // NewTemplateVersionController creates a new template version controller.
 func NewTemplateVersionController(
 	versionUC templateuc.TemplateVersionUseCase,
+	conversionUC templateuc.TemplateConversionUseCase,
 	versionMapper *mapper.TemplateVersionMapper,
 	templateMapper *mapper.TemplateMapper,
 	renderController *RenderController,
 ) *TemplateVersionController {
 	return &TemplateVersionController{
 		versionUC:        versionUC,
+		conversionUC:     conversionUC,
 		versionMapper:    versionMapper,
 		templateMapper:   templateMapper,
 		renderController: renderController,
@@ -44,9 +48,22 @@ func (c *TemplateVersionController) RegisterRoutes(templates *gin.RouterGroup) {
 		versions.POST("", middleware.RequireEditor(), c.CreateVersion)                           // EDITOR+
 		versions.POST("/from-existing", middleware.RequireEditor(), c.CreateVersionFromExisting) // EDITOR+
 		versions.GET("/:versionId", c.GetVersion)                                                // VIEWER+
+		versions.GET("/:versionId/changelog", c.GetChangelog)                                    // VIEWER+
+		versions.GET("/:versionId/diff/:otherVersionId", c.DiffVersions)                         // VIEWER+
 		versions.PUT("/:versionId", middleware.RequireEditor(), c.UpdateVersion)                 // EDITOR+
 		versions.DELETE("/:versionId", middleware.RequireAdmin(), c.DeleteVersion)               // ADMIN+
 
+		// Release notes - VIEWER+ read, EDITOR+ write (until published)
+		versions.GET("/:versionId/release-notes", c.GetReleaseNotes)
+		versions.PUT("/:versionId/release-notes", middleware.RequireEditor(), c.UpdateReleaseNotes)
+		versions.DELETE("/:versionId/release-notes", middleware.RequireEditor(), c.DeleteReleaseNotes)
+
+		// Import - EDITOR+, export - VIEWER+
+		versions.POST("/:versionId/import/html", middleware.RequireEditor(), c.ImportHTML)
+		versions.POST("/:versionId/import/markdown", middleware.RequireEditor(), c.ImportMarkdown)
+		versions.POST("/:versionId/import/docx", middleware.RequireEditor(), c.ImportDocx)
+		versions.GET("/:versionId/export/markdown", c.ExportMarkdown)
+
 		// Lifecycle actions - ADMIN+
 		versions.POST("/:versionId/stage", middleware.RequireAdmin(), c.StageVersion)
 		versions.POST("/:versionId/unstage", middleware.RequireAdmin(), c.UnstageVersion)
@@ -182,6 +199,138 @@ func (c *TemplateVersionController) GetVersion(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, c.versionMapper.ToDetailResponse(details))
 }
 
+// GetChangelog gets the changelog computed when a version was published.
+// @Summary Get version changelog
+// @Description Sections, injectables and page settings changed since the version published before it
+// @Tags Template Versions
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param templateId path string true "Template ID"
+// @Param versionId path string true "Version ID"
+// @Success 200 {object} dto.VersionChangelogResponse
+// @Failure 404 {object} dto.ErrorResponse "Version not found or never published"
+// @Router /api/v1/content/templates/{templateId}/versions/{versionId}/changelog [get]
+func (c *TemplateVersionController) GetChangelog(ctx *gin.Context) {
+	versionID := ctx.Param("versionId")
+
+	changelog, err := c.versionUC.GetChangelog(ctx.Request.Context(), versionID)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.versionMapper.ToChangelogResponse(changelog))
+}
+
+// DiffVersions computes the structural difference between two versions of a template.
+// @Summary Diff template versions
+// @Description Content blocks added, removed or changed, injectables added or removed, and page settings changed from versionId to otherVersionId
+// @Tags Template Versions
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param templateId path string true "Template ID"
+// @Param versionId path string true "Base version ID"
+// @Param otherVersionId path string true "Target version ID"
+// @Success 200 {object} dto.VersionDiffResponse
+// @Failure 404 {object} dto.ErrorResponse "Version not found in the template"
+// @Router /api/v1/content/templates/{templateId}/versions/{versionId}/diff/{otherVersionId} [get]
+func (c *TemplateVersionController) DiffVersions(ctx *gin.Context) {
+	diff, err := c.versionUC.DiffVersions(
+		ctx.Request.Context(),
+		ctx.Param("templateId"),
+		ctx.Param("versionId"),
+		ctx.Param("otherVersionId"),
+	)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.versionMapper.ToDiffResponse(diff))
+}
+
+// GetReleaseNotes gets the release notes of a version.
+// @Summary Get version release notes
+// @Tags Template Versions
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param templateId path string true "Template ID"
+// @Param versionId path string true "Version ID"
+// @Success 200 {object} dto.VersionReleaseNotesResponse
+// @Failure 404 {object} dto.ErrorResponse "Version not found or has no release notes"
+// @Router /api/v1/content/templates/{templateId}/versions/{versionId}/release-notes [get]
+func (c *TemplateVersionController) GetReleaseNotes(ctx *gin.Context) {
+	versionID := ctx.Param("versionId")
+
+	notes, err := c.versionUC.GetReleaseNotes(ctx.Request.Context(), versionID)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.versionMapper.ToReleaseNotesResponse(notes))
+}
+
+// UpdateReleaseNotes creates or replaces the release notes of a version.
+// @Summary Update version release notes
+// @Description Release notes can be edited until the version is published; the caller becomes their author.
+// @Tags Template Versions
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param templateId path string true "Template ID"
+// @Param versionId path string true "Version ID"
+// @Param request body dto.UpdateReleaseNotesRequest true "Release notes"
+// @Success 200 {object} dto.VersionReleaseNotesResponse
+// @Failure 400 {object} dto.ErrorResponse "Invalid notes or version already published"
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/content/templates/{templateId}/versions/{versionId}/release-notes [put]
+func (c *TemplateVersionController) UpdateReleaseNotes(ctx *gin.Context) {
+	versionID := ctx.Param("versionId")
+	userID, _ := middleware.GetInternalUserID(ctx)
+
+	var req dto.UpdateReleaseNotesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	cmd := c.versionMapper.ToUpdateReleaseNotesCommand(versionID, &req, userID)
+	notes, err := c.versionUC.UpdateReleaseNotes(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.versionMapper.ToReleaseNotesResponse(notes))
+}
+
+// DeleteReleaseNotes removes the release notes of a version that was never published.
+// @Summary Delete version release notes
+// @Tags Template Versions
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param templateId path string true "Template ID"
+// @Param versionId path string true "Version ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} dto.ErrorResponse "Version already published"
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/content/templates/{templateId}/versions/{versionId}/release-notes [delete]
+func (c *TemplateVersionController) DeleteReleaseNotes(ctx *gin.Context) {
+	versionID := ctx.Param("versionId")
+
+	if err := c.versionUC.DeleteReleaseNotes(ctx.Request.Context(), versionID); err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
 // UpdateVersion updates a version.
 // @Summary Update template version
 // @Tags Template Versions
@@ -190,10 +339,13 @@ func (c *TemplateVersionController) GetVersion(ctx *gin.Context) {
 // @Param X-Workspace-ID header string true "Workspace ID"
 // @Param templateId path string true "Template ID"
 // @Param versionId path string true "Version ID"
+// @Param If-Match header string false "Revision the edit is based on, when the body has none"
 // @Param request body dto.UpdateVersionRequest true "Version data"
 // @Success 200 {object} dto.TemplateVersionResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.RevisionConflictResponse "Stale revision; current holds the version with its content"
+// @Failure 428 {object} dto.ErrorResponse "Neither revision nor If-Match given"
 // @Router /api/v1/content/templates/{templateId}/versions/{versionId} [put]
 func (c *TemplateVersionController) UpdateVersion(ctx *gin.Context) {
 	versionID := ctx.Param("versionId")
@@ -203,10 +355,16 @@ func (c *TemplateVersionController) UpdateVersion(ctx *gin.Context) {
 		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
+	if !requireRevision(ctx, &req.Revision) {
+		return
+	}
 
 	cmd := c.versionMapper.ToUpdateCommand(versionID, &req)
 	version, err := c.versionUC.UpdateVersion(ctx.Request.Context(), cmd)
 	if err != nil {
+		if respondRevisionConflict(ctx, err, c.versionMapper.ToContentResponse) {
+			return
+		}
 		HandleError(ctx, err)
 		return
 	}
@@ -214,6 +372,145 @@ func (c *TemplateVersionController) UpdateVersion(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, c.versionMapper.ToResponse(version))
 }
 
+// ImportHTML replaces a draft's content with a converted legacy HTML template.
+// @Summary Import HTML template into a draft version
+// @Description Converts an HTML/Handlebars template into the version's content. {{placeholders}} are bound to workspace injectables by key; the report lists what needs review.
+// @Tags Template Versions
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param templateId path string true "Template ID"
+// @Param versionId path string true "Version ID"
+// @Param request body dto.ImportHTMLRequest true "HTML template"
+// @Success 200 {object} dto.ImportTemplateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.RevisionConflictResponse "Stale revision; current holds the version with its content"
+// @Router /api/v1/content/templates/{templateId}/versions/{versionId}/import/html [post]
+func (c *TemplateVersionController) ImportHTML(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	versionID := ctx.Param("versionId")
+
+	var req dto.ImportHTMLRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	cmd := c.versionMapper.ToImportHTMLCommand(workspaceID, versionID, &req)
+	result, err := c.conversionUC.ImportHTML(ctx.Request.Context(), cmd)
+	if err != nil {
+		if respondRevisionConflict(ctx, err, c.versionMapper.ToContentResponse) {
+			return
+		}
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.versionMapper.ToImportTemplateResponse(result))
+}
+
+// ImportMarkdown replaces a draft's content with a converted Markdown template.
+// @Summary Import Markdown into a draft version
+// @Description Converts Markdown into the version's content. The YAML front-matter sets the title, language, page config, header and footer; {{placeholders}} and injector fences are bound to workspace injectables by key.
+// @Tags Template Versions
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param templateId path string true "Template ID"
+// @Param versionId path string true "Version ID"
+// @Param request body dto.ImportMarkdownRequest true "Markdown template"
+// @Success 200 {object} dto.ImportTemplateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.RevisionConflictResponse "Stale revision; current holds the version with its content"
+// @Router /api/v1/content/templates/{templateId}/versions/{versionId}/import/markdown [post]
+func (c *TemplateVersionController) ImportMarkdown(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	versionID := ctx.Param("versionId")
+
+	var req dto.ImportMarkdownRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	cmd := c.versionMapper.ToImportMarkdownCommand(workspaceID, versionID, &req)
+	result, err := c.conversionUC.ImportMarkdown(ctx.Request.Context(), cmd)
+	if err != nil {
+		if respondRevisionConflict(ctx, err, c.versionMapper.ToContentResponse) {
+			return
+		}
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.versionMapper.ToImportTemplateResponse(result))
+}
+
+// ImportDocx replaces a draft's content with a converted Word document.
+// @Summary Import a Word document into a draft version
+// @Description Converts a base64-encoded .docx file into the version's content. {{placeholders}} and mail merge fields are bound to workspace injectables by key, or by the request's mapping. With dryRun the draft is left unchanged and only the report is returned, so unmapped placeholders can be mapped before importing.
+// @Tags Template Versions
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param templateId path string true "Template ID"
+// @Param versionId path string true "Version ID"
+// @Param request body dto.ImportDocxRequest true "Word document and placeholder mapping"
+// @Success 200 {object} dto.ImportTemplateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.RevisionConflictResponse "Stale revision; current holds the version with its content"
+// @Router /api/v1/content/templates/{templateId}/versions/{versionId}/import/docx [post]
+func (c *TemplateVersionController) ImportDocx(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	versionID := ctx.Param("versionId")
+
+	var req dto.ImportDocxRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	cmd := c.versionMapper.ToImportDocxCommand(workspaceID, versionID, &req)
+	result, err := c.conversionUC.ImportDocx(ctx.Request.Context(), cmd)
+	if err != nil {
+		if respondRevisionConflict(ctx, err, c.versionMapper.ToContentResponse) {
+			return
+		}
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.versionMapper.ToImportTemplateResponse(result))
+}
+
+// ExportMarkdown downloads a version's content as Markdown.
+// @Summary Export template version as Markdown
+// @Description Content Markdown cannot express is kept in pdf-forge fences, so importing the file restores it.
+// @Tags Template Versions
+// @Produce text/markdown
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param templateId path string true "Template ID"
+// @Param versionId path string true "Version ID"
+// @Success 200 {string} string "Markdown file"
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/content/templates/{templateId}/versions/{versionId}/export/markdown [get]
+func (c *TemplateVersionController) ExportMarkdown(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	versionID := ctx.Param("versionId")
+
+	export, err := c.conversionUC.ExportMarkdown(ctx.Request.Context(), workspaceID, versionID)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", export.Filename))
+	ctx.Data(http.StatusOK, "text/markdown; charset=utf-8", []byte(export.Content))
+}
+
 // DeleteVersion deletes a draft version.
 // @Summary Delete template version
 // @Tags Template Versions
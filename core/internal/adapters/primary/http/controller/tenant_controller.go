@@ -18,6 +18,7 @@ type TenantController struct {
 	tenantUC       organizationuc.TenantUseCase
 	workspaceUC    organizationuc.WorkspaceUseCase
 	tenantMemberUC organizationuc.TenantMemberUseCase
+	settingsUC     organizationuc.WorkspaceSettingsUseCase
 }
 
 // NewTenantController creates a new tenant controller.
@@ -25,11 +26,13 @@ func NewTenantController(
 	tenantUC organizationuc.TenantUseCase,
 	workspaceUC organizationuc.WorkspaceUseCase,
 	tenantMemberUC organizationuc.TenantMemberUseCase,
+	settingsUC organizationuc.WorkspaceSettingsUseCase,
 ) *TenantController {
 	return &TenantController{
 		tenantUC:       tenantUC,
 		workspaceUC:    workspaceUC,
 		tenantMemberUC: tenantMemberUC,
+		settingsUC:     settingsUC,
 	}
 }
 
@@ -43,6 +46,10 @@ func (c *TenantController) RegisterRoutes(rg *gin.RouterGroup, middlewareProvide
 		tenant.GET("", middleware.AuthorizeTenantRole(entity.TenantRoleAdmin), c.GetTenant)
 		tenant.PUT("", middleware.AuthorizeTenantRole(entity.TenantRoleOwner), c.UpdateCurrentTenant)
 
+		// Settings new workspaces inherit; locked sections apply to every workspace
+		tenant.GET("/workspace-defaults", middleware.AuthorizeTenantRole(entity.TenantRoleAdmin), c.GetWorkspaceDefaults)
+		tenant.PUT("/workspace-defaults", middleware.AuthorizeTenantRole(entity.TenantRoleOwner), c.UpdateWorkspaceDefaults)
+
 		// Workspace routes within tenant
 		tenant.GET("/workspaces", middleware.AuthorizeTenantRole(entity.TenantRoleAdmin), c.ListWorkspaces)
 		tenant.POST("/workspaces", middleware.AuthorizeTenantRole(entity.TenantRoleOwner), c.CreateWorkspace)
@@ -129,6 +136,72 @@ func (c *TenantController) UpdateCurrentTenant(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, mapper.TenantToResponse(tenant))
 }
 
+// GetWorkspaceDefaults retrieves the settings new workspaces of the current tenant start with.
+// @Summary Get tenant workspace defaults
+// @Tags Tenant
+// @Accept json
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Success 200 {object} dto.TenantWorkspaceDefaultsResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/tenant/workspace-defaults [get]
+// @Security BearerAuth
+func (c *TenantController) GetWorkspaceDefaults(ctx *gin.Context) {
+	tenantID, ok := middleware.GetTenantID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(entity.ErrMissingTenantID))
+		return
+	}
+
+	defaults, err := c.settingsUC.GetTenantDefaults(ctx.Request.Context(), tenantID)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.TenantWorkspaceDefaultsToResponse(defaults))
+}
+
+// UpdateWorkspaceDefaults replaces the workspace defaults of the current tenant.
+// @Summary Update tenant workspace defaults
+// @Description New workspaces start with these settings. Locked sections take the tenant value in every
+// @Description workspace of the tenant, existing ones included, and workspaces cannot change them.
+// @Tags Tenant
+// @Accept json
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param request body dto.TenantWorkspaceDefaultsRequest true "Workspace defaults"
+// @Success 200 {object} dto.TenantWorkspaceDefaultsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/tenant/workspace-defaults [put]
+// @Security BearerAuth
+func (c *TenantController) UpdateWorkspaceDefaults(ctx *gin.Context) {
+	tenantID, ok := middleware.GetTenantID(ctx)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(entity.ErrMissingTenantID))
+		return
+	}
+	userID, _ := middleware.GetInternalUserID(ctx)
+
+	var req dto.TenantWorkspaceDefaultsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(err))
+		return
+	}
+
+	cmd := mapper.TenantWorkspaceDefaultsRequestToCommand(tenantID, userID, req)
+	defaults, err := c.settingsUC.UpdateTenantDefaults(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.TenantWorkspaceDefaultsToResponse(defaults))
+}
+
 // ListWorkspaces lists workspaces with pagination and optional search in the current tenant.
 // @Summary List workspaces with pagination and optional search
 // @Tags Tenant - Workspaces
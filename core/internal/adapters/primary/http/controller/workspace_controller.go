@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"errors"
+	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -11,7 +13,9 @@ import (
 	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	cataloguc "github.com/rendis/pdf-forge/core/internal/core/usecase/catalog"
 	injectableuc "github.com/rendis/pdf-forge/core/internal/core/usecase/injectable"
+	notificationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/notification"
 	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
 )
 
 // WorkspaceController handles workspace-related HTTP requests.
@@ -21,6 +25,11 @@ type WorkspaceController struct {
 	tagUC                 cataloguc.TagUseCase
 	memberUC              organizationuc.WorkspaceMemberUseCase
 	workspaceInjectableUC injectableuc.WorkspaceInjectableUseCase
+	invitationUC          organizationuc.WorkspaceInvitationUseCase
+	webhookUC             notificationuc.NotificationWebhookUseCase
+	versionUC             templateuc.TemplateVersionUseCase
+	sandboxUC             organizationuc.WorkspaceSandboxUseCase
+	settingsUC            organizationuc.WorkspaceSettingsUseCase
 	injectableMapper      *mapper.InjectableMapper
 }
 
@@ -31,6 +40,11 @@ func NewWorkspaceController(
 	tagUC cataloguc.TagUseCase,
 	memberUC organizationuc.WorkspaceMemberUseCase,
 	workspaceInjectableUC injectableuc.WorkspaceInjectableUseCase,
+	invitationUC organizationuc.WorkspaceInvitationUseCase,
+	webhookUC notificationuc.NotificationWebhookUseCase,
+	versionUC templateuc.TemplateVersionUseCase,
+	sandboxUC organizationuc.WorkspaceSandboxUseCase,
+	settingsUC organizationuc.WorkspaceSettingsUseCase,
 	injectableMapper *mapper.InjectableMapper,
 ) *WorkspaceController {
 	return &WorkspaceController{
@@ -39,6 +53,11 @@ func NewWorkspaceController(
 		tagUC:                 tagUC,
 		memberUC:              memberUC,
 		workspaceInjectableUC: workspaceInjectableUC,
+		invitationUC:          invitationUC,
+		webhookUC:             webhookUC,
+		versionUC:             versionUC,
+		sandboxUC:             sandboxUC,
+		settingsUC:            settingsUC,
 		injectableMapper:      injectableMapper,
 	}
 }
@@ -52,16 +71,31 @@ func (c *WorkspaceController) RegisterRoutes(rg *gin.RouterGroup, middlewareProv
 	workspace.Use(middlewareProvider.WorkspaceContext())
 	{
 		// Current workspace operations
-		workspace.GET("", c.GetWorkspace)                                   // VIEWER+
-		workspace.PUT("", middleware.RequireAdmin(), c.UpdateWorkspace)     // ADMIN+
-		workspace.DELETE("", middleware.RequireOwner(), c.ArchiveWorkspace) // OWNER only
+		workspace.GET("", c.GetWorkspace)                                       // VIEWER+
+		workspace.PUT("", middleware.RequireAdmin(), c.UpdateWorkspace)         // ADMIN+
+		workspace.DELETE("", middleware.RequireOwner(), c.ArchiveWorkspace)     // OWNER only
+		workspace.POST("/sandbox", middleware.RequireAdmin(), c.CreateSandbox)  // ADMIN+
+		workspace.GET("/settings", c.GetSettings)                               // VIEWER+
+		workspace.PUT("/settings", middleware.RequireAdmin(), c.UpdateSettings) // ADMIN+
 
 		// Member routes
-		workspace.GET("/members", c.ListMembers)                                           // VIEWER+
-		workspace.POST("/members", middleware.RequireAdmin(), c.InviteMember)              // ADMIN+
-		workspace.GET("/members/:memberId", c.GetMember)                                   // VIEWER+
-		workspace.PUT("/members/:memberId", middleware.RequireOwner(), c.UpdateMemberRole) // OWNER only
-		workspace.DELETE("/members/:memberId", middleware.RequireAdmin(), c.RemoveMember)  // ADMIN+
+		workspace.GET("/members", c.ListMembers)                                                       // VIEWER+
+		workspace.POST("/members", middleware.RequireAdmin(), c.InviteMember)                          // ADMIN+
+		workspace.GET("/members/:memberId", c.GetMember)                                               // VIEWER+
+		workspace.PUT("/members/:memberId", middleware.RequireOwner(), c.UpdateMemberRole)             // OWNER only
+		workspace.DELETE("/members/:memberId", middleware.RequireAdmin(), c.RemoveMember)              // ADMIN+
+		workspace.POST("/members/:memberId/deactivate", middleware.RequireAdmin(), c.DeactivateMember) // ADMIN+
+		workspace.POST("/members/:memberId/reactivate", middleware.RequireAdmin(), c.ReactivateMember) // ADMIN+
+		workspace.POST("/ownership-transfer", middleware.RequireOwner(), c.TransferOwnership)          // OWNER only
+
+		// Invitation routes
+		invitations := workspace.Group("/invitations", middleware.RequireAdmin())
+		{
+			invitations.GET("", c.ListInvitations)                        // ADMIN+
+			invitations.POST("", c.CreateInvitation)                      // ADMIN+
+			invitations.POST("/:invitationId/resend", c.ResendInvitation) // ADMIN+
+			invitations.DELETE("/:invitationId", c.RevokeInvitation)      // ADMIN+
+		}
 
 		// Folder routes
 		folders := workspace.Group("/folders")
@@ -90,6 +124,21 @@ func (c *WorkspaceController) RegisterRoutes(rg *gin.RouterGroup, middlewareProv
 		workspace.DELETE("/injectables/:injectableId", middleware.RequireAdmin(), c.DeleteWorkspaceInjectable)      // ADMIN+
 		workspace.POST("/injectables/:injectableId/activate", middleware.RequireEditor(), c.ActivateInjectable)     // EDITOR+
 		workspace.POST("/injectables/:injectableId/deactivate", middleware.RequireEditor(), c.DeactivateInjectable) // EDITOR+
+
+		// Notification webhook routes (Slack/Teams)
+		webhooks := workspace.Group("/notification-webhooks", middleware.RequireAdmin())
+		{
+			webhooks.GET("", c.ListNotificationWebhooks)                 // ADMIN+
+			webhooks.POST("", c.CreateNotificationWebhook)               // ADMIN+
+			webhooks.GET("/:webhookId", c.GetNotificationWebhook)        // ADMIN+
+			webhooks.PUT("/:webhookId", c.UpdateNotificationWebhook)     // ADMIN+
+			webhooks.DELETE("/:webhookId", c.DeleteNotificationWebhook)  // ADMIN+
+			webhooks.POST("/:webhookId/test", c.TestNotificationWebhook) // ADMIN+
+		}
+
+		// Scheduled publication/archival routes
+		workspace.GET("/schedule", c.GetSchedule)                                                          // VIEWER+
+		workspace.POST("/schedule/:versionId/retry", middleware.RequireAdmin(), c.RetryScheduledOperation) // ADMIN+
 	}
 }
 
@@ -173,6 +222,102 @@ func (c *WorkspaceController) ArchiveWorkspace(ctx *gin.Context) {
 	ctx.Status(http.StatusNoContent)
 }
 
+// CreateSandbox clones the current workspace into a temporary sandbox workspace.
+// @Summary Create sandbox workspace
+// @Description Clones folders, tags, injectables and templates (with all versions) into a new workspace
+// @Description owned by the caller. Members are not copied. The sandbox is deleted permanently once it expires.
+// @Tags Workspaces
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param request body dto.CreateSandboxRequest false "Sandbox options"
+// @Success 201 {object} dto.SandboxResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/sandbox [post]
+func (c *WorkspaceController) CreateSandbox(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	userID, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, entity.ErrUnauthorized)
+		return
+	}
+
+	// The body is optional: an empty one uses the default lifetime
+	var req dto.CreateSandboxRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	cmd := mapper.CreateSandboxRequestToCommand(workspaceID, req, userID)
+	result, err := c.sandboxUC.CreateSandbox(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, mapper.SandboxToResponse(result))
+}
+
+// GetSettings retrieves the settings the current workspace applies.
+// @Summary Get workspace settings
+// @Description Sections locked by the tenant take the tenant value and are listed in locked.
+// @Tags Workspaces
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Success 200 {object} dto.WorkspaceSettingsResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/settings [get]
+func (c *WorkspaceController) GetSettings(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	view, err := c.settingsUC.GetWorkspaceSettings(ctx.Request.Context(), workspaceID)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.WorkspaceSettingsViewToResponse(view))
+}
+
+// UpdateSettings replaces the settings of the current workspace.
+// @Summary Update workspace settings
+// @Description Sections locked by the tenant may be omitted or sent with the tenant value; any other value is rejected.
+// @Tags Workspaces
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param request body dto.WorkspaceSettingsDTO true "Workspace settings"
+// @Success 200 {object} dto.WorkspaceSettingsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "A section is locked by the tenant"
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/settings [put]
+func (c *WorkspaceController) UpdateSettings(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	userID, _ := middleware.GetInternalUserID(ctx)
+
+	var req dto.WorkspaceSettingsDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	view, err := c.settingsUC.UpdateWorkspaceSettings(ctx.Request.Context(), organizationuc.UpdateWorkspaceSettingsCommand{
+		WorkspaceID: workspaceID,
+		Settings:    mapper.WorkspaceSettingsFromDTO(req),
+		UpdatedBy:   userID,
+	})
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.WorkspaceSettingsViewToResponse(view))
+}
+
 // --- Member Handlers ---
 
 // ListMembers lists all members of the current workspace.
@@ -200,12 +345,15 @@ func (c *WorkspaceController) ListMembers(ctx *gin.Context) {
 
 // InviteMember invites a user to the current workspace.
 // @Summary Invite member
+// @Description Adds a registered user directly. An unknown email gets a pending invitation instead, as with
+// @Description POST /api/v1/workspace/invitations; no user is created until the invitee accepts.
 // @Tags Members
 // @Accept json
 // @Produce json
 // @Param X-Workspace-ID header string true "Workspace ID"
 // @Param request body dto.InviteMemberRequest true "Member invitation data"
 // @Success 201 {object} dto.MemberResponse
+// @Success 202 {object} dto.InvitationWithTokenResponse "When the email is not registered"
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 409 {object} dto.ErrorResponse
 // @Router /api/v1/workspace/members [post]
@@ -230,6 +378,20 @@ func (c *WorkspaceController) InviteMember(ctx *gin.Context) {
 
 	cmd := mapper.InviteMemberRequestToCommand(workspaceID, req, userID)
 	member, err := c.memberUC.InviteMember(ctx.Request.Context(), cmd)
+	if errors.Is(err, entity.ErrInviteeNotRegistered) {
+		invitation, token, err := c.invitationUC.CreateInvitation(ctx.Request.Context(), organizationuc.CreateInvitationCommand{
+			WorkspaceID: workspaceID,
+			Email:       cmd.Email,
+			Role:        cmd.Role,
+			InvitedBy:   userID,
+		})
+		if err != nil {
+			HandleError(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusAccepted, mapper.InvitationWithTokenToResponse(invitation, token))
+		return
+	}
 	if err != nil {
 		HandleError(ctx, err)
 		return
@@ -332,6 +494,221 @@ func (c *WorkspaceController) RemoveMember(ctx *gin.Context) {
 	ctx.Status(http.StatusNoContent)
 }
 
+// DeactivateMember blocks a member's access while keeping the membership.
+// @Summary Deactivate member
+// @Description Deactivated members keep their history but can no longer access the workspace. Owners must transfer ownership first.
+// @Tags Members
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param memberId path string true "Member ID"
+// @Success 200 {object} dto.MemberResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/members/{memberId}/deactivate [post]
+func (c *WorkspaceController) DeactivateMember(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	userID, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, entity.ErrUnauthorized)
+		return
+	}
+
+	cmd := mapper.ChangeMemberStatusToCommand(ctx.Param("memberId"), workspaceID, userID)
+	member, err := c.memberUC.DeactivateMember(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.MemberToResponse(member))
+}
+
+// ReactivateMember restores access for a deactivated member.
+// @Summary Reactivate member
+// @Tags Members
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param memberId path string true "Member ID"
+// @Success 200 {object} dto.MemberResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/members/{memberId}/reactivate [post]
+func (c *WorkspaceController) ReactivateMember(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	userID, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, entity.ErrUnauthorized)
+		return
+	}
+
+	cmd := mapper.ChangeMemberStatusToCommand(ctx.Param("memberId"), workspaceID, userID)
+	member, err := c.memberUC.ReactivateMember(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.MemberToResponse(member))
+}
+
+// TransferOwnership makes another active member the workspace owner.
+// @Summary Transfer workspace ownership
+// @Description Promotes the given member to OWNER and demotes the current owner to ADMIN in a single atomic update.
+// @Tags Members
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param request body dto.TransferOwnershipRequest true "New owner"
+// @Success 204 "No Content"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/ownership-transfer [post]
+func (c *WorkspaceController) TransferOwnership(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	userID, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, entity.ErrUnauthorized)
+		return
+	}
+
+	var req dto.TransferOwnershipRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	cmd := mapper.TransferOwnershipRequestToCommand(workspaceID, req, userID)
+	if err := c.memberUC.TransferOwnership(ctx.Request.Context(), cmd); err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// --- Invitation Handlers ---
+
+// ListInvitations lists pending invitations of the current workspace.
+// @Summary List pending invitations
+// @Description Lists invitations still waiting for an answer. Expired ones are flagged with expired=true and can be resent.
+// @Tags Invitations
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Success 200 {object} dto.ListResponse[dto.InvitationResponse]
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/invitations [get]
+func (c *WorkspaceController) ListInvitations(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	invitations, err := c.invitationUC.ListPendingInvitations(ctx.Request.Context(), workspaceID)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewListResponse(mapper.InvitationsToResponses(invitations)))
+}
+
+// CreateInvitation invites an email to the current workspace.
+// @Summary Create invitation
+// @Description Creates a pending invitation and emails a link with an expiring token.
+// @Description No user or membership is created until the invitee accepts.
+// @Description The token is returned once so the link can also be shared manually.
+// @Tags Invitations
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param request body dto.CreateInvitationRequest true "Invitation data"
+// @Success 201 {object} dto.InvitationWithTokenResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/invitations [post]
+func (c *WorkspaceController) CreateInvitation(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	userID, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, entity.ErrUnauthorized)
+		return
+	}
+
+	var req dto.CreateInvitationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	cmd := mapper.CreateInvitationRequestToCommand(workspaceID, req, userID)
+	invitation, token, err := c.invitationUC.CreateInvitation(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, mapper.InvitationWithTokenToResponse(invitation, token))
+}
+
+// ResendInvitation issues a new token for a pending invitation and emails it again.
+// @Summary Resend invitation
+// @Description Replaces the token (the previous link stops working) and restarts the expiration window.
+// @Tags Invitations
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param invitationId path string true "Invitation ID"
+// @Success 200 {object} dto.InvitationWithTokenResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/invitations/{invitationId}/resend [post]
+func (c *WorkspaceController) ResendInvitation(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	invitation, token, err := c.invitationUC.ResendInvitation(ctx.Request.Context(), workspaceID, ctx.Param("invitationId"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.InvitationWithTokenToResponse(invitation, token))
+}
+
+// RevokeInvitation cancels a pending invitation.
+// @Summary Revoke invitation
+// @Tags Invitations
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param invitationId path string true "Invitation ID"
+// @Success 204 "Invitation revoked"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/invitations/{invitationId} [delete]
+func (c *WorkspaceController) RevokeInvitation(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	if err := c.invitationUC.RevokeInvitation(ctx.Request.Context(), workspaceID, ctx.Param("invitationId")); err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
 // --- Folder Handlers ---
 
 // ListFolders lists all folders in the current workspace.
@@ -726,6 +1103,8 @@ func (c *WorkspaceController) CreateWorkspaceInjectable(ctx *gin.Context) {
 		Description:  req.Description,
 		DefaultValue: req.DefaultValue,
 		Metadata:     req.Metadata,
+		Formula:      req.Formula,
+		DataType:     entity.InjectableDataType(req.DataType),
 	}
 
 	injectable, err := c.workspaceInjectableUC.CreateInjectable(ctx.Request.Context(), cmd)
@@ -768,10 +1147,13 @@ func (c *WorkspaceController) GetWorkspaceInjectable(ctx *gin.Context) {
 // @Produce json
 // @Param X-Workspace-ID header string true "Workspace ID"
 // @Param injectableId path string true "Injectable ID"
+// @Param If-Match header string false "Revision the edit is based on, when the body has none"
 // @Param request body dto.UpdateWorkspaceInjectableRequest true "Injectable data"
 // @Success 200 {object} dto.WorkspaceInjectableResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.RevisionConflictResponse
+// @Failure 428 {object} dto.ErrorResponse "Neither revision nor If-Match given"
 // @Router /api/v1/workspace/injectables/{injectableId} [put]
 func (c *WorkspaceController) UpdateWorkspaceInjectable(ctx *gin.Context) {
 	workspaceID, _ := middleware.GetWorkspaceID(ctx)
@@ -782,19 +1164,31 @@ func (c *WorkspaceController) UpdateWorkspaceInjectable(ctx *gin.Context) {
 		respondError(ctx, http.StatusBadRequest, err)
 		return
 	}
+	if !requireRevision(ctx, &req.Revision) {
+		return
+	}
 
 	cmd := injectableuc.UpdateWorkspaceInjectableCommand{
-		ID:           injectableID,
-		WorkspaceID:  workspaceID,
-		Key:          req.Key,
-		Label:        req.Label,
-		Description:  req.Description,
-		DefaultValue: req.DefaultValue,
-		Metadata:     req.Metadata,
+		ID:               injectableID,
+		WorkspaceID:      workspaceID,
+		Key:              req.Key,
+		Label:            req.Label,
+		Description:      req.Description,
+		DefaultValue:     req.DefaultValue,
+		Metadata:         req.Metadata,
+		Formula:          req.Formula,
+		ExpectedRevision: req.Revision,
+	}
+	if req.DataType != nil {
+		dataType := entity.InjectableDataType(*req.DataType)
+		cmd.DataType = &dataType
 	}
 
 	injectable, err := c.workspaceInjectableUC.UpdateInjectable(ctx.Request.Context(), cmd)
 	if err != nil {
+		if respondRevisionConflict(ctx, err, c.injectableMapper.ToWorkspaceResponse) {
+			return
+		}
 		HandleError(ctx, err)
 		return
 	}
@@ -872,3 +1266,241 @@ func (c *WorkspaceController) DeactivateInjectable(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, c.injectableMapper.ToWorkspaceResponse(injectable))
 }
+
+// --- Notification Webhook Handlers ---
+
+// ListNotificationWebhooks lists the Slack/Teams webhooks of the current workspace.
+// @Summary List notification webhooks
+// @Tags Notification Webhooks
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Success 200 {object} dto.ListResponse[dto.NotificationWebhookResponse]
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/notification-webhooks [get]
+func (c *WorkspaceController) ListNotificationWebhooks(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	webhooks, err := c.webhookUC.ListWebhooks(ctx.Request.Context(), workspaceID)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewListResponse(mapper.NotificationWebhooksToResponses(webhooks)))
+}
+
+// CreateNotificationWebhook creates a Slack/Teams webhook for the current workspace.
+// @Summary Create notification webhook
+// @Description Webhook URLs must use the provider's official host (hooks.slack.com for Slack,
+// @Description *.webhook.office.com or *.logic.azure.com for Teams). Empty eventTypes subscribes to all events.
+// @Tags Notification Webhooks
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param request body dto.CreateNotificationWebhookRequest true "Webhook data"
+// @Success 201 {object} dto.NotificationWebhookResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/notification-webhooks [post]
+func (c *WorkspaceController) CreateNotificationWebhook(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	userID, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, entity.ErrUnauthorized)
+		return
+	}
+
+	var req dto.CreateNotificationWebhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	cmd := mapper.CreateNotificationWebhookRequestToCommand(workspaceID, req, userID)
+	webhook, err := c.webhookUC.CreateWebhook(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, mapper.NotificationWebhookToResponse(webhook))
+}
+
+// GetNotificationWebhook retrieves a notification webhook by ID.
+// @Summary Get notification webhook
+// @Tags Notification Webhooks
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param webhookId path string true "Webhook ID"
+// @Success 200 {object} dto.NotificationWebhookResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/notification-webhooks/{webhookId} [get]
+func (c *WorkspaceController) GetNotificationWebhook(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	webhook, err := c.webhookUC.GetWebhook(ctx.Request.Context(), workspaceID, ctx.Param("webhookId"))
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.NotificationWebhookToResponse(webhook))
+}
+
+// UpdateNotificationWebhook updates a notification webhook.
+// @Summary Update notification webhook
+// @Description Omit url to keep the current webhook URL.
+// @Tags Notification Webhooks
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param webhookId path string true "Webhook ID"
+// @Param request body dto.UpdateNotificationWebhookRequest true "Webhook data"
+// @Success 200 {object} dto.NotificationWebhookResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/notification-webhooks/{webhookId} [put]
+func (c *WorkspaceController) UpdateNotificationWebhook(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	var req dto.UpdateNotificationWebhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	cmd := mapper.UpdateNotificationWebhookRequestToCommand(ctx.Param("webhookId"), workspaceID, req)
+	webhook, err := c.webhookUC.UpdateWebhook(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.NotificationWebhookToResponse(webhook))
+}
+
+// DeleteNotificationWebhook deletes a notification webhook.
+// @Summary Delete notification webhook
+// @Tags Notification Webhooks
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param webhookId path string true "Webhook ID"
+// @Success 204 "No Content"
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/notification-webhooks/{webhookId} [delete]
+func (c *WorkspaceController) DeleteNotificationWebhook(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	if err := c.webhookUC.DeleteWebhook(ctx.Request.Context(), workspaceID, ctx.Param("webhookId")); err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// TestNotificationWebhook sends a sample message through a notification webhook.
+// @Summary Test notification webhook
+// @Tags Notification Webhooks
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param webhookId path string true "Webhook ID"
+// @Success 204 "Test message delivered"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/notification-webhooks/{webhookId}/test [post]
+func (c *WorkspaceController) TestNotificationWebhook(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	if err := c.webhookUC.TestWebhook(ctx.Request.Context(), workspaceID, ctx.Param("webhookId")); err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// --- Schedule Handlers ---
+
+// GetSchedule returns the scheduled publications and archivals of the current workspace.
+// @Summary Get workspace schedule
+// @Description Returns pending scheduled operations, the 50 most recent runs and, per version and operation,
+// @Description the latest run when it failed and the operation is still pending.
+// @Tags Schedule
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Success 200 {object} dto.WorkspaceScheduleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/schedule [get]
+func (c *WorkspaceController) GetSchedule(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+
+	schedule, err := c.versionUC.GetWorkspaceSchedule(ctx.Request.Context(), workspaceID)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.WorkspaceScheduleToResponse(schedule))
+}
+
+// RetryScheduledOperation runs an overdue scheduled publication or archival now.
+// @Summary Retry scheduled operation
+// @Description Runs the operation immediately and records the run. A failed run is returned with status FAILED
+// @Description and the reason in error; 409 means the version has no overdue operation of that kind.
+// @Tags Schedule
+// @Accept json
+// @Produce json
+// @Param X-Workspace-ID header string true "Workspace ID"
+// @Param versionId path string true "Version ID"
+// @Param request body dto.RetryScheduledOperationRequest true "Operation to retry"
+// @Success 200 {object} dto.ScheduledRunResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/workspace/schedule/{versionId}/retry [post]
+func (c *WorkspaceController) RetryScheduledOperation(ctx *gin.Context) {
+	workspaceID, _ := middleware.GetWorkspaceID(ctx)
+	userID, ok := middleware.GetInternalUserID(ctx)
+	if !ok {
+		respondError(ctx, http.StatusUnauthorized, entity.ErrUnauthorized)
+		return
+	}
+
+	var req dto.RetryScheduledOperationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		respondError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	cmd := mapper.RetryScheduledOperationRequestToCommand(workspaceID, ctx.Param("versionId"), userID, req)
+	run, err := c.versionUC.RetryScheduledOperation(ctx.Request.Context(), cmd)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mapper.ScheduledRunToResponse(run))
+}
@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/middleware"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+func TestWorkspaceController_GetSchedule(t *testing.T) {
+	scheduledFor := time.Date(2026, 7, 1, 9, 0, 0, 0, time.UTC)
+	errMsg := "typst compile failed"
+	versions := &fakeScheduleVersions{schedule: &entity.WorkspaceSchedule{
+		Upcoming: []*entity.ScheduledItem{{
+			VersionID: "v-1", TemplateID: "t-1", TemplateTitle: "Offer", Operation: entity.ScheduledOperationPublish, ScheduledFor: scheduledFor,
+		}},
+		Failures: []*entity.ScheduledRun{{
+			ID: "run-1", VersionID: "v-2", Operation: entity.ScheduledOperationArchive, Status: entity.ScheduledRunFailed, Error: &errMsg,
+		}},
+		Timezone: "America/Santiago",
+	}}
+	router := newScheduleTestRouter(versions, "user-1")
+
+	rec := serveSchedule(router, http.MethodGet, "/api/v1/workspace/schedule", "")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "ws-1", versions.workspaceID)
+	var resp dto.WorkspaceScheduleResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Upcoming, 1)
+	assert.Equal(t, "PUBLISH", resp.Upcoming[0].Operation)
+	assert.True(t, scheduledFor.Equal(resp.Upcoming[0].ScheduledFor))
+	require.Len(t, resp.Failures, 1)
+	assert.Equal(t, "FAILED", resp.Failures[0].Status)
+	assert.Equal(t, &errMsg, resp.Failures[0].Error)
+	assert.Equal(t, "America/Santiago", resp.Timezone)
+}
+
+func TestWorkspaceController_RetryScheduledOperation(t *testing.T) {
+	userID := "user-1"
+	versions := &fakeScheduleVersions{run: &entity.ScheduledRun{
+		ID: "run-1", VersionID: "v-1", Operation: entity.ScheduledOperationPublish, Status: entity.ScheduledRunSucceeded, TriggeredBy: &userID,
+	}}
+	router := newScheduleTestRouter(versions, userID)
+
+	rec := serveSchedule(router, http.MethodPost, "/api/v1/workspace/schedule/v-1/retry", `{"operation":"PUBLISH"}`)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, templateuc.RetryScheduledOperationCommand{
+		WorkspaceID: "ws-1", VersionID: "v-1", Operation: entity.ScheduledOperationPublish, UserID: userID,
+	}, versions.retried)
+	var resp dto.ScheduledRunResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "run-1", resp.ID)
+	assert.Equal(t, "SUCCEEDED", resp.Status)
+	assert.Equal(t, &userID, resp.TriggeredBy)
+}
+
+func TestWorkspaceController_RetryScheduledOperationErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		err      error
+		wantCode int
+	}{
+		{name: "unknown operation", body: `{"operation":"DELETE"}`, wantCode: http.StatusBadRequest},
+		{name: "missing operation", body: `{}`, wantCode: http.StatusBadRequest},
+		{name: "not due", body: `{"operation":"ARCHIVE"}`, err: entity.ErrScheduledOperationNotDue, wantCode: http.StatusConflict},
+		{name: "other workspace", body: `{"operation":"PUBLISH"}`, err: entity.ErrVersionNotFound, wantCode: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			versions := &fakeScheduleVersions{err: tt.err}
+			router := newScheduleTestRouter(versions, "user-1")
+
+			rec := serveSchedule(router, http.MethodPost, "/api/v1/workspace/schedule/v-1/retry", tt.body)
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
+
+func TestWorkspaceController_RetryScheduledOperationRequiresUser(t *testing.T) {
+	router := newScheduleTestRouter(&fakeScheduleVersions{}, "")
+
+	rec := serveSchedule(router, http.MethodPost, "/api/v1/workspace/schedule/v-1/retry", `{"operation":"PUBLISH"}`)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+// newScheduleTestRouter serves the schedule handlers behind a superadmin identity, which
+// grants workspace access without looking up memberships.
+func newScheduleTestRouter(versions *fakeScheduleVersions, userID string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	c := &WorkspaceController{versionUC: versions}
+	router := gin.New()
+	workspace := router.Group("/api/v1/workspace")
+	if userID != "" {
+		workspace.Use(middleware.DummyIdentityAndRoles(userID), middleware.WorkspaceContext(nil, nil, nil))
+	}
+	workspace.GET("/schedule", c.GetSchedule)
+	workspace.POST("/schedule/:versionId/retry", c.RetryScheduledOperation)
+	return router
+}
+
+func serveSchedule(router *gin.Engine, method, target, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.WorkspaceIDHeader, "ws-1")
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+type fakeScheduleVersions struct {
+	templateuc.TemplateVersionUseCase
+	schedule    *entity.WorkspaceSchedule
+	run         *entity.ScheduledRun
+	err         error
+	workspaceID string
+	retried     templateuc.RetryScheduledOperationCommand
+}
+
+func (f *fakeScheduleVersions) GetWorkspaceSchedule(_ context.Context, workspaceID string) (*entity.WorkspaceSchedule, error) {
+	f.workspaceID = workspaceID
+	return f.schedule, f.err
+}
+
+func (f *fakeScheduleVersions) RetryScheduledOperation(_ context.Context, cmd templateuc.RetryScheduledOperationCommand) (*entity.ScheduledRun, error) {
+	f.retried = cmd
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.run, nil
+}
@@ -0,0 +1,76 @@
+package dto
+
+import "time"
+
+// AssetResponse represents a library asset in API responses.
+type AssetResponse struct {
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	Kind           string     `json:"kind"`
+	Tags           []string   `json:"tags"`
+	URL            string     `json:"url"` // asset:// URL to reference the asset from template content
+	CurrentVersion int        `json:"currentVersion"`
+	ContentType    string     `json:"contentType"`
+	SizeBytes      int        `json:"sizeBytes"`
+	SHA256         string     `json:"sha256"`
+	SourceURL      *string    `json:"sourceUrl,omitempty"` // Remote URL the asset was imported from; renders use the asset in its place
+	CreatedBy      *string    `json:"createdBy,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      *time.Time `json:"updatedAt,omitempty"`
+}
+
+// UploadAssetResponse is returned when a file is uploaded to the library.
+type UploadAssetResponse struct {
+	AssetResponse
+	Duplicate bool `json:"duplicate"` // The workspace already had this content; the existing asset is returned
+}
+
+// AssetVersionResponse represents a version of an asset in API responses.
+type AssetVersionResponse struct {
+	Version     int       `json:"version"`
+	ContentType string    `json:"contentType"`
+	SizeBytes   int       `json:"sizeBytes"`
+	SHA256      string    `json:"sha256"`
+	CreatedBy   *string   `json:"createdBy,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// AssetUsageResponse is a template version that references an asset.
+type AssetUsageResponse struct {
+	TemplateID    string `json:"templateId"`
+	TemplateTitle string `json:"templateTitle"`
+	VersionID     string `json:"versionId"`
+	VersionNumber int    `json:"versionNumber"`
+	VersionStatus string `json:"versionStatus"`
+}
+
+// UploadAssetRequest represents a request to add a file to the asset library.
+type UploadAssetRequest struct {
+	Name string   `json:"name" binding:"required,max=255"`
+	Tags []string `json:"tags,omitempty"`
+	File []byte   `json:"file" binding:"required" swaggertype:"string" format:"base64"` // Base64-encoded image, PDF or font, up to 10 MiB
+}
+
+// ImportAssetRequest represents a request to download a remote image into the asset library.
+type ImportAssetRequest struct {
+	URL  string   `json:"url" binding:"required,max=2048"`
+	Name string   `json:"name,omitempty" binding:"max=255"` // Defaults to the last segment of the URL path
+	Tags []string `json:"tags,omitempty"`
+}
+
+// AssetSignedURLResponse is a URL that serves an asset without an account until it expires.
+type AssetSignedURLResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// UploadAssetVersionRequest represents a request to replace the content of an asset.
+type UploadAssetVersionRequest struct {
+	File []byte `json:"file" binding:"required" swaggertype:"string" format:"base64"` // Base64-encoded file of the same kind as the asset
+}
+
+// UpdateAssetRequest represents a request to rename or retag an asset.
+type UpdateAssetRequest struct {
+	Name string   `json:"name" binding:"required,max=255"`
+	Tags []string `json:"tags"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+// AuthSessionListRequest represents a request to list authenticated principals.
+type AuthSessionListRequest struct {
+	Page    int    `form:"page,default=1" binding:"min=1"`
+	PerPage int    `form:"perPage,default=20" binding:"min=1,max=100"`
+	Type    string `form:"type"` // Optional principal type: USER, SERVICE_ACCOUNT or API_KEY
+	Query   string `form:"q"`    // Optional search filter for subject, email or name
+}
+
+// AuthSessionResponse represents an authenticated principal in API responses.
+type AuthSessionResponse struct {
+	ID               string     `json:"id"`
+	PrincipalType    string     `json:"principalType"`
+	Provider         string     `json:"provider"`
+	Subject          string     `json:"subject"`
+	UserID           *string    `json:"userId,omitempty"`
+	Email            string     `json:"email,omitempty"`
+	DisplayName      string     `json:"displayName,omitempty"`
+	IPAddress        string     `json:"ipAddress,omitempty"`
+	UserAgent        string     `json:"userAgent,omitempty"`
+	RequestCount     int64      `json:"requestCount"`
+	FirstSeenAt      time.Time  `json:"firstSeenAt"`
+	LastSeenAt       time.Time  `json:"lastSeenAt"`
+	Revoked          bool       `json:"revoked"`
+	RevokedAt        *time.Time `json:"revokedAt,omitempty"`
+	RevokedBy        *string    `json:"revokedBy,omitempty"`
+	ReauthRequiredAt *time.Time `json:"reauthRequiredAt,omitempty"`
+}
+
+// PaginatedAuthSessionsResponse represents a paginated list of sessions.
+type PaginatedAuthSessionsResponse struct {
+	Data       []*AuthSessionResponse `json:"data"`
+	Pagination PaginationMeta         `json:"pagination"`
+}
@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+// RunCleanupRequest runs the orphaned data cleanup now.
+type RunCleanupRequest struct {
+	DryRun *bool `json:"dryRun" binding:"required"` // true only reports what would be deleted
+}
+
+// CleanupRunResponse is a run of the orphaned data cleanup.
+type CleanupRunResponse struct {
+	ID          string                  `json:"id"`
+	DryRun      bool                    `json:"dryRun"`
+	Failed      bool                    `json:"failed"` // The cleanup of a category failed
+	Results     []CleanupResultResponse `json:"results"`
+	TriggeredBy *string                 `json:"triggeredBy,omitempty"` // Omitted for scheduled runs
+	StartedAt   time.Time               `json:"startedAt"`
+	FinishedAt  time.Time               `json:"finishedAt"`
+}
+
+// CleanupResultResponse is the outcome of a cleanup run for one category.
+type CleanupResultResponse struct {
+	Category  string   `json:"category"` // ORPHANED_VERSION_INJECTABLES, UNUSED_IMAGES, EXPIRED_PREVIEW_TOKENS or STALE_SANDBOXES
+	Found     int      `json:"found"`    // Up to cleanup.max_per_category
+	Deleted   int      `json:"deleted"`
+	Kept      bool     `json:"kept"`      // Deletion is disabled for the category
+	SampleIDs []string `json:"sampleIds"` // Up to 20 of the IDs found
+	Error     *string  `json:"error,omitempty"`
+}
+
+// ListCleanupRunsResponse lists the latest cleanup runs, newest first.
+type ListCleanupRunsResponse struct {
+	Items []CleanupRunResponse `json:"items"`
+}
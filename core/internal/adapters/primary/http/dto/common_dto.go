@@ -4,9 +4,30 @@ import "time"
 
 // ErrorResponse represents a standard error response.
 type ErrorResponse struct {
+	Error     string                      `json:"error"`
+	Message   string                      `json:"message,omitempty"`
+	Code      string                      `json:"code,omitempty"`
+	Retryable *bool                       `json:"retryable,omitempty"` // Set for render failures: whether sending the same render again may succeed
+	Details   []RenderErrorDetailResponse `json:"details,omitempty"`   // Causes of a render failure, located in the document
+}
+
+// RenderErrorDetailResponse locates one cause of a failed render in the document.
+type RenderErrorDetailResponse struct {
+	Reason     string   `json:"reason"` // TYPST_COMPILE_ERROR, INJECTOR_TIMEOUT, INJECTOR_FAILED, IMAGE_FETCH_FAILED, EXTERNAL_PDF_FETCH_FAILED or MISSING_REQUIRED_INJECTABLE
+	Message    string   `json:"message,omitempty"`
+	Injectable string   `json:"injectable,omitempty"` // Code of the injectable or injector involved
+	NodePaths  []string `json:"nodePaths,omitempty"`  // Document nodes involved, e.g. content.content[2].content[0]
+	Line       int      `json:"line,omitempty"`       // Position in the generated Typst source, for TYPST_COMPILE_ERROR
+	Column     int      `json:"column,omitempty"`
+	URL        string   `json:"url,omitempty"` // File that failed, without its query string
+}
+
+// RevisionConflictResponse is returned with 409 when an update was based on a stale revision.
+// Current holds the resource as it is now, in the same shape as the endpoint's success response.
+type RevisionConflictResponse struct {
 	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
-	Code    string `json:"code,omitempty"`
+	Code    string `json:"code"`
+	Current any    `json:"current"`
 }
 
 // ValidationError represents a field-level validation error.
@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -134,3 +135,18 @@ func (r *UpdateDocumentTypeRequest) Validate() error {
 	}
 	return nil
 }
+
+// SetDocumentTypeContractRequest represents the request to set the payload contract of a document type.
+type SetDocumentTypeContractRequest struct {
+	Schema  json.RawMessage `json:"schema" binding:"required" swaggertype:"object"` // JSON Schema render payloads must satisfy
+	Example json.RawMessage `json:"example,omitempty" swaggertype:"object"`         // Example payload, checked against the schema
+}
+
+// DocumentTypeContractResponse represents the payload contract of a document type.
+type DocumentTypeContractResponse struct {
+	DocumentTypeID string          `json:"documentTypeId"`
+	Schema         json.RawMessage `json:"schema" swaggertype:"object"`
+	Example        json.RawMessage `json:"example,omitempty" swaggertype:"object"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	UpdatedAt      *time.Time      `json:"updatedAt,omitempty"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+// EditorSchemaResponse lists the document features this server build renders.
+type EditorSchemaResponse struct {
+	FormatVersion    string               `json:"formatVersion"`    // Latest document format version parsed
+	ConverterVersion string               `json:"converterVersion"` // Changes whenever the lists below change
+	CommonAttrs      []string             `json:"commonAttrs"`      // Attrs every node accepts
+	Nodes            []EditorNodeResponse `json:"nodes"`
+	Marks            []EditorMarkResponse `json:"marks"`
+	InjectableTypes  []string             `json:"injectableTypes"`
+	Outputs          []string             `json:"outputs"`
+}
+
+// EditorNodeResponse is a node type the converter renders.
+type EditorNodeResponse struct {
+	Type    string   `json:"type"`
+	Attrs   []string `json:"attrs"`
+	Outputs []string `json:"outputs"` // Outputs that render the node
+}
+
+// EditorMarkResponse is a mark type the converter applies.
+type EditorMarkResponse struct {
+	Type  string   `json:"type"`
+	Attrs []string `json:"attrs"`
+}
@@ -34,4 +34,12 @@ var (
 
 	// Access History validation errors
 	ErrInvalidEntityType = errors.New("entityType must be TENANT or WORKSPACE")
+
+	// Notification webhook validation errors
+	ErrInvalidWebhookProvider  = errors.New("provider must be SLACK or TEAMS")
+	ErrInvalidNotificationType = errors.New("eventTypes contains an unknown notification type")
+
+	// Event webhook validation errors
+	ErrInvalidWebhookEventType      = errors.New("eventTypes contains an unknown webhook event type")
+	ErrInvalidWebhookDeliveryStatus = errors.New("status must be PENDING, DELIVERED or FAILED")
 )
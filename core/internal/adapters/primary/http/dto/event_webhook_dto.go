@@ -0,0 +1,97 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// EventWebhookResponse represents a workspace event webhook in API responses.
+// Secret is only set when the webhook is created or its secret rotated.
+type EventWebhookResponse struct {
+	ID          string     `json:"id"`
+	WorkspaceID string     `json:"workspaceId"`
+	Name        string     `json:"name"`
+	URL         string     `json:"url"`
+	EventTypes  []string   `json:"eventTypes"`
+	Enabled     bool       `json:"enabled"`
+	Secret      string     `json:"secret,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
+}
+
+// CreateEventWebhookRequest represents a request to create a workspace event webhook.
+type CreateEventWebhookRequest struct {
+	Name       string   `json:"name" binding:"required,max=100"`
+	URL        string   `json:"url" binding:"required"`
+	EventTypes []string `json:"eventTypes"` // Empty = all webhook events
+}
+
+// Validate validates the CreateEventWebhookRequest.
+func (r *CreateEventWebhookRequest) Validate() error {
+	if r.Name == "" {
+		return ErrNameRequired
+	}
+	return validateWebhookEventTypes(r.EventTypes)
+}
+
+// UpdateEventWebhookRequest represents a request to update a workspace event webhook.
+// Set rotateSecret to replace the signing secret; the response carries the new one.
+type UpdateEventWebhookRequest struct {
+	Name         string   `json:"name" binding:"required,max=100"`
+	URL          string   `json:"url" binding:"required"`
+	EventTypes   []string `json:"eventTypes"`
+	Enabled      bool     `json:"enabled"`
+	RotateSecret bool     `json:"rotateSecret"`
+}
+
+// Validate validates the UpdateEventWebhookRequest.
+func (r *UpdateEventWebhookRequest) Validate() error {
+	if r.Name == "" {
+		return ErrNameRequired
+	}
+	return validateWebhookEventTypes(r.EventTypes)
+}
+
+// EventWebhookTestResponse is the outcome of a webhook.ping payload posted to an event webhook.
+type EventWebhookTestResponse struct {
+	Delivered  bool    `json:"delivered"`
+	StatusCode int     `json:"statusCode,omitempty"` // Omitted when the endpoint could not be reached
+	Error      *string `json:"error,omitempty"`
+}
+
+// WebhookDeliveryResponse represents an entry of the delivery log of an event webhook.
+// Payload is the exact request body, only included when a single delivery is requested.
+type WebhookDeliveryResponse struct {
+	ID             string          `json:"id"`
+	WebhookID      string          `json:"webhookId"`
+	EventID        string          `json:"eventId"`
+	EventType      string          `json:"eventType"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	LastStatusCode *int            `json:"lastStatusCode,omitempty"`
+	LastError      *string         `json:"lastError,omitempty"`
+	NextAttemptAt  *time.Time      `json:"nextAttemptAt,omitempty"` // Set while the delivery is pending
+	CreatedAt      time.Time       `json:"createdAt"`
+	DeliveredAt    *time.Time      `json:"deliveredAt,omitempty"`
+	FailedAt       *time.Time      `json:"failedAt,omitempty"`
+	Payload        json.RawMessage `json:"payload,omitempty" swaggertype:"object"`
+}
+
+// ValidateWebhookDeliveryStatus validates the optional status filter of the delivery log.
+func ValidateWebhookDeliveryStatus(status string) error {
+	if status != "" && !entity.WebhookDeliveryStatus(status).IsValid() {
+		return ErrInvalidWebhookDeliveryStatus
+	}
+	return nil
+}
+
+func validateWebhookEventTypes(types []string) error {
+	for _, t := range types {
+		if !entity.IsWebhookEventType(entity.DomainEventType(t)) {
+			return ErrInvalidWebhookEventType
+		}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+// HostedDocumentResponse represents a hosted document in API responses.
+type HostedDocumentResponse struct {
+	ID            string     `json:"id"`
+	Filename      string     `json:"filename"`
+	SizeBytes     int        `json:"sizeBytes"`
+	PageCount     int        `json:"pageCount"`
+	Access        string     `json:"access"`
+	AllowDownload bool       `json:"allowDownload"`
+	Expired       bool       `json:"expired"`
+	ExpiresAt     time.Time  `json:"expiresAt"`
+	ViewCount     int        `json:"viewCount"`
+	LastViewedAt  *time.Time `json:"lastViewedAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	HasThumbnail  bool       `json:"hasThumbnail"`
+	IndexedAt     *time.Time `json:"indexedAt,omitempty"`
+	IndexError    *string    `json:"indexError,omitempty"`
+	Snippet       *string    `json:"snippet,omitempty"` // Matching text excerpt, in search results only
+}
+
+// HostedDocumentLinkResponse is returned by render endpoints when the PDF is hosted.
+// The token is shown only once; only its hash is stored.
+type HostedDocumentLinkResponse struct {
+	HostedDocumentResponse
+	URL      string                  `json:"url"`
+	Token    string                  `json:"token,omitempty"`
+	Warnings []RenderWarningResponse `json:"warnings,omitempty"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+import "time"
+
+// InjectableCoverageRequest represents the window of an injectable coverage report. Zero values take
+// the defaults.
+type InjectableCoverageRequest struct {
+	Days       int `form:"days"`       // 1 up to injectable_coverage.retention_days, default 7
+	MinRenders int `form:"minRenders"` // Renders an injectable needs before it is flagged, default 10
+}
+
+// TemplateInjectableCoverageResponse represents where the values of the injectables of a template
+// came from in its renders through the render API.
+type TemplateInjectableCoverageResponse struct {
+	TemplateID    string                       `json:"templateId"`
+	Days          int                          `json:"days"`
+	MinRenders    int                          `json:"minRenders"`
+	Injectables   []InjectableCoverageResponse `json:"injectables"`
+	AlwaysDefault []string                     `json:"alwaysDefault"` // Keys that always took their default
+	AlwaysEmpty   []string                     `json:"alwaysEmpty"`   // Keys that always rendered empty
+}
+
+// InjectableCoverageResponse represents the sources of the values of an injectable over the window.
+type InjectableCoverageResponse struct {
+	InjectableKey  string    `json:"injectableKey"`
+	Renders        int       `json:"renders"`
+	FromPayload    int       `json:"fromPayload"` // Given by the request or resolved by an injector
+	FromDefault    int       `json:"fromDefault"`
+	Empty          int       `json:"empty"`
+	LastRenderedAt time.Time `json:"lastRenderedAt"` // Day of the last render
+	Status         string    `json:"status"`         // OK, ALWAYS_DEFAULT, ALWAYS_EMPTY or NOT_ENOUGH_RENDERS
+}
@@ -55,7 +55,9 @@ type WorkspaceInjectableResponse struct {
 	Metadata     map[string]any        `json:"metadata,omitempty"`
 	FormatConfig *FormatConfigResponse `json:"formatConfig,omitempty"`
 	DefaultValue *string               `json:"defaultValue,omitempty"`
+	Formula      *string               `json:"formula,omitempty"`
 	IsActive     bool                  `json:"isActive"`
+	Revision     int                   `json:"revision"`
 	CreatedAt    time.Time             `json:"createdAt"`
 	UpdatedAt    *time.Time            `json:"updatedAt,omitempty"`
 }
@@ -73,6 +75,8 @@ type CreateWorkspaceInjectableRequest struct {
 	Description  string         `json:"description,omitempty"`
 	DefaultValue string         `json:"defaultValue" binding:"required"`
 	Metadata     map[string]any `json:"metadata,omitempty"`
+	Formula      *string        `json:"formula,omitempty"`  // Computes the value from other injectables
+	DataType     string         `json:"dataType,omitempty"` // TEXT by default; NUMBER, DATE or BOOLEAN need a formula
 }
 
 // UpdateWorkspaceInjectableRequest represents the request to update a workspace injectable.
@@ -82,6 +86,9 @@ type UpdateWorkspaceInjectableRequest struct {
 	Description  *string        `json:"description,omitempty"`
 	DefaultValue *string        `json:"defaultValue,omitempty"`
 	Metadata     map[string]any `json:"metadata,omitempty"`
+	Formula      *string        `json:"formula,omitempty"`  // "" makes the injectable a plain TEXT one again
+	DataType     *string        `json:"dataType,omitempty"` // NUMBER, DATE or BOOLEAN need a formula
+	Revision     *int           `json:"revision,omitempty"` // Revision the edit is based on, or the If-Match header; 409 if it changed since
 }
 
 // TemplateResponse represents a template in API responses (metadata only).
@@ -93,6 +100,7 @@ type TemplateResponse struct {
 	DocumentTypeName map[string]string `json:"documentTypeName,omitempty"`
 	Title            string            `json:"title"`
 	IsPublicLibrary  bool              `json:"isPublicLibrary"`
+	Revision         int               `json:"revision"`
 	CreatedAt        time.Time         `json:"createdAt"`
 	UpdatedAt        *time.Time        `json:"updatedAt,omitempty"`
 }
@@ -118,6 +126,7 @@ type TemplateListItemResponse struct {
 	ScheduledVersionCount  int                  `json:"scheduledVersionCount"`
 	PublishedVersionNumber *int                 `json:"publishedVersionNumber,omitempty"`
 	Tags                   []*TagSimpleResponse `json:"tags"`
+	Revision               int                  `json:"revision"`
 	CreatedAt              time.Time            `json:"createdAt"`
 	UpdatedAt              *time.Time           `json:"updatedAt,omitempty"`
 }
@@ -158,6 +167,7 @@ type UpdateTemplateRequest struct {
 	Title           *string `json:"title,omitempty" binding:"omitempty,min=1,max=255"`
 	FolderID        *string `json:"folderId,omitempty"` // Use "root" to move template to root folder
 	IsPublicLibrary *bool   `json:"isPublicLibrary,omitempty"`
+	Revision        *int    `json:"revision,omitempty"` // Revision the edit is based on, or the If-Match header; 409 if it changed since
 }
 
 // CloneTemplateRequest represents the request to clone a template.
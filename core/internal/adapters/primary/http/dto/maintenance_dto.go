@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+// MaintenanceStatusResponse is the public maintenance status, available without authentication.
+type MaintenanceStatusResponse struct {
+	Mode           string     `json:"mode"`
+	Message        string     `json:"message,omitempty"`
+	ExpectedEndAt  *time.Time `json:"expectedEndAt,omitempty"`
+	ReadOnly       bool       `json:"readOnly"`
+	RendersBlocked bool       `json:"rendersBlocked"`
+}
+
+// MaintenanceStateResponse is the maintenance state as seen by administrators.
+type MaintenanceStateResponse struct {
+	MaintenanceStatusResponse
+	InFlightRenders int64      `json:"inFlightRenders"` // Renders running on the instance that served the request
+	UpdatedBy       *string    `json:"updatedBy,omitempty"`
+	UpdatedAt       *time.Time `json:"updatedAt,omitempty"`
+}
+
+// SetMaintenanceRequest switches the maintenance mode.
+type SetMaintenanceRequest struct {
+	Mode          string     `json:"mode" binding:"required"` // OFF, READ_ONLY or DRAIN
+	Message       string     `json:"message"`                 // Shown to users while active
+	ExpectedEndAt *time.Time `json:"expectedEndAt"`           // Used for the Retry-After header
+}
@@ -19,3 +19,13 @@ func NewMyRolesResponse(roles []RoleEntry) MyRolesResponse {
 	}
 	return MyRolesResponse{Roles: roles}
 }
+
+// MeResponse represents the response for GET /api/v1/me.
+// It bundles everything the UI needs at startup in a single call.
+type MeResponse struct {
+	User       *UserBriefResponse        `json:"user"`
+	SystemRole *string                   `json:"systemRole"` // null when the user has no system role
+	Tenants    []*TenantWithRoleResponse `json:"tenants"`
+	Workspaces []WorkspaceResponse       `json:"workspaces"`
+	Features   map[string]bool           `json:"features"`
+}
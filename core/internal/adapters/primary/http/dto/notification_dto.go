@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+// NotificationListRequest represents a request to list the current user's notifications.
+type NotificationListRequest struct {
+	Page       int  `form:"page,default=1" binding:"min=1"`
+	PerPage    int  `form:"perPage,default=20" binding:"min=1,max=100"`
+	UnreadOnly bool `form:"unreadOnly"`
+}
+
+// NotificationResponse represents a notification in API responses.
+type NotificationResponse struct {
+	ID          string     `json:"id"`
+	WorkspaceID *string    `json:"workspaceId,omitempty"`
+	Type        string     `json:"type"`
+	Title       string     `json:"title"`
+	Message     string     `json:"message"`
+	ResourceID  *string    `json:"resourceId,omitempty"`
+	Read        bool       `json:"read"`
+	ReadAt      *time.Time `json:"readAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// PaginatedNotificationsResponse represents a paginated list of notifications.
+type PaginatedNotificationsResponse struct {
+	Data        []*NotificationResponse `json:"data"`
+	Pagination  PaginationMeta          `json:"pagination"`
+	UnreadCount int64                   `json:"unreadCount"`
+}
+
+// UnreadNotificationsCountResponse represents the unread notification counter.
+type UnreadNotificationsCountResponse struct {
+	Count int64 `json:"count"`
+}
@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// NotificationWebhookResponse represents a workspace notification webhook in API responses.
+// The webhook URL is a secret and is always returned masked.
+type NotificationWebhookResponse struct {
+	ID          string     `json:"id"`
+	WorkspaceID string     `json:"workspaceId"`
+	Provider    string     `json:"provider"`
+	Name        string     `json:"name"`
+	MaskedURL   string     `json:"maskedUrl"`
+	EventTypes  []string   `json:"eventTypes"`
+	Enabled     bool       `json:"enabled"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
+}
+
+// CreateNotificationWebhookRequest represents a request to create a workspace notification webhook.
+type CreateNotificationWebhookRequest struct {
+	Provider   string   `json:"provider" binding:"required,oneof=SLACK TEAMS"`
+	Name       string   `json:"name" binding:"required,max=100"`
+	URL        string   `json:"url" binding:"required"`
+	EventTypes []string `json:"eventTypes"` // Empty = all events
+}
+
+// Validate validates the CreateNotificationWebhookRequest.
+func (r *CreateNotificationWebhookRequest) Validate() error {
+	if r.Name == "" {
+		return ErrNameRequired
+	}
+	if !entity.WebhookProvider(r.Provider).IsValid() {
+		return ErrInvalidWebhookProvider
+	}
+	return validateNotificationTypes(r.EventTypes)
+}
+
+// UpdateNotificationWebhookRequest represents a request to update a workspace notification webhook.
+// Omit url to keep the current one.
+type UpdateNotificationWebhookRequest struct {
+	Name       string   `json:"name" binding:"required,max=100"`
+	URL        *string  `json:"url,omitempty"`
+	EventTypes []string `json:"eventTypes"`
+	Enabled    bool     `json:"enabled"`
+}
+
+// Validate validates the UpdateNotificationWebhookRequest.
+func (r *UpdateNotificationWebhookRequest) Validate() error {
+	if r.Name == "" {
+		return ErrNameRequired
+	}
+	return validateNotificationTypes(r.EventTypes)
+}
+
+func validateNotificationTypes(types []string) error {
+	for _, t := range types {
+		if !entity.NotificationType(t).IsValid() {
+			return ErrInvalidNotificationType
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"time"
+)
+
+// NumberingSequenceResponse represents the numbering sequence of a document type in API responses.
+type NumberingSequenceResponse struct {
+	ID             string     `json:"id"`
+	WorkspaceID    string     `json:"workspaceId"`
+	DocumentTypeID string     `json:"documentTypeId"`
+	InjectableKey  string     `json:"injectableKey"`
+	Prefix         string     `json:"prefix"`
+	Padding        int        `json:"padding"`
+	YearlyReset    bool       `json:"yearlyReset"`
+	Gapless        bool       `json:"gapless"`
+	StartNumber    int64      `json:"startNumber"`
+	NextNumber     int64      `json:"nextNumber"` // Number the next render draws, unless the year resets it
+	Year           int        `json:"year"`       // Year of the last draw; 0 before the first
+	IsActive       bool       `json:"isActive"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      *time.Time `json:"updatedAt,omitempty"`
+}
+
+// CreateNumberingSequenceRequest represents a request to create the numbering sequence of a document type.
+type CreateNumberingSequenceRequest struct {
+	DocumentTypeID string `json:"documentTypeId" binding:"required"`
+	InjectableKey  string `json:"injectableKey" binding:"required,max=100"`
+	Prefix         string `json:"prefix" binding:"max=50"` // {YYYY} and {YY} are replaced by the year
+	Padding        int    `json:"padding" binding:"min=0,max=20"`
+	YearlyReset    bool   `json:"yearlyReset"`
+	Gapless        bool   `json:"gapless"`
+	StartNumber    int64  `json:"startNumber" binding:"min=0"` // 0 = 1
+}
+
+// UpdateNumberingSequenceRequest represents a request to update a numbering sequence. The
+// document type and the counter cannot change.
+type UpdateNumberingSequenceRequest struct {
+	InjectableKey string `json:"injectableKey" binding:"required,max=100"`
+	Prefix        string `json:"prefix" binding:"max=50"`
+	Padding       int    `json:"padding" binding:"min=0,max=20"`
+	YearlyReset   bool   `json:"yearlyReset"`
+	Gapless       bool   `json:"gapless"`
+	IsActive      bool   `json:"isActive"`
+}
+
+// SequenceNumberResponse represents a number drawn from a numbering sequence.
+type SequenceNumberResponse struct {
+	ID         string    `json:"id"`
+	SequenceID string    `json:"sequenceId"`
+	Year       int       `json:"year"` // 0 for sequences not reset yearly
+	Number     int64     `json:"number"`
+	Formatted  string    `json:"formatted"`
+	Status     string    `json:"status"` // ISSUED, VOIDED, PENDING (render in progress) or RELEASED (taken by the next render)
+	VersionID  string    `json:"versionId"`
+	TemplateID string    `json:"templateId"`
+	DocumentID *string   `json:"documentId,omitempty"`
+	IssuedAt   time.Time `json:"issuedAt"`
+}
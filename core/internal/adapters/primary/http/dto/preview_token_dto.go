@@ -0,0 +1,32 @@
+package dto
+
+import "time"
+
+// PreviewTokenResponse represents a version preview link in API responses.
+type PreviewTokenResponse struct {
+	ID         string     `json:"id"`
+	VersionID  string     `json:"versionId"`
+	Label      *string    `json:"label,omitempty"`
+	Watermark  *string    `json:"watermark,omitempty"`
+	CreatedBy  *string    `json:"createdBy,omitempty"`
+	Expired    bool       `json:"expired"`
+	Revoked    bool       `json:"revoked"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// PreviewTokenWithTokenResponse is returned when a preview link is created.
+// The token is shown only once; only its hash is stored.
+type PreviewTokenWithTokenResponse struct {
+	PreviewTokenResponse
+	Token string `json:"token"`
+}
+
+// CreatePreviewTokenRequest represents a request to share a version preview with an external reviewer.
+type CreatePreviewTokenRequest struct {
+	Label          *string `json:"label,omitempty" binding:"omitempty,max=255"`
+	Watermark      *string `json:"watermark,omitempty" binding:"omitempty,max=100"`
+	ExpiresInHours *int    `json:"expiresInHours,omitempty" binding:"omitempty,min=1,max=720"` // Default: 72
+}
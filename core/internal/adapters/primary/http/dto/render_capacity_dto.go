@@ -0,0 +1,12 @@
+package dto
+
+// RenderCapacityResponse is the render load of the instance that served the request, in a flat
+// shape that KEDA's metrics-api scaler and HPA external metrics adapters can read by field name.
+type RenderCapacityResponse struct {
+	QueueDepth    int     `json:"queueDepth"`    // Renders waiting for a slot
+	ActiveRenders int     `json:"activeRenders"` // Renders holding a slot
+	MaxConcurrent int     `json:"maxConcurrent"` // Slot count; 0 means unlimited
+	Utilization   float64 `json:"utilization"`   // activeRenders / maxConcurrent; 0 when unlimited
+	AverageWaitMs float64 `json:"averageWaitMs"` // Mean wait for a slot over the last minute
+	RejectedTotal int64   `json:"rejectedTotal"` // Renders rejected as busy since startup
+}
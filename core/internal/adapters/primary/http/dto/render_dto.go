@@ -1,8 +1,147 @@
 package dto
 
+import "time"
+
 // RenderRequest represents the request body for render endpoints.
 type RenderRequest struct {
-	Injectables map[string]any `json:"injectables"`
+	Injectables map[string]any     `json:"injectables"`
+	Host        *HostRenderOptions `json:"host,omitempty"`    // Render endpoints only; ignored by previews
+	Persist     bool               `json:"persist,omitempty"` // Render endpoints only: write the PDF to object storage and return a signed URL
+	Imposition  *ImpositionRequest `json:"imposition,omitempty"`
+	Layout      *LayoutRequest     `json:"layout,omitempty"`
+	Overlays    *OverlaysRequest   `json:"overlays,omitempty"`                                             // Replaces the watermark, stamp and Bates numbering of the template
+	DocumentID  string             `json:"documentId,omitempty" binding:"max=128"`                         // Seeds security patterns (e.g. a certificate number)
+	Degraded    bool               `json:"degraded,omitempty"`                                             // Render endpoints only: report failed injectors, images and external PDFs instead of failing
+	UnitSystem  string             `json:"unitSystem,omitempty" binding:"omitempty,oneof=metric imperial"` // Converts measurements, replacing the unit system of the template
+}
+
+// HostRenderOptions asks a render endpoint to keep the PDF and return a viewer link instead of the bytes.
+type HostRenderOptions struct {
+	Access         string `json:"access" binding:"required,oneof=PUBLIC TOKEN MEMBERS"`
+	ExpiresInHours *int   `json:"expiresInHours,omitempty" binding:"omitempty,min=1,max=2160"` // Default: 168
+	AllowDownload  *bool  `json:"allowDownload,omitempty"`                                     // Default: true
+}
+
+// PersistedRenderResponse is returned by render endpoints when the PDF is persisted to object storage.
+type PersistedRenderResponse struct {
+	Key       string                  `json:"key"`
+	URL       string                  `json:"url"`
+	ExpiresAt time.Time               `json:"expiresAt"` // When the URL stops working; the object is kept
+	Filename  string                  `json:"filename"`
+	SizeBytes int64                   `json:"sizeBytes"`
+	PageCount int                     `json:"pageCount"`
+	Warnings  []RenderWarningResponse `json:"warnings,omitempty"`
+}
+
+// ImpositionRequest lays the rendered pages out on printer sheets. Sizes are in millimetres.
+type ImpositionRequest struct {
+	Layout       string  `json:"layout" binding:"required,oneof=SINGLE 2UP BOOKLET"`
+	TrimWidthMM  float64 `json:"trimWidthMm,omitempty" binding:"omitempty,gt=0,lte=1200"`  // Default: template page width
+	TrimHeightMM float64 `json:"trimHeightMm,omitempty" binding:"omitempty,gt=0,lte=1200"` // Default: template page height
+	BleedMM      float64 `json:"bleedMm,omitempty" binding:"omitempty,gte=0,lte=10"`
+	CropMarks    bool    `json:"cropMarks,omitempty"`
+}
+
+// LayoutRequest selects one of the layout variants declared by the template. Empty fields keep its page config.
+type LayoutRequest struct {
+	PaperSize    string  `json:"paperSize,omitempty" binding:"omitempty,oneof=A4 LETTER LEGAL"`
+	MarginPreset string  `json:"marginPreset,omitempty" binding:"omitempty,oneof=narrow normal wide"`
+	FontScale    float64 `json:"fontScale,omitempty" binding:"omitempty,gte=0.5,lte=2"`
+}
+
+// OverlaysRequest sets marks printed over the content of every page. Each overlay set replaces
+// the one of the template.
+type OverlaysRequest struct {
+	Watermark string               `json:"watermark,omitempty" binding:"max=100"` // Text stamped diagonally, e.g. "DRAFT"
+	Stamp     *OverlayStampRequest `json:"stamp,omitempty"`
+	Bates     *BatesRequest        `json:"bates,omitempty"`
+}
+
+// OverlayStampRequest is a framed label, e.g. "COPY", printed at a corner of every page.
+type OverlayStampRequest struct {
+	Text     string `json:"text" binding:"required,max=40"`
+	Position string `json:"position,omitempty" binding:"omitempty,oneof=top-left top-right bottom-left bottom-center bottom-right"` // Default: top-right
+	Color    string `json:"color,omitempty" binding:"omitempty,hexcolor"`                                                           // Default: #C62828
+}
+
+// BatesRequest numbers every page with a prefix and a zero-padded sequence, e.g. ACME000001.
+type BatesRequest struct {
+	Prefix   string `json:"prefix,omitempty" binding:"max=20"`
+	Start    int    `json:"start,omitempty" binding:"gte=0,lte=999999999"`                                                          // Number of the first page; default: 1
+	Digits   int    `json:"digits,omitempty" binding:"gte=0,lte=12"`                                                                // Default: 6
+	Position string `json:"position,omitempty" binding:"omitempty,oneof=top-left top-right bottom-left bottom-center bottom-right"` // Default: bottom-right
+}
+
+// BatchRenderRequest renders a template version once per item and returns the PDFs in a zip.
+type BatchRenderRequest struct {
+	Items      []BatchRenderItemRequest `json:"items" binding:"required,min=1,max=500,dive"`
+	Imposition *ImpositionRequest       `json:"imposition,omitempty"`                                           // Applied to every document
+	Layout     *LayoutRequest           `json:"layout,omitempty"`                                               // Applied to every document
+	Overlays   *OverlaysRequest         `json:"overlays,omitempty"`                                             // Applied to every document
+	Degraded   bool                     `json:"degraded,omitempty"`                                             // Applied to every document
+	UnitSystem string                   `json:"unitSystem,omitempty" binding:"omitempty,oneof=metric imperial"` // Applied to every document
+}
+
+// BatchRenderItemRequest is one document of a batch render.
+type BatchRenderItemRequest struct {
+	Injectables map[string]any   `json:"injectables"`
+	DocumentID  string           `json:"documentId,omitempty" binding:"max=128"` // Seeds security patterns (e.g. a certificate number)
+	Overlays    *OverlaysRequest `json:"overlays,omitempty"`                     // Replaces the overlays of the batch, e.g. to continue Bates numbering
+	Filename    string           `json:"filename,omitempty" binding:"max=200"`   // Name of the PDF in the zip; default: the template title
+}
+
+// BatchRenderManifest is written as manifest.json, the last entry of a batch render zip.
+type BatchRenderManifest struct {
+	VersionID string                    `json:"versionId"`
+	Rendered  int                       `json:"rendered"`
+	Failed    int                       `json:"failed"`
+	Items     []BatchRenderManifestItem `json:"items"` // In request order
+}
+
+// BatchRenderManifestItem is the outcome of one item of a batch render.
+type BatchRenderManifestItem struct {
+	Index     int                         `json:"index"`
+	File      string                      `json:"file,omitempty"` // Entry of the PDF in the zip; empty when the item failed
+	PageCount int                         `json:"pageCount,omitempty"`
+	Warnings  []RenderWarningResponse     `json:"warnings,omitempty"`
+	Degraded  bool                        `json:"degraded,omitempty"` // The PDF left out failed injectors, images or external PDFs, listed in warnings
+	Status    int                         `json:"status,omitempty"`   // HTTP status the item would have failed with as a single render
+	Error     string                      `json:"error,omitempty"`
+	Code      string                      `json:"code,omitempty"` // Render error code of a failed item
+	Retryable *bool                       `json:"retryable,omitempty"`
+	Details   []RenderErrorDetailResponse `json:"details,omitempty"` // Causes of the failure, located in the document
+
+	InjectorTimings  []InjectorTimingResponse `json:"injectorTimings,omitempty"`
+	CurrencyRates    []CurrencyRateResponse   `json:"currencyRates,omitempty"`
+	CurrencyRecordID string                   `json:"currencyRecordId,omitempty"` // ID of the stored record of CurrencyRates
+	DocumentNumber   string                   `json:"documentNumber,omitempty"`   // Drawn from the numbering sequence of the document type
+}
+
+// InjectorTimingResponse is when an injector ran while resolving the injectables of a render and
+// how long it took, in milliseconds since the resolution began.
+type InjectorTimingResponse struct {
+	Code       string  `json:"code"`
+	StartMs    float64 `json:"startMs"`
+	DurationMs float64 `json:"durationMs"`
+	Failed     bool    `json:"failed,omitempty"`
+}
+
+// CurrencyRateResponse is an exchange rate a render converted amounts with.
+type CurrencyRateResponse struct {
+	Provider string  `json:"provider"`
+	From     string  `json:"from"`
+	To       string  `json:"to"`
+	Rate     float64 `json:"rate"` // Units of To one unit of From buys
+	Date     string  `json:"date"` // Publication date of the rate, YYYY-MM-DD
+}
+
+// RenderWarningResponse is a problem found while rendering that did not stop the render.
+type RenderWarningResponse struct {
+	Code       string `json:"code"` // COMPILER, MISSING_GLYPHS, HTML_OMITTED, DOCX_OMITTED or DEGRADED_*
+	Message    string `json:"message"`
+	Script     string `json:"script,omitempty"`     // Unicode script of the characters, for MISSING_GLYPHS
+	Characters string `json:"characters,omitempty"` // Sample of the characters, for MISSING_GLYPHS
+	Source     string `json:"source,omitempty"`     // Injector code or file URL without its query, for DEGRADED_*
 }
 
 // RenderPreviewRequest is used for preview rendering.
@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// RenderEstimateResponse is the expected outcome of rendering a template with a sample payload.
+type RenderEstimateResponse struct {
+	VersionID        string                    `json:"versionId"`
+	TemplateID       string                    `json:"templateId"`
+	PageCount        int                       `json:"pageCount"`
+	CompileTimeMs    float64                   `json:"compileTimeMs"`
+	CompileTimeClass string                    `json:"compileTimeClass"` // FAST, NORMAL, SLOW or RUNAWAY
+	Cost             float64                   `json:"cost"`             // Metered units, see render_cost in the configuration
+	DryRun           bool                      `json:"dryRun"`           // False when the estimate comes from statistics only
+	Statistics       *RenderStatisticsResponse `json:"statistics,omitempty"`
+	Warnings         []string                  `json:"warnings"`
+}
+
+// RenderStatisticsResponse summarizes the recent renders of the version on the instance that served the request.
+type RenderStatisticsResponse struct {
+	Renders        int       `json:"renders"` // At most the last 100
+	AvgPageCount   float64   `json:"avgPageCount"`
+	MaxPageCount   int       `json:"maxPageCount"`
+	AvgDurationMs  float64   `json:"avgDurationMs"`
+	P95DurationMs  float64   `json:"p95DurationMs"`
+	LastRenderedAt time.Time `json:"lastRenderedAt"`
+}
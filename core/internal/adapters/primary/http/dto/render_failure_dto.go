@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+// RenderFailureResponse is the captured Typst input of a failed compile, without its source.
+type RenderFailureResponse struct {
+	ID               string                       `json:"id"`
+	TenantCode       string                       `json:"tenantCode"`
+	WorkspaceCode    string                       `json:"workspaceCode"`
+	DocumentTypeCode *string                      `json:"documentTypeCode,omitempty"` // Omitted for renders by version ID
+	VersionID        string                       `json:"versionId"`
+	TemplateID       string                       `json:"templateId"`
+	Environment      string                       `json:"environment"`
+	Error            string                       `json:"error"`
+	SourceBytes      int                          `json:"sourceBytes"`
+	SourceTruncated  bool                         `json:"sourceTruncated"` // Source was cut to render_failures.max_source_kb
+	Assets           []RenderFailureAssetResponse `json:"assets"`
+	CreatedAt        time.Time                    `json:"createdAt"`
+	ExpiresAt        time.Time                    `json:"expiresAt"`
+}
+
+// RenderFailureAssetResponse is a file the failed source referenced.
+type RenderFailureAssetResponse struct {
+	Name      string `json:"name"`
+	URL       string `json:"url,omitempty"` // Without query string; omitted for inline data
+	SizeBytes int    `json:"sizeBytes"`
+	SHA256    string `json:"sha256,omitempty"`
+	Missing   bool   `json:"missing,omitempty"`
+}
+
+// ListRenderFailuresResponse lists the captured render failures, newest first.
+type ListRenderFailuresResponse struct {
+	Items []RenderFailureResponse `json:"items"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+import "time"
+
+// RenderJobResponse represents an asynchronous render job in API responses.
+type RenderJobResponse struct {
+	ID               string                  `json:"id"`
+	Status           string                  `json:"status"` // QUEUED, RUNNING, SUCCEEDED or FAILED
+	DocumentTypeCode *string                 `json:"documentTypeCode,omitempty"`
+	VersionID        *string                 `json:"versionId,omitempty"`
+	Environment      string                  `json:"environment"`
+	Attempts         int                     `json:"attempts"`
+	Error            *string                 `json:"error,omitempty"`     // Set when the job FAILED
+	ErrorCode        *string                 `json:"errorCode,omitempty"` // Render error code of the failure, when it was classified
+	Retryable        *bool                   `json:"retryable,omitempty"` // Whether submitting the same render again may succeed
+	Filename         *string                 `json:"filename,omitempty"`
+	SizeBytes        *int                    `json:"sizeBytes,omitempty"`
+	PageCount        *int                    `json:"pageCount,omitempty"`
+	Warnings         []RenderWarningResponse `json:"warnings,omitempty"`
+	ResultURL        string                  `json:"resultUrl,omitempty"` // Set when the job SUCCEEDED
+	CreatedAt        time.Time               `json:"createdAt"`
+	StartedAt        *time.Time              `json:"startedAt,omitempty"`
+	FinishedAt       *time.Time              `json:"finishedAt,omitempty"`
+	ExpiresAt        time.Time               `json:"expiresAt"` // The job and its PDF are deleted afterwards
+}
@@ -0,0 +1,49 @@
+package dto
+
+import "time"
+
+// RenderQuotaLimitsResponse are render limits. Zero means unlimited.
+type RenderQuotaLimitsResponse struct {
+	RendersPerMinute int   `json:"rendersPerMinute"` // Enforced by each instance on its own
+	PagesPerMonth    int64 `json:"pagesPerMonth"`
+}
+
+// RenderQuotaResponse is the override of the render limits of a tenant or workspace.
+// A null limit takes the default.
+type RenderQuotaResponse struct {
+	Scope            string    `json:"scope"` // TENANT or WORKSPACE
+	TenantID         string    `json:"tenantId"`
+	TenantCode       string    `json:"tenantCode"`
+	WorkspaceID      *string   `json:"workspaceId,omitempty"`
+	WorkspaceCode    string    `json:"workspaceCode,omitempty"`
+	RendersPerMinute *int      `json:"rendersPerMinute"`
+	PagesPerMonth    *int64    `json:"pagesPerMonth"`
+	UpdatedBy        *string   `json:"updatedBy,omitempty"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+// RenderQuotaListResponse lists the quotas with the defaults they override.
+type RenderQuotaListResponse struct {
+	TenantDefaults    RenderQuotaLimitsResponse `json:"tenantDefaults"`
+	WorkspaceDefaults RenderQuotaLimitsResponse `json:"workspaceDefaults"`
+	Quotas            []*RenderQuotaResponse    `json:"quotas"`
+}
+
+// RenderQuotaStatusResponse is the limits in force for a tenant or workspace and its usage this month.
+type RenderQuotaStatusResponse struct {
+	Scope          string                    `json:"scope"` // TENANT or WORKSPACE
+	TenantID       string                    `json:"tenantId"`
+	WorkspaceID    *string                   `json:"workspaceId,omitempty"`
+	Limits         RenderQuotaLimitsResponse `json:"limits"`
+	Override       *RenderQuotaResponse      `json:"override"` // null when the defaults apply
+	Month          string                    `json:"month"`    // YYYY-MM, UTC
+	RendersInMonth int64                     `json:"rendersInMonth"`
+	PagesInMonth   int64                     `json:"pagesInMonth"`
+}
+
+// SetRenderQuotaRequest overrides the render limits of a tenant or workspace.
+// An omitted or null limit takes the default; zero means unlimited.
+type SetRenderQuotaRequest struct {
+	RendersPerMinute *int   `json:"rendersPerMinute"`
+	PagesPerMonth    *int64 `json:"pagesPerMonth"`
+}
@@ -0,0 +1,86 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ScheduleTime is when a scheduled operation runs: an RFC 3339 time, or a date-time without
+// offset (2006-01-02T15:04[:05]) in the timezone of the workspace.
+type ScheduleTime string
+
+// scheduleLocalLayouts are the accepted layouts of a date-time without offset.
+var scheduleLocalLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04"}
+
+// UnmarshalJSON rejects times in neither format, so binding fails with 400.
+func (t *ScheduleTime) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if _, _, err := parseScheduleTime(s); err != nil {
+		return err
+	}
+	*t = ScheduleTime(s)
+	return nil
+}
+
+// Parse returns the time and whether it is a wall-clock time of the workspace timezone.
+func (t ScheduleTime) Parse() (time.Time, bool) {
+	at, local, _ := parseScheduleTime(string(t))
+	return at, local
+}
+
+func parseScheduleTime(s string) (time.Time, bool, error) {
+	if at, err := time.Parse(time.RFC3339, s); err == nil {
+		return at, false, nil
+	}
+	for _, layout := range scheduleLocalLayouts {
+		if at, err := time.Parse(layout, s); err == nil {
+			return at, true, nil
+		}
+	}
+	return time.Time{}, false, fmt.Errorf("invalid schedule time %q: want RFC 3339 or YYYY-MM-DDTHH:MM[:SS]", s)
+}
+
+// ScheduledItemResponse represents a pending scheduled publication or archival.
+type ScheduledItemResponse struct {
+	VersionID     string     `json:"versionId"`
+	VersionName   string     `json:"versionName"`
+	VersionNumber int        `json:"versionNumber"`
+	TemplateID    string     `json:"templateId"`
+	TemplateTitle string     `json:"templateTitle"`
+	Operation     string     `json:"operation"`
+	ScheduledFor  time.Time  `json:"scheduledFor"`
+	FailedAt      *time.Time `json:"failedAt,omitempty"` // Set when the scheduler gave up retrying; needs a retry or reschedule
+	FailureReason *string    `json:"failureReason,omitempty"`
+}
+
+// ScheduledRunResponse represents the outcome of one scheduled operation run.
+type ScheduledRunResponse struct {
+	ID            string     `json:"id"`
+	VersionID     string     `json:"versionId"`
+	VersionName   string     `json:"versionName"`
+	TemplateID    string     `json:"templateId"`
+	TemplateTitle string     `json:"templateTitle"`
+	Operation     string     `json:"operation"`
+	ScheduledFor  *time.Time `json:"scheduledFor,omitempty"`
+	Status        string     `json:"status"`
+	Error         *string    `json:"error,omitempty"`
+	TriggeredBy   *string    `json:"triggeredBy,omitempty"` // Omitted when run by the scheduler
+	RanAt         time.Time  `json:"ranAt"`
+}
+
+// WorkspaceScheduleResponse represents the scheduler view of a workspace.
+type WorkspaceScheduleResponse struct {
+	Upcoming   []*ScheduledItemResponse `json:"upcoming"`
+	RecentRuns []*ScheduledRunResponse  `json:"recentRuns"`
+	Failures   []*ScheduledRunResponse  `json:"failures"` // Latest run failed and the operation is still pending
+	Timezone   string                   `json:"timezone"` // IANA timezone the times are in
+}
+
+// RetryScheduledOperationRequest represents a request to run an overdue scheduled operation now.
+type RetryScheduledOperationRequest struct {
+	Operation string `json:"operation" binding:"required,oneof=PUBLISH ARCHIVE"`
+}
@@ -0,0 +1,98 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// ScriptedInjectorResponse represents a scripted injector in API responses.
+type ScriptedInjectorResponse struct {
+	Code         string            `json:"code"`
+	Label        map[string]string `json:"label"`
+	Description  map[string]string `json:"description"`
+	DataType     string            `json:"dataType"`
+	Source       string            `json:"source"`
+	Dependencies []string          `json:"dependencies"`
+	IsCritical   bool              `json:"isCritical"`
+	TimeoutMs    int               `json:"timeoutMs"`
+	CreatedBy    *string           `json:"createdBy,omitempty"`
+	UpdatedBy    *string           `json:"updatedBy,omitempty"`
+	CreatedAt    string            `json:"createdAt"`
+	UpdatedAt    string            `json:"updatedAt"`
+}
+
+// ListScriptedInjectorsResponse is the response for listing scripted injectors.
+type ListScriptedInjectorsResponse struct {
+	Scripts []ScriptedInjectorResponse `json:"scripts"`
+}
+
+// ScriptedInjectorRequest is the request body for creating or updating a scripted injector.
+// The code is taken from the path on update.
+type ScriptedInjectorRequest struct {
+	Code         string            `json:"code"`
+	Label        map[string]string `json:"label" binding:"required"`
+	Description  map[string]string `json:"description"`
+	DataType     string            `json:"dataType" binding:"required,oneof=TEXT NUMBER BOOLEAN DATE IMAGE"`
+	Source       string            `json:"source" binding:"required"`
+	Dependencies []string          `json:"dependencies"`
+	IsCritical   bool              `json:"isCritical"`
+	TimeoutMs    int               `json:"timeoutMs"`
+}
+
+// TestScriptRequest is the request body for running a script against a sample input.
+type TestScriptRequest struct {
+	Source         string            `json:"source" binding:"required"`
+	DataType       string            `json:"dataType" binding:"omitempty,oneof=TEXT NUMBER BOOLEAN DATE IMAGE"`
+	TimeoutMs      int               `json:"timeoutMs"`
+	Payload        any               `json:"payload"`
+	Resolved       map[string]any    `json:"resolved"`
+	TenantCode     string            `json:"tenantCode"`
+	WorkspaceCode  string            `json:"workspaceCode"`
+	Environment    string            `json:"environment"`
+	SelectedFormat string            `json:"selectedFormat"`
+	Headers        map[string]string `json:"headers"`
+}
+
+// TestScriptResponse is the outcome of a script test run.
+type TestScriptResponse struct {
+	Value  any      `json:"value"`
+	Output []string `json:"output"`
+	Steps  uint64   `json:"steps"`
+}
+
+// ToScriptedInjectorResponse converts an entity to a DTO response.
+func ToScriptedInjectorResponse(s *entity.ScriptedInjector) ScriptedInjectorResponse {
+	return ScriptedInjectorResponse{
+		Code:         s.Code,
+		Label:        s.Label,
+		Description:  s.Description,
+		DataType:     string(s.DataType),
+		Source:       s.Source,
+		Dependencies: s.Dependencies,
+		IsCritical:   s.IsCritical,
+		TimeoutMs:    s.TimeoutMs,
+		CreatedBy:    s.CreatedBy,
+		UpdatedBy:    s.UpdatedBy,
+		CreatedAt:    s.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    s.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// ToListScriptedInjectorsResponse converts a list of entities to a DTO response.
+func ToListScriptedInjectorsResponse(scripts []*entity.ScriptedInjector) ListScriptedInjectorsResponse {
+	responses := make([]ScriptedInjectorResponse, len(scripts))
+	for i, s := range scripts {
+		responses[i] = ToScriptedInjectorResponse(s)
+	}
+	return ListScriptedInjectorsResponse{Scripts: responses}
+}
+
+// ToTestScriptResponse converts a script run to a DTO response.
+func ToTestScriptResponse(run *entity.ScriptRun) TestScriptResponse {
+	output := run.Output
+	if output == nil {
+		output = []string{}
+	}
+	return TestScriptResponse{Value: run.Value, Output: output, Steps: run.Steps}
+}
@@ -0,0 +1,67 @@
+package dto
+
+import "time"
+
+// SystemStatsResponse is the platform overview for platform admins.
+type SystemStatsResponse struct {
+	Counts      EntityCountsResponse `json:"counts"`
+	Renders     RenderTotalsResponse `json:"renders"`
+	Queues      QueueHealthResponse  `json:"queues"`
+	Storage     StorageUsageResponse `json:"storage"`
+	GeneratedAt time.Time            `json:"generatedAt"` // Stats are cached for a minute; queues.render is live
+}
+
+// EntityCountsResponse counts the main platform entities, excluding the system tenant.
+type EntityCountsResponse struct {
+	Tenants           int64 `json:"tenants"`
+	ActiveTenants     int64 `json:"activeTenants"`
+	Workspaces        int64 `json:"workspaces"` // Client workspaces, sandboxes included
+	SandboxWorkspaces int64 `json:"sandboxWorkspaces"`
+	Templates         int64 `json:"templates"`
+	PublishedVersions int64 `json:"publishedVersions"`
+	Users             int64 `json:"users"`
+}
+
+// RenderTotalsResponse counts the renders of the render API over the window and per UTC day.
+type RenderTotalsResponse struct {
+	Total     int64                    `json:"total"`
+	Failures  int64                    `json:"failures"`
+	ErrorRate float64                  `json:"errorRate"` // failures / total, 0 to 1
+	Days      []RenderDayStatsResponse `json:"days"`      // Oldest first
+}
+
+// RenderDayStatsResponse counts the renders of one UTC day.
+type RenderDayStatsResponse struct {
+	Date          string  `json:"date"` // YYYY-MM-DD
+	Renders       int64   `json:"renders"`
+	Failures      int64   `json:"failures"`
+	ErrorRate     float64 `json:"errorRate"`
+	Pages         int64   `json:"pages"`
+	AvgDurationMs float64 `json:"avgDurationMs"` // Of successful renders
+}
+
+// QueueHealthResponse summarizes the background queues and the render queue.
+type QueueHealthResponse struct {
+	OutboxPending         int64                  `json:"outboxPending"`
+	OutboxFailed          int64                  `json:"outboxFailed"`
+	OutboxOldestPendingAt *time.Time             `json:"outboxOldestPendingAt,omitempty"`
+	DocumentIndexPending  int64                  `json:"documentIndexPending"`
+	ScheduledVersions     int64                  `json:"scheduledVersions"`
+	Render                RenderCapacityResponse `json:"render"` // Instance that served the request
+}
+
+// StorageUsageResponse is the content stored in the database and its growth per UTC day.
+type StorageUsageResponse struct {
+	TotalBytes          int64                     `json:"totalBytes"`
+	AssetBytes          int64                     `json:"assetBytes"`
+	HostedDocumentBytes int64                     `json:"hostedDocumentBytes"`
+	Days                []StorageDayUsageResponse `json:"days"` // Oldest first
+}
+
+// StorageDayUsageResponse is the storage uploaded on one UTC day and the total at its end.
+// Totals only count content still stored.
+type StorageDayUsageResponse struct {
+	Date       string `json:"date"` // YYYY-MM-DD
+	AddedBytes int64  `json:"addedBytes"`
+	TotalBytes int64  `json:"totalBytes"`
+}
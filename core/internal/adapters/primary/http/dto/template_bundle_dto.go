@@ -0,0 +1,24 @@
+package dto
+
+// ImportTemplateBundleRequest represents the request to create a template from a bundle.
+type ImportTemplateBundleRequest struct {
+	Bundle   []byte  `json:"bundle" binding:"required" swaggertype:"string" format:"base64"` // Base64-encoded bundle from GET .../export
+	Title    string  `json:"title,omitempty" binding:"max=255"`                              // Replaces the bundled title, e.g. when it is taken
+	FolderID *string `json:"folderId,omitempty"`
+}
+
+// ImportTemplateBundleResponse is the template created from a bundle, with its versions and the import report.
+type ImportTemplateBundleResponse struct {
+	Template *TemplateResponse             `json:"template"`
+	Versions []*TemplateVersionResponse    `json:"versions"`
+	Report   *TemplateBundleReportResponse `json:"report"`
+}
+
+// TemplateBundleReportResponse lists what importing a bundle created, reused or could not bring over.
+type TemplateBundleReportResponse struct {
+	CreatedInjectables []string `json:"createdInjectables"` // Workspace injectables created in the workspace
+	MissingInjectables []string `json:"missingInjectables"` // Injectables this server does not provide; renders leave them empty
+	CreatedAssets      int      `json:"createdAssets"`
+	ReusedAssets       int      `json:"reusedAssets"`      // Assets whose content the library already had
+	StorageReferences  []string `json:"storageReferences"` // Gallery files (storage://) are not bundled and must exist in this environment
+}
@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// InstallLibraryTemplateRequest represents the request to install a library template into the workspace.
+type InstallLibraryTemplateRequest struct {
+	Title    string  `json:"title,omitempty" binding:"max=255"` // Title of the copy; defaults to the library title
+	FolderID *string `json:"folderId,omitempty"`
+}
+
+// InstallLibraryTemplateResponse is the copy of a library template, with its draft version and link.
+type InstallLibraryTemplateResponse struct {
+	Template *TemplateResponse            `json:"template"`
+	Version  *TemplateVersionResponse     `json:"version"`
+	Link     *TemplateLibraryLinkResponse `json:"link"`
+}
+
+// TemplateLibraryLinkResponse is the library template an installed template was copied from.
+type TemplateLibraryLinkResponse struct {
+	TemplateID        string    `json:"templateId"`
+	SourceTemplateID  string    `json:"sourceTemplateId"`
+	BaseVersionNumber int       `json:"baseVersionNumber"` // Library version installed or last pulled
+	CopiedAssets      int       `json:"copiedAssets"`
+	InstalledAt       time.Time `json:"installedAt"`
+	SyncedAt          time.Time `json:"syncedAt"`
+}
+
+// PullLibraryUpdateRequest represents the request to merge the latest library version into an installed template.
+type PullLibraryUpdateRequest struct {
+	// Strategy resolves conflicting changes; without it the pull fails on conflicts
+	Strategy string `json:"strategy,omitempty" binding:"omitempty,oneof=KEEP_LOCAL TAKE_UPSTREAM"`
+}
+
+// LibraryUpdatePreviewResponse is what pulling the latest library version into an installed template would change.
+type LibraryUpdatePreviewResponse struct {
+	TemplateID            string                          `json:"templateId"`
+	SourceTemplateID      string                          `json:"sourceTemplateId"`
+	BaseVersionNumber     int                             `json:"baseVersionNumber"`
+	UpToDate              bool                            `json:"upToDate"` // Nothing to pull
+	UpstreamVersionID     string                          `json:"upstreamVersionId"`
+	UpstreamVersionNumber int                             `json:"upstreamVersionNumber"`
+	LocalVersionID        string                          `json:"localVersionId"` // Latest non-archived version
+	LocalVersionNumber    int                             `json:"localVersionNumber"`
+	UpstreamChanges       *VersionDiffResponse            `json:"upstreamChanges"` // Library changes since the base
+	LocalChanges          *VersionDiffResponse            `json:"localChanges"`    // Local changes since the base
+	Conflicts             []*LibraryMergeConflictResponse `json:"conflicts"`
+	Merged                json.RawMessage                 `json:"merged" swaggertype:"object"` // Content of the pulled draft, keeping the local side of conflicts
+}
+
+// LibraryMergeConflictResponse is a part of a template changed differently locally and in the library.
+type LibraryMergeConflictResponse struct {
+	Path     string          `json:"path"` // e.g. "content[3]" or "pageConfig", in the base version
+	Base     json.RawMessage `json:"base" swaggertype:"object"`
+	Local    json.RawMessage `json:"local" swaggertype:"object"`
+	Upstream json.RawMessage `json:"upstream" swaggertype:"object"`
+}
@@ -0,0 +1,43 @@
+package dto
+
+import "time"
+
+// TemplateSLARequest represents a request to set the SLA of a template. At least one of maxRenderMs
+// and maxFailureRate is required; a zero window or min renders takes the default.
+type TemplateSLARequest struct {
+	MaxRenderMs    *int     `json:"maxRenderMs"`    // Max average render time of the successful renders
+	MaxFailureRate *float64 `json:"maxFailureRate"` // Max share of failed renders, from 0 to 1
+	WindowMinutes  int      `json:"windowMinutes"`  // 5 to 1440, default 60
+	MinRenders     int      `json:"minRenders"`     // Renders the window needs before it is checked, default 10
+}
+
+// TemplateSLAResponse represents the SLA of a template with the renders of its current window.
+type TemplateSLAResponse struct {
+	TemplateID     string                         `json:"templateId"`
+	MaxRenderMs    *int                           `json:"maxRenderMs,omitempty"`
+	MaxFailureRate *float64                       `json:"maxFailureRate,omitempty"`
+	WindowMinutes  int                            `json:"windowMinutes"`
+	MinRenders     int                            `json:"minRenders"`
+	BreachedAt     *time.Time                     `json:"breachedAt,omitempty"` // Set while the SLA is breached
+	UpdatedBy      *string                        `json:"updatedBy,omitempty"`
+	UpdatedAt      time.Time                      `json:"updatedAt"`
+	Window         TemplateRenderWindowResponse   `json:"window"`
+	Violations     []TemplateSLAViolationResponse `json:"violations"` // Empty when met or not judged
+}
+
+// TemplateRenderWindowResponse represents the renders of a template over the window of its SLA.
+// Renders of the last check interval may not be counted yet.
+type TemplateRenderWindowResponse struct {
+	Renders     int64   `json:"renders"`
+	Failures    int64   `json:"failures"`
+	AvgRenderMs float64 `json:"avgRenderMs"` // Of the successful renders
+	FailureRate float64 `json:"failureRate"`
+	Judged      bool    `json:"judged"` // The window has at least minRenders renders
+}
+
+// TemplateSLAViolationResponse represents an expectation the current window does not meet.
+type TemplateSLAViolationResponse struct {
+	Metric    string  `json:"metric"` // RENDER_TIME or FAILURE_RATE
+	Threshold float64 `json:"threshold"`
+	Actual    float64 `json:"actual"`
+}
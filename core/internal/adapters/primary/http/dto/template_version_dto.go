@@ -9,21 +9,52 @@ import (
 
 // TemplateVersionResponse represents a template version in API responses (without content).
 type TemplateVersionResponse struct {
-	ID                 string     `json:"id"`
-	TemplateID         string     `json:"templateId"`
-	VersionNumber      int        `json:"versionNumber"`
-	Name               string     `json:"name"`
-	Description        *string    `json:"description,omitempty"`
-	Status             string     `json:"status"`
-	ScheduledPublishAt *time.Time `json:"scheduledPublishAt,omitempty"`
-	ScheduledArchiveAt *time.Time `json:"scheduledArchiveAt,omitempty"`
-	PublishedAt        *time.Time `json:"publishedAt,omitempty"`
-	ArchivedAt         *time.Time `json:"archivedAt,omitempty"`
-	PublishedBy        *string    `json:"publishedBy,omitempty"`
-	ArchivedBy         *string    `json:"archivedBy,omitempty"`
-	CreatedBy          *string    `json:"createdBy,omitempty"`
-	CreatedAt          time.Time  `json:"createdAt"`
-	UpdatedAt          *time.Time `json:"updatedAt,omitempty"`
+	ID                    string     `json:"id"`
+	TemplateID            string     `json:"templateId"`
+	VersionNumber         int        `json:"versionNumber"`
+	Name                  string     `json:"name"`
+	Description           *string    `json:"description,omitempty"`
+	Status                string     `json:"status"`
+	ScheduledPublishAt    *time.Time `json:"scheduledPublishAt,omitempty"`
+	ScheduledArchiveAt    *time.Time `json:"scheduledArchiveAt,omitempty"`
+	PublishedAt           *time.Time `json:"publishedAt,omitempty"`
+	ArchivedAt            *time.Time `json:"archivedAt,omitempty"`
+	PublishedBy           *string    `json:"publishedBy,omitempty"`
+	ArchivedBy            *string    `json:"archivedBy,omitempty"`
+	CreatedBy             *string    `json:"createdBy,omitempty"`
+	ScheduleFailedAt      *time.Time `json:"scheduleFailedAt,omitempty"` // Set when the scheduler gave up on the pending scheduled operation
+	ScheduleFailureReason *string    `json:"scheduleFailureReason,omitempty"`
+	Revision              int        `json:"revision"`
+	CreatedAt             time.Time  `json:"createdAt"`
+	UpdatedAt             *time.Time `json:"updatedAt,omitempty"`
+
+	ReleaseNotes *VersionReleaseNotesResponse `json:"releaseNotes,omitempty"`
+}
+
+// VersionReleaseNotesResponse represents the release notes of a version.
+type VersionReleaseNotesResponse struct {
+	VersionID string     `json:"versionId"`
+	Body      string     `json:"body"` // Markdown
+	TicketIDs []string   `json:"ticketIds"`
+	AuthorID  *string    `json:"authorId,omitempty"` // User who last edited the notes
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// ImportTemplateResponse represents the draft with imported content and the conversion report.
+// Version is omitted for a dry run.
+type ImportTemplateResponse struct {
+	Version *TemplateVersionResponse      `json:"version,omitempty"`
+	Report  *TemplateImportReportResponse `json:"report"`
+}
+
+// TemplateImportReportResponse lists what a template import could not convert losslessly.
+type TemplateImportReportResponse struct {
+	MappedPlaceholders   map[string]string `json:"mappedPlaceholders"`
+	UnmappedPlaceholders []string          `json:"unmappedPlaceholders"`
+	UnsupportedElements  []string          `json:"unsupportedElements"`
+	Warnings             []string          `json:"warnings"`
+	Lossless             bool              `json:"lossless"`
 }
 
 // TemplateVersionDetailResponse represents a template version with full details.
@@ -31,6 +62,48 @@ type TemplateVersionDetailResponse struct {
 	TemplateVersionResponse
 	ContentStructure json.RawMessage                      `json:"contentStructure,omitempty"`
 	Injectables      []*TemplateVersionInjectableResponse `json:"injectables,omitempty"`
+	Changelog        *VersionChangelogResponse            `json:"changelog,omitempty"` // Set once the version was published
+}
+
+// VersionChangelogResponse lists what changed in a version compared to the version published before it.
+type VersionChangelogResponse struct {
+	PreviousVersionID     *string  `json:"previousVersionId,omitempty"` // Absent on the first publication of the template
+	PreviousVersionNumber *int     `json:"previousVersionNumber,omitempty"`
+	SectionsAdded         []string `json:"sectionsAdded"`
+	SectionsRemoved       []string `json:"sectionsRemoved"`
+	InjectablesAdded      []string `json:"injectablesAdded"`
+	InjectablesRemoved    []string `json:"injectablesRemoved"`
+	PageConfigChanges     []string `json:"pageConfigChanges"`
+	Summary               string   `json:"summary"` // Human-readable, one change per line
+}
+
+// VersionDiffResponse is the structural difference between the content of two versions.
+type VersionDiffResponse struct {
+	FromVersionID      string                 `json:"fromVersionId"`
+	FromVersionNumber  int                    `json:"fromVersionNumber"`
+	ToVersionID        string                 `json:"toVersionId"`
+	ToVersionNumber    int                    `json:"toVersionNumber"`
+	Nodes              []*NodeChangeResponse  `json:"nodes"`
+	InjectablesAdded   []string               `json:"injectablesAdded"`
+	InjectablesRemoved []string               `json:"injectablesRemoved"`
+	PageConfigChanges  []*FieldChangeResponse `json:"pageConfigChanges"` // Includes header and footer settings
+}
+
+// NodeChangeResponse is a block of content added, removed or changed between two versions.
+type NodeChangeResponse struct {
+	Kind     string          `json:"kind"` // ADDED | REMOVED | CHANGED
+	Path     string          `json:"path"` // e.g. "content[3]", in the target version for changed nodes
+	NodeType string          `json:"nodeType"`
+	Fields   []string        `json:"fields,omitempty"` // Parts of a changed node that differ, e.g. "attrs.level"
+	Before   json.RawMessage `json:"before,omitempty"` // Node in the base version
+	After    json.RawMessage `json:"after,omitempty"`  // Node in the target version
+}
+
+// FieldChangeResponse is a setting whose value differs between two versions.
+type FieldChangeResponse struct {
+	Field string `json:"field"` // Dotted path, e.g. "margins.top"
+	From  any    `json:"from"`
+	To    any    `json:"to"`
 }
 
 // TemplateVersionSummaryResponse represents a template version summary (without content).
@@ -75,16 +148,43 @@ type UpdateVersionRequest struct {
 	Name             *string         `json:"name,omitempty" binding:"omitempty,min=1,max=100"`
 	Description      *string         `json:"description,omitempty"`
 	ContentStructure json.RawMessage `json:"contentStructure,omitempty"`
+	Revision         *int            `json:"revision,omitempty"` // Revision the edit is based on, or the If-Match header; 409 if it changed since
+}
+
+// UpdateReleaseNotesRequest represents the request to write the release notes of a version.
+type UpdateReleaseNotesRequest struct {
+	Body      string   `json:"body" binding:"max=20000"`
+	TicketIDs []string `json:"ticketIds,omitempty" binding:"max=20"`
+}
+
+// ImportHTMLRequest represents the request to import a legacy HTML template into a draft version.
+type ImportHTMLRequest struct {
+	HTML     string `json:"html" binding:"required"`
+	Revision *int   `json:"revision,omitempty"` // Revision the import is based on; 409 if it changed since
+}
+
+// ImportMarkdownRequest represents the request to import a Markdown template into a draft version.
+type ImportMarkdownRequest struct {
+	Markdown string `json:"markdown" binding:"required"`
+	Revision *int   `json:"revision,omitempty"` // Revision the import is based on; 409 if it changed since
+}
+
+// ImportDocxRequest represents the request to import a Word document into a draft version.
+type ImportDocxRequest struct {
+	Document []byte            `json:"document" binding:"required" swaggertype:"string" format:"base64"` // Base64-encoded .docx file
+	Mapping  map[string]string `json:"mapping,omitempty"`                                                // Placeholder name -> injectable key
+	DryRun   bool              `json:"dryRun,omitempty"`                                                 // Only return the report, without saving
+	Revision *int              `json:"revision,omitempty"`                                               // Revision the import is based on; 409 if it changed since
 }
 
 // SchedulePublishRequest represents the request to schedule version publication.
 type SchedulePublishRequest struct {
-	PublishAt time.Time `json:"publishAt" binding:"required"`
+	PublishAt ScheduleTime `json:"publishAt" binding:"required"`
 }
 
 // ScheduleArchiveRequest represents the request to schedule version archival.
 type ScheduleArchiveRequest struct {
-	ArchiveAt time.Time `json:"archiveAt" binding:"required"`
+	ArchiveAt ScheduleTime `json:"archiveAt" binding:"required"`
 }
 
 // AddVersionInjectableRequest represents the request to add an injectable to a version.
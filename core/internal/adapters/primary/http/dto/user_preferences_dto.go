@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+// UserPreferencesResponse represents the current user's preferences.
+type UserPreferencesResponse struct {
+	Locale             string     `json:"locale"`
+	Timezone           string     `json:"timezone"`
+	DefaultWorkspaceID *string    `json:"defaultWorkspaceId"` // null when not set
+	UpdatedAt          *time.Time `json:"updatedAt,omitempty"`
+}
+
+// UpdateUserPreferencesRequest replaces the current user's preferences.
+type UpdateUserPreferencesRequest struct {
+	Locale             string  `json:"locale" binding:"required"`   // BCP 47 tag, e.g. "es-CL"
+	Timezone           string  `json:"timezone" binding:"required"` // IANA name, e.g. "America/Santiago"
+	DefaultWorkspaceID *string `json:"defaultWorkspaceId"`
+}
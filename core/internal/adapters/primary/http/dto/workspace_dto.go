@@ -94,3 +94,58 @@ func (r *UpdateWorkspaceStatusRequest) Validate() error {
 		return ErrInvalidWorkspaceStatus
 	}
 }
+
+// BulkWorkspacesRequest is the request body for bulk operations on the workspaces of a tenant.
+// An empty list applies the operation to every client workspace of the tenant that is not archived.
+type BulkWorkspacesRequest struct {
+	WorkspaceIDs []string `json:"workspaceIds"`
+}
+
+// BulkWorkspaceSettingsRequest is the request body for setting settings sections on the workspaces of a tenant.
+// An empty list applies the settings to every client workspace of the tenant that is not archived.
+type BulkWorkspaceSettingsRequest struct {
+	WorkspaceIDs []string             `json:"workspaceIds"`
+	Settings     WorkspaceSettingsDTO `json:"settings"` // Sections to set; absent sections keep their value
+}
+
+// BulkWorkspaceError represents an error for a specific workspace in a bulk operation.
+type BulkWorkspaceError struct {
+	WorkspaceID string `json:"workspaceId"`
+	Error       string `json:"error"`
+}
+
+// BulkWorkspacesResponse is the response for bulk operations on workspaces.
+type BulkWorkspacesResponse struct {
+	Succeeded []string             `json:"succeeded"`
+	Failed    []BulkWorkspaceError `json:"failed,omitempty"`
+}
+
+// BulkWebhookSecretsResponse is the response for rotating the event webhook secrets of the workspaces of a tenant.
+// Webhooks carry their new signing secret, which is not returned again.
+type BulkWebhookSecretsResponse struct {
+	BulkWorkspacesResponse
+	Webhooks []*EventWebhookResponse `json:"webhooks"`
+}
+
+// CreateSandboxRequest represents a request to clone the current workspace into a sandbox.
+type CreateSandboxRequest struct {
+	TTLHours int `json:"ttlHours,omitempty" binding:"omitempty,min=1,max=168"` // Defaults to 24
+}
+
+// SandboxCloneCounts reports how many resources were copied into a sandbox.
+type SandboxCloneCounts struct {
+	Folders     int `json:"folders"`
+	Tags        int `json:"tags"`
+	Injectables int `json:"injectables"`
+	Templates   int `json:"templates"`
+	Versions    int `json:"versions"`
+	Assets      int `json:"assets"`
+}
+
+// SandboxResponse represents a newly created sandbox workspace.
+type SandboxResponse struct {
+	Workspace         WorkspaceResponse  `json:"workspace"`
+	SourceWorkspaceID string             `json:"sourceWorkspaceId"`
+	ExpiresAt         time.Time          `json:"expiresAt"`
+	Cloned            SandboxCloneCounts `json:"cloned"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// WorkspaceFontResponse represents a font uploaded to a workspace in API responses.
+type WorkspaceFontResponse struct {
+	ID        string    `json:"id"`
+	Family    string    `json:"family"`
+	Weight    int       `json:"weight"`
+	Style     string    `json:"style"`  // normal | italic
+	Format    string    `json:"format"` // TTF | OTF
+	SizeBytes int       `json:"sizeBytes"`
+	SHA256    string    `json:"sha256"`
+	CreatedBy *string   `json:"createdBy,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// UploadFontResponse is returned when a font is uploaded to a workspace.
+type UploadFontResponse struct {
+	WorkspaceFontResponse
+	Duplicate bool `json:"duplicate"` // The workspace already had this file; the existing font is returned
+}
+
+// FontFaceResponse is a weight and style of a font family.
+type FontFaceResponse struct {
+	Weight int    `json:"weight"`
+	Style  string `json:"style"`
+}
+
+// FontFamilyResponse is a font family templates of the workspace can use.
+type FontFamilyResponse struct {
+	Name   string             `json:"name"`
+	Source string             `json:"source"`          // SYSTEM | WORKSPACE
+	Faces  []FontFaceResponse `json:"faces,omitempty"` // Faces of a workspace family
+}
+
+// UploadFontRequest represents a request to upload a font to a workspace.
+type UploadFontRequest struct {
+	File []byte `json:"file" binding:"required" swaggertype:"string" format:"base64"` // Base64-encoded TTF or OTF font, up to 10 MiB
+}
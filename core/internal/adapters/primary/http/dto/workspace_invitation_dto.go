@@ -0,0 +1,54 @@
+package dto
+
+import "time"
+
+// InvitationResponse represents a workspace invitation in API responses.
+type InvitationResponse struct {
+	ID          string     `json:"id"`
+	WorkspaceID string     `json:"workspaceId"`
+	Email       string     `json:"email"`
+	Role        string     `json:"role"`
+	Status      string     `json:"status"`
+	InvitedBy   *string    `json:"invitedBy,omitempty"`
+	Expired     bool       `json:"expired"`
+	ExpiresAt   time.Time  `json:"expiresAt"`
+	RespondedAt *time.Time `json:"respondedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
+}
+
+// InvitationWithTokenResponse is returned when an invitation token is (re)issued.
+// The token is shown only once; only its hash is stored.
+type InvitationWithTokenResponse struct {
+	InvitationResponse
+	Token string `json:"token"`
+}
+
+// MyInvitationResponse represents a pending invitation addressed to the current user.
+type MyInvitationResponse struct {
+	InvitationResponse
+	WorkspaceName string  `json:"workspaceName"`
+	TenantID      *string `json:"tenantId,omitempty"`
+}
+
+// CreateInvitationRequest represents a request to invite an email to the workspace.
+type CreateInvitationRequest struct {
+	Email string `json:"email" binding:"required,email,max=255"`
+	Role  string `json:"role" binding:"required"`
+}
+
+// Validate validates the CreateInvitationRequest.
+func (r *CreateInvitationRequest) Validate() error {
+	if r.Email == "" {
+		return ErrEmailRequired
+	}
+	if !isValidInviteRole(r.Role) {
+		return ErrInvalidRole
+	}
+	return nil
+}
+
+// InvitationTokenRequest carries the token from an invitation email link.
+type InvitationTokenRequest struct {
+	Token string `json:"token" binding:"required"`
+}
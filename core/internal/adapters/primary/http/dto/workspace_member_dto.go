@@ -37,6 +37,11 @@ type UpdateMemberRoleRequest struct {
 	Role string `json:"role" binding:"required"`
 }
 
+// TransferOwnershipRequest represents a request to transfer workspace ownership.
+type TransferOwnershipRequest struct {
+	MemberID string `json:"memberId" binding:"required"`
+}
+
 // Validate validates the InviteMemberRequest.
 func (r *InviteMemberRequest) Validate() error {
 	if r.Email == "" {
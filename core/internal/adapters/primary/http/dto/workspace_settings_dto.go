@@ -0,0 +1,53 @@
+package dto
+
+import "time"
+
+// WorkspaceSettingsDTO represents the settings of a workspace. An absent section is not set.
+type WorkspaceSettingsDTO struct {
+	Branding      *WorkspaceBrandingDTO      `json:"branding,omitempty"`
+	Retention     *WorkspaceRetentionDTO     `json:"retention,omitempty"`
+	RenderOptions *WorkspaceRenderOptionsDTO `json:"renderOptions,omitempty"`
+	AllowedFonts  []string                   `json:"allowedFonts,omitempty"` // Font families templates may use; empty allows all
+	Timezone      string                     `json:"timezone,omitempty"`     // IANA timezone of schedules and numbering years; empty is UTC
+}
+
+// WorkspaceBrandingDTO represents the look of a workspace.
+type WorkspaceBrandingDTO struct {
+	LogoURL      string `json:"logoUrl,omitempty"`
+	PrimaryColor string `json:"primaryColor,omitempty"` // #RRGGBB
+	AccentColor  string `json:"accentColor,omitempty"`  // #RRGGBB
+}
+
+// WorkspaceRetentionDTO represents how long a workspace keeps what renders leave behind.
+type WorkspaceRetentionDTO struct {
+	RenderHistoryDays int `json:"renderHistoryDays"` // 1 to 3650
+}
+
+// WorkspaceRenderOptionsDTO represents the render defaults of a workspace.
+type WorkspaceRenderOptionsDTO struct {
+	Watermark    string `json:"watermark,omitempty"` // Up to 100 characters
+	Degraded     bool   `json:"degraded"`
+	UnknownNodes string `json:"unknownNodes,omitempty"` // FLATTEN (default), PLACEHOLDER or FAIL
+}
+
+// WorkspaceSettingsResponse represents the settings a workspace applies.
+type WorkspaceSettingsResponse struct {
+	WorkspaceID string               `json:"workspaceId"`
+	Settings    WorkspaceSettingsDTO `json:"settings"`
+	Locked      []string             `json:"locked"` // Sections that take the tenant value and cannot be changed
+}
+
+// TenantWorkspaceDefaultsRequest represents a request to set the workspace defaults of a tenant.
+type TenantWorkspaceDefaultsRequest struct {
+	Settings WorkspaceSettingsDTO `json:"settings"`
+	Locked   []string             `json:"locked"` // branding | retention | renderOptions | allowedFonts | timezone
+}
+
+// TenantWorkspaceDefaultsResponse represents the settings new workspaces of a tenant start with.
+type TenantWorkspaceDefaultsResponse struct {
+	TenantID  string               `json:"tenantId"`
+	Settings  WorkspaceSettingsDTO `json:"settings"`
+	Locked    []string             `json:"locked"`
+	UpdatedBy *string              `json:"updatedBy,omitempty"`
+	UpdatedAt *time.Time           `json:"updatedAt,omitempty"` // Absent when the tenant never set defaults
+}
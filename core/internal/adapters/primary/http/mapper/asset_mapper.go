@@ -0,0 +1,74 @@
+package mapper
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	cataloguc "github.com/rendis/pdf-forge/core/internal/core/usecase/catalog"
+)
+
+// AssetToResponse converts an Asset entity to a response DTO.
+func AssetToResponse(a *entity.Asset) dto.AssetResponse {
+	return dto.AssetResponse{
+		ID:             a.ID,
+		Name:           a.Name,
+		Kind:           string(a.Kind),
+		Tags:           a.Tags,
+		URL:            a.URL(),
+		CurrentVersion: a.CurrentVersion,
+		ContentType:    a.ContentType,
+		SizeBytes:      a.SizeBytes,
+		SHA256:         a.SHA256,
+		SourceURL:      a.SourceURL,
+		CreatedBy:      a.CreatedBy,
+		CreatedAt:      a.CreatedAt,
+		UpdatedAt:      a.UpdatedAt,
+	}
+}
+
+// AssetsToResponses converts a slice of Asset entities to response DTOs.
+func AssetsToResponses(assets []*entity.Asset) []dto.AssetResponse {
+	result := make([]dto.AssetResponse, len(assets))
+	for i, a := range assets {
+		result[i] = AssetToResponse(a)
+	}
+	return result
+}
+
+// UploadAssetResultToResponse converts an upload result to a response DTO.
+func UploadAssetResultToResponse(result *cataloguc.UploadAssetResult) dto.UploadAssetResponse {
+	return dto.UploadAssetResponse{
+		AssetResponse: AssetToResponse(result.Asset),
+		Duplicate:     result.Duplicate,
+	}
+}
+
+// AssetVersionsToResponses converts asset versions to response DTOs.
+func AssetVersionsToResponses(versions []*entity.AssetVersion) []dto.AssetVersionResponse {
+	result := make([]dto.AssetVersionResponse, len(versions))
+	for i, v := range versions {
+		result[i] = dto.AssetVersionResponse{
+			Version:     v.Version,
+			ContentType: v.ContentType,
+			SizeBytes:   v.SizeBytes,
+			SHA256:      v.SHA256,
+			CreatedBy:   v.CreatedBy,
+			CreatedAt:   v.CreatedAt,
+		}
+	}
+	return result
+}
+
+// AssetUsagesToResponses converts asset usages to response DTOs.
+func AssetUsagesToResponses(usages []*entity.AssetUsage) []dto.AssetUsageResponse {
+	result := make([]dto.AssetUsageResponse, len(usages))
+	for i, u := range usages {
+		result[i] = dto.AssetUsageResponse{
+			TemplateID:    u.TemplateID,
+			TemplateTitle: u.TemplateTitle,
+			VersionID:     u.VersionID,
+			VersionNumber: u.VersionNumber,
+			VersionStatus: string(u.VersionStatus),
+		}
+	}
+	return result
+}
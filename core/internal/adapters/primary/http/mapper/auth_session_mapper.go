@@ -0,0 +1,75 @@
+package mapper
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	accessuc "github.com/rendis/pdf-forge/core/internal/core/usecase/access"
+)
+
+// AuthSessionToResponse converts a session entity to a response DTO.
+func AuthSessionToResponse(s *entity.AuthSession) *dto.AuthSessionResponse {
+	if s == nil {
+		return nil
+	}
+	return &dto.AuthSessionResponse{
+		ID:               s.ID,
+		PrincipalType:    string(s.PrincipalType),
+		Provider:         s.Provider,
+		Subject:          s.Subject,
+		UserID:           s.UserID,
+		Email:            s.Email,
+		DisplayName:      s.DisplayName,
+		IPAddress:        s.IPAddress,
+		UserAgent:        s.UserAgent,
+		RequestCount:     s.RequestCount,
+		FirstSeenAt:      s.FirstSeenAt,
+		LastSeenAt:       s.LastSeenAt,
+		Revoked:          s.IsRevoked(),
+		RevokedAt:        s.RevokedAt,
+		RevokedBy:        s.RevokedBy,
+		ReauthRequiredAt: s.ReauthRequiredAt,
+	}
+}
+
+// AuthSessionListRequestToFilters converts a list request to port filters.
+func AuthSessionListRequestToFilters(req dto.AuthSessionListRequest) port.AuthSessionFilters {
+	offset := (req.Page - 1) * req.PerPage
+	return port.AuthSessionFilters{
+		Limit:         req.PerPage,
+		Offset:        offset,
+		PrincipalType: entity.PrincipalType(req.Type),
+		Search:        req.Query,
+	}
+}
+
+// AuthSessionsToPaginatedResponse converts sessions to a paginated response.
+func AuthSessionsToPaginatedResponse(sessions []*entity.AuthSession, total int64, page, perPage int) *dto.PaginatedAuthSessionsResponse {
+	responses := make([]*dto.AuthSessionResponse, len(sessions))
+	for i, s := range sessions {
+		responses[i] = AuthSessionToResponse(s)
+	}
+
+	totalPages := int(total) / perPage
+	if int(total)%perPage > 0 {
+		totalPages++
+	}
+
+	return &dto.PaginatedAuthSessionsResponse{
+		Data: responses,
+		Pagination: dto.PaginationMeta{
+			Page:       page,
+			PerPage:    perPage,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	}
+}
+
+// RevokeSessionToCommand creates a revoke session command.
+func RevokeSessionToCommand(sessionID, revokedBy string) accessuc.RevokeSessionCommand {
+	return accessuc.RevokeSessionCommand{
+		SessionID: sessionID,
+		RevokedBy: revokedBy,
+	}
+}
@@ -0,0 +1,43 @@
+package mapper
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// CleanupRunToResponse converts a cleanup run to its DTO.
+func CleanupRunToResponse(run *entity.CleanupRun) dto.CleanupRunResponse {
+	resp := dto.CleanupRunResponse{
+		ID:          run.ID,
+		DryRun:      run.DryRun,
+		Failed:      run.Failed(),
+		Results:     make([]dto.CleanupResultResponse, 0, len(run.Results)),
+		TriggeredBy: run.TriggeredBy,
+		StartedAt:   run.StartedAt,
+		FinishedAt:  run.FinishedAt,
+	}
+	for _, r := range run.Results {
+		sampleIDs := r.SampleIDs
+		if sampleIDs == nil {
+			sampleIDs = []string{}
+		}
+		resp.Results = append(resp.Results, dto.CleanupResultResponse{
+			Category:  string(r.Category),
+			Found:     r.Found,
+			Deleted:   r.Deleted,
+			Kept:      r.Kept,
+			SampleIDs: sampleIDs,
+			Error:     r.Error,
+		})
+	}
+	return resp
+}
+
+// CleanupRunsToResponse converts a list of cleanup runs to their DTO.
+func CleanupRunsToResponse(runs []*entity.CleanupRun) *dto.ListCleanupRunsResponse {
+	items := make([]dto.CleanupRunResponse, 0, len(runs))
+	for _, run := range runs {
+		items = append(items, CleanupRunToResponse(run))
+	}
+	return &dto.ListCleanupRunsResponse{Items: items}
+}
@@ -205,3 +205,14 @@ func AssignResultToResponse(result *templateuc.AssignDocumentTypeResult, templat
 	}
 	return resp
 }
+
+// ToContractResponse converts a DocumentTypeContract entity to a response DTO.
+func (m *DocumentTypeMapper) ToContractResponse(c *entity.DocumentTypeContract) *dto.DocumentTypeContractResponse {
+	return &dto.DocumentTypeContractResponse{
+		DocumentTypeID: c.DocumentTypeID,
+		Schema:         c.Schema,
+		Example:        c.Example,
+		CreatedAt:      c.CreatedAt,
+		UpdatedAt:      c.UpdatedAt,
+	}
+}
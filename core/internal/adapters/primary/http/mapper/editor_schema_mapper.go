@@ -0,0 +1,38 @@
+package mapper
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+// EditorSchemaToResponse converts an editor schema to its DTO. Empty lists are returned as []
+// so clients can iterate them without null checks.
+func EditorSchemaToResponse(s portabledoc.EditorSchema) *dto.EditorSchemaResponse {
+	resp := &dto.EditorSchemaResponse{
+		FormatVersion:    s.FormatVersion,
+		ConverterVersion: s.ConverterVersion,
+		CommonAttrs:      nonNilStrings(s.CommonAttrs),
+		Nodes:            make([]dto.EditorNodeResponse, 0, len(s.Nodes)),
+		Marks:            make([]dto.EditorMarkResponse, 0, len(s.Marks)),
+		InjectableTypes:  nonNilStrings(s.InjectableTypes),
+		Outputs:          nonNilStrings(s.Outputs),
+	}
+	for _, n := range s.Nodes {
+		resp.Nodes = append(resp.Nodes, dto.EditorNodeResponse{
+			Type:    n.Type,
+			Attrs:   nonNilStrings(n.Attrs),
+			Outputs: nonNilStrings(n.Outputs),
+		})
+	}
+	for _, m := range s.Marks {
+		resp.Marks = append(resp.Marks, dto.EditorMarkResponse{Type: m.Type, Attrs: nonNilStrings(m.Attrs)})
+	}
+	return resp
+}
+
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
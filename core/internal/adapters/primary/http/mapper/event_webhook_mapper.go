@@ -0,0 +1,118 @@
+package mapper
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	notificationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/notification"
+)
+
+// EventWebhookToResponse converts an event webhook entity to a response DTO without its secret.
+func EventWebhookToResponse(w *entity.EventWebhook) *dto.EventWebhookResponse {
+	eventTypes := make([]string, len(w.EventTypes))
+	for i, t := range w.EventTypes {
+		eventTypes[i] = string(t)
+	}
+	return &dto.EventWebhookResponse{
+		ID:          w.ID,
+		WorkspaceID: w.WorkspaceID,
+		Name:        w.Name,
+		URL:         w.URL,
+		EventTypes:  eventTypes,
+		Enabled:     w.Enabled,
+		CreatedAt:   w.CreatedAt,
+		UpdatedAt:   w.UpdatedAt,
+	}
+}
+
+// EventWebhookToResponseWithSecret converts an event webhook entity to a response DTO
+// including its signing secret, for the responses that create or rotate it.
+func EventWebhookToResponseWithSecret(w *entity.EventWebhook) *dto.EventWebhookResponse {
+	resp := EventWebhookToResponse(w)
+	resp.Secret = w.Secret
+	return resp
+}
+
+// EventWebhooksToResponses converts event webhook entities to response DTOs.
+func EventWebhooksToResponses(webhooks []*entity.EventWebhook) []*dto.EventWebhookResponse {
+	result := make([]*dto.EventWebhookResponse, len(webhooks))
+	for i, w := range webhooks {
+		result[i] = EventWebhookToResponse(w)
+	}
+	return result
+}
+
+// CreateEventWebhookRequestToCommand converts a create request to a usecase command.
+func CreateEventWebhookRequestToCommand(workspaceID string, req dto.CreateEventWebhookRequest, createdBy string) notificationuc.CreateEventWebhookCommand {
+	return notificationuc.CreateEventWebhookCommand{
+		WorkspaceID: workspaceID,
+		Name:        req.Name,
+		URL:         req.URL,
+		EventTypes:  toDomainEventTypes(req.EventTypes),
+		CreatedBy:   createdBy,
+	}
+}
+
+// UpdateEventWebhookRequestToCommand converts an update request to a usecase command.
+func UpdateEventWebhookRequestToCommand(id, workspaceID string, req dto.UpdateEventWebhookRequest) notificationuc.UpdateEventWebhookCommand {
+	return notificationuc.UpdateEventWebhookCommand{
+		ID:           id,
+		WorkspaceID:  workspaceID,
+		Name:         req.Name,
+		URL:          req.URL,
+		EventTypes:   toDomainEventTypes(req.EventTypes),
+		Enabled:      req.Enabled,
+		RotateSecret: req.RotateSecret,
+	}
+}
+
+// EventWebhookTestResultToResponse converts a test result to a response DTO.
+func EventWebhookTestResultToResponse(r *notificationuc.EventWebhookTestResult) *dto.EventWebhookTestResponse {
+	return &dto.EventWebhookTestResponse{
+		Delivered:  r.Error == nil,
+		StatusCode: r.StatusCode,
+		Error:      r.Error,
+	}
+}
+
+// WebhookDeliveryToResponse converts a delivery entity to a response DTO, with its payload
+// when withPayload is set.
+func WebhookDeliveryToResponse(d *entity.WebhookDelivery, withPayload bool) *dto.WebhookDeliveryResponse {
+	resp := &dto.WebhookDeliveryResponse{
+		ID:             d.ID,
+		WebhookID:      d.WebhookID,
+		EventID:        d.EventID,
+		EventType:      string(d.EventType),
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt,
+		FailedAt:       d.FailedAt,
+	}
+	if d.Status == entity.WebhookDeliveryPending {
+		next := d.NextAttemptAt
+		resp.NextAttemptAt = &next
+	}
+	if withPayload {
+		resp.Payload = d.Payload
+	}
+	return resp
+}
+
+// WebhookDeliveriesToResponses converts delivery entities to response DTOs without payloads.
+func WebhookDeliveriesToResponses(deliveries []*entity.WebhookDelivery) []*dto.WebhookDeliveryResponse {
+	result := make([]*dto.WebhookDeliveryResponse, len(deliveries))
+	for i, d := range deliveries {
+		result[i] = WebhookDeliveryToResponse(d, false)
+	}
+	return result
+}
+
+func toDomainEventTypes(types []string) []entity.DomainEventType {
+	result := make([]entity.DomainEventType, len(types))
+	for i, t := range types {
+		result[i] = entity.DomainEventType(t)
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// HostedDocumentToResponse converts a HostedDocument entity to a response DTO.
+func HostedDocumentToResponse(d *entity.HostedDocument) dto.HostedDocumentResponse {
+	return dto.HostedDocumentResponse{
+		ID:            d.ID,
+		Filename:      d.Filename,
+		SizeBytes:     d.SizeBytes,
+		PageCount:     d.PageCount,
+		Access:        string(d.Access),
+		AllowDownload: d.AllowDownload,
+		Expired:       d.IsExpired(),
+		ExpiresAt:     d.ExpiresAt,
+		ViewCount:     d.ViewCount,
+		LastViewedAt:  d.LastViewedAt,
+		CreatedAt:     d.CreatedAt,
+		HasThumbnail:  d.HasThumbnail,
+		IndexedAt:     d.IndexedAt,
+		IndexError:    d.IndexError,
+		Snippet:       d.SearchSnippet,
+	}
+}
+
+// HostedDocumentsToResponses converts a slice of HostedDocument entities to response DTOs.
+func HostedDocumentsToResponses(docs []*entity.HostedDocument) []dto.HostedDocumentResponse {
+	result := make([]dto.HostedDocumentResponse, len(docs))
+	for i, d := range docs {
+		result[i] = HostedDocumentToResponse(d)
+	}
+	return result
+}
+
+// HostedDocumentLinkToResponse converts a freshly hosted document link to a response DTO.
+func HostedDocumentLinkToResponse(link *templateuc.HostedDocumentLink) *dto.HostedDocumentLinkResponse {
+	return &dto.HostedDocumentLinkResponse{
+		HostedDocumentResponse: HostedDocumentToResponse(link.Document),
+		URL:                    link.URL,
+		Token:                  link.Token,
+	}
+}
+
+// HostRenderOptionsToCommand converts render host options and a render result to a usecase command.
+func HostRenderOptionsToCommand(tenantCode, workspaceCode string, opts *dto.HostRenderOptions, result *port.RenderPreviewResult) templateuc.HostRenderCommand {
+	cmd := templateuc.HostRenderCommand{
+		TenantCode:    tenantCode,
+		WorkspaceCode: workspaceCode,
+		Result:        result,
+		Access:        entity.HostedDocumentAccess(opts.Access),
+		AllowDownload: opts.AllowDownload == nil || *opts.AllowDownload,
+	}
+	if opts.ExpiresInHours != nil {
+		cmd.TTL = time.Duration(*opts.ExpiresInHours) * time.Hour
+	}
+	return cmd
+}
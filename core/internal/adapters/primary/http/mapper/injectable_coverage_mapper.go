@@ -0,0 +1,30 @@
+package mapper
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// TemplateInjectableCoverageToResponse converts the injectable coverage of a template to a response DTO.
+func TemplateInjectableCoverageToResponse(coverage *entity.TemplateInjectableCoverage) *dto.TemplateInjectableCoverageResponse {
+	resp := &dto.TemplateInjectableCoverageResponse{
+		TemplateID:    coverage.TemplateID,
+		Days:          coverage.Days,
+		MinRenders:    coverage.MinRenders,
+		Injectables:   make([]dto.InjectableCoverageResponse, len(coverage.Injectables)),
+		AlwaysDefault: coverage.AlwaysDefault,
+		AlwaysEmpty:   coverage.AlwaysEmpty,
+	}
+	for i, injectable := range coverage.Injectables {
+		resp.Injectables[i] = dto.InjectableCoverageResponse{
+			InjectableKey:  injectable.InjectableKey,
+			Renders:        injectable.Renders,
+			FromPayload:    injectable.FromPayload,
+			FromDefault:    injectable.FromDefault,
+			Empty:          injectable.Empty,
+			LastRenderedAt: injectable.LastRenderedAt,
+			Status:         string(injectable.Status),
+		}
+	}
+	return resp
+}
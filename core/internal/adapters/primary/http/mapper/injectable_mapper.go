@@ -146,7 +146,9 @@ func (m *InjectableMapper) ToWorkspaceResponse(injectable *entity.InjectableDefi
 		Metadata:     injectable.Metadata,
 		FormatConfig: mapFormatConfig(injectable.FormatConfig),
 		DefaultValue: injectable.DefaultValue,
+		Formula:      injectable.Formula,
 		IsActive:     injectable.IsActive,
+		Revision:     injectable.Revision,
 		CreatedAt:    injectable.CreatedAt,
 		UpdatedAt:    injectable.UpdatedAt,
 	}
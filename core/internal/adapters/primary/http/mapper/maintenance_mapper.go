@@ -0,0 +1,38 @@
+package mapper
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	platformuc "github.com/rendis/pdf-forge/core/internal/core/usecase/platform"
+)
+
+// MaintenanceToStatusResponse converts the maintenance state to the public status DTO.
+func MaintenanceToStatusResponse(s *entity.MaintenanceState) *dto.MaintenanceStatusResponse {
+	return &dto.MaintenanceStatusResponse{
+		Mode:           string(s.Mode),
+		Message:        s.Message,
+		ExpectedEndAt:  s.ExpectedEndAt,
+		ReadOnly:       s.BlocksWrites(),
+		RendersBlocked: s.BlocksRenders(),
+	}
+}
+
+// MaintenanceToStateResponse converts the maintenance state to the admin DTO.
+func MaintenanceToStateResponse(s *entity.MaintenanceState, inFlightRenders int64) *dto.MaintenanceStateResponse {
+	return &dto.MaintenanceStateResponse{
+		MaintenanceStatusResponse: *MaintenanceToStatusResponse(s),
+		InFlightRenders:           inFlightRenders,
+		UpdatedBy:                 s.UpdatedBy,
+		UpdatedAt:                 s.UpdatedAt,
+	}
+}
+
+// SetMaintenanceRequestToCommand converts a set maintenance request to a usecase command.
+func SetMaintenanceRequestToCommand(req dto.SetMaintenanceRequest, updatedBy string) platformuc.SetMaintenanceCommand {
+	return platformuc.SetMaintenanceCommand{
+		Mode:          entity.MaintenanceMode(req.Mode),
+		Message:       req.Message,
+		ExpectedEndAt: req.ExpectedEndAt,
+		UpdatedBy:     updatedBy,
+	}
+}
@@ -0,0 +1,35 @@
+package mapper
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	accessuc "github.com/rendis/pdf-forge/core/internal/core/usecase/access"
+)
+
+// UserProfileToMeResponse converts a resolved user profile to the /me response DTO.
+func UserProfileToMeResponse(p *accessuc.UserProfile) *dto.MeResponse {
+	resp := &dto.MeResponse{
+		User:       UserBriefToResponse(p.User),
+		Tenants:    make([]*dto.TenantWithRoleResponse, 0, len(p.Tenants)),
+		Workspaces: make([]dto.WorkspaceResponse, 0, len(p.Workspaces)),
+		Features:   p.Features,
+	}
+
+	if p.SystemRole != nil {
+		role := string(*p.SystemRole)
+		resp.SystemRole = &role
+	}
+
+	for _, t := range p.Tenants {
+		if r := TenantWithRoleToResponse(t); r != nil {
+			resp.Tenants = append(resp.Tenants, r)
+		}
+	}
+
+	for _, w := range p.Workspaces {
+		r := WorkspaceToResponse(&w.Workspace)
+		r.Role = string(w.Role)
+		resp.Workspaces = append(resp.Workspaces, r)
+	}
+
+	return resp
+}
@@ -0,0 +1,59 @@
+package mapper
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// NotificationToResponse converts a notification entity to a response DTO.
+func NotificationToResponse(n *entity.Notification) *dto.NotificationResponse {
+	if n == nil {
+		return nil
+	}
+	return &dto.NotificationResponse{
+		ID:          n.ID,
+		WorkspaceID: n.WorkspaceID,
+		Type:        string(n.Type),
+		Title:       n.Title,
+		Message:     n.Message,
+		ResourceID:  n.ResourceID,
+		Read:        n.IsRead(),
+		ReadAt:      n.ReadAt,
+		CreatedAt:   n.CreatedAt,
+	}
+}
+
+// NotificationListRequestToFilters converts a list request to port filters.
+func NotificationListRequestToFilters(req dto.NotificationListRequest) port.NotificationFilters {
+	offset := (req.Page - 1) * req.PerPage
+	return port.NotificationFilters{
+		Limit:      req.PerPage,
+		Offset:     offset,
+		UnreadOnly: req.UnreadOnly,
+	}
+}
+
+// NotificationsToPaginatedResponse converts notifications to a paginated response.
+func NotificationsToPaginatedResponse(notifications []*entity.Notification, total, unread int64, page, perPage int) *dto.PaginatedNotificationsResponse {
+	responses := make([]*dto.NotificationResponse, len(notifications))
+	for i, n := range notifications {
+		responses[i] = NotificationToResponse(n)
+	}
+
+	totalPages := int(total) / perPage
+	if int(total)%perPage > 0 {
+		totalPages++
+	}
+
+	return &dto.PaginatedNotificationsResponse{
+		Data: responses,
+		Pagination: dto.PaginationMeta{
+			Page:       page,
+			PerPage:    perPage,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+		UnreadCount: unread,
+	}
+}
@@ -0,0 +1,67 @@
+package mapper
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	notificationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/notification"
+)
+
+// NotificationWebhookToResponse converts a webhook entity to a response DTO.
+func NotificationWebhookToResponse(w *entity.NotificationWebhook) *dto.NotificationWebhookResponse {
+	eventTypes := make([]string, len(w.EventTypes))
+	for i, t := range w.EventTypes {
+		eventTypes[i] = string(t)
+	}
+	return &dto.NotificationWebhookResponse{
+		ID:          w.ID,
+		WorkspaceID: w.WorkspaceID,
+		Provider:    string(w.Provider),
+		Name:        w.Name,
+		MaskedURL:   w.MaskedURL(),
+		EventTypes:  eventTypes,
+		Enabled:     w.Enabled,
+		CreatedAt:   w.CreatedAt,
+		UpdatedAt:   w.UpdatedAt,
+	}
+}
+
+// NotificationWebhooksToResponses converts webhook entities to response DTOs.
+func NotificationWebhooksToResponses(webhooks []*entity.NotificationWebhook) []*dto.NotificationWebhookResponse {
+	result := make([]*dto.NotificationWebhookResponse, len(webhooks))
+	for i, w := range webhooks {
+		result[i] = NotificationWebhookToResponse(w)
+	}
+	return result
+}
+
+// CreateNotificationWebhookRequestToCommand converts a create request to a usecase command.
+func CreateNotificationWebhookRequestToCommand(workspaceID string, req dto.CreateNotificationWebhookRequest, createdBy string) notificationuc.CreateWebhookCommand {
+	return notificationuc.CreateWebhookCommand{
+		WorkspaceID: workspaceID,
+		Provider:    entity.WebhookProvider(req.Provider),
+		Name:        req.Name,
+		URL:         req.URL,
+		EventTypes:  toNotificationTypes(req.EventTypes),
+		CreatedBy:   createdBy,
+	}
+}
+
+// UpdateNotificationWebhookRequestToCommand converts an update request to a usecase command.
+func UpdateNotificationWebhookRequestToCommand(id, workspaceID string, req dto.UpdateNotificationWebhookRequest) notificationuc.UpdateWebhookCommand {
+	return notificationuc.UpdateWebhookCommand{
+		ID:          id,
+		WorkspaceID: workspaceID,
+		Name:        req.Name,
+		URL:         req.URL,
+		EventTypes:  toNotificationTypes(req.EventTypes),
+		Enabled:     req.Enabled,
+	}
+}
+
+func toNotificationTypes(types []string) []entity.NotificationType {
+	result := make([]entity.NotificationType, len(types))
+	for i, t := range types {
+		result[i] = entity.NotificationType(t)
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+package mapper
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// NumberingSequenceToResponse converts a numbering sequence entity to a response DTO.
+func NumberingSequenceToResponse(s *entity.NumberingSequence) *dto.NumberingSequenceResponse {
+	return &dto.NumberingSequenceResponse{
+		ID:             s.ID,
+		WorkspaceID:    s.WorkspaceID,
+		DocumentTypeID: s.DocumentTypeID,
+		InjectableKey:  s.InjectableKey,
+		Prefix:         s.Prefix,
+		Padding:        s.Padding,
+		YearlyReset:    s.YearlyReset,
+		Gapless:        s.Gapless,
+		StartNumber:    s.StartNumber,
+		NextNumber:     s.NextNumber,
+		Year:           s.Year,
+		IsActive:       s.IsActive,
+		CreatedAt:      s.CreatedAt,
+		UpdatedAt:      s.UpdatedAt,
+	}
+}
+
+// NumberingSequencesToResponses converts numbering sequence entities to response DTOs.
+func NumberingSequencesToResponses(sequences []*entity.NumberingSequence) []*dto.NumberingSequenceResponse {
+	result := make([]*dto.NumberingSequenceResponse, len(sequences))
+	for i, s := range sequences {
+		result[i] = NumberingSequenceToResponse(s)
+	}
+	return result
+}
+
+// SequenceNumbersToResponses converts sequence number entities to response DTOs.
+func SequenceNumbersToResponses(numbers []*entity.SequenceNumber) []*dto.SequenceNumberResponse {
+	result := make([]*dto.SequenceNumberResponse, len(numbers))
+	for i, n := range numbers {
+		result[i] = &dto.SequenceNumberResponse{
+			ID:         n.ID,
+			SequenceID: n.SequenceID,
+			Year:       n.Year,
+			Number:     n.Number,
+			Formatted:  n.Formatted,
+			Status:     string(n.Status),
+			VersionID:  n.VersionID,
+			TemplateID: n.TemplateID,
+			DocumentID: n.DocumentID,
+			IssuedAt:   n.IssuedAt,
+		}
+	}
+	return result
+}
+
+// CreateNumberingSequenceRequestToCommand converts a create request to a usecase command.
+func CreateNumberingSequenceRequestToCommand(workspaceID string, req dto.CreateNumberingSequenceRequest, createdBy string) templateuc.CreateNumberingSequenceCommand {
+	return templateuc.CreateNumberingSequenceCommand{
+		WorkspaceID:    workspaceID,
+		DocumentTypeID: req.DocumentTypeID,
+		InjectableKey:  req.InjectableKey,
+		Prefix:         req.Prefix,
+		Padding:        req.Padding,
+		YearlyReset:    req.YearlyReset,
+		Gapless:        req.Gapless,
+		StartNumber:    req.StartNumber,
+		CreatedBy:      createdBy,
+	}
+}
+
+// UpdateNumberingSequenceRequestToCommand converts an update request to a usecase command.
+func UpdateNumberingSequenceRequestToCommand(id, workspaceID string, req dto.UpdateNumberingSequenceRequest) templateuc.UpdateNumberingSequenceCommand {
+	return templateuc.UpdateNumberingSequenceCommand{
+		ID:            id,
+		WorkspaceID:   workspaceID,
+		InjectableKey: req.InjectableKey,
+		Prefix:        req.Prefix,
+		Padding:       req.Padding,
+		YearlyReset:   req.YearlyReset,
+		Gapless:       req.Gapless,
+		IsActive:      req.IsActive,
+	}
+}
@@ -0,0 +1,58 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// PreviewTokenToResponse converts a PreviewToken entity to a response DTO.
+func PreviewTokenToResponse(t *entity.PreviewToken) dto.PreviewTokenResponse {
+	return dto.PreviewTokenResponse{
+		ID:         t.ID,
+		VersionID:  t.VersionID,
+		Label:      t.Label,
+		Watermark:  t.Watermark,
+		CreatedBy:  t.CreatedBy,
+		Expired:    t.IsExpired(),
+		Revoked:    t.IsRevoked(),
+		ExpiresAt:  t.ExpiresAt,
+		RevokedAt:  t.RevokedAt,
+		LastUsedAt: t.LastUsedAt,
+		CreatedAt:  t.CreatedAt,
+	}
+}
+
+// PreviewTokensToResponses converts a slice of PreviewToken entities to response DTOs.
+func PreviewTokensToResponses(tokens []*entity.PreviewToken) []dto.PreviewTokenResponse {
+	result := make([]dto.PreviewTokenResponse, len(tokens))
+	for i, t := range tokens {
+		result[i] = PreviewTokenToResponse(t)
+	}
+	return result
+}
+
+// PreviewTokenWithTokenToResponse converts a freshly created preview token and its raw token to a response DTO.
+func PreviewTokenWithTokenToResponse(t *entity.PreviewToken, token string) *dto.PreviewTokenWithTokenResponse {
+	return &dto.PreviewTokenWithTokenResponse{
+		PreviewTokenResponse: PreviewTokenToResponse(t),
+		Token:                token,
+	}
+}
+
+// CreatePreviewTokenRequestToCommand converts a create preview token request to a usecase command.
+func CreatePreviewTokenRequestToCommand(workspaceID, versionID, userID string, req dto.CreatePreviewTokenRequest) templateuc.CreatePreviewTokenCommand {
+	cmd := templateuc.CreatePreviewTokenCommand{
+		WorkspaceID: workspaceID,
+		VersionID:   versionID,
+		Label:       req.Label,
+		Watermark:   req.Watermark,
+		CreatedBy:   userID,
+	}
+	if req.ExpiresInHours != nil {
+		cmd.TTL = time.Duration(*req.ExpiresInHours) * time.Hour
+	}
+	return cmd
+}
@@ -0,0 +1,18 @@
+package mapper
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// RenderCapacityToResponse converts a render capacity snapshot to its DTO.
+func RenderCapacityToResponse(c entity.RenderCapacity) *dto.RenderCapacityResponse {
+	return &dto.RenderCapacityResponse{
+		QueueDepth:    c.QueueDepth,
+		ActiveRenders: c.ActiveRenders,
+		MaxConcurrent: c.MaxConcurrent,
+		Utilization:   c.Utilization,
+		AverageWaitMs: c.AverageWaitMs,
+		RejectedTotal: c.RejectedTotal,
+	}
+}
@@ -0,0 +1,31 @@
+package mapper
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// RenderEstimateToResponse converts a render estimate to its DTO.
+func RenderEstimateToResponse(e *entity.RenderEstimate) *dto.RenderEstimateResponse {
+	resp := &dto.RenderEstimateResponse{
+		VersionID:        e.VersionID,
+		TemplateID:       e.TemplateID,
+		PageCount:        e.PageCount,
+		CompileTimeMs:    e.CompileTimeMs,
+		CompileTimeClass: string(e.CompileTimeClass),
+		Cost:             e.Cost,
+		DryRun:           e.DryRun,
+		Warnings:         e.Warnings,
+	}
+	if s := e.Statistics; s != nil {
+		resp.Statistics = &dto.RenderStatisticsResponse{
+			Renders:        s.Renders,
+			AvgPageCount:   s.AvgPageCount,
+			MaxPageCount:   s.MaxPageCount,
+			AvgDurationMs:  s.AvgDurationMs,
+			P95DurationMs:  s.P95DurationMs,
+			LastRenderedAt: s.LastRenderedAt,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,44 @@
+package mapper
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// RenderFailureToResponse converts a captured render failure to its DTO.
+func RenderFailureToResponse(f *entity.RenderFailure) dto.RenderFailureResponse {
+	resp := dto.RenderFailureResponse{
+		ID:               f.ID,
+		TenantCode:       f.TenantCode,
+		WorkspaceCode:    f.WorkspaceCode,
+		DocumentTypeCode: f.DocumentTypeCode,
+		VersionID:        f.VersionID,
+		TemplateID:       f.TemplateID,
+		Environment:      string(f.Environment),
+		Error:            f.Error,
+		SourceBytes:      f.SourceBytes,
+		SourceTruncated:  f.SourceTruncated,
+		Assets:           make([]dto.RenderFailureAssetResponse, 0, len(f.Assets)),
+		CreatedAt:        f.CreatedAt,
+		ExpiresAt:        f.ExpiresAt,
+	}
+	for _, a := range f.Assets {
+		resp.Assets = append(resp.Assets, dto.RenderFailureAssetResponse{
+			Name:      a.Name,
+			URL:       a.URL,
+			SizeBytes: a.SizeBytes,
+			SHA256:    a.SHA256,
+			Missing:   a.Missing,
+		})
+	}
+	return resp
+}
+
+// RenderFailuresToResponse converts a list of captured render failures to their DTO.
+func RenderFailuresToResponse(failures []*entity.RenderFailure) *dto.ListRenderFailuresResponse {
+	items := make([]dto.RenderFailureResponse, 0, len(failures))
+	for _, f := range failures {
+		items = append(items, RenderFailureToResponse(f))
+	}
+	return &dto.ListRenderFailuresResponse{Items: items}
+}
@@ -0,0 +1,35 @@
+package mapper
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// RenderJobToResponse converts a render job state to a response DTO.
+func RenderJobToResponse(state *templateuc.RenderJobState) *dto.RenderJobResponse {
+	j := state.Job
+	resp := &dto.RenderJobResponse{
+		ID:               j.ID,
+		Status:           string(j.Status),
+		DocumentTypeCode: j.DocumentTypeCode,
+		VersionID:        j.VersionID,
+		Environment:      string(j.Environment),
+		Attempts:         j.Attempts,
+		Error:            j.Error,
+		Filename:         j.Filename,
+		SizeBytes:        j.SizeBytes,
+		PageCount:        j.PageCount,
+		Warnings:         RenderWarningsToResponse(j.Warnings),
+		ResultURL:        state.ResultURL,
+		CreatedAt:        j.CreatedAt,
+		StartedAt:        j.StartedAt,
+		FinishedAt:       j.FinishedAt,
+		ExpiresAt:        j.ExpiresAt,
+	}
+	if j.ErrorCode != nil {
+		code := string(*j.ErrorCode)
+		retryable := j.ErrorCode.Retryable()
+		resp.ErrorCode, resp.Retryable = &code, &retryable
+	}
+	return resp
+}
@@ -0,0 +1,156 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// ImpositionRequestToOptions converts an imposition request to renderer options.
+// Returns nil when the request has no imposition.
+func ImpositionRequestToOptions(req *dto.ImpositionRequest) *port.ImpositionOptions {
+	if req == nil {
+		return nil
+	}
+	return &port.ImpositionOptions{
+		Layout:       port.ImpositionLayout(req.Layout),
+		TrimWidthMM:  req.TrimWidthMM,
+		TrimHeightMM: req.TrimHeightMM,
+		BleedMM:      req.BleedMM,
+		CropMarks:    req.CropMarks,
+	}
+}
+
+// LayoutRequestToParams converts a layout request to renderer layout parameters.
+// Returns nil when the request has no layout.
+func LayoutRequestToParams(req *dto.LayoutRequest) *port.LayoutParams {
+	if req == nil {
+		return nil
+	}
+	return &port.LayoutParams{
+		PaperSize:    req.PaperSize,
+		MarginPreset: req.MarginPreset,
+		FontScale:    req.FontScale,
+	}
+}
+
+// OverlaysRequestToOverlays converts an overlays request to document overlays.
+// Returns nil when the request has no overlays.
+func OverlaysRequestToOverlays(req *dto.OverlaysRequest) *portabledoc.Overlays {
+	if req == nil {
+		return nil
+	}
+	overlays := &portabledoc.Overlays{Watermark: req.Watermark}
+	if req.Stamp != nil {
+		overlays.Stamp = &portabledoc.OverlayStamp{
+			Text:     req.Stamp.Text,
+			Position: req.Stamp.Position,
+			Color:    req.Stamp.Color,
+		}
+	}
+	if req.Bates != nil {
+		overlays.Bates = &portabledoc.BatesNumbering{
+			Prefix:   req.Bates.Prefix,
+			Start:    req.Bates.Start,
+			Digits:   req.Bates.Digits,
+			Position: req.Bates.Position,
+		}
+	}
+	return overlays
+}
+
+// RenderWarningsToResponse converts render warnings to response DTOs. Returns nil when there are none.
+func RenderWarningsToResponse(warnings []entity.RenderWarning) []dto.RenderWarningResponse {
+	if len(warnings) == 0 {
+		return nil
+	}
+	result := make([]dto.RenderWarningResponse, len(warnings))
+	for i, w := range warnings {
+		result[i] = dto.RenderWarningResponse{
+			Code:       string(w.Code),
+			Message:    w.Message,
+			Script:     w.Script,
+			Characters: w.Characters,
+			Source:     w.Source,
+		}
+	}
+	return result
+}
+
+// RenderErrorDetailsToResponse converts the located causes of a render failure to response DTOs.
+// Returns nil when there are none.
+func RenderErrorDetailsToResponse(details []entity.RenderErrorDetail) []dto.RenderErrorDetailResponse {
+	if len(details) == 0 {
+		return nil
+	}
+	result := make([]dto.RenderErrorDetailResponse, len(details))
+	for i, d := range details {
+		result[i] = dto.RenderErrorDetailResponse{
+			Reason:     string(d.Reason),
+			Message:    d.Message,
+			Injectable: d.Injectable,
+			NodePaths:  d.NodePaths,
+			Line:       d.Line,
+			Column:     d.Column,
+			URL:        d.URL,
+		}
+	}
+	return result
+}
+
+// InjectorTimingsToResponse converts injector timings to response DTOs.
+func InjectorTimingsToResponse(timings []entity.InjectorTiming) []dto.InjectorTimingResponse {
+	if len(timings) == 0 {
+		return nil
+	}
+	result := make([]dto.InjectorTimingResponse, len(timings))
+	for i, t := range timings {
+		result[i] = dto.InjectorTimingResponse{
+			Code:       t.Code,
+			StartMs:    durationMs(t.Start),
+			DurationMs: durationMs(t.Duration),
+			Failed:     t.Failed,
+		}
+	}
+	return result
+}
+
+// CurrencyRatesToResponse converts the exchange rates of a render to response DTOs.
+func CurrencyRatesToResponse(rates []entity.CurrencyRate) []dto.CurrencyRateResponse {
+	if len(rates) == 0 {
+		return nil
+	}
+	result := make([]dto.CurrencyRateResponse, len(rates))
+	for i, r := range rates {
+		result[i] = dto.CurrencyRateResponse{
+			Provider: r.Provider,
+			From:     r.From,
+			To:       r.To,
+			Rate:     r.Rate,
+			Date:     r.Date.Format(time.DateOnly),
+		}
+	}
+	return result
+}
+
+// durationMs returns d in milliseconds, to the microsecond.
+func durationMs(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// PersistedRenderToResponse converts a render persisted to object storage to a response DTO.
+func PersistedRenderToResponse(persisted *templateuc.PersistedRender, warnings []entity.RenderWarning) *dto.PersistedRenderResponse {
+	return &dto.PersistedRenderResponse{
+		Key:       persisted.Key,
+		URL:       persisted.URL,
+		ExpiresAt: persisted.ExpiresAt,
+		Filename:  persisted.Filename,
+		SizeBytes: persisted.SizeBytes,
+		PageCount: persisted.PageCount,
+		Warnings:  RenderWarningsToResponse(warnings),
+	}
+}
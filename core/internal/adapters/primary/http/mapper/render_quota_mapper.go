@@ -0,0 +1,71 @@
+package mapper
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	platformuc "github.com/rendis/pdf-forge/core/internal/core/usecase/platform"
+)
+
+// RenderQuotaLimitsToResponse converts render limits to their DTO.
+func RenderQuotaLimitsToResponse(l entity.RenderQuotaLimits) dto.RenderQuotaLimitsResponse {
+	return dto.RenderQuotaLimitsResponse{
+		RendersPerMinute: l.RendersPerMinute,
+		PagesPerMonth:    l.PagesPerMonth,
+	}
+}
+
+// RenderQuotaToResponse converts a render quota to its DTO.
+func RenderQuotaToResponse(q *entity.RenderQuota) *dto.RenderQuotaResponse {
+	if q == nil {
+		return nil
+	}
+	return &dto.RenderQuotaResponse{
+		Scope:            string(q.Scope()),
+		TenantID:         q.TenantID,
+		TenantCode:       q.TenantCode,
+		WorkspaceID:      q.WorkspaceID,
+		WorkspaceCode:    q.WorkspaceCode,
+		RendersPerMinute: q.RendersPerMinute,
+		PagesPerMonth:    q.PagesPerMonth,
+		UpdatedBy:        q.UpdatedBy,
+		UpdatedAt:        q.UpdatedAt,
+	}
+}
+
+// RenderQuotasToListResponse converts the render quotas and their defaults to the list DTO.
+func RenderQuotasToListResponse(quotas []*entity.RenderQuota, tenant, workspace entity.RenderQuotaLimits) *dto.RenderQuotaListResponse {
+	resp := &dto.RenderQuotaListResponse{
+		TenantDefaults:    RenderQuotaLimitsToResponse(tenant),
+		WorkspaceDefaults: RenderQuotaLimitsToResponse(workspace),
+		Quotas:            make([]*dto.RenderQuotaResponse, 0, len(quotas)),
+	}
+	for _, q := range quotas {
+		resp.Quotas = append(resp.Quotas, RenderQuotaToResponse(q))
+	}
+	return resp
+}
+
+// RenderQuotaStatusToResponse converts a render quota status to its DTO.
+func RenderQuotaStatusToResponse(s *entity.RenderQuotaStatus) *dto.RenderQuotaStatusResponse {
+	return &dto.RenderQuotaStatusResponse{
+		Scope:          string(s.Scope),
+		TenantID:       s.TenantID,
+		WorkspaceID:    s.WorkspaceID,
+		Limits:         RenderQuotaLimitsToResponse(s.Limits),
+		Override:       RenderQuotaToResponse(s.Override),
+		Month:          s.Month.Format("2006-01"),
+		RendersInMonth: s.RendersInMonth,
+		PagesInMonth:   s.PagesInMonth,
+	}
+}
+
+// SetRenderQuotaRequestToCommand converts a set render quota request to a usecase command.
+func SetRenderQuotaRequestToCommand(req dto.SetRenderQuotaRequest, tenantID string, workspaceID *string, updatedBy string) platformuc.SetRenderQuotaCommand {
+	return platformuc.SetRenderQuotaCommand{
+		TenantID:         tenantID,
+		WorkspaceID:      workspaceID,
+		RendersPerMinute: req.RendersPerMinute,
+		PagesPerMonth:    req.PagesPerMonth,
+		UpdatedBy:        updatedBy,
+	}
+}
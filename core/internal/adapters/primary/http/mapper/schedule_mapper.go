@@ -0,0 +1,67 @@
+package mapper
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// WorkspaceScheduleToResponse converts a workspace schedule to a response DTO.
+func WorkspaceScheduleToResponse(s *entity.WorkspaceSchedule) *dto.WorkspaceScheduleResponse {
+	upcoming := make([]*dto.ScheduledItemResponse, len(s.Upcoming))
+	for i, item := range s.Upcoming {
+		upcoming[i] = &dto.ScheduledItemResponse{
+			VersionID:     item.VersionID,
+			VersionName:   item.VersionName,
+			VersionNumber: item.VersionNumber,
+			TemplateID:    item.TemplateID,
+			TemplateTitle: item.TemplateTitle,
+			Operation:     string(item.Operation),
+			ScheduledFor:  item.ScheduledFor,
+			FailedAt:      item.FailedAt,
+			FailureReason: item.FailureReason,
+		}
+	}
+	return &dto.WorkspaceScheduleResponse{
+		Upcoming:   upcoming,
+		RecentRuns: ScheduledRunsToResponses(s.RecentRuns),
+		Failures:   ScheduledRunsToResponses(s.Failures),
+		Timezone:   s.Timezone,
+	}
+}
+
+// ScheduledRunToResponse converts a scheduled run to a response DTO.
+func ScheduledRunToResponse(r *entity.ScheduledRun) *dto.ScheduledRunResponse {
+	return &dto.ScheduledRunResponse{
+		ID:            r.ID,
+		VersionID:     r.VersionID,
+		VersionName:   r.VersionName,
+		TemplateID:    r.TemplateID,
+		TemplateTitle: r.TemplateTitle,
+		Operation:     string(r.Operation),
+		ScheduledFor:  r.ScheduledFor,
+		Status:        string(r.Status),
+		Error:         r.Error,
+		TriggeredBy:   r.TriggeredBy,
+		RanAt:         r.RanAt,
+	}
+}
+
+// ScheduledRunsToResponses converts scheduled runs to response DTOs.
+func ScheduledRunsToResponses(runs []*entity.ScheduledRun) []*dto.ScheduledRunResponse {
+	result := make([]*dto.ScheduledRunResponse, len(runs))
+	for i, r := range runs {
+		result[i] = ScheduledRunToResponse(r)
+	}
+	return result
+}
+
+// RetryScheduledOperationRequestToCommand converts a retry request to a usecase command.
+func RetryScheduledOperationRequestToCommand(workspaceID, versionID, userID string, req dto.RetryScheduledOperationRequest) templateuc.RetryScheduledOperationCommand {
+	return templateuc.RetryScheduledOperationCommand{
+		WorkspaceID: workspaceID,
+		VersionID:   versionID,
+		Operation:   entity.ScheduledOperation(req.Operation),
+		UserID:      userID,
+	}
+}
@@ -0,0 +1,66 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// SystemStatsToResponse converts the platform stats to their DTO.
+func SystemStatsToResponse(s *entity.SystemStats) *dto.SystemStatsResponse {
+	resp := &dto.SystemStatsResponse{
+		Counts: dto.EntityCountsResponse{
+			Tenants:           s.Counts.Tenants,
+			ActiveTenants:     s.Counts.ActiveTenants,
+			Workspaces:        s.Counts.Workspaces,
+			SandboxWorkspaces: s.Counts.SandboxWorkspaces,
+			Templates:         s.Counts.Templates,
+			PublishedVersions: s.Counts.PublishedVersions,
+			Users:             s.Counts.Users,
+		},
+		Renders: dto.RenderTotalsResponse{
+			Total:     s.Renders,
+			Failures:  s.Failures,
+			ErrorRate: s.ErrorRate,
+			Days:      make([]dto.RenderDayStatsResponse, 0, len(s.RenderDays)),
+		},
+		Queues: dto.QueueHealthResponse{
+			OutboxPending:         s.Queues.OutboxPending,
+			OutboxFailed:          s.Queues.OutboxFailed,
+			OutboxOldestPendingAt: s.Queues.OutboxOldestPendingAt,
+			DocumentIndexPending:  s.Queues.DocumentIndexPending,
+			ScheduledVersions:     s.Queues.ScheduledVersions,
+			Render:                *RenderCapacityToResponse(s.Queues.Render),
+		},
+		Storage: dto.StorageUsageResponse{
+			TotalBytes:          s.Storage.TotalBytes(),
+			AssetBytes:          s.Storage.AssetBytes,
+			HostedDocumentBytes: s.Storage.HostedDocumentBytes,
+			Days:                make([]dto.StorageDayUsageResponse, 0, len(s.Storage.Days)),
+		},
+		GeneratedAt: s.GeneratedAt,
+	}
+
+	for _, d := range s.RenderDays {
+		day := dto.RenderDayStatsResponse{
+			Date:      d.Day.Format(time.DateOnly),
+			Renders:   d.Renders,
+			Failures:  d.Failures,
+			ErrorRate: d.ErrorRate(),
+			Pages:     d.Pages,
+		}
+		if succeeded := d.Renders - d.Failures; succeeded > 0 {
+			day.AvgDurationMs = float64(d.DurationMs) / float64(succeeded)
+		}
+		resp.Renders.Days = append(resp.Renders.Days, day)
+	}
+	for _, d := range s.Storage.Days {
+		resp.Storage.Days = append(resp.Storage.Days, dto.StorageDayUsageResponse{
+			Date:       d.Day.Format(time.DateOnly),
+			AddedBytes: d.AddedBytes,
+			TotalBytes: d.TotalBytes,
+		})
+	}
+	return resp
+}
@@ -36,6 +36,7 @@ func (m *TemplateMapper) ToResponse(template *entity.Template) *dto.TemplateResp
 		DocumentTypeID:  template.DocumentTypeID,
 		Title:           template.Title,
 		IsPublicLibrary: template.IsPublicLibrary,
+		Revision:        template.Revision,
 		CreatedAt:       template.CreatedAt,
 		UpdatedAt:       template.UpdatedAt,
 	}
@@ -60,6 +61,7 @@ func (m *TemplateMapper) ToListItemResponse(item *entity.TemplateListItem) *dto.
 		ScheduledVersionCount:  item.ScheduledVersionCount,
 		PublishedVersionNumber: item.PublishedVersionNumber,
 		Tags:                   m.toSimpleTagList(item.Tags),
+		Revision:               item.Revision,
 		CreatedAt:              item.CreatedAt,
 		UpdatedAt:              item.UpdatedAt,
 	}
@@ -183,10 +185,11 @@ func (m *TemplateMapper) ToCreateCommand(req *dto.CreateTemplateRequest, workspa
 // ToUpdateCommand converts an update request to a command.
 func (m *TemplateMapper) ToUpdateCommand(id string, req *dto.UpdateTemplateRequest) templateuc.UpdateTemplateCommand {
 	return templateuc.UpdateTemplateCommand{
-		ID:              id,
-		Title:           req.Title,
-		FolderID:        req.FolderID,
-		IsPublicLibrary: req.IsPublicLibrary,
+		ID:               id,
+		Title:            req.Title,
+		FolderID:         req.FolderID,
+		IsPublicLibrary:  req.IsPublicLibrary,
+		ExpectedRevision: req.Revision,
 	}
 }
 
@@ -221,3 +224,99 @@ func (m *TemplateMapper) ToFilters(req *dto.TemplateFiltersRequest) port.Templat
 
 	return filters
 }
+
+// ToImportBundleCommand converts an import bundle request to a command.
+func (m *TemplateMapper) ToImportBundleCommand(req *dto.ImportTemplateBundleRequest, workspaceID, userID string) templateuc.ImportTemplateBundleCommand {
+	return templateuc.ImportTemplateBundleCommand{
+		WorkspaceID: workspaceID,
+		Bundle:      req.Bundle,
+		Title:       req.Title,
+		FolderID:    req.FolderID,
+		ImportedBy:  userID,
+	}
+}
+
+// ToImportBundleResponse converts an import bundle result to a response.
+func (m *TemplateMapper) ToImportBundleResponse(result *templateuc.ImportTemplateBundleResult) *dto.ImportTemplateBundleResponse {
+	versions := make([]*dto.TemplateVersionResponse, 0, len(result.Versions))
+	for _, v := range result.Versions {
+		versions = append(versions, m.versionMapper.ToResponse(v))
+	}
+	report := result.Report
+	return &dto.ImportTemplateBundleResponse{
+		Template: m.ToResponse(result.Template),
+		Versions: versions,
+		Report: &dto.TemplateBundleReportResponse{
+			CreatedInjectables: nonNilStrings(report.CreatedInjectables),
+			MissingInjectables: nonNilStrings(report.MissingInjectables),
+			CreatedAssets:      report.CreatedAssets,
+			ReusedAssets:       report.ReusedAssets,
+			StorageReferences:  nonNilStrings(report.StorageReferences),
+		},
+	}
+}
+
+// ToInstallLibraryCommand converts an install library template request to a command.
+func (m *TemplateMapper) ToInstallLibraryCommand(sourceID string, req *dto.InstallLibraryTemplateRequest, workspaceID, userID string) templateuc.InstallLibraryTemplateCommand {
+	return templateuc.InstallLibraryTemplateCommand{
+		WorkspaceID:      workspaceID,
+		SourceTemplateID: sourceID,
+		Title:            req.Title,
+		FolderID:         req.FolderID,
+		InstalledBy:      userID,
+	}
+}
+
+// ToInstallLibraryResponse converts an installed library template to a response.
+func (m *TemplateMapper) ToInstallLibraryResponse(result *templateuc.InstallLibraryTemplateResult) *dto.InstallLibraryTemplateResponse {
+	link := result.Link
+	return &dto.InstallLibraryTemplateResponse{
+		Template: m.ToResponse(result.Template),
+		Version:  m.versionMapper.ToResponse(result.Version),
+		Link: &dto.TemplateLibraryLinkResponse{
+			TemplateID:        link.TemplateID,
+			SourceTemplateID:  link.SourceTemplateID,
+			BaseVersionNumber: link.BaseVersionNumber,
+			CopiedAssets:      len(link.AssetMap),
+			InstalledAt:       link.InstalledAt,
+			SyncedAt:          link.SyncedAt,
+		},
+	}
+}
+
+// ToLibraryUpdatePreviewResponse converts a library update preview to a response.
+func (m *TemplateMapper) ToLibraryUpdatePreviewResponse(preview *entity.LibraryUpdatePreview) *dto.LibraryUpdatePreviewResponse {
+	conflicts := make([]*dto.LibraryMergeConflictResponse, len(preview.Conflicts))
+	for i, c := range preview.Conflicts {
+		conflicts[i] = &dto.LibraryMergeConflictResponse{
+			Path:     c.Path,
+			Base:     c.Base,
+			Local:    c.Local,
+			Upstream: c.Upstream,
+		}
+	}
+	return &dto.LibraryUpdatePreviewResponse{
+		TemplateID:            preview.TemplateID,
+		SourceTemplateID:      preview.SourceTemplateID,
+		BaseVersionNumber:     preview.BaseVersionNumber,
+		UpToDate:              preview.UpToDate,
+		UpstreamVersionID:     preview.UpstreamVersionID,
+		UpstreamVersionNumber: preview.UpstreamVersionNumber,
+		LocalVersionID:        preview.LocalVersionID,
+		LocalVersionNumber:    preview.LocalVersionNumber,
+		UpstreamChanges:       m.versionMapper.ToDiffResponse(preview.UpstreamChanges),
+		LocalChanges:          m.versionMapper.ToDiffResponse(preview.LocalChanges),
+		Conflicts:             conflicts,
+		Merged:                preview.Merged,
+	}
+}
+
+// ToPullLibraryUpdateCommand converts a pull library update request to a command.
+func (m *TemplateMapper) ToPullLibraryUpdateCommand(templateID string, req *dto.PullLibraryUpdateRequest, workspaceID, userID string) templateuc.PullLibraryUpdateCommand {
+	return templateuc.PullLibraryUpdateCommand{
+		WorkspaceID: workspaceID,
+		TemplateID:  templateID,
+		Strategy:    entity.LibraryMergeStrategy(req.Strategy),
+		PulledBy:    userID,
+	}
+}
@@ -0,0 +1,53 @@
+package mapper
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// TemplateSLAStatusToResponse converts an SLA and its current window to a response DTO.
+func TemplateSLAStatusToResponse(status *entity.TemplateSLAStatus) *dto.TemplateSLAResponse {
+	sla := status.SLA
+	violations, judged := sla.Check(status.Window)
+
+	resp := &dto.TemplateSLAResponse{
+		TemplateID:     sla.TemplateID,
+		MaxRenderMs:    sla.MaxRenderMs,
+		MaxFailureRate: sla.MaxFailureRate,
+		WindowMinutes:  sla.WindowMinutes,
+		MinRenders:     sla.MinRenders,
+		BreachedAt:     sla.BreachedAt,
+		UpdatedBy:      sla.UpdatedBy,
+		UpdatedAt:      sla.UpdatedAt,
+		Window: dto.TemplateRenderWindowResponse{
+			Renders:     status.Window.Renders,
+			Failures:    status.Window.Failures,
+			AvgRenderMs: status.Window.AvgRenderMs(),
+			FailureRate: status.Window.FailureRate(),
+			Judged:      judged,
+		},
+		Violations: make([]dto.TemplateSLAViolationResponse, len(violations)),
+	}
+	for i, v := range violations {
+		resp.Violations[i] = dto.TemplateSLAViolationResponse{
+			Metric:    string(v.Metric),
+			Threshold: v.Threshold,
+			Actual:    v.Actual,
+		}
+	}
+	return resp
+}
+
+// TemplateSLARequestToCommand converts a set SLA request to a usecase command.
+func TemplateSLARequestToCommand(templateID, workspaceID, updatedBy string, req dto.TemplateSLARequest) templateuc.SetTemplateSLACommand {
+	return templateuc.SetTemplateSLACommand{
+		TemplateID:     templateID,
+		WorkspaceID:    workspaceID,
+		MaxRenderMs:    req.MaxRenderMs,
+		MaxFailureRate: req.MaxFailureRate,
+		WindowMinutes:  req.WindowMinutes,
+		MinRenders:     req.MinRenders,
+		UpdatedBy:      updatedBy,
+	}
+}
@@ -25,21 +25,44 @@ func (m *TemplateVersionMapper) ToResponse(version *entity.TemplateVersion) *dto
 	}
 
 	return &dto.TemplateVersionResponse{
-		ID:                 version.ID,
-		TemplateID:         version.TemplateID,
-		VersionNumber:      version.VersionNumber,
-		Name:               version.Name,
-		Description:        version.Description,
-		Status:             string(version.Status),
-		ScheduledPublishAt: version.ScheduledPublishAt,
-		ScheduledArchiveAt: version.ScheduledArchiveAt,
-		PublishedAt:        version.PublishedAt,
-		ArchivedAt:         version.ArchivedAt,
-		PublishedBy:        version.PublishedBy,
-		ArchivedBy:         version.ArchivedBy,
-		CreatedBy:          version.CreatedBy,
-		CreatedAt:          version.CreatedAt,
-		UpdatedAt:          version.UpdatedAt,
+		ID:                    version.ID,
+		TemplateID:            version.TemplateID,
+		VersionNumber:         version.VersionNumber,
+		Name:                  version.Name,
+		Description:           version.Description,
+		Status:                string(version.Status),
+		ScheduledPublishAt:    version.ScheduledPublishAt,
+		ScheduledArchiveAt:    version.ScheduledArchiveAt,
+		PublishedAt:           version.PublishedAt,
+		ArchivedAt:            version.ArchivedAt,
+		PublishedBy:           version.PublishedBy,
+		ArchivedBy:            version.ArchivedBy,
+		CreatedBy:             version.CreatedBy,
+		ScheduleFailedAt:      version.ScheduleFailedAt,
+		ScheduleFailureReason: version.ScheduleFailureReason,
+		Revision:              version.Revision,
+		CreatedAt:             version.CreatedAt,
+		UpdatedAt:             version.UpdatedAt,
+		ReleaseNotes:          m.ToReleaseNotesResponse(version.ReleaseNotes),
+	}
+}
+
+// ToReleaseNotesResponse converts version release notes to a response DTO.
+func (m *TemplateVersionMapper) ToReleaseNotesResponse(notes *entity.VersionReleaseNotes) *dto.VersionReleaseNotesResponse {
+	if notes == nil {
+		return nil
+	}
+	ticketIDs := notes.TicketIDs
+	if ticketIDs == nil {
+		ticketIDs = []string{}
+	}
+	return &dto.VersionReleaseNotesResponse{
+		VersionID: notes.VersionID,
+		Body:      notes.Body,
+		TicketIDs: ticketIDs,
+		AuthorID:  notes.AuthorID,
+		CreatedAt: notes.CreatedAt,
+		UpdatedAt: notes.UpdatedAt,
 	}
 }
 
@@ -79,10 +102,66 @@ func (m *TemplateVersionMapper) ToDetailResponse(details *entity.TemplateVersion
 	if details.Injectables != nil {
 		resp.Injectables = m.injectableMapper.VersionInjectablesToResponse(details.Injectables)
 	}
+	resp.Changelog = m.ToChangelogResponse(details.Changelog)
 
 	return resp
 }
 
+// ToChangelogResponse converts a version changelog to a response DTO.
+func (m *TemplateVersionMapper) ToChangelogResponse(changelog *entity.VersionChangelog) *dto.VersionChangelogResponse {
+	if changelog == nil {
+		return nil
+	}
+	return &dto.VersionChangelogResponse{
+		PreviousVersionID:     changelog.PreviousVersionID,
+		PreviousVersionNumber: changelog.PreviousVersionNumber,
+		SectionsAdded:         changelog.SectionsAdded,
+		SectionsRemoved:       changelog.SectionsRemoved,
+		InjectablesAdded:      changelog.InjectablesAdded,
+		InjectablesRemoved:    changelog.InjectablesRemoved,
+		PageConfigChanges:     changelog.PageConfigChanges,
+		Summary:               changelog.Summary,
+	}
+}
+
+// ToDiffResponse converts a version diff to a response DTO.
+func (m *TemplateVersionMapper) ToDiffResponse(diff *entity.VersionDiff) *dto.VersionDiffResponse {
+	nodes := make([]*dto.NodeChangeResponse, len(diff.Nodes))
+	for i, n := range diff.Nodes {
+		nodes[i] = &dto.NodeChangeResponse{
+			Kind:     string(n.Kind),
+			Path:     n.Path,
+			NodeType: n.NodeType,
+			Fields:   n.Fields,
+			Before:   n.Before,
+			After:    n.After,
+		}
+	}
+	fields := make([]*dto.FieldChangeResponse, len(diff.PageConfigChanges))
+	for i, f := range diff.PageConfigChanges {
+		fields[i] = &dto.FieldChangeResponse{Field: f.Field, From: f.From, To: f.To}
+	}
+	return &dto.VersionDiffResponse{
+		FromVersionID:      diff.FromVersionID,
+		FromVersionNumber:  diff.FromVersionNumber,
+		ToVersionID:        diff.ToVersionID,
+		ToVersionNumber:    diff.ToVersionNumber,
+		Nodes:              nodes,
+		InjectablesAdded:   diff.InjectablesAdded,
+		InjectablesRemoved: diff.InjectablesRemoved,
+		PageConfigChanges:  fields,
+	}
+}
+
+// ToContentResponse converts a version to a detail response carrying its content but no injectables.
+// Used for the current state returned on revision conflicts so the editor can merge without reloading.
+func (m *TemplateVersionMapper) ToContentResponse(version *entity.TemplateVersion) *dto.TemplateVersionDetailResponse {
+	if version == nil {
+		return nil
+	}
+	return m.ToDetailResponse(&entity.TemplateVersionWithDetails{TemplateVersion: *version})
+}
+
 // ToDetailResponseList converts a list of template versions with details to response DTOs.
 func (m *TemplateVersionMapper) ToDetailResponseList(details []*entity.TemplateVersionWithDetails) []*dto.TemplateVersionDetailResponse {
 	if details == nil {
@@ -143,6 +222,67 @@ func (m *TemplateVersionMapper) ToUpdateCommand(versionID string, req *dto.Updat
 		Name:             req.Name,
 		Description:      req.Description,
 		ContentStructure: req.ContentStructure,
+		ExpectedRevision: req.Revision,
+	}
+}
+
+// ToUpdateReleaseNotesCommand converts an update release notes request to a command.
+func (m *TemplateVersionMapper) ToUpdateReleaseNotesCommand(versionID string, req *dto.UpdateReleaseNotesRequest, authorID string) templateuc.UpdateReleaseNotesCommand {
+	cmd := templateuc.UpdateReleaseNotesCommand{
+		VersionID: versionID,
+		Body:      req.Body,
+		TicketIDs: req.TicketIDs,
+	}
+	if authorID != "" {
+		cmd.AuthorID = &authorID
+	}
+	return cmd
+}
+
+// ToImportHTMLCommand converts an HTML import request to a command.
+func (m *TemplateVersionMapper) ToImportHTMLCommand(workspaceID, versionID string, req *dto.ImportHTMLRequest) templateuc.ImportTemplateCommand {
+	return templateuc.ImportTemplateCommand{
+		WorkspaceID:      workspaceID,
+		VersionID:        versionID,
+		Source:           req.HTML,
+		ExpectedRevision: req.Revision,
+	}
+}
+
+// ToImportMarkdownCommand converts a Markdown import request to a command.
+func (m *TemplateVersionMapper) ToImportMarkdownCommand(workspaceID, versionID string, req *dto.ImportMarkdownRequest) templateuc.ImportTemplateCommand {
+	return templateuc.ImportTemplateCommand{
+		WorkspaceID:      workspaceID,
+		VersionID:        versionID,
+		Source:           req.Markdown,
+		ExpectedRevision: req.Revision,
+	}
+}
+
+// ToImportDocxCommand converts a DOCX import request to a command.
+func (m *TemplateVersionMapper) ToImportDocxCommand(workspaceID, versionID string, req *dto.ImportDocxRequest) templateuc.ImportDocxCommand {
+	return templateuc.ImportDocxCommand{
+		WorkspaceID:      workspaceID,
+		VersionID:        versionID,
+		Document:         req.Document,
+		Mapping:          req.Mapping,
+		DryRun:           req.DryRun,
+		ExpectedRevision: req.Revision,
+	}
+}
+
+// ToImportTemplateResponse converts an import result to a response DTO.
+func (m *TemplateVersionMapper) ToImportTemplateResponse(result *templateuc.ImportResult) *dto.ImportTemplateResponse {
+	report := result.Report
+	return &dto.ImportTemplateResponse{
+		Version: m.ToResponse(result.Version),
+		Report: &dto.TemplateImportReportResponse{
+			MappedPlaceholders:   report.MappedPlaceholders,
+			UnmappedPlaceholders: report.UnmappedPlaceholders,
+			UnsupportedElements:  report.UnsupportedElements,
+			Warnings:             report.Warnings,
+			Lossless:             report.Lossless(),
+		},
 	}
 }
 
@@ -158,16 +298,20 @@ func (m *TemplateVersionMapper) ToAddInjectableCommand(versionID string, req *dt
 
 // ToSchedulePublishCommand converts a schedule publish request to a command.
 func (m *TemplateVersionMapper) ToSchedulePublishCommand(versionID string, req *dto.SchedulePublishRequest) templateuc.SchedulePublishCommand {
+	publishAt, local := req.PublishAt.Parse()
 	return templateuc.SchedulePublishCommand{
 		VersionID: versionID,
-		PublishAt: req.PublishAt,
+		PublishAt: publishAt,
+		Local:     local,
 	}
 }
 
 // ToScheduleArchiveCommand converts a schedule archive request to a command.
 func (m *TemplateVersionMapper) ToScheduleArchiveCommand(versionID string, req *dto.ScheduleArchiveRequest) templateuc.ScheduleArchiveCommand {
+	archiveAt, local := req.ArchiveAt.Parse()
 	return templateuc.ScheduleArchiveCommand{
 		VersionID: versionID,
-		ArchiveAt: req.ArchiveAt,
+		ArchiveAt: archiveAt,
+		Local:     local,
 	}
 }
@@ -0,0 +1,30 @@
+package mapper
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	accessuc "github.com/rendis/pdf-forge/core/internal/core/usecase/access"
+)
+
+// UserPreferencesToResponse converts user preferences to a response DTO.
+func UserPreferencesToResponse(p *entity.UserPreferences) *dto.UserPreferencesResponse {
+	return &dto.UserPreferencesResponse{
+		Locale:             p.Locale,
+		Timezone:           p.Timezone,
+		DefaultWorkspaceID: p.DefaultWorkspaceID,
+		UpdatedAt:          p.UpdatedAt,
+	}
+}
+
+// UpdateUserPreferencesRequestToCommand converts an update preferences request to a usecase command.
+func UpdateUserPreferencesRequestToCommand(userID string, req dto.UpdateUserPreferencesRequest) accessuc.UpdatePreferencesCommand {
+	cmd := accessuc.UpdatePreferencesCommand{
+		UserID:   userID,
+		Locale:   req.Locale,
+		Timezone: req.Timezone,
+	}
+	if req.DefaultWorkspaceID != nil && *req.DefaultWorkspaceID != "" {
+		cmd.DefaultWorkspaceID = req.DefaultWorkspaceID
+	}
+	return cmd
+}
@@ -0,0 +1,51 @@
+package mapper
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	cataloguc "github.com/rendis/pdf-forge/core/internal/core/usecase/catalog"
+)
+
+// WorkspaceFontToResponse converts a WorkspaceFont entity to a response DTO.
+func WorkspaceFontToResponse(f *entity.WorkspaceFont) dto.WorkspaceFontResponse {
+	return dto.WorkspaceFontResponse{
+		ID:        f.ID,
+		Family:    f.Family,
+		Weight:    f.Weight,
+		Style:     string(f.Style),
+		Format:    string(f.Format),
+		SizeBytes: f.SizeBytes,
+		SHA256:    f.SHA256,
+		CreatedBy: f.CreatedBy,
+		CreatedAt: f.CreatedAt,
+	}
+}
+
+// WorkspaceFontsToResponses converts workspace fonts to response DTOs.
+func WorkspaceFontsToResponses(fonts []*entity.WorkspaceFont) []dto.WorkspaceFontResponse {
+	result := make([]dto.WorkspaceFontResponse, len(fonts))
+	for i, f := range fonts {
+		result[i] = WorkspaceFontToResponse(f)
+	}
+	return result
+}
+
+// UploadFontResultToResponse converts a font upload result to a response DTO.
+func UploadFontResultToResponse(result *cataloguc.UploadFontResult) dto.UploadFontResponse {
+	return dto.UploadFontResponse{
+		WorkspaceFontResponse: WorkspaceFontToResponse(result.Font),
+		Duplicate:             result.Duplicate,
+	}
+}
+
+// FontFamiliesToResponses converts font families to response DTOs.
+func FontFamiliesToResponses(families []*entity.FontFamily) []dto.FontFamilyResponse {
+	result := make([]dto.FontFamilyResponse, len(families))
+	for i, family := range families {
+		result[i] = dto.FontFamilyResponse{Name: family.Name, Source: string(family.Source)}
+		for _, face := range family.Faces {
+			result[i].Faces = append(result[i].Faces, dto.FontFaceResponse{Weight: face.Weight, Style: string(face.Style)})
+		}
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+package mapper
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
+)
+
+// InvitationToResponse converts a WorkspaceInvitation entity to a response DTO.
+func InvitationToResponse(i *entity.WorkspaceInvitation) dto.InvitationResponse {
+	return dto.InvitationResponse{
+		ID:          i.ID,
+		WorkspaceID: i.WorkspaceID,
+		Email:       i.Email,
+		Role:        string(i.Role),
+		Status:      string(i.Status),
+		InvitedBy:   i.InvitedBy,
+		Expired:     i.Status == entity.InvitationStatusPending && i.IsExpired(),
+		ExpiresAt:   i.ExpiresAt,
+		RespondedAt: i.RespondedAt,
+		CreatedAt:   i.CreatedAt,
+		UpdatedAt:   i.UpdatedAt,
+	}
+}
+
+// InvitationsToResponses converts a slice of WorkspaceInvitation entities to response DTOs.
+func InvitationsToResponses(invitations []*entity.WorkspaceInvitation) []dto.InvitationResponse {
+	result := make([]dto.InvitationResponse, len(invitations))
+	for i, inv := range invitations {
+		result[i] = InvitationToResponse(inv)
+	}
+	return result
+}
+
+// InvitationWithTokenToResponse converts a freshly issued invitation and its raw token to a response DTO.
+func InvitationWithTokenToResponse(i *entity.WorkspaceInvitation, token string) *dto.InvitationWithTokenResponse {
+	return &dto.InvitationWithTokenResponse{
+		InvitationResponse: InvitationToResponse(i),
+		Token:              token,
+	}
+}
+
+// MyInvitationsToResponses converts the current user's pending invitations to response DTOs.
+func MyInvitationsToResponses(invitations []*organizationuc.InvitationWithWorkspace) []dto.MyInvitationResponse {
+	result := make([]dto.MyInvitationResponse, len(invitations))
+	for i, inv := range invitations {
+		result[i] = dto.MyInvitationResponse{
+			InvitationResponse: InvitationToResponse(inv.WorkspaceInvitation),
+			WorkspaceName:      inv.Workspace.Name,
+			TenantID:           inv.Workspace.TenantID,
+		}
+	}
+	return result
+}
+
+// CreateInvitationRequestToCommand converts a create invitation request to a usecase command.
+func CreateInvitationRequestToCommand(workspaceID string, req dto.CreateInvitationRequest, invitedBy string) organizationuc.CreateInvitationCommand {
+	return organizationuc.CreateInvitationCommand{
+		WorkspaceID: workspaceID,
+		Email:       req.Email,
+		Role:        entity.WorkspaceRole(req.Role),
+		InvitedBy:   invitedBy,
+	}
+}
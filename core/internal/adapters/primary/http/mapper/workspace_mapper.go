@@ -1,6 +1,8 @@
 package mapper
 
 import (
+	"time"
+
 	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
 	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	"github.com/rendis/pdf-forge/core/internal/core/port"
@@ -115,3 +117,60 @@ func WorkspacesToPaginatedResponseWithRole(
 		},
 	}
 }
+
+// CreateSandboxRequestToCommand converts a CreateSandboxRequest DTO to a command.
+func CreateSandboxRequestToCommand(workspaceID string, req dto.CreateSandboxRequest, createdBy string) organizationuc.CreateSandboxCommand {
+	return organizationuc.CreateSandboxCommand{
+		SourceWorkspaceID: workspaceID,
+		TTL:               time.Duration(req.TTLHours) * time.Hour,
+		CreatedBy:         createdBy,
+	}
+}
+
+// BulkWorkspaceResultToResponse converts a bulk workspace result to a response DTO.
+func BulkWorkspaceResultToResponse(result *organizationuc.BulkWorkspaceResult) dto.BulkWorkspacesResponse {
+	failed := make([]dto.BulkWorkspaceError, len(result.Failed))
+	for i, f := range result.Failed {
+		failed[i] = dto.BulkWorkspaceError{
+			WorkspaceID: f.WorkspaceID,
+			Error:       f.Error.Error(),
+		}
+	}
+	return dto.BulkWorkspacesResponse{
+		Succeeded: result.Succeeded,
+		Failed:    failed,
+	}
+}
+
+// BulkWebhookSecretsResultToResponse converts a bulk secret rotation result to a response DTO,
+// including the new signing secrets.
+func BulkWebhookSecretsResultToResponse(result *organizationuc.BulkWebhookSecretsResult) dto.BulkWebhookSecretsResponse {
+	webhooks := make([]*dto.EventWebhookResponse, len(result.Webhooks))
+	for i, w := range result.Webhooks {
+		webhooks[i] = EventWebhookToResponseWithSecret(w)
+	}
+	return dto.BulkWebhookSecretsResponse{
+		BulkWorkspacesResponse: BulkWorkspaceResultToResponse(&result.BulkWorkspaceResult),
+		Webhooks:               webhooks,
+	}
+}
+
+// SandboxToResponse converts a sandbox creation result to a response DTO.
+func SandboxToResponse(result *organizationuc.WorkspaceSandboxResult) dto.SandboxResponse {
+	resp := dto.SandboxResponse{
+		Workspace: WorkspaceToResponse(result.Workspace),
+		ExpiresAt: result.Sandbox.ExpiresAt,
+		Cloned: dto.SandboxCloneCounts{
+			Folders:     result.Cloned.Folders,
+			Tags:        result.Cloned.Tags,
+			Injectables: result.Cloned.Injectables,
+			Templates:   result.Cloned.Templates,
+			Versions:    result.Cloned.Versions,
+			Assets:      result.Cloned.Assets,
+		},
+	}
+	if result.Sandbox.SourceWorkspaceID != nil {
+		resp.SourceWorkspaceID = *result.Sandbox.SourceWorkspaceID
+	}
+	return resp
+}
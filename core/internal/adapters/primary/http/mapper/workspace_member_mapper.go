@@ -117,3 +117,21 @@ func RemoveMemberToCommand(memberID, workspaceID, removedBy string) organization
 		RemovedBy:   removedBy,
 	}
 }
+
+// ChangeMemberStatusToCommand creates a deactivate/reactivate member command.
+func ChangeMemberStatusToCommand(memberID, workspaceID, updatedBy string) organizationuc.ChangeMemberStatusCommand {
+	return organizationuc.ChangeMemberStatusCommand{
+		MemberID:    memberID,
+		WorkspaceID: workspaceID,
+		UpdatedBy:   updatedBy,
+	}
+}
+
+// TransferOwnershipRequestToCommand converts a transfer ownership request to a usecase command.
+func TransferOwnershipRequestToCommand(workspaceID string, req dto.TransferOwnershipRequest, requestedBy string) organizationuc.TransferOwnershipCommand {
+	return organizationuc.TransferOwnershipCommand{
+		WorkspaceID:      workspaceID,
+		NewOwnerMemberID: req.MemberID,
+		RequestedBy:      requestedBy,
+	}
+}
@@ -0,0 +1,98 @@
+package mapper
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/dto"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
+)
+
+// WorkspaceSettingsToDTO converts workspace settings to a DTO.
+func WorkspaceSettingsToDTO(s *entity.WorkspaceSettings) dto.WorkspaceSettingsDTO {
+	result := dto.WorkspaceSettingsDTO{AllowedFonts: s.AllowedFonts, Timezone: s.Timezone}
+	if s.Branding != nil {
+		result.Branding = &dto.WorkspaceBrandingDTO{
+			LogoURL:      s.Branding.LogoURL,
+			PrimaryColor: s.Branding.PrimaryColor,
+			AccentColor:  s.Branding.AccentColor,
+		}
+	}
+	if s.Retention != nil {
+		result.Retention = &dto.WorkspaceRetentionDTO{RenderHistoryDays: s.Retention.RenderHistoryDays}
+	}
+	if s.RenderOptions != nil {
+		result.RenderOptions = &dto.WorkspaceRenderOptionsDTO{
+			Watermark:    s.RenderOptions.Watermark,
+			Degraded:     s.RenderOptions.Degraded,
+			UnknownNodes: string(s.RenderOptions.UnknownNodes),
+		}
+	}
+	return result
+}
+
+// WorkspaceSettingsFromDTO converts a workspace settings DTO to an entity.
+func WorkspaceSettingsFromDTO(d dto.WorkspaceSettingsDTO) entity.WorkspaceSettings {
+	result := entity.WorkspaceSettings{AllowedFonts: d.AllowedFonts, Timezone: d.Timezone}
+	if d.Branding != nil {
+		result.Branding = &entity.WorkspaceBranding{
+			LogoURL:      d.Branding.LogoURL,
+			PrimaryColor: d.Branding.PrimaryColor,
+			AccentColor:  d.Branding.AccentColor,
+		}
+	}
+	if d.Retention != nil {
+		result.Retention = &entity.WorkspaceRetention{RenderHistoryDays: d.Retention.RenderHistoryDays}
+	}
+	if d.RenderOptions != nil {
+		result.RenderOptions = &entity.WorkspaceRenderOptions{
+			Watermark:    d.RenderOptions.Watermark,
+			Degraded:     d.RenderOptions.Degraded,
+			UnknownNodes: entity.UnknownNodeMode(d.RenderOptions.UnknownNodes),
+		}
+	}
+	return result
+}
+
+// WorkspaceSettingsViewToResponse converts the settings a workspace applies to a response DTO.
+func WorkspaceSettingsViewToResponse(view *entity.WorkspaceSettingsView) *dto.WorkspaceSettingsResponse {
+	return &dto.WorkspaceSettingsResponse{
+		WorkspaceID: view.WorkspaceID,
+		Settings:    WorkspaceSettingsToDTO(&view.Settings),
+		Locked:      settingKeysToStrings(view.Locked),
+	}
+}
+
+// TenantWorkspaceDefaultsToResponse converts tenant workspace defaults to a response DTO.
+func TenantWorkspaceDefaultsToResponse(d *entity.TenantWorkspaceDefaults) *dto.TenantWorkspaceDefaultsResponse {
+	return &dto.TenantWorkspaceDefaultsResponse{
+		TenantID:  d.TenantID,
+		Settings:  WorkspaceSettingsToDTO(&d.Settings),
+		Locked:    settingKeysToStrings(d.Locked),
+		UpdatedBy: d.UpdatedBy,
+		UpdatedAt: d.UpdatedAt,
+	}
+}
+
+// TenantWorkspaceDefaultsRequestToCommand converts a set tenant workspace defaults request to a usecase command.
+func TenantWorkspaceDefaultsRequestToCommand(
+	tenantID, updatedBy string,
+	req dto.TenantWorkspaceDefaultsRequest,
+) organizationuc.UpdateTenantWorkspaceDefaultsCommand {
+	locked := make([]entity.WorkspaceSettingKey, len(req.Locked))
+	for i, key := range req.Locked {
+		locked[i] = entity.WorkspaceSettingKey(key)
+	}
+	return organizationuc.UpdateTenantWorkspaceDefaultsCommand{
+		TenantID:  tenantID,
+		Settings:  WorkspaceSettingsFromDTO(req.Settings),
+		Locked:    locked,
+		UpdatedBy: updatedBy,
+	}
+}
+
+func settingKeysToStrings(keys []entity.WorkspaceSettingKey) []string {
+	result := make([]string, len(keys))
+	for i, key := range keys {
+		result[i] = string(key)
+	}
+	return result
+}
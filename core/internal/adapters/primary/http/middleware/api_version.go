@@ -0,0 +1,243 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// API versions of the render API. The version is the path prefix (/api/v1, /api/v2); handlers
+// always implement the latest one and older versions are served through compatibility shims.
+const (
+	APIVersion1      = 1
+	APIVersion2      = 2
+	LatestAPIVersion = APIVersion2
+)
+
+const (
+	apiVersionKey = "api_version"
+	// APIVersionHeader is sent with every versioned response, and lets a client of an older path
+	// opt in to a newer version route by route.
+	APIVersionHeader = "API-Version"
+)
+
+// APIVersion creates a middleware that serves the group under an API version. A request may ask
+// for a newer version than the path with the API-Version header, up to LatestAPIVersion, to adopt
+// a changed route before moving all its calls to the new path. Older versions than the path
+// cannot be requested.
+func APIVersion(version int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		negotiated := version
+		if requested := strings.TrimPrefix(strings.TrimSpace(c.GetHeader(APIVersionHeader)), "v"); requested != "" {
+			v, err := strconv.Atoi(requested)
+			if err != nil || v < version || v > LatestAPIVersion {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+					"error": fmt.Sprintf("unsupported %s %q: this path serves versions %d to %d",
+						APIVersionHeader, requested, version, LatestAPIVersion),
+				})
+				return
+			}
+			negotiated = v
+		}
+
+		c.Set(apiVersionKey, negotiated)
+		c.Header(APIVersionHeader, strconv.Itoa(negotiated))
+		c.Next()
+	}
+}
+
+// GetAPIVersion returns the API version of the request, APIVersion1 outside versioned groups.
+func GetAPIVersion(c *gin.Context) int {
+	if v, ok := c.Get(apiVersionKey); ok {
+		if version, ok := v.(int); ok {
+			return version
+		}
+	}
+	return APIVersion1
+}
+
+// DeprecationPolicy announces the retirement of an API version or route.
+type DeprecationPolicy struct {
+	Since     time.Time // When it was deprecated; zero omits the Deprecation header
+	Sunset    time.Time // When it stops being served; zero omits the Sunset header
+	Successor string    // Path or URL of the replacement, sent as a successor-version link
+}
+
+// Deprecated creates a middleware that announces a deprecation with the Deprecation (RFC 9745),
+// Sunset (RFC 8594) and Link headers. Requests are still served after the sunset date: removing
+// the routes is left to a release, so a misconfigured date cannot take the API down.
+func Deprecated(policy DeprecationPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !policy.Since.IsZero() {
+			c.Header("Deprecation", "@"+strconv.FormatInt(policy.Since.Unix(), 10))
+		}
+		if !policy.Sunset.IsZero() {
+			c.Header("Sunset", policy.Sunset.UTC().Format(http.TimeFormat))
+		}
+		if policy.Successor != "" {
+			c.Header("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", policy.Successor))
+		}
+		c.Next()
+	}
+}
+
+// CompatShim adapts the requests and responses of a route between an older API version and the
+// version its handler implements, so a breaking DTO change does not break existing callers.
+// Request and Response work on the decoded JSON body and either may be nil.
+type CompatShim struct {
+	// Before is the version that introduced the change: requests of older versions are shimmed.
+	Before int
+	// Request upgrades an old request body to the shape the handler expects.
+	Request func(body any) (any, error)
+	// Response downgrades a response body to the shape old callers expect.
+	Response func(status int, body any) any
+}
+
+// Compat creates a middleware that applies the shims of a route to requests of the versions they
+// cover, newest shim last on requests and first on responses. Only JSON bodies are shimmed; other
+// responses, such as rendered documents, are passed through unbuffered.
+func Compat(shims ...CompatShim) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version := GetAPIVersion(c)
+		var active []CompatShim
+		for _, shim := range shims {
+			if version < shim.Before {
+				active = append(active, shim)
+			}
+		}
+		if len(active) == 0 {
+			c.Next()
+			return
+		}
+
+		if err := shimRequest(c, active); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		writer := &compatWriter{ResponseWriter: c.Writer, shims: active}
+		c.Writer = writer
+		c.Next()
+		writer.flush()
+	}
+}
+
+// shimRequest rewrites a JSON request body with the request shims.
+func shimRequest(c *gin.Context, shims []CompatShim) error {
+	hasRequestShim := false
+	for _, shim := range shims {
+		hasRequestShim = hasRequestShim || shim.Request != nil
+	}
+	// Render routes bind JSON bodies whatever their content type, so only other declared types are skipped
+	if contentType := c.ContentType(); !hasRequestShim || c.Request.Body == nil || (contentType != "" && !isJSONContentType(contentType)) {
+		return nil
+	}
+
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return fmt.Errorf("reading request body: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		c.Request.Body = io.NopCloser(bytes.NewReader(data))
+		return nil
+	}
+
+	var body any
+	if err := json.Unmarshal(data, &body); err != nil {
+		return fmt.Errorf("invalid JSON body: %w", err)
+	}
+	for _, shim := range shims {
+		if shim.Request == nil {
+			continue
+		}
+		if body, err = shim.Request(body); err != nil {
+			return err
+		}
+	}
+	if data, err = json.Marshal(body); err != nil {
+		return fmt.Errorf("encoding shimmed request body: %w", err)
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(data))
+	c.Request.ContentLength = int64(len(data))
+	return nil
+}
+
+// compatWriter buffers JSON responses so the response shims can rewrite them. The decision is
+// taken on the first write, once the handler has set the content type.
+type compatWriter struct {
+	gin.ResponseWriter
+	shims     []CompatShim
+	decided   bool
+	buffering bool
+	buf       bytes.Buffer
+}
+
+func (w *compatWriter) decide() {
+	if w.decided {
+		return
+	}
+	w.decided = true
+	w.buffering = isJSONContentType(w.Header().Get("Content-Type"))
+}
+
+// Write implements io.Writer.
+func (w *compatWriter) Write(data []byte) (int, error) {
+	w.decide()
+	if w.buffering {
+		return w.buf.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString implements io.StringWriter.
+func (w *compatWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Written reports whether the response started, buffered or not.
+func (w *compatWriter) Written() bool {
+	return w.buf.Len() > 0 || w.ResponseWriter.Written()
+}
+
+// Size returns the bytes written so far, buffered or not.
+func (w *compatWriter) Size() int {
+	if w.buffering {
+		return w.buf.Len()
+	}
+	return w.ResponseWriter.Size()
+}
+
+// flush writes the buffered response through the response shims, newest first.
+func (w *compatWriter) flush() {
+	if !w.buffering {
+		return
+	}
+	data := w.buf.Bytes()
+
+	var body any
+	if err := json.Unmarshal(data, &body); err == nil {
+		status := w.Status()
+		for i := len(w.shims) - 1; i >= 0; i-- {
+			if w.shims[i].Response != nil {
+				body = w.shims[i].Response(status, body)
+			}
+		}
+		if shimmed, err := json.Marshal(body); err == nil {
+			data = shimmed
+		}
+	}
+	_, _ = w.ResponseWriter.Write(data)
+}
+
+func isJSONContentType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(strings.ToLower(mediaType))
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// renameShim renames the "name" field of v1 bodies to "title" in v2.
+var renameShim = CompatShim{
+	Before: APIVersion2,
+	Request: func(body any) (any, error) {
+		if m, ok := body.(map[string]any); ok {
+			m["title"] = m["name"]
+			delete(m, "name")
+		}
+		return body, nil
+	},
+	Response: func(_ int, body any) any {
+		if m, ok := body.(map[string]any); ok {
+			m["name"] = m["title"]
+			delete(m, "title")
+		}
+		return body
+	},
+}
+
+func versionedEngine(version int) *gin.Engine {
+	engine := gin.New()
+	group := engine.Group("/api", APIVersion(version))
+	group.POST("/echo", Compat(renameShim), func(c *gin.Context) {
+		var body map[string]any
+		_ = c.ShouldBindJSON(&body)
+		body["version"] = GetAPIVersion(c)
+		c.JSON(http.StatusOK, body)
+	})
+	group.GET("/file", Compat(renameShim), func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/pdf", []byte("%PDF-1.7"))
+	})
+	return engine
+}
+
+func serve(engine *gin.Engine, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCompat_ShimsOlderVersions(t *testing.T) {
+	rec := serve(versionedEngine(APIVersion1), httptest.NewRequest(http.MethodPost, "/api/echo", strings.NewReader(`{"name":"Invoice"}`)))
+
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decoding %q: %v", rec.Body.String(), err)
+	}
+	if body["name"] != "Invoice" || body["title"] != nil {
+		t.Errorf("v1 body = %v, want the v1 field names", body)
+	}
+	if body["version"] != float64(APIVersion1) || rec.Header().Get(APIVersionHeader) != "1" {
+		t.Errorf("version = %v, header %q, want 1", body["version"], rec.Header().Get(APIVersionHeader))
+	}
+}
+
+func TestCompat_LatestVersionIsNotShimmed(t *testing.T) {
+	rec := serve(versionedEngine(APIVersion2), httptest.NewRequest(http.MethodPost, "/api/echo", strings.NewReader(`{"title":"Invoice"}`)))
+
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decoding %q: %v", rec.Body.String(), err)
+	}
+	if body["title"] != "Invoice" || body["name"] != nil {
+		t.Errorf("v2 body = %v, want the v2 field names", body)
+	}
+}
+
+func TestCompat_PassesNonJSONResponsesThrough(t *testing.T) {
+	rec := serve(versionedEngine(APIVersion1), httptest.NewRequest(http.MethodGet, "/api/file", nil))
+
+	if got, _ := io.ReadAll(rec.Body); string(got) != "%PDF-1.7" {
+		t.Errorf("body = %q, want the PDF bytes unchanged", got)
+	}
+}
+
+func TestAPIVersion_Negotiation(t *testing.T) {
+	tests := []struct {
+		name       string
+		requested  string
+		wantStatus int
+		wantField  string
+	}{
+		{"path version", "", http.StatusOK, "name"},
+		{"newer version", "2", http.StatusOK, "title"},
+		{"prefixed version", "v2", http.StatusOK, "title"},
+		{"unknown version", "3", http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/echo", strings.NewReader(`{"`+tt.wantField+`":"Invoice"}`))
+			if tt.requested != "" {
+				req.Header.Set(APIVersionHeader, tt.requested)
+			}
+			rec := serve(versionedEngine(APIVersion1), req)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantField != "" && !strings.Contains(rec.Body.String(), `"`+tt.wantField+`":"Invoice"`) {
+				t.Errorf("body = %s, want field %s", rec.Body.String(), tt.wantField)
+			}
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/echo", strings.NewReader(`{}`))
+	req.Header.Set(APIVersionHeader, "1")
+	if rec := serve(versionedEngine(APIVersion2), req); rec.Code != http.StatusBadRequest {
+		t.Errorf("v1 on a v2 path: status = %d, want 400", rec.Code)
+	}
+}
+
+func TestDeprecated_SetsHeaders(t *testing.T) {
+	engine := gin.New()
+	engine.GET("/old", Deprecated(DeprecationPolicy{
+		Since:     time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+		Sunset:    time.Date(2027, 5, 1, 0, 0, 0, 0, time.UTC),
+		Successor: "/api/v2/workspace",
+	}), func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	rec := serve(engine, httptest.NewRequest(http.MethodGet, "/old", nil))
+
+	if got := rec.Header().Get("Deprecation"); got != "@1793491200" {
+		t.Errorf("Deprecation = %q", got)
+	}
+	if got := rec.Header().Get("Sunset"); got != "Sat, 01 May 2027 00:00:00 GMT" {
+		t.Errorf("Sunset = %q", got)
+	}
+	if got := rec.Header().Get("Link"); got != `</api/v2/workspace>; rel="successor-version"` {
+		t.Errorf("Link = %q", got)
+	}
+}
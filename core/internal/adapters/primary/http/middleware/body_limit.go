@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rendis/pdf-forge/core/internal/infra/config"
+)
+
+// BodyLimitErrorCode identifies body size rejections in 413 responses.
+const BodyLimitErrorCode = "BODY_TOO_LARGE"
+
+// BodyLimit creates a middleware that caps request body sizes.
+// The limit is the per-route override if configured, the render limit for render, render
+// job and preview routes, or the default limit. Requests declaring a larger Content-Length are
+// rejected before the body is read; chunked bodies fail on read once they exceed it.
+func BodyLimit(limits config.BodyLimitsConfig) gin.HandlerFunc {
+	defaultLimit := limits.DefaultBytes()
+	renderLimit := limits.RenderBytes()
+	routeLimits := limits.RouteBytes()
+
+	return func(c *gin.Context) {
+		limit := defaultLimit
+		if isRenderRoute(c) || isRenderJobRoute(c) {
+			limit = renderLimit
+		}
+		if routeLimit, ok := routeLimits[strings.ToLower(c.FullPath())]; ok {
+			limit = routeLimit
+		}
+
+		if limit <= 0 || c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+
+		if c.Request.ContentLength > limit {
+			AbortBodyTooLarge(c, limit)
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
+
+// AbortBodyTooLarge rejects the request with a 413 stating the limit.
+func AbortBodyTooLarge(c *gin.Context, limit int64) {
+	c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+		"error": fmt.Sprintf("request body exceeds the %d byte limit", limit),
+		"code":  BodyLimitErrorCode,
+	})
+}
@@ -9,7 +9,10 @@ import (
 	"github.com/rendis/pdf-forge/core/internal/core/port"
 )
 
-const renderAuthExtraKey = "render_auth_extra"
+const (
+	renderAuthExtraKey = "render_auth_extra"
+	apiKeyIDKey        = "api_key_id"
+)
 
 // CustomRenderAuth creates middleware using a custom RenderAuthenticator.
 // Claims are stored in context using the same keys as OIDC for compatibility.
@@ -50,6 +53,9 @@ func storeRenderClaims(c *gin.Context, claims *port.RenderAuthClaims) {
 	if claims.Provider != "" {
 		c.Set(oidcProviderKey, claims.Provider)
 	}
+	if claims.KeyID != "" {
+		c.Set(apiKeyIDKey, claims.KeyID)
+	}
 	if claims.Extra != nil {
 		c.Set(renderAuthExtraKey, claims.Extra)
 	}
@@ -65,3 +71,13 @@ func GetRenderAuthExtra(c *gin.Context) map[string]any {
 	extra, _ := val.(map[string]any)
 	return extra
 }
+
+// GetAPIKeyID retrieves the API key ID reported by custom render auth.
+func GetAPIKeyID(c *gin.Context) (string, bool) {
+	val, exists := c.Get(apiKeyIDKey)
+	if !exists {
+		return "", false
+	}
+	keyID, ok := val.(string)
+	return keyID, ok
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HTTPMiddleware is a standard net/http middleware, as registered through the SDK with engine.Use.
+type HTTPMiddleware func(next http.Handler) http.Handler
+
+// FromHTTP adapts a net/http middleware to Gin. The request the middleware passes on, with any
+// context values it added, is the one the next handlers see, and responses are written through
+// the writer it passes on. Not calling next aborts the chain.
+func FromHTTP(mw HTTPMiddleware) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		original := c.Writer
+		called := false
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			c.Request = r
+			if w != http.ResponseWriter(original) {
+				c.Writer = &httpResponseWriter{ResponseWriter: original, w: w}
+			}
+			c.Next()
+		})
+
+		mw(next).ServeHTTP(original, c.Request)
+		c.Writer = original
+		if !called {
+			c.Abort()
+		}
+	}
+}
+
+// httpResponseWriter routes the writes of the next handlers through the response writer a
+// net/http middleware wrapped, keeping Gin's bookkeeping of the original writer.
+type httpResponseWriter struct {
+	gin.ResponseWriter
+	w http.ResponseWriter
+}
+
+// Header implements http.ResponseWriter.
+func (w *httpResponseWriter) Header() http.Header {
+	return w.w.Header()
+}
+
+// WriteHeader implements http.ResponseWriter.
+func (w *httpResponseWriter) WriteHeader(code int) {
+	w.w.WriteHeader(code)
+}
+
+// Write implements io.Writer.
+func (w *httpResponseWriter) Write(data []byte) (int, error) {
+	return w.w.Write(data)
+}
+
+// WriteString implements io.StringWriter.
+func (w *httpResponseWriter) WriteString(s string) (int, error) {
+	return io.WriteString(w.w, s)
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type tenantKey struct{}
+
+// statusRecorder is a typical net/http middleware writer wrapper.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func TestFromHTTP_PassesRequestAndWriter(t *testing.T) {
+	var recorded int
+	mw := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Trace", "abc")
+			rec := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r.WithContext(context.WithValue(r.Context(), tenantKey{}, "ACME")))
+			recorded = rec.status
+		})
+	}
+
+	engine := gin.New()
+	engine.Use(FromHTTP(mw))
+	engine.GET("/", func(c *gin.Context) {
+		tenant, _ := c.Request.Context().Value(tenantKey{}).(string)
+		c.String(http.StatusAccepted, tenant)
+	})
+
+	rec := serve(engine, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusAccepted || rec.Body.String() != "ACME" {
+		t.Fatalf("got %d %q, want 202 \"ACME\"", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get("X-Trace") != "abc" {
+		t.Errorf("X-Trace header = %q, want abc", rec.Header().Get("X-Trace"))
+	}
+	if recorded != http.StatusAccepted {
+		t.Errorf("middleware recorded status %d, want 202", recorded)
+	}
+}
+
+func TestFromHTTP_NotCallingNextAborts(t *testing.T) {
+	mw := func(http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "denied", http.StatusUnauthorized)
+		})
+	}
+
+	handled := false
+	engine := gin.New()
+	engine.Use(FromHTTP(mw))
+	engine.GET("/", func(c *gin.Context) {
+		handled = true
+		c.Status(http.StatusOK)
+	})
+
+	rec := serve(engine, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", rec.Code)
+	}
+	if handled {
+		t.Error("handler ran after the middleware stopped the request")
+	}
+}
@@ -25,6 +25,8 @@ const (
 	userNameKey = "user_name"
 	// oidcProviderKey is the context key for the matched OIDC provider name.
 	oidcProviderKey = "oidc_provider"
+	// tokenIssuedAtKey is the context key for the token issue time (iat claim).
+	tokenIssuedAtKey = "token_issued_at"
 )
 
 // providerKeyfunc holds a keyfunc and its associated config for one provider.
@@ -224,6 +226,9 @@ func storeClaims(c *gin.Context, claims *OIDCClaims) {
 	if claims.Name != "" {
 		c.Set(userNameKey, claims.Name)
 	}
+	if claims.IssuedAt != nil {
+		c.Set(tokenIssuedAtKey, claims.IssuedAt.UTC())
+	}
 }
 
 // OIDCClaims represents standard OIDC JWT claims.
@@ -301,6 +306,17 @@ func GetUserName(c *gin.Context) (string, bool) {
 	return getStringFromContext(c, userNameKey)
 }
 
+// GetTokenIssuedAt retrieves the issue time of the authenticated token.
+// Returns nil if the credential carried no iat claim.
+func GetTokenIssuedAt(c *gin.Context) *time.Time {
+	if val, exists := c.Get(tokenIssuedAtKey); exists {
+		if t, ok := val.(time.Time); ok {
+			return &t
+		}
+	}
+	return nil
+}
+
 // getStringFromContext retrieves a non-empty string value from the Gin context.
 func getStringFromContext(c *gin.Context, key string) (string, bool) {
 	if val, exists := c.Get(key); exists {
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	platformuc "github.com/rendis/pdf-forge/core/internal/core/usecase/platform"
+)
+
+const (
+	// MaintenanceErrorCode identifies maintenance rejections in 503 responses.
+	MaintenanceErrorCode = "MAINTENANCE"
+	// maintenanceRoute is the admin switch, reachable in every mode so maintenance can be ended.
+	maintenanceRoute = "/system/maintenance"
+)
+
+// Maintenance creates a middleware that enforces the platform maintenance mode.
+// READ_ONLY rejects writes and DRAIN also rejects new renders and render jobs, both with 503.
+// Renders that pass are counted so operators can tell when an instance is drained; submitted
+// jobs are not, as the job runner renders them later.
+// It runs before authentication, so rejected requests do not touch the database.
+func Maintenance(maintenanceUC platformuc.MaintenanceUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		compiles := isRenderRoute(c)
+		render := compiles || isRenderJobRoute(c)
+
+		state := maintenanceUC.CurrentState(c.Request.Context())
+		if state.IsActive() && !strings.HasSuffix(c.FullPath(), maintenanceRoute) {
+			write := !isSafeMethod(c.Request.Method) && !render
+			if (write && state.BlocksWrites()) || (render && state.BlocksRenders()) {
+				abortMaintenance(c, state)
+				return
+			}
+		}
+
+		if compiles {
+			done := maintenanceUC.BeginRender()
+			defer done()
+		}
+		c.Next()
+	}
+}
+
+// isRenderRoute reports whether the matched route compiles a document (render, preview or estimate).
+func isRenderRoute(c *gin.Context) bool {
+	path := c.FullPath()
+	return strings.HasSuffix(path, "/render") || strings.HasSuffix(path, "/render/batch") ||
+		strings.HasSuffix(path, "/preview") || strings.HasSuffix(path, "/estimate") ||
+		strings.HasSuffix(path, "/previews/:token")
+}
+
+// isRenderJobRoute reports whether the matched route submits a render job.
+func isRenderJobRoute(c *gin.Context) bool {
+	return strings.HasSuffix(c.FullPath(), "/render/jobs")
+}
+
+// isSafeMethod reports whether the HTTP method does not modify data.
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// abortMaintenance rejects the request with a 503 describing the maintenance window.
+func abortMaintenance(c *gin.Context, state *entity.MaintenanceState) {
+	if state.ExpectedEndAt != nil {
+		if wait := time.Until(*state.ExpectedEndAt); wait > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		}
+	}
+
+	c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+		"error":         entity.ErrMaintenanceMode.Error(),
+		"code":          MaintenanceErrorCode,
+		"mode":          state.Mode,
+		"message":       state.Message,
+		"expectedEndAt": state.ExpectedEndAt,
+	})
+}
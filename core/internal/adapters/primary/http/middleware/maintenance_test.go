@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	platformuc "github.com/rendis/pdf-forge/core/internal/core/usecase/platform"
+	"github.com/rendis/pdf-forge/core/internal/infra/config"
+)
+
+type fakeMaintenance struct {
+	platformuc.MaintenanceUseCase
+	mode    entity.MaintenanceMode
+	renders int
+}
+
+func (f *fakeMaintenance) CurrentState(context.Context) *entity.MaintenanceState {
+	return &entity.MaintenanceState{Mode: f.mode}
+}
+
+func (f *fakeMaintenance) BeginRender() func() {
+	f.renders++
+	return func() {}
+}
+
+// newRenderRoutesEngine registers handlers on the route patterns of the render API, the panel
+// preview routes and the public preview links, as the server registers them.
+func newRenderRoutesEngine(mw gin.HandlerFunc) *gin.Engine {
+	engine := gin.New()
+	engine.Use(mw)
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	ws := engine.Group("/api/v1/workspace")
+	ws.POST("/document-types/:code/render", ok)
+	ws.POST("/templates/versions/:versionId/render", ok)
+	ws.POST("/document-types/:code/estimate", ok)
+	ws.POST("/templates/versions/:versionId/estimate", ok)
+	ws.POST("/document-types/:code/render/jobs", ok)
+	ws.POST("/templates/versions/:versionId/render/jobs", ok)
+	ws.GET("/render/jobs/:jobId", ok)
+	ws.GET("/render/jobs/:jobId/result", ok)
+	ws.POST("/templates/versions/:versionId/render/batch", ok)
+	ws.POST("/templates", ok)
+	ws.GET("/templates", ok)
+	engine.POST("/api/v2/workspace/document-types/:code/render", ok)
+	versions := engine.Group("/api/v1/content/templates/:templateId/versions")
+	versions.POST("/:versionId/preview", ok)
+	versions.GET("/:versionId/preview-tokens", ok)
+	versions.POST("/:versionId/preview-tokens", ok)
+	engine.GET("/api/v1/public/previews/:token", ok)
+	engine.PUT("/api/v1/system/maintenance", ok)
+	return engine
+}
+
+func TestMaintenance(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        entity.MaintenanceMode
+		method      string
+		path        string
+		wantStatus  int
+		wantCounted bool
+	}{
+		{"render off", entity.MaintenanceModeOff, http.MethodPost, "/api/v1/workspace/document-types/INVOICE/render", http.StatusOK, true},
+		{"render read only", entity.MaintenanceModeReadOnly, http.MethodPost, "/api/v1/workspace/templates/versions/v-1/render", http.StatusOK, true},
+		{"render drain", entity.MaintenanceModeDrain, http.MethodPost, "/api/v1/workspace/document-types/INVOICE/render", http.StatusServiceUnavailable, false},
+		{"v2 render drain", entity.MaintenanceModeDrain, http.MethodPost, "/api/v2/workspace/document-types/INVOICE/render", http.StatusServiceUnavailable, false},
+		{"job read only", entity.MaintenanceModeReadOnly, http.MethodPost, "/api/v1/workspace/document-types/INVOICE/render/jobs", http.StatusOK, false},
+		{"job drain", entity.MaintenanceModeDrain, http.MethodPost, "/api/v1/workspace/document-types/INVOICE/render/jobs", http.StatusServiceUnavailable, false},
+		{"version job drain", entity.MaintenanceModeDrain, http.MethodPost, "/api/v1/workspace/templates/versions/v-1/render/jobs", http.StatusServiceUnavailable, false},
+		{"job status drain", entity.MaintenanceModeDrain, http.MethodGet, "/api/v1/workspace/render/jobs/job-1", http.StatusOK, false},
+		{"batch read only", entity.MaintenanceModeReadOnly, http.MethodPost, "/api/v1/workspace/templates/versions/v-1/render/batch", http.StatusOK, true},
+		{"batch drain", entity.MaintenanceModeDrain, http.MethodPost, "/api/v1/workspace/templates/versions/v-1/render/batch", http.StatusServiceUnavailable, false},
+		{"estimate read only", entity.MaintenanceModeReadOnly, http.MethodPost, "/api/v1/workspace/document-types/INVOICE/estimate", http.StatusOK, true},
+		{"estimate drain", entity.MaintenanceModeDrain, http.MethodPost, "/api/v1/workspace/templates/versions/v-1/estimate", http.StatusServiceUnavailable, false},
+		{"document type estimate drain", entity.MaintenanceModeDrain, http.MethodPost, "/api/v1/workspace/document-types/INVOICE/estimate", http.StatusServiceUnavailable, false},
+		{"job result drain", entity.MaintenanceModeDrain, http.MethodGet, "/api/v1/workspace/render/jobs/job-1/result", http.StatusOK, false},
+		{"preview off", entity.MaintenanceModeOff, http.MethodPost, "/api/v1/content/templates/t-1/versions/v-1/preview", http.StatusOK, true},
+		{"preview read only", entity.MaintenanceModeReadOnly, http.MethodPost, "/api/v1/content/templates/t-1/versions/v-1/preview", http.StatusOK, true},
+		{"preview drain", entity.MaintenanceModeDrain, http.MethodPost, "/api/v1/content/templates/t-1/versions/v-1/preview", http.StatusServiceUnavailable, false},
+		{"preview link read only", entity.MaintenanceModeReadOnly, http.MethodGet, "/api/v1/public/previews/tok-1", http.StatusOK, true},
+		{"preview link drain", entity.MaintenanceModeDrain, http.MethodGet, "/api/v1/public/previews/tok-1", http.StatusServiceUnavailable, false},
+		{"preview token create read only", entity.MaintenanceModeReadOnly, http.MethodPost, "/api/v1/content/templates/t-1/versions/v-1/preview-tokens", http.StatusServiceUnavailable, false},
+		{"preview token list drain", entity.MaintenanceModeDrain, http.MethodGet, "/api/v1/content/templates/t-1/versions/v-1/preview-tokens", http.StatusOK, false},
+		{"write off", entity.MaintenanceModeOff, http.MethodPost, "/api/v1/workspace/templates", http.StatusOK, false},
+		{"write read only", entity.MaintenanceModeReadOnly, http.MethodPost, "/api/v1/workspace/templates", http.StatusServiceUnavailable, false},
+		{"read drain", entity.MaintenanceModeDrain, http.MethodGet, "/api/v1/workspace/templates", http.StatusOK, false},
+		{"maintenance switch drain", entity.MaintenanceModeDrain, http.MethodPut, "/api/v1/system/maintenance", http.StatusOK, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maintenance := &fakeMaintenance{mode: tt.mode}
+			engine := newRenderRoutesEngine(Maintenance(maintenance))
+
+			rec := serve(engine, httptest.NewRequest(tt.method, tt.path, nil))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("%s %s in %s = %d, want %d", tt.method, tt.path, tt.mode, rec.Code, tt.wantStatus)
+			}
+			if counted := maintenance.renders > 0; counted != tt.wantCounted {
+				t.Errorf("render counted = %v, want %v", counted, tt.wantCounted)
+			}
+		})
+	}
+}
+
+func TestBodyLimit_RenderRoutes(t *testing.T) {
+	engine := newRenderRoutesEngine(BodyLimit(config.BodyLimitsConfig{DefaultMB: 1, RenderMB: 2}))
+	body := strings.Repeat("x", 3<<19) // 1.5 MB
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{"/api/v1/workspace/document-types/INVOICE/render", http.StatusOK},
+		{"/api/v1/workspace/document-types/INVOICE/render/jobs", http.StatusOK},
+		{"/api/v1/workspace/templates/versions/v-1/render/batch", http.StatusOK},
+		{"/api/v1/workspace/templates", http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		rec := serve(engine, httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(body)))
+		if rec.Code != tt.want {
+			t.Errorf("POST %s with 1.5 MB = %d, want %d", tt.path, rec.Code, tt.want)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	accessuc "github.com/rendis/pdf-forge/core/internal/core/usecase/access"
+)
+
+const (
+	// customAuthProvider names the provider of principals authenticated by a custom RenderAuthenticator
+	// that did not report one.
+	customAuthProvider = "custom"
+	// maxUserAgentLength matches the user_agent column size.
+	maxUserAgentLength = 500
+)
+
+// PrincipalResolver decides the principal type of an authenticated request.
+type PrincipalResolver func(c *gin.Context) entity.PrincipalType
+
+// UserPrincipal resolves every request as a panel user.
+func UserPrincipal() PrincipalResolver {
+	return func(*gin.Context) entity.PrincipalType { return entity.PrincipalTypeUser }
+}
+
+// APIKeyPrincipal resolves every request as an API key (custom render authentication).
+func APIKeyPrincipal() PrincipalResolver {
+	return func(*gin.Context) entity.PrincipalType { return entity.PrincipalTypeAPIKey }
+}
+
+// OIDCPrincipal resolves tokens from the panel provider as users and
+// tokens from any other render provider as service accounts.
+func OIDCPrincipal(panelProvider string) PrincipalResolver {
+	return func(c *gin.Context) entity.PrincipalType {
+		if provider, _ := GetOIDCProvider(c); provider == panelProvider {
+			return entity.PrincipalTypeUser
+		}
+		return entity.PrincipalTypeServiceAccount
+	}
+}
+
+// SessionTracking records the authenticated principal for session introspection and
+// rejects requests of revoked sessions or of tokens issued before a forced re-authentication.
+// It must run after the authentication middleware.
+func SessionTracking(sessionUC accessuc.AuthSessionUseCase, principalOf PrincipalResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodOptions {
+			c.Next()
+			return
+		}
+
+		session := buildSession(c, principalOf(c))
+		if session == nil {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		if err := sessionUC.CheckAccess(ctx, session, GetTokenIssuedAt(c)); err != nil {
+			if errors.Is(err, entity.ErrSessionRevoked) {
+				slog.WarnContext(ctx, "rejected request of revoked session",
+					slog.String("principal_type", string(session.PrincipalType)),
+					slog.String("subject", session.Subject),
+					slog.String("operation_id", GetOperationID(c)),
+				)
+			}
+			abortWithError(c, http.StatusUnauthorized, err)
+			return
+		}
+
+		sessionUC.RecordActivity(ctx, session)
+		c.Next()
+	}
+}
+
+// buildSession describes the principal of the request from the stored claims.
+// API keys are keyed by their key ID, so each key is a session of its own even when
+// several share a caller or report none. Returns nil if the credential does not identify a principal.
+func buildSession(c *gin.Context, principalType entity.PrincipalType) *entity.AuthSession {
+	email, _ := GetUserEmail(c)
+	subject := principalSubject(c, principalType, email)
+	if subject == "" {
+		return nil
+	}
+
+	provider, ok := GetOIDCProvider(c)
+	if !ok {
+		provider = customAuthProvider
+	}
+
+	session := entity.NewAuthSession(principalType, provider, subject)
+	session.Email = email
+	session.DisplayName, _ = GetUserName(c)
+	session.IPAddress = c.ClientIP()
+	session.UserAgent = c.Request.UserAgent()
+	if ua := []rune(session.UserAgent); len(ua) > maxUserAgentLength {
+		session.UserAgent = string(ua[:maxUserAgentLength])
+	}
+	if userID, ok := GetInternalUserID(c); ok {
+		session.UserID = &userID
+	}
+	return session
+}
+
+// principalSubject picks the identifier of the principal: the key ID for API keys,
+// otherwise the subject claim, falling back to the email.
+func principalSubject(c *gin.Context, principalType entity.PrincipalType, email string) string {
+	if principalType == entity.PrincipalTypeAPIKey {
+		if keyID, ok := GetAPIKeyID(c); ok {
+			return keyID
+		}
+	}
+	if subject, ok := GetUserID(c); ok {
+		return subject
+	}
+	return email
+}
@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+func TestBuildSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name          string
+		principalType entity.PrincipalType
+		claims        port.RenderAuthClaims
+		wantSubject   string
+		wantProvider  string
+	}{
+		{
+			name:          "api key without caller is keyed by key ID",
+			principalType: entity.PrincipalTypeAPIKey,
+			claims:        port.RenderAuthClaims{KeyID: "key-1"},
+			wantSubject:   "key-1",
+			wantProvider:  customAuthProvider,
+		},
+		{
+			name:          "api key ID wins over the caller",
+			principalType: entity.PrincipalTypeAPIKey,
+			claims:        port.RenderAuthClaims{UserID: "service-1", KeyID: "key-2", Provider: "api-key"},
+			wantSubject:   "key-2",
+			wantProvider:  "api-key",
+		},
+		{
+			name:          "api key without ID falls back to the caller",
+			principalType: entity.PrincipalTypeAPIKey,
+			claims:        port.RenderAuthClaims{UserID: "service-1"},
+			wantSubject:   "service-1",
+			wantProvider:  customAuthProvider,
+		},
+		{
+			name:          "user falls back to the email",
+			principalType: entity.PrincipalTypeUser,
+			claims:        port.RenderAuthClaims{Email: "ana@example.com", Provider: "panel"},
+			wantSubject:   "ana@example.com",
+			wantProvider:  "panel",
+		},
+		{
+			name:          "key ID is ignored for other principals",
+			principalType: entity.PrincipalTypeServiceAccount,
+			claims:        port.RenderAuthClaims{UserID: "svc", KeyID: "key-3", Provider: "partner"},
+			wantSubject:   "svc",
+			wantProvider:  "partner",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+			storeRenderClaims(c, &tt.claims)
+
+			session := buildSession(c, tt.principalType)
+			if session == nil {
+				t.Fatal("buildSession() = nil, want a session")
+			}
+			if session.Subject != tt.wantSubject {
+				t.Errorf("Subject = %q, want %q", session.Subject, tt.wantSubject)
+			}
+			if session.Provider != tt.wantProvider {
+				t.Errorf("Provider = %q, want %q", session.Provider, tt.wantProvider)
+			}
+		})
+	}
+}
+
+func TestBuildSession_NoIdentifier(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+	storeRenderClaims(c, &port.RenderAuthClaims{Name: "anonymous"})
+
+	if session := buildSession(c, entity.PrincipalTypeAPIKey); session != nil {
+		t.Errorf("buildSession() = %+v, want nil", session)
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// StaticToken requires the Authorization header to carry the given bearer token, for machine
+// clients such as autoscalers that cannot obtain OIDC tokens. An empty token lets every request through.
+func StaticToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			c.Next()
+			return
+		}
+		got, err := extractBearerToken(c)
+		if err != nil {
+			abortWithError(c, http.StatusUnauthorized, err)
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			abortWithError(c, http.StatusUnauthorized, entity.ErrInvalidToken)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,88 @@
+// Package assetdownload implements the downloader of the remote images imported into the asset
+// library.
+package assetdownload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/service/rendering/pdfrenderer"
+)
+
+const (
+	defaultTimeout = 15 * time.Second
+	maxRedirects   = 5
+)
+
+// Downloader fetches remote files over HTTP.
+type Downloader struct {
+	client *http.Client
+}
+
+// New creates a downloader. Unless allowPrivateNetworks is set, connections to loopback, private,
+// link-local and other non-public addresses are refused, also after redirects, so editors cannot
+// import files from internal services. The check is the SSRF policy of render image downloads.
+func New(timeout time.Duration, allowPrivateNetworks bool) *Downloader {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	dialer := &net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	if !allowPrivateNetworks {
+		transport.DialContext = pdfrenderer.PublicDialContext(dialer.DialContext)
+	}
+
+	return &Downloader{client: &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return errors.New("too many redirects")
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("redirect to unsupported scheme %q", req.URL.Scheme)
+			}
+			return nil
+		},
+	}}
+}
+
+// Download returns the body of a GET to url, failing on non-2xx statuses and bodies larger
+// than maxBytes.
+func (d *Downloader) Download(ctx context.Context, url string, maxBytes int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("User-Agent", "pdf-forge-assets")
+	req.Header.Set("Accept", "image/*")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("responded with status %d", resp.StatusCode)
+	}
+	if resp.ContentLength > maxBytes {
+		return nil, fmt.Errorf("file of %d bytes exceeds the %d byte limit", resp.ContentLength, maxBytes)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading body: %w", err)
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("file exceeds the %d byte limit", maxBytes)
+	}
+	return data, nil
+}
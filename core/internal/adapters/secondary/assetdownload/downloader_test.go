@@ -0,0 +1,41 @@
+package assetdownload
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDownloader_Download(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/image.png":
+			_, _ = w.Write([]byte("0123456789"))
+		case "/moved":
+			http.Redirect(w, r, "/image.png", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	d := New(time.Second, true)
+	ctx := context.Background()
+
+	data, err := d.Download(ctx, server.URL+"/moved", 10)
+	if err != nil || string(data) != "0123456789" {
+		t.Fatalf("redirected download: got %q, %v", data, err)
+	}
+	if _, err := d.Download(ctx, server.URL+"/image.png", 9); err == nil {
+		t.Error("a body over the limit must fail")
+	}
+	if _, err := d.Download(ctx, server.URL+"/missing", 10); err == nil {
+		t.Error("a 404 must fail")
+	}
+
+	if _, err := New(time.Second, false).Download(ctx, server.URL+"/image.png", 10); err == nil {
+		t.Error("loopback addresses must be refused unless private networks are allowed")
+	}
+}
@@ -0,0 +1,57 @@
+package chatwebhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+func TestSlackSender_Send(t *testing.T) {
+	var got slackPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+	}))
+	defer srv.Close()
+
+	n := entity.NewNotification("user-1", entity.NotificationTypeRenderFailed,
+		`Rendering "Q&A <draft>" failed in prod`, "typst compile failed\nFailed at: 2026-03-01 10:00")
+	require.NoError(t, NewSlackSender().Send(context.Background(), srv.URL, n))
+
+	assert.Equal(t, "*Rendering \"Q&amp;A &lt;draft&gt;\" failed in prod*\ntypst compile failed\nFailed at: 2026-03-01 10:00", got.Text)
+}
+
+func TestTeamsSender_Send(t *testing.T) {
+	var got teamsMessageCard
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+	}))
+	defer srv.Close()
+
+	n := entity.NewNotification("user-1", entity.NotificationTypeRenderFailed,
+		"Render job job-1 failed in prod", "injector timed out\nFailed at: 2026-03-01 10:00")
+	require.NoError(t, NewTeamsSender().Send(context.Background(), srv.URL, n))
+
+	assert.Equal(t, "MessageCard", got.Type)
+	assert.Equal(t, "Render job job-1 failed in prod", got.Title)
+	assert.Equal(t, "injector timed out\n\nFailed at: 2026-03-01 10:00", got.Text)
+	assert.Equal(t, "D32F2F", got.ThemeColor)
+}
+
+func TestSender_FailsOnErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	n := entity.NewNotification("user-1", entity.NotificationTypeRenderFailed, "Rendering failed", "")
+	err := NewSlackSender().Send(context.Background(), srv.URL, n)
+	assert.ErrorContains(t, err, "status 403")
+}
@@ -0,0 +1,37 @@
+package chatwebhook
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// SlackSender posts notifications to Slack incoming webhooks.
+type SlackSender struct {
+	client *http.Client
+}
+
+// NewSlackSender creates a new Slack webhook sender.
+func NewSlackSender() port.ChatWebhookSender {
+	return &SlackSender{client: newHTTPClient()}
+}
+
+type slackPayload struct {
+	Text string `json:"text"`
+}
+
+// slackEscaper escapes the characters Slack reads as mrkdwn control sequences, so
+// template titles and render errors are shown as written.
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// Send posts the notification as a Slack mrkdwn message.
+func (s *SlackSender) Send(ctx context.Context, webhookURL string, n *entity.Notification) error {
+	text := "*" + slackEscaper.Replace(n.Title) + "*"
+	if n.Message != "" {
+		text += "\n" + slackEscaper.Replace(n.Message)
+	}
+	return postJSON(ctx, s.client, webhookURL, slackPayload{Text: text})
+}
@@ -0,0 +1,48 @@
+package chatwebhook
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// TeamsSender posts notifications to Microsoft Teams incoming webhooks.
+type TeamsSender struct {
+	client *http.Client
+}
+
+// NewTeamsSender creates a new Microsoft Teams webhook sender.
+func NewTeamsSender() port.ChatWebhookSender {
+	return &TeamsSender{client: newHTTPClient()}
+}
+
+// teamsMessageCard is the legacy connector card format, accepted by both
+// Office 365 connectors and Power Automate "post to channel" workflows.
+type teamsMessageCard struct {
+	Type       string `json:"@type"`
+	Context    string `json:"@context"`
+	Summary    string `json:"summary"`
+	Title      string `json:"title"`
+	Text       string `json:"text,omitempty"`
+	ThemeColor string `json:"themeColor,omitempty"`
+}
+
+// Send posts the notification as a Teams MessageCard.
+func (s *TeamsSender) Send(ctx context.Context, webhookURL string, n *entity.Notification) error {
+	card := teamsMessageCard{
+		Type:    "MessageCard",
+		Context: "http://schema.org/extensions",
+		Summary: n.Title,
+		Title:   n.Title,
+		// Teams joins single line breaks of card text into one paragraph
+		Text: strings.ReplaceAll(n.Message, "\n", "\n\n"),
+	}
+	switch n.Type {
+	case entity.NotificationTypeScheduledPublishFailed, entity.NotificationTypeRenderFailed, entity.NotificationTypeTemplateSLABreached:
+		card.ThemeColor = "D32F2F"
+	}
+	return postJSON(ctx, s.client, webhookURL, card)
+}
@@ -0,0 +1,46 @@
+// Package currencyrate implements the built-in currency rate providers: the reference rates of the
+// European Central Bank and Open Exchange Rates.
+package currencyrate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+	// maxResponseBytes bounds a response; the full ECB history is about 6 MB.
+	maxResponseBytes = 32 << 20
+)
+
+func newHTTPClient(timeout time.Duration) *http.Client {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &http.Client{Timeout: timeout}
+}
+
+// fetch returns the body of a GET of url, failing on statuses other than 200.
+func fetch(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return body, fmt.Errorf("responded with status %d", resp.StatusCode)
+	}
+	return body, nil
+}
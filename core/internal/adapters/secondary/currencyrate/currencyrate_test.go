@@ -0,0 +1,123 @@
+package currencyrate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+const ecbHistory = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<Cube>
+		<Cube time="2024-01-05">
+			<Cube currency="USD" rate="1.0921"/>
+			<Cube currency="JPY" rate="158.40"/>
+		</Cube>
+		<Cube time="2024-01-04">
+			<Cube currency="USD" rate="1.0953"/>
+		</Cube>
+		<Cube time="2024-01-02">
+			<Cube currency="USD" rate="1.0956"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+func TestECB_RateTable(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(ecbHistory))
+	}))
+	defer server.Close()
+	p := NewECB(time.Second)
+	p.baseURL = server.URL
+
+	tests := []struct {
+		name string
+		date time.Time
+		want string
+		file string
+	}{
+		{"latest", time.Time{}, "2024-01-05", ecbDailyFile},
+		{"publication day", time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), "2024-01-04", ecbHistFile},
+		{"holiday uses the previous day", time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), "2024-01-02", ecbHistFile},
+		{"weekend", time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC), "2024-01-05", ecbHistFile},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths = nil
+			table, err := p.RateTable(context.Background(), tt.date)
+			if err != nil {
+				t.Fatalf("RateTable: %v", err)
+			}
+			if got := table.Date.Format(time.DateOnly); got != tt.want {
+				t.Errorf("date = %s, want %s", got, tt.want)
+			}
+			if table.Base != "EUR" || table.Provider != "ecb" || table.Rates["USD"] == 0 {
+				t.Errorf("unexpected table %+v", table)
+			}
+			if len(paths) != 1 || paths[0] != tt.file {
+				t.Errorf("requested %v, want %s", paths, tt.file)
+			}
+		})
+	}
+
+	_, err := p.RateTable(context.Background(), time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC))
+	if !errors.Is(err, entity.ErrCurrencyRateNotFound) {
+		t.Errorf("date before every publication: got %v, want ErrCurrencyRateNotFound", err)
+	}
+}
+
+func TestOpenExchangeRates_RateTable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Query().Get("app_id") != "secret":
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": true, "status": 401, "message": "invalid_app_id", "description": "Invalid App ID provided."}`))
+		case r.URL.Path == "/latest.json":
+			_, _ = w.Write([]byte(`{"timestamp": 1704470400, "base": "USD", "rates": {"EUR": 0.9157, "CLP": 890.5}}`))
+		case r.URL.Path == "/historical/2024-01-02.json":
+			_, _ = w.Write([]byte(`{"timestamp": 1704239999, "base": "USD", "rates": {"EUR": 0.9127}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	p, err := NewOpenExchangeRates("secret", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.baseURL = server.URL
+
+	latest, err := p.RateTable(context.Background(), time.Time{})
+	if err != nil {
+		t.Fatalf("latest: %v", err)
+	}
+	if latest.Base != "USD" || latest.Date.Format(time.DateOnly) != "2024-01-05" || latest.Rates["CLP"] != 890.5 {
+		t.Errorf("unexpected latest table %+v", latest)
+	}
+
+	pinned, err := p.RateTable(context.Background(), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("historical: %v", err)
+	}
+	if pinned.Date.Format(time.DateOnly) != "2024-01-02" || pinned.Rates["EUR"] != 0.9127 {
+		t.Errorf("unexpected historical table %+v", pinned)
+	}
+
+	p.appID = "wrong"
+	if _, err := p.RateTable(context.Background(), time.Time{}); err == nil || err.Error() != "fetching open exchange rates: responded with status 401: Invalid App ID provided." {
+		t.Errorf("invalid app ID: got %v", err)
+	}
+
+	if _, err := NewOpenExchangeRates("", 0); err == nil {
+		t.Error("an empty app ID is rejected")
+	}
+}
@@ -0,0 +1,99 @@
+package currencyrate
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// ECB reference rate files. The daily file holds the last publication, the 90-day file the
+// publications of the last three months and the history file every publication since 1999.
+const (
+	ecbBaseURL    = "https://www.ecb.europa.eu/stats/eurofxref"
+	ecbDailyFile  = "/eurofxref-daily.xml"
+	ecb90DayFile  = "/eurofxref-hist-90d.xml"
+	ecbHistFile   = "/eurofxref-hist.xml"
+	ecb90DayRange = 85 * 24 * time.Hour // within the 90-day file, with room for its publication delay
+)
+
+// ECB provides the euro reference rates of the European Central Bank, published on working days
+// around 16:00 CET. It needs no credentials.
+type ECB struct {
+	client  *http.Client
+	baseURL string
+}
+
+// NewECB creates an ECB rate provider whose requests time out after timeout (10s when zero).
+func NewECB(timeout time.Duration) *ECB {
+	return &ECB{client: newHTTPClient(timeout), baseURL: ecbBaseURL}
+}
+
+// Name implements port.CurrencyRateProvider.
+func (p *ECB) Name() string { return "ecb" }
+
+// RateTable implements port.CurrencyRateProvider.
+func (p *ECB) RateTable(ctx context.Context, date time.Time) (*entity.CurrencyRateTable, error) {
+	file := ecbDailyFile
+	switch {
+	case date.IsZero():
+	case time.Since(date) < ecb90DayRange:
+		file = ecb90DayFile
+	default:
+		file = ecbHistFile
+	}
+
+	body, err := fetch(ctx, p.client, p.baseURL+file)
+	if err != nil {
+		return nil, fmt.Errorf("fetching ECB rates: %w", err)
+	}
+	var envelope ecbEnvelope
+	if err := xml.Unmarshal(body, &envelope); err != nil {
+		return nil, fmt.Errorf("parsing ECB rates: %w", err)
+	}
+
+	table := p.latestBefore(envelope, date)
+	if table == nil {
+		return nil, fmt.Errorf("ECB rates for %s: %w", date.Format(time.DateOnly), entity.ErrCurrencyRateNotFound)
+	}
+	return table, nil
+}
+
+// latestBefore returns the last publication of envelope on or before date, or the last one when
+// date is zero.
+func (p *ECB) latestBefore(envelope ecbEnvelope, date time.Time) *entity.CurrencyRateTable {
+	var table *entity.CurrencyRateTable
+	for _, day := range envelope.Days {
+		published, err := time.Parse(time.DateOnly, day.Time)
+		if err != nil || (!date.IsZero() && published.After(date)) {
+			continue
+		}
+		if table != nil && !published.After(table.Date) {
+			continue
+		}
+		table = &entity.CurrencyRateTable{
+			Provider: p.Name(),
+			Base:     "EUR",
+			Date:     published,
+			Rates:    make(map[string]float64, len(day.Rates)),
+		}
+		for _, r := range day.Rates {
+			table.Rates[r.Currency] = r.Rate
+		}
+	}
+	return table
+}
+
+// ecbEnvelope is an ECB reference rate file: a Cube per publication day holding a Cube per currency.
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string  `xml:"currency,attr"`
+			Rate     float64 `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
@@ -0,0 +1,78 @@
+package currencyrate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+const openExchangeRatesBaseURL = "https://openexchangerates.org/api"
+
+// OpenExchangeRates provides the rates of Open Exchange Rates against the US dollar, updated hourly
+// on paid plans. Historical rates are the end-of-day rates of the requested date.
+type OpenExchangeRates struct {
+	client  *http.Client
+	baseURL string
+	appID   string
+}
+
+// NewOpenExchangeRates creates an Open Exchange Rates provider authenticated with appID, whose
+// requests time out after timeout (10s when zero).
+func NewOpenExchangeRates(appID string, timeout time.Duration) (*OpenExchangeRates, error) {
+	if appID == "" {
+		return nil, errors.New("open exchange rates: app ID is required")
+	}
+	return &OpenExchangeRates{client: newHTTPClient(timeout), baseURL: openExchangeRatesBaseURL, appID: appID}, nil
+}
+
+// Name implements port.CurrencyRateProvider.
+func (p *OpenExchangeRates) Name() string { return "openexchangerates" }
+
+// RateTable implements port.CurrencyRateProvider.
+func (p *OpenExchangeRates) RateTable(ctx context.Context, date time.Time) (*entity.CurrencyRateTable, error) {
+	path := "/latest.json"
+	if !date.IsZero() {
+		path = "/historical/" + date.Format(time.DateOnly) + ".json"
+	}
+
+	body, err := fetch(ctx, p.client, p.baseURL+path+"?app_id="+url.QueryEscape(p.appID))
+	if err != nil {
+		var errResp openExchangeRatesResponse
+		if json.Unmarshal(body, &errResp) == nil && errResp.Description != "" {
+			err = fmt.Errorf("%w: %s", err, errResp.Description)
+		}
+		return nil, fmt.Errorf("fetching open exchange rates: %w", err)
+	}
+	var resp openExchangeRatesResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing open exchange rates: %w", err)
+	}
+	if len(resp.Rates) == 0 {
+		return nil, fmt.Errorf("open exchange rates for %s: %w", date.Format(time.DateOnly), entity.ErrCurrencyRateNotFound)
+	}
+
+	published := date
+	if published.IsZero() {
+		published = time.Unix(resp.Timestamp, 0).UTC()
+	}
+	return &entity.CurrencyRateTable{
+		Provider: p.Name(),
+		Base:     resp.Base,
+		Date:     time.Date(published.Year(), published.Month(), published.Day(), 0, 0, 0, 0, time.UTC),
+		Rates:    resp.Rates,
+	}, nil
+}
+
+// openExchangeRatesResponse is a rates response, or an error response with Description set.
+type openExchangeRatesResponse struct {
+	Timestamp   int64              `json:"timestamp"`
+	Base        string             `json:"base"`
+	Rates       map[string]float64 `json:"rates"`
+	Description string             `json:"description"`
+}
@@ -0,0 +1,86 @@
+package assetrepo
+
+// SQL queries for asset library operations.
+const (
+	queryCreate = `
+		INSERT INTO content.assets (workspace_id, name, kind, tags, current_version, created_by, created_at, source_url)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`
+
+	queryCreateVersion = `
+		INSERT INTO content.asset_versions (asset_id, version, content_type, size_bytes, sha256, data, storage_key, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, $9)`
+
+	querySetCurrentVersion = `
+		UPDATE content.assets
+		SET current_version = $2, updated_at = NOW()
+		WHERE id = $1`
+
+	// querySelectAsset selects an asset with the metadata of its current version.
+	querySelectAsset = `
+		SELECT a.id, a.workspace_id, a.name, a.kind, a.tags, a.current_version, a.created_by,
+		       a.created_at, a.updated_at, a.source_url, v.content_type, v.size_bytes, v.sha256
+		FROM content.assets a
+		JOIN content.asset_versions v ON v.asset_id = a.id AND v.version = a.current_version`
+
+	queryFindByID = querySelectAsset + `
+		WHERE a.id = $1 AND a.workspace_id = $2`
+
+	queryFindByWorkspace = querySelectAsset + `
+		WHERE a.workspace_id = $1
+		  AND ($2 = '' OR a.kind = $2)
+		  AND ($3 = '' OR $3 = ANY(a.tags))
+		  AND ($4 = '' OR a.name ILIKE '%' || $4 || '%')
+		ORDER BY a.created_at DESC`
+
+	queryFindBySHA256 = querySelectAsset + `
+		WHERE a.workspace_id = $1 AND v.sha256 = $2
+		ORDER BY a.created_at
+		LIMIT 1`
+
+	queryFindBySourceURL = querySelectAsset + `
+		WHERE a.workspace_id = $1 AND a.source_url = $2`
+
+	querySetSourceURL = `
+		UPDATE content.assets
+		SET source_url = $2, updated_at = NOW()
+		WHERE id = $1`
+
+	queryFindVersions = `
+		SELECT asset_id, version, content_type, size_bytes, sha256, COALESCE(storage_key, ''), created_by, created_at
+		FROM content.asset_versions
+		WHERE asset_id = $1
+		ORDER BY version DESC`
+
+	queryFindContent = `
+		SELECT v.asset_id, v.version, v.content_type, v.size_bytes, v.sha256, v.data, COALESCE(v.storage_key, ''),
+		       v.created_by, v.created_at
+		FROM content.assets a
+		JOIN content.asset_versions v ON v.asset_id = a.id
+		WHERE a.id = $1 AND a.workspace_id = $2
+		  AND v.version = CASE WHEN $3::INT = 0 THEN a.current_version ELSE $3::INT END`
+
+	// queryFindUsages matches the asset URL, or the remote URL an imported asset came from, in the
+	// serialized content of every template version of the workspace, which finds references from
+	// any node attribute.
+	queryFindUsages = `
+		SELECT t.id, t.title, v.id, v.version_number, v.status
+		FROM content.template_versions v
+		JOIN content.templates t ON t.id = v.template_id
+		WHERE t.workspace_id = $1
+		  AND (strpos(v.content_structure::TEXT, $2) > 0
+		       OR strpos(v.content_structure::TEXT, (SELECT source_url FROM content.assets WHERE id = $3)) > 0)
+		ORDER BY t.title, v.version_number DESC`
+
+	queryStorageKeyInUse = `
+		SELECT EXISTS (SELECT 1 FROM content.asset_versions WHERE storage_key = $1)`
+
+	queryUpdate = `
+		UPDATE content.assets
+		SET name = $2, tags = $3, updated_at = NOW()
+		WHERE id = $1`
+
+	queryDelete = `
+		DELETE FROM content.assets
+		WHERE id = $1`
+)
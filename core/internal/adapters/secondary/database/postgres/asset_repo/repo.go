@@ -0,0 +1,295 @@
+package assetrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new asset repository.
+func New(pool *pgxpool.Pool) port.AssetRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the asset repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// Create creates an asset.
+func (r *Repository) Create(ctx context.Context, asset *entity.Asset) (string, error) {
+	var id string
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryCreate,
+		asset.WorkspaceID,
+		asset.Name,
+		asset.Kind,
+		asset.Tags,
+		asset.CurrentVersion,
+		asset.CreatedBy,
+		asset.CreatedAt,
+		asset.SourceURL,
+	).Scan(&id)
+	if err != nil {
+		return "", fmt.Errorf("inserting asset: %w", err)
+	}
+
+	return id, nil
+}
+
+// CreateVersion stores a version of an asset with its content.
+func (r *Repository) CreateVersion(ctx context.Context, version *entity.AssetVersion) error {
+	_, err := common.Conn(ctx, r.pool).Exec(ctx, queryCreateVersion,
+		version.AssetID,
+		version.Version,
+		version.ContentType,
+		version.SizeBytes,
+		version.SHA256,
+		version.Data,
+		version.StorageKey,
+		version.CreatedBy,
+		version.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("inserting asset version: %w", err)
+	}
+
+	return nil
+}
+
+// SetCurrentVersion makes a stored version the one used by renders.
+func (r *Repository) SetCurrentVersion(ctx context.Context, id string, version int) error {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, querySetCurrentVersion, id, version)
+	if err != nil {
+		return fmt.Errorf("updating asset version: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrAssetNotFound
+	}
+
+	return nil
+}
+
+// FindByID finds an asset of a workspace, with the metadata of its current version.
+func (r *Repository) FindByID(ctx context.Context, workspaceID, id string) (*entity.Asset, error) {
+	asset, err := scanAsset(common.Conn(ctx, r.pool).QueryRow(ctx, queryFindByID, id, workspaceID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrAssetNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying asset: %w", err)
+	}
+
+	return asset, nil
+}
+
+// FindByWorkspace lists the assets of a workspace, newest first.
+func (r *Repository) FindByWorkspace(ctx context.Context, workspaceID string, filters port.AssetFilters) ([]*entity.Asset, error) {
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindByWorkspace,
+		workspaceID,
+		filters.Kind,
+		filters.Tag,
+		filters.Search,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying assets: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*entity.Asset
+	for rows.Next() {
+		asset, err := scanAsset(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning asset: %w", err)
+		}
+		result = append(result, asset)
+	}
+
+	return result, rows.Err()
+}
+
+// FindBySHA256 finds the asset of a workspace whose current version has the given content hash.
+func (r *Repository) FindBySHA256(ctx context.Context, workspaceID, sha256 string) (*entity.Asset, error) {
+	asset, err := scanAsset(common.Conn(ctx, r.pool).QueryRow(ctx, queryFindBySHA256, workspaceID, sha256))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying asset by hash: %w", err)
+	}
+
+	return asset, nil
+}
+
+// FindBySourceURL finds the asset of a workspace imported from a remote URL.
+func (r *Repository) FindBySourceURL(ctx context.Context, workspaceID, sourceURL string) (*entity.Asset, error) {
+	asset, err := scanAsset(common.Conn(ctx, r.pool).QueryRow(ctx, queryFindBySourceURL, workspaceID, sourceURL))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying asset by source URL: %w", err)
+	}
+
+	return asset, nil
+}
+
+// SetSourceURL records the remote URL an asset was imported from.
+func (r *Repository) SetSourceURL(ctx context.Context, id, sourceURL string) error {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, querySetSourceURL, id, sourceURL)
+	if err != nil {
+		return fmt.Errorf("updating asset source URL: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrAssetNotFound
+	}
+
+	return nil
+}
+
+// FindVersions lists the versions of an asset without their content, newest first.
+func (r *Repository) FindVersions(ctx context.Context, id string) ([]*entity.AssetVersion, error) {
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindVersions, id)
+	if err != nil {
+		return nil, fmt.Errorf("querying asset versions: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*entity.AssetVersion
+	for rows.Next() {
+		var v entity.AssetVersion
+		if err := rows.Scan(
+			&v.AssetID,
+			&v.Version,
+			&v.ContentType,
+			&v.SizeBytes,
+			&v.SHA256,
+			&v.StorageKey,
+			&v.CreatedBy,
+			&v.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning asset version: %w", err)
+		}
+		result = append(result, &v)
+	}
+
+	return result, rows.Err()
+}
+
+// FindContent returns a version of a workspace asset with its content.
+func (r *Repository) FindContent(ctx context.Context, workspaceID, id string, version int) (*entity.AssetVersion, error) {
+	var v entity.AssetVersion
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindContent, id, workspaceID, version).Scan(
+		&v.AssetID,
+		&v.Version,
+		&v.ContentType,
+		&v.SizeBytes,
+		&v.SHA256,
+		&v.Data,
+		&v.StorageKey,
+		&v.CreatedBy,
+		&v.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrAssetNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying asset content: %w", err)
+	}
+
+	return &v, nil
+}
+
+// FindUsages lists the template versions of the asset's workspace whose content references it.
+func (r *Repository) FindUsages(ctx context.Context, workspaceID, id string) ([]*entity.AssetUsage, error) {
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindUsages, workspaceID, entity.AssetURLScheme+id, id)
+	if err != nil {
+		return nil, fmt.Errorf("querying asset usages: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*entity.AssetUsage
+	for rows.Next() {
+		var u entity.AssetUsage
+		if err := rows.Scan(
+			&u.TemplateID,
+			&u.TemplateTitle,
+			&u.VersionID,
+			&u.VersionNumber,
+			&u.VersionStatus,
+		); err != nil {
+			return nil, fmt.Errorf("scanning asset usage: %w", err)
+		}
+		result = append(result, &u)
+	}
+
+	return result, rows.Err()
+}
+
+// StorageKeyInUse reports whether an asset version keeps its content under an object storage key.
+func (r *Repository) StorageKeyInUse(ctx context.Context, key string) (bool, error) {
+	var inUse bool
+	if err := common.Conn(ctx, r.pool).QueryRow(ctx, queryStorageKeyInUse, key).Scan(&inUse); err != nil {
+		return false, fmt.Errorf("checking asset storage key: %w", err)
+	}
+	return inUse, nil
+}
+
+// Update updates the name and tags of an asset.
+func (r *Repository) Update(ctx context.Context, asset *entity.Asset) error {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryUpdate, asset.ID, asset.Name, asset.Tags)
+	if err != nil {
+		return fmt.Errorf("updating asset: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrAssetNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes an asset and its versions.
+func (r *Repository) Delete(ctx context.Context, id string) error {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryDelete, id)
+	if err != nil {
+		return fmt.Errorf("deleting asset: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrAssetNotFound
+	}
+
+	return nil
+}
+
+// scanAsset scans a row of querySelectAsset.
+func scanAsset(row pgx.Row) (*entity.Asset, error) {
+	var a entity.Asset
+	if err := row.Scan(
+		&a.ID,
+		&a.WorkspaceID,
+		&a.Name,
+		&a.Kind,
+		&a.Tags,
+		&a.CurrentVersion,
+		&a.CreatedBy,
+		&a.CreatedAt,
+		&a.UpdatedAt,
+		&a.SourceURL,
+		&a.ContentType,
+		&a.SizeBytes,
+		&a.SHA256,
+	); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
@@ -0,0 +1,59 @@
+package authsessionrepo
+
+// SQL queries for auth session operations.
+const (
+	selectColumns = `
+		SELECT id, principal_type, provider, subject, user_id,
+		       COALESCE(email, ''), COALESCE(display_name, ''), COALESCE(ip_address, ''), COALESCE(user_agent, ''),
+		       request_count, first_seen_at, last_seen_at, revoked_at, revoked_by, reauth_required_at
+		FROM identity.auth_sessions`
+
+	// queryTouch keeps the known identity details when the credential does not carry them
+	queryTouch = `
+		INSERT INTO identity.auth_sessions (principal_type, provider, subject, user_id, email, display_name,
+		                                    ip_address, user_agent, request_count, first_seen_at, last_seen_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''), $9, $10, $10)
+		ON CONFLICT (principal_type, provider, subject)
+		DO UPDATE SET
+			user_id = COALESCE(EXCLUDED.user_id, identity.auth_sessions.user_id),
+			email = COALESCE(EXCLUDED.email, identity.auth_sessions.email),
+			display_name = COALESCE(EXCLUDED.display_name, identity.auth_sessions.display_name),
+			ip_address = COALESCE(EXCLUDED.ip_address, identity.auth_sessions.ip_address),
+			user_agent = COALESCE(EXCLUDED.user_agent, identity.auth_sessions.user_agent),
+			request_count = identity.auth_sessions.request_count + EXCLUDED.request_count,
+			last_seen_at = GREATEST(identity.auth_sessions.last_seen_at, EXCLUDED.last_seen_at)
+		RETURNING id, request_count, first_seen_at, revoked_at, revoked_by, reauth_required_at`
+
+	queryFindAll = selectColumns + `
+		WHERE ($1 = '' OR principal_type = $1)
+		  AND ($2 = '' OR subject ILIKE '%' || $2 || '%' OR email ILIKE '%' || $2 || '%' OR display_name ILIKE '%' || $2 || '%')
+		ORDER BY last_seen_at DESC
+		LIMIT $3 OFFSET $4`
+
+	queryCountAll = `
+		SELECT COUNT(*)
+		FROM identity.auth_sessions
+		WHERE ($1 = '' OR principal_type = $1)
+		  AND ($2 = '' OR subject ILIKE '%' || $2 || '%' OR email ILIKE '%' || $2 || '%' OR display_name ILIKE '%' || $2 || '%')`
+
+	queryFindByID = selectColumns + `
+		WHERE id = $1`
+
+	queryFindRestricted = selectColumns + `
+		WHERE revoked_at IS NOT NULL OR reauth_required_at IS NOT NULL`
+
+	queryRevoke = `
+		UPDATE identity.auth_sessions
+		SET revoked_at = CURRENT_TIMESTAMP, revoked_by = $2
+		WHERE id = $1`
+
+	queryRestore = `
+		UPDATE identity.auth_sessions
+		SET revoked_at = NULL, revoked_by = NULL
+		WHERE id = $1`
+
+	queryRequireReauth = `
+		UPDATE identity.auth_sessions
+		SET reauth_required_at = $2
+		WHERE id = $1`
+)
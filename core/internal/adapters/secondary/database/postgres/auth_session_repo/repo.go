@@ -0,0 +1,153 @@
+package authsessionrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new auth session repository.
+func New(pool *pgxpool.Pool) port.AuthSessionRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the auth session repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// Touch records activity for a principal, creating its session on first sight.
+func (r *Repository) Touch(ctx context.Context, session *entity.AuthSession, requests int64) error {
+	err := r.pool.QueryRow(ctx, queryTouch,
+		session.PrincipalType,
+		session.Provider,
+		session.Subject,
+		session.UserID,
+		session.Email,
+		session.DisplayName,
+		session.IPAddress,
+		session.UserAgent,
+		requests,
+		session.LastSeenAt,
+	).Scan(
+		&session.ID,
+		&session.RequestCount,
+		&session.FirstSeenAt,
+		&session.RevokedAt,
+		&session.RevokedBy,
+		&session.ReauthRequiredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("touching auth session: %w", err)
+	}
+	return nil
+}
+
+// FindAll lists sessions, most recently active first, with the total count.
+func (r *Repository) FindAll(ctx context.Context, filters port.AuthSessionFilters) ([]*entity.AuthSession, int64, error) {
+	var total int64
+	err := r.pool.QueryRow(ctx, queryCountAll, filters.PrincipalType, filters.Search).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("counting auth sessions: %w", err)
+	}
+
+	sessions, err := r.findMany(ctx, queryFindAll, filters.PrincipalType, filters.Search, filters.Limit, filters.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	return sessions, total, nil
+}
+
+// FindByID finds a session by ID.
+func (r *Repository) FindByID(ctx context.Context, id string) (*entity.AuthSession, error) {
+	session, err := scanSession(r.pool.QueryRow(ctx, queryFindByID, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying auth session: %w", err)
+	}
+	return session, nil
+}
+
+// FindRestricted lists sessions that are revoked or must re-authenticate.
+func (r *Repository) FindRestricted(ctx context.Context) ([]*entity.AuthSession, error) {
+	return r.findMany(ctx, queryFindRestricted)
+}
+
+// Revoke marks a session as revoked by the given user.
+func (r *Repository) Revoke(ctx context.Context, id, revokedBy string) error {
+	return r.update(ctx, "revoking", queryRevoke, id, revokedBy)
+}
+
+// Restore clears the revocation of a session.
+func (r *Repository) Restore(ctx context.Context, id string) error {
+	return r.update(ctx, "restoring", queryRestore, id)
+}
+
+// RequireReauth rejects credentials of the session issued before the given time.
+func (r *Repository) RequireReauth(ctx context.Context, id string, at time.Time) error {
+	return r.update(ctx, "forcing re-auth of", queryRequireReauth, id, at)
+}
+
+func (r *Repository) update(ctx context.Context, action, query string, args ...any) error {
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s auth session: %w", action, err)
+	}
+	if result.RowsAffected() == 0 {
+		return entity.ErrSessionNotFound
+	}
+	return nil
+}
+
+func (r *Repository) findMany(ctx context.Context, query string, args ...any) ([]*entity.AuthSession, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying auth sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*entity.AuthSession
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning auth session: %w", err)
+		}
+		result = append(result, session)
+	}
+
+	return result, rows.Err()
+}
+
+func scanSession(row pgx.Row) (*entity.AuthSession, error) {
+	var s entity.AuthSession
+	err := row.Scan(
+		&s.ID,
+		&s.PrincipalType,
+		&s.Provider,
+		&s.Subject,
+		&s.UserID,
+		&s.Email,
+		&s.DisplayName,
+		&s.IPAddress,
+		&s.UserAgent,
+		&s.RequestCount,
+		&s.FirstSeenAt,
+		&s.LastSeenAt,
+		&s.RevokedAt,
+		&s.RevokedBy,
+		&s.ReauthRequiredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
@@ -0,0 +1,75 @@
+package cleanuprepo
+
+// SQL queries for the orphaned data cleanup.
+const (
+	queryFindOrphanedVersionInjectables = `
+		SELECT tvi.id
+		FROM content.template_version_injectables tvi
+		LEFT JOIN content.template_versions v ON v.id = tvi.template_version_id
+		WHERE v.id IS NULL
+		ORDER BY tvi.created_at
+		LIMIT $1`
+
+	queryDeleteOrphanedVersionInjectables = `
+		DELETE FROM content.template_version_injectables tvi
+		WHERE tvi.id = ANY($1)
+		  AND NOT EXISTS (SELECT 1 FROM content.template_versions v WHERE v.id = tvi.template_version_id)`
+
+	// unusedImageCondition matches the image assets (aliased a) not changed since $1 whose URL appears
+	// in the content of no template version of their workspace, the same search as the asset usages.
+	// Imported images are also in use while templates still reference their remote URL.
+	unusedImageCondition = `
+		a.kind = 'IMAGE'
+		AND COALESCE(a.updated_at, a.created_at) < $1
+		AND NOT EXISTS (
+			SELECT 1
+			FROM content.template_versions v
+			JOIN content.templates t ON t.id = v.template_id
+			WHERE t.workspace_id = a.workspace_id
+			  AND (strpos(v.content_structure::TEXT, 'asset://' || a.id::TEXT) > 0
+			       OR strpos(v.content_structure::TEXT, a.source_url) > 0))`
+
+	queryFindUnusedImageAssets = `
+		SELECT a.id
+		FROM content.assets a
+		WHERE ` + unusedImageCondition + `
+		ORDER BY a.created_at
+		LIMIT $2`
+
+	queryDeleteUnusedImageAssets = `
+		DELETE FROM content.assets a
+		WHERE a.id = ANY($2) AND ` + unusedImageCondition
+
+	queryFindExpiredPreviewTokens = `
+		SELECT id
+		FROM content.version_preview_tokens
+		WHERE expires_at < $1 OR revoked_at < $1
+		ORDER BY created_at
+		LIMIT $2`
+
+	queryDeleteExpiredPreviewTokens = `
+		DELETE FROM content.version_preview_tokens
+		WHERE id = ANY($2) AND (expires_at < $1 OR revoked_at < $1)`
+
+	queryFindStaleSandboxes = `
+		SELECT workspace_id
+		FROM tenancy.workspace_sandboxes
+		WHERE expires_at < $1 OR source_workspace_id IS NULL
+		ORDER BY expires_at
+		LIMIT $2`
+
+	queryCreateRun = `
+		INSERT INTO tenancy.cleanup_runs (dry_run, results, triggered_by, started_at, finished_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	queryFindRuns = `
+		SELECT id, dry_run, results, triggered_by, started_at, finished_at
+		FROM tenancy.cleanup_runs
+		ORDER BY started_at DESC
+		LIMIT $1`
+
+	queryDeleteRunsBefore = `
+		DELETE FROM tenancy.cleanup_runs
+		WHERE started_at < $1`
+)
@@ -0,0 +1,170 @@
+package cleanuprepo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new cleanup repository.
+func New(pool *pgxpool.Pool) port.CleanupRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the cleanup repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// resultRecord is the stored form of a cleanup result in the results column.
+type resultRecord struct {
+	Category  entity.CleanupCategory `json:"category"`
+	Found     int                    `json:"found"`
+	Deleted   int                    `json:"deleted"`
+	Kept      bool                   `json:"kept,omitempty"`
+	SampleIDs []string               `json:"sampleIds,omitempty"`
+	Error     *string                `json:"error,omitempty"`
+}
+
+// FindOrphanedVersionInjectables returns version injectables whose template version no longer exists.
+func (r *Repository) FindOrphanedVersionInjectables(ctx context.Context, limit int) ([]string, error) {
+	return r.findIDs(ctx, "orphaned version injectables", queryFindOrphanedVersionInjectables, limit)
+}
+
+// DeleteOrphanedVersionInjectables deletes the given version injectables that are still orphaned.
+func (r *Repository) DeleteOrphanedVersionInjectables(ctx context.Context, ids []string) (int, error) {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryDeleteOrphanedVersionInjectables, ids)
+	if err != nil {
+		return 0, fmt.Errorf("deleting orphaned version injectables: %w", err)
+	}
+	return int(result.RowsAffected()), nil
+}
+
+// FindUnusedImageAssets returns image assets that no template version of their workspace references.
+func (r *Repository) FindUnusedImageAssets(ctx context.Context, before time.Time, limit int) ([]string, error) {
+	return r.findIDs(ctx, "unused image assets", queryFindUnusedImageAssets, before, limit)
+}
+
+// DeleteUnusedImageAssets deletes the given image assets that are still unused.
+func (r *Repository) DeleteUnusedImageAssets(ctx context.Context, ids []string, before time.Time) (int, error) {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryDeleteUnusedImageAssets, before, ids)
+	if err != nil {
+		return 0, fmt.Errorf("deleting unused image assets: %w", err)
+	}
+	return int(result.RowsAffected()), nil
+}
+
+// FindExpiredPreviewTokens returns preview tokens that expired or were revoked before before.
+func (r *Repository) FindExpiredPreviewTokens(ctx context.Context, before time.Time, limit int) ([]string, error) {
+	return r.findIDs(ctx, "expired preview tokens", queryFindExpiredPreviewTokens, before, limit)
+}
+
+// DeleteExpiredPreviewTokens deletes the given preview tokens that expired or were revoked before before.
+func (r *Repository) DeleteExpiredPreviewTokens(ctx context.Context, ids []string, before time.Time) (int, error) {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryDeleteExpiredPreviewTokens, before, ids)
+	if err != nil {
+		return 0, fmt.Errorf("deleting expired preview tokens: %w", err)
+	}
+	return int(result.RowsAffected()), nil
+}
+
+// FindStaleSandboxes returns sandbox workspaces that expired or whose source workspace was deleted.
+func (r *Repository) FindStaleSandboxes(ctx context.Context, now time.Time, limit int) ([]string, error) {
+	return r.findIDs(ctx, "stale sandboxes", queryFindStaleSandboxes, now, limit)
+}
+
+// findIDs runs a query that returns a single ID column.
+func (r *Repository) findIDs(ctx context.Context, what, query string, args ...any) ([]string, error) {
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying %s: %w", what, err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scanning %s: %w", what, err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating %s: %w", what, err)
+	}
+	return ids, nil
+}
+
+// CreateRun records a cleanup run.
+func (r *Repository) CreateRun(ctx context.Context, run *entity.CleanupRun) (string, error) {
+	records := make([]resultRecord, len(run.Results))
+	for i, res := range run.Results {
+		records[i] = resultRecord(*res)
+	}
+	results, err := json.Marshal(records)
+	if err != nil {
+		return "", fmt.Errorf("encoding cleanup results: %w", err)
+	}
+
+	var id string
+	err = common.Conn(ctx, r.pool).QueryRow(ctx, queryCreateRun,
+		run.DryRun,
+		results,
+		run.TriggeredBy,
+		run.StartedAt,
+		run.FinishedAt,
+	).Scan(&id)
+	if err != nil {
+		return "", fmt.Errorf("inserting cleanup run: %w", err)
+	}
+	return id, nil
+}
+
+// FindRuns lists the latest cleanup runs, newest first.
+func (r *Repository) FindRuns(ctx context.Context, limit int) ([]*entity.CleanupRun, error) {
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindRuns, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying cleanup runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []*entity.CleanupRun
+	for rows.Next() {
+		run := &entity.CleanupRun{}
+		var results []byte
+		if err := rows.Scan(&run.ID, &run.DryRun, &results, &run.TriggeredBy, &run.StartedAt, &run.FinishedAt); err != nil {
+			return nil, fmt.Errorf("scanning cleanup run: %w", err)
+		}
+
+		var records []resultRecord
+		if err := json.Unmarshal(results, &records); err != nil {
+			return nil, fmt.Errorf("decoding results of cleanup run %s: %w", run.ID, err)
+		}
+		run.Results = make([]*entity.CleanupResult, len(records))
+		for i, rec := range records {
+			res := entity.CleanupResult(rec)
+			run.Results[i] = &res
+		}
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating cleanup runs: %w", err)
+	}
+	return runs, nil
+}
+
+// DeleteRunsBefore removes the runs started before the given time.
+func (r *Repository) DeleteRunsBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryDeleteRunsBefore, before)
+	if err != nil {
+		return 0, fmt.Errorf("deleting cleanup runs: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
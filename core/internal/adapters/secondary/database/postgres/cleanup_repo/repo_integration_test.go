@@ -0,0 +1,63 @@
+//go:build integration
+
+package cleanuprepo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cleanuprepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/cleanup_repo"
+	"github.com/rendis/pdf-forge/core/internal/testutil/testpostgres"
+)
+
+func TestPostgresIntegration_UnusedImageAssets(t *testing.T) {
+	ctx := context.Background()
+	pg := testpostgres.Run(ctx, t)
+
+	pool, err := pg.NewPool(ctx)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	var tenantID, workspaceID, templateID string
+	require.NoError(t, pool.QueryRow(ctx,
+		`INSERT INTO tenancy.tenants (name, code) VALUES ('Acme', 'ACME') RETURNING id`).Scan(&tenantID))
+	require.NoError(t, pool.QueryRow(ctx,
+		`INSERT INTO tenancy.workspaces (tenant_id, name, type) VALUES ($1, 'Sales', 'CLIENT') RETURNING id`, tenantID).Scan(&workspaceID))
+	require.NoError(t, pool.QueryRow(ctx,
+		`INSERT INTO content.templates (workspace_id, title) VALUES ($1, 'Offer') RETURNING id`, workspaceID).Scan(&templateID))
+
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	insertImage := func(name string, sourceURL *string) string {
+		var id string
+		require.NoError(t, pool.QueryRow(ctx, `
+			INSERT INTO content.assets (workspace_id, name, kind, source_url, created_at)
+			VALUES ($1, $2, 'IMAGE', $3, $4) RETURNING id`, workspaceID, name, sourceURL, created).Scan(&id))
+		return id
+	}
+	logoURL, bannerURL := "https://cdn.example.com/logo.png", "https://cdn.example.com/banner.png"
+	referenced := insertImage("Referenced", nil)
+	imported := insertImage("Imported", &logoURL)
+	unused := insertImage("Unused", &bannerURL)
+
+	content := `{"type":"doc","content":[{"type":"image","attrs":{"src":"asset://` + referenced + `"}},` +
+		`{"type":"image","attrs":{"src":"` + logoURL + `"}}]}`
+	_, err = pool.Exec(ctx, `
+		INSERT INTO content.template_versions (template_id, version_number, name, status, content_structure)
+		VALUES ($1, 1, 'v1', 'PUBLISHED', $2::JSONB)`, templateID, content)
+	require.NoError(t, err)
+
+	repo := cleanuprepo.New(pool)
+	cutoff := created.Add(time.Hour)
+
+	ids, err := repo.FindUnusedImageAssets(ctx, cutoff, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{unused}, ids, "an imported image referenced by its remote URL is in use")
+
+	deleted, err := repo.DeleteUnusedImageAssets(ctx, []string{referenced, imported, unused}, cutoff)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+}
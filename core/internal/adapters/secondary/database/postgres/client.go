@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strconv"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
@@ -23,6 +24,22 @@ func NewPool(ctx context.Context, cfg *config.DatabaseConfig) (*pgxpool.Pool, er
 	poolConfig.MaxConns = int32(cfg.MaxPoolSize)
 	poolConfig.MinConns = int32(cfg.MinPoolSize)
 	poolConfig.MaxConnIdleTime = cfg.MaxIdleTimeDuration()
+	if cfg.MaxConnLifetimeSeconds > 0 {
+		poolConfig.MaxConnLifetime = cfg.MaxConnLifetimeDuration()
+	}
+	if cfg.HealthCheckPeriodSeconds > 0 {
+		poolConfig.HealthCheckPeriod = cfg.HealthCheckPeriodDuration()
+	}
+	if cfg.StatementTimeoutSeconds > 0 {
+		poolConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.StatementTimeoutDuration().Milliseconds(), 10)
+	}
+
+	// Bound every acquire and query by the caller's context or the configured timeouts
+	// (the pool picks up the acquire hooks from the connection tracer)
+	poolConfig.ConnConfig.Tracer = &deadlineTracer{
+		queryTimeout:   cfg.QueryTimeoutDuration(),
+		acquireTimeout: cfg.AcquireTimeoutDuration(),
+	}
 
 	// Create the pool
 	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
@@ -41,6 +58,8 @@ func NewPool(ctx context.Context, cfg *config.DatabaseConfig) (*pgxpool.Pool, er
 		slog.Int("port", cfg.Port),
 		slog.String("database", cfg.Name),
 		slog.Int("max_pool_size", cfg.MaxPoolSize),
+		slog.Int("statement_timeout_seconds", cfg.StatementTimeoutSeconds),
+		slog.Int("query_timeout_seconds", cfg.QueryTimeoutSeconds),
 	)
 
 	return pool, nil
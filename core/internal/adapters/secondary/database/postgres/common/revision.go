@@ -0,0 +1,22 @@
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// StaleOrMissing explains why an UPDATE guarded by "AND revision = $n" matched no row:
+// entity.ErrRevisionConflict when the row still exists (someone else updated it first),
+// notFound otherwise. existsQuery must select a single boolean.
+func StaleOrMissing(ctx context.Context, q Querier, notFound error, existsQuery string, args ...any) error {
+	var exists bool
+	if err := q.QueryRow(ctx, existsQuery, args...).Scan(&exists); err != nil {
+		return fmt.Errorf("checking row existence: %w", err)
+	}
+	if exists {
+		return entity.ErrRevisionConflict
+	}
+	return notFound
+}
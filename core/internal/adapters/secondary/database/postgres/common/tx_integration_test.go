@@ -0,0 +1,86 @@
+//go:build integration
+
+package common_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	"github.com/rendis/pdf-forge/core/internal/testutil/testpostgres"
+)
+
+func TestPostgresIntegration_TxManager(t *testing.T) {
+	ctx := context.Background()
+	pg := testpostgres.Run(ctx, t)
+
+	pool, err := pg.NewPool(ctx)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	_, err = pool.Exec(ctx, `CREATE TABLE tx_probe (name TEXT PRIMARY KEY)`)
+	require.NoError(t, err)
+
+	txm := common.NewTxManager(pool)
+	insert := func(ctx context.Context, name string) error {
+		_, err := common.Conn(ctx, pool).Exec(ctx, `INSERT INTO tx_probe (name) VALUES ($1)`, name)
+		return err
+	}
+	exists := func(name string) bool {
+		var found bool
+		require.NoError(t, pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM tx_probe WHERE name = $1)`, name).Scan(&found))
+		return found
+	}
+
+	t.Run("commits when fn succeeds", func(t *testing.T) {
+		require.NoError(t, txm.WithinTx(ctx, func(ctx context.Context) error {
+			return insert(ctx, "committed")
+		}))
+		assert.True(t, exists("committed"))
+	})
+
+	t.Run("rolls back when fn fails", func(t *testing.T) {
+		boom := errors.New("boom")
+		err := txm.WithinTx(ctx, func(ctx context.Context) error {
+			require.NoError(t, insert(ctx, "failed"))
+			return boom
+		})
+		assert.ErrorIs(t, err, boom)
+		assert.False(t, exists("failed"))
+	})
+
+	t.Run("rolls back when fn panics", func(t *testing.T) {
+		assert.Panics(t, func() {
+			_ = txm.WithinTx(ctx, func(ctx context.Context) error {
+				require.NoError(t, insert(ctx, "panicked"))
+				panic("boom")
+			})
+		})
+		assert.False(t, exists("panicked"))
+		assert.Zero(t, pool.Stat().AcquiredConns(), "the connection goes back to the pool")
+	})
+
+	t.Run("nested calls join the outer transaction", func(t *testing.T) {
+		boom := errors.New("boom")
+		err := txm.WithinTx(ctx, func(outer context.Context) error {
+			require.NoError(t, txm.WithinTx(outer, func(inner context.Context) error {
+				assert.Same(t, common.Conn(outer, pool), common.Conn(inner, pool))
+				return insert(inner, "nested")
+			}))
+			assert.False(t, exists("nested"), "the inner call must not commit on its own")
+			return boom
+		})
+		assert.ErrorIs(t, err, boom)
+		assert.False(t, exists("nested"), "the outer rollback undoes the inner write")
+	})
+
+	t.Run("conn uses the pool outside a transaction", func(t *testing.T) {
+		_, isPool := common.Conn(ctx, pool).(*pgxpool.Pool)
+		assert.True(t, isPool)
+	})
+}
@@ -0,0 +1,31 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// stubTx stands in for a live transaction; Conn only needs to hand it back.
+type stubTx struct {
+	pgx.Tx
+}
+
+func TestConn_UsesTransactionFromContext(t *testing.T) {
+	pool := &pgxpool.Pool{}
+	tx := &stubTx{}
+
+	if got := Conn(context.WithValue(context.Background(), txKey{}, pgx.Tx(tx)), pool); got != tx {
+		t.Errorf("Conn() = %T, want the transaction of the context", got)
+	}
+}
+
+func TestConn_FallsBackToPool(t *testing.T) {
+	pool := &pgxpool.Pool{}
+
+	if got := Conn(context.Background(), pool); got != pool {
+		t.Errorf("Conn() = %T, want the pool", got)
+	}
+}
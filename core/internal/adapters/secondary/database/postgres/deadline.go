@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type (
+	cancelKey     struct{}
+	noDeadlineKey struct{}
+)
+
+// WithoutQueryTimeout marks ctx so queries run with it are only bounded by the context
+// itself (waiting for a connection stays bounded). Use it for long-running maintenance
+// work such as backups and restores.
+func WithoutQueryTimeout(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noDeadlineKey{}, true)
+}
+
+// deadlineTracer gives every pool acquire and query a deadline derived from the caller's
+// context: the context deadline when it is sooner, the configured timeout otherwise.
+// Repositories therefore never block a request or a background job indefinitely, even
+// when called with a context that has no deadline.
+type deadlineTracer struct {
+	queryTimeout   time.Duration
+	acquireTimeout time.Duration
+}
+
+var (
+	_ pgx.QueryTracer       = (*deadlineTracer)(nil)
+	_ pgxpool.AcquireTracer = (*deadlineTracer)(nil)
+)
+
+// TraceQueryStart bounds Query, QueryRow and Exec calls.
+func (t *deadlineTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	if ctx.Value(noDeadlineKey{}) != nil {
+		return withDeadline(ctx, 0)
+	}
+	return withDeadline(ctx, t.queryTimeout)
+}
+
+// TraceQueryEnd releases the deadline set by TraceQueryStart.
+func (t *deadlineTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryEndData) {
+	release(ctx)
+}
+
+// TraceAcquireStart bounds the wait for a free connection when the pool is exhausted.
+func (t *deadlineTracer) TraceAcquireStart(ctx context.Context, _ *pgxpool.Pool, _ pgxpool.TraceAcquireStartData) context.Context {
+	return withDeadline(ctx, t.acquireTimeout)
+}
+
+// TraceAcquireEnd releases the deadline set by TraceAcquireStart.
+func (t *deadlineTracer) TraceAcquireEnd(ctx context.Context, _ *pgxpool.Pool, _ pgxpool.TraceAcquireEndData) {
+	release(ctx)
+}
+
+// withDeadline returns ctx bounded by timeout unless it already ends sooner.
+// The returned context always carries its own cancel func so release never
+// cancels a context owned by an enclosing operation.
+func withDeadline(ctx context.Context, timeout time.Duration) context.Context {
+	if timeout <= 0 {
+		return context.WithValue(ctx, cancelKey{}, context.CancelFunc(func() {}))
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= timeout {
+		return context.WithValue(ctx, cancelKey{}, context.CancelFunc(func() {}))
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return context.WithValue(ctx, cancelKey{}, cancel)
+}
+
+// release cancels the deadline attached by withDeadline.
+func release(ctx context.Context) {
+	if cancel, ok := ctx.Value(cancelKey{}).(context.CancelFunc); ok {
+		cancel()
+	}
+}
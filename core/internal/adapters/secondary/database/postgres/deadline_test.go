@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func TestDeadlineTracer(t *testing.T) {
+	tracer := &deadlineTracer{queryTimeout: time.Minute, acquireTimeout: time.Second}
+
+	t.Run("adds a deadline to contexts without one", func(t *testing.T) {
+		ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{})
+		deadline, ok := ctx.Deadline()
+		if !ok || time.Until(deadline) > time.Minute {
+			t.Fatalf("deadline = %v, %v; want within a minute", deadline, ok)
+		}
+		tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+		if ctx.Err() == nil {
+			t.Error("query context not released")
+		}
+	})
+
+	t.Run("keeps a sooner request deadline", func(t *testing.T) {
+		parent, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		want, _ := parent.Deadline()
+
+		ctx := tracer.TraceQueryStart(parent, nil, pgx.TraceQueryStartData{})
+		if got, _ := ctx.Deadline(); !got.Equal(want) {
+			t.Errorf("deadline = %v, want %v", got, want)
+		}
+		tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+		if parent.Err() != nil {
+			t.Error("releasing the query canceled the request context")
+		}
+	})
+
+	t.Run("caps a later request deadline", func(t *testing.T) {
+		parent, cancel := context.WithTimeout(context.Background(), time.Hour)
+		defer cancel()
+
+		ctx := tracer.TraceQueryStart(parent, nil, pgx.TraceQueryStartData{})
+		if deadline, _ := ctx.Deadline(); time.Until(deadline) > time.Minute {
+			t.Errorf("deadline = %v, want within a minute", deadline)
+		}
+		tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+	})
+
+	t.Run("opt out skips the query timeout", func(t *testing.T) {
+		ctx := tracer.TraceQueryStart(WithoutQueryTimeout(context.Background()), nil, pgx.TraceQueryStartData{})
+		if _, ok := ctx.Deadline(); ok {
+			t.Error("expected no deadline")
+		}
+		tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+	})
+}
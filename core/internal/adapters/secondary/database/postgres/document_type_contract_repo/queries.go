@@ -0,0 +1,34 @@
+package documenttypecontractrepo
+
+// SQL queries for document type contract operations.
+const (
+	queryFindByDocumentTypeID = `
+		SELECT document_type_id, schema, example, updated_by, created_at, updated_at
+		FROM content.document_type_contracts
+		WHERE document_type_id = $1`
+
+	// The tenant's own document type takes priority over the global one of the SYS tenant.
+	queryFindByDocumentTypeCode = `
+		SELECT c.document_type_id, c.schema, c.example, c.updated_by, c.created_at, c.updated_at
+		FROM content.document_types dt
+		JOIN tenancy.tenants t ON t.id = dt.tenant_id
+		LEFT JOIN content.document_type_contracts c ON c.document_type_id = dt.id
+		WHERE dt.code = $2 AND (t.code = $1 OR t.is_system = true)
+		ORDER BY CASE WHEN t.code = $1 THEN 0 ELSE 1 END
+		LIMIT 1`
+
+	queryUpsert = `
+		INSERT INTO content.document_type_contracts (document_type_id, schema, example, updated_by, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (document_type_id)
+		DO UPDATE SET
+			schema = EXCLUDED.schema,
+			example = EXCLUDED.example,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING created_at, updated_at`
+
+	queryDelete = `
+		DELETE FROM content.document_type_contracts
+		WHERE document_type_id = $1`
+)
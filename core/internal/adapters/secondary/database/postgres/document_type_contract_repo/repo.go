@@ -0,0 +1,95 @@
+package documenttypecontractrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new document type contract repository.
+func New(pool *pgxpool.Pool) port.DocumentTypeContractRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the document type contract repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// FindByDocumentTypeID finds the contract of a document type.
+func (r *Repository) FindByDocumentTypeID(ctx context.Context, documentTypeID string) (*entity.DocumentTypeContract, error) {
+	row := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindByDocumentTypeID, documentTypeID)
+	return scanContract(row)
+}
+
+// FindByDocumentTypeCode finds the contract of the document type a tenant renders for a code.
+func (r *Repository) FindByDocumentTypeCode(ctx context.Context, tenantCode, documentTypeCode string) (*entity.DocumentTypeContract, error) {
+	row := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindByDocumentTypeCode, tenantCode, documentTypeCode)
+	return scanContract(row)
+}
+
+// Upsert creates or replaces the contract of a document type.
+func (r *Repository) Upsert(ctx context.Context, contract *entity.DocumentTypeContract) error {
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryUpsert,
+		contract.DocumentTypeID,
+		contract.Schema,
+		contract.Example,
+		contract.UpdatedBy,
+		contract.CreatedAt,
+	).Scan(&contract.CreatedAt, &contract.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upserting document type contract: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the contract of a document type.
+func (r *Repository) Delete(ctx context.Context, documentTypeID string) error {
+	tag, err := common.Conn(ctx, r.pool).Exec(ctx, queryDelete, documentTypeID)
+	if err != nil {
+		return fmt.Errorf("deleting document type contract: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrPayloadContractNotFound
+	}
+	return nil
+}
+
+// scanContract scans a contract row. A row without a document type ID is a document type
+// without a contract.
+func scanContract(row pgx.Row) (*entity.DocumentTypeContract, error) {
+	var (
+		documentTypeID *string
+		createdAt      *time.Time
+		contract       entity.DocumentTypeContract
+	)
+	err := row.Scan(
+		&documentTypeID,
+		&contract.Schema,
+		&contract.Example,
+		&contract.UpdatedBy,
+		&createdAt,
+		&contract.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrPayloadContractNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying document type contract: %w", err)
+	}
+	if documentTypeID == nil {
+		return nil, entity.ErrPayloadContractNotFound
+	}
+
+	contract.DocumentTypeID = *documentTypeID
+	contract.CreatedAt = *createdAt
+	return &contract, nil
+}
@@ -0,0 +1,34 @@
+package eventwebhookrepo
+
+// SQL queries for workspace event webhook operations.
+const (
+	queryCreate = `
+		INSERT INTO tenancy.event_webhooks (
+			id, workspace_id, name, url, secret, event_types, enabled, created_by, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id`
+
+	querySelectColumns = `
+		SELECT id, workspace_id, name, url, secret, event_types, enabled, created_by, created_at, updated_at
+		FROM tenancy.event_webhooks`
+
+	queryFindByID = querySelectColumns + `
+		WHERE workspace_id = $1 AND id = $2`
+
+	queryFindByWorkspace = querySelectColumns + `
+		WHERE workspace_id = $1
+		ORDER BY created_at`
+
+	queryFindEnabledByWorkspace = querySelectColumns + `
+		WHERE workspace_id = $1 AND enabled = true`
+
+	queryUpdate = `
+		UPDATE tenancy.event_webhooks
+		SET name = $3, url = $4, secret = $5, event_types = $6, enabled = $7, updated_at = $8
+		WHERE workspace_id = $1 AND id = $2`
+
+	queryDelete = `
+		DELETE FROM tenancy.event_webhooks
+		WHERE workspace_id = $1 AND id = $2`
+)
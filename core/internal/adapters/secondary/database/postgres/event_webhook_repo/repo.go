@@ -0,0 +1,156 @@
+package eventwebhookrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new event webhook repository.
+func New(pool *pgxpool.Pool) port.EventWebhookRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the event webhook repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// Create creates a new webhook.
+func (r *Repository) Create(ctx context.Context, webhook *entity.EventWebhook) (string, error) {
+	var id string
+	err := r.pool.QueryRow(ctx, queryCreate,
+		webhook.ID,
+		webhook.WorkspaceID,
+		webhook.Name,
+		webhook.URL,
+		webhook.Secret,
+		eventTypesToStrings(webhook.EventTypes),
+		webhook.Enabled,
+		webhook.CreatedBy,
+		webhook.CreatedAt,
+	).Scan(&id)
+	if err != nil {
+		return "", fmt.Errorf("inserting event webhook: %w", err)
+	}
+
+	return id, nil
+}
+
+// FindByID finds a webhook by ID within a workspace.
+func (r *Repository) FindByID(ctx context.Context, workspaceID, id string) (*entity.EventWebhook, error) {
+	webhook, err := scanWebhook(r.pool.QueryRow(ctx, queryFindByID, workspaceID, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrEventWebhookNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying event webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+// FindByWorkspace lists all webhooks of a workspace.
+func (r *Repository) FindByWorkspace(ctx context.Context, workspaceID string) ([]*entity.EventWebhook, error) {
+	return r.findMany(ctx, queryFindByWorkspace, workspaceID)
+}
+
+// FindEnabledByWorkspace lists enabled webhooks of a workspace.
+func (r *Repository) FindEnabledByWorkspace(ctx context.Context, workspaceID string) ([]*entity.EventWebhook, error) {
+	return r.findMany(ctx, queryFindEnabledByWorkspace, workspaceID)
+}
+
+// Update updates a webhook.
+func (r *Repository) Update(ctx context.Context, webhook *entity.EventWebhook) error {
+	result, err := r.pool.Exec(ctx, queryUpdate,
+		webhook.WorkspaceID,
+		webhook.ID,
+		webhook.Name,
+		webhook.URL,
+		webhook.Secret,
+		eventTypesToStrings(webhook.EventTypes),
+		webhook.Enabled,
+		webhook.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("updating event webhook: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrEventWebhookNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes a webhook within a workspace. Its deliveries are removed by cascade.
+func (r *Repository) Delete(ctx context.Context, workspaceID, id string) error {
+	result, err := r.pool.Exec(ctx, queryDelete, workspaceID, id)
+	if err != nil {
+		return fmt.Errorf("deleting event webhook: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrEventWebhookNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) findMany(ctx context.Context, query, workspaceID string) ([]*entity.EventWebhook, error) {
+	rows, err := r.pool.Query(ctx, query, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("querying event webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*entity.EventWebhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning event webhook: %w", err)
+		}
+		result = append(result, webhook)
+	}
+
+	return result, rows.Err()
+}
+
+func scanWebhook(row pgx.Row) (*entity.EventWebhook, error) {
+	var w entity.EventWebhook
+	var eventTypes []string
+	err := row.Scan(
+		&w.ID,
+		&w.WorkspaceID,
+		&w.Name,
+		&w.URL,
+		&w.Secret,
+		&eventTypes,
+		&w.Enabled,
+		&w.CreatedBy,
+		&w.CreatedAt,
+		&w.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	w.EventTypes = make([]entity.DomainEventType, len(eventTypes))
+	for i, t := range eventTypes {
+		w.EventTypes[i] = entity.DomainEventType(t)
+	}
+	return &w, nil
+}
+
+func eventTypesToStrings(types []entity.DomainEventType) []string {
+	out := make([]string, len(types))
+	for i, t := range types {
+		out[i] = string(t)
+	}
+	return out
+}
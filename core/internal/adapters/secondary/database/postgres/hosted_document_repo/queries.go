@@ -0,0 +1,74 @@
+package hosteddocumentrepo
+
+// SQL queries for hosted document operations.
+const (
+	queryCreate = `
+		INSERT INTO content.hosted_documents (
+			id, workspace_id, filename, pdf, size_bytes, page_count, access, token_hash,
+			allow_download, expires_at, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id`
+
+	queryFindByID = `
+		SELECT id, workspace_id, filename, pdf, size_bytes, page_count, access, token_hash,
+		       allow_download, expires_at, view_count, last_viewed_at, created_at,
+		       thumbnail IS NOT NULL, indexed_at, index_error
+		FROM content.hosted_documents
+		WHERE id = $1`
+
+	queryFindByWorkspace = `
+		SELECT id, workspace_id, filename, size_bytes, page_count, access, token_hash,
+		       allow_download, expires_at, view_count, last_viewed_at, created_at,
+		       thumbnail IS NOT NULL, indexed_at, index_error, NULL::TEXT
+		FROM content.hosted_documents
+		WHERE workspace_id = $1
+		ORDER BY created_at DESC`
+
+	// querySearchByWorkspace matches filename and extracted text.
+	// The tsvector expression must match idx_hosted_documents_search (migration 000026).
+	querySearchByWorkspace = `
+		SELECT id, workspace_id, filename, size_bytes, page_count, access, token_hash,
+		       allow_download, expires_at, view_count, last_viewed_at, created_at,
+		       thumbnail IS NOT NULL, indexed_at, index_error,
+		       NULLIF(ts_headline('simple', COALESCE(text_content, ''), q, 'MaxFragments=2, MaxWords=20, MinWords=5'), '')
+		FROM content.hosted_documents, websearch_to_tsquery('simple', $2) q
+		WHERE workspace_id = $1
+		  AND to_tsvector('simple', filename || ' ' || COALESCE(text_content, '')) @@ q
+		ORDER BY ts_rank(to_tsvector('simple', filename || ' ' || COALESCE(text_content, '')), q) DESC, created_at DESC`
+
+	queryFindThumbnail = `
+		SELECT thumbnail
+		FROM content.hosted_documents
+		WHERE id = $1 AND workspace_id = $2`
+
+	// queryClaimForIndexing skips rows locked by another indexer so instances never block each other.
+	queryClaimForIndexing = `
+		UPDATE content.hosted_documents
+		SET index_attempts = index_attempts + 1, index_available_at = NOW() + $2 * INTERVAL '1 millisecond'
+		WHERE id IN (
+			SELECT id FROM content.hosted_documents
+			WHERE indexed_at IS NULL AND index_error IS NULL AND index_available_at <= NOW() AND expires_at > NOW()
+			ORDER BY index_available_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, workspace_id, pdf, index_attempts`
+
+	querySaveIndex = `
+		UPDATE content.hosted_documents
+		SET thumbnail = $2, text_content = $3, indexed_at = NOW(), index_error = NULL
+		WHERE id = $1`
+
+	queryMarkIndexFailed = `
+		UPDATE content.hosted_documents
+		SET index_error = $2
+		WHERE id = $1`
+
+	queryRecordView = `
+		UPDATE content.hosted_documents
+		SET view_count = view_count + 1, last_viewed_at = NOW()
+		WHERE id = $1`
+
+	queryDelete = `DELETE FROM content.hosted_documents WHERE id = $1`
+)
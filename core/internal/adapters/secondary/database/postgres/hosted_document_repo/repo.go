@@ -0,0 +1,194 @@
+package hosteddocumentrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new hosted document repository.
+func New(pool *pgxpool.Pool) port.HostedDocumentRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the hosted document repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// Create stores a hosted document with its PDF.
+func (r *Repository) Create(ctx context.Context, doc *entity.HostedDocument) (string, error) {
+	var id string
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryCreate,
+		doc.ID,
+		doc.WorkspaceID,
+		doc.Filename,
+		doc.PDF,
+		doc.SizeBytes,
+		doc.PageCount,
+		doc.Access,
+		doc.TokenHash,
+		doc.AllowDownload,
+		doc.ExpiresAt,
+		doc.CreatedAt,
+	).Scan(&id)
+	if err != nil {
+		return "", fmt.Errorf("inserting hosted document: %w", err)
+	}
+
+	return id, nil
+}
+
+// FindByID finds a hosted document by ID, including its PDF.
+func (r *Repository) FindByID(ctx context.Context, id string) (*entity.HostedDocument, error) {
+	var d entity.HostedDocument
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindByID, id).Scan(
+		&d.ID,
+		&d.WorkspaceID,
+		&d.Filename,
+		&d.PDF,
+		&d.SizeBytes,
+		&d.PageCount,
+		&d.Access,
+		&d.TokenHash,
+		&d.AllowDownload,
+		&d.ExpiresAt,
+		&d.ViewCount,
+		&d.LastViewedAt,
+		&d.CreatedAt,
+		&d.HasThumbnail,
+		&d.IndexedAt,
+		&d.IndexError,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrHostedDocumentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying hosted document: %w", err)
+	}
+
+	return &d, nil
+}
+
+// FindByWorkspace lists the hosted documents of a workspace without their PDFs,
+// newest first or, for a non-empty query, by relevance.
+func (r *Repository) FindByWorkspace(ctx context.Context, workspaceID, query string) ([]*entity.HostedDocument, error) {
+	sql, args := queryFindByWorkspace, []any{workspaceID}
+	if query != "" {
+		sql, args = querySearchByWorkspace, append(args, query)
+	}
+
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying hosted documents: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*entity.HostedDocument
+	for rows.Next() {
+		var d entity.HostedDocument
+		if err := rows.Scan(
+			&d.ID,
+			&d.WorkspaceID,
+			&d.Filename,
+			&d.SizeBytes,
+			&d.PageCount,
+			&d.Access,
+			&d.TokenHash,
+			&d.AllowDownload,
+			&d.ExpiresAt,
+			&d.ViewCount,
+			&d.LastViewedAt,
+			&d.CreatedAt,
+			&d.HasThumbnail,
+			&d.IndexedAt,
+			&d.IndexError,
+			&d.SearchSnippet,
+		); err != nil {
+			return nil, fmt.Errorf("scanning hosted document: %w", err)
+		}
+		result = append(result, &d)
+	}
+
+	return result, rows.Err()
+}
+
+// RecordView increments the view count of a document.
+func (r *Repository) RecordView(ctx context.Context, id string) error {
+	if _, err := common.Conn(ctx, r.pool).Exec(ctx, queryRecordView, id); err != nil {
+		return fmt.Errorf("recording hosted document view: %w", err)
+	}
+	return nil
+}
+
+// FindThumbnail returns the thumbnail of a workspace document, or nil when it is not generated yet.
+func (r *Repository) FindThumbnail(ctx context.Context, workspaceID, id string) ([]byte, error) {
+	var thumbnail []byte
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindThumbnail, id, workspaceID).Scan(&thumbnail)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrHostedDocumentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying hosted document thumbnail: %w", err)
+	}
+
+	return thumbnail, nil
+}
+
+// ClaimForIndexing claims up to limit unexpired documents waiting for indexing, with their PDFs.
+func (r *Repository) ClaimForIndexing(ctx context.Context, limit int, lease time.Duration) ([]*entity.HostedDocument, error) {
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryClaimForIndexing, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, fmt.Errorf("claiming hosted documents for indexing: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*entity.HostedDocument
+	for rows.Next() {
+		var d entity.HostedDocument
+		if err := rows.Scan(&d.ID, &d.WorkspaceID, &d.PDF, &d.IndexAttempts); err != nil {
+			return nil, fmt.Errorf("scanning hosted document: %w", err)
+		}
+		result = append(result, &d)
+	}
+
+	return result, rows.Err()
+}
+
+// SaveIndex stores the thumbnail and text of a document and marks it indexed.
+func (r *Repository) SaveIndex(ctx context.Context, index *entity.HostedDocumentIndex) error {
+	if _, err := common.Conn(ctx, r.pool).Exec(ctx, querySaveIndex, index.DocumentID, index.Thumbnail, index.Text); err != nil {
+		return fmt.Errorf("saving hosted document index: %w", err)
+	}
+	return nil
+}
+
+// MarkIndexFailed records that indexing a document was given up.
+func (r *Repository) MarkIndexFailed(ctx context.Context, id, reason string) error {
+	if _, err := common.Conn(ctx, r.pool).Exec(ctx, queryMarkIndexFailed, id, reason); err != nil {
+		return fmt.Errorf("marking hosted document index failed: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a hosted document.
+func (r *Repository) Delete(ctx context.Context, id string) error {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryDelete, id)
+	if err != nil {
+		return fmt.Errorf("deleting hosted document: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrHostedDocumentNotFound
+	}
+
+	return nil
+}
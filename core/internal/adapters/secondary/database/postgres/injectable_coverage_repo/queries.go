@@ -0,0 +1,30 @@
+package injectablecoveragerepo
+
+// SQL queries for injectable coverage operations.
+const (
+	// queryAddDays adds to the counts of each day, creating the missing days. Counts of templates
+	// deleted since the render are dropped.
+	queryAddDays = `
+		INSERT INTO content.template_injectable_coverage AS c (
+			template_id, injectable_key, day, renders, from_payload, from_default, empty
+		)
+		SELECT d.*
+		FROM unnest($1::uuid[], $2::varchar[], $3::date[], $4::int[], $5::int[], $6::int[], $7::int[])
+		     AS d(template_id, injectable_key, day, renders, from_payload, from_default, empty)
+		JOIN content.templates t ON t.id = d.template_id
+		ON CONFLICT (template_id, injectable_key, day)
+		DO UPDATE SET
+			renders = c.renders + EXCLUDED.renders,
+			from_payload = c.from_payload + EXCLUDED.from_payload,
+			from_default = c.from_default + EXCLUDED.from_default,
+			empty = c.empty + EXCLUDED.empty`
+
+	queryFindByTemplate = `
+		SELECT injectable_key, SUM(renders), SUM(from_payload), SUM(from_default), SUM(empty), MAX(day)
+		FROM content.template_injectable_coverage
+		WHERE template_id = $1 AND day >= $2
+		GROUP BY injectable_key
+		ORDER BY injectable_key`
+
+	queryDeleteBefore = `DELETE FROM content.template_injectable_coverage WHERE day < $1`
+)
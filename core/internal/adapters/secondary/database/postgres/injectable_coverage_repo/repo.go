@@ -0,0 +1,79 @@
+package injectablecoveragerepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new injectable coverage repository.
+func New(pool *pgxpool.Pool) port.InjectableCoverageRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the injectable coverage repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// AddDays adds counts to the per-day counts of their templates and injectables in one statement.
+func (r *Repository) AddDays(ctx context.Context, days []*entity.InjectableCoverageDay) error {
+	if len(days) == 0 {
+		return nil
+	}
+
+	templateIDs := make([]string, len(days))
+	keys := make([]string, len(days))
+	dates := make([]time.Time, len(days))
+	renders := make([]int64, len(days))
+	fromPayload := make([]int64, len(days))
+	fromDefault := make([]int64, len(days))
+	empty := make([]int64, len(days))
+	for i, d := range days {
+		templateIDs[i], keys[i], dates[i] = d.TemplateID, d.InjectableKey, d.Day
+		renders[i], fromPayload[i], fromDefault[i], empty[i] = int64(d.Renders), int64(d.FromPayload), int64(d.FromDefault), int64(d.Empty)
+	}
+
+	_, err := common.Conn(ctx, r.pool).Exec(ctx, queryAddDays, templateIDs, keys, dates, renders, fromPayload, fromDefault, empty)
+	if err != nil {
+		return fmt.Errorf("adding injectable coverage days: %w", err)
+	}
+	return nil
+}
+
+// FindByTemplate sums the counts of each injectable of a template since a day.
+func (r *Repository) FindByTemplate(ctx context.Context, templateID string, since time.Time) ([]*entity.InjectableCoverage, error) {
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindByTemplate, templateID, since)
+	if err != nil {
+		return nil, fmt.Errorf("querying injectable coverage: %w", err)
+	}
+	defer rows.Close()
+
+	coverages := []*entity.InjectableCoverage{}
+	for rows.Next() {
+		var c entity.InjectableCoverage
+		if err := rows.Scan(&c.InjectableKey, &c.Renders, &c.FromPayload, &c.FromDefault, &c.Empty, &c.LastRenderedAt); err != nil {
+			return nil, fmt.Errorf("scanning injectable coverage: %w", err)
+		}
+		coverages = append(coverages, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating injectable coverage: %w", err)
+	}
+	return coverages, nil
+}
+
+// DeleteBefore deletes the counts of the days before a day.
+func (r *Repository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryDeleteBefore, before)
+	if err != nil {
+		return 0, fmt.Errorf("deleting injectable coverage days: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
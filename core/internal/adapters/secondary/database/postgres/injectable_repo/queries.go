@@ -3,29 +3,29 @@ package injectablerepo
 // SQL queries for injectable definitions (read-only operations).
 const (
 	queryFindByID = `
-		SELECT id, workspace_id, key, label, description, data_type, metadata, format_config, is_active, is_deleted, created_at, updated_at
+		SELECT id, workspace_id, key, label, description, data_type, metadata, format_config, formula, is_active, is_deleted, created_at, updated_at
 		FROM content.injectable_definitions
 		WHERE id = $1 AND is_active = true AND is_deleted = false`
 
 	queryFindByWorkspace = `
-		SELECT id, workspace_id, key, label, description, data_type, metadata, format_config, is_active, is_deleted, created_at, updated_at
+		SELECT id, workspace_id, key, label, description, data_type, metadata, format_config, formula, is_active, is_deleted, created_at, updated_at
 		FROM content.injectable_definitions
 		WHERE (workspace_id = $1 OR workspace_id IS NULL) AND is_active = true AND is_deleted = false
 		ORDER BY key`
 
 	queryFindGlobal = `
-		SELECT id, workspace_id, key, label, description, data_type, metadata, format_config, is_active, is_deleted, created_at, updated_at
+		SELECT id, workspace_id, key, label, description, data_type, metadata, format_config, formula, is_active, is_deleted, created_at, updated_at
 		FROM content.injectable_definitions
 		WHERE workspace_id IS NULL AND is_active = true AND is_deleted = false
 		ORDER BY key`
 
 	queryFindByKeyGlobal = `
-		SELECT id, workspace_id, key, label, description, data_type, metadata, format_config, is_active, is_deleted, created_at, updated_at
+		SELECT id, workspace_id, key, label, description, data_type, metadata, format_config, formula, is_active, is_deleted, created_at, updated_at
 		FROM content.injectable_definitions
 		WHERE workspace_id IS NULL AND key = $1 AND is_active = true AND is_deleted = false`
 
 	queryFindByKeyWorkspace = `
-		SELECT id, workspace_id, key, label, description, data_type, metadata, format_config, is_active, is_deleted, created_at, updated_at
+		SELECT id, workspace_id, key, label, description, data_type, metadata, format_config, formula, is_active, is_deleted, created_at, updated_at
 		FROM content.injectable_definitions
 		WHERE (workspace_id = $1 OR workspace_id IS NULL) AND key = $2 AND is_active = true AND is_deleted = false
 		ORDER BY workspace_id NULLS LAST
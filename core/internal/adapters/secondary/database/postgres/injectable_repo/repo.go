@@ -35,6 +35,7 @@ func (r *Repository) FindByID(ctx context.Context, id string) (*entity.Injectabl
 		&injectable.DataType,
 		&injectable.Metadata,
 		&injectable.FormatConfig,
+		&injectable.Formula,
 		&injectable.IsActive,
 		&injectable.IsDeleted,
 		&injectable.CreatedAt,
@@ -71,6 +72,7 @@ func (r *Repository) FindByWorkspace(ctx context.Context, workspaceID string) ([
 			&injectable.DataType,
 			&injectable.Metadata,
 			&injectable.FormatConfig,
+			&injectable.Formula,
 			&injectable.IsActive,
 			&injectable.IsDeleted,
 			&injectable.CreatedAt,
@@ -109,6 +111,7 @@ func (r *Repository) FindGlobal(ctx context.Context) ([]*entity.InjectableDefini
 			&injectable.DataType,
 			&injectable.Metadata,
 			&injectable.FormatConfig,
+			&injectable.Formula,
 			&injectable.IsActive,
 			&injectable.IsDeleted,
 			&injectable.CreatedAt,
@@ -150,6 +153,7 @@ func (r *Repository) FindByKey(ctx context.Context, workspaceID *string, key str
 		&injectable.DataType,
 		&injectable.Metadata,
 		&injectable.FormatConfig,
+		&injectable.Formula,
 		&injectable.IsActive,
 		&injectable.IsDeleted,
 		&injectable.CreatedAt,
@@ -0,0 +1,22 @@
+package maintenancerepo
+
+// SQL queries for maintenance state operations.
+const (
+	queryGet = `
+		SELECT mode, COALESCE(message, ''), expected_end_at, updated_by, updated_at
+		FROM tenancy.maintenance_state
+		WHERE id`
+
+	// queryUpdate upserts so the switch keeps working if the seed row was removed
+	queryUpdate = `
+		INSERT INTO tenancy.maintenance_state (id, mode, message, expected_end_at, updated_by, updated_at)
+		VALUES (TRUE, $1, NULLIF($2, ''), $3, $4, CURRENT_TIMESTAMP)
+		ON CONFLICT (id)
+		DO UPDATE SET
+			mode = EXCLUDED.mode,
+			message = EXCLUDED.message,
+			expected_end_at = EXCLUDED.expected_end_at,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING updated_at`
+)
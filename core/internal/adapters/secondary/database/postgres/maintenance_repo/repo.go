@@ -0,0 +1,56 @@
+package maintenancerepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new maintenance repository.
+func New(pool *pgxpool.Pool) port.MaintenanceRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the maintenance repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// Get returns the current maintenance state, or normal operation if none was stored.
+func (r *Repository) Get(ctx context.Context) (*entity.MaintenanceState, error) {
+	var state entity.MaintenanceState
+	err := r.pool.QueryRow(ctx, queryGet).Scan(
+		&state.Mode,
+		&state.Message,
+		&state.ExpectedEndAt,
+		&state.UpdatedBy,
+		&state.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.NewMaintenanceState(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying maintenance state: %w", err)
+	}
+	return &state, nil
+}
+
+// Update replaces the maintenance state.
+func (r *Repository) Update(ctx context.Context, state *entity.MaintenanceState) error {
+	err := r.pool.QueryRow(ctx, queryUpdate,
+		state.Mode,
+		state.Message,
+		state.ExpectedEndAt,
+		state.UpdatedBy,
+	).Scan(&state.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("updating maintenance state: %w", err)
+	}
+	return nil
+}
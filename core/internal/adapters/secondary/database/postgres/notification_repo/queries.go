@@ -0,0 +1,39 @@
+package notificationrepo
+
+// SQL queries for notification operations.
+const (
+	queryCreate = `
+		INSERT INTO identity.notifications (id, user_id, workspace_id, type, title, message, resource_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`
+
+	queryFindByUser = `
+		SELECT id, user_id, workspace_id, type, title, message, resource_id, read_at, created_at
+		FROM identity.notifications
+		WHERE user_id = $1
+		  AND ($2 = false OR read_at IS NULL)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4`
+
+	queryCountByUser = `
+		SELECT COUNT(*)
+		FROM identity.notifications
+		WHERE user_id = $1
+		  AND ($2 = false OR read_at IS NULL)`
+
+	queryCountUnread = `
+		SELECT COUNT(*)
+		FROM identity.notifications
+		WHERE user_id = $1 AND read_at IS NULL`
+
+	// queryMarkRead keeps the original read_at when the notification was already read
+	queryMarkRead = `
+		UPDATE identity.notifications
+		SET read_at = COALESCE(read_at, CURRENT_TIMESTAMP)
+		WHERE id = $1 AND user_id = $2`
+
+	queryMarkAllRead = `
+		UPDATE identity.notifications
+		SET read_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND read_at IS NULL`
+)
@@ -0,0 +1,110 @@
+package notificationrepo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new notification repository.
+func New(pool *pgxpool.Pool) port.NotificationRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the notification repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// Create creates a new notification.
+func (r *Repository) Create(ctx context.Context, notification *entity.Notification) (string, error) {
+	var id string
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryCreate,
+		notification.ID,
+		notification.UserID,
+		notification.WorkspaceID,
+		notification.Type,
+		notification.Title,
+		notification.Message,
+		notification.ResourceID,
+		notification.CreatedAt,
+	).Scan(&id)
+	if err != nil {
+		return "", fmt.Errorf("inserting notification: %w", err)
+	}
+
+	return id, nil
+}
+
+// FindByUser lists notifications for a user, newest first, with the total count.
+func (r *Repository) FindByUser(ctx context.Context, userID string, filters port.NotificationFilters) ([]*entity.Notification, int64, error) {
+	var total int64
+	err := r.pool.QueryRow(ctx, queryCountByUser, userID, filters.UnreadOnly).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("counting notifications: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, queryFindByUser, userID, filters.UnreadOnly, filters.Limit, filters.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("querying notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*entity.Notification
+	for rows.Next() {
+		var n entity.Notification
+		err := rows.Scan(
+			&n.ID,
+			&n.UserID,
+			&n.WorkspaceID,
+			&n.Type,
+			&n.Title,
+			&n.Message,
+			&n.ResourceID,
+			&n.ReadAt,
+			&n.CreatedAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scanning notification: %w", err)
+		}
+		result = append(result, &n)
+	}
+
+	return result, total, rows.Err()
+}
+
+// CountUnread returns the number of unread notifications for a user.
+func (r *Repository) CountUnread(ctx context.Context, userID string) (int64, error) {
+	var count int64
+	if err := r.pool.QueryRow(ctx, queryCountUnread, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("counting unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// MarkRead marks a single notification as read.
+func (r *Repository) MarkRead(ctx context.Context, id, userID string) error {
+	result, err := r.pool.Exec(ctx, queryMarkRead, id, userID)
+	if err != nil {
+		return fmt.Errorf("marking notification read: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrNotificationNotFound
+	}
+
+	return nil
+}
+
+// MarkAllRead marks every unread notification of a user as read.
+func (r *Repository) MarkAllRead(ctx context.Context, userID string) error {
+	if _, err := r.pool.Exec(ctx, queryMarkAllRead, userID); err != nil {
+		return fmt.Errorf("marking all notifications read: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+//go:build integration
+
+package notificationrepo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	notificationrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/notification_repo"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	"github.com/rendis/pdf-forge/core/internal/testutil/testpostgres"
+)
+
+func TestPostgresIntegration_NotificationRepository(t *testing.T) {
+	ctx := context.Background()
+	pg := testpostgres.Run(ctx, t)
+
+	pool, err := pg.NewPool(ctx)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	var userID, otherID string
+	require.NoError(t, pool.QueryRow(ctx,
+		`INSERT INTO identity.users (email, full_name) VALUES ('ada@example.com', 'Ada') RETURNING id`).Scan(&userID))
+	require.NoError(t, pool.QueryRow(ctx,
+		`INSERT INTO identity.users (email, full_name) VALUES ('bob@example.com', 'Bob') RETURNING id`).Scan(&otherID))
+
+	repo := notificationrepo.New(pool)
+	versionID := "v-1"
+	var ids []string
+	for _, title := range []string{`Rendering "Invoice" failed in prod`, `Rendering "Receipt" failed in prod`} {
+		n := entity.NewNotification(userID, entity.NotificationTypeRenderFailed, title, "typst compile failed")
+		n.ID = uuid.NewString()
+		n.ResourceID = &versionID
+		id, err := repo.Create(ctx, n)
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	list, total, err := repo.FindByUser(ctx, userID, port.NotificationFilters{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, list, 2)
+	assert.Equal(t, entity.NotificationTypeRenderFailed, list[0].Type)
+	assert.Equal(t, &versionID, list[0].ResourceID)
+
+	unread, err := repo.CountUnread(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), unread)
+
+	assert.ErrorIs(t, repo.MarkRead(ctx, ids[0], otherID), entity.ErrNotificationNotFound,
+		"a notification of another user is not found")
+	require.NoError(t, repo.MarkRead(ctx, ids[0], userID))
+
+	list, total, err = repo.FindByUser(ctx, userID, port.NotificationFilters{Limit: 10, UnreadOnly: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, list, 1)
+	assert.Equal(t, ids[1], list[0].ID)
+
+	require.NoError(t, repo.MarkAllRead(ctx, userID))
+	unread, err = repo.CountUnread(ctx, userID)
+	require.NoError(t, err)
+	assert.Zero(t, unread)
+}
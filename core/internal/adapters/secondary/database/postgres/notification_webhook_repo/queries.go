@@ -0,0 +1,34 @@
+package notificationwebhookrepo
+
+// SQL queries for workspace notification webhook operations.
+const (
+	queryCreate = `
+		INSERT INTO tenancy.workspace_notification_webhooks (
+			id, workspace_id, provider, name, webhook_url, event_types, enabled, created_by, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id`
+
+	querySelectColumns = `
+		SELECT id, workspace_id, provider, name, webhook_url, event_types, enabled, created_by, created_at, updated_at
+		FROM tenancy.workspace_notification_webhooks`
+
+	queryFindByID = querySelectColumns + `
+		WHERE workspace_id = $1 AND id = $2`
+
+	queryFindByWorkspace = querySelectColumns + `
+		WHERE workspace_id = $1
+		ORDER BY created_at`
+
+	queryFindEnabledByWorkspace = querySelectColumns + `
+		WHERE workspace_id = $1 AND enabled = true`
+
+	queryUpdate = `
+		UPDATE tenancy.workspace_notification_webhooks
+		SET name = $3, webhook_url = $4, event_types = $5, enabled = $6, updated_at = $7
+		WHERE workspace_id = $1 AND id = $2`
+
+	queryDelete = `
+		DELETE FROM tenancy.workspace_notification_webhooks
+		WHERE workspace_id = $1 AND id = $2`
+)
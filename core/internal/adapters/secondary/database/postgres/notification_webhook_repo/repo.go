@@ -0,0 +1,155 @@
+package notificationwebhookrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new notification webhook repository.
+func New(pool *pgxpool.Pool) port.NotificationWebhookRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the notification webhook repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// Create creates a new webhook.
+func (r *Repository) Create(ctx context.Context, webhook *entity.NotificationWebhook) (string, error) {
+	var id string
+	err := r.pool.QueryRow(ctx, queryCreate,
+		webhook.ID,
+		webhook.WorkspaceID,
+		webhook.Provider,
+		webhook.Name,
+		webhook.URL,
+		eventTypesToStrings(webhook.EventTypes),
+		webhook.Enabled,
+		webhook.CreatedBy,
+		webhook.CreatedAt,
+	).Scan(&id)
+	if err != nil {
+		return "", fmt.Errorf("inserting notification webhook: %w", err)
+	}
+
+	return id, nil
+}
+
+// FindByID finds a webhook by ID within a workspace.
+func (r *Repository) FindByID(ctx context.Context, workspaceID, id string) (*entity.NotificationWebhook, error) {
+	webhook, err := scanWebhook(r.pool.QueryRow(ctx, queryFindByID, workspaceID, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrNotificationWebhookNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying notification webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+// FindByWorkspace lists all webhooks of a workspace.
+func (r *Repository) FindByWorkspace(ctx context.Context, workspaceID string) ([]*entity.NotificationWebhook, error) {
+	return r.findMany(ctx, queryFindByWorkspace, workspaceID)
+}
+
+// FindEnabledByWorkspace lists enabled webhooks of a workspace.
+func (r *Repository) FindEnabledByWorkspace(ctx context.Context, workspaceID string) ([]*entity.NotificationWebhook, error) {
+	return r.findMany(ctx, queryFindEnabledByWorkspace, workspaceID)
+}
+
+// Update updates a webhook.
+func (r *Repository) Update(ctx context.Context, webhook *entity.NotificationWebhook) error {
+	result, err := r.pool.Exec(ctx, queryUpdate,
+		webhook.WorkspaceID,
+		webhook.ID,
+		webhook.Name,
+		webhook.URL,
+		eventTypesToStrings(webhook.EventTypes),
+		webhook.Enabled,
+		webhook.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("updating notification webhook: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrNotificationWebhookNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes a webhook within a workspace.
+func (r *Repository) Delete(ctx context.Context, workspaceID, id string) error {
+	result, err := r.pool.Exec(ctx, queryDelete, workspaceID, id)
+	if err != nil {
+		return fmt.Errorf("deleting notification webhook: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrNotificationWebhookNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) findMany(ctx context.Context, query, workspaceID string) ([]*entity.NotificationWebhook, error) {
+	rows, err := r.pool.Query(ctx, query, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("querying notification webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*entity.NotificationWebhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning notification webhook: %w", err)
+		}
+		result = append(result, webhook)
+	}
+
+	return result, rows.Err()
+}
+
+func scanWebhook(row pgx.Row) (*entity.NotificationWebhook, error) {
+	var w entity.NotificationWebhook
+	var eventTypes []string
+	err := row.Scan(
+		&w.ID,
+		&w.WorkspaceID,
+		&w.Provider,
+		&w.Name,
+		&w.URL,
+		&eventTypes,
+		&w.Enabled,
+		&w.CreatedBy,
+		&w.CreatedAt,
+		&w.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	w.EventTypes = make([]entity.NotificationType, len(eventTypes))
+	for i, t := range eventTypes {
+		w.EventTypes[i] = entity.NotificationType(t)
+	}
+	return &w, nil
+}
+
+func eventTypesToStrings(types []entity.NotificationType) []string {
+	out := make([]string, len(types))
+	for i, t := range types {
+		out[i] = string(t)
+	}
+	return out
+}
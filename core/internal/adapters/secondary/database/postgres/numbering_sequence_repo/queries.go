@@ -0,0 +1,79 @@
+package numberingsequencerepo
+
+// SQL queries for numbering sequences and the registry of their numbers.
+const (
+	queryCreate = `
+		INSERT INTO content.numbering_sequences (
+			workspace_id, document_type_id, injectable_key, prefix, padding, yearly_reset, gapless,
+			start_number, next_number, year, is_active, created_by, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id`
+
+	querySelectColumns = `
+		SELECT id, workspace_id, document_type_id, injectable_key, prefix, padding, yearly_reset, gapless,
+			start_number, next_number, year, is_active, created_by, created_at, updated_at
+		FROM content.numbering_sequences`
+
+	queryFindByID = querySelectColumns + `
+		WHERE workspace_id = $1 AND id = $2`
+
+	queryFindByWorkspace = querySelectColumns + `
+		WHERE workspace_id = $1
+		ORDER BY created_at`
+
+	queryFindActiveByDocumentType = querySelectColumns + `
+		WHERE workspace_id = $1 AND document_type_id = $2 AND is_active = true`
+
+	queryLock = querySelectColumns + `
+		WHERE id = $1
+		FOR UPDATE`
+
+	queryUpdate = `
+		UPDATE content.numbering_sequences
+		SET injectable_key = $3, prefix = $4, padding = $5, yearly_reset = $6, gapless = $7,
+			is_active = $8, updated_at = $9
+		WHERE workspace_id = $1 AND id = $2`
+
+	queryAdvance = `
+		UPDATE content.numbering_sequences
+		SET next_number = $2, year = $3
+		WHERE id = $1`
+
+	queryCreateNumber = `
+		INSERT INTO content.sequence_numbers (
+			sequence_id, year, number, formatted, status, version_id, template_id, document_id, issued_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id`
+
+	queryUpdateNumberStatus = `
+		UPDATE content.sequence_numbers
+		SET status = $2
+		WHERE id = $1`
+
+	queryFindReusableNumber = `
+		SELECT id, sequence_id, year, number, formatted, status, version_id, template_id, document_id, issued_at
+		FROM content.sequence_numbers
+		WHERE sequence_id = $1 AND year = $2
+			AND (status = 'RELEASED' OR (status = 'PENDING' AND issued_at < $3))
+		ORDER BY number
+		LIMIT 1`
+
+	queryReassignNumber = `
+		UPDATE content.sequence_numbers
+		SET status = $2, formatted = $3, version_id = $4, template_id = $5, document_id = $6, issued_at = $7
+		WHERE id = $1`
+
+	querySettleNumber = `
+		UPDATE content.sequence_numbers
+		SET status = $3
+		WHERE id = $1 AND status = 'PENDING' AND issued_at = $2`
+
+	queryFindNumbers = `
+		SELECT id, sequence_id, year, number, formatted, status, version_id, template_id, document_id, issued_at
+		FROM content.sequence_numbers
+		WHERE sequence_id = $1
+		ORDER BY issued_at DESC, number DESC
+		LIMIT $2`
+)
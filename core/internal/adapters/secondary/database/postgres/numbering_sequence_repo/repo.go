@@ -0,0 +1,260 @@
+package numberingsequencerepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new numbering sequence repository.
+func New(pool *pgxpool.Pool) port.NumberingSequenceRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the numbering sequence repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// Create creates a sequence.
+func (r *Repository) Create(ctx context.Context, seq *entity.NumberingSequence) (string, error) {
+	var id string
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryCreate,
+		seq.WorkspaceID,
+		seq.DocumentTypeID,
+		seq.InjectableKey,
+		seq.Prefix,
+		seq.Padding,
+		seq.YearlyReset,
+		seq.Gapless,
+		seq.StartNumber,
+		seq.NextNumber,
+		seq.Year,
+		seq.IsActive,
+		seq.CreatedBy,
+		seq.CreatedAt,
+	).Scan(&id)
+	if err != nil {
+		return "", fmt.Errorf("inserting numbering sequence: %w", err)
+	}
+
+	return id, nil
+}
+
+// FindByID finds a sequence of a workspace.
+func (r *Repository) FindByID(ctx context.Context, workspaceID, id string) (*entity.NumberingSequence, error) {
+	return r.findOne(ctx, queryFindByID, workspaceID, id)
+}
+
+// FindByWorkspace lists the sequences of a workspace, oldest first.
+func (r *Repository) FindByWorkspace(ctx context.Context, workspaceID string) ([]*entity.NumberingSequence, error) {
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindByWorkspace, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("querying numbering sequences: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*entity.NumberingSequence
+	for rows.Next() {
+		var seq entity.NumberingSequence
+		if err := rows.Scan(sequenceFields(&seq)...); err != nil {
+			return nil, fmt.Errorf("scanning numbering sequence: %w", err)
+		}
+		result = append(result, &seq)
+	}
+
+	return result, rows.Err()
+}
+
+// FindActiveByDocumentType finds the active sequence of a document type in a workspace.
+func (r *Repository) FindActiveByDocumentType(ctx context.Context, workspaceID, documentTypeID string) (*entity.NumberingSequence, error) {
+	return r.findOne(ctx, queryFindActiveByDocumentType, workspaceID, documentTypeID)
+}
+
+// Update updates the settings of a sequence.
+func (r *Repository) Update(ctx context.Context, seq *entity.NumberingSequence) error {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryUpdate,
+		seq.WorkspaceID,
+		seq.ID,
+		seq.InjectableKey,
+		seq.Prefix,
+		seq.Padding,
+		seq.YearlyReset,
+		seq.Gapless,
+		seq.IsActive,
+		seq.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("updating numbering sequence: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrNumberingSequenceNotFound
+	}
+
+	return nil
+}
+
+// Lock finds a sequence and locks it until the transaction of ctx ends.
+func (r *Repository) Lock(ctx context.Context, id string) (*entity.NumberingSequence, error) {
+	return r.findOne(ctx, queryLock, id)
+}
+
+// Advance stores the counter of a sequence after a draw.
+func (r *Repository) Advance(ctx context.Context, seq *entity.NumberingSequence) error {
+	if _, err := common.Conn(ctx, r.pool).Exec(ctx, queryAdvance, seq.ID, seq.NextNumber, seq.Year); err != nil {
+		return fmt.Errorf("advancing numbering sequence: %w", err)
+	}
+	return nil
+}
+
+// CreateNumber adds a drawn number to the registry.
+func (r *Repository) CreateNumber(ctx context.Context, number *entity.SequenceNumber) (string, error) {
+	var id string
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryCreateNumber,
+		number.SequenceID,
+		number.Year,
+		number.Number,
+		number.Formatted,
+		number.Status,
+		number.VersionID,
+		number.TemplateID,
+		number.DocumentID,
+		number.IssuedAt,
+	).Scan(&id)
+	if err != nil {
+		return "", fmt.Errorf("inserting sequence number: %w", err)
+	}
+
+	return id, nil
+}
+
+// UpdateNumberStatus changes the status of a number in the registry.
+func (r *Repository) UpdateNumberStatus(ctx context.Context, id string, status entity.SequenceNumberStatus) error {
+	if _, err := common.Conn(ctx, r.pool).Exec(ctx, queryUpdateNumberStatus, id, status); err != nil {
+		return fmt.Errorf("updating sequence number status: %w", err)
+	}
+	return nil
+}
+
+// FindReusableNumber finds the lowest number of a sequence and year that a failed render
+// released, or that is pending since before staleBefore.
+func (r *Repository) FindReusableNumber(ctx context.Context, sequenceID string, year int, staleBefore time.Time) (*entity.SequenceNumber, error) {
+	var n entity.SequenceNumber
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindReusableNumber, sequenceID, year, staleBefore).Scan(numberFields(&n)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying reusable sequence number: %w", err)
+	}
+
+	return &n, nil
+}
+
+// ReassignNumber gives a number of the registry to another render.
+func (r *Repository) ReassignNumber(ctx context.Context, number *entity.SequenceNumber) error {
+	_, err := common.Conn(ctx, r.pool).Exec(ctx, queryReassignNumber,
+		number.ID,
+		number.Status,
+		number.Formatted,
+		number.VersionID,
+		number.TemplateID,
+		number.DocumentID,
+		number.IssuedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("reassigning sequence number: %w", err)
+	}
+	return nil
+}
+
+// SettleNumber changes the status of a pending number still reserved at its IssuedAt.
+func (r *Repository) SettleNumber(ctx context.Context, number *entity.SequenceNumber, status entity.SequenceNumberStatus) error {
+	tag, err := common.Conn(ctx, r.pool).Exec(ctx, querySettleNumber, number.ID, number.IssuedAt, status)
+	if err != nil {
+		return fmt.Errorf("settling sequence number: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrSequenceNumberReassigned
+	}
+	return nil
+}
+
+// FindNumbers lists the numbers drawn from a sequence, newest first.
+func (r *Repository) FindNumbers(ctx context.Context, sequenceID string, limit int) ([]*entity.SequenceNumber, error) {
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindNumbers, sequenceID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying sequence numbers: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*entity.SequenceNumber
+	for rows.Next() {
+		var n entity.SequenceNumber
+		if err := rows.Scan(numberFields(&n)...); err != nil {
+			return nil, fmt.Errorf("scanning sequence number: %w", err)
+		}
+		result = append(result, &n)
+	}
+
+	return result, rows.Err()
+}
+
+func (r *Repository) findOne(ctx context.Context, query string, args ...any) (*entity.NumberingSequence, error) {
+	var seq entity.NumberingSequence
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, query, args...).Scan(sequenceFields(&seq)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrNumberingSequenceNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying numbering sequence: %w", err)
+	}
+
+	return &seq, nil
+}
+
+// numberFields returns the scan targets of the columns of a sequence number.
+func numberFields(n *entity.SequenceNumber) []any {
+	return []any{
+		&n.ID,
+		&n.SequenceID,
+		&n.Year,
+		&n.Number,
+		&n.Formatted,
+		&n.Status,
+		&n.VersionID,
+		&n.TemplateID,
+		&n.DocumentID,
+		&n.IssuedAt,
+	}
+}
+
+// sequenceFields returns the scan targets of the columns of querySelectColumns.
+func sequenceFields(seq *entity.NumberingSequence) []any {
+	return []any{
+		&seq.ID,
+		&seq.WorkspaceID,
+		&seq.DocumentTypeID,
+		&seq.InjectableKey,
+		&seq.Prefix,
+		&seq.Padding,
+		&seq.YearlyReset,
+		&seq.Gapless,
+		&seq.StartNumber,
+		&seq.NextNumber,
+		&seq.Year,
+		&seq.IsActive,
+		&seq.CreatedBy,
+		&seq.CreatedAt,
+		&seq.UpdatedAt,
+	}
+}
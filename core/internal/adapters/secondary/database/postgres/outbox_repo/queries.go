@@ -0,0 +1,40 @@
+package outboxrepo
+
+// SQL queries for outbox operations.
+const (
+	queryAppend = `
+		INSERT INTO tenancy.outbox_events (event_type, aggregate_id, workspace_id, payload, available_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	// queryClaimBatch skips rows locked by another relay so instances never block each other.
+	queryClaimBatch = `
+		UPDATE tenancy.outbox_events
+		SET attempts = attempts + 1, available_at = NOW() + $2 * INTERVAL '1 millisecond'
+		WHERE id IN (
+			SELECT id FROM tenancy.outbox_events
+			WHERE delivered_at IS NULL AND failed_at IS NULL AND available_at <= NOW()
+			ORDER BY created_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, event_type, aggregate_id, workspace_id, payload, attempts, last_error,
+			available_at, created_at, delivered_at, failed_at`
+
+	queryMarkDelivered = `
+		UPDATE tenancy.outbox_events
+		SET delivered_at = NOW(), last_error = NULL
+		WHERE id = $1`
+
+	queryMarkRetry = `
+		UPDATE tenancy.outbox_events
+		SET last_error = $2, available_at = $3
+		WHERE id = $1`
+
+	queryMarkFailed = `
+		UPDATE tenancy.outbox_events
+		SET last_error = $2, failed_at = NOW()
+		WHERE id = $1`
+
+	queryDeleteDeliveredBefore = `DELETE FROM tenancy.outbox_events WHERE delivered_at < $1`
+)
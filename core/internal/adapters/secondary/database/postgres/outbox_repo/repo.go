@@ -0,0 +1,101 @@
+package outboxrepo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new outbox repository.
+func New(pool *pgxpool.Pool) port.OutboxRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the outbox repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// Append stores a pending event, joining the transaction carried by ctx if any.
+func (r *Repository) Append(ctx context.Context, event *entity.OutboxEvent) error {
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryAppend,
+		event.Type,
+		event.AggregateID,
+		event.WorkspaceID,
+		event.Payload,
+		event.AvailableAt,
+		event.CreatedAt,
+	).Scan(&event.ID)
+	if err != nil {
+		return fmt.Errorf("appending outbox event: %w", err)
+	}
+	return nil
+}
+
+// ClaimBatch claims due pending events for lease.
+func (r *Repository) ClaimBatch(ctx context.Context, limit int, lease time.Duration) ([]*entity.OutboxEvent, error) {
+	rows, err := r.pool.Query(ctx, queryClaimBatch, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, fmt.Errorf("claiming outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*entity.OutboxEvent
+	for rows.Next() {
+		e := &entity.OutboxEvent{}
+		if err := rows.Scan(
+			&e.ID, &e.Type, &e.AggregateID, &e.WorkspaceID, &e.Payload, &e.Attempts, &e.LastError,
+			&e.AvailableAt, &e.CreatedAt, &e.DeliveredAt, &e.FailedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning outbox event: %w", err)
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating outbox events: %w", err)
+	}
+
+	// UPDATE ... RETURNING does not preserve the subquery order
+	sort.Slice(events, func(i, j int) bool { return events[i].CreatedAt.Before(events[j].CreatedAt) })
+	return events, nil
+}
+
+// MarkDelivered records a successful delivery.
+func (r *Repository) MarkDelivered(ctx context.Context, id string) error {
+	if _, err := r.pool.Exec(ctx, queryMarkDelivered, id); err != nil {
+		return fmt.Errorf("marking outbox event delivered: %w", err)
+	}
+	return nil
+}
+
+// MarkRetry records a failed attempt and schedules the next one.
+func (r *Repository) MarkRetry(ctx context.Context, id, lastError string, retryAt time.Time) error {
+	if _, err := r.pool.Exec(ctx, queryMarkRetry, id, lastError, retryAt); err != nil {
+		return fmt.Errorf("scheduling outbox event retry: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed gives up on an event.
+func (r *Repository) MarkFailed(ctx context.Context, id, lastError string) error {
+	if _, err := r.pool.Exec(ctx, queryMarkFailed, id, lastError); err != nil {
+		return fmt.Errorf("marking outbox event failed: %w", err)
+	}
+	return nil
+}
+
+// DeleteDeliveredBefore removes events delivered before the given time.
+func (r *Repository) DeleteDeliveredBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.pool.Exec(ctx, queryDeleteDeliveredBefore, before)
+	if err != nil {
+		return 0, fmt.Errorf("deleting delivered outbox events: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
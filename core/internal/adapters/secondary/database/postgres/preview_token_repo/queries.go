@@ -0,0 +1,36 @@
+package previewtokenrepo
+
+// SQL queries for version preview token operations.
+const (
+	queryCreate = `
+		INSERT INTO content.version_preview_tokens (
+			id, workspace_id, version_id, token_hash, label, watermark, created_by, expires_at, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id`
+
+	querySelectColumns = `
+		SELECT id, workspace_id, version_id, token_hash, label, watermark, created_by,
+		       expires_at, revoked_at, last_used_at, created_at
+		FROM content.version_preview_tokens`
+
+	queryFindByID = querySelectColumns + `
+		WHERE id = $1`
+
+	queryFindByTokenHash = querySelectColumns + `
+		WHERE token_hash = $1`
+
+	queryFindByVersion = querySelectColumns + `
+		WHERE version_id = $1
+		ORDER BY created_at DESC`
+
+	queryRevoke = `
+		UPDATE content.version_preview_tokens
+		SET revoked_at = $2
+		WHERE id = $1`
+
+	queryTouchLastUsed = `
+		UPDATE content.version_preview_tokens
+		SET last_used_at = NOW()
+		WHERE id = $1`
+)
@@ -0,0 +1,129 @@
+package previewtokenrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new preview token repository.
+func New(pool *pgxpool.Pool) port.PreviewTokenRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the preview token repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// Create creates a new preview token.
+func (r *Repository) Create(ctx context.Context, token *entity.PreviewToken) (string, error) {
+	var id string
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryCreate,
+		token.ID,
+		token.WorkspaceID,
+		token.VersionID,
+		token.TokenHash,
+		token.Label,
+		token.Watermark,
+		token.CreatedBy,
+		token.ExpiresAt,
+		token.CreatedAt,
+	).Scan(&id)
+	if err != nil {
+		return "", fmt.Errorf("inserting preview token: %w", err)
+	}
+
+	return id, nil
+}
+
+// FindByID finds a preview token by ID.
+func (r *Repository) FindByID(ctx context.Context, id string) (*entity.PreviewToken, error) {
+	return r.findOne(ctx, queryFindByID, id)
+}
+
+// FindByTokenHash finds a preview token by the hash of its shared token.
+func (r *Repository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.PreviewToken, error) {
+	return r.findOne(ctx, queryFindByTokenHash, tokenHash)
+}
+
+// FindByVersion lists the preview tokens of a version, newest first.
+func (r *Repository) FindByVersion(ctx context.Context, versionID string) ([]*entity.PreviewToken, error) {
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindByVersion, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("querying preview tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*entity.PreviewToken
+	for rows.Next() {
+		token, err := scanToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning preview token: %w", err)
+		}
+		result = append(result, token)
+	}
+
+	return result, rows.Err()
+}
+
+// Revoke stores the revocation time of a token.
+func (r *Repository) Revoke(ctx context.Context, token *entity.PreviewToken) error {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryRevoke, token.ID, token.RevokedAt)
+	if err != nil {
+		return fmt.Errorf("revoking preview token: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrPreviewTokenNotFound
+	}
+
+	return nil
+}
+
+// TouchLastUsed records that a token was just used.
+func (r *Repository) TouchLastUsed(ctx context.Context, id string) error {
+	if _, err := common.Conn(ctx, r.pool).Exec(ctx, queryTouchLastUsed, id); err != nil {
+		return fmt.Errorf("updating preview token last use: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) findOne(ctx context.Context, query string, args ...any) (*entity.PreviewToken, error) {
+	token, err := scanToken(common.Conn(ctx, r.pool).QueryRow(ctx, query, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrPreviewTokenNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying preview token: %w", err)
+	}
+	return token, nil
+}
+
+func scanToken(row pgx.Row) (*entity.PreviewToken, error) {
+	var t entity.PreviewToken
+	err := row.Scan(
+		&t.ID,
+		&t.WorkspaceID,
+		&t.VersionID,
+		&t.TokenHash,
+		&t.Label,
+		&t.Watermark,
+		&t.CreatedBy,
+		&t.ExpiresAt,
+		&t.RevokedAt,
+		&t.LastUsedAt,
+		&t.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,11 @@
+package rendercurrencyraterepo
+
+// SQL queries for render currency rate operations.
+const (
+	queryCreate = `
+		INSERT INTO content.render_currency_rates (
+			id, tenant_code, workspace_code, document_type_code, version_id, template_id, environment,
+			document_number, job_id, rates, rendered_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+)
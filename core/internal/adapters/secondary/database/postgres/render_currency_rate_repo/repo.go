@@ -0,0 +1,49 @@
+package rendercurrencyraterepo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new render currency rate repository.
+func New(pool *pgxpool.Pool) port.RenderCurrencyRateRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the render currency rate repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// Create stores the rates of a render.
+func (r *Repository) Create(ctx context.Context, record *entity.RenderCurrencyRates) error {
+	rates, err := json.Marshal(record.Rates)
+	if err != nil {
+		return fmt.Errorf("encoding render currency rates: %w", err)
+	}
+
+	_, err = common.Conn(ctx, r.pool).Exec(ctx, queryCreate,
+		record.ID,
+		record.TenantCode,
+		record.WorkspaceCode,
+		record.DocumentTypeCode,
+		record.VersionID,
+		record.TemplateID,
+		record.Environment,
+		record.DocumentNumber,
+		record.JobID,
+		rates,
+		record.RenderedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("inserting render currency rates: %w", err)
+	}
+	return nil
+}
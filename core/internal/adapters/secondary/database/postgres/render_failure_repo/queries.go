@@ -0,0 +1,35 @@
+package renderfailurerepo
+
+// SQL queries for render failure operations.
+const (
+	queryCreate = `
+		INSERT INTO content.render_failures (
+			id, tenant_code, workspace_code, document_type_code, version_id, template_id, environment,
+			error, stderr, source, source_bytes, source_truncated, assets, created_at, expires_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+
+	queryList = `
+		SELECT id, tenant_code, workspace_code, document_type_code, version_id, template_id, environment,
+		       error, stderr, source_bytes, source_truncated, assets, created_at, expires_at
+		FROM content.render_failures
+		WHERE expires_at > NOW()
+		ORDER BY created_at DESC
+		LIMIT $1`
+
+	queryFindByID = `
+		SELECT id, tenant_code, workspace_code, document_type_code, version_id, template_id, environment,
+		       error, stderr, source_bytes, source_truncated, assets, created_at, expires_at, source
+		FROM content.render_failures
+		WHERE id = $1 AND expires_at > NOW()`
+
+	// queryDeleteExpired also deletes the failures beyond the newest $1 when $1 is positive.
+	queryDeleteExpired = `
+		DELETE FROM content.render_failures
+		WHERE expires_at <= NOW()
+		   OR ($1 > 0 AND id NOT IN (
+		       SELECT id FROM content.render_failures
+		       ORDER BY created_at DESC
+		       LIMIT $1
+		   ))`
+)
@@ -0,0 +1,135 @@
+package renderfailurerepo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new render failure repository.
+func New(pool *pgxpool.Pool) port.RenderFailureRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the render failure repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// Create stores a captured failure.
+func (r *Repository) Create(ctx context.Context, f *entity.RenderFailure) error {
+	assets, err := json.Marshal(f.Assets)
+	if err != nil {
+		return fmt.Errorf("encoding render failure assets: %w", err)
+	}
+	if f.Assets == nil {
+		assets = []byte("[]")
+	}
+
+	_, err = common.Conn(ctx, r.pool).Exec(ctx, queryCreate,
+		f.ID,
+		f.TenantCode,
+		f.WorkspaceCode,
+		f.DocumentTypeCode,
+		f.VersionID,
+		f.TemplateID,
+		f.Environment,
+		f.Error,
+		f.Stderr,
+		f.Source,
+		f.SourceBytes,
+		f.SourceTruncated,
+		assets,
+		f.CreatedAt,
+		f.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("inserting render failure: %w", err)
+	}
+	return nil
+}
+
+// List lists unexpired failures, newest first, without their sources.
+func (r *Repository) List(ctx context.Context, limit int) ([]*entity.RenderFailure, error) {
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryList, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying render failures: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*entity.RenderFailure
+	for rows.Next() {
+		f, err := scanFailure(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, f)
+	}
+
+	return result, rows.Err()
+}
+
+// FindByID finds an unexpired failure by ID, with its source.
+func (r *Repository) FindByID(ctx context.Context, id string) (*entity.RenderFailure, error) {
+	var source string
+	f, err := scanFailure(common.Conn(ctx, r.pool).QueryRow(ctx, queryFindByID, id), &source)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrRenderFailureNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	f.Source = source
+	return f, nil
+}
+
+// DeleteExpired deletes failures past their expiry and all but the newest keep failures.
+func (r *Repository) DeleteExpired(ctx context.Context, keep int) (int64, error) {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryDeleteExpired, keep)
+	if err != nil {
+		return 0, fmt.Errorf("deleting expired render failures: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// scanFailure scans the common columns of a failure, followed by extra.
+func scanFailure(row pgx.Row, extra ...any) (*entity.RenderFailure, error) {
+	var (
+		f      entity.RenderFailure
+		assets []byte
+	)
+	dest := append([]any{
+		&f.ID,
+		&f.TenantCode,
+		&f.WorkspaceCode,
+		&f.DocumentTypeCode,
+		&f.VersionID,
+		&f.TemplateID,
+		&f.Environment,
+		&f.Error,
+		&f.Stderr,
+		&f.SourceBytes,
+		&f.SourceTruncated,
+		&assets,
+		&f.CreatedAt,
+		&f.ExpiresAt,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("scanning render failure: %w", err)
+	}
+	if err := json.Unmarshal(assets, &f.Assets); err != nil {
+		return nil, fmt.Errorf("decoding render failure assets: %w", err)
+	}
+	return &f, nil
+}
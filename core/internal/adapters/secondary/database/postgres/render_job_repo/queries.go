@@ -0,0 +1,66 @@
+package renderjobrepo
+
+// SQL queries for render job operations.
+const (
+	queryCreate = `
+		INSERT INTO content.render_jobs (
+			id, workspace_id, tenant_code, workspace_code, document_type_code, version_id,
+			environment, request, status, created_at, expires_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id`
+
+	queryFindByID = `
+		SELECT id, workspace_id, tenant_code, workspace_code, document_type_code, version_id,
+		       environment, status, attempts, error, error_code, filename, size_bytes, page_count, warnings,
+		       created_at, started_at, finished_at, expires_at
+		FROM content.render_jobs
+		WHERE id = $1`
+
+	queryFindPDF = `SELECT pdf FROM content.render_jobs WHERE id = $1`
+
+	// queryClaim skips rows locked by another runner so instances never block each other.
+	// Running jobs are claimed again once their lease expires, when their runner stopped mid-render.
+	queryClaim = `
+		UPDATE content.render_jobs
+		SET status = 'RUNNING', attempts = attempts + 1, started_at = NOW(),
+		    lease_until = NOW() + $2 * INTERVAL '1 millisecond'
+		WHERE id IN (
+			SELECT id FROM content.render_jobs
+			WHERE (status = 'QUEUED' OR (status = 'RUNNING' AND lease_until <= NOW())) AND expires_at > NOW()
+			ORDER BY created_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, workspace_id, tenant_code, workspace_code, document_type_code, version_id,
+		          environment, request, status, attempts, created_at, started_at, expires_at`
+
+	queryRequeue = `
+		UPDATE content.render_jobs
+		SET status = 'QUEUED', attempts = GREATEST(attempts - 1, 0), lease_until = NULL, started_at = NULL
+		WHERE id = $1 AND status = 'RUNNING'`
+
+	queryRetry = `
+		UPDATE content.render_jobs
+		SET status = 'QUEUED', lease_until = NULL
+		WHERE id = $1 AND status = 'RUNNING'`
+
+	queryComplete = `
+		UPDATE content.render_jobs
+		SET status = 'SUCCEEDED', filename = $2, pdf = $3, size_bytes = $4, page_count = $5, warnings = $6,
+		    lease_until = NULL, finished_at = NOW()
+		WHERE id = $1`
+
+	queryFail = `
+		UPDATE content.render_jobs
+		SET status = 'FAILED', error = $2, error_code = $3, lease_until = NULL, finished_at = NOW()
+		WHERE id = $1`
+
+	queryDeleteExpired = `
+		DELETE FROM content.render_jobs
+		WHERE id IN (
+			SELECT id FROM content.render_jobs
+			WHERE expires_at <= NOW()
+			LIMIT $1
+		)`
+)
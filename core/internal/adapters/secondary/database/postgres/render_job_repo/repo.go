@@ -0,0 +1,194 @@
+package renderjobrepo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new render job repository.
+func New(pool *pgxpool.Pool) port.RenderJobRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the render job repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// Create stores a queued render job.
+func (r *Repository) Create(ctx context.Context, job *entity.RenderJob) (string, error) {
+	var id string
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryCreate,
+		job.ID,
+		job.WorkspaceID,
+		job.TenantCode,
+		job.WorkspaceCode,
+		job.DocumentTypeCode,
+		job.VersionID,
+		job.Environment,
+		job.Request,
+		job.Status,
+		job.CreatedAt,
+		job.ExpiresAt,
+	).Scan(&id)
+	if err != nil {
+		return "", fmt.Errorf("inserting render job: %w", err)
+	}
+
+	return id, nil
+}
+
+// FindByID finds a render job by ID, without its request and PDF.
+func (r *Repository) FindByID(ctx context.Context, id string) (*entity.RenderJob, error) {
+	var (
+		j        entity.RenderJob
+		warnings []byte
+	)
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindByID, id).Scan(
+		&j.ID,
+		&j.WorkspaceID,
+		&j.TenantCode,
+		&j.WorkspaceCode,
+		&j.DocumentTypeCode,
+		&j.VersionID,
+		&j.Environment,
+		&j.Status,
+		&j.Attempts,
+		&j.Error,
+		&j.ErrorCode,
+		&j.Filename,
+		&j.SizeBytes,
+		&j.PageCount,
+		&warnings,
+		&j.CreatedAt,
+		&j.StartedAt,
+		&j.FinishedAt,
+		&j.ExpiresAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrRenderJobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying render job: %w", err)
+	}
+	if err := json.Unmarshal(warnings, &j.Warnings); err != nil {
+		return nil, fmt.Errorf("decoding render job warnings: %w", err)
+	}
+
+	return &j, nil
+}
+
+// FindPDF returns the PDF of a succeeded job, or nil when the job has no result.
+func (r *Repository) FindPDF(ctx context.Context, id string) ([]byte, error) {
+	var pdf []byte
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindPDF, id).Scan(&pdf)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrRenderJobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying render job PDF: %w", err)
+	}
+
+	return pdf, nil
+}
+
+// Claim claims up to limit unexpired jobs that are queued or whose lease expired, with their requests.
+func (r *Repository) Claim(ctx context.Context, limit int, lease time.Duration) ([]*entity.RenderJob, error) {
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryClaim, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, fmt.Errorf("claiming render jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*entity.RenderJob
+	for rows.Next() {
+		var j entity.RenderJob
+		if err := rows.Scan(
+			&j.ID,
+			&j.WorkspaceID,
+			&j.TenantCode,
+			&j.WorkspaceCode,
+			&j.DocumentTypeCode,
+			&j.VersionID,
+			&j.Environment,
+			&j.Request,
+			&j.Status,
+			&j.Attempts,
+			&j.CreatedAt,
+			&j.StartedAt,
+			&j.ExpiresAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning render job: %w", err)
+		}
+		result = append(result, &j)
+	}
+
+	return result, rows.Err()
+}
+
+// Requeue returns a claimed job to the queue without counting the attempt.
+func (r *Repository) Requeue(ctx context.Context, id string) error {
+	if _, err := common.Conn(ctx, r.pool).Exec(ctx, queryRequeue, id); err != nil {
+		return fmt.Errorf("requeuing render job: %w", err)
+	}
+	return nil
+}
+
+// Retry returns a claimed job whose render failed to the queue; the attempt counts.
+func (r *Repository) Retry(ctx context.Context, id string) error {
+	if _, err := common.Conn(ctx, r.pool).Exec(ctx, queryRetry, id); err != nil {
+		return fmt.Errorf("retrying render job: %w", err)
+	}
+	return nil
+}
+
+// Complete stores the PDF of a job and marks it SUCCEEDED.
+func (r *Repository) Complete(ctx context.Context, result *entity.RenderJobResult) error {
+	warnings, err := json.Marshal(result.Warnings)
+	if err != nil {
+		return fmt.Errorf("encoding render job warnings: %w", err)
+	}
+	if result.Warnings == nil {
+		warnings = []byte("[]")
+	}
+
+	_, err = common.Conn(ctx, r.pool).Exec(ctx, queryComplete,
+		result.JobID,
+		result.Filename,
+		result.PDF,
+		len(result.PDF),
+		result.PageCount,
+		warnings,
+	)
+	if err != nil {
+		return fmt.Errorf("completing render job: %w", err)
+	}
+	return nil
+}
+
+// Fail marks a job FAILED with the reason and render error code.
+func (r *Repository) Fail(ctx context.Context, id, reason string, code *entity.RenderErrorCode) error {
+	if _, err := common.Conn(ctx, r.pool).Exec(ctx, queryFail, id, reason, code); err != nil {
+		return fmt.Errorf("failing render job: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired deletes up to limit jobs past their expiry.
+func (r *Repository) DeleteExpired(ctx context.Context, limit int) (int64, error) {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryDeleteExpired, limit)
+	if err != nil {
+		return 0, fmt.Errorf("deleting expired render jobs: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
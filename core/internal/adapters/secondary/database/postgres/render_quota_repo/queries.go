@@ -0,0 +1,73 @@
+package renderquotarepo
+
+// SQL queries for render quota operations.
+const (
+	queryFindAll = `
+		SELECT q.tenant_id, t.code, NULL::uuid, '', q.renders_per_minute, q.pages_per_month, q.updated_by, q.updated_at
+		FROM tenancy.tenant_render_quotas q
+		JOIN tenancy.tenants t ON t.id = q.tenant_id
+		UNION ALL
+		SELECT w.tenant_id, t.code, q.workspace_id, w.code, q.renders_per_minute, q.pages_per_month, q.updated_by, q.updated_at
+		FROM tenancy.workspace_render_quotas q
+		JOIN tenancy.workspaces w ON w.id = q.workspace_id
+		JOIN tenancy.tenants t ON t.id = w.tenant_id
+		ORDER BY 2, 4`
+
+	queryFindTenant = `
+		SELECT q.tenant_id, t.code, NULL::uuid, '', q.renders_per_minute, q.pages_per_month, q.updated_by, q.updated_at
+		FROM tenancy.tenant_render_quotas q
+		JOIN tenancy.tenants t ON t.id = q.tenant_id
+		WHERE q.tenant_id = $1`
+
+	queryFindWorkspace = `
+		SELECT w.tenant_id, t.code, q.workspace_id, w.code, q.renders_per_minute, q.pages_per_month, q.updated_by, q.updated_at
+		FROM tenancy.workspace_render_quotas q
+		JOIN tenancy.workspaces w ON w.id = q.workspace_id
+		JOIN tenancy.tenants t ON t.id = w.tenant_id
+		WHERE q.workspace_id = $1 AND w.tenant_id = $2`
+
+	queryUpsertTenant = `
+		INSERT INTO tenancy.tenant_render_quotas (tenant_id, renders_per_minute, pages_per_month, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+		ON CONFLICT (tenant_id)
+		DO UPDATE SET
+			renders_per_minute = EXCLUDED.renders_per_minute,
+			pages_per_month = EXCLUDED.pages_per_month,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING updated_at`
+
+	queryUpsertWorkspace = `
+		INSERT INTO tenancy.workspace_render_quotas (workspace_id, renders_per_minute, pages_per_month, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+		ON CONFLICT (workspace_id)
+		DO UPDATE SET
+			renders_per_minute = EXCLUDED.renders_per_minute,
+			pages_per_month = EXCLUDED.pages_per_month,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING updated_at`
+
+	queryDeleteTenant = `DELETE FROM tenancy.tenant_render_quotas WHERE tenant_id = $1`
+
+	queryDeleteWorkspace = `
+		DELETE FROM tenancy.workspace_render_quotas q
+		USING tenancy.workspaces w
+		WHERE q.workspace_id = $1 AND w.id = q.workspace_id AND w.tenant_id = $2`
+
+	// queryAddUsage adds to the counters of each workspace and month, creating the missing rows
+	queryAddUsage = `
+		INSERT INTO tenancy.render_monthly_usage AS u (tenant_code, workspace_code, month, renders, pages, updated_at)
+		SELECT *, CURRENT_TIMESTAMP
+		FROM unnest($1::varchar[], $2::varchar[], $3::date[], $4::bigint[], $5::bigint[])
+		ON CONFLICT (tenant_code, workspace_code, month)
+		DO UPDATE SET
+			renders = u.renders + EXCLUDED.renders,
+			pages = u.pages + EXCLUDED.pages,
+			updated_at = EXCLUDED.updated_at`
+
+	queryListUsage = `
+		SELECT tenant_code, workspace_code, month, renders, pages
+		FROM tenancy.render_monthly_usage
+		WHERE month = $1::date`
+)
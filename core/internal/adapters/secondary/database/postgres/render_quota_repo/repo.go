@@ -0,0 +1,154 @@
+package renderquotarepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new render quota repository.
+func New(pool *pgxpool.Pool) port.RenderQuotaRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the render quota repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// FindAll returns every tenant and workspace quota, by tenant code then workspace code.
+func (r *Repository) FindAll(ctx context.Context) ([]*entity.RenderQuota, error) {
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindAll)
+	if err != nil {
+		return nil, fmt.Errorf("querying render quotas: %w", err)
+	}
+	defer rows.Close()
+
+	var quotas []*entity.RenderQuota
+	for rows.Next() {
+		q, err := scanQuota(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning render quota: %w", err)
+		}
+		quotas = append(quotas, q)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating render quotas: %w", err)
+	}
+	return quotas, nil
+}
+
+// Find returns the quota of a tenant or of one of its workspaces.
+func (r *Repository) Find(ctx context.Context, tenantID string, workspaceID *string) (*entity.RenderQuota, error) {
+	query, args := queryFindTenant, []any{tenantID}
+	if workspaceID != nil {
+		query, args = queryFindWorkspace, []any{*workspaceID, tenantID}
+	}
+	q, err := scanQuota(common.Conn(ctx, r.pool).QueryRow(ctx, query, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrRenderQuotaNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying render quota: %w", err)
+	}
+	return q, nil
+}
+
+// Save creates or replaces a tenant or workspace quota.
+func (r *Repository) Save(ctx context.Context, q *entity.RenderQuota) error {
+	query, id := queryUpsertTenant, q.TenantID
+	if q.WorkspaceID != nil {
+		query, id = queryUpsertWorkspace, *q.WorkspaceID
+	}
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, query, id, q.RendersPerMinute, q.PagesPerMonth, q.UpdatedBy).Scan(&q.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("saving render quota: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a tenant or workspace quota.
+func (r *Repository) Delete(ctx context.Context, tenantID string, workspaceID *string) error {
+	query, args := queryDeleteTenant, []any{tenantID}
+	if workspaceID != nil {
+		query, args = queryDeleteWorkspace, []any{*workspaceID, tenantID}
+	}
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("deleting render quota: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return entity.ErrRenderQuotaNotFound
+	}
+	return nil
+}
+
+// AddUsage adds render counts to their workspaces and months in one statement.
+func (r *Repository) AddUsage(ctx context.Context, usage []*entity.RenderUsage) error {
+	if len(usage) == 0 {
+		return nil
+	}
+
+	tenants := make([]string, len(usage))
+	workspaces := make([]string, len(usage))
+	months := make([]time.Time, len(usage))
+	renders := make([]int64, len(usage))
+	pages := make([]int64, len(usage))
+	for i, u := range usage {
+		tenants[i], workspaces[i], months[i], renders[i], pages[i] = u.TenantCode, u.WorkspaceCode, u.Month, u.Renders, u.Pages
+	}
+
+	if _, err := common.Conn(ctx, r.pool).Exec(ctx, queryAddUsage, tenants, workspaces, months, renders, pages); err != nil {
+		return fmt.Errorf("adding render usage: %w", err)
+	}
+	return nil
+}
+
+// ListUsage returns the usage of every workspace in the month starting at month.
+func (r *Repository) ListUsage(ctx context.Context, month time.Time) ([]*entity.RenderUsage, error) {
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryListUsage, month)
+	if err != nil {
+		return nil, fmt.Errorf("querying render usage: %w", err)
+	}
+	defer rows.Close()
+
+	var usage []*entity.RenderUsage
+	for rows.Next() {
+		u := &entity.RenderUsage{}
+		if err := rows.Scan(&u.TenantCode, &u.WorkspaceCode, &u.Month, &u.Renders, &u.Pages); err != nil {
+			return nil, fmt.Errorf("scanning render usage: %w", err)
+		}
+		usage = append(usage, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating render usage: %w", err)
+	}
+	return usage, nil
+}
+
+// scanQuota scans a row of the find queries.
+func scanQuota(row pgx.Row) (*entity.RenderQuota, error) {
+	q := &entity.RenderQuota{}
+	err := row.Scan(
+		&q.TenantID,
+		&q.TenantCode,
+		&q.WorkspaceID,
+		&q.WorkspaceCode,
+		&q.RendersPerMinute,
+		&q.PagesPerMonth,
+		&q.UpdatedBy,
+		&q.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
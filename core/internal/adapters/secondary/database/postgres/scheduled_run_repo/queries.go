@@ -0,0 +1,58 @@
+package scheduledrunrepo
+
+// SQL queries for scheduled operation run operations.
+const (
+	queryCreate = `
+		INSERT INTO content.scheduled_operation_runs (version_id, operation, scheduled_for, status, error, triggered_by, ran_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`
+
+	queryFindUpcomingByWorkspace = `
+		SELECT tv.id, tv.name, tv.version_number, t.id, t.title, 'PUBLISH' AS operation, tv.scheduled_publish_at AS scheduled_for,
+			tv.schedule_failed_at, tv.schedule_failure_reason
+		FROM content.template_versions tv
+		JOIN content.templates t ON t.id = tv.template_id
+		WHERE t.workspace_id = $1 AND tv.status = 'SCHEDULED' AND tv.scheduled_publish_at IS NOT NULL
+		UNION ALL
+		SELECT tv.id, tv.name, tv.version_number, t.id, t.title, 'ARCHIVE', tv.scheduled_archive_at,
+			tv.schedule_failed_at, tv.schedule_failure_reason
+		FROM content.template_versions tv
+		JOIN content.templates t ON t.id = tv.template_id
+		WHERE t.workspace_id = $1 AND tv.status = 'PUBLISHED' AND tv.scheduled_archive_at IS NOT NULL
+		ORDER BY scheduled_for`
+
+	queryCountFailures = `
+		SELECT COUNT(*) FROM content.scheduled_operation_runs
+		WHERE version_id = $1 AND operation = $2 AND scheduled_for = $3 AND status = 'FAILED'`
+
+	queryFindRecentByWorkspace = `
+		SELECT r.id, r.version_id, tv.name, t.id, t.title, r.operation, r.scheduled_for,
+			r.status, r.error, r.triggered_by, r.ran_at
+		FROM content.scheduled_operation_runs r
+		JOIN content.template_versions tv ON tv.id = r.version_id
+		JOIN content.templates t ON t.id = tv.template_id
+		WHERE t.workspace_id = $1
+		ORDER BY r.ran_at DESC
+		LIMIT $2`
+
+	// queryFindFailuresByWorkspace keeps the latest run per version and operation,
+	// then drops it unless it failed and the version still waits for that operation.
+	queryFindFailuresByWorkspace = `
+		SELECT id, version_id, version_name, template_id, template_title, operation, scheduled_for,
+			status, error, triggered_by, ran_at
+		FROM (
+			SELECT DISTINCT ON (r.version_id, r.operation)
+				r.id, r.version_id, tv.name AS version_name, t.id AS template_id, t.title AS template_title,
+				r.operation, r.scheduled_for, r.status, r.error, r.triggered_by, r.ran_at,
+				tv.status AS version_status, tv.scheduled_archive_at
+			FROM content.scheduled_operation_runs r
+			JOIN content.template_versions tv ON tv.id = r.version_id
+			JOIN content.templates t ON t.id = tv.template_id
+			WHERE t.workspace_id = $1
+			ORDER BY r.version_id, r.operation, r.ran_at DESC
+		) latest
+		WHERE status = 'FAILED'
+			AND ((operation = 'PUBLISH' AND version_status = 'SCHEDULED')
+				OR (operation = 'ARCHIVE' AND version_status = 'PUBLISHED' AND scheduled_archive_at IS NOT NULL))
+		ORDER BY ran_at DESC`
+)
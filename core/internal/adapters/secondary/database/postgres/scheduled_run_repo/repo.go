@@ -0,0 +1,135 @@
+package scheduledrunrepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new scheduled operation run repository.
+func New(pool *pgxpool.Pool) port.ScheduledRunRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the scheduled operation run repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// Create records a run.
+func (r *Repository) Create(ctx context.Context, run *entity.ScheduledRun) (string, error) {
+	var id string
+	err := r.pool.QueryRow(ctx, queryCreate,
+		run.VersionID,
+		run.Operation,
+		run.ScheduledFor,
+		run.Status,
+		run.Error,
+		run.TriggeredBy,
+		run.RanAt,
+	).Scan(&id)
+	if err != nil {
+		return "", fmt.Errorf("inserting scheduled run: %w", err)
+	}
+
+	return id, nil
+}
+
+// FindUpcomingByWorkspace lists pending scheduled operations of a workspace.
+func (r *Repository) FindUpcomingByWorkspace(ctx context.Context, workspaceID string) ([]*entity.ScheduledItem, error) {
+	rows, err := r.pool.Query(ctx, queryFindUpcomingByWorkspace, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("querying upcoming scheduled operations: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*entity.ScheduledItem
+	for rows.Next() {
+		var item entity.ScheduledItem
+		if err := rows.Scan(
+			&item.VersionID,
+			&item.VersionName,
+			&item.VersionNumber,
+			&item.TemplateID,
+			&item.TemplateTitle,
+			&item.Operation,
+			&item.ScheduledFor,
+			&item.FailedAt,
+			&item.FailureReason,
+		); err != nil {
+			return nil, fmt.Errorf("scanning scheduled operation: %w", err)
+		}
+		result = append(result, &item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating scheduled operations: %w", err)
+	}
+
+	return result, nil
+}
+
+// CountFailures counts the failed runs of an operation scheduled for the given time.
+func (r *Repository) CountFailures(ctx context.Context, versionID string, operation entity.ScheduledOperation, scheduledFor time.Time) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx, queryCountFailures, versionID, operation, scheduledFor).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("counting scheduled run failures: %w", err)
+	}
+	return count, nil
+}
+
+// FindRecentByWorkspace lists the most recent runs of a workspace.
+func (r *Repository) FindRecentByWorkspace(ctx context.Context, workspaceID string, limit int) ([]*entity.ScheduledRun, error) {
+	rows, err := r.pool.Query(ctx, queryFindRecentByWorkspace, workspaceID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying scheduled runs: %w", err)
+	}
+	return scanRuns(rows)
+}
+
+// FindFailuresByWorkspace lists unresolved failed runs of a workspace.
+func (r *Repository) FindFailuresByWorkspace(ctx context.Context, workspaceID string) ([]*entity.ScheduledRun, error) {
+	rows, err := r.pool.Query(ctx, queryFindFailuresByWorkspace, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("querying scheduled failures: %w", err)
+	}
+	return scanRuns(rows)
+}
+
+func scanRuns(rows pgx.Rows) ([]*entity.ScheduledRun, error) {
+	defer rows.Close()
+
+	var result []*entity.ScheduledRun
+	for rows.Next() {
+		var run entity.ScheduledRun
+		if err := rows.Scan(
+			&run.ID,
+			&run.VersionID,
+			&run.VersionName,
+			&run.TemplateID,
+			&run.TemplateTitle,
+			&run.Operation,
+			&run.ScheduledFor,
+			&run.Status,
+			&run.Error,
+			&run.TriggeredBy,
+			&run.RanAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning scheduled run: %w", err)
+		}
+		result = append(result, &run)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating scheduled runs: %w", err)
+	}
+
+	return result, nil
+}
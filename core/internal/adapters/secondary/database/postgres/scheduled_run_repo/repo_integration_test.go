@@ -0,0 +1,104 @@
+//go:build integration
+
+package scheduledrunrepo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	scheduledrunrepo "github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/scheduled_run_repo"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/testutil/testpostgres"
+)
+
+func TestPostgresIntegration_ScheduledRunRepository(t *testing.T) {
+	ctx := context.Background()
+	pg := testpostgres.Run(ctx, t)
+
+	pool, err := pg.NewPool(ctx)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	var tenantID, workspaceID, otherWorkspaceID, templateID string
+	require.NoError(t, pool.QueryRow(ctx,
+		`INSERT INTO tenancy.tenants (name, code) VALUES ('Acme', 'ACME') RETURNING id`).Scan(&tenantID))
+	require.NoError(t, pool.QueryRow(ctx,
+		`INSERT INTO tenancy.workspaces (tenant_id, name, type) VALUES ($1, 'Sales', 'CLIENT') RETURNING id`, tenantID).Scan(&workspaceID))
+	require.NoError(t, pool.QueryRow(ctx,
+		`INSERT INTO tenancy.workspaces (tenant_id, name, type) VALUES ($1, 'Legal', 'CLIENT') RETURNING id`, tenantID).Scan(&otherWorkspaceID))
+	require.NoError(t, pool.QueryRow(ctx,
+		`INSERT INTO content.templates (workspace_id, title) VALUES ($1, 'Offer') RETURNING id`, workspaceID).Scan(&templateID))
+
+	publishAt := time.Date(2026, 7, 1, 9, 0, 0, 0, time.UTC)
+	archiveAt := publishAt.Add(24 * time.Hour)
+	var scheduledID, publishedID string
+	require.NoError(t, pool.QueryRow(ctx, `
+		INSERT INTO content.template_versions (template_id, version_number, name, status, scheduled_publish_at)
+		VALUES ($1, 2, 'v2', 'SCHEDULED', $2) RETURNING id`, templateID, publishAt).Scan(&scheduledID))
+	require.NoError(t, pool.QueryRow(ctx, `
+		INSERT INTO content.template_versions (template_id, version_number, name, status, scheduled_archive_at)
+		VALUES ($1, 1, 'v1', 'PUBLISHED', $2) RETURNING id`, templateID, archiveAt).Scan(&publishedID))
+
+	repo := scheduledrunrepo.New(pool)
+
+	upcoming, err := repo.FindUpcomingByWorkspace(ctx, workspaceID)
+	require.NoError(t, err)
+	require.Len(t, upcoming, 2)
+	assert.Equal(t, scheduledID, upcoming[0].VersionID, "soonest first")
+	assert.Equal(t, entity.ScheduledOperationPublish, upcoming[0].Operation)
+	assert.Equal(t, "Offer", upcoming[0].TemplateTitle)
+	assert.Equal(t, publishedID, upcoming[1].VersionID)
+	assert.Equal(t, entity.ScheduledOperationArchive, upcoming[1].Operation)
+
+	other, err := repo.FindUpcomingByWorkspace(ctx, otherWorkspaceID)
+	require.NoError(t, err)
+	assert.Empty(t, other, "operations of another workspace are not listed")
+
+	// Two failed publish attempts, then a successful archival.
+	for i, ranAt := range []time.Time{publishAt.Add(time.Minute), publishAt.Add(2 * time.Minute)} {
+		run := entity.NewScheduledRun(scheduledID, entity.ScheduledOperationPublish, &publishAt, nil, errors.New("typst failed"))
+		run.RanAt = ranAt
+		id, err := repo.Create(ctx, run)
+		require.NoError(t, err, "run %d", i)
+		assert.NotEmpty(t, id)
+	}
+	archived := entity.NewScheduledRun(publishedID, entity.ScheduledOperationArchive, &archiveAt, nil, nil)
+	archived.RanAt = archiveAt.Add(time.Minute)
+	_, err = repo.Create(ctx, archived)
+	require.NoError(t, err)
+
+	failures, err := repo.CountFailures(ctx, scheduledID, entity.ScheduledOperationPublish, publishAt)
+	require.NoError(t, err)
+	assert.Equal(t, 2, failures)
+	failures, err = repo.CountFailures(ctx, scheduledID, entity.ScheduledOperationPublish, publishAt.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, failures, "a rescheduled operation starts counting again")
+
+	recent, err := repo.FindRecentByWorkspace(ctx, workspaceID, 2)
+	require.NoError(t, err)
+	require.Len(t, recent, 2)
+	assert.Equal(t, publishedID, recent[0].VersionID, "newest first")
+	assert.Equal(t, entity.ScheduledRunSucceeded, recent[0].Status)
+	assert.Equal(t, "v1", recent[0].VersionName)
+	assert.Equal(t, entity.ScheduledRunFailed, recent[1].Status)
+	require.NotNil(t, recent[1].Error)
+	assert.Equal(t, "typst failed", *recent[1].Error)
+
+	unresolved, err := repo.FindFailuresByWorkspace(ctx, workspaceID)
+	require.NoError(t, err)
+	require.Len(t, unresolved, 1, "only the latest failed run of a pending operation")
+	assert.Equal(t, scheduledID, unresolved[0].VersionID)
+	assert.Equal(t, publishAt.Add(2*time.Minute), unresolved[0].RanAt.UTC())
+
+	// Once the schedule is cancelled the failure is resolved.
+	_, err = pool.Exec(ctx, `UPDATE content.template_versions SET status = 'DRAFT', scheduled_publish_at = NULL WHERE id = $1`, scheduledID)
+	require.NoError(t, err)
+	unresolved, err = repo.FindFailuresByWorkspace(ctx, workspaceID)
+	require.NoError(t, err)
+	assert.Empty(t, unresolved)
+}
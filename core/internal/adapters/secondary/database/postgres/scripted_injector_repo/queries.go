@@ -0,0 +1,33 @@
+package scriptedinjectorrepo
+
+// SQL queries for scripted injector operations.
+const (
+	queryColumns = `code, label, description, data_type, source, dependencies, is_critical, timeout_ms,
+		       created_by, updated_by, created_at, updated_at`
+
+	queryFindAll = `
+		SELECT ` + queryColumns + `
+		FROM content.scripted_injectors
+		ORDER BY code`
+
+	queryFindByCode = `
+		SELECT ` + queryColumns + `
+		FROM content.scripted_injectors
+		WHERE code = $1`
+
+	queryCreate = `
+		INSERT INTO content.scripted_injectors (
+			code, label, description, data_type, source, dependencies, is_critical, timeout_ms,
+			created_by, updated_by, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (code) DO NOTHING`
+
+	queryUpdate = `
+		UPDATE content.scripted_injectors
+		SET label = $2, description = $3, data_type = $4, source = $5, dependencies = $6,
+		    is_critical = $7, timeout_ms = $8, updated_by = $9, updated_at = $10
+		WHERE code = $1`
+
+	queryDelete = `DELETE FROM content.scripted_injectors WHERE code = $1`
+)
@@ -0,0 +1,140 @@
+package scriptedinjectorrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new scripted injector repository.
+func New(pool *pgxpool.Pool) port.ScriptedInjectorRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the scripted injector repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// FindAll lists every scripted injector, sorted by code.
+func (r *Repository) FindAll(ctx context.Context) ([]*entity.ScriptedInjector, error) {
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindAll)
+	if err != nil {
+		return nil, fmt.Errorf("listing scripted injectors: %w", err)
+	}
+	defer rows.Close()
+
+	var injectors []*entity.ScriptedInjector
+	for rows.Next() {
+		injector, err := scanInjector(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning scripted injector: %w", err)
+		}
+		injectors = append(injectors, injector)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating scripted injectors: %w", err)
+	}
+	return injectors, nil
+}
+
+// FindByCode finds a scripted injector.
+func (r *Repository) FindByCode(ctx context.Context, code string) (*entity.ScriptedInjector, error) {
+	injector, err := scanInjector(common.Conn(ctx, r.pool).QueryRow(ctx, queryFindByCode, code))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrScriptedInjectorNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying scripted injector: %w", err)
+	}
+	return injector, nil
+}
+
+// Create inserts a scripted injector.
+func (r *Repository) Create(ctx context.Context, injector *entity.ScriptedInjector) error {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryCreate,
+		injector.Code,
+		injector.Label,
+		injector.Description,
+		injector.DataType,
+		injector.Source,
+		injector.Dependencies,
+		injector.IsCritical,
+		injector.TimeoutMs,
+		injector.CreatedBy,
+		injector.UpdatedBy,
+		injector.CreatedAt,
+		injector.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("inserting scripted injector: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return entity.ErrScriptedInjectorExists
+	}
+	return nil
+}
+
+// Update replaces a scripted injector.
+func (r *Repository) Update(ctx context.Context, injector *entity.ScriptedInjector) error {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryUpdate,
+		injector.Code,
+		injector.Label,
+		injector.Description,
+		injector.DataType,
+		injector.Source,
+		injector.Dependencies,
+		injector.IsCritical,
+		injector.TimeoutMs,
+		injector.UpdatedBy,
+		injector.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("updating scripted injector: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return entity.ErrScriptedInjectorNotFound
+	}
+	return nil
+}
+
+// Delete deletes a scripted injector.
+func (r *Repository) Delete(ctx context.Context, code string) error {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryDelete, code)
+	if err != nil {
+		return fmt.Errorf("deleting scripted injector: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return entity.ErrScriptedInjectorNotFound
+	}
+	return nil
+}
+
+func scanInjector(row pgx.Row) (*entity.ScriptedInjector, error) {
+	injector := &entity.ScriptedInjector{}
+	err := row.Scan(
+		&injector.Code,
+		&injector.Label,
+		&injector.Description,
+		&injector.DataType,
+		&injector.Source,
+		&injector.Dependencies,
+		&injector.IsCritical,
+		&injector.TimeoutMs,
+		&injector.CreatedBy,
+		&injector.UpdatedBy,
+		&injector.CreatedAt,
+		&injector.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return injector, nil
+}
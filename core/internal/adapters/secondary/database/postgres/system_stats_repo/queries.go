@@ -0,0 +1,61 @@
+package systemstatsrepo
+
+// SQL queries for platform-wide statistics.
+const (
+	queryCountEntities = `
+		SELECT
+			(SELECT COUNT(*) FROM tenancy.tenants WHERE NOT is_system),
+			(SELECT COUNT(*) FROM tenancy.tenants WHERE NOT is_system AND status = 'ACTIVE'),
+			(SELECT COUNT(*) FROM tenancy.workspaces WHERE type = 'CLIENT'),
+			(SELECT COUNT(*) FROM tenancy.workspace_sandboxes),
+			(SELECT COUNT(*) FROM content.templates),
+			(SELECT COUNT(*) FROM content.template_versions WHERE status = 'PUBLISHED'),
+			(SELECT COUNT(*) FROM identity.users)`
+
+	// queryAddRenderCounts adds to the counters of each day, creating the missing days
+	queryAddRenderCounts = `
+		INSERT INTO tenancy.render_daily_stats AS s (day, renders, failures, pages, duration_ms, updated_at)
+		SELECT *, CURRENT_TIMESTAMP
+		FROM unnest($1::date[], $2::bigint[], $3::bigint[], $4::bigint[], $5::bigint[])
+		ON CONFLICT (day)
+		DO UPDATE SET
+			renders = s.renders + EXCLUDED.renders,
+			failures = s.failures + EXCLUDED.failures,
+			pages = s.pages + EXCLUDED.pages,
+			duration_ms = s.duration_ms + EXCLUDED.duration_ms,
+			updated_at = EXCLUDED.updated_at`
+
+	queryListRenderDays = `
+		SELECT day, renders, failures, pages, duration_ms
+		FROM tenancy.render_daily_stats
+		WHERE day >= $1::date
+		ORDER BY day`
+
+	queryGetQueueHealth = `
+		SELECT
+			(SELECT COUNT(*) FROM tenancy.outbox_events WHERE delivered_at IS NULL AND failed_at IS NULL),
+			(SELECT COUNT(*) FROM tenancy.outbox_events WHERE failed_at IS NOT NULL),
+			(SELECT MIN(created_at) FROM tenancy.outbox_events WHERE delivered_at IS NULL AND failed_at IS NULL),
+			(SELECT COUNT(*) FROM content.hosted_documents WHERE indexed_at IS NULL AND index_error IS NULL),
+			(SELECT COUNT(*) FROM content.template_versions WHERE status = 'SCHEDULED')`
+
+	queryGetStorageTotals = `
+		SELECT
+			(SELECT COALESCE(SUM(size_bytes), 0) FROM content.asset_versions),
+			(SELECT COALESCE(SUM(size_bytes), 0) FROM content.hosted_documents)`
+
+	// queryListStorageDays groups uploads by UTC day
+	queryListStorageDays = `
+		SELECT day, SUM(bytes)
+		FROM (
+			SELECT (created_at AT TIME ZONE 'UTC')::date AS day, size_bytes AS bytes
+			FROM content.asset_versions
+			WHERE created_at >= $1
+			UNION ALL
+			SELECT (created_at AT TIME ZONE 'UTC')::date, size_bytes
+			FROM content.hosted_documents
+			WHERE created_at >= $1
+		) uploads
+		GROUP BY day
+		ORDER BY day`
+)
@@ -0,0 +1,125 @@
+package systemstatsrepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new system stats repository.
+func New(pool *pgxpool.Pool) port.SystemStatsRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the system stats repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// CountEntities counts tenants, workspaces, templates and users.
+func (r *Repository) CountEntities(ctx context.Context) (*entity.EntityCounts, error) {
+	var c entity.EntityCounts
+	err := r.pool.QueryRow(ctx, queryCountEntities).Scan(
+		&c.Tenants,
+		&c.ActiveTenants,
+		&c.Workspaces,
+		&c.SandboxWorkspaces,
+		&c.Templates,
+		&c.PublishedVersions,
+		&c.Users,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("counting entities: %w", err)
+	}
+	return &c, nil
+}
+
+// AddRenderCounts adds render counts to their days in one statement.
+func (r *Repository) AddRenderCounts(ctx context.Context, days []*entity.RenderDayStats) error {
+	if len(days) == 0 {
+		return nil
+	}
+
+	dates := make([]time.Time, len(days))
+	renders := make([]int64, len(days))
+	failures := make([]int64, len(days))
+	pages := make([]int64, len(days))
+	durations := make([]int64, len(days))
+	for i, d := range days {
+		dates[i], renders[i], failures[i], pages[i], durations[i] = d.Day, d.Renders, d.Failures, d.Pages, d.DurationMs
+	}
+
+	if _, err := r.pool.Exec(ctx, queryAddRenderCounts, dates, renders, failures, pages, durations); err != nil {
+		return fmt.Errorf("adding render counts: %w", err)
+	}
+	return nil
+}
+
+// ListRenderDays returns the render counts of the days from since, oldest first.
+func (r *Repository) ListRenderDays(ctx context.Context, since time.Time) ([]*entity.RenderDayStats, error) {
+	rows, err := r.pool.Query(ctx, queryListRenderDays, since)
+	if err != nil {
+		return nil, fmt.Errorf("listing render days: %w", err)
+	}
+	defer rows.Close()
+
+	var days []*entity.RenderDayStats
+	for rows.Next() {
+		d := &entity.RenderDayStats{}
+		if err := rows.Scan(&d.Day, &d.Renders, &d.Failures, &d.Pages, &d.DurationMs); err != nil {
+			return nil, fmt.Errorf("scanning render day: %w", err)
+		}
+		days = append(days, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating render days: %w", err)
+	}
+	return days, nil
+}
+
+// GetQueueHealth returns the backlogs of the outbox, the document indexer and scheduled publications.
+func (r *Repository) GetQueueHealth(ctx context.Context) (*entity.QueueHealth, error) {
+	var q entity.QueueHealth
+	err := r.pool.QueryRow(ctx, queryGetQueueHealth).Scan(
+		&q.OutboxPending,
+		&q.OutboxFailed,
+		&q.OutboxOldestPendingAt,
+		&q.DocumentIndexPending,
+		&q.ScheduledVersions,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying queue health: %w", err)
+	}
+	return &q, nil
+}
+
+// GetStorageUsage returns the stored bytes and the bytes uploaded per day from since.
+func (r *Repository) GetStorageUsage(ctx context.Context, since time.Time) (*entity.StorageUsage, error) {
+	var u entity.StorageUsage
+	if err := r.pool.QueryRow(ctx, queryGetStorageTotals).Scan(&u.AssetBytes, &u.HostedDocumentBytes); err != nil {
+		return nil, fmt.Errorf("querying storage totals: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, queryListStorageDays, since)
+	if err != nil {
+		return nil, fmt.Errorf("listing storage days: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		d := &entity.StorageDayUsage{}
+		if err := rows.Scan(&d.Day, &d.AddedBytes); err != nil {
+			return nil, fmt.Errorf("scanning storage day: %w", err)
+		}
+		u.Days = append(u.Days, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating storage days: %w", err)
+	}
+	return &u, nil
+}
@@ -0,0 +1,22 @@
+package templatelibraryrepo
+
+// SQL queries for template library link operations.
+const (
+	queryCreate = `
+		INSERT INTO content.template_library_links (
+			template_id, source_template_id, base_version_id, base_version_number, base_content,
+			asset_map, installed_by, installed_at, synced_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	queryFindByTemplateID = `
+		SELECT template_id, source_template_id, base_version_id, base_version_number, base_content,
+		       asset_map, installed_by, installed_at, synced_at
+		FROM content.template_library_links
+		WHERE template_id = $1`
+
+	queryUpdateBase = `
+		UPDATE content.template_library_links
+		SET base_version_id = $2, base_version_number = $3, base_content = $4, asset_map = $5, synced_at = $6
+		WHERE template_id = $1`
+)
@@ -0,0 +1,93 @@
+package templatelibraryrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new template library repository.
+func New(pool *pgxpool.Pool) port.TemplateLibraryRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the template library repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// Create links a template to the library template it was installed from.
+func (r *Repository) Create(ctx context.Context, link *entity.TemplateLibraryLink) error {
+	_, err := common.Conn(ctx, r.pool).Exec(ctx, queryCreate,
+		link.TemplateID,
+		link.SourceTemplateID,
+		link.BaseVersionID,
+		link.BaseVersionNumber,
+		link.BaseContent,
+		assetMap(link),
+		link.InstalledBy,
+		link.InstalledAt,
+		link.SyncedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("inserting template library link: %w", err)
+	}
+	return nil
+}
+
+// FindByTemplateID finds the link of an installed template.
+func (r *Repository) FindByTemplateID(ctx context.Context, templateID string) (*entity.TemplateLibraryLink, error) {
+	var link entity.TemplateLibraryLink
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindByTemplateID, templateID).Scan(
+		&link.TemplateID,
+		&link.SourceTemplateID,
+		&link.BaseVersionID,
+		&link.BaseVersionNumber,
+		&link.BaseContent,
+		&link.AssetMap,
+		&link.InstalledBy,
+		&link.InstalledAt,
+		&link.SyncedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrTemplateNotLinked
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying template library link: %w", err)
+	}
+	return &link, nil
+}
+
+// UpdateBase moves the base of a link to the upstream version it pulled.
+func (r *Repository) UpdateBase(ctx context.Context, link *entity.TemplateLibraryLink) error {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryUpdateBase,
+		link.TemplateID,
+		link.BaseVersionID,
+		link.BaseVersionNumber,
+		link.BaseContent,
+		assetMap(link),
+		link.SyncedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("updating template library link: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return entity.ErrTemplateNotLinked
+	}
+	return nil
+}
+
+// assetMap returns the asset map of a link, empty rather than nil so it is stored as an object.
+func assetMap(link *entity.TemplateLibraryLink) map[string]string {
+	if link.AssetMap == nil {
+		return map[string]string{}
+	}
+	return link.AssetMap
+}
@@ -7,17 +7,17 @@ const (
 			workspace_id, folder_id, document_type_id, title, is_public_library, created_at
 		)
 		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id`
+		RETURNING id, revision`
 
 	queryFindByID = `
-		SELECT id, workspace_id, folder_id, document_type_id, title, is_public_library, created_at, updated_at
+		SELECT id, workspace_id, folder_id, document_type_id, title, is_public_library, revision, created_at, updated_at
 		FROM content.templates
 		WHERE id = $1`
 
 	queryPublishedVersion = `
 		SELECT id, template_id, version_number, name, description, content_structure,
 			status, scheduled_publish_at, scheduled_archive_at, published_at, archived_at,
-			published_by, archived_by, created_by, created_at, updated_at
+			published_by, archived_by, created_by, created_at, updated_at, revision
 		FROM content.template_versions
 		WHERE template_id = $1 AND status = 'PUBLISHED'`
 
@@ -48,9 +48,9 @@ const (
 		WHERE id = $1`
 
 	queryAllVersions = `
-		SELECT id, template_id, version_number, name, description, content_structure,
+		SELECT id, template_id, version_number, name, description,
 			status, scheduled_publish_at, scheduled_archive_at, published_at, archived_at,
-			published_by, archived_by, created_by, created_at, updated_at
+			published_by, archived_by, created_by, created_at, updated_at, revision
 		FROM content.template_versions
 		WHERE template_id = $1
 		ORDER BY version_number DESC`
@@ -59,7 +59,7 @@ const (
 		SELECT
 			t.id, t.workspace_id, t.folder_id, t.document_type_id,
 			(SELECT dt.code FROM content.document_types dt WHERE dt.id = t.document_type_id) as document_type_code,
-			t.title, t.is_public_library,
+			t.title, t.is_public_library, t.revision,
 			t.created_at, t.updated_at,
 			EXISTS(SELECT 1 FROM content.template_versions WHERE template_id = t.id AND status = 'PUBLISHED') as has_published,
 			EXISTS(SELECT 1 FROM content.template_versions WHERE template_id = t.id AND status = 'STAGING') as has_staging,
@@ -74,7 +74,7 @@ const (
 		SELECT
 			t.id, t.workspace_id, t.folder_id, t.document_type_id,
 			(SELECT dt.code FROM content.document_types dt WHERE dt.id = t.document_type_id) as document_type_code,
-			t.title, t.is_public_library,
+			t.title, t.is_public_library, t.revision,
 			t.created_at, t.updated_at,
 			EXISTS(SELECT 1 FROM content.template_versions WHERE template_id = t.id AND status = 'PUBLISHED') as has_published,
 			EXISTS(SELECT 1 FROM content.template_versions WHERE template_id = t.id AND status = 'STAGING') as has_staging,
@@ -89,7 +89,7 @@ const (
 		SELECT
 			t.id, t.workspace_id, t.folder_id, t.document_type_id,
 			(SELECT dt.code FROM content.document_types dt WHERE dt.id = t.document_type_id) as document_type_code,
-			t.title, t.is_public_library,
+			t.title, t.is_public_library, t.revision,
 			t.created_at, t.updated_at,
 			true as has_published,
 			EXISTS(SELECT 1 FROM content.template_versions WHERE template_id = t.id AND status = 'STAGING') as has_staging,
@@ -97,14 +97,21 @@ const (
 			(SELECT COUNT(*) FROM content.template_versions WHERE template_id = t.id AND status = 'SCHEDULED') as scheduled_version_count,
 			(SELECT version_number FROM content.template_versions WHERE template_id = t.id AND status = 'PUBLISHED' LIMIT 1) as published_version_number
 		FROM content.templates t
+		JOIN tenancy.workspaces w ON w.id = t.workspace_id
 		WHERE t.is_public_library = true
+			AND t.workspace_id <> $1
+			AND w.tenant_id = (SELECT tenant_id FROM tenancy.workspaces WHERE id = $1)
 			AND EXISTS(SELECT 1 FROM content.template_versions WHERE template_id = t.id AND status = 'PUBLISHED')
 		ORDER BY t.title`
 
 	queryUpdate = `
 		UPDATE content.templates
-		SET title = $2, folder_id = $3, document_type_id = $4, is_public_library = $5, updated_at = $6
-		WHERE id = $1`
+		SET title = $2, folder_id = $3, document_type_id = $4, is_public_library = $5, updated_at = $6,
+			revision = revision + 1
+		WHERE id = $1 AND revision = $7
+		RETURNING revision`
+
+	queryExists = `SELECT EXISTS(SELECT 1 FROM content.templates WHERE id = $1)`
 
 	queryDelete = `DELETE FROM content.templates WHERE id = $1`
 
@@ -123,14 +130,14 @@ const (
 
 	// Document Type queries
 	queryFindByDocumentType = `
-		SELECT id, workspace_id, folder_id, document_type_id, title, is_public_library, created_at, updated_at
+		SELECT id, workspace_id, folder_id, document_type_id, title, is_public_library, revision, created_at, updated_at
 		FROM content.templates
 		WHERE workspace_id = $1 AND document_type_id = $2`
 
 	queryFindByDocumentTypeCode = `
 		SELECT
 			t.id, t.workspace_id, t.folder_id, t.document_type_id, dt.code as document_type_code,
-			t.title, t.is_public_library,
+			t.title, t.is_public_library, t.revision,
 			t.created_at, t.updated_at,
 			EXISTS(SELECT 1 FROM content.template_versions WHERE template_id = t.id AND status = 'PUBLISHED') as has_published,
 			EXISTS(SELECT 1 FROM content.template_versions WHERE template_id = t.id AND status = 'STAGING') as has_staging,
@@ -145,6 +152,6 @@ const (
 
 	queryUpdateDocumentType = `
 		UPDATE content.templates
-		SET document_type_id = $2, updated_at = CURRENT_TIMESTAMP
+		SET document_type_id = $2, updated_at = CURRENT_TIMESTAMP, revision = revision + 1
 		WHERE id = $1`
 )
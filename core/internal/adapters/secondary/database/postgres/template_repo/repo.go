@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
 	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	"github.com/rendis/pdf-forge/core/internal/core/port"
 )
@@ -25,14 +26,14 @@ type Repository struct {
 // Create creates a new template.
 func (r *Repository) Create(ctx context.Context, template *entity.Template) (string, error) {
 	var id string
-	err := r.pool.QueryRow(ctx, queryCreate,
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryCreate,
 		template.WorkspaceID,
 		template.FolderID,
 		template.DocumentTypeID,
 		template.Title,
 		template.IsPublicLibrary,
 		template.CreatedAt,
-	).Scan(&id)
+	).Scan(&id, &template.Revision)
 	if err != nil {
 		return "", fmt.Errorf("creating template: %w", err)
 	}
@@ -43,13 +44,14 @@ func (r *Repository) Create(ctx context.Context, template *entity.Template) (str
 // FindByID finds a template by ID.
 func (r *Repository) FindByID(ctx context.Context, id string) (*entity.Template, error) {
 	template := &entity.Template{}
-	err := r.pool.QueryRow(ctx, queryFindByID, id).Scan(
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindByID, id).Scan(
 		&template.ID,
 		&template.WorkspaceID,
 		&template.FolderID,
 		&template.DocumentTypeID,
 		&template.Title,
 		&template.IsPublicLibrary,
+		&template.Revision,
 		&template.CreatedAt,
 		&template.UpdatedAt,
 	)
@@ -120,7 +122,7 @@ func (r *Repository) FindByWorkspace(ctx context.Context, workspaceID string, fi
 	query := queryFindByWorkspaceBase + filterQuery
 	args := append([]any{workspaceID}, filterArgs...)
 
-	rows, err := r.pool.Query(ctx, query, args...)
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("querying templates: %w", err)
 	}
@@ -140,7 +142,7 @@ func (r *Repository) FindByWorkspace(ctx context.Context, workspaceID string, fi
 
 // FindByFolder lists all templates in a folder.
 func (r *Repository) FindByFolder(ctx context.Context, folderID string) ([]*entity.TemplateListItem, error) {
-	rows, err := r.pool.Query(ctx, queryFindByFolder, folderID)
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindByFolder, folderID)
 	if err != nil {
 		return nil, fmt.Errorf("querying templates by folder: %w", err)
 	}
@@ -159,9 +161,10 @@ func (r *Repository) FindByFolder(ctx context.Context, folderID string) ([]*enti
 	return templates, nil
 }
 
-// FindPublicLibrary lists all public library templates (that have a published version).
+// FindPublicLibrary lists the public library templates (that have a published version) of the other
+// workspaces of the workspace's tenant.
 func (r *Repository) FindPublicLibrary(ctx context.Context, workspaceID string) ([]*entity.TemplateListItem, error) {
-	rows, err := r.pool.Query(ctx, queryFindPublicLibrary)
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindPublicLibrary, workspaceID)
 	if err != nil {
 		return nil, fmt.Errorf("querying public library templates: %w", err)
 	}
@@ -183,7 +186,7 @@ func (r *Repository) FindPublicLibrary(ctx context.Context, workspaceID string)
 // loadPublishedVersion loads the published version for a template.
 func (r *Repository) loadPublishedVersion(ctx context.Context, templateID string) (*entity.TemplateVersion, error) {
 	version := &entity.TemplateVersion{}
-	err := r.pool.QueryRow(ctx, queryPublishedVersion, templateID).Scan(
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryPublishedVersion, templateID).Scan(
 		&version.ID,
 		&version.TemplateID,
 		&version.VersionNumber,
@@ -200,6 +203,7 @@ func (r *Repository) loadPublishedVersion(ctx context.Context, templateID string
 		&version.CreatedBy,
 		&version.CreatedAt,
 		&version.UpdatedAt,
+		&version.Revision,
 	)
 	if err != nil {
 		return nil, err
@@ -209,7 +213,7 @@ func (r *Repository) loadPublishedVersion(ctx context.Context, templateID string
 
 // loadVersionInjectables loads injectables for a version.
 func (r *Repository) loadVersionInjectables(ctx context.Context, versionID string) ([]*entity.VersionInjectableWithDefinition, error) {
-	rows, err := r.pool.Query(ctx, queryVersionInjectables, versionID)
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryVersionInjectables, versionID)
 	if err != nil {
 		return nil, fmt.Errorf("querying version injectables: %w", err)
 	}
@@ -236,7 +240,7 @@ func (r *Repository) loadVersionInjectables(ctx context.Context, versionID strin
 
 // loadTemplateTags loads tags for a template.
 func (r *Repository) loadTemplateTags(ctx context.Context, templateID string) ([]*entity.Tag, error) {
-	rows, err := r.pool.Query(ctx, queryTemplateTags, templateID)
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryTemplateTags, templateID)
 	if err != nil {
 		return nil, fmt.Errorf("querying template tags: %w", err)
 	}
@@ -259,7 +263,7 @@ func (r *Repository) loadTemplateTags(ctx context.Context, templateID string) ([
 // loadFolder loads a folder by ID.
 func (r *Repository) loadFolder(ctx context.Context, folderID string) *entity.Folder {
 	folder := &entity.Folder{}
-	err := r.pool.QueryRow(ctx, queryFolder, folderID).Scan(
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFolder, folderID).Scan(
 		&folder.ID, &folder.WorkspaceID, &folder.ParentID,
 		&folder.Name, &folder.CreatedAt, &folder.UpdatedAt,
 	)
@@ -272,7 +276,7 @@ func (r *Repository) loadFolder(ctx context.Context, folderID string) *entity.Fo
 // loadDocumentType loads a document type by ID.
 func (r *Repository) loadDocumentType(ctx context.Context, documentTypeID string) *entity.DocumentType {
 	docType := &entity.DocumentType{}
-	err := r.pool.QueryRow(ctx, queryDocumentType, documentTypeID).Scan(
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryDocumentType, documentTypeID).Scan(
 		&docType.ID, &docType.TenantID, &docType.Code,
 		&docType.Name, &docType.Description,
 		&docType.CreatedAt, &docType.UpdatedAt,
@@ -283,9 +287,9 @@ func (r *Repository) loadDocumentType(ctx context.Context, documentTypeID string
 	return docType
 }
 
-// loadAllVersions loads all versions for a template.
+// loadAllVersions loads all versions for a template, without their content structure.
 func (r *Repository) loadAllVersions(ctx context.Context, templateID string) ([]*entity.TemplateVersionWithDetails, error) {
-	rows, err := r.pool.Query(ctx, queryAllVersions, templateID)
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryAllVersions, templateID)
 	if err != nil {
 		return nil, fmt.Errorf("querying template versions: %w", err)
 	}
@@ -296,9 +300,9 @@ func (r *Repository) loadAllVersions(ctx context.Context, templateID string) ([]
 		v := &entity.TemplateVersion{}
 		if err := rows.Scan(
 			&v.ID, &v.TemplateID, &v.VersionNumber, &v.Name, &v.Description,
-			&v.ContentStructure, &v.Status, &v.ScheduledPublishAt, &v.ScheduledArchiveAt,
+			&v.Status, &v.ScheduledPublishAt, &v.ScheduledArchiveAt,
 			&v.PublishedAt, &v.ArchivedAt, &v.PublishedBy, &v.ArchivedBy,
-			&v.CreatedBy, &v.CreatedAt, &v.UpdatedAt,
+			&v.CreatedBy, &v.CreatedAt, &v.UpdatedAt, &v.Revision,
 		); err != nil {
 			return nil, fmt.Errorf("scanning template version: %w", err)
 		}
@@ -368,7 +372,7 @@ func scanTemplateListItems(rows pgx.Rows) ([]*entity.TemplateListItem, error) {
 		if err := rows.Scan(
 			&item.ID, &item.WorkspaceID, &item.FolderID,
 			&item.DocumentTypeID, &item.DocumentTypeCode,
-			&item.Title, &item.IsPublicLibrary, &item.CreatedAt, &item.UpdatedAt,
+			&item.Title, &item.IsPublicLibrary, &item.Revision, &item.CreatedAt, &item.UpdatedAt,
 			&item.HasPublishedVersion, &item.HasStagingVersion,
 			&item.VersionCount, &item.ScheduledVersionCount,
 			&item.PublishedVersionNumber,
@@ -399,7 +403,7 @@ func (r *Repository) loadTagsForTemplates(ctx context.Context, templates []*enti
 	}
 
 	// Query all tags for these templates in one batch
-	rows, err := r.pool.Query(ctx, queryTemplateTagsBatch, templateIDs)
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryTemplateTagsBatch, templateIDs)
 	if err != nil {
 		return fmt.Errorf("querying template tags batch: %w", err)
 	}
@@ -429,30 +433,32 @@ func (r *Repository) loadTagsForTemplates(ctx context.Context, templates []*enti
 	return nil
 }
 
-// Update updates a template.
+// Update updates a template if its revision is still template.Revision,
+// then sets template.Revision to the new revision.
 func (r *Repository) Update(ctx context.Context, template *entity.Template) error {
-	result, err := r.pool.Exec(ctx, queryUpdate,
+	conn := common.Conn(ctx, r.pool)
+	err := conn.QueryRow(ctx, queryUpdate,
 		template.ID,
 		template.Title,
 		template.FolderID,
 		template.DocumentTypeID,
 		template.IsPublicLibrary,
 		template.UpdatedAt,
-	)
+		template.Revision,
+	).Scan(&template.Revision)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return common.StaleOrMissing(ctx, conn, entity.ErrTemplateNotFound, queryExists, template.ID)
+		}
 		return fmt.Errorf("updating template: %w", err)
 	}
 
-	if result.RowsAffected() == 0 {
-		return entity.ErrTemplateNotFound
-	}
-
 	return nil
 }
 
 // Delete deletes a template.
 func (r *Repository) Delete(ctx context.Context, id string) error {
-	result, err := r.pool.Exec(ctx, queryDelete, id)
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryDelete, id)
 	if err != nil {
 		return fmt.Errorf("deleting template: %w", err)
 	}
@@ -467,7 +473,7 @@ func (r *Repository) Delete(ctx context.Context, id string) error {
 // ExistsByTitle checks if a template with the given title exists in the workspace.
 func (r *Repository) ExistsByTitle(ctx context.Context, workspaceID, title string) (bool, error) {
 	var exists bool
-	err := r.pool.QueryRow(ctx, queryExistsByTitle, workspaceID, title).Scan(&exists)
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryExistsByTitle, workspaceID, title).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("checking template title existence: %w", err)
 	}
@@ -478,7 +484,7 @@ func (r *Repository) ExistsByTitle(ctx context.Context, workspaceID, title strin
 // ExistsByTitleExcluding checks if a template with the given title exists, excluding a specific ID.
 func (r *Repository) ExistsByTitleExcluding(ctx context.Context, workspaceID, title, excludeID string) (bool, error) {
 	var exists bool
-	err := r.pool.QueryRow(ctx, queryExistsByTitleExcluding, workspaceID, title, excludeID).Scan(&exists)
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryExistsByTitleExcluding, workspaceID, title, excludeID).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("checking template title existence: %w", err)
 	}
@@ -489,7 +495,7 @@ func (r *Repository) ExistsByTitleExcluding(ctx context.Context, workspaceID, ti
 // CountByFolder returns the number of templates in a folder.
 func (r *Repository) CountByFolder(ctx context.Context, folderID string) (int, error) {
 	var count int
-	err := r.pool.QueryRow(ctx, queryCountByFolder, folderID).Scan(&count)
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryCountByFolder, folderID).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("counting templates in folder: %w", err)
 	}
@@ -500,13 +506,14 @@ func (r *Repository) CountByFolder(ctx context.Context, folderID string) (int, e
 // FindByDocumentType finds the template assigned to a document type in a workspace.
 func (r *Repository) FindByDocumentType(ctx context.Context, workspaceID, documentTypeID string) (*entity.Template, error) {
 	template := &entity.Template{}
-	err := r.pool.QueryRow(ctx, queryFindByDocumentType, workspaceID, documentTypeID).Scan(
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindByDocumentType, workspaceID, documentTypeID).Scan(
 		&template.ID,
 		&template.WorkspaceID,
 		&template.FolderID,
 		&template.DocumentTypeID,
 		&template.Title,
 		&template.IsPublicLibrary,
+		&template.Revision,
 		&template.CreatedAt,
 		&template.UpdatedAt,
 	)
@@ -522,7 +529,7 @@ func (r *Repository) FindByDocumentType(ctx context.Context, workspaceID, docume
 
 // FindByDocumentTypeCode finds templates by document type code across a tenant.
 func (r *Repository) FindByDocumentTypeCode(ctx context.Context, tenantID, documentTypeCode string) ([]*entity.TemplateListItem, error) {
-	rows, err := r.pool.Query(ctx, queryFindByDocumentTypeCode, tenantID, documentTypeCode)
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindByDocumentTypeCode, tenantID, documentTypeCode)
 	if err != nil {
 		return nil, fmt.Errorf("querying templates by document type code: %w", err)
 	}
@@ -543,7 +550,7 @@ func (r *Repository) FindByDocumentTypeCode(ctx context.Context, tenantID, docum
 
 // UpdateDocumentType updates the document type assignment for a template.
 func (r *Repository) UpdateDocumentType(ctx context.Context, templateID string, documentTypeID *string) error {
-	result, err := r.pool.Exec(ctx, queryUpdateDocumentType, templateID, documentTypeID)
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryUpdateDocumentType, templateID, documentTypeID)
 	if err != nil {
 		return fmt.Errorf("updating template document type: %w", err)
 	}
@@ -0,0 +1,67 @@
+package templateslarepo
+
+// SQL queries for template SLA operations.
+const (
+	// queryUpsert clears the breach: the replaced SLA may no longer be breached
+	queryUpsert = `
+		INSERT INTO content.template_slas (
+			template_id, max_render_ms, max_failure_rate, window_minutes, min_renders, breached_at, updated_by, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, NULL, $6, $7)
+		ON CONFLICT (template_id)
+		DO UPDATE SET
+			max_render_ms = EXCLUDED.max_render_ms,
+			max_failure_rate = EXCLUDED.max_failure_rate,
+			window_minutes = EXCLUDED.window_minutes,
+			min_renders = EXCLUDED.min_renders,
+			breached_at = NULL,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at`
+
+	queryFindByTemplateID = `
+		SELECT template_id, max_render_ms, max_failure_rate::float8, window_minutes, min_renders,
+		       breached_at, updated_by, updated_at
+		FROM content.template_slas
+		WHERE template_id = $1`
+
+	queryDelete = `DELETE FROM content.template_slas WHERE template_id = $1`
+
+	queryFindWindow = `
+		SELECT COALESCE(SUM(renders), 0), COALESCE(SUM(failures), 0), COALESCE(SUM(duration_ms), 0)
+		FROM content.template_render_minutes
+		WHERE template_id = $1 AND minute >= NOW() - $2::interval`
+
+	queryListStatuses = `
+		SELECT s.template_id, s.max_render_ms, s.max_failure_rate::float8, s.window_minutes, s.min_renders,
+		       s.breached_at, s.updated_by, s.updated_at, t.workspace_id, t.title,
+		       COALESCE(SUM(m.renders), 0), COALESCE(SUM(m.failures), 0), COALESCE(SUM(m.duration_ms), 0)
+		FROM content.template_slas s
+		JOIN content.templates t ON t.id = s.template_id
+		LEFT JOIN content.template_render_minutes m
+		       ON m.template_id = s.template_id
+		      AND m.minute >= NOW() - make_interval(mins => s.window_minutes)
+		GROUP BY s.template_id, t.workspace_id, t.title`
+
+	// queryAddRenderMinutes adds to the counters of each minute, creating the missing minutes
+	queryAddRenderMinutes = `
+		INSERT INTO content.template_render_minutes AS m (template_id, minute, renders, failures, duration_ms)
+		SELECT *
+		FROM unnest($1::uuid[], $2::timestamptz[], $3::int[], $4::int[], $5::bigint[])
+		ON CONFLICT (template_id, minute)
+		DO UPDATE SET
+			renders = m.renders + EXCLUDED.renders,
+			failures = m.failures + EXCLUDED.failures,
+			duration_ms = m.duration_ms + EXCLUDED.duration_ms`
+
+	queryMarkBreached = `
+		UPDATE content.template_slas
+		SET breached_at = $2
+		WHERE template_id = $1 AND breached_at IS NULL`
+
+	queryMarkRecovered = `
+		UPDATE content.template_slas
+		SET breached_at = NULL
+		WHERE template_id = $1 AND breached_at = $2`
+
+	queryDeleteRenderMinutesBefore = `DELETE FROM content.template_render_minutes WHERE minute < $1`
+)
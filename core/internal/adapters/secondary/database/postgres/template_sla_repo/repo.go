@@ -0,0 +1,168 @@
+package templateslarepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new template SLA repository.
+func New(pool *pgxpool.Pool) port.TemplateSLARepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the template SLA repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// Upsert creates or replaces the SLA of a template, clearing its breach.
+func (r *Repository) Upsert(ctx context.Context, sla *entity.TemplateSLA) error {
+	_, err := common.Conn(ctx, r.pool).Exec(ctx, queryUpsert,
+		sla.TemplateID,
+		sla.MaxRenderMs,
+		sla.MaxFailureRate,
+		sla.WindowMinutes,
+		sla.MinRenders,
+		sla.UpdatedBy,
+		sla.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("upserting template SLA: %w", err)
+	}
+	sla.BreachedAt = nil
+	return nil
+}
+
+// FindByTemplateID finds the SLA of a template.
+func (r *Repository) FindByTemplateID(ctx context.Context, templateID string) (*entity.TemplateSLA, error) {
+	sla, err := scanSLA(common.Conn(ctx, r.pool).QueryRow(ctx, queryFindByTemplateID, templateID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrTemplateSLANotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying template SLA: %w", err)
+	}
+	return sla, nil
+}
+
+// Delete deletes the SLA of a template.
+func (r *Repository) Delete(ctx context.Context, templateID string) error {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryDelete, templateID)
+	if err != nil {
+		return fmt.Errorf("deleting template SLA: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return entity.ErrTemplateSLANotFound
+	}
+	return nil
+}
+
+// FindWindow sums the renders of a template over the last window.
+func (r *Repository) FindWindow(ctx context.Context, templateID string, window time.Duration) (entity.TemplateRenderWindow, error) {
+	var w entity.TemplateRenderWindow
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindWindow, templateID, window).
+		Scan(&w.Renders, &w.Failures, &w.DurationMs)
+	if err != nil {
+		return w, fmt.Errorf("querying template render window: %w", err)
+	}
+	return w, nil
+}
+
+// ListStatuses lists every SLA with the renders of its current window.
+func (r *Repository) ListStatuses(ctx context.Context) ([]*entity.TemplateSLAStatus, error) {
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryListStatuses)
+	if err != nil {
+		return nil, fmt.Errorf("listing template SLA statuses: %w", err)
+	}
+	defer rows.Close()
+
+	var statuses []*entity.TemplateSLAStatus
+	for rows.Next() {
+		s := &entity.TemplateSLAStatus{}
+		s.SLA, err = scanSLA(rows, &s.WorkspaceID, &s.TemplateTitle, &s.Window.Renders, &s.Window.Failures, &s.Window.DurationMs)
+		if err != nil {
+			return nil, fmt.Errorf("scanning template SLA status: %w", err)
+		}
+		statuses = append(statuses, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating template SLA statuses: %w", err)
+	}
+	return statuses, nil
+}
+
+// AddRenderMinutes adds render counts to the per-minute counts of their templates in one statement.
+func (r *Repository) AddRenderMinutes(ctx context.Context, minutes []*entity.TemplateRenderMinute) error {
+	if len(minutes) == 0 {
+		return nil
+	}
+
+	templateIDs := make([]string, len(minutes))
+	times := make([]time.Time, len(minutes))
+	renders := make([]int64, len(minutes))
+	failures := make([]int64, len(minutes))
+	durations := make([]int64, len(minutes))
+	for i, m := range minutes {
+		templateIDs[i], times[i], renders[i], failures[i], durations[i] = m.TemplateID, m.Minute, m.Renders, m.Failures, m.DurationMs
+	}
+
+	if _, err := common.Conn(ctx, r.pool).Exec(ctx, queryAddRenderMinutes, templateIDs, times, renders, failures, durations); err != nil {
+		return fmt.Errorf("adding template render minutes: %w", err)
+	}
+	return nil
+}
+
+// MarkBreached records that an SLA is breached since at, unless it already is.
+func (r *Repository) MarkBreached(ctx context.Context, templateID string, at time.Time) (bool, error) {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryMarkBreached, templateID, at)
+	if err != nil {
+		return false, fmt.Errorf("marking template SLA breached: %w", err)
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// MarkRecovered clears the breach recorded at breachedAt, unless it already was.
+func (r *Repository) MarkRecovered(ctx context.Context, templateID string, breachedAt time.Time) (bool, error) {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryMarkRecovered, templateID, breachedAt)
+	if err != nil {
+		return false, fmt.Errorf("marking template SLA recovered: %w", err)
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// DeleteRenderMinutesBefore deletes the per-minute counts older than before.
+func (r *Repository) DeleteRenderMinutesBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryDeleteRenderMinutesBefore, before)
+	if err != nil {
+		return 0, fmt.Errorf("deleting template render minutes: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// scanSLA scans the columns of an SLA, followed by extra.
+func scanSLA(row pgx.Row, extra ...any) (*entity.TemplateSLA, error) {
+	var sla entity.TemplateSLA
+	dest := append([]any{
+		&sla.TemplateID,
+		&sla.MaxRenderMs,
+		&sla.MaxFailureRate,
+		&sla.WindowMinutes,
+		&sla.MinRenders,
+		&sla.BreachedAt,
+		&sla.UpdatedBy,
+		&sla.UpdatedAt,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return &sla, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
 	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	"github.com/rendis/pdf-forge/core/internal/core/port"
 )
@@ -22,7 +23,7 @@ type Repository struct {
 
 // AddTag adds a tag to a template.
 func (r *Repository) AddTag(ctx context.Context, templateID, tagID string) error {
-	_, err := r.pool.Exec(ctx, queryAddTag, templateID, tagID)
+	_, err := common.Conn(ctx, r.pool).Exec(ctx, queryAddTag, templateID, tagID)
 	if err != nil {
 		return fmt.Errorf("adding tag to template: %w", err)
 	}
@@ -32,7 +33,7 @@ func (r *Repository) AddTag(ctx context.Context, templateID, tagID string) error
 
 // RemoveTag removes a tag from a template.
 func (r *Repository) RemoveTag(ctx context.Context, templateID, tagID string) error {
-	_, err := r.pool.Exec(ctx, queryRemoveTag, templateID, tagID)
+	_, err := common.Conn(ctx, r.pool).Exec(ctx, queryRemoveTag, templateID, tagID)
 	if err != nil {
 		return fmt.Errorf("removing tag from template: %w", err)
 	}
@@ -42,7 +43,7 @@ func (r *Repository) RemoveTag(ctx context.Context, templateID, tagID string) er
 
 // FindTagsByTemplate lists all tags for a template.
 func (r *Repository) FindTagsByTemplate(ctx context.Context, templateID string) ([]*entity.Tag, error) {
-	rows, err := r.pool.Query(ctx, queryFindTagsByTemplate, templateID)
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindTagsByTemplate, templateID)
 	if err != nil {
 		return nil, fmt.Errorf("querying template tags: %w", err)
 	}
@@ -73,7 +74,7 @@ func (r *Repository) FindTagsByTemplate(ctx context.Context, templateID string)
 
 // FindTemplatesByTag lists all template IDs with a specific tag.
 func (r *Repository) FindTemplatesByTag(ctx context.Context, tagID string) ([]string, error) {
-	rows, err := r.pool.Query(ctx, queryFindTemplatesByTag, tagID)
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindTemplatesByTag, tagID)
 	if err != nil {
 		return nil, fmt.Errorf("querying templates by tag: %w", err)
 	}
@@ -98,7 +99,7 @@ func (r *Repository) FindTemplatesByTag(ctx context.Context, tagID string) ([]st
 // Exists checks if a tag is already linked to a template.
 func (r *Repository) Exists(ctx context.Context, templateID, tagID string) (bool, error) {
 	var exists bool
-	err := r.pool.QueryRow(ctx, queryExists, templateID, tagID).Scan(&exists)
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryExists, templateID, tagID).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("checking template tag existence: %w", err)
 	}
@@ -108,7 +109,7 @@ func (r *Repository) Exists(ctx context.Context, templateID, tagID string) (bool
 
 // DeleteByTemplate removes all tag associations for a template.
 func (r *Repository) DeleteByTemplate(ctx context.Context, templateID string) error {
-	_, err := r.pool.Exec(ctx, queryDeleteByTemplate, templateID)
+	_, err := common.Conn(ctx, r.pool).Exec(ctx, queryDeleteByTemplate, templateID)
 	if err != nil {
 		return fmt.Errorf("deleting template tags: %w", err)
 	}
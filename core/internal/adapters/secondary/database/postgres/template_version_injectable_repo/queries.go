@@ -17,7 +17,7 @@ const (
 	queryFindByVersionID = `
 		SELECT
 			tvi.id, tvi.template_version_id, tvi.injectable_definition_id, tvi.system_injectable_key, tvi.is_required, tvi.default_value, tvi.created_at,
-			id.id, id.workspace_id, id.key, id.label, id.description, id.data_type, id.metadata, id.format_config, id.created_at, id.updated_at
+			id.id, id.workspace_id, id.key, id.label, id.description, id.data_type, id.metadata, id.format_config, id.formula, id.created_at, id.updated_at
 		FROM content.template_version_injectables tvi
 		LEFT JOIN content.injectable_definitions id ON tvi.injectable_definition_id = id.id
 		WHERE tvi.template_version_id = $1
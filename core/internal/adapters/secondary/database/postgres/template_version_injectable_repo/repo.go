@@ -26,7 +26,7 @@ type Repository struct {
 // Create creates a new template version injectable configuration.
 func (r *Repository) Create(ctx context.Context, injectable *entity.TemplateVersionInjectable) (string, error) {
 	var id string
-	err := r.pool.QueryRow(ctx, queryCreate,
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryCreate,
 		injectable.TemplateVersionID,
 		injectable.InjectableDefinitionID,
 		injectable.SystemInjectableKey,
@@ -44,7 +44,7 @@ func (r *Repository) Create(ctx context.Context, injectable *entity.TemplateVers
 // FindByID finds a template version injectable by ID.
 func (r *Repository) FindByID(ctx context.Context, id string) (*entity.TemplateVersionInjectable, error) {
 	injectable := &entity.TemplateVersionInjectable{}
-	err := r.pool.QueryRow(ctx, queryFindByID, id).Scan(
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindByID, id).Scan(
 		&injectable.ID,
 		&injectable.TemplateVersionID,
 		&injectable.InjectableDefinitionID,
@@ -65,7 +65,7 @@ func (r *Repository) FindByID(ctx context.Context, id string) (*entity.TemplateV
 
 // FindByVersionID lists all injectables for a template version with their definitions.
 func (r *Repository) FindByVersionID(ctx context.Context, versionID string) ([]*entity.VersionInjectableWithDefinition, error) {
-	rows, err := r.pool.Query(ctx, queryFindByVersionID, versionID)
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindByVersionID, versionID)
 	if err != nil {
 		return nil, fmt.Errorf("querying version injectables: %w", err)
 	}
@@ -76,7 +76,7 @@ func (r *Repository) FindByVersionID(ctx context.Context, versionID string) ([]*
 		iwd := &entity.VersionInjectableWithDefinition{}
 
 		// Nullable fields for definition (LEFT JOIN may return NULLs)
-		var defID, defWorkspaceID, defKey, defLabel, defDescription *string
+		var defID, defWorkspaceID, defKey, defLabel, defDescription, defFormula *string
 		var defDataType *entity.InjectableDataType
 		var defMetadata map[string]any
 		var defFormatConfig *entity.FormatConfig
@@ -98,6 +98,7 @@ func (r *Repository) FindByVersionID(ctx context.Context, versionID string) ([]*
 			&defDataType,
 			&defMetadata,
 			&defFormatConfig,
+			&defFormula,
 			&defCreatedAt,
 			&defUpdatedAt,
 		); err != nil {
@@ -115,6 +116,7 @@ func (r *Repository) FindByVersionID(ctx context.Context, versionID string) ([]*
 				DataType:     common.SafeDataType(defDataType),
 				Metadata:     defMetadata,
 				FormatConfig: defFormatConfig,
+				Formula:      defFormula,
 				SourceType:   entity.InjectableSourceTypeInternal,
 			}
 		}
@@ -131,7 +133,7 @@ func (r *Repository) FindByVersionID(ctx context.Context, versionID string) ([]*
 
 // Update updates a template version injectable configuration.
 func (r *Repository) Update(ctx context.Context, injectable *entity.TemplateVersionInjectable) error {
-	result, err := r.pool.Exec(ctx, queryUpdate,
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryUpdate,
 		injectable.ID,
 		injectable.IsRequired,
 		injectable.DefaultValue,
@@ -149,7 +151,7 @@ func (r *Repository) Update(ctx context.Context, injectable *entity.TemplateVers
 
 // Delete deletes a template version injectable configuration.
 func (r *Repository) Delete(ctx context.Context, id string) error {
-	result, err := r.pool.Exec(ctx, queryDelete, id)
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryDelete, id)
 	if err != nil {
 		return fmt.Errorf("deleting version injectable: %w", err)
 	}
@@ -163,7 +165,7 @@ func (r *Repository) Delete(ctx context.Context, id string) error {
 
 // DeleteByVersionID deletes all injectable configurations for a template version.
 func (r *Repository) DeleteByVersionID(ctx context.Context, versionID string) error {
-	_, err := r.pool.Exec(ctx, queryDeleteByVersionID, versionID)
+	_, err := common.Conn(ctx, r.pool).Exec(ctx, queryDeleteByVersionID, versionID)
 	if err != nil {
 		return fmt.Errorf("deleting version injectables: %w", err)
 	}
@@ -174,7 +176,7 @@ func (r *Repository) DeleteByVersionID(ctx context.Context, versionID string) er
 // Exists checks if an injectable definition is already linked to a version.
 func (r *Repository) Exists(ctx context.Context, versionID, injectableDefID string) (bool, error) {
 	var exists bool
-	err := r.pool.QueryRow(ctx, queryExists, versionID, injectableDefID).Scan(&exists)
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryExists, versionID, injectableDefID).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("checking version injectable existence: %w", err)
 	}
@@ -185,7 +187,7 @@ func (r *Repository) Exists(ctx context.Context, versionID, injectableDefID stri
 // ExistsSystemKey checks if a system injectable key is already linked to a version.
 func (r *Repository) ExistsSystemKey(ctx context.Context, versionID, systemKey string) (bool, error) {
 	var exists bool
-	err := r.pool.QueryRow(ctx, queryExistsSystemKey, versionID, systemKey).Scan(&exists)
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryExistsSystemKey, versionID, systemKey).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("checking system injectable existence: %w", err)
 	}
@@ -195,7 +197,7 @@ func (r *Repository) ExistsSystemKey(ctx context.Context, versionID, systemKey s
 
 // CopyFromVersion copies all injectable configurations from one version to another.
 func (r *Repository) CopyFromVersion(ctx context.Context, sourceVersionID, targetVersionID string) error {
-	_, err := r.pool.Exec(ctx, queryCopyFromVersion, sourceVersionID, targetVersionID)
+	_, err := common.Conn(ctx, r.pool).Exec(ctx, queryCopyFromVersion, sourceVersionID, targetVersionID)
 	if err != nil {
 		return fmt.Errorf("copying version injectables: %w", err)
 	}
@@ -8,20 +8,39 @@ const (
 			status, scheduled_publish_at, scheduled_archive_at, created_by, created_at
 		)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-		RETURNING id`
+		RETURNING id, revision`
 
 	queryFindByID = `
 		SELECT id, template_id, version_number, name, description, content_structure,
 			status, scheduled_publish_at, scheduled_archive_at, published_at, archived_at,
-			published_by, archived_by, created_by, created_at, updated_at
+			published_by, archived_by, created_by, created_at, updated_at, revision,
+			schedule_failed_at, schedule_failure_reason
 		FROM content.template_versions
 		WHERE id = $1`
 
+	// Metadata queries skip content_structure, which can be hundreds of KB per version.
+	queryFindMetadataByID = `
+		SELECT id, template_id, version_number, name, description,
+			status, scheduled_publish_at, scheduled_archive_at, published_at, archived_at,
+			published_by, archived_by, created_by, created_at, updated_at, revision,
+			schedule_failed_at, schedule_failure_reason
+		FROM content.template_versions
+		WHERE id = $1`
+
+	queryFindMetadataByTemplateID = `
+		SELECT id, template_id, version_number, name, description,
+			status, scheduled_publish_at, scheduled_archive_at, published_at, archived_at,
+			published_by, archived_by, created_by, created_at, updated_at, revision,
+			schedule_failed_at, schedule_failure_reason
+		FROM content.template_versions
+		WHERE template_id = $1
+		ORDER BY version_number DESC`
+
 	queryInjectablesWithDefinitions = `
 		SELECT
 			tvi.id, tvi.template_version_id, tvi.injectable_definition_id, tvi.system_injectable_key,
 			tvi.is_required, tvi.default_value, tvi.created_at,
-			id.id, id.workspace_id, id.key, id.label, id.description, id.data_type, id.created_at, id.updated_at
+			id.id, id.workspace_id, id.key, id.label, id.description, id.data_type, id.formula, id.created_at, id.updated_at
 		FROM content.template_version_injectables tvi
 		LEFT JOIN content.injectable_definitions id ON tvi.injectable_definition_id = id.id
 		WHERE tvi.template_version_id = $1
@@ -30,7 +49,8 @@ const (
 	queryFindByTemplateID = `
 		SELECT id, template_id, version_number, name, description, content_structure,
 			status, scheduled_publish_at, scheduled_archive_at, published_at, archived_at,
-			published_by, archived_by, created_by, created_at, updated_at
+			published_by, archived_by, created_by, created_at, updated_at, revision,
+			schedule_failed_at, schedule_failure_reason
 		FROM content.template_versions
 		WHERE template_id = $1
 		ORDER BY version_number DESC`
@@ -38,31 +58,36 @@ const (
 	queryFindPublishedByTemplateID = `
 		SELECT id, template_id, version_number, name, description, content_structure,
 			status, scheduled_publish_at, scheduled_archive_at, published_at, archived_at,
-			published_by, archived_by, created_by, created_at, updated_at
+			published_by, archived_by, created_by, created_at, updated_at, revision,
+			schedule_failed_at, schedule_failure_reason
 		FROM content.template_versions
 		WHERE template_id = $1 AND status = 'PUBLISHED'`
 
 	queryFindStagingByTemplateID = `
 		SELECT id, template_id, version_number, name, description, content_structure,
 			status, scheduled_publish_at, scheduled_archive_at, published_at, archived_at,
-			published_by, archived_by, created_by, created_at, updated_at
+			published_by, archived_by, created_by, created_at, updated_at, revision,
+			schedule_failed_at, schedule_failure_reason
 		FROM content.template_versions
 		WHERE template_id = $1 AND status = 'STAGING'`
 
 	queryFindScheduledToPublish = `
-		SELECT id, template_id, version_number, name, description, content_structure,
+		SELECT id, template_id, version_number, name, description,
 			status, scheduled_publish_at, scheduled_archive_at, published_at, archived_at,
-			published_by, archived_by, created_by, created_at, updated_at
+			published_by, archived_by, created_by, created_at, updated_at, revision,
+			schedule_failed_at, schedule_failure_reason
 		FROM content.template_versions
-		WHERE status = 'SCHEDULED' AND scheduled_publish_at <= $1
+		WHERE status = 'SCHEDULED' AND scheduled_publish_at <= $1 AND schedule_failed_at IS NULL
 		ORDER BY scheduled_publish_at`
 
 	queryFindScheduledToArchive = `
-		SELECT id, template_id, version_number, name, description, content_structure,
+		SELECT id, template_id, version_number, name, description,
 			status, scheduled_publish_at, scheduled_archive_at, published_at, archived_at,
-			published_by, archived_by, created_by, created_at, updated_at
+			published_by, archived_by, created_by, created_at, updated_at, revision,
+			schedule_failed_at, schedule_failure_reason
 		FROM content.template_versions
 		WHERE status = 'PUBLISHED' AND scheduled_archive_at IS NOT NULL AND scheduled_archive_at <= $1
+			AND schedule_failed_at IS NULL
 		ORDER BY scheduled_archive_at`
 
 	queryUpdate = `
@@ -70,20 +95,33 @@ const (
 		SET name = $2, description = $3, content_structure = $4, status = $5,
 			scheduled_publish_at = $6, scheduled_archive_at = $7,
 			published_at = $8, archived_at = $9, published_by = $10, archived_by = $11,
-			updated_at = $12
+			schedule_failed_at = $12, schedule_failure_reason = $13,
+			updated_at = $14, revision = revision + 1
+		WHERE id = $1 AND revision = $15
+		RETURNING revision`
+
+	queryMarkScheduleFailed = `
+		UPDATE content.template_versions
+		SET schedule_failed_at = NOW(), schedule_failure_reason = $2, updated_at = NOW(), revision = revision + 1
 		WHERE id = $1`
 
+	queryUpdateChangelog = `UPDATE content.template_versions SET changelog = $2 WHERE id = $1`
+
+	queryFindChangelog = `SELECT changelog FROM content.template_versions WHERE id = $1`
+
+	queryExists = `SELECT EXISTS(SELECT 1 FROM content.template_versions WHERE id = $1)`
+
 	queryUpdateStatusPublished = `
 		UPDATE content.template_versions
-		SET status = $2, published_at = NOW(), published_by = $3, updated_at = NOW()
+		SET status = $2, published_at = NOW(), published_by = $3, updated_at = NOW(), revision = revision + 1
 		WHERE id = $1`
 
 	queryUpdateStatusArchived = `
 		UPDATE content.template_versions
-		SET status = $2, archived_at = NOW(), archived_by = $3, updated_at = NOW()
+		SET status = $2, archived_at = NOW(), archived_by = $3, updated_at = NOW(), revision = revision + 1
 		WHERE id = $1`
 
-	queryUpdateStatusDefault = `UPDATE content.template_versions SET status = $2, updated_at = NOW() WHERE id = $1`
+	queryUpdateStatusDefault = `UPDATE content.template_versions SET status = $2, updated_at = NOW(), revision = revision + 1 WHERE id = $1`
 
 	queryDelete = `DELETE FROM content.template_versions WHERE id = $1`
 
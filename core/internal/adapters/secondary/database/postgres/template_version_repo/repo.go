@@ -2,6 +2,7 @@ package templateversionrepo
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -27,7 +28,7 @@ type Repository struct {
 // Create creates a new template version.
 func (r *Repository) Create(ctx context.Context, version *entity.TemplateVersion) (string, error) {
 	var id string
-	err := r.pool.QueryRow(ctx, queryCreate,
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryCreate,
 		version.TemplateID,
 		version.VersionNumber,
 		version.Name,
@@ -38,7 +39,7 @@ func (r *Repository) Create(ctx context.Context, version *entity.TemplateVersion
 		version.ScheduledArchiveAt,
 		version.CreatedBy,
 		version.CreatedAt,
-	).Scan(&id)
+	).Scan(&id, &version.Revision)
 	if err != nil {
 		return "", fmt.Errorf("creating template version: %w", err)
 	}
@@ -49,7 +50,7 @@ func (r *Repository) Create(ctx context.Context, version *entity.TemplateVersion
 // FindByID finds a template version by ID.
 func (r *Repository) FindByID(ctx context.Context, id string) (*entity.TemplateVersion, error) {
 	version := &entity.TemplateVersion{}
-	err := r.pool.QueryRow(ctx, queryFindByID, id).Scan(
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindByID, id).Scan(
 		&version.ID,
 		&version.TemplateID,
 		&version.VersionNumber,
@@ -66,6 +67,9 @@ func (r *Repository) FindByID(ctx context.Context, id string) (*entity.TemplateV
 		&version.CreatedBy,
 		&version.CreatedAt,
 		&version.UpdatedAt,
+		&version.Revision,
+		&version.ScheduleFailedAt,
+		&version.ScheduleFailureReason,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -93,13 +97,18 @@ func (r *Repository) FindByIDWithDetails(ctx context.Context, id string) (*entit
 		return nil, err
 	}
 
+	details.Changelog, err = r.FindChangelog(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
 	return details, nil
 }
 
 // loadInjectablesWithDefinitions loads version injectables with their definitions.
 // Handles both workspace injectables (with definition) and system injectables (with system_injectable_key).
 func (r *Repository) loadInjectablesWithDefinitions(ctx context.Context, versionID string) ([]*entity.VersionInjectableWithDefinition, error) {
-	rows, err := r.pool.Query(ctx, queryInjectablesWithDefinitions, versionID)
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryInjectablesWithDefinitions, versionID)
 	if err != nil {
 		return nil, fmt.Errorf("querying version injectables: %w", err)
 	}
@@ -128,13 +137,13 @@ type injectableRow interface {
 // scanVersionInjectable scans a single row into a VersionInjectableWithDefinition.
 func scanVersionInjectable(row injectableRow) (*entity.VersionInjectableWithDefinition, error) {
 	iwd := &entity.VersionInjectableWithDefinition{}
-	var defID, defWorkspaceID, defKey, defLabel, defDescription, defDataType *string
+	var defID, defWorkspaceID, defKey, defLabel, defDescription, defDataType, defFormula *string
 	var defCreatedAt, defUpdatedAt *time.Time
 
 	if err := row.Scan(
 		&iwd.ID, &iwd.TemplateVersionID, &iwd.InjectableDefinitionID, &iwd.SystemInjectableKey,
 		&iwd.IsRequired, &iwd.DefaultValue, &iwd.CreatedAt,
-		&defID, &defWorkspaceID, &defKey, &defLabel, &defDescription, &defDataType, &defCreatedAt, &defUpdatedAt,
+		&defID, &defWorkspaceID, &defKey, &defLabel, &defDescription, &defDataType, &defFormula, &defCreatedAt, &defUpdatedAt,
 	); err != nil {
 		return nil, fmt.Errorf("scanning version injectable: %w", err)
 	}
@@ -147,6 +156,7 @@ func scanVersionInjectable(row injectableRow) (*entity.VersionInjectableWithDefi
 			Label:       common.SafeString(defLabel),
 			Description: common.SafeString(defDescription),
 			DataType:    entity.InjectableDataType(common.SafeString(defDataType)),
+			Formula:     defFormula,
 			CreatedAt:   common.SafeTime(defCreatedAt),
 			UpdatedAt:   defUpdatedAt,
 		}
@@ -156,7 +166,7 @@ func scanVersionInjectable(row injectableRow) (*entity.VersionInjectableWithDefi
 
 // FindByTemplateID lists all versions for a template.
 func (r *Repository) FindByTemplateID(ctx context.Context, templateID string) ([]*entity.TemplateVersion, error) {
-	rows, err := r.pool.Query(ctx, queryFindByTemplateID, templateID)
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindByTemplateID, templateID)
 	if err != nil {
 		return nil, fmt.Errorf("querying template versions: %w", err)
 	}
@@ -182,6 +192,9 @@ func (r *Repository) FindByTemplateID(ctx context.Context, templateID string) ([
 			&v.CreatedBy,
 			&v.CreatedAt,
 			&v.UpdatedAt,
+			&v.Revision,
+			&v.ScheduleFailedAt,
+			&v.ScheduleFailureReason,
 		); err != nil {
 			return nil, fmt.Errorf("scanning template version: %w", err)
 		}
@@ -195,6 +208,71 @@ func (r *Repository) FindByTemplateID(ctx context.Context, templateID string) ([
 	return versions, nil
 }
 
+// FindMetadataByID finds a template version by ID without loading its content structure.
+func (r *Repository) FindMetadataByID(ctx context.Context, id string) (*entity.TemplateVersion, error) {
+	version, err := scanVersionMetadata(common.Conn(ctx, r.pool).QueryRow(ctx, queryFindMetadataByID, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrVersionNotFound
+		}
+		return nil, fmt.Errorf("finding template version metadata %s: %w", id, err)
+	}
+
+	return version, nil
+}
+
+// FindMetadataByTemplateID lists all versions for a template without loading their content structure.
+func (r *Repository) FindMetadataByTemplateID(ctx context.Context, templateID string) ([]*entity.TemplateVersion, error) {
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindMetadataByTemplateID, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("querying template version metadata: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []*entity.TemplateVersion
+	for rows.Next() {
+		v, err := scanVersionMetadata(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning template version metadata: %w", err)
+		}
+		versions = append(versions, v)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating template version metadata: %w", err)
+	}
+
+	return versions, nil
+}
+
+// scanVersionMetadata scans a row selected by a metadata query (all columns except content_structure).
+func scanVersionMetadata(row pgx.Row) (*entity.TemplateVersion, error) {
+	v := &entity.TemplateVersion{}
+	if err := row.Scan(
+		&v.ID,
+		&v.TemplateID,
+		&v.VersionNumber,
+		&v.Name,
+		&v.Description,
+		&v.Status,
+		&v.ScheduledPublishAt,
+		&v.ScheduledArchiveAt,
+		&v.PublishedAt,
+		&v.ArchivedAt,
+		&v.PublishedBy,
+		&v.ArchivedBy,
+		&v.CreatedBy,
+		&v.CreatedAt,
+		&v.UpdatedAt,
+		&v.Revision,
+		&v.ScheduleFailedAt,
+		&v.ScheduleFailureReason,
+	); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
 // FindByTemplateIDWithDetails lists all versions for a template with full details.
 func (r *Repository) FindByTemplateIDWithDetails(ctx context.Context, templateID string) ([]*entity.TemplateVersionWithDetails, error) {
 	versions, err := r.FindByTemplateID(ctx, templateID)
@@ -217,7 +295,7 @@ func (r *Repository) FindByTemplateIDWithDetails(ctx context.Context, templateID
 // FindPublishedByTemplateID finds the currently published version for a template.
 func (r *Repository) FindPublishedByTemplateID(ctx context.Context, templateID string) (*entity.TemplateVersion, error) {
 	version := &entity.TemplateVersion{}
-	err := r.pool.QueryRow(ctx, queryFindPublishedByTemplateID, templateID).Scan(
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindPublishedByTemplateID, templateID).Scan(
 		&version.ID,
 		&version.TemplateID,
 		&version.VersionNumber,
@@ -234,6 +312,9 @@ func (r *Repository) FindPublishedByTemplateID(ctx context.Context, templateID s
 		&version.CreatedBy,
 		&version.CreatedAt,
 		&version.UpdatedAt,
+		&version.Revision,
+		&version.ScheduleFailedAt,
+		&version.ScheduleFailureReason,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -258,7 +339,7 @@ func (r *Repository) FindPublishedByTemplateIDWithDetails(ctx context.Context, t
 // FindStagingByTemplateID finds the staging version for a template.
 func (r *Repository) FindStagingByTemplateID(ctx context.Context, templateID string) (*entity.TemplateVersion, error) {
 	version := &entity.TemplateVersion{}
-	err := r.pool.QueryRow(ctx, queryFindStagingByTemplateID, templateID).Scan(
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindStagingByTemplateID, templateID).Scan(
 		&version.ID,
 		&version.TemplateID,
 		&version.VersionNumber,
@@ -275,6 +356,9 @@ func (r *Repository) FindStagingByTemplateID(ctx context.Context, templateID str
 		&version.CreatedBy,
 		&version.CreatedAt,
 		&version.UpdatedAt,
+		&version.Revision,
+		&version.ScheduleFailedAt,
+		&version.ScheduleFailureReason,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -298,7 +382,7 @@ func (r *Repository) FindStagingByTemplateIDWithDetails(ctx context.Context, tem
 
 // FindScheduledToPublish finds all versions scheduled to publish before the given time.
 func (r *Repository) FindScheduledToPublish(ctx context.Context, before time.Time) ([]*entity.TemplateVersion, error) {
-	rows, err := r.pool.Query(ctx, queryFindScheduledToPublish, before)
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindScheduledToPublish, before)
 	if err != nil {
 		return nil, fmt.Errorf("querying scheduled versions to publish: %w", err)
 	}
@@ -313,7 +397,6 @@ func (r *Repository) FindScheduledToPublish(ctx context.Context, before time.Tim
 			&v.VersionNumber,
 			&v.Name,
 			&v.Description,
-			&v.ContentStructure,
 			&v.Status,
 			&v.ScheduledPublishAt,
 			&v.ScheduledArchiveAt,
@@ -324,6 +407,9 @@ func (r *Repository) FindScheduledToPublish(ctx context.Context, before time.Tim
 			&v.CreatedBy,
 			&v.CreatedAt,
 			&v.UpdatedAt,
+			&v.Revision,
+			&v.ScheduleFailedAt,
+			&v.ScheduleFailureReason,
 		); err != nil {
 			return nil, fmt.Errorf("scanning scheduled version: %w", err)
 		}
@@ -339,7 +425,7 @@ func (r *Repository) FindScheduledToPublish(ctx context.Context, before time.Tim
 
 // FindScheduledToArchive finds all published versions scheduled to archive before the given time.
 func (r *Repository) FindScheduledToArchive(ctx context.Context, before time.Time) ([]*entity.TemplateVersion, error) {
-	rows, err := r.pool.Query(ctx, queryFindScheduledToArchive, before)
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindScheduledToArchive, before)
 	if err != nil {
 		return nil, fmt.Errorf("querying scheduled versions to archive: %w", err)
 	}
@@ -354,7 +440,6 @@ func (r *Repository) FindScheduledToArchive(ctx context.Context, before time.Tim
 			&v.VersionNumber,
 			&v.Name,
 			&v.Description,
-			&v.ContentStructure,
 			&v.Status,
 			&v.ScheduledPublishAt,
 			&v.ScheduledArchiveAt,
@@ -365,6 +450,9 @@ func (r *Repository) FindScheduledToArchive(ctx context.Context, before time.Tim
 			&v.CreatedBy,
 			&v.CreatedAt,
 			&v.UpdatedAt,
+			&v.Revision,
+			&v.ScheduleFailedAt,
+			&v.ScheduleFailureReason,
 		); err != nil {
 			return nil, fmt.Errorf("scanning scheduled archive version: %w", err)
 		}
@@ -378,9 +466,11 @@ func (r *Repository) FindScheduledToArchive(ctx context.Context, before time.Tim
 	return versions, nil
 }
 
-// Update updates a template version.
+// Update updates a template version if its revision is still version.Revision,
+// then sets version.Revision to the new revision.
 func (r *Repository) Update(ctx context.Context, version *entity.TemplateVersion) error {
-	result, err := r.pool.Exec(ctx, queryUpdate,
+	conn := common.Conn(ctx, r.pool)
+	err := conn.QueryRow(ctx, queryUpdate,
 		version.ID,
 		version.Name,
 		version.Description,
@@ -392,12 +482,28 @@ func (r *Repository) Update(ctx context.Context, version *entity.TemplateVersion
 		version.ArchivedAt,
 		version.PublishedBy,
 		version.ArchivedBy,
+		version.ScheduleFailedAt,
+		version.ScheduleFailureReason,
 		version.UpdatedAt,
-	)
+		version.Revision,
+	).Scan(&version.Revision)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return common.StaleOrMissing(ctx, conn, entity.ErrVersionNotFound, queryExists, version.ID)
+		}
 		return fmt.Errorf("updating template version: %w", err)
 	}
 
+	return nil
+}
+
+// MarkScheduleFailed moves a version to the failed-scheduled state with the given reason.
+func (r *Repository) MarkScheduleFailed(ctx context.Context, id string, reason string) error {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryMarkScheduleFailed, id, reason)
+	if err != nil {
+		return fmt.Errorf("marking schedule failed: %w", err)
+	}
+
 	if result.RowsAffected() == 0 {
 		return entity.ErrVersionNotFound
 	}
@@ -405,6 +511,46 @@ func (r *Repository) Update(ctx context.Context, version *entity.TemplateVersion
 	return nil
 }
 
+// UpdateChangelog stores the changelog computed when a version is published.
+func (r *Repository) UpdateChangelog(ctx context.Context, id string, changelog *entity.VersionChangelog) error {
+	data, err := json.Marshal(changelog)
+	if err != nil {
+		return fmt.Errorf("encoding version changelog: %w", err)
+	}
+
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryUpdateChangelog, id, data)
+	if err != nil {
+		return fmt.Errorf("updating version changelog: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrVersionNotFound
+	}
+
+	return nil
+}
+
+// FindChangelog returns the changelog of a version, or nil when it was never published.
+func (r *Repository) FindChangelog(ctx context.Context, id string) (*entity.VersionChangelog, error) {
+	var data []byte
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindChangelog, id).Scan(&data)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrVersionNotFound
+		}
+		return nil, fmt.Errorf("finding version changelog %s: %w", id, err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	var changelog entity.VersionChangelog
+	if err := json.Unmarshal(data, &changelog); err != nil {
+		return nil, fmt.Errorf("decoding version changelog: %w", err)
+	}
+	return &changelog, nil
+}
+
 // UpdateStatus updates a version's status with optional user tracking.
 func (r *Repository) UpdateStatus(ctx context.Context, id string, status entity.VersionStatus, userID *string) error {
 	var query string
@@ -422,7 +568,7 @@ func (r *Repository) UpdateStatus(ctx context.Context, id string, status entity.
 		args = []any{id, status}
 	}
 
-	result, err := r.pool.Exec(ctx, query, args...)
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("updating version status: %w", err)
 	}
@@ -436,7 +582,7 @@ func (r *Repository) UpdateStatus(ctx context.Context, id string, status entity.
 
 // Delete deletes a template version.
 func (r *Repository) Delete(ctx context.Context, id string) error {
-	result, err := r.pool.Exec(ctx, queryDelete, id)
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryDelete, id)
 	if err != nil {
 		return fmt.Errorf("deleting template version: %w", err)
 	}
@@ -451,7 +597,7 @@ func (r *Repository) Delete(ctx context.Context, id string) error {
 // ExistsByVersionNumber checks if a version number already exists for the template.
 func (r *Repository) ExistsByVersionNumber(ctx context.Context, templateID string, versionNumber int) (bool, error) {
 	var exists bool
-	err := r.pool.QueryRow(ctx, queryExistsByVersionNumber, templateID, versionNumber).Scan(&exists)
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryExistsByVersionNumber, templateID, versionNumber).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("checking version number existence: %w", err)
 	}
@@ -462,7 +608,7 @@ func (r *Repository) ExistsByVersionNumber(ctx context.Context, templateID strin
 // ExistsByName checks if a version name already exists for the template.
 func (r *Repository) ExistsByName(ctx context.Context, templateID, name string) (bool, error) {
 	var exists bool
-	err := r.pool.QueryRow(ctx, queryExistsByName, templateID, name).Scan(&exists)
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryExistsByName, templateID, name).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("checking version name existence: %w", err)
 	}
@@ -473,7 +619,7 @@ func (r *Repository) ExistsByName(ctx context.Context, templateID, name string)
 // ExistsByNameExcluding checks if a version name exists excluding a specific version ID.
 func (r *Repository) ExistsByNameExcluding(ctx context.Context, templateID, name, excludeID string) (bool, error) {
 	var exists bool
-	err := r.pool.QueryRow(ctx, queryExistsByNameExcluding, templateID, name, excludeID).Scan(&exists)
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryExistsByNameExcluding, templateID, name, excludeID).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("checking version name existence: %w", err)
 	}
@@ -484,7 +630,7 @@ func (r *Repository) ExistsByNameExcluding(ctx context.Context, templateID, name
 // GetNextVersionNumber returns the next available version number for a template.
 func (r *Repository) GetNextVersionNumber(ctx context.Context, templateID string) (int, error) {
 	var nextNum int
-	err := r.pool.QueryRow(ctx, queryGetNextVersionNumber, templateID).Scan(&nextNum)
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryGetNextVersionNumber, templateID).Scan(&nextNum)
 	if err != nil {
 		return 0, fmt.Errorf("getting next version number: %w", err)
 	}
@@ -495,7 +641,7 @@ func (r *Repository) GetNextVersionNumber(ctx context.Context, templateID string
 // HasScheduledVersion checks if the template has a version with SCHEDULED status.
 func (r *Repository) HasScheduledVersion(ctx context.Context, templateID string) (bool, error) {
 	var exists bool
-	err := r.pool.QueryRow(ctx, queryHasScheduledVersion, templateID).Scan(&exists)
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryHasScheduledVersion, templateID).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("checking for scheduled version: %w", err)
 	}
@@ -506,7 +652,7 @@ func (r *Repository) HasScheduledVersion(ctx context.Context, templateID string)
 // ExistsScheduledAtTime checks if another version is scheduled at the exact time for the template.
 func (r *Repository) ExistsScheduledAtTime(ctx context.Context, templateID string, scheduledAt time.Time, excludeVersionID *string) (bool, error) {
 	var exists bool
-	err := r.pool.QueryRow(ctx, queryExistsScheduledAtTime, templateID, scheduledAt, excludeVersionID).Scan(&exists)
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryExistsScheduledAtTime, templateID, scheduledAt, excludeVersionID).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("checking scheduled time conflict: %w", err)
 	}
@@ -517,7 +663,7 @@ func (r *Repository) ExistsScheduledAtTime(ctx context.Context, templateID strin
 // CountByTemplateID returns the number of versions for a template.
 func (r *Repository) CountByTemplateID(ctx context.Context, templateID string) (int, error) {
 	var count int
-	err := r.pool.QueryRow(ctx, queryCountByTemplateID, templateID).Scan(&count)
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryCountByTemplateID, templateID).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("counting template versions: %w", err)
 	}
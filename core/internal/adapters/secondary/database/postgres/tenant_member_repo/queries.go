@@ -52,6 +52,12 @@ const (
 		FROM identity.tenant_members
 		WHERE tenant_id = $1 AND role = $2 AND membership_status = 'ACTIVE'`
 
+	queryLockOwners = `
+		SELECT id
+		FROM identity.tenant_members
+		WHERE tenant_id = $1 AND role = 'TENANT_OWNER'
+		FOR UPDATE`
+
 	// queryFindTenantsWithRoleByUserAndIDs uses unnest to preserve the order of input IDs.
 	// The ord column from unnest maintains the original order of the provided tenant IDs.
 	queryFindTenantsWithRoleByUserAndIDs = `
@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
 	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	"github.com/rendis/pdf-forge/core/internal/core/port"
 )
@@ -28,7 +29,7 @@ type Repository struct {
 // Create creates a new tenant membership.
 func (r *Repository) Create(ctx context.Context, member *entity.TenantMember) (string, error) {
 	var id string
-	err := r.pool.QueryRow(ctx, queryCreate,
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryCreate,
 		member.ID,
 		member.TenantID,
 		member.UserID,
@@ -47,7 +48,7 @@ func (r *Repository) Create(ctx context.Context, member *entity.TenantMember) (s
 // FindByID finds a tenant membership by ID.
 func (r *Repository) FindByID(ctx context.Context, id string) (*entity.TenantMember, error) {
 	var member entity.TenantMember
-	err := r.pool.QueryRow(ctx, queryFindByID, id).Scan(
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindByID, id).Scan(
 		&member.ID,
 		&member.TenantID,
 		&member.UserID,
@@ -69,7 +70,7 @@ func (r *Repository) FindByID(ctx context.Context, id string) (*entity.TenantMem
 // FindByUserAndTenant finds a membership for a specific user and tenant.
 func (r *Repository) FindByUserAndTenant(ctx context.Context, userID, tenantID string) (*entity.TenantMember, error) {
 	var member entity.TenantMember
-	err := r.pool.QueryRow(ctx, queryFindByUserAndTenant, userID, tenantID).Scan(
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindByUserAndTenant, userID, tenantID).Scan(
 		&member.ID,
 		&member.TenantID,
 		&member.UserID,
@@ -90,7 +91,7 @@ func (r *Repository) FindByUserAndTenant(ctx context.Context, userID, tenantID s
 
 // FindByTenant lists all members of a tenant.
 func (r *Repository) FindByTenant(ctx context.Context, tenantID string) ([]*entity.TenantMemberWithUser, error) {
-	rows, err := r.pool.Query(ctx, queryFindByTenant, tenantID)
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindByTenant, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("querying tenant members: %w", err)
 	}
@@ -129,7 +130,7 @@ func (r *Repository) FindByTenant(ctx context.Context, tenantID string) ([]*enti
 
 // FindByUser lists all tenant memberships for a user.
 func (r *Repository) FindByUser(ctx context.Context, userID string) ([]*entity.TenantMember, error) {
-	rows, err := r.pool.Query(ctx, queryFindByUser, userID)
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindByUser, userID)
 	if err != nil {
 		return nil, fmt.Errorf("querying user tenant memberships: %w", err)
 	}
@@ -158,7 +159,7 @@ func (r *Repository) FindByUser(ctx context.Context, userID string) ([]*entity.T
 
 // FindTenantsWithRoleByUser lists all tenants a user belongs to with their roles.
 func (r *Repository) FindTenantsWithRoleByUser(ctx context.Context, userID string) ([]*entity.TenantWithRole, error) {
-	rows, err := r.pool.Query(ctx, queryFindTenantsWithRoleByUser, userID)
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindTenantsWithRoleByUser, userID)
 	if err != nil {
 		return nil, fmt.Errorf("querying user tenants: %w", err)
 	}
@@ -192,7 +193,7 @@ func (r *Repository) FindTenantsWithRoleByUser(ctx context.Context, userID strin
 // FindActiveByUserAndTenant finds an active membership.
 func (r *Repository) FindActiveByUserAndTenant(ctx context.Context, userID, tenantID string) (*entity.TenantMember, error) {
 	var member entity.TenantMember
-	err := r.pool.QueryRow(ctx, queryFindActiveByUserAndTenant, userID, tenantID).Scan(
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindActiveByUserAndTenant, userID, tenantID).Scan(
 		&member.ID,
 		&member.TenantID,
 		&member.UserID,
@@ -213,7 +214,7 @@ func (r *Repository) FindActiveByUserAndTenant(ctx context.Context, userID, tena
 
 // Delete removes a tenant membership.
 func (r *Repository) Delete(ctx context.Context, id string) error {
-	result, err := r.pool.Exec(ctx, queryDelete, id)
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryDelete, id)
 	if err != nil {
 		return fmt.Errorf("deleting tenant member: %w", err)
 	}
@@ -227,7 +228,7 @@ func (r *Repository) Delete(ctx context.Context, id string) error {
 
 // UpdateRole updates a member's tenant role.
 func (r *Repository) UpdateRole(ctx context.Context, id string, role entity.TenantRole) error {
-	result, err := r.pool.Exec(ctx, queryUpdateRole, id, role)
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryUpdateRole, id, role)
 	if err != nil {
 		return fmt.Errorf("updating tenant member role: %w", err)
 	}
@@ -242,7 +243,7 @@ func (r *Repository) UpdateRole(ctx context.Context, id string, role entity.Tena
 // CountByRole counts members with a specific role in a tenant.
 func (r *Repository) CountByRole(ctx context.Context, tenantID string, role entity.TenantRole) (int, error) {
 	var count int
-	err := r.pool.QueryRow(ctx, queryCountByRole, tenantID, role).Scan(&count)
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryCountByRole, tenantID, role).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("counting tenant members by role: %w", err)
 	}
@@ -250,6 +251,19 @@ func (r *Repository) CountByRole(ctx context.Context, tenantID string, role enti
 	return count, nil
 }
 
+// LockOwners locks the owner rows of a tenant for the current transaction.
+func (r *Repository) LockOwners(ctx context.Context, tenantID string) error {
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryLockOwners, tenantID)
+	if err != nil {
+		return fmt.Errorf("locking tenant owners: %w", err)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("locking tenant owners: %w", err)
+	}
+	return nil
+}
+
 // FindTenantsWithRoleByUserAndIDs lists tenants by specific IDs that the user belongs to.
 // Returns tenants in the same order as the provided IDs.
 func (r *Repository) FindTenantsWithRoleByUserAndIDs(ctx context.Context, userID string, tenantIDs []string) ([]*entity.TenantWithRole, error) {
@@ -257,7 +271,7 @@ func (r *Repository) FindTenantsWithRoleByUserAndIDs(ctx context.Context, userID
 		return []*entity.TenantWithRole{}, nil
 	}
 
-	rows, err := r.pool.Query(ctx, queryFindTenantsWithRoleByUserAndIDs, userID, tenantIDs)
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindTenantsWithRoleByUserAndIDs, userID, tenantIDs)
 	if err != nil {
 		return nil, fmt.Errorf("querying tenants by IDs: %w", err)
 	}
@@ -293,13 +307,13 @@ func (r *Repository) FindTenantsWithRoleByUserAndIDs(ctx context.Context, userID
 func (r *Repository) FindTenantsWithRoleByUserPaginated(ctx context.Context, userID string, filters port.TenantMemberFilters) ([]*entity.TenantWithRole, int64, error) {
 	// Get total count with search filter
 	var total int64
-	err := r.pool.QueryRow(ctx, queryCountTenantsWithRoleByUser, userID, filters.Query).Scan(&total)
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryCountTenantsWithRoleByUser, userID, filters.Query).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("counting user tenants: %w", err)
 	}
 
 	// Get paginated results with unified ordering
-	rows, err := r.pool.Query(ctx, queryFindTenantsWithRoleByUserPaginated, userID, filters.Query, filters.Limit, filters.Offset)
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindTenantsWithRoleByUserPaginated, userID, filters.Query, filters.Limit, filters.Offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("querying user tenants paginated: %w", err)
 	}
@@ -0,0 +1,32 @@
+package userpreferencesrepo
+
+// SQL queries for user preferences operations.
+const (
+	queryFindByUserID = `
+		SELECT user_id, locale, timezone, default_workspace_id, created_at, updated_at
+		FROM identity.user_preferences
+		WHERE user_id = $1`
+
+	// queryUpsert writes only if the default workspace is empty or accessible to the user
+	// through a workspace membership, a tenant membership or a system role.
+	queryUpsert = `
+		INSERT INTO identity.user_preferences (user_id, locale, timezone, default_workspace_id, created_at)
+		SELECT $1, $2, $3, $4, $5
+		WHERE $4::uuid IS NULL OR EXISTS (
+			SELECT 1 FROM identity.workspace_members
+			WHERE user_id = $1 AND workspace_id = $4 AND membership_status = 'ACTIVE'
+		) OR EXISTS (
+			SELECT 1 FROM identity.tenant_members tm
+			INNER JOIN tenancy.workspaces w ON w.tenant_id = tm.tenant_id
+			WHERE tm.user_id = $1 AND w.id = $4 AND tm.membership_status = 'ACTIVE'
+		) OR EXISTS (
+			SELECT 1 FROM identity.system_roles WHERE user_id = $1
+		)
+		ON CONFLICT (user_id)
+		DO UPDATE SET
+			locale = EXCLUDED.locale,
+			timezone = EXCLUDED.timezone,
+			default_workspace_id = EXCLUDED.default_workspace_id,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING created_at, updated_at`
+)
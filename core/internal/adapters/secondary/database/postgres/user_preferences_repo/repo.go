@@ -0,0 +1,61 @@
+package userpreferencesrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new user preferences repository.
+func New(pool *pgxpool.Pool) port.UserPreferencesRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the user preferences repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// FindByUserID finds the saved preferences of a user.
+func (r *Repository) FindByUserID(ctx context.Context, userID string) (*entity.UserPreferences, error) {
+	var prefs entity.UserPreferences
+	err := r.pool.QueryRow(ctx, queryFindByUserID, userID).Scan(
+		&prefs.UserID,
+		&prefs.Locale,
+		&prefs.Timezone,
+		&prefs.DefaultWorkspaceID,
+		&prefs.CreatedAt,
+		&prefs.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrUserPreferencesNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying user preferences: %w", err)
+	}
+	return &prefs, nil
+}
+
+// Upsert creates or replaces a user's preferences.
+func (r *Repository) Upsert(ctx context.Context, prefs *entity.UserPreferences) error {
+	err := r.pool.QueryRow(ctx, queryUpsert,
+		prefs.UserID,
+		prefs.Locale,
+		prefs.Timezone,
+		prefs.DefaultWorkspaceID,
+		prefs.CreatedAt,
+	).Scan(&prefs.CreatedAt, &prefs.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.ErrWorkspaceAccessDenied
+	}
+	if err != nil {
+		return fmt.Errorf("upserting user preferences: %w", err)
+	}
+	return nil
+}
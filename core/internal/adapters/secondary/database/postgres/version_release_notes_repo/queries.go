@@ -0,0 +1,32 @@
+package versionreleasenotesrepo
+
+// SQL queries for version release notes operations.
+const (
+	// queryUpsert only writes when the version was never published, so notes cannot change
+	// after a publish that committed between the service check and this statement.
+	queryUpsert = `
+		INSERT INTO content.template_version_release_notes (version_id, body, ticket_ids, author_id, created_at)
+		SELECT tv.id, $2, $3, $4, $5
+		FROM content.template_versions tv
+		WHERE tv.id = $1 AND tv.published_at IS NULL AND tv.status NOT IN ('PUBLISHED', 'ARCHIVED')
+		ON CONFLICT (version_id) DO UPDATE
+		SET body = EXCLUDED.body, ticket_ids = EXCLUDED.ticket_ids, author_id = EXCLUDED.author_id, updated_at = $5
+		RETURNING created_at, updated_at`
+
+	queryFindByVersionID = `
+		SELECT version_id, body, ticket_ids, author_id, created_at, updated_at
+		FROM content.template_version_release_notes
+		WHERE version_id = $1`
+
+	queryFindByTemplateID = `
+		SELECT rn.version_id, rn.body, rn.ticket_ids, rn.author_id, rn.created_at, rn.updated_at
+		FROM content.template_version_release_notes rn
+		JOIN content.template_versions tv ON tv.id = rn.version_id
+		WHERE tv.template_id = $1`
+
+	queryDelete = `
+		DELETE FROM content.template_version_release_notes rn
+		USING content.template_versions tv
+		WHERE rn.version_id = $1 AND tv.id = rn.version_id
+			AND tv.published_at IS NULL AND tv.status NOT IN ('PUBLISHED', 'ARCHIVED')`
+)
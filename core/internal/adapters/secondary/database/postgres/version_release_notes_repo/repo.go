@@ -0,0 +1,102 @@
+package versionreleasenotesrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new version release notes repository.
+func New(pool *pgxpool.Pool) port.VersionReleaseNotesRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the version release notes repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// Upsert creates or replaces the release notes of a version that was never published.
+func (r *Repository) Upsert(ctx context.Context, notes *entity.VersionReleaseNotes) error {
+	now := time.Now().UTC()
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryUpsert,
+		notes.VersionID,
+		notes.Body,
+		notes.TicketIDs,
+		notes.AuthorID,
+		now,
+	).Scan(&notes.CreatedAt, &notes.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.ErrReleaseNotesFrozen
+	}
+	if err != nil {
+		return fmt.Errorf("upserting release notes: %w", err)
+	}
+	return nil
+}
+
+// FindByVersionID returns the release notes of a version.
+func (r *Repository) FindByVersionID(ctx context.Context, versionID string) (*entity.VersionReleaseNotes, error) {
+	notes, err := scanReleaseNotes(common.Conn(ctx, r.pool).QueryRow(ctx, queryFindByVersionID, versionID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrReleaseNotesNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying release notes: %w", err)
+	}
+	return notes, nil
+}
+
+// FindByTemplateID returns the release notes of every version of a template, keyed by version ID.
+func (r *Repository) FindByTemplateID(ctx context.Context, templateID string) (map[string]*entity.VersionReleaseNotes, error) {
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindByTemplateID, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("querying template release notes: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]*entity.VersionReleaseNotes)
+	for rows.Next() {
+		notes, err := scanReleaseNotes(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning release notes: %w", err)
+		}
+		result[notes.VersionID] = notes
+	}
+	return result, rows.Err()
+}
+
+// Delete removes the release notes of a version that was never published.
+func (r *Repository) Delete(ctx context.Context, versionID string) error {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryDelete, versionID)
+	if err != nil {
+		return fmt.Errorf("deleting release notes: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return entity.ErrReleaseNotesNotFound
+	}
+	return nil
+}
+
+func scanReleaseNotes(row pgx.Row) (*entity.VersionReleaseNotes, error) {
+	var n entity.VersionReleaseNotes
+	if err := row.Scan(
+		&n.VersionID,
+		&n.Body,
+		&n.TicketIDs,
+		&n.AuthorID,
+		&n.CreatedAt,
+		&n.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
@@ -0,0 +1,63 @@
+package webhookdeliveryrepo
+
+// SQL queries for event webhook delivery operations.
+const (
+	queryEnqueue = `
+		INSERT INTO tenancy.event_webhook_deliveries (
+			webhook_id, workspace_id, event_id, event_type, payload, status, next_attempt_at, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (webhook_id, event_id) DO NOTHING`
+
+	deliveryColumns = `id, webhook_id, workspace_id, event_id, event_type, payload, status, attempts,
+		last_status_code, last_error, next_attempt_at, created_at, delivered_at, failed_at`
+
+	// queryClaim skips rows locked by another dispatcher so instances never block each other.
+	queryClaim = `
+		UPDATE tenancy.event_webhook_deliveries
+		SET attempts = attempts + 1, next_attempt_at = NOW() + $2 * INTERVAL '1 millisecond'
+		WHERE id IN (
+			SELECT id FROM tenancy.event_webhook_deliveries
+			WHERE status = 'PENDING' AND next_attempt_at <= NOW()
+			ORDER BY created_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + deliveryColumns
+
+	queryMarkDelivered = `
+		UPDATE tenancy.event_webhook_deliveries
+		SET status = 'DELIVERED', last_status_code = $2, last_error = NULL, delivered_at = NOW()
+		WHERE id = $1`
+
+	queryMarkRetry = `
+		UPDATE tenancy.event_webhook_deliveries
+		SET last_status_code = $2, last_error = $3, next_attempt_at = $4
+		WHERE id = $1`
+
+	queryMarkFailed = `
+		UPDATE tenancy.event_webhook_deliveries
+		SET status = 'FAILED', last_status_code = $2, last_error = $3, failed_at = NOW()
+		WHERE id = $1`
+
+	queryFindByID = `
+		SELECT ` + deliveryColumns + `
+		FROM tenancy.event_webhook_deliveries
+		WHERE webhook_id = $1 AND id = $2`
+
+	queryFindByWebhook = `
+		SELECT ` + deliveryColumns + `
+		FROM tenancy.event_webhook_deliveries
+		WHERE webhook_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC
+		LIMIT $3`
+
+	queryReschedule = `
+		UPDATE tenancy.event_webhook_deliveries
+		SET status = 'PENDING', attempts = 0, next_attempt_at = NOW(), delivered_at = NULL, failed_at = NULL
+		WHERE webhook_id = $1 AND id = $2`
+
+	queryDeleteFinishedBefore = `
+		DELETE FROM tenancy.event_webhook_deliveries
+		WHERE status <> 'PENDING' AND created_at < $1`
+)
@@ -0,0 +1,177 @@
+package webhookdeliveryrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// defaultListLimit bounds the delivery log returned when the filter sets no limit.
+const defaultListLimit = 50
+
+// New creates a new webhook delivery repository.
+func New(pool *pgxpool.Pool) port.WebhookDeliveryRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the webhook delivery repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// Enqueue stores pending deliveries, skipping events already queued for the same webhook.
+func (r *Repository) Enqueue(ctx context.Context, deliveries []*entity.WebhookDelivery) error {
+	conn := common.Conn(ctx, r.pool)
+	for _, d := range deliveries {
+		_, err := conn.Exec(ctx, queryEnqueue,
+			d.WebhookID,
+			d.WorkspaceID,
+			d.EventID,
+			d.EventType,
+			d.Payload,
+			d.Status,
+			d.NextAttemptAt,
+			d.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("enqueuing webhook delivery: %w", err)
+		}
+	}
+	return nil
+}
+
+// Claim claims due pending deliveries for lease.
+func (r *Repository) Claim(ctx context.Context, limit int, lease time.Duration) ([]*entity.WebhookDelivery, error) {
+	deliveries, err := r.findMany(ctx, queryClaim, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, fmt.Errorf("claiming webhook deliveries: %w", err)
+	}
+
+	// UPDATE ... RETURNING does not preserve the subquery order
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].CreatedAt.Before(deliveries[j].CreatedAt) })
+	return deliveries, nil
+}
+
+// MarkDelivered records a successful delivery.
+func (r *Repository) MarkDelivered(ctx context.Context, id string, statusCode int) error {
+	if _, err := r.pool.Exec(ctx, queryMarkDelivered, id, statusCode); err != nil {
+		return fmt.Errorf("marking webhook delivery delivered: %w", err)
+	}
+	return nil
+}
+
+// MarkRetry records a failed attempt and schedules the next one.
+func (r *Repository) MarkRetry(ctx context.Context, id string, statusCode *int, lastError string, retryAt time.Time) error {
+	if _, err := r.pool.Exec(ctx, queryMarkRetry, id, statusCode, lastError, retryAt); err != nil {
+		return fmt.Errorf("scheduling webhook delivery retry: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed gives up on a delivery.
+func (r *Repository) MarkFailed(ctx context.Context, id string, statusCode *int, lastError string) error {
+	if _, err := r.pool.Exec(ctx, queryMarkFailed, id, statusCode, lastError); err != nil {
+		return fmt.Errorf("marking webhook delivery failed: %w", err)
+	}
+	return nil
+}
+
+// FindByID finds a delivery by ID within a webhook.
+func (r *Repository) FindByID(ctx context.Context, webhookID, id string) (*entity.WebhookDelivery, error) {
+	delivery, err := scanDelivery(r.pool.QueryRow(ctx, queryFindByID, webhookID, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrWebhookDeliveryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying webhook delivery: %w", err)
+	}
+	return delivery, nil
+}
+
+// FindByWebhook lists the deliveries of a webhook, newest first.
+func (r *Repository) FindByWebhook(ctx context.Context, webhookID string, filter entity.WebhookDeliveryFilter) ([]*entity.WebhookDelivery, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	deliveries, err := r.findMany(ctx, queryFindByWebhook, webhookID, string(filter.Status), limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// Reschedule moves a delivery back to pending, due now.
+func (r *Repository) Reschedule(ctx context.Context, webhookID, id string) error {
+	result, err := r.pool.Exec(ctx, queryReschedule, webhookID, id)
+	if err != nil {
+		return fmt.Errorf("rescheduling webhook delivery: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrWebhookDeliveryNotFound
+	}
+
+	return nil
+}
+
+// DeleteFinishedBefore removes delivered and failed deliveries created before the given time.
+func (r *Repository) DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.pool.Exec(ctx, queryDeleteFinishedBefore, before)
+	if err != nil {
+		return 0, fmt.Errorf("deleting finished webhook deliveries: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+func (r *Repository) findMany(ctx context.Context, query string, args ...any) ([]*entity.WebhookDelivery, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*entity.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning webhook delivery: %w", err)
+		}
+		result = append(result, delivery)
+	}
+
+	return result, rows.Err()
+}
+
+func scanDelivery(row pgx.Row) (*entity.WebhookDelivery, error) {
+	var d entity.WebhookDelivery
+	err := row.Scan(
+		&d.ID,
+		&d.WebhookID,
+		&d.WorkspaceID,
+		&d.EventID,
+		&d.EventType,
+		&d.Payload,
+		&d.Status,
+		&d.Attempts,
+		&d.LastStatusCode,
+		&d.LastError,
+		&d.NextAttemptAt,
+		&d.CreatedAt,
+		&d.DeliveredAt,
+		&d.FailedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
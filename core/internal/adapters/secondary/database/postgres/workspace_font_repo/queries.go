@@ -0,0 +1,29 @@
+package workspacefontrepo
+
+// SQL queries for workspace font operations.
+const (
+	queryCreate = `
+		INSERT INTO content.workspace_fonts (workspace_id, family, weight, style, format, size_bytes, sha256, data, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id`
+
+	querySelectFont = `
+		SELECT id, workspace_id, family, weight, style, format, size_bytes, sha256, created_by, created_at
+		FROM content.workspace_fonts`
+
+	queryFindByID = querySelectFont + `
+		WHERE id = $1 AND workspace_id = $2`
+
+	queryFindByWorkspace = querySelectFont + `
+		WHERE workspace_id = $1
+		ORDER BY LOWER(family), style, weight`
+
+	queryFindContent = `
+		SELECT id, workspace_id, family, weight, style, format, size_bytes, sha256, created_by, created_at, data
+		FROM content.workspace_fonts
+		WHERE id = $1 AND workspace_id = $2`
+
+	queryDelete = `
+		DELETE FROM content.workspace_fonts
+		WHERE id = $1 AND workspace_id = $2`
+)
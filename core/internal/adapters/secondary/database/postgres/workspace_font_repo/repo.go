@@ -0,0 +1,124 @@
+package workspacefontrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new workspace font repository.
+func New(pool *pgxpool.Pool) port.WorkspaceFontRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the workspace font repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// Create stores a font with its content.
+func (r *Repository) Create(ctx context.Context, font *entity.WorkspaceFont) (string, error) {
+	var id string
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryCreate,
+		font.WorkspaceID,
+		font.Family,
+		font.Weight,
+		font.Style,
+		font.Format,
+		font.SizeBytes,
+		font.SHA256,
+		font.Data,
+		font.CreatedBy,
+		font.CreatedAt,
+	).Scan(&id)
+	if err != nil {
+		return "", fmt.Errorf("inserting workspace font: %w", err)
+	}
+
+	return id, nil
+}
+
+// FindByID finds a font of a workspace, without its content.
+func (r *Repository) FindByID(ctx context.Context, workspaceID, id string) (*entity.WorkspaceFont, error) {
+	var font entity.WorkspaceFont
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindByID, id, workspaceID).Scan(fontFields(&font)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrWorkspaceFontNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying workspace font: %w", err)
+	}
+
+	return &font, nil
+}
+
+// FindByWorkspace lists the fonts of a workspace without their content, by family, style and weight.
+func (r *Repository) FindByWorkspace(ctx context.Context, workspaceID string) ([]*entity.WorkspaceFont, error) {
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindByWorkspace, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("querying workspace fonts: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*entity.WorkspaceFont
+	for rows.Next() {
+		var font entity.WorkspaceFont
+		if err := rows.Scan(fontFields(&font)...); err != nil {
+			return nil, fmt.Errorf("scanning workspace font: %w", err)
+		}
+		result = append(result, &font)
+	}
+
+	return result, rows.Err()
+}
+
+// FindContent returns a font of a workspace with its content.
+func (r *Repository) FindContent(ctx context.Context, workspaceID, id string) (*entity.WorkspaceFont, error) {
+	var font entity.WorkspaceFont
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindContent, id, workspaceID).Scan(append(fontFields(&font), &font.Data)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrWorkspaceFontNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying workspace font content: %w", err)
+	}
+
+	return &font, nil
+}
+
+// Delete deletes a font of a workspace.
+func (r *Repository) Delete(ctx context.Context, workspaceID, id string) error {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryDelete, id, workspaceID)
+	if err != nil {
+		return fmt.Errorf("deleting workspace font: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrWorkspaceFontNotFound
+	}
+
+	return nil
+}
+
+// fontFields returns the scan targets of the columns of querySelectFont.
+func fontFields(font *entity.WorkspaceFont) []any {
+	return []any{
+		&font.ID,
+		&font.WorkspaceID,
+		&font.Family,
+		&font.Weight,
+		&font.Style,
+		&font.Format,
+		&font.SizeBytes,
+		&font.SHA256,
+		&font.CreatedBy,
+		&font.CreatedAt,
+	}
+}
@@ -4,25 +4,29 @@ package workspaceinjectablerepo
 const (
 	queryCreate = `
 		INSERT INTO content.injectable_definitions
-			(id, workspace_id, key, label, description, data_type, metadata, format_config, default_value, is_active, is_deleted, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-		RETURNING id`
+			(id, workspace_id, key, label, description, data_type, metadata, format_config, default_value, formula, is_active, is_deleted, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id, revision`
 
 	queryFindByID = `
-		SELECT id, workspace_id, key, label, description, data_type, metadata, format_config, default_value, is_active, is_deleted, created_at, updated_at
+		SELECT id, workspace_id, key, label, description, data_type, metadata, format_config, default_value, formula, is_active, is_deleted, revision, created_at, updated_at
 		FROM content.injectable_definitions
 		WHERE id = $1 AND workspace_id = $2 AND is_deleted = false`
 
 	queryFindByWorkspaceOwned = `
-		SELECT id, workspace_id, key, label, description, data_type, metadata, format_config, default_value, is_active, is_deleted, created_at, updated_at
+		SELECT id, workspace_id, key, label, description, data_type, metadata, format_config, default_value, formula, is_active, is_deleted, revision, created_at, updated_at
 		FROM content.injectable_definitions
 		WHERE workspace_id = $1 AND is_deleted = false
 		ORDER BY key`
 
 	queryUpdate = `
 		UPDATE content.injectable_definitions
-		SET key = $2, label = $3, description = $4, metadata = $5, format_config = $6, default_value = $7, updated_at = $8
-		WHERE id = $1 AND workspace_id = $9 AND is_deleted = false`
+		SET key = $2, label = $3, description = $4, metadata = $5, format_config = $6, default_value = $7, updated_at = $8,
+			data_type = $11, formula = $12, revision = revision + 1
+		WHERE id = $1 AND workspace_id = $9 AND is_deleted = false AND revision = $10
+		RETURNING revision`
+
+	queryExists = `SELECT EXISTS(SELECT 1 FROM content.injectable_definitions WHERE id = $1 AND workspace_id = $2 AND is_deleted = false)`
 
 	querySoftDelete = `
 		UPDATE content.injectable_definitions
@@ -31,7 +35,7 @@ const (
 
 	querySetActive = `
 		UPDATE content.injectable_definitions
-		SET is_active = $3, updated_at = NOW()
+		SET is_active = $3, updated_at = NOW(), revision = revision + 1
 		WHERE id = $1 AND workspace_id = $2 AND is_deleted = false`
 
 	queryExistsByKey = `
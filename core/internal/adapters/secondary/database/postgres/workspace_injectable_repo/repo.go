@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
 	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	"github.com/rendis/pdf-forge/core/internal/core/port"
 )
@@ -25,7 +26,7 @@ type Repository struct {
 // Create creates a new workspace-owned injectable.
 func (r *Repository) Create(ctx context.Context, injectable *entity.InjectableDefinition) (string, error) {
 	var id string
-	err := r.pool.QueryRow(ctx, queryCreate,
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryCreate,
 		injectable.ID,
 		injectable.WorkspaceID,
 		injectable.Key,
@@ -35,10 +36,11 @@ func (r *Repository) Create(ctx context.Context, injectable *entity.InjectableDe
 		injectable.Metadata,
 		injectable.FormatConfig,
 		injectable.DefaultValue,
+		injectable.Formula,
 		injectable.IsActive,
 		injectable.IsDeleted,
 		injectable.CreatedAt,
-	).Scan(&id)
+	).Scan(&id, &injectable.Revision)
 	if err != nil {
 		return "", fmt.Errorf("inserting injectable: %w", err)
 	}
@@ -49,7 +51,7 @@ func (r *Repository) Create(ctx context.Context, injectable *entity.InjectableDe
 // FindByID finds an injectable by ID, ensuring it belongs to the workspace and is not deleted.
 func (r *Repository) FindByID(ctx context.Context, id, workspaceID string) (*entity.InjectableDefinition, error) {
 	injectable := &entity.InjectableDefinition{}
-	err := r.pool.QueryRow(ctx, queryFindByID, id, workspaceID).Scan(
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindByID, id, workspaceID).Scan(
 		&injectable.ID,
 		&injectable.WorkspaceID,
 		&injectable.Key,
@@ -59,8 +61,10 @@ func (r *Repository) FindByID(ctx context.Context, id, workspaceID string) (*ent
 		&injectable.Metadata,
 		&injectable.FormatConfig,
 		&injectable.DefaultValue,
+		&injectable.Formula,
 		&injectable.IsActive,
 		&injectable.IsDeleted,
+		&injectable.Revision,
 		&injectable.CreatedAt,
 		&injectable.UpdatedAt,
 	)
@@ -77,7 +81,7 @@ func (r *Repository) FindByID(ctx context.Context, id, workspaceID string) (*ent
 
 // FindByWorkspaceOwned lists injectables owned by a workspace (excluding deleted).
 func (r *Repository) FindByWorkspaceOwned(ctx context.Context, workspaceID string) ([]*entity.InjectableDefinition, error) {
-	rows, err := r.pool.Query(ctx, queryFindByWorkspaceOwned, workspaceID)
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindByWorkspaceOwned, workspaceID)
 	if err != nil {
 		return nil, fmt.Errorf("querying workspace injectables: %w", err)
 	}
@@ -86,9 +90,11 @@ func (r *Repository) FindByWorkspaceOwned(ctx context.Context, workspaceID strin
 	return scanInjectables(rows)
 }
 
-// Update updates a workspace-owned injectable.
+// Update updates a workspace-owned injectable if its revision is still injectable.Revision,
+// then sets injectable.Revision to the new revision.
 func (r *Repository) Update(ctx context.Context, injectable *entity.InjectableDefinition) error {
-	result, err := r.pool.Exec(ctx, queryUpdate,
+	conn := common.Conn(ctx, r.pool)
+	err := conn.QueryRow(ctx, queryUpdate,
 		injectable.ID,
 		injectable.Key,
 		injectable.Label,
@@ -98,21 +104,23 @@ func (r *Repository) Update(ctx context.Context, injectable *entity.InjectableDe
 		injectable.DefaultValue,
 		injectable.UpdatedAt,
 		injectable.WorkspaceID,
-	)
+		injectable.Revision,
+		injectable.DataType,
+		injectable.Formula,
+	).Scan(&injectable.Revision)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return common.StaleOrMissing(ctx, conn, entity.ErrInjectableNotFound, queryExists, injectable.ID, injectable.WorkspaceID)
+		}
 		return fmt.Errorf("updating injectable: %w", err)
 	}
 
-	if result.RowsAffected() == 0 {
-		return entity.ErrInjectableNotFound
-	}
-
 	return nil
 }
 
 // SoftDelete marks an injectable as deleted (is_deleted=true).
 func (r *Repository) SoftDelete(ctx context.Context, id, workspaceID string) error {
-	result, err := r.pool.Exec(ctx, querySoftDelete, id, workspaceID)
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, querySoftDelete, id, workspaceID)
 	if err != nil {
 		return fmt.Errorf("soft deleting injectable: %w", err)
 	}
@@ -126,7 +134,7 @@ func (r *Repository) SoftDelete(ctx context.Context, id, workspaceID string) err
 
 // SetActive sets the is_active flag for an injectable.
 func (r *Repository) SetActive(ctx context.Context, id, workspaceID string, isActive bool) error {
-	result, err := r.pool.Exec(ctx, querySetActive, id, workspaceID, isActive)
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, querySetActive, id, workspaceID, isActive)
 	if err != nil {
 		return fmt.Errorf("setting injectable active status: %w", err)
 	}
@@ -141,7 +149,7 @@ func (r *Repository) SetActive(ctx context.Context, id, workspaceID string, isAc
 // ExistsByKey checks if an injectable with the given key exists for the workspace.
 func (r *Repository) ExistsByKey(ctx context.Context, workspaceID, key string) (bool, error) {
 	var exists bool
-	err := r.pool.QueryRow(ctx, queryExistsByKey, workspaceID, key).Scan(&exists)
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryExistsByKey, workspaceID, key).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("checking injectable existence: %w", err)
 	}
@@ -152,7 +160,7 @@ func (r *Repository) ExistsByKey(ctx context.Context, workspaceID, key string) (
 // ExistsByKeyExcluding checks if an injectable with the given key exists, excluding a specific ID.
 func (r *Repository) ExistsByKeyExcluding(ctx context.Context, workspaceID, key, excludeID string) (bool, error) {
 	var exists bool
-	err := r.pool.QueryRow(ctx, queryExistsByKeyExcluding, workspaceID, key, excludeID).Scan(&exists)
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryExistsByKeyExcluding, workspaceID, key, excludeID).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("checking injectable existence: %w", err)
 	}
@@ -175,8 +183,10 @@ func scanInjectables(rows pgx.Rows) ([]*entity.InjectableDefinition, error) {
 			&injectable.Metadata,
 			&injectable.FormatConfig,
 			&injectable.DefaultValue,
+			&injectable.Formula,
 			&injectable.IsActive,
 			&injectable.IsDeleted,
+			&injectable.Revision,
 			&injectable.CreatedAt,
 			&injectable.UpdatedAt,
 		)
@@ -0,0 +1,38 @@
+package workspaceinvitationrepo
+
+// SQL queries for workspace invitation operations.
+const (
+	queryCreate = `
+		INSERT INTO identity.workspace_invitations (
+			id, workspace_id, email, role, status, token_hash, invited_by, expires_at, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id`
+
+	querySelectColumns = `
+		SELECT id, workspace_id, email, role, status, token_hash, invited_by,
+		       expires_at, responded_at, created_at, updated_at
+		FROM identity.workspace_invitations`
+
+	queryFindByID = querySelectColumns + `
+		WHERE id = $1`
+
+	queryFindByTokenHash = querySelectColumns + `
+		WHERE token_hash = $1`
+
+	queryFindPendingByWorkspace = querySelectColumns + `
+		WHERE workspace_id = $1 AND status = 'PENDING'
+		ORDER BY created_at DESC`
+
+	queryFindPendingByWorkspaceAndEmail = querySelectColumns + `
+		WHERE workspace_id = $1 AND LOWER(email) = LOWER($2) AND status = 'PENDING'`
+
+	queryFindPendingByEmail = querySelectColumns + `
+		WHERE LOWER(email) = LOWER($1) AND status = 'PENDING' AND expires_at > NOW()
+		ORDER BY created_at DESC`
+
+	queryUpdate = `
+		UPDATE identity.workspace_invitations
+		SET status = $2, token_hash = $3, expires_at = $4, responded_at = $5, updated_at = $6
+		WHERE id = $1`
+)
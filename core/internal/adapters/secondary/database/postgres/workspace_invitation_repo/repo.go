@@ -0,0 +1,142 @@
+package workspaceinvitationrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new workspace invitation repository.
+func New(pool *pgxpool.Pool) port.WorkspaceInvitationRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the workspace invitation repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// Create creates a new invitation.
+func (r *Repository) Create(ctx context.Context, invitation *entity.WorkspaceInvitation) (string, error) {
+	var id string
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryCreate,
+		invitation.ID,
+		invitation.WorkspaceID,
+		invitation.Email,
+		invitation.Role,
+		invitation.Status,
+		invitation.TokenHash,
+		invitation.InvitedBy,
+		invitation.ExpiresAt,
+		invitation.CreatedAt,
+	).Scan(&id)
+	if err != nil {
+		return "", fmt.Errorf("inserting workspace invitation: %w", err)
+	}
+
+	return id, nil
+}
+
+// FindByID finds an invitation by ID.
+func (r *Repository) FindByID(ctx context.Context, id string) (*entity.WorkspaceInvitation, error) {
+	return r.findOne(ctx, queryFindByID, id)
+}
+
+// FindByTokenHash finds an invitation by the hash of its emailed token.
+func (r *Repository) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.WorkspaceInvitation, error) {
+	return r.findOne(ctx, queryFindByTokenHash, tokenHash)
+}
+
+// FindPendingByWorkspace lists pending invitations of a workspace, including expired ones.
+func (r *Repository) FindPendingByWorkspace(ctx context.Context, workspaceID string) ([]*entity.WorkspaceInvitation, error) {
+	return r.findMany(ctx, queryFindPendingByWorkspace, workspaceID)
+}
+
+// FindPendingByWorkspaceAndEmail finds the open invitation for an email in a workspace.
+func (r *Repository) FindPendingByWorkspaceAndEmail(ctx context.Context, workspaceID, email string) (*entity.WorkspaceInvitation, error) {
+	return r.findOne(ctx, queryFindPendingByWorkspaceAndEmail, workspaceID, email)
+}
+
+// FindPendingByEmail lists unexpired pending invitations addressed to an email.
+func (r *Repository) FindPendingByEmail(ctx context.Context, email string) ([]*entity.WorkspaceInvitation, error) {
+	return r.findMany(ctx, queryFindPendingByEmail, email)
+}
+
+// Update updates an invitation's status, token and expiration.
+func (r *Repository) Update(ctx context.Context, invitation *entity.WorkspaceInvitation) error {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryUpdate,
+		invitation.ID,
+		invitation.Status,
+		invitation.TokenHash,
+		invitation.ExpiresAt,
+		invitation.RespondedAt,
+		invitation.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("updating workspace invitation: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrInvitationNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) findOne(ctx context.Context, query string, args ...any) (*entity.WorkspaceInvitation, error) {
+	invitation, err := scanInvitation(common.Conn(ctx, r.pool).QueryRow(ctx, query, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrInvitationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying workspace invitation: %w", err)
+	}
+	return invitation, nil
+}
+
+func (r *Repository) findMany(ctx context.Context, query string, arg string) ([]*entity.WorkspaceInvitation, error) {
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, query, arg)
+	if err != nil {
+		return nil, fmt.Errorf("querying workspace invitations: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*entity.WorkspaceInvitation
+	for rows.Next() {
+		invitation, err := scanInvitation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning workspace invitation: %w", err)
+		}
+		result = append(result, invitation)
+	}
+
+	return result, rows.Err()
+}
+
+func scanInvitation(row pgx.Row) (*entity.WorkspaceInvitation, error) {
+	var i entity.WorkspaceInvitation
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.Email,
+		&i.Role,
+		&i.Status,
+		&i.TokenHash,
+		&i.InvitedBy,
+		&i.ExpiresAt,
+		&i.RespondedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
@@ -49,4 +49,31 @@ const (
 		WHERE id = $1 AND membership_status = 'PENDING'`
 
 	queryUpdateRole = `UPDATE identity.workspace_members SET role = $2 WHERE id = $1`
+
+	queryUpdateStatus = `UPDATE identity.workspace_members SET membership_status = $2 WHERE id = $1`
+
+	queryCountActiveByRole = `
+		SELECT COUNT(*)
+		FROM identity.workspace_members
+		WHERE workspace_id = $1 AND role = $2 AND membership_status = 'ACTIVE'`
+
+	// Promotes $3 and demotes $2 in one statement. Both rows are locked first, so the guard sees
+	// any concurrent change to them; it only matches when $2 is an active OWNER and $3 an active
+	// member, so the workspace is never left without an owner.
+	queryTransferOwnership = `
+		WITH locked AS (
+			SELECT id, role, membership_status
+			FROM identity.workspace_members
+			WHERE workspace_id = $1 AND id IN ($2, $3)
+			ORDER BY id
+			FOR UPDATE
+		)
+		UPDATE identity.workspace_members m
+		SET role = CASE WHEN m.id = $3 THEN 'OWNER'::workspace_role ELSE $4::workspace_role END
+		WHERE m.workspace_id = $1 AND m.id IN ($2, $3)
+		  AND (
+			SELECT COUNT(*) FROM locked g
+			WHERE g.membership_status = 'ACTIVE'
+			  AND (g.id = $3 OR (g.id = $2 AND g.role = 'OWNER'))
+		  ) = 2`
 )
@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
 	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	"github.com/rendis/pdf-forge/core/internal/core/port"
 )
@@ -25,7 +26,7 @@ type Repository struct {
 // Create creates a new workspace membership.
 func (r *Repository) Create(ctx context.Context, member *entity.WorkspaceMember) (string, error) {
 	var id string
-	err := r.pool.QueryRow(ctx, queryCreate,
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryCreate,
 		member.ID,
 		member.WorkspaceID,
 		member.UserID,
@@ -45,7 +46,7 @@ func (r *Repository) Create(ctx context.Context, member *entity.WorkspaceMember)
 // FindByID finds a membership by ID.
 func (r *Repository) FindByID(ctx context.Context, id string) (*entity.WorkspaceMember, error) {
 	var member entity.WorkspaceMember
-	err := r.pool.QueryRow(ctx, queryFindByID, id).Scan(
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindByID, id).Scan(
 		&member.ID,
 		&member.WorkspaceID,
 		&member.UserID,
@@ -68,7 +69,7 @@ func (r *Repository) FindByID(ctx context.Context, id string) (*entity.Workspace
 // FindByUserAndWorkspace finds a membership for a specific user and workspace.
 func (r *Repository) FindByUserAndWorkspace(ctx context.Context, userID, workspaceID string) (*entity.WorkspaceMember, error) {
 	var member entity.WorkspaceMember
-	err := r.pool.QueryRow(ctx, queryFindByUserAndWorkspace, userID, workspaceID).Scan(
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindByUserAndWorkspace, userID, workspaceID).Scan(
 		&member.ID,
 		&member.WorkspaceID,
 		&member.UserID,
@@ -90,7 +91,7 @@ func (r *Repository) FindByUserAndWorkspace(ctx context.Context, userID, workspa
 
 // FindByWorkspace lists all members of a workspace.
 func (r *Repository) FindByWorkspace(ctx context.Context, workspaceID string) ([]*entity.MemberWithUser, error) {
-	rows, err := r.pool.Query(ctx, queryFindByWorkspace, workspaceID)
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindByWorkspace, workspaceID)
 	if err != nil {
 		return nil, fmt.Errorf("querying workspace members: %w", err)
 	}
@@ -130,7 +131,7 @@ func (r *Repository) FindByWorkspace(ctx context.Context, workspaceID string) ([
 
 // FindByUser lists all workspace memberships for a user.
 func (r *Repository) FindByUser(ctx context.Context, userID string) ([]*entity.WorkspaceMember, error) {
-	rows, err := r.pool.Query(ctx, queryFindByUser, userID)
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindByUser, userID)
 	if err != nil {
 		return nil, fmt.Errorf("querying user memberships: %w", err)
 	}
@@ -161,7 +162,7 @@ func (r *Repository) FindByUser(ctx context.Context, userID string) ([]*entity.W
 // FindActiveByUserAndWorkspace finds an active membership.
 func (r *Repository) FindActiveByUserAndWorkspace(ctx context.Context, userID, workspaceID string) (*entity.WorkspaceMember, error) {
 	var member entity.WorkspaceMember
-	err := r.pool.QueryRow(ctx, queryFindActiveByUserAndWorkspace, userID, workspaceID).Scan(
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindActiveByUserAndWorkspace, userID, workspaceID).Scan(
 		&member.ID,
 		&member.WorkspaceID,
 		&member.UserID,
@@ -183,7 +184,7 @@ func (r *Repository) FindActiveByUserAndWorkspace(ctx context.Context, userID, w
 
 // Update updates a membership.
 func (r *Repository) Update(ctx context.Context, member *entity.WorkspaceMember) error {
-	_, err := r.pool.Exec(ctx, queryUpdate,
+	_, err := common.Conn(ctx, r.pool).Exec(ctx, queryUpdate,
 		member.ID,
 		member.Role,
 		member.MembershipStatus,
@@ -197,7 +198,7 @@ func (r *Repository) Update(ctx context.Context, member *entity.WorkspaceMember)
 
 // Delete removes a membership.
 func (r *Repository) Delete(ctx context.Context, id string) error {
-	result, err := r.pool.Exec(ctx, queryDelete, id)
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryDelete, id)
 	if err != nil {
 		return fmt.Errorf("deleting workspace member: %w", err)
 	}
@@ -211,7 +212,7 @@ func (r *Repository) Delete(ctx context.Context, id string) error {
 
 // Activate activates a pending membership.
 func (r *Repository) Activate(ctx context.Context, id string) error {
-	result, err := r.pool.Exec(ctx, queryActivate, id)
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryActivate, id)
 	if err != nil {
 		return fmt.Errorf("activating membership: %w", err)
 	}
@@ -225,7 +226,7 @@ func (r *Repository) Activate(ctx context.Context, id string) error {
 
 // UpdateRole updates a member's role.
 func (r *Repository) UpdateRole(ctx context.Context, id string, role entity.WorkspaceRole) error {
-	result, err := r.pool.Exec(ctx, queryUpdateRole, id, role)
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryUpdateRole, id, role)
 	if err != nil {
 		return fmt.Errorf("updating member role: %w", err)
 	}
@@ -236,3 +237,40 @@ func (r *Repository) UpdateRole(ctx context.Context, id string, role entity.Work
 
 	return nil
 }
+
+// UpdateStatus updates a membership's status.
+func (r *Repository) UpdateStatus(ctx context.Context, id string, status entity.MembershipStatus) error {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryUpdateStatus, id, status)
+	if err != nil {
+		return fmt.Errorf("updating membership status: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrMemberNotFound
+	}
+
+	return nil
+}
+
+// CountActiveByRole counts active members with a specific role in a workspace.
+func (r *Repository) CountActiveByRole(ctx context.Context, workspaceID string, role entity.WorkspaceRole) (int, error) {
+	var count int
+	if err := common.Conn(ctx, r.pool).QueryRow(ctx, queryCountActiveByRole, workspaceID, role).Scan(&count); err != nil {
+		return 0, fmt.Errorf("counting members by role: %w", err)
+	}
+	return count, nil
+}
+
+// TransferOwnership atomically promotes a member to OWNER and demotes the current owner.
+func (r *Repository) TransferOwnership(ctx context.Context, workspaceID, fromMemberID, toMemberID string, demoteTo entity.WorkspaceRole) error {
+	result, err := common.Conn(ctx, r.pool).Exec(ctx, queryTransferOwnership, workspaceID, fromMemberID, toMemberID, demoteTo)
+	if err != nil {
+		return fmt.Errorf("transferring workspace ownership: %w", err)
+	}
+
+	if result.RowsAffected() != 2 {
+		return entity.ErrInvalidOwnershipTarget
+	}
+
+	return nil
+}
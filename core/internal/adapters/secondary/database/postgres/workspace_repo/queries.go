@@ -40,6 +40,12 @@ const (
 		  AND ($2 = '' OR name ILIKE '%' || $2 || '%' OR code ILIKE '%' || $2 || '%')
 		  AND ($3 = '' OR status = $3::workspace_status)`
 
+	queryFindByTenant = `
+		SELECT id, tenant_id, code, name, type, status, created_at, updated_at
+		FROM tenancy.workspaces
+		WHERE tenant_id = $1
+		ORDER BY name`
+
 	queryFindByUser = `
 		SELECT w.id, w.tenant_id, w.code, w.name, w.type, w.status,
 		       w.created_at, w.updated_at, m.role
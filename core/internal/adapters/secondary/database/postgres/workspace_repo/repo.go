@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
 	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	"github.com/rendis/pdf-forge/core/internal/core/port"
 )
@@ -25,7 +26,7 @@ type Repository struct {
 // Create creates a new workspace.
 func (r *Repository) Create(ctx context.Context, workspace *entity.Workspace) (string, error) {
 	var id string
-	err := r.pool.QueryRow(ctx, queryCreate,
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryCreate,
 		workspace.ID,
 		workspace.TenantID,
 		workspace.Code,
@@ -89,6 +90,17 @@ func (r *Repository) FindByTenantPaginated(ctx context.Context, tenantID string,
 	return workspaces, total, nil
 }
 
+// FindByTenant lists every workspace of a tenant, ordered by name.
+func (r *Repository) FindByTenant(ctx context.Context, tenantID string) ([]*entity.Workspace, error) {
+	rows, err := r.pool.Query(ctx, queryFindByTenant, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("querying tenant workspaces: %w", err)
+	}
+	defer rows.Close()
+
+	return scanWorkspaces(rows)
+}
+
 // FindByUser lists all workspaces a user has access to.
 func (r *Repository) FindByUser(ctx context.Context, userID string) ([]*entity.WorkspaceWithRole, error) {
 	rows, err := r.pool.Query(ctx, queryFindByUser, userID)
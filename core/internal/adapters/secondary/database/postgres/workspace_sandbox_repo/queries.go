@@ -0,0 +1,156 @@
+package workspacesandboxrepo
+
+// SQL queries for workspace sandbox operations.
+//
+// Clone queries take the source workspace ($1), the target workspace ($2) and, for each
+// table whose rows get new IDs, two parallel arrays mapping old IDs to new ones.
+const (
+	queryCreate = `
+		INSERT INTO tenancy.workspace_sandboxes (workspace_id, source_workspace_id, created_by, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	queryFindByWorkspaceID = `
+		SELECT workspace_id, source_workspace_id, created_by, expires_at, created_at
+		FROM tenancy.workspace_sandboxes
+		WHERE workspace_id = $1`
+
+	queryFindExpired = `
+		SELECT workspace_id
+		FROM tenancy.workspace_sandboxes
+		WHERE expires_at < $1
+		ORDER BY expires_at
+		LIMIT $2`
+
+	queryFolderIDs     = `SELECT id FROM organizer.folders WHERE workspace_id = $1`
+	queryTagIDs        = `SELECT id FROM organizer.tags WHERE workspace_id = $1`
+	queryInjectableIDs = `SELECT id FROM content.injectable_definitions WHERE workspace_id = $1`
+	queryTemplateIDs   = `SELECT id FROM content.templates WHERE workspace_id = $1`
+	queryAssetIDs      = `SELECT id FROM content.assets WHERE workspace_id = $1`
+	queryVersionIDs    = `
+		SELECT v.id
+		FROM content.template_versions v
+		JOIN content.templates t ON t.id = v.template_id
+		WHERE t.workspace_id = $1`
+
+	// Parents are inserted before their children so the path trigger finds them;
+	// a folder path holds one UUID per level, so it grows with depth.
+	queryCloneFolders = `
+		WITH ids AS (SELECT * FROM unnest($3::uuid[], $4::uuid[]) AS m(old_id, new_id))
+		INSERT INTO organizer.folders (id, workspace_id, parent_id, name, created_at)
+		SELECT m.new_id, $2, pm.new_id, f.name, f.created_at
+		FROM organizer.folders f
+		JOIN ids m ON m.old_id = f.id
+		LEFT JOIN ids pm ON pm.old_id = f.parent_id
+		WHERE f.workspace_id = $1
+		ORDER BY length(f.path)`
+
+	queryCloneTags = `
+		WITH ids AS (SELECT * FROM unnest($3::uuid[], $4::uuid[]) AS m(old_id, new_id))
+		INSERT INTO organizer.tags (id, workspace_id, name, color, created_at)
+		SELECT m.new_id, $2, t.name, t.color, t.created_at
+		FROM organizer.tags t
+		JOIN ids m ON m.old_id = t.id
+		WHERE t.workspace_id = $1`
+
+	queryCloneInjectables = `
+		WITH ids AS (SELECT * FROM unnest($3::uuid[], $4::uuid[]) AS m(old_id, new_id))
+		INSERT INTO content.injectable_definitions (
+			id, workspace_id, key, label, description, data_type, default_value, formula,
+			metadata, format_config, is_active, is_deleted, created_at
+		)
+		SELECT m.new_id, $2, d.key, d.label, d.description, d.data_type, d.default_value, d.formula,
+		       d.metadata, d.format_config, d.is_active, d.is_deleted, d.created_at
+		FROM content.injectable_definitions d
+		JOIN ids m ON m.old_id = d.id
+		WHERE d.workspace_id = $1`
+
+	// Copies stay out of the public library; folders are remapped with $5/$6.
+	queryCloneTemplates = `
+		WITH ids AS (SELECT * FROM unnest($3::uuid[], $4::uuid[]) AS m(old_id, new_id)),
+		     folder_ids AS (SELECT * FROM unnest($5::uuid[], $6::uuid[]) AS m(old_id, new_id))
+		INSERT INTO content.templates (id, workspace_id, folder_id, title, is_public_library, document_type_id, created_at)
+		SELECT m.new_id, $2, fm.new_id, t.title, FALSE, t.document_type_id, t.created_at
+		FROM content.templates t
+		JOIN ids m ON m.old_id = t.id
+		LEFT JOIN folder_ids fm ON fm.old_id = t.folder_id
+		WHERE t.workspace_id = $1`
+
+	// Schedules are dropped so the scheduler does not publish or archive the copies.
+	queryCloneVersions = `
+		WITH ids AS (SELECT * FROM unnest($3::uuid[], $4::uuid[]) AS m(old_id, new_id)),
+		     template_ids AS (SELECT * FROM unnest($5::uuid[], $6::uuid[]) AS m(old_id, new_id))
+		INSERT INTO content.template_versions (
+			id, template_id, version_number, name, description, content_structure, status,
+			published_at, archived_at, published_by, archived_by, created_by, created_at
+		)
+		SELECT m.new_id, tm.new_id, v.version_number, v.name, v.description, v.content_structure, v.status,
+		       v.published_at, v.archived_at, v.published_by, v.archived_by, v.created_by, v.created_at
+		FROM content.template_versions v
+		JOIN ids m ON m.old_id = v.id
+		JOIN template_ids tm ON tm.old_id = v.template_id`
+
+	// Definitions outside the source workspace (global ones) are referenced as they are.
+	queryCloneVersionInjectables = `
+		WITH version_ids AS (SELECT * FROM unnest($1::uuid[], $2::uuid[]) AS m(old_id, new_id)),
+		     injectable_ids AS (SELECT * FROM unnest($3::uuid[], $4::uuid[]) AS m(old_id, new_id))
+		INSERT INTO content.template_version_injectables (
+			template_version_id, injectable_definition_id, system_injectable_key, is_required, default_value, created_at
+		)
+		SELECT vm.new_id, COALESCE(im.new_id, vi.injectable_definition_id), vi.system_injectable_key,
+		       vi.is_required, vi.default_value, vi.created_at
+		FROM content.template_version_injectables vi
+		JOIN version_ids vm ON vm.old_id = vi.template_version_id
+		LEFT JOIN injectable_ids im ON im.old_id = vi.injectable_definition_id`
+
+	queryCloneTemplateTags = `
+		WITH template_ids AS (SELECT * FROM unnest($1::uuid[], $2::uuid[]) AS m(old_id, new_id)),
+		     tag_ids AS (SELECT * FROM unnest($3::uuid[], $4::uuid[]) AS m(old_id, new_id))
+		INSERT INTO content.template_tags (template_id, tag_id)
+		SELECT tm.new_id, gm.new_id
+		FROM content.template_tags tt
+		JOIN template_ids tm ON tm.old_id = tt.template_id
+		JOIN tag_ids gm ON gm.old_id = tt.tag_id`
+
+	queryCloneAssets = `
+		WITH ids AS (SELECT * FROM unnest($3::uuid[], $4::uuid[]) AS m(old_id, new_id))
+		INSERT INTO content.assets (id, workspace_id, name, kind, tags, current_version, source_url, created_by, created_at, updated_at)
+		SELECT m.new_id, $2, a.name, a.kind, a.tags, a.current_version, a.source_url, a.created_by, a.created_at, a.updated_at
+		FROM content.assets a
+		JOIN ids m ON m.old_id = a.id
+		WHERE a.workspace_id = $1`
+
+	queryCloneAssetVersions = `
+		WITH ids AS (SELECT * FROM unnest($1::uuid[], $2::uuid[]) AS m(old_id, new_id))
+		INSERT INTO content.asset_versions (asset_id, version, content_type, size_bytes, sha256, data, storage_key, created_by, created_at)
+		SELECT m.new_id, v.version, v.content_type, v.size_bytes, v.sha256, v.data, v.storage_key, v.created_by, v.created_at
+		FROM content.asset_versions v
+		JOIN ids m ON m.old_id = v.asset_id`
+
+	// Fonts are found by family name, so nothing references their IDs
+	queryCloneFonts = `
+		INSERT INTO content.workspace_fonts (workspace_id, family, weight, style, format, size_bytes, sha256, data, created_by, created_at)
+		SELECT $2, family, weight, style, format, size_bytes, sha256, data, created_by, created_at
+		FROM content.workspace_fonts
+		WHERE workspace_id = $1`
+
+	// queryRewriteAssetURL points the copied template versions of the target workspace ($1)
+	// at the copy of an asset: $2 and $3 are its old and new asset:// URLs.
+	queryRewriteAssetURL = `
+		UPDATE content.template_versions v
+		SET content_structure = replace(v.content_structure::TEXT, $2, $3)::JSONB
+		FROM content.templates t
+		WHERE t.id = v.template_id
+		  AND t.workspace_id = $1
+		  AND strpos(v.content_structure::TEXT, $2) > 0`
+
+	queryCloneSystemInjectableAssignments = `
+		INSERT INTO content.system_injectable_assignments (injectable_key, scope_type, workspace_id, is_active)
+		SELECT a.injectable_key, a.scope_type, $2, a.is_active
+		FROM content.system_injectable_assignments a
+		WHERE a.workspace_id = $1 AND a.scope_type = 'WORKSPACE'`
+
+	// Templates go first: their version injectables reference the workspace's injectable
+	// definitions with ON DELETE RESTRICT, which a single cascading delete may trip over.
+	queryDeleteTemplates = `DELETE FROM content.templates WHERE workspace_id = $1`
+	queryDeleteWorkspace = `DELETE FROM tenancy.workspaces WHERE id = $1`
+)
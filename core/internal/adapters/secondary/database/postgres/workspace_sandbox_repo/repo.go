@@ -0,0 +1,189 @@
+package workspacesandboxrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new workspace sandbox repository.
+func New(pool *pgxpool.Pool) port.WorkspaceSandboxRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the workspace sandbox repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// Create records a workspace as a sandbox.
+func (r *Repository) Create(ctx context.Context, sandbox *entity.WorkspaceSandbox) error {
+	_, err := common.Conn(ctx, r.pool).Exec(ctx, queryCreate,
+		sandbox.WorkspaceID,
+		sandbox.SourceWorkspaceID,
+		sandbox.CreatedBy,
+		sandbox.ExpiresAt,
+		sandbox.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("inserting workspace sandbox: %w", err)
+	}
+	return nil
+}
+
+// FindByWorkspaceID finds the sandbox record of a workspace.
+func (r *Repository) FindByWorkspaceID(ctx context.Context, workspaceID string) (*entity.WorkspaceSandbox, error) {
+	var s entity.WorkspaceSandbox
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindByWorkspaceID, workspaceID).Scan(
+		&s.WorkspaceID,
+		&s.SourceWorkspaceID,
+		&s.CreatedBy,
+		&s.ExpiresAt,
+		&s.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrSandboxNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying workspace sandbox: %w", err)
+	}
+	return &s, nil
+}
+
+// idMap pairs the IDs of source rows with the IDs of their copies.
+type idMap struct {
+	old []string
+	new []string
+}
+
+// CloneContent copies the content of the source workspace into the target workspace.
+// Run it inside a transaction so a failed clone leaves no partial copy behind.
+func (r *Repository) CloneContent(ctx context.Context, sourceWorkspaceID, targetWorkspaceID string) (*entity.WorkspaceSandboxClone, error) {
+	q := common.Conn(ctx, r.pool)
+
+	folders, err := newIDMap(ctx, q, queryFolderIDs, sourceWorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("listing folders: %w", err)
+	}
+	tags, err := newIDMap(ctx, q, queryTagIDs, sourceWorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("listing tags: %w", err)
+	}
+	injectables, err := newIDMap(ctx, q, queryInjectableIDs, sourceWorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("listing injectables: %w", err)
+	}
+	templates, err := newIDMap(ctx, q, queryTemplateIDs, sourceWorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("listing templates: %w", err)
+	}
+	versions, err := newIDMap(ctx, q, queryVersionIDs, sourceWorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("listing versions: %w", err)
+	}
+	assets, err := newIDMap(ctx, q, queryAssetIDs, sourceWorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("listing assets: %w", err)
+	}
+
+	steps := []struct {
+		name  string
+		query string
+		args  []any
+	}{
+		{"folders", queryCloneFolders, []any{sourceWorkspaceID, targetWorkspaceID, folders.old, folders.new}},
+		{"tags", queryCloneTags, []any{sourceWorkspaceID, targetWorkspaceID, tags.old, tags.new}},
+		{"injectables", queryCloneInjectables, []any{sourceWorkspaceID, targetWorkspaceID, injectables.old, injectables.new}},
+		{"templates", queryCloneTemplates, []any{sourceWorkspaceID, targetWorkspaceID, templates.old, templates.new, folders.old, folders.new}},
+		{"versions", queryCloneVersions, []any{sourceWorkspaceID, targetWorkspaceID, versions.old, versions.new, templates.old, templates.new}},
+		{"version injectables", queryCloneVersionInjectables, []any{versions.old, versions.new, injectables.old, injectables.new}},
+		{"template tags", queryCloneTemplateTags, []any{templates.old, templates.new, tags.old, tags.new}},
+		{"system injectable assignments", queryCloneSystemInjectableAssignments, []any{sourceWorkspaceID, targetWorkspaceID}},
+		{"assets", queryCloneAssets, []any{sourceWorkspaceID, targetWorkspaceID, assets.old, assets.new}},
+		{"asset versions", queryCloneAssetVersions, []any{assets.old, assets.new}},
+		{"fonts", queryCloneFonts, []any{sourceWorkspaceID, targetWorkspaceID}},
+	}
+	for _, step := range steps {
+		if _, err := q.Exec(ctx, step.query, step.args...); err != nil {
+			return nil, fmt.Errorf("cloning %s: %w", step.name, err)
+		}
+	}
+
+	// The copied content still references the source workspace's assets, which renders in the
+	// sandbox cannot resolve.
+	for i, oldID := range assets.old {
+		oldURL, newURL := entity.AssetURLScheme+oldID, entity.AssetURLScheme+assets.new[i]
+		if _, err := q.Exec(ctx, queryRewriteAssetURL, targetWorkspaceID, oldURL, newURL); err != nil {
+			return nil, fmt.Errorf("rewriting asset references: %w", err)
+		}
+	}
+
+	return &entity.WorkspaceSandboxClone{
+		Folders:     len(folders.old),
+		Tags:        len(tags.old),
+		Injectables: len(injectables.old),
+		Templates:   len(templates.old),
+		Versions:    len(versions.old),
+		Assets:      len(assets.old),
+	}, nil
+}
+
+// newIDMap lists the IDs returned by query and assigns each a new ID.
+func newIDMap(ctx context.Context, q common.Querier, query string, workspaceID string) (idMap, error) {
+	rows, err := q.Query(ctx, query, workspaceID)
+	if err != nil {
+		return idMap{}, err
+	}
+	defer rows.Close()
+
+	var m idMap
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return idMap{}, err
+		}
+		m.old = append(m.old, id)
+		m.new = append(m.new, uuid.NewString())
+	}
+	return m, rows.Err()
+}
+
+// FindExpired returns up to limit sandbox workspace IDs that expired before now.
+func (r *Repository) FindExpired(ctx context.Context, now time.Time, limit int) ([]string, error) {
+	rows, err := common.Conn(ctx, r.pool).Query(ctx, queryFindExpired, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying expired sandboxes: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scanning expired sandbox: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// DeleteWorkspace permanently deletes a sandbox workspace and everything in it.
+func (r *Repository) DeleteWorkspace(ctx context.Context, workspaceID string) error {
+	q := common.Conn(ctx, r.pool)
+	if _, err := q.Exec(ctx, queryDeleteTemplates, workspaceID); err != nil {
+		return fmt.Errorf("deleting sandbox templates: %w", err)
+	}
+	if _, err := q.Exec(ctx, queryDeleteWorkspace, workspaceID); err != nil {
+		return fmt.Errorf("deleting sandbox workspace: %w", err)
+	}
+	return nil
+}
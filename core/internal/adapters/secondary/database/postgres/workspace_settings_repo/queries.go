@@ -0,0 +1,33 @@
+package workspacesettingsrepo
+
+// SQL queries for workspace settings operations.
+const (
+	queryFindByWorkspaceID = `
+		SELECT settings
+		FROM tenancy.workspace_settings
+		WHERE workspace_id = $1`
+
+	queryUpsert = `
+		INSERT INTO tenancy.workspace_settings (workspace_id, settings, updated_by, updated_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+		ON CONFLICT (workspace_id)
+		DO UPDATE SET
+			settings = EXCLUDED.settings,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at`
+
+	queryFindTenantDefaults = `
+		SELECT tenant_id, settings, locked, updated_by, updated_at
+		FROM tenancy.tenant_workspace_defaults
+		WHERE tenant_id = $1`
+
+	queryUpsertTenantDefaults = `
+		INSERT INTO tenancy.tenant_workspace_defaults (tenant_id, settings, locked, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tenant_id)
+		DO UPDATE SET
+			settings = EXCLUDED.settings,
+			locked = EXCLUDED.locked,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at`
+)
@@ -0,0 +1,91 @@
+package workspacesettingsrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres/common"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// New creates a new workspace settings repository.
+func New(pool *pgxpool.Pool) port.WorkspaceSettingsRepository {
+	return &Repository{pool: pool}
+}
+
+// Repository implements the workspace settings repository using PostgreSQL.
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+// FindByWorkspaceID finds the settings of a workspace. Returns empty settings when none were saved.
+func (r *Repository) FindByWorkspaceID(ctx context.Context, workspaceID string) (*entity.WorkspaceSettings, error) {
+	var settings entity.WorkspaceSettings
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindByWorkspaceID, workspaceID).Scan(&settings)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &entity.WorkspaceSettings{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying workspace settings: %w", err)
+	}
+	return &settings, nil
+}
+
+// Upsert creates or replaces the settings of a workspace.
+func (r *Repository) Upsert(ctx context.Context, workspaceID string, settings *entity.WorkspaceSettings, updatedBy *string) error {
+	if _, err := common.Conn(ctx, r.pool).Exec(ctx, queryUpsert, workspaceID, settings, updatedBy); err != nil {
+		return fmt.Errorf("upserting workspace settings: %w", err)
+	}
+	return nil
+}
+
+// FindTenantDefaults finds the workspace defaults of a tenant. Returns empty defaults, without
+// UpdatedAt, when the tenant never set them.
+func (r *Repository) FindTenantDefaults(ctx context.Context, tenantID string) (*entity.TenantWorkspaceDefaults, error) {
+	defaults := &entity.TenantWorkspaceDefaults{}
+	var locked []string
+	err := common.Conn(ctx, r.pool).QueryRow(ctx, queryFindTenantDefaults, tenantID).Scan(
+		&defaults.TenantID,
+		&defaults.Settings,
+		&locked,
+		&defaults.UpdatedBy,
+		&defaults.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &entity.TenantWorkspaceDefaults{TenantID: tenantID, Locked: []entity.WorkspaceSettingKey{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying tenant workspace defaults: %w", err)
+	}
+
+	defaults.Locked = make([]entity.WorkspaceSettingKey, len(locked))
+	for i, key := range locked {
+		defaults.Locked[i] = entity.WorkspaceSettingKey(key)
+	}
+	return defaults, nil
+}
+
+// UpsertTenantDefaults creates or replaces the workspace defaults of a tenant.
+func (r *Repository) UpsertTenantDefaults(ctx context.Context, defaults *entity.TenantWorkspaceDefaults) error {
+	locked := make([]string, len(defaults.Locked))
+	for i, key := range defaults.Locked {
+		locked[i] = string(key)
+	}
+
+	_, err := common.Conn(ctx, r.pool).Exec(ctx, queryUpsertTenantDefaults,
+		defaults.TenantID,
+		defaults.Settings,
+		locked,
+		defaults.UpdatedBy,
+		defaults.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("upserting tenant workspace defaults: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Package eventwebhook implements the outgoing sender of event webhooks: signed JSON
+// POSTs to integrator endpoints.
+package eventwebhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// Request headers set on every delivery.
+const (
+	HeaderEvent     = "X-PdfForge-Event"
+	HeaderDelivery  = "X-PdfForge-Delivery"
+	HeaderSignature = "X-PdfForge-Signature"
+)
+
+var cgnatPrefix = netip.MustParsePrefix("100.64.0.0/10")
+
+// Sender posts deliveries to event webhook URLs.
+type Sender struct {
+	client *http.Client
+}
+
+// New creates a sender. Unless allowPrivateNetworks is set, connections to loopback, private,
+// link-local and other non-public addresses are refused, so workspace admins cannot point the
+// server at internal services. Redirects are never followed.
+func New(timeout time.Duration, allowPrivateNetworks bool) *Sender {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	dialer := &net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	if !allowPrivateNetworks {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			return checkPublicAddress(address)
+		}
+	}
+	transport.DialContext = dialer.DialContext
+
+	return &Sender{client: &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}}
+}
+
+// Send posts the delivery payload to the webhook URL and fails on any non-2xx status.
+func (s *Sender) Send(ctx context.Context, webhook *entity.EventWebhook, delivery *entity.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("building webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "pdf-forge-webhooks")
+	req.Header.Set(HeaderEvent, string(delivery.EventType))
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderSignature, entity.SignWebhookPayload(webhook.Secret, time.Now(), delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("posting webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// checkPublicAddress refuses the resolved address a connection is about to be made to
+// when it is not publicly routable. It runs after DNS resolution, so rebinding cannot bypass it.
+func checkPublicAddress(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("splitting webhook address %q: %w", address, err)
+	}
+	addr, err := netip.ParseAddr(strings.Trim(host, "[]"))
+	if err != nil {
+		return fmt.Errorf("parsing webhook address %q: %w", host, err)
+	}
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() || cgnatPrefix.Contains(addr) {
+		return fmt.Errorf("webhook address %s is not publicly routable", addr)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Package linkchecker implements the publish-time check of the external links
+// of a document with HTTP HEAD requests to allow-listed hosts.
+package linkchecker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+const defaultTimeout = 5 * time.Second
+
+// Checker requests the links of allow-listed hosts and reports the ones that fail.
+type Checker struct {
+	client       *http.Client
+	allowedHosts []string
+}
+
+// New creates a checker requesting only links to allowedHosts or their subdomains.
+func New(allowedHosts []string, timeout time.Duration) *Checker {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	hosts := make([]string, 0, len(allowedHosts))
+	for _, host := range allowedHosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+
+	c := &Checker{allowedHosts: hosts}
+	c.client = &http.Client{
+		Timeout: timeout,
+		// Redirects to hosts outside the allow-list are not followed; the redirect itself
+		// counts as a live link.
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 5 || !c.allowed(req.URL.Hostname()) {
+				return http.ErrUseLastResponse
+			}
+			return nil
+		},
+	}
+	return c
+}
+
+// CheckLink requests url with HEAD, falling back to GET for servers that do not
+// support HEAD, and fails on connection errors and 4xx/5xx statuses.
+func (c *Checker) CheckLink(ctx context.Context, url string) error {
+	parsed, err := neturl.Parse(url)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || !c.allowed(parsed.Hostname()) {
+		return nil
+	}
+
+	status, err := c.request(ctx, http.MethodHead, url)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = c.request(ctx, http.MethodGet, url)
+	}
+	if err != nil {
+		return err
+	}
+	if status >= 400 {
+		return fmt.Errorf("responded with status %d", status)
+	}
+	return nil
+}
+
+// allowed reports whether host is an allowed host or one of their subdomains.
+func (c *Checker) allowed(host string) bool {
+	host = strings.ToLower(host)
+	for _, allowed := range c.allowedHosts {
+		if host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// request sends a bodiless request and returns the final status after redirects.
+func (c *Checker) request(ctx context.Context, method, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("building request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("requesting link: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	return resp.StatusCode, nil
+}
+
+// Ensure Checker implements LinkChecker interface.
+var _ port.LinkChecker = (*Checker)(nil)
@@ -0,0 +1,30 @@
+package objectstorage
+
+import "strings"
+
+const defaultGCSEndpoint = "https://storage.googleapis.com"
+
+// GCSConfig configures a Google Cloud Storage bucket accessed with HMAC keys.
+type GCSConfig struct {
+	Bucket       string
+	HMACAccessID string
+	HMACSecret   string
+	Endpoint     string // Default: https://storage.googleapis.com
+}
+
+// NewGCS creates a Google Cloud Storage storage. It goes through the XML API, which accepts
+// S3-style requests signed with HMAC keys, so it shares the S3 implementation.
+func NewGCS(cfg GCSConfig) (*S3, error) {
+	endpoint := strings.TrimSpace(cfg.Endpoint)
+	if endpoint == "" {
+		endpoint = defaultGCSEndpoint
+	}
+	return newS3(S3Config{
+		Bucket:          cfg.Bucket,
+		Region:          "auto",
+		Endpoint:        endpoint,
+		AccessKeyID:     cfg.HMACAccessID,
+		SecretAccessKey: cfg.HMACSecret,
+		UsePathStyle:    true,
+	}, "gcs")
+}
@@ -0,0 +1,162 @@
+// Package objectstorage implements port.ObjectStorage on local disk, Amazon S3
+// (and S3-compatible services) and Google Cloud Storage.
+package objectstorage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// PublicObjectsPath is the route, relative to the public base URL, that serves local objects.
+const PublicObjectsPath = "/api/v1/public/objects/"
+
+// Local stores objects as files under a directory. Its signed URLs point back at the
+// engine, which serves them after checking an HMAC signature.
+type Local struct {
+	dir        string
+	baseURL    string
+	signingKey []byte
+}
+
+// NewLocal creates a local-disk storage rooted at dir, creating it if needed.
+// publicBaseURL is the externally reachable URL of the engine; signingKey signs the URLs.
+func NewLocal(dir, publicBaseURL, signingKey string) (*Local, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("local object storage: dir is required")
+	}
+	if signingKey == "" {
+		return nil, fmt.Errorf("local object storage: signing key is required")
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("local object storage: creating %s: %w", dir, err)
+	}
+	return &Local{
+		dir:        dir,
+		baseURL:    strings.TrimRight(publicBaseURL, "/"),
+		signingKey: []byte(signingKey),
+	}, nil
+}
+
+// Put writes the object to a temporary file and renames it into place, so readers
+// never see a partial object.
+func (l *Local) Put(_ context.Context, obj *port.StorageObject) error {
+	target, err := l.path(obj.Key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return fmt.Errorf("creating object directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("creating object file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // no-op after rename
+
+	if _, err := io.Copy(tmp, obj.Body); err != nil {
+		tmp.Close() //nolint:errcheck,gosec // write error takes precedence
+		return fmt.Errorf("writing object: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing object: %w", err)
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+// Get opens the object file.
+func (l *Local) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	target, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(target) //nolint:gosec // path is confined to l.dir
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, entity.ErrObjectNotFound
+	}
+	return f, err
+}
+
+// Delete removes the object file.
+func (l *Local) Delete(_ context.Context, key string) error {
+	target, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// SignedURL returns {publicBaseURL}/api/v1/public/objects/{key}?expires=...&signature=...
+func (l *Local) SignedURL(_ context.Context, key string, ttl time.Duration) (string, error) {
+	if _, err := l.path(key); err != nil {
+		return "", err
+	}
+	expires := strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+
+	q := url.Values{}
+	q.Set("expires", expires)
+	q.Set("signature", l.sign(key, expires))
+	return l.baseURL + PublicObjectsPath + escapePath(key) + "?" + q.Encode(), nil
+}
+
+// VerifySignedURL checks that a signed URL for key has not expired and was signed with this storage's key.
+func (l *Local) VerifySignedURL(key, expires, signature string) error {
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > exp {
+		return entity.ErrInvalidSignedURL
+	}
+	if !hmac.Equal([]byte(signature), []byte(l.sign(key, expires))) {
+		return entity.ErrInvalidSignedURL
+	}
+	return nil
+}
+
+func (l *Local) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, l.signingKey)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// path maps a key to a file under l.dir, rejecting keys that would escape it.
+func (l *Local) path(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(key)), nil
+}
+
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") || path.Clean(key) != key ||
+		key == ".." || strings.HasPrefix(key, "../") {
+		return fmt.Errorf("%w: invalid object key %q", entity.ErrObjectNotFound, key)
+	}
+	return nil
+}
+
+// escapePath escapes each segment of a slash-separated key.
+func escapePath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,86 @@
+package objectstorage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+func TestLocalRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocal(t.TempDir(), "https://forge.example.com/", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const key = "renders/t1/w1/doc.pdf"
+	if err := store.Put(ctx, &port.StorageObject{Key: key, Body: strings.NewReader("%PDF")}); err != nil {
+		t.Fatalf("Put() = %v", err)
+	}
+
+	r, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() = %v", err)
+	}
+	data, _ := io.ReadAll(r)
+	r.Close()
+	if string(data) != "%PDF" {
+		t.Errorf("Get() read %q", data)
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() = %v", err)
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		t.Errorf("Delete() of missing key = %v, want nil", err)
+	}
+	if _, err := store.Get(ctx, key); !errors.Is(err, entity.ErrObjectNotFound) {
+		t.Errorf("Get() after delete = %v, want ErrObjectNotFound", err)
+	}
+}
+
+func TestLocalRejectsEscapingKeys(t *testing.T) {
+	store, err := NewLocal(t.TempDir(), "", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"", "../x", "/etc/passwd", "a/../../x", "a//b", `a\b`} {
+		if _, err := store.Get(context.Background(), key); err == nil {
+			t.Errorf("Get(%q) = nil error, want rejection", key)
+		}
+	}
+}
+
+func TestLocalSignedURL(t *testing.T) {
+	store, err := NewLocal(t.TempDir(), "https://forge.example.com/", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := store.SignedURL(context.Background(), "renders/a b.pdf", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse(raw)
+	if u.Path != PublicObjectsPath+"renders/a b.pdf" {
+		t.Errorf("path = %q", u.Path)
+	}
+
+	q := u.Query()
+	if err := store.VerifySignedURL("renders/a b.pdf", q.Get("expires"), q.Get("signature")); err != nil {
+		t.Errorf("VerifySignedURL() = %v, want nil", err)
+	}
+	if err := store.VerifySignedURL("renders/other.pdf", q.Get("expires"), q.Get("signature")); !errors.Is(err, entity.ErrInvalidSignedURL) {
+		t.Errorf("VerifySignedURL() for another key = %v, want ErrInvalidSignedURL", err)
+	}
+	if err := store.VerifySignedURL("renders/a b.pdf", "1", q.Get("signature")); !errors.Is(err, entity.ErrInvalidSignedURL) {
+		t.Errorf("VerifySignedURL() expired = %v, want ErrInvalidSignedURL", err)
+	}
+}
@@ -0,0 +1,186 @@
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+const defaultRequestTimeout = 60 * time.Second
+
+// S3Config configures an Amazon S3 bucket or an S3-compatible service (MinIO, R2, ...).
+type S3Config struct {
+	Bucket          string
+	Region          string
+	Endpoint        string // Default: https://s3.{region}.amazonaws.com
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // Optional, for temporary credentials
+	UsePathStyle    bool   // {endpoint}/{bucket}/{key} instead of {bucket}.{endpoint host}/{key}
+}
+
+// S3 stores objects in an S3 bucket through the REST API, signing requests with Signature Version 4.
+type S3 struct {
+	client    *http.Client
+	endpoint  *url.URL
+	bucket    string
+	pathStyle bool
+	signer    *sigV4Signer
+}
+
+// NewS3 creates an S3 storage from cfg.
+func NewS3(cfg S3Config) (*S3, error) {
+	return newS3(cfg, "s3")
+}
+
+func newS3(cfg S3Config, name string) (*S3, error) {
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("%s object storage: bucket is required", name)
+	}
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("%s object storage: access key and secret are required", name)
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "https://s3." + cfg.Region + ".amazonaws.com"
+	}
+	endpoint, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("%s object storage: invalid endpoint %q", name, cfg.Endpoint)
+	}
+
+	return &S3{
+		client:    &http.Client{Timeout: defaultRequestTimeout},
+		endpoint:  endpoint,
+		bucket:    cfg.Bucket,
+		pathStyle: cfg.UsePathStyle,
+		signer: &sigV4Signer{
+			accessKeyID:     cfg.AccessKeyID,
+			secretAccessKey: cfg.SecretAccessKey,
+			sessionToken:    cfg.SessionToken,
+			region:          cfg.Region,
+			service:         "s3",
+		},
+	}, nil
+}
+
+// Put uploads the object with a single PUT.
+func (s *S3) Put(ctx context.Context, obj *port.StorageObject) error {
+	body, size := obj.Body, obj.Size
+	if size <= 0 {
+		data, err := io.ReadAll(obj.Body)
+		if err != nil {
+			return fmt.Errorf("reading object: %w", err)
+		}
+		body, size = bytes.NewReader(data), int64(len(data))
+	}
+
+	req, err := s.newRequest(ctx, http.MethodPut, obj.Key, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if obj.ContentType != "" {
+		req.Header.Set("Content-Type", obj.ContentType)
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Get downloads the object.
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Delete removes the object.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req)
+	if errors.Is(err, entity.ErrObjectNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// SignedURL returns a presigned GET URL. S3 caps presigned URLs at seven days.
+func (s *S3) SignedURL(_ context.Context, key string, ttl time.Duration) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	if ttl > maxPresignDuration {
+		ttl = maxPresignDuration
+	}
+	return s.signer.presign(http.MethodGet, s.objectURL(key), ttl, time.Now()).String(), nil
+}
+
+func (s *S3) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.objectURL(key).String(), body)
+	if err != nil {
+		return nil, err
+	}
+	s.signer.signRequest(req, unsignedPayload, time.Now())
+	return req, nil
+}
+
+// do sends a signed request and turns non-2xx responses into errors.
+func (s *S3) do(req *http.Request) (*http.Response, error) {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", req.Method, req.URL.Path, err)
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close() //nolint:errcheck // response is discarded
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, entity.ErrObjectNotFound
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return nil, fmt.Errorf("%s %s: status %d: %s", req.Method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(msg)))
+}
+
+func (s *S3) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	p := "/" + key
+	if s.pathStyle {
+		p = "/" + s.bucket + p
+	} else {
+		u.Host = s.bucket + "." + u.Host
+	}
+	u.Path = strings.TrimRight(s.endpoint.Path, "/") + p
+	u.RawPath = canonicalURI(&url.URL{Path: u.Path})
+	return &u
+}
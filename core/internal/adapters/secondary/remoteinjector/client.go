@@ -0,0 +1,38 @@
+// Package remoteinjector calls injectors owned by other services over the remote injector
+// protocol, as JSON over HTTP or as google.protobuf.Struct messages over gRPC. Both transports
+// carry the same documents: the manifest of the service, the injector context of a call and the
+// injector result.
+package remoteinjector
+
+import (
+	"fmt"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// DefaultMaxResponseBytes is the size limit of a response when Options leave it unset.
+const DefaultMaxResponseBytes = 1 << 20
+
+// Options limit the responses of a remote. Zero values take the defaults.
+type Options struct {
+	MaxResponseBytes int
+}
+
+// New returns the client of endpoint for its protocol.
+func New(endpoint entity.RemoteEndpoint, opts Options) (port.RemoteInjectorClient, error) {
+	if err := endpoint.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.MaxResponseBytes <= 0 {
+		opts.MaxResponseBytes = DefaultMaxResponseBytes
+	}
+	switch endpoint.Protocol {
+	case entity.RemoteProtocolGRPC:
+		return newGRPCClient(endpoint, opts)
+	case entity.RemoteProtocolHTTP:
+		return newHTTPClient(endpoint, opts), nil
+	default:
+		return nil, fmt.Errorf("%w: protocol %q", entity.ErrInvalidRemoteInjector, endpoint.Protocol)
+	}
+}
@@ -0,0 +1,188 @@
+package remoteinjector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+const testManifest = `{"injectors":[{"code":"crm_score","label":{"en":"Score"},"dataType":"NUMBER"}]}`
+
+func TestHTTPClient(t *testing.T) {
+	var got entity.RemoteInjectorRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /base/manifest":
+			_, _ = w.Write([]byte(testManifest))
+		case "POST /base/resolve":
+			_ = json.NewDecoder(r.Body).Decode(&got)
+			_, _ = w.Write([]byte(`{"value":42,"dataType":"NUMBER","metadata":{"source":"crm"}}`))
+		case "GET /base/health":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(entity.RemoteEndpoint{
+		Name: "crm", Protocol: entity.RemoteProtocolHTTP, URL: server.URL + "/base/",
+		AuthHeader: "X-Api-Key", AuthToken: "secret",
+	}, Options{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	manifest, err := client.Manifest(ctx)
+	if err != nil || len(manifest.Injectors) != 1 || manifest.Injectors[0].Code != "crm_score" {
+		t.Fatalf("Manifest() = %+v, %v", manifest, err)
+	}
+	res, err := client.Resolve(ctx, &entity.RemoteInjectorRequest{Code: "crm_score", TenantCode: "acme"})
+	if err != nil || res.Value != 42.0 || res.DataType != entity.InjectableDataTypeNumber || res.Metadata["source"] != "crm" {
+		t.Fatalf("Resolve() = %+v, %v", res, err)
+	}
+	if got.Code != "crm_score" || got.TenantCode != "acme" {
+		t.Errorf("the remote received %+v", got)
+	}
+	if err := client.Check(ctx); err != nil {
+		t.Errorf("Check() error = %v", err)
+	}
+}
+
+func TestHTTPClient_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte(`{"value":"` + strings.Repeat("x", 2048) + `"}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(entity.RemoteEndpoint{Name: "crm", Protocol: entity.RemoteProtocolHTTP, URL: server.URL}, Options{MaxResponseBytes: 1024})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Close()
+
+	if err := client.Check(context.Background()); !errors.Is(err, entity.ErrRemoteInjectorFailed) {
+		t.Errorf("Check() error = %v, want ErrRemoteInjectorFailed", err)
+	}
+	if _, err := client.Resolve(context.Background(), &entity.RemoteInjectorRequest{}); !errors.Is(err, entity.ErrRemoteInjectorFailed) {
+		t.Errorf("Resolve() of an oversized response: error = %v, want ErrRemoteInjectorFailed", err)
+	}
+}
+
+func TestNew_InvalidEndpoint(t *testing.T) {
+	tests := map[string]entity.RemoteEndpoint{
+		"bad name":         {Name: "CRM", Protocol: entity.RemoteProtocolHTTP, URL: "http://crm"},
+		"unknown protocol": {Name: "crm", Protocol: "soap", URL: "http://crm"},
+		"relative URL":     {Name: "crm", Protocol: entity.RemoteProtocolHTTP, URL: "/crm"},
+		"no gRPC target":   {Name: "crm", Protocol: entity.RemoteProtocolGRPC},
+		"token, no header": {Name: "crm", Protocol: entity.RemoteProtocolHTTP, URL: "http://crm", AuthToken: "secret"},
+		"FTP URL":          {Name: "crm", Protocol: entity.RemoteProtocolHTTP, URL: "ftp://crm"},
+	}
+	for name, endpoint := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := New(endpoint, Options{}); !errors.Is(err, entity.ErrInvalidRemoteInjector) {
+				t.Errorf("New() error = %v, want ErrInvalidRemoteInjector", err)
+			}
+		})
+	}
+}
+
+// injectorServer implements the RemoteInjector service without generated code.
+type injectorServer struct {
+	token string
+}
+
+func (s *injectorServer) handler(method string) grpc.MethodHandler {
+	return func(_ any, ctx context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if got := md.Get("authorization"); len(got) != 1 || got[0] != s.token {
+			return nil, errors.New("unauthenticated")
+		}
+		in := new(structpb.Struct)
+		if err := dec(in); err != nil {
+			return nil, err
+		}
+		if method == "Manifest" {
+			var fields map[string]any
+			_ = json.Unmarshal([]byte(testManifest), &fields)
+			return structpb.NewStruct(fields)
+		}
+		return structpb.NewStruct(map[string]any{
+			"value":    in.GetFields()["tenantCode"].GetStringValue(),
+			"metadata": map[string]any{"code": in.GetFields()["code"].GetStringValue()},
+		})
+	}
+}
+
+func TestGRPCClient(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	impl := &injectorServer{token: "Bearer secret"}
+	server := grpc.NewServer()
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: ServiceName,
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{
+			{MethodName: "Manifest", Handler: impl.handler("Manifest")},
+			{MethodName: "Resolve", Handler: impl.handler("Resolve")},
+		},
+	}, impl)
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	go func() { _ = server.Serve(lis) }()
+	defer server.Stop()
+
+	client, err := New(entity.RemoteEndpoint{
+		Name: "crm", Protocol: entity.RemoteProtocolGRPC, URL: lis.Addr().String(), Insecure: true,
+		AuthHeader: "Authorization", AuthToken: "Bearer secret",
+	}, Options{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	manifest, err := client.Manifest(ctx)
+	if err != nil || len(manifest.Injectors) != 1 || manifest.Injectors[0].DataType != entity.InjectableDataTypeNumber {
+		t.Fatalf("Manifest() = %+v, %v", manifest, err)
+	}
+	res, err := client.Resolve(ctx, &entity.RemoteInjectorRequest{Code: "crm_score", TenantCode: "acme"})
+	if err != nil || res.Value != "acme" || res.Metadata["code"] != "crm_score" {
+		t.Fatalf("Resolve() = %+v, %v", res, err)
+	}
+	if err := client.Check(ctx); err != nil {
+		t.Errorf("Check() error = %v", err)
+	}
+
+	healthServer.SetServingStatus(ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	if err := client.Check(ctx); !errors.Is(err, entity.ErrRemoteInjectorFailed) {
+		t.Errorf("Check() of a service not serving: error = %v, want ErrRemoteInjectorFailed", err)
+	}
+}
@@ -0,0 +1,138 @@
+package remoteinjector
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// ServiceName is the gRPC service a remote implements:
+//
+//	service RemoteInjector {
+//	  rpc Manifest(google.protobuf.Empty) returns (google.protobuf.Struct);
+//	  rpc Resolve(google.protobuf.Struct) returns (google.protobuf.Struct);
+//	}
+//
+// It is also the service name of its health checks.
+const ServiceName = "pdfforge.injector.v1.RemoteInjector"
+
+const (
+	methodManifest = "/" + ServiceName + "/Manifest"
+	methodResolve  = "/" + ServiceName + "/Resolve"
+)
+
+// grpcClient calls a remote over gRPC.
+type grpcClient struct {
+	endpoint entity.RemoteEndpoint
+	conn     *grpc.ClientConn
+	health   healthpb.HealthClient
+}
+
+func newGRPCClient(endpoint entity.RemoteEndpoint, opts Options) (*grpcClient, error) {
+	creds := credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	if endpoint.Insecure {
+		creds = insecure.NewCredentials()
+	}
+	conn, err := grpc.NewClient(endpoint.URL,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(opts.MaxResponseBytes)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: remote %q: %v", entity.ErrInvalidRemoteInjector, endpoint.Name, err)
+	}
+	return &grpcClient{endpoint: endpoint, conn: conn, health: healthpb.NewHealthClient(conn)}, nil
+}
+
+// Manifest calls RemoteInjector/Manifest.
+func (c *grpcClient) Manifest(ctx context.Context) (*entity.RemoteInjectorManifest, error) {
+	out := new(structpb.Struct)
+	if err := c.conn.Invoke(c.authorize(ctx), methodManifest, &emptypb.Empty{}, out); err != nil {
+		return nil, fmt.Errorf("%w: Manifest: %v", entity.ErrRemoteInjectorFailed, err)
+	}
+	var manifest entity.RemoteInjectorManifest
+	if err := fromStruct(out, &manifest); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// Resolve calls RemoteInjector/Resolve.
+func (c *grpcClient) Resolve(ctx context.Context, req *entity.RemoteInjectorRequest) (*entity.RemoteInjectorResponse, error) {
+	in, err := toStruct(req)
+	if err != nil {
+		return nil, err
+	}
+	out := new(structpb.Struct)
+	if err := c.conn.Invoke(c.authorize(ctx), methodResolve, in, out); err != nil {
+		return nil, fmt.Errorf("%w: Resolve: %v", entity.ErrRemoteInjectorFailed, err)
+	}
+	var res entity.RemoteInjectorResponse
+	if err := fromStruct(out, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Check calls grpc.health.v1.Health/Check for ServiceName.
+func (c *grpcClient) Check(ctx context.Context) error {
+	res, err := c.health.Check(c.authorize(ctx), &healthpb.HealthCheckRequest{Service: ServiceName})
+	if err != nil {
+		return fmt.Errorf("%w: health check: %v", entity.ErrRemoteInjectorFailed, err)
+	}
+	if res.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("%w: health check: %s", entity.ErrRemoteInjectorFailed, res.GetStatus())
+	}
+	return nil
+}
+
+func (c *grpcClient) Close() error { return c.conn.Close() }
+
+// authorize adds the auth header of the remote to the metadata of a call.
+func (c *grpcClient) authorize(ctx context.Context) context.Context {
+	if c.endpoint.AuthToken == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, strings.ToLower(c.endpoint.AuthHeader), c.endpoint.AuthToken)
+}
+
+// toStruct converts v to a Struct through its JSON encoding, so both transports carry the same
+// documents.
+func toStruct(v any) (*structpb.Struct, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: encoding the request: %v", entity.ErrRemoteInjectorFailed, err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("%w: encoding the request: %v", entity.ErrRemoteInjectorFailed, err)
+	}
+	s, err := structpb.NewStruct(fields)
+	if err != nil {
+		return nil, fmt.Errorf("%w: encoding the request: %v", entity.ErrRemoteInjectorFailed, err)
+	}
+	return s, nil
+}
+
+// fromStruct decodes s into out through its JSON encoding.
+func fromStruct(s *structpb.Struct, out any) error {
+	data, err := s.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("%w: decoding the response: %v", entity.ErrRemoteInjectorFailed, err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("%w: decoding the response: %v", entity.ErrRemoteInjectorFailed, err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package remoteinjector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// Paths of the HTTP protocol, relative to the URL of the remote.
+const (
+	pathManifest = "/manifest"
+	pathResolve  = "/resolve"
+	pathHealth   = "/health"
+)
+
+// httpClient calls a remote over JSON and HTTP. Deadlines come from the context of each call.
+type httpClient struct {
+	endpoint entity.RemoteEndpoint
+	baseURL  string
+	opts     Options
+	client   *http.Client
+}
+
+func newHTTPClient(endpoint entity.RemoteEndpoint, opts Options) *httpClient {
+	return &httpClient{
+		endpoint: endpoint,
+		baseURL:  strings.TrimRight(endpoint.URL, "/"),
+		opts:     opts,
+		client:   &http.Client{},
+	}
+}
+
+// Manifest gets the manifest at /manifest.
+func (c *httpClient) Manifest(ctx context.Context) (*entity.RemoteInjectorManifest, error) {
+	var manifest entity.RemoteInjectorManifest
+	if err := c.call(ctx, http.MethodGet, pathManifest, nil, &manifest); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// Resolve posts the request to /resolve.
+func (c *httpClient) Resolve(ctx context.Context, req *entity.RemoteInjectorRequest) (*entity.RemoteInjectorResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: encoding the request: %v", entity.ErrRemoteInjectorFailed, err)
+	}
+	var res entity.RemoteInjectorResponse
+	if err := c.call(ctx, http.MethodPost, pathResolve, body, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Check is healthy when /health answers with a 2xx status.
+func (c *httpClient) Check(ctx context.Context) error {
+	return c.call(ctx, http.MethodGet, pathHealth, nil, nil)
+}
+
+func (c *httpClient) Close() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
+
+// call sends a request to path and decodes the JSON response into out, unless out is nil.
+func (c *httpClient) call(ctx context.Context, method, path string, body []byte, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: %v", entity.ErrRemoteInjectorFailed, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.endpoint.AuthToken != "" {
+		req.Header.Set(c.endpoint.AuthHeader, c.endpoint.AuthToken)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %s %s: %v", entity.ErrRemoteInjectorFailed, method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, int64(c.opts.MaxResponseBytes)+1))
+	if err != nil {
+		return fmt.Errorf("%w: reading %s: %v", entity.ErrRemoteInjectorFailed, path, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%w: %s %s returned %d", entity.ErrRemoteInjectorFailed, method, path, resp.StatusCode)
+	}
+	if len(data) > c.opts.MaxResponseBytes {
+		return fmt.Errorf("%w: response of %s exceeds %d bytes", entity.ErrRemoteInjectorFailed, path, c.opts.MaxResponseBytes)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("%w: decoding the response of %s: %v", entity.ErrRemoteInjectorFailed, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,821 @@
+package starlarkscript
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	starjson "go.starlark.net/lib/json"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+)
+
+// Starlark does not account memory, and a script doubling a string reaches gigabytes in a few
+// dozen steps. Scripts are therefore rewritten before they are compiled: the operators and methods
+// that build strings, bytes and lists become calls of checked builtins, which charge the size of
+// what they build to the allocation budget of the run before building it. The names of the checked
+// builtins start with $, which the Starlark lexer never produces, so scripts cannot shadow them.
+// The built-ins that turn values into text (str, repr, print and the json encoders) are replaced
+// the same way.
+const (
+	builtinAdd     = "$add"
+	builtinMul     = "$mul"
+	builtinMod     = "$mod"
+	builtinGrow    = "$grow" // x += y: charges x + y and returns y, so lists keep growing in place
+	builtinJoin    = "$join"
+	builtinReplace = "$replace"
+	builtinExtend  = "$extend"
+	builtinFormat  = "$format"
+
+	// valueBytes is what an element of a list or tuple is charged, the size of a Starlark value.
+	valueBytes = 16
+
+	budgetKey = "alloc_budget"
+)
+
+// checkedMethods are the methods that build strings or lists of any size from a single call.
+var checkedMethods = map[string]string{
+	"join":    builtinJoin,
+	"replace": builtinReplace,
+	"extend":  builtinExtend,
+	"format":  builtinFormat,
+}
+
+// allocBudget is what a run has allocated through the checked builtins, against its limit.
+type allocBudget struct {
+	used  int64
+	limit int64
+}
+
+func (b *allocBudget) charge(size int64) error {
+	if size > b.limit-b.used {
+		return fmt.Errorf("memory budget of %d bytes exceeded", b.limit)
+	}
+	b.used += size
+	return nil
+}
+
+func charge(thread *starlark.Thread, size int64) error {
+	budget, ok := thread.Local(budgetKey).(*allocBudget)
+	if !ok {
+		return nil
+	}
+	return budget.charge(size)
+}
+
+// remaining is what the run can still allocate. Measuring text stops past it.
+func remaining(thread *starlark.Thread) int64 {
+	budget, ok := thread.Local(budgetKey).(*allocBudget)
+	if !ok {
+		return math.MaxInt64
+	}
+	return budget.limit - budget.used
+}
+
+// collectingBuiltins are the built-ins that build a list or tuple from an iterable in one step,
+// such as list(range(n)). They replace the universal ones and charge the elements first.
+var collectingBuiltins = []string{"list", "tuple", "sorted", "reversed", "enumerate", "zip"}
+
+// allocBuiltins are the checked builtins, the collecting built-ins and the text built-ins.
+func allocBuiltins() starlark.StringDict {
+	env := starlark.StringDict{
+		builtinAdd:     starlark.NewBuiltin(builtinAdd, binaryBuiltin(syntax.PLUS)),
+		builtinMul:     starlark.NewBuiltin(builtinMul, binaryBuiltin(syntax.STAR)),
+		builtinMod:     starlark.NewBuiltin(builtinMod, binaryBuiltin(syntax.PERCENT)),
+		builtinGrow:    starlark.NewBuiltin(builtinGrow, growBuiltin),
+		builtinJoin:    starlark.NewBuiltin(builtinJoin, methodBuiltin("join", joinSize)),
+		builtinReplace: starlark.NewBuiltin(builtinReplace, methodBuiltin("replace", replaceSize)),
+		builtinExtend:  starlark.NewBuiltin(builtinExtend, methodBuiltin("extend", extendSize)),
+		builtinFormat:  starlark.NewBuiltin(builtinFormat, methodBuiltin("format", formatSize)),
+		"str":          starlark.NewBuiltin("str", textBuiltin(starlark.Universe["str"], false)),
+		"repr":         starlark.NewBuiltin("repr", textBuiltin(starlark.Universe["repr"], true)),
+		"print":        starlark.NewBuiltin("print", printBuiltin),
+		"json":         checkedJSON,
+	}
+	for _, name := range collectingBuiltins {
+		env[name] = starlark.NewBuiltin(name, collectingBuiltin(starlark.Universe[name]))
+	}
+	return env
+}
+
+// checkedJSON is the json module with its encoders charging the text they build.
+var checkedJSON = &starlarkstruct.Module{
+	Name: "json",
+	Members: starlark.StringDict{
+		"decode":        starjson.Module.Members["decode"],
+		"encode":        starlark.NewBuiltin("json.encode", jsonBuiltin(starjson.Module.Members["encode"], encodeSize)),
+		"encode_indent": starlark.NewBuiltin("json.encode_indent", jsonBuiltin(starjson.Module.Members["encode_indent"], encodeIndentSize)),
+		"indent":        starlark.NewBuiltin("json.indent", jsonBuiltin(starjson.Module.Members["indent"], indentSize)),
+	},
+}
+
+// binaryBuiltin applies op to its two arguments, after charging what it builds.
+func binaryBuiltin(op syntax.Token) func(*starlark.Thread, *starlark.Builtin, starlark.Tuple, []starlark.Tuple) (starlark.Value, error) {
+	return func(thread *starlark.Thread, _ *starlark.Builtin, args starlark.Tuple, _ []starlark.Tuple) (starlark.Value, error) {
+		x, y := args[0], args[1]
+		size := binarySize(op, x, y)
+		if op == syntax.PERCENT {
+			size = percentSize(x, y, remaining(thread))
+		}
+		if err := charge(thread, size); err != nil {
+			return nil, err
+		}
+		return starlark.Binary(op, x, y)
+	}
+}
+
+func growBuiltin(thread *starlark.Thread, _ *starlark.Builtin, args starlark.Tuple, _ []starlark.Tuple) (starlark.Value, error) {
+	x, y := args[0], args[1]
+	size := binarySize(syntax.PLUS, x, y)
+	if _, ok := x.(*starlark.List); ok {
+		size = elementsSize(y)
+	}
+	return y, charge(thread, size)
+}
+
+// sizeFunc reports what a call builds. Functions measuring text may stop once past limit.
+type sizeFunc func(recv starlark.Value, args starlark.Tuple, kwargs []starlark.Tuple, limit int64) int64
+
+// methodBuiltin calls a method of its first argument with the other arguments, after charging
+// the size size reports for the call.
+func methodBuiltin(name string, size sizeFunc) func(*starlark.Thread, *starlark.Builtin, starlark.Tuple, []starlark.Tuple) (starlark.Value, error) {
+	return func(thread *starlark.Thread, _ *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		recv, args := args[0], args[1:]
+		attrs, ok := recv.(starlark.HasAttrs)
+		if !ok {
+			return nil, fmt.Errorf("%s has no .%s field or method", recv.Type(), name)
+		}
+		method, err := attrs.Attr(name)
+		if err != nil {
+			return nil, err
+		}
+		if method == nil {
+			return nil, fmt.Errorf("%s has no .%s field or method", recv.Type(), name)
+		}
+		if err := charge(thread, size(recv, args, kwargs, remaining(thread))); err != nil {
+			return nil, err
+		}
+		return starlark.Call(thread, method, args, kwargs)
+	}
+}
+
+// textBuiltin calls the universal str or repr after charging the text it builds. str of a
+// string returns the string itself and costs nothing.
+func textBuiltin(universal starlark.Value, repr bool) func(*starlark.Thread, *starlark.Builtin, starlark.Tuple, []starlark.Tuple) (starlark.Value, error) {
+	return func(thread *starlark.Thread, _ *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		if len(args) == 1 {
+			if _, ok := args[0].(starlark.String); !ok || repr {
+				if err := charge(thread, textSize(args[0], repr, remaining(thread))); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return starlark.Call(thread, universal, args, kwargs)
+	}
+}
+
+// printBuiltin calls the universal print after charging the line it builds.
+func printBuiltin(thread *starlark.Thread, _ *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	sep := int64(1)
+	for _, kwarg := range kwargs {
+		if s, ok := kwarg[1].(starlark.String); ok && kwarg[0] == starlark.String("sep") {
+			sep = int64(len(s))
+		}
+	}
+	limit := remaining(thread)
+	var size int64
+	for i, arg := range args {
+		if i > 0 {
+			size += sep
+		}
+		size += textSize(arg, false, limit-size)
+		if size > limit {
+			break
+		}
+	}
+	if err := charge(thread, size); err != nil {
+		return nil, err
+	}
+	return starlark.Call(thread, starlark.Universe["print"], args, kwargs)
+}
+
+// jsonBuiltin calls a function of the json module after charging the text it builds.
+func jsonBuiltin(fn starlark.Value, size sizeFunc) func(*starlark.Thread, *starlark.Builtin, starlark.Tuple, []starlark.Tuple) (starlark.Value, error) {
+	return func(thread *starlark.Thread, _ *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		if len(args) == 1 {
+			if err := charge(thread, size(nil, args, kwargs, remaining(thread))); err != nil {
+				return nil, err
+			}
+		}
+		return starlark.Call(thread, fn, args, kwargs)
+	}
+}
+
+// collectingBuiltin calls the universal built-in after charging the elements of its arguments.
+// Ranges are lazy, so looping over a long range costs nothing until it is collected.
+func collectingBuiltin(universal starlark.Value) func(*starlark.Thread, *starlark.Builtin, starlark.Tuple, []starlark.Tuple) (starlark.Value, error) {
+	return func(thread *starlark.Thread, _ *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var size int64
+		for _, arg := range args {
+			size += elementsSize(arg)
+		}
+		if err := charge(thread, size); err != nil {
+			return nil, err
+		}
+		return starlark.Call(thread, universal, args, kwargs)
+	}
+}
+
+// binarySize is the size of x op y, or 0 when it builds nothing of a size the budget tracks or
+// the operation fails anyway.
+func binarySize(op syntax.Token, x, y starlark.Value) int64 {
+	switch op {
+	case syntax.PLUS:
+		if sameSequence(x, y) {
+			return sizeOf(x) + sizeOf(y)
+		}
+	case syntax.STAR:
+		if _, ok := x.(starlark.Int); ok {
+			x, y = y, x
+		}
+		n, err := starlark.AsInt32(y)
+		if err != nil || n < 1 {
+			return 0
+		}
+		return sizeOf(x) * int64(n)
+	}
+	return 0
+}
+
+func sameSequence(x, y starlark.Value) bool {
+	switch x.(type) {
+	case starlark.String:
+		_, ok := y.(starlark.String)
+		return ok
+	case starlark.Bytes:
+		_, ok := y.(starlark.Bytes)
+		return ok
+	case *starlark.List:
+		_, ok := y.(*starlark.List)
+		return ok
+	case starlark.Tuple:
+		_, ok := y.(starlark.Tuple)
+		return ok
+	}
+	return false
+}
+
+// sizeOf is what the budget charges for v: its bytes for strings and bytes, valueBytes per element
+// for lists and tuples, and nothing for other values.
+func sizeOf(v starlark.Value) int64 {
+	switch v := v.(type) {
+	case starlark.String:
+		return int64(len(v))
+	case starlark.Bytes:
+		return int64(len(v))
+	case *starlark.List, starlark.Tuple:
+		return elementsSize(v)
+	}
+	return 0
+}
+
+func elementsSize(v starlark.Value) int64 {
+	if n := starlark.Len(v); n > 0 {
+		return int64(n) * valueBytes
+	}
+	return 0
+}
+
+// joinSize is the length of sep.join(items) when items is a sequence of strings.
+func joinSize(sep starlark.Value, args starlark.Tuple, _ []starlark.Tuple, _ int64) int64 {
+	s, ok := sep.(starlark.String)
+	if !ok || len(args) != 1 {
+		return 0
+	}
+	items, ok := args[0].(starlark.Iterable)
+	if !ok {
+		return 0
+	}
+	var size, n int64
+	iter := items.Iterate()
+	defer iter.Done()
+	var item starlark.Value
+	for iter.Next(&item) {
+		if str, ok := item.(starlark.String); ok {
+			size += int64(len(str))
+		}
+		n++
+	}
+	if n > 1 {
+		size += int64(len(s)) * (n - 1)
+	}
+	return size
+}
+
+// replaceSize is the length of s.replace(old, new[, count]).
+func replaceSize(recv starlark.Value, args starlark.Tuple, _ []starlark.Tuple, _ int64) int64 {
+	s, ok := recv.(starlark.String)
+	if !ok || len(args) < 2 {
+		return 0
+	}
+	old, ok1 := args[0].(starlark.String)
+	repl, ok2 := args[1].(starlark.String)
+	if !ok1 || !ok2 {
+		return 0
+	}
+	var n int
+	if old == "" {
+		n = utf8.RuneCountInString(string(s)) + 1
+	} else {
+		n = strings.Count(string(s), string(old))
+	}
+	if len(args) > 2 {
+		if limit, err := starlark.AsInt32(args[2]); err == nil && limit >= 0 && limit < n {
+			n = limit
+		}
+	}
+	return int64(len(s)) + int64(n)*int64(len(repl)-len(old))
+}
+
+// extendSize is what list.extend(items) adds to the list.
+func extendSize(_ starlark.Value, args starlark.Tuple, _ []starlark.Tuple, _ int64) int64 {
+	if len(args) != 1 {
+		return 0
+	}
+	return elementsSize(args[0])
+}
+
+// formatSize is the length of s.format(*args, **kwargs): s and the text of every field.
+func formatSize(recv starlark.Value, args starlark.Tuple, kwargs []starlark.Tuple, limit int64) int64 {
+	s, ok := recv.(starlark.String)
+	if !ok {
+		return 0
+	}
+	size := int64(len(s))
+	auto := 0
+	for rest := string(s); size <= limit; {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 || start+1 >= len(rest) {
+			break
+		}
+		if rest[start+1] == '{' {
+			rest = rest[start+2:]
+			continue
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			break
+		}
+		field := rest[start+1 : start+end]
+		rest = rest[start+end+1:]
+
+		repr := false
+		if i := strings.IndexByte(field, '!'); i >= 0 {
+			repr = strings.HasPrefix(field[i+1:], "r")
+			field = field[:i]
+		}
+		var v starlark.Value
+		if field == "" {
+			if auto < len(args) {
+				v = args[auto]
+			}
+			auto++
+		} else if n, err := strconv.Atoi(field); err == nil {
+			if n >= 0 && n < len(args) {
+				v = args[n]
+			}
+		} else {
+			for _, kwarg := range kwargs {
+				if kwarg[0] == starlark.String(field) {
+					v = kwarg[1]
+				}
+			}
+		}
+		if v != nil {
+			size += textSize(v, repr, limit-size)
+		}
+	}
+	return size
+}
+
+// percentSize is the length of s % y: s and the text of every conversion.
+func percentSize(x, y starlark.Value, limit int64) int64 {
+	s, ok := x.(starlark.String)
+	if !ok {
+		return 0
+	}
+	args, ok := y.(starlark.Tuple)
+	if !ok {
+		args = starlark.Tuple{y}
+	}
+	mapping, _ := y.(starlark.Mapping)
+
+	size := int64(len(s))
+	next := 0
+	for rest := string(s); size <= limit; {
+		i := strings.IndexByte(rest, '%')
+		if i < 0 || i+1 >= len(rest) {
+			break
+		}
+		rest = rest[i+1:]
+		var v starlark.Value
+		switch rest[0] {
+		case '%':
+			rest = rest[1:]
+			continue
+		case '(':
+			end := strings.IndexByte(rest, ')')
+			if end < 0 || mapping == nil {
+				return size
+			}
+			v, _, _ = mapping.Get(starlark.String(rest[1:end]))
+			rest = rest[end+1:]
+		default:
+			if next < len(args) {
+				v = args[next]
+			}
+			next++
+		}
+		if rest == "" {
+			break
+		}
+		if v != nil {
+			size += textSize(v, rest[0] == 'r', limit-size)
+		}
+	}
+	return size
+}
+
+// encodeSize is the length of json.encode(x), at most that of repr(x).
+func encodeSize(_ starlark.Value, args starlark.Tuple, _ []starlark.Tuple, limit int64) int64 {
+	return textSize(args[0], true, limit)
+}
+
+// encodeIndentSize is the length of json.encode_indent(x), which encodes x and then indents it.
+func encodeIndentSize(_ starlark.Value, args starlark.Tuple, kwargs []starlark.Tuple, limit int64) int64 {
+	prefix, indent := indentOptions(kwargs)
+	m := &textMeasure{limit: limit, indented: true, prefix: prefix, indent: indent}
+	m.value(args[0], 0)
+	return m.size + textSize(args[0], true, limit-m.size)
+}
+
+// indentSize is the length of json.indent(s): s with a line per element, for any JSON text.
+func indentSize(_ starlark.Value, args starlark.Tuple, kwargs []starlark.Tuple, _ int64) int64 {
+	s, ok := args[0].(starlark.String)
+	if !ok {
+		return 0
+	}
+	prefix, indent := indentOptions(kwargs)
+	size := int64(len(s))
+	var depth int64
+	inString := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if inString {
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case '[', '{':
+			depth++
+			size += 1 + prefix + indent*depth
+		case ',':
+			size += 1 + prefix + indent*depth
+		case ']', '}':
+			depth--
+			size += 1 + prefix + indent*max(depth, 0)
+		case ':':
+			size++
+		}
+	}
+	return size
+}
+
+// indentOptions returns the lengths of the prefix and indent keywords of the json encoders.
+func indentOptions(kwargs []starlark.Tuple) (prefix, indent int64) {
+	indent = 1 // "\t"
+	for _, kwarg := range kwargs {
+		s, ok := kwarg[1].(starlark.String)
+		if !ok {
+			continue
+		}
+		switch kwarg[0] {
+		case starlark.String("prefix"):
+			prefix = int64(len(s))
+		case starlark.String("indent"):
+			indent = int64(len(s))
+		}
+	}
+	return prefix, indent
+}
+
+// textSize is the length of str(v), or of repr(v) when repr is set, measured without building
+// it. Escapes in strings are not counted. Measuring stops once past limit, so values sharing
+// their elements cannot make it walk more than limit bytes of text.
+func textSize(v starlark.Value, repr bool, limit int64) int64 {
+	if s, ok := v.(starlark.String); ok && !repr {
+		return int64(len(s))
+	}
+	m := &textMeasure{limit: limit}
+	m.value(v, 0)
+	return m.size
+}
+
+// textMeasure adds up the text of a value like Starlark writes it. With indented, it counts the
+// line breaks json.encode_indent adds instead.
+type textMeasure struct {
+	size     int64
+	limit    int64
+	indented bool
+	prefix   int64
+	indent   int64
+	path     []starlark.Value // Lists and dicts being measured; cycles are written as [...]
+}
+
+func (m *textMeasure) add(n int64) bool {
+	m.size += n
+	return m.size <= m.limit
+}
+
+func (m *textMeasure) value(v starlark.Value, depth int64) bool {
+	if m.indented {
+		return m.lines(v, depth)
+	}
+	switch v := v.(type) {
+	case starlark.String:
+		return m.add(int64(len(v)) + 2)
+	case starlark.Bytes:
+		return m.add(int64(len(v)) + 3)
+	case *starlark.List:
+		return m.container(v, depth, v.Len(), func(i int) bool { return m.value(v.Index(i), depth+1) })
+	case starlark.Tuple:
+		if len(v) == 1 && !m.add(1) {
+			return false
+		}
+		return m.container(v, depth, len(v), func(i int) bool { return m.value(v[i], depth+1) })
+	case *starlark.Dict:
+		keys := v.Keys()
+		return m.container(v, depth, len(keys), func(i int) bool {
+			value, _, _ := v.Get(keys[i])
+			return m.value(keys[i], depth+1) && m.add(2) && m.value(value, depth+1)
+		})
+	case *starlark.Set:
+		elems := setElems(v)
+		return m.add(int64(len("set()"))) &&
+			m.container(v, depth, len(elems), func(i int) bool { return m.value(elems[i], depth+1) })
+	case *starlarkstruct.Struct:
+		names := v.AttrNames()
+		if !m.add(int64(len("struct"))) {
+			return false
+		}
+		return m.container(v, depth, len(names), func(i int) bool {
+			field, _ := v.Attr(names[i])
+			return m.add(int64(len(names[i]))+3) && m.value(field, depth+1)
+		})
+	}
+	return m.add(int64(len(v.String())))
+}
+
+// container adds the brackets and separators of n elements, and the elements themselves.
+func (m *textMeasure) container(v starlark.Value, depth int64, n int, elem func(i int) bool) bool {
+	if m.enter(v) {
+		return m.add(int64(len("[...]")))
+	}
+	defer m.leave(v)
+
+	if !m.add(2) {
+		return false
+	}
+	for i := range n {
+		if i > 0 && !m.add(2) {
+			return false
+		}
+		if !elem(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// lines adds the line breaks, prefixes and indentation of v and its elements in indented JSON.
+func (m *textMeasure) lines(v starlark.Value, depth int64) bool {
+	var elems []starlark.Value
+	switch v := v.(type) {
+	case *starlark.List:
+		for i := range v.Len() {
+			elems = append(elems, v.Index(i))
+		}
+	case starlark.Tuple:
+		elems = v
+	case *starlark.Dict:
+		for _, item := range v.Items() {
+			elems = append(elems, item[1])
+		}
+		if !m.add(int64(len(elems))) { // ": " instead of ":"
+			return false
+		}
+	case *starlarkstruct.Struct:
+		for _, name := range v.AttrNames() {
+			field, _ := v.Attr(name)
+			elems = append(elems, field)
+		}
+	default:
+		return true
+	}
+	if m.enter(v) {
+		return true // json.encode fails on cycles
+	}
+	defer m.leave(v)
+
+	line := 1 + m.prefix + m.indent*(depth+1)
+	for _, elem := range elems {
+		if !m.add(line) || !m.lines(elem, depth+1) {
+			return false
+		}
+	}
+	return len(elems) == 0 || m.add(1+m.prefix+m.indent*depth)
+}
+
+// enter adds v to the path of measured values and reports whether it was already on it. Only
+// lists and dicts can contain themselves.
+func (m *textMeasure) enter(v starlark.Value) bool {
+	switch v.(type) {
+	case *starlark.List, *starlark.Dict:
+	default:
+		return false
+	}
+	for _, p := range m.path {
+		if p == v {
+			return true
+		}
+	}
+	m.path = append(m.path, v)
+	return false
+}
+
+func (m *textMeasure) leave(v starlark.Value) {
+	if n := len(m.path); n > 0 && m.path[n-1] == v {
+		m.path = m.path[:n-1]
+	}
+}
+
+func setElems(s *starlark.Set) []starlark.Value {
+	elems := make([]starlark.Value, 0, s.Len())
+	iter := s.Iterate()
+	defer iter.Done()
+	var x starlark.Value
+	for iter.Next(&x) {
+		elems = append(elems, x)
+	}
+	return elems
+}
+
+// limitAllocations rewrites the statements of file to build strings, bytes and lists through the
+// checked builtins. Nodes are copied rather than changed, so an expression can be used twice.
+func limitAllocations(file *syntax.File) {
+	file.Stmts = rewriteStmts(file.Stmts)
+}
+
+func rewriteStmts(stmts []syntax.Stmt) []syntax.Stmt {
+	if stmts == nil {
+		return nil
+	}
+	out := make([]syntax.Stmt, len(stmts))
+	for i, stmt := range stmts {
+		out[i] = rewriteStmt(stmt)
+	}
+	return out
+}
+
+func rewriteStmt(stmt syntax.Stmt) syntax.Stmt {
+	switch s := stmt.(type) {
+	case *syntax.AssignStmt:
+		return rewriteAssign(s)
+	case *syntax.DefStmt:
+		return &syntax.DefStmt{Def: s.Def, Name: copyIdent(s.Name), Lparen: s.Lparen, Params: rewriteExprs(s.Params), Rparen: s.Rparen, Body: rewriteStmts(s.Body)}
+	case *syntax.ExprStmt:
+		return &syntax.ExprStmt{X: rewriteExpr(s.X)}
+	case *syntax.IfStmt:
+		return &syntax.IfStmt{If: s.If, Cond: rewriteExpr(s.Cond), True: rewriteStmts(s.True), ElsePos: s.ElsePos, False: rewriteStmts(s.False)}
+	case *syntax.ForStmt:
+		return &syntax.ForStmt{For: s.For, Vars: rewriteExpr(s.Vars), X: rewriteExpr(s.X), Body: rewriteStmts(s.Body)}
+	case *syntax.WhileStmt:
+		return &syntax.WhileStmt{While: s.While, Cond: rewriteExpr(s.Cond), Body: rewriteStmts(s.Body)}
+	case *syntax.ReturnStmt:
+		return &syntax.ReturnStmt{Return: s.Return, Result: rewriteExpr(s.Result)}
+	case *syntax.BranchStmt:
+		return &syntax.BranchStmt{Token: s.Token, TokenPos: s.TokenPos}
+	}
+	// Load statements are refused before the rewrite
+	return stmt
+}
+
+// rewriteAssign rewrites x += y to x += $grow(x, y), and x *= y and x %= y to x = $mul(x, y) and
+// x = $mod(x, y), which is what they do. Either way x is evaluated twice.
+func rewriteAssign(s *syntax.AssignStmt) syntax.Stmt {
+	switch s.Op {
+	case syntax.PLUS_EQ:
+		rhs := call(builtinGrow, s.OpPos, rewriteExpr(s.LHS), rewriteExpr(s.RHS))
+		return &syntax.AssignStmt{OpPos: s.OpPos, Op: s.Op, LHS: rewriteExpr(s.LHS), RHS: rhs}
+	case syntax.STAR_EQ, syntax.PERCENT_EQ:
+		name := builtinMul
+		if s.Op == syntax.PERCENT_EQ {
+			name = builtinMod
+		}
+		rhs := call(name, s.OpPos, rewriteExpr(s.LHS), rewriteExpr(s.RHS))
+		return &syntax.AssignStmt{OpPos: s.OpPos, Op: syntax.EQ, LHS: rewriteExpr(s.LHS), RHS: rhs}
+	}
+	return &syntax.AssignStmt{OpPos: s.OpPos, Op: s.Op, LHS: rewriteExpr(s.LHS), RHS: rewriteExpr(s.RHS)}
+}
+
+func rewriteExprs(exprs []syntax.Expr) []syntax.Expr {
+	if exprs == nil {
+		return nil
+	}
+	out := make([]syntax.Expr, len(exprs))
+	for i, e := range exprs {
+		out[i] = rewriteExpr(e)
+	}
+	return out
+}
+
+func rewriteExpr(expr syntax.Expr) syntax.Expr {
+	switch e := expr.(type) {
+	case nil:
+		return nil
+	case *syntax.Ident:
+		return copyIdent(e)
+	case *syntax.Literal:
+		return &syntax.Literal{Token: e.Token, TokenPos: e.TokenPos, Raw: e.Raw, Value: e.Value}
+	case *syntax.BinaryExpr:
+		switch e.Op {
+		case syntax.PLUS:
+			return call(builtinAdd, e.OpPos, rewriteExpr(e.X), rewriteExpr(e.Y))
+		case syntax.STAR:
+			return call(builtinMul, e.OpPos, rewriteExpr(e.X), rewriteExpr(e.Y))
+		case syntax.PERCENT:
+			return call(builtinMod, e.OpPos, rewriteExpr(e.X), rewriteExpr(e.Y))
+		}
+		return &syntax.BinaryExpr{X: rewriteExpr(e.X), OpPos: e.OpPos, Op: e.Op, Y: rewriteExpr(e.Y)}
+	case *syntax.CallExpr:
+		if dot, ok := e.Fn.(*syntax.DotExpr); ok {
+			if name, ok := checkedMethods[dot.Name.Name]; ok {
+				args := append([]syntax.Expr{rewriteExpr(dot.X)}, rewriteExprs(e.Args)...)
+				return &syntax.CallExpr{Fn: &syntax.Ident{NamePos: dot.NamePos, Name: name}, Lparen: e.Lparen, Args: args, Rparen: e.Rparen}
+			}
+		}
+		return &syntax.CallExpr{Fn: rewriteExpr(e.Fn), Lparen: e.Lparen, Args: rewriteExprs(e.Args), Rparen: e.Rparen}
+	case *syntax.UnaryExpr:
+		return &syntax.UnaryExpr{OpPos: e.OpPos, Op: e.Op, X: rewriteExpr(e.X)}
+	case *syntax.ParenExpr:
+		return &syntax.ParenExpr{Lparen: e.Lparen, X: rewriteExpr(e.X), Rparen: e.Rparen}
+	case *syntax.DotExpr:
+		return &syntax.DotExpr{X: rewriteExpr(e.X), Dot: e.Dot, NamePos: e.NamePos, Name: copyIdent(e.Name)}
+	case *syntax.IndexExpr:
+		return &syntax.IndexExpr{X: rewriteExpr(e.X), Lbrack: e.Lbrack, Y: rewriteExpr(e.Y), Rbrack: e.Rbrack}
+	case *syntax.SliceExpr:
+		return &syntax.SliceExpr{X: rewriteExpr(e.X), Lbrack: e.Lbrack, Lo: rewriteExpr(e.Lo), Hi: rewriteExpr(e.Hi), Step: rewriteExpr(e.Step), Rbrack: e.Rbrack}
+	case *syntax.ListExpr:
+		return &syntax.ListExpr{Lbrack: e.Lbrack, List: rewriteExprs(e.List), Rbrack: e.Rbrack}
+	case *syntax.TupleExpr:
+		return &syntax.TupleExpr{Lparen: e.Lparen, List: rewriteExprs(e.List), Rparen: e.Rparen}
+	case *syntax.DictExpr:
+		return &syntax.DictExpr{Lbrace: e.Lbrace, List: rewriteExprs(e.List), Rbrace: e.Rbrace}
+	case *syntax.DictEntry:
+		return &syntax.DictEntry{Key: rewriteExpr(e.Key), Colon: e.Colon, Value: rewriteExpr(e.Value)}
+	case *syntax.CondExpr:
+		return &syntax.CondExpr{If: e.If, Cond: rewriteExpr(e.Cond), True: rewriteExpr(e.True), ElsePos: e.ElsePos, False: rewriteExpr(e.False)}
+	case *syntax.LambdaExpr:
+		return &syntax.LambdaExpr{Lambda: e.Lambda, Params: rewriteExprs(e.Params), Body: rewriteExpr(e.Body)}
+	case *syntax.Comprehension:
+		clauses := make([]syntax.Node, len(e.Clauses))
+		for i, clause := range e.Clauses {
+			switch c := clause.(type) {
+			case *syntax.ForClause:
+				clauses[i] = &syntax.ForClause{For: c.For, Vars: rewriteExpr(c.Vars), In: c.In, X: rewriteExpr(c.X)}
+			case *syntax.IfClause:
+				clauses[i] = &syntax.IfClause{If: c.If, Cond: rewriteExpr(c.Cond)}
+			}
+		}
+		return &syntax.Comprehension{Curly: e.Curly, Lbrack: e.Lbrack, Body: rewriteExpr(e.Body), Clauses: clauses, Rbrack: e.Rbrack}
+	}
+	return expr
+}
+
+func copyIdent(id *syntax.Ident) *syntax.Ident {
+	return &syntax.Ident{NamePos: id.NamePos, Name: id.Name}
+}
+
+// call is the call of a checked builtin at pos.
+func call(name string, pos syntax.Position, args ...syntax.Expr) *syntax.CallExpr {
+	return &syntax.CallExpr{Fn: &syntax.Ident{NamePos: pos, Name: name}, Lparen: pos, Args: args, Rparen: pos}
+}
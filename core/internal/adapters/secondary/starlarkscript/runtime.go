@@ -0,0 +1,256 @@
+// Package starlarkscript runs the scripts of scripted injectors in a sandboxed Starlark
+// interpreter: no file, network or clock access, a step budget, an allocation budget and a
+// deadline per run.
+package starlarkscript
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	starjson "go.starlark.net/lib/json"
+	"go.starlark.net/lib/math"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// Defaults of the runtime limits.
+const (
+	DefaultMaxSteps       = 1_000_000
+	DefaultMaxSourceBytes = 64 << 10
+	DefaultMaxResultBytes = 256 << 10
+	DefaultMaxAllocBytes  = 64 << 20
+	maxOutputLines        = 50
+	resolveFunc           = "resolve"
+)
+
+// Options limit the scripts of the runtime. Zero values take the defaults.
+type Options struct {
+	MaxSteps       uint64 // Execution steps per run, the CPU budget
+	MaxSourceBytes int    // Size of a script
+	MaxResultBytes int    // Size of the value returned by a run, encoded as JSON
+	MaxAllocBytes  int64  // Strings, bytes and lists a run may build, the memory budget
+}
+
+// Runtime compiles Starlark scripts.
+type Runtime struct {
+	opts Options
+}
+
+// New creates a runtime with the given limits.
+func New(opts Options) *Runtime {
+	if opts.MaxSteps == 0 {
+		opts.MaxSteps = DefaultMaxSteps
+	}
+	if opts.MaxSourceBytes <= 0 {
+		opts.MaxSourceBytes = DefaultMaxSourceBytes
+	}
+	if opts.MaxResultBytes <= 0 {
+		opts.MaxResultBytes = DefaultMaxResultBytes
+	}
+	if opts.MaxAllocBytes <= 0 {
+		opts.MaxAllocBytes = DefaultMaxAllocBytes
+	}
+	return &Runtime{opts: opts}
+}
+
+// predeclared lists the names scripts can use besides the Starlark built-ins, and the checked
+// builtins of the allocation budget.
+var predeclared = map[string]bool{
+	"json": true, "math": true, "fetch": true,
+	builtinAdd: true, builtinMul: true, builtinMod: true, builtinGrow: true,
+	builtinJoin: true, builtinReplace: true, builtinExtend: true, builtinFormat: true,
+	"str": true, "repr": true, "print": true,
+	"list": true, "tuple": true, "sorted": true, "reversed": true, "enumerate": true, "zip": true,
+}
+
+// Compile parses source and checks that it defines resolve(ctx).
+func (r *Runtime) Compile(source string) (port.CompiledScript, error) {
+	if len(source) > r.opts.MaxSourceBytes {
+		return nil, fmt.Errorf("%w: script is larger than %d bytes", entity.ErrInvalidScriptedInjector, r.opts.MaxSourceBytes)
+	}
+	file, err := (&syntax.FileOptions{}).Parse("script.star", source, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", entity.ErrInvalidScriptedInjector, err)
+	}
+	for _, stmt := range file.Stmts {
+		if _, ok := stmt.(*syntax.LoadStmt); ok {
+			return nil, fmt.Errorf("%w: load is not allowed", entity.ErrInvalidScriptedInjector)
+		}
+	}
+	if !definesResolve(file) {
+		return nil, fmt.Errorf("%w: script must define %s(ctx)", entity.ErrInvalidScriptedInjector, resolveFunc)
+	}
+	limitAllocations(file)
+	prog, err := starlark.FileProgram(file, func(name string) bool {
+		return predeclared[name]
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", entity.ErrInvalidScriptedInjector, err)
+	}
+	return &script{prog: prog, opts: r.opts}, nil
+}
+
+func definesResolve(file *syntax.File) bool {
+	for _, stmt := range file.Stmts {
+		if def, ok := stmt.(*syntax.DefStmt); ok && def.Name.Name == resolveFunc && len(def.Params) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// script is a compiled script. Every run initializes its own globals.
+type script struct {
+	prog *starlark.Program
+	opts Options
+}
+
+// Run calls resolve(ctx) with input.
+func (s *script) Run(ctx context.Context, input *entity.ScriptInput, fetch port.ScriptFetchFunc) (*entity.ScriptRun, error) {
+	run := &entity.ScriptRun{}
+	thread := &starlark.Thread{
+		Name: "scripted-injector",
+		Print: func(_ *starlark.Thread, msg string) {
+			if len(run.Output) < maxOutputLines {
+				run.Output = append(run.Output, msg)
+			}
+		},
+		Load: func(*starlark.Thread, string) (starlark.StringDict, error) {
+			return nil, errors.New("load is not allowed")
+		},
+	}
+	thread.SetMaxExecutionSteps(s.opts.MaxSteps)
+	thread.SetLocal(budgetKey, &allocBudget{limit: s.opts.MaxAllocBytes})
+	stop := context.AfterFunc(ctx, func() { thread.Cancel(ctx.Err().Error()) })
+	defer stop()
+
+	value, err := s.call(ctx, thread, input, fetch)
+	run.Steps = thread.ExecutionSteps()
+	if err != nil {
+		return run, fmt.Errorf("%w: %s", entity.ErrScriptFailed, scriptError(err))
+	}
+
+	// Values sharing their elements encode to far more than they hold; measure before encoding.
+	// The measure counts the spaces of repr, so it only screens results twice too large.
+	if limit := 2 * int64(s.opts.MaxResultBytes); textSize(value, true, limit) > limit {
+		return run, fmt.Errorf("%w: result is larger than %d bytes", entity.ErrScriptFailed, s.opts.MaxResultBytes)
+	}
+	encoded, err := encode(thread, value)
+	if err != nil {
+		return run, fmt.Errorf("%w: resolve returned %s, which is not JSON-like: %v", entity.ErrScriptFailed, value.Type(), err)
+	}
+	if len(encoded) > s.opts.MaxResultBytes {
+		return run, fmt.Errorf("%w: result is larger than %d bytes", entity.ErrScriptFailed, s.opts.MaxResultBytes)
+	}
+	if err := json.Unmarshal([]byte(encoded), &run.Value); err != nil {
+		return run, fmt.Errorf("%w: decoding result: %v", entity.ErrScriptFailed, err)
+	}
+	return run, nil
+}
+
+func (s *script) call(ctx context.Context, thread *starlark.Thread, input *entity.ScriptInput, fetch port.ScriptFetchFunc) (starlark.Value, error) {
+	env := allocBuiltins()
+	env["math"] = math.Module
+	env["fetch"] = fetchBuiltin(ctx, fetch)
+	globals, err := s.prog.Init(thread, env)
+	if err != nil {
+		return nil, err
+	}
+	resolve, ok := globals[resolveFunc].(starlark.Callable)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a function", resolveFunc)
+	}
+
+	arg, err := inputStruct(thread, input)
+	if err != nil {
+		return nil, err
+	}
+	return starlark.Call(thread, resolve, starlark.Tuple{arg}, nil)
+}
+
+// inputStruct builds the ctx argument of resolve.
+func inputStruct(thread *starlark.Thread, input *entity.ScriptInput) (starlark.Value, error) {
+	payload, err := decode(thread, input.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("converting the payload: %w", err)
+	}
+	resolved, err := decode(thread, input.Resolved)
+	if err != nil {
+		return nil, fmt.Errorf("converting the dependencies: %w", err)
+	}
+	headers := starlark.NewDict(len(input.Headers))
+	for key, value := range input.Headers {
+		_ = headers.SetKey(starlark.String(strings.ToLower(key)), starlark.String(value))
+	}
+	return starlarkstruct.FromStringDict(starlark.String("ctx"), starlark.StringDict{
+		"payload":         payload,
+		"resolved":        resolved,
+		"tenant_code":     starlark.String(input.TenantCode),
+		"workspace_code":  starlark.String(input.WorkspaceCode),
+		"environment":     starlark.String(input.Environment),
+		"selected_format": starlark.String(input.SelectedFormat),
+		"headers":         headers,
+	}), nil
+}
+
+// fetchBuiltin is fetch(code): the value of code from the workspace provider, or None.
+func fetchBuiltin(ctx context.Context, fetch port.ScriptFetchFunc) *starlark.Builtin {
+	return starlark.NewBuiltin("fetch", func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var code string
+		if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &code); err != nil {
+			return nil, err
+		}
+		if fetch == nil {
+			return nil, errors.New("fetch: no workspace provider is registered")
+		}
+		value, err := fetch(ctx, code)
+		if err != nil {
+			return nil, fmt.Errorf("fetch %q: %w", code, err)
+		}
+		return decode(thread, value)
+	})
+}
+
+// decode converts a Go value to Starlark through JSON.
+func decode(thread *starlark.Thread, value any) (starlark.Value, error) {
+	if value == nil {
+		return starlark.None, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return starlark.Call(thread, starjson.Module.Members["decode"], starlark.Tuple{starlark.String(data)}, nil)
+}
+
+// encode converts a Starlark value to JSON.
+func encode(thread *starlark.Thread, value starlark.Value) (string, error) {
+	encoded, err := starlark.Call(thread, starjson.Module.Members["encode"], starlark.Tuple{value}, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded.(starlark.String)), nil
+}
+
+// scriptError returns the message of a failed run with the line it failed at.
+func scriptError(err error) string {
+	var evalErr *starlark.EvalError
+	if errors.As(err, &evalErr) && len(evalErr.CallStack) > 0 {
+		frame := evalErr.CallStack[len(evalErr.CallStack)-1]
+		if frame.Pos.Line > 0 {
+			return fmt.Sprintf("line %d: %s", frame.Pos.Line, evalErr.Msg)
+		}
+		return evalErr.Msg
+	}
+	return err.Error()
+}
+
+// Ensure Runtime implements port.ScriptRuntime.
+var _ port.ScriptRuntime = (*Runtime)(nil)
@@ -0,0 +1,242 @@
+package starlarkscript
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+func run(t *testing.T, rt *Runtime, source string, input *entity.ScriptInput) (*entity.ScriptRun, error) {
+	t.Helper()
+	script, err := rt.Compile(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return script.Run(context.Background(), input, nil)
+}
+
+func TestRunConvertsInputAndResult(t *testing.T) {
+	source := `
+def resolve(ctx):
+    client = ctx.payload["client"]
+    print("tier", ctx.resolved["tier"])
+    return {
+        "name": client["first"].upper() + " " + client["last"],
+        "items": len(client["orders"]),
+        "vip": ctx.resolved["tier"] == "gold",
+        "env": ctx.environment,
+        "auth": ctx.headers.get("authorization"),
+    }
+`
+	got, err := run(t, New(Options{}), source, &entity.ScriptInput{
+		Payload:     map[string]any{"client": map[string]any{"first": "ada", "last": "Lovelace", "orders": []any{1, 2}}},
+		Resolved:    map[string]any{"tier": "gold"},
+		Environment: "prod",
+		Headers:     map[string]string{"Authorization": "Bearer x"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]any{"name": "ADA Lovelace", "items": 2.0, "vip": true, "env": "prod", "auth": "Bearer x"}
+	if !reflect.DeepEqual(got.Value, want) {
+		t.Errorf("value = %#v, want %#v", got.Value, want)
+	}
+	if len(got.Output) != 1 || got.Output[0] != "tier gold" {
+		t.Errorf("output = %q", got.Output)
+	}
+	if got.Steps == 0 {
+		t.Error("steps were not counted")
+	}
+}
+
+func TestCompileRejectsInvalidScripts(t *testing.T) {
+	rt := New(Options{MaxSourceBytes: 100})
+	for name, source := range map[string]string{
+		"no resolve":   "def other(ctx):\n    return 1\n",
+		"syntax error": "def resolve(ctx)\n    return 1\n",
+		"undefined":    "def resolve(ctx):\n    return os.environ\n",
+		"load":         "load('x.star', 'y')\ndef resolve(ctx):\n    return 1\n",
+		"too large":    "def resolve(ctx):\n    return '" + strings.Repeat("x", 100) + "'\n",
+	} {
+		if _, err := rt.Compile(source); !errors.Is(err, entity.ErrInvalidScriptedInjector) {
+			t.Errorf("%s: err = %v, want ErrInvalidScriptedInjector", name, err)
+		}
+	}
+}
+
+func TestRunStopsAtTheStepBudget(t *testing.T) {
+	source := `
+def resolve(ctx):
+    total = 0
+    for i in range(10000000):
+        total += i
+    return total
+`
+	_, err := run(t, New(Options{MaxSteps: 10000}), source, &entity.ScriptInput{})
+	if !errors.Is(err, entity.ErrScriptFailed) || !strings.Contains(err.Error(), "too many steps") {
+		t.Fatalf("err = %v, want the step budget to stop the script", err)
+	}
+}
+
+func TestRunStopsAtTheDeadline(t *testing.T) {
+	script, err := New(Options{MaxSteps: 1 << 40}).Compile(`
+def resolve(ctx):
+    total = 0
+    for i in range(1000000000):
+        total += i
+    return total
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	_, err = script.Run(ctx, &entity.ScriptInput{}, nil)
+	if !errors.Is(err, entity.ErrScriptFailed) || !strings.Contains(err.Error(), "deadline") {
+		t.Fatalf("err = %v, want the deadline to stop the script", err)
+	}
+	if elapsed := time.Since(started); elapsed > 2*time.Second {
+		t.Errorf("script ran %s after the deadline", elapsed)
+	}
+}
+
+func TestRunFetchesThroughTheProvider(t *testing.T) {
+	script, err := New(Options{}).Compile(`
+def resolve(ctx):
+    return fetch("crm_balance") * 2
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fetched string
+	got, err := script.Run(context.Background(), &entity.ScriptInput{}, func(_ context.Context, code string) (any, error) {
+		fetched = code
+		return 21.0, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fetched != "crm_balance" || got.Value != 42.0 {
+		t.Errorf("fetched %q, value %v", fetched, got.Value)
+	}
+
+	if _, err := script.Run(context.Background(), &entity.ScriptInput{}, nil); !errors.Is(err, entity.ErrScriptFailed) {
+		t.Errorf("err = %v, want ErrScriptFailed without a provider", err)
+	}
+}
+
+func TestRunRejectsLargeResults(t *testing.T) {
+	_, err := run(t, New(Options{MaxResultBytes: 64}), "def resolve(ctx):\n    return 'x' * 100\n", &entity.ScriptInput{})
+	if !errors.Is(err, entity.ErrScriptFailed) {
+		t.Fatalf("err = %v, want ErrScriptFailed", err)
+	}
+
+	// A list sharing its elements is small in memory, but encodes to gigabytes
+	source := "def resolve(ctx):\n    l = ['x' * 100]\n    for i in range(24):\n        l = [l, l]\n    return l\n"
+	_, err = run(t, New(Options{MaxSteps: 1 << 40}), source, &entity.ScriptInput{})
+	if !errors.Is(err, entity.ErrScriptFailed) || !strings.Contains(err.Error(), "result is larger") {
+		t.Fatalf("err = %v, want the result size to stop the script", err)
+	}
+}
+
+func TestRunStopsAtTheAllocationBudget(t *testing.T) {
+	rt := New(Options{MaxSteps: 1 << 40, MaxAllocBytes: 1 << 20})
+	for name, body := range map[string]string{
+		"concatenation":  "s = 'x'\n    for i in range(64):\n        s = s + s\n    return len(s)",
+		"augmented":      "s = 'x'\n    for i in range(64):\n        s += s\n    return len(s)",
+		"list growth":    "l = [0]\n    for i in range(64):\n        l += l\n    return len(l)",
+		"repeat":         "return len('x' * 100000000)",
+		"list repeat":    "return len([0] * 100000000)",
+		"augmented *=":   "s = 'x'\n    s *= 100000000\n    return len(s)",
+		"join":           "s = 'x' * 1000\n    return len(''.join([s] * 10000))",
+		"replace":        "s = 'x' * 2000\n    return len(s.replace('', s))",
+		"extend":         "l = [0]\n    for i in range(64):\n        l.extend(l)\n    return len(l)",
+		"list of range":  "return len(list(range(1000000000)))",
+		"format":         "s = 'x'\n    for i in range(24):\n        s = '{}{}'.format(s, s)\n    return len(s)",
+		"format field":   "s = 'x' * 1000\n    return len(('{0}' * 2000).format(s))",
+		"format keyword": "s = 'x' * 1000\n    return len(('{s}' * 2000).format(s=s))",
+		"percent":        "s = 'x'\n    for i in range(24):\n        s = '%s%s' % (s, s)\n    return len(s)",
+		"percent key":    "s = 'x' * 1000\n    return len(('%(s)s' * 2000) % {'s': s})",
+		"str":            "l = ['x' * 100]\n    for i in range(24):\n        l = [l, l]\n    return len(str(l))",
+		"repr":           "s = 'x'\n    for i in range(24):\n        s = repr([s, s])\n    return len(s)",
+		"json.encode":    "l = ['x' * 100]\n    for i in range(24):\n        l = [l, l]\n    return len(json.encode(l))",
+		"encode_indent":  "l = [0]\n    for i in range(24):\n        l = [l, l]\n    return len(json.encode_indent(l))",
+		"print":          "l = ['x' * 100]\n    for i in range(24):\n        l = [l, l]\n    print(l)\n    return 0",
+	} {
+		_, err := run(t, rt, "def resolve(ctx):\n    "+body+"\n", &entity.ScriptInput{})
+		if !errors.Is(err, entity.ErrScriptFailed) || !strings.Contains(err.Error(), "memory budget") {
+			t.Errorf("%s: err = %v, want the allocation budget to stop the script", name, err)
+		}
+	}
+}
+
+func TestRunKeepsOperatorSemantics(t *testing.T) {
+	source := `
+def resolve(ctx):
+    a = []
+    b = a
+    b += [1]
+    b.extend([2, 3])
+    s = "%s-%s"
+    s %= ("x", "y")
+    n = 3
+    n *= 2
+    d = {"k": "v"}
+    d["k"] += "w"
+    cyclic = [1]
+    cyclic.append(cyclic)
+    print("printed", [1])
+    return {
+        "aliased": len(a),
+        "formatted": s,
+        "number": n,
+        "sum": 1 + 2,
+        "repeat": "ab" * 2,
+        "joined": ",".join([str(i) for i in range(3)]),
+        "replaced": "a.b".replace(".", "/"),
+        "sorted": sorted([3, 1, 2]),
+        "dict": d["k"],
+        "format": "{}-{name}-{!r}".format("a", "a", name="b"),
+        "percent": "%(k)s=%(k)r" % {"k": "v"},
+        "str": str([1, "a"]),
+        "repr": repr("a"),
+        "json": json.encode({"k": [1, 2]}),
+        "indent": json.encode_indent([1], indent=" "),
+        "cycle": str(cyclic),
+    }
+`
+	got, err := run(t, New(Options{}), source, &entity.ScriptInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]any{
+		"aliased": 3.0, "formatted": "x-y", "number": 6.0, "sum": 3.0, "repeat": "abab",
+		"joined": "0,1,2", "replaced": "a/b", "sorted": []any{1.0, 2.0, 3.0}, "dict": "vw",
+		"format": `a-b-"a"`, "percent": `v="v"`, "str": `[1, "a"]`, "repr": `"a"`,
+		"json": `{"k":[1,2]}`, "indent": "[\n 1\n]", "cycle": "[1, [...]]",
+	}
+	if !reflect.DeepEqual(got.Value, want) {
+		t.Errorf("value = %#v, want %#v", got.Value, want)
+	}
+	if len(got.Output) != 1 || got.Output[0] != "printed [1]" {
+		t.Errorf("output = %q", got.Output)
+	}
+}
+
+func TestCompileDeclaresTheCheckedBuiltins(t *testing.T) {
+	for name := range allocBuiltins() {
+		if !predeclared[name] {
+			t.Errorf("%s is not predeclared", name)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package wasmplugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/tetratelabs/wazero/api"
+)
+
+const maxLogBytes = 4 << 10
+
+// hostLog is log(level, ptr, len): writes a message to the engine log. Levels are 0 debug,
+// 1 info, 2 warn and 3 error.
+func (p *plugin) hostLog(ctx context.Context, mod api.Module, level, ptr, size uint32) {
+	msg, ok := mod.Memory().Read(ptr, min(size, maxLogBytes))
+	if !ok {
+		return
+	}
+	var lvl slog.Level
+	switch level {
+	case 0:
+		lvl = slog.LevelDebug
+	case 1:
+		lvl = slog.LevelInfo
+	case 2:
+		lvl = slog.LevelWarn
+	default:
+		lvl = slog.LevelError
+	}
+	p.logger.Log(ctx, lvl, string(msg))
+}
+
+// fetchRequest is the request passed to http_fetch.
+type fetchRequest struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	Body    string            `json:"body"`
+}
+
+// fetchResponse is the response http_fetch returns. Failures set Error instead of trapping, so
+// the plugin can fall back.
+type fetchResponse struct {
+	Status  int               `json:"status,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// hostHTTPFetch is http_fetch(ptr, len) -> ptr<<32 | len: sends the JSON request at ptr to an
+// allowed host and returns the JSON response, written to memory from pf_alloc.
+func (p *plugin) hostHTTPFetch(ctx context.Context, mod api.Module, ptr, size uint32) uint64 {
+	resp := p.fetch(ctx, mod, ptr, size)
+	out, _ := json.Marshal(resp)
+	outPtr, err := writeGuest(ctx, mod, out)
+	if err != nil {
+		panic(fmt.Errorf("writing the http_fetch response: %w", err))
+	}
+	return uint64(outPtr)<<32 | uint64(len(out))
+}
+
+func (p *plugin) fetch(ctx context.Context, mod api.Module, ptr, size uint32) fetchResponse {
+	data, ok := mod.Memory().Read(ptr, size)
+	if !ok {
+		return fetchResponse{Error: "request is outside memory"}
+	}
+	var in fetchRequest
+	if err := json.Unmarshal(data, &in); err != nil {
+		return fetchResponse{Error: "invalid request: " + err.Error()}
+	}
+	if in.Method == "" {
+		in.Method = http.MethodGet
+	}
+
+	req, err := http.NewRequestWithContext(ctx, in.Method, in.URL, strings.NewReader(in.Body))
+	if err != nil {
+		return fetchResponse{Error: err.Error()}
+	}
+	if req.URL.Scheme != "https" && req.URL.Scheme != "http" {
+		return fetchResponse{Error: "only http and https URLs are allowed"}
+	}
+	if !hostAllowed(p.grant.AllowedHosts, req.URL.Hostname()) {
+		return fetchResponse{Error: fmt.Sprintf("host %s is not allowed", req.URL.Hostname())}
+	}
+	for key, value := range in.Headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return fetchResponse{Error: err.Error()}
+	}
+	defer res.Body.Close()
+
+	var body bytes.Buffer
+	n, err := io.Copy(&body, io.LimitReader(res.Body, int64(p.opts.MaxHTTPResponseBytes)+1))
+	if err != nil {
+		return fetchResponse{Error: "reading the response: " + err.Error()}
+	}
+	if n > int64(p.opts.MaxHTTPResponseBytes) {
+		return fetchResponse{Error: fmt.Sprintf("response is larger than %d bytes", p.opts.MaxHTTPResponseBytes)}
+	}
+	headers := make(map[string]string, len(res.Header))
+	for key := range res.Header {
+		headers[strings.ToLower(key)] = res.Header.Get(key)
+	}
+	return fetchResponse{Status: res.StatusCode, Headers: headers, Body: body.String()}
+}
+
+// hostAllowed reports whether host matches one of allowed, exact or as "*.example.com".
+func hostAllowed(allowed []string, host string) bool {
+	host = strings.ToLower(host)
+	for _, pattern := range allowed {
+		pattern = strings.ToLower(pattern)
+		if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+			if strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+			continue
+		}
+		if host == pattern {
+			return true
+		}
+	}
+	return false
+}
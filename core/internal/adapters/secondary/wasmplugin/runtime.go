@@ -0,0 +1,344 @@
+// Package wasmplugin runs WASM plugins with wazero. Each plugin gets its own runtime with a memory
+// limit, WASI without files, environment or arguments, and only the host functions of the
+// capabilities it is granted. Every call instantiates the module afresh, so no state survives
+// between renders, tenants or concurrent calls.
+package wasmplugin
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/tetratelabs/wazero/sys"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// Defaults of the runtime limits.
+const (
+	DefaultMaxMemoryMB          = 64
+	DefaultMaxHTTPResponseBytes = 1 << 20
+	DefaultHTTPTimeout          = 10 * time.Second
+)
+
+// Module names and exports of the plugin ABI.
+const (
+	hostModule        = "pdf_forge"
+	wasiModule        = wasi_snapshot_preview1.ModuleName
+	exportMemory      = "memory"
+	exportAlloc       = "pf_alloc"
+	exportManifest    = "pf_manifest"
+	exportResolve     = "pf_resolve"
+	exportPostProcess = "pf_post_process"
+	wasmPageBytes     = 64 << 10
+)
+
+// hostFunctions are the host functions of hostModule by the capability that grants them.
+var hostFunctions = map[string]entity.PluginCapability{
+	"log":        entity.PluginCapabilityLog,
+	"http_fetch": entity.PluginCapabilityHTTP,
+}
+
+// Options limit the plugins of the runtime. Zero values take the defaults.
+type Options struct {
+	MaxMemoryMB          int           // Linear memory of an instance
+	MaxHTTPResponseBytes int           // Body of an http_fetch response
+	HTTPTimeout          time.Duration // Of an http_fetch request, within the deadline of the call
+}
+
+// Runtime loads WASM plugins.
+type Runtime struct {
+	opts Options
+}
+
+// New creates a runtime with the given limits.
+func New(opts Options) *Runtime {
+	if opts.MaxMemoryMB <= 0 {
+		opts.MaxMemoryMB = DefaultMaxMemoryMB
+	}
+	if opts.MaxHTTPResponseBytes <= 0 {
+		opts.MaxHTTPResponseBytes = DefaultMaxHTTPResponseBytes
+	}
+	if opts.HTTPTimeout <= 0 {
+		opts.HTTPTimeout = DefaultHTTPTimeout
+	}
+	return &Runtime{opts: opts}
+}
+
+// Load compiles module in a runtime of its own and reads its manifest.
+func (r *Runtime) Load(ctx context.Context, name string, module []byte, grant entity.PluginGrant) (port.Plugin, error) {
+	if err := grant.Validate(); err != nil {
+		return nil, err
+	}
+
+	runtimeCfg := wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(uint32(r.opts.MaxMemoryMB * (1 << 20) / wasmPageBytes)).
+		WithCloseOnContextDone(true)
+	rt := wazero.NewRuntimeWithConfig(ctx, runtimeCfg)
+
+	p := &plugin{
+		name:    name,
+		runtime: rt,
+		grant:   grant,
+		opts:    r.opts,
+		logger:  slog.Default().With(slog.String("plugin", name)),
+	}
+	if grant.Has(entity.PluginCapabilityHTTP) {
+		p.client = &http.Client{
+			Timeout: r.opts.HTTPTimeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= 5 {
+					return errors.New("too many redirects")
+				}
+				if !hostAllowed(grant.AllowedHosts, req.URL.Hostname()) {
+					return fmt.Errorf("redirect to %s is not allowed", req.URL.Hostname())
+				}
+				return nil
+			},
+		}
+	}
+
+	if err := p.load(ctx, module); err != nil {
+		_ = rt.Close(ctx)
+		return nil, err
+	}
+	return p, nil
+}
+
+// plugin is a loaded plugin.
+type plugin struct {
+	name     string
+	runtime  wazero.Runtime
+	compiled wazero.CompiledModule
+	grant    entity.PluginGrant
+	opts     Options
+	manifest *entity.PluginManifest
+	logger   *slog.Logger
+	client   *http.Client // Nil without the http capability
+}
+
+func (p *plugin) load(ctx context.Context, module []byte) error {
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, p.runtime); err != nil {
+		return fmt.Errorf("instantiating WASI: %w", err)
+	}
+	if err := p.instantiateHost(ctx); err != nil {
+		return fmt.Errorf("instantiating the host module: %w", err)
+	}
+
+	compiled, err := p.runtime.CompileModule(ctx, module)
+	if err != nil {
+		return fmt.Errorf("%w: %v", entity.ErrInvalidPlugin, err)
+	}
+	p.compiled = compiled
+	if err := p.checkImports(); err != nil {
+		return err
+	}
+	exports := compiled.ExportedFunctions()
+	if _, ok := compiled.ExportedMemories()[exportMemory]; !ok {
+		return fmt.Errorf("%w: module does not export %q", entity.ErrInvalidPlugin, exportMemory)
+	}
+	for _, export := range []string{exportAlloc, exportManifest} {
+		if _, ok := exports[export]; !ok {
+			return fmt.Errorf("%w: module does not export %q", entity.ErrInvalidPlugin, export)
+		}
+	}
+
+	out, err := p.call(ctx, exportManifest, nil)
+	if err != nil {
+		return fmt.Errorf("%w: %v", entity.ErrInvalidPlugin, err)
+	}
+	var manifest entity.PluginManifest
+	if err := json.Unmarshal(out, &manifest); err != nil {
+		return fmt.Errorf("%w: decoding the manifest: %v", entity.ErrInvalidPlugin, err)
+	}
+	if err := manifest.Validate(p.name); err != nil {
+		return err
+	}
+	if _, ok := exports[exportResolve]; len(manifest.Injectors) > 0 && !ok {
+		return fmt.Errorf("%w: module declares injectors but does not export %q", entity.ErrInvalidPlugin, exportResolve)
+	}
+	if _, ok := exports[exportPostProcess]; manifest.PostProcess && !ok {
+		return fmt.Errorf("%w: module declares a post-processor but does not export %q", entity.ErrInvalidPlugin, exportPostProcess)
+	}
+	p.manifest = &manifest
+	return nil
+}
+
+// checkImports rejects modules importing anything but WASI and the granted host functions.
+func (p *plugin) checkImports() error {
+	for _, fn := range p.compiled.ImportedFunctions() {
+		module, name, _ := fn.Import()
+		switch module {
+		case wasiModule:
+		case hostModule:
+			capability, ok := hostFunctions[name]
+			if !ok {
+				return fmt.Errorf("%w: unknown host function %s.%s", entity.ErrInvalidPlugin, module, name)
+			}
+			if !p.grant.Has(capability) {
+				return fmt.Errorf("%w: host function %s.%s requires the %q capability", entity.ErrInvalidPlugin, module, name, capability)
+			}
+		default:
+			return fmt.Errorf("%w: import from unknown module %q", entity.ErrInvalidPlugin, module)
+		}
+	}
+	return nil
+}
+
+// instantiateHost exports the host functions of the granted capabilities.
+func (p *plugin) instantiateHost(ctx context.Context) error {
+	builder := p.runtime.NewHostModuleBuilder(hostModule)
+	if p.grant.Has(entity.PluginCapabilityLog) {
+		builder.NewFunctionBuilder().WithFunc(p.hostLog).Export("log")
+	}
+	if p.grant.Has(entity.PluginCapabilityHTTP) {
+		builder.NewFunctionBuilder().WithFunc(p.hostHTTPFetch).Export("http_fetch")
+	}
+	_, err := builder.Instantiate(ctx)
+	return err
+}
+
+func (p *plugin) Manifest() *entity.PluginManifest { return p.manifest }
+
+// resolveOutput is what pf_resolve returns.
+type resolveOutput struct {
+	Value any    `json:"value"`
+	Error string `json:"error"`
+}
+
+// Resolve calls pf_resolve.
+func (p *plugin) Resolve(ctx context.Context, input *entity.PluginInjectorInput) (any, error) {
+	in, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("%w: encoding the input: %v", entity.ErrPluginFailed, err)
+	}
+	out, err := p.call(ctx, exportResolve, in)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", entity.ErrPluginFailed, err)
+	}
+	var result resolveOutput
+	if err := json.Unmarshal(out, &result); err != nil {
+		return nil, fmt.Errorf("%w: decoding the output: %v", entity.ErrPluginFailed, err)
+	}
+	if result.Error != "" {
+		return nil, fmt.Errorf("%w: %s", entity.ErrPluginFailed, result.Error)
+	}
+	return result.Value, nil
+}
+
+// postProcessInput is what pf_post_process receives; the PDF is base64 in JSON.
+type postProcessInput struct {
+	Render *entity.PluginRender `json:"render"`
+	PDF    []byte               `json:"pdf"`
+}
+
+// postProcessOutput is what pf_post_process returns. An empty PDF keeps the original.
+type postProcessOutput struct {
+	PDF   []byte `json:"pdf"`
+	Error string `json:"error"`
+}
+
+// PostProcess calls pf_post_process.
+func (p *plugin) PostProcess(ctx context.Context, render *entity.PluginRender, pdf []byte) ([]byte, error) {
+	in, err := json.Marshal(postProcessInput{Render: render, PDF: pdf})
+	if err != nil {
+		return nil, fmt.Errorf("%w: encoding the input: %v", entity.ErrPluginFailed, err)
+	}
+	out, err := p.call(ctx, exportPostProcess, in)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", entity.ErrPluginFailed, err)
+	}
+	var result postProcessOutput
+	if err := json.Unmarshal(out, &result); err != nil {
+		return nil, fmt.Errorf("%w: decoding the output: %v", entity.ErrPluginFailed, err)
+	}
+	if result.Error != "" {
+		return nil, fmt.Errorf("%w: %s", entity.ErrPluginFailed, result.Error)
+	}
+	if len(result.PDF) == 0 {
+		return pdf, nil
+	}
+	return result.PDF, nil
+}
+
+// Close closes the runtime of the plugin.
+func (p *plugin) Close(ctx context.Context) error {
+	return p.runtime.Close(ctx)
+}
+
+// call runs export in a fresh instance. A nil input calls export without arguments; otherwise the
+// input is written to memory from pf_alloc and passed as (ptr, len). Exports return their output
+// as ptr<<32 | len.
+func (p *plugin) call(ctx context.Context, export string, input []byte) ([]byte, error) {
+	cfg := wazero.NewModuleConfig().WithName("").WithStartFunctions("_initialize")
+	if p.grant.Has(entity.PluginCapabilityClock) {
+		cfg = cfg.WithSysWalltime().WithSysNanotime()
+	}
+	if p.grant.Has(entity.PluginCapabilityRandom) {
+		cfg = cfg.WithRandSource(rand.Reader)
+	}
+	mod, err := p.runtime.InstantiateModule(ctx, p.compiled, cfg)
+	if err != nil {
+		return nil, callError(ctx, err)
+	}
+	defer mod.Close(context.WithoutCancel(ctx))
+
+	var args []uint64
+	if input != nil {
+		ptr, err := writeGuest(ctx, mod, input)
+		if err != nil {
+			return nil, callError(ctx, err)
+		}
+		args = []uint64{uint64(ptr), uint64(len(input))}
+	}
+	results, err := mod.ExportedFunction(export).Call(ctx, args...)
+	if err != nil {
+		return nil, callError(ctx, err)
+	}
+	if len(results) != 1 {
+		return nil, fmt.Errorf("%s must return ptr<<32 | len as an i64", export)
+	}
+	ptr, size := uint32(results[0]>>32), uint32(results[0])
+	out, ok := mod.Memory().Read(ptr, size)
+	if !ok {
+		return nil, fmt.Errorf("%s returned a range outside memory", export)
+	}
+	return append([]byte(nil), out...), nil
+}
+
+// writeGuest copies data into memory allocated by pf_alloc and returns its address.
+func writeGuest(ctx context.Context, mod api.Module, data []byte) (uint32, error) {
+	results, err := mod.ExportedFunction(exportAlloc).Call(ctx, uint64(len(data)))
+	if err != nil {
+		return 0, err
+	}
+	if len(results) != 1 {
+		return 0, fmt.Errorf("%s must return a pointer", exportAlloc)
+	}
+	ptr := uint32(results[0])
+	if !mod.Memory().Write(ptr, data) {
+		return 0, fmt.Errorf("%s returned a range outside memory", exportAlloc)
+	}
+	return ptr, nil
+}
+
+// callError describes a failed call, naming the deadline when the call ran out of time.
+func callError(ctx context.Context, err error) error {
+	var exitErr *sys.ExitError
+	if errors.As(err, &exitErr) && ctx.Err() != nil {
+		return fmt.Errorf("stopped: %w", ctx.Err())
+	}
+	return err
+}
+
+// Ensure Runtime implements port.PluginRuntime.
+var _ port.PluginRuntime = (*Runtime)(nil)
@@ -0,0 +1,261 @@
+package wasmplugin
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+const demoManifest = `{"injectors":[{"code":"demo_greeting","label":{"en":"Greeting"},"dataType":"TEXT"}],"postProcess":true}`
+
+func TestLoadAndCall(t *testing.T) {
+	module := testModule{
+		manifest: demoManifest,
+		result:   `{"value":"hello"}`,
+		pdf:      `{"pdf":"` + base64.StdEncoding.EncodeToString([]byte("%PDF-stamped")) + `"}`,
+	}.build()
+
+	ctx := context.Background()
+	plugin, err := New(Options{}).Load(ctx, "demo", module, entity.PluginGrant{})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	defer plugin.Close(ctx)
+
+	manifest := plugin.Manifest()
+	if len(manifest.Injectors) != 1 || manifest.Injectors[0].Code != "demo_greeting" || !manifest.PostProcess {
+		t.Fatalf("Manifest() = %+v", manifest)
+	}
+	if manifest.Injectors[0].TimeoutMs != entity.PluginInjectorDefaultTimeoutMs {
+		t.Errorf("TimeoutMs = %d, want the default", manifest.Injectors[0].TimeoutMs)
+	}
+
+	value, err := plugin.Resolve(ctx, &entity.PluginInjectorInput{Code: "demo_greeting"})
+	if err != nil || value != "hello" {
+		t.Errorf("Resolve() = %v, %v; want hello", value, err)
+	}
+	pdf, err := plugin.PostProcess(ctx, &entity.PluginRender{}, []byte("%PDF-original"))
+	if err != nil || string(pdf) != "%PDF-stamped" {
+		t.Errorf("PostProcess() = %q, %v; want the stamped PDF", pdf, err)
+	}
+}
+
+func TestLoadChecksCapabilities(t *testing.T) {
+	module := testModule{manifest: demoManifest, result: `{"value":"hello"}`, pdf: `{}`, importLog: true}.build()
+	ctx := context.Background()
+
+	if _, err := New(Options{}).Load(ctx, "demo", module, entity.PluginGrant{}); !errors.Is(err, entity.ErrInvalidPlugin) {
+		t.Fatalf("Load without the log capability: err = %v, want ErrInvalidPlugin", err)
+	}
+
+	plugin, err := New(Options{}).Load(ctx, "demo", module, entity.PluginGrant{Capabilities: []entity.PluginCapability{entity.PluginCapabilityLog}})
+	if err != nil {
+		t.Fatalf("Load with the log capability: %v", err)
+	}
+	defer plugin.Close(ctx)
+	if value, err := plugin.Resolve(ctx, &entity.PluginInjectorInput{}); err != nil || value != "hello" {
+		t.Errorf("Resolve() = %v, %v; want hello", value, err)
+	}
+}
+
+func TestLoadRejectsInvalidManifests(t *testing.T) {
+	tests := map[string]string{
+		"code without prefix":  `{"injectors":[{"code":"greeting","label":{"en":"Greeting"},"dataType":"TEXT"}]}`,
+		"missing label":        `{"injectors":[{"code":"demo_greeting","dataType":"TEXT"}]}`,
+		"unsupported type":     `{"injectors":[{"code":"demo_greeting","label":{"en":"Greeting"},"dataType":"TABLE"}]}`,
+		"provides nothing":     `{"injectors":[]}`,
+		"not JSON":             `injectors`,
+		"duplicated injectors": `{"injectors":[{"code":"demo_a","label":{"en":"A"},"dataType":"TEXT"},{"code":"demo_a","label":{"en":"A"},"dataType":"TEXT"}]}`,
+	}
+	for name, manifest := range tests {
+		t.Run(name, func(t *testing.T) {
+			module := testModule{manifest: manifest, result: `{}`, pdf: `{}`}.build()
+			_, err := New(Options{}).Load(context.Background(), "demo", module, entity.PluginGrant{})
+			if !errors.Is(err, entity.ErrInvalidPlugin) {
+				t.Errorf("err = %v, want ErrInvalidPlugin", err)
+			}
+		})
+	}
+}
+
+func TestResolveErrorAndDeadline(t *testing.T) {
+	ctx := context.Background()
+
+	failing := testModule{manifest: demoManifest, result: `{"error":"CRM unavailable"}`, pdf: `{}`}.build()
+	plugin, err := New(Options{}).Load(ctx, "demo", failing, entity.PluginGrant{})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	defer plugin.Close(ctx)
+	if _, err := plugin.Resolve(ctx, &entity.PluginInjectorInput{}); !errors.Is(err, entity.ErrPluginFailed) {
+		t.Errorf("Resolve() err = %v, want ErrPluginFailed", err)
+	}
+
+	looping := testModule{manifest: demoManifest, pdf: `{}`, loop: true}.build()
+	plugin, err = New(Options{}).Load(ctx, "demo", looping, entity.PluginGrant{})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	defer plugin.Close(ctx)
+
+	callCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	if _, err := plugin.Resolve(callCtx, &entity.PluginInjectorInput{}); !errors.Is(err, entity.ErrPluginFailed) {
+		t.Errorf("Resolve() err = %v, want ErrPluginFailed", err)
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Errorf("Resolve() took %s after its deadline", elapsed)
+	}
+}
+
+func TestHostAllowed(t *testing.T) {
+	allowed := []string{"api.example.com", "*.crm.example.com"}
+	tests := map[string]bool{
+		"api.example.com":      true,
+		"API.example.com":      true,
+		"eu.crm.example.com":   true,
+		"crm.example.com":      false,
+		"evil.example.com":     false,
+		"api.example.com.evil": false,
+	}
+	for host, want := range tests {
+		if got := hostAllowed(allowed, host); got != want {
+			t.Errorf("hostAllowed(%q) = %v, want %v", host, got, want)
+		}
+	}
+}
+
+// testModule assembles a minimal plugin: a bump allocator and exports returning fixed JSON.
+type testModule struct {
+	manifest  string
+	result    string // Returned by pf_resolve
+	pdf       string // Returned by pf_post_process
+	importLog bool   // pf_resolve calls pdf_forge.log first
+	loop      bool   // pf_resolve never returns
+}
+
+func (m testModule) build() []byte {
+	const logMessage = "resolving"
+	var data []byte
+	place := func(s string) (offset, size int) {
+		offset = 16 + len(data)
+		data = append(data, s...)
+		return offset, len(s)
+	}
+	manifestAt, manifestLen := place(m.manifest)
+	resultAt, resultLen := place(m.result)
+	pdfAt, pdfLen := place(m.pdf)
+	logAt, logLen := place(logMessage)
+
+	packed := func(offset, size int) []byte {
+		return append([]byte{0x42}, sleb(int64(offset)<<32|int64(size))...) // i64.const
+	}
+
+	types := [][]byte{
+		{0x60, 1, 0x7f, 1, 0x7f},       // (i32) -> i32
+		{0x60, 0, 1, 0x7e},             // () -> i64
+		{0x60, 2, 0x7f, 0x7f, 1, 0x7e}, // (i32, i32) -> i64
+		{0x60, 3, 0x7f, 0x7f, 0x7f, 0}, // (i32, i32, i32) -> ()
+	}
+
+	var imports [][]byte
+	first := 0 // Index of the first defined function
+	if m.importLog {
+		imports = append(imports, concat(name("pdf_forge"), name("log"), []byte{0x00, 3}))
+		first = 1
+	}
+
+	alloc := []byte{0x23, 0, 0x23, 0, 0x20, 0, 0x6a, 0x24, 0} // old := bump; bump += size; return old
+	resolve := []byte{}
+	if m.importLog {
+		resolve = concat(resolve, []byte{0x41, 1, 0x41}, sleb(int64(logAt)), []byte{0x41}, sleb(int64(logLen)), []byte{0x10, 0})
+	}
+	if m.loop {
+		resolve = concat(resolve, []byte{0x03, 0x40, 0x0c, 0, 0x0b})
+	}
+	resolve = concat(resolve, packed(resultAt, resultLen))
+
+	bodies := [][]byte{alloc, packed(manifestAt, manifestLen), resolve, packed(pdfAt, pdfLen)}
+	var code [][]byte
+	for _, body := range bodies {
+		fn := concat([]byte{0}, body, []byte{0x0b}) // No locals
+		code = append(code, concat(uleb(uint64(len(fn))), fn))
+	}
+
+	exports := [][]byte{
+		concat(name("memory"), []byte{0x02, 0}),
+		concat(name("pf_alloc"), []byte{0x00}, uleb(uint64(first))),
+		concat(name("pf_manifest"), []byte{0x00}, uleb(uint64(first+1))),
+		concat(name("pf_resolve"), []byte{0x00}, uleb(uint64(first+2))),
+		concat(name("pf_post_process"), []byte{0x00}, uleb(uint64(first+3))),
+	}
+
+	module := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	module = append(module, section(1, vec(types))...)
+	if len(imports) > 0 {
+		module = append(module, section(2, vec(imports))...)
+	}
+	module = append(module, section(3, vec([][]byte{{0}, {1}, {2}, {2}}))...)
+	module = append(module, section(5, vec([][]byte{{0x00, 1}}))...)                         // memory, min 1 page
+	module = append(module, section(6, vec([][]byte{{0x7f, 1, 0x41, 0x80, 0x20, 0x0b}}))...) // mut i32 bump = 4096
+	module = append(module, section(7, vec(exports))...)
+	module = append(module, section(10, vec(code))...)
+	module = append(module, section(11, vec([][]byte{concat([]byte{0, 0x41, 16, 0x0b}, uleb(uint64(len(data))), data)}))...)
+	return module
+}
+
+func section(id byte, content []byte) []byte {
+	return concat([]byte{id}, uleb(uint64(len(content))), content)
+}
+
+func vec(items [][]byte) []byte {
+	return concat(append([][]byte{uleb(uint64(len(items)))}, items...)...)
+}
+
+func name(s string) []byte {
+	return concat(uleb(uint64(len(s))), []byte(s))
+}
+
+func concat(parts ...[]byte) []byte {
+	var out []byte
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+	return out
+}
+
+func uleb(v uint64) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v != 0 {
+			b |= 0x80
+		}
+		out = append(out, b)
+		if v == 0 {
+			return out
+		}
+	}
+}
+
+func sleb(v int64) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		done := (v == 0 && b&0x40 == 0) || (v == -1 && b&0x40 != 0)
+		if !done {
+			b |= 0x80
+		}
+		out = append(out, b)
+		if done {
+			return out
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// FormatVersion is the archive layout version written to the manifest.
+const FormatVersion = 1
+
+// manifestName is the first entry of every archive.
+const manifestName = "manifest.json"
+
+// Schemas are the PostgreSQL schemas owned by pdf-forge. Other schemas in the same
+// database (e.g. tables created by extensions) are never dumped or truncated.
+var Schemas = []string{"tenancy", "identity", "organizer", "content"}
+
+// Backup archive errors.
+var (
+	ErrInvalidArchive      = errors.New("invalid backup archive")
+	ErrUnsupportedFormat   = errors.New("unsupported backup archive format")
+	ErrSchemaMismatch      = errors.New("backup schema version does not match the database")
+	ErrDirtySchema         = errors.New("database migration version is dirty")
+	ErrStorageNotAvailable = errors.New("archive contains assets but no storage provider is registered")
+)
+
+// Manifest describes the content of a backup archive.
+type Manifest struct {
+	FormatVersion int       `json:"formatVersion"`
+	CreatedAt     time.Time `json:"createdAt"`
+	SchemaVersion uint      `json:"schemaVersion"` // Migration version the data was taken from
+	Tables        []Table   `json:"tables"`
+	Assets        []Asset   `json:"assets"`
+}
+
+// Table is a dumped table, stored as CSV with a header row.
+type Table struct {
+	Schema  string   `json:"schema"`
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Rows    int64    `json:"rows"`
+	File    string   `json:"file"`
+}
+
+// QualifiedName returns the quoted schema-qualified table name.
+func (t Table) QualifiedName() string {
+	return pgx.Identifier{t.Schema, t.Name}.Sanitize()
+}
+
+// columnList returns the quoted, comma-separated column names.
+func (t Table) columnList() string {
+	quoted := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		quoted[i] = pgx.Identifier{col}.Sanitize()
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// Asset is a storage object referenced from template content (storage://<key>).
+type Asset struct {
+	Key           string `json:"key"`
+	TenantID      string `json:"tenantId"`
+	TenantCode    string `json:"tenantCode"`
+	WorkspaceID   string `json:"workspaceId"`
+	WorkspaceCode string `json:"workspaceCode"`
+	ContentType   string `json:"contentType"`
+	Size          int64  `json:"size"`
+	SHA256        string `json:"sha256"`
+	File          string `json:"file"`
+}
+
+// storageContext returns the storage context of the workspace that references the asset.
+func (a Asset) storageContext() port.StorageContext {
+	return port.NewGalleryStorageContext(a.TenantID, a.TenantCode, a.WorkspaceID, a.WorkspaceCode)
+}
+
+// files returns the archive entries listed by the manifest.
+func (m *Manifest) files() map[string]bool {
+	files := make(map[string]bool, len(m.Tables)+len(m.Assets))
+	for _, t := range m.Tables {
+		files[t.File] = true
+	}
+	for _, a := range m.Assets {
+		files[a.File] = true
+	}
+	return files
+}
+
+// writeArchive writes a gzipped tar with the manifest first, followed by the files it lists from dir.
+func writeArchive(w io.Writer, manifest *Manifest, dir string) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding manifest: %w", err)
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    manifestName,
+		Mode:    0o600,
+		Size:    int64(len(data)),
+		ModTime: manifest.CreatedAt,
+	}); err != nil {
+		return fmt.Errorf("writing manifest header: %w", err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("writing manifest: %w", err)
+	}
+
+	for _, t := range manifest.Tables {
+		if err := addFile(tw, dir, t.File, manifest.CreatedAt); err != nil {
+			return err
+		}
+	}
+	for _, a := range manifest.Assets {
+		if err := addFile(tw, dir, a.File, manifest.CreatedAt); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("closing archive: %w", err)
+	}
+	return gz.Close()
+}
+
+// addFile copies dir/name into the archive.
+func addFile(tw *tar.Writer, dir, name string, modTime time.Time) error {
+	f, err := os.Open(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", name, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", name, err)
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: info.Size(), ModTime: modTime}); err != nil {
+		return fmt.Errorf("writing %s header: %w", name, err)
+	}
+	if _, err := io.Copy(tw, f); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	return nil
+}
+
+// readArchive reads the manifest and extracts every file it lists into dir.
+// Entries not listed by the manifest are rejected, so a crafted archive cannot write outside dir.
+func readArchive(r io.Reader, dir string) (*Manifest, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != manifestName {
+		return nil, fmt.Errorf("%w: %s must be the first entry", ErrInvalidArchive, manifestName)
+	}
+	var manifest Manifest
+	if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("%w: decoding manifest: %v", ErrInvalidArchive, err)
+	}
+	if manifest.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedFormat, manifest.FormatVersion)
+	}
+
+	pending := manifest.files()
+	for name := range pending {
+		if !filepath.IsLocal(filepath.FromSlash(name)) {
+			return nil, fmt.Errorf("%w: unsafe entry %q", ErrInvalidArchive, name)
+		}
+	}
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+		}
+		if !pending[hdr.Name] {
+			return nil, fmt.Errorf("%w: unexpected entry %q", ErrInvalidArchive, hdr.Name)
+		}
+		if err := extractFile(tr, dir, hdr.Name); err != nil {
+			return nil, err
+		}
+		delete(pending, hdr.Name)
+	}
+
+	if len(pending) > 0 {
+		return nil, fmt.Errorf("%w: %d entries listed by the manifest are missing", ErrInvalidArchive, len(pending))
+	}
+	return &manifest, nil
+}
+
+// extractFile writes the current archive entry to dir/name.
+func extractFile(r io.Reader, dir, name string) error {
+	f, err := createFile(dir, name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, r); err != nil {
+		return fmt.Errorf("extracting %s: %w", name, err)
+	}
+	return nil
+}
+
+// createFile creates dir/name and its parent directories.
+func createFile(dir, name string) (*os.File, error) {
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("creating directory for %s: %w", name, err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("creating %s: %w", name, err)
+	}
+	return f, nil
+}
@@ -0,0 +1,288 @@
+// Package backup dumps and restores the pdf-forge schemas, together with the storage
+// assets referenced by template content, as a single portable archive.
+//
+// It is meant for self-hosted installs without DBA support: the archive is plain CSV
+// produced by COPY, so it only needs the application's database role, and restoring
+// into an installation migrated to the same schema version brings back all tenants,
+// workspaces, templates and users.
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"regexp"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// transferTimeout bounds a single asset download or upload through a signed URL.
+const transferTimeout = 2 * time.Minute
+
+// storageRefPattern matches storage:// references inside template content JSON.
+var storageRefPattern = regexp.MustCompile(`storage://([^"\\\s]+)`)
+
+// querier is satisfied by both the pool and transactions.
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// Service creates and restores backup archives.
+type Service struct {
+	pool       *pgxpool.Pool
+	storage    port.StorageProvider
+	httpClient *http.Client
+}
+
+// New creates a backup service. storage may be nil, in which case assets are not backed up.
+func New(pool *pgxpool.Pool, storage port.StorageProvider) *Service {
+	return &Service{
+		pool:       pool,
+		storage:    storage,
+		httpClient: &http.Client{Timeout: transferTimeout},
+	}
+}
+
+// Create writes a backup archive to w.
+// All tables are read from a single snapshot, so the archive is consistent while the API keeps serving.
+func (s *Service) Create(ctx context.Context, w io.Writer) (*Manifest, error) {
+	dir, err := os.MkdirTemp("", "pdf-forge-backup-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating work directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx = postgres.WithoutQueryTimeout(ctx)
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("beginning snapshot: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := disableStatementTimeout(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{FormatVersion: FormatVersion, CreatedAt: time.Now().UTC()}
+	if manifest.SchemaVersion, err = schemaVersion(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	tables, err := listTables(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range tables {
+		if err := dumpTable(ctx, tx, &tables[i], dir); err != nil {
+			return nil, err
+		}
+	}
+	manifest.Tables = tables
+
+	refs, err := findAssetRefs(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("closing snapshot: %w", err)
+	}
+
+	if manifest.Assets, err = s.downloadAssets(ctx, refs, dir); err != nil {
+		return nil, err
+	}
+
+	if err := writeArchive(w, manifest, dir); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// disableStatementTimeout lifts the pool's statement_timeout for the rest of tx:
+// dumping or reloading a large installation can take longer than any API query.
+func disableStatementTimeout(ctx context.Context, tx pgx.Tx) error {
+	if _, err := tx.Exec(ctx, "SET LOCAL statement_timeout = 0"); err != nil {
+		return fmt.Errorf("disabling statement timeout: %w", err)
+	}
+	return nil
+}
+
+// schemaVersion returns the applied migration version, refusing dirty databases.
+func schemaVersion(ctx context.Context, q querier) (uint, error) {
+	var version int64
+	var dirty bool
+	if err := q.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty); err != nil {
+		return 0, fmt.Errorf("reading migration version: %w", err)
+	}
+	if dirty {
+		return 0, fmt.Errorf("%w: version %d", ErrDirtySchema, version)
+	}
+	return uint(version), nil
+}
+
+// listTables returns the base tables of the pdf-forge schemas with their writable columns.
+func listTables(ctx context.Context, q querier) ([]Table, error) {
+	rows, err := q.Query(ctx, `
+		SELECT c.table_schema::text, c.table_name::text, array_agg(c.column_name::text ORDER BY c.ordinal_position)
+		FROM information_schema.columns c
+		JOIN information_schema.tables t ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+		WHERE t.table_type = 'BASE TABLE' AND c.table_schema = ANY($1) AND c.is_generated = 'NEVER'
+		GROUP BY c.table_schema, c.table_name
+		ORDER BY c.table_schema, c.table_name`,
+		Schemas,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing tables: %w", err)
+	}
+	defer rows.Close()
+
+	var tables []Table
+	for rows.Next() {
+		var t Table
+		if err := rows.Scan(&t.Schema, &t.Name, &t.Columns); err != nil {
+			return nil, fmt.Errorf("scanning table: %w", err)
+		}
+		t.File = path.Join("tables", t.Schema+"."+t.Name+".csv")
+		tables = append(tables, t)
+	}
+	return tables, rows.Err()
+}
+
+// dumpTable copies a table into dir/t.File as CSV.
+func dumpTable(ctx context.Context, tx pgx.Tx, t *Table, dir string) error {
+	f, err := createFile(dir, t.File)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	query := fmt.Sprintf("COPY %s (%s) TO STDOUT WITH (FORMAT csv, HEADER true)", t.QualifiedName(), t.columnList())
+	tag, err := tx.Conn().PgConn().CopyTo(ctx, f, query)
+	if err != nil {
+		return fmt.Errorf("dumping %s: %w", t.QualifiedName(), err)
+	}
+	t.Rows = tag.RowsAffected()
+	return nil
+}
+
+// findAssetRefs returns the storage assets referenced by template versions, one per key.
+func findAssetRefs(ctx context.Context, q querier) ([]Asset, error) {
+	rows, err := q.Query(ctx, `
+		SELECT tv.content_structure::text, tn.id, tn.code, w.id, w.code
+		FROM content.template_versions tv
+		JOIN content.templates t ON t.id = tv.template_id
+		JOIN tenancy.workspaces w ON w.id = t.workspace_id
+		JOIN tenancy.tenants tn ON tn.id = w.tenant_id
+		WHERE strpos(tv.content_structure::text, 'storage://') > 0
+		ORDER BY tv.created_at`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("finding asset references: %w", err)
+	}
+	defer rows.Close()
+
+	seen := make(map[string]bool)
+	var assets []Asset
+	for rows.Next() {
+		var content string
+		var ref Asset
+		if err := rows.Scan(&content, &ref.TenantID, &ref.TenantCode, &ref.WorkspaceID, &ref.WorkspaceCode); err != nil {
+			return nil, fmt.Errorf("scanning asset reference: %w", err)
+		}
+		for _, key := range extractStorageKeys(content) {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			asset := ref
+			asset.Key = key
+			assets = append(assets, asset)
+		}
+	}
+	return assets, rows.Err()
+}
+
+// extractStorageKeys returns the storage keys referenced in content, in order of appearance.
+func extractStorageKeys(content string) []string {
+	var keys []string
+	for _, match := range storageRefPattern.FindAllStringSubmatch(content, -1) {
+		keys = append(keys, match[1])
+	}
+	return keys
+}
+
+// downloadAssets fetches referenced assets into dir. Assets that no longer resolve are
+// skipped with a warning: a dangling reference must not prevent backing up the database.
+func (s *Service) downloadAssets(ctx context.Context, refs []Asset, dir string) ([]Asset, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	if s.storage == nil {
+		slog.WarnContext(ctx, "no storage provider registered, referenced assets are not included in the backup",
+			slog.Int("assets", len(refs)))
+		return nil, nil
+	}
+
+	assets := make([]Asset, 0, len(refs))
+	for i, asset := range refs {
+		asset.File = fmt.Sprintf("assets/%05d", i+1)
+		if err := s.downloadAsset(ctx, &asset, dir); err != nil {
+			slog.WarnContext(ctx, "skipping asset",
+				slog.String("key", asset.Key),
+				slog.String("workspaceId", asset.WorkspaceID),
+				slog.Any("error", err))
+			continue
+		}
+		assets = append(assets, asset)
+	}
+	return assets, nil
+}
+
+// downloadAsset resolves the asset URL through the storage provider and saves its content.
+func (s *Service) downloadAsset(ctx context.Context, asset *Asset, dir string) error {
+	resolved, err := s.storage.GetURL(ctx, &port.StorageGetURLRequest{Storage: asset.storageContext(), Key: asset.Key})
+	if err != nil {
+		return fmt.Errorf("resolving URL: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, resolved.URL, nil)
+	if err != nil {
+		return fmt.Errorf("building request: %w", err)
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("downloading: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("downloading: unexpected status %d", resp.StatusCode)
+	}
+
+	f, err := createFile(dir, asset.File)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(f, hash), resp.Body)
+	if err != nil {
+		return fmt.Errorf("downloading: %w", err)
+	}
+
+	asset.Size = size
+	asset.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	asset.ContentType = resp.Header.Get("Content-Type")
+	return nil
+}
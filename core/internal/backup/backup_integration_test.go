@@ -0,0 +1,55 @@
+//go:build integration
+
+package backup_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/backup"
+	"github.com/rendis/pdf-forge/core/internal/testutil/testpostgres"
+)
+
+func TestPostgresIntegration_BackupAndRestore(t *testing.T) {
+	ctx := context.Background()
+	pg := testpostgres.Run(ctx, t)
+
+	pool, err := pg.NewPool(ctx)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	_, err = pool.Exec(ctx, `INSERT INTO tenancy.tenants (name, code) VALUES ('Acme', 'ACME')`)
+	require.NoError(t, err)
+
+	var tenants, workspaces int
+	require.NoError(t, pool.QueryRow(ctx, `SELECT count(*) FROM tenancy.tenants`).Scan(&tenants))
+	require.NoError(t, pool.QueryRow(ctx, `SELECT count(*) FROM tenancy.workspaces`).Scan(&workspaces))
+
+	svc := backup.New(pool, nil)
+
+	var archive bytes.Buffer
+	manifest, err := svc.Create(ctx, &archive)
+	require.NoError(t, err)
+	assert.NotZero(t, manifest.SchemaVersion)
+	assert.NotEmpty(t, manifest.Tables)
+
+	_, err = pool.Exec(ctx, `UPDATE tenancy.tenants SET name = 'Changed' WHERE code = 'ACME'`)
+	require.NoError(t, err)
+
+	_, err = svc.Restore(ctx, &archive)
+	require.NoError(t, err)
+
+	var restoredTenants, restoredWorkspaces int
+	require.NoError(t, pool.QueryRow(ctx, `SELECT count(*) FROM tenancy.tenants`).Scan(&restoredTenants))
+	require.NoError(t, pool.QueryRow(ctx, `SELECT count(*) FROM tenancy.workspaces`).Scan(&restoredWorkspaces))
+	assert.Equal(t, tenants, restoredTenants)
+
+	var name string
+	require.NoError(t, pool.QueryRow(ctx, `SELECT name FROM tenancy.tenants WHERE code = 'ACME'`).Scan(&name))
+	assert.Equal(t, "Acme", name)
+	assert.Equal(t, workspaces, restoredWorkspaces, "restore must not re-run workspace auto-creation triggers")
+}
@@ -0,0 +1,138 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExtractStorageKeys(t *testing.T) {
+	content := `{"type":"doc","content":[{"type":"image","attrs":{"src":"storage://tenants/T1/workspaces/W1/gallery/logo.png"}},` +
+		`{"type":"image","attrs":{"src":"https://example.com/a.png"}},{"type":"image","attrs":{"src":"storage://k2.jpg"}}]}`
+
+	got := extractStorageKeys(content)
+	want := []string{"tenants/T1/workspaces/W1/gallery/logo.png", "k2.jpg"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("extractStorageKeys() = %v, want %v", got, want)
+	}
+}
+
+func TestSortByDependencies(t *testing.T) {
+	tables := []Table{
+		{Schema: "content", Name: "templates"},
+		{Schema: "organizer", Name: "folders"},
+		{Schema: "tenancy", Name: "workspaces"},
+		{Schema: "tenancy", Name: "tenants"},
+	}
+	deps := map[string][]string{
+		`"content"."templates"`:  {`"tenancy"."workspaces"`, `"organizer"."folders"`},
+		`"organizer"."folders"`:  {`"tenancy"."workspaces"`, `"organizer"."folders"`}, // self reference
+		`"tenancy"."workspaces"`: {`"tenancy"."tenants"`},
+		`"tenancy"."tenants"`:    {`"public"."unrelated"`},
+	}
+
+	ordered, err := sortByDependencies(tables, deps)
+	if err != nil {
+		t.Fatalf("sortByDependencies() error = %v", err)
+	}
+	var got []string
+	for _, table := range ordered {
+		got = append(got, table.Name)
+	}
+	want := []string{"tenants", "workspaces", "folders", "templates"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+
+	deps[`"tenancy"."tenants"`] = []string{`"content"."templates"`}
+	if _, err := sortByDependencies(tables, deps); err == nil {
+		t.Error("expected an error for circular foreign keys")
+	}
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	src := t.TempDir()
+	writeTestFile(t, src, "tables/tenancy.tenants.csv", "id,name\n1,Acme\n")
+	writeTestFile(t, src, "assets/00001", "png-bytes")
+
+	manifest := &Manifest{
+		FormatVersion: FormatVersion,
+		CreatedAt:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		SchemaVersion: 17,
+		Tables:        []Table{{Schema: "tenancy", Name: "tenants", Columns: []string{"id", "name"}, Rows: 1, File: "tables/tenancy.tenants.csv"}},
+		Assets:        []Asset{{Key: "logo.png", WorkspaceID: "ws-1", File: "assets/00001"}},
+	}
+
+	var buf bytes.Buffer
+	if err := writeArchive(&buf, manifest, src); err != nil {
+		t.Fatalf("writeArchive() error = %v", err)
+	}
+
+	dst := t.TempDir()
+	got, err := readArchive(&buf, dst)
+	if err != nil {
+		t.Fatalf("readArchive() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, manifest) {
+		t.Errorf("manifest = %+v, want %+v", got, manifest)
+	}
+	data, err := os.ReadFile(filepath.Join(dst, "assets", "00001"))
+	if err != nil || string(data) != "png-bytes" {
+		t.Errorf("extracted asset = %q, %v", data, err)
+	}
+}
+
+func TestReadArchive_RejectsUnsafeEntries(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		entry    string
+	}{
+		{"path traversal in manifest", `{"formatVersion":1,"tables":[{"file":"../evil.csv"}]}`, "../evil.csv"},
+		{"entry not in manifest", `{"formatVersion":1,"tables":[]}`, "tables/extra.csv"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			gz := gzip.NewWriter(&buf)
+			tw := tar.NewWriter(gz)
+			addTestEntry(t, tw, manifestName, tt.manifest)
+			addTestEntry(t, tw, tt.entry, "x")
+			_ = tw.Close()
+			_ = gz.Close()
+
+			if _, err := readArchive(&buf, t.TempDir()); !errors.Is(err, ErrInvalidArchive) {
+				t.Errorf("readArchive() = %v, want %v", err, ErrInvalidArchive)
+			}
+		})
+	}
+}
+
+func writeTestFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	f, err := createFile(dir, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func addTestEntry(t *testing.T, tw *tar.Writer, name, content string) {
+	t.Helper()
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(content))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+}
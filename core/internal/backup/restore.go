@@ -0,0 +1,312 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/database/postgres"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// Restore replaces the content of the pdf-forge schemas with the archive read from r.
+// The database must be migrated to the same schema version as the archive. Assets are
+// uploaded first; tables are then reloaded in a single transaction, so a failed restore
+// leaves the database untouched. Stop the API (or enable maintenance mode) while restoring.
+func (s *Service) Restore(ctx context.Context, r io.Reader) (*Manifest, error) {
+	dir, err := os.MkdirTemp("", "pdf-forge-restore-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating work directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	manifest, err := readArchive(r, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := schemaVersion(ctx, s.pool)
+	if err != nil {
+		return nil, err
+	}
+	if current != manifest.SchemaVersion {
+		return nil, fmt.Errorf("%w: archive is at %d, database is at %d", ErrSchemaMismatch, manifest.SchemaVersion, current)
+	}
+
+	renamed, err := s.uploadAssets(ctx, manifest.Assets, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = postgres.WithoutQueryTimeout(ctx)
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("beginning restore: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := disableStatementTimeout(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	if err := restoreTables(ctx, tx, manifest.Tables, dir, renamed); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing restore: %w", err)
+	}
+	return manifest, nil
+}
+
+// restoreTables truncates the archived tables and reloads them in foreign key order.
+// User triggers are disabled during the load: the rows already carry the values those
+// triggers derive (timestamps, paths, caches, auto-created memberships).
+func restoreTables(ctx context.Context, tx pgx.Tx, tables []Table, dir string, renamed map[string]string) error {
+	existing, err := listTables(ctx, tx)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]bool, len(existing))
+	for _, t := range existing {
+		known[t.QualifiedName()] = true
+	}
+
+	names := make([]string, len(tables))
+	for i, t := range tables {
+		if !known[t.QualifiedName()] {
+			return fmt.Errorf("%w: table %s does not exist", ErrSchemaMismatch, t.QualifiedName())
+		}
+		names[i] = t.QualifiedName()
+	}
+
+	deps, err := foreignKeys(ctx, tx)
+	if err != nil {
+		return err
+	}
+	ordered, err := sortByDependencies(tables, deps)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, "TRUNCATE "+strings.Join(names, ", ")); err != nil {
+		return fmt.Errorf("truncating tables: %w", err)
+	}
+	for _, name := range names {
+		if _, err := tx.Exec(ctx, "ALTER TABLE "+name+" DISABLE TRIGGER USER"); err != nil {
+			return fmt.Errorf("disabling triggers on %s: %w", name, err)
+		}
+	}
+
+	for _, t := range ordered {
+		if err := loadTable(ctx, tx, t, dir); err != nil {
+			return err
+		}
+	}
+	if err := rewriteAssetKeys(ctx, tx, renamed); err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if _, err := tx.Exec(ctx, "ALTER TABLE "+name+" ENABLE TRIGGER USER"); err != nil {
+			return fmt.Errorf("enabling triggers on %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// loadTable copies dir/t.File into the table and checks every row was loaded.
+func loadTable(ctx context.Context, tx pgx.Tx, t Table, dir string) error {
+	f, err := os.Open(filepath.Join(dir, filepath.FromSlash(t.File)))
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", t.File, err)
+	}
+	defer f.Close()
+
+	query := fmt.Sprintf("COPY %s (%s) FROM STDIN WITH (FORMAT csv, HEADER true)", t.QualifiedName(), t.columnList())
+	tag, err := tx.Conn().PgConn().CopyFrom(ctx, f, query)
+	if err != nil {
+		return fmt.Errorf("loading %s: %w", t.QualifiedName(), err)
+	}
+	if tag.RowsAffected() != t.Rows {
+		return fmt.Errorf("%w: %s has %d rows, manifest lists %d", ErrInvalidArchive, t.QualifiedName(), tag.RowsAffected(), t.Rows)
+	}
+	return nil
+}
+
+// foreignKeys returns, for each table of the pdf-forge schemas, the tables it references.
+func foreignKeys(ctx context.Context, q querier) (map[string][]string, error) {
+	rows, err := q.Query(ctx, `
+		SELECT cn.nspname::text, c.relname::text, rn.nspname::text, r.relname::text
+		FROM pg_constraint k
+		JOIN pg_class c ON c.oid = k.conrelid
+		JOIN pg_namespace cn ON cn.oid = c.relnamespace
+		JOIN pg_class r ON r.oid = k.confrelid
+		JOIN pg_namespace rn ON rn.oid = r.relnamespace
+		WHERE k.contype = 'f' AND cn.nspname = ANY($1)`,
+		Schemas,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing foreign keys: %w", err)
+	}
+	defer rows.Close()
+
+	deps := make(map[string][]string)
+	for rows.Next() {
+		var schema, table, refSchema, refTable string
+		if err := rows.Scan(&schema, &table, &refSchema, &refTable); err != nil {
+			return nil, fmt.Errorf("scanning foreign key: %w", err)
+		}
+		name := pgx.Identifier{schema, table}.Sanitize()
+		deps[name] = append(deps[name], pgx.Identifier{refSchema, refTable}.Sanitize())
+	}
+	return deps, rows.Err()
+}
+
+// sortByDependencies orders tables so referenced tables are loaded before the tables
+// referencing them. Self references are fine: COPY checks them at the end of the statement.
+func sortByDependencies(tables []Table, deps map[string][]string) ([]Table, error) {
+	byName := make(map[string]Table, len(tables))
+	for _, t := range tables {
+		byName[t.QualifiedName()] = t
+	}
+
+	pending := make(map[string]int, len(tables))
+	dependents := make(map[string][]string)
+	for name := range byName {
+		for _, ref := range deps[name] {
+			if ref == name {
+				continue
+			}
+			if _, ok := byName[ref]; !ok {
+				continue
+			}
+			pending[name]++
+			dependents[ref] = append(dependents[ref], name)
+		}
+	}
+
+	var ready []string
+	for name := range byName {
+		if pending[name] == 0 {
+			ready = append(ready, name)
+		}
+	}
+
+	ordered := make([]Table, 0, len(tables))
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		name := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, byName[name])
+		for _, dependent := range dependents[name] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(ordered) != len(tables) {
+		return nil, fmt.Errorf("ordering tables: circular foreign keys between %d tables", len(tables)-len(ordered))
+	}
+	return ordered, nil
+}
+
+// rewriteAssetKeys points template content to the keys assets received when re-uploaded.
+func rewriteAssetKeys(ctx context.Context, tx pgx.Tx, renamed map[string]string) error {
+	for oldKey, newKey := range renamed {
+		if _, err := tx.Exec(ctx, `
+			UPDATE content.template_versions
+			SET content_structure = replace(content_structure::text, $1, $2)::jsonb
+			WHERE strpos(content_structure::text, $1) > 0`,
+			"storage://"+oldKey, "storage://"+newKey,
+		); err != nil {
+			return fmt.Errorf("rewriting asset key %s: %w", oldKey, err)
+		}
+	}
+	return nil
+}
+
+// uploadAssets uploads the archived assets through the storage provider and returns the
+// keys that changed. Assets already present (same SHA-256) are reused.
+func (s *Service) uploadAssets(ctx context.Context, assets []Asset, dir string) (map[string]string, error) {
+	renamed := make(map[string]string)
+	if len(assets) == 0 {
+		return renamed, nil
+	}
+	if s.storage == nil {
+		return nil, fmt.Errorf("%w: %d assets", ErrStorageNotAvailable, len(assets))
+	}
+
+	for _, asset := range assets {
+		key, err := s.uploadAsset(ctx, asset, dir)
+		if err != nil {
+			return nil, fmt.Errorf("restoring asset %s: %w", asset.Key, err)
+		}
+		if key != asset.Key {
+			renamed[asset.Key] = key
+		}
+	}
+
+	slog.InfoContext(ctx, "assets restored", slog.Int("assets", len(assets)), slog.Int("renamed", len(renamed)))
+	return renamed, nil
+}
+
+// uploadAsset runs the InitUpload, PUT, CompleteUpload flow used by the gallery and returns the stored key.
+func (s *Service) uploadAsset(ctx context.Context, asset Asset, dir string) (string, error) {
+	storageCtx := asset.storageContext()
+	init, err := s.storage.InitUpload(ctx, &port.StorageInitUploadRequest{
+		Storage:     storageCtx,
+		Filename:    path.Base(asset.Key),
+		ContentType: asset.ContentType,
+		Size:        asset.Size,
+		SHA256:      asset.SHA256,
+	})
+	if err != nil {
+		return "", fmt.Errorf("initializing upload: %w", err)
+	}
+	if init.Duplicate && init.Asset != nil {
+		return init.Asset.Key, nil
+	}
+
+	f, err := os.Open(filepath.Join(dir, filepath.FromSlash(asset.File)))
+	if err != nil {
+		return "", fmt.Errorf("opening %s: %w", asset.File, err)
+	}
+	defer f.Close()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, init.SignedURL, f)
+	if err != nil {
+		return "", fmt.Errorf("building request: %w", err)
+	}
+	req.ContentLength = asset.Size
+	if asset.ContentType != "" {
+		req.Header.Set("Content-Type", asset.ContentType)
+	}
+	for k, v := range init.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("uploading: %w", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("uploading: unexpected status %d", resp.StatusCode)
+	}
+
+	done, err := s.storage.CompleteUpload(ctx, &port.StorageCompleteUploadRequest{Storage: storageCtx, UploadID: init.UploadID})
+	if err != nil {
+		return "", fmt.Errorf("completing upload: %w", err)
+	}
+	return done.Asset.Key, nil
+}
@@ -0,0 +1,14 @@
+package entity
+
+// AddressValue is a postal address. Renders lay its fields out by the postal conventions of its
+// country: line order, where the postcode goes and which fields are capitalized.
+type AddressValue struct {
+	Name         string   `json:"name,omitempty"`         // Recipient
+	Organization string   `json:"organization,omitempty"` // Company or department
+	Lines        []string `json:"lines,omitempty"`        // Street, number, floor, apartment...
+	District     string   `json:"district,omitempty"`     // Neighborhood below the city: bairro, colonia, comuna
+	City         string   `json:"city,omitempty"`
+	Region       string   `json:"region,omitempty"` // State, province or county
+	PostalCode   string   `json:"postalCode,omitempty"`
+	Country      string   `json:"country,omitempty"` // ISO 3166-1 alpha-2 code of the destination
+}
@@ -0,0 +1,174 @@
+package entity
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// AssetMaxSize is the largest file accepted by the asset library. Uploads are base64-encoded JSON,
+// so the request stays within the default body limit.
+const AssetMaxSize = 10 << 20
+
+// AssetURLScheme prefixes the URLs that reference library assets from ContentStructure,
+// e.g. asset://<id>. Renders resolve them to the current version of the asset.
+const AssetURLScheme = "asset://"
+
+// AssetKind classifies the files of the asset library.
+type AssetKind string
+
+const (
+	AssetKindImage AssetKind = "IMAGE" // PNG, JPEG, GIF, WebP or SVG
+	AssetKindPDF   AssetKind = "PDF"   // Included with externalPdf nodes
+	AssetKindFont  AssetKind = "FONT"  // TrueType, OpenType or WOFF
+)
+
+// IsValid checks if the asset kind is valid.
+func (k AssetKind) IsValid() bool {
+	switch k {
+	case AssetKindImage, AssetKindPDF, AssetKindFont:
+		return true
+	}
+	return false
+}
+
+// DetectAssetType identifies an uploaded file from its content, so the declared type of an upload
+// is never trusted. It returns false for files the library does not accept.
+func DetectAssetType(data []byte) (AssetKind, string, bool) {
+	switch {
+	case bytes.HasPrefix(data, []byte("%PDF-")):
+		return AssetKindPDF, "application/pdf", true
+	case bytes.HasPrefix(data, []byte{0x00, 0x01, 0x00, 0x00}):
+		return AssetKindFont, "font/ttf", true
+	case bytes.HasPrefix(data, []byte("OTTO")):
+		return AssetKindFont, "font/otf", true
+	case bytes.HasPrefix(data, []byte("ttcf")):
+		return AssetKindFont, "font/collection", true
+	case bytes.HasPrefix(data, []byte("wOFF")):
+		return AssetKindFont, "font/woff", true
+	case bytes.HasPrefix(data, []byte("wOF2")):
+		return AssetKindFont, "font/woff2", true
+	}
+
+	switch ct := http.DetectContentType(data); {
+	case ct == "image/png", ct == "image/jpeg", ct == "image/gif", ct == "image/webp":
+		return AssetKindImage, ct, true
+	case isSVG(data):
+		return AssetKindImage, "image/svg+xml", true
+	}
+	return "", "", false
+}
+
+// isSVG reports whether data is an SVG document, possibly preceded by an XML declaration or comments.
+func isSVG(data []byte) bool {
+	head := data[:min(len(data), 1024)]
+	return bytes.Contains(bytes.ToLower(head), []byte("<svg"))
+}
+
+// Asset is a file of a workspace's asset library. Its content is versioned: templates reference
+// the asset, and every render uses its current version.
+type Asset struct {
+	ID             string     `json:"id"`
+	WorkspaceID    string     `json:"workspaceId"`
+	Name           string     `json:"name"`
+	Kind           AssetKind  `json:"kind"`
+	Tags           []string   `json:"tags"`
+	CurrentVersion int        `json:"currentVersion"`
+	CreatedBy      *string    `json:"createdBy,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      *time.Time `json:"updatedAt,omitempty"`
+	// SourceURL is the remote URL the asset was imported from. Renders use the asset instead of
+	// downloading images with this URL.
+	SourceURL *string `json:"sourceUrl,omitempty"`
+
+	// Of the current version; filled by queries
+	ContentType string `json:"contentType"`
+	SizeBytes   int    `json:"sizeBytes"`
+	SHA256      string `json:"sha256"`
+}
+
+// NewAsset creates an asset at version 1.
+func NewAsset(workspaceID, name string, kind AssetKind, tags []string, createdBy *string) *Asset {
+	return &Asset{
+		WorkspaceID:    workspaceID,
+		Name:           strings.TrimSpace(name),
+		Kind:           kind,
+		Tags:           NormalizeAssetTags(tags),
+		CurrentVersion: 1,
+		CreatedBy:      createdBy,
+		CreatedAt:      time.Now().UTC(),
+	}
+}
+
+// URL returns the URL that references the asset from ContentStructure.
+func (a *Asset) URL() string {
+	return AssetURLScheme + a.ID
+}
+
+// Validate checks if the asset data is valid.
+func (a *Asset) Validate() error {
+	if a.WorkspaceID == "" || a.Name == "" {
+		return ErrRequiredField
+	}
+	if len(a.Name) > 255 {
+		return ErrFieldTooLong
+	}
+	if !a.Kind.IsValid() {
+		return ErrUnsupportedAssetType
+	}
+	return nil
+}
+
+// NormalizeAssetTags normalizes tags like template tag names and drops empty and repeated ones.
+func NormalizeAssetTags(tags []string) []string {
+	result := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = NormalizeTagName(tag)
+		if _, ok := seen[tag]; ok || tag == "" {
+			continue
+		}
+		seen[tag] = struct{}{}
+		result = append(result, tag)
+	}
+	return result
+}
+
+// AssetVersion is one uploaded content of an asset.
+type AssetVersion struct {
+	AssetID     string `json:"assetId"`
+	Version     int    `json:"version"`
+	ContentType string `json:"contentType"`
+	SizeBytes   int    `json:"sizeBytes"`
+	SHA256      string `json:"sha256"`
+	Data        []byte `json:"-"` // Loaded only when the content is requested
+	// StorageKey is the object storage key of the content when it is not kept in the database.
+	StorageKey string    `json:"-"`
+	CreatedBy  *string   `json:"createdBy,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// AssetUsage is a template version whose content references an asset.
+type AssetUsage struct {
+	TemplateID    string        `json:"templateId"`
+	TemplateTitle string        `json:"templateTitle"`
+	VersionID     string        `json:"versionId"`
+	VersionNumber int           `json:"versionNumber"`
+	VersionStatus VersionStatus `json:"versionStatus"`
+}
+
+// AssetSignedURL is a URL that serves the current version of an asset without an account
+// until it expires.
+type AssetSignedURL struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// AssetImage is an image asset resized and encoded for display.
+type AssetImage struct {
+	ContentType string
+	Data        []byte
+	// Key identifies the source content and the transformation, so equal keys mean equal bytes.
+	Key string
+}
@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAuthSessionCheckToken(t *testing.T) {
+	reauthAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	before := reauthAt.Add(-time.Minute)
+	after := reauthAt.Add(time.Minute)
+
+	tests := []struct {
+		name     string
+		revoked  bool
+		reauth   *time.Time
+		issuedAt *time.Time
+		wantErr  error
+	}{
+		{"unrestricted", false, nil, nil, nil},
+		{"revoked", true, nil, &after, ErrSessionRevoked},
+		{"token issued before reauth", false, &reauthAt, &before, ErrReauthRequired},
+		{"token issued at reauth", false, &reauthAt, &reauthAt, ErrReauthRequired},
+		{"token without iat", false, &reauthAt, nil, ErrReauthRequired},
+		{"token issued after reauth", false, &reauthAt, &after, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewAuthSession(PrincipalTypeUser, "panel", "sub-1")
+			s.ReauthRequiredAt = tt.reauth
+			if tt.revoked {
+				s.RevokedAt = &reauthAt
+			}
+			if err := s.CheckToken(tt.issuedAt); !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckToken() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthSessionActions(t *testing.T) {
+	user := NewAuthSession(PrincipalTypeUser, "panel", "sub-1")
+	if err := user.CanRevoke(); !errors.Is(err, ErrCannotRevokeUserSession) {
+		t.Errorf("CanRevoke() on user = %v, want %v", err, ErrCannotRevokeUserSession)
+	}
+	if err := user.CanForceReauth(); err != nil {
+		t.Errorf("CanForceReauth() on user = %v, want nil", err)
+	}
+
+	key := NewAuthSession(PrincipalTypeAPIKey, "custom", "key-1")
+	if err := key.CanForceReauth(); !errors.Is(err, ErrCannotForceReauth) {
+		t.Errorf("CanForceReauth() on API key = %v, want %v", err, ErrCannotForceReauth)
+	}
+	if err := key.CanRevoke(); err != nil {
+		t.Errorf("CanRevoke() on API key = %v, want nil", err)
+	}
+
+	now := time.Now()
+	key.RevokedAt = &now
+	if err := key.CanRevoke(); !errors.Is(err, ErrSessionAlreadyRevoked) {
+		t.Errorf("CanRevoke() on revoked key = %v, want %v", err, ErrSessionAlreadyRevoked)
+	}
+}
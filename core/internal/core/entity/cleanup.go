@@ -0,0 +1,54 @@
+package entity
+
+import "time"
+
+// CleanupCategory identifies a kind of orphaned data removed by cleanup runs.
+type CleanupCategory string
+
+const (
+	CleanupOrphanedVersionInjectables CleanupCategory = "ORPHANED_VERSION_INJECTABLES" // Version injectables whose version no longer exists
+	CleanupUnusedImages               CleanupCategory = "UNUSED_IMAGES"                // Image assets no template version of their workspace references
+	CleanupExpiredPreviewTokens       CleanupCategory = "EXPIRED_PREVIEW_TOKENS"       // Preview tokens expired or revoked past their retention
+	CleanupStaleSandboxes             CleanupCategory = "STALE_SANDBOXES"              // Sandboxes past their expiry or whose source workspace was deleted
+)
+
+// CleanupCategories lists the categories in the order cleanup runs process them.
+var CleanupCategories = []CleanupCategory{
+	CleanupOrphanedVersionInjectables,
+	CleanupUnusedImages,
+	CleanupExpiredPreviewTokens,
+	CleanupStaleSandboxes,
+}
+
+// CleanupSampleSize bounds the IDs kept in a result to show what a run found.
+const CleanupSampleSize = 20
+
+// CleanupResult is the outcome of a cleanup run for one category.
+type CleanupResult struct {
+	Category  CleanupCategory
+	Found     int      // Orphans found, up to the per-run limit
+	Deleted   int      // Always 0 in dry runs and for kept categories
+	Kept      bool     // Deletion is disabled for the category; orphans are only reported
+	SampleIDs []string // Up to CleanupSampleSize of the IDs found
+	Error     *string
+}
+
+// CleanupRun records one run of the orphaned data cleanup, scheduled or requested by an admin.
+type CleanupRun struct {
+	ID          string
+	DryRun      bool
+	Results     []*CleanupResult
+	TriggeredBy *string // nil = scheduled
+	StartedAt   time.Time
+	FinishedAt  time.Time
+}
+
+// Failed returns true if the cleanup of any category failed.
+func (r *CleanupRun) Failed() bool {
+	for _, result := range r.Results {
+		if result.Error != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// CurrencyRoundingMode is how a converted amount is rounded to the minor units of its currency.
+type CurrencyRoundingMode string
+
+const (
+	CurrencyRoundHalfUp   CurrencyRoundingMode = "HALF_UP"   // Nearest, halves away from zero: 0.125 → 0.13
+	CurrencyRoundHalfEven CurrencyRoundingMode = "HALF_EVEN" // Nearest, halves to the even digit: 0.125 → 0.12
+	CurrencyRoundDown     CurrencyRoundingMode = "DOWN"      // Toward zero
+	CurrencyRoundUp       CurrencyRoundingMode = "UP"        // Away from zero
+)
+
+// IsValid checks if the rounding mode is valid.
+func (m CurrencyRoundingMode) IsValid() bool {
+	switch m {
+	case CurrencyRoundHalfUp, CurrencyRoundHalfEven, CurrencyRoundDown, CurrencyRoundUp:
+		return true
+	}
+	return false
+}
+
+// CurrencyRateTable is the exchange rates a provider published on one date against its base currency.
+type CurrencyRateTable struct {
+	Provider string
+	Base     string
+	// Date is when the rates were published, earlier than the requested date on weekends and holidays.
+	Date time.Time
+	// Rates are the units of each currency one unit of Base buys, by ISO 4217 code.
+	Rates map[string]float64
+}
+
+// Rate returns the units of to one unit of from buys, crossing through the base currency.
+func (t *CurrencyRateTable) Rate(from, to string) (float64, bool) {
+	fromRate, ok := t.baseRate(from)
+	if !ok {
+		return 0, false
+	}
+	toRate, ok := t.baseRate(to)
+	if !ok {
+		return 0, false
+	}
+	return toRate / fromRate, true
+}
+
+func (t *CurrencyRateTable) baseRate(code string) (float64, bool) {
+	if code == t.Base {
+		return 1, true
+	}
+	rate, ok := t.Rates[code]
+	return rate, ok && rate > 0
+}
+
+// CurrencyRate is an exchange rate a render converted amounts with, as recorded in RenderCompleted.
+type CurrencyRate struct {
+	Provider string    `json:"provider"`
+	From     string    `json:"from"`
+	To       string    `json:"to"`
+	Rate     float64   `json:"rate"` // Units of To one unit of From buys
+	Date     time.Time `json:"date"` // Publication date of the rate
+}
+
+// RenderCurrencyRates records the exchange rates a render converted amounts with, so the amounts of
+// a document can be audited after the render.
+type RenderCurrencyRates struct {
+	ID               string
+	TenantCode       string
+	WorkspaceCode    string
+	DocumentTypeCode *string // nil when rendered by version ID
+	VersionID        string
+	TemplateID       string
+	Environment      Environment
+	DocumentNumber   *string // nil when the render drew no number
+	JobID            *string // nil when the render did not run for a render job
+	Rates            []CurrencyRate
+	RenderedAt       time.Time
+}
+
+// CurrencyConvertFunc converts an amount between two ISO 4217 currencies, rounded to the minor
+// units of the target currency.
+type CurrencyConvertFunc func(amount float64, from, to string) (float64, error)
+
+// currencyMinorUnits are the ISO 4217 currencies without two decimals.
+var currencyMinorUnits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// NormalizeCurrencyCode returns code trimmed and upper-cased.
+func NormalizeCurrencyCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// CurrencyMinorUnits returns the decimals amounts of an ISO 4217 currency are written with.
+func CurrencyMinorUnits(code string) int {
+	if units, ok := currencyMinorUnits[NormalizeCurrencyCode(code)]; ok {
+		return units
+	}
+	return 2
+}
+
+// RoundCurrency rounds amount to the minor units of currency. An unknown mode rounds half up.
+func RoundCurrency(amount float64, currency string, mode CurrencyRoundingMode) float64 {
+	scale := math.Pow10(CurrencyMinorUnits(currency))
+	// Drop the binary noise of the multiplication, so 1.005 rounds as 100.5 cents and not 100.4999
+	scaled := math.Round(amount*scale*1e6) / 1e6
+
+	switch mode {
+	case CurrencyRoundHalfEven:
+		scaled = math.RoundToEven(scaled)
+	case CurrencyRoundDown:
+		scaled = math.Trunc(scaled)
+	case CurrencyRoundUp:
+		scaled = math.Copysign(math.Ceil(math.Abs(scaled)), scaled)
+	default:
+		scaled = math.Round(scaled)
+	}
+	return scaled / scale
+}
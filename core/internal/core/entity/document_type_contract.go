@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DocumentTypeContract is the payload contract of a document type: the JSON Schema render
+// requests for the type must satisfy, and an example payload for integrating teams.
+type DocumentTypeContract struct {
+	DocumentTypeID string          `json:"documentTypeId"`
+	Schema         json.RawMessage `json:"schema"`
+	Example        json.RawMessage `json:"example,omitempty"`
+	UpdatedBy      *string         `json:"updatedBy,omitempty"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	UpdatedAt      *time.Time      `json:"updatedAt,omitempty"`
+}
+
+// Validate checks that the schema is supported and that the example, when set, satisfies it.
+func (c *DocumentTypeContract) Validate() error {
+	schema, err := CompilePayloadSchema(c.Schema)
+	if err != nil {
+		return err
+	}
+	if len(c.Example) == 0 || string(c.Example) == "null" {
+		c.Example = nil
+		return nil
+	}
+
+	var example any
+	if err := json.Unmarshal(c.Example, &example); err != nil {
+		return fmt.Errorf("%w: example is not valid JSON", ErrInvalidPayloadContract)
+	}
+	if violations := schema.Validate(example); len(violations) > 0 {
+		return fmt.Errorf("%w: example does not satisfy the schema: %s %s",
+			ErrInvalidPayloadContract, violations[0].Path, violations[0].Message)
+	}
+	return nil
+}
+
+// ValidatePayload checks a render payload against the contract. Returns a *PayloadContractError
+// listing the violations when it does not satisfy the schema.
+func (c *DocumentTypeContract) ValidatePayload(payload map[string]any) error {
+	schema, err := CompilePayloadSchema(c.Schema)
+	if err != nil {
+		return err
+	}
+	if payload == nil {
+		payload = map[string]any{}
+	}
+	if violations := schema.Validate(payload); len(violations) > 0 {
+		return &PayloadContractError{Violations: violations}
+	}
+	return nil
+}
+
+// PayloadContractError reports a render payload that does not satisfy the contract of its
+// document type.
+type PayloadContractError struct {
+	Violations []ContractViolation
+}
+
+// Error implements the error interface.
+func (e *PayloadContractError) Error() string {
+	if len(e.Violations) == 0 {
+		return ErrPayloadContractViolation.Error()
+	}
+	v := e.Violations[0]
+	return fmt.Sprintf("%s: %s %s", ErrPayloadContractViolation, v.Path, v.Message)
+}
+
+// Unwrap allows errors.Is(err, ErrPayloadContractViolation).
+func (e *PayloadContractError) Unwrap() error {
+	return ErrPayloadContractViolation
+}
@@ -0,0 +1,180 @@
+package entity
+
+import "time"
+
+// DomainEventType identifies a domain event delivered to in-process subscribers.
+type DomainEventType string
+
+// DomainEventType values.
+const (
+	EventVersionPublished      DomainEventType = "version.published"
+	EventVersionArchived       DomainEventType = "version.archived"
+	EventRenderCompleted       DomainEventType = "render.completed"
+	EventRenderFailed          DomainEventType = "render.failed"
+	EventRenderJobAbandoned    DomainEventType = "render_job.abandoned"
+	EventInjectableDeactivated DomainEventType = "injectable.deactivated"
+	EventMemberInvited         DomainEventType = "member.invited"
+	EventTemplateSLABreached   DomainEventType = "template.sla_breached"
+	EventTemplateSLARecovered  DomainEventType = "template.sla_recovered"
+)
+
+// DomainEvent is implemented by every typed domain event.
+type DomainEvent interface {
+	EventType() DomainEventType
+}
+
+// VersionPublished is emitted after a template version is published, manually or by schedule.
+type VersionPublished struct {
+	VersionID     string            `json:"versionId"`
+	TemplateID    string            `json:"templateId"`
+	WorkspaceID   string            `json:"workspaceId"`
+	VersionNumber int               `json:"versionNumber"`
+	PublishedBy   *string           `json:"publishedBy,omitempty"`
+	PublishedAt   time.Time         `json:"publishedAt"`
+	Changelog     *VersionChangelog `json:"changelog,omitempty"`
+}
+
+// EventType implements DomainEvent.
+func (VersionPublished) EventType() DomainEventType { return EventVersionPublished }
+
+// VersionArchived is emitted after a published version is archived, manually, by schedule
+// or because another version of its template was published.
+type VersionArchived struct {
+	VersionID     string    `json:"versionId"`
+	TemplateID    string    `json:"templateId"`
+	WorkspaceID   string    `json:"workspaceId"`
+	VersionNumber int       `json:"versionNumber"`
+	ArchivedBy    *string   `json:"archivedBy,omitempty"`
+	ArchivedAt    time.Time `json:"archivedAt"`
+	ReplacedBy    *string   `json:"replacedBy,omitempty"` // ID of the version whose publication archived it
+}
+
+// EventType implements DomainEvent.
+func (VersionArchived) EventType() DomainEventType { return EventVersionArchived }
+
+// RenderCompleted is emitted after a PDF is rendered through the render API.
+// Failed renders emit RenderFailed instead.
+type RenderCompleted struct {
+	VersionID     string          `json:"versionId"`
+	TemplateID    string          `json:"templateId"`
+	TenantCode    string          `json:"tenantCode"`
+	WorkspaceCode string          `json:"workspaceCode"`
+	DocumentType  string          `json:"documentType,omitempty"` // empty when rendered by version ID
+	Environment   Environment     `json:"environment"`
+	PageCount     int             `json:"pageCount"`
+	SizeBytes     int             `json:"sizeBytes"`
+	Duration      time.Duration   `json:"duration"`
+	Warnings      []RenderWarning `json:"warnings,omitempty"`
+	CompletedAt   time.Time       `json:"completedAt"`
+
+	// InjectorTimings are the timings of the registry injectors resolved for the render, sorted by code.
+	InjectorTimings []InjectorTiming `json:"injectorTimings,omitempty"`
+
+	// InjectableSources are where the value of each injectable of the version came from, by key.
+	InjectableSources map[string]InjectableSource `json:"injectableSources,omitempty"`
+
+	// CurrencyRates are the exchange rates the render converted amounts with, sorted by currency pair.
+	CurrencyRates []CurrencyRate `json:"currencyRates,omitempty"`
+
+	// CurrencyRecordID is the ID of the stored record of CurrencyRates, empty when no amount was converted.
+	CurrencyRecordID string `json:"currencyRecordId,omitempty"`
+
+	// DocumentNumber is the number drawn from the numbering sequence of the document type, if any.
+	DocumentNumber string `json:"documentNumber,omitempty"`
+}
+
+// EventType implements DomainEvent.
+func (RenderCompleted) EventType() DomainEventType { return EventRenderCompleted }
+
+// RenderFailed is emitted when a resolved template version fails to render through the render API.
+// Requests that fail before a version is resolved do not emit it.
+type RenderFailed struct {
+	VersionID     string          `json:"versionId"`
+	TemplateID    string          `json:"templateId"`
+	TenantCode    string          `json:"tenantCode"`
+	WorkspaceCode string          `json:"workspaceCode"`
+	DocumentType  string          `json:"documentType,omitempty"` // empty when rendered by version ID
+	Environment   Environment     `json:"environment"`
+	Error         string          `json:"error"`
+	ErrorCode     RenderErrorCode `json:"errorCode,omitempty"` // empty when the failure was not classified
+	Retryable     bool            `json:"retryable"`
+	FailureID     string          `json:"failureId,omitempty"` // captured Typst input, for platform admins
+	JobID         string          `json:"jobId,omitempty"`     // set when the render ran for a render job
+	FailedAt      time.Time       `json:"failedAt"`
+}
+
+// EventType implements DomainEvent.
+func (RenderFailed) EventType() DomainEventType { return EventRenderFailed }
+
+// RenderJobAbandoned is emitted once when a render job fails for good. The failed attempts of
+// the job that reached a version also emit RenderFailed, with its JobID.
+type RenderJobAbandoned struct {
+	JobID         string           `json:"jobId"`
+	WorkspaceID   string           `json:"workspaceId"`
+	TenantCode    string           `json:"tenantCode"`
+	WorkspaceCode string           `json:"workspaceCode"`
+	VersionID     *string          `json:"versionId,omitempty"`    // set when the job was submitted for a version
+	DocumentType  *string          `json:"documentType,omitempty"` // set when the job was submitted for a document type
+	Environment   Environment      `json:"environment"`
+	Error         string           `json:"error"`
+	ErrorCode     *RenderErrorCode `json:"errorCode,omitempty"`
+	Attempts      int              `json:"attempts"`
+	FailedAt      time.Time        `json:"failedAt"`
+}
+
+// EventType implements DomainEvent.
+func (RenderJobAbandoned) EventType() DomainEventType { return EventRenderJobAbandoned }
+
+// InjectableDeactivated is emitted when an active workspace injectable is deactivated.
+type InjectableDeactivated struct {
+	InjectableID  string    `json:"injectableId"`
+	WorkspaceID   string    `json:"workspaceId"`
+	Key           string    `json:"key"`
+	DeactivatedAt time.Time `json:"deactivatedAt"`
+}
+
+// EventType implements DomainEvent.
+func (InjectableDeactivated) EventType() DomainEventType { return EventInjectableDeactivated }
+
+// MemberInvited is emitted when a workspace invitation is created.
+type MemberInvited struct {
+	InvitationID string        `json:"invitationId"`
+	WorkspaceID  string        `json:"workspaceId"`
+	Email        string        `json:"email"`
+	Role         WorkspaceRole `json:"role"`
+	InvitedBy    *string       `json:"invitedBy,omitempty"`
+	ExpiresAt    time.Time     `json:"expiresAt"`
+	InvitedAt    time.Time     `json:"invitedAt"`
+}
+
+// EventType implements DomainEvent.
+func (MemberInvited) EventType() DomainEventType { return EventMemberInvited }
+
+// TemplateSLABreached is emitted when the renders of a template stop meeting its SLA.
+// It is emitted once per breach, by one instance.
+type TemplateSLABreached struct {
+	TemplateID    string                 `json:"templateId"`
+	WorkspaceID   string                 `json:"workspaceId"`
+	TemplateTitle string                 `json:"templateTitle"`
+	WindowMinutes int                    `json:"windowMinutes"`
+	Window        TemplateRenderWindow   `json:"window"`
+	Violations    []TemplateSLAViolation `json:"violations"`
+	BreachedAt    time.Time              `json:"breachedAt"`
+}
+
+// EventType implements DomainEvent.
+func (TemplateSLABreached) EventType() DomainEventType { return EventTemplateSLABreached }
+
+// TemplateSLARecovered is emitted when the renders of a template meet its SLA again after a breach.
+type TemplateSLARecovered struct {
+	TemplateID    string               `json:"templateId"`
+	WorkspaceID   string               `json:"workspaceId"`
+	TemplateTitle string               `json:"templateTitle"`
+	WindowMinutes int                  `json:"windowMinutes"`
+	Window        TemplateRenderWindow `json:"window"`
+	BreachedAt    time.Time            `json:"breachedAt"`
+	RecoveredAt   time.Time            `json:"recoveredAt"`
+}
+
+// EventType implements DomainEvent.
+func (TemplateSLARecovered) EventType() DomainEventType { return EventTemplateSLARecovered }
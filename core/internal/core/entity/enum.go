@@ -189,14 +189,15 @@ func (t TenantRole) HasPermission(required TenantRole) bool {
 type MembershipStatus string
 
 const (
-	MembershipStatusPending MembershipStatus = "PENDING"
-	MembershipStatusActive  MembershipStatus = "ACTIVE"
+	MembershipStatusPending  MembershipStatus = "PENDING"
+	MembershipStatusActive   MembershipStatus = "ACTIVE"
+	MembershipStatusInactive MembershipStatus = "INACTIVE" // Deactivated: history kept, access blocked
 )
 
 // IsValid checks if the membership status is valid.
 func (m MembershipStatus) IsValid() bool {
 	switch m {
-	case MembershipStatusPending, MembershipStatusActive:
+	case MembershipStatusPending, MembershipStatusActive, MembershipStatusInactive:
 		return true
 	}
 	return false
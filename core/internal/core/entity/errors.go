@@ -66,6 +66,11 @@ var (
 	ErrCannotModifySystemWorkspace = errors.New("cannot modify system workspace status")
 	ErrInvalidWorkspaceCode        = errors.New("invalid workspace code")
 	ErrWorkspaceCodeExists         = errors.New("workspace code already exists in this tenant")
+	ErrInvalidSandboxTTL           = errors.New("sandbox lifetime must be between 1 hour and 7 days")
+	ErrCannotSandboxWorkspace      = errors.New("global workspaces and sandboxes cannot be sandboxed")
+	ErrSandboxNotFound             = errors.New("workspace is not a sandbox")
+	ErrInvalidWorkspaceSettings    = errors.New("invalid workspace settings")
+	ErrWorkspaceSettingLocked      = errors.New("workspace setting is locked by the tenant")
 )
 
 // User errors.
@@ -79,6 +84,34 @@ var (
 	ErrInvalidEmail      = errors.New("invalid email format")
 )
 
+// User Preferences errors.
+var (
+	ErrUserPreferencesNotFound = errors.New("user preferences not found")
+	ErrInvalidLocale           = errors.New("invalid locale, expected a BCP 47 tag such as en or es-CL")
+	ErrInvalidTimezone         = errors.New("invalid timezone, expected an IANA name such as America/Santiago")
+)
+
+// Auth Session errors.
+var (
+	ErrSessionNotFound         = errors.New("session not found")
+	ErrSessionRevoked          = errors.New("session has been revoked")
+	ErrReauthRequired          = errors.New("re-authentication required, please sign in again")
+	ErrSessionAlreadyRevoked   = errors.New("session is already revoked")
+	ErrSessionNotRevoked       = errors.New("session is not revoked")
+	ErrCannotRevokeUserSession = errors.New("user sessions cannot be revoked, force re-authentication instead")
+	ErrCannotForceReauth       = errors.New("API key sessions cannot be forced to re-authenticate, revoke them instead")
+	ErrInvalidPrincipalType    = errors.New("invalid principal type")
+)
+
+// Maintenance errors.
+var (
+	ErrMaintenanceMode        = errors.New("service is under maintenance, try again later")
+	ErrInvalidMaintenanceMode = errors.New("invalid maintenance mode, expected OFF, READ_ONLY or DRAIN")
+)
+
+// ErrInvalidStatsWindow is returned when the window of the platform stats is out of range.
+var ErrInvalidStatsWindow = errors.New("stats window must be between 1 and 365 days")
+
 // Workspace Member errors.
 var (
 	ErrMemberNotFound          = errors.New("workspace member not found")
@@ -87,6 +120,146 @@ var (
 	ErrCannotRemoveOwner       = errors.New("cannot remove workspace owner")
 	ErrInvalidRole             = errors.New("invalid workspace role")
 	ErrInvalidMembershipStatus = errors.New("invalid membership status")
+	ErrMemberDeactivated       = errors.New("workspace member is deactivated")
+	ErrMemberNotDeactivated    = errors.New("workspace member is not deactivated")
+	ErrCannotDeactivateOwner   = errors.New("cannot deactivate workspace owner, transfer ownership first")
+	ErrLastWorkspaceOwner      = errors.New("workspace must keep at least one owner")
+	ErrInvalidOwnershipTarget  = errors.New("new owner must be another active member of the workspace")
+	ErrInviteeNotRegistered    = errors.New("no user is registered with this email; send an invitation instead")
+)
+
+// Workspace Invitation errors.
+var (
+	ErrInvitationNotFound       = errors.New("invitation not found")
+	ErrInvitationAlreadyPending = errors.New("a pending invitation already exists for this email")
+	ErrInvitationNotPending     = errors.New("invitation is no longer pending")
+	ErrInvitationExpired        = errors.New("invitation has expired")
+	ErrInvitationEmailMismatch  = errors.New("invitation was sent to a different email")
+	ErrInvalidInvitationStatus  = errors.New("invalid invitation status")
+)
+
+// Preview token errors.
+var (
+	ErrPreviewTokenNotFound = errors.New("preview link not found")
+	ErrPreviewTokenExpired  = errors.New("preview link has expired or was revoked")
+	ErrInvalidPreviewTTL    = errors.New("preview link lifetime must be between 1 hour and 30 days")
+)
+
+// Hosted document errors.
+var (
+	ErrHostedDocumentNotFound = errors.New("hosted document not found")
+	ErrInvalidHostedAccess    = errors.New("invalid hosted document access: must be PUBLIC, TOKEN or MEMBERS")
+	ErrInvalidHostedTTL       = errors.New("hosted document lifetime must be between 1 hour and 90 days")
+	ErrDownloadNotAllowed     = errors.New("downloading this document is not allowed")
+	ErrThumbnailNotReady      = errors.New("hosted document thumbnail has not been generated")
+)
+
+// Render job errors.
+var (
+	ErrRenderJobNotFound = errors.New("render job not found")
+	ErrRenderJobNotReady = errors.New("render job has not succeeded; poll its status until it does")
+)
+
+// Render failure capture errors.
+var (
+	ErrRenderFailureNotFound = errors.New("render failure not found or past its retention")
+)
+
+// Object storage errors.
+var (
+	ErrObjectNotFound             = errors.New("storage object not found")
+	ErrObjectStorageNotConfigured = errors.New("no object storage is configured to persist renders")
+	ErrInvalidSignedURL           = errors.New("signed URL is invalid or expired")
+	ErrPersistAndHost             = errors.New("a render can be persisted or hosted, not both")
+)
+
+// Asset library errors.
+var (
+	ErrAssetNotFound          = errors.New("asset not found")
+	ErrAssetTooLarge          = errors.New("the asset exceeds the 10 MiB limit")
+	ErrUnsupportedAssetType   = errors.New("unsupported asset type: must be a PNG, JPEG, GIF, WebP or SVG image, a PDF or a TrueType, OpenType or WOFF font")
+	ErrAssetKindMismatch      = errors.New("a new version of an asset must be of the same kind as the asset")
+	ErrAssetInUse             = errors.New("asset is referenced by templates")
+	ErrAssetNotImage          = errors.New("asset is not an image")
+	ErrInvalidImageOptions    = errors.New("invalid image options: width must be between 1 and 4096 and quality between 1 and 100")
+	ErrUnsupportedImageFormat = errors.New("unsupported image format")
+	ErrInvalidAssetSourceURL  = errors.New("invalid asset source URL: must be an absolute http or https URL")
+	ErrAssetImportFailed      = errors.New("the asset source URL could not be downloaded")
+	ErrAssetSigningDisabled   = errors.New("asset signed URLs are disabled: set assets.signing_key")
+)
+
+// Workspace font errors.
+var (
+	ErrWorkspaceFontNotFound = errors.New("font not found")
+	ErrFontTooLarge          = errors.New("the font exceeds the 10 MiB limit")
+	ErrUnsupportedFontFile   = errors.New("unsupported font: must be a TrueType (.ttf) or OpenType (.otf) font with a family name")
+	ErrFontFaceExists        = errors.New("the workspace already has a font with this family, weight and style")
+	ErrTooManyWorkspaceFonts = errors.New("the workspace has reached the limit of 100 fonts")
+)
+
+// Numbering sequence errors.
+var (
+	ErrNumberingSequenceNotFound = errors.New("numbering sequence not found")
+	ErrNumberingSequenceExists   = errors.New("the workspace already has a numbering sequence for this document type")
+	ErrInvalidNumberingSequence  = errors.New("invalid numbering sequence: padding must be between 0 and 20 and the start number at least 1")
+	ErrSequenceResetLocked       = errors.New("the yearly reset of a numbering sequence cannot change once it has drawn numbers")
+	ErrSequenceNumberReassigned  = errors.New("the sequence number reserved for the render was given to another render")
+)
+
+// ErrInvalidImposition is returned when print imposition options are inconsistent or out of range.
+var ErrInvalidImposition = errors.New("invalid imposition: trim width and height must be set together (up to 1200mm) and bleed must be at most 10mm")
+
+// ErrLayoutNotAllowed is returned when render layout parameters are not declared in the template's layout variants.
+var ErrLayoutNotAllowed = errors.New("layout not allowed: paper size, margin preset and font scale must be declared in the template's layout variants")
+
+// ErrDegradedRenderNotAllowed is returned when a degraded render is requested for a template that does not allow it.
+var ErrDegradedRenderNotAllowed = errors.New("degraded render not allowed: the template must set meta.allowDegradedRender")
+
+// ErrUnknownNodes is returned when a render fails because the document has nodes of unknown types
+// and the workspace renders them with the FAIL mode.
+var ErrUnknownNodes = errors.New("the document has nodes of unknown types")
+
+// Rendering backend errors.
+var (
+	ErrUnknownRenderer        = errors.New("the template selects a rendering backend that is not registered")
+	ErrTypstExportUnavailable = errors.New("the template renders with a backend other than Typst and has no Typst project to export")
+	ErrExternalPDFUnavailable = errors.New("an external PDF included in the document could not be downloaded or read")
+	ErrImageUnavailable       = errors.New("an image included in the document could not be downloaded")
+	ErrHTMLRenderUnavailable  = errors.New("the template renders with a backend other than Typst and has no HTML output")
+	ErrDocxRenderUnavailable  = errors.New("the template renders with a backend other than Typst and has no DOCX output")
+)
+
+// Render output format errors.
+var (
+	ErrUnsupportedRenderFormat = errors.New("unsupported render format: use pdf, html or docx")
+	ErrHTMLRenderOption        = errors.New("html output cannot be hosted, persisted or imposed")
+	ErrDocxRenderOption        = errors.New("docx output cannot be hosted, persisted or imposed")
+	ErrDocxPreviewLink         = errors.New("preview links are view-only and cannot be exported to docx")
+)
+
+// Template import errors.
+var (
+	ErrEmptyTemplateImport    = errors.New("the imported template is empty")
+	ErrTemplateImportTooLarge = errors.New("the imported template exceeds the 2 MiB limit")
+	ErrInvalidTemplateImport  = errors.New("the imported template is malformed")
+	ErrDocxImportTooLarge     = errors.New("the imported DOCX exceeds the 10 MiB limit")
+	ErrInvalidImportMapping   = errors.New("the placeholder mapping references an unknown injectable")
+)
+
+// Template bundle errors.
+var (
+	ErrInvalidTemplateBundle     = errors.New("the template bundle is malformed")
+	ErrUnsupportedTemplateBundle = errors.New("the template bundle was written by a newer version of pdf-forge")
+	ErrTemplateBundleTooLarge    = errors.New("the template bundle exceeds the 50 MiB limit")
+)
+
+// Template library errors.
+var (
+	ErrLibraryTemplateNotFound     = errors.New("template is not in the library of the workspace's tenant")
+	ErrTemplateNotLinked           = errors.New("template was not installed from the library")
+	ErrLibraryTemplateUpToDate     = errors.New("template already has the latest library version")
+	ErrLibraryMergeConflict        = errors.New("local and library changes conflict; pull again with the KEEP_LOCAL or TAKE_UPSTREAM strategy")
+	ErrInvalidLibraryMergeStrategy = errors.New("merge strategy must be KEEP_LOCAL or TAKE_UPSTREAM")
 )
 
 // Folder errors.
@@ -116,7 +289,8 @@ var (
 	ErrInvalidDataType            = errors.New("invalid injectable data type")
 	ErrInvalidInjectableSource    = errors.New("must specify either injectable definition ID or system key, not both")
 	ErrTemplateInjectableNotFound = errors.New("template injectable not found")
-	ErrOnlyTextTypeAllowed        = errors.New("only TEXT type injectables can be created by workspaces")
+	ErrOnlyTextTypeAllowed        = errors.New("only TEXT type injectables can be created by workspaces, or TEXT, NUMBER, DATE and BOOLEAN computed ones")
+	ErrInvalidFormula             = errors.New("invalid injectable formula")
 	ErrWorkspaceIDRequired        = errors.New("workspace ID is required for this injectable")
 	ErrCannotModifyGlobal         = errors.New("cannot modify global injectable definitions")
 )
@@ -129,14 +303,46 @@ var (
 	ErrAssignmentNotFound       = errors.New("system injectable assignment not found")
 )
 
+// Scripted injector errors.
+var (
+	ErrScriptedInjectorNotFound  = errors.New("scripted injector not found")
+	ErrScriptedInjectorExists    = errors.New("an injector with this code already exists")
+	ErrInvalidScriptedInjector   = errors.New("invalid scripted injector")
+	ErrScriptFailed              = errors.New("script failed")
+	ErrScriptedInjectorsDisabled = errors.New("scripted injectors are disabled")
+)
+
+// WASM plugin errors.
+var (
+	ErrInvalidPlugin = errors.New("invalid WASM plugin")
+	ErrPluginFailed  = errors.New("WASM plugin failed")
+)
+
+// Render quota errors.
+var (
+	ErrQuotaExceeded        = errors.New("render quota exceeded")
+	ErrInvalidRenderQuota   = errors.New("invalid render quota")
+	ErrRenderQuotaNotFound  = errors.New("render quota not found")
+	ErrRenderQuotasDisabled = errors.New("render quotas are disabled")
+)
+
+// Remote injector errors.
+var (
+	ErrInvalidRemoteInjector     = errors.New("invalid remote injector")
+	ErrRemoteInjectorFailed      = errors.New("remote injector failed")
+	ErrRemoteInjectorUnavailable = errors.New("remote injector service is unhealthy")
+)
+
 // Document Generation errors.
 var (
-	ErrNoMapperRegistered = errors.New("no mapper registered in registry")
+	ErrNoMapperRegistered      = errors.New("no mapper registered in registry")
+	ErrInjectorDependencyCycle = errors.New("injector dependency cycle detected")
 )
 
 // MissingInjectablesError indicates that required injectables are not available.
 type MissingInjectablesError struct {
 	MissingCodes []string
+	NodePaths    map[string][]string // Document nodes that show each missing code
 }
 
 // Error implements the error interface.
@@ -144,6 +350,28 @@ func (e *MissingInjectablesError) Error() string {
 	return fmt.Sprintf("missing required injectables: %v", e.MissingCodes)
 }
 
+// Concurrency errors.
+var (
+	ErrRevisionConflict = errors.New("resource was modified by another request, reload and try again")
+	ErrRevisionRequired = errors.New("the revision the update is based on is required, as revision or If-Match")
+)
+
+// RevisionConflictError reports a stale update and carries the current state of the
+// resource so clients can merge or reapply their change without another read.
+type RevisionConflictError struct {
+	Current any
+}
+
+// Error implements the error interface.
+func (e *RevisionConflictError) Error() string {
+	return ErrRevisionConflict.Error()
+}
+
+// Unwrap allows errors.Is(err, ErrRevisionConflict).
+func (e *RevisionConflictError) Unwrap() error {
+	return ErrRevisionConflict
+}
+
 // Document Type errors.
 var (
 	ErrDocumentTypeNotFound        = errors.New("document type not found")
@@ -152,13 +380,19 @@ var (
 	ErrDocumentTypeAlreadyAssigned = errors.New("workspace already has a template for this document type")
 	ErrDocumentTypeHasTemplates    = errors.New("document type is assigned to templates")
 	ErrCannotModifyGlobalType      = errors.New("cannot modify global document type")
+	ErrPayloadContractNotFound     = errors.New("document type has no payload contract")
+	ErrInvalidPayloadContract      = errors.New("invalid payload contract")
+	ErrPayloadContractViolation    = errors.New("payload does not satisfy the document type contract")
 )
 
 // Template errors.
 var (
-	ErrTemplateNotFound      = errors.New("template not found")
-	ErrTemplateAlreadyExists = errors.New("template with this title already exists")
-	ErrTemplateNotResolved   = errors.New("no published template found for the given tenant, workspace and document type codes")
+	ErrTemplateNotFound          = errors.New("template not found")
+	ErrTemplateAlreadyExists     = errors.New("template with this title already exists")
+	ErrTemplateNotResolved       = errors.New("no published template found for the given tenant, workspace and document type codes")
+	ErrTemplateSLANotFound       = errors.New("template has no SLA")
+	ErrInvalidTemplateSLA        = errors.New("template SLA needs a positive max render time or a max failure rate between 0 and 1, a window of 5 to 1440 minutes and 1 to 100000 min renders")
+	ErrInvalidInjectableCoverage = errors.New("injectable coverage needs 1 day up to the retention of injectable_coverage.retention_days and 1 to 100000 min renders")
 )
 
 // Template Version errors.
@@ -185,6 +419,12 @@ var (
 	ErrMissingRequiredContent          = errors.New("content structure is required for publishing")
 	ErrVersionDoesNotBelongToTemplate  = errors.New("version does not belong to the specified template")
 	ErrScheduledTimeConflict           = errors.New("another version is already scheduled at this time")
+	ErrInvalidScheduledOperation       = errors.New("invalid scheduled operation")
+	ErrScheduledOperationNotDue        = errors.New("version has no overdue scheduled operation of this kind")
+	ErrChangelogNotFound               = errors.New("version has no changelog, it was never published")
+	ErrReleaseNotesNotFound            = errors.New("version has no release notes")
+	ErrReleaseNotesFrozen              = errors.New("release notes cannot be edited after the version is published")
+	ErrInvalidReleaseNotes             = errors.New("release notes accept at most 20 ticket IDs of up to 64 characters without spaces or commas")
 )
 
 // Validation errors.
@@ -209,6 +449,22 @@ var (
 	ErrInvalidAccessEntityType = errors.New("invalid access entity type")
 )
 
+// Notification errors.
+var (
+	ErrNotificationNotFound    = errors.New("notification not found")
+	ErrInvalidNotificationType = errors.New("invalid notification type")
+
+	ErrNotificationWebhookNotFound = errors.New("notification webhook not found")
+	ErrInvalidWebhookProvider      = errors.New("invalid webhook provider")
+	ErrInvalidWebhookURL           = errors.New("invalid webhook URL for provider")
+	ErrWebhookDeliveryFailed       = errors.New("webhook delivery failed")
+
+	ErrEventWebhookNotFound    = errors.New("event webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrInvalidWebhookEventType = errors.New("invalid webhook event type")
+	ErrInvalidEventWebhookURL  = errors.New("event webhook URL must be an absolute https URL")
+)
+
 // LLM Service errors.
 var (
 	ErrLLMServiceUnavailable = errors.New("AI generation service is temporarily unavailable")
@@ -219,6 +475,18 @@ var (
 	ErrRendererBusy = errors.New("PDF renderer is at capacity, try again shortly")
 )
 
+// Chaos mode errors.
+var (
+	ErrChaosFault = errors.New("fault injected by chaos mode")
+)
+
+// Currency conversion errors.
+var (
+	ErrCurrencyConversionUnavailable = errors.New("currency conversion is not configured")
+	ErrCurrencyRateNotFound          = errors.New("no exchange rate for currency")
+	ErrInvalidCurrencyRateDate       = errors.New("X-Currency-Rate-Date must be a date formatted YYYY-MM-DD and not in the future")
+)
+
 // ContentValidationError wraps multiple validation errors from content validation.
 type ContentValidationError struct {
 	Errors   []ContentValidationItem
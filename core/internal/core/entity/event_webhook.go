@@ -0,0 +1,170 @@
+package entity
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// EventWebhookPing is the event type of the sample payload sent when testing an event webhook.
+const EventWebhookPing DomainEventType = "webhook.ping"
+
+// WebhookEventTypes are the domain events integrators can subscribe an event webhook to.
+var WebhookEventTypes = []DomainEventType{
+	EventRenderCompleted,
+	EventRenderFailed,
+	EventVersionPublished,
+	EventVersionArchived,
+	EventTemplateSLABreached,
+	EventTemplateSLARecovered,
+}
+
+// IsWebhookEventType reports whether t can be delivered through an event webhook.
+func IsWebhookEventType(t DomainEventType) bool {
+	return slices.Contains(WebhookEventTypes, t)
+}
+
+// EventWebhook is a workspace-level outgoing webhook that POSTs signed JSON payloads
+// to an integrator endpoint when render and publish events happen.
+type EventWebhook struct {
+	ID          string            `json:"id"`
+	WorkspaceID string            `json:"workspaceId"`
+	Name        string            `json:"name"`
+	URL         string            `json:"url"`
+	Secret      string            `json:"-"`          // Signs the payloads; only returned when created or rotated
+	EventTypes  []DomainEventType `json:"eventTypes"` // Empty = all webhook events
+	Enabled     bool              `json:"enabled"`
+	CreatedBy   *string           `json:"createdBy,omitempty"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	UpdatedAt   *time.Time        `json:"updatedAt,omitempty"`
+}
+
+// NewEventWebhook creates a new enabled event webhook signed with secret.
+func NewEventWebhook(workspaceID, name, webhookURL, secret string, eventTypes []DomainEventType, createdBy *string) *EventWebhook {
+	return &EventWebhook{
+		WorkspaceID: workspaceID,
+		Name:        name,
+		URL:         webhookURL,
+		Secret:      secret,
+		EventTypes:  eventTypes,
+		Enabled:     true,
+		CreatedBy:   createdBy,
+		CreatedAt:   time.Now().UTC(),
+	}
+}
+
+// Accepts returns true if the webhook is enabled and subscribed to the given event type.
+func (w *EventWebhook) Accepts(t DomainEventType) bool {
+	if !w.Enabled {
+		return false
+	}
+	return len(w.EventTypes) == 0 || slices.Contains(w.EventTypes, t)
+}
+
+// Validate checks if the webhook data is valid.
+func (w *EventWebhook) Validate() error {
+	if w.WorkspaceID == "" || w.Name == "" || w.URL == "" || w.Secret == "" {
+		return ErrRequiredField
+	}
+	if len(w.Name) > 100 {
+		return ErrFieldTooLong
+	}
+	for _, t := range w.EventTypes {
+		if !IsWebhookEventType(t) {
+			return ErrInvalidWebhookEventType
+		}
+	}
+
+	u, err := url.Parse(w.URL)
+	if err != nil || u.Scheme != "https" || u.User != nil || u.Hostname() == "" {
+		return ErrInvalidEventWebhookURL
+	}
+	return nil
+}
+
+// WebhookDeliveryStatus is the state of one event delivered to one webhook.
+type WebhookDeliveryStatus string
+
+// WebhookDeliveryStatus values.
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "DELIVERED"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "FAILED"
+)
+
+// IsValid checks if the delivery status is valid.
+func (s WebhookDeliveryStatus) IsValid() bool {
+	return s == WebhookDeliveryPending || s == WebhookDeliveryDelivered || s == WebhookDeliveryFailed
+}
+
+// WebhookDelivery is one event queued for one event webhook. Deliveries are retried with
+// backoff until the endpoint answers 2xx or the attempts run out, and are kept as the
+// delivery log integrators read to debug missed calls.
+type WebhookDelivery struct {
+	ID             string                `json:"id"`
+	WebhookID      string                `json:"webhookId"`
+	WorkspaceID    string                `json:"workspaceId"`
+	EventID        string                `json:"eventId"`
+	EventType      DomainEventType       `json:"eventType"`
+	Payload        json.RawMessage       `json:"payload"` // WebhookEnvelope sent as the request body
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	LastStatusCode *int                  `json:"lastStatusCode,omitempty"`
+	LastError      *string               `json:"lastError,omitempty"`
+	NextAttemptAt  time.Time             `json:"nextAttemptAt"`
+	CreatedAt      time.Time             `json:"createdAt"`
+	DeliveredAt    *time.Time            `json:"deliveredAt,omitempty"`
+	FailedAt       *time.Time            `json:"failedAt,omitempty"`
+}
+
+// WebhookDeliveryFilter narrows the delivery log of a webhook.
+type WebhookDeliveryFilter struct {
+	Status WebhookDeliveryStatus // Empty = any status
+	Limit  int
+}
+
+// WebhookEnvelope is the JSON body POSTed to event webhooks. Data holds the domain event.
+type WebhookEnvelope struct {
+	ID          string          `json:"id"`
+	Type        DomainEventType `json:"type"`
+	WorkspaceID string          `json:"workspaceId"`
+	OccurredAt  time.Time       `json:"occurredAt"`
+	Data        any             `json:"data"`
+}
+
+// NewWebhookDelivery creates a pending delivery of the envelope to webhookID, due now.
+func NewWebhookDelivery(webhookID string, envelope WebhookEnvelope) (*WebhookDelivery, error) {
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s webhook payload: %w", envelope.Type, err)
+	}
+	now := time.Now().UTC()
+	return &WebhookDelivery{
+		WebhookID:     webhookID,
+		WorkspaceID:   envelope.WorkspaceID,
+		EventID:       envelope.ID,
+		EventType:     envelope.Type,
+		Payload:       payload,
+		Status:        WebhookDeliveryPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
+
+// SignWebhookPayload returns the signature header value of body sent at timestamp:
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<unix seconds>.<body>" keyed by secret>".
+// Receivers recompute v1 and reject stale timestamps to prevent replays.
+func SignWebhookPayload(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEventWebhookValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		eventTypes []DomainEventType
+		wantErr    error
+	}{
+		{"https endpoint", "https://hooks.example.com/pdf-forge", nil, nil},
+		{"subscribed events", "https://example.com/x", []DomainEventType{EventRenderFailed, EventVersionArchived}, nil},
+		{"plain http rejected", "http://example.com/x", nil, ErrInvalidEventWebhookURL},
+		{"userinfo rejected", "https://u:p@example.com/x", nil, ErrInvalidEventWebhookURL},
+		{"relative url rejected", "/hooks", nil, ErrInvalidEventWebhookURL},
+		{"non webhook event rejected", "https://example.com/x", []DomainEventType{EventMemberInvited}, ErrInvalidWebhookEventType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewEventWebhook("ws-1", "integration", tt.url, "whsec_x", tt.eventTypes, nil)
+			if err := w.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEventWebhookAccepts(t *testing.T) {
+	w := NewEventWebhook("ws-1", "integration", "https://example.com/x", "whsec_x", nil, nil)
+	if !w.Accepts(EventRenderCompleted) {
+		t.Error("webhook without filter should accept every event")
+	}
+
+	w.EventTypes = []DomainEventType{EventVersionPublished}
+	if w.Accepts(EventRenderCompleted) || !w.Accepts(EventVersionPublished) {
+		t.Error("webhook should only accept subscribed events")
+	}
+
+	w.Enabled = false
+	if w.Accepts(EventVersionPublished) {
+		t.Error("disabled webhook should not accept events")
+	}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	// Expected value computed with: printf '1700000000.{"a":1}' | openssl dgst -sha256 -hmac secret
+	got := SignWebhookPayload("secret", time.Unix(1700000000, 0), []byte(`{"a":1}`))
+	want := "t=1700000000,v1=49f24e537407743fa4a0242bb63b94b9a47ee99cbbe071ccd8a22550ae411686"
+	if got != want {
+		t.Errorf("SignWebhookPayload() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,97 @@
+package entity
+
+import "time"
+
+// Hosted document lifetime bounds.
+const (
+	HostedDocumentDefaultTTL = 7 * 24 * time.Hour
+	HostedDocumentMaxTTL     = 90 * 24 * time.Hour
+)
+
+// HostedDocumentAccess defines who can open a hosted document.
+type HostedDocumentAccess string
+
+const (
+	HostedAccessPublic  HostedDocumentAccess = "PUBLIC"  // Anyone with the link
+	HostedAccessToken   HostedDocumentAccess = "TOKEN"   // Anyone with the link and its token
+	HostedAccessMembers HostedDocumentAccess = "MEMBERS" // Members of the workspace only
+)
+
+// IsValid checks if the hosted document access is valid.
+func (a HostedDocumentAccess) IsValid() bool {
+	switch a {
+	case HostedAccessPublic, HostedAccessToken, HostedAccessMembers:
+		return true
+	}
+	return false
+}
+
+// HostedDocument is a rendered PDF kept by the server and shared through a viewer link
+// instead of being returned to the caller.
+type HostedDocument struct {
+	ID            string               `json:"id"`
+	WorkspaceID   string               `json:"workspaceId"`
+	Filename      string               `json:"filename"`
+	PDF           []byte               `json:"-"` // Not loaded by list queries
+	SizeBytes     int                  `json:"sizeBytes"`
+	PageCount     int                  `json:"pageCount"`
+	Access        HostedDocumentAccess `json:"access"`
+	TokenHash     *string              `json:"-"` // SHA-256 of the access token; set only for TOKEN access
+	AllowDownload bool                 `json:"allowDownload"`
+	ExpiresAt     time.Time            `json:"expiresAt"`
+	ViewCount     int                  `json:"viewCount"`
+	LastViewedAt  *time.Time           `json:"lastViewedAt,omitempty"`
+	CreatedAt     time.Time            `json:"createdAt"`
+
+	// Filled by the background indexer
+	HasThumbnail  bool       `json:"hasThumbnail"`
+	IndexedAt     *time.Time `json:"indexedAt,omitempty"`
+	IndexError    *string    `json:"indexError,omitempty"` // Set when indexing gave up
+	IndexAttempts int        `json:"-"`
+	SearchSnippet *string    `json:"searchSnippet,omitempty"` // Matching text excerpt; set by searches only
+}
+
+// NewHostedDocument creates a hosted document for a rendered PDF that expires after ttl.
+func NewHostedDocument(workspaceID, filename string, pdf []byte, pageCount int, access HostedDocumentAccess, allowDownload bool, ttl time.Duration) *HostedDocument {
+	now := time.Now().UTC()
+	return &HostedDocument{
+		WorkspaceID:   workspaceID,
+		Filename:      filename,
+		PDF:           pdf,
+		SizeBytes:     len(pdf),
+		PageCount:     pageCount,
+		Access:        access,
+		AllowDownload: allowDownload,
+		ExpiresAt:     now.Add(ttl),
+		CreatedAt:     now,
+	}
+}
+
+// IsExpired returns true if the document can no longer be viewed.
+func (d *HostedDocument) IsExpired() bool {
+	return time.Now().UTC().After(d.ExpiresAt)
+}
+
+// Validate checks if the hosted document data is valid.
+func (d *HostedDocument) Validate() error {
+	if d.WorkspaceID == "" || d.Filename == "" || len(d.PDF) == 0 {
+		return ErrRequiredField
+	}
+	if !d.Access.IsValid() {
+		return ErrInvalidHostedAccess
+	}
+	if (d.Access == HostedAccessToken) != (d.TokenHash != nil) {
+		return ErrRequiredField
+	}
+	if len(d.Filename) > 255 {
+		return ErrFieldTooLong
+	}
+	return nil
+}
+
+// HostedDocumentIndex is the thumbnail and plain text derived from a hosted document's PDF.
+type HostedDocumentIndex struct {
+	DocumentID string
+	Thumbnail  []byte // PNG of the first page
+	Text       string
+}
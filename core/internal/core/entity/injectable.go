@@ -23,8 +23,10 @@ type InjectableDefinition struct {
 	FormatConfig *FormatConfig        `json:"formatConfig,omitempty"` // Formatting options for this injectable
 	Group        *string              `json:"group,omitempty"`        // Group key for organizing in the editor (system injectables only)
 	DefaultValue *string              `json:"defaultValue,omitempty"` // Default value for workspace injectables
+	Formula      *string              `json:"formula,omitempty"`      // Computes the value from other injectables (workspace injectables)
 	IsActive     bool                 `json:"isActive"`               // Enable/disable injectable
 	IsDeleted    bool                 `json:"isDeleted"`              // Soft delete flag
+	Revision     int                  `json:"revision"`               // Incremented on every update (workspace injectables)
 	CreatedAt    time.Time            `json:"createdAt"`
 	UpdatedAt    *time.Time           `json:"updatedAt,omitempty"`
 }
@@ -72,12 +74,24 @@ func (i *InjectableDefinition) Validate() error {
 	return nil
 }
 
-// ValidateForWorkspace validates injectable for workspace-owned creation (TEXT type only).
+// IsComputed returns true if the value of the injectable is computed by a formula.
+func (i *InjectableDefinition) IsComputed() bool {
+	return i.Formula != nil && *i.Formula != ""
+}
+
+// ValidateForWorkspace validates injectable for workspace-owned creation: TEXT type only, or
+// TEXT, NUMBER, DATE or BOOLEAN when computed by a formula.
 func (i *InjectableDefinition) ValidateForWorkspace() error {
 	if err := i.Validate(); err != nil {
 		return err
 	}
-	if i.DataType != InjectableDataTypeText {
+	if i.IsComputed() {
+		switch i.DataType {
+		case InjectableDataTypeText, InjectableDataTypeNumber, InjectableDataTypeDate, InjectableDataTypeBoolean:
+		default:
+			return ErrOnlyTextTypeAllowed
+		}
+	} else if i.DataType != InjectableDataTypeText {
 		return ErrOnlyTextTypeAllowed
 	}
 	if i.WorkspaceID == nil {
@@ -0,0 +1,94 @@
+package entity
+
+import "time"
+
+// Injectable coverage limits and defaults.
+const (
+	InjectableCoverageDefaultDays       = 7
+	InjectableCoverageDefaultMinRenders = 10
+	InjectableCoverageMaxMinRenders     = 100000
+)
+
+// InjectableSource is where the value of an injectable came from in a render.
+type InjectableSource string
+
+const (
+	// InjectableSourcePayload is a value given by the render request or resolved by an injector.
+	InjectableSourcePayload InjectableSource = "PAYLOAD"
+	// InjectableSourceDefault is the default value of the injectable, used for lack of a value.
+	InjectableSourceDefault InjectableSource = "DEFAULT"
+	// InjectableSourceEmpty is an injectable rendered empty: no value and no default.
+	InjectableSourceEmpty InjectableSource = "EMPTY"
+)
+
+// InjectableCoverageDay counts where the values of an injectable of a template came from in the
+// renders of a day (UTC).
+type InjectableCoverageDay struct {
+	TemplateID    string
+	InjectableKey string
+	Day           time.Time
+	Renders       int
+	FromPayload   int
+	FromDefault   int
+	Empty         int
+}
+
+// Add counts a render that took the value of the injectable from source.
+func (d *InjectableCoverageDay) Add(source InjectableSource) {
+	d.Renders++
+	switch source {
+	case InjectableSourcePayload:
+		d.FromPayload++
+	case InjectableSourceDefault:
+		d.FromDefault++
+	case InjectableSourceEmpty:
+		d.Empty++
+	}
+}
+
+// InjectableCoverageStatus flags an injectable whose value never comes from the payload.
+type InjectableCoverageStatus string
+
+const (
+	InjectableCoverageOK               InjectableCoverageStatus = "OK"
+	InjectableCoverageAlwaysDefault    InjectableCoverageStatus = "ALWAYS_DEFAULT"
+	InjectableCoverageAlwaysEmpty      InjectableCoverageStatus = "ALWAYS_EMPTY"
+	InjectableCoverageNotEnoughRenders InjectableCoverageStatus = "NOT_ENOUGH_RENDERS"
+)
+
+// InjectableCoverage counts where the values of an injectable of a template came from over a window.
+type InjectableCoverage struct {
+	InjectableKey  string                   `json:"injectableKey"`
+	Renders        int                      `json:"renders"`
+	FromPayload    int                      `json:"fromPayload"`
+	FromDefault    int                      `json:"fromDefault"`
+	Empty          int                      `json:"empty"`
+	LastRenderedAt time.Time                `json:"lastRenderedAt"` // Day of the last render
+	Status         InjectableCoverageStatus `json:"status"`
+}
+
+// Classify sets the status of the coverage. Injectables rendered fewer than minRenders times are
+// not flagged.
+func (c *InjectableCoverage) Classify(minRenders int) {
+	switch {
+	case c.Renders < minRenders:
+		c.Status = InjectableCoverageNotEnoughRenders
+	case c.Empty == c.Renders:
+		c.Status = InjectableCoverageAlwaysEmpty
+	case c.FromDefault == c.Renders:
+		c.Status = InjectableCoverageAlwaysDefault
+	default:
+		c.Status = InjectableCoverageOK
+	}
+}
+
+// TemplateInjectableCoverage is where the values of the injectables of a template came from in its
+// renders through the render API over the last days.
+type TemplateInjectableCoverage struct {
+	TemplateID    string                `json:"templateId"`
+	Days          int                   `json:"days"`
+	MinRenders    int                   `json:"minRenders"`
+	Injectables   []*InjectableCoverage `json:"injectables"`
+	AlwaysDefault []string              `json:"alwaysDefault"` // Keys never given by the payload, always defaulted
+	AlwaysEmpty   []string              `json:"alwaysEmpty"`   // Keys never given by the payload and without default
+}
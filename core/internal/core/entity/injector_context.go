@@ -24,18 +24,28 @@ const (
 	ValueTypeImage
 	// ValueTypeList represents a list value with items and styling.
 	ValueTypeList
+	// ValueTypeAddress represents a postal address laid out by the conventions of its country.
+	ValueTypeAddress
+	// ValueTypePerson represents a person whose name and salutation follow the conventions of a language.
+	ValueTypePerson
+	// ValueTypeMeasurement represents a quantity with its unit, convertible between unit systems.
+	ValueTypeMeasurement
 )
 
 // InjectableValue is the typed value returned by an injector.
-// Only allows: string, number (float64), bool, time.Time, TableValue, ListValue.
+// Only allows: string, number (float64), bool, time.Time, TableValue, ListValue, AddressValue,
+// PersonValue, MeasurementValue.
 type InjectableValue struct {
-	typ      ValueType
-	strVal   string
-	numVal   float64
-	boolVal  bool
-	timeVal  time.Time
-	tableVal *TableValue
-	listVal  *ListValue
+	typ        ValueType
+	strVal     string
+	numVal     float64
+	boolVal    bool
+	timeVal    time.Time
+	tableVal   *TableValue
+	listVal    *ListValue
+	addrVal    *AddressValue
+	personVal  *PersonValue
+	measureVal *MeasurementValue
 }
 
 // StringValue creates an InjectableValue of type string.
@@ -73,6 +83,21 @@ func ListValueData(l *ListValue) InjectableValue {
 	return InjectableValue{typ: ValueTypeList, listVal: l}
 }
 
+// AddressValueData creates an InjectableValue of type address.
+func AddressValueData(a *AddressValue) InjectableValue {
+	return InjectableValue{typ: ValueTypeAddress, addrVal: a}
+}
+
+// PersonValueData creates an InjectableValue of type person.
+func PersonValueData(p *PersonValue) InjectableValue {
+	return InjectableValue{typ: ValueTypePerson, personVal: p}
+}
+
+// MeasurementValueData creates an InjectableValue of type measurement.
+func MeasurementValueData(m *MeasurementValue) InjectableValue {
+	return InjectableValue{typ: ValueTypeMeasurement, measureVal: m}
+}
+
 // Type returns the type of the value.
 func (v InjectableValue) Type() ValueType {
 	return v.typ
@@ -126,6 +151,30 @@ func (v InjectableValue) List() (*ListValue, bool) {
 	return v.listVal, true
 }
 
+// Address returns the value as *AddressValue. ok=false if not an address.
+func (v InjectableValue) Address() (*AddressValue, bool) {
+	if v.typ != ValueTypeAddress {
+		return nil, false
+	}
+	return v.addrVal, true
+}
+
+// Person returns the value as *PersonValue. ok=false if not a person.
+func (v InjectableValue) Person() (*PersonValue, bool) {
+	if v.typ != ValueTypePerson {
+		return nil, false
+	}
+	return v.personVal, true
+}
+
+// Measurement returns the value as *MeasurementValue. ok=false if not a measurement.
+func (v InjectableValue) Measurement() (*MeasurementValue, bool) {
+	if v.typ != ValueTypeMeasurement {
+		return nil, false
+	}
+	return v.measureVal, true
+}
+
 // AsAny returns the value as any (for rendering).
 func (v InjectableValue) AsAny() any {
 	switch v.typ {
@@ -143,6 +192,12 @@ func (v InjectableValue) AsAny() any {
 		return v.strVal
 	case ValueTypeList:
 		return v.listVal
+	case ValueTypeAddress:
+		return v.addrVal
+	case ValueTypePerson:
+		return v.personVal
+	case ValueTypeMeasurement:
+		return v.measureVal
 	default:
 		return nil
 	}
@@ -154,6 +209,14 @@ type InjectorResult struct {
 	Metadata map[string]any // optional, for logging/debug
 }
 
+// InjectorTiming is when an injector ran during the resolution of a render and how long it took.
+type InjectorTiming struct {
+	Code     string        `json:"code"`
+	Start    time.Duration `json:"start"` // Since the resolution began
+	Duration time.Duration `json:"duration"`
+	Failed   bool          `json:"failed,omitempty"`
+}
+
 // InjectorContext encapsulates context data with thread-safe access.
 type InjectorContext struct {
 	mu              sync.RWMutex
@@ -169,6 +232,7 @@ type InjectorContext struct {
 	requestPayload  any
 	initData        any
 	selectedFormats map[string]string // injector code -> selected format
+	convertCurrency CurrencyConvertFunc
 }
 
 // normalizeHeaders converts all header keys to lowercase for case-insensitive lookup.
@@ -353,6 +417,27 @@ func (c *InjectorContext) SetSelectedFormats(formats map[string]string) {
 	c.selectedFormats = formats
 }
 
+// ConvertCurrency converts amount from one ISO 4217 currency to another with the exchange rates of
+// the render, rounded to the minor units of the target currency. Every render uses the rates of one
+// date, the X-Currency-Rate-Date header or the latest ones, and records the rates it used.
+// Returns ErrCurrencyConversionUnavailable when no rate provider is configured.
+func (c *InjectorContext) ConvertCurrency(amount float64, from, to string) (float64, error) {
+	c.mu.RLock()
+	convert := c.convertCurrency
+	c.mu.RUnlock()
+	if convert == nil {
+		return 0, ErrCurrencyConversionUnavailable
+	}
+	return convert(amount, from, to)
+}
+
+// SetCurrencyConverter sets the currency conversion of the render (internal use by the render service).
+func (c *InjectorContext) SetCurrencyConverter(convert CurrencyConvertFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.convertCurrency = convert
+}
+
 // GetSelectedFormats returns a copy of all selected formats.
 func (c *InjectorContext) GetSelectedFormats() map[string]string {
 	c.mu.RLock()
@@ -4,17 +4,27 @@ package entity
 type ListSymbol string
 
 const (
-	ListSymbolBullet ListSymbol = "bullet" // • (default)
-	ListSymbolNumber ListSymbol = "number" // 1. 2. 3.
-	ListSymbolDash   ListSymbol = "dash"   // –
-	ListSymbolRoman  ListSymbol = "roman"  // i. ii. iii.
-	ListSymbolLetter ListSymbol = "letter" // a) b) c)
+	ListSymbolBullet     ListSymbol = "bullet"     // • (default)
+	ListSymbolNumber     ListSymbol = "number"     // 1. 2. 3.
+	ListSymbolDash       ListSymbol = "dash"       // –
+	ListSymbolRoman      ListSymbol = "roman"      // i. ii. iii.
+	ListSymbolLetter     ListSymbol = "letter"     // a) b) c)
+	ListSymbolMultilevel ListSymbol = "multilevel" // 1. 1.1. 1.1.1. (hierarchical clause numbering)
 )
 
 // IsValid checks if the list symbol is valid.
 func (s ListSymbol) IsValid() bool {
 	switch s {
-	case ListSymbolBullet, ListSymbolNumber, ListSymbolDash, ListSymbolRoman, ListSymbolLetter:
+	case ListSymbolBullet, ListSymbolNumber, ListSymbolDash, ListSymbolRoman, ListSymbolLetter, ListSymbolMultilevel:
+		return true
+	}
+	return false
+}
+
+// IsNumbered reports whether the symbol numbers its items.
+func (s ListSymbol) IsNumbered() bool {
+	switch s {
+	case ListSymbolNumber, ListSymbolRoman, ListSymbolLetter, ListSymbolMultilevel:
 		return true
 	}
 	return false
@@ -42,6 +52,18 @@ type ListValue struct {
 	HeaderLabel  map[string]string `json:"headerLabel,omitempty"` // i18n: {"en":"Title","es":"Título"}
 	HeaderStyles *ListStyles       `json:"headerStyles,omitempty"`
 	ItemStyles   *ListStyles       `json:"itemStyles,omitempty"`
+
+	// Start is the number of the first item of a numbered list (default: 1).
+	Start *int `json:"start,omitempty"`
+	// Continue numbers the list on from the previous numbered list in the document. Ignored when Start is set.
+	Continue bool `json:"continue,omitempty"`
+	// Markers are custom bullet markers for unordered lists, cycled by depth.
+	Markers []string `json:"markers,omitempty"`
+	// Numbering is a custom Typst numbering pattern for numbered lists, e.g. "(1)", "§1." or "1.a.".
+	// With ListSymbolMultilevel the pattern applies to the full clause number.
+	Numbering string `json:"numbering,omitempty"`
+	// LevelStyles override ItemStyles per depth; index 0 is the top level.
+	LevelStyles []ListStyles `json:"levelStyles,omitempty"`
 }
 
 // ListSchema exposes the default configuration of a list injector to the frontend.
@@ -94,6 +116,36 @@ func (l *ListValue) WithItemStyles(styles ListStyles) *ListValue {
 	return l
 }
 
+// WithStart sets the number of the first item.
+func (l *ListValue) WithStart(start int) *ListValue {
+	l.Start = &start
+	return l
+}
+
+// ContinueNumbering numbers the list on from the previous numbered list in the document.
+func (l *ListValue) ContinueNumbering() *ListValue {
+	l.Continue = true
+	return l
+}
+
+// WithMarkers sets custom bullet markers per depth.
+func (l *ListValue) WithMarkers(markers ...string) *ListValue {
+	l.Markers = markers
+	return l
+}
+
+// WithNumbering sets a custom Typst numbering pattern.
+func (l *ListValue) WithNumbering(pattern string) *ListValue {
+	l.Numbering = pattern
+	return l
+}
+
+// WithLevelStyles sets item styles per depth, starting at the top level.
+func (l *ListValue) WithLevelStyles(styles ...ListStyles) *ListValue {
+	l.LevelStyles = styles
+	return l
+}
+
 // ListItemValue creates a ListItem with a value (helper for nested items).
 func ListItemValue(value InjectableValue) ListItem {
 	return ListItem{Value: &value}
@@ -0,0 +1,61 @@
+package entity
+
+import "time"
+
+// MaintenanceMode controls which requests the API accepts during planned maintenance.
+type MaintenanceMode string
+
+const (
+	MaintenanceModeOff      MaintenanceMode = "OFF"       // Normal operation
+	MaintenanceModeReadOnly MaintenanceMode = "READ_ONLY" // Reads and renders only, no writes
+	MaintenanceModeDrain    MaintenanceMode = "DRAIN"     // Reads only; new renders rejected so in-flight ones can finish
+)
+
+// IsValid checks if the maintenance mode is valid.
+func (m MaintenanceMode) IsValid() bool {
+	switch m {
+	case MaintenanceModeOff, MaintenanceModeReadOnly, MaintenanceModeDrain:
+		return true
+	}
+	return false
+}
+
+// MaintenanceState is the platform-wide maintenance switch.
+type MaintenanceState struct {
+	Mode          MaintenanceMode `json:"mode"`
+	Message       string          `json:"message,omitempty"`
+	ExpectedEndAt *time.Time      `json:"expectedEndAt,omitempty"`
+	UpdatedBy     *string         `json:"updatedBy,omitempty"`
+	UpdatedAt     *time.Time      `json:"updatedAt,omitempty"`
+}
+
+// NewMaintenanceState returns the state of normal operation.
+func NewMaintenanceState() *MaintenanceState {
+	return &MaintenanceState{Mode: MaintenanceModeOff}
+}
+
+// IsActive returns true if any maintenance restriction applies.
+func (s *MaintenanceState) IsActive() bool {
+	return s.Mode != MaintenanceModeOff
+}
+
+// BlocksWrites returns true if requests that modify data must be rejected.
+func (s *MaintenanceState) BlocksWrites() bool {
+	return s.Mode == MaintenanceModeReadOnly || s.Mode == MaintenanceModeDrain
+}
+
+// BlocksRenders returns true if new renders must be rejected.
+func (s *MaintenanceState) BlocksRenders() bool {
+	return s.Mode == MaintenanceModeDrain
+}
+
+// Validate checks if the maintenance state is valid.
+func (s *MaintenanceState) Validate() error {
+	if !s.Mode.IsValid() {
+		return ErrInvalidMaintenanceMode
+	}
+	if len(s.Message) > 500 {
+		return ErrFieldTooLong
+	}
+	return nil
+}
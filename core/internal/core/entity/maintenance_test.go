@@ -0,0 +1,27 @@
+package entity
+
+import "testing"
+
+func TestMaintenanceStateBlocks(t *testing.T) {
+	tests := []struct {
+		mode        MaintenanceMode
+		wantWrites  bool
+		wantRenders bool
+	}{
+		{MaintenanceModeOff, false, false},
+		{MaintenanceModeReadOnly, true, false},
+		{MaintenanceModeDrain, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			s := &MaintenanceState{Mode: tt.mode}
+			if got := s.BlocksWrites(); got != tt.wantWrites {
+				t.Errorf("BlocksWrites() = %v, want %v", got, tt.wantWrites)
+			}
+			if got := s.BlocksRenders(); got != tt.wantRenders {
+				t.Errorf("BlocksRenders() = %v, want %v", got, tt.wantRenders)
+			}
+		})
+	}
+}
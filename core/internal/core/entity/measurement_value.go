@@ -0,0 +1,8 @@
+package entity
+
+// MeasurementValue is a quantity with its unit, such as the dimensions or weight of a product.
+// Renders may convert it to the unit system of the template or render request.
+type MeasurementValue struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"` // Unit code: "mm", "kg", "l", "C", "ft2", "km/h"...
+}
@@ -0,0 +1,69 @@
+package entity
+
+import "time"
+
+// NotificationType identifies the event that produced a notification.
+type NotificationType string
+
+const (
+	NotificationTypeScheduledPublishSucceeded NotificationType = "SCHEDULED_PUBLISH_SUCCEEDED"
+	NotificationTypeScheduledPublishFailed    NotificationType = "SCHEDULED_PUBLISH_FAILED"
+	NotificationTypeWorkspaceInvitation       NotificationType = "WORKSPACE_INVITATION"
+	NotificationTypeRenderFailed              NotificationType = "RENDER_FAILED"
+	NotificationTypeTemplateSLABreached       NotificationType = "TEMPLATE_SLA_BREACHED"
+	NotificationTypeTemplateSLARecovered      NotificationType = "TEMPLATE_SLA_RECOVERED"
+)
+
+// IsValid checks if the notification type is valid.
+func (t NotificationType) IsValid() bool {
+	switch t {
+	case NotificationTypeScheduledPublishSucceeded, NotificationTypeScheduledPublishFailed,
+		NotificationTypeWorkspaceInvitation, NotificationTypeRenderFailed,
+		NotificationTypeTemplateSLABreached, NotificationTypeTemplateSLARecovered:
+		return true
+	}
+	return false
+}
+
+// Notification represents an in-product notification addressed to a single user.
+type Notification struct {
+	ID          string           `json:"id"`
+	UserID      string           `json:"userId"`
+	WorkspaceID *string          `json:"workspaceId,omitempty"`
+	Type        NotificationType `json:"type"`
+	Title       string           `json:"title"`
+	Message     string           `json:"message"`
+	ResourceID  *string          `json:"resourceId,omitempty"` // e.g. version ID for publish events
+	ReadAt      *time.Time       `json:"readAt,omitempty"`
+	CreatedAt   time.Time        `json:"createdAt"`
+}
+
+// NewNotification creates a new unread notification.
+func NewNotification(userID string, notificationType NotificationType, title, message string) *Notification {
+	return &Notification{
+		UserID:    userID,
+		Type:      notificationType,
+		Title:     title,
+		Message:   message,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// IsRead returns true if the notification has been read.
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+// Validate checks if the notification data is valid.
+func (n *Notification) Validate() error {
+	if n.UserID == "" || n.Title == "" {
+		return ErrRequiredField
+	}
+	if len(n.Title) > 255 {
+		return ErrFieldTooLong
+	}
+	if !n.Type.IsValid() {
+		return ErrInvalidNotificationType
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package entity
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// WebhookProvider identifies the chat platform a notification webhook posts to.
+type WebhookProvider string
+
+const (
+	WebhookProviderSlack WebhookProvider = "SLACK"
+	WebhookProviderTeams WebhookProvider = "TEAMS"
+)
+
+// IsValid checks if the webhook provider is valid.
+func (p WebhookProvider) IsValid() bool {
+	return p == WebhookProviderSlack || p == WebhookProviderTeams
+}
+
+// allowedHost reports whether host belongs to the provider's webhook domains.
+// Restricting hosts keeps workspace admins from pointing the server at internal addresses.
+func (p WebhookProvider) allowedHost(host string) bool {
+	host = strings.ToLower(host)
+	switch p {
+	case WebhookProviderSlack:
+		return host == "hooks.slack.com"
+	case WebhookProviderTeams:
+		return strings.HasSuffix(host, ".webhook.office.com") || strings.HasSuffix(host, ".logic.azure.com")
+	}
+	return false
+}
+
+// NotificationWebhook is a workspace-level outgoing webhook that mirrors
+// notifications of the workspace to a Slack or Microsoft Teams channel.
+type NotificationWebhook struct {
+	ID          string             `json:"id"`
+	WorkspaceID string             `json:"workspaceId"`
+	Provider    WebhookProvider    `json:"provider"`
+	Name        string             `json:"name"`
+	URL         string             `json:"url"`
+	EventTypes  []NotificationType `json:"eventTypes"` // Empty = all events
+	Enabled     bool               `json:"enabled"`
+	CreatedBy   *string            `json:"createdBy,omitempty"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	UpdatedAt   *time.Time         `json:"updatedAt,omitempty"`
+}
+
+// NewNotificationWebhook creates a new enabled notification webhook.
+func NewNotificationWebhook(workspaceID string, provider WebhookProvider, name, webhookURL string, eventTypes []NotificationType, createdBy *string) *NotificationWebhook {
+	return &NotificationWebhook{
+		WorkspaceID: workspaceID,
+		Provider:    provider,
+		Name:        name,
+		URL:         webhookURL,
+		EventTypes:  eventTypes,
+		Enabled:     true,
+		CreatedBy:   createdBy,
+		CreatedAt:   time.Now().UTC(),
+	}
+}
+
+// Accepts returns true if the webhook is enabled and subscribed to the given event type.
+func (w *NotificationWebhook) Accepts(t NotificationType) bool {
+	if !w.Enabled {
+		return false
+	}
+	return len(w.EventTypes) == 0 || slices.Contains(w.EventTypes, t)
+}
+
+// Validate checks if the webhook data is valid.
+func (w *NotificationWebhook) Validate() error {
+	if w.WorkspaceID == "" || w.Name == "" || w.URL == "" {
+		return ErrRequiredField
+	}
+	if len(w.Name) > 100 {
+		return ErrFieldTooLong
+	}
+	if !w.Provider.IsValid() {
+		return ErrInvalidWebhookProvider
+	}
+	for _, t := range w.EventTypes {
+		if !t.IsValid() {
+			return ErrInvalidNotificationType
+		}
+	}
+
+	u, err := url.Parse(w.URL)
+	if err != nil || u.Scheme != "https" || u.User != nil || !w.Provider.allowedHost(u.Hostname()) {
+		return ErrInvalidWebhookURL
+	}
+	return nil
+}
+
+// MaskedURL returns the webhook URL with its secret path hidden.
+func (w *NotificationWebhook) MaskedURL() string {
+	u, err := url.Parse(w.URL)
+	if err != nil {
+		return ""
+	}
+	tail := w.URL
+	if len(tail) > 4 {
+		tail = tail[len(tail)-4:]
+	}
+	return u.Scheme + "://" + u.Host + "/…" + tail
+}
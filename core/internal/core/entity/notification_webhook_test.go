@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNotificationWebhookValidate_URL(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider WebhookProvider
+		url      string
+		wantErr  error
+	}{
+		{"slack official host", WebhookProviderSlack, "https://hooks.slack.com/services/T0/B0/x", nil},
+		{"teams connector host", WebhookProviderTeams, "https://acme.webhook.office.com/webhookb2/abc", nil},
+		{"teams workflow host", WebhookProviderTeams, "https://prod-01.westus.logic.azure.com/workflows/abc", nil},
+		{"plain http rejected", WebhookProviderSlack, "http://hooks.slack.com/services/T0/B0/x", ErrInvalidWebhookURL},
+		{"foreign host rejected", WebhookProviderSlack, "https://example.com/hook", ErrInvalidWebhookURL},
+		{"internal address rejected", WebhookProviderTeams, "https://169.254.169.254/latest", ErrInvalidWebhookURL},
+		{"suffix spoof rejected", WebhookProviderTeams, "https://webhook.office.com.evil.io/x", ErrInvalidWebhookURL},
+		{"userinfo rejected", WebhookProviderSlack, "https://u:p@hooks.slack.com/services/x", ErrInvalidWebhookURL},
+		{"unknown provider", WebhookProvider("DISCORD"), "https://hooks.slack.com/services/x", ErrInvalidWebhookProvider},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewNotificationWebhook("ws-1", tt.provider, "alerts", tt.url, nil, nil)
+			if err := w.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotificationWebhookAccepts(t *testing.T) {
+	w := NewNotificationWebhook("ws-1", WebhookProviderSlack, "alerts", "https://hooks.slack.com/x", nil, nil)
+	if !w.Accepts(NotificationTypeRenderFailed) {
+		t.Error("webhook without filter should accept every event")
+	}
+
+	w.EventTypes = []NotificationType{NotificationTypeScheduledPublishFailed}
+	if w.Accepts(NotificationTypeRenderFailed) {
+		t.Error("filtered webhook accepted an unsubscribed event")
+	}
+	if !w.Accepts(NotificationTypeScheduledPublishFailed) {
+		t.Error("filtered webhook rejected a subscribed event")
+	}
+
+	w.Enabled = false
+	if w.Accepts(NotificationTypeScheduledPublishFailed) {
+		t.Error("disabled webhook accepted an event")
+	}
+}
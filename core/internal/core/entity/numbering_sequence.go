@@ -0,0 +1,127 @@
+package entity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxSequencePadding is the most digits a numbering sequence pads its numbers to.
+const MaxSequencePadding = 20
+
+// NumberingSequence hands out the fiscal or legal numbers of the documents of one document type
+// in a workspace, such as invoice numbers. Renders of the type whose template shows the injectable
+// of InjectableKey draw the next number and render it there. A number is never issued twice.
+type NumberingSequence struct {
+	ID             string `json:"id"`
+	WorkspaceID    string `json:"workspaceId"`
+	DocumentTypeID string `json:"documentTypeId"`
+	InjectableKey  string `json:"injectableKey"` // Injectable the number renders in
+	// Prefix is written before the number; {YYYY} and {YY} are replaced by the year it is drawn in.
+	Prefix  string `json:"prefix"`
+	Padding int    `json:"padding"` // Minimum digits, zero-padded; 0 writes the number as is
+	// YearlyReset restarts the numbering at StartNumber on the first draw of each year, in the
+	// timezone of the workspace.
+	YearlyReset bool `json:"yearlyReset"`
+	// Gapless gives a number only to a render that succeeds, so no number is skipped. A render
+	// reserves its number and the number of a render that fails goes to the next one.
+	Gapless     bool       `json:"gapless"`
+	StartNumber int64      `json:"startNumber"`
+	NextNumber  int64      `json:"nextNumber"`
+	Year        int        `json:"year"` // Year of the last draw; 0 before the first
+	IsActive    bool       `json:"isActive"`
+	CreatedBy   *string    `json:"createdBy,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
+}
+
+// NewNumberingSequence creates an active sequence that starts at startNumber.
+func NewNumberingSequence(workspaceID, documentTypeID, injectableKey string, startNumber int64, createdBy *string) *NumberingSequence {
+	return &NumberingSequence{
+		WorkspaceID:    workspaceID,
+		DocumentTypeID: documentTypeID,
+		InjectableKey:  injectableKey,
+		StartNumber:    startNumber,
+		NextNumber:     startNumber,
+		IsActive:       true,
+		CreatedBy:      createdBy,
+		CreatedAt:      time.Now().UTC(),
+	}
+}
+
+// Validate checks if the sequence data is valid.
+func (s *NumberingSequence) Validate() error {
+	if s.WorkspaceID == "" || s.DocumentTypeID == "" || s.InjectableKey == "" {
+		return ErrRequiredField
+	}
+	if !injectableKeyRegex.MatchString(s.InjectableKey) {
+		return ErrInvalidInjectableKey
+	}
+	if len(s.InjectableKey) > 100 || len(s.Prefix) > 50 {
+		return ErrFieldTooLong
+	}
+	if s.Padding < 0 || s.Padding > MaxSequencePadding || s.StartNumber < 1 {
+		return ErrInvalidNumberingSequence
+	}
+	return nil
+}
+
+// Draw takes the next number of the sequence at now, in the timezone of the workspace, and
+// advances it. The number is not issued until it is stored in the registry.
+func (s *NumberingSequence) Draw(now time.Time) *SequenceNumber {
+	year := now.Year()
+	number := s.NextNumber
+	if s.YearlyReset && s.Year != year {
+		number = s.StartNumber
+	}
+	s.Year = year
+	s.NextNumber = number + 1
+
+	drawn := &SequenceNumber{
+		SequenceID: s.ID,
+		Number:     number,
+		Formatted:  s.Format(number, year),
+		IssuedAt:   now.UTC(),
+	}
+	if s.YearlyReset {
+		drawn.Year = year
+	}
+	return drawn
+}
+
+// Format writes a number of the sequence drawn in year, with its prefix and padding.
+func (s *NumberingSequence) Format(number int64, year int) string {
+	prefix := strings.NewReplacer(
+		"{YYYY}", strconv.Itoa(year),
+		"{YY}", fmt.Sprintf("%02d", year%100),
+	).Replace(s.Prefix)
+	return fmt.Sprintf("%s%0*d", prefix, s.Padding, number)
+}
+
+// SequenceNumberStatus is the state of a number drawn from a numbering sequence.
+type SequenceNumberStatus string
+
+// SequenceNumberStatus values.
+const (
+	SequenceNumberIssued   SequenceNumberStatus = "ISSUED"   // Rendered in a document
+	SequenceNumberVoided   SequenceNumberStatus = "VOIDED"   // Drawn by a render that failed; not reused
+	SequenceNumberPending  SequenceNumberStatus = "PENDING"  // Reserved by a render of a gapless sequence in progress
+	SequenceNumberReleased SequenceNumberStatus = "RELEASED" // Given back by a failed render of a gapless sequence; reused
+)
+
+// SequenceNumber is a number drawn from a numbering sequence, as kept in the registry of the
+// numbers renders drew.
+type SequenceNumber struct {
+	ID         string `json:"id"`
+	SequenceID string `json:"sequenceId"`
+	// Year is the year numbering restarted in, 0 for sequences not reset yearly.
+	Year       int                  `json:"year"`
+	Number     int64                `json:"number"`
+	Formatted  string               `json:"formatted"` // As rendered, with prefix and padding
+	Status     SequenceNumberStatus `json:"status"`
+	VersionID  string               `json:"versionId"`
+	TemplateID string               `json:"templateId"`
+	DocumentID *string              `json:"documentId,omitempty"` // Identifier the render request gave the document
+	IssuedAt   time.Time            `json:"issuedAt"`
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNumberingSequence_Validate(t *testing.T) {
+	seq := func(key string, padding int, start int64) *NumberingSequence {
+		s := NewNumberingSequence("ws-1", "dt-1", key, start, nil)
+		s.Padding = padding
+		return s
+	}
+
+	tests := []struct {
+		name    string
+		seq     *NumberingSequence
+		wantErr error
+	}{
+		{"valid", seq("invoice_number", 6, 1), nil},
+		{"no key", seq("", 6, 1), ErrRequiredField},
+		{"invalid key", seq("Invoice-Number", 6, 1), ErrInvalidInjectableKey},
+		{"padding too large", seq("invoice_number", 21, 1), ErrInvalidNumberingSequence},
+		{"negative padding", seq("invoice_number", -1, 1), ErrInvalidNumberingSequence},
+		{"start at zero", seq("invoice_number", 0, 0), ErrInvalidNumberingSequence},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.seq.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNumberingSequence_Draw(t *testing.T) {
+	s := NewNumberingSequence("ws-1", "dt-1", "invoice_number", 100, nil)
+	s.Prefix = "F-{YYYY}/"
+	s.Padding = 5
+
+	first := s.Draw(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	second := s.Draw(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	if first.Number != 100 || first.Formatted != "F-2026/00100" || first.Year != 0 {
+		t.Errorf("first draw = %d %q year %d, want 100 \"F-2026/00100\" year 0", first.Number, first.Formatted, first.Year)
+	}
+	if second.Number != 101 || second.Formatted != "F-2027/00101" {
+		t.Errorf("second draw = %d %q, want 101 \"F-2027/00101\"", second.Number, second.Formatted)
+	}
+	if s.NextNumber != 102 || s.Year != 2027 {
+		t.Errorf("sequence at %d in %d, want 102 in 2027", s.NextNumber, s.Year)
+	}
+}
+
+func TestNumberingSequence_DrawYearlyReset(t *testing.T) {
+	s := NewNumberingSequence("ws-1", "dt-1", "invoice_number", 1, nil)
+	s.Prefix = "{YY}-"
+	s.YearlyReset = true
+
+	s.Draw(time.Date(2026, 12, 30, 0, 0, 0, 0, time.UTC))
+	s.Draw(time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC))
+	reset := s.Draw(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	if reset.Number != 1 || reset.Year != 2027 || reset.Formatted != "27-1" {
+		t.Errorf("draw after new year = %d %q year %d, want 1 \"27-1\" year 2027", reset.Number, reset.Formatted, reset.Year)
+	}
+	if s.NextNumber != 2 {
+		t.Errorf("next number = %d, want 2", s.NextNumber)
+	}
+}
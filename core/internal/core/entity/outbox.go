@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// OutboxEventType identifies a domain event stored in the outbox.
+type OutboxEventType string
+
+const (
+	// OutboxEventNotificationCreated carries a persisted Notification for external channels.
+	OutboxEventNotificationCreated OutboxEventType = "notification.created"
+	// OutboxEventVersionPublished carries a VersionPublished domain event.
+	OutboxEventVersionPublished = OutboxEventType(EventVersionPublished)
+	// OutboxEventVersionArchived carries a VersionArchived domain event.
+	OutboxEventVersionArchived = OutboxEventType(EventVersionArchived)
+	// OutboxEventInjectableDeactivated carries an InjectableDeactivated domain event.
+	OutboxEventInjectableDeactivated = OutboxEventType(EventInjectableDeactivated)
+	// OutboxEventMemberInvited carries a MemberInvited domain event.
+	OutboxEventMemberInvited = OutboxEventType(EventMemberInvited)
+	// OutboxEventTemplateSLABreached carries a TemplateSLABreached domain event.
+	OutboxEventTemplateSLABreached = OutboxEventType(EventTemplateSLABreached)
+	// OutboxEventTemplateSLARecovered carries a TemplateSLARecovered domain event.
+	OutboxEventTemplateSLARecovered = OutboxEventType(EventTemplateSLARecovered)
+	// OutboxEventRenderCompleted carries a RenderCompleted domain event.
+	OutboxEventRenderCompleted = OutboxEventType(EventRenderCompleted)
+	// OutboxEventRenderFailed carries a RenderFailed domain event.
+	OutboxEventRenderFailed = OutboxEventType(EventRenderFailed)
+)
+
+// OutboxEvent is a domain event written in the same transaction as the state change
+// that produced it and delivered afterwards by the outbox relay.
+type OutboxEvent struct {
+	ID          string          `json:"id"`
+	Type        OutboxEventType `json:"type"`
+	AggregateID string          `json:"aggregateId"`
+	WorkspaceID *string         `json:"workspaceId,omitempty"`
+	Payload     json.RawMessage `json:"payload"`
+	Attempts    int             `json:"attempts"`
+	LastError   *string         `json:"lastError,omitempty"`
+	AvailableAt time.Time       `json:"availableAt"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	DeliveredAt *time.Time      `json:"deliveredAt,omitempty"`
+	FailedAt    *time.Time      `json:"failedAt,omitempty"`
+}
+
+// NewOutboxEvent creates a pending event with payload encoded as JSON.
+func NewOutboxEvent(eventType OutboxEventType, aggregateID string, workspaceID *string, payload any) (*OutboxEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s payload: %w", eventType, err)
+	}
+	now := time.Now().UTC()
+	return &OutboxEvent{
+		Type:        eventType,
+		AggregateID: aggregateID,
+		WorkspaceID: workspaceID,
+		Payload:     data,
+		AvailableAt: now,
+		CreatedAt:   now,
+	}, nil
+}
+
+// DecodePayload unmarshals the event payload into v.
+func (e *OutboxEvent) DecodePayload(v any) error {
+	if err := json.Unmarshal(e.Payload, v); err != nil {
+		return fmt.Errorf("decoding %s payload: %w", e.Type, err)
+	}
+	return nil
+}
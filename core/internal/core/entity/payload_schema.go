@@ -0,0 +1,420 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"unicode/utf8"
+)
+
+// MaxContractViolations bounds the violations reported for one payload.
+const MaxContractViolations = 50
+
+// payloadSchemaAnnotations are schema keywords that document the payload without constraining it.
+var payloadSchemaAnnotations = []string{
+	"$schema", "$id", "$comment", "title", "description", "default", "examples", "format",
+	"deprecated", "readOnly", "writeOnly",
+}
+
+var payloadSchemaTypes = []string{"object", "array", "string", "number", "integer", "boolean", "null"}
+
+// PayloadSchema is a compiled JSON Schema of a render payload. It supports the keywords payload
+// contracts need: type, properties, required, additionalProperties, items, enum, const, the
+// numeric, length and size bounds, pattern, allOf, anyOf, oneOf and not. References are not
+// supported, so every contract is self-contained.
+type PayloadSchema struct {
+	always     *bool // Set for the boolean schemas true and false
+	types      []string
+	properties map[string]*PayloadSchema
+	required   []string
+	additional *PayloadSchema
+	items      *PayloadSchema
+	enum       []any
+	constant   *any
+	minimum    *float64
+	maximum    *float64
+	exclMin    *float64
+	exclMax    *float64
+	minLength  *int
+	maxLength  *int
+	minItems   *int
+	maxItems   *int
+	minProps   *int
+	maxProps   *int
+	pattern    *regexp.Regexp
+	allOf      []*PayloadSchema
+	anyOf      []*PayloadSchema
+	oneOf      []*PayloadSchema
+	not        *PayloadSchema
+}
+
+// ContractViolation is a part of a payload that does not satisfy its contract.
+type ContractViolation struct {
+	Path    string `json:"path"` // e.g. $.client.name or $.items[2]
+	Message string `json:"message"`
+}
+
+// CompilePayloadSchema parses a JSON Schema. Returns ErrInvalidPayloadContract when the schema is
+// not valid JSON or uses keywords the validator does not support.
+func CompilePayloadSchema(data json.RawMessage) (*PayloadSchema, error) {
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%w: schema is not valid JSON", ErrInvalidPayloadContract)
+	}
+	return compileSchema(raw, "#")
+}
+
+//nolint:gocyclo // One case per keyword
+func compileSchema(raw any, at string) (*PayloadSchema, error) {
+	if b, ok := raw.(bool); ok {
+		return &PayloadSchema{always: &b}, nil
+	}
+	object, ok := raw.(map[string]any)
+	if !ok {
+		return nil, schemaError(at, "a schema must be an object or a boolean")
+	}
+
+	s := &PayloadSchema{}
+	keys := slices.Sorted(maps.Keys(object))
+	for _, key := range keys {
+		value := object[key]
+		path := at + "/" + key
+		var err error
+		switch key {
+		case "type":
+			s.types, err = compileTypes(value, path)
+		case "properties":
+			s.properties, err = compileProperties(value, path)
+		case "required":
+			s.required, err = compileStrings(value, path)
+		case "additionalProperties":
+			s.additional, err = compileSchema(value, path)
+		case "items":
+			s.items, err = compileSchema(value, path)
+		case "enum":
+			values, isArray := value.([]any)
+			if !isArray || len(values) == 0 {
+				return nil, schemaError(path, "must be a non-empty array")
+			}
+			s.enum = values
+		case "const":
+			s.constant = &value
+		case "minimum":
+			s.minimum, err = compileNumber(value, path)
+		case "maximum":
+			s.maximum, err = compileNumber(value, path)
+		case "exclusiveMinimum":
+			s.exclMin, err = compileNumber(value, path)
+		case "exclusiveMaximum":
+			s.exclMax, err = compileNumber(value, path)
+		case "minLength":
+			s.minLength, err = compileCount(value, path)
+		case "maxLength":
+			s.maxLength, err = compileCount(value, path)
+		case "minItems":
+			s.minItems, err = compileCount(value, path)
+		case "maxItems":
+			s.maxItems, err = compileCount(value, path)
+		case "minProperties":
+			s.minProps, err = compileCount(value, path)
+		case "maxProperties":
+			s.maxProps, err = compileCount(value, path)
+		case "pattern":
+			pattern, isString := value.(string)
+			if !isString {
+				return nil, schemaError(path, "must be a string")
+			}
+			if s.pattern, err = regexp.Compile(pattern); err != nil {
+				return nil, schemaError(path, "is not a valid regular expression")
+			}
+		case "allOf":
+			s.allOf, err = compileSchemas(value, path)
+		case "anyOf":
+			s.anyOf, err = compileSchemas(value, path)
+		case "oneOf":
+			s.oneOf, err = compileSchemas(value, path)
+		case "not":
+			s.not, err = compileSchema(value, path)
+		default:
+			if !slices.Contains(payloadSchemaAnnotations, key) {
+				return nil, schemaError(path, "keyword is not supported")
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func schemaError(at, message string) error {
+	return fmt.Errorf("%w: %s %s", ErrInvalidPayloadContract, at, message)
+}
+
+func compileTypes(value any, at string) ([]string, error) {
+	if name, ok := value.(string); ok {
+		value = []any{name}
+	}
+	names, err := compileStrings(value, at)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if !slices.Contains(payloadSchemaTypes, name) {
+			return nil, schemaError(at, fmt.Sprintf("has unknown type %q", name))
+		}
+	}
+	return names, nil
+}
+
+func compileProperties(value any, at string) (map[string]*PayloadSchema, error) {
+	object, ok := value.(map[string]any)
+	if !ok {
+		return nil, schemaError(at, "must be an object")
+	}
+	properties := make(map[string]*PayloadSchema, len(object))
+	for name, raw := range object {
+		property, err := compileSchema(raw, at+"/"+name)
+		if err != nil {
+			return nil, err
+		}
+		properties[name] = property
+	}
+	return properties, nil
+}
+
+func compileSchemas(value any, at string) ([]*PayloadSchema, error) {
+	list, ok := value.([]any)
+	if !ok || len(list) == 0 {
+		return nil, schemaError(at, "must be a non-empty array")
+	}
+	schemas := make([]*PayloadSchema, len(list))
+	for i, raw := range list {
+		schema, err := compileSchema(raw, at+"/"+strconv.Itoa(i))
+		if err != nil {
+			return nil, err
+		}
+		schemas[i] = schema
+	}
+	return schemas, nil
+}
+
+func compileStrings(value any, at string) ([]string, error) {
+	list, ok := value.([]any)
+	if !ok {
+		return nil, schemaError(at, "must be an array of strings")
+	}
+	names := make([]string, len(list))
+	for i, item := range list {
+		name, isString := item.(string)
+		if !isString {
+			return nil, schemaError(at, "must be an array of strings")
+		}
+		names[i] = name
+	}
+	return names, nil
+}
+
+func compileNumber(value any, at string) (*float64, error) {
+	n, ok := value.(float64)
+	if !ok {
+		return nil, schemaError(at, "must be a number")
+	}
+	return &n, nil
+}
+
+func compileCount(value any, at string) (*int, error) {
+	n, ok := value.(float64)
+	if !ok || n < 0 || n != math.Trunc(n) {
+		return nil, schemaError(at, "must be a non-negative integer")
+	}
+	count := int(n)
+	return &count, nil
+}
+
+// Validate checks a payload against the schema. The payload is compared as JSON, so any value that
+// marshals to JSON can be validated. Returns at most MaxContractViolations violations.
+func (s *PayloadSchema) Validate(payload any) []ContractViolation {
+	var value any
+	data, err := json.Marshal(payload)
+	if err == nil {
+		err = json.Unmarshal(data, &value)
+	}
+	if err != nil {
+		return []ContractViolation{{Path: "$", Message: "payload is not valid JSON"}}
+	}
+
+	violations := s.validate(value, "$", nil)
+	if len(violations) > MaxContractViolations {
+		violations = violations[:MaxContractViolations]
+	}
+	return violations
+}
+
+//nolint:gocyclo // One check per keyword
+func (s *PayloadSchema) validate(value any, path string, violations []ContractViolation) []ContractViolation {
+	fail := func(format string, args ...any) {
+		violations = append(violations, ContractViolation{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if s.always != nil {
+		if !*s.always {
+			fail("no value is allowed")
+		}
+		return violations
+	}
+
+	if len(s.types) > 0 && !slices.ContainsFunc(s.types, func(t string) bool { return jsonTypeMatches(t, value) }) {
+		fail("must be of type %s, got %s", joinTypes(s.types), jsonTypeOf(value))
+		return violations
+	}
+	if s.enum != nil && !slices.ContainsFunc(s.enum, func(v any) bool { return reflect.DeepEqual(v, value) }) {
+		fail("must be one of the allowed values")
+	}
+	if s.constant != nil && !reflect.DeepEqual(*s.constant, value) {
+		fail("must be %s", jsonText(*s.constant))
+	}
+
+	switch v := value.(type) {
+	case float64:
+		if s.minimum != nil && v < *s.minimum {
+			fail("must be at least %v", *s.minimum)
+		}
+		if s.maximum != nil && v > *s.maximum {
+			fail("must be at most %v", *s.maximum)
+		}
+		if s.exclMin != nil && v <= *s.exclMin {
+			fail("must be greater than %v", *s.exclMin)
+		}
+		if s.exclMax != nil && v >= *s.exclMax {
+			fail("must be less than %v", *s.exclMax)
+		}
+	case string:
+		length := utf8.RuneCountInString(v)
+		if s.minLength != nil && length < *s.minLength {
+			fail("must have at least %d characters", *s.minLength)
+		}
+		if s.maxLength != nil && length > *s.maxLength {
+			fail("must have at most %d characters", *s.maxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			fail("must match %s", s.pattern)
+		}
+	case []any:
+		if s.minItems != nil && len(v) < *s.minItems {
+			fail("must have at least %d items", *s.minItems)
+		}
+		if s.maxItems != nil && len(v) > *s.maxItems {
+			fail("must have at most %d items", *s.maxItems)
+		}
+		if s.items != nil {
+			for i, item := range v {
+				violations = s.items.validate(item, fmt.Sprintf("%s[%d]", path, i), violations)
+			}
+		}
+	case map[string]any:
+		if s.minProps != nil && len(v) < *s.minProps {
+			fail("must have at least %d properties", *s.minProps)
+		}
+		if s.maxProps != nil && len(v) > *s.maxProps {
+			fail("must have at most %d properties", *s.maxProps)
+		}
+		for _, name := range s.required {
+			if _, ok := v[name]; !ok {
+				violations = append(violations, ContractViolation{Path: propertyPath(path, name), Message: "is required"})
+			}
+		}
+		for _, name := range slices.Sorted(maps.Keys(v)) {
+			if property, ok := s.properties[name]; ok {
+				violations = property.validate(v[name], propertyPath(path, name), violations)
+			} else if s.additional != nil {
+				if s.additional.always != nil && !*s.additional.always {
+					violations = append(violations, ContractViolation{Path: propertyPath(path, name), Message: "is not allowed"})
+					continue
+				}
+				violations = s.additional.validate(v[name], propertyPath(path, name), violations)
+			}
+		}
+	}
+
+	for _, sub := range s.allOf {
+		violations = sub.validate(value, path, violations)
+	}
+	if s.anyOf != nil && !slices.ContainsFunc(s.anyOf, func(sub *PayloadSchema) bool { return sub.matches(value) }) {
+		fail("must match at least one of the allowed schemas")
+	}
+	if s.oneOf != nil {
+		matched := 0
+		for _, sub := range s.oneOf {
+			if sub.matches(value) {
+				matched++
+			}
+		}
+		if matched != 1 {
+			fail("must match exactly one of the allowed schemas, matched %d", matched)
+		}
+	}
+	if s.not != nil && s.not.matches(value) {
+		fail("must not match the excluded schema")
+	}
+	return violations
+}
+
+func (s *PayloadSchema) matches(value any) bool {
+	return len(s.validate(value, "$", nil)) == 0
+}
+
+func propertyPath(path, name string) string {
+	return path + "." + name
+}
+
+func jsonTypeMatches(t string, value any) bool {
+	switch t {
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	default:
+		return jsonTypeOf(value) == t
+	}
+}
+
+func jsonTypeOf(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return "null"
+	}
+}
+
+func joinTypes(types []string) string {
+	if len(types) == 1 {
+		return types[0]
+	}
+	text := types[0]
+	for _, t := range types[1 : len(types)-1] {
+		text += ", " + t
+	}
+	return text + " or " + types[len(types)-1]
+}
+
+func jsonText(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
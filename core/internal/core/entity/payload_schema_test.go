@@ -0,0 +1,113 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+const testPayloadSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"required": ["client_name", "amount"],
+	"additionalProperties": false,
+	"properties": {
+		"client_name": {"type": "string", "minLength": 1, "description": "Legal name"},
+		"amount": {"type": "number", "exclusiveMinimum": 0},
+		"currency": {"enum": ["EUR", "USD"]},
+		"due_date": {"type": "string", "pattern": "^\\d{4}-\\d{2}-\\d{2}$", "format": "date"},
+		"items": {"type": "array", "maxItems": 2, "items": {"type": "object", "required": ["qty"], "properties": {"qty": {"type": "integer"}}}}
+	}
+}`
+
+func TestPayloadSchema_Validate(t *testing.T) {
+	schema, err := CompilePayloadSchema(json.RawMessage(testPayloadSchema))
+	if err != nil {
+		t.Fatalf("CompilePayloadSchema() = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		payload string
+		want    []ContractViolation
+	}{
+		{"valid", `{"client_name":"ACME","amount":10,"currency":"EUR","items":[{"qty":2}]}`, nil},
+		{"missing required", `{"client_name":"ACME"}`, []ContractViolation{{"$.amount", "is required"}}},
+		{"wrong type", `{"client_name":7,"amount":1}`, []ContractViolation{{"$.client_name", "must be of type string, got number"}}},
+		{"bounds", `{"client_name":"","amount":0}`, []ContractViolation{
+			{"$.amount", "must be greater than 0"},
+			{"$.client_name", "must have at least 1 characters"},
+		}},
+		{"enum and pattern", `{"client_name":"A","amount":1,"currency":"GBP","due_date":"tomorrow"}`, []ContractViolation{
+			{"$.currency", "must be one of the allowed values"},
+			{"$.due_date", `must match ^\d{4}-\d{2}-\d{2}$`},
+		}},
+		{"nested items", `{"client_name":"A","amount":1,"items":[{"qty":1.5},{}]}`, []ContractViolation{
+			{"$.items[0].qty", "must be of type integer, got number"},
+			{"$.items[1].qty", "is required"},
+		}},
+		{"additional property", `{"client_name":"A","amount":1,"note":"x"}`, []ContractViolation{{"$.note", "is not allowed"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var payload any
+			if err := json.Unmarshal([]byte(tt.payload), &payload); err != nil {
+				t.Fatal(err)
+			}
+			got := schema.Validate(payload)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Validate() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("violation %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCompilePayloadSchema_RejectsUnsupportedSchemas(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{"not JSON", `{"type":`},
+		{"reference", `{"properties":{"client":{"$ref":"#/$defs/client"}}}`},
+		{"unknown type", `{"type":"date"}`},
+		{"bad pattern", `{"pattern":"("}`},
+		{"negative length", `{"maxLength":-1}`},
+		{"scalar schema", `{"properties":{"a":1}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CompilePayloadSchema(json.RawMessage(tt.schema)); !errors.Is(err, ErrInvalidPayloadContract) {
+				t.Errorf("CompilePayloadSchema() = %v, want ErrInvalidPayloadContract", err)
+			}
+		})
+	}
+}
+
+func TestDocumentTypeContract_Validate(t *testing.T) {
+	contract := &DocumentTypeContract{
+		Schema:  json.RawMessage(testPayloadSchema),
+		Example: json.RawMessage(`{"client_name":"ACME","amount":100}`),
+	}
+	if err := contract.Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+
+	contract.Example = json.RawMessage(`{"client_name":"ACME"}`)
+	if err := contract.Validate(); !errors.Is(err, ErrInvalidPayloadContract) {
+		t.Errorf("Validate() with an invalid example = %v, want ErrInvalidPayloadContract", err)
+	}
+
+	err := contract.ValidatePayload(nil)
+	var contractErr *PayloadContractError
+	if !errors.As(err, &contractErr) || !errors.Is(err, ErrPayloadContractViolation) {
+		t.Fatalf("ValidatePayload(nil) = %v, want a PayloadContractError", err)
+	}
+	if len(contractErr.Violations) != 2 {
+		t.Errorf("violations = %v, want both required properties", contractErr.Violations)
+	}
+}
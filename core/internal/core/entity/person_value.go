@@ -0,0 +1,21 @@
+package entity
+
+// Gender selects the gendered forms of salutations and honorifics.
+type Gender string
+
+// Genders of a person. Any other value, including "", is written with neutral forms.
+const (
+	GenderFemale Gender = "female"
+	GenderMale   Gender = "male"
+)
+
+// PersonValue is a person or party named in a document. Renders order its name parts and write
+// salutations by the conventions of the language of the format.
+type PersonValue struct {
+	GivenName  string `json:"givenName,omitempty"`
+	MiddleName string `json:"middleName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	Gender     Gender `json:"gender,omitempty"`
+	Title      string `json:"title,omitempty"`  // Academic or professional title before the name: "Dr.", "Prof. Dr."
+	Suffix     string `json:"suffix,omitempty"` // Written after the full name: "Jr.", "MBA"
+}
@@ -36,11 +36,19 @@ const (
 	NodeTypeTaskItem    = "taskItem"
 	NodeTypeInjector    = "injector"
 	NodeTypeConditional = "conditional"
+	NodeTypeRepeater    = "repeater" // Block repeated per row or item of a table or list injectable
 	NodeTypePageBreak   = "pageBreak"
 	NodeTypeImage       = "image"
 	NodeTypeCustomImage = "customImage"
 	NodeTypeText        = "text"
-	NodeTypeHardBreak   = "hardBreak" // Line break within paragraph (Shift+Enter)
+	NodeTypeHardBreak   = "hardBreak"  // Line break within paragraph (Shift+Enter)
+	NodeTypePageNumber  = "pageNumber" // Current page or total page count, resolved at compile time
+	// Security types
+	NodeTypeSecurityPattern = "securityPattern" // Anti-copy guilloche or microtext seeded by the document ID
+	// Attachment types
+	NodeTypeExternalPDF = "externalPdf" // Pages of an external PDF (uploaded asset or injectable URL)
+	// Reference types
+	NodeTypeReferences = "references" // Numbered table of the external links of the document
 	// List types
 	NodeTypeListInjector = "listInjector" // Dynamic list from system injector
 	// Table types
@@ -51,6 +59,39 @@ const (
 	NodeTypeTableHeader   = "tableHeader"
 )
 
+// Security pattern variants (securityPattern "variant" attr).
+const (
+	SecurityPatternGuilloche = "guilloche" // Interlaced fine-line waves; a band, or the page background without height
+	SecurityPatternMicrotext = "microtext" // A line of text too small to photocopy legibly
+)
+
+// Page number displays (pageNumber "display" attr).
+const (
+	PageNumberCurrent = "current" // Number of the page the node lands on
+	PageNumberTotal   = "total"   // Total number of pages in the rendered document
+)
+
+// Output formats a node can be restricted to (node "visibility" attr).
+// Nodes without a visibility are rendered in every output.
+const (
+	OutputPDF   = "pdf"   // Digital PDF, e.g. the archival copy
+	OutputHTML  = "html"  // HTML and email output
+	OutputPrint = "print" // PDF imposed for print
+)
+
+// ValidOutputFormats contains allowed node visibility values.
+var ValidOutputFormats = Set[string]{
+	OutputPDF:   {},
+	OutputHTML:  {},
+	OutputPrint: {},
+}
+
+// VisibleIn reports whether the node is rendered in the given output format.
+func (n Node) VisibleIn(output string) bool {
+	visibility, _ := n.Attrs["visibility"].(string)
+	return visibility == "" || visibility == output
+}
+
 // Mark type constants.
 const (
 	MarkTypeBold      = "bold"
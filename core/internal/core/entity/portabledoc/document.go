@@ -31,6 +31,20 @@ var ValidSurfaceLayouts = Set[string]{
 	SurfaceLayoutImageCenter: {},
 }
 
+// Surface pages constants: the pages a header or footer is rendered on.
+const (
+	SurfacePagesFirst = "first" // Header default
+	SurfacePagesLast  = "last"  // Footer default
+	SurfacePagesAll   = "all"
+)
+
+// ValidSurfacePages contains allowed surface pages values.
+var ValidSurfacePages = Set[string]{
+	SurfacePagesFirst: {},
+	SurfacePagesLast:  {},
+	SurfacePagesAll:   {},
+}
+
 // DocumentSurface is the common interface for header and footer surfaces.
 type DocumentSurface interface {
 	IsEnabled() bool
@@ -44,10 +58,12 @@ type DocumentSurface interface {
 }
 
 // DocumentHeader contains the document header configuration.
-// The header is rendered only on the first page.
+// The header is rendered only on the first page unless Pages says otherwise. Its text may hold
+// {{page}}, {{total}}, {{date}}, {{title}} and injectable key placeholders.
 type DocumentHeader struct {
 	Enabled              bool            `json:"enabled"`
-	Layout               string          `json:"layout"` // image-left | image-right | image-center
+	Layout               string          `json:"layout"`          // image-left | image-right | image-center
+	Pages                string          `json:"pages,omitempty"` // first (default) | last | all
 	ImageURL             string          `json:"imageUrl,omitempty"`
 	ImageAlt             string          `json:"imageAlt,omitempty"`
 	ImageInjectableID    string          `json:"imageInjectableId,omitempty"`
@@ -80,10 +96,12 @@ func (h *DocumentHeader) ContentNodes() []Node {
 }
 
 // DocumentFooter contains the document footer configuration.
-// The footer is rendered only on the last page.
+// The footer is rendered only on the last page unless Pages says otherwise. Its text may hold the
+// same placeholders as the header.
 type DocumentFooter struct {
 	Enabled              bool            `json:"enabled"`
-	Layout               string          `json:"layout"` // image-left | image-right | image-center
+	Layout               string          `json:"layout"`          // image-left | image-right | image-center
+	Pages                string          `json:"pages,omitempty"` // last (default) | first | all
 	ImageURL             string          `json:"imageUrl,omitempty"`
 	ImageAlt             string          `json:"imageAlt,omitempty"`
 	ImageInjectableID    string          `json:"imageInjectableId,omitempty"`
@@ -1,6 +1,9 @@
 package portabledoc
 
-import "testing"
+import (
+	"slices"
+	"testing"
+)
 
 func TestHeaderEnabled_NilHeader(t *testing.T) {
 	doc := &Document{}
@@ -153,3 +156,50 @@ func TestImageInjectableIDs_SkipsRegularImage(t *testing.T) {
 		t.Errorf("expected [img_regular], got %v", ids)
 	}
 }
+
+func TestUnknownNodes(t *testing.T) {
+	doc := &Document{Content: &ProseMirrorDoc{Type: NodeTypeDoc, Content: []Node{
+		{Type: NodeTypeParagraph},
+		{Type: "callout", Content: []Node{
+			{Type: NodeTypeParagraph},
+			{Type: "mention"},
+		}},
+	}}}
+
+	unknown := doc.UnknownNodes()
+	want := []UnknownNode{
+		{Type: "callout", Path: "content.content[1]"},
+		{Type: "mention", Path: "content.content[1].content[1]"},
+	}
+	if len(unknown) != len(want) {
+		t.Fatalf("expected %v, got %v", want, unknown)
+	}
+	for i := range want {
+		if unknown[i] != want[i] {
+			t.Errorf("expected %v, got %v", want[i], unknown[i])
+		}
+	}
+	if types := UnknownNodeTypes(append(unknown, unknown...)); len(types) != 2 || types[0] != "callout" {
+		t.Errorf("expected the sorted distinct types, got %v", types)
+	}
+}
+
+func TestInjectableNodePaths(t *testing.T) {
+	doc := &Document{Content: &ProseMirrorDoc{Type: NodeTypeDoc, Content: []Node{
+		{Type: NodeTypeParagraph, Content: []Node{
+			{Type: NodeTypeInjector, Attrs: map[string]any{"variableId": "customer_name"}},
+			{Type: NodeTypeInjector, Attrs: map[string]any{"variableId": "customer_id"}},
+		}},
+		{Type: NodeTypeCustomImage, Attrs: map[string]any{"injectableId": "customer_name"}},
+		{Type: NodeTypeTableInjector, Attrs: map[string]any{"variableId": "customer_name"}},
+	}}}
+
+	got := doc.InjectableNodePaths("customer_name")
+	want := []string{"content.content[0].content[0]", "content.content[1]", "content.content[2]"}
+	if !slices.Equal(got, want) {
+		t.Errorf("InjectableNodePaths() = %v, want %v", got, want)
+	}
+	if got := doc.InjectableNodePaths("unused"); got != nil {
+		t.Errorf("InjectableNodePaths(unused) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,112 @@
+package portabledoc
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ExternalPDFAttrs represents the attributes of an externalPdf node.
+// The PDF comes from Src, or from the URL injected into InjectableID when it has a value.
+type ExternalPDFAttrs struct {
+	Src          string `json:"src,omitempty"`          // PDF URL (http(s), storage:// or asset://)
+	InjectableID string `json:"injectableId,omitempty"` // injectable holding the PDF URL
+	Mode         string `json:"mode,omitempty"`         // ExternalPDFAppend (default) or ExternalPDFEmbed
+	Pages        string `json:"pages,omitempty"`        // page ranges, e.g. "1-3,5"; empty includes every page
+	Label        string `json:"label,omitempty"`        // editor display name
+}
+
+// External PDF placement modes (externalPdf "mode" attr).
+const (
+	ExternalPDFAppend = "append" // Each page is placed on a page of its own, at full size
+	ExternalPDFEmbed  = "embed"  // Pages are scaled to the content width and flow with the text
+)
+
+// ValidExternalPDFModes contains allowed external PDF modes.
+var ValidExternalPDFModes = Set[string]{
+	ExternalPDFAppend: {},
+	ExternalPDFEmbed:  {},
+}
+
+// EffectiveMode returns the placement mode, defaulting to append.
+func (a *ExternalPDFAttrs) EffectiveMode() string {
+	if a.Mode == "" {
+		return ExternalPDFAppend
+	}
+	return a.Mode
+}
+
+// pageRange is an inclusive range of 1-based page numbers. An end of 0 means the last page.
+type pageRange struct {
+	start, end int
+}
+
+// parsePageRanges parses a comma-separated list of pages and ranges: "2", "1-3", "4-" (to the end).
+func parsePageRanges(spec string) ([]pageRange, error) {
+	var ranges []pageRange
+	for part := range strings.SplitSeq(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, errors.New("empty page range")
+		}
+
+		startStr, endStr, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(strings.TrimSpace(startStr))
+		if err != nil || start < 1 {
+			return nil, fmt.Errorf("invalid page %q", part)
+		}
+		r := pageRange{start: start, end: start}
+		if isRange {
+			r.end = 0
+			if endStr = strings.TrimSpace(endStr); endStr != "" {
+				r.end, err = strconv.Atoi(endStr)
+				if err != nil || r.end < start {
+					return nil, fmt.Errorf("invalid page range %q", part)
+				}
+			}
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
+
+// ValidatePages checks the syntax of the pages attr.
+func (a *ExternalPDFAttrs) ValidatePages() error {
+	if strings.TrimSpace(a.Pages) == "" {
+		return nil
+	}
+	_, err := parsePageRanges(a.Pages)
+	return err
+}
+
+// PageNumbers returns the 1-based pages to include from a PDF of total pages, in the order
+// the pages attr lists them. Ranges are clipped to the document; pages past its end are an error.
+func (a *ExternalPDFAttrs) PageNumbers(total int) ([]int, error) {
+	if strings.TrimSpace(a.Pages) == "" {
+		pages := make([]int, total)
+		for i := range pages {
+			pages[i] = i + 1
+		}
+		return pages, nil
+	}
+
+	ranges, err := parsePageRanges(a.Pages)
+	if err != nil {
+		return nil, err
+	}
+	var pages []int
+	for _, r := range ranges {
+		if r.start > total {
+			return nil, fmt.Errorf("page %d is past the end of the document (%d pages)", r.start, total)
+		}
+		end := r.end
+		if end == 0 || end > total {
+			end = total
+		}
+		for p := r.start; p <= end; p++ {
+			pages = append(pages, p)
+		}
+	}
+	return pages, nil
+}
@@ -0,0 +1,32 @@
+package portabledoc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExternalPDFAttrs_PageNumbers(t *testing.T) {
+	tests := []struct {
+		pages   string
+		total   int
+		want    []int
+		wantErr bool
+	}{
+		{"", 3, []int{1, 2, 3}, false},
+		{"1-2, 5", 5, []int{1, 2, 5}, false},
+		{"3-", 4, []int{3, 4}, false},
+		{"2-9", 3, []int{2, 3}, false},
+		{"4", 3, nil, true},
+		{"3-1", 5, nil, true},
+		{"a", 5, nil, true},
+		{"1,,2", 5, nil, true},
+	}
+
+	for _, tt := range tests {
+		attrs := &ExternalPDFAttrs{Pages: tt.pages}
+		got, err := attrs.PageNumbers(tt.total)
+		if (err != nil) != tt.wantErr || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("PageNumbers(%q, %d) = %v, %v, want %v (error %v)", tt.pages, tt.total, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
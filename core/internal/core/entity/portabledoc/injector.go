@@ -12,6 +12,8 @@ type InjectorAttrs struct {
 	ShowLabelIfEmpty *bool    `json:"showLabelIfEmpty,omitempty"`
 	DefaultValue     *string  `json:"defaultValue,omitempty"`
 	Width            *float64 `json:"width,omitempty"`
+	TimeZone         *string  `json:"timeZone,omitempty"`   // IANA time zone of a date or time, replacing the document's
+	DateOffset       *string  `json:"dateOffset,omitempty"` // Shows the date moved by an offset such as "+30d" or "+10wd"
 }
 
 // Injector type constants.
@@ -1,21 +1,124 @@
 package portabledoc
 
+import "time"
+
 // Meta contains document metadata.
 type Meta struct {
 	Title        string            `json:"title"`
 	Description  *string           `json:"description,omitempty"`
 	Language     string            `json:"language"` // "en" | "es"
 	CustomFields map[string]string `json:"customFields,omitempty"`
+
+	// Renderer names the rendering backend registered through the SDK that renders this document.
+	// Empty or "typst" uses the built-in Typst renderer.
+	Renderer string `json:"renderer,omitempty"`
+
+	// AllowDegradedRender lets render requests ask for a degraded render, where failed injectors,
+	// images and external PDFs are reported instead of failing the render.
+	AllowDegradedRender bool `json:"allowDegradedRender,omitempty"`
+
+	// UnitSystem converts measurements to "metric" or "imperial" units; empty keeps their units.
+	// Render requests may override it.
+	UnitSystem string `json:"unitSystem,omitempty"`
+
+	// TimeZone is the IANA time zone dates and times are shown in, such as "America/Santiago";
+	// injector nodes may set their own. Empty shows each value in its own zone.
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Holidays are the days off, as YYYY-MM-DD, that working-day offsets and formulas skip
+	// besides weekends.
+	Holidays []string `json:"holidays,omitempty"`
+}
+
+// HolidayDates returns the holidays of the document, leaving out those that are not YYYY-MM-DD.
+func (m Meta) HolidayDates() []time.Time {
+	dates := make([]time.Time, 0, len(m.Holidays))
+	for _, holiday := range m.Holidays {
+		if date, err := time.Parse(time.DateOnly, holiday); err == nil {
+			dates = append(dates, date)
+		}
+	}
+	return dates
 }
 
 // PageConfig contains page configuration.
 type PageConfig struct {
-	FormatID        string  `json:"formatId"` // "A4" | "LETTER" | "LEGAL" | "CUSTOM"
-	Width           float64 `json:"width"`
-	Height          float64 `json:"height"`
-	Margins         Margins `json:"margins"`
-	ShowPageNumbers bool    `json:"showPageNumbers"`
-	PageGap         float64 `json:"pageGap"`
+	FormatID        string     `json:"formatId"` // "A4" | "LETTER" | "LEGAL" | "CUSTOM"
+	Width           float64    `json:"width"`
+	Height          float64    `json:"height"`
+	Margins         Margins    `json:"margins"`
+	ShowPageNumbers bool       `json:"showPageNumbers"`
+	PageGap         float64    `json:"pageGap"`
+	PageStamp       *PageStamp `json:"pageStamp,omitempty"`
+	Overlays        *Overlays  `json:"overlays,omitempty"` // Watermark, stamp and Bates numbering; render requests may override them
+
+	// LayoutVariants declares the layout parameters a render request may switch to.
+	LayoutVariants *LayoutVariants `json:"layoutVariants,omitempty"`
+}
+
+// LayoutVariants lists the values a render request may choose besides the page config itself,
+// so one template version can serve A4 and US Letter audiences.
+type LayoutVariants struct {
+	PaperSizes    []string  `json:"paperSizes,omitempty"`    // "A4" | "LETTER" | "LEGAL"
+	MarginPresets []string  `json:"marginPresets,omitempty"` // "narrow" | "normal" | "wide"
+	FontScales    []float64 `json:"fontScales,omitempty"`    // Multipliers of the font sizes (0.5-2)
+}
+
+// Font scale limits for layout variants.
+const (
+	MinFontScale = 0.5
+	MaxFontScale = 2.0
+)
+
+// Margin presets for layout variants.
+const (
+	MarginPresetNarrow = "narrow"
+	MarginPresetNormal = "normal"
+	MarginPresetWide   = "wide"
+)
+
+// MarginPresets maps margin presets to their margins.
+var MarginPresets = map[string]Margins{
+	MarginPresetNarrow: {Top: 64, Bottom: 64, Left: 64, Right: 64},
+	MarginPresetNormal: {Top: 96, Bottom: 96, Left: 96, Right: 96},
+	MarginPresetWide:   {Top: 144, Bottom: 144, Left: 144, Right: 144},
+}
+
+// PageSize is a page width and height in pixels.
+type PageSize struct {
+	Width  float64
+	Height float64
+}
+
+// StandardPageSizes maps standard page formats to the sizes used by the editor.
+var StandardPageSizes = map[string]PageSize{
+	PageFormatA4:     {Width: 794, Height: 1123},
+	PageFormatLetter: {Width: 818, Height: 1060},
+	PageFormatLegal:  {Width: 818, Height: 1404},
+}
+
+// PageStamp is a page counter printed on every page, outside the header and footer.
+type PageStamp struct {
+	Position string `json:"position"` // "top-left" | "top-right" | "bottom-left" | "bottom-center" | "bottom-right"
+	Format   string `json:"format"`   // Text with {{page}} and {{total}} placeholders (default: "{{page}} / {{total}}")
+}
+
+// Page stamp positions.
+const (
+	PageStampTopLeft      = "top-left"
+	PageStampTopRight     = "top-right"
+	PageStampBottomLeft   = "bottom-left"
+	PageStampBottomCenter = "bottom-center"
+	PageStampBottomRight  = "bottom-right"
+)
+
+// ValidPageStampPositions contains allowed page stamp positions.
+var ValidPageStampPositions = Set[string]{
+	PageStampTopLeft:      {},
+	PageStampTopRight:     {},
+	PageStampBottomLeft:   {},
+	PageStampBottomCenter: {},
+	PageStampBottomRight:  {},
 }
 
 // Margins defines page margins in pixels.
@@ -26,6 +129,9 @@ type Margins struct {
 	Right  float64 `json:"right"`
 }
 
+// RendererTypst is the name of the built-in rendering backend.
+const RendererTypst = "typst"
+
 // Language constants.
 const (
 	LanguageEnglish = "en"
@@ -38,6 +144,18 @@ var ValidLanguages = Set[string]{
 	LanguageSpanish: {},
 }
 
+// Unit system constants.
+const (
+	UnitSystemMetric   = "metric"
+	UnitSystemImperial = "imperial"
+)
+
+// ValidUnitSystems contains allowed unit systems.
+var ValidUnitSystems = Set[string]{
+	UnitSystemMetric:   {},
+	UnitSystemImperial: {},
+}
+
 // Page format constants.
 const (
 	PageFormatA4     = "A4"
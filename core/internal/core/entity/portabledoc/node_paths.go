@@ -0,0 +1,47 @@
+package portabledoc
+
+import "fmt"
+
+// NodePaths returns the JSON paths of the nodes match accepts, in document order, e.g.
+// content.content[2].content[0]. Nodes of the header and footer have no path.
+func (d *Document) NodePaths(match func(Node) bool) []string {
+	var paths []string
+	d.walkPaths(func(node Node, path string) {
+		if match(node) {
+			paths = append(paths, path)
+		}
+	})
+	return paths
+}
+
+// InjectableNodePaths returns the paths of the nodes that show the injectable key: injectors, list
+// and table injectors, repeaters, and images bound to it.
+func (d *Document) InjectableNodePaths(key string) []string {
+	return d.NodePaths(func(node Node) bool {
+		switch node.Type {
+		case NodeTypeInjector, NodeTypeListInjector, NodeTypeTableInjector, NodeTypeRepeater:
+			id, _ := node.Attrs["variableId"].(string)
+			return id == key
+		case NodeTypeImage, NodeTypeCustomImage:
+			id, _ := node.Attrs["injectableId"].(string)
+			return id == key
+		}
+		return false
+	})
+}
+
+// walkPaths calls visit for every node of the content in document order, with its JSON path.
+func (d *Document) walkPaths(visit func(node Node, path string)) {
+	if d.Content == nil {
+		return
+	}
+	var walk func(nodes []Node, path string)
+	walk = func(nodes []Node, path string) {
+		for i, node := range nodes {
+			nodePath := fmt.Sprintf("%s.content[%d]", path, i)
+			visit(node, nodePath)
+			walk(node.Content, nodePath)
+		}
+	}
+	walk(d.Content.Content, "content")
+}
@@ -0,0 +1,72 @@
+package portabledoc
+
+// Overlays are marks printed over the content of every page. Templates set them in their page
+// config and render requests may override each of them.
+type Overlays struct {
+	Watermark string          `json:"watermark,omitempty"` // Text stamped diagonally across every page, e.g. "DRAFT"
+	Stamp     *OverlayStamp   `json:"stamp,omitempty"`
+	Bates     *BatesNumbering `json:"bates,omitempty"`
+}
+
+// OverlayStamp is a framed label, e.g. "COPY", printed at a corner of every page.
+type OverlayStamp struct {
+	Text     string `json:"text"`
+	Position string `json:"position,omitempty"` // Page stamp positions (default: "top-right")
+	Color    string `json:"color,omitempty"`    // #RRGGBB (default: "#C62828")
+}
+
+// BatesNumbering prints a sequential identifier on every page, e.g. ACME000001, continuing from
+// Start so a set of documents can be numbered across renders.
+type BatesNumbering struct {
+	Prefix   string `json:"prefix,omitempty"`
+	Start    int    `json:"start,omitempty"`    // Number of the first page (default: 1)
+	Digits   int    `json:"digits,omitempty"`   // Width the number is zero-padded to (default: 6)
+	Position string `json:"position,omitempty"` // Page stamp positions (default: "bottom-right")
+}
+
+// Overlay limits.
+const (
+	MaxOverlayWatermarkLength = 100
+	MaxOverlayStampLength     = 40
+	MaxBatesPrefixLength      = 20
+	MaxBatesDigits            = 12
+	MaxBatesStart             = 999_999_999
+)
+
+// Overlay defaults.
+const (
+	DefaultOverlayStampPosition = PageStampTopRight
+	DefaultOverlayStampColor    = "#C62828"
+	DefaultBatesStart           = 1
+	DefaultBatesDigits          = 6
+	DefaultBatesPosition        = PageStampBottomRight
+)
+
+// MergeOverlays returns the overlays of base with those set in override replacing them.
+// Returns nil when neither sets an overlay.
+func MergeOverlays(base, override *Overlays) *Overlays {
+	var merged Overlays
+	for _, o := range []*Overlays{base, override} {
+		if o == nil {
+			continue
+		}
+		if o.Watermark != "" {
+			merged.Watermark = o.Watermark
+		}
+		if o.Stamp != nil {
+			merged.Stamp = o.Stamp
+		}
+		if o.Bates != nil {
+			merged.Bates = o.Bates
+		}
+	}
+	if merged.IsEmpty() {
+		return nil
+	}
+	return &merged
+}
+
+// IsEmpty reports whether no overlay is set.
+func (o *Overlays) IsEmpty() bool {
+	return o == nil || (o.Watermark == "" && o.Stamp == nil && o.Bates == nil)
+}
@@ -57,6 +57,66 @@ func ParseInjectorAttrs(attrs map[string]any) (*InjectorAttrs, error) {
 	return &ia, nil
 }
 
+// ParseTableInjectorAttrs parses node attrs into TableInjectorAttrs.
+func ParseTableInjectorAttrs(attrs map[string]any) (*TableInjectorAttrs, error) {
+	data, err := json.Marshal(attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	var ta TableInjectorAttrs
+	if err := json.Unmarshal(data, &ta); err != nil {
+		return nil, err
+	}
+
+	return &ta, nil
+}
+
+// ParseExternalPDFAttrs parses node attrs into ExternalPDFAttrs.
+func ParseExternalPDFAttrs(attrs map[string]any) (*ExternalPDFAttrs, error) {
+	data, err := json.Marshal(attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	var ea ExternalPDFAttrs
+	if err := json.Unmarshal(data, &ea); err != nil {
+		return nil, err
+	}
+
+	return &ea, nil
+}
+
+// ParseReferencesAttrs parses node attrs into ReferencesAttrs.
+func ParseReferencesAttrs(attrs map[string]any) (*ReferencesAttrs, error) {
+	data, err := json.Marshal(attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	var ra ReferencesAttrs
+	if err := json.Unmarshal(data, &ra); err != nil {
+		return nil, err
+	}
+
+	return &ra, nil
+}
+
+// ParseRepeaterAttrs parses node attrs into RepeaterAttrs.
+func ParseRepeaterAttrs(attrs map[string]any) (*RepeaterAttrs, error) {
+	data, err := json.Marshal(attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	var ra RepeaterAttrs
+	if err := json.Unmarshal(data, &ra); err != nil {
+		return nil, err
+	}
+
+	return &ra, nil
+}
+
 // ParseLogicGroup parses any value into LogicGroup.
 func ParseLogicGroup(v any) (*LogicGroup, error) {
 	data, err := json.Marshal(v)
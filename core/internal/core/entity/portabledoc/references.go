@@ -0,0 +1,53 @@
+package portabledoc
+
+import "strings"
+
+// ReferencesAttrs represents the attributes of a references node.
+type ReferencesAttrs struct {
+	Title   string `json:"title,omitempty"`   // shown above the table; empty shows none
+	Markers bool   `json:"markers,omitempty"` // follow each link in the text with its reference number
+}
+
+// LinkHref returns the href of the link mark of a text node, or an empty string when it has none.
+func LinkHref(node Node) string {
+	if node.Type != NodeTypeText {
+		return ""
+	}
+	for _, mark := range node.Marks {
+		if mark.Type == MarkTypeLink {
+			href, _ := mark.Attrs["href"].(string)
+			return strings.TrimSpace(href)
+		}
+	}
+	return ""
+}
+
+// LinkRunLength returns the number of leading nodes that are text linked to href: the
+// fragments of a single link whose style changes midway, such as a partly bold link.
+func LinkRunLength(nodes []Node, href string) int {
+	n := 0
+	for n < len(nodes) && LinkHref(nodes[n]) == href {
+		n++
+	}
+	return n
+}
+
+// ExternalLinks returns the http(s) URLs of the link marks in the document content, once
+// each and in document order.
+func (d *Document) ExternalLinks() []string {
+	seen := make(Set[string])
+	var links []string
+	for node := range d.AllNodesRecursive() {
+		href := LinkHref(node)
+		lower := strings.ToLower(href)
+		if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+			continue
+		}
+		if seen.Contains(href) {
+			continue
+		}
+		seen.Add(href)
+		links = append(links, href)
+	}
+	return links
+}
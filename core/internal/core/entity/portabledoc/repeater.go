@@ -0,0 +1,46 @@
+package portabledoc
+
+import "strings"
+
+// DefaultRepeaterAlias names the current item inside a repeater without an alias.
+const DefaultRepeaterAlias = "item"
+
+// RepeaterIndexField is the field of the current item holding its 1-based position.
+const RepeaterIndexField = "index"
+
+// RepeaterAttrs represents repeater block attributes. A repeater renders its content once per
+// row of a table injectable or item of a list injectable. Inside the block, injectors, conditions
+// and images read the current item as "<alias>.<column key>" (a cell of a table row), "<alias>"
+// (a list item) and "<alias>.index" (its 1-based position).
+type RepeaterAttrs struct {
+	VariableID string `json:"variableId"`      // Table or list injectable repeated over
+	Alias      string `json:"alias,omitempty"` // Name of the current item; default DefaultRepeaterAlias
+}
+
+// ItemAlias returns the alias of the current item, or DefaultRepeaterAlias.
+func (a *RepeaterAttrs) ItemAlias() string {
+	if a.Alias == "" {
+		return DefaultRepeaterAlias
+	}
+	return a.Alias
+}
+
+// RepeaterAliases returns the item aliases of the repeaters of the document.
+func (d *Document) RepeaterAliases() Set[string] {
+	aliases := make(Set[string])
+	for _, node := range d.NodesOfType(NodeTypeRepeater) {
+		attrs, err := ParseRepeaterAttrs(node.Attrs)
+		if err != nil {
+			continue
+		}
+		aliases[attrs.ItemAlias()] = struct{}{}
+	}
+	return aliases
+}
+
+// IsRepeaterItemVariable reports whether a variable ID reads the current item of a repeater
+// with one of the aliases: the alias itself or one of its fields.
+func IsRepeaterItemVariable(id string, aliases Set[string]) bool {
+	alias, _, _ := strings.Cut(id, ".")
+	return aliases.Contains(alias)
+}
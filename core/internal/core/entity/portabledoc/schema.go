@@ -0,0 +1,26 @@
+package portabledoc
+
+// EditorSchema describes the parts of the document format a server build renders, so the editor
+// can disable features the converter would drop instead of letting users author them.
+type EditorSchema struct {
+	FormatVersion    string       // Latest document format version the server parses
+	ConverterVersion string       // Changes whenever the supported nodes, marks or attrs change
+	CommonAttrs      []string     // Attrs every node accepts
+	Nodes            []NodeSchema // Sorted by type
+	Marks            []MarkSchema // Sorted by type
+	InjectableTypes  []string     // Value types injectors can resolve
+	Outputs          []string     // Output formats a render can produce
+}
+
+// NodeSchema is a node type the converter renders and the attrs it reads.
+type NodeSchema struct {
+	Type    string
+	Attrs   []string
+	Outputs []string // Outputs that render the node; the others omit it and report a warning
+}
+
+// MarkSchema is a mark type the converter applies and the attrs it reads.
+type MarkSchema struct {
+	Type  string
+	Attrs []string
+}
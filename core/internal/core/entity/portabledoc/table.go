@@ -0,0 +1,49 @@
+package portabledoc
+
+// TableInjectorAttrs represents the calculation attributes of a tableInjector node.
+// Calculated columns and aggregates are evaluated by the renderer over the injected table.
+type TableInjectorAttrs struct {
+	VariableID        string             `json:"variableId"`
+	CalculatedColumns []CalculatedColumn `json:"calculatedColumns,omitempty"`
+	Aggregates        []TableAggregate   `json:"aggregates,omitempty"`
+	FooterLabel       string             `json:"footerLabel,omitempty"` // shown in the first footer cell when it has no aggregate
+}
+
+// HasCalculations returns true if the node declares calculated columns or aggregates.
+func (a *TableInjectorAttrs) HasCalculations() bool {
+	return len(a.CalculatedColumns) > 0 || len(a.Aggregates) > 0
+}
+
+// CalculatedColumn is a column appended to the injected table whose cells are derived
+// from the other cells of the row.
+type CalculatedColumn struct {
+	Key        string `json:"key"`
+	Label      string `json:"label"`
+	Expression string `json:"expression"`       // expr-lang expression over column keys, e.g. "qty * unitPrice"
+	Format     string `json:"format,omitempty"` // same format strings as injected columns
+	Width      string `json:"width,omitempty"`
+}
+
+// TableAggregate is a footer cell summarizing one column.
+type TableAggregate struct {
+	Column   string `json:"column"`
+	Function string `json:"function"`
+}
+
+// Aggregate function constants.
+const (
+	AggregateSum   = "sum"
+	AggregateAvg   = "avg"
+	AggregateMin   = "min"
+	AggregateMax   = "max"
+	AggregateCount = "count"
+)
+
+// ValidAggregateFunctions contains allowed aggregate functions.
+var ValidAggregateFunctions = Set[string]{
+	AggregateSum:   {},
+	AggregateAvg:   {},
+	AggregateMin:   {},
+	AggregateMax:   {},
+	AggregateCount: {},
+}
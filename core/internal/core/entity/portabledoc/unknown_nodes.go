@@ -0,0 +1,42 @@
+package portabledoc
+
+import "slices"
+
+// KnownNodeTypes contains the node types the converters render. Nodes of other types, such as
+// nodes of a newer editor or of a custom extension, are unknown.
+var KnownNodeTypes = NewSet([]string{
+	NodeTypeParagraph, NodeTypeHeading, NodeTypeBlockquote, NodeTypeCodeBlock, NodeTypeHR,
+	NodeTypeBulletList, NodeTypeOrderedList, NodeTypeTaskList, NodeTypeListItem, NodeTypeTaskItem,
+	NodeTypeInjector, NodeTypeConditional, NodeTypeRepeater, NodeTypePageBreak, NodeTypeImage,
+	NodeTypeCustomImage, NodeTypeText, NodeTypeHardBreak, NodeTypePageNumber, NodeTypeSecurityPattern,
+	NodeTypeExternalPDF, NodeTypeReferences, NodeTypeListInjector, NodeTypeTableInjector,
+	NodeTypeTable, NodeTypeTableRow, NodeTypeTableCell, NodeTypeTableHeader,
+})
+
+// UnknownNode is a node of a type outside KnownNodeTypes.
+type UnknownNode struct {
+	Type string
+	Path string // JSON path of the node in the document, e.g. content.content[2].content[0]
+}
+
+// UnknownNodes returns the unknown nodes of the document in document order, including those
+// nested in other unknown nodes.
+func (d *Document) UnknownNodes() []UnknownNode {
+	var unknown []UnknownNode
+	d.walkPaths(func(node Node, path string) {
+		if !KnownNodeTypes.Contains(node.Type) {
+			unknown = append(unknown, UnknownNode{Type: node.Type, Path: path})
+		}
+	})
+	return unknown
+}
+
+// UnknownNodeTypes returns the distinct types of the unknown nodes, sorted.
+func UnknownNodeTypes(unknown []UnknownNode) []string {
+	types := make([]string, 0, len(unknown))
+	for _, node := range unknown {
+		types = append(types, node.Type)
+	}
+	slices.Sort(types)
+	return slices.Compact(types)
+}
@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// Preview token lifetime bounds.
+const (
+	PreviewTokenDefaultTTL = 72 * time.Hour
+	PreviewTokenMaxTTL     = 30 * 24 * time.Hour
+)
+
+// PreviewToken is a share link that lets anyone holding it view a rendered preview of one
+// template version, without a workspace account, until it expires or is revoked.
+type PreviewToken struct {
+	ID          string     `json:"id"`
+	WorkspaceID string     `json:"workspaceId"`
+	VersionID   string     `json:"versionId"`
+	TokenHash   string     `json:"-"` // SHA-256 of the shared token; the raw token is never stored
+	Label       *string    `json:"label,omitempty"`
+	Watermark   *string    `json:"watermark,omitempty"` // Text stamped across every page of the preview
+	CreatedBy   *string    `json:"createdBy,omitempty"`
+	ExpiresAt   time.Time  `json:"expiresAt"`
+	RevokedAt   *time.Time `json:"revokedAt,omitempty"`
+	LastUsedAt  *time.Time `json:"lastUsedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// NewPreviewToken creates a preview token for a version that expires after ttl.
+func NewPreviewToken(workspaceID, versionID string, label, watermark, createdBy *string, ttl time.Duration) *PreviewToken {
+	now := time.Now().UTC()
+	return &PreviewToken{
+		WorkspaceID: workspaceID,
+		VersionID:   versionID,
+		Label:       trimmedOrNil(label),
+		Watermark:   trimmedOrNil(watermark),
+		CreatedBy:   createdBy,
+		ExpiresAt:   now.Add(ttl),
+		CreatedAt:   now,
+	}
+}
+
+// IsExpired returns true if the token can no longer be used because of its age.
+func (t *PreviewToken) IsExpired() bool {
+	return time.Now().UTC().After(t.ExpiresAt)
+}
+
+// IsRevoked returns true if the token was revoked.
+func (t *PreviewToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// CanUse returns an error if the token no longer grants access to the preview.
+func (t *PreviewToken) CanUse() error {
+	if t.IsRevoked() || t.IsExpired() {
+		return ErrPreviewTokenExpired
+	}
+	return nil
+}
+
+// Revoke ends the token before its expiration. Revoking twice keeps the first time.
+func (t *PreviewToken) Revoke() {
+	if t.RevokedAt != nil {
+		return
+	}
+	now := time.Now().UTC()
+	t.RevokedAt = &now
+}
+
+// Validate checks if the preview token data is valid.
+func (t *PreviewToken) Validate() error {
+	if t.WorkspaceID == "" || t.VersionID == "" || t.TokenHash == "" {
+		return ErrRequiredField
+	}
+	if t.Label != nil && len(*t.Label) > 255 {
+		return ErrFieldTooLong
+	}
+	if t.Watermark != nil && len(*t.Watermark) > 100 {
+		return ErrFieldTooLong
+	}
+	return nil
+}
+
+func trimmedOrNil(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := strings.TrimSpace(*s)
+	if v == "" {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Remote injector limits.
+const (
+	RemoteInjectorDefaultTimeoutMs = PluginInjectorDefaultTimeoutMs
+	RemoteInjectorMaxTimeoutMs     = PluginInjectorMaxTimeoutMs
+)
+
+// RemoteProtocol is how the engine calls a remote injector service.
+type RemoteProtocol string
+
+const (
+	// RemoteProtocolHTTP posts JSON to the /manifest, /resolve and /health paths of the URL.
+	RemoteProtocolHTTP RemoteProtocol = "http"
+	// RemoteProtocolGRPC calls the pdfforge.injector.v1.RemoteInjector service and the standard
+	// gRPC health service.
+	RemoteProtocolGRPC RemoteProtocol = "grpc"
+)
+
+// RemoteEndpoint is a remote injector service registered by the operator.
+type RemoteEndpoint struct {
+	Name     string // Prefix of its injector codes
+	Protocol RemoteProtocol
+	// URL is the base URL of an HTTP service, or the host:port target of a gRPC service.
+	URL string
+	// AuthHeader and AuthToken are sent with every call when the token is set.
+	AuthHeader string
+	AuthToken  string
+	// Insecure calls a gRPC service without TLS.
+	Insecure bool
+}
+
+// Validate checks the endpoint.
+func (e *RemoteEndpoint) Validate() error {
+	if !pluginNamePattern.MatchString(e.Name) {
+		return fmt.Errorf("%w: name %q must be 2 to 30 lowercase letters and digits, starting with a letter", ErrInvalidRemoteInjector, e.Name)
+	}
+	switch e.Protocol {
+	case RemoteProtocolHTTP:
+		u, err := url.Parse(e.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: remote %q needs an http or https URL", ErrInvalidRemoteInjector, e.Name)
+		}
+	case RemoteProtocolGRPC:
+		if e.URL == "" {
+			return fmt.Errorf("%w: remote %q needs a gRPC target", ErrInvalidRemoteInjector, e.Name)
+		}
+	default:
+		return fmt.Errorf("%w: remote %q protocol must be http or grpc", ErrInvalidRemoteInjector, e.Name)
+	}
+	if e.AuthToken != "" && e.AuthHeader == "" {
+		return fmt.Errorf("%w: remote %q has a token but no auth header", ErrInvalidRemoteInjector, e.Name)
+	}
+	return nil
+}
+
+// RemoteInjectorManifest is what a remote injector service provides. Its injectors are described
+// like those of a WASM plugin, with codes starting with the name of the remote.
+type RemoteInjectorManifest struct {
+	Injectors []*PluginInjectorSpec `json:"injectors"`
+}
+
+// Validate checks the manifest of the remote called name. Zero timeouts take the default.
+func (m *RemoteInjectorManifest) Validate(name string) error {
+	if len(m.Injectors) == 0 {
+		return fmt.Errorf("%w: remote %q provides no injectors", ErrInvalidRemoteInjector, name)
+	}
+	return validateInjectorSpecs(name, m.Injectors, ErrInvalidRemoteInjector)
+}
+
+// RemoteInjectorRequest is the injector context sent to a remote injector service to resolve an
+// injector. Payload and Headers are only sent to remotes trusted with the request.
+type RemoteInjectorRequest struct {
+	Code           string            `json:"code"`
+	Payload        any               `json:"payload,omitempty"`
+	Headers        map[string]string `json:"headers,omitempty"`
+	Resolved       map[string]any    `json:"resolved"` // Values of the dependencies
+	ExternalID     string            `json:"externalId,omitempty"`
+	TemplateID     string            `json:"templateId,omitempty"`
+	Operation      string            `json:"operation,omitempty"`
+	TenantCode     string            `json:"tenantCode"`
+	WorkspaceCode  string            `json:"workspaceCode"`
+	Environment    string            `json:"environment"`
+	SelectedFormat string            `json:"selectedFormat,omitempty"`
+}
+
+// RemoteInjectorResponse is the injector result returned by a remote injector service. A null
+// value yields no value; a non-empty Error fails the injector.
+type RemoteInjectorResponse struct {
+	Value any `json:"value"`
+	// DataType, when set, must be the data type declared in the manifest.
+	DataType InjectableDataType `json:"dataType,omitempty"`
+	Metadata map[string]any     `json:"metadata,omitempty"`
+	Error    string             `json:"error,omitempty"`
+}
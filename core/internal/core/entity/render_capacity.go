@@ -0,0 +1,12 @@
+package entity
+
+// RenderCapacity is a snapshot of the render load on one instance, used by external autoscalers
+// to scale render workers on backlog rather than CPU.
+type RenderCapacity struct {
+	QueueDepth    int     // Renders waiting for a slot
+	ActiveRenders int     // Renders holding a slot
+	MaxConcurrent int     // Slot count; 0 means unlimited
+	Utilization   float64 // ActiveRenders / MaxConcurrent; 0 when unlimited
+	AverageWaitMs float64 // Mean wait for a slot over the last minute, in milliseconds
+	RejectedTotal int64   // Renders rejected as busy since startup
+}
@@ -0,0 +1,219 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// RenderErrorCode classifies why a render failed, so callers can tell failures worth retrying from
+// the ones that fail again until the request or the template changes.
+type RenderErrorCode string
+
+const (
+	RenderErrorInvalidPayload  RenderErrorCode = "RENDER_INVALID_PAYLOAD"  // The request is missing values or has invalid options
+	RenderErrorInjectorTimeout RenderErrorCode = "RENDER_INJECTOR_TIMEOUT" // A critical injector did not answer within its timeout
+	RenderErrorInjectorFailed  RenderErrorCode = "RENDER_INJECTOR_FAILED"  // A critical injector or the injectable provider returned an error
+	RenderErrorAssetFetch      RenderErrorCode = "RENDER_ASSET_FETCH"      // A file the document includes could not be downloaded
+	RenderErrorCompile         RenderErrorCode = "RENDER_COMPILE"          // Typst rejected the generated source
+	RenderErrorPostProcessing  RenderErrorCode = "RENDER_POST_PROCESSING"  // The compiled PDF could not be imposed or inspected
+	RenderErrorCapacity        RenderErrorCode = "RENDER_CAPACITY"         // The renderer was at capacity and the render never started
+)
+
+// RenderErrorInfo describes a render error code.
+type RenderErrorInfo struct {
+	Code        RenderErrorCode `json:"code"`
+	Retryable   bool            `json:"retryable"`
+	Description string          `json:"description"`
+}
+
+// renderErrors is the registry of render error codes.
+var renderErrors = []RenderErrorInfo{
+	{RenderErrorInvalidPayload, false, "the request is missing required values or has invalid render options"},
+	{RenderErrorInjectorTimeout, true, "a critical injector did not answer within its timeout"},
+	{RenderErrorInjectorFailed, true, "a critical injector or the injectable provider returned an error"},
+	{RenderErrorAssetFetch, true, "a file the document includes could not be downloaded"},
+	{RenderErrorCompile, false, "the template could not be compiled with the given values"},
+	{RenderErrorPostProcessing, false, "the compiled PDF could not be imposed or inspected"},
+	{RenderErrorCapacity, true, "the renderer was at capacity; the render never started"},
+}
+
+// RenderErrorCodes returns the registry of render error codes.
+func RenderErrorCodes() []RenderErrorInfo {
+	return append([]RenderErrorInfo(nil), renderErrors...)
+}
+
+// Retryable returns true if the same render may succeed when sent again unchanged.
+func (c RenderErrorCode) Retryable() bool {
+	for _, info := range renderErrors {
+		if info.Code == c {
+			return info.Retryable
+		}
+	}
+	return false
+}
+
+// ErrRenderPostProcessing is returned when a compiled PDF cannot be post-processed.
+var ErrRenderPostProcessing = errors.New("the rendered PDF could not be post-processed")
+
+// InjectorError is returned when a critical injector, or the workspace injectable provider,
+// fails and the render cannot go on.
+type InjectorError struct {
+	Code      string   // Injector code; empty for the workspace injectable provider
+	NodePaths []string // Document nodes that show the code
+	Err       error
+}
+
+func (e *InjectorError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("injectable provider failed: %v", e.Err)
+	}
+	return fmt.Sprintf("critical injector %q failed: %v", e.Code, e.Err)
+}
+
+func (e *InjectorError) Unwrap() error {
+	return e.Err
+}
+
+// RenderErrorCodeOf classifies a render error. It returns false for errors that are not render
+// failures, such as a template that does not exist.
+func RenderErrorCodeOf(err error) (RenderErrorCode, bool) {
+	var (
+		injectorErr *InjectorError
+		compileErr  *CompileError
+		missingErr  *MissingInjectablesError
+	)
+	switch {
+	case err == nil:
+		return "", false
+	case errors.As(err, &injectorErr):
+		if errors.Is(err, context.DeadlineExceeded) {
+			return RenderErrorInjectorTimeout, true
+		}
+		return RenderErrorInjectorFailed, true
+	case errors.Is(err, ErrRenderPostProcessing):
+		// Checked before compile errors: imposition compiles a second Typst source
+		return RenderErrorPostProcessing, true
+	case errors.As(err, &compileErr):
+		return RenderErrorCompile, true
+	case errors.Is(err, ErrExternalPDFUnavailable), errors.Is(err, ErrImageUnavailable):
+		return RenderErrorAssetFetch, true
+	case errors.Is(err, ErrRendererBusy):
+		return RenderErrorCapacity, true
+	case errors.As(err, &missingErr),
+		errors.Is(err, ErrPayloadContractViolation),
+		errors.Is(err, ErrLayoutNotAllowed),
+		errors.Is(err, ErrDegradedRenderNotAllowed),
+		errors.Is(err, ErrInvalidImposition),
+		errors.Is(err, ErrPersistAndHost),
+		errors.Is(err, ErrInvalidHostedAccess),
+		errors.Is(err, ErrInvalidHostedTTL),
+		errors.Is(err, ErrUnsupportedRenderFormat),
+		errors.Is(err, ErrHTMLRenderOption),
+		errors.Is(err, ErrDocxRenderOption):
+		return RenderErrorInvalidPayload, true
+	default:
+		return "", false
+	}
+}
+
+// RenderErrorReason names what broke in a failed render, finer than its RenderErrorCode, so
+// callers can point users at the placeholder or file to fix.
+type RenderErrorReason string
+
+const (
+	RenderReasonTypstCompile      RenderErrorReason = "TYPST_COMPILE_ERROR"         // Typst rejected the source generated for a node
+	RenderReasonInjectorTimeout   RenderErrorReason = "INJECTOR_TIMEOUT"            // A critical injector did not answer within its timeout
+	RenderReasonInjectorFailed    RenderErrorReason = "INJECTOR_FAILED"             // A critical injector or the injectable provider returned an error
+	RenderReasonImageFetch        RenderErrorReason = "IMAGE_FETCH_FAILED"          // An image could not be downloaded
+	RenderReasonExternalPDFFetch  RenderErrorReason = "EXTERNAL_PDF_FETCH_FAILED"   // An external PDF could not be downloaded or read
+	RenderReasonMissingInjectable RenderErrorReason = "MISSING_REQUIRED_INJECTABLE" // A required injectable has no value and no default
+)
+
+// RenderErrorDetail locates one cause of a failed render in the document.
+type RenderErrorDetail struct {
+	Reason     RenderErrorReason `json:"reason"`
+	Message    string            `json:"message,omitempty"`
+	Injectable string            `json:"injectable,omitempty"` // Code of the injectable or injector involved
+	NodePaths  []string          `json:"nodePaths,omitempty"`  // Document nodes involved, e.g. content.content[2].content[0]
+	Line       int               `json:"line,omitempty"`       // Position in the generated Typst source, for TYPST_COMPILE_ERROR
+	Column     int               `json:"column,omitempty"`
+	URL        string            `json:"url,omitempty"` // File that failed, without its query string
+}
+
+// AssetFetchError is returned when a file the document includes cannot be downloaded or read. Err
+// wraps ErrImageUnavailable or ErrExternalPDFUnavailable.
+type AssetFetchError struct {
+	URL       string   // Without its query string, which may hold signed credentials
+	NodePaths []string // Document nodes that include the file
+	Err       error
+}
+
+func (e *AssetFetchError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *AssetFetchError) Unwrap() error {
+	return e.Err
+}
+
+// RenderErrorDetailsOf returns the causes of a render error that can be located, in the order they
+// were joined. It returns nil for errors without any.
+func RenderErrorDetailsOf(err error) []RenderErrorDetail {
+	if errors.Is(err, ErrRenderPostProcessing) {
+		// Imposition compiles a source of its own, which is not the template's
+		return nil
+	}
+	var details []RenderErrorDetail
+	var walk func(err error)
+	walk = func(err error) {
+		switch e := err.(type) {
+		case nil:
+		case *CompileError:
+			detail := RenderErrorDetail{
+				Reason:     RenderReasonTypstCompile,
+				Message:    e.Message,
+				Injectable: e.Injectable,
+				Line:       e.Line,
+				Column:     e.Column,
+			}
+			if e.NodePath != "" {
+				detail.NodePaths = []string{e.NodePath}
+			}
+			details = append(details, detail)
+		case *InjectorError:
+			reason := RenderReasonInjectorFailed
+			if errors.Is(e.Err, context.DeadlineExceeded) {
+				reason = RenderReasonInjectorTimeout
+			}
+			details = append(details, RenderErrorDetail{
+				Reason:     reason,
+				Message:    e.Err.Error(),
+				Injectable: e.Code,
+				NodePaths:  e.NodePaths,
+			})
+		case *MissingInjectablesError:
+			for _, code := range e.MissingCodes {
+				details = append(details, RenderErrorDetail{
+					Reason:     RenderReasonMissingInjectable,
+					Injectable: code,
+					NodePaths:  e.NodePaths[code],
+				})
+			}
+		case *AssetFetchError:
+			reason := RenderReasonExternalPDFFetch
+			if errors.Is(e.Err, ErrImageUnavailable) {
+				reason = RenderReasonImageFetch
+			}
+			details = append(details, RenderErrorDetail{Reason: reason, URL: e.URL, NodePaths: e.NodePaths})
+		case interface{ Unwrap() []error }:
+			for _, inner := range e.Unwrap() {
+				walk(inner)
+			}
+		default:
+			walk(errors.Unwrap(err))
+		}
+	}
+	walk(err)
+	return details
+}
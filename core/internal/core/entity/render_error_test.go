@@ -0,0 +1,113 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestRenderErrorCodeOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want RenderErrorCode
+	}{
+		{"missing injectables", &MissingInjectablesError{MissingCodes: []string{"name"}}, RenderErrorInvalidPayload},
+		{"layout", fmt.Errorf("rendering: %w", ErrLayoutNotAllowed), RenderErrorInvalidPayload},
+		{"injector timeout", &InjectorError{Code: "customer", Err: fmt.Errorf("calling CRM: %w", context.DeadlineExceeded)}, RenderErrorInjectorTimeout},
+		{"injector error", &InjectorError{Code: "customer", Err: errors.New("status 500")}, RenderErrorInjectorFailed},
+		{"provider error", &InjectorError{Err: errors.New("unavailable")}, RenderErrorInjectorFailed},
+		{"external pdf", fmt.Errorf("%w: status 404", ErrExternalPDFUnavailable), RenderErrorAssetFetch},
+		{"image", &AssetFetchError{URL: "https://cdn.example.com/logo.png", Err: ErrImageUnavailable}, RenderErrorAssetFetch},
+		{"compile", fmt.Errorf("failed to generate PDF: %w", &CompileError{Err: errors.New("exit status 1")}), RenderErrorCompile},
+		{"imposition compile", fmt.Errorf("%w: %w", ErrRenderPostProcessing, &CompileError{Err: errors.New("exit status 1")}), RenderErrorPostProcessing},
+		{"busy", ErrRendererBusy, RenderErrorCapacity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := RenderErrorCodeOf(tt.err)
+			if !ok || got != tt.want {
+				t.Errorf("RenderErrorCodeOf() = %q, %v; want %q", got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderErrorCodeOf_NotRenderFailure(t *testing.T) {
+	for _, err := range []error{nil, ErrTemplateNotFound, errors.New("boom")} {
+		if code, ok := RenderErrorCodeOf(err); ok {
+			t.Errorf("RenderErrorCodeOf(%v) = %q, want no code", err, code)
+		}
+	}
+}
+
+func TestRenderErrorCode_Retryable(t *testing.T) {
+	if RenderErrorCompile.Retryable() || RenderErrorInvalidPayload.Retryable() {
+		t.Error("compile and payload errors must not be retryable")
+	}
+	if !RenderErrorInjectorTimeout.Retryable() || !RenderErrorCapacity.Retryable() {
+		t.Error("injector timeouts and capacity errors must be retryable")
+	}
+	if RenderErrorCode("UNKNOWN").Retryable() {
+		t.Error("unknown codes must not be retryable")
+	}
+	if got := len(RenderErrorCodes()); got != 7 {
+		t.Errorf("RenderErrorCodes() has %d codes, want 7", got)
+	}
+}
+
+func TestRenderErrorDetailsOf(t *testing.T) {
+	logo := &AssetFetchError{URL: "https://cdn.example.com/logo.png", NodePaths: []string{"content.content[0]"}, Err: ErrImageUnavailable}
+	annex := &AssetFetchError{URL: "https://cdn.example.com/annex.pdf", Err: ErrExternalPDFUnavailable}
+	tests := []struct {
+		name string
+		err  error
+		want []RenderErrorDetail
+	}{
+		{
+			"compile",
+			fmt.Errorf("failed to generate PDF: %w", &CompileError{
+				Line: 40, Column: 7, Message: "expected expression", NodePath: "content.content[3]", Injectable: "total", Err: errors.New("exit status 1"),
+			}),
+			[]RenderErrorDetail{{
+				Reason: RenderReasonTypstCompile, Message: "expected expression", Injectable: "total",
+				NodePaths: []string{"content.content[3]"}, Line: 40, Column: 7,
+			}},
+		},
+		{
+			"injector timeout",
+			&InjectorError{Code: "customer", NodePaths: []string{"content.content[1]"}, Err: context.DeadlineExceeded},
+			[]RenderErrorDetail{{
+				Reason: RenderReasonInjectorTimeout, Message: context.DeadlineExceeded.Error(), Injectable: "customer",
+				NodePaths: []string{"content.content[1]"},
+			}},
+		},
+		{
+			"missing injectables",
+			&MissingInjectablesError{MissingCodes: []string{"name", "rut"}, NodePaths: map[string][]string{"name": {"content.content[0]"}}},
+			[]RenderErrorDetail{
+				{Reason: RenderReasonMissingInjectable, Injectable: "name", NodePaths: []string{"content.content[0]"}},
+				{Reason: RenderReasonMissingInjectable, Injectable: "rut"},
+			},
+		},
+		{
+			"joined files",
+			fmt.Errorf("%w; %w", logo, annex),
+			[]RenderErrorDetail{
+				{Reason: RenderReasonImageFetch, URL: logo.URL, NodePaths: logo.NodePaths},
+				{Reason: RenderReasonExternalPDFFetch, URL: annex.URL},
+			},
+		},
+		{"imposition compile", fmt.Errorf("%w: %w", ErrRenderPostProcessing, &CompileError{Line: 1, Err: errors.New("exit status 1")}), nil},
+		{"not a render failure", ErrTemplateNotFound, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderErrorDetailsOf(tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RenderErrorDetailsOf() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"math"
+	"time"
+)
+
+// CompileTimeClass buckets how long a template takes to compile, so batch pipelines can budget
+// jobs without tracking raw durations.
+type CompileTimeClass string
+
+const (
+	CompileTimeFast   CompileTimeClass = "FAST"   // Under 1 s
+	CompileTimeNormal CompileTimeClass = "NORMAL" // Under 5 s
+	CompileTimeSlow   CompileTimeClass = "SLOW"   // 5 s or more
+	// CompileTimeRunaway takes at least half the compile timeout: renders risk failing under load.
+	CompileTimeRunaway CompileTimeClass = "RUNAWAY"
+)
+
+// ClassifyCompileTime returns the class of a compile duration. timeout is the compile timeout of
+// the renderer; 0 disables the RUNAWAY class.
+func ClassifyCompileTime(d, timeout time.Duration) CompileTimeClass {
+	switch {
+	case timeout > 0 && d >= timeout/2:
+		return CompileTimeRunaway
+	case d < time.Second:
+		return CompileTimeFast
+	case d < 5*time.Second:
+		return CompileTimeNormal
+	default:
+		return CompileTimeSlow
+	}
+}
+
+// RenderCostModel prices renders in metered units: a fixed part per render plus parts per page
+// and per second of compile time.
+type RenderCostModel struct {
+	PerRender float64
+	PerPage   float64
+	PerSecond float64
+}
+
+// Cost returns the metered cost of a render, rounded to 4 decimals.
+func (m RenderCostModel) Cost(pageCount int, compileTime time.Duration) float64 {
+	cost := m.PerRender + m.PerPage*float64(pageCount) + m.PerSecond*compileTime.Seconds()
+	return math.Round(cost*1e4) / 1e4
+}
+
+// RenderStatistics summarizes the recent renders of a template version on this instance.
+type RenderStatistics struct {
+	Renders        int // Renders summarized, at most the last 100
+	AvgPageCount   float64
+	MaxPageCount   int
+	AvgDurationMs  float64 // Compile time, in milliseconds
+	P95DurationMs  float64
+	LastRenderedAt time.Time
+}
+
+// RenderEstimate is the expected outcome of rendering a template version with a sample payload.
+type RenderEstimate struct {
+	VersionID        string
+	TemplateID       string
+	PageCount        int
+	CompileTimeMs    float64
+	CompileTimeClass CompileTimeClass
+	Cost             float64           // Metered units of the configured RenderCostModel
+	DryRun           bool              // False when the estimate comes from statistics only
+	Statistics       *RenderStatistics // Nil until the version is rendered on this instance
+	Warnings         []string
+}
@@ -0,0 +1,56 @@
+package entity
+
+import "time"
+
+// RenderFailure is the Typst input of a render whose compile failed, kept for a while so support
+// can reproduce the failure without the customer's payload.
+type RenderFailure struct {
+	ID               string
+	TenantCode       string
+	WorkspaceCode    string
+	DocumentTypeCode *string // nil when rendered by version ID
+	VersionID        string
+	TemplateID       string
+	Environment      Environment
+	Error            string
+	Stderr           string
+	Source           string // Generated Typst source; loaded only for download
+	SourceBytes      int    // Size of the generated source, before truncation
+	SourceTruncated  bool   // Source was cut to the configured maximum
+	Assets           []RenderFailureAsset
+	CreatedAt        time.Time
+	ExpiresAt        time.Time
+}
+
+// RenderFailureAsset describes a file the failed source referenced. The files themselves are not kept.
+type RenderFailureAsset struct {
+	Name      string `json:"name"`          // Filename in the source
+	URL       string `json:"url,omitempty"` // Where it was fetched from, without query string; empty for inline data
+	SizeBytes int    `json:"sizeBytes"`
+	SHA256    string `json:"sha256,omitempty"`
+	Missing   bool   `json:"missing,omitempty"` // The file was not on disk when the compile failed
+}
+
+// CompileError is returned by the renderer when Typst rejects a generated source. It carries what
+// the compile received so the failure can be captured, and where the first error is.
+type CompileError struct {
+	Source    string
+	Stderr    string
+	Assets    []RenderFailureAsset
+	Err       error
+	FailureID string // ID of the captured RenderFailure; empty when it was not captured
+
+	Line       int    // Line of the first error in Source, from 1; 0 when Typst reported no position
+	Column     int    // Column of the first error, from 1
+	Message    string // First error Typst reported
+	NodePath   string // Document node whose output holds the error; empty when it cannot be told
+	Injectable string // Code of the injectable the node shows, when it is an injector
+}
+
+func (e *CompileError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CompileError) Unwrap() error {
+	return e.Err
+}
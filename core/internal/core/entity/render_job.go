@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// RenderJobResultTTL is how long a render job and its PDF are kept after submission.
+const RenderJobResultTTL = 24 * time.Hour
+
+// RenderJobStatus is the state of an asynchronous render.
+type RenderJobStatus string
+
+const (
+	RenderJobQueued    RenderJobStatus = "QUEUED"    // Waiting for a worker
+	RenderJobRunning   RenderJobStatus = "RUNNING"   // Claimed by a worker
+	RenderJobSucceeded RenderJobStatus = "SUCCEEDED" // PDF ready for download
+	RenderJobFailed    RenderJobStatus = "FAILED"    // Gave up; see Error
+)
+
+// IsFinished returns true if the job will not change anymore.
+func (s RenderJobStatus) IsFinished() bool {
+	return s == RenderJobSucceeded || s == RenderJobFailed
+}
+
+// RenderJob is a render submitted to run in the background, for documents too large to render
+// within a request. Exactly one of DocumentTypeCode and VersionID is set.
+type RenderJob struct {
+	ID               string           `json:"id"`
+	WorkspaceID      string           `json:"workspaceId"`
+	TenantCode       string           `json:"tenantCode"`
+	WorkspaceCode    string           `json:"workspaceCode"`
+	DocumentTypeCode *string          `json:"documentTypeCode,omitempty"`
+	VersionID        *string          `json:"versionId,omitempty"`
+	Environment      Environment      `json:"environment"`
+	Request          json.RawMessage  `json:"-"` // Injectables, headers and render options, as submitted
+	Status           RenderJobStatus  `json:"status"`
+	Attempts         int              `json:"attempts"`
+	Error            *string          `json:"error,omitempty"`
+	ErrorCode        *RenderErrorCode `json:"errorCode,omitempty"` // Set when the failure was classified
+
+	// Set once the job succeeded
+	Filename  *string         `json:"filename,omitempty"`
+	PDF       []byte          `json:"-"` // Loaded only for download
+	SizeBytes *int            `json:"sizeBytes,omitempty"`
+	PageCount *int            `json:"pageCount,omitempty"`
+	Warnings  []RenderWarning `json:"warnings,omitempty"`
+
+	CreatedAt  time.Time  `json:"createdAt"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+}
+
+// NewRenderJob creates a queued render job of a workspace.
+func NewRenderJob(workspaceID, tenantCode, workspaceCode string, env Environment, request json.RawMessage) *RenderJob {
+	now := time.Now().UTC()
+	return &RenderJob{
+		WorkspaceID:   workspaceID,
+		TenantCode:    tenantCode,
+		WorkspaceCode: workspaceCode,
+		Environment:   env,
+		Request:       request,
+		Status:        RenderJobQueued,
+		CreatedAt:     now,
+		ExpiresAt:     now.Add(RenderJobResultTTL),
+	}
+}
+
+// IsExpired returns true if the job and its result are no longer available.
+func (j *RenderJob) IsExpired() bool {
+	return time.Now().UTC().After(j.ExpiresAt)
+}
+
+// Validate checks if the render job data is valid.
+func (j *RenderJob) Validate() error {
+	if j.WorkspaceID == "" || j.TenantCode == "" || j.WorkspaceCode == "" {
+		return ErrRequiredField
+	}
+	if (j.DocumentTypeCode == nil) == (j.VersionID == nil) {
+		return ErrRequiredField
+	}
+	if !j.Environment.IsDev() && !j.Environment.IsProd() {
+		return ErrRequiredField
+	}
+	return nil
+}
+
+// RenderJobResult is the PDF of a succeeded render job.
+type RenderJobResult struct {
+	JobID     string
+	Filename  string
+	PDF       []byte
+	PageCount int
+	Warnings  []RenderWarning
+}
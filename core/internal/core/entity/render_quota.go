@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// RenderQuotaScope is what a render quota limits: a whole tenant or one of its workspaces.
+type RenderQuotaScope string
+
+const (
+	RenderQuotaScopeTenant    RenderQuotaScope = "TENANT"
+	RenderQuotaScopeWorkspace RenderQuotaScope = "WORKSPACE"
+)
+
+// RenderQuotaLimit names the limit a rejected render exceeded.
+type RenderQuotaLimit string
+
+const (
+	RenderQuotaRendersPerMinute RenderQuotaLimit = "RENDERS_PER_MINUTE"
+	RenderQuotaPagesPerMonth    RenderQuotaLimit = "PAGES_PER_MONTH"
+)
+
+// RenderQuota overrides the default render limits of a tenant, or of a workspace when
+// WorkspaceID is set. A nil limit takes the default; zero means unlimited.
+type RenderQuota struct {
+	TenantID         string
+	TenantCode       string
+	WorkspaceID      *string
+	WorkspaceCode    string // Empty for a tenant quota
+	RendersPerMinute *int
+	PagesPerMonth    *int64
+	UpdatedBy        *string
+	UpdatedAt        time.Time
+}
+
+// Scope returns whether the quota limits a tenant or a workspace.
+func (q *RenderQuota) Scope() RenderQuotaScope {
+	if q.WorkspaceID != nil {
+		return RenderQuotaScopeWorkspace
+	}
+	return RenderQuotaScopeTenant
+}
+
+// Validate checks the limits of the quota.
+func (q *RenderQuota) Validate() error {
+	if q.RendersPerMinute != nil && *q.RendersPerMinute < 0 {
+		return fmt.Errorf("%w: renders per minute cannot be negative", ErrInvalidRenderQuota)
+	}
+	if q.PagesPerMonth != nil && *q.PagesPerMonth < 0 {
+		return fmt.Errorf("%w: pages per month cannot be negative", ErrInvalidRenderQuota)
+	}
+	return nil
+}
+
+// RenderQuotaLimits are the limits in force for a tenant or workspace. Zero means unlimited.
+type RenderQuotaLimits struct {
+	RendersPerMinute int
+	PagesPerMonth    int64
+}
+
+// Apply returns the limits with the overrides of q, when it is not nil.
+func (l RenderQuotaLimits) Apply(q *RenderQuota) RenderQuotaLimits {
+	if q == nil {
+		return l
+	}
+	if q.RendersPerMinute != nil {
+		l.RendersPerMinute = *q.RendersPerMinute
+	}
+	if q.PagesPerMonth != nil {
+		l.PagesPerMonth = *q.PagesPerMonth
+	}
+	return l
+}
+
+// RenderUsage counts the renders of a workspace in a calendar month (UTC).
+type RenderUsage struct {
+	TenantCode    string
+	WorkspaceCode string
+	Month         time.Time // First day of the month
+	Renders       int64
+	Pages         int64
+}
+
+// RenderQuotaStatus is a quota with the limits it puts in force and the usage of the month.
+type RenderQuotaStatus struct {
+	Scope          RenderQuotaScope
+	TenantID       string
+	WorkspaceID    *string
+	Override       *RenderQuota // nil when the defaults apply
+	Limits         RenderQuotaLimits
+	Month          time.Time
+	RendersInMonth int64
+	PagesInMonth   int64
+}
+
+// MonthStart returns the first instant of the UTC month of t.
+func MonthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// QuotaExceededError rejects a render that would exceed a render quota.
+type QuotaExceededError struct {
+	Scope      RenderQuotaScope
+	Limit      RenderQuotaLimit
+	Max        int64
+	RetryAfter time.Duration // When the render may be accepted again
+}
+
+func (e *QuotaExceededError) Error() string {
+	scope := "tenant"
+	if e.Scope == RenderQuotaScopeWorkspace {
+		scope = "workspace"
+	}
+	switch e.Limit {
+	case RenderQuotaPagesPerMonth:
+		return fmt.Sprintf("%s: %s page quota of %d pages per month reached", ErrQuotaExceeded, scope, e.Max)
+	default:
+		return fmt.Sprintf("%s: %s limit of %d renders per minute reached", ErrQuotaExceeded, scope, e.Max)
+	}
+}
+
+func (e *QuotaExceededError) Unwrap() error {
+	return ErrQuotaExceeded
+}
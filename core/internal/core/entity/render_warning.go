@@ -0,0 +1,47 @@
+package entity
+
+// RenderWarningCode identifies the kind of a render warning.
+type RenderWarningCode string
+
+const (
+	RenderWarningCompiler      RenderWarningCode = "COMPILER"       // Reported by the Typst compiler
+	RenderWarningMissingGlyphs RenderWarningCode = "MISSING_GLYPHS" // Characters of a script no configured font covers
+	RenderWarningHTMLOmitted   RenderWarningCode = "HTML_OMITTED"   // Content the HTML output cannot show, such as page numbers
+	RenderWarningDocxOmitted   RenderWarningCode = "DOCX_OMITTED"   // Content the DOCX output leaves out, such as security patterns
+	RenderWarningUnknownNode   RenderWarningCode = "UNKNOWN_NODE"   // A node of an unknown type was flattened or shown as a placeholder
+
+	// Degradations: failures a degraded render left out instead of failing
+	RenderWarningDegradedInjector    RenderWarningCode = "DEGRADED_INJECTOR"     // An injector failed; its value is empty or its default
+	RenderWarningDegradedImage       RenderWarningCode = "DEGRADED_IMAGE"        // An image could not be downloaded; a placeholder is shown
+	RenderWarningDegradedExternalPDF RenderWarningCode = "DEGRADED_EXTERNAL_PDF" // An external PDF could not be included; its pages are left out
+)
+
+// IsDegradation returns true if the warning records a failure a degraded render left out.
+func (c RenderWarningCode) IsDegradation() bool {
+	switch c {
+	case RenderWarningDegradedInjector, RenderWarningDegradedImage, RenderWarningDegradedExternalPDF:
+		return true
+	}
+	return false
+}
+
+// RenderWarning is a problem found while rendering that did not stop the render.
+// It travels in RenderCompleted, so it keeps the JSON names of the event.
+type RenderWarning struct {
+	Code       RenderWarningCode `json:"code"`
+	Message    string            `json:"message"`
+	Script     string            `json:"script,omitempty"`     // Unicode script of the characters, for MISSING_GLYPHS
+	Characters string            `json:"characters,omitempty"` // Sample of the characters, for MISSING_GLYPHS
+	Source     string            `json:"source,omitempty"`     // Injector code or file URL without its query, for DEGRADED_*; node path, for UNKNOWN_NODE
+}
+
+// CountDegradations returns the number of warnings that record a degradation.
+func CountDegradations(warnings []RenderWarning) int {
+	n := 0
+	for _, w := range warnings {
+		if w.Code.IsDegradation() {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func failedScheduledVersion(status VersionStatus) *TemplateVersion {
+	now := time.Now().UTC()
+	reason := "content validation failed"
+	past := now.Add(-time.Hour)
+	return &TemplateVersion{
+		Status:                status,
+		ScheduledPublishAt:    &past,
+		ScheduledArchiveAt:    &past,
+		ScheduleFailedAt:      &now,
+		ScheduleFailureReason: &reason,
+	}
+}
+
+func TestTemplateVersion_ScheduleFailureClearedByTransitions(t *testing.T) {
+	future := time.Now().UTC().Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		status VersionStatus
+		apply  func(tv *TemplateVersion) error
+	}{
+		{"reschedule publication", VersionStatusScheduled, func(tv *TemplateVersion) error { return tv.SchedulePublish(future) }},
+		{"reschedule archival", VersionStatusPublished, func(tv *TemplateVersion) error { return tv.ScheduleArchive(future) }},
+		{"cancel schedule", VersionStatusScheduled, func(tv *TemplateVersion) error { return tv.CancelSchedule() }},
+		{"publish", VersionStatusScheduled, func(tv *TemplateVersion) error { tv.Publish(""); return nil }},
+		{"archive", VersionStatusPublished, func(tv *TemplateVersion) error { tv.Archive(""); return nil }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tv := failedScheduledVersion(tt.status)
+			require.True(t, tv.IsScheduleFailed())
+
+			require.NoError(t, tt.apply(tv))
+			assert.False(t, tv.IsScheduleFailed())
+			assert.Nil(t, tv.ScheduleFailureReason)
+		})
+	}
+}
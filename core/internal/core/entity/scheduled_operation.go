@@ -0,0 +1,79 @@
+package entity
+
+import "time"
+
+// ScheduledOperation identifies a time-triggered version lifecycle change.
+type ScheduledOperation string
+
+const (
+	ScheduledOperationPublish ScheduledOperation = "PUBLISH"
+	ScheduledOperationArchive ScheduledOperation = "ARCHIVE"
+)
+
+// IsValid checks if the scheduled operation is valid.
+func (o ScheduledOperation) IsValid() bool {
+	return o == ScheduledOperationPublish || o == ScheduledOperationArchive
+}
+
+// ScheduledRunStatus is the outcome of a scheduled operation run.
+type ScheduledRunStatus string
+
+const (
+	ScheduledRunSucceeded ScheduledRunStatus = "SUCCEEDED"
+	ScheduledRunFailed    ScheduledRunStatus = "FAILED"
+)
+
+// ScheduledItem is a pending scheduled publication or archival.
+type ScheduledItem struct {
+	VersionID     string             `json:"versionId"`
+	VersionName   string             `json:"versionName"`
+	VersionNumber int                `json:"versionNumber"`
+	TemplateID    string             `json:"templateId"`
+	TemplateTitle string             `json:"templateTitle"`
+	Operation     ScheduledOperation `json:"operation"`
+	ScheduledFor  time.Time          `json:"scheduledFor"`
+	FailedAt      *time.Time         `json:"failedAt,omitempty"` // Set when the scheduler gave up; only a retry or reschedule runs it
+	FailureReason *string            `json:"failureReason,omitempty"`
+}
+
+// ScheduledRun records one execution of a scheduled operation, by the scheduler or a manual retry.
+type ScheduledRun struct {
+	ID            string             `json:"id"`
+	VersionID     string             `json:"versionId"`
+	VersionName   string             `json:"versionName,omitempty"`
+	TemplateID    string             `json:"templateId,omitempty"`
+	TemplateTitle string             `json:"templateTitle,omitempty"`
+	Operation     ScheduledOperation `json:"operation"`
+	ScheduledFor  *time.Time         `json:"scheduledFor,omitempty"`
+	Status        ScheduledRunStatus `json:"status"`
+	Error         *string            `json:"error,omitempty"`
+	TriggeredBy   *string            `json:"triggeredBy,omitempty"` // nil = scheduler
+	RanAt         time.Time          `json:"ranAt"`
+}
+
+// NewScheduledRun creates a run record from the outcome of an operation.
+// A nil runErr means the operation succeeded.
+func NewScheduledRun(versionID string, operation ScheduledOperation, scheduledFor *time.Time, triggeredBy *string, runErr error) *ScheduledRun {
+	run := &ScheduledRun{
+		VersionID:    versionID,
+		Operation:    operation,
+		ScheduledFor: scheduledFor,
+		Status:       ScheduledRunSucceeded,
+		TriggeredBy:  triggeredBy,
+		RanAt:        time.Now().UTC(),
+	}
+	if runErr != nil {
+		msg := runErr.Error()
+		run.Status = ScheduledRunFailed
+		run.Error = &msg
+	}
+	return run
+}
+
+// WorkspaceSchedule is the scheduler view of a workspace.
+type WorkspaceSchedule struct {
+	Upcoming   []*ScheduledItem `json:"upcoming"`
+	RecentRuns []*ScheduledRun  `json:"recentRuns"`
+	Failures   []*ScheduledRun  `json:"failures"` // latest run failed and the operation is still pending
+	Timezone   string           `json:"timezone"` // IANA timezone of the workspace the times are in
+}
@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Scripted injector limits and defaults.
+const (
+	ScriptedInjectorDefaultTimeoutMs = 2000
+	ScriptedInjectorMaxTimeoutMs     = 10000
+	ScriptedInjectorMaxDependencies  = 20
+)
+
+var scriptedInjectorCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,99}$`)
+
+// ScriptedInjector is an injector written in Starlark by a system admin and run in a sandbox, so
+// simple transformations need no Go deploy. The script defines resolve(ctx) returning the value.
+type ScriptedInjector struct {
+	Code         string             `json:"code"`
+	Label        map[string]string  `json:"label"`       // By locale
+	Description  map[string]string  `json:"description"` // By locale
+	DataType     InjectableDataType `json:"dataType"`    // TEXT, NUMBER, BOOLEAN, DATE or IMAGE
+	Source       string             `json:"source"`
+	Dependencies []string           `json:"dependencies"` // Codes resolved before the script runs
+	IsCritical   bool               `json:"isCritical"`
+	TimeoutMs    int                `json:"timeoutMs"`
+	CreatedBy    *string            `json:"createdBy,omitempty"`
+	UpdatedBy    *string            `json:"updatedBy,omitempty"`
+	CreatedAt    time.Time          `json:"createdAt"`
+	UpdatedAt    time.Time          `json:"updatedAt"`
+}
+
+// Validate checks the fields of the injector. A zero timeout takes the default.
+func (s *ScriptedInjector) Validate() error {
+	if !scriptedInjectorCodePattern.MatchString(s.Code) {
+		return fmt.Errorf("%w: code must be 2 to 100 lowercase letters, digits and underscores, starting with a letter", ErrInvalidScriptedInjector)
+	}
+	if s.Label["en"] == "" {
+		return fmt.Errorf("%w: an English label is required", ErrInvalidScriptedInjector)
+	}
+	switch s.DataType {
+	case InjectableDataTypeText, InjectableDataTypeNumber, InjectableDataTypeBoolean, InjectableDataTypeDate, InjectableDataTypeImage:
+	default:
+		return fmt.Errorf("%w: data type must be TEXT, NUMBER, BOOLEAN, DATE or IMAGE", ErrInvalidScriptedInjector)
+	}
+	if s.Source == "" {
+		return fmt.Errorf("%w: source is required", ErrInvalidScriptedInjector)
+	}
+	if len(s.Dependencies) > ScriptedInjectorMaxDependencies {
+		return fmt.Errorf("%w: at most %d dependencies", ErrInvalidScriptedInjector, ScriptedInjectorMaxDependencies)
+	}
+	for _, dep := range s.Dependencies {
+		if !scriptedInjectorCodePattern.MatchString(dep) {
+			return fmt.Errorf("%w: invalid dependency %q", ErrInvalidScriptedInjector, dep)
+		}
+	}
+	if s.TimeoutMs == 0 {
+		s.TimeoutMs = ScriptedInjectorDefaultTimeoutMs
+	}
+	if s.TimeoutMs < 1 || s.TimeoutMs > ScriptedInjectorMaxTimeoutMs {
+		return fmt.Errorf("%w: timeout must be 1 to %d ms", ErrInvalidScriptedInjector, ScriptedInjectorMaxTimeoutMs)
+	}
+	return nil
+}
+
+// ValueType returns the type of the values the script produces.
+func (s *ScriptedInjector) ValueType() ValueType {
+	switch s.DataType {
+	case InjectableDataTypeNumber:
+		return ValueTypeNumber
+	case InjectableDataTypeBoolean:
+		return ValueTypeBool
+	case InjectableDataTypeDate:
+		return ValueTypeTime
+	case InjectableDataTypeImage:
+		return ValueTypeImage
+	default:
+		return ValueTypeString
+	}
+}
+
+// ScriptInput is what a script sees as ctx.
+type ScriptInput struct {
+	Payload        any            // Request payload, as decoded JSON
+	Resolved       map[string]any // Values of the dependencies
+	TenantCode     string
+	WorkspaceCode  string
+	Environment    string
+	SelectedFormat string            // Format selected in the template, if any
+	Headers        map[string]string // Request headers
+}
+
+// ScriptRun is the outcome of running a script.
+type ScriptRun struct {
+	Value  any      // Value returned by resolve, converted to Go
+	Output []string // Lines printed by the script
+	Steps  uint64   // Execution steps used
+}
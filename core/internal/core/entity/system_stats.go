@@ -0,0 +1,73 @@
+package entity
+
+import "time"
+
+// SystemStats is the platform-wide overview shown to platform admins.
+type SystemStats struct {
+	Counts      EntityCounts
+	RenderDays  []*RenderDayStats // One per day of the window, oldest first; days without renders are zero
+	Renders     int64             // Renders in the window
+	Failures    int64             // Failed renders in the window
+	ErrorRate   float64           // Failures / Renders in the window; 0 without renders
+	Queues      QueueHealth
+	Storage     StorageUsage
+	GeneratedAt time.Time
+}
+
+// EntityCounts counts the main platform entities. The system tenant and its workspace are excluded.
+type EntityCounts struct {
+	Tenants           int64
+	ActiveTenants     int64
+	Workspaces        int64
+	SandboxWorkspaces int64
+	Templates         int64
+	PublishedVersions int64
+	Users             int64
+}
+
+// RenderDayStats counts the renders of the render API on one UTC day, on every instance.
+type RenderDayStats struct {
+	Day        time.Time
+	Renders    int64 // Successful and failed
+	Failures   int64
+	Pages      int64 // Pages of successful renders
+	DurationMs int64 // Compile time of successful renders
+}
+
+// ErrorRate returns Failures / Renders, or 0 without renders.
+func (d *RenderDayStats) ErrorRate() float64 {
+	if d.Renders == 0 {
+		return 0
+	}
+	return float64(d.Failures) / float64(d.Renders)
+}
+
+// QueueHealth summarizes the backlogs of the background queues and the render queue.
+type QueueHealth struct {
+	OutboxPending         int64
+	OutboxFailed          int64 // Events that exhausted their attempts
+	OutboxOldestPendingAt *time.Time
+	DocumentIndexPending  int64          // Hosted documents waiting for the indexer
+	ScheduledVersions     int64          // Versions waiting for a scheduled publication
+	Render                RenderCapacity // Render queue of the instance that served the request
+}
+
+// StorageUsage is the content stored in the database: asset library files and hosted PDFs.
+type StorageUsage struct {
+	AssetBytes          int64
+	HostedDocumentBytes int64
+	Days                []*StorageDayUsage // One per day of the window, oldest first
+}
+
+// TotalBytes returns the bytes of every stored file.
+func (u StorageUsage) TotalBytes() int64 {
+	return u.AssetBytes + u.HostedDocumentBytes
+}
+
+// StorageDayUsage is the storage uploaded on one UTC day and the running total at its end.
+// Totals only count content still stored: deleted and expired files are not in them.
+type StorageDayUsage struct {
+	Day        time.Time
+	AddedBytes int64
+	TotalBytes int64
+}
@@ -12,6 +12,7 @@ type Template struct {
 	DocumentTypeID  *string    `json:"documentTypeId,omitempty"`
 	Title           string     `json:"title"`
 	IsPublicLibrary bool       `json:"isPublicLibrary"`
+	Revision        int        `json:"revision"` // Incremented on every update, checked for optimistic concurrency
 	CreatedAt       time.Time  `json:"createdAt"`
 	UpdatedAt       *time.Time `json:"updatedAt,omitempty"`
 }
@@ -62,6 +63,7 @@ type TemplateListItem struct {
 	VersionCount           int        `json:"versionCount"`
 	ScheduledVersionCount  int        `json:"scheduledVersionCount"`
 	PublishedVersionNumber *int       `json:"publishedVersionNumber,omitempty"`
+	Revision               int        `json:"revision"`
 	CreatedAt              time.Time  `json:"createdAt"`
 	UpdatedAt              *time.Time `json:"updatedAt,omitempty"`
 }
@@ -0,0 +1,73 @@
+package entity
+
+import "time"
+
+// TemplateBundleFormatVersion is the layout version written to bundle manifests.
+const TemplateBundleFormatVersion = 1
+
+// TemplateBundleMaxSize is the largest bundle accepted for import.
+const TemplateBundleMaxSize = 50 << 20
+
+// TemplateBundleManifest describes the content of a template bundle: a zip archive with the
+// template, all its versions, the injectables they reference and the library assets they use,
+// for moving a template between workspaces or environments.
+type TemplateBundleManifest struct {
+	FormatVersion int                        `json:"formatVersion"`
+	ExportedAt    time.Time                  `json:"exportedAt"`
+	Template      TemplateBundleTemplate     `json:"template"`
+	Versions      []TemplateBundleVersion    `json:"versions"` // Oldest first
+	Injectables   []TemplateBundleInjectable `json:"injectables"`
+	Assets        []TemplateBundleAsset      `json:"assets"`
+}
+
+// TemplateBundleTemplate is the template metadata carried by a bundle.
+type TemplateBundleTemplate struct {
+	Title           string `json:"title"`
+	IsPublicLibrary bool   `json:"isPublicLibrary"`
+}
+
+// TemplateBundleVersion is a version of the bundled template. Its content is stored in File.
+type TemplateBundleVersion struct {
+	VersionNumber int           `json:"versionNumber"`
+	Name          string        `json:"name"`
+	Description   *string       `json:"description,omitempty"`
+	Status        VersionStatus `json:"status"`
+	File          string        `json:"file"`
+}
+
+// TemplateBundleInjectable is the definition of an injectable referenced by the bundled versions,
+// with its i18n labels. Workspace injectables are recreated on import; the others are provided
+// by the target server and only checked.
+type TemplateBundleInjectable struct {
+	Key          string             `json:"key"`
+	Label        string             `json:"label"`
+	Description  string             `json:"description,omitempty"`
+	Labels       map[string]string  `json:"labels,omitempty"`
+	Descriptions map[string]string  `json:"descriptions,omitempty"`
+	DataType     InjectableDataType `json:"dataType"`
+	DefaultValue *string            `json:"defaultValue,omitempty"`
+	Metadata     map[string]any     `json:"metadata,omitempty"`
+	Formula      *string            `json:"formula,omitempty"` // Set for computed workspace injectables
+	Workspace    bool               `json:"workspace"`         // Defined in the source workspace
+}
+
+// TemplateBundleAsset is a library asset referenced by the bundled versions (asset://<ID>).
+// Its current content is stored in File.
+type TemplateBundleAsset struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Kind        AssetKind `json:"kind"`
+	Tags        []string  `json:"tags,omitempty"`
+	ContentType string    `json:"contentType"`
+	SHA256      string    `json:"sha256"`
+	File        string    `json:"file"`
+}
+
+// TemplateBundleReport lists what importing a bundle created, reused or could not bring over.
+type TemplateBundleReport struct {
+	CreatedInjectables []string // Workspace injectables created in the target workspace
+	MissingInjectables []string // Injectables the target server does not provide; renders leave them empty
+	CreatedAssets      int
+	ReusedAssets       int      // Assets whose content was already in the target library
+	StorageReferences  []string // storage:// URLs left as they are: gallery files are not bundled
+}
@@ -0,0 +1,29 @@
+package entity
+
+// TemplateImportMaxBytes is the largest HTML or Markdown template accepted for import.
+const TemplateImportMaxBytes = 2 << 20 // 2 MiB
+
+// DocxImportMaxBytes is the largest DOCX file accepted for import. It is larger than the text
+// formats because Word documents embed their images.
+const DocxImportMaxBytes = 10 << 20 // 10 MiB
+
+// TemplateImportReport describes how an HTML, Markdown or DOCX template was converted into a portable document,
+// so the author knows what to review before publishing.
+type TemplateImportReport struct {
+	// MappedPlaceholders maps each {{placeholder}} to the injectable key it was bound to.
+	MappedPlaceholders map[string]string `json:"mappedPlaceholders"`
+
+	// UnmappedPlaceholders are placeholders without a matching injectable. They are kept as literal text.
+	UnmappedPlaceholders []string `json:"unmappedPlaceholders"`
+
+	// UnsupportedElements are HTML tags, Markdown constructs or Word elements that were dropped or flattened into their text.
+	UnsupportedElements []string `json:"unsupportedElements"`
+
+	// Warnings describe other lossy conversions, such as Handlebars block helpers.
+	Warnings []string `json:"warnings"`
+}
+
+// Lossless reports whether the template converted without anything to review.
+func (r *TemplateImportReport) Lossless() bool {
+	return len(r.UnmappedPlaceholders) == 0 && len(r.UnsupportedElements) == 0 && len(r.Warnings) == 0
+}
@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// TemplateLibraryLink links a template installed from the tenant library to the library template it
+// was copied from. The base is the upstream version the template last took: the common ancestor of
+// the next three-way merge between the local and upstream changes.
+type TemplateLibraryLink struct {
+	TemplateID        string
+	SourceTemplateID  string
+	BaseVersionID     *string         // Nil once the upstream version is deleted
+	BaseVersionNumber int             // Kept when the upstream version is deleted
+	BaseContent       json.RawMessage // Content of the base version, with its assets copied
+	// AssetMap maps the upstream asset IDs referenced by the content to their copies in the
+	// workspace of the installed template.
+	AssetMap    map[string]string
+	InstalledBy *string
+	InstalledAt time.Time
+	SyncedAt    time.Time // Last install or pull
+}
+
+// LibraryMergeStrategy is how a pull resolves the parts of a template changed both locally and
+// upstream.
+type LibraryMergeStrategy string
+
+const (
+	// LibraryMergeKeepLocal keeps the local side of conflicting changes.
+	LibraryMergeKeepLocal LibraryMergeStrategy = "KEEP_LOCAL"
+	// LibraryMergeTakeUpstream takes the upstream side of conflicting changes.
+	LibraryMergeTakeUpstream LibraryMergeStrategy = "TAKE_UPSTREAM"
+)
+
+// IsValid returns true if the strategy is known. The empty strategy is valid: it fails on conflicts.
+func (s LibraryMergeStrategy) IsValid() bool {
+	switch s {
+	case "", LibraryMergeKeepLocal, LibraryMergeTakeUpstream:
+		return true
+	}
+	return false
+}
+
+// LibraryMergeConflict is a part of a template changed differently locally and upstream since the
+// base version.
+type LibraryMergeConflict struct {
+	// Path locates the part in the base content, e.g. "content[3]" for the blocks from the fourth
+	// block on, or "pageConfig".
+	Path     string          `json:"path"`
+	Base     json.RawMessage `json:"base"`     // Blocks are JSON arrays; absent parts are null
+	Local    json.RawMessage `json:"local"`    // Blocks are JSON arrays; absent parts are null
+	Upstream json.RawMessage `json:"upstream"` // Blocks are JSON arrays; absent parts are null
+}
+
+// LibraryUpdatePreview is what pulling the latest upstream version into an installed template
+// would change.
+type LibraryUpdatePreview struct {
+	TemplateID        string `json:"templateId"`
+	SourceTemplateID  string `json:"sourceTemplateId"`
+	BaseVersionNumber int    `json:"baseVersionNumber"`
+	// UpToDate is true when the base is the published upstream version; there is nothing to pull.
+	UpToDate              bool   `json:"upToDate"`
+	UpstreamVersionID     string `json:"upstreamVersionId"`
+	UpstreamVersionNumber int    `json:"upstreamVersionNumber"`
+	LocalVersionID        string `json:"localVersionId"` // Latest non-archived version of the template
+	LocalVersionNumber    int    `json:"localVersionNumber"`
+	// UpstreamChanges and LocalChanges are the changes of each side since the base version.
+	UpstreamChanges *VersionDiff           `json:"upstreamChanges"`
+	LocalChanges    *VersionDiff           `json:"localChanges"`
+	Conflicts       []LibraryMergeConflict `json:"conflicts"`
+	// Merged is the content of the draft a pull creates, resolving conflicts with the KEEP_LOCAL
+	// strategy.
+	Merged json.RawMessage `json:"merged"`
+}
@@ -0,0 +1,156 @@
+package entity
+
+import "time"
+
+// Template SLA limits and defaults.
+const (
+	TemplateSLAMinWindowMinutes     = 5
+	TemplateSLAMaxWindowMinutes     = 1440
+	TemplateSLAMaxMinRenders        = 100000
+	TemplateSLADefaultWindowMinutes = 60
+	TemplateSLADefaultMinRenders    = 10
+)
+
+// TemplateSLAMetric identifies an expectation of a template SLA.
+type TemplateSLAMetric string
+
+const (
+	TemplateSLARenderTime  TemplateSLAMetric = "RENDER_TIME"  // Average render time of the successful renders, in ms
+	TemplateSLAFailureRate TemplateSLAMetric = "FAILURE_RATE" // Share of the renders that failed
+)
+
+// TemplateSLA is what the owners of a template expect from its renders through the render API,
+// checked over a rolling window. The user who set it is alerted when the window breaches it
+// and again when it recovers.
+type TemplateSLA struct {
+	TemplateID     string     `json:"templateId"`
+	MaxRenderMs    *int       `json:"maxRenderMs,omitempty"`    // Max average render time of the successful renders
+	MaxFailureRate *float64   `json:"maxFailureRate,omitempty"` // Max share of failed renders, from 0 to 1
+	WindowMinutes  int        `json:"windowMinutes"`
+	MinRenders     int        `json:"minRenders"`           // Renders the window needs before it is checked
+	BreachedAt     *time.Time `json:"breachedAt,omitempty"` // Set while the SLA is breached
+	UpdatedBy      *string    `json:"updatedBy,omitempty"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+// NewTemplateSLA creates an SLA for a template. A zero window or min renders takes the default.
+func NewTemplateSLA(templateID string, maxRenderMs *int, maxFailureRate *float64, windowMinutes, minRenders int, updatedBy *string) *TemplateSLA {
+	if windowMinutes == 0 {
+		windowMinutes = TemplateSLADefaultWindowMinutes
+	}
+	if minRenders == 0 {
+		minRenders = TemplateSLADefaultMinRenders
+	}
+	return &TemplateSLA{
+		TemplateID:     templateID,
+		MaxRenderMs:    maxRenderMs,
+		MaxFailureRate: maxFailureRate,
+		WindowMinutes:  windowMinutes,
+		MinRenders:     minRenders,
+		UpdatedBy:      updatedBy,
+		UpdatedAt:      time.Now().UTC(),
+	}
+}
+
+// Validate checks that the SLA sets at least one expectation and that its values are in range.
+func (s *TemplateSLA) Validate() error {
+	if s.TemplateID == "" {
+		return ErrRequiredField
+	}
+	if s.MaxRenderMs == nil && s.MaxFailureRate == nil {
+		return ErrInvalidTemplateSLA
+	}
+	if s.MaxRenderMs != nil && *s.MaxRenderMs <= 0 {
+		return ErrInvalidTemplateSLA
+	}
+	if s.MaxFailureRate != nil && (*s.MaxFailureRate < 0 || *s.MaxFailureRate > 1) {
+		return ErrInvalidTemplateSLA
+	}
+	if s.WindowMinutes < TemplateSLAMinWindowMinutes || s.WindowMinutes > TemplateSLAMaxWindowMinutes {
+		return ErrInvalidTemplateSLA
+	}
+	if s.MinRenders < 1 || s.MinRenders > TemplateSLAMaxMinRenders {
+		return ErrInvalidTemplateSLA
+	}
+	return nil
+}
+
+// Window returns the duration of the rolling window.
+func (s *TemplateSLA) Window() time.Duration {
+	return time.Duration(s.WindowMinutes) * time.Minute
+}
+
+// Check returns the expectations the renders of window do not meet. judged is false when the
+// window has fewer renders than MinRenders, too few to tell.
+func (s *TemplateSLA) Check(window TemplateRenderWindow) (violations []TemplateSLAViolation, judged bool) {
+	if window.Renders < int64(s.MinRenders) {
+		return nil, false
+	}
+	if s.MaxRenderMs != nil && window.Renders > window.Failures {
+		if avg := window.AvgRenderMs(); avg > float64(*s.MaxRenderMs) {
+			violations = append(violations, TemplateSLAViolation{
+				Metric:    TemplateSLARenderTime,
+				Threshold: float64(*s.MaxRenderMs),
+				Actual:    avg,
+			})
+		}
+	}
+	if s.MaxFailureRate != nil {
+		if rate := window.FailureRate(); rate > *s.MaxFailureRate {
+			violations = append(violations, TemplateSLAViolation{
+				Metric:    TemplateSLAFailureRate,
+				Threshold: *s.MaxFailureRate,
+				Actual:    rate,
+			})
+		}
+	}
+	return violations, true
+}
+
+// TemplateSLAViolation is an expectation of a template SLA that a render window does not meet.
+type TemplateSLAViolation struct {
+	Metric    TemplateSLAMetric `json:"metric"`
+	Threshold float64           `json:"threshold"`
+	Actual    float64           `json:"actual"`
+}
+
+// TemplateRenderWindow counts the renders of a template over the window of its SLA.
+type TemplateRenderWindow struct {
+	Renders    int64 `json:"renders"`
+	Failures   int64 `json:"failures"`
+	DurationMs int64 `json:"durationMs"` // Total render time of the successful renders
+}
+
+// AvgRenderMs returns the average render time of the successful renders, 0 when there are none.
+func (w TemplateRenderWindow) AvgRenderMs() float64 {
+	succeeded := w.Renders - w.Failures
+	if succeeded <= 0 {
+		return 0
+	}
+	return float64(w.DurationMs) / float64(succeeded)
+}
+
+// FailureRate returns the share of the renders that failed, 0 when there are none.
+func (w TemplateRenderWindow) FailureRate() float64 {
+	if w.Renders == 0 {
+		return 0
+	}
+	return float64(w.Failures) / float64(w.Renders)
+}
+
+// TemplateSLAStatus is an SLA with the renders of its current window.
+type TemplateSLAStatus struct {
+	SLA           *TemplateSLA
+	WorkspaceID   string
+	TemplateTitle string
+	Window        TemplateRenderWindow
+}
+
+// TemplateRenderMinute counts the renders of a template during one minute.
+type TemplateRenderMinute struct {
+	TemplateID string
+	Minute     time.Time
+	Renders    int64
+	Failures   int64
+	DurationMs int64 // Total render time of the successful renders
+}
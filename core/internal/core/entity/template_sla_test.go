@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTemplateSLA_Validate(t *testing.T) {
+	ms := func(v int) *int { return &v }
+	rate := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name    string
+		sla     *TemplateSLA
+		wantErr error
+	}{
+		{"render time", NewTemplateSLA("t-1", ms(3000), nil, 0, 0, nil), nil},
+		{"failure rate", NewTemplateSLA("t-1", nil, rate(0.05), 30, 5, nil), nil},
+		{"no expectation", NewTemplateSLA("t-1", nil, nil, 0, 0, nil), ErrInvalidTemplateSLA},
+		{"zero render time", NewTemplateSLA("t-1", ms(0), nil, 0, 0, nil), ErrInvalidTemplateSLA},
+		{"rate above one", NewTemplateSLA("t-1", nil, rate(1.5), 0, 0, nil), ErrInvalidTemplateSLA},
+		{"window too short", NewTemplateSLA("t-1", ms(3000), nil, 1, 0, nil), ErrInvalidTemplateSLA},
+		{"window too long", NewTemplateSLA("t-1", ms(3000), nil, 1441, 0, nil), ErrInvalidTemplateSLA},
+		{"negative min renders", NewTemplateSLA("t-1", ms(3000), nil, 0, -1, nil), ErrInvalidTemplateSLA},
+		{"no template", NewTemplateSLA("", ms(3000), nil, 0, 0, nil), ErrRequiredField},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.sla.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewTemplateSLA_Defaults(t *testing.T) {
+	sla := NewTemplateSLA("t-1", nil, nil, 0, 0, nil)
+	if sla.WindowMinutes != TemplateSLADefaultWindowMinutes || sla.MinRenders != TemplateSLADefaultMinRenders {
+		t.Errorf("window = %d, min renders = %d, want the defaults", sla.WindowMinutes, sla.MinRenders)
+	}
+}
+
+func TestTemplateSLA_Check(t *testing.T) {
+	maxMs, maxRate := 1000, 0.1
+	sla := NewTemplateSLA("t-1", &maxMs, &maxRate, 60, 10, nil)
+
+	if _, judged := sla.Check(TemplateRenderWindow{Renders: 9, Failures: 9}); judged {
+		t.Error("a window below min renders should not be judged")
+	}
+
+	violations, judged := sla.Check(TemplateRenderWindow{Renders: 10, Failures: 1, DurationMs: 9 * 900})
+	if !judged || len(violations) != 0 {
+		t.Errorf("Check() = %v, %v, want no violations", violations, judged)
+	}
+
+	violations, _ = sla.Check(TemplateRenderWindow{Renders: 10, Failures: 2, DurationMs: 8 * 1500})
+	if len(violations) != 2 {
+		t.Fatalf("Check() = %v, want 2 violations", violations)
+	}
+	if v := violations[0]; v.Metric != TemplateSLARenderTime || v.Actual != 1500 {
+		t.Errorf("violations[0] = %+v, want an average render time of 1500", v)
+	}
+	if v := violations[1]; v.Metric != TemplateSLAFailureRate || v.Actual != 0.2 {
+		t.Errorf("violations[1] = %+v, want a failure rate of 0.2", v)
+	}
+}
+
+func TestTemplateSLA_CheckAllFailedIgnoresRenderTime(t *testing.T) {
+	maxMs := 1000
+	sla := NewTemplateSLA("t-1", &maxMs, nil, 60, 1, nil)
+
+	violations, judged := sla.Check(TemplateRenderWindow{Renders: 5, Failures: 5})
+	if !judged || len(violations) != 0 {
+		t.Errorf("Check() = %v, %v, want judged without violations", violations, judged)
+	}
+}
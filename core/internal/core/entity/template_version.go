@@ -7,22 +7,28 @@ import (
 
 // TemplateVersion represents a specific version of a template with content and lifecycle management.
 type TemplateVersion struct {
-	ID                 string          `json:"id"`
-	TemplateID         string          `json:"templateId"`
-	VersionNumber      int             `json:"versionNumber"`
-	Name               string          `json:"name"`
-	Description        *string         `json:"description,omitempty"`
-	ContentStructure   json.RawMessage `json:"contentStructure,omitempty"`
-	Status             VersionStatus   `json:"status"`
-	ScheduledPublishAt *time.Time      `json:"scheduledPublishAt,omitempty"`
-	ScheduledArchiveAt *time.Time      `json:"scheduledArchiveAt,omitempty"`
-	PublishedAt        *time.Time      `json:"publishedAt,omitempty"`
-	ArchivedAt         *time.Time      `json:"archivedAt,omitempty"`
-	PublishedBy        *string         `json:"publishedBy,omitempty"`
-	ArchivedBy         *string         `json:"archivedBy,omitempty"`
-	CreatedBy          *string         `json:"createdBy,omitempty"`
-	CreatedAt          time.Time       `json:"createdAt"`
-	UpdatedAt          *time.Time      `json:"updatedAt,omitempty"`
+	ID                    string          `json:"id"`
+	TemplateID            string          `json:"templateId"`
+	VersionNumber         int             `json:"versionNumber"`
+	Name                  string          `json:"name"`
+	Description           *string         `json:"description,omitempty"`
+	ContentStructure      json.RawMessage `json:"contentStructure,omitempty"`
+	Status                VersionStatus   `json:"status"`
+	ScheduledPublishAt    *time.Time      `json:"scheduledPublishAt,omitempty"`
+	ScheduledArchiveAt    *time.Time      `json:"scheduledArchiveAt,omitempty"`
+	PublishedAt           *time.Time      `json:"publishedAt,omitempty"`
+	ArchivedAt            *time.Time      `json:"archivedAt,omitempty"`
+	PublishedBy           *string         `json:"publishedBy,omitempty"`
+	ArchivedBy            *string         `json:"archivedBy,omitempty"`
+	CreatedBy             *string         `json:"createdBy,omitempty"`
+	ScheduleFailedAt      *time.Time      `json:"scheduleFailedAt,omitempty"` // Set when the scheduler gave up on the pending scheduled operation
+	ScheduleFailureReason *string         `json:"scheduleFailureReason,omitempty"`
+	Revision              int             `json:"revision"` // Incremented on every update, checked for optimistic concurrency
+	CreatedAt             time.Time       `json:"createdAt"`
+	UpdatedAt             *time.Time      `json:"updatedAt,omitempty"`
+
+	// ReleaseNotes is stored apart from the version row; set by version listings and details.
+	ReleaseNotes *VersionReleaseNotes `json:"releaseNotes,omitempty"`
 }
 
 // NewTemplateVersion creates a new template version with DRAFT status.
@@ -62,6 +68,11 @@ func (tv *TemplateVersion) IsArchived() bool {
 	return tv.Status == VersionStatusArchived
 }
 
+// IsScheduleFailed returns true if the scheduler stopped retrying the pending scheduled operation.
+func (tv *TemplateVersion) IsScheduleFailed() bool {
+	return tv.ScheduleFailedAt != nil
+}
+
 // CanEdit returns an error if the version cannot be edited.
 func (tv *TemplateVersion) CanEdit() error {
 	if tv.IsPublished() {
@@ -129,25 +140,36 @@ func (tv *TemplateVersion) CanArchive() error {
 }
 
 // Publish changes the version status to PUBLISHED.
+// An empty userID (scheduled publication) leaves PublishedBy unset.
 func (tv *TemplateVersion) Publish(userID string) {
 	now := time.Now().UTC()
 	tv.Status = VersionStatusPublished
 	tv.PublishedAt = &now
-	tv.PublishedBy = &userID
+	tv.PublishedBy = optionalUserID(userID)
 	tv.ScheduledPublishAt = nil
+	tv.clearScheduleFailure()
 	tv.UpdatedAt = &now
 }
 
 // Archive changes the version status to ARCHIVED.
+// An empty userID (scheduled archival) leaves ArchivedBy unset.
 func (tv *TemplateVersion) Archive(userID string) {
 	now := time.Now().UTC()
 	tv.Status = VersionStatusArchived
 	tv.ArchivedAt = &now
-	tv.ArchivedBy = &userID
+	tv.ArchivedBy = optionalUserID(userID)
 	tv.ScheduledArchiveAt = nil
+	tv.clearScheduleFailure()
 	tv.UpdatedAt = &now
 }
 
+func optionalUserID(userID string) *string {
+	if userID == "" {
+		return nil
+	}
+	return &userID
+}
+
 // SchedulePublish sets the scheduled publication time.
 func (tv *TemplateVersion) SchedulePublish(publishAt time.Time) error {
 	if err := tv.CanSchedulePublish(publishAt); err != nil {
@@ -155,6 +177,7 @@ func (tv *TemplateVersion) SchedulePublish(publishAt time.Time) error {
 	}
 	tv.Status = VersionStatusScheduled
 	tv.ScheduledPublishAt = &publishAt
+	tv.clearScheduleFailure()
 	now := time.Now().UTC()
 	tv.UpdatedAt = &now
 	return nil
@@ -169,6 +192,7 @@ func (tv *TemplateVersion) ScheduleArchive(archiveAt time.Time) error {
 		return ErrScheduledTimeInPast
 	}
 	tv.ScheduledArchiveAt = &archiveAt
+	tv.clearScheduleFailure()
 	now := time.Now().UTC()
 	tv.UpdatedAt = &now
 	return nil
@@ -181,11 +205,18 @@ func (tv *TemplateVersion) CancelSchedule() error {
 		tv.ScheduledPublishAt = nil
 	}
 	tv.ScheduledArchiveAt = nil
+	tv.clearScheduleFailure()
 	now := time.Now().UTC()
 	tv.UpdatedAt = &now
 	return nil
 }
 
+// clearScheduleFailure leaves the failed-scheduled state.
+func (tv *TemplateVersion) clearScheduleFailure() {
+	tv.ScheduleFailedAt = nil
+	tv.ScheduleFailureReason = nil
+}
+
 // Validate checks if the template version data is valid.
 func (tv *TemplateVersion) Validate() error {
 	if tv.TemplateID == "" {
@@ -210,6 +241,7 @@ func (tv *TemplateVersion) Validate() error {
 type TemplateVersionWithDetails struct {
 	TemplateVersion
 	Injectables []*VersionInjectableWithDefinition `json:"injectables,omitempty"`
+	Changelog   *VersionChangelog                  `json:"changelog,omitempty"` // Set once the version was published
 }
 
 // TemplateWithDetails represents a template with its published version and metadata.
@@ -108,6 +108,11 @@ func (m *WorkspaceMember) Activate() {
 	m.JoinedAt = &now
 }
 
+// IsDeactivated returns true if the membership was deactivated.
+func (m *WorkspaceMember) IsDeactivated() bool {
+	return m.MembershipStatus == MembershipStatusInactive
+}
+
 // HasPermission checks if the member has at least the required role.
 func (m *WorkspaceMember) HasPermission(required WorkspaceRole) bool {
 	return m.Role.HasPermission(required)
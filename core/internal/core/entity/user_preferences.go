@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"time"
+	// Embedded zone database: runtime images (alpine) ship without tzdata.
+	_ "time/tzdata"
+
+	"golang.org/x/text/language"
+)
+
+// Default preference values used until a user saves their own.
+const (
+	DefaultLocale   = "en"
+	DefaultTimezone = "UTC"
+)
+
+// timeLayouts maps a base language to the layout used when formatting times for users.
+var timeLayouts = map[string]string{
+	"en": "Jan 2, 2006 3:04 PM MST",
+	"es": "02/01/2006 15:04 MST",
+}
+
+// UserPreferences holds per-user settings that shape how the UI and generated
+// content are presented: locale, timezone and the workspace opened by default.
+type UserPreferences struct {
+	UserID             string     `json:"userId"`
+	Locale             string     `json:"locale"`   // BCP 47 tag, e.g. "es-CL"
+	Timezone           string     `json:"timezone"` // IANA name, e.g. "America/Santiago"
+	DefaultWorkspaceID *string    `json:"defaultWorkspaceId,omitempty"`
+	CreatedAt          time.Time  `json:"createdAt"`
+	UpdatedAt          *time.Time `json:"updatedAt,omitempty"`
+}
+
+// NewUserPreferences returns the default preferences for a user.
+func NewUserPreferences(userID string) *UserPreferences {
+	return &UserPreferences{
+		UserID:    userID,
+		Locale:    DefaultLocale,
+		Timezone:  DefaultTimezone,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// Language returns the base language of the locale (e.g. "es" for "es-CL").
+func (p *UserPreferences) Language() string {
+	tag, err := language.Parse(p.Locale)
+	if err != nil {
+		return DefaultLocale
+	}
+	base, _ := tag.Base()
+	return base.String()
+}
+
+// Location returns the preferred timezone, falling back to UTC if it cannot be loaded.
+func (p *UserPreferences) Location() *time.Location {
+	return loadTimezone(p.Timezone)
+}
+
+// FormatTime formats t in the user's timezone using a layout suited to their language.
+func (p *UserPreferences) FormatTime(t time.Time) string {
+	layout, ok := timeLayouts[p.Language()]
+	if !ok {
+		layout = timeLayouts[DefaultLocale]
+	}
+	return t.In(p.Location()).Format(layout)
+}
+
+// Validate checks if the preferences are valid.
+func (p *UserPreferences) Validate() error {
+	if p.UserID == "" || p.Locale == "" || p.Timezone == "" {
+		return ErrRequiredField
+	}
+	if _, err := language.Parse(p.Locale); err != nil || len(p.Locale) > 35 {
+		return ErrInvalidLocale
+	}
+	if !validTimezone(p.Timezone) {
+		return ErrInvalidTimezone
+	}
+	return nil
+}
+
+// validTimezone reports whether name is an IANA timezone. time.LoadLocation also accepts "Local",
+// which depends on the server and is no user or workspace timezone.
+func validTimezone(name string) bool {
+	_, err := time.LoadLocation(name)
+	return err == nil && name != "Local" && len(name) <= 64
+}
+
+// loadTimezone loads a timezone, falling back to UTC when it is empty or cannot be loaded.
+func loadTimezone(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUserPreferencesValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		locale   string
+		timezone string
+		wantErr  error
+	}{
+		{"defaults", DefaultLocale, DefaultTimezone, nil},
+		{"regional locale", "es-CL", "America/Santiago", nil},
+		{"malformed locale", "not a locale", "UTC", ErrInvalidLocale},
+		{"unknown timezone", "en", "Mars/Olympus", ErrInvalidTimezone},
+		{"server local timezone", "en", "Local", ErrInvalidTimezone},
+		{"missing timezone", "en", "", ErrRequiredField},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewUserPreferences("user-1")
+			p.Locale = tt.locale
+			p.Timezone = tt.timezone
+			if err := p.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserPreferencesFormatTime(t *testing.T) {
+	at := time.Date(2026, 3, 5, 18, 30, 0, 0, time.UTC)
+
+	p := NewUserPreferences("user-1")
+	if got, want := p.FormatTime(at), "Mar 5, 2026 6:30 PM UTC"; got != want {
+		t.Errorf("FormatTime() = %q, want %q", got, want)
+	}
+
+	p.Locale = "es-CL"
+	p.Timezone = "America/Santiago"
+	if got := p.Language(); got != "es" {
+		t.Errorf("Language() = %q, want es", got)
+	}
+	if got, want := p.FormatTime(at), "05/03/2026 15:30 -03"; got != want {
+		t.Errorf("FormatTime() = %q, want %q", got, want)
+	}
+}
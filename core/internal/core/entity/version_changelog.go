@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VersionChangelog lists what changed in a version compared to the version published before it.
+// It is computed when the version is published.
+type VersionChangelog struct {
+	PreviousVersionID     *string  `json:"previousVersionId,omitempty"` // nil on the first publication of the template
+	PreviousVersionNumber *int     `json:"previousVersionNumber,omitempty"`
+	SectionsAdded         []string `json:"sectionsAdded"`
+	SectionsRemoved       []string `json:"sectionsRemoved"`
+	InjectablesAdded      []string `json:"injectablesAdded"`
+	InjectablesRemoved    []string `json:"injectablesRemoved"`
+	PageConfigChanges     []string `json:"pageConfigChanges"` // e.g. "format: A4 → LETTER"
+	Summary               string   `json:"summary"`           // human-readable, one change per line
+}
+
+// IsEmpty returns true if no change was found.
+func (c *VersionChangelog) IsEmpty() bool {
+	return len(c.SectionsAdded) == 0 && len(c.SectionsRemoved) == 0 &&
+		len(c.InjectablesAdded) == 0 && len(c.InjectablesRemoved) == 0 &&
+		len(c.PageConfigChanges) == 0
+}
+
+// Lines returns the changes as human-readable lines.
+func (c *VersionChangelog) Lines() []string {
+	var lines []string
+	if c.PreviousVersionNumber == nil {
+		lines = append(lines, "First published version")
+	} else {
+		lines = append(lines, fmt.Sprintf("Changes since version %d", *c.PreviousVersionNumber))
+	}
+	if c.IsEmpty() {
+		return append(lines, "No changes to sections, injectables or page configuration")
+	}
+
+	for _, s := range c.SectionsAdded {
+		lines = append(lines, fmt.Sprintf("+ Section added: %s", s))
+	}
+	for _, s := range c.SectionsRemoved {
+		lines = append(lines, fmt.Sprintf("- Section removed: %s", s))
+	}
+	for _, key := range c.InjectablesAdded {
+		lines = append(lines, fmt.Sprintf("+ Injectable added: %s", key))
+	}
+	for _, key := range c.InjectablesRemoved {
+		lines = append(lines, fmt.Sprintf("- Injectable removed: %s", key))
+	}
+	for _, change := range c.PageConfigChanges {
+		lines = append(lines, fmt.Sprintf("~ Page %s", change))
+	}
+	return lines
+}
+
+// Summarize sets Summary from the changes.
+func (c *VersionChangelog) Summarize() {
+	c.Summary = strings.Join(c.Lines(), "\n")
+}
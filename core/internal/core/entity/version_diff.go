@@ -0,0 +1,50 @@
+package entity
+
+import "encoding/json"
+
+// NodeChangeKind is how a content node differs between two versions.
+type NodeChangeKind string
+
+const (
+	NodeAdded   NodeChangeKind = "ADDED"
+	NodeRemoved NodeChangeKind = "REMOVED"
+	NodeChanged NodeChangeKind = "CHANGED"
+)
+
+// NodeChange is a block of content added, removed or changed between two versions.
+type NodeChange struct {
+	Kind NodeChangeKind `json:"kind"`
+	// Path locates the node in the version that has it, the target version for changed nodes,
+	// e.g. "content[3]" or "header.content[0]".
+	Path     string          `json:"path"`
+	NodeType string          `json:"nodeType"`
+	Fields   []string        `json:"fields,omitempty"` // Parts of a changed node that differ, e.g. "attrs.level" or "content"
+	Before   json.RawMessage `json:"before,omitempty"` // Node in the base version; absent for added nodes
+	After    json.RawMessage `json:"after,omitempty"`  // Node in the target version; absent for removed nodes
+}
+
+// FieldChange is a setting whose value differs between two versions.
+type FieldChange struct {
+	Field string `json:"field"` // Dotted path, e.g. "margins.top" or "header.layout"
+	From  any    `json:"from"`  // nil when the setting is new
+	To    any    `json:"to"`    // nil when the setting was removed
+}
+
+// VersionDiff is the structural difference between the content of two versions of a template,
+// from a base version to a target version.
+type VersionDiff struct {
+	FromVersionID      string        `json:"fromVersionId"`
+	FromVersionNumber  int           `json:"fromVersionNumber"`
+	ToVersionID        string        `json:"toVersionId"`
+	ToVersionNumber    int           `json:"toVersionNumber"`
+	Nodes              []NodeChange  `json:"nodes"`
+	InjectablesAdded   []string      `json:"injectablesAdded"`
+	InjectablesRemoved []string      `json:"injectablesRemoved"`
+	PageConfigChanges  []FieldChange `json:"pageConfigChanges"` // Includes header and footer settings
+}
+
+// IsEmpty returns true if both versions have the same content.
+func (d *VersionDiff) IsEmpty() bool {
+	return len(d.Nodes) == 0 && len(d.InjectablesAdded) == 0 &&
+		len(d.InjectablesRemoved) == 0 && len(d.PageConfigChanges) == 0
+}
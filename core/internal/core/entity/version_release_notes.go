@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Release notes limits.
+const (
+	MaxReleaseNotesBodyLength = 20000
+	MaxReleaseNotesTickets    = 20
+	MaxReleaseNoteTicketLen   = 64
+)
+
+// VersionReleaseNotes are the release notes an author writes for a version, separate from its description.
+// They can be edited until the version is published and are frozen afterwards.
+type VersionReleaseNotes struct {
+	VersionID string     `json:"versionId"`
+	Body      string     `json:"body"`      // Markdown
+	TicketIDs []string   `json:"ticketIds"` // Linked issue tracker IDs, e.g. "DOC-142"
+	AuthorID  *string    `json:"authorId,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// NewVersionReleaseNotes creates release notes for a version with trimmed, deduplicated ticket IDs.
+func NewVersionReleaseNotes(versionID, body string, ticketIDs []string, authorID *string) *VersionReleaseNotes {
+	return &VersionReleaseNotes{
+		VersionID: versionID,
+		Body:      strings.TrimSpace(body),
+		TicketIDs: normalizeTicketIDs(ticketIDs),
+		AuthorID:  authorID,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// Validate checks the release notes limits.
+func (n *VersionReleaseNotes) Validate() error {
+	if n.Body == "" && len(n.TicketIDs) == 0 {
+		return ErrRequiredField
+	}
+	if utf8.RuneCountInString(n.Body) > MaxReleaseNotesBodyLength {
+		return ErrFieldTooLong
+	}
+	if len(n.TicketIDs) > MaxReleaseNotesTickets {
+		return ErrInvalidReleaseNotes
+	}
+	for _, id := range n.TicketIDs {
+		if len(id) > MaxReleaseNoteTicketLen || strings.ContainsFunc(id, isTicketSeparator) {
+			return ErrInvalidReleaseNotes
+		}
+	}
+	return nil
+}
+
+// CanEditReleaseNotes returns an error once the version was published; archived versions were
+// published before, so their notes stay frozen too.
+func (tv *TemplateVersion) CanEditReleaseNotes() error {
+	if tv.PublishedAt != nil || tv.IsPublished() || tv.IsArchived() {
+		return ErrReleaseNotesFrozen
+	}
+	return nil
+}
+
+func normalizeTicketIDs(ticketIDs []string) []string {
+	result := make([]string, 0, len(ticketIDs))
+	seen := make(map[string]bool, len(ticketIDs))
+	for _, id := range ticketIDs {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
+
+func isTicketSeparator(r rune) bool {
+	return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewVersionReleaseNotesNormalizesTickets(t *testing.T) {
+	notes := NewVersionReleaseNotes("v-1", "  Fixes the footer  ", []string{" DOC-1", "", "DOC-2", "DOC-1"}, nil)
+
+	if notes.Body != "Fixes the footer" {
+		t.Errorf("Body = %q, want trimmed", notes.Body)
+	}
+	if want := []string{"DOC-1", "DOC-2"}; !slices.Equal(notes.TicketIDs, want) {
+		t.Errorf("TicketIDs = %v, want %v", notes.TicketIDs, want)
+	}
+}
+
+func TestVersionReleaseNotesValidate(t *testing.T) {
+	tooManyTickets := make([]string, MaxReleaseNotesTickets+1)
+	for i := range tooManyTickets {
+		tooManyTickets[i] = "DOC-" + strings.Repeat("9", i+1)
+	}
+
+	tests := []struct {
+		name      string
+		body      string
+		ticketIDs []string
+		wantErr   error
+	}{
+		{"body only", "Adds a signature block", nil, nil},
+		{"tickets only", "", []string{"DOC-142"}, nil},
+		{"empty", "  ", nil, ErrRequiredField},
+		{"body too long", strings.Repeat("a", MaxReleaseNotesBodyLength+1), nil, ErrFieldTooLong},
+		{"too many tickets", "", tooManyTickets, ErrInvalidReleaseNotes},
+		{"ticket with comma", "", []string{"DOC-1,DOC-2"}, ErrInvalidReleaseNotes},
+		{"ticket with space", "", []string{"DOC 1"}, ErrInvalidReleaseNotes},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notes := NewVersionReleaseNotes("v-1", tt.body, tt.ticketIDs, nil)
+			if err := notes.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCanEditReleaseNotes(t *testing.T) {
+	publishedAt := time.Now()
+	tests := []struct {
+		name    string
+		version TemplateVersion
+		wantErr error
+	}{
+		{"draft", TemplateVersion{Status: VersionStatusDraft}, nil},
+		{"staging", TemplateVersion{Status: VersionStatusStaging}, nil},
+		{"scheduled", TemplateVersion{Status: VersionStatusScheduled}, nil},
+		{"published", TemplateVersion{Status: VersionStatusPublished, PublishedAt: &publishedAt}, ErrReleaseNotesFrozen},
+		{"archived", TemplateVersion{Status: VersionStatusArchived, PublishedAt: &publishedAt}, ErrReleaseNotesFrozen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.version.CanEditReleaseNotes(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("CanEditReleaseNotes() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// WASM plugin limits.
+const (
+	PluginInjectorDefaultTimeoutMs = 2000
+	PluginInjectorMaxTimeoutMs     = 30000
+)
+
+var (
+	pluginNamePattern         = regexp.MustCompile(`^[a-z][a-z0-9]{1,29}$`)
+	pluginInjectorCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,99}$`)
+)
+
+// PluginCapability is a host function or resource granted to a WASM plugin by the operator.
+// Plugins get nothing they are not granted: importing a host function of a capability the plugin
+// lacks fails its load.
+type PluginCapability string
+
+const (
+	// PluginCapabilityLog grants the log host function, writing to the engine log.
+	PluginCapabilityLog PluginCapability = "log"
+	// PluginCapabilityHTTP grants the http_fetch host function, limited to the allowed hosts.
+	PluginCapabilityHTTP PluginCapability = "http"
+	// PluginCapabilityClock gives the WASI clocks the real time instead of a fixed one.
+	PluginCapabilityClock PluginCapability = "clock"
+	// PluginCapabilityRandom gives WASI random_get a secure source instead of a deterministic one.
+	PluginCapabilityRandom PluginCapability = "random"
+	// PluginCapabilityRequest passes the request payload and headers to the injectors of the plugin.
+	PluginCapabilityRequest PluginCapability = "request"
+)
+
+// PluginGrant is what the operator allows a plugin to use.
+type PluginGrant struct {
+	Capabilities []PluginCapability
+	// AllowedHosts are the hosts http_fetch may reach, exact or as "*.example.com".
+	AllowedHosts []string
+}
+
+// Has reports whether the grant includes capability.
+func (g PluginGrant) Has(capability PluginCapability) bool {
+	return slices.Contains(g.Capabilities, capability)
+}
+
+// Validate checks the capabilities of the grant.
+func (g PluginGrant) Validate() error {
+	for _, capability := range g.Capabilities {
+		switch capability {
+		case PluginCapabilityLog, PluginCapabilityHTTP, PluginCapabilityClock, PluginCapabilityRandom, PluginCapabilityRequest:
+		default:
+			return fmt.Errorf("%w: unknown capability %q", ErrInvalidPlugin, capability)
+		}
+	}
+	if len(g.AllowedHosts) > 0 && !g.Has(PluginCapabilityHTTP) {
+		return fmt.Errorf("%w: allowed hosts require the %q capability", ErrInvalidPlugin, PluginCapabilityHTTP)
+	}
+	return nil
+}
+
+// PluginManifest is what a WASM plugin provides, returned by its pf_manifest export.
+type PluginManifest struct {
+	Injectors []*PluginInjectorSpec `json:"injectors"`
+	// PostProcess is true when the plugin exports pf_post_process, run on every PDF of the
+	// render API after it is rendered.
+	PostProcess bool `json:"postProcess"`
+}
+
+// PluginInjectorSpec describes an injector of a WASM plugin or of a remote injector service.
+type PluginInjectorSpec struct {
+	Code         string             `json:"code"` // Starts with "<plugin or remote name>_"
+	Label        map[string]string  `json:"label"`
+	Description  map[string]string  `json:"description"`
+	DataType     InjectableDataType `json:"dataType"` // TEXT, NUMBER, BOOLEAN, DATE or IMAGE
+	Dependencies []string           `json:"dependencies"`
+	IsCritical   bool               `json:"isCritical"`
+	TimeoutMs    int                `json:"timeoutMs"`
+}
+
+// Validate checks the manifest of the plugin called name. Zero timeouts take the default.
+func (m *PluginManifest) Validate(name string) error {
+	if !pluginNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: name %q must be 2 to 30 lowercase letters and digits, starting with a letter", ErrInvalidPlugin, name)
+	}
+	if len(m.Injectors) == 0 && !m.PostProcess {
+		return fmt.Errorf("%w: plugin %q provides no injectors and no post-processor", ErrInvalidPlugin, name)
+	}
+	return validateInjectorSpecs(name, m.Injectors, ErrInvalidPlugin)
+}
+
+// validateInjectorSpecs checks the injectors declared by the plugin or remote called name. Errors
+// wrap invalid. Zero timeouts take the default.
+func validateInjectorSpecs(name string, specs []*PluginInjectorSpec, invalid error) error {
+	codes := make(map[string]bool, len(specs))
+	for _, inj := range specs {
+		if inj == nil {
+			return fmt.Errorf("%w: empty injector declaration", invalid)
+		}
+		if !pluginInjectorCodePattern.MatchString(inj.Code) || !strings.HasPrefix(inj.Code, name+"_") {
+			return fmt.Errorf("%w: injector code %q must start with %q and use lowercase letters, digits and underscores", invalid, inj.Code, name+"_")
+		}
+		if codes[inj.Code] {
+			return fmt.Errorf("%w: injector %q declared twice", invalid, inj.Code)
+		}
+		codes[inj.Code] = true
+		if inj.Label["en"] == "" {
+			return fmt.Errorf("%w: injector %q needs an English label", invalid, inj.Code)
+		}
+		switch inj.DataType {
+		case InjectableDataTypeText, InjectableDataTypeNumber, InjectableDataTypeBoolean, InjectableDataTypeDate, InjectableDataTypeImage:
+		default:
+			return fmt.Errorf("%w: injector %q data type must be TEXT, NUMBER, BOOLEAN, DATE or IMAGE", invalid, inj.Code)
+		}
+		if inj.TimeoutMs == 0 {
+			inj.TimeoutMs = PluginInjectorDefaultTimeoutMs
+		}
+		if inj.TimeoutMs < 1 || inj.TimeoutMs > PluginInjectorMaxTimeoutMs {
+			return fmt.Errorf("%w: injector %q timeout must be 1 to %d ms", invalid, inj.Code, PluginInjectorMaxTimeoutMs)
+		}
+	}
+	return nil
+}
+
+// ValueType returns the type of the values the injector produces.
+func (s *PluginInjectorSpec) ValueType() ValueType {
+	switch s.DataType {
+	case InjectableDataTypeNumber:
+		return ValueTypeNumber
+	case InjectableDataTypeBoolean:
+		return ValueTypeBool
+	case InjectableDataTypeDate:
+		return ValueTypeTime
+	case InjectableDataTypeImage:
+		return ValueTypeImage
+	default:
+		return ValueTypeString
+	}
+}
+
+// PluginInjectorInput is what pf_resolve of a plugin receives. Payload and Headers are only set
+// with the request capability.
+type PluginInjectorInput struct {
+	Code           string            `json:"code"`
+	Payload        any               `json:"payload,omitempty"`
+	Headers        map[string]string `json:"headers,omitempty"`
+	Resolved       map[string]any    `json:"resolved"` // Values of the dependencies
+	TenantCode     string            `json:"tenantCode"`
+	WorkspaceCode  string            `json:"workspaceCode"`
+	Environment    string            `json:"environment"`
+	SelectedFormat string            `json:"selectedFormat,omitempty"`
+}
+
+// PluginRender describes the render of a PDF passed to pf_post_process.
+type PluginRender struct {
+	Operation     string `json:"operation"`
+	TenantCode    string `json:"tenantCode"`
+	WorkspaceCode string `json:"workspaceCode"`
+	DocumentType  string `json:"documentType,omitempty"`
+	TemplateID    string `json:"templateId"`
+	VersionID     string `json:"versionId"`
+	Environment   string `json:"environment"`
+}
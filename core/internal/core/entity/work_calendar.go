@@ -0,0 +1,70 @@
+package entity
+
+import "time"
+
+// WorkCalendar tells working days from weekends and holidays, for deadlines counted in working
+// days such as "payment due within 10 working days".
+type WorkCalendar struct {
+	Weekend  []time.Weekday // Days off every week; Saturday and Sunday when empty
+	Holidays []time.Time    // Days off, compared by their date in the zone of the date checked
+}
+
+// IsWorkingDay reports whether the date of t is neither a weekend day nor a holiday.
+func (c WorkCalendar) IsWorkingDay(t time.Time) bool {
+	weekend := c.Weekend
+	if len(weekend) == 0 {
+		weekend = []time.Weekday{time.Saturday, time.Sunday}
+	}
+	for _, day := range weekend {
+		if t.Weekday() == day {
+			return false
+		}
+	}
+	y, m, d := t.Date()
+	for _, holiday := range c.Holidays {
+		if hy, hm, hd := holiday.Date(); hy == y && hm == m && hd == d {
+			return false
+		}
+	}
+	return true
+}
+
+// AddWorkingDays returns t moved n working days forward, or backward when n is negative. The
+// clock of t is kept; n = 0 returns t even when it is not a working day.
+func (c WorkCalendar) AddWorkingDays(t time.Time, n int) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if c.IsWorkingDay(t) {
+			n--
+		}
+	}
+	return t
+}
+
+// WorkingDaysBetween counts the working days after the date of from up to and including the date
+// of to, so that AddWorkingDays(from, WorkingDaysBetween(from, to)) lands on to when to is a
+// working day. It is negative when to is before from.
+func (c WorkCalendar) WorkingDaysBetween(from, to time.Time) int {
+	from = startOfDay(from)
+	to = startOfDay(to.In(from.Location()))
+	sign := 1
+	if to.Before(from) {
+		from, to, sign = to, from, -1
+	}
+	count := 0
+	for day := from.AddDate(0, 0, 1); !day.After(to); day = day.AddDate(0, 0, 1) {
+		if c.IsWorkingDay(day) {
+			count++
+		}
+	}
+	return sign * count
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
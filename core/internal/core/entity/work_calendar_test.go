@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorkCalendar(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, time.December, d, 9, 30, 0, 0, time.UTC) }
+	cal := WorkCalendar{Holidays: []time.Time{day(25)}}
+
+	tests := []struct {
+		name string
+		from time.Time
+		n    int
+		want time.Time
+	}{
+		{"skips the weekend", day(20), 1, day(23)},
+		{"skips holidays", day(24), 1, day(26)},
+		{"ten working days", day(16), 10, day(31)},
+		{"backward", day(23), -1, day(20)},
+		{"zero keeps the date", day(21), 0, day(21)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cal.AddWorkingDays(tt.from, tt.n)
+			if !got.Equal(tt.want) {
+				t.Errorf("AddWorkingDays(%s, %d) = %s, want %s", tt.from.Format(time.DateOnly), tt.n, got.Format(time.DateOnly), tt.want.Format(time.DateOnly))
+			}
+			if tt.n != 0 {
+				if between := cal.WorkingDaysBetween(tt.from, got); between != tt.n {
+					t.Errorf("WorkingDaysBetween(%s, %s) = %d, want %d", tt.from.Format(time.DateOnly), got.Format(time.DateOnly), between, tt.n)
+				}
+			}
+		})
+	}
+
+	friday := WorkCalendar{Weekend: []time.Weekday{time.Friday, time.Saturday}}
+	if friday.IsWorkingDay(day(20)) || !friday.IsWorkingDay(day(22)) {
+		t.Error("a Friday-Saturday weekend works on Sundays and not on Fridays")
+	}
+}
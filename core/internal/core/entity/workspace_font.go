@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// FontMaxSize is the largest font file a workspace can upload. Uploads are base64-encoded JSON,
+// like assets, so the request stays within the default body limit.
+const FontMaxSize = 10 << 20
+
+// MaxWorkspaceFonts is the number of fonts a workspace can upload.
+const MaxWorkspaceFonts = 100
+
+// FontFormat is the format of an uploaded font file.
+type FontFormat string
+
+const (
+	FontFormatTTF FontFormat = "TTF" // TrueType outlines
+	FontFormatOTF FontFormat = "OTF" // CFF (PostScript) outlines
+)
+
+// ContentType returns the media type of font files of the format.
+func (f FontFormat) ContentType() string {
+	if f == FontFormatOTF {
+		return "font/otf"
+	}
+	return "font/ttf"
+}
+
+// Extension returns the file extension of the format, without the dot.
+func (f FontFormat) Extension() string {
+	return strings.ToLower(string(f))
+}
+
+// FontStyle is the slant of a font face.
+type FontStyle string
+
+const (
+	FontStyleNormal FontStyle = "normal"
+	FontStyleItalic FontStyle = "italic"
+)
+
+// FontSource is where a font family available to renders comes from.
+type FontSource string
+
+const (
+	FontSourceSystem    FontSource = "SYSTEM"    // Installed on the server or in the configured font dirs
+	FontSourceWorkspace FontSource = "WORKSPACE" // Uploaded to the workspace
+)
+
+// WorkspaceFont is a font face uploaded to a workspace. Renders of the workspace's templates find
+// it by its family name, like the fonts installed on the server.
+type WorkspaceFont struct {
+	ID          string     `json:"id"`
+	WorkspaceID string     `json:"workspaceId"`
+	Family      string     `json:"family"` // Typographic family name, as templates reference it
+	Weight      int        `json:"weight"` // 100-900
+	Style       FontStyle  `json:"style"`
+	Format      FontFormat `json:"format"`
+	SizeBytes   int        `json:"sizeBytes"`
+	SHA256      string     `json:"sha256"`
+	Data        []byte     `json:"-"` // Loaded only when the content is requested
+	CreatedBy   *string    `json:"createdBy,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// SameFace reports whether f and other are the same face of the same family, which Typst could
+// not tell apart.
+func (f *WorkspaceFont) SameFace(other *WorkspaceFont) bool {
+	return strings.EqualFold(f.Family, other.Family) && f.Weight == other.Weight && f.Style == other.Style
+}
+
+// FontFace is a weight and style of a font family.
+type FontFace struct {
+	Weight int       `json:"weight"`
+	Style  FontStyle `json:"style"`
+}
+
+// FontFamily is a font family renders of a workspace can use, for the editor font picker.
+type FontFamily struct {
+	Name   string     `json:"name"`
+	Source FontSource `json:"source"`
+	// Faces of a workspace family, sorted by style and weight; empty for system families,
+	// whose faces are not listed.
+	Faces []FontFace `json:"faces,omitempty"`
+}
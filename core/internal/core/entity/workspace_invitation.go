@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// InvitationStatus represents the lifecycle state of a workspace invitation.
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "PENDING"
+	InvitationStatusAccepted InvitationStatus = "ACCEPTED"
+	InvitationStatusDeclined InvitationStatus = "DECLINED"
+	InvitationStatusRevoked  InvitationStatus = "REVOKED"
+)
+
+// IsValid checks if the invitation status is valid.
+func (s InvitationStatus) IsValid() bool {
+	switch s {
+	case InvitationStatusPending, InvitationStatusAccepted, InvitationStatusDeclined, InvitationStatusRevoked:
+		return true
+	}
+	return false
+}
+
+// WorkspaceInvitation is an invitation addressed to an email to join a workspace.
+// No user or membership exists until the invitee accepts it.
+type WorkspaceInvitation struct {
+	ID          string           `json:"id"`
+	WorkspaceID string           `json:"workspaceId"`
+	Email       string           `json:"email"`
+	Role        WorkspaceRole    `json:"role"`
+	Status      InvitationStatus `json:"status"`
+	TokenHash   string           `json:"-"` // SHA-256 of the emailed token; the raw token is never stored
+	InvitedBy   *string          `json:"invitedBy,omitempty"`
+	ExpiresAt   time.Time        `json:"expiresAt"`
+	RespondedAt *time.Time       `json:"respondedAt,omitempty"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	UpdatedAt   *time.Time       `json:"updatedAt,omitempty"`
+}
+
+// NewWorkspaceInvitation creates a new pending invitation that expires after ttl.
+func NewWorkspaceInvitation(workspaceID, email string, role WorkspaceRole, invitedBy *string, ttl time.Duration) *WorkspaceInvitation {
+	now := time.Now().UTC()
+	return &WorkspaceInvitation{
+		WorkspaceID: workspaceID,
+		Email:       strings.ToLower(strings.TrimSpace(email)),
+		Role:        role,
+		Status:      InvitationStatusPending,
+		InvitedBy:   invitedBy,
+		ExpiresAt:   now.Add(ttl),
+		CreatedAt:   now,
+	}
+}
+
+// IsExpired returns true if the invitation can no longer be answered.
+func (i *WorkspaceInvitation) IsExpired() bool {
+	return time.Now().UTC().After(i.ExpiresAt)
+}
+
+// IsAddressedTo reports whether the invitation belongs to the given email.
+func (i *WorkspaceInvitation) IsAddressedTo(email string) bool {
+	return strings.EqualFold(i.Email, strings.TrimSpace(email))
+}
+
+// CanRespond returns an error if the invitation cannot be accepted or declined.
+func (i *WorkspaceInvitation) CanRespond() error {
+	if i.Status != InvitationStatusPending {
+		return ErrInvitationNotPending
+	}
+	if i.IsExpired() {
+		return ErrInvitationExpired
+	}
+	return nil
+}
+
+// Respond closes the invitation with the given final status.
+func (i *WorkspaceInvitation) Respond(status InvitationStatus) {
+	now := time.Now().UTC()
+	i.Status = status
+	i.RespondedAt = &now
+	i.UpdatedAt = &now
+}
+
+// Renew replaces the token and extends the expiration of a pending invitation.
+func (i *WorkspaceInvitation) Renew(tokenHash string, ttl time.Duration) {
+	now := time.Now().UTC()
+	i.TokenHash = tokenHash
+	i.ExpiresAt = now.Add(ttl)
+	i.UpdatedAt = &now
+}
+
+// Validate checks if the invitation data is valid.
+func (i *WorkspaceInvitation) Validate() error {
+	if i.WorkspaceID == "" || i.Email == "" || i.TokenHash == "" {
+		return ErrRequiredField
+	}
+	if len(i.Email) > 255 {
+		return ErrFieldTooLong
+	}
+	if !strings.Contains(i.Email, "@") {
+		return ErrInvalidEmail
+	}
+	if !i.Role.IsValid() || i.Role == WorkspaceRoleOwner {
+		return ErrInvalidRole
+	}
+	if !i.Status.IsValid() {
+		return ErrInvalidInvitationStatus
+	}
+	return nil
+}
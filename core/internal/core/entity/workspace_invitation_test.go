@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWorkspaceInvitationCanRespond(t *testing.T) {
+	inv := NewWorkspaceInvitation("ws-1", " Ana@Acme.com ", WorkspaceRoleEditor, nil, time.Hour)
+	if err := inv.CanRespond(); err != nil {
+		t.Fatalf("fresh invitation: CanRespond() = %v", err)
+	}
+	if !inv.IsAddressedTo("ana@acme.com") {
+		t.Error("email match should be case-insensitive")
+	}
+
+	inv.ExpiresAt = time.Now().Add(-time.Minute)
+	if err := inv.CanRespond(); !errors.Is(err, ErrInvitationExpired) {
+		t.Errorf("expired invitation: CanRespond() = %v, want %v", err, ErrInvitationExpired)
+	}
+
+	inv.Renew("hash", time.Hour)
+	inv.Respond(InvitationStatusDeclined)
+	if err := inv.CanRespond(); !errors.Is(err, ErrInvitationNotPending) {
+		t.Errorf("declined invitation: CanRespond() = %v, want %v", err, ErrInvitationNotPending)
+	}
+}
+
+func TestWorkspaceInvitationValidate_RejectsOwner(t *testing.T) {
+	inv := NewWorkspaceInvitation("ws-1", "ana@acme.com", WorkspaceRoleOwner, nil, time.Hour)
+	inv.TokenHash = "hash"
+	if err := inv.Validate(); !errors.Is(err, ErrInvalidRole) {
+		t.Errorf("Validate() = %v, want %v", err, ErrInvalidRole)
+	}
+}
@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+// Workspace sandbox lifetime bounds.
+const (
+	WorkspaceSandboxDefaultTTL = 24 * time.Hour
+	WorkspaceSandboxMaxTTL     = 7 * 24 * time.Hour
+)
+
+// WorkspaceSandbox marks a workspace as a temporary clone of another workspace.
+// The sandbox workspace and everything in it is deleted once it expires.
+type WorkspaceSandbox struct {
+	WorkspaceID       string    `json:"workspaceId"`
+	SourceWorkspaceID *string   `json:"sourceWorkspaceId,omitempty"` // NULL once the source is deleted
+	CreatedBy         *string   `json:"createdBy,omitempty"`
+	ExpiresAt         time.Time `json:"expiresAt"`
+	CreatedAt         time.Time `json:"createdAt"`
+}
+
+// NewWorkspaceSandbox creates the sandbox record of a clone of sourceWorkspaceID that expires after ttl.
+func NewWorkspaceSandbox(workspaceID, sourceWorkspaceID, createdBy string, ttl time.Duration) *WorkspaceSandbox {
+	now := time.Now().UTC()
+	return &WorkspaceSandbox{
+		WorkspaceID:       workspaceID,
+		SourceWorkspaceID: &sourceWorkspaceID,
+		CreatedBy:         &createdBy,
+		ExpiresAt:         now.Add(ttl),
+		CreatedAt:         now,
+	}
+}
+
+// IsExpired returns true if the sandbox is due for deletion.
+func (s *WorkspaceSandbox) IsExpired() bool {
+	return time.Now().UTC().After(s.ExpiresAt)
+}
+
+// WorkspaceSandboxClone counts the resources copied into a sandbox workspace.
+type WorkspaceSandboxClone struct {
+	Folders     int `json:"folders"`
+	Tags        int `json:"tags"`
+	Injectables int `json:"injectables"`
+	Templates   int `json:"templates"`
+	Versions    int `json:"versions"`
+	Assets      int `json:"assets"`
+}
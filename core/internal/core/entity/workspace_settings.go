@@ -0,0 +1,269 @@
+package entity
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// WorkspaceSettingKey identifies a section of the workspace settings a tenant can lock.
+type WorkspaceSettingKey string
+
+const (
+	WorkspaceSettingBranding      WorkspaceSettingKey = "branding"
+	WorkspaceSettingRetention     WorkspaceSettingKey = "retention"
+	WorkspaceSettingRenderOptions WorkspaceSettingKey = "renderOptions"
+	WorkspaceSettingAllowedFonts  WorkspaceSettingKey = "allowedFonts"
+	WorkspaceSettingTimezone      WorkspaceSettingKey = "timezone"
+)
+
+// WorkspaceSettingKeys lists every workspace setting section.
+var WorkspaceSettingKeys = []WorkspaceSettingKey{
+	WorkspaceSettingBranding,
+	WorkspaceSettingRetention,
+	WorkspaceSettingRenderOptions,
+	WorkspaceSettingAllowedFonts,
+	WorkspaceSettingTimezone,
+}
+
+// IsValid checks if the setting key is valid.
+func (k WorkspaceSettingKey) IsValid() bool {
+	return slices.Contains(WorkspaceSettingKeys, k)
+}
+
+// Workspace settings limits.
+const (
+	MaxRenderHistoryDays = 3650
+	MaxWatermarkLength   = 100
+	MaxAllowedFonts      = 50
+)
+
+// WorkspaceSettings are the settings of a workspace. A nil section is not set.
+type WorkspaceSettings struct {
+	Branding      *WorkspaceBranding      `json:"branding,omitempty"`
+	Retention     *WorkspaceRetention     `json:"retention,omitempty"`
+	RenderOptions *WorkspaceRenderOptions `json:"renderOptions,omitempty"`
+	AllowedFonts  []string                `json:"allowedFonts,omitempty"` // Font families templates may use; empty allows all
+	// Timezone is the IANA timezone schedules are shown and evaluated in and numbering sequences
+	// reset their year in; empty is UTC.
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// WorkspaceBranding is the look of the workspace in the UI and hosted documents.
+type WorkspaceBranding struct {
+	LogoURL      string `json:"logoUrl,omitempty"`
+	PrimaryColor string `json:"primaryColor,omitempty"` // #RRGGBB
+	AccentColor  string `json:"accentColor,omitempty"`  // #RRGGBB
+}
+
+// WorkspaceRetention is how long the workspace keeps what renders leave behind.
+type WorkspaceRetention struct {
+	RenderHistoryDays int `json:"renderHistoryDays"`
+}
+
+// WorkspaceRenderOptions are the render defaults of the workspace.
+type WorkspaceRenderOptions struct {
+	Watermark    string          `json:"watermark,omitempty"`    // Default watermark of the workspace renders
+	Degraded     bool            `json:"degraded"`               // Default of the degraded render mode
+	UnknownNodes UnknownNodeMode `json:"unknownNodes,omitempty"` // How renders treat unknown nodes; empty flattens them
+}
+
+// UnknownNodeMode is how renders treat nodes of a type the converters do not know, such as nodes
+// of a newer editor or of a custom extension.
+type UnknownNodeMode string
+
+const (
+	UnknownNodesFlatten     UnknownNodeMode = "FLATTEN"     // Render their content as if the node were not there
+	UnknownNodesPlaceholder UnknownNodeMode = "PLACEHOLDER" // Show a visible box naming the node type in place of the node
+	UnknownNodesFail        UnknownNodeMode = "FAIL"        // Fail the render, listing the unknown nodes
+)
+
+// IsValid checks if the mode is valid. Empty is valid and flattens.
+func (m UnknownNodeMode) IsValid() bool {
+	switch m {
+	case "", UnknownNodesFlatten, UnknownNodesPlaceholder, UnknownNodesFail:
+		return true
+	}
+	return false
+}
+
+// Validate checks if the workspace settings are valid.
+func (s *WorkspaceSettings) Validate() error {
+	if b := s.Branding; b != nil {
+		if len(b.LogoURL) > 2048 {
+			return ErrInvalidWorkspaceSettings
+		}
+		if (b.PrimaryColor != "" && !hexColorRegex.MatchString(b.PrimaryColor)) ||
+			(b.AccentColor != "" && !hexColorRegex.MatchString(b.AccentColor)) {
+			return ErrInvalidWorkspaceSettings
+		}
+	}
+	if r := s.Retention; r != nil && (r.RenderHistoryDays < 1 || r.RenderHistoryDays > MaxRenderHistoryDays) {
+		return ErrInvalidWorkspaceSettings
+	}
+	if o := s.RenderOptions; o != nil && (len(o.Watermark) > MaxWatermarkLength || !o.UnknownNodes.IsValid()) {
+		return ErrInvalidWorkspaceSettings
+	}
+	if len(s.AllowedFonts) > MaxAllowedFonts {
+		return ErrInvalidWorkspaceSettings
+	}
+	for i, font := range s.AllowedFonts {
+		s.AllowedFonts[i] = strings.TrimSpace(font)
+		if s.AllowedFonts[i] == "" {
+			return ErrInvalidWorkspaceSettings
+		}
+	}
+	if s.Timezone != "" && !validTimezone(s.Timezone) {
+		return ErrInvalidTimezone
+	}
+	return nil
+}
+
+// Location returns the timezone of the workspace; UTC when not set.
+func (s *WorkspaceSettings) Location() *time.Location {
+	if s.Timezone == "" {
+		return time.UTC
+	}
+	return loadTimezone(s.Timezone)
+}
+
+// UnknownNodeMode returns how the workspace renders nodes of unknown types; FLATTEN when not set.
+func (s *WorkspaceSettings) UnknownNodeMode() UnknownNodeMode {
+	if s.RenderOptions == nil || s.RenderOptions.UnknownNodes == "" {
+		return UnknownNodesFlatten
+	}
+	return s.RenderOptions.UnknownNodes
+}
+
+// Apply sets the sections that are set in changes; the other sections keep their value.
+func (s *WorkspaceSettings) Apply(changes *WorkspaceSettings) {
+	for _, key := range WorkspaceSettingKeys {
+		if changes.section(key) != nil {
+			s.copySection(key, changes)
+		}
+	}
+}
+
+// IsEmpty reports whether no section is set.
+func (s *WorkspaceSettings) IsEmpty() bool {
+	for _, key := range WorkspaceSettingKeys {
+		if s.section(key) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// section returns the value of a section, nil when it is not set.
+func (s *WorkspaceSettings) section(key WorkspaceSettingKey) any {
+	switch key {
+	case WorkspaceSettingBranding:
+		if s.Branding != nil {
+			return *s.Branding
+		}
+	case WorkspaceSettingRetention:
+		if s.Retention != nil {
+			return *s.Retention
+		}
+	case WorkspaceSettingRenderOptions:
+		if s.RenderOptions != nil {
+			return *s.RenderOptions
+		}
+	case WorkspaceSettingAllowedFonts:
+		if len(s.AllowedFonts) > 0 {
+			return s.AllowedFonts
+		}
+	case WorkspaceSettingTimezone:
+		if s.Timezone != "" {
+			return s.Timezone
+		}
+	}
+	return nil
+}
+
+// copySection sets a section to its value in from.
+func (s *WorkspaceSettings) copySection(key WorkspaceSettingKey, from *WorkspaceSettings) {
+	switch key {
+	case WorkspaceSettingBranding:
+		s.Branding = from.Branding
+	case WorkspaceSettingRetention:
+		s.Retention = from.Retention
+	case WorkspaceSettingRenderOptions:
+		s.RenderOptions = from.RenderOptions
+	case WorkspaceSettingAllowedFonts:
+		s.AllowedFonts = from.AllowedFonts
+	case WorkspaceSettingTimezone:
+		s.Timezone = from.Timezone
+	}
+}
+
+// TenantWorkspaceDefaults are the settings new workspaces of a tenant start with.
+// Locked sections always take the tenant value and cannot be changed by workspaces.
+type TenantWorkspaceDefaults struct {
+	TenantID  string                `json:"tenantId"`
+	Settings  WorkspaceSettings     `json:"settings"`
+	Locked    []WorkspaceSettingKey `json:"locked"`
+	UpdatedBy *string               `json:"updatedBy,omitempty"`
+	UpdatedAt *time.Time            `json:"updatedAt,omitempty"` // nil when the tenant never set defaults
+}
+
+// Validate checks if the defaults are valid.
+func (d *TenantWorkspaceDefaults) Validate() error {
+	for _, key := range d.Locked {
+		if !key.IsValid() {
+			return ErrInvalidWorkspaceSettings
+		}
+	}
+	slices.Sort(d.Locked)
+	d.Locked = slices.Compact(d.Locked)
+	return d.Settings.Validate()
+}
+
+// Effective returns the settings a workspace applies: its own, with the locked sections
+// replaced by the tenant values.
+func (d *TenantWorkspaceDefaults) Effective(settings *WorkspaceSettings) *WorkspaceSettings {
+	effective := *settings
+	if d == nil {
+		return &effective
+	}
+	for _, key := range d.Locked {
+		effective.copySection(key, &d.Settings)
+	}
+	return &effective
+}
+
+// WithoutLocked returns settings without the locked sections, which workspaces do not store.
+func (d *TenantWorkspaceDefaults) WithoutLocked(settings *WorkspaceSettings) *WorkspaceSettings {
+	unlocked := *settings
+	if d == nil {
+		return &unlocked
+	}
+	for _, key := range d.Locked {
+		unlocked.copySection(key, &WorkspaceSettings{})
+	}
+	return &unlocked
+}
+
+// CheckOverride returns ErrWorkspaceSettingLocked if settings set a locked section to a value
+// other than the tenant's.
+func (d *TenantWorkspaceDefaults) CheckOverride(settings *WorkspaceSettings) error {
+	if d == nil {
+		return nil
+	}
+	for _, key := range d.Locked {
+		value := settings.section(key)
+		if value != nil && !reflect.DeepEqual(value, d.Settings.section(key)) {
+			return ErrWorkspaceSettingLocked
+		}
+	}
+	return nil
+}
+
+// WorkspaceSettingsView is the settings of a workspace as it applies them, with the sections the
+// tenant locked.
+type WorkspaceSettingsView struct {
+	WorkspaceID string                `json:"workspaceId"`
+	Settings    WorkspaceSettings     `json:"settings"`
+	Locked      []WorkspaceSettingKey `json:"locked"`
+}
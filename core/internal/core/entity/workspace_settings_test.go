@@ -0,0 +1,132 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWorkspaceSettings_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings WorkspaceSettings
+		wantErr  error
+	}{
+		{"empty", WorkspaceSettings{}, nil},
+		{"branding", WorkspaceSettings{Branding: &WorkspaceBranding{PrimaryColor: "#1A2B3C"}}, nil},
+		{"bad color", WorkspaceSettings{Branding: &WorkspaceBranding{AccentColor: "red"}}, ErrInvalidWorkspaceSettings},
+		{"zero retention", WorkspaceSettings{Retention: &WorkspaceRetention{}}, ErrInvalidWorkspaceSettings},
+		{"retention too long", WorkspaceSettings{Retention: &WorkspaceRetention{RenderHistoryDays: MaxRenderHistoryDays + 1}}, ErrInvalidWorkspaceSettings},
+		{"placeholder unknown nodes", WorkspaceSettings{RenderOptions: &WorkspaceRenderOptions{UnknownNodes: UnknownNodesPlaceholder}}, nil},
+		{"bad unknown nodes", WorkspaceSettings{RenderOptions: &WorkspaceRenderOptions{UnknownNodes: "IGNORE"}}, ErrInvalidWorkspaceSettings},
+		{"blank font", WorkspaceSettings{AllowedFonts: []string{"Inter", " "}}, ErrInvalidWorkspaceSettings},
+		{"timezone", WorkspaceSettings{Timezone: "America/Santiago"}, nil},
+		{"unknown timezone", WorkspaceSettings{Timezone: "Mars/Olympus"}, ErrInvalidTimezone},
+		{"server timezone", WorkspaceSettings{Timezone: "Local"}, ErrInvalidTimezone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.settings.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTenantWorkspaceDefaults_Validate(t *testing.T) {
+	d := &TenantWorkspaceDefaults{Locked: []WorkspaceSettingKey{WorkspaceSettingRetention, WorkspaceSettingBranding, WorkspaceSettingRetention}}
+	if err := d.Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+	if len(d.Locked) != 2 || d.Locked[0] != WorkspaceSettingBranding {
+		t.Errorf("locked = %v, want sorted and deduplicated", d.Locked)
+	}
+
+	d = &TenantWorkspaceDefaults{Locked: []WorkspaceSettingKey{"colors"}}
+	if err := d.Validate(); !errors.Is(err, ErrInvalidWorkspaceSettings) {
+		t.Errorf("Validate() = %v, want ErrInvalidWorkspaceSettings", err)
+	}
+}
+
+func TestTenantWorkspaceDefaults_Effective(t *testing.T) {
+	d := &TenantWorkspaceDefaults{
+		Settings: WorkspaceSettings{
+			Retention:    &WorkspaceRetention{RenderHistoryDays: 30},
+			AllowedFonts: []string{"Inter"},
+		},
+		Locked: []WorkspaceSettingKey{WorkspaceSettingRetention},
+	}
+	own := &WorkspaceSettings{
+		Retention:    &WorkspaceRetention{RenderHistoryDays: 365},
+		AllowedFonts: []string{"Roboto"},
+	}
+
+	effective := d.Effective(own)
+	if effective.Retention.RenderHistoryDays != 30 {
+		t.Errorf("retention = %d, want the locked tenant value 30", effective.Retention.RenderHistoryDays)
+	}
+	if effective.AllowedFonts[0] != "Roboto" {
+		t.Errorf("fonts = %v, want the workspace value", effective.AllowedFonts)
+	}
+	if own.Retention.RenderHistoryDays != 365 {
+		t.Error("Effective modified the workspace settings")
+	}
+
+	var none *TenantWorkspaceDefaults
+	if got := none.Effective(own); got.Retention.RenderHistoryDays != 365 {
+		t.Errorf("without defaults retention = %d, want 365", got.Retention.RenderHistoryDays)
+	}
+}
+
+func TestTenantWorkspaceDefaults_CheckOverride(t *testing.T) {
+	d := &TenantWorkspaceDefaults{
+		Settings: WorkspaceSettings{Branding: &WorkspaceBranding{PrimaryColor: "#000000"}},
+		Locked:   []WorkspaceSettingKey{WorkspaceSettingBranding},
+	}
+
+	tests := []struct {
+		name     string
+		settings WorkspaceSettings
+		wantErr  error
+	}{
+		{"locked section omitted", WorkspaceSettings{AllowedFonts: []string{"Inter"}}, nil},
+		{"locked section with tenant value", WorkspaceSettings{Branding: &WorkspaceBranding{PrimaryColor: "#000000"}}, nil},
+		{"locked section changed", WorkspaceSettings{Branding: &WorkspaceBranding{PrimaryColor: "#FFFFFF"}}, ErrWorkspaceSettingLocked},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := d.CheckOverride(&tt.settings); !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckOverride() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if unlocked := d.WithoutLocked(&WorkspaceSettings{Branding: &WorkspaceBranding{}}); unlocked.Branding != nil {
+		t.Error("WithoutLocked kept a locked section")
+	}
+}
+
+func TestWorkspaceSettings_Location(t *testing.T) {
+	if loc := (&WorkspaceSettings{}).Location(); loc != time.UTC {
+		t.Errorf("Location() without timezone = %s, want UTC", loc)
+	}
+	if loc := (&WorkspaceSettings{Timezone: "Europe/Madrid"}).Location(); loc.String() != "Europe/Madrid" {
+		t.Errorf("Location() = %s, want Europe/Madrid", loc)
+	}
+}
+
+func TestWorkspaceSettings_Apply(t *testing.T) {
+	s := WorkspaceSettings{Timezone: "Europe/Madrid", RenderOptions: &WorkspaceRenderOptions{Watermark: "DRAFT"}}
+
+	s.Apply(&WorkspaceSettings{RenderOptions: &WorkspaceRenderOptions{Degraded: true}})
+
+	if s.Timezone != "Europe/Madrid" {
+		t.Errorf("Apply() changed a section not set in changes: timezone = %q", s.Timezone)
+	}
+	if s.RenderOptions == nil || !s.RenderOptions.Degraded || s.RenderOptions.Watermark != "" {
+		t.Errorf("Apply() renderOptions = %+v, want the section from changes", s.RenderOptions)
+	}
+	if !(&WorkspaceSettings{}).IsEmpty() || s.IsEmpty() {
+		t.Error("IsEmpty() does not match the sections set")
+	}
+}
@@ -0,0 +1,162 @@
+package formatter
+
+import (
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// AddressPrefix starts the format patterns of addresses.
+// Pattern format: "address[:<layout>]", where the layout is "domestic" (without the country line)
+// or "oneline" (all lines joined by commas). "address" alone lays out an address for international
+// mail, with the country in capitals on the last line.
+const AddressPrefix = "address"
+
+// addressLayout lays out the addresses of one country. Each line is a template of fields: %N name,
+// %O organization, %A street lines, %D district, %C city, %S region and %Z postal code.
+type addressLayout struct {
+	lines []string
+	upper string // fields written in capitals, such as the post town in the UK
+}
+
+var (
+	layoutPostcodeFirst = addressLayout{lines: []string{"%N", "%O", "%A", "%Z %C"}}
+	layoutRegionLast    = addressLayout{lines: []string{"%N", "%O", "%A", "%D", "%Z %C", "%S"}}
+	layoutNorthAmerica  = addressLayout{lines: []string{"%N", "%O", "%A", "%C %S %Z"}}
+)
+
+// addressLayouts are the layouts of the countries with conventions of their own, keyed by ISO
+// 3166-1 alpha-2 code. Other countries take defaultAddressLayout.
+var addressLayouts = map[string]addressLayout{
+	"US": {lines: []string{"%N", "%O", "%A", "%C, %S %Z"}},
+	"CA": layoutNorthAmerica,
+	"AU": layoutNorthAmerica,
+	"NZ": {lines: []string{"%N", "%O", "%A", "%D", "%C %Z"}},
+	"GB": {lines: []string{"%N", "%O", "%A", "%D", "%C", "%Z"}, upper: "C"},
+	"IE": {lines: []string{"%N", "%O", "%A", "%D", "%C", "%S", "%Z"}},
+	"IN": {lines: []string{"%N", "%O", "%A", "%D", "%C %Z", "%S"}},
+	"DE": layoutPostcodeFirst,
+	"AT": layoutPostcodeFirst,
+	"CH": layoutPostcodeFirst,
+	"NL": layoutPostcodeFirst,
+	"BE": layoutPostcodeFirst,
+	"DK": layoutPostcodeFirst,
+	"NO": layoutPostcodeFirst,
+	"SE": layoutPostcodeFirst,
+	"FI": layoutPostcodeFirst,
+	"PL": layoutPostcodeFirst,
+	"PT": layoutPostcodeFirst,
+	"FR": {lines: []string{"%N", "%O", "%A", "%Z %C"}, upper: "C"},
+	"IT": {lines: []string{"%N", "%O", "%A", "%Z %C %S"}},
+	"ES": {lines: []string{"%N", "%O", "%A", "%Z %C", "%S"}},
+	"BR": {lines: []string{"%N", "%O", "%A", "%D", "%C-%S", "%Z"}},
+	"MX": {lines: []string{"%N", "%O", "%A", "%D", "%Z %C, %S"}},
+	"CO": {lines: []string{"%N", "%O", "%A", "%D", "%C, %S, %Z"}},
+	"AR": layoutRegionLast,
+	"CL": layoutRegionLast,
+}
+
+var defaultAddressLayout = layoutRegionLast
+
+// countryNames are the English names printed on the country line of international mail.
+// Codes without a name are printed as they are.
+var countryNames = map[string]string{
+	"AR": "Argentina", "AT": "Austria", "AU": "Australia", "BE": "Belgium", "BR": "Brazil",
+	"CA": "Canada", "CH": "Switzerland", "CL": "Chile", "CN": "China", "CO": "Colombia",
+	"DE": "Germany", "DK": "Denmark", "ES": "Spain", "FI": "Finland", "FR": "France",
+	"GB": "United Kingdom", "IE": "Ireland", "IN": "India", "IT": "Italy", "JP": "Japan",
+	"MX": "Mexico", "NL": "Netherlands", "NO": "Norway", "NZ": "New Zealand", "PE": "Peru",
+	"PL": "Poland", "PT": "Portugal", "SE": "Sweden", "US": "United States", "UY": "Uruguay",
+}
+
+// IsAddressPattern reports whether the format pattern is an address layout.
+func IsAddressPattern(pattern string) bool {
+	return pattern == AddressPrefix || strings.HasPrefix(pattern, AddressPrefix+":")
+}
+
+// FormatAddress lays an address out by the conventions of its country and the layout of the
+// pattern (see AddressPrefix), returning its lines. Lines left empty by missing fields are dropped.
+func FormatAddress(a *entity.AddressValue, pattern string) []string {
+	if a == nil {
+		return nil
+	}
+	layout := strings.TrimPrefix(strings.TrimPrefix(pattern, AddressPrefix), ":")
+	lines := AddressLines(a)
+	if country := strings.ToUpper(strings.TrimSpace(a.Country)); country != "" && layout != "domestic" {
+		if name, ok := countryNames[country]; ok {
+			country = strings.ToUpper(name)
+		}
+		lines = append(lines, country)
+	}
+	if layout == "oneline" && len(lines) > 0 {
+		return []string{strings.Join(lines, ", ")}
+	}
+	return lines
+}
+
+// AddressLines returns the lines of an address in the layout of its country, without the country.
+func AddressLines(a *entity.AddressValue) []string {
+	layout, ok := addressLayouts[strings.ToUpper(strings.TrimSpace(a.Country))]
+	if !ok {
+		layout = defaultAddressLayout
+	}
+	fields := map[byte]string{
+		'N': a.Name,
+		'O': a.Organization,
+		'D': a.District,
+		'C': a.City,
+		'S': a.Region,
+		'Z': a.PostalCode,
+	}
+	for field, value := range fields {
+		value = strings.TrimSpace(value)
+		if strings.IndexByte(layout.upper, field) >= 0 {
+			value = strings.ToUpper(value)
+		}
+		fields[field] = value
+	}
+
+	var lines []string
+	for _, tmpl := range layout.lines {
+		if tmpl == "%A" {
+			for _, line := range a.Lines {
+				if line = strings.TrimSpace(line); line != "" {
+					lines = append(lines, line)
+				}
+			}
+			continue
+		}
+		if line := fillAddressLine(tmpl, fields); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// fillAddressLine replaces the fields of a line template. The text between two fields is kept
+// only when both are written; an empty field keeps the separator before it for the next one,
+// so "%C, %S %Z" without a region writes "Springfield, 62701".
+func fillAddressLine(tmpl string, fields map[byte]string) string {
+	var sb strings.Builder
+	sep, skip := "", false
+	for i := 0; i < len(tmpl); i++ {
+		if tmpl[i] != '%' || i+1 == len(tmpl) {
+			if !skip {
+				sep += string(tmpl[i])
+			}
+			continue
+		}
+		i++
+		value := fields[tmpl[i]]
+		if value == "" {
+			skip = true
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteString(sep)
+		}
+		sb.WriteString(value)
+		sep, skip = "", false
+	}
+	return sb.String()
+}
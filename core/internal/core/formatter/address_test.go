@@ -0,0 +1,87 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+func TestFormatAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		address entity.AddressValue
+		pattern string
+		want    string
+	}{
+		{
+			name:    "united states",
+			address: entity.AddressValue{Name: "Jane Doe", Lines: []string{"1600 Amphitheatre Pkwy"}, City: "Mountain View", Region: "CA", PostalCode: "94043", Country: "US"},
+			pattern: "address",
+			want:    "Jane Doe|1600 Amphitheatre Pkwy|Mountain View, CA 94043|UNITED STATES",
+		},
+		{
+			name:    "united states without region",
+			address: entity.AddressValue{City: "Springfield", PostalCode: "62701", Country: "us"},
+			pattern: "address:domestic",
+			want:    "Springfield, 62701",
+		},
+		{
+			name:    "united kingdom post town and postcode lines",
+			address: entity.AddressValue{Organization: "Acme Ltd", Lines: []string{"10 Downing Street"}, City: "London", PostalCode: "SW1A 2AA", Country: "GB"},
+			pattern: "address",
+			want:    "Acme Ltd|10 Downing Street|LONDON|SW1A 2AA|UNITED KINGDOM",
+		},
+		{
+			name:    "germany postcode before city",
+			address: entity.AddressValue{Name: "Max Mustermann", Lines: []string{"Musterstraße 12"}, City: "Berlin", PostalCode: "10115", Region: "Berlin", Country: "DE"},
+			pattern: "address:domestic",
+			want:    "Max Mustermann|Musterstraße 12|10115 Berlin",
+		},
+		{
+			name:    "brazil district and city-state",
+			address: entity.AddressValue{Lines: []string{"Av. Paulista, 1578"}, District: "Bela Vista", City: "São Paulo", Region: "SP", PostalCode: "01310-200", Country: "BR"},
+			pattern: "address:domestic",
+			want:    "Av. Paulista, 1578|Bela Vista|São Paulo-SP|01310-200",
+		},
+		{
+			name:    "chile region last",
+			address: entity.AddressValue{Lines: []string{"Av. Libertador Bernardo O'Higgins 1449", "Piso 5"}, City: "Santiago", Region: "Región Metropolitana", PostalCode: "8320000", Country: "CL"},
+			pattern: "address",
+			want:    "Av. Libertador Bernardo O'Higgins 1449|Piso 5|8320000 Santiago|Región Metropolitana|CHILE",
+		},
+		{
+			name:    "unknown country uses the default layout and its code",
+			address: entity.AddressValue{Lines: []string{"Calle 1"}, City: "Asunción", PostalCode: "1209", Country: "PY"},
+			pattern: "address",
+			want:    "Calle 1|1209 Asunción|PY",
+		},
+		{
+			name:    "one line",
+			address: entity.AddressValue{Lines: []string{"12 rue de la Paix"}, City: "Paris", PostalCode: "75002", Country: "FR"},
+			pattern: "address:oneline",
+			want:    "12 rue de la Paix, 75002 PARIS, FRANCE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(FormatAddress(&tt.address, tt.pattern), "|")
+			if got != tt.want {
+				t.Errorf("FormatAddress() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsAddressPattern(t *testing.T) {
+	for pattern, want := range map[string]bool{
+		"address":          true,
+		"address:domestic": true,
+		"addresses":        false,
+		"words:en":         false,
+	} {
+		if got := IsAddressPattern(pattern); got != want {
+			t.Errorf("IsAddressPattern(%q) = %v, want %v", pattern, got, want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package formatter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// Units of date offsets.
+const (
+	OffsetDays        = "d"
+	OffsetWorkingDays = "wd"
+	OffsetWeeks       = "w"
+	OffsetMonths      = "m"
+	OffsetYears       = "y"
+)
+
+// DateOffset moves a date by a number of calendar days, working days, weeks, months or years.
+// Written as "+30d", "-2w", "+10wd", "+1m" or "+1y".
+type DateOffset struct {
+	N    int
+	Unit string
+}
+
+// ParseDateOffset parses an offset such as "+30d" or "-10wd". The sign is optional.
+func ParseDateOffset(offset string) (DateOffset, error) {
+	s := strings.ToLower(strings.TrimSpace(offset))
+	digits := strings.TrimLeft(s, "+-")
+	end := strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' })
+	if end <= 0 || len(s)-len(digits) > 1 {
+		return DateOffset{}, fmt.Errorf("invalid date offset %q: want a number and d, wd, w, m or y, such as +30d", offset)
+	}
+	n, err := strconv.Atoi(digits[:end])
+	if err != nil {
+		return DateOffset{}, fmt.Errorf("invalid date offset %q: %w", offset, err)
+	}
+	if strings.HasPrefix(s, "-") {
+		n = -n
+	}
+	switch unit := digits[end:]; unit {
+	case OffsetDays, OffsetWorkingDays, OffsetWeeks, OffsetMonths, OffsetYears:
+		return DateOffset{N: n, Unit: unit}, nil
+	default:
+		return DateOffset{}, fmt.Errorf("invalid date offset %q: unknown unit %q, want d, wd, w, m or y", offset, unit)
+	}
+}
+
+// Apply returns t moved by the offset. Working days are counted with cal. Months and years keep
+// the day of the month, or take the last day of shorter months: 31 January plus one month is
+// 29 February in a leap year.
+func (o DateOffset) Apply(t time.Time, cal entity.WorkCalendar) time.Time {
+	switch o.Unit {
+	case OffsetWorkingDays:
+		return cal.AddWorkingDays(t, o.N)
+	case OffsetWeeks:
+		return t.AddDate(0, 0, 7*o.N)
+	case OffsetMonths:
+		return addMonths(t, o.N)
+	case OffsetYears:
+		return addMonths(t, 12*o.N)
+	default:
+		return t.AddDate(0, 0, o.N)
+	}
+}
+
+// ShiftDate returns t moved by an offset such as "+30d" (see ParseDateOffset), counting working
+// days with cal. Injectors use it for dates relative to another, such as a due date 30 days
+// after the issue date.
+func ShiftDate(t time.Time, offset string, cal entity.WorkCalendar) (time.Time, error) {
+	o, err := ParseDateOffset(offset)
+	if err != nil {
+		return t, err
+	}
+	return o.Apply(t, cal), nil
+}
+
+// addMonths adds n months to t, clamping the day to the length of the target month.
+func addMonths(t time.Time, n int) time.Time {
+	y, m, d := t.Date()
+	first := time.Date(y, m+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); d > last {
+		d = last
+	}
+	return first.AddDate(0, 0, d-1)
+}
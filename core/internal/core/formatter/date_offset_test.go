@@ -0,0 +1,43 @@
+package formatter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+func TestShiftDate(t *testing.T) {
+	issued := time.Date(2024, time.January, 31, 10, 0, 0, 0, time.UTC)
+	cal := entity.WorkCalendar{Holidays: []time.Time{time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)}}
+
+	tests := []struct {
+		offset string
+		want   string
+	}{
+		{"+30d", "2024-03-01"},
+		{"30d", "2024-03-01"},
+		{"-1w", "2024-01-24"},
+		{"+1m", "2024-02-29"},
+		{"+13m", "2025-02-28"},
+		{"+1y", "2025-01-31"},
+		{"+2wd", "2024-02-05"},
+		{"-2WD", "2024-01-29"},
+	}
+	for _, tt := range tests {
+		got, err := ShiftDate(issued, tt.offset, cal)
+		if err != nil {
+			t.Errorf("ShiftDate(%q): %v", tt.offset, err)
+			continue
+		}
+		if got.Format(time.DateOnly) != tt.want || got.Hour() != 10 {
+			t.Errorf("ShiftDate(%q) = %s, want %s at 10:00", tt.offset, got, tt.want)
+		}
+	}
+
+	for _, offset := range []string{"", "30", "+d", "+-3d", "+3h", "three days"} {
+		if _, err := ParseDateOffset(offset); err == nil {
+			t.Errorf("ParseDateOffset(%q) succeeded, want an error", offset)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package formatter
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MaskPrefix starts the format patterns that mask a value instead of formatting it.
+// Pattern format: "mask:<kind>[:<start>,<end>][:<region>]", e.g. "mask:card", "mask:iban:2,4",
+// "mask:national_id:CL". Kinds are iban, card, national_id and email.
+const MaskPrefix = "mask:"
+
+// Mask kinds used in mask patterns.
+const (
+	MaskKindIBAN       = "iban"
+	MaskKindCard       = "card"
+	MaskKindNationalID = "national_id"
+	MaskKindEmail      = "email"
+)
+
+// DefaultMaskChar replaces the hidden characters when MaskOptions.Char is not set.
+const DefaultMaskChar = '*'
+
+// MaskOptions sets how many characters a mask leaves visible. Only significant characters count:
+// letters and digits, or the local part of an email. When Start and End together would show the
+// whole value, everything is masked.
+type MaskOptions struct {
+	Start int  // characters visible at the start
+	End   int  // characters visible at the end
+	Char  rune // replaces hidden characters; DefaultMaskChar when zero
+}
+
+// Default visible characters of each kind.
+var (
+	// IBANMask keeps the country code, check digits and the last four characters.
+	IBANMask = MaskOptions{Start: 4, End: 4}
+	// CardMask keeps the last four digits.
+	CardMask = MaskOptions{End: 4}
+	// NationalIDMask keeps the last four characters.
+	NationalIDMask = MaskOptions{End: 4}
+	// EmailMask keeps the first character of the local part and the domain.
+	EmailMask = MaskOptions{Start: 1}
+)
+
+// nationalIDLayouts are the printed forms of national IDs by region. # is a significant character.
+// Chile is handled by FormatRUT because its body has seven or eight digits.
+var nationalIDLayouts = map[string]string{
+	"AR": "##.###.###",         // DNI
+	"BR": "###.###.###-##",     // CPF
+	"ES": "#########",          // DNI and NIE
+	"MX": "##################", // CURP
+	"US": "###-##-####",        // SSN
+}
+
+// IsMaskPattern reports whether pattern masks the value.
+func IsMaskPattern(pattern string) bool {
+	return strings.HasPrefix(pattern, MaskPrefix)
+}
+
+// Mask masks value according to a mask pattern. locale is used for national IDs when the pattern
+// sets no region (e.g. "es-CL"). An unknown kind masks every letter and digit, so a mistyped
+// pattern never shows the value.
+func Mask(value, pattern, locale string) string {
+	kind, opts, region := parseMaskPattern(pattern)
+	switch kind {
+	case MaskKindIBAN:
+		return MaskIBAN(value, opts.or(IBANMask))
+	case MaskKindCard:
+		return MaskCard(value, opts.or(CardMask))
+	case MaskKindNationalID:
+		if region == "" {
+			region = locale
+		}
+		return MaskNationalID(value, region, opts.or(NationalIDMask))
+	case MaskKindEmail:
+		return MaskEmail(value, opts.or(EmailMask))
+	default:
+		return maskAlphanumerics(value, MaskOptions{})
+	}
+}
+
+// MaskIBAN masks an IBAN and prints it in groups of four, e.g. "DE89 **** **** **** **30 00".
+func MaskIBAN(iban string, opts MaskOptions) string {
+	chars := maskRunes(significantRunes(strings.ToUpper(iban)), opts)
+
+	var sb strings.Builder
+	for i, r := range chars {
+		if i > 0 && i%4 == 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// MaskCard masks a payment card number and prints it in groups of four, or 4-6-5 for American
+// Express, e.g. "**** **** **** 4242".
+func MaskCard(number string, opts MaskOptions) string {
+	digits := []rune(extractDigits(number))
+	layout := strings.Repeat("#", len(digits))
+	switch {
+	case len(digits) == 15 && (strings.HasPrefix(string(digits), "34") || strings.HasPrefix(string(digits), "37")):
+		layout = "#### ###### #####"
+	case len(digits) > 4:
+		var sb strings.Builder
+		for i := range digits {
+			if i > 0 && i%4 == 0 {
+				sb.WriteByte(' ')
+			}
+			sb.WriteByte('#')
+		}
+		layout = sb.String()
+	}
+	return applyLayout(maskRunes(digits, opts), layout)
+}
+
+// MaskNationalID masks a national ID printed the way the region of locale writes it, e.g.
+// "**.***.678-9" for a Chilean RUT or "***-**-6789" for a US SSN. locale may be a region ("CL")
+// or a language tag ("es-CL"). IDs of other regions, or that do not fit the region layout, keep
+// their separators.
+func MaskNationalID(id, locale string, opts MaskOptions) string {
+	chars := significantRunes(strings.ToUpper(id))
+	switch region := localeRegion(locale); {
+	case region == "CL" && len(chars) >= 8 && len(chars) <= 9:
+		return FormatRUT(string(maskRunes(chars, opts)), "##.###.###-#")
+	case len(chars) == strings.Count(nationalIDLayouts[region], "#"):
+		return applyLayout(maskRunes(chars, opts), nationalIDLayouts[region])
+	default:
+		return maskAlphanumerics(id, opts)
+	}
+}
+
+// MaskEmail masks the local part of an email and keeps the domain, e.g. "j*******@example.com".
+func MaskEmail(email string, opts MaskOptions) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return maskAlphanumerics(email, MaskOptions{Char: opts.Char})
+	}
+	return string(maskRunes([]rune(email[:at]), opts)) + email[at:]
+}
+
+// parseMaskPattern returns the kind, visible characters and region of a mask pattern. opts is
+// nil when the pattern sets no visible characters.
+func parseMaskPattern(pattern string) (kind string, opts *MaskOptions, region string) {
+	fields := strings.Split(strings.TrimPrefix(pattern, MaskPrefix), ":")
+	kind = strings.ToLower(strings.TrimSpace(fields[0]))
+	for _, field := range fields[1:] {
+		field = strings.TrimSpace(field)
+		start, end, ok := strings.Cut(field, ",")
+		if !ok {
+			region = field
+			continue
+		}
+		s, errStart := strconv.Atoi(strings.TrimSpace(start))
+		e, errEnd := strconv.Atoi(strings.TrimSpace(end))
+		if errStart == nil && errEnd == nil && s >= 0 && e >= 0 {
+			opts = &MaskOptions{Start: s, End: e}
+		}
+	}
+	return kind, opts, region
+}
+
+// or returns the options, or def when none were set.
+func (o *MaskOptions) or(def MaskOptions) MaskOptions {
+	if o == nil {
+		return def
+	}
+	return *o
+}
+
+// maskRunes replaces the characters of chars outside the visible start and end.
+func maskRunes(chars []rune, opts MaskOptions) []rune {
+	char := opts.Char
+	if char == 0 {
+		char = DefaultMaskChar
+	}
+	start, end := max(opts.Start, 0), max(opts.End, 0)
+	if start+end >= len(chars) {
+		start, end = 0, 0
+	}
+
+	masked := make([]rune, len(chars))
+	for i, r := range chars {
+		if i < start || i >= len(chars)-end {
+			masked[i] = r
+		} else {
+			masked[i] = char
+		}
+	}
+	return masked
+}
+
+// maskAlphanumerics masks the letters and digits of s, counting visible characters among them,
+// and keeps every other character.
+func maskAlphanumerics(s string, opts MaskOptions) string {
+	masked := maskRunes(significantRunes(s), opts)
+	result := []rune(s)
+	j := 0
+	for i, r := range result {
+		if isSignificant(r) {
+			result[i] = masked[j]
+			j++
+		}
+	}
+	return string(result)
+}
+
+// applyLayout writes chars into the # placeholders of layout.
+func applyLayout(chars []rune, layout string) string {
+	var sb strings.Builder
+	i := 0
+	for _, c := range layout {
+		if c != '#' {
+			sb.WriteRune(c)
+			continue
+		}
+		if i < len(chars) {
+			sb.WriteRune(chars[i])
+			i++
+		}
+	}
+	return sb.String()
+}
+
+// significantRunes returns the letters and digits of s.
+func significantRunes(s string) []rune {
+	var chars []rune
+	for _, r := range s {
+		if isSignificant(r) {
+			chars = append(chars, r)
+		}
+	}
+	return chars
+}
+
+func isSignificant(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// localeRegion returns the upper-case region of a locale: "CL" for "es-CL", "es_CL" or "CL".
+// A language alone ("es") has no region.
+func localeRegion(locale string) string {
+	locale = strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+	if i := strings.LastIndex(locale, "-"); i >= 0 {
+		locale = locale[i+1:]
+	} else if locale != strings.ToUpper(locale) {
+		return ""
+	}
+	return strings.ToUpper(locale)
+}
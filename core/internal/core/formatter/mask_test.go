@@ -0,0 +1,54 @@
+package formatter
+
+import "testing"
+
+func TestMask(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		pattern string
+		locale  string
+		want    string
+	}{
+		{"iban", "DE89 3704 0044 0532 0130 00", "mask:iban", "", "DE89 **** **** **** **30 00"},
+		{"iban compact lower case", "gb29nwbk60161331926819", "mask:iban:2,4", "", "GB** **** **** **** **68 19"},
+		{"card", "4242-4242-4242-4242", "mask:card", "", "**** **** **** 4242"},
+		{"card first six", "4242424242424242", "mask:card:6,4", "", "4242 42** **** 4242"},
+		{"amex", "378282246310005", "mask:card", "", "**** ****** *0005"},
+		{"chilean rut", "12.345.678-9", "mask:national_id:CL", "", "**.***.678-9"},
+		{"chilean rut short body", "9876543-k", "mask:national_id", "es-CL", "*.***.543-K"},
+		{"us ssn from locale", "123456789", "mask:national_id", "en_US", "***-**-6789"},
+		{"brazilian cpf", "123.456.789-09", "mask:national_id:BR", "", "***.***.*89-09"},
+		{"spanish dni", "12345678z", "mask:national_id:ES", "", "*****678Z"},
+		{"region without layout", "AB-123456", "mask:national_id:FR", "", "**-**3456"},
+		{"layout mismatch", "1234-5", "mask:national_id:US", "", "*234-5"},
+		{"language only", "12.345.678-9", "mask:national_id", "es", "**.***.678-9"},
+		{"email", "jane.doe@example.com", "mask:email", "", "j*******@example.com"},
+		{"email visible end", "jane.doe@example.com", "mask:email:2,1", "", "ja*****e@example.com"},
+		{"short email", "j@example.com", "mask:email", "", "*@example.com"},
+		{"not an email", "jane.doe", "mask:email", "", "****.***"},
+		{"visible exceeds length", "1234", "mask:card:2,2", "", "****"},
+		{"unknown kind", "secret-42", "mask:phone", "", "******-**"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Mask(tt.value, tt.pattern, tt.locale); got != tt.want {
+				t.Errorf("Mask(%q, %q, %q) = %q, want %q", tt.value, tt.pattern, tt.locale, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaskCustomChar(t *testing.T) {
+	got := MaskEmail("jane@example.com", MaskOptions{Start: 1, Char: '•'})
+	if want := "j•••@example.com"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestIsMaskPattern(t *testing.T) {
+	if !IsMaskPattern("mask:iban") || IsMaskPattern("#,##0.00") || IsMaskPattern("") {
+		t.Error("IsMaskPattern only matches patterns starting with mask:")
+	}
+}
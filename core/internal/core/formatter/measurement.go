@@ -0,0 +1,242 @@
+package formatter
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+// MeasurePrefix starts the format patterns of measurements.
+// Pattern format: "measure[:<target>[:<style>[:<decimals>]]]". The target is "auto" (the default:
+// the unit system of the template or render request, keeping the unit when there is none),
+// "metric", "imperial", "keep" or a unit code such as "cm". The style is "symbol" (the default:
+// "1.8 m") or "name" ("1.8 meters"), and decimals is the most decimals written (default 2;
+// trailing zeros are dropped). Converting to a system picks the unit of that system that reads
+// naturally: 1.8 m is 5.91 ft, 30 cm is 11.81 in.
+const MeasurePrefix = "measure"
+
+// Measurement dimensions. Only units of the same dimension convert to each other.
+const (
+	dimensionLength      = "length"
+	dimensionMass        = "mass"
+	dimensionVolume      = "volume"
+	dimensionArea        = "area"
+	dimensionSpeed       = "speed"
+	dimensionTemperature = "temperature"
+)
+
+// measureUnit is a unit of the catalog. A value v of the unit is v*factor+offset base units of
+// its dimension: meters, kilograms, liters, square meters, meters per second or degrees Celsius.
+type measureUnit struct {
+	dimension string
+	system    string
+	factor    float64
+	offset    float64
+	symbol    string
+	names     map[string][2]string // singular and plural per language
+}
+
+var measureUnits = map[string]*measureUnit{
+	"mm": {dimensionLength, portabledoc.UnitSystemMetric, 0.001, 0, "mm", unitNames("millimeter", "milímetro")},
+	"cm": {dimensionLength, portabledoc.UnitSystemMetric, 0.01, 0, "cm", unitNames("centimeter", "centímetro")},
+	"m":  {dimensionLength, portabledoc.UnitSystemMetric, 1, 0, "m", unitNames("meter", "metro")},
+	"km": {dimensionLength, portabledoc.UnitSystemMetric, 1000, 0, "km", unitNames("kilometer", "kilómetro")},
+	"in": {dimensionLength, portabledoc.UnitSystemImperial, 0.0254, 0, "in", map[string][2]string{"en": {"inch", "inches"}, "es": {"pulgada", "pulgadas"}}},
+	"ft": {dimensionLength, portabledoc.UnitSystemImperial, 0.3048, 0, "ft", map[string][2]string{"en": {"foot", "feet"}, "es": {"pie", "pies"}}},
+	"yd": {dimensionLength, portabledoc.UnitSystemImperial, 0.9144, 0, "yd", unitNames("yard", "yarda")},
+	"mi": {dimensionLength, portabledoc.UnitSystemImperial, 1609.344, 0, "mi", unitNames("mile", "milla")},
+
+	"mg": {dimensionMass, portabledoc.UnitSystemMetric, 0.000001, 0, "mg", unitNames("milligram", "miligramo")},
+	"g":  {dimensionMass, portabledoc.UnitSystemMetric, 0.001, 0, "g", unitNames("gram", "gramo")},
+	"kg": {dimensionMass, portabledoc.UnitSystemMetric, 1, 0, "kg", unitNames("kilogram", "kilogramo")},
+	"t":  {dimensionMass, portabledoc.UnitSystemMetric, 1000, 0, "t", unitNames("tonne", "tonelada")},
+	"oz": {dimensionMass, portabledoc.UnitSystemImperial, 0.028349523125, 0, "oz", unitNames("ounce", "onza")},
+	"lb": {dimensionMass, portabledoc.UnitSystemImperial, 0.45359237, 0, "lb", unitNames("pound", "libra")},
+
+	"ml":    {dimensionVolume, portabledoc.UnitSystemMetric, 0.001, 0, "ml", unitNames("milliliter", "mililitro")},
+	"l":     {dimensionVolume, portabledoc.UnitSystemMetric, 1, 0, "l", unitNames("liter", "litro")},
+	"fl_oz": {dimensionVolume, portabledoc.UnitSystemImperial, 0.0295735295625, 0, "fl oz", map[string][2]string{"en": {"fluid ounce", "fluid ounces"}, "es": {"onza líquida", "onzas líquidas"}}},
+	"gal":   {dimensionVolume, portabledoc.UnitSystemImperial, 3.785411784, 0, "gal", map[string][2]string{"en": {"gallon", "gallons"}, "es": {"galón", "galones"}}},
+
+	"cm2":  {dimensionArea, portabledoc.UnitSystemMetric, 0.0001, 0, "cm²", squareNames("centimeter", "centímetro")},
+	"m2":   {dimensionArea, portabledoc.UnitSystemMetric, 1, 0, "m²", squareNames("meter", "metro")},
+	"ha":   {dimensionArea, portabledoc.UnitSystemMetric, 10000, 0, "ha", unitNames("hectare", "hectárea")},
+	"km2":  {dimensionArea, portabledoc.UnitSystemMetric, 1000000, 0, "km²", squareNames("kilometer", "kilómetro")},
+	"in2":  {dimensionArea, portabledoc.UnitSystemImperial, 0.00064516, 0, "in²", map[string][2]string{"en": {"square inch", "square inches"}, "es": {"pulgada cuadrada", "pulgadas cuadradas"}}},
+	"ft2":  {dimensionArea, portabledoc.UnitSystemImperial, 0.09290304, 0, "ft²", map[string][2]string{"en": {"square foot", "square feet"}, "es": {"pie cuadrado", "pies cuadrados"}}},
+	"acre": {dimensionArea, portabledoc.UnitSystemImperial, 4046.8564224, 0, "ac", unitNames("acre", "acre")},
+
+	"m/s":  {dimensionSpeed, portabledoc.UnitSystemMetric, 1, 0, "m/s", map[string][2]string{"en": {"meter per second", "meters per second"}, "es": {"metro por segundo", "metros por segundo"}}},
+	"km/h": {dimensionSpeed, portabledoc.UnitSystemMetric, 1000.0 / 3600, 0, "km/h", map[string][2]string{"en": {"kilometer per hour", "kilometers per hour"}, "es": {"kilómetro por hora", "kilómetros por hora"}}},
+	"mph":  {dimensionSpeed, portabledoc.UnitSystemImperial, 0.44704, 0, "mph", map[string][2]string{"en": {"mile per hour", "miles per hour"}, "es": {"milla por hora", "millas por hora"}}},
+
+	"C": {dimensionTemperature, portabledoc.UnitSystemMetric, 1, 0, "°C", map[string][2]string{"en": {"degree Celsius", "degrees Celsius"}, "es": {"grado Celsius", "grados Celsius"}}},
+	"K": {dimensionTemperature, portabledoc.UnitSystemMetric, 1, -273.15, "K", map[string][2]string{"en": {"kelvin", "kelvins"}, "es": {"kelvin", "kelvin"}}},
+	"F": {dimensionTemperature, portabledoc.UnitSystemImperial, 5.0 / 9, -160.0 / 9, "°F", map[string][2]string{"en": {"degree Fahrenheit", "degrees Fahrenheit"}, "es": {"grado Fahrenheit", "grados Fahrenheit"}}},
+}
+
+// measureUnitAliases are the other spellings of unit codes, after lower-casing.
+var measureUnitAliases = map[string]string{
+	"c": "C", "°c": "C", "celsius": "C",
+	"f": "F", "°f": "F", "fahrenheit": "F",
+	"k": "K", "kelvin": "K",
+	"cm²": "cm2", "m²": "m2", "km²": "km2", "in²": "in2", "ft²": "ft2", "ac": "acre",
+	"fl oz": "fl_oz", "floz": "fl_oz",
+	"kmh": "km/h", "kph": "km/h", "mps": "m/s",
+	"lbs": "lb", "tonne": "t",
+}
+
+// measureLadders are the units a conversion to a system may land on, smallest first. A converted
+// value takes the largest unit it is at least one of.
+var measureLadders = map[string]map[string][]string{
+	portabledoc.UnitSystemMetric: {
+		dimensionLength:      {"mm", "cm", "m", "km"},
+		dimensionMass:        {"g", "kg", "t"},
+		dimensionVolume:      {"ml", "l"},
+		dimensionArea:        {"cm2", "m2", "km2"},
+		dimensionSpeed:       {"km/h"},
+		dimensionTemperature: {"C"},
+	},
+	portabledoc.UnitSystemImperial: {
+		dimensionLength:      {"in", "ft", "mi"},
+		dimensionMass:        {"oz", "lb"},
+		dimensionVolume:      {"fl_oz", "gal"},
+		dimensionArea:        {"in2", "ft2", "acre"},
+		dimensionSpeed:       {"mph"},
+		dimensionTemperature: {"F"},
+	},
+}
+
+// defaultMeasureDecimals is the most decimals a measurement is written with.
+const defaultMeasureDecimals = 2
+
+// IsMeasurePattern reports whether the format pattern is a measurement form.
+func IsMeasurePattern(pattern string) bool {
+	return pattern == MeasurePrefix || strings.HasPrefix(pattern, MeasurePrefix+":")
+}
+
+// FormatMeasurement writes a measurement in the form of the pattern (see MeasurePrefix). system
+// is the unit system of the template or render request ("metric", "imperial" or "" to keep
+// units) and language, "en" or "es", selects the decimal separator and the unit names.
+func FormatMeasurement(m *entity.MeasurementValue, pattern, system, language string) string {
+	if m == nil {
+		return ""
+	}
+	parts := strings.SplitN(strings.TrimPrefix(strings.TrimPrefix(pattern, MeasurePrefix), ":"), ":", 3)
+	target, style := parts[0], ""
+	decimals := defaultMeasureDecimals
+	if len(parts) > 1 {
+		style = parts[1]
+	}
+	if len(parts) > 2 {
+		if d, err := strconv.Atoi(parts[2]); err == nil && d >= 0 && d <= 6 {
+			decimals = d
+		}
+	}
+	if target == "" || target == "auto" {
+		target = system
+	}
+
+	lang := measureLanguageOf(language)
+	code, ok := measureUnitCode(m.Unit)
+	if !ok {
+		return joinNonEmpty(" ", formatMeasureNumber(m.Value, decimals, lang), m.Unit)
+	}
+	value, code := convertMeasurement(m.Value, code, target)
+
+	unit := measureUnits[code]
+	number := formatMeasureNumber(value, decimals, lang)
+	if style == "name" {
+		names := unit.names[lang]
+		if number == formatMeasureNumber(1, decimals, lang) {
+			return number + " " + names[0]
+		}
+		return number + " " + names[1]
+	}
+	return number + " " + unit.symbol
+}
+
+// convertMeasurement converts a value of the unit code to the target and returns the unit it
+// landed on. Units already in the target system keep their unit.
+func convertMeasurement(value float64, code, target string) (float64, string) {
+	unit := measureUnits[code]
+	if toCode, ok := measureUnitCode(target); ok {
+		to := measureUnits[toCode]
+		if to.dimension != unit.dimension {
+			return value, code
+		}
+		return (value*unit.factor + unit.offset - to.offset) / to.factor, toCode
+	}
+
+	ladder := measureLadders[target][unit.dimension]
+	if len(ladder) == 0 || unit.system == target {
+		return value, code
+	}
+	base := value*unit.factor + unit.offset
+	toCode := ladder[0]
+	for _, candidate := range ladder[1:] {
+		to := measureUnits[candidate]
+		if math.Abs((base-to.offset)/to.factor) < 1 {
+			break
+		}
+		toCode = candidate
+	}
+	to := measureUnits[toCode]
+	return (base - to.offset) / to.factor, toCode
+}
+
+// measureUnitCode returns the catalog code of a unit code or one of its aliases.
+func measureUnitCode(unit string) (string, bool) {
+	unit = strings.TrimSpace(unit)
+	if _, ok := measureUnits[unit]; ok {
+		return unit, true
+	}
+	lower := strings.ToLower(unit)
+	if _, ok := measureUnits[lower]; ok {
+		return lower, true
+	}
+	code, ok := measureUnitAliases[lower]
+	return code, ok
+}
+
+// measureLanguageOf returns the measurement language of a language or locale; languages other
+// than Spanish use English.
+func measureLanguageOf(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if language == "es" || strings.HasPrefix(language, "es-") || strings.HasPrefix(language, "es_") {
+		return "es"
+	}
+	return "en"
+}
+
+// formatMeasureNumber writes a number with at most decimals decimals and thousands separators,
+// using a decimal comma in Spanish.
+func formatMeasureNumber(n float64, decimals int, language string) string {
+	formatted := formatNumberValue(n, decimals, true)
+	if strings.Contains(formatted, ".") {
+		formatted = strings.TrimRight(strings.TrimRight(formatted, "0"), ".")
+	}
+	if formatted == "-0" {
+		formatted = "0"
+	}
+	if language == "es" {
+		formatted = strings.NewReplacer(",", ".", ".", ",").Replace(formatted)
+	}
+	return formatted
+}
+
+// unitNames returns the names of a unit whose plural adds an "s" in English and Spanish.
+func unitNames(en, es string) map[string][2]string {
+	return map[string][2]string{"en": {en, en + "s"}, "es": {es, es + "s"}}
+}
+
+// squareNames returns the names of the square of a unit named like unitNames.
+func squareNames(en, es string) map[string][2]string {
+	return map[string][2]string{
+		"en": {"square " + en, "square " + en + "s"},
+		"es": {es + " cuadrado", es + "s cuadrados"},
+	}
+}
@@ -0,0 +1,57 @@
+package formatter
+
+import (
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+func TestFormatMeasurement(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    entity.MeasurementValue
+		pattern  string
+		system   string
+		language string
+		want     string
+	}{
+		{"keeps the unit without a system", entity.MeasurementValue{Value: 1.8, Unit: "m"}, "measure", "", "en", "1.8 m"},
+		{"height to imperial", entity.MeasurementValue{Value: 1.8, Unit: "m"}, "measure", "imperial", "en", "5.91 ft"},
+		{"short length steps down to inches", entity.MeasurementValue{Value: 30, Unit: "cm"}, "measure", "imperial", "en", "11.81 in"},
+		{"weight to metric", entity.MeasurementValue{Value: 5, Unit: "lb"}, "measure", "metric", "en", "2.27 kg"},
+		{"light weight steps down to grams", entity.MeasurementValue{Value: 8, Unit: "oz"}, "measure", "metric", "es", "226,8 g"},
+		{"unit of the target system is kept", entity.MeasurementValue{Value: 12, Unit: "yd"}, "measure", "imperial", "en", "12 yd"},
+		{"pattern system overrides the render", entity.MeasurementValue{Value: 500, Unit: "ml"}, "measure:imperial", "metric", "en", "16.91 fl oz"},
+		{"keep ignores the render", entity.MeasurementValue{Value: 500, Unit: "ml"}, "measure:keep", "imperial", "en", "500 ml"},
+		{"explicit unit", entity.MeasurementValue{Value: 2.5, Unit: "km"}, "measure:m", "imperial", "en", "2,500 m"},
+		{"unit of another dimension is ignored", entity.MeasurementValue{Value: 2.5, Unit: "km"}, "measure:kg", "", "en", "2.5 km"},
+		{"temperature", entity.MeasurementValue{Value: 100, Unit: "°C"}, "measure", "imperial", "en", "212 °F"},
+		{"kelvin to celsius", entity.MeasurementValue{Value: 0, Unit: "K"}, "measure:C", "", "en", "-273.15 °C"},
+		{"area", entity.MeasurementValue{Value: 1, Unit: "ha"}, "measure", "imperial", "en", "2.47 ac"},
+		{"names in english", entity.MeasurementValue{Value: 1, Unit: "ft"}, "measure:auto:name", "", "en", "1 foot"},
+		{"plural names in spanish", entity.MeasurementValue{Value: 60, Unit: "mph"}, "measure::name:0", "metric", "es", "97 kilómetros por hora"},
+		{"spanish separators", entity.MeasurementValue{Value: 1234.5, Unit: "kg"}, "measure", "", "es-CL", "1.234,5 kg"},
+		{"decimals", entity.MeasurementValue{Value: 1, Unit: "in"}, "measure:mm:symbol:0", "", "en", "25 mm"},
+		{"unknown unit keeps its code", entity.MeasurementValue{Value: 3, Unit: "bar"}, "measure", "imperial", "en", "3 bar"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatMeasurement(&tt.value, tt.pattern, tt.system, tt.language); got != tt.want {
+				t.Errorf("FormatMeasurement() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsMeasurePattern(t *testing.T) {
+	for pattern, want := range map[string]bool{
+		"measure":          true,
+		"measure:imperial": true,
+		"measurement":      false,
+		"person":           false,
+	} {
+		if got := IsMeasurePattern(pattern); got != want {
+			t.Errorf("IsMeasurePattern(%q) = %v, want %v", pattern, got, want)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package formatter
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// NumberPrefix starts the format patterns of numbers written by the conventions of a locale.
+// Pattern format: "number[:<locale>[:<currency>[:<decimals>[:plain]]]]". The locale is a BCP 47
+// tag such as "es-ES" (default: the language of the document). With a currency (ISO 4217 code)
+// the number is an amount, written with the symbol where the locale puts it: 1234.56 is
+// "1.234,56 €" with "number:es-ES:EUR" and "€1,234.56" with "number:en-US:EUR". decimals fixes
+// the decimals written (default: the minor units of the currency, or at most 2 for plain numbers,
+// with trailing zeros dropped) and "plain" leaves out the thousands separators.
+const NumberPrefix = "number"
+
+// maxNumberDecimals is the most decimals a number pattern writes.
+const maxNumberDecimals = 10
+
+// IsNumberPattern reports whether pattern writes a number by the conventions of a locale.
+func IsNumberPattern(pattern string) bool {
+	return pattern == NumberPrefix || strings.HasPrefix(pattern, NumberPrefix+":")
+}
+
+// FormatLocaleNumber writes n in the form of a number pattern (see NumberPrefix). language is the
+// locale of patterns without one, usually the language of the document.
+func FormatLocaleNumber(n float64, pattern, language string) string {
+	fields := strings.Split(strings.TrimPrefix(strings.TrimPrefix(pattern, NumberPrefix), ":"), ":")
+	field := func(i int) string {
+		if i < len(fields) {
+			return strings.TrimSpace(fields[i])
+		}
+		return ""
+	}
+
+	locale := field(0)
+	if locale == "" {
+		locale = language
+	}
+	decimals := -1
+	if d, err := strconv.Atoi(field(2)); err == nil && d >= 0 && d <= maxNumberDecimals {
+		decimals = d
+	}
+	return formatLocaleNumber(n, localeTag(locale), field(1), decimals, !strings.EqualFold(field(3), "plain"))
+}
+
+// FormatAmount writes an amount by the conventions of a locale. currency is an ISO 4217 code,
+// written with its symbol, or a symbol of its own, written before the amount ("$ 1,234.56"); an
+// empty currency writes the number alone. Amounts take the decimals of their currency, and two
+// when it is not an ISO 4217 code.
+func FormatAmount(amount float64, currency, locale string) string {
+	tag := localeTag(locale)
+	if isCurrencyCode(currency) {
+		return formatLocaleNumber(amount, tag, currency, -1, true)
+	}
+	digits := formatLocaleNumber(amount, tag, "", 2, true)
+	if currency == "" {
+		return digits
+	}
+	return currency + " " + digits
+}
+
+// FormatDecimal writes n with the decimal separator of a locale and the given decimals, without
+// thousands separators.
+func FormatDecimal(n float64, locale string, decimals int) string {
+	return formatLocaleNumber(n, localeTag(locale), "", decimals, false)
+}
+
+// formatLocaleNumber writes n in a locale, as an amount of code when it is set. A negative
+// decimals takes the minor units of the currency (2 when it is not an ISO 4217 code), or at most
+// 2 decimals without one.
+func formatLocaleNumber(n float64, tag language.Tag, code string, decimals int, grouping bool) string {
+	if math.IsNaN(n) || math.IsInf(n, 0) {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+
+	code = entity.NormalizeCurrencyCode(code)
+	unit, err := currency.ParseISO(code)
+	known := code != "" && err == nil
+
+	var opts []number.Option
+	scale := decimals
+	switch {
+	case decimals >= 0:
+		opts = append(opts, number.Scale(decimals))
+	case known:
+		scale, _ = currency.Standard.Rounding(unit)
+		opts = append(opts, number.Scale(scale))
+	case code != "":
+		scale = 2
+		opts = append(opts, number.Scale(scale))
+	default:
+		scale = 2
+		opts = append(opts, number.MaxFractionDigits(scale))
+	}
+	if !grouping {
+		opts = append(opts, number.NoSeparator())
+	}
+
+	// Rounded half up here, as amounts in words are, rather than to even by the printer.
+	pow := math.Pow(10, float64(scale))
+	abs := math.Round(math.Abs(n)*pow) / pow
+
+	printer := message.NewPrinter(tag)
+	digits := printer.Sprint(number.Decimal(abs, opts...))
+	var sign string
+	if n < 0 && abs != 0 {
+		sign = "-"
+	}
+	if code == "" {
+		return sign + digits
+	}
+
+	symbol := code
+	if known {
+		symbol = printer.Sprint(currency.Symbol(unit))
+	}
+	switch {
+	case symbolAfterAmount(tag):
+		return sign + digits + " " + symbol
+	case endsInLetter(symbol):
+		return sign + symbol + " " + digits
+	default:
+		return sign + symbol + digits
+	}
+}
+
+// symbolAfterLanguages write the currency symbol after the amount ("1.234,56 €"), except in the
+// regions of symbolBeforeRegions and Latin America.
+var symbolAfterLanguages = map[string]bool{
+	"bg": true, "ca": true, "cs": true, "da": true, "de": true, "el": true, "es": true,
+	"et": true, "fi": true, "fr": true, "hr": true, "hu": true, "it": true, "lt": true,
+	"lv": true, "nb": true, "pl": true, "pt": true, "ro": true, "ru": true, "sk": true,
+	"sl": true, "sv": true, "uk": true,
+}
+
+// symbolBeforeRegions write the symbol before the amount in languages that otherwise write it after.
+var symbolBeforeRegions = map[string]bool{"AT": true, "CH": true, "LI": true, "US": true}
+
+var latinAmerica = language.MustParseRegion("419")
+
+func symbolAfterAmount(tag language.Tag) bool {
+	base, _ := tag.Base()
+	if !symbolAfterLanguages[base.String()] {
+		return false
+	}
+	region, _ := tag.Region()
+	return !symbolBeforeRegions[region.String()] && !latinAmerica.Contains(region)
+}
+
+func endsInLetter(s string) bool {
+	r := []rune(s)
+	return len(r) > 0 && unicode.IsLetter(r[len(r)-1])
+}
+
+// isCurrencyCode reports whether s is written like an ISO 4217 code: three letters.
+func isCurrencyCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) || r > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
+
+// localeTag parses a locale such as "es-CL" or "es_CL"; unknown locales use English.
+func localeTag(locale string) language.Tag {
+	tag, err := language.Parse(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	if err != nil {
+		return language.English
+	}
+	return tag
+}
@@ -0,0 +1,76 @@
+package formatter
+
+import "testing"
+
+func TestFormatLocaleNumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    float64
+		pattern  string
+		language string
+		want     string
+	}{
+		{"spanish amount", 1234.56, "number:es-ES:EUR", "en", "1.234,56 €"},
+		{"document language", 1234.56, "number::EUR", "es", "1.234,56 €"},
+		{"english amount", 1234.56, "number:en-US:EUR", "es", "€1,234.56"},
+		{"dollars", 1234.56, "number:en-US:USD", "", "$1,234.56"},
+		{"currency without minor units", 1234567.4, "number:es-CL:CLP", "", "$1.234.567"},
+		{"latin american symbol before", 1234.5, "number:es-MX:MXN", "", "$1,234.50"},
+		{"letter symbol", 1234.56, "number:de-CH:CHF", "", "CHF 1’234.56"},
+		{"unknown currency", 10, "number:en:XYZ", "", "XYZ 10.00"},
+		{"lowercase currency", 5, "number:es:eur", "", "5,00 €"},
+		{"plain number", 1234.5, "number", "es", "1.234,5"},
+		{"plain number english", 1234.567, "number", "en", "1,234.57"},
+		{"fixed decimals", 1234.5, "number:::3", "es", "1.234,500"},
+		{"no decimals", 1234.5, "number:::0", "en", "1,235"},
+		{"without grouping", 1234.5, "number:::2:plain", "es", "1234,50"},
+		{"negative amount", -1234.56, "number:es:EUR", "", "-1.234,56 €"},
+		{"negative rounded to zero", -0.001, "number:es:EUR", "", "0,00 €"},
+		{"underscore locale", 1234.5, "number:es_ES", "", "1.234,5"},
+		{"unknown locale", 1234.5, "number:??", "", "1,234.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatLocaleNumber(tt.value, tt.pattern, tt.language); got != tt.want {
+				t.Errorf("FormatLocaleNumber(%v, %q, %q) = %q, want %q", tt.value, tt.pattern, tt.language, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   float64
+		currency string
+		locale   string
+		want     string
+	}{
+		{"iso code", 1234.56, "EUR", "es", "1.234,56 €"},
+		{"own symbol", 1234.56, "$", "en", "$ 1,234.56"},
+		{"own symbol spanish", 1234.5, "US$", "es", "US$ 1.234,50"},
+		{"no currency", 1234.5, "", "es", "1.234,50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatAmount(tt.amount, tt.currency, tt.locale); got != tt.want {
+				t.Errorf("FormatAmount(%v, %q, %q) = %q, want %q", tt.amount, tt.currency, tt.locale, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsNumberPattern(t *testing.T) {
+	for pattern, want := range map[string]bool{
+		"number":      true,
+		"number::EUR": true,
+		"numbers":     false,
+		"#,##0.00":    false,
+	} {
+		if got := IsNumberPattern(pattern); got != want {
+			t.Errorf("IsNumberPattern(%q) = %v, want %v", pattern, got, want)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package formatter
+
+import (
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// PersonPrefix starts the format patterns of people.
+// Pattern format: "person[:<form>[:<language>]]", where the form is "full" (the default: "Dr. Anna
+// Müller", "山田太郎"), "formal" (honorific or title and family name: "Frau Dr. Müller", "山田様"),
+// "salutation" (opening of a letter: "Dear Dr. Müller", "山田太郎 様") or "sorted" (family name first
+// for indexes: "Müller, Anna"). Languages are "en", "es", "pt", "fr", "de", "ja" and "zh"; a locale
+// such as "es-CL" uses its language and unknown languages use English.
+const PersonPrefix = "person"
+
+// personLanguage holds the naming conventions of one language.
+type personLanguage struct {
+	familyFirst bool                     // family name before the given name, without a space
+	honorifics  map[entity.Gender]string // before the family name, or after it when familyFirst
+	titled      bool                     // the title follows the honorific ("Frau Dr. Müller") instead of replacing it
+	greetings   map[entity.Gender]string // opening of the salutation; the "" key is the neutral one
+	suffix      string                   // after the name of anyone, such as 様
+}
+
+var personLanguages = map[string]*personLanguage{
+	"en": {
+		honorifics: map[entity.Gender]string{entity.GenderFemale: "Ms.", entity.GenderMale: "Mr."},
+		greetings:  map[entity.Gender]string{"": "Dear"},
+	},
+	"es": {
+		honorifics: map[entity.Gender]string{entity.GenderFemale: "Sra.", entity.GenderMale: "Sr."},
+		greetings:  map[entity.Gender]string{entity.GenderFemale: "Estimada", entity.GenderMale: "Estimado", "": "Estimado/a"},
+	},
+	"pt": {
+		honorifics: map[entity.Gender]string{entity.GenderFemale: "Sra.", entity.GenderMale: "Sr."},
+		greetings:  map[entity.Gender]string{entity.GenderFemale: "Prezada", entity.GenderMale: "Prezado", "": "Prezado(a)"},
+	},
+	"fr": {
+		honorifics: map[entity.Gender]string{entity.GenderFemale: "Madame", entity.GenderMale: "Monsieur"},
+		greetings:  map[entity.Gender]string{entity.GenderFemale: "Chère", entity.GenderMale: "Cher", "": "Bonjour"},
+	},
+	"de": {
+		honorifics: map[entity.Gender]string{entity.GenderFemale: "Frau", entity.GenderMale: "Herr"},
+		titled:     true,
+		greetings:  map[entity.Gender]string{entity.GenderFemale: "Sehr geehrte", entity.GenderMale: "Sehr geehrter", "": "Guten Tag"},
+	},
+	"ja": {
+		familyFirst: true,
+		suffix:      "様",
+	},
+	"zh": {
+		familyFirst: true,
+		honorifics:  map[entity.Gender]string{entity.GenderFemale: "女士", entity.GenderMale: "先生"},
+		greetings:   map[entity.Gender]string{"": "尊敬的"},
+	},
+}
+
+// IsPersonPattern reports whether the format pattern is a person form.
+func IsPersonPattern(pattern string) bool {
+	return pattern == PersonPrefix || strings.HasPrefix(pattern, PersonPrefix+":")
+}
+
+// FormatPerson writes a person in the form and language of the pattern (see PersonPrefix).
+func FormatPerson(p *entity.PersonValue, pattern string) string {
+	if p == nil {
+		return ""
+	}
+	form, language, _ := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(pattern, PersonPrefix), ":"), ":")
+	lang := personLanguageOf(language)
+	switch form {
+	case "formal":
+		return lang.formalName(p)
+	case "salutation":
+		return lang.salutation(p)
+	case "sorted":
+		return lang.sortedName(p)
+	default:
+		return lang.fullName(p)
+	}
+}
+
+// Salutation returns the opening of a letter to a person in a language, such as "Dear Dr. Müller".
+func Salutation(p *entity.PersonValue, language string) string {
+	if p == nil {
+		return ""
+	}
+	return personLanguageOf(language).salutation(p)
+}
+
+func personLanguageOf(language string) *personLanguage {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	if lang, ok := personLanguages[language]; ok {
+		return lang
+	}
+	return personLanguages["en"]
+}
+
+// name returns the given, middle and family names in the order of the language.
+func (l *personLanguage) name(p *entity.PersonValue) string {
+	given := joinNonEmpty(" ", p.GivenName, p.MiddleName)
+	if l.familyFirst {
+		return strings.TrimSpace(p.FamilyName) + given
+	}
+	return joinNonEmpty(" ", given, p.FamilyName)
+}
+
+// fullName returns the name with its title and suffix. Languages that write the family name
+// first leave them out.
+func (l *personLanguage) fullName(p *entity.PersonValue) string {
+	if l.familyFirst {
+		return l.name(p)
+	}
+	return joinNonEmpty(" ", p.Title, l.name(p), p.Suffix)
+}
+
+// sortedName returns the family name first, as indexes and lists sort people.
+func (l *personLanguage) sortedName(p *entity.PersonValue) string {
+	if l.familyFirst {
+		return l.name(p)
+	}
+	return joinNonEmpty(", ", p.FamilyName, joinNonEmpty(" ", p.GivenName, p.MiddleName))
+}
+
+// formalName returns the family name with the honorific of the gender or the title. People
+// without a family name, or without a gender or title to address them by, get their name.
+func (l *personLanguage) formalName(p *entity.PersonValue) string {
+	family := strings.TrimSpace(p.FamilyName)
+	honorific := l.honorifics[normalizeGender(p.Gender)]
+	if family == "" {
+		return l.name(p)
+	}
+	if l.familyFirst {
+		if honorific == "" {
+			honorific = l.suffix
+		}
+		if honorific == "" {
+			return l.name(p)
+		}
+		return family + honorific
+	}
+
+	title := strings.TrimSpace(p.Title)
+	switch {
+	case honorific != "" && title != "" && l.titled:
+		return joinNonEmpty(" ", honorific, title, family)
+	case title != "":
+		return title + " " + family
+	case honorific != "":
+		return honorific + " " + family
+	default:
+		return l.name(p)
+	}
+}
+
+// salutation returns the greeting of the gender followed by the formal name. Languages that
+// greet with a suffix alone, such as Japanese, write the name and the suffix.
+func (l *personLanguage) salutation(p *entity.PersonValue) string {
+	if l.familyFirst && l.suffix != "" {
+		return joinNonEmpty(" ", l.name(p), l.suffix)
+	}
+	greeting, ok := l.greetings[normalizeGender(p.Gender)]
+	if !ok {
+		greeting = l.greetings[""]
+	}
+	if l.familyFirst {
+		return greeting + l.formalName(p)
+	}
+	return joinNonEmpty(" ", greeting, l.formalName(p))
+}
+
+func normalizeGender(g entity.Gender) entity.Gender {
+	switch gender := entity.Gender(strings.ToLower(strings.TrimSpace(string(g)))); gender {
+	case entity.GenderFemale, entity.GenderMale:
+		return gender
+	default:
+		return ""
+	}
+}
+
+// joinNonEmpty joins the trimmed parts that are not empty.
+func joinNonEmpty(sep string, parts ...string) string {
+	kept := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, sep)
+}
@@ -0,0 +1,57 @@
+package formatter
+
+import (
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+func TestFormatPerson(t *testing.T) {
+	mueller := entity.PersonValue{GivenName: "Anna", FamilyName: "Müller", Gender: entity.GenderFemale, Title: "Dr."}
+	garcia := entity.PersonValue{GivenName: "Juan", MiddleName: "Pablo", FamilyName: "García", Gender: entity.GenderMale}
+	smith := entity.PersonValue{GivenName: "Alex", FamilyName: "Smith", Suffix: "Jr."}
+	yamada := entity.PersonValue{GivenName: "太郎", FamilyName: "山田", Gender: entity.GenderMale}
+	wang := entity.PersonValue{GivenName: "小明", FamilyName: "王", Gender: "Male"}
+
+	tests := []struct {
+		name    string
+		person  entity.PersonValue
+		pattern string
+		want    string
+	}{
+		{"full with title", mueller, "person", "Dr. Anna Müller"},
+		{"full with suffix", smith, "person:full", "Alex Smith Jr."},
+		{"english salutation with title", mueller, "person:salutation:en", "Dear Dr. Müller"},
+		{"english salutation without gender", smith, "person:salutation", "Dear Alex Smith"},
+		{"german salutation keeps honorific and title", mueller, "person:salutation:de", "Sehr geehrte Frau Dr. Müller"},
+		{"german neutral salutation", smith, "person:salutation:de-AT", "Guten Tag Alex Smith"},
+		{"spanish formal", garcia, "person:formal:es", "Sr. García"},
+		{"spanish salutation", garcia, "person:salutation:es-CL", "Estimado Sr. García"},
+		{"sorted", garcia, "person:sorted", "García, Juan Pablo"},
+		{"japanese full", yamada, "person:full:ja", "山田太郎"},
+		{"japanese formal", yamada, "person:formal:ja", "山田様"},
+		{"japanese salutation", yamada, "person:salutation:ja", "山田太郎 様"},
+		{"chinese salutation", wang, "person:salutation:zh", "尊敬的王先生"},
+		{"unknown language uses english", mueller, "person:formal:xx", "Dr. Müller"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatPerson(&tt.person, tt.pattern); got != tt.want {
+				t.Errorf("FormatPerson(%q) = %q, want %q", tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsPersonPattern(t *testing.T) {
+	for pattern, want := range map[string]bool{
+		"person":               true,
+		"person:salutation:de": true,
+		"persona":              false,
+		"address":              false,
+	} {
+		if got := IsPersonPattern(pattern); got != want {
+			t.Errorf("IsPersonPattern(%q) = %v, want %v", pattern, got, want)
+		}
+	}
+}
@@ -99,3 +99,127 @@ var BoolFormats = &entity.FormatConfig{
 		"Sí/No",
 	},
 }
+
+// IBANMaskFormats provides IBAN masking options.
+var IBANMaskFormats = &entity.FormatConfig{
+	Default: "mask:iban",
+	Options: []string{
+		"mask:iban",     // DE89 **** **** **** **30 00
+		"mask:iban:2,4", // DE** **** **** **** **30 00
+		"mask:iban:0,4", // **** **** **** **** **30 00
+	},
+}
+
+// CardMaskFormats provides payment card masking options.
+var CardMaskFormats = &entity.FormatConfig{
+	Default: "mask:card",
+	Options: []string{
+		"mask:card",     // **** **** **** 4242
+		"mask:card:6,4", // 4242 42** **** 4242
+		"mask:card:0,0", // **** **** **** ****
+	},
+}
+
+// NationalIDMaskFormats provides national ID masking options. Without a region the ID keeps its
+// own separators.
+var NationalIDMaskFormats = &entity.FormatConfig{
+	Default: "mask:national_id",
+	Options: []string{
+		"mask:national_id",        // *****678-9
+		"mask:national_id:CL",     // **.***.678-9 (Chilean RUT)
+		"mask:national_id:0,3:CL", // **.***.*78-9
+		"mask:national_id:US",     // ***-**-6789 (SSN)
+		"mask:national_id:BR",     // ***.***.*89-09 (CPF)
+		"mask:national_id:ES",     // *****678Z (DNI)
+	},
+}
+
+// EmailMaskFormats provides email masking options.
+var EmailMaskFormats = &entity.FormatConfig{
+	Default: "mask:email",
+	Options: []string{
+		"mask:email",     // j*******@example.com
+		"mask:email:2,1", // ja*****e@example.com
+		"mask:email:0,0", // ********@example.com
+	},
+}
+
+// NumberWordsFormats provides options that spell a number out in words.
+var NumberWordsFormats = &entity.FormatConfig{
+	Default: "words:en",
+	Options: []string{
+		"words:en", // one thousand two hundred point five
+		"words:es", // mil doscientos coma cinco
+		"words:pt", // mil e duzentos vírgula cinco
+		"words:fr", // mille deux cents virgule cinq
+		"words:de", // eintausendzweihundert Komma fünf
+	},
+}
+
+// AmountWordsFormats provides options that spell an amount out in words, as written on checks
+// and contracts.
+var AmountWordsFormats = &entity.FormatConfig{
+	Default: "words:en:EUR",
+	Options: []string{
+		"words:en:EUR", // one thousand two hundred euros and five cents
+		"words:en:USD", // one thousand two hundred dollars and five cents
+		"words:es:EUR", // mil doscientos euros con cinco céntimos
+		"words:es:CLP", // mil doscientos pesos
+		"words:pt:BRL", // mil e duzentos reais e cinco centavos
+		"words:fr:EUR", // mille deux cents euros et cinq centimes
+		"words:de:EUR", // eintausendzweihundert Euro und fünf Cent
+	},
+}
+
+// LocaleNumberFormats provides options that write a number or an amount by the conventions of a
+// locale, which defaults to the language of the document (1234.56 in a Spanish document).
+var LocaleNumberFormats = &entity.FormatConfig{
+	Default: "number",
+	Options: []string{
+		"number",           // 1.234,56
+		"number:::0",       // 1.235
+		"number:::2:plain", // 1234,56
+		"number::EUR",      // 1.234,56 €
+		"number:es-ES:EUR", // 1.234,56 €
+		"number:es-CL:CLP", // $1.235
+		"number:en-US:USD", // $1,234.56
+		"number:en-GB:GBP", // £1,234.56
+	},
+}
+
+// AddressFormats provides the layouts of address values.
+var AddressFormats = &entity.FormatConfig{
+	Default: "address",
+	Options: []string{
+		"address",          // lines of the destination country, then the country in capitals
+		"address:domestic", // the same lines without the country
+		"address:oneline",  // all lines joined by commas
+	},
+}
+
+// PersonFormats provides the name forms and salutations of person values.
+var PersonFormats = &entity.FormatConfig{
+	Default: "person",
+	Options: []string{
+		"person",               // Dr. Anna Müller
+		"person:formal",        // Dr. Müller
+		"person:salutation:en", // Dear Dr. Müller
+		"person:salutation:es", // Estimada Sra. Müller
+		"person:salutation:de", // Sehr geehrte Frau Dr. Müller
+		"person:salutation:ja", // 山田太郎 様
+		"person:sorted",        // Müller, Anna
+	},
+}
+
+// MeasurementFormats provides the conversions and unit styles of measurement values.
+var MeasurementFormats = &entity.FormatConfig{
+	Default: "measure",
+	Options: []string{
+		"measure",             // 1.8 m, or 5.91 ft when the template or render is imperial
+		"measure::name",       // 1.8 meters
+		"measure:metric",      // 5.91 ft as 1.8 m, whatever the render
+		"measure:imperial",    // 1.8 m as 5.91 ft, whatever the render
+		"measure:keep",        // the unit of the value, whatever the render
+		"measure:cm:symbol:0", // 180 cm
+	},
+}
@@ -0,0 +1,621 @@
+package formatter
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// WordsPrefix starts the format patterns that spell a number out in words.
+// Pattern format: "words:<language>[:<currency>]", e.g. "words:en" (one thousand two hundred
+// point five) or "words:en:EUR" (one thousand two hundred euros and fifty cents).
+const WordsPrefix = "words:"
+
+// maxWordsAmount is the first amount too large to be spelled out; larger amounts keep their digits.
+const maxWordsAmount = 1_000_000_000_000
+
+// wordsLanguage spells numbers in one language.
+type wordsLanguage struct {
+	// spell writes n in words. feminine selects the feminine forms used before feminine nouns;
+	// noun is set when a currency name follows, for the forms used before nouns ("un euro").
+	spell func(n uint64, feminine, noun bool) string
+	minus string
+	point string // separates the decimals of a number that is not an amount
+	and   string // joins the major and minor units of an amount
+	// millionsOf returns the currency name after a round number of millions ("un millón de
+	// euros"); nil when the name follows as usual.
+	millionsOf func(name string) string
+	// singularZero is set when zero takes the singular, as in French "zéro euro".
+	singularZero bool
+	// currencies are the names of the ISO 4217 currencies; others use their code.
+	currencies map[string]currencyWords
+	// minor is the name of the minor units of currencies without their own.
+	minor currencyWords
+}
+
+// currencyWords are the names of a currency and of its minor units.
+type currencyWords struct {
+	one, many           string
+	minorOne, minorMany string
+	feminine            bool // the major unit is feminine ("una libra")
+}
+
+var wordsLanguages = map[string]*wordsLanguage{
+	"en": {
+		spell: spellEnglish, minus: "minus", point: "point", and: "and",
+		currencies: map[string]currencyWords{
+			"ARS": {"peso", "pesos", "centavo", "centavos", false},
+			"BRL": {"real", "reais", "centavo", "centavos", false},
+			"CHF": {"franc", "francs", "centime", "centimes", false},
+			"CLP": {"peso", "pesos", "", "", false},
+			"COP": {"peso", "pesos", "centavo", "centavos", false},
+			"EUR": {"euro", "euros", "cent", "cents", false},
+			"GBP": {"pound", "pounds", "penny", "pence", false},
+			"JPY": {"yen", "yen", "", "", false},
+			"MXN": {"peso", "pesos", "centavo", "centavos", false},
+			"PEN": {"sol", "soles", "céntimo", "céntimos", false},
+			"USD": {"dollar", "dollars", "cent", "cents", false},
+		},
+		minor: currencyWords{minorOne: "cent", minorMany: "cents"},
+	},
+	"es": {
+		spell: spellSpanish, minus: "menos", point: "coma", and: "con",
+		millionsOf: func(name string) string { return "de " + name },
+		currencies: map[string]currencyWords{
+			"ARS": {"peso", "pesos", "centavo", "centavos", false},
+			"BRL": {"real", "reales", "centavo", "centavos", false},
+			"CHF": {"franco", "francos", "céntimo", "céntimos", false},
+			"CLP": {"peso", "pesos", "", "", false},
+			"COP": {"peso", "pesos", "centavo", "centavos", false},
+			"EUR": {"euro", "euros", "céntimo", "céntimos", false},
+			"GBP": {"libra", "libras", "penique", "peniques", true},
+			"JPY": {"yen", "yenes", "", "", false},
+			"MXN": {"peso", "pesos", "centavo", "centavos", false},
+			"PEN": {"sol", "soles", "céntimo", "céntimos", false},
+			"USD": {"dólar", "dólares", "centavo", "centavos", false},
+		},
+		minor: currencyWords{minorOne: "céntimo", minorMany: "céntimos"},
+	},
+	"pt": {
+		spell: spellPortuguese, minus: "menos", point: "vírgula", and: "e",
+		millionsOf: func(name string) string { return "de " + name },
+		currencies: map[string]currencyWords{
+			"ARS": {"peso", "pesos", "centavo", "centavos", false},
+			"BRL": {"real", "reais", "centavo", "centavos", false},
+			"CHF": {"franco", "francos", "cêntimo", "cêntimos", false},
+			"CLP": {"peso", "pesos", "", "", false},
+			"COP": {"peso", "pesos", "centavo", "centavos", false},
+			"EUR": {"euro", "euros", "cêntimo", "cêntimos", false},
+			"GBP": {"libra", "libras", "pêni", "pence", true},
+			"JPY": {"iene", "ienes", "", "", false},
+			"MXN": {"peso", "pesos", "centavo", "centavos", false},
+			"PEN": {"sol", "soles", "cêntimo", "cêntimos", false},
+			"USD": {"dólar", "dólares", "centavo", "centavos", false},
+		},
+		minor: currencyWords{minorOne: "centavo", minorMany: "centavos"},
+	},
+	"fr": {
+		spell: spellFrench, minus: "moins", point: "virgule", and: "et", singularZero: true,
+		millionsOf: func(name string) string {
+			if strings.ContainsAny(name[:1], "aeiouyh") || strings.HasPrefix(name, "é") {
+				return "d'" + name
+			}
+			return "de " + name
+		},
+		currencies: map[string]currencyWords{
+			"ARS": {"peso", "pesos", "centavo", "centavos", false},
+			"BRL": {"réal", "réaux", "centavo", "centavos", false},
+			"CHF": {"franc", "francs", "centime", "centimes", false},
+			"CLP": {"peso", "pesos", "", "", false},
+			"COP": {"peso", "pesos", "centavo", "centavos", false},
+			"EUR": {"euro", "euros", "centime", "centimes", false},
+			"GBP": {"livre", "livres", "penny", "pence", true},
+			"JPY": {"yen", "yens", "", "", false},
+			"MXN": {"peso", "pesos", "centavo", "centavos", false},
+			"PEN": {"sol", "soles", "céntimo", "céntimos", false},
+			"USD": {"dollar", "dollars", "cent", "cents", false},
+		},
+		minor: currencyWords{minorOne: "centime", minorMany: "centimes"},
+	},
+	"de": {
+		spell: spellGerman, minus: "minus", point: "Komma", and: "und",
+		currencies: map[string]currencyWords{
+			"ARS": {"Peso", "Pesos", "Centavo", "Centavos", false},
+			"BRL": {"Real", "Reais", "Centavo", "Centavos", false},
+			"CHF": {"Franken", "Franken", "Rappen", "Rappen", false},
+			"CLP": {"Peso", "Pesos", "", "", false},
+			"COP": {"Peso", "Pesos", "Centavo", "Centavos", false},
+			"EUR": {"Euro", "Euro", "Cent", "Cent", false},
+			"GBP": {"Pfund", "Pfund", "Penny", "Pence", false},
+			"JPY": {"Yen", "Yen", "", "", false},
+			"MXN": {"Peso", "Pesos", "Centavo", "Centavos", false},
+			"PEN": {"Sol", "Soles", "Céntimo", "Céntimos", false},
+			"USD": {"Dollar", "Dollar", "Cent", "Cent", false},
+		},
+		minor: currencyWords{minorOne: "Cent", minorMany: "Cent"},
+	},
+}
+
+// IsWordsPattern reports whether pattern spells the value out in words.
+func IsWordsPattern(pattern string) bool {
+	return strings.HasPrefix(pattern, WordsPrefix)
+}
+
+// FormatWords spells n out according to a words pattern: as an amount of the currency of the
+// pattern when it has one, else as a plain number.
+func FormatWords(n float64, pattern string) string {
+	fields := strings.Split(strings.TrimPrefix(pattern, WordsPrefix), ":")
+	language := fields[0]
+	if len(fields) > 1 && strings.TrimSpace(fields[1]) != "" {
+		return AmountToWords(n, language, fields[1])
+	}
+	return NumberToWords(n, language)
+}
+
+// NumberToWords spells a number out in a language ("en", "es", "pt", "fr" or "de"; a locale such
+// as "es-CL" uses its language). Decimals are read digit by digit: 12.05 is "twelve point zero
+// five". Unknown languages use English; numbers of a trillion or more keep their digits.
+func NumberToWords(n float64, language string) string {
+	lang := wordsLanguageOf(language)
+	if math.IsNaN(n) || math.IsInf(n, 0) || math.Abs(n) >= maxWordsAmount {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+
+	digits := strconv.FormatFloat(math.Abs(n), 'f', -1, 64)
+	whole, fraction, _ := strings.Cut(digits, ".")
+	integer, _ := strconv.ParseUint(whole, 10, 64)
+
+	words := []string{lang.spell(integer, false, false)}
+	if n < 0 {
+		words = append([]string{lang.minus}, words...)
+	}
+	if fraction != "" {
+		words = append(words, lang.point)
+		for _, d := range fraction {
+			words = append(words, lang.spell(uint64(d-'0'), false, false))
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// AmountToWords spells an amount of an ISO 4217 currency out in a language, rounded half up to
+// the minor units of the currency: 1200.05 EUR is "one thousand two hundred euros and five cents".
+// Minor units are left out when they are zero. Currencies without a name in the language use
+// their code.
+func AmountToWords(amount float64, language, currency string) string {
+	lang := wordsLanguageOf(language)
+	code := entity.NormalizeCurrencyCode(currency)
+	if math.IsNaN(amount) || math.IsInf(amount, 0) || math.Abs(amount) >= maxWordsAmount {
+		return strconv.FormatFloat(amount, 'f', -1, 64) + " " + code
+	}
+
+	names, ok := lang.currencies[code]
+	if !ok {
+		names = currencyWords{one: code, many: code, minorOne: lang.minor.minorOne, minorMany: lang.minor.minorMany}
+	}
+	if names.minorOne == "" {
+		names.minorOne, names.minorMany = lang.minor.minorOne, lang.minor.minorMany
+	}
+
+	units := entity.CurrencyMinorUnits(code)
+	scale := math.Pow10(units)
+	rounded := entity.RoundCurrency(math.Abs(amount), code, entity.CurrencyRoundHalfUp)
+	major := uint64(rounded)
+	minor := uint64(math.Round((rounded - float64(major)) * scale))
+
+	words := []string{lang.spell(major, names.feminine, true)}
+	if amount < 0 && (major > 0 || minor > 0) {
+		words = append([]string{lang.minus}, words...)
+	}
+	if lang.millionsOf != nil && major >= 1_000_000 && major%1_000_000 == 0 {
+		words = append(words, lang.millionsOf(names.many))
+	} else {
+		words = append(words, lang.plural(major, names.one, names.many))
+	}
+	if minor > 0 {
+		words = append(words, lang.and, lang.spell(minor, false, true), lang.plural(minor, names.minorOne, names.minorMany))
+	}
+	return strings.Join(words, " ")
+}
+
+// plural returns the singular or plural name for n units.
+func (l *wordsLanguage) plural(n uint64, one, many string) string {
+	if n == 1 || (n == 0 && l.singularZero) {
+		return one
+	}
+	return many
+}
+
+// wordsLanguageOf returns the language of a language code or locale, English when unknown.
+func wordsLanguageOf(language string) *wordsLanguage {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	if lang, ok := wordsLanguages[language]; ok {
+		return lang
+	}
+	return wordsLanguages["en"]
+}
+
+// scaleGroups splits n into its billions, millions, thousands and units.
+func scaleGroups(n uint64) (billions, millions, thousands, units uint64) {
+	return n / 1_000_000_000, n / 1_000_000 % 1000, n / 1000 % 1000, n % 1000
+}
+
+func joinWords(words ...string) string {
+	var parts []string
+	for _, w := range words {
+		if w != "" {
+			parts = append(parts, w)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// --- English ---
+
+var (
+	englishUnits = []string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+	englishTens = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+)
+
+func spellEnglish(n uint64, _, _ bool) string {
+	if n == 0 {
+		return englishUnits[0]
+	}
+	billions, millions, thousands, units := scaleGroups(n)
+	group := func(g uint64, scale string) string {
+		if g == 0 {
+			return ""
+		}
+		return joinWords(englishBelow1000(g), scale)
+	}
+	return joinWords(group(billions, "billion"), group(millions, "million"), group(thousands, "thousand"), group(units, ""))
+}
+
+func englishBelow1000(n uint64) string {
+	var words []string
+	if n >= 100 {
+		words = append(words, englishUnits[n/100], "hundred")
+		n %= 100
+	}
+	switch {
+	case n == 0:
+	case n < 20:
+		words = append(words, englishUnits[n])
+	case n%10 == 0:
+		words = append(words, englishTens[n/10])
+	default:
+		words = append(words, englishTens[n/10]+"-"+englishUnits[n%10])
+	}
+	return strings.Join(words, " ")
+}
+
+// --- Spanish ---
+
+var (
+	spanishUnits = []string{"cero", "uno", "dos", "tres", "cuatro", "cinco", "seis", "siete", "ocho", "nueve",
+		"diez", "once", "doce", "trece", "catorce", "quince", "dieciséis", "diecisiete", "dieciocho", "diecinueve",
+		"veinte", "veintiuno", "veintidós", "veintitrés", "veinticuatro", "veinticinco", "veintiséis",
+		"veintisiete", "veintiocho", "veintinueve"}
+	spanishTens     = []string{"", "", "", "treinta", "cuarenta", "cincuenta", "sesenta", "setenta", "ochenta", "noventa"}
+	spanishHundreds = []string{"", "ciento", "doscientos", "trescientos", "cuatrocientos", "quinientos",
+		"seiscientos", "setecientos", "ochocientos", "novecientos"}
+)
+
+// spellSpanish writes n in Spanish. One before a noun or "mil" is "un" ("veintiún euros"), or
+// "una" before a feminine noun ("doscientas una libras").
+func spellSpanish(n uint64, feminine, noun bool) string {
+	if n == 0 {
+		return spanishUnits[0]
+	}
+	billions, millions, thousands, units := scaleGroups(n)
+	millions += billions * 1000
+
+	var words []string
+	switch {
+	case millions == 1:
+		words = append(words, "un millón")
+	case millions > 1:
+		words = append(words, spellSpanish(millions, false, true), "millones")
+	}
+	switch {
+	case thousands == 1:
+		words = append(words, "mil")
+	case thousands > 1:
+		words = append(words, spanishBelow1000(thousands, feminine, true), "mil")
+	}
+	if units > 0 {
+		words = append(words, spanishBelow1000(units, feminine, noun))
+	}
+	return strings.Join(words, " ")
+}
+
+func spanishBelow1000(n uint64, feminine, noun bool) string {
+	var words []string
+	if h := n / 100; h > 0 {
+		switch {
+		case n == 100:
+			words = append(words, "cien")
+		case feminine && h > 1:
+			words = append(words, strings.TrimSuffix(spanishHundreds[h], "os")+"as")
+		default:
+			words = append(words, spanishHundreds[h])
+		}
+	}
+	n %= 100
+	if n == 0 {
+		return strings.Join(words, " ")
+	}
+
+	var below100 string
+	if n < 30 {
+		below100 = spanishUnits[n]
+	} else {
+		below100 = spanishTens[n/10]
+		if n%10 > 0 {
+			below100 += " y " + spanishUnits[n%10]
+		}
+	}
+	if n%10 == 1 && n != 11 {
+		switch {
+		case feminine:
+			below100 = strings.TrimSuffix(below100, "o") + "a"
+		case noun && n == 21:
+			below100 = "veintiún"
+		case noun:
+			below100 = strings.TrimSuffix(below100, "o")
+		}
+	}
+	return strings.Join(append(words, below100), " ")
+}
+
+// --- Portuguese ---
+
+var (
+	portugueseUnits = []string{"zero", "um", "dois", "três", "quatro", "cinco", "seis", "sete", "oito", "nove",
+		"dez", "onze", "doze", "treze", "catorze", "quinze", "dezesseis", "dezessete", "dezoito", "dezenove"}
+	portugueseTens     = []string{"", "", "vinte", "trinta", "quarenta", "cinquenta", "sessenta", "setenta", "oitenta", "noventa"}
+	portugueseHundreds = []string{"", "cento", "duzentos", "trezentos", "quatrocentos", "quinhentos",
+		"seiscentos", "setecentos", "oitocentos", "novecentos"}
+)
+
+// spellPortuguese writes n in Brazilian Portuguese. The last group is joined with "e" when it
+// is below one hundred or a round number of hundreds ("mil e duzentos", "mil duzentos e cinco").
+func spellPortuguese(n uint64, feminine, _ bool) string {
+	if n == 0 {
+		return portugueseUnits[0]
+	}
+	billions, millions, thousands, units := scaleGroups(n)
+
+	type group struct {
+		value uint64
+		words string
+	}
+	var groups []group
+	scale := func(g uint64, one, many string) {
+		switch {
+		case g == 1:
+			groups = append(groups, group{g, "um " + one})
+		case g > 1:
+			groups = append(groups, group{g, portugueseBelow1000(g, false) + " " + many})
+		}
+	}
+	scale(billions, "bilhão", "bilhões")
+	scale(millions, "milhão", "milhões")
+	switch {
+	case thousands == 1:
+		groups = append(groups, group{thousands, "mil"})
+	case thousands > 1:
+		groups = append(groups, group{thousands, portugueseBelow1000(thousands, feminine) + " mil"})
+	}
+	if units > 0 {
+		groups = append(groups, group{units, portugueseBelow1000(units, feminine)})
+	}
+
+	words := groups[0].words
+	for i, g := range groups[1:] {
+		last := i == len(groups)-2
+		if last && (g.value < 100 || g.value%100 == 0) {
+			words += " e " + g.words
+		} else {
+			words += " " + g.words
+		}
+	}
+	return words
+}
+
+func portugueseBelow1000(n uint64, feminine bool) string {
+	var words []string
+	if h := n / 100; h > 0 {
+		switch {
+		case n == 100:
+			words = append(words, "cem")
+		case feminine && h > 1:
+			words = append(words, strings.TrimSuffix(portugueseHundreds[h], "os")+"as")
+		default:
+			words = append(words, portugueseHundreds[h])
+		}
+	}
+	n %= 100
+	unit := func(u uint64) string {
+		switch {
+		case feminine && u == 1:
+			return "uma"
+		case feminine && u == 2:
+			return "duas"
+		}
+		return portugueseUnits[u]
+	}
+	switch {
+	case n == 0:
+	case n < 20:
+		words = append(words, unit(n))
+	case n%10 == 0:
+		words = append(words, portugueseTens[n/10])
+	default:
+		words = append(words, portugueseTens[n/10]+" e "+unit(n%10))
+	}
+	return strings.Join(words, " e ")
+}
+
+// --- French ---
+
+var (
+	frenchUnits = []string{"zéro", "un", "deux", "trois", "quatre", "cinq", "six", "sept", "huit", "neuf",
+		"dix", "onze", "douze", "treize", "quatorze", "quinze", "seize", "dix-sept", "dix-huit", "dix-neuf"}
+	frenchTens = []string{"", "", "vingt", "trente", "quarante", "cinquante", "soixante"}
+)
+
+// spellFrench writes n in French with the traditional spelling: hyphens only below one hundred,
+// and "cents" and "quatre-vingts" plural only at the end of the number or before a noun.
+func spellFrench(n uint64, feminine, _ bool) string {
+	if n == 0 {
+		return frenchUnits[0]
+	}
+	billions, millions, thousands, units := scaleGroups(n)
+	scale := func(g uint64, one, many string) string {
+		switch {
+		case g == 1:
+			return "un " + one
+		case g > 1:
+			return frenchBelow1000(g, false, true) + " " + many
+		}
+		return ""
+	}
+
+	var thousandWords string
+	switch {
+	case thousands == 1:
+		thousandWords = "mille"
+	case thousands > 1:
+		thousandWords = frenchBelow1000(thousands, feminine, false) + " mille"
+	}
+	var unitWords string
+	if units > 0 {
+		unitWords = frenchBelow1000(units, feminine, true)
+	}
+	return joinWords(scale(billions, "milliard", "milliards"), scale(millions, "million", "millions"), thousandWords, unitWords)
+}
+
+// frenchBelow1000 writes n below one thousand. final is false before "mille", which takes away
+// the plural of "cents" and "quatre-vingts".
+func frenchBelow1000(n uint64, feminine, final bool) string {
+	var words []string
+	h, rest := n/100, n%100
+	if h > 0 {
+		hundreds := "cent"
+		if h > 1 {
+			hundreds = frenchUnits[h] + " cent"
+			if rest == 0 && final {
+				hundreds += "s"
+			}
+		}
+		words = append(words, hundreds)
+	}
+	if rest > 0 {
+		words = append(words, frenchBelow100(rest, feminine, final))
+	}
+	return strings.Join(words, " ")
+}
+
+func frenchBelow100(n uint64, feminine, final bool) string {
+	var words string
+	switch {
+	case n < 20:
+		words = frenchUnits[n]
+	case n < 70:
+		words = frenchTens[n/10]
+		switch u := n % 10; {
+		case u == 1:
+			words += " et un"
+		case u > 1:
+			words += "-" + frenchUnits[u]
+		}
+	case n < 80:
+		if n == 71 {
+			words = "soixante et onze"
+		} else {
+			words = "soixante-" + frenchUnits[n-60]
+		}
+	case n == 80:
+		words = "quatre-vingt"
+		if final {
+			words += "s"
+		}
+	default:
+		words = "quatre-vingt-" + frenchUnits[n-80]
+	}
+	if feminine && strings.HasSuffix(words, "un") {
+		words += "e"
+	}
+	return words
+}
+
+// --- German ---
+
+var (
+	germanUnits = []string{"null", "eins", "zwei", "drei", "vier", "fünf", "sechs", "sieben", "acht", "neun",
+		"zehn", "elf", "zwölf", "dreizehn", "vierzehn", "fünfzehn", "sechzehn", "siebzehn", "achtzehn", "neunzehn"}
+	germanTens = []string{"", "", "zwanzig", "dreißig", "vierzig", "fünfzig", "sechzig", "siebzig", "achtzig", "neunzig"}
+)
+
+// spellGerman writes n in German: numbers below a million are one word, as written on cheques
+// ("eintausendzweihundert"). One before a noun is "ein" ("ein Euro").
+func spellGerman(n uint64, _, noun bool) string {
+	if n == 0 {
+		return germanUnits[0]
+	}
+	if n == 1 && noun {
+		return "ein"
+	}
+	billions, millions, thousands, units := scaleGroups(n)
+	scale := func(g uint64, one, many string) string {
+		switch {
+		case g == 1:
+			return "eine " + one
+		case g > 1:
+			return germanBelow1000(g, false) + " " + many
+		}
+		return ""
+	}
+
+	var below1M string
+	if thousands > 0 {
+		below1M = germanBelow1000(thousands, false) + "tausend"
+	}
+	if units > 0 {
+		below1M += germanBelow1000(units, true)
+	}
+	return joinWords(scale(billions, "Milliarde", "Milliarden"), scale(millions, "Million", "Millionen"), below1M)
+}
+
+// germanBelow1000 writes n below one thousand as one word. final is false when more of the
+// word follows, which shortens "eins" to "ein".
+func germanBelow1000(n uint64, final bool) string {
+	var sb strings.Builder
+	if h := n / 100; h > 0 {
+		sb.WriteString(germanUnit(h, false) + "hundert")
+	}
+	switch rest := n % 100; {
+	case rest == 0:
+	case rest < 20:
+		sb.WriteString(germanUnit(rest, final))
+	case rest%10 == 0:
+		sb.WriteString(germanTens[rest/10])
+	default:
+		sb.WriteString(germanUnit(rest%10, false) + "und" + germanTens[rest/10])
+	}
+	return sb.String()
+}
+
+func germanUnit(n uint64, final bool) string {
+	if n == 1 && !final {
+		return "ein"
+	}
+	return germanUnits[n]
+}
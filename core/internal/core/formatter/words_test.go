@@ -0,0 +1,54 @@
+package formatter
+
+import "testing"
+
+func TestFormatWords(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   float64
+		pattern string
+		want    string
+	}{
+		{"english amount", 1200.05, "words:en:EUR", "one thousand two hundred euros and five cents"},
+		{"english one unit", 1.01, "words:en:USD", "one dollar and one cent"},
+		{"english pence", 21.5, "words:en:GBP", "twenty-one pounds and fifty pence"},
+		{"english zero", 0.99, "words:en:USD", "zero dollars and ninety-nine cents"},
+		{"english number", 1234567.25, "words:en", "one million two hundred thirty-four thousand five hundred sixty-seven point two five"},
+		{"english negative", -15, "words:en", "minus fifteen"},
+		{"rounded half up", 10.005, "words:en:EUR", "ten euros and one cent"},
+		{"currency without minor units", 15000.6, "words:en:CLP", "fifteen thousand one pesos"},
+		{"unknown currency", 3.5, "words:en:CAD", "three CAD and fifty cents"},
+		{"spanish amount", 1200.05, "words:es:EUR", "mil doscientos euros con cinco céntimos"},
+		{"spanish apocope", 21.21, "words:es:USD", "veintiún dólares con veintiún centavos"},
+		{"spanish feminine", 201, "words:es:GBP", "doscientas una libras"},
+		{"spanish round millions", 2000000, "words:es:EUR", "dos millones de euros"},
+		{"spanish thousands of millions", 1021000000, "words:es-CL:CLP", "mil veintiún millones de pesos"},
+		{"spanish hundred", 100, "words:es", "cien"},
+		{"spanish number", 31, "words:es", "treinta y uno"},
+		{"portuguese amount", 1234.56, "words:pt-BR:BRL", "mil duzentos e trinta e quatro reais e cinquenta e seis centavos"},
+		{"portuguese joined hundreds", 1200, "words:pt:BRL", "mil e duzentos reais"},
+		{"portuguese feminine", 2, "words:pt:GBP", "duas libras"},
+		{"portuguese millions", 1000000, "words:pt:BRL", "um milhão de reais"},
+		{"french amount", 1280.71, "words:fr:EUR", "mille deux cent quatre-vingts euros et soixante et onze centimes"},
+		{"french plural hundreds", 200, "words:fr:EUR", "deux cents euros"},
+		{"french hundreds before mille", 280000, "words:fr", "deux cent quatre-vingt mille"},
+		{"french zero", 0.5, "words:fr:EUR", "zéro euro et cinquante centimes"},
+		{"french elision", 3000000, "words:fr:EUR", "trois millions d'euros"},
+		{"french feminine", 21, "words:fr:GBP", "vingt et une livres"},
+		{"french nineties", 91, "words:fr", "quatre-vingt-onze"},
+		{"german amount", 1200.05, "words:de:EUR", "eintausendzweihundert Euro und fünf Cent"},
+		{"german one", 1, "words:de:EUR", "ein Euro"},
+		{"german number", 2000021, "words:de", "zwei Millionen einundzwanzig"},
+		{"german trailing one", 101, "words:de", "einhunderteins"},
+		{"unknown language", 7, "words:xx", "seven"},
+		{"too large", 1e12, "words:en", "1000000000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatWords(tt.value, tt.pattern); got != tt.want {
+				t.Errorf("FormatWords(%v, %q) = %q, want %q", tt.value, tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+// Package payload provides bounded, streaming JSON decoding for request mappers.
+//
+// json.Unmarshal needs the whole body in memory plus a full copy of the decoded
+// tree. For large payloads (tens of MB of line items) Stream lets a mapper walk the
+// document field by field and element by element, keeping only what it needs.
+package payload
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrTooLarge is returned when the payload exceeds Options.MaxBytes.
+	ErrTooLarge = errors.New("payload exceeds the maximum size")
+
+	// ErrInvalid is returned when the payload is not the JSON expected by the caller.
+	ErrInvalid = errors.New("invalid payload")
+
+	// ErrMissingFields is returned by Stream when required top-level fields are absent.
+	ErrMissingFields = errors.New("payload is missing required fields")
+)
+
+// Options bounds how a payload is decoded.
+type Options struct {
+	// MaxBytes is the maximum payload size. Zero disables the limit.
+	MaxBytes int64
+
+	// Required lists top-level fields that must be present. Only checked by Stream.
+	Required []string
+
+	// DisallowUnknownFields rejects object keys that do not match a struct field.
+	DisallowUnknownFields bool
+}
+
+// Decoder reads a single JSON document token by token.
+// Every value must be consumed exactly once through Decode, Skip, Object or Array.
+type Decoder struct {
+	dec *json.Decoder
+}
+
+// NewDecoder returns a Decoder reading from r, bounded by opts.MaxBytes.
+func NewDecoder(r io.Reader, opts Options) *Decoder {
+	if opts.MaxBytes > 0 {
+		r = &limitedReader{r: r, remaining: opts.MaxBytes}
+	}
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	if opts.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	return &Decoder{dec: dec}
+}
+
+// Decode decodes the next value into v.
+func (d *Decoder) Decode(v any) error {
+	return wrap(d.dec.Decode(v))
+}
+
+// Skip discards the next value without materializing it.
+func (d *Decoder) Skip() error {
+	depth := 0
+	for {
+		tok, err := d.dec.Token()
+		if err != nil {
+			return wrap(err)
+		}
+		if delim, ok := tok.(json.Delim); ok {
+			switch delim {
+			case '{', '[':
+				depth++
+			default:
+				depth--
+			}
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// Object reads the next value as an object and calls fn for each field.
+// fn must consume the field value before returning.
+func (d *Decoder) Object(fn func(field string) error) error {
+	if err := d.expect('{'); err != nil {
+		return err
+	}
+	for d.dec.More() {
+		tok, err := d.dec.Token()
+		if err != nil {
+			return wrap(err)
+		}
+		field, _ := tok.(string)
+		if err := fn(field); err != nil {
+			return err
+		}
+	}
+	_, err := d.dec.Token()
+	return wrap(err)
+}
+
+// Array reads the next value as an array and calls fn for each element.
+// fn must consume the element before returning.
+func (d *Decoder) Array(fn func(index int) error) error {
+	if err := d.expect('['); err != nil {
+		return err
+	}
+	for i := 0; d.dec.More(); i++ {
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+	_, err := d.dec.Token()
+	return wrap(err)
+}
+
+// End checks that nothing but whitespace follows the decoded document.
+func (d *Decoder) End() error {
+	if _, err := d.dec.Token(); !errors.Is(err, io.EOF) {
+		if err != nil {
+			return wrap(err)
+		}
+		return fmt.Errorf("%w: unexpected data after the document", ErrInvalid)
+	}
+	return nil
+}
+
+func (d *Decoder) expect(want json.Delim) error {
+	tok, err := d.dec.Token()
+	if err != nil {
+		return wrap(err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("%w: expected %q, got %v", ErrInvalid, want, tok)
+	}
+	return nil
+}
+
+// Decode decodes a whole JSON document from r into v, enforcing opts.MaxBytes.
+func Decode(r io.Reader, v any, opts Options) error {
+	d := NewDecoder(r, opts)
+	if err := d.Decode(v); err != nil {
+		return err
+	}
+	return d.End()
+}
+
+// Stream reads a JSON object from r and calls fn for each top-level field.
+// fn must consume the value through d. Returning an error stops reading immediately,
+// so invalid payloads are rejected without reading the rest of the body.
+func Stream(r io.Reader, opts Options, fn func(field string, d *Decoder) error) error {
+	d := NewDecoder(r, opts)
+	if err := d.Fields(opts.Required, func(field string) error { return fn(field, d) }); err != nil {
+		return err
+	}
+	return d.End()
+}
+
+// Fields reads the next value as an object like Object, then reports the required fields
+// it lacked. It walks a payload handed over inside a larger document, such as the
+// injectables of a render request.
+func (d *Decoder) Fields(required []string, fn func(field string) error) error {
+	seen := make(map[string]bool, len(required))
+	err := d.Object(func(field string) error {
+		seen[field] = true
+		return fn(field)
+	})
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, field := range required {
+		if !seen[field] {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("%w: %s", ErrMissingFields, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// wrap tags JSON errors with ErrInvalid; size and I/O errors pass through.
+func wrap(err error) error {
+	if err == nil || errors.Is(err, ErrTooLarge) {
+		return err
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: unexpected end of input", ErrInvalid)
+	}
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) || strings.HasPrefix(err.Error(), "json: ") {
+		return fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+	return err
+}
+
+// limitedReader fails with ErrTooLarge instead of silently truncating like io.LimitReader.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		var probe [1]byte
+		n, err := l.r.Read(probe[:])
+		if n > 0 {
+			return 0, ErrTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
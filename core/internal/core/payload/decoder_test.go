@@ -0,0 +1,123 @@
+package payload
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestStream(t *testing.T) {
+	body := `{"customer":{"name":"Acme"},"lines":[{"sku":"A","qty":2},{"sku":"B","qty":3}],"notes":{"ignored":[1,2,{"x":null}]}}`
+
+	var customer struct{ Name string }
+	total := 0
+	err := Stream(strings.NewReader(body), Options{Required: []string{"customer", "lines"}}, func(field string, d *Decoder) error {
+		switch field {
+		case "customer":
+			return d.Decode(&customer)
+		case "lines":
+			return d.Array(func(int) error {
+				var line struct{ Qty int }
+				if err := d.Decode(&line); err != nil {
+					return err
+				}
+				total += line.Qty
+				return nil
+			})
+		default:
+			return d.Skip()
+		}
+	})
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	if customer.Name != "Acme" || total != 5 {
+		t.Errorf("got customer %q and total %d, want Acme and 5", customer.Name, total)
+	}
+}
+
+func TestStream_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		opts Options
+		want error
+	}{
+		{"not an object", `[1,2]`, Options{}, ErrInvalid},
+		{"missing field", `{"a":1}`, Options{Required: []string{"b", "a", "c"}}, ErrMissingFields},
+		{"too large", `{"a":"` + strings.Repeat("x", 100) + `"}`, Options{MaxBytes: 50}, ErrTooLarge},
+		{"truncated", `{"a":1`, Options{}, ErrInvalid},
+		{"trailing data", `{"a":1} {}`, Options{}, ErrInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Stream(strings.NewReader(tt.body), tt.opts, func(_ string, d *Decoder) error {
+				return d.Skip()
+			})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Stream() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStream_StopsOnCallbackError(t *testing.T) {
+	stop := errors.New("stop")
+	fields := 0
+	err := Stream(strings.NewReader(`{"a":1,"b":2,"c":3}`), Options{}, func(_ string, d *Decoder) error {
+		fields++
+		return stop
+	})
+	if !errors.Is(err, stop) || fields != 1 {
+		t.Errorf("Stream() = %v after %d fields, want stop after 1", err, fields)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	var v struct {
+		Amount float64 `json:"amount"`
+	}
+	if err := Decode(strings.NewReader(`{"amount":12.5}`), &v, Options{MaxBytes: 64}); err != nil || v.Amount != 12.5 {
+		t.Fatalf("Decode() = %v, amount %v", err, v.Amount)
+	}
+
+	err := Decode(strings.NewReader(`{"amount":1,"other":2}`), &v, Options{DisallowUnknownFields: true})
+	if !errors.Is(err, ErrInvalid) {
+		t.Errorf("Decode() unknown field error = %v, want %v", err, ErrInvalid)
+	}
+
+	err = Decode(strings.NewReader(`{"amount":"`+strings.Repeat("9", 100)+`"}`), &v, Options{MaxBytes: 32})
+	if !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Decode() oversized error = %v, want %v", err, ErrTooLarge)
+	}
+}
+
+func TestDecoder_Fields(t *testing.T) {
+	d := NewDecoder(strings.NewReader(`{"payload":{"customer":"Acme","notes":[1,2]},"persist":true}`), Options{})
+
+	var customer string
+	err := d.Object(func(field string) error {
+		if field != "payload" {
+			return d.Skip()
+		}
+		return d.Fields([]string{"customer"}, func(field string) error {
+			if field == "customer" {
+				return d.Decode(&customer)
+			}
+			return d.Skip()
+		})
+	})
+	if err != nil {
+		t.Fatalf("Fields() error = %v", err)
+	}
+	if customer != "Acme" {
+		t.Errorf("customer = %q, want Acme", customer)
+	}
+
+	d = NewDecoder(strings.NewReader(`{"notes":[]}`), Options{})
+	err = d.Fields([]string{"product", "customer"}, func(string) error { return d.Skip() })
+	if !errors.Is(err, ErrMissingFields) || !strings.Contains(err.Error(), "customer, product") {
+		t.Errorf("Fields() error = %v, want ErrMissingFields for customer, product", err)
+	}
+}
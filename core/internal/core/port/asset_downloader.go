@@ -0,0 +1,10 @@
+package port
+
+import "context"
+
+// AssetDownloader downloads the remote files imported into the asset library.
+type AssetDownloader interface {
+	// Download returns the body of a GET to url. Bodies larger than maxBytes and non-2xx
+	// responses fail.
+	Download(ctx context.Context, url string, maxBytes int64) ([]byte, error)
+}
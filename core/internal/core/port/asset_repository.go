@@ -0,0 +1,65 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// AssetFilters contains optional filters for listing assets.
+type AssetFilters struct {
+	Kind   string // Asset kind; empty for any
+	Tag    string // Normalized tag name
+	Search string // Matches the asset name
+}
+
+// AssetRepository defines the interface for asset library data access.
+type AssetRepository interface {
+	// Create creates an asset.
+	Create(ctx context.Context, asset *entity.Asset) (string, error)
+
+	// CreateVersion stores a version of an asset with its content.
+	CreateVersion(ctx context.Context, version *entity.AssetVersion) error
+
+	// SetCurrentVersion makes a stored version the one used by renders.
+	SetCurrentVersion(ctx context.Context, id string, version int) error
+
+	// FindByID finds an asset of a workspace, with the metadata of its current version.
+	FindByID(ctx context.Context, workspaceID, id string) (*entity.Asset, error)
+
+	// FindByWorkspace lists the assets of a workspace, newest first.
+	FindByWorkspace(ctx context.Context, workspaceID string, filters AssetFilters) ([]*entity.Asset, error)
+
+	// FindBySHA256 finds the asset of a workspace whose current version has the given content hash.
+	// Returns nil when there is none.
+	FindBySHA256(ctx context.Context, workspaceID, sha256 string) (*entity.Asset, error)
+
+	// FindBySourceURL finds the asset of a workspace imported from a remote URL.
+	// Returns nil when there is none.
+	FindBySourceURL(ctx context.Context, workspaceID, sourceURL string) (*entity.Asset, error)
+
+	// SetSourceURL records the remote URL an asset was imported from.
+	SetSourceURL(ctx context.Context, id, sourceURL string) error
+
+	// FindVersions lists the versions of an asset without their content, newest first.
+	FindVersions(ctx context.Context, id string) ([]*entity.AssetVersion, error)
+
+	// FindContent returns a version of a workspace asset with its content.
+	// Version 0 returns the current version. Versions kept in object storage have a StorageKey
+	// instead of Data.
+	FindContent(ctx context.Context, workspaceID, id string, version int) (*entity.AssetVersion, error)
+
+	// FindUsages lists the template versions of the asset's workspace whose content references it,
+	// by its asset:// URL or by the remote URL it was imported from.
+	FindUsages(ctx context.Context, workspaceID, id string) ([]*entity.AssetUsage, error)
+
+	// StorageKeyInUse reports whether an asset version keeps its content under an object storage key.
+	// Copies of a workspace share the keys of its versions.
+	StorageKeyInUse(ctx context.Context, key string) (bool, error)
+
+	// Update updates the name and tags of an asset.
+	Update(ctx context.Context, asset *entity.Asset) error
+
+	// Delete deletes an asset and its versions.
+	Delete(ctx context.Context, id string) error
+}
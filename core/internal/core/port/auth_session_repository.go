@@ -0,0 +1,41 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// AuthSessionFilters defines filters for paginated session queries.
+type AuthSessionFilters struct {
+	Limit         int
+	Offset        int
+	PrincipalType entity.PrincipalType // Empty = all types
+	Search        string               // Matches subject, email or display name
+}
+
+// AuthSessionRepository defines the interface for auth session data access.
+type AuthSessionRepository interface {
+	// Touch records activity for a principal, creating its session on first sight.
+	// requests is added to the session's request counter.
+	Touch(ctx context.Context, session *entity.AuthSession, requests int64) error
+
+	// FindAll lists sessions, most recently active first, with the total count.
+	FindAll(ctx context.Context, filters AuthSessionFilters) ([]*entity.AuthSession, int64, error)
+
+	// FindByID finds a session by ID.
+	FindByID(ctx context.Context, id string) (*entity.AuthSession, error)
+
+	// FindRestricted lists sessions that are revoked or must re-authenticate.
+	FindRestricted(ctx context.Context) ([]*entity.AuthSession, error)
+
+	// Revoke marks a session as revoked by the given user.
+	Revoke(ctx context.Context, id, revokedBy string) error
+
+	// Restore clears the revocation of a session.
+	Restore(ctx context.Context, id string) error
+
+	// RequireReauth rejects credentials of the session issued before the given time.
+	RequireReauth(ctx context.Context, id string, at time.Time) error
+}
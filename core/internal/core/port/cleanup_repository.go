@@ -0,0 +1,45 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// CleanupRepository defines the interface for finding and deleting orphaned data.
+// Deletions check again that each row is still orphaned, so data referenced since it was found is kept.
+type CleanupRepository interface {
+	// FindOrphanedVersionInjectables returns up to limit version injectable IDs whose template version
+	// no longer exists.
+	FindOrphanedVersionInjectables(ctx context.Context, limit int) ([]string, error)
+
+	// DeleteOrphanedVersionInjectables deletes the given version injectables that are still orphaned.
+	DeleteOrphanedVersionInjectables(ctx context.Context, ids []string) (int, error)
+
+	// FindUnusedImageAssets returns up to limit image asset IDs that no template version of their
+	// workspace references and that were not changed since before.
+	FindUnusedImageAssets(ctx context.Context, before time.Time, limit int) ([]string, error)
+
+	// DeleteUnusedImageAssets deletes the given image assets, with their versions, that are still unused.
+	DeleteUnusedImageAssets(ctx context.Context, ids []string, before time.Time) (int, error)
+
+	// FindExpiredPreviewTokens returns up to limit preview token IDs that expired or were revoked before before.
+	FindExpiredPreviewTokens(ctx context.Context, before time.Time, limit int) ([]string, error)
+
+	// DeleteExpiredPreviewTokens deletes the given preview tokens that expired or were revoked before before.
+	DeleteExpiredPreviewTokens(ctx context.Context, ids []string, before time.Time) (int, error)
+
+	// FindStaleSandboxes returns up to limit sandbox workspace IDs that expired before now or whose
+	// source workspace was deleted.
+	FindStaleSandboxes(ctx context.Context, now time.Time, limit int) ([]string, error)
+
+	// CreateRun records a cleanup run.
+	CreateRun(ctx context.Context, run *entity.CleanupRun) (string, error)
+
+	// FindRuns lists the latest cleanup runs, newest first.
+	FindRuns(ctx context.Context, limit int) ([]*entity.CleanupRun, error)
+
+	// DeleteRunsBefore removes the runs started before the given time.
+	DeleteRunsBefore(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// CurrencyRateProvider publishes the exchange rates injectors and table formulas convert amounts
+// with. The engine caches the tables it returns, so providers need not cache themselves.
+type CurrencyRateProvider interface {
+	// Name identifies the provider in the rates recorded for a render, e.g. "ecb".
+	Name() string
+
+	// RateTable returns the rates published on date, or on the last day before it with rates
+	// (weekends and holidays have none). A zero date returns the latest rates.
+	RateTable(ctx context.Context, date time.Time) (*entity.CurrencyRateTable, error)
+}
@@ -0,0 +1,13 @@
+package port
+
+import "context"
+
+// DocumentIndexer derives browsing and search data from rendered PDFs.
+type DocumentIndexer interface {
+	// Thumbnail renders the first page of a PDF as a PNG image.
+	Thumbnail(ctx context.Context, pdf []byte) ([]byte, error)
+
+	// ExtractText returns the plain text of a PDF.
+	// It returns an empty string when text extraction is not configured.
+	ExtractText(ctx context.Context, pdf []byte) (string, error)
+}
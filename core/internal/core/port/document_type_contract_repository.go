@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// DocumentTypeContractRepository defines the interface for document type payload contract data access.
+type DocumentTypeContractRepository interface {
+	// FindByDocumentTypeID finds the contract of a document type.
+	// Returns ErrPayloadContractNotFound when the type has none.
+	FindByDocumentTypeID(ctx context.Context, documentTypeID string) (*entity.DocumentTypeContract, error)
+
+	// FindByDocumentTypeCode finds the contract of the document type a tenant renders for a code:
+	// its own type, or the global one when it has none. Returns ErrPayloadContractNotFound when
+	// the type has no contract.
+	FindByDocumentTypeCode(ctx context.Context, tenantCode, documentTypeCode string) (*entity.DocumentTypeContract, error)
+
+	// Upsert creates or replaces the contract of a document type.
+	Upsert(ctx context.Context, contract *entity.DocumentTypeContract) error
+
+	// Delete removes the contract of a document type.
+	Delete(ctx context.Context, documentTypeID string) error
+}
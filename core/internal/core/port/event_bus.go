@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// EventHandler reacts to a domain event. Type-assert event to the struct matching
+// the subscribed type (e.g., entity.VersionPublished).
+type EventHandler func(ctx context.Context, event entity.DomainEvent) error
+
+// EventDispatcher hands domain events to in-process subscribers.
+// Register subscribers via engine.Subscribe().
+type EventDispatcher interface {
+	// Dispatch runs the handlers subscribed to the event's type in registration order.
+	// Handler errors are joined; every handler runs even if an earlier one fails.
+	Dispatch(ctx context.Context, event entity.DomainEvent) error
+}
@@ -0,0 +1,70 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// EventWebhookRepository defines the interface for workspace event webhook data access.
+type EventWebhookRepository interface {
+	// Create creates a new webhook.
+	Create(ctx context.Context, webhook *entity.EventWebhook) (string, error)
+
+	// FindByID finds a webhook by ID within a workspace.
+	FindByID(ctx context.Context, workspaceID, id string) (*entity.EventWebhook, error)
+
+	// FindByWorkspace lists all webhooks of a workspace.
+	FindByWorkspace(ctx context.Context, workspaceID string) ([]*entity.EventWebhook, error)
+
+	// FindEnabledByWorkspace lists enabled webhooks of a workspace (used when queuing events).
+	FindEnabledByWorkspace(ctx context.Context, workspaceID string) ([]*entity.EventWebhook, error)
+
+	// Update updates a webhook, including its secret.
+	Update(ctx context.Context, webhook *entity.EventWebhook) error
+
+	// Delete deletes a webhook and its delivery log within a workspace.
+	Delete(ctx context.Context, workspaceID, id string) error
+}
+
+// WebhookDeliveryRepository defines the interface for the event webhook delivery queue and log.
+type WebhookDeliveryRepository interface {
+	// Enqueue stores pending deliveries. A delivery of an event already queued for the same
+	// webhook is skipped, so events published more than once are delivered once.
+	Enqueue(ctx context.Context, deliveries []*entity.WebhookDelivery) error
+
+	// Claim returns up to limit pending deliveries that are due, oldest first, and hides them
+	// from other dispatchers for lease by pushing their next attempt forward.
+	// Each claim counts as a delivery attempt.
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]*entity.WebhookDelivery, error)
+
+	// MarkDelivered records a successful delivery.
+	MarkDelivered(ctx context.Context, id string, statusCode int) error
+
+	// MarkRetry records a failed attempt and schedules the next one at retryAt.
+	// statusCode is nil when the endpoint could not be reached.
+	MarkRetry(ctx context.Context, id string, statusCode *int, lastError string, retryAt time.Time) error
+
+	// MarkFailed gives up on a delivery after its last attempt.
+	MarkFailed(ctx context.Context, id string, statusCode *int, lastError string) error
+
+	// FindByID finds a delivery by ID within a webhook.
+	FindByID(ctx context.Context, webhookID, id string) (*entity.WebhookDelivery, error)
+
+	// FindByWebhook lists the deliveries of a webhook, newest first.
+	FindByWebhook(ctx context.Context, webhookID string, filter entity.WebhookDeliveryFilter) ([]*entity.WebhookDelivery, error)
+
+	// Reschedule moves a delivery back to pending, due now, with its attempts reset.
+	Reschedule(ctx context.Context, webhookID, id string) error
+
+	// DeleteFinishedBefore removes delivered and failed deliveries created before the given time.
+	DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// EventWebhookSender posts a webhook envelope to an integrator endpoint.
+type EventWebhookSender interface {
+	// Send posts the delivery payload to the webhook URL, signed with the webhook secret. It returns the response status
+	// code, or 0 when no response was received; non-2xx responses are returned as errors.
+	Send(ctx context.Context, webhook *entity.EventWebhook, delivery *entity.WebhookDelivery) (int, error)
+}
@@ -0,0 +1,41 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// HostedDocumentRepository defines the interface for hosted document data access.
+type HostedDocumentRepository interface {
+	// Create stores a hosted document with its PDF.
+	Create(ctx context.Context, doc *entity.HostedDocument) (string, error)
+
+	// FindByID finds a hosted document by ID, including its PDF.
+	FindByID(ctx context.Context, id string) (*entity.HostedDocument, error)
+
+	// FindByWorkspace lists the hosted documents of a workspace without their PDFs.
+	// An empty query lists newest first; otherwise documents whose filename or extracted text
+	// match the query are returned by relevance, with a snippet of the matching text.
+	FindByWorkspace(ctx context.Context, workspaceID, query string) ([]*entity.HostedDocument, error)
+
+	// FindThumbnail returns the thumbnail of a workspace document, or nil when it is not generated yet.
+	FindThumbnail(ctx context.Context, workspaceID, id string) ([]byte, error)
+
+	// RecordView increments the view count of a document.
+	RecordView(ctx context.Context, id string) error
+
+	// ClaimForIndexing claims up to limit unexpired documents waiting for indexing, with their PDFs,
+	// and hides them from other indexers for the lease. Each claim counts as an attempt.
+	ClaimForIndexing(ctx context.Context, limit int, lease time.Duration) ([]*entity.HostedDocument, error)
+
+	// SaveIndex stores the thumbnail and text of a document and marks it indexed.
+	SaveIndex(ctx context.Context, index *entity.HostedDocumentIndex) error
+
+	// MarkIndexFailed records that indexing a document was given up.
+	MarkIndexFailed(ctx context.Context, id, reason string) error
+
+	// Delete deletes a hosted document.
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,15 @@
+package port
+
+import "image"
+
+// ImageEncoder writes resized asset images in a format the standard library cannot encode,
+// such as WebP or AVIF. PNG and JPEG are built in.
+// Register encoders with engine.RegisterImageEncoder; clients that list the content type in their
+// Accept header receive it.
+type ImageEncoder interface {
+	// ContentType is the media type the encoder writes, e.g. "image/webp".
+	ContentType() string
+
+	// Encode encodes an image. quality ranges from 1 to 100; lossless encoders may ignore it.
+	Encode(img image.Image, quality int) ([]byte, error)
+}
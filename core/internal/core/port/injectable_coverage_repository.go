@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// InjectableCoverageRepository defines the interface for the per-day counts of where the values of
+// template injectables came from.
+type InjectableCoverageRepository interface {
+	// AddDays adds counts to the per-day counts of their templates and injectables.
+	AddDays(ctx context.Context, days []*entity.InjectableCoverageDay) error
+
+	// FindByTemplate sums the counts of each injectable of a template since a day, sorted by key.
+	// The status of the returned coverages is not set.
+	FindByTemplate(ctx context.Context, templateID string, since time.Time) ([]*entity.InjectableCoverage, error)
+
+	// DeleteBefore deletes the counts of the days before a day, returning how many were deleted.
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
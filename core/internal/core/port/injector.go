@@ -47,6 +47,16 @@ type Injector interface {
 // The result (user's custom struct) will be available via InjectorContext.InitData().
 type InitFunc func(ctx context.Context, injCtx *entity.InjectorContext) (any, error)
 
+// LabeledInjector is an optional interface for injectors that carry their own translated name and
+// description instead of having them in injectors.i18n.yaml, such as injectors defined at runtime.
+type LabeledInjector interface {
+	// Labels returns the name of the injector by locale.
+	Labels() map[string]string
+
+	// Descriptions returns the description of the injector by locale.
+	Descriptions() map[string]string
+}
+
 // TableSchemaProvider is an optional interface that table injectors can implement
 // to expose their column structure at the API level.
 // This allows the frontend to know what columns a TABLE injectable will have.
@@ -0,0 +1,39 @@
+package port
+
+// InjectorPack is a distributable set of injectors (e.g. a Stripe or Salesforce pack) shipped as a
+// Go module with a manifest and translations. Register it with Engine.RegisterPack.
+type InjectorPack interface {
+	// Manifest describes the pack: its namespace, version and the versions it works with.
+	Manifest() InjectorPackManifest
+
+	// I18n returns the translations of the injectors and groups of the pack, in the format of
+	// injectors.i18n.yaml. The user i18n file overrides them.
+	I18n() []byte
+
+	// Injectors returns the injectors of the pack. Their codes start with the namespace and an
+	// underscore (e.g. stripe_customer_email).
+	Injectors() []Injector
+}
+
+// InjectorPackManifest is the pack.yaml of an injector pack.
+type InjectorPackManifest struct {
+	// Name is the display name of the pack, e.g. "Stripe pack".
+	Name string `yaml:"name"`
+
+	// Namespace prefixes the codes of the injectors and groups of the pack. Lowercase letters and
+	// digits, starting with a letter; unique among the registered packs.
+	Namespace string `yaml:"namespace"`
+
+	// Version of the pack, as MAJOR.MINOR.PATCH.
+	Version string `yaml:"version"`
+
+	// Description of what the pack resolves.
+	Description string `yaml:"description,omitempty"`
+
+	// Engine constrains the pdf-forge versions the pack works with, e.g. ">=1.4.0 <2.0.0".
+	// Empty accepts any version.
+	Engine string `yaml:"engine,omitempty"`
+
+	// Requires constrains the versions of other packs the pack depends on, by namespace.
+	Requires map[string]string `yaml:"requires,omitempty"`
+}
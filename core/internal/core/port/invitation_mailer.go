@@ -0,0 +1,14 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// InvitationMailer delivers workspace invitation emails.
+// The raw token is only available at send time; embed it in the link the invitee opens
+// so the frontend can call POST /api/v1/me/invitations/accept with it.
+type InvitationMailer interface {
+	SendInvitation(ctx context.Context, invitation *entity.WorkspaceInvitation, workspace *entity.Workspace, token string) error
+}
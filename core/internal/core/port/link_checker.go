@@ -0,0 +1,10 @@
+package port
+
+import "context"
+
+// LinkChecker checks that the external links of a document still resolve.
+type LinkChecker interface {
+	// CheckLink returns an error describing why the URL is dead. URLs the checker is not
+	// allowed to request are not checked and return nil.
+	CheckLink(ctx context.Context, url string) error
+}
@@ -0,0 +1,16 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// MaintenanceRepository defines the interface for the platform maintenance state.
+type MaintenanceRepository interface {
+	// Get returns the current maintenance state.
+	Get(ctx context.Context) (*entity.MaintenanceState, error)
+
+	// Update replaces the maintenance state.
+	Update(ctx context.Context, state *entity.MaintenanceState) error
+}
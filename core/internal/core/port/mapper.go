@@ -1,9 +1,11 @@
 package port
 
 import (
+	"bytes"
 	"context"
 
 	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/payload"
 )
 
 // MapperContext contains the context for request mapping.
@@ -15,15 +17,25 @@ type MapperContext struct {
 	Operation       string             // operation type
 	Environment     entity.Environment // render environment (dev or prod)
 	Headers         map[string]string  // HTTP request headers
-	RawBody         []byte             // unparsed body
+	RawBody         []byte             // unparsed body; nil when the body is streamed through Payload
+	Payload         *payload.Decoder   // streamed render payload, positioned at its value; nil when RawBody is set
+}
+
+// Decoder returns a decoder of the body: Payload when the request is streamed, otherwise
+// RawBody bounded by opts. Map must consume exactly one value through it.
+func (c *MapperContext) Decoder(opts payload.Options) *payload.Decoder {
+	if c.Payload != nil {
+		return c.Payload
+	}
+	return payload.NewDecoder(bytes.NewReader(c.RawBody), opts)
 }
 
 // RequestMapper defines the interface that users implement to map requests.
-// The user only needs to parse RawBody and return the typed payload.
+// The user only needs to parse the body and return the typed payload.
 // If multiple document types are needed, the user handles routing internally.
 // The system handles building InjectorContext from MapperContext + payload.
 type RequestMapper interface {
-	// Map parses the raw body and returns the business-specific payload.
+	// Map parses the body and returns the business-specific payload.
 	// The system handles building InjectorContext from MapperContext + payload.
 	Map(ctx context.Context, mapCtx *MapperContext) (any, error)
 }
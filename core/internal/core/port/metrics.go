@@ -0,0 +1,31 @@
+package port
+
+import "time"
+
+// RenderPhase is a step of a PDF render measured on its own.
+type RenderPhase string
+
+const (
+	// RenderPhaseConvert generates the Typst source from the document.
+	RenderPhaseConvert RenderPhase = "convert"
+	// RenderPhaseImageFetch downloads the remote images and external PDFs the source references.
+	RenderPhaseImageFetch RenderPhase = "image_fetch"
+	// RenderPhaseCompile runs the Typst compiler.
+	RenderPhaseCompile RenderPhase = "compile"
+)
+
+// Metrics records measurements of the engine for monitoring. Implementations must be safe for
+// concurrent use.
+type Metrics interface {
+	// ObserveRender records a PDF render and whether it failed.
+	ObserveRender(d time.Duration, failed bool)
+
+	// ObserveRenderPhase records a phase of a PDF render.
+	ObserveRenderPhase(phase RenderPhase, d time.Duration)
+
+	// ObserveTypstFailure counts a failed Typst compile by reason: "timeout", "canceled" or "error".
+	ObserveTypstFailure(reason string)
+
+	// ObserveInjector records the resolution of a registry injector and whether it failed.
+	ObserveInjector(code string, d time.Duration, failed bool)
+}
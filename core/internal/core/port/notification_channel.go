@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// NotificationChannel mirrors in-product notifications to an external medium
+// (email, Slack, etc.). Register implementations via engine.RegisterNotificationChannel().
+// Notifications are always persisted first; channel delivery is best-effort.
+type NotificationChannel interface {
+	// Name returns a short identifier used in logs (e.g., "email", "slack").
+	Name() string
+
+	// Deliver sends the notification to the recipient.
+	// Errors are logged and never fail the originating operation.
+	Deliver(ctx context.Context, notification *entity.Notification, recipient *entity.User) error
+}
+
+// ChatWebhookSender posts a notification to a chat platform incoming webhook (Slack, Teams).
+type ChatWebhookSender interface {
+	// Send formats the notification for the platform and posts it to webhookURL.
+	Send(ctx context.Context, webhookURL string, notification *entity.Notification) error
+}
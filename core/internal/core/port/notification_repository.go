@@ -0,0 +1,33 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// NotificationFilters defines filters for paginated notification queries.
+type NotificationFilters struct {
+	Limit      int
+	Offset     int
+	UnreadOnly bool
+}
+
+// NotificationRepository defines the interface for notification data access.
+type NotificationRepository interface {
+	// Create creates a new notification.
+	Create(ctx context.Context, notification *entity.Notification) (string, error)
+
+	// FindByUser lists notifications for a user, newest first, with the total count.
+	FindByUser(ctx context.Context, userID string, filters NotificationFilters) ([]*entity.Notification, int64, error)
+
+	// CountUnread returns the number of unread notifications for a user.
+	CountUnread(ctx context.Context, userID string) (int64, error)
+
+	// MarkRead marks a single notification as read.
+	// Returns ErrNotificationNotFound if it does not exist or belongs to another user.
+	MarkRead(ctx context.Context, id, userID string) error
+
+	// MarkAllRead marks every unread notification of a user as read.
+	MarkAllRead(ctx context.Context, userID string) error
+}
@@ -0,0 +1,28 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// NotificationWebhookRepository defines the interface for workspace notification webhook data access.
+type NotificationWebhookRepository interface {
+	// Create creates a new webhook.
+	Create(ctx context.Context, webhook *entity.NotificationWebhook) (string, error)
+
+	// FindByID finds a webhook by ID within a workspace.
+	FindByID(ctx context.Context, workspaceID, id string) (*entity.NotificationWebhook, error)
+
+	// FindByWorkspace lists all webhooks of a workspace.
+	FindByWorkspace(ctx context.Context, workspaceID string) ([]*entity.NotificationWebhook, error)
+
+	// FindEnabledByWorkspace lists enabled webhooks of a workspace (used during delivery).
+	FindEnabledByWorkspace(ctx context.Context, workspaceID string) ([]*entity.NotificationWebhook, error)
+
+	// Update updates a webhook.
+	Update(ctx context.Context, webhook *entity.NotificationWebhook) error
+
+	// Delete deletes a webhook within a workspace.
+	Delete(ctx context.Context, workspaceID, id string) error
+}
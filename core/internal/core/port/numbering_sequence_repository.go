@@ -0,0 +1,55 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// NumberingSequenceRepository defines the interface for numbering sequence data access, and for
+// the registry of the numbers drawn from them.
+type NumberingSequenceRepository interface {
+	// Create creates a sequence.
+	Create(ctx context.Context, seq *entity.NumberingSequence) (string, error)
+
+	// FindByID finds a sequence of a workspace.
+	FindByID(ctx context.Context, workspaceID, id string) (*entity.NumberingSequence, error)
+
+	// FindByWorkspace lists the sequences of a workspace, oldest first.
+	FindByWorkspace(ctx context.Context, workspaceID string) ([]*entity.NumberingSequence, error)
+
+	// FindActiveByDocumentType finds the active sequence of a document type in a workspace.
+	// Returns entity.ErrNumberingSequenceNotFound when it has none.
+	FindActiveByDocumentType(ctx context.Context, workspaceID, documentTypeID string) (*entity.NumberingSequence, error)
+
+	// Update updates the settings of a sequence. Its counter is left as is.
+	Update(ctx context.Context, seq *entity.NumberingSequence) error
+
+	// Lock finds a sequence and locks it until the transaction of ctx ends.
+	Lock(ctx context.Context, id string) (*entity.NumberingSequence, error)
+
+	// Advance stores the counter of a sequence after a draw.
+	Advance(ctx context.Context, seq *entity.NumberingSequence) error
+
+	// CreateNumber adds a drawn number to the registry.
+	CreateNumber(ctx context.Context, number *entity.SequenceNumber) (string, error)
+
+	// UpdateNumberStatus changes the status of a number in the registry.
+	UpdateNumberStatus(ctx context.Context, id string, status entity.SequenceNumberStatus) error
+
+	// FindReusableNumber finds the lowest number of a sequence and year that a failed render
+	// released, or that is pending since before staleBefore. Returns nil when there is none.
+	FindReusableNumber(ctx context.Context, sequenceID string, year int, staleBefore time.Time) (*entity.SequenceNumber, error)
+
+	// ReassignNumber gives a number of the registry to another render: it stores its status,
+	// formatted number, render and issue time.
+	ReassignNumber(ctx context.Context, number *entity.SequenceNumber) error
+
+	// SettleNumber changes the status of a pending number, as long as it is still reserved at
+	// its IssuedAt. Returns entity.ErrSequenceNumberReassigned when it was given to another render.
+	SettleNumber(ctx context.Context, number *entity.SequenceNumber, status entity.SequenceNumberStatus) error
+
+	// FindNumbers lists the numbers drawn from a sequence, newest first, at most limit.
+	FindNumbers(ctx context.Context, sequenceID string, limit int) ([]*entity.SequenceNumber, error)
+}
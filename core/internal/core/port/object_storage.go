@@ -0,0 +1,40 @@
+package port
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// ObjectStorage persists blobs, such as rendered PDFs and asset content, under keys of the form "a/b/c.pdf".
+// Unlike StorageProvider, which backs the image gallery, it has no listing or upload flow:
+// the engine writes objects itself and hands out signed URLs to read them.
+type ObjectStorage interface {
+	// Put stores an object, replacing any object with the same key.
+	Put(ctx context.Context, obj *StorageObject) error
+
+	// Get opens an object for reading. The caller closes the returned reader.
+	// Returns entity.ErrObjectNotFound when the key holds no object.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes an object. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+
+	// SignedURL returns a URL that reads the object without credentials until ttl elapses.
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// StorageObject is an object written with ObjectStorage.Put.
+type StorageObject struct {
+	Key         string
+	ContentType string
+	Size        int64 // Length of Body in bytes
+	Body        io.Reader
+}
+
+// SignedObjectServer is implemented by ObjectStorage adapters whose signed URLs point back at
+// the engine (e.g. local disk). The engine then serves /api/v1/public/objects/{key} with it.
+type SignedObjectServer interface {
+	// VerifySignedURL checks the expiry and signature query values of a signed URL for key.
+	VerifySignedURL(key, expires, signature string) error
+}
@@ -0,0 +1,43 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// OutboxRepository defines the interface for the transactional outbox.
+type OutboxRepository interface {
+	// Append stores a pending event. Called inside TransactionManager.WithinTx, the event
+	// commits or rolls back together with the state change that produced it.
+	Append(ctx context.Context, event *entity.OutboxEvent) error
+
+	// ClaimBatch returns up to limit pending events that are due, oldest first, and hides
+	// them from other relays for lease by pushing their availability forward.
+	// Each claim counts as a delivery attempt.
+	ClaimBatch(ctx context.Context, limit int, lease time.Duration) ([]*entity.OutboxEvent, error)
+
+	// MarkDelivered records a successful delivery.
+	MarkDelivered(ctx context.Context, id string) error
+
+	// MarkRetry records a failed attempt and schedules the next one at retryAt.
+	MarkRetry(ctx context.Context, id, lastError string, retryAt time.Time) error
+
+	// MarkFailed gives up on an event after its last attempt.
+	MarkFailed(ctx context.Context, id, lastError string) error
+
+	// DeleteDeliveredBefore removes events delivered before the given time.
+	DeleteDeliveredBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// EventPublisher delivers outbox events to a destination (chat webhooks, an event bus, ...).
+// Delivery is at-least-once: an event is published again when any publisher fails,
+// so implementations must tolerate duplicates. Ignore event types you do not handle.
+type EventPublisher interface {
+	// Name returns a short identifier used in logs.
+	Name() string
+
+	// Publish delivers the event. Returning an error schedules a retry.
+	Publish(ctx context.Context, event *entity.OutboxEvent) error
+}
@@ -2,7 +2,9 @@ package port
 
 import (
 	"context"
+	"slices"
 
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
 )
 
@@ -24,6 +26,125 @@ type RenderPreviewRequest struct {
 	// Called during Typst source generation for URLs that are not http://, https://, or data:.
 	// May be nil if no custom resolution is needed.
 	ImageURLResolver func(ctx context.Context, url string) (string, error)
+
+	// Watermark is text stamped diagonally across every page, taking precedence over the
+	// watermark of the overlays. Empty means no watermark besides the overlays.
+	Watermark string
+
+	// Overlays replace the overlays of the document page config: a watermark, a stamp and Bates
+	// numbering printed on every page. Nil keeps the document overlays.
+	Overlays *portabledoc.Overlays
+
+	// Imposition lays the rendered pages out on printer sheets. Nil returns the pages as rendered.
+	Imposition *ImpositionOptions
+
+	// DocumentID identifies the generated document (e.g. a certificate number) and seeds security
+	// patterns. Empty derives the seed from the injectable values.
+	DocumentID string
+
+	// Layout switches to one of the layout variants declared by the document. Nil keeps its page config.
+	Layout *LayoutParams
+
+	// Degraded renders despite images and external PDFs that cannot be downloaded: images show a
+	// placeholder, external PDF pages are left out, and each failure is reported as a DEGRADED_*
+	// warning. Off, an external PDF that cannot be included fails the render.
+	Degraded bool
+
+	// UnknownNodes is how nodes of a type the converters do not know are rendered: flattened to
+	// their content (empty or FLATTEN), shown as a placeholder box, or failing the render with
+	// entity.ErrUnknownNodes. Rendered unknown nodes are reported as UNKNOWN_NODE warnings.
+	UnknownNodes entity.UnknownNodeMode
+
+	// FontDirs are searched for fonts after the configured font directories, e.g. the directory
+	// holding the fonts uploaded to the workspace of the template.
+	FontDirs []string
+
+	// ConvertCurrency converts amounts for the convert() function of table formulas. Nil makes
+	// convert() fail, which leaves the calculated cells empty.
+	ConvertCurrency entity.CurrencyConvertFunc
+}
+
+// ImpositionLayout defines how rendered pages are arranged on printer sheets.
+type ImpositionLayout string
+
+const (
+	ImpositionSingle  ImpositionLayout = "SINGLE"  // One page per sheet (trim, bleed and marks only)
+	ImpositionTwoUp   ImpositionLayout = "2UP"     // Two consecutive pages side by side per sheet
+	ImpositionBooklet ImpositionLayout = "BOOKLET" // Saddle-stitch order: print duplex, fold the stack in half
+)
+
+// IsValid checks if the imposition layout is valid.
+func (l ImpositionLayout) IsValid() bool {
+	switch l {
+	case ImpositionSingle, ImpositionTwoUp, ImpositionBooklet:
+		return true
+	}
+	return false
+}
+
+// Imposition size limits, in millimetres.
+const (
+	ImpositionMaxTrimMM  = 1200.0
+	ImpositionMaxBleedMM = 10.0
+)
+
+// ImpositionOptions prepares a render for physical printing. Sizes are in millimetres.
+type ImpositionOptions struct {
+	Layout ImpositionLayout
+
+	// TrimWidthMM and TrimHeightMM are the finished page size. Zero keeps the template page size.
+	// Pages are scaled to fit the trim size without distortion.
+	TrimWidthMM  float64
+	TrimHeightMM float64
+
+	// BleedMM is the margin added around each page that is cut off after printing.
+	BleedMM float64
+
+	// CropMarks draws trim marks outside the bleed, in a slug area added around the sheet.
+	CropMarks bool
+}
+
+// Validate checks if the imposition options are valid.
+func (o *ImpositionOptions) Validate() error {
+	if !o.Layout.IsValid() {
+		return entity.ErrInvalidImposition
+	}
+	if (o.TrimWidthMM == 0) != (o.TrimHeightMM == 0) {
+		return entity.ErrInvalidImposition
+	}
+	if o.TrimWidthMM < 0 || o.TrimHeightMM < 0 || o.TrimWidthMM > ImpositionMaxTrimMM || o.TrimHeightMM > ImpositionMaxTrimMM {
+		return entity.ErrInvalidImposition
+	}
+	if o.BleedMM < 0 || o.BleedMM > ImpositionMaxBleedMM {
+		return entity.ErrInvalidImposition
+	}
+	return nil
+}
+
+// LayoutParams selects a layout variant at render time. Empty fields keep the document page config.
+type LayoutParams struct {
+	PaperSize    string  // Page format, e.g. "A4" or "LETTER"
+	MarginPreset string  // "narrow" | "normal" | "wide"
+	FontScale    float64 // Font size multiplier; zero keeps the template sizes
+}
+
+// Validate checks that every requested value is declared in the page config's layout variants.
+// The page config's own format and a font scale of 1 are always allowed.
+func (p *LayoutParams) Validate(config *portabledoc.PageConfig) error {
+	variants := config.LayoutVariants
+	if variants == nil {
+		variants = &portabledoc.LayoutVariants{}
+	}
+	if p.PaperSize != "" && p.PaperSize != config.FormatID && !slices.Contains(variants.PaperSizes, p.PaperSize) {
+		return entity.ErrLayoutNotAllowed
+	}
+	if p.MarginPreset != "" && !slices.Contains(variants.MarginPresets, p.MarginPreset) {
+		return entity.ErrLayoutNotAllowed
+	}
+	if p.FontScale != 0 && p.FontScale != 1 && !slices.Contains(variants.FontScales, p.FontScale) {
+		return entity.ErrLayoutNotAllowed
+	}
+	return nil
 }
 
 // RenderPreviewResult contains the result of rendering a preview PDF.
@@ -36,6 +157,63 @@ type RenderPreviewResult struct {
 
 	// PageCount is the number of pages in the generated PDF.
 	PageCount int
+
+	// Warnings are the problems found while rendering, such as characters no configured font
+	// covers or Typst compiler warnings. A render with warnings still succeeds.
+	Warnings []entity.RenderWarning
+
+	// InjectorTimings are when each registry injector ran while resolving the injectables and how
+	// long it took, sorted by code. Set by the render API, not by renderers.
+	InjectorTimings []entity.InjectorTiming
+
+	// InjectableSources are where the value of each injectable of the version came from, by key.
+	// Set by the render API, not by renderers.
+	InjectableSources map[string]entity.InjectableSource
+
+	// CurrencyRates are the exchange rates injectors and table formulas converted amounts with,
+	// sorted by currency pair. Set by the render API, not by renderers.
+	CurrencyRates []entity.CurrencyRate
+
+	// CurrencyRecordID is the ID of the stored record of CurrencyRates, empty when no amount was
+	// converted. Set by the render API, not by renderers.
+	CurrencyRecordID string
+
+	// DocumentNumber is the number the render drew from the numbering sequence of its document
+	// type, empty when it drew none. Set by the render API, not by renderers.
+	DocumentNumber string
+}
+
+// TypstProjectResult contains the Typst project generated for a render.
+type TypstProjectResult struct {
+	// Archive is a zip with main.typ, the images it references under assets/ and a fonts manifest.
+	Archive []byte
+
+	// Filename is the suggested filename for the archive.
+	Filename string
+}
+
+// HTMLRenderResult contains the result of rendering a document to HTML.
+type HTMLRenderResult struct {
+	// HTML is a standalone page: the styles are inline and images keep their source URLs.
+	HTML []byte
+
+	// Filename is the suggested filename for the page.
+	Filename string
+
+	// Warnings are the parts of the document HTML cannot show, such as page numbers.
+	Warnings []entity.RenderWarning
+}
+
+// DocxRenderResult contains the result of rendering a document to DOCX.
+type DocxRenderResult struct {
+	// Docx is an editable Word document with its images embedded.
+	Docx []byte
+
+	// Filename is the suggested filename for the document.
+	Filename string
+
+	// Warnings are the parts of the document DOCX leaves out, such as security patterns.
+	Warnings []entity.RenderWarning
 }
 
 // PDFRenderer defines the interface for PDF rendering operations.
@@ -45,7 +223,50 @@ type PDFRenderer interface {
 	// Conditional blocks are evaluated based on the injectable values.
 	RenderPreview(ctx context.Context, req *RenderPreviewRequest) (*RenderPreviewResult, error)
 
+	// ExportTypstProject generates the Typst project RenderPreview would compile, without compiling it,
+	// so the render can be reproduced offline with the typst CLI.
+	ExportTypstProject(ctx context.Context, req *RenderPreviewRequest) (*TypstProjectResult, error)
+
+	// RenderHTML converts the document RenderPreview would render to a standalone HTML page, for
+	// in-browser previews. Values are resolved as in the PDF; nodes restricted to the html output
+	// are shown and those restricted to other outputs are left out. Imposition is not supported.
+	RenderHTML(ctx context.Context, req *RenderPreviewRequest) (*HTMLRenderResult, error)
+
+	// RenderDocx converts the document RenderPreview would render to an editable Word document,
+	// for hand-off and redlining. Values are resolved as in the PDF and nodes restricted to the
+	// pdf output are included. Imposition is not supported.
+	RenderDocx(ctx context.Context, req *RenderPreviewRequest) (*DocxRenderResult, error)
+
 	// Close releases any resources held by the renderer.
 	// This should be called when the renderer is no longer needed.
 	Close() error
 }
+
+// RendererBackend is an alternative PDF engine (e.g. LaTeX or headless Chromium) for templates whose
+// document selects it by name in meta.renderer, covering cases Typst cannot express.
+// Register backends with engine.RegisterRendererBackend; Typst stays the default.
+type RendererBackend interface {
+	// Name is the value documents set in meta.renderer to select this backend.
+	Name() string
+
+	// Render generates the PDF for a render request. Layout, imposition and watermark options are
+	// passed as requested; backends that do not support one should return an error rather than ignore it.
+	Render(ctx context.Context, req *RenderPreviewRequest) (*RenderPreviewResult, error)
+}
+
+// RenderCapacityReporter reports the render load of this instance for autoscaling.
+type RenderCapacityReporter interface {
+	RenderCapacity() entity.RenderCapacity
+}
+
+// EditorSchemaProvider reports the document features this build renders, for the editor to
+// disable the ones the converter would drop.
+type EditorSchemaProvider interface {
+	EditorSchema() portabledoc.EditorSchema
+}
+
+// FontFamilyLister lists the font families installed for the renderer, for the editor font picker.
+type FontFamilyLister interface {
+	// FontFamilies returns the installed families, sorted. Nil when they cannot be listed.
+	FontFamilies(ctx context.Context) []string
+}
@@ -0,0 +1,33 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// PluginRuntime loads WASM plugins into a sandbox that only exposes the host functions granted to
+// each plugin.
+type PluginRuntime interface {
+	// Load compiles module, checks that it imports nothing beyond grant and reads its manifest.
+	// Errors wrap entity.ErrInvalidPlugin.
+	Load(ctx context.Context, name string, module []byte, grant entity.PluginGrant) (Plugin, error)
+}
+
+// Plugin is a loaded WASM plugin. Every call runs in a fresh instance of the module, so calls are
+// independent, may be concurrent and share no memory.
+type Plugin interface {
+	// Manifest returns the manifest read at load.
+	Manifest() *entity.PluginManifest
+
+	// Resolve calls pf_resolve with input and returns the value, nil for no value.
+	// Errors wrap entity.ErrPluginFailed.
+	Resolve(ctx context.Context, input *entity.PluginInjectorInput) (any, error)
+
+	// PostProcess calls pf_post_process with a rendered PDF and returns the PDF to use instead.
+	// Errors wrap entity.ErrPluginFailed.
+	PostProcess(ctx context.Context, render *entity.PluginRender, pdf []byte) ([]byte, error)
+
+	// Close releases the compiled module.
+	Close(ctx context.Context) error
+}
@@ -0,0 +1,28 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// PreviewTokenRepository defines the interface for version preview token data access.
+type PreviewTokenRepository interface {
+	// Create creates a new preview token.
+	Create(ctx context.Context, token *entity.PreviewToken) (string, error)
+
+	// FindByID finds a preview token by ID.
+	FindByID(ctx context.Context, id string) (*entity.PreviewToken, error)
+
+	// FindByTokenHash finds a preview token by the hash of its shared token.
+	FindByTokenHash(ctx context.Context, tokenHash string) (*entity.PreviewToken, error)
+
+	// FindByVersion lists the preview tokens of a version, newest first, including expired and revoked ones.
+	FindByVersion(ctx context.Context, versionID string) ([]*entity.PreviewToken, error)
+
+	// Revoke stores the revocation time of a token.
+	Revoke(ctx context.Context, token *entity.PreviewToken) error
+
+	// TouchLastUsed records that a token was just used.
+	TouchLastUsed(ctx context.Context, id string) error
+}
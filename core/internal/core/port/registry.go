@@ -13,6 +13,10 @@ type InjectorRegistry interface {
 	// Register registra un inyector en el registry.
 	Register(injector Injector) error
 
+	// Unregister elimina un inyector del registry.
+	// Retorna false si no estaba registrado.
+	Unregister(code string) bool
+
 	// Get obtiene un inyector por su code.
 	Get(code string) (Injector, bool)
 
@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// RemoteInjectorClient calls a remote injector service over the remote injector protocol. Calls
+// may be concurrent.
+type RemoteInjectorClient interface {
+	// Manifest returns the injectors the service provides.
+	// Errors wrap entity.ErrRemoteInjectorFailed.
+	Manifest(ctx context.Context) (*entity.RemoteInjectorManifest, error)
+
+	// Resolve asks the service for the value of an injector.
+	// Errors wrap entity.ErrRemoteInjectorFailed.
+	Resolve(ctx context.Context, req *entity.RemoteInjectorRequest) (*entity.RemoteInjectorResponse, error)
+
+	// Check returns nil when the service reports itself healthy.
+	Check(ctx context.Context) error
+
+	// Close releases the connection to the service.
+	Close() error
+}
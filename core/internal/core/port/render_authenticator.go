@@ -17,5 +17,6 @@ type RenderAuthClaims struct {
 	Email    string         // Optional
 	Name     string         // Optional
 	Provider string         // Auth provider/method name (e.g., "api-key", "custom-jwt")
+	KeyID    string         // ID of the API key used, never the key itself; identifies the session when set
 	Extra    map[string]any // Custom claims accessible via middleware.GetRenderAuthExtra()
 }
@@ -0,0 +1,13 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// RenderCurrencyRateRepository keeps the exchange rates renders converted amounts with.
+type RenderCurrencyRateRepository interface {
+	// Create stores the rates of a render.
+	Create(ctx context.Context, record *entity.RenderCurrencyRates) error
+}
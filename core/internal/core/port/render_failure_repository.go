@@ -0,0 +1,29 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// RenderFailureRepository defines the interface for captured render failure data access.
+type RenderFailureRepository interface {
+	// Create stores a captured failure.
+	Create(ctx context.Context, failure *entity.RenderFailure) error
+
+	// List lists unexpired failures, newest first, without their sources.
+	List(ctx context.Context, limit int) ([]*entity.RenderFailure, error)
+
+	// FindByID finds an unexpired failure by ID, with its source.
+	FindByID(ctx context.Context, id string) (*entity.RenderFailure, error)
+
+	// DeleteExpired deletes failures past their expiry and all but the newest keep failures,
+	// returning how many were deleted. keep <= 0 keeps any number.
+	DeleteExpired(ctx context.Context, keep int) (int64, error)
+}
+
+// RenderFailureCapturer keeps the Typst input of renders whose compile failed.
+type RenderFailureCapturer interface {
+	// CaptureRenderFailure stores failure and returns its ID, or an empty string when it was not stored.
+	CaptureRenderFailure(ctx context.Context, failure *entity.RenderFailure) string
+}
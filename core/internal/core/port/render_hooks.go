@@ -0,0 +1,57 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// RenderContext describes a render of the render API to the render hooks.
+// Pre-render hooks may change Headers, Payload, Injectables and Watermark; the other fields are
+// informative.
+type RenderContext struct {
+	// Operation is the InjectorContext operation of the render: "render", "html", "docx" or "estimate".
+	Operation     string
+	TenantCode    string
+	WorkspaceCode string
+	// DocumentType is the code of the rendered document type, empty for renders by version ID.
+	DocumentType string
+	TemplateID   string
+	VersionID    string
+	Environment  entity.Environment
+
+	// Headers are the request headers passed to the injectors.
+	Headers map[string]string
+	// Payload is the mapped request payload passed to the injectors.
+	Payload any
+	// Injectables are the values given by the caller; they override the values of the injectors.
+	Injectables map[string]any
+	// Watermark is text stamped diagonally across every page. Empty means no watermark.
+	Watermark string
+	// DocumentNumber is the number the render drew from the numbering sequence of its document
+	// type, empty when it drew none.
+	DocumentNumber string
+}
+
+// PreRenderHook runs before the injectables of a render are resolved, in registration order.
+// It may change the render through its RenderContext; an error fails the render.
+// Register hooks with engine.RegisterPreRenderHook.
+type PreRenderHook func(ctx context.Context, render *RenderContext) error
+
+// PostRenderHook runs after a PDF is rendered and before it is returned, in registration order.
+// It may change the result, e.g. to stamp or sign the PDF; RenderContextFrom(ctx) describes the
+// render. An error fails the render. Register hooks with engine.RegisterPostRenderHook.
+type PostRenderHook func(ctx context.Context, result *RenderPreviewResult) error
+
+type renderContextKey struct{}
+
+// WithRenderContext returns a copy of ctx carrying the render context.
+func WithRenderContext(ctx context.Context, render *RenderContext) context.Context {
+	return context.WithValue(ctx, renderContextKey{}, render)
+}
+
+// RenderContextFrom returns the render context carried by ctx, nil outside render hooks.
+func RenderContextFrom(ctx context.Context) *RenderContext {
+	render, _ := ctx.Value(renderContextKey{}).(*RenderContext)
+	return render
+}
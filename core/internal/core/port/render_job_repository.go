@@ -0,0 +1,41 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// RenderJobRepository defines the interface for render job data access.
+type RenderJobRepository interface {
+	// Create stores a queued render job.
+	Create(ctx context.Context, job *entity.RenderJob) (string, error)
+
+	// FindByID finds a render job by ID, without its request and PDF.
+	FindByID(ctx context.Context, id string) (*entity.RenderJob, error)
+
+	// FindPDF returns the PDF of a succeeded job, or nil when the job has no result.
+	FindPDF(ctx context.Context, id string) ([]byte, error)
+
+	// Claim claims up to limit unexpired jobs that are queued or whose lease expired, with their
+	// requests, marks them RUNNING and hides them from other workers for the lease. Each claim
+	// counts as an attempt.
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]*entity.RenderJob, error)
+
+	// Requeue returns a claimed job to the queue without counting the attempt.
+	Requeue(ctx context.Context, id string) error
+
+	// Retry returns a claimed job whose render failed to the queue; the attempt counts.
+	Retry(ctx context.Context, id string) error
+
+	// Complete stores the PDF of a job and marks it SUCCEEDED.
+	Complete(ctx context.Context, result *entity.RenderJobResult) error
+
+	// Fail marks a job FAILED with the reason and, when the failure was classified, its render
+	// error code.
+	Fail(ctx context.Context, id, reason string, code *entity.RenderErrorCode) error
+
+	// DeleteExpired deletes up to limit jobs past their expiry, returning how many were deleted.
+	DeleteExpired(ctx context.Context, limit int) (int64, error)
+}
@@ -0,0 +1,42 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// RenderQuotaRepository stores the render quotas of tenants and workspaces and their monthly usage.
+type RenderQuotaRepository interface {
+	// FindAll returns every quota with the codes of its tenant and workspace.
+	FindAll(ctx context.Context) ([]*entity.RenderQuota, error)
+
+	// Find returns the quota of a tenant, or of one of its workspaces when workspaceID is set.
+	// Returns entity.ErrRenderQuotaNotFound when the defaults apply.
+	Find(ctx context.Context, tenantID string, workspaceID *string) (*entity.RenderQuota, error)
+
+	// Save creates or replaces a quota.
+	Save(ctx context.Context, quota *entity.RenderQuota) error
+
+	// Delete removes a quota, so the defaults apply again.
+	// Returns entity.ErrRenderQuotaNotFound when there is none.
+	Delete(ctx context.Context, tenantID string, workspaceID *string) error
+
+	// AddUsage adds render counts to the usage of their workspace and month.
+	AddUsage(ctx context.Context, usage []*entity.RenderUsage) error
+
+	// ListUsage returns the usage of every workspace in a month.
+	ListUsage(ctx context.Context, month time.Time) ([]*entity.RenderUsage, error)
+}
+
+// RenderQuotaEnforcer checks renders against the quotas of their tenant and workspace. It decides
+// from memory, so renders never wait on the database.
+type RenderQuotaEnforcer interface {
+	// AllowRender takes a render from the quotas of the workspace, or returns an
+	// *entity.QuotaExceededError when the tenant or workspace is over a limit.
+	AllowRender(tenantCode, workspaceCode string) error
+
+	// RecordPages counts the pages of a finished render towards the monthly quotas.
+	RecordPages(tenantCode, workspaceCode string, pages int)
+}
@@ -0,0 +1,9 @@
+package port
+
+import "time"
+
+// RenderRecorder counts the renders of the render API for the platform stats.
+type RenderRecorder interface {
+	// RecordRender counts a render. err is the render error, nil when it succeeded.
+	RecordRender(pageCount int, duration time.Duration, err error)
+}
@@ -0,0 +1,27 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// ScheduledRunRepository defines the interface for scheduled operation run data access.
+type ScheduledRunRepository interface {
+	// Create records a run.
+	Create(ctx context.Context, run *entity.ScheduledRun) (string, error)
+
+	// CountFailures counts the failed runs of an operation scheduled for the given time.
+	CountFailures(ctx context.Context, versionID string, operation entity.ScheduledOperation, scheduledFor time.Time) (int, error)
+
+	// FindUpcomingByWorkspace lists pending scheduled publications and archivals of a workspace, soonest first.
+	FindUpcomingByWorkspace(ctx context.Context, workspaceID string) ([]*entity.ScheduledItem, error)
+
+	// FindRecentByWorkspace lists the most recent runs of a workspace, newest first.
+	FindRecentByWorkspace(ctx context.Context, workspaceID string, limit int) ([]*entity.ScheduledRun, error)
+
+	// FindFailuresByWorkspace lists, per version and operation, the latest run when it failed
+	// and the operation is still pending.
+	FindFailuresByWorkspace(ctx context.Context, workspaceID string) ([]*entity.ScheduledRun, error)
+}
@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// ScriptFetchFunc resolves a code through the workspace provider for a running script, so
+// scripts reach external systems only through the provider layer.
+type ScriptFetchFunc func(ctx context.Context, code string) (any, error)
+
+// ScriptRuntime compiles the sources of scripted injectors.
+type ScriptRuntime interface {
+	// Compile parses source and checks that it defines resolve(ctx). Errors wrap
+	// entity.ErrInvalidScriptedInjector.
+	Compile(source string) (CompiledScript, error)
+}
+
+// CompiledScript is a script ready to run. Runs are independent and may be concurrent.
+type CompiledScript interface {
+	// Run calls resolve(ctx) with input within the limits of the runtime and the deadline of ctx.
+	// fetch may be nil when no provider is registered. Errors wrap entity.ErrScriptFailed.
+	Run(ctx context.Context, input *entity.ScriptInput, fetch ScriptFetchFunc) (*entity.ScriptRun, error)
+}
@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// ScriptedInjectorRepository defines the interface for scripted injector data access.
+type ScriptedInjectorRepository interface {
+	// FindAll lists every scripted injector, sorted by code.
+	FindAll(ctx context.Context) ([]*entity.ScriptedInjector, error)
+
+	// FindByCode finds a scripted injector. Returns entity.ErrScriptedInjectorNotFound if none.
+	FindByCode(ctx context.Context, code string) (*entity.ScriptedInjector, error)
+
+	// Create inserts a scripted injector. Returns entity.ErrScriptedInjectorExists if the code is taken.
+	Create(ctx context.Context, injector *entity.ScriptedInjector) error
+
+	// Update replaces a scripted injector. Returns entity.ErrScriptedInjectorNotFound if none.
+	Update(ctx context.Context, injector *entity.ScriptedInjector) error
+
+	// Delete deletes a scripted injector. Returns entity.ErrScriptedInjectorNotFound if none.
+	Delete(ctx context.Context, code string) error
+}
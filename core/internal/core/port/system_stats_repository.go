@@ -0,0 +1,28 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// SystemStatsRepository defines the interface for the platform-wide statistics.
+type SystemStatsRepository interface {
+	// CountEntities counts tenants, workspaces, templates and users.
+	CountEntities(ctx context.Context) (*entity.EntityCounts, error)
+
+	// AddRenderCounts adds render counts to their days.
+	AddRenderCounts(ctx context.Context, days []*entity.RenderDayStats) error
+
+	// ListRenderDays returns the render counts of the days from since, oldest first.
+	// Days without renders are missing.
+	ListRenderDays(ctx context.Context, since time.Time) ([]*entity.RenderDayStats, error)
+
+	// GetQueueHealth returns the backlogs of the outbox, the document indexer and scheduled publications.
+	GetQueueHealth(ctx context.Context) (*entity.QueueHealth, error)
+
+	// GetStorageUsage returns the stored bytes and the bytes uploaded per day from since, oldest first.
+	// Days without uploads are missing.
+	GetStorageUsage(ctx context.Context, since time.Time) (*entity.StorageUsage, error)
+}
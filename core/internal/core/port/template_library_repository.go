@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// TemplateLibraryRepository defines the interface for the links of templates installed from the library.
+type TemplateLibraryRepository interface {
+	// Create links a template to the library template it was installed from.
+	Create(ctx context.Context, link *entity.TemplateLibraryLink) error
+
+	// FindByTemplateID finds the link of an installed template.
+	FindByTemplateID(ctx context.Context, templateID string) (*entity.TemplateLibraryLink, error)
+
+	// UpdateBase moves the base of a link to the upstream version it pulled.
+	UpdateBase(ctx context.Context, link *entity.TemplateLibraryLink) error
+}
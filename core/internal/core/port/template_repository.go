@@ -39,7 +39,8 @@ type TemplateRepository interface {
 	// FindByFolder lists all templates in a folder.
 	FindByFolder(ctx context.Context, folderID string) ([]*entity.TemplateListItem, error)
 
-	// FindPublicLibrary lists all public library templates.
+	// FindPublicLibrary lists the public library templates with a published version of the other
+	// workspaces of the workspace's tenant.
 	FindPublicLibrary(ctx context.Context, workspaceID string) ([]*entity.TemplateListItem, error)
 
 	// Update updates a template.
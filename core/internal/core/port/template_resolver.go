@@ -19,7 +19,12 @@ type TemplateResolverRequest struct {
 	// Headers contains the HTTP headers from the original render request.
 	Headers map[string]string
 	// RawBody is the unparsed HTTP request body.
+	//
+	// Deprecated: always nil; the body is streamed to the request mapper. Use Payload.
 	RawBody []byte
+	// Payload is the render payload: the result of the request mapper, or the injectables of the
+	// request when no mapper is registered.
+	Payload any
 	// Injectables contains pre-resolved injectable values available at resolution time.
 	Injectables map[string]any
 	// Environment is the render environment from the X-Environment header ("dev" or "prod").
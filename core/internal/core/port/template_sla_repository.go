@@ -0,0 +1,41 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// TemplateSLARepository defines the interface for template SLA and render window data access.
+type TemplateSLARepository interface {
+	// Upsert creates or replaces the SLA of a template, clearing its breach.
+	Upsert(ctx context.Context, sla *entity.TemplateSLA) error
+
+	// FindByTemplateID finds the SLA of a template.
+	FindByTemplateID(ctx context.Context, templateID string) (*entity.TemplateSLA, error)
+
+	// Delete deletes the SLA of a template.
+	Delete(ctx context.Context, templateID string) error
+
+	// FindWindow sums the renders of a template over the last window.
+	FindWindow(ctx context.Context, templateID string, window time.Duration) (entity.TemplateRenderWindow, error)
+
+	// ListStatuses lists every SLA with the renders of its current window.
+	ListStatuses(ctx context.Context) ([]*entity.TemplateSLAStatus, error)
+
+	// AddRenderMinutes adds render counts to the per-minute counts of their templates.
+	AddRenderMinutes(ctx context.Context, minutes []*entity.TemplateRenderMinute) error
+
+	// MarkBreached records that an SLA is breached since at. It returns false when the SLA was
+	// already breached or no longer exists, so only one instance alerts.
+	MarkBreached(ctx context.Context, templateID string, at time.Time) (bool, error)
+
+	// MarkRecovered clears the breach recorded at breachedAt. It returns false when the breach was
+	// already cleared or replaced.
+	MarkRecovered(ctx context.Context, templateID string, breachedAt time.Time) (bool, error)
+
+	// DeleteRenderMinutesBefore deletes the per-minute counts older than before, returning how many
+	// were deleted.
+	DeleteRenderMinutesBefore(ctx context.Context, before time.Time) (int64, error)
+}
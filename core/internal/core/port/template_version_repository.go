@@ -28,6 +28,13 @@ type TemplateVersionRepository interface {
 	// FindByTemplateID lists all versions for a template.
 	FindByTemplateID(ctx context.Context, templateID string) ([]*entity.TemplateVersion, error)
 
+	// FindMetadataByID finds a template version by ID without loading ContentStructure.
+	// The result must not be passed to Update, which would clear the content.
+	FindMetadataByID(ctx context.Context, id string) (*entity.TemplateVersion, error)
+
+	// FindMetadataByTemplateID lists all versions for a template without loading ContentStructure.
+	FindMetadataByTemplateID(ctx context.Context, templateID string) ([]*entity.TemplateVersion, error)
+
 	// FindByTemplateIDWithDetails lists all versions for a template with full details.
 	FindByTemplateIDWithDetails(ctx context.Context, templateID string) ([]*entity.TemplateVersionWithDetails, error)
 
@@ -44,14 +51,26 @@ type TemplateVersionRepository interface {
 	FindStagingByTemplateIDWithDetails(ctx context.Context, templateID string) (*entity.TemplateVersionWithDetails, error)
 
 	// FindScheduledToPublish finds all versions scheduled to publish before the given time.
+	// ContentStructure is not loaded.
 	FindScheduledToPublish(ctx context.Context, before time.Time) ([]*entity.TemplateVersion, error)
 
 	// FindScheduledToArchive finds all published versions scheduled to archive before the given time.
+	// ContentStructure is not loaded.
 	FindScheduledToArchive(ctx context.Context, before time.Time) ([]*entity.TemplateVersion, error)
 
 	// Update updates a template version.
 	Update(ctx context.Context, version *entity.TemplateVersion) error
 
+	// UpdateChangelog stores the changelog computed when a version is published.
+	UpdateChangelog(ctx context.Context, id string, changelog *entity.VersionChangelog) error
+
+	// FindChangelog returns the changelog of a version, or nil when it was never published.
+	FindChangelog(ctx context.Context, id string) (*entity.VersionChangelog, error)
+
+	// MarkScheduleFailed moves a version to the failed-scheduled state: the scheduler skips it
+	// until it is rescheduled, its schedule is cancelled or the operation is retried.
+	MarkScheduleFailed(ctx context.Context, id string, reason string) error
+
 	// UpdateStatus updates a version's status with optional user tracking.
 	UpdateStatus(ctx context.Context, id string, status entity.VersionStatus, userID *string) error
 
@@ -0,0 +1,11 @@
+package port
+
+import "context"
+
+// TransactionManager runs multi-write use cases as a single unit of work.
+// Repositories called with the context passed to fn take part in the transaction.
+type TransactionManager interface {
+	// WithinTx runs fn inside a transaction, committing when fn returns nil and
+	// rolling back otherwise. Nested calls join the outer transaction.
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// UserPreferencesRepository defines the interface for user preferences data access.
+type UserPreferencesRepository interface {
+	// FindByUserID finds the saved preferences of a user.
+	// Returns ErrUserPreferencesNotFound if the user never saved any.
+	FindByUserID(ctx context.Context, userID string) (*entity.UserPreferences, error)
+
+	// Upsert creates or replaces a user's preferences.
+	// Returns ErrWorkspaceAccessDenied if the default workspace is not accessible to the user.
+	Upsert(ctx context.Context, prefs *entity.UserPreferences) error
+}
@@ -0,0 +1,24 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// VersionReleaseNotesRepository defines the interface for version release notes data access.
+type VersionReleaseNotesRepository interface {
+	// Upsert creates or replaces the release notes of a version that was never published.
+	// Returns ErrReleaseNotesFrozen when the version was published or archived in the meantime.
+	Upsert(ctx context.Context, notes *entity.VersionReleaseNotes) error
+
+	// FindByVersionID returns the release notes of a version, or ErrReleaseNotesNotFound.
+	FindByVersionID(ctx context.Context, versionID string) (*entity.VersionReleaseNotes, error)
+
+	// FindByTemplateID returns the release notes of every version of a template, keyed by version ID.
+	FindByTemplateID(ctx context.Context, templateID string) (map[string]*entity.VersionReleaseNotes, error)
+
+	// Delete removes the release notes of a version that was never published.
+	// Returns ErrReleaseNotesNotFound when there are none.
+	Delete(ctx context.Context, versionID string) error
+}
@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// WorkspaceFontRepository defines the interface for workspace font data access.
+type WorkspaceFontRepository interface {
+	// Create stores a font with its content.
+	Create(ctx context.Context, font *entity.WorkspaceFont) (string, error)
+
+	// FindByID finds a font of a workspace, without its content.
+	FindByID(ctx context.Context, workspaceID, id string) (*entity.WorkspaceFont, error)
+
+	// FindByWorkspace lists the fonts of a workspace without their content, by family, style and weight.
+	FindByWorkspace(ctx context.Context, workspaceID string) ([]*entity.WorkspaceFont, error)
+
+	// FindContent returns a font of a workspace with its content.
+	FindContent(ctx context.Context, workspaceID, id string) (*entity.WorkspaceFont, error)
+
+	// Delete deletes a font of a workspace.
+	Delete(ctx context.Context, workspaceID, id string) error
+}
@@ -0,0 +1,31 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// WorkspaceInvitationRepository defines the interface for workspace invitation data access.
+type WorkspaceInvitationRepository interface {
+	// Create creates a new invitation.
+	Create(ctx context.Context, invitation *entity.WorkspaceInvitation) (string, error)
+
+	// FindByID finds an invitation by ID.
+	FindByID(ctx context.Context, id string) (*entity.WorkspaceInvitation, error)
+
+	// FindByTokenHash finds an invitation by the hash of its emailed token.
+	FindByTokenHash(ctx context.Context, tokenHash string) (*entity.WorkspaceInvitation, error)
+
+	// FindPendingByWorkspace lists pending invitations of a workspace, including expired ones.
+	FindPendingByWorkspace(ctx context.Context, workspaceID string) ([]*entity.WorkspaceInvitation, error)
+
+	// FindPendingByWorkspaceAndEmail finds the open invitation for an email in a workspace.
+	FindPendingByWorkspaceAndEmail(ctx context.Context, workspaceID, email string) (*entity.WorkspaceInvitation, error)
+
+	// FindPendingByEmail lists unexpired pending invitations addressed to an email.
+	FindPendingByEmail(ctx context.Context, email string) ([]*entity.WorkspaceInvitation, error)
+
+	// Update updates an invitation's status, token and expiration.
+	Update(ctx context.Context, invitation *entity.WorkspaceInvitation) error
+}
@@ -37,4 +37,14 @@ type WorkspaceMemberRepository interface {
 
 	// UpdateRole updates a member's role.
 	UpdateRole(ctx context.Context, id string, role entity.WorkspaceRole) error
+
+	// UpdateStatus updates a membership's status (e.g. deactivation).
+	UpdateStatus(ctx context.Context, id string, status entity.MembershipStatus) error
+
+	// CountActiveByRole counts active members with a specific role in a workspace.
+	CountActiveByRole(ctx context.Context, workspaceID string, role entity.WorkspaceRole) (int, error)
+
+	// TransferOwnership atomically promotes toMemberID to OWNER and demotes fromMemberID to demoteTo.
+	// Returns ErrInvalidOwnershipTarget if fromMemberID is not an active owner or toMemberID is not active.
+	TransferOwnership(ctx context.Context, workspaceID, fromMemberID, toMemberID string, demoteTo entity.WorkspaceRole) error
 }
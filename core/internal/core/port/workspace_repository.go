@@ -27,6 +27,9 @@ type WorkspaceRepository interface {
 	// When filters.Query is provided, orders by similarity. Otherwise, orders by access history.
 	FindByTenantPaginated(ctx context.Context, tenantID string, filters WorkspaceFilters) ([]*entity.Workspace, int64, error)
 
+	// FindByTenant lists every workspace of a tenant, ordered by name.
+	FindByTenant(ctx context.Context, tenantID string) ([]*entity.Workspace, error)
+
 	// FindByUser lists all workspaces a user has access to.
 	FindByUser(ctx context.Context, userID string) ([]*entity.WorkspaceWithRole, error)
 
@@ -0,0 +1,29 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// WorkspaceSandboxRepository defines the interface for sandbox workspace data access.
+type WorkspaceSandboxRepository interface {
+	// Create records a workspace as a sandbox.
+	Create(ctx context.Context, sandbox *entity.WorkspaceSandbox) error
+
+	// FindByWorkspaceID finds the sandbox record of a workspace.
+	// Returns entity.ErrSandboxNotFound when the workspace is not a sandbox.
+	FindByWorkspaceID(ctx context.Context, workspaceID string) (*entity.WorkspaceSandbox, error)
+
+	// CloneContent copies the folders, tags, workspace injectables and templates (with all their
+	// versions) of the source workspace into the target workspace, under new IDs.
+	// Members, invitations, webhooks and hosted documents are not copied.
+	CloneContent(ctx context.Context, sourceWorkspaceID, targetWorkspaceID string) (*entity.WorkspaceSandboxClone, error)
+
+	// FindExpired returns up to limit sandbox workspace IDs that expired before now.
+	FindExpired(ctx context.Context, now time.Time, limit int) ([]string, error)
+
+	// DeleteWorkspace permanently deletes a sandbox workspace and everything in it.
+	DeleteWorkspace(ctx context.Context, workspaceID string) error
+}
@@ -0,0 +1,23 @@
+package port
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// WorkspaceSettingsRepository defines the interface for workspace settings and tenant workspace defaults data access.
+type WorkspaceSettingsRepository interface {
+	// FindByWorkspaceID finds the settings of a workspace. Returns empty settings when none were saved.
+	FindByWorkspaceID(ctx context.Context, workspaceID string) (*entity.WorkspaceSettings, error)
+
+	// Upsert creates or replaces the settings of a workspace.
+	Upsert(ctx context.Context, workspaceID string, settings *entity.WorkspaceSettings, updatedBy *string) error
+
+	// FindTenantDefaults finds the workspace defaults of a tenant. Returns empty defaults, without
+	// UpdatedAt, when the tenant never set them.
+	FindTenantDefaults(ctx context.Context, tenantID string) (*entity.TenantWorkspaceDefaults, error)
+
+	// UpsertTenantDefaults creates or replaces the workspace defaults of a tenant.
+	UpsertTenantDefaults(ctx context.Context, defaults *entity.TenantWorkspaceDefaults) error
+}
@@ -0,0 +1,223 @@
+package access
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	accessuc "github.com/rendis/pdf-forge/core/internal/core/usecase/access"
+)
+
+const (
+	// activityFlushInterval is the minimum time between activity writes for the same principal.
+	activityFlushInterval = time.Minute
+	// restrictionsTTL bounds how long a revocation made on another instance takes to apply here.
+	restrictionsTTL = 30 * time.Second
+	// activityIdleTTL is how long a principal without pending requests is kept in memory.
+	activityIdleTTL = 10 * time.Minute
+)
+
+// pendingActivity accumulates requests of a principal between two writes.
+type pendingActivity struct {
+	requests  int64
+	flushedAt time.Time
+}
+
+// NewAuthSessionService creates a new auth session service.
+func NewAuthSessionService(sessionRepo port.AuthSessionRepository) accessuc.AuthSessionUseCase {
+	return &AuthSessionService{
+		sessionRepo: sessionRepo,
+		activity:    make(map[string]*pendingActivity),
+	}
+}
+
+// AuthSessionService implements the AuthSessionUseCase interface.
+// Restricted sessions are cached in memory so checking a request costs no query.
+type AuthSessionService struct {
+	sessionRepo port.AuthSessionRepository
+
+	mu           sync.Mutex
+	activity     map[string]*pendingActivity
+	restricted   map[string]*entity.AuthSession
+	restrictedAt time.Time
+}
+
+// CheckAccess returns an error if the principal may not use a credential issued at issuedAt.
+func (s *AuthSessionService) CheckAccess(ctx context.Context, session *entity.AuthSession, issuedAt *time.Time) error {
+	restricted, ok := s.restrictions(ctx)[session.Key()]
+	if !ok {
+		return nil
+	}
+	return restricted.CheckToken(issuedAt)
+}
+
+// RecordActivity records a request made by the principal.
+func (s *AuthSessionService) RecordActivity(ctx context.Context, session *entity.AuthSession) {
+	now := time.Now().UTC()
+	key := session.Key()
+
+	s.mu.Lock()
+	p, ok := s.activity[key]
+	if !ok {
+		p = &pendingActivity{}
+		s.activity[key] = p
+	}
+	p.requests++
+	if now.Sub(p.flushedAt) < activityFlushInterval {
+		s.mu.Unlock()
+		return
+	}
+	requests := p.requests
+	p.requests = 0
+	p.flushedAt = now
+	s.mu.Unlock()
+
+	session.LastSeenAt = now
+	go s.flush(context.WithoutCancel(ctx), session, requests)
+}
+
+func (s *AuthSessionService) flush(ctx context.Context, session *entity.AuthSession, requests int64) {
+	if err := s.sessionRepo.Touch(ctx, session, requests); err != nil {
+		slog.WarnContext(ctx, "failed to record session activity",
+			slog.String("principal_type", string(session.PrincipalType)),
+			slog.String("subject", session.Subject),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// restrictions returns the cached restricted sessions keyed by principal, reloading them when stale.
+// On reload failure the previous set is kept, so a database outage does not lift revocations.
+func (s *AuthSessionService) restrictions(ctx context.Context) map[string]*entity.AuthSession {
+	s.mu.Lock()
+	if time.Since(s.restrictedAt) < restrictionsTTL {
+		defer s.mu.Unlock()
+		return s.restricted
+	}
+	// Claim the reload so concurrent requests keep using the current set meanwhile
+	s.restrictedAt = time.Now()
+	current := s.restricted
+	s.pruneActivity()
+	s.mu.Unlock()
+
+	sessions, err := s.sessionRepo.FindRestricted(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to load restricted sessions", slog.Any("error", err))
+		return current
+	}
+
+	restricted := make(map[string]*entity.AuthSession, len(sessions))
+	for _, session := range sessions {
+		restricted[session.Key()] = session
+	}
+
+	s.mu.Lock()
+	s.restricted = restricted
+	s.mu.Unlock()
+	return restricted
+}
+
+// pruneActivity drops principals idle for a while. Must be called with s.mu held.
+func (s *AuthSessionService) pruneActivity() {
+	for key, p := range s.activity {
+		if p.requests == 0 && time.Since(p.flushedAt) > activityIdleTTL {
+			delete(s.activity, key)
+		}
+	}
+}
+
+// invalidateRestrictions forces the next check to reload restricted sessions.
+func (s *AuthSessionService) invalidateRestrictions() {
+	s.mu.Lock()
+	s.restrictedAt = time.Time{}
+	s.mu.Unlock()
+}
+
+// ListSessions lists sessions, most recently active first, with the total count.
+func (s *AuthSessionService) ListSessions(ctx context.Context, filters port.AuthSessionFilters) ([]*entity.AuthSession, int64, error) {
+	if filters.PrincipalType != "" && !filters.PrincipalType.IsValid() {
+		return nil, 0, entity.ErrInvalidPrincipalType
+	}
+
+	sessions, total, err := s.sessionRepo.FindAll(ctx, filters)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing sessions: %w", err)
+	}
+	return sessions, total, nil
+}
+
+// RevokeSession rejects every further request of an API key or service account.
+func (s *AuthSessionService) RevokeSession(ctx context.Context, cmd accessuc.RevokeSessionCommand) (*entity.AuthSession, error) {
+	session, err := s.sessionRepo.FindByID(ctx, cmd.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := session.CanRevoke(); err != nil {
+		return nil, err
+	}
+
+	if err := s.sessionRepo.Revoke(ctx, session.ID, cmd.RevokedBy); err != nil {
+		return nil, fmt.Errorf("revoking session: %w", err)
+	}
+	s.invalidateRestrictions()
+
+	slog.WarnContext(ctx, "session revoked",
+		slog.String("session_id", session.ID),
+		slog.String("principal_type", string(session.PrincipalType)),
+		slog.String("subject", session.Subject),
+		slog.String("revoked_by", cmd.RevokedBy),
+	)
+
+	return s.sessionRepo.FindByID(ctx, session.ID)
+}
+
+// RestoreSession lifts the revocation of a session.
+func (s *AuthSessionService) RestoreSession(ctx context.Context, id string) (*entity.AuthSession, error) {
+	session, err := s.sessionRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsRevoked() {
+		return nil, entity.ErrSessionNotRevoked
+	}
+
+	if err := s.sessionRepo.Restore(ctx, id); err != nil {
+		return nil, fmt.Errorf("restoring session: %w", err)
+	}
+	s.invalidateRestrictions()
+
+	slog.InfoContext(ctx, "session restored",
+		slog.String("session_id", id),
+		slog.String("subject", session.Subject),
+	)
+
+	return s.sessionRepo.FindByID(ctx, id)
+}
+
+// ForceReauth rejects credentials of the session issued before now.
+func (s *AuthSessionService) ForceReauth(ctx context.Context, id string) (*entity.AuthSession, error) {
+	session, err := s.sessionRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := session.CanForceReauth(); err != nil {
+		return nil, err
+	}
+
+	if err := s.sessionRepo.RequireReauth(ctx, id, time.Now().UTC()); err != nil {
+		return nil, fmt.Errorf("forcing re-authentication: %w", err)
+	}
+	s.invalidateRestrictions()
+
+	slog.WarnContext(ctx, "session re-authentication forced",
+		slog.String("session_id", id),
+		slog.String("principal_type", string(session.PrincipalType)),
+		slog.String("subject", session.Subject),
+	)
+
+	return s.sessionRepo.FindByID(ctx, id)
+}
@@ -0,0 +1,238 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	accessuc "github.com/rendis/pdf-forge/core/internal/core/usecase/access"
+)
+
+func TestRevokeSession(t *testing.T) {
+	tests := []struct {
+		name          string
+		principalType entity.PrincipalType
+		revoked       bool
+		wantErr       error
+	}{
+		{name: "api key", principalType: entity.PrincipalTypeAPIKey},
+		{name: "service account", principalType: entity.PrincipalTypeServiceAccount},
+		{name: "user", principalType: entity.PrincipalTypeUser, wantErr: entity.ErrCannotRevokeUserSession},
+		{name: "already revoked", principalType: entity.PrincipalTypeAPIKey, revoked: true, wantErr: entity.ErrSessionAlreadyRevoked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := newTestSession("s-1", tt.principalType, "key-1")
+			if tt.revoked {
+				now := time.Now().UTC()
+				session.RevokedAt = &now
+			}
+			repo := newFakeSessionRepo(session)
+			svc := NewAuthSessionService(repo)
+			ctx := context.Background()
+
+			// Load the restrictions before revoking: the revocation must apply without waiting for the cache
+			if !tt.revoked {
+				require.NoError(t, svc.CheckAccess(ctx, newTestSession("", tt.principalType, "key-1"), nil))
+			}
+
+			got, err := svc.RevokeSession(ctx, accessuc.RevokeSessionCommand{SessionID: "s-1", RevokedBy: "admin-1"})
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, got.IsRevoked())
+			assert.ErrorIs(t, svc.CheckAccess(ctx, newTestSession("", tt.principalType, "key-1"), nil), entity.ErrSessionRevoked)
+			assert.NoError(t, svc.CheckAccess(ctx, newTestSession("", tt.principalType, "key-2"), nil),
+				"other principals are not affected")
+		})
+	}
+}
+
+func TestRestoreSession(t *testing.T) {
+	session := newTestSession("s-1", entity.PrincipalTypeAPIKey, "key-1")
+	repo := newFakeSessionRepo(session)
+	svc := NewAuthSessionService(repo)
+	ctx := context.Background()
+
+	_, err := svc.RestoreSession(ctx, "s-1")
+	require.ErrorIs(t, err, entity.ErrSessionNotRevoked)
+
+	_, err = svc.RevokeSession(ctx, accessuc.RevokeSessionCommand{SessionID: "s-1", RevokedBy: "admin-1"})
+	require.NoError(t, err)
+	require.ErrorIs(t, svc.CheckAccess(ctx, newTestSession("", entity.PrincipalTypeAPIKey, "key-1"), nil), entity.ErrSessionRevoked)
+
+	got, err := svc.RestoreSession(ctx, "s-1")
+	require.NoError(t, err)
+	assert.False(t, got.IsRevoked())
+	assert.NoError(t, svc.CheckAccess(ctx, newTestSession("", entity.PrincipalTypeAPIKey, "key-1"), nil))
+}
+
+func TestForceReauth(t *testing.T) {
+	t.Run("rejects credentials issued before", func(t *testing.T) {
+		repo := newFakeSessionRepo(newTestSession("s-1", entity.PrincipalTypeUser, "sub-1"))
+		svc := NewAuthSessionService(repo)
+		ctx := context.Background()
+		principal := newTestSession("", entity.PrincipalTypeUser, "sub-1")
+		issuedBefore := time.Now().UTC().Add(-time.Minute)
+
+		got, err := svc.ForceReauth(ctx, "s-1")
+		require.NoError(t, err)
+		require.NotNil(t, got.ReauthRequiredAt)
+
+		issuedAfter := got.ReauthRequiredAt.Add(time.Second)
+		assert.ErrorIs(t, svc.CheckAccess(ctx, principal, &issuedBefore), entity.ErrReauthRequired)
+		assert.ErrorIs(t, svc.CheckAccess(ctx, principal, nil), entity.ErrReauthRequired)
+		assert.NoError(t, svc.CheckAccess(ctx, principal, &issuedAfter))
+	})
+
+	t.Run("api keys can only be revoked", func(t *testing.T) {
+		repo := newFakeSessionRepo(newTestSession("s-1", entity.PrincipalTypeAPIKey, "key-1"))
+		svc := NewAuthSessionService(repo)
+
+		_, err := svc.ForceReauth(context.Background(), "s-1")
+
+		require.ErrorIs(t, err, entity.ErrCannotForceReauth)
+	})
+}
+
+func TestCheckAccess_KeepsRestrictionsWhenReloadFails(t *testing.T) {
+	session := newTestSession("s-1", entity.PrincipalTypeAPIKey, "key-1")
+	now := time.Now().UTC()
+	session.RevokedAt = &now
+	repo := newFakeSessionRepo(session)
+	svc := NewAuthSessionService(repo).(*AuthSessionService)
+	ctx := context.Background()
+	principal := newTestSession("", entity.PrincipalTypeAPIKey, "key-1")
+
+	require.ErrorIs(t, svc.CheckAccess(ctx, principal, nil), entity.ErrSessionRevoked)
+
+	repo.restrictedErr = errors.New("database unavailable")
+	svc.invalidateRestrictions()
+
+	assert.ErrorIs(t, svc.CheckAccess(ctx, principal, nil), entity.ErrSessionRevoked,
+		"a failed reload must not lift a revocation")
+}
+
+func TestRecordActivity_BatchesWrites(t *testing.T) {
+	repo := newFakeSessionRepo()
+	svc := NewAuthSessionService(repo).(*AuthSessionService)
+	ctx := context.Background()
+	principal := newTestSession("", entity.PrincipalTypeAPIKey, "key-1")
+
+	for range 3 {
+		svc.RecordActivity(ctx, principal)
+	}
+	assert.Equal(t, int64(1), <-repo.touched, "the first request is written at once")
+
+	// The next request after the flush interval writes the requests counted meanwhile
+	svc.mu.Lock()
+	svc.activity[principal.Key()].flushedAt = time.Now().Add(-activityFlushInterval)
+	svc.mu.Unlock()
+	svc.RecordActivity(ctx, principal)
+	assert.Equal(t, int64(3), <-repo.touched)
+
+	select {
+	case requests := <-repo.touched:
+		t.Fatalf("unexpected write of %d requests", requests)
+	default:
+	}
+}
+
+func TestListSessions_RejectsUnknownPrincipalType(t *testing.T) {
+	svc := NewAuthSessionService(newFakeSessionRepo())
+
+	_, _, err := svc.ListSessions(context.Background(), port.AuthSessionFilters{PrincipalType: "ROBOT"})
+
+	require.ErrorIs(t, err, entity.ErrInvalidPrincipalType)
+}
+
+func newTestSession(id string, principalType entity.PrincipalType, subject string) *entity.AuthSession {
+	session := entity.NewAuthSession(principalType, "provider", subject)
+	session.ID = id
+	return session
+}
+
+// fakeSessionRepo keeps sessions in memory. Touch reports the request count of each write,
+// as the service writes activity in the background.
+type fakeSessionRepo struct {
+	port.AuthSessionRepository
+	mu            sync.Mutex
+	sessions      map[string]*entity.AuthSession
+	restrictedErr error
+	touched       chan int64
+}
+
+func newFakeSessionRepo(sessions ...*entity.AuthSession) *fakeSessionRepo {
+	repo := &fakeSessionRepo{sessions: make(map[string]*entity.AuthSession), touched: make(chan int64, 8)}
+	for _, s := range sessions {
+		repo.sessions[s.ID] = s
+	}
+	return repo
+}
+
+func (f *fakeSessionRepo) Touch(_ context.Context, _ *entity.AuthSession, requests int64) error {
+	f.touched <- requests
+	return nil
+}
+
+func (f *fakeSessionRepo) FindByID(_ context.Context, id string) (*entity.AuthSession, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	session, ok := f.sessions[id]
+	if !ok {
+		return nil, entity.ErrSessionNotFound
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (f *fakeSessionRepo) FindRestricted(context.Context) ([]*entity.AuthSession, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.restrictedErr != nil {
+		return nil, f.restrictedErr
+	}
+	var restricted []*entity.AuthSession
+	for _, s := range f.sessions {
+		if s.IsRevoked() || s.ReauthRequiredAt != nil {
+			copied := *s
+			restricted = append(restricted, &copied)
+		}
+	}
+	return restricted, nil
+}
+
+func (f *fakeSessionRepo) Revoke(_ context.Context, id, revokedBy string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := time.Now().UTC()
+	f.sessions[id].RevokedAt = &now
+	f.sessions[id].RevokedBy = &revokedBy
+	return nil
+}
+
+func (f *fakeSessionRepo) Restore(_ context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sessions[id].RevokedAt = nil
+	f.sessions[id].RevokedBy = nil
+	return nil
+}
+
+func (f *fakeSessionRepo) RequireReauth(_ context.Context, id string, at time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sessions[id].ReauthRequiredAt = &at
+	return nil
+}
@@ -0,0 +1,58 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	accessuc "github.com/rendis/pdf-forge/core/internal/core/usecase/access"
+)
+
+// NewUserPreferencesService creates a new user preferences service.
+func NewUserPreferencesService(preferencesRepo port.UserPreferencesRepository) accessuc.UserPreferencesUseCase {
+	return &UserPreferencesService{preferencesRepo: preferencesRepo}
+}
+
+// UserPreferencesService implements the UserPreferencesUseCase interface.
+type UserPreferencesService struct {
+	preferencesRepo port.UserPreferencesRepository
+}
+
+// GetPreferences returns the user's preferences, or the defaults if none were saved.
+func (s *UserPreferencesService) GetPreferences(ctx context.Context, userID string) (*entity.UserPreferences, error) {
+	prefs, err := s.preferencesRepo.FindByUserID(ctx, userID)
+	if errors.Is(err, entity.ErrUserPreferencesNotFound) {
+		return entity.NewUserPreferences(userID), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("finding user preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+// UpdatePreferences validates and saves the user's preferences.
+func (s *UserPreferencesService) UpdatePreferences(ctx context.Context, cmd accessuc.UpdatePreferencesCommand) (*entity.UserPreferences, error) {
+	prefs := entity.NewUserPreferences(cmd.UserID)
+	prefs.Locale = cmd.Locale
+	prefs.Timezone = cmd.Timezone
+	prefs.DefaultWorkspaceID = cmd.DefaultWorkspaceID
+
+	if err := prefs.Validate(); err != nil {
+		return nil, fmt.Errorf("validating preferences: %w", err)
+	}
+
+	if err := s.preferencesRepo.Upsert(ctx, prefs); err != nil {
+		return nil, fmt.Errorf("saving user preferences: %w", err)
+	}
+
+	slog.InfoContext(ctx, "user preferences updated",
+		slog.String("user_id", cmd.UserID),
+		slog.String("locale", prefs.Locale),
+		slog.String("timezone", prefs.Timezone),
+	)
+
+	return prefs, nil
+}
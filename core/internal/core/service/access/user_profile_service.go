@@ -0,0 +1,78 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	accessuc "github.com/rendis/pdf-forge/core/internal/core/usecase/access"
+)
+
+// featureAdministration is enabled for users holding a system role.
+const featureAdministration = "administration"
+
+// NewUserProfileService creates a new user profile service.
+// features holds deployment-level flags (e.g. "gallery"); role-derived flags are added per user.
+func NewUserProfileService(
+	userRepo port.UserRepository,
+	systemRoleRepo port.SystemRoleRepository,
+	tenantMemberRepo port.TenantMemberRepository,
+	workspaceRepo port.WorkspaceRepository,
+	features map[string]bool,
+) accessuc.UserProfileUseCase {
+	return &UserProfileService{
+		userRepo:         userRepo,
+		systemRoleRepo:   systemRoleRepo,
+		tenantMemberRepo: tenantMemberRepo,
+		workspaceRepo:    workspaceRepo,
+		features:         features,
+	}
+}
+
+// UserProfileService implements the UserProfileUseCase interface.
+type UserProfileService struct {
+	userRepo         port.UserRepository
+	systemRoleRepo   port.SystemRoleRepository
+	tenantMemberRepo port.TenantMemberRepository
+	workspaceRepo    port.WorkspaceRepository
+	features         map[string]bool
+}
+
+// GetProfile resolves the user with their system role, memberships and effective feature flags.
+func (s *UserProfileService) GetProfile(ctx context.Context, userID string) (*accessuc.UserProfile, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("finding user: %w", err)
+	}
+
+	profile := &accessuc.UserProfile{User: user}
+
+	assignment, err := s.systemRoleRepo.FindByUserID(ctx, userID)
+	switch {
+	case err == nil:
+		profile.SystemRole = &assignment.Role
+	case !errors.Is(err, entity.ErrSystemRoleNotFound):
+		return nil, fmt.Errorf("finding system role: %w", err)
+	}
+
+	profile.Tenants, err = s.tenantMemberRepo.FindTenantsWithRoleByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("listing tenant memberships: %w", err)
+	}
+
+	profile.Workspaces, err = s.workspaceRepo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("listing workspace memberships: %w", err)
+	}
+
+	profile.Features = maps.Clone(s.features)
+	if profile.Features == nil {
+		profile.Features = map[string]bool{}
+	}
+	profile.Features[featureAdministration] = profile.SystemRole != nil
+
+	return profile, nil
+}
@@ -0,0 +1,241 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // Registers the GIF decoder
+	"image/jpeg"
+	"image/png"
+	"log/slog"
+	"strings"
+
+	"github.com/dgraph-io/ristretto/v2"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	cataloguc "github.com/rendis/pdf-forge/core/internal/core/usecase/catalog"
+)
+
+const (
+	assetImageMaxWidth       = 4096
+	assetImageDefaultQuality = 80
+
+	// assetImageMaxPixels guards against decompression bombs: larger images are served as stored.
+	assetImageMaxPixels = 50_000_000
+
+	// assetImageCacheMaxBytes bounds the memory held by resized images.
+	assetImageCacheMaxBytes = 64 << 20
+
+	// assetRenderMaxWidth caps the bitmaps embedded in PDFs: a full-width image on an A4 page at 300 dpi.
+	assetRenderMaxWidth = 2480
+	assetRenderQuality  = 85
+)
+
+// GetAssetImage returns an image asset resized and encoded for display. Images the server cannot
+// decode (SVG, WebP) and very large ones are returned as stored.
+func (s *AssetService) GetAssetImage(ctx context.Context, cmd cataloguc.GetAssetImageCommand) (*entity.AssetImage, error) {
+	if cmd.Width < 0 || cmd.Width > assetImageMaxWidth || cmd.Quality < 0 || cmd.Quality > 100 {
+		return nil, entity.ErrInvalidImageOptions
+	}
+	quality := cmd.Quality
+	if quality == 0 {
+		quality = assetImageDefaultQuality
+	}
+
+	content, err := s.findContent(ctx, cmd.WorkspaceID, cmd.ID, cmd.Version)
+	if err != nil {
+		return nil, fmt.Errorf("finding asset %s content: %w", cmd.ID, err)
+	}
+	if !strings.HasPrefix(content.ContentType, "image/") {
+		return nil, entity.ErrAssetNotImage
+	}
+
+	contentType, err := s.negotiateImageType(content.ContentType, cmd.Format, cmd.Accept)
+	if err != nil {
+		return nil, err
+	}
+	return s.transformImage(content, cmd.Width, contentType, quality)
+}
+
+// negotiateImageType picks the content type of a resized image: the requested format, else the
+// first registered encoder the client accepts, else the format of the source.
+func (s *AssetService) negotiateImageType(source, format, accept string) (string, error) {
+	if format != "" {
+		contentType := "image/" + strings.ToLower(format)
+		if contentType == "image/jpg" {
+			contentType = "image/jpeg"
+		}
+		if contentType != "image/jpeg" && contentType != "image/png" && s.imageEncoders[contentType] == nil {
+			return "", fmt.Errorf("%w: %s", entity.ErrUnsupportedImageFormat, format)
+		}
+		return contentType, nil
+	}
+
+	for _, contentType := range s.imageEncoderOrder {
+		if acceptsContentType(accept, contentType) {
+			return contentType, nil
+		}
+	}
+	return defaultImageType(source), nil
+}
+
+// defaultImageType keeps JPEG photos as JPEG and writes every other decodable image as PNG,
+// which preserves transparency.
+func defaultImageType(source string) string {
+	if source == "image/jpeg" {
+		return source
+	}
+	return "image/png"
+}
+
+// acceptsContentType reports whether an Accept header lists a content type explicitly.
+// Wildcards are ignored so that only clients known to support a format receive it.
+func acceptsContentType(accept, contentType string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, _ := strings.Cut(part, ";")
+		if strings.TrimSpace(mediaType) == contentType && strings.ReplaceAll(params, " ", "") != "q=0" {
+			return true
+		}
+	}
+	return false
+}
+
+// transformImage scales an image version down to width (0 keeps its width) and encodes it as
+// contentType. Results are cached by source hash and transformation.
+func (s *AssetService) transformImage(content *entity.AssetVersion, width int, contentType string, quality int) (*entity.AssetImage, error) {
+	original := &entity.AssetImage{ContentType: content.ContentType, Data: content.Data, Key: content.SHA256}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(content.Data))
+	if err != nil || cfg.Width*cfg.Height > assetImageMaxPixels {
+		return original, nil
+	}
+	if width == 0 || width > cfg.Width {
+		width = cfg.Width
+	}
+	if width == cfg.Width && contentType == content.ContentType {
+		return original, nil
+	}
+
+	key := fmt.Sprintf("%s-w%d-%s-q%d", content.SHA256, width, strings.TrimPrefix(contentType, "image/"), quality)
+	if cached := s.images.get(key); cached != nil {
+		return cached, nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(content.Data))
+	if err != nil {
+		return nil, fmt.Errorf("decoding image: %w", err)
+	}
+	data, err := s.encodeImage(resizeImage(img, width), contentType, quality)
+	if err != nil {
+		return nil, fmt.Errorf("encoding image as %s: %w", contentType, err)
+	}
+
+	result := &entity.AssetImage{ContentType: contentType, Data: data, Key: key}
+	s.images.set(key, result)
+	return result, nil
+}
+
+// encodeImage encodes an image with the built-in PNG and JPEG encoders or a registered one.
+func (s *AssetService) encodeImage(img image.Image, contentType string, quality int) ([]byte, error) {
+	var buf bytes.Buffer
+	switch contentType {
+	case "image/png":
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, err
+		}
+	case "image/jpeg":
+		// JPEG has no alpha channel: transparent areas would turn black.
+		flat := image.NewRGBA(img.Bounds())
+		draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+		draw.Draw(flat, flat.Bounds(), img, img.Bounds().Min, draw.Over)
+		if err := jpeg.Encode(&buf, flat, &jpeg.Options{Quality: quality}); err != nil {
+			return nil, err
+		}
+	default:
+		encoder := s.imageEncoders[contentType]
+		if encoder == nil {
+			return nil, entity.ErrUnsupportedImageFormat
+		}
+		return encoder.Encode(img, quality)
+	}
+	return buf.Bytes(), nil
+}
+
+// resizeImage scales img down to width pixels, keeping its aspect ratio, by averaging the source
+// pixels each destination pixel covers. Images already at most width pixels wide are returned as is.
+func resizeImage(img image.Image, width int) image.Image {
+	bounds := img.Bounds()
+	sw, sh := bounds.Dx(), bounds.Dy()
+	if width >= sw {
+		return img
+	}
+	height := max(1, (sh*width+sw/2)/sw)
+
+	// Averaging premultiplied values keeps transparent pixels from darkening their neighbours.
+	src := image.NewRGBA(image.Rect(0, 0, sw, sh))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		y0, y1 := y*sh/height, (y+1)*sh/height
+		for x := range width {
+			x0, x1 := x*sw/width, (x+1)*sw/width
+			var sum [4]uint64
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride+x0*4 : sy*src.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					sum[0] += uint64(row[i])
+					sum[1] += uint64(row[i+1])
+					sum[2] += uint64(row[i+2])
+					sum[3] += uint64(row[i+3])
+				}
+			}
+			n := uint64((y1 - y0) * (x1 - x0))
+			p := dst.Pix[y*dst.Stride+x*4:]
+			for c := range sum {
+				p[c] = uint8(sum[c] / n)
+			}
+		}
+	}
+	return dst
+}
+
+// assetImageCache keeps resized images in memory, bounded by their total size.
+type assetImageCache struct {
+	cache *ristretto.Cache[string, *entity.AssetImage]
+}
+
+// newAssetImageCache creates the resized image cache. It returns nil, which disables caching,
+// if the cache cannot be created.
+func newAssetImageCache() *assetImageCache {
+	cache, err := ristretto.NewCache(&ristretto.Config[string, *entity.AssetImage]{
+		NumCounters: 10_000,
+		MaxCost:     assetImageCacheMaxBytes,
+		BufferItems: 64,
+	})
+	if err != nil {
+		slog.Warn("asset image cache disabled", slog.Any("error", err))
+		return nil
+	}
+	return &assetImageCache{cache: cache}
+}
+
+// get returns a cached image, or nil on miss.
+func (c *assetImageCache) get(key string) *entity.AssetImage {
+	if c == nil {
+		return nil
+	}
+	img, _ := c.cache.Get(key)
+	return img
+}
+
+// set caches an image, with its size as cost.
+func (c *assetImageCache) set(key string, img *entity.AssetImage) {
+	if c == nil {
+		return
+	}
+	c.cache.Set(key, img, int64(len(img.Data)))
+}
@@ -0,0 +1,125 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	cataloguc "github.com/rendis/pdf-forge/core/internal/core/usecase/catalog"
+)
+
+type fakeWebPEncoder struct{}
+
+func (fakeWebPEncoder) ContentType() string { return "image/webp" }
+
+func (fakeWebPEncoder) Encode(img image.Image, _ int) ([]byte, error) {
+	return []byte("webp:" + img.Bounds().Size().String()), nil
+}
+
+func encodeTestPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func decodedSize(t *testing.T, data []byte) image.Point {
+	t.Helper()
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	require.NoError(t, err)
+	return image.Pt(cfg.Width, cfg.Height)
+}
+
+func TestGetAssetImage(t *testing.T) {
+	s := NewAssetService(newAssetRepoStub(), noTx{}, []port.ImageEncoder{fakeWebPEncoder{}}, nil, AssetSigningOptions{}, AssetStorageOptions{})
+	ctx := context.Background()
+
+	photo := encodeTestPNG(t, 400, 200)
+	uploaded, err := s.UploadAsset(ctx, cataloguc.UploadAssetCommand{WorkspaceID: "ws-1", Name: "Photo", Data: photo})
+	require.NoError(t, err)
+	cmd := cataloguc.GetAssetImageCommand{WorkspaceID: "ws-1", ID: uploaded.Asset.ID}
+
+	img, err := s.GetAssetImage(ctx, cmd)
+	require.NoError(t, err)
+	assert.Equal(t, photo, img.Data, "without options the image is served as stored")
+
+	cmd.Width = 100
+	img, err = s.GetAssetImage(ctx, cmd)
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", img.ContentType)
+	assert.Equal(t, image.Pt(100, 50), decodedSize(t, img.Data))
+	assert.NotEqual(t, uploaded.Asset.SHA256, img.Key)
+
+	cmd.Accept = "image/avif,image/webp,*/*;q=0.8"
+	img, err = s.GetAssetImage(ctx, cmd)
+	require.NoError(t, err)
+	assert.Equal(t, "image/webp", img.ContentType)
+	assert.Equal(t, "webp:(100,50)", string(img.Data))
+
+	cmd.Format = "jpg"
+	img, err = s.GetAssetImage(ctx, cmd)
+	require.NoError(t, err)
+	assert.Equal(t, "image/jpeg", img.ContentType)
+	assert.Equal(t, image.Pt(100, 50), decodedSize(t, img.Data))
+
+	cmd.Format = "avif"
+	_, err = s.GetAssetImage(ctx, cmd)
+	assert.ErrorIs(t, err, entity.ErrUnsupportedImageFormat)
+
+	_, err = s.GetAssetImage(ctx, cataloguc.GetAssetImageCommand{WorkspaceID: "ws-1", ID: uploaded.Asset.ID, Width: 5000})
+	assert.ErrorIs(t, err, entity.ErrInvalidImageOptions)
+
+	pdf, err := s.UploadAsset(ctx, cataloguc.UploadAssetCommand{WorkspaceID: "ws-1", Name: "Terms", Data: testPDF})
+	require.NoError(t, err)
+	_, err = s.GetAssetImage(ctx, cataloguc.GetAssetImageCommand{WorkspaceID: "ws-1", ID: pdf.Asset.ID})
+	assert.ErrorIs(t, err, entity.ErrAssetNotImage)
+}
+
+func TestResizeImage_AveragesCoveredPixels(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for x := range 4 {
+		c := color.RGBA{A: 255}
+		if x%2 == 0 {
+			c = color.RGBA{R: 200, G: 100, B: 50, A: 255}
+		}
+		src.Set(x, 0, c)
+		src.Set(x, 1, c)
+	}
+
+	got := resizeImage(src, 2)
+	assert.Equal(t, image.Rect(0, 0, 2, 1), got.Bounds())
+	assert.Equal(t, color.RGBA{R: 100, G: 50, B: 25, A: 255}, got.At(1, 0))
+	assert.Same(t, src, resizeImage(src, 4), "images are never enlarged")
+}
+
+func TestURLResolver_ScalesImagesToWidthHint(t *testing.T) {
+	s := NewAssetService(newAssetRepoStub(), noTx{}, nil, nil, AssetSigningOptions{}, AssetStorageOptions{})
+	ctx := context.Background()
+
+	uploaded, err := s.UploadAsset(ctx, cataloguc.UploadAssetCommand{WorkspaceID: "ws-1", Name: "Photo", Data: encodeTestPNG(t, 400, 200)})
+	require.NoError(t, err)
+
+	url, err := s.URLResolver("ws-1", nil)(ctx, uploaded.Asset.URL()+"?w=50")
+	require.NoError(t, err)
+	data, ok := strings.CutPrefix(url, "data:image/png;base64,")
+	require.True(t, ok, url)
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	require.NoError(t, err)
+	assert.Equal(t, image.Pt(50, 25), decodedSize(t, decoded))
+}
@@ -0,0 +1,98 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// AssetStorageOptions keeps the content of new asset versions in object storage instead of the
+// database. Versions uploaded before stay where they are.
+type AssetStorageOptions struct {
+	// Objects holds the content of the versions kept in object storage; nil when none is configured.
+	Objects port.ObjectStorage
+	// KeyPrefix is prepended to every object key, like the keys of persisted renders.
+	KeyPrefix string
+	// Store writes the content of new versions to Objects instead of the database.
+	Store bool
+}
+
+// storeVersionContent moves the content of a version to object storage when it is configured,
+// under {prefix}/assets/{workspace}/{asset}/{sha256}. The version then only keeps the key.
+// Keys follow the content, so racing uploads of a version never overwrite each other's bytes.
+func (s *AssetService) storeVersionContent(ctx context.Context, workspaceID string, version *entity.AssetVersion) error {
+	if !s.storage.Store || s.storage.Objects == nil {
+		return nil
+	}
+
+	key := path.Join(strings.Trim(s.storage.KeyPrefix, "/"), "assets", workspaceID, version.AssetID, version.SHA256)
+	err := s.storage.Objects.Put(ctx, &port.StorageObject{
+		Key:         key,
+		ContentType: version.ContentType,
+		Size:        int64(len(version.Data)),
+		Body:        bytes.NewReader(version.Data),
+	})
+	if err != nil {
+		return fmt.Errorf("storing asset content: %w", err)
+	}
+	version.StorageKey = key
+	version.Data = nil
+	return nil
+}
+
+// findContent returns a version of a workspace asset with its content, reading it from object
+// storage when the version is kept there.
+func (s *AssetService) findContent(ctx context.Context, workspaceID, id string, version int) (*entity.AssetVersion, error) {
+	content, err := s.assetRepo.FindContent(ctx, workspaceID, id, version)
+	if err != nil || content.StorageKey == "" {
+		return content, err
+	}
+	if s.storage.Objects == nil {
+		return nil, fmt.Errorf("asset %s version %d is kept in object storage: %w",
+			id, content.Version, entity.ErrObjectStorageNotConfigured)
+	}
+
+	body, err := s.storage.Objects.Get(ctx, content.StorageKey)
+	if err != nil {
+		return nil, fmt.Errorf("reading asset content: %w", err)
+	}
+	defer body.Close()
+	data, err := io.ReadAll(io.LimitReader(body, entity.AssetMaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading asset content: %w", err)
+	}
+
+	loaded := *content
+	loaded.Data = data
+	return &loaded, nil
+}
+
+// deleteObjects removes the stored content of deleted versions, except keys that copies of the
+// workspace still use. Failures are logged: the versions are already gone.
+func (s *AssetService) deleteObjects(ctx context.Context, versions []*entity.AssetVersion) {
+	if s.storage.Objects == nil {
+		return
+	}
+	for _, v := range versions {
+		if v.StorageKey == "" {
+			continue
+		}
+		inUse, err := s.assetRepo.StorageKeyInUse(ctx, v.StorageKey)
+		if err == nil && !inUse {
+			err = s.storage.Objects.Delete(ctx, v.StorageKey)
+		}
+		if err != nil {
+			slog.WarnContext(ctx, "failed to delete asset content from object storage",
+				slog.String("key", v.StorageKey),
+				slog.Any("error", err),
+			)
+		}
+	}
+}
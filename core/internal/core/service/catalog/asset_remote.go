@@ -0,0 +1,179 @@
+package catalog
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	neturl "net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	cataloguc "github.com/rendis/pdf-forge/core/internal/core/usecase/catalog"
+)
+
+// PublicAssetsPath is where signed asset URLs are served, followed by {workspaceId}/{assetId}.
+const PublicAssetsPath = "/api/v1/public/assets/"
+
+// defaultAssetSignedURLTTL is how long signed asset URLs work when no TTL is configured.
+const defaultAssetSignedURLTTL = time.Hour
+
+// AssetSigningOptions configures the signed URLs that serve assets without an account.
+type AssetSigningOptions struct {
+	// Key signs the URLs; empty disables them. Set the same key on every instance.
+	Key string
+	// PublicBaseURL is the external URL of the engine the signed URLs point at.
+	PublicBaseURL string
+	// TTL is how long a signed URL works; zero takes one hour.
+	TTL time.Duration
+}
+
+// ImportAsset downloads an image from a remote URL into the library and records the URL, so
+// renders of templates that still reference it use the stored copy. A URL already imported returns
+// its asset; content already in the library without a source URL is linked to the URL.
+func (s *AssetService) ImportAsset(ctx context.Context, cmd cataloguc.ImportAssetCommand) (*cataloguc.UploadAssetResult, error) {
+	sourceURL := strings.TrimSpace(cmd.URL)
+	if !isRemoteAssetURL(sourceURL) {
+		return nil, entity.ErrInvalidAssetSourceURL
+	}
+	parsed, err := neturl.Parse(sourceURL)
+	if err != nil || parsed.Host == "" {
+		return nil, entity.ErrInvalidAssetSourceURL
+	}
+	if s.downloader == nil {
+		return nil, fmt.Errorf("%w: remote imports are disabled", entity.ErrAssetImportFailed)
+	}
+
+	existing, err := s.assetRepo.FindBySourceURL(ctx, cmd.WorkspaceID, sourceURL)
+	if err != nil {
+		return nil, fmt.Errorf("checking imported assets: %w", err)
+	}
+	if existing != nil {
+		return &cataloguc.UploadAssetResult{Asset: existing, Duplicate: true}, nil
+	}
+
+	data, err := s.downloader.Download(ctx, sourceURL, entity.AssetMaxSize)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", entity.ErrAssetImportFailed, err)
+	}
+	file, err := inspectAssetFile(data)
+	if err != nil {
+		return nil, err
+	}
+	if file.kind != entity.AssetKindImage {
+		return nil, entity.ErrAssetNotImage
+	}
+
+	existing, err = s.assetRepo.FindBySHA256(ctx, cmd.WorkspaceID, file.sha256)
+	if err != nil {
+		return nil, fmt.Errorf("checking asset duplicates: %w", err)
+	}
+	if existing != nil && existing.SourceURL == nil {
+		if err := s.assetRepo.SetSourceURL(ctx, existing.ID, sourceURL); err != nil {
+			return nil, fmt.Errorf("linking asset to its source URL: %w", err)
+		}
+		existing.SourceURL = &sourceURL
+		return &cataloguc.UploadAssetResult{Asset: existing, Duplicate: true}, nil
+	}
+
+	name := strings.TrimSpace(cmd.Name)
+	if name == "" {
+		name = path.Base(parsed.Path)
+		if name == "/" || name == "." {
+			name = parsed.Host
+		}
+	}
+	asset := entity.NewAsset(cmd.WorkspaceID, name, file.kind, cmd.Tags, optionalUserID(cmd.CreatedBy))
+	asset.SourceURL = &sourceURL
+	if err := s.createAsset(ctx, asset, file, data); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "asset imported",
+		slog.String("asset_id", asset.ID),
+		slog.String("source_url", sourceURL),
+		slog.Int("size_bytes", asset.SizeBytes),
+		slog.String("workspace_id", asset.WorkspaceID),
+	)
+
+	return &cataloguc.UploadAssetResult{Asset: asset}, nil
+}
+
+// SignAssetURL returns a URL that serves the current version of an asset without an account.
+func (s *AssetService) SignAssetURL(ctx context.Context, workspaceID, id string) (*entity.AssetSignedURL, error) {
+	if s.signing.Key == "" {
+		return nil, entity.ErrAssetSigningDisabled
+	}
+	if _, err := s.assetRepo.FindByID(ctx, workspaceID, id); err != nil {
+		return nil, fmt.Errorf("finding asset %s: %w", id, err)
+	}
+
+	ttl := s.signing.TTL
+	if ttl <= 0 {
+		ttl = defaultAssetSignedURLTTL
+	}
+	expiresAt := time.Now().Add(ttl).UTC().Truncate(time.Second)
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	q := neturl.Values{}
+	q.Set("expires", expires)
+	q.Set("signature", s.signAsset(workspaceID, id, expires))
+	return &entity.AssetSignedURL{
+		URL:       strings.TrimRight(s.signing.PublicBaseURL, "/") + PublicAssetsPath + workspaceID + "/" + id + "?" + q.Encode(),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// OpenSignedAsset returns the current version of an asset with its content when the signature
+// of its signed URL is valid and has not expired.
+func (s *AssetService) OpenSignedAsset(ctx context.Context, workspaceID, id, expires, signature string) (*entity.AssetVersion, error) {
+	if s.signing.Key == "" {
+		return nil, entity.ErrInvalidSignedURL
+	}
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > exp {
+		return nil, entity.ErrInvalidSignedURL
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.signAsset(workspaceID, id, expires))) {
+		return nil, entity.ErrInvalidSignedURL
+	}
+
+	content, err := s.findContent(ctx, workspaceID, id, 0)
+	if err != nil {
+		return nil, fmt.Errorf("finding asset %s content: %w", id, err)
+	}
+	return content, nil
+}
+
+func (s *AssetService) signAsset(workspaceID, id, expires string) string {
+	mac := hmac.New(sha256.New, []byte(s.signing.Key))
+	mac.Write([]byte(workspaceID + "\n" + id + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// importedAssetRef returns the ID of the asset a workspace imported from a remote URL. Lookup
+// errors are logged and the URL is downloaded as usual.
+func (s *AssetService) importedAssetRef(ctx context.Context, workspaceID, url string) (string, bool) {
+	asset, err := s.assetRepo.FindBySourceURL(ctx, workspaceID, url)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to look up imported asset, downloading the image",
+			slog.String("url", url),
+			slog.Any("error", err),
+		)
+		return "", false
+	}
+	if asset == nil {
+		return "", false
+	}
+	return asset.ID, true
+}
+
+// isRemoteAssetURL reports whether url is an http or https URL.
+func isRemoteAssetURL(url string) bool {
+	return strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://")
+}
@@ -0,0 +1,366 @@
+package catalog
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	neturl "net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	cataloguc "github.com/rendis/pdf-forge/core/internal/core/usecase/catalog"
+)
+
+// NewAssetService creates a new asset library service.
+// imageEncoders add output formats to resized images, in order of preference. downloader fetches
+// the images imported from remote URLs; nil disables imports. storage keeps the content of new
+// versions in object storage instead of the database.
+func NewAssetService(
+	assetRepo port.AssetRepository,
+	txManager port.TransactionManager,
+	imageEncoders []port.ImageEncoder,
+	downloader port.AssetDownloader,
+	signing AssetSigningOptions,
+	storage AssetStorageOptions,
+) cataloguc.AssetUseCase {
+	s := &AssetService{
+		assetRepo:     assetRepo,
+		txManager:     txManager,
+		imageEncoders: make(map[string]port.ImageEncoder, len(imageEncoders)),
+		images:        newAssetImageCache(),
+		downloader:    downloader,
+		signing:       signing,
+		storage:       storage,
+	}
+	for _, encoder := range imageEncoders {
+		contentType := encoder.ContentType()
+		if _, ok := s.imageEncoders[contentType]; !ok {
+			s.imageEncoderOrder = append(s.imageEncoderOrder, contentType)
+		}
+		s.imageEncoders[contentType] = encoder
+	}
+	return s
+}
+
+// AssetService implements asset library business logic.
+type AssetService struct {
+	assetRepo         port.AssetRepository
+	txManager         port.TransactionManager
+	imageEncoders     map[string]port.ImageEncoder
+	imageEncoderOrder []string
+	images            *assetImageCache
+	downloader        port.AssetDownloader
+	signing           AssetSigningOptions
+	storage           AssetStorageOptions
+}
+
+// assetFile is an uploaded file checked against the limits of the library.
+type assetFile struct {
+	kind        entity.AssetKind
+	contentType string
+	sha256      string
+}
+
+// inspectAssetFile checks the size and type of an uploaded file and hashes it.
+func inspectAssetFile(data []byte) (*assetFile, error) {
+	if len(data) == 0 {
+		return nil, entity.ErrRequiredField
+	}
+	if len(data) > entity.AssetMaxSize {
+		return nil, entity.ErrAssetTooLarge
+	}
+	kind, contentType, ok := entity.DetectAssetType(data)
+	if !ok {
+		return nil, entity.ErrUnsupportedAssetType
+	}
+	sum := sha256.Sum256(data)
+	return &assetFile{kind: kind, contentType: contentType, sha256: hex.EncodeToString(sum[:])}, nil
+}
+
+// UploadAsset adds a file to the library, or returns the asset that already holds its content.
+func (s *AssetService) UploadAsset(ctx context.Context, cmd cataloguc.UploadAssetCommand) (*cataloguc.UploadAssetResult, error) {
+	file, err := inspectAssetFile(cmd.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.assetRepo.FindBySHA256(ctx, cmd.WorkspaceID, file.sha256)
+	if err != nil {
+		return nil, fmt.Errorf("checking asset duplicates: %w", err)
+	}
+	if existing != nil {
+		return &cataloguc.UploadAssetResult{Asset: existing, Duplicate: true}, nil
+	}
+
+	asset := entity.NewAsset(cmd.WorkspaceID, cmd.Name, file.kind, cmd.Tags, optionalUserID(cmd.CreatedBy))
+	if err := s.createAsset(ctx, asset, file, cmd.Data); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "asset uploaded",
+		slog.String("asset_id", asset.ID),
+		slog.String("kind", string(asset.Kind)),
+		slog.Int("size_bytes", asset.SizeBytes),
+		slog.String("workspace_id", asset.WorkspaceID),
+	)
+
+	return &cataloguc.UploadAssetResult{Asset: asset}, nil
+}
+
+// createAsset validates a new asset and stores it with data as its first version.
+func (s *AssetService) createAsset(ctx context.Context, asset *entity.Asset, file *assetFile, data []byte) error {
+	if err := asset.Validate(); err != nil {
+		return fmt.Errorf("validating asset: %w", err)
+	}
+	asset.ContentType = file.contentType
+	asset.SizeBytes = len(data)
+	asset.SHA256 = file.sha256
+
+	version := &entity.AssetVersion{
+		Version:     asset.CurrentVersion,
+		ContentType: file.contentType,
+		SizeBytes:   len(data),
+		SHA256:      file.sha256,
+		Data:        data,
+		CreatedBy:   asset.CreatedBy,
+		CreatedAt:   asset.CreatedAt,
+	}
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		id, err := s.assetRepo.Create(ctx, asset)
+		if err != nil {
+			return fmt.Errorf("creating asset: %w", err)
+		}
+		asset.ID = id
+		version.AssetID = id
+
+		if err := s.storeVersionContent(ctx, asset.WorkspaceID, version); err != nil {
+			return err
+		}
+		return s.assetRepo.CreateVersion(ctx, version)
+	})
+	if err != nil {
+		s.deleteObjects(ctx, []*entity.AssetVersion{version})
+	}
+	return err
+}
+
+// UploadAssetVersion stores new content for an asset and makes it current.
+// Uploading the content of the current version again changes nothing.
+func (s *AssetService) UploadAssetVersion(ctx context.Context, cmd cataloguc.UploadAssetVersionCommand) (*entity.Asset, error) {
+	file, err := inspectAssetFile(cmd.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	asset, err := s.assetRepo.FindByID(ctx, cmd.WorkspaceID, cmd.ID)
+	if err != nil {
+		return nil, fmt.Errorf("finding asset %s: %w", cmd.ID, err)
+	}
+	if file.kind != asset.Kind {
+		return nil, entity.ErrAssetKindMismatch
+	}
+	if file.sha256 == asset.SHA256 {
+		return asset, nil
+	}
+
+	version := &entity.AssetVersion{
+		AssetID:     asset.ID,
+		Version:     asset.CurrentVersion + 1,
+		ContentType: file.contentType,
+		SizeBytes:   len(cmd.Data),
+		SHA256:      file.sha256,
+		Data:        cmd.Data,
+		CreatedBy:   optionalUserID(cmd.CreatedBy),
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err := s.storeVersionContent(ctx, asset.WorkspaceID, version); err != nil {
+		return nil, err
+	}
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.assetRepo.CreateVersion(ctx, version); err != nil {
+			return fmt.Errorf("creating asset version: %w", err)
+		}
+		return s.assetRepo.SetCurrentVersion(ctx, asset.ID, version.Version)
+	})
+	if err != nil {
+		s.deleteObjects(ctx, []*entity.AssetVersion{version})
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "asset version uploaded",
+		slog.String("asset_id", asset.ID),
+		slog.Int("version", version.Version),
+		slog.String("workspace_id", asset.WorkspaceID),
+	)
+
+	asset.CurrentVersion = version.Version
+	asset.ContentType = version.ContentType
+	asset.SizeBytes = version.SizeBytes
+	asset.SHA256 = version.SHA256
+	asset.UpdatedAt = &version.CreatedAt
+	return asset, nil
+}
+
+// GetAsset retrieves an asset of a workspace.
+func (s *AssetService) GetAsset(ctx context.Context, workspaceID, id string) (*entity.Asset, error) {
+	asset, err := s.assetRepo.FindByID(ctx, workspaceID, id)
+	if err != nil {
+		return nil, fmt.Errorf("finding asset %s: %w", id, err)
+	}
+	return asset, nil
+}
+
+// ListAssets lists the assets of a workspace with optional filters.
+func (s *AssetService) ListAssets(ctx context.Context, workspaceID string, filters port.AssetFilters) ([]*entity.Asset, error) {
+	filters.Tag = entity.NormalizeTagName(filters.Tag)
+	assets, err := s.assetRepo.FindByWorkspace(ctx, workspaceID, filters)
+	if err != nil {
+		return nil, fmt.Errorf("listing assets: %w", err)
+	}
+	return assets, nil
+}
+
+// ListAssetVersions lists the versions of an asset, newest first.
+func (s *AssetService) ListAssetVersions(ctx context.Context, workspaceID, id string) ([]*entity.AssetVersion, error) {
+	if _, err := s.assetRepo.FindByID(ctx, workspaceID, id); err != nil {
+		return nil, fmt.Errorf("finding asset %s: %w", id, err)
+	}
+	versions, err := s.assetRepo.FindVersions(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("listing asset versions: %w", err)
+	}
+	return versions, nil
+}
+
+// GetAssetContent returns a version of an asset with its content.
+func (s *AssetService) GetAssetContent(ctx context.Context, workspaceID, id string, version int) (*entity.AssetVersion, error) {
+	content, err := s.findContent(ctx, workspaceID, id, version)
+	if err != nil {
+		return nil, fmt.Errorf("finding asset %s content: %w", id, err)
+	}
+	return content, nil
+}
+
+// ListAssetUsages lists the template versions that reference an asset.
+func (s *AssetService) ListAssetUsages(ctx context.Context, workspaceID, id string) ([]*entity.AssetUsage, error) {
+	if _, err := s.assetRepo.FindByID(ctx, workspaceID, id); err != nil {
+		return nil, fmt.Errorf("finding asset %s: %w", id, err)
+	}
+	usages, err := s.assetRepo.FindUsages(ctx, workspaceID, id)
+	if err != nil {
+		return nil, fmt.Errorf("listing asset usages: %w", err)
+	}
+	return usages, nil
+}
+
+// UpdateAsset renames or retags an asset.
+func (s *AssetService) UpdateAsset(ctx context.Context, cmd cataloguc.UpdateAssetCommand) (*entity.Asset, error) {
+	asset, err := s.assetRepo.FindByID(ctx, cmd.WorkspaceID, cmd.ID)
+	if err != nil {
+		return nil, fmt.Errorf("finding asset %s: %w", cmd.ID, err)
+	}
+
+	asset.Name = strings.TrimSpace(cmd.Name)
+	asset.Tags = entity.NormalizeAssetTags(cmd.Tags)
+	if err := asset.Validate(); err != nil {
+		return nil, fmt.Errorf("validating asset: %w", err)
+	}
+
+	if err := s.assetRepo.Update(ctx, asset); err != nil {
+		return nil, fmt.Errorf("updating asset: %w", err)
+	}
+	now := time.Now().UTC()
+	asset.UpdatedAt = &now
+
+	return asset, nil
+}
+
+// DeleteAsset deletes an asset with its versions.
+func (s *AssetService) DeleteAsset(ctx context.Context, workspaceID, id string) error {
+	if _, err := s.assetRepo.FindByID(ctx, workspaceID, id); err != nil {
+		return fmt.Errorf("finding asset %s: %w", id, err)
+	}
+
+	usages, err := s.assetRepo.FindUsages(ctx, workspaceID, id)
+	if err != nil {
+		return fmt.Errorf("checking asset usage: %w", err)
+	}
+	if len(usages) > 0 {
+		return entity.ErrAssetInUse
+	}
+
+	versions, err := s.assetRepo.FindVersions(ctx, id)
+	if err != nil {
+		return fmt.Errorf("listing asset versions: %w", err)
+	}
+	if err := s.assetRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("deleting asset: %w", err)
+	}
+	s.deleteObjects(ctx, versions)
+
+	slog.InfoContext(ctx, "asset deleted",
+		slog.String("asset_id", id),
+		slog.String("workspace_id", workspaceID),
+	)
+
+	return nil
+}
+
+// URLResolver returns a render URL resolver for the assets of a workspace. Assets are inlined as
+// data URLs so renders never depend on a storage service being reachable. Images are scaled down
+// to the width hint of the URL (asset://<id>?w=<px>), capped at the width of a 300 dpi A4 page.
+// Remote URLs the workspace imported are resolved to their asset, so they are not downloaded.
+func (s *AssetService) URLResolver(workspaceID string, next func(ctx context.Context, url string) (string, error)) func(ctx context.Context, url string) (string, error) {
+	return func(ctx context.Context, url string) (string, error) {
+		ref, ok := strings.CutPrefix(url, entity.AssetURLScheme)
+		if !ok && isRemoteAssetURL(url) {
+			ref, ok = s.importedAssetRef(ctx, workspaceID, url)
+		}
+		if !ok {
+			if next == nil {
+				return url, nil
+			}
+			return next(ctx, url)
+		}
+		id, query, _ := strings.Cut(ref, "?")
+
+		content, err := s.findContent(ctx, workspaceID, id, 0)
+		if err != nil {
+			return "", fmt.Errorf("resolving asset %s: %w", id, err)
+		}
+
+		contentType, data := content.ContentType, content.Data
+		if strings.HasPrefix(contentType, "image/") {
+			width := assetRenderMaxWidth
+			if values, err := neturl.ParseQuery(query); err == nil {
+				if w, err := strconv.Atoi(values.Get("w")); err == nil && w > 0 && w < width {
+					width = w
+				}
+			}
+			img, err := s.transformImage(content, width, defaultImageType(contentType), assetRenderQuality)
+			if err != nil {
+				slog.WarnContext(ctx, "failed to resize asset image, using the original",
+					slog.String("asset_id", id),
+					slog.Any("error", err),
+				)
+			} else {
+				contentType, data = img.ContentType, img.Data
+			}
+		}
+		return "data:" + contentType + ";base64," + base64.StdEncoding.EncodeToString(data), nil
+	}
+}
+
+// optionalUserID returns nil for an empty user ID, e.g. for API key callers.
+func optionalUserID(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
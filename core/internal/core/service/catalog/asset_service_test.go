@@ -0,0 +1,446 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	neturl "net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	cataloguc "github.com/rendis/pdf-forge/core/internal/core/usecase/catalog"
+)
+
+var (
+	testPNG = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	testPDF = []byte("%PDF-1.7\n%%EOF")
+)
+
+type noTx struct{}
+
+func (noTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// assetRepoStub keeps assets and their versions in memory.
+type assetRepoStub struct {
+	port.AssetRepository
+	assets   map[string]*entity.Asset
+	versions map[string][]*entity.AssetVersion
+	usages   map[string][]*entity.AssetUsage
+}
+
+func newAssetRepoStub() *assetRepoStub {
+	return &assetRepoStub{
+		assets:   map[string]*entity.Asset{},
+		versions: map[string][]*entity.AssetVersion{},
+		usages:   map[string][]*entity.AssetUsage{},
+	}
+}
+
+func (r *assetRepoStub) Create(_ context.Context, asset *entity.Asset) (string, error) {
+	id := "asset-" + string(rune('a'+len(r.assets)))
+	stored := *asset
+	stored.ID = id
+	r.assets[id] = &stored
+	return id, nil
+}
+
+func (r *assetRepoStub) CreateVersion(_ context.Context, version *entity.AssetVersion) error {
+	r.versions[version.AssetID] = append(r.versions[version.AssetID], version)
+	return nil
+}
+
+func (r *assetRepoStub) SetCurrentVersion(_ context.Context, id string, version int) error {
+	r.assets[id].CurrentVersion = version
+	return nil
+}
+
+func (r *assetRepoStub) current(id string) *entity.AssetVersion {
+	for _, v := range r.versions[id] {
+		if v.Version == r.assets[id].CurrentVersion {
+			return v
+		}
+	}
+	return nil
+}
+
+func (r *assetRepoStub) FindByID(_ context.Context, workspaceID, id string) (*entity.Asset, error) {
+	a, ok := r.assets[id]
+	if !ok || a.WorkspaceID != workspaceID {
+		return nil, entity.ErrAssetNotFound
+	}
+	found := *a
+	v := r.current(id)
+	found.ContentType, found.SizeBytes, found.SHA256 = v.ContentType, v.SizeBytes, v.SHA256
+	return &found, nil
+}
+
+func (r *assetRepoStub) FindBySHA256(ctx context.Context, workspaceID, sha256 string) (*entity.Asset, error) {
+	for id := range r.assets {
+		if a, err := r.FindByID(ctx, workspaceID, id); err == nil && a.SHA256 == sha256 {
+			return a, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *assetRepoStub) FindBySourceURL(ctx context.Context, workspaceID, sourceURL string) (*entity.Asset, error) {
+	for id, a := range r.assets {
+		if a.WorkspaceID == workspaceID && a.SourceURL != nil && *a.SourceURL == sourceURL {
+			return r.FindByID(ctx, workspaceID, id)
+		}
+	}
+	return nil, nil
+}
+
+func (r *assetRepoStub) SetSourceURL(_ context.Context, id, sourceURL string) error {
+	r.assets[id].SourceURL = &sourceURL
+	return nil
+}
+
+func (r *assetRepoStub) FindContent(_ context.Context, workspaceID, id string, _ int) (*entity.AssetVersion, error) {
+	if a, ok := r.assets[id]; !ok || a.WorkspaceID != workspaceID {
+		return nil, entity.ErrAssetNotFound
+	}
+	return r.current(id), nil
+}
+
+func (r *assetRepoStub) FindUsages(_ context.Context, _, id string) ([]*entity.AssetUsage, error) {
+	return r.usages[id], nil
+}
+
+func (r *assetRepoStub) FindVersions(_ context.Context, id string) ([]*entity.AssetVersion, error) {
+	return r.versions[id], nil
+}
+
+func (r *assetRepoStub) StorageKeyInUse(_ context.Context, key string) (bool, error) {
+	for _, versions := range r.versions {
+		for _, v := range versions {
+			if v.StorageKey == key {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (r *assetRepoStub) Delete(_ context.Context, id string) error {
+	delete(r.assets, id)
+	delete(r.versions, id)
+	return nil
+}
+
+// objectStorageStub keeps objects in memory.
+type objectStorageStub struct {
+	port.ObjectStorage
+	objects map[string][]byte
+}
+
+func (o *objectStorageStub) Put(_ context.Context, obj *port.StorageObject) error {
+	data, err := io.ReadAll(obj.Body)
+	if err != nil {
+		return err
+	}
+	o.objects[obj.Key] = data
+	return nil
+}
+
+func (o *objectStorageStub) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	data, ok := o.objects[key]
+	if !ok {
+		return nil, entity.ErrObjectNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (o *objectStorageStub) Delete(_ context.Context, key string) error {
+	delete(o.objects, key)
+	return nil
+}
+
+func TestUploadAsset_DeduplicatesByContent(t *testing.T) {
+	repo := newAssetRepoStub()
+	s := NewAssetService(repo, noTx{}, nil, nil, AssetSigningOptions{}, AssetStorageOptions{})
+	ctx := context.Background()
+
+	first, err := s.UploadAsset(ctx, cataloguc.UploadAssetCommand{WorkspaceID: "ws-1", Name: " Logo ", Tags: []string{"Brand", "brand", ""}, Data: testPNG})
+	require.NoError(t, err)
+	assert.False(t, first.Duplicate)
+	assert.Equal(t, "Logo", first.Asset.Name)
+	assert.Equal(t, entity.AssetKindImage, first.Asset.Kind)
+	assert.Equal(t, "image/png", first.Asset.ContentType)
+	assert.Equal(t, []string{"brand"}, first.Asset.Tags)
+
+	again, err := s.UploadAsset(ctx, cataloguc.UploadAssetCommand{WorkspaceID: "ws-1", Name: "Logo copy", Data: testPNG})
+	require.NoError(t, err)
+	assert.True(t, again.Duplicate)
+	assert.Equal(t, first.Asset.ID, again.Asset.ID)
+
+	other, err := s.UploadAsset(ctx, cataloguc.UploadAssetCommand{WorkspaceID: "ws-2", Name: "Logo", Data: testPNG})
+	require.NoError(t, err)
+	assert.False(t, other.Duplicate, "deduplication is scoped to the workspace")
+}
+
+func TestUploadAsset_RejectsInvalidFiles(t *testing.T) {
+	s := NewAssetService(newAssetRepoStub(), noTx{}, nil, nil, AssetSigningOptions{}, AssetStorageOptions{})
+	ctx := context.Background()
+
+	_, err := s.UploadAsset(ctx, cataloguc.UploadAssetCommand{WorkspaceID: "ws-1", Name: "notes", Data: []byte("plain text")})
+	assert.ErrorIs(t, err, entity.ErrUnsupportedAssetType)
+
+	large := append([]byte("%PDF-"), make([]byte, entity.AssetMaxSize)...)
+	_, err = s.UploadAsset(ctx, cataloguc.UploadAssetCommand{WorkspaceID: "ws-1", Name: "big", Data: large})
+	assert.ErrorIs(t, err, entity.ErrAssetTooLarge)
+}
+
+func TestUploadAssetVersion(t *testing.T) {
+	repo := newAssetRepoStub()
+	s := NewAssetService(repo, noTx{}, nil, nil, AssetSigningOptions{}, AssetStorageOptions{})
+	ctx := context.Background()
+
+	uploaded, err := s.UploadAsset(ctx, cataloguc.UploadAssetCommand{WorkspaceID: "ws-1", Name: "Terms", Data: testPDF})
+	require.NoError(t, err)
+	id := uploaded.Asset.ID
+
+	_, err = s.UploadAssetVersion(ctx, cataloguc.UploadAssetVersionCommand{WorkspaceID: "ws-1", ID: id, Data: testPNG})
+	assert.ErrorIs(t, err, entity.ErrAssetKindMismatch)
+
+	same, err := s.UploadAssetVersion(ctx, cataloguc.UploadAssetVersionCommand{WorkspaceID: "ws-1", ID: id, Data: testPDF})
+	require.NoError(t, err)
+	assert.Equal(t, 1, same.CurrentVersion, "identical content does not create a version")
+
+	updated, err := s.UploadAssetVersion(ctx, cataloguc.UploadAssetVersionCommand{WorkspaceID: "ws-1", ID: id, Data: []byte("%PDF-2.0\n%%EOF")})
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.CurrentVersion)
+	assert.Len(t, repo.versions[id], 2)
+
+	_, err = s.UploadAssetVersion(ctx, cataloguc.UploadAssetVersionCommand{WorkspaceID: "ws-2", ID: id, Data: testPDF})
+	assert.ErrorIs(t, err, entity.ErrAssetNotFound)
+}
+
+func TestDeleteAsset_RejectsReferencedAssets(t *testing.T) {
+	repo := newAssetRepoStub()
+	s := NewAssetService(repo, noTx{}, nil, nil, AssetSigningOptions{}, AssetStorageOptions{})
+	ctx := context.Background()
+
+	uploaded, err := s.UploadAsset(ctx, cataloguc.UploadAssetCommand{WorkspaceID: "ws-1", Name: "Logo", Data: testPNG})
+	require.NoError(t, err)
+	id := uploaded.Asset.ID
+
+	repo.usages[id] = []*entity.AssetUsage{{TemplateID: "tpl-1", VersionID: "v-1"}}
+	assert.ErrorIs(t, s.DeleteAsset(ctx, "ws-1", id), entity.ErrAssetInUse)
+
+	delete(repo.usages, id)
+	require.NoError(t, s.DeleteAsset(ctx, "ws-1", id))
+	assert.Empty(t, repo.assets)
+}
+
+func TestAssetContentInObjectStorage(t *testing.T) {
+	repo := newAssetRepoStub()
+	objects := &objectStorageStub{objects: map[string][]byte{}}
+	s := NewAssetService(repo, noTx{}, nil, nil, AssetSigningOptions{}, AssetStorageOptions{Objects: objects, KeyPrefix: "/forge/", Store: true})
+	ctx := context.Background()
+
+	uploaded, err := s.UploadAsset(ctx, cataloguc.UploadAssetCommand{WorkspaceID: "ws-1", Name: "Logo", Data: testPNG})
+	require.NoError(t, err)
+	id := uploaded.Asset.ID
+
+	stored := repo.versions[id][0]
+	assert.Nil(t, stored.Data, "the database keeps only the key")
+	assert.Equal(t, "forge/assets/ws-1/"+id+"/"+uploaded.Asset.SHA256, stored.StorageKey)
+	assert.Equal(t, testPNG, objects.objects[stored.StorageKey])
+
+	content, err := s.GetAssetContent(ctx, "ws-1", id, 0)
+	require.NoError(t, err)
+	assert.Equal(t, testPNG, content.Data)
+	assert.Nil(t, stored.Data, "reading the content leaves the stored version untouched")
+
+	resolved, err := s.URLResolver("ws-1", nil)(ctx, "asset://"+id)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(resolved, "data:image/png;base64,"))
+
+	require.NoError(t, s.DeleteAsset(ctx, "ws-1", id))
+	assert.Empty(t, objects.objects)
+}
+
+func TestAssetContentInObjectStorage_KeepsKeysOfCopies(t *testing.T) {
+	repo := newAssetRepoStub()
+	objects := &objectStorageStub{objects: map[string][]byte{}}
+	s := NewAssetService(repo, noTx{}, nil, nil, AssetSigningOptions{}, AssetStorageOptions{Objects: objects, Store: true})
+	ctx := context.Background()
+
+	uploaded, err := s.UploadAsset(ctx, cataloguc.UploadAssetCommand{WorkspaceID: "ws-1", Name: "Terms", Data: testPDF})
+	require.NoError(t, err)
+	id := uploaded.Asset.ID
+	// A sandbox copy of the workspace shares the key of the version
+	copied := *repo.versions[id][0]
+	copied.AssetID = "asset-copy"
+	repo.versions["asset-copy"] = []*entity.AssetVersion{&copied}
+
+	require.NoError(t, s.DeleteAsset(ctx, "ws-1", id))
+	assert.Contains(t, objects.objects, copied.StorageKey)
+}
+
+func TestAssetContentInObjectStorage_NotConfigured(t *testing.T) {
+	repo := newAssetRepoStub()
+	objects := &objectStorageStub{objects: map[string][]byte{}}
+	ctx := context.Background()
+	uploaded, err := NewAssetService(repo, noTx{}, nil, nil, AssetSigningOptions{}, AssetStorageOptions{Objects: objects, Store: true}).
+		UploadAsset(ctx, cataloguc.UploadAssetCommand{WorkspaceID: "ws-1", Name: "Terms", Data: testPDF})
+	require.NoError(t, err)
+
+	s := NewAssetService(repo, noTx{}, nil, nil, AssetSigningOptions{}, AssetStorageOptions{})
+	_, err = s.GetAssetContent(ctx, "ws-1", uploaded.Asset.ID, 0)
+	assert.ErrorIs(t, err, entity.ErrObjectStorageNotConfigured)
+}
+
+func TestURLResolver(t *testing.T) {
+	repo := newAssetRepoStub()
+	s := NewAssetService(repo, noTx{}, nil, nil, AssetSigningOptions{}, AssetStorageOptions{})
+	ctx := context.Background()
+
+	uploaded, err := s.UploadAsset(ctx, cataloguc.UploadAssetCommand{WorkspaceID: "ws-1", Name: "Logo", Data: testPNG})
+	require.NoError(t, err)
+
+	next := func(_ context.Context, url string) (string, error) {
+		return "https://cdn.example.com/" + strings.TrimPrefix(url, "storage://"), nil
+	}
+	resolve := s.URLResolver("ws-1", next)
+
+	url, err := resolve(ctx, uploaded.Asset.URL())
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(url, "data:image/png;base64,"), url)
+
+	url, err = resolve(ctx, "storage://logo.png")
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.example.com/logo.png", url)
+
+	_, err = s.URLResolver("ws-2", nil)(ctx, uploaded.Asset.URL())
+	assert.ErrorIs(t, err, entity.ErrAssetNotFound, "assets of other workspaces are not resolved")
+
+	url, err = s.URLResolver("ws-1", nil)(ctx, "https://example.com/a.png")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/a.png", url)
+}
+
+// assetDownloaderStub serves files by URL and counts the downloads.
+type assetDownloaderStub struct {
+	files     map[string][]byte
+	downloads int
+}
+
+func (d *assetDownloaderStub) Download(_ context.Context, url string, _ int64) ([]byte, error) {
+	d.downloads++
+	data, ok := d.files[url]
+	if !ok {
+		return nil, errors.New("responded with status 404")
+	}
+	return data, nil
+}
+
+func TestImportAsset(t *testing.T) {
+	repo := newAssetRepoStub()
+	downloader := &assetDownloaderStub{files: map[string][]byte{
+		"https://picsum.photos/200":    testPNG,
+		"https://example.com/logo.png": testPNG,
+		"https://example.com/doc.pdf":  testPDF,
+	}}
+	s := NewAssetService(repo, noTx{}, nil, downloader, AssetSigningOptions{}, AssetStorageOptions{})
+	ctx := context.Background()
+
+	imported, err := s.ImportAsset(ctx, cataloguc.ImportAssetCommand{WorkspaceID: "ws-1", URL: "https://picsum.photos/200"})
+	require.NoError(t, err)
+	assert.False(t, imported.Duplicate)
+	assert.Equal(t, "200", imported.Asset.Name, "the name defaults to the last path segment")
+	require.NotNil(t, imported.Asset.SourceURL)
+	assert.Equal(t, "https://picsum.photos/200", *imported.Asset.SourceURL)
+
+	again, err := s.ImportAsset(ctx, cataloguc.ImportAssetCommand{WorkspaceID: "ws-1", URL: "https://picsum.photos/200"})
+	require.NoError(t, err)
+	assert.True(t, again.Duplicate)
+	assert.Equal(t, 1, downloader.downloads, "an imported URL is not downloaded again")
+
+	_, err = s.ImportAsset(ctx, cataloguc.ImportAssetCommand{WorkspaceID: "ws-1", URL: "https://example.com/doc.pdf"})
+	assert.ErrorIs(t, err, entity.ErrAssetNotImage)
+	_, err = s.ImportAsset(ctx, cataloguc.ImportAssetCommand{WorkspaceID: "ws-1", URL: "https://example.com/missing.png"})
+	assert.ErrorIs(t, err, entity.ErrAssetImportFailed)
+	_, err = s.ImportAsset(ctx, cataloguc.ImportAssetCommand{WorkspaceID: "ws-1", URL: "file:///etc/passwd"})
+	assert.ErrorIs(t, err, entity.ErrInvalidAssetSourceURL)
+}
+
+func TestImportAsset_LinksUploadedContent(t *testing.T) {
+	repo := newAssetRepoStub()
+	downloader := &assetDownloaderStub{files: map[string][]byte{"https://example.com/logo.png": testPNG}}
+	s := NewAssetService(repo, noTx{}, nil, downloader, AssetSigningOptions{}, AssetStorageOptions{})
+	ctx := context.Background()
+
+	uploaded, err := s.UploadAsset(ctx, cataloguc.UploadAssetCommand{WorkspaceID: "ws-1", Name: "Logo", Data: testPNG})
+	require.NoError(t, err)
+
+	imported, err := s.ImportAsset(ctx, cataloguc.ImportAssetCommand{WorkspaceID: "ws-1", URL: "https://example.com/logo.png"})
+	require.NoError(t, err)
+	assert.True(t, imported.Duplicate)
+	assert.Equal(t, uploaded.Asset.ID, imported.Asset.ID)
+	assert.Len(t, repo.assets, 1)
+}
+
+func TestURLResolver_ImportedRemoteURLs(t *testing.T) {
+	repo := newAssetRepoStub()
+	downloader := &assetDownloaderStub{files: map[string][]byte{"https://picsum.photos/200": testPNG}}
+	s := NewAssetService(repo, noTx{}, nil, downloader, AssetSigningOptions{}, AssetStorageOptions{})
+	ctx := context.Background()
+
+	_, err := s.ImportAsset(ctx, cataloguc.ImportAssetCommand{WorkspaceID: "ws-1", URL: "https://picsum.photos/200"})
+	require.NoError(t, err)
+
+	url, err := s.URLResolver("ws-1", nil)(ctx, "https://picsum.photos/200")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(url, "data:image/png;base64,"), url)
+
+	url, err = s.URLResolver("ws-2", nil)(ctx, "https://picsum.photos/200")
+	require.NoError(t, err)
+	assert.Equal(t, "https://picsum.photos/200", url, "other workspaces download the image")
+}
+
+func TestSignAssetURL(t *testing.T) {
+	repo := newAssetRepoStub()
+	signing := AssetSigningOptions{Key: "secret", PublicBaseURL: "https://forge.example.com/", TTL: time.Minute}
+	s := NewAssetService(repo, noTx{}, nil, nil, signing, AssetStorageOptions{})
+	ctx := context.Background()
+
+	uploaded, err := s.UploadAsset(ctx, cataloguc.UploadAssetCommand{WorkspaceID: "ws-1", Name: "Logo", Data: testPNG})
+	require.NoError(t, err)
+	id := uploaded.Asset.ID
+
+	signed, err := s.SignAssetURL(ctx, "ws-1", id)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), signed.ExpiresAt, 2*time.Second)
+
+	u, err := neturl.Parse(signed.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "forge.example.com", u.Host)
+	assert.Equal(t, PublicAssetsPath+"ws-1/"+id, u.Path)
+	expires, signature := u.Query().Get("expires"), u.Query().Get("signature")
+
+	content, err := s.OpenSignedAsset(ctx, "ws-1", id, expires, signature)
+	require.NoError(t, err)
+	assert.Equal(t, testPNG, content.Data)
+
+	_, err = s.OpenSignedAsset(ctx, "ws-2", id, expires, signature)
+	assert.ErrorIs(t, err, entity.ErrInvalidSignedURL, "the signature covers the workspace")
+	_, err = s.OpenSignedAsset(ctx, "ws-1", id, "1", signature)
+	assert.ErrorIs(t, err, entity.ErrInvalidSignedURL)
+
+	_, err = s.SignAssetURL(ctx, "ws-2", id)
+	assert.ErrorIs(t, err, entity.ErrAssetNotFound)
+	_, err = NewAssetService(repo, noTx{}, nil, nil, AssetSigningOptions{}, AssetStorageOptions{}).SignAssetURL(ctx, "ws-1", id)
+	assert.ErrorIs(t, err, entity.ErrAssetSigningDisabled)
+}
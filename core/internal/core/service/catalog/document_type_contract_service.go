@@ -0,0 +1,108 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	cataloguc "github.com/rendis/pdf-forge/core/internal/core/usecase/catalog"
+)
+
+// NewDocumentTypeContractService creates a new document type contract service.
+func NewDocumentTypeContractService(
+	contractRepo port.DocumentTypeContractRepository,
+	docTypeRepo port.DocumentTypeRepository,
+) cataloguc.DocumentTypeContractUseCase {
+	return &DocumentTypeContractService{
+		contractRepo: contractRepo,
+		docTypeRepo:  docTypeRepo,
+	}
+}
+
+// DocumentTypeContractService implements document type payload contract business logic.
+type DocumentTypeContractService struct {
+	contractRepo port.DocumentTypeContractRepository
+	docTypeRepo  port.DocumentTypeRepository
+}
+
+// GetContract gets the contract of a document type of the tenant or a global one.
+func (s *DocumentTypeContractService) GetContract(ctx context.Context, tenantID, documentTypeID string) (*entity.DocumentTypeContract, error) {
+	docType, err := s.docTypeRepo.FindByID(ctx, documentTypeID)
+	if err != nil {
+		return nil, fmt.Errorf("finding document type: %w", err)
+	}
+	if docType.TenantID != tenantID {
+		isGlobal, err := s.docTypeRepo.IsSysTenant(ctx, docType.TenantID)
+		if err != nil {
+			return nil, fmt.Errorf("checking tenant type: %w", err)
+		}
+		if !isGlobal {
+			return nil, entity.ErrDocumentTypeNotFound
+		}
+	}
+	return s.contractRepo.FindByDocumentTypeID(ctx, documentTypeID)
+}
+
+// GetContractByCode gets the contract render requests for a document type code must satisfy.
+func (s *DocumentTypeContractService) GetContractByCode(ctx context.Context, tenantCode, documentTypeCode string) (*entity.DocumentTypeContract, error) {
+	return s.contractRepo.FindByDocumentTypeCode(ctx,
+		strings.ToUpper(strings.TrimSpace(tenantCode)),
+		strings.ToUpper(strings.TrimSpace(documentTypeCode)),
+	)
+}
+
+// SetContract creates or replaces the contract of a document type of the tenant.
+func (s *DocumentTypeContractService) SetContract(ctx context.Context, cmd cataloguc.SetDocumentTypeContractCommand) (*entity.DocumentTypeContract, error) {
+	if err := s.checkOwnership(ctx, cmd.TenantID, cmd.DocumentTypeID); err != nil {
+		return nil, err
+	}
+
+	contract := &entity.DocumentTypeContract{
+		DocumentTypeID: cmd.DocumentTypeID,
+		Schema:         cmd.Schema,
+		Example:        cmd.Example,
+		UpdatedBy:      &cmd.UpdatedBy,
+		CreatedAt:      time.Now().UTC(),
+	}
+	if err := contract.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.contractRepo.Upsert(ctx, contract); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "document type contract set",
+		slog.String("document_type_id", cmd.DocumentTypeID),
+		slog.String("updated_by", cmd.UpdatedBy),
+	)
+	return contract, nil
+}
+
+// DeleteContract removes the contract of a document type of the tenant.
+func (s *DocumentTypeContractService) DeleteContract(ctx context.Context, tenantID, documentTypeID string) error {
+	if err := s.checkOwnership(ctx, tenantID, documentTypeID); err != nil {
+		return err
+	}
+	if err := s.contractRepo.Delete(ctx, documentTypeID); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "document type contract deleted", slog.String("document_type_id", documentTypeID))
+	return nil
+}
+
+// checkOwnership returns ErrCannotModifyGlobalType unless the document type belongs to the tenant.
+func (s *DocumentTypeContractService) checkOwnership(ctx context.Context, tenantID, documentTypeID string) error {
+	docType, err := s.docTypeRepo.FindByID(ctx, documentTypeID)
+	if err != nil {
+		return fmt.Errorf("finding document type: %w", err)
+	}
+	if docType.TenantID != tenantID {
+		return entity.ErrCannotModifyGlobalType
+	}
+	return nil
+}
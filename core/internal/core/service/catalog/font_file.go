@@ -0,0 +1,163 @@
+package catalog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// fontFile is the face an uploaded font file holds, read from its name and OS/2 tables.
+type fontFile struct {
+	format entity.FontFormat
+	family string
+	weight int
+	style  entity.FontStyle
+}
+
+// Name IDs of the OpenType name table.
+const (
+	nameIDFamily            = 1
+	nameIDSubfamily         = 2
+	nameIDTypographicFamily = 16
+)
+
+// parseFontFile reads the family, weight and style of a TrueType or OpenType font. Collections and
+// web fonts are rejected: Typst does not load WOFF, and a collection would add several families
+// under one upload.
+func parseFontFile(data []byte) (*fontFile, error) {
+	var format entity.FontFormat
+	switch {
+	case bytes.HasPrefix(data, []byte{0x00, 0x01, 0x00, 0x00}), bytes.HasPrefix(data, []byte("true")):
+		format = entity.FontFormatTTF
+	case bytes.HasPrefix(data, []byte("OTTO")):
+		format = entity.FontFormatOTF
+	default:
+		return nil, entity.ErrUnsupportedFontFile
+	}
+
+	tables, ok := fontTables(data)
+	if !ok {
+		return nil, entity.ErrUnsupportedFontFile
+	}
+	names, ok := fontNames(tables["name"])
+	if !ok {
+		return nil, entity.ErrUnsupportedFontFile
+	}
+
+	// Typst prefers the typographic family, which groups more than the four legacy styles
+	family := names[nameIDTypographicFamily]
+	if family == "" {
+		family = names[nameIDFamily]
+	}
+	family = strings.TrimSpace(family)
+	if family == "" || len(family) > 255 {
+		return nil, entity.ErrUnsupportedFontFile
+	}
+
+	font := &fontFile{format: format, family: family, weight: 400, style: entity.FontStyleNormal}
+	subfamily := strings.ToLower(names[nameIDSubfamily])
+	if strings.Contains(subfamily, "italic") || strings.Contains(subfamily, "oblique") {
+		font.style = entity.FontStyleItalic
+	}
+	if os2 := tables["OS/2"]; len(os2) >= 64 {
+		if weight := int(binary.BigEndian.Uint16(os2[4:])); weight >= 1 && weight <= 1000 {
+			// Round to the CSS weights the editor offers
+			font.weight = min(max((weight+50)/100*100, 100), 900)
+		}
+		// fsSelection bit 0 is italic, bit 9 oblique
+		if selection := binary.BigEndian.Uint16(os2[62:]); selection&(1|1<<9) != 0 {
+			font.style = entity.FontStyleItalic
+		}
+	}
+	return font, nil
+}
+
+// fontTables returns the tables of an sfnt font by tag. Tables extending past the data are left out.
+func fontTables(data []byte) (map[string][]byte, bool) {
+	if len(data) < 12 {
+		return nil, false
+	}
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	if len(data) < 12+16*numTables {
+		return nil, false
+	}
+	tables := make(map[string][]byte, numTables)
+	for i := range numTables {
+		record := data[12+16*i:]
+		offset := int64(binary.BigEndian.Uint32(record[8:]))
+		length := int64(binary.BigEndian.Uint32(record[12:]))
+		if offset+length > int64(len(data)) {
+			continue
+		}
+		tables[string(record[:4])] = data[offset : offset+length]
+	}
+	return tables, true
+}
+
+// fontNames decodes the family and subfamily names of a name table, preferring Windows English
+// (United States) names over other Windows, Unicode and Macintosh Roman ones.
+func fontNames(table []byte) (map[int]string, bool) {
+	if len(table) < 6 {
+		return nil, false
+	}
+	count := int(binary.BigEndian.Uint16(table[2:]))
+	storage := int(binary.BigEndian.Uint16(table[4:]))
+	if len(table) < 6+12*count {
+		return nil, false
+	}
+
+	names := make(map[int]string)
+	ranks := make(map[int]int)
+	for i := range count {
+		record := table[6+12*i:]
+		platform := binary.BigEndian.Uint16(record)
+		encoding := binary.BigEndian.Uint16(record[2:])
+		language := binary.BigEndian.Uint16(record[4:])
+		nameID := int(binary.BigEndian.Uint16(record[6:]))
+		length := int(binary.BigEndian.Uint16(record[8:]))
+		offset := storage + int(binary.BigEndian.Uint16(record[10:]))
+		if nameID != nameIDFamily && nameID != nameIDSubfamily && nameID != nameIDTypographicFamily {
+			continue
+		}
+		if offset+length > len(table) {
+			continue
+		}
+		raw := table[offset : offset+length]
+
+		var name string
+		var rank int
+		switch {
+		case platform == 3 && (encoding == 1 || encoding == 10):
+			name, rank = decodeUTF16BE(raw), 2
+			if language == 0x0409 {
+				rank = 3
+			}
+		case platform == 0:
+			name, rank = decodeUTF16BE(raw), 1
+		case platform == 1 && encoding == 0:
+			// Mac Roman matches Latin-1 for the ASCII names fonts use in practice
+			runes := make([]rune, len(raw))
+			for i, b := range raw {
+				runes[i] = rune(b)
+			}
+			name, rank = string(runes), 0
+		default:
+			continue
+		}
+		if existing, ok := ranks[nameID]; (!ok || rank > existing) && name != "" {
+			names[nameID], ranks[nameID] = name, rank
+		}
+	}
+	return names, true
+}
+
+func decodeUTF16BE(raw []byte) string {
+	units := make([]uint16, len(raw)/2)
+	for i := range units {
+		units[i] = binary.BigEndian.Uint16(raw[2*i:])
+	}
+	return string(utf16.Decode(units))
+}
@@ -0,0 +1,275 @@
+package catalog
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	cataloguc "github.com/rendis/pdf-forge/core/internal/core/usecase/catalog"
+)
+
+// staleFontDirRetention is how long the font directory of a workspace is kept after its fonts
+// change, so renders that started with it finish before it is removed.
+const staleFontDirRetention = 5 * time.Minute
+
+// NewWorkspaceFontService creates a new workspace font service. The fonts of each workspace are
+// written to a directory under cacheDir for renders; empty uses the temp directory. system lists
+// the fonts installed on the server and may be nil.
+func NewWorkspaceFontService(fontRepo port.WorkspaceFontRepository, cacheDir string, system port.FontFamilyLister) cataloguc.WorkspaceFontUseCase {
+	if cacheDir == "" {
+		cacheDir = filepath.Join(os.TempDir(), "pdf-forge-fonts")
+	}
+	return &WorkspaceFontService{
+		fontRepo: fontRepo,
+		cacheDir: cacheDir,
+		system:   system,
+		dirs:     make(map[string]string),
+	}
+}
+
+// WorkspaceFontService implements the fonts uploaded to workspaces.
+type WorkspaceFontService struct {
+	fontRepo port.WorkspaceFontRepository
+	cacheDir string
+	system   port.FontFamilyLister
+
+	mu   sync.Mutex
+	dirs map[string]string // current font set key by workspace ID
+}
+
+// UploadFont adds a font to a workspace, or returns the font that already holds the same file.
+func (s *WorkspaceFontService) UploadFont(ctx context.Context, cmd cataloguc.UploadFontCommand) (*cataloguc.UploadFontResult, error) {
+	if len(cmd.Data) == 0 {
+		return nil, entity.ErrRequiredField
+	}
+	if len(cmd.Data) > entity.FontMaxSize {
+		return nil, entity.ErrFontTooLarge
+	}
+	file, err := parseFontFile(cmd.Data)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(cmd.Data)
+
+	font := &entity.WorkspaceFont{
+		WorkspaceID: cmd.WorkspaceID,
+		Family:      file.family,
+		Weight:      file.weight,
+		Style:       file.style,
+		Format:      file.format,
+		SizeBytes:   len(cmd.Data),
+		SHA256:      hex.EncodeToString(sum[:]),
+		Data:        cmd.Data,
+		CreatedBy:   optionalUserID(cmd.CreatedBy),
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	existing, err := s.fontRepo.FindByWorkspace(ctx, cmd.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("listing workspace fonts: %w", err)
+	}
+	for _, f := range existing {
+		if f.SHA256 == font.SHA256 {
+			return &cataloguc.UploadFontResult{Font: f, Duplicate: true}, nil
+		}
+		if f.SameFace(font) {
+			return nil, entity.ErrFontFaceExists
+		}
+	}
+	if len(existing) >= entity.MaxWorkspaceFonts {
+		return nil, entity.ErrTooManyWorkspaceFonts
+	}
+
+	id, err := s.fontRepo.Create(ctx, font)
+	if err != nil {
+		return nil, fmt.Errorf("creating font: %w", err)
+	}
+	font.ID = id
+
+	slog.InfoContext(ctx, "workspace font uploaded",
+		slog.String("font_id", font.ID),
+		slog.String("family", font.Family),
+		slog.Int("weight", font.Weight),
+		slog.String("style", string(font.Style)),
+		slog.String("workspace_id", font.WorkspaceID),
+	)
+
+	return &cataloguc.UploadFontResult{Font: font}, nil
+}
+
+// ListFonts lists the fonts uploaded to a workspace.
+func (s *WorkspaceFontService) ListFonts(ctx context.Context, workspaceID string) ([]*entity.WorkspaceFont, error) {
+	fonts, err := s.fontRepo.FindByWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("listing workspace fonts: %w", err)
+	}
+	return fonts, nil
+}
+
+// ListFontFamilies lists the installed families and those of the workspace fonts. A workspace
+// family named like an installed one is listed once, as a workspace family.
+func (s *WorkspaceFontService) ListFontFamilies(ctx context.Context, workspaceID string) ([]*entity.FontFamily, error) {
+	fonts, err := s.fontRepo.FindByWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("listing workspace fonts: %w", err)
+	}
+
+	families := make(map[string]*entity.FontFamily)
+	if s.system != nil {
+		for _, name := range s.system.FontFamilies(ctx) {
+			families[strings.ToLower(name)] = &entity.FontFamily{Name: name, Source: entity.FontSourceSystem}
+		}
+	}
+	for _, f := range fonts {
+		key := strings.ToLower(f.Family)
+		family, ok := families[key]
+		if !ok || family.Source != entity.FontSourceWorkspace {
+			family = &entity.FontFamily{Name: f.Family, Source: entity.FontSourceWorkspace}
+			families[key] = family
+		}
+		family.Faces = append(family.Faces, entity.FontFace{Weight: f.Weight, Style: f.Style})
+	}
+
+	result := make([]*entity.FontFamily, 0, len(families))
+	for _, family := range families {
+		slices.SortFunc(family.Faces, func(a, b entity.FontFace) int {
+			return cmp.Or(cmp.Compare(a.Style, b.Style), cmp.Compare(a.Weight, b.Weight))
+		})
+		result = append(result, family)
+	}
+	slices.SortFunc(result, func(a, b *entity.FontFamily) int {
+		return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	return result, nil
+}
+
+// GetFontContent returns a font of a workspace with its file.
+func (s *WorkspaceFontService) GetFontContent(ctx context.Context, workspaceID, id string) (*entity.WorkspaceFont, error) {
+	font, err := s.fontRepo.FindContent(ctx, workspaceID, id)
+	if err != nil {
+		return nil, fmt.Errorf("finding font %s: %w", id, err)
+	}
+	return font, nil
+}
+
+// DeleteFont deletes a font of a workspace. Templates using its family render with the fallback
+// fonts from then on.
+func (s *WorkspaceFontService) DeleteFont(ctx context.Context, workspaceID, id string) error {
+	if err := s.fontRepo.Delete(ctx, workspaceID, id); err != nil {
+		return fmt.Errorf("deleting font %s: %w", id, err)
+	}
+
+	slog.InfoContext(ctx, "workspace font deleted",
+		slog.String("font_id", id),
+		slog.String("workspace_id", workspaceID),
+	)
+
+	return nil
+}
+
+// FontDir returns the directory holding the current fonts of a workspace. Each set of fonts gets
+// its own directory, named by the hashes of the files, so a deleted font is never found by later
+// renders; the directory of the previous set is removed once renders using it are done.
+func (s *WorkspaceFontService) FontDir(ctx context.Context, workspaceID string) (string, error) {
+	fonts, err := s.fontRepo.FindByWorkspace(ctx, workspaceID)
+	if err != nil {
+		return "", fmt.Errorf("listing workspace fonts: %w", err)
+	}
+	if len(fonts) == 0 {
+		s.mu.Lock()
+		if _, ok := s.dirs[workspaceID]; ok {
+			delete(s.dirs, workspaceID)
+			time.AfterFunc(staleFontDirRetention, func() { s.removeStaleDirs(workspaceID) })
+		}
+		s.mu.Unlock()
+		return "", nil
+	}
+
+	hashes := make([]string, len(fonts))
+	for i, f := range fonts {
+		hashes[i] = f.SHA256
+	}
+	slices.Sort(hashes)
+	sum := sha256.Sum256([]byte(strings.Join(hashes, ",")))
+	key := hex.EncodeToString(sum[:8])
+	workspaceDir := filepath.Join(s.cacheDir, workspaceID)
+	dir := filepath.Join(workspaceDir, key)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.dirs[workspaceID] == key {
+		return dir, nil
+	}
+
+	if _, err := os.Stat(dir); err != nil {
+		if err := s.writeFonts(ctx, workspaceDir, dir, fonts); err != nil {
+			return "", err
+		}
+	}
+	s.dirs[workspaceID] = key
+	time.AfterFunc(staleFontDirRetention, func() { s.removeStaleDirs(workspaceID) })
+	return dir, nil
+}
+
+// writeFonts writes the files of fonts to dir, through a temporary directory so renders never
+// see a partial set.
+func (s *WorkspaceFontService) writeFonts(ctx context.Context, workspaceDir, dir string, fonts []*entity.WorkspaceFont) error {
+	if err := os.MkdirAll(workspaceDir, 0o750); err != nil {
+		return fmt.Errorf("creating font directory: %w", err)
+	}
+	tmp, err := os.MkdirTemp(workspaceDir, ".tmp-")
+	if err != nil {
+		return fmt.Errorf("creating font directory: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	for _, f := range fonts {
+		content, err := s.fontRepo.FindContent(ctx, f.WorkspaceID, f.ID)
+		if err != nil {
+			return fmt.Errorf("loading font %s: %w", f.ID, err)
+		}
+		name := f.SHA256 + "." + f.Format.Extension()
+		if err := os.WriteFile(filepath.Join(tmp, name), content.Data, 0o640); err != nil {
+			return fmt.Errorf("writing font %s: %w", f.ID, err)
+		}
+	}
+	if err := os.Rename(tmp, dir); err != nil && !os.IsExist(err) {
+		return fmt.Errorf("writing font directory: %w", err)
+	}
+	return nil
+}
+
+// removeStaleDirs removes the font directories of a workspace other than the current one.
+func (s *WorkspaceFontService) removeStaleDirs(workspaceID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	workspaceDir := filepath.Join(s.cacheDir, workspaceID)
+	entries, err := os.ReadDir(workspaceDir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if e.Name() == s.dirs[workspaceID] || strings.HasPrefix(e.Name(), ".tmp-") {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(workspaceDir, e.Name())); err != nil {
+			slog.Warn("failed to remove stale font directory",
+				slog.String("workspace_id", workspaceID),
+				slog.String("dir", e.Name()),
+				slog.Any("error", err),
+			)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package catalog
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	cataloguc "github.com/rendis/pdf-forge/core/internal/core/usecase/catalog"
+)
+
+// testFont builds a minimal sfnt file with a Windows name table and an OS/2 table.
+func testFont(magic, family, subfamily string, weight uint16, italic bool) []byte {
+	names := []struct {
+		id   uint16
+		text string
+	}{{nameIDFamily, family}, {nameIDSubfamily, subfamily}}
+	var storage []byte
+	name := binary.BigEndian.AppendUint16(nil, 0)
+	name = binary.BigEndian.AppendUint16(name, uint16(len(names)))
+	name = binary.BigEndian.AppendUint16(name, uint16(6+12*len(names)))
+	for _, n := range names {
+		var encoded []byte
+		for _, u := range utf16.Encode([]rune(n.text)) {
+			encoded = binary.BigEndian.AppendUint16(encoded, u)
+		}
+		for _, v := range []uint16{3, 1, 0x0409, n.id, uint16(len(encoded)), uint16(len(storage))} {
+			name = binary.BigEndian.AppendUint16(name, v)
+		}
+		storage = append(storage, encoded...)
+	}
+	name = append(name, storage...)
+
+	os2 := make([]byte, 96)
+	binary.BigEndian.PutUint16(os2[4:], weight)
+	if italic {
+		binary.BigEndian.PutUint16(os2[62:], 1)
+	}
+
+	data := []byte(magic)
+	data = binary.BigEndian.AppendUint16(data, 2)
+	data = append(data, make([]byte, 6)...)
+	offset := uint32(12 + 16*2)
+	for _, table := range []struct {
+		tag  string
+		data []byte
+	}{{"OS/2", os2}, {"name", name}} {
+		data = append(data, table.tag...)
+		data = binary.BigEndian.AppendUint32(data, 0)
+		data = binary.BigEndian.AppendUint32(data, offset)
+		data = binary.BigEndian.AppendUint32(data, uint32(len(table.data)))
+		offset += uint32(len(table.data))
+	}
+	return append(append(data, os2...), name...)
+}
+
+// fontRepoStub keeps workspace fonts in memory.
+type fontRepoStub struct {
+	fonts []*entity.WorkspaceFont
+	reads int
+}
+
+func (r *fontRepoStub) Create(_ context.Context, font *entity.WorkspaceFont) (string, error) {
+	stored := *font
+	stored.ID = "font-" + string(rune('a'+len(r.fonts)))
+	r.fonts = append(r.fonts, &stored)
+	return stored.ID, nil
+}
+
+func (r *fontRepoStub) FindByID(_ context.Context, workspaceID, id string) (*entity.WorkspaceFont, error) {
+	for _, f := range r.fonts {
+		if f.ID == id && f.WorkspaceID == workspaceID {
+			return f, nil
+		}
+	}
+	return nil, entity.ErrWorkspaceFontNotFound
+}
+
+func (r *fontRepoStub) FindByWorkspace(_ context.Context, workspaceID string) ([]*entity.WorkspaceFont, error) {
+	var result []*entity.WorkspaceFont
+	for _, f := range r.fonts {
+		if f.WorkspaceID == workspaceID {
+			result = append(result, f)
+		}
+	}
+	return result, nil
+}
+
+func (r *fontRepoStub) FindContent(ctx context.Context, workspaceID, id string) (*entity.WorkspaceFont, error) {
+	r.reads++
+	return r.FindByID(ctx, workspaceID, id)
+}
+
+func (r *fontRepoStub) Delete(_ context.Context, workspaceID, id string) error {
+	for i, f := range r.fonts {
+		if f.ID == id && f.WorkspaceID == workspaceID {
+			r.fonts = slices.Delete(r.fonts, i, i+1)
+			return nil
+		}
+	}
+	return entity.ErrWorkspaceFontNotFound
+}
+
+type fontListerStub []string
+
+func (l fontListerStub) FontFamilies(context.Context) []string { return l }
+
+func TestParseFontFile(t *testing.T) {
+	font, err := parseFontFile(testFont("OTTO", "Acme Sans", "Bold Italic", 700, true))
+	require.NoError(t, err)
+	assert.Equal(t, &fontFile{format: entity.FontFormatOTF, family: "Acme Sans", weight: 700, style: entity.FontStyleItalic}, font)
+
+	font, err = parseFontFile(testFont("\x00\x01\x00\x00", "Acme Serif", "Regular", 350, false))
+	require.NoError(t, err)
+	assert.Equal(t, entity.FontFormatTTF, font.format)
+	assert.Equal(t, 400, font.weight, "weights round to the nearest hundred")
+	assert.Equal(t, entity.FontStyleNormal, font.style)
+
+	for name, data := range map[string][]byte{
+		"WOFF":       []byte("wOFF\x00\x01\x00\x00"),
+		"collection": []byte("ttcf\x00\x01\x00\x00"),
+		"truncated":  testFont("OTTO", "Acme", "Regular", 400, false)[:40],
+		"no family":  testFont("OTTO", "", "Regular", 400, false),
+	} {
+		_, err := parseFontFile(data)
+		assert.ErrorIs(t, err, entity.ErrUnsupportedFontFile, name)
+	}
+}
+
+func TestWorkspaceFontService_UploadFont(t *testing.T) {
+	repo := &fontRepoStub{}
+	svc := NewWorkspaceFontService(repo, t.TempDir(), nil)
+	ctx := context.Background()
+	regular := testFont("OTTO", "Acme Sans", "Regular", 400, false)
+
+	result, err := svc.UploadFont(ctx, cataloguc.UploadFontCommand{WorkspaceID: "ws-1", Data: regular, CreatedBy: "u1"})
+	require.NoError(t, err)
+	assert.False(t, result.Duplicate)
+	assert.Equal(t, "Acme Sans", result.Font.Family)
+	assert.Equal(t, "u1", *result.Font.CreatedBy)
+
+	result, err = svc.UploadFont(ctx, cataloguc.UploadFontCommand{WorkspaceID: "ws-1", Data: regular})
+	require.NoError(t, err)
+	assert.True(t, result.Duplicate)
+	assert.Len(t, repo.fonts, 1)
+
+	_, err = svc.UploadFont(ctx, cataloguc.UploadFontCommand{WorkspaceID: "ws-1", Data: testFont("\x00\x01\x00\x00", "acme sans", "Book", 400, false)})
+	assert.ErrorIs(t, err, entity.ErrFontFaceExists)
+
+	_, err = svc.UploadFont(ctx, cataloguc.UploadFontCommand{WorkspaceID: "ws-1", Data: testPNG})
+	assert.ErrorIs(t, err, entity.ErrUnsupportedFontFile)
+	_, err = svc.UploadFont(ctx, cataloguc.UploadFontCommand{WorkspaceID: "ws-1", Data: make([]byte, entity.FontMaxSize+1)})
+	assert.ErrorIs(t, err, entity.ErrFontTooLarge)
+}
+
+func TestWorkspaceFontService_ListFontFamilies(t *testing.T) {
+	repo := &fontRepoStub{}
+	svc := NewWorkspaceFontService(repo, t.TempDir(), fontListerStub{"Libertinus Serif", "Acme Sans", "DejaVu Sans"})
+	ctx := context.Background()
+	for _, data := range [][]byte{
+		testFont("OTTO", "Acme Sans", "Bold", 700, false),
+		testFont("OTTO", "Acme Sans", "Italic", 400, true),
+		testFont("OTTO", "Acme Sans", "Regular", 400, false),
+		testFont("OTTO", "Brand Display", "Regular", 400, false),
+	} {
+		_, err := svc.UploadFont(ctx, cataloguc.UploadFontCommand{WorkspaceID: "ws-1", Data: data})
+		require.NoError(t, err)
+	}
+
+	families, err := svc.ListFontFamilies(ctx, "ws-1")
+	require.NoError(t, err)
+	require.Len(t, families, 4)
+	assert.Equal(t, &entity.FontFamily{Name: "Acme Sans", Source: entity.FontSourceWorkspace, Faces: []entity.FontFace{
+		{Weight: 400, Style: entity.FontStyleItalic},
+		{Weight: 400, Style: entity.FontStyleNormal},
+		{Weight: 700, Style: entity.FontStyleNormal},
+	}}, families[0], "the workspace family replaces the installed one")
+	assert.Equal(t, "Brand Display", families[1].Name)
+	assert.Equal(t, &entity.FontFamily{Name: "DejaVu Sans", Source: entity.FontSourceSystem}, families[2])
+
+	families, err = svc.ListFontFamilies(ctx, "ws-2")
+	require.NoError(t, err)
+	assert.Len(t, families, 3, "other workspaces only see the installed fonts")
+}
+
+func TestWorkspaceFontService_FontDir(t *testing.T) {
+	repo := &fontRepoStub{}
+	svc := NewWorkspaceFontService(repo, t.TempDir(), nil)
+	ctx := context.Background()
+
+	dir, err := svc.FontDir(ctx, "ws-1")
+	require.NoError(t, err)
+	assert.Empty(t, dir, "a workspace without fonts adds no directory")
+
+	uploaded, err := svc.UploadFont(ctx, cataloguc.UploadFontCommand{WorkspaceID: "ws-1", Data: testFont("OTTO", "Acme Sans", "Regular", 400, false)})
+	require.NoError(t, err)
+	dir, err = svc.FontDir(ctx, "ws-1")
+	require.NoError(t, err)
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, uploaded.Font.SHA256+".otf", files[0].Name())
+
+	again, err := svc.FontDir(ctx, "ws-1")
+	require.NoError(t, err)
+	assert.Equal(t, dir, again)
+	assert.Equal(t, 1, repo.reads, "an unchanged set is not written again")
+
+	_, err = svc.UploadFont(ctx, cataloguc.UploadFontCommand{WorkspaceID: "ws-1", Data: testFont("\x00\x01\x00\x00", "Acme Sans", "Bold", 700, false)})
+	require.NoError(t, err)
+	next, err := svc.FontDir(ctx, "ws-1")
+	require.NoError(t, err)
+	assert.NotEqual(t, dir, next, "a new set of fonts gets a new directory")
+	files, err = os.ReadDir(next)
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	svc.(*WorkspaceFontService).removeStaleDirs("ws-1")
+	_, err = os.Stat(dir)
+	assert.True(t, os.IsNotExist(err), "the previous set is removed")
+	entries, err := os.ReadDir(filepath.Dir(next))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
@@ -0,0 +1,47 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// NewBus creates an in-process event bus.
+// Subscriptions are registered before the engine starts and never change afterwards.
+func NewBus(subscriptions map[entity.DomainEventType][]port.EventHandler) *Bus {
+	return &Bus{handlers: subscriptions}
+}
+
+// Bus dispatches domain events to in-process subscribers.
+type Bus struct {
+	handlers map[entity.DomainEventType][]port.EventHandler
+}
+
+// Dispatch runs every handler subscribed to the event's type.
+// A panicking handler is reported as an error and does not stop the others.
+func (b *Bus) Dispatch(ctx context.Context, event entity.DomainEvent) error {
+	var errs []error
+	for i, handler := range b.handlers[event.EventType()] {
+		if err := safeCall(ctx, handler, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s handler %d: %w", event.EventType(), i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// HasSubscribers reports whether any handler listens to eventType.
+func (b *Bus) HasSubscribers(eventType entity.DomainEventType) bool {
+	return len(b.handlers[eventType]) > 0
+}
+
+func safeCall(ctx context.Context, handler port.EventHandler, event entity.DomainEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return handler(ctx, event)
+}
@@ -0,0 +1,89 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+func TestBus_DispatchRunsEveryHandler(t *testing.T) {
+	var calls []string
+	bus := NewBus(map[entity.DomainEventType][]port.EventHandler{
+		entity.EventMemberInvited: {
+			func(context.Context, entity.DomainEvent) error {
+				calls = append(calls, "first")
+				return errors.New("boom")
+			},
+			func(context.Context, entity.DomainEvent) error {
+				calls = append(calls, "second")
+				panic("handler bug")
+			},
+			func(_ context.Context, event entity.DomainEvent) error {
+				calls = append(calls, event.(entity.MemberInvited).Email)
+				return nil
+			},
+		},
+	})
+
+	err := bus.Dispatch(context.Background(), entity.MemberInvited{Email: "a@b.c"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boom")
+	assert.Contains(t, err.Error(), "panic: handler bug")
+	assert.Equal(t, []string{"first", "second", "a@b.c"}, calls)
+}
+
+func TestOutboxPublisher_DecodesTypedEvent(t *testing.T) {
+	var received entity.VersionPublished
+	bus := NewBus(map[entity.DomainEventType][]port.EventHandler{
+		entity.EventVersionPublished: {
+			func(_ context.Context, event entity.DomainEvent) error {
+				received = event.(entity.VersionPublished)
+				return nil
+			},
+		},
+	})
+	outboxEvent, err := entity.NewOutboxEvent(entity.OutboxEventVersionPublished, "v-1", nil,
+		entity.VersionPublished{VersionID: "v-1", TemplateID: "t-1", VersionNumber: 3})
+	require.NoError(t, err)
+
+	require.NoError(t, NewOutboxPublisher(bus).Publish(context.Background(), outboxEvent))
+	assert.Equal(t, "t-1", received.TemplateID)
+	assert.Equal(t, 3, received.VersionNumber)
+}
+
+func TestOutboxPublisher_DecodesRenderEvents(t *testing.T) {
+	var received []entity.DomainEvent
+	handler := func(_ context.Context, event entity.DomainEvent) error {
+		received = append(received, event)
+		return nil
+	}
+	bus := NewBus(map[entity.DomainEventType][]port.EventHandler{
+		entity.EventRenderCompleted: {handler},
+		entity.EventRenderFailed:    {handler},
+	})
+	completed, err := entity.NewOutboxEvent(entity.OutboxEventRenderCompleted, "v-1", nil,
+		entity.RenderCompleted{VersionID: "v-1", PageCount: 2, Duration: time.Second})
+	require.NoError(t, err)
+	failed, err := entity.NewOutboxEvent(entity.OutboxEventRenderFailed, "v-1", nil,
+		entity.RenderFailed{VersionID: "v-1", Error: "typst compile failed"})
+	require.NoError(t, err)
+
+	require.NoError(t, NewOutboxPublisher(bus).Publish(context.Background(), completed))
+	require.NoError(t, NewOutboxPublisher(bus).Publish(context.Background(), failed))
+	require.Len(t, received, 2)
+	assert.Equal(t, 2, received[0].(entity.RenderCompleted).PageCount)
+	assert.Equal(t, time.Second, received[0].(entity.RenderCompleted).Duration)
+	assert.Equal(t, "typst compile failed", received[1].(entity.RenderFailed).Error)
+}
+
+func TestOutboxPublisher_IgnoresNotificationEvents(t *testing.T) {
+	outboxEvent := &entity.OutboxEvent{Type: entity.OutboxEventNotificationCreated, Payload: []byte(`{}`)}
+	assert.NoError(t, NewOutboxPublisher(NewBus(nil)).Publish(context.Background(), outboxEvent))
+}
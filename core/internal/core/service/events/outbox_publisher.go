@@ -0,0 +1,68 @@
+package events
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// NewOutboxPublisher creates an outbox publisher that decodes domain events
+// and dispatches them to the bus subscribers.
+func NewOutboxPublisher(bus *Bus) port.EventPublisher {
+	return &OutboxPublisher{bus: bus}
+}
+
+// OutboxPublisher delivers committed outbox events to in-process subscribers.
+// A failing handler makes the relay retry the event for every handler of its type.
+type OutboxPublisher struct {
+	bus *Bus
+}
+
+// Name returns the publisher identifier.
+func (p *OutboxPublisher) Name() string {
+	return "subscribers"
+}
+
+// Publish decodes the outbox event into its typed domain event and dispatches it.
+// Outbox events without a domain event counterpart are ignored.
+func (p *OutboxPublisher) Publish(ctx context.Context, event *entity.OutboxEvent) error {
+	if !p.bus.HasSubscribers(entity.DomainEventType(event.Type)) {
+		return nil
+	}
+
+	var domainEvent entity.DomainEvent
+	var err error
+	switch event.Type {
+	case entity.OutboxEventVersionPublished:
+		domainEvent, err = decode[entity.VersionPublished](event)
+	case entity.OutboxEventVersionArchived:
+		domainEvent, err = decode[entity.VersionArchived](event)
+	case entity.OutboxEventInjectableDeactivated:
+		domainEvent, err = decode[entity.InjectableDeactivated](event)
+	case entity.OutboxEventMemberInvited:
+		domainEvent, err = decode[entity.MemberInvited](event)
+	case entity.OutboxEventTemplateSLABreached:
+		domainEvent, err = decode[entity.TemplateSLABreached](event)
+	case entity.OutboxEventTemplateSLARecovered:
+		domainEvent, err = decode[entity.TemplateSLARecovered](event)
+	case entity.OutboxEventRenderCompleted:
+		domainEvent, err = decode[entity.RenderCompleted](event)
+	case entity.OutboxEventRenderFailed:
+		domainEvent, err = decode[entity.RenderFailed](event)
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return p.bus.Dispatch(ctx, domainEvent)
+}
+
+func decode[T entity.DomainEvent](event *entity.OutboxEvent) (entity.DomainEvent, error) {
+	var v T
+	if err := event.DecodePayload(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
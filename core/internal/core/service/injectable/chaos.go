@@ -0,0 +1,113 @@
+package injectable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// ChaosOptions configures fault injection for injectors and the workspace provider, to check
+// that IsCritical flags, timeouts and default values behave as intended when data sources are
+// slow or failing. It is a testing aid and cannot be enabled in production.
+type ChaosOptions struct {
+	// Injectors limits faults to these injector codes. Empty targets every registered injector.
+	Injectors []string
+
+	// Provider also injects faults into the WorkspaceInjectableProvider batch call.
+	Provider bool
+
+	// FailureRate is the probability (0-1) that a call fails with entity.ErrChaosFault.
+	FailureRate float64
+
+	// DelayRate is the probability (0-1) that a call is delayed before it runs.
+	// Delays count against the injector timeout, so a long enough delay makes the call time out.
+	DelayRate float64
+
+	// MinDelay and MaxDelay bound the delay, picked uniformly between them.
+	MinDelay time.Duration
+	MaxDelay time.Duration
+
+	// Seed makes the fault sequence reproducible. Zero uses a random seed.
+	Seed uint64
+}
+
+// Validate checks that rates are probabilities and the delay range is well formed.
+func (o ChaosOptions) Validate() error {
+	if o.FailureRate < 0 || o.FailureRate > 1 || o.DelayRate < 0 || o.DelayRate > 1 {
+		return errors.New("chaos: failure and delay rates must be between 0 and 1")
+	}
+	if o.MinDelay < 0 || o.MaxDelay < o.MinDelay {
+		return errors.New("chaos: delays must satisfy 0 <= MinDelay <= MaxDelay")
+	}
+	return nil
+}
+
+// chaos decides which calls to delay or fail. A nil *chaos injects nothing.
+type chaos struct {
+	opts ChaosOptions
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+func newChaos(opts ChaosOptions) *chaos {
+	seed := opts.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	return &chaos{opts: opts, rng: rand.New(rand.NewPCG(seed, seed))}
+}
+
+// injectorFault delays or fails the call to an injector if it is targeted.
+func (c *chaos) injectorFault(ctx context.Context, code string) error {
+	if c == nil || (len(c.opts.Injectors) > 0 && !slices.Contains(c.opts.Injectors, code)) {
+		return nil
+	}
+	return c.fault(ctx, "injector "+code)
+}
+
+// providerFault delays or fails the workspace provider call if it is targeted.
+func (c *chaos) providerFault(ctx context.Context) error {
+	if c == nil || !c.opts.Provider {
+		return nil
+	}
+	return c.fault(ctx, "workspace provider")
+}
+
+// fault rolls the configured probabilities for one call. Delays end early when ctx is done,
+// returning its error as a slow data source would.
+func (c *chaos) fault(ctx context.Context, target string) error {
+	c.mu.Lock()
+	var delay time.Duration
+	if c.rng.Float64() < c.opts.DelayRate {
+		delay = c.opts.MinDelay
+		if spread := c.opts.MaxDelay - c.opts.MinDelay; spread > 0 {
+			delay += time.Duration(c.rng.Int64N(int64(spread) + 1))
+		}
+	}
+	fail := c.rng.Float64() < c.opts.FailureRate
+	c.mu.Unlock()
+
+	if delay > 0 {
+		slog.WarnContext(ctx, "chaos: delaying call", slog.String("target", target), slog.Duration("delay", delay))
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if fail {
+		slog.WarnContext(ctx, "chaos: failing call", slog.String("target", target))
+		return fmt.Errorf("%s: %w", target, entity.ErrChaosFault)
+	}
+	return nil
+}
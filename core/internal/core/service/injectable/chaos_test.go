@@ -0,0 +1,104 @@
+package injectable
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+type chaosInjectorStub struct {
+	code     string
+	critical bool
+	timeout  time.Duration
+}
+
+func (i *chaosInjectorStub) Code() string { return i.code }
+
+func (i *chaosInjectorStub) Resolve() (port.ResolveFunc, []string) {
+	return func(context.Context, *entity.InjectorContext) (*entity.InjectorResult, error) {
+		return &entity.InjectorResult{Value: entity.StringValue(i.code)}, nil
+	}, nil
+}
+
+func (i *chaosInjectorStub) IsCritical() bool                      { return i.critical }
+func (i *chaosInjectorStub) Timeout() time.Duration                { return i.timeout }
+func (i *chaosInjectorStub) DataType() entity.ValueType            { return entity.ValueTypeString }
+func (i *chaosInjectorStub) DefaultValue() *entity.InjectableValue { return nil }
+func (i *chaosInjectorStub) Formats() *entity.FormatConfig         { return nil }
+
+// chaosRegistryStub implements the registry methods the resolver uses.
+type chaosRegistryStub struct {
+	port.InjectorRegistry
+	injectors map[string]port.Injector
+}
+
+func (r chaosRegistryStub) Get(code string) (port.Injector, bool) {
+	inj, ok := r.injectors[code]
+	return inj, ok
+}
+
+func (r chaosRegistryStub) GetInitFunc() port.InitFunc { return nil }
+
+func newChaosResolver(t *testing.T, opts ChaosOptions, injectors ...*chaosInjectorStub) *InjectableResolverService {
+	t.Helper()
+	registry := chaosRegistryStub{injectors: make(map[string]port.Injector)}
+	for _, inj := range injectors {
+		registry.injectors[inj.code] = inj
+	}
+	s := NewInjectableResolverService(registry, nil)
+	require.NoError(t, s.EnableChaos(opts))
+	return s
+}
+
+func TestChaos_FailuresFollowIsCritical(t *testing.T) {
+	s := newChaosResolver(t, ChaosOptions{Injectors: []string{"crm_name"}, FailureRate: 1},
+		&chaosInjectorStub{code: "crm_name"},
+		&chaosInjectorStub{code: "date_now"},
+	)
+
+	result, err := s.Resolve(context.Background(), newChaosContext(), []string{"crm_name", "date_now"})
+	require.NoError(t, err)
+	assert.ErrorIs(t, result.Errors["crm_name"], entity.ErrChaosFault)
+	assert.NotContains(t, result.Values, "crm_name")
+	assert.Contains(t, result.Values, "date_now", "untargeted injectors resolve normally")
+
+	s = newChaosResolver(t, ChaosOptions{FailureRate: 1}, &chaosInjectorStub{code: "crm_name", critical: true})
+	_, err = s.Resolve(context.Background(), newChaosContext(), []string{"crm_name"})
+	assert.ErrorIs(t, err, entity.ErrChaosFault)
+}
+
+func TestChaos_DelaysCountAgainstTimeout(t *testing.T) {
+	s := newChaosResolver(t, ChaosOptions{DelayRate: 1, MinDelay: time.Second, MaxDelay: time.Second},
+		&chaosInjectorStub{code: "crm_name", timeout: 10 * time.Millisecond},
+	)
+
+	started := time.Now()
+	result, err := s.Resolve(context.Background(), newChaosContext(), []string{"crm_name"})
+	require.NoError(t, err)
+	assert.ErrorIs(t, result.Errors["crm_name"], context.DeadlineExceeded)
+	assert.Less(t, time.Since(started), 500*time.Millisecond)
+}
+
+func TestChaosOptions_Validate(t *testing.T) {
+	tests := map[string]ChaosOptions{
+		"failure rate above 1": {FailureRate: 1.5},
+		"negative delay rate":  {DelayRate: -0.1},
+		"inverted delays":      {MinDelay: time.Second, MaxDelay: time.Millisecond},
+	}
+	for name, opts := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, opts.Validate())
+		})
+	}
+	assert.NoError(t, ChaosOptions{FailureRate: 0.2, DelayRate: 0.5, MaxDelay: time.Second}.Validate())
+}
+
+func newChaosContext() *entity.InjectorContext {
+	return entity.NewInjectorContext("ext-1", "tpl-1", "tx-1", "render", entity.EnvironmentDev, nil, nil)
+}
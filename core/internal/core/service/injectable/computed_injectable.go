@@ -0,0 +1,203 @@
+package injectable
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// ComputedInjectables are the computed injectables of a render, with their formulas compiled.
+// Build them with InjectableResolverService.PrepareComputed.
+type ComputedInjectables struct {
+	defs     map[string]*entity.InjectableDefinition // by key
+	formulas map[string]*Formula                     // by key; missing when the formula does not compile
+	failed   map[string]error                        // compile errors, by key
+}
+
+// Len returns the number of computed injectables.
+func (c *ComputedInjectables) Len() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.defs)
+}
+
+// Dependencies returns the codes the formulas reference that are not computed themselves, sorted.
+// They are resolved like any other injectable before the formulas run.
+func (c *ComputedInjectables) Dependencies() []string {
+	if c == nil {
+		return nil
+	}
+	var deps []string
+	for _, formula := range c.formulas {
+		for _, dep := range formula.Dependencies() {
+			if _, computed := c.defs[dep]; !computed && !slices.Contains(deps, dep) {
+				deps = append(deps, dep)
+			}
+		}
+	}
+	slices.Sort(deps)
+	return deps
+}
+
+// graph returns the dependencies between the computed injectables.
+func (c *ComputedInjectables) graph() *DependencyGraph {
+	graph := NewDependencyGraph()
+	for key, formula := range c.formulas {
+		graph.AddNode(key)
+		for _, dep := range formula.Dependencies() {
+			if _, computed := c.defs[dep]; computed {
+				graph.AddEdge(key, dep)
+			}
+		}
+	}
+	return graph
+}
+
+func (c *ComputedInjectables) add(def *entity.InjectableDefinition) {
+	c.defs[def.Key] = def
+	formula, err := CompileFormula(*def.Formula)
+	if err != nil {
+		c.failed[def.Key] = err
+		return
+	}
+	c.formulas[def.Key] = formula
+}
+
+// compileComputed compiles the computed injectables among defs. Returns nil when there are none.
+func compileComputed(defs []*entity.InjectableDefinition) *ComputedInjectables {
+	computed := &ComputedInjectables{
+		defs:     make(map[string]*entity.InjectableDefinition),
+		formulas: make(map[string]*Formula),
+		failed:   make(map[string]error),
+	}
+	for _, def := range defs {
+		if def != nil && def.IsComputed() {
+			computed.add(def)
+		}
+	}
+	if len(computed.defs) == 0 {
+		return nil
+	}
+	return computed
+}
+
+// SetWorkspaceInjectables lets PrepareComputed load the computed injectables that formulas
+// reference and a template does not use.
+func (s *InjectableResolverService) SetWorkspaceInjectables(repo port.WorkspaceInjectableRepository) {
+	s.workspaceInjectables = repo
+}
+
+// PrepareComputed compiles the computed injectables among defs and, when workspace injectables
+// are set, the active computed injectables of the same workspace their formulas reference.
+// Returns nil when defs has no computed injectable.
+func (s *InjectableResolverService) PrepareComputed(ctx context.Context, defs []*entity.InjectableDefinition) *ComputedInjectables {
+	computed := compileComputed(defs)
+	if computed == nil || s.workspaceInjectables == nil {
+		return computed
+	}
+	var workspaceID string
+	for _, def := range computed.defs {
+		if def.WorkspaceID != nil {
+			workspaceID = *def.WorkspaceID
+		}
+	}
+	if workspaceID == "" {
+		return computed
+	}
+
+	// Formulas may reference computed injectables the template does not use
+	var workspace map[string]*entity.InjectableDefinition
+	for pending := computed.Dependencies(); len(pending) > 0; pending = computed.Dependencies() {
+		if workspace == nil {
+			owned, err := s.workspaceInjectables.FindByWorkspaceOwned(ctx, workspaceID)
+			if err != nil {
+				slog.WarnContext(ctx, "loading computed injectables failed",
+					slog.String("workspace_id", workspaceID),
+					slog.Any("error", err),
+				)
+				return computed
+			}
+			workspace = make(map[string]*entity.InjectableDefinition, len(owned))
+			for _, def := range owned {
+				if def.IsActive && def.IsComputed() {
+					workspace[def.Key] = def
+				}
+			}
+		}
+
+		added := false
+		for _, dep := range pending {
+			if def, ok := workspace[dep]; ok {
+				computed.add(def)
+				added = true
+			}
+		}
+		if !added {
+			break
+		}
+	}
+	return computed
+}
+
+// ResolveComputed evaluates the computed injectables once their dependencies are in values,
+// each after the computed injectables it references. Dependencies missing from values take
+// their value from defaults. Computed injectables already in values, given by the caller, are
+// not evaluated. A formula that fails leaves its injectable without a value and its error in
+// Errors; it never stops the render.
+func (s *InjectableResolverService) ResolveComputed(
+	ctx context.Context,
+	computed *ComputedInjectables,
+	values map[string]any,
+	defaults map[string]string,
+) *ResolveResult {
+	result := &ResolveResult{
+		Values:   make(map[string]entity.InjectableValue),
+		Errors:   make(map[string]error),
+		Metadata: make(map[string]map[string]any),
+	}
+	if computed.Len() == 0 {
+		return result
+	}
+	maps.Copy(result.Errors, computed.failed)
+
+	env := make(map[string]any, len(defaults)+len(values))
+	for key, value := range defaults {
+		env[key] = value
+	}
+	maps.Copy(env, values)
+
+	levels, err := computed.graph().TopologicalSort()
+	if err != nil {
+		for key := range computed.formulas {
+			result.Errors[key] = fmt.Errorf("%w: %w", entity.ErrInvalidFormula, err)
+		}
+		return result
+	}
+
+	for _, level := range levels {
+		slices.Sort(level)
+		for _, key := range level {
+			if _, given := values[key]; given {
+				continue
+			}
+			value, err := computed.formulas[key].Eval(env, computed.defs[key].DataType)
+			if err != nil {
+				slog.WarnContext(ctx, "computed injectable failed", slog.String("key", key), slog.Any("error", err))
+				result.Errors[key] = err
+				continue
+			}
+			if value == nil {
+				continue
+			}
+			result.Values[key] = *value
+			env[key] = value.AsAny()
+		}
+	}
+	return result
+}
@@ -0,0 +1,132 @@
+package injectable
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+func computedDef(key, formula string, dataType entity.InjectableDataType) *entity.InjectableDefinition {
+	workspaceID := "ws-1"
+	return &entity.InjectableDefinition{
+		ID: key, WorkspaceID: &workspaceID, Key: key, DataType: dataType, Formula: &formula, IsActive: true,
+	}
+}
+
+func TestFormula_Eval(t *testing.T) {
+	values := map[string]any{
+		"price": 10.5, "quantity": "2", "discount": nil,
+		"first_name": "Ana", "last_name": "Ruiz",
+		"issued": time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC), "due": "2024-04-15",
+	}
+	tests := []struct {
+		formula  string
+		dataType entity.InjectableDataType
+		want     any
+	}{
+		{"sum(price, quantity, discount)", entity.InjectableDataTypeNumber, 12.5},
+		{"price * 2", entity.InjectableDataTypeNumber, 21.0},
+		{`concat(first_name, " ", last_name)`, entity.InjectableDataTypeText, "Ana Ruiz"},
+		{"dateDiff(issued, due)", entity.InjectableDataTypeNumber, 75.0},
+		{`dateDiff(issued, due, "months")`, entity.InjectableDataTypeNumber, 2.0},
+		{`price > 10 ? "high" : "low"`, entity.InjectableDataTypeText, "high"},
+		{"discount ?? 0", entity.InjectableDataTypeNumber, 0.0},
+		{"price > 100", entity.InjectableDataTypeBoolean, false},
+		{`len($env["first_name"])`, entity.InjectableDataTypeNumber, 3.0},
+	}
+	for _, tt := range tests {
+		formula, err := CompileFormula(tt.formula)
+		require.NoError(t, err, tt.formula)
+		value, err := formula.Eval(values, tt.dataType)
+		require.NoError(t, err, tt.formula)
+		assert.Equal(t, tt.want, value.AsAny(), tt.formula)
+	}
+}
+
+func TestCompileFormula(t *testing.T) {
+	formula, err := CompileFormula(`concat(first_name, " ", $env["last"], sum(fee, fee))`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fee", "first_name", "last"}, formula.Dependencies(), "function names are not dependencies")
+
+	_, err = CompileFormula("price *")
+	assert.ErrorIs(t, err, entity.ErrInvalidFormula)
+	_, err = CompileFormula("round(price) + unknown(price)")
+	assert.ErrorIs(t, err, entity.ErrInvalidFormula, "only known functions can be called")
+}
+
+func TestResolveComputed(t *testing.T) {
+	s := NewInjectableResolverService(chaosRegistryStub{}, nil)
+	computed := s.PrepareComputed(context.Background(), []*entity.InjectableDefinition{
+		computedDef("total", "subtotal * (1 + tax_rate)", entity.InjectableDataTypeNumber),
+		computedDef("subtotal", "sum(price, shipping)", entity.InjectableDataTypeNumber),
+		computedDef("label", `concat("Total: ", total)`, entity.InjectableDataTypeText),
+		computedDef("broken", "price *", entity.InjectableDataTypeNumber),
+		computedDef("failing", `dateDiff(price, "2024-01-01")`, entity.InjectableDataTypeNumber),
+		computedDef("given", "price", entity.InjectableDataTypeNumber),
+		{Key: "customer_name", DataType: entity.InjectableDataTypeText},
+	})
+	require.Equal(t, 6, computed.Len())
+	assert.Equal(t, []string{"price", "shipping", "tax_rate"}, computed.Dependencies())
+
+	values := map[string]any{"price": 100.0, "tax_rate": 0.5, "given": "from the caller"}
+	result := s.ResolveComputed(context.Background(), computed, values, map[string]string{"shipping": "20"})
+
+	assert.Equal(t, 120.0, result.Values["subtotal"].AsAny(), "dependencies fall back to defaults")
+	assert.Equal(t, 180.0, result.Values["total"].AsAny())
+	assert.Equal(t, "Total: 180", result.Values["label"].AsAny())
+	assert.ErrorIs(t, result.Errors["broken"], entity.ErrInvalidFormula)
+	assert.ErrorIs(t, result.Errors["failing"], entity.ErrInvalidFormula)
+	assert.NotContains(t, result.Values, "given", "caller values are not recomputed")
+}
+
+func TestResolveComputed_Cycle(t *testing.T) {
+	s := NewInjectableResolverService(chaosRegistryStub{}, nil)
+	computed := s.PrepareComputed(context.Background(), []*entity.InjectableDefinition{
+		computedDef("a", "b + 1", entity.InjectableDataTypeNumber),
+		computedDef("b", "a + 1", entity.InjectableDataTypeNumber),
+	})
+
+	result := s.ResolveComputed(context.Background(), computed, map[string]any{}, nil)
+	assert.Empty(t, result.Values)
+	assert.ErrorIs(t, result.Errors["a"], entity.ErrInjectorDependencyCycle)
+}
+
+// workspaceInjectableRepoStub lists the injectables of one workspace.
+type workspaceInjectableRepoStub struct {
+	port.WorkspaceInjectableRepository
+	owned []*entity.InjectableDefinition
+	calls int
+}
+
+func (r *workspaceInjectableRepoStub) FindByWorkspaceOwned(context.Context, string) ([]*entity.InjectableDefinition, error) {
+	r.calls++
+	return r.owned, nil
+}
+
+func TestPrepareComputed_LoadsReferencedComputedInjectables(t *testing.T) {
+	inactive := computedDef("inactive_fee", "1", entity.InjectableDataTypeNumber)
+	inactive.IsActive = false
+	repo := &workspaceInjectableRepoStub{owned: []*entity.InjectableDefinition{
+		computedDef("net", "gross - fee", entity.InjectableDataTypeNumber),
+		computedDef("fee", "gross * rate", entity.InjectableDataTypeNumber),
+		inactive,
+	}}
+	s := NewInjectableResolverService(chaosRegistryStub{}, nil)
+	s.SetWorkspaceInjectables(repo)
+
+	computed := s.PrepareComputed(context.Background(), []*entity.InjectableDefinition{
+		computedDef("summary", `concat(net, " ", inactive_fee)`, entity.InjectableDataTypeText),
+	})
+	assert.Equal(t, 3, computed.Len(), "net and, through it, fee are loaded")
+	assert.Equal(t, []string{"gross", "inactive_fee", "rate"}, computed.Dependencies())
+	assert.Equal(t, 1, repo.calls)
+
+	result := s.ResolveComputed(context.Background(), computed, map[string]any{"gross": 100.0, "rate": 0.1}, nil)
+	assert.Equal(t, "90 ", result.Values["summary"].AsAny())
+}
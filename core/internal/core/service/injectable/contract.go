@@ -0,0 +1,220 @@
+package injectable
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// ContractOperation is the InjectorContext operation during a contract check.
+// Injectors with side effects (writes, notifications, sequence numbers) should skip them for it.
+const ContractOperation = "contract"
+
+// ContractStatus is the outcome of one injector in a contract check.
+type ContractStatus string
+
+const (
+	// ContractOK means the injector resolved from the sample payload.
+	ContractOK ContractStatus = "ok"
+
+	// ContractFailed means the injector returned an error.
+	ContractFailed ContractStatus = "failed"
+
+	// ContractTimeout means the injector did not finish within its timeout.
+	ContractTimeout ContractStatus = "timeout"
+
+	// ContractSkipped means the injector did not run because a dependency did not resolve.
+	ContractSkipped ContractStatus = "skipped"
+)
+
+// InjectorContract is the contract check outcome of one injector.
+type InjectorContract struct {
+	Code       string         `json:"code"`
+	Critical   bool           `json:"critical"`
+	Status     ContractStatus `json:"status"`
+	Error      string         `json:"error,omitempty"`
+	DurationMs int64          `json:"durationMs"`
+}
+
+// ContractReport tells whether the registered injectors can resolve from the payload the mapper
+// produces for a sample request body.
+type ContractReport struct {
+	// MapperError is set when the mapper rejected the sample body; no injector runs then.
+	MapperError string `json:"mapperError,omitempty"`
+
+	// InitError is set when the global init function failed; no injector runs then.
+	InitError string `json:"initError,omitempty"`
+
+	// Injectors holds one entry per registered injector, sorted by code.
+	Injectors []InjectorContract `json:"injectors"`
+}
+
+// OK reports whether the mapper, the init function and every injector succeeded.
+// Non-critical failures count too: they would silently render default values.
+func (r *ContractReport) OK() bool {
+	if r.MapperError != "" || r.InitError != "" {
+		return false
+	}
+	for _, inj := range r.Injectors {
+		if inj.Status != ContractOK {
+			return false
+		}
+	}
+	return true
+}
+
+// String renders the report as plain text, one line per injector.
+func (r *ContractReport) String() string {
+	var b strings.Builder
+	if r.MapperError != "" {
+		fmt.Fprintf(&b, "mapper: %s\n", r.MapperError)
+	}
+	if r.InitError != "" {
+		fmt.Fprintf(&b, "init: %s\n", r.InitError)
+	}
+	for _, inj := range r.Injectors {
+		critical := ""
+		if inj.Critical {
+			critical = " (critical)"
+		}
+		fmt.Fprintf(&b, "%-8s %s%s", inj.Status, inj.Code, critical)
+		if inj.Error != "" {
+			fmt.Fprintf(&b, ": %s", inj.Error)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// CheckContract maps rawBody with mapper and runs every registered injector against the result,
+// recording each outcome instead of stopping at the first critical failure.
+// Without a mapper, injectors receive rawBody decoded as JSON. Provider-owned codes are not checked.
+func (s *InjectableResolverService) CheckContract(
+	ctx context.Context,
+	mapper port.RequestMapper, // can be nil
+	rawBody []byte,
+	headers map[string]string,
+) (*ContractReport, error) {
+	report := &ContractReport{}
+
+	payload, err := mapContractPayload(ctx, mapper, rawBody, headers)
+	if err != nil {
+		report.MapperError = err.Error()
+		return report, nil
+	}
+
+	injCtx := entity.NewInjectorContext("", "", "", ContractOperation, entity.EnvironmentDev, headers, payload)
+	if initFunc := s.registry.GetInitFunc(); initFunc != nil {
+		initData, err := initFunc(ctx, injCtx)
+		if err != nil {
+			report.InitError = err.Error()
+			return report, nil
+		}
+		injCtx.SetInitData(initData)
+	}
+
+	codes := s.registry.Codes()
+	graph := NewDependencyGraph()
+	err = graph.BuildFromInjectors(func(code string) ([]string, bool) {
+		inj, ok := s.registry.Get(code)
+		if !ok {
+			return nil, false
+		}
+		_, deps := inj.Resolve()
+		return deps, true
+	}, codes)
+	if err != nil {
+		return nil, fmt.Errorf("building dependency graph: %w", err)
+	}
+
+	outcomes := make(map[string]InjectorContract, len(codes))
+	var mu sync.Mutex
+	err = graph.Run(ctx, func(ctx context.Context, code string) error {
+		inj, ok := s.registry.Get(code)
+		if !ok {
+			return nil
+		}
+		outcome := s.checkInjector(ctx, injCtx, inj, outcomes, &mu)
+		mu.Lock()
+		outcomes[code] = outcome
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, code := range slices.Sorted(maps.Keys(outcomes)) {
+		report.Injectors = append(report.Injectors, outcomes[code])
+	}
+	return report, nil
+}
+
+// checkInjector runs one injector unless one of its dependencies did not resolve.
+func (s *InjectableResolverService) checkInjector(
+	ctx context.Context,
+	injCtx *entity.InjectorContext,
+	inj port.Injector,
+	outcomes map[string]InjectorContract,
+	mu *sync.Mutex,
+) InjectorContract {
+	outcome := InjectorContract{Code: inj.Code(), Critical: inj.IsCritical()}
+
+	_, deps := inj.Resolve()
+	mu.Lock()
+	for _, dep := range deps {
+		if prev, ok := outcomes[dep]; ok && prev.Status != ContractOK {
+			outcome.Status = ContractSkipped
+			outcome.Error = fmt.Sprintf("dependency %q did not resolve", dep)
+			break
+		}
+	}
+	mu.Unlock()
+	if outcome.Status == ContractSkipped {
+		return outcome
+	}
+
+	started := time.Now()
+	result, err := s.runInjector(ctx, injCtx, inj)
+	outcome.DurationMs = time.Since(started).Milliseconds()
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		outcome.Status = ContractTimeout
+		outcome.Error = err.Error()
+	case err != nil:
+		outcome.Status = ContractFailed
+		outcome.Error = err.Error()
+	default:
+		outcome.Status = ContractOK
+		if result != nil {
+			injCtx.SetResolved(outcome.Code, result.Value.AsAny())
+		}
+	}
+	return outcome
+}
+
+// mapContractPayload produces the payload injectors receive for the sample body.
+func mapContractPayload(ctx context.Context, mapper port.RequestMapper, rawBody []byte, headers map[string]string) (any, error) {
+	if mapper == nil {
+		var payload any
+		if err := json.Unmarshal(rawBody, &payload); err != nil {
+			return nil, fmt.Errorf("decoding sample body: %w", err)
+		}
+		return payload, nil
+	}
+	return mapper.Map(ctx, &port.MapperContext{
+		Operation:   ContractOperation,
+		Environment: entity.EnvironmentDev,
+		Headers:     headers,
+		RawBody:     rawBody,
+	})
+}
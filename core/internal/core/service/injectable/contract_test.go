@@ -0,0 +1,143 @@
+package injectable
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+type contractPayload struct {
+	CustomerName string
+}
+
+type contractMapper struct{}
+
+func (contractMapper) Map(_ context.Context, mapCtx *port.MapperContext) (any, error) {
+	if len(mapCtx.RawBody) == 0 {
+		return nil, errors.New("empty body")
+	}
+	return contractPayload{CustomerName: string(mapCtx.RawBody)}, nil
+}
+
+type contractInjectorStub struct {
+	chaosInjectorStub
+	deps    []string
+	resolve port.ResolveFunc
+}
+
+func (i *contractInjectorStub) Resolve() (port.ResolveFunc, []string) { return i.resolve, i.deps }
+
+type contractRegistryStub struct {
+	chaosRegistryStub
+	initFunc port.InitFunc
+}
+
+func (r contractRegistryStub) Codes() []string {
+	codes := make([]string, 0, len(r.injectors))
+	for code := range r.injectors {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+	return codes
+}
+
+func (r contractRegistryStub) GetInitFunc() port.InitFunc { return r.initFunc }
+
+func newContractResolver(initFunc port.InitFunc, injectors ...*contractInjectorStub) *InjectableResolverService {
+	registry := contractRegistryStub{
+		chaosRegistryStub: chaosRegistryStub{injectors: make(map[string]port.Injector)},
+		initFunc:          initFunc,
+	}
+	for _, inj := range injectors {
+		registry.injectors[inj.code] = inj
+	}
+	return NewInjectableResolverService(registry, nil)
+}
+
+func customerName(_ context.Context, injCtx *entity.InjectorContext) (*entity.InjectorResult, error) {
+	p, ok := injCtx.RequestPayload().(contractPayload)
+	if !ok {
+		return nil, errors.New("unexpected payload type")
+	}
+	return &entity.InjectorResult{Value: entity.StringValue(p.CustomerName)}, nil
+}
+
+func TestCheckContract_ReportsEveryInjector(t *testing.T) {
+	s := newContractResolver(nil,
+		&contractInjectorStub{chaosInjectorStub: chaosInjectorStub{code: "customer_name", critical: true}, resolve: customerName},
+		&contractInjectorStub{
+			chaosInjectorStub: chaosInjectorStub{code: "contract_total", critical: true},
+			resolve: func(context.Context, *entity.InjectorContext) (*entity.InjectorResult, error) {
+				return nil, errors.New("missing field totalAmount")
+			},
+		},
+		&contractInjectorStub{
+			chaosInjectorStub: chaosInjectorStub{code: "total_words"},
+			deps:              []string{"contract_total"},
+			resolve:           customerName,
+		},
+		&contractInjectorStub{
+			chaosInjectorStub: chaosInjectorStub{code: "crm_segment", timeout: 10 * time.Millisecond},
+			resolve: func(ctx context.Context, _ *entity.InjectorContext) (*entity.InjectorResult, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+		},
+	)
+
+	report, err := s.CheckContract(context.Background(), contractMapper{}, []byte("Ada"), nil)
+	require.NoError(t, err)
+	assert.False(t, report.OK())
+
+	statuses := make(map[string]ContractStatus)
+	for _, inj := range report.Injectors {
+		statuses[inj.Code] = inj.Status
+	}
+	assert.Equal(t, map[string]ContractStatus{
+		"contract_total": ContractFailed,
+		"crm_segment":    ContractTimeout,
+		"customer_name":  ContractOK,
+		"total_words":    ContractSkipped,
+	}, statuses)
+	assert.Equal(t, "contract_total", report.Injectors[0].Code, "injectors are sorted by code")
+}
+
+func TestCheckContract_StopsOnMapperAndInitErrors(t *testing.T) {
+	inj := &contractInjectorStub{chaosInjectorStub: chaosInjectorStub{code: "customer_name"}, resolve: customerName}
+
+	report, err := newContractResolver(nil, inj).CheckContract(context.Background(), contractMapper{}, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "empty body", report.MapperError)
+	assert.Empty(t, report.Injectors)
+
+	failingInit := func(context.Context, *entity.InjectorContext) (any, error) {
+		return nil, errors.New("crm unreachable")
+	}
+	report, err = newContractResolver(failingInit, inj).CheckContract(context.Background(), contractMapper{}, []byte("Ada"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "crm unreachable", report.InitError)
+	assert.False(t, report.OK())
+}
+
+func TestCheckContract_WithoutMapperDecodesJSON(t *testing.T) {
+	s := newContractResolver(nil, &contractInjectorStub{
+		chaosInjectorStub: chaosInjectorStub{code: "customer_name"},
+		resolve: func(_ context.Context, injCtx *entity.InjectorContext) (*entity.InjectorResult, error) {
+			assert.Equal(t, ContractOperation, injCtx.Operation())
+			p := injCtx.RequestPayload().(map[string]any)
+			return &entity.InjectorResult{Value: entity.StringValue(p["customerName"].(string))}, nil
+		},
+	})
+
+	report, err := s.CheckContract(context.Background(), nil, []byte(`{"customerName":"Ada"}`), nil)
+	require.NoError(t, err)
+	assert.True(t, report.OK(), report.String())
+}
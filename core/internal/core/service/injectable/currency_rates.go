@@ -0,0 +1,172 @@
+package injectable
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+const (
+	// DefaultCurrencyRatesTTL is how long the latest rates are kept before they are fetched again.
+	DefaultCurrencyRatesTTL = time.Hour
+	// maxCachedRateDates bounds the rate tables of pinned dates kept in memory.
+	maxCachedRateDates = 500
+)
+
+// CurrencyRateService caches the rate tables of a currency rate provider and hands each render a
+// CurrencyConverter. The rates of a past date never change and are kept; the latest rates, and
+// those of the last two days that may still be published, are fetched again after the TTL.
+type CurrencyRateService struct {
+	provider  port.CurrencyRateProvider
+	rounding  entity.CurrencyRoundingMode
+	latestTTL time.Duration
+
+	fetches singleflight.Group
+	mu      sync.Mutex
+	tables  map[string]cachedRateTable // by requested date, "" for the latest
+}
+
+type cachedRateTable struct {
+	table   *entity.CurrencyRateTable
+	expires time.Time // zero: never
+}
+
+// NewCurrencyRateService creates a currency rate service over provider. Converted amounts are
+// rounded with rounding (HALF_UP when empty); latestTTL zero takes DefaultCurrencyRatesTTL.
+func NewCurrencyRateService(provider port.CurrencyRateProvider, rounding entity.CurrencyRoundingMode, latestTTL time.Duration) *CurrencyRateService {
+	if rounding == "" {
+		rounding = entity.CurrencyRoundHalfUp
+	}
+	if latestTTL <= 0 {
+		latestTTL = DefaultCurrencyRatesTTL
+	}
+	return &CurrencyRateService{
+		provider:  provider,
+		rounding:  rounding,
+		latestTTL: latestTTL,
+		tables:    make(map[string]cachedRateTable),
+	}
+}
+
+// Converter returns the currency conversion of one render, with the rates of date or the latest
+// ones when date is zero. The rates are fetched on the first conversion. Cancelling ctx does not
+// abort the fetch, which other renders may be waiting on; the provider timeout bounds it.
+func (s *CurrencyRateService) Converter(ctx context.Context, date time.Time) *CurrencyConverter {
+	if !date.IsZero() {
+		date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return &CurrencyConverter{
+		ctx:     context.WithoutCancel(ctx),
+		service: s,
+		date:    date,
+		used:    make(map[[2]string]entity.CurrencyRate),
+	}
+}
+
+// rateTable returns the cached rate table of date, fetching it when missing or expired.
+// Concurrent renders needing the same table share one fetch.
+func (s *CurrencyRateService) rateTable(ctx context.Context, date time.Time) (*entity.CurrencyRateTable, error) {
+	key := ""
+	if !date.IsZero() {
+		key = date.Format(time.DateOnly)
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	cached, ok := s.tables[key]
+	s.mu.Unlock()
+	if ok && (cached.expires.IsZero() || now.Before(cached.expires)) {
+		return cached.table, nil
+	}
+
+	table, err, _ := s.fetches.Do(key, func() (any, error) {
+		table, err := s.provider.RateTable(ctx, date)
+		if err != nil {
+			return nil, err
+		}
+		entry := cachedRateTable{table: table}
+		if date.IsZero() || now.Sub(date) < 48*time.Hour {
+			entry.expires = now.Add(s.latestTTL)
+		}
+		s.mu.Lock()
+		if len(s.tables) >= maxCachedRateDates {
+			clear(s.tables)
+		}
+		s.tables[key] = entry
+		s.mu.Unlock()
+		return table, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("loading %s currency rates: %w", s.provider.Name(), err)
+	}
+	return table.(*entity.CurrencyRateTable), nil
+}
+
+// CurrencyConverter converts the amounts of one render with the rates of one date and records the
+// rates it used, for the render to report them.
+type CurrencyConverter struct {
+	// ctx is the context of the render: conversions are requested from injectors and table
+	// formulas, which do not carry one.
+	ctx     context.Context
+	service *CurrencyRateService
+	date    time.Time
+
+	load  sync.Once
+	table *entity.CurrencyRateTable
+	err   error
+
+	mu   sync.Mutex
+	used map[[2]string]entity.CurrencyRate
+}
+
+// Convert converts amount from one ISO 4217 currency to another, rounded to the minor units of
+// the target currency. It implements entity.CurrencyConvertFunc.
+func (c *CurrencyConverter) Convert(amount float64, from, to string) (float64, error) {
+	from, to = entity.NormalizeCurrencyCode(from), entity.NormalizeCurrencyCode(to)
+	if from == to {
+		return entity.RoundCurrency(amount, to, c.service.rounding), nil
+	}
+
+	c.load.Do(func() {
+		c.table, c.err = c.service.rateTable(c.ctx, c.date)
+	})
+	if c.err != nil {
+		return 0, c.err
+	}
+	rate, ok := c.table.Rate(from, to)
+	if !ok {
+		return 0, fmt.Errorf("%s to %s on %s: %w", from, to, c.table.Date.Format(time.DateOnly), entity.ErrCurrencyRateNotFound)
+	}
+
+	c.mu.Lock()
+	c.used[[2]string{from, to}] = entity.CurrencyRate{
+		Provider: c.table.Provider,
+		From:     from,
+		To:       to,
+		Rate:     rate,
+		Date:     c.table.Date,
+	}
+	c.mu.Unlock()
+	return entity.RoundCurrency(amount*rate, to, c.service.rounding), nil
+}
+
+// Rates returns the rates used by the conversions so far, sorted by currency pair.
+func (c *CurrencyConverter) Rates() []entity.CurrencyRate {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.used) == 0 {
+		return nil
+	}
+	return slices.SortedFunc(maps.Values(c.used), func(a, b entity.CurrencyRate) int {
+		return cmp.Or(cmp.Compare(a.From, b.From), cmp.Compare(a.To, b.To))
+	})
+}
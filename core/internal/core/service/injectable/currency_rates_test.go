@@ -0,0 +1,89 @@
+package injectable
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+type currencyRateProviderStub struct {
+	mu    sync.Mutex
+	calls []time.Time
+}
+
+func (p *currencyRateProviderStub) Name() string { return "stub" }
+
+func (p *currencyRateProviderStub) RateTable(_ context.Context, date time.Time) (*entity.CurrencyRateTable, error) {
+	p.mu.Lock()
+	p.calls = append(p.calls, date)
+	p.mu.Unlock()
+	published := date
+	if published.IsZero() {
+		published = time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	}
+	return &entity.CurrencyRateTable{
+		Provider: "stub",
+		Base:     "EUR",
+		Date:     published,
+		Rates:    map[string]float64{"USD": 1.1, "JPY": 160, "CLP": 1000},
+	}, nil
+}
+
+func TestCurrencyConverter_Convert(t *testing.T) {
+	provider := &currencyRateProviderStub{}
+	svc := NewCurrencyRateService(provider, entity.CurrencyRoundHalfUp, 0)
+	conv := svc.Converter(context.Background(), time.Time{})
+
+	eur, err := conv.Convert(11, "usd", "EUR")
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, eur)
+
+	jpy, err := conv.Convert(10.5, "USD", "JPY")
+	require.NoError(t, err)
+	assert.Equal(t, 1527.0, jpy, "cross rate through EUR, rounded to whole yen")
+
+	same, err := conv.Convert(1.005, "EUR", "EUR")
+	require.NoError(t, err)
+	assert.Equal(t, 1.01, same)
+
+	_, err = conv.Convert(1, "EUR", "XYZ")
+	assert.ErrorIs(t, err, entity.ErrCurrencyRateNotFound)
+
+	rates := conv.Rates()
+	require.Len(t, rates, 2)
+	assert.Equal(t, "USD", rates[0].From)
+	assert.Equal(t, "EUR", rates[0].To)
+	assert.Equal(t, "JPY", rates[1].To)
+	assert.Equal(t, "stub", rates[1].Provider)
+	assert.Len(t, provider.calls, 1, "the converter loads the rates once")
+}
+
+func TestCurrencyRateService_CachesRateTables(t *testing.T) {
+	provider := &currencyRateProviderStub{}
+	svc := NewCurrencyRateService(provider, "", time.Hour)
+	pinned := time.Date(2024, 1, 2, 15, 30, 0, 0, time.UTC)
+
+	for range 3 {
+		_, err := svc.Converter(context.Background(), pinned).Convert(1, "EUR", "USD")
+		require.NoError(t, err)
+		_, err = svc.Converter(context.Background(), time.Time{}).Convert(1, "EUR", "USD")
+		require.NoError(t, err)
+	}
+
+	require.Len(t, provider.calls, 2)
+	assert.Contains(t, provider.calls, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), "pinned dates are truncated to the day")
+	assert.Contains(t, provider.calls, time.Time{})
+}
+
+func TestCurrencyRateService_RoundingMode(t *testing.T) {
+	svc := NewCurrencyRateService(&currencyRateProviderStub{}, entity.CurrencyRoundDown, 0)
+	clp, err := svc.Converter(context.Background(), time.Time{}).Convert(1.2345, "EUR", "CLP")
+	require.NoError(t, err)
+	assert.Equal(t, 1234.0, clp)
+}
@@ -1,9 +1,16 @@
 package injectable
 
 import (
+	"context"
 	"fmt"
 	"maps"
+	"slices"
 	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
 )
 
 // DependencyGraph is a directed acyclic graph (DAG) that manages dependencies
@@ -35,9 +42,15 @@ import (
 //	  Level 0: [client_name, base_price] - run in parallel
 //	  Level 1: [calculated_price] - runs after level 0
 //
+// Run executes the graph without levels: each node starts as soon as its own
+// dependencies finished, so a slow injector only delays the injectors that
+// depend on it.
+//
 // Cycle Detection:
-// If a dependency cycle is detected (A -> B -> C -> A), TopologicalSort
-// returns an error with the cycle path for debugging.
+// If a dependency cycle is detected (A -> B -> C -> A), TopologicalSort and Run
+// return an error wrapping entity.ErrInjectorDependencyCycle with the cycle
+// path for debugging. The injector registry rejects cycles when injectors are
+// registered, so this only guards graphs built some other way.
 type DependencyGraph struct {
 	nodes    map[string]bool
 	edges    map[string][]string // node -> dependencies
@@ -67,6 +80,9 @@ func (g *DependencyGraph) AddEdge(from, to string) {
 	g.AddNode(from)
 	g.AddNode(to)
 
+	if slices.Contains(g.edges[from], to) {
+		return
+	}
 	g.edges[from] = append(g.edges[from], to)
 	g.inDegree[to]++
 }
@@ -79,17 +95,16 @@ func (g *DependencyGraph) TopologicalSort() ([][]string, error) {
 		return nil, nil
 	}
 
-	// Copy inDegree to avoid modifying the original
-	inDegree := make(map[string]int, len(g.inDegree))
-	maps.Copy(inDegree, g.inDegree)
+	// Copy nodes and inDegree to avoid modifying the original
+	remaining := maps.Clone(g.nodes)
+	inDegree := maps.Clone(g.inDegree)
 
 	var levels [][]string
-	processed := 0
 
-	for processed < len(g.nodes) {
+	for len(remaining) > 0 {
 		// Find nodes with inDegree 0
 		var currentLevel []string
-		for node := range g.nodes {
+		for node := range remaining {
 			if inDegree[node] == 0 {
 				currentLevel = append(currentLevel, node)
 			}
@@ -97,14 +112,12 @@ func (g *DependencyGraph) TopologicalSort() ([][]string, error) {
 
 		if len(currentLevel) == 0 {
 			// Cycle detected - find the involved nodes
-			cycle := g.findCycle()
-			return nil, fmt.Errorf("dependency cycle detected: %s", strings.Join(cycle, " -> "))
+			return nil, g.cycleError()
 		}
 
 		// Mark as processed
 		for _, node := range currentLevel {
-			delete(g.nodes, node)
-			processed++
+			delete(remaining, node)
 
 			// Decrement inDegree of dependent nodes
 			for _, dep := range g.edges[node] {
@@ -126,6 +139,67 @@ func (g *DependencyGraph) TopologicalSort() ([][]string, error) {
 	return reversed, nil
 }
 
+// Run calls fn once per node, each as soon as fn returned for all the node's dependencies, running
+// independent nodes concurrently. After fn returns an error no other node starts, the context of
+// the running ones is canceled and the first error is returned.
+func (g *DependencyGraph) Run(ctx context.Context, fn func(ctx context.Context, code string) error) error {
+	if g.findCycle() != nil {
+		return g.cycleError()
+	}
+
+	pending := make(map[string]int, len(g.nodes))
+	dependents := make(map[string][]string, len(g.nodes))
+	for node := range g.nodes {
+		pending[node] = len(g.edges[node])
+		for _, dep := range g.edges[node] {
+			dependents[dep] = append(dependents[dep], node)
+		}
+	}
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	var mu sync.Mutex
+	var start func(code string)
+	start = func(code string) {
+		eg.Go(func() error {
+			if err := fn(egCtx, code); err != nil {
+				return err
+			}
+			mu.Lock()
+			var ready []string
+			for _, dependent := range dependents[code] {
+				pending[dependent]--
+				if pending[dependent] == 0 {
+					ready = append(ready, dependent)
+				}
+			}
+			mu.Unlock()
+
+			for _, dependent := range ready {
+				if egCtx.Err() == nil {
+					start(dependent)
+				}
+			}
+			return nil
+		})
+	}
+
+	var roots []string
+	for _, node := range slices.Sorted(maps.Keys(g.nodes)) {
+		if pending[node] == 0 {
+			roots = append(roots, node)
+		}
+	}
+	for _, root := range roots {
+		start(root)
+	}
+	return eg.Wait()
+}
+
+// cycleError returns the error of a graph with a cycle, naming the cycle.
+func (g *DependencyGraph) cycleError() error {
+	return fmt.Errorf("%w: %s", entity.ErrInjectorDependencyCycle, strings.Join(g.findCycle(), " -> "))
+}
+
 // buildCyclePath constructs the cycle path from cycleStart to currentNode using the parent map.
 // Returns the path with cycleStart at both start and end (representing the cycle).
 func buildCyclePath(currentNode, cycleStart string, parent map[string]string) []string {
@@ -182,31 +256,35 @@ func (g *DependencyGraph) findCycle() []string {
 }
 
 // BuildFromInjectors builds the dependency graph from injectors.
-// Only includes injectors whose codes are in referencedCodes.
+// Includes the injectors whose codes are in referencedCodes and, transitively, the registered
+// injectors they depend on, so a referenced injector always runs after its dependencies even
+// when the template does not reference them.
 func (g *DependencyGraph) BuildFromInjectors(
 	getInjector func(code string) (dependencies []string, exists bool),
 	referencedCodes []string,
 ) error {
-	// Set of referenced codes for fast lookup
-	referenced := make(map[string]bool)
-	for _, code := range referencedCodes {
-		referenced[code] = true
-	}
+	queue := slices.Clone(referencedCodes)
+	visited := make(map[string]bool)
+	for len(queue) > 0 {
+		code := queue[0]
+		queue = queue[1:]
+		if visited[code] {
+			continue
+		}
+		visited[code] = true
 
-	// Add nodes for the referenced injectors
-	for _, code := range referencedCodes {
 		deps, exists := getInjector(code)
 		if !exists {
 			continue // Injector not registered, skip
 		}
 
 		g.AddNode(code)
-
-		// Add dependencies only if they are also referenced
 		for _, dep := range deps {
-			if referenced[dep] {
-				g.AddEdge(code, dep)
+			if _, registered := getInjector(dep); !registered {
+				continue // Provided by the caller or the workspace provider
 			}
+			g.AddEdge(code, dep)
+			queue = append(queue, dep)
 		}
 	}
 
@@ -0,0 +1,114 @@
+package injectable
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+func TestDependencyGraph_RunRespectsDependencies(t *testing.T) {
+	g := NewDependencyGraph()
+	g.AddEdge("total", "price")
+	g.AddEdge("total", "quantity")
+	g.AddEdge("price", "currency")
+
+	var mu sync.Mutex
+	var done []string
+	err := g.Run(context.Background(), func(_ context.Context, code string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, dep := range g.edges[code] {
+			assert.Contains(t, done, dep, "%s ran before its dependency %s", code, dep)
+		}
+		done = append(done, code)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"total", "price", "quantity", "currency"}, done)
+}
+
+func TestDependencyGraph_RunDoesNotWaitForUnrelatedBranches(t *testing.T) {
+	// slow and fast share a level; fast_total only depends on fast and must not wait for slow
+	g := NewDependencyGraph()
+	g.AddNode("slow")
+	g.AddEdge("fast_total", "fast")
+
+	fastTotalDone := make(chan struct{})
+	err := g.Run(context.Background(), func(_ context.Context, code string) error {
+		switch code {
+		case "slow":
+			select {
+			case <-fastTotalDone:
+			case <-time.After(5 * time.Second):
+				return errors.New("fast_total waited for slow")
+			}
+		case "fast_total":
+			close(fastTotalDone)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestDependencyGraph_RunStopsDependentsOnError(t *testing.T) {
+	g := NewDependencyGraph()
+	g.AddEdge("total", "price")
+	failure := errors.New("price failed")
+
+	var mu sync.Mutex
+	var ran []string
+	err := g.Run(context.Background(), func(_ context.Context, code string) error {
+		mu.Lock()
+		ran = append(ran, code)
+		mu.Unlock()
+		if code == "price" {
+			return failure
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, failure)
+	assert.Equal(t, []string{"price"}, ran)
+}
+
+func TestDependencyGraph_RunRejectsCycle(t *testing.T) {
+	g := NewDependencyGraph()
+	g.AddEdge("a", "b")
+	g.AddEdge("b", "a")
+
+	called := false
+	err := g.Run(context.Background(), func(context.Context, string) error {
+		called = true
+		return nil
+	})
+	require.ErrorIs(t, err, entity.ErrInjectorDependencyCycle)
+	assert.False(t, called)
+
+	_, err = g.TopologicalSort()
+	assert.ErrorIs(t, err, entity.ErrInjectorDependencyCycle)
+}
+
+func TestDependencyGraph_BuildFromInjectorsAddsTransitiveDependencies(t *testing.T) {
+	deps := map[string][]string{
+		"total":    {"price", "crm_discount"}, // crm_discount belongs to the workspace provider
+		"price":    {"currency"},
+		"currency": nil,
+	}
+	g := NewDependencyGraph()
+	require.NoError(t, g.BuildFromInjectors(func(code string) ([]string, bool) {
+		d, ok := deps[code]
+		return d, ok
+	}, []string{"total"}))
+
+	levels, err := g.TopologicalSort()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"currency"}, {"price"}, {"total"}}, levels)
+	assert.False(t, slices.Contains(slices.Concat(levels...), "crm_discount"))
+}
@@ -0,0 +1,270 @@
+package injectable
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/vm"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// Formula is the compiled formula of a computed injectable.
+//
+// Formulas use expr-lang syntax and reference other injectables by key, or as $env["key"] when the
+// key is also the name of a function, such as first or last. Besides the expr
+// operators and builtins, they can call:
+//   - sum(a, b, ...): adds numbers, numeric strings and lists of them; empty values count as 0
+//   - concat(a, b, ...): joins values as text; empty values are skipped
+//   - dateDiff(from, to, unit): whole days, months or years from one date to another; unit is
+//     "days" (the default), "months" or "years"
+//
+// Conditionals use the ternary operator, if/else or ??, e.g. `discount > 0 ? total - discount : total`.
+type Formula struct {
+	program      *vm.Program
+	dependencies []string
+}
+
+// CompileFormula compiles a formula and finds the injectables it references. Errors wrap
+// entity.ErrInvalidFormula.
+func CompileFormula(source string) (*Formula, error) {
+	program, err := expr.Compile(source, append(formulaFunctions(), expr.AllowUndefinedVariables())...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", entity.ErrInvalidFormula, err)
+	}
+
+	refs := &referenceCollector{}
+	node := program.Node()
+	ast.Walk(&node, refs)
+	for _, callee := range refs.callees {
+		if !slices.Contains(formulaFunctionNames, callee) {
+			return nil, fmt.Errorf("%w: unknown function %s", entity.ErrInvalidFormula, callee)
+		}
+	}
+	return &Formula{program: program, dependencies: refs.references()}, nil
+}
+
+// Dependencies returns the keys the formula references, sorted.
+func (f *Formula) Dependencies() []string {
+	return f.dependencies
+}
+
+// Eval runs the formula with values bound to their keys and converts the result to dataType.
+// A nil value means the formula produced no value. Errors wrap entity.ErrInvalidFormula.
+func (f *Formula) Eval(values map[string]any, dataType entity.InjectableDataType) (*entity.InjectableValue, error) {
+	env := make(map[string]any, len(f.dependencies))
+	for _, key := range f.dependencies {
+		if value, ok := values[key]; ok {
+			env[key] = value
+		}
+	}
+
+	result, err := expr.Run(f.program, env)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", entity.ErrInvalidFormula, err)
+	}
+	return formulaValue(result, dataType)
+}
+
+// formulaValue converts the result of a formula to dataType. Dates are kept as they are; other
+// results convert like the values of scripted injectors.
+func formulaValue(result any, dataType entity.InjectableDataType) (*entity.InjectableValue, error) {
+	switch v := result.(type) {
+	case time.Time:
+		value := entity.TimeValue(v)
+		if dataType == entity.InjectableDataTypeText {
+			value = entity.StringValue(v.Format(time.DateOnly))
+		}
+		return &value, nil
+	case int:
+		result = float64(v)
+	case int64:
+		result = float64(v)
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("%w: result is not a finite number", entity.ErrInvalidFormula)
+		}
+	}
+	return convertValue(result, dataType, entity.ErrInvalidFormula)
+}
+
+// referenceCollector collects the identifiers of a formula that are not called as functions.
+type referenceCollector struct {
+	identifiers []string
+	callees     []string
+}
+
+func (r *referenceCollector) Visit(node *ast.Node) {
+	switch n := (*node).(type) {
+	case *ast.IdentifierNode:
+		if n.Value != "$env" {
+			r.identifiers = append(r.identifiers, n.Value)
+		}
+	case *ast.MemberNode: // $env["key"]
+		if env, ok := n.Node.(*ast.IdentifierNode); ok && env.Value == "$env" {
+			if key, ok := n.Property.(*ast.StringNode); ok {
+				r.identifiers = append(r.identifiers, key.Value)
+			}
+		}
+	case *ast.CallNode:
+		if callee, ok := n.Callee.(*ast.IdentifierNode); ok {
+			r.callees = append(r.callees, callee.Value)
+		}
+	}
+}
+
+func (r *referenceCollector) references() []string {
+	var refs []string
+	for _, name := range r.identifiers {
+		if !slices.Contains(r.callees, name) && !slices.Contains(refs, name) {
+			refs = append(refs, name)
+		}
+	}
+	slices.Sort(refs)
+	return refs
+}
+
+// formulaFunctionNames are the names of formulaFunctions.
+var formulaFunctionNames = []string{"sum", "concat", "dateDiff"}
+
+// formulaFunctions are the functions formulas can call besides the expr builtins.
+func formulaFunctions() []expr.Option {
+	return []expr.Option{
+		expr.Function("sum", formulaSum, new(func(...any) float64)),
+		expr.Function("concat", formulaConcat, new(func(...any) string)),
+		expr.Function("dateDiff", formulaDateDiff, new(func(any, any) int), new(func(any, any, string) int)),
+	}
+}
+
+func formulaSum(params ...any) (any, error) {
+	total := 0.0
+	for _, param := range params {
+		n, err := formulaNumber(param)
+		if err != nil {
+			return nil, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// formulaNumber reads a number, a numeric string or a list of them; nil and "" are 0.
+func formulaNumber(v any) (float64, error) {
+	switch n := v.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return n, nil
+	case int:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	case string:
+		if strings.TrimSpace(n) == "" {
+			return 0, nil
+		}
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		if err != nil {
+			return 0, fmt.Errorf("sum: %q is not a number", n)
+		}
+		return f, nil
+	case []any:
+		total := 0.0
+		for _, item := range n {
+			f, err := formulaNumber(item)
+			if err != nil {
+				return 0, err
+			}
+			total += f
+		}
+		return total, nil
+	default:
+		return 0, fmt.Errorf("sum: %T is not a number", v)
+	}
+}
+
+func formulaConcat(params ...any) (any, error) {
+	var sb strings.Builder
+	for _, param := range params {
+		switch v := param.(type) {
+		case nil:
+		case string:
+			sb.WriteString(v)
+		case float64:
+			sb.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+		case time.Time:
+			sb.WriteString(v.Format(time.DateOnly))
+		default:
+			fmt.Fprint(&sb, v)
+		}
+	}
+	return sb.String(), nil
+}
+
+func formulaDateDiff(params ...any) (any, error) {
+	from, err := formulaDate(params[0])
+	if err != nil {
+		return nil, err
+	}
+	to, err := formulaDate(params[1])
+	if err != nil {
+		return nil, err
+	}
+	unit := "days"
+	if len(params) > 2 {
+		unit = params[2].(string)
+	}
+
+	switch unit {
+	case "days":
+		fy, fm, fd := from.Date()
+		ty, tm, td := to.In(from.Location()).Date()
+		days := time.Date(ty, tm, td, 0, 0, 0, 0, time.UTC).Sub(time.Date(fy, fm, fd, 0, 0, 0, 0, time.UTC)).Hours() / 24
+		return int(math.Round(days)), nil
+	case "months", "years":
+		months := wholeMonths(from, to.In(from.Location()))
+		if unit == "years" {
+			return months / 12, nil
+		}
+		return months, nil
+	default:
+		return nil, fmt.Errorf("dateDiff: unknown unit %q, want days, months or years", unit)
+	}
+}
+
+// wholeMonths counts the months from one date to another that are complete.
+func wholeMonths(from, to time.Time) int {
+	if to.Before(from) {
+		return -wholeMonths(to, from)
+	}
+	fy, fm, fd := from.Date()
+	ty, tm, td := to.Date()
+	months := (ty-fy)*12 + int(tm-fm)
+	if td < fd {
+		months--
+	}
+	return months
+}
+
+// formulaDate reads a date or an RFC 3339 or YYYY-MM-DD string.
+func formulaDate(v any) (time.Time, error) {
+	switch d := v.(type) {
+	case time.Time:
+		return d, nil
+	case string:
+		s := strings.TrimSpace(d)
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			return t, nil
+		}
+		if t, err := time.Parse(time.DateOnly, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("dateDiff: %v is not a date", v)
+}
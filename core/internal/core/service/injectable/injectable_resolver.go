@@ -4,11 +4,11 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
-	"golang.org/x/sync/errgroup"
-
 	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	"github.com/rendis/pdf-forge/core/internal/core/port"
 )
@@ -25,17 +25,24 @@ type ResolveResult struct {
 	// Values contains the resolved values (code -> value).
 	Values map[string]entity.InjectableValue
 
-	// Errors contains errors from non-critical injectors.
+	// Errors contains errors from non-critical injectors, and from every failed injector of a
+	// partial resolution.
 	Errors map[string]error
 
 	// Metadata contains additional metadata per injector.
 	Metadata map[string]map[string]any
+
+	// Timings records when each registry injector ran and how long it took, sorted by code.
+	Timings []entity.InjectorTiming
 }
 
 // InjectableResolverService resolves injector values.
 type InjectableResolverService struct {
-	registry          port.InjectorRegistry
-	workspaceProvider port.WorkspaceInjectableProvider // can be nil
+	registry             port.InjectorRegistry
+	workspaceProvider    port.WorkspaceInjectableProvider   // can be nil
+	workspaceInjectables port.WorkspaceInjectableRepository // can be nil; loads computed injectables
+	chaos                *chaos                             // nil unless chaos mode is enabled
+	metrics              port.Metrics                       // can be nil
 }
 
 // NewInjectableResolverService creates a new resolution service.
@@ -49,13 +56,48 @@ func NewInjectableResolverService(
 	}
 }
 
+// EnableChaos makes injector and provider calls fail or slow down as configured.
+// For resilience testing only.
+func (s *InjectableResolverService) EnableChaos(opts ChaosOptions) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	s.chaos = newChaos(opts)
+	return nil
+}
+
+// SetMetrics records the resolution time of each registry injector in m.
+func (s *InjectableResolverService) SetMetrics(m port.Metrics) {
+	s.metrics = m
+}
+
 // Resolve resolves the values of the referenced injectors.
-// Executes Init() GLOBAL first, then resolves registry injectors by dependency levels,
-// then resolves provider injectors in batch.
+// Executes Init() GLOBAL first, then resolves registry injectors in dependency order, each as soon
+// as its dependencies are resolved, then resolves provider injectors in batch.
 func (s *InjectableResolverService) Resolve(
 	ctx context.Context,
 	injCtx *entity.InjectorContext,
 	referencedCodes []string,
+) (*ResolveResult, error) {
+	return s.resolve(ctx, injCtx, referencedCodes, false)
+}
+
+// ResolvePartial resolves like Resolve, but a failed critical injector or provider does not stop
+// the resolution: its codes are recorded in Errors like non-critical failures. Used by degraded
+// renders.
+func (s *InjectableResolverService) ResolvePartial(
+	ctx context.Context,
+	injCtx *entity.InjectorContext,
+	referencedCodes []string,
+) (*ResolveResult, error) {
+	return s.resolve(ctx, injCtx, referencedCodes, true)
+}
+
+func (s *InjectableResolverService) resolve(
+	ctx context.Context,
+	injCtx *entity.InjectorContext,
+	referencedCodes []string,
+	partial bool,
 ) (*ResolveResult, error) {
 	result := &ResolveResult{
 		Values:   make(map[string]entity.InjectableValue),
@@ -83,14 +125,14 @@ func (s *InjectableResolverService) Resolve(
 
 	// 3. Resolve registry codes via dependency graph
 	if len(registryCodes) > 0 {
-		if err := s.resolveRegistryCodes(ctx, injCtx, registryCodes, result); err != nil {
+		if err := s.resolveRegistryCodes(ctx, injCtx, registryCodes, partial, result); err != nil {
 			return nil, err
 		}
 	}
 
 	// 4. Resolve provider codes in batch (if provider is registered)
 	if len(providerCodes) > 0 && s.workspaceProvider != nil {
-		if err := s.resolveProviderCodes(ctx, injCtx, providerCodes, result); err != nil {
+		if err := s.resolveProviderCodes(ctx, injCtx, providerCodes, partial, result); err != nil {
 			return nil, err
 		}
 	}
@@ -115,6 +157,7 @@ func (s *InjectableResolverService) resolveRegistryCodes(
 	ctx context.Context,
 	injCtx *entity.InjectorContext,
 	codes []string,
+	partial bool,
 	result *ResolveResult,
 ) error {
 	// Build dependency graph
@@ -134,25 +177,15 @@ func (s *InjectableResolverService) resolveRegistryCodes(
 		return fmt.Errorf("building dependency graph: %w", err)
 	}
 
-	// Get execution order (by levels)
-	levels, err := graph.TopologicalSort()
-	if err != nil {
-		return fmt.Errorf("topological sort: %w", err)
-	}
-
-	// Execute injectors by levels
-	for levelIdx, level := range levels {
-		slog.DebugContext(ctx, "executing injector level",
-			"level", levelIdx,
-			"injectors", level,
-		)
-
-		if err := s.executeLevel(ctx, injCtx, level, result); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	// Independent branches run concurrently; an injector waits only for its own dependencies
+	started := time.Now()
+	err = graph.Run(ctx, func(ctx context.Context, code string) error {
+		return s.executeInjector(ctx, injCtx, code, partial, started, result)
+	})
+	slices.SortFunc(result.Timings, func(a, b entity.InjectorTiming) int {
+		return strings.Compare(a.Code, b.Code)
+	})
+	return err
 }
 
 // resolveProviderCodes resolves codes from the workspace provider in batch.
@@ -160,6 +193,7 @@ func (s *InjectableResolverService) resolveProviderCodes(
 	ctx context.Context,
 	injCtx *entity.InjectorContext,
 	codes []string,
+	partial bool,
 	result *ResolveResult,
 ) error {
 	slog.DebugContext(ctx, "resolving provider codes",
@@ -168,20 +202,18 @@ func (s *InjectableResolverService) resolveProviderCodes(
 		"workspace_code", injCtx.WorkspaceCode(),
 	)
 
-	providerResult, err := s.workspaceProvider.ResolveInjectables(ctx, &port.ResolveInjectablesRequest{
-		TenantCode:      injCtx.TenantCode(),
-		WorkspaceCode:   injCtx.WorkspaceCode(),
-		TemplateID:      injCtx.TemplateID(),
-		Environment:     injCtx.Environment(),
-		Codes:           codes,
-		SelectedFormats: injCtx.GetSelectedFormats(),
-		Headers:         injCtx.GetHeaders(),
-		Payload:         injCtx.RequestPayload(),
-		InitData:        injCtx.InitData(),
-	})
+	providerResult, err := s.callProvider(ctx, injCtx, codes)
+	if err != nil && partial {
+		result.mu.Lock()
+		for _, code := range codes {
+			result.Errors[code] = err
+		}
+		result.mu.Unlock()
+		return nil
+	}
 	if err != nil {
 		// Critical error from provider - stop render
-		return fmt.Errorf("provider resolution failed: %w", err)
+		return &entity.InjectorError{Err: err}
 	}
 
 	// Merge provider values into result
@@ -203,29 +235,36 @@ func (s *InjectableResolverService) resolveProviderCodes(
 	return nil
 }
 
-// executeLevel executes all injectors in a level in parallel.
-func (s *InjectableResolverService) executeLevel(
+// callProvider asks the workspace provider for the values of codes.
+func (s *InjectableResolverService) callProvider(
 	ctx context.Context,
 	injCtx *entity.InjectorContext,
 	codes []string,
-	result *ResolveResult,
-) error {
-	g, gCtx := errgroup.WithContext(ctx)
-
-	for _, code := range codes {
-		g.Go(func() error {
-			return s.executeInjector(gCtx, injCtx, code, result)
-		})
+) (*port.ResolveInjectablesResult, error) {
+	if err := s.chaos.providerFault(ctx); err != nil {
+		return nil, err
 	}
 
-	return g.Wait()
+	return s.workspaceProvider.ResolveInjectables(ctx, &port.ResolveInjectablesRequest{
+		TenantCode:      injCtx.TenantCode(),
+		WorkspaceCode:   injCtx.WorkspaceCode(),
+		TemplateID:      injCtx.TemplateID(),
+		Environment:     injCtx.Environment(),
+		Codes:           codes,
+		SelectedFormats: injCtx.GetSelectedFormats(),
+		Headers:         injCtx.GetHeaders(),
+		Payload:         injCtx.RequestPayload(),
+		InitData:        injCtx.InitData(),
+	})
 }
 
-// executeInjector executes an individual injector.
+// executeInjector executes an individual injector and records its timing relative to started.
 func (s *InjectableResolverService) executeInjector(
 	ctx context.Context,
 	injCtx *entity.InjectorContext,
 	code string,
+	partial bool,
+	started time.Time,
 	result *ResolveResult,
 ) error {
 	inj, ok := s.registry.Get(code)
@@ -234,27 +273,20 @@ func (s *InjectableResolverService) executeInjector(
 		return nil
 	}
 
-	// Get the resolution function
-	resolveFunc, _ := inj.Resolve()
-	if resolveFunc == nil {
-		slog.WarnContext(ctx, "injector has nil resolve function", "code", code)
-		return nil
-	}
-
-	// Determine timeout
-	timeout := inj.Timeout()
-	if timeout <= 0 {
-		timeout = DefaultInjectorTimeout
+	begin := time.Now()
+	injResult, err := s.runInjector(ctx, injCtx, inj)
+	if s.metrics != nil {
+		s.metrics.ObserveInjector(code, time.Since(begin), err != nil)
 	}
+	result.mu.Lock()
+	result.Timings = append(result.Timings, entity.InjectorTiming{
+		Code:     code,
+		Start:    begin.Sub(started),
+		Duration: time.Since(begin),
+		Failed:   err != nil,
+	})
+	result.mu.Unlock()
 
-	// Create context with timeout
-	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
-
-	// Execute injector
-	slog.DebugContext(ctx, "executing injector", "code", code, "timeout", timeout)
-
-	injResult, err := resolveFunc(timeoutCtx, injCtx)
 	if err != nil {
 		slog.ErrorContext(ctx, "injector failed",
 			"code", code,
@@ -262,11 +294,11 @@ func (s *InjectableResolverService) executeInjector(
 			"critical", inj.IsCritical(),
 		)
 
-		if inj.IsCritical() {
-			return fmt.Errorf("critical injector %q failed: %w", code, err)
+		if inj.IsCritical() && !partial {
+			return &entity.InjectorError{Code: code, Err: err}
 		}
 
-		// Non-critical error, save and continue
+		// Non-critical error or partial resolution, save and continue
 		result.mu.Lock()
 		result.Errors[code] = err
 		result.mu.Unlock()
@@ -289,6 +321,41 @@ func (s *InjectableResolverService) executeInjector(
 	return nil
 }
 
+// runInjector calls the injector's resolve function under its timeout.
+// Returns a nil result when the injector has no resolve function.
+func (s *InjectableResolverService) runInjector(
+	ctx context.Context,
+	injCtx *entity.InjectorContext,
+	inj port.Injector,
+) (*entity.InjectorResult, error) {
+	code := inj.Code()
+
+	// Get the resolution function
+	resolveFunc, _ := inj.Resolve()
+	if resolveFunc == nil {
+		slog.WarnContext(ctx, "injector has nil resolve function", "code", code)
+		return nil, nil
+	}
+
+	// Determine timeout
+	timeout := inj.Timeout()
+	if timeout <= 0 {
+		timeout = DefaultInjectorTimeout
+	}
+
+	// Create context with timeout
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Execute injector
+	slog.DebugContext(ctx, "executing injector", "code", code, "timeout", timeout)
+
+	if err := s.chaos.injectorFault(timeoutCtx, code); err != nil {
+		return nil, err
+	}
+	return resolveFunc(timeoutCtx, injCtx)
+}
+
 // MergeWithPayloadValues combines injector values with values extracted from the payload.
 // Payload values have priority (they overwrite injector values).
 func (s *InjectableResolverService) MergeWithPayloadValues(
@@ -0,0 +1,65 @@
+package injectable
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+func TestResolvePartial_RecordsCriticalFailures(t *testing.T) {
+	s := newChaosResolver(t, ChaosOptions{Injectors: []string{"crm_name"}, FailureRate: 1},
+		&chaosInjectorStub{code: "crm_name", critical: true},
+		&chaosInjectorStub{code: "date_now", critical: true},
+	)
+
+	_, err := s.Resolve(context.Background(), newChaosContext(), []string{"crm_name", "date_now"})
+	var injectorErr *entity.InjectorError
+	require.ErrorAs(t, err, &injectorErr)
+	assert.Equal(t, "crm_name", injectorErr.Code)
+
+	result, err := s.ResolvePartial(context.Background(), newChaosContext(), []string{"crm_name", "date_now"})
+	require.NoError(t, err)
+	assert.ErrorIs(t, result.Errors["crm_name"], entity.ErrChaosFault)
+	assert.Contains(t, result.Values, "date_now", "the other injectors still resolve")
+}
+
+// timedInjectorStub is an injector with dependencies that takes delay to resolve.
+type timedInjectorStub struct {
+	chaosInjectorStub
+	deps  []string
+	delay time.Duration
+}
+
+func (i *timedInjectorStub) Resolve() (port.ResolveFunc, []string) {
+	return func(context.Context, *entity.InjectorContext) (*entity.InjectorResult, error) {
+		time.Sleep(i.delay)
+		return &entity.InjectorResult{Value: entity.StringValue(i.code)}, nil
+	}, i.deps
+}
+
+func TestResolve_RecordsInjectorTimings(t *testing.T) {
+	registry := chaosRegistryStub{injectors: map[string]port.Injector{
+		"price": &timedInjectorStub{chaosInjectorStub: chaosInjectorStub{code: "price"}, delay: 20 * time.Millisecond},
+		"total": &timedInjectorStub{chaosInjectorStub: chaosInjectorStub{code: "total"}, deps: []string{"price"}},
+	}}
+	s := NewInjectableResolverService(registry, nil)
+
+	// price is not referenced, but total depends on it
+	result, err := s.Resolve(context.Background(), newChaosContext(), []string{"total"})
+	require.NoError(t, err)
+	require.Len(t, result.Timings, 2)
+
+	price, total := result.Timings[0], result.Timings[1]
+	assert.Equal(t, "price", price.Code)
+	assert.Equal(t, "total", total.Code)
+	assert.GreaterOrEqual(t, price.Duration, 20*time.Millisecond)
+	assert.GreaterOrEqual(t, total.Start, price.Start+price.Duration, "total starts after its dependency")
+	assert.False(t, total.Failed)
+	assert.Contains(t, result.Values, "total")
+}
@@ -214,6 +214,15 @@ func convertValueTypeToDataType(vt entity.ValueType) entity.InjectableDataType {
 		return entity.InjectableDataTypeImage
 	case entity.ValueTypeList:
 		return entity.InjectableDataTypeList
+	case entity.ValueTypeAddress:
+		// Addresses are placed like text injectors; their lines become line breaks.
+		return entity.InjectableDataTypeText
+	case entity.ValueTypePerson:
+		// People are placed like text injectors, in the name form or salutation of their format.
+		return entity.InjectableDataTypeText
+	case entity.ValueTypeMeasurement:
+		// Measurements are placed like text injectors, converted to the unit system of the render.
+		return entity.InjectableDataTypeText
 	default:
 		return entity.InjectableDataTypeText
 	}
@@ -0,0 +1,289 @@
+package injectable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// Defaults of the remote injector limits.
+const (
+	DefaultRemoteMaxConcurrent  = 32
+	DefaultRemoteHealthInterval = 15 * time.Second
+	remoteCheckTimeout          = 5 * time.Second
+)
+
+// RemoteSource is a remote injector service to register.
+type RemoteSource struct {
+	Name   string
+	Client port.RemoteInjectorClient
+	// Timeout is the default timeout of the injectors of the remote and caps the timeouts of
+	// its manifest. Zero takes entity.RemoteInjectorDefaultTimeoutMs.
+	Timeout time.Duration
+	// ForwardRequest sends the request payload and headers to the remote.
+	ForwardRequest bool
+}
+
+// RemoteOptions limit the calls to the remotes. Zero values take the defaults.
+type RemoteOptions struct {
+	MaxConcurrent  int           // Calls to any remote at once
+	HealthInterval time.Duration // Between health checks
+}
+
+// RemoteInjectorService keeps the injectors of remote injector services registered in the
+// injector registry. A remote whose manifest cannot be read is retried every health interval;
+// the injectors of a remote failing its health check fail at once instead of waiting for their
+// timeout, until it is healthy again.
+type RemoteInjectorService struct {
+	registry port.InjectorRegistry
+	remotes  []*remote
+	interval time.Duration
+	sem      chan struct{}
+
+	stopCh   chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// remote is a remote injector service and its state.
+type remote struct {
+	src        RemoteSource
+	registered bool        // Its manifest was read and its injectors registered
+	healthy    atomic.Bool // Result of the last health check
+}
+
+// NewRemoteInjectorService creates the service of sources. Names must be unique. Call RunOnce to
+// register the injectors of the reachable remotes and Start to keep checking them.
+func NewRemoteInjectorService(registry port.InjectorRegistry, sources []RemoteSource, opts RemoteOptions) (*RemoteInjectorService, error) {
+	if opts.MaxConcurrent <= 0 {
+		opts.MaxConcurrent = DefaultRemoteMaxConcurrent
+	}
+	if opts.HealthInterval <= 0 {
+		opts.HealthInterval = DefaultRemoteHealthInterval
+	}
+	s := &RemoteInjectorService{
+		registry: registry,
+		interval: opts.HealthInterval,
+		sem:      make(chan struct{}, opts.MaxConcurrent),
+		stopCh:   make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	names := make(map[string]bool, len(sources))
+	for _, src := range sources {
+		if names[src.Name] {
+			return nil, fmt.Errorf("%w: remote %q configured twice", entity.ErrInvalidRemoteInjector, src.Name)
+		}
+		names[src.Name] = true
+		if src.Timeout <= 0 {
+			src.Timeout = entity.RemoteInjectorDefaultTimeoutMs * time.Millisecond
+		}
+		s.remotes = append(s.remotes, &remote{src: src})
+	}
+	return s, nil
+}
+
+// Start runs the health checks in the background until Stop is called.
+func (s *RemoteInjectorService) Start() {
+	go s.loop()
+}
+
+// Stop ends the health checks and closes the clients of the remotes.
+func (s *RemoteInjectorService) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+		<-s.stopped
+		for _, r := range s.remotes {
+			if err := r.src.Client.Close(); err != nil {
+				slog.Warn("failed to close remote injector client", slog.String("remote", r.src.Name), slog.Any("error", err))
+			}
+		}
+	})
+}
+
+func (s *RemoteInjectorService) loop() {
+	defer close(s.stopped)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.RunOnce(context.Background())
+		}
+	}
+}
+
+// RunOnce registers the injectors of the remotes whose manifest was not read yet and checks the
+// health of the others. Failures are logged.
+func (s *RemoteInjectorService) RunOnce(ctx context.Context) {
+	for _, r := range s.remotes {
+		if !r.registered {
+			if err := s.register(ctx, r); err != nil {
+				slog.WarnContext(ctx, "failed to register remote injectors",
+					slog.String("remote", r.src.Name), slog.Any("error", err))
+			}
+			continue
+		}
+		s.check(ctx, r)
+	}
+}
+
+// register reads the manifest of r and registers its injectors. If one fails to register, those
+// registered before it are removed and the manifest is read again at the next run.
+func (s *RemoteInjectorService) register(ctx context.Context, r *remote) error {
+	callCtx, cancel := context.WithTimeout(ctx, remoteCheckTimeout)
+	manifest, err := r.src.Client.Manifest(callCtx)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	maxTimeoutMs := int(r.src.Timeout.Milliseconds())
+	for _, spec := range manifest.Injectors {
+		if spec != nil && spec.TimeoutMs == 0 {
+			spec.TimeoutMs = maxTimeoutMs
+		}
+	}
+	if err := manifest.Validate(r.src.Name); err != nil {
+		return err
+	}
+
+	injectors := make([]*remoteInjector, 0, len(manifest.Injectors))
+	for _, spec := range manifest.Injectors {
+		spec.TimeoutMs = min(spec.TimeoutMs, maxTimeoutMs)
+		injectors = append(injectors, &remoteInjector{spec: spec, remote: r, sem: s.sem})
+	}
+	for i, inj := range injectors {
+		if err := s.registry.Register(inj); err != nil {
+			for _, done := range injectors[:i] {
+				s.registry.Unregister(done.Code())
+			}
+			if errors.Is(err, entity.ErrInjectorDependencyCycle) {
+				return fmt.Errorf("%w: %v", entity.ErrInvalidRemoteInjector, err)
+			}
+			return err
+		}
+	}
+
+	r.registered = true
+	r.healthy.Store(true)
+	slog.InfoContext(ctx, "remote injectors registered",
+		slog.String("remote", r.src.Name), slog.Int("injectors", len(injectors)))
+	return nil
+}
+
+// check runs the health check of r, logging when its health changes.
+func (s *RemoteInjectorService) check(ctx context.Context, r *remote) {
+	callCtx, cancel := context.WithTimeout(ctx, remoteCheckTimeout)
+	err := r.src.Client.Check(callCtx)
+	cancel()
+
+	healthy := err == nil
+	if r.healthy.Swap(healthy) == healthy {
+		return
+	}
+	if healthy {
+		slog.InfoContext(ctx, "remote injector service is healthy again", slog.String("remote", r.src.Name))
+	} else {
+		slog.WarnContext(ctx, "remote injector service is unhealthy",
+			slog.String("remote", r.src.Name), slog.Any("error", err))
+	}
+}
+
+// remoteInjector is an injector of a remote injector service.
+type remoteInjector struct {
+	spec   *entity.PluginInjectorSpec
+	remote *remote
+	sem    chan struct{} // Shared by all remotes, bounds concurrent calls
+}
+
+func (i *remoteInjector) Code() string { return i.spec.Code }
+
+func (i *remoteInjector) Resolve() (port.ResolveFunc, []string) {
+	return i.resolve, i.spec.Dependencies
+}
+
+func (i *remoteInjector) IsCritical() bool { return i.spec.IsCritical }
+
+func (i *remoteInjector) Timeout() time.Duration {
+	return time.Duration(i.spec.TimeoutMs) * time.Millisecond
+}
+
+func (i *remoteInjector) DataType() entity.ValueType { return i.spec.ValueType() }
+
+func (i *remoteInjector) DefaultValue() *entity.InjectableValue { return nil }
+
+func (i *remoteInjector) Formats() *entity.FormatConfig { return nil }
+
+func (i *remoteInjector) Labels() map[string]string { return i.spec.Label }
+
+func (i *remoteInjector) Descriptions() map[string]string {
+	if i.spec.Description == nil {
+		return map[string]string{}
+	}
+	return i.spec.Description
+}
+
+// resolve sends the injector context to the remote and converts the value it returns to the data
+// type of the manifest.
+func (i *remoteInjector) resolve(ctx context.Context, injCtx *entity.InjectorContext) (*entity.InjectorResult, error) {
+	if !i.remote.healthy.Load() {
+		return nil, fmt.Errorf("%w: %s", entity.ErrRemoteInjectorUnavailable, i.remote.src.Name)
+	}
+	resolved := make(map[string]any, len(i.spec.Dependencies))
+	for _, dep := range i.spec.Dependencies {
+		if value, ok := injCtx.GetResolved(dep); ok {
+			resolved[dep] = value
+		}
+	}
+	req := &entity.RemoteInjectorRequest{
+		Code:           i.spec.Code,
+		Resolved:       resolved,
+		ExternalID:     injCtx.ExternalID(),
+		TemplateID:     injCtx.TemplateID(),
+		Operation:      injCtx.Operation(),
+		TenantCode:     injCtx.TenantCode(),
+		WorkspaceCode:  injCtx.WorkspaceCode(),
+		Environment:    string(injCtx.Environment()),
+		SelectedFormat: injCtx.SelectedFormat(i.spec.Code),
+	}
+	if i.remote.src.ForwardRequest {
+		req.Payload = injCtx.RequestPayload()
+		req.Headers = injCtx.GetHeaders()
+	}
+
+	if err := acquire(ctx, i.sem); err != nil {
+		return nil, err
+	}
+	res, err := i.remote.src.Client.Resolve(ctx, req)
+	<-i.sem
+	if err != nil {
+		return nil, err
+	}
+	if res.Error != "" {
+		return nil, fmt.Errorf("%w: %s", entity.ErrRemoteInjectorFailed, res.Error)
+	}
+	if res.DataType != "" && res.DataType != i.spec.DataType {
+		return nil, fmt.Errorf("%w: returned a %s value, expected %s", entity.ErrRemoteInjectorFailed, res.DataType, i.spec.DataType)
+	}
+	value, err := convertValue(res.Value, i.spec.DataType, entity.ErrRemoteInjectorFailed)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return &entity.InjectorResult{Value: *value, Metadata: res.Metadata}, nil
+}
+
+// Ensure remoteInjector implements port.Injector and port.LabeledInjector.
+var (
+	_ port.Injector        = (*remoteInjector)(nil)
+	_ port.LabeledInjector = (*remoteInjector)(nil)
+)
@@ -0,0 +1,169 @@
+package injectable
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// remoteClientStub serves a fixed manifest and answers with the response of each code.
+type remoteClientStub struct {
+	manifest    *entity.RemoteInjectorManifest
+	manifestErr error
+	responses   map[string]*entity.RemoteInjectorResponse
+	checkErr    error
+	requests    []*entity.RemoteInjectorRequest
+	closed      bool
+}
+
+func (c *remoteClientStub) Manifest(context.Context) (*entity.RemoteInjectorManifest, error) {
+	return c.manifest, c.manifestErr
+}
+
+func (c *remoteClientStub) Resolve(_ context.Context, req *entity.RemoteInjectorRequest) (*entity.RemoteInjectorResponse, error) {
+	c.requests = append(c.requests, req)
+	return c.responses[req.Code], nil
+}
+
+func (c *remoteClientStub) Check(context.Context) error { return c.checkErr }
+
+func (c *remoteClientStub) Close() error {
+	c.closed = true
+	return nil
+}
+
+func remoteTestManifest() *entity.RemoteInjectorManifest {
+	return &entity.RemoteInjectorManifest{Injectors: []*entity.PluginInjectorSpec{
+		{Code: "crm_score", Label: map[string]string{"en": "Score"}, DataType: entity.InjectableDataTypeNumber},
+		{Code: "crm_tier", Label: map[string]string{"en": "Tier"}, DataType: entity.InjectableDataTypeText, TimeoutMs: 10000},
+	}}
+}
+
+func resolveRemote(t *testing.T, registry *scriptRegistryStub, code string, injCtx *entity.InjectorContext) (*entity.InjectorResult, error) {
+	t.Helper()
+	inj, ok := registry.Get(code)
+	require.True(t, ok, "%s is registered", code)
+	resolve, _ := inj.Resolve()
+	return resolve(context.Background(), injCtx)
+}
+
+func TestRemoteInjectorService_RegistersAndResolves(t *testing.T) {
+	client := &remoteClientStub{
+		manifest: remoteTestManifest(),
+		responses: map[string]*entity.RemoteInjectorResponse{
+			"crm_score": {Value: "42", Metadata: map[string]any{"source": "crm"}},
+			"crm_tier":  {Value: 3.0, DataType: entity.InjectableDataTypeNumber},
+		},
+	}
+	registry := &scriptRegistryStub{injectors: make(map[string]port.Injector)}
+	svc, err := NewRemoteInjectorService(registry, []RemoteSource{{Name: "crm", Client: client, Timeout: 3 * time.Second}}, RemoteOptions{})
+	require.NoError(t, err)
+	svc.RunOnce(context.Background())
+	require.Len(t, registry.injectors, 2)
+
+	score, _ := registry.Get("crm_score")
+	assert.Equal(t, 3*time.Second, score.Timeout(), "the remote timeout is the default")
+	assert.Equal(t, "Score", score.(port.LabeledInjector).Labels()["en"])
+	tier, _ := registry.Get("crm_tier")
+	assert.Equal(t, 3*time.Second, tier.Timeout(), "the remote timeout caps the manifest")
+
+	injCtx := entity.NewInjectorContextWithCodes("", "tpl", "", "render", "acme", "sales", entity.EnvironmentProd,
+		map[string]string{"X-Token": "secret"}, map[string]any{"id": 1})
+	result, err := resolveRemote(t, registry, "crm_score", injCtx)
+	require.NoError(t, err)
+	assert.Equal(t, 42.0, result.Value.AsAny())
+	assert.Equal(t, "crm", result.Metadata["source"])
+	req := client.requests[0]
+	assert.Equal(t, "acme", req.TenantCode)
+	assert.Equal(t, "tpl", req.TemplateID)
+	assert.Nil(t, req.Payload, "the request is only forwarded when configured")
+	assert.Nil(t, req.Headers)
+
+	_, err = resolveRemote(t, registry, "crm_tier", injCtx)
+	assert.ErrorIs(t, err, entity.ErrRemoteInjectorFailed, "a value of another type fails")
+
+	svc.Start()
+	svc.Stop()
+	assert.True(t, client.closed, "Stop closes the clients")
+}
+
+func TestRemoteInjectorService_ForwardRequestAndErrors(t *testing.T) {
+	client := &remoteClientStub{
+		manifest:  remoteTestManifest(),
+		responses: map[string]*entity.RemoteInjectorResponse{"crm_tier": {Error: "customer not found"}},
+	}
+	registry := &scriptRegistryStub{injectors: make(map[string]port.Injector)}
+	svc, err := NewRemoteInjectorService(registry, []RemoteSource{{Name: "crm", Client: client, ForwardRequest: true}}, RemoteOptions{})
+	require.NoError(t, err)
+	svc.RunOnce(context.Background())
+
+	injCtx := entity.NewInjectorContext("", "tpl", "", "render", entity.EnvironmentProd,
+		map[string]string{"X-Token": "secret"}, map[string]any{"id": 1})
+	_, err = resolveRemote(t, registry, "crm_tier", injCtx)
+	assert.ErrorIs(t, err, entity.ErrRemoteInjectorFailed)
+	assert.ErrorContains(t, err, "customer not found")
+	assert.Equal(t, "secret", client.requests[0].Headers["x-token"])
+	assert.Equal(t, map[string]any{"id": 1}, client.requests[0].Payload)
+}
+
+func TestRemoteInjectorService_Health(t *testing.T) {
+	client := &remoteClientStub{
+		manifest:  remoteTestManifest(),
+		responses: map[string]*entity.RemoteInjectorResponse{"crm_score": {Value: 1.0}},
+	}
+	registry := &scriptRegistryStub{injectors: make(map[string]port.Injector)}
+	svc, err := NewRemoteInjectorService(registry, []RemoteSource{{Name: "crm", Client: client}}, RemoteOptions{})
+	require.NoError(t, err)
+	svc.RunOnce(context.Background())
+	injCtx := entity.NewInjectorContext("", "tpl", "", "render", entity.EnvironmentProd, nil, nil)
+
+	client.checkErr = errors.New("connection refused")
+	svc.RunOnce(context.Background())
+	_, err = resolveRemote(t, registry, "crm_score", injCtx)
+	assert.ErrorIs(t, err, entity.ErrRemoteInjectorUnavailable)
+	assert.Empty(t, client.requests, "an unhealthy remote is not called")
+
+	client.checkErr = nil
+	svc.RunOnce(context.Background())
+	result, err := resolveRemote(t, registry, "crm_score", injCtx)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, result.Value.AsAny())
+}
+
+func TestRemoteInjectorService_RetriesManifest(t *testing.T) {
+	client := &remoteClientStub{manifestErr: entity.ErrRemoteInjectorFailed}
+	registry := &scriptRegistryStub{injectors: make(map[string]port.Injector)}
+	svc, err := NewRemoteInjectorService(registry, []RemoteSource{{Name: "crm", Client: client}}, RemoteOptions{})
+	require.NoError(t, err)
+
+	svc.RunOnce(context.Background())
+	assert.Empty(t, registry.injectors)
+
+	client.manifestErr = nil
+	client.manifest = &entity.RemoteInjectorManifest{Injectors: []*entity.PluginInjectorSpec{
+		{Code: "crm_score", Label: map[string]string{"en": "Score"}, DataType: entity.InjectableDataTypeNumber},
+		{Code: "crm_loop", Label: map[string]string{"en": "Loop"}, DataType: entity.InjectableDataTypeText, Dependencies: []string{"crm_loop"}},
+	}}
+	svc.RunOnce(context.Background())
+	assert.Empty(t, registry.injectors, "injectors registered before a failure are removed")
+
+	client.manifest = remoteTestManifest()
+	svc.RunOnce(context.Background())
+	assert.Len(t, registry.injectors, 2)
+}
+
+func TestNewRemoteInjectorService_DuplicateName(t *testing.T) {
+	registry := &scriptRegistryStub{injectors: make(map[string]port.Injector)}
+	_, err := NewRemoteInjectorService(registry, []RemoteSource{
+		{Name: "crm", Client: &remoteClientStub{}},
+		{Name: "crm", Client: &remoteClientStub{}},
+	}, RemoteOptions{})
+	assert.ErrorIs(t, err, entity.ErrInvalidRemoteInjector)
+}
@@ -0,0 +1,192 @@
+package injectable
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// scriptInjector is a scripted injector compiled and ready to be registered as a port.Injector.
+type scriptInjector struct {
+	def      *entity.ScriptedInjector
+	script   port.CompiledScript
+	provider port.WorkspaceInjectableProvider // can be nil
+	sem      chan struct{}                    // Shared by all scripts, bounds concurrent runs
+}
+
+func (i *scriptInjector) Code() string { return i.def.Code }
+
+func (i *scriptInjector) Resolve() (port.ResolveFunc, []string) {
+	return i.resolve, i.def.Dependencies
+}
+
+func (i *scriptInjector) IsCritical() bool { return i.def.IsCritical }
+
+func (i *scriptInjector) Timeout() time.Duration {
+	return time.Duration(i.def.TimeoutMs) * time.Millisecond
+}
+
+func (i *scriptInjector) DataType() entity.ValueType { return i.def.ValueType() }
+
+func (i *scriptInjector) DefaultValue() *entity.InjectableValue { return nil }
+
+func (i *scriptInjector) Formats() *entity.FormatConfig { return nil }
+
+func (i *scriptInjector) Labels() map[string]string { return i.def.Label }
+
+func (i *scriptInjector) Descriptions() map[string]string {
+	if i.def.Description == nil {
+		return map[string]string{}
+	}
+	return i.def.Description
+}
+
+// resolve runs the script with the render context. A script returning None yields no value.
+func (i *scriptInjector) resolve(ctx context.Context, injCtx *entity.InjectorContext) (*entity.InjectorResult, error) {
+	resolved := make(map[string]any, len(i.def.Dependencies))
+	for _, dep := range i.def.Dependencies {
+		if value, ok := injCtx.GetResolved(dep); ok {
+			resolved[dep] = value
+		}
+	}
+	input := &entity.ScriptInput{
+		Payload:        injCtx.RequestPayload(),
+		Resolved:       resolved,
+		TenantCode:     injCtx.TenantCode(),
+		WorkspaceCode:  injCtx.WorkspaceCode(),
+		Environment:    string(injCtx.Environment()),
+		SelectedFormat: injCtx.SelectedFormat(i.def.Code),
+		Headers:        injCtx.GetHeaders(),
+	}
+
+	fetch := providerFetch(i.provider, port.ResolveInjectablesRequest{
+		TenantCode:      injCtx.TenantCode(),
+		WorkspaceCode:   injCtx.WorkspaceCode(),
+		TemplateID:      injCtx.TemplateID(),
+		Environment:     injCtx.Environment(),
+		SelectedFormats: injCtx.GetSelectedFormats(),
+		Headers:         injCtx.GetHeaders(),
+		Payload:         injCtx.RequestPayload(),
+		InitData:        injCtx.InitData(),
+	})
+	run, err := runScript(ctx, i.script, i.sem, input, fetch)
+	if err != nil {
+		return nil, err
+	}
+	value, err := scriptValue(run.Value, i.def.DataType)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return &entity.InjectorResult{Value: *value, Metadata: map[string]any{"steps": run.Steps}}, nil
+}
+
+// runScript runs script once a slot of sem is free.
+func runScript(ctx context.Context, script port.CompiledScript, sem chan struct{}, input *entity.ScriptInput, fetch port.ScriptFetchFunc) (*entity.ScriptRun, error) {
+	select {
+	case sem <- struct{}{}:
+		defer func() { <-sem }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return script.Run(ctx, input, fetch)
+}
+
+// providerFetch returns the fetch of scripts: one code resolved by the workspace provider with
+// the render context of base. Nil without a provider.
+func providerFetch(provider port.WorkspaceInjectableProvider, base port.ResolveInjectablesRequest) port.ScriptFetchFunc {
+	if provider == nil {
+		return nil
+	}
+	return func(ctx context.Context, code string) (any, error) {
+		req := base
+		req.Codes = []string{code}
+		result, err := provider.ResolveInjectables(ctx, &req)
+		if err != nil {
+			return nil, err
+		}
+		if msg, ok := result.Errors[code]; ok {
+			return nil, fmt.Errorf("%s", msg)
+		}
+		if value := result.Values[code]; value != nil {
+			return value.AsAny(), nil
+		}
+		return nil, nil
+	}
+}
+
+// scriptValue converts the value returned by a script to the data type of the injector.
+func scriptValue(raw any, dataType entity.InjectableDataType) (*entity.InjectableValue, error) {
+	return convertValue(raw, dataType, entity.ErrScriptFailed)
+}
+
+// convertValue converts a JSON-decoded value to dataType; errors wrap failed. Numbers, booleans
+// and text convert leniently between each other; dates are RFC 3339 or YYYY-MM-DD.
+func convertValue(raw any, dataType entity.InjectableDataType, failed error) (*entity.InjectableValue, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	var value entity.InjectableValue
+	switch dataType {
+	case entity.InjectableDataTypeNumber:
+		switch v := raw.(type) {
+		case float64:
+			value = entity.NumberValue(v)
+		case string:
+			n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q is not a number", failed, v)
+			}
+			value = entity.NumberValue(n)
+		default:
+			return nil, fmt.Errorf("%w: expected a number, got %T", failed, raw)
+		}
+	case entity.InjectableDataTypeBoolean:
+		b, ok := raw.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%w: expected a bool, got %T", failed, raw)
+		}
+		value = entity.BoolValue(b)
+	case entity.InjectableDataTypeDate:
+		s, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: expected a date string, got %T", failed, raw)
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			if t, err = time.Parse(time.DateOnly, s); err != nil {
+				return nil, fmt.Errorf("%w: %q is not an RFC 3339 or YYYY-MM-DD date", failed, s)
+			}
+		}
+		value = entity.TimeValue(t)
+	case entity.InjectableDataTypeImage:
+		s, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: expected an image URL, got %T", failed, raw)
+		}
+		value = entity.ImageValue(s)
+	default:
+		switch v := raw.(type) {
+		case string:
+			value = entity.StringValue(v)
+		case float64:
+			value = entity.StringValue(strconv.FormatFloat(v, 'f', -1, 64))
+		case bool:
+			value = entity.StringValue(strconv.FormatBool(v))
+		default:
+			return nil, fmt.Errorf("%w: expected text, got %T", failed, raw)
+		}
+	}
+	return &value, nil
+}
+
+// Ensure scriptInjector implements port.Injector and port.LabeledInjector.
+var (
+	_ port.Injector        = (*scriptInjector)(nil)
+	_ port.LabeledInjector = (*scriptInjector)(nil)
+)
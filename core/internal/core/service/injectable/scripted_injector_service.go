@@ -0,0 +1,333 @@
+package injectable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	injectableuc "github.com/rendis/pdf-forge/core/internal/core/usecase/injectable"
+)
+
+// ScriptedInjectorService manages scripted injectors and keeps them registered in the injector
+// registry. Every API instance loads them from the database and reloads the ones changed by other
+// instances every interval.
+type ScriptedInjectorService struct {
+	repo     port.ScriptedInjectorRepository
+	registry port.InjectorRegistry
+	runtime  port.ScriptRuntime
+	provider port.WorkspaceInjectableProvider // can be nil
+	enabled  bool
+	interval time.Duration
+	sem      chan struct{}
+
+	mu     sync.Mutex           // Serializes changes to the registry
+	loaded map[string]time.Time // UpdatedAt of the registered scripts, by code
+
+	stopCh   chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// NewScriptedInjectorService creates a scripted injector service. At most maxConcurrent scripts
+// run at once. When disabled, scripts are neither registered nor run, and only listing works.
+// Call RunOnce to register the stored scripts and Start to keep them in sync.
+func NewScriptedInjectorService(
+	repo port.ScriptedInjectorRepository,
+	registry port.InjectorRegistry,
+	runtime port.ScriptRuntime,
+	provider port.WorkspaceInjectableProvider,
+	enabled bool,
+	interval time.Duration,
+	maxConcurrent int,
+) *ScriptedInjectorService {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	return &ScriptedInjectorService{
+		repo:     repo,
+		registry: registry,
+		runtime:  runtime,
+		provider: provider,
+		enabled:  enabled,
+		interval: interval,
+		sem:      make(chan struct{}, max(maxConcurrent, 1)),
+		loaded:   make(map[string]time.Time),
+		stopCh:   make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+}
+
+// List returns all scripted injectors, sorted by code.
+func (s *ScriptedInjectorService) List(ctx context.Context) ([]*entity.ScriptedInjector, error) {
+	return s.repo.FindAll(ctx)
+}
+
+// Get returns a scripted injector by code.
+func (s *ScriptedInjectorService) Get(ctx context.Context, code string) (*entity.ScriptedInjector, error) {
+	return s.repo.FindByCode(ctx, code)
+}
+
+// Create validates, compiles and registers a new scripted injector.
+func (s *ScriptedInjectorService) Create(ctx context.Context, cmd injectableuc.SaveScriptedInjectorCommand) (*entity.ScriptedInjector, error) {
+	if !s.enabled {
+		return nil, entity.ErrScriptedInjectorsDisabled
+	}
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	def := scriptedInjectorFromCommand(cmd)
+	def.CreatedBy = def.UpdatedBy
+	def.CreatedAt = now
+	def.UpdatedAt = now
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.registry.Get(def.Code); exists {
+		return nil, entity.ErrScriptedInjectorExists
+	}
+	if err := s.install(def); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, def); err != nil {
+		s.uninstall(def.Code)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "scripted injector created", slog.String("code", def.Code))
+	return def, nil
+}
+
+// Update replaces a scripted injector. If the new script does not compile or its dependencies
+// form a cycle, the old one stays registered.
+func (s *ScriptedInjectorService) Update(ctx context.Context, cmd injectableuc.SaveScriptedInjectorCommand) (*entity.ScriptedInjector, error) {
+	if !s.enabled {
+		return nil, entity.ErrScriptedInjectorsDisabled
+	}
+	existing, err := s.repo.FindByCode(ctx, cmd.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	def := scriptedInjectorFromCommand(cmd)
+	def.CreatedBy = existing.CreatedBy
+	def.CreatedAt = existing.CreatedAt
+	def.UpdatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.install(def); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, def); err != nil {
+		if restoreErr := s.install(existing); restoreErr != nil {
+			s.uninstall(def.Code)
+		}
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "scripted injector updated", slog.String("code", def.Code))
+	return def, nil
+}
+
+// Delete removes a scripted injector from the registry and the database.
+func (s *ScriptedInjectorService) Delete(ctx context.Context, code string) error {
+	if err := s.repo.Delete(ctx, code); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.uninstall(code)
+
+	slog.InfoContext(ctx, "scripted injector deleted", slog.String("code", code))
+	return nil
+}
+
+// Test runs a script against a sample input without saving it. The value must convert to the
+// data type, TEXT by default.
+func (s *ScriptedInjectorService) Test(ctx context.Context, cmd injectableuc.TestScriptCommand) (*entity.ScriptRun, error) {
+	if !s.enabled {
+		return nil, entity.ErrScriptedInjectorsDisabled
+	}
+	dataType := cmd.DataType
+	if dataType == "" {
+		dataType = entity.InjectableDataTypeText
+	}
+	check := &entity.ScriptedInjector{
+		Code:      "script_test",
+		Label:     map[string]string{"en": "Test"},
+		DataType:  dataType,
+		Source:    cmd.Source,
+		TimeoutMs: cmd.TimeoutMs,
+	}
+	if err := check.Validate(); err != nil {
+		return nil, err
+	}
+	script, err := s.runtime.Compile(cmd.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(check.TimeoutMs)*time.Millisecond)
+	defer cancel()
+
+	input := &entity.ScriptInput{
+		Payload:        cmd.Payload,
+		Resolved:       cmd.Resolved,
+		TenantCode:     cmd.TenantCode,
+		WorkspaceCode:  cmd.WorkspaceCode,
+		Environment:    cmd.Environment,
+		SelectedFormat: cmd.SelectedFormat,
+		Headers:        cmd.Headers,
+	}
+	fetch := providerFetch(s.provider, port.ResolveInjectablesRequest{
+		TenantCode:    cmd.TenantCode,
+		WorkspaceCode: cmd.WorkspaceCode,
+		Environment:   entity.Environment(cmd.Environment),
+		Headers:       cmd.Headers,
+		Payload:       cmd.Payload,
+	})
+	run, err := runScript(ctx, script, s.sem, input, fetch)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := scriptValue(run.Value, dataType); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// Start runs the sync loop in the background until Stop is called.
+func (s *ScriptedInjectorService) Start() {
+	go s.loop()
+}
+
+// Stop ends the sync loop.
+func (s *ScriptedInjectorService) Stop() {
+	s.stopOnce.Do(func() { close(s.stopCh) })
+	<-s.stopped
+}
+
+func (s *ScriptedInjectorService) loop() {
+	defer close(s.stopped)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.RunOnce(context.Background())
+		}
+	}
+}
+
+// RunOnce brings the registry in line with the database: new and changed scripts are registered
+// and deleted ones removed. Scripts that fail to compile or register are logged and skipped.
+func (s *ScriptedInjectorService) RunOnce(ctx context.Context) {
+	if !s.enabled {
+		return
+	}
+	defs, err := s.repo.FindAll(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to load scripted injectors", slog.Any("error", err))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := make(map[string]bool, len(defs))
+	for _, def := range defs {
+		stored[def.Code] = true
+		if loadedAt, ok := s.loaded[def.Code]; ok && loadedAt.Equal(def.UpdatedAt) {
+			continue
+		}
+		if err := s.install(def); err != nil {
+			slog.WarnContext(ctx, "failed to register scripted injector",
+				slog.String("code", def.Code), slog.Any("error", err))
+		}
+	}
+	for code := range s.loaded {
+		if !stored[code] {
+			s.uninstall(code)
+		}
+	}
+}
+
+// install compiles def and registers it, replacing the registered script with the same code.
+// The replaced script stays registered if def fails. The caller holds s.mu.
+func (s *ScriptedInjectorService) install(def *entity.ScriptedInjector) error {
+	script, err := s.runtime.Compile(def.Source)
+	if err != nil {
+		return err
+	}
+	injector := &scriptInjector{def: def, script: script, provider: s.provider, sem: s.sem}
+
+	previous, exists := s.registry.Get(def.Code)
+	if exists {
+		if _, isScript := previous.(*scriptInjector); !isScript {
+			return entity.ErrScriptedInjectorExists
+		}
+		s.registry.Unregister(def.Code)
+	}
+	if err := s.registry.Register(injector); err != nil {
+		if exists {
+			_ = s.registry.Register(previous)
+		}
+		if errors.Is(err, entity.ErrInjectorDependencyCycle) {
+			return fmt.Errorf("%w: %v", entity.ErrInvalidScriptedInjector, err)
+		}
+		return err
+	}
+	s.loaded[def.Code] = def.UpdatedAt
+	return nil
+}
+
+// uninstall removes the script with the code from the registry. The caller holds s.mu.
+func (s *ScriptedInjectorService) uninstall(code string) {
+	if injector, ok := s.registry.Get(code); ok {
+		if _, isScript := injector.(*scriptInjector); isScript {
+			s.registry.Unregister(code)
+		}
+	}
+	delete(s.loaded, code)
+}
+
+func scriptedInjectorFromCommand(cmd injectableuc.SaveScriptedInjectorCommand) *entity.ScriptedInjector {
+	def := &entity.ScriptedInjector{
+		Code:         cmd.Code,
+		Label:        cmd.Label,
+		Description:  cmd.Description,
+		DataType:     cmd.DataType,
+		Source:       cmd.Source,
+		Dependencies: cmd.Dependencies,
+		IsCritical:   cmd.IsCritical,
+		TimeoutMs:    cmd.TimeoutMs,
+	}
+	if def.Description == nil {
+		def.Description = map[string]string{}
+	}
+	if def.Dependencies == nil {
+		def.Dependencies = []string{}
+	}
+	if cmd.UserID != "" {
+		def.UpdatedBy = &cmd.UserID
+	}
+	return def
+}
+
+// Ensure ScriptedInjectorService implements injectableuc.ScriptedInjectorUseCase.
+var _ injectableuc.ScriptedInjectorUseCase = (*ScriptedInjectorService)(nil)
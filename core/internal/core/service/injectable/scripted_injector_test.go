@@ -0,0 +1,221 @@
+package injectable
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	injectableuc "github.com/rendis/pdf-forge/core/internal/core/usecase/injectable"
+)
+
+// scriptRuntimeStub compiles every source to a script returning the source itself, and fails on
+// "broken".
+type scriptRuntimeStub struct{}
+
+func (scriptRuntimeStub) Compile(source string) (port.CompiledScript, error) {
+	if source == "broken" {
+		return nil, entity.ErrInvalidScriptedInjector
+	}
+	return scriptStub(source), nil
+}
+
+type scriptStub string
+
+func (s scriptStub) Run(context.Context, *entity.ScriptInput, port.ScriptFetchFunc) (*entity.ScriptRun, error) {
+	return &entity.ScriptRun{Value: string(s)}, nil
+}
+
+// scriptRegistryStub implements the registry methods the scripted injector service uses.
+type scriptRegistryStub struct {
+	port.InjectorRegistry
+	injectors map[string]port.Injector
+}
+
+func (r *scriptRegistryStub) Get(code string) (port.Injector, bool) {
+	inj, ok := r.injectors[code]
+	return inj, ok
+}
+
+func (r *scriptRegistryStub) Register(inj port.Injector) error {
+	_, deps := inj.Resolve()
+	for _, dep := range deps {
+		if dep == inj.Code() {
+			return entity.ErrInjectorDependencyCycle
+		}
+	}
+	r.injectors[inj.Code()] = inj
+	return nil
+}
+
+func (r *scriptRegistryStub) Unregister(code string) bool {
+	_, ok := r.injectors[code]
+	delete(r.injectors, code)
+	return ok
+}
+
+// scriptRepoStub keeps scripted injectors in memory.
+type scriptRepoStub struct {
+	port.ScriptedInjectorRepository
+	scripts map[string]*entity.ScriptedInjector
+	fail    error
+}
+
+func (r *scriptRepoStub) FindAll(context.Context) ([]*entity.ScriptedInjector, error) {
+	var all []*entity.ScriptedInjector
+	for _, s := range r.scripts {
+		all = append(all, s)
+	}
+	return all, nil
+}
+
+func (r *scriptRepoStub) FindByCode(_ context.Context, code string) (*entity.ScriptedInjector, error) {
+	if s, ok := r.scripts[code]; ok {
+		return s, nil
+	}
+	return nil, entity.ErrScriptedInjectorNotFound
+}
+
+func (r *scriptRepoStub) Create(_ context.Context, s *entity.ScriptedInjector) error {
+	if r.fail != nil {
+		return r.fail
+	}
+	r.scripts[s.Code] = s
+	return nil
+}
+
+func (r *scriptRepoStub) Update(_ context.Context, s *entity.ScriptedInjector) error {
+	if r.fail != nil {
+		return r.fail
+	}
+	r.scripts[s.Code] = s
+	return nil
+}
+
+func newScriptedInjectorTestService(compiled ...port.Injector) (*ScriptedInjectorService, *scriptRepoStub, *scriptRegistryStub) {
+	repo := &scriptRepoStub{scripts: make(map[string]*entity.ScriptedInjector)}
+	registry := &scriptRegistryStub{injectors: make(map[string]port.Injector)}
+	for _, inj := range compiled {
+		registry.injectors[inj.Code()] = inj
+	}
+	return NewScriptedInjectorService(repo, registry, scriptRuntimeStub{}, nil, true, time.Minute, 2), repo, registry
+}
+
+func saveCommand(code, source string) injectableuc.SaveScriptedInjectorCommand {
+	return injectableuc.SaveScriptedInjectorCommand{
+		Code:     code,
+		Label:    map[string]string{"en": "Greeting"},
+		DataType: entity.InjectableDataTypeText,
+		Source:   source,
+	}
+}
+
+func resolveScript(t *testing.T, inj port.Injector) string {
+	t.Helper()
+	resolve, _ := inj.Resolve()
+	result, err := resolve(context.Background(), newChaosContext())
+	require.NoError(t, err)
+	value, _ := result.Value.String()
+	return value
+}
+
+func TestScriptedInjector_CreateRegistersTheScript(t *testing.T) {
+	s, repo, registry := newScriptedInjectorTestService(&chaosInjectorStub{code: "date_now"})
+	ctx := context.Background()
+
+	created, err := s.Create(ctx, saveCommand("greeting", "hello"))
+	require.NoError(t, err)
+	assert.Equal(t, entity.ScriptedInjectorDefaultTimeoutMs, created.TimeoutMs)
+	assert.Contains(t, repo.scripts, "greeting")
+	require.Contains(t, registry.injectors, "greeting")
+	assert.Equal(t, "hello", resolveScript(t, registry.injectors["greeting"]))
+	assert.Equal(t, "Greeting", registry.injectors["greeting"].(port.LabeledInjector).Labels()["en"])
+
+	_, err = s.Create(ctx, saveCommand("date_now", "hello"))
+	assert.ErrorIs(t, err, entity.ErrScriptedInjectorExists, "compiled injectors keep their codes")
+	_, err = s.Create(ctx, saveCommand("greeting", "hello"))
+	assert.ErrorIs(t, err, entity.ErrScriptedInjectorExists)
+
+	repo.fail = errors.New("db down")
+	_, err = s.Create(ctx, saveCommand("farewell", "bye"))
+	require.Error(t, err)
+	assert.NotContains(t, registry.injectors, "farewell", "a script that was not saved is unregistered")
+}
+
+func TestScriptedInjector_UpdateKeepsTheOldScriptOnFailure(t *testing.T) {
+	s, _, registry := newScriptedInjectorTestService()
+	ctx := context.Background()
+	_, err := s.Create(ctx, saveCommand("greeting", "hello"))
+	require.NoError(t, err)
+
+	_, err = s.Update(ctx, saveCommand("greeting", "broken"))
+	assert.ErrorIs(t, err, entity.ErrInvalidScriptedInjector)
+	cycle := saveCommand("greeting", "hi")
+	cycle.Dependencies = []string{"greeting"}
+	_, err = s.Update(ctx, cycle)
+	assert.ErrorIs(t, err, entity.ErrInvalidScriptedInjector)
+	assert.Equal(t, "hello", resolveScript(t, registry.injectors["greeting"]))
+
+	_, err = s.Update(ctx, saveCommand("greeting", "hi"))
+	require.NoError(t, err)
+	assert.Equal(t, "hi", resolveScript(t, registry.injectors["greeting"]))
+}
+
+func TestScriptedInjector_RunOnceSyncsTheRegistry(t *testing.T) {
+	s, repo, registry := newScriptedInjectorTestService()
+	ctx := context.Background()
+	_, err := s.Create(ctx, saveCommand("greeting", "hello"))
+	require.NoError(t, err)
+
+	// Another instance changes greeting and adds farewell
+	changed := *repo.scripts["greeting"]
+	changed.Source = "hi"
+	changed.UpdatedAt = changed.UpdatedAt.Add(time.Second)
+	repo.scripts["greeting"] = &changed
+	repo.scripts["farewell"] = &entity.ScriptedInjector{Code: "farewell", DataType: entity.InjectableDataTypeText, Source: "bye"}
+
+	s.RunOnce(ctx)
+	assert.Equal(t, "hi", resolveScript(t, registry.injectors["greeting"]))
+	assert.Equal(t, "bye", resolveScript(t, registry.injectors["farewell"]))
+
+	delete(repo.scripts, "greeting")
+	s.RunOnce(ctx)
+	assert.NotContains(t, registry.injectors, "greeting")
+}
+
+func TestScriptValue(t *testing.T) {
+	tests := []struct {
+		raw      any
+		dataType entity.InjectableDataType
+		want     any
+		wantErr  bool
+	}{
+		{"ok", entity.InjectableDataTypeText, "ok", false},
+		{2.5, entity.InjectableDataTypeText, "2.5", false},
+		{"12.5", entity.InjectableDataTypeNumber, 12.5, false},
+		{"twelve", entity.InjectableDataTypeNumber, nil, true},
+		{true, entity.InjectableDataTypeBoolean, true, false},
+		{"yes", entity.InjectableDataTypeBoolean, nil, true},
+		{"2026-03-01", entity.InjectableDataTypeDate, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{"March 1st", entity.InjectableDataTypeDate, nil, true},
+		{map[string]any{}, entity.InjectableDataTypeText, nil, true},
+	}
+	for _, tt := range tests {
+		value, err := scriptValue(tt.raw, tt.dataType)
+		if tt.wantErr {
+			assert.ErrorIs(t, err, entity.ErrScriptFailed, "%v as %s", tt.raw, tt.dataType)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, value.AsAny(), "%v as %s", tt.raw, tt.dataType)
+	}
+
+	value, err := scriptValue(nil, entity.InjectableDataTypeText)
+	assert.NoError(t, err)
+	assert.Nil(t, value, "None yields no value")
+}
@@ -0,0 +1,215 @@
+package injectable
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// Defaults of the WASM plugin limits.
+const (
+	DefaultPluginMaxConcurrent      = 8
+	DefaultPluginPostProcessTimeout = 30 * time.Second
+)
+
+// PluginSource is a WASM plugin to load: its module and what the operator grants it.
+type PluginSource struct {
+	Name   string
+	Module []byte
+	Grant  entity.PluginGrant
+}
+
+// PluginOptions limit the calls into the plugins. Zero values take the defaults.
+type PluginOptions struct {
+	MaxConcurrent      int           // Calls into any plugin at once
+	PostProcessTimeout time.Duration // Of a post-processor call
+}
+
+// Plugins are the loaded WASM plugins, ready to be registered as injectors and post-render hooks.
+type Plugins struct {
+	plugins   []port.Plugin
+	injectors []port.Injector
+	hooks     []port.PostRenderHook
+}
+
+// LoadPlugins loads sources in order. Injectors keep the codes of their manifest, which start with
+// the name of their plugin; post-processors run in the order of sources.
+func LoadPlugins(ctx context.Context, runtime port.PluginRuntime, sources []PluginSource, opts PluginOptions) (*Plugins, error) {
+	if opts.MaxConcurrent <= 0 {
+		opts.MaxConcurrent = DefaultPluginMaxConcurrent
+	}
+	if opts.PostProcessTimeout <= 0 {
+		opts.PostProcessTimeout = DefaultPluginPostProcessTimeout
+	}
+	sem := make(chan struct{}, opts.MaxConcurrent)
+
+	loaded := &Plugins{}
+	names := make(map[string]bool, len(sources))
+	for _, src := range sources {
+		if names[src.Name] {
+			loaded.Close(ctx)
+			return nil, fmt.Errorf("%w: plugin %q configured twice", entity.ErrInvalidPlugin, src.Name)
+		}
+		names[src.Name] = true
+
+		plugin, err := runtime.Load(ctx, src.Name, src.Module, src.Grant)
+		if err != nil {
+			loaded.Close(ctx)
+			return nil, fmt.Errorf("loading WASM plugin %q: %w", src.Name, err)
+		}
+		loaded.plugins = append(loaded.plugins, plugin)
+
+		manifest := plugin.Manifest()
+		for _, spec := range manifest.Injectors {
+			loaded.injectors = append(loaded.injectors, &pluginInjector{spec: spec, plugin: plugin, grant: src.Grant, sem: sem})
+		}
+		if manifest.PostProcess {
+			loaded.hooks = append(loaded.hooks, pluginPostProcessor(src.Name, plugin, sem, opts.PostProcessTimeout))
+		}
+		slog.InfoContext(ctx, "WASM plugin loaded",
+			slog.String("plugin", src.Name),
+			slog.Int("injectors", len(manifest.Injectors)),
+			slog.Bool("postProcess", manifest.PostProcess),
+		)
+	}
+	return loaded, nil
+}
+
+// Injectors returns the injectors of the plugins.
+func (p *Plugins) Injectors() []port.Injector { return p.injectors }
+
+// PostRenderHooks returns the post-processors of the plugins as post-render hooks.
+func (p *Plugins) PostRenderHooks() []port.PostRenderHook { return p.hooks }
+
+// Close releases the plugins.
+func (p *Plugins) Close(ctx context.Context) {
+	for _, plugin := range p.plugins {
+		if err := plugin.Close(ctx); err != nil {
+			slog.WarnContext(ctx, "failed to close WASM plugin", slog.Any("error", err))
+		}
+	}
+}
+
+// pluginInjector is an injector of a WASM plugin.
+type pluginInjector struct {
+	spec   *entity.PluginInjectorSpec
+	plugin port.Plugin
+	grant  entity.PluginGrant
+	sem    chan struct{} // Shared by all plugins, bounds concurrent calls
+}
+
+func (i *pluginInjector) Code() string { return i.spec.Code }
+
+func (i *pluginInjector) Resolve() (port.ResolveFunc, []string) {
+	return i.resolve, i.spec.Dependencies
+}
+
+func (i *pluginInjector) IsCritical() bool { return i.spec.IsCritical }
+
+func (i *pluginInjector) Timeout() time.Duration {
+	return time.Duration(i.spec.TimeoutMs) * time.Millisecond
+}
+
+func (i *pluginInjector) DataType() entity.ValueType { return i.spec.ValueType() }
+
+func (i *pluginInjector) DefaultValue() *entity.InjectableValue { return nil }
+
+func (i *pluginInjector) Formats() *entity.FormatConfig { return nil }
+
+func (i *pluginInjector) Labels() map[string]string { return i.spec.Label }
+
+func (i *pluginInjector) Descriptions() map[string]string {
+	if i.spec.Description == nil {
+		return map[string]string{}
+	}
+	return i.spec.Description
+}
+
+// resolve calls the plugin with the render context. The payload and headers are only passed with
+// the request capability.
+func (i *pluginInjector) resolve(ctx context.Context, injCtx *entity.InjectorContext) (*entity.InjectorResult, error) {
+	resolved := make(map[string]any, len(i.spec.Dependencies))
+	for _, dep := range i.spec.Dependencies {
+		if value, ok := injCtx.GetResolved(dep); ok {
+			resolved[dep] = value
+		}
+	}
+	input := &entity.PluginInjectorInput{
+		Code:           i.spec.Code,
+		Resolved:       resolved,
+		TenantCode:     injCtx.TenantCode(),
+		WorkspaceCode:  injCtx.WorkspaceCode(),
+		Environment:    string(injCtx.Environment()),
+		SelectedFormat: injCtx.SelectedFormat(i.spec.Code),
+	}
+	if i.grant.Has(entity.PluginCapabilityRequest) {
+		input.Payload = injCtx.RequestPayload()
+		input.Headers = injCtx.GetHeaders()
+	}
+
+	if err := acquire(ctx, i.sem); err != nil {
+		return nil, err
+	}
+	raw, err := i.plugin.Resolve(ctx, input)
+	<-i.sem
+	if err != nil {
+		return nil, err
+	}
+	value, err := convertValue(raw, i.spec.DataType, entity.ErrPluginFailed)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return &entity.InjectorResult{Value: *value}, nil
+}
+
+// pluginPostProcessor returns the post-render hook that passes each rendered PDF through the
+// plugin. A failure fails the render.
+func pluginPostProcessor(name string, plugin port.Plugin, sem chan struct{}, timeout time.Duration) port.PostRenderHook {
+	return func(ctx context.Context, result *port.RenderPreviewResult) error {
+		render := &entity.PluginRender{}
+		if rc := port.RenderContextFrom(ctx); rc != nil {
+			render = &entity.PluginRender{
+				Operation:     rc.Operation,
+				TenantCode:    rc.TenantCode,
+				WorkspaceCode: rc.WorkspaceCode,
+				DocumentType:  rc.DocumentType,
+				TemplateID:    rc.TemplateID,
+				VersionID:     rc.VersionID,
+				Environment:   string(rc.Environment),
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		if err := acquire(ctx, sem); err != nil {
+			return err
+		}
+		pdf, err := plugin.PostProcess(ctx, render, result.PDF)
+		<-sem
+		if err != nil {
+			return fmt.Errorf("post-processor %q: %w", name, err)
+		}
+		result.PDF = pdf
+		return nil
+	}
+}
+
+// acquire takes a slot of sem, giving up when ctx is done.
+func acquire(ctx context.Context, sem chan struct{}) error {
+	select {
+	case sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Ensure pluginInjector implements port.Injector and port.LabeledInjector.
+var (
+	_ port.Injector        = (*pluginInjector)(nil)
+	_ port.LabeledInjector = (*pluginInjector)(nil)
+)
@@ -0,0 +1,125 @@
+package injectable
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// pluginRuntimeStub loads plugins with the manifest registered by name.
+type pluginRuntimeStub struct {
+	manifests map[string]*entity.PluginManifest
+	loaded    []*pluginStub
+}
+
+func (r *pluginRuntimeStub) Load(_ context.Context, name string, _ []byte, _ entity.PluginGrant) (port.Plugin, error) {
+	manifest, ok := r.manifests[name]
+	if !ok {
+		return nil, entity.ErrInvalidPlugin
+	}
+	plugin := &pluginStub{manifest: manifest}
+	r.loaded = append(r.loaded, plugin)
+	return plugin, nil
+}
+
+// pluginStub resolves to the payload it receives and stamps PDFs with the render tenant.
+type pluginStub struct {
+	manifest *entity.PluginManifest
+	inputs   []*entity.PluginInjectorInput
+	closed   bool
+}
+
+func (p *pluginStub) Manifest() *entity.PluginManifest { return p.manifest }
+
+func (p *pluginStub) Resolve(_ context.Context, input *entity.PluginInjectorInput) (any, error) {
+	p.inputs = append(p.inputs, input)
+	if input.Payload == nil {
+		return "no payload", nil
+	}
+	return "42", nil
+}
+
+func (p *pluginStub) PostProcess(_ context.Context, render *entity.PluginRender, pdf []byte) ([]byte, error) {
+	return append(pdf, " stamped for "+render.TenantCode...), nil
+}
+
+func (p *pluginStub) Close(context.Context) error {
+	p.closed = true
+	return nil
+}
+
+func TestLoadPlugins(t *testing.T) {
+	runtime := &pluginRuntimeStub{manifests: map[string]*entity.PluginManifest{
+		"crm": {Injectors: []*entity.PluginInjectorSpec{
+			{Code: "crm_score", Label: map[string]string{"en": "Score"}, DataType: entity.InjectableDataTypeNumber, TimeoutMs: 500},
+		}},
+		"stamp": {PostProcess: true},
+	}}
+	sources := []PluginSource{
+		{Name: "crm", Grant: entity.PluginGrant{Capabilities: []entity.PluginCapability{entity.PluginCapabilityRequest}}},
+		{Name: "stamp"},
+	}
+
+	plugins, err := LoadPlugins(context.Background(), runtime, sources, PluginOptions{})
+	require.NoError(t, err)
+	require.Len(t, plugins.Injectors(), 1)
+	require.Len(t, plugins.PostRenderHooks(), 1)
+
+	inj := plugins.Injectors()[0]
+	assert.Equal(t, "crm_score", inj.Code())
+	assert.Equal(t, entity.ValueTypeNumber, inj.DataType())
+	assert.Equal(t, "Score", inj.(port.LabeledInjector).Labels()["en"])
+
+	resolve, _ := inj.Resolve()
+	injCtx := entity.NewInjectorContextWithCodes("", "tpl", "", "render", "acme", "sales", entity.EnvironmentProd,
+		map[string]string{"X-Token": "secret"}, map[string]any{"id": 1})
+	result, err := resolve(context.Background(), injCtx)
+	require.NoError(t, err)
+	assert.Equal(t, 42.0, result.Value.AsAny())
+	input := runtime.loaded[0].inputs[0]
+	assert.Equal(t, "acme", input.TenantCode)
+	assert.Equal(t, "secret", input.Headers["x-token"], "the request capability passes the headers")
+
+	ctx := port.WithRenderContext(context.Background(), &port.RenderContext{TenantCode: "acme"})
+	rendered := &port.RenderPreviewResult{PDF: []byte("%PDF")}
+	require.NoError(t, plugins.PostRenderHooks()[0](ctx, rendered))
+	assert.Equal(t, "%PDF stamped for acme", string(rendered.PDF))
+
+	plugins.Close(context.Background())
+	assert.True(t, runtime.loaded[0].closed)
+	assert.True(t, runtime.loaded[1].closed)
+}
+
+func TestLoadPlugins_RequestNotGranted(t *testing.T) {
+	runtime := &pluginRuntimeStub{manifests: map[string]*entity.PluginManifest{
+		"crm": {Injectors: []*entity.PluginInjectorSpec{
+			{Code: "crm_note", Label: map[string]string{"en": "Note"}, DataType: entity.InjectableDataTypeText},
+		}},
+	}}
+	plugins, err := LoadPlugins(context.Background(), runtime, []PluginSource{{Name: "crm"}}, PluginOptions{})
+	require.NoError(t, err)
+
+	resolve, _ := plugins.Injectors()[0].Resolve()
+	injCtx := entity.NewInjectorContext("", "tpl", "", "render", entity.EnvironmentProd,
+		map[string]string{"X-Token": "secret"}, map[string]any{"id": 1})
+	result, err := resolve(context.Background(), injCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "no payload", result.Value.AsAny())
+	assert.Nil(t, runtime.loaded[0].inputs[0].Headers)
+}
+
+func TestLoadPlugins_Errors(t *testing.T) {
+	runtime := &pluginRuntimeStub{manifests: map[string]*entity.PluginManifest{"stamp": {PostProcess: true}}}
+
+	_, err := LoadPlugins(context.Background(), runtime, []PluginSource{{Name: "stamp"}, {Name: "stamp"}}, PluginOptions{})
+	assert.ErrorIs(t, err, entity.ErrInvalidPlugin)
+	assert.True(t, runtime.loaded[0].closed, "plugins loaded before the failure are closed")
+
+	_, err = LoadPlugins(context.Background(), runtime, []PluginSource{{Name: "missing"}}, PluginOptions{})
+	assert.ErrorIs(t, err, entity.ErrInvalidPlugin)
+}
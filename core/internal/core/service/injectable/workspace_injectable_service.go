@@ -2,8 +2,10 @@ package injectable
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,18 +16,26 @@ import (
 )
 
 // NewWorkspaceInjectableService creates a new workspace injectable service.
-func NewWorkspaceInjectableService(repo port.WorkspaceInjectableRepository) injectableuc.WorkspaceInjectableUseCase {
+func NewWorkspaceInjectableService(
+	repo port.WorkspaceInjectableRepository,
+	outboxRepo port.OutboxRepository,
+	txManager port.TransactionManager,
+) injectableuc.WorkspaceInjectableUseCase {
 	return &WorkspaceInjectableService{
-		repo: repo,
+		repo:       repo,
+		outboxRepo: outboxRepo,
+		txManager:  txManager,
 	}
 }
 
 // WorkspaceInjectableService implements workspace injectable business logic.
 type WorkspaceInjectableService struct {
-	repo port.WorkspaceInjectableRepository
+	repo       port.WorkspaceInjectableRepository
+	outboxRepo port.OutboxRepository
+	txManager  port.TransactionManager
 }
 
-// CreateInjectable creates a new TEXT type injectable for the workspace.
+// CreateInjectable creates a new TEXT type injectable, or a computed one, for the workspace.
 func (s *WorkspaceInjectableService) CreateInjectable(ctx context.Context, cmd injectableuc.CreateWorkspaceInjectableCommand) (*entity.InjectableDefinition, error) {
 	// Check for duplicate key
 	exists, err := s.repo.ExistsByKey(ctx, cmd.WorkspaceID, cmd.Key)
@@ -42,9 +52,10 @@ func (s *WorkspaceInjectableService) CreateInjectable(ctx context.Context, cmd i
 		Key:          cmd.Key,
 		Label:        cmd.Label,
 		Description:  cmd.Description,
-		DataType:     entity.InjectableDataTypeText, // Only TEXT type allowed
+		DataType:     entity.InjectableDataTypeText, // Only TEXT type allowed unless computed
 		Metadata:     cmd.Metadata,
 		DefaultValue: &cmd.DefaultValue,
+		Formula:      cmd.Formula,
 		IsActive:     true,
 		IsDeleted:    false,
 		CreatedAt:    time.Now().UTC(),
@@ -54,9 +65,16 @@ func (s *WorkspaceInjectableService) CreateInjectable(ctx context.Context, cmd i
 		injectable.Metadata = make(map[string]any)
 	}
 
+	if cmd.DataType != "" {
+		injectable.DataType = cmd.DataType
+	}
+
 	if err := injectable.ValidateForWorkspace(); err != nil {
 		return nil, fmt.Errorf("validating injectable: %w", err)
 	}
+	if err := s.validateFormula(ctx, injectable); err != nil {
+		return nil, err
+	}
 
 	id, err := s.repo.Create(ctx, injectable)
 	if err != nil {
@@ -73,6 +91,36 @@ func (s *WorkspaceInjectableService) CreateInjectable(ctx context.Context, cmd i
 	return injectable, nil
 }
 
+// validateFormula checks that the formula of a computed injectable compiles and that it does not
+// depend on itself through the other computed injectables of the workspace.
+func (s *WorkspaceInjectableService) validateFormula(ctx context.Context, injectable *entity.InjectableDefinition) error {
+	if !injectable.IsComputed() {
+		return nil
+	}
+	formula, err := CompileFormula(*injectable.Formula)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(formula.Dependencies(), injectable.Key) {
+		return fmt.Errorf("%w: %s references itself", entity.ErrInvalidFormula, injectable.Key)
+	}
+
+	owned, err := s.repo.FindByWorkspaceOwned(ctx, *injectable.WorkspaceID)
+	if err != nil {
+		return fmt.Errorf("listing injectables: %w", err)
+	}
+	defs := []*entity.InjectableDefinition{injectable}
+	for _, def := range owned {
+		if def.ID != injectable.ID && def.Key != injectable.Key {
+			defs = append(defs, def)
+		}
+	}
+	if _, err := compileComputed(defs).graph().TopologicalSort(); err != nil {
+		return fmt.Errorf("%w: %w", entity.ErrInvalidFormula, err)
+	}
+	return nil
+}
+
 // GetInjectable retrieves an injectable by ID.
 func (s *WorkspaceInjectableService) GetInjectable(ctx context.Context, id, workspaceID string) (*entity.InjectableDefinition, error) {
 	injectable, err := s.repo.FindByID(ctx, id, workspaceID)
@@ -98,6 +146,10 @@ func (s *WorkspaceInjectableService) UpdateInjectable(ctx context.Context, cmd i
 		return nil, fmt.Errorf("finding injectable: %w", err)
 	}
 
+	if cmd.ExpectedRevision != nil && *cmd.ExpectedRevision != injectable.Revision {
+		return nil, &entity.RevisionConflictError{Current: injectable}
+	}
+
 	// Check for duplicate key if key changed
 	if cmd.Key != nil && *cmd.Key != injectable.Key {
 		exists, err := s.repo.ExistsByKeyExcluding(ctx, cmd.WorkspaceID, *cmd.Key, cmd.ID)
@@ -122,6 +174,15 @@ func (s *WorkspaceInjectableService) UpdateInjectable(ctx context.Context, cmd i
 	if cmd.Metadata != nil {
 		injectable.Metadata = cmd.Metadata
 	}
+	if cmd.Formula != nil {
+		injectable.Formula = cmd.Formula
+		if *cmd.Formula == "" {
+			injectable.Formula = nil
+		}
+	}
+	if cmd.DataType != nil {
+		injectable.DataType = *cmd.DataType
+	}
 
 	now := time.Now().UTC()
 	injectable.UpdatedAt = &now
@@ -129,8 +190,16 @@ func (s *WorkspaceInjectableService) UpdateInjectable(ctx context.Context, cmd i
 	if err := injectable.ValidateForWorkspace(); err != nil {
 		return nil, fmt.Errorf("validating injectable: %w", err)
 	}
+	if err := s.validateFormula(ctx, injectable); err != nil {
+		return nil, err
+	}
 
 	if err := s.repo.Update(ctx, injectable); err != nil {
+		if errors.Is(err, entity.ErrRevisionConflict) {
+			if current, findErr := s.repo.FindByID(ctx, cmd.ID, cmd.WorkspaceID); findErr == nil {
+				return nil, &entity.RevisionConflictError{Current: current}
+			}
+		}
 		return nil, fmt.Errorf("updating injectable: %w", err)
 	}
 
@@ -166,13 +235,29 @@ func (s *WorkspaceInjectableService) DeactivateInjectable(ctx context.Context, i
 }
 
 func (s *WorkspaceInjectableService) setActiveStatus(ctx context.Context, id, workspaceID string, active bool) (*entity.InjectableDefinition, error) {
-	if err := s.repo.SetActive(ctx, id, workspaceID, active); err != nil {
-		return nil, fmt.Errorf("setting injectable active status: %w", err)
-	}
+	var injectable *entity.InjectableDefinition
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.repo.FindByID(ctx, id, workspaceID)
+		if err != nil {
+			return fmt.Errorf("finding injectable: %w", err)
+		}
 
-	injectable, err := s.repo.FindByID(ctx, id, workspaceID)
+		if err := s.repo.SetActive(ctx, id, workspaceID, active); err != nil {
+			return fmt.Errorf("setting injectable active status: %w", err)
+		}
+
+		injectable, err = s.repo.FindByID(ctx, id, workspaceID)
+		if err != nil {
+			return fmt.Errorf("finding injectable: %w", err)
+		}
+
+		if current.IsActive && !active {
+			return s.appendDeactivatedEvent(ctx, injectable, workspaceID)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("finding injectable: %w", err)
+		return nil, err
 	}
 
 	action := "deactivated"
@@ -186,3 +271,20 @@ func (s *WorkspaceInjectableService) setActiveStatus(ctx context.Context, id, wo
 
 	return injectable, nil
 }
+
+// appendDeactivatedEvent records an injectable.deactivated event in the outbox.
+func (s *WorkspaceInjectableService) appendDeactivatedEvent(ctx context.Context, injectable *entity.InjectableDefinition, workspaceID string) error {
+	event, err := entity.NewOutboxEvent(entity.OutboxEventInjectableDeactivated, injectable.ID, &workspaceID, entity.InjectableDeactivated{
+		InjectableID:  injectable.ID,
+		WorkspaceID:   workspaceID,
+		Key:           injectable.Key,
+		DeactivatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.outboxRepo.Append(ctx, event); err != nil {
+		return fmt.Errorf("recording deactivation event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package injectable
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	injectableuc "github.com/rendis/pdf-forge/core/internal/core/usecase/injectable"
+)
+
+func TestUpdateInjectable_StaleRevisionConflicts(t *testing.T) {
+	repo := &fakeRevisionInjectableRepo{current: workspaceInjectable(3)}
+	s := &WorkspaceInjectableService{repo: repo}
+	label, revision := "Client name", 2
+
+	_, err := s.UpdateInjectable(context.Background(), injectableuc.UpdateWorkspaceInjectableCommand{
+		ID: "inj-1", WorkspaceID: "ws-1", Label: &label, ExpectedRevision: &revision,
+	})
+
+	var conflict *entity.RevisionConflictError
+	require.ErrorAs(t, err, &conflict)
+	require.ErrorIs(t, err, entity.ErrRevisionConflict)
+	assert.Equal(t, 3, conflict.Current.(*entity.InjectableDefinition).Revision)
+	assert.Zero(t, repo.updates, "a stale update must not be written")
+}
+
+func TestUpdateInjectable_LostRaceReturnsCurrentInjectable(t *testing.T) {
+	repo := &fakeRevisionInjectableRepo{current: workspaceInjectable(3), conflicting: true}
+	s := &WorkspaceInjectableService{repo: repo}
+	label, revision := "Client name", 3
+
+	_, err := s.UpdateInjectable(context.Background(), injectableuc.UpdateWorkspaceInjectableCommand{
+		ID: "inj-1", WorkspaceID: "ws-1", Label: &label, ExpectedRevision: &revision,
+	})
+
+	var conflict *entity.RevisionConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, 4, conflict.Current.(*entity.InjectableDefinition).Revision)
+}
+
+func TestUpdateInjectable_CurrentRevisionIsWritten(t *testing.T) {
+	repo := &fakeRevisionInjectableRepo{current: workspaceInjectable(3)}
+	s := &WorkspaceInjectableService{repo: repo}
+	label, revision := "Client name", 3
+
+	injectable, err := s.UpdateInjectable(context.Background(), injectableuc.UpdateWorkspaceInjectableCommand{
+		ID: "inj-1", WorkspaceID: "ws-1", Label: &label, ExpectedRevision: &revision,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Client name", injectable.Label)
+	assert.Equal(t, 1, repo.updates)
+}
+
+func workspaceInjectable(revision int) *entity.InjectableDefinition {
+	workspaceID := "ws-1"
+	return &entity.InjectableDefinition{
+		ID:          "inj-1",
+		WorkspaceID: &workspaceID,
+		Key:         "client_name",
+		Label:       "Client",
+		DataType:    entity.InjectableDataTypeText,
+		Revision:    revision,
+	}
+}
+
+// fakeRevisionInjectableRepo hands out copies so the service cannot mutate the stored injectable.
+// When conflicting is set, Update behaves as if another writer got there first.
+type fakeRevisionInjectableRepo struct {
+	port.WorkspaceInjectableRepository
+	current     *entity.InjectableDefinition
+	conflicting bool
+	updates     int
+}
+
+func (f *fakeRevisionInjectableRepo) FindByID(context.Context, string, string) (*entity.InjectableDefinition, error) {
+	i := *f.current
+	return &i, nil
+}
+
+func (f *fakeRevisionInjectableRepo) Update(context.Context, *entity.InjectableDefinition) error {
+	if f.conflicting {
+		f.current.Revision++
+		return entity.ErrRevisionConflict
+	}
+	f.updates++
+	return nil
+}
@@ -0,0 +1,58 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// NewChannelPublisher creates an outbox publisher that mirrors notification.created
+// events to the given notification channels.
+func NewChannelPublisher(userRepo port.UserRepository, channels []port.NotificationChannel) port.EventPublisher {
+	return &ChannelPublisher{userRepo: userRepo, channels: channels}
+}
+
+// ChannelPublisher delivers persisted notifications to external channels.
+// A failing channel makes the relay retry the event, so channels that already
+// succeeded may receive the notification again.
+type ChannelPublisher struct {
+	userRepo port.UserRepository
+	channels []port.NotificationChannel
+}
+
+// Name returns the publisher identifier.
+func (p *ChannelPublisher) Name() string {
+	return "notification-channels"
+}
+
+// Publish delivers a notification.created event to every channel.
+func (p *ChannelPublisher) Publish(ctx context.Context, event *entity.OutboxEvent) error {
+	if event.Type != entity.OutboxEventNotificationCreated || len(p.channels) == 0 {
+		return nil
+	}
+
+	var notification entity.Notification
+	if err := event.DecodePayload(&notification); err != nil {
+		return err
+	}
+
+	recipient, err := p.userRepo.FindByID(ctx, notification.UserID)
+	if err != nil {
+		if errors.Is(err, entity.ErrUserNotFound) {
+			// The recipient is gone; retrying cannot succeed
+			return nil
+		}
+		return fmt.Errorf("loading notification recipient: %w", err)
+	}
+
+	var errs []error
+	for _, ch := range p.channels {
+		if err := ch.Deliver(ctx, &notification, recipient); err != nil {
+			errs = append(errs, fmt.Errorf("channel %s: %w", ch.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,223 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	outboxsvc "github.com/rendis/pdf-forge/core/internal/core/service/outbox"
+)
+
+// EventWebhookDispatcherOptions configures the event webhook dispatcher.
+type EventWebhookDispatcherOptions struct {
+	// PollInterval is how often the dispatcher looks for due deliveries.
+	PollInterval time.Duration
+	// Lease is how long a claimed delivery stays hidden from other dispatchers.
+	// It must exceed the sender timeout.
+	Lease time.Duration
+	// BatchSize is the maximum number of deliveries claimed, and posted concurrently, per poll.
+	BatchSize int
+	// MaxAttempts is the number of posts tried before a delivery is marked failed.
+	MaxAttempts int
+	// Retention is how long finished deliveries are kept in the log. Zero keeps them forever.
+	Retention time.Duration
+}
+
+// EventWebhookDispatcher posts pending webhook deliveries and retries failed ones with backoff.
+// Several dispatchers (one per API instance) can run against the same database.
+type EventWebhookDispatcher struct {
+	webhookRepo  port.EventWebhookRepository
+	deliveryRepo port.WebhookDeliveryRepository
+	sender       port.EventWebhookSender
+	opts         EventWebhookDispatcherOptions
+	stopCh       chan struct{}
+	stopped      chan struct{}
+	stopOnce     sync.Once
+}
+
+// NewEventWebhookDispatcher creates an event webhook dispatcher. Call Start to begin polling.
+func NewEventWebhookDispatcher(
+	webhookRepo port.EventWebhookRepository,
+	deliveryRepo port.WebhookDeliveryRepository,
+	sender port.EventWebhookSender,
+	opts EventWebhookDispatcherOptions,
+) *EventWebhookDispatcher {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 2 * time.Second
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = time.Minute
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 20
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 8
+	}
+
+	return &EventWebhookDispatcher{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		sender:       sender,
+		opts:         opts,
+		stopCh:       make(chan struct{}),
+		stopped:      make(chan struct{}),
+	}
+}
+
+// Start runs the polling loop in the background until Stop is called.
+func (d *EventWebhookDispatcher) Start() {
+	go d.loop()
+}
+
+// Stop ends the polling loop and waits for the batch in flight to finish.
+func (d *EventWebhookDispatcher) Stop() {
+	d.stopOnce.Do(func() { close(d.stopCh) })
+	<-d.stopped
+}
+
+func (d *EventWebhookDispatcher) loop() {
+	defer close(d.stopped)
+	ticker := time.NewTicker(d.opts.PollInterval)
+	defer ticker.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-d.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	lastPurge := time.Time{}
+	for {
+		select {
+		case <-d.stopCh:
+			return
+		case <-ticker.C:
+			if _, err := d.RunOnce(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				slog.WarnContext(ctx, "event webhook poll failed", slog.Any("error", err))
+			}
+			if d.opts.Retention > 0 && time.Since(lastPurge) >= time.Hour {
+				d.purge(ctx)
+				lastPurge = time.Now()
+			}
+		}
+	}
+}
+
+// RunOnce claims one batch of due deliveries and posts it, returning the number delivered.
+func (d *EventWebhookDispatcher) RunOnce(ctx context.Context) (int, error) {
+	deliveries, err := d.deliveryRepo.Claim(ctx, d.opts.BatchSize, d.opts.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		delivered int
+	)
+	for _, delivery := range deliveries {
+		wg.Go(func() {
+			if d.deliver(ctx, delivery) {
+				mu.Lock()
+				delivered++
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+	return delivered, ctx.Err()
+}
+
+// deliver posts one delivery and records the outcome.
+func (d *EventWebhookDispatcher) deliver(ctx context.Context, delivery *entity.WebhookDelivery) bool {
+	webhook, err := d.webhookRepo.FindByID(ctx, delivery.WorkspaceID, delivery.WebhookID)
+	if err != nil {
+		if errors.Is(err, entity.ErrEventWebhookNotFound) {
+			d.fail(ctx, delivery, nil, "webhook deleted")
+		}
+		// Other lookup errors leave the claim to expire and be retried
+		return false
+	}
+	if !webhook.Enabled {
+		d.fail(ctx, delivery, nil, "webhook disabled")
+		return false
+	}
+
+	statusCode, sendErr := d.sender.Send(ctx, webhook, delivery)
+	if sendErr == nil {
+		if err := d.deliveryRepo.MarkDelivered(ctx, delivery.ID, statusCode); err != nil {
+			slog.WarnContext(ctx, "event webhook dispatcher could not record delivery",
+				slog.String("delivery_id", delivery.ID),
+				slog.Any("error", err),
+			)
+		}
+		return true
+	}
+	if ctx.Err() != nil {
+		// Stopping: the delivery is claimed again once its lease expires
+		return false
+	}
+
+	var code *int
+	if statusCode != 0 {
+		code = &statusCode
+	}
+	if delivery.Attempts >= d.opts.MaxAttempts {
+		d.fail(ctx, delivery, code, sendErr.Error())
+		return false
+	}
+
+	slog.WarnContext(ctx, "event webhook delivery failed, will retry",
+		slog.String("delivery_id", delivery.ID),
+		slog.String("webhook_id", delivery.WebhookID),
+		slog.String("event_type", string(delivery.EventType)),
+		slog.Int("attempts", delivery.Attempts),
+		slog.Any("error", sendErr),
+	)
+	retryAt := time.Now().Add(outboxsvc.RetryDelay(delivery.Attempts))
+	if err := d.deliveryRepo.MarkRetry(ctx, delivery.ID, code, sendErr.Error(), retryAt); err != nil {
+		slog.WarnContext(ctx, "event webhook dispatcher could not schedule retry",
+			slog.String("delivery_id", delivery.ID),
+			slog.Any("error", err),
+		)
+	}
+	return false
+}
+
+// fail gives up on a delivery.
+func (d *EventWebhookDispatcher) fail(ctx context.Context, delivery *entity.WebhookDelivery, statusCode *int, lastError string) {
+	slog.ErrorContext(ctx, "event webhook delivery failed permanently",
+		slog.String("delivery_id", delivery.ID),
+		slog.String("webhook_id", delivery.WebhookID),
+		slog.String("event_type", string(delivery.EventType)),
+		slog.Int("attempts", delivery.Attempts),
+		slog.String("error", lastError),
+	)
+	if err := d.deliveryRepo.MarkFailed(ctx, delivery.ID, statusCode, lastError); err != nil {
+		slog.WarnContext(ctx, "event webhook dispatcher could not record failure",
+			slog.String("delivery_id", delivery.ID),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// purge removes finished deliveries older than the retention window.
+func (d *EventWebhookDispatcher) purge(ctx context.Context) {
+	removed, err := d.deliveryRepo.DeleteFinishedBefore(ctx, time.Now().Add(-d.opts.Retention))
+	if err != nil {
+		slog.WarnContext(ctx, "event webhook delivery purge failed", slog.Any("error", err))
+		return
+	}
+	if removed > 0 {
+		slog.InfoContext(ctx, "event webhook delivery purge", slog.Int64("removed", removed))
+	}
+}
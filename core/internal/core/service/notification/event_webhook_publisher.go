@@ -0,0 +1,73 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// NewEventWebhookPublisher creates the publisher that queues webhook events for the
+// subscribed event webhooks of their workspace.
+func NewEventWebhookPublisher(
+	webhookRepo port.EventWebhookRepository,
+	deliveryRepo port.WebhookDeliveryRepository,
+) *EventWebhookPublisher {
+	return &EventWebhookPublisher{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+	}
+}
+
+// EventWebhookPublisher turns outbox events into pending webhook deliveries, one per subscribed
+// webhook. The dispatcher posts the deliveries afterwards.
+type EventWebhookPublisher struct {
+	webhookRepo  port.EventWebhookRepository
+	deliveryRepo port.WebhookDeliveryRepository
+}
+
+// Name returns the publisher identifier.
+func (p *EventWebhookPublisher) Name() string {
+	return "event-webhooks"
+}
+
+// Publish queues a workspace outbox event whose type event webhooks can subscribe to.
+// The outbox event ID identifies the event, so a republished event is queued once.
+func (p *EventWebhookPublisher) Publish(ctx context.Context, event *entity.OutboxEvent) error {
+	eventType := entity.DomainEventType(event.Type)
+	if !entity.IsWebhookEventType(eventType) || event.WorkspaceID == nil {
+		return nil
+	}
+
+	return p.enqueue(ctx, entity.WebhookEnvelope{
+		ID:          event.ID,
+		Type:        eventType,
+		WorkspaceID: *event.WorkspaceID,
+		OccurredAt:  event.CreatedAt,
+		Data:        event.Payload,
+	})
+}
+
+func (p *EventWebhookPublisher) enqueue(ctx context.Context, envelope entity.WebhookEnvelope) error {
+	webhooks, err := p.webhookRepo.FindEnabledByWorkspace(ctx, envelope.WorkspaceID)
+	if err != nil {
+		return fmt.Errorf("listing event webhooks: %w", err)
+	}
+
+	var deliveries []*entity.WebhookDelivery
+	for _, w := range webhooks {
+		if !w.Accepts(envelope.Type) {
+			continue
+		}
+		delivery, err := entity.NewWebhookDelivery(w.ID, envelope)
+		if err != nil {
+			return err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return p.deliveryRepo.Enqueue(ctx, deliveries)
+}
@@ -0,0 +1,244 @@
+package notification
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	notificationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/notification"
+)
+
+// maxDeliveryListLimit bounds the delivery log returned per request.
+const maxDeliveryListLimit = 200
+
+// NewEventWebhookService creates a new workspace event webhook service.
+func NewEventWebhookService(
+	webhookRepo port.EventWebhookRepository,
+	deliveryRepo port.WebhookDeliveryRepository,
+	sender port.EventWebhookSender,
+) notificationuc.EventWebhookUseCase {
+	return &EventWebhookService{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		sender:       sender,
+	}
+}
+
+// EventWebhookService implements event webhook management and the delivery log.
+type EventWebhookService struct {
+	webhookRepo  port.EventWebhookRepository
+	deliveryRepo port.WebhookDeliveryRepository
+	sender       port.EventWebhookSender
+}
+
+// ListWebhooks lists all event webhooks of a workspace.
+func (s *EventWebhookService) ListWebhooks(ctx context.Context, workspaceID string) ([]*entity.EventWebhook, error) {
+	webhooks, err := s.webhookRepo.FindByWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("listing event webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// GetWebhook retrieves an event webhook by ID within a workspace.
+func (s *EventWebhookService) GetWebhook(ctx context.Context, workspaceID, id string) (*entity.EventWebhook, error) {
+	webhook, err := s.webhookRepo.FindByID(ctx, workspaceID, id)
+	if err != nil {
+		return nil, fmt.Errorf("finding event webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+// CreateWebhook creates a new event webhook with a generated signing secret.
+func (s *EventWebhookService) CreateWebhook(ctx context.Context, cmd notificationuc.CreateEventWebhookCommand) (*entity.EventWebhook, error) {
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := entity.NewEventWebhook(cmd.WorkspaceID, cmd.Name, cmd.URL, secret, cmd.EventTypes, &cmd.CreatedBy)
+	webhook.ID = uuid.NewString()
+
+	if err := webhook.Validate(); err != nil {
+		return nil, err
+	}
+
+	id, err := s.webhookRepo.Create(ctx, webhook)
+	if err != nil {
+		return nil, fmt.Errorf("creating event webhook: %w", err)
+	}
+	webhook.ID = id
+
+	slog.InfoContext(ctx, "event webhook created",
+		slog.String("webhook_id", id),
+		slog.String("workspace_id", cmd.WorkspaceID),
+	)
+	return webhook, nil
+}
+
+// UpdateWebhook updates an event webhook.
+func (s *EventWebhookService) UpdateWebhook(ctx context.Context, cmd notificationuc.UpdateEventWebhookCommand) (*entity.EventWebhook, error) {
+	webhook, err := s.webhookRepo.FindByID(ctx, cmd.WorkspaceID, cmd.ID)
+	if err != nil {
+		return nil, fmt.Errorf("finding event webhook: %w", err)
+	}
+
+	now := time.Now().UTC()
+	webhook.Name = cmd.Name
+	webhook.URL = cmd.URL
+	webhook.EventTypes = cmd.EventTypes
+	webhook.Enabled = cmd.Enabled
+	webhook.UpdatedAt = &now
+	if cmd.RotateSecret {
+		if webhook.Secret, err = generateWebhookSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := webhook.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.webhookRepo.Update(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("updating event webhook: %w", err)
+	}
+
+	slog.InfoContext(ctx, "event webhook updated",
+		slog.String("webhook_id", webhook.ID),
+		slog.Bool("secret_rotated", cmd.RotateSecret),
+	)
+	return webhook, nil
+}
+
+// RotateSecrets replaces the signing secret of every event webhook of a workspace, one at a time.
+// The new secrets are only returned here, so the webhooks rotated before a failure are returned with it.
+func (s *EventWebhookService) RotateSecrets(ctx context.Context, workspaceID string) ([]*entity.EventWebhook, error) {
+	webhooks, err := s.webhookRepo.FindByWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("listing event webhooks: %w", err)
+	}
+
+	rotated := make([]*entity.EventWebhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		if webhook.Secret, err = generateWebhookSecret(); err != nil {
+			return rotated, err
+		}
+		now := time.Now().UTC()
+		webhook.UpdatedAt = &now
+		if err := s.webhookRepo.Update(ctx, webhook); err != nil {
+			return rotated, fmt.Errorf("updating event webhook %s: %w", webhook.ID, err)
+		}
+		rotated = append(rotated, webhook)
+	}
+
+	slog.InfoContext(ctx, "event webhook secrets rotated",
+		slog.String("workspace_id", workspaceID),
+		slog.Int("webhooks", len(rotated)),
+	)
+	return rotated, nil
+}
+
+// DeleteWebhook deletes an event webhook and its delivery log.
+func (s *EventWebhookService) DeleteWebhook(ctx context.Context, workspaceID, id string) error {
+	if err := s.webhookRepo.Delete(ctx, workspaceID, id); err != nil {
+		return fmt.Errorf("deleting event webhook: %w", err)
+	}
+
+	slog.InfoContext(ctx, "event webhook deleted", slog.String("webhook_id", id))
+	return nil
+}
+
+// TestWebhook posts a signed webhook.ping payload and reports the response.
+// The ping is not recorded in the delivery log.
+func (s *EventWebhookService) TestWebhook(ctx context.Context, workspaceID, id string) (*notificationuc.EventWebhookTestResult, error) {
+	webhook, err := s.webhookRepo.FindByID(ctx, workspaceID, id)
+	if err != nil {
+		return nil, fmt.Errorf("finding event webhook: %w", err)
+	}
+
+	delivery, err := entity.NewWebhookDelivery(webhook.ID, entity.WebhookEnvelope{
+		ID:          uuid.NewString(),
+		Type:        entity.EventWebhookPing,
+		WorkspaceID: workspaceID,
+		OccurredAt:  time.Now().UTC(),
+		Data:        map[string]string{"webhookId": webhook.ID, "name": webhook.Name},
+	})
+	if err != nil {
+		return nil, err
+	}
+	delivery.ID = delivery.EventID
+
+	result := &notificationuc.EventWebhookTestResult{}
+	result.StatusCode, err = s.sender.Send(ctx, webhook, delivery)
+	if err != nil {
+		msg := err.Error()
+		result.Error = &msg
+	}
+	return result, nil
+}
+
+// ListDeliveries lists the deliveries of an event webhook, newest first.
+func (s *EventWebhookService) ListDeliveries(
+	ctx context.Context,
+	workspaceID, webhookID string,
+	filter entity.WebhookDeliveryFilter,
+) ([]*entity.WebhookDelivery, error) {
+	if _, err := s.webhookRepo.FindByID(ctx, workspaceID, webhookID); err != nil {
+		return nil, fmt.Errorf("finding event webhook: %w", err)
+	}
+	if filter.Limit <= 0 || filter.Limit > maxDeliveryListLimit {
+		filter.Limit = maxDeliveryListLimit
+	}
+
+	deliveries, err := s.deliveryRepo.FindByWebhook(ctx, webhookID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("listing webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// GetDelivery retrieves a delivery of an event webhook.
+func (s *EventWebhookService) GetDelivery(ctx context.Context, workspaceID, webhookID, id string) (*entity.WebhookDelivery, error) {
+	if _, err := s.webhookRepo.FindByID(ctx, workspaceID, webhookID); err != nil {
+		return nil, fmt.Errorf("finding event webhook: %w", err)
+	}
+
+	delivery, err := s.deliveryRepo.FindByID(ctx, webhookID, id)
+	if err != nil {
+		return nil, fmt.Errorf("finding webhook delivery: %w", err)
+	}
+	return delivery, nil
+}
+
+// RedeliverDelivery queues a delivery again with its attempts reset.
+func (s *EventWebhookService) RedeliverDelivery(ctx context.Context, workspaceID, webhookID, id string) error {
+	if _, err := s.webhookRepo.FindByID(ctx, workspaceID, webhookID); err != nil {
+		return fmt.Errorf("finding event webhook: %w", err)
+	}
+
+	if err := s.deliveryRepo.Reschedule(ctx, webhookID, id); err != nil {
+		return fmt.Errorf("rescheduling webhook delivery: %w", err)
+	}
+
+	slog.InfoContext(ctx, "webhook delivery rescheduled",
+		slog.String("webhook_id", webhookID),
+		slog.String("delivery_id", id),
+	)
+	return nil
+}
+
+// generateWebhookSecret returns a random signing secret prefixed so it is recognizable in configs.
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generating webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,268 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+func TestEventWebhookPublisher_PublishQueuesSubscribedWebhooks(t *testing.T) {
+	webhooks := &fakeEventWebhookRepo{webhooks: []*entity.EventWebhook{
+		{ID: "wh-all", WorkspaceID: "ws-1", Enabled: true},
+		{ID: "wh-published", WorkspaceID: "ws-1", Enabled: true, EventTypes: []entity.DomainEventType{entity.EventVersionPublished}},
+		{ID: "wh-renders", WorkspaceID: "ws-1", Enabled: true, EventTypes: []entity.DomainEventType{entity.EventRenderFailed}},
+	}}
+	deliveries := &fakeDeliveryRepo{}
+	publisher := NewEventWebhookPublisher(webhooks, deliveries)
+
+	workspaceID := "ws-1"
+	err := publisher.Publish(context.Background(), &entity.OutboxEvent{
+		ID:          "evt-1",
+		Type:        entity.OutboxEventVersionPublished,
+		WorkspaceID: &workspaceID,
+		Payload:     json.RawMessage(`{"versionId":"v-1"}`),
+	})
+	require.NoError(t, err)
+
+	require.Len(t, deliveries.enqueued, 2)
+	assert.Equal(t, "wh-all", deliveries.enqueued[0].WebhookID)
+	assert.Equal(t, "wh-published", deliveries.enqueued[1].WebhookID)
+
+	var envelope map[string]any
+	require.NoError(t, json.Unmarshal(deliveries.enqueued[0].Payload, &envelope))
+	assert.Equal(t, "evt-1", envelope["id"])
+	assert.Equal(t, "version.published", envelope["type"])
+	assert.Equal(t, map[string]any{"versionId": "v-1"}, envelope["data"])
+}
+
+func TestEventWebhookPublisher_PublishIgnoresOtherEvents(t *testing.T) {
+	webhooks := &fakeEventWebhookRepo{webhooks: []*entity.EventWebhook{{ID: "wh-all", WorkspaceID: "ws-1", Enabled: true}}}
+	deliveries := &fakeDeliveryRepo{}
+	publisher := NewEventWebhookPublisher(webhooks, deliveries)
+
+	workspaceID := "ws-1"
+	require.NoError(t, publisher.Publish(context.Background(), &entity.OutboxEvent{
+		ID: "evt-1", Type: entity.OutboxEventMemberInvited, WorkspaceID: &workspaceID,
+	}))
+	require.NoError(t, publisher.Publish(context.Background(), &entity.OutboxEvent{
+		ID: "evt-2", Type: entity.OutboxEventVersionPublished,
+	}))
+
+	assert.Empty(t, deliveries.enqueued)
+}
+
+func TestEventWebhookPublisher_PublishQueuesRenderEvents(t *testing.T) {
+	webhooks := &fakeEventWebhookRepo{webhooks: []*entity.EventWebhook{
+		{ID: "wh-renders", WorkspaceID: "ws-owner", Enabled: true, EventTypes: []entity.DomainEventType{entity.EventRenderFailed}},
+	}}
+	deliveries := &fakeDeliveryRepo{}
+	publisher := NewEventWebhookPublisher(webhooks, deliveries)
+
+	workspaceID := "ws-owner"
+	event, err := entity.NewOutboxEvent(entity.OutboxEventRenderFailed, "v-1", &workspaceID, entity.RenderFailed{
+		VersionID:  "v-1",
+		TemplateID: "tpl-1",
+		Error:      "typst: compile error",
+		FailedAt:   time.Now(),
+	})
+	require.NoError(t, err)
+	event.ID = "evt-1"
+	require.NoError(t, publisher.Publish(context.Background(), event))
+
+	require.Len(t, deliveries.enqueued, 1)
+	assert.Equal(t, "ws-owner", deliveries.enqueued[0].WorkspaceID)
+	assert.Equal(t, entity.EventRenderFailed, deliveries.enqueued[0].EventType)
+	assert.Equal(t, "evt-1", deliveries.enqueued[0].EventID, "a republished render event is queued once")
+}
+
+func TestEventWebhookService_RotateSecretsOfWorkspace(t *testing.T) {
+	webhooks := &fakeEventWebhookRepo{webhooks: []*entity.EventWebhook{
+		{ID: "wh-1", WorkspaceID: "ws-1", Secret: "whsec_old"},
+		{ID: "wh-2", WorkspaceID: "ws-1", Secret: "whsec_old"},
+		{ID: "wh-3", WorkspaceID: "ws-2", Secret: "whsec_old"},
+	}}
+	svc := NewEventWebhookService(webhooks, &fakeDeliveryRepo{}, nil)
+
+	rotated, err := svc.RotateSecrets(context.Background(), "ws-1")
+	require.NoError(t, err)
+
+	require.Len(t, rotated, 2)
+	assert.NotEqual(t, rotated[0].Secret, rotated[1].Secret)
+	for _, w := range rotated {
+		assert.True(t, strings.HasPrefix(w.Secret, "whsec_"))
+		assert.NotEqual(t, "whsec_old", w.Secret)
+		assert.NotNil(t, w.UpdatedAt)
+	}
+	assert.Equal(t, "whsec_old", webhooks.webhooks[2].Secret, "webhooks of other workspaces keep their secret")
+}
+
+func TestEventWebhookDispatcher_RunOnceMarksDelivered(t *testing.T) {
+	webhooks := &fakeEventWebhookRepo{webhooks: []*entity.EventWebhook{{ID: "wh-1", WorkspaceID: "ws-1", Enabled: true}}}
+	deliveries := &fakeDeliveryRepo{batch: []*entity.WebhookDelivery{{ID: "d-1", WebhookID: "wh-1", WorkspaceID: "ws-1", Attempts: 1}}}
+	dispatcher := NewEventWebhookDispatcher(webhooks, deliveries, &fakeEventWebhookSender{status: 204}, EventWebhookDispatcherOptions{})
+
+	delivered, err := dispatcher.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, []string{"d-1"}, deliveries.delivered)
+}
+
+func TestEventWebhookDispatcher_RunOnceSchedulesRetry(t *testing.T) {
+	webhooks := &fakeEventWebhookRepo{webhooks: []*entity.EventWebhook{{ID: "wh-1", WorkspaceID: "ws-1", Enabled: true}}}
+	deliveries := &fakeDeliveryRepo{batch: []*entity.WebhookDelivery{{ID: "d-1", WebhookID: "wh-1", WorkspaceID: "ws-1", Attempts: 2}}}
+	sender := &fakeEventWebhookSender{status: 503, err: errors.New("webhook responded with status 503")}
+	dispatcher := NewEventWebhookDispatcher(webhooks, deliveries, sender, EventWebhookDispatcherOptions{MaxAttempts: 3})
+
+	delivered, err := dispatcher.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+	assert.Equal(t, []string{"d-1"}, deliveries.retried)
+	require.NotNil(t, deliveries.lastStatusCode)
+	assert.Equal(t, 503, *deliveries.lastStatusCode)
+}
+
+func TestEventWebhookDispatcher_RunOnceFailsAfterMaxAttempts(t *testing.T) {
+	webhooks := &fakeEventWebhookRepo{webhooks: []*entity.EventWebhook{{ID: "wh-1", WorkspaceID: "ws-1", Enabled: true}}}
+	deliveries := &fakeDeliveryRepo{batch: []*entity.WebhookDelivery{{ID: "d-1", WebhookID: "wh-1", WorkspaceID: "ws-1", Attempts: 3}}}
+	sender := &fakeEventWebhookSender{err: errors.New("posting webhook: connection refused")}
+	dispatcher := NewEventWebhookDispatcher(webhooks, deliveries, sender, EventWebhookDispatcherOptions{MaxAttempts: 3})
+
+	_, err := dispatcher.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, deliveries.retried)
+	assert.Equal(t, []string{"d-1"}, deliveries.failed)
+	assert.Nil(t, deliveries.lastStatusCode)
+}
+
+func TestEventWebhookDispatcher_RunOnceFailsDisabledWebhook(t *testing.T) {
+	webhooks := &fakeEventWebhookRepo{webhooks: []*entity.EventWebhook{{ID: "wh-1", WorkspaceID: "ws-1"}}}
+	deliveries := &fakeDeliveryRepo{batch: []*entity.WebhookDelivery{{ID: "d-1", WebhookID: "wh-1", WorkspaceID: "ws-1", Attempts: 1}}}
+	sender := &fakeEventWebhookSender{status: 200}
+	dispatcher := NewEventWebhookDispatcher(webhooks, deliveries, sender, EventWebhookDispatcherOptions{})
+
+	_, err := dispatcher.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"d-1"}, deliveries.failed)
+	assert.Zero(t, sender.calls)
+}
+
+type fakeEventWebhookRepo struct {
+	webhooks []*entity.EventWebhook
+}
+
+func (f *fakeEventWebhookRepo) Create(context.Context, *entity.EventWebhook) (string, error) {
+	return "", nil
+}
+
+func (f *fakeEventWebhookRepo) FindByID(_ context.Context, workspaceID, id string) (*entity.EventWebhook, error) {
+	for _, w := range f.webhooks {
+		if w.WorkspaceID == workspaceID && w.ID == id {
+			return w, nil
+		}
+	}
+	return nil, entity.ErrEventWebhookNotFound
+}
+
+func (f *fakeEventWebhookRepo) FindByWorkspace(_ context.Context, workspaceID string) ([]*entity.EventWebhook, error) {
+	var result []*entity.EventWebhook
+	for _, w := range f.webhooks {
+		if w.WorkspaceID == workspaceID {
+			result = append(result, w)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeEventWebhookRepo) FindEnabledByWorkspace(ctx context.Context, workspaceID string) ([]*entity.EventWebhook, error) {
+	all, _ := f.FindByWorkspace(ctx, workspaceID)
+	var result []*entity.EventWebhook
+	for _, w := range all {
+		if w.Enabled {
+			result = append(result, w)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeEventWebhookRepo) Update(context.Context, *entity.EventWebhook) error { return nil }
+
+func (f *fakeEventWebhookRepo) Delete(context.Context, string, string) error { return nil }
+
+type fakeDeliveryRepo struct {
+	mu             sync.Mutex
+	batch          []*entity.WebhookDelivery
+	enqueued       []*entity.WebhookDelivery
+	delivered      []string
+	retried        []string
+	failed         []string
+	lastStatusCode *int
+}
+
+func (f *fakeDeliveryRepo) Enqueue(_ context.Context, deliveries []*entity.WebhookDelivery) error {
+	f.enqueued = append(f.enqueued, deliveries...)
+	return nil
+}
+
+func (f *fakeDeliveryRepo) Claim(context.Context, int, time.Duration) ([]*entity.WebhookDelivery, error) {
+	return f.batch, nil
+}
+
+func (f *fakeDeliveryRepo) MarkDelivered(_ context.Context, id string, _ int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.delivered = append(f.delivered, id)
+	return nil
+}
+
+func (f *fakeDeliveryRepo) MarkRetry(_ context.Context, id string, statusCode *int, _ string, _ time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.retried = append(f.retried, id)
+	f.lastStatusCode = statusCode
+	return nil
+}
+
+func (f *fakeDeliveryRepo) MarkFailed(_ context.Context, id string, statusCode *int, _ string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failed = append(f.failed, id)
+	f.lastStatusCode = statusCode
+	return nil
+}
+
+func (f *fakeDeliveryRepo) FindByID(context.Context, string, string) (*entity.WebhookDelivery, error) {
+	return nil, entity.ErrWebhookDeliveryNotFound
+}
+
+func (f *fakeDeliveryRepo) FindByWebhook(context.Context, string, entity.WebhookDeliveryFilter) ([]*entity.WebhookDelivery, error) {
+	return nil, nil
+}
+
+func (f *fakeDeliveryRepo) Reschedule(context.Context, string, string) error { return nil }
+
+func (f *fakeDeliveryRepo) DeleteFinishedBefore(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+type fakeEventWebhookSender struct {
+	mu     sync.Mutex
+	status int
+	err    error
+	calls  int
+}
+
+func (f *fakeEventWebhookSender) Send(context.Context, *entity.EventWebhook, *entity.WebhookDelivery) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	return f.status, f.err
+}
@@ -0,0 +1,143 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	notificationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/notification"
+)
+
+// NewNotificationService creates a new notification service.
+// Each persisted notification is also appended to the outbox in the same transaction;
+// the outbox relay mirrors it to external channels through ChannelPublisher.
+func NewNotificationService(
+	notificationRepo port.NotificationRepository,
+	userRepo port.UserRepository,
+	preferencesRepo port.UserPreferencesRepository,
+	outboxRepo port.OutboxRepository,
+	txManager port.TransactionManager,
+) notificationuc.NotificationUseCase {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		preferencesRepo:  preferencesRepo,
+		outboxRepo:       outboxRepo,
+		txManager:        txManager,
+	}
+}
+
+// NotificationService implements in-product notification business logic.
+type NotificationService struct {
+	notificationRepo port.NotificationRepository
+	userRepo         port.UserRepository
+	preferencesRepo  port.UserPreferencesRepository
+	outboxRepo       port.OutboxRepository
+	txManager        port.TransactionManager
+}
+
+// Notify persists a notification and mirrors it to registered channels.
+func (s *NotificationService) Notify(ctx context.Context, cmd notificationuc.NotifyCommand) error {
+	notification := entity.NewNotification(cmd.UserID, cmd.Type, cmd.Title, s.formatMessage(ctx, cmd))
+	notification.ID = uuid.NewString()
+	notification.WorkspaceID = cmd.WorkspaceID
+	notification.ResourceID = cmd.ResourceID
+
+	if err := notification.Validate(); err != nil {
+		return fmt.Errorf("validating notification: %w", err)
+	}
+
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		id, err := s.notificationRepo.Create(ctx, notification)
+		if err != nil {
+			return fmt.Errorf("creating notification: %w", err)
+		}
+		notification.ID = id
+
+		event, err := entity.NewOutboxEvent(entity.OutboxEventNotificationCreated, id, notification.WorkspaceID, notification)
+		if err != nil {
+			return err
+		}
+		if err := s.outboxRepo.Append(ctx, event); err != nil {
+			return fmt.Errorf("recording notification event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.DebugContext(ctx, "notification created",
+		slog.String("notification_id", notification.ID),
+		slog.String("user_id", cmd.UserID),
+		slog.String("type", string(cmd.Type)),
+	)
+	return nil
+}
+
+// ListNotifications lists a user's notifications with pagination.
+func (s *NotificationService) ListNotifications(ctx context.Context, userID string, filters port.NotificationFilters) ([]*entity.Notification, int64, error) {
+	notifications, total, err := s.notificationRepo.FindByUser(ctx, userID, filters)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing notifications: %w", err)
+	}
+	return notifications, total, nil
+}
+
+// CountUnread returns the number of unread notifications for a user.
+func (s *NotificationService) CountUnread(ctx context.Context, userID string) (int64, error) {
+	count, err := s.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("counting unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// MarkRead marks a single notification as read.
+func (s *NotificationService) MarkRead(ctx context.Context, id, userID string) error {
+	if err := s.notificationRepo.MarkRead(ctx, id, userID); err != nil {
+		return fmt.Errorf("marking notification read: %w", err)
+	}
+	return nil
+}
+
+// MarkAllRead marks all of a user's notifications as read.
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID string) error {
+	if err := s.notificationRepo.MarkAllRead(ctx, userID); err != nil {
+		return fmt.Errorf("marking all notifications read: %w", err)
+	}
+	return nil
+}
+
+// formatMessage appends the command's time, rendered for the recipient, to the message.
+func (s *NotificationService) formatMessage(ctx context.Context, cmd notificationuc.NotifyCommand) string {
+	if cmd.Time == nil {
+		return cmd.Message
+	}
+
+	prefs := entity.NewUserPreferences(cmd.UserID)
+	if s.preferencesRepo != nil {
+		saved, err := s.preferencesRepo.FindByUserID(ctx, cmd.UserID)
+		switch {
+		case err == nil:
+			prefs = saved
+		case !errors.Is(err, entity.ErrUserPreferencesNotFound):
+			slog.WarnContext(ctx, "failed to load notification recipient preferences",
+				slog.String("user_id", cmd.UserID),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	formatted := prefs.FormatTime(*cmd.Time)
+	if cmd.TimeLabel != "" {
+		formatted = cmd.TimeLabel + ": " + formatted
+	}
+	return strings.TrimSpace(cmd.Message + "\n" + formatted)
+}
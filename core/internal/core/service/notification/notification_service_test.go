@@ -0,0 +1,114 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	notificationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/notification"
+)
+
+func TestNotificationService_NotifyRecordsNotificationAndEventInTx(t *testing.T) {
+	repo := &fakeNotificationRepo{}
+	outbox := &fakeNotificationOutbox{}
+	svc := NewNotificationService(repo, nil, nil, outbox, fakeNotificationTx{})
+	workspaceID, versionID := "ws-1", "v-1"
+	failedAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	err := svc.Notify(context.Background(), notificationuc.NotifyCommand{
+		UserID:      "user-1",
+		WorkspaceID: &workspaceID,
+		Type:        entity.NotificationTypeRenderFailed,
+		Title:       `Rendering "Invoice" failed in prod`,
+		Message:     "typst compile failed",
+		ResourceID:  &versionID,
+		Time:        &failedAt,
+		TimeLabel:   "Failed at",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, repo.created, 1)
+	created := repo.created[0]
+	assert.True(t, repo.inTx, "the notification is created inside the transaction")
+	assert.Equal(t, "user-1", created.UserID)
+	assert.Equal(t, entity.NotificationTypeRenderFailed, created.Type)
+	assert.Equal(t, &versionID, created.ResourceID)
+	assert.Contains(t, created.Message, "typst compile failed\nFailed at: ")
+
+	require.Len(t, outbox.events, 1)
+	event := outbox.events[0]
+	assert.True(t, outbox.inTx, "the outbox event is appended inside the transaction")
+	assert.Equal(t, entity.OutboxEventNotificationCreated, event.Type)
+	assert.Equal(t, "n-1", event.AggregateID)
+	assert.Equal(t, &workspaceID, event.WorkspaceID)
+	var payload entity.Notification
+	require.NoError(t, event.DecodePayload(&payload))
+	assert.Equal(t, "n-1", payload.ID)
+	assert.Equal(t, entity.NotificationTypeRenderFailed, payload.Type)
+}
+
+func TestNotificationService_NotifyFailsWhenEventIsNotRecorded(t *testing.T) {
+	outbox := &fakeNotificationOutbox{err: errors.New("connection refused")}
+	svc := NewNotificationService(&fakeNotificationRepo{}, nil, nil, outbox, fakeNotificationTx{})
+
+	err := svc.Notify(context.Background(), notificationuc.NotifyCommand{
+		UserID: "user-1", Type: entity.NotificationTypeRenderFailed, Title: "Rendering failed",
+	})
+	assert.ErrorContains(t, err, "recording notification event")
+}
+
+func TestNotificationService_NotifyRejectsInvalidNotification(t *testing.T) {
+	repo := &fakeNotificationRepo{}
+	svc := NewNotificationService(repo, nil, nil, &fakeNotificationOutbox{}, fakeNotificationTx{})
+
+	err := svc.Notify(context.Background(), notificationuc.NotifyCommand{UserID: "user-1", Type: "UNKNOWN", Title: "Hi"})
+	assert.ErrorIs(t, err, entity.ErrInvalidNotificationType)
+	assert.Empty(t, repo.created)
+}
+
+type notificationTxKey struct{}
+
+type fakeNotificationTx struct{}
+
+func (fakeNotificationTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, notificationTxKey{}, true))
+}
+
+func inNotificationTx(ctx context.Context) bool {
+	in, _ := ctx.Value(notificationTxKey{}).(bool)
+	return in
+}
+
+type fakeNotificationRepo struct {
+	port.NotificationRepository
+	created []*entity.Notification
+	inTx    bool
+}
+
+func (f *fakeNotificationRepo) Create(ctx context.Context, n *entity.Notification) (string, error) {
+	f.created = append(f.created, n)
+	f.inTx = inNotificationTx(ctx)
+	return "n-1", nil
+}
+
+type fakeNotificationOutbox struct {
+	port.OutboxRepository
+	err    error
+	events []*entity.OutboxEvent
+	inTx   bool
+}
+
+func (f *fakeNotificationOutbox) Append(ctx context.Context, event *entity.OutboxEvent) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.events = append(f.events, event)
+	f.inTx = inNotificationTx(ctx)
+	return nil
+}
@@ -0,0 +1,134 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	notificationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/notification"
+)
+
+// NewNotificationWebhookService creates a new workspace notification webhook service.
+func NewNotificationWebhookService(
+	webhookRepo port.NotificationWebhookRepository,
+	senders map[entity.WebhookProvider]port.ChatWebhookSender,
+) notificationuc.NotificationWebhookUseCase {
+	return &NotificationWebhookService{
+		webhookRepo: webhookRepo,
+		senders:     senders,
+	}
+}
+
+// NotificationWebhookService implements workspace webhook management.
+type NotificationWebhookService struct {
+	webhookRepo port.NotificationWebhookRepository
+	senders     map[entity.WebhookProvider]port.ChatWebhookSender
+}
+
+// ListWebhooks lists all webhooks of a workspace.
+func (s *NotificationWebhookService) ListWebhooks(ctx context.Context, workspaceID string) ([]*entity.NotificationWebhook, error) {
+	webhooks, err := s.webhookRepo.FindByWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("listing notification webhooks: %w", err)
+	}
+	return webhooks, nil
+}
+
+// GetWebhook retrieves a webhook by ID within a workspace.
+func (s *NotificationWebhookService) GetWebhook(ctx context.Context, workspaceID, id string) (*entity.NotificationWebhook, error) {
+	webhook, err := s.webhookRepo.FindByID(ctx, workspaceID, id)
+	if err != nil {
+		return nil, fmt.Errorf("finding notification webhook: %w", err)
+	}
+	return webhook, nil
+}
+
+// CreateWebhook creates a new webhook.
+func (s *NotificationWebhookService) CreateWebhook(ctx context.Context, cmd notificationuc.CreateWebhookCommand) (*entity.NotificationWebhook, error) {
+	webhook := entity.NewNotificationWebhook(cmd.WorkspaceID, cmd.Provider, cmd.Name, cmd.URL, cmd.EventTypes, &cmd.CreatedBy)
+	webhook.ID = uuid.NewString()
+
+	if err := webhook.Validate(); err != nil {
+		return nil, err
+	}
+
+	id, err := s.webhookRepo.Create(ctx, webhook)
+	if err != nil {
+		return nil, fmt.Errorf("creating notification webhook: %w", err)
+	}
+	webhook.ID = id
+
+	slog.InfoContext(ctx, "notification webhook created",
+		slog.String("webhook_id", id),
+		slog.String("workspace_id", cmd.WorkspaceID),
+		slog.String("provider", string(cmd.Provider)),
+	)
+	return webhook, nil
+}
+
+// UpdateWebhook updates a webhook.
+func (s *NotificationWebhookService) UpdateWebhook(ctx context.Context, cmd notificationuc.UpdateWebhookCommand) (*entity.NotificationWebhook, error) {
+	webhook, err := s.webhookRepo.FindByID(ctx, cmd.WorkspaceID, cmd.ID)
+	if err != nil {
+		return nil, fmt.Errorf("finding notification webhook: %w", err)
+	}
+
+	now := time.Now().UTC()
+	webhook.Name = cmd.Name
+	webhook.EventTypes = cmd.EventTypes
+	webhook.Enabled = cmd.Enabled
+	webhook.UpdatedAt = &now
+	if cmd.URL != nil {
+		webhook.URL = *cmd.URL
+	}
+
+	if err := webhook.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.webhookRepo.Update(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("updating notification webhook: %w", err)
+	}
+
+	slog.InfoContext(ctx, "notification webhook updated", slog.String("webhook_id", webhook.ID))
+	return webhook, nil
+}
+
+// DeleteWebhook deletes a webhook.
+func (s *NotificationWebhookService) DeleteWebhook(ctx context.Context, workspaceID, id string) error {
+	if err := s.webhookRepo.Delete(ctx, workspaceID, id); err != nil {
+		return fmt.Errorf("deleting notification webhook: %w", err)
+	}
+
+	slog.InfoContext(ctx, "notification webhook deleted", slog.String("webhook_id", id))
+	return nil
+}
+
+// TestWebhook sends a sample notification through the webhook.
+func (s *NotificationWebhookService) TestWebhook(ctx context.Context, workspaceID, id string) error {
+	webhook, err := s.webhookRepo.FindByID(ctx, workspaceID, id)
+	if err != nil {
+		return fmt.Errorf("finding notification webhook: %w", err)
+	}
+
+	sender, ok := s.senders[webhook.Provider]
+	if !ok {
+		return entity.ErrInvalidWebhookProvider
+	}
+
+	sample := &entity.Notification{
+		WorkspaceID: &workspaceID,
+		Title:       "pdf-forge test notification",
+		Message:     fmt.Sprintf("Webhook %q is configured correctly.", webhook.Name),
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err := sender.Send(ctx, webhook.URL, sample); err != nil {
+		return fmt.Errorf("%w: %w", entity.ErrWebhookDeliveryFailed, err)
+	}
+	return nil
+}
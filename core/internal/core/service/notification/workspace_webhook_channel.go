@@ -0,0 +1,103 @@
+package notification
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// fanOutWindow is how long a notification sent to several members of a workspace, such as a
+// failed render notified to every owner, is posted only once to the workspace webhooks.
+const fanOutWindow = 5 * time.Minute
+
+// NewWorkspaceWebhookChannel creates the built-in channel that mirrors workspace
+// notifications to the Slack/Teams webhooks configured for that workspace.
+func NewWorkspaceWebhookChannel(
+	webhookRepo port.NotificationWebhookRepository,
+	senders map[entity.WebhookProvider]port.ChatWebhookSender,
+) port.NotificationChannel {
+	return &WorkspaceWebhookChannel{
+		webhookRepo: webhookRepo,
+		senders:     senders,
+		now:         time.Now,
+		posted:      make(map[string]time.Time),
+	}
+}
+
+// WorkspaceWebhookChannel delivers notifications to workspace chat webhooks.
+type WorkspaceWebhookChannel struct {
+	webhookRepo port.NotificationWebhookRepository
+	senders     map[entity.WebhookProvider]port.ChatWebhookSender
+	now         func() time.Time
+
+	mu     sync.Mutex
+	posted map[string]time.Time // by workspace, type, resource and title
+}
+
+// Name returns the channel identifier.
+func (c *WorkspaceWebhookChannel) Name() string {
+	return "workspace-webhooks"
+}
+
+// Deliver posts the notification to every enabled webhook of its workspace
+// whose event filter matches. Notifications without a workspace are skipped, and
+// so are copies of a notification sent to other members within fanOutWindow.
+func (c *WorkspaceWebhookChannel) Deliver(ctx context.Context, n *entity.Notification, _ *entity.User) error {
+	if n.WorkspaceID == nil {
+		return nil
+	}
+
+	webhooks, err := c.webhookRepo.FindEnabledByWorkspace(ctx, *n.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	if len(webhooks) == 0 || !c.claim(n) {
+		return nil
+	}
+
+	for _, webhook := range webhooks {
+		if !webhook.Accepts(n.Type) {
+			continue
+		}
+		sender, ok := c.senders[webhook.Provider]
+		if !ok {
+			continue
+		}
+		if err := sender.Send(ctx, webhook.URL, n); err != nil {
+			slog.WarnContext(ctx, "notification webhook delivery failed",
+				slog.String("webhook_id", webhook.ID),
+				slog.String("provider", string(webhook.Provider)),
+				slog.Any("error", err),
+			)
+		}
+	}
+	return nil
+}
+
+// claim reports whether n is the first of its copies to be posted, starting its window.
+// Copies are told apart by instance only: with several replicas a channel may still
+// see the same notification more than once.
+func (c *WorkspaceWebhookChannel) claim(n *entity.Notification) bool {
+	key := *n.WorkspaceID + "|" + string(n.Type) + "|" + n.Title
+	if n.ResourceID != nil {
+		key += "|" + *n.ResourceID
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if last, ok := c.posted[key]; ok && now.Sub(last) < fanOutWindow {
+		return false
+	}
+	for k, last := range c.posted {
+		if now.Sub(last) >= fanOutWindow {
+			delete(c.posted, k)
+		}
+	}
+	c.posted[key] = now
+	return true
+}
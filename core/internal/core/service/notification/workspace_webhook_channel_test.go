@@ -0,0 +1,77 @@
+package notification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+func TestWorkspaceWebhookChannel_DeliverPostsMatchingWebhooks(t *testing.T) {
+	slack, teams := &fakeChatSender{}, &fakeChatSender{}
+	channel := NewWorkspaceWebhookChannel(&fakeNotificationWebhookRepo{webhooks: []*entity.NotificationWebhook{
+		{ID: "wh-1", Provider: entity.WebhookProviderSlack, URL: "https://hooks.slack.com/services/a", Enabled: true},
+		{ID: "wh-2", Provider: entity.WebhookProviderTeams, URL: "https://x.webhook.office.com/b", Enabled: true,
+			EventTypes: []entity.NotificationType{entity.NotificationTypeScheduledPublishFailed}},
+	}}, map[entity.WebhookProvider]port.ChatWebhookSender{
+		entity.WebhookProviderSlack: slack,
+		entity.WebhookProviderTeams: teams,
+	})
+
+	require.NoError(t, channel.Deliver(context.Background(), renderFailedNotification("user-1"), nil))
+
+	assert.Equal(t, []string{"https://hooks.slack.com/services/a"}, slack.urls)
+	assert.Empty(t, teams.urls, "the Teams webhook only listens to failed scheduled publications")
+}
+
+func TestWorkspaceWebhookChannel_DeliverPostsFanOutOnce(t *testing.T) {
+	slack := &fakeChatSender{}
+	channel := NewWorkspaceWebhookChannel(&fakeNotificationWebhookRepo{webhooks: []*entity.NotificationWebhook{
+		{ID: "wh-1", Provider: entity.WebhookProviderSlack, URL: "https://hooks.slack.com/services/a", Enabled: true},
+	}}, map[entity.WebhookProvider]port.ChatWebhookSender{entity.WebhookProviderSlack: slack}).(*WorkspaceWebhookChannel)
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	channel.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	require.NoError(t, channel.Deliver(ctx, renderFailedNotification("owner-1"), nil))
+	require.NoError(t, channel.Deliver(ctx, renderFailedNotification("owner-2"), nil))
+	assert.Len(t, slack.sent, 1, "a failure notified to every owner is posted once")
+
+	now = now.Add(fanOutWindow)
+	require.NoError(t, channel.Deliver(ctx, renderFailedNotification("owner-1"), nil))
+	assert.Len(t, slack.sent, 2)
+}
+
+func renderFailedNotification(userID string) *entity.Notification {
+	workspaceID, jobID := "ws-1", "job-1"
+	n := entity.NewNotification(userID, entity.NotificationTypeRenderFailed,
+		"Render job of document type INVOICE failed in prod", "injector timed out")
+	n.WorkspaceID = &workspaceID
+	n.ResourceID = &jobID
+	return n
+}
+
+type fakeNotificationWebhookRepo struct {
+	port.NotificationWebhookRepository
+	webhooks []*entity.NotificationWebhook
+}
+
+func (f *fakeNotificationWebhookRepo) FindEnabledByWorkspace(context.Context, string) ([]*entity.NotificationWebhook, error) {
+	return f.webhooks, nil
+}
+
+type fakeChatSender struct {
+	urls []string
+	sent []*entity.Notification
+}
+
+func (f *fakeChatSender) Send(_ context.Context, webhookURL string, n *entity.Notification) error {
+	f.urls = append(f.urls, webhookURL)
+	f.sent = append(f.sent, n)
+	return nil
+}
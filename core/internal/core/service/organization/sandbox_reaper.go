@@ -0,0 +1,64 @@
+package organization
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
+)
+
+// SandboxReaper deletes expired sandbox workspaces periodically.
+type SandboxReaper struct {
+	sandboxUC organizationuc.WorkspaceSandboxUseCase
+	interval  time.Duration
+	stopCh    chan struct{}
+	stopped   chan struct{}
+	stopOnce  sync.Once
+}
+
+// NewSandboxReaper creates a reaper that ticks every interval. Call Start to begin.
+func NewSandboxReaper(sandboxUC organizationuc.WorkspaceSandboxUseCase, interval time.Duration) *SandboxReaper {
+	if interval <= 0 {
+		interval = 10 * time.Minute
+	}
+	return &SandboxReaper{
+		sandboxUC: sandboxUC,
+		interval:  interval,
+		stopCh:    make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}
+}
+
+// Start runs the reaper loop in the background until Stop is called.
+func (r *SandboxReaper) Start() {
+	go r.loop()
+}
+
+// Stop ends the loop and waits for the tick in flight to finish.
+func (r *SandboxReaper) Stop() {
+	r.stopOnce.Do(func() { close(r.stopCh) })
+	<-r.stopped
+}
+
+func (r *SandboxReaper) loop() {
+	defer close(r.stopped)
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stopCh:
+			return
+		case <-ticker.C:
+			r.tick(context.Background())
+		}
+	}
+}
+
+func (r *SandboxReaper) tick(ctx context.Context) {
+	if _, err := r.sandboxUC.DeleteExpiredSandboxes(ctx); err != nil {
+		slog.ErrorContext(ctx, "expired sandbox cleanup failed", slog.Any("error", err))
+	}
+}
@@ -17,10 +17,12 @@ import (
 func NewTenantMemberService(
 	memberRepo port.TenantMemberRepository,
 	userRepo port.UserRepository,
+	txManager port.TransactionManager,
 ) organizationuc.TenantMemberUseCase {
 	return &TenantMemberService{
 		memberRepo: memberRepo,
 		userRepo:   userRepo,
+		txManager:  txManager,
 	}
 }
 
@@ -28,6 +30,7 @@ func NewTenantMemberService(
 type TenantMemberService struct {
 	memberRepo port.TenantMemberRepository
 	userRepo   port.UserRepository
+	txManager  port.TransactionManager
 }
 
 // ListMembers lists all members of a tenant.
@@ -130,20 +133,21 @@ func (s *TenantMemberService) UpdateMemberRole(ctx context.Context, cmd organiza
 		return nil, entity.ErrTenantMemberNotFound
 	}
 
-	// If changing from TENANT_OWNER, verify there will be at least one owner remaining
-	if member.Role == entity.TenantRoleOwner && cmd.NewRole != entity.TenantRoleOwner {
-		count, err := s.memberRepo.CountByRole(ctx, cmd.TenantID, entity.TenantRoleOwner)
-		if err != nil {
-			return nil, fmt.Errorf("counting tenant owners: %w", err)
-		}
-		if count <= 1 {
-			return nil, entity.ErrCannotRemoveTenantOwner
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		// If changing from TENANT_OWNER, verify there will be at least one owner remaining
+		if member.Role == entity.TenantRoleOwner && cmd.NewRole != entity.TenantRoleOwner {
+			if err := s.ensureAnotherOwner(ctx, cmd.TenantID); err != nil {
+				return err
+			}
 		}
-	}
 
-	// Update role
-	if err := s.memberRepo.UpdateRole(ctx, cmd.MemberID, cmd.NewRole); err != nil {
-		return nil, fmt.Errorf("updating member role: %w", err)
+		if err := s.memberRepo.UpdateRole(ctx, cmd.MemberID, cmd.NewRole); err != nil {
+			return fmt.Errorf("updating member role: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	member.Role = cmd.NewRole
@@ -177,19 +181,21 @@ func (s *TenantMemberService) RemoveMember(ctx context.Context, cmd organization
 		return entity.ErrTenantMemberNotFound
 	}
 
-	// Cannot remove the last TENANT_OWNER
-	if member.Role == entity.TenantRoleOwner {
-		count, err := s.memberRepo.CountByRole(ctx, cmd.TenantID, entity.TenantRoleOwner)
-		if err != nil {
-			return fmt.Errorf("counting tenant owners: %w", err)
-		}
-		if count <= 1 {
-			return entity.ErrCannotRemoveTenantOwner
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		// Cannot remove the last TENANT_OWNER
+		if member.Role == entity.TenantRoleOwner {
+			if err := s.ensureAnotherOwner(ctx, cmd.TenantID); err != nil {
+				return err
+			}
 		}
-	}
 
-	if err := s.memberRepo.Delete(ctx, cmd.MemberID); err != nil {
-		return fmt.Errorf("removing member: %w", err)
+		if err := s.memberRepo.Delete(ctx, cmd.MemberID); err != nil {
+			return fmt.Errorf("removing member: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	slog.InfoContext(ctx, "tenant member removed",
@@ -201,6 +207,23 @@ func (s *TenantMemberService) RemoveMember(ctx context.Context, cmd organization
 	return nil
 }
 
+// ensureAnotherOwner fails unless the tenant keeps an owner after one is demoted or removed.
+// The owner rows stay locked until the transaction ends, so two concurrent changes
+// cannot each see the other owner and leave the tenant without one.
+func (s *TenantMemberService) ensureAnotherOwner(ctx context.Context, tenantID string) error {
+	if err := s.memberRepo.LockOwners(ctx, tenantID); err != nil {
+		return err
+	}
+	count, err := s.memberRepo.CountByRole(ctx, tenantID, entity.TenantRoleOwner)
+	if err != nil {
+		return fmt.Errorf("counting tenant owners: %w", err)
+	}
+	if count <= 1 {
+		return entity.ErrCannotRemoveTenantOwner
+	}
+	return nil
+}
+
 // CountOwners counts the number of TENANT_OWNER members in a tenant.
 func (s *TenantMemberService) CountOwners(ctx context.Context, tenantID string) (int, error) {
 	return s.memberRepo.CountByRole(ctx, tenantID, entity.TenantRoleOwner)
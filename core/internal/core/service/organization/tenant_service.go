@@ -10,6 +10,7 @@ import (
 
 	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	"github.com/rendis/pdf-forge/core/internal/core/port"
+	notificationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/notification"
 	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
 )
 
@@ -20,6 +21,8 @@ func NewTenantService(
 	tenantMemberRepo port.TenantMemberRepository,
 	systemRoleRepo port.SystemRoleRepository,
 	accessHistoryRepo port.UserAccessHistoryRepository,
+	settingsUC organizationuc.WorkspaceSettingsUseCase,
+	webhookUC notificationuc.EventWebhookUseCase,
 ) organizationuc.TenantUseCase {
 	return &TenantService{
 		tenantRepo:        tenantRepo,
@@ -27,6 +30,8 @@ func NewTenantService(
 		tenantMemberRepo:  tenantMemberRepo,
 		systemRoleRepo:    systemRoleRepo,
 		accessHistoryRepo: accessHistoryRepo,
+		settingsUC:        settingsUC,
+		webhookUC:         webhookUC,
 	}
 }
 
@@ -37,6 +42,8 @@ type TenantService struct {
 	tenantMemberRepo  port.TenantMemberRepository
 	systemRoleRepo    port.SystemRoleRepository
 	accessHistoryRepo port.UserAccessHistoryRepository
+	settingsUC        organizationuc.WorkspaceSettingsUseCase
+	webhookUC         notificationuc.EventWebhookUseCase
 }
 
 // CreateTenant creates a new tenant. System workspace is auto-created via DB trigger.
@@ -244,6 +251,218 @@ func (s *TenantService) UpdateTenantStatus(ctx context.Context, cmd organization
 	return tenant, nil
 }
 
+// BulkUpdateWorkspaceStatus suspends or reactivates workspaces of a tenant, one at a time, so a
+// failure only fails its workspace.
+func (s *TenantService) BulkUpdateWorkspaceStatus(ctx context.Context, cmd organizationuc.BulkWorkspaceStatusCommand) (*organizationuc.BulkWorkspaceResult, error) {
+	if cmd.Status != entity.WorkspaceStatusActive && cmd.Status != entity.WorkspaceStatusSuspended {
+		return nil, entity.ErrInvalidWorkspaceStatus
+	}
+	if _, err := s.tenantRepo.FindByID(ctx, cmd.TenantID); err != nil {
+		return nil, fmt.Errorf("finding tenant: %w", err)
+	}
+
+	result := &organizationuc.BulkWorkspaceResult{
+		Succeeded: []string{},
+		Failed:    []organizationuc.BulkWorkspaceError{},
+	}
+
+	workspaces, err := s.bulkWorkspaces(ctx, cmd.TenantID, cmd.WorkspaceIDs, result)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, workspace := range workspaces {
+		if err := s.setBulkWorkspaceStatus(ctx, workspace, cmd.Status); err != nil {
+			result.Failed = append(result.Failed, organizationuc.BulkWorkspaceError{WorkspaceID: workspace.ID, Error: err})
+			continue
+		}
+		result.Succeeded = append(result.Succeeded, workspace.ID)
+	}
+
+	slog.InfoContext(ctx, "workspace status updated in bulk",
+		slog.String("tenant_id", cmd.TenantID),
+		slog.String("status", string(cmd.Status)),
+		slog.Int("succeeded", len(result.Succeeded)),
+		slog.Int("failed", len(result.Failed)),
+	)
+
+	return result, nil
+}
+
+// bulkWorkspaces returns the workspaces a bulk operation applies to: the listed ones, or every client
+// workspace of the tenant that is not archived. Listed workspaces that are not in the tenant are
+// added to result as failed.
+func (s *TenantService) bulkWorkspaces(ctx context.Context, tenantID string, ids []string, result *organizationuc.BulkWorkspaceResult) ([]*entity.Workspace, error) {
+	if len(ids) == 0 {
+		all, err := s.workspaceRepo.FindByTenant(ctx, tenantID)
+		if err != nil {
+			return nil, fmt.Errorf("listing tenant workspaces: %w", err)
+		}
+		workspaces := make([]*entity.Workspace, 0, len(all))
+		for _, w := range all {
+			if w.Type == entity.WorkspaceTypeClient && w.Status != entity.WorkspaceStatusArchived {
+				workspaces = append(workspaces, w)
+			}
+		}
+		return workspaces, nil
+	}
+
+	workspaces := make([]*entity.Workspace, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		workspace, err := s.workspaceRepo.FindByID(ctx, id)
+		if err == nil && (workspace.TenantID == nil || *workspace.TenantID != tenantID) {
+			err = entity.ErrWorkspaceNotFound
+		}
+		if err != nil {
+			result.Failed = append(result.Failed, organizationuc.BulkWorkspaceError{WorkspaceID: id, Error: err})
+			continue
+		}
+		workspaces = append(workspaces, workspace)
+	}
+	return workspaces, nil
+}
+
+// setBulkWorkspaceStatus sets the status of one workspace of a bulk operation. A workspace already
+// in the status is left as is.
+func (s *TenantService) setBulkWorkspaceStatus(ctx context.Context, workspace *entity.Workspace, status entity.WorkspaceStatus) error {
+	switch {
+	case workspace.Type == entity.WorkspaceTypeSystem:
+		return entity.ErrCannotModifySystemWorkspace
+	case workspace.Status == entity.WorkspaceStatusArchived:
+		return entity.ErrWorkspaceArchived
+	case workspace.Status == status:
+		return nil
+	}
+	if err := s.workspaceRepo.UpdateStatus(ctx, workspace.ID, status); err != nil {
+		return fmt.Errorf("updating workspace status: %w", err)
+	}
+	return nil
+}
+
+// BulkUpdateWorkspaceSettings sets settings sections on workspaces of a tenant, one at a time, so a
+// failure only fails its workspace. Sections not in the command keep their value in each workspace.
+func (s *TenantService) BulkUpdateWorkspaceSettings(ctx context.Context, cmd organizationuc.BulkWorkspaceSettingsCommand) (*organizationuc.BulkWorkspaceResult, error) {
+	if cmd.Settings.IsEmpty() {
+		return nil, entity.ErrInvalidWorkspaceSettings
+	}
+	if err := cmd.Settings.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.tenantRepo.FindByID(ctx, cmd.TenantID); err != nil {
+		return nil, fmt.Errorf("finding tenant: %w", err)
+	}
+
+	result := &organizationuc.BulkWorkspaceResult{
+		Succeeded: []string{},
+		Failed:    []organizationuc.BulkWorkspaceError{},
+	}
+
+	workspaces, err := s.bulkWorkspaces(ctx, cmd.TenantID, cmd.WorkspaceIDs, result)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, workspace := range workspaces {
+		if err := s.applyBulkWorkspaceSettings(ctx, workspace, cmd); err != nil {
+			result.Failed = append(result.Failed, organizationuc.BulkWorkspaceError{WorkspaceID: workspace.ID, Error: err})
+			continue
+		}
+		result.Succeeded = append(result.Succeeded, workspace.ID)
+	}
+
+	slog.InfoContext(ctx, "workspace settings updated in bulk",
+		slog.String("tenant_id", cmd.TenantID),
+		slog.String("updated_by", cmd.UpdatedBy),
+		slog.Int("succeeded", len(result.Succeeded)),
+		slog.Int("failed", len(result.Failed)),
+	)
+
+	return result, nil
+}
+
+// applyBulkWorkspaceSettings sets the sections of a bulk operation on one workspace, keeping its other sections.
+func (s *TenantService) applyBulkWorkspaceSettings(ctx context.Context, workspace *entity.Workspace, cmd organizationuc.BulkWorkspaceSettingsCommand) error {
+	switch {
+	case workspace.Type == entity.WorkspaceTypeSystem:
+		return entity.ErrCannotModifySystemWorkspace
+	case workspace.Status == entity.WorkspaceStatusArchived:
+		return entity.ErrWorkspaceArchived
+	}
+
+	view, err := s.settingsUC.GetWorkspaceSettings(ctx, workspace.ID)
+	if err != nil {
+		return fmt.Errorf("getting workspace settings: %w", err)
+	}
+	settings := view.Settings
+	settings.Apply(&cmd.Settings)
+
+	_, err = s.settingsUC.UpdateWorkspaceSettings(ctx, organizationuc.UpdateWorkspaceSettingsCommand{
+		WorkspaceID: workspace.ID,
+		Settings:    settings,
+		UpdatedBy:   cmd.UpdatedBy,
+	})
+	return err
+}
+
+// BulkRotateWebhookSecrets replaces the event webhook signing secrets of workspaces of a tenant, one
+// workspace at a time, so a failure only fails its workspace. Secrets rotated in a workspace that then
+// failed are still returned: their webhooks already sign with them.
+func (s *TenantService) BulkRotateWebhookSecrets(ctx context.Context, cmd organizationuc.BulkRotateWebhookSecretsCommand) (*organizationuc.BulkWebhookSecretsResult, error) {
+	if _, err := s.tenantRepo.FindByID(ctx, cmd.TenantID); err != nil {
+		return nil, fmt.Errorf("finding tenant: %w", err)
+	}
+
+	result := &organizationuc.BulkWebhookSecretsResult{
+		BulkWorkspaceResult: organizationuc.BulkWorkspaceResult{
+			Succeeded: []string{},
+			Failed:    []organizationuc.BulkWorkspaceError{},
+		},
+		Webhooks: []*entity.EventWebhook{},
+	}
+
+	workspaces, err := s.bulkWorkspaces(ctx, cmd.TenantID, cmd.WorkspaceIDs, &result.BulkWorkspaceResult)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, workspace := range workspaces {
+		rotated, err := s.rotateBulkWebhookSecrets(ctx, workspace)
+		result.Webhooks = append(result.Webhooks, rotated...)
+		if err != nil {
+			result.Failed = append(result.Failed, organizationuc.BulkWorkspaceError{WorkspaceID: workspace.ID, Error: err})
+			continue
+		}
+		result.Succeeded = append(result.Succeeded, workspace.ID)
+	}
+
+	slog.InfoContext(ctx, "event webhook secrets rotated in bulk",
+		slog.String("tenant_id", cmd.TenantID),
+		slog.String("rotated_by", cmd.RotatedBy),
+		slog.Int("succeeded", len(result.Succeeded)),
+		slog.Int("failed", len(result.Failed)),
+		slog.Int("webhooks", len(result.Webhooks)),
+	)
+
+	return result, nil
+}
+
+// rotateBulkWebhookSecrets rotates the event webhook secrets of one workspace of a bulk operation.
+func (s *TenantService) rotateBulkWebhookSecrets(ctx context.Context, workspace *entity.Workspace) ([]*entity.EventWebhook, error) {
+	switch {
+	case workspace.Type == entity.WorkspaceTypeSystem:
+		return nil, entity.ErrCannotModifySystemWorkspace
+	case workspace.Status == entity.WorkspaceStatusArchived:
+		return nil, entity.ErrWorkspaceArchived
+	}
+	return s.webhookUC.RotateSecrets(ctx, workspace.ID)
+}
+
 // DeleteTenant deletes a tenant and all its data.
 func (s *TenantService) DeleteTenant(ctx context.Context, id string) error {
 	// Check if tenant exists
@@ -0,0 +1,247 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	notificationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/notification"
+	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
+)
+
+type bulkTenantRepoStub struct {
+	port.TenantRepository
+}
+
+func (bulkTenantRepoStub) FindByID(_ context.Context, id string) (*entity.Tenant, error) {
+	if id != "tenant-1" {
+		return nil, entity.ErrTenantNotFound
+	}
+	return &entity.Tenant{ID: id}, nil
+}
+
+type bulkWorkspaceRepoStub struct {
+	port.WorkspaceRepository
+	workspaces []*entity.Workspace
+	updated    map[string]entity.WorkspaceStatus
+}
+
+func (r *bulkWorkspaceRepoStub) FindByID(_ context.Context, id string) (*entity.Workspace, error) {
+	for _, w := range r.workspaces {
+		if w.ID == id {
+			return w, nil
+		}
+	}
+	return nil, entity.ErrWorkspaceNotFound
+}
+
+func (r *bulkWorkspaceRepoStub) FindByTenant(context.Context, string) ([]*entity.Workspace, error) {
+	return r.workspaces, nil
+}
+
+func (r *bulkWorkspaceRepoStub) UpdateStatus(_ context.Context, id string, status entity.WorkspaceStatus) error {
+	r.updated[id] = status
+	return nil
+}
+
+func newBulkWorkspaceRepo() *bulkWorkspaceRepoStub {
+	tenantID, otherTenantID := "tenant-1", "tenant-2"
+	return &bulkWorkspaceRepoStub{
+		workspaces: []*entity.Workspace{
+			{ID: "system", TenantID: &tenantID, Type: entity.WorkspaceTypeSystem, Status: entity.WorkspaceStatusActive},
+			{ID: "active", TenantID: &tenantID, Type: entity.WorkspaceTypeClient, Status: entity.WorkspaceStatusActive},
+			{ID: "suspended", TenantID: &tenantID, Type: entity.WorkspaceTypeClient, Status: entity.WorkspaceStatusSuspended},
+			{ID: "archived", TenantID: &tenantID, Type: entity.WorkspaceTypeClient, Status: entity.WorkspaceStatusArchived},
+			{ID: "other", TenantID: &otherTenantID, Type: entity.WorkspaceTypeClient, Status: entity.WorkspaceStatusActive},
+		},
+		updated: map[string]entity.WorkspaceStatus{},
+	}
+}
+
+func TestBulkUpdateWorkspaceStatus_AllWorkspacesOfTenant(t *testing.T) {
+	workspaces := newBulkWorkspaceRepo()
+	workspaces.workspaces = workspaces.workspaces[:4]
+	s := NewTenantService(bulkTenantRepoStub{}, workspaces, nil, nil, nil, nil, nil)
+
+	result, err := s.BulkUpdateWorkspaceStatus(context.Background(), organizationuc.BulkWorkspaceStatusCommand{
+		TenantID: "tenant-1",
+		Status:   entity.WorkspaceStatusSuspended,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"active", "suspended"}, result.Succeeded, "system and archived workspaces are skipped")
+	assert.Empty(t, result.Failed)
+	assert.Equal(t, map[string]entity.WorkspaceStatus{"active": entity.WorkspaceStatusSuspended}, workspaces.updated)
+}
+
+func TestBulkUpdateWorkspaceStatus_ListedWorkspaces(t *testing.T) {
+	workspaces := newBulkWorkspaceRepo()
+	s := NewTenantService(bulkTenantRepoStub{}, workspaces, nil, nil, nil, nil, nil)
+
+	result, err := s.BulkUpdateWorkspaceStatus(context.Background(), organizationuc.BulkWorkspaceStatusCommand{
+		TenantID:     "tenant-1",
+		WorkspaceIDs: []string{"suspended", "suspended", "system", "archived", "other", "missing"},
+		Status:       entity.WorkspaceStatusActive,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"suspended"}, result.Succeeded)
+
+	failures := make(map[string]error, len(result.Failed))
+	for _, f := range result.Failed {
+		failures[f.WorkspaceID] = f.Error
+	}
+	assert.ErrorIs(t, failures["system"], entity.ErrCannotModifySystemWorkspace)
+	assert.ErrorIs(t, failures["archived"], entity.ErrWorkspaceArchived)
+	assert.ErrorIs(t, failures["other"], entity.ErrWorkspaceNotFound, "workspaces of other tenants are not found")
+	assert.ErrorIs(t, failures["missing"], entity.ErrWorkspaceNotFound)
+	assert.Equal(t, map[string]entity.WorkspaceStatus{"suspended": entity.WorkspaceStatusActive}, workspaces.updated)
+}
+
+func TestBulkUpdateWorkspaceStatus_UnknownTenant(t *testing.T) {
+	s := NewTenantService(bulkTenantRepoStub{}, newBulkWorkspaceRepo(), nil, nil, nil, nil, nil)
+
+	_, err := s.BulkUpdateWorkspaceStatus(context.Background(), organizationuc.BulkWorkspaceStatusCommand{
+		TenantID: "tenant-9",
+		Status:   entity.WorkspaceStatusSuspended,
+	})
+	assert.ErrorIs(t, err, entity.ErrTenantNotFound)
+}
+
+type bulkSettingsStub struct {
+	organizationuc.WorkspaceSettingsUseCase
+	current map[string]entity.WorkspaceSettings
+	updated map[string]entity.WorkspaceSettings
+	locked  map[string]error
+}
+
+func (s *bulkSettingsStub) GetWorkspaceSettings(_ context.Context, workspaceID string) (*entity.WorkspaceSettingsView, error) {
+	return &entity.WorkspaceSettingsView{WorkspaceID: workspaceID, Settings: s.current[workspaceID]}, nil
+}
+
+func (s *bulkSettingsStub) UpdateWorkspaceSettings(_ context.Context, cmd organizationuc.UpdateWorkspaceSettingsCommand) (*entity.WorkspaceSettingsView, error) {
+	if err := s.locked[cmd.WorkspaceID]; err != nil {
+		return nil, err
+	}
+	s.updated[cmd.WorkspaceID] = cmd.Settings
+	return &entity.WorkspaceSettingsView{WorkspaceID: cmd.WorkspaceID, Settings: cmd.Settings}, nil
+}
+
+func TestBulkUpdateWorkspaceSettings_KeepsOtherSections(t *testing.T) {
+	settings := &bulkSettingsStub{
+		current: map[string]entity.WorkspaceSettings{
+			"active":    {Timezone: "Europe/Madrid", RenderOptions: &entity.WorkspaceRenderOptions{Watermark: "DRAFT"}},
+			"suspended": {AllowedFonts: []string{"Inter"}},
+		},
+		updated: map[string]entity.WorkspaceSettings{},
+		locked:  map[string]error{"suspended": entity.ErrWorkspaceSettingLocked},
+	}
+	workspaces := newBulkWorkspaceRepo()
+	workspaces.workspaces = workspaces.workspaces[:4]
+	s := NewTenantService(bulkTenantRepoStub{}, workspaces, nil, nil, nil, settings, nil)
+	flags := &entity.WorkspaceRenderOptions{Degraded: true, UnknownNodes: entity.UnknownNodesFail}
+
+	result, err := s.BulkUpdateWorkspaceSettings(context.Background(), organizationuc.BulkWorkspaceSettingsCommand{
+		TenantID:  "tenant-1",
+		Settings:  entity.WorkspaceSettings{RenderOptions: flags},
+		UpdatedBy: "admin-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"active"}, result.Succeeded, "system and archived workspaces are skipped")
+	require.Len(t, result.Failed, 1)
+	assert.Equal(t, "suspended", result.Failed[0].WorkspaceID)
+	assert.ErrorIs(t, result.Failed[0].Error, entity.ErrWorkspaceSettingLocked)
+
+	assert.Equal(t, entity.WorkspaceSettings{Timezone: "Europe/Madrid", RenderOptions: flags}, settings.updated["active"])
+	assert.Equal(t, entity.WorkspaceStatus(""), workspaces.updated["active"], "the status is left alone")
+}
+
+func TestBulkUpdateWorkspaceSettings_RejectsEmptyOrInvalidSettings(t *testing.T) {
+	s := NewTenantService(bulkTenantRepoStub{}, newBulkWorkspaceRepo(), nil, nil, nil, &bulkSettingsStub{}, nil)
+
+	_, err := s.BulkUpdateWorkspaceSettings(context.Background(), organizationuc.BulkWorkspaceSettingsCommand{TenantID: "tenant-1"})
+	assert.ErrorIs(t, err, entity.ErrInvalidWorkspaceSettings)
+
+	_, err = s.BulkUpdateWorkspaceSettings(context.Background(), organizationuc.BulkWorkspaceSettingsCommand{
+		TenantID: "tenant-1",
+		Settings: entity.WorkspaceSettings{Timezone: "Mars/Olympus"},
+	})
+	assert.ErrorIs(t, err, entity.ErrInvalidTimezone)
+}
+
+type bulkWebhookStub struct {
+	notificationuc.EventWebhookUseCase
+	webhooks map[string][]*entity.EventWebhook
+	failing  map[string]error
+}
+
+// RotateSecrets rotates the first webhook of a failing workspace before failing, like a repository
+// error on its second webhook.
+func (s *bulkWebhookStub) RotateSecrets(_ context.Context, workspaceID string) ([]*entity.EventWebhook, error) {
+	rotated := []*entity.EventWebhook{}
+	for _, w := range s.webhooks[workspaceID] {
+		w.Secret = "whsec_new_" + w.ID
+		rotated = append(rotated, w)
+		if err := s.failing[workspaceID]; err != nil {
+			return rotated, err
+		}
+	}
+	return rotated, nil
+}
+
+func TestBulkRotateWebhookSecrets(t *testing.T) {
+	webhooks := &bulkWebhookStub{
+		webhooks: map[string][]*entity.EventWebhook{
+			"active":    {{ID: "wh-1", WorkspaceID: "active", Secret: "whsec_old"}, {ID: "wh-2", WorkspaceID: "active", Secret: "whsec_old"}},
+			"suspended": {{ID: "wh-3", WorkspaceID: "suspended", Secret: "whsec_old"}, {ID: "wh-4", WorkspaceID: "suspended", Secret: "whsec_old"}},
+			"archived":  {{ID: "wh-5", WorkspaceID: "archived", Secret: "whsec_old"}},
+		},
+		failing: map[string]error{"suspended": errors.New("database unavailable")},
+	}
+	workspaces := newBulkWorkspaceRepo()
+	workspaces.workspaces = workspaces.workspaces[:4]
+	s := NewTenantService(bulkTenantRepoStub{}, workspaces, nil, nil, nil, nil, webhooks)
+
+	result, err := s.BulkRotateWebhookSecrets(context.Background(), organizationuc.BulkRotateWebhookSecretsCommand{
+		TenantID:  "tenant-1",
+		RotatedBy: "admin-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"active"}, result.Succeeded, "system and archived workspaces are skipped")
+	require.Len(t, result.Failed, 1)
+	assert.Equal(t, "suspended", result.Failed[0].WorkspaceID)
+
+	rotated := make(map[string]string, len(result.Webhooks))
+	for _, w := range result.Webhooks {
+		rotated[w.ID] = w.Secret
+	}
+	assert.Equal(t, map[string]string{"wh-1": "whsec_new_wh-1", "wh-2": "whsec_new_wh-2", "wh-3": "whsec_new_wh-3"}, rotated,
+		"a secret rotated before its workspace failed is still returned")
+	assert.Equal(t, "whsec_old", webhooks.webhooks["archived"][0].Secret)
+}
+
+func TestBulkRotateWebhookSecrets_ListedWorkspaces(t *testing.T) {
+	webhooks := &bulkWebhookStub{webhooks: map[string][]*entity.EventWebhook{}}
+	s := NewTenantService(bulkTenantRepoStub{}, newBulkWorkspaceRepo(), nil, nil, nil, nil, webhooks)
+
+	result, err := s.BulkRotateWebhookSecrets(context.Background(), organizationuc.BulkRotateWebhookSecretsCommand{
+		TenantID:     "tenant-1",
+		WorkspaceIDs: []string{"active", "system", "other"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"active"}, result.Succeeded, "a workspace without webhooks succeeds")
+	assert.Empty(t, result.Webhooks)
+
+	failures := make(map[string]error, len(result.Failed))
+	for _, f := range result.Failed {
+		failures[f.WorkspaceID] = f.Error
+	}
+	assert.ErrorIs(t, failures["system"], entity.ErrCannotModifySystemWorkspace)
+	assert.ErrorIs(t, failures["other"], entity.ErrWorkspaceNotFound, "workspaces of other tenants are not found")
+
+	_, err = s.BulkRotateWebhookSecrets(context.Background(), organizationuc.BulkRotateWebhookSecretsCommand{TenantID: "tenant-9"})
+	assert.ErrorIs(t, err, entity.ErrTenantNotFound)
+}
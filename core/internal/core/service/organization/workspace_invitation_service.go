@@ -0,0 +1,445 @@
+package organization
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	notificationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/notification"
+	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
+)
+
+// invitationTTL is how long an invitation token stays valid after being sent.
+const invitationTTL = 7 * 24 * time.Hour
+
+// NewWorkspaceInvitationService creates a new workspace invitation service.
+// mailer and notificationUC are optional.
+func NewWorkspaceInvitationService(
+	invitationRepo port.WorkspaceInvitationRepository,
+	memberRepo port.WorkspaceMemberRepository,
+	userRepo port.UserRepository,
+	workspaceRepo port.WorkspaceRepository,
+	mailer port.InvitationMailer,
+	notificationUC notificationuc.NotificationUseCase,
+	outboxRepo port.OutboxRepository,
+	txManager port.TransactionManager,
+) organizationuc.WorkspaceInvitationUseCase {
+	return &WorkspaceInvitationService{
+		invitationRepo: invitationRepo,
+		memberRepo:     memberRepo,
+		userRepo:       userRepo,
+		workspaceRepo:  workspaceRepo,
+		mailer:         mailer,
+		notificationUC: notificationUC,
+		outboxRepo:     outboxRepo,
+		txManager:      txManager,
+	}
+}
+
+// WorkspaceInvitationService implements workspace invitation business logic.
+type WorkspaceInvitationService struct {
+	invitationRepo port.WorkspaceInvitationRepository
+	memberRepo     port.WorkspaceMemberRepository
+	userRepo       port.UserRepository
+	workspaceRepo  port.WorkspaceRepository
+	mailer         port.InvitationMailer
+	notificationUC notificationuc.NotificationUseCase
+	outboxRepo     port.OutboxRepository
+	txManager      port.TransactionManager
+}
+
+// ListPendingInvitations lists pending invitations of a workspace, including expired ones.
+func (s *WorkspaceInvitationService) ListPendingInvitations(ctx context.Context, workspaceID string) ([]*entity.WorkspaceInvitation, error) {
+	invitations, err := s.invitationRepo.FindPendingByWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("listing pending invitations: %w", err)
+	}
+	return invitations, nil
+}
+
+// CreateInvitation creates a pending invitation and emails its token.
+func (s *WorkspaceInvitationService) CreateInvitation(ctx context.Context, cmd organizationuc.CreateInvitationCommand) (*entity.WorkspaceInvitation, string, error) {
+	if err := s.ensureNotMember(ctx, cmd.WorkspaceID, cmd.Email); err != nil {
+		return nil, "", err
+	}
+
+	_, err := s.invitationRepo.FindPendingByWorkspaceAndEmail(ctx, cmd.WorkspaceID, cmd.Email)
+	if err == nil {
+		return nil, "", entity.ErrInvitationAlreadyPending
+	}
+	if !errors.Is(err, entity.ErrInvitationNotFound) {
+		return nil, "", fmt.Errorf("checking pending invitation: %w", err)
+	}
+
+	token, tokenHash, err := newInvitationToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	invitation := entity.NewWorkspaceInvitation(cmd.WorkspaceID, cmd.Email, cmd.Role, &cmd.InvitedBy, invitationTTL)
+	invitation.ID = uuid.NewString()
+	invitation.TokenHash = tokenHash
+
+	if err := invitation.Validate(); err != nil {
+		return nil, "", fmt.Errorf("validating invitation: %w", err)
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		id, err := s.invitationRepo.Create(ctx, invitation)
+		if err != nil {
+			return fmt.Errorf("creating invitation: %w", err)
+		}
+		invitation.ID = id
+
+		return s.appendInvitedEvent(ctx, invitation)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	slog.InfoContext(ctx, "workspace invitation created",
+		slog.String("invitation_id", invitation.ID),
+		slog.String("workspace_id", cmd.WorkspaceID),
+		slog.String("email", invitation.Email),
+		slog.String("role", string(cmd.Role)),
+	)
+
+	s.deliver(ctx, invitation, token)
+
+	return invitation, token, nil
+}
+
+// appendInvitedEvent records a member.invited event in the outbox.
+func (s *WorkspaceInvitationService) appendInvitedEvent(ctx context.Context, invitation *entity.WorkspaceInvitation) error {
+	event, err := entity.NewOutboxEvent(entity.OutboxEventMemberInvited, invitation.ID, &invitation.WorkspaceID, entity.MemberInvited{
+		InvitationID: invitation.ID,
+		WorkspaceID:  invitation.WorkspaceID,
+		Email:        invitation.Email,
+		Role:         invitation.Role,
+		InvitedBy:    invitation.InvitedBy,
+		ExpiresAt:    invitation.ExpiresAt,
+		InvitedAt:    invitation.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.outboxRepo.Append(ctx, event); err != nil {
+		return fmt.Errorf("recording invitation event: %w", err)
+	}
+	return nil
+}
+
+// ResendInvitation issues a new token, extends the expiration and emails it again.
+func (s *WorkspaceInvitationService) ResendInvitation(ctx context.Context, workspaceID, invitationID string) (*entity.WorkspaceInvitation, string, error) {
+	invitation, err := s.findInWorkspace(ctx, workspaceID, invitationID)
+	if err != nil {
+		return nil, "", err
+	}
+	if invitation.Status != entity.InvitationStatusPending {
+		return nil, "", entity.ErrInvitationNotPending
+	}
+
+	token, tokenHash, err := newInvitationToken()
+	if err != nil {
+		return nil, "", err
+	}
+	invitation.Renew(tokenHash, invitationTTL)
+
+	if err := s.invitationRepo.Update(ctx, invitation); err != nil {
+		return nil, "", fmt.Errorf("renewing invitation: %w", err)
+	}
+
+	slog.InfoContext(ctx, "workspace invitation resent",
+		slog.String("invitation_id", invitation.ID),
+		slog.String("workspace_id", workspaceID),
+	)
+
+	s.deliver(ctx, invitation, token)
+
+	return invitation, token, nil
+}
+
+// RevokeInvitation cancels a pending invitation.
+func (s *WorkspaceInvitationService) RevokeInvitation(ctx context.Context, workspaceID, invitationID string) error {
+	invitation, err := s.findInWorkspace(ctx, workspaceID, invitationID)
+	if err != nil {
+		return err
+	}
+	if invitation.Status != entity.InvitationStatusPending {
+		return entity.ErrInvitationNotPending
+	}
+
+	invitation.Respond(entity.InvitationStatusRevoked)
+	if err := s.invitationRepo.Update(ctx, invitation); err != nil {
+		return fmt.Errorf("revoking invitation: %w", err)
+	}
+
+	slog.InfoContext(ctx, "workspace invitation revoked",
+		slog.String("invitation_id", invitation.ID),
+		slog.String("workspace_id", workspaceID),
+	)
+	return nil
+}
+
+// ListMyInvitations lists unexpired pending invitations addressed to the user's email.
+func (s *WorkspaceInvitationService) ListMyInvitations(ctx context.Context, userID string) ([]*organizationuc.InvitationWithWorkspace, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("finding user: %w", err)
+	}
+
+	invitations, err := s.invitationRepo.FindPendingByEmail(ctx, user.Email)
+	if err != nil {
+		return nil, fmt.Errorf("listing user invitations: %w", err)
+	}
+
+	result := make([]*organizationuc.InvitationWithWorkspace, 0, len(invitations))
+	for _, invitation := range invitations {
+		workspace, err := s.workspaceRepo.FindByID(ctx, invitation.WorkspaceID)
+		if err != nil {
+			slog.WarnContext(ctx, "workspace not found for invitation",
+				slog.String("invitation_id", invitation.ID),
+				slog.Any("error", err),
+			)
+			continue
+		}
+		result = append(result, &organizationuc.InvitationWithWorkspace{
+			WorkspaceInvitation: invitation,
+			Workspace:           workspace,
+		})
+	}
+
+	return result, nil
+}
+
+// AcceptInvitation accepts an invitation and creates the active membership.
+// A membership that already exists, such as a pending one created by a direct add, is activated
+// with the role of the invitation instead of duplicated. The membership and the invitation
+// change in one transaction.
+func (s *WorkspaceInvitationService) AcceptInvitation(ctx context.Context, cmd organizationuc.RespondInvitationCommand) (*entity.WorkspaceMember, error) {
+	var invitation *entity.WorkspaceInvitation
+	var member *entity.WorkspaceMember
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if invitation, err = s.resolveForResponse(ctx, cmd); err != nil {
+			return err
+		}
+		if member, err = s.joinWorkspace(ctx, invitation, cmd.UserID); err != nil {
+			return err
+		}
+
+		invitation.Respond(entity.InvitationStatusAccepted)
+		if err := s.invitationRepo.Update(ctx, invitation); err != nil {
+			return fmt.Errorf("accepting invitation: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "workspace invitation accepted",
+		slog.String("invitation_id", invitation.ID),
+		slog.String("workspace_id", invitation.WorkspaceID),
+		slog.String("user_id", cmd.UserID),
+		slog.String("role", string(member.Role)),
+	)
+
+	return member, nil
+}
+
+// joinWorkspace gives the user the active membership an accepted invitation grants.
+// The owner keeps its role; ownership only changes through a transfer.
+func (s *WorkspaceInvitationService) joinWorkspace(ctx context.Context, invitation *entity.WorkspaceInvitation, userID string) (*entity.WorkspaceMember, error) {
+	member, err := s.memberRepo.FindByUserAndWorkspace(ctx, userID, invitation.WorkspaceID)
+	if errors.Is(err, entity.ErrMemberNotFound) {
+		member = entity.NewActiveMember(invitation.WorkspaceID, userID, invitation.Role)
+		member.ID = uuid.NewString()
+		member.InvitedBy = invitation.InvitedBy
+		id, err := s.memberRepo.Create(ctx, member)
+		if err != nil {
+			return nil, fmt.Errorf("creating membership: %w", err)
+		}
+		member.ID = id
+		return member, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("checking existing membership: %w", err)
+	}
+
+	if member.IsDeactivated() {
+		return nil, entity.ErrMemberDeactivated
+	}
+	if member.Role != invitation.Role && member.Role != entity.WorkspaceRoleOwner {
+		if err := s.memberRepo.UpdateRole(ctx, member.ID, invitation.Role); err != nil {
+			return nil, fmt.Errorf("updating membership role: %w", err)
+		}
+		member.Role = invitation.Role
+	}
+	if !member.IsActive() {
+		if err := s.memberRepo.Activate(ctx, member.ID); err != nil {
+			return nil, fmt.Errorf("activating membership: %w", err)
+		}
+		member.Activate()
+	}
+	return member, nil
+}
+
+// DeclineInvitation declines an invitation.
+func (s *WorkspaceInvitationService) DeclineInvitation(ctx context.Context, cmd organizationuc.RespondInvitationCommand) error {
+	invitation, err := s.resolveForResponse(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	invitation.Respond(entity.InvitationStatusDeclined)
+	if err := s.invitationRepo.Update(ctx, invitation); err != nil {
+		return fmt.Errorf("declining invitation: %w", err)
+	}
+
+	slog.InfoContext(ctx, "workspace invitation declined",
+		slog.String("invitation_id", invitation.ID),
+		slog.String("workspace_id", invitation.WorkspaceID),
+		slog.String("user_id", cmd.UserID),
+	)
+	return nil
+}
+
+// resolveForResponse loads the invitation by ID or token and checks the user may answer it.
+func (s *WorkspaceInvitationService) resolveForResponse(ctx context.Context, cmd organizationuc.RespondInvitationCommand) (*entity.WorkspaceInvitation, error) {
+	var invitation *entity.WorkspaceInvitation
+	var err error
+	if cmd.Token != "" {
+		invitation, err = s.invitationRepo.FindByTokenHash(ctx, hashInvitationToken(cmd.Token))
+	} else {
+		invitation, err = s.invitationRepo.FindByID(ctx, cmd.InvitationID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("finding invitation: %w", err)
+	}
+
+	user, err := s.userRepo.FindByID(ctx, cmd.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("finding user: %w", err)
+	}
+	if !invitation.IsAddressedTo(user.Email) {
+		// Hide invitations of other users when looked up by ID
+		if cmd.Token == "" {
+			return nil, entity.ErrInvitationNotFound
+		}
+		return nil, entity.ErrInvitationEmailMismatch
+	}
+
+	if err := invitation.CanRespond(); err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// findInWorkspace loads an invitation and verifies it belongs to the workspace.
+func (s *WorkspaceInvitationService) findInWorkspace(ctx context.Context, workspaceID, invitationID string) (*entity.WorkspaceInvitation, error) {
+	invitation, err := s.invitationRepo.FindByID(ctx, invitationID)
+	if err != nil {
+		return nil, fmt.Errorf("finding invitation: %w", err)
+	}
+	if invitation.WorkspaceID != workspaceID {
+		return nil, entity.ErrInvitationNotFound
+	}
+	return invitation, nil
+}
+
+// ensureNotMember rejects invitations for emails that already belong to the workspace.
+func (s *WorkspaceInvitationService) ensureNotMember(ctx context.Context, workspaceID, email string) error {
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if errors.Is(err, entity.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("finding user by email: %w", err)
+	}
+
+	member, err := s.memberRepo.FindByUserAndWorkspace(ctx, user.ID, workspaceID)
+	if err == nil && member.IsActive() {
+		return entity.ErrMemberAlreadyExists
+	}
+	if err == nil && member.IsDeactivated() {
+		// Deactivated members are reactivated by an admin, not re-invited
+		return entity.ErrMemberDeactivated
+	}
+	if err != nil && !errors.Is(err, entity.ErrMemberNotFound) {
+		return fmt.Errorf("checking existing membership: %w", err)
+	}
+	return nil
+}
+
+// deliver emails the invitation and, for known users, raises an in-product notification.
+// Failures are logged and never fail the invitation; admins can resend.
+func (s *WorkspaceInvitationService) deliver(ctx context.Context, invitation *entity.WorkspaceInvitation, token string) {
+	workspace, err := s.workspaceRepo.FindByID(ctx, invitation.WorkspaceID)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to load workspace for invitation",
+			slog.String("invitation_id", invitation.ID),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	if s.mailer != nil {
+		if err := s.mailer.SendInvitation(ctx, invitation, workspace, token); err != nil {
+			slog.WarnContext(ctx, "failed to send invitation email",
+				slog.String("invitation_id", invitation.ID),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	if s.notificationUC == nil {
+		return
+	}
+	user, err := s.userRepo.FindByEmail(ctx, invitation.Email)
+	if err != nil {
+		return // Not registered yet; the email is the only channel
+	}
+	err = s.notificationUC.Notify(ctx, notificationuc.NotifyCommand{
+		UserID:      user.ID,
+		WorkspaceID: &invitation.WorkspaceID,
+		Type:        entity.NotificationTypeWorkspaceInvitation,
+		Title:       fmt.Sprintf("You were invited to %s", workspace.Name),
+		Message:     fmt.Sprintf("Role: %s", invitation.Role),
+		ResourceID:  &invitation.ID,
+		Time:        &invitation.ExpiresAt,
+		TimeLabel:   "Expires",
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "failed to notify invitation",
+			slog.String("invitation_id", invitation.ID),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// newInvitationToken returns a random URL-safe token and its SHA-256 hash.
+func newInvitationToken() (token, tokenHash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("generating invitation token: %w", err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashInvitationToken(token), nil
+}
+
+// hashInvitationToken hashes a raw invitation token for storage and lookup.
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,431 @@
+package organization
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
+)
+
+func TestAcceptInvitation_CreatesMembership(t *testing.T) {
+	invitations := newInvitationRepoStub(newPendingInvitation(entity.WorkspaceRoleEditor))
+	members := &memberRepoStub{}
+	s := newInvitationService(invitations, members)
+
+	member, err := s.AcceptInvitation(context.Background(), organizationuc.RespondInvitationCommand{Token: "token-1", UserID: "user-1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.WorkspaceRoleEditor, member.Role)
+	assert.True(t, member.IsActive())
+	require.Len(t, members.created, 1)
+	assert.Equal(t, "ws-1", members.created[0].WorkspaceID)
+	assert.Equal(t, entity.InvitationStatusAccepted, invitations.updated.Status)
+	assert.True(t, members.writesInTx && invitations.writesInTx, "the membership and the invitation change in one transaction")
+}
+
+func TestAcceptInvitation_AppliesRoleToExistingMember(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  *entity.WorkspaceMember
+		wantRole  entity.WorkspaceRole
+		activated bool
+	}{
+		{"pending member", entity.NewWorkspaceMember("ws-1", "user-1", entity.WorkspaceRoleViewer, nil), entity.WorkspaceRoleAdmin, true},
+		{"active member", entity.NewActiveMember("ws-1", "user-1", entity.WorkspaceRoleViewer), entity.WorkspaceRoleAdmin, false},
+		{"owner", entity.NewActiveMember("ws-1", "user-1", entity.WorkspaceRoleOwner), entity.WorkspaceRoleOwner, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.existing.ID = "member-1"
+			invitations := newInvitationRepoStub(newPendingInvitation(entity.WorkspaceRoleAdmin))
+			members := &memberRepoStub{existing: tt.existing}
+			s := newInvitationService(invitations, members)
+
+			member, err := s.AcceptInvitation(context.Background(), organizationuc.RespondInvitationCommand{InvitationID: "inv-1", UserID: "user-1"})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRole, member.Role)
+			assert.True(t, member.IsActive())
+			assert.Empty(t, members.created, "the existing membership is reused")
+			assert.Equal(t, tt.activated, members.activated == "member-1")
+			if tt.wantRole != tt.existing.Role {
+				assert.Equal(t, tt.wantRole, members.roles["member-1"])
+			}
+		})
+	}
+}
+
+func TestAcceptInvitation_RejectsClosedInvitations(t *testing.T) {
+	expired := newPendingInvitation(entity.WorkspaceRoleEditor)
+	expired.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+	revoked := newPendingInvitation(entity.WorkspaceRoleEditor)
+	revoked.Respond(entity.InvitationStatusRevoked)
+
+	tests := []struct {
+		name       string
+		invitation *entity.WorkspaceInvitation
+		want       error
+	}{
+		{"expired", expired, entity.ErrInvitationExpired},
+		{"revoked", revoked, entity.ErrInvitationNotPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invitations := newInvitationRepoStub(tt.invitation)
+			members := &memberRepoStub{}
+			s := newInvitationService(invitations, members)
+
+			_, err := s.AcceptInvitation(context.Background(), organizationuc.RespondInvitationCommand{Token: "token-1", UserID: "user-1"})
+
+			assert.ErrorIs(t, err, tt.want)
+			assert.Empty(t, members.created)
+			assert.Nil(t, invitations.updated)
+		})
+	}
+}
+
+func TestAcceptInvitation_RejectsDeactivatedMember(t *testing.T) {
+	existing := entity.NewActiveMember("ws-1", "user-1", entity.WorkspaceRoleViewer)
+	existing.ID = "member-1"
+	existing.MembershipStatus = entity.MembershipStatusInactive
+	invitations := newInvitationRepoStub(newPendingInvitation(entity.WorkspaceRoleEditor))
+	members := &memberRepoStub{existing: existing}
+	s := newInvitationService(invitations, members)
+
+	_, err := s.AcceptInvitation(context.Background(), organizationuc.RespondInvitationCommand{Token: "token-1", UserID: "user-1"})
+
+	assert.ErrorIs(t, err, entity.ErrMemberDeactivated)
+	assert.Nil(t, invitations.updated)
+	assert.Empty(t, members.roles)
+}
+
+func TestAcceptInvitation_RejectsOtherEmail(t *testing.T) {
+	invitation := newPendingInvitation(entity.WorkspaceRoleEditor)
+	invitation.Email = "someone@example.com"
+	s := newInvitationService(newInvitationRepoStub(invitation), &memberRepoStub{})
+
+	_, err := s.AcceptInvitation(context.Background(), organizationuc.RespondInvitationCommand{Token: "token-1", UserID: "user-1"})
+	assert.ErrorIs(t, err, entity.ErrInvitationEmailMismatch)
+
+	_, err = s.AcceptInvitation(context.Background(), organizationuc.RespondInvitationCommand{InvitationID: "inv-1", UserID: "user-1"})
+	assert.ErrorIs(t, err, entity.ErrInvitationNotFound, "invitations of other users are hidden when looked up by ID")
+}
+
+func TestCreateInvitation_StoresHashAndEmailsToken(t *testing.T) {
+	invitations := newInvitationRepoStub(nil)
+	mailer := &invitationMailerStub{}
+	outbox := &invitationOutboxStub{}
+	s := newDeliveringInvitationService(invitations, &memberRepoStub{}, &invitationUserRepoStub{}, mailer, outbox)
+
+	invitation, token, err := s.CreateInvitation(context.Background(), organizationuc.CreateInvitationCommand{
+		WorkspaceID: "ws-1", Email: "ada@example.com", Role: entity.WorkspaceRoleEditor, InvitedBy: "admin-1",
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	assert.Equal(t, hashInvitationToken(token), invitation.TokenHash, "only the hash of the token is stored")
+	assert.Equal(t, entity.InvitationStatusPending, invitation.Status)
+	assert.WithinDuration(t, time.Now().Add(invitationTTL), invitation.ExpiresAt, time.Minute)
+	assert.Equal(t, []string{token}, mailer.tokens)
+	require.Len(t, outbox.events, 1)
+	assert.Equal(t, entity.OutboxEventMemberInvited, outbox.events[0].Type)
+	assert.True(t, invitations.writesInTx && outbox.inTx, "the invitation and its event are written in one transaction")
+}
+
+func TestCreateInvitation_Rejects(t *testing.T) {
+	deactivated := entity.NewActiveMember("ws-1", "user-1", entity.WorkspaceRoleViewer)
+	deactivated.MembershipStatus = entity.MembershipStatusInactive
+
+	tests := []struct {
+		name     string
+		pending  *entity.WorkspaceInvitation
+		existing *entity.WorkspaceMember
+		want     error
+	}{
+		{"pending invitation for the email", newPendingInvitation(entity.WorkspaceRoleViewer), nil, entity.ErrInvitationAlreadyPending},
+		{"active member", nil, entity.NewActiveMember("ws-1", "user-1", entity.WorkspaceRoleViewer), entity.ErrMemberAlreadyExists},
+		{"deactivated member", nil, deactivated, entity.ErrMemberDeactivated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invitations := newInvitationRepoStub(tt.pending)
+			mailer := &invitationMailerStub{}
+			outbox := &invitationOutboxStub{}
+			users := &invitationUserRepoStub{registered: &entity.User{ID: "user-1", Email: "ada@example.com"}}
+			s := newDeliveringInvitationService(invitations, &memberRepoStub{existing: tt.existing}, users, mailer, outbox)
+
+			_, _, err := s.CreateInvitation(context.Background(), organizationuc.CreateInvitationCommand{
+				WorkspaceID: "ws-1", Email: "ada@example.com", Role: entity.WorkspaceRoleEditor, InvitedBy: "admin-1",
+			})
+
+			assert.ErrorIs(t, err, tt.want)
+			assert.Empty(t, invitations.created)
+			assert.Empty(t, mailer.tokens)
+			assert.Empty(t, outbox.events)
+		})
+	}
+}
+
+func TestResendInvitation_ReplacesToken(t *testing.T) {
+	invitation := newPendingInvitation(entity.WorkspaceRoleEditor)
+	invitation.ExpiresAt = time.Now().UTC().Add(time.Hour)
+	invitations := newInvitationRepoStub(invitation)
+	mailer := &invitationMailerStub{}
+	s := newDeliveringInvitationService(invitations, &memberRepoStub{}, &invitationUserRepoStub{}, mailer, &invitationOutboxStub{})
+
+	renewed, token, err := s.ResendInvitation(context.Background(), "ws-1", "inv-1")
+
+	require.NoError(t, err)
+	assert.NotEqual(t, "token-1", token)
+	assert.Equal(t, hashInvitationToken(token), renewed.TokenHash)
+	assert.WithinDuration(t, time.Now().Add(invitationTTL), renewed.ExpiresAt, time.Minute)
+	assert.Equal(t, []string{token}, mailer.tokens)
+
+	_, err = s.AcceptInvitation(context.Background(), organizationuc.RespondInvitationCommand{Token: "token-1", UserID: "user-1"})
+	assert.ErrorIs(t, err, entity.ErrInvitationNotFound, "the previous token no longer works")
+}
+
+func TestManageInvitation_Rejects(t *testing.T) {
+	declined := newPendingInvitation(entity.WorkspaceRoleEditor)
+	declined.Respond(entity.InvitationStatusDeclined)
+
+	tests := []struct {
+		name        string
+		invitation  *entity.WorkspaceInvitation
+		workspaceID string
+		want        error
+	}{
+		{"invitation of another workspace", newPendingInvitation(entity.WorkspaceRoleEditor), "ws-2", entity.ErrInvitationNotFound},
+		{"answered invitation", declined, "ws-1", entity.ErrInvitationNotPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invitations := newInvitationRepoStub(tt.invitation)
+			mailer := &invitationMailerStub{}
+			s := newDeliveringInvitationService(invitations, &memberRepoStub{}, &invitationUserRepoStub{}, mailer, &invitationOutboxStub{})
+
+			_, _, err := s.ResendInvitation(context.Background(), tt.workspaceID, "inv-1")
+			assert.ErrorIs(t, err, tt.want)
+
+			err = s.RevokeInvitation(context.Background(), tt.workspaceID, "inv-1")
+			assert.ErrorIs(t, err, tt.want)
+
+			assert.Nil(t, invitations.updated)
+			assert.Empty(t, mailer.tokens)
+		})
+	}
+}
+
+func TestRevokeInvitation_ClosesToken(t *testing.T) {
+	invitations := newInvitationRepoStub(newPendingInvitation(entity.WorkspaceRoleEditor))
+	members := &memberRepoStub{}
+	s := newInvitationService(invitations, members)
+
+	require.NoError(t, s.RevokeInvitation(context.Background(), "ws-1", "inv-1"))
+	assert.Equal(t, entity.InvitationStatusRevoked, invitations.updated.Status)
+
+	_, err := s.AcceptInvitation(context.Background(), organizationuc.RespondInvitationCommand{Token: "token-1", UserID: "user-1"})
+	assert.ErrorIs(t, err, entity.ErrInvitationNotPending)
+	assert.Empty(t, members.created)
+}
+
+func TestDeclineInvitation(t *testing.T) {
+	invitations := newInvitationRepoStub(newPendingInvitation(entity.WorkspaceRoleEditor))
+	members := &memberRepoStub{}
+	s := newInvitationService(invitations, members)
+
+	err := s.DeclineInvitation(context.Background(), organizationuc.RespondInvitationCommand{Token: "token-1", UserID: "user-1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.InvitationStatusDeclined, invitations.updated.Status)
+	assert.Empty(t, members.created)
+	assert.Empty(t, members.roles)
+}
+
+func TestInviteMember_DoesNotCreateShadowUsers(t *testing.T) {
+	users := &invitationUserRepoStub{}
+	members := &memberRepoStub{}
+	s := NewWorkspaceMemberService(members, users, nil, nil, invitationTxStub{})
+
+	_, err := s.InviteMember(context.Background(), organizationuc.InviteMemberCommand{
+		WorkspaceID: "ws-1", Email: "new@example.com", FullName: "New", Role: entity.WorkspaceRoleEditor, InvitedBy: "admin-1",
+	})
+
+	assert.ErrorIs(t, err, entity.ErrInviteeNotRegistered)
+	assert.Empty(t, users.created)
+	assert.Empty(t, members.created)
+}
+
+func newInvitationService(invitations *invitationRepoStub, members *memberRepoStub) organizationuc.WorkspaceInvitationUseCase {
+	return NewWorkspaceInvitationService(invitations, members, &invitationUserRepoStub{}, nil, nil, nil, nil, invitationTxStub{})
+}
+
+// newDeliveringInvitationService wires the mailer and the outbox, for the flows that issue tokens.
+func newDeliveringInvitationService(invitations *invitationRepoStub, members *memberRepoStub, users *invitationUserRepoStub, mailer *invitationMailerStub, outbox *invitationOutboxStub) organizationuc.WorkspaceInvitationUseCase {
+	workspaces := sandboxWorkspaceRepoStub{workspaces: map[string]*entity.Workspace{"ws-1": {ID: "ws-1", Name: "Legal"}}}
+	return NewWorkspaceInvitationService(invitations, members, users, workspaces, mailer, nil, outbox, invitationTxStub{})
+}
+
+func newPendingInvitation(role entity.WorkspaceRole) *entity.WorkspaceInvitation {
+	invitedBy := "admin-1"
+	invitation := entity.NewWorkspaceInvitation("ws-1", "ada@example.com", role, &invitedBy, invitationTTL)
+	invitation.ID = "inv-1"
+	invitation.TokenHash = hashInvitationToken("token-1")
+	return invitation
+}
+
+type invitationTxKey struct{}
+
+type invitationTxStub struct{}
+
+func (invitationTxStub) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, invitationTxKey{}, true))
+}
+
+func inInvitationTx(ctx context.Context) bool {
+	in, _ := ctx.Value(invitationTxKey{}).(bool)
+	return in
+}
+
+type invitationRepoStub struct {
+	port.WorkspaceInvitationRepository
+	invitation *entity.WorkspaceInvitation
+	created    []*entity.WorkspaceInvitation
+	updated    *entity.WorkspaceInvitation
+	writesInTx bool
+}
+
+func newInvitationRepoStub(invitation *entity.WorkspaceInvitation) *invitationRepoStub {
+	return &invitationRepoStub{invitation: invitation}
+}
+
+func (r *invitationRepoStub) Create(ctx context.Context, invitation *entity.WorkspaceInvitation) (string, error) {
+	r.created = append(r.created, invitation)
+	r.invitation = invitation
+	r.writesInTx = inInvitationTx(ctx)
+	return invitation.ID, nil
+}
+
+func (r *invitationRepoStub) FindPendingByWorkspaceAndEmail(_ context.Context, workspaceID, email string) (*entity.WorkspaceInvitation, error) {
+	if r.invitation == nil || r.invitation.Status != entity.InvitationStatusPending ||
+		r.invitation.WorkspaceID != workspaceID || r.invitation.Email != email {
+		return nil, entity.ErrInvitationNotFound
+	}
+	return r.invitation, nil
+}
+
+func (r *invitationRepoStub) FindByID(_ context.Context, id string) (*entity.WorkspaceInvitation, error) {
+	if r.invitation.ID != id {
+		return nil, entity.ErrInvitationNotFound
+	}
+	return r.invitation, nil
+}
+
+func (r *invitationRepoStub) FindByTokenHash(_ context.Context, tokenHash string) (*entity.WorkspaceInvitation, error) {
+	if r.invitation.TokenHash != tokenHash {
+		return nil, entity.ErrInvitationNotFound
+	}
+	return r.invitation, nil
+}
+
+func (r *invitationRepoStub) Update(ctx context.Context, invitation *entity.WorkspaceInvitation) error {
+	r.updated = invitation
+	r.writesInTx = inInvitationTx(ctx)
+	return nil
+}
+
+type memberRepoStub struct {
+	port.WorkspaceMemberRepository
+	existing   *entity.WorkspaceMember
+	created    []*entity.WorkspaceMember
+	roles      map[string]entity.WorkspaceRole
+	activated  string
+	writesInTx bool
+
+	// Ownership transfer
+	byID          map[string]*entity.WorkspaceMember
+	owners        int
+	transferErr   error
+	transferredTo string
+	ownersInTx    bool
+}
+
+func (r *memberRepoStub) FindByUserAndWorkspace(_ context.Context, userID, workspaceID string) (*entity.WorkspaceMember, error) {
+	if r.existing == nil || r.existing.UserID != userID || r.existing.WorkspaceID != workspaceID {
+		return nil, entity.ErrMemberNotFound
+	}
+	return r.existing, nil
+}
+
+func (r *memberRepoStub) Create(ctx context.Context, member *entity.WorkspaceMember) (string, error) {
+	r.created = append(r.created, member)
+	r.writesInTx = inInvitationTx(ctx)
+	return member.ID, nil
+}
+
+func (r *memberRepoStub) UpdateRole(ctx context.Context, id string, role entity.WorkspaceRole) error {
+	if r.roles == nil {
+		r.roles = make(map[string]entity.WorkspaceRole)
+	}
+	r.roles[id] = role
+	r.writesInTx = inInvitationTx(ctx)
+	return nil
+}
+
+func (r *memberRepoStub) Activate(ctx context.Context, id string) error {
+	r.activated = id
+	r.writesInTx = inInvitationTx(ctx)
+	return nil
+}
+
+type invitationUserRepoStub struct {
+	port.UserRepository
+	registered *entity.User // Returned by FindByEmail; nil when nobody is registered
+	created    []*entity.User
+}
+
+func (r *invitationUserRepoStub) FindByID(_ context.Context, id string) (*entity.User, error) {
+	if id != "user-1" {
+		return nil, entity.ErrUserNotFound
+	}
+	return &entity.User{ID: id, Email: "ada@example.com", Status: entity.UserStatusActive}, nil
+}
+
+func (r *invitationUserRepoStub) FindByEmail(_ context.Context, email string) (*entity.User, error) {
+	if r.registered == nil || r.registered.Email != email {
+		return nil, entity.ErrUserNotFound
+	}
+	return r.registered, nil
+}
+
+func (r *invitationUserRepoStub) Create(_ context.Context, user *entity.User) (string, error) {
+	r.created = append(r.created, user)
+	return user.ID, nil
+}
+
+type invitationMailerStub struct {
+	tokens []string
+}
+
+func (m *invitationMailerStub) SendInvitation(_ context.Context, _ *entity.WorkspaceInvitation, _ *entity.Workspace, token string) error {
+	m.tokens = append(m.tokens, token)
+	return nil
+}
+
+type invitationOutboxStub struct {
+	port.OutboxRepository
+	events []*entity.OutboxEvent
+	inTx   bool
+}
+
+func (o *invitationOutboxStub) Append(ctx context.Context, event *entity.OutboxEvent) error {
+	o.events = append(o.events, event)
+	o.inTx = inInvitationTx(ctx)
+	return nil
+}
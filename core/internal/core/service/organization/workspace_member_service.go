@@ -10,6 +10,7 @@ import (
 
 	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	"github.com/rendis/pdf-forge/core/internal/core/port"
+	notificationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/notification"
 	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
 )
 
@@ -17,17 +18,26 @@ import (
 func NewWorkspaceMemberService(
 	memberRepo port.WorkspaceMemberRepository,
 	userRepo port.UserRepository,
+	workspaceRepo port.WorkspaceRepository,
+	notificationUC notificationuc.NotificationUseCase,
+	txManager port.TransactionManager,
 ) organizationuc.WorkspaceMemberUseCase {
 	return &WorkspaceMemberService{
-		memberRepo: memberRepo,
-		userRepo:   userRepo,
+		memberRepo:     memberRepo,
+		userRepo:       userRepo,
+		workspaceRepo:  workspaceRepo,
+		notificationUC: notificationUC,
+		txManager:      txManager,
 	}
 }
 
 // WorkspaceMemberService implements workspace member business logic.
 type WorkspaceMemberService struct {
-	memberRepo port.WorkspaceMemberRepository
-	userRepo   port.UserRepository
+	memberRepo     port.WorkspaceMemberRepository
+	userRepo       port.UserRepository
+	workspaceRepo  port.WorkspaceRepository
+	notificationUC notificationuc.NotificationUseCase
+	txManager      port.TransactionManager
 }
 
 // ListMembers lists all members of a workspace.
@@ -57,16 +67,20 @@ func (s *WorkspaceMemberService) GetMember(ctx context.Context, memberID string)
 	}, nil
 }
 
-// InviteMember invites a user to join a workspace.
-// Creates a shadow user if the email doesn't exist.
+// InviteMember adds a registered user to a workspace.
+// Unknown emails fail with ErrInviteeNotRegistered: they are invited through a
+// WorkspaceInvitation, which creates no user until the invitee accepts.
 func (s *WorkspaceMemberService) InviteMember(ctx context.Context, cmd organizationuc.InviteMemberCommand) (*entity.MemberWithUser, error) {
 	if cmd.Role == entity.WorkspaceRoleOwner {
 		return nil, entity.ErrInvalidRole
 	}
 
-	user, err := s.findOrCreateUser(ctx, cmd.Email, cmd.FullName)
+	user, err := s.userRepo.FindByEmail(ctx, cmd.Email)
+	if errors.Is(err, entity.ErrUserNotFound) {
+		return nil, entity.ErrInviteeNotRegistered
+	}
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("finding user by email: %w", err)
 	}
 
 	// Check if user is already a member
@@ -107,36 +121,40 @@ func (s *WorkspaceMemberService) InviteMember(ctx context.Context, cmd organizat
 		slog.String("role", string(cmd.Role)),
 	)
 
+	s.notifyInvitation(ctx, member)
+
 	return &entity.MemberWithUser{
 		WorkspaceMember: *member,
 		User:            user,
 	}, nil
 }
 
-// findOrCreateUser finds a user by email or creates a shadow user if not found.
-func (s *WorkspaceMemberService) findOrCreateUser(ctx context.Context, email, fullName string) (*entity.User, error) {
-	user, err := s.userRepo.FindByEmail(ctx, email)
-	if err == nil {
-		return user, nil
+// notifyInvitation tells the invited user they were added to the workspace.
+// Failures are logged and never fail the invitation.
+func (s *WorkspaceMemberService) notifyInvitation(ctx context.Context, member *entity.WorkspaceMember) {
+	if s.notificationUC == nil {
+		return
 	}
 
-	if !errors.Is(err, entity.ErrUserNotFound) {
-		return nil, fmt.Errorf("finding user by email: %w", err)
+	title := "You were invited to a workspace"
+	if workspace, err := s.workspaceRepo.FindByID(ctx, member.WorkspaceID); err == nil {
+		title = fmt.Sprintf("You were invited to %s", workspace.Name)
 	}
 
-	user = entity.NewUser(email, fullName)
-	user.ID = uuid.NewString()
-
-	if _, err = s.userRepo.Create(ctx, user); err != nil {
-		return nil, fmt.Errorf("creating shadow user: %w", err)
+	err := s.notificationUC.Notify(ctx, notificationuc.NotifyCommand{
+		UserID:      member.UserID,
+		WorkspaceID: &member.WorkspaceID,
+		Type:        entity.NotificationTypeWorkspaceInvitation,
+		Title:       title,
+		Message:     fmt.Sprintf("Role: %s", member.Role),
+		ResourceID:  &member.ID,
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "failed to notify invitation",
+			slog.String("member_id", member.ID),
+			slog.Any("error", err),
+		)
 	}
-
-	slog.InfoContext(ctx, "shadow user created",
-		slog.String("user_id", user.ID),
-		slog.String("email", user.Email),
-	)
-
-	return user, nil
 }
 
 // UpdateMemberRole updates a member's role within the workspace.
@@ -209,3 +227,145 @@ func (s *WorkspaceMemberService) RemoveMember(ctx context.Context, cmd organizat
 
 	return nil
 }
+
+// DeactivateMember blocks a member's access while keeping the membership for history.
+func (s *WorkspaceMemberService) DeactivateMember(ctx context.Context, cmd organizationuc.ChangeMemberStatusCommand) (*entity.MemberWithUser, error) {
+	member, err := s.findInWorkspace(ctx, cmd.MemberID, cmd.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Owners must hand over the workspace before losing access
+	if member.Role == entity.WorkspaceRoleOwner {
+		return nil, entity.ErrCannotDeactivateOwner
+	}
+	if member.IsDeactivated() {
+		return nil, entity.ErrMemberDeactivated
+	}
+
+	return s.changeStatus(ctx, member, entity.MembershipStatusInactive, cmd.UpdatedBy)
+}
+
+// ReactivateMember restores access for a deactivated member.
+func (s *WorkspaceMemberService) ReactivateMember(ctx context.Context, cmd organizationuc.ChangeMemberStatusCommand) (*entity.MemberWithUser, error) {
+	member, err := s.findInWorkspace(ctx, cmd.MemberID, cmd.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !member.IsDeactivated() {
+		return nil, entity.ErrMemberNotDeactivated
+	}
+
+	return s.changeStatus(ctx, member, entity.MembershipStatusActive, cmd.UpdatedBy)
+}
+
+// changeStatus persists a new membership status and returns the member with its user.
+func (s *WorkspaceMemberService) changeStatus(
+	ctx context.Context,
+	member *entity.WorkspaceMember,
+	status entity.MembershipStatus,
+	updatedBy string,
+) (*entity.MemberWithUser, error) {
+	if err := s.memberRepo.UpdateStatus(ctx, member.ID, status); err != nil {
+		return nil, fmt.Errorf("updating membership status: %w", err)
+	}
+	member.MembershipStatus = status
+
+	user, err := s.userRepo.FindByID(ctx, member.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("finding user: %w", err)
+	}
+
+	slog.InfoContext(ctx, "membership status changed",
+		slog.String("member_id", member.ID),
+		slog.String("workspace_id", member.WorkspaceID),
+		slog.String("status", string(status)),
+		slog.String("updated_by", updatedBy),
+	)
+
+	return &entity.MemberWithUser{
+		WorkspaceMember: *member,
+		User:            user,
+	}, nil
+}
+
+// TransferOwnership makes another active member the workspace owner.
+// The previous owner is demoted to ADMIN in the same operation.
+func (s *WorkspaceMemberService) TransferOwnership(ctx context.Context, cmd organizationuc.TransferOwnershipCommand) error {
+	target, err := s.findInWorkspace(ctx, cmd.NewOwnerMemberID, cmd.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	if target.Role == entity.WorkspaceRoleOwner || !target.IsActive() {
+		return entity.ErrInvalidOwnershipTarget
+	}
+
+	current, err := s.findCurrentOwner(ctx, cmd.WorkspaceID, cmd.RequestedBy)
+	if err != nil {
+		return err
+	}
+
+	// The owner count is checked in the transaction of the swap, so a concurrent role change that
+	// would leave the workspace without an owner rolls the transfer back instead of committing it.
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.memberRepo.TransferOwnership(ctx, cmd.WorkspaceID, current.ID, target.ID, entity.WorkspaceRoleAdmin); err != nil {
+			return fmt.Errorf("transferring ownership: %w", err)
+		}
+		owners, err := s.memberRepo.CountActiveByRole(ctx, cmd.WorkspaceID, entity.WorkspaceRoleOwner)
+		if err != nil {
+			return fmt.Errorf("verifying workspace owners: %w", err)
+		}
+		if owners == 0 {
+			return entity.ErrLastWorkspaceOwner
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "workspace ownership transferred",
+		slog.String("workspace_id", cmd.WorkspaceID),
+		slog.String("from_member_id", current.ID),
+		slog.String("to_member_id", target.ID),
+	)
+
+	return nil
+}
+
+// findCurrentOwner returns the membership that hands over ownership.
+// The requester's own membership is preferred; system-role users acting as owner
+// without a membership fall back to the workspace's active owner.
+func (s *WorkspaceMemberService) findCurrentOwner(ctx context.Context, workspaceID, requestedBy string) (*entity.WorkspaceMember, error) {
+	member, err := s.memberRepo.FindActiveByUserAndWorkspace(ctx, requestedBy, workspaceID)
+	if err == nil && member.Role == entity.WorkspaceRoleOwner {
+		return member, nil
+	}
+	if err != nil && !errors.Is(err, entity.ErrMemberNotFound) {
+		return nil, fmt.Errorf("finding requesting member: %w", err)
+	}
+
+	members, err := s.memberRepo.FindByWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("listing workspace members: %w", err)
+	}
+	for _, m := range members {
+		if m.Role == entity.WorkspaceRoleOwner && m.IsActive() {
+			return &m.WorkspaceMember, nil
+		}
+	}
+	return nil, entity.ErrLastWorkspaceOwner
+}
+
+// findInWorkspace loads a member and verifies it belongs to the given workspace.
+func (s *WorkspaceMemberService) findInWorkspace(ctx context.Context, memberID, workspaceID string) (*entity.WorkspaceMember, error) {
+	member, err := s.memberRepo.FindByID(ctx, memberID)
+	if err != nil {
+		return nil, fmt.Errorf("finding member: %w", err)
+	}
+	if member.WorkspaceID != workspaceID {
+		return nil, entity.ErrMemberNotFound
+	}
+	return member, nil
+}
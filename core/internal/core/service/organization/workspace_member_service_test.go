@@ -0,0 +1,122 @@
+package organization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
+)
+
+func (r *memberRepoStub) FindByID(_ context.Context, id string) (*entity.WorkspaceMember, error) {
+	if m, ok := r.byID[id]; ok {
+		return m, nil
+	}
+	return nil, entity.ErrMemberNotFound
+}
+
+func (r *memberRepoStub) FindActiveByUserAndWorkspace(_ context.Context, userID, workspaceID string) (*entity.WorkspaceMember, error) {
+	for _, m := range r.byID {
+		if m.UserID == userID && m.WorkspaceID == workspaceID && m.IsActive() {
+			return m, nil
+		}
+	}
+	return nil, entity.ErrMemberNotFound
+}
+
+func (r *memberRepoStub) TransferOwnership(ctx context.Context, _, _, toMemberID string, _ entity.WorkspaceRole) error {
+	if r.transferErr != nil {
+		return r.transferErr
+	}
+	r.transferredTo = toMemberID
+	r.writesInTx = inInvitationTx(ctx)
+	return nil
+}
+
+func (r *memberRepoStub) CountActiveByRole(ctx context.Context, _ string, _ entity.WorkspaceRole) (int, error) {
+	r.ownersInTx = inInvitationTx(ctx)
+	return r.owners, nil
+}
+
+func newOwnershipRepo() *memberRepoStub {
+	owner := entity.NewActiveMember("ws-1", "owner-user", entity.WorkspaceRoleOwner)
+	owner.ID = "owner"
+	admin := entity.NewActiveMember("ws-1", "admin-user", entity.WorkspaceRoleAdmin)
+	admin.ID = "admin"
+	pending := entity.NewWorkspaceMember("ws-1", "pending-user", entity.WorkspaceRoleEditor, nil)
+	pending.ID = "pending"
+	other := entity.NewActiveMember("ws-2", "other-user", entity.WorkspaceRoleAdmin)
+	other.ID = "other"
+	return &memberRepoStub{
+		byID:   map[string]*entity.WorkspaceMember{"owner": owner, "admin": admin, "pending": pending, "other": other},
+		owners: 1,
+	}
+}
+
+func TestTransferOwnership_ChecksOwnersInTheTransferTransaction(t *testing.T) {
+	members := newOwnershipRepo()
+	s := NewWorkspaceMemberService(members, nil, nil, nil, invitationTxStub{})
+
+	err := s.TransferOwnership(context.Background(), organizationuc.TransferOwnershipCommand{
+		WorkspaceID: "ws-1", NewOwnerMemberID: "admin", RequestedBy: "owner-user",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "admin", members.transferredTo)
+	assert.True(t, members.writesInTx && members.ownersInTx, "the swap and the owner check share one transaction")
+}
+
+func TestTransferOwnership_RollsBackWhenNoOwnerIsLeft(t *testing.T) {
+	members := newOwnershipRepo()
+	members.owners = 0 // a concurrent role change demoted the new owner
+	s := NewWorkspaceMemberService(members, nil, nil, nil, invitationTxStub{})
+
+	err := s.TransferOwnership(context.Background(), organizationuc.TransferOwnershipCommand{
+		WorkspaceID: "ws-1", NewOwnerMemberID: "admin", RequestedBy: "owner-user",
+	})
+
+	assert.ErrorIs(t, err, entity.ErrLastWorkspaceOwner)
+	assert.True(t, members.ownersInTx, "the failed check fails the transaction")
+}
+
+func TestTransferOwnership_RejectsInvalidTargets(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		want   error
+	}{
+		{"current owner", "owner", entity.ErrInvalidOwnershipTarget},
+		{"pending member", "pending", entity.ErrInvalidOwnershipTarget},
+		{"member of another workspace", "other", entity.ErrMemberNotFound},
+		{"unknown member", "missing", entity.ErrMemberNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			members := newOwnershipRepo()
+			s := NewWorkspaceMemberService(members, nil, nil, nil, invitationTxStub{})
+
+			err := s.TransferOwnership(context.Background(), organizationuc.TransferOwnershipCommand{
+				WorkspaceID: "ws-1", NewOwnerMemberID: tt.target, RequestedBy: "owner-user",
+			})
+
+			assert.ErrorIs(t, err, tt.want)
+			assert.Empty(t, members.transferredTo)
+		})
+	}
+}
+
+func TestTransferOwnership_LostRaceIsRejected(t *testing.T) {
+	members := newOwnershipRepo()
+	members.transferErr = entity.ErrInvalidOwnershipTarget // the target was deactivated meanwhile
+	s := NewWorkspaceMemberService(members, nil, nil, nil, invitationTxStub{})
+
+	err := s.TransferOwnership(context.Background(), organizationuc.TransferOwnershipCommand{
+		WorkspaceID: "ws-1", NewOwnerMemberID: "admin", RequestedBy: "owner-user",
+	})
+
+	assert.ErrorIs(t, err, entity.ErrInvalidOwnershipTarget)
+	assert.False(t, members.ownersInTx, "owners are not counted after a rejected swap")
+}
@@ -0,0 +1,169 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
+)
+
+// expiredSandboxBatch is how many expired sandboxes are looked up per query.
+const expiredSandboxBatch = 50
+
+// NewWorkspaceSandboxService creates a new workspace sandbox service.
+func NewWorkspaceSandboxService(
+	workspaceRepo port.WorkspaceRepository,
+	memberRepo port.WorkspaceMemberRepository,
+	sandboxRepo port.WorkspaceSandboxRepository,
+	txManager port.TransactionManager,
+) organizationuc.WorkspaceSandboxUseCase {
+	return &WorkspaceSandboxService{
+		workspaceRepo: workspaceRepo,
+		memberRepo:    memberRepo,
+		sandboxRepo:   sandboxRepo,
+		txManager:     txManager,
+	}
+}
+
+// WorkspaceSandboxService implements sandbox workspace business logic.
+type WorkspaceSandboxService struct {
+	workspaceRepo port.WorkspaceRepository
+	memberRepo    port.WorkspaceMemberRepository
+	sandboxRepo   port.WorkspaceSandboxRepository
+	txManager     port.TransactionManager
+}
+
+// CreateSandbox clones a workspace into a new temporary workspace owned by the requester.
+func (s *WorkspaceSandboxService) CreateSandbox(
+	ctx context.Context,
+	cmd organizationuc.CreateSandboxCommand,
+) (*organizationuc.WorkspaceSandboxResult, error) {
+	ttl := cmd.TTL
+	if ttl == 0 {
+		ttl = entity.WorkspaceSandboxDefaultTTL
+	}
+	if ttl < time.Hour || ttl > entity.WorkspaceSandboxMaxTTL {
+		return nil, entity.ErrInvalidSandboxTTL
+	}
+
+	source, err := s.workspaceRepo.FindByID(ctx, cmd.SourceWorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("finding workspace %s: %w", cmd.SourceWorkspaceID, err)
+	}
+	if source.IsGlobal() {
+		return nil, entity.ErrCannotSandboxWorkspace
+	}
+	_, err = s.sandboxRepo.FindByWorkspaceID(ctx, source.ID)
+	if err == nil {
+		return nil, entity.ErrCannotSandboxWorkspace
+	}
+	if !errors.Is(err, entity.ErrSandboxNotFound) {
+		return nil, fmt.Errorf("checking sandbox status: %w", err)
+	}
+
+	// Sandboxes are always CLIENT workspaces: a tenant can only have one SYSTEM workspace.
+	workspace := &entity.Workspace{
+		ID:        uuid.NewString(),
+		TenantID:  source.TenantID,
+		Code:      sandboxCode(source.Code),
+		Name:      sandboxName(source.Name),
+		Type:      entity.WorkspaceTypeClient,
+		Status:    entity.WorkspaceStatusActive,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := workspace.Validate(); err != nil {
+		return nil, fmt.Errorf("validating sandbox workspace: %w", err)
+	}
+
+	result := &organizationuc.WorkspaceSandboxResult{Workspace: workspace}
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		id, err := s.workspaceRepo.Create(ctx, workspace)
+		if err != nil {
+			return fmt.Errorf("creating sandbox workspace: %w", err)
+		}
+		workspace.ID = id
+
+		// The requester is the only member: source members are not copied.
+		member := entity.NewActiveMember(workspace.ID, cmd.CreatedBy, entity.WorkspaceRoleOwner)
+		member.ID = uuid.NewString()
+		if _, err := s.memberRepo.Create(ctx, member); err != nil {
+			return fmt.Errorf("adding sandbox owner: %w", err)
+		}
+
+		result.Cloned, err = s.sandboxRepo.CloneContent(ctx, source.ID, workspace.ID)
+		if err != nil {
+			return fmt.Errorf("cloning workspace content: %w", err)
+		}
+
+		result.Sandbox = entity.NewWorkspaceSandbox(workspace.ID, source.ID, cmd.CreatedBy, ttl)
+		if err := s.sandboxRepo.Create(ctx, result.Sandbox); err != nil {
+			return fmt.Errorf("creating sandbox: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "workspace sandbox created",
+		slog.String("workspace_id", workspace.ID),
+		slog.String("source_workspace_id", source.ID),
+		slog.String("created_by", cmd.CreatedBy),
+		slog.Time("expires_at", result.Sandbox.ExpiresAt),
+		slog.Int("templates", result.Cloned.Templates),
+		slog.Int("injectables", result.Cloned.Injectables),
+	)
+
+	return result, nil
+}
+
+// DeleteExpiredSandboxes permanently deletes the sandboxes past their expiry.
+func (s *WorkspaceSandboxService) DeleteExpiredSandboxes(ctx context.Context) (int, error) {
+	deleted := 0
+	for {
+		ids, err := s.sandboxRepo.FindExpired(ctx, time.Now().UTC(), expiredSandboxBatch)
+		if err != nil {
+			return deleted, fmt.Errorf("finding expired sandboxes: %w", err)
+		}
+		if len(ids) == 0 {
+			return deleted, nil
+		}
+
+		for _, id := range ids {
+			err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+				return s.sandboxRepo.DeleteWorkspace(ctx, id)
+			})
+			if err != nil {
+				return deleted, fmt.Errorf("deleting sandbox %s: %w", id, err)
+			}
+			deleted++
+			slog.InfoContext(ctx, "expired workspace sandbox deleted", slog.String("workspace_id", id))
+		}
+	}
+}
+
+// sandboxCode derives a unique workspace code from the source code, within the 50 character limit.
+func sandboxCode(sourceCode string) string {
+	const suffixLen = len("_SBX_") + 6
+	if len(sourceCode) > 50-suffixLen {
+		sourceCode = sourceCode[:50-suffixLen]
+	}
+	return fmt.Sprintf("%s_SBX_%s", sourceCode, strings.ToUpper(uuid.NewString()[:6]))
+}
+
+// sandboxName derives the sandbox workspace name from the source name.
+func sandboxName(sourceName string) string {
+	const suffix = " (sandbox)"
+	if len(sourceName) > 255-len(suffix) {
+		sourceName = sourceName[:255-len(suffix)]
+	}
+	return sourceName + suffix
+}
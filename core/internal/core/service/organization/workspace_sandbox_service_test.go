@@ -0,0 +1,74 @@
+package organization
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
+)
+
+type sandboxWorkspaceRepoStub struct {
+	port.WorkspaceRepository
+	workspaces map[string]*entity.Workspace
+}
+
+func (r sandboxWorkspaceRepoStub) FindByID(_ context.Context, id string) (*entity.Workspace, error) {
+	ws, ok := r.workspaces[id]
+	if !ok {
+		return nil, entity.ErrWorkspaceNotFound
+	}
+	return ws, nil
+}
+
+type sandboxRepoStub struct {
+	port.WorkspaceSandboxRepository
+	sandboxes map[string]*entity.WorkspaceSandbox
+}
+
+func (r sandboxRepoStub) FindByWorkspaceID(_ context.Context, id string) (*entity.WorkspaceSandbox, error) {
+	s, ok := r.sandboxes[id]
+	if !ok {
+		return nil, entity.ErrSandboxNotFound
+	}
+	return s, nil
+}
+
+func TestCreateSandbox_RejectsInvalidSources(t *testing.T) {
+	tenantID := "tenant-1"
+	workspaces := sandboxWorkspaceRepoStub{workspaces: map[string]*entity.Workspace{
+		"global":  {ID: "global", Code: "GLOBAL", Name: "Global", Type: entity.WorkspaceTypeSystem},
+		"sandbox": {ID: "sandbox", TenantID: &tenantID, Code: "ACME_SBX_1A2B3C", Name: "Acme (sandbox)", Type: entity.WorkspaceTypeClient},
+	}}
+	sandboxes := sandboxRepoStub{sandboxes: map[string]*entity.WorkspaceSandbox{
+		"sandbox": entity.NewWorkspaceSandbox("sandbox", "acme", "user-1", time.Hour),
+	}}
+	s := NewWorkspaceSandboxService(workspaces, nil, sandboxes, nil)
+
+	for _, id := range []string{"global", "sandbox"} {
+		_, err := s.CreateSandbox(context.Background(), organizationuc.CreateSandboxCommand{SourceWorkspaceID: id, CreatedBy: "user-1"})
+		assert.ErrorIs(t, err, entity.ErrCannotSandboxWorkspace, id)
+	}
+
+	for _, ttl := range []time.Duration{time.Minute, entity.WorkspaceSandboxMaxTTL + time.Hour} {
+		_, err := s.CreateSandbox(context.Background(), organizationuc.CreateSandboxCommand{SourceWorkspaceID: "global", TTL: ttl})
+		assert.ErrorIs(t, err, entity.ErrInvalidSandboxTTL, ttl.String())
+	}
+}
+
+func TestSandboxCode_FitsWorkspaceCodeLimit(t *testing.T) {
+	code := sandboxCode(strings.Repeat("A", 50))
+	require.Len(t, code, 50)
+	assert.Contains(t, code, "_SBX_")
+
+	ws := &entity.Workspace{Code: sandboxCode("ACME"), Name: sandboxName("Acme"), Type: entity.WorkspaceTypeClient, Status: entity.WorkspaceStatusActive}
+	ws.TenantID = new(string)
+	assert.NoError(t, ws.Validate())
+	assert.Equal(t, "Acme (sandbox)", ws.Name)
+}
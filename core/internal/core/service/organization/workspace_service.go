@@ -19,12 +19,14 @@ func NewWorkspaceService(
 	tenantRepo port.TenantRepository,
 	memberRepo port.WorkspaceMemberRepository,
 	accessHistoryRepo port.UserAccessHistoryRepository,
+	settingsRepo port.WorkspaceSettingsRepository,
 ) organizationuc.WorkspaceUseCase {
 	return &WorkspaceService{
 		workspaceRepo:     workspaceRepo,
 		tenantRepo:        tenantRepo,
 		memberRepo:        memberRepo,
 		accessHistoryRepo: accessHistoryRepo,
+		settingsRepo:      settingsRepo,
 	}
 }
 
@@ -34,6 +36,7 @@ type WorkspaceService struct {
 	tenantRepo        port.TenantRepository
 	memberRepo        port.WorkspaceMemberRepository
 	accessHistoryRepo port.UserAccessHistoryRepository
+	settingsRepo      port.WorkspaceSettingsRepository
 }
 
 // CreateWorkspace creates a new workspace.
@@ -93,6 +96,10 @@ func (s *WorkspaceService) CreateWorkspace(ctx context.Context, cmd organization
 		)
 	}
 
+	if workspace.Type == entity.WorkspaceTypeClient {
+		s.inheritTenantDefaults(ctx, workspace, cmd.CreatedBy)
+	}
+
 	slog.InfoContext(ctx, "workspace created",
 		slog.String("workspace_id", workspace.ID),
 		slog.String("name", workspace.Name),
@@ -102,6 +109,22 @@ func (s *WorkspaceService) CreateWorkspace(ctx context.Context, cmd organization
 	return workspace, nil
 }
 
+// inheritTenantDefaults copies the workspace defaults of its tenant to a new workspace.
+// Locked sections apply even if the copy fails, since they are read from the tenant.
+func (s *WorkspaceService) inheritTenantDefaults(ctx context.Context, workspace *entity.Workspace, createdBy string) {
+	defaults, err := s.settingsRepo.FindTenantDefaults(ctx, *workspace.TenantID)
+	if err == nil && defaults.UpdatedAt != nil {
+		err = s.settingsRepo.Upsert(ctx, workspace.ID, defaults.WithoutLocked(&defaults.Settings), &createdBy)
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "failed to inherit tenant workspace defaults",
+			slog.String("workspace_id", workspace.ID),
+			slog.String("tenant_id", *workspace.TenantID),
+			slog.Any("error", err),
+		)
+	}
+}
+
 // GetWorkspace retrieves a workspace by ID.
 func (s *WorkspaceService) GetWorkspace(ctx context.Context, id string) (*entity.Workspace, error) {
 	workspace, err := s.workspaceRepo.FindByID(ctx, id)
@@ -0,0 +1,135 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
+)
+
+// NewWorkspaceSettingsService creates a new workspace settings service.
+func NewWorkspaceSettingsService(
+	settingsRepo port.WorkspaceSettingsRepository,
+	workspaceRepo port.WorkspaceRepository,
+) organizationuc.WorkspaceSettingsUseCase {
+	return &WorkspaceSettingsService{
+		settingsRepo:  settingsRepo,
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+// WorkspaceSettingsService implements workspace settings business logic.
+type WorkspaceSettingsService struct {
+	settingsRepo  port.WorkspaceSettingsRepository
+	workspaceRepo port.WorkspaceRepository
+}
+
+// GetTenantDefaults gets the settings new workspaces of a tenant start with.
+func (s *WorkspaceSettingsService) GetTenantDefaults(ctx context.Context, tenantID string) (*entity.TenantWorkspaceDefaults, error) {
+	return s.settingsRepo.FindTenantDefaults(ctx, tenantID)
+}
+
+// UpdateTenantDefaults replaces the workspace defaults of a tenant.
+func (s *WorkspaceSettingsService) UpdateTenantDefaults(
+	ctx context.Context,
+	cmd organizationuc.UpdateTenantWorkspaceDefaultsCommand,
+) (*entity.TenantWorkspaceDefaults, error) {
+	now := time.Now().UTC()
+	defaults := &entity.TenantWorkspaceDefaults{
+		TenantID:  cmd.TenantID,
+		Settings:  cmd.Settings,
+		Locked:    cmd.Locked,
+		UpdatedBy: &cmd.UpdatedBy,
+		UpdatedAt: &now,
+	}
+	if defaults.Locked == nil {
+		defaults.Locked = []entity.WorkspaceSettingKey{}
+	}
+	if err := defaults.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.settingsRepo.UpsertTenantDefaults(ctx, defaults); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "tenant workspace defaults updated",
+		slog.String("tenant_id", cmd.TenantID),
+		slog.Any("locked", defaults.Locked),
+		slog.String("updated_by", cmd.UpdatedBy),
+	)
+	return defaults, nil
+}
+
+// GetWorkspaceSettings gets the settings a workspace applies, with the sections its tenant locked.
+func (s *WorkspaceSettingsService) GetWorkspaceSettings(ctx context.Context, workspaceID string) (*entity.WorkspaceSettingsView, error) {
+	defaults, err := s.workspaceDefaults(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.settingsRepo.FindByWorkspaceID(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	return settingsView(workspaceID, settings, defaults), nil
+}
+
+// UpdateWorkspaceSettings replaces the settings of a workspace. Locked sections are not stored:
+// they always take the tenant value.
+func (s *WorkspaceSettingsService) UpdateWorkspaceSettings(
+	ctx context.Context,
+	cmd organizationuc.UpdateWorkspaceSettingsCommand,
+) (*entity.WorkspaceSettingsView, error) {
+	defaults, err := s.workspaceDefaults(ctx, cmd.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := cmd.Settings
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+	if err := defaults.CheckOverride(&settings); err != nil {
+		return nil, err
+	}
+	stored := defaults.WithoutLocked(&settings)
+	if err := s.settingsRepo.Upsert(ctx, cmd.WorkspaceID, stored, &cmd.UpdatedBy); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "workspace settings updated",
+		slog.String("workspace_id", cmd.WorkspaceID),
+		slog.String("updated_by", cmd.UpdatedBy),
+	)
+	return settingsView(cmd.WorkspaceID, stored, defaults), nil
+}
+
+// workspaceDefaults returns the workspace defaults of the tenant of a workspace, nil for global workspaces.
+func (s *WorkspaceSettingsService) workspaceDefaults(ctx context.Context, workspaceID string) (*entity.TenantWorkspaceDefaults, error) {
+	workspace, err := s.workspaceRepo.FindByID(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("finding workspace %s: %w", workspaceID, err)
+	}
+	if workspace.TenantID == nil {
+		return nil, nil
+	}
+	return s.settingsRepo.FindTenantDefaults(ctx, *workspace.TenantID)
+}
+
+func settingsView(workspaceID string, settings *entity.WorkspaceSettings, defaults *entity.TenantWorkspaceDefaults) *entity.WorkspaceSettingsView {
+	return &entity.WorkspaceSettingsView{
+		WorkspaceID: workspaceID,
+		Settings:    *defaults.Effective(settings),
+		Locked:      lockedKeys(defaults),
+	}
+}
+
+func lockedKeys(defaults *entity.TenantWorkspaceDefaults) []entity.WorkspaceSettingKey {
+	if defaults == nil {
+		return []entity.WorkspaceSettingKey{}
+	}
+	return defaults.Locked
+}
@@ -0,0 +1,207 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// RelayOptions configures the outbox relay.
+type RelayOptions struct {
+	// PollInterval is how often the relay looks for due events.
+	PollInterval time.Duration
+	// Lease is how long a claimed event stays hidden from other relays.
+	// It must exceed the time publishers need for one event.
+	Lease time.Duration
+	// BatchSize is the maximum number of events claimed per poll.
+	BatchSize int
+	// MaxAttempts is the number of deliveries tried before an event is marked failed.
+	MaxAttempts int
+	// Retention is how long delivered events are kept. Zero keeps them forever.
+	Retention time.Duration
+}
+
+const (
+	minRetryDelay = 5 * time.Second
+	maxRetryDelay = time.Hour
+)
+
+// Relay delivers outbox events to the registered publishers.
+// Several relays (one per API instance) can run against the same database.
+type Relay struct {
+	repo       port.OutboxRepository
+	publishers []port.EventPublisher
+	opts       RelayOptions
+	stopCh     chan struct{}
+	stopped    chan struct{}
+	stopOnce   sync.Once
+}
+
+// NewRelay creates an outbox relay. Call Start to begin polling.
+func NewRelay(repo port.OutboxRepository, publishers []port.EventPublisher, opts RelayOptions) *Relay {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 2 * time.Second
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = time.Minute
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 50
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 10
+	}
+
+	return &Relay{
+		repo:       repo,
+		publishers: publishers,
+		opts:       opts,
+		stopCh:     make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+}
+
+// Start runs the polling loop in the background until Stop is called.
+func (r *Relay) Start() {
+	go r.loop()
+}
+
+// Stop ends the polling loop and waits for the batch in flight to finish.
+func (r *Relay) Stop() {
+	r.stopOnce.Do(func() { close(r.stopCh) })
+	<-r.stopped
+}
+
+func (r *Relay) loop() {
+	defer close(r.stopped)
+	ticker := time.NewTicker(r.opts.PollInterval)
+	defer ticker.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-r.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	lastPurge := time.Time{}
+	for {
+		select {
+		case <-r.stopCh:
+			return
+		case <-ticker.C:
+			if _, err := r.RunOnce(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				slog.WarnContext(ctx, "outbox relay poll failed", slog.Any("error", err))
+			}
+			if r.opts.Retention > 0 && time.Since(lastPurge) >= time.Hour {
+				r.purge(ctx)
+				lastPurge = time.Now()
+			}
+		}
+	}
+}
+
+// RunOnce claims one batch of due events and delivers it, returning the number delivered.
+func (r *Relay) RunOnce(ctx context.Context) (int, error) {
+	events, err := r.repo.ClaimBatch(ctx, r.opts.BatchSize, r.opts.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, event := range events {
+		if ctx.Err() != nil {
+			// Unprocessed claims become visible again once the lease expires
+			return delivered, ctx.Err()
+		}
+		if r.deliver(ctx, event) {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// deliver publishes one event and records the outcome.
+func (r *Relay) deliver(ctx context.Context, event *entity.OutboxEvent) bool {
+	var errs []error
+	for _, p := range r.publishers {
+		if err := p.Publish(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.Name(), err))
+		}
+	}
+
+	if len(errs) == 0 {
+		if err := r.repo.MarkDelivered(ctx, event.ID); err != nil {
+			slog.WarnContext(ctx, "outbox relay could not record delivery",
+				slog.String("event_id", event.ID),
+				slog.Any("error", err),
+			)
+		}
+		return true
+	}
+
+	lastError := errors.Join(errs...).Error()
+	if event.Attempts >= r.opts.MaxAttempts {
+		slog.ErrorContext(ctx, "outbox event delivery failed permanently",
+			slog.String("event_id", event.ID),
+			slog.String("event_type", string(event.Type)),
+			slog.Int("attempts", event.Attempts),
+			slog.String("error", lastError),
+		)
+		if err := r.repo.MarkFailed(ctx, event.ID, lastError); err != nil {
+			slog.WarnContext(ctx, "outbox relay could not record failure",
+				slog.String("event_id", event.ID),
+				slog.Any("error", err),
+			)
+		}
+		return false
+	}
+
+	slog.WarnContext(ctx, "outbox event delivery failed, will retry",
+		slog.String("event_id", event.ID),
+		slog.String("event_type", string(event.Type)),
+		slog.Int("attempts", event.Attempts),
+		slog.String("error", lastError),
+	)
+	if err := r.repo.MarkRetry(ctx, event.ID, lastError, time.Now().Add(RetryDelay(event.Attempts))); err != nil {
+		slog.WarnContext(ctx, "outbox relay could not schedule retry",
+			slog.String("event_id", event.ID),
+			slog.Any("error", err),
+		)
+	}
+	return false
+}
+
+// purge removes delivered events older than the retention window.
+func (r *Relay) purge(ctx context.Context) {
+	removed, err := r.repo.DeleteDeliveredBefore(ctx, time.Now().Add(-r.opts.Retention))
+	if err != nil {
+		slog.WarnContext(ctx, "outbox purge failed", slog.Any("error", err))
+		return
+	}
+	if removed > 0 {
+		slog.InfoContext(ctx, "outbox purge", slog.Int64("removed", removed))
+	}
+}
+
+// RetryDelay returns the backoff before the next delivery after the given number of attempts:
+// 5s, 10s, 20s, ... capped at one hour.
+func RetryDelay(attempts int) time.Duration {
+	delay := minRetryDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxRetryDelay {
+			return maxRetryDelay
+		}
+	}
+	return delay
+}
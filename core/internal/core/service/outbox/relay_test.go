@@ -0,0 +1,102 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+func TestRelay_RunOnceMarksDelivered(t *testing.T) {
+	repo := &fakeOutboxRepo{batch: []*entity.OutboxEvent{{ID: "evt-1", Attempts: 1}}}
+	publisher := &fakePublisher{}
+	relay := NewRelay(repo, []port.EventPublisher{publisher}, RelayOptions{})
+
+	delivered, err := relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, []string{"evt-1"}, repo.delivered)
+	assert.Equal(t, 1, publisher.calls)
+}
+
+func TestRelay_RunOnceSchedulesRetry(t *testing.T) {
+	repo := &fakeOutboxRepo{batch: []*entity.OutboxEvent{{ID: "evt-1", Attempts: 2}}}
+	relay := NewRelay(repo, []port.EventPublisher{&fakePublisher{err: errors.New("boom")}}, RelayOptions{MaxAttempts: 3})
+
+	delivered, err := relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+	assert.Empty(t, repo.delivered)
+	assert.Equal(t, []string{"evt-1"}, repo.retried)
+	assert.Contains(t, repo.lastError, "fake: boom")
+}
+
+func TestRelay_RunOnceFailsAfterMaxAttempts(t *testing.T) {
+	repo := &fakeOutboxRepo{batch: []*entity.OutboxEvent{{ID: "evt-1", Attempts: 3}}}
+	relay := NewRelay(repo, []port.EventPublisher{&fakePublisher{err: errors.New("boom")}}, RelayOptions{MaxAttempts: 3})
+
+	_, err := relay.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, repo.retried)
+	assert.Equal(t, []string{"evt-1"}, repo.failed)
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 5*time.Second, RetryDelay(1))
+	assert.Equal(t, 10*time.Second, RetryDelay(2))
+	assert.Equal(t, 40*time.Second, RetryDelay(4))
+	assert.Equal(t, time.Hour, RetryDelay(50))
+}
+
+type fakeOutboxRepo struct {
+	batch     []*entity.OutboxEvent
+	delivered []string
+	retried   []string
+	failed    []string
+	lastError string
+}
+
+func (f *fakeOutboxRepo) Append(context.Context, *entity.OutboxEvent) error { return nil }
+
+func (f *fakeOutboxRepo) ClaimBatch(context.Context, int, time.Duration) ([]*entity.OutboxEvent, error) {
+	return f.batch, nil
+}
+
+func (f *fakeOutboxRepo) MarkDelivered(_ context.Context, id string) error {
+	f.delivered = append(f.delivered, id)
+	return nil
+}
+
+func (f *fakeOutboxRepo) MarkRetry(_ context.Context, id, lastError string, _ time.Time) error {
+	f.retried = append(f.retried, id)
+	f.lastError = lastError
+	return nil
+}
+
+func (f *fakeOutboxRepo) MarkFailed(_ context.Context, id, lastError string) error {
+	f.failed = append(f.failed, id)
+	f.lastError = lastError
+	return nil
+}
+
+func (f *fakeOutboxRepo) DeleteDeliveredBefore(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+type fakePublisher struct {
+	err   error
+	calls int
+}
+
+func (f *fakePublisher) Name() string { return "fake" }
+
+func (f *fakePublisher) Publish(context.Context, *entity.OutboxEvent) error {
+	f.calls++
+	return f.err
+}
@@ -0,0 +1,79 @@
+package platform
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	platformuc "github.com/rendis/pdf-forge/core/internal/core/usecase/platform"
+)
+
+// CleanupJob runs the orphaned data cleanup periodically. Runs are skipped while maintenance
+// blocks writes.
+type CleanupJob struct {
+	cleanupUC     platformuc.CleanupUseCase
+	maintenanceUC platformuc.MaintenanceUseCase
+	interval      time.Duration
+	dryRun        bool
+	stopCh        chan struct{}
+	stopped       chan struct{}
+	stopOnce      sync.Once
+}
+
+// NewCleanupJob creates a job that runs the cleanup every interval, only reporting the orphans
+// when dryRun is set. Call Start to begin.
+func NewCleanupJob(
+	cleanupUC platformuc.CleanupUseCase,
+	maintenanceUC platformuc.MaintenanceUseCase,
+	interval time.Duration,
+	dryRun bool,
+) *CleanupJob {
+	if interval <= 0 {
+		interval = 6 * time.Hour
+	}
+	return &CleanupJob{
+		cleanupUC:     cleanupUC,
+		maintenanceUC: maintenanceUC,
+		interval:      interval,
+		dryRun:        dryRun,
+		stopCh:        make(chan struct{}),
+		stopped:       make(chan struct{}),
+	}
+}
+
+// Start runs the cleanup loop in the background until Stop is called.
+func (j *CleanupJob) Start() {
+	go j.loop()
+}
+
+// Stop ends the loop and waits for the run in flight to finish.
+func (j *CleanupJob) Stop() {
+	j.stopOnce.Do(func() { close(j.stopCh) })
+	<-j.stopped
+}
+
+func (j *CleanupJob) loop() {
+	defer close(j.stopped)
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-j.stopCh:
+			return
+		case <-ticker.C:
+			j.tick(context.Background())
+		}
+	}
+}
+
+func (j *CleanupJob) tick(ctx context.Context) {
+	if state := j.maintenanceUC.CurrentState(ctx); state.BlocksWrites() {
+		slog.InfoContext(ctx, "cleanup run skipped during maintenance", slog.String("mode", string(state.Mode)))
+		return
+	}
+	if _, err := j.cleanupUC.RunCleanup(ctx, platformuc.RunCleanupCommand{DryRun: j.dryRun}); err != nil {
+		slog.ErrorContext(ctx, "scheduled cleanup run failed", slog.Any("error", err))
+	}
+}
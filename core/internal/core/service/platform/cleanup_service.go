@@ -0,0 +1,210 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	platformuc "github.com/rendis/pdf-forge/core/internal/core/usecase/platform"
+)
+
+const (
+	// cleanupDeleteBatch is how many orphans are deleted per statement.
+	cleanupDeleteBatch = 100
+
+	// cleanupRunRetention is how long cleanup runs are kept.
+	cleanupRunRetention = 90 * 24 * time.Hour
+
+	cleanupDefaultRunsListed = 20
+	cleanupMaxRunsListed     = 100
+)
+
+// CleanupOptions configures the orphaned data cleanup.
+type CleanupOptions struct {
+	// MaxPerCategory bounds the orphans found and deleted per category in a run.
+	MaxPerCategory int
+	// PreviewTokenRetention is how long preview tokens are kept after they expire or are revoked.
+	PreviewTokenRetention time.Duration
+	// UnusedImageRetention is how long an unused image asset is kept after its last change.
+	// Zero reports unused images without deleting them.
+	UnusedImageRetention time.Duration
+}
+
+// NewCleanupService creates a new orphaned data cleanup service.
+func NewCleanupService(
+	cleanupRepo port.CleanupRepository,
+	sandboxRepo port.WorkspaceSandboxRepository,
+	txManager port.TransactionManager,
+	opts CleanupOptions,
+) platformuc.CleanupUseCase {
+	if opts.MaxPerCategory <= 0 {
+		opts.MaxPerCategory = 1000
+	}
+	return &CleanupService{
+		cleanupRepo: cleanupRepo,
+		sandboxRepo: sandboxRepo,
+		txManager:   txManager,
+		opts:        opts,
+	}
+}
+
+// CleanupService implements the CleanupUseCase interface.
+type CleanupService struct {
+	cleanupRepo port.CleanupRepository
+	sandboxRepo port.WorkspaceSandboxRepository
+	txManager   port.TransactionManager
+	opts        CleanupOptions
+}
+
+// cleaner finds and deletes the orphans of a category.
+type cleaner struct {
+	find   func(ctx context.Context, limit int) ([]string, error)
+	delete func(ctx context.Context, ids []string) (int, error) // nil keeps the orphans
+}
+
+// RunCleanup cleans every category and records the run.
+func (s *CleanupService) RunCleanup(ctx context.Context, cmd platformuc.RunCleanupCommand) (*entity.CleanupRun, error) {
+	now := time.Now().UTC()
+	run := &entity.CleanupRun{DryRun: cmd.DryRun, StartedAt: now}
+	if cmd.TriggeredBy != "" {
+		run.TriggeredBy = &cmd.TriggeredBy
+	}
+
+	for _, category := range entity.CleanupCategories {
+		run.Results = append(run.Results, s.clean(ctx, category, s.cleaner(category, now), cmd.DryRun))
+	}
+	run.FinishedAt = time.Now().UTC()
+
+	id, err := s.cleanupRepo.CreateRun(ctx, run)
+	if err != nil {
+		return nil, fmt.Errorf("recording cleanup run: %w", err)
+	}
+	run.ID = id
+
+	if _, err := s.cleanupRepo.DeleteRunsBefore(ctx, now.Add(-cleanupRunRetention)); err != nil {
+		slog.WarnContext(ctx, "cleanup run retention failed", slog.Any("error", err))
+	}
+
+	attrs := []any{slog.String("cleanup_run_id", id), slog.Bool("dry_run", run.DryRun)}
+	for _, result := range run.Results {
+		attrs = append(attrs, slog.Group(string(result.Category),
+			slog.Int("found", result.Found),
+			slog.Int("deleted", result.Deleted),
+		))
+	}
+	slog.InfoContext(ctx, "cleanup run finished", attrs...)
+	return run, nil
+}
+
+// cleaner returns the cleaner of a category for a run started at now.
+func (s *CleanupService) cleaner(category entity.CleanupCategory, now time.Time) cleaner {
+	switch category {
+	case entity.CleanupOrphanedVersionInjectables:
+		return cleaner{
+			find:   s.cleanupRepo.FindOrphanedVersionInjectables,
+			delete: s.cleanupRepo.DeleteOrphanedVersionInjectables,
+		}
+
+	case entity.CleanupUnusedImages:
+		cutoff := now.Add(-s.opts.UnusedImageRetention)
+		c := cleaner{
+			find: func(ctx context.Context, limit int) ([]string, error) {
+				return s.cleanupRepo.FindUnusedImageAssets(ctx, cutoff, limit)
+			},
+		}
+		if s.opts.UnusedImageRetention > 0 {
+			c.delete = func(ctx context.Context, ids []string) (int, error) {
+				return s.cleanupRepo.DeleteUnusedImageAssets(ctx, ids, cutoff)
+			}
+		}
+		return c
+
+	case entity.CleanupExpiredPreviewTokens:
+		cutoff := now.Add(-s.opts.PreviewTokenRetention)
+		return cleaner{
+			find: func(ctx context.Context, limit int) ([]string, error) {
+				return s.cleanupRepo.FindExpiredPreviewTokens(ctx, cutoff, limit)
+			},
+			delete: func(ctx context.Context, ids []string) (int, error) {
+				return s.cleanupRepo.DeleteExpiredPreviewTokens(ctx, ids, cutoff)
+			},
+		}
+
+	case entity.CleanupStaleSandboxes:
+		return cleaner{
+			find: func(ctx context.Context, limit int) ([]string, error) {
+				return s.cleanupRepo.FindStaleSandboxes(ctx, now, limit)
+			},
+			delete: s.deleteSandboxes,
+		}
+	}
+	return cleaner{
+		find: func(context.Context, int) ([]string, error) {
+			return nil, fmt.Errorf("unknown cleanup category %s", category)
+		},
+	}
+}
+
+// clean finds the orphans of a category and deletes them in batches unless dryRun is set.
+func (s *CleanupService) clean(ctx context.Context, category entity.CleanupCategory, c cleaner, dryRun bool) *entity.CleanupResult {
+	result := &entity.CleanupResult{Category: category, Kept: c.delete == nil, SampleIDs: []string{}}
+	fail := func(err error) *entity.CleanupResult {
+		msg := err.Error()
+		result.Error = &msg
+		slog.ErrorContext(ctx, "cleanup failed",
+			slog.String("category", string(category)),
+			slog.Int("deleted", result.Deleted),
+			slog.Any("error", err),
+		)
+		return result
+	}
+
+	ids, err := c.find(ctx, s.opts.MaxPerCategory)
+	if err != nil {
+		return fail(err)
+	}
+	result.Found = len(ids)
+	result.SampleIDs = append(result.SampleIDs, ids[:min(len(ids), entity.CleanupSampleSize)]...)
+	if dryRun || c.delete == nil {
+		return result
+	}
+
+	for start := 0; start < len(ids); start += cleanupDeleteBatch {
+		deleted, err := c.delete(ctx, ids[start:min(start+cleanupDeleteBatch, len(ids))])
+		result.Deleted += deleted
+		if err != nil {
+			return fail(err)
+		}
+	}
+	return result
+}
+
+// deleteSandboxes deletes sandbox workspaces one transaction each, like the expired sandbox reaper.
+func (s *CleanupService) deleteSandboxes(ctx context.Context, ids []string) (int, error) {
+	deleted := 0
+	for _, id := range ids {
+		err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+			return s.sandboxRepo.DeleteWorkspace(ctx, id)
+		})
+		if err != nil {
+			return deleted, fmt.Errorf("deleting sandbox %s: %w", id, err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// ListCleanupRuns lists the latest cleanup runs. A limit of 0 lists the default number of runs.
+func (s *CleanupService) ListCleanupRuns(ctx context.Context, limit int) ([]*entity.CleanupRun, error) {
+	if limit <= 0 {
+		limit = cleanupDefaultRunsListed
+	}
+	runs, err := s.cleanupRepo.FindRuns(ctx, min(limit, cleanupMaxRunsListed))
+	if err != nil {
+		return nil, fmt.Errorf("listing cleanup runs: %w", err)
+	}
+	return runs, nil
+}
@@ -0,0 +1,211 @@
+package platform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	platformuc "github.com/rendis/pdf-forge/core/internal/core/usecase/platform"
+)
+
+type cleanupRepoStub struct {
+	injectables []string
+	images      []string
+	tokens      []string
+	sandboxes   []string
+	findErr     error // Returned when finding preview tokens
+
+	deletedInjectables []string
+	deletedImages      []string
+	deletedTokens      []string
+	imageCutoff        time.Time
+	tokenCutoff        time.Time
+	runs               []*entity.CleanupRun
+}
+
+func (r *cleanupRepoStub) FindOrphanedVersionInjectables(_ context.Context, limit int) ([]string, error) {
+	return r.injectables[:min(limit, len(r.injectables))], nil
+}
+
+func (r *cleanupRepoStub) DeleteOrphanedVersionInjectables(_ context.Context, ids []string) (int, error) {
+	r.deletedInjectables = append(r.deletedInjectables, ids...)
+	return len(ids), nil
+}
+
+func (r *cleanupRepoStub) FindUnusedImageAssets(_ context.Context, before time.Time, _ int) ([]string, error) {
+	r.imageCutoff = before
+	return r.images, nil
+}
+
+func (r *cleanupRepoStub) DeleteUnusedImageAssets(_ context.Context, ids []string, _ time.Time) (int, error) {
+	r.deletedImages = append(r.deletedImages, ids...)
+	return len(ids), nil
+}
+
+func (r *cleanupRepoStub) FindExpiredPreviewTokens(_ context.Context, before time.Time, _ int) ([]string, error) {
+	r.tokenCutoff = before
+	return r.tokens, r.findErr
+}
+
+func (r *cleanupRepoStub) DeleteExpiredPreviewTokens(_ context.Context, ids []string, _ time.Time) (int, error) {
+	r.deletedTokens = append(r.deletedTokens, ids...)
+	return len(ids), nil
+}
+
+func (r *cleanupRepoStub) FindStaleSandboxes(context.Context, time.Time, int) ([]string, error) {
+	return r.sandboxes, nil
+}
+
+func (r *cleanupRepoStub) CreateRun(_ context.Context, run *entity.CleanupRun) (string, error) {
+	r.runs = append(r.runs, run)
+	return fmt.Sprintf("run-%d", len(r.runs)), nil
+}
+
+func (r *cleanupRepoStub) FindRuns(context.Context, int) ([]*entity.CleanupRun, error) {
+	return r.runs, nil
+}
+
+func (r *cleanupRepoStub) DeleteRunsBefore(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+type sandboxRepoStub struct {
+	port.WorkspaceSandboxRepository
+	deleted []string
+}
+
+func (r *sandboxRepoStub) DeleteWorkspace(_ context.Context, workspaceID string) error {
+	r.deleted = append(r.deleted, workspaceID)
+	return nil
+}
+
+type txManagerStub struct{}
+
+func (txManagerStub) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func testIDs(prefix string, n int) []string {
+	result := make([]string, n)
+	for i := range result {
+		result[i] = fmt.Sprintf("%s-%d", prefix, i)
+	}
+	return result
+}
+
+func resultOf(t *testing.T, run *entity.CleanupRun, category entity.CleanupCategory) *entity.CleanupResult {
+	t.Helper()
+	for _, result := range run.Results {
+		if result.Category == category {
+			return result
+		}
+	}
+	t.Fatalf("no result for %s", category)
+	return nil
+}
+
+func TestRunCleanup_DryRunDeletesNothing(t *testing.T) {
+	repo := &cleanupRepoStub{
+		injectables: testIDs("tvi", 30),
+		tokens:      testIDs("token", 2),
+		sandboxes:   testIDs("sandbox", 1),
+	}
+	sandboxes := &sandboxRepoStub{}
+	svc := NewCleanupService(repo, sandboxes, txManagerStub{}, CleanupOptions{})
+
+	run, err := svc.RunCleanup(context.Background(), platformuc.RunCleanupCommand{DryRun: true, TriggeredBy: "admin"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "run-1", run.ID)
+	assert.True(t, run.DryRun)
+	require.NotNil(t, run.TriggeredBy)
+	assert.Equal(t, "admin", *run.TriggeredBy)
+	assert.Len(t, run.Results, len(entity.CleanupCategories))
+
+	injectables := resultOf(t, run, entity.CleanupOrphanedVersionInjectables)
+	assert.Equal(t, 30, injectables.Found)
+	assert.Zero(t, injectables.Deleted)
+	assert.Len(t, injectables.SampleIDs, entity.CleanupSampleSize)
+
+	assert.Empty(t, repo.deletedInjectables)
+	assert.Empty(t, repo.deletedTokens)
+	assert.Empty(t, sandboxes.deleted)
+}
+
+func TestRunCleanup_DeletesInBatchesAndKeepsImages(t *testing.T) {
+	repo := &cleanupRepoStub{
+		injectables: testIDs("tvi", 250),
+		images:      testIDs("image", 3),
+		tokens:      testIDs("token", 2),
+		sandboxes:   testIDs("sandbox", 2),
+	}
+	sandboxes := &sandboxRepoStub{}
+	svc := NewCleanupService(repo, sandboxes, txManagerStub{}, CleanupOptions{
+		MaxPerCategory:        200,
+		PreviewTokenRetention: 7 * 24 * time.Hour,
+	})
+
+	before := time.Now()
+	run, err := svc.RunCleanup(context.Background(), platformuc.RunCleanupCommand{})
+	require.NoError(t, err)
+
+	assert.Nil(t, run.TriggeredBy, "scheduled runs have no trigger")
+	assert.False(t, run.Failed())
+
+	injectables := resultOf(t, run, entity.CleanupOrphanedVersionInjectables)
+	assert.Equal(t, 200, injectables.Found, "runs are bounded by MaxPerCategory")
+	assert.Equal(t, 200, injectables.Deleted)
+	assert.Len(t, repo.deletedInjectables, 200)
+
+	images := resultOf(t, run, entity.CleanupUnusedImages)
+	assert.True(t, images.Kept)
+	assert.Equal(t, 3, images.Found)
+	assert.Zero(t, images.Deleted)
+	assert.Empty(t, repo.deletedImages)
+
+	assert.Equal(t, 2, resultOf(t, run, entity.CleanupExpiredPreviewTokens).Deleted)
+	assert.WithinDuration(t, before.Add(-7*24*time.Hour), repo.tokenCutoff, time.Second)
+	assert.Equal(t, testIDs("sandbox", 2), sandboxes.deleted)
+}
+
+func TestRunCleanup_DeletesImagesPastRetention(t *testing.T) {
+	repo := &cleanupRepoStub{images: testIDs("image", 2)}
+	svc := NewCleanupService(repo, &sandboxRepoStub{}, txManagerStub{}, CleanupOptions{
+		UnusedImageRetention: 30 * 24 * time.Hour,
+	})
+
+	run, err := svc.RunCleanup(context.Background(), platformuc.RunCleanupCommand{})
+	require.NoError(t, err)
+
+	images := resultOf(t, run, entity.CleanupUnusedImages)
+	assert.False(t, images.Kept)
+	assert.Equal(t, 2, images.Deleted)
+	assert.WithinDuration(t, time.Now().Add(-30*24*time.Hour), repo.imageCutoff, time.Second)
+}
+
+func TestRunCleanup_FailedCategoryDoesNotStopOthers(t *testing.T) {
+	repo := &cleanupRepoStub{
+		tokens:    testIDs("token", 1),
+		sandboxes: testIDs("sandbox", 1),
+		findErr:   errors.New("connection reset"),
+	}
+	sandboxes := &sandboxRepoStub{}
+	svc := NewCleanupService(repo, sandboxes, txManagerStub{}, CleanupOptions{})
+
+	run, err := svc.RunCleanup(context.Background(), platformuc.RunCleanupCommand{})
+	require.NoError(t, err)
+
+	assert.True(t, run.Failed())
+	tokens := resultOf(t, run, entity.CleanupExpiredPreviewTokens)
+	require.NotNil(t, tokens.Error)
+	assert.Contains(t, *tokens.Error, "connection reset")
+	assert.Equal(t, 1, resultOf(t, run, entity.CleanupStaleSandboxes).Deleted)
+	assert.Len(t, repo.runs, 1, "the run is recorded with the failure")
+}
@@ -0,0 +1,121 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	platformuc "github.com/rendis/pdf-forge/core/internal/core/usecase/platform"
+)
+
+// maintenanceStateTTL bounds how long a switch made on another instance takes to apply here.
+const maintenanceStateTTL = 5 * time.Second
+
+// NewMaintenanceService creates a new maintenance service.
+func NewMaintenanceService(maintenanceRepo port.MaintenanceRepository) platformuc.MaintenanceUseCase {
+	return &MaintenanceService{
+		maintenanceRepo: maintenanceRepo,
+		state:           entity.NewMaintenanceState(),
+	}
+}
+
+// MaintenanceService implements the MaintenanceUseCase interface.
+// The state is cached briefly so checking it on every request costs no query,
+// and the last known state survives the database being unavailable mid-maintenance.
+type MaintenanceService struct {
+	maintenanceRepo port.MaintenanceRepository
+	inFlight        atomic.Int64
+
+	mu       sync.Mutex
+	state    *entity.MaintenanceState
+	loadedAt time.Time
+}
+
+// CurrentState returns the maintenance state as seen by this instance.
+func (s *MaintenanceService) CurrentState(ctx context.Context) *entity.MaintenanceState {
+	s.mu.Lock()
+	if time.Since(s.loadedAt) < maintenanceStateTTL {
+		defer s.mu.Unlock()
+		return s.state
+	}
+	// Claim the reload so concurrent requests keep using the current state meanwhile
+	s.loadedAt = time.Now()
+	current := s.state
+	s.mu.Unlock()
+
+	state, err := s.maintenanceRepo.Get(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to load maintenance state, keeping last known",
+			slog.String("mode", string(current.Mode)),
+			slog.Any("error", err),
+		)
+		return current
+	}
+
+	s.setCached(state)
+	return state
+}
+
+// GetState reads the maintenance state from storage.
+func (s *MaintenanceService) GetState(ctx context.Context) (*entity.MaintenanceState, error) {
+	state, err := s.maintenanceRepo.Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting maintenance state: %w", err)
+	}
+	s.setCached(state)
+	return state, nil
+}
+
+// SetState switches the maintenance mode for every instance.
+func (s *MaintenanceService) SetState(ctx context.Context, cmd platformuc.SetMaintenanceCommand) (*entity.MaintenanceState, error) {
+	state := &entity.MaintenanceState{
+		Mode:          cmd.Mode,
+		Message:       cmd.Message,
+		ExpectedEndAt: cmd.ExpectedEndAt,
+		UpdatedBy:     &cmd.UpdatedBy,
+	}
+	if !state.IsActive() {
+		state.Message = ""
+		state.ExpectedEndAt = nil
+	}
+
+	if err := state.Validate(); err != nil {
+		return nil, fmt.Errorf("validating maintenance state: %w", err)
+	}
+
+	if err := s.maintenanceRepo.Update(ctx, state); err != nil {
+		return nil, fmt.Errorf("saving maintenance state: %w", err)
+	}
+	s.setCached(state)
+
+	slog.WarnContext(ctx, "maintenance mode changed",
+		slog.String("mode", string(state.Mode)),
+		slog.String("updated_by", cmd.UpdatedBy),
+		slog.Int64("in_flight_renders", s.InFlightRenders()),
+	)
+
+	return state, nil
+}
+
+func (s *MaintenanceService) setCached(state *entity.MaintenanceState) {
+	s.mu.Lock()
+	s.state = state
+	s.loadedAt = time.Now()
+	s.mu.Unlock()
+}
+
+// BeginRender counts a render in flight on this instance.
+func (s *MaintenanceService) BeginRender() func() {
+	s.inFlight.Add(1)
+	return func() { s.inFlight.Add(-1) }
+}
+
+// InFlightRenders returns the number of renders in flight on this instance.
+func (s *MaintenanceService) InFlightRenders() int64 {
+	return s.inFlight.Load()
+}
@@ -0,0 +1,127 @@
+package platform
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// RenderCounter counts renders in memory and adds them to the daily render stats periodically,
+// so renders never wait on the database. Counts not yet flushed are lost if the process dies.
+type RenderCounter struct {
+	statsRepo port.SystemStatsRepository
+	interval  time.Duration
+
+	mu      sync.Mutex
+	pending map[string]*entity.RenderDayStats // By UTC day
+
+	stopCh   chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// NewRenderCounter creates a counter that flushes every interval. Call Start to begin.
+func NewRenderCounter(statsRepo port.SystemStatsRepository, interval time.Duration) *RenderCounter {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &RenderCounter{
+		statsRepo: statsRepo,
+		interval:  interval,
+		pending:   make(map[string]*entity.RenderDayStats),
+		stopCh:    make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}
+}
+
+// RecordRender counts a render on the current UTC day.
+func (c *RenderCounter) RecordRender(pageCount int, duration time.Duration, err error) {
+	day := time.Now().UTC().Truncate(24 * time.Hour)
+	key := day.Format(time.DateOnly)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counts, ok := c.pending[key]
+	if !ok {
+		counts = &entity.RenderDayStats{Day: day}
+		c.pending[key] = counts
+	}
+	counts.Renders++
+	if err != nil {
+		counts.Failures++
+		return
+	}
+	counts.Pages += int64(pageCount)
+	counts.DurationMs += duration.Milliseconds()
+}
+
+// Start runs the flush loop in the background until Stop is called.
+func (c *RenderCounter) Start() {
+	go c.loop()
+}
+
+// Stop ends the loop and flushes the pending counts.
+func (c *RenderCounter) Stop() {
+	c.stopOnce.Do(func() { close(c.stopCh) })
+	<-c.stopped
+}
+
+func (c *RenderCounter) loop() {
+	defer close(c.stopped)
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stopCh:
+			c.Flush(context.Background())
+			return
+		case <-ticker.C:
+			c.Flush(context.Background())
+		}
+	}
+}
+
+// Flush adds the pending counts to the daily stats. On failure they are kept for the next flush.
+func (c *RenderCounter) Flush(ctx context.Context) {
+	c.mu.Lock()
+	if len(c.pending) == 0 {
+		c.mu.Unlock()
+		return
+	}
+	batch := c.pending
+	c.pending = make(map[string]*entity.RenderDayStats, len(batch))
+	c.mu.Unlock()
+
+	days := make([]*entity.RenderDayStats, 0, len(batch))
+	for _, counts := range batch {
+		days = append(days, counts)
+	}
+	if err := c.statsRepo.AddRenderCounts(ctx, days); err != nil {
+		slog.WarnContext(ctx, "failed to flush render counts, retrying on the next flush", slog.Any("error", err))
+		c.restore(batch)
+	}
+}
+
+// restore merges counts that failed to flush back into the pending ones.
+func (c *RenderCounter) restore(batch map[string]*entity.RenderDayStats) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, counts := range batch {
+		current, ok := c.pending[key]
+		if !ok {
+			c.pending[key] = counts
+			continue
+		}
+		current.Renders += counts.Renders
+		current.Failures += counts.Failures
+		current.Pages += counts.Pages
+		current.DurationMs += counts.DurationMs
+	}
+}
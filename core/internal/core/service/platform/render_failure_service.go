@@ -0,0 +1,177 @@
+package platform
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	platformuc "github.com/rendis/pdf-forge/core/internal/core/usecase/platform"
+)
+
+const (
+	// renderFailureMaxStderr bounds the compiler output kept per failure.
+	renderFailureMaxStderr = 64 << 10
+
+	renderFailureDefaultListed = 20
+	renderFailureMaxListed     = 100
+)
+
+// RenderFailureOptions configures the capture of failed compiles.
+type RenderFailureOptions struct {
+	// Retention is how long a capture is kept. Zero uses 7 days.
+	Retention time.Duration
+	// MaxKept bounds the captures kept; the oldest are deleted first. Zero keeps any number.
+	MaxKept int
+	// MaxSourceBytes truncates larger sources. Zero keeps whole sources.
+	MaxSourceBytes int
+}
+
+// NewRenderFailureService creates a service that captures failed compiles in repo.
+func NewRenderFailureService(repo port.RenderFailureRepository, opts RenderFailureOptions) *RenderFailureService {
+	if opts.Retention <= 0 {
+		opts.Retention = 7 * 24 * time.Hour
+	}
+	return &RenderFailureService{repo: repo, opts: opts}
+}
+
+// RenderFailureService keeps the Typst input of failed compiles and serves it to platform admins.
+// It implements port.RenderFailureCapturer and platformuc.RenderFailureUseCase.
+type RenderFailureService struct {
+	repo port.RenderFailureRepository
+	opts RenderFailureOptions
+}
+
+// CaptureRenderFailure stores failure, then deletes the captures past their retention or beyond
+// the maximum kept. Storage errors are logged: capturing must never change the render outcome.
+func (s *RenderFailureService) CaptureRenderFailure(ctx context.Context, failure *entity.RenderFailure) string {
+	ctx = context.WithoutCancel(ctx)
+	now := time.Now().UTC()
+
+	failure.ID = uuid.NewString()
+	failure.CreatedAt = now
+	failure.ExpiresAt = now.Add(s.opts.Retention)
+	failure.SourceBytes = len(failure.Source)
+	if s.opts.MaxSourceBytes > 0 && len(failure.Source) > s.opts.MaxSourceBytes {
+		failure.Source = failure.Source[:s.opts.MaxSourceBytes]
+		failure.SourceTruncated = true
+	}
+	if len(failure.Stderr) > renderFailureMaxStderr {
+		failure.Stderr = failure.Stderr[:renderFailureMaxStderr]
+	}
+
+	if err := s.repo.Create(ctx, failure); err != nil {
+		slog.WarnContext(ctx, "failed to capture render failure",
+			slog.String("version_id", failure.VersionID),
+			slog.Any("error", err),
+		)
+		return ""
+	}
+	if deleted, err := s.repo.DeleteExpired(ctx, s.opts.MaxKept); err != nil {
+		slog.WarnContext(ctx, "failed to delete expired render failures", slog.Any("error", err))
+	} else if deleted > 0 {
+		slog.InfoContext(ctx, "expired render failures deleted", slog.Int64("count", deleted))
+	}
+	return failure.ID
+}
+
+// ListRenderFailures lists the captured failures still retained, newest first.
+func (s *RenderFailureService) ListRenderFailures(ctx context.Context, limit int) ([]*entity.RenderFailure, error) {
+	if limit <= 0 {
+		limit = renderFailureDefaultListed
+	}
+	failures, err := s.repo.List(ctx, min(limit, renderFailureMaxListed))
+	if err != nil {
+		return nil, fmt.Errorf("listing render failures: %w", err)
+	}
+	return failures, nil
+}
+
+// renderFailureMetadata is the failure.json of a downloaded capture.
+type renderFailureMetadata struct {
+	ID               string             `json:"id"`
+	TenantCode       string             `json:"tenantCode"`
+	WorkspaceCode    string             `json:"workspaceCode"`
+	DocumentTypeCode *string            `json:"documentTypeCode,omitempty"`
+	VersionID        string             `json:"versionId"`
+	TemplateID       string             `json:"templateId"`
+	Environment      entity.Environment `json:"environment"`
+	Error            string             `json:"error"`
+	SourceBytes      int                `json:"sourceBytes"`
+	SourceTruncated  bool               `json:"sourceTruncated"`
+	CreatedAt        time.Time          `json:"createdAt"`
+	ExpiresAt        time.Time          `json:"expiresAt"`
+}
+
+// DownloadRenderFailure packages a captured failure as a zip.
+func (s *RenderFailureService) DownloadRenderFailure(ctx context.Context, id string) (*platformuc.RenderFailureArchive, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, entity.ErrRenderFailureNotFound
+	}
+	f, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := json.MarshalIndent(renderFailureMetadata{
+		ID:               f.ID,
+		TenantCode:       f.TenantCode,
+		WorkspaceCode:    f.WorkspaceCode,
+		DocumentTypeCode: f.DocumentTypeCode,
+		VersionID:        f.VersionID,
+		TemplateID:       f.TemplateID,
+		Environment:      f.Environment,
+		Error:            f.Error,
+		SourceBytes:      f.SourceBytes,
+		SourceTruncated:  f.SourceTruncated,
+		CreatedAt:        f.CreatedAt,
+		ExpiresAt:        f.ExpiresAt,
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding render failure: %w", err)
+	}
+	assets := f.Assets
+	if assets == nil {
+		assets = []entity.RenderFailureAsset{}
+	}
+	manifest, err := json.MarshalIndent(assets, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding render failure assets: %w", err)
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{"main.typ", []byte(f.Source)},
+		{"stderr.txt", []byte(f.Stderr)},
+		{"assets.json", manifest},
+		{"failure.json", metadata},
+	}
+	for _, file := range files {
+		w, err := zw.Create(file.name)
+		if err != nil {
+			return nil, fmt.Errorf("writing render failure archive: %w", err)
+		}
+		if _, err := w.Write(file.data); err != nil {
+			return nil, fmt.Errorf("writing render failure archive: %w", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("writing render failure archive: %w", err)
+	}
+
+	return &platformuc.RenderFailureArchive{
+		Archive:  buf.Bytes(),
+		Filename: "render-failure-" + f.ID + ".zip",
+	}, nil
+}
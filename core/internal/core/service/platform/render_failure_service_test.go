@@ -0,0 +1,117 @@
+package platform
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+type renderFailureRepoStub struct {
+	created   []*entity.RenderFailure
+	createErr error
+	kept      []int // keep argument of each DeleteExpired call
+}
+
+func (r *renderFailureRepoStub) Create(_ context.Context, f *entity.RenderFailure) error {
+	if r.createErr != nil {
+		return r.createErr
+	}
+	r.created = append(r.created, f)
+	return nil
+}
+
+func (r *renderFailureRepoStub) List(_ context.Context, limit int) ([]*entity.RenderFailure, error) {
+	return r.created[:min(limit, len(r.created))], nil
+}
+
+func (r *renderFailureRepoStub) FindByID(_ context.Context, id string) (*entity.RenderFailure, error) {
+	for _, f := range r.created {
+		if f.ID == id {
+			return f, nil
+		}
+	}
+	return nil, entity.ErrRenderFailureNotFound
+}
+
+func (r *renderFailureRepoStub) DeleteExpired(_ context.Context, keep int) (int64, error) {
+	r.kept = append(r.kept, keep)
+	return 0, nil
+}
+
+func TestRenderFailureService_Capture(t *testing.T) {
+	repo := &renderFailureRepoStub{}
+	svc := NewRenderFailureService(repo, RenderFailureOptions{Retention: time.Hour, MaxKept: 10, MaxSourceBytes: 4})
+
+	id := svc.CaptureRenderFailure(context.Background(), &entity.RenderFailure{
+		VersionID: "v1",
+		Source:    "#set page()",
+		Stderr:    "error: unknown variable",
+	})
+
+	require.NotEmpty(t, id)
+	require.Len(t, repo.created, 1)
+	f := repo.created[0]
+	assert.Equal(t, id, f.ID)
+	assert.Equal(t, "#set", f.Source)
+	assert.Equal(t, len("#set page()"), f.SourceBytes)
+	assert.True(t, f.SourceTruncated)
+	assert.Equal(t, time.Hour, f.ExpiresAt.Sub(f.CreatedAt))
+	assert.Equal(t, []int{10}, repo.kept)
+}
+
+func TestRenderFailureService_CaptureErrorIsNotFatal(t *testing.T) {
+	repo := &renderFailureRepoStub{createErr: errors.New("db down")}
+	svc := NewRenderFailureService(repo, RenderFailureOptions{})
+
+	id := svc.CaptureRenderFailure(context.Background(), &entity.RenderFailure{VersionID: "v1"})
+
+	assert.Empty(t, id)
+	assert.Empty(t, repo.kept)
+}
+
+func TestRenderFailureService_Download(t *testing.T) {
+	repo := &renderFailureRepoStub{}
+	svc := NewRenderFailureService(repo, RenderFailureOptions{})
+	id := svc.CaptureRenderFailure(context.Background(), &entity.RenderFailure{
+		VersionID: "v1",
+		Source:    "#text(\"hi\")",
+		Stderr:    "error: boom",
+		Assets:    []entity.RenderFailureAsset{{Name: "img_1.png", SizeBytes: 3}},
+	})
+
+	archive, err := svc.DownloadRenderFailure(context.Background(), id)
+	require.NoError(t, err)
+	assert.Equal(t, "render-failure-"+id+".zip", archive.Filename)
+
+	zr, err := zip.NewReader(bytes.NewReader(archive.Archive), int64(len(archive.Archive)))
+	require.NoError(t, err)
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		files[f.Name] = string(data)
+	}
+	assert.Equal(t, "#text(\"hi\")", files["main.typ"])
+	assert.Equal(t, "error: boom", files["stderr.txt"])
+	assert.Contains(t, files["assets.json"], "img_1.png")
+	assert.Contains(t, files["failure.json"], `"versionId": "v1"`)
+}
+
+func TestRenderFailureService_DownloadInvalidID(t *testing.T) {
+	svc := NewRenderFailureService(&renderFailureRepoStub{}, RenderFailureOptions{})
+
+	_, err := svc.DownloadRenderFailure(context.Background(), "not-a-uuid")
+
+	assert.ErrorIs(t, err, entity.ErrRenderFailureNotFound)
+}
@@ -0,0 +1,468 @@
+package platform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	platformuc "github.com/rendis/pdf-forge/core/internal/core/usecase/platform"
+)
+
+// RenderQuotaDefaults are the limits of tenants and workspaces without a quota of their own.
+type RenderQuotaDefaults struct {
+	Tenant    entity.RenderQuotaLimits
+	Workspace entity.RenderQuotaLimits
+}
+
+// RenderQuotaService enforces the render quotas of tenants and workspaces and manages them.
+//
+// Quotas and the page usage of the month are kept in memory and refreshed every interval, so
+// renders never wait on the database. Renders per minute are best-effort: each instance allows its
+// share of the limit, the limit divided by the number of replicas, so the cluster stays near the
+// limit only while the load is spread evenly. Pages per month are shared through the database, so
+// instances may together go past a monthly quota by the pages rendered within one interval.
+type RenderQuotaService struct {
+	quotaRepo     port.RenderQuotaRepository
+	tenantRepo    port.TenantRepository
+	workspaceRepo port.WorkspaceRepository
+	enabled       bool
+	defaults      RenderQuotaDefaults
+	replicas      int
+	interval      time.Duration
+
+	mu        sync.Mutex
+	overrides map[string]*entity.RenderQuota // By quotaKey
+	limiters  map[string]*minuteLimiter      // By quotaKey
+	month     time.Time                      // Month of used
+	used      map[string]int64               // Pages this month, by quotaKey
+	pending   map[string]*entity.RenderUsage // Not flushed yet, by workspace quotaKey and month
+
+	stopCh   chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// minuteLimiter limits the renders per minute of a tenant or workspace.
+type minuteLimiter struct {
+	max     int
+	limiter *rate.Limiter
+}
+
+// NewRenderQuotaService creates the quota service. When enabled is false every render is allowed
+// and quotas cannot be changed. replicas is the number of instances that share the per-minute
+// limits. Call RunOnce to load the quotas and Start to keep them fresh.
+func NewRenderQuotaService(
+	quotaRepo port.RenderQuotaRepository,
+	tenantRepo port.TenantRepository,
+	workspaceRepo port.WorkspaceRepository,
+	enabled bool,
+	defaults RenderQuotaDefaults,
+	replicas int,
+	interval time.Duration,
+) *RenderQuotaService {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	if replicas < 1 {
+		replicas = 1
+	}
+	return &RenderQuotaService{
+		quotaRepo:     quotaRepo,
+		tenantRepo:    tenantRepo,
+		workspaceRepo: workspaceRepo,
+		enabled:       enabled,
+		defaults:      defaults,
+		replicas:      replicas,
+		interval:      interval,
+		overrides:     make(map[string]*entity.RenderQuota),
+		limiters:      make(map[string]*minuteLimiter),
+		month:         entity.MonthStart(time.Now()),
+		used:          make(map[string]int64),
+		pending:       make(map[string]*entity.RenderUsage),
+		stopCh:        make(chan struct{}),
+		stopped:       make(chan struct{}),
+	}
+}
+
+// quotaKey identifies a tenant, or one of its workspaces when workspaceCode is not empty.
+func quotaKey(tenantCode, workspaceCode string) string {
+	if workspaceCode == "" {
+		return tenantCode
+	}
+	return tenantCode + "/" + workspaceCode
+}
+
+// AllowRender takes a render from the per-minute quotas of the tenant and workspace, or returns
+// an *entity.QuotaExceededError when either is over a limit.
+func (s *RenderQuotaService) AllowRender(tenantCode, workspaceCode string) error {
+	if !s.enabled {
+		return nil
+	}
+	now := time.Now()
+	tenantKey, workspaceKey := quotaKey(tenantCode, ""), quotaKey(tenantCode, workspaceCode)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rollMonth(now)
+
+	tenantLimits := s.defaults.Tenant.Apply(s.overrides[tenantKey])
+	workspaceLimits := s.defaults.Workspace.Apply(s.overrides[workspaceKey])
+
+	nextMonth := s.month.AddDate(0, 1, 0).Sub(now)
+	if limit := tenantLimits.PagesPerMonth; limit > 0 && s.used[tenantKey] >= limit {
+		return &entity.QuotaExceededError{Scope: entity.RenderQuotaScopeTenant, Limit: entity.RenderQuotaPagesPerMonth, Max: limit, RetryAfter: nextMonth}
+	}
+	if limit := workspaceLimits.PagesPerMonth; limit > 0 && s.used[workspaceKey] >= limit {
+		return &entity.QuotaExceededError{Scope: entity.RenderQuotaScopeWorkspace, Limit: entity.RenderQuotaPagesPerMonth, Max: limit, RetryAfter: nextMonth}
+	}
+
+	tenantRes := s.reserve(tenantKey, tenantLimits.RendersPerMinute, now)
+	if delay := delayFrom(tenantRes, now); delay > 0 {
+		tenantRes.CancelAt(now)
+		return &entity.QuotaExceededError{Scope: entity.RenderQuotaScopeTenant, Limit: entity.RenderQuotaRendersPerMinute, Max: int64(tenantLimits.RendersPerMinute), RetryAfter: delay}
+	}
+	workspaceRes := s.reserve(workspaceKey, workspaceLimits.RendersPerMinute, now)
+	if delay := delayFrom(workspaceRes, now); delay > 0 {
+		workspaceRes.CancelAt(now)
+		if tenantRes != nil {
+			tenantRes.CancelAt(now)
+		}
+		return &entity.QuotaExceededError{Scope: entity.RenderQuotaScopeWorkspace, Limit: entity.RenderQuotaRendersPerMinute, Max: int64(workspaceLimits.RendersPerMinute), RetryAfter: delay}
+	}
+	return nil
+}
+
+// reserve takes a render from the per-minute limiter of key, replacing it when its limit changed.
+// The limiter allows the share of limit of this instance. Returns nil when renders per minute are
+// unlimited. The caller holds s.mu.
+func (s *RenderQuotaService) reserve(key string, limit int, now time.Time) *rate.Reservation {
+	if limit <= 0 {
+		delete(s.limiters, key)
+		return nil
+	}
+	limit = instanceShare(limit, s.replicas)
+	l, ok := s.limiters[key]
+	if !ok || l.max != limit {
+		l = &minuteLimiter{max: limit, limiter: rate.NewLimiter(rate.Every(time.Minute/time.Duration(limit)), limit)}
+		s.limiters[key] = l
+	}
+	return l.limiter.ReserveN(now, 1)
+}
+
+// instanceShare divides a per-minute limit between replicas, rounding up so no instance is left
+// without renders.
+func instanceShare(limit, replicas int) int {
+	return (limit + replicas - 1) / replicas
+}
+
+// delayFrom returns how long the reservation r must wait, zero for no reservation.
+func delayFrom(r *rate.Reservation, now time.Time) time.Duration {
+	if r == nil {
+		return 0
+	}
+	return r.DelayFrom(now)
+}
+
+// RecordPages counts the pages of a finished render towards the monthly quotas.
+func (s *RenderQuotaService) RecordPages(tenantCode, workspaceCode string, pages int) {
+	if !s.enabled {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rollMonth(time.Now())
+
+	s.used[quotaKey(tenantCode, "")] += int64(pages)
+	key := quotaKey(tenantCode, workspaceCode)
+	s.used[key] += int64(pages)
+
+	pendingKey := key + "@" + s.month.Format(time.DateOnly)
+	usage, ok := s.pending[pendingKey]
+	if !ok {
+		usage = &entity.RenderUsage{TenantCode: tenantCode, WorkspaceCode: workspaceCode, Month: s.month}
+		s.pending[pendingKey] = usage
+	}
+	usage.Renders++
+	usage.Pages += int64(pages)
+}
+
+// rollMonth resets the page usage when a new month starts. The caller holds s.mu.
+func (s *RenderQuotaService) rollMonth(now time.Time) {
+	if month := entity.MonthStart(now); !month.Equal(s.month) {
+		s.month = month
+		s.used = make(map[string]int64)
+	}
+}
+
+// Start runs the refresh loop in the background until Stop is called.
+func (s *RenderQuotaService) Start() {
+	go s.loop()
+}
+
+// Stop ends the loop and flushes the pending usage.
+func (s *RenderQuotaService) Stop() {
+	s.stopOnce.Do(func() { close(s.stopCh) })
+	<-s.stopped
+}
+
+func (s *RenderQuotaService) loop() {
+	defer close(s.stopped)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			s.flush(context.Background())
+			return
+		case <-ticker.C:
+			s.RunOnce(context.Background())
+		}
+	}
+}
+
+// RunOnce flushes the usage counted here and reloads the quotas and the usage of every instance.
+// Failures are logged and the last loaded state is kept.
+func (s *RenderQuotaService) RunOnce(ctx context.Context) {
+	if !s.enabled {
+		return
+	}
+	s.flush(ctx)
+
+	quotas, err := s.quotaRepo.FindAll(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to load render quotas, keeping the last loaded", slog.Any("error", err))
+	} else {
+		overrides := make(map[string]*entity.RenderQuota, len(quotas))
+		for _, q := range quotas {
+			overrides[quotaKey(q.TenantCode, q.WorkspaceCode)] = q
+		}
+		s.mu.Lock()
+		s.overrides = overrides
+		s.mu.Unlock()
+	}
+
+	month := entity.MonthStart(time.Now())
+	usage, err := s.quotaRepo.ListUsage(ctx, month)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to load render usage, keeping the last loaded", slog.Any("error", err))
+		return
+	}
+	used := make(map[string]int64, len(usage)*2)
+	for _, u := range usage {
+		used[quotaKey(u.TenantCode, "")] += u.Pages
+		used[quotaKey(u.TenantCode, u.WorkspaceCode)] += u.Pages
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rollMonth(time.Now())
+	if !s.month.Equal(month) {
+		return
+	}
+	// Usage counted since the flush is not in the database yet
+	for _, u := range s.pending {
+		if u.Month.Equal(month) {
+			used[quotaKey(u.TenantCode, "")] += u.Pages
+			used[quotaKey(u.TenantCode, u.WorkspaceCode)] += u.Pages
+		}
+	}
+	s.used = used
+}
+
+// flush adds the pending usage to the database. On failure it is kept for the next flush.
+func (s *RenderQuotaService) flush(ctx context.Context) {
+	s.mu.Lock()
+	if len(s.pending) == 0 {
+		s.mu.Unlock()
+		return
+	}
+	batch := s.pending
+	s.pending = make(map[string]*entity.RenderUsage, len(batch))
+	s.mu.Unlock()
+
+	usage := make([]*entity.RenderUsage, 0, len(batch))
+	for _, u := range batch {
+		usage = append(usage, u)
+	}
+	if err := s.quotaRepo.AddUsage(ctx, usage); err != nil {
+		slog.WarnContext(ctx, "failed to flush render usage, retrying on the next flush", slog.Any("error", err))
+		s.restore(batch)
+	}
+}
+
+// restore merges usage that failed to flush back into the pending usage.
+func (s *RenderQuotaService) restore(batch map[string]*entity.RenderUsage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, u := range batch {
+		current, ok := s.pending[key]
+		if !ok {
+			s.pending[key] = u
+			continue
+		}
+		current.Renders += u.Renders
+		current.Pages += u.Pages
+	}
+}
+
+// Defaults returns the limits of tenants and workspaces without a quota.
+func (s *RenderQuotaService) Defaults() (tenant, workspace entity.RenderQuotaLimits) {
+	return s.defaults.Tenant, s.defaults.Workspace
+}
+
+// ListQuotas returns every tenant and workspace quota.
+func (s *RenderQuotaService) ListQuotas(ctx context.Context) ([]*entity.RenderQuota, error) {
+	return s.quotaRepo.FindAll(ctx)
+}
+
+// GetQuota returns the limits in force for a tenant or workspace and its usage this month.
+func (s *RenderQuotaService) GetQuota(ctx context.Context, tenantID string, workspaceID *string) (*entity.RenderQuotaStatus, error) {
+	tenantCode, workspaceCode, err := s.resolveCodes(ctx, tenantID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	override, err := s.quotaRepo.Find(ctx, tenantID, workspaceID)
+	if err != nil && !errors.Is(err, entity.ErrRenderQuotaNotFound) {
+		return nil, err
+	}
+	return s.status(ctx, tenantID, workspaceID, tenantCode, workspaceCode, override)
+}
+
+// SetQuota overrides the limits of a tenant or workspace. Other instances apply it on their next
+// refresh.
+func (s *RenderQuotaService) SetQuota(ctx context.Context, cmd platformuc.SetRenderQuotaCommand) (*entity.RenderQuotaStatus, error) {
+	if !s.enabled {
+		return nil, entity.ErrRenderQuotasDisabled
+	}
+	tenantCode, workspaceCode, err := s.resolveCodes(ctx, cmd.TenantID, cmd.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	quota := &entity.RenderQuota{
+		TenantID:         cmd.TenantID,
+		TenantCode:       tenantCode,
+		WorkspaceID:      cmd.WorkspaceID,
+		WorkspaceCode:    workspaceCode,
+		RendersPerMinute: cmd.RendersPerMinute,
+		PagesPerMonth:    cmd.PagesPerMonth,
+		UpdatedBy:        &cmd.UpdatedBy,
+	}
+	if err := quota.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.quotaRepo.Save(ctx, quota); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.overrides[quotaKey(tenantCode, workspaceCode)] = quota
+	s.mu.Unlock()
+
+	slog.InfoContext(ctx, "render quota set",
+		slog.String("tenant", tenantCode),
+		slog.String("workspace", workspaceCode),
+		slog.String("updated_by", cmd.UpdatedBy),
+	)
+	return s.status(ctx, cmd.TenantID, cmd.WorkspaceID, tenantCode, workspaceCode, quota)
+}
+
+// DeleteQuota removes the override of a tenant or workspace, so the defaults apply again.
+func (s *RenderQuotaService) DeleteQuota(ctx context.Context, tenantID string, workspaceID *string) error {
+	if !s.enabled {
+		return entity.ErrRenderQuotasDisabled
+	}
+	tenantCode, workspaceCode, err := s.resolveCodes(ctx, tenantID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if err := s.quotaRepo.Delete(ctx, tenantID, workspaceID); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	delete(s.overrides, quotaKey(tenantCode, workspaceCode))
+	s.mu.Unlock()
+
+	slog.InfoContext(ctx, "render quota removed",
+		slog.String("tenant", tenantCode),
+		slog.String("workspace", workspaceCode),
+	)
+	return nil
+}
+
+// resolveCodes returns the codes of a tenant and of one of its workspaces when workspaceID is set.
+func (s *RenderQuotaService) resolveCodes(ctx context.Context, tenantID string, workspaceID *string) (string, string, error) {
+	tenant, err := s.tenantRepo.FindByID(ctx, tenantID)
+	if err != nil {
+		return "", "", err
+	}
+	if workspaceID == nil {
+		return tenant.Code, "", nil
+	}
+	workspace, err := s.workspaceRepo.FindByID(ctx, *workspaceID)
+	if err != nil {
+		return "", "", err
+	}
+	if workspace.TenantID == nil || *workspace.TenantID != tenantID {
+		return "", "", entity.ErrWorkspaceNotFound
+	}
+	return tenant.Code, workspace.Code, nil
+}
+
+// status builds the quota status of a tenant or workspace with its usage this month.
+func (s *RenderQuotaService) status(
+	ctx context.Context,
+	tenantID string,
+	workspaceID *string,
+	tenantCode, workspaceCode string,
+	override *entity.RenderQuota,
+) (*entity.RenderQuotaStatus, error) {
+	status := &entity.RenderQuotaStatus{
+		Scope:       entity.RenderQuotaScopeTenant,
+		TenantID:    tenantID,
+		WorkspaceID: workspaceID,
+		Override:    override,
+		Limits:      s.defaults.Tenant.Apply(override),
+		Month:       entity.MonthStart(time.Now()),
+	}
+	if workspaceID != nil {
+		status.Scope = entity.RenderQuotaScopeWorkspace
+		status.Limits = s.defaults.Workspace.Apply(override)
+	}
+
+	usage, err := s.quotaRepo.ListUsage(ctx, status.Month)
+	if err != nil {
+		return nil, fmt.Errorf("loading render usage: %w", err)
+	}
+	s.mu.Lock()
+	for _, u := range s.pending {
+		if u.Month.Equal(status.Month) {
+			usage = append(usage, u)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, u := range usage {
+		if u.TenantCode != tenantCode || (workspaceCode != "" && u.WorkspaceCode != workspaceCode) {
+			continue
+		}
+		status.RendersInMonth += u.Renders
+		status.PagesInMonth += u.Pages
+	}
+	return status, nil
+}
+
+// Ensure RenderQuotaService implements port.RenderQuotaEnforcer and platformuc.RenderQuotaUseCase.
+var (
+	_ port.RenderQuotaEnforcer      = (*RenderQuotaService)(nil)
+	_ platformuc.RenderQuotaUseCase = (*RenderQuotaService)(nil)
+)
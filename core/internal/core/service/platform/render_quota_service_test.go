@@ -0,0 +1,230 @@
+package platform
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	platformuc "github.com/rendis/pdf-forge/core/internal/core/usecase/platform"
+)
+
+type renderQuotaRepoStub struct {
+	quotas []*entity.RenderQuota
+	usage  []*entity.RenderUsage
+	added  [][]*entity.RenderUsage
+	addErr error
+	saved  []*entity.RenderQuota
+}
+
+func (r *renderQuotaRepoStub) FindAll(context.Context) ([]*entity.RenderQuota, error) {
+	return r.quotas, nil
+}
+
+func (r *renderQuotaRepoStub) Find(_ context.Context, tenantID string, workspaceID *string) (*entity.RenderQuota, error) {
+	for _, q := range r.quotas {
+		if q.TenantID == tenantID && (q.WorkspaceID == nil) == (workspaceID == nil) {
+			return q, nil
+		}
+	}
+	return nil, entity.ErrRenderQuotaNotFound
+}
+
+func (r *renderQuotaRepoStub) Save(_ context.Context, q *entity.RenderQuota) error {
+	r.saved = append(r.saved, q)
+	return nil
+}
+
+func (r *renderQuotaRepoStub) Delete(context.Context, string, *string) error { return nil }
+
+func (r *renderQuotaRepoStub) AddUsage(_ context.Context, usage []*entity.RenderUsage) error {
+	if r.addErr != nil {
+		return r.addErr
+	}
+	r.added = append(r.added, usage)
+	r.usage = append(r.usage, usage...)
+	return nil
+}
+
+func (r *renderQuotaRepoStub) ListUsage(context.Context, time.Time) ([]*entity.RenderUsage, error) {
+	return r.usage, nil
+}
+
+type quotaTenantRepoStub struct {
+	port.TenantRepository
+}
+
+func (quotaTenantRepoStub) FindByID(_ context.Context, id string) (*entity.Tenant, error) {
+	if id != "t1" {
+		return nil, entity.ErrTenantNotFound
+	}
+	return &entity.Tenant{ID: "t1", Code: "acme"}, nil
+}
+
+type quotaWorkspaceRepoStub struct {
+	port.WorkspaceRepository
+}
+
+func (quotaWorkspaceRepoStub) FindByID(_ context.Context, id string) (*entity.Workspace, error) {
+	tenantID := "t1"
+	if id == "w2" {
+		tenantID = "t2"
+	}
+	return &entity.Workspace{ID: id, TenantID: &tenantID, Code: "sales"}, nil
+}
+
+func newTestRenderQuotaService(repo *renderQuotaRepoStub, enabled bool, defaults RenderQuotaDefaults) *RenderQuotaService {
+	return NewRenderQuotaService(repo, quotaTenantRepoStub{}, quotaWorkspaceRepoStub{}, enabled, defaults, 1, time.Hour)
+}
+
+func TestRenderQuotaService_RendersPerMinute(t *testing.T) {
+	repo := &renderQuotaRepoStub{}
+	svc := newTestRenderQuotaService(repo, true, RenderQuotaDefaults{
+		Tenant:    entity.RenderQuotaLimits{RendersPerMinute: 3},
+		Workspace: entity.RenderQuotaLimits{RendersPerMinute: 2},
+	})
+
+	require.NoError(t, svc.AllowRender("acme", "sales"))
+	require.NoError(t, svc.AllowRender("acme", "sales"))
+	err := svc.AllowRender("acme", "sales")
+	var quotaErr *entity.QuotaExceededError
+	require.ErrorAs(t, err, &quotaErr)
+	assert.ErrorIs(t, err, entity.ErrQuotaExceeded)
+	assert.Equal(t, entity.RenderQuotaScopeWorkspace, quotaErr.Scope)
+	assert.Equal(t, entity.RenderQuotaRendersPerMinute, quotaErr.Limit)
+	assert.Positive(t, quotaErr.RetryAfter)
+	assert.LessOrEqual(t, quotaErr.RetryAfter, 30*time.Second)
+
+	require.NoError(t, svc.AllowRender("acme", "hr"), "the rejected render did not take from the tenant")
+	err = svc.AllowRender("acme", "legal")
+	require.ErrorAs(t, err, &quotaErr)
+	assert.Equal(t, entity.RenderQuotaScopeTenant, quotaErr.Scope)
+
+	assert.NoError(t, svc.AllowRender("globex", "sales"), "tenants are limited separately")
+}
+
+func TestRenderQuotaService_RendersPerMinuteSplitBetweenReplicas(t *testing.T) {
+	repo := &renderQuotaRepoStub{}
+	svc := NewRenderQuotaService(repo, quotaTenantRepoStub{}, quotaWorkspaceRepoStub{}, true, RenderQuotaDefaults{
+		Tenant: entity.RenderQuotaLimits{RendersPerMinute: 4},
+	}, 2, time.Hour)
+
+	require.NoError(t, svc.AllowRender("acme", "sales"))
+	require.NoError(t, svc.AllowRender("acme", "sales"))
+	err := svc.AllowRender("acme", "sales")
+	var quotaErr *entity.QuotaExceededError
+	require.ErrorAs(t, err, &quotaErr, "each of 2 replicas allows half of the limit")
+	assert.Equal(t, int64(4), quotaErr.Max, "the error reports the configured limit")
+
+	assert.Equal(t, 2, instanceShare(3, 2), "the share is rounded up")
+	assert.Equal(t, 1, instanceShare(1, 3), "every replica allows at least one render")
+}
+
+func TestRenderQuotaService_Overrides(t *testing.T) {
+	unlimited, one := 0, 1
+	repo := &renderQuotaRepoStub{quotas: []*entity.RenderQuota{
+		{TenantID: "t1", TenantCode: "acme", RendersPerMinute: &unlimited},
+		{TenantID: "t2", TenantCode: "globex", RendersPerMinute: &one},
+	}}
+	svc := newTestRenderQuotaService(repo, true, RenderQuotaDefaults{Tenant: entity.RenderQuotaLimits{RendersPerMinute: 1}})
+	svc.RunOnce(context.Background())
+
+	for range 5 {
+		require.NoError(t, svc.AllowRender("acme", "sales"), "zero is unlimited")
+	}
+	require.NoError(t, svc.AllowRender("globex", "sales"))
+	assert.ErrorIs(t, svc.AllowRender("globex", "sales"), entity.ErrQuotaExceeded)
+}
+
+func TestRenderQuotaService_PagesPerMonth(t *testing.T) {
+	month := entity.MonthStart(time.Now())
+	repo := &renderQuotaRepoStub{usage: []*entity.RenderUsage{
+		{TenantCode: "acme", WorkspaceCode: "hr", Month: month, Renders: 4, Pages: 90},
+	}}
+	svc := newTestRenderQuotaService(repo, true, RenderQuotaDefaults{Tenant: entity.RenderQuotaLimits{PagesPerMonth: 100}})
+	svc.RunOnce(context.Background())
+
+	require.NoError(t, svc.AllowRender("acme", "sales"))
+	svc.RecordPages("acme", "sales", 10)
+
+	err := svc.AllowRender("acme", "sales")
+	var quotaErr *entity.QuotaExceededError
+	require.ErrorAs(t, err, &quotaErr)
+	assert.Equal(t, entity.RenderQuotaPagesPerMonth, quotaErr.Limit)
+	assert.Equal(t, int64(100), quotaErr.Max)
+	assert.WithinDuration(t, month.AddDate(0, 1, 0), time.Now().Add(quotaErr.RetryAfter), time.Second)
+
+	svc.RunOnce(context.Background())
+	require.Len(t, repo.added, 1)
+	assert.Equal(t, &entity.RenderUsage{TenantCode: "acme", WorkspaceCode: "sales", Month: month, Renders: 1, Pages: 10}, repo.added[0][0])
+	assert.ErrorIs(t, svc.AllowRender("acme", "sales"), entity.ErrQuotaExceeded, "the flushed usage is still counted")
+}
+
+func TestRenderQuotaService_FlushFailureKeepsUsage(t *testing.T) {
+	repo := &renderQuotaRepoStub{addErr: errors.New("db down")}
+	svc := newTestRenderQuotaService(repo, true, RenderQuotaDefaults{})
+	svc.RecordPages("acme", "sales", 3)
+	svc.RunOnce(context.Background())
+	svc.RecordPages("acme", "sales", 2)
+
+	repo.addErr = nil
+	svc.Start()
+	svc.Stop()
+	require.Len(t, repo.added, 1)
+	assert.Equal(t, int64(2), repo.added[0][0].Renders)
+	assert.Equal(t, int64(5), repo.added[0][0].Pages)
+}
+
+func TestRenderQuotaService_Disabled(t *testing.T) {
+	repo := &renderQuotaRepoStub{}
+	svc := newTestRenderQuotaService(repo, false, RenderQuotaDefaults{Tenant: entity.RenderQuotaLimits{RendersPerMinute: 1}})
+
+	for range 3 {
+		require.NoError(t, svc.AllowRender("acme", "sales"))
+	}
+	_, err := svc.SetQuota(context.Background(), platformuc.SetRenderQuotaCommand{TenantID: "t1"})
+	assert.ErrorIs(t, err, entity.ErrRenderQuotasDisabled)
+}
+
+func TestRenderQuotaService_SetAndGetQuota(t *testing.T) {
+	month := entity.MonthStart(time.Now())
+	repo := &renderQuotaRepoStub{usage: []*entity.RenderUsage{
+		{TenantCode: "acme", WorkspaceCode: "sales", Month: month, Renders: 2, Pages: 7},
+		{TenantCode: "acme", WorkspaceCode: "hr", Month: month, Renders: 1, Pages: 1},
+	}}
+	svc := newTestRenderQuotaService(repo, true, RenderQuotaDefaults{
+		Workspace: entity.RenderQuotaLimits{RendersPerMinute: 10, PagesPerMonth: 500},
+	})
+	ctx := context.Background()
+	ws := "w1"
+
+	pages := int64(50)
+	status, err := svc.SetQuota(ctx, platformuc.SetRenderQuotaCommand{TenantID: "t1", WorkspaceID: &ws, PagesPerMonth: &pages, UpdatedBy: "u1"})
+	require.NoError(t, err)
+	assert.Equal(t, entity.RenderQuotaScopeWorkspace, status.Scope)
+	assert.Equal(t, entity.RenderQuotaLimits{RendersPerMinute: 10, PagesPerMonth: 50}, status.Limits, "a nil limit takes the default")
+	assert.Equal(t, int64(2), status.RendersInMonth)
+	assert.Equal(t, int64(7), status.PagesInMonth)
+	require.Len(t, repo.saved, 1)
+	assert.Equal(t, "sales", repo.saved[0].WorkspaceCode)
+
+	status, err = svc.GetQuota(ctx, "t1", nil)
+	require.NoError(t, err)
+	assert.Nil(t, status.Override)
+	assert.Equal(t, int64(8), status.PagesInMonth, "a tenant counts all its workspaces")
+
+	negative := -1
+	_, err = svc.SetQuota(ctx, platformuc.SetRenderQuotaCommand{TenantID: "t1", RendersPerMinute: &negative})
+	assert.ErrorIs(t, err, entity.ErrInvalidRenderQuota)
+
+	other := "w2"
+	_, err = svc.GetQuota(ctx, "t1", &other)
+	assert.ErrorIs(t, err, entity.ErrWorkspaceNotFound, "the workspace must belong to the tenant")
+	_, err = svc.GetQuota(ctx, "t9", nil)
+	assert.ErrorIs(t, err, entity.ErrTenantNotFound)
+}
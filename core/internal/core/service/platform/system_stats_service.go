@@ -0,0 +1,143 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	platformuc "github.com/rendis/pdf-forge/core/internal/core/usecase/platform"
+)
+
+const (
+	systemStatsMaxDays = 365
+
+	// systemStatsTTL bounds how often the stats queries run; they scan whole tables.
+	systemStatsTTL = time.Minute
+)
+
+// NewSystemStatsService creates a new system stats service. capacity reports the render queue of
+// this instance and may be nil.
+func NewSystemStatsService(statsRepo port.SystemStatsRepository, capacity port.RenderCapacityReporter) platformuc.SystemStatsUseCase {
+	return &SystemStatsService{
+		statsRepo: statsRepo,
+		capacity:  capacity,
+		cached:    make(map[int]*entity.SystemStats),
+	}
+}
+
+// SystemStatsService implements the SystemStatsUseCase interface.
+// Stats are cached per window for a minute; the render queue is always live.
+type SystemStatsService struct {
+	statsRepo port.SystemStatsRepository
+	capacity  port.RenderCapacityReporter
+
+	mu     sync.Mutex
+	cached map[int]*entity.SystemStats
+}
+
+// GetSystemStats returns the platform overview over the last days days.
+func (s *SystemStatsService) GetSystemStats(ctx context.Context, days int) (*entity.SystemStats, error) {
+	if days < 1 || days > systemStatsMaxDays {
+		return nil, entity.ErrInvalidStatsWindow
+	}
+
+	s.mu.Lock()
+	stats, ok := s.cached[days]
+	s.mu.Unlock()
+	if !ok || time.Since(stats.GeneratedAt) >= systemStatsTTL {
+		var err error
+		if stats, err = s.loadStats(ctx, days); err != nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		s.cached[days] = stats
+		s.mu.Unlock()
+	}
+
+	result := *stats
+	if s.capacity != nil {
+		result.Queues.Render = s.capacity.RenderCapacity()
+	}
+	return &result, nil
+}
+
+// loadStats queries the stats of the window of days ending today (UTC).
+func (s *SystemStatsService) loadStats(ctx context.Context, days int) (*entity.SystemStats, error) {
+	now := time.Now().UTC()
+	today := now.Truncate(24 * time.Hour)
+	since := today.AddDate(0, 0, -(days - 1))
+
+	counts, err := s.statsRepo.CountEntities(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("counting entities: %w", err)
+	}
+	renderDays, err := s.statsRepo.ListRenderDays(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("listing render days: %w", err)
+	}
+	queues, err := s.statsRepo.GetQueueHealth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting queue health: %w", err)
+	}
+	storage, err := s.statsRepo.GetStorageUsage(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("getting storage usage: %w", err)
+	}
+
+	stats := &entity.SystemStats{
+		Counts:      *counts,
+		RenderDays:  fillRenderDays(since, days, renderDays),
+		Queues:      *queues,
+		Storage:     *storage,
+		GeneratedAt: now,
+	}
+	stats.Storage.Days = fillStorageDays(since, days, storage)
+	for _, d := range stats.RenderDays {
+		stats.Renders += d.Renders
+		stats.Failures += d.Failures
+	}
+	if stats.Renders > 0 {
+		stats.ErrorRate = float64(stats.Failures) / float64(stats.Renders)
+	}
+	return stats, nil
+}
+
+// fillRenderDays returns one entry per day from since, with zero counts for days without renders.
+func fillRenderDays(since time.Time, days int, recorded []*entity.RenderDayStats) []*entity.RenderDayStats {
+	byDay := make(map[string]*entity.RenderDayStats, len(recorded))
+	for _, d := range recorded {
+		byDay[d.Day.Format(time.DateOnly)] = d
+	}
+
+	result := make([]*entity.RenderDayStats, days)
+	for i := range days {
+		day := since.AddDate(0, 0, i)
+		if d, ok := byDay[day.Format(time.DateOnly)]; ok {
+			result[i] = d
+			continue
+		}
+		result[i] = &entity.RenderDayStats{Day: day}
+	}
+	return result
+}
+
+// fillStorageDays returns one entry per day from since with the bytes uploaded that day and the
+// running total, worked back from the current total.
+func fillStorageDays(since time.Time, days int, usage *entity.StorageUsage) []*entity.StorageDayUsage {
+	added := make(map[string]int64, len(usage.Days))
+	for _, d := range usage.Days {
+		added[d.Day.Format(time.DateOnly)] = d.AddedBytes
+	}
+
+	result := make([]*entity.StorageDayUsage, days)
+	total := usage.TotalBytes()
+	for i := days - 1; i >= 0; i-- {
+		day := since.AddDate(0, 0, i)
+		result[i] = &entity.StorageDayUsage{Day: day, AddedBytes: added[day.Format(time.DateOnly)], TotalBytes: total}
+		total -= result[i].AddedBytes
+	}
+	return result
+}
@@ -0,0 +1,114 @@
+package platform
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+type systemStatsRepoStub struct {
+	renderDays []*entity.RenderDayStats
+	storage    entity.StorageUsage
+	added      [][]*entity.RenderDayStats
+	addErr     error
+	loads      int
+}
+
+func (r *systemStatsRepoStub) CountEntities(context.Context) (*entity.EntityCounts, error) {
+	r.loads++
+	return &entity.EntityCounts{Tenants: 2}, nil
+}
+
+func (r *systemStatsRepoStub) AddRenderCounts(_ context.Context, days []*entity.RenderDayStats) error {
+	if r.addErr != nil {
+		return r.addErr
+	}
+	r.added = append(r.added, days)
+	return nil
+}
+
+func (r *systemStatsRepoStub) ListRenderDays(context.Context, time.Time) ([]*entity.RenderDayStats, error) {
+	return r.renderDays, nil
+}
+
+func (r *systemStatsRepoStub) GetQueueHealth(context.Context) (*entity.QueueHealth, error) {
+	return &entity.QueueHealth{OutboxPending: 3}, nil
+}
+
+func (r *systemStatsRepoStub) GetStorageUsage(context.Context, time.Time) (*entity.StorageUsage, error) {
+	usage := r.storage
+	return &usage, nil
+}
+
+type renderCapacityStub struct{}
+
+func (renderCapacityStub) RenderCapacity() entity.RenderCapacity {
+	return entity.RenderCapacity{ActiveRenders: 1}
+}
+
+func TestGetSystemStats(t *testing.T) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	yesterday := today.AddDate(0, 0, -1)
+	repo := &systemStatsRepoStub{
+		renderDays: []*entity.RenderDayStats{{Day: yesterday, Renders: 8, Failures: 2}},
+		storage: entity.StorageUsage{
+			AssetBytes:          700,
+			HostedDocumentBytes: 300,
+			Days:                []*entity.StorageDayUsage{{Day: yesterday, AddedBytes: 100}, {Day: today, AddedBytes: 50}},
+		},
+	}
+	s := NewSystemStatsService(repo, renderCapacityStub{})
+
+	stats, err := s.GetSystemStats(context.Background(), 3)
+	require.NoError(t, err)
+
+	require.Len(t, stats.RenderDays, 3)
+	assert.Equal(t, today.AddDate(0, 0, -2), stats.RenderDays[0].Day)
+	assert.Zero(t, stats.RenderDays[0].Renders)
+	assert.Equal(t, int64(8), stats.RenderDays[1].Renders)
+	assert.Equal(t, 0.25, stats.ErrorRate)
+
+	require.Len(t, stats.Storage.Days, 3)
+	assert.Equal(t, []int64{850, 950, 1000}, []int64{
+		stats.Storage.Days[0].TotalBytes, stats.Storage.Days[1].TotalBytes, stats.Storage.Days[2].TotalBytes,
+	})
+	assert.Equal(t, 1, stats.Queues.Render.ActiveRenders)
+
+	_, err = s.GetSystemStats(context.Background(), 3)
+	require.NoError(t, err)
+	assert.Equal(t, 1, repo.loads, "stats are cached per window")
+
+	_, err = s.GetSystemStats(context.Background(), 366)
+	assert.ErrorIs(t, err, entity.ErrInvalidStatsWindow)
+}
+
+func TestRenderCounter_KeepsCountsWhenFlushFails(t *testing.T) {
+	repo := &systemStatsRepoStub{addErr: errors.New("db down")}
+	c := NewRenderCounter(repo, time.Hour)
+
+	c.RecordRender(3, 2*time.Second, nil)
+	c.RecordRender(0, 0, errors.New("compile failed"))
+	c.Flush(context.Background())
+	assert.Empty(t, repo.added)
+
+	repo.addErr = nil
+	c.RecordRender(1, time.Second, nil)
+	c.Flush(context.Background())
+
+	require.Len(t, repo.added, 1)
+	require.Len(t, repo.added[0], 1)
+	day := repo.added[0][0]
+	assert.Equal(t, int64(3), day.Renders)
+	assert.Equal(t, int64(1), day.Failures)
+	assert.Equal(t, int64(4), day.Pages)
+	assert.Equal(t, int64(3000), day.DurationMs)
+
+	c.Flush(context.Background())
+	assert.Len(t, repo.added, 1, "nothing left to flush")
+}
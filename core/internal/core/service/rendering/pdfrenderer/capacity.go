@@ -0,0 +1,74 @@
+package pdfrenderer
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+const (
+	// waitWindow is how far back slot waits count toward the average wait.
+	waitWindow = time.Minute
+
+	// waitSamples bounds the slot waits kept for the average; older ones are overwritten.
+	waitSamples = 512
+)
+
+// slotStats tracks how renders queue for and hold render slots.
+type slotStats struct {
+	waiting  atomic.Int64
+	active   atomic.Int64
+	rejected atomic.Int64
+
+	mu    sync.Mutex
+	waits [waitSamples]waitSample
+	next  int
+}
+
+type waitSample struct {
+	at   time.Time
+	wait time.Duration
+}
+
+// recordWait stores the time a render waited before getting a slot.
+func (st *slotStats) recordWait(at time.Time, wait time.Duration) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.waits[st.next] = waitSample{at: at, wait: wait}
+	st.next = (st.next + 1) % waitSamples
+}
+
+// averageWait returns the mean of the waits recorded within waitWindow of now.
+func (st *slotStats) averageWait(now time.Time) time.Duration {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	var total time.Duration
+	var count int
+	for _, sample := range st.waits {
+		if !sample.at.IsZero() && now.Sub(sample.at) <= waitWindow {
+			total += sample.wait
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return total / time.Duration(count)
+}
+
+// RenderCapacity reports the render slots in use, the renders queued for one and how long they wait.
+func (s *Service) RenderCapacity() entity.RenderCapacity {
+	capacity := entity.RenderCapacity{
+		QueueDepth:    int(s.stats.waiting.Load()),
+		ActiveRenders: int(s.stats.active.Load()),
+		MaxConcurrent: cap(s.sem),
+		AverageWaitMs: float64(s.stats.averageWait(time.Now())) / float64(time.Millisecond),
+		RejectedTotal: s.stats.rejected.Load(),
+	}
+	if capacity.MaxConcurrent > 0 {
+		capacity.Utilization = float64(capacity.ActiveRenders) / float64(capacity.MaxConcurrent)
+	}
+	return capacity
+}
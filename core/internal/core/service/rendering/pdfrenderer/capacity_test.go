@@ -0,0 +1,81 @@
+package pdfrenderer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+func TestRenderCapacity_TracksQueueAndWaits(t *testing.T) {
+	service := &Service{sem: make(chan struct{}, 1), acquireTimeout: time.Second}
+	ctx := context.Background()
+
+	if err := service.acquireSlot(ctx); err != nil {
+		t.Fatalf("acquireSlot() error = %v", err)
+	}
+
+	acquired := make(chan error)
+	go func() { acquired <- service.acquireSlot(ctx) }()
+	waitFor(t, func() bool { return service.RenderCapacity().QueueDepth == 1 })
+
+	got := service.RenderCapacity()
+	if got.ActiveRenders != 1 || got.MaxConcurrent != 1 || got.Utilization != 1 {
+		t.Errorf("RenderCapacity() = %+v, want one active render on one slot", got)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	service.releaseSlot()
+	if err := <-acquired; err != nil {
+		t.Fatalf("queued acquireSlot() error = %v", err)
+	}
+
+	got = service.RenderCapacity()
+	if got.QueueDepth != 0 || got.ActiveRenders != 1 {
+		t.Errorf("RenderCapacity() = %+v, want the queued render active", got)
+	}
+	if got.AverageWaitMs < 10 {
+		t.Errorf("AverageWaitMs = %v, want the queued render's wait counted", got.AverageWaitMs)
+	}
+}
+
+func TestRenderCapacity_CountsRejections(t *testing.T) {
+	service := &Service{sem: make(chan struct{}, 1), acquireTimeout: 10 * time.Millisecond}
+	ctx := context.Background()
+
+	if err := service.acquireSlot(ctx); err != nil {
+		t.Fatalf("acquireSlot() error = %v", err)
+	}
+	if err := service.acquireSlot(ctx); !errors.Is(err, entity.ErrRendererBusy) {
+		t.Fatalf("acquireSlot() error = %v, want ErrRendererBusy", err)
+	}
+
+	if got := service.RenderCapacity(); got.RejectedTotal != 1 || got.QueueDepth != 0 {
+		t.Errorf("RenderCapacity() = %+v, want one rejection and an empty queue", got)
+	}
+}
+
+func TestSlotStats_AverageWaitIgnoresOldSamples(t *testing.T) {
+	var stats slotStats
+	now := time.Now()
+	stats.recordWait(now.Add(-2*waitWindow), time.Second)
+	stats.recordWait(now, 100*time.Millisecond)
+	stats.recordWait(now, 300*time.Millisecond)
+
+	if got := stats.averageWait(now); got != 200*time.Millisecond {
+		t.Errorf("averageWait() = %v, want 200ms", got)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within 1s")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
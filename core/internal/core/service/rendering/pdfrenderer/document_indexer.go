@@ -0,0 +1,125 @@
+package pdfrenderer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	defaultThumbnailWidthPx = 320
+
+	// thumbnailPPI makes one Typst point one pixel, so the page width in points is the image width.
+	thumbnailPPI = 72
+
+	// maxIndexedTextBytes keeps extracted text well below the PostgreSQL tsvector size limit.
+	maxIndexedTextBytes = 512 * 1024
+)
+
+// DocumentIndexOptions configures thumbnail generation and text extraction.
+type DocumentIndexOptions struct {
+	// ThumbnailWidth is the width of first-page thumbnails in pixels (default: 320).
+	ThumbnailWidth int
+
+	// PdftotextPath is the path to the pdftotext binary (poppler-utils).
+	// Empty, or a binary that cannot be found, disables text extraction.
+	PdftotextPath string
+}
+
+// DocumentIndexer implements port.DocumentIndexer with a Typst pass for thumbnails
+// and pdftotext for text extraction.
+type DocumentIndexer struct {
+	typst *TypstRenderer
+	opts  DocumentIndexOptions
+}
+
+// NewDocumentIndexer creates a document indexer using its own Typst renderer.
+func NewDocumentIndexer(typstOpts TypstOptions, opts DocumentIndexOptions) (*DocumentIndexer, error) {
+	typst, err := NewTypstRenderer(typstOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create typst renderer: %w", err)
+	}
+
+	if opts.ThumbnailWidth <= 0 {
+		opts.ThumbnailWidth = defaultThumbnailWidthPx
+	}
+	if opts.PdftotextPath != "" {
+		if _, err := exec.LookPath(opts.PdftotextPath); err != nil {
+			slog.Warn("pdftotext not found, text extraction disabled",
+				slog.String("path", opts.PdftotextPath),
+				slog.Any("error", err),
+			)
+			opts.PdftotextPath = ""
+		}
+	}
+
+	return &DocumentIndexer{typst: typst, opts: opts}, nil
+}
+
+// Thumbnail renders the first page of a PDF as a PNG image.
+func (x *DocumentIndexer) Thumbnail(ctx context.Context, pdf []byte) ([]byte, error) {
+	var thumbnail []byte
+	err := withSourcePDF(pdf, func(dir string) error {
+		var err error
+		thumbnail, err = x.typst.GeneratePNG(ctx, thumbnailSource(x.opts.ThumbnailWidth), dir, thumbnailPPI)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render thumbnail: %w", err)
+	}
+	return thumbnail, nil
+}
+
+// thumbnailSource generates a single page as wide as the thumbnail holding the first source page.
+func thumbnailSource(widthPx int) string {
+	return fmt.Sprintf("#set page(width: %dpt, height: auto, margin: 0pt)\n#image(%q, page: 1, width: 100%%)\n",
+		widthPx, sourcePDFFile)
+}
+
+// ExtractText returns the plain text of a PDF, or an empty string when text extraction is disabled.
+func (x *DocumentIndexer) ExtractText(ctx context.Context, pdf []byte) (string, error) {
+	if x.opts.PdftotextPath == "" {
+		return "", nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, x.typst.opts.Timeout)
+	defer cancel()
+
+	var text string
+	err := withSourcePDF(pdf, func(dir string) error {
+		cmd := exec.CommandContext(ctx, x.opts.PdftotextPath, "-q", "-enc", "UTF-8", filepath.Join(dir, sourcePDFFile), "-") //nolint:gosec // Path comes from configuration
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("pdftotext failed: %w\nstderr: %s", err, stderr.String())
+		}
+		text = normalizeIndexedText(stdout.String())
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return text, nil
+}
+
+// normalizeIndexedText makes extracted text storable in a TEXT column and caps its size.
+func normalizeIndexedText(text string) string {
+	text = strings.ToValidUTF8(text, "")
+	text = strings.ReplaceAll(text, "\x00", "")
+	text = strings.TrimSpace(text)
+	if len(text) <= maxIndexedTextBytes {
+		return text
+	}
+
+	cut := maxIndexedTextBytes
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut]
+}
@@ -0,0 +1,33 @@
+package pdfrenderer
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestThumbnailSource(t *testing.T) {
+	src := thumbnailSource(240)
+
+	for _, want := range []string{
+		"#set page(width: 240pt, height: auto, margin: 0pt)",
+		`#image("source.pdf", page: 1, width: 100%)`,
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("expected source to contain %q, got:\n%s", want, src)
+		}
+	}
+}
+
+func TestNormalizeIndexedText(t *testing.T) {
+	if got := normalizeIndexedText("  Invoice\x00 42\n\f"); got != "Invoice 42" {
+		t.Errorf("normalizeIndexedText() = %q, want %q", got, "Invoice 42")
+	}
+
+	// Truncation never splits a multi-byte rune.
+	long := strings.Repeat("é", maxIndexedTextBytes)
+	got := normalizeIndexedText(long)
+	if len(got) > maxIndexedTextBytes || !utf8.ValidString(got) {
+		t.Errorf("normalizeIndexedText() returned %d bytes, valid UTF-8 = %v", len(got), utf8.ValidString(got))
+	}
+}
@@ -0,0 +1,1632 @@
+package pdfrenderer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif"  // decode the size of GIF images
+	_ "image/jpeg" // decode the size of JPEG images
+	neturl "net/url"
+	"strconv"
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// RenderDocx converts the document of a render request to an editable Word document. Nothing is
+// compiled, so it does not take a render slot. Images are downloaded and embedded in the file.
+func (s *Service) RenderDocx(ctx context.Context, req *port.RenderPreviewRequest) (*port.DocxRenderResult, error) {
+	if req.Document == nil {
+		return nil, fmt.Errorf("document is required")
+	}
+	if req.Imposition != nil {
+		return nil, entity.ErrDocxRenderOption
+	}
+	doc := req.Document
+	if req.Layout != nil {
+		if err := req.Layout.Validate(&doc.PageConfig); err != nil {
+			return nil, err
+		}
+		doc = applyLayout(doc, req.Layout)
+	}
+	unknownWarnings, err := checkUnknownNodes(doc, req.UnknownNodes)
+	if err != nil {
+		return nil, err
+	}
+
+	injectableDefaults := req.InjectableDefaults
+	if injectableDefaults == nil {
+		injectableDefaults = make(map[string]string)
+	}
+
+	converter := NewDocxConverter(req.Injectables, injectableDefaults, s.designTokens)
+	if req.ImageURLResolver != nil {
+		converter.SetImageURLResolver(func(url string) (string, error) {
+			return req.ImageURLResolver(ctx, url)
+		})
+	}
+	converter.SetImageLoader(func(url string) ([]byte, error) {
+		return s.fetcher().Load(ctx, url)
+	})
+	converter.SetWatermark(req.Watermark)
+	converter.SetOverlays(req.Overlays)
+	converter.SetUnknownNodes(req.UnknownNodes)
+	converter.SetCurrencyConverter(req.ConvertCurrency)
+	if req.Layout != nil {
+		converter.SetFontScale(req.Layout.FontScale)
+	}
+	docx, err := converter.Build(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate DOCX: %w", err)
+	}
+
+	return &port.DocxRenderResult{
+		Docx:     docx,
+		Filename: strings.TrimSuffix(s.generateFilename(doc.Meta.Title), ".pdf") + ".docx",
+		Warnings: append(unknownWarnings, converter.Warnings()...),
+	}, nil
+}
+
+// Word units. Page dimensions in portable documents are pixels at 96 DPI.
+const (
+	pxToTwip = 15   // 1px = 0.75pt = 15 twentieths of a point
+	pxToEMU  = 9525 // English Metric Units per pixel
+	ptToTwip = 20   // twentieths of a point
+	docxList = 360  // indent of one list level, in twips
+	docxGap  = 284  // indent of blockquotes, in twips (0.5cm)
+	docxCode = "Courier New"
+)
+
+// DocxConverter converts portable documents to an editable Word (OOXML) document, so they can be
+// handed off for redlining. Values are resolved by a TypstConverter, so injectors, conditions,
+// list and table injectors and table calculations show the same values as the PDF, and nodes
+// restricted to the pdf output are included. Word lays the pages out: page breaks are kept, the
+// header is placed on the first page, the footer closes the document and page numbers become
+// Word fields.
+type DocxConverter struct {
+	values     *TypstConverter
+	tokens     TypstDesignTokens
+	fontScale  float64
+	watermark  string
+	overlays   *portabledoc.Overlays
+	loadImage  func(url string) ([]byte, error)
+	part       *docxPart      // part being written; relationships are added to it
+	media      []docxMedia    // images embedded in the package
+	mediaIndex map[string]int // image URL → position in media, -1 when it could not be embedded
+	numbering  docxNumbering  // list formats and instances
+	references []portabledoc.ReferencesAttrs
+	markers    bool            // follow links with their reference number
+	links      []htmlReference // links of the document, once per URL
+	linkIndex  map[string]int  // URL → position in links
+	drawings   int             // drawing IDs, unique within the document
+	warnings   []entity.RenderWarning
+	warned     map[string]bool // node types already reported as omitted
+}
+
+// NewDocxConverter creates a new DOCX converter.
+func NewDocxConverter(
+	injectables map[string]any,
+	injectableDefaults map[string]string,
+	tokens TypstDesignTokens,
+) *DocxConverter {
+	return &DocxConverter{
+		values:     NewTypstConverter(injectables, injectableDefaults, tokens),
+		tokens:     tokens,
+		fontScale:  1,
+		mediaIndex: make(map[string]int),
+		linkIndex:  make(map[string]int),
+		warned:     make(map[string]bool),
+	}
+}
+
+// SetImageURLResolver sets a function to resolve non-standard image URL schemes.
+func (c *DocxConverter) SetImageURLResolver(fn func(url string) (string, error)) {
+	c.values.imageURLResolver = fn
+}
+
+// SetImageLoader sets the function that fetches images to embed. Without one, images are left out.
+func (c *DocxConverter) SetImageLoader(fn func(url string) ([]byte, error)) {
+	c.loadImage = fn
+}
+
+// SetWatermark sets the watermark of the render. Word documents are not watermarked; a warning
+// is reported instead.
+func (c *DocxConverter) SetWatermark(text string) {
+	c.watermark = text
+}
+
+// SetOverlays sets the overlays of the render request. Word documents get no overlays; a warning
+// is reported instead.
+func (c *DocxConverter) SetOverlays(overlays *portabledoc.Overlays) {
+	c.overlays = overlays
+}
+
+// SetUnknownNodes sets how nodes of an unknown type are rendered: PLACEHOLDER shows a box naming
+// their type in the document, the other modes flatten them to their content.
+func (c *DocxConverter) SetUnknownNodes(mode entity.UnknownNodeMode) {
+	c.values.unknownPlaceholders = mode == entity.UnknownNodesPlaceholder
+}
+
+// SetFontScale multiplies the base, heading and inline font sizes. Zero or 1 keeps them.
+func (c *DocxConverter) SetFontScale(scale float64) {
+	if scale == 0 || scale == 1 {
+		return
+	}
+	c.fontScale = scale
+	c.values.fontScale = scale
+}
+
+// SetCurrencyConverter sets the currency conversion behind convert() in table formulas.
+func (c *DocxConverter) SetCurrencyConverter(fn entity.CurrencyConvertFunc) {
+	c.values.convertCurrency = fn
+}
+
+// Warnings returns the parts of the document the Word document leaves out, once per kind of node.
+func (c *DocxConverter) Warnings() []entity.RenderWarning {
+	return c.warnings
+}
+
+// Build creates the Word document of a portable document.
+func (c *DocxConverter) Build(doc *portabledoc.Document) ([]byte, error) {
+	c.values.defaultLang = doc.Meta.Language
+	c.values.unitSystem = doc.Meta.UnitSystem
+	c.values.setDocumentDates(doc.Meta)
+	page := doc.PageConfig
+	c.values.contentWidthPx = page.Width - page.Margins.Left - page.Margins.Right
+	for _, node := range doc.NodesOfType(portabledoc.NodeTypeReferences) {
+		if attrs, err := portabledoc.ParseReferencesAttrs(node.Attrs); err == nil && attrs.Markers {
+			c.markers = true
+		}
+	}
+	overlays := portabledoc.MergeOverlays(page.Overlays, c.overlays)
+	if c.watermark != "" || (overlays != nil && overlays.Watermark != "") {
+		c.omit("watermark", "the watermark is not added to DOCX")
+	}
+	if overlays != nil && (overlays.Stamp != nil || overlays.Bates != nil) {
+		c.omit("overlays", "the stamp and Bates numbering are not added to DOCX")
+	}
+
+	pkg := &docxPackage{title: doc.Meta.Title, lang: doc.Meta.Language}
+	pkg.document = &docxPart{name: "document.xml"}
+	c.part = pkg.document
+	var body strings.Builder
+	if doc.Content != nil {
+		body.WriteString(c.blocks(doc.Content.Content, &docxBlock{}))
+	}
+	if doc.FooterEnabled() {
+		// Word repeats footers on every page; the PDF footer closes the document, so it does here too
+		body.WriteString(c.surface(doc.Footer))
+	}
+	content := body.String()
+	for i := range c.references {
+		content = strings.Replace(content, referencesPlaceholder(i), c.referencesTable(c.references[i]), 1)
+	}
+	pkg.document.content = content
+
+	if doc.HeaderEnabled() {
+		header := &docxPart{name: "header1.xml", kind: docxHeader}
+		c.part = header
+		header.content = c.surface(doc.Header)
+		pkg.firstHeader = header
+	}
+	if page.PageStamp != nil {
+		c.pageStampParts(pkg, page.PageStamp)
+	}
+
+	pkg.media = c.media
+	pkg.numbering = &c.numbering
+	return pkg.write(c.sectionProperties(&page, pkg), c.styles())
+}
+
+// omit records that a kind of node was left out of the document.
+func (c *DocxConverter) omit(nodeType, message string) {
+	if c.warned[nodeType] {
+		return
+	}
+	c.warned[nodeType] = true
+	c.warnings = append(c.warnings, entity.RenderWarning{Code: entity.RenderWarningDocxOmitted, Message: message})
+}
+
+// --- Blocks ---
+
+// docxBlock is the context block nodes are converted in: the paragraph and run properties of
+// the blockquote, list item or table cell that holds them.
+type docxBlock struct {
+	para     docxParaProps
+	run      docxRunProps
+	depth    int  // list nesting
+	numbered bool // para carries list numbering no paragraph has used yet
+}
+
+// next switches to the context of the paragraphs after the first one of a list item: indented
+// like the item, without its number.
+func (b *docxBlock) next() {
+	if b.numbered {
+		b.numbered = false
+		b.para.numID = 0
+		b.para.indent = docxList * 2 * b.depth
+	}
+}
+
+// blocks converts nodes to paragraphs and tables. Inline nodes outside a paragraph are gathered
+// into one.
+func (c *DocxConverter) blocks(nodes []portabledoc.Node, ctx *docxBlock) string {
+	var sb strings.Builder
+	nodes = c.values.visibleNodes(nodes)
+	for i := 0; i < len(nodes); i++ {
+		if isDocxInline(nodes[i]) {
+			j := i
+			for j < len(nodes) && isDocxInline(nodes[j]) {
+				j++
+			}
+			sb.WriteString(c.paragraphXML(ctx, ctx.para, c.inline(nodes[i:j], ctx.run)))
+			i = j - 1
+			continue
+		}
+		sb.WriteString(c.block(nodes[i], ctx))
+	}
+	return sb.String()
+}
+
+// isDocxInline reports whether a node is converted to runs rather than paragraphs.
+func isDocxInline(node portabledoc.Node) bool {
+	switch node.Type {
+	case portabledoc.NodeTypeText, portabledoc.NodeTypeHardBreak, portabledoc.NodeTypeInjector, portabledoc.NodeTypePageNumber:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *DocxConverter) block(node portabledoc.Node, ctx *docxBlock) string {
+	if !node.VisibleIn(portabledoc.OutputPDF) {
+		return ""
+	}
+	switch node.Type {
+	case portabledoc.NodeTypeParagraph:
+		para := ctx.para
+		applyDocxAlign(&para, node.Attrs)
+		c.applyLineSpacing(&para, node.Attrs)
+		return c.paragraphXML(ctx, para, c.inline(node.Content, ctx.run))
+	case portabledoc.NodeTypeHeading:
+		para := ctx.para
+		para.style = fmt.Sprintf("Heading%d", c.values.parseHeadingLevel(node.Attrs))
+		applyDocxAlign(&para, node.Attrs)
+		c.applyLineSpacing(&para, node.Attrs)
+		return c.paragraphXML(ctx, para, c.inline(node.Content, ctx.run))
+	case portabledoc.NodeTypeBlockquote:
+		quote := *ctx
+		quote.para.style = "Quote"
+		return c.blocks(node.Content, &quote)
+	case portabledoc.NodeTypeCodeBlock:
+		para := ctx.para
+		para.style = "Code"
+		return c.paragraphXML(ctx, para, c.codeRuns(node.Content))
+	case portabledoc.NodeTypeHR:
+		para := ctx.para
+		para.border = fmt.Sprintf(`<w:bottom w:val="single" w:sz="4" w:space="1" w:color="%s"/>`, docxColor(c.tokens.HRStrokeColor, "C8C8C8"))
+		return c.paragraphXML(ctx, para, "")
+	case portabledoc.NodeTypeBulletList:
+		return c.list(node.Content, ctx, docxListFormat{kind: docxListBullet}, 1)
+	case portabledoc.NodeTypeOrderedList:
+		start := 1
+		if s, ok := node.Attrs["start"].(float64); ok && s > 0 {
+			start = int(s)
+		}
+		return c.list(node.Content, ctx, docxListFormat{kind: docxListDecimal}, start)
+	case portabledoc.NodeTypeTaskList, portabledoc.NodeTypeListItem, portabledoc.NodeTypeTaskItem:
+		items := node.Content
+		if node.Type != portabledoc.NodeTypeTaskList {
+			items = []portabledoc.Node{node}
+		}
+		return c.list(items, ctx, docxListFormat{kind: docxListBullet}, 1)
+	case portabledoc.NodeTypeConditional:
+		if c.values.evaluateCondition(node.Attrs) {
+			return c.blocks(node.Content, ctx)
+		}
+		return ""
+	case portabledoc.NodeTypeRepeater:
+		var sb strings.Builder
+		c.values.repeat(node, func() { sb.WriteString(c.blocks(node.Content, ctx)) })
+		return sb.String()
+	case portabledoc.NodeTypePageBreak:
+		c.values.currentPage++
+		return `<w:p><w:r><w:br w:type="page"/></w:r></w:p>`
+	case portabledoc.NodeTypeImage, portabledoc.NodeTypeCustomImage:
+		para := ctx.para
+		align, _ := node.Attrs["align"].(string)
+		para.align = docxAlign(align)
+		return c.paragraphXML(ctx, para, c.image(node, ctx.run))
+	case portabledoc.NodeTypeListInjector:
+		return c.listInjector(node, ctx)
+	case portabledoc.NodeTypeTableInjector:
+		return c.tableInjector(node)
+	case portabledoc.NodeTypeTable:
+		return c.table(node)
+	case portabledoc.NodeTypeSecurityPattern:
+		c.omit(node.Type, "security patterns are not added to DOCX")
+		return ""
+	case portabledoc.NodeTypeExternalPDF:
+		return c.externalPDF(node, ctx)
+	case portabledoc.NodeTypeReferences:
+		attrs, err := portabledoc.ParseReferencesAttrs(node.Attrs)
+		if err != nil {
+			attrs = &portabledoc.ReferencesAttrs{}
+		}
+		c.references = append(c.references, *attrs)
+		return referencesPlaceholder(len(c.references) - 1)
+	default:
+		if c.values.unknownPlaceholders {
+			return c.paragraphXML(ctx, ctx.para, c.unknownNode(node, ctx.run))
+		}
+		return c.blocks(node.Content, ctx)
+	}
+}
+
+// paragraphXML writes a paragraph. The numbering of a list item goes to its first paragraph only.
+func (c *DocxConverter) paragraphXML(ctx *docxBlock, para docxParaProps, runs string) string {
+	ctx.next()
+	return "<w:p>" + para.xml() + runs + "</w:p>"
+}
+
+// applyDocxAlign sets the alignment of a paragraph or heading from its textAlign attribute.
+func applyDocxAlign(para *docxParaProps, attrs map[string]any) {
+	if align, _ := attrs["textAlign"].(string); docxAlign(align) != "" {
+		para.align = docxAlign(align)
+	}
+}
+
+// applyLineSpacing sets the line spacing of a paragraph or heading with a lineSpacing attribute.
+func (c *DocxConverter) applyLineSpacing(para *docxParaProps, attrs map[string]any) {
+	if raw, _ := attrs["lineSpacing"].(string); raw == "" {
+		return
+	}
+	ls := c.values.resolveLineSpacing(attrs)
+	para.spacing = c.spacingXML(ls.leading, ls.spacing)
+}
+
+// spacingXML converts Typst paragraph leading and spacing to Word line and after spacing. Word's
+// single spacing corresponds to the default leading of 0.5em.
+func (c *DocxConverter) spacingXML(leading, spacing string) string {
+	base := parsePt(c.tokens.BaseFontSize, 12) * c.fontScale
+	line := max(200, int(240*(1+parseEm(leading))/1.5))
+	after := int(parseEm(spacing) * base * ptToTwip)
+	return fmt.Sprintf(`<w:spacing w:after="%d" w:line="%d" w:lineRule="auto"/>`, after, line)
+}
+
+// codeRuns returns the runs of a code block, keeping its line breaks.
+func (c *DocxConverter) codeRuns(nodes []portabledoc.Node) string {
+	var text strings.Builder
+	var collect func([]portabledoc.Node)
+	collect = func(nodes []portabledoc.Node) {
+		for _, node := range nodes {
+			if node.Text != nil {
+				text.WriteString(*node.Text)
+			}
+			collect(node.Content)
+		}
+	}
+	collect(nodes)
+
+	var sb strings.Builder
+	for i, line := range strings.Split(text.String(), "\n") {
+		if i > 0 {
+			sb.WriteString("<w:r><w:br/></w:r>")
+		}
+		sb.WriteString(docxRun(line, docxRunProps{}))
+	}
+	return sb.String()
+}
+
+// --- Lists ---
+
+// list writes the items of a user-built list as numbered paragraphs. Task items are marked with
+// a checkbox instead of a number.
+func (c *DocxConverter) list(items []portabledoc.Node, ctx *docxBlock, format docxListFormat, start int) string {
+	level := min(ctx.depth, 8)
+	numID := c.numbering.add(format, level, start)
+
+	var sb strings.Builder
+	sb.WriteString(c.openItem(ctx))
+	for _, item := range items {
+		itemCtx := &docxBlock{para: ctx.para, run: ctx.run, depth: ctx.depth + 1, numbered: true}
+		itemCtx.para.numID, itemCtx.para.level, itemCtx.para.indent = numID, level, 0
+
+		content := item.Content
+		if item.Type == portabledoc.NodeTypeTaskItem {
+			// The checkbox replaces the bullet
+			itemCtx.para.numID, itemCtx.numbered = 0, false
+			itemCtx.para.indent = docxList * 2 * (level + 1)
+			box := "☐ "
+			if checked, _ := item.Attrs["checked"].(bool); checked {
+				box = "☑ "
+			}
+			content = prependText(content, box)
+		}
+
+		text := c.blocks(content, itemCtx)
+		if itemCtx.numbered {
+			// The item does not open with a paragraph; number an empty one
+			text = "<w:p>" + itemCtx.para.xml() + "</w:p>" + text
+		}
+		sb.WriteString(text)
+	}
+	return sb.String()
+}
+
+// openItem returns an empty numbered paragraph when a list item opens with a nested list, so
+// the item keeps its number.
+func (c *DocxConverter) openItem(ctx *docxBlock) string {
+	if !ctx.numbered {
+		return ""
+	}
+	return c.paragraphXML(ctx, ctx.para, "")
+}
+
+// prependText adds text at the start of the first paragraph of nodes, or as a paragraph of its own.
+func prependText(nodes []portabledoc.Node, text string) []portabledoc.Node {
+	t := text
+	textNode := portabledoc.Node{Type: portabledoc.NodeTypeText, Text: &t}
+	out := append([]portabledoc.Node(nil), nodes...)
+	if len(out) > 0 && out[0].Type == portabledoc.NodeTypeParagraph {
+		first := out[0]
+		first.Content = append([]portabledoc.Node{textNode}, first.Content...)
+		out[0] = first
+		return out
+	}
+	return append([]portabledoc.Node{{Type: portabledoc.NodeTypeParagraph, Content: []portabledoc.Node{textNode}}}, out...)
+}
+
+func (c *DocxConverter) listInjector(node portabledoc.Node, ctx *docxBlock) string {
+	variableID, _ := node.Attrs["variableId"].(string)
+	lang, _ := node.Attrs["lang"].(string)
+	if lang == "" {
+		lang = c.values.fallbackLang()
+	}
+
+	resolved := c.values.resolveListValue(variableID)
+	if resolved == nil {
+		return ""
+	}
+	listCopy := *resolved
+	listData := &listCopy
+
+	if sym, ok := node.Attrs["symbol"].(string); ok && sym != "" {
+		listData.Symbol = entity.ListSymbol(sym)
+	}
+	if start, ok := node.Attrs["start"].(float64); ok && start > 0 {
+		s := int(start)
+		listData.Start = &s
+	}
+	if cont, ok := node.Attrs["continueNumbering"].(bool); ok && cont {
+		listData.Continue = true
+	}
+
+	headerStyles := c.values.parseListStylesFromAttrs(node.Attrs, "header")
+	itemStyles := c.values.parseListStylesFromAttrs(node.Attrs, "item")
+	if listData.HeaderStyles != nil {
+		headerStyles = c.values.mergeListStyles(listData.HeaderStyles, headerStyles)
+	}
+	if listData.ItemStyles != nil {
+		itemStyles = c.values.mergeListStyles(listData.ItemStyles, itemStyles)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(c.openItem(ctx))
+	label, _ := node.Attrs["label"].(string)
+	if label == "" && len(listData.HeaderLabel) > 0 {
+		label = c.values.getListHeaderLabel(listData.HeaderLabel, lang)
+	}
+	if label != "" {
+		para, run := ctx.para, ctx.run
+		applyListStyles(&para, &run, headerStyles, c.fontScale)
+		sb.WriteString(c.paragraphXML(ctx, para, docxRun(label, run)))
+	}
+
+	isEnum := listData.Symbol.IsNumbered()
+	start := c.values.listStart(listData, isEnum)
+	format := docxListFormatOf(listData)
+	numID := c.numbering.add(format, 0, start)
+	c.listLevel(&sb, listData, listData.Items, itemStyles, ctx, numID, 0)
+	return sb.String()
+}
+
+// listLevel writes one level of a list injector.
+func (c *DocxConverter) listLevel(sb *strings.Builder, list *entity.ListValue, items []entity.ListItem, styles *entity.ListStyles, ctx *docxBlock, numID, depth int) {
+	para, run := ctx.para, ctx.run
+	para.numID, para.level, para.indent = numID, min(depth, 8), 0
+	applyListStyles(&para, &run, styles, c.fontScale)
+	if depth < len(list.LevelStyles) {
+		applyListStyles(&para, &run, &list.LevelStyles[depth], c.fontScale)
+	}
+	for _, item := range items {
+		value := ""
+		if item.Value != nil {
+			value = strings.TrimSpace(c.values.formatCellValue(item.Value, ""))
+		}
+		sb.WriteString("<w:p>" + para.xml() + docxRun(value, run) + "</w:p>")
+		if len(item.Children) > 0 {
+			c.listLevel(sb, list, item.Children, styles, ctx, numID, depth+1)
+		}
+	}
+}
+
+// docxListFormatOf returns the numbering format of a list injector.
+func docxListFormatOf(list *entity.ListValue) docxListFormat {
+	switch list.Symbol {
+	case entity.ListSymbolMultilevel:
+		return docxListFormat{kind: docxListMultilevel}
+	case entity.ListSymbolNumber:
+		return docxListFormat{kind: docxListDecimal}
+	case entity.ListSymbolRoman:
+		return docxListFormat{kind: docxListRoman}
+	case entity.ListSymbolLetter:
+		return docxListFormat{kind: docxListLetter}
+	}
+	var markers []string
+	for _, m := range list.Markers {
+		if m = strings.TrimSpace(m); m != "" {
+			markers = append(markers, m)
+		}
+	}
+	if len(markers) == 0 && list.Symbol == entity.ListSymbolDash {
+		markers = []string{"–"}
+	}
+	return docxListFormat{kind: docxListBullet, markers: markers}
+}
+
+// applyListStyles applies list injector styles to a paragraph and its runs. Font sizes are in points.
+func applyListStyles(para *docxParaProps, run *docxRunProps, styles *entity.ListStyles, scale float64) {
+	if styles == nil {
+		return
+	}
+	applyTextStyles(para, run, styles.FontFamily, styles.FontSize, styles.FontWeight, styles.TextColor, styles.TextAlign, scale)
+}
+
+// applyTextStyles applies the text styles of list and table injectors.
+func applyTextStyles(para *docxParaProps, run *docxRunProps, family *string, size *int, weight, color, align *string, scale float64) {
+	if family != nil {
+		run.font = *family
+	}
+	if size != nil && *size > 0 {
+		run.size = int(float64(*size) * 2 * scale)
+	}
+	if weight != nil {
+		run.bold = *weight == "bold"
+	}
+	if color != nil {
+		run.color = docxColor(*color, "")
+	}
+	if align != nil && docxAlign(*align) != "" {
+		para.align = docxAlign(*align)
+	}
+}
+
+// --- Inline content ---
+
+// inline converts the nodes of a paragraph to runs. The text fragments of one link are grouped
+// in one hyperlink, so the link is listed once in references nodes.
+func (c *DocxConverter) inline(nodes []portabledoc.Node, props docxRunProps) string {
+	var sb strings.Builder
+	nodes = c.values.visibleNodes(nodes)
+	for i := 0; i < len(nodes); i++ {
+		if href := portabledoc.LinkHref(nodes[i]); href != "" && nodes[i].Text != nil {
+			run := portabledoc.LinkRunLength(nodes[i:], href)
+			sb.WriteString(c.linkRun(nodes[i:i+run], href, props))
+			i += run - 1
+			continue
+		}
+		sb.WriteString(c.inlineNode(nodes[i], props))
+	}
+	return sb.String()
+}
+
+func (c *DocxConverter) inlineNode(node portabledoc.Node, props docxRunProps) string {
+	if !node.VisibleIn(portabledoc.OutputPDF) {
+		return ""
+	}
+	switch node.Type {
+	case portabledoc.NodeTypeText:
+		if node.Text == nil {
+			return ""
+		}
+		return docxRun(*node.Text, c.applyMarks(props, node.Marks))
+	case portabledoc.NodeTypeHardBreak:
+		return "<w:r><w:br/></w:r>"
+	case portabledoc.NodeTypeInjector:
+		return c.injector(node, props)
+	case portabledoc.NodeTypePageNumber:
+		display, _ := node.Attrs["display"].(string)
+		field := "NUMPAGES"
+		if display == portabledoc.PageNumberCurrent {
+			field = "PAGE"
+		}
+		return docxField(field, c.applyMarks(props, node.Marks))
+	case portabledoc.NodeTypeImage, portabledoc.NodeTypeCustomImage:
+		return c.image(node, props)
+	case portabledoc.NodeTypeConditional:
+		if c.values.evaluateCondition(node.Attrs) {
+			return c.inline(node.Content, props)
+		}
+		return ""
+	case portabledoc.NodeTypePageBreak:
+		c.values.currentPage++
+		return `<w:r><w:br w:type="page"/></w:r>`
+	default:
+		if c.values.unknownPlaceholders {
+			return c.unknownNode(node, props)
+		}
+		return c.inline(node.Content, props)
+	}
+}
+
+// unknownNode returns the placeholder run shown in place of a node of an unknown type.
+func (c *DocxConverter) unknownNode(node portabledoc.Node, props docxRunProps) string {
+	props.color, props.shading = "C0392B", "FBEAEA"
+	return docxRun("["+unknownNodeLabel+node.Type+"]", props)
+}
+
+func (c *DocxConverter) injector(node portabledoc.Node, props docxRunProps) string {
+	variableID, _ := node.Attrs["variableId"].(string)
+	prefix, _ := node.Attrs["prefix"].(string)
+	suffix, _ := node.Attrs["suffix"].(string)
+	showLabelIfEmpty, _ := node.Attrs["showLabelIfEmpty"].(bool)
+
+	value := c.values.injectorValue(variableID, node.Attrs)
+	if value == "" && !showLabelIfEmpty {
+		return ""
+	}
+	return docxRun(prefix+value+suffix, c.applyMarks(props, node.Marks))
+}
+
+// applyMarks returns the run properties of text with marks. Links are applied by linkRun.
+func (c *DocxConverter) applyMarks(props docxRunProps, marks []portabledoc.Mark) docxRunProps {
+	for _, m := range marks {
+		switch m.Type {
+		case portabledoc.MarkTypeBold:
+			props.bold = true
+		case portabledoc.MarkTypeItalic:
+			props.italic = true
+		case portabledoc.MarkTypeStrike:
+			props.strike = true
+		case portabledoc.MarkTypeUnderline:
+			props.underline = true
+		case portabledoc.MarkTypeCode:
+			props.font = docxCode
+		case portabledoc.MarkTypeHighlight:
+			clr, _ := m.Attrs["color"].(string)
+			props.shading = docxColor(clr, docxColor(c.tokens.HighlightDefaultColor, "FFEB3B"))
+		case portabledoc.MarkTypeTextStyle:
+			if color, ok := m.Attrs["color"].(string); ok && color != "" {
+				props.color = docxColor(color, props.color)
+			}
+			if fontSize, ok := m.Attrs["fontSize"].(string); ok && fontSize != "" {
+				if n, err := strconv.ParseFloat(strings.TrimSuffix(fontSize, "px"), 64); err == nil && n > 0 {
+					props.size = int(n * pxToPt * 2 * c.fontScale)
+				}
+			}
+			if fontFamily, ok := m.Attrs["fontFamily"].(string); ok && fontFamily != "" {
+				props.font = strings.Trim(strings.TrimSpace(strings.Split(fontFamily, ",")[0]), `"'`)
+			}
+		}
+	}
+	return props
+}
+
+// linkRun writes the text nodes of one link as a hyperlink and records the link for references nodes.
+func (c *DocxConverter) linkRun(nodes []portabledoc.Node, href string, props docxRunProps) string {
+	target := docxLinkTarget(href)
+	linkProps := props
+	if target != "" {
+		linkProps.style = "Hyperlink"
+	}
+	var runs, title strings.Builder
+	for _, node := range nodes {
+		runs.WriteString(docxRun(*node.Text, c.applyMarks(linkProps, node.Marks)))
+		title.WriteString(*node.Text)
+	}
+
+	var sb strings.Builder
+	if target != "" {
+		fmt.Fprintf(&sb, `<w:hyperlink r:id="%s" w:history="1">%s</w:hyperlink>`, c.part.addRel(docxRelHyperlink, target, true), runs.String())
+	} else {
+		// Word cannot open the link; keep its text
+		sb.WriteString(runs.String())
+	}
+
+	n, ok := c.linkIndex[href]
+	if !ok {
+		c.links = append(c.links, htmlReference{url: href, title: strings.TrimSpace(title.String())})
+		n = len(c.links)
+		c.linkIndex[href] = n
+	}
+	if c.markers {
+		marker := props
+		marker.superscript = true
+		sb.WriteString(docxRun(fmt.Sprintf("[%d]", n), marker))
+	}
+	return sb.String()
+}
+
+// docxLinkTarget returns the target of a hyperlink relationship, or "" for links Word cannot open.
+func docxLinkTarget(href string) string {
+	href = safeHref(href)
+	if href == "" || strings.HasPrefix(href, "#") {
+		return ""
+	}
+	if _, err := neturl.Parse(href); err != nil {
+		return ""
+	}
+	return href
+}
+
+// --- Images ---
+
+// docxMedia is an image embedded in the package.
+type docxMedia struct {
+	name          string // filename under word/media
+	data          []byte
+	width, height int // pixels
+}
+
+// embedImage adds the image at url to the package once and returns its position in media, or -1
+// when it cannot be embedded.
+func (c *DocxConverter) embedImage(url string) int {
+	if i, ok := c.mediaIndex[url]; ok {
+		return i
+	}
+	c.mediaIndex[url] = -1
+	if c.loadImage == nil {
+		c.omit("imageLoader", "images are not added to DOCX")
+		return -1
+	}
+
+	data, err := c.loadImage(url)
+	if err != nil {
+		c.omit("imageDownload", "an image could not be downloaded and is not added to DOCX")
+		return -1
+	}
+	ext := detectImageExt(data)
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || (ext != ".png" && ext != ".jpg" && ext != ".gif") {
+		c.omit("imageFormat", "images in formats other than PNG, JPEG and GIF are not added to DOCX")
+		return -1
+	}
+
+	c.media = append(c.media, docxMedia{
+		name:   fmt.Sprintf("image%d%s", len(c.media)+1, ext),
+		data:   data,
+		width:  cfg.Width,
+		height: cfg.Height,
+	})
+	c.mediaIndex[url] = len(c.media) - 1
+	return len(c.media) - 1
+}
+
+// image returns the run of an image node, or "" when it cannot be embedded. Images keep their
+// aspect ratio and are no wider than the content area; circle images are cropped to an ellipse.
+func (c *DocxConverter) image(node portabledoc.Node, props docxRunProps) string {
+	src := c.values.resolveSource(node.Attrs)
+	lower := strings.ToLower(src)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") && !strings.HasPrefix(lower, "data:image/") {
+		return ""
+	}
+	i := c.embedImage(src)
+	if i < 0 {
+		return ""
+	}
+	media := c.media[i]
+
+	width, _ := node.Attrs["width"].(float64)
+	if width <= 0 {
+		width = float64(media.width)
+	}
+	if maxWidth := c.values.contentWidthPx; maxWidth > 0 && width > maxWidth {
+		width = maxWidth
+	}
+	height := width * float64(media.height) / float64(media.width)
+	geometry := "rect"
+	if shape, _ := node.Attrs["shape"].(string); shape == "circle" {
+		geometry = "ellipse"
+		if h, _ := node.Attrs["height"].(float64); h > 0 && h < width {
+			width = h
+		}
+		height = width
+	}
+	alt, _ := node.Attrs["alt"].(string)
+	return c.drawing(i, width, height, alt, geometry, props)
+}
+
+// drawing returns the run of an embedded image of the given size in pixels.
+func (c *DocxConverter) drawing(media int, width, height float64, alt, geometry string, props docxRunProps) string {
+	c.drawings++
+	id := c.drawings
+	rel := c.part.addRel(docxRelImage, "media/"+c.media[media].name, false)
+	cx, cy := int(width*pxToEMU), int(height*pxToEMU)
+	return fmt.Sprintf(`<w:r>%s<w:drawing><wp:inline distT="0" distB="0" distL="0" distR="0">`+
+		`<wp:extent cx="%d" cy="%d"/><wp:docPr id="%d" name="Picture %d" descr="%s"/>`+
+		`<wp:cNvGraphicFramePr><a:graphicFrameLocks xmlns:a="%s" noChangeAspect="1"/></wp:cNvGraphicFramePr>`+
+		`<a:graphic xmlns:a="%s"><a:graphicData uri="%s"><pic:pic xmlns:pic="%s">`+
+		`<pic:nvPicPr><pic:cNvPr id="%d" name="%s"/><pic:cNvPicPr/></pic:nvPicPr>`+
+		`<pic:blipFill><a:blip r:embed="%s"/><a:stretch><a:fillRect/></a:stretch></pic:blipFill>`+
+		`<pic:spPr><a:xfrm><a:off x="0" y="0"/><a:ext cx="%d" cy="%d"/></a:xfrm><a:prstGeom prst="%s"><a:avLst/></a:prstGeom></pic:spPr>`+
+		`</pic:pic></a:graphicData></a:graphic></wp:inline></w:drawing></w:r>`,
+		props.xml(), cx, cy, id, id, docxEscape(alt), nsDrawingML, nsDrawingML, nsPicture, nsPicture,
+		id, c.media[media].name, rel, cx, cy, geometry)
+}
+
+// --- Tables ---
+
+// docxCell is a table cell: its paragraphs, span and shading.
+type docxCell struct {
+	content string
+	colspan int
+	rowspan int
+	fill    string
+}
+
+// tableXML writes a table. The first headerRows rows repeat on each page. Cells covered by the
+// rowspan of a cell above are added as merged cells.
+func (c *DocxConverter) tableXML(widths []int, rows [][]docxCell, headerRows int) string {
+	var sb strings.Builder
+	sb.WriteString(`<w:tbl><w:tblPr><w:tblStyle w:val="TableGrid"/><w:tblW w:w="0" w:type="auto"/><w:tblLayout w:type="fixed"/>`)
+	sb.WriteString(c.cellMargins(c.tokens.TableBodyCellInset))
+	sb.WriteString(`</w:tblPr><w:tblGrid>`)
+	for _, w := range widths {
+		fmt.Fprintf(&sb, `<w:gridCol w:w="%d"/>`, w)
+	}
+	sb.WriteString(`</w:tblGrid>`)
+
+	covered := make([]int, len(widths)) // remaining rows covered by a rowspan, per column
+	spans := make([]int, len(widths))   // colspan of the cell covering the column
+	writeCovered := func(col int) int {
+		span := max(1, spans[col])
+		fmt.Fprintf(&sb, `<w:tc><w:tcPr>%s<w:vMerge/></w:tcPr><w:p/></w:tc>`, gridSpanXML(span))
+		covered[col]--
+		return col + span
+	}
+	for r, row := range rows {
+		sb.WriteString("<w:tr>")
+		if r < headerRows {
+			sb.WriteString(`<w:trPr><w:tblHeader/></w:trPr>`)
+		}
+		col := 0
+		for _, cell := range row {
+			for col < len(widths) && covered[col] > 0 {
+				col = writeCovered(col)
+			}
+			span := max(1, cell.colspan)
+			if col+span > len(widths) {
+				span = max(1, len(widths)-col)
+			}
+			width := 0
+			for i := col; i < col+span && i < len(widths); i++ {
+				width += widths[i]
+			}
+			sb.WriteString("<w:tc><w:tcPr>")
+			fmt.Fprintf(&sb, `<w:tcW w:w="%d" w:type="dxa"/>%s`, width, gridSpanXML(span))
+			if cell.rowspan > 1 && col < len(widths) {
+				sb.WriteString(`<w:vMerge w:val="restart"/>`)
+				covered[col], spans[col] = cell.rowspan-1, span
+			}
+			if cell.fill != "" {
+				fmt.Fprintf(&sb, `<w:shd w:val="clear" w:color="auto" w:fill="%s"/>`, cell.fill)
+			}
+			sb.WriteString("</w:tcPr>")
+			sb.WriteString(cellContent(cell.content))
+			sb.WriteString("</w:tc>")
+			col += span
+		}
+		for col < len(widths) && covered[col] > 0 {
+			col = writeCovered(col)
+		}
+		sb.WriteString("</w:tr>")
+	}
+	sb.WriteString("</w:tbl>")
+	// Two adjacent tables would be merged by Word
+	sb.WriteString("<w:p/>")
+	return sb.String()
+}
+
+// cellContent makes sure a cell ends with a paragraph, as Word requires.
+func cellContent(content string) string {
+	if content == "" || strings.HasSuffix(content, "</w:tbl>") {
+		return content + "<w:p/>"
+	}
+	return content
+}
+
+func gridSpanXML(span int) string {
+	if span <= 1 {
+		return ""
+	}
+	return fmt.Sprintf(`<w:gridSpan w:val="%d"/>`, span)
+}
+
+// cellMargins converts a Typst inset token to the default cell margins of a table.
+func (c *DocxConverter) cellMargins(inset string) string {
+	x, y := parseInset(inset)
+	return fmt.Sprintf(`<w:tblCellMar><w:top w:w="%d" w:type="dxa"/><w:left w:w="%d" w:type="dxa"/>`+
+		`<w:bottom w:w="%d" w:type="dxa"/><w:right w:w="%d" w:type="dxa"/></w:tblCellMar>`,
+		int(y*ptToTwip), int(x*ptToTwip), int(y*ptToTwip), int(x*ptToTwip))
+}
+
+// contentWidth returns the width of the content area in twips.
+func (c *DocxConverter) contentWidth() int {
+	if c.values.contentWidthPx > 0 {
+		return int(c.values.contentWidthPx * pxToTwip)
+	}
+	return int((portabledoc.StandardPageSizes[portabledoc.PageFormatA4].Width - 2*72) * pxToTwip)
+}
+
+// gridWidths completes column widths in twips: columns without a width share the rest of the
+// content area, and the columns are narrowed when they do not fit.
+func (c *DocxConverter) gridWidths(widths []int) []int {
+	total := c.contentWidth()
+	used, unset := 0, 0
+	for _, w := range widths {
+		if w > 0 {
+			used += w
+		} else {
+			unset++
+		}
+	}
+	if unset > 0 {
+		share := max(total-used, unset*docxList*2) / unset
+		for i, w := range widths {
+			if w <= 0 {
+				widths[i] = share
+				used += share
+			}
+		}
+	}
+	if used > total {
+		for i := range widths {
+			widths[i] = widths[i] * total / used
+		}
+	}
+	return widths
+}
+
+func (c *DocxConverter) tableInjector(node portabledoc.Node) string {
+	variableID, _ := node.Attrs["variableId"].(string)
+	lang, _ := node.Attrs["lang"].(string)
+	if lang == "" {
+		lang = c.values.fallbackLang()
+	}
+
+	tableData := c.values.resolveTableValue(variableID)
+	if tableData == nil || len(tableData.Columns) == 0 {
+		return ""
+	}
+
+	headerStyles := c.values.parseTableStylesFromAttrs(node.Attrs, "header")
+	bodyStyles := c.values.parseTableStylesFromAttrs(node.Attrs, "body")
+	if tableData.HeaderStyles != nil {
+		headerStyles = c.values.mergeTableStyles(tableData.HeaderStyles, headerStyles)
+	}
+	if tableData.BodyStyles != nil {
+		bodyStyles = c.values.mergeTableStyles(tableData.BodyStyles, bodyStyles)
+	}
+
+	var footer []string
+	if attrs, err := portabledoc.ParseTableInjectorAttrs(node.Attrs); err == nil && attrs.HasCalculations() {
+		tableData, footer = c.values.applyTableCalculations(tableData, attrs, lang)
+	}
+
+	total := c.contentWidth()
+	widths := make([]int, len(tableData.Columns))
+	for i, col := range tableData.Columns {
+		widths[i] = docxColumnWidth(col.Width, total)
+	}
+	widths = c.gridWidths(widths)
+
+	headerFill := docxColor(c.tokens.TableHeaderFillDefault, "F5F5F5")
+	rows := make([][]docxCell, 0, len(tableData.Rows)+2)
+	header := make([]docxCell, len(tableData.Columns))
+	for i, col := range tableData.Columns {
+		header[i] = c.styledCell(c.values.getColumnLabel(col, lang), headerStyles, headerFill, false)
+	}
+	rows = append(rows, header)
+	for _, row := range tableData.Rows {
+		cells := make([]docxCell, 0, len(row.Cells))
+		for i, cell := range row.Cells {
+			if cell.Value == nil && cell.Colspan == 0 && cell.Rowspan == 0 {
+				continue
+			}
+			text := c.values.formatCellValue(cell.Value, c.values.getColumnFormat(tableData.Columns, i))
+			dc := c.styledCell(text, bodyStyles, "", false)
+			dc.colspan, dc.rowspan = cell.Colspan, cell.Rowspan
+			cells = append(cells, dc)
+		}
+		rows = append(rows, cells)
+	}
+	if len(footer) > 0 {
+		cells := make([]docxCell, len(footer))
+		for i, text := range footer {
+			cells[i] = c.styledCell(text, bodyStyles, "", true)
+		}
+		rows = append(rows, cells)
+	}
+	return c.tableXML(widths, rows, 1)
+}
+
+// styledCell returns a table injector cell with styles applied to its text.
+func (c *DocxConverter) styledCell(text string, styles *entity.TableStyles, fill string, bold bool) docxCell {
+	var para docxParaProps
+	run := docxRunProps{bold: bold}
+	if styles != nil {
+		applyTextStyles(&para, &run, styles.FontFamily, styles.FontSize, styles.FontWeight, styles.TextColor, styles.TextAlign, c.fontScale)
+		if styles.Background != nil {
+			fill = docxColor(*styles.Background, fill)
+		}
+	}
+	para.spacing = `<w:spacing w:after="0"/>`
+	return docxCell{content: "<w:p>" + para.xml() + docxRun(text, run) + "</w:p>", fill: fill}
+}
+
+// docxColumnWidth converts the width of a table injector column to twips, or 0 for an even share.
+func docxColumnWidth(width *string, total int) int {
+	if width == nil {
+		return 0
+	}
+	w := strings.TrimSpace(*width)
+	if n, err := strconv.ParseFloat(strings.TrimSuffix(w, "%"), 64); strings.HasSuffix(w, "%") && err == nil && n > 0 {
+		return int(n * float64(total) / 100)
+	}
+	if n, err := strconv.ParseFloat(strings.TrimSuffix(w, "px"), 64); strings.HasSuffix(w, "px") && err == nil && n > 0 {
+		return int(n * pxToTwip)
+	}
+	return 0
+}
+
+// table writes a user-created editable table. Header styles apply to the first row, which
+// repeats on each page.
+func (c *DocxConverter) table(node portabledoc.Node) string {
+	headerStyles := c.values.parseTableStylesFromAttrs(node.Attrs, "header")
+	bodyStyles := c.values.parseTableStylesFromAttrs(node.Attrs, "body")
+
+	var widths []int
+	var rows [][]docxCell
+	headerFill := docxColor(c.tokens.TableHeaderFillDefault, "F5F5F5")
+	for _, row := range node.Content {
+		if row.Type != portabledoc.NodeTypeTableRow {
+			continue
+		}
+		styles := bodyStyles
+		if len(rows) == 0 {
+			styles = headerStyles
+		}
+		cells := make([]docxCell, 0, len(row.Content))
+		for _, cell := range row.Content {
+			ctx := &docxBlock{}
+			fill := ""
+			if cell.Type == portabledoc.NodeTypeTableHeader {
+				ctx.run.bold = true
+				fill = headerFill
+			}
+			if styles != nil {
+				applyTextStyles(&ctx.para, &ctx.run, styles.FontFamily, styles.FontSize, styles.FontWeight, styles.TextColor, styles.TextAlign, c.fontScale)
+				if styles.Background != nil {
+					fill = docxColor(*styles.Background, fill)
+				}
+			}
+			ctx.para.spacing = `<w:spacing w:after="0"/>`
+			colspan := getIntAttr(cell.Attrs, "colspan", 1)
+			if len(rows) == 0 {
+				colWidths, ok := parseCellColwidthAttr(cell.Attrs["colwidth"])
+				for i := range colspan {
+					w := 0
+					if ok && i < len(colWidths) {
+						w = int(colWidths[i] * pxToTwip)
+					}
+					widths = append(widths, w)
+				}
+			}
+			cells = append(cells, docxCell{
+				content: c.blocks(cell.Content, ctx),
+				colspan: colspan,
+				rowspan: getIntAttr(cell.Attrs, "rowspan", 1),
+				fill:    fill,
+			})
+		}
+		rows = append(rows, cells)
+	}
+	if len(widths) == 0 {
+		return ""
+	}
+	return c.tableXML(c.gridWidths(widths), rows, 1)
+}
+
+// --- Other blocks ---
+
+// externalPDF links to an external PDF, whose pages the document cannot embed.
+func (c *DocxConverter) externalPDF(node portabledoc.Node, ctx *docxBlock) string {
+	c.omit(node.Type, "external PDF pages are not embedded in DOCX; a link to the PDF is added instead")
+	attrs, err := portabledoc.ParseExternalPDFAttrs(node.Attrs)
+	if err != nil {
+		return ""
+	}
+	url := c.values.resolveSource(node.Attrs)
+	if docxLinkTarget(url) == "" {
+		return ""
+	}
+	label := strings.TrimSpace(attrs.Label)
+	if label == "" {
+		label = sourceLabel(url)
+	}
+	props := ctx.run
+	props.style = "Hyperlink"
+	link := fmt.Sprintf(`<w:hyperlink r:id="%s" w:history="1">%s</w:hyperlink>`,
+		c.part.addRel(docxRelHyperlink, url, true), docxRun(label, props))
+	return c.paragraphXML(ctx, ctx.para, link)
+}
+
+// referencesTable writes the numbered table of the links of the document. Nothing is written
+// when the document has no links.
+func (c *DocxConverter) referencesTable(attrs portabledoc.ReferencesAttrs) string {
+	if len(c.links) == 0 {
+		return ""
+	}
+	labels, ok := referenceColumnLabels[c.values.fallbackLang()]
+	if !ok {
+		labels = referenceColumnLabels[portabledoc.LanguageEnglish]
+	}
+
+	var sb strings.Builder
+	if title := strings.TrimSpace(attrs.Title); title != "" {
+		sb.WriteString("<w:p>" + docxRun(title, docxRunProps{bold: true}) + "</w:p>")
+	}
+	cellPara := func(runs string) docxCell {
+		return docxCell{content: `<w:p><w:pPr><w:spacing w:after="0"/></w:pPr>` + runs + "</w:p>"}
+	}
+	header := []docxCell{
+		cellPara(docxRun(labels[0], docxRunProps{bold: true})),
+		cellPara(docxRun(labels[1], docxRunProps{bold: true})),
+		cellPara(docxRun(labels[2], docxRunProps{bold: true})),
+	}
+	for i := range header {
+		header[i].fill = docxColor(c.tokens.TableHeaderFillDefault, "F5F5F5")
+	}
+	rows := [][]docxCell{header}
+	for i, link := range c.links {
+		url := docxRun(link.url, docxRunProps{})
+		if target := docxLinkTarget(link.url); target != "" {
+			url = fmt.Sprintf(`<w:hyperlink r:id="%s" w:history="1">%s</w:hyperlink>`,
+				c.part.addRel(docxRelHyperlink, target, true), docxRun(link.url, docxRunProps{style: "Hyperlink"}))
+		}
+		rows = append(rows, []docxCell{
+			cellPara(docxRun(strconv.Itoa(i+1), docxRunProps{})),
+			cellPara(docxRun(link.title, docxRunProps{})),
+			cellPara(url),
+		})
+	}
+	total := c.contentWidth()
+	sb.WriteString(c.tableXML([]int{total / 10, total * 4 / 10, total / 2}, rows, 1))
+	return sb.String()
+}
+
+// --- Header, footer and page stamp ---
+
+// surface writes the header or footer: its image beside or above its text, as in the PDF. An
+// image beside text is laid out with a borderless table.
+func (c *DocxConverter) surface(s portabledoc.DocumentSurface) string {
+	ctx := &docxBlock{run: docxRunProps{size: int(surfaceTextBaseFontPt * 2 * c.fontScale)}}
+	ctx.para.spacing = `<w:spacing w:after="0"/>`
+	text := c.blocks(s.ContentNodes(), ctx)
+
+	var image string
+	widthPx := 0.0
+	if s.HasImage() {
+		src := c.values.resolveSource(map[string]any{"src": s.SurfaceImageURL(), "injectableId": s.SurfaceImageInjectableID()})
+		lower := strings.ToLower(src)
+		if strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "data:image/") {
+			if i := c.embedImage(src); i >= 0 {
+				height := surfaceImageHeightPx
+				if s.SurfaceImageHeight() > 0 {
+					height = s.SurfaceImageHeight()
+				}
+				widthPx = height * float64(c.media[i].width) / float64(c.media[i].height)
+				if s.SurfaceImageWidth() > 0 {
+					widthPx = max(surfaceImageMinWidthPx, s.SurfaceImageWidth())
+				}
+				image = c.drawing(i, widthPx, height, "", "rect", docxRunProps{})
+			}
+		}
+	}
+	if image == "" && text == "" {
+		return ""
+	}
+
+	layout := s.SurfaceLayout()
+	if layout == portabledoc.SurfaceLayoutImageCenter || image == "" || text == "" {
+		// The image has priority with image-center; text is shown only without one, as in the PDF
+		if image == "" {
+			return text
+		}
+		para := docxParaProps{spacing: `<w:spacing w:after="0"/>`}
+		if layout == portabledoc.SurfaceLayoutImageCenter {
+			para.align = "center"
+		} else if layout == portabledoc.SurfaceLayoutImageRight {
+			para.align = "right"
+		}
+		return "<w:p>" + para.xml() + image + "</w:p>"
+	}
+
+	imageCell := docxCell{content: `<w:p><w:pPr><w:spacing w:after="0"/></w:pPr>` + image + "</w:p>"}
+	textCell := docxCell{content: text}
+	imageWidth := int((widthPx + surfaceImageGapPx) * pxToTwip)
+	widths := []int{imageWidth, max(c.contentWidth()-imageWidth, docxList*2)}
+	cells := []docxCell{imageCell, textCell}
+	if layout == portabledoc.SurfaceLayoutImageRight {
+		widths[0], widths[1] = widths[1], widths[0]
+		cells[0], cells[1] = cells[1], cells[0]
+	}
+	table := c.tableXML(widths, [][]docxCell{cells}, 0)
+	return strings.Replace(table, `<w:tblStyle w:val="TableGrid"/>`, `<w:tblStyle w:val="TableGrid"/><w:tblBorders>`+
+		`<w:top w:val="nil"/><w:left w:val="nil"/><w:bottom w:val="nil"/><w:right w:val="nil"/>`+
+		`<w:insideH w:val="nil"/><w:insideV w:val="nil"/></w:tblBorders>`, 1)
+}
+
+// pageStampParts adds the page stamp to the headers or footers of every page, with Word page fields.
+func (c *DocxConverter) pageStampParts(pkg *docxPackage, stamp *portabledoc.PageStamp) {
+	format := stamp.Format
+	if strings.TrimSpace(format) == "" {
+		format = pageStampDefaultFormat
+	}
+	props := docxRunProps{size: pageStampFontSizePt * 2, color: docxColor(c.tokens.BaseTextColor, "")}
+	runs := docxPageCounterRuns(format, props)
+
+	para := docxParaProps{spacing: `<w:spacing w:after="0"/>`}
+	top := false
+	switch stamp.Position {
+	case portabledoc.PageStampTopLeft:
+		top = true
+	case portabledoc.PageStampTopRight:
+		top, para.align = true, "right"
+	case portabledoc.PageStampBottomLeft:
+	case portabledoc.PageStampBottomCenter:
+		para.align = "center"
+	default:
+		para.align = "right"
+	}
+	paragraph := "<w:p>" + para.xml() + runs + "</w:p>"
+
+	if top {
+		if pkg.firstHeader != nil {
+			pkg.firstHeader.content += paragraph
+		} else {
+			pkg.firstHeader = &docxPart{name: "header1.xml", kind: docxHeader, content: paragraph}
+		}
+		pkg.header = &docxPart{name: "header2.xml", kind: docxHeader, content: paragraph}
+		return
+	}
+	pkg.firstFooter = &docxPart{name: "footer1.xml", kind: docxFooter, content: paragraph}
+	pkg.footer = &docxPart{name: "footer2.xml", kind: docxFooter, content: paragraph}
+}
+
+// docxPageCounterRuns returns the runs of a page stamp format, with the {{page}} and {{total}}
+// placeholders replaced by Word fields.
+func docxPageCounterRuns(format string, props docxRunProps) string {
+	var sb strings.Builder
+	for format != "" {
+		start := strings.Index(format, "{{")
+		end := -1
+		if start >= 0 {
+			end = strings.Index(format[start:], "}}")
+		}
+		if start < 0 || end < 0 {
+			sb.WriteString(docxRun(format, props))
+			break
+		}
+		end += start
+
+		sb.WriteString(docxRun(format[:start], props))
+		switch strings.TrimSpace(format[start+2 : end]) {
+		case "page":
+			sb.WriteString(docxField("PAGE", props))
+		case "total":
+			sb.WriteString(docxField("NUMPAGES", props))
+		default:
+			sb.WriteString(docxRun(format[start:end+2], props))
+		}
+		format = format[end+2:]
+	}
+	return sb.String()
+}
+
+// sectionProperties returns the page size, margins and header and footer references of the document.
+func (c *DocxConverter) sectionProperties(page *portabledoc.PageConfig, pkg *docxPackage) string {
+	width, height := page.Width, page.Height
+	if width <= 0 || height <= 0 {
+		a4 := portabledoc.StandardPageSizes[portabledoc.PageFormatA4]
+		width, height = a4.Width, a4.Height
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<w:sectPr>")
+	for _, ref := range []struct {
+		part *docxPart
+		tag  string
+		typ  string
+	}{
+		{pkg.header, "headerReference", "default"},
+		{pkg.firstHeader, "headerReference", "first"},
+		{pkg.footer, "footerReference", "default"},
+		{pkg.firstFooter, "footerReference", "first"},
+	} {
+		if ref.part != nil {
+			fmt.Fprintf(&sb, `<w:%s w:type="%s" r:id="%s"/>`, ref.tag, ref.typ, pkg.document.addRel(ref.part.relType(), ref.part.name, false))
+		}
+	}
+	orient := ""
+	if width > height {
+		orient = ` w:orient="landscape"`
+	}
+	fmt.Fprintf(&sb, `<w:pgSz w:w="%d" w:h="%d"%s/>`, int(width*pxToTwip), int(height*pxToTwip), orient)
+	fmt.Fprintf(&sb, `<w:pgMar w:top="%d" w:right="%d" w:bottom="%d" w:left="%d" w:header="%d" w:footer="%d" w:gutter="0"/>`,
+		int(page.Margins.Top*pxToTwip), int(page.Margins.Right*pxToTwip), int(page.Margins.Bottom*pxToTwip), int(page.Margins.Left*pxToTwip),
+		int(page.Margins.Top*pxToTwip/2), int(page.Margins.Bottom*pxToTwip/2))
+	if pkg.firstHeader != nil || pkg.firstFooter != nil {
+		// The header opens the first page only, as in the PDF
+		sb.WriteString("<w:titlePg/>")
+	}
+	sb.WriteString("</w:sectPr>")
+	return sb.String()
+}
+
+// --- Runs and paragraphs ---
+
+// docxParaProps are the properties of a paragraph.
+type docxParaProps struct {
+	style   string
+	numID   int // list numbering instance; 0 for none
+	level   int
+	border  string // contents of w:pBdr
+	spacing string // w:spacing element
+	indent  int    // left indent in twips
+	align   string
+}
+
+// xml returns the w:pPr element, with its children in schema order.
+func (p docxParaProps) xml() string {
+	var sb strings.Builder
+	if p.style != "" {
+		fmt.Fprintf(&sb, `<w:pStyle w:val="%s"/>`, p.style)
+	}
+	if p.numID > 0 {
+		fmt.Fprintf(&sb, `<w:numPr><w:ilvl w:val="%d"/><w:numId w:val="%d"/></w:numPr>`, p.level, p.numID)
+	}
+	if p.border != "" {
+		sb.WriteString("<w:pBdr>" + p.border + "</w:pBdr>")
+	}
+	sb.WriteString(p.spacing)
+	if p.indent > 0 {
+		fmt.Fprintf(&sb, `<w:ind w:left="%d"/>`, p.indent)
+	}
+	if p.align != "" {
+		fmt.Fprintf(&sb, `<w:jc w:val="%s"/>`, p.align)
+	}
+	if sb.Len() == 0 {
+		return ""
+	}
+	return "<w:pPr>" + sb.String() + "</w:pPr>"
+}
+
+// docxRunProps are the properties of a run of text.
+type docxRunProps struct {
+	style       string
+	font        string
+	bold        bool
+	italic      bool
+	strike      bool
+	color       string // hex without #
+	size        int    // half-points
+	underline   bool
+	shading     string // background hex without #
+	superscript bool
+}
+
+// xml returns the w:rPr element, with its children in schema order.
+func (p docxRunProps) xml() string {
+	var sb strings.Builder
+	if p.style != "" {
+		fmt.Fprintf(&sb, `<w:rStyle w:val="%s"/>`, p.style)
+	}
+	if p.font != "" {
+		f := docxEscape(p.font)
+		fmt.Fprintf(&sb, `<w:rFonts w:ascii="%s" w:hAnsi="%s" w:cs="%s"/>`, f, f, f)
+	}
+	if p.bold {
+		sb.WriteString("<w:b/>")
+	}
+	if p.italic {
+		sb.WriteString("<w:i/>")
+	}
+	if p.strike {
+		sb.WriteString("<w:strike/>")
+	}
+	if p.color != "" {
+		fmt.Fprintf(&sb, `<w:color w:val="%s"/>`, p.color)
+	}
+	if p.size > 0 {
+		fmt.Fprintf(&sb, `<w:sz w:val="%d"/><w:szCs w:val="%d"/>`, p.size, p.size)
+	}
+	if p.underline {
+		sb.WriteString(`<w:u w:val="single"/>`)
+	}
+	if p.shading != "" {
+		fmt.Fprintf(&sb, `<w:shd w:val="clear" w:color="auto" w:fill="%s"/>`, p.shading)
+	}
+	if p.superscript {
+		sb.WriteString(`<w:vertAlign w:val="superscript"/>`)
+	}
+	if sb.Len() == 0 {
+		return ""
+	}
+	return "<w:rPr>" + sb.String() + "</w:rPr>"
+}
+
+// docxRun returns a run of text, or "" for empty text. Tabs and newlines are kept as Word tabs
+// and line breaks.
+func docxRun(text string, props docxRunProps) string {
+	if text == "" {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("<w:r>" + props.xml())
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			sb.WriteString("<w:br/>")
+		}
+		for j, part := range strings.Split(line, "\t") {
+			if j > 0 {
+				sb.WriteString("<w:tab/>")
+			}
+			if part != "" {
+				sb.WriteString(`<w:t xml:space="preserve">` + docxEscape(part) + "</w:t>")
+			}
+		}
+	}
+	sb.WriteString("</w:r>")
+	return sb.String()
+}
+
+// docxField returns a simple field, such as PAGE, whose value Word computes when it lays the
+// document out.
+func docxField(instr string, props docxRunProps) string {
+	return fmt.Sprintf(`<w:fldSimple w:instr=" %s "><w:r>%s<w:t>1</w:t></w:r></w:fldSimple>`, instr, props.xml())
+}
+
+// docxEscape escapes text for XML content and attributes, dropping the control characters XML
+// cannot hold.
+func docxEscape(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '&':
+			sb.WriteString("&amp;")
+		case r == '<':
+			sb.WriteString("&lt;")
+		case r == '>':
+			sb.WriteString("&gt;")
+		case r == '"':
+			sb.WriteString("&quot;")
+		case r == '\'':
+			sb.WriteString("&apos;")
+		case r < ' ' && r != '\t' && r != '\n' && r != '\r', r == 0xFFFE, r == 0xFFFF:
+			continue
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// docxAlign returns the Word justification of a textAlign value, or "" for the default.
+func docxAlign(align string) string {
+	switch align {
+	case "center", "right":
+		return align
+	case "justify":
+		return "both"
+	default:
+		return ""
+	}
+}
+
+// docxNamedColors are the hex values of the named colors Typst knows, so Word shows the colors
+// the PDF does.
+var docxNamedColors = map[string]string{
+	"aqua": "7FDBFF", "black": "000000", "blue": "0074D9", "fuchsia": "F012BE", "gray": "AAAAAA",
+	"green": "2ECC40", "grey": "AAAAAA", "lime": "01FF70", "maroon": "85144B", "navy": "001F3F",
+	"olive": "3D9970", "orange": "FF851B", "purple": "B10DC9", "red": "FF4136", "silver": "DDDDDD",
+	"teal": "39CCCC", "white": "FFFFFF", "yellow": "FFDC00",
+}
+
+// docxColor converts a color from a document or the design tokens to the hex Word uses. Hex,
+// rgb(), rgba(), the named colors Typst knows and luma() are accepted; anything else gives
+// fallback. Alpha is dropped.
+func docxColor(raw, fallback string) string {
+	color := strings.ToLower(strings.TrimSpace(raw))
+	if isHexColor(color) {
+		hex := color[1:]
+		if len(hex) <= 4 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		return strings.ToUpper(hex[:6])
+	}
+	if hex, ok := docxNamedColors[color]; ok {
+		return hex
+	}
+	var inner string
+	switch {
+	case strings.HasPrefix(color, "rgb(") && strings.HasSuffix(color, ")"):
+		inner = color[4 : len(color)-1]
+	case strings.HasPrefix(color, "rgba(") && strings.HasSuffix(color, ")"):
+		inner = color[5 : len(color)-1]
+	case strings.HasPrefix(color, "luma(") && strings.HasSuffix(color, ")"):
+		if channel, ok := parseCSSColorChannel(color[5 : len(color)-1]); ok {
+			return fmt.Sprintf("%02X%02X%02X", channel, channel, channel)
+		}
+		return fallback
+	default:
+		return fallback
+	}
+	parts := splitCSSColorParts(inner)
+	if len(parts) < 3 {
+		return fallback
+	}
+	var channels [3]int
+	for i := range channels {
+		channel, ok := parseCSSColorChannel(parts[i])
+		if !ok {
+			return fallback
+		}
+		channels[i] = channel
+	}
+	return fmt.Sprintf("%02X%02X%02X", channels[0], channels[1], channels[2])
+}
+
+// parsePt returns the points of a size token such as "12pt", or fallback.
+func parsePt(size string, fallback float64) float64 {
+	if n, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(size), "pt"), 64); err == nil && n > 0 {
+		return n
+	}
+	return fallback
+}
+
+// parseEm returns the ems of a spacing token such as "0.65em", or 0.
+func parseEm(size string) float64 {
+	n, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(size), "em"), 64)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// parseInset returns the horizontal and vertical points of a Typst inset token ("6pt" or
+// "(x: 6pt, y: 12pt)").
+func parseInset(inset string) (x, y float64) {
+	inset = strings.TrimSpace(inset)
+	if !strings.HasPrefix(inset, "(") {
+		v := parsePt(inset, 0)
+		return v, v
+	}
+	for part := range strings.SplitSeq(strings.Trim(inset, "()"), ",") {
+		key, value, ok := strings.Cut(part, ":")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "x":
+			x = parsePt(value, 0)
+		case "y":
+			y = parsePt(value, 0)
+		}
+	}
+	return x, y
+}
@@ -0,0 +1,200 @@
+package pdfrenderer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+// buildDocx converts nodes and returns the files of the package by name.
+func buildDocx(t *testing.T, injectables map[string]any, nodes ...portabledoc.Node) (map[string]string, *DocxConverter) {
+	t.Helper()
+	if injectables == nil {
+		injectables = map[string]any{}
+	}
+	c := NewDocxConverter(injectables, map[string]string{}, DefaultDesignTokens())
+	data, err := c.Build(htmlDoc(nodes...))
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("output is not a zip: %v", err)
+	}
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("read %s: %v", f.Name, err)
+		}
+		files[f.Name] = string(content)
+	}
+	return files, c
+}
+
+// assertWellFormed fails when an XML part does not parse.
+func assertWellFormed(t *testing.T, name, content string) {
+	t.Helper()
+	dec := xml.NewDecoder(strings.NewReader(content))
+	for {
+		_, err := dec.Token()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			t.Fatalf("%s is not well-formed: %v\n%s", name, err, content)
+		}
+	}
+}
+
+func TestDocxConverter_PackageParts(t *testing.T) {
+	files, _ := buildDocx(t, nil, paragraphNode(textNode("Hello")))
+
+	for _, name := range []string{
+		"[Content_Types].xml", "_rels/.rels", "docProps/core.xml",
+		"word/document.xml", "word/_rels/document.xml.rels", "word/styles.xml", "word/numbering.xml",
+	} {
+		content, ok := files[name]
+		if !ok {
+			t.Errorf("missing part %s", name)
+			continue
+		}
+		assertWellFormed(t, name, content)
+	}
+	if !strings.Contains(files["docProps/core.xml"], "Offer &lt;draft&gt;") {
+		t.Errorf("expected escaped title, got:\n%s", files["docProps/core.xml"])
+	}
+	if !strings.Contains(files["word/document.xml"], ">Hello</w:t>") {
+		t.Errorf("expected paragraph text, got:\n%s", files["word/document.xml"])
+	}
+}
+
+func TestDocxConverter_EscapesTextAndValues(t *testing.T) {
+	injector := portabledoc.Node{Type: portabledoc.NodeTypeInjector, Attrs: map[string]any{"variableId": "client"}}
+	files, _ := buildDocx(t, map[string]any{"client": "<b>Acme</b>\x01"},
+		paragraphNode(textNode("a < b & c"), injector))
+
+	doc := files["word/document.xml"]
+	assertWellFormed(t, "word/document.xml", doc)
+	if !strings.Contains(doc, "a &lt; b &amp; c") || !strings.Contains(doc, "&lt;b&gt;Acme&lt;/b&gt;") {
+		t.Errorf("expected escaped text and value, got:\n%s", doc)
+	}
+}
+
+func TestDocxConverter_IncludesPDFOnlyNodes(t *testing.T) {
+	pdfOnly := paragraphNode(textNode("print only"))
+	pdfOnly.Attrs = map[string]any{"visibility": portabledoc.OutputPDF}
+	htmlOnly := paragraphNode(textNode("screen only"))
+	htmlOnly.Attrs = map[string]any{"visibility": portabledoc.OutputHTML}
+
+	files, _ := buildDocx(t, nil, pdfOnly, htmlOnly)
+
+	doc := files["word/document.xml"]
+	if !strings.Contains(doc, "print only") {
+		t.Errorf("pdf-only node should be included, got:\n%s", doc)
+	}
+	if strings.Contains(doc, "screen only") {
+		t.Errorf("html-only node should be omitted, got:\n%s", doc)
+	}
+}
+
+func TestDocxConverter_Lists(t *testing.T) {
+	list := portabledoc.Node{
+		Type:  portabledoc.NodeTypeOrderedList,
+		Attrs: map[string]any{"start": float64(3)},
+		Content: []portabledoc.Node{
+			{Type: portabledoc.NodeTypeListItem, Content: []portabledoc.Node{paragraphNode(textNode("first"))}},
+			{Type: portabledoc.NodeTypeListItem, Content: []portabledoc.Node{paragraphNode(textNode("second"))}},
+		},
+	}
+	files, _ := buildDocx(t, nil, list)
+
+	doc := files["word/document.xml"]
+	if got := strings.Count(doc, `<w:numId w:val="1"/>`); got != 2 {
+		t.Errorf("expected 2 numbered paragraphs, got %d:\n%s", got, doc)
+	}
+	numbering := files["word/numbering.xml"]
+	for _, want := range []string{`<w:numFmt w:val="decimal"/>`, `<w:startOverride w:val="3"/>`} {
+		if !strings.Contains(numbering, want) {
+			t.Errorf("expected %q in numbering:\n%s", want, numbering)
+		}
+	}
+}
+
+func TestDocxConverter_Hyperlinks(t *testing.T) {
+	files, _ := buildDocx(t, nil, paragraphNode(
+		markedTextNode("bad", mark(portabledoc.MarkTypeLink, map[string]any{"href": "javascript:alert(1)"})),
+		markedTextNode("good", mark(portabledoc.MarkTypeLink, map[string]any{"href": "https://example.com/a?b=1&c=2"})),
+	))
+
+	doc := files["word/document.xml"]
+	if got := strings.Count(doc, "<w:hyperlink "); got != 1 {
+		t.Errorf("expected only the safe link, got %d hyperlinks:\n%s", got, doc)
+	}
+	rels := files["word/_rels/document.xml.rels"]
+	if !strings.Contains(rels, `Target="https://example.com/a?b=1&amp;c=2" TargetMode="External"`) {
+		t.Errorf("expected external link relationship, got:\n%s", rels)
+	}
+	if strings.Contains(rels, "javascript") {
+		t.Errorf("unsafe link must not be kept, got:\n%s", rels)
+	}
+}
+
+func TestDocxConverter_PageNumberIsField(t *testing.T) {
+	pageNumber := portabledoc.Node{Type: portabledoc.NodeTypePageNumber, Attrs: map[string]any{"display": portabledoc.PageNumberCurrent}}
+	files, c := buildDocx(t, nil, paragraphNode(pageNumber))
+
+	if !strings.Contains(files["word/document.xml"], `w:instr=" PAGE "`) {
+		t.Errorf("expected PAGE field, got:\n%s", files["word/document.xml"])
+	}
+	if len(c.Warnings()) != 0 {
+		t.Errorf("page numbers should not warn, got %v", c.Warnings())
+	}
+}
+
+func TestDocxConverter_OmittedNodesWarnOnce(t *testing.T) {
+	c := NewDocxConverter(map[string]any{}, map[string]string{}, DefaultDesignTokens())
+	c.SetWatermark("PREVIEW")
+	if _, err := c.Build(htmlDoc(paragraphNode(textNode("x")))); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	c.omit("watermark", "again")
+
+	warnings := c.Warnings()
+	if len(warnings) != 1 || warnings[0].Code != entity.RenderWarningDocxOmitted {
+		t.Errorf("expected one %s warning, got %v", entity.RenderWarningDocxOmitted, warnings)
+	}
+}
+
+func TestDocxColor(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"#abc", "AABBCC"},
+		{"#1a2B3cff", "1A2B3C"},
+		{"rgb(255, 0, 16)", "FF0010"},
+		{"rgba(0, 128, 255, 0.5)", "0080FF"},
+		{"luma(50%)", "808080"},
+		{"red", "FF4136"},
+		{"var(--brand)", "000000"},
+		{"", "000000"},
+	}
+	for _, tt := range tests {
+		if got := docxColor(tt.raw, "000000"); got != tt.want {
+			t.Errorf("docxColor(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
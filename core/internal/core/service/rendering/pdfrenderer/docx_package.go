@@ -0,0 +1,377 @@
+package pdfrenderer
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OOXML namespaces and relationship types.
+const (
+	nsWordML      = "http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+	nsRelations   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+	nsWPDrawing   = "http://schemas.openxmlformats.org/drawingml/2006/wordprocessingDrawing"
+	nsDrawingML   = "http://schemas.openxmlformats.org/drawingml/2006/main"
+	nsPicture     = "http://schemas.openxmlformats.org/drawingml/2006/picture"
+	nsPackageRels = "http://schemas.openxmlformats.org/package/2006/relationships"
+
+	docxRelDocument  = nsRelations + "/officeDocument"
+	docxRelStyles    = nsRelations + "/styles"
+	docxRelNumbering = nsRelations + "/numbering"
+	docxRelHeader    = nsRelations + "/header"
+	docxRelFooter    = nsRelations + "/footer"
+	docxRelImage     = nsRelations + "/image"
+	docxRelHyperlink = nsRelations + "/hyperlink"
+	docxRelCore      = "http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties"
+
+	docxContentTypeMain = "application/vnd.openxmlformats-officedocument.wordprocessingml"
+)
+
+// Kinds of document parts.
+const (
+	docxBody = iota
+	docxHeader
+	docxFooter
+)
+
+// docxPart is a part of the package holding content: the document body, a header or a footer.
+type docxPart struct {
+	name    string // filename under word/
+	kind    int
+	content string
+	rels    []docxRel
+}
+
+// docxRel is a relationship from a part to another part or an external link.
+type docxRel struct {
+	id       string
+	typ      string
+	target   string
+	external bool
+}
+
+// addRel adds a relationship to the part and returns its ID.
+func (p *docxPart) addRel(typ, target string, external bool) string {
+	id := fmt.Sprintf("rId%d", len(p.rels)+1)
+	p.rels = append(p.rels, docxRel{id: id, typ: typ, target: target, external: external})
+	return id
+}
+
+// relType returns the type of the relationship from the document to the part.
+func (p *docxPart) relType() string {
+	if p.kind == docxFooter {
+		return docxRelFooter
+	}
+	return docxRelHeader
+}
+
+// xml returns the part with its root element.
+func (p *docxPart) xml(sectPr string) string {
+	root := "w:document"
+	switch p.kind {
+	case docxHeader:
+		root = "w:hdr"
+	case docxFooter:
+		root = "w:ftr"
+	}
+	content := p.content
+	if p.kind != docxBody && !strings.HasSuffix(content, "</w:p>") {
+		// Headers and footers must end with a paragraph
+		content += "<w:p/>"
+	}
+	if p.kind == docxBody {
+		content = "<w:body>" + content + sectPr + "</w:body>"
+	}
+	return fmt.Sprintf(`%s<%s xmlns:w="%s" xmlns:r="%s" xmlns:wp="%s">%s</%s>`,
+		xmlHeader, root, nsWordML, nsRelations, nsWPDrawing, content, root)
+}
+
+const xmlHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+
+// relsXML returns the relationships part of rels.
+func relsXML(rels []docxRel) string {
+	var sb strings.Builder
+	sb.WriteString(xmlHeader)
+	fmt.Fprintf(&sb, `<Relationships xmlns="%s">`, nsPackageRels)
+	for _, r := range rels {
+		mode := ""
+		if r.external {
+			mode = ` TargetMode="External"`
+		}
+		fmt.Fprintf(&sb, `<Relationship Id="%s" Type="%s" Target="%s"%s/>`, r.id, r.typ, docxEscape(r.target), mode)
+	}
+	sb.WriteString("</Relationships>")
+	return sb.String()
+}
+
+// docxPackage is a Word document being written.
+type docxPackage struct {
+	title       string
+	lang        string
+	document    *docxPart
+	header      *docxPart // every page but the first
+	firstHeader *docxPart
+	footer      *docxPart // every page but the first
+	firstFooter *docxPart
+	media       []docxMedia
+	numbering   *docxNumbering
+}
+
+// docxFile is a file of the zipped package.
+type docxFile struct {
+	name    string
+	content []byte
+}
+
+// write zips the package. sectPr closes the document body.
+func (p *docxPackage) write(sectPr, styles string) ([]byte, error) {
+	p.document.addRel(docxRelStyles, "styles.xml", false)
+	p.document.addRel(docxRelNumbering, "numbering.xml", false)
+
+	files := []docxFile{
+		{"[Content_Types].xml", []byte(p.contentTypes())},
+		{"_rels/.rels", []byte(relsXML([]docxRel{
+			{id: "rId1", typ: docxRelDocument, target: "word/document.xml"},
+			{id: "rId2", typ: docxRelCore, target: "docProps/core.xml"},
+		}))},
+		{"docProps/core.xml", []byte(p.coreProperties())},
+		{"word/document.xml", []byte(p.document.xml(sectPr))},
+		{"word/_rels/document.xml.rels", []byte(relsXML(p.document.rels))},
+		{"word/styles.xml", []byte(styles)},
+		{"word/numbering.xml", []byte(p.numbering.xml())},
+	}
+	for _, part := range p.parts() {
+		files = append(files, docxFile{"word/" + part.name, []byte(part.xml(""))})
+		if len(part.rels) > 0 {
+			files = append(files, docxFile{"word/_rels/" + part.name + ".rels", []byte(relsXML(part.rels))})
+		}
+	}
+	for _, m := range p.media {
+		files = append(files, docxFile{"word/media/" + m.name, m.data})
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		w, err := zw.Create(f.name)
+		if err != nil {
+			return nil, fmt.Errorf("adding %s: %w", f.name, err)
+		}
+		if _, err := w.Write(f.content); err != nil {
+			return nil, fmt.Errorf("writing %s: %w", f.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("closing docx: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// parts returns the headers and footers of the package.
+func (p *docxPackage) parts() []*docxPart {
+	var parts []*docxPart
+	for _, part := range []*docxPart{p.firstHeader, p.header, p.firstFooter, p.footer} {
+		if part != nil {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+func (p *docxPackage) contentTypes() string {
+	var sb strings.Builder
+	sb.WriteString(xmlHeader)
+	sb.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	sb.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	sb.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	sb.WriteString(`<Default Extension="png" ContentType="image/png"/>`)
+	sb.WriteString(`<Default Extension="jpg" ContentType="image/jpeg"/>`)
+	sb.WriteString(`<Default Extension="gif" ContentType="image/gif"/>`)
+	fmt.Fprintf(&sb, `<Override PartName="/word/document.xml" ContentType="%s.document.main+xml"/>`, docxContentTypeMain)
+	fmt.Fprintf(&sb, `<Override PartName="/word/styles.xml" ContentType="%s.styles+xml"/>`, docxContentTypeMain)
+	fmt.Fprintf(&sb, `<Override PartName="/word/numbering.xml" ContentType="%s.numbering+xml"/>`, docxContentTypeMain)
+	for _, part := range p.parts() {
+		kind := "header"
+		if part.kind == docxFooter {
+			kind = "footer"
+		}
+		fmt.Fprintf(&sb, `<Override PartName="/word/%s" ContentType="%s.%s+xml"/>`, part.name, docxContentTypeMain, kind)
+	}
+	sb.WriteString(`<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>`)
+	sb.WriteString(`</Types>`)
+	return sb.String()
+}
+
+// coreProperties returns the document title and creation date shown by Word.
+func (p *docxPackage) coreProperties() string {
+	now := time.Now().UTC().Format(time.RFC3339)
+	return xmlHeader + `<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" ` +
+		`xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/" ` +
+		`xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">` +
+		"<dc:title>" + docxEscape(p.title) + "</dc:title>" +
+		"<dc:language>" + docxEscape(p.lang) + "</dc:language>" +
+		`<dcterms:created xsi:type="dcterms:W3CDTF">` + now + "</dcterms:created>" +
+		`<dcterms:modified xsi:type="dcterms:W3CDTF">` + now + "</dcterms:modified>" +
+		"</cp:coreProperties>"
+}
+
+// --- Styles ---
+
+// styles returns the styles part: the design tokens of the PDF as Word styles, so the document
+// can be edited with the same look.
+func (c *DocxConverter) styles() string {
+	font := "Arial"
+	if len(c.tokens.FontStack) > 0 {
+		font = docxEscape(c.tokens.FontStack[0])
+	}
+	base := parsePt(c.tokens.BaseFontSize, 12) * c.fontScale
+	color := docxColor(c.tokens.BaseTextColor, "333333")
+	heading := "<w:b/>"
+	if weight, err := strconv.Atoi(strings.TrimSpace(c.tokens.HeadingWeight)); (err == nil && weight < 600) || c.tokens.HeadingWeight == "normal" {
+		heading = ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(xmlHeader)
+	fmt.Fprintf(&sb, `<w:styles xmlns:w="%s">`, nsWordML)
+	fmt.Fprintf(&sb, `<w:docDefaults><w:rPrDefault><w:rPr><w:rFonts w:ascii="%s" w:hAnsi="%s" w:eastAsia="%s" w:cs="%s"/>`+
+		`<w:color w:val="%s"/><w:sz w:val="%d"/><w:szCs w:val="%d"/>`, font, font, font, font, color, int(base*2), int(base*2))
+	if c.values.defaultLang != "" {
+		fmt.Fprintf(&sb, `<w:lang w:val="%s"/>`, docxEscape(c.values.defaultLang))
+	}
+	fmt.Fprintf(&sb, `</w:rPr></w:rPrDefault><w:pPrDefault><w:pPr>%s</w:pPr></w:pPrDefault></w:docDefaults>`,
+		c.spacingXML(c.tokens.ParagraphLeading, c.tokens.ParagraphSpacing))
+
+	sb.WriteString(`<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/><w:qFormat/></w:style>`)
+	for i, size := range c.tokens.HeadingSizes {
+		pt := parsePt(size, base) * c.fontScale
+		fmt.Fprintf(&sb, `<w:style w:type="paragraph" w:styleId="Heading%d"><w:name w:val="heading %d"/><w:basedOn w:val="Normal"/>`+
+			`<w:next w:val="Normal"/><w:qFormat/><w:pPr><w:keepNext/><w:spacing w:before="%d" w:after="%d"/><w:outlineLvl w:val="%d"/></w:pPr>`+
+			`<w:rPr>%s<w:sz w:val="%d"/><w:szCs w:val="%d"/></w:rPr></w:style>`,
+			i+1, i+1, int(pt*0.6*ptToTwip), int(pt*0.4*ptToTwip), i, heading, int(pt*2), int(pt*2))
+	}
+	fmt.Fprintf(&sb, `<w:style w:type="paragraph" w:styleId="Quote"><w:name w:val="Quote"/><w:basedOn w:val="Normal"/><w:qFormat/>`+
+		`<w:pPr><w:pBdr><w:left w:val="single" w:sz="16" w:space="8" w:color="%s"/></w:pBdr><w:shd w:val="clear" w:color="auto" w:fill="%s"/>`+
+		`<w:ind w:left="%d" w:right="%d"/></w:pPr><w:rPr><w:i/></w:rPr></w:style>`,
+		docxColor(c.tokens.BlockquoteStrokeColor, "C8C8C8"), docxColor(c.tokens.BlockquoteFill, "F9F9F9"), docxGap, docxGap)
+	fmt.Fprintf(&sb, `<w:style w:type="paragraph" w:styleId="Code"><w:name w:val="Code"/><w:basedOn w:val="Normal"/>`+
+		`<w:pPr><w:shd w:val="clear" w:color="auto" w:fill="F5F5F5"/><w:spacing w:after="120" w:line="240" w:lineRule="auto"/></w:pPr>`+
+		`<w:rPr><w:rFonts w:ascii="%s" w:hAnsi="%s" w:cs="%s"/></w:rPr></w:style>`, docxCode, docxCode, docxCode)
+	sb.WriteString(`<w:style w:type="character" w:styleId="Hyperlink"><w:name w:val="Hyperlink"/>` +
+		`<w:rPr><w:color w:val="0563C1"/><w:u w:val="single"/></w:rPr></w:style>`)
+	stroke := docxColor(c.tokens.TableStrokeColor, "C8C8C8")
+	sb.WriteString(`<w:style w:type="table" w:default="1" w:styleId="TableNormal"><w:name w:val="Normal Table"/>` +
+		`<w:tblPr><w:tblInd w:w="0" w:type="dxa"/><w:tblCellMar><w:top w:w="0" w:type="dxa"/><w:left w:w="108" w:type="dxa"/>` +
+		`<w:bottom w:w="0" w:type="dxa"/><w:right w:w="108" w:type="dxa"/></w:tblCellMar></w:tblPr></w:style>`)
+	fmt.Fprintf(&sb, `<w:style w:type="table" w:styleId="TableGrid"><w:name w:val="Table Grid"/><w:basedOn w:val="TableNormal"/>`+
+		`<w:tblPr><w:tblBorders><w:top w:val="single" w:sz="4" w:space="0" w:color="%[1]s"/><w:left w:val="single" w:sz="4" w:space="0" w:color="%[1]s"/>`+
+		`<w:bottom w:val="single" w:sz="4" w:space="0" w:color="%[1]s"/><w:right w:val="single" w:sz="4" w:space="0" w:color="%[1]s"/>`+
+		`<w:insideH w:val="single" w:sz="4" w:space="0" w:color="%[1]s"/><w:insideV w:val="single" w:sz="4" w:space="0" w:color="%[1]s"/>`+
+		`</w:tblBorders></w:tblPr></w:style>`, stroke)
+	sb.WriteString(`</w:styles>`)
+	return sb.String()
+}
+
+// --- Numbering ---
+
+// Kinds of list numbering.
+const (
+	docxListBullet     = "bullet"
+	docxListDecimal    = "decimal"
+	docxListRoman      = "lowerRoman"
+	docxListLetter     = "lowerLetter"
+	docxListMultilevel = "multilevel"
+)
+
+// docxBullets are the default bullets of user-built lists, cycled by depth as in the PDF.
+var docxBullets = []string{"•", "◦", "▪"}
+
+// docxListFormat is the numbering of the levels of a list.
+type docxListFormat struct {
+	kind    string
+	markers []string // bullets cycled by depth; empty uses docxBullets
+}
+
+func (f docxListFormat) key() string {
+	return f.kind + "\x00" + strings.Join(f.markers, "\x00")
+}
+
+// docxNumbering collects the list formats (abstract numberings) and the lists numbered with
+// them. Each list is its own instance, so its numbering starts over.
+type docxNumbering struct {
+	formats []docxListFormat
+	index   map[string]int // format key → position in formats
+	lists   []docxNumInstance
+}
+
+// docxNumInstance is a list: its format and the number of its first item.
+type docxNumInstance struct {
+	format int
+	level  int
+	start  int
+}
+
+// add registers a list whose items are at level and returns its numbering ID.
+func (n *docxNumbering) add(format docxListFormat, level, start int) int {
+	if n.index == nil {
+		n.index = make(map[string]int)
+	}
+	i, ok := n.index[format.key()]
+	if !ok {
+		n.formats = append(n.formats, format)
+		i = len(n.formats) - 1
+		n.index[format.key()] = i
+	}
+	n.lists = append(n.lists, docxNumInstance{format: i, level: level, start: max(start, 0)})
+	return len(n.lists)
+}
+
+// xml returns the numbering part.
+func (n *docxNumbering) xml() string {
+	var sb strings.Builder
+	sb.WriteString(xmlHeader)
+	fmt.Fprintf(&sb, `<w:numbering xmlns:w="%s">`, nsWordML)
+	for i, f := range n.formats {
+		fmt.Fprintf(&sb, `<w:abstractNum w:abstractNumId="%d"><w:multiLevelType w:val="hybridMultilevel"/>`, i)
+		for level := range 9 {
+			numFmt, text := f.level(level)
+			fmt.Fprintf(&sb, `<w:lvl w:ilvl="%d"><w:start w:val="1"/><w:numFmt w:val="%s"/><w:lvlText w:val="%s"/>`+
+				`<w:lvlJc w:val="left"/><w:pPr><w:ind w:left="%d" w:hanging="%d"/></w:pPr></w:lvl>`,
+				level, numFmt, docxEscape(text), docxList*2*(level+1), docxList)
+		}
+		sb.WriteString(`</w:abstractNum>`)
+	}
+	for i, l := range n.lists {
+		fmt.Fprintf(&sb, `<w:num w:numId="%d"><w:abstractNumId w:val="%d"/>`, i+1, l.format)
+		fmt.Fprintf(&sb, `<w:lvlOverride w:ilvl="%d"><w:startOverride w:val="%d"/></w:lvlOverride></w:num>`, l.level, l.start)
+	}
+	sb.WriteString(`</w:numbering>`)
+	return sb.String()
+}
+
+// level returns the Word number format and level text of a level of the format.
+func (f docxListFormat) level(level int) (numFmt, text string) {
+	switch f.kind {
+	case docxListDecimal:
+		return "decimal", fmt.Sprintf("%%%d.", level+1)
+	case docxListRoman:
+		return "lowerRoman", fmt.Sprintf("%%%d.", level+1)
+	case docxListLetter:
+		return "lowerLetter", fmt.Sprintf("%%%d)", level+1)
+	case docxListMultilevel:
+		var sb strings.Builder
+		for l := range level + 1 {
+			fmt.Fprintf(&sb, "%%%d.", l+1)
+		}
+		return "decimal", sb.String()
+	default:
+		markers := f.markers
+		if len(markers) == 0 {
+			markers = docxBullets
+		}
+		return "bullet", markers[level%len(markers)]
+	}
+}
@@ -0,0 +1,125 @@
+package pdfrenderer
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+// ConverterVersion identifies the set of nodes, marks and attrs this build renders.
+// Bump it whenever the editor schema below changes.
+const ConverterVersion = "2026.10.1"
+
+// Render output formats reported in the editor schema.
+const (
+	outputFormatPDF  = "pdf"
+	outputFormatHTML = "html"
+	outputFormatDOCX = "docx"
+)
+
+var (
+	allOutputs      = []string{outputFormatPDF, outputFormatHTML, outputFormatDOCX}
+	paragraphAttrs  = []string{"textAlign", "lineSpacing"}
+	tableStyleAttrs = []string{
+		"headerFontFamily", "headerFontSize", "headerFontWeight", "headerTextColor", "headerTextAlign", "headerBackground",
+		"bodyFontFamily", "bodyFontSize", "bodyFontWeight", "bodyTextColor", "bodyTextAlign", "bodyBackground",
+	}
+	imageAttrs = []string{"src", "injectableId", "width", "height", "align", "shape", "displayMode"}
+)
+
+// editorNodes lists the attrs the converters read per node type and the outputs that render it.
+// Nodes missing from an output are omitted with a warning (pageNumber in HTML) or degraded to a
+// link (externalPdf in HTML and DOCX).
+var editorNodes = []portabledoc.NodeSchema{
+	{Type: portabledoc.NodeTypeParagraph, Attrs: paragraphAttrs},
+	{Type: portabledoc.NodeTypeHeading, Attrs: append([]string{"level"}, paragraphAttrs...)},
+	{Type: portabledoc.NodeTypeBlockquote},
+	{Type: portabledoc.NodeTypeCodeBlock, Attrs: []string{"language"}},
+	{Type: portabledoc.NodeTypeHR},
+	{Type: portabledoc.NodeTypeBulletList},
+	{Type: portabledoc.NodeTypeOrderedList, Attrs: []string{"start"}},
+	{Type: portabledoc.NodeTypeTaskList},
+	{Type: portabledoc.NodeTypeListItem},
+	{Type: portabledoc.NodeTypeTaskItem, Attrs: []string{"checked"}},
+	{Type: portabledoc.NodeTypeInjector, Attrs: []string{
+		"type", "label", "variableId", "format", "prefix", "suffix", "showLabelIfEmpty", "defaultValue", "width",
+	}},
+	{Type: portabledoc.NodeTypeConditional, Attrs: []string{"conditions", "expression"}},
+	{Type: portabledoc.NodeTypeRepeater, Attrs: []string{"variableId", "alias"}},
+	{Type: portabledoc.NodeTypePageBreak},
+	{Type: portabledoc.NodeTypeImage, Attrs: imageAttrs},
+	{Type: portabledoc.NodeTypeCustomImage, Attrs: imageAttrs},
+	{Type: portabledoc.NodeTypeText},
+	{Type: portabledoc.NodeTypeHardBreak},
+	{Type: portabledoc.NodeTypeListInjector, Attrs: []string{
+		"variableId", "lang", "label", "symbol", "start", "continueNumbering",
+	}},
+	{Type: portabledoc.NodeTypeTableInjector, Attrs: append([]string{
+		"variableId", "lang", "calculatedColumns", "aggregates", "footerLabel",
+	}, tableStyleAttrs...)},
+	{Type: portabledoc.NodeTypeTable, Attrs: tableStyleAttrs},
+	{Type: portabledoc.NodeTypeTableRow},
+	{Type: portabledoc.NodeTypeTableCell, Attrs: []string{"colspan", "rowspan", "colwidth"}},
+	{Type: portabledoc.NodeTypeTableHeader, Attrs: []string{"colspan", "rowspan", "colwidth"}},
+	{Type: portabledoc.NodeTypePageNumber, Attrs: []string{"display"}, Outputs: []string{outputFormatPDF, outputFormatDOCX}},
+	{Type: portabledoc.NodeTypeSecurityPattern, Attrs: []string{"variant", "text", "color", "height"}, Outputs: []string{outputFormatPDF}},
+	{Type: portabledoc.NodeTypeExternalPDF, Attrs: []string{"src", "injectableId", "mode", "pages", "label"}, Outputs: []string{outputFormatPDF}},
+	{Type: portabledoc.NodeTypeReferences, Attrs: []string{"title", "markers"}},
+}
+
+var editorMarks = []portabledoc.MarkSchema{
+	{Type: portabledoc.MarkTypeBold},
+	{Type: portabledoc.MarkTypeItalic},
+	{Type: portabledoc.MarkTypeStrike},
+	{Type: portabledoc.MarkTypeCode},
+	{Type: portabledoc.MarkTypeUnderline},
+	{Type: portabledoc.MarkTypeHighlight, Attrs: []string{"color"}},
+	{Type: portabledoc.MarkTypeLink, Attrs: []string{"href"}},
+	{Type: portabledoc.MarkTypeTextStyle, Attrs: []string{"color", "fontSize", "fontFamily"}},
+}
+
+var editorInjectableTypes = []entity.InjectableDataType{
+	entity.InjectableDataTypeText,
+	entity.InjectableDataTypeNumber,
+	entity.InjectableDataTypeDate,
+	entity.InjectableDataTypeCurrency,
+	entity.InjectableDataTypeBoolean,
+	entity.InjectableDataTypeImage,
+	entity.InjectableDataTypeTable,
+	entity.InjectableDataTypeList,
+}
+
+// EditorSchema reports the nodes, marks, attrs and injectable value types this build renders.
+// Block nodes that take pagination hints list them with their own attrs.
+func (s *Service) EditorSchema() portabledoc.EditorSchema {
+	schema := portabledoc.EditorSchema{
+		FormatVersion:    portabledoc.CurrentVersion,
+		ConverterVersion: ConverterVersion,
+		CommonAttrs:      []string{"visibility"},
+		Outputs:          slices.Clone(allOutputs),
+	}
+
+	for _, n := range editorNodes {
+		node := portabledoc.NodeSchema{Type: n.Type, Attrs: slices.Clone(n.Attrs), Outputs: slices.Clone(n.Outputs)}
+		if paginationHintNodes.Contains(n.Type) {
+			node.Attrs = append(node.Attrs, "keepTogether", "breakBefore", "breakAfter", "minOrphanLines")
+		}
+		if node.Outputs == nil {
+			node.Outputs = slices.Clone(allOutputs)
+		}
+		schema.Nodes = append(schema.Nodes, node)
+	}
+	slices.SortFunc(schema.Nodes, func(a, b portabledoc.NodeSchema) int { return cmp.Compare(a.Type, b.Type) })
+
+	for _, m := range editorMarks {
+		schema.Marks = append(schema.Marks, portabledoc.MarkSchema{Type: m.Type, Attrs: slices.Clone(m.Attrs)})
+	}
+	slices.SortFunc(schema.Marks, func(a, b portabledoc.MarkSchema) int { return cmp.Compare(a.Type, b.Type) })
+
+	for _, t := range editorInjectableTypes {
+		schema.InjectableTypes = append(schema.InjectableTypes, string(t))
+	}
+	return schema
+}
@@ -0,0 +1,45 @@
+package pdfrenderer
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+func TestEditorSchema_NodesHaveHandlers(t *testing.T) {
+	converter := &TypstConverter{}
+	schema := (&Service{}).EditorSchema()
+
+	for _, node := range schema.Nodes {
+		if converter.getNodeHandler(node.Type) == nil {
+			t.Errorf("node %q is in the editor schema but the converter has no handler for it", node.Type)
+		}
+		if !portabledoc.KnownNodeTypes.Contains(node.Type) {
+			t.Errorf("node %q is in the editor schema but not in the known node types", node.Type)
+		}
+	}
+	if len(schema.Nodes) != portabledoc.KnownNodeTypes.Len() {
+		t.Errorf("expected the editor schema to list the %d known node types, got %d", portabledoc.KnownNodeTypes.Len(), len(schema.Nodes))
+	}
+	if !slices.IsSortedFunc(schema.Nodes, func(a, b portabledoc.NodeSchema) int { return cmp.Compare(a.Type, b.Type) }) {
+		t.Error("expected nodes sorted by type")
+	}
+}
+
+func TestEditorSchema_PaginationAttrs(t *testing.T) {
+	schema := (&Service{}).EditorSchema()
+
+	for _, node := range schema.Nodes {
+		hasHints := slices.Contains(node.Attrs, "keepTogether")
+		if hasHints != paginationHintNodes.Contains(node.Type) {
+			t.Errorf("node %q: keepTogether listed = %v, want %v", node.Type, hasHints, !hasHints)
+		}
+	}
+
+	// The shared attr slices must not be extended by the appended pagination hints.
+	if len(paragraphAttrs) != 2 || len(tableStyleAttrs) != 12 {
+		t.Errorf("expected the catalog attr lists unchanged, got %v and %v", paragraphAttrs, tableStyleAttrs)
+	}
+}
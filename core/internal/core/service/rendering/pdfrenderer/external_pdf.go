@@ -0,0 +1,229 @@
+package pdfrenderer
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+// maxInflatedPDFStream caps how much of a compressed PDF stream is inflated while looking for
+// the page tree.
+const maxInflatedPDFStream = 16 << 20
+
+// externalPDFRef is an externalPdf node. Its page numbers are only known once the service has
+// downloaded the file, so the source refers to them through the variable name, which the service
+// defines in front of the source.
+type externalPDFRef struct {
+	url      string
+	filename string // local filename referenced by the source
+	variable string // Typst variable holding the page numbers to place
+	attrs    *portabledoc.ExternalPDFAttrs
+}
+
+// externalPDF places the pages of an external PDF. Append mode gives each page a page of its own
+// without margins, header or footer; embed mode scales the pages to the content width. Placing
+// PDF pages as images requires Typst 0.14 or later.
+func (c *TypstConverter) externalPDF(node portabledoc.Node) string {
+	attrs, err := portabledoc.ParseExternalPDFAttrs(node.Attrs)
+	if err != nil {
+		return ""
+	}
+	url := c.resolveSource(node.Attrs)
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "data:") {
+		return ""
+	}
+
+	n := len(c.externalPDFs) + 1
+	ref := externalPDFRef{
+		url:      url,
+		filename: fmt.Sprintf("ext_%d.pdf", n),
+		variable: fmt.Sprintf("external-pdf-%d", n),
+		attrs:    attrs,
+	}
+	c.externalPDFs = append(c.externalPDFs, ref)
+
+	// Pages can only be set at the top level: nested in a container, the pages are embedded.
+	// The node itself counts as one level of nesting.
+	if attrs.EffectiveMode() == portabledoc.ExternalPDFAppend && c.nestingDepth <= 1 {
+		return fmt.Sprintf(
+			"#for p in %s {\n  page(margin: 0pt, header: none, footer: none, image(\"%s\", page: p, width: 100%%, height: 100%%, fit: \"contain\"))\n}\n",
+			ref.variable, escapeTypstString(ref.filename),
+		)
+	}
+	return fmt.Sprintf(
+		"#for p in %s {\n  block(width: 100%%, image(\"%s\", page: p, width: 100%%))\n}\n",
+		ref.variable, escapeTypstString(ref.filename),
+	)
+}
+
+// externalPDFFiles are the external PDFs of a job, written to disk.
+type externalPDFFiles struct {
+	rootDir string            // compile root: the job's, or a new temporary directory when it had none
+	prelude string            // Typst definitions of the page numbers of every node
+	paths   map[string]string // filename referenced by the source → path under rootDir
+	omitted []string          // URLs of the PDFs a degraded render left out, once each
+	cleanup func()
+}
+
+// resolveExternalPDFs downloads the external PDFs of a job into a directory of their own under
+// rootDir and defines the pages each node places. A PDF that cannot be downloaded or read fails
+// the render: leaving out an annex would silently produce an incomplete document. Degraded renders
+// place no pages for it instead and report it in omitted.
+func (s *Service) resolveExternalPDFs(ctx context.Context, refs []externalPDFRef, rootDir string, degraded bool) (*externalPDFFiles, error) {
+	dir, err := os.MkdirTemp(rootDir, "typst-external-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	files := &externalPDFFiles{
+		rootDir: rootDir,
+		paths:   make(map[string]string, len(refs)),
+		cleanup: func() { os.RemoveAll(dir) },
+	}
+	prefix := filepath.Base(dir) + "/"
+	if rootDir == "" {
+		files.rootDir, prefix = dir, ""
+	}
+
+	type download struct {
+		data  []byte
+		pages int
+	}
+	downloads := make(map[string]download)
+	var prelude strings.Builder
+	for _, ref := range refs {
+		if slices.Contains(files.omitted, ref.url) {
+			fmt.Fprintf(&prelude, "#let %s = ()\n", ref.variable)
+			continue
+		}
+		dl, ok := downloads[ref.url]
+		if !ok {
+			dl.data, dl.pages, err = s.downloadExternalPDF(ctx, ref.url)
+			if err != nil {
+				slog.WarnContext(ctx, "failed to include external PDF", slog.String("url", sourceLabel(ref.url)), slog.Any("error", err))
+				if degraded {
+					files.omitted = append(files.omitted, ref.url)
+					fmt.Fprintf(&prelude, "#let %s = ()\n", ref.variable)
+					continue
+				}
+				files.cleanup()
+				return nil, &entity.AssetFetchError{URL: degradationSource(ref.url), Err: fmt.Errorf("%w: %w", entity.ErrExternalPDFUnavailable, err)}
+			}
+			downloads[ref.url] = dl
+		}
+
+		pages, err := ref.attrs.PageNumbers(dl.pages)
+		if err != nil {
+			files.cleanup()
+			return nil, &entity.AssetFetchError{
+				URL: degradationSource(ref.url),
+				Err: fmt.Errorf("%w: %s: %w", entity.ErrExternalPDFUnavailable, sourceLabel(ref.url), err),
+			}
+		}
+		if err := os.WriteFile(filepath.Join(dir, ref.filename), dl.data, 0o600); err != nil {
+			files.cleanup()
+			return nil, fmt.Errorf("writing external PDF: %w", err)
+		}
+		files.paths[ref.filename] = prefix + ref.filename
+		fmt.Fprintf(&prelude, "#let %s = %s\n", ref.variable, typstIntArray(pages))
+	}
+	prelude.WriteString("\n")
+	files.prelude = prelude.String()
+	return files, nil
+}
+
+// downloadExternalPDF downloads a PDF with the protections of remote images and counts its pages.
+// Data URLs, which library assets resolve to, are decoded instead.
+func (s *Service) downloadExternalPDF(ctx context.Context, url string) ([]byte, int, error) {
+	var data []byte
+	var err error
+	if strings.HasPrefix(url, "data:") {
+		data, err = decodeDataURL(url)
+	} else {
+		data, err = s.fetcher().download(ctx, url)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		return nil, 0, fmt.Errorf("not a PDF: %s", sourceLabel(url))
+	}
+	pages, err := countExternalPDFPages(data)
+	if err != nil {
+		return nil, 0, fmt.Errorf("reading %s: %w", sourceLabel(url), err)
+	}
+	return data, pages, nil
+}
+
+// sourceLabel names a PDF source in errors and logs, where a data URL would put the whole file.
+func sourceLabel(url string) string {
+	if strings.HasPrefix(url, "data:") {
+		return "data URL"
+	}
+	return url
+}
+
+// countExternalPDFPages reads the page count of a PDF from any producer. Many producers store
+// the page tree in compressed object streams, so when it is not found in the plain text of the
+// file the Flate streams are inflated and searched too.
+func countExternalPDFPages(pdf []byte) (int, error) {
+	if count, err := countPDFPages(pdf); err == nil {
+		return count, nil
+	}
+
+	count := 0
+	for rest := pdf; ; {
+		start := bytes.Index(rest, []byte("stream"))
+		if start < 0 {
+			break
+		}
+		rest = rest[start+len("stream"):]
+		rest = bytes.TrimPrefix(bytes.TrimPrefix(rest, []byte("\r")), []byte("\n"))
+		end := bytes.Index(rest, []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		if n, err := countPDFPages(inflatePDFStream(rest[:end])); err == nil && n > count {
+			count = n
+		}
+		rest = rest[end+len("endstream"):]
+	}
+	if count == 0 {
+		return 0, fmt.Errorf("counting PDF pages: page tree not found")
+	}
+	return count, nil
+}
+
+// inflatePDFStream returns the inflated content of a Flate stream, or nil when it is not one.
+func inflatePDFStream(stream []byte) []byte {
+	r, err := zlib.NewReader(bytes.NewReader(stream))
+	if err != nil {
+		return nil
+	}
+	defer r.Close()
+	// A truncated read still holds the objects inflated so far.
+	data, _ := io.ReadAll(io.LimitReader(r, maxInflatedPDFStream))
+	return data
+}
+
+// typstIntArray formats numbers as a Typst array. The trailing comma keeps a single element an array.
+func typstIntArray(nums []int) string {
+	if len(nums) == 0 {
+		return "()"
+	}
+	parts := make([]string, len(nums))
+	for i, n := range nums {
+		parts[i] = strconv.Itoa(n)
+	}
+	return "(" + strings.Join(parts, ", ") + ",)"
+}
@@ -0,0 +1,132 @@
+package pdfrenderer
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+func externalPDFNode(attrs map[string]any) portabledoc.Node {
+	return portabledoc.Node{Type: portabledoc.NodeTypeExternalPDF, Attrs: attrs}
+}
+
+func TestTypstConverter_ExternalPDF(t *testing.T) {
+	c := newConverter(map[string]any{"annex": "https://example.com/annex.pdf"}, nil)
+
+	appended := c.ConvertNode(externalPDFNode(map[string]any{"injectableId": "annex"}))
+	embedded := c.ConvertNode(externalPDFNode(map[string]any{"src": "https://example.com/spec.pdf", "mode": "embed"}))
+	nested := c.ConvertNode(portabledoc.Node{
+		Type:    portabledoc.NodeTypeBlockquote,
+		Content: []portabledoc.Node{externalPDFNode(map[string]any{"src": "https://example.com/spec.pdf"})},
+	})
+
+	if want := `page(margin: 0pt, header: none, footer: none, image("ext_1.pdf", page: p,`; !strings.Contains(appended, want) {
+		t.Errorf("expected appended pages, got:\n%s", appended)
+	}
+	if !strings.HasPrefix(embedded, "#for p in external-pdf-2 {") || strings.Contains(embedded, "page(") {
+		t.Errorf("expected embedded pages, got:\n%s", embedded)
+	}
+	if strings.Contains(nested, "page(") {
+		t.Errorf("expected nested pages to be embedded, got:\n%s", nested)
+	}
+
+	refs := c.externalPDFs
+	if len(refs) != 3 || refs[0].url != "https://example.com/annex.pdf" || refs[2].filename != "ext_3.pdf" {
+		t.Errorf("unexpected external PDF refs: %+v", refs)
+	}
+}
+
+func TestTypstConverter_ExternalPDFWithoutSource(t *testing.T) {
+	c := newConverter(nil, nil)
+
+	if got := c.ConvertNode(externalPDFNode(map[string]any{"injectableId": "missing"})); got != "" {
+		t.Errorf("expected no output for an unresolved PDF, got %q", got)
+	}
+	if len(c.externalPDFs) != 0 {
+		t.Errorf("expected no external PDF refs, got %d", len(c.externalPDFs))
+	}
+}
+
+func TestCountExternalPDFPages_ObjectStream(t *testing.T) {
+	var stream bytes.Buffer
+	zw := zlib.NewWriter(&stream)
+	zw.Write([]byte("<</Type/Catalog/Pages 2 0 R>><</Type/Pages/Kids[3 0 R 4 0 R]/Count 2>>"))
+	zw.Close()
+
+	pdf := []byte("%PDF-1.7\n5 0 obj\n<</Type/ObjStm/N 2/Filter/FlateDecode>>\nstream\r\n")
+	pdf = append(pdf, stream.Bytes()...)
+	pdf = append(pdf, []byte("\r\nendstream\nendobj\n")...)
+
+	n, err := countExternalPDFPages(pdf)
+	if err != nil || n != 2 {
+		t.Errorf("countExternalPDFPages() = %d, %v, want 2", n, err)
+	}
+}
+
+func TestDownloadExternalPDF_DataURL(t *testing.T) {
+	pdf := "%PDF-1.7\n2 0 obj\n<</Type/Pages/Kids[3 0 R]/Count 1>>\nendobj\n"
+	url := "data:application/pdf;base64," + base64.StdEncoding.EncodeToString([]byte(pdf))
+
+	data, pages, err := (&Service{}).downloadExternalPDF(context.Background(), url)
+	if err != nil || pages != 1 || string(data) != pdf {
+		t.Errorf("downloadExternalPDF() = %q, %d, %v", data, pages, err)
+	}
+
+	png := "data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte("\x89PNG\r\n"))
+	if _, _, err := (&Service{}).downloadExternalPDF(context.Background(), png); err == nil || strings.Contains(err.Error(), "base64") {
+		t.Errorf("expected a not-a-PDF error without the data URL, got %v", err)
+	}
+}
+
+func TestTypstIntArray(t *testing.T) {
+	tests := []struct {
+		nums []int
+		want string
+	}{
+		{nil, "()"},
+		{[]int{1}, "(1,)"},
+		{[]int{1, 2}, "(1, 2,)"},
+	}
+
+	for _, tt := range tests {
+		if got := typstIntArray(tt.nums); got != tt.want {
+			t.Errorf("typstIntArray(%v) = %q, want %q", tt.nums, got, tt.want)
+		}
+	}
+}
+
+func TestResolveExternalPDFs_Degraded(t *testing.T) {
+	notPDF := "data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte("\x89PNG\r\n"))
+	refs := []externalPDFRef{
+		{url: notPDF, filename: "ext_1.pdf", variable: "external-pdf-1"},
+		{url: notPDF, filename: "ext_2.pdf", variable: "external-pdf-2"},
+	}
+
+	if _, err := (&Service{}).resolveExternalPDFs(context.Background(), refs, t.TempDir(), false); !errors.Is(err, entity.ErrExternalPDFUnavailable) {
+		t.Fatalf("expected ErrExternalPDFUnavailable, got %v", err)
+	}
+
+	files, err := (&Service{}).resolveExternalPDFs(context.Background(), refs, t.TempDir(), true)
+	if err != nil {
+		t.Fatalf("resolveExternalPDFs() error = %v", err)
+	}
+	defer files.cleanup()
+	if len(files.omitted) != 1 || files.omitted[0] != notPDF {
+		t.Errorf("omitted = %v, want the PDF once", files.omitted)
+	}
+	for _, want := range []string{"#let external-pdf-1 = ()\n", "#let external-pdf-2 = ()\n"} {
+		if !strings.Contains(files.prelude, want) {
+			t.Errorf("prelude %q does not contain %q", files.prelude, want)
+		}
+	}
+	if len(files.paths) != 0 {
+		t.Errorf("paths = %v, want none", files.paths)
+	}
+}
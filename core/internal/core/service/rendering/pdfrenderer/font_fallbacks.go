@@ -2,7 +2,13 @@ package pdfrenderer
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
 )
 
 // cssFontFallbacks maps common CSS font names (lowercase) to cross-platform fallback chains.
@@ -33,3 +39,235 @@ func fontWithFallbacks(family string) string {
 	}
 	return "(" + strings.Join(quoted, ", ") + ")"
 }
+
+// EmojiScript is the FontFallback script of emoji, which Unicode assigns no script of their own.
+const EmojiScript = "Emoji"
+
+// FontFallback is the font chain used for the characters of a script the base font stack does not cover.
+type FontFallback struct {
+	// Script is a Unicode script name (Han, Hangul, Arabic, ...) or EmojiScript.
+	Script string
+
+	// Language restricts the fallback to documents in this language. It takes precedence over the
+	// fallback of the same script without a language.
+	Language string
+
+	// Fonts are the families tried in order for the characters of the script.
+	Fonts []string
+}
+
+// DefaultFontFallbacks returns the fallbacks for the Noto fonts installed in the Docker image. Emoji
+// also fall back to the color emoji fonts of macOS and Windows, for renders outside the image.
+func DefaultFontFallbacks() []FontFallback {
+	return []FontFallback{
+		{Script: "Han", Fonts: []string{"Noto Sans CJK SC", "Noto Sans CJK TC", "Noto Sans CJK JP"}},
+		{Script: "Han", Language: "ja", Fonts: []string{"Noto Sans CJK JP"}},
+		{Script: "Han", Language: "ko", Fonts: []string{"Noto Sans CJK KR"}},
+		{Script: "Hiragana", Fonts: []string{"Noto Sans CJK JP"}},
+		{Script: "Katakana", Fonts: []string{"Noto Sans CJK JP"}},
+		{Script: "Hangul", Fonts: []string{"Noto Sans CJK KR"}},
+		{Script: "Arabic", Fonts: []string{"Noto Sans Arabic", "Noto Naskh Arabic"}},
+		{Script: "Hebrew", Fonts: []string{"Noto Sans Hebrew"}},
+		{Script: "Thai", Fonts: []string{"Noto Sans Thai"}},
+		{Script: "Devanagari", Fonts: []string{"Noto Sans Devanagari"}},
+		{Script: EmojiScript, Fonts: []string{"Noto Color Emoji", "Apple Color Emoji", "Segoe UI Emoji"}},
+	}
+}
+
+// validateFontFallbacks checks that every fallback names a known script and at least one font.
+func validateFontFallbacks(fallbacks []FontFallback) error {
+	for _, f := range fallbacks {
+		if _, ok := unicode.Scripts[f.Script]; !ok && f.Script != EmojiScript {
+			return fmt.Errorf("font fallback: unknown script %q", f.Script)
+		}
+		if len(f.Fonts) == 0 {
+			return fmt.Errorf("font fallback: no fonts for script %q", f.Script)
+		}
+	}
+	return nil
+}
+
+// baseScripts are the scripts the default font stack and its CSS fallbacks cover.
+var baseScripts = []string{"Latin", "Greek", "Cyrillic", "Common", "Inherited"}
+
+// maxScriptSample bounds the characters (or emoji sequences) kept to show which characters of a
+// script a document uses.
+const maxScriptSample = 5
+
+// emojiPresentation holds the characters that render as emoji without a variation selector: the
+// emoji and pictograph blocks and the symbols below them with the Unicode Emoji_Presentation property.
+var emojiPresentation = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x231A, 0x231B, 1}, {0x23E9, 0x23EC, 1}, {0x23F0, 0x23F3, 3}, {0x25FD, 0x25FE, 1},
+		{0x2614, 0x2615, 1}, {0x2648, 0x2653, 1}, {0x267F, 0x2693, 20}, {0x26A1, 0x26A1, 1},
+		{0x26AA, 0x26AB, 1}, {0x26BD, 0x26BE, 1}, {0x26C4, 0x26C5, 1}, {0x26CE, 0x26D4, 6},
+		{0x26EA, 0x26EA, 1}, {0x26F2, 0x26F3, 1}, {0x26F5, 0x26FA, 5}, {0x26FD, 0x26FD, 1},
+		{0x2705, 0x2705, 1}, {0x270A, 0x270B, 1}, {0x2728, 0x2728, 1}, {0x274C, 0x274E, 2},
+		{0x2753, 0x2755, 1}, {0x2757, 0x2757, 1}, {0x2795, 0x2797, 1}, {0x27B0, 0x27BF, 15},
+		{0x2B1B, 0x2B1C, 1}, {0x2B50, 0x2B55, 5},
+	},
+	R32: []unicode.Range32{
+		{0x1F000, 0x1FAFF, 1},
+	},
+}
+
+// emojiPattern matches an emoji sequence as Unicode defines it, so that the emoji font shapes it
+// whole: a flag (two regional indicators), a keycap, an emoji or a character followed by the emoji
+// variation selector, with its skin tone modifier and tags, and the characters joined to it by
+// zero width joiners. Go and Typst (Rust) regexes share its syntax.
+var emojiPattern = `(?:[\x{1F1E6}-\x{1F1FF}]{2}|[0-9#*]\x{FE0F}?\x{20E3}|[^\s\x{200D}]\x{FE0F}|[` + regexClass(emojiPresentation) + `])` +
+	`[\x{FE0F}\x{1F3FB}-\x{1F3FF}\x{E0020}-\x{E007F}]*` +
+	`(?:\x{200D}[^\s\x{200D}]\x{FE0F}?[\x{1F3FB}-\x{1F3FF}]?)*`
+
+// emojiSequence finds the emoji sequences of a source as the show rule of the emoji fallback matches them.
+var emojiSequence = regexp.MustCompile(emojiPattern)
+
+// regexClass returns the contents of a regex character class matching the runes of table.
+func regexClass(table *unicode.RangeTable) string {
+	var sb strings.Builder
+	write := func(lo, hi, stride uint32) {
+		if stride == 1 && hi > lo {
+			fmt.Fprintf(&sb, `\x{%X}-\x{%X}`, lo, hi)
+			return
+		}
+		for r := lo; r <= hi; r += stride {
+			fmt.Fprintf(&sb, `\x{%X}`, r)
+		}
+	}
+	for _, r := range table.R16 {
+		write(uint32(r.Lo), uint32(r.Hi), uint32(r.Stride))
+	}
+	for _, r := range table.R32 {
+		write(r.Lo, r.Hi, r.Stride)
+	}
+	return sb.String()
+}
+
+// scriptOf returns the script a fallback is needed for to render r, or "" when the base fonts cover it.
+func scriptOf(r rune) string {
+	if r < utf8.RuneSelf {
+		return ""
+	}
+	for _, name := range baseScripts {
+		if unicode.Is(unicode.Scripts[name], r) {
+			return ""
+		}
+	}
+	for name, table := range unicode.Scripts {
+		if unicode.Is(table, r) {
+			return name
+		}
+	}
+	return ""
+}
+
+// scriptUsage is a script outside the base scripts used by a document, with a sample of its characters.
+type scriptUsage struct {
+	script string
+	sample []string
+}
+
+// uncoveredScripts returns the scripts of source the base fonts do not cover, in order of first use.
+// Emoji sequences count as a whole, including the base characters they start with.
+func uncoveredScripts(source string) []*scriptUsage {
+	var usages []*scriptUsage
+	byScript := make(map[string]*scriptUsage)
+	seen := make(map[string]bool)
+	use := func(script, chars string) {
+		if seen[chars] {
+			return
+		}
+		seen[chars] = true
+		usage, ok := byScript[script]
+		if !ok {
+			usage = &scriptUsage{script: script}
+			byScript[script] = usage
+			usages = append(usages, usage)
+		}
+		if len(usage.sample) < maxScriptSample {
+			usage.sample = append(usage.sample, chars)
+		}
+	}
+
+	emojis := emojiSequence.FindAllStringIndex(source, -1)
+	for i := 0; i < len(source); {
+		if len(emojis) > 0 && emojis[0][0] == i {
+			use(EmojiScript, source[i:emojis[0][1]])
+			i = emojis[0][1]
+			emojis = emojis[1:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(source[i:])
+		i += size
+		if script := scriptOf(r); script != "" {
+			use(script, string(r))
+		}
+	}
+	return usages
+}
+
+// fontFallbackFor returns the fallback of a script for a document language, preferring the one
+// restricted to the language. Nil when none is configured.
+func fontFallbackFor(fallbacks []FontFallback, script, language string) *FontFallback {
+	var general *FontFallback
+	for i, f := range fallbacks {
+		if f.Script != script {
+			continue
+		}
+		if f.Language == "" && general == nil {
+			general = &fallbacks[i]
+		} else if f.Language != "" && strings.EqualFold(f.Language, language) {
+			return &fallbacks[i]
+		}
+	}
+	return general
+}
+
+// fontFallbackRules returns the Typst show rules that set the fallback fonts of the scripts the
+// source uses beyond the base fonts, and a warning for each script left without an installed
+// fallback font. installed holds the lowercased families typst finds; nil trusts the configured fonts.
+func fontFallbackRules(source, language string, fallbacks []FontFallback, installed map[string]bool) (string, []entity.RenderWarning) {
+	var sb strings.Builder
+	var warnings []entity.RenderWarning
+	for _, usage := range uncoveredScripts(source) {
+		sample := strings.Join(usage.sample, "")
+		fallback := fontFallbackFor(fallbacks, usage.script, language)
+		if fallback == nil {
+			warnings = append(warnings, missingGlyphsWarning(usage.script, sample,
+				fmt.Sprintf("no fallback font is configured for %s characters (%s); they may render blank", usage.script, sample)))
+			continue
+		}
+
+		var fonts []string
+		for _, font := range fallback.Fonts {
+			if installed == nil || installed[strings.ToLower(font)] {
+				fonts = append(fonts, strconv.Quote(font))
+			}
+		}
+		if len(fonts) == 0 {
+			warnings = append(warnings, missingGlyphsWarning(usage.script, sample,
+				fmt.Sprintf("the fallback fonts for %s characters (%s) are not installed: %s; they may render blank",
+					usage.script, sample, strings.Join(fallback.Fonts, ", "))))
+			continue
+		}
+
+		pattern := emojiPattern
+		if usage.script != EmojiScript {
+			pattern = `\p{` + usage.script + `}+`
+		}
+		fmt.Fprintf(&sb, "#show regex(%s): set text(font: (%s,))\n", strconv.Quote(pattern), strings.Join(fonts, ", "))
+	}
+	if sb.Len() > 0 {
+		sb.WriteString("\n")
+	}
+	return sb.String(), warnings
+}
+
+func missingGlyphsWarning(script, sample, message string) entity.RenderWarning {
+	return entity.RenderWarning{
+		Code:       entity.RenderWarningMissingGlyphs,
+		Message:    message,
+		Script:     script,
+		Characters: sample,
+	}
+}
@@ -1,6 +1,9 @@
 package pdfrenderer
 
 import (
+	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -303,3 +306,130 @@ func TestTypstBuilder_DocumentWithInlineFont(t *testing.T) {
 		t.Errorf("expected Liberation Mono fallback in output, got:\n%s", got)
 	}
 }
+
+// --- Script font fallbacks ---
+
+func TestUncoveredScripts(t *testing.T) {
+	usages := uncoveredScripts("Señor Ünal — 東京 😀 привет 漢字 👍")
+
+	if len(usages) != 2 {
+		t.Fatalf("uncoveredScripts() returned %d scripts, want 2 (Han, Emoji)", len(usages))
+	}
+	if usages[0].script != "Han" || strings.Join(usages[0].sample, "") != "東京漢字" {
+		t.Errorf("first usage = %s %q, want Han %q", usages[0].script, strings.Join(usages[0].sample, ""), "東京漢字")
+	}
+	if usages[1].script != EmojiScript || strings.Join(usages[1].sample, "") != "😀👍" {
+		t.Errorf("second usage = %s %q, want Emoji %q", usages[1].script, strings.Join(usages[1].sample, ""), "😀👍")
+	}
+}
+
+func TestFontFallbackRules_PrefersLanguage(t *testing.T) {
+	installed := map[string]bool{"noto sans cjk sc": true, "noto sans cjk jp": true}
+
+	rules, warnings := fontFallbackRules("#text[東京]", "ja", DefaultFontFallbacks(), installed)
+
+	if len(warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+	want := `#show regex("\\p{Han}+"): set text(font: ("Noto Sans CJK JP",))`
+	if !strings.Contains(rules, want) {
+		t.Errorf("expected %s in rules, got:\n%s", want, rules)
+	}
+}
+
+func TestFontFallbackRules_SkipsMissingFonts(t *testing.T) {
+	installed := map[string]bool{"noto sans cjk tc": true}
+
+	rules, _ := fontFallbackRules("#text[東京]", "en", DefaultFontFallbacks(), installed)
+
+	want := `set text(font: ("Noto Sans CJK TC",))`
+	if !strings.Contains(rules, want) {
+		t.Errorf("expected %s in rules, got:\n%s", want, rules)
+	}
+}
+
+func TestFontFallbackRules_Warnings(t *testing.T) {
+	fallbacks := []FontFallback{{Script: EmojiScript, Fonts: []string{"Noto Color Emoji"}}}
+
+	rules, warnings := fontFallbackRules("#text[안녕 😀]", "en", fallbacks, map[string]bool{})
+
+	if rules != "" {
+		t.Errorf("expected no rules, got:\n%s", rules)
+	}
+	if len(warnings) != 2 {
+		t.Fatalf("got %d warnings, want 2: %v", len(warnings), warnings)
+	}
+	for _, w := range warnings {
+		if w.Code != entity.RenderWarningMissingGlyphs {
+			t.Errorf("warning code = %s, want %s", w.Code, entity.RenderWarningMissingGlyphs)
+		}
+	}
+	if warnings[0].Script != "Hangul" || warnings[0].Characters != "안녕" {
+		t.Errorf("first warning = %+v, want Hangul 안녕", warnings[0])
+	}
+	if !strings.Contains(warnings[1].Message, "not installed: Noto Color Emoji") {
+		t.Errorf("second warning message = %q, want it to name the missing font", warnings[1].Message)
+	}
+}
+
+func TestFontFallbackRules_TrustsConfigWhenFontsUnknown(t *testing.T) {
+	rules, warnings := fontFallbackRules("#text[😀]", "en", DefaultFontFallbacks(), nil)
+
+	if len(warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+	if !strings.Contains(rules, `set text(font: ("Noto Color Emoji", "Apple Color Emoji", "Segoe UI Emoji",))`) {
+		t.Errorf("expected the emoji fallback in rules, got:\n%s", rules)
+	}
+}
+
+func TestEmojiSequence(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"single", "ok 😀 done", []string{"😀"}},
+		{"skin tone", "👍🏽👍", []string{"👍🏽", "👍"}},
+		{"zwj family", "👨\u200d👩\u200d👧\u200d👦", []string{"👨\u200d👩\u200d👧\u200d👦"}},
+		{"zwj with text symbol", "👩\u200d⚕️ and ❤️\u200d🔥", []string{"👩\u200d⚕️", "❤️\u200d🔥"}},
+		{"flags", "🇨🇱🇯🇵", []string{"🇨🇱", "🇯🇵"}},
+		{"subdivision flag", "🏴\U000E0067\U000E0062\U000E0065\U000E006E\U000E0067\U000E007F", []string{"🏴\U000E0067\U000E0062\U000E0065\U000E006E\U000E0067\U000E007F"}},
+		{"keycap", "press 1️⃣ or #️⃣", []string{"1️⃣", "#️⃣"}},
+		{"emoji presentation symbols", "✅ ⭐ ⌚", []string{"✅", "⭐", "⌚"}},
+		{"text presentation symbols", "© ™ ☐ ↔ 12#", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := emojiSequence.FindAllString(tt.text, -1); !slices.Equal(got, tt.want) {
+				t.Errorf("emojiSequence.FindAllString(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFontFallbackRules_EmojiSequence(t *testing.T) {
+	installed := map[string]bool{"noto color emoji": true}
+
+	rules, warnings := fontFallbackRules("#text[Bien hecho 👍🏽]", "es", DefaultFontFallbacks(), installed)
+
+	if len(warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+	want := fmt.Sprintf("#show regex(%s): set text(font: (\"Noto Color Emoji\",))", strconv.Quote(emojiPattern))
+	if !strings.Contains(rules, want) {
+		t.Errorf("expected %s in rules, got:\n%s", want, rules)
+	}
+}
+
+func TestValidateFontFallbacks(t *testing.T) {
+	if err := validateFontFallbacks(DefaultFontFallbacks()); err != nil {
+		t.Errorf("default fallbacks are invalid: %v", err)
+	}
+	if err := validateFontFallbacks([]FontFallback{{Script: "Klingon", Fonts: []string{"pIqaD"}}}); err == nil {
+		t.Error("expected an error for an unknown script")
+	}
+	if err := validateFontFallbacks([]FontFallback{{Script: "Han"}}); err == nil {
+		t.Error("expected an error for a fallback without fonts")
+	}
+}
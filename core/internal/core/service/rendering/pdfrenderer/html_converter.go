@@ -0,0 +1,1086 @@
+package pdfrenderer
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// RenderHTML converts the document of a render request to a standalone HTML page. Nothing is
+// compiled, so it does not take a render slot. Images keep their URLs and are loaded by the browser.
+func (s *Service) RenderHTML(ctx context.Context, req *port.RenderPreviewRequest) (*port.HTMLRenderResult, error) {
+	if req.Document == nil {
+		return nil, fmt.Errorf("document is required")
+	}
+	if req.Imposition != nil {
+		return nil, entity.ErrHTMLRenderOption
+	}
+	doc := req.Document
+	if req.Layout != nil {
+		if err := req.Layout.Validate(&doc.PageConfig); err != nil {
+			return nil, err
+		}
+		doc = applyLayout(doc, req.Layout)
+	}
+	unknownWarnings, err := checkUnknownNodes(doc, req.UnknownNodes)
+	if err != nil {
+		return nil, err
+	}
+
+	injectableDefaults := req.InjectableDefaults
+	if injectableDefaults == nil {
+		injectableDefaults = make(map[string]string)
+	}
+
+	converter := NewHTMLConverter(req.Injectables, injectableDefaults, s.designTokens)
+	if req.ImageURLResolver != nil {
+		converter.SetImageURLResolver(func(url string) (string, error) {
+			return req.ImageURLResolver(ctx, url)
+		})
+	}
+	converter.SetWatermark(req.Watermark)
+	converter.SetOverlays(req.Overlays)
+	converter.SetUnknownNodes(req.UnknownNodes)
+	converter.SetCurrencyConverter(req.ConvertCurrency)
+	if req.Layout != nil {
+		converter.SetFontScale(req.Layout.FontScale)
+	}
+	page := converter.Build(doc)
+
+	return &port.HTMLRenderResult{
+		HTML:     []byte(page),
+		Filename: strings.TrimSuffix(s.generateFilename(doc.Meta.Title), ".pdf") + ".html",
+		Warnings: append(unknownWarnings, converter.Warnings()...),
+	}, nil
+}
+
+// HTMLConverter converts portable documents to a standalone HTML page for in-browser previews.
+// Values are resolved by a TypstConverter, so injectors, conditions, list and table injectors and
+// table calculations show the same values as the PDF. Pagination is approximated: the page is as
+// wide as the document page, page breaks are drawn as separators, the header opens the page and
+// the footer closes it.
+type HTMLConverter struct {
+	values     *TypstConverter
+	tokens     TypstDesignTokens
+	fontScale  float64
+	watermark  string
+	overlays   *portabledoc.Overlays
+	references []portabledoc.ReferencesAttrs // references nodes, replaced once every link is known
+	markers    bool                          // follow links with their reference number
+	links      []htmlReference               // links of the rendered content, once per URL
+	linkIndex  map[string]int                // URL → position in links
+	warnings   []entity.RenderWarning
+	warned     map[string]bool // node types already reported as omitted
+}
+
+// htmlReference is a link listed by references nodes.
+type htmlReference struct {
+	url   string
+	title string
+}
+
+// NewHTMLConverter creates a new HTML converter.
+func NewHTMLConverter(
+	injectables map[string]any,
+	injectableDefaults map[string]string,
+	tokens TypstDesignTokens,
+) *HTMLConverter {
+	values := NewTypstConverter(injectables, injectableDefaults, tokens)
+	values.outputFormat = portabledoc.OutputHTML
+	return &HTMLConverter{
+		values:    values,
+		tokens:    tokens,
+		fontScale: 1,
+		linkIndex: make(map[string]int),
+		warned:    make(map[string]bool),
+	}
+}
+
+// SetImageURLResolver sets a function to resolve non-standard image URL schemes.
+func (c *HTMLConverter) SetImageURLResolver(fn func(url string) (string, error)) {
+	c.values.imageURLResolver = fn
+}
+
+// SetWatermark sets text stamped across the page, taking precedence over the overlays.
+// Empty keeps the watermark of the overlays.
+func (c *HTMLConverter) SetWatermark(text string) {
+	c.watermark = text
+}
+
+// SetOverlays sets the overlays of the render request, replacing those of the page config.
+// Only the watermark is shown; the stamp and Bates numbering are reported as omitted.
+func (c *HTMLConverter) SetOverlays(overlays *portabledoc.Overlays) {
+	c.overlays = overlays
+}
+
+// SetUnknownNodes sets how nodes of an unknown type are rendered: PLACEHOLDER shows a box naming
+// their type in the page, the other modes flatten them to their content.
+func (c *HTMLConverter) SetUnknownNodes(mode entity.UnknownNodeMode) {
+	c.values.unknownPlaceholders = mode == entity.UnknownNodesPlaceholder
+}
+
+// SetFontScale multiplies the base, heading and inline font sizes. Zero or 1 keeps them.
+func (c *HTMLConverter) SetFontScale(scale float64) {
+	if scale == 0 || scale == 1 {
+		return
+	}
+	c.fontScale = scale
+	c.values.fontScale = scale
+}
+
+// SetCurrencyConverter sets the currency conversion behind convert() in table formulas.
+func (c *HTMLConverter) SetCurrencyConverter(fn entity.CurrencyConvertFunc) {
+	c.values.convertCurrency = fn
+}
+
+// Warnings returns the parts of the document the page leaves out, once per kind of node.
+func (c *HTMLConverter) Warnings() []entity.RenderWarning {
+	return c.warnings
+}
+
+// Build creates the HTML page of a portable document.
+func (c *HTMLConverter) Build(doc *portabledoc.Document) string {
+	c.values.defaultLang = doc.Meta.Language
+	c.values.unitSystem = doc.Meta.UnitSystem
+	c.values.setDocumentDates(doc.Meta)
+	page := doc.PageConfig
+	c.values.contentWidthPx = page.Width - page.Margins.Left - page.Margins.Right
+	for _, node := range doc.NodesOfType(portabledoc.NodeTypeReferences) {
+		if attrs, err := portabledoc.ParseReferencesAttrs(node.Attrs); err == nil && attrs.Markers {
+			c.markers = true
+		}
+	}
+
+	var body strings.Builder
+	if doc.HeaderEnabled() {
+		body.WriteString(c.surface(doc.Header, "pf-header"))
+	}
+	if doc.Content != nil {
+		body.WriteString(c.convertNodes(doc.Content.Content))
+	}
+	if doc.FooterEnabled() {
+		body.WriteString(c.surface(doc.Footer, "pf-footer"))
+	}
+	if page.PageStamp != nil {
+		c.omit("pageStamp", "the page stamp is not shown in HTML")
+	}
+	watermark := c.watermark
+	if overlays := portabledoc.MergeOverlays(page.Overlays, c.overlays); overlays != nil {
+		if watermark == "" {
+			watermark = overlays.Watermark
+		}
+		if overlays.Stamp != nil || overlays.Bates != nil {
+			c.omit("overlays", "the stamp and Bates numbering are not shown in HTML")
+		}
+	}
+
+	content := body.String()
+	for i := range c.references {
+		content = strings.Replace(content, referencesPlaceholder(i), c.referencesTable(c.references[i]), 1)
+	}
+
+	lang := doc.Meta.Language
+	if lang == "" {
+		lang = portabledoc.LanguageEnglish
+	}
+	title := doc.Meta.Title
+	if title == "" {
+		title = "Document"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n")
+	fmt.Fprintf(&sb, "<html lang=\"%s\">\n<head>\n<meta charset=\"utf-8\">\n", html.EscapeString(lang))
+	sb.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n")
+	fmt.Fprintf(&sb, "<title>%s</title>\n<style>\n%s</style>\n</head>\n<body>\n", html.EscapeString(title), c.stylesheet(&page))
+	sb.WriteString("<main class=\"pf-page\">\n")
+	if watermark != "" {
+		fmt.Fprintf(&sb, "<div class=\"pf-watermark\" aria-hidden=\"true\">%s</div>\n", html.EscapeString(watermark))
+	}
+	sb.WriteString(content)
+	sb.WriteString("</main>\n</body>\n</html>\n")
+	return sb.String()
+}
+
+// stylesheet returns the page styles: the design tokens of the PDF translated to CSS.
+func (c *HTMLConverter) stylesheet(page *portabledoc.PageConfig) string {
+	width := page.Width
+	if width <= 0 {
+		width = portabledoc.StandardPageSizes[portabledoc.PageFormatA4].Width
+	}
+	fonts := make([]string, len(c.tokens.FontStack))
+	for i, f := range c.tokens.FontStack {
+		fonts[i] = cssFontName(f)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("*, *::before, *::after { box-sizing: border-box; }\n")
+	sb.WriteString("body { margin: 0; background: #e9ebee; }\n")
+	fmt.Fprintf(&sb, ".pf-page { position: relative; overflow: hidden; overflow-wrap: break-word; background: #ffffff; margin: 24px auto; "+
+		"box-shadow: 0 1px 4px rgba(0, 0, 0, 0.2); width: %spx; max-width: 100%%; min-height: %spx; padding: %spx %spx %spx %spx; "+
+		"font-family: %s, sans-serif; font-size: %s; color: %s; line-height: calc(1em + %s); }\n",
+		trimFloat(width), trimFloat(page.Height),
+		trimFloat(page.Margins.Top), trimFloat(page.Margins.Right), trimFloat(page.Margins.Bottom), trimFloat(page.Margins.Left),
+		strings.Join(fonts, ", "), c.fontSize(c.tokens.BaseFontSize), cssColor(c.tokens.BaseTextColor, "#333333"), c.tokens.ParagraphLeading)
+	fmt.Fprintf(&sb, ".pf-page p { margin: 0 0 %s; }\n", c.tokens.ParagraphSpacing)
+	for i, size := range c.tokens.HeadingSizes {
+		fmt.Fprintf(&sb, ".pf-page h%d { font-size: %s; font-weight: %s; line-height: 1.25; margin: 0.6em 0 0.4em; }\n",
+			i+1, c.fontSize(size), c.tokens.HeadingWeight)
+	}
+	fmt.Fprintf(&sb, ".pf-page blockquote { margin: 0.75em 0; padding: 0.5em 1em; border-left: 2pt solid %s; background: %s; font-style: italic; }\n",
+		cssColor(c.tokens.BlockquoteStrokeColor, "#c8c8c8"), cssColor(c.tokens.BlockquoteFill, "#f9f9f9"))
+	fmt.Fprintf(&sb, ".pf-page hr { border: 0; border-top: 0.5pt solid %s; margin: 0.75em 0; }\n", cssColor(c.tokens.HRStrokeColor, "#c8c8c8"))
+	fmt.Fprintf(&sb, ".pf-page mark { background: %s; color: inherit; }\n", cssColor(c.tokens.HighlightDefaultColor, "#ffeb3b"))
+	sb.WriteString(".pf-page pre { white-space: pre-wrap; font-family: monospace; background: #f5f5f5; padding: 0.5em; margin: 0.5em 0; }\n")
+	sb.WriteString(".pf-page ul, .pf-page ol { margin: 0 0 0.65em; padding-left: 1.5em; }\n")
+	sb.WriteString(".pf-page li > p { margin: 0; }\n")
+	sb.WriteString(".pf-page ul.pf-tasks { list-style: none; padding-left: 0.25em; }\n")
+	sb.WriteString(".pf-page ol.pf-multilevel, .pf-page ol.pf-multilevel ol { list-style: none; counter-reset: pf-item; }\n")
+	sb.WriteString(".pf-page ol.pf-multilevel li { counter-increment: pf-item; }\n")
+	sb.WriteString(".pf-page ol.pf-multilevel li::before { content: counters(pf-item, \".\") \". \"; }\n")
+	sb.WriteString(".pf-page table { border-collapse: collapse; width: 100%; margin: 0.5em 0; line-height: calc(1em + 0.65em); }\n")
+	fmt.Fprintf(&sb, ".pf-page th, .pf-page td { border: 0.5pt solid %s; padding: %s; vertical-align: top; text-align: left; }\n",
+		cssColor(c.tokens.TableStrokeColor, "#c8c8c8"), cssInset(c.tokens.TableCellInset))
+	fmt.Fprintf(&sb, ".pf-page tr:first-child > th, .pf-page thead th { background: %s; }\n", cssColor(c.tokens.TableHeaderFillDefault, "#f5f5f5"))
+	sb.WriteString(".pf-page th > p, .pf-page td > p { margin: 0; }\n")
+	sb.WriteString(".pf-page img { max-width: 100%; }\n")
+	sb.WriteString(".pf-page .pf-page-break { break-after: page; border: 0; border-top: 1px dashed #b0b0b0; margin: 1.5em 0; }\n")
+	fmt.Fprintf(&sb, ".pf-header, .pf-footer { display: flex; align-items: flex-start; gap: %spx; font-size: %spt; min-height: %spx; }\n",
+		trimFloat(surfaceImageGapPx), trimFloat(surfaceTextBaseFontPt), trimFloat(surfaceTextHeightPx))
+	sb.WriteString(".pf-header { margin-bottom: 1em; } .pf-footer { margin-top: 2em; }\n")
+	sb.WriteString(".pf-header p, .pf-footer p { margin: 0; }\n")
+	sb.WriteString(".pf-surface-text { flex: 1; min-width: 0; }\n")
+	sb.WriteString(".pf-watermark { position: absolute; top: 45%; left: 0; right: 0; text-align: center; transform: rotate(-35deg); " +
+		"font-size: 64pt; font-weight: bold; color: rgba(128, 128, 128, 0.2); pointer-events: none; white-space: nowrap; }\n")
+	sb.WriteString(".pf-ref-marker { font-size: 0.7em; }\n")
+	sb.WriteString(".pf-unknown-node { display: inline-block; border: 0.75pt solid #c0392b; border-radius: 2pt; padding: 2pt 4pt; " +
+		"color: #c0392b; font-size: 9pt; }\n")
+	sb.WriteString("@media print { body { background: none; } .pf-page { margin: 0; box-shadow: none; width: auto; min-height: 0; padding: 0; } " +
+		".pf-page .pf-page-break { border: 0; margin: 0; } }\n")
+	return sb.String()
+}
+
+// fontSize returns a CSS font size for a design token size, with the font scale applied.
+func (c *HTMLConverter) fontSize(size string) string {
+	if c.fontScale == 1 {
+		return size
+	}
+	return fmt.Sprintf("calc(%s * %g)", size, c.fontScale)
+}
+
+// omit records that a kind of node was left out of the page.
+func (c *HTMLConverter) omit(nodeType, message string) {
+	if c.warned[nodeType] {
+		return
+	}
+	c.warned[nodeType] = true
+	c.warnings = append(c.warnings, entity.RenderWarning{Code: entity.RenderWarningHTMLOmitted, Message: message})
+}
+
+// --- Nodes ---
+
+// convertNodes converts a slice of nodes. The text fragments of one link are grouped, so the
+// link is listed once in references nodes.
+func (c *HTMLConverter) convertNodes(nodes []portabledoc.Node) string {
+	var sb strings.Builder
+	nodes = c.values.visibleNodes(nodes)
+	for i := 0; i < len(nodes); i++ {
+		if href := portabledoc.LinkHref(nodes[i]); href != "" && nodes[i].Text != nil {
+			run := portabledoc.LinkRunLength(nodes[i:], href)
+			sb.WriteString(c.linkRun(nodes[i:i+run], href))
+			i += run - 1
+			continue
+		}
+		sb.WriteString(c.convertNode(nodes[i]))
+	}
+	return sb.String()
+}
+
+func (c *HTMLConverter) convertNode(node portabledoc.Node) string {
+	if !node.VisibleIn(portabledoc.OutputHTML) {
+		return ""
+	}
+	switch node.Type {
+	case portabledoc.NodeTypeParagraph:
+		return c.paragraph(node)
+	case portabledoc.NodeTypeHeading:
+		return c.heading(node)
+	case portabledoc.NodeTypeBlockquote:
+		return "<blockquote>" + c.convertNodes(node.Content) + "</blockquote>\n"
+	case portabledoc.NodeTypeCodeBlock:
+		return "<pre><code>" + c.plainText(node.Content) + "</code></pre>\n"
+	case portabledoc.NodeTypeHR:
+		return "<hr>\n"
+	case portabledoc.NodeTypeBulletList:
+		return "<ul>\n" + c.listItems(node.Content) + "</ul>\n"
+	case portabledoc.NodeTypeOrderedList:
+		start := ""
+		if s, ok := node.Attrs["start"].(float64); ok && s != 1 {
+			start = fmt.Sprintf(" start=\"%d\"", int(s))
+		}
+		return "<ol" + start + ">\n" + c.listItems(node.Content) + "</ol>\n"
+	case portabledoc.NodeTypeTaskList:
+		return "<ul class=\"pf-tasks\">\n" + c.listItems(node.Content) + "</ul>\n"
+	case portabledoc.NodeTypeListItem, portabledoc.NodeTypeTaskItem:
+		return c.listItems([]portabledoc.Node{node})
+	case portabledoc.NodeTypeInjector:
+		return c.injector(node)
+	case portabledoc.NodeTypeConditional:
+		if c.values.evaluateCondition(node.Attrs) {
+			return c.convertNodes(node.Content)
+		}
+		return ""
+	case portabledoc.NodeTypeRepeater:
+		var sb strings.Builder
+		c.values.repeat(node, func() { sb.WriteString(c.convertNodes(node.Content)) })
+		return sb.String()
+	case portabledoc.NodeTypePageBreak:
+		c.values.currentPage++
+		return "<hr class=\"pf-page-break\">\n"
+	case portabledoc.NodeTypeImage, portabledoc.NodeTypeCustomImage:
+		return c.image(node)
+	case portabledoc.NodeTypeText:
+		return c.text(node)
+	case portabledoc.NodeTypeHardBreak:
+		return "<br>"
+	case portabledoc.NodeTypeListInjector:
+		return c.listInjector(node)
+	case portabledoc.NodeTypeTableInjector:
+		return c.tableInjector(node)
+	case portabledoc.NodeTypeTable:
+		return c.table(node)
+	case portabledoc.NodeTypePageNumber:
+		c.omit(node.Type, "page numbers are not shown in HTML")
+		return ""
+	case portabledoc.NodeTypeSecurityPattern:
+		c.omit(node.Type, "security patterns are not shown in HTML")
+		return ""
+	case portabledoc.NodeTypeExternalPDF:
+		return c.externalPDF(node)
+	case portabledoc.NodeTypeReferences:
+		return c.referencesNode(node)
+	default:
+		if c.values.unknownPlaceholders {
+			return "<span class=\"pf-unknown-node\">" + html.EscapeString(unknownNodeLabel+node.Type) + "</span>"
+		}
+		return c.convertNodes(node.Content)
+	}
+}
+
+func (c *HTMLConverter) paragraph(node portabledoc.Node) string {
+	content := c.convertNodes(node.Content)
+	if content == "" {
+		content = "<br>"
+	}
+	return "<p" + styleAttr(c.blockStyles(node.Attrs)...) + ">" + content + "</p>\n"
+}
+
+func (c *HTMLConverter) heading(node portabledoc.Node) string {
+	level := c.values.parseHeadingLevel(node.Attrs)
+	return fmt.Sprintf("<h%d%s>%s</h%d>\n", level, styleAttr(c.blockStyles(node.Attrs)...), c.convertNodes(node.Content), level)
+}
+
+// blockStyles returns the alignment and line spacing declarations of a paragraph or heading.
+func (c *HTMLConverter) blockStyles(attrs map[string]any) []string {
+	var decls []string
+	if align, _ := attrs["textAlign"].(string); cssTextAlign(align) != "" {
+		decls = append(decls, "text-align: "+cssTextAlign(align))
+	}
+	if raw, _ := attrs["lineSpacing"].(string); raw != "" {
+		ls := c.values.resolveLineSpacing(attrs)
+		decls = append(decls, "line-height: calc(1em + "+ls.leading+")", "margin-bottom: "+ls.spacing)
+	}
+	return decls
+}
+
+// plainText returns the escaped text of nodes without marks, for code blocks.
+func (c *HTMLConverter) plainText(nodes []portabledoc.Node) string {
+	var sb strings.Builder
+	for _, node := range nodes {
+		if node.Text != nil {
+			sb.WriteString(html.EscapeString(*node.Text))
+		}
+		sb.WriteString(c.plainText(node.Content))
+	}
+	return sb.String()
+}
+
+// listItems renders the items of a user-built list, with task markers for task items.
+func (c *HTMLConverter) listItems(items []portabledoc.Node) string {
+	var sb strings.Builder
+	for _, item := range items {
+		sb.WriteString("<li>")
+		if item.Type == portabledoc.NodeTypeTaskItem {
+			if checked, _ := item.Attrs["checked"].(bool); checked {
+				sb.WriteString("☑ ")
+			} else {
+				sb.WriteString("☐ ")
+			}
+		}
+		sb.WriteString(strings.TrimSpace(c.convertNodes(item.Content)))
+		sb.WriteString("</li>\n")
+	}
+	return sb.String()
+}
+
+func (c *HTMLConverter) injector(node portabledoc.Node) string {
+	variableID, _ := node.Attrs["variableId"].(string)
+	prefix, _ := node.Attrs["prefix"].(string)
+	suffix, _ := node.Attrs["suffix"].(string)
+	showLabelIfEmpty, _ := node.Attrs["showLabelIfEmpty"].(bool)
+
+	value := c.values.injectorValue(variableID, node.Attrs)
+	if value == "" && !showLabelIfEmpty {
+		return ""
+	}
+
+	content := c.applyMarks(strings.ReplaceAll(html.EscapeString(prefix+value+suffix), "\n", "<br>"), node.Marks)
+	if widthPx, ok := node.Attrs["width"].(float64); ok && widthPx > 0 && value != "" {
+		return fmt.Sprintf("<span style=\"display: inline-block; width: %spx\">%s</span>", trimFloat(widthPx), content)
+	}
+	return content
+}
+
+// --- Text ---
+
+func (c *HTMLConverter) text(node portabledoc.Node) string {
+	if node.Text == nil {
+		return ""
+	}
+	return c.applyMarks(html.EscapeString(*node.Text), node.Marks)
+}
+
+func (c *HTMLConverter) applyMarks(content string, marks []portabledoc.Mark) string {
+	for _, m := range marks {
+		content = c.applyMark(content, m)
+	}
+	return content
+}
+
+func (c *HTMLConverter) applyMark(text string, mark portabledoc.Mark) string {
+	switch mark.Type {
+	case portabledoc.MarkTypeBold:
+		return "<strong>" + text + "</strong>"
+	case portabledoc.MarkTypeItalic:
+		return "<em>" + text + "</em>"
+	case portabledoc.MarkTypeStrike:
+		return "<s>" + text + "</s>"
+	case portabledoc.MarkTypeCode:
+		return "<code>" + text + "</code>"
+	case portabledoc.MarkTypeUnderline:
+		return "<u>" + text + "</u>"
+	case portabledoc.MarkTypeHighlight:
+		if clr, ok := mark.Attrs["color"].(string); ok && clr != "" {
+			return "<mark" + styleAttr("background: "+cssColor(clr, c.tokens.HighlightDefaultColor)) + ">" + text + "</mark>"
+		}
+		return "<mark>" + text + "</mark>"
+	case portabledoc.MarkTypeLink:
+		href, _ := mark.Attrs["href"].(string)
+		if safe := safeHref(href); safe != "" {
+			return "<a href=\"" + html.EscapeString(safe) + "\" target=\"_blank\" rel=\"noopener noreferrer\">" + text + "</a>"
+		}
+		return text
+	case portabledoc.MarkTypeTextStyle:
+		return c.applyTextStyleMark(text, mark)
+	default:
+		return text
+	}
+}
+
+func (c *HTMLConverter) applyTextStyleMark(text string, mark portabledoc.Mark) string {
+	var decls []string
+	if color, ok := mark.Attrs["color"].(string); ok && color != "" {
+		decls = append(decls, "color: "+cssColor(color, "inherit"))
+	}
+	if fontSize, ok := mark.Attrs["fontSize"].(string); ok && fontSize != "" {
+		if n, err := strconv.ParseFloat(strings.TrimSuffix(fontSize, "px"), 64); err == nil && n > 0 {
+			decls = append(decls, "font-size: "+trimFloat(n*c.fontScale)+"px")
+		}
+	}
+	if fontFamily, ok := mark.Attrs["fontFamily"].(string); ok && fontFamily != "" {
+		decls = append(decls, "font-family: "+cssFontName(strings.Split(fontFamily, ",")[0]))
+	}
+	if len(decls) == 0 {
+		return text
+	}
+	return "<span" + styleAttr(decls...) + ">" + text + "</span>"
+}
+
+// linkRun converts the text nodes of one link and records the link for references nodes.
+func (c *HTMLConverter) linkRun(nodes []portabledoc.Node, href string) string {
+	var sb, title strings.Builder
+	for _, node := range nodes {
+		sb.WriteString(c.convertNode(node))
+		title.WriteString(*node.Text)
+	}
+
+	n, ok := c.linkIndex[href]
+	if !ok {
+		c.links = append(c.links, htmlReference{url: href, title: strings.TrimSpace(title.String())})
+		n = len(c.links)
+		c.linkIndex[href] = n
+	}
+	if c.markers {
+		fmt.Fprintf(&sb, "<sup class=\"pf-ref-marker\">[%d]</sup>", n)
+	}
+	return sb.String()
+}
+
+// --- Images ---
+
+// imageSource returns the URL of an image node, or "" when it cannot be shown in a browser.
+func (c *HTMLConverter) imageSource(attrs map[string]any) string {
+	src := c.values.resolveSource(attrs)
+	lower := strings.ToLower(src)
+	if strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "data:image/") {
+		return src
+	}
+	return ""
+}
+
+func (c *HTMLConverter) image(node portabledoc.Node) string {
+	src := c.imageSource(node.Attrs)
+	if src == "" {
+		return ""
+	}
+
+	var decls []string
+	width, _ := node.Attrs["width"].(float64)
+	if width > 0 {
+		decls = append(decls, "width: "+trimFloat(width)+"px")
+	}
+	if shape, _ := node.Attrs["shape"].(string); shape == "circle" && width > 0 {
+		height, _ := node.Attrs["height"].(float64)
+		if height <= 0 || height > width {
+			height = width
+		}
+		decls = []string{"width: " + trimFloat(height) + "px", "height: " + trimFloat(height) + "px", "border-radius: 50%", "object-fit: cover"}
+	}
+	alt, _ := node.Attrs["alt"].(string)
+	img := fmt.Sprintf("<img src=\"%s\" alt=\"%s\"%s>", html.EscapeString(src), html.EscapeString(alt), styleAttr(decls...))
+
+	align, _ := node.Attrs["align"].(string)
+	if dm, _ := node.Attrs["displayMode"].(string); dm == "inline" {
+		if align == "right" {
+			return "<div style=\"float: right; margin: 0 0 0.5em 0.75em\">" + img + "</div>\n"
+		}
+		return "<div style=\"float: left; margin: 0 0.75em 0.5em 0\">" + img + "</div>\n"
+	}
+	return "<div" + styleAttr("text-align: "+cssTextAlignOr(align, "left")) + ">" + img + "</div>\n"
+}
+
+// --- Dynamic content ---
+
+func (c *HTMLConverter) listInjector(node portabledoc.Node) string {
+	variableID, _ := node.Attrs["variableId"].(string)
+	lang, _ := node.Attrs["lang"].(string)
+	if lang == "" {
+		lang = c.values.fallbackLang()
+	}
+
+	resolved := c.values.resolveListValue(variableID)
+	if resolved == nil {
+		return ""
+	}
+	listCopy := *resolved
+	listData := &listCopy
+
+	if sym, ok := node.Attrs["symbol"].(string); ok && sym != "" {
+		listData.Symbol = entity.ListSymbol(sym)
+	}
+	if start, ok := node.Attrs["start"].(float64); ok && start > 0 {
+		s := int(start)
+		listData.Start = &s
+	}
+	if cont, ok := node.Attrs["continueNumbering"].(bool); ok && cont {
+		listData.Continue = true
+	}
+
+	headerStyles := c.values.parseListStylesFromAttrs(node.Attrs, "header")
+	itemStyles := c.values.parseListStylesFromAttrs(node.Attrs, "item")
+	if listData.HeaderStyles != nil {
+		headerStyles = c.values.mergeListStyles(listData.HeaderStyles, headerStyles)
+	}
+	if listData.ItemStyles != nil {
+		itemStyles = c.values.mergeListStyles(listData.ItemStyles, itemStyles)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<div class=\"pf-list\">\n")
+	label, _ := node.Attrs["label"].(string)
+	if label == "" && len(listData.HeaderLabel) > 0 {
+		label = c.values.getListHeaderLabel(listData.HeaderLabel, lang)
+	}
+	if label != "" {
+		fmt.Fprintf(&sb, "<p%s>%s</p>\n", styleAttr(listStyleDecls(headerStyles)...), html.EscapeString(label))
+	}
+	isEnum := listData.Symbol.IsNumbered()
+	start := c.values.listStart(listData, isEnum)
+	sb.WriteString(c.listLevel(listData, listData.Items, itemStyles, isEnum, start, 0))
+	sb.WriteString("</div>\n")
+	return sb.String()
+}
+
+// listLevel renders one level of a list injector.
+func (c *HTMLConverter) listLevel(list *entity.ListValue, items []entity.ListItem, styles *entity.ListStyles, isEnum bool, start, depth int) string {
+	tag, attrs := "ul", ""
+	decls := listStyleDecls(styles)
+	switch {
+	case list.Symbol == entity.ListSymbolMultilevel:
+		tag, attrs = "ol", " class=\"pf-multilevel\""
+		if depth == 0 && start != 1 {
+			decls = append(decls, fmt.Sprintf("counter-reset: pf-item %d", start-1))
+		}
+	case isEnum:
+		tag = "ol"
+		switch list.Symbol {
+		case entity.ListSymbolRoman:
+			attrs = " type=\"i\""
+		case entity.ListSymbolLetter:
+			attrs = " type=\"a\""
+		}
+		if depth == 0 && start != 1 {
+			attrs += fmt.Sprintf(" start=\"%d\"", start)
+		}
+	default:
+		if marker := listMarker(list, depth); marker != "" {
+			decls = append(decls, "list-style-type: "+strconv.Quote(marker+" "))
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<%s%s%s>\n", tag, attrs, styleAttr(decls...))
+	for _, item := range items {
+		value := ""
+		if item.Value != nil {
+			value = html.EscapeString(strings.TrimSpace(c.values.formatCellValue(item.Value, "")))
+		}
+		if depth < len(list.LevelStyles) && value != "" {
+			if levelDecls := listStyleDecls(&list.LevelStyles[depth]); len(levelDecls) > 0 {
+				value = "<span" + styleAttr(levelDecls...) + ">" + value + "</span>"
+			}
+		}
+		sb.WriteString("<li>" + value)
+		if len(item.Children) > 0 {
+			sb.WriteString("\n" + c.listLevel(list, item.Children, nil, isEnum, 1, depth+1))
+		}
+		sb.WriteString("</li>\n")
+	}
+	fmt.Fprintf(&sb, "</%s>\n", tag)
+	return sb.String()
+}
+
+// listMarker returns the marker of an unnumbered list level, or "" for the default bullet.
+func listMarker(list *entity.ListValue, depth int) string {
+	var markers []string
+	for _, m := range list.Markers {
+		if m = strings.TrimSpace(m); m != "" {
+			markers = append(markers, m)
+		}
+	}
+	if len(markers) > 0 {
+		// Markers cycle by depth, as in the PDF
+		return markers[depth%len(markers)]
+	}
+	if list.Symbol == entity.ListSymbolDash {
+		return "–"
+	}
+	return ""
+}
+
+func (c *HTMLConverter) tableInjector(node portabledoc.Node) string {
+	variableID, _ := node.Attrs["variableId"].(string)
+	lang, _ := node.Attrs["lang"].(string)
+	if lang == "" {
+		lang = c.values.fallbackLang()
+	}
+
+	tableData := c.values.resolveTableValue(variableID)
+	if tableData == nil || len(tableData.Columns) == 0 {
+		return ""
+	}
+
+	headerStyles := c.values.parseTableStylesFromAttrs(node.Attrs, "header")
+	bodyStyles := c.values.parseTableStylesFromAttrs(node.Attrs, "body")
+	if tableData.HeaderStyles != nil {
+		headerStyles = c.values.mergeTableStyles(tableData.HeaderStyles, headerStyles)
+	}
+	if tableData.BodyStyles != nil {
+		bodyStyles = c.values.mergeTableStyles(tableData.BodyStyles, bodyStyles)
+	}
+
+	var footer []string
+	if attrs, err := portabledoc.ParseTableInjectorAttrs(node.Attrs); err == nil && attrs.HasCalculations() {
+		tableData, footer = c.values.applyTableCalculations(tableData, attrs, lang)
+	}
+
+	headerDecls := tableStyleDecls(headerStyles)
+	bodyDecls := tableStyleDecls(bodyStyles)
+	bodyPadding := "padding: " + cssInset(c.tokens.TableBodyCellInset)
+
+	var sb strings.Builder
+	sb.WriteString("<table>\n<colgroup>")
+	for _, col := range tableData.Columns {
+		sb.WriteString("<col" + styleAttr(cssColumnWidth(col.Width)) + ">")
+	}
+	sb.WriteString("</colgroup>\n<thead><tr>")
+	for _, col := range tableData.Columns {
+		fmt.Fprintf(&sb, "<th%s>%s</th>", styleAttr(append([]string{"padding: " + cssInset(c.tokens.TableHeaderCellInset)}, headerDecls...)...),
+			html.EscapeString(c.values.getColumnLabel(col, lang)))
+	}
+	sb.WriteString("</tr></thead>\n<tbody>\n")
+	for _, row := range tableData.Rows {
+		sb.WriteString("<tr>")
+		for i, cell := range row.Cells {
+			if cell.Value == nil && cell.Colspan == 0 && cell.Rowspan == 0 {
+				continue
+			}
+			content := html.EscapeString(c.values.formatCellValue(cell.Value, c.values.getColumnFormat(tableData.Columns, i)))
+			fmt.Fprintf(&sb, "<td%s%s>%s</td>", spanAttrs(cell.Colspan, cell.Rowspan), styleAttr(append([]string{bodyPadding}, bodyDecls...)...), content)
+		}
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("</tbody>\n")
+	if len(footer) > 0 {
+		sb.WriteString("<tfoot><tr>")
+		for _, content := range footer {
+			if content == "" {
+				fmt.Fprintf(&sb, "<td%s></td>", styleAttr(bodyPadding))
+				continue
+			}
+			fmt.Fprintf(&sb, "<td%s><strong>%s</strong></td>", styleAttr(append([]string{bodyPadding}, bodyDecls...)...), html.EscapeString(content))
+		}
+		sb.WriteString("</tr></tfoot>\n")
+	}
+	sb.WriteString("</table>\n")
+	return sb.String()
+}
+
+// table renders a user-created editable table. Header styles apply to the first row.
+func (c *HTMLConverter) table(node portabledoc.Node) string {
+	headerDecls := tableStyleDecls(c.values.parseTableStylesFromAttrs(node.Attrs, "header"))
+	bodyDecls := tableStyleDecls(c.values.parseTableStylesFromAttrs(node.Attrs, "body"))
+
+	var sb strings.Builder
+	sb.WriteString("<table>\n")
+	isFirstRow := true
+	for _, row := range node.Content {
+		if row.Type != portabledoc.NodeTypeTableRow {
+			continue
+		}
+		sb.WriteString("<tr>")
+		for _, cell := range row.Content {
+			tag := "td"
+			if cell.Type == portabledoc.NodeTypeTableHeader {
+				tag = "th"
+			}
+			decls := bodyDecls
+			if isFirstRow {
+				decls = headerDecls
+			}
+			if widths, ok := parseCellColwidthAttr(cell.Attrs["colwidth"]); ok {
+				total := 0.0
+				for _, w := range widths {
+					total += w
+				}
+				decls = append([]string{"width: " + trimFloat(total) + "px"}, decls...)
+			}
+			colspan := getIntAttr(cell.Attrs, "colspan", 1)
+			rowspan := getIntAttr(cell.Attrs, "rowspan", 1)
+			fmt.Fprintf(&sb, "<%s%s%s>%s</%s>", tag, spanAttrs(colspan, rowspan), styleAttr(decls...), strings.TrimSpace(c.convertNodes(cell.Content)), tag)
+		}
+		sb.WriteString("</tr>\n")
+		isFirstRow = false
+	}
+	sb.WriteString("</table>\n")
+	return sb.String()
+}
+
+// externalPDF links to an external PDF, whose pages the page cannot embed.
+func (c *HTMLConverter) externalPDF(node portabledoc.Node) string {
+	c.omit(node.Type, "external PDF pages are not embedded in HTML; a link to the PDF is shown instead")
+	attrs, err := portabledoc.ParseExternalPDFAttrs(node.Attrs)
+	if err != nil {
+		return ""
+	}
+	url := safeHref(c.values.resolveSource(node.Attrs))
+	if url == "" {
+		return ""
+	}
+	label := strings.TrimSpace(attrs.Label)
+	if label == "" {
+		label = sourceLabel(url)
+	}
+	return fmt.Sprintf("<p><a href=\"%s\" target=\"_blank\" rel=\"noopener noreferrer\">%s</a></p>\n", html.EscapeString(url), html.EscapeString(label))
+}
+
+// --- References ---
+
+// referencesNode leaves a placeholder for the references table, which may come before the links it lists.
+func (c *HTMLConverter) referencesNode(node portabledoc.Node) string {
+	attrs, err := portabledoc.ParseReferencesAttrs(node.Attrs)
+	if err != nil {
+		attrs = &portabledoc.ReferencesAttrs{}
+	}
+	c.references = append(c.references, *attrs)
+	return referencesPlaceholder(len(c.references) - 1)
+}
+
+func referencesPlaceholder(i int) string {
+	return fmt.Sprintf("<!--pf-references-%d-->", i)
+}
+
+// referencesTable renders the numbered table of the links of the page. Nothing is rendered when
+// the page has no links.
+func (c *HTMLConverter) referencesTable(attrs portabledoc.ReferencesAttrs) string {
+	if len(c.links) == 0 {
+		return ""
+	}
+	labels, ok := referenceColumnLabels[c.values.fallbackLang()]
+	if !ok {
+		labels = referenceColumnLabels[portabledoc.LanguageEnglish]
+	}
+
+	var sb strings.Builder
+	if title := strings.TrimSpace(attrs.Title); title != "" {
+		fmt.Fprintf(&sb, "<p><strong>%s</strong></p>\n", html.EscapeString(title))
+	}
+	fmt.Fprintf(&sb, "<table>\n<thead><tr><th>%s</th><th>%s</th><th>%s</th></tr></thead>\n<tbody>\n",
+		html.EscapeString(labels[0]), html.EscapeString(labels[1]), html.EscapeString(labels[2]))
+	for i, link := range c.links {
+		url := html.EscapeString(link.url)
+		if safe := safeHref(link.url); safe != "" {
+			url = fmt.Sprintf("<a href=\"%s\" target=\"_blank\" rel=\"noopener noreferrer\">%s</a>", html.EscapeString(safe), url)
+		}
+		fmt.Fprintf(&sb, "<tr><td>%d</td><td>%s</td><td>%s</td></tr>\n", i+1, html.EscapeString(link.title), url)
+	}
+	sb.WriteString("</tbody>\n</table>\n")
+	return sb.String()
+}
+
+// --- Header and footer ---
+
+// surface renders the header or footer: its image beside or above its text, as in the PDF.
+func (c *HTMLConverter) surface(s portabledoc.DocumentSurface, class string) string {
+	var text strings.Builder
+	for _, node := range c.values.visibleNodes(s.ContentNodes()) {
+		text.WriteString(c.convertNode(node))
+	}
+
+	var image string
+	if s.HasImage() {
+		src := c.imageSource(map[string]any{"src": s.SurfaceImageURL(), "injectableId": s.SurfaceImageInjectableID()})
+		if src != "" {
+			height := surfaceImageHeightPx
+			if s.SurfaceImageHeight() > 0 {
+				height = s.SurfaceImageHeight()
+			}
+			decls := []string{"height: " + trimFloat(height) + "px", "object-fit: contain"}
+			if s.SurfaceImageWidth() > 0 {
+				decls = append(decls, "width: "+trimFloat(max(surfaceImageMinWidthPx, s.SurfaceImageWidth()))+"px")
+			}
+			image = fmt.Sprintf("<img src=\"%s\" alt=\"\"%s>", html.EscapeString(src), styleAttr(decls...))
+		}
+	}
+
+	textBlock := ""
+	if strings.TrimSpace(text.String()) != "" {
+		textBlock = "<div class=\"pf-surface-text\">" + text.String() + "</div>"
+	}
+	if image == "" && textBlock == "" {
+		return ""
+	}
+
+	var content string
+	switch s.SurfaceLayout() {
+	case portabledoc.SurfaceLayoutImageCenter:
+		// The image has priority; text is shown only without one, as in the PDF
+		if image != "" {
+			return fmt.Sprintf("<header class=\"%s\" style=\"justify-content: center\">%s</header>\n", class, image)
+		}
+		content = textBlock
+	case portabledoc.SurfaceLayoutImageRight:
+		content = textBlock + image
+	default: // image-left
+		content = image + textBlock
+	}
+	tag := "header"
+	if class == "pf-footer" {
+		tag = "footer"
+	}
+	return fmt.Sprintf("<%s class=\"%s\">%s</%s>\n", tag, class, content, tag)
+}
+
+// --- CSS helpers ---
+
+// styleAttr returns a style attribute with the declarations, or "" without any.
+func styleAttr(decls ...string) string {
+	if len(decls) == 0 {
+		return ""
+	}
+	return " style=\"" + html.EscapeString(strings.Join(decls, "; ")) + "\""
+}
+
+// spanAttrs returns the colspan and rowspan attributes of a table cell.
+func spanAttrs(colspan, rowspan int) string {
+	var attrs string
+	if colspan > 1 {
+		attrs += fmt.Sprintf(" colspan=\"%d\"", colspan)
+	}
+	if rowspan > 1 {
+		attrs += fmt.Sprintf(" rowspan=\"%d\"", rowspan)
+	}
+	return attrs
+}
+
+// cssColor converts a color from a document or the design tokens to CSS. Hex, rgb(), rgba(), the
+// named colors Typst knows and luma() are accepted; anything else gives fallback, so document
+// values cannot inject other declarations.
+func cssColor(raw, fallback string) string {
+	color := strings.TrimSpace(raw)
+	if isHexColor(color) {
+		return color
+	}
+	if expr, ok := parseCSSRGBColor(color); ok {
+		return expr
+	}
+	if _, ok := typstNamedColors[strings.ToLower(color)]; ok {
+		return strings.ToLower(color)
+	}
+	lower := strings.ToLower(color)
+	if strings.HasPrefix(lower, "luma(") && strings.HasSuffix(lower, ")") {
+		if channel, ok := parseCSSColorChannel(color[5 : len(color)-1]); ok {
+			return fmt.Sprintf("rgb(%d, %d, %d)", channel, channel, channel)
+		}
+	}
+	return fallback
+}
+
+// cssFontName quotes a font family name, keeping only the characters family names use.
+func cssFontName(family string) string {
+	name := strings.Map(func(r rune) rune {
+		if r == '"' || r == '\'' || r == ';' || r == '\\' || r == '{' || r == '}' || r == '<' || r == '>' || r < ' ' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(family))
+	return "\"" + name + "\""
+}
+
+// cssTextAlign returns the CSS text-align of a ProseMirror textAlign value, or "" for the default.
+func cssTextAlign(align string) string {
+	switch align {
+	case "center", "right", "justify":
+		return align
+	default:
+		return ""
+	}
+}
+
+func cssTextAlignOr(align, fallback string) string {
+	if a := cssTextAlign(align); a != "" {
+		return a
+	}
+	return fallback
+}
+
+// cssInset converts a Typst inset token ("6pt" or "(x: 6pt, y: 12pt)") to a CSS padding.
+func cssInset(inset string) string {
+	inset = strings.TrimSpace(inset)
+	if !strings.HasPrefix(inset, "(") {
+		return html.EscapeString(inset)
+	}
+	x, y := "0", "0"
+	for part := range strings.SplitSeq(strings.Trim(inset, "()"), ",") {
+		key, value, ok := strings.Cut(part, ":")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "x":
+			x = html.EscapeString(strings.TrimSpace(value))
+		case "y":
+			y = html.EscapeString(strings.TrimSpace(value))
+		}
+	}
+	return y + " " + x
+}
+
+// cssColumnWidth returns the width declaration of a table injector column, or "" for an even share.
+func cssColumnWidth(width *string) string {
+	if width == nil {
+		return ""
+	}
+	w := strings.TrimSpace(*width)
+	for _, unit := range []string{"%", "px"} {
+		if n, err := strconv.ParseFloat(strings.TrimSuffix(w, unit), 64); strings.HasSuffix(w, unit) && err == nil && n > 0 {
+			return "width: " + trimFloat(n) + unit
+		}
+	}
+	return ""
+}
+
+// listStyleDecls returns the CSS declarations of list injector styles.
+func listStyleDecls(styles *entity.ListStyles) []string {
+	if styles == nil {
+		return nil
+	}
+	return textStyleDecls(styles.FontFamily, styles.FontSize, styles.FontWeight, styles.TextColor, styles.TextAlign)
+}
+
+// tableStyleDecls returns the CSS declarations of table cell styles.
+func tableStyleDecls(styles *entity.TableStyles) []string {
+	if styles == nil {
+		return nil
+	}
+	decls := textStyleDecls(styles.FontFamily, styles.FontSize, styles.FontWeight, styles.TextColor, styles.TextAlign)
+	if styles.Background != nil {
+		decls = append(decls, "background: "+cssColor(*styles.Background, "transparent"))
+	}
+	return decls
+}
+
+// textStyleDecls returns the CSS declarations of list and table styles. Font sizes are in points,
+// as in the PDF.
+func textStyleDecls(family *string, size *int, weight, color, align *string) []string {
+	var decls []string
+	if family != nil {
+		decls = append(decls, "font-family: "+cssFontName(*family))
+	}
+	if size != nil && *size > 0 {
+		decls = append(decls, fmt.Sprintf("font-size: %dpt", *size))
+	}
+	if weight != nil {
+		if *weight == "bold" {
+			decls = append(decls, "font-weight: bold")
+		} else {
+			decls = append(decls, "font-weight: normal")
+		}
+	}
+	if color != nil {
+		decls = append(decls, "color: "+cssColor(*color, "inherit"))
+	}
+	if align != nil && cssTextAlign(*align) != "" {
+		decls = append(decls, "text-align: "+cssTextAlign(*align))
+	}
+	return decls
+}
+
+// safeHref returns href when it is a web, mail or phone link or a fragment, and "" otherwise, so
+// document links cannot run scripts in the preview.
+func safeHref(href string) string {
+	href = strings.TrimSpace(href)
+	lower := strings.ToLower(href)
+	for _, prefix := range []string{"http://", "https://", "mailto:", "tel:", "#"} {
+		if strings.HasPrefix(lower, prefix) {
+			return href
+		}
+	}
+	return ""
+}
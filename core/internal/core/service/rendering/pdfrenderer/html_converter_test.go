@@ -0,0 +1,137 @@
+package pdfrenderer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+func htmlDoc(nodes ...portabledoc.Node) *portabledoc.Document {
+	return &portabledoc.Document{
+		Meta:       portabledoc.Meta{Title: "Offer <draft>", Language: "en"},
+		PageConfig: portabledoc.PageConfig{FormatID: portabledoc.PageFormatA4, Width: 794, Height: 1123, Margins: portabledoc.Margins{}},
+		Content:    &portabledoc.ProseMirrorDoc{Type: "doc", Content: nodes},
+	}
+}
+
+func buildHTML(injectables map[string]any, nodes ...portabledoc.Node) (string, *HTMLConverter) {
+	if injectables == nil {
+		injectables = map[string]any{}
+	}
+	c := NewHTMLConverter(injectables, map[string]string{}, DefaultDesignTokens())
+	return c.Build(htmlDoc(nodes...)), c
+}
+
+func TestHTMLConverter_DocumentShell(t *testing.T) {
+	got, _ := buildHTML(nil, paragraphNode(textNode("Hello")))
+
+	for _, want := range []string{"<!DOCTYPE html>", `<html lang="en">`, "<title>Offer &lt;draft&gt;</title>", "<p>Hello</p>"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestHTMLConverter_EscapesTextAndValues(t *testing.T) {
+	injector := portabledoc.Node{Type: portabledoc.NodeTypeInjector, Attrs: map[string]any{"variableId": "client"}}
+	got, _ := buildHTML(map[string]any{"client": "<script>alert(1)</script>"},
+		paragraphNode(textNode("a < b & c"), injector))
+
+	if strings.Contains(got, "<script>") {
+		t.Errorf("injected value must be escaped, got:\n%s", got)
+	}
+	if !strings.Contains(got, "a &lt; b &amp; c&lt;script&gt;") {
+		t.Errorf("expected escaped text and value, got:\n%s", got)
+	}
+}
+
+func TestHTMLConverter_InjectorDefaultValue(t *testing.T) {
+	injector := portabledoc.Node{Type: portabledoc.NodeTypeInjector, Attrs: map[string]any{
+		"variableId": "city", "defaultValue": "Santiago", "prefix": "City: ",
+	}}
+	got, _ := buildHTML(nil, paragraphNode(injector))
+
+	if !strings.Contains(got, "<p>City: Santiago</p>") {
+		t.Errorf("expected default value, got:\n%s", got)
+	}
+}
+
+func TestHTMLConverter_Visibility(t *testing.T) {
+	pdfOnly := paragraphNode(textNode("print only"))
+	pdfOnly.Attrs = map[string]any{"visibility": portabledoc.OutputPDF}
+	htmlOnly := paragraphNode(textNode("screen only"))
+	htmlOnly.Attrs = map[string]any{"visibility": portabledoc.OutputHTML}
+
+	got, _ := buildHTML(nil, pdfOnly, htmlOnly)
+
+	if strings.Contains(got, "print only") {
+		t.Errorf("pdf-only node should be omitted, got:\n%s", got)
+	}
+	if !strings.Contains(got, "screen only") {
+		t.Errorf("html-only node should be shown, got:\n%s", got)
+	}
+}
+
+func TestHTMLConverter_OmittedNodesWarnOnce(t *testing.T) {
+	pageNumber := portabledoc.Node{Type: portabledoc.NodeTypePageNumber}
+	_, c := buildHTML(nil, paragraphNode(pageNumber), paragraphNode(pageNumber))
+
+	warnings := c.Warnings()
+	if len(warnings) != 1 {
+		t.Fatalf("expected one warning, got %v", warnings)
+	}
+	if warnings[0].Code != entity.RenderWarningHTMLOmitted {
+		t.Errorf("got code %q, want %q", warnings[0].Code, entity.RenderWarningHTMLOmitted)
+	}
+}
+
+func TestHTMLConverter_UnsafeLinkDropped(t *testing.T) {
+	got, _ := buildHTML(nil, paragraphNode(
+		markedTextNode("bad", mark(portabledoc.MarkTypeLink, map[string]any{"href": "javascript:alert(1)"})),
+		markedTextNode("good", mark(portabledoc.MarkTypeLink, map[string]any{"href": "https://example.com/a?b=1&c=2"})),
+	))
+
+	if strings.Contains(got, "javascript:") {
+		t.Errorf("javascript link should be dropped, got:\n%s", got)
+	}
+	if !strings.Contains(got, `href="https://example.com/a?b=1&amp;c=2"`) {
+		t.Errorf("expected escaped https link, got:\n%s", got)
+	}
+}
+
+func TestHTMLConverter_ReferencesTable(t *testing.T) {
+	link := mark(portabledoc.MarkTypeLink, map[string]any{"href": "https://example.com"})
+	references := portabledoc.Node{Type: portabledoc.NodeTypeReferences, Attrs: map[string]any{"title": "Links", "markers": true}}
+
+	// The references node comes first: links after it must still be listed.
+	got, _ := buildHTML(nil, references, paragraphNode(markedTextNode("Example", link)))
+
+	if !strings.Contains(got, `<sup class="pf-ref-marker">[1]</sup>`) {
+		t.Errorf("expected reference marker, got:\n%s", got)
+	}
+	if !strings.Contains(got, "<strong>Links</strong>") || !strings.Contains(got, "https://example.com") {
+		t.Errorf("expected references table, got:\n%s", got)
+	}
+	if strings.Contains(got, "pf-references-") {
+		t.Errorf("placeholder should be replaced, got:\n%s", got)
+	}
+}
+
+func TestCSSColor(t *testing.T) {
+	tests := []struct {
+		raw, want string
+	}{
+		{"#ff0000", "#ff0000"},
+		{"Red", "red"},
+		{"luma(128)", "rgb(128, 128, 128)"},
+		{"red; background: url(x)", "#000"},
+		{"", "#000"},
+	}
+	for _, tt := range tests {
+		if got := cssColor(tt.raw, "#000"); got != tt.want {
+			t.Errorf("cssColor(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
@@ -7,18 +7,23 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
 // ImageCache provides a shared disk-based cache for downloaded images.
-// Files are keyed by SHA-256 of the URL and cleaned up periodically by age.
+// Files are keyed by SHA-256 of the URL and served for maxAge after their download. Cleanup
+// removes them once twice that old, leaving renders that looked them up time to compile.
 type ImageCache struct {
-	dir     string
-	maxAge  time.Duration
-	mu      sync.RWMutex
-	stopCh  chan struct{}
-	stopped chan struct{}
+	dir      string
+	maxAge   time.Duration
+	mu       sync.RWMutex
+	inflight singleflight.Group // one download per URL across concurrent renders
+	stopCh   chan struct{}
+	stopped  chan struct{}
 }
 
 // ImageCacheOptions configures the image cache.
@@ -67,7 +72,7 @@ func cacheKeyForURL(url string) string {
 	return hex.EncodeToString(h[:])
 }
 
-// Lookup checks if an image for the given URL exists in cache.
+// Lookup checks if an image for the given URL was cached less than maxAge ago.
 // Returns the file path and true if found, or empty string and false if not.
 func (ic *ImageCache) Lookup(url string) (string, bool) {
 	ic.mu.RLock()
@@ -75,15 +80,16 @@ func (ic *ImageCache) Lookup(url string) (string, bool) {
 
 	prefix := cacheKeyForURL(url)
 	matches, err := filepath.Glob(filepath.Join(ic.dir, prefix+".*"))
-	if err != nil || len(matches) == 0 {
+	if err != nil {
 		return "", false
 	}
-
-	// Touch the file to keep it alive in cache
-	now := time.Now()
-	_ = os.Chtimes(matches[0], now, now)
-
-	return matches[0], true
+	cutoff := time.Now().Add(-ic.maxAge)
+	for _, match := range matches {
+		if info, err := os.Stat(match); err == nil && info.ModTime().After(cutoff) {
+			return match, true
+		}
+	}
+	return "", false
 }
 
 // Store saves image data to the cache, returning the stored file path.
@@ -91,11 +97,18 @@ func (ic *ImageCache) Store(url string, ext string, data []byte) (string, error)
 	ic.mu.Lock()
 	defer ic.mu.Unlock()
 
-	filename := cacheKeyForURL(url) + ext
-	path := filepath.Join(ic.dir, filename)
+	key := cacheKeyForURL(url)
+	path := filepath.Join(ic.dir, key+ext)
 	if err := os.WriteFile(path, data, 0o600); err != nil {
 		return "", err
 	}
+	// Drop an expired entry of another type, which Lookup could otherwise find first.
+	matches, _ := filepath.Glob(filepath.Join(ic.dir, key+".*"))
+	for _, match := range matches {
+		if match != path {
+			_ = os.Remove(match)
+		}
+	}
 	return path, nil
 }
 
@@ -124,7 +137,7 @@ func (ic *ImageCache) cleanup() {
 	ic.mu.Lock()
 	defer ic.mu.Unlock()
 
-	cutoff := time.Now().Add(-ic.maxAge)
+	cutoff := time.Now().Add(-2 * ic.maxAge)
 	entries, err := os.ReadDir(ic.dir)
 	if err != nil {
 		return
@@ -158,38 +171,43 @@ func (ic *ImageCache) Close() {
 	<-ic.stopped
 }
 
-// ResolveImages downloads images that aren't cached, stores them, and returns
-// a map of typst placeholder filenames to actual filenames in the cache dir.
-func (ic *ImageCache) ResolveImages(ctx context.Context, images map[string]string, downloadFn func(ctx context.Context, url, destPath string) (string, error)) map[string]string {
-	renames := make(map[string]string)
-	for url, typstFilename := range images {
-		if cachedName := ic.resolveOne(ctx, url, typstFilename, downloadFn); cachedName != typstFilename {
-			renames[typstFilename] = cachedName
-		}
-	}
-	return renames
+// ResolveImages downloads images that aren't cached, at most concurrency at once, stores them, and
+// returns a map of typst placeholder filenames to actual filenames in the cache dir, with the URLs
+// replaced by a placeholder image.
+func (ic *ImageCache) ResolveImages(ctx context.Context, images map[string]string, concurrency int, downloadFn func(ctx context.Context, url, destPath string) (string, error)) (map[string]string, []string) {
+	return fetchAll(images, concurrency, func(url, _ string) (string, bool) {
+		cachedName := ic.resolveOne(ctx, url, downloadFn)
+		return cachedName, strings.HasSuffix(cachedName, placeholderCacheExt)
+	})
 }
 
 // resolveOne resolves a single image, returning the actual filename in the cache dir.
-func (ic *ImageCache) resolveOne(ctx context.Context, url, typstFilename string, downloadFn func(ctx context.Context, url, destPath string) (string, error)) string {
+// Renders asking for an image being downloaded wait for that download. A failed download is
+// cached as a placeholder until the entry expires.
+func (ic *ImageCache) resolveOne(ctx context.Context, url string, downloadFn func(ctx context.Context, url, destPath string) (string, error)) string {
 	if cachedPath, found := ic.Lookup(url); found {
 		return filepath.Base(cachedPath)
 	}
 
-	storedName, err := ic.downloadAndStore(ctx, url, typstFilename, downloadFn)
-	if err != nil {
-		slog.WarnContext(ctx, "failed to download image, using placeholder",
-			slog.String("url", url), slog.Any("error", err),
-		)
-		return ic.storePlaceholder(url)
-	}
-	return storedName
+	name, _, _ := ic.inflight.Do(url, func() (any, error) {
+		if cachedPath, found := ic.Lookup(url); found {
+			return filepath.Base(cachedPath), nil
+		}
+		storedName, err := ic.downloadAndStore(ctx, url, downloadFn)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to download image, using placeholder",
+				slog.String("url", url), slog.Any("error", err),
+			)
+			return ic.storePlaceholder(url), nil
+		}
+		return storedName, nil
+	})
+	return name.(string)
 }
 
 // downloadAndStore downloads an image and stores it in the cache.
-func (ic *ImageCache) downloadAndStore(ctx context.Context, url, typstFilename string, downloadFn func(ctx context.Context, url, destPath string) (string, error)) (string, error) {
-	tmpPath := filepath.Join(ic.dir, "tmp_"+typstFilename)
-	defer os.Remove(tmpPath)
+func (ic *ImageCache) downloadAndStore(ctx context.Context, url string, downloadFn func(ctx context.Context, url, destPath string) (string, error)) (string, error) {
+	tmpPath := filepath.Join(ic.dir, "tmp_"+cacheKeyForURL(url))
 
 	actualName, err := downloadFn(ctx, url, tmpPath)
 	if err != nil {
@@ -211,8 +229,11 @@ func (ic *ImageCache) downloadAndStore(ctx context.Context, url, typstFilename s
 	return filepath.Base(storedPath), nil
 }
 
+// placeholderCacheExt is the extension of cached placeholders, which tells them from downloaded PNGs.
+const placeholderCacheExt = ".placeholder.png"
+
 // storePlaceholder stores a 1x1 PNG placeholder and returns its cache filename.
 func (ic *ImageCache) storePlaceholder(url string) string {
-	_, _ = ic.Store(url, ".png", getPlaceholderPNG())
-	return cacheKeyForURL(url) + ".png"
+	_, _ = ic.Store(url, placeholderCacheExt, getPlaceholderPNG())
+	return cacheKeyForURL(url) + placeholderCacheExt
 }
@@ -0,0 +1,47 @@
+package pdfrenderer
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestImageCache_ResolveImagesReportsPlaceholders(t *testing.T) {
+	ic, err := NewImageCache(ImageCacheOptions{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("NewImageCache() error = %v", err)
+	}
+	defer ic.Close()
+
+	downloads := 0
+	failing := func(context.Context, string, string) (string, error) {
+		downloads++
+		return "", errors.New("404 Not Found")
+	}
+	images := map[string]string{"https://cdn.example.com/logo.png": "img_1"}
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		renames, failed := ic.ResolveImages(context.Background(), images, 1, failing)
+		if len(failed) != 1 || failed[0] != "https://cdn.example.com/logo.png" {
+			t.Errorf("attempt %d: failed = %v, want the logo", attempt, failed)
+		}
+		if renames["img_1"] != cacheKeyForURL("https://cdn.example.com/logo.png")+placeholderCacheExt {
+			t.Errorf("attempt %d: renames = %v, want the cached placeholder", attempt, renames)
+		}
+	}
+	if downloads != 1 {
+		t.Errorf("downloads = %d, want 1: the placeholder is cached", downloads)
+	}
+}
+
+func TestDegradationSource(t *testing.T) {
+	tests := map[string]string{
+		"https://user:pw@cdn.example.com/a.png?X-Amz-Signature=abc#top": "https://cdn.example.com/a.png",
+		"data:image/png;base64,AAAA":                                    "data URL",
+	}
+	for url, want := range tests {
+		if got := degradationSource(url); got != want {
+			t.Errorf("degradationSource(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
@@ -0,0 +1,347 @@
+package pdfrenderer
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Defaults of ImageFetcherOptions.
+const (
+	DefaultImageMaxBytes         = 20 << 20
+	DefaultImageFetchConcurrency = 4
+)
+
+var errRemoteFileTooLarge = errors.New("remote file exceeds the size limit")
+
+// ImageFetcherOptions configures an ImageFetcher.
+type ImageFetcherOptions struct {
+	Cache       *ImageCache // nil downloads the images of each render to a temp dir
+	MaxBytes    int64       // largest file downloaded; 0 = DefaultImageMaxBytes
+	Concurrency int         // downloads at once per render; 0 = DefaultImageFetchConcurrency
+}
+
+// ImageFetcher downloads the remote images and external PDFs of renders. Hosts are checked
+// against the SSRF policy at every redirect and dial, bodies over the size limit are rejected, and
+// the type of images is sniffed from their content rather than guessed from their URL.
+type ImageFetcher struct {
+	client      *http.Client
+	policy      *remoteImagePolicy
+	cache       *ImageCache
+	maxBytes    int64
+	concurrency int
+}
+
+// NewImageFetcher creates an image fetcher.
+func NewImageFetcher(opts ImageFetcherOptions) *ImageFetcher {
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = DefaultImageMaxBytes
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultImageFetchConcurrency
+	}
+	policy := newRemoteImagePolicy()
+	return &ImageFetcher{
+		client:      newRemoteImageHTTPClient(policy),
+		policy:      policy,
+		cache:       opts.Cache,
+		maxBytes:    opts.MaxBytes,
+		concurrency: opts.Concurrency,
+	}
+}
+
+// Resolve downloads the images of a render, keyed by URL to the file name the Typst source uses.
+// Returns the directory the files are in, the names changed to the extension of their content,
+// the URLs replaced by a placeholder, and a cleanup of the temp dir used when there is no cache.
+func (f *ImageFetcher) Resolve(ctx context.Context, images map[string]string) (string, map[string]string, []string, func(), error) {
+	if len(images) == 0 {
+		return "", nil, nil, nil, nil
+	}
+
+	if f.cache != nil {
+		renames, failed := f.cache.ResolveImages(ctx, images, f.concurrency, f.downloadFile)
+		return f.cache.Dir(), renames, failed, nil, nil
+	}
+
+	tmpDir, err := os.MkdirTemp("", "typst-images-*")
+	if err != nil {
+		return "", nil, nil, nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+
+	renames, failed := f.downloadImages(ctx, images, tmpDir)
+	return tmpDir, renames, failed, func() { os.RemoveAll(tmpDir) }, nil
+}
+
+// downloadImages downloads remote images to the given directory.
+// Returns a map of old filename → new filename with the extension of the image type, and the URLs
+// that failed. For failed downloads, creates a 1x1 PNG placeholder so Typst doesn't crash.
+func (f *ImageFetcher) downloadImages(ctx context.Context, images map[string]string, dir string) (map[string]string, []string) {
+	return fetchAll(images, f.concurrency, func(url, filename string) (string, bool) {
+		actualName, err := f.downloadFile(ctx, url, filepath.Join(dir, filename))
+		if err == nil {
+			return actualName, false
+		}
+		slog.WarnContext(ctx, "failed to download image, using placeholder",
+			slog.String("url", url),
+			slog.Any("error", err),
+		)
+		// Use .png for placeholder since it's a real PNG
+		placeholderName := strings.TrimSuffix(filename, filepath.Ext(filename)) + ".png"
+		_ = os.WriteFile(filepath.Join(dir, placeholderName), getPlaceholderPNG(), 0o600)
+		return placeholderName, true
+	})
+}
+
+// fetchAll runs fetch for every image, at most concurrency at once. fetch returns the name the
+// image was written under and whether it is a placeholder. Returns the names that changed and
+// the URLs replaced by a placeholder, sorted.
+func fetchAll(images map[string]string, concurrency int, fetch func(url, filename string) (string, bool)) (map[string]string, []string) {
+	var (
+		mu      sync.Mutex
+		renames = make(map[string]string)
+		failed  []string
+	)
+	var g errgroup.Group
+	g.SetLimit(max(concurrency, 1))
+	for url, filename := range images {
+		g.Go(func() error {
+			actualName, placeholder := fetch(url, filename)
+			mu.Lock()
+			defer mu.Unlock()
+			if actualName != filename {
+				renames[filename] = actualName
+			}
+			if placeholder {
+				failed = append(failed, url)
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+	slices.Sort(failed)
+	return renames, failed
+}
+
+// Load returns the content of a remote image or data URL, through the image cache when there is one.
+func (f *ImageFetcher) Load(ctx context.Context, url string) ([]byte, error) {
+	if strings.HasPrefix(url, "data:") {
+		return decodeDataURL(url)
+	}
+	if f.cache != nil {
+		if path, ok := f.cache.Lookup(url); ok && !strings.HasSuffix(path, placeholderCacheExt) {
+			if data, err := os.ReadFile(path); err == nil {
+				return data, nil
+			}
+		}
+	}
+
+	data, err := f.download(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	if ext := detectImageExt(data); f.cache != nil && ext != "" {
+		_, _ = f.cache.Store(url, ext, data)
+	}
+	return data, nil
+}
+
+// downloadFile downloads a URL to a local file with the extension of the image type it contains.
+// Also handles data: URLs by decoding base64 content directly.
+// Returns the actual filename (basename) used: destPath's base name with that extension.
+func (f *ImageFetcher) downloadFile(ctx context.Context, url, destPath string) (string, error) {
+	var data []byte
+	var err error
+	if strings.HasPrefix(url, "data:") {
+		data, err = decodeDataURL(url)
+	} else {
+		data, err = f.download(ctx, url)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// Detect real image type from magic bytes
+	realExt := detectImageExt(data)
+	if realExt == "" {
+		slog.WarnContext(ctx, "downloaded content is not a valid image",
+			slog.String("url", degradationSource(url)),
+			slog.Int("size", len(data)),
+		)
+		return "", fmt.Errorf("not a valid image: %s", degradationSource(url))
+	}
+
+	base := strings.TrimSuffix(filepath.Base(destPath), filepath.Ext(destPath))
+	actualName := base + realExt
+	actualPath := filepath.Join(filepath.Dir(destPath), actualName)
+
+	if err := os.WriteFile(actualPath, data, 0o600); err != nil {
+		return "", fmt.Errorf("writing file: %w", err)
+	}
+	return actualName, nil
+}
+
+// download returns the body of a remote URL, following redirects with the SSRF policy checked at
+// each hop.
+func (f *ImageFetcher) download(ctx context.Context, rawURL string) ([]byte, error) {
+	currentURL := rawURL
+	for redirects := 0; redirects <= maxRemoteImageRedirects; redirects++ {
+		parsedURL, resp, err := f.fetchResponse(ctx, currentURL)
+		if err != nil {
+			return nil, err
+		}
+
+		nextURL, redirected, err := resolveRemoteImageRedirect(rawURL, parsedURL, resp, redirects)
+		if err != nil {
+			return nil, err
+		}
+		if redirected {
+			currentURL = nextURL
+			continue
+		}
+
+		return f.readBody(parsedURL, resp)
+	}
+
+	return nil, fmt.Errorf("downloading %s: too many redirects", rawURL)
+}
+
+func (f *ImageFetcher) fetchResponse(ctx context.Context, rawURL string) (*neturl.URL, *http.Response, error) {
+	parsedURL, err := f.policy.validateURL(ctx, rawURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsedURL.String(), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := f.client.Do(req) //nolint:gosec // Remote-image client disables env proxies, validates public destinations at URL and dial time, and follows redirects manually with revalidation.
+	if err != nil {
+		return nil, nil, fmt.Errorf("downloading %s: %w", parsedURL.String(), err)
+	}
+
+	return parsedURL, resp, nil
+}
+
+func resolveRemoteImageRedirect(rawURL string, parsedURL *neturl.URL, resp *http.Response, redirects int) (string, bool, error) {
+	if !isRedirectStatus(resp.StatusCode) {
+		return "", false, nil
+	}
+
+	location := resp.Header.Get("Location")
+	resp.Body.Close()
+
+	if redirects == maxRemoteImageRedirects {
+		return "", false, fmt.Errorf("downloading %s: too many redirects", rawURL)
+	}
+	if location == "" {
+		return "", false, fmt.Errorf("downloading %s: redirect missing location header", parsedURL.String())
+	}
+
+	nextURL, err := parsedURL.Parse(location)
+	if err != nil {
+		return "", false, fmt.Errorf("resolving redirect location %q: %w", location, err)
+	}
+
+	return nextURL.String(), true, nil
+}
+
+// readBody reads a response body of at most maxBytes, rejecting larger ones without reading them
+// whole when the server announces their length.
+func (f *ImageFetcher) readBody(parsedURL *neturl.URL, resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading %s: status %d", parsedURL.String(), resp.StatusCode)
+	}
+	if resp.ContentLength > f.maxBytes {
+		return nil, fmt.Errorf("downloading %s: %w (%d bytes)", parsedURL.String(), errRemoteFileTooLarge, f.maxBytes)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, f.maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	if int64(len(data)) > f.maxBytes {
+		return nil, fmt.Errorf("downloading %s: %w (%d bytes)", parsedURL.String(), errRemoteFileTooLarge, f.maxBytes)
+	}
+
+	return data, nil
+}
+
+// Close closes the idle connections of the fetcher.
+func (f *ImageFetcher) Close() {
+	if transport, ok := f.client.Transport.(*http.Transport); ok {
+		transport.CloseIdleConnections()
+	}
+}
+
+func isRedirectStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusMovedPermanently,
+		http.StatusFound,
+		http.StatusSeeOther,
+		http.StatusTemporaryRedirect,
+		http.StatusPermanentRedirect:
+		return true
+	default:
+		return false
+	}
+}
+
+// decodeDataURL returns the content of a base64 data URL.
+func decodeDataURL(dataURL string) ([]byte, error) {
+	commaIdx := strings.Index(dataURL, ",")
+	if commaIdx < 0 {
+		return nil, fmt.Errorf("invalid data URL: missing comma separator")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(dataURL[commaIdx+1:])
+	if err != nil {
+		return nil, fmt.Errorf("decoding base64 data URL: %w", err)
+	}
+	return data, nil
+}
+
+// detectImageExt returns the file extension for the detected image type, or "" if not a valid image.
+func detectImageExt(data []byte) string {
+	if len(data) < 4 {
+		return ""
+	}
+	switch {
+	case bytes.HasPrefix(data, []byte{0x89, 0x50, 0x4E, 0x47}):
+		return ".png"
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return ".jpg"
+	case bytes.HasPrefix(data, []byte("GIF8")):
+		return ".gif"
+	case len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return ".webp"
+	case isSVG(data):
+		return ".svg"
+	default:
+		return ""
+	}
+}
+
+// isSVG checks if data looks like an SVG by searching for "<svg" in the first 256 bytes.
+func isSVG(data []byte) bool {
+	limit := len(data)
+	if limit > 256 {
+		limit = 256
+	}
+	return bytes.Contains(bytes.ToLower(data[:limit]), []byte("<svg"))
+}
@@ -0,0 +1,120 @@
+package pdfrenderer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestImageFetcherRejectsOversizedBody(t *testing.T) {
+	t.Parallel()
+
+	fetcher := newRemoteImageTestFetcher(
+		roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			// No Content-Length: the limit applies while reading.
+			return imageResponse(req, append(getPlaceholderPNG(), make([]byte, 64)...)), nil
+		}),
+		map[string][]netip.Addr{"cdn.example": {netip.MustParseAddr("93.184.216.34")}},
+	)
+	fetcher.maxBytes = 32
+
+	if _, err := fetcher.download(context.Background(), "https://cdn.example/huge.png"); !errors.Is(err, errRemoteFileTooLarge) {
+		t.Fatalf("download() error = %v, want errRemoteFileTooLarge", err)
+	}
+}
+
+func TestImageFetcherSniffsTypeOfURLWithoutExtension(t *testing.T) {
+	t.Parallel()
+
+	jpeg := []byte{0xFF, 0xD8, 0xFF, 0xE0, 0, 0x10, 'J', 'F', 'I', 'F'}
+	fetcher := newRemoteImageTestFetcher(
+		roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return imageResponse(req, jpeg), nil
+		}),
+		map[string][]netip.Addr{"cdn.example": {netip.MustParseAddr("93.184.216.34")}},
+	)
+
+	dir, renames, failed, cleanup, err := fetcher.Resolve(context.Background(), map[string]string{
+		"https://cdn.example/avatar?id=7": "img_1",
+	})
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	defer cleanup()
+	if len(failed) != 0 {
+		t.Fatalf("failed = %v, want none", failed)
+	}
+	if renames["img_1"] != "img_1.jpg" {
+		t.Fatalf("renames = %v, want img_1 → img_1.jpg", renames)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "img_1.jpg")); err != nil {
+		t.Fatalf("downloaded image missing: %v", err)
+	}
+}
+
+func TestImageCache_ConcurrentRendersDownloadOnce(t *testing.T) {
+	ic, err := NewImageCache(ImageCacheOptions{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("NewImageCache() error = %v", err)
+	}
+	defer ic.Close()
+
+	var downloads atomic.Int32
+	download := func(_ context.Context, _, destPath string) (string, error) {
+		downloads.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		name := strings.TrimSuffix(filepath.Base(destPath), filepath.Ext(destPath)) + ".png"
+		return name, os.WriteFile(filepath.Join(filepath.Dir(destPath), name), getPlaceholderPNG(), 0o600)
+	}
+	url := "https://cdn.example.com/logo"
+	want := cacheKeyForURL(url) + ".png"
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			renames, failed := ic.ResolveImages(context.Background(), map[string]string{url: "img_1"}, 4, download)
+			if len(failed) != 0 || renames["img_1"] != want {
+				t.Errorf("renames = %v, failed = %v, want img_1 → %s", renames, failed, want)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := downloads.Load(); n != 1 {
+		t.Errorf("downloads = %d, want 1", n)
+	}
+}
+
+func TestImageCache_LookupSkipsExpiredEntries(t *testing.T) {
+	ic, err := NewImageCache(ImageCacheOptions{Dir: t.TempDir(), MaxAge: time.Minute})
+	if err != nil {
+		t.Fatalf("NewImageCache() error = %v", err)
+	}
+	defer ic.Close()
+
+	url := "https://cdn.example.com/logo.png"
+	path, err := ic.Store(url, ".png", getPlaceholderPNG())
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if _, ok := ic.Lookup(url); !ok {
+		t.Fatal("Lookup() missed a fresh entry")
+	}
+
+	old := time.Now().Add(-2 * time.Minute)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ic.Lookup(url); ok {
+		t.Error("Lookup() served an entry older than MaxAge")
+	}
+}
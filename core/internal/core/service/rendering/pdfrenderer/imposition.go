@@ -0,0 +1,210 @@
+package pdfrenderer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+const (
+	ptToMM = 25.4 / 72
+
+	// sourcePDFFile is the rendered PDF placed by second-pass documents, relative to the compile root.
+	sourcePDFFile = "source.pdf"
+
+	cropMarkSlugMM   = 10.0 // Space around the sheet content reserved for crop marks
+	cropMarkGapMM    = 2.0  // Distance between a crop mark and the bleed edge
+	cropMarkStrokePt = 0.25
+)
+
+// impositionLayout holds the sheet geometry derived from imposition options, in millimetres.
+type impositionLayout struct {
+	opts          *port.ImpositionOptions
+	trimW, trimH  float64
+	cellW, cellH  float64 // Trim size plus bleed on every side
+	slug          float64
+	cellsPerSheet int
+}
+
+func newImpositionLayout(opts *port.ImpositionOptions, pageWidthMM, pageHeightMM float64) impositionLayout {
+	l := impositionLayout{opts: opts, trimW: pageWidthMM, trimH: pageHeightMM, cellsPerSheet: 1}
+	if opts.TrimWidthMM > 0 {
+		l.trimW, l.trimH = opts.TrimWidthMM, opts.TrimHeightMM
+	}
+	l.cellW = l.trimW + 2*opts.BleedMM
+	l.cellH = l.trimH + 2*opts.BleedMM
+	if opts.CropMarks {
+		l.slug = cropMarkSlugMM
+	}
+	if opts.Layout != port.ImpositionSingle {
+		l.cellsPerSheet = 2
+	}
+	return l
+}
+
+// sheetSides returns the 1-based source page placed in each cell of each sheet side.
+// Zero marks a blank cell.
+func (l impositionLayout) sheetSides(pageCount int) [][]int {
+	switch l.opts.Layout {
+	case port.ImpositionBooklet:
+		return bookletOrder(pageCount)
+	case port.ImpositionTwoUp:
+		sides := make([][]int, 0, (pageCount+1)/2)
+		for p := 1; p <= pageCount; p += 2 {
+			right := p + 1
+			if right > pageCount {
+				right = 0
+			}
+			sides = append(sides, []int{p, right})
+		}
+		return sides
+	default:
+		sides := make([][]int, pageCount)
+		for p := 1; p <= pageCount; p++ {
+			sides[p-1] = []int{p}
+		}
+		return sides
+	}
+}
+
+// bookletOrder returns saddle-stitch page pairs. Pages are padded with blanks to a multiple of four;
+// printing the sides duplex in order and folding the stack yields the pages in reading order.
+func bookletOrder(pageCount int) [][]int {
+	padded := (pageCount + 3) / 4 * 4
+	page := func(i int) int {
+		if i >= pageCount {
+			return 0
+		}
+		return i + 1
+	}
+
+	sides := make([][]int, padded/2)
+	for k := range sides {
+		outer, inner := page(padded-1-k), page(k)
+		if k%2 == 0 {
+			sides[k] = []int{outer, inner}
+		} else {
+			sides[k] = []int{inner, outer}
+		}
+	}
+	return sides
+}
+
+// source generates the Typst document that places the pages of sourcePDFFile on sheets.
+func (l impositionLayout) source(pageCount int) string {
+	sheetW := 2*l.slug + float64(l.cellsPerSheet)*l.cellW
+	sheetH := 2*l.slug + l.cellH
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "#set page(width: %.2fmm, height: %.2fmm, margin: 0pt)\n\n", sheetW, sheetH)
+	fmt.Fprintf(&sb, "#let cell(p) = box(width: %.2fmm, height: %.2fmm, align(center + horizon, image(%q, page: p, width: %.2fmm, height: %.2fmm, fit: \"contain\")))\n",
+		l.cellW, l.cellH, sourcePDFFile, l.trimW, l.trimH)
+	fmt.Fprintf(&sb, "#let mark(x, y, w, h) = place(top + left, dx: x, dy: y, line(start: (0mm, 0mm), end: (w, h), stroke: %.2fpt))\n\n", cropMarkStrokePt)
+
+	for i, side := range l.sheetSides(pageCount) {
+		if i > 0 {
+			sb.WriteString("#pagebreak()\n")
+		}
+		for c, p := range side {
+			if p == 0 {
+				continue
+			}
+			fmt.Fprintf(&sb, "#place(top + left, dx: %.2fmm, dy: %.2fmm, cell(%d))\n", l.slug+float64(c)*l.cellW, l.slug, p)
+		}
+		if l.opts.CropMarks {
+			sb.WriteString(l.cropMarks())
+		}
+	}
+	return sb.String()
+}
+
+// cropMarks draws marks on the trim lines of every cell, in the slug outside the sheet content.
+func (l impositionLayout) cropMarks() string {
+	markLen := l.slug - cropMarkGapMM
+	bottom := l.slug + l.cellH + cropMarkGapMM
+	right := l.slug + float64(l.cellsPerSheet)*l.cellW + cropMarkGapMM
+	bleed := l.opts.BleedMM
+
+	var sb strings.Builder
+	for c := 0; c < l.cellsPerSheet; c++ {
+		left := l.slug + float64(c)*l.cellW + bleed
+		for _, x := range []float64{left, left + l.trimW} {
+			fmt.Fprintf(&sb, "#mark(%.2fmm, 0mm, 0mm, %.2fmm)\n", x, markLen)
+			fmt.Fprintf(&sb, "#mark(%.2fmm, %.2fmm, 0mm, %.2fmm)\n", x, bottom, markLen)
+		}
+	}
+	top := l.slug + bleed
+	for _, y := range []float64{top, top + l.trimH} {
+		fmt.Fprintf(&sb, "#mark(0mm, %.2fmm, %.2fmm, 0mm)\n", y, markLen)
+		fmt.Fprintf(&sb, "#mark(%.2fmm, %.2fmm, %.2fmm, 0mm)\n", right, y, markLen)
+	}
+	return sb.String()
+}
+
+// sheetCount returns the number of sheet sides produced for pageCount pages.
+func (l impositionLayout) sheetCount(pageCount int) int {
+	return len(l.sheetSides(pageCount))
+}
+
+// impose lays the pages of a rendered PDF out on printer sheets with a second Typst pass.
+func (s *Service) impose(ctx context.Context, pdf []byte, opts *port.ImpositionOptions, pageWidthMM, pageHeightMM float64) ([]byte, int, error) {
+	pageCount, err := countPDFPages(pdf)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	layout := newImpositionLayout(opts, pageWidthMM, pageHeightMM)
+	var imposed []byte
+	err = withSourcePDF(pdf, func(dir string) error {
+		imposed, _, err = s.typst.GeneratePDF(ctx, layout.source(pageCount), dir)
+		return err
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to impose PDF: %w", err)
+	}
+	return imposed, layout.sheetCount(pageCount), nil
+}
+
+// withSourcePDF writes pdf as sourcePDFFile into a temporary compile root and calls fn with it.
+// Placing PDF pages as images requires Typst 0.14 or later.
+func withSourcePDF(pdf []byte, fn func(dir string) error) error {
+	dir, err := os.MkdirTemp("", "typst-source-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.WriteFile(filepath.Join(dir, sourcePDFFile), pdf, 0o600); err != nil {
+		return fmt.Errorf("writing rendered PDF: %w", err)
+	}
+	return fn(dir)
+}
+
+var pdfPageTreeCount = regexp.MustCompile(`/Type\s*/Pages\b[^>]*?/Count\s+(\d+)|/Count\s+(\d+)[^>]*?/Type\s*/Pages\b`)
+
+// countPDFPages reads the page count from the page tree of a PDF written by Typst.
+// The root node of the page tree holds the largest count.
+func countPDFPages(pdf []byte) (int, error) {
+	count := 0
+	for _, m := range pdfPageTreeCount.FindAllSubmatch(pdf, -1) {
+		raw := m[1]
+		if len(raw) == 0 {
+			raw = m[2]
+		}
+		n, err := strconv.Atoi(string(bytes.TrimSpace(raw)))
+		if err == nil && n > count {
+			count = n
+		}
+	}
+	if count == 0 {
+		return 0, fmt.Errorf("counting PDF pages: page tree not found")
+	}
+	return count, nil
+}
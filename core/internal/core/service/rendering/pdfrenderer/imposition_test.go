@@ -0,0 +1,101 @@
+package pdfrenderer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+func TestBookletOrder(t *testing.T) {
+	tests := []struct {
+		pages int
+		want  [][]int
+	}{
+		{4, [][]int{{4, 1}, {2, 3}}},
+		{8, [][]int{{8, 1}, {2, 7}, {6, 3}, {4, 5}}},
+		// Padded to a multiple of four with blank cells.
+		{5, [][]int{{0, 1}, {2, 0}, {0, 3}, {4, 5}}},
+	}
+
+	for _, tt := range tests {
+		if got := bookletOrder(tt.pages); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("bookletOrder(%d) = %v, want %v", tt.pages, got, tt.want)
+		}
+	}
+}
+
+func TestImpositionLayout_TwoUpSides(t *testing.T) {
+	l := newImpositionLayout(&port.ImpositionOptions{Layout: port.ImpositionTwoUp}, 210, 297)
+
+	want := [][]int{{1, 2}, {3, 0}}
+	if got := l.sheetSides(3); !reflect.DeepEqual(got, want) {
+		t.Errorf("sheetSides(3) = %v, want %v", got, want)
+	}
+}
+
+func TestImpositionLayout_Source(t *testing.T) {
+	l := newImpositionLayout(&port.ImpositionOptions{
+		Layout:       port.ImpositionSingle,
+		TrimWidthMM:  100,
+		TrimHeightMM: 150,
+		BleedMM:      3,
+		CropMarks:    true,
+	}, 210, 297)
+
+	src := l.source(2)
+
+	// Sheet = trim + 2*bleed + 2*slug.
+	for _, want := range []string{
+		"#set page(width: 126.00mm, height: 176.00mm, margin: 0pt)",
+		`image("source.pdf", page: p, width: 100.00mm, height: 150.00mm, fit: "contain")`,
+		"#place(top + left, dx: 10.00mm, dy: 10.00mm, cell(2))",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("expected source to contain %q, got:\n%s", want, src)
+		}
+	}
+	if n := strings.Count(src, "#pagebreak()"); n != 1 {
+		t.Errorf("expected 1 page break, got %d", n)
+	}
+	// Two marks per trim line end, four trim lines, on each of the two sheets.
+	if n := strings.Count(src, "#mark("); n != 16 {
+		t.Errorf("expected 16 crop marks, got %d", n)
+	}
+}
+
+func TestImpositionOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    port.ImpositionOptions
+		wantErr bool
+	}{
+		{"booklet", port.ImpositionOptions{Layout: port.ImpositionBooklet}, false},
+		{"unknown layout", port.ImpositionOptions{Layout: "4UP"}, true},
+		{"width without height", port.ImpositionOptions{Layout: port.ImpositionSingle, TrimWidthMM: 100}, true},
+		{"bleed too large", port.ImpositionOptions{Layout: port.ImpositionSingle, BleedMM: 20}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.opts.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCountPDFPages(t *testing.T) {
+	pdf := []byte("1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n" +
+		"2 0 obj\n<< /Type /Pages /Kids [3 0 R 4 0 R 5 0 R] /Count 3 >>\nendobj\n")
+
+	n, err := countPDFPages(pdf)
+	if err != nil || n != 3 {
+		t.Errorf("countPDFPages() = %d, %v, want 3", n, err)
+	}
+
+	if _, err := countPDFPages([]byte("not a pdf")); err == nil {
+		t.Error("expected error for data without a page tree")
+	}
+}
@@ -0,0 +1,31 @@
+package pdfrenderer
+
+import (
+	"fmt"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// applyLayout returns a copy of the document switched to the requested paper size and margin preset.
+// The layout must have been validated against the document's layout variants.
+func applyLayout(doc *portabledoc.Document, layout *port.LayoutParams) *portabledoc.Document {
+	out := *doc
+	if size, ok := portabledoc.StandardPageSizes[layout.PaperSize]; ok {
+		out.PageConfig.FormatID = layout.PaperSize
+		out.PageConfig.Width = size.Width
+		out.PageConfig.Height = size.Height
+	}
+	if margins, ok := portabledoc.MarginPresets[layout.MarginPreset]; ok {
+		out.PageConfig.Margins = margins
+	}
+	return &out
+}
+
+// scaleTypstSize multiplies a Typst length expression (e.g. "12pt") by a font scale.
+func scaleTypstSize(size string, scale float64) string {
+	if scale == 0 || scale == 1 {
+		return size
+	}
+	return fmt.Sprintf("%s * %g", size, scale)
+}
@@ -0,0 +1,71 @@
+package pdfrenderer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+func TestLayoutParams_Validate(t *testing.T) {
+	config := testDoc(nil).PageConfig
+	config.LayoutVariants = &portabledoc.LayoutVariants{
+		PaperSizes:    []string{portabledoc.PageFormatLetter},
+		MarginPresets: []string{portabledoc.MarginPresetNarrow},
+		FontScales:    []float64{1.2},
+	}
+
+	tests := []struct {
+		name    string
+		layout  port.LayoutParams
+		wantErr bool
+	}{
+		{"empty", port.LayoutParams{}, false},
+		{"own format", port.LayoutParams{PaperSize: portabledoc.PageFormatA4, FontScale: 1}, false},
+		{"declared values", port.LayoutParams{PaperSize: portabledoc.PageFormatLetter, MarginPreset: portabledoc.MarginPresetNarrow, FontScale: 1.2}, false},
+		{"undeclared paper", port.LayoutParams{PaperSize: portabledoc.PageFormatLegal}, true},
+		{"undeclared margins", port.LayoutParams{MarginPreset: portabledoc.MarginPresetWide}, true},
+		{"undeclared scale", port.LayoutParams{FontScale: 1.5}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.layout.Validate(&config); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := (&port.LayoutParams{MarginPreset: portabledoc.MarginPresetNormal}).Validate(&testDoc(nil).PageConfig); err == nil {
+		t.Error("expected an error without declared layout variants")
+	}
+}
+
+func TestApplyLayout(t *testing.T) {
+	doc := testDoc(nil)
+	got := applyLayout(doc, &port.LayoutParams{PaperSize: portabledoc.PageFormatLetter, MarginPreset: portabledoc.MarginPresetWide})
+
+	letter := portabledoc.StandardPageSizes[portabledoc.PageFormatLetter]
+	if got.PageConfig.FormatID != portabledoc.PageFormatLetter || got.PageConfig.Width != letter.Width || got.PageConfig.Height != letter.Height {
+		t.Errorf("expected a Letter page, got %+v", got.PageConfig)
+	}
+	if got.PageConfig.Margins != portabledoc.MarginPresets[portabledoc.MarginPresetWide] {
+		t.Errorf("expected wide margins, got %+v", got.PageConfig.Margins)
+	}
+	if doc.PageConfig.FormatID != portabledoc.PageFormatA4 {
+		t.Error("expected the original document to be left unchanged")
+	}
+}
+
+func TestBuild_FontScale(t *testing.T) {
+	b := newTestBuilder()
+	b.SetFontScale(1.1)
+
+	got := b.Build(testDoc(nil))
+
+	for _, want := range []string{"size: 12pt * 1.1,", "heading.where(level: 1): set text(size: 24pt * 1.1"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in output", want)
+		}
+	}
+}
@@ -54,6 +54,13 @@ func newRemoteImageHTTPClient(policy *remoteImagePolicy) *http.Client {
 	}
 }
 
+// PublicDialContext wraps dial so it only connects to publicly routable addresses, checked after
+// DNS resolution like the remote images of renders. Other downloaders of user-supplied URLs use it
+// to apply the same SSRF policy.
+func PublicDialContext(dial func(ctx context.Context, network, address string) (net.Conn, error)) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return newRemoteImagePolicy().secureDialContext(dial)
+}
+
 func (p *remoteImagePolicy) validateURL(ctx context.Context, rawURL string) (*neturl.URL, error) {
 	parsedURL, err := p.parseURL(rawURL)
 	if err != nil {
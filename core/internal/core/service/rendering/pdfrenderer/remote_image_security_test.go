@@ -169,7 +169,7 @@ func TestDownloadRemoteImageFollowsSafeRedirects(t *testing.T) {
 	t.Parallel()
 
 	var requests []string
-	service := newRemoteImageTestService(
+	fetcher := newRemoteImageTestFetcher(
 		roundTripFunc(func(req *http.Request) (*http.Response, error) {
 			requests = append(requests, req.URL.String())
 			switch req.URL.Host {
@@ -188,7 +188,7 @@ func TestDownloadRemoteImageFollowsSafeRedirects(t *testing.T) {
 		},
 	)
 
-	data, err := service.downloadRemoteImage(context.Background(), "https://origin.example/start.png")
+	data, err := fetcher.download(context.Background(), "https://origin.example/start.png")
 	if err != nil {
 		t.Fatalf("unexpected error following redirects: %v", err)
 	}
@@ -204,7 +204,7 @@ func TestDownloadRemoteImageBlocksUnsafeRedirectTarget(t *testing.T) {
 	t.Parallel()
 
 	var requests []string
-	service := newRemoteImageTestService(
+	fetcher := newRemoteImageTestFetcher(
 		roundTripFunc(func(req *http.Request) (*http.Response, error) {
 			requests = append(requests, req.URL.String())
 			return redirectResponse(req, "http://127.0.0.1/secret.png"), nil
@@ -214,7 +214,7 @@ func TestDownloadRemoteImageBlocksUnsafeRedirectTarget(t *testing.T) {
 		},
 	)
 
-	_, err := service.downloadRemoteImage(context.Background(), "https://origin.example/start.png")
+	_, err := fetcher.download(context.Background(), "https://origin.example/start.png")
 	if err == nil {
 		t.Fatal("expected unsafe redirect target to be blocked")
 	}
@@ -227,7 +227,7 @@ func TestDownloadRemoteImageResolvesRelativeRedirect(t *testing.T) {
 	t.Parallel()
 
 	var requests []string
-	service := newRemoteImageTestService(
+	fetcher := newRemoteImageTestFetcher(
 		roundTripFunc(func(req *http.Request) (*http.Response, error) {
 			requests = append(requests, req.URL.String())
 			if strings.HasSuffix(req.URL.Path, "/start.png") {
@@ -240,7 +240,7 @@ func TestDownloadRemoteImageResolvesRelativeRedirect(t *testing.T) {
 		},
 	)
 
-	_, err := service.downloadRemoteImage(context.Background(), "https://cdn.example/assets/start.png")
+	_, err := fetcher.download(context.Background(), "https://cdn.example/assets/start.png")
 	if err != nil {
 		t.Fatalf("unexpected error for relative redirect: %v", err)
 	}
@@ -257,7 +257,7 @@ func TestDownloadRemoteImageRejectsRedirectLoop(t *testing.T) {
 	t.Parallel()
 
 	attempts := 0
-	service := newRemoteImageTestService(
+	fetcher := newRemoteImageTestFetcher(
 		roundTripFunc(func(req *http.Request) (*http.Response, error) {
 			attempts++
 			return redirectResponse(req, "https://loop.example/image.png"), nil
@@ -267,7 +267,7 @@ func TestDownloadRemoteImageRejectsRedirectLoop(t *testing.T) {
 		},
 	)
 
-	_, err := service.downloadRemoteImage(context.Background(), "https://loop.example/image.png")
+	_, err := fetcher.download(context.Background(), "https://loop.example/image.png")
 	if err == nil {
 		t.Fatal("expected redirect loop to be rejected")
 	}
@@ -279,17 +279,17 @@ func TestDownloadRemoteImageRejectsRedirectLoop(t *testing.T) {
 func TestDownloadImagesBlockedRemoteImageUsesPlaceholder(t *testing.T) {
 	t.Parallel()
 
-	service := &Service{}
+	fetcher := NewImageFetcher(ImageFetcherOptions{})
 	dir := t.TempDir()
-	renames, err := service.downloadImages(context.Background(), map[string]string{
+	renames, failed := fetcher.downloadImages(context.Background(), map[string]string{
 		"http://127.0.0.1/secret.png": "blocked.png",
 	}, dir)
-	if err == nil {
-		t.Fatal("expected blocked remote image to produce an error")
-	}
 	if len(renames) != 0 {
 		t.Fatalf("expected no renames, got %#v", renames)
 	}
+	if len(failed) != 1 || failed[0] != "http://127.0.0.1/secret.png" {
+		t.Fatalf("expected the blocked image to be reported as failed, got %#v", failed)
+	}
 
 	data, readErr := os.ReadFile(filepath.Join(dir, "blocked.png"))
 	if readErr != nil {
@@ -303,14 +303,14 @@ func TestDownloadImagesBlockedRemoteImageUsesPlaceholder(t *testing.T) {
 func TestDownloadFileDataURLBypassesRemotePolicy(t *testing.T) {
 	t.Parallel()
 
-	service := &Service{
-		remotePolicy: &remoteImagePolicy{
+	fetcher := &ImageFetcher{
+		policy: &remoteImagePolicy{
 			resolver: &staticResolver{hosts: map[string][]netip.Addr{}},
 		},
 	}
 
 	dataURL := "data:image/png;base64," + base64.StdEncoding.EncodeToString(getPlaceholderPNG())
-	actualName, err := service.downloadFile(context.Background(), dataURL, filepath.Join(t.TempDir(), "image.bin"))
+	actualName, err := fetcher.downloadFile(context.Background(), dataURL, filepath.Join(t.TempDir(), "image.bin"))
 	if err != nil {
 		t.Fatalf("expected data URL download to succeed: %v", err)
 	}
@@ -319,18 +319,20 @@ func TestDownloadFileDataURLBypassesRemotePolicy(t *testing.T) {
 	}
 }
 
-func newRemoteImageTestService(rt http.RoundTripper, hosts map[string][]netip.Addr) *Service {
-	return &Service{
-		httpClient: &http.Client{
+func newRemoteImageTestFetcher(rt http.RoundTripper, hosts map[string][]netip.Addr) *ImageFetcher {
+	return &ImageFetcher{
+		client: &http.Client{
 			Timeout:   15 * time.Second,
 			Transport: rt,
 			CheckRedirect: func(*http.Request, []*http.Request) error {
 				return http.ErrUseLastResponse
 			},
 		},
-		remotePolicy: &remoteImagePolicy{
+		policy: &remoteImagePolicy{
 			resolver: &staticResolver{hosts: hosts},
 		},
+		maxBytes:    DefaultImageMaxBytes,
+		concurrency: DefaultImageFetchConcurrency,
 	}
 }
 
@@ -0,0 +1,83 @@
+package pdfrenderer
+
+import (
+	"maps"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+// repeat calls render once per item of the injectable a repeater node iterates over, with the
+// values of the item in scope (see portabledoc.RepeaterAttrs), and restores the injectables after.
+// Values that are not tables or lists have no items, so the block is left out.
+func (c *TypstConverter) repeat(node portabledoc.Node, render func()) {
+	attrs, err := portabledoc.ParseRepeaterAttrs(node.Attrs)
+	if err != nil || attrs.VariableID == "" {
+		return
+	}
+	outer := c.injectables
+	defer func() { c.injectables = outer }()
+
+	for _, item := range repeaterItems(outer[attrs.VariableID], attrs.ItemAlias()) {
+		scope := maps.Clone(outer)
+		if scope == nil {
+			scope = make(map[string]any, len(item))
+		}
+		maps.Copy(scope, item)
+		c.injectables = scope
+		render()
+	}
+}
+
+// repeaterItems returns the values of each item of a table, list or JSON array, keyed as the
+// variable IDs the block reads them by: "<alias>.<column key>" for table cells and JSON object
+// fields, "<alias>" for list items and other array elements, and "<alias>.index" for the 1-based
+// position of the item.
+func repeaterItems(value any, alias string) []map[string]any {
+	field := func(name string) string { return alias + "." + name }
+	var items []map[string]any
+	switch v := value.(type) {
+	case *entity.TableValue:
+		if v == nil {
+			return nil
+		}
+		for _, row := range v.Rows {
+			item := make(map[string]any, len(v.Columns)+1)
+			col := 0
+			for _, cell := range row.Cells {
+				if col < len(v.Columns) && cell.Value != nil {
+					item[field(v.Columns[col].Key)] = cell.Value.AsAny()
+				}
+				col += max(cell.Colspan, 1)
+			}
+			items = append(items, item)
+		}
+	case *entity.ListValue:
+		if v == nil {
+			return nil
+		}
+		for _, listItem := range v.Items {
+			item := make(map[string]any, 2)
+			if listItem.Value != nil {
+				item[alias] = listItem.Value.AsAny()
+			}
+			items = append(items, item)
+		}
+	case []any:
+		for _, elem := range v {
+			item := make(map[string]any)
+			if fields, ok := elem.(map[string]any); ok {
+				for k, fv := range fields {
+					item[field(k)] = fv
+				}
+			} else {
+				item[alias] = elem
+			}
+			items = append(items, item)
+		}
+	}
+	for i, item := range items {
+		item[field(portabledoc.RepeaterIndexField)] = float64(i + 1)
+	}
+	return items
+}
@@ -3,38 +3,47 @@ package pdfrenderer
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"image"
 	"image/color"
 	"image/png"
-	"io"
 	"log/slog"
-	"net/http"
 	neturl "net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
 	"github.com/rendis/pdf-forge/core/internal/core/port"
 )
 
 // Service implements the PDFRenderer interface using Typst.
 type Service struct {
 	typst          *TypstRenderer
-	httpClient     *http.Client
 	sem            chan struct{}
 	acquireTimeout time.Duration
-	imageCache     *ImageCache
+	images         *ImageFetcher
+	imagesOnce     sync.Once
 	designTokens   TypstDesignTokens
-	remotePolicy   *remoteImagePolicy
+	stats          slotStats
+	fontFallbacks  []FontFallback
+	fontsOnce      sync.Once
+	installedFonts map[string]bool // lowercased families typst finds; nil when they cannot be listed
+	fontFamilies   []string        // families typst finds, sorted
+	metrics        port.Metrics    // nil records nothing
 }
 
-// NewService creates a new PDF renderer service.
-func NewService(opts TypstOptions, imageCache *ImageCache, tokens *TypstDesignTokens) (*Service, error) {
+// NewService creates a new PDF renderer service. A nil images downloads the images of each render
+// with the default limits and no cache.
+func NewService(opts TypstOptions, images *ImageFetcher, tokens *TypstDesignTokens) (*Service, error) {
 	typst, err := NewTypstRenderer(opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create typst renderer: %w", err)
@@ -45,14 +54,22 @@ func NewService(opts TypstOptions, imageCache *ImageCache, tokens *TypstDesignTo
 		dt = *tokens
 	}
 
+	fallbacks := opts.FontFallbacks
+	if fallbacks == nil {
+		fallbacks = DefaultFontFallbacks()
+	}
+	if err := validateFontFallbacks(fallbacks); err != nil {
+		return nil, err
+	}
+
 	s := &Service{
 		typst:          typst,
 		acquireTimeout: opts.AcquireTimeout,
-		imageCache:     imageCache,
+		images:         images,
 		designTokens:   dt,
-		remotePolicy:   newRemoteImagePolicy(),
+		fontFallbacks:  fallbacks,
+		metrics:        opts.Metrics,
 	}
-	s.httpClient = newRemoteImageHTTPClient(s.remotePolicy)
 
 	if opts.MaxConcurrent > 0 {
 		s.sem = make(chan struct{}, opts.MaxConcurrent)
@@ -65,325 +82,424 @@ func NewService(opts TypstOptions, imageCache *ImageCache, tokens *TypstDesignTo
 }
 
 // RenderPreview generates a preview PDF with injected values.
-func (s *Service) RenderPreview(ctx context.Context, req *port.RenderPreviewRequest) (*port.RenderPreviewResult, error) {
+func (s *Service) RenderPreview(ctx context.Context, req *port.RenderPreviewRequest) (_ *port.RenderPreviewResult, err error) {
 	if err := s.acquireSlot(ctx); err != nil {
 		return nil, err
 	}
 	defer s.releaseSlot()
 
-	if req.Document == nil {
-		return nil, fmt.Errorf("document is required")
+	if s.metrics != nil {
+		started := time.Now()
+		defer func() { s.metrics.ObserveRender(time.Since(started), err != nil) }()
 	}
 
-	injectableDefaults := req.InjectableDefaults
-	if injectableDefaults == nil {
-		injectableDefaults = make(map[string]string)
+	if req.Imposition != nil {
+		if err := req.Imposition.Validate(); err != nil {
+			return nil, err
+		}
 	}
-
-	builder := NewTypstBuilder(req.Injectables, injectableDefaults, s.designTokens)
-	if req.ImageURLResolver != nil {
-		builder.SetImageURLResolver(func(url string) (string, error) {
-			return req.ImageURLResolver(ctx, url)
-		})
+	job, err := s.prepare(ctx, req)
+	if err != nil {
+		return nil, err
 	}
-	typstSource := builder.Build(req.Document)
-	slog.DebugContext(ctx, "typst source generated")
-	pageCount := builder.GetPageCount()
+	defer job.close()
+	doc, pageCount := job.doc, job.pageCount
 
-	// Resolve remote images
-	remoteImages := builder.RemoteImages()
-	rootDir, renames, cleanup, err := s.resolveRemoteImages(ctx, remoteImages)
+	compileStarted := time.Now()
+	pdfBytes, compilerWarnings, err := s.typst.GeneratePDF(ctx, job.source, job.rootDir, req.FontDirs...)
+	s.observePhase(port.RenderPhaseCompile, compileStarted)
 	if err != nil {
-		return nil, err
+		s.observeTypstFailure(err)
+		var compileErr *entity.CompileError
+		if errors.As(err, &compileErr) {
+			compileErr.Source = job.source
+			compileErr.Assets = job.assetManifest()
+			job.locate(compileErr)
+		}
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
 	}
-	if cleanup != nil {
-		defer cleanup()
+	warnings := job.warnings
+	for _, w := range compilerWarnings {
+		warnings = append(warnings, entity.RenderWarning{Code: entity.RenderWarningCompiler, Message: w})
 	}
-	for oldName, newName := range renames {
-		typstSource = strings.ReplaceAll(typstSource, oldName, newName)
+
+	if job.externalPDFs {
+		if pageCount, err = countPDFPages(pdfBytes); err != nil {
+			return nil, fmt.Errorf("%w: %w", entity.ErrRenderPostProcessing, err)
+		}
 	}
 
-	pdfBytes, err := s.typst.GeneratePDF(ctx, typstSource, rootDir)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	if req.Imposition != nil {
+		page := doc.PageConfig
+		pdfBytes, pageCount, err = s.impose(ctx, pdfBytes, req.Imposition, page.Width*pxToPt*ptToMM, page.Height*pxToPt*ptToMM)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", entity.ErrRenderPostProcessing, err)
+		}
 	}
 
-	filename := s.generateFilename(req.Document.Meta.Title)
+	filename := s.generateFilename(doc.Meta.Title)
 
 	return &port.RenderPreviewResult{
 		PDF:       pdfBytes,
 		Filename:  filename,
 		PageCount: pageCount,
+		Warnings:  warnings,
 	}, nil
 }
 
-// resolveRemoteImages handles image resolution via cache or direct download.
-// Returns rootDir, renames map, optional cleanup func, and error.
-func (s *Service) resolveRemoteImages(ctx context.Context, images map[string]string) (string, map[string]string, func(), error) {
-	if len(images) == 0 {
-		return "", nil, nil, nil
-	}
-
-	if s.imageCache != nil {
-		renames := s.imageCache.ResolveImages(ctx, images, s.downloadFile)
-		return s.imageCache.Dir(), renames, nil, nil
-	}
-
-	tmpDir, err := os.MkdirTemp("", "typst-images-*")
-	if err != nil {
-		return "", nil, nil, fmt.Errorf("failed to create temp dir: %w", err)
-	}
-
-	renames, dlErr := s.downloadImages(ctx, images, tmpDir)
-	if dlErr != nil {
-		slog.WarnContext(ctx, "some images failed to download", slog.Any("error", dlErr))
+// observePhase records the duration of a render phase started at started.
+func (s *Service) observePhase(phase port.RenderPhase, started time.Time) {
+	if s.metrics != nil {
+		s.metrics.ObserveRenderPhase(phase, time.Since(started))
 	}
-
-	return tmpDir, renames, func() { os.RemoveAll(tmpDir) }, nil
 }
 
-// downloadImages downloads remote images to the given directory.
-// Returns a map of old filename → new filename for cases where the extension was corrected.
-// For failed downloads, creates a 1x1 PNG placeholder so Typst doesn't crash.
-func (s *Service) downloadImages(ctx context.Context, images map[string]string, dir string) (map[string]string, error) {
-	renames := make(map[string]string)
-	var lastErr error
-	for url, filename := range images {
-		dest := filepath.Join(dir, filename)
-		actualName, err := s.downloadFile(ctx, url, dest)
-		if err != nil {
-			slog.WarnContext(ctx, "failed to download image, using placeholder",
-				slog.String("url", url),
-				slog.Any("error", err),
-			)
-			lastErr = err
-			// Use .png for placeholder since it's a real PNG
-			placeholderName := strings.TrimSuffix(filename, filepath.Ext(filename)) + ".png"
-			placeholderDest := filepath.Join(dir, placeholderName)
-			_ = os.WriteFile(placeholderDest, getPlaceholderPNG(), 0o600)
-			if placeholderName != filename {
-				renames[filename] = placeholderName
-			}
-			continue
-		}
-		if actualName != filename {
-			renames[filename] = actualName
-		}
+// observeTypstFailure counts a failed compile by whether it timed out, was canceled or failed.
+func (s *Service) observeTypstFailure(err error) {
+	if s.metrics == nil {
+		return
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		s.metrics.ObserveTypstFailure("timeout")
+	case errors.Is(err, context.Canceled):
+		s.metrics.ObserveTypstFailure("canceled")
+	default:
+		s.metrics.ObserveTypstFailure("error")
 	}
-	return renames, lastErr
 }
 
-var (
-	placeholderPNG     []byte
-	placeholderPNGOnce sync.Once
-)
-
-// getPlaceholderPNG returns a valid 1x1 light gray PNG image.
-func getPlaceholderPNG() []byte {
-	placeholderPNGOnce.Do(func() {
-		img := image.NewRGBA(image.Rect(0, 0, 1, 1))
-		img.Set(0, 0, color.RGBA{R: 220, G: 220, B: 220, A: 255})
-		var buf bytes.Buffer
-		_ = png.Encode(&buf, img)
-		placeholderPNG = buf.Bytes()
-	})
-	return placeholderPNG
+// typstJob is the Typst source generated for a render, with the images it references resolved on disk.
+type typstJob struct {
+	doc          *portabledoc.Document
+	source       string
+	pageCount    int
+	rootDir      string
+	images       []string          // filenames under rootDir referenced by source, sorted
+	sources      map[string]string // image filename → URL it was fetched from
+	externalPDFs bool              // source places external PDFs, whose pages the builder does not count
+	warnings     []entity.RenderWarning
+	cleanup      func()
+	locator      *sourceLocator
+	prefixLen    int // Length of the definitions added in front of the built source
 }
 
-// downloadFile downloads a URL to a local file with the correct extension based on content type.
-// Also handles data: URLs by decoding base64 content directly.
-// Returns the actual filename (basename) used, which may differ from destPath's basename if the
-// extension was corrected to match the real image type.
-func (s *Service) downloadFile(ctx context.Context, url, destPath string) (string, error) {
-	if strings.HasPrefix(url, "data:") {
-		return s.writeDataURL(url, destPath)
+func (j *typstJob) close() {
+	if j.cleanup != nil {
+		j.cleanup()
 	}
+}
 
-	data, err := s.downloadRemoteImage(ctx, url)
-	if err != nil {
-		return "", err
+// locate sets the document node whose output holds the position of a compile error of the job's
+// source. The node is left empty when it cannot be told.
+func (j *typstJob) locate(compileErr *entity.CompileError) {
+	if compileErr.Line == 0 || j.locator == nil {
+		return
 	}
-
-	// Detect real image type from magic bytes
-	realExt := detectImageExt(data)
-	if realExt == "" {
-		slog.WarnContext(ctx, "downloaded content is not a valid image",
-			slog.String("url", url),
-			slog.Int("size", len(data)),
-		)
-		return "", fmt.Errorf("not a valid image: %s", url)
+	offset, ok := sourceOffset(j.source, compileErr.Line, compileErr.Column)
+	if !ok || offset < j.prefixLen {
+		return
 	}
-
-	// Fix extension to match actual content
-	base := strings.TrimSuffix(filepath.Base(destPath), filepath.Ext(destPath))
-	actualName := base + realExt
-	actualPath := filepath.Join(filepath.Dir(destPath), actualName)
-
-	if err := os.WriteFile(actualPath, data, 0o600); err != nil {
-		return "", fmt.Errorf("writing file: %w", err)
+	if m := j.locator.sourceMap(j.source[j.prefixLen:]); m != nil {
+		compileErr.NodePath, compileErr.Injectable = m.nodeAt(offset - j.prefixLen)
 	}
-	return actualName, nil
 }
 
-func (s *Service) downloadRemoteImage(ctx context.Context, rawURL string) ([]byte, error) {
-	s.ensureRemoteImageClient()
-
-	currentURL := rawURL
-	for redirects := 0; redirects <= maxRemoteImageRedirects; redirects++ {
-		parsedURL, resp, err := s.fetchRemoteImageResponse(ctx, currentURL)
-		if err != nil {
-			return nil, err
-		}
-
-		nextURL, redirected, err := resolveRemoteImageRedirect(rawURL, parsedURL, resp, redirects)
-		if err != nil {
+// prepare applies the layout of a render request, generates its Typst source and resolves the
+// remote images the source references.
+func (s *Service) prepare(ctx context.Context, req *port.RenderPreviewRequest) (*typstJob, error) {
+	if req.Document == nil {
+		return nil, fmt.Errorf("document is required")
+	}
+	doc := req.Document
+	if req.Layout != nil {
+		if err := req.Layout.Validate(&doc.PageConfig); err != nil {
 			return nil, err
 		}
-		if redirected {
-			currentURL = nextURL
-			continue
-		}
-
-		return readRemoteImageBody(parsedURL, resp)
+		doc = applyLayout(doc, req.Layout)
 	}
-
-	return nil, fmt.Errorf("downloading %s: too many redirects", rawURL)
-}
-
-func (s *Service) fetchRemoteImageResponse(ctx context.Context, rawURL string) (*neturl.URL, *http.Response, error) {
-	parsedURL, err := s.remotePolicy.validateURL(ctx, rawURL)
+	unknownWarnings, err := checkUnknownNodes(doc, req.UnknownNodes)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsedURL.String(), nil)
-	if err != nil {
-		return nil, nil, fmt.Errorf("creating request: %w", err)
+	injectableDefaults := req.InjectableDefaults
+	if injectableDefaults == nil {
+		injectableDefaults = make(map[string]string)
 	}
 
-	resp, err := s.httpClient.Do(req) //nolint:gosec // Remote-image client disables env proxies, validates public destinations at URL and dial time, and follows redirects manually with revalidation.
-	if err != nil {
-		return nil, nil, fmt.Errorf("downloading %s: %w", parsedURL.String(), err)
+	convertStarted := time.Now()
+	newBuilder := func() *TypstBuilder {
+		builder := NewTypstBuilder(req.Injectables, injectableDefaults, s.designTokens)
+		if req.ImageURLResolver != nil {
+			builder.SetImageURLResolver(func(url string) (string, error) {
+				return req.ImageURLResolver(ctx, url)
+			})
+		}
+		builder.SetWatermark(req.Watermark)
+		builder.SetOverlays(req.Overlays)
+		builder.SetDocumentID(req.DocumentID)
+		builder.SetUnknownNodes(req.UnknownNodes)
+		builder.SetCurrencyConverter(req.ConvertCurrency)
+		if req.Layout != nil {
+			builder.SetFontScale(req.Layout.FontScale)
+		}
+		if req.Imposition != nil {
+			builder.SetOutputFormat(portabledoc.OutputPrint)
+		}
+		return builder
 	}
+	builder := newBuilder()
+	locator := &sourceLocator{doc: doc, newBuilder: newBuilder}
+	typstSource := builder.Build(doc)
+	slog.DebugContext(ctx, "typst source generated")
 
-	return parsedURL, resp, nil
-}
+	fontRules, warnings := fontFallbackRules(typstSource, doc.Meta.Language, s.fontFallbacks, s.installedFontFamilies(ctx))
+	typstSource = fontRules + typstSource
+	warnings = append(unknownWarnings, warnings...)
+	s.observePhase(port.RenderPhaseConvert, convertStarted)
 
-func resolveRemoteImageRedirect(rawURL string, parsedURL *neturl.URL, resp *http.Response, redirects int) (string, bool, error) {
-	if !isRedirectStatus(resp.StatusCode) {
-		return "", false, nil
+	// Resolve remote images and external PDFs
+	fetchStarted := time.Now()
+	defer s.observePhase(port.RenderPhaseImageFetch, fetchStarted)
+	remoteImages := builder.RemoteImages()
+	rootDir, renames, failed, cleanup, err := s.fetcher().Resolve(ctx, remoteImages)
+	if err != nil {
+		return nil, err
 	}
-
-	location := resp.Header.Get("Location")
-	resp.Body.Close()
-
-	if redirects == maxRemoteImageRedirects {
-		return "", false, fmt.Errorf("downloading %s: too many redirects", rawURL)
+	for oldName, newName := range renames {
+		typstSource = locator.replace(typstSource, strconv.Quote(oldName), strconv.Quote(newName))
 	}
-	if location == "" {
-		return "", false, fmt.Errorf("downloading %s: redirect missing location header", parsedURL.String())
+	if len(failed) > 0 && !req.Degraded {
+		if cleanup != nil {
+			cleanup()
+		}
+		return nil, imageFetchError(failed, remoteImages, renames, locator, typstSource[len(fontRules):])
+	}
+	if req.Degraded {
+		slices.Sort(failed)
+		for _, url := range failed {
+			warnings = append(warnings, entity.RenderWarning{
+				Code:    entity.RenderWarningDegradedImage,
+				Message: "image could not be downloaded; a placeholder is shown",
+				Source:  degradationSource(url),
+			})
+		}
 	}
 
-	nextURL, err := parsedURL.Parse(location)
-	if err != nil {
-		return "", false, fmt.Errorf("resolving redirect location %q: %w", location, err)
+	images := make([]string, 0, len(remoteImages))
+	sources := make(map[string]string, len(remoteImages))
+	for url, name := range remoteImages {
+		if renamed, ok := renames[name]; ok {
+			name = renamed
+		}
+		images = append(images, name)
+		sources[name] = url
 	}
 
-	return nextURL.String(), true, nil
+	// Resolve external PDFs
+	prefixLen := len(fontRules)
+	externalPDFs := builder.ExternalPDFs()
+	if len(externalPDFs) > 0 {
+		files, err := s.resolveExternalPDFs(ctx, externalPDFs, rootDir, req.Degraded)
+		if err != nil {
+			if cleanup != nil {
+				cleanup()
+			}
+			var assetErr *entity.AssetFetchError
+			if errors.As(err, &assetErr) {
+				assetErr.NodePaths = externalPDFNodes(assetErr.URL, externalPDFs, locator, typstSource[len(fontRules):])
+			}
+			return nil, err
+		}
+		for _, url := range files.omitted {
+			warnings = append(warnings, entity.RenderWarning{
+				Code:    entity.RenderWarningDegradedExternalPDF,
+				Message: "external PDF could not be downloaded or read; its pages are left out",
+				Source:  degradationSource(url),
+			})
+		}
+		for name, path := range files.paths {
+			typstSource = locator.replace(typstSource, strconv.Quote(name), strconv.Quote(path))
+			images = append(images, path)
+		}
+		for _, ref := range externalPDFs {
+			if path, ok := files.paths[ref.filename]; ok {
+				sources[path] = ref.url
+			}
+		}
+		typstSource = files.prelude + typstSource
+		prefixLen += len(files.prelude)
+		rootDir = files.rootDir
+		cleanup = chainCleanup(files.cleanup, cleanup)
+	}
+	slices.Sort(images)
+
+	return &typstJob{
+		doc:          doc,
+		source:       typstSource,
+		pageCount:    builder.GetPageCount(),
+		rootDir:      rootDir,
+		images:       images,
+		sources:      sources,
+		externalPDFs: len(externalPDFs) > 0,
+		warnings:     warnings,
+		cleanup:      cleanup,
+		locator:      locator,
+		prefixLen:    prefixLen,
+	}, nil
 }
 
-func readRemoteImageBody(parsedURL *neturl.URL, resp *http.Response) ([]byte, error) {
-	if resp.StatusCode != http.StatusOK {
-		resp.Body.Close()
-		return nil, fmt.Errorf("downloading %s: status %d", parsedURL.String(), resp.StatusCode)
-	}
-
-	data, err := io.ReadAll(resp.Body)
-	resp.Body.Close()
-	if err != nil {
-		return nil, fmt.Errorf("reading response: %w", err)
+// imageFetchError reports the images of a render that could not be downloaded, on the nodes that
+// show them. body is the source of the render without the definitions added in front of it.
+func imageFetchError(failed []string, images, renames map[string]string, locator *sourceLocator, body string) error {
+	slices.Sort(failed)
+	m := locator.sourceMap(body)
+	errs := make([]error, 0, len(failed))
+	for _, url := range failed {
+		source := degradationSource(url)
+		assetErr := &entity.AssetFetchError{URL: source, Err: fmt.Errorf("%w: %s", entity.ErrImageUnavailable, source)}
+		if m != nil {
+			name := images[url]
+			if renamed, ok := renames[name]; ok {
+				name = renamed
+			}
+			assetErr.NodePaths = m.nodesOf(body, strconv.Quote(name))
+		}
+		errs = append(errs, assetErr)
 	}
-
-	return data, nil
+	return joinErrors(errs)
 }
 
-func (s *Service) ensureRemoteImageClient() {
-	if s.remotePolicy == nil {
-		s.remotePolicy = newRemoteImagePolicy()
+// externalPDFNodes returns the nodes that place the external PDF of url, its query string left out.
+func externalPDFNodes(url string, refs []externalPDFRef, locator *sourceLocator, body string) []string {
+	m := locator.sourceMap(body)
+	if m == nil {
+		return nil
 	}
-	if s.httpClient == nil {
-		s.httpClient = newRemoteImageHTTPClient(s.remotePolicy)
+	var paths []string
+	for _, ref := range refs {
+		if degradationSource(ref.url) == url {
+			paths = append(paths, m.nodesOf(body, strconv.Quote(ref.filename))...)
+		}
 	}
+	return paths
 }
 
-func isRedirectStatus(statusCode int) bool {
-	switch statusCode {
-	case http.StatusMovedPermanently,
-		http.StatusFound,
-		http.StatusSeeOther,
-		http.StatusTemporaryRedirect,
-		http.StatusPermanentRedirect:
-		return true
-	default:
-		return false
+// joinErrors joins errs into one error whose message lists them on one line.
+func joinErrors(errs []error) error {
+	if len(errs) == 1 {
+		return errs[0]
 	}
+	args := make([]any, len(errs))
+	for i, err := range errs {
+		args[i] = err
+	}
+	return fmt.Errorf(strings.TrimSuffix(strings.Repeat("%w; ", len(errs)), "; "), args...)
 }
 
-// writeDataURL decodes a base64 data URL and writes the image to disk.
-func (s *Service) writeDataURL(dataURL, destPath string) (string, error) {
-	commaIdx := strings.Index(dataURL, ",")
-	if commaIdx < 0 {
-		return "", fmt.Errorf("invalid data URL: missing comma separator")
-	}
+// assetManifest describes the files the job's source references, for the capture of a failed compile.
+// Data URLs and query strings, which may hold signed credentials, are not kept.
+func (j *typstJob) assetManifest() []entity.RenderFailureAsset {
+	assets := make([]entity.RenderFailureAsset, 0, len(j.images))
+	for _, name := range j.images {
+		asset := entity.RenderFailureAsset{Name: name}
+		if u, err := neturl.Parse(j.sources[name]); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+			u.RawQuery, u.Fragment, u.User = "", "", nil
+			asset.URL = u.String()
+		}
 
-	data, err := base64.StdEncoding.DecodeString(dataURL[commaIdx+1:])
-	if err != nil {
-		return "", fmt.Errorf("decoding base64 data URL: %w", err)
+		data, err := os.ReadFile(filepath.Join(j.rootDir, name))
+		if err != nil {
+			asset.Missing = true
+		} else {
+			sum := sha256.Sum256(data)
+			asset.SizeBytes = len(data)
+			asset.SHA256 = hex.EncodeToString(sum[:])
+		}
+		assets = append(assets, asset)
 	}
+	return assets
+}
 
-	realExt := detectImageExt(data)
-	if realExt == "" {
-		return "", fmt.Errorf("data URL does not contain a valid image")
-	}
+// installedFontFamilies returns the lowercased font families typst finds, listed once per service.
+// Nil when typst cannot list them.
+func (s *Service) installedFontFamilies(ctx context.Context) map[string]bool {
+	s.fontsOnce.Do(func() {
+		if s.typst == nil {
+			return
+		}
+		families, err := s.typst.FontFamilies(context.WithoutCancel(ctx))
+		if err != nil {
+			slog.WarnContext(ctx, "failed to list typst fonts", slog.Any("error", err))
+			return
+		}
+		s.installedFonts = make(map[string]bool, len(families))
+		for _, f := range families {
+			s.installedFonts[strings.ToLower(f)] = true
+		}
+		s.fontFamilies = slices.Sorted(slices.Values(families))
+	})
+	return s.installedFonts
+}
 
-	base := strings.TrimSuffix(filepath.Base(destPath), filepath.Ext(destPath))
-	actualName := base + realExt
-	actualPath := filepath.Join(filepath.Dir(destPath), actualName)
+// FontFamilies returns the font families typst finds, sorted, for the editor font picker.
+// Nil when typst cannot list them.
+func (s *Service) FontFamilies(ctx context.Context) []string {
+	s.installedFontFamilies(ctx)
+	return s.fontFamilies
+}
 
-	if err := os.WriteFile(actualPath, data, 0o600); err != nil {
-		return "", fmt.Errorf("writing data URL file: %w", err)
+// chainCleanup returns a func that runs the non-nil cleanups in order.
+func chainCleanup(cleanups ...func()) func() {
+	return func() {
+		for _, cleanup := range cleanups {
+			if cleanup != nil {
+				cleanup()
+			}
+		}
 	}
-	return actualName, nil
 }
 
-// detectImageExt returns the file extension for the detected image type, or "" if not a valid image.
-func detectImageExt(data []byte) string {
-	if len(data) < 4 {
-		return ""
+// degradationSource returns the URL of a file a degraded render left out, without the query
+// string, fragment or user info, which may hold signed credentials.
+func degradationSource(rawURL string) string {
+	if strings.HasPrefix(rawURL, "data:") {
+		return "data URL"
 	}
-	switch {
-	case bytes.HasPrefix(data, []byte{0x89, 0x50, 0x4E, 0x47}):
-		return ".png"
-	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
-		return ".jpg"
-	case bytes.HasPrefix(data, []byte("GIF8")):
-		return ".gif"
-	case len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WEBP":
-		return ".webp"
-	case isSVG(data):
-		return ".svg"
-	default:
+	u, err := neturl.Parse(rawURL)
+	if err != nil {
 		return ""
 	}
+	u.RawQuery, u.Fragment, u.User = "", "", nil
+	return u.String()
 }
 
-// isSVG checks if data looks like an SVG by searching for "<svg" in the first 256 bytes.
-func isSVG(data []byte) bool {
-	limit := len(data)
-	if limit > 256 {
-		limit = 256
-	}
-	return bytes.Contains(bytes.ToLower(data[:limit]), []byte("<svg"))
+var (
+	placeholderPNG     []byte
+	placeholderPNGOnce sync.Once
+)
+
+// getPlaceholderPNG returns a valid 1x1 light gray PNG image.
+func getPlaceholderPNG() []byte {
+	placeholderPNGOnce.Do(func() {
+		img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+		img.Set(0, 0, color.RGBA{R: 220, G: 220, B: 220, A: 255})
+		var buf bytes.Buffer
+		_ = png.Encode(&buf, img)
+		placeholderPNG = buf.Bytes()
+	})
+	return placeholderPNG
+}
+
+// fetcher returns the image fetcher of the service, a default one for services built without NewService.
+func (s *Service) fetcher() *ImageFetcher {
+	s.imagesOnce.Do(func() {
+		if s.images == nil {
+			s.images = NewImageFetcher(ImageFetcherOptions{})
+		}
+	})
+	return s.images
 }
 
 // generateFilename creates a safe filename from the document title.
@@ -413,22 +529,31 @@ func (s *Service) generateFilename(title string) string {
 // acquireSlot blocks until a render slot is available or the timeout expires.
 func (s *Service) acquireSlot(ctx context.Context) error {
 	if s.sem == nil {
+		s.stats.active.Add(1)
 		return nil
 	}
+	started := time.Now()
+	s.stats.waiting.Add(1)
+	defer s.stats.waiting.Add(-1)
+
 	timer := time.NewTimer(s.acquireTimeout)
 	defer timer.Stop()
 	select {
 	case s.sem <- struct{}{}:
+		s.stats.active.Add(1)
+		s.stats.recordWait(time.Now(), time.Since(started))
 		return nil
 	case <-ctx.Done():
 		return ctx.Err()
 	case <-timer.C:
+		s.stats.rejected.Add(1)
 		return entity.ErrRendererBusy
 	}
 }
 
 // releaseSlot returns a render slot to the pool.
 func (s *Service) releaseSlot() {
+	s.stats.active.Add(-1)
 	if s.sem == nil {
 		return
 	}
@@ -437,10 +562,8 @@ func (s *Service) releaseSlot() {
 
 // Close releases resources held by the service.
 func (s *Service) Close() error {
-	if s.httpClient != nil {
-		if transport, ok := s.httpClient.Transport.(*http.Transport); ok {
-			transport.CloseIdleConnections()
-		}
+	if s.images != nil {
+		s.images.Close()
 	}
 	if s.typst != nil {
 		return s.typst.Close()
@@ -450,3 +573,9 @@ func (s *Service) Close() error {
 
 // Ensure Service implements port.PDFRenderer
 var _ port.PDFRenderer = (*Service)(nil)
+
+// Ensure Service implements port.RenderCapacityReporter
+var _ port.RenderCapacityReporter = (*Service)(nil)
+
+// Ensure Service implements port.EditorSchemaProvider
+var _ port.EditorSchemaProvider = (*Service)(nil)
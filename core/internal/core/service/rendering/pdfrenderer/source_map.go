@@ -0,0 +1,209 @@
+package pdfrenderer
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+// Source maps tell which document node produced a part of a generated Typst source, so a compile
+// error or a failed file can be reported on the node to fix. They are only built when a render
+// fails: the document is converted again with the output of each node wrapped in source marks, and
+// the map is kept only when the source without the marks is the one that failed.
+
+// sourcePathAttr is the attribute annotateSourcePaths sets on every node of the content.
+const sourcePathAttr = "__sourcePath"
+
+// Source marks: markOpen, the node path, markPath, the node output, markClose.
+const (
+	markOpen  = '\x01'
+	markPath  = '\x02'
+	markClose = '\x03'
+)
+
+// markSource wraps the output of a node in source marks. Leading and trailing whitespace stays
+// outside the marks, so parents that trim or test their content see the same text.
+func (c *TypstConverter) markSource(node portabledoc.Node, out string) string {
+	if !c.sourceMarks {
+		return out
+	}
+	path, _ := node.Attrs[sourcePathAttr].(string)
+	core := strings.TrimSpace(out)
+	if path == "" || core == "" {
+		return out
+	}
+	start := len(out) - len(strings.TrimLeftFunc(out, unicode.IsSpace))
+	end := start + len(core)
+	return out[:start] + string(markOpen) + path + string(markPath) + core + string(markClose) + out[end:]
+}
+
+// annotateSourcePaths returns a copy of doc whose content nodes carry their JSON path in
+// sourcePathAttr, and the injectable shown by each injector node, by path.
+func annotateSourcePaths(doc *portabledoc.Document) (*portabledoc.Document, map[string]string) {
+	annotated := *doc
+	injectables := make(map[string]string)
+	if doc.Content == nil {
+		return &annotated, injectables
+	}
+	content := *doc.Content
+	content.Content = cloneNodes(doc.Content.Content)
+	annotated.Content = &content
+
+	var walk func(nodes []portabledoc.Node, path string)
+	walk = func(nodes []portabledoc.Node, path string) {
+		for i := range nodes {
+			nodePath := path + ".content[" + strconv.Itoa(i) + "]"
+			if nodes[i].Attrs == nil {
+				nodes[i].Attrs = make(map[string]any, 1)
+			}
+			nodes[i].Attrs[sourcePathAttr] = nodePath
+			switch nodes[i].Type {
+			case portabledoc.NodeTypeInjector, portabledoc.NodeTypeListInjector, portabledoc.NodeTypeTableInjector:
+				if id, _ := nodes[i].Attrs["variableId"].(string); id != "" {
+					injectables[nodePath] = id
+				}
+			}
+			walk(nodes[i].Content, nodePath)
+		}
+	}
+	walk(content.Content, "content")
+	return &annotated, injectables
+}
+
+// sourceSpan is the byte range of the output of a node in a source without marks.
+type sourceSpan struct {
+	start, end int
+	path       string
+}
+
+// sourceMap holds the spans of the nodes of a generated source.
+type sourceMap struct {
+	spans       []sourceSpan
+	injectables map[string]string // Injectable shown by each injector node, by path
+}
+
+// stripSourceMarks removes the source marks of marked and returns the spans they delimited.
+func stripSourceMarks(marked string) (string, []sourceSpan) {
+	var (
+		out   strings.Builder
+		spans []sourceSpan
+		open  []int // Indexes in spans of the spans not closed yet
+	)
+	out.Grow(len(marked))
+	for i := 0; i < len(marked); i++ {
+		switch marked[i] {
+		case markOpen:
+			end := strings.IndexByte(marked[i:], markPath)
+			if end < 0 {
+				return marked, nil
+			}
+			open = append(open, len(spans))
+			spans = append(spans, sourceSpan{start: out.Len(), path: marked[i+1 : i+end]})
+			i += end
+		case markClose:
+			if len(open) == 0 {
+				return marked, nil
+			}
+			spans[open[len(open)-1]].end = out.Len()
+			open = open[:len(open)-1]
+		default:
+			out.WriteByte(marked[i])
+		}
+	}
+	if len(open) > 0 {
+		return marked, nil
+	}
+	return out.String(), spans
+}
+
+// nodeAt returns the path of the innermost node whose output holds offset, and the injectable it
+// shows when it is an injector. It returns "" when no node does.
+func (m *sourceMap) nodeAt(offset int) (path, injectable string) {
+	best := -1
+	for i, span := range m.spans {
+		if offset < span.start || offset >= span.end {
+			continue
+		}
+		if best < 0 || span.end-span.start < m.spans[best].end-m.spans[best].start {
+			best = i
+		}
+	}
+	if best < 0 {
+		return "", ""
+	}
+	path = m.spans[best].path
+	return path, m.injectables[path]
+}
+
+// nodesOf returns the paths of the innermost nodes whose output holds s, once each, in order.
+func (m *sourceMap) nodesOf(source, s string) []string {
+	var paths []string
+	for offset := 0; ; {
+		i := strings.Index(source[offset:], s)
+		if i < 0 {
+			return paths
+		}
+		offset += i
+		if path, _ := m.nodeAt(offset); path != "" && !slices.Contains(paths, path) {
+			paths = append(paths, path)
+		}
+		offset += len(s)
+	}
+}
+
+// sourceLocator builds the source map of a job.
+type sourceLocator struct {
+	doc          *portabledoc.Document
+	newBuilder   func() *TypstBuilder
+	replacements []string // Old and new strings the job replaced in the built source, in order
+}
+
+// replace replaces from with to in source, recording the replacement to replay it on the source
+// with marks.
+func (l *sourceLocator) replace(source, from, to string) string {
+	l.replacements = append(l.replacements, from, to)
+	return strings.ReplaceAll(source, from, to)
+}
+
+// sourceMap converts the document again with source marks and replays the replacements. It
+// returns nil when the source without marks differs from body, the source of the job without
+// what was added in front of it.
+func (l *sourceLocator) sourceMap(body string) *sourceMap {
+	doc, injectables := annotateSourcePaths(l.doc)
+	builder := l.newBuilder()
+	builder.converter.sourceMarks = true
+	marked := builder.Build(doc)
+	for i := 0; i+1 < len(l.replacements); i += 2 {
+		marked = strings.ReplaceAll(marked, l.replacements[i], l.replacements[i+1])
+	}
+	clean, spans := stripSourceMarks(marked)
+	if clean != body || len(spans) == 0 {
+		return nil
+	}
+	return &sourceMap{spans: spans, injectables: injectables}
+}
+
+// sourceOffset returns the byte offset of a line and column of source, both counted from 1 and
+// the column in characters, as Typst reports them.
+func sourceOffset(source string, line, column int) (int, bool) {
+	offset := 0
+	for range line - 1 {
+		i := strings.IndexByte(source[offset:], '\n')
+		if i < 0 {
+			return 0, false
+		}
+		offset += i + 1
+	}
+	for range column - 1 {
+		if offset >= len(source) || source[offset] == '\n' {
+			break
+		}
+		_, size := utf8.DecodeRuneInString(source[offset:])
+		offset += size
+	}
+	return offset, true
+}
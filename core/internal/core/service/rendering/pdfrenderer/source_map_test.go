@@ -0,0 +1,155 @@
+package pdfrenderer
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+func sourceMapTestDocument(nodes ...portabledoc.Node) *portabledoc.Document {
+	return &portabledoc.Document{
+		Version: portabledoc.CurrentVersion,
+		Meta:    portabledoc.Meta{Title: "Contract", Language: "en"},
+		PageConfig: portabledoc.PageConfig{
+			FormatID: portabledoc.PageFormatA4,
+			Width:    794,
+			Height:   1123,
+			Margins:  portabledoc.Margins{Top: 96, Bottom: 96, Left: 72, Right: 72},
+		},
+		Content: &portabledoc.ProseMirrorDoc{Type: portabledoc.NodeTypeDoc, Content: nodes},
+	}
+}
+
+func sourceMapTestContent() []portabledoc.Node {
+	return []portabledoc.Node{
+		{Type: portabledoc.NodeTypeHeading, Attrs: map[string]any{"level": float64(1)}, Content: []portabledoc.Node{
+			{Type: portabledoc.NodeTypeText, Text: strPtr("Service agreement")},
+		}},
+		{Type: portabledoc.NodeTypeParagraph, Content: []portabledoc.Node{
+			{Type: portabledoc.NodeTypeText, Text: strPtr("Between ")},
+			{Type: portabledoc.NodeTypeInjector, Attrs: map[string]any{"type": "TEXT", "label": "Client", "variableId": "client_name"}},
+			{Type: portabledoc.NodeTypeText, Text: strPtr(" and us.")},
+		}},
+		{Type: portabledoc.NodeTypeBulletList, Content: []portabledoc.Node{
+			{Type: portabledoc.NodeTypeListItem, Content: []portabledoc.Node{
+				{Type: portabledoc.NodeTypeParagraph, Content: []portabledoc.Node{
+					{Type: portabledoc.NodeTypeText, Text: strPtr("First clause")},
+				}},
+			}},
+		}},
+	}
+}
+
+func TestSourceMap_NodeAt(t *testing.T) {
+	doc := sourceMapTestDocument(sourceMapTestContent()...)
+	injectables := map[string]any{"client_name": "Ada Lovelace"}
+	newBuilder := func() *TypstBuilder {
+		return NewTypstBuilder(injectables, map[string]string{}, DefaultDesignTokens())
+	}
+	source := newBuilder().Build(doc)
+
+	m := (&sourceLocator{doc: doc, newBuilder: newBuilder}).sourceMap(source)
+	if m == nil {
+		t.Fatal("sourceMap() = nil, want the spans of the nodes")
+	}
+	tests := []struct {
+		text, path, injectable string
+	}{
+		{"Ada Lovelace", "content.content[1].content[1]", "client_name"},
+		{"Between", "content.content[1].content[0]", ""},
+		{"First clause", "content.content[2].content[0].content[0]", ""}, // List items convert the text of their paragraph
+	}
+	for _, tt := range tests {
+		path, injectable := m.nodeAt(strings.Index(source, tt.text))
+		if path != tt.path || injectable != tt.injectable {
+			t.Errorf("nodeAt(%q) = %q, %q; want %q, %q", tt.text, path, injectable, tt.path, tt.injectable)
+		}
+	}
+	if path, _ := m.nodeAt(0); path != "" {
+		t.Errorf("nodeAt(0) = %q, want no node for the preamble", path)
+	}
+	if doc.Content.Content[1].Content[1].Attrs[sourcePathAttr] != nil {
+		t.Error("the document of the render must not be annotated")
+	}
+}
+
+func TestSourceMap_MismatchIsNil(t *testing.T) {
+	doc := sourceMapTestDocument(sourceMapTestContent()...)
+	newBuilder := func() *TypstBuilder {
+		return NewTypstBuilder(nil, map[string]string{}, DefaultDesignTokens())
+	}
+	if m := (&sourceLocator{doc: doc, newBuilder: newBuilder}).sourceMap("#set page()\n"); m != nil {
+		t.Errorf("sourceMap() = %v, want nil for another source", m.spans)
+	}
+}
+
+func TestSourceOffset(t *testing.T) {
+	source := "first\nséptimo x\nthird"
+	offset, ok := sourceOffset(source, 2, 9)
+	if !ok || source[offset:offset+1] != "x" {
+		t.Errorf("sourceOffset(2, 9) = %d, %v; want the offset of x", offset, ok)
+	}
+	if _, ok := sourceOffset(source, 5, 1); ok {
+		t.Error("sourceOffset() past the last line must fail")
+	}
+}
+
+func TestTypstJob_LocateCompileError(t *testing.T) {
+	s := &Service{designTokens: DefaultDesignTokens()}
+	job, err := s.prepare(context.Background(), &port.RenderPreviewRequest{
+		Document:    sourceMapTestDocument(sourceMapTestContent()...),
+		Injectables: map[string]any{"client_name": "Ada Lovelace"},
+	})
+	if err != nil {
+		t.Fatalf("prepare() error = %v", err)
+	}
+	defer job.close()
+
+	offset := strings.Index(job.source, "Ada Lovelace")
+	line := strings.Count(job.source[:offset], "\n") + 1
+	column := len([]rune(job.source[strings.LastIndexByte(job.source[:offset], '\n')+1:offset])) + 1
+
+	compileErr := &entity.CompileError{Line: line, Column: column, Err: errors.New("exit status 1")}
+	job.locate(compileErr)
+	if compileErr.NodePath != "content.content[1].content[1]" || compileErr.Injectable != "client_name" {
+		t.Errorf("locate() = %q, %q; want the injector node", compileErr.NodePath, compileErr.Injectable)
+	}
+}
+
+func TestPrepare_ImageFetchFailed(t *testing.T) {
+	const url = "http://127.0.0.1/logo.png?token=secret"
+	doc := sourceMapTestDocument(
+		portabledoc.Node{Type: portabledoc.NodeTypeParagraph, Content: []portabledoc.Node{
+			{Type: portabledoc.NodeTypeText, Text: strPtr("Logo")},
+		}},
+		portabledoc.Node{Type: portabledoc.NodeTypeImage, Attrs: map[string]any{"src": url}},
+	)
+	s := &Service{designTokens: DefaultDesignTokens()}
+
+	_, err := s.prepare(context.Background(), &port.RenderPreviewRequest{Document: doc})
+	var assetErr *entity.AssetFetchError
+	if !errors.As(err, &assetErr) || !errors.Is(err, entity.ErrImageUnavailable) {
+		t.Fatalf("prepare() error = %v, want an AssetFetchError for the image", err)
+	}
+	if assetErr.URL != "http://127.0.0.1/logo.png" {
+		t.Errorf("URL = %q, want it without the query string", assetErr.URL)
+	}
+	if !slices.Equal(assetErr.NodePaths, []string{"content.content[1]"}) {
+		t.Errorf("NodePaths = %v, want the image node", assetErr.NodePaths)
+	}
+
+	job, err := s.prepare(context.Background(), &port.RenderPreviewRequest{Document: doc, Degraded: true})
+	if err != nil {
+		t.Fatalf("degraded prepare() error = %v", err)
+	}
+	defer job.close()
+	if len(job.warnings) != 1 || job.warnings[0].Code != entity.RenderWarningDegradedImage {
+		t.Errorf("warnings = %v, want the degraded image", job.warnings)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
 )
 
@@ -35,6 +36,9 @@ type surfaceRenderMetrics struct {
 type TypstBuilder struct {
 	converter *TypstConverter
 	tokens    TypstDesignTokens
+	watermark string
+	// overlayOverrides are the overlays of the render request, replacing those of the page config.
+	overlayOverrides *portabledoc.Overlays
 }
 
 // NewTypstBuilder creates a new Typst builder.
@@ -53,23 +57,42 @@ func NewTypstBuilder(
 func (b *TypstBuilder) Build(doc *portabledoc.Document) string {
 	var sb strings.Builder
 
+	b.converter.defaultLang = doc.Meta.Language
+	b.converter.unitSystem = doc.Meta.UnitSystem
+	b.converter.setDocumentDates(doc.Meta)
+	b.converter.documentTitle = doc.Meta.Title
+
 	// Package imports
 	sb.WriteString("#import \"@preview/wrap-it:0.1.1\": wrap-content\n\n")
 
 	// Page configuration
 	sb.WriteString(b.pageSetup(&doc.PageConfig, doc.HeaderEnabled(), doc.FooterEnabled()))
 
+	if overlays := b.overlays(&doc.PageConfig); overlays != nil {
+		sb.WriteString(b.overlaysSetup(overlays))
+	}
+
+	if doc.PageConfig.PageStamp != nil {
+		sb.WriteString(b.pageStampSetup(doc.PageConfig.PageStamp))
+	}
+
 	// Base typography
 	sb.WriteString(b.typographySetup())
 
 	// Heading styles
 	sb.WriteString(b.headingStyles())
 
+	if doc.HasNodeOfType(portabledoc.NodeTypeReferences) {
+		sb.WriteString(referencesPreamble)
+		b.converter.enableReferences(doc)
+	}
+
 	// Set content area width for table column calculations
 	b.converter.contentWidthPx = doc.PageConfig.Width - doc.PageConfig.Margins.Left - doc.PageConfig.Margins.Right
+	b.converter.contentHeightPx = contentHeightPx(&doc.PageConfig, doc.HeaderEnabled(), doc.FooterEnabled())
 
 	// Header/footer as native page header/footer — must be #set rules before content.
-	// Header renders only on page 1, footer only on the last page.
+	// Header renders on page 1 and footer on the last page unless their pages say otherwise.
 	// Margins reserve space on ALL pages for consistent text flow area.
 	if doc.HeaderEnabled() {
 		sb.WriteString(b.headerBlock(doc))
@@ -86,6 +109,18 @@ func (b *TypstBuilder) Build(doc *portabledoc.Document) string {
 	return sb.String()
 }
 
+// contentHeightPx returns the height of the page content area, with the margins set by pageSetup.
+func contentHeightPx(config *portabledoc.PageConfig, hasHeader, hasFooter bool) float64 {
+	top, bottom := config.Margins.Top, config.Margins.Bottom
+	if hasHeader {
+		top = surfaceMinHeightPx
+	}
+	if hasFooter {
+		bottom = surfaceMinHeightPx
+	}
+	return config.Height - top - bottom
+}
+
 // pageSetup generates #set page(...) directive from PageConfig.
 // When hasHeader is true, top margin is halved.
 // When hasFooter is true, bottom margin is halved.
@@ -179,6 +214,52 @@ func (b *TypstBuilder) SetImageURLResolver(fn func(url string) (string, error))
 	b.converter.imageURLResolver = fn
 }
 
+// SetWatermark sets text stamped across every page, taking precedence over the overlays.
+// Empty keeps the watermark of the overlays.
+func (b *TypstBuilder) SetWatermark(text string) {
+	b.watermark = text
+}
+
+// SetOverlays sets the overlays of the render request. Each overlay set replaces the one of the
+// document page config.
+func (b *TypstBuilder) SetOverlays(overlays *portabledoc.Overlays) {
+	b.overlayOverrides = overlays
+}
+
+// SetDocumentID sets the identifier of the generated document, which seeds security patterns.
+func (b *TypstBuilder) SetDocumentID(id string) {
+	b.converter.documentID = id
+}
+
+// SetOutputFormat sets the output being rendered (portabledoc.OutputPDF or OutputPrint),
+// which decides the nodes restricted by their visibility attr.
+func (b *TypstBuilder) SetOutputFormat(format string) {
+	b.converter.outputFormat = format
+}
+
+// SetUnknownNodes sets how nodes of an unknown type are rendered. Only PLACEHOLDER changes the
+// output; failing renders are stopped before the source is built.
+func (b *TypstBuilder) SetUnknownNodes(mode entity.UnknownNodeMode) {
+	b.converter.unknownPlaceholders = mode == entity.UnknownNodesPlaceholder
+}
+
+// SetFontScale multiplies the base, heading and inline font sizes. Zero or 1 keeps them.
+func (b *TypstBuilder) SetFontScale(scale float64) {
+	if scale == 0 || scale == 1 {
+		return
+	}
+	b.tokens.BaseFontSize = scaleTypstSize(b.tokens.BaseFontSize, scale)
+	for i, size := range b.tokens.HeadingSizes {
+		b.tokens.HeadingSizes[i] = scaleTypstSize(size, scale)
+	}
+	b.converter.fontScale = scale
+}
+
+// SetCurrencyConverter sets the currency conversion behind convert() in table formulas.
+func (b *TypstBuilder) SetCurrencyConverter(fn entity.CurrencyConvertFunc) {
+	b.converter.convertCurrency = fn
+}
+
 // GetPageCount returns the page count based on page breaks encountered.
 func (b *TypstBuilder) GetPageCount() int {
 	return b.converter.GetCurrentPage()
@@ -189,6 +270,11 @@ func (b *TypstBuilder) RemoteImages() map[string]string {
 	return b.converter.RemoteImages()
 }
 
+// ExternalPDFs returns the externalPdf nodes collected during build.
+func (b *TypstBuilder) ExternalPDFs() []externalPDFRef {
+	return b.converter.externalPDFs
+}
+
 // renderSurfaceContent resolves text, image, and layout for a surface and returns
 // the inner Typst content string. Returns "" if the surface produces no visible output.
 func (b *TypstBuilder) renderSurfaceContent(
@@ -220,8 +306,8 @@ func (b *TypstBuilder) renderSurfaceContent(
 	return content
 }
 
-// headerBlock generates a #set page(header: ...) directive that renders the header
-// only on the first page using Typst's native page header mechanism.
+// headerBlock generates a #set page(header: ...) directive that renders the header on the
+// pages it is set to, the first by default, using Typst's native page header mechanism.
 func (b *TypstBuilder) headerBlock(doc *portabledoc.Document) string {
 	h := doc.Header
 	if h == nil || !h.Enabled {
@@ -237,8 +323,9 @@ func (b *TypstBuilder) headerBlock(doc *portabledoc.Document) string {
 	// align(top) is required because Typst bottom-aligns header content by default.
 	return fmt.Sprintf(
 		"#set page(header: context {\n"+
+			"  let total = counter(page).final().first()\n"+
 			"  let current = counter(page).get().first()\n"+
-			"  if current == 1 [\n"+
+			"  if %s [\n"+
 			"    #block(width: 100%%, height: %.1fpt, inset: (top: %.1fpt, bottom: %.1fpt), clip: true)[\n"+
 			"      #align(top)[\n"+
 			"%s"+
@@ -246,6 +333,7 @@ func (b *TypstBuilder) headerBlock(doc *portabledoc.Document) string {
 			"    ]\n"+
 			"  ]\n"+
 			"})\n\n",
+		surfacePageCondition(h.Pages, portabledoc.SurfacePagesFirst),
 		metrics.surfaceMinHeightPt,
 		metrics.surfaceVerticalPadPt,
 		metrics.surfaceVerticalPadPt,
@@ -253,8 +341,8 @@ func (b *TypstBuilder) headerBlock(doc *portabledoc.Document) string {
 	)
 }
 
-// footerBlock generates a #set page(footer: ...) directive that renders the footer
-// only on the last page using Typst's native page footer mechanism.
+// footerBlock generates a #set page(footer: ...) directive that renders the footer on the
+// pages it is set to, the last by default, using Typst's native page footer mechanism.
 func (b *TypstBuilder) footerBlock(doc *portabledoc.Document) string {
 	f := doc.Footer
 	if f == nil || !f.Enabled {
@@ -272,7 +360,7 @@ func (b *TypstBuilder) footerBlock(doc *portabledoc.Document) string {
 		"#set page(footer: context {\n"+
 			"  let total = counter(page).final().first()\n"+
 			"  let current = counter(page).get().first()\n"+
-			"  if current == total [\n"+
+			"  if %s [\n"+
 			"    #block(width: 100%%, height: %.1fpt, inset: (top: %.1fpt, bottom: %.1fpt), clip: true)[\n"+
 			"      #align(top)[\n"+
 			"%s"+
@@ -280,6 +368,7 @@ func (b *TypstBuilder) footerBlock(doc *portabledoc.Document) string {
 			"    ]\n"+
 			"  ]\n"+
 			"})\n\n",
+		surfacePageCondition(f.Pages, portabledoc.SurfacePagesLast),
 		metrics.surfaceMinHeightPt,
 		metrics.surfaceVerticalPadPt,
 		metrics.surfaceVerticalPadPt,
@@ -287,6 +376,22 @@ func (b *TypstBuilder) footerBlock(doc *portabledoc.Document) string {
 	)
 }
 
+// surfacePageCondition returns the Typst condition, on the current and total page numbers, of the
+// pages a surface renders on. Unknown values take fallback.
+func surfacePageCondition(pages, fallback string) string {
+	if !portabledoc.ValidSurfacePages.Contains(pages) {
+		pages = fallback
+	}
+	switch pages {
+	case portabledoc.SurfacePagesAll:
+		return "true"
+	case portabledoc.SurfacePagesLast:
+		return "current == total"
+	default:
+		return "current == 1"
+	}
+}
+
 // renderSurfaceImage generates the Typst #image() directive for a surface (header or footer) image.
 // Uses height as primary dimension; fit depends on whether image is injectable.
 func (b *TypstBuilder) renderSurfaceImage(s portabledoc.DocumentSurface, maxWidthPx float64) string {
@@ -368,9 +473,13 @@ func (b *TypstBuilder) renderSurfaceText(nodes []portabledoc.Node, metrics surfa
 
 // convertSurfaceNodes renders header/footer content nodes extracting inline text
 // from paragraphs, wrapping each text run in #text(size) for the surface font size.
+// Text placeholders such as {{page}} resolve (see surfaceExpr).
 func (b *TypstBuilder) convertSurfaceNodes(nodes []portabledoc.Node) string {
+	b.converter.surfaceText = true
+	defer func() { b.converter.surfaceText = false }()
+
 	var sb strings.Builder
-	for _, node := range nodes {
+	for _, node := range b.converter.visibleNodes(nodes) {
 		if node.Type == portabledoc.NodeTypeParagraph {
 			content := b.converter.ConvertNodes(node.Content)
 			if content != "" {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/formatter"
 )
 
 // TypstConverter converts ProseMirror/TipTap nodes to Typst markup.
@@ -21,9 +22,28 @@ type TypstConverter struct {
 	currentTableHeaderStyles *entity.TableStyles
 	currentTableBodyStyles   *entity.TableStyles
 	remoteImages             map[string]string // URL → local filename
+	externalPDFs             []externalPDFRef  // externalPdf nodes, resolved by the service after the build
 	imageCounter             int
 	listDepth                int                              // tracks nesting depth for user-built lists
-	imageURLResolver         func(url string) (string, error) // resolves non-standard URL schemes (e.g. storage://)
+	lastListNumber           int                              // last number of the previous numbered list injector, for continued numbering
+	nestingDepth             int                              // depth of the node being converted; page breaks are only emitted at depth 0
+	imageURLResolver         func(url string) (string, error) // resolves non-standard URL schemes (e.g. storage://) and imported remote URLs
+	defaultLang              string                           // fallback for i18n labels when a node has no lang (document language)
+	contentHeightPx          float64                          // page content area height in pixels (for background patterns)
+	documentID               string                           // seeds security patterns; empty falls back to the injectable values
+	outputFormat             string                           // output being rendered; nodes restricted to other outputs are skipped
+	fontScale                float64                          // multiplier of inline font sizes from layout variants
+	collectReferences        bool                             // record links for references nodes
+	referenceMarkers         bool                             // follow links with their reference number
+	unknownPlaceholders      bool                             // show unknown nodes as a placeholder box instead of their content
+	sourceMarks              bool                             // wrap the output of each node in source marks, for a source map
+	convertCurrency          entity.CurrencyConvertFunc       // backs convert() in table formulas; nil fails it
+	surfaceText              bool                             // converting header or footer text, where {{...}} placeholders resolve
+	documentTitle            string                           // value of {{title}} in header and footer text
+	renderDate               time.Time                        // value of {{date}} in header and footer text
+	unitSystem               string                           // unit system measurements are converted to; empty keeps their units
+	timeZone                 *time.Location                   // zone dates and times are shown in; nil keeps the zone of each value
+	workCalendar             entity.WorkCalendar              // working days of dateOffset and table formulas
 }
 
 // NewTypstConverter creates a new Typst node converter.
@@ -37,6 +57,8 @@ func NewTypstConverter(
 		injectableDefaults: injectableDefaults,
 		tokens:             tokens,
 		currentPage:        1,
+		outputFormat:       portabledoc.OutputPDF,
+		fontScale:          1,
 		remoteImages:       make(map[string]string),
 	}
 }
@@ -51,14 +73,14 @@ func (c *TypstConverter) RemoteImages() map[string]string {
 	return c.remoteImages
 }
 
-// registerRemoteImage registers a remote URL or data URL and returns a local filename.
+// registerRemoteImage registers a remote URL or data URL and returns a local filename. The name has
+// no extension: the fetcher adds the one of the image type it finds in the content.
 func (c *TypstConverter) registerRemoteImage(url string) string {
 	if existing, ok := c.remoteImages[url]; ok {
 		return existing
 	}
 	c.imageCounter++
-	ext := detectExtFromURL(url)
-	filename := fmt.Sprintf("img_%d%s", c.imageCounter, ext)
+	filename := fmt.Sprintf("img_%d", c.imageCounter)
 	c.remoteImages[url] = filename
 	return filename
 }
@@ -70,6 +92,7 @@ func (c *TypstConverter) registerRemoteImage(url string) string {
 //nolint:gocognit,nestif // Central dispatch loop keeps node grouping behavior in one place.
 func (c *TypstConverter) ConvertNodes(nodes []portabledoc.Node) string {
 	var sb strings.Builder
+	nodes = c.visibleNodes(nodes)
 	for i := 0; i < len(nodes); i++ {
 		node := nodes[i]
 		if c.isInlineImage(node) {
@@ -108,6 +131,10 @@ func (c *TypstConverter) ConvertNodes(nodes []portabledoc.Node) string {
 			}
 
 			sb.WriteString(wrapTypstBlockWithLineSpacing(groupContent.String(), ls))
+		} else if href := c.referenceHref(node); href != "" {
+			run := portabledoc.LinkRunLength(nodes[i:], href)
+			sb.WriteString(c.linkRun(nodes[i:i+run], href))
+			i += run - 1 // skip the other fragments of the link
 		} else {
 			sb.WriteString(c.ConvertNode(node))
 		}
@@ -115,6 +142,24 @@ func (c *TypstConverter) ConvertNodes(nodes []portabledoc.Node) string {
 	return sb.String()
 }
 
+// visibleNodes drops the nodes restricted to other output formats, so that they do not
+// take part in paragraph grouping or image wrapping.
+func (c *TypstConverter) visibleNodes(nodes []portabledoc.Node) []portabledoc.Node {
+	for i, node := range nodes {
+		if node.VisibleIn(c.outputFormat) {
+			continue
+		}
+		visible := append([]portabledoc.Node(nil), nodes[:i]...)
+		for _, rest := range nodes[i+1:] {
+			if rest.VisibleIn(c.outputFormat) {
+				visible = append(visible, rest)
+			}
+		}
+		return visible
+	}
+	return nodes
+}
+
 // isLineSpacingNode returns true for node types that support lineSpacing.
 func (c *TypstConverter) isLineSpacingNode(node portabledoc.Node) bool {
 	return node.Type == portabledoc.NodeTypeParagraph || node.Type == portabledoc.NodeTypeHeading
@@ -122,44 +167,58 @@ func (c *TypstConverter) isLineSpacingNode(node portabledoc.Node) bool {
 
 // ConvertNode converts a single node to Typst markup.
 func (c *TypstConverter) ConvertNode(node portabledoc.Node) string {
+	if !node.VisibleIn(c.outputFormat) {
+		return ""
+	}
 	if handler := c.getNodeHandler(node.Type); handler != nil {
-		return handler(node)
+		return c.markSource(node, c.convertWithPaginationHints(node, handler))
 	}
-	return c.handleUnknownNode(node)
+	return c.markSource(node, c.handleUnknownNode(node))
 }
 
 type typstNodeHandler func(node portabledoc.Node) string
 
 func (c *TypstConverter) getNodeHandler(nodeType string) typstNodeHandler {
 	handlers := map[string]typstNodeHandler{
-		portabledoc.NodeTypeParagraph:     c.paragraph,
-		portabledoc.NodeTypeHeading:       c.heading,
-		portabledoc.NodeTypeBlockquote:    c.blockquote,
-		portabledoc.NodeTypeCodeBlock:     c.codeBlock,
-		portabledoc.NodeTypeHR:            c.horizontalRule,
-		portabledoc.NodeTypeBulletList:    c.bulletList,
-		portabledoc.NodeTypeOrderedList:   c.orderedList,
-		portabledoc.NodeTypeTaskList:      c.taskList,
-		portabledoc.NodeTypeListItem:      c.listItem,
-		portabledoc.NodeTypeTaskItem:      c.taskItem,
-		portabledoc.NodeTypeInjector:      c.injector,
-		portabledoc.NodeTypeConditional:   c.conditional,
-		portabledoc.NodeTypePageBreak:     c.pageBreak,
-		portabledoc.NodeTypeImage:         c.image,
-		portabledoc.NodeTypeCustomImage:   c.image,
-		portabledoc.NodeTypeText:          c.text,
-		portabledoc.NodeTypeListInjector:  c.listInjector,
-		portabledoc.NodeTypeTableInjector: c.tableInjector,
-		portabledoc.NodeTypeTable:         c.table,
-		portabledoc.NodeTypeTableRow:      c.tableRow,
-		portabledoc.NodeTypeTableCell:     c.tableCellData,
-		portabledoc.NodeTypeTableHeader:   c.tableCellHeader,
-		portabledoc.NodeTypeHardBreak:     c.hardBreak,
+		portabledoc.NodeTypeParagraph:       c.paragraph,
+		portabledoc.NodeTypeHeading:         c.heading,
+		portabledoc.NodeTypeBlockquote:      c.blockquote,
+		portabledoc.NodeTypeCodeBlock:       c.codeBlock,
+		portabledoc.NodeTypeHR:              c.horizontalRule,
+		portabledoc.NodeTypeBulletList:      c.bulletList,
+		portabledoc.NodeTypeOrderedList:     c.orderedList,
+		portabledoc.NodeTypeTaskList:        c.taskList,
+		portabledoc.NodeTypeListItem:        c.listItem,
+		portabledoc.NodeTypeTaskItem:        c.taskItem,
+		portabledoc.NodeTypeInjector:        c.injector,
+		portabledoc.NodeTypeConditional:     c.conditional,
+		portabledoc.NodeTypeRepeater:        c.repeater,
+		portabledoc.NodeTypePageBreak:       c.pageBreak,
+		portabledoc.NodeTypeImage:           c.image,
+		portabledoc.NodeTypeCustomImage:     c.image,
+		portabledoc.NodeTypeText:            c.text,
+		portabledoc.NodeTypeListInjector:    c.listInjector,
+		portabledoc.NodeTypeTableInjector:   c.tableInjector,
+		portabledoc.NodeTypeTable:           c.table,
+		portabledoc.NodeTypeTableRow:        c.tableRow,
+		portabledoc.NodeTypeTableCell:       c.tableCellData,
+		portabledoc.NodeTypeTableHeader:     c.tableCellHeader,
+		portabledoc.NodeTypeHardBreak:       c.hardBreak,
+		portabledoc.NodeTypePageNumber:      c.pageNumber,
+		portabledoc.NodeTypeSecurityPattern: c.securityPattern,
+		portabledoc.NodeTypeExternalPDF:     c.externalPDF,
+		portabledoc.NodeTypeReferences:      c.references,
 	}
 	return handlers[nodeType]
 }
 
+// handleUnknownNode flattens a node of an unknown type to its content, or shows a placeholder box
+// naming its type when unknown nodes are shown as placeholders.
 func (c *TypstConverter) handleUnknownNode(node portabledoc.Node) string {
+	if c.unknownPlaceholders {
+		return fmt.Sprintf("#box(stroke: 0.75pt + rgb(\"#c0392b\"), inset: 4pt, radius: 2pt)[#text(size: 9pt, fill: rgb(\"#c0392b\"))[%s]]",
+			escapeTypst(unknownNodeLabel+node.Type))
+	}
 	if len(node.Content) > 0 {
 		return c.ConvertNodes(node.Content)
 	}
@@ -378,18 +437,9 @@ func (c *TypstConverter) injector(node portabledoc.Node) string {
 	prefix, _ := node.Attrs["prefix"].(string)
 	suffix, _ := node.Attrs["suffix"].(string)
 	showLabelIfEmpty, _ := node.Attrs["showLabelIfEmpty"].(bool)
-	nodeDefaultValue, _ := node.Attrs["defaultValue"].(string)
 	widthPx, hasWidth := node.Attrs["width"].(float64)
 
-	// Resolve value with priority: injected > node default > global default
-	value := c.resolveRegularInjectable(variableID, node.Attrs)
-	if value == "" {
-		if nodeDefaultValue != "" {
-			value = nodeDefaultValue
-		} else {
-			value = c.getDefaultValue(variableID)
-		}
-	}
+	value := c.injectorValue(variableID, node.Attrs)
 
 	// Empty value handling
 	if value == "" {
@@ -405,7 +455,8 @@ func (c *TypstConverter) injector(node portabledoc.Node) string {
 	if prefix != "" {
 		parts = append(parts, escapeTypst(prefix))
 	}
-	parts = append(parts, escapeTypst(value))
+	// Multi-line values, such as addresses, keep their line breaks.
+	parts = append(parts, strings.ReplaceAll(escapeTypst(value), "\n", "\\\n"))
 	if suffix != "" {
 		parts = append(parts, escapeTypst(suffix))
 	}
@@ -420,6 +471,17 @@ func (c *TypstConverter) injector(node portabledoc.Node) string {
 	return content
 }
 
+// injectorValue resolves the value of an injector with priority: injected > node default > global default.
+func (c *TypstConverter) injectorValue(variableID string, attrs map[string]any) string {
+	if value := c.resolveRegularInjectable(variableID, attrs); value != "" {
+		return value
+	}
+	if nodeDefaultValue, _ := attrs["defaultValue"].(string); nodeDefaultValue != "" {
+		return nodeDefaultValue
+	}
+	return c.getDefaultValue(variableID)
+}
+
 func (c *TypstConverter) resolveRegularInjectable(variableID string, attrs map[string]any) string {
 	if v, ok := c.injectables[variableID]; ok {
 		return c.formatInjectableValue(v, attrs)
@@ -438,28 +500,75 @@ func (c *TypstConverter) formatInjectableValue(value any, attrs map[string]any)
 	injectorType, _ := attrs["type"].(string)
 	format, _ := attrs["format"].(string)
 
+	if formatter.IsWordsPattern(format) {
+		if n, ok := numericValue(value); ok {
+			return formatter.FormatWords(n, format)
+		}
+	}
+	if formatter.IsNumberPattern(format) {
+		if n, ok := numericValue(value); ok {
+			return formatter.FormatLocaleNumber(n, format, c.fallbackLang())
+		}
+	}
+
 	switch v := value.(type) {
 	case string:
-		return v
+		if isDateNode(attrs) {
+			if t, ok := parseDate(v, c.nodeTimeZone(attrs)); ok {
+				return c.formatTime(t, attrs)
+			}
+		}
+		return maskValue(v, format)
 	case float64:
 		return c.formatFloat64(v, injectorType, format)
 	case int:
-		return strconv.Itoa(v)
+		return maskValue(strconv.Itoa(v), format)
 	case int64:
-		return strconv.FormatInt(v, 10)
+		return maskValue(strconv.FormatInt(v, 10), format)
 	case bool:
 		return formatBool(v)
+	case time.Time:
+		return c.formatTime(v, attrs)
+	case *entity.AddressValue:
+		return strings.Join(formatter.FormatAddress(v, format), "\n")
+	case *entity.PersonValue:
+		return formatter.FormatPerson(v, format)
+	case *entity.MeasurementValue:
+		return formatter.FormatMeasurement(v, format, c.unitSystem, c.fallbackLang())
 	default:
 		return fmt.Sprintf("%v", v)
 	}
 }
 
+// numericValue returns a number injectable value, or a string holding one, as a float64.
+func numericValue(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// maskValue masks a value when the format of its node is a mask pattern ("mask:card", ...).
+func maskValue(value, format string) string {
+	if !formatter.IsMaskPattern(format) {
+		return value
+	}
+	return formatter.Mask(value, format, "")
+}
+
+// formatFloat64 writes a number injectable. Currency formats are an ISO 4217 code or a symbol,
+// written by the conventions of the document language.
 func (c *TypstConverter) formatFloat64(v float64, injectorType, format string) string {
 	if injectorType == portabledoc.InjectorTypeCurrency {
-		if format != "" {
-			return fmt.Sprintf("%s %.2f", format, v)
-		}
-		return fmt.Sprintf("%.2f", v)
+		return formatter.FormatAmount(v, format, c.fallbackLang())
 	}
 
 	if v == float64(int64(v)) {
@@ -475,6 +584,12 @@ func (c *TypstConverter) conditional(node portabledoc.Node) string {
 	return ""
 }
 
+func (c *TypstConverter) repeater(node portabledoc.Node) string {
+	var sb strings.Builder
+	c.repeat(node, func() { sb.WriteString(c.ConvertNodes(node.Content)) })
+	return sb.String()
+}
+
 func (c *TypstConverter) pageBreak(_ portabledoc.Node) string {
 	c.currentPage++
 	return "#pagebreak()\n"
@@ -485,6 +600,16 @@ func (c *TypstConverter) pageBreak(_ portabledoc.Node) string {
 // resolveImagePath resolves the final local image path from node attributes.
 // Handles injectable bindings, remote URLs, and data URLs.
 func (c *TypstConverter) resolveImagePath(attrs map[string]any) string {
+	src := c.resolveSource(attrs)
+	if strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://") || strings.HasPrefix(src, "data:") {
+		return c.registerRemoteImage(src)
+	}
+	return src
+}
+
+// resolveSource resolves the src of a node bound to a file: the value of its injectableId when
+// the injectable has one, with non-standard URL schemes resolved. Returns "" when unresolved.
+func (c *TypstConverter) resolveSource(attrs map[string]any) string {
 	src, _ := attrs["src"].(string)
 
 	if injectableId, ok := attrs["injectableId"].(string); ok && injectableId != "" {
@@ -499,20 +624,37 @@ func (c *TypstConverter) resolveImagePath(attrs map[string]any) string {
 		return ""
 	}
 
-	// Resolve non-standard URL schemes (e.g., storage://)
-	if c.imageURLResolver != nil &&
-		!strings.HasPrefix(src, "http://") && !strings.HasPrefix(src, "https://") && !strings.HasPrefix(src, "data:") {
-		resolved, err := c.imageURLResolver(src)
+	// Resolve non-standard URL schemes (e.g., storage://). Remote URLs go through the resolver too,
+	// since the workspace may have imported them into its asset library, but keep the original URL
+	// when resolution fails.
+	if c.imageURLResolver != nil && !strings.HasPrefix(src, "data:") {
+		remote := strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://")
+		resolved, err := c.imageURLResolver(withAssetWidthHint(src, attrs))
 		if err != nil || resolved == "" {
+			if remote {
+				return src
+			}
 			return ""
 		}
 		src = resolved
 	}
+	return src
+}
 
-	if strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://") || strings.HasPrefix(src, "data:") {
-		return c.registerRemoteImage(src)
+// assetImageScale is the number of bitmap pixels requested per CSS pixel of an image node:
+// about 200 dpi once printed.
+const assetImageScale = 2
+
+// withAssetWidthHint appends the bitmap width an image node needs to a library asset URL, so the
+// asset is scaled down before it is embedded. Circle-cropped images keep the full size, since the
+// crop may need more than the node width.
+func withAssetWidthHint(src string, attrs map[string]any) string {
+	width, _ := attrs["width"].(float64)
+	shape, _ := attrs["shape"].(string)
+	if width <= 0 || shape == "circle" || !strings.HasPrefix(src, entity.AssetURLScheme) || strings.Contains(src, "?") {
+		return src
 	}
-	return src
+	return src + "?w=" + strconv.Itoa(int(math.Ceil(width*assetImageScale)))
 }
 
 // isInlineImage checks if a node is an image with displayMode "inline" (text wrapping).
@@ -612,6 +754,9 @@ func (c *TypstConverter) text(node portabledoc.Node) string {
 	if node.Text == nil {
 		return ""
 	}
+	if c.surfaceText {
+		return c.applyMarks(placeholderMarkup(*node.Text, c.surfaceExpr), node.Marks)
+	}
 	return c.applyMarks(escapeTypst(*node.Text), node.Marks)
 }
 
@@ -672,7 +817,7 @@ func (c *TypstConverter) applyTextStyleMark(text string, mark portabledoc.Mark)
 		// Convert CSS px to Typst pt (1px ≈ 0.75pt)
 		size := strings.TrimSuffix(fontSize, "px")
 		if n, err := strconv.ParseFloat(size, 64); err == nil {
-			params = append(params, fmt.Sprintf("size: %.1fpt", n*0.75))
+			params = append(params, fmt.Sprintf("size: %.1fpt", n*0.75*c.fontScale))
 		}
 	}
 	if fontFamily, ok := mark.Attrs["fontFamily"].(string); ok && fontFamily != "" {
@@ -690,22 +835,40 @@ func (c *TypstConverter) applyTextStyleMark(text string, mark portabledoc.Mark)
 
 // --- List Injector Nodes ---
 
+// fallbackLang returns the language used for injector labels when the node does not set one.
+func (c *TypstConverter) fallbackLang() string {
+	if c.defaultLang != "" {
+		return c.defaultLang
+	}
+	return portabledoc.LanguageEnglish
+}
+
 func (c *TypstConverter) listInjector(node portabledoc.Node) string {
 	variableID, _ := node.Attrs["variableId"].(string)
 	lang, _ := node.Attrs["lang"].(string)
 	if lang == "" {
-		lang = "en"
+		lang = c.fallbackLang()
 	}
 
-	listData := c.resolveListValue(variableID)
-	if listData == nil {
+	resolved := c.resolveListValue(variableID)
+	if resolved == nil {
 		return ""
 	}
+	// Copy so editor overrides do not leak into other injectors of the same variable
+	listCopy := *resolved
+	listData := &listCopy
 
-	// Override symbol from editor attrs
+	// Override symbol and numbering from editor attrs
 	if sym, ok := node.Attrs["symbol"].(string); ok && sym != "" {
 		listData.Symbol = entity.ListSymbol(sym)
 	}
+	if start, ok := node.Attrs["start"].(float64); ok && start > 0 {
+		s := int(start)
+		listData.Start = &s
+	}
+	if cont, ok := node.Attrs["continueNumbering"].(bool); ok && cont {
+		listData.Continue = true
+	}
 
 	// Override header label from editor attrs
 	if label, ok := node.Attrs["label"].(string); ok && label != "" {
@@ -763,9 +926,35 @@ func (c *TypstConverter) parseListFromMap(m map[string]any) *entity.ListValue {
 			}
 		}
 	}
+	c.parseListNumberingFromMap(m, list)
 	return list
 }
 
+func (c *TypstConverter) parseListNumberingFromMap(m map[string]any, list *entity.ListValue) {
+	if start, ok := m["start"].(float64); ok {
+		list.WithStart(int(start))
+	}
+	if cont, ok := m["continue"].(bool); ok && cont {
+		list.ContinueNumbering()
+	}
+	if numbering, ok := m["numbering"].(string); ok {
+		list.WithNumbering(numbering)
+	}
+	if markers, ok := m["markers"].([]any); ok {
+		for _, marker := range markers {
+			if s, ok := marker.(string); ok {
+				list.Markers = append(list.Markers, s)
+			}
+		}
+	}
+	if levels, ok := m["levelStyles"].([]any); ok {
+		for _, level := range levels {
+			levelMap, _ := level.(map[string]any)
+			list.LevelStyles = append(list.LevelStyles, c.parseListStylesFromMap(levelMap))
+		}
+	}
+}
+
 func (c *TypstConverter) parseListItemFromMap(m map[string]any) entity.ListItem {
 	item := entity.ListItem{}
 	if valueMap, ok := m["value"].(map[string]any); ok {
@@ -798,7 +987,7 @@ func (c *TypstConverter) renderTypstList(listData *entity.ListValue, lang string
 	}
 
 	// Emit symbol config
-	isEnum, config := typstListConfig(listData.Symbol)
+	isEnum, config := typstListConfig(listData)
 	if config != "" {
 		sb.WriteString(config)
 	}
@@ -814,14 +1003,38 @@ func (c *TypstConverter) renderTypstList(listData *entity.ListValue, lang string
 	sb.WriteString("\n")
 
 	// Render items recursively
-	for _, item := range listData.Items {
-		c.renderListItem(&sb, item, isEnum, 0)
+	start := c.listStart(listData, isEnum)
+	for i, item := range listData.Items {
+		marker := ""
+		if i == 0 && start != 1 {
+			// An explicit number on the first item only, so nested levels still start at 1
+			marker = fmt.Sprintf("%d. ", start)
+		}
+		c.renderListItem(&sb, item, listData, marker, isEnum, 0)
 	}
 	sb.WriteString("]\n") // close content block
 
 	return sb.String()
 }
 
+// listStart returns the number of the first item of a list injector and records where the
+// numbering ends, so a following list can continue it.
+func (c *TypstConverter) listStart(listData *entity.ListValue, isEnum bool) int {
+	if !isEnum {
+		return 1
+	}
+
+	start := 1
+	switch {
+	case listData.Start != nil:
+		start = *listData.Start
+	case listData.Continue:
+		start = c.lastListNumber + 1
+	}
+	c.lastListNumber = start + len(listData.Items) - 1
+	return start
+}
+
 func (c *TypstConverter) renderListHeader(label string, styles *entity.ListStyles) string {
 	var sb strings.Builder
 	sb.WriteString("#text(")
@@ -833,22 +1046,30 @@ func (c *TypstConverter) renderListHeader(label string, styles *entity.ListStyle
 	return sb.String()
 }
 
-func (c *TypstConverter) renderListItem(sb *strings.Builder, item entity.ListItem, isEnum bool, depth int) {
+// renderListItem writes an item and its children. An empty marker uses the list's default marker.
+func (c *TypstConverter) renderListItem(sb *strings.Builder, item entity.ListItem, list *entity.ListValue, marker string, isEnum bool, depth int) {
 	indent := strings.Repeat("  ", depth)
-	marker := "- "
-	if isEnum {
-		marker = "+ "
+	if marker == "" {
+		marker = "- "
+		if isEnum {
+			marker = "+ "
+		}
 	}
 
 	value := ""
 	if item.Value != nil {
-		value = c.formatCellValue(item.Value, "")
+		value = strings.TrimSpace(c.formatCellValue(item.Value, ""))
+	}
+	if depth < len(list.LevelStyles) && value != "" {
+		if parts := c.collectListStyleParts(&list.LevelStyles[depth]); len(parts) > 0 {
+			value = fmt.Sprintf("#text(%s)[%s]", strings.Join(parts, ", "), value)
+		}
 	}
 
-	fmt.Fprintf(sb, "%s%s%s\n", indent, marker, strings.TrimSpace(value))
+	fmt.Fprintf(sb, "%s%s%s\n", indent, marker, value)
 
 	for _, child := range item.Children {
-		c.renderListItem(sb, child, isEnum, depth+1)
+		c.renderListItem(sb, child, list, "", isEnum, depth+1)
 	}
 }
 
@@ -879,7 +1100,7 @@ func (c *TypstConverter) tableInjector(node portabledoc.Node) string {
 	variableID, _ := node.Attrs["variableId"].(string)
 	lang, _ := node.Attrs["lang"].(string)
 	if lang == "" {
-		lang = "en"
+		lang = c.fallbackLang()
 	}
 
 	tableData := c.resolveTableValue(variableID)
@@ -897,7 +1118,12 @@ func (c *TypstConverter) tableInjector(node portabledoc.Node) string {
 		bodyStyles = c.mergeTableStyles(tableData.BodyStyles, bodyStyles)
 	}
 
-	return c.renderTypstTable(tableData, lang, headerStyles, bodyStyles)
+	var footer []string
+	if attrs, err := portabledoc.ParseTableInjectorAttrs(node.Attrs); err == nil && attrs.HasCalculations() {
+		tableData, footer = c.applyTableCalculations(tableData, attrs, lang)
+	}
+
+	return c.renderTypstTable(tableData, lang, headerStyles, bodyStyles, footer)
 }
 
 func (c *TypstConverter) resolveTableValue(variableID string) *entity.TableValue {
@@ -1068,7 +1294,8 @@ func (c *TypstConverter) parseDataType(s string) entity.ValueType {
 }
 
 // renderTypstTable generates Typst table markup for a TableValue (tableInjector).
-func (c *TypstConverter) renderTypstTable(tableData *entity.TableValue, lang string, headerStyles, bodyStyles *entity.TableStyles) string {
+// A non-empty footer is rendered once, after the last row.
+func (c *TypstConverter) renderTypstTable(tableData *entity.TableValue, lang string, headerStyles, bodyStyles *entity.TableStyles, footer []string) string {
 	if len(tableData.Columns) == 0 {
 		return ""
 	}
@@ -1086,6 +1313,7 @@ func (c *TypstConverter) renderTypstTable(tableData *entity.TableValue, lang str
 	sb.WriteString(c.buildTableAlignParam(headerStyles, bodyStyles))
 	sb.WriteString(c.renderTypstTableHeader(tableData.Columns, lang))
 	sb.WriteString(c.renderTypstTableRows(tableData))
+	sb.WriteString(c.renderTypstTableFooter(footer))
 	sb.WriteString(")\n")
 	sb.WriteString("]\n") // close content block
 	return sb.String()
@@ -1118,6 +1346,26 @@ func (c *TypstConverter) renderTypstTableRows(tableData *entity.TableValue) stri
 	return sb.String()
 }
 
+func (c *TypstConverter) renderTypstTableFooter(footer []string) string {
+	if len(footer) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("  table.footer(repeat: false, ")
+	for i, content := range footer {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		if content == "" {
+			fmt.Fprintf(&sb, "table.cell(inset: %s)[]", c.tokens.TableBodyCellInset)
+			continue
+		}
+		fmt.Fprintf(&sb, "table.cell(inset: %s)[#strong[%s]]", c.tokens.TableBodyCellInset, escapeTypst(content))
+	}
+	sb.WriteString("),\n")
+	return sb.String()
+}
+
 func (c *TypstConverter) getColumnFormat(columns []entity.TableColumn, idx int) string {
 	if idx < len(columns) && columns[idx].Format != nil {
 		return *columns[idx].Format
@@ -1196,13 +1444,16 @@ func (c *TypstConverter) formatCellValue(value *entity.InjectableValue, format s
 		return s
 	case entity.ValueTypeNumber:
 		n, _ := value.Number()
+		if formatter.IsNumberPattern(format) {
+			return formatter.FormatLocaleNumber(n, format, c.fallbackLang())
+		}
 		if format != "" {
 			return fmt.Sprintf(format, n)
 		}
 		if n == float64(int64(n)) {
 			return strconv.FormatInt(int64(n), 10)
 		}
-		return strconv.FormatFloat(n, 'f', 2, 64)
+		return formatter.FormatDecimal(n, c.fallbackLang(), 2)
 	case entity.ValueTypeBool:
 		b, _ := value.Bool()
 		if b {
@@ -1211,6 +1462,7 @@ func (c *TypstConverter) formatCellValue(value *entity.InjectableValue, format s
 		return "No"
 	case entity.ValueTypeTime:
 		t, _ := value.Time()
+		t = c.inTimeZone(t, nil)
 		if format != "" {
 			return t.Format(format)
 		}
@@ -1,8 +1,11 @@
 package pdfrenderer
 
 import (
+	"errors"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
@@ -297,6 +300,47 @@ func TestTypstConverter_ResolveImagePath_UsesResolverForStorageURL(t *testing.T)
 	}
 }
 
+func TestTypstConverter_ResolveImagePath_UsesResolverForHTTPURL(t *testing.T) {
+	c := newConverter(nil, nil)
+	c.imageURLResolver = func(url string) (string, error) {
+		if url == "https://example.com/imported.png" {
+			return "data:image/png;base64,abc123", nil
+		}
+		return "", errors.New("lookup failed")
+	}
+
+	c.resolveImagePath(map[string]any{"src": "https://example.com/imported.png"})
+	c.resolveImagePath(map[string]any{"src": "https://example.com/other.png"})
+
+	for _, src := range []string{"data:image/png;base64,abc123", "https://example.com/other.png"} {
+		if _, ok := c.RemoteImages()[src]; !ok {
+			t.Fatalf("expected %s to be registered as remote image, got %#v", src, c.RemoteImages())
+		}
+	}
+	if _, ok := c.RemoteImages()["https://example.com/imported.png"]; ok {
+		t.Fatal("an imported URL must not be downloaded")
+	}
+}
+
+func TestTypstConverter_ResolveImagePath_AddsWidthHintToAssetURL(t *testing.T) {
+	var got []string
+	c := newConverter(nil, nil)
+	c.imageURLResolver = func(url string) (string, error) {
+		got = append(got, url)
+		return "data:image/png;base64,abc123", nil
+	}
+
+	c.resolveImagePath(map[string]any{"src": "asset://logo", "width": 150.5})
+	c.resolveImagePath(map[string]any{"src": "asset://logo"})
+	c.resolveImagePath(map[string]any{"src": "asset://logo", "width": 150.0, "shape": "circle"})
+	c.resolveImagePath(map[string]any{"src": "storage://logo.png", "width": 150.0})
+
+	want := []string{"asset://logo?w=301", "asset://logo", "asset://logo", "storage://logo.png"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("resolver inputs = %v, want %v", got, want)
+	}
+}
+
 func TestTypstConverter_TableCellWithLineBreaks(t *testing.T) {
 	c := newConverter(nil, nil)
 	node := portabledoc.Node{
@@ -497,6 +541,70 @@ func TestTypstConverter_OrderedListCustomStart(t *testing.T) {
 	}
 }
 
+func listInjectorNode(variableID string, attrs map[string]any) portabledoc.Node {
+	all := map[string]any{"variableId": variableID}
+	for k, v := range attrs {
+		all[k] = v
+	}
+	return portabledoc.Node{Type: portabledoc.NodeTypeListInjector, Attrs: all}
+}
+
+func TestTypstConverter_ListInjectorMultilevel(t *testing.T) {
+	list := entity.NewListValue().
+		WithSymbol(entity.ListSymbolMultilevel).
+		AddNestedItem(entity.StringValue("Scope"), entity.ListItemValue(entity.StringValue("Services"))).
+		WithLevelStyles(entity.ListStyles{FontWeight: strPtr("bold")})
+	c := newConverter(map[string]any{"clauses": list}, nil)
+
+	got := c.ConvertNode(listInjectorNode("clauses", nil))
+
+	for _, want := range []string{
+		`#set enum(numbering: "1.1.", full: true)`,
+		`+ #text(weight: "bold")[Scope]`,
+		"  + Services\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestTypstConverter_ListInjectorContinuesNumbering(t *testing.T) {
+	first := entity.NewListValue().WithSymbol(entity.ListSymbolNumber).WithStart(3).
+		AddItem(entity.StringValue("a")).AddItem(entity.StringValue("b"))
+	second := entity.NewListValue().WithSymbol(entity.ListSymbolNumber).ContinueNumbering().
+		AddItem(entity.StringValue("c"))
+	c := newConverter(map[string]any{"first": first, "second": second}, nil)
+
+	if got := c.ConvertNode(listInjectorNode("first", nil)); !strings.Contains(got, "3. a\n+ b\n") {
+		t.Errorf("expected the list to start at 3, got:\n%s", got)
+	}
+	if got := c.ConvertNode(listInjectorNode("second", nil)); !strings.Contains(got, "5. c\n") {
+		t.Errorf("expected the list to continue at 5, got:\n%s", got)
+	}
+
+	// The node's start attr wins and does not change the shared list value
+	if got := c.ConvertNode(listInjectorNode("second", map[string]any{"start": float64(10)})); !strings.Contains(got, "10. c\n") {
+		t.Errorf("expected the node start to override, got:\n%s", got)
+	}
+	if second.Start != nil {
+		t.Error("expected the injected list value to be left unchanged")
+	}
+}
+
+func TestTypstConverter_ListInjectorCustomMarkersFromMap(t *testing.T) {
+	c := newConverter(map[string]any{"items": map[string]any{
+		"symbol":  "bullet",
+		"markers": []any{"→", "#"},
+		"items":   []any{map[string]any{"value": "x"}},
+	}}, nil)
+
+	got := c.ConvertNode(listInjectorNode("items", nil))
+	if !strings.Contains(got, `#set list(marker: ([→], [\#]))`) {
+		t.Errorf("expected custom markers, got:\n%s", got)
+	}
+}
+
 func TestTypstConverter_TaskList(t *testing.T) {
 	c := newConverter(nil, nil)
 	node := portabledoc.Node{
@@ -548,7 +656,7 @@ func TestTypstConverter_Image(t *testing.T) {
 		t.Errorf("expected image with 150pt width (200*0.75), got %q", got)
 	}
 	// Remote URL should be registered
-	if !strings.Contains(got, "img_1.png") {
+	if !strings.Contains(got, `"img_1"`) {
 		t.Errorf("expected local filename, got %q", got)
 	}
 }
@@ -563,7 +671,7 @@ func TestTypstConverter_ImageCenter(t *testing.T) {
 	if !strings.Contains(got, "#align(center)") {
 		t.Errorf("expected center alignment, got %q", got)
 	}
-	if !strings.Contains(got, "img_1.png") {
+	if !strings.Contains(got, `"img_1"`) {
 		t.Errorf("expected local filename for remote URL, got %q", got)
 	}
 }
@@ -588,7 +696,7 @@ func TestTypstConverter_ImageInjectable(t *testing.T) {
 	}
 	got := c.ConvertNode(node)
 	// Remote URLs are replaced with local filenames for Typst
-	if !strings.Contains(got, "img_1.jpg") {
+	if !strings.Contains(got, `"img_1"`) {
 		t.Errorf("expected local image filename, got %q", got)
 	}
 	if len(c.RemoteImages()) != 1 {
@@ -646,6 +754,152 @@ func TestTypstConverter_InjectorCurrency(t *testing.T) {
 	}
 }
 
+func TestTypstConverter_InjectorLocaleNumber(t *testing.T) {
+	c := newConverter(map[string]any{"total": 1234.56, "count": int64(1500), "year": float64(2024)}, nil)
+	c.defaultLang = "es"
+	tests := []struct {
+		attrs map[string]any
+		want  string
+	}{
+		{map[string]any{"variableId": "total", "type": "CURRENCY", "format": "EUR"}, "1.234,56 €"},
+		{map[string]any{"variableId": "total", "type": "CURRENCY", "format": "$"}, "\\$ 1.234,56"},
+		{map[string]any{"variableId": "total", "format": "number:en-US:USD"}, "\\$1,234.56"},
+		{map[string]any{"variableId": "count", "format": "number"}, "1.500"},
+		{map[string]any{"variableId": "year"}, "2024"},
+	}
+	for _, tt := range tests {
+		got := c.ConvertNode(portabledoc.Node{Type: portabledoc.NodeTypeInjector, Attrs: tt.attrs})
+		if got != tt.want {
+			t.Errorf("format %v: got %q, want %q", tt.attrs["format"], got, tt.want)
+		}
+	}
+}
+
+func TestTypstConverter_InjectorMask(t *testing.T) {
+	c := newConverter(map[string]any{"card": "4242 4242 4242 4242", "rut": "12.345.678-9"}, nil)
+	tests := []struct {
+		attrs map[string]any
+		want  string
+	}{
+		{map[string]any{"variableId": "card", "format": "mask:card"}, "\\*\\*\\*\\* \\*\\*\\*\\* \\*\\*\\*\\* 4242"},
+		{map[string]any{"variableId": "rut", "format": "mask:national_id:0,2:CL"}, "\\*\\*.\\*\\*\\*.\\*\\*8-9"},
+		{map[string]any{"variableId": "rut", "format": "mask:unknown"}, "\\*\\*.\\*\\*\\*.\\*\\*\\*-\\*"},
+	}
+	for _, tt := range tests {
+		got := c.ConvertNode(portabledoc.Node{Type: portabledoc.NodeTypeInjector, Attrs: tt.attrs})
+		if got != tt.want {
+			t.Errorf("format %v: got %q, want %q", tt.attrs["format"], got, tt.want)
+		}
+	}
+}
+
+func TestTypstConverter_InjectorWords(t *testing.T) {
+	c := newConverter(map[string]any{"total": 1200.05, "count": int64(21), "text": "3", "name": "n/a"}, nil)
+	tests := []struct {
+		attrs map[string]any
+		want  string
+	}{
+		{map[string]any{"variableId": "total", "type": "CURRENCY", "format": "words:en:EUR"}, "one thousand two hundred euros and five cents"},
+		{map[string]any{"variableId": "count", "format": "words:es"}, "veintiuno"},
+		{map[string]any{"variableId": "text", "format": "words:fr:EUR"}, "trois euros"},
+		{map[string]any{"variableId": "name", "format": "words:en"}, "n/a"},
+	}
+	for _, tt := range tests {
+		got := c.ConvertNode(portabledoc.Node{Type: portabledoc.NodeTypeInjector, Attrs: tt.attrs})
+		if got != tt.want {
+			t.Errorf("format %v: got %q, want %q", tt.attrs["format"], got, tt.want)
+		}
+	}
+}
+
+func TestTypstConverter_InjectorAddress(t *testing.T) {
+	address := &entity.AddressValue{
+		Name:       "Max Mustermann",
+		Lines:      []string{"Musterstraße 12"},
+		City:       "Berlin",
+		PostalCode: "10115",
+		Country:    "DE",
+	}
+	c := newConverter(map[string]any{"addr": address}, nil)
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"", "Max Mustermann\\\nMusterstraße 12\\\n10115 Berlin\\\nGERMANY"},
+		{"address:oneline", "Max Mustermann, Musterstraße 12, 10115 Berlin, GERMANY"},
+	}
+	for _, tt := range tests {
+		got := c.ConvertNode(portabledoc.Node{Type: portabledoc.NodeTypeInjector, Attrs: map[string]any{"variableId": "addr", "format": tt.format}})
+		if got != tt.want {
+			t.Errorf("format %q: got %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestTypstConverter_InjectorPerson(t *testing.T) {
+	person := &entity.PersonValue{GivenName: "Anna", FamilyName: "Müller", Gender: entity.GenderFemale, Title: "Dr."}
+	c := newConverter(map[string]any{"recipient": person}, nil)
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"", "Dr. Anna Müller"},
+		{"person:salutation:de", "Sehr geehrte Frau Dr. Müller"},
+	}
+	for _, tt := range tests {
+		got := c.ConvertNode(portabledoc.Node{Type: portabledoc.NodeTypeInjector, Attrs: map[string]any{"variableId": "recipient", "format": tt.format}})
+		if got != tt.want {
+			t.Errorf("format %q: got %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestTypstConverter_InjectorMeasurement(t *testing.T) {
+	c := newConverter(map[string]any{"height": &entity.MeasurementValue{Value: 1.8, Unit: "m"}}, nil)
+	tests := []struct {
+		system string
+		lang   string
+		format string
+		want   string
+	}{
+		{"", "", "", "1.8 m"},
+		{portabledoc.UnitSystemImperial, "", "", "5.91 ft"},
+		{portabledoc.UnitSystemImperial, portabledoc.LanguageSpanish, "measure::name", "5,91 pies"},
+		{portabledoc.UnitSystemImperial, "", "measure:keep", "1.8 m"},
+	}
+	for _, tt := range tests {
+		c.unitSystem, c.defaultLang = tt.system, tt.lang
+		got := c.ConvertNode(portabledoc.Node{Type: portabledoc.NodeTypeInjector, Attrs: map[string]any{"variableId": "height", "format": tt.format}})
+		if got != tt.want {
+			t.Errorf("system %q, format %q: got %q, want %q", tt.system, tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestTypstConverter_InjectorTime(t *testing.T) {
+	signed := time.Date(2024, time.March, 1, 2, 30, 0, 0, time.UTC)
+	c := newConverter(map[string]any{"signed_at": signed, "issue_date": "2024-12-20"}, nil)
+	c.setDocumentDates(portabledoc.Meta{TimeZone: "America/Santiago", Holidays: []string{"2024-12-25"}})
+
+	tests := []struct {
+		name  string
+		attrs map[string]any
+		want  string
+	}{
+		{"document zone", map[string]any{"variableId": "signed_at"}, "29/02/2024 23:30"},
+		{"node zone", map[string]any{"variableId": "signed_at", "timeZone": "Europe/Madrid", "format": "DD/MM/YYYY HH:mm"}, "01/03/2024 03:30"},
+		{"offset", map[string]any{"variableId": "signed_at", "dateOffset": "+1m", "format": "YYYY-MM-DD"}, "2024-03-29"},
+		{"date string with working days", map[string]any{"variableId": "issue_date", "type": portabledoc.InjectorTypeDate, "dateOffset": "+3wd"}, "26/12/2024"},
+		{"date string without offset is kept", map[string]any{"variableId": "issue_date", "type": portabledoc.InjectorTypeDate}, "2024-12-20"},
+	}
+	for _, tt := range tests {
+		got := c.ConvertNode(portabledoc.Node{Type: portabledoc.NodeTypeInjector, Attrs: tt.attrs})
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
 func TestTypstConverter_InjectorBoolean(t *testing.T) {
 	c := newConverter(map[string]any{"active": true}, nil)
 	node := portabledoc.Node{
@@ -948,6 +1202,72 @@ func TestTypstConverter_ConditionalFalse(t *testing.T) {
 	}
 }
 
+func TestTypstConverter_RepeaterTable(t *testing.T) {
+	lines := entity.NewTableValue().
+		AddColumn("name", map[string]string{"en": "Item"}, entity.ValueTypeString).
+		AddColumn("qty", map[string]string{"en": "Qty"}, entity.ValueTypeNumber).
+		AddRow(entity.Cell(entity.StringValue("Pens")), entity.Cell(entity.NumberValue(1))).
+		AddRow(entity.Cell(entity.StringValue("Paper")), entity.Cell(entity.NumberValue(5)))
+	c := newConverter(map[string]any{"lines": lines}, nil)
+	node := portabledoc.Node{
+		Type:  portabledoc.NodeTypeRepeater,
+		Attrs: map[string]any{"variableId": "lines"},
+		Content: []portabledoc.Node{
+			paragraphNode(
+				portabledoc.Node{Type: portabledoc.NodeTypeInjector, Attrs: map[string]any{"variableId": "item.index"}},
+				textNode(". "),
+				portabledoc.Node{Type: portabledoc.NodeTypeInjector, Attrs: map[string]any{"variableId": "item.name"}},
+			),
+			{
+				Type: portabledoc.NodeTypeConditional,
+				Attrs: map[string]any{"conditions": map[string]any{
+					"logic": "AND",
+					"children": []any{map[string]any{
+						"type":       "rule",
+						"variableId": "item.qty",
+						"operator":   "gt",
+						"value":      map[string]any{"mode": "text", "value": "1"},
+					}},
+				}},
+				Content: []portabledoc.Node{paragraphNode(textNode("Bulk order"))},
+			},
+		},
+	}
+
+	got := c.ConvertNode(node)
+	for _, want := range []string{"1. Pens", "2. Paper"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in %q", want, got)
+		}
+	}
+	if n := strings.Count(got, "Bulk order"); n != 1 {
+		t.Errorf("expected the condition to hold for one row, got %d in %q", n, got)
+	}
+	if _, ok := c.injectables["item.name"]; ok {
+		t.Error("item values leaked out of the repeater")
+	}
+}
+
+func TestTypstConverter_RepeaterList(t *testing.T) {
+	students := entity.NewListValue().AddItem(entity.StringValue("Ana")).AddItem(entity.StringValue("Luis"))
+	c := newConverter(map[string]any{"students": students}, nil)
+	node := portabledoc.Node{
+		Type:  portabledoc.NodeTypeRepeater,
+		Attrs: map[string]any{"variableId": "students", "alias": "student"},
+		Content: []portabledoc.Node{paragraphNode(
+			portabledoc.Node{Type: portabledoc.NodeTypeInjector, Attrs: map[string]any{"variableId": "student"}},
+		)},
+	}
+
+	got := c.ConvertNode(node)
+	if !strings.Contains(got, "Ana") || !strings.Contains(got, "Luis") || strings.Index(got, "Ana") > strings.Index(got, "Luis") {
+		t.Errorf("expected one section per student in order, got %q", got)
+	}
+	if got := c.ConvertNode(portabledoc.Node{Type: portabledoc.NodeTypeRepeater, Attrs: map[string]any{"variableId": "missing"}, Content: node.Content}); got != "" {
+		t.Errorf("expected no output without items, got %q", got)
+	}
+}
+
 func TestTypstConverter_ConditionalOR(t *testing.T) {
 	c := newConverter(map[string]any{"a": "no", "b": "yes"}, nil)
 	node := portabledoc.Node{
@@ -1117,6 +1437,135 @@ func TestTypstConverter_TableInjectorMissing(t *testing.T) {
 	}
 }
 
+func TestTypstConverter_FormatCellValueLocale(t *testing.T) {
+	c := newConverter(nil, nil)
+	c.defaultLang = "es"
+	tests := []struct {
+		value  entity.InjectableValue
+		format string
+		want   string
+	}{
+		{entity.NumberValue(1234.5), "", "1234,50"},
+		{entity.NumberValue(1234), "", "1234"},
+		{entity.NumberValue(1234.5), "%.1f", "1234.5"},
+		{entity.NumberValue(1234.56), "number::EUR", "1.234,56 €"},
+		{entity.NumberValue(1234.56), "number:en-GB:GBP", "£1,234.56"},
+	}
+	for _, tt := range tests {
+		if got := c.formatCellValue(&tt.value, tt.format); got != tt.want {
+			t.Errorf("format %q: got %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestTypstConverter_TableInjectorCalculations(t *testing.T) {
+	tv := entity.NewTableValue()
+	tv.AddColumn("item", map[string]string{"en": "Item"}, entity.ValueTypeString)
+	tv.AddColumn("qty", map[string]string{"en": "Qty"}, entity.ValueTypeNumber)
+	tv.AddColumnWithFormat("unitPrice", map[string]string{"en": "Unit price"}, entity.ValueTypeNumber, "%.2f")
+	tv.AddRow(entity.Cell(entity.StringValue("Pens")), entity.Cell(entity.NumberValue(3)), entity.Cell(entity.NumberValue(1.5)))
+	tv.AddRow(entity.Cell(entity.StringValue("Paper")), entity.Cell(entity.NumberValue(2)), entity.Cell(entity.NumberValue(4)))
+	tv.AddRow(entity.Cell(entity.StringValue("Gift")), entity.Cell(entity.NumberValue(1)), entity.Cell(entity.StringValue("")))
+
+	c := newConverter(map[string]any{"lines": tv}, nil)
+	node := portabledoc.Node{
+		Type: portabledoc.NodeTypeTableInjector,
+		Attrs: map[string]any{
+			"variableId": "lines",
+			"lang":       "en",
+			"calculatedColumns": []any{
+				map[string]any{"key": "total", "label": "Total", "expression": "qty * unitPrice", "format": "%.2f"},
+			},
+			"aggregates": []any{
+				map[string]any{"column": "total", "function": "sum"},
+				map[string]any{"column": "item", "function": "count"},
+			},
+			"footerLabel": "Grand total",
+		},
+	}
+	got := c.ConvertNode(node)
+
+	for _, want := range []string{"[Total]", "[4.50]", "[8.00]", "table.footer(repeat: false", "#strong[12.50]", "#strong[3]"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in output, got %q", want, got)
+		}
+	}
+	if strings.Contains(got, "Grand total") {
+		t.Errorf("footer label must not replace the count of the first column, got %q", got)
+	}
+	if len(tv.Columns) != 3 || len(tv.Rows[0].Cells) != 3 {
+		t.Errorf("injected table was modified: %d columns, %d cells", len(tv.Columns), len(tv.Rows[0].Cells))
+	}
+}
+
+func TestTypstConverter_TableInjectorCurrencyConversion(t *testing.T) {
+	tv := entity.NewTableValue()
+	tv.AddColumn("amount", map[string]string{"en": "Amount"}, entity.ValueTypeNumber)
+	tv.AddColumn("currency", map[string]string{"en": "Currency"}, entity.ValueTypeString)
+	tv.AddRow(entity.Cell(entity.NumberValue(10)), entity.Cell(entity.StringValue("USD")))
+	tv.AddRow(entity.Cell(entity.NumberValue(20)), entity.Cell(entity.StringValue("GBP")))
+
+	node := portabledoc.Node{
+		Type: portabledoc.NodeTypeTableInjector,
+		Attrs: map[string]any{
+			"variableId": "lines",
+			"lang":       "en",
+			"calculatedColumns": []any{
+				map[string]any{"key": "eur", "label": "EUR", "expression": `convert(amount, currency, "EUR")`, "format": "%.2f"},
+			},
+		},
+	}
+
+	c := newConverter(map[string]any{"lines": tv}, nil)
+	c.convertCurrency = func(amount float64, from, to string) (float64, error) {
+		if from != "USD" || to != "EUR" {
+			return 0, entity.ErrCurrencyRateNotFound
+		}
+		return amount * 0.9, nil
+	}
+	got := c.ConvertNode(node)
+	if !strings.Contains(got, "[9.00]") {
+		t.Errorf("expected the converted amount in output, got %q", got)
+	}
+	if strings.Contains(got, "[18.00]") {
+		t.Errorf("a rate that is not found must leave the cell empty, got %q", got)
+	}
+
+	c = newConverter(map[string]any{"lines": tv}, nil)
+	if got := c.ConvertNode(node); strings.Contains(got, "[9.00]") {
+		t.Errorf("without a converter the cells must be empty, got %q", got)
+	}
+}
+
+func TestTypstConverter_TableInjectorWorkingDays(t *testing.T) {
+	tv := entity.NewTableValue()
+	tv.AddColumn("issued", map[string]string{"en": "Issued"}, entity.ValueTypeTime)
+	tv.AddColumn("terms", map[string]string{"en": "Terms"}, entity.ValueTypeNumber)
+	tv.AddRow(entity.Cell(entity.TimeValue(time.Date(2024, time.December, 20, 0, 0, 0, 0, time.UTC))), entity.Cell(entity.NumberValue(3)))
+
+	node := portabledoc.Node{
+		Type: portabledoc.NodeTypeTableInjector,
+		Attrs: map[string]any{
+			"variableId": "invoices",
+			"lang":       "en",
+			"calculatedColumns": []any{
+				map[string]any{"key": "due", "label": "Due", "expression": "addWorkdays(issued, terms)", "format": "2006-01-02"},
+				map[string]any{"key": "days", "label": "Days", "expression": `workdays(issued, "2025-01-02")`},
+				map[string]any{"key": "renewal", "label": "Renewal", "expression": "addMonths(issued, 2)", "format": "02/01/2006"},
+			},
+		},
+	}
+
+	c := newConverter(map[string]any{"invoices": tv}, nil)
+	c.setDocumentDates(portabledoc.Meta{Holidays: []string{"2024-12-25", "2025-01-01"}})
+	got := c.ConvertNode(node)
+	for _, want := range []string{"[2024-12-26]", "[7]", "[20/02/2025]"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in output, got %q", want, got)
+		}
+	}
+}
+
 // --- Escaping ---
 
 func TestEscapeTypst(t *testing.T) {
@@ -1262,6 +1711,26 @@ func TestTypstBuilder_CustomPageSize(t *testing.T) {
 	}
 }
 
+func TestTypstBuilder_Watermark(t *testing.T) {
+	doc := &portabledoc.Document{
+		Meta:       portabledoc.Meta{Title: "Draft", Language: "en"},
+		PageConfig: portabledoc.PageConfig{FormatID: portabledoc.PageFormatA4, Width: 794, Height: 1123},
+		Content:    &portabledoc.ProseMirrorDoc{Type: "doc", Content: []portabledoc.Node{}},
+	}
+
+	builder := NewTypstBuilder(nil, nil, DefaultDesignTokens())
+	if got := builder.Build(doc); strings.Contains(got, "foreground:") {
+		t.Errorf("expected no watermark by default, got:\n%s", got)
+	}
+
+	builder = NewTypstBuilder(nil, nil, DefaultDesignTokens())
+	builder.SetWatermark("DRAFT #2")
+	got := builder.Build(doc)
+	if !strings.Contains(got, "#set page(foreground:") || !strings.Contains(got, `[DRAFT \#2]`) {
+		t.Errorf("expected escaped watermark in page foreground, got:\n%s", got)
+	}
+}
+
 func TestTypstBuilder_PageCount(t *testing.T) {
 	doc := &portabledoc.Document{
 		Meta:       portabledoc.Meta{Title: "Test", Language: "en"},
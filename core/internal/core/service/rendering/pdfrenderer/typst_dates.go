@@ -0,0 +1,98 @@
+package pdfrenderer
+
+import (
+	"strings"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/formatter"
+)
+
+// setDocumentDates sets the time zone dates are shown in and the working-day calendar from the
+// document metadata, and the render date when it is not set. Zones that cannot be loaded keep
+// each value in its own zone.
+func (c *TypstConverter) setDocumentDates(meta portabledoc.Meta) {
+	c.timeZone = loadTimeZone(meta.TimeZone)
+	c.workCalendar = entity.WorkCalendar{Holidays: meta.HolidayDates()}
+	if c.renderDate.IsZero() {
+		c.renderDate = time.Now()
+	}
+}
+
+// formatTime writes a date or time injector value: moved to the time zone of the node or the
+// document, shifted by the dateOffset of the node, in the format of the node. Without a format,
+// values at midnight are written as dates and others as dates with times.
+func (c *TypstConverter) formatTime(t time.Time, attrs map[string]any) string {
+	t = c.inTimeZone(t, attrs)
+	if offset, _ := attrs["dateOffset"].(string); offset != "" {
+		if shifted, err := formatter.ShiftDate(t, offset, c.workCalendar); err == nil {
+			t = shifted
+		}
+	}
+
+	format, _ := attrs["format"].(string)
+	if format == "" {
+		format = formatter.DateTimeFormats.Default
+		if h, m, s := t.Clock(); h == 0 && m == 0 && s == 0 {
+			format = formatter.DateFormats.Default
+		}
+	}
+	return formatter.FormatTime(t, format)
+}
+
+// inTimeZone returns t in the time zone of the node attrs.
+func (c *TypstConverter) inTimeZone(t time.Time, attrs map[string]any) time.Time {
+	if loc := c.nodeTimeZone(attrs); loc != nil {
+		return t.In(loc)
+	}
+	return t
+}
+
+// nodeTimeZone returns the timeZone of the node attrs, or else of the document; nil when neither
+// sets one.
+func (c *TypstConverter) nodeTimeZone(attrs map[string]any) *time.Location {
+	if name, _ := attrs["timeZone"].(string); name != "" {
+		if loc := loadTimeZone(name); loc != nil {
+			return loc
+		}
+	}
+	return c.timeZone
+}
+
+// isDateNode reports whether a string value of an injector node should be read as a date: DATE
+// injectors that set a time zone or a date offset.
+func isDateNode(attrs map[string]any) bool {
+	if injectorType, _ := attrs["type"].(string); injectorType != portabledoc.InjectorTypeDate {
+		return false
+	}
+	timeZone, _ := attrs["timeZone"].(string)
+	offset, _ := attrs["dateOffset"].(string)
+	return timeZone != "" || offset != ""
+}
+
+// parseDate reads an RFC 3339 date-time or a YYYY-MM-DD date. Dates without a zone are taken in
+// loc, or UTC when loc is nil, so that showing them in loc keeps their day.
+func parseDate(s string, loc *time.Location) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, true
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	t, err := time.ParseInLocation(time.DateOnly, s, loc)
+	return t, err == nil
+}
+
+// loadTimeZone returns the IANA time zone of name; nil when name is empty, "Local" or unknown.
+func loadTimeZone(name string) *time.Location {
+	if name == "" || name == "Local" {
+		return nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil
+	}
+	return loc
+}
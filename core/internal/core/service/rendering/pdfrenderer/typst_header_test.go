@@ -3,6 +3,7 @@ package pdfrenderer
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
 )
@@ -297,6 +298,44 @@ func TestHeaderBlock_UsesNativePageHeader(t *testing.T) {
 	}
 }
 
+func TestHeaderBlock_Pages(t *testing.T) {
+	tests := map[string]string{
+		"":                           "if current == 1",
+		portabledoc.SurfacePagesAll:  "if true",
+		portabledoc.SurfacePagesLast: "if current == total",
+		"every":                      "if current == 1",
+	}
+	for pages, want := range tests {
+		doc := testDoc(&portabledoc.DocumentHeader{Enabled: true, Pages: pages, Content: headerText("Test")})
+		if got := newTestBuilder().headerBlock(doc); !strings.Contains(got, want) {
+			t.Errorf("pages %q: expected %q, got %q", pages, want, got)
+		}
+	}
+}
+
+func TestHeaderBlock_Placeholders(t *testing.T) {
+	b := newTestBuilderWithInjectables(map[string]any{"client": "ACME #1"})
+	b.converter.documentTitle = "Service Contract"
+	b.converter.renderDate = time.Date(2026, 3, 9, 10, 0, 0, 0, time.UTC)
+	doc := testDoc(&portabledoc.DocumentHeader{
+		Enabled: true,
+		Pages:   portabledoc.SurfacePagesAll,
+		Content: headerText("Page {{page}} of {{total}} · {{title}} · {{date}} · {{date:YYYY-MM-DD}} · {{client}} · {{unknown}}"),
+	})
+	got := b.headerBlock(doc)
+
+	want := "Page " + typstCurrentPageExpr + " of " + typstTotalPagesExpr +
+		" · Service Contract · 09/03/2026 · 2026-03-09 · ACME \\#1 · {{unknown}}"
+	if !strings.Contains(got, want) {
+		t.Errorf("expected resolved placeholders %q, got %q", want, got)
+	}
+
+	// Body text keeps placeholders as written.
+	if body := b.converter.ConvertNode(textNode("{{page}}")); body != "{{page}}" {
+		t.Errorf("body text = %q, want placeholders kept", body)
+	}
+}
+
 func TestHeaderBlock_EnforcesSurfaceHeight(t *testing.T) {
 	b := newTestBuilder()
 	doc := testDoc(&portabledoc.DocumentHeader{
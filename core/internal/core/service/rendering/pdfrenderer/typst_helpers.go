@@ -1,6 +1,7 @@
 package pdfrenderer
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -48,52 +49,53 @@ func escapeTypstString(s string) string {
 	return strings.ReplaceAll(strings.ReplaceAll(s, "\\", "\\\\"), "\"", "\\\"")
 }
 
-// --- Image utilities ---
-
-// detectExtFromURL detects the image extension from a URL or data URL.
-func detectExtFromURL(url string) string {
-	if strings.HasPrefix(url, "data:image/") {
-		mimeEnd := strings.Index(url, ";")
-		if mimeEnd > 0 {
-			mime := url[11:mimeEnd] // after "data:image/"
-			switch {
-			case strings.Contains(mime, "jpeg"), strings.Contains(mime, "jpg"):
-				return ".jpg"
-			case strings.Contains(mime, "png"):
-				return ".png"
-			case strings.Contains(mime, "gif"):
-				return ".gif"
-			case strings.Contains(mime, "svg"):
-				return ".svg"
-			case strings.Contains(mime, "webp"):
-				return ".webp"
-			}
+// --- List utilities ---
+
+// typstListConfig returns whether the list maps to an enum (vs list) and the #set rule
+// for its symbol, custom numbering pattern, or custom markers.
+func typstListConfig(list *entity.ListValue) (isEnum bool, config string) {
+	if list.Symbol.IsNumbered() {
+		numbering := list.Numbering
+		if numbering == "" {
+			numbering = defaultListNumbering(list.Symbol)
 		}
-		return ".png"
+		if list.Symbol == entity.ListSymbolMultilevel {
+			// full: true numbers nested items with their parents' numbers (1.2.3.)
+			return true, fmt.Sprintf("#set enum(numbering: \"%s\", full: true)\n", escapeTypstString(numbering))
+		}
+		return true, fmt.Sprintf("#set enum(numbering: \"%s\")\n", escapeTypstString(numbering))
 	}
-	for _, candidate := range []string{".jpg", ".jpeg", ".png", ".gif", ".svg", ".webp"} {
-		if strings.Contains(strings.ToLower(url), candidate) {
-			return candidate
+
+	markers := make([]string, 0, len(list.Markers))
+	for _, m := range list.Markers {
+		if m = strings.TrimSpace(m); m != "" {
+			markers = append(markers, "["+escapeTypst(m)+"]")
 		}
 	}
-	return ".png"
+	switch {
+	case len(markers) == 1:
+		return false, fmt.Sprintf("#set list(marker: %s)\n", markers[0])
+	case len(markers) > 1:
+		// Typst cycles through the markers by depth
+		return false, fmt.Sprintf("#set list(marker: (%s))\n", strings.Join(markers, ", "))
+	case list.Symbol == entity.ListSymbolDash:
+		return false, "#set list(marker: [–])\n"
+	default: // bullet
+		return false, ""
+	}
 }
 
-// --- List utilities ---
-
-// typstListConfig returns whether the symbol maps to an enum (vs list) and the #set rule.
-func typstListConfig(symbol entity.ListSymbol) (isEnum bool, config string) {
+// defaultListNumbering returns the Typst numbering pattern of a numbered list symbol.
+func defaultListNumbering(symbol entity.ListSymbol) string {
 	switch symbol {
-	case entity.ListSymbolNumber:
-		return true, "#set enum(numbering: \"1.\")\n"
 	case entity.ListSymbolRoman:
-		return true, "#set enum(numbering: \"i.\")\n"
+		return "i."
 	case entity.ListSymbolLetter:
-		return true, "#set enum(numbering: \"a)\")\n"
-	case entity.ListSymbolDash:
-		return false, "#set list(marker: [–])\n"
-	default: // bullet
-		return false, ""
+		return "a)"
+	case entity.ListSymbolMultilevel:
+		return "1.1."
+	default:
+		return "1."
 	}
 }
 
@@ -0,0 +1,84 @@
+package pdfrenderer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+const (
+	overlayStampFontSizePt = 12
+	overlayBatesFontSizePt = 9
+)
+
+// overlays returns the overlays of the render: those of the page config, replaced by the ones
+// set on the builder, with the builder watermark taking precedence. Nil when there are none.
+func (b *TypstBuilder) overlays(config *portabledoc.PageConfig) *portabledoc.Overlays {
+	merged := portabledoc.MergeOverlays(config.Overlays, b.overlayOverrides)
+	if b.watermark != "" {
+		merged = portabledoc.MergeOverlays(merged, &portabledoc.Overlays{Watermark: b.watermark})
+	}
+	return merged
+}
+
+// overlaysSetup generates a #set page(foreground: ...) directive that places the overlays on
+// every page, above the content. The foreground is laid out per page, so the overlays follow
+// pagination and the Bates number reads the page counter of the page it is placed on.
+func (b *TypstBuilder) overlaysSetup(overlays *portabledoc.Overlays) string {
+	var sb strings.Builder
+	sb.WriteString("#set page(foreground: {\n")
+	if overlays.Watermark != "" {
+		fmt.Fprintf(&sb,
+			"  place(center + horizon, rotate(-45deg, text(size: 56pt, weight: \"bold\", fill: rgb(128, 128, 128, 70))[%s]))\n",
+			escapeTypst(overlays.Watermark))
+	}
+	if stamp := overlays.Stamp; stamp != nil {
+		sb.WriteString("  " + overlayStampPlacement(stamp) + "\n")
+	}
+	if bates := overlays.Bates; bates != nil {
+		sb.WriteString("  " + b.batesPlacement(bates) + "\n")
+	}
+	sb.WriteString("})\n\n")
+	return sb.String()
+}
+
+// overlayStampPlacement places the stamp text in a frame of its color.
+func overlayStampPlacement(stamp *portabledoc.OverlayStamp) string {
+	position := stamp.Position
+	if position == "" {
+		position = portabledoc.DefaultOverlayStampPosition
+	}
+	color := stamp.Color
+	if color == "" {
+		color = portabledoc.DefaultOverlayStampColor
+	}
+	align, dx, dy := pageStampPlacement(position)
+	fill := typstColorExpr(color)
+	return fmt.Sprintf(
+		"place(%s, dx: %dpt, dy: %dpt, box(stroke: 1.5pt + %s, inset: 4pt, radius: 2pt, text(size: %dpt, weight: \"bold\", tracking: 1pt, fill: %s)[%s]))",
+		align, dx, dy, fill, overlayStampFontSizePt, fill, escapeTypst(stamp.Text),
+	)
+}
+
+// batesPlacement places the Bates number of the page: the prefix followed by the page number,
+// offset by the start number and zero-padded to the number of digits.
+func (b *TypstBuilder) batesPlacement(bates *portabledoc.BatesNumbering) string {
+	position := bates.Position
+	if position == "" {
+		position = portabledoc.DefaultBatesPosition
+	}
+	start := bates.Start
+	if start == 0 {
+		start = portabledoc.DefaultBatesStart
+	}
+	digits := bates.Digits
+	if digits == 0 {
+		digits = portabledoc.DefaultBatesDigits
+	}
+	align, dx, dy := pageStampPlacement(position)
+	return fmt.Sprintf(
+		"place(%s, dx: %dpt, dy: %dpt, text(size: %dpt, fill: %s)[%s#context { let n = str(counter(page).get().first() + %d); \"0\" * calc.max(0, %d - n.len()) + n }])",
+		align, dx, dy, overlayBatesFontSizePt, typstColorExpr(b.tokens.BaseTextColor), escapeTypst(bates.Prefix), start-1, digits,
+	)
+}
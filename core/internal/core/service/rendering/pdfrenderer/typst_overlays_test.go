@@ -0,0 +1,80 @@
+package pdfrenderer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+func TestBuild_Overlays(t *testing.T) {
+	doc := testDoc(nil)
+	doc.PageConfig.Overlays = &portabledoc.Overlays{
+		Watermark: "DRAFT",
+		Stamp:     &portabledoc.OverlayStamp{Text: "COPY"},
+	}
+
+	got := newTestBuilder().Build(doc)
+
+	for _, want := range []string{
+		"#set page(foreground: {",
+		`rotate(-45deg, text(size: 56pt, weight: "bold", fill: rgb(128, 128, 128, 70))[DRAFT])`,
+		`place(top + right, dx: -24pt, dy: 18pt, box(stroke: 1.5pt + rgb("#C62828")`,
+		`[COPY]`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in:\n%s", want, got)
+		}
+	}
+	if strings.Count(got, "foreground:") != 1 {
+		t.Errorf("expected the overlays in a single foreground, got:\n%s", got)
+	}
+}
+
+func TestBuild_OverlaysRequestReplacesTemplate(t *testing.T) {
+	doc := testDoc(nil)
+	doc.PageConfig.Overlays = &portabledoc.Overlays{
+		Watermark: "DRAFT",
+		Stamp:     &portabledoc.OverlayStamp{Text: "COPY"},
+	}
+
+	builder := newTestBuilder()
+	builder.SetOverlays(&portabledoc.Overlays{
+		Stamp: &portabledoc.OverlayStamp{Text: "ORIGINAL", Position: portabledoc.PageStampBottomLeft, Color: "#1565C0"},
+		Bates: &portabledoc.BatesNumbering{Prefix: "ACME_", Start: 41, Digits: 8},
+	})
+	builder.SetWatermark("PREVIEW")
+	got := builder.Build(doc)
+
+	for _, want := range []string{
+		"[PREVIEW]",
+		`place(bottom + left, dx: 24pt, dy: -18pt, box(stroke: 1.5pt + rgb("#1565C0")`,
+		"[ORIGINAL]",
+		`[ACME\_#context { let n = str(counter(page).get().first() + 40); "0" * calc.max(0, 8 - n.len()) + n }]`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in:\n%s", want, got)
+		}
+	}
+	for _, replaced := range []string{"[DRAFT]", "[COPY]"} {
+		if strings.Contains(got, replaced) {
+			t.Errorf("expected %s to be replaced, got:\n%s", replaced, got)
+		}
+	}
+}
+
+func TestBuild_BatesDefaults(t *testing.T) {
+	doc := testDoc(nil)
+	doc.PageConfig.Overlays = &portabledoc.Overlays{Bates: &portabledoc.BatesNumbering{}}
+
+	got := newTestBuilder().Build(doc)
+
+	want := `place(bottom + right, dx: -24pt, dy: -18pt, text(size: 9pt, fill: rgb("#333333"))[#context { let n = str(counter(page).get().first() + 0); "0" * calc.max(0, 6 - n.len()) + n }])`
+	if !strings.Contains(got, want) {
+		t.Errorf("expected %q in:\n%s", want, got)
+	}
+
+	if strings.Contains(newTestBuilder().Build(testDoc(nil)), "foreground:") {
+		t.Error("expected no foreground without overlays")
+	}
+}
@@ -0,0 +1,134 @@
+package pdfrenderer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/formatter"
+)
+
+const (
+	pageStampDefaultFormat = "{{page}} / {{total}}"
+	pageStampFontSizePt    = 8
+	pageStampInsetXPt      = 24
+	pageStampInsetYPt      = 18
+
+	surfaceDefaultDateFormat = "DD/MM/YYYY"
+
+	// Page counter expressions. They only resolve inside a context, where Typst iterates the
+	// layout until the final page count is stable, so no second render pass is needed.
+	typstCurrentPageExpr = "#{context counter(page).get().first()}"
+	typstTotalPagesExpr  = "#{context counter(page).final().first()}"
+)
+
+// pageNumber renders the current page or the total page count where the node is placed.
+func (c *TypstConverter) pageNumber(node portabledoc.Node) string {
+	display, _ := node.Attrs["display"].(string)
+	if display == portabledoc.PageNumberCurrent {
+		return c.applyMarks(typstCurrentPageExpr, node.Marks)
+	}
+	return c.applyMarks(typstTotalPagesExpr, node.Marks)
+}
+
+// pageStampSetup generates a #set page(background: ...) directive that prints the page
+// counter on every page. The background is used because the header and footer render on
+// the first and last page by default, and the foreground is taken by the overlays.
+func (b *TypstBuilder) pageStampSetup(stamp *portabledoc.PageStamp) string {
+	format := stamp.Format
+	if strings.TrimSpace(format) == "" {
+		format = pageStampDefaultFormat
+	}
+
+	align, dx, dy := pageStampPlacement(stamp.Position)
+	return fmt.Sprintf(
+		"#set page(background: place(%s, dx: %dpt, dy: %dpt, text(size: %dpt, fill: %s)[%s]))\n\n",
+		align, dx, dy, pageStampFontSizePt, typstColorExpr(b.tokens.BaseTextColor), pageCounterMarkup(format),
+	)
+}
+
+// pageStampPlacement maps a stamp position to a Typst alignment and the offset that keeps
+// the stamp off the page edge.
+func pageStampPlacement(position string) (align string, dx, dy int) {
+	switch position {
+	case portabledoc.PageStampTopLeft:
+		return "top + left", pageStampInsetXPt, pageStampInsetYPt
+	case portabledoc.PageStampTopRight:
+		return "top + right", -pageStampInsetXPt, pageStampInsetYPt
+	case portabledoc.PageStampBottomLeft:
+		return "bottom + left", pageStampInsetXPt, -pageStampInsetYPt
+	case portabledoc.PageStampBottomCenter:
+		return "bottom + center", 0, -pageStampInsetYPt
+	default: // bottom-right
+		return "bottom + right", -pageStampInsetXPt, -pageStampInsetYPt
+	}
+}
+
+// pageCounterMarkup escapes format text and replaces the {{page}} and {{total}} placeholders
+// with page counter expressions.
+func pageCounterMarkup(format string) string {
+	return placeholderMarkup(format, pageCounterExpr)
+}
+
+// pageCounterExpr returns the page counter expression of a {{page}} or {{total}} placeholder.
+func pageCounterExpr(name string) (string, bool) {
+	switch name {
+	case "page":
+		return typstCurrentPageExpr, true
+	case "total":
+		return typstTotalPagesExpr, true
+	}
+	return "", false
+}
+
+// placeholderMarkup escapes text and replaces its {{name}} placeholders with the markup expr
+// returns for them. Placeholders expr does not know are kept as written.
+func placeholderMarkup(text string, expr func(name string) (string, bool)) string {
+	var sb strings.Builder
+	for text != "" {
+		start := strings.Index(text, "{{")
+		if start < 0 {
+			sb.WriteString(escapeTypst(text))
+			break
+		}
+		end := strings.Index(text[start:], "}}")
+		if end < 0 {
+			sb.WriteString(escapeTypst(text))
+			break
+		}
+		end += start
+
+		sb.WriteString(escapeTypst(text[:start]))
+		if markup, ok := expr(strings.TrimSpace(text[start+2 : end])); ok {
+			sb.WriteString(markup)
+		} else {
+			sb.WriteString(escapeTypst(text[start : end+2]))
+		}
+		text = text[end+2:]
+	}
+	return sb.String()
+}
+
+// surfaceExpr returns the markup of a placeholder in header and footer text: the page counters,
+// {{date}} or {{date:<pattern>}} for the render date, {{title}} for the document title, and the
+// key of an injectable for its value.
+func (c *TypstConverter) surfaceExpr(name string) (string, bool) {
+	if markup, ok := pageCounterExpr(name); ok {
+		return markup, true
+	}
+	switch {
+	case name == "title":
+		return escapeTypst(c.documentTitle), true
+	case name == "date":
+		return escapeTypst(formatter.FormatTime(c.inTimeZone(c.renderDate, nil), surfaceDefaultDateFormat)), true
+	case strings.HasPrefix(name, "date:"):
+		return escapeTypst(formatter.FormatTime(c.inTimeZone(c.renderDate, nil), strings.TrimPrefix(name, "date:"))), true
+	}
+	if v, ok := c.injectables[name]; ok {
+		return escapeTypst(c.formatInjectableValue(v, nil)), true
+	}
+	if v := c.getDefaultValue(name); v != "" {
+		return escapeTypst(v), true
+	}
+	return "", false
+}
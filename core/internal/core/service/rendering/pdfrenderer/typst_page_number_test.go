@@ -0,0 +1,59 @@
+package pdfrenderer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+func TestTypstConverter_PageNumber(t *testing.T) {
+	c := newConverter(nil, nil)
+
+	tests := []struct {
+		name  string
+		attrs map[string]any
+		want  string
+	}{
+		{"total by default", nil, typstTotalPagesExpr},
+		{"total", map[string]any{"display": portabledoc.PageNumberTotal}, typstTotalPagesExpr},
+		{"current", map[string]any{"display": portabledoc.PageNumberCurrent}, typstCurrentPageExpr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := c.ConvertNode(portabledoc.Node{Type: portabledoc.NodeTypePageNumber, Attrs: tt.attrs})
+			if got != tt.want {
+				t.Errorf("ConvertNode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageCounterMarkup(t *testing.T) {
+	got := pageCounterMarkup("Page {{page}} of {{ total }} #{{other}}")
+	want := "Page " + typstCurrentPageExpr + " of " + typstTotalPagesExpr + ` \#{{other}}`
+	if got != want {
+		t.Errorf("pageCounterMarkup() = %q, want %q", got, want)
+	}
+
+	if got := pageCounterMarkup("{{page"); got != "{{page" {
+		t.Errorf("expected an unclosed placeholder to stay literal, got %q", got)
+	}
+}
+
+func TestBuild_PageStamp(t *testing.T) {
+	doc := testDoc(nil)
+	doc.PageConfig.PageStamp = &portabledoc.PageStamp{Position: portabledoc.PageStampTopRight}
+
+	got := newTestBuilder().Build(doc)
+
+	want := "#set page(background: place(top + right, dx: -24pt, dy: 18pt, text(size: 8pt, fill: rgb(\"#333333\"))[" +
+		typstCurrentPageExpr + " / " + typstTotalPagesExpr + "]))"
+	if !strings.Contains(got, want) {
+		t.Errorf("expected page stamp %q, got:\n%s", want, got)
+	}
+
+	if strings.Contains(newTestBuilder().Build(testDoc(nil)), "background:") {
+		t.Error("expected no page stamp without pageStamp config")
+	}
+}
@@ -0,0 +1,74 @@
+package pdfrenderer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+// paginationHintNodes are the block nodes that accept keepTogether, breakBefore,
+// breakAfter and minOrphanLines attrs.
+var paginationHintNodes = portabledoc.Set[string]{
+	portabledoc.NodeTypeParagraph:     {},
+	portabledoc.NodeTypeHeading:       {},
+	portabledoc.NodeTypeBlockquote:    {},
+	portabledoc.NodeTypeBulletList:    {},
+	portabledoc.NodeTypeOrderedList:   {},
+	portabledoc.NodeTypeTaskList:      {},
+	portabledoc.NodeTypeListInjector:  {},
+	portabledoc.NodeTypeTable:         {},
+	portabledoc.NodeTypeTableInjector: {},
+}
+
+// transparentNodes render their children in place, so children keep the parent's nesting level.
+var transparentNodes = portabledoc.Set[string]{
+	portabledoc.NodeTypeConditional: {},
+	portabledoc.NodeTypeRepeater:    {},
+}
+
+// orphanCostPercent strongly discourages Typst from leaving a single line of a paragraph
+// at the bottom (orphan) or top (widow) of a page.
+const orphanCostPercent = 1000
+
+// convertWithPaginationHints renders a node and applies its pagination hints.
+// Page breaks are only emitted for top-level nodes: Typst rejects them inside containers.
+func (c *TypstConverter) convertWithPaginationHints(node portabledoc.Node, handler typstNodeHandler) string {
+	topLevel := c.nestingDepth == 0
+	if !transparentNodes.Contains(node.Type) {
+		c.nestingDepth++
+		defer func() { c.nestingDepth-- }()
+	}
+
+	out := handler(node)
+	if !paginationHintNodes.Contains(node.Type) || len(node.Attrs) == 0 || out == "" {
+		return out
+	}
+
+	if lines, ok := node.Attrs["minOrphanLines"].(float64); ok && lines > 0 {
+		out = wrapOrphanCosts(out, int(lines))
+	}
+	if keep, _ := node.Attrs["keepTogether"].(bool); keep {
+		out = fmt.Sprintf("#block(breakable: false)[\n%s\n]\n", strings.TrimRight(out, "\n"))
+	}
+	if !topLevel {
+		return out
+	}
+	if before, _ := node.Attrs["breakBefore"].(bool); before {
+		out = "#pagebreak(weak: true)\n" + out
+	}
+	if after, _ := node.Attrs["breakAfter"].(bool); after {
+		out = strings.TrimRight(out, "\n") + "\n#pagebreak(weak: true)\n"
+	}
+	return out
+}
+
+// wrapOrphanCosts scopes Typst's orphan and widow costs to the content. Typst only weighs
+// single-line orphans and widows, so 1 allows them and any higher minimum avoids them.
+func wrapOrphanCosts(content string, minLines int) string {
+	cost := orphanCostPercent
+	if minLines <= 1 {
+		cost = 0
+	}
+	return fmt.Sprintf("#[\n#set text(costs: (orphan: %d%%, widow: %d%%))\n%s\n]\n", cost, cost, strings.TrimRight(content, "\n"))
+}
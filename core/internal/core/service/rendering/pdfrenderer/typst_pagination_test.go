@@ -0,0 +1,60 @@
+package pdfrenderer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+func TestTypstConverter_PaginationHintsTopLevel(t *testing.T) {
+	c := newConverter(nil, nil)
+	node := paragraphNode(textNode("Clause"))
+	node.Attrs = map[string]any{"keepTogether": true, "breakBefore": true, "breakAfter": true}
+
+	got := c.ConvertNode(node)
+
+	want := "#pagebreak(weak: true)\n#block(breakable: false)[\nClause\n]\n#pagebreak(weak: true)\n"
+	if got != want {
+		t.Errorf("ConvertNode() = %q, want %q", got, want)
+	}
+}
+
+func TestTypstConverter_PaginationHintsNestedSkipBreaks(t *testing.T) {
+	c := newConverter(nil, nil)
+	inner := paragraphNode(textNode("Quoted"))
+	inner.Attrs = map[string]any{"keepTogether": true, "breakBefore": true}
+
+	got := c.ConvertNode(portabledoc.Node{Type: portabledoc.NodeTypeBlockquote, Content: []portabledoc.Node{inner}})
+
+	if strings.Contains(got, "pagebreak") {
+		t.Errorf("expected no page break inside a container, got:\n%s", got)
+	}
+	if !strings.Contains(got, "#block(breakable: false)[\nQuoted\n]") {
+		t.Errorf("expected keepTogether inside a container, got:\n%s", got)
+	}
+}
+
+func TestTypstConverter_PaginationHintsThroughConditional(t *testing.T) {
+	c := newConverter(nil, nil)
+	inner := paragraphNode(textNode("Annex"))
+	inner.Attrs = map[string]any{"breakBefore": true}
+	conditional := portabledoc.Node{
+		Type:    portabledoc.NodeTypeConditional,
+		Attrs:   map[string]any{},
+		Content: []portabledoc.Node{inner},
+	}
+
+	if got := c.ConvertNode(conditional); !strings.Contains(got, "#pagebreak(weak: true)\nAnnex") {
+		t.Errorf("expected a page break for a top-level node inside a conditional, got:\n%s", got)
+	}
+}
+
+func TestWrapOrphanCosts(t *testing.T) {
+	if got := wrapOrphanCosts("text", 3); !strings.Contains(got, "#set text(costs: (orphan: 1000%, widow: 1000%))") {
+		t.Errorf("expected high orphan costs, got %q", got)
+	}
+	if got := wrapOrphanCosts("text", 1); !strings.Contains(got, "(orphan: 0%, widow: 0%)") {
+		t.Errorf("expected orphans allowed, got %q", got)
+	}
+}
@@ -0,0 +1,165 @@
+package pdfrenderer
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// typstProjectAssetsDir is the directory of the project archive that holds the images.
+const typstProjectAssetsDir = "assets"
+
+// fontParamPattern matches the font parameter of generated text rules, whose value is a quoted
+// family or a tuple of them.
+var fontParamPattern = regexp.MustCompile(`font: (\([^)]*\)|"[^"]*")`)
+
+// quotedStringPattern matches a Typst string literal.
+var quotedStringPattern = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"`)
+
+// typstProjectReadme explains how to compile an exported project.
+const typstProjectReadme = `# Typst project
+
+Generated by pdf-forge from a template version and render payload.
+
+Compile it with the typst CLI:
+
+    typst compile main.typ output.pdf --font-path <fonts-dir>
+
+Fonts are not bundled. fonts.json lists the families main.typ uses and whether the
+server that generated it had them installed. Print imposition is applied after
+compiling and is not part of the project.
+`
+
+// typstFontsManifest lists the fonts an exported Typst project uses.
+type typstFontsManifest struct {
+	TypstVersion string            `json:"typstVersion,omitempty"`
+	Families     []typstFontFamily `json:"families"`
+}
+
+// typstFontFamily is a font family used by an exported Typst project.
+type typstFontFamily struct {
+	Name string `json:"name"`
+	// Available reports whether the server had the family; omitted when the server fonts could not be listed.
+	Available *bool `json:"available,omitempty"`
+}
+
+// ExportTypstProject generates the Typst source a render would compile, without compiling it, and
+// packages it as a zip with the images it references and a fonts manifest so the compile can be
+// reproduced offline.
+func (s *Service) ExportTypstProject(ctx context.Context, req *port.RenderPreviewRequest) (*port.TypstProjectResult, error) {
+	if err := s.acquireSlot(ctx); err != nil {
+		return nil, err
+	}
+	defer s.releaseSlot()
+
+	job, err := s.prepare(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer job.close()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	source := job.source
+	for _, name := range job.images {
+		asset := typstProjectAssetsDir + "/" + name
+		source = strings.ReplaceAll(source, strconv.Quote(name), strconv.Quote(asset))
+
+		data, err := os.ReadFile(filepath.Join(job.rootDir, name))
+		if err != nil {
+			slog.WarnContext(ctx, "failed to read image for typst project, using placeholder",
+				slog.String("image", name), slog.Any("error", err))
+			data = getPlaceholderPNG()
+		}
+		if err := writeZipFile(zw, asset, data); err != nil {
+			return nil, err
+		}
+	}
+
+	manifest, err := json.MarshalIndent(s.fontsManifest(ctx, source), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode fonts manifest: %w", err)
+	}
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{"main.typ", []byte(source)},
+		{"fonts.json", manifest},
+		{"README.md", []byte(typstProjectReadme)},
+	}
+	for _, f := range files {
+		if err := writeZipFile(zw, f.name, f.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write typst project: %w", err)
+	}
+
+	return &port.TypstProjectResult{
+		Archive:  buf.Bytes(),
+		Filename: strings.TrimSuffix(s.generateFilename(job.doc.Meta.Title), ".pdf") + "-typst.zip",
+	}, nil
+}
+
+// fontsManifest lists the font families the source uses, with the typst version and whether each
+// family is installed when the typst binary can report them.
+func (s *Service) fontsManifest(ctx context.Context, source string) typstFontsManifest {
+	manifest := typstFontsManifest{Families: []typstFontFamily{}}
+	if s.typst != nil {
+		if version, err := s.typst.Version(ctx); err == nil {
+			manifest.TypstVersion = version
+		}
+	}
+	installed := s.installedFontFamilies(ctx)
+
+	for _, name := range fontFamilies(source) {
+		family := typstFontFamily{Name: name}
+		if installed != nil {
+			available := installed[strings.ToLower(name)]
+			family.Available = &available
+		}
+		manifest.Families = append(manifest.Families, family)
+	}
+	return manifest
+}
+
+// fontFamilies returns the font families referenced by font parameters in Typst source, sorted.
+func fontFamilies(source string) []string {
+	var families []string
+	for _, param := range fontParamPattern.FindAllStringSubmatch(source, -1) {
+		for _, quoted := range quotedStringPattern.FindAllString(param[1], -1) {
+			name, err := strconv.Unquote(quoted)
+			if err != nil || name == "" || slices.Contains(families, name) {
+				continue
+			}
+			families = append(families, name)
+		}
+	}
+	slices.Sort(families)
+	return families
+}
+
+func writeZipFile(zw *zip.Writer, name string, data []byte) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to typst project: %w", name, err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to add %s to typst project: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package pdfrenderer
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+func TestExportTypstProject(t *testing.T) {
+	// No typst binary: the project is generated without compiling and the manifest omits availability.
+	service := &Service{designTokens: DefaultDesignTokens(), images: NewImageFetcher(ImageFetcherOptions{})}
+
+	text := "Hello"
+	dataURL := "data:image/png;base64," + base64.StdEncoding.EncodeToString(getPlaceholderPNG())
+	doc := &portabledoc.Document{
+		Version: portabledoc.CurrentVersion,
+		Meta:    portabledoc.Meta{Title: "Service Contract", Language: "en"},
+		PageConfig: portabledoc.PageConfig{
+			FormatID: portabledoc.PageFormatA4,
+			Width:    794,
+			Height:   1123,
+			Margins:  portabledoc.MarginPresets[portabledoc.MarginPresetNormal],
+		},
+		Content: &portabledoc.ProseMirrorDoc{
+			Type: portabledoc.NodeTypeDoc,
+			Content: []portabledoc.Node{
+				{Type: portabledoc.NodeTypeParagraph, Content: []portabledoc.Node{{Type: portabledoc.NodeTypeText, Text: &text}}},
+				{Type: portabledoc.NodeTypeCustomImage, Attrs: map[string]any{"src": dataURL, "width": 100.0, "height": 100.0}},
+			},
+		},
+	}
+
+	result, err := service.ExportTypstProject(context.Background(), &port.RenderPreviewRequest{Document: doc})
+	if err != nil {
+		t.Fatalf("ExportTypstProject() error = %v", err)
+	}
+	if result.Filename != "Service Contract-typst.zip" {
+		t.Errorf("Filename = %q", result.Filename)
+	}
+
+	files := readZip(t, result.Archive)
+	for _, name := range []string{"main.typ", "fonts.json", "README.md", "assets/img_1.png"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("archive is missing %s, has %v", name, slices.Sorted(maps.Keys(files)))
+		}
+	}
+	if main := files["main.typ"]; !strings.Contains(main, `"assets/img_1.png"`) || !strings.Contains(main, "Hello") {
+		t.Errorf("main.typ should reference the bundled image and contain the text:\n%s", main)
+	}
+
+	var manifest typstFontsManifest
+	if err := json.Unmarshal([]byte(files["fonts.json"]), &manifest); err != nil {
+		t.Fatalf("fonts.json: %v", err)
+	}
+	if len(manifest.Families) == 0 || manifest.Families[0].Available != nil {
+		t.Errorf("fonts.json = %+v, want families without availability", manifest)
+	}
+}
+
+func TestFontFamilies(t *testing.T) {
+	source := "#set text(font: (\"Inter\", \"Liberation Sans\"), size: 11pt)\n" +
+		"#text(font: \"Georgia\")[x]\n#text(font: (\"Inter\"))[y]\n"
+
+	got := fontFamilies(source)
+	want := []string{"Georgia", "Inter", "Liberation Sans"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("fontFamilies() = %v, want %v", got, want)
+	}
+}
+
+func readZip(t *testing.T, archive []byte) map[string]string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatalf("invalid zip: %v", err)
+	}
+	files := make(map[string]string, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(data)
+	}
+	return files
+}
@@ -0,0 +1,94 @@
+package pdfrenderer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+// referenceLabel labels the metadata recorded after every link while the document has a
+// references node.
+const referenceLabel = "pf-reference"
+
+// referencesPreamble defines pf-references(): the links of the compiled document, once per URL
+// in order of first appearance. Querying the compiled document leaves out links of conditional
+// branches that were not rendered and lets the table come before the links it lists.
+const referencesPreamble = `#let pf-references() = {
+  let refs = ()
+  for m in query(<` + referenceLabel + `>) {
+    if refs.all(r => r.url != m.value.url) { refs.push(m.value) }
+  }
+  refs
+}
+
+`
+
+// referenceColumnLabels are the header labels of the references table per language.
+var referenceColumnLabels = map[string][3]string{
+	portabledoc.LanguageEnglish: {"#", "Reference", "URL"},
+	portabledoc.LanguageSpanish: {"#", "Referencia", "URL"},
+}
+
+// enableReferences makes the converter record every link for the references nodes of doc.
+func (c *TypstConverter) enableReferences(doc *portabledoc.Document) {
+	c.collectReferences = true
+	for _, node := range doc.NodesOfType(portabledoc.NodeTypeReferences) {
+		if attrs, err := portabledoc.ParseReferencesAttrs(node.Attrs); err == nil && attrs.Markers {
+			c.referenceMarkers = true
+		}
+	}
+}
+
+// referenceHref returns the href of a linked text node when links are being recorded.
+func (c *TypstConverter) referenceHref(node portabledoc.Node) string {
+	if !c.collectReferences || node.Text == nil {
+		return ""
+	}
+	return portabledoc.LinkHref(node)
+}
+
+// linkRun converts the text nodes of one link, then records the link once with its whole text.
+func (c *TypstConverter) linkRun(nodes []portabledoc.Node, href string) string {
+	var sb, title strings.Builder
+	for _, node := range nodes {
+		sb.WriteString(c.ConvertNode(node))
+		if node.Text != nil {
+			title.WriteString(*node.Text)
+		}
+	}
+
+	url := escapeTypstString(href)
+	fmt.Fprintf(&sb, "#metadata((url: \"%s\", title: \"%s\"))<%s>",
+		url, escapeTypstString(strings.TrimSpace(title.String())), referenceLabel)
+	if c.referenceMarkers {
+		fmt.Fprintf(&sb, "#context super[\\[#(pf-references().position(r => r.url == \"%s\") + 1)\\]]", url)
+	}
+	return sb.String()
+}
+
+// references renders the numbered table of the links of the document. Nothing is rendered
+// when the document has no links.
+func (c *TypstConverter) references(node portabledoc.Node) string {
+	attrs, err := portabledoc.ParseReferencesAttrs(node.Attrs)
+	if err != nil {
+		attrs = &portabledoc.ReferencesAttrs{}
+	}
+	labels, ok := referenceColumnLabels[c.fallbackLang()]
+	if !ok {
+		labels = referenceColumnLabels[portabledoc.LanguageEnglish]
+	}
+
+	var sb strings.Builder
+	sb.WriteString("#context {\n  let refs = pf-references()\n  if refs.len() > 0 {\n")
+	if title := strings.TrimSpace(attrs.Title); title != "" {
+		fmt.Fprintf(&sb, "    block(below: 0.75em, strong[%s])\n", escapeTypst(title))
+	}
+	fmt.Fprintf(&sb, "    table(\n      columns: (auto, 1fr, 1.5fr),\n      inset: %s,\n      stroke: 0.5pt + %s,\n      fill: (x, y) => if y == 0 { %s },\n",
+		c.tokens.TableCellInset, c.tokens.TableStrokeColor, typstColorExpr(c.tokens.TableHeaderFillDefault))
+	fmt.Fprintf(&sb, "      table.header([*%s*], [*%s*], [*%s*]),\n",
+		escapeTypst(labels[0]), escapeTypst(labels[1]), escapeTypst(labels[2]))
+	sb.WriteString("      ..refs.enumerate().map(((i, r)) => ([#(i + 1)], [#r.title], link(r.url))).flatten(),\n")
+	sb.WriteString("    )\n  }\n}\n")
+	return sb.String()
+}
@@ -0,0 +1,76 @@
+package pdfrenderer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+func referencesDoc(lang string, nodes ...portabledoc.Node) *portabledoc.Document {
+	return &portabledoc.Document{
+		Meta:       portabledoc.Meta{Title: "Refs", Language: lang},
+		PageConfig: portabledoc.PageConfig{FormatID: portabledoc.PageFormatA4, Width: 794, Height: 1123},
+		Content:    &portabledoc.ProseMirrorDoc{Type: "doc", Content: nodes},
+	}
+}
+
+func TestTypstBuilder_ReferencesCollectsLinks(t *testing.T) {
+	link := mark(portabledoc.MarkTypeLink, map[string]any{"href": "https://example.com/spec"})
+	doc := referencesDoc("es",
+		portabledoc.Node{Type: portabledoc.NodeTypeReferences, Attrs: map[string]any{"title": "Sources", "markers": true}},
+		paragraphNode(
+			textNode("See "),
+			markedTextNode("the ", link),
+			markedTextNode("spec", link, mark(portabledoc.MarkTypeBold)),
+			textNode(" for details."),
+		),
+	)
+
+	got := NewTypstBuilder(nil, nil, DefaultDesignTokens()).Build(doc)
+
+	checks := []string{
+		"#let pf-references() = {",
+		`#link("https://example.com/spec")[the ]`,
+		`#metadata((url: "https://example.com/spec", title: "the spec"))<pf-reference>`,
+		`#context super[\[#(pf-references().position(r => r.url == "https://example.com/spec") + 1)\]] for details.`,
+		"block(below: 0.75em, strong[Sources])",
+		`table.header([*\#*], [*Referencia*], [*URL*])`,
+	}
+	for _, check := range checks {
+		if !strings.Contains(got, check) {
+			t.Errorf("expected output to contain %q, got:\n%s", check, got)
+		}
+	}
+	if n := strings.Count(got, "))<pf-reference>"); n != 1 {
+		t.Errorf("expected the fragments of one link to be recorded once, got %d", n)
+	}
+}
+
+func TestTypstBuilder_ReferencesWithoutMarkers(t *testing.T) {
+	link := mark(portabledoc.MarkTypeLink, map[string]any{"href": "https://example.com"})
+	doc := referencesDoc("en",
+		paragraphNode(markedTextNode("Example", link)),
+		portabledoc.Node{Type: portabledoc.NodeTypeReferences},
+	)
+
+	got := NewTypstBuilder(nil, nil, DefaultDesignTokens()).Build(doc)
+
+	if !strings.Contains(got, "))<pf-reference>") || !strings.Contains(got, "[*Reference*]") {
+		t.Errorf("expected the link to be recorded for the table, got:\n%s", got)
+	}
+	if strings.Contains(got, "super[") || strings.Contains(got, "strong[") {
+		t.Errorf("expected no markers and no title, got:\n%s", got)
+	}
+}
+
+func TestTypstBuilder_LinksNotRecordedWithoutReferences(t *testing.T) {
+	link := mark(portabledoc.MarkTypeLink, map[string]any{"href": "https://example.com"})
+	doc := referencesDoc("en", paragraphNode(markedTextNode("Example", link)))
+
+	got := NewTypstBuilder(nil, nil, DefaultDesignTokens()).Build(doc)
+
+	if strings.Contains(got, "pf-reference") {
+		t.Errorf("expected no reference metadata without a references node, got:\n%s", got)
+	}
+}
@@ -5,7 +5,14 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
 )
 
 // TypstRenderer handles PDF generation using the Typst CLI.
@@ -29,6 +36,13 @@ type TypstOptions struct {
 
 	// AcquireTimeout is the max wait time to acquire a render slot.
 	AcquireTimeout time.Duration
+
+	// FontFallbacks are the fonts used for scripts the base font stack does not cover
+	// (default: DefaultFontFallbacks).
+	FontFallbacks []FontFallback
+
+	// Metrics records render durations and Typst failures (default: none).
+	Metrics port.Metrics
 }
 
 // DefaultTypstOptions returns sensible default options.
@@ -56,37 +70,150 @@ func NewTypstRenderer(opts TypstOptions) (*TypstRenderer, error) {
 	return &TypstRenderer{opts: opts}, nil
 }
 
-// GeneratePDF compiles Typst source to PDF bytes.
+// GeneratePDF compiles Typst source to PDF bytes, returning the compiler warnings with them.
 // rootDir is optional; if set, it's passed as --root to typst for resolving local file paths.
-func (r *TypstRenderer) GeneratePDF(ctx context.Context, typstSource string, rootDir string) ([]byte, error) {
+// fontDirs are searched for fonts after the configured font directories.
+func (r *TypstRenderer) GeneratePDF(ctx context.Context, typstSource string, rootDir string, fontDirs ...string) ([]byte, []string, error) {
+	return r.compile(ctx, typstSource, r.buildArgs(rootDir, fontDirs, "pdf"))
+}
+
+// GeneratePNG compiles single-page Typst source to a PNG image at the given pixels per inch.
+func (r *TypstRenderer) GeneratePNG(ctx context.Context, typstSource string, rootDir string, ppi int) ([]byte, error) {
+	out, _, err := r.compile(ctx, typstSource, r.buildArgs(rootDir, nil, "png", "--ppi", strconv.Itoa(ppi)))
+	return out, err
+}
+
+func (r *TypstRenderer) compile(ctx context.Context, typstSource string, args []string) ([]byte, []string, error) {
+	out, stderr, err := r.run(ctx, []byte(typstSource), args)
+	if err != nil {
+		compileErr := &entity.CompileError{Stderr: stderr, Err: fmt.Errorf("typst compile failed: %w", err)}
+		compileErr.Line, compileErr.Column, compileErr.Message = parseTypstError(stderr)
+		return nil, nil, compileErr
+	}
+	return out, parseTypstWarnings(stderr), nil
+}
+
+// maxTypstWarnings bounds the warnings kept per compile; a template repeating a problem in a long
+// table would otherwise return thousands.
+const maxTypstWarnings = 20
+
+// typstWarningPattern matches a warning in the short diagnostic format:
+// "<stdin>:12:5: warning: unknown font family: acme sans".
+var typstWarningPattern = regexp.MustCompile(`^(?:.*?:(\d+):\d+: )?warning: (.+)$`)
+
+// parseTypstWarnings returns the distinct warnings of a successful compile, in order, with the line
+// of the generated source they point at.
+func parseTypstWarnings(stderr string) []string {
+	var warnings []string
+	for line := range strings.Lines(stderr) {
+		m := typstWarningPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		warning := m[2]
+		if m[1] != "" {
+			warning = fmt.Sprintf("%s (line %s)", warning, m[1])
+		}
+		if slices.Contains(warnings, warning) {
+			continue
+		}
+		if len(warnings) == maxTypstWarnings {
+			break
+		}
+		warnings = append(warnings, warning)
+	}
+	return warnings
+}
+
+// typstErrorPattern matches an error in the short diagnostic format:
+// "<stdin>:12:5: error: unknown variable: foo".
+var typstErrorPattern = regexp.MustCompile(`^(?:(.*?):(\d+):(\d+): )?error: (.+)$`)
+
+// parseTypstError returns the first error of a failed compile with its line and column in the
+// source, or 0 when Typst reported none or the error is in a file other than the source, such as
+// a package.
+func parseTypstError(stderr string) (line, column int, message string) {
+	for l := range strings.Lines(stderr) {
+		m := typstErrorPattern.FindStringSubmatch(strings.TrimSpace(l))
+		if m == nil {
+			continue
+		}
+		if m[1] == "<stdin>" {
+			line, _ = strconv.Atoi(m[2])
+			column, _ = strconv.Atoi(m[3])
+		}
+		return line, column, m[4]
+	}
+	return 0, 0, ""
+}
+
+// Version returns the version string reported by the typst binary.
+func (r *TypstRenderer) Version(ctx context.Context) (string, error) {
+	out, _, err := r.run(ctx, nil, []string{"--version"})
+	if err != nil {
+		return "", fmt.Errorf("typst version failed: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// FontFamilies lists the font families typst finds, including those in the configured font directories.
+func (r *TypstRenderer) FontFamilies(ctx context.Context) ([]string, error) {
+	args := []string{"fonts"}
+	for _, dir := range r.opts.FontDirs {
+		args = append(args, "--font-path", dir)
+	}
+	out, _, err := r.run(ctx, nil, args)
+	if err != nil {
+		return nil, fmt.Errorf("typst fonts failed: %w", err)
+	}
+
+	var families []string
+	for line := range strings.Lines(string(out)) {
+		if family := strings.TrimSpace(line); family != "" {
+			families = append(families, family)
+		}
+	}
+	return families, nil
+}
+
+// run executes the typst binary with the given stdin, returning its stdout and stderr. Stderr is
+// also returned when the command fails.
+func (r *TypstRenderer) run(ctx context.Context, stdin []byte, args []string) ([]byte, string, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.opts.Timeout)
 	defer cancel()
 
-	args := r.buildArgs(rootDir)
 	cmd := exec.CommandContext(ctx, r.opts.BinPath, args...) //nolint:gosec // BinPath is validated at init
-	cmd.Stdin = bytes.NewReader([]byte(typstSource))
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
 	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("typst compile failed: %w\nstderr: %s", err, stderr.String())
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			// Killed by the timeout or a canceled request, not failed
+			return nil, stderr.String(), fmt.Errorf("%w: %w\nstderr: %s", ctxErr, err, stderr.String())
+		}
+		return nil, stderr.String(), fmt.Errorf("%w\nstderr: %s", err, stderr.String())
 	}
 
-	return stdout.Bytes(), nil
+	return stdout.Bytes(), stderr.String(), nil
 }
 
 // buildArgs constructs the CLI arguments for typst compile.
-func (r *TypstRenderer) buildArgs(rootDir string) []string {
-	args := make([]string, 0, 3+2*len(r.opts.FontDirs)+4)
-	args = append(args, "compile", "--format", "pdf")
+// formatArgs is the output format followed by any format-specific flags.
+func (r *TypstRenderer) buildArgs(rootDir string, fontDirs []string, format string, formatArgs ...string) []string {
+	args := make([]string, 0, 5+len(formatArgs)+2*(len(r.opts.FontDirs)+len(fontDirs))+4)
+	args = append(args, "compile", "--format", format, "--diagnostic-format", "short")
+	args = append(args, formatArgs...)
 
 	if rootDir != "" {
 		args = append(args, "--root", rootDir)
 	}
 
-	for _, dir := range r.opts.FontDirs {
+	for _, dir := range slices.Concat(r.opts.FontDirs, fontDirs) {
 		args = append(args, "--font-path", dir)
 	}
 
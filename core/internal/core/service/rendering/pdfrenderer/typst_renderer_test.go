@@ -0,0 +1,77 @@
+package pdfrenderer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseTypstWarnings(t *testing.T) {
+	stderr := strings.Join([]string{
+		"<stdin>:12:5: warning: unknown font family: acme sans",
+		"<stdin>:40:9: warning: current font does not support the glyph: ✓",
+		"<stdin>:12:5: warning: unknown font family: acme sans",
+		"warning: layout did not converge within 5 attempts",
+		"<stdin>:7:1: hint: try checking the font name",
+		"",
+	}, "\n")
+
+	got := parseTypstWarnings(stderr)
+	want := []string{
+		"unknown font family: acme sans (line 12)",
+		"current font does not support the glyph: ✓ (line 40)",
+		"layout did not converge within 5 attempts",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("parseTypstWarnings() = %q, want %q", got, want)
+	}
+}
+
+func TestParseTypstWarnings_Empty(t *testing.T) {
+	if got := parseTypstWarnings(""); got != nil {
+		t.Errorf("parseTypstWarnings(\"\") = %q, want nil", got)
+	}
+}
+
+func TestParseTypstWarnings_Capped(t *testing.T) {
+	var b strings.Builder
+	for i := range maxTypstWarnings + 5 {
+		fmt.Fprintf(&b, "<stdin>:%d:1: warning: unknown font family: font%d\n", i+1, i)
+	}
+
+	if got := parseTypstWarnings(b.String()); len(got) != maxTypstWarnings {
+		t.Errorf("parseTypstWarnings() returned %d warnings, want %d", len(got), maxTypstWarnings)
+	}
+}
+
+func TestParseTypstError(t *testing.T) {
+	stderr := "<stdin>:3:1: warning: unknown font family: acme\n" +
+		"<stdin>:12:5: error: unknown variable: foo\n" +
+		"<stdin>:14:1: error: unclosed delimiter\n"
+	line, column, message := parseTypstError(stderr)
+	if line != 12 || column != 5 || message != "unknown variable: foo" {
+		t.Errorf("parseTypstError() = %d, %d, %q", line, column, message)
+	}
+
+	line, _, message = parseTypstError("@preview/wrap-it:0.1.1/wrap-it.typ:4:2: error: expected content\n")
+	if line != 0 || message != "expected content" {
+		t.Errorf("parseTypstError() in a package = %d, %q; want no position", line, message)
+	}
+	if _, _, message := parseTypstError("error: failed to load file\n"); message != "failed to load file" {
+		t.Errorf("parseTypstError() without position = %q", message)
+	}
+}
+
+func TestBuildArgs_FontDirs(t *testing.T) {
+	r := &TypstRenderer{opts: TypstOptions{FontDirs: []string{"/fonts"}}}
+
+	got := r.buildArgs("/root", []string{"/ws-fonts"}, "pdf")
+	want := []string{
+		"compile", "--format", "pdf", "--diagnostic-format", "short", "--root", "/root",
+		"--font-path", "/fonts", "--font-path", "/ws-fonts", "-", "-",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("buildArgs() = %q, want %q", got, want)
+	}
+}
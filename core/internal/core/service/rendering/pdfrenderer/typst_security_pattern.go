@@ -0,0 +1,166 @@
+package pdfrenderer
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+const (
+	securityPatternDefaultColor = "#3a6ea5"
+	securityPatternDefaultText  = "ORIGINAL"
+
+	guillocheRibbons      = 12  // Wave pairs drawn in a guilloche band
+	guillocheWavePoints   = 120 // Vertices per wave
+	guillocheRosettePts   = 720 // Vertices of the background rosette
+	guillocheStrokePt     = 0.3
+	guillocheMaxHeightPx  = 600
+	microtextSizePt       = 1.8
+	microtextCharWidthEm  = 0.6 // Generous average glyph width, so the line is always filled
+	microtextLineHeightPt = microtextSizePt * 1.4
+)
+
+// securityPattern renders an anti-copy element whose geometry is derived from the document ID.
+// Fine lines and microtext survive printing but break up when photocopied or scanned.
+func (c *TypstConverter) securityPattern(node portabledoc.Node) string {
+	variant, _ := node.Attrs["variant"].(string)
+	color := securityPatternDefaultColor
+	if raw, ok := node.Attrs["color"].(string); ok && isHexColor(strings.TrimSpace(raw)) {
+		color = strings.TrimSpace(raw)
+	}
+	rng := rand.New(rand.NewPCG(c.securitySeed(), 0x9e3779b97f4a7c15)) //nolint:gosec // Pattern geometry, not a secret
+
+	widthPt := c.contentWidthPx * pxToPt
+	if variant == portabledoc.SecurityPatternMicrotext {
+		text, _ := node.Attrs["text"].(string)
+		return microtextLine(text, c.securityLabel(), color, widthPt, rng)
+	}
+
+	height, _ := node.Attrs["height"].(float64)
+	if height <= 0 {
+		// Background: fills the content area behind the content that follows
+		heightPt := c.contentHeightPx * pxToPt
+		return fmt.Sprintf("#place(top + left, %s)\n", guillocheBox(widthPt, heightPt, color, rng, true))
+	}
+	heightPt := clampFloat(height, 8, guillocheMaxHeightPx) * pxToPt
+	return fmt.Sprintf("#block(above: 0.5em, below: 0.5em, %s)\n", guillocheBox(widthPt, heightPt, color, rng, false))
+}
+
+// securitySeed returns the pattern seed: the document ID or, when the render has none,
+// the injectable values, so the same document data always yields the same pattern.
+func (c *TypstConverter) securitySeed() uint64 {
+	h := fnv.New64a()
+	if c.documentID != "" {
+		_, _ = h.Write([]byte(c.documentID))
+		return h.Sum64()
+	}
+
+	keys := make([]string, 0, len(c.injectables))
+	for k := range c.injectables {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s=%v\x00", k, c.injectables[k])
+	}
+	return h.Sum64()
+}
+
+// securityLabel is the identifier repeated in microtext.
+func (c *TypstConverter) securityLabel() string {
+	if c.documentID != "" {
+		return c.documentID
+	}
+	return fmt.Sprintf("%016X", c.securitySeed())
+}
+
+// guillocheBox draws interlaced wave ribbons, plus a central rosette for backgrounds.
+// Ribbons are closed polygons (a wave and its mirror) so only basic Typst shapes are needed.
+func guillocheBox(widthPt, heightPt float64, color string, rng *rand.Rand, rosette bool) string {
+	stroke := fmt.Sprintf("%.2fpt + rgb(%q)", guillocheStrokePt, color)
+	mid := heightPt / 2
+	major := 1 + rng.IntN(4) // Slow and fast cycles across the width
+	minor := 6 + rng.IntN(10)
+	ampMajor := heightPt * (0.22 + 0.12*rng.Float64())
+	ampMinor := heightPt * (0.04 + 0.04*rng.Float64())
+	phase := rng.Float64() * 2 * math.Pi
+	step := (0.15 + 0.25*rng.Float64()) * math.Pi / guillocheRibbons
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "box(width: %.2fpt, height: %.2fpt, clip: true)[", widthPt, heightPt)
+	for k := 0; k < guillocheRibbons; k++ {
+		wave := func(x float64) float64 {
+			t := 2 * math.Pi * x / widthPt
+			return ampMajor*math.Sin(float64(major)*t+phase+float64(k)*step) +
+				ampMinor*math.Sin(float64(minor)*t-float64(k)*step*3)
+		}
+
+		points := make([]string, 0, 2*(guillocheWavePoints+1))
+		for i := 0; i <= guillocheWavePoints; i++ {
+			x := widthPt * float64(i) / guillocheWavePoints
+			points = append(points, fmt.Sprintf("(%.2fpt, %.2fpt)", x, mid+wave(x)))
+		}
+		for i := guillocheWavePoints; i >= 0; i-- {
+			x := widthPt * float64(i) / guillocheWavePoints
+			points = append(points, fmt.Sprintf("(%.2fpt, %.2fpt)", x, mid-wave(x)))
+		}
+		fmt.Fprintf(&sb, "#place(top + left, polygon(stroke: %s, %s))", stroke, strings.Join(points, ", "))
+	}
+	if rosette {
+		sb.WriteString(guillocheRosette(widthPt, heightPt, stroke, rng))
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
+
+// guillocheRosette draws an epitrochoid centred in the box.
+func guillocheRosette(widthPt, heightPt float64, stroke string, rng *rand.Rand) string {
+	bigR := float64(5 + rng.IntN(6))
+	smallR := float64(1 + rng.IntN(3))
+	d := smallR * (1.5 + 2*rng.Float64())
+	extent := bigR + smallR + d
+	scale := math.Min(widthPt, heightPt) * 0.3 / extent
+	cx, cy := widthPt/2, heightPt/2
+
+	// The curve closes after smallR turns when both radii are integers
+	turns := smallR * 2 * math.Pi
+	points := make([]string, 0, guillocheRosettePts)
+	for i := 0; i < guillocheRosettePts; i++ {
+		t := turns * float64(i) / guillocheRosettePts
+		x := (bigR+smallR)*math.Cos(t) - d*math.Cos((bigR+smallR)/smallR*t)
+		y := (bigR+smallR)*math.Sin(t) - d*math.Sin((bigR+smallR)/smallR*t)
+		points = append(points, fmt.Sprintf("(%.2fpt, %.2fpt)", cx+x*scale, cy+y*scale))
+	}
+	return fmt.Sprintf("#place(top + left, polygon(stroke: %s, %s))", stroke, strings.Join(points, ", "))
+}
+
+// microtextLine renders one line of text too small to reproduce, repeating the text and the
+// document label from a seed-dependent offset.
+func microtextLine(text, label, color string, widthPt float64, rng *rand.Rand) string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		text = securityPatternDefaultText
+	}
+	unit := []rune(strings.ToUpper(text) + " " + label + " · ")
+
+	needed := int(widthPt/(microtextSizePt*microtextCharWidthEm)) + len(unit)
+	offset := rng.IntN(len(unit))
+	line := make([]rune, 0, needed)
+	for i := 0; len(line) < needed; i++ {
+		line = append(line, unit[(offset+i)%len(unit)])
+	}
+
+	return fmt.Sprintf(
+		"#block(width: 100%%, height: %.2fpt, clip: true, above: 0.5em, below: 0.5em)[#text(size: %.2fpt, fill: rgb(%q), hyphenate: false)[%s]]\n",
+		microtextLineHeightPt, microtextSizePt, color, escapeTypst(string(line)),
+	)
+}
+
+func clampFloat(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
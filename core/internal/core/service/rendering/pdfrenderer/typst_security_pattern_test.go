@@ -0,0 +1,77 @@
+package pdfrenderer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+func securityPatternNode(attrs map[string]any) portabledoc.Node {
+	return portabledoc.Node{Type: portabledoc.NodeTypeSecurityPattern, Attrs: attrs}
+}
+
+func securityConverter(documentID string) *TypstConverter {
+	c := newConverter(map[string]any{"name": "Ada"}, nil)
+	c.contentWidthPx = 600
+	c.contentHeightPx = 900
+	c.documentID = documentID
+	return c
+}
+
+func TestTypstConverter_SecurityPatternDeterministicPerDocument(t *testing.T) {
+	node := securityPatternNode(map[string]any{"variant": "guilloche", "height": float64(80)})
+
+	first := securityConverter("CERT-001").ConvertNode(node)
+	again := securityConverter("CERT-001").ConvertNode(node)
+	other := securityConverter("CERT-002").ConvertNode(node)
+
+	if first != again {
+		t.Error("expected the same pattern for the same document ID")
+	}
+	if first == other {
+		t.Error("expected different patterns for different document IDs")
+	}
+	if !strings.HasPrefix(first, "#block(") || strings.Count(first, "polygon(") != guillocheRibbons {
+		t.Errorf("expected a block with %d ribbons, got:\n%.200s", guillocheRibbons, first)
+	}
+}
+
+func TestTypstConverter_SecurityPatternBackground(t *testing.T) {
+	got := securityConverter("CERT-001").ConvertNode(securityPatternNode(map[string]any{"variant": "guilloche"}))
+
+	if !strings.HasPrefix(got, "#place(top + left, box(width: 450.00pt, height: 675.00pt") {
+		t.Errorf("expected a placed box filling the content area, got:\n%.200s", got)
+	}
+	// Ribbons plus the rosette
+	if n := strings.Count(got, "polygon("); n != guillocheRibbons+1 {
+		t.Errorf("expected %d polygons, got %d", guillocheRibbons+1, n)
+	}
+}
+
+func TestTypstConverter_SecurityPatternMicrotext(t *testing.T) {
+	got := securityConverter("CERT-001").ConvertNode(securityPatternNode(map[string]any{
+		"variant": "microtext",
+		"text":    "Valid",
+		"color":   "#aa0000",
+	}))
+
+	for _, want := range []string{"VALID CERT-001", `fill: rgb("#aa0000")`, "clip: true"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected microtext to contain %q, got:\n%.200s", want, got)
+		}
+	}
+}
+
+func TestTypstConverter_SecuritySeedWithoutDocumentID(t *testing.T) {
+	a := securityConverter("")
+	b := securityConverter("")
+	b.injectables = map[string]any{"name": "Grace"}
+
+	if a.securitySeed() != securityConverter("").securitySeed() {
+		t.Error("expected the same seed for the same injectable values")
+	}
+	if a.securitySeed() == b.securitySeed() {
+		t.Error("expected different seeds for different injectable values")
+	}
+}
@@ -109,6 +109,28 @@ func (c *TypstConverter) parseListStylesFromAttrs(attrs map[string]any, prefix s
 	return styles
 }
 
+// parseListStylesFromMap parses list styles with their JSON field names (fontFamily, fontSize, ...).
+func (c *TypstConverter) parseListStylesFromMap(m map[string]any) entity.ListStyles {
+	var styles entity.ListStyles
+	if v, ok := m["fontFamily"].(string); ok && v != "" {
+		styles.FontFamily = &v
+	}
+	if v, ok := m["fontSize"].(float64); ok && v > 0 {
+		intVal := int(v)
+		styles.FontSize = &intVal
+	}
+	if v, ok := m["fontWeight"].(string); ok && v != "" {
+		styles.FontWeight = &v
+	}
+	if v, ok := m["textColor"].(string); ok && v != "" {
+		styles.TextColor = &v
+	}
+	if v, ok := m["textAlign"].(string); ok && v != "" {
+		styles.TextAlign = &v
+	}
+	return styles
+}
+
 func (c *TypstConverter) mergeListStyles(base, override *entity.ListStyles) *entity.ListStyles {
 	if base == nil {
 		return override
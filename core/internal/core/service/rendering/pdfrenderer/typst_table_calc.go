@@ -0,0 +1,248 @@
+package pdfrenderer
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/formatter"
+)
+
+// applyTableCalculations returns a copy of table with the calculated columns of attrs appended,
+// and the formatted footer cells of its aggregates (nil when it has none). The injected table is
+// not modified: the same value may be rendered by several nodes.
+func (c *TypstConverter) applyTableCalculations(table *entity.TableValue, attrs *portabledoc.TableInjectorAttrs, lang string) (*entity.TableValue, []string) {
+	out := &entity.TableValue{
+		Columns:      append([]entity.TableColumn(nil), table.Columns...),
+		Rows:         make([]entity.TableRow, len(table.Rows)),
+		HeaderStyles: table.HeaderStyles,
+		BodyStyles:   table.BodyStyles,
+	}
+	for i, row := range table.Rows {
+		out.Rows[i] = entity.TableRow{Cells: append([]entity.TableCell(nil), row.Cells...)}
+	}
+
+	for _, calc := range attrs.CalculatedColumns {
+		addCalculatedColumn(out, calc, lang, c.calculationFunctions())
+	}
+
+	if len(attrs.Aggregates) == 0 {
+		return out, nil
+	}
+	return out, c.buildAggregateFooter(out, attrs)
+}
+
+// calculationFunctions are the functions table formulas can call besides the expr builtins:
+// convert(amount, from, to) converts an amount between ISO 4217 currencies with the rates of the render;
+// today() is the render date in the time zone of the document; addDays(date, n), addMonths(date, n)
+// and addWorkdays(date, n) move a date, and workdays(from, to) counts the working days between two,
+// skipping weekends and the holidays of the document. Dates are date cells or YYYY-MM-DD strings.
+func (c *TypstConverter) calculationFunctions() []expr.Option {
+	convert := func(params ...any) (any, error) {
+		if c.convertCurrency == nil {
+			return nil, entity.ErrCurrencyConversionUnavailable
+		}
+		var amount float64
+		switch n := params[0].(type) {
+		case float64, int, int64:
+			amount = toFloat64(n)
+		default:
+			return nil, fmt.Errorf("convert: amount must be a number, got %T", params[0])
+		}
+		return c.convertCurrency(amount, params[1].(string), params[2].(string))
+	}
+	today := func(...any) (any, error) {
+		now := c.inTimeZone(c.renderDate, nil)
+		y, m, d := now.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, now.Location()), nil
+	}
+	shift := func(name string, move func(t time.Time, n int) time.Time) func(...any) (any, error) {
+		return func(params ...any) (any, error) {
+			t, err := c.formulaDate(name, params[0])
+			if err != nil {
+				return nil, err
+			}
+			return move(t, int(toFloat64(params[1]))), nil
+		}
+	}
+	workdays := func(params ...any) (any, error) {
+		from, err := c.formulaDate("workdays", params[0])
+		if err != nil {
+			return nil, err
+		}
+		to, err := c.formulaDate("workdays", params[1])
+		if err != nil {
+			return nil, err
+		}
+		return c.workCalendar.WorkingDaysBetween(from, to), nil
+	}
+	return []expr.Option{
+		expr.Function("convert", convert, new(func(any, string, string) float64)),
+		expr.Function("today", today, new(func() time.Time)),
+		expr.Function("addDays", shift("addDays", func(t time.Time, n int) time.Time { return t.AddDate(0, 0, n) }), new(func(any, any) time.Time)),
+		expr.Function("addMonths", shift("addMonths", func(t time.Time, n int) time.Time {
+			return formatter.DateOffset{N: n, Unit: formatter.OffsetMonths}.Apply(t, c.workCalendar)
+		}), new(func(any, any) time.Time)),
+		expr.Function("addWorkdays", shift("addWorkdays", c.workCalendar.AddWorkingDays), new(func(any, any) time.Time)),
+		expr.Function("workdays", workdays, new(func(any, any) int)),
+	}
+}
+
+// formulaDate reads a date argument of a table formula function: a date cell or a YYYY-MM-DD or
+// RFC 3339 string.
+func (c *TypstConverter) formulaDate(function string, v any) (time.Time, error) {
+	switch d := v.(type) {
+	case time.Time:
+		return c.inTimeZone(d, nil), nil
+	case string:
+		if t, ok := parseDate(d, c.timeZone); ok {
+			return c.inTimeZone(t, nil), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%s: %v is not a date", function, v)
+}
+
+// addCalculatedColumn evaluates calc on every row. Rows where the expression fails, for example
+// because a referenced cell is empty, get an empty cell. Earlier calculated columns can be referenced.
+func addCalculatedColumn(table *entity.TableValue, calc portabledoc.CalculatedColumn, lang string, functions []expr.Option) {
+	col := entity.TableColumn{
+		Key:      calc.Key,
+		Labels:   map[string]string{lang: calc.Label},
+		DataType: entity.ValueTypeNumber,
+	}
+	if calc.Width != "" {
+		col.Width = &calc.Width
+	}
+	if calc.Format != "" {
+		col.Format = &calc.Format
+	}
+
+	program, err := expr.Compile(calc.Expression, append(functions, expr.AllowUndefinedVariables())...)
+	for i := range table.Rows {
+		cell := entity.Cell(entity.StringValue(""))
+		if err == nil {
+			if value, ok := evaluateRow(program, table.Columns, table.Rows[i]); ok {
+				cell = entity.Cell(value)
+				col.DataType = value.Type()
+			}
+		}
+		table.Rows[i].Cells = append(table.Rows[i].Cells, cell)
+	}
+	table.Columns = append(table.Columns, col)
+}
+
+// evaluateRow runs program with the row's cells bound to their column keys.
+func evaluateRow(program *vm.Program, columns []entity.TableColumn, row entity.TableRow) (entity.InjectableValue, bool) {
+	env := make(map[string]any, len(columns))
+	for i, col := range columns {
+		if i < len(row.Cells) && row.Cells[i].Value != nil {
+			env[col.Key] = row.Cells[i].Value.AsAny()
+		}
+	}
+
+	result, err := expr.Run(program, env)
+	if err != nil {
+		return entity.InjectableValue{}, false
+	}
+	switch v := result.(type) {
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return entity.InjectableValue{}, false
+		}
+		return entity.NumberValue(v), true
+	case int:
+		return entity.NumberValue(float64(v)), true
+	case int64:
+		return entity.NumberValue(float64(v)), true
+	case string:
+		return entity.StringValue(v), true
+	case bool:
+		return entity.BoolValue(v), true
+	case time.Time:
+		return entity.TimeValue(v), true
+	default:
+		return entity.InjectableValue{}, false
+	}
+}
+
+// buildAggregateFooter returns one formatted footer cell per column. Aggregates use the format of
+// their column, except count.
+func (c *TypstConverter) buildAggregateFooter(table *entity.TableValue, attrs *portabledoc.TableInjectorAttrs) []string {
+	footer := make([]string, len(table.Columns))
+	for _, agg := range attrs.Aggregates {
+		for i, col := range table.Columns {
+			if col.Key != agg.Column {
+				continue
+			}
+			value, ok := aggregateColumn(table.Rows, i, agg.Function)
+			if !ok {
+				continue
+			}
+			format := c.getColumnFormat(table.Columns, i)
+			if agg.Function == portabledoc.AggregateCount {
+				format = ""
+			}
+			footer[i] = c.formatCellValue(&value, format)
+		}
+	}
+
+	if attrs.FooterLabel != "" && len(footer) > 0 && footer[0] == "" {
+		footer[0] = attrs.FooterLabel
+	}
+	return footer
+}
+
+// aggregateColumn applies fn to the cells of column idx. Sum, avg, min and max ignore
+// non-numeric cells; count counts non-empty cells.
+func aggregateColumn(rows []entity.TableRow, idx int, fn string) (entity.InjectableValue, bool) {
+	var nums []float64
+	count := 0
+	for _, row := range rows {
+		if idx >= len(row.Cells) || row.Cells[idx].Value == nil {
+			continue
+		}
+		value := row.Cells[idx].Value
+		if s, ok := value.String(); ok && s == "" {
+			continue
+		}
+		count++
+		if n, ok := value.Number(); ok {
+			nums = append(nums, n)
+		}
+	}
+
+	switch fn {
+	case portabledoc.AggregateCount:
+		return entity.NumberValue(float64(count)), true
+	case portabledoc.AggregateSum:
+		sum := 0.0
+		for _, n := range nums {
+			sum += n
+		}
+		return entity.NumberValue(sum), true
+	}
+
+	if len(nums) == 0 {
+		return entity.InjectableValue{}, false
+	}
+	switch fn {
+	case portabledoc.AggregateAvg:
+		sum := 0.0
+		for _, n := range nums {
+			sum += n
+		}
+		return entity.NumberValue(sum / float64(len(nums))), true
+	case portabledoc.AggregateMin:
+		return entity.NumberValue(slices.Min(nums)), true
+	case portabledoc.AggregateMax:
+		return entity.NumberValue(slices.Max(nums)), true
+	default:
+		return entity.InjectableValue{}, false
+	}
+}
@@ -0,0 +1,58 @@
+package pdfrenderer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+func TestTypstConverter_VisibilityByOutputFormat(t *testing.T) {
+	visibleIn := func(text, output string) portabledoc.Node {
+		node := paragraphNode(textNode(text))
+		node.Attrs = map[string]any{"visibility": output}
+		return node
+	}
+	nodes := []portabledoc.Node{
+		paragraphNode(textNode("Always")),
+		visibleIn("Archive", portabledoc.OutputPDF),
+		visibleIn("Interactive", portabledoc.OutputHTML),
+		visibleIn("Printed", portabledoc.OutputPrint),
+	}
+
+	tests := []struct {
+		output string
+		want   []string
+		hidden []string
+	}{
+		{portabledoc.OutputPDF, []string{"Always", "Archive"}, []string{"Interactive", "Printed"}},
+		{portabledoc.OutputPrint, []string{"Always", "Printed"}, []string{"Interactive", "Archive"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			c := newConverter(nil, nil)
+			c.outputFormat = tt.output
+			got := c.ConvertNodes(nodes)
+
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("expected %q in %s output, got:\n%s", want, tt.output, got)
+				}
+			}
+			for _, hidden := range tt.hidden {
+				if strings.Contains(got, hidden) {
+					t.Errorf("expected %q to be skipped in %s output, got:\n%s", hidden, tt.output, got)
+				}
+			}
+		})
+	}
+}
+
+func TestTypstConverter_VisibilityDefaultsToPDF(t *testing.T) {
+	node := paragraphNode(textNode("Email note"))
+	node.Attrs = map[string]any{"visibility": portabledoc.OutputHTML}
+
+	if got := newConverter(nil, nil).ConvertNode(node); got != "" {
+		t.Errorf("expected html-only node to be skipped in the PDF, got %q", got)
+	}
+}
@@ -0,0 +1,49 @@
+package pdfrenderer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+// unknownNodeLabel leads the placeholder shown in place of an unknown node.
+const unknownNodeLabel = "Unsupported content: "
+
+// maxListedUnknownNodes caps the unknown nodes listed in the error of a failed render.
+const maxListedUnknownNodes = 5
+
+// checkUnknownNodes applies the unknown node mode of a render: it fails the render in FAIL mode
+// and otherwise reports each unknown node as an UNKNOWN_NODE warning.
+func checkUnknownNodes(doc *portabledoc.Document, mode entity.UnknownNodeMode) ([]entity.RenderWarning, error) {
+	unknown := doc.UnknownNodes()
+	if len(unknown) == 0 {
+		return nil, nil
+	}
+
+	if mode == entity.UnknownNodesFail {
+		listed := make([]string, 0, maxListedUnknownNodes+1)
+		for _, node := range unknown[:min(len(unknown), maxListedUnknownNodes)] {
+			listed = append(listed, fmt.Sprintf("%s at %s", node.Type, node.Path))
+		}
+		if more := len(unknown) - len(listed); more > 0 {
+			listed = append(listed, fmt.Sprintf("and %d more", more))
+		}
+		return nil, fmt.Errorf("%w: %s", entity.ErrUnknownNodes, strings.Join(listed, ", "))
+	}
+
+	action := "its content is rendered without it"
+	if mode == entity.UnknownNodesPlaceholder {
+		action = "a placeholder is shown"
+	}
+	warnings := make([]entity.RenderWarning, 0, len(unknown))
+	for _, node := range unknown {
+		warnings = append(warnings, entity.RenderWarning{
+			Code:    entity.RenderWarningUnknownNode,
+			Message: fmt.Sprintf("unknown node type %q; %s", node.Type, action),
+			Source:  node.Path,
+		})
+	}
+	return warnings, nil
+}
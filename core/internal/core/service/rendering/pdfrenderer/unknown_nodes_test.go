@@ -0,0 +1,57 @@
+package pdfrenderer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+func unknownNodeDoc() *portabledoc.Document {
+	return &portabledoc.Document{Content: &portabledoc.ProseMirrorDoc{Type: portabledoc.NodeTypeDoc, Content: []portabledoc.Node{
+		paragraphNode(textNode("Intro")),
+		{Type: "callout", Content: []portabledoc.Node{paragraphNode(textNode("Inside"))}},
+	}}}
+}
+
+func TestCheckUnknownNodes(t *testing.T) {
+	warnings, err := checkUnknownNodes(unknownNodeDoc(), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(warnings) != 1 || warnings[0].Code != entity.RenderWarningUnknownNode || warnings[0].Source != "content.content[1]" {
+		t.Errorf("expected one UNKNOWN_NODE warning with the node path, got %+v", warnings)
+	}
+
+	_, err = checkUnknownNodes(unknownNodeDoc(), entity.UnknownNodesFail)
+	if !errors.Is(err, entity.ErrUnknownNodes) || !strings.Contains(err.Error(), "callout at content.content[1]") {
+		t.Errorf("expected ErrUnknownNodes listing the node, got %v", err)
+	}
+
+	known := &portabledoc.Document{Content: &portabledoc.ProseMirrorDoc{Content: []portabledoc.Node{paragraphNode(textNode("Intro"))}}}
+	if warnings, err := checkUnknownNodes(known, entity.UnknownNodesFail); err != nil || warnings != nil {
+		t.Errorf("expected no warnings and no error without unknown nodes, got %v, %v", warnings, err)
+	}
+}
+
+func TestUnknownNodes_Placeholder(t *testing.T) {
+	node := portabledoc.Node{Type: "callout", Content: []portabledoc.Node{paragraphNode(textNode("Inside"))}}
+
+	c := newConverter(nil, nil)
+	if got := c.ConvertNode(node); !strings.Contains(got, "Inside") || strings.Contains(got, unknownNodeLabel) {
+		t.Errorf("expected the node flattened to its content, got %q", got)
+	}
+
+	c.unknownPlaceholders = true
+	if got := c.ConvertNode(node); strings.Contains(got, "Inside") || !strings.Contains(got, unknownNodeLabel+"callout") {
+		t.Errorf("expected a placeholder naming the node type, got %q", got)
+	}
+
+	h := NewHTMLConverter(map[string]any{}, map[string]string{}, DefaultDesignTokens())
+	h.SetUnknownNodes(entity.UnknownNodesPlaceholder)
+	if got := h.convertNode(node); !strings.Contains(got, `class="pf-unknown-node"`) {
+		t.Errorf("expected an HTML placeholder, got %q", got)
+	}
+}
@@ -0,0 +1,117 @@
+// Package rendering picks the PDF rendering backend for each document.
+package rendering
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// Router is a PDFRenderer that renders each document with the backend it selects in meta.renderer,
+// falling back to Typst.
+type Router struct {
+	typst    port.PDFRenderer
+	backends map[string]port.RendererBackend
+}
+
+// NewRouter creates a Router over the Typst renderer and the backends registered through the SDK.
+// Backend names must be unique and non-empty, and cannot shadow the built-in "typst".
+func NewRouter(typst port.PDFRenderer, backends []port.RendererBackend) (*Router, error) {
+	byName := make(map[string]port.RendererBackend, len(backends))
+	for _, b := range backends {
+		name := b.Name()
+		switch {
+		case name == "":
+			return nil, fmt.Errorf("renderer backend %T has an empty name", b)
+		case name == portabledoc.RendererTypst:
+			return nil, fmt.Errorf("renderer backend %T cannot use the reserved name %q", b, name)
+		case byName[name] != nil:
+			return nil, fmt.Errorf("renderer backend %q is registered twice", name)
+		}
+		byName[name] = b
+	}
+	return &Router{typst: typst, backends: byName}, nil
+}
+
+// RenderPreview renders the document with the backend it selects.
+func (r *Router) RenderPreview(ctx context.Context, req *port.RenderPreviewRequest) (*port.RenderPreviewResult, error) {
+	backend, err := r.backend(req)
+	if err != nil {
+		return nil, err
+	}
+	if backend == nil {
+		return r.typst.RenderPreview(ctx, req)
+	}
+	return backend.Render(ctx, req)
+}
+
+// ExportTypstProject exports the Typst project of documents rendered with Typst.
+func (r *Router) ExportTypstProject(ctx context.Context, req *port.RenderPreviewRequest) (*port.TypstProjectResult, error) {
+	backend, err := r.backend(req)
+	if err != nil {
+		return nil, err
+	}
+	if backend != nil {
+		return nil, entity.ErrTypstExportUnavailable
+	}
+	return r.typst.ExportTypstProject(ctx, req)
+}
+
+// RenderHTML converts documents rendered with Typst to HTML. Backends only produce PDFs.
+func (r *Router) RenderHTML(ctx context.Context, req *port.RenderPreviewRequest) (*port.HTMLRenderResult, error) {
+	backend, err := r.backend(req)
+	if err != nil {
+		return nil, err
+	}
+	if backend != nil {
+		return nil, entity.ErrHTMLRenderUnavailable
+	}
+	return r.typst.RenderHTML(ctx, req)
+}
+
+// RenderDocx converts documents rendered with Typst to DOCX. Backends only produce PDFs.
+func (r *Router) RenderDocx(ctx context.Context, req *port.RenderPreviewRequest) (*port.DocxRenderResult, error) {
+	backend, err := r.backend(req)
+	if err != nil {
+		return nil, err
+	}
+	if backend != nil {
+		return nil, entity.ErrDocxRenderUnavailable
+	}
+	return r.typst.RenderDocx(ctx, req)
+}
+
+// Close closes the Typst renderer and the backends that hold resources.
+func (r *Router) Close() error {
+	errs := []error{r.typst.Close()}
+	for _, b := range r.backends {
+		if closer, ok := b.(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// backend returns the backend the request document selects, or nil for Typst.
+func (r *Router) backend(req *port.RenderPreviewRequest) (port.RendererBackend, error) {
+	if req.Document == nil {
+		return nil, nil // Typst reports the missing document
+	}
+	name := req.Document.Meta.Renderer
+	if name == "" || name == portabledoc.RendererTypst {
+		return nil, nil
+	}
+	backend, ok := r.backends[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", entity.ErrUnknownRenderer, name)
+	}
+	return backend, nil
+}
+
+// Ensure Router implements port.PDFRenderer
+var _ port.PDFRenderer = (*Router)(nil)
@@ -0,0 +1,102 @@
+package rendering
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+type typstStub struct{ renders int }
+
+func (s *typstStub) RenderPreview(context.Context, *port.RenderPreviewRequest) (*port.RenderPreviewResult, error) {
+	s.renders++
+	return &port.RenderPreviewResult{Filename: "typst.pdf"}, nil
+}
+
+func (s *typstStub) ExportTypstProject(context.Context, *port.RenderPreviewRequest) (*port.TypstProjectResult, error) {
+	return &port.TypstProjectResult{Filename: "typst.zip"}, nil
+}
+
+func (s *typstStub) RenderHTML(context.Context, *port.RenderPreviewRequest) (*port.HTMLRenderResult, error) {
+	return &port.HTMLRenderResult{Filename: "typst.html"}, nil
+}
+
+func (s *typstStub) RenderDocx(context.Context, *port.RenderPreviewRequest) (*port.DocxRenderResult, error) {
+	return &port.DocxRenderResult{Filename: "typst.docx"}, nil
+}
+
+func (s *typstStub) Close() error { return nil }
+
+type backendStub struct{ name string }
+
+func (b backendStub) Name() string { return b.name }
+
+func (b backendStub) Render(context.Context, *port.RenderPreviewRequest) (*port.RenderPreviewResult, error) {
+	return &port.RenderPreviewResult{Filename: b.name + ".pdf"}, nil
+}
+
+func requestFor(renderer string) *port.RenderPreviewRequest {
+	return &port.RenderPreviewRequest{Document: &portabledoc.Document{Meta: portabledoc.Meta{Renderer: renderer}}}
+}
+
+func TestRouter_SelectsBackendPerDocument(t *testing.T) {
+	typst := &typstStub{}
+	router, err := NewRouter(typst, []port.RendererBackend{backendStub{name: "latex"}})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	for _, name := range []string{"", portabledoc.RendererTypst} {
+		result, err := router.RenderPreview(ctx, requestFor(name))
+		require.NoError(t, err)
+		assert.Equal(t, "typst.pdf", result.Filename)
+	}
+	assert.Equal(t, 2, typst.renders)
+
+	result, err := router.RenderPreview(ctx, requestFor("latex"))
+	require.NoError(t, err)
+	assert.Equal(t, "latex.pdf", result.Filename)
+
+	_, err = router.RenderPreview(ctx, requestFor("chromium"))
+	assert.ErrorIs(t, err, entity.ErrUnknownRenderer)
+
+	_, err = router.ExportTypstProject(ctx, requestFor("latex"))
+	assert.ErrorIs(t, err, entity.ErrTypstExportUnavailable)
+
+	project, err := router.ExportTypstProject(ctx, requestFor(""))
+	require.NoError(t, err)
+	assert.Equal(t, "typst.zip", project.Filename)
+
+	_, err = router.RenderHTML(ctx, requestFor("latex"))
+	assert.ErrorIs(t, err, entity.ErrHTMLRenderUnavailable)
+
+	page, err := router.RenderHTML(ctx, requestFor(""))
+	require.NoError(t, err)
+	assert.Equal(t, "typst.html", page.Filename)
+
+	_, err = router.RenderDocx(ctx, requestFor("latex"))
+	assert.ErrorIs(t, err, entity.ErrDocxRenderUnavailable)
+
+	docx, err := router.RenderDocx(ctx, requestFor(""))
+	require.NoError(t, err)
+	assert.Equal(t, "typst.docx", docx.Filename)
+}
+
+func TestNewRouter_RejectsInvalidNames(t *testing.T) {
+	tests := map[string][]port.RendererBackend{
+		"empty":     {backendStub{}},
+		"reserved":  {backendStub{name: portabledoc.RendererTypst}},
+		"duplicate": {backendStub{name: "latex"}, backendStub{name: "latex"}},
+	}
+	for name, backends := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewRouter(&typstStub{}, backends)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+const (
+	// batchRenderConcurrency is how many items of a batch render at a time, leaving the other
+	// renderer slots to single renders.
+	batchRenderConcurrency = 4
+
+	// batchRenderBusyRetries is how many times an item is retried when the renderer is at capacity.
+	batchRenderBusyRetries = 3
+)
+
+// RenderBatch renders a specific template version once per item.
+func (s *InternalRenderService) RenderBatch(
+	ctx context.Context,
+	cmd templateuc.BatchRenderCommand,
+	emit func(templateuc.BatchRenderItemResult) error,
+) error {
+	version, err := s.versionRepo.FindByIDWithDetails(ctx, cmd.VersionID)
+	if err != nil {
+		return fmt.Errorf("finding version %s: %w", cmd.VersionID, err)
+	}
+
+	var emitMu sync.Mutex
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(batchRenderConcurrency)
+	for i, item := range cmd.Items {
+		g.Go(func() error {
+			if err := gCtx.Err(); err != nil {
+				return err
+			}
+			result, err := s.renderBatchItem(gCtx, version, cmd, item)
+
+			emitMu.Lock()
+			defer emitMu.Unlock()
+			return emit(templateuc.BatchRenderItemResult{Index: i, Result: result, Err: err})
+		})
+	}
+	return g.Wait()
+}
+
+// renderBatchItem renders one item of a batch, waiting and retrying while the renderer is at capacity.
+func (s *InternalRenderService) renderBatchItem(
+	ctx context.Context,
+	version *entity.TemplateVersionWithDetails,
+	cmd templateuc.BatchRenderCommand,
+	item templateuc.BatchRenderItem,
+) (*port.RenderPreviewResult, error) {
+	itemCmd := templateuc.InternalRenderCommand{
+		TenantCode:    cmd.TenantCode,
+		WorkspaceCode: cmd.WorkspaceCode,
+		Injectables:   item.Injectables,
+		Headers:       cmd.Headers,
+		Payload:       item.Injectables,
+		Environment:   cmd.Environment,
+		Imposition:    cmd.Imposition,
+		Layout:        cmd.Layout,
+		Overlays:      portabledoc.MergeOverlays(cmd.Overlays, item.Overlays),
+		DocumentID:    item.DocumentID,
+		Degraded:      cmd.Degraded,
+		UnitSystem:    cmd.UnitSystem,
+	}
+
+	for attempt := 1; ; attempt++ {
+		result, err := s.renderVersion(ctx, version, itemCmd)
+		if !errors.Is(err, entity.ErrRendererBusy) || attempt > batchRenderBusyRetries {
+			return result, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Duration(attempt) * time.Second):
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	cataloguc "github.com/rendis/pdf-forge/core/internal/core/usecase/catalog"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// batchRendererStub renders every document except those whose ID is in fail.
+type batchRendererStub struct {
+	pdfRendererStub
+	mu       sync.Mutex
+	docs     []string
+	fontDirs [][]string
+	fail     map[string]error
+}
+
+func (s *batchRendererStub) RenderPreview(_ context.Context, req *port.RenderPreviewRequest) (*port.RenderPreviewResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.docs = append(s.docs, req.DocumentID)
+	s.fontDirs = append(s.fontDirs, req.FontDirs)
+	if err := s.fail[req.DocumentID]; err != nil {
+		return nil, err
+	}
+	return &port.RenderPreviewResult{PDF: []byte("%PDF-" + req.DocumentID), Filename: "test.pdf", PageCount: 1}, nil
+}
+
+func newBatchTestService(t *testing.T, renderer port.PDFRenderer) *InternalRenderService {
+	t.Helper()
+	return &InternalRenderService{
+		versionRepo: &templateResolverTemplateVersionRepoStub{
+			byID: map[string]*entity.TemplateVersionWithDetails{
+				"v-1": {
+					TemplateVersion: entity.TemplateVersion{
+						ID:               "v-1",
+						TemplateID:       "tpl-1",
+						Status:           entity.VersionStatusPublished,
+						ContentStructure: mustBuildPortableDoc(t),
+					},
+				},
+			},
+		},
+		pdfRenderer: renderer,
+		estimation:  RenderEstimationOptions{Stats: NewRenderStatsCache()},
+	}
+}
+
+func TestInternalRenderService_RenderBatchEmitsEveryItem(t *testing.T) {
+	renderer := &batchRendererStub{fail: map[string]error{"cert-2": errors.New("typst compile failed")}}
+	service := newBatchTestService(t, renderer)
+
+	cmd := templateuc.BatchRenderCommand{VersionID: "v-1", TenantCode: "TENANT_A", WorkspaceCode: "WS_1"}
+	for _, id := range []string{"cert-1", "cert-2", "cert-3", "cert-4", "cert-5", "cert-6"} {
+		cmd.Items = append(cmd.Items, templateuc.BatchRenderItem{DocumentID: id})
+	}
+
+	var results []templateuc.BatchRenderItemResult
+	err := service.RenderBatch(context.Background(), cmd, func(item templateuc.BatchRenderItemResult) error {
+		results = append(results, item)
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, results, len(cmd.Items))
+	sort.Slice(results, func(i, j int) bool { return results[i].Index < results[j].Index })
+	for i, item := range results {
+		assert.Equal(t, i, item.Index)
+		if i == 1 {
+			assert.EqualError(t, item.Err, "typst compile failed")
+			assert.Nil(t, item.Result)
+			continue
+		}
+		require.NoError(t, item.Err)
+		assert.Equal(t, "%PDF-"+cmd.Items[i].DocumentID, string(item.Result.PDF))
+	}
+	assert.ElementsMatch(t, []string{"cert-1", "cert-2", "cert-3", "cert-4", "cert-5", "cert-6"}, renderer.docs)
+}
+
+func TestInternalRenderService_RenderBatchStopsWhenEmitFails(t *testing.T) {
+	renderer := &batchRendererStub{}
+	service := newBatchTestService(t, renderer)
+
+	cmd := templateuc.BatchRenderCommand{VersionID: "v-1", Items: make([]templateuc.BatchRenderItem, 20)}
+	emitted := 0
+	err := service.RenderBatch(context.Background(), cmd, func(templateuc.BatchRenderItemResult) error {
+		emitted++
+		return errors.New("client gone")
+	})
+
+	require.EqualError(t, err, "client gone")
+	assert.Less(t, emitted, len(cmd.Items), "items after the failed emit are skipped")
+}
+
+func TestInternalRenderService_RenderBatchUnknownVersion(t *testing.T) {
+	service := newBatchTestService(t, &batchRendererStub{})
+
+	cmd := templateuc.BatchRenderCommand{VersionID: "missing", Items: make([]templateuc.BatchRenderItem, 2)}
+	err := service.RenderBatch(context.Background(), cmd, func(templateuc.BatchRenderItemResult) error {
+		t.Fatal("no item is emitted when the version is not found")
+		return nil
+	})
+
+	assert.ErrorIs(t, err, entity.ErrVersionNotFound)
+}
+
+// renderQuotaStub allows the first allowed renders and records the pages of each workspace.
+type renderQuotaStub struct {
+	mu      sync.Mutex
+	allowed int
+	pages   map[string]int
+}
+
+func (q *renderQuotaStub) AllowRender(_, _ string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.allowed == 0 {
+		return &entity.QuotaExceededError{Scope: entity.RenderQuotaScopeTenant, Limit: entity.RenderQuotaRendersPerMinute, Max: 2}
+	}
+	q.allowed--
+	return nil
+}
+
+func (q *renderQuotaStub) RecordPages(tenantCode, workspaceCode string, pages int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pages[tenantCode+"/"+workspaceCode] += pages
+}
+
+func TestInternalRenderService_RenderBatchQuotas(t *testing.T) {
+	renderer := &batchRendererStub{}
+	service := newBatchTestService(t, renderer)
+	quotas := &renderQuotaStub{allowed: 2, pages: make(map[string]int)}
+	service.quotas = quotas
+
+	cmd := templateuc.BatchRenderCommand{VersionID: "v-1", TenantCode: "TENANT_A", WorkspaceCode: "WS_1", Items: make([]templateuc.BatchRenderItem, 5)}
+	var rejected int
+	err := service.RenderBatch(context.Background(), cmd, func(item templateuc.BatchRenderItemResult) error {
+		if errors.Is(item.Err, entity.ErrQuotaExceeded) {
+			rejected++
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, rejected)
+	assert.Len(t, renderer.docs, 2, "rejected items are not rendered")
+	assert.Equal(t, map[string]int{"TENANT_A/WS_1": 2}, quotas.pages)
+}
+
+// workspaceFontStub serves a font directory for the workspaces that have one.
+type workspaceFontStub struct {
+	cataloguc.WorkspaceFontUseCase
+	dirs map[string]string
+}
+
+func (f *workspaceFontStub) FontDir(_ context.Context, workspaceID string) (string, error) {
+	return f.dirs[workspaceID], nil
+}
+
+func TestInternalRenderService_WorkspaceFontDirs(t *testing.T) {
+	renderer := &batchRendererStub{}
+	service := newBatchTestService(t, renderer)
+	service.templateRepo = &templateResolverTemplateRepoStub{byID: map[string]*entity.Template{
+		"tpl-1": {ID: "tpl-1", WorkspaceID: "ws-1"},
+	}}
+	service.fonts = &workspaceFontStub{dirs: map[string]string{"ws-1": "/cache/ws-1/abc"}}
+
+	_, err := service.RenderByVersionID(context.Background(), templateuc.RenderByVersionIDCommand{VersionID: "v-1"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"/cache/ws-1/abc"}}, renderer.fontDirs, "the fonts of the template's workspace are added")
+
+	service.fonts = &workspaceFontStub{}
+	_, err = service.RenderByVersionID(context.Background(), templateuc.RenderByVersionIDCommand{VersionID: "v-1"})
+	require.NoError(t, err)
+	assert.Nil(t, renderer.fontDirs[1], "a workspace without fonts adds no directory")
+}
@@ -117,7 +117,7 @@ func validateLogicRule(vctx *validationContext, rule *portabledoc.LogicRule, pat
 	if rule.VariableID == "" {
 		vctx.addError(ErrCodeInvalidConditionVar, path+".variableId",
 			"Rule variableId is required")
-	} else if !vctx.variableSet.Contains(rule.VariableID) {
+	} else if !vctx.hasVariable(rule.VariableID) {
 		vctx.addErrorf(ErrCodeInvalidConditionVar, path+".variableId",
 			"Variable '%s' not found in document variables or role variables", rule.VariableID)
 	}
@@ -139,7 +139,7 @@ func validateLogicRule(vctx *validationContext, rule *portabledoc.LogicRule, pat
 
 	// If comparing to another variable, validate it exists
 	if rule.Value.Mode == portabledoc.RuleModeVariable && rule.Value.Value != "" {
-		if !vctx.variableSet.Contains(rule.Value.Value) {
+		if !vctx.hasVariable(rule.Value.Value) {
 			vctx.addErrorf(ErrCodeInvalidConditionVar, path+".value.value",
 				"Comparison variable '%s' not found", rule.Value.Value)
 		}
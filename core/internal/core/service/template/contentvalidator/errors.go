@@ -14,9 +14,18 @@ const (
 	ErrCodeInvalidVersion    = "INVALID_VERSION_FORMAT"
 	ErrCodeMissingMetaTitle  = "MISSING_META_TITLE"
 	ErrCodeInvalidLanguage   = "INVALID_LANGUAGE"
+	ErrCodeInvalidUnitSystem = "INVALID_UNIT_SYSTEM"
+	ErrCodeInvalidTimeZone   = "INVALID_TIME_ZONE"
+	ErrCodeInvalidHoliday    = "INVALID_HOLIDAY"
 	ErrCodeInvalidPageFormat = "INVALID_PAGE_FORMAT"
 	ErrCodeInvalidPageSize   = "INVALID_PAGE_SIZE"
 	ErrCodeInvalidMargins    = "INVALID_MARGINS"
+	ErrCodeInvalidPageStamp  = "INVALID_PAGE_STAMP"
+	ErrCodeInvalidVisibility = "INVALID_NODE_VISIBILITY"
+	ErrCodeInvalidLayout     = "INVALID_LAYOUT_VARIANTS"
+	ErrCodeInvalidOverlays   = "INVALID_OVERLAYS"
+	ErrCodeInvalidSurface    = "INVALID_SURFACE"
+	ErrCodeUnknownNode       = "UNKNOWN_NODE" // The workspace fails renders of unknown nodes
 
 	ErrCodeInaccessibleInjectable = "INACCESSIBLE_INJECTABLE"
 
@@ -25,6 +34,7 @@ const (
 	ErrCodeInaccessibleVariable = "INACCESSIBLE_VARIABLE"
 	ErrCodeOrphanedVariable     = "ORPHANED_VARIABLE"
 	ErrCodeInvalidInjectorType  = "INVALID_INJECTOR_TYPE"
+	ErrCodeInvalidDateOffset    = "INVALID_DATE_OFFSET"
 
 	// Conditional errors
 	ErrCodeInvalidConditionVar   = "UNKNOWN_VARIABLE_IN_CONDITION"
@@ -37,6 +47,15 @@ const (
 	ErrCodeEmptyConditionGroup   = "EMPTY_CONDITION_GROUP"
 	ErrCodeMissingConditionValue = "MISSING_CONDITION_VALUE"
 
+	// Table calculation errors
+	ErrCodeInvalidTableCalculation = "INVALID_TABLE_CALCULATION"
+
+	// External PDF errors
+	ErrCodeInvalidExternalPDF = "INVALID_EXTERNAL_PDF"
+
+	// Repeater errors
+	ErrCodeInvalidRepeater = "INVALID_REPEATER"
+
 	// Context errors
 	ErrCodeValidationCancelled = "VALIDATION_CANCELLED"
 )
@@ -47,6 +66,8 @@ const (
 	WarnCodeDeprecatedVersion = "DEPRECATED_VERSION"
 	WarnCodeExpressionWarning = "EXPRESSION_WARNING"
 	WarnCodeUnusedVariable    = "UNUSED_VARIABLE"
+	WarnCodeDeadLink          = "DEAD_LINK"
+	WarnCodeUnknownNode       = "UNKNOWN_NODE" // Rendered flattened or as a placeholder
 )
 
 // sanitizeJSONError converts raw JSON parse errors to user-friendly messages.
@@ -0,0 +1,48 @@
+package contentvalidator
+
+import (
+	"fmt"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+// validateExternalPDFs validates the source, mode and pages of externalPdf nodes.
+// The page ranges are checked against the PDF at render time, when it is downloaded.
+func (s *Service) validateExternalPDFs(vctx *validationContext) {
+	for i, node := range vctx.doc.NodesOfType(portabledoc.NodeTypeExternalPDF) {
+		path := fmt.Sprintf("content.externalPdf[%d].attrs", i)
+		attrs, err := portabledoc.ParseExternalPDFAttrs(node.Attrs)
+		if err != nil {
+			vctx.addErrorf(ErrCodeInvalidExternalPDF, path,
+				"Invalid external PDF attributes: %s", err.Error())
+			continue
+		}
+
+		if attrs.Src == "" && attrs.InjectableID == "" {
+			vctx.addError(ErrCodeInvalidExternalPDF, path+".src", "External PDF requires a src or an injectableId")
+		}
+		if attrs.InjectableID != "" {
+			validateExternalPDFInjectable(vctx, attrs.InjectableID, path+".injectableId")
+		}
+		if attrs.Mode != "" && !portabledoc.ValidExternalPDFModes.Contains(attrs.Mode) {
+			vctx.addErrorf(ErrCodeInvalidExternalPDF, path+".mode",
+				"Invalid external PDF mode: %s. Must be append or embed", attrs.Mode)
+		}
+		if err := attrs.ValidatePages(); err != nil {
+			vctx.addErrorf(ErrCodeInvalidExternalPDF, path+".pages",
+				"Invalid external PDF pages: %s", err.Error())
+		}
+	}
+}
+
+// validateExternalPDFInjectable checks the injectable holding the PDF URL is declared and accessible.
+func validateExternalPDFInjectable(vctx *validationContext, id, path string) {
+	if !vctx.variableSet.Contains(id) {
+		vctx.addErrorf(ErrCodeUnknownVariable, path,
+			"External PDF injectable '%s' not found in document variableIds", id)
+	}
+	if vctx.accessibleInjectables.Len() > 0 && !vctx.accessibleInjectables.Contains(id) {
+		vctx.addErrorf(ErrCodeInaccessibleVariable, path,
+			"External PDF injectable '%s' is not accessible to this workspace", id)
+	}
+}
@@ -0,0 +1,39 @@
+package contentvalidator
+
+import (
+	"fmt"
+	"sync"
+)
+
+// maxConcurrentLinkChecks bounds the links of a document checked at once.
+const maxConcurrentLinkChecks = 8
+
+// validateLinks checks the external links of the document when a link checker is
+// configured. Dead links are warnings: the document still publishes.
+func (s *Service) validateLinks(vctx *validationContext) {
+	if s.linkChecker == nil {
+		return
+	}
+
+	links := vctx.doc.ExternalLinks()
+	failures := make([]error, len(links))
+	sem := make(chan struct{}, maxConcurrentLinkChecks)
+	var wg sync.WaitGroup
+	for i, link := range links {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			failures[i] = s.linkChecker.CheckLink(vctx.ctx, link)
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range failures {
+		if err != nil {
+			vctx.addWarningf(WarnCodeDeadLink, fmt.Sprintf("content.links[%d]", i),
+				"Link %s appears to be dead: %s", links[i], err.Error())
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package contentvalidator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+type linkCheckerStub struct {
+	dead map[string]bool
+}
+
+func (s linkCheckerStub) CheckLink(_ context.Context, url string) error {
+	if s.dead[url] {
+		return errors.New("responded with status 404")
+	}
+	return nil
+}
+
+func linkedText(text, href string) portabledoc.Node {
+	return portabledoc.Node{
+		Type:  portabledoc.NodeTypeText,
+		Text:  &text,
+		Marks: []portabledoc.Mark{{Type: portabledoc.MarkTypeLink, Attrs: map[string]any{"href": href}}},
+	}
+}
+
+func TestValidateForPublish_WarnsOnDeadLinks(t *testing.T) {
+	t.Parallel()
+
+	doc := baseDoc()
+	doc.Content.Content = []portabledoc.Node{{
+		Type: portabledoc.NodeTypeParagraph,
+		Content: []portabledoc.Node{
+			linkedText("live", "https://example.com/live"),
+			linkedText("dead", "https://example.com/dead"),
+			linkedText("dead again", "https://example.com/dead"),
+			linkedText("mail", "mailto:someone@example.com"),
+		},
+	}}
+	checker := linkCheckerStub{dead: map[string]bool{"https://example.com/dead": true}}
+
+	result := New(nil, WithLinkChecker(checker)).ValidateForPublish(context.Background(), "ws-1", "ver-1", mustMarshalDoc(t, doc))
+
+	if !result.Valid {
+		t.Fatalf("expected dead links not to block publishing, got %+v", result.Errors)
+	}
+	if len(result.Warnings) != 1 {
+		t.Fatalf("expected 1 warning, got %d: %+v", len(result.Warnings), result.Warnings)
+	}
+	if w := result.Warnings[0]; w.Code != WarnCodeDeadLink || w.Path != "content.links[1]" {
+		t.Fatalf("expected DEAD_LINK at content.links[1], got %+v", w)
+	}
+}
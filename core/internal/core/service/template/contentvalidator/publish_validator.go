@@ -21,13 +21,20 @@ type validationContext struct {
 	service     *Service
 
 	// Computed sets for validation
-	variableSet portabledoc.Set[string]
+	variableSet     portabledoc.Set[string]
+	repeaterAliases portabledoc.Set[string] // Item aliases of the repeaters, read as variables inside them
 
 	// Accessible injectables cache (loaded from DB)
 	accessibleInjectables    portabledoc.Set[string]
 	accessibleInjectableList []*entity.InjectableDefinition // Full list for extraction
 }
 
+// hasVariable reports whether a variable is declared in the document, or reads the current item
+// of a repeater.
+func (vc *validationContext) hasVariable(id string) bool {
+	return vc.variableSet.Contains(id) || portabledoc.IsRepeaterItemVariable(id, vc.repeaterAliases)
+}
+
 // addError adds a validation error.
 func (vc *validationContext) addError(code, path, message string) {
 	vc.result.AddError(code, path, message)
@@ -102,13 +109,14 @@ func (s *Service) validatePublish(
 	}
 
 	vctx := &validationContext{
-		ctx:         ctx,
-		workspaceID: workspaceID,
-		versionID:   versionID,
-		doc:         doc,
-		result:      result,
-		service:     s,
-		variableSet: buildVariableSet(doc.VariableIDs),
+		ctx:             ctx,
+		workspaceID:     workspaceID,
+		versionID:       versionID,
+		doc:             doc,
+		result:          result,
+		service:         s,
+		variableSet:     buildVariableSet(doc.VariableIDs),
+		repeaterAliases: doc.RepeaterAliases(),
 	}
 
 	if err := s.loadAccessibleInjectables(vctx); err != nil {
@@ -120,6 +128,9 @@ func (s *Service) validatePublish(
 		s.validatePageConfig,
 		s.validateVariables,
 		s.validateConditionals,
+		s.validateTableCalculations,
+		s.validateExternalPDFs,
+		s.validateLinks,
 	}
 	for _, validate := range validators {
 		if vctx.checkCancelled() {
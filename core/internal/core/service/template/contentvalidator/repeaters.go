@@ -0,0 +1,40 @@
+package contentvalidator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+// validateRepeaters validates the injectable and item alias of every repeater block.
+func validateRepeaters(vctx *validationContext) {
+	for i, node := range vctx.doc.NodesOfType(portabledoc.NodeTypeRepeater) {
+		path := fmt.Sprintf("content.repeater[%d]", i)
+		attrs, err := portabledoc.ParseRepeaterAttrs(node.Attrs)
+		if err != nil {
+			vctx.addErrorf(ErrCodeInvalidRepeater, path+".attrs",
+				"Invalid repeater attributes: %s", err.Error())
+			continue
+		}
+
+		// Nested repeaters may iterate over a field of the item of an outer one
+		if attrs.VariableID == "" {
+			vctx.addError(ErrCodeUnknownVariable, path+".attrs.variableId",
+				"Repeater variableId is required")
+		} else if !vctx.hasVariable(attrs.VariableID) {
+			vctx.addErrorf(ErrCodeUnknownVariable, path+".attrs.variableId",
+				"Variable '%s' not found in document variableIds", attrs.VariableID)
+		}
+
+		alias := attrs.ItemAlias()
+		switch {
+		case strings.Contains(alias, "."):
+			vctx.addErrorf(ErrCodeInvalidRepeater, path+".attrs.alias",
+				"Repeater alias '%s' cannot contain dots", alias)
+		case vctx.variableSet.Contains(alias):
+			vctx.addErrorf(ErrCodeInvalidRepeater, path+".attrs.alias",
+				"Repeater alias '%s' hides the document variable of the same name", alias)
+		}
+	}
+}
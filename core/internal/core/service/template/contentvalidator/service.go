@@ -5,6 +5,7 @@ import (
 
 	"github.com/rendis/pdf-forge/core/internal/core/port"
 	injectableuc "github.com/rendis/pdf-forge/core/internal/core/usecase/injectable"
+	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
 )
 
 // Service implements the ContentValidator interface.
@@ -12,6 +13,8 @@ type Service struct {
 	injectableUC    injectableuc.InjectableUseCase
 	maxNestingDepth int
 	strictMode      bool
+	linkChecker     port.LinkChecker
+	settings        organizationuc.WorkspaceSettingsUseCase
 }
 
 // Option configures the validator service.
@@ -35,6 +38,22 @@ func WithMaxNestingDepth(depth int) Option {
 	}
 }
 
+// WithLinkChecker checks the external links of the document on publish,
+// reporting dead links as warnings.
+func WithLinkChecker(checker port.LinkChecker) Option {
+	return func(s *Service) {
+		s.linkChecker = checker
+	}
+}
+
+// WithWorkspaceSettings reports unknown nodes as errors on publish in workspaces whose renders
+// fail on them. Without it, unknown nodes are always reported as warnings.
+func WithWorkspaceSettings(settings organizationuc.WorkspaceSettingsUseCase) Option {
+	return func(s *Service) {
+		s.settings = settings
+	}
+}
+
 // New creates a new content validator service.
 func New(injectableUC injectableuc.InjectableUseCase, opts ...Option) *Service {
 	s := &Service{
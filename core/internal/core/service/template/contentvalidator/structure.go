@@ -1,14 +1,20 @@
 package contentvalidator
 
 import (
+	"fmt"
 	"regexp"
+	"time"
 
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
 )
 
 // versionRegex validates semantic version format.
 var versionRegex = regexp.MustCompile(`^\d+\.\d+\.\d+$`)
 
+// hexColorRegex validates hex color format (#RRGGBB).
+var hexColorRegex = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
 // validateStructure validates document structure (version, meta).
 func (s *Service) validateStructure(vctx *validationContext) {
 	doc := vctx.doc
@@ -23,6 +29,27 @@ func (s *Service) validateStructure(vctx *validationContext) {
 
 	// Validate meta
 	validateMeta(vctx)
+
+	// Validate output format restrictions on nodes
+	validateNodeVisibility(vctx)
+
+	// Report nodes the converters do not render
+	s.validateUnknownNodes(vctx)
+
+	validateSurfaces(vctx)
+}
+
+// validateSurfaces validates the pages the header and footer render on.
+func validateSurfaces(vctx *validationContext) {
+	doc := vctx.doc
+	if doc.Header != nil && doc.Header.Pages != "" && !portabledoc.ValidSurfacePages.Contains(doc.Header.Pages) {
+		vctx.addErrorf(ErrCodeInvalidSurface, "header.pages",
+			"Invalid header pages: %s. Must be first, last, or all", doc.Header.Pages)
+	}
+	if doc.Footer != nil && doc.Footer.Pages != "" && !portabledoc.ValidSurfacePages.Contains(doc.Footer.Pages) {
+		vctx.addErrorf(ErrCodeInvalidSurface, "footer.pages",
+			"Invalid footer pages: %s. Must be first, last, or all", doc.Footer.Pages)
+	}
 }
 
 // validateMeta validates document metadata.
@@ -39,6 +66,76 @@ func validateMeta(vctx *validationContext) {
 		vctx.addErrorf(ErrCodeInvalidLanguage, "meta.language",
 			"Invalid language code: %s. Must be 'en' or 'es'", meta.Language)
 	}
+
+	if meta.UnitSystem != "" && !portabledoc.ValidUnitSystems.Contains(meta.UnitSystem) {
+		vctx.addErrorf(ErrCodeInvalidUnitSystem, "meta.unitSystem",
+			"Invalid unit system: %s. Must be 'metric' or 'imperial'", meta.UnitSystem)
+	}
+
+	if meta.TimeZone != "" && !validTimeZone(meta.TimeZone) {
+		vctx.addErrorf(ErrCodeInvalidTimeZone, "meta.timeZone",
+			"Unknown time zone: %s. Must be an IANA time zone such as 'America/Santiago'", meta.TimeZone)
+	}
+	for i, holiday := range meta.Holidays {
+		if _, err := time.Parse(time.DateOnly, holiday); err != nil {
+			vctx.addErrorf(ErrCodeInvalidHoliday, fmt.Sprintf("meta.holidays[%d]", i),
+				"Invalid holiday: %s. Must be a YYYY-MM-DD date", holiday)
+		}
+	}
+}
+
+// validTimeZone reports whether name is an IANA time zone. "Local" depends on the server and is
+// not accepted.
+func validTimeZone(name string) bool {
+	_, err := time.LoadLocation(name)
+	return err == nil && name != "Local"
+}
+
+// validateNodeVisibility validates the output format a node is restricted to.
+func validateNodeVisibility(vctx *validationContext) {
+	indexByType := make(map[string]int)
+	for node := range vctx.doc.AllNodes() {
+		i := indexByType[node.Type]
+		indexByType[node.Type]++
+
+		visibility, ok := node.Attrs["visibility"]
+		if !ok || visibility == nil || visibility == "" {
+			continue
+		}
+		if v, isString := visibility.(string); !isString || !portabledoc.ValidOutputFormats.Contains(v) {
+			vctx.addErrorf(ErrCodeInvalidVisibility, fmt.Sprintf("content.%s[%d].attrs.visibility", node.Type, i),
+				"Invalid node visibility: %v. Must be pdf, html, or print", visibility)
+		}
+	}
+}
+
+// validateUnknownNodes reports the nodes of unknown types with their paths. They are errors when
+// the workspace fails renders of unknown nodes and warnings otherwise, as renders still succeed.
+func (s *Service) validateUnknownNodes(vctx *validationContext) {
+	unknown := vctx.doc.UnknownNodes()
+	if len(unknown) == 0 {
+		return
+	}
+
+	mode := entity.UnknownNodesFlatten
+	if s.settings != nil {
+		if view, err := s.settings.GetWorkspaceSettings(vctx.ctx, vctx.workspaceID); err == nil {
+			mode = view.Settings.UnknownNodeMode()
+		}
+	}
+	for _, node := range unknown {
+		switch mode {
+		case entity.UnknownNodesFail:
+			vctx.addErrorf(ErrCodeUnknownNode, node.Path,
+				"Unknown node type %q: the workspace fails renders of unknown nodes", node.Type)
+		case entity.UnknownNodesPlaceholder:
+			vctx.addWarningf(WarnCodeUnknownNode, node.Path,
+				"Unknown node type %q: renders show a placeholder in its place", node.Type)
+		default:
+			vctx.addWarningf(WarnCodeUnknownNode, node.Path,
+				"Unknown node type %q: renders show its content without it", node.Type)
+		}
+	}
 }
 
 // validatePageConfig validates page configuration.
@@ -61,6 +158,82 @@ func (s *Service) validatePageConfig(vctx *validationContext) {
 
 	// Validate margins
 	validateMargins(vctx, pc.Margins)
+
+	// Page stamp position must be valid if a stamp is set
+	if pc.PageStamp != nil && !portabledoc.ValidPageStampPositions.Contains(pc.PageStamp.Position) {
+		vctx.addErrorf(ErrCodeInvalidPageStamp, "pageConfig.pageStamp.position",
+			"Invalid page stamp position: %s", pc.PageStamp.Position)
+	}
+
+	if pc.LayoutVariants != nil {
+		validateLayoutVariants(vctx, pc.LayoutVariants)
+	}
+
+	if pc.Overlays != nil {
+		validateOverlays(vctx, pc.Overlays)
+	}
+}
+
+// validateOverlays validates the watermark, stamp and Bates numbering printed on every page.
+func validateOverlays(vctx *validationContext, overlays *portabledoc.Overlays) {
+	if len(overlays.Watermark) > portabledoc.MaxOverlayWatermarkLength {
+		vctx.addErrorf(ErrCodeInvalidOverlays, "pageConfig.overlays.watermark",
+			"Watermark must be at most %d characters", portabledoc.MaxOverlayWatermarkLength)
+	}
+	if stamp := overlays.Stamp; stamp != nil {
+		if stamp.Text == "" || len(stamp.Text) > portabledoc.MaxOverlayStampLength {
+			vctx.addErrorf(ErrCodeInvalidOverlays, "pageConfig.overlays.stamp.text",
+				"Stamp text is required and must be at most %d characters", portabledoc.MaxOverlayStampLength)
+		}
+		if stamp.Position != "" && !portabledoc.ValidPageStampPositions.Contains(stamp.Position) {
+			vctx.addErrorf(ErrCodeInvalidOverlays, "pageConfig.overlays.stamp.position",
+				"Invalid stamp position: %s", stamp.Position)
+		}
+		if stamp.Color != "" && !hexColorRegex.MatchString(stamp.Color) {
+			vctx.addErrorf(ErrCodeInvalidOverlays, "pageConfig.overlays.stamp.color",
+				"Invalid stamp color: %s. Must be #RRGGBB", stamp.Color)
+		}
+	}
+	if bates := overlays.Bates; bates != nil {
+		if len(bates.Prefix) > portabledoc.MaxBatesPrefixLength {
+			vctx.addErrorf(ErrCodeInvalidOverlays, "pageConfig.overlays.bates.prefix",
+				"Bates prefix must be at most %d characters", portabledoc.MaxBatesPrefixLength)
+		}
+		if bates.Start < 0 || bates.Start > portabledoc.MaxBatesStart {
+			vctx.addErrorf(ErrCodeInvalidOverlays, "pageConfig.overlays.bates.start",
+				"Bates start must be between 0 and %d, got: %d", portabledoc.MaxBatesStart, bates.Start)
+		}
+		if bates.Digits < 0 || bates.Digits > portabledoc.MaxBatesDigits {
+			vctx.addErrorf(ErrCodeInvalidOverlays, "pageConfig.overlays.bates.digits",
+				"Bates digits must be between 0 and %d, got: %d", portabledoc.MaxBatesDigits, bates.Digits)
+		}
+		if bates.Position != "" && !portabledoc.ValidPageStampPositions.Contains(bates.Position) {
+			vctx.addErrorf(ErrCodeInvalidOverlays, "pageConfig.overlays.bates.position",
+				"Invalid Bates position: %s", bates.Position)
+		}
+	}
+}
+
+// validateLayoutVariants validates the layout values a render request may choose.
+func validateLayoutVariants(vctx *validationContext, variants *portabledoc.LayoutVariants) {
+	for i, size := range variants.PaperSizes {
+		if _, ok := portabledoc.StandardPageSizes[size]; !ok {
+			vctx.addErrorf(ErrCodeInvalidLayout, fmt.Sprintf("pageConfig.layoutVariants.paperSizes[%d]", i),
+				"Invalid paper size: %s. Must be A4, LETTER, or LEGAL", size)
+		}
+	}
+	for i, preset := range variants.MarginPresets {
+		if _, ok := portabledoc.MarginPresets[preset]; !ok {
+			vctx.addErrorf(ErrCodeInvalidLayout, fmt.Sprintf("pageConfig.layoutVariants.marginPresets[%d]", i),
+				"Invalid margin preset: %s. Must be narrow, normal, or wide", preset)
+		}
+	}
+	for i, scale := range variants.FontScales {
+		if scale < portabledoc.MinFontScale || scale > portabledoc.MaxFontScale {
+			vctx.addErrorf(ErrCodeInvalidLayout, fmt.Sprintf("pageConfig.layoutVariants.fontScales[%d]", i),
+				"Font scale must be between %.1f and %.1f, got: %g", portabledoc.MinFontScale, portabledoc.MaxFontScale, scale)
+		}
+	}
 }
 
 // validateMargins validates page margins.
@@ -0,0 +1,120 @@
+package contentvalidator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
+)
+
+type workspaceSettingsStub struct {
+	organizationuc.WorkspaceSettingsUseCase
+	mode entity.UnknownNodeMode
+}
+
+func (s workspaceSettingsStub) GetWorkspaceSettings(_ context.Context, workspaceID string) (*entity.WorkspaceSettingsView, error) {
+	return &entity.WorkspaceSettingsView{
+		WorkspaceID: workspaceID,
+		Settings:    entity.WorkspaceSettings{RenderOptions: &entity.WorkspaceRenderOptions{UnknownNodes: s.mode}},
+	}, nil
+}
+
+func TestValidateForPublish_UnknownNodes(t *testing.T) {
+	t.Parallel()
+
+	doc := baseDoc()
+	doc.Content.Content = []portabledoc.Node{
+		{Type: portabledoc.NodeTypeParagraph},
+		{Type: "callout", Content: []portabledoc.Node{{Type: portabledoc.NodeTypeParagraph}}},
+	}
+	content := mustMarshalDoc(t, doc)
+
+	result := New(nil).ValidateForPublish(context.Background(), "ws-1", "ver-1", content)
+	if !result.Valid {
+		t.Fatalf("expected unknown nodes to be warnings by default, got %+v", result.Errors)
+	}
+	if len(result.Warnings) != 1 || result.Warnings[0].Code != WarnCodeUnknownNode || result.Warnings[0].Path != "content.content[1]" {
+		t.Errorf("expected an UNKNOWN_NODE warning at content.content[1], got %+v", result.Warnings)
+	}
+
+	strict := New(nil, WithWorkspaceSettings(workspaceSettingsStub{mode: entity.UnknownNodesFail}))
+	result = strict.ValidateForPublish(context.Background(), "ws-1", "ver-1", content)
+	if result.Valid {
+		t.Fatal("expected unknown nodes to fail publishing in a workspace that fails their renders")
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Code != ErrCodeUnknownNode || result.Errors[0].Path != "content.content[1]" {
+		t.Errorf("expected an UNKNOWN_NODE error at content.content[1], got %+v", result.Errors)
+	}
+}
+
+func TestValidateForPublish_SurfacePages(t *testing.T) {
+	t.Parallel()
+
+	doc := baseDoc()
+	doc.Header = &portabledoc.DocumentHeader{Enabled: true, Pages: portabledoc.SurfacePagesAll}
+	doc.Footer = &portabledoc.DocumentFooter{Enabled: true, Pages: "odd"}
+
+	result := New(nil).ValidateForPublish(context.Background(), "ws-1", "ver-1", mustMarshalDoc(t, doc))
+	if result.Valid {
+		t.Fatal("expected invalid footer pages to fail publishing")
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Code != ErrCodeInvalidSurface || result.Errors[0].Path != "footer.pages" {
+		t.Errorf("expected an INVALID_SURFACE error at footer.pages, got %+v", result.Errors)
+	}
+}
+
+func TestValidateForPublish_UnitSystem(t *testing.T) {
+	t.Parallel()
+
+	doc := baseDoc()
+	doc.Meta.UnitSystem = portabledoc.UnitSystemImperial
+	if result := New(nil).ValidateForPublish(context.Background(), "ws-1", "ver-1", mustMarshalDoc(t, doc)); !result.Valid {
+		t.Fatalf("expected the imperial unit system to be valid, got %+v", result.Errors)
+	}
+
+	doc.Meta.UnitSystem = "us"
+	result := New(nil).ValidateForPublish(context.Background(), "ws-1", "ver-1", mustMarshalDoc(t, doc))
+	if len(result.Errors) != 1 || result.Errors[0].Code != ErrCodeInvalidUnitSystem || result.Errors[0].Path != "meta.unitSystem" {
+		t.Errorf("expected an INVALID_UNIT_SYSTEM error at meta.unitSystem, got %+v", result.Errors)
+	}
+}
+
+func TestValidateForPublish_Dates(t *testing.T) {
+	t.Parallel()
+
+	doc := baseDoc()
+	doc.Meta.TimeZone = "America/Santiago"
+	doc.Meta.Holidays = []string{"2024-12-25", "25/12/2024"}
+	doc.Content.Content = []portabledoc.Node{{
+		Type: portabledoc.NodeTypeParagraph,
+		Content: []portabledoc.Node{{
+			Type: portabledoc.NodeTypeInjector,
+			Attrs: map[string]any{
+				"variableId": "issue_date", "type": portabledoc.InjectorTypeDate,
+				"timeZone": "Mars/Olympus", "dateOffset": "+30 days",
+			},
+		}},
+	}}
+
+	result := New(nil).ValidateForPublish(context.Background(), "ws-1", "ver-1", mustMarshalDoc(t, doc))
+	found := map[string]string{}
+	for _, err := range result.Errors {
+		found[err.Code] = err.Path
+	}
+	if found[ErrCodeInvalidHoliday] != "meta.holidays[1]" {
+		t.Errorf("expected an INVALID_HOLIDAY error at meta.holidays[1], got %+v", result.Errors)
+	}
+	if _, ok := found[ErrCodeInvalidTimeZone]; !ok {
+		t.Errorf("expected an INVALID_TIME_ZONE error for the injector, got %+v", result.Errors)
+	}
+	if _, ok := found[ErrCodeInvalidDateOffset]; !ok {
+		t.Errorf("expected an INVALID_DATE_OFFSET error for the injector, got %+v", result.Errors)
+	}
+	for _, err := range result.Errors {
+		if err.Path == "meta.timeZone" {
+			t.Errorf("America/Santiago is a valid time zone, got %+v", err)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package contentvalidator
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+// validateTableCalculations validates the calculated columns and aggregates of table injectors.
+// Column keys are not checked: the columns of an injected table are only known at render time.
+func (s *Service) validateTableCalculations(vctx *validationContext) {
+	for i, node := range vctx.doc.NodesOfType(portabledoc.NodeTypeTableInjector) {
+		path := fmt.Sprintf("content.tableInjector[%d].attrs", i)
+		attrs, err := portabledoc.ParseTableInjectorAttrs(node.Attrs)
+		if err != nil {
+			vctx.addErrorf(ErrCodeInvalidTableCalculation, path,
+				"Invalid table calculation attributes: %s", err.Error())
+			continue
+		}
+		validateCalculatedColumns(vctx, attrs.CalculatedColumns, path+".calculatedColumns")
+		validateTableAggregates(vctx, attrs.Aggregates, path+".aggregates")
+	}
+}
+
+// validateCalculatedColumns checks keys are set and unique and expressions compile.
+func validateCalculatedColumns(vctx *validationContext, columns []portabledoc.CalculatedColumn, path string) {
+	keys := make(portabledoc.Set[string], len(columns))
+	for i, col := range columns {
+		colPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case col.Key == "":
+			vctx.addError(ErrCodeInvalidTableCalculation, colPath+".key", "Calculated column key is required")
+		case keys.Contains(col.Key):
+			vctx.addErrorf(ErrCodeInvalidTableCalculation, colPath+".key",
+				"Duplicate calculated column key: %s", col.Key)
+		default:
+			keys.Add(col.Key)
+		}
+
+		if col.Expression == "" {
+			vctx.addError(ErrCodeInvalidTableCalculation, colPath+".expression", "Calculated column expression is required")
+			continue
+		}
+		if _, err := expr.Compile(col.Expression, expr.AllowUndefinedVariables()); err != nil {
+			vctx.addErrorf(ErrCodeExpressionSyntax, colPath+".expression",
+				"Invalid calculated column expression: %s", err.Error())
+		}
+	}
+}
+
+// validateTableAggregates checks every aggregate names a column and a known function.
+func validateTableAggregates(vctx *validationContext, aggregates []portabledoc.TableAggregate, path string) {
+	for i, agg := range aggregates {
+		aggPath := fmt.Sprintf("%s[%d]", path, i)
+		if agg.Column == "" {
+			vctx.addError(ErrCodeInvalidTableCalculation, aggPath+".column", "Aggregate column is required")
+		}
+		if !portabledoc.ValidAggregateFunctions.Contains(agg.Function) {
+			vctx.addErrorf(ErrCodeInvalidTableCalculation, aggPath+".function",
+				"Invalid aggregate function: %s. Must be sum, avg, min, max, or count", agg.Function)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/formatter"
 )
 
 // validateVariables validates all variables and injectors in the document.
@@ -17,6 +18,9 @@ func (s *Service) validateVariables(vctx *validationContext) {
 
 	// Validate image injectable references (body + header)
 	validateImageInjectableRefs(vctx)
+
+	// Validate the injectables repeaters iterate over
+	validateRepeaters(vctx)
 }
 
 // validateDeclaredVariables validates that all declared variableIds are accessible.
@@ -91,6 +95,16 @@ func validateInjectorNode(vctx *validationContext, node portabledoc.Node, path s
 			"Invalid injector type: %s", attrs.Type)
 	}
 
+	if attrs.TimeZone != nil && *attrs.TimeZone != "" && !validTimeZone(*attrs.TimeZone) {
+		vctx.addErrorf(ErrCodeInvalidTimeZone, path+".attrs.timeZone",
+			"Unknown time zone: %s", *attrs.TimeZone)
+	}
+	if attrs.DateOffset != nil && *attrs.DateOffset != "" {
+		if _, err := formatter.ParseDateOffset(*attrs.DateOffset); err != nil {
+			vctx.addError(ErrCodeInvalidDateOffset, path+".attrs.dateOffset", err.Error())
+		}
+	}
+
 	// Validate variableId
 	if attrs.VariableID == "" {
 		vctx.addError(ErrCodeUnknownVariable, path+".attrs.variableId",
@@ -98,8 +112,8 @@ func validateInjectorNode(vctx *validationContext, node portabledoc.Node, path s
 		return
 	}
 
-	// Variable must be in variableIds and in variableSet
-	if !vctx.variableSet.Contains(attrs.VariableID) {
+	// Variable must be in variableIds and in variableSet, or read the item of a repeater
+	if !vctx.hasVariable(attrs.VariableID) {
 		vctx.addErrorf(ErrCodeUnknownVariable, path+".attrs.variableId",
 			"Variable '%s' not found in document variableIds", attrs.VariableID)
 	}
@@ -119,6 +133,9 @@ func validateImageInjectableRefs(vctx *validationContext) {
 		}
 		path := fmt.Sprintf("content.customImage[%d].attrs.injectableId", idx)
 		idx++
+		if portabledoc.IsRepeaterItemVariable(id, vctx.repeaterAliases) {
+			continue
+		}
 
 		if !vctx.variableSet.Contains(id) {
 			vctx.addErrorf(ErrCodeUnknownVariable, path,
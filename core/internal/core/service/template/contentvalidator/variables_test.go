@@ -221,3 +221,45 @@ func TestValidateForPublish_ExtractsInjectablesUsedOnlyByImagesAndHeader(t *test
 		t.Fatalf("expected system/external header injectable to be extracted: %+v", result.ExtractedInjectables)
 	}
 }
+
+func TestValidateForPublish_RepeaterItemVariables(t *testing.T) {
+	t.Parallel()
+
+	doc := baseDoc()
+	doc.VariableIDs = []string{"invoice_lines"}
+	doc.Content.Content = []portabledoc.Node{
+		{
+			Type:  portabledoc.NodeTypeRepeater,
+			Attrs: map[string]any{"variableId": "invoice_lines", "alias": "line"},
+			Content: []portabledoc.Node{{
+				Type: portabledoc.NodeTypeParagraph,
+				Content: []portabledoc.Node{
+					{Type: portabledoc.NodeTypeInjector, Attrs: map[string]any{"variableId": "line.description", "type": portabledoc.InjectorTypeText}},
+					{Type: portabledoc.NodeTypeInjector, Attrs: map[string]any{"variableId": "line.index", "type": portabledoc.InjectorTypeNumber}},
+				},
+			}},
+		},
+		{
+			Type:  portabledoc.NodeTypeRepeater,
+			Attrs: map[string]any{"variableId": "students", "alias": "invoice_lines"},
+		},
+	}
+
+	result := New(nil).ValidateForPublish(context.Background(), "ws-1", "ver-1", mustMarshalDoc(t, doc))
+
+	if result.Valid {
+		t.Fatal("expected validation to fail, got valid result")
+	}
+	want := map[string]string{
+		"content.repeater[1].attrs.variableId": ErrCodeUnknownVariable,
+		"content.repeater[1].attrs.alias":      ErrCodeInvalidRepeater,
+	}
+	if len(result.Errors) != len(want) {
+		t.Fatalf("expected %d errors, got %+v", len(want), result.Errors)
+	}
+	for _, err := range result.Errors {
+		if want[err.Path] != err.Code {
+			t.Errorf("unexpected error %s at %s", err.Code, err.Path)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package template
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// DocxRenderOperation is the InjectorContext operation of a DOCX export render.
+const DocxRenderOperation = "docx"
+
+// RenderDocxByDocumentType resolves a template like RenderByDocumentType and converts it to DOCX.
+func (s *InternalRenderService) RenderDocxByDocumentType(ctx context.Context, cmd templateuc.InternalRenderCommand) (*port.DocxRenderResult, error) {
+	version, err := s.resolveVersion(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return s.renderVersionDocx(ctx, version, cmd)
+}
+
+// RenderDocxByVersionID converts a specific template version to DOCX.
+func (s *InternalRenderService) RenderDocxByVersionID(ctx context.Context, cmd templateuc.RenderByVersionIDCommand) (*port.DocxRenderResult, error) {
+	version, err := s.versionRepo.FindByIDWithDetails(ctx, cmd.VersionID)
+	if err != nil {
+		return nil, fmt.Errorf("finding version %s: %w", cmd.VersionID, err)
+	}
+	return s.renderVersionDocx(ctx, version, versionRenderCommand(cmd))
+}
+
+// renderVersionDocx converts a version to DOCX with the injectables of a render. Like HTML
+// renders, it is neither dispatched to subscribers nor recorded in the render statistics, but the
+// render quotas apply to it.
+func (s *InternalRenderService) renderVersionDocx(
+	ctx context.Context,
+	version *entity.TemplateVersionWithDetails,
+	cmd templateuc.InternalRenderCommand,
+) (*port.DocxRenderResult, error) {
+	if cmd.Imposition != nil {
+		return nil, entity.ErrDocxRenderOption
+	}
+	if err := s.allowRender(cmd); err != nil {
+		return nil, err
+	}
+	renderReq, resolution, err := s.buildRenderRequest(ctx, version, cmd, newRenderContext(version, cmd, DocxRenderOperation))
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.pdfRenderer.RenderDocx(ctx, renderReq)
+	if err != nil {
+		return nil, err
+	}
+	result.Warnings = append(resolution.degradations, result.Warnings...)
+	return result, nil
+}
@@ -0,0 +1,447 @@
+// Package docximport converts Word (.docx) templates into portable documents.
+package docximport
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/service/template/templateimport"
+)
+
+// SourceApp identifies imported documents in their export info.
+const SourceApp = "pdf-forge-docx-import"
+
+// twipsPerPixel converts Word's twentieths of a point to 96 DPI pixels.
+const twipsPerPixel = 15
+
+// pageSizeTolerance is how far, in pixels, a Word page size may be from a standard format
+// and still use it: Word stores sizes rounded to twips.
+const pageSizeTolerance = 6
+
+// maxListLevels is the number of list levels Word supports.
+const maxListLevels = 9
+
+// alignments maps paragraph justification values to the textAlign attr.
+var alignments = map[string]string{
+	"center":     "center",
+	"right":      "right",
+	"end":        "right",
+	"both":       "justify",
+	"distribute": "justify",
+}
+
+// wrapperElements hold content without meaning of their own; their children are converted in place.
+var wrapperElements = portabledoc.Set[string]{
+	"ins": {}, "smartTag": {}, "customXml": {}, "dir": {}, "bdo": {}, "moveTo": {},
+}
+
+// Import converts a DOCX file into a portable document. {{placeholders}} and MERGEFIELD fields
+// that match an injectable key become injectors, even when Word split them across runs. mapping
+// binds placeholder names to injectable keys explicitly, ahead of the match by name.
+func Import(data []byte, injectables []*entity.InjectableDefinition, mapping map[string]string) (*portabledoc.Document, *entity.TemplateImportReport, error) {
+	if len(data) == 0 {
+		return nil, nil, entity.ErrEmptyTemplateImport
+	}
+	if len(data) > entity.DocxImportMaxBytes {
+		return nil, nil, entity.ErrDocxImportTooLarge
+	}
+
+	pkg, err := openPackage(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c := &converter{
+		Session:    templateimport.NewSession(injectables),
+		pkg:        pkg,
+		listCounts: make(map[string]int),
+	}
+	names := make([]string, 0, len(mapping))
+	for name := range mapping {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if !c.Alias(name, mapping[name]) {
+			return nil, nil, fmt.Errorf("%w: {{%s}} is mapped to %q", entity.ErrInvalidImportMapping, name, mapping[name])
+		}
+	}
+
+	content := c.blocks(pkg.body.children)
+	if isEmptyDocument(content) {
+		return nil, nil, entity.ErrEmptyTemplateImport
+	}
+
+	sectPr := pkg.body.child("sectPr")
+	if sectPr.child("headerReference") != nil || sectPr.child("footerReference") != nil {
+		c.WarnOnce("surfaces", "Word headers and footers were not imported; recreate them in the editor")
+	}
+
+	doc := c.Document(portabledoc.Meta{Title: pkg.title}, pageConfig(sectPr), content, SourceApp)
+	return doc, c.Report(), nil
+}
+
+// converter holds the state of one conversion.
+type converter struct {
+	*templateimport.Session
+	pkg *docxPackage
+
+	// listCounts counts the items of each list level already converted, keyed by numId and level,
+	// so a list interrupted by other paragraphs continues its numbering.
+	listCounts map[string]int
+
+	// fields is the stack of complex fields (w:fldChar) open in the current run sequence.
+	fields []*field
+
+	// pendingImages collects images found inside a paragraph; they are placed after it.
+	pendingImages []portabledoc.Node
+}
+
+// listEntry is a numbered paragraph waiting to be nested into lists.
+type listEntry struct {
+	numID  string
+	level  int
+	blocks []portabledoc.Node
+}
+
+// blocks converts body-level elements into block nodes.
+func (c *converter) blocks(elements []*xmlNode) []portabledoc.Node {
+	var out []portabledoc.Node
+	var entries []listEntry
+
+	flushList := func() {
+		out = append(out, c.lists(entries)...)
+		entries = nil
+	}
+
+	for _, el := range flattenBlocks(elements) {
+		switch el.name {
+		case "p":
+			numID, level := c.numbering(el)
+			blocks, quote := c.paragraph(el)
+			if numID != "" {
+				entries = append(entries, listEntry{numID: numID, level: level, blocks: blocks})
+				continue
+			}
+			flushList()
+			if quote {
+				out = appendQuote(out, blocks)
+				continue
+			}
+			out = append(out, blocks...)
+		case "tbl":
+			flushList()
+			out = append(out, c.table(el)...)
+		case "altChunk":
+			c.Unsupported("embedded document")
+		}
+	}
+	flushList()
+	return out
+}
+
+// flattenBlocks replaces content controls and other wrappers with the blocks they hold.
+func flattenBlocks(elements []*xmlNode) []*xmlNode {
+	var out []*xmlNode
+	for _, el := range elements {
+		switch {
+		case el.name == "sdt":
+			if content := el.child("sdtContent"); content != nil {
+				out = append(out, flattenBlocks(content.children)...)
+			}
+		case wrapperElements.Contains(el.name):
+			out = append(out, flattenBlocks(el.children)...)
+		default:
+			out = append(out, el)
+		}
+	}
+	return out
+}
+
+// numbering returns the list a paragraph belongs to, from its own properties or its style.
+// numId 0 removes the numbering a style would apply.
+func (c *converter) numbering(p *xmlNode) (numID string, level int) {
+	pPr := p.child("pPr")
+	if numPr := pPr.child("numPr"); numPr != nil && numPr.val("numId") != "" {
+		numID = numPr.val("numId")
+		level, _ = strconv.Atoi(numPr.val("ilvl"))
+	} else {
+		numID, level = c.pkg.styleNumbering(pPr.val("pStyle"))
+	}
+	if numID == "0" {
+		return "", 0
+	}
+	return numID, level
+}
+
+// paragraph converts a paragraph into a paragraph or heading, split at page breaks and followed by
+// the images it held. quote reports whether it uses a quote style.
+func (c *converter) paragraph(p *xmlNode) (blocks []portabledoc.Node, quote bool) {
+	pPr := p.child("pPr")
+	styleID := pPr.val("pStyle")
+
+	node := portabledoc.Node{Type: portabledoc.NodeTypeParagraph}
+	if level := c.pkg.headingLevel(styleID); level > 0 {
+		node = portabledoc.Node{Type: portabledoc.NodeTypeHeading, Attrs: map[string]any{"level": level}}
+	}
+	align, hasAlign := alignments[pPr.val("jc")]
+	if hasAlign {
+		if node.Attrs == nil {
+			node.Attrs = map[string]any{}
+		}
+		node.Attrs["textAlign"] = align
+	}
+
+	if on, _ := pPr.flag("pageBreakBefore"); on {
+		blocks = append(blocks, portabledoc.Node{Type: portabledoc.NodeTypePageBreak})
+	}
+
+	// Page breaks inside the paragraph split it. An empty paragraph is kept only when it held
+	// nothing at all, since Word documents use them for spacing.
+	inline := c.inline(p.children)
+	images := c.pendingImages
+	c.pendingImages = nil
+
+	part := node
+	flush := func(keepEmpty bool) {
+		if len(part.Content) > 0 || keepEmpty {
+			part.Attrs = maps.Clone(node.Attrs)
+			blocks = append(blocks, part)
+		}
+		part = node
+	}
+	for _, n := range inline {
+		if n.Type == portabledoc.NodeTypePageBreak {
+			flush(false)
+			blocks = append(blocks, n)
+			continue
+		}
+		part.Content = append(part.Content, n)
+	}
+	flush(len(inline) == 0 && len(images) == 0)
+
+	for _, img := range images {
+		if hasAlign && align != "justify" {
+			img.Attrs["align"] = align
+		}
+		blocks = append(blocks, img)
+	}
+	return blocks, c.pkg.isQuote(styleID)
+}
+
+// appendQuote adds blocks to the blockquote ending out, starting one if needed, so consecutive
+// quote paragraphs form a single quote.
+func appendQuote(out, blocks []portabledoc.Node) []portabledoc.Node {
+	if len(blocks) == 0 {
+		return out
+	}
+	if last := len(out) - 1; last >= 0 && out[last].Type == portabledoc.NodeTypeBlockquote {
+		out[last].Content = append(out[last].Content, blocks...)
+		return out
+	}
+	return append(out, portabledoc.Node{Type: portabledoc.NodeTypeBlockquote, Content: blocks})
+}
+
+// lists nests consecutive numbered paragraphs by level. A change of list at the same level
+// starts a new list.
+func (c *converter) lists(entries []listEntry) []portabledoc.Node {
+	var out []portabledoc.Node
+	for i := 0; i < len(entries); {
+		numID, level := entries[i].numID, entries[i].level
+		format := c.pkg.listLevel(numID, level)
+
+		list := portabledoc.Node{Type: portabledoc.NodeTypeBulletList}
+		if format.ordered {
+			list.Type = portabledoc.NodeTypeOrderedList
+			if start := format.start + c.listCounts[listCountKey(numID, level)]; start != 1 {
+				list.Attrs = map[string]any{"start": start}
+			}
+		}
+
+		for i < len(entries) && entries[i].level >= level {
+			entry := entries[i]
+			if entry.level == level {
+				if entry.numID != numID {
+					break
+				}
+				list.Content = append(list.Content, portabledoc.Node{
+					Type:    portabledoc.NodeTypeListItem,
+					Content: nonEmpty(entry.blocks),
+				})
+				c.countItem(numID, level)
+				i++
+				continue
+			}
+
+			// Deeper entries nest into the last item
+			j := i
+			for j < len(entries) && entries[j].level > level {
+				j++
+			}
+			if len(list.Content) == 0 {
+				list.Content = append(list.Content, portabledoc.Node{
+					Type:    portabledoc.NodeTypeListItem,
+					Content: nonEmpty(nil),
+				})
+			}
+			last := &list.Content[len(list.Content)-1]
+			last.Content = append(last.Content, c.lists(entries[i:j])...)
+			i = j
+		}
+		out = append(out, list)
+	}
+	return out
+}
+
+// countItem counts a list item. Deeper levels restart, as Word numbers "1, 1.1, 2, 2.1".
+func (c *converter) countItem(numID string, level int) {
+	c.listCounts[listCountKey(numID, level)]++
+	for deeper := level + 1; deeper < maxListLevels; deeper++ {
+		delete(c.listCounts, listCountKey(numID, deeper))
+	}
+}
+
+func listCountKey(numID string, level int) string {
+	return numID + "/" + strconv.Itoa(level)
+}
+
+// tableCell is a cell being placed in the table grid.
+type tableCell struct {
+	node    portabledoc.Node
+	rowspan int
+}
+
+// table converts a table into an editable table. Horizontally merged cells become colspans and
+// vertically merged ones rowspans; rows marked to repeat as header get header cells.
+func (c *converter) table(tbl *xmlNode) []portabledoc.Node {
+	var rows [][]*tableCell
+	open := make(map[int]*tableCell) // vertical merges in progress, by grid column
+
+	for _, tr := range flattenBlocks(tbl.children) {
+		if tr.name != "tr" {
+			continue
+		}
+		trPr := tr.child("trPr")
+		header, _ := trPr.flag("tblHeader")
+		col, _ := strconv.Atoi(trPr.val("gridBefore"))
+
+		var row []*tableCell
+		for _, tc := range flattenBlocks(tr.children) {
+			if tc.name != "tc" {
+				continue
+			}
+			tcPr := tc.child("tcPr")
+			colspan, err := strconv.Atoi(tcPr.val("gridSpan"))
+			if err != nil || colspan < 1 {
+				colspan = 1
+			}
+
+			merge := tcPr.child("vMerge")
+			if merge != nil && merge.attr("val") != "restart" && open[col] != nil {
+				open[col].rowspan++
+				col += colspan
+				continue
+			}
+
+			cellType := portabledoc.NodeTypeTableCell
+			if header {
+				cellType = portabledoc.NodeTypeTableHeader
+			}
+			cell := &tableCell{
+				node: portabledoc.Node{
+					Type:    cellType,
+					Attrs:   map[string]any{"colspan": colspan},
+					Content: nonEmpty(c.blocks(tc.children)),
+				},
+				rowspan: 1,
+			}
+			if merge != nil {
+				open[col] = cell
+			} else {
+				delete(open, col)
+			}
+			row = append(row, cell)
+			col += colspan
+		}
+		if len(row) > 0 {
+			rows = append(rows, row)
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	table := portabledoc.Node{Type: portabledoc.NodeTypeTable}
+	for _, row := range rows {
+		tableRow := portabledoc.Node{Type: portabledoc.NodeTypeTableRow}
+		for _, cell := range row {
+			cell.node.Attrs["rowspan"] = cell.rowspan
+			tableRow.Content = append(tableRow.Content, cell.node)
+		}
+		table.Content = append(table.Content, tableRow)
+	}
+	return []portabledoc.Node{table}
+}
+
+// pageConfig reads the page size and margins of the last section. Sizes within a few pixels of a
+// standard format use it; others become a custom format.
+func pageConfig(sectPr *xmlNode) portabledoc.PageConfig {
+	config := templateimport.DefaultPageConfig
+	if pgSz := sectPr.child("pgSz"); pgSz != nil {
+		width, height := twipsToPixels(pgSz.attr("w")), twipsToPixels(pgSz.attr("h"))
+		if width > 0 && height > 0 {
+			config.FormatID, config.Width, config.Height = portabledoc.PageFormatCustom, width, height
+			for formatID, size := range portabledoc.StandardPageSizes {
+				if math.Abs(size.Width-width) <= pageSizeTolerance && math.Abs(size.Height-height) <= pageSizeTolerance {
+					config.FormatID, config.Width, config.Height = formatID, size.Width, size.Height
+				}
+			}
+		}
+	}
+	if pgMar := sectPr.child("pgMar"); pgMar != nil {
+		margins := portabledoc.Margins{
+			Top:    twipsToPixels(pgMar.attr("top")),
+			Bottom: twipsToPixels(pgMar.attr("bottom")),
+			Left:   twipsToPixels(pgMar.attr("left")),
+			Right:  twipsToPixels(pgMar.attr("right")),
+		}
+		if margins.Top > 0 && margins.Bottom > 0 && margins.Left > 0 && margins.Right > 0 {
+			config.Margins = margins
+		}
+	}
+	return config
+}
+
+// twipsToPixels converts a twips attribute to whole pixels, returning 0 when it is not a number.
+// Negative top and bottom margins (text overlapping the header) are read as their size.
+func twipsToPixels(value string) float64 {
+	twips, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return math.Round(math.Abs(twips) / twipsPerPixel)
+}
+
+// nonEmpty returns blocks, or an empty paragraph when there are none: list items and table cells
+// need at least one block.
+func nonEmpty(blocks []portabledoc.Node) []portabledoc.Node {
+	if len(blocks) == 0 {
+		return []portabledoc.Node{{Type: portabledoc.NodeTypeParagraph}}
+	}
+	return blocks
+}
+
+// isEmptyDocument reports whether the content is only empty paragraphs.
+func isEmptyDocument(content []portabledoc.Node) bool {
+	for _, node := range content {
+		if node.Type != portabledoc.NodeTypeParagraph || len(node.Content) > 0 {
+			return false
+		}
+	}
+	return true
+}
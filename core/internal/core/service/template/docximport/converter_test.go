@@ -0,0 +1,224 @@
+package docximport
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+func testInjectables() []*entity.InjectableDefinition {
+	return []*entity.InjectableDefinition{
+		{Key: "customer_name", Label: "Customer name", DataType: entity.InjectableDataTypeText},
+		{Key: "total_amount", Label: "Total", DataType: entity.InjectableDataTypeCurrency},
+		{Key: "logo", Label: "Logo", DataType: entity.InjectableDataTypeImage},
+	}
+}
+
+const wordNS = `xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+	`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" ` +
+	`xmlns:wp="http://schemas.openxmlformats.org/drawingml/2006/wordprocessingDrawing" ` +
+	`xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"`
+
+const contractBody = `
+<w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t>Service agreement</w:t></w:r></w:p>
+<w:p><w:pPr><w:jc w:val="both"/></w:pPr>
+  <w:r><w:t xml:space="preserve">Between </w:t></w:r>
+  <w:r><w:rPr><w:b/></w:rPr><w:t>{{cust</w:t></w:r>
+  <w:proofErr w:type="spellStart"/>
+  <w:r><w:rPr><w:b/></w:rPr><w:t>omer_name</w:t></w:r>
+  <w:r><w:t>}} and {{Client Ref}}, for </w:t></w:r>
+  <w:r><w:fldChar w:fldCharType="begin"/></w:r>
+  <w:r><w:instrText xml:space="preserve"> MERGEFIELD total_amount \* MERGEFORMAT </w:instrText></w:r>
+  <w:r><w:fldChar w:fldCharType="separate"/></w:r>
+  <w:r><w:t>«total_amount»</w:t></w:r>
+  <w:r><w:fldChar w:fldCharType="end"/></w:r>
+  <w:r><w:t xml:space="preserve">. See </w:t></w:r>
+  <w:hyperlink r:id="rIdLink"><w:r><w:t>terms</w:t></w:r></w:hyperlink>
+  <w:r><w:t xml:space="preserve"> and {{unknown}}.</w:t></w:r>
+</w:p>
+<w:p><w:pPr><w:numPr><w:ilvl w:val="0"/><w:numId w:val="1"/></w:numPr></w:pPr><w:r><w:t>First</w:t></w:r></w:p>
+<w:p><w:pPr><w:numPr><w:ilvl w:val="1"/><w:numId w:val="1"/></w:numPr></w:pPr><w:r><w:t>Nested</w:t></w:r></w:p>
+<w:p><w:pPr><w:numPr><w:ilvl w:val="0"/><w:numId w:val="1"/></w:numPr></w:pPr><w:r><w:t>Second</w:t></w:r></w:p>
+<w:tbl>
+  <w:tr><w:trPr><w:tblHeader/></w:trPr>
+    <w:tc><w:tcPr><w:gridSpan w:val="2"/></w:tcPr><w:p><w:r><w:t>Item</w:t></w:r></w:p></w:tc>
+  </w:tr>
+  <w:tr>
+    <w:tc><w:tcPr><w:vMerge w:val="restart"/></w:tcPr><w:p><w:r><w:t>A</w:t></w:r></w:p></w:tc>
+    <w:tc><w:p><w:r><w:t>10</w:t></w:r></w:p></w:tc>
+  </w:tr>
+  <w:tr>
+    <w:tc><w:tcPr><w:vMerge/></w:tcPr><w:p/></w:tc>
+    <w:tc><w:p><w:r><w:t>20</w:t></w:r></w:p></w:tc>
+  </w:tr>
+</w:tbl>
+<w:p><w:r><w:t>Before</w:t></w:r><w:r><w:br w:type="page"/></w:r><w:r><w:t>After</w:t></w:r></w:p>
+<w:p><w:pPr><w:jc w:val="center"/></w:pPr><w:r><w:drawing><wp:inline>
+  <wp:extent cx="952500" cy="476250"/><wp:docPr id="1" name="Picture 1" descr="{{logo}}"/>
+  <a:graphic><a:graphicData><a:blip r:embed="rIdImage"/></a:graphicData></a:graphic>
+</wp:inline></w:drawing></w:r></w:p>
+<w:p><w:pPr><w:numPr><w:ilvl w:val="0"/><w:numId w:val="1"/></w:numPr></w:pPr><w:r><w:t>Third</w:t></w:r></w:p>
+<w:sectPr>
+  <w:footerReference r:id="rIdFooter"/>
+  <w:pgSz w:w="12240" w:h="15840"/>
+  <w:pgMar w:top="1440" w:right="1080" w:bottom="1440" w:left="1080"/>
+</w:sectPr>`
+
+const contractStyles = `<w:styles ` + wordNS + `>
+  <w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/></w:style>
+</w:styles>`
+
+const contractNumbering = `<w:numbering ` + wordNS + `>
+  <w:abstractNum w:abstractNumId="0">
+    <w:lvl w:ilvl="0"><w:start w:val="1"/><w:numFmt w:val="decimal"/></w:lvl>
+    <w:lvl w:ilvl="1"><w:start w:val="1"/><w:numFmt w:val="bullet"/></w:lvl>
+  </w:abstractNum>
+  <w:num w:numId="1"><w:abstractNumId w:val="0"/></w:num>
+</w:numbering>`
+
+const contractRels = `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rIdStyles" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+  <Relationship Id="rIdNumbering" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/numbering" Target="numbering.xml"/>
+  <Relationship Id="rIdLink" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" Target="https://example.com/terms" TargetMode="External"/>
+  <Relationship Id="rIdImage" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="media/image1.png"/>
+</Relationships>`
+
+// buildDocx zips a minimal DOCX package around a document body.
+func buildDocx(t *testing.T, body string) []byte {
+	t.Helper()
+	parts := map[string]string{
+		"_rels/.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+</Relationships>`,
+		"word/document.xml":            `<w:document ` + wordNS + `><w:body>` + body + `</w:body></w:document>`,
+		"word/_rels/document.xml.rels": contractRels,
+		"word/styles.xml":              contractStyles,
+		"word/numbering.xml":           contractNumbering,
+		"word/media/image1.png":        "\x89PNG\r\n\x1a\n",
+		"docProps/core.xml": `<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" ` +
+			`xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:title>Service agreement</dc:title></cp:coreProperties>`,
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("zip create: %v", err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("zip write: %v", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zip close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestImport_Contract(t *testing.T) {
+	mapping := map[string]string{"Client Ref": "customer_name"}
+	doc, report, err := Import(buildDocx(t, contractBody), testInjectables(), mapping)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	if doc.Meta.Title != "Service agreement" {
+		t.Errorf("expected title from core properties, got %q", doc.Meta.Title)
+	}
+	if doc.PageConfig.FormatID != portabledoc.PageFormatLetter || doc.PageConfig.Margins.Left != 72 || doc.PageConfig.Margins.Top != 96 {
+		t.Errorf("unexpected page config: %+v", doc.PageConfig)
+	}
+
+	var types []string
+	for _, node := range doc.Content.Content {
+		types = append(types, node.Type)
+	}
+	want := []string{
+		portabledoc.NodeTypeHeading, portabledoc.NodeTypeParagraph, portabledoc.NodeTypeOrderedList,
+		portabledoc.NodeTypeTable, portabledoc.NodeTypeParagraph, portabledoc.NodeTypePageBreak,
+		portabledoc.NodeTypeParagraph, portabledoc.NodeTypeCustomImage, portabledoc.NodeTypeOrderedList,
+	}
+	if !slices.Equal(types, want) {
+		t.Fatalf("block types = %v, want %v", types, want)
+	}
+
+	para := doc.Content.Content[1]
+	if para.Attrs["textAlign"] != "justify" {
+		t.Errorf("expected a justified paragraph, got %+v", para.Attrs)
+	}
+	var injectors []string
+	for _, node := range para.Content {
+		if node.Type == portabledoc.NodeTypeInjector {
+			injectors = append(injectors, node.Attrs["variableId"].(string))
+		}
+		if node.Text != nil && strings.Contains(*node.Text, "«") {
+			t.Errorf("the merge field result should be replaced, got %q", *node.Text)
+		}
+	}
+	if !slices.Equal(injectors, []string{"customer_name", "customer_name", "total_amount"}) {
+		t.Errorf("unexpected injectors: %v", injectors)
+	}
+	if first := para.Content[1]; len(first.Marks) != 1 || first.Marks[0].Type != portabledoc.MarkTypeBold {
+		t.Errorf("expected the split placeholder to keep its bold mark, got %+v", first)
+	}
+
+	list := doc.Content.Content[2]
+	if nested := list.Content[0].Content; len(nested) != 2 || nested[1].Type != portabledoc.NodeTypeBulletList {
+		t.Errorf("expected a nested bullet list, got %+v", nested)
+	}
+	if continued := doc.Content.Content[8]; continued.Attrs["start"] != 3 {
+		t.Errorf("expected the interrupted list to continue at 3, got %+v", continued.Attrs)
+	}
+
+	table := doc.Content.Content[3]
+	header := table.Content[0].Content[0]
+	if header.Type != portabledoc.NodeTypeTableHeader || header.Attrs["colspan"] != 2 {
+		t.Errorf("expected a header cell spanning two columns, got %+v", header)
+	}
+	if merged := table.Content[1].Content[0]; merged.Attrs["rowspan"] != 2 || len(table.Content[2].Content) != 1 {
+		t.Errorf("expected a cell spanning two rows, got %+v", table.Content)
+	}
+
+	image := doc.Content.Content[7]
+	if image.Attrs["injectableId"] != "logo" || image.Attrs["align"] != "center" || image.Attrs["width"] != 100 {
+		t.Errorf("expected a centered logo injectable, got %+v", image.Attrs)
+	}
+
+	if !slices.Equal(doc.VariableIDs, []string{"customer_name", "total_amount", "logo"}) {
+		t.Errorf("unexpected variableIds: %v", doc.VariableIDs)
+	}
+	if !slices.Equal(report.UnmappedPlaceholders, []string{"unknown"}) {
+		t.Errorf("unexpected unmapped placeholders: %v", report.UnmappedPlaceholders)
+	}
+	if report.MappedPlaceholders["Client Ref"] != "customer_name" || len(report.Warnings) != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+}
+
+func TestImport_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		mapping map[string]string
+		want    error
+	}{
+		{"empty file", nil, nil, entity.ErrEmptyTemplateImport},
+		{"not a zip", []byte("plain text"), nil, entity.ErrInvalidTemplateImport},
+		{"empty body", buildDocx(t, `<w:p/>`), nil, entity.ErrEmptyTemplateImport},
+		{"unknown mapping", buildDocx(t, contractBody), map[string]string{"x": "missing"}, entity.ErrInvalidImportMapping},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := Import(tt.data, testInjectables(), tt.mapping); !errors.Is(err, tt.want) {
+				t.Errorf("Import() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
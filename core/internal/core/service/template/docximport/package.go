@@ -0,0 +1,460 @@
+package docximport
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+// maxPartBytes bounds the uncompressed size of a single part, so a crafted archive cannot
+// expand into an unbounded amount of memory.
+const maxPartBytes = 64 << 20 // 64 MiB
+
+// Relationship types, matched by suffix: strict and transitional documents use different prefixes.
+const (
+	relOfficeDocument = "/officeDocument"
+	relStyles         = "/styles"
+	relNumbering      = "/numbering"
+)
+
+// xmlNode is an element of a WordprocessingML part. Elements and attributes are matched by local
+// name: the w:, r:, wp: and a: prefixes do not clash for the elements the importer reads.
+type xmlNode struct {
+	name     string
+	attrs    map[string]string
+	children []*xmlNode
+	text     string // Character data, read from leaf elements such as w:t and w:instrText
+}
+
+// child returns the first direct child with the given name, or nil.
+func (n *xmlNode) child(name string) *xmlNode {
+	if n == nil {
+		return nil
+	}
+	for _, c := range n.children {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// childrenNamed returns the direct children with the given name.
+func (n *xmlNode) childrenNamed(name string) []*xmlNode {
+	if n == nil {
+		return nil
+	}
+	var out []*xmlNode
+	for _, c := range n.children {
+		if c.name == name {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// find returns the first descendant with the given name, depth first, or nil.
+func (n *xmlNode) find(name string) *xmlNode {
+	if n == nil {
+		return nil
+	}
+	for _, c := range n.children {
+		if c.name == name {
+			return c
+		}
+		if found := c.find(name); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// textOrEmpty returns the character data of n, or "" when n is nil.
+func (n *xmlNode) textOrEmpty() string {
+	if n == nil {
+		return ""
+	}
+	return n.text
+}
+
+// attr returns an attribute of n, or "" when n is nil or has no such attribute.
+func (n *xmlNode) attr(name string) string {
+	if n == nil {
+		return ""
+	}
+	return n.attrs[name]
+}
+
+// val returns the w:val attribute of the child with the given name, as in <w:pStyle w:val="Heading1"/>.
+func (n *xmlNode) val(name string) string {
+	return n.child(name).attr("val")
+}
+
+// flag reports whether the on/off property with the given name is set, as in <w:b/> or <w:b w:val="0"/>.
+// The second result is false when the property is absent.
+func (n *xmlNode) flag(name string) (on, set bool) {
+	prop := n.child(name)
+	if prop == nil {
+		return false, false
+	}
+	switch prop.attr("val") {
+	case "0", "false", "off", "none":
+		return false, true
+	}
+	return true, true
+}
+
+// parseXML reads a part into a tree of elements.
+func parseXML(data []byte) (*xmlNode, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	root := &xmlNode{}
+	stack := []*xmlNode{root}
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return root, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &xmlNode{name: t.Name.Local, attrs: make(map[string]string, len(t.Attr))}
+			for _, a := range t.Attr {
+				n.attrs[a.Name.Local] = a.Value
+			}
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, n)
+			stack = append(stack, n)
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			stack[len(stack)-1].text += string(t)
+		}
+	}
+}
+
+// relationship is a target referenced from the main document by relationship ID.
+type relationship struct {
+	kind     string
+	target   string // Part name inside the archive, or the URL of an external target
+	external bool
+}
+
+// style is the part of a paragraph or character style the importer uses.
+type style struct {
+	name         string // Lower-cased, e.g. "heading 1"
+	basedOn      string
+	outlineLevel int // -1 when unset
+	numID        string
+	numLevel     int
+	runProps     *xmlNode
+}
+
+// listLevel is the numbering format of one level of a Word list.
+type listLevel struct {
+	ordered bool
+	start   int
+}
+
+// docxPackage holds the parts of a DOCX file the importer reads.
+type docxPackage struct {
+	files     map[string]*zip.File
+	body      *xmlNode
+	rels      map[string]relationship
+	styles    map[string]*style
+	numbering map[string]map[int]listLevel // numId -> level -> format
+	title     string
+}
+
+// openPackage reads the main document of a DOCX file with its relationships, styles and numbering.
+func openPackage(data []byte) (*docxPackage, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: not a DOCX file", entity.ErrInvalidTemplateImport)
+	}
+
+	pkg := &docxPackage{files: make(map[string]*zip.File, len(archive.File))}
+	for _, f := range archive.File {
+		pkg.files[f.Name] = f
+	}
+
+	mainPart := "word/document.xml"
+	rootRels, err := pkg.relationships("_rels/.rels", "")
+	if err != nil {
+		return nil, err
+	}
+	for _, rel := range rootRels {
+		if strings.HasSuffix(rel.kind, relOfficeDocument) {
+			mainPart = rel.target
+		}
+	}
+
+	document, err := pkg.xmlPart(mainPart)
+	if err != nil {
+		return nil, err
+	}
+	if pkg.body = document.find("body"); pkg.body == nil {
+		return nil, fmt.Errorf("%w: the DOCX file has no document body", entity.ErrInvalidTemplateImport)
+	}
+
+	dir := path.Dir(mainPart)
+	if pkg.rels, err = pkg.relationships(path.Join(dir, "_rels", path.Base(mainPart)+".rels"), dir); err != nil {
+		return nil, err
+	}
+	if err := pkg.readStyles(); err != nil {
+		return nil, err
+	}
+	if err := pkg.readNumbering(); err != nil {
+		return nil, err
+	}
+	if core, err := pkg.xmlPart("docProps/core.xml"); err == nil {
+		pkg.title = strings.TrimSpace(core.find("title").textOrEmpty())
+	}
+	return pkg, nil
+}
+
+// part returns the uncompressed content of a part. Missing parts return nil without error.
+func (p *docxPackage) part(name string) ([]byte, error) {
+	f, ok := p.files[name]
+	if !ok {
+		return nil, nil
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading %s: %v", entity.ErrInvalidTemplateImport, name, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, maxPartBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading %s: %v", entity.ErrInvalidTemplateImport, name, err)
+	}
+	if len(data) > maxPartBytes {
+		return nil, fmt.Errorf("%w: %s is too large once uncompressed", entity.ErrInvalidTemplateImport, name)
+	}
+	return data, nil
+}
+
+// xmlPart reads and parses an XML part.
+func (p *docxPackage) xmlPart(name string) (*xmlNode, error) {
+	data, err := p.part(name)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, fmt.Errorf("%w: the DOCX file has no %s", entity.ErrInvalidTemplateImport, name)
+	}
+	root, err := parseXML(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: parsing %s: %v", entity.ErrInvalidTemplateImport, name, err)
+	}
+	return root, nil
+}
+
+// relationships reads a relationships part, resolving internal targets against dir.
+func (p *docxPackage) relationships(name, dir string) (map[string]relationship, error) {
+	rels := make(map[string]relationship)
+	if _, ok := p.files[name]; !ok {
+		return rels, nil
+	}
+	root, err := p.xmlPart(name)
+	if err != nil {
+		return nil, err
+	}
+	for _, rel := range root.find("Relationships").childrenNamed("Relationship") {
+		target := rel.attr("Target")
+		external := rel.attr("TargetMode") == "External"
+		if !external {
+			if strings.HasPrefix(target, "/") {
+				target = strings.TrimPrefix(target, "/")
+			} else {
+				target = path.Join(dir, target)
+			}
+		}
+		rels[rel.attr("Id")] = relationship{kind: rel.attr("Type"), target: target, external: external}
+	}
+	return rels, nil
+}
+
+// relatedPart returns the part name of the first relationship of the given kind, or fallback.
+func (p *docxPackage) relatedPart(kind, fallback string) string {
+	for _, rel := range p.rels {
+		if strings.HasSuffix(rel.kind, kind) && !rel.external {
+			return rel.target
+		}
+	}
+	return fallback
+}
+
+// readStyles reads the styles part. Documents without one use no styles.
+func (p *docxPackage) readStyles() error {
+	p.styles = make(map[string]*style)
+	name := p.relatedPart(relStyles, "word/styles.xml")
+	if _, ok := p.files[name]; !ok {
+		return nil
+	}
+	root, err := p.xmlPart(name)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range root.find("styles").childrenNamed("style") {
+		st := &style{
+			name:         strings.ToLower(s.val("name")),
+			basedOn:      s.val("basedOn"),
+			outlineLevel: -1,
+			runProps:     s.child("rPr"),
+		}
+		if pPr := s.child("pPr"); pPr != nil {
+			if level, err := strconv.Atoi(pPr.val("outlineLvl")); err == nil {
+				st.outlineLevel = level
+			}
+			if numPr := pPr.child("numPr"); numPr != nil {
+				st.numID = numPr.val("numId")
+				st.numLevel, _ = strconv.Atoi(numPr.val("ilvl"))
+			}
+		}
+		p.styles[s.attr("styleId")] = st
+	}
+	return nil
+}
+
+// styleChain returns a style followed by the styles it is based on, guarding against cycles.
+func (p *docxPackage) styleChain(id string) []*style {
+	var chain []*style
+	for id != "" && len(chain) < 16 {
+		st, ok := p.styles[id]
+		if !ok {
+			break
+		}
+		chain = append(chain, st)
+		id = st.basedOn
+	}
+	return chain
+}
+
+// headingLevel returns the heading level of a paragraph style, or 0 for body text. Built-in
+// heading styles are recognized by name, which Word keeps in English in every locale.
+func (p *docxPackage) headingLevel(styleID string) int {
+	for _, st := range p.styleChain(styleID) {
+		if st.name == "title" {
+			return 1
+		}
+		if level, ok := strings.CutPrefix(st.name, "heading "); ok {
+			if n, err := strconv.Atoi(level); err == nil && n >= 1 {
+				return min(n, 6)
+			}
+		}
+		if st.outlineLevel >= 0 && st.outlineLevel < 9 {
+			return min(st.outlineLevel+1, 6)
+		}
+	}
+	return 0
+}
+
+// isQuote reports whether a paragraph style is a quote style, such as "Quote" or "Intense Quote".
+func (p *docxPackage) isQuote(styleID string) bool {
+	for _, st := range p.styleChain(styleID) {
+		if strings.Contains(st.name, "quote") {
+			return true
+		}
+	}
+	return false
+}
+
+// styleNumbering returns the list numbering a paragraph style applies, as "List Bullet" does.
+func (p *docxPackage) styleNumbering(styleID string) (numID string, level int) {
+	for _, st := range p.styleChain(styleID) {
+		if st.numID != "" {
+			return st.numID, st.numLevel
+		}
+	}
+	return "", 0
+}
+
+// styleMarks applies the run properties of a character style and the styles it is based on.
+func (p *docxPackage) styleMarks(styleID string, marks []portabledoc.Mark) []portabledoc.Mark {
+	chain := p.styleChain(styleID)
+	for i := len(chain) - 1; i >= 0; i-- {
+		marks = formatMarks(chain[i].runProps, marks)
+	}
+	return marks
+}
+
+// readNumbering reads the numbering part, resolving each list instance to the format of its levels.
+func (p *docxPackage) readNumbering() error {
+	p.numbering = make(map[string]map[int]listLevel)
+	name := p.relatedPart(relNumbering, "word/numbering.xml")
+	if _, ok := p.files[name]; !ok {
+		return nil
+	}
+	root, err := p.xmlPart(name)
+	if err != nil {
+		return err
+	}
+	numbering := root.find("numbering")
+
+	abstract := make(map[string]map[int]listLevel)
+	for _, an := range numbering.childrenNamed("abstractNum") {
+		levels := make(map[int]listLevel)
+		for _, lvl := range an.childrenNamed("lvl") {
+			readListLevel(lvl, levels)
+		}
+		abstract[an.attr("abstractNumId")] = levels
+	}
+
+	for _, num := range numbering.childrenNamed("num") {
+		levels := make(map[int]listLevel)
+		for level, format := range abstract[num.val("abstractNumId")] {
+			levels[level] = format
+		}
+		for _, override := range num.childrenNamed("lvlOverride") {
+			level, _ := strconv.Atoi(override.attr("ilvl"))
+			if lvl := override.child("lvl"); lvl != nil {
+				readListLevel(lvl, levels)
+			}
+			if start, err := strconv.Atoi(override.val("startOverride")); err == nil {
+				format := levels[level]
+				format.start = start
+				levels[level] = format
+			}
+		}
+		p.numbering[num.attr("numId")] = levels
+	}
+	return nil
+}
+
+// readListLevel reads a w:lvl definition into levels.
+func readListLevel(lvl *xmlNode, levels map[int]listLevel) {
+	level, _ := strconv.Atoi(lvl.attr("ilvl"))
+	format := listLevel{start: 1}
+	if start, err := strconv.Atoi(lvl.val("start")); err == nil {
+		format.start = start
+	}
+	switch lvl.val("numFmt") {
+	case "bullet", "none", "":
+	default:
+		format.ordered = true
+	}
+	levels[level] = format
+}
+
+// listLevel returns the format of a level of a list instance. Unknown lists are bulleted.
+func (p *docxPackage) listLevel(numID string, level int) listLevel {
+	if format, ok := p.numbering[numID][level]; ok {
+		return format
+	}
+	return listLevel{start: 1}
+}
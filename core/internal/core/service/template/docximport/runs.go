@@ -0,0 +1,432 @@
+package docximport
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/service/template/templateimport"
+)
+
+// emuPerPixel converts drawing extents (English Metric Units) to 96 DPI pixels.
+const emuPerPixel = 9525
+
+// placeholderPattern matches {{...}} and {{{...}}} spans, which are kept in a single run.
+var placeholderPattern = regexp.MustCompile(`\{\{\{?[^{}]*\}?\}\}`)
+
+// imagePlaceholderPattern matches image alt text that names an image injectable, as in {{logo}}.
+var imagePlaceholderPattern = regexp.MustCompile(`^\{\{\s*([^{}\s]+)\s*\}\}$`)
+
+// imageTypes maps the image formats the renderer supports to their MIME type.
+var imageTypes = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".svg":  "image/svg+xml",
+}
+
+// runFormats maps on/off run properties to the mark they apply.
+var runFormats = []struct {
+	property string
+	mark     string
+}{
+	{"b", portabledoc.MarkTypeBold},
+	{"i", portabledoc.MarkTypeItalic},
+	{"u", portabledoc.MarkTypeUnderline},
+	{"strike", portabledoc.MarkTypeStrike},
+	{"dstrike", portabledoc.MarkTypeStrike},
+	{"highlight", portabledoc.MarkTypeHighlight},
+}
+
+// segment is a piece of paragraph content: text with marks, or another inline node.
+type segment struct {
+	text  string
+	marks []portabledoc.Mark
+	node  *portabledoc.Node
+}
+
+// field is a complex field, spread over runs as begin, instruction, separate, result and end.
+type field struct {
+	instr     strings.Builder
+	separated bool
+	converted bool // The field was converted, so its displayed result is skipped
+}
+
+// inline converts the content of a paragraph into inline nodes. Page breaks are returned as
+// pageBreak nodes for the caller to split on; images are moved to pendingImages.
+func (c *converter) inline(children []*xmlNode) []portabledoc.Node {
+	segments := joinPlaceholders(mergeSegments(c.segments(children, nil)))
+
+	var out []portabledoc.Node
+	for _, seg := range segments {
+		if seg.node != nil {
+			out = append(out, *seg.node)
+			continue
+		}
+		out = append(out, c.Text(seg.text, seg.marks)...)
+	}
+	return templateimport.MergeText(out)
+}
+
+// segments collects the runs of a paragraph, or of an element inside one.
+func (c *converter) segments(children []*xmlNode, marks []portabledoc.Mark) []segment {
+	var out []segment
+	for _, el := range children {
+		switch {
+		case el.name == "r":
+			out = append(out, c.run(el, marks)...)
+		case el.name == "hyperlink":
+			linkMarks := marks
+			if rel, ok := c.pkg.rels[el.attr("id")]; ok && rel.external {
+				link := portabledoc.Mark{Type: portabledoc.MarkTypeLink, Attrs: map[string]any{"href": rel.target}}
+				linkMarks = templateimport.AppendMark(marks, link)
+			}
+			out = append(out, c.segments(el.children, linkMarks)...)
+		case el.name == "fldSimple":
+			if seg, ok := c.fieldSegment(el.attr("instr"), c.runMarks(el.find("rPr"), marks)); ok && !c.inFieldCode(c.fields) {
+				out = append(out, seg)
+				continue
+			}
+			out = append(out, c.segments(el.children, marks)...)
+		case el.name == "sdt":
+			if content := el.child("sdtContent"); content != nil {
+				out = append(out, c.segments(content.children, marks)...)
+			}
+		case wrapperElements.Contains(el.name):
+			out = append(out, c.segments(el.children, marks)...)
+		case el.name == "oMath" || el.name == "oMathPara":
+			c.Unsupported("equation")
+		}
+	}
+	return out
+}
+
+// run converts a run. Text inside field instructions, and the displayed result of fields that
+// were converted, is skipped.
+func (c *converter) run(r *xmlNode, marks []portabledoc.Mark) []segment {
+	marks = c.runMarks(r.child("rPr"), marks)
+
+	var out []segment
+	text := func(t string) {
+		if t != "" && !c.inFieldCode(c.fields) {
+			out = append(out, segment{text: t, marks: marks})
+		}
+	}
+	node := func(n portabledoc.Node) {
+		if !c.inFieldCode(c.fields) {
+			out = append(out, segment{node: &n})
+		}
+	}
+
+	for _, el := range r.children {
+		switch el.name {
+		case "t":
+			text(el.text)
+		case "tab", "ptab":
+			text("\t")
+		case "noBreakHyphen":
+			text("-")
+		case "br":
+			switch el.attr("type") {
+			case "page":
+				node(portabledoc.Node{Type: portabledoc.NodeTypePageBreak})
+			case "column":
+			default:
+				node(portabledoc.Node{Type: portabledoc.NodeTypeHardBreak})
+			}
+		case "cr":
+			node(portabledoc.Node{Type: portabledoc.NodeTypeHardBreak})
+		case "drawing":
+			if !c.inFieldCode(c.fields) {
+				c.drawing(el)
+			}
+		case "pict", "object":
+			c.Unsupported("embedded object")
+		case "sym":
+			c.Unsupported("symbol")
+		case "footnoteReference", "endnoteReference":
+			c.Unsupported("footnote")
+		case "fldChar":
+			out = append(out, c.fieldChar(el.attr("fldCharType"), marks)...)
+		case "instrText":
+			if last := len(c.fields) - 1; last >= 0 && !c.fields[last].separated {
+				c.fields[last].instr.WriteString(el.text)
+			}
+		}
+	}
+	return out
+}
+
+// fieldChar advances the complex field state, returning the converted field once its
+// instruction is complete.
+func (c *converter) fieldChar(kind string, marks []portabledoc.Mark) []segment {
+	last := len(c.fields) - 1
+	switch kind {
+	case "begin":
+		c.fields = append(c.fields, &field{})
+	case "separate":
+		if last < 0 {
+			return nil
+		}
+		f := c.fields[last]
+		f.separated = true
+		if seg, ok := c.fieldSegment(f.instr.String(), marks); ok && !c.inFieldCode(c.fields[:last]) {
+			f.converted = true
+			return []segment{seg}
+		}
+	case "end":
+		if last < 0 {
+			return nil
+		}
+		f := c.fields[last]
+		c.fields = c.fields[:last]
+		if f.separated {
+			return nil
+		}
+		// A field without a displayed result
+		if seg, ok := c.fieldSegment(f.instr.String(), marks); ok && !c.inFieldCode(c.fields) {
+			return []segment{seg}
+		}
+	}
+	return nil
+}
+
+// inFieldCode reports whether content is currently skipped: it is a field instruction, or the
+// result of a field that was converted.
+func (c *converter) inFieldCode(fields []*field) bool {
+	for _, f := range fields {
+		if !f.separated || f.converted {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldSegment converts a field instruction. Mail merge fields become placeholders, so they bind
+// to injectables like {{placeholders}} do; page fields become page numbers.
+func (c *converter) fieldSegment(instr string, marks []portabledoc.Mark) (segment, bool) {
+	keyword, args, _ := strings.Cut(strings.TrimSpace(instr), " ")
+	switch strings.ToUpper(keyword) {
+	case "MERGEFIELD":
+		if name := fieldArgument(args); name != "" {
+			return segment{text: "{{" + name + "}}", marks: marks}, true
+		}
+	case "PAGE":
+		return pageNumberSegment(portabledoc.PageNumberCurrent, marks), true
+	case "NUMPAGES", "SECTIONPAGES":
+		return pageNumberSegment(portabledoc.PageNumberTotal, marks), true
+	case "TOC":
+		c.Unsupported("table of contents")
+	}
+	return segment{}, false
+}
+
+// fieldArgument returns the first argument of a field instruction, which may be quoted.
+func fieldArgument(args string) string {
+	args = strings.TrimSpace(args)
+	if rest, ok := strings.CutPrefix(args, `"`); ok {
+		name, _, _ := strings.Cut(rest, `"`)
+		return strings.TrimSpace(name)
+	}
+	name, _, _ := strings.Cut(args, " ")
+	return name
+}
+
+func pageNumberSegment(display string, marks []portabledoc.Mark) segment {
+	return segment{node: &portabledoc.Node{
+		Type:  portabledoc.NodeTypePageNumber,
+		Attrs: map[string]any{"display": display},
+		Marks: marks,
+	}}
+}
+
+// drawing converts a picture into an image node queued after the paragraph. A picture whose alt
+// text is a {{placeholder}} is bound to that image injectable, keeping the sample picture out.
+func (c *converter) drawing(d *xmlNode) {
+	blip := d.find("blip")
+	if blip == nil {
+		switch {
+		case d.find("txbxContent") != nil:
+			c.Unsupported("text box")
+		case d.find("chart") != nil:
+			c.Unsupported("chart")
+		default:
+			c.Unsupported("shape")
+		}
+		return
+	}
+
+	attrs := map[string]any{}
+	docPr := d.find("docPr")
+	alt := strings.TrimSpace(docPr.attr("descr"))
+	if m := imagePlaceholderPattern.FindStringSubmatch(alt); m != nil {
+		key := m[1]
+		alt = ""
+		if inj, ok := c.Injectable(key); ok && inj.DataType == entity.InjectableDataTypeImage {
+			c.Bind(key, inj)
+			attrs["injectableId"] = inj.Key
+			attrs["injectableLabel"] = templateimport.InjectableLabel(inj)
+		} else {
+			c.Unmapped(key)
+			c.WarnOnce("image:"+key, fmt.Sprintf(
+				"Picture with alt text {{%s}} does not match an image injectable and was kept as a static image", key))
+		}
+	}
+	if _, bound := attrs["injectableId"]; !bound {
+		src, ok := c.imageSource(blip)
+		if !ok {
+			return
+		}
+		attrs["src"] = src
+	}
+
+	if alt == "" {
+		alt = strings.TrimSpace(docPr.attr("title"))
+	}
+	if alt != "" {
+		attrs["alt"] = alt
+	}
+	if extent := d.find("extent"); extent != nil {
+		if width, err := strconv.Atoi(extent.attr("cx")); err == nil && width > 0 {
+			attrs["width"] = width / emuPerPixel
+		}
+		if height, err := strconv.Atoi(extent.attr("cy")); err == nil && height > 0 {
+			attrs["height"] = height / emuPerPixel
+		}
+	}
+	c.pendingImages = append(c.pendingImages, portabledoc.Node{Type: portabledoc.NodeTypeCustomImage, Attrs: attrs})
+}
+
+// imageSource returns an embedded picture as a data URI, or the URL of a linked one.
+func (c *converter) imageSource(blip *xmlNode) (string, bool) {
+	if rel, ok := c.pkg.rels[blip.attr("link")]; ok && rel.external {
+		return rel.target, true
+	}
+
+	rel, ok := c.pkg.rels[blip.attr("embed")]
+	if !ok || rel.external {
+		c.Unsupported("picture")
+		return "", false
+	}
+	ext := strings.ToLower(path.Ext(rel.target))
+	mime, ok := imageTypes[ext]
+	if !ok {
+		c.Unsupported(strings.ToUpper(strings.TrimPrefix(ext, ".")) + " picture")
+		return "", false
+	}
+	data, err := c.pkg.part(rel.target)
+	if err != nil || data == nil {
+		c.Unsupported("picture")
+		return "", false
+	}
+	return "data:" + mime + ";base64," + base64.StdEncoding.EncodeToString(data), true
+}
+
+// runMarks applies the character style and direct formatting of a run to marks.
+func (c *converter) runMarks(rPr *xmlNode, marks []portabledoc.Mark) []portabledoc.Mark {
+	if rPr == nil {
+		return marks
+	}
+	if styleID := rPr.val("rStyle"); styleID != "" {
+		marks = c.pkg.styleMarks(styleID, marks)
+	}
+	return formatMarks(rPr, marks)
+}
+
+// formatMarks applies run properties to marks. Properties switched off, as in <w:b w:val="0"/>,
+// remove the mark a style applied.
+func formatMarks(rPr *xmlNode, marks []portabledoc.Mark) []portabledoc.Mark {
+	for _, format := range runFormats {
+		on, set := rPr.flag(format.property)
+		switch {
+		case !set:
+		case on:
+			marks = templateimport.AppendMark(marks, portabledoc.Mark{Type: format.mark})
+		default:
+			marks = slices.DeleteFunc(slices.Clone(marks), func(m portabledoc.Mark) bool { return m.Type == format.mark })
+		}
+	}
+	return marks
+}
+
+// mergeSegments joins adjacent text segments with the same marks: Word splits runs for
+// revision tracking and spell checking even when their formatting is the same.
+func mergeSegments(segments []segment) []segment {
+	var out []segment
+	for _, seg := range segments {
+		if last := len(out) - 1; last >= 0 && seg.node == nil && out[last].node == nil && sameMarks(seg.marks, out[last].marks) {
+			out[last].text += seg.text
+			continue
+		}
+		out = append(out, seg)
+	}
+	return out
+}
+
+// joinPlaceholders moves placeholders that span several text segments into the segment where
+// they start, so {{customer_name}} typed with a bold "customer" still binds.
+func joinPlaceholders(segments []segment) []segment {
+	var out []segment
+	for i := 0; i < len(segments); {
+		if segments[i].node != nil {
+			out = append(out, segments[i])
+			i++
+			continue
+		}
+		j := i
+		for j < len(segments) && segments[j].node == nil {
+			j++
+		}
+		out = append(out, joinStretch(segments[i:j])...)
+		i = j
+	}
+	return out
+}
+
+// joinStretch joins placeholders across a run of consecutive text segments by moving the end of
+// every segment that falls inside a placeholder to the end of the placeholder.
+func joinStretch(stretch []segment) []segment {
+	if len(stretch) < 2 {
+		return stretch
+	}
+
+	var full strings.Builder
+	ends := make([]int, len(stretch))
+	for k, seg := range stretch {
+		full.WriteString(seg.text)
+		ends[k] = full.Len()
+	}
+	text := full.String()
+	for _, m := range placeholderPattern.FindAllStringIndex(text, -1) {
+		for k := range ends {
+			if ends[k] > m[0] && ends[k] < m[1] {
+				ends[k] = m[1]
+			}
+		}
+	}
+
+	var out []segment
+	start := 0
+	for k, seg := range stretch {
+		if ends[k] > start {
+			seg.text = text[start:ends[k]]
+			out = append(out, seg)
+			start = ends[k]
+		}
+	}
+	return out
+}
+
+func sameMarks(a, b []portabledoc.Mark) bool {
+	return slices.EqualFunc(a, b, func(x, y portabledoc.Mark) bool {
+		return x.Type == y.Type && reflect.DeepEqual(x.Attrs, y.Attrs)
+	})
+}
@@ -0,0 +1,164 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// HostedDocumentIndexerOptions configures the hosted document indexer.
+type HostedDocumentIndexerOptions struct {
+	// PollInterval is how often the indexer looks for documents to index.
+	PollInterval time.Duration
+	// Lease is how long a claimed document stays hidden from other indexers.
+	// A failed attempt is retried once its lease expires.
+	Lease time.Duration
+	// BatchSize is the maximum number of documents claimed per poll.
+	BatchSize int
+	// MaxAttempts is the number of attempts before indexing a document is given up.
+	MaxAttempts int
+}
+
+// HostedDocumentIndexer generates the thumbnail and plain text of hosted documents in the background.
+// Several indexers (one per API instance) can run against the same database.
+type HostedDocumentIndexer struct {
+	repo     port.HostedDocumentRepository
+	indexer  port.DocumentIndexer
+	opts     HostedDocumentIndexerOptions
+	stopCh   chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// NewHostedDocumentIndexer creates a hosted document indexer. Call Start to begin polling.
+func NewHostedDocumentIndexer(repo port.HostedDocumentRepository, indexer port.DocumentIndexer, opts HostedDocumentIndexerOptions) *HostedDocumentIndexer {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 5 * time.Second
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = 2 * time.Minute
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 10
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 3
+	}
+
+	return &HostedDocumentIndexer{
+		repo:    repo,
+		indexer: indexer,
+		opts:    opts,
+		stopCh:  make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+// Start runs the polling loop in the background until Stop is called.
+func (x *HostedDocumentIndexer) Start() {
+	go x.loop()
+}
+
+// Stop ends the polling loop and waits for the batch in flight to finish.
+func (x *HostedDocumentIndexer) Stop() {
+	x.stopOnce.Do(func() { close(x.stopCh) })
+	<-x.stopped
+}
+
+func (x *HostedDocumentIndexer) loop() {
+	defer close(x.stopped)
+	ticker := time.NewTicker(x.opts.PollInterval)
+	defer ticker.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-x.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		select {
+		case <-x.stopCh:
+			return
+		case <-ticker.C:
+			if _, err := x.RunOnce(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				slog.WarnContext(ctx, "hosted document indexer poll failed", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// RunOnce claims one batch of documents and indexes it, returning the number indexed.
+func (x *HostedDocumentIndexer) RunOnce(ctx context.Context) (int, error) {
+	docs, err := x.repo.ClaimForIndexing(ctx, x.opts.BatchSize, x.opts.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	indexed := 0
+	for _, doc := range docs {
+		if ctx.Err() != nil {
+			// Unprocessed claims become visible again once the lease expires
+			return indexed, ctx.Err()
+		}
+		if x.index(ctx, doc) {
+			indexed++
+		}
+	}
+	return indexed, nil
+}
+
+// index derives and stores the index of one document, recording the outcome.
+func (x *HostedDocumentIndexer) index(ctx context.Context, doc *entity.HostedDocument) bool {
+	idx, err := x.build(ctx, doc)
+	if err == nil {
+		err = x.repo.SaveIndex(ctx, idx)
+	}
+	if err == nil {
+		return true
+	}
+
+	if doc.IndexAttempts < x.opts.MaxAttempts {
+		slog.WarnContext(ctx, "hosted document indexing failed, will retry",
+			slog.String("hosted_document_id", doc.ID),
+			slog.Int("attempts", doc.IndexAttempts),
+			slog.Any("error", err),
+		)
+		return false
+	}
+
+	slog.ErrorContext(ctx, "hosted document indexing failed permanently",
+		slog.String("hosted_document_id", doc.ID),
+		slog.Int("attempts", doc.IndexAttempts),
+		slog.Any("error", err),
+	)
+	if err := x.repo.MarkIndexFailed(ctx, doc.ID, err.Error()); err != nil {
+		slog.WarnContext(ctx, "hosted document indexer could not record failure",
+			slog.String("hosted_document_id", doc.ID),
+			slog.Any("error", err),
+		)
+	}
+	return false
+}
+
+func (x *HostedDocumentIndexer) build(ctx context.Context, doc *entity.HostedDocument) (*entity.HostedDocumentIndex, error) {
+	thumbnail, err := x.indexer.Thumbnail(ctx, doc.PDF)
+	if err != nil {
+		return nil, fmt.Errorf("generating thumbnail: %w", err)
+	}
+	text, err := x.indexer.ExtractText(ctx, doc.PDF)
+	if err != nil {
+		return nil, fmt.Errorf("extracting text: %w", err)
+	}
+	return &entity.HostedDocumentIndex{DocumentID: doc.ID, Thumbnail: thumbnail, Text: text}, nil
+}
@@ -0,0 +1,105 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+func TestHostedDocumentIndexer_RunOnceSavesIndex(t *testing.T) {
+	repo := &fakeHostedDocumentRepo{batch: []*entity.HostedDocument{{ID: "doc-1", PDF: []byte("%PDF"), IndexAttempts: 1}}}
+	indexer := NewHostedDocumentIndexer(repo, &fakeDocumentIndexer{text: "Invoice 42"}, HostedDocumentIndexerOptions{})
+
+	indexed, err := indexer.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, indexed)
+	require.Len(t, repo.saved, 1)
+	assert.Equal(t, "doc-1", repo.saved[0].DocumentID)
+	assert.Equal(t, []byte("png"), repo.saved[0].Thumbnail)
+	assert.Equal(t, "Invoice 42", repo.saved[0].Text)
+}
+
+func TestHostedDocumentIndexer_RunOnceLeavesFailureForRetry(t *testing.T) {
+	repo := &fakeHostedDocumentRepo{batch: []*entity.HostedDocument{{ID: "doc-1", IndexAttempts: 1}}}
+	indexer := NewHostedDocumentIndexer(repo, &fakeDocumentIndexer{err: errors.New("boom")}, HostedDocumentIndexerOptions{MaxAttempts: 3})
+
+	indexed, err := indexer.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, indexed)
+	assert.Empty(t, repo.saved)
+	assert.Empty(t, repo.failed)
+}
+
+func TestHostedDocumentIndexer_RunOnceFailsAfterMaxAttempts(t *testing.T) {
+	repo := &fakeHostedDocumentRepo{batch: []*entity.HostedDocument{{ID: "doc-1", IndexAttempts: 3}}}
+	indexer := NewHostedDocumentIndexer(repo, &fakeDocumentIndexer{err: errors.New("boom")}, HostedDocumentIndexerOptions{MaxAttempts: 3})
+
+	_, err := indexer.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"doc-1"}, repo.failed)
+	assert.Contains(t, repo.reason, "generating thumbnail: boom")
+}
+
+type fakeDocumentIndexer struct {
+	text string
+	err  error
+}
+
+func (f *fakeDocumentIndexer) Thumbnail(context.Context, []byte) ([]byte, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []byte("png"), nil
+}
+
+func (f *fakeDocumentIndexer) ExtractText(context.Context, []byte) (string, error) {
+	return f.text, nil
+}
+
+type fakeHostedDocumentRepo struct {
+	batch  []*entity.HostedDocument
+	saved  []*entity.HostedDocumentIndex
+	failed []string
+	reason string
+}
+
+func (f *fakeHostedDocumentRepo) Create(context.Context, *entity.HostedDocument) (string, error) {
+	return "", nil
+}
+
+func (f *fakeHostedDocumentRepo) FindByID(context.Context, string) (*entity.HostedDocument, error) {
+	return nil, entity.ErrHostedDocumentNotFound
+}
+
+func (f *fakeHostedDocumentRepo) FindByWorkspace(context.Context, string, string) ([]*entity.HostedDocument, error) {
+	return nil, nil
+}
+
+func (f *fakeHostedDocumentRepo) FindThumbnail(context.Context, string, string) ([]byte, error) {
+	return nil, nil
+}
+
+func (f *fakeHostedDocumentRepo) RecordView(context.Context, string) error { return nil }
+
+func (f *fakeHostedDocumentRepo) ClaimForIndexing(context.Context, int, time.Duration) ([]*entity.HostedDocument, error) {
+	return f.batch, nil
+}
+
+func (f *fakeHostedDocumentRepo) SaveIndex(_ context.Context, index *entity.HostedDocumentIndex) error {
+	f.saved = append(f.saved, index)
+	return nil
+}
+
+func (f *fakeHostedDocumentRepo) MarkIndexFailed(_ context.Context, id, reason string) error {
+	f.failed = append(f.failed, id)
+	f.reason = reason
+	return nil
+}
+
+func (f *fakeHostedDocumentRepo) Delete(context.Context, string) error { return nil }
@@ -0,0 +1,189 @@
+package template
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// NewHostedDocumentService creates a new hosted document service.
+// baseURL prefixes viewer links (public origin and base path); empty yields relative links.
+func NewHostedDocumentService(
+	docRepo port.HostedDocumentRepository,
+	tenantRepo port.TenantRepository,
+	workspaceRepo port.WorkspaceRepository,
+	baseURL string,
+) templateuc.HostedDocumentUseCase {
+	return &HostedDocumentService{
+		docRepo:       docRepo,
+		tenantRepo:    tenantRepo,
+		workspaceRepo: workspaceRepo,
+		baseURL:       baseURL,
+	}
+}
+
+// HostedDocumentService implements hosted document business logic.
+type HostedDocumentService struct {
+	docRepo       port.HostedDocumentRepository
+	tenantRepo    port.TenantRepository
+	workspaceRepo port.WorkspaceRepository
+	baseURL       string
+}
+
+// HostRender stores a rendered PDF in the caller's workspace and returns its viewer link.
+func (s *HostedDocumentService) HostRender(ctx context.Context, cmd templateuc.HostRenderCommand) (*templateuc.HostedDocumentLink, error) {
+	if !cmd.Access.IsValid() {
+		return nil, entity.ErrInvalidHostedAccess
+	}
+	ttl := cmd.TTL
+	if ttl == 0 {
+		ttl = entity.HostedDocumentDefaultTTL
+	}
+	if ttl < time.Hour || ttl > entity.HostedDocumentMaxTTL {
+		return nil, entity.ErrInvalidHostedTTL
+	}
+
+	tenant, err := s.tenantRepo.FindByCode(ctx, cmd.TenantCode)
+	if err != nil {
+		return nil, fmt.Errorf("finding tenant: %w", err)
+	}
+	workspace, err := s.workspaceRepo.FindByCodeAndTenant(ctx, tenant.ID, cmd.WorkspaceCode)
+	if err != nil {
+		return nil, fmt.Errorf("finding workspace: %w", err)
+	}
+
+	doc := entity.NewHostedDocument(workspace.ID, cmd.Result.Filename, cmd.Result.PDF, cmd.Result.PageCount, cmd.Access, cmd.AllowDownload, ttl)
+	doc.ID = uuid.NewString()
+
+	var token string
+	if cmd.Access == entity.HostedAccessToken {
+		var tokenHash string
+		token, tokenHash, err = newShareToken()
+		if err != nil {
+			return nil, err
+		}
+		doc.TokenHash = &tokenHash
+	}
+
+	if err := doc.Validate(); err != nil {
+		return nil, fmt.Errorf("validating hosted document: %w", err)
+	}
+
+	id, err := s.docRepo.Create(ctx, doc)
+	if err != nil {
+		return nil, fmt.Errorf("creating hosted document: %w", err)
+	}
+	doc.ID = id
+
+	slog.InfoContext(ctx, "render hosted",
+		slog.String("hosted_document_id", id),
+		slog.String("workspace_id", workspace.ID),
+		slog.String("access", string(doc.Access)),
+		slog.Time("expires_at", doc.ExpiresAt),
+	)
+
+	return &templateuc.HostedDocumentLink{Document: doc, URL: s.viewerURL(doc, token), Token: token}, nil
+}
+
+// ListHostedDocuments lists the hosted documents of a workspace, without their PDFs.
+func (s *HostedDocumentService) ListHostedDocuments(ctx context.Context, workspaceID, query string) ([]*entity.HostedDocument, error) {
+	docs, err := s.docRepo.FindByWorkspace(ctx, workspaceID, strings.TrimSpace(query))
+	if err != nil {
+		return nil, fmt.Errorf("listing hosted documents: %w", err)
+	}
+	return docs, nil
+}
+
+// GetHostedDocumentThumbnail returns the PNG thumbnail of a workspace document.
+func (s *HostedDocumentService) GetHostedDocumentThumbnail(ctx context.Context, workspaceID, documentID string) ([]byte, error) {
+	thumbnail, err := s.docRepo.FindThumbnail(ctx, workspaceID, documentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(thumbnail) == 0 {
+		return nil, entity.ErrThumbnailNotReady
+	}
+	return thumbnail, nil
+}
+
+// DeleteHostedDocument deletes a hosted document of the workspace.
+func (s *HostedDocumentService) DeleteHostedDocument(ctx context.Context, workspaceID, documentID string) error {
+	doc, err := s.docRepo.FindByID(ctx, documentID)
+	if err != nil {
+		return err
+	}
+	if doc.WorkspaceID != workspaceID {
+		return entity.ErrHostedDocumentNotFound
+	}
+
+	if err := s.docRepo.Delete(ctx, documentID); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "hosted document deleted", slog.String("hosted_document_id", documentID))
+	return nil
+}
+
+// OpenHostedDocument checks access to a hosted document, counts the view and returns it with its PDF.
+func (s *HostedDocumentService) OpenHostedDocument(ctx context.Context, cmd templateuc.OpenHostedDocumentCommand) (*entity.HostedDocument, error) {
+	doc, err := s.docRepo.FindByID(ctx, cmd.DocumentID)
+	if err != nil {
+		return nil, err
+	}
+	if doc.IsExpired() || !canOpenHostedDocument(doc, cmd) {
+		return nil, entity.ErrHostedDocumentNotFound
+	}
+	if cmd.Download && !doc.AllowDownload {
+		return nil, entity.ErrDownloadNotAllowed
+	}
+
+	if err := s.docRepo.RecordView(ctx, doc.ID); err != nil {
+		slog.WarnContext(ctx, "failed to record hosted document view",
+			slog.String("hosted_document_id", doc.ID),
+			slog.Any("error", err),
+		)
+	}
+	return doc, nil
+}
+
+// canOpenHostedDocument reports whether the viewer satisfies the document's access mode.
+// Members of the owning workspace can open every access mode.
+func canOpenHostedDocument(doc *entity.HostedDocument, cmd templateuc.OpenHostedDocumentCommand) bool {
+	if cmd.WorkspaceID != "" {
+		return cmd.WorkspaceID == doc.WorkspaceID
+	}
+
+	switch doc.Access {
+	case entity.HostedAccessPublic:
+		return true
+	case entity.HostedAccessToken:
+		return cmd.Token != "" && doc.TokenHash != nil &&
+			subtle.ConstantTimeCompare([]byte(hashShareToken(cmd.Token)), []byte(*doc.TokenHash)) == 1
+	default:
+		return false
+	}
+}
+
+// viewerURL builds the link to share for a hosted document.
+// MEMBERS documents are opened through the panel API, which requires a workspace session.
+func (s *HostedDocumentService) viewerURL(doc *entity.HostedDocument, token string) string {
+	if doc.Access == entity.HostedAccessMembers {
+		return s.baseURL + "/api/v1/workspace/hosted-documents/" + doc.ID
+	}
+
+	link := s.baseURL + "/api/v1/public/documents/" + doc.ID
+	if token != "" {
+		link += "?token=" + url.QueryEscape(token)
+	}
+	return link
+}
@@ -0,0 +1,125 @@
+package template
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+func TestCanOpenHostedDocument(t *testing.T) {
+	tokenHash := hashShareToken("secret")
+
+	tests := []struct {
+		name   string
+		access entity.HostedDocumentAccess
+		cmd    templateuc.OpenHostedDocumentCommand
+		want   bool
+	}{
+		{"public anonymous", entity.HostedAccessPublic, templateuc.OpenHostedDocumentCommand{}, true},
+		{"token with matching token", entity.HostedAccessToken, templateuc.OpenHostedDocumentCommand{Token: "secret"}, true},
+		{"token with wrong token", entity.HostedAccessToken, templateuc.OpenHostedDocumentCommand{Token: "guess"}, false},
+		{"token without token", entity.HostedAccessToken, templateuc.OpenHostedDocumentCommand{}, false},
+		{"members anonymous", entity.HostedAccessMembers, templateuc.OpenHostedDocumentCommand{}, false},
+		{"members of the workspace", entity.HostedAccessMembers, templateuc.OpenHostedDocumentCommand{WorkspaceID: "ws-1"}, true},
+		{"members of another workspace", entity.HostedAccessMembers, templateuc.OpenHostedDocumentCommand{WorkspaceID: "ws-2"}, false},
+		{"token document opened by a member", entity.HostedAccessToken, templateuc.OpenHostedDocumentCommand{WorkspaceID: "ws-1"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := &entity.HostedDocument{WorkspaceID: "ws-1", Access: tt.access}
+			if tt.access == entity.HostedAccessToken {
+				doc.TokenHash = &tokenHash
+			}
+			assert.Equal(t, tt.want, canOpenHostedDocument(doc, tt.cmd))
+		})
+	}
+}
+
+func TestOpenHostedDocument(t *testing.T) {
+	tokenHash := hashShareToken("secret")
+
+	tests := []struct {
+		name          string
+		access        entity.HostedDocumentAccess
+		expired       bool
+		allowDownload bool
+		cmd           templateuc.OpenHostedDocumentCommand
+		wantErr       error
+	}{
+		{name: "public", access: entity.HostedAccessPublic},
+		{name: "token with matching token", access: entity.HostedAccessToken, cmd: templateuc.OpenHostedDocumentCommand{Token: "secret"}},
+		{name: "token with wrong token", access: entity.HostedAccessToken, cmd: templateuc.OpenHostedDocumentCommand{Token: "guess"}, wantErr: entity.ErrHostedDocumentNotFound},
+		{name: "token without token", access: entity.HostedAccessToken, wantErr: entity.ErrHostedDocumentNotFound},
+		{name: "members of the workspace", access: entity.HostedAccessMembers, cmd: templateuc.OpenHostedDocumentCommand{WorkspaceID: "ws-1"}},
+		{name: "members anonymous", access: entity.HostedAccessMembers, wantErr: entity.ErrHostedDocumentNotFound},
+		{name: "members of another workspace", access: entity.HostedAccessMembers, cmd: templateuc.OpenHostedDocumentCommand{WorkspaceID: "ws-2"}, wantErr: entity.ErrHostedDocumentNotFound},
+		{name: "expired", access: entity.HostedAccessPublic, expired: true, wantErr: entity.ErrHostedDocumentNotFound},
+		{name: "expired opened by a member", access: entity.HostedAccessMembers, expired: true, cmd: templateuc.OpenHostedDocumentCommand{WorkspaceID: "ws-1"}, wantErr: entity.ErrHostedDocumentNotFound},
+		{name: "download allowed", access: entity.HostedAccessPublic, allowDownload: true, cmd: templateuc.OpenHostedDocumentCommand{Download: true}},
+		{name: "download not allowed", access: entity.HostedAccessPublic, cmd: templateuc.OpenHostedDocumentCommand{Download: true}, wantErr: entity.ErrDownloadNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := entity.NewHostedDocument("ws-1", "offer.pdf", []byte("%PDF-1.7"), 1, tt.access, tt.allowDownload, time.Hour)
+			doc.ID = "doc-1"
+			if tt.expired {
+				doc.ExpiresAt = time.Now().Add(-time.Minute)
+			}
+			if tt.access == entity.HostedAccessToken {
+				doc.TokenHash = &tokenHash
+			}
+			repo := &fakeOpenedDocumentRepo{docs: map[string]*entity.HostedDocument{doc.ID: doc}}
+			svc := NewHostedDocumentService(repo, nil, nil, "")
+
+			cmd := tt.cmd
+			cmd.DocumentID = doc.ID
+			got, err := svc.OpenHostedDocument(context.Background(), cmd)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, repo.views, "a refused open must not count a view")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, doc, got)
+			assert.Equal(t, []string{doc.ID}, repo.views)
+		})
+	}
+}
+
+func TestOpenHostedDocument_UnknownDocument(t *testing.T) {
+	repo := &fakeOpenedDocumentRepo{}
+	svc := NewHostedDocumentService(repo, nil, nil, "")
+
+	_, err := svc.OpenHostedDocument(context.Background(), templateuc.OpenHostedDocumentCommand{DocumentID: "missing"})
+
+	require.ErrorIs(t, err, entity.ErrHostedDocumentNotFound)
+	assert.Empty(t, repo.views)
+}
+
+type fakeOpenedDocumentRepo struct {
+	port.HostedDocumentRepository
+	docs  map[string]*entity.HostedDocument
+	views []string
+}
+
+func (f *fakeOpenedDocumentRepo) FindByID(_ context.Context, id string) (*entity.HostedDocument, error) {
+	if doc, ok := f.docs[id]; ok {
+		return doc, nil
+	}
+	return nil, entity.ErrHostedDocumentNotFound
+}
+
+func (f *fakeOpenedDocumentRepo) RecordView(_ context.Context, id string) error {
+	f.views = append(f.views, id)
+	return nil
+}
@@ -0,0 +1,57 @@
+package template
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// HTMLRenderOperation is the InjectorContext operation of an HTML preview render.
+const HTMLRenderOperation = "html"
+
+// RenderHTMLByDocumentType resolves a template like RenderByDocumentType and converts it to HTML.
+func (s *InternalRenderService) RenderHTMLByDocumentType(ctx context.Context, cmd templateuc.InternalRenderCommand) (*port.HTMLRenderResult, error) {
+	version, err := s.resolveVersion(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return s.renderVersionHTML(ctx, version, cmd)
+}
+
+// RenderHTMLByVersionID converts a specific template version to HTML.
+func (s *InternalRenderService) RenderHTMLByVersionID(ctx context.Context, cmd templateuc.RenderByVersionIDCommand) (*port.HTMLRenderResult, error) {
+	version, err := s.versionRepo.FindByIDWithDetails(ctx, cmd.VersionID)
+	if err != nil {
+		return nil, fmt.Errorf("finding version %s: %w", cmd.VersionID, err)
+	}
+	return s.renderVersionHTML(ctx, version, versionRenderCommand(cmd))
+}
+
+// renderVersionHTML converts a version to HTML with the injectables of a render. It is a preview, so
+// it is neither dispatched to subscribers nor recorded in the render statistics, but the render
+// quotas apply to it.
+func (s *InternalRenderService) renderVersionHTML(
+	ctx context.Context,
+	version *entity.TemplateVersionWithDetails,
+	cmd templateuc.InternalRenderCommand,
+) (*port.HTMLRenderResult, error) {
+	if cmd.Imposition != nil {
+		return nil, entity.ErrHTMLRenderOption
+	}
+	if err := s.allowRender(cmd); err != nil {
+		return nil, err
+	}
+	renderReq, resolution, err := s.buildRenderRequest(ctx, version, cmd, newRenderContext(version, cmd, HTMLRenderOperation))
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.pdfRenderer.RenderHTML(ctx, renderReq)
+	if err != nil {
+		return nil, err
+	}
+	result.Warnings = append(resolution.degradations, result.Warnings...)
+	return result, nil
+}
@@ -0,0 +1,328 @@
+// Package htmlimport converts legacy HTML/Handlebars templates into portable documents.
+package htmlimport
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/service/template/templateimport"
+)
+
+// SourceApp identifies imported documents in their export info.
+const SourceApp = "pdf-forge-html-import"
+
+// whitespacePattern matches runs of HTML whitespace, which collapse to a single space.
+var whitespacePattern = regexp.MustCompile(`[ \t\n\r\f]+`)
+
+// headingLevels maps heading tags to their level.
+var headingLevels = map[atom.Atom]int{
+	atom.H1: 1, atom.H2: 2, atom.H3: 3, atom.H4: 4, atom.H5: 5, atom.H6: 6,
+}
+
+// inlineMarks maps formatting tags to the mark they apply.
+var inlineMarks = map[atom.Atom]string{
+	atom.B:      portabledoc.MarkTypeBold,
+	atom.Strong: portabledoc.MarkTypeBold,
+	atom.I:      portabledoc.MarkTypeItalic,
+	atom.Em:     portabledoc.MarkTypeItalic,
+	atom.U:      portabledoc.MarkTypeUnderline,
+	atom.S:      portabledoc.MarkTypeStrike,
+	atom.Strike: portabledoc.MarkTypeStrike,
+	atom.Del:    portabledoc.MarkTypeStrike,
+	atom.Code:   portabledoc.MarkTypeCode,
+	atom.Mark:   portabledoc.MarkTypeHighlight,
+}
+
+// containerTags hold blocks without meaning of their own; their children are converted in place.
+var containerTags = portabledoc.Set[atom.Atom]{
+	atom.Html: {}, atom.Body: {}, atom.Div: {}, atom.Section: {}, atom.Article: {}, atom.Main: {},
+	atom.Header: {}, atom.Footer: {}, atom.Center: {}, atom.Form: {}, atom.Figure: {}, atom.Aside: {}, atom.Nav: {},
+}
+
+// inlineContainerTags are inline tags without a mark; their text is kept.
+var inlineContainerTags = portabledoc.Set[atom.Atom]{
+	atom.Span: {}, atom.Font: {}, atom.Small: {}, atom.Big: {}, atom.Sub: {}, atom.Sup: {}, atom.Label: {}, atom.Abbr: {},
+}
+
+// droppedTags never carry document content.
+var droppedTags = portabledoc.Set[atom.Atom]{
+	atom.Head: {}, atom.Script: {}, atom.Style: {}, atom.Noscript: {}, atom.Template: {}, atom.Meta: {}, atom.Link: {},
+}
+
+// Convert converts an HTML template into a portable document. {{placeholders}} that match an
+// injectable key become injectors; everything that could not be converted faithfully is listed
+// in the report.
+func Convert(source string, injectables []*entity.InjectableDefinition) (*portabledoc.Document, *entity.TemplateImportReport, error) {
+	if err := templateimport.CheckSource(source); err != nil {
+		return nil, nil, err
+	}
+
+	root, err := html.Parse(strings.NewReader(source))
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing HTML: %w", err)
+	}
+
+	c := &converter{Session: templateimport.NewSession(injectables)}
+	content := c.blocks(root)
+
+	doc := c.Document(portabledoc.Meta{Title: c.title}, templateimport.DefaultPageConfig, content, SourceApp)
+	return doc, c.Report(), nil
+}
+
+// converter holds the state of one conversion.
+type converter struct {
+	*templateimport.Session
+	title string
+
+	// pendingImages collects images found inside inline content; they are placed after the paragraph.
+	pendingImages []portabledoc.Node
+}
+
+// blocks converts the children of n into block nodes. Loose inline content is gathered into paragraphs.
+func (c *converter) blocks(n *html.Node) []portabledoc.Node {
+	var out []portabledoc.Node
+	var inline []portabledoc.Node
+
+	flush := func() {
+		if para, ok := paragraph(inline); ok {
+			out = append(out, para)
+		}
+		inline = nil
+		out = append(out, c.pendingImages...)
+		c.pendingImages = nil
+	}
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && c.isBlock(child) {
+			flush()
+			if hasPageBreak(child, "before") {
+				out = append(out, portabledoc.Node{Type: portabledoc.NodeTypePageBreak})
+			}
+			out = append(out, c.block(child)...)
+			if hasPageBreak(child, "after") {
+				out = append(out, portabledoc.Node{Type: portabledoc.NodeTypePageBreak})
+			}
+			continue
+		}
+		inline = append(inline, c.inline(child, nil)...)
+	}
+	flush()
+	return out
+}
+
+// isBlock reports whether an element starts its own block.
+func (c *converter) isBlock(n *html.Node) bool {
+	if _, ok := headingLevels[n.DataAtom]; ok {
+		return true
+	}
+	if containerTags.Contains(n.DataAtom) || droppedTags.Contains(n.DataAtom) {
+		return true
+	}
+	switch n.DataAtom {
+	case atom.P, atom.Ul, atom.Ol, atom.Table, atom.Blockquote, atom.Hr, atom.Img, atom.Pre, atom.Title:
+		return true
+	}
+	return false
+}
+
+// block converts a block element.
+func (c *converter) block(n *html.Node) []portabledoc.Node {
+	if level, ok := headingLevels[n.DataAtom]; ok {
+		return c.textBlock(n, portabledoc.Node{
+			Type:  portabledoc.NodeTypeHeading,
+			Attrs: withAlign(map[string]any{"level": level}, n),
+		})
+	}
+
+	switch {
+	case droppedTags.Contains(n.DataAtom):
+		if n.DataAtom == atom.Head {
+			c.findTitle(n)
+		}
+		return nil
+	case containerTags.Contains(n.DataAtom):
+		return c.blocks(n)
+	}
+
+	switch n.DataAtom {
+	case atom.Title:
+		c.title = strings.TrimSpace(textContent(n))
+		return nil
+	case atom.P:
+		return c.textBlock(n, portabledoc.Node{Type: portabledoc.NodeTypeParagraph, Attrs: withAlign(nil, n)})
+	case atom.Pre:
+		return []portabledoc.Node{{Type: portabledoc.NodeTypeCodeBlock, Content: textNodes(textContent(n))}}
+	case atom.Blockquote:
+		return []portabledoc.Node{{Type: portabledoc.NodeTypeBlockquote, Content: nonEmpty(c.blocks(n))}}
+	case atom.Hr:
+		return []portabledoc.Node{{Type: portabledoc.NodeTypeHR}}
+	case atom.Img:
+		return c.image(n)
+	case atom.Ul:
+		return []portabledoc.Node{c.list(n, portabledoc.NodeTypeBulletList)}
+	case atom.Ol:
+		return []portabledoc.Node{c.list(n, portabledoc.NodeTypeOrderedList)}
+	case atom.Table:
+		return c.table(n)
+	}
+	return nil
+}
+
+// findTitle reads the document title from <head>.
+func (c *converter) findTitle(head *html.Node) {
+	for child := head.FirstChild; child != nil; child = child.NextSibling {
+		if child.DataAtom == atom.Title {
+			c.title = strings.TrimSpace(textContent(child))
+		}
+	}
+}
+
+// textBlock converts an element with inline content into node, followed by any images it held.
+func (c *converter) textBlock(n *html.Node, node portabledoc.Node) []portabledoc.Node {
+	var inline []portabledoc.Node
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		inline = append(inline, c.inline(child, nil)...)
+	}
+	node.Content = trimInline(inline)
+
+	out := []portabledoc.Node{node}
+	if len(node.Content) == 0 && node.Type == portabledoc.NodeTypeParagraph {
+		out = nil
+	}
+	out = append(out, c.pendingImages...)
+	c.pendingImages = nil
+	return out
+}
+
+// inline converts n into inline nodes carrying marks.
+func (c *converter) inline(n *html.Node, marks []portabledoc.Mark) []portabledoc.Node {
+	switch n.Type {
+	case html.TextNode:
+		return c.Text(whitespacePattern.ReplaceAllString(n.Data, " "), marks)
+	case html.ElementNode:
+	default:
+		return nil
+	}
+
+	if mark, ok := inlineMarks[n.DataAtom]; ok {
+		marks = templateimport.AppendMark(marks, portabledoc.Mark{Type: mark})
+	}
+	switch {
+	case n.DataAtom == atom.Br:
+		return []portabledoc.Node{{Type: portabledoc.NodeTypeHardBreak}}
+	case n.DataAtom == atom.Img:
+		c.pendingImages = append(c.pendingImages, c.image(n)...)
+		return nil
+	case n.DataAtom == atom.A:
+		if href := attr(n, "href"); href != "" {
+			marks = templateimport.AppendMark(marks, portabledoc.Mark{Type: portabledoc.MarkTypeLink, Attrs: map[string]any{"href": href}})
+		}
+	case droppedTags.Contains(n.DataAtom):
+		return nil
+	case inlineMarks[n.DataAtom] == "" && !inlineContainerTags.Contains(n.DataAtom) && !c.isBlock(n):
+		// Unknown tags keep their text
+		c.Unsupported(n.Data)
+	}
+
+	var out []portabledoc.Node
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		out = append(out, c.inline(child, marks)...)
+	}
+	return out
+}
+
+// image converts <img> into an image node. Images whose source is a placeholder are dropped:
+// the editor binds images to image injectables instead.
+func (c *converter) image(n *html.Node) []portabledoc.Node {
+	src := attr(n, "src")
+	if src == "" {
+		return nil
+	}
+	if strings.Contains(src, "{{") {
+		c.WarnOnce("image:"+src, fmt.Sprintf(
+			"Image with source %s was removed; bind the image to an image injectable in the editor", src))
+		return nil
+	}
+
+	attrs := map[string]any{"src": src}
+	if alt := attr(n, "alt"); alt != "" {
+		attrs["alt"] = alt
+	}
+	if width, err := strconv.Atoi(strings.TrimSuffix(attr(n, "width"), "px")); err == nil && width > 0 {
+		attrs["width"] = width
+	}
+	if height, err := strconv.Atoi(strings.TrimSuffix(attr(n, "height"), "px")); err == nil && height > 0 {
+		attrs["height"] = height
+	}
+	return []portabledoc.Node{{Type: portabledoc.NodeTypeCustomImage, Attrs: attrs}}
+}
+
+// list converts <ul>/<ol> into a list of list items holding paragraphs and nested lists.
+func (c *converter) list(n *html.Node, listType string) portabledoc.Node {
+	list := portabledoc.Node{Type: listType}
+	if listType == portabledoc.NodeTypeOrderedList {
+		if start, err := strconv.Atoi(attr(n, "start")); err == nil && start > 1 {
+			list.Attrs = map[string]any{"start": start}
+		}
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.DataAtom != atom.Li {
+			continue
+		}
+		list.Content = append(list.Content, portabledoc.Node{
+			Type:    portabledoc.NodeTypeListItem,
+			Content: nonEmpty(c.blocks(child)),
+		})
+	}
+	return list
+}
+
+// table converts <table> into an editable table. The first row with <th> cells becomes the header.
+func (c *converter) table(n *html.Node) []portabledoc.Node {
+	table := portabledoc.Node{Type: portabledoc.NodeTypeTable}
+	for _, tr := range tableRows(n) {
+		row := portabledoc.Node{Type: portabledoc.NodeTypeTableRow}
+		for cell := tr.FirstChild; cell != nil; cell = cell.NextSibling {
+			if cell.DataAtom != atom.Td && cell.DataAtom != atom.Th {
+				continue
+			}
+			cellType := portabledoc.NodeTypeTableCell
+			if cell.DataAtom == atom.Th {
+				cellType = portabledoc.NodeTypeTableHeader
+			}
+			row.Content = append(row.Content, portabledoc.Node{
+				Type:    cellType,
+				Attrs:   map[string]any{"colspan": spanAttr(cell, "colspan"), "rowspan": spanAttr(cell, "rowspan")},
+				Content: nonEmpty(c.blocks(cell)),
+			})
+		}
+		if len(row.Content) > 0 {
+			table.Content = append(table.Content, row)
+		}
+	}
+	if len(table.Content) == 0 {
+		return nil
+	}
+	return []portabledoc.Node{table}
+}
+
+// tableRows returns the rows of a table in order, looking inside thead, tbody and tfoot.
+func tableRows(n *html.Node) []*html.Node {
+	var rows []*html.Node
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		switch child.DataAtom {
+		case atom.Tr:
+			rows = append(rows, child)
+		case atom.Thead, atom.Tbody, atom.Tfoot:
+			rows = append(rows, tableRows(child)...)
+		}
+	}
+	return rows
+}
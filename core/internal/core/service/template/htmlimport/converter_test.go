@@ -0,0 +1,136 @@
+package htmlimport
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+func testInjectables() []*entity.InjectableDefinition {
+	return []*entity.InjectableDefinition{
+		{Key: "customer_name", Label: "Customer name", DataType: entity.InjectableDataTypeText},
+		{Key: "total_amount", Label: "Total", DataType: entity.InjectableDataTypeCurrency},
+		{Key: "line_items", Label: "Items", DataType: entity.InjectableDataTypeTable},
+	}
+}
+
+const legacyInvoice = `<!DOCTYPE html>
+<html>
+<head><title>Invoice</title><style>h1 { color: red; }</style></head>
+<body>
+  <h1 style="text-align: center">Invoice</h1>
+  <p>Dear <strong>{{customer.name}}</strong>,</p>
+  <p>You owe {{ total_amount }} by {{due_date}}.</p>
+  {{#if paid}}<p>Thank you!</p>{{/if}}
+  <table>
+    <thead><tr><th>Item</th><th>Price</th></tr></thead>
+    <tbody><tr><td colspan="2">{{line_items}}</td></tr></tbody>
+  </table>
+  <ul><li>First</li><li>Second<ul><li>Nested</li></ul></li></ul>
+  <div style="page-break-before: always"><marquee>Annex</marquee></div>
+</body>
+</html>`
+
+func TestConvert_LegacyInvoice(t *testing.T) {
+	doc, report, err := Convert(legacyInvoice, testInjectables())
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	if doc.Meta.Title != "Invoice" {
+		t.Errorf("expected title from <title>, got %q", doc.Meta.Title)
+	}
+	if !slices.Equal(doc.VariableIDs, []string{"customer_name", "total_amount"}) {
+		t.Errorf("unexpected variableIds: %v", doc.VariableIDs)
+	}
+
+	var types []string
+	for _, node := range doc.Content.Content {
+		types = append(types, node.Type)
+	}
+	want := []string{
+		portabledoc.NodeTypeHeading, portabledoc.NodeTypeParagraph, portabledoc.NodeTypeParagraph,
+		portabledoc.NodeTypeParagraph, portabledoc.NodeTypeTable, portabledoc.NodeTypeBulletList,
+		portabledoc.NodeTypePageBreak, portabledoc.NodeTypeParagraph,
+	}
+	if !slices.Equal(types, want) {
+		t.Errorf("block types = %v, want %v", types, want)
+	}
+
+	if align := doc.Content.Content[0].Attrs["textAlign"]; align != "center" {
+		t.Errorf("expected centered heading, got %v", align)
+	}
+
+	greeting := doc.Content.Content[1].Content
+	if len(greeting) != 3 || greeting[1].Type != portabledoc.NodeTypeInjector || greeting[1].Attrs["variableId"] != "customer_name" {
+		t.Fatalf("expected an injector for {{customer.name}}, got %+v", greeting)
+	}
+	if greeting[1].Marks[0].Type != portabledoc.MarkTypeBold {
+		t.Errorf("expected the injector to keep the bold mark")
+	}
+
+	if report.MappedPlaceholders["customer.name"] != "customer_name" || report.MappedPlaceholders["total_amount"] != "total_amount" {
+		t.Errorf("unexpected mapped placeholders: %v", report.MappedPlaceholders)
+	}
+	if !slices.Equal(report.UnmappedPlaceholders, []string{"due_date", "line_items"}) {
+		t.Errorf("unexpected unmapped placeholders: %v", report.UnmappedPlaceholders)
+	}
+	if !slices.Equal(report.UnsupportedElements, []string{"marquee"}) {
+		t.Errorf("unexpected unsupported elements: %v", report.UnsupportedElements)
+	}
+	if len(report.Warnings) != 2 {
+		t.Errorf("expected warnings for {{#if}} and the TABLE injectable, got %v", report.Warnings)
+	}
+}
+
+func TestConvert_ProducesValidJSON(t *testing.T) {
+	doc, _, err := Convert(legacyInvoice, testInjectables())
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	parsed, err := portabledoc.Parse(data)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if !strings.Contains(string(data), `"text":"Dear "`) || parsed.Content == nil {
+		t.Errorf("expected collapsed whitespace in text, got %s", data)
+	}
+}
+
+func TestConvert_InlineImageAndBreaks(t *testing.T) {
+	doc, report, err := Convert(`<p>Logo <img src="https://example.com/logo.png" width="120">below<br>line</p><img src="{{logo}}">`, nil)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	if len(doc.Content.Content) != 2 || doc.Content.Content[1].Type != portabledoc.NodeTypeCustomImage {
+		t.Fatalf("expected the paragraph followed by the image, got %+v", doc.Content.Content)
+	}
+	if width := doc.Content.Content[1].Attrs["width"]; width != 120 {
+		t.Errorf("expected image width 120, got %v", width)
+	}
+	if doc.Content.Content[0].Content[2].Type != portabledoc.NodeTypeHardBreak {
+		t.Errorf("expected a hard break, got %+v", doc.Content.Content[0].Content)
+	}
+	if len(report.Warnings) != 1 || !strings.Contains(report.Warnings[0], "{{logo}}") {
+		t.Errorf("expected a warning for the placeholder image, got %v", report.Warnings)
+	}
+}
+
+func TestConvert_Errors(t *testing.T) {
+	if _, _, err := Convert("  ", nil); !errors.Is(err, entity.ErrEmptyTemplateImport) {
+		t.Errorf("expected ErrEmptyTemplateImport, got %v", err)
+	}
+	if _, _, err := Convert(strings.Repeat("a", entity.TemplateImportMaxBytes+1), nil); !errors.Is(err, entity.ErrTemplateImportTooLarge) {
+		t.Errorf("expected ErrTemplateImportTooLarge, got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package htmlimport
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/service/template/templateimport"
+)
+
+// paragraph wraps inline nodes in a paragraph, reporting false when nothing visible is left.
+func paragraph(inline []portabledoc.Node) (portabledoc.Node, bool) {
+	content := trimInline(inline)
+	if len(content) == 0 {
+		return portabledoc.Node{}, false
+	}
+	return portabledoc.Node{Type: portabledoc.NodeTypeParagraph, Content: content}, true
+}
+
+// trimInline trims the whitespace left at the edges of a block by HTML indentation
+// and drops the text nodes it empties.
+func trimInline(nodes []portabledoc.Node) []portabledoc.Node {
+	if len(nodes) > 0 && nodes[0].Text != nil {
+		trimmed := strings.TrimLeft(*nodes[0].Text, " ")
+		nodes[0].Text = &trimmed
+	}
+	if last := len(nodes) - 1; last >= 0 && nodes[last].Text != nil {
+		trimmed := strings.TrimRight(*nodes[last].Text, " ")
+		nodes[last].Text = &trimmed
+	}
+
+	out := nodes[:0]
+	for _, node := range nodes {
+		if node.Text != nil && *node.Text == "" {
+			continue
+		}
+		out = append(out, node)
+	}
+	return out
+}
+
+// nonEmpty returns blocks, or an empty paragraph when there are none: list items and table cells
+// need at least one block.
+func nonEmpty(blocks []portabledoc.Node) []portabledoc.Node {
+	if len(blocks) == 0 {
+		return []portabledoc.Node{{Type: portabledoc.NodeTypeParagraph}}
+	}
+	return blocks
+}
+
+// textNodes returns a single text node, or none for empty text.
+func textNodes(text string) []portabledoc.Node {
+	if text == "" {
+		return nil
+	}
+	return []portabledoc.Node{templateimport.TextNode(text, nil)}
+}
+
+// withAlign adds the textAlign attr from the align attribute or text-align style of n.
+func withAlign(attrs map[string]any, n *html.Node) map[string]any {
+	align := attr(n, "align")
+	if value, ok := styleValue(n, "text-align"); ok {
+		align = value
+	}
+	switch align {
+	case "left", "center", "right", "justify":
+		if attrs == nil {
+			attrs = map[string]any{}
+		}
+		attrs["textAlign"] = align
+	}
+	return attrs
+}
+
+// hasPageBreak reports whether n forces a page break on the given side ("before" or "after"),
+// as wkhtmltopdf templates do with page-break-before: always.
+func hasPageBreak(n *html.Node, side string) bool {
+	if value, ok := styleValue(n, "page-break-"+side); ok && value == "always" {
+		return true
+	}
+	value, ok := styleValue(n, "break-"+side)
+	return ok && value == "page"
+}
+
+// styleValue returns the value of a property in the inline style of n.
+func styleValue(n *html.Node, property string) (string, bool) {
+	for _, declaration := range strings.Split(attr(n, "style"), ";") {
+		name, value, ok := strings.Cut(declaration, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(name), property) {
+			return strings.ToLower(strings.TrimSpace(value)), true
+		}
+	}
+	return "", false
+}
+
+func attr(n *html.Node, name string) string {
+	for _, a := range n.Attr {
+		if a.Key == name {
+			return strings.TrimSpace(a.Val)
+		}
+	}
+	return ""
+}
+
+// spanAttr reads a colspan or rowspan attribute, defaulting to 1.
+func spanAttr(n *html.Node, name string) int {
+	if span, err := strconv.Atoi(attr(n, name)); err == nil && span > 1 {
+		return span
+	}
+	return 1
+}
+
+// textContent returns the concatenated text of n and its descendants.
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var sb strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		sb.WriteString(textContent(child))
+	}
+	return sb.String()
+}
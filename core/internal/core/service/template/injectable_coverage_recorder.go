@@ -0,0 +1,189 @@
+package template
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// InjectableCoverageRecorder counts where the values of the injectables of each template came from
+// in its renders through the render API. Counts are kept in memory and added to per-day counts in
+// the database every interval; days older than the retention are deleted. Several recorders (one
+// per API instance) can add to the same counts.
+type InjectableCoverageRecorder struct {
+	repo      port.InjectableCoverageRepository
+	interval  time.Duration
+	retention time.Duration
+
+	mu      sync.Mutex
+	pending map[string]*entity.InjectableCoverageDay // By template ID, injectable key and day
+
+	stopCh   chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// NewInjectableCoverageRecorder creates a recorder that flushes every interval and keeps retention
+// days of counts. Call Start to begin.
+func NewInjectableCoverageRecorder(repo port.InjectableCoverageRepository, interval time.Duration, retentionDays int) *InjectableCoverageRecorder {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &InjectableCoverageRecorder{
+		repo:      repo,
+		interval:  interval,
+		retention: time.Duration(max(retentionDays, 1)) * 24 * time.Hour,
+		pending:   make(map[string]*entity.InjectableCoverageDay),
+		stopCh:    make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}
+}
+
+// HandleRenderEvent is a port.EventHandler for render.completed. Failed renders are not counted.
+func (r *InjectableCoverageRecorder) HandleRenderEvent(_ context.Context, event entity.DomainEvent) error {
+	e, ok := event.(entity.RenderCompleted)
+	if !ok || len(e.InjectableSources) == 0 {
+		return nil
+	}
+	day := e.CompletedAt.UTC().Truncate(24 * time.Hour)
+	dayKey := day.Format(time.DateOnly)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, source := range e.InjectableSources {
+		pendingKey := e.TemplateID + "|" + key + "|" + dayKey
+		counts, ok := r.pending[pendingKey]
+		if !ok {
+			counts = &entity.InjectableCoverageDay{TemplateID: e.TemplateID, InjectableKey: key, Day: day}
+			r.pending[pendingKey] = counts
+		}
+		counts.Add(source)
+	}
+	return nil
+}
+
+// Start runs the flush loop in the background until Stop is called.
+func (r *InjectableCoverageRecorder) Start() {
+	go r.loop()
+}
+
+// Stop ends the loop and flushes the pending counts.
+func (r *InjectableCoverageRecorder) Stop() {
+	r.stopOnce.Do(func() { close(r.stopCh) })
+	<-r.stopped
+}
+
+func (r *InjectableCoverageRecorder) loop() {
+	defer close(r.stopped)
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stopCh:
+			r.Flush(context.Background())
+			return
+		case <-ticker.C:
+			r.RunOnce(context.Background())
+		}
+	}
+}
+
+// RunOnce flushes the pending counts and deletes the days older than the retention.
+func (r *InjectableCoverageRecorder) RunOnce(ctx context.Context) {
+	r.Flush(ctx)
+
+	before := time.Now().UTC().Add(-r.retention).Truncate(24 * time.Hour)
+	if deleted, err := r.repo.DeleteBefore(ctx, before); err != nil {
+		slog.WarnContext(ctx, "failed to delete injectable coverage days", slog.Any("error", err))
+	} else if deleted > 0 {
+		slog.DebugContext(ctx, "injectable coverage days deleted", slog.Int64("count", deleted))
+	}
+}
+
+// Flush adds the pending counts to the per-day counts. On failure they are kept for the next flush.
+func (r *InjectableCoverageRecorder) Flush(ctx context.Context) {
+	r.mu.Lock()
+	if len(r.pending) == 0 {
+		r.mu.Unlock()
+		return
+	}
+	batch := r.pending
+	r.pending = make(map[string]*entity.InjectableCoverageDay, len(batch))
+	r.mu.Unlock()
+
+	days := make([]*entity.InjectableCoverageDay, 0, len(batch))
+	for _, counts := range batch {
+		days = append(days, counts)
+	}
+	if err := r.repo.AddDays(ctx, days); err != nil {
+		slog.WarnContext(ctx, "failed to flush injectable coverage, retrying on the next flush", slog.Any("error", err))
+		r.restore(batch)
+	}
+}
+
+// restore merges counts that failed to flush back into the pending ones.
+func (r *InjectableCoverageRecorder) restore(batch map[string]*entity.InjectableCoverageDay) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, counts := range batch {
+		if pending, ok := r.pending[key]; ok {
+			pending.Renders += counts.Renders
+			pending.FromPayload += counts.FromPayload
+			pending.FromDefault += counts.FromDefault
+			pending.Empty += counts.Empty
+			continue
+		}
+		r.pending[key] = counts
+	}
+}
+
+// injectableSources returns where the value of each injectable of a version came from in a render,
+// given the values passed to the renderer and the defaults of the version.
+func injectableSources(versionInjectables []*entity.VersionInjectableWithDefinition, values map[string]any, defaults map[string]string) map[string]entity.InjectableSource {
+	sources := make(map[string]entity.InjectableSource, len(versionInjectables))
+	for _, injectable := range versionInjectables {
+		var key string
+		if injectable.Definition != nil {
+			key = injectable.Definition.Key
+		} else if injectable.SystemInjectableKey != nil {
+			key = *injectable.SystemInjectableKey
+		}
+		if key == "" {
+			continue
+		}
+
+		switch {
+		case !isEmptyInjectableValue(values[key]):
+			sources[key] = entity.InjectableSourcePayload
+		case defaults[key] != "":
+			sources[key] = entity.InjectableSourceDefault
+		default:
+			sources[key] = entity.InjectableSourceEmpty
+		}
+	}
+	return sources
+}
+
+// isEmptyInjectableValue reports whether a value renders as nothing: absent, null, blank text or
+// an empty list or object.
+func isEmptyInjectableValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+func TestInjectableCoverageRecorder_CountsPerDay(t *testing.T) {
+	repo := &fakeInjectableCoverageRepo{}
+	recorder := NewInjectableCoverageRecorder(repo, 0, 30)
+	ctx := context.Background()
+	at := time.Date(2026, 3, 1, 23, 30, 0, 0, time.UTC)
+	sources := map[string]entity.InjectableSource{
+		"client_name": entity.InjectableSourcePayload,
+		"branch":      entity.InjectableSourceDefault,
+	}
+
+	require.NoError(t, recorder.HandleRenderEvent(ctx, entity.RenderCompleted{TemplateID: "t-1", CompletedAt: at, InjectableSources: sources}))
+	require.NoError(t, recorder.HandleRenderEvent(ctx, entity.RenderCompleted{TemplateID: "t-1", CompletedAt: at.Add(time.Hour), InjectableSources: map[string]entity.InjectableSource{
+		"branch": entity.InjectableSourceEmpty,
+	}}))
+	require.NoError(t, recorder.HandleRenderEvent(ctx, entity.RenderFailed{TemplateID: "t-1", FailedAt: at}))
+	recorder.RunOnce(ctx)
+
+	require.Len(t, repo.added, 3)
+	byKey := make(map[string]entity.InjectableCoverageDay)
+	for _, day := range repo.added {
+		byKey[day.InjectableKey+"|"+day.Day.Format(time.DateOnly)] = *day
+	}
+	assert.Equal(t, entity.InjectableCoverageDay{
+		TemplateID: "t-1", InjectableKey: "branch", Day: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), Renders: 1, FromDefault: 1,
+	}, byKey["branch|2026-03-01"])
+	assert.Equal(t, 1, byKey["branch|2026-03-02"].Empty)
+	assert.Equal(t, 1, byKey["client_name|2026-03-01"].FromPayload)
+	assert.Equal(t, 1, repo.deletes)
+}
+
+func TestInjectableCoverageRecorder_FlushKeepsCountsOnFailure(t *testing.T) {
+	repo := &fakeInjectableCoverageRepo{addErr: errors.New("connection refused")}
+	recorder := NewInjectableCoverageRecorder(repo, 0, 30)
+	ctx := context.Background()
+	event := entity.RenderCompleted{TemplateID: "t-1", CompletedAt: time.Now(), InjectableSources: map[string]entity.InjectableSource{
+		"branch": entity.InjectableSourceDefault,
+	}}
+
+	require.NoError(t, recorder.HandleRenderEvent(ctx, event))
+	recorder.Flush(ctx)
+	assert.Empty(t, repo.added)
+
+	repo.addErr = nil
+	require.NoError(t, recorder.HandleRenderEvent(ctx, event))
+	recorder.Flush(ctx)
+	require.Len(t, repo.added, 1)
+	assert.Equal(t, 2, repo.added[0].Renders)
+	assert.Equal(t, 2, repo.added[0].FromDefault)
+}
+
+func TestInjectableSources(t *testing.T) {
+	systemKey := "current_date"
+	injectables := []*entity.VersionInjectableWithDefinition{
+		{Definition: &entity.InjectableDefinition{Key: "client_name"}},
+		{Definition: &entity.InjectableDefinition{Key: "branch"}},
+		{Definition: &entity.InjectableDefinition{Key: "items"}},
+		{Definition: &entity.InjectableDefinition{Key: "notes"}},
+		{TemplateVersionInjectable: entity.TemplateVersionInjectable{SystemInjectableKey: &systemKey}},
+	}
+	values := map[string]any{
+		"client_name":  "Acme",
+		"branch":       "  ",
+		"items":        []any{},
+		"current_date": "2026-03-01",
+	}
+	defaults := map[string]string{"branch": "Main office"}
+
+	assert.Equal(t, map[string]entity.InjectableSource{
+		"client_name":  entity.InjectableSourcePayload,
+		"branch":       entity.InjectableSourceDefault,
+		"items":        entity.InjectableSourceEmpty,
+		"notes":        entity.InjectableSourceEmpty,
+		"current_date": entity.InjectableSourcePayload,
+	}, injectableSources(injectables, values, defaults))
+}
+
+func TestInjectableCoverage_Classify(t *testing.T) {
+	cases := []struct {
+		coverage entity.InjectableCoverage
+		want     entity.InjectableCoverageStatus
+	}{
+		{entity.InjectableCoverage{Renders: 5, FromDefault: 5}, entity.InjectableCoverageNotEnoughRenders},
+		{entity.InjectableCoverage{Renders: 10, FromDefault: 10}, entity.InjectableCoverageAlwaysDefault},
+		{entity.InjectableCoverage{Renders: 10, Empty: 10}, entity.InjectableCoverageAlwaysEmpty},
+		{entity.InjectableCoverage{Renders: 10, FromDefault: 4, Empty: 6}, entity.InjectableCoverageOK},
+		{entity.InjectableCoverage{Renders: 10, FromPayload: 1, FromDefault: 9}, entity.InjectableCoverageOK},
+	}
+	for _, tc := range cases {
+		tc.coverage.Classify(10)
+		assert.Equal(t, tc.want, tc.coverage.Status, "%+v", tc.coverage)
+	}
+}
+
+type fakeInjectableCoverageRepo struct {
+	added   []*entity.InjectableCoverageDay
+	addErr  error
+	deletes int
+}
+
+func (f *fakeInjectableCoverageRepo) AddDays(_ context.Context, days []*entity.InjectableCoverageDay) error {
+	if f.addErr != nil {
+		return f.addErr
+	}
+	f.added = append(f.added, days...)
+	return nil
+}
+
+func (f *fakeInjectableCoverageRepo) FindByTemplate(context.Context, string, time.Time) ([]*entity.InjectableCoverage, error) {
+	return nil, nil
+}
+
+func (f *fakeInjectableCoverageRepo) DeleteBefore(context.Context, time.Time) (int64, error) {
+	f.deletes++
+	return 0, nil
+}
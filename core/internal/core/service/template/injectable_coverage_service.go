@@ -0,0 +1,77 @@
+package template
+
+import (
+	"context"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// NewInjectableCoverageService creates a new injectable coverage service. Windows can reach back
+// retentionDays, the days of counts the recorder keeps.
+func NewInjectableCoverageService(
+	templateRepo port.TemplateRepository,
+	coverageRepo port.InjectableCoverageRepository,
+	retentionDays int,
+) templateuc.InjectableCoverageUseCase {
+	return &InjectableCoverageService{
+		templateRepo:  templateRepo,
+		coverageRepo:  coverageRepo,
+		retentionDays: max(retentionDays, 1),
+	}
+}
+
+// InjectableCoverageService implements the injectable coverage of templates.
+type InjectableCoverageService struct {
+	templateRepo  port.TemplateRepository
+	coverageRepo  port.InjectableCoverageRepository
+	retentionDays int
+}
+
+// GetCoverage returns the injectable coverage of a template of the workspace over the last days.
+func (s *InjectableCoverageService) GetCoverage(ctx context.Context, workspaceID, templateID string, days, minRenders int) (*entity.TemplateInjectableCoverage, error) {
+	if days == 0 {
+		days = min(entity.InjectableCoverageDefaultDays, s.retentionDays)
+	}
+	if minRenders == 0 {
+		minRenders = entity.InjectableCoverageDefaultMinRenders
+	}
+	if days < 1 || days > s.retentionDays || minRenders < 1 || minRenders > entity.InjectableCoverageMaxMinRenders {
+		return nil, entity.ErrInvalidInjectableCoverage
+	}
+
+	template, err := s.templateRepo.FindByID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template.WorkspaceID != workspaceID {
+		return nil, entity.ErrTemplateNotFound
+	}
+
+	since := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1-days)
+	injectables, err := s.coverageRepo.FindByTemplate(ctx, templateID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	coverage := &entity.TemplateInjectableCoverage{
+		TemplateID:    templateID,
+		Days:          days,
+		MinRenders:    minRenders,
+		Injectables:   injectables,
+		AlwaysDefault: []string{},
+		AlwaysEmpty:   []string{},
+	}
+	for _, injectable := range injectables {
+		injectable.Classify(minRenders)
+		switch injectable.Status {
+		case entity.InjectableCoverageAlwaysDefault:
+			coverage.AlwaysDefault = append(coverage.AlwaysDefault, injectable.InjectableKey)
+		case entity.InjectableCoverageAlwaysEmpty:
+			coverage.AlwaysEmpty = append(coverage.AlwaysEmpty, injectable.InjectableKey)
+		}
+	}
+	return coverage, nil
+}
@@ -2,15 +2,23 @@ package template
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
 	"github.com/rendis/pdf-forge/core/internal/core/port"
 	injectablesvc "github.com/rendis/pdf-forge/core/internal/core/service/injectable"
+	cataloguc "github.com/rendis/pdf-forge/core/internal/core/usecase/catalog"
+	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
 	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
 )
 
@@ -19,6 +27,7 @@ func NewInternalRenderService(
 	tenantRepo port.TenantRepository,
 	workspaceRepo port.WorkspaceRepository,
 	docTypeRepo port.DocumentTypeRepository,
+	contracts port.DocumentTypeContractRepository,
 	templateRepo port.TemplateRepository,
 	versionRepo port.TemplateVersionRepository,
 	pdfRenderer port.PDFRenderer,
@@ -26,11 +35,24 @@ func NewInternalRenderService(
 	templateCache *TemplateCache,
 	customResolver port.TemplateResolver,
 	storageProvider port.StorageProvider,
+	assets cataloguc.AssetUseCase,
+	outboxRepo port.OutboxRepository,
+	recorder port.RenderRecorder,
+	failures port.RenderFailureCapturer,
+	estimation RenderEstimationOptions,
+	hooks RenderHooks,
+	settings organizationuc.WorkspaceSettingsUseCase,
+	quotas port.RenderQuotaEnforcer,
+	fonts cataloguc.WorkspaceFontUseCase,
+	currency *injectablesvc.CurrencyRateService,
+	currencyRecords port.RenderCurrencyRateRepository,
+	sequences templateuc.NumberingSequenceUseCase,
 ) templateuc.InternalRenderUseCase {
 	return &InternalRenderService{
 		tenantRepo:      tenantRepo,
 		workspaceRepo:   workspaceRepo,
 		docTypeRepo:     docTypeRepo,
+		contracts:       contracts,
 		templateRepo:    templateRepo,
 		versionRepo:     versionRepo,
 		pdfRenderer:     pdfRenderer,
@@ -38,6 +60,18 @@ func NewInternalRenderService(
 		templateCache:   templateCache,
 		customResolver:  customResolver,
 		storageProvider: storageProvider,
+		assets:          assets,
+		outboxRepo:      outboxRepo,
+		recorder:        recorder,
+		failures:        failures,
+		estimation:      estimation,
+		hooks:           hooks,
+		settings:        settings,
+		quotas:          quotas,
+		fonts:           fonts,
+		currency:        currency,
+		currencyRecords: currencyRecords,
+		sequences:       sequences,
 		defaultResolver: NewDefaultTemplateResolver(),
 		searchAdapter: NewTemplateVersionSearchAdapter(
 			tenantRepo,
@@ -54,6 +88,7 @@ type InternalRenderService struct {
 	tenantRepo      templateResolverTenantRepository
 	workspaceRepo   templateResolverWorkspaceRepository
 	docTypeRepo     templateResolverDocumentTypeRepository
+	contracts       port.DocumentTypeContractRepository
 	templateRepo    templateResolverTemplateRepository
 	versionRepo     templateResolverTemplateVersionRepository
 	pdfRenderer     port.PDFRenderer
@@ -61,12 +96,39 @@ type InternalRenderService struct {
 	templateCache   templateResolutionCache
 	customResolver  port.TemplateResolver
 	storageProvider port.StorageProvider
+	assets          cataloguc.AssetUseCase
 	defaultResolver port.TemplateResolver
 	searchAdapter   port.TemplateVersionSearchAdapter
+	outboxRepo      port.OutboxRepository
+	recorder        port.RenderRecorder
+	failures        port.RenderFailureCapturer
+	estimation      RenderEstimationOptions
+	hooks           RenderHooks
+	settings        organizationuc.WorkspaceSettingsUseCase
+	quotas          port.RenderQuotaEnforcer // nil when render quotas are disabled
+	fonts           cataloguc.WorkspaceFontUseCase
+	currency        *injectablesvc.CurrencyRateService // nil when currency conversion is disabled
+	currencyRecords port.RenderCurrencyRateRepository
+	sequences       templateuc.NumberingSequenceUseCase
 }
 
 // RenderByDocumentType resolves a template using the fallback chain and renders a PDF.
 func (s *InternalRenderService) RenderByDocumentType(ctx context.Context, cmd templateuc.InternalRenderCommand) (*port.RenderPreviewResult, error) {
+	version, err := s.resolveVersion(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return s.renderVersion(ctx, version, cmd)
+}
+
+// resolveVersion resolves the template version of a document type render: the custom resolver
+// first, then the template cache and the fallback chain. The payload is checked against the
+// contract of the document type before anything is resolved.
+func (s *InternalRenderService) resolveVersion(ctx context.Context, cmd templateuc.InternalRenderCommand) (*entity.TemplateVersionWithDetails, error) {
+	if err := s.checkPayloadContract(ctx, cmd); err != nil {
+		return nil, err
+	}
+
 	// Custom resolver is always evaluated first. If it resolves, bypass cache.
 	if s.customResolver != nil {
 		customVersion, err := s.resolveWithCustomResolver(ctx, cmd)
@@ -80,7 +142,7 @@ func (s *InternalRenderService) RenderByDocumentType(ctx context.Context, cmd te
 				slog.String("template_type_code", cmd.TemplateTypeCode),
 				slog.String("version_id", customVersion.ID),
 			)
-			return s.renderVersion(ctx, customVersion, cmd)
+			return customVersion, nil
 		}
 	}
 
@@ -92,7 +154,7 @@ func (s *InternalRenderService) RenderByDocumentType(ctx context.Context, cmd te
 				slog.String("workspace_code", cmd.WorkspaceCode),
 				slog.String("template_type_code", cmd.TemplateTypeCode),
 			)
-			return s.renderVersion(ctx, cached, cmd)
+			return cached, nil
 		}
 	}
 
@@ -107,7 +169,23 @@ func (s *InternalRenderService) RenderByDocumentType(ctx context.Context, cmd te
 		s.templateCache.Set(cmd.TenantCode, cmd.WorkspaceCode, cmd.TemplateTypeCode, version)
 	}
 
-	return s.renderVersion(ctx, version, cmd)
+	return version, nil
+}
+
+// checkPayloadContract validates the injectables of a document type render against the payload
+// contract of the type. Types without a contract accept any payload.
+func (s *InternalRenderService) checkPayloadContract(ctx context.Context, cmd templateuc.InternalRenderCommand) error {
+	if s.contracts == nil {
+		return nil
+	}
+	contract, err := s.contracts.FindByDocumentTypeCode(ctx, cmd.TenantCode, cmd.TemplateTypeCode)
+	if errors.Is(err, entity.ErrPayloadContractNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("finding payload contract of %s: %w", cmd.TemplateTypeCode, err)
+	}
+	return contract.ValidatePayload(cmd.Injectables)
 }
 
 // RenderByVersionID renders a specific template version by ID, bypassing document type resolution.
@@ -117,31 +195,38 @@ func (s *InternalRenderService) RenderByVersionID(ctx context.Context, cmd templ
 		return nil, fmt.Errorf("finding version %s: %w", cmd.VersionID, err)
 	}
 
-	return s.renderVersion(ctx, version, templateuc.InternalRenderCommand{
+	return s.renderVersion(ctx, version, versionRenderCommand(cmd))
+}
+
+// versionRenderCommand converts a render by version ID to the command of the render pipeline.
+func versionRenderCommand(cmd templateuc.RenderByVersionIDCommand) templateuc.InternalRenderCommand {
+	return templateuc.InternalRenderCommand{
 		TenantCode:    cmd.TenantCode,
 		WorkspaceCode: cmd.WorkspaceCode,
 		Injectables:   cmd.Injectables,
 		Headers:       cmd.Headers,
 		Payload:       cmd.Payload,
 		Environment:   cmd.Environment,
-	})
+		Imposition:    cmd.Imposition,
+		Layout:        cmd.Layout,
+		Overlays:      cmd.Overlays,
+		DocumentID:    cmd.DocumentID,
+		Degraded:      cmd.Degraded,
+		UnitSystem:    cmd.UnitSystem,
+		JobID:         cmd.JobID,
+	}
 }
 
 func (s *InternalRenderService) resolveWithCustomResolver(
 	ctx context.Context,
 	cmd templateuc.InternalRenderCommand,
 ) (*entity.TemplateVersionWithDetails, error) {
-	rawBody, err := json.Marshal(cmd.Payload)
-	if err != nil {
-		rawBody = nil
-	}
-
 	versionID, err := s.customResolver.Resolve(ctx, &port.TemplateResolverRequest{
 		TenantCode:    cmd.TenantCode,
 		WorkspaceCode: cmd.WorkspaceCode,
 		DocumentType:  cmd.TemplateTypeCode,
 		Headers:       cmd.Headers,
-		RawBody:       rawBody,
+		Payload:       cmd.Payload,
 		Injectables:   cmd.Injectables,
 		Environment:   cmd.Environment,
 	}, s.searchAdapter)
@@ -249,27 +334,388 @@ func (s *InternalRenderService) validateVersionOwnership(
 	return nil
 }
 
-// renderVersion parses the content structure and renders a PDF.
+// assetURLResolver resolves asset:// URLs, and remote URLs imported into the library, against the
+// library of the template's workspace, which may differ from the requested one when the template
+// was resolved from a fallback workspace. The workspace is looked up on the first asset or remote
+// URL, so renders without images do not pay for it.
+func (s *InternalRenderService) assetURLResolver(ctx context.Context, templateID string, next func(context.Context, string) (string, error)) func(context.Context, string) (string, error) {
+	if s.assets == nil {
+		return next
+	}
+
+	workspaceID := sync.OnceValues(func() (string, error) {
+		tmpl, err := s.templateRepo.FindByID(ctx, templateID)
+		if err != nil {
+			return "", fmt.Errorf("finding template %s: %w", templateID, err)
+		}
+		return tmpl.WorkspaceID, nil
+	})
+	return func(ctx context.Context, url string) (string, error) {
+		isAsset := strings.HasPrefix(url, entity.AssetURLScheme)
+		isRemote := strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://")
+		if !isAsset && !isRemote {
+			if next == nil {
+				return url, nil
+			}
+			return next(ctx, url)
+		}
+		wsID, err := workspaceID()
+		if err != nil {
+			return "", err
+		}
+		return s.assets.URLResolver(wsID, next)(ctx, url)
+	}
+}
+
+// unknownNodeMode returns how the workspace of a template renders the unknown nodes of a document.
+// Nothing is looked up for documents without unknown nodes.
+func (s *InternalRenderService) unknownNodeMode(ctx context.Context, templateID string, doc *portabledoc.Document) entity.UnknownNodeMode {
+	if s.settings == nil || len(doc.UnknownNodes()) == 0 {
+		return entity.UnknownNodesFlatten
+	}
+	tmpl, err := s.templateRepo.FindByID(ctx, templateID)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to find template workspace, flattening unknown nodes",
+			slog.String("template_id", templateID),
+			slog.Any("error", err),
+		)
+		return entity.UnknownNodesFlatten
+	}
+	return WorkspaceUnknownNodeMode(ctx, s.settings, tmpl.WorkspaceID)
+}
+
+// workspaceFontDirs returns the directory of the fonts uploaded to the workspace of a template, or
+// nil when it has none. When the fonts cannot be loaded the render goes on with the installed
+// fonts, and Typst warns about the families it does not find.
+func (s *InternalRenderService) workspaceFontDirs(ctx context.Context, templateID string) []string {
+	if s.fonts == nil {
+		return nil
+	}
+	tmpl, err := s.templateRepo.FindByID(ctx, templateID)
+	if err == nil {
+		var dir string
+		if dir, err = s.fonts.FontDir(ctx, tmpl.WorkspaceID); err == nil && dir != "" {
+			return []string{dir}
+		}
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "failed to load workspace fonts, rendering with the installed fonts",
+			slog.String("template_id", templateID),
+			slog.Any("error", err),
+		)
+	}
+	return nil
+}
+
+// renderVersion renders a PDF, runs the post-render hooks on it and reports it to subscribers
+// and the render statistics. A render rejected by the quotas of its tenant or workspace is
+// neither reported nor counted.
 func (s *InternalRenderService) renderVersion(ctx context.Context, version *entity.TemplateVersionWithDetails, cmd templateuc.InternalRenderCommand) (*port.RenderPreviewResult, error) {
+	if err := s.allowRender(cmd); err != nil {
+		return nil, err
+	}
+	render := newRenderContext(version, cmd, RenderOperation)
+	result, duration, err := s.compileNumbered(ctx, version, cmd, render)
+	if s.recorder != nil {
+		var pageCount int
+		if result != nil {
+			pageCount = result.PageCount
+		}
+		s.recorder.RecordRender(pageCount, duration, err)
+	}
+	if err != nil {
+		s.captureCompileFailure(ctx, version, cmd, err)
+		s.emitRenderFailed(ctx, version, cmd, err)
+		return nil, err
+	}
+
+	if s.quotas != nil {
+		s.quotas.RecordPages(cmd.TenantCode, cmd.WorkspaceCode, result.PageCount)
+	}
+	s.estimation.Stats.Record(version.ID, result.PageCount, duration)
+	s.emitRenderCompleted(ctx, version, cmd, result, duration)
+	return result, nil
+}
+
+// compileNumbered compiles a render, runs the post-render hooks on it and records the exchange
+// rates it converted amounts with. A render of a document type with a numbering sequence draws the
+// next number of the sequence first and renders it in the injectable of the sequence, in place of
+// any value given for it.
+func (s *InternalRenderService) compileNumbered(
+	ctx context.Context,
+	version *entity.TemplateVersionWithDetails,
+	cmd templateuc.InternalRenderCommand,
+	render *port.RenderContext,
+) (*port.RenderPreviewResult, time.Duration, error) {
+	compile := func() (*port.RenderPreviewResult, time.Duration, error) {
+		result, duration, err := s.compileVersion(ctx, version, cmd, render)
+		if err == nil {
+			err = s.hooks.postRender(ctx, render, result)
+		}
+		if err == nil {
+			err = s.recordCurrencyRates(ctx, version, cmd, render, result)
+		}
+		if err != nil {
+			result = nil
+		}
+		return result, duration, err
+	}
+
+	seq, err := s.numberingSequence(ctx, version, cmd)
+	if err != nil {
+		return nil, 0, err
+	}
+	if seq == nil {
+		return compile()
+	}
+
+	var result *port.RenderPreviewResult
+	var duration time.Duration
+	issue := templateuc.IssueNumberCommand{
+		Sequence:   seq,
+		VersionID:  version.ID,
+		TemplateID: version.TemplateID,
+		DocumentID: cmd.DocumentID,
+	}
+	err = s.sequences.Issue(ctx, issue, func(number *entity.SequenceNumber) error {
+		render.DocumentNumber = number.Formatted
+		render.Injectables[seq.InjectableKey] = number.Formatted
+		var err error
+		result, duration, err = compile()
+		return err
+	})
+	if err != nil {
+		return nil, duration, err
+	}
+	result.DocumentNumber = render.DocumentNumber
+	return result, duration, nil
+}
+
+// recordCurrencyRates stores the exchange rates a render converted amounts with before the render
+// is returned, so every document with converted amounts can be traced to its rates. A render whose
+// rates cannot be stored fails, and a numbered render gives its number back.
+func (s *InternalRenderService) recordCurrencyRates(
+	ctx context.Context,
+	version *entity.TemplateVersionWithDetails,
+	cmd templateuc.InternalRenderCommand,
+	render *port.RenderContext,
+	result *port.RenderPreviewResult,
+) error {
+	if s.currencyRecords == nil || len(result.CurrencyRates) == 0 {
+		return nil
+	}
+
+	record := &entity.RenderCurrencyRates{
+		ID:            uuid.NewString(),
+		TenantCode:    cmd.TenantCode,
+		WorkspaceCode: cmd.WorkspaceCode,
+		VersionID:     version.ID,
+		TemplateID:    version.TemplateID,
+		Environment:   cmd.Environment,
+		Rates:         result.CurrencyRates,
+		RenderedAt:    time.Now().UTC(),
+	}
+	if cmd.TemplateTypeCode != "" {
+		record.DocumentTypeCode = &cmd.TemplateTypeCode
+	}
+	if render.DocumentNumber != "" {
+		record.DocumentNumber = &render.DocumentNumber
+	}
+	if cmd.JobID != "" {
+		record.JobID = &cmd.JobID
+	}
+	if err := s.currencyRecords.Create(ctx, record); err != nil {
+		return fmt.Errorf("recording currency rates: %w", err)
+	}
+	result.CurrencyRecordID = record.ID
+	return nil
+}
+
+// numberingSequence returns the active numbering sequence of the document type of a render, or
+// nil when the render draws no number: renders in the dev environment, renders of templates
+// without a document type and renders of versions without the injectable of the sequence.
+func (s *InternalRenderService) numberingSequence(
+	ctx context.Context,
+	version *entity.TemplateVersionWithDetails,
+	cmd templateuc.InternalRenderCommand,
+) (*entity.NumberingSequence, error) {
+	if s.sequences == nil || cmd.Environment.IsDev() {
+		return nil, nil
+	}
+	tmpl, err := s.templateRepo.FindByID(ctx, version.TemplateID)
+	if err != nil {
+		return nil, fmt.Errorf("finding template %s: %w", version.TemplateID, err)
+	}
+	if tmpl.DocumentTypeID == nil {
+		return nil, nil
+	}
+	seq, err := s.sequences.SequenceFor(ctx, tmpl.WorkspaceID, *tmpl.DocumentTypeID)
+	if err != nil {
+		return nil, fmt.Errorf("finding numbering sequence: %w", err)
+	}
+	if seq == nil {
+		return nil, nil
+	}
+	for _, inj := range version.Injectables {
+		if inj.Definition != nil && inj.Definition.Key == seq.InjectableKey {
+			return seq, nil
+		}
+	}
+	return nil, nil
+}
+
+// allowRender checks a render against the quotas of its tenant and workspace.
+func (s *InternalRenderService) allowRender(cmd templateuc.InternalRenderCommand) error {
+	if s.quotas == nil {
+		return nil
+	}
+	return s.quotas.AllowRender(cmd.TenantCode, cmd.WorkspaceCode)
+}
+
+// captureCompileFailure keeps the Typst input of a render Typst rejected and records the capture
+// ID on the error.
+func (s *InternalRenderService) captureCompileFailure(
+	ctx context.Context,
+	version *entity.TemplateVersionWithDetails,
+	cmd templateuc.InternalRenderCommand,
+	renderErr error,
+) {
+	var compileErr *entity.CompileError
+	if s.failures == nil || !errors.As(renderErr, &compileErr) || errors.Is(renderErr, entity.ErrRenderPostProcessing) {
+		// Imposition compiles a source of its own, which is not the template's
+		return
+	}
+
+	failure := &entity.RenderFailure{
+		TenantCode:    cmd.TenantCode,
+		WorkspaceCode: cmd.WorkspaceCode,
+		VersionID:     version.ID,
+		TemplateID:    version.TemplateID,
+		Environment:   cmd.Environment,
+		Error:         renderErr.Error(),
+		Stderr:        compileErr.Stderr,
+		Source:        compileErr.Source,
+		Assets:        compileErr.Assets,
+	}
+	if cmd.TemplateTypeCode != "" {
+		failure.DocumentTypeCode = &cmd.TemplateTypeCode
+	}
+	compileErr.FailureID = s.failures.CaptureRenderFailure(ctx, failure)
+	if compileErr.FailureID != "" {
+		slog.WarnContext(ctx, "render compile failed, input captured",
+			slog.String("version_id", version.ID),
+			slog.String("failure_id", compileErr.FailureID),
+		)
+	}
+}
+
+// compileVersion parses the content structure, resolves its injectables for the render and renders
+// a PDF. The returned duration covers the renderer only.
+func (s *InternalRenderService) compileVersion(
+	ctx context.Context,
+	version *entity.TemplateVersionWithDetails,
+	cmd templateuc.InternalRenderCommand,
+	render *port.RenderContext,
+) (*port.RenderPreviewResult, time.Duration, error) {
+	renderReq, resolution, err := s.buildRenderRequest(ctx, version, cmd, render)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	started := time.Now()
+	result, err := s.pdfRenderer.RenderPreview(ctx, renderReq)
+	if err != nil {
+		return nil, 0, err
+	}
+	result.Warnings = append(resolution.degradations, result.Warnings...)
+	result.InjectorTimings = resolution.timings
+	result.InjectableSources = resolution.sources
+	if resolution.currency != nil {
+		result.CurrencyRates = resolution.currency.Rates()
+	}
+	return result, time.Since(started), nil
+}
+
+// injectableResolution reports how the injectables of a render were resolved.
+type injectableResolution struct {
+	// degradations are the injectors that failed in a degraded render, as DEGRADED_INJECTOR
+	// warnings to lead the warnings of the result.
+	degradations []entity.RenderWarning
+	// timings are the timings of the registry injectors, sorted by code.
+	timings []entity.InjectorTiming
+	// sources are where the value of each injectable of the version came from, by key.
+	sources map[string]entity.InjectableSource
+	// currency converts the amounts of the render, nil when currency conversion is disabled.
+	currency *injectablesvc.CurrencyConverter
+}
+
+// buildRenderRequest parses the content structure, runs the pre-render hooks and resolves the
+// injectables of the render into the request passed to the renderer, reporting how the
+// injectables were resolved.
+func (s *InternalRenderService) buildRenderRequest(
+	ctx context.Context,
+	version *entity.TemplateVersionWithDetails,
+	cmd templateuc.InternalRenderCommand,
+	render *port.RenderContext,
+) (*port.RenderPreviewRequest, injectableResolution, error) {
 	doc, err := portabledoc.Parse(version.ContentStructure)
 	if err != nil {
-		return nil, fmt.Errorf("parsing content structure: %w", err)
+		return nil, injectableResolution{}, fmt.Errorf("parsing content structure: %w", err)
 	}
 
 	if doc == nil {
-		return nil, fmt.Errorf("version has no content")
+		return nil, injectableResolution{}, fmt.Errorf("version has no content")
+	}
+	if cmd.Degraded && !doc.Meta.AllowDegradedRender {
+		return nil, injectableResolution{}, entity.ErrDegradedRenderNotAllowed
+	}
+	if cmd.UnitSystem != "" {
+		doc.Meta.UnitSystem = cmd.UnitSystem
+	}
+
+	if err := s.hooks.preRender(ctx, render); err != nil {
+		return nil, injectableResolution{}, err
+	}
+	cmd.Headers, cmd.Payload, cmd.Injectables = render.Headers, render.Payload, render.Injectables
+
+	converter, err := s.currencyConverter(ctx, cmd.Headers)
+	if err != nil {
+		return nil, injectableResolution{}, err
 	}
 
 	// Resolve all injectables (system + custom registry + provider)
-	injectables := s.resolveInjectables(ctx, version.Injectables, cmd.Injectables, cmd.TenantCode, cmd.WorkspaceCode, cmd.Environment, cmd.Headers, cmd.Payload)
+	injectables, resolution, err := s.resolveInjectables(ctx, render.Operation, version.Injectables, cmd, converter)
+	if err != nil {
+		var injectorErr *entity.InjectorError
+		if errors.As(err, &injectorErr) && injectorErr.Code != "" {
+			injectorErr.NodePaths = doc.InjectableNodePaths(injectorErr.Code)
+		}
+		return nil, injectableResolution{}, err
+	}
 
 	// Build injectable defaults
 	defaults := BuildVersionInjectableDefaults(version.Injectables)
+	resolution.sources = injectableSources(version.Injectables, injectables, defaults)
+	resolution.currency = converter
+	if err := missingRequiredInjectables(doc, version.Injectables, resolution.sources); err != nil {
+		return nil, injectableResolution{}, err
+	}
 
 	renderReq := &port.RenderPreviewRequest{
 		Document:           doc,
 		Injectables:        injectables,
 		InjectableDefaults: defaults,
+		Watermark:          render.Watermark,
+		Imposition:         cmd.Imposition,
+		Layout:             cmd.Layout,
+		Overlays:           cmd.Overlays,
+		DocumentID:         cmd.DocumentID,
+		Degraded:           cmd.Degraded,
+		UnknownNodes:       s.unknownNodeMode(ctx, version.TemplateID, doc),
+		FontDirs:           s.workspaceFontDirs(ctx, version.TemplateID),
+	}
+	if converter != nil {
+		renderReq.ConvertCurrency = converter.Convert
 	}
 
 	if s.storageProvider != nil {
@@ -278,44 +724,189 @@ func (s *InternalRenderService) renderVersion(ctx context.Context, version *enti
 			port.NewRenderStorageContext(cmd.TenantCode, cmd.WorkspaceCode),
 		)
 	}
+	renderReq.ImageURLResolver = s.assetURLResolver(ctx, version.TemplateID, renderReq.ImageURLResolver)
+	return renderReq, resolution, nil
+}
+
+// CurrencyRateDateHeader pins the date of the exchange rates a render converts amounts with, as
+// YYYY-MM-DD. Without it renders use the latest rates.
+const CurrencyRateDateHeader = "X-Currency-Rate-Date"
 
-	return s.pdfRenderer.RenderPreview(ctx, renderReq)
+// currencyConverter returns the currency conversion of a render, with the rates of the date in
+// the X-Currency-Rate-Date header or the latest ones, or nil when currency conversion is disabled.
+func (s *InternalRenderService) currencyConverter(ctx context.Context, headers map[string]string) (*injectablesvc.CurrencyConverter, error) {
+	if s.currency == nil {
+		return nil, nil
+	}
+	var date time.Time
+	for key, value := range headers {
+		if !strings.EqualFold(key, CurrencyRateDateHeader) {
+			continue
+		}
+		parsed, err := time.Parse(time.DateOnly, strings.TrimSpace(value))
+		if err != nil || parsed.After(time.Now().UTC()) {
+			return nil, entity.ErrInvalidCurrencyRateDate
+		}
+		date = parsed
+	}
+	return s.currency.Converter(ctx, date), nil
+}
+
+// emitRenderCompleted records RenderCompleted in the outbox; the relay delivers it to subscribers
+// and event webhooks.
+func (s *InternalRenderService) emitRenderCompleted(
+	ctx context.Context,
+	version *entity.TemplateVersionWithDetails,
+	cmd templateuc.InternalRenderCommand,
+	result *port.RenderPreviewResult,
+	duration time.Duration,
+) {
+	if s.outboxRepo == nil {
+		return
+	}
+
+	s.appendRenderEvent(ctx, entity.OutboxEventRenderCompleted, version, entity.RenderCompleted{
+		VersionID:         version.ID,
+		TemplateID:        version.TemplateID,
+		TenantCode:        cmd.TenantCode,
+		WorkspaceCode:     cmd.WorkspaceCode,
+		DocumentType:      cmd.TemplateTypeCode,
+		Environment:       cmd.Environment,
+		PageCount:         result.PageCount,
+		SizeBytes:         len(result.PDF),
+		Duration:          duration,
+		Warnings:          result.Warnings,
+		InjectorTimings:   result.InjectorTimings,
+		InjectableSources: result.InjectableSources,
+		CurrencyRates:     result.CurrencyRates,
+		CurrencyRecordID:  result.CurrencyRecordID,
+		DocumentNumber:    result.DocumentNumber,
+		CompletedAt:       time.Now().UTC(),
+	})
+}
+
+// emitRenderFailed records RenderFailed in the outbox. Renders rejected because the renderer is
+// saturated are not reported: they never started and are retried.
+func (s *InternalRenderService) emitRenderFailed(
+	ctx context.Context,
+	version *entity.TemplateVersionWithDetails,
+	cmd templateuc.InternalRenderCommand,
+	renderErr error,
+) {
+	if s.outboxRepo == nil || errors.Is(renderErr, entity.ErrRendererBusy) {
+		return
+	}
+
+	code, _ := entity.RenderErrorCodeOf(renderErr)
+	s.appendRenderEvent(ctx, entity.OutboxEventRenderFailed, version, entity.RenderFailed{
+		VersionID:     version.ID,
+		TemplateID:    version.TemplateID,
+		TenantCode:    cmd.TenantCode,
+		WorkspaceCode: cmd.WorkspaceCode,
+		DocumentType:  cmd.TemplateTypeCode,
+		Environment:   cmd.Environment,
+		Error:         renderErr.Error(),
+		ErrorCode:     code,
+		Retryable:     code.Retryable(),
+		FailureID:     renderFailureID(renderErr),
+		JobID:         cmd.JobID,
+		FailedAt:      time.Now().UTC(),
+	})
+}
+
+// appendRenderEvent stores a render event under the workspace that owns the rendered template, so
+// the relay can queue it for the event webhooks of that workspace. The render already happened:
+// failures are logged and never fail it.
+func (s *InternalRenderService) appendRenderEvent(
+	ctx context.Context,
+	eventType entity.OutboxEventType,
+	version *entity.TemplateVersionWithDetails,
+	payload entity.DomainEvent,
+) {
+	ctx = context.WithoutCancel(ctx)
+
+	var workspaceID *string
+	if tmpl, err := s.templateRepo.FindByID(ctx, version.TemplateID); err != nil {
+		slog.WarnContext(ctx, "failed to find the workspace of a rendered template",
+			slog.String("template_id", version.TemplateID),
+			slog.Any("error", err),
+		)
+	} else {
+		workspaceID = &tmpl.WorkspaceID
+	}
+
+	event, err := entity.NewOutboxEvent(eventType, version.ID, workspaceID, payload)
+	if err == nil {
+		err = s.outboxRepo.Append(ctx, event)
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "failed to record render event",
+			slog.String("type", string(eventType)),
+			slog.String("version_id", version.ID),
+			slog.Any("error", err),
+		)
+	}
 }
 
 // resolveInjectables resolves all injectable values (system, registry, and provider)
 // and merges them with caller-provided values. Caller-provided values take priority.
+// Computed injectables are evaluated last, from the merged values.
+// A failed critical injector or provider stops the render with an *entity.InjectorError, unless
+// the render is degraded: then every failed injector the caller gave no value for is reported as
+// a DEGRADED_INJECTOR warning.
 func (s *InternalRenderService) resolveInjectables(
 	ctx context.Context,
+	operation string,
 	versionInjectables []*entity.VersionInjectableWithDefinition,
-	callerValues map[string]any,
-	tenantCode, workspaceCode string,
-	env entity.Environment,
-	headers map[string]string,
-	payload any,
-) map[string]any {
-	// Collect all injectable codes (system + workspace/custom)
+	cmd templateuc.InternalRenderCommand,
+	converter *injectablesvc.CurrencyConverter,
+) (map[string]any, injectableResolution, error) {
+	callerValues := cmd.Injectables
+	// Collect all injectable codes (system + workspace/custom). Computed injectables are not
+	// resolved themselves: the codes their formulas reference are, and the formulas run after
 	var codes []string
+	var definitions []*entity.InjectableDefinition
 	for _, inj := range versionInjectables {
 		if inj.SystemInjectableKey != nil && *inj.SystemInjectableKey != "" {
 			codes = append(codes, *inj.SystemInjectableKey)
 		} else if inj.Definition != nil && inj.Definition.Key != "" {
-			codes = append(codes, inj.Definition.Key)
+			definitions = append(definitions, inj.Definition)
+			if !inj.Definition.IsComputed() {
+				codes = append(codes, inj.Definition.Key)
+			}
+		}
+	}
+	computed := s.resolver.PrepareComputed(ctx, definitions)
+	for _, dep := range computed.Dependencies() {
+		if !slices.Contains(codes, dep) {
+			codes = append(codes, dep)
 		}
 	}
 
-	if len(codes) == 0 {
-		return callerValues
+	if len(codes) == 0 && computed.Len() == 0 {
+		return callerValues, injectableResolution{}, nil
 	}
 
 	// Resolve injectables with full context (headers, payload, tenant/workspace codes)
-	injCtx := entity.NewInjectorContextWithCodes("", "", "", "render", tenantCode, workspaceCode, env, headers, payload)
-	result, err := s.resolver.Resolve(ctx, injCtx, codes)
+	injCtx := entity.NewInjectorContextWithCodes("", "", "", operation, cmd.TenantCode, cmd.WorkspaceCode, cmd.Environment, cmd.Headers, cmd.Payload)
+	if converter != nil {
+		injCtx.SetCurrencyConverter(converter.Convert)
+	}
+	resolve := s.resolver.Resolve
+	if cmd.Degraded {
+		resolve = s.resolver.ResolvePartial
+	}
+	result, err := resolve(ctx, injCtx, codes)
+	var injectorErr *entity.InjectorError
+	if errors.As(err, &injectorErr) {
+		return nil, injectableResolution{}, err
+	}
 	if err != nil {
 		slog.WarnContext(ctx, "failed to resolve injectables",
 			slog.Any("error", err),
 			slog.Any("codes", codes),
 		)
-		return callerValues
+		return callerValues, injectableResolution{}, nil
 	}
 
 	// Merge: resolved values as base, caller values override
@@ -326,8 +917,63 @@ func (s *InternalRenderService) resolveInjectables(
 	for key, val := range callerValues {
 		merged[key] = val
 	}
+	if computed.Len() > 0 {
+		computedResult := s.resolver.ResolveComputed(ctx, computed, merged, BuildVersionInjectableDefaults(versionInjectables))
+		for key, val := range computedResult.Values {
+			merged[key] = val.AsAny()
+		}
+		maps.Copy(result.Errors, computedResult.Errors)
+	}
 
-	return merged
+	resolution := injectableResolution{timings: result.Timings}
+	if !cmd.Degraded {
+		return merged, resolution, nil
+	}
+	for _, code := range slices.Sorted(maps.Keys(result.Errors)) {
+		if _, ok := callerValues[code]; ok {
+			continue
+		}
+		resolution.degradations = append(resolution.degradations, entity.RenderWarning{
+			Code:    entity.RenderWarningDegradedInjector,
+			Message: fmt.Sprintf("injector failed; rendered with its default value or empty: %v", result.Errors[code]),
+			Source:  code,
+		})
+	}
+	return merged, resolution, nil
+}
+
+// missingRequiredInjectables returns a *entity.MissingInjectablesError for the required
+// injectables of a version that would render empty, with the nodes that show them, or nil when
+// every required injectable has a value or a default.
+func missingRequiredInjectables(
+	doc *portabledoc.Document,
+	versionInjectables []*entity.VersionInjectableWithDefinition,
+	sources map[string]entity.InjectableSource,
+) error {
+	missing := &entity.MissingInjectablesError{NodePaths: make(map[string][]string)}
+	for _, injectable := range versionInjectables {
+		if !injectable.IsRequired {
+			continue
+		}
+		var key string
+		if injectable.Definition != nil {
+			key = injectable.Definition.Key
+		} else if injectable.SystemInjectableKey != nil {
+			key = *injectable.SystemInjectableKey
+		}
+		if key == "" || sources[key] != entity.InjectableSourceEmpty {
+			continue
+		}
+		missing.MissingCodes = append(missing.MissingCodes, key)
+		if paths := doc.InjectableNodePaths(key); len(paths) > 0 {
+			missing.NodePaths[key] = paths
+		}
+	}
+	if len(missing.MissingCodes) == 0 {
+		return nil
+	}
+	slices.Sort(missing.MissingCodes)
+	return missing
 }
 
 // BuildVersionInjectableDefaults builds a map of default values from version injectables.
@@ -357,3 +1003,12 @@ func BuildVersionInjectableDefaults(injectables []*entity.VersionInjectableWithD
 
 	return defaults
 }
+
+// renderFailureID returns the ID of the captured input of a failed compile, or "" when none was captured.
+func renderFailureID(err error) string {
+	var compileErr *entity.CompileError
+	if errors.As(err, &compileErr) {
+		return compileErr.FailureID
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+type contractRepoStub struct {
+	port.DocumentTypeContractRepository
+	byCode map[string]*entity.DocumentTypeContract
+}
+
+func (s *contractRepoStub) FindByDocumentTypeCode(_ context.Context, tenantCode, documentTypeCode string) (*entity.DocumentTypeContract, error) {
+	if contract, ok := s.byCode[tenantCode+"|"+documentTypeCode]; ok {
+		return contract, nil
+	}
+	return nil, entity.ErrPayloadContractNotFound
+}
+
+func contractRenderService(resolver *templateResolverStub) *InternalRenderService {
+	return &InternalRenderService{
+		contracts: &contractRepoStub{byCode: map[string]*entity.DocumentTypeContract{
+			"TENANT_A|CONTRACT": {
+				DocumentTypeID: "doc-1",
+				Schema:         json.RawMessage(`{"type":"object","required":["client_name"],"properties":{"client_name":{"type":"string","minLength":1}}}`),
+			},
+		}},
+		defaultResolver: resolver,
+		searchAdapter:   &stubTemplateVersionSearchAdapter{},
+	}
+}
+
+func TestInternalRenderService_RejectsPayloadBreakingContract(t *testing.T) {
+	resolver := &templateResolverStub{}
+	service := contractRenderService(resolver)
+
+	_, err := service.RenderByDocumentType(context.Background(), templateuc.InternalRenderCommand{
+		TenantCode:       "TENANT_A",
+		WorkspaceCode:    "WS_1",
+		TemplateTypeCode: "CONTRACT",
+		Injectables:      map[string]any{"amount": 10},
+	})
+
+	var contractErr *entity.PayloadContractError
+	require.ErrorAs(t, err, &contractErr)
+	assert.Equal(t, []entity.ContractViolation{{Path: "$.client_name", Message: "is required"}}, contractErr.Violations)
+	assert.Equal(t, 0, resolver.calls)
+}
+
+func TestInternalRenderService_PayloadSatisfyingContractIsResolved(t *testing.T) {
+	resolver := &templateResolverStub{}
+	service := contractRenderService(resolver)
+
+	_, err := service.RenderByDocumentType(context.Background(), templateuc.InternalRenderCommand{
+		TenantCode:       "TENANT_A",
+		WorkspaceCode:    "WS_1",
+		TemplateTypeCode: "CONTRACT",
+		Injectables:      map[string]any{"client_name": "ACME"},
+	})
+	require.ErrorIs(t, err, entity.ErrTemplateNotResolved)
+	assert.Equal(t, 1, resolver.calls)
+
+	// Types without a contract accept any payload
+	_, err = service.RenderByDocumentType(context.Background(), templateuc.InternalRenderCommand{
+		TenantCode:       "TENANT_A",
+		WorkspaceCode:    "WS_1",
+		TemplateTypeCode: "INVOICE",
+	})
+	require.ErrorIs(t, err, entity.ErrTemplateNotResolved)
+	assert.Equal(t, 2, resolver.calls)
+}
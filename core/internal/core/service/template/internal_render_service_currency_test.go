@@ -0,0 +1,178 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	injectablesvc "github.com/rendis/pdf-forge/core/internal/core/service/injectable"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+func TestInternalRenderService_RenderVersionRecordsCurrencyRates(t *testing.T) {
+	provider := &currencyRateProviderStub{}
+	renderer := &currencyPDFRendererStub{}
+	service := &InternalRenderService{
+		pdfRenderer: renderer,
+		currency:    injectablesvc.NewCurrencyRateService(provider, entity.CurrencyRoundHalfUp, 0),
+	}
+
+	version := &entity.TemplateVersionWithDetails{
+		TemplateVersion: entity.TemplateVersion{
+			ID:               "version-1",
+			ContentStructure: mustBuildPortableDoc(t),
+		},
+	}
+
+	result, err := service.renderVersion(context.Background(), version, templateuc.InternalRenderCommand{
+		Headers: map[string]string{"x-currency-rate-date": "2024-01-02"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 9.09, renderer.converted)
+	require.Len(t, result.CurrencyRates, 1)
+	assert.Equal(t, entity.CurrencyRate{
+		Provider: "stub",
+		From:     "USD",
+		To:       "EUR",
+		Rate:     1 / 1.1,
+		Date:     time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	}, result.CurrencyRates[0])
+	assert.Equal(t, []time.Time{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}, provider.dates)
+}
+
+func TestInternalRenderService_RenderVersionStoresCurrencyRates(t *testing.T) {
+	records := &renderCurrencyRateRepoStub{}
+	service := &InternalRenderService{
+		pdfRenderer:     &currencyPDFRendererStub{},
+		currency:        injectablesvc.NewCurrencyRateService(&currencyRateProviderStub{}, entity.CurrencyRoundHalfUp, 0),
+		currencyRecords: records,
+	}
+	version := &entity.TemplateVersionWithDetails{
+		TemplateVersion: entity.TemplateVersion{
+			ID:               "version-1",
+			TemplateID:       "template-1",
+			ContentStructure: mustBuildPortableDoc(t),
+		},
+	}
+
+	result, err := service.renderVersion(context.Background(), version, templateuc.InternalRenderCommand{
+		TenantCode:       "acme",
+		WorkspaceCode:    "sales",
+		TemplateTypeCode: "invoice",
+		Environment:      entity.EnvironmentProd,
+		JobID:            "job-1",
+	})
+	require.NoError(t, err)
+	require.Len(t, records.created, 1)
+	record := records.created[0]
+	assert.NotEmpty(t, record.ID)
+	assert.Equal(t, record.ID, result.CurrencyRecordID)
+	assert.Equal(t, "acme", record.TenantCode)
+	assert.Equal(t, "sales", record.WorkspaceCode)
+	assert.Equal(t, "version-1", record.VersionID)
+	assert.Equal(t, "template-1", record.TemplateID)
+	require.NotNil(t, record.DocumentTypeCode)
+	assert.Equal(t, "invoice", *record.DocumentTypeCode)
+	require.NotNil(t, record.JobID)
+	assert.Equal(t, "job-1", *record.JobID)
+	assert.Nil(t, record.DocumentNumber)
+	assert.Equal(t, result.CurrencyRates, record.Rates)
+}
+
+func TestInternalRenderService_RenderVersionFailsWhenCurrencyRatesCannotBeStored(t *testing.T) {
+	service := &InternalRenderService{
+		pdfRenderer:     &currencyPDFRendererStub{},
+		currency:        injectablesvc.NewCurrencyRateService(&currencyRateProviderStub{}, entity.CurrencyRoundHalfUp, 0),
+		currencyRecords: &renderCurrencyRateRepoStub{err: errors.New("connection refused")},
+	}
+	version := &entity.TemplateVersionWithDetails{
+		TemplateVersion: entity.TemplateVersion{ID: "version-1", ContentStructure: mustBuildPortableDoc(t)},
+	}
+
+	result, err := service.renderVersion(context.Background(), version, templateuc.InternalRenderCommand{})
+	require.ErrorContains(t, err, "recording currency rates")
+	assert.Nil(t, result, "a document whose rates were not stored is not returned")
+}
+
+func TestInternalRenderService_RenderVersionStoresNoRecordWithoutConversions(t *testing.T) {
+	records := &renderCurrencyRateRepoStub{}
+	service := &InternalRenderService{
+		pdfRenderer:     &imageResolverPDFRendererStub{},
+		currency:        injectablesvc.NewCurrencyRateService(&currencyRateProviderStub{}, entity.CurrencyRoundHalfUp, 0),
+		currencyRecords: records,
+	}
+	version := &entity.TemplateVersionWithDetails{
+		TemplateVersion: entity.TemplateVersion{ID: "version-1", ContentStructure: mustBuildPortableDoc(t)},
+	}
+
+	result, err := service.renderVersion(context.Background(), version, templateuc.InternalRenderCommand{})
+	require.NoError(t, err)
+	assert.Empty(t, records.created)
+	assert.Empty(t, result.CurrencyRecordID)
+}
+
+func TestInternalRenderService_CurrencyConverterRejectsInvalidDates(t *testing.T) {
+	service := &InternalRenderService{
+		currency: injectablesvc.NewCurrencyRateService(&currencyRateProviderStub{}, "", 0),
+	}
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format(time.DateOnly)
+
+	for _, date := range []string{"02/01/2024", "2024-13-01", tomorrow} {
+		_, err := service.currencyConverter(context.Background(), map[string]string{CurrencyRateDateHeader: date})
+		assert.ErrorIs(t, err, entity.ErrInvalidCurrencyRateDate, date)
+	}
+
+	converter, err := (&InternalRenderService{}).currencyConverter(context.Background(), map[string]string{CurrencyRateDateHeader: "bad"})
+	require.NoError(t, err, "the header is ignored when currency conversion is disabled")
+	assert.Nil(t, converter)
+}
+
+type currencyRateProviderStub struct {
+	dates []time.Time
+}
+
+func (p *currencyRateProviderStub) Name() string { return "stub" }
+
+func (p *currencyRateProviderStub) RateTable(_ context.Context, date time.Time) (*entity.CurrencyRateTable, error) {
+	p.dates = append(p.dates, date)
+	return &entity.CurrencyRateTable{
+		Provider: "stub",
+		Base:     "EUR",
+		Date:     date,
+		Rates:    map[string]float64{"USD": 1.1},
+	}, nil
+}
+
+type renderCurrencyRateRepoStub struct {
+	created []*entity.RenderCurrencyRates
+	err     error
+}
+
+func (r *renderCurrencyRateRepoStub) Create(_ context.Context, record *entity.RenderCurrencyRates) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.created = append(r.created, record)
+	return nil
+}
+
+// currencyPDFRendererStub converts an amount while rendering, like a table formula would.
+type currencyPDFRendererStub struct {
+	imageResolverPDFRendererStub
+	converted float64
+}
+
+func (s *currencyPDFRendererStub) RenderPreview(ctx context.Context, req *port.RenderPreviewRequest) (*port.RenderPreviewResult, error) {
+	converted, err := req.ConvertCurrency(10, "USD", "EUR")
+	if err != nil {
+		return nil, err
+	}
+	s.converted = converted
+	return s.imageResolverPDFRendererStub.RenderPreview(ctx, req)
+}
@@ -0,0 +1,113 @@
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	injectablesvc "github.com/rendis/pdf-forge/core/internal/core/service/injectable"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+func TestInternalRenderService_DegradedRenderReportsFailedInjectors(t *testing.T) {
+	service := newDegradedRenderService(&degradedInjectorStub{code: "customer_name", err: errors.New("crm unavailable")})
+	version := degradedRenderVersion(t, true, "customer_name")
+
+	_, err := service.renderVersion(context.Background(), version, templateuc.InternalRenderCommand{})
+	var injectorErr *entity.InjectorError
+	require.ErrorAs(t, err, &injectorErr, "a failed critical injector stops a strict render")
+
+	result, err := service.renderVersion(context.Background(), version, templateuc.InternalRenderCommand{Degraded: true})
+	require.NoError(t, err)
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, entity.RenderWarningDegradedInjector, result.Warnings[0].Code)
+	assert.Equal(t, "customer_name", result.Warnings[0].Source)
+	assert.Contains(t, result.Warnings[0].Message, "crm unavailable")
+
+	result, err = service.renderVersion(context.Background(), version, templateuc.InternalRenderCommand{
+		Degraded:    true,
+		Injectables: map[string]any{"customer_name": "Ada"},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Warnings, "a value given by the caller is not a degradation")
+}
+
+func TestInternalRenderService_DegradedRenderRequiresTemplateOptIn(t *testing.T) {
+	service := newDegradedRenderService(&degradedInjectorStub{code: "customer_name"})
+	version := degradedRenderVersion(t, false, "customer_name")
+
+	_, err := service.renderVersion(context.Background(), version, templateuc.InternalRenderCommand{Degraded: true})
+	assert.ErrorIs(t, err, entity.ErrDegradedRenderNotAllowed)
+}
+
+func newDegradedRenderService(injectors ...*degradedInjectorStub) *InternalRenderService {
+	registry := degradedRegistryStub{injectors: make(map[string]port.Injector)}
+	for _, inj := range injectors {
+		registry.injectors[inj.code] = inj
+	}
+	return &InternalRenderService{
+		pdfRenderer: &imageResolverPDFRendererStub{},
+		resolver:    injectablesvc.NewInjectableResolverService(registry, nil),
+	}
+}
+
+func degradedRenderVersion(t *testing.T, allow bool, codes ...string) *entity.TemplateVersionWithDetails {
+	t.Helper()
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(mustBuildPortableDoc(t), &doc))
+	doc["meta"].(map[string]any)["allowDegradedRender"] = allow
+	content, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	version := &entity.TemplateVersionWithDetails{
+		TemplateVersion: entity.TemplateVersion{ID: "version-1", ContentStructure: content},
+	}
+	for _, code := range codes {
+		version.Injectables = append(version.Injectables, &entity.VersionInjectableWithDefinition{
+			TemplateVersionInjectable: entity.TemplateVersionInjectable{SystemInjectableKey: &code},
+		})
+	}
+	return version
+}
+
+type degradedInjectorStub struct {
+	code string
+	err  error
+}
+
+func (i *degradedInjectorStub) Code() string { return i.code }
+
+func (i *degradedInjectorStub) Resolve() (port.ResolveFunc, []string) {
+	return func(context.Context, *entity.InjectorContext) (*entity.InjectorResult, error) {
+		if i.err != nil {
+			return nil, i.err
+		}
+		return &entity.InjectorResult{Value: entity.StringValue(i.code)}, nil
+	}, nil
+}
+
+func (i *degradedInjectorStub) IsCritical() bool                      { return true }
+func (i *degradedInjectorStub) Timeout() time.Duration                { return time.Second }
+func (i *degradedInjectorStub) DataType() entity.ValueType            { return entity.ValueTypeString }
+func (i *degradedInjectorStub) DefaultValue() *entity.InjectableValue { return nil }
+func (i *degradedInjectorStub) Formats() *entity.FormatConfig         { return nil }
+
+// degradedRegistryStub implements the registry methods the resolver uses.
+type degradedRegistryStub struct {
+	port.InjectorRegistry
+	injectors map[string]port.Injector
+}
+
+func (r degradedRegistryStub) Get(code string) (port.Injector, bool) {
+	inj, ok := r.injectors[code]
+	return inj, ok
+}
+
+func (r degradedRegistryStub) GetInitFunc() port.InitFunc { return nil }
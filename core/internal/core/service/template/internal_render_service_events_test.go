@@ -0,0 +1,78 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+func TestInternalRenderService_EmitRenderCompletedAppendsToOutbox(t *testing.T) {
+	outbox := &fakeFailureOutbox{}
+	service := &InternalRenderService{
+		outboxRepo:   outbox,
+		templateRepo: &fakeFailureTemplateRepo{templates: map[string]*entity.Template{"tpl-1": {ID: "tpl-1", WorkspaceID: "ws-1"}}},
+	}
+	version := &entity.TemplateVersionWithDetails{TemplateVersion: entity.TemplateVersion{ID: "v1", TemplateID: "tpl-1"}}
+
+	service.emitRenderCompleted(context.Background(), version, templateuc.InternalRenderCommand{TenantCode: "TENANT_A"},
+		&port.RenderPreviewResult{PDF: []byte("%PDF"), PageCount: 2}, 0)
+
+	require.Len(t, outbox.events, 1)
+	event := outbox.events[0]
+	assert.Equal(t, entity.OutboxEventRenderCompleted, event.Type)
+	assert.Equal(t, "v1", event.AggregateID)
+	require.NotNil(t, event.WorkspaceID, "the workspace of the template routes the event to its webhooks")
+	assert.Equal(t, "ws-1", *event.WorkspaceID)
+
+	var completed entity.RenderCompleted
+	require.NoError(t, event.DecodePayload(&completed))
+	assert.Equal(t, "TENANT_A", completed.TenantCode)
+	assert.Equal(t, 2, completed.PageCount)
+}
+
+func TestInternalRenderService_EmitRenderFailedAppendsToOutbox(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		templateID    string
+		wantEvent     bool
+		wantWorkspace bool
+	}{
+		{name: "compile failure", err: errors.New("typst compile failed"), templateID: "tpl-1", wantEvent: true, wantWorkspace: true},
+		{name: "renderer busy", err: entity.ErrRendererBusy, templateID: "tpl-1"},
+		{name: "template not found", err: errors.New("typst compile failed"), templateID: "missing", wantEvent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outbox := &fakeFailureOutbox{}
+			service := &InternalRenderService{
+				outboxRepo:   outbox,
+				templateRepo: &fakeFailureTemplateRepo{templates: map[string]*entity.Template{"tpl-1": {ID: "tpl-1", WorkspaceID: "ws-1"}}},
+			}
+			version := &entity.TemplateVersionWithDetails{TemplateVersion: entity.TemplateVersion{ID: "v1", TemplateID: tt.templateID}}
+
+			service.emitRenderFailed(context.Background(), version, templateuc.InternalRenderCommand{}, tt.err)
+
+			if !tt.wantEvent {
+				assert.Empty(t, outbox.events)
+				return
+			}
+			require.Len(t, outbox.events, 1)
+			assert.Equal(t, entity.OutboxEventRenderFailed, outbox.events[0].Type)
+			if tt.wantWorkspace {
+				require.NotNil(t, outbox.events[0].WorkspaceID)
+				assert.Equal(t, "ws-1", *outbox.events[0].WorkspaceID)
+			} else {
+				assert.Nil(t, outbox.events[0].WorkspaceID, "subscribers still receive an event of an unknown workspace")
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+type renderFailureCapturerStub struct {
+	captured []*entity.RenderFailure
+}
+
+func (c *renderFailureCapturerStub) CaptureRenderFailure(_ context.Context, f *entity.RenderFailure) string {
+	c.captured = append(c.captured, f)
+	return "failure-1"
+}
+
+func TestInternalRenderService_CaptureCompileFailure(t *testing.T) {
+	capturer := &renderFailureCapturerStub{}
+	service := &InternalRenderService{failures: capturer}
+	version := &entity.TemplateVersionWithDetails{TemplateVersion: entity.TemplateVersion{ID: "v1", TemplateID: "tpl-1"}}
+	compileErr := &entity.CompileError{Source: "#boom", Stderr: "error: boom", Err: errors.New("typst compile failed")}
+	renderErr := fmt.Errorf("failed to generate PDF: %w", compileErr)
+
+	service.captureCompileFailure(context.Background(), version, templateuc.InternalRenderCommand{
+		TenantCode:       "TENANT_A",
+		WorkspaceCode:    "WS_1",
+		TemplateTypeCode: "CONTRACT",
+	}, renderErr)
+
+	require.Len(t, capturer.captured, 1)
+	f := capturer.captured[0]
+	assert.Equal(t, "#boom", f.Source)
+	assert.Equal(t, "error: boom", f.Stderr)
+	assert.Equal(t, "v1", f.VersionID)
+	require.NotNil(t, f.DocumentTypeCode)
+	assert.Equal(t, "CONTRACT", *f.DocumentTypeCode)
+	assert.Equal(t, "failure-1", renderFailureID(renderErr))
+}
+
+func TestInternalRenderService_CaptureSkipsOtherErrors(t *testing.T) {
+	capturer := &renderFailureCapturerStub{}
+	service := &InternalRenderService{failures: capturer}
+	version := &entity.TemplateVersionWithDetails{TemplateVersion: entity.TemplateVersion{ID: "v1"}}
+
+	service.captureCompileFailure(context.Background(), version, templateuc.InternalRenderCommand{}, entity.ErrRendererBusy)
+
+	assert.Empty(t, capturer.captured)
+	assert.Empty(t, renderFailureID(entity.ErrRendererBusy))
+}
+
+func TestInternalRenderService_ErrorsLocateInjectorNodes(t *testing.T) {
+	service := newDegradedRenderService(&degradedInjectorStub{code: "customer_name", err: errors.New("crm unavailable")})
+	version := degradedRenderVersion(t, true, "customer_name")
+	version.ContentStructure = injectorNodeContent(t, "customer_name")
+	version.Injectables[0].IsRequired = true
+
+	_, err := service.renderVersion(context.Background(), version, templateuc.InternalRenderCommand{})
+	var injectorErr *entity.InjectorError
+	require.ErrorAs(t, err, &injectorErr)
+	assert.Equal(t, []string{"content.content[0].content[1]"}, injectorErr.NodePaths)
+
+	_, err = service.renderVersion(context.Background(), version, templateuc.InternalRenderCommand{Degraded: true})
+	var missingErr *entity.MissingInjectablesError
+	require.ErrorAs(t, err, &missingErr, "a required injectable left empty fails even a degraded render")
+	assert.Equal(t, []string{"customer_name"}, missingErr.MissingCodes)
+	assert.Equal(t, []string{"content.content[0].content[1]"}, missingErr.NodePaths["customer_name"])
+
+	_, err = service.renderVersion(context.Background(), version, templateuc.InternalRenderCommand{
+		Degraded:    true,
+		Injectables: map[string]any{"customer_name": "Ada"},
+	})
+	require.NoError(t, err)
+}
+
+func injectorNodeContent(t *testing.T, code string) json.RawMessage {
+	t.Helper()
+	doc, err := portabledoc.Parse(mustBuildPortableDoc(t))
+	require.NoError(t, err)
+	doc.Meta.AllowDegradedRender = true
+	doc.Content.Content[0].Content = append(doc.Content.Content[0].Content, portabledoc.Node{
+		Type:  portabledoc.NodeTypeInjector,
+		Attrs: map[string]any{"type": "TEXT", "label": "Customer", "variableId": code},
+	})
+	raw, err := json.Marshal(doc)
+	require.NoError(t, err)
+	return raw
+}
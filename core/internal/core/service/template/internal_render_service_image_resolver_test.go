@@ -61,6 +61,18 @@ func (s *imageResolverPDFRendererStub) RenderPreview(ctx context.Context, req *p
 	}, nil
 }
 
+func (s *imageResolverPDFRendererStub) ExportTypstProject(context.Context, *port.RenderPreviewRequest) (*port.TypstProjectResult, error) {
+	return &port.TypstProjectResult{}, nil
+}
+
+func (s *imageResolverPDFRendererStub) RenderHTML(context.Context, *port.RenderPreviewRequest) (*port.HTMLRenderResult, error) {
+	return &port.HTMLRenderResult{}, nil
+}
+
+func (s *imageResolverPDFRendererStub) RenderDocx(context.Context, *port.RenderPreviewRequest) (*port.DocxRenderResult, error) {
+	return &port.DocxRenderResult{}, nil
+}
+
 func (s *imageResolverPDFRendererStub) Close() error {
 	return nil
 }
@@ -0,0 +1,97 @@
+package template
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	injectablesvc "github.com/rendis/pdf-forge/core/internal/core/service/injectable"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+func TestInternalRenderService_RenderVersionDrawsDocumentNumber(t *testing.T) {
+	repo := newFakeSequenceRepo(entity.NumberingSequence{
+		ID: "seq-1", WorkspaceID: "ws-1", DocumentTypeID: "dt-1", InjectableKey: "invoice_number",
+		Prefix: "F-", Padding: 4, StartNumber: 1, NextNumber: 42, IsActive: true,
+	})
+	renderer := &numberingPDFRendererStub{}
+	service := newNumberingRenderService(repo, renderer)
+
+	result, err := service.renderVersion(context.Background(), numberingRenderVersion(t, "invoice_number"), templateuc.InternalRenderCommand{
+		Environment: entity.EnvironmentProd,
+		Injectables: map[string]any{"invoice_number": "given by the caller"},
+		DocumentID:  "order-7",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "F-0042", result.DocumentNumber)
+	assert.Equal(t, "F-0042", renderer.injectables["invoice_number"], "the drawn number replaces the caller's value")
+	require.Len(t, repo.numbers, 1)
+	assert.Equal(t, "version-1", repo.numbers[0].VersionID)
+	assert.Equal(t, "order-7", *repo.numbers[0].DocumentID)
+}
+
+func TestInternalRenderService_RenderVersionSkipsDocumentNumber(t *testing.T) {
+	tests := []struct {
+		name string
+		env  entity.Environment
+		key  string
+	}{
+		{"dev environment", entity.EnvironmentDev, "invoice_number"},
+		{"version without the injectable", entity.EnvironmentProd, "customer_name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeSequenceRepo(entity.NumberingSequence{
+				ID: "seq-1", WorkspaceID: "ws-1", DocumentTypeID: "dt-1", InjectableKey: "invoice_number",
+				StartNumber: 1, NextNumber: 1, IsActive: true,
+			})
+			service := newNumberingRenderService(repo, &numberingPDFRendererStub{})
+
+			result, err := service.renderVersion(context.Background(), numberingRenderVersion(t, tt.key), templateuc.InternalRenderCommand{
+				Environment: tt.env,
+			})
+
+			require.NoError(t, err)
+			assert.Empty(t, result.DocumentNumber)
+			assert.Empty(t, repo.numbers)
+		})
+	}
+}
+
+func newNumberingRenderService(repo *fakeSequenceRepo, renderer port.PDFRenderer) *InternalRenderService {
+	docTypeID := "dt-1"
+	return &InternalRenderService{
+		pdfRenderer: renderer,
+		resolver:    injectablesvc.NewInjectableResolverService(degradedRegistryStub{injectors: map[string]port.Injector{}}, nil),
+		templateRepo: &templateResolverTemplateRepoStub{byID: map[string]*entity.Template{
+			"template-1": {ID: "template-1", WorkspaceID: "ws-1", DocumentTypeID: &docTypeID},
+		}},
+		sequences: newTestSequenceService(repo),
+	}
+}
+
+func numberingRenderVersion(t *testing.T, key string) *entity.TemplateVersionWithDetails {
+	t.Helper()
+	return &entity.TemplateVersionWithDetails{
+		TemplateVersion: entity.TemplateVersion{ID: "version-1", TemplateID: "template-1", ContentStructure: mustBuildPortableDoc(t)},
+		Injectables: []*entity.VersionInjectableWithDefinition{{
+			Definition: &entity.InjectableDefinition{Key: key, DataType: entity.InjectableDataTypeText},
+		}},
+	}
+}
+
+// numberingPDFRendererStub keeps the injectables it rendered.
+type numberingPDFRendererStub struct {
+	imageResolverPDFRendererStub
+	injectables map[string]any
+}
+
+func (s *numberingPDFRendererStub) RenderPreview(ctx context.Context, req *port.RenderPreviewRequest) (*port.RenderPreviewResult, error) {
+	s.injectables = req.Injectables
+	return s.imageResolverPDFRendererStub.RenderPreview(ctx, req)
+}
@@ -636,6 +636,26 @@ func (s *pdfRendererStub) RenderPreview(_ context.Context, req *port.RenderPrevi
 	}, nil
 }
 
+func (s *pdfRendererStub) ExportTypstProject(context.Context, *port.RenderPreviewRequest) (*port.TypstProjectResult, error) {
+	return &port.TypstProjectResult{}, nil
+}
+
+func (s *pdfRendererStub) RenderHTML(_ context.Context, req *port.RenderPreviewRequest) (*port.HTMLRenderResult, error) {
+	s.calls++
+	if req != nil && req.Document != nil {
+		s.lastTitle = req.Document.Meta.Title
+	}
+	return &port.HTMLRenderResult{HTML: []byte("<!DOCTYPE html>"), Filename: "test.html"}, nil
+}
+
+func (s *pdfRendererStub) RenderDocx(_ context.Context, req *port.RenderPreviewRequest) (*port.DocxRenderResult, error) {
+	s.calls++
+	if req != nil && req.Document != nil {
+		s.lastTitle = req.Document.Meta.Title
+	}
+	return &port.DocxRenderResult{Docx: []byte("PK"), Filename: "test.docx"}, nil
+}
+
 func (s *pdfRendererStub) Close() error {
 	return nil
 }
@@ -0,0 +1,292 @@
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// mergeLibraryContent merges the changes of local and upstream since base. The blocks of the body
+// are merged block by block and the variableIds as a set; every other part of the document, header
+// and footer included, is merged as a whole. Parts changed differently on both sides are reported
+// as conflicts and resolved with strategy, keeping the local side when it is empty.
+func mergeLibraryContent(base, local, upstream json.RawMessage, strategy entity.LibraryMergeStrategy) (json.RawMessage, []entity.LibraryMergeConflict, error) {
+	baseDoc, err := jsonObject(base)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing base content: %w", err)
+	}
+	localDoc, err := jsonObject(local)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing local content: %w", err)
+	}
+	upstreamDoc, err := jsonObject(upstream)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing upstream content: %w", err)
+	}
+
+	m := &libraryMerge{strategy: strategy, conflicts: []entity.LibraryMergeConflict{}}
+	merged := make(map[string]json.RawMessage, len(localDoc))
+	for _, key := range objectKeys(baseDoc, localDoc, upstreamDoc) {
+		var value json.RawMessage
+		switch key {
+		case "content":
+			value, err = m.mergeBody(baseDoc[key], localDoc[key], upstreamDoc[key])
+			if err != nil {
+				return nil, nil, err
+			}
+		case "variableIds":
+			value, err = mergeIDSet(baseDoc[key], localDoc[key], upstreamDoc[key])
+			if err != nil {
+				return nil, nil, err
+			}
+		default:
+			value = m.mergeValue(key, baseDoc[key], localDoc[key], upstreamDoc[key])
+		}
+		if value != nil {
+			merged[key] = value
+		}
+	}
+
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshaling merged content: %w", err)
+	}
+	return data, m.conflicts, nil
+}
+
+// libraryMerge collects the conflicts of a merge.
+type libraryMerge struct {
+	strategy  entity.LibraryMergeStrategy
+	conflicts []entity.LibraryMergeConflict
+}
+
+// mergeValue merges a part of the document as a whole. Absent parts are nil.
+func (m *libraryMerge) mergeValue(path string, base, local, upstream json.RawMessage) json.RawMessage {
+	b, l, u := canonicalJSON(base), canonicalJSON(local), canonicalJSON(upstream)
+	switch {
+	case b == l:
+		return upstream
+	case b == u, l == u:
+		return local
+	}
+	m.conflicts = append(m.conflicts, entity.LibraryMergeConflict{
+		Path:     path,
+		Base:     orNull(base),
+		Local:    orNull(local),
+		Upstream: orNull(upstream),
+	})
+	if m.strategy == entity.LibraryMergeTakeUpstream {
+		return upstream
+	}
+	return local
+}
+
+// mergeBody merges the body of the document: its blocks block by block and its other attributes
+// as a whole.
+func (m *libraryMerge) mergeBody(base, local, upstream json.RawMessage) (json.RawMessage, error) {
+	if base == nil && local == nil && upstream == nil {
+		return nil, nil
+	}
+	baseBody, err := jsonObject(base)
+	if err != nil {
+		return nil, fmt.Errorf("parsing base body: %w", err)
+	}
+	localBody, err := jsonObject(local)
+	if err != nil {
+		return nil, fmt.Errorf("parsing local body: %w", err)
+	}
+	upstreamBody, err := jsonObject(upstream)
+	if err != nil {
+		return nil, fmt.Errorf("parsing upstream body: %w", err)
+	}
+
+	merged := make(map[string]json.RawMessage, len(localBody))
+	for _, key := range objectKeys(baseBody, localBody, upstreamBody) {
+		if key != "content" {
+			if value := m.mergeValue("content."+key, baseBody[key], localBody[key], upstreamBody[key]); value != nil {
+				merged[key] = value
+			}
+			continue
+		}
+
+		var baseBlocks, localBlocks, upstreamBlocks []json.RawMessage
+		for _, side := range []struct {
+			data   json.RawMessage
+			blocks *[]json.RawMessage
+		}{{baseBody[key], &baseBlocks}, {localBody[key], &localBlocks}, {upstreamBody[key], &upstreamBlocks}} {
+			if side.data == nil {
+				continue
+			}
+			if err := json.Unmarshal(side.data, side.blocks); err != nil {
+				return nil, fmt.Errorf("parsing body blocks: %w", err)
+			}
+		}
+		merged[key] = jsonValue(m.mergeBlocks("content", baseBlocks, localBlocks, upstreamBlocks))
+	}
+	return jsonValue(merged), nil
+}
+
+// mergeBlocks merges lists of blocks three-way. The blocks of base kept by both sides anchor the
+// merge; between two anchors, the side that changed the blocks of base wins, and blocks changed
+// differently by both sides conflict.
+func (m *libraryMerge) mergeBlocks(path string, base, local, upstream []json.RawMessage) []json.RawMessage {
+	baseKeys, localKeys, upstreamKeys := blockKeys(base), blockKeys(local), blockKeys(upstream)
+	localMatch := lcsMatch(baseKeys, localKeys)
+	upstreamMatch := lcsMatch(baseKeys, upstreamKeys)
+
+	merged := []json.RawMessage{}
+	resolve := func(i, bi, jl, kl, ju, ku int) {
+		b, l, u := baseKeys[i:bi], localKeys[jl:kl], upstreamKeys[ju:ku]
+		switch {
+		case slices.Equal(b, l):
+			merged = append(merged, upstream[ju:ku]...)
+		case slices.Equal(b, u), slices.Equal(l, u):
+			merged = append(merged, local[jl:kl]...)
+		default:
+			m.conflicts = append(m.conflicts, entity.LibraryMergeConflict{
+				Path:     fmt.Sprintf("%s[%d]", path, i),
+				Base:     jsonValue(blockList(base[i:bi])),
+				Local:    jsonValue(blockList(local[jl:kl])),
+				Upstream: jsonValue(blockList(upstream[ju:ku])),
+			})
+			if m.strategy == entity.LibraryMergeTakeUpstream {
+				merged = append(merged, upstream[ju:ku]...)
+			} else {
+				merged = append(merged, local[jl:kl]...)
+			}
+		}
+	}
+
+	i, jl, ju := 0, 0, 0
+	for bi := range base {
+		if localMatch[bi] < 0 || upstreamMatch[bi] < 0 {
+			continue
+		}
+		resolve(i, bi, jl, localMatch[bi], ju, upstreamMatch[bi])
+		merged = append(merged, local[localMatch[bi]])
+		i, jl, ju = bi+1, localMatch[bi]+1, upstreamMatch[bi]+1
+	}
+	resolve(i, len(base), jl, len(local), ju, len(upstream))
+	return merged
+}
+
+// lcsMatch aligns a and b on their longest common subsequence, returning for each element of a the
+// index of its match in b, or -1.
+func lcsMatch(a, b []string) []int {
+	lcs := lcsTable(a, b)
+	match := make([]int, len(a))
+	i, j := 0, 0
+	for i < len(a) {
+		switch {
+		case j < len(b) && a[i] == b[j]:
+			match[i] = j
+			i++
+			j++
+		case j < len(b) && lcs[i][j+1] >= lcs[i+1][j]:
+			j++
+		default:
+			match[i] = -1
+			i++
+		}
+	}
+	return match
+}
+
+// mergeIDSet merges lists of IDs as sets: the local list without the IDs upstream removed, followed
+// by the IDs upstream added.
+func mergeIDSet(base, local, upstream json.RawMessage) (json.RawMessage, error) {
+	if base == nil && local == nil && upstream == nil {
+		return nil, nil
+	}
+	var baseIDs, localIDs, upstreamIDs []string
+	for _, side := range []struct {
+		data json.RawMessage
+		ids  *[]string
+	}{{base, &baseIDs}, {local, &localIDs}, {upstream, &upstreamIDs}} {
+		if side.data == nil {
+			continue
+		}
+		if err := json.Unmarshal(side.data, side.ids); err != nil {
+			return nil, fmt.Errorf("parsing variable IDs: %w", err)
+		}
+	}
+
+	added, removed := diffLists(baseIDs, upstreamIDs)
+	merged := []string{}
+	for _, id := range localIDs {
+		if !slices.Contains(removed, id) {
+			merged = append(merged, id)
+		}
+	}
+	for _, id := range added {
+		if !slices.Contains(merged, id) {
+			merged = append(merged, id)
+		}
+	}
+	return jsonValue(merged), nil
+}
+
+// jsonObject parses a JSON object into its members; null and absent content have none.
+func jsonObject(data json.RawMessage) (map[string]json.RawMessage, error) {
+	object := map[string]json.RawMessage{}
+	if len(data) == 0 {
+		return object, nil
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	if object == nil {
+		object = map[string]json.RawMessage{}
+	}
+	return object, nil
+}
+
+// objectKeys returns the sorted keys of all objects.
+func objectKeys(objects ...map[string]json.RawMessage) []string {
+	keys := map[string]bool{}
+	for _, object := range objects {
+		for k := range object {
+			keys[k] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(keys))
+}
+
+// blockKeys returns the canonical JSON of each block, which identifies equal blocks.
+func blockKeys(blocks []json.RawMessage) []string {
+	keys := make([]string, len(blocks))
+	for i, block := range blocks {
+		keys[i] = canonicalJSON(block)
+	}
+	return keys
+}
+
+// canonicalJSON re-marshals data so that equal values compare equal whatever their formatting and
+// key order. Absent values compare as null.
+func canonicalJSON(data json.RawMessage) string {
+	if len(data) == 0 {
+		return "null"
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return string(data)
+	}
+	return string(jsonValue(value))
+}
+
+func blockList(blocks []json.RawMessage) []json.RawMessage {
+	if blocks == nil {
+		return []json.RawMessage{}
+	}
+	return blocks
+}
+
+func orNull(data json.RawMessage) json.RawMessage {
+	if data == nil {
+		return json.RawMessage("null")
+	}
+	return data
+}
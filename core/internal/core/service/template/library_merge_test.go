@@ -0,0 +1,98 @@
+package template
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+func mergeContent(t *testing.T, doc *portabledoc.Document) json.RawMessage {
+	t.Helper()
+	content, err := json.Marshal(doc)
+	require.NoError(t, err)
+	return content
+}
+
+func mergedDocument(t *testing.T, content json.RawMessage) *portabledoc.Document {
+	t.Helper()
+	var doc portabledoc.Document
+	require.NoError(t, json.Unmarshal(content, &doc))
+	return &doc
+}
+
+func TestMergeLibraryContent_MergesBothSides(t *testing.T) {
+	base := mergeContent(t, diffDocument([]string{"client_name", "amount"},
+		heading(1, "Parties"),
+		paragraph("The client agrees."),
+		paragraph("Late payments accrue interest."),
+		heading(2, "Signatures"),
+	))
+	local := mergeContent(t, diffDocument([]string{"client_name", "amount", "branch"},
+		heading(1, "Parties"),
+		paragraph("The client agrees."),
+		paragraph("Our branch handles the contract."),
+		paragraph("Late payments accrue interest."),
+		heading(2, "Signatures"),
+	))
+	upstreamDoc := diffDocument([]string{"client_name", "due_date"},
+		heading(1, "Parties"),
+		paragraph("The client agrees."),
+		paragraph("Late payments accrue interest."),
+		heading(2, "Signatures"),
+		paragraph("Signed in duplicate."),
+	)
+	upstreamDoc.PageConfig.ShowPageNumbers = true
+	upstream := mergeContent(t, upstreamDoc)
+
+	merged, conflicts, err := mergeLibraryContent(base, local, upstream, "")
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+
+	doc := mergedDocument(t, merged)
+	assert.Equal(t, []string{"client_name", "branch", "due_date"}, doc.VariableIDs)
+	assert.True(t, doc.PageConfig.ShowPageNumbers, "upstream page settings are taken")
+	require.Len(t, doc.Content.Content, 6)
+	assert.Equal(t, "Our branch handles the contract.", *doc.Content.Content[2].Content[0].Text)
+	assert.Equal(t, "Signed in duplicate.", *doc.Content.Content[5].Content[0].Text)
+}
+
+func TestMergeLibraryContent_Conflicts(t *testing.T) {
+	base := mergeContent(t, diffDocument(nil, heading(1, "Parties"), paragraph("The client agrees.")))
+	local := mergeContent(t, diffDocument(nil, heading(1, "Parties"), paragraph("The customer agrees.")))
+	upstream := mergeContent(t, diffDocument(nil, heading(1, "Parties"), paragraph("The client accepts.")))
+
+	merged, conflicts, err := mergeLibraryContent(base, local, upstream, "")
+	require.NoError(t, err)
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, "content[1]", conflicts[0].Path)
+	assert.JSONEq(t, `[`+string(nodeJSON(paragraph("The client accepts.")))+`]`, string(conflicts[0].Upstream))
+	assert.Equal(t, "The customer agrees.", *mergedDocument(t, merged).Content.Content[1].Content[0].Text)
+
+	merged, conflicts, err = mergeLibraryContent(base, local, upstream, entity.LibraryMergeTakeUpstream)
+	require.NoError(t, err)
+	assert.Len(t, conflicts, 1)
+	assert.Equal(t, "The client accepts.", *mergedDocument(t, merged).Content.Content[1].Content[0].Text)
+}
+
+func TestMergeLibraryContent_WholeParts(t *testing.T) {
+	baseDoc := diffDocument(nil, paragraph("Body"))
+	localDoc := diffDocument(nil, paragraph("Body"))
+	localDoc.PageConfig.Width = 800
+	upstreamDoc := diffDocument(nil, paragraph("Body"))
+	upstreamDoc.PageConfig.Height = 1200
+
+	_, conflicts, err := mergeLibraryContent(mergeContent(t, baseDoc), mergeContent(t, localDoc), mergeContent(t, upstreamDoc), "")
+	require.NoError(t, err)
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, "pageConfig", conflicts[0].Path)
+
+	same, conflicts, err := mergeLibraryContent(mergeContent(t, baseDoc), mergeContent(t, localDoc), mergeContent(t, localDoc), "")
+	require.NoError(t, err)
+	assert.Empty(t, conflicts, "the same change on both sides does not conflict")
+	assert.Equal(t, 800.0, mergedDocument(t, same).PageConfig.Width)
+}
@@ -0,0 +1,509 @@
+package markdown
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+// markdownMarks are the marks Markdown can express, in the order they are opened.
+var markdownMarks = []string{
+	portabledoc.MarkTypeLink,
+	portabledoc.MarkTypeBold,
+	portabledoc.MarkTypeItalic,
+	portabledoc.MarkTypeStrike,
+	portabledoc.MarkTypeCode,
+}
+
+// markDelimiters are the delimiters of the marks written around text.
+var markDelimiters = map[string]string{
+	portabledoc.MarkTypeBold:   "**",
+	portabledoc.MarkTypeItalic: "*",
+	portabledoc.MarkTypeStrike: "~~",
+	portabledoc.MarkTypeCode:   "`",
+}
+
+// expressibleAttrs lists, per node type, the attrs Markdown can express. Nodes with any other attr
+// set are exported as a pdf-forge fence, so importing the export gives back the same content.
+var expressibleAttrs = map[string]portabledoc.Set[string]{
+	portabledoc.NodeTypeParagraph:     {},
+	portabledoc.NodeTypeHeading:       {"level": {}},
+	portabledoc.NodeTypeBlockquote:    {},
+	portabledoc.NodeTypeCodeBlock:     {"language": {}},
+	portabledoc.NodeTypeHR:            {},
+	portabledoc.NodeTypePageBreak:     {},
+	portabledoc.NodeTypeBulletList:    {},
+	portabledoc.NodeTypeOrderedList:   {"start": {}},
+	portabledoc.NodeTypeTaskList:      {},
+	portabledoc.NodeTypeListItem:      {},
+	portabledoc.NodeTypeTaskItem:      {"checked": {}},
+	portabledoc.NodeTypeTable:         {},
+	portabledoc.NodeTypeTableRow:      {},
+	portabledoc.NodeTypeTableHeader:   {"colspan": {}, "rowspan": {}},
+	portabledoc.NodeTypeTableCell:     {"colspan": {}, "rowspan": {}},
+	portabledoc.NodeTypeCustomImage:   {"src": {}, "alt": {}, "injectableId": {}, "injectableLabel": {}},
+	portabledoc.NodeTypeImage:         {"src": {}, "alt": {}, "injectableId": {}, "injectableLabel": {}},
+	portabledoc.NodeTypeTableInjector: {"variableId": {}, "label": {}},
+	portabledoc.NodeTypeListInjector:  {"variableId": {}, "label": {}},
+	portabledoc.NodeTypeInjector:      {"type": {}, "label": {}, "variableId": {}},
+	portabledoc.NodeTypeText:          {},
+	portabledoc.NodeTypeHardBreak:     {},
+}
+
+// Export renders a portable document as Markdown with a YAML front-matter. Content Markdown cannot
+// express, such as conditionals or centered paragraphs, is written as pdf-forge fences.
+func Export(doc *portabledoc.Document) (string, error) {
+	fm, err := newFrontMatter(doc)
+	if err != nil {
+		return "", fmt.Errorf("building front-matter: %w", err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(frontMatterDelimiter + "\n")
+	enc := yaml.NewEncoder(&sb)
+	enc.SetIndent(2)
+	if err := enc.Encode(fm); err != nil {
+		return "", fmt.Errorf("encoding front-matter: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return "", fmt.Errorf("encoding front-matter: %w", err)
+	}
+	sb.WriteString(frontMatterDelimiter + "\n")
+	if doc.Content != nil {
+		body, err := exportBlocks(doc.Content.Content)
+		if err != nil {
+			return "", err
+		}
+		if body != "" {
+			sb.WriteString("\n" + body + "\n")
+		}
+	}
+	return sb.String(), nil
+}
+
+// exportBlocks renders block nodes separated by blank lines.
+func exportBlocks(nodes []portabledoc.Node) (string, error) {
+	parts := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		part, err := exportBlock(node)
+		if err != nil {
+			return "", err
+		}
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "\n\n"), nil
+}
+
+// exportBlock renders a block node, falling back to a pdf-forge fence.
+func exportBlock(node portabledoc.Node) (string, error) {
+	if !expressible(node) {
+		return nodeFence(node)
+	}
+
+	switch node.Type {
+	case portabledoc.NodeTypeParagraph:
+		lines := strings.Split(strings.TrimSpace(exportInline(node.Content)), "\n")
+		for i, line := range lines {
+			lines[i] = escapeLineStart(line)
+		}
+		return strings.Join(lines, "\n"), nil
+	case portabledoc.NodeTypeHeading:
+		level := intAttr(node.Attrs, "level", 1)
+		return strings.Repeat("#", max(1, min(level, 6))) + " " + exportInline(node.Content), nil
+	case portabledoc.NodeTypeBlockquote:
+		inner, err := exportBlocks(node.Content)
+		if err != nil {
+			return "", err
+		}
+		return prefixLines(inner, "> ", ">"), nil
+	case portabledoc.NodeTypeCodeBlock:
+		language, _ := node.Attrs["language"].(string)
+		text := plainText(node.Content)
+		fence := codeFence(text)
+		return fence + language + "\n" + text + "\n" + fence, nil
+	case portabledoc.NodeTypeHR:
+		return "---", nil
+	case portabledoc.NodeTypePageBreak:
+		return pageBreakComment, nil
+	case portabledoc.NodeTypeBulletList, portabledoc.NodeTypeOrderedList, portabledoc.NodeTypeTaskList:
+		return exportList(node)
+	case portabledoc.NodeTypeTable:
+		return exportTable(node), nil
+	case portabledoc.NodeTypeCustomImage, portabledoc.NodeTypeImage:
+		return exportImage(node), nil
+	case portabledoc.NodeTypeTableInjector, portabledoc.NodeTypeListInjector:
+		key, _ := node.Attrs["variableId"].(string)
+		return "```" + InjectorFence + "\n" + key + "\n```", nil
+	}
+	return nodeFence(node)
+}
+
+// exportList renders a list. Item content is indented to the width of the item marker.
+func exportList(node portabledoc.Node) (string, error) {
+	number := intAttr(node.Attrs, "start", 1)
+	lines := make([]string, 0, len(node.Content))
+	for _, item := range node.Content {
+		marker := "- "
+		switch {
+		case node.Type == portabledoc.NodeTypeOrderedList:
+			marker = strconv.Itoa(number) + ". "
+			number++
+		case node.Type == portabledoc.NodeTypeTaskList:
+			marker = "- [ ] "
+			if checked, _ := item.Attrs["checked"].(bool); checked {
+				marker = "- [x] "
+			}
+		}
+
+		content, err := exportBlocks(item.Content)
+		if err != nil {
+			return "", err
+		}
+		indent := strings.Repeat(" ", len(marker))
+		if node.Type == portabledoc.NodeTypeTaskList {
+			indent = "  "
+		}
+		lines = append(lines, marker+strings.TrimPrefix(prefixLines(content, indent, ""), indent))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// exportTable renders a pipe table, whose first row is the header.
+func exportTable(node portabledoc.Node) string {
+	var lines []string
+	for i, row := range node.Content {
+		cells := make([]string, 0, len(row.Content))
+		for _, cell := range row.Content {
+			var content []portabledoc.Node
+			if len(cell.Content) > 0 {
+				content = cell.Content[0].Content
+			}
+			cells = append(cells, strings.ReplaceAll(exportInline(content), "|", `\|`))
+		}
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+
+		if i == 0 {
+			delims := make([]string, len(row.Content))
+			for j, cell := range row.Content {
+				delims[j] = alignDelimiter(cell)
+			}
+			lines = append(lines, "| "+strings.Join(delims, " | ")+" |")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// alignDelimiter returns the delimiter cell for the alignment of a column's header cell.
+func alignDelimiter(cell portabledoc.Node) string {
+	align := ""
+	if len(cell.Content) > 0 {
+		align, _ = cell.Content[0].Attrs["textAlign"].(string)
+	}
+	switch align {
+	case "center":
+		return ":---:"
+	case "right":
+		return "---:"
+	}
+	return "---"
+}
+
+// exportImage renders an image. Images bound to an injectable use a {{placeholder}} source.
+func exportImage(node portabledoc.Node) string {
+	alt, _ := node.Attrs["alt"].(string)
+	src, _ := node.Attrs["src"].(string)
+	if key, _ := node.Attrs["injectableId"].(string); key != "" {
+		src = "{{" + key + "}}"
+	}
+	return "![" + escapeText(alt) + "](<" + src + ">)"
+}
+
+// exportInline renders inline nodes, opening and closing marks as they change between nodes.
+// Spaces at the edges of marked text are moved outside the delimiters, where Markdown expects them.
+func exportInline(nodes []portabledoc.Node) string {
+	var sb strings.Builder
+	var open []portabledoc.Mark
+	pendingSpace := ""
+
+	closeFrom := func(i int) {
+		for j := len(open) - 1; j >= i; j-- {
+			sb.WriteString(closeMark(open[j]))
+		}
+		open = open[:i]
+	}
+
+	for _, node := range nodes {
+		text := ""
+		if node.Text != nil {
+			text = *node.Text
+		}
+		if node.Type == portabledoc.NodeTypeText && strings.TrimSpace(text) == "" {
+			pendingSpace += text
+			continue
+		}
+
+		marks := sortedMarks(node.Marks)
+		isCode := slices.ContainsFunc(marks, func(m portabledoc.Mark) bool { return m.Type == portabledoc.MarkTypeCode })
+		lead, trail := "", ""
+		if node.Type == portabledoc.NodeTypeText && len(marks) > 0 && !isCode {
+			core := strings.TrimSpace(text)
+			start := strings.Index(text, core)
+			lead, text, trail = text[:start], core, text[start+len(core):]
+		}
+
+		// Keep the longest prefix of open marks the node still has
+		keep := 0
+		for keep < len(open) && keep < len(marks) && sameMark(open[keep], marks[keep]) {
+			keep++
+		}
+		closeFrom(keep)
+		sb.WriteString(pendingSpace + lead)
+		for _, mark := range marks[keep:] {
+			sb.WriteString(openMark(mark))
+			open = append(open, mark)
+		}
+		pendingSpace = trail
+
+		switch node.Type {
+		case portabledoc.NodeTypeText:
+			if isCode {
+				sb.WriteString(text)
+			} else {
+				sb.WriteString(escapeText(text))
+			}
+		case portabledoc.NodeTypeInjector:
+			key, _ := node.Attrs["variableId"].(string)
+			sb.WriteString("{{" + key + "}}")
+		case portabledoc.NodeTypeHardBreak:
+			sb.WriteString("\\\n")
+		}
+	}
+	closeFrom(0)
+	sb.WriteString(pendingSpace)
+	return sb.String()
+}
+
+// expressible reports whether node and its descendants can be written as Markdown.
+func expressible(node portabledoc.Node) bool {
+	allowed, ok := expressibleAttrs[node.Type]
+	if !ok {
+		return false
+	}
+	for name, value := range node.Attrs {
+		if !allowed.Contains(name) && !isDefaultAttr(name, value) {
+			return false
+		}
+	}
+	for _, mark := range node.Marks {
+		if !slices.Contains(markdownMarks, mark.Type) {
+			return false
+		}
+	}
+
+	switch node.Type {
+	case portabledoc.NodeTypeTable:
+		return expressibleTable(node)
+	case portabledoc.NodeTypeCodeBlock:
+		return true
+	case portabledoc.NodeTypeHeading:
+		if slices.ContainsFunc(node.Content, func(n portabledoc.Node) bool { return n.Type == portabledoc.NodeTypeHardBreak }) {
+			return false
+		}
+	case portabledoc.NodeTypeListItem, portabledoc.NodeTypeTaskItem:
+		if len(node.Content) == 0 || node.Content[0].Type != portabledoc.NodeTypeParagraph {
+			return false // Markdown items start with a paragraph
+		}
+	}
+	for _, child := range node.Content {
+		if !expressible(child) {
+			return false
+		}
+	}
+	return true
+}
+
+// expressibleTable reports whether a table fits a pipe table: a header row, single-paragraph
+// cells without spans or line breaks, and the same number of cells in every row.
+func expressibleTable(node portabledoc.Node) bool {
+	if len(node.Content) == 0 {
+		return false
+	}
+	columns := len(node.Content[0].Content)
+	for i, row := range node.Content {
+		if len(row.Content) != columns || columns == 0 {
+			return false
+		}
+		for _, cell := range row.Content {
+			wantType := portabledoc.NodeTypeTableCell
+			if i == 0 {
+				wantType = portabledoc.NodeTypeTableHeader
+			}
+			if cell.Type != wantType || intAttr(cell.Attrs, "colspan", 1) != 1 || intAttr(cell.Attrs, "rowspan", 1) != 1 {
+				return false
+			}
+			if len(cell.Content) > 1 || (len(cell.Content) == 1 && !expressibleCell(cell.Content[0])) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// expressibleCell reports whether a cell paragraph fits on one line of a pipe table.
+func expressibleCell(para portabledoc.Node) bool {
+	if para.Type != portabledoc.NodeTypeParagraph {
+		return false
+	}
+	for name, value := range para.Attrs {
+		if name != "textAlign" && !isDefaultAttr(name, value) {
+			return false
+		}
+	}
+	for _, child := range para.Content {
+		if child.Type == portabledoc.NodeTypeHardBreak || !expressible(child) {
+			return false
+		}
+	}
+	return true
+}
+
+// isDefaultAttr reports whether an attr holds the editor's default value, which Markdown
+// does not need to carry.
+func isDefaultAttr(name string, value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case string:
+		return v == "" || (name == "textAlign" && v == "left")
+	case float64:
+		return v == 0
+	}
+	return false
+}
+
+// nodeFence writes a node as indented JSON in a pdf-forge fence.
+func nodeFence(node portabledoc.Node) (string, error) {
+	data, err := json.MarshalIndent(node, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encoding %s node: %w", node.Type, err)
+	}
+	fence := codeFence(string(data))
+	return fence + NodeFence + "\n" + string(data) + "\n" + fence, nil
+}
+
+// codeFence returns a backtick fence longer than any backtick run in text.
+func codeFence(text string) string {
+	longest, run := 0, 0
+	for i := 0; i < len(text); i++ {
+		if text[i] == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
+
+func sortedMarks(marks []portabledoc.Mark) []portabledoc.Mark {
+	out := slices.Clone(marks)
+	slices.SortStableFunc(out, func(a, b portabledoc.Mark) int {
+		return slices.Index(markdownMarks, a.Type) - slices.Index(markdownMarks, b.Type)
+	})
+	return out
+}
+
+func sameMark(a, b portabledoc.Mark) bool {
+	if a.Type != b.Type {
+		return false
+	}
+	hrefA, _ := a.Attrs["href"].(string)
+	hrefB, _ := b.Attrs["href"].(string)
+	return hrefA == hrefB
+}
+
+func openMark(mark portabledoc.Mark) string {
+	if mark.Type == portabledoc.MarkTypeLink {
+		return "["
+	}
+	return markDelimiters[mark.Type]
+}
+
+func closeMark(mark portabledoc.Mark) string {
+	if mark.Type == portabledoc.MarkTypeLink {
+		href, _ := mark.Attrs["href"].(string)
+		return "](<" + href + ">)"
+	}
+	return markDelimiters[mark.Type]
+}
+
+// escapeText escapes the characters that would start inline Markdown.
+func escapeText(text string) string {
+	var sb strings.Builder
+	for i := 0; i < len(text); i++ {
+		if strings.IndexByte("\\`*_[]~<", text[i]) >= 0 {
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(text[i])
+	}
+	return sb.String()
+}
+
+// escapeLineStart escapes a paragraph start that would read as a heading, quote, list or table.
+func escapeLineStart(text string) string {
+	if text == "" {
+		return ""
+	}
+	if strings.IndexByte("#>-+=|", text[0]) >= 0 {
+		return "\\" + text
+	}
+	if i := strings.IndexFunc(text, func(r rune) bool { return r < '0' || r > '9' }); i > 0 && (text[i] == '.' || text[i] == ')') {
+		return text[:i] + "\\" + text[i:]
+	}
+	return text
+}
+
+// prefixLines prefixes every line of text, using blankPrefix for empty lines.
+func prefixLines(text, prefix, blankPrefix string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = blankPrefix
+		} else {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// plainText concatenates the text of inline nodes.
+func plainText(nodes []portabledoc.Node) string {
+	var sb strings.Builder
+	for _, node := range nodes {
+		if node.Text != nil {
+			sb.WriteString(*node.Text)
+		}
+	}
+	return sb.String()
+}
+
+// intAttr reads a numeric attr, which is a float64 in parsed documents.
+func intAttr(attrs map[string]any, name string, fallback int) int {
+	switch v := attrs[name].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,124 @@
+package markdown
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/service/template/templateimport"
+)
+
+// frontMatterDelimiter opens and closes the YAML front-matter block.
+const frontMatterDelimiter = "---"
+
+// frontMatter is the YAML block at the top of a Markdown template. The page config, header and
+// footer use the field names of the portable document, so anything the editor sets survives an
+// export and import.
+type frontMatter struct {
+	Title       string         `yaml:"title,omitempty"`
+	Description string         `yaml:"description,omitempty"`
+	Language    string         `yaml:"language,omitempty"`
+	PageConfig  map[string]any `yaml:"pageConfig,omitempty"`
+	Header      map[string]any `yaml:"header,omitempty"`
+	Footer      map[string]any `yaml:"footer,omitempty"`
+}
+
+// splitFrontMatter separates the front-matter block from the Markdown body lines.
+// Documents without front-matter return a zero frontMatter.
+func splitFrontMatter(lines []string) (frontMatter, []string, error) {
+	var fm frontMatter
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != frontMatterDelimiter {
+		return fm, lines, nil
+	}
+	for i := 1; i < len(lines); i++ {
+		if end := strings.TrimSpace(lines[i]); end == frontMatterDelimiter || end == "..." {
+			if err := yaml.Unmarshal([]byte(strings.Join(lines[1:i], "\n")), &fm); err != nil {
+				return fm, nil, fmt.Errorf("parsing front-matter: %w", err)
+			}
+			return fm, lines[i+1:], nil
+		}
+	}
+	return fm, lines, nil // No closing delimiter: the opening line is a thematic break
+}
+
+// meta returns the document metadata declared in the front-matter.
+func (fm frontMatter) meta() portabledoc.Meta {
+	meta := portabledoc.Meta{Title: fm.Title, Language: fm.Language}
+	if fm.Description != "" {
+		meta.Description = &fm.Description
+	}
+	return meta
+}
+
+// pageConfig returns the default page config overridden by the front-matter. Declaring a standard
+// formatId without a width and height uses that format's size.
+func (fm frontMatter) pageConfig() (portabledoc.PageConfig, error) {
+	config := templateimport.DefaultPageConfig
+	if len(fm.PageConfig) == 0 {
+		return config, nil
+	}
+	if err := convert(fm.PageConfig, &config); err != nil {
+		return config, fmt.Errorf("reading pageConfig: %w", err)
+	}
+
+	_, hasWidth := fm.PageConfig["width"]
+	_, hasHeight := fm.PageConfig["height"]
+	if size, ok := portabledoc.StandardPageSizes[config.FormatID]; ok && !hasWidth && !hasHeight {
+		config.Width, config.Height = size.Width, size.Height
+	}
+	return config, nil
+}
+
+// surfaces returns the header and footer declared in the front-matter.
+func (fm frontMatter) surfaces() (*portabledoc.DocumentHeader, *portabledoc.DocumentFooter, error) {
+	var header *portabledoc.DocumentHeader
+	var footer *portabledoc.DocumentFooter
+	if len(fm.Header) > 0 {
+		header = &portabledoc.DocumentHeader{}
+		if err := convert(fm.Header, header); err != nil {
+			return nil, nil, fmt.Errorf("reading header: %w", err)
+		}
+	}
+	if len(fm.Footer) > 0 {
+		footer = &portabledoc.DocumentFooter{}
+		if err := convert(fm.Footer, footer); err != nil {
+			return nil, nil, fmt.Errorf("reading footer: %w", err)
+		}
+	}
+	return header, footer, nil
+}
+
+// newFrontMatter builds the front-matter of an exported document.
+func newFrontMatter(doc *portabledoc.Document) (frontMatter, error) {
+	fm := frontMatter{Title: doc.Meta.Title, Language: doc.Meta.Language}
+	if doc.Meta.Description != nil {
+		fm.Description = *doc.Meta.Description
+	}
+	if err := convert(doc.PageConfig, &fm.PageConfig); err != nil {
+		return fm, err
+	}
+	if doc.Header != nil {
+		if err := convert(doc.Header, &fm.Header); err != nil {
+			return fm, err
+		}
+	}
+	if doc.Footer != nil {
+		if err := convert(doc.Footer, &fm.Footer); err != nil {
+			return fm, err
+		}
+	}
+	return fm, nil
+}
+
+// convert copies src into dst through JSON, so YAML maps and portable document structs
+// share the JSON field names.
+func convert(src, dst any) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
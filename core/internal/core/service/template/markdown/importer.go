@@ -0,0 +1,536 @@
+// Package markdown converts version content to and from Markdown for docs-as-code workflows.
+package markdown
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/service/template/templateimport"
+)
+
+// SourceApp identifies imported documents in their export info.
+const SourceApp = "pdf-forge-markdown-import"
+
+// Fenced block info strings with a meaning of their own.
+const (
+	// InjectorFence holds injectable keys, one per line. Each key becomes the node for its data type:
+	// a table or list injector, an image bound to the injectable, or a paragraph with an injector.
+	InjectorFence = "injector"
+
+	// NodeFence holds portable document nodes as JSON, for content Markdown cannot express.
+	NodeFence = "pdf-forge"
+)
+
+// pageBreakComment marks a page break. Other Markdown renderers hide it.
+const pageBreakComment = "<!-- pagebreak -->"
+
+var (
+	headingPattern    = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))??(?:[ \t]+#+)?[ \t]*$`)
+	fencePattern      = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})[ \t]*([^`]*)$")
+	thematicPattern   = regexp.MustCompile(`^ {0,3}(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	setextPattern     = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	listMarkerPattern = regexp.MustCompile(`^( {0,3})([-*+]|(\d{1,9})[.)])(?:[ \t]+|$)`)
+	taskMarkerPattern = regexp.MustCompile(`^\[([ xX])\](?:[ \t]+|$)`)
+	htmlBlockPattern  = regexp.MustCompile(`^ {0,3}<(?:[a-zA-Z][a-zA-Z0-9-]*(?:[ \t/>]|$)|/[a-zA-Z]|!--)`)
+	tableDelimPattern = regexp.MustCompile(`^ {0,3}\|?[ \t]*:?-+:?[ \t]*(?:\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+)
+
+// Import converts a Markdown template into a portable document. The YAML front-matter sets the
+// metadata, page config, header and footer; {{placeholders}} and injector fences that match an
+// injectable key become injectors. Everything that could not be converted is listed in the report.
+func Import(source string, injectables []*entity.InjectableDefinition) (*portabledoc.Document, *entity.TemplateImportReport, error) {
+	if err := templateimport.CheckSource(source); err != nil {
+		return nil, nil, err
+	}
+
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = expandIndent(line)
+	}
+
+	fm, body, err := splitFrontMatter(lines)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", entity.ErrInvalidTemplateImport, err)
+	}
+	pageConfig, err := fm.pageConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", entity.ErrInvalidTemplateImport, err)
+	}
+	header, footer, err := fm.surfaces()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", entity.ErrInvalidTemplateImport, err)
+	}
+
+	im := &importer{Session: templateimport.NewSession(injectables)}
+	if header != nil {
+		im.bindNodes(header.ContentNodes())
+		im.bindKey(header.ImageInjectableID)
+	}
+	if footer != nil {
+		im.bindNodes(footer.ContentNodes())
+		im.bindKey(footer.ImageInjectableID)
+	}
+	content := im.blocks(body)
+
+	doc := im.Document(fm.meta(), pageConfig, content, SourceApp)
+	doc.Header, doc.Footer = header, footer
+	return doc, im.Report(), nil
+}
+
+// importer holds the state of one conversion.
+type importer struct {
+	*templateimport.Session
+
+	// pendingImages collects images found inside a paragraph; they are placed after it.
+	pendingImages []portabledoc.Node
+}
+
+// blocks converts lines into block nodes.
+func (im *importer) blocks(lines []string) []portabledoc.Node {
+	var out []portabledoc.Node
+	var para []string
+
+	flush := func() {
+		if len(para) > 0 {
+			out = append(out, im.paragraph(para)...)
+			para = nil
+		}
+	}
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		if len(para) > 0 {
+			if m := setextPattern.FindStringSubmatch(line); m != nil {
+				level := 2
+				if m[1][0] == '=' {
+					level = 1
+				}
+				out = append(out, im.heading(level, strings.Join(para, " ")))
+				para = nil
+				i++
+				continue
+			}
+		}
+
+		switch {
+		case trimmed == "":
+			flush()
+			i++
+		case fencePattern.MatchString(line):
+			flush()
+			nodes, n := im.fence(lines[i:])
+			out = append(out, nodes...)
+			i += n
+		case headingPattern.MatchString(line):
+			flush()
+			m := headingPattern.FindStringSubmatch(line)
+			out = append(out, im.heading(len(m[1]), m[2]))
+			i++
+		case trimmed == pageBreakComment:
+			flush()
+			out = append(out, portabledoc.Node{Type: portabledoc.NodeTypePageBreak})
+			i++
+		case thematicPattern.MatchString(line):
+			flush()
+			out = append(out, portabledoc.Node{Type: portabledoc.NodeTypeHR})
+			i++
+		case strings.HasPrefix(trimmed, ">") && leadingSpaces(line) < 4:
+			flush()
+			node, n := im.blockquote(lines[i:])
+			out = append(out, node)
+			i += n
+		case listMarkerPattern.MatchString(line):
+			flush()
+			node, n := im.list(lines[i:])
+			out = append(out, node)
+			i += n
+		case len(para) == 0 && i+1 < len(lines) && strings.Contains(line, "|") && tableDelimPattern.MatchString(lines[i+1]):
+			node, n := im.table(lines[i:])
+			out = append(out, node)
+			i += n
+		case len(para) == 0 && htmlBlockPattern.MatchString(line):
+			i += im.skipHTML(lines[i:])
+		default:
+			para = append(para, line)
+			i++
+		}
+	}
+	flush()
+	return out
+}
+
+// skipHTML drops a raw HTML block up to the next blank line, or an HTML comment up to its end,
+// returning the lines consumed. Only comments are dropped silently.
+func (im *importer) skipHTML(lines []string) int {
+	if strings.HasPrefix(strings.TrimSpace(lines[0]), "<!--") {
+		for n, line := range lines {
+			if strings.Contains(line, "-->") {
+				return n + 1
+			}
+		}
+		return len(lines)
+	}
+
+	im.Unsupported("raw HTML")
+	for n, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			return n
+		}
+	}
+	return len(lines)
+}
+
+// heading converts an ATX or setext heading.
+func (im *importer) heading(level int, text string) portabledoc.Node {
+	return portabledoc.Node{
+		Type:    portabledoc.NodeTypeHeading,
+		Attrs:   map[string]any{"level": level},
+		Content: templateimport.MergeText(im.inline(strings.TrimSpace(text), nil)),
+	}
+}
+
+// paragraph converts paragraph lines, followed by the images they held. A line ending in a
+// backslash or two spaces is a hard break; other line ends are spaces.
+func (im *importer) paragraph(lines []string) []portabledoc.Node {
+	var sb strings.Builder
+	for i, line := range lines {
+		text := strings.TrimLeft(line, " ")
+		if i == len(lines)-1 {
+			sb.WriteString(strings.TrimRight(text, " "))
+			break
+		}
+		switch {
+		case strings.HasSuffix(text, "  "):
+			sb.WriteString(strings.TrimRight(text, " ") + "\n")
+		case hasHardBreakBackslash(text):
+			sb.WriteString(text[:len(text)-1] + "\n")
+		default:
+			sb.WriteString(strings.TrimRight(text, " ") + " ")
+		}
+	}
+
+	content := templateimport.MergeText(im.inline(sb.String(), nil))
+	var out []portabledoc.Node
+	if len(content) > 0 {
+		out = append(out, portabledoc.Node{Type: portabledoc.NodeTypeParagraph, Content: content})
+	}
+	out = append(out, im.pendingImages...)
+	im.pendingImages = nil
+	return out
+}
+
+// fence converts a fenced code block, returning the nodes and the lines consumed.
+func (im *importer) fence(lines []string) ([]portabledoc.Node, int) {
+	m := fencePattern.FindStringSubmatch(lines[0])
+	indent, marker, info := len(m[1]), m[2], strings.TrimSpace(m[3])
+
+	var body []string
+	n := 1
+	for ; n < len(lines); n++ {
+		trimmed := strings.TrimSpace(lines[n])
+		if strings.HasPrefix(trimmed, marker) && strings.Trim(trimmed, marker[:1]) == "" {
+			n++
+			break
+		}
+		body = append(body, strings.TrimPrefix(lines[n], strings.Repeat(" ", min(indent, leadingSpaces(lines[n])))))
+	}
+
+	language, _, _ := strings.Cut(info, " ")
+	switch language {
+	case InjectorFence:
+		return im.injectorFence(body), n
+	case NodeFence:
+		return im.nodeFence(body), n
+	}
+
+	node := portabledoc.Node{Type: portabledoc.NodeTypeCodeBlock}
+	if language != "" {
+		node.Attrs = map[string]any{"language": language}
+	}
+	if text := strings.Join(body, "\n"); text != "" {
+		node.Content = []portabledoc.Node{templateimport.TextNode(text, nil)}
+	}
+	return []portabledoc.Node{node}, n
+}
+
+// injectorFence converts each injectable key of an injector fence into the node for its data type.
+func (im *importer) injectorFence(body []string) []portabledoc.Node {
+	var out []portabledoc.Node
+	for _, line := range body {
+		key := strings.Trim(strings.TrimSpace(line), "{}")
+		if key == "" {
+			continue
+		}
+
+		inj, ok := im.Injectable(key)
+		if !ok {
+			im.Unmapped(key)
+			im.WarnOnce("fence:"+key, fmt.Sprintf("Injector block %q does not match an injectable and was removed", key))
+			continue
+		}
+
+		attrs := map[string]any{"variableId": inj.Key, "label": templateimport.InjectableLabel(inj)}
+		switch inj.DataType {
+		case entity.InjectableDataTypeTable:
+			out = append(out, portabledoc.Node{Type: portabledoc.NodeTypeTableInjector, Attrs: attrs})
+		case entity.InjectableDataTypeList:
+			out = append(out, portabledoc.Node{Type: portabledoc.NodeTypeListInjector, Attrs: attrs})
+		case entity.InjectableDataTypeImage:
+			out = append(out, portabledoc.Node{Type: portabledoc.NodeTypeCustomImage, Attrs: map[string]any{
+				"injectableId":    inj.Key,
+				"injectableLabel": templateimport.InjectableLabel(inj),
+			}})
+		default:
+			out = append(out, portabledoc.Node{Type: portabledoc.NodeTypeParagraph, Content: im.Text("{{"+key+"}}", nil)})
+			continue // Text binds the injectable
+		}
+		im.Bind(key, inj)
+	}
+	return out
+}
+
+// nodeFence decodes the JSON node, or array of nodes, of a pdf-forge fence.
+func (im *importer) nodeFence(body []string) []portabledoc.Node {
+	data := []byte(strings.Join(body, "\n"))
+	var nodes []portabledoc.Node
+	var node portabledoc.Node
+	if err := json.Unmarshal(data, &node); err == nil && node.Type != "" {
+		nodes = []portabledoc.Node{node}
+	} else if err := json.Unmarshal(data, &nodes); err != nil {
+		im.WarnOnce("json:"+string(data), fmt.Sprintf("A %s block is not valid node JSON and was removed: %v", NodeFence, err))
+		return nil
+	}
+	im.bindNodes(nodes)
+	return nodes
+}
+
+// bindNodes binds the injectables referenced by nodes taken verbatim from the source.
+func (im *importer) bindNodes(nodes []portabledoc.Node) {
+	for _, node := range nodes {
+		switch node.Type {
+		case portabledoc.NodeTypeInjector, portabledoc.NodeTypeListInjector, portabledoc.NodeTypeTableInjector:
+			key, _ := node.Attrs["variableId"].(string)
+			im.bindKey(key)
+		case portabledoc.NodeTypeImage, portabledoc.NodeTypeCustomImage:
+			key, _ := node.Attrs["injectableId"].(string)
+			im.bindKey(key)
+		}
+		im.bindNodes(node.Content)
+	}
+}
+
+// bindKey binds an injectable referenced by key, reporting it when the workspace does not have it.
+func (im *importer) bindKey(key string) {
+	if key == "" {
+		return
+	}
+	if inj, ok := im.Injectable(key); ok {
+		im.Bind(key, inj)
+		return
+	}
+	im.Unmapped(key)
+}
+
+// blockquote converts the lines starting with ">" and their lazy continuations.
+func (im *importer) blockquote(lines []string) (portabledoc.Node, int) {
+	var inner []string
+	n := 0
+	for ; n < len(lines); n++ {
+		trimmed := strings.TrimSpace(lines[n])
+		if strings.HasPrefix(trimmed, ">") {
+			inner = append(inner, strings.TrimPrefix(trimmed[1:], " "))
+			continue
+		}
+		if trimmed == "" || startsBlock(lines[n]) || len(inner) == 0 || strings.TrimSpace(inner[len(inner)-1]) == "" {
+			break
+		}
+		inner = append(inner, trimmed)
+	}
+	return portabledoc.Node{Type: portabledoc.NodeTypeBlockquote, Content: nonEmpty(im.blocks(inner))}, n
+}
+
+// list converts a bullet, ordered or task list. Lines indented past the item marker belong to
+// the item, so nested lists are parsed from the item's own lines.
+func (im *importer) list(lines []string) (portabledoc.Node, int) {
+	first := listMarkerPattern.FindStringSubmatch(lines[0])
+	ordered := first[3] != ""
+
+	var items [][]string
+	contentIndent := 0
+	n := 0
+	for ; n < len(lines); n++ {
+		line := lines[n]
+		if m := listMarkerPattern.FindStringSubmatch(line); m != nil && (n == 0 || leadingSpaces(line) < contentIndent) {
+			if (m[3] != "") != ordered || thematicPattern.MatchString(line) {
+				break
+			}
+			contentIndent = len(m[0])
+			if strings.TrimSpace(line[len(m[0]):]) == "" {
+				contentIndent = len(m[1]) + len(m[2]) + 1
+			}
+			items = append(items, []string{strings.TrimSpace(line[len(m[0]):])})
+			continue
+		}
+
+		current := items[len(items)-1]
+		previousBlank := strings.TrimSpace(current[len(current)-1]) == ""
+		switch {
+		case strings.TrimSpace(line) == "":
+			items[len(items)-1] = append(current, "")
+			continue
+		case leadingSpaces(line) >= contentIndent:
+			items[len(items)-1] = append(current, line[contentIndent:])
+			continue
+		case !previousBlank && !startsBlock(line):
+			items[len(items)-1] = append(current, strings.TrimSpace(line)) // Lazy continuation
+			continue
+		}
+		break
+	}
+
+	listType, itemType := portabledoc.NodeTypeBulletList, portabledoc.NodeTypeListItem
+	if ordered {
+		listType = portabledoc.NodeTypeOrderedList
+	} else if taskMarkerPattern.MatchString(items[0][0]) {
+		listType, itemType = portabledoc.NodeTypeTaskList, portabledoc.NodeTypeTaskItem
+	}
+
+	list := portabledoc.Node{Type: listType}
+	if start, err := strconv.Atoi(first[3]); err == nil && start != 1 {
+		list.Attrs = map[string]any{"start": start}
+	}
+	for _, itemLines := range items {
+		item := portabledoc.Node{Type: itemType}
+		if itemType == portabledoc.NodeTypeTaskItem {
+			checked := false
+			if m := taskMarkerPattern.FindStringSubmatch(itemLines[0]); m != nil {
+				checked = m[1] != " "
+				itemLines[0] = itemLines[0][len(m[0]):]
+			}
+			item.Attrs = map[string]any{"checked": checked}
+		}
+		item.Content = nonEmpty(im.blocks(itemLines))
+		list.Content = append(list.Content, item)
+	}
+	return list, n
+}
+
+// table converts a pipe table. The first row is the header; the delimiter row sets the alignment.
+func (im *importer) table(lines []string) (portabledoc.Node, int) {
+	aligns := tableAligns(splitRow(lines[1]))
+	table := portabledoc.Node{Type: portabledoc.NodeTypeTable}
+	table.Content = append(table.Content, im.tableRow(splitRow(lines[0]), aligns, portabledoc.NodeTypeTableHeader))
+
+	n := 2
+	for ; n < len(lines); n++ {
+		if strings.TrimSpace(lines[n]) == "" || !strings.Contains(lines[n], "|") {
+			break
+		}
+		table.Content = append(table.Content, im.tableRow(splitRow(lines[n]), aligns, portabledoc.NodeTypeTableCell))
+	}
+	return table, n
+}
+
+func (im *importer) tableRow(cells []string, aligns []string, cellType string) portabledoc.Node {
+	row := portabledoc.Node{Type: portabledoc.NodeTypeTableRow}
+	for i, align := range aligns {
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		para := portabledoc.Node{Type: portabledoc.NodeTypeParagraph, Content: templateimport.MergeText(im.inline(cell, nil))}
+		if align != "" {
+			para.Attrs = map[string]any{"textAlign": align}
+		}
+		content := append([]portabledoc.Node{para}, im.pendingImages...)
+		im.pendingImages = nil
+		row.Content = append(row.Content, portabledoc.Node{
+			Type:    cellType,
+			Attrs:   map[string]any{"colspan": 1, "rowspan": 1},
+			Content: content,
+		})
+	}
+	return row
+}
+
+// splitRow splits a table row on unescaped pipes, dropping the outer ones.
+func splitRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	var sb strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			sb.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(sb.String()))
+			sb.Reset()
+		default:
+			sb.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(sb.String()))
+}
+
+// tableAligns reads the column alignments of a delimiter row.
+func tableAligns(cells []string) []string {
+	aligns := make([]string, len(cells))
+	for i, cell := range cells {
+		left, right := strings.HasPrefix(cell, ":"), strings.HasSuffix(cell, ":")
+		switch {
+		case left && right:
+			aligns[i] = "center"
+		case right:
+			aligns[i] = "right"
+		}
+	}
+	return aligns
+}
+
+// startsBlock reports whether a line starts a block that ends a lazy continuation.
+func startsBlock(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return headingPattern.MatchString(line) || fencePattern.MatchString(line) || thematicPattern.MatchString(line) ||
+		listMarkerPattern.MatchString(line) || strings.HasPrefix(trimmed, ">") || trimmed == pageBreakComment
+}
+
+// nonEmpty returns blocks, or an empty paragraph when there are none: list items, table cells
+// and blockquotes need at least one block.
+func nonEmpty(blocks []portabledoc.Node) []portabledoc.Node {
+	if len(blocks) == 0 {
+		return []portabledoc.Node{{Type: portabledoc.NodeTypeParagraph}}
+	}
+	return blocks
+}
+
+// hasHardBreakBackslash reports whether a line ends in an unescaped backslash.
+func hasHardBreakBackslash(line string) bool {
+	trailing := len(line) - len(strings.TrimRight(line, `\`))
+	return trailing%2 == 1
+}
+
+func leadingSpaces(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// expandIndent replaces tabs in the indentation of a line with four spaces.
+func expandIndent(line string) string {
+	indent := len(line) - len(strings.TrimLeft(line, " \t"))
+	if !strings.Contains(line[:indent], "\t") {
+		return line
+	}
+	return strings.ReplaceAll(line[:indent], "\t", "    ") + line[indent:]
+}
@@ -0,0 +1,273 @@
+package markdown
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/service/template/templateimport"
+)
+
+// emphasisMarks maps the length of a * or _ delimiter run to the marks it applies.
+var emphasisMarks = map[int][]string{
+	1: {portabledoc.MarkTypeItalic},
+	2: {portabledoc.MarkTypeBold},
+	3: {portabledoc.MarkTypeBold, portabledoc.MarkTypeItalic},
+}
+
+// inline converts inline Markdown into text, injector and hard break nodes. Images are moved
+// to pendingImages; "\n" marks a hard break.
+func (im *importer) inline(text string, marks []portabledoc.Mark) []portabledoc.Node {
+	var out []portabledoc.Node
+	var buf strings.Builder
+
+	flush := func() {
+		if buf.Len() > 0 {
+			out = append(out, im.Text(buf.String(), marks)...)
+			buf.Reset()
+		}
+	}
+	emit := func(nodes ...portabledoc.Node) {
+		flush()
+		out = append(out, nodes...)
+	}
+
+	for i := 0; i < len(text); {
+		ch := text[i]
+		switch {
+		case ch == '\\' && i+1 < len(text) && isPunct(text[i+1]):
+			// Escaped characters skip placeholder matching, so \{\{ stays literal
+			emit(templateimport.TextNode(text[i+1:i+2], marks))
+			i += 2
+			continue
+		case ch == '\n':
+			emit(portabledoc.Node{Type: portabledoc.NodeTypeHardBreak})
+			i++
+			continue
+		case ch == '`':
+			if code, end, ok := codeSpan(text, i); ok {
+				emit(templateimport.TextNode(code, templateimport.AppendMark(marks, portabledoc.Mark{Type: portabledoc.MarkTypeCode})))
+				i = end
+				continue
+			}
+		case ch == '{' && strings.HasPrefix(text[i:], "{{"):
+			// Keep the placeholder whole, so "_" in keys is not read as emphasis
+			if end := strings.Index(text[i:], "}}"); end > 0 {
+				end = i + end + 2
+				if end < len(text) && text[end] == '}' {
+					end++
+				}
+				buf.WriteString(text[i:end])
+				i = end
+				continue
+			}
+		case ch == '!' && strings.HasPrefix(text[i:], "!["):
+			if alt, dest, end, ok := parseLink(text, i+1); ok {
+				im.image(alt, dest)
+				i = end
+				continue
+			}
+		case ch == '[':
+			if label, dest, end, ok := parseLink(text, i); ok {
+				link := portabledoc.Mark{Type: portabledoc.MarkTypeLink, Attrs: map[string]any{"href": dest}}
+				emit(im.inline(label, templateimport.AppendMark(marks, link))...)
+				i = end
+				continue
+			}
+		case ch == '<':
+			if end := strings.IndexByte(text[i:], '>'); end > 0 && isURL(text[i+1:i+end]) {
+				href := text[i+1 : i+end]
+				link := portabledoc.Mark{Type: portabledoc.MarkTypeLink, Attrs: map[string]any{"href": href}}
+				emit(templateimport.TextNode(href, templateimport.AppendMark(marks, link)))
+				i += end + 1
+				continue
+			}
+		case ch == '*' || ch == '_' || (ch == '~' && strings.HasPrefix(text[i:], "~~")):
+			if inner, innerMarks, end, ok := emphasis(text, i); ok {
+				next := marks
+				for _, mark := range innerMarks {
+					next = templateimport.AppendMark(next, portabledoc.Mark{Type: mark})
+				}
+				emit(im.inline(inner, next)...)
+				i = end
+				continue
+			}
+			run := delimiterRun(text, i)
+			buf.WriteString(text[i : i+run])
+			i += run
+			continue
+		}
+		buf.WriteByte(ch)
+		i++
+	}
+	flush()
+	return out
+}
+
+// image queues an image. A {{placeholder}} source binds the image to an image injectable.
+func (im *importer) image(alt, src string) {
+	attrs := map[string]any{}
+	if key, ok := strings.CutPrefix(src, "{{"); ok {
+		key = strings.TrimSpace(strings.TrimSuffix(key, "}}"))
+		inj, found := im.Injectable(key)
+		if !found {
+			im.Unmapped(key)
+			im.WarnOnce("image:"+key, "Image with source {{"+key+"}} does not match an injectable and was removed")
+			return
+		}
+		im.Bind(key, inj)
+		attrs["injectableId"] = inj.Key
+		attrs["injectableLabel"] = templateimport.InjectableLabel(inj)
+	} else {
+		attrs["src"] = src
+	}
+	if alt != "" {
+		attrs["alt"] = alt
+	}
+	im.pendingImages = append(im.pendingImages, portabledoc.Node{Type: portabledoc.NodeTypeCustomImage, Attrs: attrs})
+}
+
+// codeSpan reads a code span opened by the backtick run at start.
+func codeSpan(text string, start int) (code string, end int, ok bool) {
+	run := delimiterRun(text, start)
+	for i := start + run; i < len(text); {
+		if text[i] != '`' {
+			i++
+			continue
+		}
+		closing := delimiterRun(text, i)
+		if closing == run {
+			code = text[start+run : i]
+			if len(code) > 1 && code[0] == ' ' && code[len(code)-1] == ' ' {
+				code = code[1 : len(code)-1]
+			}
+			return code, i + closing, true
+		}
+		i += closing
+	}
+	return "", 0, false
+}
+
+// emphasis reads an emphasis span opened by the delimiter run at start. Runs of three apply
+// bold and italic; when they close separately the outer mark is read first.
+func emphasis(text string, start int) (inner string, marks []string, end int, ok bool) {
+	delim := text[start]
+	run := delimiterRun(text, start)
+	if start+run >= len(text) || text[start+run] == ' ' || (delim == '_' && start > 0 && isWordChar(text[start-1])) {
+		return "", nil, 0, false
+	}
+
+	if delim == '~' {
+		if run != 2 {
+			return "", nil, 0, false
+		}
+		if closing := findClosing(text, start+run, delim, run); closing >= 0 {
+			return text[start+run : closing], []string{portabledoc.MarkTypeStrike}, closing + run, true
+		}
+		return "", nil, 0, false
+	}
+
+	for n := min(run, 3); n >= 1; n-- {
+		if closing := findClosing(text, start+n, delim, n); closing >= 0 {
+			return text[start+n : closing], emphasisMarks[n], closing + n, true
+		}
+	}
+	return "", nil, 0, false
+}
+
+// findClosing returns the index of the run of n delimiters that closes an emphasis opened before
+// from, preferring a run of exactly n. A longer run closes with its last n delimiters.
+func findClosing(text string, from int, delim byte, n int) int {
+	longer := -1
+	for i := from; i < len(text); {
+		switch {
+		case text[i] == '\\':
+			i += 2
+			continue
+		case text[i] == '`':
+			if _, end, ok := codeSpan(text, i); ok {
+				i = end
+				continue
+			}
+		case text[i] == delim:
+			run := delimiterRun(text, i)
+			after := i + run
+			closes := i > from && (delim != '_' || after >= len(text) || !isWordChar(text[after]))
+			if closes && run == n {
+				return i
+			}
+			if closes && run > n && longer < 0 {
+				longer = i + run - n
+			}
+			i = after
+			continue
+		}
+		i++
+	}
+	return longer
+}
+
+// parseLink reads [label](destination "title") starting at the opening bracket.
+func parseLink(text string, start int) (label, dest string, end int, ok bool) {
+	if start >= len(text) || text[start] != '[' {
+		return "", "", 0, false
+	}
+	closeBracket := matching(text, start, '[', ']')
+	if closeBracket < 0 || closeBracket+1 >= len(text) || text[closeBracket+1] != '(' {
+		return "", "", 0, false
+	}
+	closeParen := matching(text, closeBracket+1, '(', ')')
+	if closeParen < 0 {
+		return "", "", 0, false
+	}
+
+	dest = strings.TrimSpace(text[closeBracket+2 : closeParen])
+	if strings.HasPrefix(dest, "<") {
+		if i := strings.IndexByte(dest, '>'); i > 0 {
+			dest = dest[1:i]
+		}
+	} else if i := strings.IndexAny(dest, " \t"); i > 0 {
+		dest = dest[:i] // Drop the title
+	}
+	return text[start+1 : closeBracket], dest, closeParen + 1, true
+}
+
+// matching returns the index of the bracket that closes the one at start, skipping escapes.
+func matching(text string, start int, open, close byte) int {
+	depth := 0
+	for i := start; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func delimiterRun(text string, start int) int {
+	n := 0
+	for start+n < len(text) && text[start+n] == text[start] {
+		n++
+	}
+	return n
+}
+
+func isPunct(ch byte) bool {
+	return ch < unicode.MaxASCII && unicode.IsPunct(rune(ch)) || strings.IndexByte("$+<=>^`|~", ch) >= 0
+}
+
+func isWordChar(ch byte) bool {
+	return ch >= unicode.MaxASCII || unicode.IsLetter(rune(ch)) || unicode.IsDigit(rune(ch))
+}
+
+func isURL(s string) bool {
+	return (strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "mailto:")) &&
+		!strings.ContainsAny(s, " <")
+}
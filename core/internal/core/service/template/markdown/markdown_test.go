@@ -0,0 +1,193 @@
+package markdown
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+func testInjectables() []*entity.InjectableDefinition {
+	return []*entity.InjectableDefinition{
+		{Key: "customer_name", Label: "Customer name", DataType: entity.InjectableDataTypeText},
+		{Key: "line_items", Label: "Items", DataType: entity.InjectableDataTypeTable},
+		{Key: "logo", Label: "Logo", DataType: entity.InjectableDataTypeImage},
+	}
+}
+
+const contract = "---\n" +
+	"title: Service agreement\n" +
+	"language: es\n" +
+	"pageConfig:\n" +
+	"  formatId: LETTER\n" +
+	"  showPageNumbers: true\n" +
+	"---\n" +
+	"\n" +
+	"# Agreement\n" +
+	"\n" +
+	"Between **{{customer_name}}** and *ACME*,\\\n" +
+	"see [terms](https://example.com/terms) and {{unknown}}.\n" +
+	"\n" +
+	"1. First\n" +
+	"2. Second\n" +
+	"   - nested\n" +
+	"\n" +
+	"| Item | Price |\n" +
+	"| --- | ---: |\n" +
+	"| A | 10 |\n" +
+	"\n" +
+	"```injector\n" +
+	"line_items\n" +
+	"```\n" +
+	"\n" +
+	"<!-- pagebreak -->\n" +
+	"\n" +
+	"> Signed\n" +
+	"\n" +
+	"![Logo]({{logo}})\n"
+
+func TestImport_Contract(t *testing.T) {
+	doc, report, err := Import(contract, testInjectables())
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	if doc.Meta.Title != "Service agreement" || doc.Meta.Language != "es" {
+		t.Errorf("unexpected meta: %+v", doc.Meta)
+	}
+	if doc.PageConfig.FormatID != portabledoc.PageFormatLetter || doc.PageConfig.Width != 818 || !doc.PageConfig.ShowPageNumbers {
+		t.Errorf("unexpected page config: %+v", doc.PageConfig)
+	}
+	if doc.PageConfig.Margins != portabledoc.MarginPresets[portabledoc.MarginPresetNormal] {
+		t.Errorf("expected default margins, got %+v", doc.PageConfig.Margins)
+	}
+
+	var types []string
+	for _, node := range doc.Content.Content {
+		types = append(types, node.Type)
+	}
+	want := []string{
+		portabledoc.NodeTypeHeading, portabledoc.NodeTypeParagraph, portabledoc.NodeTypeOrderedList,
+		portabledoc.NodeTypeTable, portabledoc.NodeTypeTableInjector, portabledoc.NodeTypePageBreak,
+		portabledoc.NodeTypeBlockquote, portabledoc.NodeTypeCustomImage,
+	}
+	if !slices.Equal(types, want) {
+		t.Fatalf("block types = %v, want %v", types, want)
+	}
+
+	para := doc.Content.Content[1].Content
+	if para[1].Type != portabledoc.NodeTypeInjector || para[1].Marks[0].Type != portabledoc.MarkTypeBold {
+		t.Errorf("expected a bold injector, got %+v", para[1])
+	}
+	if !slices.ContainsFunc(para, func(n portabledoc.Node) bool { return n.Type == portabledoc.NodeTypeHardBreak }) {
+		t.Errorf("expected a hard break, got %+v", para)
+	}
+
+	nested := doc.Content.Content[2].Content[1].Content
+	if len(nested) != 2 || nested[1].Type != portabledoc.NodeTypeBulletList {
+		t.Errorf("expected a nested bullet list, got %+v", nested)
+	}
+
+	priceHeader := doc.Content.Content[3].Content[0].Content[1]
+	if priceHeader.Type != portabledoc.NodeTypeTableHeader || priceHeader.Content[0].Attrs["textAlign"] != "right" {
+		t.Errorf("expected a right-aligned header cell, got %+v", priceHeader)
+	}
+
+	if !slices.Equal(doc.VariableIDs, []string{"customer_name", "line_items", "logo"}) {
+		t.Errorf("unexpected variableIds: %v", doc.VariableIDs)
+	}
+	if !slices.Equal(report.UnmappedPlaceholders, []string{"unknown"}) {
+		t.Errorf("unexpected unmapped placeholders: %v", report.UnmappedPlaceholders)
+	}
+}
+
+func TestExport_RoundTrip(t *testing.T) {
+	doc, _, err := Import(contract, testInjectables())
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	doc.Content.Content = append(doc.Content.Content,
+		portabledoc.Node{Type: portabledoc.NodeTypeParagraph, Attrs: map[string]any{"textAlign": "center"}, Content: []portabledoc.Node{textNode("Centered")}},
+		portabledoc.Node{Type: portabledoc.NodeTypeParagraph, Content: []portabledoc.Node{textNode("# not a heading * or_emphasis")}},
+	)
+
+	md, err := Export(doc)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if !strings.Contains(md, "```"+NodeFence) {
+		t.Errorf("expected the centered paragraph in a %s fence:\n%s", NodeFence, md)
+	}
+
+	reimported, _, err := Import(md, testInjectables())
+	if err != nil {
+		t.Fatalf("Import(Export()) error = %v", err)
+	}
+	if !sameContent(t, doc.Content, reimported.Content) {
+		t.Errorf("round trip changed the content.\nmarkdown:\n%s", md)
+	}
+	if !reflect.DeepEqual(doc.PageConfig, reimported.PageConfig) || doc.Meta.Title != reimported.Meta.Title {
+		t.Errorf("round trip changed the front-matter: %+v vs %+v", doc.PageConfig, reimported.PageConfig)
+	}
+}
+
+func TestExport_MarksAndEscapes(t *testing.T) {
+	bold := []portabledoc.Mark{{Type: portabledoc.MarkTypeBold}}
+	doc := &portabledoc.Document{
+		Meta: portabledoc.Meta{Title: "Marks"},
+		Content: &portabledoc.ProseMirrorDoc{Type: portabledoc.NodeTypeDoc, Content: []portabledoc.Node{
+			{Type: portabledoc.NodeTypeParagraph, Content: []portabledoc.Node{
+				{Type: portabledoc.NodeTypeText, Text: strPtr("Total "), Marks: bold},
+				{Type: portabledoc.NodeTypeText, Text: strPtr("due"), Marks: []portabledoc.Mark{{Type: portabledoc.MarkTypeBold}, {Type: portabledoc.MarkTypeItalic}}},
+				textNode(": 5 * 3 [net]"),
+			}},
+		}},
+	}
+
+	md, err := Export(doc)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if !strings.Contains(md, `**Total *due*** : 5 \* 3 \[net\]`) && !strings.Contains(md, `**Total *due***: 5 \* 3 \[net\]`) {
+		t.Errorf("unexpected inline markdown:\n%s", md)
+	}
+}
+
+func TestImport_Errors(t *testing.T) {
+	if _, _, err := Import("\n", nil); !errors.Is(err, entity.ErrEmptyTemplateImport) {
+		t.Errorf("expected ErrEmptyTemplateImport, got %v", err)
+	}
+	if _, _, err := Import("---\ntitle: [unclosed\n---\nBody", nil); !errors.Is(err, entity.ErrInvalidTemplateImport) {
+		t.Errorf("expected ErrInvalidTemplateImport, got %v", err)
+	}
+}
+
+// sameContent compares documents through JSON, where numbers are float64 on both sides.
+func sameContent(t *testing.T, a, b *portabledoc.ProseMirrorDoc) bool {
+	t.Helper()
+	var left, right any
+	for _, pair := range []struct {
+		doc *portabledoc.ProseMirrorDoc
+		out *any
+	}{{a, &left}, {b, &right}} {
+		data, err := json.Marshal(pair.doc)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		if err := json.Unmarshal(data, pair.out); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+	}
+	return reflect.DeepEqual(left, right)
+}
+
+func textNode(text string) portabledoc.Node {
+	return portabledoc.Node{Type: portabledoc.NodeTypeText, Text: &text}
+}
+
+func strPtr(s string) *string { return &s }
@@ -0,0 +1,322 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// maxSequenceNumberListLimit caps the numbers a sequence number listing returns.
+const maxSequenceNumberListLimit = 200
+
+// staleReservationAge is how long a number a gapless render reserved stays its own. A process
+// that dies mid-render never settles its number, so the next render takes it after this; it is
+// well above any render time.
+const staleReservationAge = time.Hour
+
+// NewNumberingSequenceService creates a new numbering sequence service.
+func NewNumberingSequenceService(
+	repo port.NumberingSequenceRepository,
+	workspaceRepo port.WorkspaceRepository,
+	docTypeRepo port.DocumentTypeRepository,
+	txManager port.TransactionManager,
+	settingsUC organizationuc.WorkspaceSettingsUseCase,
+) templateuc.NumberingSequenceUseCase {
+	return &NumberingSequenceService{
+		repo:          repo,
+		workspaceRepo: workspaceRepo,
+		docTypeRepo:   docTypeRepo,
+		txManager:     txManager,
+		settingsUC:    settingsUC,
+		now:           time.Now,
+	}
+}
+
+// NumberingSequenceService implements numbering sequence business logic.
+type NumberingSequenceService struct {
+	repo          port.NumberingSequenceRepository
+	workspaceRepo port.WorkspaceRepository
+	docTypeRepo   port.DocumentTypeRepository
+	txManager     port.TransactionManager
+	settingsUC    organizationuc.WorkspaceSettingsUseCase // can be nil; years are then UTC
+	now           func() time.Time
+}
+
+// ListSequences lists the numbering sequences of a workspace.
+func (s *NumberingSequenceService) ListSequences(ctx context.Context, workspaceID string) ([]*entity.NumberingSequence, error) {
+	return s.repo.FindByWorkspace(ctx, workspaceID)
+}
+
+// GetSequence retrieves a numbering sequence of a workspace.
+func (s *NumberingSequenceService) GetSequence(ctx context.Context, workspaceID, id string) (*entity.NumberingSequence, error) {
+	return s.repo.FindByID(ctx, workspaceID, id)
+}
+
+// CreateSequence creates the numbering sequence of a document type of the workspace's tenant.
+func (s *NumberingSequenceService) CreateSequence(ctx context.Context, cmd templateuc.CreateNumberingSequenceCommand) (*entity.NumberingSequence, error) {
+	if err := s.checkDocumentType(ctx, cmd.WorkspaceID, cmd.DocumentTypeID); err != nil {
+		return nil, err
+	}
+
+	startNumber := cmd.StartNumber
+	if startNumber == 0 {
+		startNumber = 1
+	}
+	seq := entity.NewNumberingSequence(cmd.WorkspaceID, cmd.DocumentTypeID, cmd.InjectableKey, startNumber, &cmd.CreatedBy)
+	seq.Prefix = cmd.Prefix
+	seq.Padding = cmd.Padding
+	seq.YearlyReset = cmd.YearlyReset
+	seq.Gapless = cmd.Gapless
+	if err := seq.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.FindByWorkspace(ctx, cmd.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("checking existing sequences: %w", err)
+	}
+	for _, other := range existing {
+		if other.DocumentTypeID == cmd.DocumentTypeID {
+			return nil, entity.ErrNumberingSequenceExists
+		}
+	}
+
+	id, err := s.repo.Create(ctx, seq)
+	if err != nil {
+		return nil, fmt.Errorf("creating numbering sequence: %w", err)
+	}
+	seq.ID = id
+
+	slog.InfoContext(ctx, "numbering sequence created",
+		slog.String("sequence_id", id),
+		slog.String("workspace_id", cmd.WorkspaceID),
+		slog.String("document_type_id", cmd.DocumentTypeID),
+		slog.Bool("gapless", seq.Gapless),
+		slog.String("created_by", cmd.CreatedBy),
+	)
+
+	return seq, nil
+}
+
+// UpdateSequence updates a numbering sequence. Its counter is left as is.
+func (s *NumberingSequenceService) UpdateSequence(ctx context.Context, cmd templateuc.UpdateNumberingSequenceCommand) (*entity.NumberingSequence, error) {
+	seq, err := s.repo.FindByID(ctx, cmd.WorkspaceID, cmd.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Numbers drawn before and after turning the yearly reset on or off could collide in the
+	// registry, so it is fixed once the sequence has drawn.
+	if seq.Year != 0 && seq.YearlyReset != cmd.YearlyReset {
+		return nil, entity.ErrSequenceResetLocked
+	}
+
+	seq.InjectableKey = cmd.InjectableKey
+	seq.Prefix = cmd.Prefix
+	seq.Padding = cmd.Padding
+	seq.YearlyReset = cmd.YearlyReset
+	seq.Gapless = cmd.Gapless
+	seq.IsActive = cmd.IsActive
+	if err := seq.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	seq.UpdatedAt = &now
+	if err := s.repo.Update(ctx, seq); err != nil {
+		return nil, fmt.Errorf("updating numbering sequence: %w", err)
+	}
+
+	slog.InfoContext(ctx, "numbering sequence updated",
+		slog.String("sequence_id", seq.ID),
+		slog.String("workspace_id", seq.WorkspaceID),
+		slog.Bool("is_active", seq.IsActive),
+	)
+
+	return seq, nil
+}
+
+// ListNumbers lists the numbers drawn from a sequence, newest first.
+func (s *NumberingSequenceService) ListNumbers(ctx context.Context, workspaceID, sequenceID string, limit int) ([]*entity.SequenceNumber, error) {
+	if _, err := s.repo.FindByID(ctx, workspaceID, sequenceID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > maxSequenceNumberListLimit {
+		limit = maxSequenceNumberListLimit
+	}
+	return s.repo.FindNumbers(ctx, sequenceID, limit)
+}
+
+// SequenceFor returns the active sequence of a document type in a workspace, or nil when it has none.
+func (s *NumberingSequenceService) SequenceFor(ctx context.Context, workspaceID, documentTypeID string) (*entity.NumberingSequence, error) {
+	seq, err := s.repo.FindActiveByDocumentType(ctx, workspaceID, documentTypeID)
+	if errors.Is(err, entity.ErrNumberingSequenceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return seq, nil
+}
+
+// Issue draws the next number of a sequence and runs render with it.
+func (s *NumberingSequenceService) Issue(ctx context.Context, cmd templateuc.IssueNumberCommand, render func(*entity.SequenceNumber) error) error {
+	// The workspace timezone decides the year of the number; it is read before locking the sequence.
+	now := s.now().In(WorkspaceLocation(ctx, s.settingsUC, cmd.Sequence.WorkspaceID)).Truncate(time.Microsecond)
+	if cmd.Sequence.Gapless {
+		return s.issueGapless(ctx, cmd, now, render)
+	}
+
+	var number *entity.SequenceNumber
+	err := s.txManager.WithinTx(ctx, func(txCtx context.Context) error {
+		var err error
+		number, err = s.draw(txCtx, cmd, now)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("drawing sequence number: %w", err)
+	}
+
+	if err := render(number); err != nil {
+		if voidErr := s.repo.UpdateNumberStatus(ctx, number.ID, entity.SequenceNumberVoided); voidErr != nil {
+			slog.WarnContext(ctx, "failed to void sequence number",
+				slog.String("sequence_id", number.SequenceID),
+				slog.String("number", number.Formatted),
+				slog.Any("error", voidErr),
+			)
+		}
+		return err
+	}
+	return nil
+}
+
+// issueGapless reserves a number as pending in a short transaction and runs render without
+// holding the lock of the sequence. The number is issued when render succeeds and released to the
+// next render when it fails.
+func (s *NumberingSequenceService) issueGapless(ctx context.Context, cmd templateuc.IssueNumberCommand, now time.Time, render func(*entity.SequenceNumber) error) error {
+	var number *entity.SequenceNumber
+	err := s.txManager.WithinTx(ctx, func(txCtx context.Context) error {
+		var err error
+		number, err = s.reserve(txCtx, cmd, now)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("reserving sequence number: %w", err)
+	}
+
+	if renderErr := render(number); renderErr != nil {
+		if err := s.repo.SettleNumber(ctx, number, entity.SequenceNumberReleased); err != nil {
+			slog.WarnContext(ctx, "failed to release sequence number",
+				slog.String("sequence_id", number.SequenceID),
+				slog.String("number", number.Formatted),
+				slog.Any("error", err),
+			)
+		}
+		return renderErr
+	}
+	if err := s.repo.SettleNumber(ctx, number, entity.SequenceNumberIssued); err != nil {
+		return fmt.Errorf("issuing sequence number %s: %w", number.Formatted, err)
+	}
+	return nil
+}
+
+// draw locks the sequence, takes its next number and stores both.
+func (s *NumberingSequenceService) draw(ctx context.Context, cmd templateuc.IssueNumberCommand, now time.Time) (*entity.SequenceNumber, error) {
+	seq, err := s.repo.Lock(ctx, cmd.Sequence.ID)
+	if err != nil {
+		return nil, err
+	}
+	number := s.newNumber(seq, cmd, now, entity.SequenceNumberIssued)
+	if err := s.store(ctx, seq, number); err != nil {
+		return nil, err
+	}
+	return number, nil
+}
+
+// reserve locks the sequence and reserves the lowest number of the year a failed render released
+// or a stale reservation holds; without one it takes the next number of the sequence.
+func (s *NumberingSequenceService) reserve(ctx context.Context, cmd templateuc.IssueNumberCommand, now time.Time) (*entity.SequenceNumber, error) {
+	seq, err := s.repo.Lock(ctx, cmd.Sequence.ID)
+	if err != nil {
+		return nil, err
+	}
+	number := s.newNumber(seq, cmd, now, entity.SequenceNumberPending)
+
+	reusable, err := s.repo.FindReusableNumber(ctx, seq.ID, number.Year, now.Add(-staleReservationAge))
+	if err != nil {
+		return nil, err
+	}
+	if reusable == nil {
+		if err := s.store(ctx, seq, number); err != nil {
+			return nil, err
+		}
+		return number, nil
+	}
+
+	// The sequence is not advanced: the number drawn above stays the next one.
+	number.ID = reusable.ID
+	number.Number = reusable.Number
+	number.Formatted = seq.Format(reusable.Number, now.Year())
+	if err := s.repo.ReassignNumber(ctx, number); err != nil {
+		return nil, err
+	}
+	return number, nil
+}
+
+func (s *NumberingSequenceService) newNumber(
+	seq *entity.NumberingSequence,
+	cmd templateuc.IssueNumberCommand,
+	now time.Time,
+	status entity.SequenceNumberStatus,
+) *entity.SequenceNumber {
+	number := seq.Draw(now)
+	number.Status = status
+	number.VersionID = cmd.VersionID
+	number.TemplateID = cmd.TemplateID
+	if cmd.DocumentID != "" {
+		number.DocumentID = &cmd.DocumentID
+	}
+	return number
+}
+
+func (s *NumberingSequenceService) store(ctx context.Context, seq *entity.NumberingSequence, number *entity.SequenceNumber) error {
+	if err := s.repo.Advance(ctx, seq); err != nil {
+		return err
+	}
+	id, err := s.repo.CreateNumber(ctx, number)
+	if err != nil {
+		return err
+	}
+	number.ID = id
+	return nil
+}
+
+// checkDocumentType checks that a document type belongs to the workspace's tenant or is a
+// system type every tenant uses.
+func (s *NumberingSequenceService) checkDocumentType(ctx context.Context, workspaceID, documentTypeID string) error {
+	workspace, err := s.workspaceRepo.FindByID(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("finding workspace: %w", err)
+	}
+	docType, err := s.docTypeRepo.FindByID(ctx, documentTypeID)
+	if err != nil {
+		return fmt.Errorf("finding document type: %w", err)
+	}
+	if workspace.TenantID != nil && docType.TenantID == *workspace.TenantID {
+		return nil
+	}
+	isSys, err := s.docTypeRepo.IsSysTenant(ctx, docType.TenantID)
+	if err != nil {
+		return fmt.Errorf("checking document type tenant: %w", err)
+	}
+	if !isSys {
+		return entity.ErrDocumentTypeNotFound
+	}
+	return nil
+}
@@ -0,0 +1,371 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+func TestNumberingSequenceService_IssueStoresNumber(t *testing.T) {
+	repo := newFakeSequenceRepo(entity.NumberingSequence{ID: "seq-1", Prefix: "F-", Padding: 3, StartNumber: 1, NextNumber: 7})
+	svc := newTestSequenceService(repo)
+
+	var rendered string
+	err := svc.Issue(context.Background(), issueCommand(repo, "seq-1"), func(n *entity.SequenceNumber) error {
+		rendered = n.Formatted
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "F-007", rendered)
+	require.Len(t, repo.numbers, 1)
+	assert.Equal(t, entity.SequenceNumberIssued, repo.numbers[0].Status)
+	assert.Equal(t, "v-1", repo.numbers[0].VersionID)
+	assert.Equal(t, "doc-1", *repo.numbers[0].DocumentID)
+	assert.Equal(t, int64(8), repo.sequences["seq-1"].NextNumber)
+}
+
+func TestNumberingSequenceService_IssueVoidsNumberOfFailedRender(t *testing.T) {
+	repo := newFakeSequenceRepo(entity.NumberingSequence{ID: "seq-1", StartNumber: 1, NextNumber: 1})
+	svc := newTestSequenceService(repo)
+	renderErr := errors.New("typst failed")
+
+	err := svc.Issue(context.Background(), issueCommand(repo, "seq-1"), func(*entity.SequenceNumber) error { return renderErr })
+	require.ErrorIs(t, err, renderErr)
+
+	var next string
+	require.NoError(t, svc.Issue(context.Background(), issueCommand(repo, "seq-1"), func(n *entity.SequenceNumber) error {
+		next = n.Formatted
+		return nil
+	}))
+
+	assert.Equal(t, "2", next, "a voided number is never drawn again")
+	require.Len(t, repo.numbers, 2)
+	assert.Equal(t, entity.SequenceNumberVoided, repo.numbers[0].Status)
+	assert.Equal(t, entity.SequenceNumberIssued, repo.numbers[1].Status)
+}
+
+func TestNumberingSequenceService_GaplessIssueTakesNumberBack(t *testing.T) {
+	repo := newFakeSequenceRepo(entity.NumberingSequence{ID: "seq-1", Gapless: true, StartNumber: 1, NextNumber: 1})
+	svc := newTestSequenceService(repo)
+	renderErr := errors.New("typst failed")
+
+	err := svc.Issue(context.Background(), issueCommand(repo, "seq-1"), func(n *entity.SequenceNumber) error {
+		assert.Equal(t, entity.SequenceNumberPending, repo.numbers[0].Status, "the number is reserved while rendering")
+		return renderErr
+	})
+	require.ErrorIs(t, err, renderErr)
+	require.Len(t, repo.numbers, 1)
+	assert.Equal(t, entity.SequenceNumberReleased, repo.numbers[0].Status)
+
+	var next string
+	require.NoError(t, svc.Issue(context.Background(), issueCommand(repo, "seq-1"), func(n *entity.SequenceNumber) error {
+		next = n.Formatted
+		return nil
+	}))
+	assert.Equal(t, "1", next, "the number of the failed render goes to the next one")
+	require.Len(t, repo.numbers, 1)
+	assert.Equal(t, entity.SequenceNumberIssued, repo.numbers[0].Status)
+	assert.Equal(t, int64(2), repo.sequences["seq-1"].NextNumber)
+}
+
+func TestNumberingSequenceService_GaplessRendersRunConcurrently(t *testing.T) {
+	repo := newFakeSequenceRepo(entity.NumberingSequence{ID: "seq-1", Gapless: true, StartNumber: 1, NextNumber: 1})
+	svc := newTestSequenceService(repo)
+	const renders = 8
+
+	// Every render waits until all of them hold a number, which deadlocks if a render holds the
+	// lock of the sequence.
+	var started sync.WaitGroup
+	started.Add(renders)
+	allStarted := make(chan struct{})
+	go func() {
+		started.Wait()
+		close(allStarted)
+	}()
+
+	cmd := issueCommand(repo, "seq-1")
+	var wg sync.WaitGroup
+	errs := make([]error, renders)
+	for i := range renders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = svc.Issue(context.Background(), cmd, func(*entity.SequenceNumber) error {
+				started.Done()
+				select {
+				case <-allStarted:
+				case <-time.After(5 * time.Second):
+					return errors.New("renders ran one at a time")
+				}
+				if i%2 == 1 {
+					return errors.New("typst failed")
+				}
+				return nil
+			})
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if i%2 == 0 {
+			require.NoError(t, err)
+		}
+	}
+	assert.Equal(t, map[entity.SequenceNumberStatus]int{entity.SequenceNumberIssued: 4, entity.SequenceNumberReleased: 4},
+		repo.statusCounts())
+	assert.Equal(t, []int64{1, 2, 3, 4, 5, 6, 7, 8}, repo.sortedNumbers(), "every render reserved its own number")
+
+	// The released numbers go to the next renders, lowest first, without drawing new ones.
+	for range 4 {
+		require.NoError(t, svc.Issue(context.Background(), issueCommand(repo, "seq-1"), func(*entity.SequenceNumber) error { return nil }))
+	}
+	assert.Equal(t, map[entity.SequenceNumberStatus]int{entity.SequenceNumberIssued: 8}, repo.statusCounts())
+	assert.Equal(t, int64(9), repo.sequences["seq-1"].NextNumber)
+}
+
+func TestNumberingSequenceService_GaplessReassignsStaleReservation(t *testing.T) {
+	repo := newFakeSequenceRepo(entity.NumberingSequence{ID: "seq-1", Gapless: true, StartNumber: 1, NextNumber: 1})
+	svc := newTestSequenceService(repo)
+	start := svc.now()
+
+	err := svc.Issue(context.Background(), issueCommand(repo, "seq-1"), func(*entity.SequenceNumber) error {
+		// The render outlives its reservation and another render takes the number.
+		svc.now = func() time.Time { return start.Add(staleReservationAge + time.Minute) }
+		require.NoError(t, svc.Issue(context.Background(), issueCommand(repo, "seq-1"), func(n *entity.SequenceNumber) error {
+			assert.Equal(t, "1", n.Formatted)
+			return nil
+		}))
+		return nil
+	})
+
+	assert.ErrorIs(t, err, entity.ErrSequenceNumberReassigned)
+	require.Len(t, repo.numbers, 1)
+	assert.Equal(t, entity.SequenceNumberIssued, repo.numbers[0].Status)
+}
+
+func TestNumberingSequenceService_YearInWorkspaceTimezone(t *testing.T) {
+	repo := newFakeSequenceRepo(entity.NumberingSequence{
+		ID: "seq-1", WorkspaceID: "ws-1", Prefix: "{YYYY}-", YearlyReset: true, StartNumber: 1, NextNumber: 40, Year: 2026,
+	})
+	svc := newTestSequenceService(repo)
+	svc.settingsUC = &fakeScheduleSettings{timezone: "Pacific/Auckland"}
+	svc.now = func() time.Time { return time.Date(2026, 12, 31, 12, 0, 0, 0, time.UTC) } // 2027-01-01 01:00 in Auckland
+
+	var rendered string
+	require.NoError(t, svc.Issue(context.Background(), issueCommand(repo, "seq-1"), func(n *entity.SequenceNumber) error {
+		rendered = n.Formatted
+		return nil
+	}))
+
+	assert.Equal(t, "2027-1", rendered, "the workspace is already in 2027, so numbering restarts")
+}
+
+func TestNumberingSequenceService_UpdateKeepsYearlyResetOnceDrawn(t *testing.T) {
+	repo := newFakeSequenceRepo(entity.NumberingSequence{
+		ID: "seq-1", WorkspaceID: "ws-1", DocumentTypeID: "dt-1", InjectableKey: "invoice_number",
+		StartNumber: 1, NextNumber: 5, Year: 2026, IsActive: true,
+	})
+	svc := newTestSequenceService(repo)
+
+	_, err := svc.UpdateSequence(context.Background(), templateuc.UpdateNumberingSequenceCommand{
+		ID: "seq-1", WorkspaceID: "ws-1", InjectableKey: "invoice_number", YearlyReset: true, IsActive: true,
+	})
+
+	assert.ErrorIs(t, err, entity.ErrSequenceResetLocked)
+}
+
+func newTestSequenceService(repo *fakeSequenceRepo) *NumberingSequenceService {
+	return &NumberingSequenceService{
+		repo:      repo,
+		txManager: &fakeSequenceTx{repo: repo},
+		now:       func() time.Time { return time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC) },
+	}
+}
+
+func issueCommand(repo *fakeSequenceRepo, id string) templateuc.IssueNumberCommand {
+	seq := *repo.sequences[id]
+	return templateuc.IssueNumberCommand{Sequence: &seq, VersionID: "v-1", TemplateID: "t-1", DocumentID: "doc-1"}
+}
+
+// fakeSequenceTx runs one transaction at a time, as the lock every issue takes on the sequence
+// does, and rolls the fake repository back when fn fails.
+type fakeSequenceTx struct {
+	mu   sync.Mutex
+	repo *fakeSequenceRepo
+}
+
+func (f *fakeSequenceTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.repo.mu.Lock()
+	sequences := make(map[string]*entity.NumberingSequence, len(f.repo.sequences))
+	for id, seq := range f.repo.sequences {
+		copied := *seq
+		sequences[id] = &copied
+	}
+	numbers := make([]*entity.SequenceNumber, len(f.repo.numbers))
+	for i, n := range f.repo.numbers {
+		copied := *n
+		numbers[i] = &copied
+	}
+	f.repo.mu.Unlock()
+
+	if err := fn(ctx); err != nil {
+		f.repo.mu.Lock()
+		f.repo.sequences, f.repo.numbers = sequences, numbers
+		f.repo.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+type fakeSequenceRepo struct {
+	port.NumberingSequenceRepository
+	mu        sync.Mutex
+	sequences map[string]*entity.NumberingSequence
+	numbers   []*entity.SequenceNumber
+}
+
+func newFakeSequenceRepo(seqs ...entity.NumberingSequence) *fakeSequenceRepo {
+	repo := &fakeSequenceRepo{sequences: map[string]*entity.NumberingSequence{}}
+	for _, seq := range seqs {
+		repo.sequences[seq.ID] = &seq
+	}
+	return repo
+}
+
+func (f *fakeSequenceRepo) FindByID(_ context.Context, workspaceID, id string) (*entity.NumberingSequence, error) {
+	seq, ok := f.sequences[id]
+	if !ok || seq.WorkspaceID != workspaceID {
+		return nil, entity.ErrNumberingSequenceNotFound
+	}
+	copied := *seq
+	return &copied, nil
+}
+
+func (f *fakeSequenceRepo) FindActiveByDocumentType(_ context.Context, workspaceID, documentTypeID string) (*entity.NumberingSequence, error) {
+	for _, seq := range f.sequences {
+		if seq.WorkspaceID == workspaceID && seq.DocumentTypeID == documentTypeID && seq.IsActive {
+			copied := *seq
+			return &copied, nil
+		}
+	}
+	return nil, entity.ErrNumberingSequenceNotFound
+}
+
+func (f *fakeSequenceRepo) Update(_ context.Context, seq *entity.NumberingSequence) error {
+	copied := *seq
+	f.sequences[seq.ID] = &copied
+	return nil
+}
+
+func (f *fakeSequenceRepo) Lock(_ context.Context, id string) (*entity.NumberingSequence, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	copied := *f.sequences[id]
+	return &copied, nil
+}
+
+func (f *fakeSequenceRepo) Advance(_ context.Context, seq *entity.NumberingSequence) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sequences[seq.ID].NextNumber, f.sequences[seq.ID].Year = seq.NextNumber, seq.Year
+	return nil
+}
+
+func (f *fakeSequenceRepo) CreateNumber(_ context.Context, n *entity.SequenceNumber) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, other := range f.numbers {
+		if other.SequenceID == n.SequenceID && other.Year == n.Year && other.Number == n.Number {
+			return "", errors.New("duplicate sequence number")
+		}
+	}
+	copied := *n
+	copied.ID = "num-" + strconv.Itoa(len(f.numbers)+1)
+	f.numbers = append(f.numbers, &copied)
+	return copied.ID, nil
+}
+
+func (f *fakeSequenceRepo) UpdateNumberStatus(_ context.Context, id string, status entity.SequenceNumberStatus) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, n := range f.numbers {
+		if n.ID == id {
+			n.Status = status
+		}
+	}
+	return nil
+}
+
+func (f *fakeSequenceRepo) FindReusableNumber(_ context.Context, sequenceID string, year int, staleBefore time.Time) (*entity.SequenceNumber, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var found *entity.SequenceNumber
+	for _, n := range f.numbers {
+		reusable := n.Status == entity.SequenceNumberReleased ||
+			(n.Status == entity.SequenceNumberPending && n.IssuedAt.Before(staleBefore))
+		if n.SequenceID == sequenceID && n.Year == year && reusable && (found == nil || n.Number < found.Number) {
+			found = n
+		}
+	}
+	if found == nil {
+		return nil, nil
+	}
+	copied := *found
+	return &copied, nil
+}
+
+func (f *fakeSequenceRepo) ReassignNumber(_ context.Context, number *entity.SequenceNumber) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, n := range f.numbers {
+		if n.ID == number.ID {
+			copied := *number
+			f.numbers[i] = &copied
+		}
+	}
+	return nil
+}
+
+func (f *fakeSequenceRepo) SettleNumber(_ context.Context, number *entity.SequenceNumber, status entity.SequenceNumberStatus) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, n := range f.numbers {
+		if n.ID == number.ID && n.Status == entity.SequenceNumberPending && n.IssuedAt.Equal(number.IssuedAt) {
+			n.Status = status
+			return nil
+		}
+	}
+	return entity.ErrSequenceNumberReassigned
+}
+
+func (f *fakeSequenceRepo) statusCounts() map[entity.SequenceNumberStatus]int {
+	counts := map[entity.SequenceNumberStatus]int{}
+	for _, n := range f.numbers {
+		counts[n.Status]++
+	}
+	return counts
+}
+
+func (f *fakeSequenceRepo) sortedNumbers() []int64 {
+	numbers := make([]int64, len(f.numbers))
+	for i, n := range f.numbers {
+		numbers[i] = n.Number
+	}
+	slices.Sort(numbers)
+	return numbers
+}
@@ -0,0 +1,97 @@
+package template
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// DefaultSignedURLTTL is how long the URL of a persisted render works when no TTL is configured.
+const DefaultSignedURLTTL = time.Hour
+
+// NewPersistedRenderService creates a new persisted render service.
+// storage may be nil, in which case persisting fails with ErrObjectStorageNotConfigured.
+// keyPrefix is prepended to every object key.
+func NewPersistedRenderService(storage port.ObjectStorage, keyPrefix string, urlTTL time.Duration) templateuc.PersistedRenderUseCase {
+	if urlTTL <= 0 {
+		urlTTL = DefaultSignedURLTTL
+	}
+	return &PersistedRenderService{
+		storage:   storage,
+		keyPrefix: strings.Trim(keyPrefix, "/"),
+		urlTTL:    urlTTL,
+	}
+}
+
+// PersistedRenderService implements persisting rendered PDFs to object storage.
+type PersistedRenderService struct {
+	storage   port.ObjectStorage
+	keyPrefix string
+	urlTTL    time.Duration
+}
+
+// PersistRender writes the PDF under {prefix}/renders/{tenant}/{workspace}/{yyyy}/{mm}/{uuid}.pdf
+// and returns a signed URL to it.
+func (s *PersistedRenderService) PersistRender(ctx context.Context, cmd templateuc.PersistRenderCommand) (*templateuc.PersistedRender, error) {
+	if s.storage == nil {
+		return nil, entity.ErrObjectStorageNotConfigured
+	}
+
+	now := time.Now().UTC()
+	key := path.Join(s.keyPrefix, "renders", strings.ToLower(cmd.TenantCode), strings.ToLower(cmd.WorkspaceCode),
+		now.Format("2006"), now.Format("01"), uuid.NewString()+".pdf")
+
+	err := s.storage.Put(ctx, &port.StorageObject{
+		Key:         key,
+		ContentType: "application/pdf",
+		Size:        int64(len(cmd.Result.PDF)),
+		Body:        bytes.NewReader(cmd.Result.PDF),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("persisting render: %w", err)
+	}
+
+	url, err := s.storage.SignedURL(ctx, key, s.urlTTL)
+	if err != nil {
+		return nil, fmt.Errorf("signing render URL: %w", err)
+	}
+
+	slog.InfoContext(ctx, "render persisted",
+		slog.String("key", key),
+		slog.String("tenant_code", cmd.TenantCode),
+		slog.String("workspace_code", cmd.WorkspaceCode),
+		slog.Int("size_bytes", len(cmd.Result.PDF)),
+	)
+
+	return &templateuc.PersistedRender{
+		Key:       key,
+		URL:       url,
+		ExpiresAt: now.Add(s.urlTTL),
+		Filename:  cmd.Result.Filename,
+		SizeBytes: int64(len(cmd.Result.PDF)),
+		PageCount: cmd.Result.PageCount,
+	}, nil
+}
+
+// OpenSignedObject checks a signed URL served by the engine and opens the object it points to.
+func (s *PersistedRenderService) OpenSignedObject(ctx context.Context, key, expires, signature string) (io.ReadCloser, error) {
+	server, ok := s.storage.(port.SignedObjectServer)
+	if !ok {
+		return nil, entity.ErrInvalidSignedURL
+	}
+	if err := server.VerifySignedURL(key, expires, signature); err != nil {
+		return nil, err
+	}
+	return s.storage.Get(ctx, key)
+}
@@ -0,0 +1,64 @@
+package template
+
+import (
+	"context"
+	"io"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+type memoryObjectStorage struct {
+	objects map[string][]byte
+}
+
+func (m *memoryObjectStorage) Put(_ context.Context, obj *port.StorageObject) error {
+	data, err := io.ReadAll(obj.Body)
+	m.objects[obj.Key] = data
+	return err
+}
+
+func (m *memoryObjectStorage) Get(context.Context, string) (io.ReadCloser, error) {
+	return nil, entity.ErrObjectNotFound
+}
+
+func (m *memoryObjectStorage) Delete(context.Context, string) error { return nil }
+
+func (m *memoryObjectStorage) SignedURL(_ context.Context, key string, _ time.Duration) (string, error) {
+	return "https://objects.example.com/" + key, nil
+}
+
+func TestPersistRender(t *testing.T) {
+	storage := &memoryObjectStorage{objects: map[string][]byte{}}
+	svc := NewPersistedRenderService(storage, "/forge/", 0)
+
+	persisted, err := svc.PersistRender(context.Background(), templateuc.PersistRenderCommand{
+		TenantCode:    "ACME",
+		WorkspaceCode: "BILLING",
+		Result:        &port.RenderPreviewResult{PDF: []byte("%PDF-1.7"), Filename: "invoice.pdf", PageCount: 2},
+	})
+	require.NoError(t, err)
+
+	assert.Regexp(t, regexp.MustCompile(`^forge/renders/acme/billing/\d{4}/\d{2}/[0-9a-f-]{36}\.pdf$`), persisted.Key)
+	assert.Equal(t, "https://objects.example.com/"+persisted.Key, persisted.URL)
+	assert.Equal(t, []byte("%PDF-1.7"), storage.objects[persisted.Key])
+	assert.EqualValues(t, 8, persisted.SizeBytes)
+	assert.WithinDuration(t, time.Now().Add(DefaultSignedURLTTL), persisted.ExpiresAt, time.Minute)
+}
+
+func TestPersistRenderWithoutStorage(t *testing.T) {
+	svc := NewPersistedRenderService(nil, "", time.Hour)
+
+	_, err := svc.PersistRender(context.Background(), templateuc.PersistRenderCommand{Result: &port.RenderPreviewResult{}})
+	assert.ErrorIs(t, err, entity.ErrObjectStorageNotConfigured)
+
+	_, err = svc.OpenSignedObject(context.Background(), "renders/x.pdf", "1", "sig")
+	assert.ErrorIs(t, err, entity.ErrInvalidSignedURL)
+}
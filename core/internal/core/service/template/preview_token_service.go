@@ -0,0 +1,189 @@
+package template
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// NewPreviewTokenService creates a new preview token service.
+func NewPreviewTokenService(
+	tokenRepo port.PreviewTokenRepository,
+	versionRepo port.TemplateVersionRepository,
+	templateRepo port.TemplateRepository,
+	workspaceRepo port.WorkspaceRepository,
+) templateuc.PreviewTokenUseCase {
+	return &PreviewTokenService{
+		tokenRepo:     tokenRepo,
+		versionRepo:   versionRepo,
+		templateRepo:  templateRepo,
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+// PreviewTokenService implements version preview token business logic.
+type PreviewTokenService struct {
+	tokenRepo     port.PreviewTokenRepository
+	versionRepo   port.TemplateVersionRepository
+	templateRepo  port.TemplateRepository
+	workspaceRepo port.WorkspaceRepository
+}
+
+// CreatePreviewToken creates a preview token for a version of the workspace.
+func (s *PreviewTokenService) CreatePreviewToken(ctx context.Context, cmd templateuc.CreatePreviewTokenCommand) (*entity.PreviewToken, string, error) {
+	ttl := cmd.TTL
+	if ttl == 0 {
+		ttl = entity.PreviewTokenDefaultTTL
+	}
+	if ttl < time.Hour || ttl > entity.PreviewTokenMaxTTL {
+		return nil, "", entity.ErrInvalidPreviewTTL
+	}
+
+	if err := s.checkVersionInWorkspace(ctx, cmd.WorkspaceID, cmd.VersionID); err != nil {
+		return nil, "", err
+	}
+
+	token, tokenHash, err := newShareToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	previewToken := entity.NewPreviewToken(cmd.WorkspaceID, cmd.VersionID, cmd.Label, cmd.Watermark, &cmd.CreatedBy, ttl)
+	previewToken.ID = uuid.NewString()
+	previewToken.TokenHash = tokenHash
+
+	if err := previewToken.Validate(); err != nil {
+		return nil, "", fmt.Errorf("validating preview token: %w", err)
+	}
+
+	id, err := s.tokenRepo.Create(ctx, previewToken)
+	if err != nil {
+		return nil, "", fmt.Errorf("creating preview token: %w", err)
+	}
+	previewToken.ID = id
+
+	slog.InfoContext(ctx, "preview token created",
+		slog.String("preview_token_id", id),
+		slog.String("version_id", cmd.VersionID),
+		slog.Time("expires_at", previewToken.ExpiresAt),
+	)
+
+	return previewToken, token, nil
+}
+
+// ListPreviewTokens lists the preview tokens of a version of the workspace.
+func (s *PreviewTokenService) ListPreviewTokens(ctx context.Context, workspaceID, versionID string) ([]*entity.PreviewToken, error) {
+	if err := s.checkVersionInWorkspace(ctx, workspaceID, versionID); err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.tokenRepo.FindByVersion(ctx, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("listing preview tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// RevokePreviewToken revokes a preview token. Revoking an already revoked token succeeds.
+func (s *PreviewTokenService) RevokePreviewToken(ctx context.Context, workspaceID, versionID, tokenID string) error {
+	token, err := s.tokenRepo.FindByID(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+	if token.WorkspaceID != workspaceID || token.VersionID != versionID {
+		return entity.ErrPreviewTokenNotFound
+	}
+	if token.IsRevoked() {
+		return nil
+	}
+
+	token.Revoke()
+	if err := s.tokenRepo.Revoke(ctx, token); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "preview token revoked",
+		slog.String("preview_token_id", tokenID),
+		slog.String("version_id", versionID),
+	)
+	return nil
+}
+
+// ResolvePreviewToken checks a raw token and loads the version it grants access to.
+func (s *PreviewTokenService) ResolvePreviewToken(ctx context.Context, token string) (*templateuc.PreviewAccess, error) {
+	previewToken, err := s.tokenRepo.FindByTokenHash(ctx, hashShareToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if err := previewToken.CanUse(); err != nil {
+		return nil, err
+	}
+
+	version, err := s.versionRepo.FindByIDWithDetails(ctx, previewToken.VersionID)
+	if err != nil {
+		return nil, fmt.Errorf("finding version: %w", err)
+	}
+
+	workspace, err := s.workspaceRepo.FindByID(ctx, previewToken.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("finding workspace: %w", err)
+	}
+
+	if err := s.tokenRepo.TouchLastUsed(ctx, previewToken.ID); err != nil {
+		slog.WarnContext(ctx, "failed to record preview token use",
+			slog.String("preview_token_id", previewToken.ID),
+			slog.Any("error", err),
+		)
+	}
+
+	access := &templateuc.PreviewAccess{Token: previewToken, Version: version}
+	if workspace.TenantID != nil {
+		access.TenantID = *workspace.TenantID
+	}
+	return access, nil
+}
+
+// checkVersionInWorkspace returns ErrVersionNotFound unless the version's template belongs to the workspace.
+func (s *PreviewTokenService) checkVersionInWorkspace(ctx context.Context, workspaceID, versionID string) error {
+	version, err := s.versionRepo.FindMetadataByID(ctx, versionID)
+	if err != nil {
+		return fmt.Errorf("finding version: %w", err)
+	}
+
+	template, err := s.templateRepo.FindByID(ctx, version.TemplateID)
+	if err != nil {
+		return fmt.Errorf("finding template: %w", err)
+	}
+	if template.WorkspaceID != workspaceID {
+		return entity.ErrVersionNotFound
+	}
+	return nil
+}
+
+// newShareToken returns a random URL-safe token and its SHA-256 hash.
+// Used for preview links and hosted document links.
+func newShareToken() (token, tokenHash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("generating share token: %w", err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashShareToken(token), nil
+}
+
+// hashShareToken hashes a raw share token for storage and lookup.
+func hashShareToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
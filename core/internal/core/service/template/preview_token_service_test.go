@@ -0,0 +1,245 @@
+package template
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+func TestCreatePreviewToken_TTL(t *testing.T) {
+	tests := []struct {
+		name    string
+		ttl     time.Duration
+		wantTTL time.Duration
+		wantErr error
+	}{
+		{name: "zero uses the default", ttl: 0, wantTTL: entity.PreviewTokenDefaultTTL},
+		{name: "one hour", ttl: time.Hour, wantTTL: time.Hour},
+		{name: "thirty days", ttl: entity.PreviewTokenMaxTTL, wantTTL: entity.PreviewTokenMaxTTL},
+		{name: "below one hour", ttl: time.Hour - time.Second, wantErr: entity.ErrInvalidPreviewTTL},
+		{name: "negative", ttl: -time.Hour, wantErr: entity.ErrInvalidPreviewTTL},
+		{name: "above thirty days", ttl: entity.PreviewTokenMaxTTL + time.Second, wantErr: entity.ErrInvalidPreviewTTL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens := &fakePreviewTokenRepo{}
+			svc := newTestPreviewTokenService(tokens)
+
+			before := time.Now().UTC()
+			got, raw, err := svc.CreatePreviewToken(context.Background(), templateuc.CreatePreviewTokenCommand{
+				WorkspaceID: "ws-1",
+				VersionID:   "ver-1",
+				TTL:         tt.ttl,
+				CreatedBy:   "user-1",
+			})
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, tokens.created, "an invalid TTL must not store a token")
+				return
+			}
+			require.NoError(t, err)
+			assert.WithinDuration(t, before.Add(tt.wantTTL), got.ExpiresAt, time.Second)
+			assert.NotEmpty(t, raw)
+			assert.Equal(t, hashShareToken(raw), got.TokenHash, "only the hash of the token is stored")
+			require.Len(t, tokens.created, 1)
+		})
+	}
+}
+
+func TestCreatePreviewToken_VersionOfAnotherWorkspace(t *testing.T) {
+	tokens := &fakePreviewTokenRepo{}
+	svc := newTestPreviewTokenService(tokens)
+
+	_, _, err := svc.CreatePreviewToken(context.Background(), templateuc.CreatePreviewTokenCommand{
+		WorkspaceID: "ws-2",
+		VersionID:   "ver-1",
+		CreatedBy:   "user-1",
+	})
+
+	require.ErrorIs(t, err, entity.ErrVersionNotFound)
+	assert.Empty(t, tokens.created)
+}
+
+func TestRevokePreviewToken(t *testing.T) {
+	tests := []struct {
+		name        string
+		workspaceID string
+		versionID   string
+		tokenID     string
+		revoked     bool
+		wantErr     error
+		wantRevoke  bool
+	}{
+		{name: "token of the version", workspaceID: "ws-1", versionID: "ver-1", tokenID: "tok-1", wantRevoke: true},
+		{name: "already revoked", workspaceID: "ws-1", versionID: "ver-1", tokenID: "tok-1", revoked: true},
+		{name: "token of another workspace", workspaceID: "ws-2", versionID: "ver-1", tokenID: "tok-1", wantErr: entity.ErrPreviewTokenNotFound},
+		{name: "token of another version", workspaceID: "ws-1", versionID: "ver-2", tokenID: "tok-1", wantErr: entity.ErrPreviewTokenNotFound},
+		{name: "unknown token", workspaceID: "ws-1", versionID: "ver-1", tokenID: "missing", wantErr: entity.ErrPreviewTokenNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := entity.NewPreviewToken("ws-1", "ver-1", nil, nil, nil, time.Hour)
+			token.ID = "tok-1"
+			if tt.revoked {
+				token.Revoke()
+			}
+			tokens := &fakePreviewTokenRepo{tokens: []*entity.PreviewToken{token}}
+			svc := newTestPreviewTokenService(tokens)
+
+			err := svc.RevokePreviewToken(context.Background(), tt.workspaceID, tt.versionID, tt.tokenID)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, token.RevokedAt, "a refused revocation must leave the token usable")
+				assert.Empty(t, tokens.revoked)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, token.RevokedAt)
+			if tt.wantRevoke {
+				assert.Equal(t, []string{"tok-1"}, tokens.revoked)
+			} else {
+				assert.Empty(t, tokens.revoked)
+			}
+		})
+	}
+}
+
+func TestResolvePreviewToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		expired bool
+		revoked bool
+		wantErr error
+	}{
+		{name: "valid", token: "secret"},
+		{name: "expired", token: "secret", expired: true, wantErr: entity.ErrPreviewTokenExpired},
+		{name: "revoked", token: "secret", revoked: true, wantErr: entity.ErrPreviewTokenExpired},
+		{name: "unknown", token: "guess", wantErr: entity.ErrPreviewTokenNotFound},
+		{name: "the stored hash is not a token", token: hashShareToken("secret"), wantErr: entity.ErrPreviewTokenNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := entity.NewPreviewToken("ws-1", "ver-1", nil, nil, nil, time.Hour)
+			token.ID = "tok-1"
+			token.TokenHash = hashShareToken("secret")
+			if tt.expired {
+				token.ExpiresAt = time.Now().Add(-time.Minute)
+			}
+			if tt.revoked {
+				token.Revoke()
+			}
+			tokens := &fakePreviewTokenRepo{tokens: []*entity.PreviewToken{token}}
+			svc := newTestPreviewTokenService(tokens)
+
+			access, err := svc.ResolvePreviewToken(context.Background(), tt.token)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, tokens.used, "a refused token must not be marked as used")
+				return
+			}
+			require.NoError(t, err)
+			assert.Same(t, token, access.Token)
+			assert.Equal(t, "ver-1", access.Version.ID)
+			assert.Equal(t, "tenant-1", access.TenantID)
+			assert.Equal(t, []string{"tok-1"}, tokens.used)
+		})
+	}
+}
+
+func newTestPreviewTokenService(tokens *fakePreviewTokenRepo) templateuc.PreviewTokenUseCase {
+	tenantID := "tenant-1"
+	return NewPreviewTokenService(
+		tokens,
+		&fakePreviewVersionRepo{version: &entity.TemplateVersion{ID: "ver-1", TemplateID: "tpl-1"}},
+		&fakeFailureTemplateRepo{templates: map[string]*entity.Template{"tpl-1": {ID: "tpl-1", WorkspaceID: "ws-1"}}},
+		&fakePreviewWorkspaceRepo{workspace: &entity.Workspace{ID: "ws-1", TenantID: &tenantID}},
+	)
+}
+
+// fakePreviewTokenRepo looks tokens up by ID and hash like the database does.
+type fakePreviewTokenRepo struct {
+	port.PreviewTokenRepository
+	tokens  []*entity.PreviewToken
+	created []*entity.PreviewToken
+	revoked []string
+	used    []string
+}
+
+func (f *fakePreviewTokenRepo) Create(_ context.Context, token *entity.PreviewToken) (string, error) {
+	f.created = append(f.created, token)
+	f.tokens = append(f.tokens, token)
+	return token.ID, nil
+}
+
+func (f *fakePreviewTokenRepo) FindByID(_ context.Context, id string) (*entity.PreviewToken, error) {
+	for _, t := range f.tokens {
+		if t.ID == id {
+			return t, nil
+		}
+	}
+	return nil, entity.ErrPreviewTokenNotFound
+}
+
+func (f *fakePreviewTokenRepo) FindByTokenHash(_ context.Context, tokenHash string) (*entity.PreviewToken, error) {
+	for _, t := range f.tokens {
+		if t.TokenHash == tokenHash {
+			return t, nil
+		}
+	}
+	return nil, entity.ErrPreviewTokenNotFound
+}
+
+func (f *fakePreviewTokenRepo) Revoke(_ context.Context, token *entity.PreviewToken) error {
+	f.revoked = append(f.revoked, token.ID)
+	return nil
+}
+
+func (f *fakePreviewTokenRepo) TouchLastUsed(_ context.Context, id string) error {
+	f.used = append(f.used, id)
+	return nil
+}
+
+type fakePreviewVersionRepo struct {
+	port.TemplateVersionRepository
+	version *entity.TemplateVersion
+}
+
+func (f *fakePreviewVersionRepo) FindMetadataByID(_ context.Context, id string) (*entity.TemplateVersion, error) {
+	if id != f.version.ID {
+		return nil, entity.ErrVersionNotFound
+	}
+	return f.version, nil
+}
+
+func (f *fakePreviewVersionRepo) FindByIDWithDetails(_ context.Context, id string) (*entity.TemplateVersionWithDetails, error) {
+	if id != f.version.ID {
+		return nil, entity.ErrVersionNotFound
+	}
+	return &entity.TemplateVersionWithDetails{TemplateVersion: *f.version}, nil
+}
+
+type fakePreviewWorkspaceRepo struct {
+	port.WorkspaceRepository
+	workspace *entity.Workspace
+}
+
+func (f *fakePreviewWorkspaceRepo) FindByID(_ context.Context, id string) (*entity.Workspace, error) {
+	if id != f.workspace.ID {
+		return nil, entity.ErrWorkspaceNotFound
+	}
+	return f.workspace, nil
+}
@@ -0,0 +1,111 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+const (
+	// RenderOperation is the InjectorContext operation of a render.
+	RenderOperation = "render"
+
+	// EstimateOperation is the InjectorContext operation of the dry compile of a render estimate.
+	EstimateOperation = "estimate"
+)
+
+// RenderEstimationOptions configures render estimates.
+type RenderEstimationOptions struct {
+	// Stats collects the renders of each version; nil disables statistics.
+	Stats *RenderStatsCache
+	// Cost prices renders in metered units.
+	Cost entity.RenderCostModel
+	// CompileTimeout is the compile timeout of the renderer; compile times of at least half of it
+	// are classified RUNAWAY.
+	CompileTimeout time.Duration
+}
+
+// EstimateByDocumentType resolves a template like RenderByDocumentType and estimates its render.
+func (s *InternalRenderService) EstimateByDocumentType(ctx context.Context, cmd templateuc.InternalRenderCommand, statisticsOnly bool) (*entity.RenderEstimate, error) {
+	version, err := s.resolveVersion(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return s.estimateVersion(ctx, version, cmd, statisticsOnly)
+}
+
+// EstimateByVersionID estimates the render of a specific template version.
+func (s *InternalRenderService) EstimateByVersionID(ctx context.Context, cmd templateuc.RenderByVersionIDCommand, statisticsOnly bool) (*entity.RenderEstimate, error) {
+	version, err := s.versionRepo.FindByIDWithDetails(ctx, cmd.VersionID)
+	if err != nil {
+		return nil, fmt.Errorf("finding version %s: %w", cmd.VersionID, err)
+	}
+	return s.estimateVersion(ctx, version, versionRenderCommand(cmd), statisticsOnly)
+}
+
+// estimateVersion estimates a render from a dry compile of the request, or from the statistics of
+// the version when statisticsOnly is set and there are any.
+func (s *InternalRenderService) estimateVersion(
+	ctx context.Context,
+	version *entity.TemplateVersionWithDetails,
+	cmd templateuc.InternalRenderCommand,
+	statisticsOnly bool,
+) (*entity.RenderEstimate, error) {
+	estimate := &entity.RenderEstimate{
+		VersionID:  version.ID,
+		TemplateID: version.TemplateID,
+		Statistics: s.estimation.Stats.Get(version.ID),
+		Warnings:   []string{},
+	}
+	stats := estimate.Statistics
+
+	var compileTime time.Duration
+	if statisticsOnly && stats != nil {
+		estimate.PageCount = int(math.Ceil(stats.AvgPageCount))
+		compileTime = time.Duration(stats.AvgDurationMs * float64(time.Millisecond))
+	} else {
+		if statisticsOnly {
+			estimate.Warnings = append(estimate.Warnings, "no render statistics for this version yet; estimated with a dry compile")
+		}
+		result, duration, err := s.compileVersion(ctx, version, cmd, newRenderContext(version, cmd, EstimateOperation))
+		if err != nil {
+			return nil, err
+		}
+		estimate.DryRun = true
+		estimate.PageCount = result.PageCount
+		compileTime = duration
+		for _, w := range result.Warnings {
+			estimate.Warnings = append(estimate.Warnings, w.Message)
+		}
+	}
+
+	timeout := s.estimation.CompileTimeout
+	estimate.CompileTimeMs = durationMs(compileTime)
+	estimate.CompileTimeClass = entity.ClassifyCompileTime(compileTime, timeout)
+	estimate.Cost = s.estimation.Cost.Cost(estimate.PageCount, compileTime)
+
+	if estimate.CompileTimeClass == entity.CompileTimeRunaway {
+		estimate.Warnings = append(estimate.Warnings, fmt.Sprintf(
+			"compile takes %.1fs, at least half the %s compile timeout; renders may time out under load",
+			compileTime.Seconds(), timeout))
+	}
+	if stats != nil {
+		p95 := time.Duration(stats.P95DurationMs * float64(time.Millisecond))
+		if estimate.CompileTimeClass != entity.CompileTimeRunaway && entity.ClassifyCompileTime(p95, timeout) == entity.CompileTimeRunaway {
+			estimate.Warnings = append(estimate.Warnings, fmt.Sprintf(
+				"the slowest 5%% of recent renders took over %.1fs, close to the %s compile timeout",
+				p95.Seconds(), timeout))
+		}
+		if estimate.DryRun && estimate.PageCount > stats.MaxPageCount {
+			estimate.Warnings = append(estimate.Warnings, fmt.Sprintf(
+				"the sample payload renders %d pages, more than any of the last %d renders (at most %d)",
+				estimate.PageCount, stats.Renders, stats.MaxPageCount))
+		}
+	}
+
+	return estimate, nil
+}
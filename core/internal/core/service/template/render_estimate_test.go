@@ -0,0 +1,108 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+func newEstimateTestService(t *testing.T, renderer *pdfRendererStub, stats *RenderStatsCache) *InternalRenderService {
+	t.Helper()
+	return &InternalRenderService{
+		versionRepo: &templateResolverTemplateVersionRepoStub{
+			byID: map[string]*entity.TemplateVersionWithDetails{
+				"v-1": {
+					TemplateVersion: entity.TemplateVersion{
+						ID:               "v-1",
+						TemplateID:       "tpl-1",
+						Status:           entity.VersionStatusPublished,
+						ContentStructure: mustBuildPortableDoc(t),
+					},
+				},
+			},
+		},
+		pdfRenderer: renderer,
+		estimation: RenderEstimationOptions{
+			Stats:          stats,
+			Cost:           entity.RenderCostModel{PerRender: 1, PerPage: 0.5},
+			CompileTimeout: 10 * time.Second,
+		},
+	}
+}
+
+func TestInternalRenderService_EstimateDryCompileSkipsStatistics(t *testing.T) {
+	renderer := &pdfRendererStub{}
+	stats := NewRenderStatsCache()
+	service := newEstimateTestService(t, renderer, stats)
+	cmd := templateuc.RenderByVersionIDCommand{VersionID: "v-1", TenantCode: "TENANT_A", WorkspaceCode: "WS_1"}
+
+	estimate, err := service.EstimateByVersionID(context.Background(), cmd, false)
+	require.NoError(t, err)
+	assert.True(t, estimate.DryRun)
+	assert.Equal(t, 1, estimate.PageCount)
+	assert.Equal(t, entity.CompileTimeFast, estimate.CompileTimeClass)
+	assert.InDelta(t, 1.5, estimate.Cost, 0.01)
+	assert.Nil(t, estimate.Statistics)
+	assert.Equal(t, 1, renderer.calls)
+
+	assert.Nil(t, stats.Get("v-1"), "dry compiles are not counted as renders")
+}
+
+func TestInternalRenderService_EstimateStatisticsOnly(t *testing.T) {
+	renderer := &pdfRendererStub{}
+	stats := NewRenderStatsCache()
+	service := newEstimateTestService(t, renderer, stats)
+	cmd := templateuc.RenderByVersionIDCommand{VersionID: "v-1", TenantCode: "TENANT_A", WorkspaceCode: "WS_1"}
+
+	estimate, err := service.EstimateByVersionID(context.Background(), cmd, true)
+	require.NoError(t, err)
+	assert.True(t, estimate.DryRun, "without statistics the estimate falls back to a dry compile")
+	assert.Len(t, estimate.Warnings, 1)
+
+	stats.Record("v-1", 3, 2*time.Second)
+	stats.Record("v-1", 4, 6*time.Second)
+	renderer.calls = 0
+
+	estimate, err = service.EstimateByVersionID(context.Background(), cmd, true)
+	require.NoError(t, err)
+	assert.False(t, estimate.DryRun)
+	assert.Zero(t, renderer.calls)
+	assert.Equal(t, 4, estimate.PageCount)
+	assert.Equal(t, 4000.0, estimate.CompileTimeMs)
+	assert.Equal(t, entity.CompileTimeNormal, estimate.CompileTimeClass)
+	assert.Equal(t, 3.0, estimate.Cost)
+	require.NotNil(t, estimate.Statistics)
+	assert.Equal(t, 2, estimate.Statistics.Renders)
+	assert.Equal(t, 6000.0, estimate.Statistics.P95DurationMs)
+	assert.Len(t, estimate.Warnings, 1, "p95 reaches half the compile timeout")
+}
+
+func TestRenderStatsCache_EvictsLeastRecentlyRendered(t *testing.T) {
+	stats := NewRenderStatsCache()
+	for i := range renderStatsMaxVersions {
+		stats.Record(fmt.Sprintf("v-%d", i), 1, time.Millisecond)
+	}
+	stats.Record("first", 1, time.Millisecond)
+	assert.Len(t, stats.versions, renderStatsMaxVersions)
+	assert.NotNil(t, stats.Get("first"))
+
+	for range renderStatsSamples + 5 {
+		stats.Record("first", 2, time.Millisecond)
+	}
+	assert.Equal(t, renderStatsSamples, stats.Get("first").Renders)
+	assert.Equal(t, 2.0, stats.Get("first").AvgPageCount)
+}
+
+func TestClassifyCompileTime(t *testing.T) {
+	assert.Equal(t, entity.CompileTimeFast, entity.ClassifyCompileTime(300*time.Millisecond, 0))
+	assert.Equal(t, entity.CompileTimeNormal, entity.ClassifyCompileTime(2*time.Second, 0))
+	assert.Equal(t, entity.CompileTimeSlow, entity.ClassifyCompileTime(20*time.Second, 0))
+	assert.Equal(t, entity.CompileTimeRunaway, entity.ClassifyCompileTime(5*time.Second, 10*time.Second))
+}
@@ -0,0 +1,198 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	notificationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/notification"
+)
+
+// defaultRenderFailureCooldown is how long the failures of a template version or a document type
+// stay quiet after one is notified.
+const defaultRenderFailureCooldown = 15 * time.Minute
+
+// RenderFailureNotifier notifies failed renders and render jobs given up. The author of the
+// version that failed is notified; when the version is unknown or has no author, the owners of
+// the workspace are. The workspace chat webhooks mirror the notifications.
+type RenderFailureNotifier struct {
+	versionRepo    port.TemplateVersionRepository
+	templateRepo   port.TemplateRepository
+	memberRepo     port.WorkspaceMemberRepository
+	notificationUC notificationuc.NotificationUseCase
+	cooldown       time.Duration
+	now            func() time.Time
+
+	mu       sync.Mutex
+	notified map[string]time.Time // by workspace and version or document type
+}
+
+// NewRenderFailureNotifier creates a render failure notifier. A non-positive cooldown uses 15 minutes.
+// Subscribe HandleRenderEvent to RenderFailed and RenderJobAbandoned.
+func NewRenderFailureNotifier(
+	versionRepo port.TemplateVersionRepository,
+	templateRepo port.TemplateRepository,
+	memberRepo port.WorkspaceMemberRepository,
+	notificationUC notificationuc.NotificationUseCase,
+	cooldown time.Duration,
+) *RenderFailureNotifier {
+	if cooldown <= 0 {
+		cooldown = defaultRenderFailureCooldown
+	}
+	return &RenderFailureNotifier{
+		versionRepo:    versionRepo,
+		templateRepo:   templateRepo,
+		memberRepo:     memberRepo,
+		notificationUC: notificationUC,
+		cooldown:       cooldown,
+		now:            time.Now,
+		notified:       make(map[string]time.Time),
+	}
+}
+
+// HandleRenderEvent notifies a failed render or an abandoned render job. Failures of renders run
+// for a render job are left to the RenderJobAbandoned of the job, and failures within the cooldown
+// of an earlier notification of the same version or document type are not notified. Notification
+// errors are logged: the render has already failed.
+func (n *RenderFailureNotifier) HandleRenderEvent(ctx context.Context, event entity.DomainEvent) error {
+	switch e := event.(type) {
+	case entity.RenderFailed:
+		if e.JobID != "" {
+			return nil
+		}
+		n.notifyRenderFailed(ctx, e)
+	case entity.RenderJobAbandoned:
+		n.notifyJobAbandoned(ctx, e)
+	}
+	return nil
+}
+
+func (n *RenderFailureNotifier) notifyRenderFailed(ctx context.Context, e entity.RenderFailed) {
+	template, err := n.templateRepo.FindByID(ctx, e.TemplateID)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to load template of failed render",
+			slog.String("template_id", e.TemplateID),
+			slog.Any("error", err),
+		)
+		return
+	}
+	if !n.claim(template.WorkspaceID, e.VersionID) {
+		return
+	}
+
+	message := e.Error
+	if e.ErrorCode != "" {
+		message = fmt.Sprintf("%s (%s)", e.Error, e.ErrorCode)
+	}
+	n.notify(ctx, template.WorkspaceID, &e.VersionID, notificationuc.NotifyCommand{
+		Type:       entity.NotificationTypeRenderFailed,
+		Title:      fmt.Sprintf("Rendering %q failed in %s", template.Title, e.Environment),
+		Message:    message,
+		ResourceID: &e.VersionID,
+		Time:       &e.FailedAt,
+		TimeLabel:  "Failed at",
+	})
+}
+
+func (n *RenderFailureNotifier) notifyJobAbandoned(ctx context.Context, e entity.RenderJobAbandoned) {
+	subject := "type:"
+	if e.DocumentType != nil {
+		subject += *e.DocumentType
+	}
+	if e.VersionID != nil {
+		subject = *e.VersionID
+	}
+	if !n.claim(e.WorkspaceID, subject) {
+		return
+	}
+
+	message := e.Error
+	if e.ErrorCode != nil {
+		message = fmt.Sprintf("%s (%s)", e.Error, *e.ErrorCode)
+	}
+	title := fmt.Sprintf("Render job %s failed in %s", e.JobID, e.Environment)
+	if e.DocumentType != nil {
+		title = fmt.Sprintf("Render job of document type %s failed in %s", *e.DocumentType, e.Environment)
+	}
+	n.notify(ctx, e.WorkspaceID, e.VersionID, notificationuc.NotifyCommand{
+		Type:       entity.NotificationTypeRenderFailed,
+		Title:      title,
+		Message:    message,
+		ResourceID: &e.JobID,
+		Time:       &e.FailedAt,
+		TimeLabel:  "Failed at",
+	})
+}
+
+// claim reports whether a failure of subject in a workspace is notified, starting its cooldown.
+func (n *RenderFailureNotifier) claim(workspaceID, subject string) bool {
+	key := workspaceID + "|" + subject
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	now := n.now()
+	if last, ok := n.notified[key]; ok && now.Sub(last) < n.cooldown {
+		return false
+	}
+	for k, last := range n.notified {
+		if now.Sub(last) >= n.cooldown {
+			delete(n.notified, k)
+		}
+	}
+	n.notified[key] = now
+	return true
+}
+
+// notify sends cmd to the recipients of a failure of versionID, which may be nil.
+func (n *RenderFailureNotifier) notify(ctx context.Context, workspaceID string, versionID *string, cmd notificationuc.NotifyCommand) {
+	recipients, err := n.recipients(ctx, workspaceID, versionID)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to find render failure recipients",
+			slog.String("workspace_id", workspaceID),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	cmd.WorkspaceID = &workspaceID
+	for _, userID := range recipients {
+		cmd.UserID = userID
+		if err := n.notificationUC.Notify(ctx, cmd); err != nil {
+			slog.WarnContext(ctx, "failed to notify render failure",
+				slog.String("workspace_id", workspaceID),
+				slog.String("user_id", userID),
+				slog.Any("error", err),
+			)
+		}
+	}
+}
+
+// recipients returns the author of the version, or the active owners of the workspace.
+func (n *RenderFailureNotifier) recipients(ctx context.Context, workspaceID string, versionID *string) ([]string, error) {
+	if versionID != nil {
+		version, err := n.versionRepo.FindMetadataByID(ctx, *versionID)
+		switch {
+		case err == nil && version.CreatedBy != nil:
+			return []string{*version.CreatedBy}, nil
+		case err != nil && !errors.Is(err, entity.ErrVersionNotFound):
+			return nil, err
+		}
+	}
+
+	members, err := n.memberRepo.FindByWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	var owners []string
+	for _, m := range members {
+		if m.Role == entity.WorkspaceRoleOwner && m.MembershipStatus == entity.MembershipStatusActive {
+			owners = append(owners, m.UserID)
+		}
+	}
+	return owners, nil
+}
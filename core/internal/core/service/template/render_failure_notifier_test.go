@@ -0,0 +1,220 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	eventsvc "github.com/rendis/pdf-forge/core/internal/core/service/events"
+	notificationsvc "github.com/rendis/pdf-forge/core/internal/core/service/notification"
+)
+
+func TestRenderFailureNotifier_NotifiesVersionAuthor(t *testing.T) {
+	notifications := &fakeSLANotifications{}
+	notifier := newTestRenderFailureNotifier(notifications)
+	failedAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	require.NoError(t, notifier.HandleRenderEvent(context.Background(), entity.RenderFailed{
+		VersionID: "v-1", TemplateID: "t-1", Environment: entity.EnvironmentProd,
+		Error: "typst compile failed", ErrorCode: entity.RenderErrorCompile, FailedAt: failedAt,
+	}))
+
+	require.Len(t, notifications.sent, 1)
+	cmd := notifications.sent[0]
+	assert.Equal(t, "author-1", cmd.UserID)
+	assert.Equal(t, "ws-1", *cmd.WorkspaceID)
+	assert.Equal(t, entity.NotificationTypeRenderFailed, cmd.Type)
+	assert.Equal(t, `Rendering "Invoice" failed in prod`, cmd.Title)
+	assert.Equal(t, "typst compile failed (RENDER_COMPILE)", cmd.Message)
+	assert.Equal(t, "v-1", *cmd.ResourceID)
+	assert.Equal(t, failedAt, *cmd.Time)
+}
+
+func TestRenderFailureNotifier_LeavesJobAttemptsToAbandonedJob(t *testing.T) {
+	notifications := &fakeSLANotifications{}
+	notifier := newTestRenderFailureNotifier(notifications)
+
+	require.NoError(t, notifier.HandleRenderEvent(context.Background(), entity.RenderFailed{
+		VersionID: "v-1", TemplateID: "t-1", JobID: "job-1", Error: "typst compile failed",
+	}))
+	assert.Empty(t, notifications.sent)
+}
+
+func TestRenderFailureNotifier_NotifiesOwnersOfAbandonedJob(t *testing.T) {
+	notifications := &fakeSLANotifications{}
+	notifier := newTestRenderFailureNotifier(notifications)
+	docType := "INVOICE"
+	code := entity.RenderErrorInjectorTimeout
+
+	require.NoError(t, notifier.HandleRenderEvent(context.Background(), entity.RenderJobAbandoned{
+		JobID: "job-1", WorkspaceID: "ws-1", DocumentType: &docType, Environment: entity.EnvironmentProd,
+		Error: "injector timed out", ErrorCode: &code, Attempts: 3, FailedAt: time.Now(),
+	}))
+
+	require.Len(t, notifications.sent, 1, "only active owners are notified")
+	cmd := notifications.sent[0]
+	assert.Equal(t, "owner-1", cmd.UserID)
+	assert.Equal(t, "Render job of document type INVOICE failed in prod", cmd.Title)
+	assert.Equal(t, "injector timed out (RENDER_INJECTOR_TIMEOUT)", cmd.Message)
+	assert.Equal(t, "job-1", *cmd.ResourceID)
+}
+
+func TestRenderFailureNotifier_Cooldown(t *testing.T) {
+	notifications := &fakeSLANotifications{}
+	notifier := newTestRenderFailureNotifier(notifications)
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	notifier.now = func() time.Time { return now }
+	failed := entity.RenderFailed{VersionID: "v-1", TemplateID: "t-1", Error: "typst compile failed"}
+	ctx := context.Background()
+
+	require.NoError(t, notifier.HandleRenderEvent(ctx, failed))
+	require.NoError(t, notifier.HandleRenderEvent(ctx, failed))
+	assert.Len(t, notifications.sent, 1, "repeats within the cooldown are quiet")
+
+	now = now.Add(defaultRenderFailureCooldown)
+	require.NoError(t, notifier.HandleRenderEvent(ctx, failed))
+	assert.Len(t, notifications.sent, 2)
+}
+
+func newTestRenderFailureNotifier(notifications *fakeSLANotifications) *RenderFailureNotifier {
+	author := "author-1"
+	return NewRenderFailureNotifier(
+		&fakeFailureVersionRepo{versions: map[string]*entity.TemplateVersion{"v-1": {ID: "v-1", CreatedBy: &author}}},
+		&fakeFailureTemplateRepo{templates: map[string]*entity.Template{"t-1": {ID: "t-1", WorkspaceID: "ws-1", Title: "Invoice"}}},
+		&fakeFailureMemberRepo{members: []*entity.MemberWithUser{
+			{WorkspaceMember: entity.WorkspaceMember{UserID: "owner-1", Role: entity.WorkspaceRoleOwner, MembershipStatus: entity.MembershipStatusActive}},
+			{WorkspaceMember: entity.WorkspaceMember{UserID: "owner-2", Role: entity.WorkspaceRoleOwner, MembershipStatus: entity.MembershipStatusPending}},
+			{WorkspaceMember: entity.WorkspaceMember{UserID: "editor-1", Role: entity.WorkspaceRoleEditor, MembershipStatus: entity.MembershipStatusActive}},
+		}},
+		notifications,
+		0,
+	)
+}
+
+type fakeFailureVersionRepo struct {
+	port.TemplateVersionRepository
+	versions map[string]*entity.TemplateVersion
+}
+
+func (f *fakeFailureVersionRepo) FindMetadataByID(_ context.Context, id string) (*entity.TemplateVersion, error) {
+	if v, ok := f.versions[id]; ok {
+		return v, nil
+	}
+	return nil, entity.ErrVersionNotFound
+}
+
+type fakeFailureTemplateRepo struct {
+	port.TemplateRepository
+	templates map[string]*entity.Template
+}
+
+func (f *fakeFailureTemplateRepo) FindByID(_ context.Context, id string) (*entity.Template, error) {
+	if t, ok := f.templates[id]; ok {
+		return t, nil
+	}
+	return nil, entity.ErrTemplateNotFound
+}
+
+type fakeFailureMemberRepo struct {
+	port.WorkspaceMemberRepository
+	members []*entity.MemberWithUser
+}
+
+func (f *fakeFailureMemberRepo) FindByWorkspace(context.Context, string) ([]*entity.MemberWithUser, error) {
+	return f.members, nil
+}
+
+func TestRenderFailureNotifier_AbandonedJobReachesChatWebhook(t *testing.T) {
+	outbox := &fakeFailureOutbox{}
+	notifier := NewRenderFailureNotifier(
+		&fakeFailureVersionRepo{},
+		&fakeFailureTemplateRepo{},
+		&fakeFailureMemberRepo{members: []*entity.MemberWithUser{
+			{WorkspaceMember: entity.WorkspaceMember{UserID: "owner-1", Role: entity.WorkspaceRoleOwner, MembershipStatus: entity.MembershipStatusActive}},
+			{WorkspaceMember: entity.WorkspaceMember{UserID: "owner-2", Role: entity.WorkspaceRoleOwner, MembershipStatus: entity.MembershipStatusActive}},
+		}},
+		notificationsvc.NewNotificationService(&fakeFailureNotificationRepo{}, nil, nil, outbox, fakeSLATx{}),
+		0,
+	)
+	bus := eventsvc.NewBus(map[entity.DomainEventType][]port.EventHandler{
+		entity.EventRenderJobAbandoned: {notifier.HandleRenderEvent},
+	})
+	code := "INVOICE"
+	repo := &fakeRenderJobRepo{batch: []*entity.RenderJob{{
+		ID: "job-1", WorkspaceID: "ws-1", DocumentTypeCode: &code, Environment: entity.EnvironmentProd,
+		Request: []byte("{}"), Attempts: 1,
+	}}}
+	renderErr := &entity.CompileError{Err: errors.New("typst compile failed")}
+	runner := NewRenderJobRunner(repo, &fakeJobRenderUseCase{err: renderErr}, &fakeJobMaintenance{}, bus, RenderJobRunnerOptions{})
+
+	_, err := runner.RunOnce(context.Background())
+	require.NoError(t, err)
+	require.Len(t, outbox.events, 2, "each owner is notified")
+
+	slack := &fakeFailureChatSender{}
+	publisher := notificationsvc.NewChannelPublisher(fakeFailureUserRepo{}, []port.NotificationChannel{
+		notificationsvc.NewWorkspaceWebhookChannel(&fakeFailureWebhookRepo{}, map[entity.WebhookProvider]port.ChatWebhookSender{
+			entity.WebhookProviderSlack: slack,
+		}),
+	})
+	for _, event := range outbox.events {
+		require.NoError(t, publisher.Publish(context.Background(), event))
+	}
+
+	require.Len(t, slack.sent, 1, "the workspace channel hears about the job once")
+	assert.Equal(t, entity.NotificationTypeRenderFailed, slack.sent[0].Type)
+	assert.Equal(t, "Render job of document type INVOICE failed in prod", slack.sent[0].Title)
+	assert.Contains(t, slack.sent[0].Message, "typst compile failed (RENDER_COMPILE)")
+}
+
+type fakeFailureNotificationRepo struct {
+	port.NotificationRepository
+}
+
+func (f *fakeFailureNotificationRepo) Create(_ context.Context, n *entity.Notification) (string, error) {
+	return n.ID, nil
+}
+
+type fakeFailureOutbox struct {
+	port.OutboxRepository
+	events []*entity.OutboxEvent
+}
+
+func (f *fakeFailureOutbox) Append(_ context.Context, event *entity.OutboxEvent) error {
+	f.events = append(f.events, event)
+	return nil
+}
+
+type fakeFailureUserRepo struct {
+	port.UserRepository
+}
+
+func (fakeFailureUserRepo) FindByID(_ context.Context, id string) (*entity.User, error) {
+	return &entity.User{ID: id}, nil
+}
+
+type fakeFailureWebhookRepo struct {
+	port.NotificationWebhookRepository
+}
+
+func (f *fakeFailureWebhookRepo) FindEnabledByWorkspace(_ context.Context, workspaceID string) ([]*entity.NotificationWebhook, error) {
+	return []*entity.NotificationWebhook{{
+		ID: "wh-1", WorkspaceID: workspaceID, Provider: entity.WebhookProviderSlack,
+		URL: "https://hooks.slack.com/services/a", Enabled: true,
+	}}, nil
+}
+
+type fakeFailureChatSender struct {
+	sent []*entity.Notification
+}
+
+func (f *fakeFailureChatSender) Send(_ context.Context, _ string, n *entity.Notification) error {
+	f.sent = append(f.sent, n)
+	return nil
+}
@@ -0,0 +1,69 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// RenderHooks are the hooks registered through the SDK around the renders of the render API.
+type RenderHooks struct {
+	PreRender  []port.PreRenderHook
+	PostRender []port.PostRenderHook
+}
+
+// newRenderContext describes a render of version as operation to the render hooks. The headers
+// and injectables are copied, so hooks can change them without touching the other items of a batch.
+func newRenderContext(version *entity.TemplateVersionWithDetails, cmd templateuc.InternalRenderCommand, operation string) *port.RenderContext {
+	render := &port.RenderContext{
+		Operation:     operation,
+		TenantCode:    cmd.TenantCode,
+		WorkspaceCode: cmd.WorkspaceCode,
+		DocumentType:  cmd.TemplateTypeCode,
+		TemplateID:    version.TemplateID,
+		VersionID:     version.ID,
+		Environment:   cmd.Environment,
+		Headers:       maps.Clone(cmd.Headers),
+		Payload:       cmd.Payload,
+		Injectables:   maps.Clone(cmd.Injectables),
+	}
+	if render.Headers == nil {
+		render.Headers = map[string]string{}
+	}
+	if render.Injectables == nil {
+		render.Injectables = map[string]any{}
+	}
+	return render
+}
+
+// preRender runs the pre-render hooks on the render, stopping at the first error.
+func (h RenderHooks) preRender(ctx context.Context, render *port.RenderContext) error {
+	if len(h.PreRender) == 0 {
+		return nil
+	}
+	ctx = port.WithRenderContext(ctx, render)
+	for _, hook := range h.PreRender {
+		if err := hook(ctx, render); err != nil {
+			return fmt.Errorf("pre-render hook: %w", err)
+		}
+	}
+	return nil
+}
+
+// postRender runs the post-render hooks on the result of the render, stopping at the first error.
+func (h RenderHooks) postRender(ctx context.Context, render *port.RenderContext, result *port.RenderPreviewResult) error {
+	if len(h.PostRender) == 0 {
+		return nil
+	}
+	ctx = port.WithRenderContext(ctx, render)
+	for _, hook := range h.PostRender {
+		if err := hook(ctx, result); err != nil {
+			return fmt.Errorf("post-render hook: %w", err)
+		}
+	}
+	return nil
+}
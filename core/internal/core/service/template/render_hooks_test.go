@@ -0,0 +1,100 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// requestRendererStub records the last request it rendered.
+type requestRendererStub struct {
+	pdfRendererStub
+	last *port.RenderPreviewRequest
+}
+
+func (s *requestRendererStub) RenderPreview(ctx context.Context, req *port.RenderPreviewRequest) (*port.RenderPreviewResult, error) {
+	s.last = req
+	return s.pdfRendererStub.RenderPreview(ctx, req)
+}
+
+func newHooksTestVersion(t *testing.T) *entity.TemplateVersionWithDetails {
+	t.Helper()
+	return &entity.TemplateVersionWithDetails{
+		TemplateVersion: entity.TemplateVersion{
+			ID:               "v-1",
+			TemplateID:       "tpl-1",
+			Status:           entity.VersionStatusPublished,
+			ContentStructure: mustBuildPortableDoc(t),
+		},
+	}
+}
+
+func TestInternalRenderService_RenderHooksChangeRequestAndResult(t *testing.T) {
+	renderer := &requestRendererStub{}
+	service := newBatchTestService(t, renderer)
+	var seen *port.RenderContext
+	service.hooks = RenderHooks{
+		PreRender: []port.PreRenderHook{func(_ context.Context, render *port.RenderContext) error {
+			render.Injectables["customer"] = "ACME"
+			render.Watermark = "DRAFT"
+			return nil
+		}},
+		PostRender: []port.PostRenderHook{func(ctx context.Context, result *port.RenderPreviewResult) error {
+			seen = port.RenderContextFrom(ctx)
+			result.PDF = append(result.PDF, "%signed"...)
+			return nil
+		}},
+	}
+
+	cmd := templateuc.InternalRenderCommand{
+		TenantCode:    "TENANT_A",
+		WorkspaceCode: "WS_1",
+		Injectables:   map[string]any{"amount": 10},
+	}
+	result, err := service.renderVersion(context.Background(), newHooksTestVersion(t), cmd)
+	require.NoError(t, err)
+
+	require.NotNil(t, renderer.last)
+	assert.Equal(t, map[string]any{"amount": 10, "customer": "ACME"}, renderer.last.Injectables)
+	assert.Equal(t, "DRAFT", renderer.last.Watermark)
+	assert.Equal(t, "%PDF-1.7%signed", string(result.PDF))
+	assert.NotContains(t, cmd.Injectables, "customer", "hooks work on a copy of the caller injectables")
+
+	require.NotNil(t, seen)
+	assert.Equal(t, RenderOperation, seen.Operation)
+	assert.Equal(t, "v-1", seen.VersionID)
+	assert.Equal(t, "WS_1", seen.WorkspaceCode)
+}
+
+func TestInternalRenderService_PreRenderHookErrorFailsRender(t *testing.T) {
+	renderer := &requestRendererStub{}
+	service := newBatchTestService(t, renderer)
+	errRejected := errors.New("payload rejected")
+	service.hooks = RenderHooks{
+		PreRender: []port.PreRenderHook{func(context.Context, *port.RenderContext) error { return errRejected }},
+	}
+
+	result, err := service.renderVersion(context.Background(), newHooksTestVersion(t), templateuc.InternalRenderCommand{})
+	require.ErrorIs(t, err, errRejected)
+	assert.Nil(t, result)
+	assert.Nil(t, renderer.last, "the document is not rendered")
+}
+
+func TestInternalRenderService_PostRenderHookErrorFailsRender(t *testing.T) {
+	service := newBatchTestService(t, &requestRendererStub{})
+	errUpload := errors.New("metrics push failed")
+	service.hooks = RenderHooks{
+		PostRender: []port.PostRenderHook{func(context.Context, *port.RenderPreviewResult) error { return errUpload }},
+	}
+
+	result, err := service.renderVersion(context.Background(), newHooksTestVersion(t), templateuc.InternalRenderCommand{})
+	require.ErrorIs(t, err, errUpload)
+	assert.Nil(t, result)
+}
@@ -0,0 +1,302 @@
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	platformuc "github.com/rendis/pdf-forge/core/internal/core/usecase/platform"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// renderJobPurgeBatch bounds the expired jobs deleted per poll.
+const renderJobPurgeBatch = 100
+
+// RenderJobRunnerOptions configures the render job runner.
+type RenderJobRunnerOptions struct {
+	// PollInterval is how often the runner looks for queued jobs.
+	PollInterval time.Duration
+	// Lease is how long a claimed job stays hidden from other runners. It also bounds the render:
+	// a job still running when its lease expires is canceled and claimed again.
+	Lease time.Duration
+	// Concurrency is the maximum number of jobs this runner renders at a time.
+	Concurrency int
+	// MaxAttempts is the number of claims before a job is given up, for jobs whose runner stopped
+	// mid-render or whose render failed with a retryable error. Other render errors fail the job
+	// at once.
+	MaxAttempts int
+}
+
+// RenderJobRunner renders submitted render jobs in the background and stores their PDFs.
+// Several runners (one per API instance) can run against the same database.
+type RenderJobRunner struct {
+	repo          port.RenderJobRepository
+	renderUC      templateuc.InternalRenderUseCase
+	maintenanceUC platformuc.MaintenanceUseCase
+	events        port.EventDispatcher
+	opts          RenderJobRunnerOptions
+	stopCh        chan struct{}
+	stopped       chan struct{}
+	stopOnce      sync.Once
+}
+
+// NewRenderJobRunner creates a render job runner. Call Start to begin polling. Jobs given up are
+// reported to events as RenderJobAbandoned; events may be nil.
+func NewRenderJobRunner(
+	repo port.RenderJobRepository,
+	renderUC templateuc.InternalRenderUseCase,
+	maintenanceUC platformuc.MaintenanceUseCase,
+	events port.EventDispatcher,
+	opts RenderJobRunnerOptions,
+) *RenderJobRunner {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 2 * time.Second
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = 10 * time.Minute
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 2
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 3
+	}
+
+	return &RenderJobRunner{
+		repo:          repo,
+		renderUC:      renderUC,
+		maintenanceUC: maintenanceUC,
+		events:        events,
+		opts:          opts,
+		stopCh:        make(chan struct{}),
+		stopped:       make(chan struct{}),
+	}
+}
+
+// Start runs the polling loop in the background until Stop is called.
+func (r *RenderJobRunner) Start() {
+	go r.loop()
+}
+
+// Stop ends the polling loop and cancels the jobs in flight, which are claimed again by another
+// runner once their lease expires.
+func (r *RenderJobRunner) Stop() {
+	r.stopOnce.Do(func() { close(r.stopCh) })
+	<-r.stopped
+}
+
+func (r *RenderJobRunner) loop() {
+	defer close(r.stopped)
+	ticker := time.NewTicker(r.opts.PollInterval)
+	defer ticker.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-r.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		select {
+		case <-r.stopCh:
+			return
+		case <-ticker.C:
+			if _, err := r.RunOnce(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				slog.WarnContext(ctx, "render job poll failed", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// RunOnce deletes expired jobs, then claims one batch of jobs and renders it, returning the number
+// of jobs that succeeded. Nothing is claimed while maintenance mode blocks renders.
+func (r *RenderJobRunner) RunOnce(ctx context.Context) (int, error) {
+	if deleted, err := r.repo.DeleteExpired(ctx, renderJobPurgeBatch); err != nil {
+		slog.WarnContext(ctx, "failed to delete expired render jobs", slog.Any("error", err))
+	} else if deleted > 0 {
+		slog.InfoContext(ctx, "expired render jobs deleted", slog.Int64("count", deleted))
+	}
+
+	if state := r.maintenanceUC.CurrentState(ctx); state.BlocksRenders() {
+		return 0, nil
+	}
+
+	jobs, err := r.repo.Claim(ctx, r.opts.Concurrency, r.opts.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+	)
+	for _, job := range jobs {
+		wg.Go(func() {
+			if r.run(ctx, job) {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+	return succeeded, ctx.Err()
+}
+
+// run renders one claimed job and records the outcome.
+func (r *RenderJobRunner) run(ctx context.Context, job *entity.RenderJob) bool {
+	if job.Attempts > r.opts.MaxAttempts {
+		r.fail(ctx, job, fmt.Sprintf("render job abandoned after %d attempts", r.opts.MaxAttempts), nil)
+		return false
+	}
+
+	done := r.maintenanceUC.BeginRender()
+	defer done()
+
+	renderCtx, cancel := context.WithTimeout(ctx, r.opts.Lease)
+	defer cancel()
+
+	started := time.Now()
+	result, err := r.render(renderCtx, job)
+	var quotaErr *entity.QuotaExceededError
+	switch {
+	case err == nil:
+		if err := r.repo.Complete(ctx, &entity.RenderJobResult{
+			JobID:     job.ID,
+			Filename:  result.Filename,
+			PDF:       result.PDF,
+			PageCount: result.PageCount,
+			Warnings:  result.Warnings,
+		}); err != nil {
+			// The job is claimed again once its lease expires
+			slog.ErrorContext(ctx, "failed to store render job result", slog.String("render_job_id", job.ID), slog.Any("error", err))
+			return false
+		}
+		slog.InfoContext(ctx, "render job succeeded",
+			slog.String("render_job_id", job.ID),
+			slog.Int("page_count", result.PageCount),
+			slog.Duration("duration", time.Since(started)),
+		)
+		return true
+	case errors.Is(err, entity.ErrRendererBusy),
+		errors.As(err, &quotaErr) && quotaErr.Limit == entity.RenderQuotaRendersPerMinute:
+		// Renders per minute free up soon; a monthly page quota fails the job
+		if err := r.repo.Requeue(ctx, job.ID); err != nil {
+			slog.WarnContext(ctx, "failed to requeue render job", slog.String("render_job_id", job.ID), slog.Any("error", err))
+		}
+	case ctx.Err() != nil:
+		// Stopping: the job is claimed again once its lease expires
+	case renderCtx.Err() != nil:
+		r.fail(ctx, job, fmt.Sprintf("render did not finish within %s", r.opts.Lease), nil)
+	default:
+		code, ok := entity.RenderErrorCodeOf(err)
+		if !ok {
+			r.fail(ctx, job, err.Error(), nil)
+			break
+		}
+		if code.Retryable() && job.Attempts < r.opts.MaxAttempts {
+			slog.InfoContext(ctx, "render job failed, retrying",
+				slog.String("render_job_id", job.ID),
+				slog.Int("attempts", job.Attempts),
+				slog.String("error_code", string(code)),
+				slog.Any("error", err),
+			)
+			if err := r.repo.Retry(ctx, job.ID); err != nil {
+				slog.WarnContext(ctx, "failed to retry render job", slog.String("render_job_id", job.ID), slog.Any("error", err))
+			}
+			break
+		}
+		r.fail(ctx, job, err.Error(), &code)
+	}
+	return false
+}
+
+// render runs the render a job was submitted for.
+func (r *RenderJobRunner) render(ctx context.Context, job *entity.RenderJob) (*port.RenderPreviewResult, error) {
+	var req renderJobRequest
+	if err := json.Unmarshal(job.Request, &req); err != nil {
+		return nil, fmt.Errorf("decoding render job request: %w", err)
+	}
+	if req.Injectables == nil {
+		req.Injectables = make(map[string]any)
+	}
+
+	if job.VersionID != nil {
+		return r.renderUC.RenderByVersionID(ctx, templateuc.RenderByVersionIDCommand{
+			VersionID:     *job.VersionID,
+			TenantCode:    job.TenantCode,
+			WorkspaceCode: job.WorkspaceCode,
+			Injectables:   req.Injectables,
+			Headers:       req.Headers,
+			Payload:       req.Injectables,
+			Environment:   job.Environment,
+			Imposition:    req.Imposition,
+			Layout:        req.Layout,
+			Overlays:      req.Overlays,
+			DocumentID:    req.DocumentID,
+			Degraded:      req.Degraded,
+			UnitSystem:    req.UnitSystem,
+			JobID:         job.ID,
+		})
+	}
+	return r.renderUC.RenderByDocumentType(ctx, templateuc.InternalRenderCommand{
+		TenantCode:       job.TenantCode,
+		WorkspaceCode:    job.WorkspaceCode,
+		TemplateTypeCode: *job.DocumentTypeCode,
+		Injectables:      req.Injectables,
+		Headers:          req.Headers,
+		Payload:          req.Injectables,
+		Environment:      job.Environment,
+		Imposition:       req.Imposition,
+		Layout:           req.Layout,
+		Overlays:         req.Overlays,
+		DocumentID:       req.DocumentID,
+		Degraded:         req.Degraded,
+		UnitSystem:       req.UnitSystem,
+		JobID:            job.ID,
+	})
+}
+
+func (r *RenderJobRunner) fail(ctx context.Context, job *entity.RenderJob, reason string, code *entity.RenderErrorCode) {
+	slog.WarnContext(ctx, "render job failed",
+		slog.String("render_job_id", job.ID),
+		slog.Int("attempts", job.Attempts),
+		slog.String("reason", reason),
+	)
+	if err := r.repo.Fail(ctx, job.ID, reason, code); err != nil {
+		// The job is claimed again once its lease expires, and reported then
+		slog.WarnContext(ctx, "failed to record render job failure", slog.String("render_job_id", job.ID), slog.Any("error", err))
+		return
+	}
+	if r.events == nil {
+		return
+	}
+
+	event := entity.RenderJobAbandoned{
+		JobID:         job.ID,
+		WorkspaceID:   job.WorkspaceID,
+		TenantCode:    job.TenantCode,
+		WorkspaceCode: job.WorkspaceCode,
+		VersionID:     job.VersionID,
+		DocumentType:  job.DocumentTypeCode,
+		Environment:   job.Environment,
+		Error:         reason,
+		ErrorCode:     code,
+		Attempts:      job.Attempts,
+		FailedAt:      time.Now().UTC(),
+	}
+	if err := r.events.Dispatch(context.WithoutCancel(ctx), event); err != nil {
+		slog.WarnContext(ctx, "render job abandoned subscriber failed", slog.String("render_job_id", job.ID), slog.Any("error", err))
+	}
+}
@@ -0,0 +1,302 @@
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	platformuc "github.com/rendis/pdf-forge/core/internal/core/usecase/platform"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+func TestRenderJobRunner_RunOnceCompletesJob(t *testing.T) {
+	request, err := json.Marshal(renderJobRequest{
+		Injectables: map[string]any{"name": "Ada"},
+		Headers:     map[string]string{"X-Request-Id": "req-1"},
+		DocumentID:  "cert-1",
+	})
+	require.NoError(t, err)
+	versionID := "v-1"
+	repo := &fakeRenderJobRepo{batch: []*entity.RenderJob{{
+		ID: "job-1", TenantCode: "TENANT_A", WorkspaceCode: "WS_1", VersionID: &versionID,
+		Environment: entity.EnvironmentProd, Request: request, Attempts: 1,
+	}}}
+	renderUC := &fakeJobRenderUseCase{}
+	runner := NewRenderJobRunner(repo, renderUC, &fakeJobMaintenance{}, nil, RenderJobRunnerOptions{})
+
+	succeeded, err := runner.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, succeeded)
+
+	require.Len(t, renderUC.byVersion, 1)
+	cmd := renderUC.byVersion[0]
+	assert.Equal(t, "v-1", cmd.VersionID)
+	assert.Equal(t, "WS_1", cmd.WorkspaceCode)
+	assert.Equal(t, map[string]any{"name": "Ada"}, cmd.Injectables)
+	assert.Equal(t, "req-1", cmd.Headers["X-Request-Id"])
+	assert.Equal(t, "cert-1", cmd.DocumentID)
+	assert.Equal(t, "job-1", cmd.JobID)
+
+	require.Len(t, repo.completed, 1)
+	assert.Equal(t, "job-1", repo.completed[0].JobID)
+	assert.Equal(t, "%PDF", string(repo.completed[0].PDF))
+}
+
+func TestRenderJobRunner_RunOnceRequeuesWhenRendererBusy(t *testing.T) {
+	code := "INVOICE"
+	repo := &fakeRenderJobRepo{batch: []*entity.RenderJob{{ID: "job-1", DocumentTypeCode: &code, Request: []byte("{}"), Attempts: 1}}}
+	runner := NewRenderJobRunner(repo, &fakeJobRenderUseCase{err: entity.ErrRendererBusy}, &fakeJobMaintenance{}, nil, RenderJobRunnerOptions{})
+
+	succeeded, err := runner.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, succeeded)
+	assert.Equal(t, []string{"job-1"}, repo.requeued)
+	assert.Empty(t, repo.failed)
+}
+
+func TestRenderJobRunner_RunOnceQuotas(t *testing.T) {
+	code := "INVOICE"
+	perMinute := &entity.QuotaExceededError{Scope: entity.RenderQuotaScopeTenant, Limit: entity.RenderQuotaRendersPerMinute, Max: 10}
+	repo := &fakeRenderJobRepo{batch: []*entity.RenderJob{{ID: "job-1", DocumentTypeCode: &code, Request: []byte("{}"), Attempts: 1}}}
+	runner := NewRenderJobRunner(repo, &fakeJobRenderUseCase{err: perMinute}, &fakeJobMaintenance{}, nil, RenderJobRunnerOptions{})
+
+	_, err := runner.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"job-1"}, repo.requeued, "renders per minute free up soon")
+	assert.Empty(t, repo.failed)
+
+	perMonth := &entity.QuotaExceededError{Scope: entity.RenderQuotaScopeWorkspace, Limit: entity.RenderQuotaPagesPerMonth, Max: 1000}
+	repo = &fakeRenderJobRepo{batch: []*entity.RenderJob{{ID: "job-2", DocumentTypeCode: &code, Request: []byte("{}"), Attempts: 1}}}
+	runner = NewRenderJobRunner(repo, &fakeJobRenderUseCase{err: perMonth}, &fakeJobMaintenance{}, nil, RenderJobRunnerOptions{})
+
+	_, err = runner.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, repo.requeued)
+	assert.Equal(t, []string{"job-2"}, repo.failed)
+}
+
+func TestRenderJobRunner_RunOnceFailsJob(t *testing.T) {
+	code := "INVOICE"
+	repo := &fakeRenderJobRepo{batch: []*entity.RenderJob{{ID: "job-1", DocumentTypeCode: &code, Request: []byte("{}"), Attempts: 1}}}
+	runner := NewRenderJobRunner(repo, &fakeJobRenderUseCase{err: errors.New("typst compile failed")}, &fakeJobMaintenance{}, nil, RenderJobRunnerOptions{})
+
+	_, err := runner.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"job-1"}, repo.failed)
+	assert.Equal(t, "typst compile failed", repo.reason)
+}
+
+func TestRenderJobRunner_RunOnceFailsCompileErrorAtOnce(t *testing.T) {
+	code := "INVOICE"
+	repo := &fakeRenderJobRepo{batch: []*entity.RenderJob{{ID: "job-1", DocumentTypeCode: &code, Request: []byte("{}"), Attempts: 1}}}
+	renderErr := &entity.CompileError{Err: errors.New("typst compile failed")}
+	runner := NewRenderJobRunner(repo, &fakeJobRenderUseCase{err: renderErr}, &fakeJobMaintenance{}, nil, RenderJobRunnerOptions{})
+
+	_, err := runner.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, repo.retried)
+	assert.Equal(t, []string{"job-1"}, repo.failed)
+	require.NotNil(t, repo.code)
+	assert.Equal(t, entity.RenderErrorCompile, *repo.code)
+}
+
+func TestRenderJobRunner_RunOnceReportsAbandonedJob(t *testing.T) {
+	versionID := "v-1"
+	repo := &fakeRenderJobRepo{batch: []*entity.RenderJob{{
+		ID: "job-1", WorkspaceID: "ws-1", VersionID: &versionID, Environment: entity.EnvironmentProd,
+		Request: []byte("{}"), Attempts: 1,
+	}}}
+	events := &fakeJobEvents{}
+	renderErr := &entity.CompileError{Err: errors.New("typst compile failed")}
+	runner := NewRenderJobRunner(repo, &fakeJobRenderUseCase{err: renderErr}, &fakeJobMaintenance{}, events, RenderJobRunnerOptions{})
+
+	_, err := runner.RunOnce(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, events.dispatched, 1)
+	abandoned, ok := events.dispatched[0].(entity.RenderJobAbandoned)
+	require.True(t, ok)
+	assert.Equal(t, "job-1", abandoned.JobID)
+	assert.Equal(t, "ws-1", abandoned.WorkspaceID)
+	assert.Equal(t, &versionID, abandoned.VersionID)
+	assert.Equal(t, "typst compile failed", abandoned.Error)
+	require.NotNil(t, abandoned.ErrorCode)
+	assert.Equal(t, entity.RenderErrorCompile, *abandoned.ErrorCode)
+	assert.Equal(t, 1, abandoned.Attempts)
+}
+
+func TestRenderJobRunner_RunOnceRetriesRetryableError(t *testing.T) {
+	code := "INVOICE"
+	repo := &fakeRenderJobRepo{batch: []*entity.RenderJob{{ID: "job-1", DocumentTypeCode: &code, Request: []byte("{}"), Attempts: 1}}}
+	renderErr := &entity.InjectorError{Code: "customer", Err: context.DeadlineExceeded}
+	runner := NewRenderJobRunner(repo, &fakeJobRenderUseCase{err: renderErr}, &fakeJobMaintenance{}, nil, RenderJobRunnerOptions{MaxAttempts: 3})
+
+	_, err := runner.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"job-1"}, repo.retried)
+	assert.Empty(t, repo.failed)
+
+	repo.batch[0].Attempts = 3
+	_, err = runner.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"job-1"}, repo.failed, "the last attempt fails the job")
+	require.NotNil(t, repo.code)
+	assert.Equal(t, entity.RenderErrorInjectorTimeout, *repo.code)
+}
+
+func TestRenderJobRunner_RunOnceAbandonsJobAfterMaxAttempts(t *testing.T) {
+	code := "INVOICE"
+	repo := &fakeRenderJobRepo{batch: []*entity.RenderJob{{ID: "job-1", DocumentTypeCode: &code, Request: []byte("{}"), Attempts: 4}}}
+	renderUC := &fakeJobRenderUseCase{}
+	runner := NewRenderJobRunner(repo, renderUC, &fakeJobMaintenance{}, nil, RenderJobRunnerOptions{MaxAttempts: 3})
+
+	_, err := runner.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, renderUC.byType, "an abandoned job is not rendered again")
+	assert.Equal(t, []string{"job-1"}, repo.failed)
+	assert.Equal(t, "render job abandoned after 3 attempts", repo.reason)
+}
+
+func TestRenderJobRunner_RunOnceClaimsNothingWhileDraining(t *testing.T) {
+	repo := &fakeRenderJobRepo{batch: []*entity.RenderJob{{ID: "job-1"}}}
+	maintenance := &fakeJobMaintenance{mode: entity.MaintenanceModeDrain}
+	runner := NewRenderJobRunner(repo, &fakeJobRenderUseCase{}, maintenance, nil, RenderJobRunnerOptions{})
+
+	succeeded, err := runner.RunOnce(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, succeeded)
+	assert.Zero(t, repo.claims)
+	assert.Equal(t, 1, repo.purges, "expired jobs are still deleted")
+}
+
+func TestRenderJobHeaders_DropsCredentials(t *testing.T) {
+	got := renderJobHeaders(map[string]string{
+		"Authorization": "Bearer token",
+		"Cookie":        "session=1",
+		"X-Request-Id":  "req-1",
+	})
+	assert.Equal(t, map[string]string{"X-Request-Id": "req-1"}, got)
+}
+
+type fakeJobRenderUseCase struct {
+	templateuc.InternalRenderUseCase
+	err       error
+	byVersion []templateuc.RenderByVersionIDCommand
+	byType    []templateuc.InternalRenderCommand
+}
+
+func (f *fakeJobRenderUseCase) RenderByVersionID(_ context.Context, cmd templateuc.RenderByVersionIDCommand) (*port.RenderPreviewResult, error) {
+	f.byVersion = append(f.byVersion, cmd)
+	return f.result()
+}
+
+func (f *fakeJobRenderUseCase) RenderByDocumentType(_ context.Context, cmd templateuc.InternalRenderCommand) (*port.RenderPreviewResult, error) {
+	f.byType = append(f.byType, cmd)
+	return f.result()
+}
+
+func (f *fakeJobRenderUseCase) result() (*port.RenderPreviewResult, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &port.RenderPreviewResult{PDF: []byte("%PDF"), Filename: "doc.pdf", PageCount: 1}, nil
+}
+
+type fakeJobEvents struct {
+	mu         sync.Mutex
+	dispatched []entity.DomainEvent
+}
+
+func (f *fakeJobEvents) Dispatch(_ context.Context, event entity.DomainEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.dispatched = append(f.dispatched, event)
+	return nil
+}
+
+type fakeJobMaintenance struct {
+	platformuc.MaintenanceUseCase
+	mode entity.MaintenanceMode
+}
+
+func (f *fakeJobMaintenance) CurrentState(context.Context) *entity.MaintenanceState {
+	if f.mode == "" {
+		return entity.NewMaintenanceState()
+	}
+	return &entity.MaintenanceState{Mode: f.mode}
+}
+
+func (f *fakeJobMaintenance) BeginRender() func() { return func() {} }
+
+type fakeRenderJobRepo struct {
+	mu        sync.Mutex
+	batch     []*entity.RenderJob
+	claims    int
+	purges    int
+	completed []*entity.RenderJobResult
+	requeued  []string
+	retried   []string
+	failed    []string
+	reason    string
+	code      *entity.RenderErrorCode
+}
+
+func (f *fakeRenderJobRepo) Create(_ context.Context, job *entity.RenderJob) (string, error) {
+	return job.ID, nil
+}
+
+func (f *fakeRenderJobRepo) FindByID(context.Context, string) (*entity.RenderJob, error) {
+	return nil, entity.ErrRenderJobNotFound
+}
+
+func (f *fakeRenderJobRepo) FindPDF(context.Context, string) ([]byte, error) {
+	return nil, entity.ErrRenderJobNotFound
+}
+
+func (f *fakeRenderJobRepo) Claim(context.Context, int, time.Duration) ([]*entity.RenderJob, error) {
+	f.claims++
+	return f.batch, nil
+}
+
+func (f *fakeRenderJobRepo) Requeue(_ context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requeued = append(f.requeued, id)
+	return nil
+}
+
+func (f *fakeRenderJobRepo) Complete(_ context.Context, result *entity.RenderJobResult) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.completed = append(f.completed, result)
+	return nil
+}
+
+func (f *fakeRenderJobRepo) Retry(_ context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.retried = append(f.retried, id)
+	return nil
+}
+
+func (f *fakeRenderJobRepo) Fail(_ context.Context, id, reason string, code *entity.RenderErrorCode) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failed = append(f.failed, id)
+	f.reason = reason
+	f.code = code
+	return nil
+}
+
+func (f *fakeRenderJobRepo) DeleteExpired(context.Context, int) (int64, error) {
+	f.purges++
+	return 0, nil
+}
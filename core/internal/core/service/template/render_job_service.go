@@ -0,0 +1,175 @@
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// renderJobDroppedHeaders are the request headers not stored with a job, lowercased: the job
+// outlives the request and its credentials.
+var renderJobDroppedHeaders = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"cookie":              true,
+}
+
+// renderJobRequest is the stored part of a submitted render: what the worker needs besides the
+// target and the environment.
+type renderJobRequest struct {
+	Injectables map[string]any          `json:"injectables,omitempty"`
+	Headers     map[string]string       `json:"headers,omitempty"`
+	Imposition  *port.ImpositionOptions `json:"imposition,omitempty"`
+	Layout      *port.LayoutParams      `json:"layout,omitempty"`
+	Overlays    *portabledoc.Overlays   `json:"overlays,omitempty"`
+	DocumentID  string                  `json:"documentId,omitempty"`
+	Degraded    bool                    `json:"degraded,omitempty"`
+	UnitSystem  string                  `json:"unitSystem,omitempty"`
+}
+
+// NewRenderJobService creates a new render job service.
+// baseURL prefixes result links (public origin and base path); empty yields relative links.
+func NewRenderJobService(
+	jobRepo port.RenderJobRepository,
+	tenantRepo port.TenantRepository,
+	workspaceRepo port.WorkspaceRepository,
+	baseURL string,
+) templateuc.RenderJobUseCase {
+	return &RenderJobService{
+		jobRepo:       jobRepo,
+		tenantRepo:    tenantRepo,
+		workspaceRepo: workspaceRepo,
+		baseURL:       baseURL,
+	}
+}
+
+// RenderJobService implements render job submission and polling. Jobs are run by RenderJobRunner.
+type RenderJobService struct {
+	jobRepo       port.RenderJobRepository
+	tenantRepo    port.TenantRepository
+	workspaceRepo port.WorkspaceRepository
+	baseURL       string
+}
+
+// SubmitRenderJob queues a render in the caller's workspace and returns the job to poll.
+func (s *RenderJobService) SubmitRenderJob(ctx context.Context, cmd templateuc.SubmitRenderJobCommand) (*templateuc.RenderJobState, error) {
+	if cmd.Imposition != nil {
+		if err := cmd.Imposition.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	tenant, err := s.tenantRepo.FindByCode(ctx, cmd.TenantCode)
+	if err != nil {
+		return nil, fmt.Errorf("finding tenant: %w", err)
+	}
+	workspace, err := s.workspaceRepo.FindByCodeAndTenant(ctx, tenant.ID, cmd.WorkspaceCode)
+	if err != nil {
+		return nil, fmt.Errorf("finding workspace: %w", err)
+	}
+
+	request, err := json.Marshal(renderJobRequest{
+		Injectables: cmd.Injectables,
+		Headers:     renderJobHeaders(cmd.Headers),
+		Imposition:  cmd.Imposition,
+		Layout:      cmd.Layout,
+		Overlays:    cmd.Overlays,
+		DocumentID:  cmd.DocumentID,
+		Degraded:    cmd.Degraded,
+		UnitSystem:  cmd.UnitSystem,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding render job request: %w", err)
+	}
+
+	job := entity.NewRenderJob(workspace.ID, cmd.TenantCode, cmd.WorkspaceCode, cmd.Environment, request)
+	job.ID = uuid.NewString()
+	if cmd.DocumentTypeCode != "" {
+		job.DocumentTypeCode = &cmd.DocumentTypeCode
+	}
+	if cmd.VersionID != "" {
+		job.VersionID = &cmd.VersionID
+	}
+	if err := job.Validate(); err != nil {
+		return nil, fmt.Errorf("validating render job: %w", err)
+	}
+
+	id, err := s.jobRepo.Create(ctx, job)
+	if err != nil {
+		return nil, fmt.Errorf("creating render job: %w", err)
+	}
+	job.ID = id
+
+	slog.InfoContext(ctx, "render job submitted",
+		slog.String("render_job_id", id),
+		slog.String("workspace_id", workspace.ID),
+		slog.String("environment", string(job.Environment)),
+	)
+	return s.state(job), nil
+}
+
+// GetRenderJob returns a job of the caller's workspace.
+func (s *RenderJobService) GetRenderJob(ctx context.Context, tenantCode, workspaceCode, jobID string) (*templateuc.RenderJobState, error) {
+	job, err := s.jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if !ownsRenderJob(job, tenantCode, workspaceCode) {
+		return nil, entity.ErrRenderJobNotFound
+	}
+	return s.state(job), nil
+}
+
+// GetRenderJobResult returns a succeeded job of the caller's workspace with its PDF.
+func (s *RenderJobService) GetRenderJobResult(ctx context.Context, tenantCode, workspaceCode, jobID string) (*entity.RenderJob, error) {
+	job, err := s.jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if !ownsRenderJob(job, tenantCode, workspaceCode) {
+		return nil, entity.ErrRenderJobNotFound
+	}
+	if job.Status != entity.RenderJobSucceeded {
+		return nil, entity.ErrRenderJobNotReady
+	}
+
+	job.PDF, err = s.jobRepo.FindPDF(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// state adds the result link to a job that succeeded.
+func (s *RenderJobService) state(job *entity.RenderJob) *templateuc.RenderJobState {
+	state := &templateuc.RenderJobState{Job: job}
+	if job.Status == entity.RenderJobSucceeded {
+		state.ResultURL = s.baseURL + "/api/v1/workspace/render/jobs/" + job.ID + "/result"
+	}
+	return state
+}
+
+// ownsRenderJob reports whether an unexpired job was submitted for the workspace.
+func ownsRenderJob(job *entity.RenderJob, tenantCode, workspaceCode string) bool {
+	return !job.IsExpired() && job.TenantCode == tenantCode && job.WorkspaceCode == workspaceCode
+}
+
+// renderJobHeaders returns the headers of a submitted render without its credentials.
+func renderJobHeaders(headers map[string]string) map[string]string {
+	kept := make(map[string]string, len(headers))
+	for k, v := range headers {
+		if !renderJobDroppedHeaders[strings.ToLower(k)] {
+			kept[k] = v
+		}
+	}
+	return kept
+}
@@ -0,0 +1,122 @@
+package template
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+const (
+	// renderStatsSamples bounds the renders kept per version; older ones are overwritten.
+	renderStatsSamples = 100
+
+	// renderStatsMaxVersions bounds the versions kept; the one rendered least recently is dropped first.
+	renderStatsMaxVersions = 1000
+)
+
+type renderSample struct {
+	pageCount int
+	duration  time.Duration
+	at        time.Time
+}
+
+// versionRenderStats holds the latest render samples of a version in a ring.
+type versionRenderStats struct {
+	samples [renderStatsSamples]renderSample
+	next    int
+	count   int
+	last    time.Time
+}
+
+// RenderStatsCache keeps the durations and page counts of recent renders per template version in
+// memory, for render estimates. Statistics are per instance and reset on restart.
+type RenderStatsCache struct {
+	mu       sync.Mutex
+	versions map[string]*versionRenderStats
+}
+
+// NewRenderStatsCache creates an empty render statistics cache.
+func NewRenderStatsCache() *RenderStatsCache {
+	return &RenderStatsCache{versions: make(map[string]*versionRenderStats)}
+}
+
+// Record adds a completed render of a version.
+func (c *RenderStatsCache) Record(versionID string, pageCount int, duration time.Duration) {
+	if c == nil {
+		return
+	}
+	now := time.Now().UTC()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats, ok := c.versions[versionID]
+	if !ok {
+		if len(c.versions) >= renderStatsMaxVersions {
+			c.evictOldest()
+		}
+		stats = &versionRenderStats{}
+		c.versions[versionID] = stats
+	}
+	stats.samples[stats.next] = renderSample{pageCount: pageCount, duration: duration, at: now}
+	stats.next = (stats.next + 1) % renderStatsSamples
+	stats.count = min(stats.count+1, renderStatsSamples)
+	stats.last = now
+}
+
+// evictOldest drops the version rendered least recently. Callers hold mu.
+func (c *RenderStatsCache) evictOldest() {
+	var oldestID string
+	var oldest time.Time
+	for id, stats := range c.versions {
+		if oldestID == "" || stats.last.Before(oldest) {
+			oldestID, oldest = id, stats.last
+		}
+	}
+	delete(c.versions, oldestID)
+}
+
+// Get summarizes the recorded renders of a version, or returns nil when there are none.
+func (c *RenderStatsCache) Get(versionID string) *entity.RenderStatistics {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	stats, ok := c.versions[versionID]
+	var samples []renderSample
+	if ok {
+		samples = slices.Clone(stats.samples[:stats.count])
+	}
+	c.mu.Unlock()
+	if len(samples) == 0 {
+		return nil
+	}
+
+	result := &entity.RenderStatistics{Renders: len(samples)}
+	durations := make([]time.Duration, len(samples))
+	var pages int
+	var total time.Duration
+	for i, s := range samples {
+		pages += s.pageCount
+		total += s.duration
+		durations[i] = s.duration
+		result.MaxPageCount = max(result.MaxPageCount, s.pageCount)
+		if s.at.After(result.LastRenderedAt) {
+			result.LastRenderedAt = s.at
+		}
+	}
+	slices.Sort(durations)
+
+	result.AvgPageCount = float64(pages) / float64(len(samples))
+	result.AvgDurationMs = durationMs(total / time.Duration(len(samples)))
+	result.P95DurationMs = durationMs(durations[(len(durations)*95+99)/100-1])
+	return result
+}
+
+// durationMs converts a duration to fractional milliseconds.
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
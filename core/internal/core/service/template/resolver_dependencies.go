@@ -30,7 +30,7 @@ type templateResolverTemplateVersionRepository interface {
 	FindPublishedByTemplateIDWithDetails(ctx context.Context, templateID string) (*entity.TemplateVersionWithDetails, error)
 	FindStagingByTemplateIDWithDetails(ctx context.Context, templateID string) (*entity.TemplateVersionWithDetails, error)
 	FindByIDWithDetails(ctx context.Context, id string) (*entity.TemplateVersionWithDetails, error)
-	FindByTemplateID(ctx context.Context, templateID string) ([]*entity.TemplateVersion, error)
+	FindMetadataByTemplateID(ctx context.Context, templateID string) ([]*entity.TemplateVersion, error)
 }
 
 type templateResolutionCache interface {
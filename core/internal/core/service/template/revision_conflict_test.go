@@ -0,0 +1,139 @@
+package template
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+func TestUpdateVersion_StaleRevisionConflicts(t *testing.T) {
+	versions := &fakeRevisionVersionRepo{current: &entity.TemplateVersion{ID: "v-1", TemplateID: "t-1", VersionNumber: 1, Name: "v1", Status: entity.VersionStatusDraft, Revision: 4}}
+	s := &TemplateVersionService{versionRepo: versions}
+	name := "renamed"
+	revision := 3
+
+	_, err := s.UpdateVersion(context.Background(), templateuc.UpdateVersionCommand{ID: "v-1", Name: &name, ExpectedRevision: &revision})
+
+	var conflict *entity.RevisionConflictError
+	require.ErrorAs(t, err, &conflict)
+	require.ErrorIs(t, err, entity.ErrRevisionConflict)
+	assert.Equal(t, 4, conflict.Current.(*entity.TemplateVersion).Revision)
+	assert.Zero(t, versions.updates, "a stale update must not be written")
+}
+
+func TestUpdateVersion_LostRaceReturnsCurrentVersion(t *testing.T) {
+	versions := &fakeRevisionVersionRepo{
+		current:     &entity.TemplateVersion{ID: "v-1", TemplateID: "t-1", VersionNumber: 1, Name: "v1", Status: entity.VersionStatusDraft, Revision: 4},
+		conflicting: true,
+	}
+	s := &TemplateVersionService{versionRepo: versions}
+	name := "renamed"
+	revision := 4
+
+	_, err := s.UpdateVersion(context.Background(), templateuc.UpdateVersionCommand{ID: "v-1", Name: &name, ExpectedRevision: &revision})
+
+	var conflict *entity.RevisionConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, 5, conflict.Current.(*entity.TemplateVersion).Revision)
+}
+
+func TestUpdateVersion_CurrentRevisionIsWritten(t *testing.T) {
+	versions := &fakeRevisionVersionRepo{current: &entity.TemplateVersion{ID: "v-1", TemplateID: "t-1", VersionNumber: 1, Name: "v1", Status: entity.VersionStatusDraft, Revision: 4}}
+	s := &TemplateVersionService{versionRepo: versions}
+	name := "renamed"
+	revision := 4
+
+	version, err := s.UpdateVersion(context.Background(), templateuc.UpdateVersionCommand{ID: "v-1", Name: &name, ExpectedRevision: &revision})
+
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", version.Name)
+	assert.Equal(t, 1, versions.updates)
+}
+
+func TestUpdateTemplate_StaleRevisionConflicts(t *testing.T) {
+	templates := &fakeRevisionTemplateRepo{current: &entity.Template{ID: "t-1", WorkspaceID: "ws-1", Title: "Offer", Revision: 7}}
+	s := &TemplateService{templateRepo: templates}
+	title := "Offer letter"
+	revision := 6
+
+	_, err := s.UpdateTemplate(context.Background(), templateuc.UpdateTemplateCommand{ID: "t-1", Title: &title, ExpectedRevision: &revision})
+
+	var conflict *entity.RevisionConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, 7, conflict.Current.(*entity.Template).Revision)
+	assert.Zero(t, templates.updates)
+}
+
+func TestUpdateTemplate_LostRaceReturnsCurrentTemplate(t *testing.T) {
+	templates := &fakeRevisionTemplateRepo{
+		current:     &entity.Template{ID: "t-1", WorkspaceID: "ws-1", Title: "Offer", Revision: 7},
+		conflicting: true,
+	}
+	s := &TemplateService{templateRepo: templates}
+	folder := "root"
+	revision := 7
+
+	_, err := s.UpdateTemplate(context.Background(), templateuc.UpdateTemplateCommand{ID: "t-1", FolderID: &folder, ExpectedRevision: &revision})
+
+	var conflict *entity.RevisionConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, 8, conflict.Current.(*entity.Template).Revision)
+}
+
+// fakeRevisionVersionRepo hands out copies so the service cannot mutate the stored version.
+// When conflicting is set, Update behaves as if another writer got there first.
+type fakeRevisionVersionRepo struct {
+	port.TemplateVersionRepository
+	current     *entity.TemplateVersion
+	conflicting bool
+	updates     int
+}
+
+func (f *fakeRevisionVersionRepo) FindByID(context.Context, string) (*entity.TemplateVersion, error) {
+	v := *f.current
+	return &v, nil
+}
+
+func (f *fakeRevisionVersionRepo) ExistsByNameExcluding(context.Context, string, string, string) (bool, error) {
+	return false, nil
+}
+
+func (f *fakeRevisionVersionRepo) Update(context.Context, *entity.TemplateVersion) error {
+	if f.conflicting {
+		f.current.Revision++
+		return entity.ErrRevisionConflict
+	}
+	f.updates++
+	return nil
+}
+
+type fakeRevisionTemplateRepo struct {
+	port.TemplateRepository
+	current     *entity.Template
+	conflicting bool
+	updates     int
+}
+
+func (f *fakeRevisionTemplateRepo) FindByID(context.Context, string) (*entity.Template, error) {
+	t := *f.current
+	return &t, nil
+}
+
+func (f *fakeRevisionTemplateRepo) ExistsByTitleExcluding(context.Context, string, string, string) (bool, error) {
+	return false, nil
+}
+
+func (f *fakeRevisionTemplateRepo) Update(context.Context, *entity.Template) error {
+	if f.conflicting {
+		f.current.Revision++
+		return entity.ErrRevisionConflict
+	}
+	f.updates++
+	return nil
+}
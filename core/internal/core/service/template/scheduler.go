@@ -0,0 +1,68 @@
+package template
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// Scheduler runs due scheduled publications and archivals periodically.
+// Run it on a single instance; concurrent schedulers record the losing attempt as a failed run.
+type Scheduler struct {
+	versionUC templateuc.TemplateVersionUseCase
+	interval  time.Duration
+	stopCh    chan struct{}
+	stopped   chan struct{}
+	stopOnce  sync.Once
+}
+
+// NewScheduler creates a scheduler that ticks every interval. Call Start to begin.
+func NewScheduler(versionUC templateuc.TemplateVersionUseCase, interval time.Duration) *Scheduler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &Scheduler{
+		versionUC: versionUC,
+		interval:  interval,
+		stopCh:    make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}
+}
+
+// Start runs the scheduler loop in the background until Stop is called.
+func (s *Scheduler) Start() {
+	go s.loop()
+}
+
+// Stop ends the loop and waits for the tick in flight to finish.
+func (s *Scheduler) Stop() {
+	s.stopOnce.Do(func() { close(s.stopCh) })
+	<-s.stopped
+}
+
+func (s *Scheduler) loop() {
+	defer close(s.stopped)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.tick(context.Background())
+		}
+	}
+}
+
+func (s *Scheduler) tick(ctx context.Context) {
+	if err := s.versionUC.ProcessScheduledPublications(ctx); err != nil {
+		slog.ErrorContext(ctx, "scheduled publications run failed", slog.Any("error", err))
+	}
+	if err := s.versionUC.ProcessScheduledArchivals(ctx); err != nil {
+		slog.ErrorContext(ctx, "scheduled archivals run failed", slog.Any("error", err))
+	}
+}
@@ -0,0 +1,538 @@
+package template
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	cataloguc "github.com/rendis/pdf-forge/core/internal/core/usecase/catalog"
+	injectableuc "github.com/rendis/pdf-forge/core/internal/core/usecase/injectable"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// bundleManifestName is the manifest entry of every bundle.
+const bundleManifestName = "manifest.json"
+
+var (
+	// bundleAssetRefPattern matches asset:// references inside template content JSON.
+	bundleAssetRefPattern = regexp.MustCompile(`asset://([0-9a-fA-F-]{36})`)
+	// bundleStorageRefPattern matches storage:// references inside template content JSON.
+	bundleStorageRefPattern = regexp.MustCompile(`storage://[^"\\\s?]+`)
+)
+
+// NewTemplateBundleService creates a new template bundle service.
+func NewTemplateBundleService(
+	templateRepo port.TemplateRepository,
+	versionRepo port.TemplateVersionRepository,
+	injectableUC injectableuc.InjectableUseCase,
+	workspaceInjectableUC injectableuc.WorkspaceInjectableUseCase,
+	assetUC cataloguc.AssetUseCase,
+	versionUC templateuc.TemplateVersionUseCase,
+	txManager port.TransactionManager,
+) templateuc.TemplateBundleUseCase {
+	return &TemplateBundleService{
+		templateRepo:          templateRepo,
+		versionRepo:           versionRepo,
+		injectableUC:          injectableUC,
+		workspaceInjectableUC: workspaceInjectableUC,
+		assetUC:               assetUC,
+		versionUC:             versionUC,
+		txManager:             txManager,
+	}
+}
+
+// TemplateBundleService implements template bundle export and import.
+type TemplateBundleService struct {
+	templateRepo          port.TemplateRepository
+	versionRepo           port.TemplateVersionRepository
+	injectableUC          injectableuc.InjectableUseCase
+	workspaceInjectableUC injectableuc.WorkspaceInjectableUseCase
+	assetUC               cataloguc.AssetUseCase
+	versionUC             templateuc.TemplateVersionUseCase
+	txManager             port.TransactionManager
+}
+
+// ExportBundle packs a template with its versions, injectables and library assets.
+func (s *TemplateBundleService) ExportBundle(ctx context.Context, workspaceID, templateID string) (*templateuc.TemplateBundle, error) {
+	template, err := s.templateRepo.FindByID(ctx, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("finding template: %w", err)
+	}
+	if template.WorkspaceID != workspaceID {
+		return nil, entity.ErrTemplateNotFound
+	}
+
+	versions, err := s.versionRepo.FindByTemplateID(ctx, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("listing versions: %w", err)
+	}
+	slices.SortFunc(versions, func(a, b *entity.TemplateVersion) int { return a.VersionNumber - b.VersionNumber })
+
+	manifest := &entity.TemplateBundleManifest{
+		FormatVersion: entity.TemplateBundleFormatVersion,
+		ExportedAt:    time.Now().UTC(),
+		Template: entity.TemplateBundleTemplate{
+			Title:           template.Title,
+			IsPublicLibrary: template.IsPublicLibrary,
+		},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	keys := make(portabledoc.Set[string])
+	assetIDs := make(portabledoc.Set[string])
+	for _, v := range versions {
+		bundled := entity.TemplateBundleVersion{
+			VersionNumber: v.VersionNumber,
+			Name:          v.Name,
+			Description:   v.Description,
+			Status:        v.Status,
+			File:          fmt.Sprintf("versions/v%d.json", v.VersionNumber),
+		}
+		if err := writeBundleEntry(zw, bundled.File, v.ContentStructure); err != nil {
+			return nil, err
+		}
+		manifest.Versions = append(manifest.Versions, bundled)
+
+		collectBundleReferences(v.ContentStructure, keys, assetIDs)
+	}
+
+	if manifest.Injectables, err = s.bundleInjectables(ctx, workspaceID, keys); err != nil {
+		return nil, err
+	}
+	if manifest.Assets, err = s.bundleAssets(ctx, zw, workspaceID, assetIDs); err != nil {
+		return nil, err
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshaling bundle manifest: %w", err)
+	}
+	if err := writeBundleEntry(zw, bundleManifestName, manifestJSON); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("closing bundle: %w", err)
+	}
+
+	slog.InfoContext(ctx, "template bundle exported",
+		slog.String("template_id", templateID),
+		slog.Int("versions", len(manifest.Versions)),
+		slog.Int("injectables", len(manifest.Injectables)),
+		slog.Int("assets", len(manifest.Assets)),
+	)
+
+	return &templateuc.TemplateBundle{
+		Filename: safeFilename(template.Title) + ".pdfforge.zip",
+		Data:     buf.Bytes(),
+	}, nil
+}
+
+// bundleInjectables returns the definitions of the injectables with the given keys.
+// Keys no longer defined in the workspace are skipped: renders already leave them empty.
+func (s *TemplateBundleService) bundleInjectables(ctx context.Context, workspaceID string, keys portabledoc.Set[string]) ([]entity.TemplateBundleInjectable, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	available, err := s.injectableUC.ListInjectables(ctx, &injectableuc.ListInjectablesRequest{WorkspaceID: workspaceID})
+	if err != nil {
+		return nil, fmt.Errorf("listing injectables: %w", err)
+	}
+
+	var injectables []entity.TemplateBundleInjectable
+	for _, def := range available.Injectables {
+		if !keys.Contains(def.Key) {
+			continue
+		}
+		injectables = append(injectables, entity.TemplateBundleInjectable{
+			Key:          def.Key,
+			Label:        def.Label,
+			Description:  def.Description,
+			Labels:       def.Labels,
+			Descriptions: def.Descriptions,
+			DataType:     def.DataType,
+			DefaultValue: def.DefaultValue,
+			Metadata:     def.Metadata,
+			Formula:      def.Formula,
+			Workspace:    def.WorkspaceID != nil && *def.WorkspaceID == workspaceID,
+		})
+		keys.Remove(def.Key)
+	}
+	slices.SortFunc(injectables, func(a, b entity.TemplateBundleInjectable) int { return strings.Compare(a.Key, b.Key) })
+	return injectables, nil
+}
+
+// bundleAssets writes the current content of the referenced library assets to the bundle.
+// Assets deleted since they were referenced are skipped with a warning.
+func (s *TemplateBundleService) bundleAssets(ctx context.Context, zw *zip.Writer, workspaceID string, ids portabledoc.Set[string]) ([]entity.TemplateBundleAsset, error) {
+	sorted := ids.ToSlice()
+	slices.Sort(sorted)
+
+	var assets []entity.TemplateBundleAsset
+	for _, id := range sorted {
+		asset, err := s.assetUC.GetAsset(ctx, workspaceID, id)
+		if errors.Is(err, entity.ErrAssetNotFound) {
+			slog.WarnContext(ctx, "skipping missing asset in template bundle", slog.String("asset_id", id))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		content, err := s.assetUC.GetAssetContent(ctx, workspaceID, id, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		bundled := entity.TemplateBundleAsset{
+			ID:          asset.ID,
+			Name:        asset.Name,
+			Kind:        asset.Kind,
+			Tags:        asset.Tags,
+			ContentType: content.ContentType,
+			SHA256:      content.SHA256,
+			File:        fmt.Sprintf("assets/%05d", len(assets)+1),
+		}
+		if err := writeBundleEntry(zw, bundled.File, content.Data); err != nil {
+			return nil, err
+		}
+		assets = append(assets, bundled)
+	}
+	return assets, nil
+}
+
+// ImportBundle creates a template from a bundle in a single transaction.
+func (s *TemplateBundleService) ImportBundle(ctx context.Context, cmd templateuc.ImportTemplateBundleCommand) (*templateuc.ImportTemplateBundleResult, error) {
+	bundle, err := openTemplateBundle(cmd.Bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(cmd.Title)
+	if title == "" {
+		title = bundle.manifest.Template.Title
+	}
+
+	report := &entity.TemplateBundleReport{}
+	result := &templateuc.ImportTemplateBundleResult{Report: report}
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		template, err := s.createBundleTemplate(ctx, cmd, title, bundle.manifest.Template.IsPublicLibrary)
+		if err != nil {
+			return err
+		}
+		result.Template = template
+
+		if err := s.importInjectables(ctx, cmd.WorkspaceID, bundle.manifest.Injectables, report); err != nil {
+			return err
+		}
+		renamed, err := s.importAssets(ctx, cmd, bundle, report)
+		if err != nil {
+			return err
+		}
+
+		result.Versions, err = s.importVersions(ctx, cmd.ImportedBy, template.ID, bundle, renamed, report)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "template bundle imported",
+		slog.String("template_id", result.Template.ID),
+		slog.String("workspace_id", cmd.WorkspaceID),
+		slog.Int("versions", len(result.Versions)),
+		slog.Int("created_injectables", len(report.CreatedInjectables)),
+		slog.Int("missing_injectables", len(report.MissingInjectables)),
+		slog.Int("created_assets", report.CreatedAssets),
+		slog.Int("reused_assets", report.ReusedAssets),
+	)
+
+	return result, nil
+}
+
+func (s *TemplateBundleService) createBundleTemplate(ctx context.Context, cmd templateuc.ImportTemplateBundleCommand, title string, isPublicLibrary bool) (*entity.Template, error) {
+	exists, err := s.templateRepo.ExistsByTitle(ctx, cmd.WorkspaceID, title)
+	if err != nil {
+		return nil, fmt.Errorf("checking template title: %w", err)
+	}
+	if exists {
+		return nil, entity.ErrTemplateAlreadyExists
+	}
+
+	template := &entity.Template{
+		ID:              uuid.NewString(),
+		WorkspaceID:     cmd.WorkspaceID,
+		FolderID:        cmd.FolderID,
+		Title:           title,
+		IsPublicLibrary: isPublicLibrary,
+		CreatedAt:       time.Now().UTC(),
+	}
+	if err := template.Validate(); err != nil {
+		return nil, fmt.Errorf("validating template: %w", err)
+	}
+
+	id, err := s.templateRepo.Create(ctx, template)
+	if err != nil {
+		return nil, fmt.Errorf("creating template: %w", err)
+	}
+	template.ID = id
+	return template, nil
+}
+
+// importInjectables creates the bundled workspace injectables the target workspace lacks and
+// reports the other injectables it does not provide.
+func (s *TemplateBundleService) importInjectables(ctx context.Context, workspaceID string, injectables []entity.TemplateBundleInjectable, report *entity.TemplateBundleReport) error {
+	if len(injectables) == 0 {
+		return nil
+	}
+	available, err := s.injectableUC.ListInjectables(ctx, &injectableuc.ListInjectablesRequest{WorkspaceID: workspaceID})
+	if err != nil {
+		return fmt.Errorf("listing injectables: %w", err)
+	}
+	keys := make(portabledoc.Set[string], len(available.Injectables))
+	for _, def := range available.Injectables {
+		keys.Add(def.Key)
+	}
+
+	for _, inj := range injectables {
+		if keys.Contains(inj.Key) {
+			continue
+		}
+		if !inj.Workspace {
+			report.MissingInjectables = append(report.MissingInjectables, inj.Key)
+			continue
+		}
+
+		var defaultValue string
+		if inj.DefaultValue != nil {
+			defaultValue = *inj.DefaultValue
+		}
+		if _, err := s.workspaceInjectableUC.CreateInjectable(ctx, injectableuc.CreateWorkspaceInjectableCommand{
+			WorkspaceID:  workspaceID,
+			Key:          inj.Key,
+			Label:        inj.Label,
+			Description:  inj.Description,
+			DefaultValue: defaultValue,
+			Metadata:     inj.Metadata,
+			Formula:      inj.Formula,
+			DataType:     inj.DataType,
+		}); err != nil {
+			return fmt.Errorf("creating injectable %s: %w", inj.Key, err)
+		}
+		report.CreatedInjectables = append(report.CreatedInjectables, inj.Key)
+	}
+	return nil
+}
+
+// importAssets adds the bundled assets to the workspace library and returns the new ID of each
+// bundled asset ID. Assets whose content the library already has are reused.
+func (s *TemplateBundleService) importAssets(ctx context.Context, cmd templateuc.ImportTemplateBundleCommand, bundle *templateBundle, report *entity.TemplateBundleReport) (map[string]string, error) {
+	renamed := make(map[string]string, len(bundle.manifest.Assets))
+	for _, asset := range bundle.manifest.Assets {
+		data, err := bundle.read(asset.File)
+		if err != nil {
+			return nil, err
+		}
+		uploaded, err := s.assetUC.UploadAsset(ctx, cataloguc.UploadAssetCommand{
+			WorkspaceID: cmd.WorkspaceID,
+			Name:        asset.Name,
+			Tags:        asset.Tags,
+			Data:        data,
+			CreatedBy:   cmd.ImportedBy,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("importing asset %s: %w", asset.Name, err)
+		}
+		if uploaded.Duplicate {
+			report.ReusedAssets++
+		} else {
+			report.CreatedAssets++
+		}
+		renamed[asset.ID] = uploaded.Asset.ID
+	}
+	return renamed, nil
+}
+
+// importVersions recreates the bundled versions, pointing their asset references at the imported
+// assets. Archived versions are archived directly; the published version goes through publishing.
+func (s *TemplateBundleService) importVersions(
+	ctx context.Context,
+	importedBy, templateID string,
+	bundle *templateBundle,
+	renamed map[string]string,
+	report *entity.TemplateBundleReport,
+) ([]*entity.TemplateVersion, error) {
+	storageRefs := make(portabledoc.Set[string])
+	versions := make([]*entity.TemplateVersion, 0, len(bundle.manifest.Versions))
+	var published *entity.TemplateVersion
+
+	for _, bundled := range bundle.manifest.Versions {
+		content, err := bundle.read(bundled.File)
+		if err != nil {
+			return nil, err
+		}
+		for oldID, newID := range renamed {
+			content = bytes.ReplaceAll(content, []byte(entity.AssetURLScheme+oldID), []byte(entity.AssetURLScheme+newID))
+		}
+		for _, ref := range bundleStorageRefPattern.FindAll(content, -1) {
+			storageRefs.Add(string(ref))
+		}
+
+		version := entity.NewTemplateVersion(templateID, bundled.VersionNumber, bundled.Name, optionalImporter(importedBy))
+		version.ID = uuid.NewString()
+		version.Description = bundled.Description
+		if len(content) > 0 && !bytes.Equal(content, []byte("null")) {
+			version.ContentStructure = content
+		}
+		if err := version.Validate(); err != nil {
+			return nil, fmt.Errorf("validating version %d: %w", bundled.VersionNumber, err)
+		}
+
+		id, err := s.versionRepo.Create(ctx, version)
+		if err != nil {
+			return nil, fmt.Errorf("creating version %d: %w", bundled.VersionNumber, err)
+		}
+		version.ID = id
+
+		switch bundled.Status {
+		case entity.VersionStatusArchived:
+			version.Archive(importedBy)
+			if err := s.versionRepo.Update(ctx, version); err != nil {
+				return nil, fmt.Errorf("archiving version %d: %w", bundled.VersionNumber, err)
+			}
+		case entity.VersionStatusPublished:
+			published = version
+		}
+		versions = append(versions, version)
+	}
+
+	if published != nil {
+		if err := s.versionUC.PublishVersion(ctx, published.ID, importedBy); err != nil {
+			return nil, fmt.Errorf("publishing version %d: %w", published.VersionNumber, err)
+		}
+		published.Publish(importedBy)
+	}
+
+	report.StorageReferences = storageRefs.ToSlice()
+	slices.Sort(report.StorageReferences)
+	return versions, nil
+}
+
+// templateBundle is an opened bundle archive.
+type templateBundle struct {
+	manifest *entity.TemplateBundleManifest
+	files    map[string]*zip.File
+}
+
+// openTemplateBundle reads the manifest of a bundle and checks that every file it lists is present.
+func openTemplateBundle(data []byte) (*templateBundle, error) {
+	if len(data) > entity.TemplateBundleMaxSize {
+		return nil, entity.ErrTemplateBundleTooLarge
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", entity.ErrInvalidTemplateBundle, err)
+	}
+
+	bundle := &templateBundle{files: make(map[string]*zip.File, len(zr.File))}
+	for _, f := range zr.File {
+		bundle.files[f.Name] = f
+	}
+
+	raw, err := bundle.read(bundleManifestName)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &bundle.manifest); err != nil || bundle.manifest == nil {
+		return nil, fmt.Errorf("%w: unreadable manifest", entity.ErrInvalidTemplateBundle)
+	}
+	if bundle.manifest.FormatVersion > entity.TemplateBundleFormatVersion {
+		return nil, entity.ErrUnsupportedTemplateBundle
+	}
+	if bundle.manifest.FormatVersion < 1 || len(bundle.manifest.Versions) == 0 {
+		return nil, fmt.Errorf("%w: the manifest lists no versions", entity.ErrInvalidTemplateBundle)
+	}
+	for _, v := range bundle.manifest.Versions {
+		if _, ok := bundle.files[v.File]; !ok {
+			return nil, fmt.Errorf("%w: missing %s", entity.ErrInvalidTemplateBundle, v.File)
+		}
+	}
+	for _, a := range bundle.manifest.Assets {
+		if _, ok := bundle.files[a.File]; !ok {
+			return nil, fmt.Errorf("%w: missing %s", entity.ErrInvalidTemplateBundle, a.File)
+		}
+	}
+	return bundle, nil
+}
+
+// read returns the content of a bundle file, bounded so a crafted archive cannot expand past
+// the bundle size limit.
+func (b *templateBundle) read(name string) ([]byte, error) {
+	f, ok := b.files[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: missing %s", entity.ErrInvalidTemplateBundle, name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", entity.ErrInvalidTemplateBundle, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, entity.TemplateBundleMaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", entity.ErrInvalidTemplateBundle, err)
+	}
+	if len(data) > entity.TemplateBundleMaxSize {
+		return nil, entity.ErrTemplateBundleTooLarge
+	}
+	return data, nil
+}
+
+// collectBundleReferences adds the injectable keys and library asset IDs a version content references.
+func collectBundleReferences(content json.RawMessage, keys, assetIDs portabledoc.Set[string]) {
+	for _, m := range bundleAssetRefPattern.FindAllSubmatch(content, -1) {
+		assetIDs.Add(string(m[1]))
+	}
+	if len(content) == 0 {
+		return
+	}
+	doc, err := portabledoc.Parse(content)
+	if err != nil {
+		return
+	}
+	for _, key := range doc.VariableIDs {
+		keys.Add(key)
+	}
+}
+
+func writeBundleEntry(zw *zip.Writer, name string, data []byte) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	return nil
+}
+
+func optionalImporter(userID string) *string {
+	if userID == "" {
+		return nil
+	}
+	return &userID
+}
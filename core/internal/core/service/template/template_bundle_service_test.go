@@ -0,0 +1,97 @@
+package template
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+func testBundle(t *testing.T, manifest *entity.TemplateBundleManifest, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	if manifest != nil {
+		raw, err := json.Marshal(manifest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := writeBundleEntry(zw, bundleManifestName, raw); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, content := range files {
+		if err := writeBundleEntry(zw, name, []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestOpenTemplateBundle(t *testing.T) {
+	valid := &entity.TemplateBundleManifest{
+		FormatVersion: entity.TemplateBundleFormatVersion,
+		Template:      entity.TemplateBundleTemplate{Title: "Contract"},
+		Versions:      []entity.TemplateBundleVersion{{VersionNumber: 1, Name: "v1", File: "versions/v1.json"}},
+	}
+
+	bundle, err := openTemplateBundle(testBundle(t, valid, map[string]string{"versions/v1.json": `{"version":"2.2.0"}`}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content, err := bundle.read("versions/v1.json")
+	if err != nil || string(content) != `{"version":"2.2.0"}` {
+		t.Errorf("expected the version content, got %q, %v", content, err)
+	}
+
+	newer := *valid
+	newer.FormatVersion = entity.TemplateBundleFormatVersion + 1
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"not a zip", []byte("not a zip"), entity.ErrInvalidTemplateBundle},
+		{"no manifest", testBundle(t, nil, map[string]string{"versions/v1.json": "{}"}), entity.ErrInvalidTemplateBundle},
+		{"missing version file", testBundle(t, valid, nil), entity.ErrInvalidTemplateBundle},
+		{"newer format", testBundle(t, &newer, map[string]string{"versions/v1.json": "{}"}), entity.ErrUnsupportedTemplateBundle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := openTemplateBundle(tt.data); !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestCollectBundleReferences(t *testing.T) {
+	content := json.RawMessage(`{
+		"version": "2.2.0",
+		"meta": {"title": "Contract", "language": "en"},
+		"pageConfig": {"formatId": "A4", "width": 794, "height": 1123, "margins": {"top": 72, "bottom": 72, "left": 72, "right": 72}},
+		"variableIds": ["client_name", "amount"],
+		"content": {"type": "doc", "content": [
+			{"type": "customImage", "attrs": {"src": "asset://0b5c5e1c-8a55-4a8e-9d7e-2f1f3c1d2e3f?w=800"}}
+		]}
+	}`)
+
+	keys := make(portabledoc.Set[string])
+	assetIDs := make(portabledoc.Set[string])
+	collectBundleReferences(content, keys, assetIDs)
+
+	if !keys.Contains("client_name") || !keys.Contains("amount") || keys.Len() != 2 {
+		t.Errorf("expected the variable keys, got %v", keys.ToSlice())
+	}
+	if !assetIDs.Contains("0b5c5e1c-8a55-4a8e-9d7e-2f1f3c1d2e3f") || assetIDs.Len() != 1 {
+		t.Errorf("expected the asset ID, got %v", assetIDs.ToSlice())
+	}
+}
@@ -0,0 +1,193 @@
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	"github.com/rendis/pdf-forge/core/internal/core/service/template/docximport"
+	"github.com/rendis/pdf-forge/core/internal/core/service/template/htmlimport"
+	"github.com/rendis/pdf-forge/core/internal/core/service/template/markdown"
+	injectableuc "github.com/rendis/pdf-forge/core/internal/core/usecase/injectable"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// templateConverter converts a template into a portable document bound to the given injectables.
+type templateConverter func(injectables []*entity.InjectableDefinition) (*portabledoc.Document, *entity.TemplateImportReport, error)
+
+// NewTemplateConversionService creates a new template conversion service.
+func NewTemplateConversionService(
+	versionRepo port.TemplateVersionRepository,
+	templateRepo port.TemplateRepository,
+	injectableUC injectableuc.InjectableUseCase,
+	versionUC templateuc.TemplateVersionUseCase,
+) templateuc.TemplateConversionUseCase {
+	return &TemplateConversionService{
+		versionRepo:  versionRepo,
+		templateRepo: templateRepo,
+		injectableUC: injectableUC,
+		versionUC:    versionUC,
+	}
+}
+
+// TemplateConversionService implements template import and export business logic.
+type TemplateConversionService struct {
+	versionRepo  port.TemplateVersionRepository
+	templateRepo port.TemplateRepository
+	injectableUC injectableuc.InjectableUseCase
+	versionUC    templateuc.TemplateVersionUseCase
+}
+
+// ImportHTML converts an HTML template and saves it as the content of a draft version.
+func (s *TemplateConversionService) ImportHTML(ctx context.Context, cmd templateuc.ImportTemplateCommand) (*templateuc.ImportResult, error) {
+	return s.importTemplate(ctx, cmd, "html", false, func(injectables []*entity.InjectableDefinition) (*portabledoc.Document, *entity.TemplateImportReport, error) {
+		return htmlimport.Convert(cmd.Source, injectables)
+	})
+}
+
+// ImportMarkdown converts a Markdown template and saves it as the content of a draft version.
+func (s *TemplateConversionService) ImportMarkdown(ctx context.Context, cmd templateuc.ImportTemplateCommand) (*templateuc.ImportResult, error) {
+	return s.importTemplate(ctx, cmd, "markdown", false, func(injectables []*entity.InjectableDefinition) (*portabledoc.Document, *entity.TemplateImportReport, error) {
+		return markdown.Import(cmd.Source, injectables)
+	})
+}
+
+// ImportDocx converts a Word document and saves it as the content of a draft version, or only
+// reports on the conversion for a dry run.
+func (s *TemplateConversionService) ImportDocx(ctx context.Context, cmd templateuc.ImportDocxCommand) (*templateuc.ImportResult, error) {
+	target := templateuc.ImportTemplateCommand{
+		WorkspaceID:      cmd.WorkspaceID,
+		VersionID:        cmd.VersionID,
+		ExpectedRevision: cmd.ExpectedRevision,
+	}
+	return s.importTemplate(ctx, target, "docx", cmd.DryRun, func(injectables []*entity.InjectableDefinition) (*portabledoc.Document, *entity.TemplateImportReport, error) {
+		return docximport.Import(cmd.Document, injectables, cmd.Mapping)
+	})
+}
+
+// importTemplate converts a template with the workspace injectables and, unless dryRun is set, saves
+// it as the content of a draft version. The content goes through UpdateVersion, so the draft-only
+// and revision checks apply. cmd.Source is not read: convert holds the template.
+func (s *TemplateConversionService) importTemplate(
+	ctx context.Context,
+	cmd templateuc.ImportTemplateCommand,
+	format string,
+	dryRun bool,
+	convert templateConverter,
+) (*templateuc.ImportResult, error) {
+	if _, err := s.findVersionInWorkspace(ctx, cmd.WorkspaceID, cmd.VersionID); err != nil {
+		return nil, err
+	}
+
+	injectables, err := s.injectableUC.ListInjectables(ctx, &injectableuc.ListInjectablesRequest{WorkspaceID: cmd.WorkspaceID})
+	if err != nil {
+		return nil, fmt.Errorf("listing injectables: %w", err)
+	}
+
+	doc, report, err := convert(injectables.Injectables)
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return &templateuc.ImportResult{Report: report}, nil
+	}
+
+	content, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling imported content: %w", err)
+	}
+
+	version, err := s.versionUC.UpdateVersion(ctx, templateuc.UpdateVersionCommand{
+		ID:               cmd.VersionID,
+		ContentStructure: content,
+		ExpectedRevision: cmd.ExpectedRevision,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "template imported",
+		slog.String("version_id", cmd.VersionID),
+		slog.String("format", format),
+		slog.Int("mapped_placeholders", len(report.MappedPlaceholders)),
+		slog.Int("unmapped_placeholders", len(report.UnmappedPlaceholders)),
+		slog.Int("unsupported_elements", len(report.UnsupportedElements)),
+	)
+
+	return &templateuc.ImportResult{Version: version, Report: report}, nil
+}
+
+// ExportMarkdown renders the content of a version as Markdown.
+func (s *TemplateConversionService) ExportMarkdown(ctx context.Context, workspaceID, versionID string) (*templateuc.MarkdownExport, error) {
+	template, err := s.findVersionInWorkspace(ctx, workspaceID, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := s.versionRepo.FindByID(ctx, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("finding version: %w", err)
+	}
+
+	doc := &portabledoc.Document{}
+	if len(version.ContentStructure) > 0 {
+		if doc, err = portabledoc.Parse(version.ContentStructure); err != nil {
+			return nil, fmt.Errorf("parsing version content: %w", err)
+		}
+	}
+
+	content, err := markdown.Export(doc)
+	if err != nil {
+		return nil, fmt.Errorf("exporting markdown: %w", err)
+	}
+
+	return &templateuc.MarkdownExport{
+		Filename: markdownFilename(template.Title, version.VersionNumber),
+		Content:  content,
+	}, nil
+}
+
+// findVersionInWorkspace returns the template of a version, or ErrVersionNotFound when
+// the version belongs to another workspace.
+func (s *TemplateConversionService) findVersionInWorkspace(ctx context.Context, workspaceID, versionID string) (*entity.Template, error) {
+	version, err := s.versionRepo.FindMetadataByID(ctx, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("finding version: %w", err)
+	}
+
+	template, err := s.templateRepo.FindByID(ctx, version.TemplateID)
+	if err != nil {
+		return nil, fmt.Errorf("finding template: %w", err)
+	}
+	if template.WorkspaceID != workspaceID {
+		return nil, entity.ErrVersionNotFound
+	}
+	return template, nil
+}
+
+// markdownFilename creates a safe filename from the template title and version number.
+func markdownFilename(title string, versionNumber int) string {
+	return fmt.Sprintf("%s-v%d.md", safeFilename(title), versionNumber)
+}
+
+// safeFilename turns a template title into a filename stem, keeping letters, digits, - and _.
+func safeFilename(title string) string {
+	safe := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			return r
+		}
+		if r == ' ' {
+			return '-'
+		}
+		return -1
+	}, title)
+	if safe == "" {
+		safe = "template"
+	}
+	return safe
+}
@@ -0,0 +1,388 @@
+package template
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	cataloguc "github.com/rendis/pdf-forge/core/internal/core/usecase/catalog"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// NewTemplateLibraryService creates a new template library service.
+func NewTemplateLibraryService(
+	templateRepo port.TemplateRepository,
+	versionRepo port.TemplateVersionRepository,
+	workspaceRepo port.WorkspaceRepository,
+	libraryRepo port.TemplateLibraryRepository,
+	assetUC cataloguc.AssetUseCase,
+	txManager port.TransactionManager,
+) templateuc.TemplateLibraryUseCase {
+	return &TemplateLibraryService{
+		templateRepo:  templateRepo,
+		versionRepo:   versionRepo,
+		workspaceRepo: workspaceRepo,
+		libraryRepo:   libraryRepo,
+		assetUC:       assetUC,
+		txManager:     txManager,
+	}
+}
+
+// TemplateLibraryService implements installing library templates and pulling their updates.
+type TemplateLibraryService struct {
+	templateRepo  port.TemplateRepository
+	versionRepo   port.TemplateVersionRepository
+	workspaceRepo port.WorkspaceRepository
+	libraryRepo   port.TemplateLibraryRepository
+	assetUC       cataloguc.AssetUseCase
+	txManager     port.TransactionManager
+}
+
+// InstallTemplate copies the published version of a library template into the workspace.
+func (s *TemplateLibraryService) InstallTemplate(ctx context.Context, cmd templateuc.InstallLibraryTemplateCommand) (*templateuc.InstallLibraryTemplateResult, error) {
+	source, err := s.libraryTemplate(ctx, cmd.WorkspaceID, cmd.SourceTemplateID)
+	if err != nil {
+		return nil, err
+	}
+	upstream, err := s.upstreamVersion(ctx, source.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(cmd.Title)
+	if title == "" {
+		title = source.Title
+	}
+
+	now := time.Now().UTC()
+	result := &templateuc.InstallLibraryTemplateResult{}
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		exists, err := s.templateRepo.ExistsByTitle(ctx, cmd.WorkspaceID, title)
+		if err != nil {
+			return fmt.Errorf("checking template title: %w", err)
+		}
+		if exists {
+			return entity.ErrTemplateAlreadyExists
+		}
+
+		link := &entity.TemplateLibraryLink{
+			SourceTemplateID:  source.ID,
+			BaseVersionID:     &upstream.ID,
+			BaseVersionNumber: upstream.VersionNumber,
+			AssetMap:          map[string]string{},
+			InstalledBy:       optionalImporter(cmd.InstalledBy),
+			InstalledAt:       now,
+			SyncedAt:          now,
+		}
+		link.BaseContent, err = s.copyAssets(ctx, source.WorkspaceID, cmd.WorkspaceID, cmd.InstalledBy, upstream.ContentStructure, link.AssetMap)
+		if err != nil {
+			return err
+		}
+
+		template := &entity.Template{
+			ID:          uuid.NewString(),
+			WorkspaceID: cmd.WorkspaceID,
+			FolderID:    cmd.FolderID,
+			Title:       title,
+			CreatedAt:   now,
+		}
+		if err := template.Validate(); err != nil {
+			return fmt.Errorf("validating template: %w", err)
+		}
+		if template.ID, err = s.templateRepo.Create(ctx, template); err != nil {
+			return fmt.Errorf("creating template: %w", err)
+		}
+
+		version := entity.NewTemplateVersion(template.ID, 1, upstream.Name, optionalImporter(cmd.InstalledBy))
+		version.ID = uuid.NewString()
+		version.Description = upstream.Description
+		version.ContentStructure = link.BaseContent
+		if version.ID, err = s.versionRepo.Create(ctx, version); err != nil {
+			return fmt.Errorf("creating version: %w", err)
+		}
+
+		link.TemplateID = template.ID
+		if err := s.libraryRepo.Create(ctx, link); err != nil {
+			return err
+		}
+
+		result.Template, result.Version, result.Link = template, version, link
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "library template installed",
+		slog.String("template_id", result.Template.ID),
+		slog.String("workspace_id", cmd.WorkspaceID),
+		slog.String("source_template_id", source.ID),
+		slog.Int("source_version", upstream.VersionNumber),
+		slog.Int("copied_assets", len(result.Link.AssetMap)),
+	)
+
+	return result, nil
+}
+
+// PreviewUpdate compares the local and upstream changes of an installed template since its base.
+func (s *TemplateLibraryService) PreviewUpdate(ctx context.Context, workspaceID, templateID string) (*entity.LibraryUpdatePreview, error) {
+	update, err := s.loadUpdate(ctx, workspaceID, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Assets new upstream are only copied on pull; until then they keep their upstream reference
+	upstreamContent := rewriteAssetRefs(update.upstream.ContentStructure, update.link.AssetMap)
+	merged, conflicts, err := mergeLibraryContent(update.link.BaseContent, update.local.ContentStructure, upstreamContent, entity.LibraryMergeKeepLocal)
+	if err != nil {
+		return nil, err
+	}
+
+	baseID := ""
+	if update.link.BaseVersionID != nil {
+		baseID = *update.link.BaseVersionID
+	}
+	base := &entity.TemplateVersion{ID: baseID, VersionNumber: update.link.BaseVersionNumber, ContentStructure: update.link.BaseContent}
+	upstream := *update.upstream
+	upstream.ContentStructure = upstreamContent
+
+	return &entity.LibraryUpdatePreview{
+		TemplateID:            templateID,
+		SourceTemplateID:      update.link.SourceTemplateID,
+		BaseVersionNumber:     update.link.BaseVersionNumber,
+		UpToDate:              update.upToDate(),
+		UpstreamVersionID:     update.upstream.ID,
+		UpstreamVersionNumber: update.upstream.VersionNumber,
+		LocalVersionID:        update.local.ID,
+		LocalVersionNumber:    update.local.VersionNumber,
+		UpstreamChanges:       buildVersionDiff(base, &upstream),
+		LocalChanges:          buildVersionDiff(base, update.local),
+		Conflicts:             conflicts,
+		Merged:                merged,
+	}, nil
+}
+
+// PullUpdate merges the published upstream version into a new draft of an installed template.
+func (s *TemplateLibraryService) PullUpdate(ctx context.Context, cmd templateuc.PullLibraryUpdateCommand) (*entity.TemplateVersion, error) {
+	if !cmd.Strategy.IsValid() {
+		return nil, entity.ErrInvalidLibraryMergeStrategy
+	}
+	update, err := s.loadUpdate(ctx, cmd.WorkspaceID, cmd.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	if update.upToDate() {
+		return nil, entity.ErrLibraryTemplateUpToDate
+	}
+
+	var version *entity.TemplateVersion
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		link := update.link
+		upstreamContent, err := s.copyAssets(ctx, update.sourceWorkspaceID, cmd.WorkspaceID, cmd.PulledBy, update.upstream.ContentStructure, link.AssetMap)
+		if err != nil {
+			return err
+		}
+		merged, conflicts, err := mergeLibraryContent(link.BaseContent, update.local.ContentStructure, upstreamContent, cmd.Strategy)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 && cmd.Strategy == "" {
+			return fmt.Errorf("%w: %d conflicting changes", entity.ErrLibraryMergeConflict, len(conflicts))
+		}
+
+		name := fmt.Sprintf("Library v%d", update.upstream.VersionNumber)
+		exists, err := s.versionRepo.ExistsByName(ctx, cmd.TemplateID, name)
+		if err != nil {
+			return fmt.Errorf("checking version name: %w", err)
+		}
+		if exists {
+			return entity.ErrVersionNameExists
+		}
+		number, err := s.versionRepo.GetNextVersionNumber(ctx, cmd.TemplateID)
+		if err != nil {
+			return fmt.Errorf("getting next version number: %w", err)
+		}
+
+		version = entity.NewTemplateVersion(cmd.TemplateID, number, name, optionalImporter(cmd.PulledBy))
+		version.ID = uuid.NewString()
+		description := fmt.Sprintf("Merged library version %d into version %d", update.upstream.VersionNumber, update.local.VersionNumber)
+		version.Description = &description
+		version.ContentStructure = merged
+		if version.ID, err = s.versionRepo.Create(ctx, version); err != nil {
+			return fmt.Errorf("creating version: %w", err)
+		}
+
+		link.BaseVersionID = &update.upstream.ID
+		link.BaseVersionNumber = update.upstream.VersionNumber
+		link.BaseContent = upstreamContent
+		link.SyncedAt = time.Now().UTC()
+		return s.libraryRepo.UpdateBase(ctx, link)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "library update pulled",
+		slog.String("template_id", cmd.TemplateID),
+		slog.String("version_id", version.ID),
+		slog.Int("source_version", update.upstream.VersionNumber),
+		slog.String("strategy", string(cmd.Strategy)),
+	)
+
+	return version, nil
+}
+
+// libraryUpdate is the state an update of an installed template is computed from.
+type libraryUpdate struct {
+	link              *entity.TemplateLibraryLink
+	local             *entity.TemplateVersion // Latest non-archived version of the installed template
+	upstream          *entity.TemplateVersion // Published version of the library template
+	sourceWorkspaceID string
+}
+
+func (u *libraryUpdate) upToDate() bool {
+	return u.link.BaseVersionID != nil && *u.link.BaseVersionID == u.upstream.ID
+}
+
+// loadUpdate loads the link, local version and upstream version of an installed template of the
+// workspace. The library template must still be in the library of the workspace's tenant.
+func (s *TemplateLibraryService) loadUpdate(ctx context.Context, workspaceID, templateID string) (*libraryUpdate, error) {
+	template, err := s.templateRepo.FindByID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template.WorkspaceID != workspaceID {
+		return nil, entity.ErrTemplateNotFound
+	}
+	link, err := s.libraryRepo.FindByTemplateID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if link.AssetMap == nil {
+		link.AssetMap = map[string]string{}
+	}
+
+	source, err := s.libraryTemplate(ctx, workspaceID, link.SourceTemplateID)
+	if err != nil {
+		return nil, err
+	}
+	upstream, err := s.upstreamVersion(ctx, source.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := s.versionRepo.FindByTemplateID(ctx, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("listing versions: %w", err)
+	}
+	var local *entity.TemplateVersion
+	for _, version := range versions {
+		if version.Status != entity.VersionStatusArchived && (local == nil || version.VersionNumber > local.VersionNumber) {
+			local = version
+		}
+	}
+	if local == nil {
+		return nil, entity.ErrVersionNotFound
+	}
+
+	return &libraryUpdate{link: link, local: local, upstream: upstream, sourceWorkspaceID: source.WorkspaceID}, nil
+}
+
+// libraryTemplate gets a template of the library of the workspace's tenant: a public library
+// template of another workspace of the same tenant.
+func (s *TemplateLibraryService) libraryTemplate(ctx context.Context, workspaceID, templateID string) (*entity.Template, error) {
+	source, err := s.templateRepo.FindByID(ctx, templateID)
+	if errors.Is(err, entity.ErrTemplateNotFound) {
+		return nil, entity.ErrLibraryTemplateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !source.IsPublicLibrary || source.WorkspaceID == workspaceID {
+		return nil, entity.ErrLibraryTemplateNotFound
+	}
+
+	workspace, err := s.workspaceRepo.FindByID(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	sourceWorkspace, err := s.workspaceRepo.FindByID(ctx, source.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if workspace.TenantID == nil || sourceWorkspace.TenantID == nil || *workspace.TenantID != *sourceWorkspace.TenantID {
+		return nil, entity.ErrLibraryTemplateNotFound
+	}
+	return source, nil
+}
+
+// upstreamVersion gets the published version of a library template; templates without one are
+// not in the library.
+func (s *TemplateLibraryService) upstreamVersion(ctx context.Context, templateID string) (*entity.TemplateVersion, error) {
+	version, err := s.versionRepo.FindPublishedByTemplateID(ctx, templateID)
+	if errors.Is(err, entity.ErrVersionNotFound) || errors.Is(err, entity.ErrNoPublishedVersion) {
+		return nil, entity.ErrLibraryTemplateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// copyAssets copies the upstream assets content references that are not in assetMap yet from the
+// source workspace to the target workspace, adding them to assetMap, and returns content with its
+// asset references pointing at the copies. Assets missing upstream keep their reference.
+func (s *TemplateLibraryService) copyAssets(
+	ctx context.Context,
+	sourceWorkspaceID, targetWorkspaceID, userID string,
+	content json.RawMessage,
+	assetMap map[string]string,
+) (json.RawMessage, error) {
+	for _, match := range bundleAssetRefPattern.FindAllSubmatch(content, -1) {
+		id := string(match[1])
+		if _, ok := assetMap[id]; ok {
+			continue
+		}
+		asset, err := s.assetUC.GetAsset(ctx, sourceWorkspaceID, id)
+		if errors.Is(err, entity.ErrAssetNotFound) {
+			slog.WarnContext(ctx, "skipping missing library asset", slog.String("asset_id", id))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		data, err := s.assetUC.GetAssetContent(ctx, sourceWorkspaceID, id, 0)
+		if err != nil {
+			return nil, err
+		}
+		uploaded, err := s.assetUC.UploadAsset(ctx, cataloguc.UploadAssetCommand{
+			WorkspaceID: targetWorkspaceID,
+			Name:        asset.Name,
+			Tags:        asset.Tags,
+			Data:        data.Data,
+			CreatedBy:   userID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("copying asset %s: %w", asset.Name, err)
+		}
+		assetMap[id] = uploaded.Asset.ID
+	}
+	return rewriteAssetRefs(content, assetMap), nil
+}
+
+// rewriteAssetRefs points the asset references of content at their copies in assetMap.
+func rewriteAssetRefs(content json.RawMessage, assetMap map[string]string) json.RawMessage {
+	for sourceID, copyID := range assetMap {
+		content = bytes.ReplaceAll(content, []byte(entity.AssetURLScheme+sourceID), []byte(entity.AssetURLScheme+copyID))
+	}
+	return content
+}
@@ -2,6 +2,7 @@ package template
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
@@ -18,11 +19,13 @@ func NewTemplateService(
 	templateRepo port.TemplateRepository,
 	versionRepo port.TemplateVersionRepository,
 	tagRepo port.TemplateTagRepository,
+	txManager port.TransactionManager,
 ) templateuc.TemplateUseCase {
 	return &TemplateService{
 		templateRepo: templateRepo,
 		versionRepo:  versionRepo,
 		tagRepo:      tagRepo,
+		txManager:    txManager,
 	}
 }
 
@@ -31,6 +34,7 @@ type TemplateService struct {
 	templateRepo port.TemplateRepository
 	versionRepo  port.TemplateVersionRepository
 	tagRepo      port.TemplateTagRepository
+	txManager    port.TransactionManager
 }
 
 // CreateTemplate creates a new template with an initial draft version.
@@ -131,7 +135,7 @@ func (s *TemplateService) ListTemplatesByFolder(ctx context.Context, folderID st
 	return templates, nil
 }
 
-// ListPublicLibrary lists all public library templates.
+// ListPublicLibrary lists the library of the workspace's tenant.
 func (s *TemplateService) ListPublicLibrary(ctx context.Context, workspaceID string) ([]*entity.TemplateListItem, error) {
 	templates, err := s.templateRepo.FindPublicLibrary(ctx, workspaceID)
 	if err != nil {
@@ -148,6 +152,10 @@ func (s *TemplateService) UpdateTemplate(ctx context.Context, cmd templateuc.Upd
 		return nil, fmt.Errorf("finding template: %w", err)
 	}
 
+	if cmd.ExpectedRevision != nil && *cmd.ExpectedRevision != template.Revision {
+		return nil, &entity.RevisionConflictError{Current: template}
+	}
+
 	// Check for duplicate title if changed
 	if cmd.Title != nil && template.Title != *cmd.Title {
 		exists, err := s.templateRepo.ExistsByTitleExcluding(ctx, template.WorkspaceID, *cmd.Title, template.ID)
@@ -180,6 +188,9 @@ func (s *TemplateService) UpdateTemplate(ctx context.Context, cmd templateuc.Upd
 	}
 
 	if err := s.templateRepo.Update(ctx, template); err != nil {
+		if errors.Is(err, entity.ErrRevisionConflict) {
+			return nil, s.revisionConflict(ctx, template.ID)
+		}
 		return nil, fmt.Errorf("updating template: %w", err)
 	}
 
@@ -191,6 +202,15 @@ func (s *TemplateService) UpdateTemplate(ctx context.Context, cmd templateuc.Upd
 	return template, nil
 }
 
+// revisionConflict reports a lost update race together with the template as it is now.
+func (s *TemplateService) revisionConflict(ctx context.Context, id string) error {
+	current, err := s.templateRepo.FindByID(ctx, id)
+	if err != nil {
+		return entity.ErrRevisionConflict
+	}
+	return &entity.RevisionConflictError{Current: current}
+}
+
 // CloneTemplate creates a copy of an existing template from a specific version.
 func (s *TemplateService) CloneTemplate(ctx context.Context, cmd templateuc.CloneTemplateCommand) (*entity.Template, *entity.TemplateVersion, error) {
 	source, sourceVersion, err := s.validateCloneSource(ctx, cmd.SourceTemplateID, cmd.VersionID)
@@ -198,23 +218,29 @@ func (s *TemplateService) CloneTemplate(ctx context.Context, cmd templateuc.Clon
 		return nil, nil, err
 	}
 
-	newTemplate, err := s.createClonedTemplate(ctx, source, cmd.NewTitle, cmd.TargetFolderID)
-	if err != nil {
-		return nil, nil, err
-	}
+	var newTemplate *entity.Template
+	var version *entity.TemplateVersion
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		newTemplate, err = s.createClonedTemplate(ctx, source, cmd.NewTitle, cmd.TargetFolderID)
+		if err != nil {
+			return err
+		}
 
-	version := entity.NewTemplateVersion(newTemplate.ID, 1, "Initial Version", &cmd.ClonedBy)
-	version.ID = uuid.NewString()
-	version.ContentStructure = sourceVersion.ContentStructure
+		version = entity.NewTemplateVersion(newTemplate.ID, 1, "Initial Version", &cmd.ClonedBy)
+		version.ID = uuid.NewString()
+		version.ContentStructure = sourceVersion.ContentStructure
 
-	versionID, err := s.versionRepo.Create(ctx, version)
+		versionID, err := s.versionRepo.Create(ctx, version)
+		if err != nil {
+			return fmt.Errorf("creating cloned version: %w", err)
+		}
+		version.ID = versionID
+
+		return s.cloneTags(ctx, newTemplate.ID, source.Tags)
+	})
 	if err != nil {
-		_ = s.templateRepo.Delete(ctx, newTemplate.ID)
-		return nil, nil, fmt.Errorf("creating cloned version: %w", err)
+		return nil, nil, err
 	}
-	version.ID = versionID
-
-	s.cloneTags(ctx, newTemplate.ID, source.Tags)
 
 	slog.InfoContext(ctx, "template cloned",
 		slog.String("source_id", cmd.SourceTemplateID),
@@ -271,15 +297,13 @@ func (s *TemplateService) createClonedTemplate(ctx context.Context, source *enti
 	return newTemplate, nil
 }
 
-func (s *TemplateService) cloneTags(ctx context.Context, newTemplateID string, tags []*entity.Tag) {
+func (s *TemplateService) cloneTags(ctx context.Context, newTemplateID string, tags []*entity.Tag) error {
 	for _, tag := range tags {
 		if err := s.tagRepo.AddTag(ctx, newTemplateID, tag.ID); err != nil {
-			slog.WarnContext(ctx, "failed to clone tag",
-				slog.String("template_id", newTemplateID),
-				slog.Any("error", err),
-			)
+			return fmt.Errorf("cloning tag %s: %w", tag.ID, err)
 		}
 	}
+	return nil
 }
 
 // DeleteTemplate deletes a template and all its versions.
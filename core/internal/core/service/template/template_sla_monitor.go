@@ -0,0 +1,349 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	notificationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/notification"
+)
+
+// templateRenderRetention is how long per-minute render counts are kept: the longest SLA window.
+const templateRenderRetention = entity.TemplateSLAMaxWindowMinutes * time.Minute
+
+// TemplateSLAMonitor checks the renders of templates against their SLAs. It counts the renders of
+// templates with an SLA in memory, adds them to the per-minute counts periodically and then checks
+// every SLA over its window. A breach and the recovery that follows are each recorded as an outbox
+// event, for event webhooks, and notified to the user who set the SLA. Several monitors (one per
+// API instance) can run against the same database; each breach is alerted once.
+type TemplateSLAMonitor struct {
+	slaRepo        port.TemplateSLARepository
+	outboxRepo     port.OutboxRepository
+	txManager      port.TransactionManager
+	notificationUC notificationuc.NotificationUseCase
+	interval       time.Duration
+
+	mu      sync.Mutex
+	watched map[string]struct{}                     // Templates with an SLA, as of the last check
+	pending map[string]*entity.TemplateRenderMinute // By template ID and minute
+
+	stopCh   chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// NewTemplateSLAMonitor creates a monitor that checks every interval. Call Start to begin.
+func NewTemplateSLAMonitor(
+	slaRepo port.TemplateSLARepository,
+	outboxRepo port.OutboxRepository,
+	txManager port.TransactionManager,
+	notificationUC notificationuc.NotificationUseCase,
+	interval time.Duration,
+) *TemplateSLAMonitor {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &TemplateSLAMonitor{
+		slaRepo:        slaRepo,
+		outboxRepo:     outboxRepo,
+		txManager:      txManager,
+		notificationUC: notificationUC,
+		interval:       interval,
+		watched:        make(map[string]struct{}),
+		pending:        make(map[string]*entity.TemplateRenderMinute),
+		stopCh:         make(chan struct{}),
+		stopped:        make(chan struct{}),
+	}
+}
+
+// HandleRenderEvent is a port.EventHandler for render.completed and render.failed. Renders of
+// templates without an SLA are ignored; an SLA counts renders from the first check after it is set.
+func (m *TemplateSLAMonitor) HandleRenderEvent(_ context.Context, event entity.DomainEvent) error {
+	var (
+		templateID string
+		at         time.Time
+		duration   time.Duration
+		failed     bool
+	)
+	switch e := event.(type) {
+	case entity.RenderCompleted:
+		templateID, at, duration = e.TemplateID, e.CompletedAt, e.Duration
+	case entity.RenderFailed:
+		templateID, at, failed = e.TemplateID, e.FailedAt, true
+	default:
+		return nil
+	}
+	minute := at.UTC().Truncate(time.Minute)
+	key := templateID + "|" + minute.Format(time.RFC3339)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.watched[templateID]; !ok {
+		return nil
+	}
+	counts, ok := m.pending[key]
+	if !ok {
+		counts = &entity.TemplateRenderMinute{TemplateID: templateID, Minute: minute}
+		m.pending[key] = counts
+	}
+	counts.Renders++
+	if failed {
+		counts.Failures++
+		return nil
+	}
+	counts.DurationMs += duration.Milliseconds()
+	return nil
+}
+
+// Start runs the check loop in the background until Stop is called.
+func (m *TemplateSLAMonitor) Start() {
+	go m.loop()
+}
+
+// Stop ends the loop and flushes the pending counts.
+func (m *TemplateSLAMonitor) Stop() {
+	m.stopOnce.Do(func() { close(m.stopCh) })
+	<-m.stopped
+}
+
+func (m *TemplateSLAMonitor) loop() {
+	defer close(m.stopped)
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	// Check at once so the templates to count are known before the first tick
+	m.runOnce()
+	for {
+		select {
+		case <-m.stopCh:
+			m.Flush(context.Background())
+			return
+		case <-ticker.C:
+			m.runOnce()
+		}
+	}
+}
+
+func (m *TemplateSLAMonitor) runOnce() {
+	ctx := context.Background()
+	if err := m.RunOnce(ctx); err != nil {
+		slog.WarnContext(ctx, "template SLA check failed", slog.Any("error", err))
+	}
+}
+
+// RunOnce flushes the pending counts, then checks every SLA over its window, alerting the breaches
+// and recoveries, and deletes the per-minute counts no window reaches anymore.
+func (m *TemplateSLAMonitor) RunOnce(ctx context.Context) error {
+	m.Flush(ctx)
+
+	statuses, err := m.slaRepo.ListStatuses(ctx)
+	if err != nil {
+		return err
+	}
+
+	watched := make(map[string]struct{}, len(statuses))
+	for _, status := range statuses {
+		watched[status.SLA.TemplateID] = struct{}{}
+	}
+	m.mu.Lock()
+	m.watched = watched
+	m.mu.Unlock()
+
+	for _, status := range statuses {
+		if err := m.check(ctx, status); err != nil {
+			slog.WarnContext(ctx, "failed to check template SLA",
+				slog.String("template_id", status.SLA.TemplateID),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	if deleted, err := m.slaRepo.DeleteRenderMinutesBefore(ctx, time.Now().Add(-templateRenderRetention)); err != nil {
+		slog.WarnContext(ctx, "failed to delete template render minutes", slog.Any("error", err))
+	} else if deleted > 0 {
+		slog.DebugContext(ctx, "template render minutes deleted", slog.Int64("count", deleted))
+	}
+	return nil
+}
+
+// Flush adds the pending counts to the per-minute counts. On failure they are kept for the next flush.
+func (m *TemplateSLAMonitor) Flush(ctx context.Context) {
+	m.mu.Lock()
+	if len(m.pending) == 0 {
+		m.mu.Unlock()
+		return
+	}
+	batch := m.pending
+	m.pending = make(map[string]*entity.TemplateRenderMinute, len(batch))
+	m.mu.Unlock()
+
+	minutes := make([]*entity.TemplateRenderMinute, 0, len(batch))
+	for _, counts := range batch {
+		minutes = append(minutes, counts)
+	}
+	if err := m.slaRepo.AddRenderMinutes(ctx, minutes); err != nil {
+		slog.WarnContext(ctx, "failed to flush template render counts, retrying on the next flush", slog.Any("error", err))
+		m.restore(batch)
+	}
+}
+
+// restore merges counts that failed to flush back into the pending ones.
+func (m *TemplateSLAMonitor) restore(batch map[string]*entity.TemplateRenderMinute) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, counts := range batch {
+		current, ok := m.pending[key]
+		if !ok {
+			m.pending[key] = counts
+			continue
+		}
+		current.Renders += counts.Renders
+		current.Failures += counts.Failures
+		current.DurationMs += counts.DurationMs
+	}
+}
+
+// check alerts a breach of the SLA, or its recovery. A window with too few renders keeps the SLA
+// as it was.
+func (m *TemplateSLAMonitor) check(ctx context.Context, status *entity.TemplateSLAStatus) error {
+	violations, judged := status.SLA.Check(status.Window)
+	switch {
+	case !judged:
+		return nil
+	case len(violations) > 0 && status.SLA.BreachedAt == nil:
+		return m.breach(ctx, status, violations)
+	case len(violations) == 0 && status.SLA.BreachedAt != nil:
+		return m.recover(ctx, status)
+	}
+	return nil
+}
+
+func (m *TemplateSLAMonitor) breach(ctx context.Context, status *entity.TemplateSLAStatus, violations []entity.TemplateSLAViolation) error {
+	sla := status.SLA
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	event, err := entity.NewOutboxEvent(entity.OutboxEventTemplateSLABreached, sla.TemplateID, &status.WorkspaceID, entity.TemplateSLABreached{
+		TemplateID:    sla.TemplateID,
+		WorkspaceID:   status.WorkspaceID,
+		TemplateTitle: status.TemplateTitle,
+		WindowMinutes: sla.WindowMinutes,
+		Window:        status.Window,
+		Violations:    violations,
+		BreachedAt:    now,
+	})
+	if err != nil {
+		return err
+	}
+
+	marked, err := m.record(ctx, event, func(ctx context.Context) (bool, error) {
+		return m.slaRepo.MarkBreached(ctx, sla.TemplateID, now)
+	})
+	if err != nil || !marked {
+		return err
+	}
+
+	slog.WarnContext(ctx, "template SLA breached",
+		slog.String("template_id", sla.TemplateID),
+		slog.Int64("renders", status.Window.Renders),
+		slog.Int64("failures", status.Window.Failures),
+		slog.Float64("avg_render_ms", status.Window.AvgRenderMs()),
+	)
+
+	details := make([]string, len(violations))
+	for i, v := range violations {
+		details[i] = describeSLAViolation(v)
+	}
+	m.notify(ctx, status, notificationuc.NotifyCommand{
+		Type:  entity.NotificationTypeTemplateSLABreached,
+		Title: fmt.Sprintf("Template %q is breaching its SLA", status.TemplateTitle),
+		Message: fmt.Sprintf("Over the last %d minutes (%d renders): %s.",
+			sla.WindowMinutes, status.Window.Renders, strings.Join(details, "; ")),
+	})
+	return nil
+}
+
+func (m *TemplateSLAMonitor) recover(ctx context.Context, status *entity.TemplateSLAStatus) error {
+	sla := status.SLA
+	now := time.Now().UTC()
+	event, err := entity.NewOutboxEvent(entity.OutboxEventTemplateSLARecovered, sla.TemplateID, &status.WorkspaceID, entity.TemplateSLARecovered{
+		TemplateID:    sla.TemplateID,
+		WorkspaceID:   status.WorkspaceID,
+		TemplateTitle: status.TemplateTitle,
+		WindowMinutes: sla.WindowMinutes,
+		Window:        status.Window,
+		BreachedAt:    *sla.BreachedAt,
+		RecoveredAt:   now,
+	})
+	if err != nil {
+		return err
+	}
+
+	marked, err := m.record(ctx, event, func(ctx context.Context) (bool, error) {
+		return m.slaRepo.MarkRecovered(ctx, sla.TemplateID, *sla.BreachedAt)
+	})
+	if err != nil || !marked {
+		return err
+	}
+
+	slog.InfoContext(ctx, "template SLA recovered",
+		slog.String("template_id", sla.TemplateID),
+		slog.Duration("breached_for", now.Sub(*sla.BreachedAt)),
+	)
+
+	m.notify(ctx, status, notificationuc.NotifyCommand{
+		Type:  entity.NotificationTypeTemplateSLARecovered,
+		Title: fmt.Sprintf("Template %q meets its SLA again", status.TemplateTitle),
+		Message: fmt.Sprintf("Over the last %d minutes (%d renders) the renders meet the SLA.",
+			sla.WindowMinutes, status.Window.Renders),
+		Time:      sla.BreachedAt,
+		TimeLabel: "Breached since",
+	})
+	return nil
+}
+
+// record runs mark and appends event in one transaction, unless mark returns false because another
+// monitor got there first.
+func (m *TemplateSLAMonitor) record(ctx context.Context, event *entity.OutboxEvent, mark func(ctx context.Context) (bool, error)) (bool, error) {
+	var marked bool
+	err := m.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if marked, err = mark(ctx); err != nil || !marked {
+			return err
+		}
+		if err := m.outboxRepo.Append(ctx, event); err != nil {
+			return fmt.Errorf("recording %s event: %w", event.Type, err)
+		}
+		return nil
+	})
+	return marked && err == nil, err
+}
+
+// notify notifies the user who set the SLA. Failures are logged: the outbox event is already recorded.
+func (m *TemplateSLAMonitor) notify(ctx context.Context, status *entity.TemplateSLAStatus, cmd notificationuc.NotifyCommand) {
+	if m.notificationUC == nil || status.SLA.UpdatedBy == nil {
+		return
+	}
+	cmd.UserID = *status.SLA.UpdatedBy
+	cmd.WorkspaceID = &status.WorkspaceID
+	cmd.ResourceID = &status.SLA.TemplateID
+	if err := m.notificationUC.Notify(ctx, cmd); err != nil {
+		slog.WarnContext(ctx, "failed to notify template SLA",
+			slog.String("template_id", status.SLA.TemplateID),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// describeSLAViolation describes a violation for a notification, e.g. "failure rate 12.5% above 5%".
+func describeSLAViolation(v entity.TemplateSLAViolation) string {
+	if v.Metric == entity.TemplateSLAFailureRate {
+		return fmt.Sprintf("failure rate %.1f%% above %.1f%%", v.Actual*100, v.Threshold*100)
+	}
+	return fmt.Sprintf("average render time %.0f ms above %.0f ms", v.Actual, v.Threshold)
+}
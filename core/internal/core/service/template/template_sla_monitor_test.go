@@ -0,0 +1,193 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	notificationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/notification"
+)
+
+func TestTemplateSLAMonitor_CountsOnlyWatchedTemplates(t *testing.T) {
+	repo := &fakeTemplateSLARepo{statuses: []*entity.TemplateSLAStatus{slaStatus(entity.TemplateRenderWindow{})}}
+	monitor := NewTemplateSLAMonitor(repo, &fakeSLAOutbox{}, fakeSLATx{}, nil, 0)
+	ctx := context.Background()
+	at := time.Date(2026, 3, 1, 10, 4, 30, 0, time.UTC)
+
+	require.NoError(t, monitor.HandleRenderEvent(ctx, entity.RenderCompleted{TemplateID: "t-1", CompletedAt: at, Duration: time.Second}))
+	require.NoError(t, monitor.RunOnce(ctx))
+	assert.Empty(t, repo.added, "renders before the first check are not counted")
+
+	require.NoError(t, monitor.HandleRenderEvent(ctx, entity.RenderCompleted{TemplateID: "t-1", CompletedAt: at, Duration: time.Second}))
+	require.NoError(t, monitor.HandleRenderEvent(ctx, entity.RenderFailed{TemplateID: "t-1", FailedAt: at.Add(10 * time.Second)}))
+	require.NoError(t, monitor.HandleRenderEvent(ctx, entity.RenderCompleted{TemplateID: "t-2", CompletedAt: at}))
+	require.NoError(t, monitor.RunOnce(ctx))
+
+	require.Len(t, repo.added, 1)
+	assert.Equal(t, entity.TemplateRenderMinute{
+		TemplateID: "t-1",
+		Minute:     time.Date(2026, 3, 1, 10, 4, 0, 0, time.UTC),
+		Renders:    2,
+		Failures:   1,
+		DurationMs: 1000,
+	}, *repo.added[0])
+	assert.Equal(t, 2, repo.purges)
+}
+
+func TestTemplateSLAMonitor_FlushKeepsCountsOnFailure(t *testing.T) {
+	repo := &fakeTemplateSLARepo{statuses: []*entity.TemplateSLAStatus{slaStatus(entity.TemplateRenderWindow{})}}
+	monitor := NewTemplateSLAMonitor(repo, &fakeSLAOutbox{}, fakeSLATx{}, nil, 0)
+	ctx := context.Background()
+	require.NoError(t, monitor.RunOnce(ctx))
+
+	require.NoError(t, monitor.HandleRenderEvent(ctx, entity.RenderFailed{TemplateID: "t-1", FailedAt: time.Now()}))
+	repo.addErr = errors.New("connection refused")
+	monitor.Flush(ctx)
+	assert.Empty(t, repo.added)
+
+	repo.addErr = nil
+	monitor.Flush(ctx)
+	require.Len(t, repo.added, 1)
+	assert.Equal(t, int64(1), repo.added[0].Failures)
+}
+
+func TestTemplateSLAMonitor_AlertsBreachOnce(t *testing.T) {
+	status := slaStatus(entity.TemplateRenderWindow{Renders: 20, Failures: 5, DurationMs: 15 * 500})
+	repo := &fakeTemplateSLARepo{statuses: []*entity.TemplateSLAStatus{status}}
+	outbox := &fakeSLAOutbox{}
+	notifications := &fakeSLANotifications{}
+	monitor := NewTemplateSLAMonitor(repo, outbox, fakeSLATx{}, notifications, 0)
+
+	require.NoError(t, monitor.RunOnce(context.Background()))
+
+	require.Len(t, outbox.events, 1)
+	assert.Equal(t, entity.OutboxEventTemplateSLABreached, outbox.events[0].Type)
+	assert.Equal(t, "ws-1", *outbox.events[0].WorkspaceID)
+	require.Len(t, notifications.sent, 1)
+	cmd := notifications.sent[0]
+	assert.Equal(t, "user-1", cmd.UserID)
+	assert.Equal(t, entity.NotificationTypeTemplateSLABreached, cmd.Type)
+	assert.Equal(t, "Template \"Quarterly report\" is breaching its SLA", cmd.Title)
+	assert.Equal(t, "Over the last 60 minutes (20 renders): failure rate 25.0% above 10.0%.", cmd.Message)
+
+	// Another instance marked it first
+	repo.breachedElsewhere = true
+	require.NoError(t, monitor.RunOnce(context.Background()))
+	assert.Len(t, outbox.events, 1)
+	assert.Len(t, notifications.sent, 1)
+}
+
+func TestTemplateSLAMonitor_AlertsRecovery(t *testing.T) {
+	breachedAt := time.Now().Add(-time.Hour).UTC()
+	status := slaStatus(entity.TemplateRenderWindow{Renders: 20, Failures: 1, DurationMs: 19 * 500})
+	status.SLA.BreachedAt = &breachedAt
+	repo := &fakeTemplateSLARepo{statuses: []*entity.TemplateSLAStatus{status}}
+	outbox := &fakeSLAOutbox{}
+	notifications := &fakeSLANotifications{}
+	monitor := NewTemplateSLAMonitor(repo, outbox, fakeSLATx{}, notifications, 0)
+
+	require.NoError(t, monitor.RunOnce(context.Background()))
+
+	assert.Equal(t, []time.Time{breachedAt}, repo.recovered)
+	require.Len(t, outbox.events, 1)
+	assert.Equal(t, entity.OutboxEventTemplateSLARecovered, outbox.events[0].Type)
+	require.Len(t, notifications.sent, 1)
+	assert.Equal(t, entity.NotificationTypeTemplateSLARecovered, notifications.sent[0].Type)
+}
+
+func TestTemplateSLAMonitor_KeepsStateOfQuietWindow(t *testing.T) {
+	breachedAt := time.Now().UTC()
+	status := slaStatus(entity.TemplateRenderWindow{Renders: 3})
+	status.SLA.BreachedAt = &breachedAt
+	repo := &fakeTemplateSLARepo{statuses: []*entity.TemplateSLAStatus{status}}
+	outbox := &fakeSLAOutbox{}
+	monitor := NewTemplateSLAMonitor(repo, outbox, fakeSLATx{}, nil, 0)
+
+	require.NoError(t, monitor.RunOnce(context.Background()))
+	assert.Empty(t, repo.recovered)
+	assert.Empty(t, outbox.events)
+}
+
+func slaStatus(window entity.TemplateRenderWindow) *entity.TemplateSLAStatus {
+	maxRate := 0.1
+	userID := "user-1"
+	return &entity.TemplateSLAStatus{
+		SLA:           entity.NewTemplateSLA("t-1", nil, &maxRate, 60, 10, &userID),
+		WorkspaceID:   "ws-1",
+		TemplateTitle: "Quarterly report",
+		Window:        window,
+	}
+}
+
+type fakeTemplateSLARepo struct {
+	port.TemplateSLARepository
+	statuses          []*entity.TemplateSLAStatus
+	added             []*entity.TemplateRenderMinute
+	addErr            error
+	breachedElsewhere bool
+	recovered         []time.Time
+	purges            int
+}
+
+func (f *fakeTemplateSLARepo) ListStatuses(context.Context) ([]*entity.TemplateSLAStatus, error) {
+	return f.statuses, nil
+}
+
+func (f *fakeTemplateSLARepo) AddRenderMinutes(_ context.Context, minutes []*entity.TemplateRenderMinute) error {
+	if f.addErr != nil {
+		return f.addErr
+	}
+	f.added = append(f.added, minutes...)
+	return nil
+}
+
+func (f *fakeTemplateSLARepo) MarkBreached(context.Context, string, time.Time) (bool, error) {
+	if f.breachedElsewhere {
+		return false, nil
+	}
+	// The status listed on the next check would carry the breach
+	f.breachedElsewhere = true
+	return true, nil
+}
+
+func (f *fakeTemplateSLARepo) MarkRecovered(_ context.Context, _ string, breachedAt time.Time) (bool, error) {
+	f.recovered = append(f.recovered, breachedAt)
+	return true, nil
+}
+
+func (f *fakeTemplateSLARepo) DeleteRenderMinutesBefore(context.Context, time.Time) (int64, error) {
+	f.purges++
+	return 0, nil
+}
+
+type fakeSLAOutbox struct {
+	port.OutboxRepository
+	events []*entity.OutboxEvent
+}
+
+func (f *fakeSLAOutbox) Append(_ context.Context, event *entity.OutboxEvent) error {
+	f.events = append(f.events, event)
+	return nil
+}
+
+type fakeSLATx struct{}
+
+func (fakeSLATx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeSLANotifications struct {
+	notificationuc.NotificationUseCase
+	sent []notificationuc.NotifyCommand
+}
+
+func (f *fakeSLANotifications) Notify(_ context.Context, cmd notificationuc.NotifyCommand) error {
+	f.sent = append(f.sent, cmd)
+	return nil
+}
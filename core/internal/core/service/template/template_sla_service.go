@@ -0,0 +1,100 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// NewTemplateSLAService creates a new template SLA service.
+func NewTemplateSLAService(slaRepo port.TemplateSLARepository, templateRepo port.TemplateRepository) templateuc.TemplateSLAUseCase {
+	return &TemplateSLAService{
+		slaRepo:      slaRepo,
+		templateRepo: templateRepo,
+	}
+}
+
+// TemplateSLAService implements template SLA business logic.
+type TemplateSLAService struct {
+	slaRepo      port.TemplateSLARepository
+	templateRepo port.TemplateRepository
+}
+
+// GetSLA returns the SLA of a template with the renders of its current window.
+func (s *TemplateSLAService) GetSLA(ctx context.Context, workspaceID, templateID string) (*entity.TemplateSLAStatus, error) {
+	template, err := s.findTemplate(ctx, workspaceID, templateID)
+	if err != nil {
+		return nil, err
+	}
+	sla, err := s.slaRepo.FindByTemplateID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	return s.status(ctx, template, sla)
+}
+
+// SetSLA creates or replaces the SLA of a template.
+func (s *TemplateSLAService) SetSLA(ctx context.Context, cmd templateuc.SetTemplateSLACommand) (*entity.TemplateSLAStatus, error) {
+	template, err := s.findTemplate(ctx, cmd.WorkspaceID, cmd.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	sla := entity.NewTemplateSLA(cmd.TemplateID, cmd.MaxRenderMs, cmd.MaxFailureRate, cmd.WindowMinutes, cmd.MinRenders, &cmd.UpdatedBy)
+	if err := sla.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.slaRepo.Upsert(ctx, sla); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "template SLA set",
+		slog.String("template_id", cmd.TemplateID),
+		slog.Int("window_minutes", sla.WindowMinutes),
+		slog.String("updated_by", cmd.UpdatedBy),
+	)
+
+	return s.status(ctx, template, sla)
+}
+
+// DeleteSLA deletes the SLA of a template.
+func (s *TemplateSLAService) DeleteSLA(ctx context.Context, workspaceID, templateID string) error {
+	if _, err := s.findTemplate(ctx, workspaceID, templateID); err != nil {
+		return err
+	}
+	if err := s.slaRepo.Delete(ctx, templateID); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "template SLA deleted", slog.String("template_id", templateID))
+	return nil
+}
+
+// findTemplate finds a template of the workspace. Templates of other workspaces are not found.
+func (s *TemplateSLAService) findTemplate(ctx context.Context, workspaceID, templateID string) (*entity.Template, error) {
+	template, err := s.templateRepo.FindByID(ctx, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("finding template: %w", err)
+	}
+	if template.WorkspaceID != workspaceID {
+		return nil, entity.ErrTemplateNotFound
+	}
+	return template, nil
+}
+
+func (s *TemplateSLAService) status(ctx context.Context, template *entity.Template, sla *entity.TemplateSLA) (*entity.TemplateSLAStatus, error) {
+	window, err := s.slaRepo.FindWindow(ctx, sla.TemplateID, sla.Window())
+	if err != nil {
+		return nil, err
+	}
+	return &entity.TemplateSLAStatus{
+		SLA:           sla,
+		WorkspaceID:   template.WorkspaceID,
+		TemplateTitle: template.Title,
+		Window:        window,
+	}, nil
+}
@@ -0,0 +1,171 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+func TestSchedulePublish_LocalTimeIsInWorkspaceTimezone(t *testing.T) {
+	versions := &fakeScheduleVersionRepo{version: &entity.TemplateVersion{ID: "v-1", TemplateID: "t-1", Status: entity.VersionStatusDraft}}
+	s := newScheduleTestService(versions, &fakeScheduleSettings{timezone: "America/Santiago"})
+	year := time.Now().Year() + 1
+
+	err := s.SchedulePublish(context.Background(), templateuc.SchedulePublishCommand{
+		VersionID: "v-1", PublishAt: time.Date(year, 7, 1, 9, 0, 0, 0, time.UTC), Local: true,
+	})
+
+	require.NoError(t, err)
+	santiago, _ := time.LoadLocation("America/Santiago")
+	want := time.Date(year, 7, 1, 9, 0, 0, 0, santiago)
+	assert.True(t, want.Equal(*versions.updated.ScheduledPublishAt), "9:00 in Santiago, got %s", versions.updated.ScheduledPublishAt)
+	assert.True(t, want.Equal(versions.conflictAt), "the conflict check uses the resolved time")
+}
+
+func TestSchedulePublish_AbsoluteTimeIgnoresWorkspaceTimezone(t *testing.T) {
+	versions := &fakeScheduleVersionRepo{version: &entity.TemplateVersion{ID: "v-1", TemplateID: "t-1", Status: entity.VersionStatusDraft}}
+	s := newScheduleTestService(versions, &fakeScheduleSettings{timezone: "America/Santiago"})
+	publishAt := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+
+	require.NoError(t, s.SchedulePublish(context.Background(), templateuc.SchedulePublishCommand{VersionID: "v-1", PublishAt: publishAt}))
+
+	assert.True(t, publishAt.Equal(*versions.updated.ScheduledPublishAt))
+}
+
+func TestScheduleArchive_LocalTimeIsInWorkspaceTimezone(t *testing.T) {
+	versions := &fakeScheduleVersionRepo{
+		version:      &entity.TemplateVersion{ID: "v-1", TemplateID: "t-1", Status: entity.VersionStatusPublished},
+		hasScheduled: true,
+	}
+	s := newScheduleTestService(versions, &fakeScheduleSettings{timezone: "Asia/Tokyo"})
+	year := time.Now().Year() + 1
+
+	err := s.ScheduleArchive(context.Background(), templateuc.ScheduleArchiveCommand{
+		VersionID: "v-1", ArchiveAt: time.Date(year, 1, 15, 18, 30, 0, 0, time.UTC), Local: true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(year, 1, 15, 9, 30, 0, 0, time.UTC), versions.updated.ScheduledArchiveAt.UTC())
+}
+
+func TestSchedulePublish_FallsBackToUTC(t *testing.T) {
+	versions := &fakeScheduleVersionRepo{version: &entity.TemplateVersion{ID: "v-1", TemplateID: "t-1", Status: entity.VersionStatusDraft}}
+	s := newScheduleTestService(versions, &fakeScheduleSettings{err: errors.New("db down")})
+	year := time.Now().Year() + 1
+
+	require.NoError(t, s.SchedulePublish(context.Background(), templateuc.SchedulePublishCommand{
+		VersionID: "v-1", PublishAt: time.Date(year, 7, 1, 9, 0, 0, 0, time.UTC), Local: true,
+	}))
+
+	assert.Equal(t, time.Date(year, 7, 1, 9, 0, 0, 0, time.UTC), versions.updated.ScheduledPublishAt.UTC())
+}
+
+func TestGetWorkspaceSchedule_ShowsTimesInWorkspaceTimezone(t *testing.T) {
+	scheduledFor := time.Date(2026, 7, 1, 13, 0, 0, 0, time.UTC)
+	runs := &fakeScheduleRunRepo{
+		upcoming: []*entity.ScheduledItem{{VersionID: "v-1", ScheduledFor: scheduledFor}},
+		recent:   []*entity.ScheduledRun{{VersionID: "v-2", ScheduledFor: &scheduledFor, RanAt: scheduledFor}},
+	}
+	s := newScheduleTestService(&fakeScheduleVersionRepo{}, &fakeScheduleSettings{timezone: "America/Santiago"})
+	s.runRepo = runs
+
+	schedule, err := s.GetWorkspaceSchedule(context.Background(), "ws-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "America/Santiago", schedule.Timezone)
+	assert.Equal(t, "2026-07-01T09:00:00-04:00", schedule.Upcoming[0].ScheduledFor.Format(time.RFC3339))
+	assert.Equal(t, "2026-07-01T09:00:00-04:00", schedule.RecentRuns[0].ScheduledFor.Format(time.RFC3339))
+	assert.Equal(t, "2026-07-01T09:00:00-04:00", schedule.RecentRuns[0].RanAt.Format(time.RFC3339))
+}
+
+func newScheduleTestService(versions *fakeScheduleVersionRepo, settings *fakeScheduleSettings) *TemplateVersionService {
+	return &TemplateVersionService{
+		versionRepo:      versions,
+		templateRepo:     &fakeScheduleTemplateRepo{},
+		contentValidator: fakeScheduleValidator{},
+		runRepo:          &fakeScheduleRunRepo{},
+		settingsUC:       settings,
+	}
+}
+
+type fakeScheduleVersionRepo struct {
+	port.TemplateVersionRepository
+	version      *entity.TemplateVersion
+	hasScheduled bool
+	conflictAt   time.Time
+	updated      *entity.TemplateVersion
+}
+
+func (f *fakeScheduleVersionRepo) FindByID(context.Context, string) (*entity.TemplateVersion, error) {
+	return f.version, nil
+}
+
+func (f *fakeScheduleVersionRepo) ExistsScheduledAtTime(_ context.Context, _ string, at time.Time, _ *string) (bool, error) {
+	f.conflictAt = at
+	return false, nil
+}
+
+func (f *fakeScheduleVersionRepo) HasScheduledVersion(context.Context, string) (bool, error) {
+	return f.hasScheduled, nil
+}
+
+func (f *fakeScheduleVersionRepo) Update(_ context.Context, version *entity.TemplateVersion) error {
+	f.updated = version
+	return nil
+}
+
+type fakeScheduleTemplateRepo struct {
+	port.TemplateRepository
+}
+
+func (f *fakeScheduleTemplateRepo) FindByID(_ context.Context, id string) (*entity.Template, error) {
+	return &entity.Template{ID: id, WorkspaceID: "ws-1"}, nil
+}
+
+type fakeScheduleValidator struct {
+	port.ContentValidator
+}
+
+func (fakeScheduleValidator) ValidateForPublish(context.Context, string, string, []byte) *port.ContentValidationResult {
+	return port.NewValidationResult()
+}
+
+type fakeScheduleRunRepo struct {
+	port.ScheduledRunRepository
+	upcoming []*entity.ScheduledItem
+	recent   []*entity.ScheduledRun
+}
+
+func (f *fakeScheduleRunRepo) FindUpcomingByWorkspace(context.Context, string) ([]*entity.ScheduledItem, error) {
+	return f.upcoming, nil
+}
+
+func (f *fakeScheduleRunRepo) FindRecentByWorkspace(context.Context, string, int) ([]*entity.ScheduledRun, error) {
+	return f.recent, nil
+}
+
+func (f *fakeScheduleRunRepo) FindFailuresByWorkspace(context.Context, string) ([]*entity.ScheduledRun, error) {
+	return nil, nil
+}
+
+type fakeScheduleSettings struct {
+	organizationuc.WorkspaceSettingsUseCase
+	timezone string
+	err      error
+}
+
+func (f *fakeScheduleSettings) GetWorkspaceSettings(_ context.Context, workspaceID string) (*entity.WorkspaceSettingsView, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &entity.WorkspaceSettingsView{WorkspaceID: workspaceID, Settings: entity.WorkspaceSettings{Timezone: f.timezone}}, nil
+}
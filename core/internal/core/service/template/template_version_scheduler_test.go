@@ -0,0 +1,258 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+func TestProcessScheduledArchivals_RecordsEachRun(t *testing.T) {
+	dueAt := time.Now().Add(-time.Minute).UTC()
+	versions := newFakeRunVersionRepo(
+		&entity.TemplateVersion{ID: "v-1", TemplateID: "t-1", Status: entity.VersionStatusPublished, ScheduledArchiveAt: &dueAt},
+		&entity.TemplateVersion{ID: "v-2", TemplateID: "t-1", Status: entity.VersionStatusPublished, ScheduledArchiveAt: &dueAt},
+	)
+	versions.updateErr["v-2"] = errors.New("connection reset")
+	runs := &fakeRunRepo{failures: 1}
+	s := newRunTestService(versions, runs, 3)
+
+	require.NoError(t, s.ProcessScheduledArchivals(context.Background()))
+
+	require.Len(t, runs.created, 2)
+	assert.Equal(t, entity.ScheduledRunSucceeded, runs.created[0].Status)
+	assert.Nil(t, runs.created[0].TriggeredBy, "scheduler runs have no trigger user")
+	assert.True(t, dueAt.Equal(*runs.created[0].ScheduledFor))
+	assert.Equal(t, entity.ScheduledRunFailed, runs.created[1].Status)
+	assert.Contains(t, *runs.created[1].Error, "connection reset")
+	assert.Empty(t, versions.markedFailed, "a transient failure below the attempt limit is retried on the next tick")
+}
+
+func TestProcessScheduledArchivals_GivesUpAfterMaxAttempts(t *testing.T) {
+	dueAt := time.Now().Add(-time.Minute).UTC()
+	versions := newFakeRunVersionRepo(&entity.TemplateVersion{ID: "v-1", TemplateID: "t-1", Status: entity.VersionStatusPublished, ScheduledArchiveAt: &dueAt})
+	versions.updateErr["v-1"] = errors.New("connection reset")
+	s := newRunTestService(versions, &fakeRunRepo{failures: 3}, 3)
+
+	require.NoError(t, s.ProcessScheduledArchivals(context.Background()))
+
+	assert.Contains(t, versions.markedFailed["v-1"], "connection reset")
+}
+
+func TestProcessScheduledArchivals_PermanentErrorGivesUpAtOnce(t *testing.T) {
+	dueAt := time.Now().Add(-time.Minute).UTC()
+	versions := newFakeRunVersionRepo(&entity.TemplateVersion{ID: "v-1", TemplateID: "t-1", Status: entity.VersionStatusDraft, ScheduledArchiveAt: &dueAt})
+	runs := &fakeRunRepo{}
+	s := newRunTestService(versions, runs, 3)
+
+	require.NoError(t, s.ProcessScheduledArchivals(context.Background()))
+
+	assert.Contains(t, versions.markedFailed, "v-1")
+	assert.Zero(t, runs.counted, "a permanent error does not wait for the attempt limit")
+}
+
+func TestRetryScheduledOperation_Guards(t *testing.T) {
+	past := time.Now().Add(-time.Hour).UTC()
+	future := time.Now().Add(time.Hour).UTC()
+
+	tests := []struct {
+		name      string
+		version   *entity.TemplateVersion
+		operation entity.ScheduledOperation
+		workspace string
+		wantErr   error
+	}{
+		{
+			name:      "invalid operation",
+			version:   &entity.TemplateVersion{ID: "v-1", TemplateID: "t-1", Status: entity.VersionStatusPublished},
+			operation: "DELETE",
+			workspace: "ws-1",
+			wantErr:   entity.ErrInvalidScheduledOperation,
+		},
+		{
+			name:      "version of another workspace",
+			version:   &entity.TemplateVersion{ID: "v-1", TemplateID: "t-1", Status: entity.VersionStatusPublished, ScheduledArchiveAt: &past},
+			operation: entity.ScheduledOperationArchive,
+			workspace: "ws-2",
+			wantErr:   entity.ErrVersionNotFound,
+		},
+		{
+			name:      "archive not due yet",
+			version:   &entity.TemplateVersion{ID: "v-1", TemplateID: "t-1", Status: entity.VersionStatusPublished, ScheduledArchiveAt: &future},
+			operation: entity.ScheduledOperationArchive,
+			workspace: "ws-1",
+			wantErr:   entity.ErrScheduledOperationNotDue,
+		},
+		{
+			name:      "publish of a version that is not scheduled",
+			version:   &entity.TemplateVersion{ID: "v-1", TemplateID: "t-1", Status: entity.VersionStatusDraft, ScheduledPublishAt: &past},
+			operation: entity.ScheduledOperationPublish,
+			workspace: "ws-1",
+			wantErr:   entity.ErrScheduledOperationNotDue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs := &fakeRunRepo{}
+			s := newRunTestService(newFakeRunVersionRepo(tt.version), runs, 3)
+
+			_, err := s.RetryScheduledOperation(context.Background(), templateuc.RetryScheduledOperationCommand{
+				WorkspaceID: tt.workspace, VersionID: "v-1", Operation: tt.operation, UserID: "user-1",
+			})
+
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Empty(t, runs.created, "a rejected retry is not a run")
+		})
+	}
+}
+
+func TestRetryScheduledOperation_RecordsManualRun(t *testing.T) {
+	past := time.Now().Add(-time.Hour).UTC()
+	versions := newFakeRunVersionRepo(&entity.TemplateVersion{ID: "v-1", TemplateID: "t-1", Name: "v1", Status: entity.VersionStatusPublished, ScheduledArchiveAt: &past})
+	runs := &fakeRunRepo{}
+	s := newRunTestService(versions, runs, 3)
+
+	run, err := s.RetryScheduledOperation(context.Background(), templateuc.RetryScheduledOperationCommand{
+		WorkspaceID: "ws-1", VersionID: "v-1", Operation: entity.ScheduledOperationArchive, UserID: "user-1",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "run-1", run.ID)
+	assert.Equal(t, entity.ScheduledRunSucceeded, run.Status)
+	require.NotNil(t, run.TriggeredBy)
+	assert.Equal(t, "user-1", *run.TriggeredBy)
+	assert.Equal(t, "Offer", run.TemplateTitle)
+	assert.Equal(t, entity.VersionStatusArchived, versions.updated["v-1"].Status)
+}
+
+func TestRetryScheduledOperation_FailedRunIsReturned(t *testing.T) {
+	past := time.Now().Add(-time.Hour).UTC()
+	versions := newFakeRunVersionRepo(&entity.TemplateVersion{
+		ID: "v-1", TemplateID: "t-1", Status: entity.VersionStatusPublished, ScheduledArchiveAt: &past, ScheduleFailedAt: &past,
+	})
+	versions.updateErr["v-1"] = errors.New("connection reset")
+	runs := &fakeRunRepo{}
+	s := newRunTestService(versions, runs, 3)
+
+	run, err := s.RetryScheduledOperation(context.Background(), templateuc.RetryScheduledOperationCommand{
+		WorkspaceID: "ws-1", VersionID: "v-1", Operation: entity.ScheduledOperationArchive, UserID: "user-1",
+	})
+
+	require.NoError(t, err, "a failed run is a result, not an error")
+	assert.Equal(t, entity.ScheduledRunFailed, run.Status)
+	require.Len(t, runs.created, 1)
+	assert.Contains(t, versions.markedFailed["v-1"], "connection reset", "the failure reason shows the latest attempt")
+}
+
+func newRunTestService(versions *fakeRunVersionRepo, runs *fakeRunRepo, maxAttempts int) *TemplateVersionService {
+	return &TemplateVersionService{
+		versionRepo:         versions,
+		templateRepo:        &fakeRunTemplateRepo{},
+		txManager:           fakeRunTx{},
+		outboxRepo:          fakeRunOutbox{},
+		runRepo:             runs,
+		maxScheduleAttempts: maxAttempts,
+	}
+}
+
+// fakeRunVersionRepo hands out copies of the stored versions, as the database would.
+type fakeRunVersionRepo struct {
+	port.TemplateVersionRepository
+	versions     map[string]*entity.TemplateVersion
+	order        []string
+	updateErr    map[string]error
+	updated      map[string]*entity.TemplateVersion
+	markedFailed map[string]string
+}
+
+func newFakeRunVersionRepo(versions ...*entity.TemplateVersion) *fakeRunVersionRepo {
+	f := &fakeRunVersionRepo{
+		versions:     map[string]*entity.TemplateVersion{},
+		updateErr:    map[string]error{},
+		updated:      map[string]*entity.TemplateVersion{},
+		markedFailed: map[string]string{},
+	}
+	for _, v := range versions {
+		f.versions[v.ID] = v
+		f.order = append(f.order, v.ID)
+	}
+	return f
+}
+
+func (f *fakeRunVersionRepo) FindByID(_ context.Context, id string) (*entity.TemplateVersion, error) {
+	v, ok := f.versions[id]
+	if !ok {
+		return nil, entity.ErrVersionNotFound
+	}
+	c := *v
+	return &c, nil
+}
+
+func (f *fakeRunVersionRepo) FindScheduledToArchive(context.Context, time.Time) ([]*entity.TemplateVersion, error) {
+	due := make([]*entity.TemplateVersion, 0, len(f.order))
+	for _, id := range f.order {
+		v := *f.versions[id]
+		due = append(due, &v)
+	}
+	return due, nil
+}
+
+func (f *fakeRunVersionRepo) Update(_ context.Context, version *entity.TemplateVersion) error {
+	if err := f.updateErr[version.ID]; err != nil {
+		return err
+	}
+	f.updated[version.ID] = version
+	return nil
+}
+
+func (f *fakeRunVersionRepo) MarkScheduleFailed(_ context.Context, id, reason string) error {
+	f.markedFailed[id] = reason
+	return nil
+}
+
+type fakeRunTemplateRepo struct {
+	port.TemplateRepository
+}
+
+func (f *fakeRunTemplateRepo) FindByID(_ context.Context, id string) (*entity.Template, error) {
+	return &entity.Template{ID: id, WorkspaceID: "ws-1", Title: "Offer"}, nil
+}
+
+type fakeRunRepo struct {
+	port.ScheduledRunRepository
+	failures int
+	counted  int
+	created  []*entity.ScheduledRun
+}
+
+func (f *fakeRunRepo) Create(_ context.Context, run *entity.ScheduledRun) (string, error) {
+	f.created = append(f.created, run)
+	return fmt.Sprintf("run-%d", len(f.created)), nil
+}
+
+func (f *fakeRunRepo) CountFailures(context.Context, string, entity.ScheduledOperation, time.Time) (int, error) {
+	f.counted++
+	return f.failures, nil
+}
+
+type fakeRunTx struct{}
+
+func (fakeRunTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeRunOutbox struct {
+	port.OutboxRepository
+}
+
+func (fakeRunOutbox) Append(context.Context, *entity.OutboxEvent) error {
+	return nil
+}
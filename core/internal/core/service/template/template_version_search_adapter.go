@@ -214,7 +214,7 @@ func (a *TemplateVersionSearchAdapter) collectDraftVersions(
 	tenantCode, workspaceCode string,
 	templateID string,
 ) ([]port.TemplateVersionSearchItem, error) {
-	versions, err := a.versionRepo.FindByTemplateID(ctx, templateID)
+	versions, err := a.versionRepo.FindMetadataByTemplateID(ctx, templateID)
 	if err != nil {
 		return nil, fmt.Errorf("finding versions by template: %w", err)
 	}
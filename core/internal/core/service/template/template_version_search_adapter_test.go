@@ -559,7 +559,7 @@ func (s *templateResolverTemplateVersionRepoStub) FindByIDWithDetails(_ context.
 	return nil, entity.ErrVersionNotFound
 }
 
-func (s *templateResolverTemplateVersionRepoStub) FindByTemplateID(_ context.Context, templateID string) ([]*entity.TemplateVersion, error) {
+func (s *templateResolverTemplateVersionRepoStub) FindMetadataByTemplateID(_ context.Context, templateID string) ([]*entity.TemplateVersion, error) {
 	if versions, ok := s.versionsByTemplate[templateID]; ok {
 		return versions, nil
 	}
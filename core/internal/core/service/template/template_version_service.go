@@ -3,6 +3,7 @@ package template
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
@@ -11,6 +12,8 @@ import (
 
 	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	"github.com/rendis/pdf-forge/core/internal/core/port"
+	notificationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/notification"
+	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
 	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
 )
 
@@ -20,12 +23,29 @@ func NewTemplateVersionService(
 	injectableRepo port.TemplateVersionInjectableRepository,
 	templateRepo port.TemplateRepository,
 	contentValidator port.ContentValidator,
+	notificationUC notificationuc.NotificationUseCase,
+	txManager port.TransactionManager,
+	outboxRepo port.OutboxRepository,
+	runRepo port.ScheduledRunRepository,
+	releaseNotesRepo port.VersionReleaseNotesRepository,
+	settingsUC organizationuc.WorkspaceSettingsUseCase,
+	maxScheduleAttempts int,
 ) templateuc.TemplateVersionUseCase {
+	if maxScheduleAttempts < 1 {
+		maxScheduleAttempts = 1
+	}
 	return &TemplateVersionService{
-		versionRepo:      versionRepo,
-		injectableRepo:   injectableRepo,
-		templateRepo:     templateRepo,
-		contentValidator: contentValidator,
+		versionRepo:         versionRepo,
+		injectableRepo:      injectableRepo,
+		templateRepo:        templateRepo,
+		contentValidator:    contentValidator,
+		notificationUC:      notificationUC,
+		txManager:           txManager,
+		outboxRepo:          outboxRepo,
+		runRepo:             runRepo,
+		releaseNotesRepo:    releaseNotesRepo,
+		settingsUC:          settingsUC,
+		maxScheduleAttempts: maxScheduleAttempts,
 	}
 }
 
@@ -35,6 +55,16 @@ type TemplateVersionService struct {
 	injectableRepo   port.TemplateVersionInjectableRepository
 	templateRepo     port.TemplateRepository
 	contentValidator port.ContentValidator
+	notificationUC   notificationuc.NotificationUseCase
+	txManager        port.TransactionManager
+	outboxRepo       port.OutboxRepository
+	runRepo          port.ScheduledRunRepository
+	releaseNotesRepo port.VersionReleaseNotesRepository
+	settingsUC       organizationuc.WorkspaceSettingsUseCase // can be nil; schedules are then in UTC
+
+	// maxScheduleAttempts is how many times the scheduler runs a failing operation
+	// before moving the version to the failed-scheduled state.
+	maxScheduleAttempts int
 }
 
 // CreateVersion creates a new version for a template.
@@ -123,15 +153,42 @@ func (s *TemplateVersionService) GetVersionWithDetails(ctx context.Context, id s
 	if err != nil {
 		return nil, fmt.Errorf("finding version details %s: %w", id, err)
 	}
+
+	notes, err := s.releaseNotesRepo.FindByVersionID(ctx, id)
+	if err != nil && !errors.Is(err, entity.ErrReleaseNotesNotFound) {
+		return nil, err
+	}
+	details.ReleaseNotes = notes
 	return details, nil
 }
 
-// ListVersions lists all versions for a template.
+// GetChangelog gets the changelog computed when a version was published.
+func (s *TemplateVersionService) GetChangelog(ctx context.Context, versionID string) (*entity.VersionChangelog, error) {
+	changelog, err := s.versionRepo.FindChangelog(ctx, versionID)
+	if err != nil {
+		return nil, err
+	}
+	if changelog == nil {
+		return nil, entity.ErrChangelogNotFound
+	}
+	return changelog, nil
+}
+
+// ListVersions lists all versions for a template without their content structure,
+// each with its release notes.
 func (s *TemplateVersionService) ListVersions(ctx context.Context, templateID string) ([]*entity.TemplateVersion, error) {
-	versions, err := s.versionRepo.FindByTemplateID(ctx, templateID)
+	versions, err := s.versionRepo.FindMetadataByTemplateID(ctx, templateID)
 	if err != nil {
 		return nil, fmt.Errorf("listing versions: %w", err)
 	}
+
+	notes, err := s.releaseNotesRepo.FindByTemplateID(ctx, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("listing release notes: %w", err)
+	}
+	for _, version := range versions {
+		version.ReleaseNotes = notes[version.ID]
+	}
 	return versions, nil
 }
 
@@ -155,6 +212,10 @@ func (s *TemplateVersionService) UpdateVersion(ctx context.Context, cmd template
 		return nil, err
 	}
 
+	if cmd.ExpectedRevision != nil && *cmd.ExpectedRevision != version.Revision {
+		return nil, &entity.RevisionConflictError{Current: version}
+	}
+
 	if err := s.applyVersionUpdates(ctx, version, cmd); err != nil {
 		return nil, err
 	}
@@ -167,6 +228,9 @@ func (s *TemplateVersionService) UpdateVersion(ctx context.Context, cmd template
 	}
 
 	if err := s.versionRepo.Update(ctx, version); err != nil {
+		if errors.Is(err, entity.ErrRevisionConflict) {
+			return nil, s.revisionConflict(ctx, version.ID)
+		}
 		return nil, fmt.Errorf("updating version: %w", err)
 	}
 
@@ -174,6 +238,15 @@ func (s *TemplateVersionService) UpdateVersion(ctx context.Context, cmd template
 	return version, nil
 }
 
+// revisionConflict reports a lost update race together with the version as it is now.
+func (s *TemplateVersionService) revisionConflict(ctx context.Context, id string) error {
+	current, err := s.versionRepo.FindByID(ctx, id)
+	if err != nil {
+		return entity.ErrRevisionConflict
+	}
+	return &entity.RevisionConflictError{Current: current}
+}
+
 // PublishVersion publishes a version (archives current published if exists).
 func (s *TemplateVersionService) PublishVersion(ctx context.Context, id string, userID string) error {
 	version, err := s.versionRepo.FindByID(ctx, id)
@@ -195,17 +268,30 @@ func (s *TemplateVersionService) PublishVersion(ctx context.Context, id string,
 		return toContentValidationError(result)
 	}
 
-	if err := s.replaceInjectables(ctx, version.ID, result.ExtractedInjectables); err != nil {
-		return err
-	}
+	changelog := buildVersionChangelog(version, s.previousPublishedVersion(ctx, version))
 
-	if err := s.archiveCurrentPublished(ctx, version.TemplateID, id, userID); err != nil {
-		return err
-	}
+	// Injectables, the archived predecessor, the published version, its changelog and its event change together
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.replaceInjectables(ctx, version.ID, result.ExtractedInjectables); err != nil {
+			return err
+		}
 
-	version.Publish(userID)
-	if err := s.versionRepo.Update(ctx, version); err != nil {
-		return fmt.Errorf("publishing version: %w", err)
+		if err := s.archiveCurrentPublished(ctx, version.TemplateID, template.WorkspaceID, id, userID); err != nil {
+			return err
+		}
+
+		version.Publish(userID)
+		if err := s.versionRepo.Update(ctx, version); err != nil {
+			return fmt.Errorf("publishing version: %w", err)
+		}
+		if err := s.versionRepo.UpdateChangelog(ctx, version.ID, changelog); err != nil {
+			return fmt.Errorf("saving version changelog: %w", err)
+		}
+
+		return s.appendPublishedEvent(ctx, version, template.WorkspaceID, changelog)
+	})
+	if err != nil {
+		return err
 	}
 
 	slog.InfoContext(ctx, "template version published",
@@ -215,6 +301,65 @@ func (s *TemplateVersionService) PublishVersion(ctx context.Context, id string,
 	return nil
 }
 
+// appendPublishedEvent records a version.published event in the outbox.
+func (s *TemplateVersionService) appendPublishedEvent(
+	ctx context.Context,
+	version *entity.TemplateVersion,
+	workspaceID string,
+	changelog *entity.VersionChangelog,
+) error {
+	payload := entity.VersionPublished{
+		VersionID:     version.ID,
+		TemplateID:    version.TemplateID,
+		WorkspaceID:   workspaceID,
+		VersionNumber: version.VersionNumber,
+		PublishedBy:   version.PublishedBy,
+		Changelog:     changelog,
+	}
+	if version.PublishedAt != nil {
+		payload.PublishedAt = *version.PublishedAt
+	}
+
+	event, err := entity.NewOutboxEvent(entity.OutboxEventVersionPublished, version.ID, &workspaceID, payload)
+	if err != nil {
+		return err
+	}
+	if err := s.outboxRepo.Append(ctx, event); err != nil {
+		return fmt.Errorf("recording publish event: %w", err)
+	}
+	return nil
+}
+
+// appendArchivedEvent records a version.archived event in the outbox. replacedBy is the version
+// whose publication archived it, if any.
+func (s *TemplateVersionService) appendArchivedEvent(
+	ctx context.Context,
+	version *entity.TemplateVersion,
+	workspaceID string,
+	replacedBy *string,
+) error {
+	payload := entity.VersionArchived{
+		VersionID:     version.ID,
+		TemplateID:    version.TemplateID,
+		WorkspaceID:   workspaceID,
+		VersionNumber: version.VersionNumber,
+		ArchivedBy:    version.ArchivedBy,
+		ReplacedBy:    replacedBy,
+	}
+	if version.ArchivedAt != nil {
+		payload.ArchivedAt = *version.ArchivedAt
+	}
+
+	event, err := entity.NewOutboxEvent(entity.OutboxEventVersionArchived, version.ID, &workspaceID, payload)
+	if err != nil {
+		return err
+	}
+	if err := s.outboxRepo.Append(ctx, event); err != nil {
+		return fmt.Errorf("recording archive event: %w", err)
+	}
+	return nil
+}
+
 // SchedulePublish schedules a version for future publication.
 func (s *TemplateVersionService) SchedulePublish(ctx context.Context, cmd templateuc.SchedulePublishCommand) error {
 	version, err := s.versionRepo.FindByID(ctx, cmd.VersionID)
@@ -222,21 +367,22 @@ func (s *TemplateVersionService) SchedulePublish(ctx context.Context, cmd templa
 		return fmt.Errorf("finding version: %w", err)
 	}
 
-	if err := version.SchedulePublish(cmd.PublishAt); err != nil {
-		return err
-	}
-
 	template, err := s.templateRepo.FindByID(ctx, version.TemplateID)
 	if err != nil {
 		return fmt.Errorf("finding template: %w", err)
 	}
 
+	publishAt := s.scheduleTime(ctx, template.WorkspaceID, cmd.PublishAt, cmd.Local)
+	if err := version.SchedulePublish(publishAt); err != nil {
+		return err
+	}
+
 	result := s.contentValidator.ValidateForPublish(ctx, template.WorkspaceID, version.ID, version.ContentStructure)
 	if !result.Valid {
 		return toContentValidationError(result)
 	}
 
-	conflict, err := s.versionRepo.ExistsScheduledAtTime(ctx, version.TemplateID, cmd.PublishAt, &cmd.VersionID)
+	conflict, err := s.versionRepo.ExistsScheduledAtTime(ctx, version.TemplateID, publishAt, &cmd.VersionID)
 	if err != nil {
 		return fmt.Errorf("checking schedule conflict: %w", err)
 	}
@@ -250,7 +396,7 @@ func (s *TemplateVersionService) SchedulePublish(ctx context.Context, cmd templa
 
 	slog.InfoContext(ctx, "version scheduled for publication",
 		slog.String("version_id", cmd.VersionID),
-		slog.Time("publish_at", cmd.PublishAt),
+		slog.Time("publish_at", publishAt),
 	)
 	return nil
 }
@@ -270,7 +416,16 @@ func (s *TemplateVersionService) ScheduleArchive(ctx context.Context, cmd templa
 		return entity.ErrCannotArchiveWithoutReplacement
 	}
 
-	if err := version.ScheduleArchive(cmd.ArchiveAt); err != nil {
+	archiveAt := cmd.ArchiveAt
+	if cmd.Local {
+		template, err := s.templateRepo.FindByID(ctx, version.TemplateID)
+		if err != nil {
+			return fmt.Errorf("finding template: %w", err)
+		}
+		archiveAt = s.scheduleTime(ctx, template.WorkspaceID, cmd.ArchiveAt, true)
+	}
+
+	if err := version.ScheduleArchive(archiveAt); err != nil {
 		return err
 	}
 
@@ -280,11 +435,21 @@ func (s *TemplateVersionService) ScheduleArchive(ctx context.Context, cmd templa
 
 	slog.InfoContext(ctx, "version scheduled for archival",
 		slog.String("version_id", cmd.VersionID),
-		slog.Time("archive_at", cmd.ArchiveAt),
+		slog.Time("archive_at", archiveAt),
 	)
 	return nil
 }
 
+// scheduleTime returns when a scheduled operation runs. A local time is a wall-clock time of the
+// workspace timezone; across a DST change it resolves as time.Date does.
+func (s *TemplateVersionService) scheduleTime(ctx context.Context, workspaceID string, at time.Time, local bool) time.Time {
+	if !local {
+		return at
+	}
+	loc := WorkspaceLocation(ctx, s.settingsUC, workspaceID)
+	return time.Date(at.Year(), at.Month(), at.Day(), at.Hour(), at.Minute(), at.Second(), 0, loc)
+}
+
 // CancelSchedule cancels any scheduled publication or archival.
 func (s *TemplateVersionService) CancelSchedule(ctx context.Context, versionID string) error {
 	version, err := s.versionRepo.FindByID(ctx, versionID)
@@ -362,9 +527,20 @@ func (s *TemplateVersionService) ArchiveVersion(ctx context.Context, id string,
 		return err
 	}
 
+	template, err := s.templateRepo.FindByID(ctx, version.TemplateID)
+	if err != nil {
+		return fmt.Errorf("finding template: %w", err)
+	}
+
 	version.Archive(userID)
-	if err := s.versionRepo.Update(ctx, version); err != nil {
-		return fmt.Errorf("archiving version: %w", err)
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.versionRepo.Update(ctx, version); err != nil {
+			return fmt.Errorf("archiving version: %w", err)
+		}
+		return s.appendArchivedEvent(ctx, version, template.WorkspaceID, nil)
+	})
+	if err != nil {
+		return err
 	}
 
 	slog.InfoContext(ctx, "template version archived", slog.String("version_id", id))
@@ -373,7 +549,7 @@ func (s *TemplateVersionService) ArchiveVersion(ctx context.Context, id string,
 
 // DeleteVersion deletes a draft version.
 func (s *TemplateVersionService) DeleteVersion(ctx context.Context, id string) error {
-	version, err := s.versionRepo.FindByID(ctx, id)
+	version, err := s.versionRepo.FindMetadataByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("finding version: %w", err)
 	}
@@ -394,7 +570,7 @@ func (s *TemplateVersionService) DeleteVersion(ctx context.Context, id string) e
 
 // AddInjectable adds an injectable to a version.
 func (s *TemplateVersionService) AddInjectable(ctx context.Context, cmd templateuc.AddVersionInjectableCommand) (*entity.TemplateVersionInjectable, error) {
-	version, err := s.versionRepo.FindByID(ctx, cmd.VersionID)
+	version, err := s.versionRepo.FindMetadataByID(ctx, cmd.VersionID)
 	if err != nil {
 		return nil, fmt.Errorf("finding version: %w", err)
 	}
@@ -443,7 +619,7 @@ func (s *TemplateVersionService) RemoveInjectable(ctx context.Context, id string
 		return fmt.Errorf("finding injectable: %w", err)
 	}
 
-	version, err := s.versionRepo.FindByID(ctx, injectable.TemplateVersionID)
+	version, err := s.versionRepo.FindMetadataByID(ctx, injectable.TemplateVersionID)
 	if err != nil {
 		return fmt.Errorf("finding version: %w", err)
 	}
@@ -467,14 +643,21 @@ func (s *TemplateVersionService) ProcessScheduledPublications(ctx context.Contex
 	}
 
 	for _, version := range versions {
-		if err := s.PublishVersion(ctx, version.ID, "system"); err != nil {
-			slog.ErrorContext(ctx, "failed to process scheduled publication",
+		err := s.PublishVersion(ctx, version.ID, "")
+		s.recordRun(ctx, entity.NewScheduledRun(version.ID, entity.ScheduledOperationPublish, version.ScheduledPublishAt, nil, err))
+		if err != nil {
+			slog.WarnContext(ctx, "failed to process scheduled publication",
 				slog.String("version_id", version.ID),
 				slog.Any("error", err),
 			)
+			// Notify once, when the scheduler gives up, rather than on every tick
+			if s.failScheduleIfExhausted(ctx, version.ID, entity.ScheduledOperationPublish, version.ScheduledPublishAt, err) {
+				s.notifyScheduledPublish(ctx, version, err)
+			}
 			continue
 		}
 		slog.InfoContext(ctx, "scheduled publication processed", slog.String("version_id", version.ID))
+		s.notifyScheduledPublish(ctx, version, nil)
 	}
 
 	return nil
@@ -488,11 +671,14 @@ func (s *TemplateVersionService) ProcessScheduledArchivals(ctx context.Context)
 	}
 
 	for _, version := range versions {
-		if err := s.ArchiveVersion(ctx, version.ID, "system"); err != nil {
-			slog.ErrorContext(ctx, "failed to process scheduled archival",
+		err := s.ArchiveVersion(ctx, version.ID, "")
+		s.recordRun(ctx, entity.NewScheduledRun(version.ID, entity.ScheduledOperationArchive, version.ScheduledArchiveAt, nil, err))
+		if err != nil {
+			slog.WarnContext(ctx, "failed to process scheduled archival",
 				slog.String("version_id", version.ID),
 				slog.Any("error", err),
 			)
+			s.failScheduleIfExhausted(ctx, version.ID, entity.ScheduledOperationArchive, version.ScheduledArchiveAt, err)
 			continue
 		}
 		slog.InfoContext(ctx, "scheduled archival processed", slog.String("version_id", version.ID))
@@ -501,6 +687,105 @@ func (s *TemplateVersionService) ProcessScheduledArchivals(ctx context.Context)
 	return nil
 }
 
+// recentScheduledRuns is the number of runs returned by GetWorkspaceSchedule.
+const recentScheduledRuns = 50
+
+// GetWorkspaceSchedule returns the scheduler view of a workspace.
+func (s *TemplateVersionService) GetWorkspaceSchedule(ctx context.Context, workspaceID string) (*entity.WorkspaceSchedule, error) {
+	upcoming, err := s.runRepo.FindUpcomingByWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("listing upcoming scheduled operations: %w", err)
+	}
+
+	recent, err := s.runRepo.FindRecentByWorkspace(ctx, workspaceID, recentScheduledRuns)
+	if err != nil {
+		return nil, fmt.Errorf("listing scheduled runs: %w", err)
+	}
+
+	failures, err := s.runRepo.FindFailuresByWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("listing scheduled failures: %w", err)
+	}
+
+	loc := WorkspaceLocation(ctx, s.settingsUC, workspaceID)
+	for _, item := range upcoming {
+		item.ScheduledFor = item.ScheduledFor.In(loc)
+		item.FailedAt = timeIn(item.FailedAt, loc)
+	}
+	for _, runs := range [][]*entity.ScheduledRun{recent, failures} {
+		for _, run := range runs {
+			run.ScheduledFor = timeIn(run.ScheduledFor, loc)
+			run.RanAt = run.RanAt.In(loc)
+		}
+	}
+
+	return &entity.WorkspaceSchedule{Upcoming: upcoming, RecentRuns: recent, Failures: failures, Timezone: loc.String()}, nil
+}
+
+func timeIn(t *time.Time, loc *time.Location) *time.Time {
+	if t == nil {
+		return nil
+	}
+	in := t.In(loc)
+	return &in
+}
+
+// RetryScheduledOperation runs an overdue scheduled publication or archival now.
+func (s *TemplateVersionService) RetryScheduledOperation(ctx context.Context, cmd templateuc.RetryScheduledOperationCommand) (*entity.ScheduledRun, error) {
+	if !cmd.Operation.IsValid() {
+		return nil, entity.ErrInvalidScheduledOperation
+	}
+
+	version, err := s.versionRepo.FindByID(ctx, cmd.VersionID)
+	if err != nil {
+		return nil, fmt.Errorf("finding version: %w", err)
+	}
+
+	template, err := s.templateRepo.FindByID(ctx, version.TemplateID)
+	if err != nil {
+		return nil, fmt.Errorf("finding template: %w", err)
+	}
+	if template.WorkspaceID != cmd.WorkspaceID {
+		return nil, entity.ErrVersionNotFound
+	}
+
+	var scheduledFor *time.Time
+	var runErr error
+	switch cmd.Operation {
+	case entity.ScheduledOperationPublish:
+		if !version.IsScheduled() {
+			return nil, entity.ErrScheduledOperationNotDue
+		}
+		scheduledFor = version.ScheduledPublishAt
+		if scheduledFor == nil || scheduledFor.After(time.Now()) {
+			return nil, entity.ErrScheduledOperationNotDue
+		}
+		runErr = s.PublishVersion(ctx, version.ID, cmd.UserID)
+	case entity.ScheduledOperationArchive:
+		if !version.IsPublished() || version.ScheduledArchiveAt == nil || version.ScheduledArchiveAt.After(time.Now()) {
+			return nil, entity.ErrScheduledOperationNotDue
+		}
+		scheduledFor = version.ScheduledArchiveAt
+		runErr = s.ArchiveVersion(ctx, version.ID, cmd.UserID)
+	}
+
+	run := entity.NewScheduledRun(version.ID, cmd.Operation, scheduledFor, &cmd.UserID, runErr)
+	s.recordRun(ctx, run)
+	if runErr != nil && version.IsScheduleFailed() {
+		s.markScheduleFailed(ctx, version.ID, runErr)
+	}
+	run.VersionName = version.Name
+	run.TemplateID = template.ID
+	run.TemplateTitle = template.Title
+
+	slog.InfoContext(ctx, "scheduled operation retried",
+		slog.String("version_id", version.ID),
+		slog.String("operation", string(cmd.Operation)),
+		slog.String("status", string(run.Status)),
+	)
+	return run, nil
+}
+
 // --- Private helper methods ---
 
 // checkVersionNameUnique verifies the version name is unique within a template.
@@ -535,6 +820,111 @@ func (s *TemplateVersionService) copyVersionRelatedData(ctx context.Context, sou
 	}
 }
 
+// recordRun stores the outcome of a scheduled operation. Failures to record are logged only.
+func (s *TemplateVersionService) recordRun(ctx context.Context, run *entity.ScheduledRun) {
+	id, err := s.runRepo.Create(ctx, run)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to record scheduled run",
+			slog.String("version_id", run.VersionID),
+			slog.Any("error", err),
+		)
+		return
+	}
+	run.ID = id
+}
+
+// failScheduleIfExhausted moves a version to the failed-scheduled state when runErr cannot be
+// fixed by retrying or the operation has failed maxScheduleAttempts times for its scheduled time.
+// It reports whether the version was moved.
+func (s *TemplateVersionService) failScheduleIfExhausted(
+	ctx context.Context,
+	versionID string,
+	operation entity.ScheduledOperation,
+	scheduledFor *time.Time,
+	runErr error,
+) bool {
+	if !isPermanentScheduleError(runErr) && scheduledFor != nil {
+		failures, err := s.runRepo.CountFailures(ctx, versionID, operation, *scheduledFor)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to count scheduled run failures",
+				slog.String("version_id", versionID),
+				slog.Any("error", err),
+			)
+			return false
+		}
+		if failures < s.maxScheduleAttempts {
+			return false
+		}
+	}
+
+	if !s.markScheduleFailed(ctx, versionID, runErr) {
+		return false
+	}
+
+	slog.ErrorContext(ctx, "scheduled operation failed, scheduler stopped retrying",
+		slog.String("version_id", versionID),
+		slog.String("operation", string(operation)),
+		slog.Any("error", runErr),
+	)
+	return true
+}
+
+// markScheduleFailed stores runErr as the failure reason of a version. Failures to store are logged only.
+func (s *TemplateVersionService) markScheduleFailed(ctx context.Context, versionID string, runErr error) bool {
+	if err := s.versionRepo.MarkScheduleFailed(ctx, versionID, runErr.Error()); err != nil {
+		slog.WarnContext(ctx, "failed to mark schedule failed",
+			slog.String("version_id", versionID),
+			slog.Any("error", err),
+		)
+		return false
+	}
+	return true
+}
+
+// isPermanentScheduleError reports whether a scheduled operation cannot succeed until the
+// version changes, e.g. its content references an injectable deactivated since scheduling.
+func isPermanentScheduleError(err error) bool {
+	var validationErr *entity.ContentValidationError
+	return errors.As(err, &validationErr) ||
+		errors.Is(err, entity.ErrVersionAlreadyPublished) ||
+		errors.Is(err, entity.ErrCannotEditArchived) ||
+		errors.Is(err, entity.ErrVersionNotPublished)
+}
+
+// notifyScheduledPublish notifies the version author about the outcome of a scheduled publication.
+// A nil publishErr means the publication succeeded.
+func (s *TemplateVersionService) notifyScheduledPublish(ctx context.Context, version *entity.TemplateVersion, publishErr error) {
+	if s.notificationUC == nil || version.CreatedBy == nil {
+		return
+	}
+
+	cmd := notificationuc.NotifyCommand{
+		UserID:     *version.CreatedBy,
+		Type:       entity.NotificationTypeScheduledPublishSucceeded,
+		Title:      fmt.Sprintf("Version %q was published", version.Name),
+		Message:    "The scheduled publication completed successfully.",
+		ResourceID: &version.ID,
+		Time:       version.ScheduledPublishAt,
+		TimeLabel:  "Scheduled for",
+	}
+	if publishErr != nil {
+		cmd.Type = entity.NotificationTypeScheduledPublishFailed
+		cmd.Title = fmt.Sprintf("Version %q could not be published", version.Name)
+		cmd.Message = publishErr.Error()
+	}
+
+	if template, err := s.templateRepo.FindByID(ctx, version.TemplateID); err == nil {
+		cmd.WorkspaceID = &template.WorkspaceID
+	}
+
+	if err := s.notificationUC.Notify(ctx, cmd); err != nil {
+		slog.WarnContext(ctx, "failed to notify scheduled publication",
+			slog.String("version_id", version.ID),
+			slog.Any("error", err),
+		)
+	}
+}
+
 // deleteVersionRelatedData deletes injectables for a version.
 func (s *TemplateVersionService) deleteVersionRelatedData(ctx context.Context, versionID string) {
 	if err := s.injectableRepo.DeleteByVersionID(ctx, versionID); err != nil {
@@ -573,10 +963,7 @@ func (s *TemplateVersionService) applyVersionUpdates(ctx context.Context, versio
 // replaceInjectables deletes existing injectables and inserts new ones.
 func (s *TemplateVersionService) replaceInjectables(ctx context.Context, versionID string, injectables []*entity.TemplateVersionInjectable) error {
 	if err := s.injectableRepo.DeleteByVersionID(ctx, versionID); err != nil {
-		slog.WarnContext(ctx, "failed to delete existing injectables",
-			slog.String("version_id", versionID),
-			slog.Any("error", err),
-		)
+		return fmt.Errorf("deleting existing injectables: %w", err)
 	}
 
 	for _, injectable := range injectables {
@@ -623,7 +1010,7 @@ func (s *TemplateVersionService) unstageCurrentStaging(ctx context.Context, temp
 }
 
 // archiveCurrentPublished archives the currently published version if one exists.
-func (s *TemplateVersionService) archiveCurrentPublished(ctx context.Context, templateID, newVersionID, userID string) error {
+func (s *TemplateVersionService) archiveCurrentPublished(ctx context.Context, templateID, workspaceID, newVersionID, userID string) error {
 	currentPublished, err := s.versionRepo.FindPublishedByTemplateID(ctx, templateID)
 	if err != nil {
 		// No published version exists - this is expected for first publish
@@ -637,6 +1024,9 @@ func (s *TemplateVersionService) archiveCurrentPublished(ctx context.Context, te
 	if err := s.versionRepo.Update(ctx, currentPublished); err != nil {
 		return fmt.Errorf("archiving current version: %w", err)
 	}
+	if err := s.appendArchivedEvent(ctx, currentPublished, workspaceID, &newVersionID); err != nil {
+		return err
+	}
 
 	slog.InfoContext(ctx, "previous version archived",
 		slog.String("archived_version_id", currentPublished.ID),
@@ -0,0 +1,296 @@
+// Package templateimport holds what the template importers share: binding {{placeholders}}
+// to injectables, collecting the conversion report and building the imported document.
+package templateimport
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+// DefaultTitle is the title of imported documents that do not declare one.
+const DefaultTitle = "Imported template"
+
+// DefaultPageConfig is the page setup of imported documents: A4 with normal margins.
+var DefaultPageConfig = portabledoc.PageConfig{
+	FormatID: portabledoc.PageFormatA4,
+	Width:    portabledoc.StandardPageSizes[portabledoc.PageFormatA4].Width,
+	Height:   portabledoc.StandardPageSizes[portabledoc.PageFormatA4].Height,
+	Margins:  portabledoc.MarginPresets[portabledoc.MarginPresetNormal],
+}
+
+// mustachePattern matches {{expr}} and {{{expr}}} tags. Group 1 is the tag kind (#, /, ^, !, >)
+// and group 2 the expression.
+var mustachePattern = regexp.MustCompile(`\{\{\{?\s*([#/^!>]?)\s*([^{}]*?)\s*\}?\}\}`)
+
+// injectorTypes maps the injectable data types that render inline to their injector type.
+var injectorTypes = map[entity.InjectableDataType]string{
+	entity.InjectableDataTypeText:     portabledoc.InjectorTypeText,
+	entity.InjectableDataTypeNumber:   portabledoc.InjectorTypeNumber,
+	entity.InjectableDataTypeDate:     portabledoc.InjectorTypeDate,
+	entity.InjectableDataTypeCurrency: portabledoc.InjectorTypeCurrency,
+	entity.InjectableDataTypeBoolean:  portabledoc.InjectorTypeBoolean,
+}
+
+// CheckSource rejects empty and oversized templates.
+func CheckSource(source string) error {
+	if strings.TrimSpace(source) == "" {
+		return entity.ErrEmptyTemplateImport
+	}
+	if len(source) > entity.TemplateImportMaxBytes {
+		return entity.ErrTemplateImportTooLarge
+	}
+	return nil
+}
+
+// Session holds the state of one conversion: the workspace injectables, the variables the
+// document references and what goes into the report.
+type Session struct {
+	injectables map[string]*entity.InjectableDefinition // by normalized key
+	aliases     portabledoc.Set[string]                 // normalized names bound by Alias
+	variableIDs []string
+
+	mapped      map[string]string
+	unmapped    portabledoc.Set[string]
+	unsupported portabledoc.Set[string]
+	warnings    []string
+	warned      portabledoc.Set[string]
+}
+
+// NewSession creates a conversion session that binds placeholders to the given injectables.
+func NewSession(injectables []*entity.InjectableDefinition) *Session {
+	byKey := make(map[string]*entity.InjectableDefinition, len(injectables))
+	for _, inj := range injectables {
+		byKey[NormalizePlaceholder(inj.Key)] = inj
+	}
+	return &Session{
+		injectables: byKey,
+		aliases:     make(portabledoc.Set[string]),
+		variableIDs: []string{},
+		mapped:      make(map[string]string),
+		unmapped:    make(portabledoc.Set[string]),
+		unsupported: make(portabledoc.Set[string]),
+		warned:      make(portabledoc.Set[string]),
+	}
+}
+
+// Text splits text on {{placeholders}}, turning the ones bound to an injectable into injectors.
+// Unbound placeholders stay as literal text; Handlebars helpers, partials and comments are removed.
+func (s *Session) Text(text string, marks []portabledoc.Mark) []portabledoc.Node {
+	var out []portabledoc.Node
+	literal := func(t string) {
+		if t != "" {
+			out = append(out, TextNode(t, marks))
+		}
+	}
+
+	last := 0
+	for _, m := range mustachePattern.FindAllStringSubmatchIndex(text, -1) {
+		literal(text[last:m[0]])
+		last = m[1]
+
+		kind, expr := text[m[2]:m[3]], text[m[4]:m[5]]
+		switch {
+		case kind == "!":
+			// Comment
+		case kind == ">":
+			s.WarnOnce("partial:"+expr, fmt.Sprintf("Partial {{> %s}} was removed; paste its content into the template", expr))
+		case kind != "" || expr == "else":
+			s.warnBlockHelper(kind, expr)
+		default:
+			if injector, ok := s.placeholder(expr, marks); ok {
+				out = append(out, injector)
+			} else {
+				literal(text[m[0]:m[1]])
+			}
+		}
+	}
+	literal(text[last:])
+	return out
+}
+
+// placeholder binds a {{placeholder}} to an injectable, returning its injector node.
+func (s *Session) placeholder(expr string, marks []portabledoc.Mark) (portabledoc.Node, bool) {
+	name := NormalizePlaceholder(expr)
+	inj, ok := s.injectables[name]
+	if !ok || (strings.ContainsAny(expr, " ()") && !s.aliases.Contains(name)) {
+		s.unmapped.Add(expr)
+		return portabledoc.Node{}, false
+	}
+
+	injectorType, ok := injectorTypes[inj.DataType]
+	if !ok {
+		s.unmapped.Add(expr)
+		s.WarnOnce("type:"+expr, fmt.Sprintf(
+			"{{%s}} is a %s injectable and was kept as text; insert it with the editor instead", expr, inj.DataType))
+		return portabledoc.Node{}, false
+	}
+
+	s.Bind(expr, inj)
+	return portabledoc.Node{
+		Type: portabledoc.NodeTypeInjector,
+		Attrs: map[string]any{
+			"type":       injectorType,
+			"label":      InjectableLabel(inj),
+			"variableId": inj.Key,
+		},
+		Marks: marks,
+	}, true
+}
+
+// warnBlockHelper reports a Handlebars block helper once per helper name.
+func (s *Session) warnBlockHelper(kind, expr string) {
+	name, _, _ := strings.Cut(expr, " ")
+	if kind == "/" || name == "else" {
+		return // reported with the opening tag
+	}
+	s.WarnOnce("helper:"+name, fmt.Sprintf(
+		"Block helper {{%s%s}} was removed and its content kept; recreate it as a conditional or list injector", kind, name))
+}
+
+// Alias binds the placeholder name to the injectable with the given key, overriding the match by
+// name. Aliased names may contain spaces, as in {{Client Name}}. It reports false when no
+// injectable has that key.
+func (s *Session) Alias(name, key string) bool {
+	for _, inj := range s.injectables {
+		if inj.Key == key {
+			normalized := NormalizePlaceholder(name)
+			s.injectables[normalized] = inj
+			s.aliases.Add(normalized)
+			return true
+		}
+	}
+	return false
+}
+
+// Injectable looks up the injectable a placeholder name refers to.
+func (s *Session) Injectable(name string) (*entity.InjectableDefinition, bool) {
+	inj, ok := s.injectables[NormalizePlaceholder(name)]
+	return inj, ok
+}
+
+// Bind records that the placeholder name was bound to inj, adding it to the document variables.
+func (s *Session) Bind(name string, inj *entity.InjectableDefinition) {
+	s.mapped[name] = inj.Key
+	if !slices.Contains(s.variableIDs, inj.Key) {
+		s.variableIDs = append(s.variableIDs, inj.Key)
+	}
+}
+
+// Unmapped records a placeholder without a matching injectable.
+func (s *Session) Unmapped(name string) {
+	s.unmapped.Add(name)
+}
+
+// Unsupported records an element or construct that could not be converted.
+func (s *Session) Unsupported(name string) {
+	s.unsupported.Add(name)
+}
+
+// WarnOnce adds a warning to the report unless one with the same key was already added.
+func (s *Session) WarnOnce(key, message string) {
+	if s.warned.Contains(key) {
+		return
+	}
+	s.warned.Add(key)
+	s.warnings = append(s.warnings, message)
+}
+
+// Document wraps the converted content into a portable document referencing the bound variables.
+func (s *Session) Document(meta portabledoc.Meta, pageConfig portabledoc.PageConfig, content []portabledoc.Node, sourceApp string) *portabledoc.Document {
+	if len(content) == 0 {
+		content = []portabledoc.Node{{Type: portabledoc.NodeTypeParagraph}}
+	}
+	if meta.Title == "" {
+		meta.Title = DefaultTitle
+	}
+	if meta.Language == "" {
+		meta.Language = portabledoc.LanguageEnglish
+	}
+
+	return &portabledoc.Document{
+		Version:     portabledoc.CurrentVersion,
+		Meta:        meta,
+		PageConfig:  pageConfig,
+		VariableIDs: s.variableIDs,
+		Content:     &portabledoc.ProseMirrorDoc{Type: portabledoc.NodeTypeDoc, Content: content},
+		ExportInfo: portabledoc.ExportInfo{
+			ExportedAt: time.Now().UTC().Format(time.RFC3339),
+			SourceApp:  sourceApp,
+		},
+	}
+}
+
+// Report assembles the conversion report with sorted lists.
+func (s *Session) Report() *entity.TemplateImportReport {
+	unmapped := s.unmapped.ToSlice()
+	slices.Sort(unmapped)
+	unsupported := s.unsupported.ToSlice()
+	slices.Sort(unsupported)
+
+	warnings := s.warnings
+	if warnings == nil {
+		warnings = []string{}
+	}
+	return &entity.TemplateImportReport{
+		MappedPlaceholders:   s.mapped,
+		UnmappedPlaceholders: unmapped,
+		UnsupportedElements:  unsupported,
+		Warnings:             warnings,
+	}
+}
+
+// TextNode creates a text node with marks.
+func TextNode(text string, marks []portabledoc.Mark) portabledoc.Node {
+	return portabledoc.Node{Type: portabledoc.NodeTypeText, Text: &text, Marks: marks}
+}
+
+// MergeText joins adjacent text nodes with the same marks, which escapes and placeholders split.
+func MergeText(nodes []portabledoc.Node) []portabledoc.Node {
+	var out []portabledoc.Node
+	for _, node := range nodes {
+		if last := len(out) - 1; last >= 0 && node.Text != nil && out[last].Text != nil &&
+			reflect.DeepEqual(node.Marks, out[last].Marks) {
+			joined := *out[last].Text + *node.Text
+			out[last].Text = &joined
+			continue
+		}
+		out = append(out, node)
+	}
+	return out
+}
+
+// AppendMark returns a copy of marks with mark added, so sibling nodes do not share the slice.
+func AppendMark(marks []portabledoc.Mark, mark portabledoc.Mark) []portabledoc.Mark {
+	out := make([]portabledoc.Mark, 0, len(marks)+1)
+	for _, m := range marks {
+		if m.Type != mark.Type {
+			out = append(out, m)
+		}
+	}
+	return append(out, mark)
+}
+
+// NormalizePlaceholder makes placeholder names comparable with injectable keys:
+// "Customer.Name", "customer-name" and "customer_name" all match.
+func NormalizePlaceholder(name string) string {
+	name = strings.TrimPrefix(strings.TrimSpace(name), "this.")
+	return strings.ToLower(strings.NewReplacer(".", "_", "-", "_").Replace(name))
+}
+
+// InjectableLabel returns the label shown on the injector chip.
+func InjectableLabel(inj *entity.InjectableDefinition) string {
+	if inj.Label != "" {
+		return inj.Label
+	}
+	if label := inj.Labels["en"]; label != "" {
+		return label
+	}
+	return inj.Key
+}
@@ -0,0 +1,27 @@
+package template
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
+)
+
+// WorkspaceUnknownNodeMode returns how a workspace renders nodes of unknown types. Callers only
+// look it up for documents that have unknown nodes. When the settings cannot be loaded the nodes
+// are flattened, as they were before the setting existed.
+func WorkspaceUnknownNodeMode(ctx context.Context, settings organizationuc.WorkspaceSettingsUseCase, workspaceID string) entity.UnknownNodeMode {
+	if settings == nil {
+		return entity.UnknownNodesFlatten
+	}
+	view, err := settings.GetWorkspaceSettings(ctx, workspaceID)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to load workspace settings, flattening unknown nodes",
+			slog.String("workspace_id", workspaceID),
+			slog.Any("error", err),
+		)
+		return entity.UnknownNodesFlatten
+	}
+	return view.Settings.UnknownNodeMode()
+}
@@ -0,0 +1,148 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+// buildVersionChangelog computes the changelog of version against previous, the version
+// published before it. previous is nil on the first publication of the template.
+func buildVersionChangelog(version, previous *entity.TemplateVersion) *entity.VersionChangelog {
+	changelog := &entity.VersionChangelog{}
+	next := parseChangelogDocument(version.ContentStructure)
+	prev := &portabledoc.Document{}
+	if previous != nil {
+		changelog.PreviousVersionID = &previous.ID
+		changelog.PreviousVersionNumber = &previous.VersionNumber
+		prev = parseChangelogDocument(previous.ContentStructure)
+	}
+
+	changelog.SectionsAdded, changelog.SectionsRemoved = diffLists(sectionTitles(prev), sectionTitles(next))
+	changelog.InjectablesAdded, changelog.InjectablesRemoved = diffLists(prev.VariableIDs, next.VariableIDs)
+	if previous != nil {
+		changelog.PageConfigChanges = pageConfigChanges(&prev.PageConfig, &next.PageConfig)
+	} else {
+		changelog.PageConfigChanges = []string{}
+	}
+	changelog.Summarize()
+	return changelog
+}
+
+// parseChangelogDocument parses version content; content that does not parse is compared as empty.
+func parseChangelogDocument(content []byte) *portabledoc.Document {
+	doc, err := portabledoc.Parse(content)
+	if err != nil || doc == nil {
+		return &portabledoc.Document{}
+	}
+	return doc
+}
+
+// sectionTitles returns the text of the headings of the document in order.
+func sectionTitles(doc *portabledoc.Document) []string {
+	var titles []string
+	for node := range doc.AllNodesRecursive() {
+		if node.Type != portabledoc.NodeTypeHeading {
+			continue
+		}
+		if title := strings.TrimSpace(nodeText(node)); title != "" {
+			titles = append(titles, title)
+		}
+	}
+	return titles
+}
+
+// nodeText concatenates the text of node and its descendants.
+func nodeText(node portabledoc.Node) string {
+	var sb strings.Builder
+	if node.Text != nil {
+		sb.WriteString(*node.Text)
+	}
+	for _, child := range node.Content {
+		sb.WriteString(nodeText(child))
+	}
+	return sb.String()
+}
+
+// diffLists returns the values of next missing from prev and the values of prev missing
+// from next, each once and in the order of its list. Neither result is nil.
+func diffLists(prev, next []string) (added, removed []string) {
+	added, removed = []string{}, []string{}
+	for _, v := range next {
+		if !slices.Contains(prev, v) && !slices.Contains(added, v) {
+			added = append(added, v)
+		}
+	}
+	for _, v := range prev {
+		if !slices.Contains(next, v) && !slices.Contains(removed, v) {
+			removed = append(removed, v)
+		}
+	}
+	return added, removed
+}
+
+// pageConfigChanges describes the page settings that differ between prev and next.
+func pageConfigChanges(prev, next *portabledoc.PageConfig) []string {
+	changes := []string{}
+	change := func(name, from, to string) {
+		if from != to {
+			changes = append(changes, fmt.Sprintf("%s: %s → %s", name, from, to))
+		}
+	}
+
+	change("format", prev.FormatID, next.FormatID)
+	change("size", fmt.Sprintf("%gx%g", prev.Width, prev.Height), fmt.Sprintf("%gx%g", next.Width, next.Height))
+	change("margins", formatMargins(prev.Margins), formatMargins(next.Margins))
+	change("page numbers", onOff(prev.ShowPageNumbers), onOff(next.ShowPageNumbers))
+	change("stamp", onOff(prev.PageStamp != nil), onOff(next.PageStamp != nil))
+	change("overlays", onOff(!prev.Overlays.IsEmpty()), onOff(!next.Overlays.IsEmpty()))
+	return changes
+}
+
+// formatMargins formats margins as top/right/bottom/left.
+func formatMargins(m portabledoc.Margins) string {
+	return fmt.Sprintf("%g/%g/%g/%g", m.Top, m.Right, m.Bottom, m.Left)
+}
+
+func onOff(v bool) string {
+	if v {
+		return "on"
+	}
+	return "off"
+}
+
+// previousPublishedVersion returns the version published before version: the current published
+// version, or else the last one published and since archived. Returns nil when there is none.
+func (s *TemplateVersionService) previousPublishedVersion(ctx context.Context, version *entity.TemplateVersion) *entity.TemplateVersion {
+	if current, err := s.versionRepo.FindPublishedByTemplateID(ctx, version.TemplateID); err == nil && current != nil && current.ID != version.ID {
+		return current
+	}
+
+	versions, err := s.versionRepo.FindMetadataByTemplateID(ctx, version.TemplateID)
+	if err != nil {
+		return nil
+	}
+	var last *entity.TemplateVersion
+	for _, v := range versions {
+		if v.ID == version.ID || v.PublishedAt == nil {
+			continue
+		}
+		if last == nil || v.PublishedAt.After(*last.PublishedAt) {
+			last = v
+		}
+	}
+	if last == nil {
+		return nil
+	}
+
+	// Metadata queries skip the content the changelog is computed from
+	previous, err := s.versionRepo.FindByID(ctx, last.ID)
+	if err != nil {
+		return nil
+	}
+	return previous
+}
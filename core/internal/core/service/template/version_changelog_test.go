@@ -0,0 +1,64 @@
+package template
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+func changelogVersion(t *testing.T, id string, number int, format string, variableIDs []string, headings ...string) *entity.TemplateVersion {
+	t.Helper()
+	nodes := make([]portabledoc.Node, 0, len(headings))
+	for _, h := range headings {
+		nodes = append(nodes, portabledoc.Node{
+			Type:    portabledoc.NodeTypeHeading,
+			Attrs:   map[string]any{"level": 1},
+			Content: []portabledoc.Node{{Type: portabledoc.NodeTypeText, Text: strPtr(h)}},
+		})
+	}
+	doc := &portabledoc.Document{
+		Version:     portabledoc.CurrentVersion,
+		Meta:        portabledoc.Meta{Title: "Contract", Language: "en"},
+		PageConfig:  portabledoc.PageConfig{FormatID: format, Width: 794, Height: 1123},
+		VariableIDs: variableIDs,
+		Content:     &portabledoc.ProseMirrorDoc{Type: "doc", Content: nodes},
+	}
+	content, err := json.Marshal(doc)
+	require.NoError(t, err)
+	return &entity.TemplateVersion{ID: id, VersionNumber: number, ContentStructure: content}
+}
+
+func TestBuildVersionChangelog_DiffsAgainstPreviousVersion(t *testing.T) {
+	previous := changelogVersion(t, "v-1", 1, portabledoc.PageFormatA4, []string{"client_name", "amount"}, "Parties", "Payment")
+	version := changelogVersion(t, "v-2", 2, portabledoc.PageFormatLetter, []string{"client_name", "due_date"}, "Parties", "Termination")
+
+	changelog := buildVersionChangelog(version, previous)
+
+	require.NotNil(t, changelog.PreviousVersionNumber)
+	assert.Equal(t, 1, *changelog.PreviousVersionNumber)
+	assert.Equal(t, []string{"Termination"}, changelog.SectionsAdded)
+	assert.Equal(t, []string{"Payment"}, changelog.SectionsRemoved)
+	assert.Equal(t, []string{"due_date"}, changelog.InjectablesAdded)
+	assert.Equal(t, []string{"amount"}, changelog.InjectablesRemoved)
+	assert.Equal(t, []string{"format: A4 → LETTER"}, changelog.PageConfigChanges)
+	assert.Contains(t, changelog.Summary, "Changes since version 1")
+	assert.Contains(t, changelog.Summary, "+ Section added: Termination")
+	assert.Contains(t, changelog.Summary, "~ Page format: A4 → LETTER")
+}
+
+func TestBuildVersionChangelog_FirstPublication(t *testing.T) {
+	version := changelogVersion(t, "v-1", 1, portabledoc.PageFormatA4, []string{"client_name"}, "Parties")
+
+	changelog := buildVersionChangelog(version, nil)
+
+	assert.Nil(t, changelog.PreviousVersionID)
+	assert.Equal(t, []string{"Parties"}, changelog.SectionsAdded)
+	assert.Equal(t, []string{"client_name"}, changelog.InjectablesAdded)
+	assert.Empty(t, changelog.PageConfigChanges)
+	assert.Contains(t, changelog.Summary, "First published version")
+}
@@ -0,0 +1,304 @@
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+// DiffVersions computes the structural difference between two versions of a template.
+func (s *TemplateVersionService) DiffVersions(ctx context.Context, templateID, fromVersionID, toVersionID string) (*entity.VersionDiff, error) {
+	from, err := s.templateVersion(ctx, templateID, fromVersionID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.templateVersion(ctx, templateID, toVersionID)
+	if err != nil {
+		return nil, err
+	}
+	return buildVersionDiff(from, to), nil
+}
+
+// templateVersion gets a version of the template; versions of other templates are not found.
+func (s *TemplateVersionService) templateVersion(ctx context.Context, templateID, versionID string) (*entity.TemplateVersion, error) {
+	version, err := s.versionRepo.FindByID(ctx, versionID)
+	if err != nil {
+		return nil, err
+	}
+	if version.TemplateID != templateID {
+		return nil, entity.ErrVersionNotFound
+	}
+	return version, nil
+}
+
+// buildVersionDiff computes the difference from the content of version from to that of version to.
+func buildVersionDiff(from, to *entity.TemplateVersion) *entity.VersionDiff {
+	prev := parseChangelogDocument(from.ContentStructure)
+	next := parseChangelogDocument(to.ContentStructure)
+
+	diff := &entity.VersionDiff{
+		FromVersionID:     from.ID,
+		FromVersionNumber: from.VersionNumber,
+		ToVersionID:       to.ID,
+		ToVersionNumber:   to.VersionNumber,
+		Nodes:             []entity.NodeChange{},
+		PageConfigChanges: []entity.FieldChange{},
+	}
+
+	prevHeader, prevHeaderSettings := headerParts(prev.Header)
+	nextHeader, nextHeaderSettings := headerParts(next.Header)
+	prevFooter, prevFooterSettings := footerParts(prev.Footer)
+	nextFooter, nextFooterSettings := footerParts(next.Footer)
+
+	diff.Nodes = append(diff.Nodes, diffNodes("header.content", prevHeader, nextHeader)...)
+	diff.Nodes = append(diff.Nodes, diffNodes("content", docNodes(prev.Content), docNodes(next.Content))...)
+	diff.Nodes = append(diff.Nodes, diffNodes("footer.content", prevFooter, nextFooter)...)
+
+	diff.InjectablesAdded, diff.InjectablesRemoved = diffLists(prev.VariableIDs, next.VariableIDs)
+
+	diff.PageConfigChanges = append(diff.PageConfigChanges, diffFields("", prev.PageConfig, next.PageConfig)...)
+	diff.PageConfigChanges = append(diff.PageConfigChanges, diffFields("header", prevHeaderSettings, nextHeaderSettings)...)
+	diff.PageConfigChanges = append(diff.PageConfigChanges, diffFields("footer", prevFooterSettings, nextFooterSettings)...)
+	return diff
+}
+
+func docNodes(content *portabledoc.ProseMirrorDoc) []portabledoc.Node {
+	if content == nil {
+		return nil
+	}
+	return content.Content
+}
+
+// headerParts splits the header into its content nodes and its other settings.
+func headerParts(header *portabledoc.DocumentHeader) ([]portabledoc.Node, any) {
+	if header == nil {
+		return nil, nil
+	}
+	settings := *header
+	settings.Content = nil
+	return header.ContentNodes(), settings
+}
+
+// footerParts splits the footer into its content nodes and its other settings.
+func footerParts(footer *portabledoc.DocumentFooter) ([]portabledoc.Node, any) {
+	if footer == nil {
+		return nil, nil
+	}
+	settings := *footer
+	settings.Content = nil
+	return footer.ContentNodes(), settings
+}
+
+// diffNodes aligns the blocks of prev and next on their longest common subsequence. Between two
+// aligned blocks, removed and added blocks of the same type are paired as changed.
+func diffNodes(path string, prev, next []portabledoc.Node) []entity.NodeChange {
+	prevKeys := nodeKeys(prev)
+	nextKeys := nodeKeys(next)
+	lcs := lcsTable(prevKeys, nextKeys)
+
+	changes := []entity.NodeChange{}
+	var removed, added []int
+	flush := func() {
+		changes = append(changes, pairNodes(path, prev, next, removed, added)...)
+		removed, added = nil, nil
+	}
+	i, j := 0, 0
+	for i < len(prev) || j < len(next) {
+		switch {
+		case i < len(prev) && j < len(next) && prevKeys[i] == nextKeys[j]:
+			flush()
+			i++
+			j++
+		case j < len(next) && (i == len(prev) || lcs[i][j+1] >= lcs[i+1][j]):
+			added = append(added, j)
+			j++
+		default:
+			removed = append(removed, i)
+			i++
+		}
+	}
+	flush()
+	return changes
+}
+
+// lcsTable returns the lengths of the longest common subsequences of the suffixes of a and b:
+// lcs[i][j] is the length of the common subsequence of a[i:] and b[j:].
+func lcsTable(a, b []string) [][]int {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	return lcs
+}
+
+// pairNodes reports the blocks removed from prev and added to next between two aligned blocks.
+// A removed and an added block of the same type are paired as changed, preferring blocks with
+// the same text so that e.g. a heading whose level changed is not matched to another heading.
+func pairNodes(path string, prev, next []portabledoc.Node, removed, added []int) []entity.NodeChange {
+	pairs := make(map[int]int, len(removed))
+	paired := make(map[int]bool, len(added))
+	pair := func(match func(r, a int) bool) {
+		for _, r := range removed {
+			if _, ok := pairs[r]; ok {
+				continue
+			}
+			for _, a := range added {
+				if !paired[a] && next[a].Type == prev[r].Type && match(r, a) {
+					pairs[r] = a
+					paired[a] = true
+					break
+				}
+			}
+		}
+	}
+	pair(func(r, a int) bool { return nodeText(prev[r]) == nodeText(next[a]) })
+	pair(func(int, int) bool { return true })
+
+	var changes []entity.NodeChange
+	for _, r := range removed {
+		a, ok := pairs[r]
+		if !ok {
+			changes = append(changes, entity.NodeChange{
+				Kind:     entity.NodeRemoved,
+				Path:     fmt.Sprintf("%s[%d]", path, r),
+				NodeType: prev[r].Type,
+				Before:   nodeJSON(prev[r]),
+			})
+			continue
+		}
+		changes = append(changes, entity.NodeChange{
+			Kind:     entity.NodeChanged,
+			Path:     fmt.Sprintf("%s[%d]", path, a),
+			NodeType: next[a].Type,
+			Fields:   nodeFieldChanges(prev[r], next[a]),
+			Before:   nodeJSON(prev[r]),
+			After:    nodeJSON(next[a]),
+		})
+	}
+	for _, a := range added {
+		if paired[a] {
+			continue
+		}
+		changes = append(changes, entity.NodeChange{
+			Kind:     entity.NodeAdded,
+			Path:     fmt.Sprintf("%s[%d]", path, a),
+			NodeType: next[a].Type,
+			After:    nodeJSON(next[a]),
+		})
+	}
+	return changes
+}
+
+// nodeFieldChanges lists the parts of two nodes of the same type that differ.
+func nodeFieldChanges(prev, next portabledoc.Node) []string {
+	var fields []string
+	keys := slices.Collect(maps.Keys(prev.Attrs))
+	for k := range next.Attrs {
+		if _, ok := prev.Attrs[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		if string(jsonValue(prev.Attrs[k])) != string(jsonValue(next.Attrs[k])) {
+			fields = append(fields, "attrs."+k)
+		}
+	}
+	if string(jsonValue(prev.Text)) != string(jsonValue(next.Text)) {
+		fields = append(fields, "text")
+	}
+	if string(jsonValue(prev.Marks)) != string(jsonValue(next.Marks)) {
+		fields = append(fields, "marks")
+	}
+	if string(jsonValue(prev.Content)) != string(jsonValue(next.Content)) {
+		fields = append(fields, "content")
+	}
+	return fields
+}
+
+// nodeKeys returns the JSON of each node, which identifies equal blocks since object keys are sorted.
+func nodeKeys(nodes []portabledoc.Node) []string {
+	keys := make([]string, len(nodes))
+	for i, node := range nodes {
+		keys[i] = string(nodeJSON(node))
+	}
+	return keys
+}
+
+func nodeJSON(node portabledoc.Node) json.RawMessage {
+	return jsonValue(node)
+}
+
+// jsonValue marshals v; values that cannot be marshaled compare as null.
+func jsonValue(v any) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return json.RawMessage("null")
+	}
+	return data
+}
+
+// diffFields compares the JSON of prev and next leaf by leaf. Arrays are compared as a whole.
+func diffFields(prefix string, prev, next any) []entity.FieldChange {
+	prevFields := map[string]any{}
+	nextFields := map[string]any{}
+	flattenJSON(prefix, jsonValue(prev), prevFields)
+	flattenJSON(prefix, jsonValue(next), nextFields)
+
+	keys := slices.Collect(maps.Keys(prevFields))
+	for k := range nextFields {
+		if _, ok := prevFields[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	changes := []entity.FieldChange{}
+	for _, k := range keys {
+		from, to := prevFields[k], nextFields[k]
+		if string(jsonValue(from)) != string(jsonValue(to)) {
+			changes = append(changes, entity.FieldChange{Field: k, From: from, To: to})
+		}
+	}
+	return changes
+}
+
+// flattenJSON adds the leaves of data to fields keyed by their dotted path under prefix.
+func flattenJSON(prefix string, data json.RawMessage, fields map[string]any) {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return
+	}
+	flattenValue(prefix, value, fields)
+}
+
+func flattenValue(prefix string, value any, fields map[string]any) {
+	object, ok := value.(map[string]any)
+	if !ok {
+		if value != nil && prefix != "" {
+			fields[prefix] = value
+		}
+		return
+	}
+	for k, v := range object {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		flattenValue(key, v, fields)
+	}
+}
@@ -0,0 +1,123 @@
+package template
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+)
+
+func diffVersion(t *testing.T, id string, number int, doc *portabledoc.Document) *entity.TemplateVersion {
+	t.Helper()
+	content, err := json.Marshal(doc)
+	require.NoError(t, err)
+	return &entity.TemplateVersion{ID: id, TemplateID: "tpl-1", VersionNumber: number, ContentStructure: content}
+}
+
+func diffDocument(variableIDs []string, nodes ...portabledoc.Node) *portabledoc.Document {
+	return &portabledoc.Document{
+		Version:     portabledoc.CurrentVersion,
+		Meta:        portabledoc.Meta{Title: "Contract", Language: "en"},
+		PageConfig:  portabledoc.PageConfig{FormatID: portabledoc.PageFormatA4, Width: 794, Height: 1123},
+		VariableIDs: variableIDs,
+		Content:     &portabledoc.ProseMirrorDoc{Type: "doc", Content: nodes},
+	}
+}
+
+func paragraph(text string) portabledoc.Node {
+	return portabledoc.Node{
+		Type:    portabledoc.NodeTypeParagraph,
+		Content: []portabledoc.Node{{Type: portabledoc.NodeTypeText, Text: strPtr(text)}},
+	}
+}
+
+func heading(level int, text string) portabledoc.Node {
+	return portabledoc.Node{
+		Type:    portabledoc.NodeTypeHeading,
+		Attrs:   map[string]any{"level": level},
+		Content: []portabledoc.Node{{Type: portabledoc.NodeTypeText, Text: strPtr(text)}},
+	}
+}
+
+func TestBuildVersionDiff_ReportsNodeChanges(t *testing.T) {
+	from := diffVersion(t, "v-1", 1, diffDocument([]string{"client_name", "amount"},
+		heading(1, "Parties"),
+		paragraph("The client agrees."),
+		paragraph("Late payments accrue interest."),
+		heading(2, "Signatures"),
+	))
+	to := diffVersion(t, "v-2", 2, diffDocument([]string{"client_name", "due_date"},
+		heading(1, "Parties"),
+		paragraph("The client agrees."),
+		heading(1, "Signatures"),
+		portabledoc.Node{Type: portabledoc.NodeTypePageBreak},
+	))
+
+	diff := buildVersionDiff(from, to)
+
+	assert.Equal(t, "v-1", diff.FromVersionID)
+	assert.Equal(t, 2, diff.ToVersionNumber)
+	require.Len(t, diff.Nodes, 3)
+
+	assert.Equal(t, entity.NodeRemoved, diff.Nodes[0].Kind)
+	assert.Equal(t, "content[2]", diff.Nodes[0].Path)
+	assert.Equal(t, portabledoc.NodeTypeParagraph, diff.Nodes[0].NodeType)
+	assert.Nil(t, diff.Nodes[0].After)
+
+	assert.Equal(t, entity.NodeChanged, diff.Nodes[1].Kind)
+	assert.Equal(t, "content[2]", diff.Nodes[1].Path)
+	assert.Equal(t, []string{"attrs.level"}, diff.Nodes[1].Fields)
+	assert.NotNil(t, diff.Nodes[1].Before)
+	assert.NotNil(t, diff.Nodes[1].After)
+
+	assert.Equal(t, entity.NodeAdded, diff.Nodes[2].Kind)
+	assert.Equal(t, "content[3]", diff.Nodes[2].Path)
+	assert.Equal(t, portabledoc.NodeTypePageBreak, diff.Nodes[2].NodeType)
+
+	assert.Equal(t, []string{"due_date"}, diff.InjectablesAdded)
+	assert.Equal(t, []string{"amount"}, diff.InjectablesRemoved)
+}
+
+func TestBuildVersionDiff_ReportsPageConfigAndHeaderChanges(t *testing.T) {
+	prev := diffDocument(nil, paragraph("Body"))
+	next := diffDocument(nil, paragraph("Body"))
+	next.PageConfig.FormatID = portabledoc.PageFormatLetter
+	next.PageConfig.Margins.Top = 96
+	next.Header = &portabledoc.DocumentHeader{
+		Enabled: true,
+		Layout:  portabledoc.SurfaceLayoutImageLeft,
+		Content: &portabledoc.ProseMirrorDoc{Type: "doc", Content: []portabledoc.Node{paragraph("ACME")}},
+	}
+
+	diff := buildVersionDiff(diffVersion(t, "v-1", 1, prev), diffVersion(t, "v-2", 2, next))
+
+	require.Len(t, diff.Nodes, 1)
+	assert.Equal(t, entity.NodeAdded, diff.Nodes[0].Kind)
+	assert.Equal(t, "header.content[0]", diff.Nodes[0].Path)
+
+	fields := make(map[string]entity.FieldChange)
+	for _, c := range diff.PageConfigChanges {
+		fields[c.Field] = c
+	}
+	assert.Equal(t, portabledoc.PageFormatA4, fields["formatId"].From)
+	assert.Equal(t, portabledoc.PageFormatLetter, fields["formatId"].To)
+	assert.Equal(t, float64(96), fields["margins.top"].To)
+	assert.Nil(t, fields["header.enabled"].From)
+	assert.Equal(t, true, fields["header.enabled"].To)
+	assert.Equal(t, portabledoc.SurfaceLayoutImageLeft, fields["header.layout"].To)
+	assert.NotContains(t, fields, "header.content")
+}
+
+func TestBuildVersionDiff_SameContentIsEmpty(t *testing.T) {
+	doc := diffDocument([]string{"client_name"}, heading(1, "Parties"), paragraph("Body"))
+
+	diff := buildVersionDiff(diffVersion(t, "v-1", 1, doc), diffVersion(t, "v-2", 2, doc))
+
+	assert.True(t, diff.IsEmpty())
+	assert.NotNil(t, diff.Nodes)
+	assert.NotNil(t, diff.PageConfigChanges)
+}
@@ -0,0 +1,61 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	templateuc "github.com/rendis/pdf-forge/core/internal/core/usecase/template"
+)
+
+// GetReleaseNotes gets the release notes of a version.
+func (s *TemplateVersionService) GetReleaseNotes(ctx context.Context, versionID string) (*entity.VersionReleaseNotes, error) {
+	if _, err := s.versionRepo.FindMetadataByID(ctx, versionID); err != nil {
+		return nil, fmt.Errorf("finding version: %w", err)
+	}
+	return s.releaseNotesRepo.FindByVersionID(ctx, versionID)
+}
+
+// UpdateReleaseNotes creates or replaces the release notes of a version that was never published.
+func (s *TemplateVersionService) UpdateReleaseNotes(ctx context.Context, cmd templateuc.UpdateReleaseNotesCommand) (*entity.VersionReleaseNotes, error) {
+	version, err := s.versionRepo.FindMetadataByID(ctx, cmd.VersionID)
+	if err != nil {
+		return nil, fmt.Errorf("finding version: %w", err)
+	}
+	if err := version.CanEditReleaseNotes(); err != nil {
+		return nil, err
+	}
+
+	notes := entity.NewVersionReleaseNotes(cmd.VersionID, cmd.Body, cmd.TicketIDs, cmd.AuthorID)
+	if err := notes.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.releaseNotesRepo.Upsert(ctx, notes); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "version release notes updated",
+		slog.String("version_id", cmd.VersionID),
+		slog.Int("tickets", len(notes.TicketIDs)),
+	)
+	return notes, nil
+}
+
+// DeleteReleaseNotes removes the release notes of a version that was never published.
+func (s *TemplateVersionService) DeleteReleaseNotes(ctx context.Context, versionID string) error {
+	version, err := s.versionRepo.FindMetadataByID(ctx, versionID)
+	if err != nil {
+		return fmt.Errorf("finding version: %w", err)
+	}
+	if err := version.CanEditReleaseNotes(); err != nil {
+		return err
+	}
+
+	if err := s.releaseNotesRepo.Delete(ctx, versionID); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "version release notes deleted", slog.String("version_id", versionID))
+	return nil
+}
@@ -0,0 +1,26 @@
+package template
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	organizationuc "github.com/rendis/pdf-forge/core/internal/core/usecase/organization"
+)
+
+// WorkspaceLocation returns the timezone of a workspace. When the settings cannot be loaded
+// it is UTC, the timezone workspaces had before the setting existed.
+func WorkspaceLocation(ctx context.Context, settings organizationuc.WorkspaceSettingsUseCase, workspaceID string) *time.Location {
+	if settings == nil {
+		return time.UTC
+	}
+	view, err := settings.GetWorkspaceSettings(ctx, workspaceID)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to load workspace settings, using UTC",
+			slog.String("workspace_id", workspaceID),
+			slog.Any("error", err),
+		)
+		return time.UTC
+	}
+	return view.Settings.Location()
+}
@@ -0,0 +1,39 @@
+package access
+
+import (
+	"context"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// RevokeSessionCommand represents the command to revoke a session.
+type RevokeSessionCommand struct {
+	SessionID string
+	RevokedBy string
+}
+
+// AuthSessionUseCase defines the input port for tracking and restricting authenticated principals.
+type AuthSessionUseCase interface {
+	// CheckAccess returns ErrSessionRevoked or ErrReauthRequired if the principal
+	// may not use a credential issued at issuedAt.
+	CheckAccess(ctx context.Context, session *entity.AuthSession, issuedAt *time.Time) error
+
+	// RecordActivity records a request made by the principal. Writes are throttled
+	// and happen in the background, so this never blocks the request.
+	RecordActivity(ctx context.Context, session *entity.AuthSession)
+
+	// ListSessions lists sessions, most recently active first, with the total count.
+	ListSessions(ctx context.Context, filters port.AuthSessionFilters) ([]*entity.AuthSession, int64, error)
+
+	// RevokeSession rejects every further request of an API key or service account.
+	RevokeSession(ctx context.Context, cmd RevokeSessionCommand) (*entity.AuthSession, error)
+
+	// RestoreSession lifts the revocation of a session.
+	RestoreSession(ctx context.Context, id string) (*entity.AuthSession, error)
+
+	// ForceReauth rejects credentials of the session issued before now,
+	// forcing the principal to obtain a new token.
+	ForceReauth(ctx context.Context, id string) (*entity.AuthSession, error)
+}
@@ -0,0 +1,24 @@
+package access
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// UpdatePreferencesCommand contains the full set of preferences to save for a user.
+type UpdatePreferencesCommand struct {
+	UserID             string
+	Locale             string
+	Timezone           string
+	DefaultWorkspaceID *string
+}
+
+// UserPreferencesUseCase defines the interface for per-user preferences.
+type UserPreferencesUseCase interface {
+	// GetPreferences returns the user's preferences, or the defaults if none were saved.
+	GetPreferences(ctx context.Context, userID string) (*entity.UserPreferences, error)
+
+	// UpdatePreferences validates and saves the user's preferences.
+	UpdatePreferences(ctx context.Context, cmd UpdatePreferencesCommand) (*entity.UserPreferences, error)
+}
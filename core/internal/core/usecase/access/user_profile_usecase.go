@@ -0,0 +1,22 @@
+package access
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// UserProfile aggregates everything the UI needs about the current user at startup.
+type UserProfile struct {
+	User       *entity.User
+	SystemRole *entity.SystemRole // nil when the user has no system role
+	Tenants    []*entity.TenantWithRole
+	Workspaces []*entity.WorkspaceWithRole
+	Features   map[string]bool
+}
+
+// UserProfileUseCase defines the interface for resolving the current user's profile.
+type UserProfileUseCase interface {
+	// GetProfile resolves the user with their system role, memberships and effective feature flags.
+	GetProfile(ctx context.Context, userID string) (*UserProfile, error)
+}
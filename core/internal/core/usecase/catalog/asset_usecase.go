@@ -0,0 +1,117 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// UploadAssetCommand represents the command to add a file to the asset library.
+type UploadAssetCommand struct {
+	WorkspaceID string
+	Name        string
+	Tags        []string
+	Data        []byte
+	CreatedBy   string
+}
+
+// UploadAssetResult is the asset holding an uploaded file.
+type UploadAssetResult struct {
+	Asset *entity.Asset
+	// Duplicate is true when the workspace already had an asset with the same content,
+	// which is returned instead of creating a new one.
+	Duplicate bool
+}
+
+// ImportAssetCommand represents the command to download a remote image into the asset library.
+type ImportAssetCommand struct {
+	WorkspaceID string
+	URL         string
+	Name        string // Empty takes the last segment of the URL path
+	Tags        []string
+	CreatedBy   string
+}
+
+// UploadAssetVersionCommand represents the command to replace the content of an asset.
+type UploadAssetVersionCommand struct {
+	WorkspaceID string
+	ID          string
+	Data        []byte
+	CreatedBy   string
+}
+
+// UpdateAssetCommand represents the command to rename or retag an asset.
+type UpdateAssetCommand struct {
+	WorkspaceID string
+	ID          string
+	Name        string
+	Tags        []string
+}
+
+// GetAssetImageCommand represents the command to get an image asset resized for display.
+type GetAssetImageCommand struct {
+	WorkspaceID string
+	ID          string
+	Version     int // 0 is the current version
+	Width       int // 0 keeps the original width; images are never enlarged
+	// Format is "png", "jpeg" or the subtype of a registered encoder such as "webp".
+	// Empty negotiates it from Accept.
+	Format  string
+	Quality int    // 1-100; 0 uses the default
+	Accept  string // Accept header of the client
+}
+
+// AssetUseCase defines the input port for asset library operations.
+type AssetUseCase interface {
+	// UploadAsset adds a file to the library. A file whose content is already in the workspace
+	// returns the existing asset.
+	UploadAsset(ctx context.Context, cmd UploadAssetCommand) (*UploadAssetResult, error)
+
+	// ImportAsset downloads an image from a remote URL into the library. Renders use the asset
+	// instead of downloading the URL. A URL already imported returns its asset.
+	ImportAsset(ctx context.Context, cmd ImportAssetCommand) (*UploadAssetResult, error)
+
+	// UploadAssetVersion stores new content for an asset and makes it current.
+	// Templates referencing the asset render the new version from then on.
+	UploadAssetVersion(ctx context.Context, cmd UploadAssetVersionCommand) (*entity.Asset, error)
+
+	// GetAsset retrieves an asset of a workspace.
+	GetAsset(ctx context.Context, workspaceID, id string) (*entity.Asset, error)
+
+	// ListAssets lists the assets of a workspace with optional filters.
+	ListAssets(ctx context.Context, workspaceID string, filters port.AssetFilters) ([]*entity.Asset, error)
+
+	// ListAssetVersions lists the versions of an asset, newest first.
+	ListAssetVersions(ctx context.Context, workspaceID, id string) ([]*entity.AssetVersion, error)
+
+	// GetAssetContent returns a version of an asset with its content. Version 0 is the current one.
+	GetAssetContent(ctx context.Context, workspaceID, id string, version int) (*entity.AssetVersion, error)
+
+	// GetAssetImage returns an image asset resized and encoded for display.
+	GetAssetImage(ctx context.Context, cmd GetAssetImageCommand) (*entity.AssetImage, error)
+
+	// ListAssetUsages lists the template versions that reference an asset.
+	ListAssetUsages(ctx context.Context, workspaceID, id string) ([]*entity.AssetUsage, error)
+
+	// UpdateAsset renames or retags an asset.
+	UpdateAsset(ctx context.Context, cmd UpdateAssetCommand) (*entity.Asset, error)
+
+	// DeleteAsset deletes an asset with its versions.
+	// Returns error if a template version references it.
+	DeleteAsset(ctx context.Context, workspaceID, id string) error
+
+	// SignAssetURL returns a URL that serves the current version of an asset without an account
+	// until it expires.
+	SignAssetURL(ctx context.Context, workspaceID, id string) (*entity.AssetSignedURL, error)
+
+	// OpenSignedAsset returns the current version of an asset with its content when the signed
+	// URL parameters are valid. Invalid and expired signatures return ErrInvalidSignedURL.
+	OpenSignedAsset(ctx context.Context, workspaceID, id, expires, signature string) (*entity.AssetVersion, error)
+
+	// URLResolver returns a render URL resolver that turns asset:// URLs of the workspace's assets
+	// into data URLs of their current version, as well as the remote URLs the workspace imported,
+	// and passes other URLs to next, when set.
+	// Images are scaled down to the width hint of the URL, e.g. asset://<id>?w=800.
+	URLResolver(workspaceID string, next func(ctx context.Context, url string) (string, error)) func(ctx context.Context, url string) (string, error)
+}
@@ -0,0 +1,33 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// SetDocumentTypeContractCommand represents the command to set the payload contract of a document type.
+type SetDocumentTypeContractCommand struct {
+	DocumentTypeID string
+	TenantID       string // Required to verify ownership (cannot set contracts of global types)
+	Schema         json.RawMessage
+	Example        json.RawMessage
+	UpdatedBy      string
+}
+
+// DocumentTypeContractUseCase defines the input port for document type payload contract operations.
+type DocumentTypeContractUseCase interface {
+	// GetContract gets the contract of a document type of the tenant or a global one.
+	GetContract(ctx context.Context, tenantID, documentTypeID string) (*entity.DocumentTypeContract, error)
+
+	// GetContractByCode gets the contract render requests for a document type code must satisfy.
+	GetContractByCode(ctx context.Context, tenantCode, documentTypeCode string) (*entity.DocumentTypeContract, error)
+
+	// SetContract creates or replaces the contract of a document type of the tenant.
+	SetContract(ctx context.Context, cmd SetDocumentTypeContractCommand) (*entity.DocumentTypeContract, error)
+
+	// DeleteContract removes the contract of a document type of the tenant, so its renders are
+	// no longer validated.
+	DeleteContract(ctx context.Context, tenantID, documentTypeID string) error
+}
@@ -0,0 +1,46 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// UploadFontCommand represents the command to upload a font to a workspace.
+type UploadFontCommand struct {
+	WorkspaceID string
+	Data        []byte
+	CreatedBy   string
+}
+
+// UploadFontResult is the font holding an uploaded file.
+type UploadFontResult struct {
+	Font *entity.WorkspaceFont
+	// Duplicate is true when the workspace already had the same file, which is returned instead
+	// of creating a new font.
+	Duplicate bool
+}
+
+// WorkspaceFontUseCase defines the input port for the fonts uploaded to workspaces.
+type WorkspaceFontUseCase interface {
+	// UploadFont adds a TrueType or OpenType font to a workspace. Its family, weight and style
+	// are read from the file. A file the workspace already has returns the existing font.
+	UploadFont(ctx context.Context, cmd UploadFontCommand) (*UploadFontResult, error)
+
+	// ListFonts lists the fonts uploaded to a workspace, by family, style and weight.
+	ListFonts(ctx context.Context, workspaceID string) ([]*entity.WorkspaceFont, error)
+
+	// ListFontFamilies lists the font families renders of a workspace can use: those installed
+	// on the server and those uploaded to the workspace, sorted by name.
+	ListFontFamilies(ctx context.Context, workspaceID string) ([]*entity.FontFamily, error)
+
+	// GetFontContent returns a font of a workspace with its file.
+	GetFontContent(ctx context.Context, workspaceID, id string) (*entity.WorkspaceFont, error)
+
+	// DeleteFont deletes a font of a workspace. Renders no longer find it.
+	DeleteFont(ctx context.Context, workspaceID, id string) error
+
+	// FontDir returns a directory holding the fonts of a workspace, to add to the font path of
+	// its renders. Empty when the workspace has no fonts.
+	FontDir(ctx context.Context, workspaceID string) (string, error)
+}
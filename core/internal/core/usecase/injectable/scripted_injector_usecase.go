@@ -0,0 +1,56 @@
+package injectable
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// ScriptedInjectorUseCase defines the input port for scripted injectors: injectors written in
+// Starlark by system admins and registered at runtime next to the compiled ones.
+type ScriptedInjectorUseCase interface {
+	// List returns all scripted injectors, sorted by code.
+	List(ctx context.Context) ([]*entity.ScriptedInjector, error)
+
+	// Get returns a scripted injector by code.
+	Get(ctx context.Context, code string) (*entity.ScriptedInjector, error)
+
+	// Create validates, compiles and registers a new scripted injector.
+	Create(ctx context.Context, cmd SaveScriptedInjectorCommand) (*entity.ScriptedInjector, error)
+
+	// Update replaces a scripted injector. The new script takes over once it compiles.
+	Update(ctx context.Context, cmd SaveScriptedInjectorCommand) (*entity.ScriptedInjector, error)
+
+	// Delete removes a scripted injector from the registry and the database.
+	Delete(ctx context.Context, code string) error
+
+	// Test runs a script against a sample input without saving it.
+	Test(ctx context.Context, cmd TestScriptCommand) (*entity.ScriptRun, error)
+}
+
+// SaveScriptedInjectorCommand holds the data needed to create or update a scripted injector.
+type SaveScriptedInjectorCommand struct {
+	Code         string
+	Label        map[string]string
+	Description  map[string]string
+	DataType     entity.InjectableDataType
+	Source       string
+	Dependencies []string
+	IsCritical   bool
+	TimeoutMs    int
+	UserID       string
+}
+
+// TestScriptCommand holds a script and the sample input to run it with.
+type TestScriptCommand struct {
+	Source         string
+	DataType       entity.InjectableDataType
+	TimeoutMs      int
+	Payload        any
+	Resolved       map[string]any
+	TenantCode     string
+	WorkspaceCode  string
+	Environment    string
+	SelectedFormat string
+	Headers        map[string]string
+}
@@ -14,6 +14,11 @@ type CreateWorkspaceInjectableCommand struct {
 	Description  string
 	DefaultValue string
 	Metadata     map[string]any
+
+	// Formula, when set, computes the value from other injectables; DataType is then TEXT,
+	// NUMBER, DATE or BOOLEAN. Without a formula DataType is TEXT.
+	Formula  *string
+	DataType entity.InjectableDataType
 }
 
 // UpdateWorkspaceInjectableCommand represents the command to update a workspace injectable.
@@ -25,11 +30,16 @@ type UpdateWorkspaceInjectableCommand struct {
 	Description  *string
 	DefaultValue *string
 	Metadata     map[string]any
+	Formula      *string // "" removes the formula
+	DataType     *entity.InjectableDataType
+
+	// ExpectedRevision, when set, rejects the update if the injectable changed since it was read.
+	ExpectedRevision *int
 }
 
 // WorkspaceInjectableUseCase defines the input port for workspace injectable operations.
 type WorkspaceInjectableUseCase interface {
-	// CreateInjectable creates a new TEXT type injectable for the workspace.
+	// CreateInjectable creates a new TEXT type injectable, or a computed one, for the workspace.
 	CreateInjectable(ctx context.Context, cmd CreateWorkspaceInjectableCommand) (*entity.InjectableDefinition, error)
 
 	// GetInjectable retrieves an injectable by ID (must belong to workspace).
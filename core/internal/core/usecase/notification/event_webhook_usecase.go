@@ -0,0 +1,69 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// CreateEventWebhookCommand contains data for creating a workspace event webhook.
+type CreateEventWebhookCommand struct {
+	WorkspaceID string
+	Name        string
+	URL         string
+	EventTypes  []entity.DomainEventType
+	CreatedBy   string
+}
+
+// UpdateEventWebhookCommand contains data for updating a workspace event webhook.
+// RotateSecret replaces the signing secret; the new one is returned once.
+type UpdateEventWebhookCommand struct {
+	ID           string
+	WorkspaceID  string
+	Name         string
+	URL          string
+	EventTypes   []entity.DomainEventType
+	Enabled      bool
+	RotateSecret bool
+}
+
+// EventWebhookTestResult is the outcome of a sample payload posted to an event webhook.
+type EventWebhookTestResult struct {
+	StatusCode int     // 0 when the endpoint could not be reached
+	Error      *string // nil when the endpoint answered 2xx
+}
+
+// EventWebhookUseCase defines the interface for workspace event webhook management
+// and their delivery log.
+type EventWebhookUseCase interface {
+	// ListWebhooks lists all event webhooks of a workspace.
+	ListWebhooks(ctx context.Context, workspaceID string) ([]*entity.EventWebhook, error)
+
+	// GetWebhook retrieves an event webhook by ID within a workspace.
+	GetWebhook(ctx context.Context, workspaceID, id string) (*entity.EventWebhook, error)
+
+	// CreateWebhook creates a new event webhook with a generated signing secret.
+	CreateWebhook(ctx context.Context, cmd CreateEventWebhookCommand) (*entity.EventWebhook, error)
+
+	// UpdateWebhook updates an event webhook.
+	UpdateWebhook(ctx context.Context, cmd UpdateEventWebhookCommand) (*entity.EventWebhook, error)
+
+	// RotateSecrets replaces the signing secret of every event webhook of a workspace and returns
+	// the webhooks with their new secret. On error, the webhooks rotated before it are returned with it.
+	RotateSecrets(ctx context.Context, workspaceID string) ([]*entity.EventWebhook, error)
+
+	// DeleteWebhook deletes an event webhook and its delivery log.
+	DeleteWebhook(ctx context.Context, workspaceID, id string) error
+
+	// TestWebhook posts a signed webhook.ping payload to the webhook and reports the response.
+	TestWebhook(ctx context.Context, workspaceID, id string) (*EventWebhookTestResult, error)
+
+	// ListDeliveries lists the deliveries of an event webhook, newest first.
+	ListDeliveries(ctx context.Context, workspaceID, webhookID string, filter entity.WebhookDeliveryFilter) ([]*entity.WebhookDelivery, error)
+
+	// GetDelivery retrieves a delivery of an event webhook, including its payload.
+	GetDelivery(ctx context.Context, workspaceID, webhookID, id string) (*entity.WebhookDelivery, error)
+
+	// RedeliverDelivery queues a delivery again with its attempts reset.
+	RedeliverDelivery(ctx context.Context, workspaceID, webhookID, id string) error
+}
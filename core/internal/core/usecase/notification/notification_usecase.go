@@ -0,0 +1,42 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// NotifyCommand contains data for creating a notification.
+type NotifyCommand struct {
+	UserID      string
+	WorkspaceID *string
+	Type        entity.NotificationType
+	Title       string
+	Message     string
+	ResourceID  *string
+
+	// Time, when set, is appended to the message as "TimeLabel: <time>", formatted
+	// in the recipient's timezone and locale from their preferences.
+	Time      *time.Time
+	TimeLabel string
+}
+
+// NotificationUseCase defines the interface for in-product notification operations.
+type NotificationUseCase interface {
+	// Notify persists a notification and mirrors it to registered channels.
+	Notify(ctx context.Context, cmd NotifyCommand) error
+
+	// ListNotifications lists a user's notifications with pagination.
+	ListNotifications(ctx context.Context, userID string, filters port.NotificationFilters) ([]*entity.Notification, int64, error)
+
+	// CountUnread returns the number of unread notifications for a user.
+	CountUnread(ctx context.Context, userID string) (int64, error)
+
+	// MarkRead marks a single notification as read.
+	MarkRead(ctx context.Context, id, userID string) error
+
+	// MarkAllRead marks all of a user's notifications as read.
+	MarkAllRead(ctx context.Context, userID string) error
+}
@@ -0,0 +1,49 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// CreateWebhookCommand contains data for creating a workspace notification webhook.
+type CreateWebhookCommand struct {
+	WorkspaceID string
+	Provider    entity.WebhookProvider
+	Name        string
+	URL         string
+	EventTypes  []entity.NotificationType
+	CreatedBy   string
+}
+
+// UpdateWebhookCommand contains data for updating a workspace notification webhook.
+// A nil URL keeps the current one, so clients never need to resend the secret.
+type UpdateWebhookCommand struct {
+	ID          string
+	WorkspaceID string
+	Name        string
+	URL         *string
+	EventTypes  []entity.NotificationType
+	Enabled     bool
+}
+
+// NotificationWebhookUseCase defines the interface for workspace Slack/Teams webhook management.
+type NotificationWebhookUseCase interface {
+	// ListWebhooks lists all webhooks of a workspace.
+	ListWebhooks(ctx context.Context, workspaceID string) ([]*entity.NotificationWebhook, error)
+
+	// GetWebhook retrieves a webhook by ID within a workspace.
+	GetWebhook(ctx context.Context, workspaceID, id string) (*entity.NotificationWebhook, error)
+
+	// CreateWebhook creates a new webhook.
+	CreateWebhook(ctx context.Context, cmd CreateWebhookCommand) (*entity.NotificationWebhook, error)
+
+	// UpdateWebhook updates a webhook.
+	UpdateWebhook(ctx context.Context, cmd UpdateWebhookCommand) (*entity.NotificationWebhook, error)
+
+	// DeleteWebhook deletes a webhook.
+	DeleteWebhook(ctx context.Context, workspaceID, id string) error
+
+	// TestWebhook sends a sample notification through the webhook.
+	TestWebhook(ctx context.Context, workspaceID, id string) error
+}
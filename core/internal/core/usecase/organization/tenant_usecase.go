@@ -28,6 +28,48 @@ type UpdateTenantStatusCommand struct {
 	Status entity.TenantStatus
 }
 
+// BulkWorkspaceStatusCommand represents the command to set the status of several workspaces of a tenant.
+type BulkWorkspaceStatusCommand struct {
+	TenantID     string
+	WorkspaceIDs []string // Empty = every client workspace of the tenant that is not archived
+	Status       entity.WorkspaceStatus
+}
+
+// BulkWorkspaceSettingsCommand represents the command to set settings sections on several workspaces of a tenant.
+type BulkWorkspaceSettingsCommand struct {
+	TenantID     string
+	WorkspaceIDs []string                 // Empty = every client workspace of the tenant that is not archived
+	Settings     entity.WorkspaceSettings // Sections to set; sections not set keep their value
+	UpdatedBy    string
+}
+
+// BulkRotateWebhookSecretsCommand represents the command to rotate the event webhook signing secrets
+// of several workspaces of a tenant.
+type BulkRotateWebhookSecretsCommand struct {
+	TenantID     string
+	WorkspaceIDs []string // Empty = every client workspace of the tenant that is not archived
+	RotatedBy    string
+}
+
+// BulkWebhookSecretsResult holds the per-workspace outcome of a bulk secret rotation and the rotated
+// webhooks with their new secret, which is not returned again.
+type BulkWebhookSecretsResult struct {
+	BulkWorkspaceResult
+	Webhooks []*entity.EventWebhook
+}
+
+// BulkWorkspaceResult holds the per-workspace outcome of a bulk workspace operation.
+type BulkWorkspaceResult struct {
+	Succeeded []string
+	Failed    []BulkWorkspaceError
+}
+
+// BulkWorkspaceError represents an error for a specific workspace in a bulk operation.
+type BulkWorkspaceError struct {
+	WorkspaceID string
+	Error       error
+}
+
 // TenantUseCase defines the input port for tenant operations.
 type TenantUseCase interface {
 	// CreateTenant creates a new tenant with its system workspace.
@@ -61,6 +103,19 @@ type TenantUseCase interface {
 	// UpdateTenantStatus updates a tenant's status (ACTIVE, SUSPENDED, ARCHIVED).
 	UpdateTenantStatus(ctx context.Context, cmd UpdateTenantStatusCommand) (*entity.Tenant, error)
 
+	// BulkUpdateWorkspaceStatus suspends or reactivates workspaces of a tenant (system admin use).
+	// Workspaces already in the status succeed; system and archived workspaces fail.
+	BulkUpdateWorkspaceStatus(ctx context.Context, cmd BulkWorkspaceStatusCommand) (*BulkWorkspaceResult, error)
+
+	// BulkUpdateWorkspaceSettings sets settings sections, such as the render flags, on workspaces of a
+	// tenant (system admin use). System and archived workspaces fail, and so do workspaces whose
+	// tenant locked a section with another value.
+	BulkUpdateWorkspaceSettings(ctx context.Context, cmd BulkWorkspaceSettingsCommand) (*BulkWorkspaceResult, error)
+
+	// BulkRotateWebhookSecrets replaces the signing secret of every event webhook of workspaces of a
+	// tenant (system admin use). Workspaces without webhooks succeed; system and archived workspaces fail.
+	BulkRotateWebhookSecrets(ctx context.Context, cmd BulkRotateWebhookSecretsCommand) (*BulkWebhookSecretsResult, error)
+
 	// DeleteTenant deletes a tenant and all its data.
 	DeleteTenant(ctx context.Context, id string) error
 }
@@ -0,0 +1,54 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// CreateInvitationCommand contains data for inviting an email to a workspace.
+type CreateInvitationCommand struct {
+	WorkspaceID string
+	Email       string
+	Role        entity.WorkspaceRole
+	InvitedBy   string
+}
+
+// RespondInvitationCommand identifies the invitation a user accepts or declines.
+// Either InvitationID (in-app) or Token (email link) must be set.
+type RespondInvitationCommand struct {
+	InvitationID string
+	Token        string
+	UserID       string // The authenticated user answering the invitation
+}
+
+// InvitationWithWorkspace is a pending invitation with the workspace it grants access to.
+type InvitationWithWorkspace struct {
+	*entity.WorkspaceInvitation
+	Workspace *entity.Workspace
+}
+
+// WorkspaceInvitationUseCase defines the interface for workspace invitation operations.
+type WorkspaceInvitationUseCase interface {
+	// ListPendingInvitations lists pending invitations of a workspace, including expired ones.
+	ListPendingInvitations(ctx context.Context, workspaceID string) ([]*entity.WorkspaceInvitation, error)
+
+	// CreateInvitation creates a pending invitation and emails its token.
+	// The raw token is returned once so admins can share the link manually.
+	CreateInvitation(ctx context.Context, cmd CreateInvitationCommand) (*entity.WorkspaceInvitation, string, error)
+
+	// ResendInvitation issues a new token, extends the expiration and emails it again.
+	ResendInvitation(ctx context.Context, workspaceID, invitationID string) (*entity.WorkspaceInvitation, string, error)
+
+	// RevokeInvitation cancels a pending invitation.
+	RevokeInvitation(ctx context.Context, workspaceID, invitationID string) error
+
+	// ListMyInvitations lists unexpired pending invitations addressed to the user's email.
+	ListMyInvitations(ctx context.Context, userID string) ([]*InvitationWithWorkspace, error)
+
+	// AcceptInvitation accepts an invitation and creates the active membership.
+	AcceptInvitation(ctx context.Context, cmd RespondInvitationCommand) (*entity.WorkspaceMember, error)
+
+	// DeclineInvitation declines an invitation.
+	DeclineInvitation(ctx context.Context, cmd RespondInvitationCommand) error
+}
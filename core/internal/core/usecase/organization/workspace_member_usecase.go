@@ -30,6 +30,20 @@ type RemoveMemberCommand struct {
 	RemovedBy   string // The user performing the removal
 }
 
+// ChangeMemberStatusCommand contains data for deactivating or reactivating a member.
+type ChangeMemberStatusCommand struct {
+	MemberID    string
+	WorkspaceID string
+	UpdatedBy   string // The user performing the change
+}
+
+// TransferOwnershipCommand contains data for transferring workspace ownership.
+type TransferOwnershipCommand struct {
+	WorkspaceID      string
+	NewOwnerMemberID string
+	RequestedBy      string // The user performing the transfer
+}
+
 // WorkspaceMemberUseCase defines the interface for workspace member operations.
 type WorkspaceMemberUseCase interface {
 	// ListMembers lists all members of a workspace.
@@ -47,4 +61,14 @@ type WorkspaceMemberUseCase interface {
 
 	// RemoveMember removes a member from the workspace.
 	RemoveMember(ctx context.Context, cmd RemoveMemberCommand) error
+
+	// DeactivateMember blocks a member's access while keeping the membership for history.
+	DeactivateMember(ctx context.Context, cmd ChangeMemberStatusCommand) (*entity.MemberWithUser, error)
+
+	// ReactivateMember restores access for a deactivated member.
+	ReactivateMember(ctx context.Context, cmd ChangeMemberStatusCommand) (*entity.MemberWithUser, error)
+
+	// TransferOwnership makes another active member the workspace owner.
+	// The previous owner is demoted to ADMIN in the same operation.
+	TransferOwnership(ctx context.Context, cmd TransferOwnershipCommand) error
 }
@@ -0,0 +1,33 @@
+package organization
+
+import (
+	"context"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// CreateSandboxCommand represents the command to clone a workspace into a sandbox.
+type CreateSandboxCommand struct {
+	SourceWorkspaceID string
+	TTL               time.Duration // Zero uses entity.WorkspaceSandboxDefaultTTL
+	CreatedBy         string
+}
+
+// WorkspaceSandboxResult is a newly created sandbox workspace and what was copied into it.
+type WorkspaceSandboxResult struct {
+	Workspace *entity.Workspace
+	Sandbox   *entity.WorkspaceSandbox
+	Cloned    *entity.WorkspaceSandboxClone
+}
+
+// WorkspaceSandboxUseCase defines the input port for sandbox workspace operations.
+type WorkspaceSandboxUseCase interface {
+	// CreateSandbox clones a workspace's templates, injectables, folders and tags into a new
+	// temporary workspace owned by the requester. Members are not copied.
+	CreateSandbox(ctx context.Context, cmd CreateSandboxCommand) (*WorkspaceSandboxResult, error)
+
+	// DeleteExpiredSandboxes permanently deletes the sandboxes past their expiry.
+	// Returns the number of sandboxes deleted.
+	DeleteExpiredSandboxes(ctx context.Context) (int, error)
+}
@@ -0,0 +1,39 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// UpdateTenantWorkspaceDefaultsCommand represents the command to set the workspace defaults of a tenant.
+type UpdateTenantWorkspaceDefaultsCommand struct {
+	TenantID  string
+	Settings  entity.WorkspaceSettings
+	Locked    []entity.WorkspaceSettingKey
+	UpdatedBy string
+}
+
+// UpdateWorkspaceSettingsCommand represents the command to set the settings of a workspace.
+type UpdateWorkspaceSettingsCommand struct {
+	WorkspaceID string
+	Settings    entity.WorkspaceSettings
+	UpdatedBy   string
+}
+
+// WorkspaceSettingsUseCase defines the input port for workspace settings operations.
+type WorkspaceSettingsUseCase interface {
+	// GetTenantDefaults gets the settings new workspaces of a tenant start with.
+	GetTenantDefaults(ctx context.Context, tenantID string) (*entity.TenantWorkspaceDefaults, error)
+
+	// UpdateTenantDefaults replaces the workspace defaults of a tenant. Locked sections apply
+	// to existing workspaces at once; the others only to workspaces created afterwards.
+	UpdateTenantDefaults(ctx context.Context, cmd UpdateTenantWorkspaceDefaultsCommand) (*entity.TenantWorkspaceDefaults, error)
+
+	// GetWorkspaceSettings gets the settings a workspace applies, with the sections its tenant locked.
+	GetWorkspaceSettings(ctx context.Context, workspaceID string) (*entity.WorkspaceSettingsView, error)
+
+	// UpdateWorkspaceSettings replaces the settings of a workspace.
+	// Returns ErrWorkspaceSettingLocked when it changes a section the tenant locked.
+	UpdateWorkspaceSettings(ctx context.Context, cmd UpdateWorkspaceSettingsCommand) (*entity.WorkspaceSettingsView, error)
+}
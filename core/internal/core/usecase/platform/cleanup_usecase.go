@@ -0,0 +1,23 @@
+package platform
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// RunCleanupCommand represents the command to run the orphaned data cleanup.
+type RunCleanupCommand struct {
+	DryRun      bool   // Find orphans without deleting them
+	TriggeredBy string // Empty for scheduled runs
+}
+
+// CleanupUseCase defines the input port for the orphaned data cleanup.
+type CleanupUseCase interface {
+	// RunCleanup finds the orphaned data of every category, deletes it unless the run is dry, and
+	// records the run. A failing category does not stop the others; its error is in the run.
+	RunCleanup(ctx context.Context, cmd RunCleanupCommand) (*entity.CleanupRun, error)
+
+	// ListCleanupRuns lists the latest cleanup runs, newest first.
+	ListCleanupRuns(ctx context.Context, limit int) ([]*entity.CleanupRun, error)
+}
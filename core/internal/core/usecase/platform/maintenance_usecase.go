@@ -0,0 +1,35 @@
+package platform
+
+import (
+	"context"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// SetMaintenanceCommand represents the command to switch the maintenance mode.
+type SetMaintenanceCommand struct {
+	Mode          entity.MaintenanceMode
+	Message       string
+	ExpectedEndAt *time.Time
+	UpdatedBy     string
+}
+
+// MaintenanceUseCase defines the input port for the platform maintenance switch.
+type MaintenanceUseCase interface {
+	// CurrentState returns the maintenance state as seen by this instance.
+	// It never fails: if the state cannot be loaded, the last known state is returned.
+	CurrentState(ctx context.Context) *entity.MaintenanceState
+
+	// GetState reads the maintenance state from storage.
+	GetState(ctx context.Context) (*entity.MaintenanceState, error)
+
+	// SetState switches the maintenance mode for every instance.
+	SetState(ctx context.Context, cmd SetMaintenanceCommand) (*entity.MaintenanceState, error)
+
+	// BeginRender counts a render in flight on this instance; call the returned func when it ends.
+	BeginRender() func()
+
+	// InFlightRenders returns the number of renders in flight on this instance.
+	InFlightRenders() int64
+}
@@ -0,0 +1,22 @@
+package platform
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// RenderFailureArchive is a captured render failure packaged for download.
+type RenderFailureArchive struct {
+	Archive  []byte // zip with main.typ, stderr.txt, assets.json and failure.json
+	Filename string
+}
+
+// RenderFailureUseCase defines the input port for the captured Typst input of failed renders.
+type RenderFailureUseCase interface {
+	// ListRenderFailures lists the captured failures still retained, newest first.
+	ListRenderFailures(ctx context.Context, limit int) ([]*entity.RenderFailure, error)
+
+	// DownloadRenderFailure packages a captured failure with its source.
+	DownloadRenderFailure(ctx context.Context, id string) (*RenderFailureArchive, error)
+}
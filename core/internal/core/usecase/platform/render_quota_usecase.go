@@ -0,0 +1,35 @@
+package platform
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// SetRenderQuotaCommand represents the command to override the render limits of a tenant, or of
+// one of its workspaces when WorkspaceID is set. A nil limit takes the default; zero is unlimited.
+type SetRenderQuotaCommand struct {
+	TenantID         string
+	WorkspaceID      *string
+	RendersPerMinute *int
+	PagesPerMonth    *int64
+	UpdatedBy        string
+}
+
+// RenderQuotaUseCase defines the input port for viewing and adjusting render quotas.
+type RenderQuotaUseCase interface {
+	// Defaults returns the limits of tenants and workspaces without a quota.
+	Defaults() (tenant, workspace entity.RenderQuotaLimits)
+
+	// ListQuotas returns every tenant and workspace quota.
+	ListQuotas(ctx context.Context) ([]*entity.RenderQuota, error)
+
+	// GetQuota returns the limits in force for a tenant or workspace and its usage this month.
+	GetQuota(ctx context.Context, tenantID string, workspaceID *string) (*entity.RenderQuotaStatus, error)
+
+	// SetQuota overrides the limits of a tenant or workspace.
+	SetQuota(ctx context.Context, cmd SetRenderQuotaCommand) (*entity.RenderQuotaStatus, error)
+
+	// DeleteQuota removes the override of a tenant or workspace, so the defaults apply again.
+	DeleteQuota(ctx context.Context, tenantID string, workspaceID *string) error
+}
@@ -0,0 +1,14 @@
+package platform
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// SystemStatsUseCase defines the input port for the platform overview.
+type SystemStatsUseCase interface {
+	// GetSystemStats returns entity counts, queue health, and renders and storage per day over the
+	// last days days, today included.
+	GetSystemStats(ctx context.Context, days int) (*entity.SystemStats, error)
+}
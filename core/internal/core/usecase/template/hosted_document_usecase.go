@@ -0,0 +1,54 @@
+package template
+
+import (
+	"context"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// HostRenderCommand represents the command to keep a rendered PDF and share it through a viewer link.
+type HostRenderCommand struct {
+	TenantCode    string
+	WorkspaceCode string
+	Result        *port.RenderPreviewResult
+	Access        entity.HostedDocumentAccess
+	TTL           time.Duration // Zero uses entity.HostedDocumentDefaultTTL
+	AllowDownload bool
+}
+
+// HostedDocumentLink is a freshly hosted document with the link to share.
+type HostedDocumentLink struct {
+	Document *entity.HostedDocument
+	URL      string // Viewer URL; includes the token for TOKEN access
+	Token    string // Raw token for TOKEN access, empty otherwise; it cannot be retrieved later
+}
+
+// OpenHostedDocumentCommand represents a request to view a hosted document.
+type OpenHostedDocumentCommand struct {
+	DocumentID  string
+	Token       string // Token from the link, for TOKEN access
+	WorkspaceID string // Workspace of the authenticated member; empty for anonymous viewers
+	Download    bool
+}
+
+// HostedDocumentUseCase defines the input port for hosted document operations.
+type HostedDocumentUseCase interface {
+	// HostRender stores a rendered PDF in the caller's workspace and returns its viewer link.
+	HostRender(ctx context.Context, cmd HostRenderCommand) (*HostedDocumentLink, error)
+
+	// ListHostedDocuments lists the hosted documents of a workspace, without their PDFs.
+	// A non-empty query searches filenames and extracted text and orders by relevance.
+	ListHostedDocuments(ctx context.Context, workspaceID, query string) ([]*entity.HostedDocument, error)
+
+	// GetHostedDocumentThumbnail returns the PNG thumbnail of a workspace document.
+	GetHostedDocumentThumbnail(ctx context.Context, workspaceID, documentID string) ([]byte, error)
+
+	// DeleteHostedDocument deletes a hosted document of the workspace; its link stops working.
+	DeleteHostedDocument(ctx context.Context, workspaceID, documentID string) error
+
+	// OpenHostedDocument checks access to a hosted document, counts the view and returns it with its PDF.
+	// Documents the caller cannot open are reported as not found.
+	OpenHostedDocument(ctx context.Context, cmd OpenHostedDocumentCommand) (*entity.HostedDocument, error)
+}
@@ -0,0 +1,16 @@
+package template
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// InjectableCoverageUseCase defines the input port for the injectable coverage of templates.
+type InjectableCoverageUseCase interface {
+	// GetCoverage returns where the values of the injectables of a template came from in its renders
+	// through the render API over the last days, today included, flagging the injectables rendered
+	// at least minRenders times that were always defaulted or always empty. Zero days or min
+	// renders take the default.
+	GetCoverage(ctx context.Context, workspaceID, templateID string, days, minRenders int) (*entity.TemplateInjectableCoverage, error)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
 	"github.com/rendis/pdf-forge/core/internal/core/port"
 )
 
@@ -15,7 +16,14 @@ type InternalRenderCommand struct {
 	Injectables      map[string]any
 	Headers          map[string]string
 	Payload          any
-	Environment      entity.Environment // Render environment (dev or prod)
+	Environment      entity.Environment      // Render environment (dev or prod)
+	Imposition       *port.ImpositionOptions // Optional print layout applied after rendering
+	Layout           *port.LayoutParams      // Optional layout variant declared by the template
+	Overlays         *portabledoc.Overlays   // Optional watermark, stamp and Bates numbering replacing those of the template
+	DocumentID       string                  // Optional identifier of the generated document; seeds security patterns
+	Degraded         bool                    // Report failed injectors, images and external PDFs instead of failing; the template must allow it
+	UnitSystem       string                  // Optional unit system measurements are converted to, replacing that of the template
+	JobID            string                  // Set when the render runs for a render job
 }
 
 // RenderByVersionIDCommand contains the parameters for rendering a specific template version by ID.
@@ -26,7 +34,46 @@ type RenderByVersionIDCommand struct {
 	Injectables   map[string]any
 	Headers       map[string]string
 	Payload       any
-	Environment   entity.Environment // Render environment (dev or prod)
+	Environment   entity.Environment      // Render environment (dev or prod)
+	Imposition    *port.ImpositionOptions // Optional print layout applied after rendering
+	Layout        *port.LayoutParams      // Optional layout variant declared by the template
+	Overlays      *portabledoc.Overlays   // Optional watermark, stamp and Bates numbering replacing those of the template
+	DocumentID    string                  // Optional identifier of the generated document; seeds security patterns
+	Degraded      bool                    // Report failed injectors, images and external PDFs instead of failing; the template must allow it
+	UnitSystem    string                  // Optional unit system measurements are converted to, replacing that of the template
+	JobID         string                  // Set when the render runs for a render job
+}
+
+// MaxBatchRenderItems bounds the documents of a batch render.
+const MaxBatchRenderItems = 500
+
+// BatchRenderCommand contains the parameters for rendering a template version once per item.
+type BatchRenderCommand struct {
+	VersionID     string
+	TenantCode    string
+	WorkspaceCode string
+	Headers       map[string]string
+	Environment   entity.Environment      // Render environment (dev or prod)
+	Imposition    *port.ImpositionOptions // Optional print layout applied to every document
+	Layout        *port.LayoutParams      // Optional layout variant applied to every document
+	Overlays      *portabledoc.Overlays   // Optional overlays applied to every document
+	Degraded      bool                    // Degrades every document; see InternalRenderCommand
+	UnitSystem    string                  // Optional unit system applied to every document
+	Items         []BatchRenderItem
+}
+
+// BatchRenderItem is one document of a batch render.
+type BatchRenderItem struct {
+	Injectables map[string]any
+	DocumentID  string                // Optional identifier of the generated document; seeds security patterns
+	Overlays    *portabledoc.Overlays // Optional overlays replacing those of the batch, e.g. to continue Bates numbering
+}
+
+// BatchRenderItemResult is the outcome of one item of a batch render.
+type BatchRenderItemResult struct {
+	Index  int                       // Position of the item in the command
+	Result *port.RenderPreviewResult // Nil when the item failed
+	Err    error
 }
 
 // InternalRenderUseCase defines the input port for internal template rendering by codes.
@@ -38,4 +85,34 @@ type InternalRenderUseCase interface {
 	// RenderByVersionID renders a specific template version by ID, bypassing document type resolution.
 	// Uses the full injectable resolution pipeline (InitFuncs, registry, provider).
 	RenderByVersionID(ctx context.Context, cmd RenderByVersionIDCommand) (*port.RenderPreviewResult, error)
+
+	// RenderHTMLByDocumentType resolves a template like RenderByDocumentType and converts it to a
+	// standalone HTML page instead of a PDF, for in-browser previews. HTML renders are not reported
+	// to render subscribers or to the render statistics.
+	RenderHTMLByDocumentType(ctx context.Context, cmd InternalRenderCommand) (*port.HTMLRenderResult, error)
+
+	// RenderHTMLByVersionID converts a specific template version to HTML like RenderHTMLByDocumentType.
+	RenderHTMLByVersionID(ctx context.Context, cmd RenderByVersionIDCommand) (*port.HTMLRenderResult, error)
+
+	// RenderDocxByDocumentType resolves a template like RenderByDocumentType and converts it to an
+	// editable Word document, for hand-off and redlining. Like HTML renders, DOCX renders are not
+	// reported to render subscribers or to the render statistics.
+	RenderDocxByDocumentType(ctx context.Context, cmd InternalRenderCommand) (*port.DocxRenderResult, error)
+
+	// RenderDocxByVersionID converts a specific template version to DOCX like RenderDocxByDocumentType.
+	RenderDocxByVersionID(ctx context.Context, cmd RenderByVersionIDCommand) (*port.DocxRenderResult, error)
+
+	// EstimateByDocumentType resolves a template like RenderByDocumentType and estimates the page count,
+	// compile time and metered cost of rendering it with the command's payload, using a dry compile.
+	// With statisticsOnly, recent renders of the version are used instead when there are any.
+	EstimateByDocumentType(ctx context.Context, cmd InternalRenderCommand, statisticsOnly bool) (*entity.RenderEstimate, error)
+
+	// EstimateByVersionID estimates the render of a specific template version like EstimateByDocumentType.
+	EstimateByVersionID(ctx context.Context, cmd RenderByVersionIDCommand, statisticsOnly bool) (*entity.RenderEstimate, error)
+
+	// RenderBatch renders a specific template version once per item, loading the version once and
+	// rendering several items at a time. emit receives each item as it finishes, in completion order
+	// and never concurrently; a failed item does not stop the others. An error returned by emit stops
+	// the batch. Errors finding the version are returned before any item is emitted.
+	RenderBatch(ctx context.Context, cmd BatchRenderCommand, emit func(BatchRenderItemResult) error) error
 }
@@ -0,0 +1,73 @@
+package template
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// CreateNumberingSequenceCommand represents the command to create the numbering sequence of a
+// document type in a workspace.
+type CreateNumberingSequenceCommand struct {
+	WorkspaceID    string
+	DocumentTypeID string
+	InjectableKey  string
+	Prefix         string
+	Padding        int
+	YearlyReset    bool
+	Gapless        bool
+	StartNumber    int64 // Zero starts at 1
+	CreatedBy      string
+}
+
+// UpdateNumberingSequenceCommand represents the command to update a numbering sequence. The
+// document type and the counter cannot change.
+type UpdateNumberingSequenceCommand struct {
+	ID            string
+	WorkspaceID   string
+	InjectableKey string
+	Prefix        string
+	Padding       int
+	YearlyReset   bool
+	Gapless       bool
+	IsActive      bool
+}
+
+// IssueNumberCommand represents the command to issue the next number of a sequence to a render.
+type IssueNumberCommand struct {
+	Sequence   *entity.NumberingSequence
+	VersionID  string
+	TemplateID string
+	DocumentID string // Empty when the render request gave none
+}
+
+// NumberingSequenceUseCase defines the input port for the numbering sequences of workspaces and
+// the numbers renders draw from them.
+type NumberingSequenceUseCase interface {
+	// ListSequences lists the numbering sequences of a workspace.
+	ListSequences(ctx context.Context, workspaceID string) ([]*entity.NumberingSequence, error)
+
+	// GetSequence retrieves a numbering sequence of a workspace.
+	GetSequence(ctx context.Context, workspaceID, id string) (*entity.NumberingSequence, error)
+
+	// CreateSequence creates the numbering sequence of a document type of the workspace's tenant.
+	// A workspace has one sequence per document type.
+	CreateSequence(ctx context.Context, cmd CreateNumberingSequenceCommand) (*entity.NumberingSequence, error)
+
+	// UpdateSequence updates a numbering sequence. Deactivated sequences are not drawn from.
+	UpdateSequence(ctx context.Context, cmd UpdateNumberingSequenceCommand) (*entity.NumberingSequence, error)
+
+	// ListNumbers lists the numbers drawn from a sequence, newest first. limit is capped at 200;
+	// zero takes the cap.
+	ListNumbers(ctx context.Context, workspaceID, sequenceID string, limit int) ([]*entity.SequenceNumber, error)
+
+	// SequenceFor returns the active sequence of a document type in a workspace, or nil when it
+	// has none.
+	SequenceFor(ctx context.Context, workspaceID, documentTypeID string) (*entity.NumberingSequence, error)
+
+	// Issue draws the next number of a sequence and runs render with it. The number is stored in
+	// the registry with the render it was issued to and is never drawn again. When render fails, a
+	// gapless sequence releases the number to its next render; other sequences keep it in the
+	// registry as voided. Renders of a gapless sequence run concurrently on reserved numbers.
+	Issue(ctx context.Context, cmd IssueNumberCommand, render func(*entity.SequenceNumber) error) error
+}
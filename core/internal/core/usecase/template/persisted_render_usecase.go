@@ -0,0 +1,37 @@
+package template
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// PersistRenderCommand represents the command to write a rendered PDF to object storage.
+type PersistRenderCommand struct {
+	TenantCode    string
+	WorkspaceCode string
+	Result        *port.RenderPreviewResult
+}
+
+// PersistedRender is a rendered PDF written to object storage, with a signed URL to read it.
+type PersistedRender struct {
+	Key       string
+	URL       string
+	ExpiresAt time.Time // When URL stops working; the object itself is kept
+	Filename  string
+	SizeBytes int64
+	PageCount int
+}
+
+// PersistedRenderUseCase defines the input port for renders persisted to object storage.
+type PersistedRenderUseCase interface {
+	// PersistRender writes a rendered PDF to object storage and returns a signed URL to it.
+	// Returns ErrObjectStorageNotConfigured when no object storage is configured.
+	PersistRender(ctx context.Context, cmd PersistRenderCommand) (*PersistedRender, error)
+
+	// OpenSignedObject checks a signed URL served by the engine and opens the object it points to.
+	// Returns ErrInvalidSignedURL when the signature is wrong or expired, or the storage signs its own URLs.
+	OpenSignedObject(ctx context.Context, key, expires, signature string) (io.ReadCloser, error)
+}
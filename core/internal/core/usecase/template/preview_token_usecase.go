@@ -0,0 +1,41 @@
+package template
+
+import (
+	"context"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// CreatePreviewTokenCommand represents the command to share a version preview with an external reviewer.
+type CreatePreviewTokenCommand struct {
+	WorkspaceID string
+	VersionID   string
+	Label       *string
+	Watermark   *string
+	TTL         time.Duration // Zero uses entity.PreviewTokenDefaultTTL
+	CreatedBy   string
+}
+
+// PreviewAccess is what a valid preview token unlocks.
+type PreviewAccess struct {
+	Token    *entity.PreviewToken
+	Version  *entity.TemplateVersionWithDetails
+	TenantID string // Empty for the global workspace
+}
+
+// PreviewTokenUseCase defines the input port for version preview token operations.
+type PreviewTokenUseCase interface {
+	// CreatePreviewToken creates a preview token for a version of the workspace.
+	// Returns the token and the raw value to share; the raw value cannot be retrieved later.
+	CreatePreviewToken(ctx context.Context, cmd CreatePreviewTokenCommand) (*entity.PreviewToken, string, error)
+
+	// ListPreviewTokens lists the preview tokens of a version of the workspace.
+	ListPreviewTokens(ctx context.Context, workspaceID, versionID string) ([]*entity.PreviewToken, error)
+
+	// RevokePreviewToken revokes a preview token of a version of the workspace.
+	RevokePreviewToken(ctx context.Context, workspaceID, versionID, tokenID string) error
+
+	// ResolvePreviewToken checks a raw token and loads the version it grants access to.
+	ResolvePreviewToken(ctx context.Context, token string) (*PreviewAccess, error)
+}
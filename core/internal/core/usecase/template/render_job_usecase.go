@@ -0,0 +1,47 @@
+package template
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// SubmitRenderJobCommand represents a render to run in the background instead of within the request.
+// Exactly one of DocumentTypeCode and VersionID is set.
+type SubmitRenderJobCommand struct {
+	TenantCode       string
+	WorkspaceCode    string
+	DocumentTypeCode string // Resolved like RenderByDocumentType when the job runs
+	VersionID        string // Rendered like RenderByVersionID
+	Injectables      map[string]any
+	Headers          map[string]string // Credentials (Authorization, Cookie) are not kept
+	Environment      entity.Environment
+	Imposition       *port.ImpositionOptions
+	Layout           *port.LayoutParams
+	Overlays         *portabledoc.Overlays
+	DocumentID       string
+	Degraded         bool
+	UnitSystem       string
+}
+
+// RenderJobState is a render job with the link to download its PDF.
+type RenderJobState struct {
+	Job       *entity.RenderJob
+	ResultURL string // Set once the job succeeded
+}
+
+// RenderJobUseCase defines the input port for asynchronous renders.
+type RenderJobUseCase interface {
+	// SubmitRenderJob queues a render in the caller's workspace and returns the job to poll.
+	SubmitRenderJob(ctx context.Context, cmd SubmitRenderJobCommand) (*RenderJobState, error)
+
+	// GetRenderJob returns a job of the caller's workspace. Jobs of other workspaces and expired
+	// jobs are reported as not found.
+	GetRenderJob(ctx context.Context, tenantCode, workspaceCode, jobID string) (*RenderJobState, error)
+
+	// GetRenderJobResult returns a succeeded job of the caller's workspace with its PDF.
+	// Jobs that have not succeeded return ErrRenderJobNotReady.
+	GetRenderJobResult(ctx context.Context, tenantCode, workspaceCode, jobID string) (*entity.RenderJob, error)
+}
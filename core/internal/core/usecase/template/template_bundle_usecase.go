@@ -0,0 +1,43 @@
+package template
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// TemplateBundle is a template exported as a portable bundle archive.
+type TemplateBundle struct {
+	Filename string
+	Data     []byte
+}
+
+// ImportTemplateBundleCommand represents the command to create a template from a bundle.
+type ImportTemplateBundleCommand struct {
+	WorkspaceID string
+	Bundle      []byte
+	Title       string  // Replaces the bundled title when set
+	FolderID    *string // Folder of the new template; nil for the root
+	ImportedBy  string
+}
+
+// ImportTemplateBundleResult is the template created from a bundle, with the import report.
+type ImportTemplateBundleResult struct {
+	Template *entity.Template
+	Versions []*entity.TemplateVersion
+	Report   *entity.TemplateBundleReport
+}
+
+// TemplateBundleUseCase defines the input port for moving templates between workspaces and
+// environments as portable bundles.
+type TemplateBundleUseCase interface {
+	// ExportBundle packs a template of the workspace with all its versions, the definitions and
+	// i18n labels of the injectables they reference and the library assets they use.
+	ExportBundle(ctx context.Context, workspaceID, templateID string) (*TemplateBundle, error)
+
+	// ImportBundle creates a new template in the workspace from a bundle. Versions keep their
+	// order and names; the version published in the source is published again, going through
+	// publish validation, and archived versions stay archived. Missing workspace injectables are
+	// created and bundled assets are added to the library. Nothing is saved if any step fails.
+	ImportBundle(ctx context.Context, cmd ImportTemplateBundleCommand) (*ImportTemplateBundleResult, error)
+}
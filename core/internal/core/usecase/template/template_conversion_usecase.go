@@ -0,0 +1,67 @@
+package template
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// ImportTemplateCommand represents the command to replace a draft's content with a template
+// written in another format.
+type ImportTemplateCommand struct {
+	WorkspaceID string
+	VersionID   string
+	Source      string
+
+	// ExpectedRevision, when set, rejects the import if the version changed since it was read.
+	ExpectedRevision *int
+}
+
+// ImportDocxCommand represents the command to replace a draft's content with a Word document.
+type ImportDocxCommand struct {
+	WorkspaceID string
+	VersionID   string
+	Document    []byte
+
+	// Mapping binds placeholder names to injectable keys, for placeholders whose name does not match a key.
+	Mapping map[string]string
+
+	// DryRun converts the document without saving it, so the placeholders in the report can be
+	// mapped before the actual import.
+	DryRun bool
+
+	// ExpectedRevision, when set, rejects the import if the version changed since it was read.
+	ExpectedRevision *int
+}
+
+// ImportResult is the draft with the imported content and the conversion report.
+type ImportResult struct {
+	Version *entity.TemplateVersion // nil for a dry run
+	Report  *entity.TemplateImportReport
+}
+
+// MarkdownExport is a version's content rendered as Markdown.
+type MarkdownExport struct {
+	Filename string
+	Content  string
+}
+
+// TemplateConversionUseCase defines the input port for converting version content to and from other formats.
+type TemplateConversionUseCase interface {
+	// ImportHTML converts an HTML/Handlebars template into a portable document and saves it
+	// as the content of a draft version of the workspace. Placeholders are bound to the
+	// workspace's injectables by key; anything that could not be converted is listed in the report.
+	ImportHTML(ctx context.Context, cmd ImportTemplateCommand) (*ImportResult, error)
+
+	// ImportMarkdown converts a Markdown template, with its front-matter, into a portable document
+	// and saves it as the content of a draft version of the workspace.
+	ImportMarkdown(ctx context.Context, cmd ImportTemplateCommand) (*ImportResult, error)
+
+	// ImportDocx converts a Word document into a portable document and saves it as the content of
+	// a draft version of the workspace. {{placeholders}} and mail merge fields are bound by key or
+	// by the command's mapping; a dry run only returns the report.
+	ImportDocx(ctx context.Context, cmd ImportDocxCommand) (*ImportResult, error)
+
+	// ExportMarkdown renders the content of any version of the workspace as Markdown.
+	ExportMarkdown(ctx context.Context, workspaceID, versionID string) (*MarkdownExport, error)
+}
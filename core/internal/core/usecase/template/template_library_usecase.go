@@ -0,0 +1,51 @@
+package template
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// InstallLibraryTemplateCommand represents the command to install a library template into a workspace.
+type InstallLibraryTemplateCommand struct {
+	WorkspaceID      string
+	SourceTemplateID string
+	Title            string  // Title of the copy; empty keeps the library title
+	FolderID         *string // Folder of the copy; nil for the root
+	InstalledBy      string
+}
+
+// PullLibraryUpdateCommand represents the command to merge the latest library version into an
+// installed template.
+type PullLibraryUpdateCommand struct {
+	WorkspaceID string
+	TemplateID  string
+	Strategy    entity.LibraryMergeStrategy // Empty fails on conflicts
+	PulledBy    string
+}
+
+// InstallLibraryTemplateResult is the copy of a library template with its link.
+type InstallLibraryTemplateResult struct {
+	Template *entity.Template
+	Version  *entity.TemplateVersion
+	Link     *entity.TemplateLibraryLink
+}
+
+// TemplateLibraryUseCase defines the input port for installing templates from the tenant library
+// and keeping them up to date. The library of a tenant is made of the public library templates of
+// its workspaces that have a published version.
+type TemplateLibraryUseCase interface {
+	// InstallTemplate copies the published version of a library template into the workspace as a
+	// new template with a draft version, linked to the library template. Assets it uses are copied
+	// to the workspace.
+	InstallTemplate(ctx context.Context, cmd InstallLibraryTemplateCommand) (*InstallLibraryTemplateResult, error)
+
+	// PreviewUpdate compares the latest version of an installed template and the published version
+	// of its library template against the version it was installed or last pulled from.
+	PreviewUpdate(ctx context.Context, workspaceID, templateID string) (*entity.LibraryUpdatePreview, error)
+
+	// PullUpdate merges the published version of the library template into the latest version of
+	// an installed template, creating a new draft version. Conflicting changes fail the pull unless
+	// a strategy resolves them.
+	PullUpdate(ctx context.Context, cmd PullLibraryUpdateCommand) (*entity.TemplateVersion, error)
+}
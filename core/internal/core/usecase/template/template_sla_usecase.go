@@ -0,0 +1,31 @@
+package template
+
+import (
+	"context"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+// SetTemplateSLACommand represents the command to set the SLA of a template.
+type SetTemplateSLACommand struct {
+	TemplateID     string
+	WorkspaceID    string
+	MaxRenderMs    *int
+	MaxFailureRate *float64
+	WindowMinutes  int // Zero takes the default
+	MinRenders     int // Zero takes the default
+	UpdatedBy      string
+}
+
+// TemplateSLAUseCase defines the input port for template SLA operations.
+type TemplateSLAUseCase interface {
+	// GetSLA returns the SLA of a template with the renders of its current window.
+	GetSLA(ctx context.Context, workspaceID, templateID string) (*entity.TemplateSLAStatus, error)
+
+	// SetSLA creates or replaces the SLA of a template. Replacing it clears any breach: the new SLA is
+	// checked from scratch and alerts again if still breached.
+	SetSLA(ctx context.Context, cmd SetTemplateSLACommand) (*entity.TemplateSLAStatus, error)
+
+	// DeleteSLA deletes the SLA of a template.
+	DeleteSLA(ctx context.Context, workspaceID, templateID string) error
+}
@@ -25,6 +25,9 @@ type UpdateTemplateCommand struct {
 	Title           *string
 	FolderID        *string
 	IsPublicLibrary *bool
+
+	// ExpectedRevision, when set, rejects the update if the template changed since it was read.
+	ExpectedRevision *int
 }
 
 // CloneTemplateCommand represents the command to clone a template.
@@ -76,7 +79,8 @@ type TemplateUseCase interface {
 	// ListTemplatesByFolder lists all templates in a folder.
 	ListTemplatesByFolder(ctx context.Context, folderID string) ([]*entity.TemplateListItem, error)
 
-	// ListPublicLibrary lists all public library templates.
+	// ListPublicLibrary lists the library of the workspace's tenant: the public library templates
+	// with a published version of its other workspaces.
 	ListPublicLibrary(ctx context.Context, workspaceID string) ([]*entity.TemplateListItem, error)
 
 	// UpdateTemplate updates a template's metadata.
@@ -22,6 +22,17 @@ type UpdateVersionCommand struct {
 	Name             *string
 	Description      *string
 	ContentStructure json.RawMessage
+
+	// ExpectedRevision, when set, rejects the update if the version changed since it was read.
+	ExpectedRevision *int
+}
+
+// UpdateReleaseNotesCommand represents the command to write the release notes of a version.
+type UpdateReleaseNotesCommand struct {
+	VersionID string
+	Body      string
+	TicketIDs []string
+	AuthorID  *string
 }
 
 // AddVersionInjectableCommand represents the command to add an injectable to a version.
@@ -36,12 +47,22 @@ type AddVersionInjectableCommand struct {
 type SchedulePublishCommand struct {
 	VersionID string
 	PublishAt time.Time
+	Local     bool // PublishAt is a wall-clock time of the workspace timezone
 }
 
 // ScheduleArchiveCommand represents the command to schedule version archival.
 type ScheduleArchiveCommand struct {
 	VersionID string
 	ArchiveAt time.Time
+	Local     bool // ArchiveAt is a wall-clock time of the workspace timezone
+}
+
+// RetryScheduledOperationCommand represents the command to run a scheduled operation now.
+type RetryScheduledOperationCommand struct {
+	WorkspaceID string
+	VersionID   string
+	Operation   entity.ScheduledOperation
+	UserID      string
 }
 
 // TemplateVersionUseCase defines the input port for template version operations.
@@ -58,7 +79,26 @@ type TemplateVersionUseCase interface {
 	// GetVersionWithDetails retrieves a version with all related data.
 	GetVersionWithDetails(ctx context.Context, id string) (*entity.TemplateVersionWithDetails, error)
 
-	// ListVersions lists all versions for a template.
+	// GetChangelog gets the changelog computed when a version was published.
+	// Returns ErrChangelogNotFound for versions never published.
+	GetChangelog(ctx context.Context, versionID string) (*entity.VersionChangelog, error)
+
+	// DiffVersions computes the structural difference between two versions of a template, from
+	// fromVersionID to toVersionID. Returns ErrVersionNotFound for versions of other templates.
+	DiffVersions(ctx context.Context, templateID, fromVersionID, toVersionID string) (*entity.VersionDiff, error)
+
+	// GetReleaseNotes gets the release notes of a version.
+	// Returns ErrReleaseNotesNotFound when none were written.
+	GetReleaseNotes(ctx context.Context, versionID string) (*entity.VersionReleaseNotes, error)
+
+	// UpdateReleaseNotes creates or replaces the release notes of a version.
+	// Returns ErrReleaseNotesFrozen once the version was published.
+	UpdateReleaseNotes(ctx context.Context, cmd UpdateReleaseNotesCommand) (*entity.VersionReleaseNotes, error)
+
+	// DeleteReleaseNotes removes the release notes of a version that was never published.
+	DeleteReleaseNotes(ctx context.Context, versionID string) error
+
+	// ListVersions lists all versions for a template with their release notes.
 	ListVersions(ctx context.Context, templateID string) ([]*entity.TemplateVersion, error)
 
 	// GetPublishedVersion gets the currently published version for a template.
@@ -102,4 +142,12 @@ type TemplateVersionUseCase interface {
 
 	// ProcessScheduledArchivals archives all published versions whose scheduled archive time has passed.
 	ProcessScheduledArchivals(ctx context.Context) error
+
+	// GetWorkspaceSchedule returns the pending scheduled operations of a workspace
+	// with recent run results and unresolved failures.
+	GetWorkspaceSchedule(ctx context.Context, workspaceID string) (*entity.WorkspaceSchedule, error)
+
+	// RetryScheduledOperation runs an overdue scheduled operation immediately and records the outcome.
+	// A failed run is returned, not reported as an error.
+	RetryScheduledOperation(ctx context.Context, cmd RetryScheduledOperationCommand) (*entity.ScheduledRun, error)
 }
@@ -2,11 +2,14 @@ package mappers
 
 import (
 	"context"
-	"encoding/json"
 
+	"github.com/rendis/pdf-forge/core/internal/core/payload"
 	"github.com/rendis/pdf-forge/core/internal/core/port"
 )
 
+// maxPayloadBytes caps the render payload the mapper accepts when it is not streamed.
+const maxPayloadBytes = 50 << 20
+
 // ExamplePayload is the business-specific payload.
 // Define the fields you expect to receive in the request body.
 type ExamplePayload struct {
@@ -28,12 +31,35 @@ type ExamplePayload struct {
 
 type ExampleMapper struct{}
 
-// Map parses the raw body and returns the business-specific payload.
+// Map parses the render payload and returns the business-specific payload.
 // If you need to support multiple document types, route internally here.
+//
+// The payload is read field by field straight from the request body: unknown fields are
+// skipped without being materialized and missing required fields are reported before any
+// rendering starts.
 func (m *ExampleMapper) Map(ctx context.Context, mapCtx *port.MapperContext) (any, error) {
-	var payload ExamplePayload
-	if err := json.Unmarshal(mapCtx.RawBody, &payload); err != nil {
+	var p ExamplePayload
+	opts := payload.Options{
+		MaxBytes: maxPayloadBytes,
+		Required: []string{"customerName", "productId"},
+	}
+	d := mapCtx.Decoder(opts)
+	err := d.Fields(opts.Required, func(field string) error {
+		switch field {
+		case "customerName":
+			return d.Decode(&p.CustomerName)
+		case "productId":
+			return d.Decode(&p.ProductID)
+		case "amount":
+			return d.Decode(&p.Amount)
+		case "quantity":
+			return d.Decode(&p.Quantity)
+		default:
+			return d.Skip()
+		}
+	})
+	if err != nil {
 		return nil, err
 	}
-	return payload, nil
+	return p, nil
 }
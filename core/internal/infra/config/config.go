@@ -70,16 +70,89 @@ func bindEnvVars(v *viper.Viper) {
 		"database.host", "database.port", "database.user", "database.password",
 		"database.name", "database.ssl_mode", "database.max_pool_size",
 		"database.min_pool_size", "database.max_idle_time_seconds",
+		"database.max_conn_lifetime_seconds", "database.health_check_period_seconds",
+		"database.statement_timeout_seconds", "database.query_timeout_seconds",
+		"database.acquire_timeout_seconds",
+		"database.migration_lock_timeout_seconds", "database.migration_max_retries",
 		// Server
-		"server.port", "server.base_path", "server.read_timeout", "server.write_timeout",
+		"server.port", "server.base_path", "server.public_url", "server.read_timeout", "server.write_timeout",
 		"server.shutdown_timeout", "server.swagger_ui",
+		"server.body_limits.default_mb", "server.body_limits.render_mb", "server.capacity_token", "server.metrics_token",
+		"server.batch_render_timeout",
+		"server.render_api_v1_deprecation.since", "server.render_api_v1_deprecation.sunset",
+		"server.cors.allowed_origins", "server.cors.allowed_headers", "server.cors.exposed_headers",
+		"server.cors.allow_credentials", "server.cors.max_age",
+		"server.tls.cert_file", "server.tls.key_file", "server.tls.acme.enabled", "server.tls.acme.domains",
+		"server.tls.acme.email", "server.tls.acme.cache_dir", "server.tls.acme.directory_url",
+		"server.tls.acme.http_port",
+		"server.http2", "server.h2c", "server.max_header_kb",
 		// Logging
 		"logging.level", "logging.format",
+		"logging.modules.http", "logging.modules.renderer", "logging.modules.injectors",
+		"logging.modules.scheduler", "logging.modules.repos", "logging.modules.app",
+		"logging.sampling.first", "logging.sampling.thereafter", "logging.sampling.interval_seconds",
 		// Typst
 		"typst.bin_path", "typst.timeout_seconds", "typst.max_concurrent",
-		"typst.acquire_timeout_seconds",
+		"typst.acquire_timeout_seconds", "typst.image_max_size_mb", "typst.image_fetch_concurrency",
 		// Bootstrap
 		"bootstrap.enabled",
+		// Outbox
+		"outbox.poll_interval_seconds", "outbox.batch_size", "outbox.max_attempts",
+		"outbox.retention_hours",
+		// Scheduler
+		"scheduler.enabled", "scheduler.poll_interval_seconds", "scheduler.max_attempts",
+		// Document index
+		"document_index.enabled", "document_index.poll_interval_seconds", "document_index.batch_size",
+		"document_index.max_attempts", "document_index.thumbnail_width", "document_index.pdftotext_path",
+		// Render jobs
+		"render_jobs.enabled", "render_jobs.poll_interval_seconds", "render_jobs.lease_seconds",
+		"render_jobs.concurrency", "render_jobs.max_attempts",
+		// Render failures
+		"render_failures.enabled", "render_failures.retention_hours", "render_failures.max_kept",
+		"render_failures.max_source_kb",
+		// Template SLAs
+		"template_slas.enabled", "template_slas.interval_seconds",
+		// Injectable coverage
+		"injectable_coverage.enabled", "injectable_coverage.interval_seconds", "injectable_coverage.retention_days",
+		// Scripted injectors
+		"scripted_injectors.enabled", "scripted_injectors.refresh_seconds", "scripted_injectors.max_steps",
+		"scripted_injectors.max_source_kb", "scripted_injectors.max_result_kb", "scripted_injectors.max_alloc_mb",
+		"scripted_injectors.max_concurrent",
+		"wasm_plugins.enabled", "wasm_plugins.max_memory_mb", "wasm_plugins.max_concurrent",
+		"wasm_plugins.post_process_timeout_seconds", "wasm_plugins.max_http_response_kb",
+		"remote_injectors.enabled", "remote_injectors.max_concurrent", "remote_injectors.health_check_seconds",
+		"remote_injectors.max_response_kb",
+		// Render cost
+		"render_cost.per_render", "render_cost.per_page", "render_cost.per_second",
+		// Render quotas
+		"render_quotas.enabled", "render_quotas.refresh_seconds", "render_quotas.replicas",
+		"render_quotas.tenant.renders_per_minute", "render_quotas.tenant.pages_per_month",
+		"render_quotas.workspace.renders_per_minute", "render_quotas.workspace.pages_per_month",
+		// Cleanup
+		"cleanup.enabled", "cleanup.interval_seconds", "cleanup.dry_run", "cleanup.max_per_category",
+		"cleanup.preview_token_retention_days", "cleanup.unused_image_retention_days",
+		// Link check
+		"link_check.enabled", "link_check.allowed_hosts", "link_check.timeout_seconds",
+		// Event webhooks
+		"event_webhooks.enabled", "event_webhooks.poll_interval_seconds", "event_webhooks.batch_size",
+		"event_webhooks.max_attempts", "event_webhooks.timeout_seconds", "event_webhooks.retention_hours",
+		"event_webhooks.allow_private_networks",
+		// Object storage
+		"object_storage.provider", "object_storage.key_prefix", "object_storage.signed_url_ttl_seconds",
+		"object_storage.assets",
+		"object_storage.local.dir", "object_storage.local.signing_key",
+		"object_storage.s3.bucket", "object_storage.s3.region", "object_storage.s3.endpoint",
+		"object_storage.s3.access_key_id", "object_storage.s3.secret_access_key", "object_storage.s3.use_path_style",
+		"object_storage.gcs.bucket", "object_storage.gcs.hmac_access_id", "object_storage.gcs.hmac_secret",
+		// Currency
+		"currency.provider", "currency.open_exchange_rates_app_id", "currency.rounding",
+		"currency.cache_seconds", "currency.timeout_seconds",
+		// Assets
+		"assets.signing_key", "assets.signed_url_ttl_seconds",
+		"assets.import_timeout_seconds", "assets.import_allow_private_networks",
+		// Frontend
+		"frontend.api_base_url", "frontend.branding.title", "frontend.branding.logo_url",
+		"frontend.branding.favicon_url", "frontend.branding.primary_color",
 		// Environment
 		"environment",
 	}
@@ -93,10 +166,21 @@ func setDefaults(v *viper.Viper) {
 	// Server defaults
 	v.SetDefault("server.port", "8080")
 	v.SetDefault("server.base_path", "")
+	v.SetDefault("server.public_url", "")
 	v.SetDefault("server.read_timeout", 30)
 	v.SetDefault("server.write_timeout", 30)
 	v.SetDefault("server.shutdown_timeout", 10)
 	v.SetDefault("server.swagger_ui", false)
+	v.SetDefault("server.body_limits.default_mb", 20)
+	v.SetDefault("server.body_limits.render_mb", 50)
+	v.SetDefault("server.capacity_token", "")
+	v.SetDefault("server.metrics_token", "")
+	v.SetDefault("server.batch_render_timeout", 600)
+	v.SetDefault("server.cors.allow_credentials", true)
+	v.SetDefault("server.tls.acme.cache_dir", "certs")
+	v.SetDefault("server.http2", true)
+	v.SetDefault("server.h2c", false)
+	v.SetDefault("server.max_header_kb", 1024)
 
 	// Database defaults
 	v.SetDefault("database.host", "localhost")
@@ -107,18 +191,155 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("database.max_pool_size", 10)
 	v.SetDefault("database.min_pool_size", 2)
 	v.SetDefault("database.max_idle_time_seconds", 300)
+	v.SetDefault("database.max_conn_lifetime_seconds", 3600)
+	v.SetDefault("database.health_check_period_seconds", 60)
+	v.SetDefault("database.statement_timeout_seconds", 30)
+	v.SetDefault("database.query_timeout_seconds", 30)
+	v.SetDefault("database.acquire_timeout_seconds", 10)
+	v.SetDefault("database.migration_lock_timeout_seconds", 5)
+	v.SetDefault("database.migration_max_retries", 5)
 
 	// Logging defaults
 	v.SetDefault("logging.level", "info")
 	v.SetDefault("logging.format", "json")
+	v.SetDefault("logging.sampling.first", 100)
+	v.SetDefault("logging.sampling.thereafter", 100)
+	v.SetDefault("logging.sampling.interval_seconds", 1)
 
 	// Typst defaults
 	v.SetDefault("typst.bin_path", "typst")
 	v.SetDefault("typst.timeout_seconds", 10)
+	v.SetDefault("typst.image_max_size_mb", 20)
+	v.SetDefault("typst.image_fetch_concurrency", 4)
 
 	// Bootstrap defaults
 	v.SetDefault("bootstrap.enabled", true)
 
+	// Outbox defaults
+	v.SetDefault("outbox.poll_interval_seconds", 2)
+	v.SetDefault("outbox.batch_size", 50)
+	v.SetDefault("outbox.max_attempts", 10)
+	v.SetDefault("outbox.retention_hours", 168)
+
+	// Scheduler defaults
+	v.SetDefault("scheduler.enabled", true)
+	v.SetDefault("scheduler.poll_interval_seconds", 60)
+	v.SetDefault("scheduler.max_attempts", 3)
+
+	// Document index defaults
+	v.SetDefault("document_index.enabled", true)
+	v.SetDefault("document_index.poll_interval_seconds", 5)
+	v.SetDefault("document_index.batch_size", 10)
+	v.SetDefault("document_index.max_attempts", 3)
+	v.SetDefault("document_index.thumbnail_width", 320)
+	v.SetDefault("document_index.pdftotext_path", "pdftotext")
+
+	// Render job defaults
+	v.SetDefault("render_jobs.enabled", true)
+	v.SetDefault("render_jobs.poll_interval_seconds", 2)
+	v.SetDefault("render_jobs.lease_seconds", 600)
+	v.SetDefault("render_jobs.concurrency", 2)
+	v.SetDefault("render_jobs.max_attempts", 3)
+
+	// Render failure defaults
+	v.SetDefault("render_failures.enabled", true)
+	v.SetDefault("render_failures.retention_hours", 168)
+	v.SetDefault("render_failures.max_kept", 500)
+	v.SetDefault("render_failures.max_source_kb", 2048)
+
+	// Template SLA defaults
+	v.SetDefault("template_slas.enabled", true)
+	v.SetDefault("template_slas.interval_seconds", 60)
+
+	// Injectable coverage defaults
+	v.SetDefault("injectable_coverage.enabled", true)
+	v.SetDefault("injectable_coverage.interval_seconds", 60)
+	v.SetDefault("injectable_coverage.retention_days", 30)
+
+	// Scripted injector defaults
+	v.SetDefault("scripted_injectors.enabled", true)
+	v.SetDefault("scripted_injectors.refresh_seconds", 30)
+	v.SetDefault("scripted_injectors.max_steps", 1000000)
+	v.SetDefault("scripted_injectors.max_source_kb", 64)
+	v.SetDefault("scripted_injectors.max_result_kb", 256)
+	v.SetDefault("scripted_injectors.max_alloc_mb", 64)
+	v.SetDefault("scripted_injectors.max_concurrent", 8)
+	v.SetDefault("wasm_plugins.enabled", false)
+	v.SetDefault("wasm_plugins.max_memory_mb", 64)
+	v.SetDefault("wasm_plugins.max_concurrent", 8)
+	v.SetDefault("wasm_plugins.post_process_timeout_seconds", 30)
+	v.SetDefault("wasm_plugins.max_http_response_kb", 1024)
+	v.SetDefault("remote_injectors.enabled", false)
+	v.SetDefault("remote_injectors.max_concurrent", 32)
+	v.SetDefault("remote_injectors.health_check_seconds", 15)
+	v.SetDefault("remote_injectors.max_response_kb", 1024)
+
+	// Render cost defaults
+	v.SetDefault("render_cost.per_render", 1.0)
+	v.SetDefault("render_cost.per_page", 0.1)
+	v.SetDefault("render_cost.per_second", 1.0)
+
+	// Render quota defaults
+	v.SetDefault("render_quotas.enabled", false)
+	v.SetDefault("render_quotas.refresh_seconds", 30)
+	v.SetDefault("render_quotas.replicas", 1)
+	v.SetDefault("render_quotas.tenant.renders_per_minute", 0)
+	v.SetDefault("render_quotas.tenant.pages_per_month", 0)
+	v.SetDefault("render_quotas.workspace.renders_per_minute", 0)
+	v.SetDefault("render_quotas.workspace.pages_per_month", 0)
+
+	// Cleanup defaults
+	v.SetDefault("cleanup.enabled", true)
+	v.SetDefault("cleanup.interval_seconds", 21600)
+	v.SetDefault("cleanup.dry_run", false)
+	v.SetDefault("cleanup.max_per_category", 1000)
+	v.SetDefault("cleanup.preview_token_retention_days", 7)
+	v.SetDefault("cleanup.unused_image_retention_days", 0)
+
+	// Link check defaults
+	v.SetDefault("link_check.enabled", false)
+	v.SetDefault("link_check.allowed_hosts", []string{})
+	v.SetDefault("link_check.timeout_seconds", 5)
+
+	// Event webhook defaults
+	v.SetDefault("event_webhooks.enabled", true)
+	v.SetDefault("event_webhooks.poll_interval_seconds", 2)
+	v.SetDefault("event_webhooks.batch_size", 20)
+	v.SetDefault("event_webhooks.max_attempts", 8)
+	v.SetDefault("event_webhooks.timeout_seconds", 10)
+	v.SetDefault("event_webhooks.retention_hours", 720)
+	v.SetDefault("event_webhooks.allow_private_networks", false)
+
+	// Object storage defaults
+	v.SetDefault("object_storage.provider", "")
+	v.SetDefault("object_storage.key_prefix", "")
+	v.SetDefault("object_storage.signed_url_ttl_seconds", 3600)
+	v.SetDefault("object_storage.assets", false)
+	v.SetDefault("object_storage.local.dir", "./data/objects")
+	v.SetDefault("object_storage.local.signing_key", "")
+	v.SetDefault("object_storage.s3.bucket", "")
+	v.SetDefault("object_storage.s3.region", "us-east-1")
+	v.SetDefault("object_storage.s3.endpoint", "")
+	v.SetDefault("object_storage.s3.access_key_id", "")
+	v.SetDefault("object_storage.s3.secret_access_key", "")
+	v.SetDefault("object_storage.s3.use_path_style", false)
+	v.SetDefault("object_storage.gcs.bucket", "")
+	v.SetDefault("object_storage.gcs.hmac_access_id", "")
+	v.SetDefault("object_storage.gcs.hmac_secret", "")
+
+	// Currency defaults
+	v.SetDefault("currency.provider", "")
+	v.SetDefault("currency.open_exchange_rates_app_id", "")
+	v.SetDefault("currency.rounding", "HALF_UP")
+	v.SetDefault("currency.cache_seconds", 3600)
+	v.SetDefault("currency.timeout_seconds", 10)
+
+	// Assets defaults
+	v.SetDefault("assets.signing_key", "")
+	v.SetDefault("assets.signed_url_ttl_seconds", 3600)
+	v.SetDefault("assets.import_timeout_seconds", 15)
+	v.SetDefault("assets.import_allow_private_networks", false)
+
 	// Environment default
 	v.SetDefault("environment", "development")
 }
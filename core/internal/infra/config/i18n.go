@@ -59,6 +59,11 @@ func LoadInjectorI18nFromFile(filePath string) (*InjectorI18nConfig, error) {
 	return parseI18nData(data)
 }
 
+// ParseInjectorI18n parses injector translations in the format of injectors.i18n.yaml.
+func ParseInjectorI18n(data []byte) (*InjectorI18nConfig, error) {
+	return parseI18nData(data)
+}
+
 // LoadBuiltinInjectorI18n loads the embedded built-in i18n translations.
 // This includes datetime injectors and other built-in injectors.
 func LoadBuiltinInjectorI18n() (*InjectorI18nConfig, error) {
@@ -1,19 +1,40 @@
 package config
 
 import (
+	"fmt"
+	"regexp"
 	"strings"
 	"time"
 )
 
 // Config represents the complete application configuration.
 type Config struct {
-	Environment string          `mapstructure:"environment"`
-	Server      ServerConfig    `mapstructure:"server"`
-	Database    DatabaseConfig  `mapstructure:"database"`
-	Auth        *AuthConfig     `mapstructure:"auth"`
-	Logging     LoggingConfig   `mapstructure:"logging"`
-	Typst       TypstConfig     `mapstructure:"typst"`
-	Bootstrap   BootstrapConfig `mapstructure:"bootstrap"`
+	Environment        string                   `mapstructure:"environment"`
+	Server             ServerConfig             `mapstructure:"server"`
+	Database           DatabaseConfig           `mapstructure:"database"`
+	Auth               *AuthConfig              `mapstructure:"auth"`
+	Logging            LoggingConfig            `mapstructure:"logging"`
+	Typst              TypstConfig              `mapstructure:"typst"`
+	Bootstrap          BootstrapConfig          `mapstructure:"bootstrap"`
+	Outbox             OutboxConfig             `mapstructure:"outbox"`
+	Scheduler          SchedulerConfig          `mapstructure:"scheduler"`
+	DocumentIndex      DocumentIndexConfig      `mapstructure:"document_index"`
+	RenderJobs         RenderJobsConfig         `mapstructure:"render_jobs"`
+	RenderFailures     RenderFailuresConfig     `mapstructure:"render_failures"`
+	TemplateSLAs       TemplateSLAsConfig       `mapstructure:"template_slas"`
+	InjectableCoverage InjectableCoverageConfig `mapstructure:"injectable_coverage"`
+	ScriptedInjectors  ScriptedInjectorsConfig  `mapstructure:"scripted_injectors"`
+	WASMPlugins        WASMPluginsConfig        `mapstructure:"wasm_plugins"`
+	RemoteInjectors    RemoteInjectorsConfig    `mapstructure:"remote_injectors"`
+	RenderCost         RenderCostConfig         `mapstructure:"render_cost"`
+	RenderQuotas       RenderQuotasConfig       `mapstructure:"render_quotas"`
+	Cleanup            CleanupConfig            `mapstructure:"cleanup"`
+	LinkCheck          LinkCheckConfig          `mapstructure:"link_check"`
+	EventWebhooks      EventWebhooksConfig      `mapstructure:"event_webhooks"`
+	ObjectStorage      ObjectStorageConfig      `mapstructure:"object_storage"`
+	Currency           CurrencyConfig           `mapstructure:"currency"`
+	Assets             AssetsConfig             `mapstructure:"assets"`
+	Frontend           FrontendConfig           `mapstructure:"frontend"`
 
 	// DummyAuth is set at runtime when no OIDC providers are configured.
 	// Not loaded from YAML.
@@ -63,13 +84,121 @@ func (c *Config) IsDummyAuth() bool {
 
 // ServerConfig holds HTTP server configuration.
 type ServerConfig struct {
-	Port            string     `mapstructure:"port"`
-	BasePath        string     `mapstructure:"base_path"`
-	ReadTimeout     int        `mapstructure:"read_timeout"`
-	WriteTimeout    int        `mapstructure:"write_timeout"`
-	ShutdownTimeout int        `mapstructure:"shutdown_timeout"`
-	SwaggerUI       bool       `mapstructure:"swagger_ui"`
-	CORS            CORSConfig `mapstructure:"cors"`
+	Port            string           `mapstructure:"port"`
+	BasePath        string           `mapstructure:"base_path"`
+	PublicURL       string           `mapstructure:"public_url"` // Origin used in links returned to clients (e.g. https://docs.example.com)
+	ReadTimeout     int              `mapstructure:"read_timeout"`
+	WriteTimeout    int              `mapstructure:"write_timeout"`
+	ShutdownTimeout int              `mapstructure:"shutdown_timeout"`
+	SwaggerUI       bool             `mapstructure:"swagger_ui"`
+	CORS            CORSConfig       `mapstructure:"cors"`
+	BodyLimits      BodyLimitsConfig `mapstructure:"body_limits"`
+	CapacityToken   string           `mapstructure:"capacity_token"` // Bearer token for the render capacity endpoints; empty leaves them open
+	MetricsToken    string           `mapstructure:"metrics_token"`  // Bearer token for /metrics; empty leaves it open
+	// BatchRenderTimeout is the seconds a batch render may take, in place of write_timeout.
+	BatchRenderTimeout int `mapstructure:"batch_render_timeout"`
+	// RenderAPIV1Deprecation announces the retirement of the v1 render API to its callers.
+	RenderAPIV1Deprecation APIDeprecationConfig `mapstructure:"render_api_v1_deprecation"`
+	TLS                    TLSConfig            `mapstructure:"tls"`
+	HTTP2                  bool                 `mapstructure:"http2"`         // Serve HTTP/2 over TLS
+	H2C                    bool                 `mapstructure:"h2c"`           // Serve unencrypted HTTP/2 (prior knowledge) to proxies that speak it
+	MaxHeaderKB            int                  `mapstructure:"max_header_kb"` // Max size of request headers in KB
+}
+
+// FrontendConfig is injected into the index.html of the embedded frontend, so one build serves
+// every install.
+type FrontendConfig struct {
+	// APIBaseURL is the URL the frontend calls the API at; empty uses <base_path>/api/v1 on the same origin.
+	APIBaseURL string           `mapstructure:"api_base_url"`
+	Branding   FrontendBranding `mapstructure:"branding"`
+}
+
+// FrontendBranding is the look of the install in the frontend.
+type FrontendBranding struct {
+	Title        string `mapstructure:"title"`         // Replaces the page title
+	LogoURL      string `mapstructure:"logo_url"`      // Replaces the logo of the app header
+	FaviconURL   string `mapstructure:"favicon_url"`   // Replaces the favicon
+	PrimaryColor string `mapstructure:"primary_color"` // #RRGGBB; replaces the primary color of the theme
+}
+
+var frontendColorRegex = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// Validate checks that the primary color is a #RRGGBB color.
+func (b FrontendBranding) Validate() error {
+	if b.PrimaryColor != "" && !frontendColorRegex.MatchString(b.PrimaryColor) {
+		return fmt.Errorf("frontend.branding.primary_color: %q is not a #RRGGBB color", b.PrimaryColor)
+	}
+	return nil
+}
+
+// TLSConfig serves HTTPS directly, for installs without a TLS-terminating proxy. Either a
+// certificate and key, or certificates obtained automatically with ACME (e.g. Let's Encrypt).
+type TLSConfig struct {
+	CertFile string     `mapstructure:"cert_file"`
+	KeyFile  string     `mapstructure:"key_file"`
+	ACME     ACMEConfig `mapstructure:"acme"`
+}
+
+// ACMEConfig obtains and renews certificates automatically with the ACME protocol.
+type ACMEConfig struct {
+	Enabled      bool     `mapstructure:"enabled"`
+	Domains      []string `mapstructure:"domains"`       // Hosts certificates are requested for; others are refused
+	Email        string   `mapstructure:"email"`         // Contact for expiry notices; optional
+	CacheDir     string   `mapstructure:"cache_dir"`     // Directory keeping accounts and certificates across restarts
+	DirectoryURL string   `mapstructure:"directory_url"` // ACME directory; empty uses Let's Encrypt production
+	// HTTPPort serves HTTP-01 challenges and redirects HTTP to HTTPS. Empty relies on TLS-ALPN-01
+	// challenges on the HTTPS port.
+	HTTPPort string `mapstructure:"http_port"`
+}
+
+// Enabled returns true if the server serves HTTPS.
+func (t TLSConfig) Enabled() bool {
+	return t.CertFile != "" || t.KeyFile != "" || t.ACME.Enabled
+}
+
+// Validate checks that exactly one certificate source is configured completely.
+func (t TLSConfig) Validate() error {
+	hasFiles := t.CertFile != "" || t.KeyFile != ""
+	switch {
+	case hasFiles && t.ACME.Enabled:
+		return fmt.Errorf("server.tls: cert_file/key_file and acme are mutually exclusive")
+	case hasFiles && (t.CertFile == "" || t.KeyFile == ""):
+		return fmt.Errorf("server.tls: cert_file and key_file must be set together")
+	case t.ACME.Enabled && len(t.ACME.Domains) == 0:
+		return fmt.Errorf("server.tls.acme: domains are required")
+	case t.ACME.Enabled && t.ACME.CacheDir == "":
+		return fmt.Errorf("server.tls.acme: cache_dir is required")
+	}
+	return nil
+}
+
+// APIDeprecationConfig announces the retirement of an API version with the Deprecation and
+// Sunset response headers. Dates are RFC 3339 timestamps or YYYY-MM-DD; empty omits the header.
+type APIDeprecationConfig struct {
+	Since  string `mapstructure:"since"`
+	Sunset string `mapstructure:"sunset"`
+}
+
+// Dates parses the deprecation and sunset dates; a zero time is an unset date.
+func (d APIDeprecationConfig) Dates() (since, sunset time.Time, err error) {
+	if since, err = parseConfigDate(d.Since); err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("since: %w", err)
+	}
+	if sunset, err = parseConfigDate(d.Sunset); err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("sunset: %w", err)
+	}
+	return since, sunset, nil
+}
+
+func parseConfigDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
 }
 
 // NormalizedBasePath returns the base path with leading slash and no trailing slash.
@@ -83,10 +212,49 @@ func (s ServerConfig) NormalizedBasePath() string {
 	return bp
 }
 
+// PublicBaseURL returns the public origin followed by the base path, for links returned to clients.
+// Returns only the base path when no public URL is configured.
+func (s ServerConfig) PublicBaseURL() string {
+	return strings.TrimRight(strings.TrimSpace(s.PublicURL), "/") + s.NormalizedBasePath()
+}
+
 // CORSConfig holds CORS configuration.
 type CORSConfig struct {
-	AllowedOrigins []string `mapstructure:"allowed_origins"`
-	AllowedHeaders []string `mapstructure:"allowed_headers"`
+	AllowedOrigins   []string `mapstructure:"allowed_origins"`
+	AllowedHeaders   []string `mapstructure:"allowed_headers"`   // Appended to the built-in request headers
+	ExposedHeaders   []string `mapstructure:"exposed_headers"`   // Appended to the built-in response headers
+	AllowCredentials bool     `mapstructure:"allow_credentials"` // Let browsers send cookies and auth headers
+	MaxAge           int      `mapstructure:"max_age"`           // Seconds browsers may cache a preflight; 0 omits it
+}
+
+// BodyLimitsConfig bounds request body sizes, in megabytes. Zero disables a limit.
+type BodyLimitsConfig struct {
+	// DefaultMB applies to every API route without a more specific limit.
+	DefaultMB int `mapstructure:"default_mb"`
+	// RenderMB applies to render, render job and preview routes, which carry injectable payloads.
+	RenderMB int `mapstructure:"render_mb"`
+	// Routes overrides the limit per route pattern (e.g. "/api/v1/workspace/document-types/:code/render").
+	Routes map[string]int `mapstructure:"routes"`
+}
+
+// DefaultBytes returns the default body limit in bytes.
+func (b BodyLimitsConfig) DefaultBytes() int64 {
+	return int64(b.DefaultMB) << 20
+}
+
+// RenderBytes returns the render body limit in bytes.
+func (b BodyLimitsConfig) RenderBytes() int64 {
+	return int64(b.RenderMB) << 20
+}
+
+// RouteBytes returns the per-route limits in bytes, keyed by lower-cased route pattern
+// (config keys are case-insensitive).
+func (b BodyLimitsConfig) RouteBytes() map[string]int64 {
+	routes := make(map[string]int64, len(b.Routes))
+	for route, mb := range b.Routes {
+		routes[strings.ToLower(route)] = int64(mb) << 20
+	}
+	return routes
 }
 
 // ReadTimeoutDuration returns the read timeout as time.Duration.
@@ -99,6 +267,16 @@ func (s ServerConfig) WriteTimeoutDuration() time.Duration {
 	return time.Duration(s.WriteTimeout) * time.Second
 }
 
+// BatchRenderTimeoutDuration returns the batch render timeout as time.Duration.
+func (s ServerConfig) BatchRenderTimeoutDuration() time.Duration {
+	return time.Duration(s.BatchRenderTimeout) * time.Second
+}
+
+// MaxHeaderBytes returns the max size of request headers in bytes.
+func (s ServerConfig) MaxHeaderBytes() int {
+	return s.MaxHeaderKB << 10
+}
+
 // ShutdownTimeoutDuration returns the shutdown timeout as time.Duration.
 func (s ServerConfig) ShutdownTimeoutDuration() time.Duration {
 	return time.Duration(s.ShutdownTimeout) * time.Second
@@ -115,6 +293,25 @@ type DatabaseConfig struct {
 	MaxPoolSize        int    `mapstructure:"max_pool_size"`
 	MinPoolSize        int    `mapstructure:"min_pool_size"`
 	MaxIdleTimeSeconds int    `mapstructure:"max_idle_time_seconds"`
+
+	// MaxConnLifetimeSeconds recycles connections after this age, so failovers and
+	// server-side settings changes are picked up.
+	MaxConnLifetimeSeconds int `mapstructure:"max_conn_lifetime_seconds"`
+	// HealthCheckPeriodSeconds is how often idle connections are checked and replaced.
+	HealthCheckPeriodSeconds int `mapstructure:"health_check_period_seconds"`
+	// StatementTimeoutSeconds is the server-side statement_timeout of pool connections (0 = server default).
+	StatementTimeoutSeconds int `mapstructure:"statement_timeout_seconds"`
+	// QueryTimeoutSeconds caps how long a repository call may run when its context
+	// has no sooner deadline (0 = only the context applies).
+	QueryTimeoutSeconds int `mapstructure:"query_timeout_seconds"`
+	// AcquireTimeoutSeconds caps how long a call waits for a free connection (0 = only the context applies).
+	AcquireTimeoutSeconds int `mapstructure:"acquire_timeout_seconds"`
+
+	// MigrationLockTimeoutSeconds bounds how long a migration waits for a table lock
+	// before giving up, so it never queues application queries behind it.
+	MigrationLockTimeoutSeconds int `mapstructure:"migration_lock_timeout_seconds"`
+	// MigrationMaxRetries is how many times a migration that timed out on a lock is retried.
+	MigrationMaxRetries int `mapstructure:"migration_max_retries"`
 }
 
 // MaxIdleTimeDuration returns the max idle time as time.Duration.
@@ -122,6 +319,36 @@ func (d DatabaseConfig) MaxIdleTimeDuration() time.Duration {
 	return time.Duration(d.MaxIdleTimeSeconds) * time.Second
 }
 
+// MaxConnLifetimeDuration returns the max connection lifetime as time.Duration.
+func (d DatabaseConfig) MaxConnLifetimeDuration() time.Duration {
+	return time.Duration(d.MaxConnLifetimeSeconds) * time.Second
+}
+
+// HealthCheckPeriodDuration returns the health check period as time.Duration.
+func (d DatabaseConfig) HealthCheckPeriodDuration() time.Duration {
+	return time.Duration(d.HealthCheckPeriodSeconds) * time.Second
+}
+
+// StatementTimeoutDuration returns the statement timeout as time.Duration.
+func (d DatabaseConfig) StatementTimeoutDuration() time.Duration {
+	return time.Duration(d.StatementTimeoutSeconds) * time.Second
+}
+
+// QueryTimeoutDuration returns the query timeout as time.Duration.
+func (d DatabaseConfig) QueryTimeoutDuration() time.Duration {
+	return time.Duration(d.QueryTimeoutSeconds) * time.Second
+}
+
+// AcquireTimeoutDuration returns the connection acquire timeout as time.Duration.
+func (d DatabaseConfig) AcquireTimeoutDuration() time.Duration {
+	return time.Duration(d.AcquireTimeoutSeconds) * time.Second
+}
+
+// MigrationLockTimeout returns the migration lock timeout as time.Duration.
+func (d DatabaseConfig) MigrationLockTimeout() time.Duration {
+	return time.Duration(d.MigrationLockTimeoutSeconds) * time.Second
+}
+
 // OIDCProvider represents a single OIDC identity provider configuration.
 type OIDCProvider struct {
 	Name         string `mapstructure:"name"`          // Human-readable name for logging
@@ -139,8 +366,18 @@ type OIDCProvider struct {
 
 // LoggingConfig holds logging configuration.
 type LoggingConfig struct {
-	Level  string `mapstructure:"level"`
-	Format string `mapstructure:"format"`
+	Level    string            `mapstructure:"level"`
+	Format   string            `mapstructure:"format"`
+	Modules  map[string]string `mapstructure:"modules"` // Level per module (http, renderer, injectors, scheduler, repos, app); others use Level
+	Sampling LogSamplingConfig `mapstructure:"sampling"`
+}
+
+// LogSamplingConfig thins out repeated debug records: per module and message, the first First
+// records of each interval are logged, then one in every Thereafter.
+type LogSamplingConfig struct {
+	First           int `mapstructure:"first"`      // 0 disables sampling
+	Thereafter      int `mapstructure:"thereafter"` // 0 drops every record past First
+	IntervalSeconds int `mapstructure:"interval_seconds"`
 }
 
 // TypstConfig holds Typst renderer configuration.
@@ -155,6 +392,20 @@ type TypstConfig struct {
 	ImageCacheDir            string   `mapstructure:"image_cache_dir"`
 	ImageCacheMaxAgeSeconds  int      `mapstructure:"image_cache_max_age_seconds"`
 	ImageCacheCleanupSeconds int      `mapstructure:"image_cache_cleanup_interval_seconds"`
+	ImageMaxSizeMB           int      `mapstructure:"image_max_size_mb"`       // Largest remote image or external PDF downloaded
+	ImageFetchConcurrency    int      `mapstructure:"image_fetch_concurrency"` // Downloads at once per render
+	WorkspaceFontDir         string   `mapstructure:"workspace_font_dir"`      // Where workspace fonts are written for renders; empty = temp dir
+
+	// FontFallbacks are the fonts used for scripts the base fonts do not cover, such as CJK or emoji.
+	// Empty uses the fallbacks for the Noto fonts of the Docker image. YAML only.
+	FontFallbacks []FontFallbackConfig `mapstructure:"font_fallbacks"`
+}
+
+// FontFallbackConfig is the font chain of a script, optionally for documents in one language.
+type FontFallbackConfig struct {
+	Script   string   `mapstructure:"script"`   // Unicode script name (Han, Hangul, Arabic, ...) or Emoji
+	Language string   `mapstructure:"language"` // Optional; preferred over the script's fallback without one
+	Fonts    []string `mapstructure:"fonts"`
 }
 
 // TimeoutDuration returns the timeout as time.Duration.
@@ -167,6 +418,11 @@ func (t TypstConfig) AcquireTimeoutDuration() time.Duration {
 	return time.Duration(t.AcquireTimeoutSeconds) * time.Second
 }
 
+// ImageMaxBytes returns the remote image size limit in bytes.
+func (t TypstConfig) ImageMaxBytes() int64 {
+	return int64(t.ImageMaxSizeMB) << 20
+}
+
 // BootstrapConfig holds first-user bootstrap configuration.
 type BootstrapConfig struct {
 	// Enabled controls whether the first user to login is auto-created as SUPERADMIN.
@@ -174,3 +430,475 @@ type BootstrapConfig struct {
 	// Default: true
 	Enabled bool `mapstructure:"enabled"`
 }
+
+// OutboxConfig holds the outbox relay configuration.
+type OutboxConfig struct {
+	// PollIntervalSeconds is how often the relay looks for pending events.
+	PollIntervalSeconds int `mapstructure:"poll_interval_seconds"`
+	// BatchSize is the maximum number of events delivered per poll.
+	BatchSize int `mapstructure:"batch_size"`
+	// MaxAttempts is the number of deliveries tried before an event is marked failed.
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// RetentionHours is how long delivered events are kept. 0 keeps them forever.
+	RetentionHours int `mapstructure:"retention_hours"`
+}
+
+// PollInterval returns the poll interval as a time.Duration.
+func (o OutboxConfig) PollInterval() time.Duration {
+	return time.Duration(o.PollIntervalSeconds) * time.Second
+}
+
+// Retention returns the retention window as a time.Duration.
+func (o OutboxConfig) Retention() time.Duration {
+	return time.Duration(o.RetentionHours) * time.Hour
+}
+
+// SchedulerConfig holds the scheduled publication/archival runner configuration.
+type SchedulerConfig struct {
+	// Enabled runs the scheduler in this instance. Enable it on a single instance
+	// when running several replicas.
+	// Default: true
+	Enabled bool `mapstructure:"enabled"`
+	// PollIntervalSeconds is how often due operations are processed.
+	PollIntervalSeconds int `mapstructure:"poll_interval_seconds"`
+	// MaxAttempts is how many times a failing scheduled operation is run before the
+	// version moves to the failed-scheduled state. Content validation failures move it
+	// there on the first attempt.
+	MaxAttempts int `mapstructure:"max_attempts"`
+}
+
+// PollInterval returns the poll interval as a time.Duration.
+func (s SchedulerConfig) PollInterval() time.Duration {
+	return time.Duration(s.PollIntervalSeconds) * time.Second
+}
+
+// DocumentIndexConfig holds the hosted document indexer configuration.
+// The indexer generates thumbnails and extracted text used for browsing and search.
+type DocumentIndexConfig struct {
+	// Enabled runs the indexer in this instance. Several instances can run it at once.
+	// Default: true
+	Enabled bool `mapstructure:"enabled"`
+	// PollIntervalSeconds is how often documents waiting for indexing are processed.
+	PollIntervalSeconds int `mapstructure:"poll_interval_seconds"`
+	// BatchSize is the maximum number of documents indexed per poll.
+	BatchSize int `mapstructure:"batch_size"`
+	// MaxAttempts is the number of attempts before indexing a document is given up.
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// ThumbnailWidth is the width of first-page thumbnails in pixels.
+	ThumbnailWidth int `mapstructure:"thumbnail_width"`
+	// PdftotextPath is the pdftotext binary used for text extraction. Empty disables it,
+	// leaving search to filenames.
+	PdftotextPath string `mapstructure:"pdftotext_path"`
+}
+
+// PollInterval returns the poll interval as a time.Duration.
+func (d DocumentIndexConfig) PollInterval() time.Duration {
+	return time.Duration(d.PollIntervalSeconds) * time.Second
+}
+
+// RenderJobsConfig holds the asynchronous render job runner configuration.
+type RenderJobsConfig struct {
+	// Enabled runs queued render jobs in this instance. Several instances can run them at once.
+	// Jobs can be submitted from every instance.
+	// Default: true
+	Enabled bool `mapstructure:"enabled"`
+	// PollIntervalSeconds is how often queued jobs are claimed.
+	PollIntervalSeconds int `mapstructure:"poll_interval_seconds"`
+	// LeaseSeconds bounds a single render of a job. A job whose instance stopped while rendering
+	// is claimed again once its lease ends.
+	LeaseSeconds int `mapstructure:"lease_seconds"`
+	// Concurrency is the number of jobs rendered at once by this instance. They share the
+	// renderer slots (typst.max_concurrent) with request renders.
+	Concurrency int `mapstructure:"concurrency"`
+	// MaxAttempts is the number of claims before a job is failed.
+	MaxAttempts int `mapstructure:"max_attempts"`
+}
+
+// PollInterval returns the poll interval as a time.Duration.
+func (r RenderJobsConfig) PollInterval() time.Duration {
+	return time.Duration(r.PollIntervalSeconds) * time.Second
+}
+
+// Lease returns the lease as a time.Duration.
+func (r RenderJobsConfig) Lease() time.Duration {
+	return time.Duration(r.LeaseSeconds) * time.Second
+}
+
+// RenderFailuresConfig holds the capture of the Typst input of failed compiles.
+type RenderFailuresConfig struct {
+	// Enabled keeps the generated source, asset manifest and compiler output of failed compiles.
+	// Default: true
+	Enabled bool `mapstructure:"enabled"`
+	// RetentionHours is how long a capture is kept.
+	RetentionHours int `mapstructure:"retention_hours"`
+	// MaxKept bounds the captures kept; the oldest are deleted first. 0 keeps any number.
+	MaxKept int `mapstructure:"max_kept"`
+	// MaxSourceKB truncates larger sources. 0 keeps whole sources.
+	MaxSourceKB int `mapstructure:"max_source_kb"`
+}
+
+// Retention returns the capture retention as a time.Duration.
+func (r RenderFailuresConfig) Retention() time.Duration {
+	return time.Duration(r.RetentionHours) * time.Hour
+}
+
+// TemplateSLAsConfig holds the checking of renders against template SLAs.
+type TemplateSLAsConfig struct {
+	// Enabled counts the renders of templates with an SLA in this instance and checks the SLAs.
+	// Several instances can check them at once; each breach is alerted once.
+	// Default: true
+	Enabled bool `mapstructure:"enabled"`
+	// IntervalSeconds is how often render counts are flushed and SLAs are checked.
+	IntervalSeconds int `mapstructure:"interval_seconds"`
+}
+
+// Interval returns the check interval as a time.Duration.
+func (t TemplateSLAsConfig) Interval() time.Duration {
+	return time.Duration(t.IntervalSeconds) * time.Second
+}
+
+// InjectableCoverageConfig holds the recording of where injectable values come from in renders.
+type InjectableCoverageConfig struct {
+	// Enabled counts the injectable sources of the renders of this instance.
+	// Default: true
+	Enabled bool `mapstructure:"enabled"`
+	// IntervalSeconds is how often counts are flushed to the database.
+	IntervalSeconds int `mapstructure:"interval_seconds"`
+	// RetentionDays is how many days of counts are kept, and the longest window reported.
+	RetentionDays int `mapstructure:"retention_days"`
+}
+
+// Interval returns the flush interval as a time.Duration.
+func (i InjectableCoverageConfig) Interval() time.Duration {
+	return time.Duration(i.IntervalSeconds) * time.Second
+}
+
+// ScriptedInjectorsConfig holds the sandbox of the Starlark injectors authored by system admins.
+// Scripts cannot reach files, the network or the clock; what they allocate is bounded by the
+// allocation budget.
+type ScriptedInjectorsConfig struct {
+	// Enabled registers and runs scripted injectors in this instance.
+	// Default: true
+	Enabled bool `mapstructure:"enabled"`
+	// RefreshSeconds is how often scripts changed by other instances are reloaded.
+	RefreshSeconds int `mapstructure:"refresh_seconds"`
+	// MaxSteps is the execution steps a run can take, its CPU budget.
+	MaxSteps uint64 `mapstructure:"max_steps"`
+	// MaxSourceKB is the size limit of a script.
+	MaxSourceKB int `mapstructure:"max_source_kb"`
+	// MaxResultKB is the size limit of the value a run returns, encoded as JSON.
+	MaxResultKB int `mapstructure:"max_result_kb"`
+	// MaxAllocMB is the strings, bytes and lists a run can build, its memory budget.
+	MaxAllocMB int64 `mapstructure:"max_alloc_mb"`
+	// MaxConcurrent is how many scripts run at once in this instance.
+	MaxConcurrent int `mapstructure:"max_concurrent"`
+}
+
+// RefreshInterval returns the reload interval as a time.Duration.
+func (s ScriptedInjectorsConfig) RefreshInterval() time.Duration {
+	return time.Duration(s.RefreshSeconds) * time.Second
+}
+
+// WASMPluginsConfig holds the WASM plugins loaded at startup and the limits of their sandbox.
+type WASMPluginsConfig struct {
+	// Enabled loads the plugins of Plugins.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+	// MaxMemoryMB is the linear memory of a plugin instance.
+	MaxMemoryMB int `mapstructure:"max_memory_mb"`
+	// MaxConcurrent is how many plugin calls run at once in this instance.
+	MaxConcurrent int `mapstructure:"max_concurrent"`
+	// PostProcessTimeoutSeconds is how long a post-processor may take per PDF.
+	PostProcessTimeoutSeconds int `mapstructure:"post_process_timeout_seconds"`
+	// MaxHTTPResponseKB is the size limit of a response of http_fetch.
+	MaxHTTPResponseKB int `mapstructure:"max_http_response_kb"`
+	// Plugins are the plugins to load, in order. YAML only.
+	Plugins []WASMPluginConfig `mapstructure:"plugins"`
+}
+
+// WASMPluginConfig is a plugin module and what it is granted.
+type WASMPluginConfig struct {
+	Name         string   `mapstructure:"name"`          // Prefix of its injector codes
+	Path         string   `mapstructure:"path"`          // .wasm file
+	Capabilities []string `mapstructure:"capabilities"`  // log, http, clock, random, request
+	AllowedHosts []string `mapstructure:"allowed_hosts"` // Hosts http_fetch may reach
+}
+
+// PostProcessTimeout returns the post-processor timeout as a time.Duration.
+func (w WASMPluginsConfig) PostProcessTimeout() time.Duration {
+	return time.Duration(w.PostProcessTimeoutSeconds) * time.Second
+}
+
+// RemoteInjectorsConfig holds the remote injector services whose injectors are registered at
+// startup.
+type RemoteInjectorsConfig struct {
+	// Enabled registers the injectors of Remotes.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+	// MaxConcurrent is how many remote calls run at once in this instance.
+	MaxConcurrent int `mapstructure:"max_concurrent"`
+	// HealthCheckSeconds is how often the remotes are checked, and unreachable manifests retried.
+	HealthCheckSeconds int `mapstructure:"health_check_seconds"`
+	// MaxResponseKB is the size limit of a response of a remote.
+	MaxResponseKB int `mapstructure:"max_response_kb"`
+	// Remotes are the services to call. YAML only.
+	Remotes []RemoteInjectorConfig `mapstructure:"remotes"`
+}
+
+// RemoteInjectorConfig is a remote injector service and how to reach it.
+type RemoteInjectorConfig struct {
+	Name           string `mapstructure:"name"`            // Prefix of its injector codes
+	Protocol       string `mapstructure:"protocol"`        // http or grpc
+	URL            string `mapstructure:"url"`             // Base URL (http) or host:port (grpc)
+	TimeoutMs      int    `mapstructure:"timeout_ms"`      // Default and cap of its injector timeouts
+	AuthHeader     string `mapstructure:"auth_header"`     // Header carrying the token
+	AuthTokenEnv   string `mapstructure:"auth_token_env"`  // Environment variable holding the token
+	ForwardRequest bool   `mapstructure:"forward_request"` // Send the request payload and headers
+	Insecure       bool   `mapstructure:"insecure"`        // gRPC without TLS
+}
+
+// HealthInterval returns the health check interval as a time.Duration.
+func (r RemoteInjectorsConfig) HealthInterval() time.Duration {
+	return time.Duration(r.HealthCheckSeconds) * time.Second
+}
+
+// RenderCostConfig prices renders in metered units for render estimates:
+// per_render + per_page × pages + per_second × compile seconds.
+type RenderCostConfig struct {
+	PerRender float64 `mapstructure:"per_render"`
+	PerPage   float64 `mapstructure:"per_page"`
+	PerSecond float64 `mapstructure:"per_second"`
+}
+
+// RenderQuotasConfig holds the default render limits of tenants and workspaces. System admins
+// override them per tenant or workspace at /api/v1/system/tenants/{tenantId}/quota.
+type RenderQuotasConfig struct {
+	// Enabled rejects renders over a quota with 429 Too Many Requests.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+	// RefreshSeconds is how often usage is shared with other instances and quotas reloaded.
+	RefreshSeconds int `mapstructure:"refresh_seconds"`
+	// Replicas is the number of instances serving renders. Each instance allows renders per
+	// minute up to its share of the limit, the limit divided by Replicas and rounded up.
+	// Default: 1
+	Replicas int `mapstructure:"replicas"`
+	// Tenant are the limits of each tenant without a quota, across its workspaces.
+	Tenant RenderQuotaLimitsConfig `mapstructure:"tenant"`
+	// Workspace are the limits of each workspace without a quota.
+	Workspace RenderQuotaLimitsConfig `mapstructure:"workspace"`
+}
+
+// RenderQuotaLimitsConfig holds render limits. Zero means unlimited.
+type RenderQuotaLimitsConfig struct {
+	// RendersPerMinute is split between the instances, see RenderQuotasConfig.Replicas.
+	RendersPerMinute int `mapstructure:"renders_per_minute"`
+	// PagesPerMonth counts the pages of the PDF renders of the calendar month (UTC).
+	PagesPerMonth int64 `mapstructure:"pages_per_month"`
+}
+
+// RefreshInterval returns the refresh interval as a time.Duration.
+func (r RenderQuotasConfig) RefreshInterval() time.Duration {
+	return time.Duration(r.RefreshSeconds) * time.Second
+}
+
+// CleanupConfig holds the orphaned data cleanup job configuration.
+type CleanupConfig struct {
+	// Enabled runs the cleanup job in this instance. Several instances can run it at once,
+	// but each records its own runs.
+	// Default: true
+	Enabled bool `mapstructure:"enabled"`
+	// IntervalSeconds is how often the cleanup runs.
+	IntervalSeconds int `mapstructure:"interval_seconds"`
+	// DryRun makes scheduled runs report orphans without deleting them.
+	DryRun bool `mapstructure:"dry_run"`
+	// MaxPerCategory bounds the orphans found and deleted per category in a run.
+	MaxPerCategory int `mapstructure:"max_per_category"`
+	// PreviewTokenRetentionDays is how long preview tokens are kept after they expire or are revoked.
+	PreviewTokenRetentionDays int `mapstructure:"preview_token_retention_days"`
+	// UnusedImageRetentionDays is how long an image asset no template references is kept after
+	// its last change. 0 reports unused images without deleting them.
+	UnusedImageRetentionDays int `mapstructure:"unused_image_retention_days"`
+}
+
+// Interval returns the cleanup interval as a time.Duration.
+func (c CleanupConfig) Interval() time.Duration {
+	return time.Duration(c.IntervalSeconds) * time.Second
+}
+
+// PreviewTokenRetention returns the preview token retention as a time.Duration.
+func (c CleanupConfig) PreviewTokenRetention() time.Duration {
+	return time.Duration(c.PreviewTokenRetentionDays) * 24 * time.Hour
+}
+
+// UnusedImageRetention returns the unused image retention as a time.Duration.
+func (c CleanupConfig) UnusedImageRetention() time.Duration {
+	return time.Duration(c.UnusedImageRetentionDays) * 24 * time.Hour
+}
+
+// LinkCheckConfig holds the publish-time checker of the external links of a document.
+// Dead links are reported as validation warnings and never block publishing.
+type LinkCheckConfig struct {
+	// Enabled checks links when a version is published.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+	// AllowedHosts are the hosts whose links are requested. A host also allows its subdomains.
+	// Links to other hosts are not checked, so an empty list checks none.
+	AllowedHosts []string `mapstructure:"allowed_hosts"`
+	// TimeoutSeconds bounds the request made for each link.
+	TimeoutSeconds int `mapstructure:"timeout_seconds"`
+}
+
+// Timeout returns the request timeout as a time.Duration.
+func (l LinkCheckConfig) Timeout() time.Duration {
+	return time.Duration(l.TimeoutSeconds) * time.Second
+}
+
+// EventWebhooksConfig holds the dispatcher of workspace event webhooks.
+// Events are queued on every instance; the dispatcher posts them.
+type EventWebhooksConfig struct {
+	// Enabled runs the dispatcher in this instance. Several instances can run it at once.
+	// Default: true
+	Enabled bool `mapstructure:"enabled"`
+	// PollIntervalSeconds is how often due deliveries are claimed.
+	PollIntervalSeconds int `mapstructure:"poll_interval_seconds"`
+	// BatchSize is the maximum number of deliveries posted concurrently per poll.
+	BatchSize int `mapstructure:"batch_size"`
+	// MaxAttempts is the number of posts tried before a delivery is marked failed.
+	// Retries back off from 5 seconds, doubling up to one hour.
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// TimeoutSeconds bounds each post to an integrator endpoint.
+	TimeoutSeconds int `mapstructure:"timeout_seconds"`
+	// RetentionHours is how long finished deliveries stay in the delivery log. 0 keeps them forever.
+	RetentionHours int `mapstructure:"retention_hours"`
+	// AllowPrivateNetworks lets webhooks post to loopback and private addresses.
+	// Keep it off in shared deployments so workspace admins cannot reach internal services.
+	// Default: false
+	AllowPrivateNetworks bool `mapstructure:"allow_private_networks"`
+}
+
+// PollInterval returns the poll interval as a time.Duration.
+func (w EventWebhooksConfig) PollInterval() time.Duration {
+	return time.Duration(w.PollIntervalSeconds) * time.Second
+}
+
+// Timeout returns the post timeout as a time.Duration.
+func (w EventWebhooksConfig) Timeout() time.Duration {
+	return time.Duration(w.TimeoutSeconds) * time.Second
+}
+
+// Retention returns the delivery log retention as a time.Duration.
+func (w EventWebhooksConfig) Retention() time.Duration {
+	return time.Duration(w.RetentionHours) * time.Hour
+}
+
+// ObjectStorageConfig holds the object storage that render endpoints persist PDFs to when asked,
+// and that can hold the content of uploaded assets.
+// A storage registered with engine.SetObjectStorage takes precedence over this configuration.
+type ObjectStorageConfig struct {
+	// Provider selects the adapter: "local", "s3" or "gcs". Empty disables persisted renders.
+	// Default: ""
+	Provider string `mapstructure:"provider"`
+	// KeyPrefix is prepended to every object key, e.g. "pdf-forge".
+	KeyPrefix string `mapstructure:"key_prefix"`
+	// SignedURLTTLSeconds is how long the URL returned for a persisted render works.
+	// S3 and GCS cap it at seven days.
+	SignedURLTTLSeconds int `mapstructure:"signed_url_ttl_seconds"`
+	// Assets keeps the content of new asset library versions in object storage instead of the
+	// database. Backups then no longer include it.
+	// Default: false
+	Assets bool `mapstructure:"assets"`
+
+	Local LocalObjectStorageConfig `mapstructure:"local"`
+	S3    S3ObjectStorageConfig    `mapstructure:"s3"`
+	GCS   GCSObjectStorageConfig   `mapstructure:"gcs"`
+}
+
+// SignedURLTTL returns the signed URL lifetime as a time.Duration.
+func (o ObjectStorageConfig) SignedURLTTL() time.Duration {
+	return time.Duration(o.SignedURLTTLSeconds) * time.Second
+}
+
+// LocalObjectStorageConfig stores objects on the local disk. Signed URLs point at the engine,
+// under server.public_url, which serves them at /api/v1/public/objects/{key}.
+type LocalObjectStorageConfig struct {
+	Dir string `mapstructure:"dir"`
+	// SigningKey signs the URLs. Set the same key on every instance.
+	SigningKey string `mapstructure:"signing_key"`
+}
+
+// S3ObjectStorageConfig stores objects in an Amazon S3 bucket or an S3-compatible service.
+type S3ObjectStorageConfig struct {
+	Bucket string `mapstructure:"bucket"`
+	Region string `mapstructure:"region"`
+	// Endpoint overrides the AWS endpoint for S3-compatible services, e.g. http://minio:9000.
+	Endpoint        string `mapstructure:"endpoint"`
+	AccessKeyID     string `mapstructure:"access_key_id"`
+	SecretAccessKey string `mapstructure:"secret_access_key"`
+	// UsePathStyle addresses the bucket in the path instead of the host; most S3-compatible services need it.
+	UsePathStyle bool `mapstructure:"use_path_style"`
+}
+
+// GCSObjectStorageConfig stores objects in a Google Cloud Storage bucket, authenticated with HMAC keys.
+type GCSObjectStorageConfig struct {
+	Bucket       string `mapstructure:"bucket"`
+	HMACAccessID string `mapstructure:"hmac_access_id"`
+	HMACSecret   string `mapstructure:"hmac_secret"`
+}
+
+// CurrencyConfig holds the exchange rates injectors and table formulas convert amounts with.
+// A provider registered with engine.SetCurrencyRateProvider takes precedence over Provider.
+type CurrencyConfig struct {
+	// Provider selects the rate source: "ecb" or "openexchangerates". Empty disables currency conversion.
+	// Default: ""
+	Provider string `mapstructure:"provider"`
+	// OpenExchangeRatesAppID is the App ID of the openexchangerates provider.
+	OpenExchangeRatesAppID string `mapstructure:"open_exchange_rates_app_id"`
+	// Rounding is how converted amounts are rounded to the minor units of their currency:
+	// HALF_UP, HALF_EVEN, DOWN or UP.
+	// Default: HALF_UP
+	Rounding string `mapstructure:"rounding"`
+	// CacheSeconds is how long the latest rates are kept before they are fetched again.
+	// Rates of past dates are kept until restart.
+	// Default: 3600
+	CacheSeconds int `mapstructure:"cache_seconds"`
+	// TimeoutSeconds is the timeout of each rate request.
+	// Default: 10
+	TimeoutSeconds int `mapstructure:"timeout_seconds"`
+}
+
+// CacheTTL returns the lifetime of the latest rates as a time.Duration.
+func (c CurrencyConfig) CacheTTL() time.Duration {
+	return time.Duration(c.CacheSeconds) * time.Second
+}
+
+// Timeout returns the rate request timeout as a time.Duration.
+func (c CurrencyConfig) Timeout() time.Duration {
+	return time.Duration(c.TimeoutSeconds) * time.Second
+}
+
+// AssetsConfig holds the settings of the workspace asset library that are not per workspace.
+type AssetsConfig struct {
+	// SigningKey signs the public asset URLs returned by the signed-url endpoint. Empty disables
+	// them. Use the same key on every instance.
+	// Default: ""
+	SigningKey string `mapstructure:"signing_key"`
+	// SignedURLTTLSeconds is how long a signed asset URL works.
+	// Default: 3600
+	SignedURLTTLSeconds int `mapstructure:"signed_url_ttl_seconds"`
+	// ImportTimeoutSeconds is the timeout of each download of an imported remote image.
+	// Default: 15
+	ImportTimeoutSeconds int `mapstructure:"import_timeout_seconds"`
+	// ImportAllowPrivateNetworks lets imports download from loopback and private addresses.
+	// Default: false
+	ImportAllowPrivateNetworks bool `mapstructure:"import_allow_private_networks"`
+}
+
+// SignedURLTTL returns the lifetime of signed asset URLs as a time.Duration.
+func (c AssetsConfig) SignedURLTTL() time.Duration {
+	return time.Duration(c.SignedURLTTLSeconds) * time.Second
+}
+
+// ImportTimeout returns the remote image download timeout as a time.Duration.
+func (c AssetsConfig) ImportTimeout() time.Duration {
+	return time.Duration(c.ImportTimeoutSeconds) * time.Second
+}
@@ -0,0 +1,152 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// moduleLevelHandler filters records by the level of the module that logged them and samples
+// debug records, so a noisy module can be turned up without flooding the output.
+type moduleLevelHandler struct {
+	slog.Handler
+	levels   map[string]slog.Level
+	fallback slog.Level
+	minLevel slog.Level
+	sampler  *sampler
+	module   string // Set by a "module" attribute added with WithAttrs
+}
+
+// newModuleLevelHandler wraps h with per-module levels. Modules missing from levels log at
+// fallback. A nil sampler keeps every debug record.
+func newModuleLevelHandler(h slog.Handler, fallback slog.Level, levels map[string]slog.Level, s *sampler) *moduleLevelHandler {
+	minLevel := fallback
+	for _, level := range levels {
+		minLevel = min(minLevel, level)
+	}
+	return &moduleLevelHandler{Handler: h, levels: levels, fallback: fallback, minLevel: minLevel, sampler: s}
+}
+
+// Enabled reports whether any module logs at level; Handle applies the level of the record's module.
+func (h *moduleLevelHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.minLevel
+}
+
+// Handle drops records below the level of their module and debug records over the sampling rate.
+func (h *moduleLevelHandler) Handle(ctx context.Context, r slog.Record) error {
+	module := h.module
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key == moduleAttrKey {
+			module = a.Value.String()
+			return false
+		}
+		return true
+	})
+	if module == "" {
+		module = moduleOf(r.PC)
+	}
+
+	level, ok := h.levels[module]
+	if !ok {
+		level = h.fallback
+	}
+	if r.Level < level {
+		return nil
+	}
+	if r.Level < slog.LevelInfo && !h.sampler.allow(module, r.Message, r.Time) {
+		return nil
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a handler with the given attributes, keeping the levels and sampling state.
+func (h *moduleLevelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	for _, a := range attrs {
+		if a.Key == moduleAttrKey {
+			clone.module = a.Value.String()
+		}
+	}
+	clone.Handler = h.Handler.WithAttrs(attrs)
+	return &clone
+}
+
+// WithGroup returns a handler with the given group, keeping the levels and sampling state.
+func (h *moduleLevelHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.Handler = h.Handler.WithGroup(name)
+	return &clone
+}
+
+// moduleAttrKey is the attribute that assigns a record to a module explicitly.
+const moduleAttrKey = "module"
+
+// sampler keeps the first records of each module and message within an interval, then one in
+// every thereafter, like zap's sampling.
+type sampler struct {
+	first      int
+	thereafter int
+	interval   time.Duration
+
+	mu     sync.Mutex
+	counts map[sampleKey]*sampleCount
+}
+
+type sampleKey struct {
+	module  string
+	message string
+}
+
+type sampleCount struct {
+	start time.Time
+	n     int
+}
+
+// samplerMaxKeys bounds the tracked messages; counts reset when it is reached.
+const samplerMaxKeys = 4096
+
+// newSampler creates a sampler, or returns nil, which keeps every record, when first is not positive.
+func newSampler(first, thereafter int, interval time.Duration) *sampler {
+	if first <= 0 {
+		return nil
+	}
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return &sampler{
+		first:      first,
+		thereafter: thereafter,
+		interval:   interval,
+		counts:     make(map[sampleKey]*sampleCount),
+	}
+}
+
+// allow reports whether a record should be logged.
+func (s *sampler) allow(module, message string, at time.Time) bool {
+	if s == nil {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := sampleKey{module: module, message: message}
+	count, ok := s.counts[key]
+	if !ok {
+		if len(s.counts) >= samplerMaxKeys {
+			clear(s.counts)
+		}
+		count = &sampleCount{start: at}
+		s.counts[key] = count
+	}
+	if at.Sub(count.start) >= s.interval {
+		count.start, count.n = at, 0
+	}
+	count.n++
+
+	if count.n <= s.first {
+		return true
+	}
+	return s.thereafter > 0 && (count.n-s.first)%s.thereafter == 0
+}
@@ -0,0 +1,81 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rendis/pdf-forge/core/internal/infra/config"
+)
+
+func TestNewHandler_AppliesModuleLevels(t *testing.T) {
+	var buf bytes.Buffer
+	handler, err := NewHandler(&buf, config.LoggingConfig{
+		Level:   "info",
+		Format:  "text",
+		Modules: map[string]string{ModuleRenderer: "debug", ModuleRepos: "error"},
+	})
+	require.NoError(t, err)
+	logger := slog.New(handler)
+	ctx := context.Background()
+
+	logger.DebugContext(ctx, "app debug")
+	logger.DebugContext(ctx, "renderer debug", "module", ModuleRenderer)
+	logger.With("module", ModuleRepos).WarnContext(ctx, "repos warn")
+	logger.InfoContext(ctx, "app info")
+
+	out := buf.String()
+	assert.NotContains(t, out, "app debug")
+	assert.Contains(t, out, "renderer debug")
+	assert.NotContains(t, out, "repos warn")
+	assert.Contains(t, out, "app info")
+}
+
+func TestNewHandler_RejectsInvalidConfig(t *testing.T) {
+	_, err := NewHandler(&bytes.Buffer{}, config.LoggingConfig{Level: "verbose"})
+	assert.ErrorContains(t, err, "logging.level")
+
+	_, err = NewHandler(&bytes.Buffer{}, config.LoggingConfig{Format: "xml"})
+	assert.ErrorContains(t, err, "logging.format")
+
+	_, err = NewHandler(&bytes.Buffer{}, config.LoggingConfig{Modules: map[string]string{"http": "loud"}})
+	assert.ErrorContains(t, err, "logging.modules.http")
+}
+
+func TestSampler_KeepsFirstThenEveryNth(t *testing.T) {
+	s := newSampler(2, 3, time.Second)
+	start := time.Now()
+
+	var kept []int
+	for i := 1; i <= 8; i++ {
+		if s.allow(ModuleHTTP, "request", start) {
+			kept = append(kept, i)
+		}
+	}
+	assert.Equal(t, []int{1, 2, 5, 8}, kept)
+	assert.True(t, s.allow(ModuleHTTP, "other message", start), "messages are counted separately")
+	assert.True(t, s.allow(ModuleHTTP, "request", start.Add(time.Second)), "counts reset every interval")
+}
+
+func TestModuleOfFunc(t *testing.T) {
+	const root = "github.com/rendis/pdf-forge/core/"
+	cases := map[string]string{
+		root + "internal/adapters/primary/http/middleware.Operation.func1":                     ModuleHTTP,
+		root + "internal/core/service/rendering/pdfrenderer.(*Service).RenderPreview":          ModuleRenderer,
+		root + "internal/core/service/injectable.(*InjectableResolver).Resolve":                ModuleInjectors,
+		root + "internal/core/service/template.(*Scheduler).tick":                              ModuleScheduler,
+		root + "internal/core/service/template.(*InternalRenderService).renderVersion":         ModuleApp,
+		root + "internal/core/service/platform.(*CleanupService).RunCleanup":                   ModuleScheduler,
+		root + "internal/adapters/secondary/database/postgres/asset_repo.(*Repository).Create": ModuleRepos,
+		"github.com/acme/extensions.(*Injector).Resolve":                                       ModuleApp,
+	}
+	for name, want := range cases {
+		assert.Equal(t, want, moduleOfFunc(name), name)
+	}
+	assert.Equal(t, ModuleApp, moduleOf(0))
+}
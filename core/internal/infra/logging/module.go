@@ -0,0 +1,77 @@
+package logging
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Built-in modules with their own log level. Records are assigned a module from the function that
+// logged them, or from a "module" attribute on the record, which takes precedence and may name any
+// module, e.g. one of an extension.
+const (
+	ModuleHTTP      = "http"      // Routing, middleware and controllers
+	ModuleRenderer  = "renderer"  // Typst generation and compilation
+	ModuleInjectors = "injectors" // Injectable resolution and registered injectors
+	ModuleScheduler = "scheduler" // Background jobs: scheduled publications, outbox relay, reapers, indexers, cleanup, render jobs
+	ModuleRepos     = "repos"     // Database access
+	ModuleApp       = "app"       // Everything else
+)
+
+// modulePrefixes maps function name prefixes, relative to the module path, to their module.
+// More specific prefixes come first.
+var modulePrefixes = []struct {
+	prefix string
+	module string
+}{
+	{"internal/core/service/template.(*Scheduler)", ModuleScheduler},
+	{"internal/core/service/template.(*HostedDocumentIndexer)", ModuleScheduler},
+	{"internal/core/service/template.(*RenderJobRunner)", ModuleScheduler},
+	{"internal/core/service/organization.(*SandboxReaper)", ModuleScheduler},
+	{"internal/core/service/platform.(*Cleanup", ModuleScheduler},
+	{"internal/core/service/outbox.", ModuleScheduler},
+	{"internal/adapters/primary/http/", ModuleHTTP},
+	{"internal/infra/server.", ModuleHTTP},
+	{"internal/core/service/rendering/", ModuleRenderer},
+	{"internal/core/service/rendering.", ModuleRenderer},
+	{"internal/core/service/injectable.", ModuleInjectors},
+	{"internal/extensions/", ModuleInjectors},
+	{"internal/infra/registry.", ModuleInjectors},
+	{"internal/adapters/secondary/database/", ModuleRepos},
+}
+
+// moduleRoot is the path every function of this module starts with.
+const moduleRoot = "github.com/rendis/pdf-forge/core/"
+
+// moduleCache memoizes the module of each program counter.
+var moduleCache sync.Map
+
+// moduleOf returns the module of the function at pc.
+func moduleOf(pc uintptr) string {
+	if pc == 0 {
+		return ModuleApp
+	}
+	if module, ok := moduleCache.Load(pc); ok {
+		return module.(string)
+	}
+
+	frames := runtime.CallersFrames([]uintptr{pc})
+	frame, _ := frames.Next()
+	module := moduleOfFunc(frame.Function)
+	moduleCache.Store(pc, module)
+	return module
+}
+
+// moduleOfFunc returns the module of a fully qualified function name.
+func moduleOfFunc(name string) string {
+	name, ok := strings.CutPrefix(name, moduleRoot)
+	if !ok {
+		return ModuleApp
+	}
+	for _, p := range modulePrefixes {
+		if strings.HasPrefix(name, p.prefix) {
+			return p.module
+		}
+	}
+	return ModuleApp
+}
@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/infra/config"
+)
+
+// NewHandler builds the application log handler from the logging configuration: a JSON or text
+// handler writing to w, filtered by module level and sampling, that adds context attributes.
+func NewHandler(w io.Writer, cfg config.LoggingConfig) (slog.Handler, error) {
+	fallback, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, fmt.Errorf("logging.level: %w", err)
+	}
+
+	levels := make(map[string]slog.Level, len(cfg.Modules))
+	for module, value := range cfg.Modules {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		level, err := ParseLevel(value)
+		if err != nil {
+			return nil, fmt.Errorf("logging.modules.%s: %w", module, err)
+		}
+		levels[strings.ToLower(module)] = level
+	}
+
+	// The base handler lets everything through; moduleLevelHandler decides per record.
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug}
+	var base slog.Handler
+	switch strings.ToLower(cfg.Format) {
+	case "", "json":
+		base = slog.NewJSONHandler(w, opts)
+	case "text":
+		base = slog.NewTextHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("logging.format: unknown format %q (valid: json, text)", cfg.Format)
+	}
+
+	s := cfg.Sampling
+	sampling := newSampler(s.First, s.Thereafter, time.Duration(s.IntervalSeconds)*time.Second)
+	return NewContextHandler(newModuleLevelHandler(base, fallback, levels, sampling)), nil
+}
+
+// ParseLevel parses a log level name: debug, info, warn or error. Empty means info.
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("unknown level %q (valid: debug, info, warn, error)", s)
+	}
+}
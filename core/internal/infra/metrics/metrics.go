@@ -0,0 +1,148 @@
+// Package metrics keeps the metrics of the engine in a Prometheus registry.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// Bucket upper bounds, in seconds.
+var (
+	renderBuckets   = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+	injectorBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+	httpBuckets     = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+)
+
+// Metrics are the metrics of the engine, served at /metrics together with the Go runtime and
+// process metrics.
+type Metrics struct {
+	registry      *prometheus.Registry
+	renders       *prometheus.HistogramVec
+	renderPhases  *prometheus.HistogramVec
+	typstFailures *prometheus.CounterVec
+	injectors     *prometheus.HistogramVec
+	httpRequests  *prometheus.HistogramVec
+}
+
+// New creates the metrics of the engine.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		renders: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "pdf_forge_render_duration_seconds",
+			Help:    "Duration of PDF renders, from the render slot to the PDF.",
+			Buckets: renderBuckets,
+		}, []string{"outcome"}),
+		renderPhases: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "pdf_forge_render_phase_duration_seconds",
+			Help:    "Duration of the phases of PDF renders: convert, image_fetch and compile.",
+			Buckets: renderBuckets,
+		}, []string{"phase"}),
+		typstFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "pdf_forge_typst_failures_total",
+			Help: "Failed Typst compiles by reason: timeout, canceled or error.",
+		}, []string{"reason"}),
+		injectors: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "pdf_forge_injector_duration_seconds",
+			Help:    "Resolution time of registry injectors.",
+			Buckets: injectorBuckets,
+		}, []string{"code", "outcome"}),
+		httpRequests: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "pdf_forge_http_request_duration_seconds",
+			Help:    "Latency of HTTP requests by route template.",
+			Buckets: httpBuckets,
+		}, []string{"method", "route", "status"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.renders, m.renderPhases, m.typstFailures, m.injectors, m.httpRequests,
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveRender records a PDF render and whether it failed.
+func (m *Metrics) ObserveRender(d time.Duration, failed bool) {
+	m.renders.WithLabelValues(outcome(failed)).Observe(d.Seconds())
+}
+
+// ObserveRenderPhase records a phase of a PDF render.
+func (m *Metrics) ObserveRenderPhase(phase port.RenderPhase, d time.Duration) {
+	m.renderPhases.WithLabelValues(string(phase)).Observe(d.Seconds())
+}
+
+// ObserveTypstFailure counts a failed Typst compile.
+func (m *Metrics) ObserveTypstFailure(reason string) {
+	m.typstFailures.WithLabelValues(reason).Inc()
+}
+
+// ObserveInjector records the resolution of a registry injector.
+func (m *Metrics) ObserveInjector(code string, d time.Duration, failed bool) {
+	m.injectors.WithLabelValues(code, outcome(failed)).Observe(d.Seconds())
+}
+
+// ObserveHTTPRequest records an HTTP request. route is the route template, such as
+// /api/v1/content/templates/:templateId, so IDs do not multiply the series.
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, d time.Duration) {
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Observe(d.Seconds())
+}
+
+// RegisterPool adds the connection stats of the database pool, read at each scrape.
+func (m *Metrics) RegisterPool(pool *pgxpool.Pool) {
+	stat := func(fn func(s *pgxpool.Stat) float64) func() float64 {
+		return func() float64 { return fn(pool.Stat()) }
+	}
+	gauge := func(name, help string, fn func() float64) prometheus.Collector {
+		return prometheus.NewGaugeFunc(prometheus.GaugeOpts{Name: name, Help: help}, fn)
+	}
+	counter := func(name, help string, fn func() float64) prometheus.Collector {
+		return prometheus.NewCounterFunc(prometheus.CounterOpts{Name: name, Help: help}, fn)
+	}
+	m.registry.MustRegister(
+		gauge("pdf_forge_db_pool_connections_max", "Maximum size of the database pool.",
+			stat(func(s *pgxpool.Stat) float64 { return float64(s.MaxConns()) })),
+		gauge("pdf_forge_db_pool_connections_total", "Open connections of the database pool.",
+			stat(func(s *pgxpool.Stat) float64 { return float64(s.TotalConns()) })),
+		gauge("pdf_forge_db_pool_connections_acquired", "Connections of the database pool in use.",
+			stat(func(s *pgxpool.Stat) float64 { return float64(s.AcquiredConns()) })),
+		gauge("pdf_forge_db_pool_connections_idle", "Idle connections of the database pool.",
+			stat(func(s *pgxpool.Stat) float64 { return float64(s.IdleConns()) })),
+		counter("pdf_forge_db_pool_acquires_total", "Connections acquired from the database pool.",
+			stat(func(s *pgxpool.Stat) float64 { return float64(s.AcquireCount()) })),
+		counter("pdf_forge_db_pool_empty_acquires_total", "Acquires that waited because the database pool was empty.",
+			stat(func(s *pgxpool.Stat) float64 { return float64(s.EmptyAcquireCount()) })),
+		counter("pdf_forge_db_pool_canceled_acquires_total", "Acquires canceled before a connection was available.",
+			stat(func(s *pgxpool.Stat) float64 { return float64(s.CanceledAcquireCount()) })),
+		counter("pdf_forge_db_pool_acquire_seconds_total", "Time spent acquiring connections from the database pool.",
+			stat(func(s *pgxpool.Stat) float64 { return s.AcquireDuration().Seconds() })),
+	)
+}
+
+// RegisterRenderCapacity adds the render load of the instance, read at each scrape, so /metrics
+// carries the autoscaler signals too.
+func (m *Metrics) RegisterRenderCapacity(reporter port.RenderCapacityReporter) {
+	m.registry.MustRegister(NewRenderCapacityCollector(reporter))
+}
+
+func outcome(failed bool) string {
+	if failed {
+		return "failure"
+	}
+	return "success"
+}
+
+// Ensure Metrics implements port.Metrics.
+var _ port.Metrics = (*Metrics)(nil)
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+var (
+	renderQueueDepthDesc = prometheus.NewDesc("pdf_forge_render_queue_depth",
+		"Renders waiting for a render slot.", nil, nil)
+	renderActiveDesc = prometheus.NewDesc("pdf_forge_render_active",
+		"Renders holding a render slot.", nil, nil)
+	renderSlotsDesc = prometheus.NewDesc("pdf_forge_render_slots",
+		"Render slots; 0 means unlimited.", nil, nil)
+	renderUtilizationDesc = prometheus.NewDesc("pdf_forge_render_utilization",
+		"Share of render slots in use.", nil, nil)
+	renderWaitDesc = prometheus.NewDesc("pdf_forge_render_wait_avg_seconds",
+		"Mean wait for a render slot over the last minute.", nil, nil)
+	renderRejectedDesc = prometheus.NewDesc("pdf_forge_render_rejected_total",
+		"Renders rejected because no slot freed up in time.", nil, nil)
+)
+
+// RenderCapacityCollector reports the render load of the instance. It reads the load once per
+// scrape, so the series of a scrape agree with each other.
+type RenderCapacityCollector struct {
+	reporter port.RenderCapacityReporter
+}
+
+// NewRenderCapacityCollector creates a collector of the render load reported by reporter.
+func NewRenderCapacityCollector(reporter port.RenderCapacityReporter) *RenderCapacityCollector {
+	return &RenderCapacityCollector{reporter: reporter}
+}
+
+// Describe implements prometheus.Collector.
+func (c *RenderCapacityCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- renderQueueDepthDesc
+	ch <- renderActiveDesc
+	ch <- renderSlotsDesc
+	ch <- renderUtilizationDesc
+	ch <- renderWaitDesc
+	ch <- renderRejectedDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *RenderCapacityCollector) Collect(ch chan<- prometheus.Metric) {
+	capacity := c.reporter.RenderCapacity()
+	ch <- prometheus.MustNewConstMetric(renderQueueDepthDesc, prometheus.GaugeValue, float64(capacity.QueueDepth))
+	ch <- prometheus.MustNewConstMetric(renderActiveDesc, prometheus.GaugeValue, float64(capacity.ActiveRenders))
+	ch <- prometheus.MustNewConstMetric(renderSlotsDesc, prometheus.GaugeValue, float64(capacity.MaxConcurrent))
+	ch <- prometheus.MustNewConstMetric(renderUtilizationDesc, prometheus.GaugeValue, capacity.Utilization)
+	ch <- prometheus.MustNewConstMetric(renderWaitDesc, prometheus.GaugeValue, capacity.AverageWaitMs/1000)
+	ch <- prometheus.MustNewConstMetric(renderRejectedDesc, prometheus.CounterValue, float64(capacity.RejectedTotal))
+}
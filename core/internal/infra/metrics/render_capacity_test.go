@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+)
+
+type capacityReporterStub struct {
+	capacity entity.RenderCapacity
+}
+
+func (s capacityReporterStub) RenderCapacity() entity.RenderCapacity { return s.capacity }
+
+func TestRenderCapacityOnMetrics(t *testing.T) {
+	m := New()
+	m.RegisterRenderCapacity(capacityReporterStub{capacity: entity.RenderCapacity{
+		QueueDepth:    3,
+		ActiveRenders: 4,
+		MaxConcurrent: 4,
+		Utilization:   1,
+		AverageWaitMs: 250,
+		RejectedTotal: 2,
+	}})
+
+	want := `# HELP pdf_forge_render_queue_depth Renders waiting for a render slot.
+# TYPE pdf_forge_render_queue_depth gauge
+pdf_forge_render_queue_depth 3
+# HELP pdf_forge_render_wait_avg_seconds Mean wait for a render slot over the last minute.
+# TYPE pdf_forge_render_wait_avg_seconds gauge
+pdf_forge_render_wait_avg_seconds 0.25
+# HELP pdf_forge_render_rejected_total Renders rejected because no slot freed up in time.
+# TYPE pdf_forge_render_rejected_total counter
+pdf_forge_render_rejected_total 2
+`
+	err := testutil.GatherAndCompare(m.registry, strings.NewReader(want),
+		"pdf_forge_render_queue_depth", "pdf_forge_render_wait_avg_seconds", "pdf_forge_render_rejected_total")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestObserveRender(t *testing.T) {
+	m := New()
+	m.ObserveRender(300*time.Millisecond, false)
+	m.ObserveRender(2*time.Second, true)
+
+	if got := testutil.CollectAndCount(m.renders, "pdf_forge_render_duration_seconds"); got != 2 {
+		t.Errorf("render series = %d, want 2", got)
+	}
+}
@@ -0,0 +1,172 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"regexp"
+	"runtime/debug"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	"github.com/rendis/pdf-forge/core/internal/infra/config"
+)
+
+// Files of a pack directory.
+const (
+	PackManifestFile = "pack.yaml"
+	PackI18nFile     = "injectors.i18n.yaml"
+)
+
+// engineModulePath is the module whose version pack engine constraints are checked against.
+const engineModulePath = "github.com/rendis/pdf-forge"
+
+var (
+	packNamespacePattern = regexp.MustCompile(`^[a-z][a-z0-9]{1,29}$`)
+	pseudoVersionPattern = regexp.MustCompile(`\d{14}-[0-9a-f]{12}$`)
+)
+
+// staticPack is a pack loaded from a pack directory.
+type staticPack struct {
+	manifest  port.InjectorPackManifest
+	i18n      []byte
+	injectors []port.Injector
+}
+
+func (p *staticPack) Manifest() port.InjectorPackManifest { return p.manifest }
+func (p *staticPack) I18n() []byte                        { return p.i18n }
+func (p *staticPack) Injectors() []port.Injector          { return p.injectors }
+
+// LoadInjectorPack builds a pack from the pack.yaml and the optional injectors.i18n.yaml at the
+// root of fsys, usually an embed.FS of the pack module, and its injectors.
+func LoadInjectorPack(fsys fs.FS, injectors ...port.Injector) (port.InjectorPack, error) {
+	data, err := fs.ReadFile(fsys, PackManifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", PackManifestFile, err)
+	}
+	pack := &staticPack{injectors: injectors}
+	if err := yaml.Unmarshal(data, &pack.manifest); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", PackManifestFile, err)
+	}
+
+	pack.i18n, err = fs.ReadFile(fsys, PackI18nFile)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("reading %s: %w", PackI18nFile, err)
+	}
+	return pack, nil
+}
+
+// ResolvePacks checks the manifests of packs against each other and the engine version, and
+// returns their injectors and merged translations. An empty engineVersion (a development build)
+// skips the engine constraints.
+func ResolvePacks(packs []port.InjectorPack, engineVersion string) ([]port.Injector, *config.InjectorI18nConfig, error) {
+	versions := make(map[string]semver, len(packs))
+	for _, pack := range packs {
+		m := pack.Manifest()
+		if !packNamespacePattern.MatchString(m.Namespace) {
+			return nil, nil, fmt.Errorf("injector pack %q: namespace %q must be 2 to 30 lowercase letters and digits, starting with a letter", m.Name, m.Namespace)
+		}
+		if _, exists := versions[m.Namespace]; exists {
+			return nil, nil, fmt.Errorf("injector pack %q: namespace %q already registered", m.Name, m.Namespace)
+		}
+		version, err := parseSemver(m.Version)
+		if err != nil {
+			return nil, nil, fmt.Errorf("injector pack %q: %w", m.Namespace, err)
+		}
+		versions[m.Namespace] = version
+
+		if err := checkEngineConstraint(m, engineVersion); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var injectors []port.Injector
+	i18n, _ := config.ParseInjectorI18n(nil)
+	for _, pack := range packs {
+		m := pack.Manifest()
+		for namespace, constraint := range m.Requires {
+			version, ok := versions[namespace]
+			if !ok {
+				return nil, nil, fmt.Errorf("injector pack %q requires pack %q %s, which is not registered", m.Namespace, namespace, constraint)
+			}
+			if ok, err := version.satisfies(constraint); err != nil {
+				return nil, nil, fmt.Errorf("injector pack %q: %w", m.Namespace, err)
+			} else if !ok {
+				return nil, nil, fmt.Errorf("injector pack %q requires pack %q %s, got %s", m.Namespace, namespace, constraint, version)
+			}
+		}
+
+		prefix := m.Namespace + "_"
+		for _, inj := range pack.Injectors() {
+			if !strings.HasPrefix(inj.Code(), prefix) {
+				return nil, nil, fmt.Errorf("injector pack %q: injector code %q must start with %q", m.Namespace, inj.Code(), prefix)
+			}
+			injectors = append(injectors, inj)
+		}
+
+		packI18n, err := config.ParseInjectorI18n(pack.I18n())
+		if err != nil {
+			return nil, nil, fmt.Errorf("injector pack %q: parsing i18n: %w", m.Namespace, err)
+		}
+		for _, code := range packI18n.Codes() {
+			if !strings.HasPrefix(code, prefix) {
+				return nil, nil, fmt.Errorf("injector pack %q: i18n entry %q must start with %q", m.Namespace, code, prefix)
+			}
+		}
+		for _, group := range packI18n.GetAllGroups() {
+			if !strings.HasPrefix(group.Key, prefix) {
+				return nil, nil, fmt.Errorf("injector pack %q: i18n group %q must start with %q", m.Namespace, group.Key, prefix)
+			}
+		}
+		i18n.Merge(packI18n)
+	}
+	return injectors, i18n, nil
+}
+
+func checkEngineConstraint(m port.InjectorPackManifest, engineVersion string) error {
+	if m.Engine == "" {
+		return nil
+	}
+	if engineVersion == "" {
+		slog.Debug("engine version unknown, skipping the engine constraint of injector pack",
+			slog.String("namespace", m.Namespace), slog.String("engine", m.Engine))
+		return nil
+	}
+	version, err := parseSemver(engineVersion)
+	if err != nil {
+		return fmt.Errorf("engine %w", err)
+	}
+	ok, err := version.satisfies(m.Engine)
+	if err != nil {
+		return fmt.Errorf("injector pack %q: %w", m.Namespace, err)
+	}
+	if !ok {
+		return fmt.Errorf("injector pack %q requires pdf-forge %s, got %s", m.Namespace, m.Engine, version)
+	}
+	return nil
+}
+
+// EngineVersion returns the version of the pdf-forge module in the running binary, or "" when it
+// is not a tagged release (a development build, a replace or a pseudo-version).
+func EngineVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	version := ""
+	if info.Main.Path == engineModulePath {
+		version = info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == engineModulePath && dep.Replace == nil {
+			version = dep.Version
+		}
+	}
+	if version == "(devel)" || pseudoVersionPattern.MatchString(version) {
+		return ""
+	}
+	return version
+}
@@ -0,0 +1,119 @@
+package registry
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+const stripeI18n = `
+groups:
+  - key: stripe_billing
+    name:
+      en: "Stripe"
+
+stripe_customer_email:
+  group: stripe_billing
+  name:
+    en: "Customer email"
+`
+
+func stripePack(t *testing.T, manifest string) port.InjectorPack {
+	t.Helper()
+	pack, err := LoadInjectorPack(fstest.MapFS{
+		PackManifestFile: {Data: []byte(manifest)},
+		PackI18nFile:     {Data: []byte(stripeI18n)},
+	}, &stubInjector{code: "stripe_customer_email"})
+	if err != nil {
+		t.Fatalf("LoadInjectorPack() = %v", err)
+	}
+	return pack
+}
+
+func TestResolvePacks(t *testing.T) {
+	stripe := stripePack(t, "name: Stripe pack\nnamespace: stripe\nversion: 1.2.0\nengine: \">=1.4.0 <2.0.0\"\n")
+	crm := &staticPack{manifest: port.InjectorPackManifest{
+		Name: "CRM pack", Namespace: "crm", Version: "0.3.1", Requires: map[string]string{"stripe": "^1.1.0"},
+	}}
+
+	injectors, i18n, err := ResolvePacks([]port.InjectorPack{stripe, crm}, "v1.5.2")
+	if err != nil {
+		t.Fatalf("ResolvePacks() = %v", err)
+	}
+	if len(injectors) != 1 || injectors[0].Code() != "stripe_customer_email" {
+		t.Errorf("injectors = %v, want stripe_customer_email", injectors)
+	}
+	if got := i18n.GetName("stripe_customer_email", "es"); got != "Customer email" {
+		t.Errorf("GetName() = %q, want the pack translation", got)
+	}
+
+	if _, _, err := ResolvePacks([]port.InjectorPack{stripe}, ""); err != nil {
+		t.Errorf("ResolvePacks() on a development build = %v, want the engine constraint skipped", err)
+	}
+}
+
+func TestResolvePacks_Rejects(t *testing.T) {
+	stripe := stripePack(t, "name: Stripe pack\nnamespace: stripe\nversion: 1.2.0\nengine: \">=1.4.0\"\n")
+	cases := []struct {
+		name  string
+		packs []port.InjectorPack
+		want  string
+	}{
+		{"duplicate namespace", []port.InjectorPack{stripe, stripe}, `namespace "stripe" already registered`},
+		{"bad namespace", []port.InjectorPack{&staticPack{manifest: port.InjectorPackManifest{Namespace: "Stripe", Version: "1.0.0"}}}, "lowercase"},
+		{"missing requirement", []port.InjectorPack{&staticPack{manifest: port.InjectorPackManifest{
+			Namespace: "crm", Version: "1.0.0", Requires: map[string]string{"stripe": "^1.0.0"},
+		}}}, "not registered"},
+		{"unmet requirement", []port.InjectorPack{stripe, &staticPack{manifest: port.InjectorPackManifest{
+			Namespace: "crm", Version: "1.0.0", Requires: map[string]string{"stripe": "~1.1.0"},
+		}}}, `requires pack "stripe" ~1.1.0, got 1.2.0`},
+		{"unprefixed code", []port.InjectorPack{&staticPack{
+			manifest:  port.InjectorPackManifest{Namespace: "crm", Version: "1.0.0"},
+			injectors: []port.Injector{&stubInjector{code: "account_id"}},
+		}}, `injector code "account_id" must start with "crm_"`},
+		{"foreign i18n", []port.InjectorPack{&staticPack{
+			manifest: port.InjectorPackManifest{Namespace: "crm", Version: "1.0.0"},
+			i18n:     []byte(stripeI18n),
+		}}, `must start with "crm_"`},
+	}
+	if _, _, err := ResolvePacks([]port.InjectorPack{stripe}, "v1.3.9"); err == nil || !strings.Contains(err.Error(), "requires pdf-forge >=1.4.0, got 1.3.9") {
+		t.Errorf("ResolvePacks() on an old engine = %v", err)
+	}
+	for _, tc := range cases {
+		_, _, err := ResolvePacks(tc.packs, "1.5.0")
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: ResolvePacks() = %v, want an error containing %q", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestSemverSatisfies(t *testing.T) {
+	cases := []struct {
+		version, constraint string
+		want                bool
+	}{
+		{"1.4.0", ">=1.4.0 <2.0.0", true},
+		{"2.0.0", ">=1.4.0, <2.0.0", false},
+		{"1.9.3", "^1.2.0", true},
+		{"0.3.0", "^0.2.1", false},
+		{"1.2.9", "~1.2.3", true},
+		{"1.3.0", "~1.2.3", false},
+		{"1.2.3-rc.1", "1.2.3", true},
+		{"v1.2.4", ">1.2.3", true},
+	}
+	for _, tc := range cases {
+		v, err := parseSemver(tc.version)
+		if err != nil {
+			t.Fatalf("parseSemver(%q) = %v", tc.version, err)
+		}
+		got, err := v.satisfies(tc.constraint)
+		if err != nil || got != tc.want {
+			t.Errorf("%s satisfies %q = %v, %v; want %v", tc.version, tc.constraint, got, err, tc.want)
+		}
+	}
+	if _, err := (semver{1, 0, 0}).satisfies("=>1.0.0"); err == nil {
+		t.Error("an unknown operator is rejected")
+	}
+}
@@ -2,8 +2,10 @@ package registry
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
 	"github.com/rendis/pdf-forge/core/internal/core/port"
 	"github.com/rendis/pdf-forge/core/internal/infra/config"
 )
@@ -25,6 +27,8 @@ func NewInjectorRegistry(i18n *config.InjectorI18nConfig) port.InjectorRegistry
 }
 
 // Register registers an injector in the registry.
+// Fails with entity.ErrInjectorDependencyCycle if the injector's dependencies, followed through
+// the registered injectors, lead back to it.
 func (r *injectorRegistry) Register(injector port.Injector) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -38,10 +42,63 @@ func (r *injectorRegistry) Register(injector port.Injector) error {
 		return fmt.Errorf("injector with code %q already registered", code)
 	}
 
+	if cycle := r.findCycle(injector); cycle != nil {
+		return fmt.Errorf("registering injector %q: %w: %s", code, entity.ErrInjectorDependencyCycle, strings.Join(cycle, " -> "))
+	}
+
 	r.injectors[code] = injector
 	return nil
 }
 
+// findCycle returns the dependency path from injector back to itself, or nil if there is none.
+// Injectors registered earlier cannot form a cycle among themselves, so any cycle runs through
+// the new injector. Unregistered dependencies are provider codes and end the path.
+func (r *injectorRegistry) findCycle(injector port.Injector) []string {
+	code := injector.Code()
+	visited := make(map[string]bool)
+
+	var visit func(inj port.Injector, path []string) []string
+	visit = func(inj port.Injector, path []string) []string {
+		_, deps := inj.Resolve()
+		for _, dep := range deps {
+			if dep == code {
+				return append(path, dep)
+			}
+			next, ok := r.injectors[dep]
+			if !ok || visited[dep] {
+				continue
+			}
+			visited[dep] = true
+			if cycle := visit(next, append(path, dep)); cycle != nil {
+				return cycle
+			}
+		}
+		return nil
+	}
+	return visit(injector, []string{code})
+}
+
+// Unregister removes an injector from the registry.
+func (r *injectorRegistry) Unregister(code string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.injectors[code]; !exists {
+		return false
+	}
+	delete(r.injectors, code)
+	return true
+}
+
+// labeled returns the injector with the code if it carries its own labels.
+func (r *injectorRegistry) labeled(code string) (port.LabeledInjector, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	labeled, ok := r.injectors[code].(port.LabeledInjector)
+	return labeled, ok
+}
+
 // Get retrieves an injector by its code.
 func (r *injectorRegistry) Get(code string) (port.Injector, bool) {
 	r.mu.RLock()
@@ -77,6 +134,9 @@ func (r *injectorRegistry) Codes() []string {
 
 // GetName returns the translated name of the injector.
 func (r *injectorRegistry) GetName(code, locale string) string {
+	if labeled, ok := r.labeled(code); ok {
+		return localized(labeled.Labels(), locale, code)
+	}
 	if r.i18n == nil {
 		return code
 	}
@@ -85,6 +145,9 @@ func (r *injectorRegistry) GetName(code, locale string) string {
 
 // GetDescription returns the translated description of the injector.
 func (r *injectorRegistry) GetDescription(code, locale string) string {
+	if labeled, ok := r.labeled(code); ok {
+		return localized(labeled.Descriptions(), locale, "")
+	}
 	if r.i18n == nil {
 		return ""
 	}
@@ -93,6 +156,9 @@ func (r *injectorRegistry) GetDescription(code, locale string) string {
 
 // GetAllNames returns all translations for the injector name.
 func (r *injectorRegistry) GetAllNames(code string) map[string]string {
+	if labeled, ok := r.labeled(code); ok {
+		return labeled.Labels()
+	}
 	if r.i18n == nil {
 		return map[string]string{"en": code}
 	}
@@ -101,6 +167,9 @@ func (r *injectorRegistry) GetAllNames(code string) map[string]string {
 
 // GetAllDescriptions returns all translations for the injector description.
 func (r *injectorRegistry) GetAllDescriptions(code string) map[string]string {
+	if labeled, ok := r.labeled(code); ok {
+		return labeled.Descriptions()
+	}
 	if r.i18n == nil {
 		return map[string]string{}
 	}
@@ -148,5 +217,16 @@ func (r *injectorRegistry) GetAllGroups() []port.GroupConfig {
 	return result
 }
 
+// localized returns the translation for locale, falling back to English and then to fallback.
+func localized(translations map[string]string, locale, fallback string) string {
+	if text, ok := translations[locale]; ok && text != "" {
+		return text
+	}
+	if text, ok := translations["en"]; ok && text != "" {
+		return text
+	}
+	return fallback
+}
+
 // Ensure InjectorRegistry implements port.InjectorRegistry.
 var _ port.InjectorRegistry = (*injectorRegistry)(nil)
@@ -0,0 +1,88 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+type stubInjector struct {
+	port.Injector
+	code string
+	deps []string
+}
+
+func (i *stubInjector) Code() string { return i.code }
+
+func (i *stubInjector) Resolve() (port.ResolveFunc, []string) {
+	return func(context.Context, *entity.InjectorContext) (*entity.InjectorResult, error) {
+		return &entity.InjectorResult{Value: entity.StringValue(i.code)}, nil
+	}, i.deps
+}
+
+func TestRegister_RejectsDependencyCycle(t *testing.T) {
+	r := NewInjectorRegistry(nil)
+	for _, inj := range []*stubInjector{
+		{code: "total", deps: []string{"price", "crm_discount"}},
+		{code: "price", deps: []string{"currency"}},
+	} {
+		if err := r.Register(inj); err != nil {
+			t.Fatalf("Register(%s) = %v", inj.code, err)
+		}
+	}
+
+	err := r.Register(&stubInjector{code: "currency", deps: []string{"total"}})
+	if !errors.Is(err, entity.ErrInjectorDependencyCycle) {
+		t.Fatalf("Register(currency) = %v, want a dependency cycle", err)
+	}
+	if want := "currency -> total -> price -> currency"; !strings.Contains(err.Error(), want) {
+		t.Errorf("error %q does not name the cycle %q", err, want)
+	}
+	if _, ok := r.Get("currency"); ok {
+		t.Error("an injector closing a cycle is not registered")
+	}
+
+	if err := r.Register(&stubInjector{code: "self", deps: []string{"self"}}); !errors.Is(err, entity.ErrInjectorDependencyCycle) {
+		t.Errorf("Register(self) = %v, want a dependency cycle", err)
+	}
+	if err := r.Register(&stubInjector{code: "currency"}); err != nil {
+		t.Errorf("Register(currency) without dependencies = %v", err)
+	}
+}
+
+type labeledStubInjector struct {
+	stubInjector
+}
+
+func (i *labeledStubInjector) Labels() map[string]string {
+	return map[string]string{"en": "Greeting", "es": "Saludo"}
+}
+
+func (i *labeledStubInjector) Descriptions() map[string]string { return map[string]string{} }
+
+func TestUnregister_LabeledInjectors(t *testing.T) {
+	r := NewInjectorRegistry(nil)
+	if err := r.Register(&labeledStubInjector{stubInjector{code: "greeting"}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := r.GetName("greeting", "es"); got != "Saludo" {
+		t.Errorf("GetName(es) = %q, want Saludo", got)
+	}
+	if got := r.GetName("greeting", "fr"); got != "Greeting" {
+		t.Errorf("GetName(fr) = %q, want the English label", got)
+	}
+
+	if !r.Unregister("greeting") {
+		t.Fatal("Unregister(greeting) = false")
+	}
+	if _, ok := r.Get("greeting"); ok || r.Unregister("greeting") {
+		t.Error("greeting is still registered")
+	}
+	if err := r.Register(&stubInjector{code: "greeting"}); err != nil {
+		t.Errorf("registering the code again = %v", err)
+	}
+}
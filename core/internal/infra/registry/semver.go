@@ -0,0 +1,92 @@
+package registry
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// semver is a MAJOR.MINOR.PATCH version. Pre-release and build suffixes are ignored.
+type semver [3]int
+
+func parseSemver(s string) (semver, error) {
+	core := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return semver{}, fmt.Errorf("version %q is not MAJOR.MINOR.PATCH", s)
+	}
+	var v semver
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return semver{}, fmt.Errorf("version %q is not MAJOR.MINOR.PATCH", s)
+		}
+		v[i] = n
+	}
+	return v, nil
+}
+
+func (v semver) compare(o semver) int {
+	for i := range v {
+		if v[i] != o[i] {
+			if v[i] < o[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func (v semver) String() string {
+	return fmt.Sprintf("%d.%d.%d", v[0], v[1], v[2])
+}
+
+// satisfies reports whether v meets every term of constraint. Terms are separated by spaces or
+// commas and use =, >, >=, <, <=, ^ (same major, or same minor below 1.0.0) or ~ (same minor).
+// A term without operator is an exact version.
+func (v semver) satisfies(constraint string) (bool, error) {
+	terms := strings.FieldsFunc(constraint, func(r rune) bool { return r == ' ' || r == ',' })
+	if len(terms) == 0 {
+		return false, fmt.Errorf("empty version constraint")
+	}
+	for _, term := range terms {
+		op := strings.TrimRight(term, "v0123456789.-+abcdefghijklmnopqrstuvwxyz")
+		bound, err := parseSemver(term[len(op):])
+		if err != nil {
+			return false, fmt.Errorf("constraint %q: %w", constraint, err)
+		}
+		cmp := v.compare(bound)
+
+		var ok bool
+		switch op {
+		case "", "=":
+			ok = cmp == 0
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		case "^":
+			upper := semver{bound[0] + 1, 0, 0}
+			if bound[0] == 0 {
+				upper = semver{0, bound[1] + 1, 0}
+			}
+			ok = cmp >= 0 && v.compare(upper) < 0
+		case "~":
+			ok = cmp >= 0 && v.compare(semver{bound[0], bound[1] + 1, 0}) < 0
+		default:
+			return false, fmt.Errorf("constraint %q: unknown operator %q", constraint, op)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rendis/pdf-forge/core/internal/infra/config"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	listed := config.CORSConfig{AllowedOrigins: []string{"https://app.example.com", "https://admin.example.com"}}
+
+	tests := []struct {
+		name            string
+		cfg             config.CORSConfig
+		method          string
+		origin          string
+		wantStatus      int
+		wantOrigin      string
+		wantVary        string
+		wantCredentials string
+		wantMaxAge      string
+	}{
+		{name: "no origins allows any", method: http.MethodGet, origin: "https://evil.example", wantStatus: http.StatusOK, wantOrigin: "*"},
+		{name: "wildcard among origins", cfg: config.CORSConfig{AllowedOrigins: []string{"https://app.example.com", "*"}}, method: http.MethodGet, origin: "https://other.example", wantStatus: http.StatusOK, wantOrigin: "*"},
+		{name: "listed origin is echoed", cfg: listed, method: http.MethodGet, origin: "https://admin.example.com", wantStatus: http.StatusOK, wantOrigin: "https://admin.example.com", wantVary: "Origin"},
+		{name: "unlisted origin gets no grant", cfg: listed, method: http.MethodGet, origin: "https://evil.example", wantStatus: http.StatusOK},
+		{name: "request without origin", cfg: listed, method: http.MethodGet, wantStatus: http.StatusOK},
+		{
+			name:            "credentials",
+			cfg:             config.CORSConfig{AllowedOrigins: listed.AllowedOrigins, AllowCredentials: true},
+			method:          http.MethodPost,
+			origin:          "https://app.example.com",
+			wantStatus:      http.StatusOK,
+			wantOrigin:      "https://app.example.com",
+			wantVary:        "Origin",
+			wantCredentials: "true",
+		},
+		{name: "preflight without max age", cfg: listed, method: http.MethodOptions, origin: "https://app.example.com", wantStatus: http.StatusNoContent, wantOrigin: "https://app.example.com", wantVary: "Origin"},
+		{
+			name:       "preflight with max age",
+			cfg:        config.CORSConfig{AllowedOrigins: listed.AllowedOrigins, MaxAge: 600},
+			method:     http.MethodOptions,
+			origin:     "https://app.example.com",
+			wantStatus: http.StatusNoContent,
+			wantOrigin: "https://app.example.com",
+			wantVary:   "Origin",
+			wantMaxAge: "600",
+		},
+		{name: "max age only on preflight", cfg: config.CORSConfig{MaxAge: 600}, method: http.MethodGet, origin: "https://app.example.com", wantStatus: http.StatusOK, wantOrigin: "*"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveCORS(tt.cfg, tt.method, tt.origin)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			for header, want := range map[string]string{
+				"Access-Control-Allow-Origin":      tt.wantOrigin,
+				"Vary":                             tt.wantVary,
+				"Access-Control-Allow-Credentials": tt.wantCredentials,
+				"Access-Control-Max-Age":           tt.wantMaxAge,
+			} {
+				if got := rec.Header().Get(header); got != want {
+					t.Errorf("%s = %q, want %q", header, got, want)
+				}
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Methods"); !strings.Contains(got, "PATCH") {
+				t.Errorf("Access-Control-Allow-Methods = %q, want PATCH among them", got)
+			}
+		})
+	}
+}
+
+func TestCORSMiddleware_ExtraHeaders(t *testing.T) {
+	rec := serveCORS(config.CORSConfig{
+		AllowedHeaders: []string{"X-Request-ID"},
+		ExposedHeaders: []string{"X-Trace-ID"},
+	}, http.MethodOptions, "https://app.example.com")
+
+	allowed := rec.Header().Get("Access-Control-Allow-Headers")
+	for _, want := range []string{"Authorization", "X-Workspace-ID", "X-Request-ID"} {
+		if !strings.Contains(allowed, want) {
+			t.Errorf("Access-Control-Allow-Headers = %q, want %s", allowed, want)
+		}
+	}
+	exposed := rec.Header().Get("Access-Control-Expose-Headers")
+	for _, want := range []string{"X-Render-Warnings", "X-Trace-ID"} {
+		if !strings.Contains(exposed, want) {
+			t.Errorf("Access-Control-Expose-Headers = %q, want %s", exposed, want)
+		}
+	}
+}
+
+// serveCORS sends one request through the CORS middleware to a handler that answers 200.
+func serveCORS(cfg config.CORSConfig, method, origin string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(corsMiddleware(cfg))
+	engine.Handle(method, "/api/v1/things", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(method, "/api/v1/things", nil)
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	return rec
+}
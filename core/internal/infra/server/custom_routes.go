@@ -0,0 +1,45 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CustomRoute is an endpoint added through the SDK with engine.RegisterRoute.
+type CustomRoute struct {
+	Method  string
+	Path    string // Gin pattern under the base path, e.g. /internal/jobs/:id; parameters are exposed with r.PathValue
+	Handler http.Handler
+}
+
+// registerCustomRoutes mounts the custom routes on the router. They only get the global
+// middleware: authenticating them is left to their handlers. An invalid method or path, or one
+// that clashes with another route, is reported instead of panicking.
+func registerCustomRoutes(router gin.IRouter, routes []CustomRoute) error {
+	for _, route := range routes {
+		if route.Handler == nil {
+			return fmt.Errorf("custom route %s %s has no handler", route.Method, route.Path)
+		}
+		if err := handleCustomRoute(router, route); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func handleCustomRoute(router gin.IRouter, route CustomRoute) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("custom route %s %s: %v", route.Method, route.Path, r)
+		}
+	}()
+	router.Handle(route.Method, route.Path, func(c *gin.Context) {
+		for _, param := range c.Params {
+			c.Request.SetPathValue(param.Key, param.Value)
+		}
+		route.Handler.ServeHTTP(c.Writer, c.Request)
+	})
+	return nil
+}
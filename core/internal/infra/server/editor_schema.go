@@ -0,0 +1,18 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/mapper"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// editorSchemaHandler returns the nodes, marks, attrs and injectable value types this build
+// renders, so the editor can disable features the converter would drop.
+func editorSchemaHandler(provider port.EditorSchemaProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, mapper.EditorSchemaToResponse(provider.EditorSchema()))
+	}
+}
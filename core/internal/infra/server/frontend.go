@@ -0,0 +1,222 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io/fs"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rendis/pdf-forge/core/internal/infra/config"
+)
+
+// frontendConfigGlobal is the window property index.html assigns the client config to, so the
+// frontend starts without fetching /api/v1/config.
+const frontendConfigGlobal = "__PDF_FORGE_CONFIG__"
+
+// Cache policies of the frontend files. Vite hashes the names of the files under assets/.
+const (
+	cacheImmutable  = "public, max-age=31536000, immutable"
+	cacheStatic     = "public, max-age=3600"
+	cacheRevalidate = "no-cache"
+)
+
+// clientConfig is the non-sensitive configuration the frontend needs before login.
+type clientConfig struct {
+	DummyAuth     bool            `json:"dummyAuth"`
+	BasePath      string          `json:"basePath"`
+	APIBaseURL    string          `json:"apiBaseUrl"`
+	PanelProvider *clientProvider `json:"panelProvider,omitempty"`
+	Features      clientFeatures  `json:"features"`
+	Branding      *clientBranding `json:"branding,omitempty"`
+}
+
+type clientProvider struct {
+	Name               string `json:"name"`
+	Issuer             string `json:"issuer"`
+	TokenEndpoint      string `json:"tokenEndpoint,omitempty"`
+	UserinfoEndpoint   string `json:"userinfoEndpoint,omitempty"`
+	EndSessionEndpoint string `json:"endSessionEndpoint,omitempty"`
+	ClientID           string `json:"clientId,omitempty"`
+}
+
+type clientFeatures struct {
+	Gallery bool `json:"gallery"`
+}
+
+type clientBranding struct {
+	Title        string `json:"title,omitempty"`
+	LogoURL      string `json:"logoUrl,omitempty"`
+	FaviconURL   string `json:"faviconUrl,omitempty"`
+	PrimaryColor string `json:"primaryColor,omitempty"`
+}
+
+// newClientConfig builds the client config served at /api/v1/config and injected into index.html.
+func newClientConfig(cfg *config.Config, hasGallery bool) clientConfig {
+	basePath := cfg.Server.NormalizedBasePath()
+	resp := clientConfig{
+		DummyAuth:  cfg.IsDummyAuth(),
+		BasePath:   basePath,
+		APIBaseURL: strings.TrimRight(cfg.Frontend.APIBaseURL, "/"),
+		Features:   clientFeatures{Gallery: hasGallery},
+	}
+	if resp.APIBaseURL == "" {
+		resp.APIBaseURL = basePath + "/api/v1"
+	}
+	if panel := cfg.GetPanelOIDC(); panel != nil {
+		resp.PanelProvider = &clientProvider{
+			Name:               panel.Name,
+			Issuer:             panel.Issuer,
+			TokenEndpoint:      panel.TokenEndpoint,
+			UserinfoEndpoint:   panel.UserinfoEndpoint,
+			EndSessionEndpoint: panel.EndSessionEndpoint,
+			ClientID:           panel.ClientID,
+		}
+	}
+	if b := cfg.Frontend.Branding; b != (config.FrontendBranding{}) {
+		resp.Branding = &clientBranding{
+			Title:        b.Title,
+			LogoURL:      b.LogoURL,
+			FaviconURL:   b.FaviconURL,
+			PrimaryColor: b.PrimaryColor,
+		}
+	}
+	return resp
+}
+
+var (
+	titleTagRegex   = regexp.MustCompile(`(?is)<title>.*?</title>`)
+	faviconTagRegex = regexp.MustCompile(`(?is)<link[^>]*\brel="icon"[^>]*>`)
+	headCloseRegex  = regexp.MustCompile(`(?i)</head>`)
+)
+
+// indexPage is the index.html of the frontend with the client config and branding injected.
+// It is rendered once at startup.
+type indexPage struct {
+	content []byte
+	etag    string
+}
+
+// renderIndexPage injects the client config into the index.html of fsys and applies the title and
+// favicon of the branding. Returns nil when no frontend is served.
+func renderIndexPage(fsys fs.FS, clientCfg clientConfig) (*indexPage, error) {
+	if fsys == nil {
+		return nil, nil
+	}
+	content, err := fs.ReadFile(fsys, "index.html")
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errors.New("frontend: index.html not found at the root of the frontend filesystem (use fs.Sub to root it at the build directory)")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("frontend: reading index.html: %w", err)
+	}
+
+	// json.Marshal escapes <, > and &, so the config cannot close the script element
+	configJSON, err := json.Marshal(clientCfg)
+	if err != nil {
+		return nil, fmt.Errorf("frontend: encoding client config: %w", err)
+	}
+	script := []byte(fmt.Sprintf("<script>window.%s=%s;</script>", frontendConfigGlobal, configJSON))
+
+	if b := clientCfg.Branding; b != nil {
+		if b.Title != "" {
+			title := []byte("<title>" + html.EscapeString(b.Title) + "</title>")
+			content = titleTagRegex.ReplaceAllLiteral(content, title)
+		}
+		if b.FaviconURL != "" {
+			favicon := []byte(`<link rel="icon" href="` + html.EscapeString(b.FaviconURL) + `" />`)
+			content = faviconTagRegex.ReplaceAllLiteral(content, favicon)
+		}
+	}
+
+	if loc := headCloseRegex.FindIndex(content); loc != nil {
+		content = bytes.Join([][]byte{content[:loc[0]], script, content[loc[0]:]}, nil)
+	} else {
+		content = append(script, content...)
+	}
+
+	sum := sha256.Sum256(content)
+	return &indexPage{content: content, etag: `"` + hex.EncodeToString(sum[:8]) + `"`}, nil
+}
+
+// serve writes the page, revalidated on every load so a new config or build is picked up at once.
+func (p *indexPage) serve(c *gin.Context) {
+	c.Header("Cache-Control", cacheRevalidate)
+	c.Header("ETag", p.etag)
+	if c.GetHeader("If-None-Match") == p.etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", p.content)
+}
+
+// stripBasePath removes the basePath prefix from reqPath.
+// Returns the stripped path and true, or empty and false if the prefix doesn't match.
+func stripBasePath(reqPath, basePath string) (string, bool) {
+	if basePath == "" {
+		return reqPath, true
+	}
+	if !strings.HasPrefix(reqPath, basePath) {
+		return "", false
+	}
+	stripped := strings.TrimPrefix(reqPath, basePath)
+	if stripped == "" {
+		return "/", true
+	}
+	return stripped, true
+}
+
+// isBackendPath returns true if the path belongs to backend-owned prefixes.
+func isBackendPath(p string) bool {
+	return strings.HasPrefix(p, "/api/") || strings.HasPrefix(p, "/swagger/")
+}
+
+// spaHandler returns a Gin handler that serves the embedded SPA frontend.
+// Explicit routes (/health, /ready, /api/v1/*) are matched by Gin before NoRoute.
+// This handler only runs for unmatched paths: static files get served with cache
+// headers, unknown paths and directories get index.html (SPA client-side routing).
+// basePath is stripped from the request URL before filesystem lookup.
+func spaHandler(fsys fs.FS, basePath string, index *indexPage) gin.HandlerFunc {
+	var fileServer http.Handler
+	if fsys != nil {
+		fileServer = http.StripPrefix(basePath, http.FileServer(http.FS(fsys)))
+	}
+
+	return func(c *gin.Context) {
+		stripped, ok := stripBasePath(c.Request.URL.Path, basePath)
+		if !ok || isBackendPath(stripped) || index == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+			return
+		}
+
+		// Normalize path for fs lookup
+		cleanPath := path.Clean(strings.TrimPrefix(stripped, "/"))
+		if cleanPath == "." || cleanPath == "" || cleanPath == "index.html" {
+			index.serve(c)
+			return
+		}
+
+		// Try serving the exact file
+		if info, err := fs.Stat(fsys, cleanPath); err == nil && !info.IsDir() {
+			if strings.HasPrefix(cleanPath, "assets/") {
+				c.Header("Cache-Control", cacheImmutable)
+			} else {
+				c.Header("Cache-Control", cacheStatic)
+			}
+			fileServer.ServeHTTP(c.Writer, c.Request)
+			return
+		}
+
+		// SPA fallback → serve index.html
+		index.serve(c)
+	}
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rendis/pdf-forge/core/internal/infra/config"
+)
+
+const testIndexHTML = `<!DOCTYPE html>
+<html>
+  <head>
+    <link rel="icon" type="image/svg+xml" href="/favicon.svg" />
+    <title>PDF Forge</title>
+  </head>
+  <body><div id="root"></div></body>
+</html>`
+
+func testFrontendFS() fstest.MapFS {
+	return fstest.MapFS{
+		"index.html":         {Data: []byte(testIndexHTML)},
+		"favicon.svg":        {Data: []byte("<svg/>")},
+		"assets/app-1a2b.js": {Data: []byte("console.log(1)")},
+	}
+}
+
+func TestRenderIndexPage_InjectsConfigAndBranding(t *testing.T) {
+	cfg := &config.Config{
+		Server: config.ServerConfig{BasePath: "/docs"},
+		Frontend: config.FrontendConfig{Branding: config.FrontendBranding{
+			Title:      "Acme </title> Docs",
+			FaviconURL: "https://cdn.example.com/acme.ico",
+		}},
+	}
+	page, err := renderIndexPage(testFrontendFS(), newClientConfig(cfg, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(page.content)
+
+	for _, want := range []string{
+		`<title>Acme &lt;/title&gt; Docs</title>`,
+		`<link rel="icon" href="https://cdn.example.com/acme.ico" />`,
+		`<script>window.__PDF_FORGE_CONFIG__={`,
+		`"apiBaseUrl":"/docs/api/v1"`,
+		`"title":"Acme \u003c/title\u003e Docs"`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("index.html does not contain %s:\n%s", want, content)
+		}
+	}
+	if strings.Index(content, "<script>") > strings.Index(content, "</head>") {
+		t.Error("config script is not in the head")
+	}
+}
+
+func TestRenderIndexPage_RequiresIndexHTML(t *testing.T) {
+	if _, err := renderIndexPage(fstest.MapFS{"dist/index.html": {}}, clientConfig{}); err == nil {
+		t.Error("expected an error for a filesystem without index.html at its root")
+	}
+	if page, err := renderIndexPage(nil, clientConfig{}); page != nil || err != nil {
+		t.Errorf("got %v, %v for no frontend, want nil, nil", page, err)
+	}
+}
+
+func TestSPAHandler_CachePolicies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	fsys := testFrontendFS()
+	page, err := renderIndexPage(fsys, newClientConfig(&config.Config{}, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine := gin.New()
+	engine.NoRoute(spaHandler(fsys, "", page))
+
+	tests := []struct {
+		path  string
+		cache string
+		index bool
+	}{
+		{"/", cacheRevalidate, true},
+		{"/workspaces/ws-1/templates", cacheRevalidate, true},
+		{"/assets", cacheRevalidate, true},
+		{"/assets/app-1a2b.js", cacheImmutable, false},
+		{"/favicon.svg", cacheStatic, false},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if rec.Code != http.StatusOK {
+			t.Errorf("%s: status %d", tt.path, rec.Code)
+		}
+		if got := rec.Header().Get("Cache-Control"); got != tt.cache {
+			t.Errorf("%s: Cache-Control %q, want %q", tt.path, got, tt.cache)
+		}
+		if isIndex := strings.Contains(rec.Body.String(), frontendConfigGlobal); isIndex != tt.index {
+			t.Errorf("%s: served index.html = %v, want %v", tt.path, isIndex, tt.index)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("If-None-Match", page.etag)
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("revalidation with the current ETag: status %d, want 304", rec.Code)
+	}
+}
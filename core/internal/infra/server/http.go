@@ -3,12 +3,11 @@ package server
 import (
 	"context"
 	"fmt"
-	"io"
 	"io/fs"
 	"log/slog"
 	"mime"
 	"net/http"
-	"path"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,9 +16,13 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 
 	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/controller"
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/mapper"
 	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/middleware"
 	"github.com/rendis/pdf-forge/core/internal/core/port"
+	accessuc "github.com/rendis/pdf-forge/core/internal/core/usecase/access"
+	platformuc "github.com/rendis/pdf-forge/core/internal/core/usecase/platform"
 	"github.com/rendis/pdf-forge/core/internal/infra/config"
+	"github.com/rendis/pdf-forge/core/internal/infra/metrics"
 
 	_ "github.com/rendis/pdf-forge/core/docs" // swagger generated docs
 )
@@ -36,9 +39,11 @@ func init() {
 type HTTPServer struct {
 	engine *gin.Engine
 	config *config.ServerConfig
+	tls    *serverTLS // nil serves plain HTTP
 }
 
 // NewHTTPServer creates a new HTTP server with all routes and middleware configured.
+// Fails when the TLS or frontend configuration is invalid, or a custom route is invalid or clashes with another route.
 func NewHTTPServer(
 	cfg *config.Config,
 	middlewareProvider *middleware.Provider,
@@ -51,11 +56,34 @@ func NewHTTPServer(
 	documentTypeController *controller.DocumentTypeController,
 	renderController *controller.RenderController,
 	galleryController *controller.GalleryController,
+	hostedDocumentController *controller.HostedDocumentController,
+	assetController *controller.AssetController,
+	fontController *controller.FontController,
+	eventWebhookController *controller.EventWebhookController,
+	numberingSequenceController *controller.NumberingSequenceController,
 	globalMiddleware []gin.HandlerFunc,
 	apiMiddleware []gin.HandlerFunc,
+	customRoutes []CustomRoute,
 	renderAuthenticator port.RenderAuthenticator,
+	sessionUC accessuc.AuthSessionUseCase,
+	maintenanceUC platformuc.MaintenanceUseCase,
+	renderCapacity port.RenderCapacityReporter,
+	appMetrics *metrics.Metrics,
+	editorSchema port.EditorSchemaProvider,
 	frontendFS fs.FS,
-) *HTTPServer {
+) (*HTTPServer, error) {
+	serverTLS, err := newServerTLS(cfg.Server)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Frontend.Branding.Validate(); err != nil {
+		return nil, err
+	}
+	index, err := renderIndexPage(frontendFS, newClientConfig(cfg, galleryController != nil))
+	if err != nil {
+		return nil, err
+	}
+
 	// Set Gin mode based on environment
 	if cfg.Environment == "production" {
 		gin.SetMode(gin.ReleaseMode)
@@ -66,6 +94,7 @@ func NewHTTPServer(
 	// Global middleware
 	engine.Use(gin.Recovery())
 	engine.Use(gin.Logger())
+	engine.Use(httpMetricsMiddleware(appMetrics))
 	engine.Use(corsMiddleware(cfg.Server.CORS))
 
 	// User-provided global middleware (after CORS, before routes)
@@ -84,9 +113,20 @@ func NewHTTPServer(
 	base.GET("/health", healthHandler)
 	base.GET("/ready", readyHandler)
 
+	// Prometheus metrics (no panel auth, optional static bearer token)
+	base.GET("/metrics", noCacheAPI(), middleware.StaticToken(cfg.Server.MetricsToken), metricsHandler(appMetrics))
+
 	// Client config endpoint (no auth required)
 	base.GET("/api/v1/config", clientConfigHandler(cfg, galleryController != nil))
 
+	// Maintenance status endpoint (no auth required, lets clients show the maintenance banner)
+	base.GET("/api/v1/maintenance", maintenanceStatusHandler(maintenanceUC))
+
+	// Render capacity for autoscalers (no panel auth, optional static bearer token)
+	capacity := base.Group("/api/v1/system/render-capacity", noCacheAPI(), middleware.StaticToken(cfg.Server.CapacityToken))
+	capacity.GET("", renderCapacityHandler(renderCapacity))
+	capacity.GET("/metrics", renderCapacityMetricsHandler(renderCapacity))
+
 	// Swagger UI (enabled via DOC_ENGINE_SERVER_SWAGGER_UI=true)
 	if cfg.Server.SwaggerUI {
 		base.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -108,6 +148,8 @@ func NewHTTPServer(
 	v1.Use(noCacheAPI())
 	v1.Use(middleware.Operation())
 	v1.Use(middleware.RequestTimeout(requestTimeout))
+	v1.Use(middleware.BodyLimit(cfg.Server.BodyLimits))
+	v1.Use(middleware.Maintenance(maintenanceUC))
 
 	if cfg.IsDummyAuth() {
 		// Dummy auth mode: skip JWT, inject fixed superadmin identity
@@ -118,6 +160,7 @@ func NewHTTPServer(
 		v1.Use(middleware.PanelAuth(cfg))
 		v1.Use(middlewareProvider.IdentityContext())
 		v1.Use(middlewareProvider.SystemRoleContext())
+		v1.Use(middleware.SessionTracking(sessionUC, middleware.UserPrincipal()))
 	}
 
 	// User-provided API middleware (after auth, before controllers)
@@ -130,6 +173,9 @@ func NewHTTPServer(
 			c.JSON(http.StatusOK, gin.H{"message": "pong"})
 		})
 
+		// Document features this build renders, for the editor to disable the rest
+		v1.GET("/editor/schema", editorSchemaHandler(editorSchema))
+
 		// =====================================================
 		// SYSTEM ROUTES - No X-Workspace-ID or X-Tenant-ID required
 		// Requires system roles (SUPERADMIN or PLATFORM_ADMIN)
@@ -168,6 +214,11 @@ func NewHTTPServer(
 		if galleryController != nil {
 			galleryController.RegisterRoutes(v1, middlewareProvider)
 		}
+		hostedDocumentController.RegisterRoutes(v1, middlewareProvider)
+		assetController.RegisterRoutes(v1, middlewareProvider)
+		fontController.RegisterRoutes(v1, middlewareProvider)
+		eventWebhookController.RegisterRoutes(v1, middlewareProvider)
+		numberingSequenceController.RegisterRoutes(v1, middlewareProvider)
 	}
 
 	// =====================================================
@@ -175,35 +226,83 @@ func NewHTTPServer(
 	// Auth priority: dummy > custom RenderAuthenticator > OIDC
 	// Custom authorization via engine.UseAPIMiddleware().
 	// =====================================================
-	renderGroup := base.Group("/api/v1/workspace")
-	renderGroup.Use(noCacheAPI())
-	renderGroup.Use(middleware.Operation())
-	renderGroup.Use(middleware.RequestTimeout(requestTimeout))
-
+	var renderAuth []gin.HandlerFunc
 	switch {
 	case cfg.IsDummyAuth():
-		renderGroup.Use(middleware.DummyAuth())
+		renderAuth = append(renderAuth, middleware.DummyAuth())
 	case renderAuthenticator != nil:
-		renderGroup.Use(middleware.CustomRenderAuth(renderAuthenticator))
+		renderAuth = append(renderAuth,
+			middleware.CustomRenderAuth(renderAuthenticator),
+			middleware.SessionTracking(sessionUC, middleware.APIKeyPrincipal()),
+		)
 	default:
-		renderGroup.Use(middleware.RenderAuth(cfg))
-		renderGroup.Use(middleware.RenderClaimsContext())
+		renderAuth = append(renderAuth,
+			middleware.RenderAuth(cfg),
+			middleware.RenderClaimsContext(),
+			middleware.SessionTracking(sessionUC, middleware.OIDCPrincipal(panelProviderName(cfg))),
+		)
 	}
 
 	// User-provided API middleware for render routes
-	for _, mw := range apiMiddleware {
-		renderGroup.Use(mw)
+	renderAuth = append(renderAuth, apiMiddleware...)
+
+	// Every render API version gets the same routes: handlers implement the latest version and
+	// serve older ones through the compatibility shims registered on their routes.
+	// Batch renders get their own group for a longer timeout than single renders.
+	newRenderGroup := func(version int, timeout time.Duration, versionMiddleware ...gin.HandlerFunc) *gin.RouterGroup {
+		group := base.Group(fmt.Sprintf("/api/v%d/workspace", version))
+		group.Use(noCacheAPI())
+		group.Use(middleware.APIVersion(version))
+		group.Use(versionMiddleware...)
+		group.Use(middleware.Operation())
+		group.Use(middleware.RequestTimeout(timeout))
+		group.Use(middleware.BodyLimit(cfg.Server.BodyLimits))
+		group.Use(middleware.Maintenance(maintenanceUC))
+		group.Use(renderAuth...)
+		return group
+	}
+
+	renderVersions := []struct {
+		version    int
+		middleware []gin.HandlerFunc
+	}{
+		{middleware.APIVersion1, renderAPIV1Deprecation(cfg.Server)},
+		{middleware.APIVersion2, nil},
+	}
+	for _, v := range renderVersions {
+		renderGroup := newRenderGroup(v.version, requestTimeout, v.middleware...)
+		renderController.RegisterWorkspaceRoutes(renderGroup)
+		documentTypeController.RegisterWorkspaceRoutes(renderGroup)
+		renderController.RegisterBatchRoutes(newRenderGroup(v.version, cfg.Server.BatchRenderTimeoutDuration(), v.middleware...))
 	}
 
-	renderController.RegisterWorkspaceRoutes(renderGroup)
+	// =====================================================
+	// PUBLIC ROUTES - No auth, the token in the path is the credential
+	// Preview links and hosted documents shared outside the workspace.
+	// =====================================================
+	publicGroup := base.Group("/api/v1/public")
+	publicGroup.Use(noCacheAPI())
+	publicGroup.Use(middleware.Operation())
+	publicGroup.Use(middleware.RequestTimeout(requestTimeout))
+	publicGroup.Use(middleware.Maintenance(maintenanceUC))
+
+	renderController.RegisterPublicRoutes(publicGroup)
+	hostedDocumentController.RegisterPublicRoutes(publicGroup)
+	assetController.RegisterPublicRoutes(publicGroup)
+
+	// Routes registered through the SDK (global middleware only), last so clashes are reported
+	if err := registerCustomRoutes(base, customRoutes); err != nil {
+		return nil, err
+	}
 
 	// NoRoute handler: serves embedded SPA or returns JSON 404
-	engine.NoRoute(spaHandler(frontendFS, basePath))
+	engine.NoRoute(spaHandler(frontendFS, basePath, index))
 
 	return &HTTPServer{
 		engine: engine,
 		config: &cfg.Server,
-	}
+		tls:    serverTLS,
+	}, nil
 }
 
 // Start starts the HTTP server.
@@ -211,23 +310,49 @@ func (s *HTTPServer) Start(ctx context.Context) error {
 	addr := fmt.Sprintf(":%s", s.config.Port)
 
 	srv := &http.Server{
-		Addr:         addr,
-		Handler:      s.engine,
-		ReadTimeout:  s.config.ReadTimeoutDuration(),
-		WriteTimeout: s.config.WriteTimeoutDuration(),
+		Addr:           addr,
+		Handler:        s.engine,
+		ReadTimeout:    s.config.ReadTimeoutDuration(),
+		WriteTimeout:   s.config.WriteTimeoutDuration(),
+		MaxHeaderBytes: s.config.MaxHeaderBytes(),
+		Protocols:      serverProtocols(*s.config),
 	}
 
 	// Channel to catch server errors
-	errChan := make(chan error, 1)
+	errChan := make(chan error, 2)
 
 	// Start server in goroutine
 	go func() {
-		slog.InfoContext(ctx, "starting HTTP server", slog.String("addr", addr))
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if s.tls != nil {
+			srv.TLSConfig = s.tls.config
+			slog.InfoContext(ctx, "starting HTTPS server", slog.String("addr", addr))
+			err = srv.ListenAndServeTLS("", "") // certificates come from TLSConfig
+		} else {
+			slog.InfoContext(ctx, "starting HTTP server", slog.String("addr", addr))
+			err = srv.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			errChan <- err
 		}
 	}()
 
+	// ACME HTTP-01 challenges and the redirect to HTTPS
+	var challengeSrv *http.Server
+	if s.tls != nil && s.tls.challenge != nil {
+		challengeSrv = &http.Server{
+			Addr:              fmt.Sprintf(":%s", s.config.TLS.ACME.HTTPPort),
+			Handler:           s.tls.challenge,
+			ReadHeaderTimeout: s.config.ReadTimeoutDuration(),
+		}
+		go func() {
+			slog.InfoContext(ctx, "starting ACME challenge server", slog.String("addr", challengeSrv.Addr))
+			if err := challengeSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				errChan <- fmt.Errorf("ACME challenge server: %w", err)
+			}
+		}()
+	}
+
 	// Wait for context cancellation or server error
 	select {
 	case <-ctx.Done():
@@ -235,6 +360,9 @@ func (s *HTTPServer) Start(ctx context.Context) error {
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), s.config.ShutdownTimeoutDuration())
 		defer cancel()
 
+		if challengeSrv != nil {
+			_ = challengeSrv.Shutdown(shutdownCtx)
+		}
 		if err := srv.Shutdown(shutdownCtx); err != nil {
 			return fmt.Errorf("server shutdown: %w", err)
 		}
@@ -270,48 +398,37 @@ func readyHandler(c *gin.Context) {
 
 // clientConfigHandler returns a handler that exposes non-sensitive config to the frontend.
 func clientConfigHandler(cfg *config.Config, hasGallery bool) gin.HandlerFunc {
-	type providerInfo struct {
-		Name               string `json:"name"`
-		Issuer             string `json:"issuer"`
-		TokenEndpoint      string `json:"tokenEndpoint,omitempty"`
-		UserinfoEndpoint   string `json:"userinfoEndpoint,omitempty"`
-		EndSessionEndpoint string `json:"endSessionEndpoint,omitempty"`
-		ClientID           string `json:"clientId,omitempty"`
-	}
-
-	type features struct {
-		Gallery bool `json:"gallery"`
-	}
-
-	type clientConfig struct {
-		DummyAuth     bool          `json:"dummyAuth"`
-		BasePath      string        `json:"basePath"`
-		PanelProvider *providerInfo `json:"panelProvider,omitempty"`
-		Features      features      `json:"features"`
+	resp := newClientConfig(cfg, hasGallery)
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, resp)
 	}
+}
 
-	var panelProvider *providerInfo
-	if panel := cfg.GetPanelOIDC(); panel != nil {
-		panelProvider = &providerInfo{
-			Name:               panel.Name,
-			Issuer:             panel.Issuer,
-			TokenEndpoint:      panel.TokenEndpoint,
-			UserinfoEndpoint:   panel.UserinfoEndpoint,
-			EndSessionEndpoint: panel.EndSessionEndpoint,
-			ClientID:           panel.ClientID,
-		}
+// maintenanceStatusHandler returns a handler that exposes the current maintenance mode.
+func maintenanceStatusHandler(maintenanceUC platformuc.MaintenanceUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		state := maintenanceUC.CurrentState(c.Request.Context())
+		c.JSON(http.StatusOK, mapper.MaintenanceToStatusResponse(state))
 	}
+}
 
-	resp := clientConfig{
-		DummyAuth:     cfg.IsDummyAuth(),
-		BasePath:      cfg.Server.NormalizedBasePath(),
-		PanelProvider: panelProvider,
-		Features:      features{Gallery: hasGallery},
+// renderAPIV1Deprecation returns the middleware announcing the retirement of the v1 render API,
+// none while no date is configured. Invalid dates are logged and ignored so they cannot keep the
+// server from starting.
+func renderAPIV1Deprecation(cfg config.ServerConfig) []gin.HandlerFunc {
+	since, sunset, err := cfg.RenderAPIV1Deprecation.Dates()
+	if err != nil {
+		slog.Error("ignoring invalid render API v1 deprecation dates", slog.Any("error", err))
+		return nil
 	}
-
-	return func(c *gin.Context) {
-		c.JSON(http.StatusOK, resp)
+	if since.IsZero() && sunset.IsZero() {
+		return nil
 	}
+	return []gin.HandlerFunc{middleware.Deprecated(middleware.DeprecationPolicy{
+		Since:     since,
+		Sunset:    sunset,
+		Successor: cfg.PublicBaseURL() + fmt.Sprintf("/api/v%d/workspace", middleware.LatestAPIVersion),
+	})}
 }
 
 // noCacheAPI ensures browsers never cache API responses.
@@ -327,94 +444,6 @@ func noCacheAPI() gin.HandlerFunc {
 	}
 }
 
-// stripBasePath removes the basePath prefix from reqPath.
-// Returns the stripped path and true, or empty and false if the prefix doesn't match.
-func stripBasePath(reqPath, basePath string) (string, bool) {
-	if basePath == "" {
-		return reqPath, true
-	}
-	if !strings.HasPrefix(reqPath, basePath) {
-		return "", false
-	}
-	stripped := strings.TrimPrefix(reqPath, basePath)
-	if stripped == "" {
-		return "/", true
-	}
-	return stripped, true
-}
-
-// isBackendPath returns true if the path belongs to backend-owned prefixes.
-func isBackendPath(p string) bool {
-	return strings.HasPrefix(p, "/api/") || strings.HasPrefix(p, "/swagger/")
-}
-
-// spaHandler returns a Gin handler that serves the embedded SPA frontend.
-// Explicit routes (/health, /ready, /api/v1/*) are matched by Gin before NoRoute.
-// This handler only runs for unmatched paths: static files get served with cache
-// headers, unknown paths get index.html (SPA client-side routing).
-// basePath is stripped from the request URL before filesystem lookup.
-func spaHandler(fsys fs.FS, basePath string) gin.HandlerFunc {
-	var fileServer http.Handler
-	if fsys != nil {
-		fileServer = http.StripPrefix(basePath, http.FileServer(http.FS(fsys)))
-	}
-
-	return func(c *gin.Context) {
-		stripped, ok := stripBasePath(c.Request.URL.Path, basePath)
-		if !ok || isBackendPath(stripped) || fsys == nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
-			return
-		}
-
-		// Normalize path for fs lookup
-		cleanPath := path.Clean(strings.TrimPrefix(stripped, "/"))
-		if cleanPath == "." || cleanPath == "" {
-			cleanPath = "index.html"
-		}
-
-		// Try serving the exact file
-		f, err := fsys.Open(cleanPath)
-		if err == nil {
-			f.Close()
-			if strings.HasPrefix(cleanPath, "assets/") {
-				c.Header("Cache-Control", "public, max-age=31536000, immutable")
-			}
-			fileServer.ServeHTTP(c.Writer, c.Request)
-			return
-		}
-
-		// SPA fallback → serve index.html
-		serveIndexHTML(c, fsys)
-	}
-}
-
-// serveIndexHTML serves index.html with no-cache headers for SPA fallback routing.
-func serveIndexHTML(c *gin.Context, fsys fs.FS) {
-	indexFile, err := fsys.Open("index.html")
-	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
-		return
-	}
-	defer indexFile.Close()
-
-	c.Header("Cache-Control", "no-cache, no-store, must-revalidate")
-	c.Header("Content-Type", "text/html; charset=utf-8")
-	stat, _ := indexFile.Stat()
-
-	if rs, ok := indexFile.(io.ReadSeeker); ok {
-		http.ServeContent(c.Writer, c.Request, "index.html", stat.ModTime(), rs)
-		return
-	}
-
-	// Fallback if fs.File doesn't implement ReadSeeker
-	content, err := io.ReadAll(indexFile)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read index"})
-		return
-	}
-	c.Data(http.StatusOK, "text/html; charset=utf-8", content)
-}
-
 // corsMiddleware configures CORS for the API using allowed origins from config.
 // Access-Control-Allow-Origin only accepts a single origin or "*".
 // When multiple origins are configured, we check the request Origin header
@@ -437,10 +466,21 @@ func corsMiddleware(corsCfg config.CORSConfig) gin.HandlerFunc {
 		"Cache-Control", "Pragma",
 		"X-Workspace-ID", "X-Tenant-ID", "X-Tenant-Code", "X-Workspace-Code",
 		"X-External-ID", "X-Template-ID", "X-Transactional-ID",
-		"X-Environment",
+		"X-Environment", "API-Version",
 	}
 	allowedHeaders := strings.Join(append(baseHeaders, corsCfg.AllowedHeaders...), ", ")
 
+	baseExposed := []string{
+		"Content-Length", "X-Render-Warnings", "X-Render-Warning-Count", "X-Render-Degradation-Count",
+		"X-Render-Injector-Timings", "X-Render-Currency-Rates", "X-Render-Failure-ID", "API-Version", "Deprecation", "Sunset", "Link",
+	}
+	exposedHeaders := strings.Join(append(baseExposed, corsCfg.ExposedHeaders...), ", ")
+
+	var maxAge string
+	if corsCfg.MaxAge > 0 {
+		maxAge = strconv.Itoa(corsCfg.MaxAge)
+	}
+
 	return func(c *gin.Context) {
 		origin := c.GetHeader("Origin")
 
@@ -453,10 +493,15 @@ func corsMiddleware(corsCfg config.CORSConfig) gin.HandlerFunc {
 
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
 		c.Header("Access-Control-Allow-Headers", allowedHeaders)
-		c.Header("Access-Control-Expose-Headers", "Content-Length")
-		c.Header("Access-Control-Allow-Credentials", "true")
+		c.Header("Access-Control-Expose-Headers", exposedHeaders)
+		if corsCfg.AllowCredentials {
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
 
 		if c.Request.Method == "OPTIONS" {
+			if maxAge != "" {
+				c.Header("Access-Control-Max-Age", maxAge)
+			}
 			c.AbortWithStatus(http.StatusNoContent)
 			return
 		}
@@ -464,3 +509,11 @@ func corsMiddleware(corsCfg config.CORSConfig) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// panelProviderName returns the name of the panel OIDC provider, or "" if none is configured.
+func panelProviderName(cfg *config.Config) string {
+	if panel := cfg.GetPanelOIDC(); panel != nil {
+		return panel.Name
+	}
+	return ""
+}
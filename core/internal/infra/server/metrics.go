@@ -0,0 +1,29 @@
+package server
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rendis/pdf-forge/core/internal/infra/metrics"
+)
+
+// metricsHandler returns the metrics of this instance in the Prometheus exposition format.
+func metricsHandler(m *metrics.Metrics) gin.HandlerFunc {
+	return gin.WrapH(m.Handler())
+}
+
+// httpMetricsMiddleware records the latency of each request by method, route pattern and status.
+// Requests that match no route are grouped under "other" to keep the series bounded.
+func httpMetricsMiddleware(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "other"
+		}
+		m.ObserveHTTPRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
@@ -0,0 +1,29 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/mapper"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	"github.com/rendis/pdf-forge/core/internal/infra/metrics"
+)
+
+// renderCapacityHandler returns the render load of this instance as JSON, for KEDA's metrics-api
+// scaler or an HPA external metrics adapter.
+func renderCapacityHandler(reporter port.RenderCapacityReporter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, mapper.RenderCapacityToResponse(reporter.RenderCapacity()))
+	}
+}
+
+// renderCapacityMetricsHandler returns only the render load of this instance in the Prometheus
+// exposition format, for scalers that scrape it apart from /metrics.
+func renderCapacityMetricsHandler(reporter port.RenderCapacityReporter) gin.HandlerFunc {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(metrics.NewRenderCapacityCollector(reporter))
+	return gin.WrapH(promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/rendis/pdf-forge/core/internal/infra/config"
+)
+
+// serverTLS is how the server obtains its certificates when it serves HTTPS.
+type serverTLS struct {
+	config *tls.Config
+	// challenge serves ACME HTTP-01 challenges and redirects other requests to HTTPS; nil when
+	// no ACME HTTP port is configured.
+	challenge http.Handler
+}
+
+// newServerTLS validates the TLS configuration and loads the certificate, so a bad one stops the
+// engine at startup. Returns nil when the server serves plain HTTP.
+func newServerTLS(cfg config.ServerConfig) (*serverTLS, error) {
+	if !cfg.TLS.Enabled() {
+		return nil, nil
+	}
+	if err := cfg.TLS.Validate(); err != nil {
+		return nil, err
+	}
+
+	if !cfg.TLS.ACME.Enabled {
+		cert, err := tls.LoadX509KeyPair(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("server.tls: loading certificate: %w", err)
+		}
+		return &serverTLS{config: &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{cert},
+		}}, nil
+	}
+
+	acmeCfg := cfg.TLS.ACME
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		Cache:      autocert.DirCache(acmeCfg.CacheDir),
+		HostPolicy: autocert.HostWhitelist(acmeCfg.Domains...),
+		Email:      acmeCfg.Email,
+	}
+	if acmeCfg.DirectoryURL != "" {
+		manager.Client = &acme.Client{DirectoryURL: acmeCfg.DirectoryURL}
+	}
+
+	tlsConfig := manager.TLSConfig()
+	if !cfg.HTTP2 {
+		tlsConfig.NextProtos = slices.DeleteFunc(tlsConfig.NextProtos, func(p string) bool { return p == "h2" })
+	}
+	result := &serverTLS{config: tlsConfig}
+	if acmeCfg.HTTPPort != "" {
+		result.challenge = manager.HTTPHandler(nil)
+	}
+	return result, nil
+}
+
+// serverProtocols returns the HTTP versions the server accepts. HTTP/2 over TLS is negotiated
+// with ALPN; unencrypted HTTP/2 needs clients that use it with prior knowledge.
+func serverProtocols(cfg config.ServerConfig) *http.Protocols {
+	var protocols http.Protocols
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(cfg.HTTP2)
+	protocols.SetUnencryptedHTTP2(cfg.H2C)
+	return &protocols
+}
@@ -0,0 +1,180 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rendis/pdf-forge/core/internal/infra/config"
+)
+
+func TestNewServerTLS_PlainHTTP(t *testing.T) {
+	got, err := newServerTLS(config.ServerConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != nil {
+		t.Errorf("newServerTLS() = %+v, want nil without TLS", got)
+	}
+}
+
+func TestNewServerTLS_CertificateFiles(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t)
+
+	got, err := newServerTLS(config.ServerConfig{TLS: config.TLSConfig{CertFile: certFile, KeyFile: keyFile}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.config.Certificates) != 1 {
+		t.Errorf("certificates = %d, want 1", len(got.config.Certificates))
+	}
+	if got.config.MinVersion != tls.VersionTLS12 {
+		t.Errorf("MinVersion = %x, want TLS 1.2", got.config.MinVersion)
+	}
+	if got.challenge != nil {
+		t.Error("certificate files serve no ACME challenges")
+	}
+}
+
+func TestNewServerTLS_ACME(t *testing.T) {
+	tests := []struct {
+		name          string
+		http2         bool
+		httpPort      string
+		wantH2        bool
+		wantChallenge bool
+	}{
+		{name: "http2 with challenge port", http2: true, httpPort: ":80", wantH2: true, wantChallenge: true},
+		{name: "http1 only", http2: false, wantH2: false},
+		{name: "tls-alpn challenges only", http2: true, wantH2: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newServerTLS(config.ServerConfig{
+				HTTP2: tt.http2,
+				TLS: config.TLSConfig{ACME: config.ACMEConfig{
+					Enabled:  true,
+					Domains:  []string{"docs.example.com"},
+					CacheDir: t.TempDir(),
+					HTTPPort: tt.httpPort,
+				}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if h2 := slices.Contains(got.config.NextProtos, "h2"); h2 != tt.wantH2 {
+				t.Errorf("NextProtos = %v, want h2 %v", got.config.NextProtos, tt.wantH2)
+			}
+			if !slices.Contains(got.config.NextProtos, "acme-tls/1") {
+				t.Errorf("NextProtos = %v, want the TLS-ALPN-01 protocol", got.config.NextProtos)
+			}
+			if (got.challenge != nil) != tt.wantChallenge {
+				t.Errorf("challenge handler set = %v, want %v", got.challenge != nil, tt.wantChallenge)
+			}
+		})
+	}
+}
+
+func TestNewServerTLS_InvalidConfig(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t)
+
+	tests := []struct {
+		name    string
+		tls     config.TLSConfig
+		wantErr string
+	}{
+		{name: "cert without key", tls: config.TLSConfig{CertFile: certFile}, wantErr: "must be set together"},
+		{
+			name:    "files and acme",
+			tls:     config.TLSConfig{CertFile: certFile, KeyFile: keyFile, ACME: config.ACMEConfig{Enabled: true}},
+			wantErr: "mutually exclusive",
+		},
+		{name: "acme without domains", tls: config.TLSConfig{ACME: config.ACMEConfig{Enabled: true, CacheDir: "/tmp"}}, wantErr: "domains are required"},
+		{name: "acme without cache", tls: config.TLSConfig{ACME: config.ACMEConfig{Enabled: true, Domains: []string{"a.example"}}}, wantErr: "cache_dir is required"},
+		{name: "unreadable certificate", tls: config.TLSConfig{CertFile: filepath.Join(t.TempDir(), "missing.pem"), KeyFile: keyFile}, wantErr: "loading certificate"},
+		{name: "key of another format", tls: config.TLSConfig{CertFile: certFile, KeyFile: certFile}, wantErr: "loading certificate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newServerTLS(config.ServerConfig{TLS: tt.tls})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("newServerTLS() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServerProtocols(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       config.ServerConfig
+		wantHTTP2 bool
+		wantH2C   bool
+	}{
+		{name: "http1 only"},
+		{name: "http2", cfg: config.ServerConfig{HTTP2: true}, wantHTTP2: true},
+		{name: "http2 and h2c", cfg: config.ServerConfig{HTTP2: true, H2C: true}, wantHTTP2: true, wantH2C: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := serverProtocols(tt.cfg)
+			if !got.HTTP1() {
+				t.Error("HTTP/1 is always served")
+			}
+			if got.HTTP2() != tt.wantHTTP2 {
+				t.Errorf("HTTP2() = %v, want %v", got.HTTP2(), tt.wantHTTP2)
+			}
+			if got.UnencryptedHTTP2() != tt.wantH2C {
+				t.Errorf("UnencryptedHTTP2() = %v, want %v", got.UnencryptedHTTP2(), tt.wantH2C)
+			}
+		})
+	}
+}
+
+// writeTestCertificate writes a self-signed certificate and its key as PEM files.
+func writeTestCertificate(t *testing.T) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	writePEM(t, certFile, "CERTIFICATE", der)
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+	return certFile, keyFile
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,389 @@
+package migrations
+
+import (
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LintBaselineVersion is the last migration written before linting existed.
+// Findings in migrations up to this version are reported but never block:
+// they are already applied everywhere and must not be edited.
+const LintBaselineVersion = 17
+
+// Class tells how a migration affects a database serving traffic.
+type Class string
+
+const (
+	// ClassOnline migrations only touch tables they create, or use non-blocking operations.
+	ClassOnline Class = "online"
+	// ClassLocking migrations take brief exclusive locks on existing tables.
+	// They run under lock_timeout and are retried when the lock is not granted in time.
+	ClassLocking Class = "locking"
+)
+
+// Severity of a lint finding.
+type Severity string
+
+const (
+	// SeverityLocking findings take a brief lock; they are allowed and make the migration locking.
+	SeverityLocking Severity = "locking"
+	// SeverityDangerous findings block or break a busy database and fail the lint
+	// unless acknowledged with a "-- migrate:allow <rule> <reason>" directive.
+	SeverityDangerous Severity = "dangerous"
+)
+
+// Lint rules.
+const (
+	RuleIndexNotConcurrent      = "index-not-concurrent"
+	RuleConcurrentInTransaction = "concurrent-in-transaction"
+	RuleConstraintNotValid      = "constraint-not-valid"
+	RuleConstraintBuildsIndex   = "constraint-builds-index"
+	RuleSetNotNull              = "set-not-null"
+	RuleColumnTypeChange        = "column-type-change"
+	RuleAddColumnNotNull        = "add-column-not-null"
+	RuleVolatileDefault         = "volatile-default"
+	RuleDestructiveChange       = "destructive-change"
+	RuleTableRewrite            = "table-rewrite"
+	RuleAlterExistingTable      = "alter-existing-table"
+)
+
+// ruleHints explains how to rewrite each dangerous pattern in an expand/contract friendly way.
+var ruleHints = map[string]string{
+	RuleIndexNotConcurrent:      "use CREATE INDEX CONCURRENTLY in a migration of its own",
+	RuleConcurrentInTransaction: "CONCURRENTLY cannot run inside the implicit transaction of a multi-statement file; move it to its own migration",
+	RuleConstraintNotValid:      "add the constraint NOT VALID, then VALIDATE CONSTRAINT in a later migration",
+	RuleConstraintBuildsIndex:   "CREATE UNIQUE INDEX CONCURRENTLY first, then ADD CONSTRAINT ... USING INDEX",
+	RuleSetNotNull:              "add a CHECK (col IS NOT NULL) NOT VALID constraint, validate it, then SET NOT NULL",
+	RuleColumnTypeChange:        "add a new column, backfill it, switch readers, then drop the old one",
+	RuleAddColumnNotNull:        "add the column with a constant DEFAULT, or nullable and backfill it",
+	RuleVolatileDefault:         "add the column without default, backfill it in batches, then set the default",
+	RuleDestructiveChange:       "contract step: only once no running release uses it; acknowledge with migrate:allow",
+	RuleTableRewrite:            "rewrites or locks the whole table; run it manually in a maintenance window",
+}
+
+// Migration is an embedded up migration.
+type Migration struct {
+	Version uint
+	Name    string
+	SQL     string
+}
+
+// Finding is a lint result for one statement of a migration.
+type Finding struct {
+	Rule      string
+	Severity  Severity
+	Table     string
+	Statement string
+	Allowed   bool // Acknowledged with a migrate:allow directive
+}
+
+// String formats the finding for logs and test output.
+func (f Finding) String() string {
+	msg := fmt.Sprintf("[%s] %s on %s: %s", f.Severity, f.Rule, f.Table, f.Statement)
+	if hint, ok := ruleHints[f.Rule]; ok {
+		msg += " (" + hint + ")"
+	}
+	return msg
+}
+
+// Report is the lint result of a migration.
+type Report struct {
+	Migration Migration
+	Class     Class
+	Findings  []Finding
+}
+
+// Blocking returns the dangerous findings that were not acknowledged.
+// Migrations up to LintBaselineVersion never block.
+func (r Report) Blocking() []Finding {
+	if r.Migration.Version <= LintBaselineVersion {
+		return nil
+	}
+	var blocking []Finding
+	for _, f := range r.Findings {
+		if f.Severity == SeverityDangerous && !f.Allowed {
+			blocking = append(blocking, f)
+		}
+	}
+	return blocking
+}
+
+// Retryable reports whether a failed attempt rolls back entirely, so the migration can be retried.
+// Files using CONCURRENTLY run outside a transaction and may leave invalid indexes behind.
+func (r Report) Retryable() bool {
+	return !concurrentlyPattern.MatchString(stripComments(r.Migration.SQL))
+}
+
+var (
+	fileNamePattern     = regexp.MustCompile(`^(\d+)_(.+)\.up\.sql$`)
+	allowPattern        = regexp.MustCompile(`(?im)^\s*--\s*migrate:allow\s+([a-z-]+)\s+\S`)
+	concurrentlyPattern = regexp.MustCompile(`(?i)\bCONCURRENTLY\b`)
+
+	createTablePattern = regexp.MustCompile(`(?is)^CREATE\s+(?:UNLOGGED\s+)?TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?([\w."]+)`)
+	createIndexPattern = regexp.MustCompile(`(?is)^CREATE\s+(?:UNIQUE\s+)?INDEX\s+(CONCURRENTLY\s+)?(?:IF\s+NOT\s+EXISTS\s+)?(?:[\w"]+\s+)?ON\s+(?:ONLY\s+)?([\w."]+)`)
+	alterTablePattern  = regexp.MustCompile(`(?is)^ALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?(?:ONLY\s+)?([\w."]+)\s+(.*)$`)
+	dropTablePattern   = regexp.MustCompile(`(?is)^DROP\s+TABLE\s+(?:IF\s+EXISTS\s+)?([\w."]+)`)
+	rewritePattern     = regexp.MustCompile(`(?is)^(VACUUM\s+FULL|CLUSTER|LOCK\s+TABLE|REINDEX)\b`)
+
+	addConstraintPattern = regexp.MustCompile(`(?is)\bADD\s+(?:CONSTRAINT\s+[\w"]+\s+)?(FOREIGN\s+KEY|CHECK|UNIQUE|PRIMARY\s+KEY|EXCLUDE)\b`)
+	notValidPattern      = regexp.MustCompile(`(?is)\bNOT\s+VALID\b`)
+	usingIndexPattern    = regexp.MustCompile(`(?is)\bUSING\s+INDEX\b`)
+	setNotNullPattern    = regexp.MustCompile(`(?is)\bALTER\s+(?:COLUMN\s+)?[\w"]+\s+SET\s+NOT\s+NULL\b`)
+	typeChangePattern    = regexp.MustCompile(`(?is)\bALTER\s+(?:COLUMN\s+)?[\w"]+\s+(?:SET\s+DATA\s+)?TYPE\b`)
+	addColumnPattern     = regexp.MustCompile(`(?is)\bADD\s+(?:COLUMN\s+)?(?:IF\s+NOT\s+EXISTS\s+)?([\w"]+)\s+([^,]*)`)
+	notNullPattern       = regexp.MustCompile(`(?is)\bNOT\s+NULL\b`)
+	defaultPattern       = regexp.MustCompile(`(?is)\bDEFAULT\b`)
+	volatilePattern      = regexp.MustCompile(`(?is)\bDEFAULT\s+(gen_random_uuid|uuid_generate_v[14]|random|clock_timestamp|timeofday)\s*\(`)
+	destructivePattern   = regexp.MustCompile(`(?is)\b(DROP\s+COLUMN|RENAME\s+(?:COLUMN\s+|CONSTRAINT\s+)?[\w"]+\s+TO|RENAME\s+TO)\b`)
+)
+
+// addColumnKeywords are words after ADD that start a constraint, not a column definition.
+var addColumnKeywords = map[string]bool{
+	"CONSTRAINT": true, "FOREIGN": true, "CHECK": true, "UNIQUE": true, "PRIMARY": true, "EXCLUDE": true,
+}
+
+// Load returns the embedded up migrations ordered by version.
+func Load() ([]Migration, error) {
+	return loadFS(sqlFiles, "sql")
+}
+
+func loadFS(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading migrations: %w", err)
+	}
+
+	var result []Migration
+	for _, entry := range entries {
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parsing migration version %q: %w", entry.Name(), err)
+		}
+		content, err := fs.ReadFile(fsys, dir+"/"+entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("reading migration %q: %w", entry.Name(), err)
+		}
+		result = append(result, Migration{Version: uint(version), Name: match[2], SQL: string(content)})
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result, nil
+}
+
+// Lint classifies a migration and reports operations that are unsafe on a busy database.
+// Tables created by the migration itself are new and can be changed freely.
+func Lint(m Migration) Report {
+	allowed := make(map[string]bool)
+	for _, match := range allowPattern.FindAllStringSubmatch(m.SQL, -1) {
+		allowed[match[1]] = true
+	}
+
+	statements := splitStatements(stripComments(m.SQL))
+	newTables := make(map[string]bool)
+	var findings []Finding
+	add := func(rule string, severity Severity, table, stmt string) {
+		findings = append(findings, Finding{
+			Rule:      rule,
+			Severity:  severity,
+			Table:     table,
+			Statement: abbreviate(stmt),
+			Allowed:   allowed[rule],
+		})
+	}
+
+	for _, stmt := range statements {
+		if match := createTablePattern.FindStringSubmatch(stmt); match != nil {
+			newTables[normalizeName(match[1])] = true
+			continue
+		}
+
+		if match := createIndexPattern.FindStringSubmatch(stmt); match != nil {
+			table := normalizeName(match[2])
+			concurrent := match[1] != ""
+			switch {
+			case concurrent && len(statements) > 1:
+				add(RuleConcurrentInTransaction, SeverityDangerous, table, stmt)
+			case !concurrent && !newTables[table]:
+				add(RuleIndexNotConcurrent, SeverityDangerous, table, stmt)
+			}
+			continue
+		}
+
+		if match := alterTablePattern.FindStringSubmatch(stmt); match != nil {
+			table := normalizeName(match[1])
+			if newTables[table] {
+				continue
+			}
+			lintAlterTable(table, stmt, match[2], add)
+			continue
+		}
+
+		if match := dropTablePattern.FindStringSubmatch(stmt); match != nil {
+			table := normalizeName(match[1])
+			if !newTables[table] {
+				add(RuleDestructiveChange, SeverityDangerous, table, stmt)
+			}
+			continue
+		}
+
+		if rewritePattern.MatchString(stmt) && !concurrentlyPattern.MatchString(stmt) {
+			add(RuleTableRewrite, SeverityDangerous, "-", stmt)
+		}
+	}
+
+	class := ClassOnline
+	if len(findings) > 0 {
+		class = ClassLocking
+	}
+	return Report{Migration: m, Class: class, Findings: findings}
+}
+
+// lintAlterTable checks the actions of an ALTER TABLE on an existing table.
+func lintAlterTable(table, stmt, actions string, add func(rule string, severity Severity, table, stmt string)) {
+	found := false
+	flag := func(rule string) {
+		add(rule, SeverityDangerous, table, stmt)
+		found = true
+	}
+
+	if match := addConstraintPattern.FindStringSubmatch(actions); match != nil {
+		kind := strings.ToUpper(strings.Join(strings.Fields(match[1]), " "))
+		switch {
+		case kind == "FOREIGN KEY" || kind == "CHECK":
+			if !notValidPattern.MatchString(actions) {
+				flag(RuleConstraintNotValid)
+			}
+		case !usingIndexPattern.MatchString(actions):
+			flag(RuleConstraintBuildsIndex)
+		}
+	}
+	if setNotNullPattern.MatchString(actions) {
+		flag(RuleSetNotNull)
+	}
+	if typeChangePattern.MatchString(actions) {
+		flag(RuleColumnTypeChange)
+	}
+	for _, match := range addColumnPattern.FindAllStringSubmatch(actions, -1) {
+		if addColumnKeywords[strings.ToUpper(strings.Trim(match[1], `"`))] {
+			continue
+		}
+		definition := match[2]
+		if notNullPattern.MatchString(definition) && !defaultPattern.MatchString(definition) {
+			flag(RuleAddColumnNotNull)
+		}
+		if volatilePattern.MatchString(definition) {
+			flag(RuleVolatileDefault)
+		}
+	}
+	if destructivePattern.MatchString(actions) {
+		flag(RuleDestructiveChange)
+	}
+
+	if !found {
+		add(RuleAlterExistingTable, SeverityLocking, table, stmt)
+	}
+}
+
+// stripComments removes SQL comments, keeping quoted strings intact.
+func stripComments(sql string) string {
+	var b strings.Builder
+	inQuote := false
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == '\'':
+			inQuote = !inQuote
+			b.WriteByte(c)
+		case !inQuote && c == '-' && i+1 < len(sql) && sql[i+1] == '-':
+			for i < len(sql) && sql[i] != '\n' {
+				i++
+			}
+			b.WriteByte('\n')
+		case !inQuote && c == '/' && i+1 < len(sql) && sql[i+1] == '*':
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				return b.String()
+			}
+			i += end + 3
+			b.WriteByte(' ')
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// splitStatements splits a comment-free script on semicolons outside quotes and dollar-quoted bodies.
+func splitStatements(sql string) []string {
+	var statements []string
+	var b strings.Builder
+	inQuote := false
+	dollarTag := ""
+
+	flush := func() {
+		if stmt := strings.Join(strings.Fields(b.String()), " "); stmt != "" {
+			statements = append(statements, stmt)
+		}
+		b.Reset()
+	}
+
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case dollarTag != "":
+			if strings.HasPrefix(sql[i:], dollarTag) {
+				b.WriteString(dollarTag)
+				i += len(dollarTag) - 1
+				dollarTag = ""
+				continue
+			}
+		case c == '\'':
+			inQuote = !inQuote
+		case !inQuote && c == '$':
+			if end := strings.IndexByte(sql[i+1:], '$'); end >= 0 && isDollarTag(sql[i+1:i+1+end]) {
+				dollarTag = sql[i : i+end+2]
+				b.WriteString(dollarTag)
+				i += end + 1
+				continue
+			}
+		case !inQuote && c == ';':
+			flush()
+			continue
+		}
+		b.WriteByte(c)
+	}
+	flush()
+	return statements
+}
+
+// isDollarTag reports whether s is a valid dollar-quote tag body (possibly empty).
+func isDollarTag(s string) bool {
+	for _, r := range s {
+		if r != '_' && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeName lowercases an identifier and strips quotes.
+func normalizeName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, `"`, ""))
+}
+
+// abbreviate shortens a statement for messages.
+func abbreviate(stmt string) string {
+	const maxLen = 120
+	if len(stmt) <= maxLen {
+		return stmt
+	}
+	return stmt[:maxLen] + "…"
+}
@@ -0,0 +1,189 @@
+package migrations
+
+import (
+	"testing"
+)
+
+// TestEmbeddedMigrationsAreSafe is the CI gate: new migrations must not contain
+// dangerous patterns unless acknowledged with a migrate:allow directive.
+func TestEmbeddedMigrationsAreSafe(t *testing.T) {
+	all, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(all) == 0 {
+		t.Fatal("no embedded migrations found")
+	}
+
+	for _, m := range all {
+		report := Lint(m)
+		for _, f := range report.Blocking() {
+			t.Errorf("%06d_%s: %s", m.Version, m.Name, f)
+		}
+	}
+}
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name      string
+		sql       string
+		wantRule  string // Empty when no finding is expected
+		wantClass Class
+		blocking  bool
+	}{
+		{
+			name:      "new table with constraints and index",
+			sql:       "CREATE TABLE app.items (id UUID, name TEXT NOT NULL);\nALTER TABLE app.items ADD CONSTRAINT uq_items_name UNIQUE (name);\nCREATE INDEX idx_items_name ON app.items (name);",
+			wantClass: ClassOnline,
+		},
+		{
+			name:      "index on existing table",
+			sql:       "CREATE INDEX idx_users_email ON identity.users (email);",
+			wantRule:  RuleIndexNotConcurrent,
+			wantClass: ClassLocking,
+			blocking:  true,
+		},
+		{
+			name:      "concurrent index alone",
+			sql:       "-- Migration: index\nCREATE INDEX CONCURRENTLY idx_users_email ON identity.users (email);",
+			wantClass: ClassOnline,
+		},
+		{
+			name:      "concurrent index with other statements",
+			sql:       "CREATE TABLE app.a (id INT);\nCREATE INDEX CONCURRENTLY idx_users_email ON identity.users (email);",
+			wantRule:  RuleConcurrentInTransaction,
+			wantClass: ClassLocking,
+			blocking:  true,
+		},
+		{
+			name:      "foreign key without NOT VALID",
+			sql:       "ALTER TABLE identity.users ADD CONSTRAINT fk_x FOREIGN KEY (x) REFERENCES a.b(id);",
+			wantRule:  RuleConstraintNotValid,
+			wantClass: ClassLocking,
+			blocking:  true,
+		},
+		{
+			name:      "foreign key NOT VALID",
+			sql:       "ALTER TABLE identity.users ADD CONSTRAINT fk_x FOREIGN KEY (x) REFERENCES a.b(id) NOT VALID;",
+			wantRule:  RuleAlterExistingTable,
+			wantClass: ClassLocking,
+		},
+		{
+			name:      "unique constraint builds index",
+			sql:       "ALTER TABLE identity.users ADD CONSTRAINT uq_x UNIQUE (x);",
+			wantRule:  RuleConstraintBuildsIndex,
+			wantClass: ClassLocking,
+			blocking:  true,
+		},
+		{
+			name:      "unique constraint using existing index",
+			sql:       "ALTER TABLE identity.users ADD CONSTRAINT uq_x UNIQUE USING INDEX idx_x;",
+			wantRule:  RuleAlterExistingTable,
+			wantClass: ClassLocking,
+		},
+		{
+			name:      "set not null",
+			sql:       "ALTER TABLE identity.users ALTER COLUMN email SET NOT NULL;",
+			wantRule:  RuleSetNotNull,
+			wantClass: ClassLocking,
+			blocking:  true,
+		},
+		{
+			name:      "column type change",
+			sql:       "ALTER TABLE identity.users ALTER COLUMN email TYPE TEXT;",
+			wantRule:  RuleColumnTypeChange,
+			wantClass: ClassLocking,
+			blocking:  true,
+		},
+		{
+			name:      "not null column without default",
+			sql:       "ALTER TABLE identity.users ADD COLUMN status VARCHAR(20) NOT NULL;",
+			wantRule:  RuleAddColumnNotNull,
+			wantClass: ClassLocking,
+			blocking:  true,
+		},
+		{
+			name:      "not null column with constant default",
+			sql:       "ALTER TABLE identity.users ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE';",
+			wantRule:  RuleAlterExistingTable,
+			wantClass: ClassLocking,
+		},
+		{
+			name:      "volatile default",
+			sql:       "ALTER TABLE identity.users ADD COLUMN token UUID DEFAULT gen_random_uuid();",
+			wantRule:  RuleVolatileDefault,
+			wantClass: ClassLocking,
+			blocking:  true,
+		},
+		{
+			name:      "drop column",
+			sql:       "ALTER TABLE identity.users DROP COLUMN legacy;",
+			wantRule:  RuleDestructiveChange,
+			wantClass: ClassLocking,
+			blocking:  true,
+		},
+		{
+			name:      "drop column acknowledged",
+			sql:       "-- migrate:allow destructive-change column unused since v2.3\nALTER TABLE identity.users DROP COLUMN legacy;",
+			wantRule:  RuleDestructiveChange,
+			wantClass: ClassLocking,
+		},
+		{
+			name:      "drop existing table",
+			sql:       "DROP TABLE IF EXISTS identity.legacy CASCADE;",
+			wantRule:  RuleDestructiveChange,
+			wantClass: ClassLocking,
+			blocking:  true,
+		},
+		{
+			name:      "lock table",
+			sql:       "LOCK TABLE identity.users IN ACCESS EXCLUSIVE MODE;",
+			wantRule:  RuleTableRewrite,
+			wantClass: ClassLocking,
+			blocking:  true,
+		},
+		{
+			name:      "statements inside function body and strings are ignored",
+			sql:       "CREATE FUNCTION app.f() RETURNS void AS $fn$ BEGIN ALTER TABLE identity.users DROP COLUMN x; END; $fn$ LANGUAGE plpgsql;\nCOMMENT ON TABLE app.t IS 'DROP TABLE x; -- nope';",
+			wantClass: ClassOnline,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Lint(Migration{Version: LintBaselineVersion + 1, Name: "test", SQL: tt.sql})
+
+			if report.Class != tt.wantClass {
+				t.Errorf("Class = %s, want %s (findings: %v)", report.Class, tt.wantClass, report.Findings)
+			}
+			if tt.wantRule == "" && len(report.Findings) > 0 {
+				t.Errorf("unexpected findings: %v", report.Findings)
+			}
+			if tt.wantRule != "" && (len(report.Findings) == 0 || report.Findings[0].Rule != tt.wantRule) {
+				t.Errorf("findings = %v, want rule %s", report.Findings, tt.wantRule)
+			}
+			if got := len(report.Blocking()) > 0; got != tt.blocking {
+				t.Errorf("blocking = %v, want %v (findings: %v)", got, tt.blocking, report.Findings)
+			}
+		})
+	}
+}
+
+func TestLintBaselineNeverBlocks(t *testing.T) {
+	report := Lint(Migration{Version: LintBaselineVersion, Name: "old", SQL: "ALTER TABLE a.b DROP COLUMN c;"})
+	if len(report.Findings) == 0 {
+		t.Fatal("expected findings to be reported for baseline migrations")
+	}
+	if blocking := report.Blocking(); len(blocking) > 0 {
+		t.Errorf("baseline migration blocked: %v", blocking)
+	}
+}
+
+func TestReportRetryable(t *testing.T) {
+	if !Lint(Migration{SQL: "ALTER TABLE a.b ADD COLUMN c TEXT;"}).Retryable() {
+		t.Error("transactional migration should be retryable")
+	}
+	if Lint(Migration{SQL: "CREATE INDEX CONCURRENTLY idx ON a.b (c);"}).Retryable() {
+		t.Error("CONCURRENTLY migration should not be retryable")
+	}
+}
@@ -1,12 +1,19 @@
 package migrations
 
 import (
+	"context"
 	"embed"
+	"errors"
 	"fmt"
+	"log/slog"
+	"os"
+	"time"
 
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
 	_ "github.com/golang-migrate/migrate/v4/database/pgx/v5"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5/pgconn"
 
 	"github.com/rendis/pdf-forge/core/internal/infra/config"
 )
@@ -14,32 +21,157 @@ import (
 //go:embed sql/*.sql
 var sqlFiles embed.FS
 
+// retryBackoff is the wait before the first retry of a migration that timed out on a lock.
+// It doubles on every attempt.
+const retryBackoff = 2 * time.Second
+
+// PostgreSQL error codes raised when a lock cannot be acquired.
+const (
+	pgLockNotAvailable = "55P03"
+	pgDeadlockDetected = "40P01"
+)
+
 // Run applies all pending migrations to the database.
-func Run(cfg *config.DatabaseConfig) error {
+// Pending migrations are linted first and dangerous ones are refused. Each migration then runs
+// with lock_timeout so it fails fast instead of queuing traffic behind it, and transactional
+// migrations that timed out on a lock are retried with backoff. Canceling ctx stops the
+// wait between retries.
+func Run(ctx context.Context, cfg *config.DatabaseConfig) error {
+	all, err := Load()
+	if err != nil {
+		return err
+	}
+
 	src, err := iofs.New(sqlFiles, "sql")
 	if err != nil {
 		return fmt.Errorf("loading embedded migrations: %w", err)
 	}
 
-	connURL := fmt.Sprintf("pgx5://%s:%s@%s:%d/%s?sslmode=%s",
-		cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Name, cfg.SSLMode,
-	)
-
-	m, err := migrate.NewWithSourceInstance("iofs", src, connURL)
+	m, err := migrate.NewWithSourceInstance("iofs", src, connectionURL(cfg))
 	if err != nil {
 		return fmt.Errorf("creating migrate instance: %w", err)
 	}
 	defer m.Close()
 
-	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
-		return fmt.Errorf("running migrations: %w", err)
+	current, dirty, err := m.Version()
+	hasVersion := err == nil
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return fmt.Errorf("reading migration version: %w", err)
 	}
-
-	v, dirty, _ := m.Version()
 	if dirty {
-		return fmt.Errorf("migration version %d is dirty — manual intervention required", v)
+		return fmt.Errorf("migration version %d is dirty — manual intervention required", current)
+	}
+
+	pending := make([]Report, 0, len(all))
+	for _, mig := range all {
+		if hasVersion && mig.Version <= current {
+			continue
+		}
+		report := Lint(mig)
+		if blocking := report.Blocking(); len(blocking) > 0 {
+			return fmt.Errorf("migration %d_%s is unsafe to apply online: %s", mig.Version, mig.Name, blocking[0])
+		}
+		pending = append(pending, report)
+	}
+
+	if len(pending) == 0 {
+		fmt.Printf("No pending migrations (version: %d)\n", current)
+		return nil
 	}
 
-	fmt.Printf("Migrations applied successfully (version: %d)\n", v)
+	prev := -1
+	if hasVersion {
+		prev = int(current)
+	}
+	for _, report := range pending {
+		if err := applyNext(ctx, m, cfg, report, prev); err != nil {
+			return err
+		}
+		prev = int(report.Migration.Version)
+	}
+
+	fmt.Printf("Migrations applied successfully (version: %d)\n", prev)
 	return nil
 }
+
+// applyNext applies the next migration, retrying when it gave up waiting for a lock.
+// prev is the version to restore before a retry (-1 when the database had no version).
+func applyNext(ctx context.Context, m *migrate.Migrate, cfg *config.DatabaseConfig, report Report, prev int) error {
+	mig := report.Migration
+	backoff := retryBackoff
+
+	for attempt := 0; ; attempt++ {
+		start := time.Now()
+		err := m.Steps(1)
+		if err == nil {
+			slog.InfoContext(ctx, "migration applied",
+				slog.Uint64("version", uint64(mig.Version)),
+				slog.String("name", mig.Name),
+				slog.String("class", string(report.Class)),
+				slog.Duration("duration", time.Since(start)),
+			)
+			return nil
+		}
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		if !isLockTimeout(err) || !report.Retryable() || attempt >= cfg.MigrationMaxRetries {
+			return fmt.Errorf("applying migration %d_%s: %w", mig.Version, mig.Name, err)
+		}
+
+		// The migration ran in a single transaction that was rolled back; only the dirty flag remains.
+		if err := m.Force(prev); err != nil {
+			return fmt.Errorf("resetting migration %d_%s after lock timeout: %w", mig.Version, mig.Name, err)
+		}
+
+		slog.WarnContext(ctx, "migration timed out waiting for a lock, retrying",
+			slog.Uint64("version", uint64(mig.Version)),
+			slog.String("name", mig.Name),
+			slog.Int("attempt", attempt+1),
+			slog.Duration("backoff", backoff),
+		)
+		if err := wait(ctx, backoff); err != nil {
+			return fmt.Errorf("retrying migration %d_%s: %w", mig.Version, mig.Name, err)
+		}
+		backoff *= 2
+	}
+}
+
+// wait blocks for d, or until ctx is done.
+func wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// connectionURL builds the migrate URL. lock_timeout is passed to PostgreSQL as a
+// session parameter, so every statement gives up instead of waiting behind long transactions.
+func connectionURL(cfg *config.DatabaseConfig) string {
+	connURL := fmt.Sprintf("pgx5://%s:%s@%s:%d/%s?sslmode=%s",
+		cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Name, cfg.SSLMode,
+	)
+	if timeout := cfg.MigrationLockTimeout(); timeout > 0 {
+		connURL += fmt.Sprintf("&lock_timeout=%d", timeout.Milliseconds())
+	}
+	return connURL
+}
+
+// isLockTimeout reports whether a migration statement failed because a lock was not granted in time.
+// The migrate driver returns statement errors as database.Error values, which do not implement Unwrap.
+func isLockTimeout(err error) bool {
+	var dbErr database.Error
+	if !errors.As(err, &dbErr) {
+		return false
+	}
+	var pgErr *pgconn.PgError
+	if !errors.As(dbErr.OrigErr, &pgErr) {
+		return false
+	}
+	return pgErr.Code == pgLockNotAvailable || pgErr.Code == pgDeadlockDetected
+}
@@ -4,6 +4,7 @@ package migrations_test
 
 import (
 	"context"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -69,3 +70,50 @@ func TestPostgresIntegration_MigrationsAndRestore(t *testing.T) {
 		assert.False(t, exists)
 	})
 }
+
+func TestPostgresIntegration_TemplateContentCompression(t *testing.T) {
+	ctx := context.Background()
+	pg := testpostgres.Run(ctx, t)
+
+	pool, err := pg.NewPool(ctx)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	// attcompression is 'l' for lz4 and empty for the server default
+	compression := func(t *testing.T) string {
+		t.Helper()
+		var method string
+		err := pool.QueryRow(ctx, `
+			SELECT attcompression::TEXT
+			FROM pg_attribute
+			WHERE attrelid = 'content.template_versions'::regclass AND attname = 'content_structure'
+		`).Scan(&method)
+		require.NoError(t, err)
+		return method
+	}
+	migration := func(t *testing.T, direction string) string {
+		t.Helper()
+		sql, err := os.ReadFile("sql/000018_template_content_compression." + direction + ".sql")
+		require.NoError(t, err)
+		return string(sql)
+	}
+
+	var lz4 bool
+	err = pool.QueryRow(ctx, `
+		SELECT 'lz4' = ANY(enumvals) FROM pg_settings WHERE name = 'default_toast_compression'
+	`).Scan(&lz4)
+	require.NoError(t, err)
+	if !lz4 {
+		t.Skip("the server was built without lz4")
+	}
+
+	assert.Equal(t, "l", compression(t), "up compresses content with lz4")
+
+	_, err = pool.Exec(ctx, migration(t, "down"))
+	require.NoError(t, err)
+	assert.Empty(t, compression(t), "down restores the default compression")
+
+	_, err = pool.Exec(ctx, migration(t, "up"))
+	require.NoError(t, err)
+	assert.Equal(t, "l", compression(t), "up applies again after down")
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWaitStopsWhenContextIsCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	err := wait(ctx, time.Minute)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("wait() error = %v, want context.Canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("wait() took %s after cancel", elapsed)
+	}
+}
+
+func TestWaitReturnsAfterDelay(t *testing.T) {
+	if err := wait(context.Background(), time.Millisecond); err != nil {
+		t.Fatalf("wait() error = %v", err)
+	}
+}
@@ -0,0 +1,3 @@
+-- Reverse migration 000011: Drop notifications table
+
+DROP TABLE IF EXISTS identity.notifications CASCADE;
@@ -0,0 +1,31 @@
+-- Migration 000011: Per-user in-product notifications
+
+-- ========== NOTIFICATIONS TABLE ==========
+
+CREATE TABLE identity.notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    workspace_id UUID,
+    type VARCHAR(50) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL DEFAULT '',
+    resource_id VARCHAR(255),
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE identity.notifications
+ADD CONSTRAINT fk_notifications_user_id
+FOREIGN KEY (user_id) REFERENCES identity.users(id) ON DELETE CASCADE;
+
+ALTER TABLE identity.notifications
+ADD CONSTRAINT fk_notifications_workspace_id
+FOREIGN KEY (workspace_id) REFERENCES tenancy.workspaces(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_notifications_user_created
+ON identity.notifications (user_id, created_at DESC);
+
+-- Partial index for unread counters
+CREATE INDEX idx_notifications_user_unread
+ON identity.notifications (user_id)
+WHERE read_at IS NULL;
@@ -0,0 +1,3 @@
+-- Reverse migration 000012: Drop workspace notification webhooks table
+
+DROP TABLE IF EXISTS tenancy.workspace_notification_webhooks CASCADE;
@@ -0,0 +1,31 @@
+-- Migration 000012: Per-workspace Slack/Teams notification webhooks
+
+-- ========== WORKSPACE NOTIFICATION WEBHOOKS TABLE ==========
+
+CREATE TABLE tenancy.workspace_notification_webhooks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    workspace_id UUID NOT NULL,
+    provider VARCHAR(20) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    webhook_url TEXT NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ
+);
+
+ALTER TABLE tenancy.workspace_notification_webhooks
+ADD CONSTRAINT fk_workspace_notification_webhooks_workspace_id
+FOREIGN KEY (workspace_id) REFERENCES tenancy.workspaces(id) ON DELETE CASCADE;
+
+ALTER TABLE tenancy.workspace_notification_webhooks
+ADD CONSTRAINT fk_workspace_notification_webhooks_created_by
+FOREIGN KEY (created_by) REFERENCES identity.users(id) ON DELETE SET NULL;
+
+ALTER TABLE tenancy.workspace_notification_webhooks
+ADD CONSTRAINT chk_workspace_notification_webhooks_provider
+CHECK (provider IN ('SLACK', 'TEAMS'));
+
+CREATE INDEX idx_workspace_notification_webhooks_workspace_id
+ON tenancy.workspace_notification_webhooks (workspace_id);
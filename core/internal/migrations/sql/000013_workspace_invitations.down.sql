@@ -0,0 +1,3 @@
+-- Reverse migration 000013: Drop workspace invitations table
+
+DROP TABLE IF EXISTS identity.workspace_invitations CASCADE;
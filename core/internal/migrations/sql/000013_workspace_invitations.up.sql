@@ -0,0 +1,45 @@
+-- Migration 000013: Workspace invitations with expiring email tokens
+
+-- ========== WORKSPACE INVITATIONS TABLE ==========
+
+CREATE TABLE identity.workspace_invitations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    workspace_id UUID NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    role workspace_role NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    token_hash VARCHAR(64) NOT NULL,
+    invited_by UUID,
+    expires_at TIMESTAMPTZ NOT NULL,
+    responded_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ
+);
+
+ALTER TABLE identity.workspace_invitations
+ADD CONSTRAINT fk_workspace_invitations_workspace_id
+FOREIGN KEY (workspace_id) REFERENCES tenancy.workspaces(id) ON DELETE CASCADE;
+
+ALTER TABLE identity.workspace_invitations
+ADD CONSTRAINT fk_workspace_invitations_invited_by
+FOREIGN KEY (invited_by) REFERENCES identity.users(id) ON DELETE SET NULL;
+
+ALTER TABLE identity.workspace_invitations
+ADD CONSTRAINT chk_workspace_invitations_status
+CHECK (status IN ('PENDING', 'ACCEPTED', 'DECLINED', 'REVOKED'));
+
+ALTER TABLE identity.workspace_invitations
+ADD CONSTRAINT chk_workspace_invitations_role
+CHECK (role != 'OWNER');
+
+CREATE UNIQUE INDEX idx_workspace_invitations_token_hash
+ON identity.workspace_invitations (token_hash);
+
+-- Only one open invitation per email and workspace
+CREATE UNIQUE INDEX idx_workspace_invitations_pending_email
+ON identity.workspace_invitations (workspace_id, LOWER(email))
+WHERE status = 'PENDING';
+
+CREATE INDEX idx_workspace_invitations_email_pending
+ON identity.workspace_invitations (LOWER(email))
+WHERE status = 'PENDING';
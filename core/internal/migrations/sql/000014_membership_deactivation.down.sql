@@ -0,0 +1,21 @@
+-- Reverse migration 000014: Remove INACTIVE membership status
+-- PostgreSQL cannot drop enum values, so the type is recreated.
+-- Deactivated memberships are removed, matching the pre-deactivation behavior.
+
+DELETE FROM identity.workspace_members WHERE membership_status = 'INACTIVE';
+DELETE FROM identity.tenant_members WHERE membership_status = 'INACTIVE';
+
+ALTER TYPE membership_status RENAME TO membership_status_old;
+CREATE TYPE membership_status AS ENUM ('PENDING', 'ACTIVE');
+
+ALTER TABLE identity.workspace_members ALTER COLUMN membership_status DROP DEFAULT;
+ALTER TABLE identity.workspace_members
+ALTER COLUMN membership_status TYPE membership_status USING membership_status::text::membership_status;
+ALTER TABLE identity.workspace_members ALTER COLUMN membership_status SET DEFAULT 'PENDING';
+
+ALTER TABLE identity.tenant_members ALTER COLUMN membership_status DROP DEFAULT;
+ALTER TABLE identity.tenant_members
+ALTER COLUMN membership_status TYPE membership_status USING membership_status::text::membership_status;
+ALTER TABLE identity.tenant_members ALTER COLUMN membership_status SET DEFAULT 'ACTIVE';
+
+DROP TYPE membership_status_old;
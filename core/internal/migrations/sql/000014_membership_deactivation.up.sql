@@ -0,0 +1,4 @@
+-- Migration 000014: Deactivated workspace memberships
+-- INACTIVE memberships keep their row (and history) but no longer grant access.
+
+ALTER TYPE membership_status ADD VALUE IF NOT EXISTS 'INACTIVE';
@@ -0,0 +1,3 @@
+-- Reverse migration 000015: Drop user preferences table
+
+DROP TABLE IF EXISTS identity.user_preferences CASCADE;
@@ -0,0 +1,20 @@
+-- Migration 000015: Per-user preferences (locale, timezone, default workspace)
+
+-- ========== USER PREFERENCES TABLE ==========
+
+CREATE TABLE identity.user_preferences (
+    user_id UUID PRIMARY KEY,
+    locale VARCHAR(35) NOT NULL DEFAULT 'en',
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    default_workspace_id UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ
+);
+
+ALTER TABLE identity.user_preferences
+ADD CONSTRAINT fk_user_preferences_user_id
+FOREIGN KEY (user_id) REFERENCES identity.users(id) ON DELETE CASCADE;
+
+ALTER TABLE identity.user_preferences
+ADD CONSTRAINT fk_user_preferences_default_workspace_id
+FOREIGN KEY (default_workspace_id) REFERENCES tenancy.workspaces(id) ON DELETE SET NULL;
@@ -0,0 +1,3 @@
+-- Reverse migration 000016: Drop auth sessions table
+
+DROP TABLE IF EXISTS identity.auth_sessions CASCADE;
@@ -0,0 +1,38 @@
+-- Migration 000016: Authenticated principals seen by the API (users, service accounts, API keys)
+
+-- ========== AUTH SESSIONS TABLE ==========
+
+CREATE TABLE identity.auth_sessions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    principal_type VARCHAR(20) NOT NULL,
+    provider VARCHAR(100) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    user_id UUID,
+    email VARCHAR(255),
+    display_name VARCHAR(255),
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(500),
+    request_count BIGINT NOT NULL DEFAULT 0,
+    first_seen_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMPTZ,
+    revoked_by UUID,
+    reauth_required_at TIMESTAMPTZ
+);
+
+ALTER TABLE identity.auth_sessions
+ADD CONSTRAINT uq_auth_sessions_principal UNIQUE (principal_type, provider, subject);
+
+ALTER TABLE identity.auth_sessions
+ADD CONSTRAINT chk_auth_sessions_principal_type
+CHECK (principal_type IN ('USER', 'SERVICE_ACCOUNT', 'API_KEY'));
+
+ALTER TABLE identity.auth_sessions
+ADD CONSTRAINT fk_auth_sessions_user_id
+FOREIGN KEY (user_id) REFERENCES identity.users(id) ON DELETE SET NULL;
+
+ALTER TABLE identity.auth_sessions
+ADD CONSTRAINT fk_auth_sessions_revoked_by
+FOREIGN KEY (revoked_by) REFERENCES identity.users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_auth_sessions_last_seen_at ON identity.auth_sessions (last_seen_at DESC);
@@ -0,0 +1,3 @@
+-- Reverse migration 000017: Drop maintenance state table
+
+DROP TABLE IF EXISTS tenancy.maintenance_state CASCADE;
@@ -0,0 +1,25 @@
+-- Migration 000017: Platform maintenance mode (single-row state shared by all instances)
+
+-- ========== MAINTENANCE STATE TABLE ==========
+
+CREATE TABLE tenancy.maintenance_state (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE,
+    mode VARCHAR(20) NOT NULL DEFAULT 'OFF',
+    message VARCHAR(500),
+    expected_end_at TIMESTAMPTZ,
+    updated_by UUID,
+    updated_at TIMESTAMPTZ
+);
+
+ALTER TABLE tenancy.maintenance_state
+ADD CONSTRAINT chk_maintenance_state_single_row CHECK (id);
+
+ALTER TABLE tenancy.maintenance_state
+ADD CONSTRAINT chk_maintenance_state_mode
+CHECK (mode IN ('OFF', 'READ_ONLY', 'DRAIN'));
+
+ALTER TABLE tenancy.maintenance_state
+ADD CONSTRAINT fk_maintenance_state_updated_by
+FOREIGN KEY (updated_by) REFERENCES identity.users(id) ON DELETE SET NULL;
+
+INSERT INTO tenancy.maintenance_state (id, mode) VALUES (TRUE, 'OFF');
@@ -0,0 +1,3 @@
+-- Reverse migration 000018: Restore default compression for template version content
+
+ALTER TABLE content.template_versions ALTER COLUMN content_structure SET COMPRESSION DEFAULT;
@@ -0,0 +1,16 @@
+-- Migration 000018: Compress template version content with LZ4
+--
+-- SET COMPRESSION only changes column metadata: existing rows keep their current
+-- compression until they are next written. Skipped when the server was built without lz4.
+
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM pg_settings
+        WHERE name = 'default_toast_compression' AND 'lz4' = ANY(enumvals)
+    ) THEN
+        ALTER TABLE content.template_versions ALTER COLUMN content_structure SET COMPRESSION lz4;
+    ELSE
+        RAISE NOTICE 'lz4 is not available, content.template_versions.content_structure keeps the default compression';
+    END IF;
+END $$;
@@ -0,0 +1,7 @@
+-- Reverse migration 000019: Remove revision counters
+
+ALTER TABLE content.injectable_definitions DROP COLUMN IF EXISTS revision;
+
+ALTER TABLE content.template_versions DROP COLUMN IF EXISTS revision;
+
+ALTER TABLE content.templates DROP COLUMN IF EXISTS revision;
@@ -0,0 +1,9 @@
+-- Migration 000019: Revision counters for optimistic concurrency on concurrently edited resources
+
+-- Constant defaults are metadata-only: existing rows read revision 1 without a table rewrite.
+
+ALTER TABLE content.templates ADD COLUMN revision INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE content.template_versions ADD COLUMN revision INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE content.injectable_definitions ADD COLUMN revision INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,3 @@
+-- Reverse migration 000020: Drop the outbox
+
+DROP TABLE IF EXISTS tenancy.outbox_events;
@@ -0,0 +1,24 @@
+-- Migration 000020: Transactional outbox for domain events delivered by the relay worker
+
+-- ========== OUTBOX EVENTS TABLE ==========
+
+CREATE TABLE tenancy.outbox_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_type VARCHAR(100) NOT NULL,
+    aggregate_id VARCHAR(255) NOT NULL,
+    workspace_id UUID,
+    payload JSONB NOT NULL DEFAULT '{}',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    available_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMPTZ,
+    failed_at TIMESTAMPTZ
+);
+
+-- Pending events in delivery order; delivered and failed events are not scanned by the relay
+CREATE INDEX idx_outbox_events_pending ON tenancy.outbox_events (available_at, created_at)
+WHERE delivered_at IS NULL AND failed_at IS NULL;
+
+CREATE INDEX idx_outbox_events_delivered_at ON tenancy.outbox_events (delivered_at)
+WHERE delivered_at IS NOT NULL;
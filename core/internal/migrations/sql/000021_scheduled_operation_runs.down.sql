@@ -0,0 +1,3 @@
+-- Reverse migration 000021: Drop scheduled operation runs
+
+DROP TABLE IF EXISTS content.scheduled_operation_runs;
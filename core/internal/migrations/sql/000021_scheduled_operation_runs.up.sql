@@ -0,0 +1,33 @@
+-- Migration 000021: Outcomes of scheduled publications and archivals
+
+-- ========== SCHEDULED OPERATION RUNS TABLE ==========
+
+CREATE TABLE content.scheduled_operation_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    version_id UUID NOT NULL,
+    operation VARCHAR(20) NOT NULL,
+    scheduled_for TIMESTAMPTZ,
+    status VARCHAR(20) NOT NULL,
+    error TEXT,
+    triggered_by UUID,
+    ran_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE content.scheduled_operation_runs
+ADD CONSTRAINT chk_scheduled_operation_runs_operation
+CHECK (operation IN ('PUBLISH', 'ARCHIVE'));
+
+ALTER TABLE content.scheduled_operation_runs
+ADD CONSTRAINT chk_scheduled_operation_runs_status
+CHECK (status IN ('SUCCEEDED', 'FAILED'));
+
+ALTER TABLE content.scheduled_operation_runs
+ADD CONSTRAINT fk_scheduled_operation_runs_version_id
+FOREIGN KEY (version_id) REFERENCES content.template_versions(id) ON DELETE CASCADE;
+
+ALTER TABLE content.scheduled_operation_runs
+ADD CONSTRAINT fk_scheduled_operation_runs_triggered_by
+FOREIGN KEY (triggered_by) REFERENCES identity.users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_scheduled_operation_runs_version ON content.scheduled_operation_runs (version_id, operation, ran_at DESC);
+CREATE INDEX idx_scheduled_operation_runs_ran_at ON content.scheduled_operation_runs (ran_at DESC);
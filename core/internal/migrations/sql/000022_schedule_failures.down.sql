@@ -0,0 +1,5 @@
+-- Reverse migration 000022: Remove the failed-scheduled state
+
+ALTER TABLE content.template_versions DROP COLUMN IF EXISTS schedule_failure_reason;
+
+ALTER TABLE content.template_versions DROP COLUMN IF EXISTS schedule_failed_at;
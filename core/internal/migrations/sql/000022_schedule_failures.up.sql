@@ -0,0 +1,7 @@
+-- Migration 000022: Failed-scheduled state for versions whose scheduled operation keeps failing
+
+-- Set when the scheduler gives up on the pending publication or archival; cleared when
+-- the version is rescheduled, its schedule is cancelled, or the operation succeeds.
+ALTER TABLE content.template_versions ADD COLUMN schedule_failed_at TIMESTAMPTZ;
+
+ALTER TABLE content.template_versions ADD COLUMN schedule_failure_reason TEXT;
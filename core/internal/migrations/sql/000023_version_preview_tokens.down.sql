@@ -0,0 +1,3 @@
+-- Reverse migration 000023: Drop version preview tokens table
+
+DROP TABLE IF EXISTS content.version_preview_tokens CASCADE;
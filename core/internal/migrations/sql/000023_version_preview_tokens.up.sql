@@ -0,0 +1,35 @@
+-- Migration 000023: Expiring preview links that show a template version to reviewers outside the workspace
+
+-- ========== VERSION PREVIEW TOKENS TABLE ==========
+
+CREATE TABLE content.version_preview_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    workspace_id UUID NOT NULL,
+    version_id UUID NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    label VARCHAR(255),
+    watermark VARCHAR(100),
+    created_by UUID,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE content.version_preview_tokens
+ADD CONSTRAINT fk_version_preview_tokens_workspace_id
+FOREIGN KEY (workspace_id) REFERENCES tenancy.workspaces(id) ON DELETE CASCADE;
+
+ALTER TABLE content.version_preview_tokens
+ADD CONSTRAINT fk_version_preview_tokens_version_id
+FOREIGN KEY (version_id) REFERENCES content.template_versions(id) ON DELETE CASCADE;
+
+ALTER TABLE content.version_preview_tokens
+ADD CONSTRAINT fk_version_preview_tokens_created_by
+FOREIGN KEY (created_by) REFERENCES identity.users(id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX idx_version_preview_tokens_token_hash
+ON content.version_preview_tokens (token_hash);
+
+CREATE INDEX idx_version_preview_tokens_version
+ON content.version_preview_tokens (version_id, created_at DESC);
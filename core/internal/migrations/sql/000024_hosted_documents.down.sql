@@ -0,0 +1,3 @@
+-- Reverse migration 000024: Drop hosted documents table
+
+DROP TABLE IF EXISTS content.hosted_documents CASCADE;
@@ -0,0 +1,31 @@
+-- Migration 000024: Rendered PDFs kept by the server and shared through viewer links
+
+-- ========== HOSTED DOCUMENTS TABLE ==========
+
+CREATE TABLE content.hosted_documents (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    workspace_id UUID NOT NULL,
+    filename VARCHAR(255) NOT NULL,
+    pdf BYTEA NOT NULL,
+    size_bytes INTEGER NOT NULL,
+    page_count INTEGER NOT NULL,
+    access VARCHAR(20) NOT NULL,
+    token_hash VARCHAR(64),
+    allow_download BOOLEAN NOT NULL DEFAULT TRUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    view_count INTEGER NOT NULL DEFAULT 0,
+    last_viewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_hosted_documents_access CHECK (access IN ('PUBLIC', 'TOKEN', 'MEMBERS')),
+    CONSTRAINT chk_hosted_documents_token CHECK ((access = 'TOKEN') = (token_hash IS NOT NULL))
+);
+
+ALTER TABLE content.hosted_documents
+ADD CONSTRAINT fk_hosted_documents_workspace_id
+FOREIGN KEY (workspace_id) REFERENCES tenancy.workspaces(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_hosted_documents_workspace
+ON content.hosted_documents (workspace_id, created_at DESC);
+
+CREATE INDEX idx_hosted_documents_expires_at
+ON content.hosted_documents (expires_at);
@@ -0,0 +1,9 @@
+-- Reverse migration 000025: Drop hosted document index columns
+
+ALTER TABLE content.hosted_documents
+    DROP COLUMN IF EXISTS index_available_at,
+    DROP COLUMN IF EXISTS index_error,
+    DROP COLUMN IF EXISTS index_attempts,
+    DROP COLUMN IF EXISTS indexed_at,
+    DROP COLUMN IF EXISTS text_content,
+    DROP COLUMN IF EXISTS thumbnail;
@@ -0,0 +1,11 @@
+-- Migration 000025: Thumbnails and extracted text for hosted documents
+
+-- ========== HOSTED DOCUMENT INDEX COLUMNS ==========
+
+ALTER TABLE content.hosted_documents
+    ADD COLUMN thumbnail BYTEA,
+    ADD COLUMN text_content TEXT,
+    ADD COLUMN indexed_at TIMESTAMPTZ,
+    ADD COLUMN index_attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN index_error TEXT,
+    ADD COLUMN index_available_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP;
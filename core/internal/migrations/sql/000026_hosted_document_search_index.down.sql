@@ -0,0 +1,3 @@
+-- Reverse migration 000026: Drop hosted document search index
+
+DROP INDEX IF EXISTS content.idx_hosted_documents_search;
@@ -0,0 +1,5 @@
+-- Migration 000026: Full-text search over hosted document filenames and extracted text
+-- The expression must match the search query in hosted_document_repo.
+
+CREATE INDEX CONCURRENTLY idx_hosted_documents_search
+ON content.hosted_documents USING GIN (to_tsvector('simple', filename || ' ' || COALESCE(text_content, '')));
@@ -0,0 +1,3 @@
+-- Reverse migration 000027: Drop hosted document pending index
+
+DROP INDEX IF EXISTS content.idx_hosted_documents_index_pending;
@@ -0,0 +1,5 @@
+-- Migration 000027: Hosted documents waiting for the indexer
+
+CREATE INDEX CONCURRENTLY idx_hosted_documents_index_pending
+ON content.hosted_documents (index_available_at)
+WHERE indexed_at IS NULL AND index_error IS NULL;
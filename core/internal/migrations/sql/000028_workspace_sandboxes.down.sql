@@ -0,0 +1,4 @@
+-- Reverse migration 000028: Drop workspace sandboxes table
+-- Sandbox workspaces themselves are kept and become regular workspaces
+
+DROP TABLE IF EXISTS tenancy.workspace_sandboxes CASCADE;
@@ -0,0 +1,29 @@
+-- Migration 000028: Sandbox workspaces, temporary clones of a workspace deleted when they expire
+
+-- ========== WORKSPACE SANDBOXES TABLE ==========
+
+CREATE TABLE tenancy.workspace_sandboxes (
+    workspace_id UUID PRIMARY KEY,
+    source_workspace_id UUID,
+    created_by UUID,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE tenancy.workspace_sandboxes
+ADD CONSTRAINT fk_workspace_sandboxes_workspace_id
+FOREIGN KEY (workspace_id) REFERENCES tenancy.workspaces(id) ON DELETE CASCADE;
+
+ALTER TABLE tenancy.workspace_sandboxes
+ADD CONSTRAINT fk_workspace_sandboxes_source_workspace_id
+FOREIGN KEY (source_workspace_id) REFERENCES tenancy.workspaces(id) ON DELETE SET NULL;
+
+ALTER TABLE tenancy.workspace_sandboxes
+ADD CONSTRAINT fk_workspace_sandboxes_created_by
+FOREIGN KEY (created_by) REFERENCES identity.users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_workspace_sandboxes_source
+ON tenancy.workspace_sandboxes (source_workspace_id);
+
+CREATE INDEX idx_workspace_sandboxes_expires_at
+ON tenancy.workspace_sandboxes (expires_at);
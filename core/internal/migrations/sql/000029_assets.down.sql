@@ -0,0 +1,4 @@
+-- Reverse migration 000029: Drop asset library tables
+
+DROP TABLE IF EXISTS content.asset_versions CASCADE;
+DROP TABLE IF EXISTS content.assets CASCADE;
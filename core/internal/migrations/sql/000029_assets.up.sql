@@ -0,0 +1,55 @@
+-- Migration 000029: Workspace asset library (images, PDFs and fonts) with versioned content
+
+-- ========== ASSETS TABLE ==========
+
+CREATE TABLE content.assets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    workspace_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    current_version INTEGER NOT NULL DEFAULT 1,
+    created_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT chk_assets_kind CHECK (kind IN ('IMAGE', 'PDF', 'FONT'))
+);
+
+ALTER TABLE content.assets
+ADD CONSTRAINT fk_assets_workspace_id
+FOREIGN KEY (workspace_id) REFERENCES tenancy.workspaces(id) ON DELETE CASCADE;
+
+ALTER TABLE content.assets
+ADD CONSTRAINT fk_assets_created_by
+FOREIGN KEY (created_by) REFERENCES identity.users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_assets_workspace
+ON content.assets (workspace_id, created_at DESC);
+
+CREATE INDEX idx_assets_tags
+ON content.assets USING GIN (tags);
+
+-- ========== ASSET VERSIONS TABLE ==========
+
+CREATE TABLE content.asset_versions (
+    asset_id UUID NOT NULL,
+    version INTEGER NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes INTEGER NOT NULL,
+    sha256 VARCHAR(64) NOT NULL,
+    data BYTEA NOT NULL,
+    created_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (asset_id, version)
+);
+
+ALTER TABLE content.asset_versions
+ADD CONSTRAINT fk_asset_versions_asset_id
+FOREIGN KEY (asset_id) REFERENCES content.assets(id) ON DELETE CASCADE;
+
+ALTER TABLE content.asset_versions
+ADD CONSTRAINT fk_asset_versions_created_by
+FOREIGN KEY (created_by) REFERENCES identity.users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_asset_versions_sha256
+ON content.asset_versions (sha256);
@@ -0,0 +1,3 @@
+-- Reverse migration 000030: Drop render daily stats
+
+DROP TABLE IF EXISTS tenancy.render_daily_stats CASCADE;
@@ -0,0 +1,12 @@
+-- Migration 000030: Render counters per day for the platform stats
+
+-- ========== RENDER DAILY STATS TABLE ==========
+
+CREATE TABLE tenancy.render_daily_stats (
+    day DATE PRIMARY KEY,
+    renders BIGINT NOT NULL DEFAULT 0,
+    failures BIGINT NOT NULL DEFAULT 0,
+    pages BIGINT NOT NULL DEFAULT 0,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
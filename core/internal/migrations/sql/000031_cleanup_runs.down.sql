@@ -0,0 +1,3 @@
+-- Reverse migration 000031: Drop cleanup runs
+
+DROP TABLE IF EXISTS tenancy.cleanup_runs CASCADE;
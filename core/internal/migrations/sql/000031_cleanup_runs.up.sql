@@ -0,0 +1,19 @@
+-- Migration 000031: Runs of the orphaned data cleanup, with what each found and deleted
+
+-- ========== CLEANUP RUNS TABLE ==========
+
+CREATE TABLE tenancy.cleanup_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    results JSONB NOT NULL DEFAULT '[]',
+    triggered_by UUID,
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ NOT NULL
+);
+
+ALTER TABLE tenancy.cleanup_runs
+ADD CONSTRAINT fk_cleanup_runs_triggered_by
+FOREIGN KEY (triggered_by) REFERENCES identity.users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_cleanup_runs_started_at
+ON tenancy.cleanup_runs (started_at DESC);
@@ -0,0 +1,3 @@
+-- Reverse migration 000032: Drop render jobs
+
+DROP TABLE IF EXISTS content.render_jobs CASCADE;
@@ -0,0 +1,40 @@
+-- Migration 000032: Renders submitted asynchronously, polled by job ID
+
+-- ========== RENDER JOBS TABLE ==========
+
+CREATE TABLE content.render_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    workspace_id UUID NOT NULL,
+    tenant_code VARCHAR(50) NOT NULL,
+    workspace_code VARCHAR(50) NOT NULL,
+    document_type_code VARCHAR(50),
+    version_id UUID,
+    environment VARCHAR(10) NOT NULL,
+    request JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'QUEUED',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    lease_until TIMESTAMPTZ,
+    error TEXT,
+    filename VARCHAR(255),
+    pdf BYTEA,
+    size_bytes INTEGER,
+    page_count INTEGER,
+    warnings JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT chk_render_jobs_status CHECK (status IN ('QUEUED', 'RUNNING', 'SUCCEEDED', 'FAILED')),
+    CONSTRAINT chk_render_jobs_target CHECK ((document_type_code IS NULL) <> (version_id IS NULL))
+);
+
+ALTER TABLE content.render_jobs
+ADD CONSTRAINT fk_render_jobs_workspace_id
+FOREIGN KEY (workspace_id) REFERENCES tenancy.workspaces(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_render_jobs_pending
+ON content.render_jobs (created_at)
+WHERE status IN ('QUEUED', 'RUNNING');
+
+CREATE INDEX idx_render_jobs_expires_at
+ON content.render_jobs (expires_at);
@@ -0,0 +1,3 @@
+-- Reverse migration 000033: Drop version changelogs
+
+ALTER TABLE content.template_versions DROP COLUMN IF EXISTS changelog;
@@ -0,0 +1,5 @@
+-- Migration 000033: Changelog computed when a version is published
+
+-- Differences from the version published before it (sections, injectables, page config);
+-- NULL for versions never published.
+ALTER TABLE content.template_versions ADD COLUMN changelog JSONB;
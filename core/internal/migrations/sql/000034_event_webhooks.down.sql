@@ -0,0 +1,4 @@
+-- Reverse migration 000034: Drop event webhooks and their delivery log
+
+DROP TABLE IF EXISTS tenancy.event_webhook_deliveries CASCADE;
+DROP TABLE IF EXISTS tenancy.event_webhooks CASCADE;
@@ -0,0 +1,66 @@
+-- Migration 000034: Per-workspace event webhooks posting signed render/publish events, with their delivery log
+
+-- ========== EVENT WEBHOOKS TABLE ==========
+
+CREATE TABLE tenancy.event_webhooks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    workspace_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    url TEXT NOT NULL,
+    secret VARCHAR(100) NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ
+);
+
+ALTER TABLE tenancy.event_webhooks
+ADD CONSTRAINT fk_event_webhooks_workspace_id
+FOREIGN KEY (workspace_id) REFERENCES tenancy.workspaces(id) ON DELETE CASCADE;
+
+ALTER TABLE tenancy.event_webhooks
+ADD CONSTRAINT fk_event_webhooks_created_by
+FOREIGN KEY (created_by) REFERENCES identity.users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_event_webhooks_workspace_id
+ON tenancy.event_webhooks (workspace_id);
+
+-- ========== EVENT WEBHOOK DELIVERIES TABLE ==========
+
+CREATE TABLE tenancy.event_webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    webhook_id UUID NOT NULL,
+    workspace_id UUID NOT NULL,
+    event_id VARCHAR(255) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    attempts INT NOT NULL DEFAULT 0,
+    last_status_code INT,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMPTZ,
+    failed_at TIMESTAMPTZ
+);
+
+ALTER TABLE tenancy.event_webhook_deliveries
+ADD CONSTRAINT fk_event_webhook_deliveries_webhook_id
+FOREIGN KEY (webhook_id) REFERENCES tenancy.event_webhooks(id) ON DELETE CASCADE;
+
+ALTER TABLE tenancy.event_webhook_deliveries
+ADD CONSTRAINT chk_event_webhook_deliveries_status
+CHECK (status IN ('PENDING', 'DELIVERED', 'FAILED'));
+
+-- Outbox events are delivered at least once; an event is queued once per webhook
+ALTER TABLE tenancy.event_webhook_deliveries
+ADD CONSTRAINT uq_event_webhook_deliveries_webhook_event
+UNIQUE (webhook_id, event_id);
+
+CREATE INDEX idx_event_webhook_deliveries_pending
+ON tenancy.event_webhook_deliveries (next_attempt_at)
+WHERE status = 'PENDING';
+
+CREATE INDEX idx_event_webhook_deliveries_webhook_created
+ON tenancy.event_webhook_deliveries (webhook_id, created_at DESC);
@@ -0,0 +1,3 @@
+-- Reverse migration 000035: Drop version release notes
+
+DROP TABLE IF EXISTS content.template_version_release_notes;
@@ -0,0 +1,18 @@
+-- Migration 000035: Release notes written for template versions, frozen once the version is published
+
+CREATE TABLE content.template_version_release_notes (
+    version_id UUID PRIMARY KEY,
+    body TEXT NOT NULL DEFAULT '',
+    ticket_ids TEXT[] NOT NULL DEFAULT '{}',
+    author_id UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ
+);
+
+ALTER TABLE content.template_version_release_notes
+ADD CONSTRAINT fk_template_version_release_notes_version_id
+FOREIGN KEY (version_id) REFERENCES content.template_versions(id) ON DELETE CASCADE;
+
+ALTER TABLE content.template_version_release_notes
+ADD CONSTRAINT fk_template_version_release_notes_author_id
+FOREIGN KEY (author_id) REFERENCES identity.users(id) ON DELETE SET NULL;
@@ -0,0 +1,3 @@
+-- Reverse migration 000036: Drop render failures
+
+DROP TABLE IF EXISTS content.render_failures;
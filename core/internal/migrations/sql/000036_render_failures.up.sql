@@ -0,0 +1,27 @@
+-- Migration 000036: Typst input of failed compiles, kept for support to reproduce them
+
+-- ========== RENDER FAILURES TABLE ==========
+
+CREATE TABLE content.render_failures (
+    id UUID PRIMARY KEY,
+    tenant_code VARCHAR(50) NOT NULL,
+    workspace_code VARCHAR(50) NOT NULL,
+    document_type_code VARCHAR(50),
+    version_id UUID NOT NULL,
+    template_id UUID NOT NULL,
+    environment VARCHAR(10) NOT NULL,
+    error TEXT NOT NULL,
+    stderr TEXT NOT NULL DEFAULT '',
+    source TEXT NOT NULL,
+    source_bytes INTEGER NOT NULL,
+    source_truncated BOOLEAN NOT NULL DEFAULT FALSE,
+    assets JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_render_failures_created_at
+ON content.render_failures (created_at DESC);
+
+CREATE INDEX idx_render_failures_expires_at
+ON content.render_failures (expires_at);
@@ -0,0 +1,3 @@
+-- Reverse migration 000037: Remove the render error code of render jobs
+
+ALTER TABLE content.render_jobs DROP COLUMN IF EXISTS error_code;
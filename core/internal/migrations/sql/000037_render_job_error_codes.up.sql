@@ -0,0 +1,3 @@
+-- Migration 000037: Render error code of failed render jobs, telling callers whether a retry can help
+
+ALTER TABLE content.render_jobs ADD COLUMN error_code VARCHAR(40);
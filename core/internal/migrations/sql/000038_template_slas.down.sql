@@ -0,0 +1,4 @@
+-- Reverse migration 000038: Drop template SLAs and their render counts
+
+DROP TABLE IF EXISTS content.template_render_minutes;
+DROP TABLE IF EXISTS content.template_slas;
@@ -0,0 +1,41 @@
+-- Migration 000038: Render expectations per template, and the per-minute render counts they are checked against
+
+-- ========== TEMPLATE SLAS TABLE ==========
+
+CREATE TABLE content.template_slas (
+    template_id UUID PRIMARY KEY,
+    max_render_ms INTEGER,
+    max_failure_rate NUMERIC(5, 4),
+    window_minutes INTEGER NOT NULL,
+    min_renders INTEGER NOT NULL,
+    breached_at TIMESTAMPTZ,
+    updated_by UUID,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE content.template_slas
+ADD CONSTRAINT fk_template_slas_template_id
+FOREIGN KEY (template_id) REFERENCES content.templates(id) ON DELETE CASCADE;
+
+ALTER TABLE content.template_slas
+ADD CONSTRAINT fk_template_slas_updated_by
+FOREIGN KEY (updated_by) REFERENCES identity.users(id) ON DELETE SET NULL;
+
+-- ========== TEMPLATE RENDER MINUTES TABLE ==========
+
+-- Only templates with an SLA are counted; minutes older than the longest window are deleted
+CREATE TABLE content.template_render_minutes (
+    template_id UUID NOT NULL,
+    minute TIMESTAMPTZ NOT NULL,
+    renders INTEGER NOT NULL DEFAULT 0,
+    failures INTEGER NOT NULL DEFAULT 0,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (template_id, minute)
+);
+
+ALTER TABLE content.template_render_minutes
+ADD CONSTRAINT fk_template_render_minutes_template_id
+FOREIGN KEY (template_id) REFERENCES content.templates(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_template_render_minutes_minute
+ON content.template_render_minutes (minute);
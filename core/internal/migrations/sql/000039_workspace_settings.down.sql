@@ -0,0 +1,4 @@
+-- Reverse migration 000039: Drop workspace settings and tenant workspace defaults
+
+DROP TABLE IF EXISTS tenancy.tenant_workspace_defaults;
+DROP TABLE IF EXISTS tenancy.workspace_settings;
@@ -0,0 +1,37 @@
+-- Migration 000039: Workspace settings, and the defaults new workspaces of a tenant inherit
+
+-- ========== WORKSPACE SETTINGS TABLE ==========
+
+CREATE TABLE tenancy.workspace_settings (
+    workspace_id UUID PRIMARY KEY,
+    settings JSONB NOT NULL DEFAULT '{}',
+    updated_by UUID,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE tenancy.workspace_settings
+ADD CONSTRAINT fk_workspace_settings_workspace_id
+FOREIGN KEY (workspace_id) REFERENCES tenancy.workspaces(id) ON DELETE CASCADE;
+
+ALTER TABLE tenancy.workspace_settings
+ADD CONSTRAINT fk_workspace_settings_updated_by
+FOREIGN KEY (updated_by) REFERENCES identity.users(id) ON DELETE SET NULL;
+
+-- ========== TENANT WORKSPACE DEFAULTS TABLE ==========
+
+-- Locked sections take the tenant value in every workspace of the tenant
+CREATE TABLE tenancy.tenant_workspace_defaults (
+    tenant_id UUID PRIMARY KEY,
+    settings JSONB NOT NULL DEFAULT '{}',
+    locked TEXT[] NOT NULL DEFAULT '{}',
+    updated_by UUID,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE tenancy.tenant_workspace_defaults
+ADD CONSTRAINT fk_tenant_workspace_defaults_tenant_id
+FOREIGN KEY (tenant_id) REFERENCES tenancy.tenants(id) ON DELETE CASCADE;
+
+ALTER TABLE tenancy.tenant_workspace_defaults
+ADD CONSTRAINT fk_tenant_workspace_defaults_updated_by
+FOREIGN KEY (updated_by) REFERENCES identity.users(id) ON DELETE SET NULL;
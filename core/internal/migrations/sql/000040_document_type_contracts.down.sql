@@ -0,0 +1,3 @@
+-- Reverse migration 000040: Drop document type contracts
+
+DROP TABLE IF EXISTS content.document_type_contracts;
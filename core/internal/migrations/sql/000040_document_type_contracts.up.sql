@@ -0,0 +1,21 @@
+-- Migration 000040: Payload contracts of document types
+
+-- ========== DOCUMENT TYPE CONTRACTS TABLE ==========
+
+-- JSON Schema render-by-type payloads must satisfy, with an example for integrating teams
+CREATE TABLE content.document_type_contracts (
+    document_type_id UUID PRIMARY KEY,
+    schema JSONB NOT NULL,
+    example JSONB,
+    updated_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ
+);
+
+ALTER TABLE content.document_type_contracts
+ADD CONSTRAINT fk_document_type_contracts_document_type_id
+FOREIGN KEY (document_type_id) REFERENCES content.document_types(id) ON DELETE CASCADE;
+
+ALTER TABLE content.document_type_contracts
+ADD CONSTRAINT fk_document_type_contracts_updated_by
+FOREIGN KEY (updated_by) REFERENCES identity.users(id) ON DELETE SET NULL;
@@ -0,0 +1,3 @@
+-- Reverse migration 000041: Drop template library links
+
+DROP TABLE IF EXISTS content.template_library_links;
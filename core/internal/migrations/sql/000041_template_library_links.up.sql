@@ -0,0 +1,35 @@
+-- Migration 000041: Links of templates installed from the tenant library
+
+-- ========== TEMPLATE LIBRARY LINKS TABLE ==========
+
+-- Library template a workspace template was installed from, with the upstream content it last
+-- took as the base of the next three-way merge
+CREATE TABLE content.template_library_links (
+    template_id UUID PRIMARY KEY,
+    source_template_id UUID NOT NULL,
+    base_version_id UUID,
+    base_version_number INT NOT NULL,
+    base_content JSONB NOT NULL,
+    asset_map JSONB NOT NULL DEFAULT '{}',
+    installed_by UUID,
+    installed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    synced_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_template_library_links_source_template_id ON content.template_library_links(source_template_id);
+
+ALTER TABLE content.template_library_links
+ADD CONSTRAINT fk_template_library_links_template_id
+FOREIGN KEY (template_id) REFERENCES content.templates(id) ON DELETE CASCADE;
+
+ALTER TABLE content.template_library_links
+ADD CONSTRAINT fk_template_library_links_source_template_id
+FOREIGN KEY (source_template_id) REFERENCES content.templates(id) ON DELETE CASCADE;
+
+ALTER TABLE content.template_library_links
+ADD CONSTRAINT fk_template_library_links_base_version_id
+FOREIGN KEY (base_version_id) REFERENCES content.template_versions(id) ON DELETE SET NULL;
+
+ALTER TABLE content.template_library_links
+ADD CONSTRAINT fk_template_library_links_installed_by
+FOREIGN KEY (installed_by) REFERENCES identity.users(id) ON DELETE SET NULL;
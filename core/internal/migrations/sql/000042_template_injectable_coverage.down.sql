@@ -0,0 +1,3 @@
+-- Reverse migration 000042: Drop template injectable coverage
+
+DROP TABLE IF EXISTS content.template_injectable_coverage;
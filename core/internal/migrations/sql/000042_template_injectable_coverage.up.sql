@@ -0,0 +1,22 @@
+-- Migration 000042: Per-day counts of where the values of template injectables came from in renders
+
+-- ========== TEMPLATE INJECTABLE COVERAGE TABLE ==========
+
+-- Days older than injectable_coverage.retention_days are deleted
+CREATE TABLE content.template_injectable_coverage (
+    template_id UUID NOT NULL,
+    injectable_key VARCHAR(100) NOT NULL,
+    day DATE NOT NULL,
+    renders INTEGER NOT NULL DEFAULT 0,
+    from_payload INTEGER NOT NULL DEFAULT 0,
+    from_default INTEGER NOT NULL DEFAULT 0,
+    empty INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (template_id, injectable_key, day)
+);
+
+ALTER TABLE content.template_injectable_coverage
+ADD CONSTRAINT fk_template_injectable_coverage_template_id
+FOREIGN KEY (template_id) REFERENCES content.templates(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_template_injectable_coverage_day
+ON content.template_injectable_coverage (day);
@@ -0,0 +1,3 @@
+-- Reverse migration 000043: Drop scripted injectors
+
+DROP TABLE IF EXISTS content.scripted_injectors;
@@ -0,0 +1,28 @@
+-- Migration 000043: Scripted injectors written in Starlark by system admins
+
+-- ========== SCRIPTED INJECTORS TABLE ==========
+
+-- Registered in the injector registry of every API instance next to the compiled injectors
+CREATE TABLE content.scripted_injectors (
+    code VARCHAR(100) PRIMARY KEY,
+    label JSONB NOT NULL,
+    description JSONB NOT NULL DEFAULT '{}',
+    data_type VARCHAR(20) NOT NULL,
+    source TEXT NOT NULL,
+    dependencies VARCHAR(100)[] NOT NULL DEFAULT '{}',
+    is_critical BOOLEAN NOT NULL DEFAULT FALSE,
+    timeout_ms INTEGER NOT NULL,
+    created_by UUID,
+    updated_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_scripted_injectors_data_type CHECK (data_type IN ('TEXT', 'NUMBER', 'BOOLEAN', 'DATE', 'IMAGE'))
+);
+
+ALTER TABLE content.scripted_injectors
+ADD CONSTRAINT fk_scripted_injectors_created_by
+FOREIGN KEY (created_by) REFERENCES identity.users(id) ON DELETE SET NULL;
+
+ALTER TABLE content.scripted_injectors
+ADD CONSTRAINT fk_scripted_injectors_updated_by
+FOREIGN KEY (updated_by) REFERENCES identity.users(id) ON DELETE SET NULL;
@@ -0,0 +1,5 @@
+-- Reverse migration 000044: Drop render quotas and usage
+
+DROP TABLE IF EXISTS tenancy.render_monthly_usage;
+DROP TABLE IF EXISTS tenancy.workspace_render_quotas;
+DROP TABLE IF EXISTS tenancy.tenant_render_quotas;
@@ -0,0 +1,57 @@
+-- Migration 000044: Render quotas of tenants and workspaces, and the monthly usage they are checked against
+
+-- ========== TENANT RENDER QUOTAS TABLE ==========
+
+-- NULL limits take the configured default; 0 is unlimited
+CREATE TABLE tenancy.tenant_render_quotas (
+    tenant_id UUID PRIMARY KEY,
+    renders_per_minute INTEGER,
+    pages_per_month BIGINT,
+    updated_by UUID,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_tenant_render_quotas_renders CHECK (renders_per_minute >= 0),
+    CONSTRAINT chk_tenant_render_quotas_pages CHECK (pages_per_month >= 0)
+);
+
+ALTER TABLE tenancy.tenant_render_quotas
+ADD CONSTRAINT fk_tenant_render_quotas_tenant_id
+FOREIGN KEY (tenant_id) REFERENCES tenancy.tenants(id) ON DELETE CASCADE;
+
+ALTER TABLE tenancy.tenant_render_quotas
+ADD CONSTRAINT fk_tenant_render_quotas_updated_by
+FOREIGN KEY (updated_by) REFERENCES identity.users(id) ON DELETE SET NULL;
+
+-- ========== WORKSPACE RENDER QUOTAS TABLE ==========
+
+CREATE TABLE tenancy.workspace_render_quotas (
+    workspace_id UUID PRIMARY KEY,
+    renders_per_minute INTEGER,
+    pages_per_month BIGINT,
+    updated_by UUID,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_workspace_render_quotas_renders CHECK (renders_per_minute >= 0),
+    CONSTRAINT chk_workspace_render_quotas_pages CHECK (pages_per_month >= 0)
+);
+
+ALTER TABLE tenancy.workspace_render_quotas
+ADD CONSTRAINT fk_workspace_render_quotas_workspace_id
+FOREIGN KEY (workspace_id) REFERENCES tenancy.workspaces(id) ON DELETE CASCADE;
+
+ALTER TABLE tenancy.workspace_render_quotas
+ADD CONSTRAINT fk_workspace_render_quotas_updated_by
+FOREIGN KEY (updated_by) REFERENCES identity.users(id) ON DELETE SET NULL;
+
+-- ========== RENDER MONTHLY USAGE TABLE ==========
+
+-- Keyed by the codes renders are addressed with, so counting a render needs no lookup
+CREATE TABLE tenancy.render_monthly_usage (
+    tenant_code VARCHAR(50) NOT NULL,
+    workspace_code VARCHAR(50) NOT NULL,
+    month DATE NOT NULL,
+    renders BIGINT NOT NULL DEFAULT 0,
+    pages BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tenant_code, workspace_code, month)
+);
+
+CREATE INDEX idx_render_monthly_usage_month ON tenancy.render_monthly_usage(month);
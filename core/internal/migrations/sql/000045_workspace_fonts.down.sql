@@ -0,0 +1,3 @@
+-- Reverse migration 000045: Drop workspace fonts
+
+DROP TABLE IF EXISTS content.workspace_fonts;
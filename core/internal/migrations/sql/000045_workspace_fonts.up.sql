@@ -0,0 +1,35 @@
+-- Migration 000045: Fonts uploaded to workspaces, added to the font path of their renders
+
+-- ========== WORKSPACE FONTS TABLE ==========
+
+CREATE TABLE content.workspace_fonts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    workspace_id UUID NOT NULL,
+    family VARCHAR(255) NOT NULL,
+    weight SMALLINT NOT NULL DEFAULT 400,
+    style VARCHAR(10) NOT NULL DEFAULT 'normal',
+    format VARCHAR(10) NOT NULL,
+    size_bytes INTEGER NOT NULL,
+    sha256 VARCHAR(64) NOT NULL,
+    data BYTEA NOT NULL,
+    created_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_workspace_fonts_weight CHECK (weight BETWEEN 100 AND 900),
+    CONSTRAINT chk_workspace_fonts_style CHECK (style IN ('normal', 'italic')),
+    CONSTRAINT chk_workspace_fonts_format CHECK (format IN ('TTF', 'OTF'))
+);
+
+ALTER TABLE content.workspace_fonts
+ADD CONSTRAINT fk_workspace_fonts_workspace_id
+FOREIGN KEY (workspace_id) REFERENCES tenancy.workspaces(id) ON DELETE CASCADE;
+
+ALTER TABLE content.workspace_fonts
+ADD CONSTRAINT fk_workspace_fonts_created_by
+FOREIGN KEY (created_by) REFERENCES identity.users(id) ON DELETE SET NULL;
+
+-- A file is uploaded once per workspace, and Typst cannot tell apart two files of the same face
+CREATE UNIQUE INDEX uq_workspace_fonts_sha256
+ON content.workspace_fonts (workspace_id, sha256);
+
+CREATE UNIQUE INDEX uq_workspace_fonts_face
+ON content.workspace_fonts (workspace_id, LOWER(family), weight, style);
@@ -0,0 +1,4 @@
+-- Reverse migration 000046: Drop asset source URLs
+
+ALTER TABLE content.assets
+DROP COLUMN IF EXISTS source_url;
@@ -0,0 +1,4 @@
+-- Migration 000046: Remote URLs assets were imported from, so renders use the stored copy
+
+ALTER TABLE content.assets
+ADD COLUMN source_url TEXT;
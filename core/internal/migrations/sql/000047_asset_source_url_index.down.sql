@@ -0,0 +1,3 @@
+-- Reverse migration 000047: Drop asset source URL index
+
+DROP INDEX IF EXISTS content.idx_assets_source_url;
@@ -0,0 +1,5 @@
+-- Migration 000047: One asset per imported URL in each workspace
+
+CREATE UNIQUE INDEX CONCURRENTLY idx_assets_source_url
+ON content.assets (workspace_id, source_url)
+WHERE source_url IS NOT NULL;
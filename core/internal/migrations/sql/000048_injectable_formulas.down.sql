@@ -0,0 +1,4 @@
+-- Reverse migration 000048: Drop injectable formulas
+
+ALTER TABLE content.injectable_definitions
+DROP COLUMN IF EXISTS formula;
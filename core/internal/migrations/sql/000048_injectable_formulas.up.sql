@@ -0,0 +1,4 @@
+-- Migration 000048: Workspace injectables computed from other injectables by a formula
+
+ALTER TABLE content.injectable_definitions
+ADD COLUMN formula TEXT;
@@ -0,0 +1,4 @@
+-- Reverse migration 000049: Drop numbering sequences and their numbers
+
+DROP TABLE IF EXISTS content.sequence_numbers;
+DROP TABLE IF EXISTS content.numbering_sequences;
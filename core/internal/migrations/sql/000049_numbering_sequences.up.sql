@@ -0,0 +1,69 @@
+-- Migration 000049: Fiscal and legal numbering sequences of workspaces, and the numbers renders drew from them
+
+-- ========== NUMBERING SEQUENCES TABLE ==========
+
+-- One sequence per document type of a workspace. Sequences are deactivated, not deleted, so the
+-- numbers they issued stay in the registry
+CREATE TABLE content.numbering_sequences (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    workspace_id UUID NOT NULL,
+    document_type_id UUID NOT NULL,
+    injectable_key VARCHAR(100) NOT NULL,
+    prefix VARCHAR(50) NOT NULL DEFAULT '',
+    padding SMALLINT NOT NULL DEFAULT 0,
+    yearly_reset BOOLEAN NOT NULL DEFAULT FALSE,
+    gapless BOOLEAN NOT NULL DEFAULT FALSE,
+    start_number BIGINT NOT NULL DEFAULT 1,
+    next_number BIGINT NOT NULL DEFAULT 1,
+    year INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT chk_numbering_sequences_padding CHECK (padding BETWEEN 0 AND 20),
+    CONSTRAINT chk_numbering_sequences_start_number CHECK (start_number >= 1),
+    CONSTRAINT chk_numbering_sequences_next_number CHECK (next_number >= 1)
+);
+
+ALTER TABLE content.numbering_sequences
+ADD CONSTRAINT fk_numbering_sequences_workspace_id
+FOREIGN KEY (workspace_id) REFERENCES tenancy.workspaces(id) ON DELETE CASCADE;
+
+ALTER TABLE content.numbering_sequences
+ADD CONSTRAINT fk_numbering_sequences_document_type_id
+FOREIGN KEY (document_type_id) REFERENCES content.document_types(id) ON DELETE CASCADE;
+
+ALTER TABLE content.numbering_sequences
+ADD CONSTRAINT fk_numbering_sequences_created_by
+FOREIGN KEY (created_by) REFERENCES identity.users(id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX uq_numbering_sequences_document_type
+ON content.numbering_sequences (workspace_id, document_type_id);
+
+-- ========== SEQUENCE NUMBERS TABLE ==========
+
+-- The registry of the numbers renders drew. year is 0 for sequences not reset yearly
+CREATE TABLE content.sequence_numbers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    sequence_id UUID NOT NULL,
+    year INTEGER NOT NULL,
+    number BIGINT NOT NULL,
+    formatted VARCHAR(100) NOT NULL,
+    status VARCHAR(10) NOT NULL,
+    version_id UUID NOT NULL,
+    template_id UUID NOT NULL,
+    document_id VARCHAR(255),
+    issued_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_sequence_numbers_status CHECK (status IN ('ISSUED', 'VOIDED'))
+);
+
+ALTER TABLE content.sequence_numbers
+ADD CONSTRAINT fk_sequence_numbers_sequence_id
+FOREIGN KEY (sequence_id) REFERENCES content.numbering_sequences(id) ON DELETE CASCADE;
+
+-- A number is never issued twice
+CREATE UNIQUE INDEX uq_sequence_numbers_number
+ON content.sequence_numbers (sequence_id, year, number);
+
+CREATE INDEX idx_sequence_numbers_issued_at
+ON content.sequence_numbers (sequence_id, issued_at DESC);
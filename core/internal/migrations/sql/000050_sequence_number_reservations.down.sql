@@ -0,0 +1,12 @@
+-- Reverse migration 000050: Restore the sequence number statuses before reservations
+
+-- Reservations that never settled are numbers no render was issued
+UPDATE content.sequence_numbers
+SET status = 'VOIDED'
+WHERE status IN ('PENDING', 'RELEASED');
+
+ALTER TABLE content.sequence_numbers
+DROP CONSTRAINT chk_sequence_numbers_status;
+
+ALTER TABLE content.sequence_numbers
+ADD CONSTRAINT chk_sequence_numbers_status CHECK (status IN ('ISSUED', 'VOIDED'));
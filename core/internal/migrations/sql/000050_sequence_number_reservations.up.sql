@@ -0,0 +1,9 @@
+-- Migration 000050: Gapless numbering sequences reserve numbers while the render runs
+
+-- PENDING numbers are reserved by a render in progress; RELEASED numbers were given back by a
+-- failed render and go to the next one. Existing rows are checked by migration 000051
+ALTER TABLE content.sequence_numbers
+DROP CONSTRAINT chk_sequence_numbers_status;
+
+ALTER TABLE content.sequence_numbers
+ADD CONSTRAINT chk_sequence_numbers_status CHECK (status IN ('ISSUED', 'VOIDED', 'PENDING', 'RELEASED')) NOT VALID;
@@ -0,0 +1 @@
+-- Reverse migration 000051: Nothing to undo; migration 000050 restores the constraint
@@ -0,0 +1,4 @@
+-- Migration 000051: Check existing sequence numbers against the statuses of migration 000050
+
+ALTER TABLE content.sequence_numbers
+VALIDATE CONSTRAINT chk_sequence_numbers_status;
@@ -0,0 +1,3 @@
+-- Reverse migration 000052: Drop the reusable sequence numbers index
+
+DROP INDEX IF EXISTS content.idx_sequence_numbers_reusable;
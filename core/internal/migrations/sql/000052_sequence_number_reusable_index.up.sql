@@ -0,0 +1,5 @@
+-- Migration 000052: Numbers a gapless render can take
+
+CREATE INDEX CONCURRENTLY idx_sequence_numbers_reusable
+ON content.sequence_numbers (sequence_id, year, number)
+WHERE status IN ('PENDING', 'RELEASED');
@@ -0,0 +1,3 @@
+-- Reverse migration 000053: Drop render currency rates
+
+DROP TABLE IF EXISTS content.render_currency_rates;
@@ -0,0 +1,20 @@
+-- Migration 000053: Exchange rates renders converted amounts with, kept for audits
+
+-- ========== RENDER CURRENCY RATES TABLE ==========
+
+CREATE TABLE content.render_currency_rates (
+    id UUID PRIMARY KEY,
+    tenant_code VARCHAR(50) NOT NULL,
+    workspace_code VARCHAR(50) NOT NULL,
+    document_type_code VARCHAR(50),
+    version_id UUID NOT NULL,
+    template_id UUID NOT NULL,
+    environment VARCHAR(10) NOT NULL,
+    document_number VARCHAR(100),
+    job_id UUID,
+    rates JSONB NOT NULL,
+    rendered_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_render_currency_rates_workspace
+ON content.render_currency_rates (tenant_code, workspace_code, rendered_at DESC);
@@ -0,0 +1,8 @@
+-- Reverse migration 000054: Drop asset version storage keys
+-- Versions kept in object storage have no data and block the NOT NULL; delete them first.
+
+ALTER TABLE content.asset_versions
+ALTER COLUMN data SET NOT NULL;
+
+ALTER TABLE content.asset_versions
+DROP COLUMN IF EXISTS storage_key;
@@ -0,0 +1,7 @@
+-- Migration 000054: Asset versions whose content is kept in object storage instead of the database
+
+ALTER TABLE content.asset_versions
+ADD COLUMN storage_key TEXT;
+
+ALTER TABLE content.asset_versions
+ALTER COLUMN data DROP NOT NULL;
@@ -72,7 +72,7 @@ func Run(ctx context.Context, t *testing.T) *Container {
 		MaxIdleTimeSeconds: 30,
 	}
 
-	require.NoError(t, migrations.Run(&cfg))
+	require.NoError(t, migrations.Run(ctx, &cfg))
 
 	connString, err := ctr.ConnectionString(ctx, "sslmode=disable")
 	require.NoError(t, err)
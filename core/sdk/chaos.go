@@ -0,0 +1,13 @@
+package sdk
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	injectablesvc "github.com/rendis/pdf-forge/core/internal/core/service/injectable"
+)
+
+// ChaosOptions configures the fault injection enabled with engine.EnableChaos():
+// which injectors fail or slow down, how often and for how long. For test environments only.
+type ChaosOptions = injectablesvc.ChaosOptions
+
+// ErrChaosFault is the error returned by calls failed on purpose in chaos mode.
+var ErrChaosFault = entity.ErrChaosFault
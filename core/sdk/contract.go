@@ -0,0 +1,36 @@
+package sdk
+
+import (
+	injectablesvc "github.com/rendis/pdf-forge/core/internal/core/service/injectable"
+	templatesvc "github.com/rendis/pdf-forge/core/internal/core/service/template"
+)
+
+// ContractReport is the result of engine.CheckContract(): the mapper outcome and one entry per injector.
+type ContractReport = injectablesvc.ContractReport
+
+// InjectorContract is the contract check outcome of one injector.
+type InjectorContract = injectablesvc.InjectorContract
+
+// ContractStatus is the outcome of one injector in a contract check.
+type ContractStatus = injectablesvc.ContractStatus
+
+const (
+	ContractOK      = injectablesvc.ContractOK
+	ContractFailed  = injectablesvc.ContractFailed
+	ContractTimeout = injectablesvc.ContractTimeout
+	ContractSkipped = injectablesvc.ContractSkipped
+)
+
+// ContractOperation is InjectorContext.Operation() during a contract check.
+// Skip side effects (writes, notifications, sequence numbers) when you see it.
+const ContractOperation = injectablesvc.ContractOperation
+
+// EstimateOperation is InjectorContext.Operation() during the dry compile of a render estimate.
+// Like contract checks, skip side effects when you see it.
+const EstimateOperation = templatesvc.EstimateOperation
+
+// HTMLRenderOperation is InjectorContext.Operation() while rendering an HTML preview (format=html).
+const HTMLRenderOperation = templatesvc.HTMLRenderOperation
+
+// DocxRenderOperation is InjectorContext.Operation() while rendering a Word document (format=docx).
+const DocxRenderOperation = templatesvc.DocxRenderOperation
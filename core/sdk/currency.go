@@ -0,0 +1,51 @@
+package sdk
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/currencyrate"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// CurrencyRateProvider publishes the exchange rates injectors (injCtx.ConvertCurrency) and table
+// formulas (convert) convert amounts with. Register one with engine.SetCurrencyRateProvider, or
+// configure a built-in provider under currency.
+type CurrencyRateProvider = port.CurrencyRateProvider
+
+// CurrencyRateTable is the exchange rates a provider published on one date against its base currency.
+type CurrencyRateTable = entity.CurrencyRateTable
+
+// CurrencyRate is an exchange rate a render converted amounts with, as recorded in RenderCompleted.
+type CurrencyRate = entity.CurrencyRate
+
+// CurrencyRoundingMode is how converted amounts are rounded to the minor units of their currency.
+type CurrencyRoundingMode = entity.CurrencyRoundingMode
+
+// Currency rounding modes.
+const (
+	CurrencyRoundHalfUp   = entity.CurrencyRoundHalfUp
+	CurrencyRoundHalfEven = entity.CurrencyRoundHalfEven
+	CurrencyRoundDown     = entity.CurrencyRoundDown
+	CurrencyRoundUp       = entity.CurrencyRoundUp
+)
+
+// Built-in CurrencyRateProviders.
+var (
+	// NewECBRateProvider uses the euro reference rates of the European Central Bank.
+	NewECBRateProvider = currencyrate.NewECB
+
+	// NewOpenExchangeRatesProvider uses the USD rates of openexchangerates.org; historical rates
+	// need a paid plan.
+	NewOpenExchangeRatesProvider = currencyrate.NewOpenExchangeRates
+)
+
+// RoundCurrency rounds an amount to the minor units of an ISO 4217 currency.
+var RoundCurrency = entity.RoundCurrency
+
+var (
+	// ErrCurrencyConversionUnavailable is returned by injCtx.ConvertCurrency when no currency rate
+	// provider is configured.
+	ErrCurrencyConversionUnavailable = entity.ErrCurrencyConversionUnavailable
+
+	// ErrCurrencyRateNotFound is returned when the provider has no rate for a currency.
+	ErrCurrencyRateNotFound = entity.ErrCurrencyRateNotFound
+)
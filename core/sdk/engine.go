@@ -11,3 +11,10 @@ var New = bootstrap.New
 
 // NewWithConfig creates a new Engine that loads config from the given file path.
 var NewWithConfig = bootstrap.NewWithConfig
+
+// Option configures an Engine at creation.
+type Option = bootstrap.Option
+
+// WithFrontendFS serves a custom frontend build instead of the embedded one, e.g.
+// sdk.New(sdk.WithFrontendFS(dist)) with dist rooted at the directory holding index.html.
+var WithFrontendFS = bootstrap.WithFrontendFS
@@ -1,6 +1,9 @@
 package sdk
 
-import "github.com/rendis/pdf-forge/core/internal/core/port"
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/primary/http/middleware"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
 
 // ── Extension interfaces ────────────────────────────────────────────────────
 
@@ -87,8 +90,35 @@ type StorageGetURLRequest = port.StorageGetURLRequest
 // StorageGetURLResult is the output of StorageProvider.GetURL.
 type StorageGetURLResult = port.StorageGetURLResult
 
+// NotificationChannel mirrors in-product notifications to an external medium (email, Slack, etc.).
+type NotificationChannel = port.NotificationChannel
+
+// EventPublisher receives outbox events (version.published, notification.created) after commit.
+type EventPublisher = port.EventPublisher
+
+// InvitationMailer emails workspace invitation links containing the one-time token.
+type InvitationMailer = port.InvitationMailer
+
+// RendererBackend is an alternative PDF engine that templates select by name in meta.renderer.
+type RendererBackend = port.RendererBackend
+
+// ImageEncoder adds an output format such as WebP or AVIF to resized asset images.
+type ImageEncoder = port.ImageEncoder
+
 // ── Function types ──────────────────────────────────────────────────────────
 
+// EventHandler reacts to a domain event registered with Engine.Subscribe.
+type EventHandler = port.EventHandler
+
+// PreRenderHook runs before the injectables of a render are resolved; see Engine.RegisterPreRenderHook.
+type PreRenderHook = port.PreRenderHook
+
+// PostRenderHook runs after a PDF is rendered; see Engine.RegisterPostRenderHook.
+type PostRenderHook = port.PostRenderHook
+
+// HTTPMiddleware is a standard net/http middleware for Engine.Use.
+type HTTPMiddleware = middleware.HTTPMiddleware
+
 // ResolveFunc is the function that resolves the injector value.
 type ResolveFunc = port.ResolveFunc
 
@@ -120,3 +150,16 @@ type ResolveInjectablesRequest = port.ResolveInjectablesRequest
 
 // ResolveInjectablesResult contains the resolved values and any non-critical errors.
 type ResolveInjectablesResult = port.ResolveInjectablesResult
+
+// RenderRequest is the input for RendererBackend.Render: the document, injectable values and render options.
+type RenderRequest = port.RenderPreviewRequest
+
+// RenderResult is the output of RendererBackend.Render.
+type RenderResult = port.RenderPreviewResult
+
+// RenderContext describes a render to the render hooks. Pre-render hooks may change its payload,
+// injectables, headers and watermark.
+type RenderContext = port.RenderContext
+
+// RenderContextFrom returns the render context carried by the ctx of a render hook.
+var RenderContextFrom = port.RenderContextFrom
@@ -0,0 +1,38 @@
+package sdk
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/adapters/secondary/objectstorage"
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+)
+
+// ObjectStorage persists rendered PDFs and, with object_storage.assets, uploaded asset files
+// (Put/Get/Delete/SignedURL). Register one with engine.SetObjectStorage, or configure a built-in
+// adapter under object_storage.
+// StorageProvider is a different interface: it backs the image gallery.
+type ObjectStorage = port.ObjectStorage
+
+// StorageObject is an object written with ObjectStorage.Put.
+type StorageObject = port.StorageObject
+
+// S3StorageConfig configures the built-in S3 adapter.
+type S3StorageConfig = objectstorage.S3Config
+
+// GCSStorageConfig configures the built-in Google Cloud Storage adapter.
+type GCSStorageConfig = objectstorage.GCSConfig
+
+// Built-in ObjectStorage adapters.
+var (
+	// NewLocalObjectStorage stores objects under a directory. Its signed URLs point at the engine
+	// (publicBaseURL + /api/v1/public/objects/...), which checks them with signingKey.
+	NewLocalObjectStorage = objectstorage.NewLocal
+
+	// NewS3ObjectStorage stores objects in an S3 bucket or an S3-compatible service.
+	NewS3ObjectStorage = objectstorage.NewS3
+
+	// NewGCSObjectStorage stores objects in a Google Cloud Storage bucket, authenticated with HMAC keys.
+	NewGCSObjectStorage = objectstorage.NewGCS
+)
+
+// ErrObjectNotFound is returned by ObjectStorage.Get when the key holds no object.
+var ErrObjectNotFound = entity.ErrObjectNotFound
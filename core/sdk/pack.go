@@ -0,0 +1,17 @@
+package sdk
+
+import (
+	"github.com/rendis/pdf-forge/core/internal/core/port"
+	"github.com/rendis/pdf-forge/core/internal/infra/registry"
+)
+
+// InjectorPack is a distributable set of injectors with a manifest and translations, registered
+// with engine.RegisterPack.
+type InjectorPack = port.InjectorPack
+
+// InjectorPackManifest is the pack.yaml of an injector pack.
+type InjectorPackManifest = port.InjectorPackManifest
+
+// LoadInjectorPack builds a pack from the pack.yaml and optional injectors.i18n.yaml at the root
+// of a filesystem, usually the embed.FS of the pack module, and the injectors of the pack.
+var LoadInjectorPack = registry.LoadInjectorPack
@@ -0,0 +1,28 @@
+package sdk
+
+import "github.com/rendis/pdf-forge/core/internal/core/payload"
+
+// PayloadDecoder reads a JSON payload token by token. Use it from StreamPayload
+// callbacks to decode, skip or iterate values without loading the whole body.
+type PayloadDecoder = payload.Decoder
+
+// PayloadOptions bounds payload decoding (max size, required top-level fields).
+type PayloadOptions = payload.Options
+
+var (
+	// DecodePayload decodes a whole JSON document with a size limit.
+	DecodePayload = payload.Decode
+
+	// StreamPayload walks a JSON object field by field, for payloads too large to unmarshal at once.
+	StreamPayload = payload.Stream
+
+	// NewPayloadDecoder returns a PayloadDecoder for custom streaming logic.
+	NewPayloadDecoder = payload.NewDecoder
+)
+
+// Payload decoding errors.
+var (
+	ErrPayloadTooLarge      = payload.ErrTooLarge
+	ErrPayloadInvalid       = payload.ErrInvalid
+	ErrPayloadMissingFields = payload.ErrMissingFields
+)
@@ -1,6 +1,10 @@
 package sdk
 
-import "github.com/rendis/pdf-forge/core/internal/core/entity"
+import (
+	"github.com/rendis/pdf-forge/core/internal/core/entity"
+	"github.com/rendis/pdf-forge/core/internal/core/entity/portabledoc"
+	"github.com/rendis/pdf-forge/core/internal/core/formatter"
+)
 
 // ── Environment ──────────────────────────────────────────────────────────────
 
@@ -23,6 +27,14 @@ type InjectorContext = entity.InjectorContext
 // InjectorResult is the result of resolving an injector.
 type InjectorResult = entity.InjectorResult
 
+// InjectorTiming is when an injector ran during the resolution of a render and how long it took,
+// as listed in RenderCompleted.
+type InjectorTiming = entity.InjectorTiming
+
+// ErrInjectorDependencyCycle is returned at startup when the dependencies of the registered
+// injectors form a cycle.
+var ErrInjectorDependencyCycle = entity.ErrInjectorDependencyCycle
+
 // InjectableValue is the typed value returned by an injector.
 type InjectableValue = entity.InjectableValue
 
@@ -38,13 +50,16 @@ type FormatConfig = entity.FormatConfig
 // ── ValueType constants ─────────────────────────────────────────────────────
 
 const (
-	ValueTypeString = entity.ValueTypeString
-	ValueTypeNumber = entity.ValueTypeNumber
-	ValueTypeBool   = entity.ValueTypeBool
-	ValueTypeTime   = entity.ValueTypeTime
-	ValueTypeTable  = entity.ValueTypeTable
-	ValueTypeImage  = entity.ValueTypeImage
-	ValueTypeList   = entity.ValueTypeList
+	ValueTypeString      = entity.ValueTypeString
+	ValueTypeNumber      = entity.ValueTypeNumber
+	ValueTypeBool        = entity.ValueTypeBool
+	ValueTypeTime        = entity.ValueTypeTime
+	ValueTypeTable       = entity.ValueTypeTable
+	ValueTypeImage       = entity.ValueTypeImage
+	ValueTypeList        = entity.ValueTypeList
+	ValueTypeAddress     = entity.ValueTypeAddress
+	ValueTypePerson      = entity.ValueTypePerson
+	ValueTypeMeasurement = entity.ValueTypeMeasurement
 )
 
 // ── InjectableDataType constants ────────────────────────────────────────────
@@ -63,13 +78,16 @@ const (
 // ── Value constructors ──────────────────────────────────────────────────────
 
 var (
-	StringValue    = entity.StringValue
-	NumberValue    = entity.NumberValue
-	BoolValue      = entity.BoolValue
-	TimeValue      = entity.TimeValue
-	ImageValue     = entity.ImageValue
-	TableValueData = entity.TableValueData
-	ListValueData  = entity.ListValueData
+	StringValue          = entity.StringValue
+	NumberValue          = entity.NumberValue
+	BoolValue            = entity.BoolValue
+	TimeValue            = entity.TimeValue
+	ImageValue           = entity.ImageValue
+	TableValueData       = entity.TableValueData
+	ListValueData        = entity.ListValueData
+	AddressValueData     = entity.AddressValueData
+	PersonValueData      = entity.PersonValueData
+	MeasurementValueData = entity.MeasurementValueData
 )
 
 // ── Table types ─────────────────────────────────────────────────────────────
@@ -116,11 +134,12 @@ type ListItem = entity.ListItem
 
 // ListSymbol constants.
 const (
-	ListSymbolBullet = entity.ListSymbolBullet
-	ListSymbolNumber = entity.ListSymbolNumber
-	ListSymbolDash   = entity.ListSymbolDash
-	ListSymbolRoman  = entity.ListSymbolRoman
-	ListSymbolLetter = entity.ListSymbolLetter
+	ListSymbolBullet     = entity.ListSymbolBullet
+	ListSymbolNumber     = entity.ListSymbolNumber
+	ListSymbolDash       = entity.ListSymbolDash
+	ListSymbolRoman      = entity.ListSymbolRoman
+	ListSymbolLetter     = entity.ListSymbolLetter
+	ListSymbolMultilevel = entity.ListSymbolMultilevel
 )
 
 // List constructors and helpers.
@@ -130,9 +149,288 @@ var (
 	ListItemNested = entity.ListItemNested
 )
 
+// ── Addresses ───────────────────────────────────────────────────────────────
+
+// AddressValue is a postal address, laid out by the postal conventions of its country.
+type AddressValue = entity.AddressValue
+
+// Address helpers. FormatAddress applies an "address[:domestic|oneline]" format, such as the one
+// selected in the editor (injCtx.SelectedFormat), and returns the lines; AddressLines returns the
+// lines in the layout of the country without the country line. Templates lay address values out
+// on their own.
+var (
+	FormatAddress    = formatter.FormatAddress
+	IsAddressPattern = formatter.IsAddressPattern
+	AddressLines     = formatter.AddressLines
+)
+
+// AddressFormats provides the layouts of address values.
+var AddressFormats = formatter.AddressFormats
+
+// ── People ──────────────────────────────────────────────────────────────────
+
+// PersonValue is a person or party, named and greeted by the conventions of a language.
+type PersonValue = entity.PersonValue
+
+// Gender selects the gendered forms of salutations; other values use neutral forms.
+type Gender = entity.Gender
+
+const (
+	GenderFemale = entity.GenderFemale
+	GenderMale   = entity.GenderMale
+)
+
+// Person helpers. FormatPerson applies a "person[:<form>[:<language>]]" format, such as the one
+// selected in the editor (injCtx.SelectedFormat); Salutation returns the opening of a letter, such
+// as "Sehr geehrte Frau Dr. Müller", for templates that greet in the language of the recipient.
+var (
+	FormatPerson    = formatter.FormatPerson
+	IsPersonPattern = formatter.IsPersonPattern
+	Salutation      = formatter.Salutation
+)
+
+// PersonFormats provides the name forms and salutations of person values.
+var PersonFormats = formatter.PersonFormats
+
+// ── Measurements ────────────────────────────────────────────────────────────
+
+// MeasurementValue is a quantity with its unit, converted to the unit system of the template or
+// render request.
+type MeasurementValue = entity.MeasurementValue
+
+// Unit systems of templates and render requests.
+const (
+	UnitSystemMetric   = portabledoc.UnitSystemMetric
+	UnitSystemImperial = portabledoc.UnitSystemImperial
+)
+
+// Measurement helpers. FormatMeasurement applies a "measure[:<target>[:<style>[:<decimals>]]]"
+// format in a unit system ("" keeps units) and language, for injectors that build text around a
+// measurement, such as "Fits boxes up to 60 cm".
+var (
+	FormatMeasurement = formatter.FormatMeasurement
+	IsMeasurePattern  = formatter.IsMeasurePattern
+)
+
+// MeasurementFormats provides the conversions and unit styles of measurement values.
+var MeasurementFormats = formatter.MeasurementFormats
+
+// ── Dates ───────────────────────────────────────────────────────────────────
+
+// WorkCalendar tells working days from weekends and holidays, for deadlines counted in working
+// days. Templates build theirs from the holidays in their metadata.
+type WorkCalendar = entity.WorkCalendar
+
+// DateOffset moves a date by days, working days, weeks, months or years ("+30d", "+10wd").
+type DateOffset = formatter.DateOffset
+
+// Units of date offsets.
+const (
+	OffsetDays        = formatter.OffsetDays
+	OffsetWorkingDays = formatter.OffsetWorkingDays
+	OffsetWeeks       = formatter.OffsetWeeks
+	OffsetMonths      = formatter.OffsetMonths
+	OffsetYears       = formatter.OffsetYears
+)
+
+// Date offset helpers, for injectors that compute dates relative to another, such as a due date
+// 30 days after the issue date.
+var (
+	ParseDateOffset = formatter.ParseDateOffset
+	ShiftDate       = formatter.ShiftDate
+)
+
+// ── Masking ─────────────────────────────────────────────────────────────────
+
+// MaskOptions sets how many characters a mask leaves visible at each end and the mask character.
+type MaskOptions = formatter.MaskOptions
+
+// Masking helpers for personal data. Mask applies a "mask:<kind>[:<start>,<end>][:<region>]"
+// format, such as the one selected in the editor (injCtx.SelectedFormat); the others mask one kind
+// with explicit options. Templates apply mask formats to injector values on their own.
+var (
+	Mask           = formatter.Mask
+	IsMaskPattern  = formatter.IsMaskPattern
+	MaskIBAN       = formatter.MaskIBAN
+	MaskCard       = formatter.MaskCard
+	MaskNationalID = formatter.MaskNationalID
+	MaskEmail      = formatter.MaskEmail
+)
+
+// Default masking options and format presets of each kind.
+var (
+	IBANMask              = formatter.IBANMask
+	CardMask              = formatter.CardMask
+	NationalIDMask        = formatter.NationalIDMask
+	EmailMask             = formatter.EmailMask
+	IBANMaskFormats       = formatter.IBANMaskFormats
+	CardMaskFormats       = formatter.CardMaskFormats
+	NationalIDMaskFormats = formatter.NationalIDMaskFormats
+	EmailMaskFormats      = formatter.EmailMaskFormats
+)
+
+// ── Locale numbers ──────────────────────────────────────────────────────────
+
+// Locale number helpers. FormatLocaleNumber applies a
+// "number[:<locale>[:<currency>[:<decimals>[:plain]]]]" format, such as the one selected in the
+// editor (injCtx.SelectedFormat); FormatAmount writes an amount of an ISO 4217 currency, or with a
+// symbol of its own, by the conventions of a locale ("1.234,56 €" in "es"). Templates apply number
+// formats to injector values and table cells on their own.
+var (
+	FormatLocaleNumber = formatter.FormatLocaleNumber
+	IsNumberPattern    = formatter.IsNumberPattern
+	FormatAmount       = formatter.FormatAmount
+)
+
+// LocaleNumberFormats is the format preset of numbers and amounts written by the conventions of a locale.
+var LocaleNumberFormats = formatter.LocaleNumberFormats
+
+// ── Amounts in words ────────────────────────────────────────────────────────
+
+// Number-to-words helpers. FormatWords applies a "words:<language>[:<currency>]" format, such as
+// the one selected in the editor (injCtx.SelectedFormat); NumberToWords and AmountToWords spell a
+// number or an amount of an ISO 4217 currency in "en", "es", "pt", "fr" or "de". Templates apply
+// words formats to injector values on their own.
+var (
+	FormatWords    = formatter.FormatWords
+	IsWordsPattern = formatter.IsWordsPattern
+	NumberToWords  = formatter.NumberToWords
+	AmountToWords  = formatter.AmountToWords
+)
+
+// Format presets that spell numbers and amounts out in words.
+var (
+	NumberWordsFormats = formatter.NumberWordsFormats
+	AmountWordsFormats = formatter.AmountWordsFormats
+)
+
+// ── Notifications ───────────────────────────────────────────────────────────
+
+// Notification is an in-product notification delivered to NotificationChannel implementations.
+type Notification = entity.Notification
+
+// NotificationType identifies the event that produced a notification.
+type NotificationType = entity.NotificationType
+
+// User is the recipient passed to NotificationChannel.Deliver.
+type User = entity.User
+
+// NotificationType constants.
+const (
+	NotificationTypeScheduledPublishSucceeded = entity.NotificationTypeScheduledPublishSucceeded
+	NotificationTypeScheduledPublishFailed    = entity.NotificationTypeScheduledPublishFailed
+	NotificationTypeWorkspaceInvitation       = entity.NotificationTypeWorkspaceInvitation
+	NotificationTypeRenderFailed              = entity.NotificationTypeRenderFailed
+	NotificationTypeTemplateSLABreached       = entity.NotificationTypeTemplateSLABreached
+	NotificationTypeTemplateSLARecovered      = entity.NotificationTypeTemplateSLARecovered
+)
+
+// ── Invitations ─────────────────────────────────────────────────────────────
+
+// WorkspaceInvitation is the invitation passed to InvitationMailer.SendInvitation.
+type WorkspaceInvitation = entity.WorkspaceInvitation
+
+// Workspace is the workspace the invitation grants access to.
+type Workspace = entity.Workspace
+
 // ── Pointer helpers ─────────────────────────────────────────────────────────
 
 var (
 	StringPtr = entity.StringPtr
 	IntPtr    = entity.IntPtr
 )
+
+// ── Outbox events ───────────────────────────────────────────────────────────
+
+// OutboxEvent is a domain event delivered to EventPublisher implementations.
+type OutboxEvent = entity.OutboxEvent
+
+// OutboxEventType identifies an outbox event.
+type OutboxEventType = entity.OutboxEventType
+
+// OutboxEventType constants.
+const (
+	OutboxEventNotificationCreated   = entity.OutboxEventNotificationCreated
+	OutboxEventVersionPublished      = entity.OutboxEventVersionPublished
+	OutboxEventVersionArchived       = entity.OutboxEventVersionArchived
+	OutboxEventInjectableDeactivated = entity.OutboxEventInjectableDeactivated
+	OutboxEventMemberInvited         = entity.OutboxEventMemberInvited
+	OutboxEventTemplateSLABreached   = entity.OutboxEventTemplateSLABreached
+	OutboxEventTemplateSLARecovered  = entity.OutboxEventTemplateSLARecovered
+)
+
+// ── Domain events ───────────────────────────────────────────────────────────
+
+// DomainEvent is implemented by every typed event passed to EventHandler.
+type DomainEvent = entity.DomainEvent
+
+// DomainEventType identifies a domain event for Engine.Subscribe.
+type DomainEventType = entity.DomainEventType
+
+// Typed domain events. Handlers receive them as values, e.g. event.(sdk.VersionPublished).
+type (
+	VersionPublished      = entity.VersionPublished
+	VersionArchived       = entity.VersionArchived
+	RenderCompleted       = entity.RenderCompleted
+	RenderFailed          = entity.RenderFailed
+	InjectableDeactivated = entity.InjectableDeactivated
+	MemberInvited         = entity.MemberInvited
+	TemplateSLABreached   = entity.TemplateSLABreached
+	TemplateSLARecovered  = entity.TemplateSLARecovered
+)
+
+// DomainEventType constants.
+const (
+	EventVersionPublished      = entity.EventVersionPublished
+	EventVersionArchived       = entity.EventVersionArchived
+	EventRenderCompleted       = entity.EventRenderCompleted
+	EventRenderFailed          = entity.EventRenderFailed
+	EventInjectableDeactivated = entity.EventInjectableDeactivated
+	EventMemberInvited         = entity.EventMemberInvited
+	EventTemplateSLABreached   = entity.EventTemplateSLABreached
+	EventTemplateSLARecovered  = entity.EventTemplateSLARecovered
+)
+
+// TemplateSLAViolation is an expectation of a template SLA that renders did not meet, as listed in
+// TemplateSLABreached.Violations.
+type TemplateSLAViolation = entity.TemplateSLAViolation
+
+// TemplateRenderWindow counts the renders of a template over the window of its SLA.
+type TemplateRenderWindow = entity.TemplateRenderWindow
+
+// VersionChangelog lists what changed in a published version, as carried by VersionPublished.
+type VersionChangelog = entity.VersionChangelog
+
+// RenderWarning is a problem found while rendering that did not stop the render, as listed in
+// RenderCompleted.Warnings.
+type RenderWarning = entity.RenderWarning
+
+// RenderWarningCode identifies the kind of a RenderWarning.
+type RenderWarningCode = entity.RenderWarningCode
+
+// RenderWarningCode constants.
+const (
+	RenderWarningCompiler      = entity.RenderWarningCompiler
+	RenderWarningMissingGlyphs = entity.RenderWarningMissingGlyphs
+	RenderWarningHTMLOmitted   = entity.RenderWarningHTMLOmitted
+	RenderWarningDocxOmitted   = entity.RenderWarningDocxOmitted
+
+	RenderWarningDegradedInjector    = entity.RenderWarningDegradedInjector
+	RenderWarningDegradedImage       = entity.RenderWarningDegradedImage
+	RenderWarningDegradedExternalPDF = entity.RenderWarningDegradedExternalPDF
+)
+
+// RenderErrorCode classifies why a render failed, as carried by RenderFailed.ErrorCode.
+// RenderErrorCode.Retryable tells whether sending the same render again may succeed.
+type RenderErrorCode = entity.RenderErrorCode
+
+// RenderErrorCode constants.
+const (
+	RenderErrorInvalidPayload  = entity.RenderErrorInvalidPayload
+	RenderErrorInjectorTimeout = entity.RenderErrorInjectorTimeout
+	RenderErrorInjectorFailed  = entity.RenderErrorInjectorFailed
+	RenderErrorAssetFetch      = entity.RenderErrorAssetFetch
+	RenderErrorCompile         = entity.RenderErrorCompile
+	RenderErrorPostProcessing  = entity.RenderErrorPostProcessing
+	RenderErrorCapacity        = entity.RenderErrorCapacity
+)